@@ -13,6 +13,8 @@ import (
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/analytics/v1"
+	v11 "zero-trust-control-plane/backend/api/generated/common/v1"
 )
 
 const (
@@ -22,6 +24,182 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// MaintenanceMode is the platform-wide availability of mutating and read RPCs.
+type MaintenanceMode int32
+
+const (
+	MaintenanceMode_MAINTENANCE_MODE_UNSPECIFIED MaintenanceMode = 0
+	// OFF serves all RPCs.
+	MaintenanceMode_MAINTENANCE_MODE_OFF MaintenanceMode = 1
+	// READ_ONLY rejects mutating RPCs with UNAVAILABLE; reads and token refreshes continue.
+	MaintenanceMode_MAINTENANCE_MODE_READ_ONLY MaintenanceMode = 2
+	// MAINTENANCE also rejects reads; only health checks, token refreshes and the maintenance RPCs are served.
+	MaintenanceMode_MAINTENANCE_MODE_MAINTENANCE MaintenanceMode = 3
+)
+
+// Enum value maps for MaintenanceMode.
+var (
+	MaintenanceMode_name = map[int32]string{
+		0: "MAINTENANCE_MODE_UNSPECIFIED",
+		1: "MAINTENANCE_MODE_OFF",
+		2: "MAINTENANCE_MODE_READ_ONLY",
+		3: "MAINTENANCE_MODE_MAINTENANCE",
+	}
+	MaintenanceMode_value = map[string]int32{
+		"MAINTENANCE_MODE_UNSPECIFIED": 0,
+		"MAINTENANCE_MODE_OFF":         1,
+		"MAINTENANCE_MODE_READ_ONLY":   2,
+		"MAINTENANCE_MODE_MAINTENANCE": 3,
+	}
+)
+
+func (x MaintenanceMode) Enum() *MaintenanceMode {
+	p := new(MaintenanceMode)
+	*p = x
+	return p
+}
+
+func (x MaintenanceMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MaintenanceMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (MaintenanceMode) Type() protoreflect.EnumType {
+	return &file_admin_admin_proto_enumTypes[0]
+}
+
+func (x MaintenanceMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MaintenanceMode.Descriptor instead.
+func (MaintenanceMode) EnumDescriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// LicenseState is where the license of a self-hosted deployment is in its lifetime.
+type LicenseState int32
+
+const (
+	LicenseState_LICENSE_STATE_UNSPECIFIED LicenseState = 0
+	// UNLICENSED is a deployment without a license file; nothing is enforced.
+	LicenseState_LICENSE_STATE_UNLICENSED LicenseState = 1
+	// VALID is a license that expires in more than 30 days.
+	LicenseState_LICENSE_STATE_VALID LicenseState = 2
+	// EXPIRING is a license that expires within 30 days.
+	LicenseState_LICENSE_STATE_EXPIRING LicenseState = 3
+	// GRACE is an expired license within its grace period; everything keeps working.
+	LicenseState_LICENSE_STATE_GRACE LicenseState = 4
+	// EXPIRED is a license past its grace period: licensed features are refused and no users can be added.
+	LicenseState_LICENSE_STATE_EXPIRED LicenseState = 5
+)
+
+// Enum value maps for LicenseState.
+var (
+	LicenseState_name = map[int32]string{
+		0: "LICENSE_STATE_UNSPECIFIED",
+		1: "LICENSE_STATE_UNLICENSED",
+		2: "LICENSE_STATE_VALID",
+		3: "LICENSE_STATE_EXPIRING",
+		4: "LICENSE_STATE_GRACE",
+		5: "LICENSE_STATE_EXPIRED",
+	}
+	LicenseState_value = map[string]int32{
+		"LICENSE_STATE_UNSPECIFIED": 0,
+		"LICENSE_STATE_UNLICENSED":  1,
+		"LICENSE_STATE_VALID":       2,
+		"LICENSE_STATE_EXPIRING":    3,
+		"LICENSE_STATE_GRACE":       4,
+		"LICENSE_STATE_EXPIRED":     5,
+	}
+)
+
+func (x LicenseState) Enum() *LicenseState {
+	p := new(LicenseState)
+	*p = x
+	return p
+}
+
+func (x LicenseState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LicenseState) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (LicenseState) Type() protoreflect.EnumType {
+	return &file_admin_admin_proto_enumTypes[1]
+}
+
+func (x LicenseState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LicenseState.Descriptor instead.
+func (LicenseState) EnumDescriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{1}
+}
+
+// CircuitBreakerState is the state of a circuit breaker around an external dependency.
+type CircuitBreakerState int32
+
+const (
+	CircuitBreakerState_CIRCUIT_BREAKER_STATE_UNSPECIFIED CircuitBreakerState = 0
+	// CLOSED lets every call through.
+	CircuitBreakerState_CIRCUIT_BREAKER_STATE_CLOSED CircuitBreakerState = 1
+	// OPEN rejects calls at once and the caller falls back (e.g. SMS queued for retry) until the open timeout passes.
+	CircuitBreakerState_CIRCUIT_BREAKER_STATE_OPEN CircuitBreakerState = 2
+	// HALF_OPEN lets one probe call through; its outcome closes or reopens the breaker.
+	CircuitBreakerState_CIRCUIT_BREAKER_STATE_HALF_OPEN CircuitBreakerState = 3
+)
+
+// Enum value maps for CircuitBreakerState.
+var (
+	CircuitBreakerState_name = map[int32]string{
+		0: "CIRCUIT_BREAKER_STATE_UNSPECIFIED",
+		1: "CIRCUIT_BREAKER_STATE_CLOSED",
+		2: "CIRCUIT_BREAKER_STATE_OPEN",
+		3: "CIRCUIT_BREAKER_STATE_HALF_OPEN",
+	}
+	CircuitBreakerState_value = map[string]int32{
+		"CIRCUIT_BREAKER_STATE_UNSPECIFIED": 0,
+		"CIRCUIT_BREAKER_STATE_CLOSED":      1,
+		"CIRCUIT_BREAKER_STATE_OPEN":        2,
+		"CIRCUIT_BREAKER_STATE_HALF_OPEN":   3,
+	}
+)
+
+func (x CircuitBreakerState) Enum() *CircuitBreakerState {
+	p := new(CircuitBreakerState)
+	*p = x
+	return p
+}
+
+func (x CircuitBreakerState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CircuitBreakerState) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_admin_proto_enumTypes[2].Descriptor()
+}
+
+func (CircuitBreakerState) Type() protoreflect.EnumType {
+	return &file_admin_admin_proto_enumTypes[2]
+}
+
+func (x CircuitBreakerState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CircuitBreakerState.Descriptor instead.
+func (CircuitBreakerState) EnumDescriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{2}
+}
+
 // SystemStats returns aggregate system-level metrics. Only for platform admins.
 type SystemStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -181,24 +359,3211 @@ func (x *GetSystemStatsResponse) GetStats() *SystemStats {
 	return nil
 }
 
-var File_admin_admin_proto protoreflect.FileDescriptor
+// ConfigEntry is one effective configuration setting.
+type ConfigEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key is the env var / config file key (e.g. JWT_ACCESS_TTL).
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// value is the effective value; empty with redacted=true for secrets.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// reloadable is true when the setting is applied on SIGHUP or CONFIG_RELOAD_INTERVAL without a restart.
+	Reloadable bool `protobuf:"varint,3,opt,name=reloadable,proto3" json:"reloadable,omitempty"`
+	// redacted is true when value was withheld because the setting is a secret.
+	Redacted      bool `protobuf:"varint,4,opt,name=redacted,proto3" json:"redacted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_admin_admin_proto_rawDesc = "" +
-	"\n" +
-	"\x11admin/admin.proto\x12\rztcp.admin.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xce\x01\n" +
-	"\vSystemStats\x12\x1b\n" +
-	"\torg_count\x18\x01 \x01(\x03R\borgCount\x12\x1d\n" +
-	"\n" +
-	"user_count\x18\x02 \x01(\x03R\tuserCount\x12#\n" +
-	"\rsession_count\x18\x03 \x01(\x03R\fsessionCount\x12!\n" +
-	"\fdevice_count\x18\x04 \x01(\x03R\vdeviceCount\x12;\n" +
-	"\vcomputed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"computedAt\"\x17\n" +
-	"\x15GetSystemStatsRequest\"J\n" +
-	"\x16GetSystemStatsResponse\x120\n" +
-	"\x05stats\x18\x01 \x01(\v2\x1a.ztcp.admin.v1.SystemStatsR\x05stats2m\n" +
-	"\fAdminService\x12]\n" +
-	"\x0eGetSystemStats\x12$.ztcp.admin.v1.GetSystemStatsRequest\x1a%.ztcp.admin.v1.GetSystemStatsResponseBAZ?zero-trust-control-plane/backend/api/generated/admin/v1;adminv1b\x06proto3"
+func (x *ConfigEntry) Reset() {
+	*x = ConfigEntry{}
+	mi := &file_admin_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigEntry) ProtoMessage() {}
+
+func (x *ConfigEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigEntry.ProtoReflect.Descriptor instead.
+func (*ConfigEntry) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConfigEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ConfigEntry) GetReloadable() bool {
+	if x != nil {
+		return x.Reloadable
+	}
+	return false
+}
+
+func (x *ConfigEntry) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+// GetEffectiveConfigRequest is empty.
+type GetEffectiveConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEffectiveConfigRequest) Reset() {
+	*x = GetEffectiveConfigRequest{}
+	mi := &file_admin_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveConfigRequest) ProtoMessage() {}
+
+func (x *GetEffectiveConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfigRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{4}
+}
+
+// GetEffectiveConfigResponse returns the running server's configuration, sorted by key, with secrets redacted.
+type GetEffectiveConfigResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*ConfigEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// loaded_at is when the configuration was last loaded or reloaded.
+	LoadedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=loaded_at,json=loadedAt,proto3" json:"loaded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEffectiveConfigResponse) Reset() {
+	*x = GetEffectiveConfigResponse{}
+	mi := &file_admin_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveConfigResponse) ProtoMessage() {}
+
+func (x *GetEffectiveConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfigResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetEffectiveConfigResponse) GetEntries() []*ConfigEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetEffectiveConfigResponse) GetLoadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LoadedAt
+	}
+	return nil
+}
+
+// MaintenanceStatus is the current maintenance mode.
+type MaintenanceStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Mode  MaintenanceMode        `protobuf:"varint,1,opt,name=mode,proto3,enum=ztcp.admin.v1.MaintenanceMode" json:"mode,omitempty"`
+	// message is shown to callers in rejected RPCs (e.g. "Database upgrade until 02:00 UTC").
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// retry_after_seconds is the retry hint returned with rejected RPCs.
+	RetryAfterSeconds int32 `protobuf:"varint,3,opt,name=retry_after_seconds,json=retryAfterSeconds,proto3" json:"retry_after_seconds,omitempty"`
+	// updated_by is the platform admin who last changed the mode; empty if never changed.
+	UpdatedBy     string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceStatus) Reset() {
+	*x = MaintenanceStatus{}
+	mi := &file_admin_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceStatus) ProtoMessage() {}
+
+func (x *MaintenanceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceStatus.ProtoReflect.Descriptor instead.
+func (*MaintenanceStatus) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MaintenanceStatus) GetMode() MaintenanceMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MaintenanceMode_MAINTENANCE_MODE_UNSPECIFIED
+}
+
+func (x *MaintenanceStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MaintenanceStatus) GetRetryAfterSeconds() int32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+func (x *MaintenanceStatus) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *MaintenanceStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// GetMaintenanceModeRequest is empty.
+type GetMaintenanceModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaintenanceModeRequest) Reset() {
+	*x = GetMaintenanceModeRequest{}
+	mi := &file_admin_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaintenanceModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaintenanceModeRequest) ProtoMessage() {}
+
+func (x *GetMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*GetMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{7}
+}
+
+// GetMaintenanceModeResponse returns the current maintenance mode.
+type GetMaintenanceModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *MaintenanceStatus     `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaintenanceModeResponse) Reset() {
+	*x = GetMaintenanceModeResponse{}
+	mi := &file_admin_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaintenanceModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaintenanceModeResponse) ProtoMessage() {}
+
+func (x *GetMaintenanceModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaintenanceModeResponse.ProtoReflect.Descriptor instead.
+func (*GetMaintenanceModeResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMaintenanceModeResponse) GetStatus() *MaintenanceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// SetMaintenanceModeRequest switches the maintenance mode.
+type SetMaintenanceModeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Mode  MaintenanceMode        `protobuf:"varint,1,opt,name=mode,proto3,enum=ztcp.admin.v1.MaintenanceMode" json:"mode,omitempty"`
+	// message is optional, at most 500 characters.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// retry_after_seconds is optional (0 = 300), at most 86400. Ignored when mode is OFF.
+	RetryAfterSeconds int32 `protobuf:"varint,3,opt,name=retry_after_seconds,json=retryAfterSeconds,proto3" json:"retry_after_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeRequest) Reset() {
+	*x = SetMaintenanceModeRequest{}
+	mi := &file_admin_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeRequest) ProtoMessage() {}
+
+func (x *SetMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetMaintenanceModeRequest) GetMode() MaintenanceMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MaintenanceMode_MAINTENANCE_MODE_UNSPECIFIED
+}
+
+func (x *SetMaintenanceModeRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetMaintenanceModeRequest) GetRetryAfterSeconds() int32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+// SetMaintenanceModeResponse returns the new maintenance mode.
+type SetMaintenanceModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *MaintenanceStatus     `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeResponse) Reset() {
+	*x = SetMaintenanceModeResponse{}
+	mi := &file_admin_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeResponse) ProtoMessage() {}
+
+func (x *SetMaintenanceModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeResponse.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetMaintenanceModeResponse) GetStatus() *MaintenanceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// QuotaPlan is a plan's API quotas, in requests per minute; 0 is unlimited.
+type QuotaPlan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// org_requests_per_minute caps the authenticated requests of all of an org's sessions together.
+	OrgRequestsPerMinute int32 `protobuf:"varint,2,opt,name=org_requests_per_minute,json=orgRequestsPerMinute,proto3" json:"org_requests_per_minute,omitempty"`
+	// token_requests_per_minute caps the requests made with the access tokens of one session.
+	TokenRequestsPerMinute int32 `protobuf:"varint,3,opt,name=token_requests_per_minute,json=tokenRequestsPerMinute,proto3" json:"token_requests_per_minute,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *QuotaPlan) Reset() {
+	*x = QuotaPlan{}
+	mi := &file_admin_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotaPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaPlan) ProtoMessage() {}
+
+func (x *QuotaPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaPlan.ProtoReflect.Descriptor instead.
+func (*QuotaPlan) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QuotaPlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QuotaPlan) GetOrgRequestsPerMinute() int32 {
+	if x != nil {
+		return x.OrgRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *QuotaPlan) GetTokenRequestsPerMinute() int32 {
+	if x != nil {
+		return x.TokenRequestsPerMinute
+	}
+	return 0
+}
+
+// ListQuotaPlansRequest is empty.
+type ListQuotaPlansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuotaPlansRequest) Reset() {
+	*x = ListQuotaPlansRequest{}
+	mi := &file_admin_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuotaPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuotaPlansRequest) ProtoMessage() {}
+
+func (x *ListQuotaPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuotaPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListQuotaPlansRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{12}
+}
+
+// ListQuotaPlansResponse returns the configured plans (QUOTA_PLANS) sorted by name.
+type ListQuotaPlansResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Plans []*QuotaPlan           `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	// default_plan is the plan of orgs without an assigned plan (QUOTA_DEFAULT_PLAN).
+	DefaultPlan string `protobuf:"bytes,2,opt,name=default_plan,json=defaultPlan,proto3" json:"default_plan,omitempty"`
+	// enforced is false when quotas are not enforced (QUOTA_ENABLED=false); usage is then not counted.
+	Enforced      bool `protobuf:"varint,3,opt,name=enforced,proto3" json:"enforced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuotaPlansResponse) Reset() {
+	*x = ListQuotaPlansResponse{}
+	mi := &file_admin_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuotaPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuotaPlansResponse) ProtoMessage() {}
+
+func (x *ListQuotaPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuotaPlansResponse.ProtoReflect.Descriptor instead.
+func (*ListQuotaPlansResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListQuotaPlansResponse) GetPlans() []*QuotaPlan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+func (x *ListQuotaPlansResponse) GetDefaultPlan() string {
+	if x != nil {
+		return x.DefaultPlan
+	}
+	return ""
+}
+
+func (x *ListQuotaPlansResponse) GetEnforced() bool {
+	if x != nil {
+		return x.Enforced
+	}
+	return false
+}
+
+// OrgQuotaOverride is an org's stored quota assignment.
+type OrgQuotaOverride struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// plan is the assigned plan; empty uses the default plan.
+	Plan string `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	// org_requests_per_minute, when set, replaces the plan's org quota (0 = unlimited).
+	OrgRequestsPerMinute *int32 `protobuf:"varint,2,opt,name=org_requests_per_minute,json=orgRequestsPerMinute,proto3,oneof" json:"org_requests_per_minute,omitempty"`
+	// token_requests_per_minute, when set, replaces the plan's per-session quota (0 = unlimited).
+	TokenRequestsPerMinute *int32                 `protobuf:"varint,3,opt,name=token_requests_per_minute,json=tokenRequestsPerMinute,proto3,oneof" json:"token_requests_per_minute,omitempty"`
+	UpdatedBy              string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt              *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *OrgQuotaOverride) Reset() {
+	*x = OrgQuotaOverride{}
+	mi := &file_admin_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgQuotaOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgQuotaOverride) ProtoMessage() {}
+
+func (x *OrgQuotaOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgQuotaOverride.ProtoReflect.Descriptor instead.
+func (*OrgQuotaOverride) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OrgQuotaOverride) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+func (x *OrgQuotaOverride) GetOrgRequestsPerMinute() int32 {
+	if x != nil && x.OrgRequestsPerMinute != nil {
+		return *x.OrgRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *OrgQuotaOverride) GetTokenRequestsPerMinute() int32 {
+	if x != nil && x.TokenRequestsPerMinute != nil {
+		return *x.TokenRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *OrgQuotaOverride) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *OrgQuotaOverride) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// OrgQuotaUsage is an org's quota usage on the server instance that answered.
+type OrgQuotaUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// org_requests is the number of requests counted against the org quota in the current minute.
+	OrgRequests int32 `protobuf:"varint,1,opt,name=org_requests,json=orgRequests,proto3" json:"org_requests,omitempty"`
+	// allowed, rejected_org and rejected_token count requests since the instance started.
+	Allowed       int64 `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	RejectedOrg   int64 `protobuf:"varint,3,opt,name=rejected_org,json=rejectedOrg,proto3" json:"rejected_org,omitempty"`
+	RejectedToken int64 `protobuf:"varint,4,opt,name=rejected_token,json=rejectedToken,proto3" json:"rejected_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgQuotaUsage) Reset() {
+	*x = OrgQuotaUsage{}
+	mi := &file_admin_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgQuotaUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgQuotaUsage) ProtoMessage() {}
+
+func (x *OrgQuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgQuotaUsage.ProtoReflect.Descriptor instead.
+func (*OrgQuotaUsage) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OrgQuotaUsage) GetOrgRequests() int32 {
+	if x != nil {
+		return x.OrgRequests
+	}
+	return 0
+}
+
+func (x *OrgQuotaUsage) GetAllowed() int64 {
+	if x != nil {
+		return x.Allowed
+	}
+	return 0
+}
+
+func (x *OrgQuotaUsage) GetRejectedOrg() int64 {
+	if x != nil {
+		return x.RejectedOrg
+	}
+	return 0
+}
+
+func (x *OrgQuotaUsage) GetRejectedToken() int64 {
+	if x != nil {
+		return x.RejectedToken
+	}
+	return 0
+}
+
+// OrgQuota is an org's quotas in effect, its override and its usage.
+type OrgQuota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	OrgId string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// limits are the quotas in effect: the plan's (name is the effective plan) with the override applied.
+	Limits *QuotaPlan `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	// override is the org's stored assignment; unset if the org has none.
+	Override      *OrgQuotaOverride `protobuf:"bytes,3,opt,name=override,proto3" json:"override,omitempty"`
+	Usage         *OrgQuotaUsage    `protobuf:"bytes,4,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgQuota) Reset() {
+	*x = OrgQuota{}
+	mi := &file_admin_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgQuota) ProtoMessage() {}
+
+func (x *OrgQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgQuota.ProtoReflect.Descriptor instead.
+func (*OrgQuota) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OrgQuota) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgQuota) GetLimits() *QuotaPlan {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *OrgQuota) GetOverride() *OrgQuotaOverride {
+	if x != nil {
+		return x.Override
+	}
+	return nil
+}
+
+func (x *OrgQuota) GetUsage() *OrgQuotaUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// GetOrgQuotaRequest names the org.
+type GetOrgQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgQuotaRequest) Reset() {
+	*x = GetOrgQuotaRequest{}
+	mi := &file_admin_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgQuotaRequest) ProtoMessage() {}
+
+func (x *GetOrgQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetOrgQuotaRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// GetOrgQuotaResponse returns the org's quotas.
+type GetOrgQuotaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quota         *OrgQuota              `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgQuotaResponse) Reset() {
+	*x = GetOrgQuotaResponse{}
+	mi := &file_admin_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgQuotaResponse) ProtoMessage() {}
+
+func (x *GetOrgQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetOrgQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetOrgQuotaResponse) GetQuota() *OrgQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+// SetOrgQuotaRequest assigns an org's plan and quota overrides, replacing any earlier assignment.
+type SetOrgQuotaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	OrgId string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// plan is optional (empty = default plan); it must be a configured plan.
+	Plan string `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	// org_requests_per_minute optionally overrides the plan's org quota (0 = unlimited).
+	OrgRequestsPerMinute *int32 `protobuf:"varint,3,opt,name=org_requests_per_minute,json=orgRequestsPerMinute,proto3,oneof" json:"org_requests_per_minute,omitempty"`
+	// token_requests_per_minute optionally overrides the plan's per-session quota (0 = unlimited).
+	TokenRequestsPerMinute *int32 `protobuf:"varint,4,opt,name=token_requests_per_minute,json=tokenRequestsPerMinute,proto3,oneof" json:"token_requests_per_minute,omitempty"`
+	// clear deletes the org's assignment instead, returning it to the default plan; the other fields must be empty.
+	Clear         bool `protobuf:"varint,5,opt,name=clear,proto3" json:"clear,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgQuotaRequest) Reset() {
+	*x = SetOrgQuotaRequest{}
+	mi := &file_admin_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgQuotaRequest) ProtoMessage() {}
+
+func (x *SetOrgQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgQuotaRequest.ProtoReflect.Descriptor instead.
+func (*SetOrgQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetOrgQuotaRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SetOrgQuotaRequest) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+func (x *SetOrgQuotaRequest) GetOrgRequestsPerMinute() int32 {
+	if x != nil && x.OrgRequestsPerMinute != nil {
+		return *x.OrgRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *SetOrgQuotaRequest) GetTokenRequestsPerMinute() int32 {
+	if x != nil && x.TokenRequestsPerMinute != nil {
+		return *x.TokenRequestsPerMinute
+	}
+	return 0
+}
+
+func (x *SetOrgQuotaRequest) GetClear() bool {
+	if x != nil {
+		return x.Clear
+	}
+	return false
+}
+
+// SetOrgQuotaResponse returns the org's quotas after the change.
+type SetOrgQuotaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quota         *OrgQuota              `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgQuotaResponse) Reset() {
+	*x = SetOrgQuotaResponse{}
+	mi := &file_admin_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgQuotaResponse) ProtoMessage() {}
+
+func (x *SetOrgQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgQuotaResponse.ProtoReflect.Descriptor instead.
+func (*SetOrgQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetOrgQuotaResponse) GetQuota() *OrgQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+// BillingPlan is a billing plan and the gated features it includes.
+type BillingPlan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is free, team or enterprise.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// features are the gated features the plan includes: custom_smtp, custom_rego, audit_streaming.
+	Features      []string `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BillingPlan) Reset() {
+	*x = BillingPlan{}
+	mi := &file_admin_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BillingPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BillingPlan) ProtoMessage() {}
+
+func (x *BillingPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BillingPlan.ProtoReflect.Descriptor instead.
+func (*BillingPlan) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BillingPlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BillingPlan) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// ListBillingPlansRequest is empty.
+type ListBillingPlansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBillingPlansRequest) Reset() {
+	*x = ListBillingPlansRequest{}
+	mi := &file_admin_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBillingPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBillingPlansRequest) ProtoMessage() {}
+
+func (x *ListBillingPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBillingPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListBillingPlansRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{22}
+}
+
+// ListBillingPlansResponse returns the billing plans from the lowest to the highest; each includes the features of
+// the plans before it.
+type ListBillingPlansResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Plans []*BillingPlan         `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	// enforced is false when features are not refused by plan (PLAN_ENFORCEMENT_ENABLED=false).
+	Enforced      bool `protobuf:"varint,2,opt,name=enforced,proto3" json:"enforced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBillingPlansResponse) Reset() {
+	*x = ListBillingPlansResponse{}
+	mi := &file_admin_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBillingPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBillingPlansResponse) ProtoMessage() {}
+
+func (x *ListBillingPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBillingPlansResponse.ProtoReflect.Descriptor instead.
+func (*ListBillingPlansResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListBillingPlansResponse) GetPlans() []*BillingPlan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+func (x *ListBillingPlansResponse) GetEnforced() bool {
+	if x != nil {
+		return x.Enforced
+	}
+	return false
+}
+
+// SetOrgBillingPlanRequest assigns an org's billing plan.
+type SetOrgBillingPlanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	OrgId string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// plan is free, team or enterprise.
+	Plan          string `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgBillingPlanRequest) Reset() {
+	*x = SetOrgBillingPlanRequest{}
+	mi := &file_admin_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgBillingPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgBillingPlanRequest) ProtoMessage() {}
+
+func (x *SetOrgBillingPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgBillingPlanRequest.ProtoReflect.Descriptor instead.
+func (*SetOrgBillingPlanRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetOrgBillingPlanRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SetOrgBillingPlanRequest) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+// SetOrgBillingPlanResponse returns the org's new plan.
+type SetOrgBillingPlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Plan          *BillingPlan           `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgBillingPlanResponse) Reset() {
+	*x = SetOrgBillingPlanResponse{}
+	mi := &file_admin_admin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgBillingPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgBillingPlanResponse) ProtoMessage() {}
+
+func (x *SetOrgBillingPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgBillingPlanResponse.ProtoReflect.Descriptor instead.
+func (*SetOrgBillingPlanResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetOrgBillingPlanResponse) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SetOrgBillingPlanResponse) GetPlan() *BillingPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+// ExportUsageRequest pages through every org's usage in one month, for billing systems.
+type ExportUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`                          // YYYY-MM; default the previous month
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // default 100, max 1000
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	Csv           bool                   `protobuf:"varint,4,opt,name=csv,proto3" json:"csv,omitempty"`                             // also return the page as CSV
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsageRequest) Reset() {
+	*x = ExportUsageRequest{}
+	mi := &file_admin_admin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsageRequest) ProtoMessage() {}
+
+func (x *ExportUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsageRequest.ProtoReflect.Descriptor instead.
+func (*ExportUsageRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExportUsageRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ExportUsageRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ExportUsageRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ExportUsageRequest) GetCsv() bool {
+	if x != nil {
+		return x.Csv
+	}
+	return false
+}
+
+// ExportUsageResponse returns one page of orgs' usage, ordered by org ID. Orgs without usage are omitted.
+type ExportUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         []*v1.OrgUsage         `protobuf:"bytes,1,rep,name=usage,proto3" json:"usage,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	// csv is the page as CSV when requested: a header row, then one row per org.
+	Csv           string `protobuf:"bytes,3,opt,name=csv,proto3" json:"csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsageResponse) Reset() {
+	*x = ExportUsageResponse{}
+	mi := &file_admin_admin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsageResponse) ProtoMessage() {}
+
+func (x *ExportUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsageResponse.ProtoReflect.Descriptor instead.
+func (*ExportUsageResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ExportUsageResponse) GetUsage() []*v1.OrgUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *ExportUsageResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ExportUsageResponse) GetCsv() string {
+	if x != nil {
+		return x.Csv
+	}
+	return ""
+}
+
+// LicenseStatus is the license of the deployment and its seat usage. Only state is set when unlicensed.
+type LicenseStatus struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	State     LicenseState           `protobuf:"varint,1,opt,name=state,proto3,enum=ztcp.admin.v1.LicenseState" json:"state,omitempty"`
+	LicenseId string                 `protobuf:"bytes,2,opt,name=license_id,json=licenseId,proto3" json:"license_id,omitempty"`
+	Customer  string                 `protobuf:"bytes,3,opt,name=customer,proto3" json:"customer,omitempty"`
+	// seats is the number of users the license allows; 0 is unlimited.
+	Seats int32 `protobuf:"varint,4,opt,name=seats,proto3" json:"seats,omitempty"`
+	// seats_used counts active users with an identity, not counting honeytokens and break-glass accounts.
+	SeatsUsed int64 `protobuf:"varint,5,opt,name=seats_used,json=seatsUsed,proto3" json:"seats_used,omitempty"`
+	// features are the licensed features (billing feature names, e.g. custom_rego).
+	Features    []string               `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	IssuedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	GraceEndsAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=grace_ends_at,json=graceEndsAt,proto3" json:"grace_ends_at,omitempty"`
+	// days_remaining is the whole days until expiry, rounded up; 0 once expired.
+	DaysRemaining int32 `protobuf:"varint,10,opt,name=days_remaining,json=daysRemaining,proto3" json:"days_remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LicenseStatus) Reset() {
+	*x = LicenseStatus{}
+	mi := &file_admin_admin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LicenseStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseStatus) ProtoMessage() {}
+
+func (x *LicenseStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LicenseStatus.ProtoReflect.Descriptor instead.
+func (*LicenseStatus) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LicenseStatus) GetState() LicenseState {
+	if x != nil {
+		return x.State
+	}
+	return LicenseState_LICENSE_STATE_UNSPECIFIED
+}
+
+func (x *LicenseStatus) GetLicenseId() string {
+	if x != nil {
+		return x.LicenseId
+	}
+	return ""
+}
+
+func (x *LicenseStatus) GetCustomer() string {
+	if x != nil {
+		return x.Customer
+	}
+	return ""
+}
+
+func (x *LicenseStatus) GetSeats() int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
+func (x *LicenseStatus) GetSeatsUsed() int64 {
+	if x != nil {
+		return x.SeatsUsed
+	}
+	return 0
+}
+
+func (x *LicenseStatus) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *LicenseStatus) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *LicenseStatus) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LicenseStatus) GetGraceEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GraceEndsAt
+	}
+	return nil
+}
+
+func (x *LicenseStatus) GetDaysRemaining() int32 {
+	if x != nil {
+		return x.DaysRemaining
+	}
+	return 0
+}
+
+// GetLicenseStatusRequest is empty.
+type GetLicenseStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLicenseStatusRequest) Reset() {
+	*x = GetLicenseStatusRequest{}
+	mi := &file_admin_admin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLicenseStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLicenseStatusRequest) ProtoMessage() {}
+
+func (x *GetLicenseStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLicenseStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetLicenseStatusRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{29}
+}
+
+// GetLicenseStatusResponse returns the license status.
+type GetLicenseStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *LicenseStatus         `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLicenseStatusResponse) Reset() {
+	*x = GetLicenseStatusResponse{}
+	mi := &file_admin_admin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLicenseStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLicenseStatusResponse) ProtoMessage() {}
+
+func (x *GetLicenseStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLicenseStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetLicenseStatusResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetLicenseStatusResponse) GetStatus() *LicenseStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// BackupTable is the number of rows of one table in a backup.
+type BackupTable struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rows          int64                  `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupTable) Reset() {
+	*x = BackupTable{}
+	mi := &file_admin_admin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupTable) ProtoMessage() {}
+
+func (x *BackupTable) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupTable.ProtoReflect.Descriptor instead.
+func (*BackupTable) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BackupTable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BackupTable) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+// CreateBackupRequest names the org to back up.
+type CreateBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBackupRequest) Reset() {
+	*x = CreateBackupRequest{}
+	mi := &file_admin_admin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackupRequest) ProtoMessage() {}
+
+func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBackupRequest.ProtoReflect.Descriptor instead.
+func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateBackupRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// CreateBackupResponse describes the backup written to the server's BACKUP_DIR.
+type CreateBackupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// file_name is the name of the backup file in BACKUP_DIR; restore it with cmd/backup.
+	FileName  string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// size_bytes is the size of the encrypted backup.
+	SizeBytes int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// schema_version is the database migration version; a backup is only restored into a database at that version.
+	SchemaVersion int64 `protobuf:"varint,4,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// tables are the backed-up tables in restore order.
+	Tables        []*BackupTable `protobuf:"bytes,5,rep,name=tables,proto3" json:"tables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBackupResponse) Reset() {
+	*x = CreateBackupResponse{}
+	mi := &file_admin_admin_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBackupResponse) ProtoMessage() {}
+
+func (x *CreateBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBackupResponse.ProtoReflect.Descriptor instead.
+func (*CreateBackupResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateBackupResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *CreateBackupResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CreateBackupResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *CreateBackupResponse) GetSchemaVersion() int64 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *CreateBackupResponse) GetTables() []*BackupTable {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+// Honeytoken is a decoy user: every sign-in attempt against it is rejected and alerted.
+type Honeytoken struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// label says where the credential was planted (e.g. "wiki backup page").
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// created_by is the platform admin who marked the user.
+	CreatedBy string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// trigger_count is the number of sign-in attempts against the honeytoken.
+	TriggerCount int64 `protobuf:"varint,5,opt,name=trigger_count,json=triggerCount,proto3" json:"trigger_count,omitempty"`
+	// last_triggered_at is unset until the first attempt.
+	LastTriggeredAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_triggered_at,json=lastTriggeredAt,proto3" json:"last_triggered_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Honeytoken) Reset() {
+	*x = Honeytoken{}
+	mi := &file_admin_admin_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Honeytoken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Honeytoken) ProtoMessage() {}
+
+func (x *Honeytoken) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Honeytoken.ProtoReflect.Descriptor instead.
+func (*Honeytoken) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Honeytoken) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Honeytoken) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Honeytoken) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Honeytoken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Honeytoken) GetTriggerCount() int64 {
+	if x != nil {
+		return x.TriggerCount
+	}
+	return 0
+}
+
+func (x *Honeytoken) GetLastTriggeredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTriggeredAt
+	}
+	return nil
+}
+
+// MarkHoneytokenRequest marks a user as a honeytoken, or relabels one.
+type MarkHoneytokenRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// label is optional, at most 200 characters.
+	Label         string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkHoneytokenRequest) Reset() {
+	*x = MarkHoneytokenRequest{}
+	mi := &file_admin_admin_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkHoneytokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkHoneytokenRequest) ProtoMessage() {}
+
+func (x *MarkHoneytokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkHoneytokenRequest.ProtoReflect.Descriptor instead.
+func (*MarkHoneytokenRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MarkHoneytokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkHoneytokenRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// MarkHoneytokenResponse returns the honeytoken.
+type MarkHoneytokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Honeytoken    *Honeytoken            `protobuf:"bytes,1,opt,name=honeytoken,proto3" json:"honeytoken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkHoneytokenResponse) Reset() {
+	*x = MarkHoneytokenResponse{}
+	mi := &file_admin_admin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkHoneytokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkHoneytokenResponse) ProtoMessage() {}
+
+func (x *MarkHoneytokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkHoneytokenResponse.ProtoReflect.Descriptor instead.
+func (*MarkHoneytokenResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *MarkHoneytokenResponse) GetHoneytoken() *Honeytoken {
+	if x != nil {
+		return x.Honeytoken
+	}
+	return nil
+}
+
+// UnmarkHoneytokenRequest names the honeytoken user.
+type UnmarkHoneytokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmarkHoneytokenRequest) Reset() {
+	*x = UnmarkHoneytokenRequest{}
+	mi := &file_admin_admin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmarkHoneytokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmarkHoneytokenRequest) ProtoMessage() {}
+
+func (x *UnmarkHoneytokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmarkHoneytokenRequest.ProtoReflect.Descriptor instead.
+func (*UnmarkHoneytokenRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UnmarkHoneytokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// UnmarkHoneytokenResponse is empty on success.
+type UnmarkHoneytokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmarkHoneytokenResponse) Reset() {
+	*x = UnmarkHoneytokenResponse{}
+	mi := &file_admin_admin_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmarkHoneytokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmarkHoneytokenResponse) ProtoMessage() {}
+
+func (x *UnmarkHoneytokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmarkHoneytokenResponse.ProtoReflect.Descriptor instead.
+func (*UnmarkHoneytokenResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{38}
+}
+
+// ListHoneytokensRequest lists honeytokens with pagination.
+type ListHoneytokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *v11.Pagination        `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoneytokensRequest) Reset() {
+	*x = ListHoneytokensRequest{}
+	mi := &file_admin_admin_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoneytokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoneytokensRequest) ProtoMessage() {}
+
+func (x *ListHoneytokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoneytokensRequest.ProtoReflect.Descriptor instead.
+func (*ListHoneytokensRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListHoneytokensRequest) GetPagination() *v11.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// ListHoneytokensResponse returns a page of honeytokens, most recently marked first.
+type ListHoneytokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Honeytokens   []*Honeytoken          `protobuf:"bytes,1,rep,name=honeytokens,proto3" json:"honeytokens,omitempty"`
+	Pagination    *v11.PaginationResult  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoneytokensResponse) Reset() {
+	*x = ListHoneytokensResponse{}
+	mi := &file_admin_admin_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoneytokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoneytokensResponse) ProtoMessage() {}
+
+func (x *ListHoneytokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoneytokensResponse.ProtoReflect.Descriptor instead.
+func (*ListHoneytokensResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListHoneytokensResponse) GetHoneytokens() []*Honeytoken {
+	if x != nil {
+		return x.Honeytokens
+	}
+	return nil
+}
+
+func (x *ListHoneytokensResponse) GetPagination() *v11.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// UserMergeDiff is what MergeUsers moves from the duplicate user to the primary, or drops. Counts are the
+// duplicate's rows.
+type UserMergeDiff struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PrimaryUserId   string                 `protobuf:"bytes,1,opt,name=primary_user_id,json=primaryUserId,proto3" json:"primary_user_id,omitempty"`
+	PrimaryEmail    string                 `protobuf:"bytes,2,opt,name=primary_email,json=primaryEmail,proto3" json:"primary_email,omitempty"`
+	DuplicateUserId string                 `protobuf:"bytes,3,opt,name=duplicate_user_id,json=duplicateUserId,proto3" json:"duplicate_user_id,omitempty"`
+	DuplicateEmail  string                 `protobuf:"bytes,4,opt,name=duplicate_email,json=duplicateEmail,proto3" json:"duplicate_email,omitempty"`
+	// identities are moved; identities_dropped is the duplicate's password, dropped because the primary has one.
+	Identities        int64 `protobuf:"varint,5,opt,name=identities,proto3" json:"identities,omitempty"`
+	IdentitiesDropped int64 `protobuf:"varint,6,opt,name=identities_dropped,json=identitiesDropped,proto3" json:"identities_dropped,omitempty"`
+	// memberships are moved; memberships_merged are of orgs both users belong to, where the primary keeps the higher role.
+	Memberships       int64 `protobuf:"varint,7,opt,name=memberships,proto3" json:"memberships,omitempty"`
+	MembershipsMerged int64 `protobuf:"varint,8,opt,name=memberships_merged,json=membershipsMerged,proto3" json:"memberships_merged,omitempty"`
+	// group_memberships are moved; group_memberships_merged are of groups both users are in.
+	GroupMemberships       int64 `protobuf:"varint,9,opt,name=group_memberships,json=groupMemberships,proto3" json:"group_memberships,omitempty"`
+	GroupMembershipsMerged int64 `protobuf:"varint,10,opt,name=group_memberships_merged,json=groupMembershipsMerged,proto3" json:"group_memberships_merged,omitempty"`
+	Devices                int64 `protobuf:"varint,11,opt,name=devices,proto3" json:"devices,omitempty"`
+	Sessions               int64 `protobuf:"varint,12,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	AuditLogs              int64 `protobuf:"varint,13,opt,name=audit_logs,json=auditLogs,proto3" json:"audit_logs,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UserMergeDiff) Reset() {
+	*x = UserMergeDiff{}
+	mi := &file_admin_admin_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserMergeDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserMergeDiff) ProtoMessage() {}
+
+func (x *UserMergeDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserMergeDiff.ProtoReflect.Descriptor instead.
+func (*UserMergeDiff) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UserMergeDiff) GetPrimaryUserId() string {
+	if x != nil {
+		return x.PrimaryUserId
+	}
+	return ""
+}
+
+func (x *UserMergeDiff) GetPrimaryEmail() string {
+	if x != nil {
+		return x.PrimaryEmail
+	}
+	return ""
+}
+
+func (x *UserMergeDiff) GetDuplicateUserId() string {
+	if x != nil {
+		return x.DuplicateUserId
+	}
+	return ""
+}
+
+func (x *UserMergeDiff) GetDuplicateEmail() string {
+	if x != nil {
+		return x.DuplicateEmail
+	}
+	return ""
+}
+
+func (x *UserMergeDiff) GetIdentities() int64 {
+	if x != nil {
+		return x.Identities
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetIdentitiesDropped() int64 {
+	if x != nil {
+		return x.IdentitiesDropped
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetMemberships() int64 {
+	if x != nil {
+		return x.Memberships
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetMembershipsMerged() int64 {
+	if x != nil {
+		return x.MembershipsMerged
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetGroupMemberships() int64 {
+	if x != nil {
+		return x.GroupMemberships
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetGroupMembershipsMerged() int64 {
+	if x != nil {
+		return x.GroupMembershipsMerged
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetDevices() int64 {
+	if x != nil {
+		return x.Devices
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetSessions() int64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *UserMergeDiff) GetAuditLogs() int64 {
+	if x != nil {
+		return x.AuditLogs
+	}
+	return 0
+}
+
+// MergeUsersRequest merges the duplicate user into the primary. Without confirmation_token nothing is changed: the
+// reply carries the diff and a token to send back to apply exactly that merge.
+type MergeUsersRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PrimaryUserId     string                 `protobuf:"bytes,1,opt,name=primary_user_id,json=primaryUserId,proto3" json:"primary_user_id,omitempty"`
+	DuplicateUserId   string                 `protobuf:"bytes,2,opt,name=duplicate_user_id,json=duplicateUserId,proto3" json:"duplicate_user_id,omitempty"`
+	ConfirmationToken string                 `protobuf:"bytes,3,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MergeUsersRequest) Reset() {
+	*x = MergeUsersRequest{}
+	mi := &file_admin_admin_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUsersRequest) ProtoMessage() {}
+
+func (x *MergeUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUsersRequest.ProtoReflect.Descriptor instead.
+func (*MergeUsersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MergeUsersRequest) GetPrimaryUserId() string {
+	if x != nil {
+		return x.PrimaryUserId
+	}
+	return ""
+}
+
+func (x *MergeUsersRequest) GetDuplicateUserId() string {
+	if x != nil {
+		return x.DuplicateUserId
+	}
+	return ""
+}
+
+func (x *MergeUsersRequest) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+// MergeUsersResponse returns the diff of a dry run with its confirmation token, or of the applied merge.
+type MergeUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Diff  *UserMergeDiff         `protobuf:"bytes,1,opt,name=diff,proto3" json:"diff,omitempty"`
+	// merged is set when the merge was applied.
+	Merged                bool                   `protobuf:"varint,2,opt,name=merged,proto3" json:"merged,omitempty"`
+	ConfirmationToken     string                 `protobuf:"bytes,3,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"` // set only in reply to a request without one
+	ConfirmationExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=confirmation_expires_at,json=confirmationExpiresAt,proto3" json:"confirmation_expires_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *MergeUsersResponse) Reset() {
+	*x = MergeUsersResponse{}
+	mi := &file_admin_admin_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUsersResponse) ProtoMessage() {}
+
+func (x *MergeUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUsersResponse.ProtoReflect.Descriptor instead.
+func (*MergeUsersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MergeUsersResponse) GetDiff() *UserMergeDiff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+func (x *MergeUsersResponse) GetMerged() bool {
+	if x != nil {
+		return x.Merged
+	}
+	return false
+}
+
+func (x *MergeUsersResponse) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+func (x *MergeUsersResponse) GetConfirmationExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmationExpiresAt
+	}
+	return nil
+}
+
+// ExportUserDataRequest requests an archive of a user's personal data, for a data-subject request received outside
+// the product.
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_admin_admin_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// ExportUserDataResponse returns the download token of the export, for UserService.DownloadDataExport by the same
+// admin. The token is shown only once.
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	DownloadToken string                 `protobuf:"bytes,2,opt,name=download_token,json=downloadToken,proto3" json:"download_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_admin_admin_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ExportUserDataResponse) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse) GetDownloadToken() string {
+	if x != nil {
+		return x.DownloadToken
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// CircuitBreaker is one breaker of this server instance.
+type CircuitBreaker struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the guarded dependency: sms or policy.
+	Name                string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State               CircuitBreakerState `protobuf:"varint,2,opt,name=state,proto3,enum=ztcp.admin.v1.CircuitBreakerState" json:"state,omitempty"`
+	ConsecutiveFailures int32               `protobuf:"varint,3,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// opens counts transitions to open since the instance started.
+	Opens int64 `protobuf:"varint,4,opt,name=opens,proto3" json:"opens,omitempty"`
+	// rejected counts calls refused while open since the instance started.
+	Rejected int64 `protobuf:"varint,5,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	// state_since is when the breaker entered its state; unset while it has never opened.
+	StateSince    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=state_since,json=stateSince,proto3" json:"state_since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CircuitBreaker) Reset() {
+	*x = CircuitBreaker{}
+	mi := &file_admin_admin_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitBreaker) ProtoMessage() {}
+
+func (x *CircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitBreaker.ProtoReflect.Descriptor instead.
+func (*CircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CircuitBreaker) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CircuitBreaker) GetState() CircuitBreakerState {
+	if x != nil {
+		return x.State
+	}
+	return CircuitBreakerState_CIRCUIT_BREAKER_STATE_UNSPECIFIED
+}
+
+func (x *CircuitBreaker) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *CircuitBreaker) GetOpens() int64 {
+	if x != nil {
+		return x.Opens
+	}
+	return 0
+}
+
+func (x *CircuitBreaker) GetRejected() int64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *CircuitBreaker) GetStateSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StateSince
+	}
+	return nil
+}
+
+// ListCircuitBreakersRequest is empty.
+type ListCircuitBreakersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCircuitBreakersRequest) Reset() {
+	*x = ListCircuitBreakersRequest{}
+	mi := &file_admin_admin_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCircuitBreakersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCircuitBreakersRequest) ProtoMessage() {}
+
+func (x *ListCircuitBreakersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCircuitBreakersRequest.ProtoReflect.Descriptor instead.
+func (*ListCircuitBreakersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{47}
+}
+
+// ListCircuitBreakersResponse returns the breakers of the instance that served the call, sorted by name.
+type ListCircuitBreakersResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Breakers []*CircuitBreaker      `protobuf:"bytes,1,rep,name=breakers,proto3" json:"breakers,omitempty"`
+	// sms_retry_queued is how many SMS messages wait for retry on this instance.
+	SmsRetryQueued int32 `protobuf:"varint,2,opt,name=sms_retry_queued,json=smsRetryQueued,proto3" json:"sms_retry_queued,omitempty"`
+	// sms_retry_dropped counts queued SMS messages dropped unsent since the instance started.
+	SmsRetryDropped int64 `protobuf:"varint,3,opt,name=sms_retry_dropped,json=smsRetryDropped,proto3" json:"sms_retry_dropped,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListCircuitBreakersResponse) Reset() {
+	*x = ListCircuitBreakersResponse{}
+	mi := &file_admin_admin_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCircuitBreakersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCircuitBreakersResponse) ProtoMessage() {}
+
+func (x *ListCircuitBreakersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCircuitBreakersResponse.ProtoReflect.Descriptor instead.
+func (*ListCircuitBreakersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListCircuitBreakersResponse) GetBreakers() []*CircuitBreaker {
+	if x != nil {
+		return x.Breakers
+	}
+	return nil
+}
+
+func (x *ListCircuitBreakersResponse) GetSmsRetryQueued() int32 {
+	if x != nil {
+		return x.SmsRetryQueued
+	}
+	return 0
+}
+
+func (x *ListCircuitBreakersResponse) GetSmsRetryDropped() int64 {
+	if x != nil {
+		return x.SmsRetryDropped
+	}
+	return 0
+}
+
+// SharedDevice is a device fingerprint registered by several users: one machine many people sign in on, or a
+// fingerprint that is not unique. Counts cover unrevoked devices only.
+type SharedDevice struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Fingerprint string                 `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// user_count is the number of distinct users with a device of the fingerprint.
+	UserCount int32 `protobuf:"varint,2,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
+	// org_count is the number of distinct orgs with a device of the fingerprint.
+	OrgCount int32 `protobuf:"varint,3,opt,name=org_count,json=orgCount,proto3" json:"org_count,omitempty"`
+	// device_count is the number of devices (one per user and org).
+	DeviceCount int32 `protobuf:"varint,4,opt,name=device_count,json=deviceCount,proto3" json:"device_count,omitempty"`
+	// last_seen_at is when any of its devices was last used.
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedDevice) Reset() {
+	*x = SharedDevice{}
+	mi := &file_admin_admin_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDevice) ProtoMessage() {}
+
+func (x *SharedDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDevice.ProtoReflect.Descriptor instead.
+func (*SharedDevice) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SharedDevice) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *SharedDevice) GetUserCount() int32 {
+	if x != nil {
+		return x.UserCount
+	}
+	return 0
+}
+
+func (x *SharedDevice) GetOrgCount() int32 {
+	if x != nil {
+		return x.OrgCount
+	}
+	return 0
+}
+
+func (x *SharedDevice) GetDeviceCount() int32 {
+	if x != nil {
+		return x.DeviceCount
+	}
+	return 0
+}
+
+func (x *SharedDevice) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+// ListSharedDevicesRequest filters the report of shared fingerprints.
+type ListSharedDevicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// min_users is the least number of users a fingerprint must have; 0 uses the server's
+	// SHARED_DEVICE_USER_THRESHOLD.
+	MinUsers int32 `protobuf:"varint,1,opt,name=min_users,json=minUsers,proto3" json:"min_users,omitempty"`
+	// limit caps the fingerprints returned; 0 or above 500 means 500.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedDevicesRequest) Reset() {
+	*x = ListSharedDevicesRequest{}
+	mi := &file_admin_admin_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedDevicesRequest) ProtoMessage() {}
+
+func (x *ListSharedDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListSharedDevicesRequest) GetMinUsers() int32 {
+	if x != nil {
+		return x.MinUsers
+	}
+	return 0
+}
+
+func (x *ListSharedDevicesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListSharedDevicesResponse returns the shared fingerprints, most widely shared first.
+type ListSharedDevicesResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Devices []*SharedDevice        `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// min_users is the threshold applied.
+	MinUsers      int32 `protobuf:"varint,2,opt,name=min_users,json=minUsers,proto3" json:"min_users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedDevicesResponse) Reset() {
+	*x = ListSharedDevicesResponse{}
+	mi := &file_admin_admin_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedDevicesResponse) ProtoMessage() {}
+
+func (x *ListSharedDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_admin_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListSharedDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_admin_admin_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListSharedDevicesResponse) GetDevices() []*SharedDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *ListSharedDevicesResponse) GetMinUsers() int32 {
+	if x != nil {
+		return x.MinUsers
+	}
+	return 0
+}
+
+var File_admin_admin_proto protoreflect.FileDescriptor
+
+const file_admin_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x11admin/admin.proto\x12\rztcp.admin.v1\x1a\x19analytics/analytics.proto\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xce\x01\n" +
+	"\vSystemStats\x12\x1b\n" +
+	"\torg_count\x18\x01 \x01(\x03R\borgCount\x12\x1d\n" +
+	"\n" +
+	"user_count\x18\x02 \x01(\x03R\tuserCount\x12#\n" +
+	"\rsession_count\x18\x03 \x01(\x03R\fsessionCount\x12!\n" +
+	"\fdevice_count\x18\x04 \x01(\x03R\vdeviceCount\x12;\n" +
+	"\vcomputed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\"\x17\n" +
+	"\x15GetSystemStatsRequest\"J\n" +
+	"\x16GetSystemStatsResponse\x120\n" +
+	"\x05stats\x18\x01 \x01(\v2\x1a.ztcp.admin.v1.SystemStatsR\x05stats\"q\n" +
+	"\vConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x1e\n" +
+	"\n" +
+	"reloadable\x18\x03 \x01(\bR\n" +
+	"reloadable\x12\x1a\n" +
+	"\bredacted\x18\x04 \x01(\bR\bredacted\"\x1b\n" +
+	"\x19GetEffectiveConfigRequest\"\x8b\x01\n" +
+	"\x1aGetEffectiveConfigResponse\x124\n" +
+	"\aentries\x18\x01 \x03(\v2\x1a.ztcp.admin.v1.ConfigEntryR\aentries\x127\n" +
+	"\tloaded_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bloadedAt\"\xeb\x01\n" +
+	"\x11MaintenanceStatus\x122\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x1e.ztcp.admin.v1.MaintenanceModeR\x04mode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x13retry_after_seconds\x18\x03 \x01(\x05R\x11retryAfterSeconds\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x1b\n" +
+	"\x19GetMaintenanceModeRequest\"V\n" +
+	"\x1aGetMaintenanceModeResponse\x128\n" +
+	"\x06status\x18\x01 \x01(\v2 .ztcp.admin.v1.MaintenanceStatusR\x06status\"\x99\x01\n" +
+	"\x19SetMaintenanceModeRequest\x122\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x1e.ztcp.admin.v1.MaintenanceModeR\x04mode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x13retry_after_seconds\x18\x03 \x01(\x05R\x11retryAfterSeconds\"V\n" +
+	"\x1aSetMaintenanceModeResponse\x128\n" +
+	"\x06status\x18\x01 \x01(\v2 .ztcp.admin.v1.MaintenanceStatusR\x06status\"\x91\x01\n" +
+	"\tQuotaPlan\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x125\n" +
+	"\x17org_requests_per_minute\x18\x02 \x01(\x05R\x14orgRequestsPerMinute\x129\n" +
+	"\x19token_requests_per_minute\x18\x03 \x01(\x05R\x16tokenRequestsPerMinute\"\x17\n" +
+	"\x15ListQuotaPlansRequest\"\x87\x01\n" +
+	"\x16ListQuotaPlansResponse\x12.\n" +
+	"\x05plans\x18\x01 \x03(\v2\x18.ztcp.admin.v1.QuotaPlanR\x05plans\x12!\n" +
+	"\fdefault_plan\x18\x02 \x01(\tR\vdefaultPlan\x12\x1a\n" +
+	"\benforced\x18\x03 \x01(\bR\benforced\"\xb6\x02\n" +
+	"\x10OrgQuotaOverride\x12\x12\n" +
+	"\x04plan\x18\x01 \x01(\tR\x04plan\x12:\n" +
+	"\x17org_requests_per_minute\x18\x02 \x01(\x05H\x00R\x14orgRequestsPerMinute\x88\x01\x01\x12>\n" +
+	"\x19token_requests_per_minute\x18\x03 \x01(\x05H\x01R\x16tokenRequestsPerMinute\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x1a\n" +
+	"\x18_org_requests_per_minuteB\x1c\n" +
+	"\x1a_token_requests_per_minute\"\x96\x01\n" +
+	"\rOrgQuotaUsage\x12!\n" +
+	"\forg_requests\x18\x01 \x01(\x05R\vorgRequests\x12\x18\n" +
+	"\aallowed\x18\x02 \x01(\x03R\aallowed\x12!\n" +
+	"\frejected_org\x18\x03 \x01(\x03R\vrejectedOrg\x12%\n" +
+	"\x0erejected_token\x18\x04 \x01(\x03R\rrejectedToken\"\xc4\x01\n" +
+	"\bOrgQuota\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x120\n" +
+	"\x06limits\x18\x02 \x01(\v2\x18.ztcp.admin.v1.QuotaPlanR\x06limits\x12;\n" +
+	"\boverride\x18\x03 \x01(\v2\x1f.ztcp.admin.v1.OrgQuotaOverrideR\boverride\x122\n" +
+	"\x05usage\x18\x04 \x01(\v2\x1c.ztcp.admin.v1.OrgQuotaUsageR\x05usage\"+\n" +
+	"\x12GetOrgQuotaRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"D\n" +
+	"\x13GetOrgQuotaResponse\x12-\n" +
+	"\x05quota\x18\x01 \x01(\v2\x17.ztcp.admin.v1.OrgQuotaR\x05quota\"\x8b\x02\n" +
+	"\x12SetOrgQuotaRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04plan\x18\x02 \x01(\tR\x04plan\x12:\n" +
+	"\x17org_requests_per_minute\x18\x03 \x01(\x05H\x00R\x14orgRequestsPerMinute\x88\x01\x01\x12>\n" +
+	"\x19token_requests_per_minute\x18\x04 \x01(\x05H\x01R\x16tokenRequestsPerMinute\x88\x01\x01\x12\x14\n" +
+	"\x05clear\x18\x05 \x01(\bR\x05clearB\x1a\n" +
+	"\x18_org_requests_per_minuteB\x1c\n" +
+	"\x1a_token_requests_per_minute\"D\n" +
+	"\x13SetOrgQuotaResponse\x12-\n" +
+	"\x05quota\x18\x01 \x01(\v2\x17.ztcp.admin.v1.OrgQuotaR\x05quota\"=\n" +
+	"\vBillingPlan\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bfeatures\x18\x02 \x03(\tR\bfeatures\"\x19\n" +
+	"\x17ListBillingPlansRequest\"h\n" +
+	"\x18ListBillingPlansResponse\x120\n" +
+	"\x05plans\x18\x01 \x03(\v2\x1a.ztcp.admin.v1.BillingPlanR\x05plans\x12\x1a\n" +
+	"\benforced\x18\x02 \x01(\bR\benforced\"E\n" +
+	"\x18SetOrgBillingPlanRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04plan\x18\x02 \x01(\tR\x04plan\"b\n" +
+	"\x19SetOrgBillingPlanResponse\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12.\n" +
+	"\x04plan\x18\x02 \x01(\v2\x1a.ztcp.admin.v1.BillingPlanR\x04plan\"x\n" +
+	"\x12ExportUsageRequest\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x10\n" +
+	"\x03csv\x18\x04 \x01(\bR\x03csv\"\x82\x01\n" +
+	"\x13ExportUsageResponse\x121\n" +
+	"\x05usage\x18\x01 \x03(\v2\x1b.ztcp.analytics.v1.OrgUsageR\x05usage\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x10\n" +
+	"\x03csv\x18\x03 \x01(\tR\x03csv\"\xa9\x03\n" +
+	"\rLicenseStatus\x121\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x1b.ztcp.admin.v1.LicenseStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"license_id\x18\x02 \x01(\tR\tlicenseId\x12\x1a\n" +
+	"\bcustomer\x18\x03 \x01(\tR\bcustomer\x12\x14\n" +
+	"\x05seats\x18\x04 \x01(\x05R\x05seats\x12\x1d\n" +
+	"\n" +
+	"seats_used\x18\x05 \x01(\x03R\tseatsUsed\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\tR\bfeatures\x127\n" +
+	"\tissued_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12>\n" +
+	"\rgrace_ends_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vgraceEndsAt\x12%\n" +
+	"\x0edays_remaining\x18\n" +
+	" \x01(\x05R\rdaysRemaining\"\x19\n" +
+	"\x17GetLicenseStatusRequest\"P\n" +
+	"\x18GetLicenseStatusResponse\x124\n" +
+	"\x06status\x18\x01 \x01(\v2\x1c.ztcp.admin.v1.LicenseStatusR\x06status\"5\n" +
+	"\vBackupTable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04rows\x18\x02 \x01(\x03R\x04rows\",\n" +
+	"\x13CreateBackupRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"\xe8\x01\n" +
+	"\x14CreateBackupResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12%\n" +
+	"\x0eschema_version\x18\x04 \x01(\x03R\rschemaVersion\x122\n" +
+	"\x06tables\x18\x05 \x03(\v2\x1a.ztcp.admin.v1.BackupTableR\x06tables\"\x82\x02\n" +
+	"\n" +
+	"Honeytoken\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rtrigger_count\x18\x05 \x01(\x03R\ftriggerCount\x12F\n" +
+	"\x11last_triggered_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastTriggeredAt\"F\n" +
+	"\x15MarkHoneytokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"S\n" +
+	"\x16MarkHoneytokenResponse\x129\n" +
+	"\n" +
+	"honeytoken\x18\x01 \x01(\v2\x19.ztcp.admin.v1.HoneytokenR\n" +
+	"honeytoken\"2\n" +
+	"\x17UnmarkHoneytokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x1a\n" +
+	"\x18UnmarkHoneytokenResponse\"T\n" +
+	"\x16ListHoneytokensRequest\x12:\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\x98\x01\n" +
+	"\x17ListHoneytokensResponse\x12;\n" +
+	"\vhoneytokens\x18\x01 \x03(\v2\x19.ztcp.admin.v1.HoneytokenR\vhoneytokens\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"\x8d\x04\n" +
+	"\rUserMergeDiff\x12&\n" +
+	"\x0fprimary_user_id\x18\x01 \x01(\tR\rprimaryUserId\x12#\n" +
+	"\rprimary_email\x18\x02 \x01(\tR\fprimaryEmail\x12*\n" +
+	"\x11duplicate_user_id\x18\x03 \x01(\tR\x0fduplicateUserId\x12'\n" +
+	"\x0fduplicate_email\x18\x04 \x01(\tR\x0eduplicateEmail\x12\x1e\n" +
+	"\n" +
+	"identities\x18\x05 \x01(\x03R\n" +
+	"identities\x12-\n" +
+	"\x12identities_dropped\x18\x06 \x01(\x03R\x11identitiesDropped\x12 \n" +
+	"\vmemberships\x18\a \x01(\x03R\vmemberships\x12-\n" +
+	"\x12memberships_merged\x18\b \x01(\x03R\x11membershipsMerged\x12+\n" +
+	"\x11group_memberships\x18\t \x01(\x03R\x10groupMemberships\x128\n" +
+	"\x18group_memberships_merged\x18\n" +
+	" \x01(\x03R\x16groupMembershipsMerged\x12\x18\n" +
+	"\adevices\x18\v \x01(\x03R\adevices\x12\x1a\n" +
+	"\bsessions\x18\f \x01(\x03R\bsessions\x12\x1d\n" +
+	"\n" +
+	"audit_logs\x18\r \x01(\x03R\tauditLogs\"\x96\x01\n" +
+	"\x11MergeUsersRequest\x12&\n" +
+	"\x0fprimary_user_id\x18\x01 \x01(\tR\rprimaryUserId\x12*\n" +
+	"\x11duplicate_user_id\x18\x02 \x01(\tR\x0fduplicateUserId\x12-\n" +
+	"\x12confirmation_token\x18\x03 \x01(\tR\x11confirmationToken\"\xe1\x01\n" +
+	"\x12MergeUsersResponse\x120\n" +
+	"\x04diff\x18\x01 \x01(\v2\x1c.ztcp.admin.v1.UserMergeDiffR\x04diff\x12\x16\n" +
+	"\x06merged\x18\x02 \x01(\bR\x06merged\x12-\n" +
+	"\x12confirmation_token\x18\x03 \x01(\tR\x11confirmationToken\x12R\n" +
+	"\x17confirmation_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x15confirmationExpiresAt\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x97\x01\n" +
+	"\x16ExportUserDataResponse\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\x12%\n" +
+	"\x0edownload_token\x18\x02 \x01(\tR\rdownloadToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x80\x02\n" +
+	"\x0eCircuitBreaker\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\x05state\x18\x02 \x01(\x0e2\".ztcp.admin.v1.CircuitBreakerStateR\x05state\x121\n" +
+	"\x14consecutive_failures\x18\x03 \x01(\x05R\x13consecutiveFailures\x12\x14\n" +
+	"\x05opens\x18\x04 \x01(\x03R\x05opens\x12\x1a\n" +
+	"\brejected\x18\x05 \x01(\x03R\brejected\x12;\n" +
+	"\vstate_since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"stateSince\"\x1c\n" +
+	"\x1aListCircuitBreakersRequest\"\xae\x01\n" +
+	"\x1bListCircuitBreakersResponse\x129\n" +
+	"\bbreakers\x18\x01 \x03(\v2\x1d.ztcp.admin.v1.CircuitBreakerR\bbreakers\x12(\n" +
+	"\x10sms_retry_queued\x18\x02 \x01(\x05R\x0esmsRetryQueued\x12*\n" +
+	"\x11sms_retry_dropped\x18\x03 \x01(\x03R\x0fsmsRetryDropped\"\xcd\x01\n" +
+	"\fSharedDevice\x12 \n" +
+	"\vfingerprint\x18\x01 \x01(\tR\vfingerprint\x12\x1d\n" +
+	"\n" +
+	"user_count\x18\x02 \x01(\x05R\tuserCount\x12\x1b\n" +
+	"\torg_count\x18\x03 \x01(\x05R\borgCount\x12!\n" +
+	"\fdevice_count\x18\x04 \x01(\x05R\vdeviceCount\x12<\n" +
+	"\flast_seen_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"M\n" +
+	"\x18ListSharedDevicesRequest\x12\x1b\n" +
+	"\tmin_users\x18\x01 \x01(\x05R\bminUsers\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"o\n" +
+	"\x19ListSharedDevicesResponse\x125\n" +
+	"\adevices\x18\x01 \x03(\v2\x1b.ztcp.admin.v1.SharedDeviceR\adevices\x12\x1b\n" +
+	"\tmin_users\x18\x02 \x01(\x05R\bminUsers*\x8f\x01\n" +
+	"\x0fMaintenanceMode\x12 \n" +
+	"\x1cMAINTENANCE_MODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14MAINTENANCE_MODE_OFF\x10\x01\x12\x1e\n" +
+	"\x1aMAINTENANCE_MODE_READ_ONLY\x10\x02\x12 \n" +
+	"\x1cMAINTENANCE_MODE_MAINTENANCE\x10\x03*\xb4\x01\n" +
+	"\fLicenseState\x12\x1d\n" +
+	"\x19LICENSE_STATE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18LICENSE_STATE_UNLICENSED\x10\x01\x12\x17\n" +
+	"\x13LICENSE_STATE_VALID\x10\x02\x12\x1a\n" +
+	"\x16LICENSE_STATE_EXPIRING\x10\x03\x12\x17\n" +
+	"\x13LICENSE_STATE_GRACE\x10\x04\x12\x19\n" +
+	"\x15LICENSE_STATE_EXPIRED\x10\x05*\xa3\x01\n" +
+	"\x13CircuitBreakerState\x12%\n" +
+	"!CIRCUIT_BREAKER_STATE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cCIRCUIT_BREAKER_STATE_CLOSED\x10\x01\x12\x1e\n" +
+	"\x1aCIRCUIT_BREAKER_STATE_OPEN\x10\x02\x12#\n" +
+	"\x1fCIRCUIT_BREAKER_STATE_HALF_OPEN\x10\x032\xff\x0e\n" +
+	"\fAdminService\x12b\n" +
+	"\x0eGetSystemStats\x12$.ztcp.admin.v1.GetSystemStatsRequest\x1a%.ztcp.admin.v1.GetSystemStatsResponse\"\x03\x90\x02\x01\x12n\n" +
+	"\x12GetEffectiveConfig\x12(.ztcp.admin.v1.GetEffectiveConfigRequest\x1a).ztcp.admin.v1.GetEffectiveConfigResponse\"\x03\x90\x02\x01\x12n\n" +
+	"\x12GetMaintenanceMode\x12(.ztcp.admin.v1.GetMaintenanceModeRequest\x1a).ztcp.admin.v1.GetMaintenanceModeResponse\"\x03\x90\x02\x01\x12i\n" +
+	"\x12SetMaintenanceMode\x12(.ztcp.admin.v1.SetMaintenanceModeRequest\x1a).ztcp.admin.v1.SetMaintenanceModeResponse\x12b\n" +
+	"\x0eListQuotaPlans\x12$.ztcp.admin.v1.ListQuotaPlansRequest\x1a%.ztcp.admin.v1.ListQuotaPlansResponse\"\x03\x90\x02\x01\x12Y\n" +
+	"\vGetOrgQuota\x12!.ztcp.admin.v1.GetOrgQuotaRequest\x1a\".ztcp.admin.v1.GetOrgQuotaResponse\"\x03\x90\x02\x01\x12T\n" +
+	"\vSetOrgQuota\x12!.ztcp.admin.v1.SetOrgQuotaRequest\x1a\".ztcp.admin.v1.SetOrgQuotaResponse\x12h\n" +
+	"\x10ListBillingPlans\x12&.ztcp.admin.v1.ListBillingPlansRequest\x1a'.ztcp.admin.v1.ListBillingPlansResponse\"\x03\x90\x02\x01\x12f\n" +
+	"\x11SetOrgBillingPlan\x12'.ztcp.admin.v1.SetOrgBillingPlanRequest\x1a(.ztcp.admin.v1.SetOrgBillingPlanResponse\x12Y\n" +
+	"\vExportUsage\x12!.ztcp.admin.v1.ExportUsageRequest\x1a\".ztcp.admin.v1.ExportUsageResponse\"\x03\x90\x02\x01\x12h\n" +
+	"\x10GetLicenseStatus\x12&.ztcp.admin.v1.GetLicenseStatusRequest\x1a'.ztcp.admin.v1.GetLicenseStatusResponse\"\x03\x90\x02\x01\x12W\n" +
+	"\fCreateBackup\x12\".ztcp.admin.v1.CreateBackupRequest\x1a#.ztcp.admin.v1.CreateBackupResponse\x12]\n" +
+	"\x0eMarkHoneytoken\x12$.ztcp.admin.v1.MarkHoneytokenRequest\x1a%.ztcp.admin.v1.MarkHoneytokenResponse\x12c\n" +
+	"\x10UnmarkHoneytoken\x12&.ztcp.admin.v1.UnmarkHoneytokenRequest\x1a'.ztcp.admin.v1.UnmarkHoneytokenResponse\x12e\n" +
+	"\x0fListHoneytokens\x12%.ztcp.admin.v1.ListHoneytokensRequest\x1a&.ztcp.admin.v1.ListHoneytokensResponse\"\x03\x90\x02\x01\x12Q\n" +
+	"\n" +
+	"MergeUsers\x12 .ztcp.admin.v1.MergeUsersRequest\x1a!.ztcp.admin.v1.MergeUsersResponse\x12]\n" +
+	"\x0eExportUserData\x12$.ztcp.admin.v1.ExportUserDataRequest\x1a%.ztcp.admin.v1.ExportUserDataResponse\x12q\n" +
+	"\x13ListCircuitBreakers\x12).ztcp.admin.v1.ListCircuitBreakersRequest\x1a*.ztcp.admin.v1.ListCircuitBreakersResponse\"\x03\x90\x02\x01\x12k\n" +
+	"\x11ListSharedDevices\x12'.ztcp.admin.v1.ListSharedDevicesRequest\x1a(.ztcp.admin.v1.ListSharedDevicesResponse\"\x03\x90\x02\x01BAZ?zero-trust-control-plane/backend/api/generated/admin/v1;adminv1b\x06proto3"
 
 var (
 	file_admin_admin_proto_rawDescOnce sync.Once
@@ -212,23 +3577,153 @@ func file_admin_admin_proto_rawDescGZIP() []byte {
 	return file_admin_admin_proto_rawDescData
 }
 
-var file_admin_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_admin_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_admin_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_admin_admin_proto_goTypes = []any{
-	(*SystemStats)(nil),            // 0: ztcp.admin.v1.SystemStats
-	(*GetSystemStatsRequest)(nil),  // 1: ztcp.admin.v1.GetSystemStatsRequest
-	(*GetSystemStatsResponse)(nil), // 2: ztcp.admin.v1.GetSystemStatsResponse
-	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+	(MaintenanceMode)(0),                // 0: ztcp.admin.v1.MaintenanceMode
+	(LicenseState)(0),                   // 1: ztcp.admin.v1.LicenseState
+	(CircuitBreakerState)(0),            // 2: ztcp.admin.v1.CircuitBreakerState
+	(*SystemStats)(nil),                 // 3: ztcp.admin.v1.SystemStats
+	(*GetSystemStatsRequest)(nil),       // 4: ztcp.admin.v1.GetSystemStatsRequest
+	(*GetSystemStatsResponse)(nil),      // 5: ztcp.admin.v1.GetSystemStatsResponse
+	(*ConfigEntry)(nil),                 // 6: ztcp.admin.v1.ConfigEntry
+	(*GetEffectiveConfigRequest)(nil),   // 7: ztcp.admin.v1.GetEffectiveConfigRequest
+	(*GetEffectiveConfigResponse)(nil),  // 8: ztcp.admin.v1.GetEffectiveConfigResponse
+	(*MaintenanceStatus)(nil),           // 9: ztcp.admin.v1.MaintenanceStatus
+	(*GetMaintenanceModeRequest)(nil),   // 10: ztcp.admin.v1.GetMaintenanceModeRequest
+	(*GetMaintenanceModeResponse)(nil),  // 11: ztcp.admin.v1.GetMaintenanceModeResponse
+	(*SetMaintenanceModeRequest)(nil),   // 12: ztcp.admin.v1.SetMaintenanceModeRequest
+	(*SetMaintenanceModeResponse)(nil),  // 13: ztcp.admin.v1.SetMaintenanceModeResponse
+	(*QuotaPlan)(nil),                   // 14: ztcp.admin.v1.QuotaPlan
+	(*ListQuotaPlansRequest)(nil),       // 15: ztcp.admin.v1.ListQuotaPlansRequest
+	(*ListQuotaPlansResponse)(nil),      // 16: ztcp.admin.v1.ListQuotaPlansResponse
+	(*OrgQuotaOverride)(nil),            // 17: ztcp.admin.v1.OrgQuotaOverride
+	(*OrgQuotaUsage)(nil),               // 18: ztcp.admin.v1.OrgQuotaUsage
+	(*OrgQuota)(nil),                    // 19: ztcp.admin.v1.OrgQuota
+	(*GetOrgQuotaRequest)(nil),          // 20: ztcp.admin.v1.GetOrgQuotaRequest
+	(*GetOrgQuotaResponse)(nil),         // 21: ztcp.admin.v1.GetOrgQuotaResponse
+	(*SetOrgQuotaRequest)(nil),          // 22: ztcp.admin.v1.SetOrgQuotaRequest
+	(*SetOrgQuotaResponse)(nil),         // 23: ztcp.admin.v1.SetOrgQuotaResponse
+	(*BillingPlan)(nil),                 // 24: ztcp.admin.v1.BillingPlan
+	(*ListBillingPlansRequest)(nil),     // 25: ztcp.admin.v1.ListBillingPlansRequest
+	(*ListBillingPlansResponse)(nil),    // 26: ztcp.admin.v1.ListBillingPlansResponse
+	(*SetOrgBillingPlanRequest)(nil),    // 27: ztcp.admin.v1.SetOrgBillingPlanRequest
+	(*SetOrgBillingPlanResponse)(nil),   // 28: ztcp.admin.v1.SetOrgBillingPlanResponse
+	(*ExportUsageRequest)(nil),          // 29: ztcp.admin.v1.ExportUsageRequest
+	(*ExportUsageResponse)(nil),         // 30: ztcp.admin.v1.ExportUsageResponse
+	(*LicenseStatus)(nil),               // 31: ztcp.admin.v1.LicenseStatus
+	(*GetLicenseStatusRequest)(nil),     // 32: ztcp.admin.v1.GetLicenseStatusRequest
+	(*GetLicenseStatusResponse)(nil),    // 33: ztcp.admin.v1.GetLicenseStatusResponse
+	(*BackupTable)(nil),                 // 34: ztcp.admin.v1.BackupTable
+	(*CreateBackupRequest)(nil),         // 35: ztcp.admin.v1.CreateBackupRequest
+	(*CreateBackupResponse)(nil),        // 36: ztcp.admin.v1.CreateBackupResponse
+	(*Honeytoken)(nil),                  // 37: ztcp.admin.v1.Honeytoken
+	(*MarkHoneytokenRequest)(nil),       // 38: ztcp.admin.v1.MarkHoneytokenRequest
+	(*MarkHoneytokenResponse)(nil),      // 39: ztcp.admin.v1.MarkHoneytokenResponse
+	(*UnmarkHoneytokenRequest)(nil),     // 40: ztcp.admin.v1.UnmarkHoneytokenRequest
+	(*UnmarkHoneytokenResponse)(nil),    // 41: ztcp.admin.v1.UnmarkHoneytokenResponse
+	(*ListHoneytokensRequest)(nil),      // 42: ztcp.admin.v1.ListHoneytokensRequest
+	(*ListHoneytokensResponse)(nil),     // 43: ztcp.admin.v1.ListHoneytokensResponse
+	(*UserMergeDiff)(nil),               // 44: ztcp.admin.v1.UserMergeDiff
+	(*MergeUsersRequest)(nil),           // 45: ztcp.admin.v1.MergeUsersRequest
+	(*MergeUsersResponse)(nil),          // 46: ztcp.admin.v1.MergeUsersResponse
+	(*ExportUserDataRequest)(nil),       // 47: ztcp.admin.v1.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),      // 48: ztcp.admin.v1.ExportUserDataResponse
+	(*CircuitBreaker)(nil),              // 49: ztcp.admin.v1.CircuitBreaker
+	(*ListCircuitBreakersRequest)(nil),  // 50: ztcp.admin.v1.ListCircuitBreakersRequest
+	(*ListCircuitBreakersResponse)(nil), // 51: ztcp.admin.v1.ListCircuitBreakersResponse
+	(*SharedDevice)(nil),                // 52: ztcp.admin.v1.SharedDevice
+	(*ListSharedDevicesRequest)(nil),    // 53: ztcp.admin.v1.ListSharedDevicesRequest
+	(*ListSharedDevicesResponse)(nil),   // 54: ztcp.admin.v1.ListSharedDevicesResponse
+	(*timestamppb.Timestamp)(nil),       // 55: google.protobuf.Timestamp
+	(*v1.OrgUsage)(nil),                 // 56: ztcp.analytics.v1.OrgUsage
+	(*v11.Pagination)(nil),              // 57: ztcp.common.v1.Pagination
+	(*v11.PaginationResult)(nil),        // 58: ztcp.common.v1.PaginationResult
 }
 var file_admin_admin_proto_depIdxs = []int32{
-	3, // 0: ztcp.admin.v1.SystemStats.computed_at:type_name -> google.protobuf.Timestamp
-	0, // 1: ztcp.admin.v1.GetSystemStatsResponse.stats:type_name -> ztcp.admin.v1.SystemStats
-	1, // 2: ztcp.admin.v1.AdminService.GetSystemStats:input_type -> ztcp.admin.v1.GetSystemStatsRequest
-	2, // 3: ztcp.admin.v1.AdminService.GetSystemStats:output_type -> ztcp.admin.v1.GetSystemStatsResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	55, // 0: ztcp.admin.v1.SystemStats.computed_at:type_name -> google.protobuf.Timestamp
+	3,  // 1: ztcp.admin.v1.GetSystemStatsResponse.stats:type_name -> ztcp.admin.v1.SystemStats
+	6,  // 2: ztcp.admin.v1.GetEffectiveConfigResponse.entries:type_name -> ztcp.admin.v1.ConfigEntry
+	55, // 3: ztcp.admin.v1.GetEffectiveConfigResponse.loaded_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: ztcp.admin.v1.MaintenanceStatus.mode:type_name -> ztcp.admin.v1.MaintenanceMode
+	55, // 5: ztcp.admin.v1.MaintenanceStatus.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: ztcp.admin.v1.GetMaintenanceModeResponse.status:type_name -> ztcp.admin.v1.MaintenanceStatus
+	0,  // 7: ztcp.admin.v1.SetMaintenanceModeRequest.mode:type_name -> ztcp.admin.v1.MaintenanceMode
+	9,  // 8: ztcp.admin.v1.SetMaintenanceModeResponse.status:type_name -> ztcp.admin.v1.MaintenanceStatus
+	14, // 9: ztcp.admin.v1.ListQuotaPlansResponse.plans:type_name -> ztcp.admin.v1.QuotaPlan
+	55, // 10: ztcp.admin.v1.OrgQuotaOverride.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 11: ztcp.admin.v1.OrgQuota.limits:type_name -> ztcp.admin.v1.QuotaPlan
+	17, // 12: ztcp.admin.v1.OrgQuota.override:type_name -> ztcp.admin.v1.OrgQuotaOverride
+	18, // 13: ztcp.admin.v1.OrgQuota.usage:type_name -> ztcp.admin.v1.OrgQuotaUsage
+	19, // 14: ztcp.admin.v1.GetOrgQuotaResponse.quota:type_name -> ztcp.admin.v1.OrgQuota
+	19, // 15: ztcp.admin.v1.SetOrgQuotaResponse.quota:type_name -> ztcp.admin.v1.OrgQuota
+	24, // 16: ztcp.admin.v1.ListBillingPlansResponse.plans:type_name -> ztcp.admin.v1.BillingPlan
+	24, // 17: ztcp.admin.v1.SetOrgBillingPlanResponse.plan:type_name -> ztcp.admin.v1.BillingPlan
+	56, // 18: ztcp.admin.v1.ExportUsageResponse.usage:type_name -> ztcp.analytics.v1.OrgUsage
+	1,  // 19: ztcp.admin.v1.LicenseStatus.state:type_name -> ztcp.admin.v1.LicenseState
+	55, // 20: ztcp.admin.v1.LicenseStatus.issued_at:type_name -> google.protobuf.Timestamp
+	55, // 21: ztcp.admin.v1.LicenseStatus.expires_at:type_name -> google.protobuf.Timestamp
+	55, // 22: ztcp.admin.v1.LicenseStatus.grace_ends_at:type_name -> google.protobuf.Timestamp
+	31, // 23: ztcp.admin.v1.GetLicenseStatusResponse.status:type_name -> ztcp.admin.v1.LicenseStatus
+	55, // 24: ztcp.admin.v1.CreateBackupResponse.created_at:type_name -> google.protobuf.Timestamp
+	34, // 25: ztcp.admin.v1.CreateBackupResponse.tables:type_name -> ztcp.admin.v1.BackupTable
+	55, // 26: ztcp.admin.v1.Honeytoken.created_at:type_name -> google.protobuf.Timestamp
+	55, // 27: ztcp.admin.v1.Honeytoken.last_triggered_at:type_name -> google.protobuf.Timestamp
+	37, // 28: ztcp.admin.v1.MarkHoneytokenResponse.honeytoken:type_name -> ztcp.admin.v1.Honeytoken
+	57, // 29: ztcp.admin.v1.ListHoneytokensRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	37, // 30: ztcp.admin.v1.ListHoneytokensResponse.honeytokens:type_name -> ztcp.admin.v1.Honeytoken
+	58, // 31: ztcp.admin.v1.ListHoneytokensResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	44, // 32: ztcp.admin.v1.MergeUsersResponse.diff:type_name -> ztcp.admin.v1.UserMergeDiff
+	55, // 33: ztcp.admin.v1.MergeUsersResponse.confirmation_expires_at:type_name -> google.protobuf.Timestamp
+	55, // 34: ztcp.admin.v1.ExportUserDataResponse.expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 35: ztcp.admin.v1.CircuitBreaker.state:type_name -> ztcp.admin.v1.CircuitBreakerState
+	55, // 36: ztcp.admin.v1.CircuitBreaker.state_since:type_name -> google.protobuf.Timestamp
+	49, // 37: ztcp.admin.v1.ListCircuitBreakersResponse.breakers:type_name -> ztcp.admin.v1.CircuitBreaker
+	55, // 38: ztcp.admin.v1.SharedDevice.last_seen_at:type_name -> google.protobuf.Timestamp
+	52, // 39: ztcp.admin.v1.ListSharedDevicesResponse.devices:type_name -> ztcp.admin.v1.SharedDevice
+	4,  // 40: ztcp.admin.v1.AdminService.GetSystemStats:input_type -> ztcp.admin.v1.GetSystemStatsRequest
+	7,  // 41: ztcp.admin.v1.AdminService.GetEffectiveConfig:input_type -> ztcp.admin.v1.GetEffectiveConfigRequest
+	10, // 42: ztcp.admin.v1.AdminService.GetMaintenanceMode:input_type -> ztcp.admin.v1.GetMaintenanceModeRequest
+	12, // 43: ztcp.admin.v1.AdminService.SetMaintenanceMode:input_type -> ztcp.admin.v1.SetMaintenanceModeRequest
+	15, // 44: ztcp.admin.v1.AdminService.ListQuotaPlans:input_type -> ztcp.admin.v1.ListQuotaPlansRequest
+	20, // 45: ztcp.admin.v1.AdminService.GetOrgQuota:input_type -> ztcp.admin.v1.GetOrgQuotaRequest
+	22, // 46: ztcp.admin.v1.AdminService.SetOrgQuota:input_type -> ztcp.admin.v1.SetOrgQuotaRequest
+	25, // 47: ztcp.admin.v1.AdminService.ListBillingPlans:input_type -> ztcp.admin.v1.ListBillingPlansRequest
+	27, // 48: ztcp.admin.v1.AdminService.SetOrgBillingPlan:input_type -> ztcp.admin.v1.SetOrgBillingPlanRequest
+	29, // 49: ztcp.admin.v1.AdminService.ExportUsage:input_type -> ztcp.admin.v1.ExportUsageRequest
+	32, // 50: ztcp.admin.v1.AdminService.GetLicenseStatus:input_type -> ztcp.admin.v1.GetLicenseStatusRequest
+	35, // 51: ztcp.admin.v1.AdminService.CreateBackup:input_type -> ztcp.admin.v1.CreateBackupRequest
+	38, // 52: ztcp.admin.v1.AdminService.MarkHoneytoken:input_type -> ztcp.admin.v1.MarkHoneytokenRequest
+	40, // 53: ztcp.admin.v1.AdminService.UnmarkHoneytoken:input_type -> ztcp.admin.v1.UnmarkHoneytokenRequest
+	42, // 54: ztcp.admin.v1.AdminService.ListHoneytokens:input_type -> ztcp.admin.v1.ListHoneytokensRequest
+	45, // 55: ztcp.admin.v1.AdminService.MergeUsers:input_type -> ztcp.admin.v1.MergeUsersRequest
+	47, // 56: ztcp.admin.v1.AdminService.ExportUserData:input_type -> ztcp.admin.v1.ExportUserDataRequest
+	50, // 57: ztcp.admin.v1.AdminService.ListCircuitBreakers:input_type -> ztcp.admin.v1.ListCircuitBreakersRequest
+	53, // 58: ztcp.admin.v1.AdminService.ListSharedDevices:input_type -> ztcp.admin.v1.ListSharedDevicesRequest
+	5,  // 59: ztcp.admin.v1.AdminService.GetSystemStats:output_type -> ztcp.admin.v1.GetSystemStatsResponse
+	8,  // 60: ztcp.admin.v1.AdminService.GetEffectiveConfig:output_type -> ztcp.admin.v1.GetEffectiveConfigResponse
+	11, // 61: ztcp.admin.v1.AdminService.GetMaintenanceMode:output_type -> ztcp.admin.v1.GetMaintenanceModeResponse
+	13, // 62: ztcp.admin.v1.AdminService.SetMaintenanceMode:output_type -> ztcp.admin.v1.SetMaintenanceModeResponse
+	16, // 63: ztcp.admin.v1.AdminService.ListQuotaPlans:output_type -> ztcp.admin.v1.ListQuotaPlansResponse
+	21, // 64: ztcp.admin.v1.AdminService.GetOrgQuota:output_type -> ztcp.admin.v1.GetOrgQuotaResponse
+	23, // 65: ztcp.admin.v1.AdminService.SetOrgQuota:output_type -> ztcp.admin.v1.SetOrgQuotaResponse
+	26, // 66: ztcp.admin.v1.AdminService.ListBillingPlans:output_type -> ztcp.admin.v1.ListBillingPlansResponse
+	28, // 67: ztcp.admin.v1.AdminService.SetOrgBillingPlan:output_type -> ztcp.admin.v1.SetOrgBillingPlanResponse
+	30, // 68: ztcp.admin.v1.AdminService.ExportUsage:output_type -> ztcp.admin.v1.ExportUsageResponse
+	33, // 69: ztcp.admin.v1.AdminService.GetLicenseStatus:output_type -> ztcp.admin.v1.GetLicenseStatusResponse
+	36, // 70: ztcp.admin.v1.AdminService.CreateBackup:output_type -> ztcp.admin.v1.CreateBackupResponse
+	39, // 71: ztcp.admin.v1.AdminService.MarkHoneytoken:output_type -> ztcp.admin.v1.MarkHoneytokenResponse
+	41, // 72: ztcp.admin.v1.AdminService.UnmarkHoneytoken:output_type -> ztcp.admin.v1.UnmarkHoneytokenResponse
+	43, // 73: ztcp.admin.v1.AdminService.ListHoneytokens:output_type -> ztcp.admin.v1.ListHoneytokensResponse
+	46, // 74: ztcp.admin.v1.AdminService.MergeUsers:output_type -> ztcp.admin.v1.MergeUsersResponse
+	48, // 75: ztcp.admin.v1.AdminService.ExportUserData:output_type -> ztcp.admin.v1.ExportUserDataResponse
+	51, // 76: ztcp.admin.v1.AdminService.ListCircuitBreakers:output_type -> ztcp.admin.v1.ListCircuitBreakersResponse
+	54, // 77: ztcp.admin.v1.AdminService.ListSharedDevices:output_type -> ztcp.admin.v1.ListSharedDevicesResponse
+	59, // [59:78] is the sub-list for method output_type
+	40, // [40:59] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_admin_admin_proto_init() }
@@ -236,18 +3731,21 @@ func file_admin_admin_proto_init() {
 	if File_admin_admin_proto != nil {
 		return
 	}
+	file_admin_admin_proto_msgTypes[14].OneofWrappers = []any{}
+	file_admin_admin_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_admin_proto_rawDesc), len(file_admin_admin_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      3,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_admin_admin_proto_goTypes,
 		DependencyIndexes: file_admin_admin_proto_depIdxs,
+		EnumInfos:         file_admin_admin_proto_enumTypes,
 		MessageInfos:      file_admin_admin_proto_msgTypes,
 	}.Build()
 	File_admin_admin_proto = out.File
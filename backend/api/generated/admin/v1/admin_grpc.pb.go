@@ -19,7 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AdminService_GetSystemStats_FullMethodName = "/ztcp.admin.v1.AdminService/GetSystemStats"
+	AdminService_GetSystemStats_FullMethodName      = "/ztcp.admin.v1.AdminService/GetSystemStats"
+	AdminService_GetEffectiveConfig_FullMethodName  = "/ztcp.admin.v1.AdminService/GetEffectiveConfig"
+	AdminService_GetMaintenanceMode_FullMethodName  = "/ztcp.admin.v1.AdminService/GetMaintenanceMode"
+	AdminService_SetMaintenanceMode_FullMethodName  = "/ztcp.admin.v1.AdminService/SetMaintenanceMode"
+	AdminService_ListQuotaPlans_FullMethodName      = "/ztcp.admin.v1.AdminService/ListQuotaPlans"
+	AdminService_GetOrgQuota_FullMethodName         = "/ztcp.admin.v1.AdminService/GetOrgQuota"
+	AdminService_SetOrgQuota_FullMethodName         = "/ztcp.admin.v1.AdminService/SetOrgQuota"
+	AdminService_ListBillingPlans_FullMethodName    = "/ztcp.admin.v1.AdminService/ListBillingPlans"
+	AdminService_SetOrgBillingPlan_FullMethodName   = "/ztcp.admin.v1.AdminService/SetOrgBillingPlan"
+	AdminService_ExportUsage_FullMethodName         = "/ztcp.admin.v1.AdminService/ExportUsage"
+	AdminService_GetLicenseStatus_FullMethodName    = "/ztcp.admin.v1.AdminService/GetLicenseStatus"
+	AdminService_CreateBackup_FullMethodName        = "/ztcp.admin.v1.AdminService/CreateBackup"
+	AdminService_MarkHoneytoken_FullMethodName      = "/ztcp.admin.v1.AdminService/MarkHoneytoken"
+	AdminService_UnmarkHoneytoken_FullMethodName    = "/ztcp.admin.v1.AdminService/UnmarkHoneytoken"
+	AdminService_ListHoneytokens_FullMethodName     = "/ztcp.admin.v1.AdminService/ListHoneytokens"
+	AdminService_MergeUsers_FullMethodName          = "/ztcp.admin.v1.AdminService/MergeUsers"
+	AdminService_ExportUserData_FullMethodName      = "/ztcp.admin.v1.AdminService/ExportUserData"
+	AdminService_ListCircuitBreakers_FullMethodName = "/ztcp.admin.v1.AdminService/ListCircuitBreakers"
+	AdminService_ListSharedDevices_FullMethodName   = "/ztcp.admin.v1.AdminService/ListSharedDevices"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -29,6 +47,44 @@ const (
 // AdminService handles system-level operations. Only for platform admins.
 type AdminServiceClient interface {
 	GetSystemStats(ctx context.Context, in *GetSystemStatsRequest, opts ...grpc.CallOption) (*GetSystemStatsResponse, error)
+	// GetEffectiveConfig returns the effective server configuration for debugging. Secrets are redacted.
+	GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfigRequest, opts ...grpc.CallOption) (*GetEffectiveConfigResponse, error)
+	// GetMaintenanceMode returns the current maintenance mode.
+	GetMaintenanceMode(ctx context.Context, in *GetMaintenanceModeRequest, opts ...grpc.CallOption) (*GetMaintenanceModeResponse, error)
+	// SetMaintenanceMode puts the control plane into read-only or maintenance mode, or back to normal operation.
+	SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*SetMaintenanceModeResponse, error)
+	// ListQuotaPlans returns the configured API quota plans.
+	ListQuotaPlans(ctx context.Context, in *ListQuotaPlansRequest, opts ...grpc.CallOption) (*ListQuotaPlansResponse, error)
+	// GetOrgQuota returns an org's API quotas in effect, its override and its usage on this instance.
+	GetOrgQuota(ctx context.Context, in *GetOrgQuotaRequest, opts ...grpc.CallOption) (*GetOrgQuotaResponse, error)
+	// SetOrgQuota assigns an org's plan and overrides its quotas, or clears its assignment.
+	SetOrgQuota(ctx context.Context, in *SetOrgQuotaRequest, opts ...grpc.CallOption) (*SetOrgQuotaResponse, error)
+	// ListBillingPlans returns the billing plans and the features each includes.
+	ListBillingPlans(ctx context.Context, in *ListBillingPlansRequest, opts ...grpc.CallOption) (*ListBillingPlansResponse, error)
+	// SetOrgBillingPlan assigns an org's billing plan, which decides the gated features it may use.
+	SetOrgBillingPlan(ctx context.Context, in *SetOrgBillingPlanRequest, opts ...grpc.CallOption) (*SetOrgBillingPlanResponse, error)
+	// ExportUsage returns every org's metered usage in a month, for billing systems.
+	ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (*ExportUsageResponse, error)
+	// GetLicenseStatus returns the self-hosted license, its expiry and seat usage.
+	GetLicenseStatus(ctx context.Context, in *GetLicenseStatusRequest, opts ...grpc.CallOption) (*GetLicenseStatusResponse, error)
+	// CreateBackup writes an encrypted logical backup of an org's data (not its sessions) to the server's BACKUP_DIR.
+	CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*CreateBackupResponse, error)
+	// MarkHoneytoken makes a user a decoy whose sign-in attempts are always rejected and alerted; its sessions are revoked.
+	MarkHoneytoken(ctx context.Context, in *MarkHoneytokenRequest, opts ...grpc.CallOption) (*MarkHoneytokenResponse, error)
+	// UnmarkHoneytoken turns a honeytoken back into an ordinary user.
+	UnmarkHoneytoken(ctx context.Context, in *UnmarkHoneytokenRequest, opts ...grpc.CallOption) (*UnmarkHoneytokenResponse, error)
+	// ListHoneytokens returns the honeytokens with their trigger counts.
+	ListHoneytokens(ctx context.Context, in *ListHoneytokensRequest, opts ...grpc.CallOption) (*ListHoneytokensResponse, error)
+	// MergeUsers moves a duplicate user's identities, memberships, devices, sessions and audit log entries to the primary
+	// user and disables the duplicate. It cannot be undone; a call without a confirmation token is a dry run.
+	MergeUsers(ctx context.Context, in *MergeUsersRequest, opts ...grpc.CallOption) (*MergeUsersResponse, error)
+	// ExportUserData requests an archive of a user's personal data (data-subject access request), generated
+	// asynchronously and downloaded with UserService.DownloadDataExport.
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	// ListCircuitBreakers returns the state of this instance's circuit breakers around external dependencies.
+	ListCircuitBreakers(ctx context.Context, in *ListCircuitBreakersRequest, opts ...grpc.CallOption) (*ListCircuitBreakersResponse, error)
+	// ListSharedDevices reports device fingerprints used by many users or orgs, whose device trust may be too generous.
+	ListSharedDevices(ctx context.Context, in *ListSharedDevicesRequest, opts ...grpc.CallOption) (*ListSharedDevicesResponse, error)
 }
 
 type adminServiceClient struct {
@@ -49,6 +105,186 @@ func (c *adminServiceClient) GetSystemStats(ctx context.Context, in *GetSystemSt
 	return out, nil
 }
 
+func (c *adminServiceClient) GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfigRequest, opts ...grpc.CallOption) (*GetEffectiveConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEffectiveConfigResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetEffectiveConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetMaintenanceMode(ctx context.Context, in *GetMaintenanceModeRequest, opts ...grpc.CallOption) (*GetMaintenanceModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMaintenanceModeResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetMaintenanceMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*SetMaintenanceModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMaintenanceModeResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetMaintenanceMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListQuotaPlans(ctx context.Context, in *ListQuotaPlansRequest, opts ...grpc.CallOption) (*ListQuotaPlansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuotaPlansResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListQuotaPlans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetOrgQuota(ctx context.Context, in *GetOrgQuotaRequest, opts ...grpc.CallOption) (*GetOrgQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrgQuotaResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetOrgQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetOrgQuota(ctx context.Context, in *SetOrgQuotaRequest, opts ...grpc.CallOption) (*SetOrgQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOrgQuotaResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetOrgQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListBillingPlans(ctx context.Context, in *ListBillingPlansRequest, opts ...grpc.CallOption) (*ListBillingPlansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBillingPlansResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListBillingPlans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetOrgBillingPlan(ctx context.Context, in *SetOrgBillingPlanRequest, opts ...grpc.CallOption) (*SetOrgBillingPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOrgBillingPlanResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetOrgBillingPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (*ExportUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUsageResponse)
+	err := c.cc.Invoke(ctx, AdminService_ExportUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLicenseStatus(ctx context.Context, in *GetLicenseStatusRequest, opts ...grpc.CallOption) (*GetLicenseStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLicenseStatusResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetLicenseStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*CreateBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBackupResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) MarkHoneytoken(ctx context.Context, in *MarkHoneytokenRequest, opts ...grpc.CallOption) (*MarkHoneytokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkHoneytokenResponse)
+	err := c.cc.Invoke(ctx, AdminService_MarkHoneytoken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnmarkHoneytoken(ctx context.Context, in *UnmarkHoneytokenRequest, opts ...grpc.CallOption) (*UnmarkHoneytokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnmarkHoneytokenResponse)
+	err := c.cc.Invoke(ctx, AdminService_UnmarkHoneytoken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListHoneytokens(ctx context.Context, in *ListHoneytokensRequest, opts ...grpc.CallOption) (*ListHoneytokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHoneytokensResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListHoneytokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) MergeUsers(ctx context.Context, in *MergeUsersRequest, opts ...grpc.CallOption) (*MergeUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeUsersResponse)
+	err := c.cc.Invoke(ctx, AdminService_MergeUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, AdminService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListCircuitBreakers(ctx context.Context, in *ListCircuitBreakersRequest, opts ...grpc.CallOption) (*ListCircuitBreakersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCircuitBreakersResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListCircuitBreakers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListSharedDevices(ctx context.Context, in *ListSharedDevicesRequest, opts ...grpc.CallOption) (*ListSharedDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharedDevicesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListSharedDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility.
@@ -56,6 +292,44 @@ func (c *adminServiceClient) GetSystemStats(ctx context.Context, in *GetSystemSt
 // AdminService handles system-level operations. Only for platform admins.
 type AdminServiceServer interface {
 	GetSystemStats(context.Context, *GetSystemStatsRequest) (*GetSystemStatsResponse, error)
+	// GetEffectiveConfig returns the effective server configuration for debugging. Secrets are redacted.
+	GetEffectiveConfig(context.Context, *GetEffectiveConfigRequest) (*GetEffectiveConfigResponse, error)
+	// GetMaintenanceMode returns the current maintenance mode.
+	GetMaintenanceMode(context.Context, *GetMaintenanceModeRequest) (*GetMaintenanceModeResponse, error)
+	// SetMaintenanceMode puts the control plane into read-only or maintenance mode, or back to normal operation.
+	SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*SetMaintenanceModeResponse, error)
+	// ListQuotaPlans returns the configured API quota plans.
+	ListQuotaPlans(context.Context, *ListQuotaPlansRequest) (*ListQuotaPlansResponse, error)
+	// GetOrgQuota returns an org's API quotas in effect, its override and its usage on this instance.
+	GetOrgQuota(context.Context, *GetOrgQuotaRequest) (*GetOrgQuotaResponse, error)
+	// SetOrgQuota assigns an org's plan and overrides its quotas, or clears its assignment.
+	SetOrgQuota(context.Context, *SetOrgQuotaRequest) (*SetOrgQuotaResponse, error)
+	// ListBillingPlans returns the billing plans and the features each includes.
+	ListBillingPlans(context.Context, *ListBillingPlansRequest) (*ListBillingPlansResponse, error)
+	// SetOrgBillingPlan assigns an org's billing plan, which decides the gated features it may use.
+	SetOrgBillingPlan(context.Context, *SetOrgBillingPlanRequest) (*SetOrgBillingPlanResponse, error)
+	// ExportUsage returns every org's metered usage in a month, for billing systems.
+	ExportUsage(context.Context, *ExportUsageRequest) (*ExportUsageResponse, error)
+	// GetLicenseStatus returns the self-hosted license, its expiry and seat usage.
+	GetLicenseStatus(context.Context, *GetLicenseStatusRequest) (*GetLicenseStatusResponse, error)
+	// CreateBackup writes an encrypted logical backup of an org's data (not its sessions) to the server's BACKUP_DIR.
+	CreateBackup(context.Context, *CreateBackupRequest) (*CreateBackupResponse, error)
+	// MarkHoneytoken makes a user a decoy whose sign-in attempts are always rejected and alerted; its sessions are revoked.
+	MarkHoneytoken(context.Context, *MarkHoneytokenRequest) (*MarkHoneytokenResponse, error)
+	// UnmarkHoneytoken turns a honeytoken back into an ordinary user.
+	UnmarkHoneytoken(context.Context, *UnmarkHoneytokenRequest) (*UnmarkHoneytokenResponse, error)
+	// ListHoneytokens returns the honeytokens with their trigger counts.
+	ListHoneytokens(context.Context, *ListHoneytokensRequest) (*ListHoneytokensResponse, error)
+	// MergeUsers moves a duplicate user's identities, memberships, devices, sessions and audit log entries to the primary
+	// user and disables the duplicate. It cannot be undone; a call without a confirmation token is a dry run.
+	MergeUsers(context.Context, *MergeUsersRequest) (*MergeUsersResponse, error)
+	// ExportUserData requests an archive of a user's personal data (data-subject access request), generated
+	// asynchronously and downloaded with UserService.DownloadDataExport.
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	// ListCircuitBreakers returns the state of this instance's circuit breakers around external dependencies.
+	ListCircuitBreakers(context.Context, *ListCircuitBreakersRequest) (*ListCircuitBreakersResponse, error)
+	// ListSharedDevices reports device fingerprints used by many users or orgs, whose device trust may be too generous.
+	ListSharedDevices(context.Context, *ListSharedDevicesRequest) (*ListSharedDevicesResponse, error)
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -69,6 +343,60 @@ type UnimplementedAdminServiceServer struct{}
 func (UnimplementedAdminServiceServer) GetSystemStats(context.Context, *GetSystemStatsRequest) (*GetSystemStatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetSystemStats not implemented")
 }
+func (UnimplementedAdminServiceServer) GetEffectiveConfig(context.Context, *GetEffectiveConfigRequest) (*GetEffectiveConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEffectiveConfig not implemented")
+}
+func (UnimplementedAdminServiceServer) GetMaintenanceMode(context.Context, *GetMaintenanceModeRequest) (*GetMaintenanceModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMaintenanceMode not implemented")
+}
+func (UnimplementedAdminServiceServer) SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*SetMaintenanceModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMaintenanceMode not implemented")
+}
+func (UnimplementedAdminServiceServer) ListQuotaPlans(context.Context, *ListQuotaPlansRequest) (*ListQuotaPlansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListQuotaPlans not implemented")
+}
+func (UnimplementedAdminServiceServer) GetOrgQuota(context.Context, *GetOrgQuotaRequest) (*GetOrgQuotaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrgQuota not implemented")
+}
+func (UnimplementedAdminServiceServer) SetOrgQuota(context.Context, *SetOrgQuotaRequest) (*SetOrgQuotaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOrgQuota not implemented")
+}
+func (UnimplementedAdminServiceServer) ListBillingPlans(context.Context, *ListBillingPlansRequest) (*ListBillingPlansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBillingPlans not implemented")
+}
+func (UnimplementedAdminServiceServer) SetOrgBillingPlan(context.Context, *SetOrgBillingPlanRequest) (*SetOrgBillingPlanResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOrgBillingPlan not implemented")
+}
+func (UnimplementedAdminServiceServer) ExportUsage(context.Context, *ExportUsageRequest) (*ExportUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUsage not implemented")
+}
+func (UnimplementedAdminServiceServer) GetLicenseStatus(context.Context, *GetLicenseStatusRequest) (*GetLicenseStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLicenseStatus not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateBackup(context.Context, *CreateBackupRequest) (*CreateBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateBackup not implemented")
+}
+func (UnimplementedAdminServiceServer) MarkHoneytoken(context.Context, *MarkHoneytokenRequest) (*MarkHoneytokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkHoneytoken not implemented")
+}
+func (UnimplementedAdminServiceServer) UnmarkHoneytoken(context.Context, *UnmarkHoneytokenRequest) (*UnmarkHoneytokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnmarkHoneytoken not implemented")
+}
+func (UnimplementedAdminServiceServer) ListHoneytokens(context.Context, *ListHoneytokensRequest) (*ListHoneytokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHoneytokens not implemented")
+}
+func (UnimplementedAdminServiceServer) MergeUsers(context.Context, *MergeUsersRequest) (*MergeUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeUsers not implemented")
+}
+func (UnimplementedAdminServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedAdminServiceServer) ListCircuitBreakers(context.Context, *ListCircuitBreakersRequest) (*ListCircuitBreakersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCircuitBreakers not implemented")
+}
+func (UnimplementedAdminServiceServer) ListSharedDevices(context.Context, *ListSharedDevicesRequest) (*ListSharedDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSharedDevices not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
 
@@ -108,6 +436,330 @@ func _AdminService_GetSystemStats_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_GetEffectiveConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectiveConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetEffectiveConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetEffectiveConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetEffectiveConfig(ctx, req.(*GetEffectiveConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetMaintenanceMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaintenanceModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetMaintenanceMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetMaintenanceMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetMaintenanceMode(ctx, req.(*GetMaintenanceModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetMaintenanceMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetMaintenanceMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetMaintenanceMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetMaintenanceMode(ctx, req.(*SetMaintenanceModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListQuotaPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuotaPlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListQuotaPlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListQuotaPlans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListQuotaPlans(ctx, req.(*ListQuotaPlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetOrgQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrgQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetOrgQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetOrgQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetOrgQuota(ctx, req.(*GetOrgQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetOrgQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrgQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetOrgQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetOrgQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetOrgQuota(ctx, req.(*SetOrgQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListBillingPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBillingPlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListBillingPlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListBillingPlans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListBillingPlans(ctx, req.(*ListBillingPlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetOrgBillingPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrgBillingPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetOrgBillingPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetOrgBillingPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetOrgBillingPlan(ctx, req.(*SetOrgBillingPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExportUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ExportUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ExportUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ExportUsage(ctx, req.(*ExportUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLicenseStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLicenseStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLicenseStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetLicenseStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLicenseStatus(ctx, req.(*GetLicenseStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateBackup(ctx, req.(*CreateBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_MarkHoneytoken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkHoneytokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).MarkHoneytoken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_MarkHoneytoken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).MarkHoneytoken(ctx, req.(*MarkHoneytokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnmarkHoneytoken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmarkHoneytokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnmarkHoneytoken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UnmarkHoneytoken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnmarkHoneytoken(ctx, req.(*UnmarkHoneytokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListHoneytokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoneytokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListHoneytokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListHoneytokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListHoneytokens(ctx, req.(*ListHoneytokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_MergeUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).MergeUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_MergeUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).MergeUsers(ctx, req.(*MergeUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListCircuitBreakers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCircuitBreakersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListCircuitBreakers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListCircuitBreakers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListCircuitBreakers(ctx, req.(*ListCircuitBreakersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListSharedDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListSharedDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListSharedDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListSharedDevices(ctx, req.(*ListSharedDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -119,6 +771,78 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSystemStats",
 			Handler:    _AdminService_GetSystemStats_Handler,
 		},
+		{
+			MethodName: "GetEffectiveConfig",
+			Handler:    _AdminService_GetEffectiveConfig_Handler,
+		},
+		{
+			MethodName: "GetMaintenanceMode",
+			Handler:    _AdminService_GetMaintenanceMode_Handler,
+		},
+		{
+			MethodName: "SetMaintenanceMode",
+			Handler:    _AdminService_SetMaintenanceMode_Handler,
+		},
+		{
+			MethodName: "ListQuotaPlans",
+			Handler:    _AdminService_ListQuotaPlans_Handler,
+		},
+		{
+			MethodName: "GetOrgQuota",
+			Handler:    _AdminService_GetOrgQuota_Handler,
+		},
+		{
+			MethodName: "SetOrgQuota",
+			Handler:    _AdminService_SetOrgQuota_Handler,
+		},
+		{
+			MethodName: "ListBillingPlans",
+			Handler:    _AdminService_ListBillingPlans_Handler,
+		},
+		{
+			MethodName: "SetOrgBillingPlan",
+			Handler:    _AdminService_SetOrgBillingPlan_Handler,
+		},
+		{
+			MethodName: "ExportUsage",
+			Handler:    _AdminService_ExportUsage_Handler,
+		},
+		{
+			MethodName: "GetLicenseStatus",
+			Handler:    _AdminService_GetLicenseStatus_Handler,
+		},
+		{
+			MethodName: "CreateBackup",
+			Handler:    _AdminService_CreateBackup_Handler,
+		},
+		{
+			MethodName: "MarkHoneytoken",
+			Handler:    _AdminService_MarkHoneytoken_Handler,
+		},
+		{
+			MethodName: "UnmarkHoneytoken",
+			Handler:    _AdminService_UnmarkHoneytoken_Handler,
+		},
+		{
+			MethodName: "ListHoneytokens",
+			Handler:    _AdminService_ListHoneytokens_Handler,
+		},
+		{
+			MethodName: "MergeUsers",
+			Handler:    _AdminService_MergeUsers_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _AdminService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "ListCircuitBreakers",
+			Handler:    _AdminService_ListCircuitBreakers_Handler,
+		},
+		{
+			MethodName: "ListSharedDevices",
+			Handler:    _AdminService_ListSharedDevices_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "admin/admin.proto",
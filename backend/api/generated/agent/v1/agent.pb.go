@@ -0,0 +1,670 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: agent/agent.proto
+
+package agentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Agent is a browser or endpoint agent registered on a device. A device has at most one agent.
+type Agent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId           string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                               // user of the session that last registered the agent
+	DeviceId        string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                                                         // device of that session
+	Platform        string                 `protobuf:"bytes,5,opt,name=platform,proto3" json:"platform,omitempty"`                                                                         // e.g. chrome, edge, windows, macos
+	Version         string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`                                                                           // agent version, as last reported
+	Posture         map[string]string      `protobuf:"bytes,7,rep,name=posture,proto3" json:"posture,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // posture summary, as last reported (e.g. disk_encryption: "on")
+	PolicyVersion   int64                  `protobuf:"varint,8,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`                                         // org policy config version the agent last reported having in effect; 0 if unknown
+	RegisteredAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	LastHeartbeatAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_heartbeat_at,json=lastHeartbeatAt,proto3" json:"last_heartbeat_at,omitempty"`
+	// Set when the agent stopped reporting for long enough that the device's trust was cleared (AGENT_TRUST_DEGRADE_DAYS).
+	// Cleared by the next heartbeat; the device is trusted again only after the next sign-in with MFA.
+	TrustDegradedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=trust_degraded_at,json=trustDegradedAt,proto3" json:"trust_degraded_at,omitempty"`
+	Stale           bool                   `protobuf:"varint,12,opt,name=stale,proto3" json:"stale,omitempty"`                                         // no heartbeat within AGENT_STALE_AFTER
+	PolicyOutdated  bool                   `protobuf:"varint,13,opt,name=policy_outdated,json=policyOutdated,proto3" json:"policy_outdated,omitempty"` // policy_version is not the org's current config version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Agent) Reset() {
+	*x = Agent{}
+	mi := &file_agent_agent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Agent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Agent) ProtoMessage() {}
+
+func (x *Agent) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Agent.ProtoReflect.Descriptor instead.
+func (*Agent) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Agent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Agent) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Agent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Agent) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Agent) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Agent) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Agent) GetPosture() map[string]string {
+	if x != nil {
+		return x.Posture
+	}
+	return nil
+}
+
+func (x *Agent) GetPolicyVersion() int64 {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return 0
+}
+
+func (x *Agent) GetRegisteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredAt
+	}
+	return nil
+}
+
+func (x *Agent) GetLastHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeatAt
+	}
+	return nil
+}
+
+func (x *Agent) GetTrustDegradedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TrustDegradedAt
+	}
+	return nil
+}
+
+func (x *Agent) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *Agent) GetPolicyOutdated() bool {
+	if x != nil {
+		return x.PolicyOutdated
+	}
+	return false
+}
+
+// RegisterAgentRequest registers the agent of the caller's device. org, user and device are taken from the caller's
+// access token. Registering again from the same device updates the existing agent and keeps its ID.
+type RegisterAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`                                                                         // required, max 64 characters
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                                                           // required, max 64 characters
+	Posture       map[string]string      `protobuf:"bytes,3,rep,name=posture,proto3" json:"posture,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // optional; at most 32 entries, keys max 64 and values max 256 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterAgentRequest) Reset() {
+	*x = RegisterAgentRequest{}
+	mi := &file_agent_agent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentRequest) ProtoMessage() {}
+
+func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterAgentRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *RegisterAgentRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RegisterAgentRequest) GetPosture() map[string]string {
+	if x != nil {
+		return x.Posture
+	}
+	return nil
+}
+
+type RegisterAgentResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Agent                    *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	HeartbeatIntervalSeconds int64                  `protobuf:"varint,2,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"` // how often the agent should call Heartbeat
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *RegisterAgentResponse) Reset() {
+	*x = RegisterAgentResponse{}
+	mi := &file_agent_agent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentResponse) ProtoMessage() {}
+
+func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterAgentResponse) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *RegisterAgentResponse) GetHeartbeatIntervalSeconds() int64 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                                            // required; from RegisterAgent
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                                                           // optional; empty keeps the last reported version
+	Posture       map[string]string      `protobuf:"bytes,3,rep,name=posture,proto3" json:"posture,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // optional; replaces the last reported posture when not empty
+	PolicyVersion int64                  `protobuf:"varint,4,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`                                         // org policy config version in effect on the agent; 0 if unknown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_agent_agent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HeartbeatRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetPosture() map[string]string {
+	if x != nil {
+		return x.Posture
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetPolicyVersion() int64 {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return 0
+}
+
+type HeartbeatResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	PolicyVersion            int64                  `protobuf:"varint,1,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`    // the org's current policy config version; 0 when the org has no stored config
+	PolicyOutdated           bool                   `protobuf:"varint,2,opt,name=policy_outdated,json=policyOutdated,proto3" json:"policy_outdated,omitempty"` // the agent should fetch the policy config again
+	HeartbeatIntervalSeconds int64                  `protobuf:"varint,3,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_agent_agent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HeartbeatResponse) GetPolicyVersion() int64 {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return 0
+}
+
+func (x *HeartbeatResponse) GetPolicyOutdated() bool {
+	if x != nil {
+		return x.PolicyOutdated
+	}
+	return false
+}
+
+func (x *HeartbeatResponse) GetHeartbeatIntervalSeconds() int64 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+// ListAgentsRequest lists the org's agents, longest silent first. Filters are optional.
+type ListAgentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StaleOnly     bool                   `protobuf:"varint,3,opt,name=stale_only,json=staleOnly,proto3" json:"stale_only,omitempty"` // only agents without a heartbeat within AGENT_STALE_AFTER
+	Pagination    *v1.Pagination         `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_agent_agent_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAgentsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetStaleOnly() bool {
+	if x != nil {
+		return x.StaleOnly
+	}
+	return false
+}
+
+func (x *ListAgentsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListAgentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_agent_agent_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_agent_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_agent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*Agent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_agent_agent_proto protoreflect.FileDescriptor
+
+const file_agent_agent_proto_rawDesc = "" +
+	"\n" +
+	"\x11agent/agent.proto\x12\rztcp.agent.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x04\n" +
+	"\x05Agent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x12\x1a\n" +
+	"\bplatform\x18\x05 \x01(\tR\bplatform\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversion\x12;\n" +
+	"\aposture\x18\a \x03(\v2!.ztcp.agent.v1.Agent.PostureEntryR\aposture\x12%\n" +
+	"\x0epolicy_version\x18\b \x01(\x03R\rpolicyVersion\x12?\n" +
+	"\rregistered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x12F\n" +
+	"\x11last_heartbeat_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0flastHeartbeatAt\x12F\n" +
+	"\x11trust_degraded_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0ftrustDegradedAt\x12\x14\n" +
+	"\x05stale\x18\f \x01(\bR\x05stale\x12'\n" +
+	"\x0fpolicy_outdated\x18\r \x01(\bR\x0epolicyOutdated\x1a:\n" +
+	"\fPostureEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd4\x01\n" +
+	"\x14RegisterAgentRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12J\n" +
+	"\aposture\x18\x03 \x03(\v20.ztcp.agent.v1.RegisterAgentRequest.PostureEntryR\aposture\x1a:\n" +
+	"\fPostureEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81\x01\n" +
+	"\x15RegisterAgentResponse\x12*\n" +
+	"\x05agent\x18\x01 \x01(\v2\x14.ztcp.agent.v1.AgentR\x05agent\x12<\n" +
+	"\x1aheartbeat_interval_seconds\x18\x02 \x01(\x03R\x18heartbeatIntervalSeconds\"\xf2\x01\n" +
+	"\x10HeartbeatRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12F\n" +
+	"\aposture\x18\x03 \x03(\v2,.ztcp.agent.v1.HeartbeatRequest.PostureEntryR\aposture\x12%\n" +
+	"\x0epolicy_version\x18\x04 \x01(\x03R\rpolicyVersion\x1a:\n" +
+	"\fPostureEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x01\n" +
+	"\x11HeartbeatResponse\x12%\n" +
+	"\x0epolicy_version\x18\x01 \x01(\x03R\rpolicyVersion\x12'\n" +
+	"\x0fpolicy_outdated\x18\x02 \x01(\bR\x0epolicyOutdated\x12<\n" +
+	"\x1aheartbeat_interval_seconds\x18\x03 \x01(\x03R\x18heartbeatIntervalSeconds\"\x9e\x01\n" +
+	"\x11ListAgentsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"stale_only\x18\x03 \x01(\bR\tstaleOnly\x12:\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\x84\x01\n" +
+	"\x12ListAgentsResponse\x12,\n" +
+	"\x06agents\x18\x01 \x03(\v2\x14.ztcp.agent.v1.AgentR\x06agents\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination2\x92\x02\n" +
+	"\fAgentService\x12Z\n" +
+	"\rRegisterAgent\x12#.ztcp.agent.v1.RegisterAgentRequest\x1a$.ztcp.agent.v1.RegisterAgentResponse\x12N\n" +
+	"\tHeartbeat\x12\x1f.ztcp.agent.v1.HeartbeatRequest\x1a .ztcp.agent.v1.HeartbeatResponse\x12V\n" +
+	"\n" +
+	"ListAgents\x12 .ztcp.agent.v1.ListAgentsRequest\x1a!.ztcp.agent.v1.ListAgentsResponse\"\x03\x90\x02\x01BAZ?zero-trust-control-plane/backend/api/generated/agent/v1;agentv1b\x06proto3"
+
+var (
+	file_agent_agent_proto_rawDescOnce sync.Once
+	file_agent_agent_proto_rawDescData []byte
+)
+
+func file_agent_agent_proto_rawDescGZIP() []byte {
+	file_agent_agent_proto_rawDescOnce.Do(func() {
+		file_agent_agent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_agent_agent_proto_rawDesc), len(file_agent_agent_proto_rawDesc)))
+	})
+	return file_agent_agent_proto_rawDescData
+}
+
+var file_agent_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_agent_agent_proto_goTypes = []any{
+	(*Agent)(nil),                 // 0: ztcp.agent.v1.Agent
+	(*RegisterAgentRequest)(nil),  // 1: ztcp.agent.v1.RegisterAgentRequest
+	(*RegisterAgentResponse)(nil), // 2: ztcp.agent.v1.RegisterAgentResponse
+	(*HeartbeatRequest)(nil),      // 3: ztcp.agent.v1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),     // 4: ztcp.agent.v1.HeartbeatResponse
+	(*ListAgentsRequest)(nil),     // 5: ztcp.agent.v1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),    // 6: ztcp.agent.v1.ListAgentsResponse
+	nil,                           // 7: ztcp.agent.v1.Agent.PostureEntry
+	nil,                           // 8: ztcp.agent.v1.RegisterAgentRequest.PostureEntry
+	nil,                           // 9: ztcp.agent.v1.HeartbeatRequest.PostureEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),         // 11: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),   // 12: ztcp.common.v1.PaginationResult
+}
+var file_agent_agent_proto_depIdxs = []int32{
+	7,  // 0: ztcp.agent.v1.Agent.posture:type_name -> ztcp.agent.v1.Agent.PostureEntry
+	10, // 1: ztcp.agent.v1.Agent.registered_at:type_name -> google.protobuf.Timestamp
+	10, // 2: ztcp.agent.v1.Agent.last_heartbeat_at:type_name -> google.protobuf.Timestamp
+	10, // 3: ztcp.agent.v1.Agent.trust_degraded_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: ztcp.agent.v1.RegisterAgentRequest.posture:type_name -> ztcp.agent.v1.RegisterAgentRequest.PostureEntry
+	0,  // 5: ztcp.agent.v1.RegisterAgentResponse.agent:type_name -> ztcp.agent.v1.Agent
+	9,  // 6: ztcp.agent.v1.HeartbeatRequest.posture:type_name -> ztcp.agent.v1.HeartbeatRequest.PostureEntry
+	11, // 7: ztcp.agent.v1.ListAgentsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	0,  // 8: ztcp.agent.v1.ListAgentsResponse.agents:type_name -> ztcp.agent.v1.Agent
+	12, // 9: ztcp.agent.v1.ListAgentsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	1,  // 10: ztcp.agent.v1.AgentService.RegisterAgent:input_type -> ztcp.agent.v1.RegisterAgentRequest
+	3,  // 11: ztcp.agent.v1.AgentService.Heartbeat:input_type -> ztcp.agent.v1.HeartbeatRequest
+	5,  // 12: ztcp.agent.v1.AgentService.ListAgents:input_type -> ztcp.agent.v1.ListAgentsRequest
+	2,  // 13: ztcp.agent.v1.AgentService.RegisterAgent:output_type -> ztcp.agent.v1.RegisterAgentResponse
+	4,  // 14: ztcp.agent.v1.AgentService.Heartbeat:output_type -> ztcp.agent.v1.HeartbeatResponse
+	6,  // 15: ztcp.agent.v1.AgentService.ListAgents:output_type -> ztcp.agent.v1.ListAgentsResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_agent_agent_proto_init() }
+func file_agent_agent_proto_init() {
+	if File_agent_agent_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agent_agent_proto_rawDesc), len(file_agent_agent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_agent_agent_proto_goTypes,
+		DependencyIndexes: file_agent_agent_proto_depIdxs,
+		MessageInfos:      file_agent_agent_proto_msgTypes,
+	}.Build()
+	File_agent_agent_proto = out.File
+	file_agent_agent_proto_goTypes = nil
+	file_agent_agent_proto_depIdxs = nil
+}
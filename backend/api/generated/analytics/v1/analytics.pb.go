@@ -0,0 +1,1638 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: analytics/analytics.proto
+
+package analyticsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LoginStats holds login counters for one day (or a range total) of the caller's org.
+type LoginStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Date             string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`                                            // YYYY-MM-DD; empty for range totals
+	LoginSuccesses   int64                  `protobuf:"varint,2,opt,name=login_successes,json=loginSuccesses,proto3" json:"login_successes,omitempty"` // password checks that passed (with or without MFA)
+	LoginFailures    int64                  `protobuf:"varint,3,opt,name=login_failures,json=loginFailures,proto3" json:"login_failures,omitempty"`
+	MfaChallenges    int64                  `protobuf:"varint,4,opt,name=mfa_challenges,json=mfaChallenges,proto3" json:"mfa_challenges,omitempty"`             // OTP challenges sent
+	SessionsCreated  int64                  `protobuf:"varint,5,opt,name=sessions_created,json=sessionsCreated,proto3" json:"sessions_created,omitempty"`       // completed sign-ins
+	FailureRate      float64                `protobuf:"fixed64,6,opt,name=failure_rate,json=failureRate,proto3" json:"failure_rate,omitempty"`                  // login_failures / (login_successes + login_failures)
+	MfaChallengeRate float64                `protobuf:"fixed64,7,opt,name=mfa_challenge_rate,json=mfaChallengeRate,proto3" json:"mfa_challenge_rate,omitempty"` // mfa_challenges / login_successes
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LoginStats) Reset() {
+	*x = LoginStats{}
+	mi := &file_analytics_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginStats) ProtoMessage() {}
+
+func (x *LoginStats) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginStats.ProtoReflect.Descriptor instead.
+func (*LoginStats) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginStats) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *LoginStats) GetLoginSuccesses() int64 {
+	if x != nil {
+		return x.LoginSuccesses
+	}
+	return 0
+}
+
+func (x *LoginStats) GetLoginFailures() int64 {
+	if x != nil {
+		return x.LoginFailures
+	}
+	return 0
+}
+
+func (x *LoginStats) GetMfaChallenges() int64 {
+	if x != nil {
+		return x.MfaChallenges
+	}
+	return 0
+}
+
+func (x *LoginStats) GetSessionsCreated() int64 {
+	if x != nil {
+		return x.SessionsCreated
+	}
+	return 0
+}
+
+func (x *LoginStats) GetFailureRate() float64 {
+	if x != nil {
+		return x.FailureRate
+	}
+	return 0
+}
+
+func (x *LoginStats) GetMfaChallengeRate() float64 {
+	if x != nil {
+		return x.MfaChallengeRate
+	}
+	return 0
+}
+
+type GetLoginStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      string                 `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string                 `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginStatsRequest) Reset() {
+	*x = GetLoginStatsRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginStatsRequest) ProtoMessage() {}
+
+func (x *GetLoginStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLoginStatsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLoginStatsRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *GetLoginStatsRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+type GetLoginStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*LoginStats          `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"` // only days with activity, oldest first
+	Totals        *LoginStats            `protobuf:"bytes,2,opt,name=totals,proto3" json:"totals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginStatsResponse) Reset() {
+	*x = GetLoginStatsResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginStatsResponse) ProtoMessage() {}
+
+func (x *GetLoginStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetLoginStatsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLoginStatsResponse) GetDays() []*LoginStats {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetLoginStatsResponse) GetTotals() *LoginStats {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+type DeviceSessionCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sessions      int64                  `protobuf:"varint,3,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceSessionCount) Reset() {
+	*x = DeviceSessionCount{}
+	mi := &file_analytics_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceSessionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceSessionCount) ProtoMessage() {}
+
+func (x *DeviceSessionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceSessionCount.ProtoReflect.Descriptor instead.
+func (*DeviceSessionCount) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeviceSessionCount) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceSessionCount) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeviceSessionCount) GetSessions() int64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+type ListTopDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      string                 `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string                 `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // default 10, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopDevicesRequest) Reset() {
+	*x = ListTopDevicesRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopDevicesRequest) ProtoMessage() {}
+
+func (x *ListTopDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListTopDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTopDevicesRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *ListTopDevicesRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+func (x *ListTopDevicesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTopDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*DeviceSessionCount  `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopDevicesResponse) Reset() {
+	*x = ListTopDevicesResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopDevicesResponse) ProtoMessage() {}
+
+func (x *ListTopDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListTopDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTopDevicesResponse) GetDevices() []*DeviceSessionCount {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type CountrySessionCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"` // ISO country code, or "unknown"
+	Sessions      int64                  `protobuf:"varint,2,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountrySessionCount) Reset() {
+	*x = CountrySessionCount{}
+	mi := &file_analytics_analytics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountrySessionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountrySessionCount) ProtoMessage() {}
+
+func (x *CountrySessionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountrySessionCount.ProtoReflect.Descriptor instead.
+func (*CountrySessionCount) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CountrySessionCount) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CountrySessionCount) GetSessions() int64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+type ListSessionsByCountryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      string                 `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string                 `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsByCountryRequest) Reset() {
+	*x = ListSessionsByCountryRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsByCountryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsByCountryRequest) ProtoMessage() {}
+
+func (x *ListSessionsByCountryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsByCountryRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsByCountryRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSessionsByCountryRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *ListSessionsByCountryRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+type ListSessionsByCountryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Countries     []*CountrySessionCount `protobuf:"bytes,1,rep,name=countries,proto3" json:"countries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsByCountryResponse) Reset() {
+	*x = ListSessionsByCountryResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsByCountryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsByCountryResponse) ProtoMessage() {}
+
+func (x *ListSessionsByCountryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsByCountryResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsByCountryResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSessionsByCountryResponse) GetCountries() []*CountrySessionCount {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+// PolicyViolationCount is the number of blocked actions of one kind reported by agents over a date range.
+type PolicyViolationCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // navigate, download, upload, copy_paste
+	Violations    int64                  `protobuf:"varint,2,opt,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyViolationCount) Reset() {
+	*x = PolicyViolationCount{}
+	mi := &file_analytics_analytics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyViolationCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyViolationCount) ProtoMessage() {}
+
+func (x *PolicyViolationCount) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyViolationCount.ProtoReflect.Descriptor instead.
+func (*PolicyViolationCount) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PolicyViolationCount) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *PolicyViolationCount) GetViolations() int64 {
+	if x != nil {
+		return x.Violations
+	}
+	return 0
+}
+
+type GetPolicyViolationStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      string                 `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string                 `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPolicyViolationStatsRequest) Reset() {
+	*x = GetPolicyViolationStatsRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPolicyViolationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyViolationStatsRequest) ProtoMessage() {}
+
+func (x *GetPolicyViolationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyViolationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetPolicyViolationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPolicyViolationStatsRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *GetPolicyViolationStatsRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+type GetPolicyViolationStatsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Actions       []*PolicyViolationCount `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"` // most first
+	Total         int64                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPolicyViolationStatsResponse) Reset() {
+	*x = GetPolicyViolationStatsResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPolicyViolationStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyViolationStatsResponse) ProtoMessage() {}
+
+func (x *GetPolicyViolationStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyViolationStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetPolicyViolationStatsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPolicyViolationStatsResponse) GetActions() []*PolicyViolationCount {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *GetPolicyViolationStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// BlockedDomainCount is the number of URLs on one domain denied over a date range.
+type BlockedDomainCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Blocks        int64                  `protobuf:"varint,2,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedDomainCount) Reset() {
+	*x = BlockedDomainCount{}
+	mi := &file_analytics_analytics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedDomainCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedDomainCount) ProtoMessage() {}
+
+func (x *BlockedDomainCount) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedDomainCount.ProtoReflect.Descriptor instead.
+func (*BlockedDomainCount) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BlockedDomainCount) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *BlockedDomainCount) GetBlocks() int64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+type ListTopBlockedDomainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      string                 `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string                 `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // default 10, max 100
+	Csv           bool                   `protobuf:"varint,4,opt,name=csv,proto3" json:"csv,omitempty"`     // also return the result as CSV
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopBlockedDomainsRequest) Reset() {
+	*x = ListTopBlockedDomainsRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopBlockedDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopBlockedDomainsRequest) ProtoMessage() {}
+
+func (x *ListTopBlockedDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopBlockedDomainsRequest.ProtoReflect.Descriptor instead.
+func (*ListTopBlockedDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListTopBlockedDomainsRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *ListTopBlockedDomainsRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+func (x *ListTopBlockedDomainsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTopBlockedDomainsRequest) GetCsv() bool {
+	if x != nil {
+		return x.Csv
+	}
+	return false
+}
+
+type ListTopBlockedDomainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domains       []*BlockedDomainCount  `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"` // most first
+	Csv           string                 `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`         // columns domain, blocks
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopBlockedDomainsResponse) Reset() {
+	*x = ListTopBlockedDomainsResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopBlockedDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopBlockedDomainsResponse) ProtoMessage() {}
+
+func (x *ListTopBlockedDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopBlockedDomainsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopBlockedDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTopBlockedDomainsResponse) GetDomains() []*BlockedDomainCount {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *ListTopBlockedDomainsResponse) GetCsv() string {
+	if x != nil {
+		return x.Csv
+	}
+	return ""
+}
+
+// UserViolationCount is the number of policy violations reported for one user over a date range.
+type UserViolationCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Violations    int64                  `protobuf:"varint,2,opt,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserViolationCount) Reset() {
+	*x = UserViolationCount{}
+	mi := &file_analytics_analytics_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserViolationCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserViolationCount) ProtoMessage() {}
+
+func (x *UserViolationCount) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserViolationCount.ProtoReflect.Descriptor instead.
+func (*UserViolationCount) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UserViolationCount) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserViolationCount) GetViolations() int64 {
+	if x != nil {
+		return x.Violations
+	}
+	return 0
+}
+
+type ListTopViolatorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      string                 `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string                 `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // default 10, max 100
+	Csv           bool                   `protobuf:"varint,4,opt,name=csv,proto3" json:"csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopViolatorsRequest) Reset() {
+	*x = ListTopViolatorsRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopViolatorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopViolatorsRequest) ProtoMessage() {}
+
+func (x *ListTopViolatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopViolatorsRequest.ProtoReflect.Descriptor instead.
+func (*ListTopViolatorsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTopViolatorsRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *ListTopViolatorsRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+func (x *ListTopViolatorsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTopViolatorsRequest) GetCsv() bool {
+	if x != nil {
+		return x.Csv
+	}
+	return false
+}
+
+type ListTopViolatorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserViolationCount  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // most first
+	Csv           string                 `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`     // columns user_id, violations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopViolatorsResponse) Reset() {
+	*x = ListTopViolatorsResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopViolatorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopViolatorsResponse) ProtoMessage() {}
+
+func (x *ListTopViolatorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopViolatorsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopViolatorsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListTopViolatorsResponse) GetUsers() []*UserViolationCount {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListTopViolatorsResponse) GetCsv() string {
+	if x != nil {
+		return x.Csv
+	}
+	return ""
+}
+
+// PolicyTrendDay holds the org's URL denials and reported policy violations for one day (or a range total).
+type PolicyTrendDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD; empty for range totals
+	UrlBlocks     int64                  `protobuf:"varint,2,opt,name=url_blocks,json=urlBlocks,proto3" json:"url_blocks,omitempty"`
+	Violations    int64                  `protobuf:"varint,3,opt,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyTrendDay) Reset() {
+	*x = PolicyTrendDay{}
+	mi := &file_analytics_analytics_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyTrendDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyTrendDay) ProtoMessage() {}
+
+func (x *PolicyTrendDay) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyTrendDay.ProtoReflect.Descriptor instead.
+func (*PolicyTrendDay) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PolicyTrendDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *PolicyTrendDay) GetUrlBlocks() int64 {
+	if x != nil {
+		return x.UrlBlocks
+	}
+	return 0
+}
+
+func (x *PolicyTrendDay) GetViolations() int64 {
+	if x != nil {
+		return x.Violations
+	}
+	return 0
+}
+
+type GetPolicyTrendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDate      string                 `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string                 `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	Csv           bool                   `protobuf:"varint,3,opt,name=csv,proto3" json:"csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPolicyTrendRequest) Reset() {
+	*x = GetPolicyTrendRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPolicyTrendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyTrendRequest) ProtoMessage() {}
+
+func (x *GetPolicyTrendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyTrendRequest.ProtoReflect.Descriptor instead.
+func (*GetPolicyTrendRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPolicyTrendRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *GetPolicyTrendRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+func (x *GetPolicyTrendRequest) GetCsv() bool {
+	if x != nil {
+		return x.Csv
+	}
+	return false
+}
+
+type GetPolicyTrendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*PolicyTrendDay      `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"` // only days with activity, oldest first
+	Totals        *PolicyTrendDay        `protobuf:"bytes,2,opt,name=totals,proto3" json:"totals,omitempty"`
+	Csv           string                 `protobuf:"bytes,3,opt,name=csv,proto3" json:"csv,omitempty"` // columns date, url_blocks, violations; one row per day
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPolicyTrendResponse) Reset() {
+	*x = GetPolicyTrendResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPolicyTrendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyTrendResponse) ProtoMessage() {}
+
+func (x *GetPolicyTrendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyTrendResponse.ProtoReflect.Descriptor instead.
+func (*GetPolicyTrendResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPolicyTrendResponse) GetDays() []*PolicyTrendDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetPolicyTrendResponse) GetTotals() *PolicyTrendDay {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *GetPolicyTrendResponse) GetCsv() string {
+	if x != nil {
+		return x.Csv
+	}
+	return ""
+}
+
+// OrgUsage is one org's metered usage in one UTC calendar month (see GetUsage).
+type OrgUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Month         string                 `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"`                                       // YYYY-MM
+	ActiveUsers   int64                  `protobuf:"varint,3,opt,name=active_users,json=activeUsers,proto3" json:"active_users,omitempty"`       // distinct users that signed in or made an authenticated call
+	Logins        int64                  `protobuf:"varint,4,opt,name=logins,proto3" json:"logins,omitempty"`                                    // completed sign-ins
+	MfaChallenges int64                  `protobuf:"varint,5,opt,name=mfa_challenges,json=mfaChallenges,proto3" json:"mfa_challenges,omitempty"` // OTP challenges sent by SMS
+	MfaResends    int64                  `protobuf:"varint,6,opt,name=mfa_resends,json=mfaResends,proto3" json:"mfa_resends,omitempty"`          // OTP codes sent again for an open challenge
+	SmsMessages   int64                  `protobuf:"varint,7,opt,name=sms_messages,json=smsMessages,proto3" json:"sms_messages,omitempty"`       // mfa_challenges + mfa_resends
+	ApiCalls      int64                  `protobuf:"varint,8,opt,name=api_calls,json=apiCalls,proto3" json:"api_calls,omitempty"`                // authenticated RPCs
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgUsage) Reset() {
+	*x = OrgUsage{}
+	mi := &file_analytics_analytics_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgUsage) ProtoMessage() {}
+
+func (x *OrgUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgUsage.ProtoReflect.Descriptor instead.
+func (*OrgUsage) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OrgUsage) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgUsage) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *OrgUsage) GetActiveUsers() int64 {
+	if x != nil {
+		return x.ActiveUsers
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetLogins() int64 {
+	if x != nil {
+		return x.Logins
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetMfaChallenges() int64 {
+	if x != nil {
+		return x.MfaChallenges
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetMfaResends() int64 {
+	if x != nil {
+		return x.MfaResends
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetSmsMessages() int64 {
+	if x != nil {
+		return x.SmsMessages
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetApiCalls() int64 {
+	if x != nil {
+		return x.ApiCalls
+	}
+	return 0
+}
+
+func (x *OrgUsage) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// GetUsageRequest selects months formatted as YYYY-MM. When from_month/to_month are empty the last 12 months
+// (including the current one) are used. Ranges are capped at 36 months.
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromMonth     string                 `protobuf:"bytes,1,opt,name=from_month,json=fromMonth,proto3" json:"from_month,omitempty"`
+	ToMonth       string                 `protobuf:"bytes,2,opt,name=to_month,json=toMonth,proto3" json:"to_month,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_analytics_analytics_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUsageRequest) GetFromMonth() string {
+	if x != nil {
+		return x.FromMonth
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetToMonth() string {
+	if x != nil {
+		return x.ToMonth
+	}
+	return ""
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Months        []*OrgUsage            `protobuf:"bytes,1,rep,name=months,proto3" json:"months,omitempty"` // only months with usage, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_analytics_analytics_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_analytics_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_analytics_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetUsageResponse) GetMonths() []*OrgUsage {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+var File_analytics_analytics_proto protoreflect.FileDescriptor
+
+const file_analytics_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x19analytics/analytics.proto\x12\x11ztcp.analytics.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x02\n" +
+	"\n" +
+	"LoginStats\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12'\n" +
+	"\x0flogin_successes\x18\x02 \x01(\x03R\x0eloginSuccesses\x12%\n" +
+	"\x0elogin_failures\x18\x03 \x01(\x03R\rloginFailures\x12%\n" +
+	"\x0emfa_challenges\x18\x04 \x01(\x03R\rmfaChallenges\x12)\n" +
+	"\x10sessions_created\x18\x05 \x01(\x03R\x0fsessionsCreated\x12!\n" +
+	"\ffailure_rate\x18\x06 \x01(\x01R\vfailureRate\x12,\n" +
+	"\x12mfa_challenge_rate\x18\a \x01(\x01R\x10mfaChallengeRate\"L\n" +
+	"\x14GetLoginStatsRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\tR\x06toDate\"\x81\x01\n" +
+	"\x15GetLoginStatsResponse\x121\n" +
+	"\x04days\x18\x01 \x03(\v2\x1d.ztcp.analytics.v1.LoginStatsR\x04days\x125\n" +
+	"\x06totals\x18\x02 \x01(\v2\x1d.ztcp.analytics.v1.LoginStatsR\x06totals\"f\n" +
+	"\x12DeviceSessionCount\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bsessions\x18\x03 \x01(\x03R\bsessions\"c\n" +
+	"\x15ListTopDevicesRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\tR\x06toDate\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Y\n" +
+	"\x16ListTopDevicesResponse\x12?\n" +
+	"\adevices\x18\x01 \x03(\v2%.ztcp.analytics.v1.DeviceSessionCountR\adevices\"K\n" +
+	"\x13CountrySessionCount\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x1a\n" +
+	"\bsessions\x18\x02 \x01(\x03R\bsessions\"T\n" +
+	"\x1cListSessionsByCountryRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\tR\x06toDate\"e\n" +
+	"\x1dListSessionsByCountryResponse\x12D\n" +
+	"\tcountries\x18\x01 \x03(\v2&.ztcp.analytics.v1.CountrySessionCountR\tcountries\"N\n" +
+	"\x14PolicyViolationCount\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x1e\n" +
+	"\n" +
+	"violations\x18\x02 \x01(\x03R\n" +
+	"violations\"V\n" +
+	"\x1eGetPolicyViolationStatsRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\tR\x06toDate\"z\n" +
+	"\x1fGetPolicyViolationStatsResponse\x12A\n" +
+	"\aactions\x18\x01 \x03(\v2'.ztcp.analytics.v1.PolicyViolationCountR\aactions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"D\n" +
+	"\x12BlockedDomainCount\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x16\n" +
+	"\x06blocks\x18\x02 \x01(\x03R\x06blocks\"|\n" +
+	"\x1cListTopBlockedDomainsRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\tR\x06toDate\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x10\n" +
+	"\x03csv\x18\x04 \x01(\bR\x03csv\"r\n" +
+	"\x1dListTopBlockedDomainsResponse\x12?\n" +
+	"\adomains\x18\x01 \x03(\v2%.ztcp.analytics.v1.BlockedDomainCountR\adomains\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\tR\x03csv\"M\n" +
+	"\x12UserViolationCount\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"violations\x18\x02 \x01(\x03R\n" +
+	"violations\"w\n" +
+	"\x17ListTopViolatorsRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\tR\x06toDate\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x10\n" +
+	"\x03csv\x18\x04 \x01(\bR\x03csv\"i\n" +
+	"\x18ListTopViolatorsResponse\x12;\n" +
+	"\x05users\x18\x01 \x03(\v2%.ztcp.analytics.v1.UserViolationCountR\x05users\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\tR\x03csv\"c\n" +
+	"\x0ePolicyTrendDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"url_blocks\x18\x02 \x01(\x03R\turlBlocks\x12\x1e\n" +
+	"\n" +
+	"violations\x18\x03 \x01(\x03R\n" +
+	"violations\"_\n" +
+	"\x15GetPolicyTrendRequest\x12\x1b\n" +
+	"\tfrom_date\x18\x01 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x02 \x01(\tR\x06toDate\x12\x10\n" +
+	"\x03csv\x18\x03 \x01(\bR\x03csv\"\x9c\x01\n" +
+	"\x16GetPolicyTrendResponse\x125\n" +
+	"\x04days\x18\x01 \x03(\v2!.ztcp.analytics.v1.PolicyTrendDayR\x04days\x129\n" +
+	"\x06totals\x18\x02 \x01(\v2!.ztcp.analytics.v1.PolicyTrendDayR\x06totals\x12\x10\n" +
+	"\x03csv\x18\x03 \x01(\tR\x03csv\"\xb5\x02\n" +
+	"\bOrgUsage\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\tR\x05month\x12!\n" +
+	"\factive_users\x18\x03 \x01(\x03R\vactiveUsers\x12\x16\n" +
+	"\x06logins\x18\x04 \x01(\x03R\x06logins\x12%\n" +
+	"\x0emfa_challenges\x18\x05 \x01(\x03R\rmfaChallenges\x12\x1f\n" +
+	"\vmfa_resends\x18\x06 \x01(\x03R\n" +
+	"mfaResends\x12!\n" +
+	"\fsms_messages\x18\a \x01(\x03R\vsmsMessages\x12\x1b\n" +
+	"\tapi_calls\x18\b \x01(\x03R\bapiCalls\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"K\n" +
+	"\x0fGetUsageRequest\x12\x1d\n" +
+	"\n" +
+	"from_month\x18\x01 \x01(\tR\tfromMonth\x12\x19\n" +
+	"\bto_month\x18\x02 \x01(\tR\atoMonth\"G\n" +
+	"\x10GetUsageResponse\x123\n" +
+	"\x06months\x18\x01 \x03(\v2\x1b.ztcp.analytics.v1.OrgUsageR\x06months2\xa9\a\n" +
+	"\x10AnalyticsService\x12g\n" +
+	"\rGetLoginStats\x12'.ztcp.analytics.v1.GetLoginStatsRequest\x1a(.ztcp.analytics.v1.GetLoginStatsResponse\"\x03\x90\x02\x01\x12j\n" +
+	"\x0eListTopDevices\x12(.ztcp.analytics.v1.ListTopDevicesRequest\x1a).ztcp.analytics.v1.ListTopDevicesResponse\"\x03\x90\x02\x01\x12\x7f\n" +
+	"\x15ListSessionsByCountry\x12/.ztcp.analytics.v1.ListSessionsByCountryRequest\x1a0.ztcp.analytics.v1.ListSessionsByCountryResponse\"\x03\x90\x02\x01\x12\x85\x01\n" +
+	"\x17GetPolicyViolationStats\x121.ztcp.analytics.v1.GetPolicyViolationStatsRequest\x1a2.ztcp.analytics.v1.GetPolicyViolationStatsResponse\"\x03\x90\x02\x01\x12\x7f\n" +
+	"\x15ListTopBlockedDomains\x12/.ztcp.analytics.v1.ListTopBlockedDomainsRequest\x1a0.ztcp.analytics.v1.ListTopBlockedDomainsResponse\"\x03\x90\x02\x01\x12p\n" +
+	"\x10ListTopViolators\x12*.ztcp.analytics.v1.ListTopViolatorsRequest\x1a+.ztcp.analytics.v1.ListTopViolatorsResponse\"\x03\x90\x02\x01\x12j\n" +
+	"\x0eGetPolicyTrend\x12(.ztcp.analytics.v1.GetPolicyTrendRequest\x1a).ztcp.analytics.v1.GetPolicyTrendResponse\"\x03\x90\x02\x01\x12X\n" +
+	"\bGetUsage\x12\".ztcp.analytics.v1.GetUsageRequest\x1a#.ztcp.analytics.v1.GetUsageResponse\"\x03\x90\x02\x01BIZGzero-trust-control-plane/backend/api/generated/analytics/v1;analyticsv1b\x06proto3"
+
+var (
+	file_analytics_analytics_proto_rawDescOnce sync.Once
+	file_analytics_analytics_proto_rawDescData []byte
+)
+
+func file_analytics_analytics_proto_rawDescGZIP() []byte {
+	file_analytics_analytics_proto_rawDescOnce.Do(func() {
+		file_analytics_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analytics_analytics_proto_rawDesc), len(file_analytics_analytics_proto_rawDesc)))
+	})
+	return file_analytics_analytics_proto_rawDescData
+}
+
+var file_analytics_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_analytics_analytics_proto_goTypes = []any{
+	(*LoginStats)(nil),                      // 0: ztcp.analytics.v1.LoginStats
+	(*GetLoginStatsRequest)(nil),            // 1: ztcp.analytics.v1.GetLoginStatsRequest
+	(*GetLoginStatsResponse)(nil),           // 2: ztcp.analytics.v1.GetLoginStatsResponse
+	(*DeviceSessionCount)(nil),              // 3: ztcp.analytics.v1.DeviceSessionCount
+	(*ListTopDevicesRequest)(nil),           // 4: ztcp.analytics.v1.ListTopDevicesRequest
+	(*ListTopDevicesResponse)(nil),          // 5: ztcp.analytics.v1.ListTopDevicesResponse
+	(*CountrySessionCount)(nil),             // 6: ztcp.analytics.v1.CountrySessionCount
+	(*ListSessionsByCountryRequest)(nil),    // 7: ztcp.analytics.v1.ListSessionsByCountryRequest
+	(*ListSessionsByCountryResponse)(nil),   // 8: ztcp.analytics.v1.ListSessionsByCountryResponse
+	(*PolicyViolationCount)(nil),            // 9: ztcp.analytics.v1.PolicyViolationCount
+	(*GetPolicyViolationStatsRequest)(nil),  // 10: ztcp.analytics.v1.GetPolicyViolationStatsRequest
+	(*GetPolicyViolationStatsResponse)(nil), // 11: ztcp.analytics.v1.GetPolicyViolationStatsResponse
+	(*BlockedDomainCount)(nil),              // 12: ztcp.analytics.v1.BlockedDomainCount
+	(*ListTopBlockedDomainsRequest)(nil),    // 13: ztcp.analytics.v1.ListTopBlockedDomainsRequest
+	(*ListTopBlockedDomainsResponse)(nil),   // 14: ztcp.analytics.v1.ListTopBlockedDomainsResponse
+	(*UserViolationCount)(nil),              // 15: ztcp.analytics.v1.UserViolationCount
+	(*ListTopViolatorsRequest)(nil),         // 16: ztcp.analytics.v1.ListTopViolatorsRequest
+	(*ListTopViolatorsResponse)(nil),        // 17: ztcp.analytics.v1.ListTopViolatorsResponse
+	(*PolicyTrendDay)(nil),                  // 18: ztcp.analytics.v1.PolicyTrendDay
+	(*GetPolicyTrendRequest)(nil),           // 19: ztcp.analytics.v1.GetPolicyTrendRequest
+	(*GetPolicyTrendResponse)(nil),          // 20: ztcp.analytics.v1.GetPolicyTrendResponse
+	(*OrgUsage)(nil),                        // 21: ztcp.analytics.v1.OrgUsage
+	(*GetUsageRequest)(nil),                 // 22: ztcp.analytics.v1.GetUsageRequest
+	(*GetUsageResponse)(nil),                // 23: ztcp.analytics.v1.GetUsageResponse
+	(*timestamppb.Timestamp)(nil),           // 24: google.protobuf.Timestamp
+}
+var file_analytics_analytics_proto_depIdxs = []int32{
+	0,  // 0: ztcp.analytics.v1.GetLoginStatsResponse.days:type_name -> ztcp.analytics.v1.LoginStats
+	0,  // 1: ztcp.analytics.v1.GetLoginStatsResponse.totals:type_name -> ztcp.analytics.v1.LoginStats
+	3,  // 2: ztcp.analytics.v1.ListTopDevicesResponse.devices:type_name -> ztcp.analytics.v1.DeviceSessionCount
+	6,  // 3: ztcp.analytics.v1.ListSessionsByCountryResponse.countries:type_name -> ztcp.analytics.v1.CountrySessionCount
+	9,  // 4: ztcp.analytics.v1.GetPolicyViolationStatsResponse.actions:type_name -> ztcp.analytics.v1.PolicyViolationCount
+	12, // 5: ztcp.analytics.v1.ListTopBlockedDomainsResponse.domains:type_name -> ztcp.analytics.v1.BlockedDomainCount
+	15, // 6: ztcp.analytics.v1.ListTopViolatorsResponse.users:type_name -> ztcp.analytics.v1.UserViolationCount
+	18, // 7: ztcp.analytics.v1.GetPolicyTrendResponse.days:type_name -> ztcp.analytics.v1.PolicyTrendDay
+	18, // 8: ztcp.analytics.v1.GetPolicyTrendResponse.totals:type_name -> ztcp.analytics.v1.PolicyTrendDay
+	24, // 9: ztcp.analytics.v1.OrgUsage.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 10: ztcp.analytics.v1.GetUsageResponse.months:type_name -> ztcp.analytics.v1.OrgUsage
+	1,  // 11: ztcp.analytics.v1.AnalyticsService.GetLoginStats:input_type -> ztcp.analytics.v1.GetLoginStatsRequest
+	4,  // 12: ztcp.analytics.v1.AnalyticsService.ListTopDevices:input_type -> ztcp.analytics.v1.ListTopDevicesRequest
+	7,  // 13: ztcp.analytics.v1.AnalyticsService.ListSessionsByCountry:input_type -> ztcp.analytics.v1.ListSessionsByCountryRequest
+	10, // 14: ztcp.analytics.v1.AnalyticsService.GetPolicyViolationStats:input_type -> ztcp.analytics.v1.GetPolicyViolationStatsRequest
+	13, // 15: ztcp.analytics.v1.AnalyticsService.ListTopBlockedDomains:input_type -> ztcp.analytics.v1.ListTopBlockedDomainsRequest
+	16, // 16: ztcp.analytics.v1.AnalyticsService.ListTopViolators:input_type -> ztcp.analytics.v1.ListTopViolatorsRequest
+	19, // 17: ztcp.analytics.v1.AnalyticsService.GetPolicyTrend:input_type -> ztcp.analytics.v1.GetPolicyTrendRequest
+	22, // 18: ztcp.analytics.v1.AnalyticsService.GetUsage:input_type -> ztcp.analytics.v1.GetUsageRequest
+	2,  // 19: ztcp.analytics.v1.AnalyticsService.GetLoginStats:output_type -> ztcp.analytics.v1.GetLoginStatsResponse
+	5,  // 20: ztcp.analytics.v1.AnalyticsService.ListTopDevices:output_type -> ztcp.analytics.v1.ListTopDevicesResponse
+	8,  // 21: ztcp.analytics.v1.AnalyticsService.ListSessionsByCountry:output_type -> ztcp.analytics.v1.ListSessionsByCountryResponse
+	11, // 22: ztcp.analytics.v1.AnalyticsService.GetPolicyViolationStats:output_type -> ztcp.analytics.v1.GetPolicyViolationStatsResponse
+	14, // 23: ztcp.analytics.v1.AnalyticsService.ListTopBlockedDomains:output_type -> ztcp.analytics.v1.ListTopBlockedDomainsResponse
+	17, // 24: ztcp.analytics.v1.AnalyticsService.ListTopViolators:output_type -> ztcp.analytics.v1.ListTopViolatorsResponse
+	20, // 25: ztcp.analytics.v1.AnalyticsService.GetPolicyTrend:output_type -> ztcp.analytics.v1.GetPolicyTrendResponse
+	23, // 26: ztcp.analytics.v1.AnalyticsService.GetUsage:output_type -> ztcp.analytics.v1.GetUsageResponse
+	19, // [19:27] is the sub-list for method output_type
+	11, // [11:19] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_analytics_analytics_proto_init() }
+func file_analytics_analytics_proto_init() {
+	if File_analytics_analytics_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analytics_analytics_proto_rawDesc), len(file_analytics_analytics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analytics_analytics_proto_goTypes,
+		DependencyIndexes: file_analytics_analytics_proto_depIdxs,
+		MessageInfos:      file_analytics_analytics_proto_msgTypes,
+	}.Build()
+	File_analytics_analytics_proto = out.File
+	file_analytics_analytics_proto_goTypes = nil
+	file_analytics_analytics_proto_depIdxs = nil
+}
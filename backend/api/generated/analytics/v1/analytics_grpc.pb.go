@@ -0,0 +1,401 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: analytics/analytics.proto
+
+package analyticsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_GetLoginStats_FullMethodName           = "/ztcp.analytics.v1.AnalyticsService/GetLoginStats"
+	AnalyticsService_ListTopDevices_FullMethodName          = "/ztcp.analytics.v1.AnalyticsService/ListTopDevices"
+	AnalyticsService_ListSessionsByCountry_FullMethodName   = "/ztcp.analytics.v1.AnalyticsService/ListSessionsByCountry"
+	AnalyticsService_GetPolicyViolationStats_FullMethodName = "/ztcp.analytics.v1.AnalyticsService/GetPolicyViolationStats"
+	AnalyticsService_ListTopBlockedDomains_FullMethodName   = "/ztcp.analytics.v1.AnalyticsService/ListTopBlockedDomains"
+	AnalyticsService_ListTopViolators_FullMethodName        = "/ztcp.analytics.v1.AnalyticsService/ListTopViolators"
+	AnalyticsService_GetPolicyTrend_FullMethodName          = "/ztcp.analytics.v1.AnalyticsService/GetPolicyTrend"
+	AnalyticsService_GetUsage_FullMethodName                = "/ztcp.analytics.v1.AnalyticsService/GetUsage"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AnalyticsService serves org-admin login dashboards from pre-aggregated daily rollups
+// (refreshed by the analytics rollup job), not from raw audit rows.
+type AnalyticsServiceClient interface {
+	GetLoginStats(ctx context.Context, in *GetLoginStatsRequest, opts ...grpc.CallOption) (*GetLoginStatsResponse, error)
+	ListTopDevices(ctx context.Context, in *ListTopDevicesRequest, opts ...grpc.CallOption) (*ListTopDevicesResponse, error)
+	ListSessionsByCountry(ctx context.Context, in *ListSessionsByCountryRequest, opts ...grpc.CallOption) (*ListSessionsByCountryResponse, error)
+	GetPolicyViolationStats(ctx context.Context, in *GetPolicyViolationStatsRequest, opts ...grpc.CallOption) (*GetPolicyViolationStatsResponse, error)
+	// ListTopBlockedDomains returns the domains with the most URLs denied by CheckUrlAccess.
+	ListTopBlockedDomains(ctx context.Context, in *ListTopBlockedDomainsRequest, opts ...grpc.CallOption) (*ListTopBlockedDomainsResponse, error)
+	// ListTopViolators returns the users with the most reported policy violations.
+	ListTopViolators(ctx context.Context, in *ListTopViolatorsRequest, opts ...grpc.CallOption) (*ListTopViolatorsResponse, error)
+	// GetPolicyTrend returns URL denials and policy violations per day.
+	GetPolicyTrend(ctx context.Context, in *GetPolicyTrendRequest, opts ...grpc.CallOption) (*GetPolicyTrendResponse, error)
+	// GetUsage returns the caller's org's metered monthly usage (the figures it is billed on).
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetLoginStats(ctx context.Context, in *GetLoginStatsRequest, opts ...grpc.CallOption) (*GetLoginStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLoginStatsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetLoginStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListTopDevices(ctx context.Context, in *ListTopDevicesRequest, opts ...grpc.CallOption) (*ListTopDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopDevicesResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ListTopDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListSessionsByCountry(ctx context.Context, in *ListSessionsByCountryRequest, opts ...grpc.CallOption) (*ListSessionsByCountryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsByCountryResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ListSessionsByCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetPolicyViolationStats(ctx context.Context, in *GetPolicyViolationStatsRequest, opts ...grpc.CallOption) (*GetPolicyViolationStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPolicyViolationStatsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetPolicyViolationStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListTopBlockedDomains(ctx context.Context, in *ListTopBlockedDomainsRequest, opts ...grpc.CallOption) (*ListTopBlockedDomainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopBlockedDomainsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ListTopBlockedDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListTopViolators(ctx context.Context, in *ListTopViolatorsRequest, opts ...grpc.CallOption) (*ListTopViolatorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopViolatorsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ListTopViolators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetPolicyTrend(ctx context.Context, in *GetPolicyTrendRequest, opts ...grpc.CallOption) (*GetPolicyTrendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPolicyTrendResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetPolicyTrend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// AnalyticsService serves org-admin login dashboards from pre-aggregated daily rollups
+// (refreshed by the analytics rollup job), not from raw audit rows.
+type AnalyticsServiceServer interface {
+	GetLoginStats(context.Context, *GetLoginStatsRequest) (*GetLoginStatsResponse, error)
+	ListTopDevices(context.Context, *ListTopDevicesRequest) (*ListTopDevicesResponse, error)
+	ListSessionsByCountry(context.Context, *ListSessionsByCountryRequest) (*ListSessionsByCountryResponse, error)
+	GetPolicyViolationStats(context.Context, *GetPolicyViolationStatsRequest) (*GetPolicyViolationStatsResponse, error)
+	// ListTopBlockedDomains returns the domains with the most URLs denied by CheckUrlAccess.
+	ListTopBlockedDomains(context.Context, *ListTopBlockedDomainsRequest) (*ListTopBlockedDomainsResponse, error)
+	// ListTopViolators returns the users with the most reported policy violations.
+	ListTopViolators(context.Context, *ListTopViolatorsRequest) (*ListTopViolatorsResponse, error)
+	// GetPolicyTrend returns URL denials and policy violations per day.
+	GetPolicyTrend(context.Context, *GetPolicyTrendRequest) (*GetPolicyTrendResponse, error)
+	// GetUsage returns the caller's org's metered monthly usage (the figures it is billed on).
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) GetLoginStats(context.Context, *GetLoginStatsRequest) (*GetLoginStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLoginStats not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListTopDevices(context.Context, *ListTopDevicesRequest) (*ListTopDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTopDevices not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListSessionsByCountry(context.Context, *ListSessionsByCountryRequest) (*ListSessionsByCountryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSessionsByCountry not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetPolicyViolationStats(context.Context, *GetPolicyViolationStatsRequest) (*GetPolicyViolationStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPolicyViolationStats not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListTopBlockedDomains(context.Context, *ListTopBlockedDomainsRequest) (*ListTopBlockedDomainsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTopBlockedDomains not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListTopViolators(context.Context, *ListTopViolatorsRequest) (*ListTopViolatorsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTopViolators not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetPolicyTrend(context.Context, *GetPolicyTrendRequest) (*GetPolicyTrendResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPolicyTrend not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call panics, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetLoginStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoginStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetLoginStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetLoginStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetLoginStats(ctx, req.(*GetLoginStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListTopDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListTopDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ListTopDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListTopDevices(ctx, req.(*ListTopDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListSessionsByCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsByCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListSessionsByCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ListSessionsByCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListSessionsByCountry(ctx, req.(*ListSessionsByCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetPolicyViolationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPolicyViolationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetPolicyViolationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetPolicyViolationStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetPolicyViolationStats(ctx, req.(*GetPolicyViolationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListTopBlockedDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopBlockedDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListTopBlockedDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ListTopBlockedDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListTopBlockedDomains(ctx, req.(*ListTopBlockedDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListTopViolators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopViolatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListTopViolators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ListTopViolators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListTopViolators(ctx, req.(*ListTopViolatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetPolicyTrend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPolicyTrendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetPolicyTrend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetPolicyTrend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetPolicyTrend(ctx, req.(*GetPolicyTrendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.analytics.v1.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLoginStats",
+			Handler:    _AnalyticsService_GetLoginStats_Handler,
+		},
+		{
+			MethodName: "ListTopDevices",
+			Handler:    _AnalyticsService_ListTopDevices_Handler,
+		},
+		{
+			MethodName: "ListSessionsByCountry",
+			Handler:    _AnalyticsService_ListSessionsByCountry_Handler,
+		},
+		{
+			MethodName: "GetPolicyViolationStats",
+			Handler:    _AnalyticsService_GetPolicyViolationStats_Handler,
+		},
+		{
+			MethodName: "ListTopBlockedDomains",
+			Handler:    _AnalyticsService_ListTopBlockedDomains_Handler,
+		},
+		{
+			MethodName: "ListTopViolators",
+			Handler:    _AnalyticsService_ListTopViolators_Handler,
+		},
+		{
+			MethodName: "GetPolicyTrend",
+			Handler:    _AnalyticsService_GetPolicyTrend_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _AnalyticsService_GetUsage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "analytics/analytics.proto",
+}
@@ -34,6 +34,7 @@ type AuditEvent struct {
 	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
 	Metadata      string                 `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RequestId     string                 `protobuf:"bytes,9,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // x-request-id correlation ID; empty for legacy entries
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -124,14 +125,22 @@ func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
 // ListAuditLogsRequest lists audit logs for an org with pagination.
 type ListAuditLogsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	Pagination    *v1.Pagination         `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional filter
-	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`               // optional filter
-	Resource      string                 `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`           // optional filter
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // optional filter
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                        // optional filter
+	Resource      string                 `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`                    // optional filter
+	RequestId     string                 `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // optional filter; traces a single request (e.g. one login attempt)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -201,6 +210,13 @@ func (x *ListAuditLogsRequest) GetResource() string {
 	return ""
 }
 
+func (x *ListAuditLogsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
 // ListAuditLogsResponse returns a page of audit logs.
 type ListAuditLogsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -254,11 +270,72 @@ func (x *ListAuditLogsResponse) GetPagination() *v1.PaginationResult {
 	return nil
 }
 
+// StreamAuditEventsRequest tails audit events for an org with optional filters.
+type StreamAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional filter
+	Actions       []string               `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`             // optional filter; any of these actions (e.g. login_success, login_failure)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAuditEventsRequest) Reset() {
+	*x = StreamAuditEventsRequest{}
+	mi := &file_audit_audit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAuditEventsRequest) ProtoMessage() {}
+
+func (x *StreamAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audit_audit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_audit_audit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamAuditEventsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *StreamAuditEventsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StreamAuditEventsRequest) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
 var File_audit_audit_proto protoreflect.FileDescriptor
 
 const file_audit_audit_proto_rawDesc = "" +
 	"\n" +
-	"\x11audit/audit.proto\x12\rztcp.audit.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x01\n" +
+	"\x11audit/audit.proto\x12\rztcp.audit.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x02\n" +
 	"\n" +
 	"AuditEvent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
@@ -269,7 +346,9 @@ const file_audit_audit_proto_rawDesc = "" +
 	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x1a\n" +
 	"\bmetadata\x18\a \x01(\tR\bmetadata\x129\n" +
 	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb6\x01\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\t \x01(\tR\trequestId\"\xd5\x01\n" +
 	"\x14ListAuditLogsRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12:\n" +
 	"\n" +
@@ -277,14 +356,21 @@ const file_audit_audit_proto_rawDesc = "" +
 	"pagination\x12\x17\n" +
 	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1a\n" +
-	"\bresource\x18\x05 \x01(\tR\bresource\"\x88\x01\n" +
+	"\bresource\x18\x05 \x01(\tR\bresource\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x06 \x01(\tR\trequestId\"\x88\x01\n" +
 	"\x15ListAuditLogsResponse\x12-\n" +
 	"\x04logs\x18\x01 \x03(\v2\x19.ztcp.audit.v1.AuditEventR\x04logs\x12@\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
-	"pagination2j\n" +
-	"\fAuditService\x12Z\n" +
-	"\rListAuditLogs\x12#.ztcp.audit.v1.ListAuditLogsRequest\x1a$.ztcp.audit.v1.ListAuditLogsResponseBAZ?zero-trust-control-plane/backend/api/generated/audit/v1;auditv1b\x06proto3"
+	"pagination\"d\n" +
+	"\x18StreamAuditEventsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\aactions\x18\x03 \x03(\tR\aactions2\xcf\x01\n" +
+	"\fAuditService\x12_\n" +
+	"\rListAuditLogs\x12#.ztcp.audit.v1.ListAuditLogsRequest\x1a$.ztcp.audit.v1.ListAuditLogsResponse\"\x03\x90\x02\x01\x12^\n" +
+	"\x11StreamAuditEvents\x12'.ztcp.audit.v1.StreamAuditEventsRequest\x1a\x19.ztcp.audit.v1.AuditEvent\"\x03\x90\x02\x010\x01BAZ?zero-trust-control-plane/backend/api/generated/audit/v1;auditv1b\x06proto3"
 
 var (
 	file_audit_audit_proto_rawDescOnce sync.Once
@@ -298,24 +384,27 @@ func file_audit_audit_proto_rawDescGZIP() []byte {
 	return file_audit_audit_proto_rawDescData
 }
 
-var file_audit_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_audit_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_audit_audit_proto_goTypes = []any{
-	(*AuditEvent)(nil),            // 0: ztcp.audit.v1.AuditEvent
-	(*ListAuditLogsRequest)(nil),  // 1: ztcp.audit.v1.ListAuditLogsRequest
-	(*ListAuditLogsResponse)(nil), // 2: ztcp.audit.v1.ListAuditLogsResponse
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
-	(*v1.Pagination)(nil),         // 4: ztcp.common.v1.Pagination
-	(*v1.PaginationResult)(nil),   // 5: ztcp.common.v1.PaginationResult
+	(*AuditEvent)(nil),               // 0: ztcp.audit.v1.AuditEvent
+	(*ListAuditLogsRequest)(nil),     // 1: ztcp.audit.v1.ListAuditLogsRequest
+	(*ListAuditLogsResponse)(nil),    // 2: ztcp.audit.v1.ListAuditLogsResponse
+	(*StreamAuditEventsRequest)(nil), // 3: ztcp.audit.v1.StreamAuditEventsRequest
+	(*timestamppb.Timestamp)(nil),    // 4: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),            // 5: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),      // 6: ztcp.common.v1.PaginationResult
 }
 var file_audit_audit_proto_depIdxs = []int32{
-	3, // 0: ztcp.audit.v1.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
-	4, // 1: ztcp.audit.v1.ListAuditLogsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	4, // 0: ztcp.audit.v1.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	5, // 1: ztcp.audit.v1.ListAuditLogsRequest.pagination:type_name -> ztcp.common.v1.Pagination
 	0, // 2: ztcp.audit.v1.ListAuditLogsResponse.logs:type_name -> ztcp.audit.v1.AuditEvent
-	5, // 3: ztcp.audit.v1.ListAuditLogsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	6, // 3: ztcp.audit.v1.ListAuditLogsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
 	1, // 4: ztcp.audit.v1.AuditService.ListAuditLogs:input_type -> ztcp.audit.v1.ListAuditLogsRequest
-	2, // 5: ztcp.audit.v1.AuditService.ListAuditLogs:output_type -> ztcp.audit.v1.ListAuditLogsResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
+	3, // 5: ztcp.audit.v1.AuditService.StreamAuditEvents:input_type -> ztcp.audit.v1.StreamAuditEventsRequest
+	2, // 6: ztcp.audit.v1.AuditService.ListAuditLogs:output_type -> ztcp.audit.v1.ListAuditLogsResponse
+	0, // 7: ztcp.audit.v1.AuditService.StreamAuditEvents:output_type -> ztcp.audit.v1.AuditEvent
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
 	4, // [4:4] is the sub-list for extension type_name
 	4, // [4:4] is the sub-list for extension extendee
 	0, // [0:4] is the sub-list for field type_name
@@ -332,7 +421,7 @@ func file_audit_audit_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_audit_audit_proto_rawDesc), len(file_audit_audit_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuditService_ListAuditLogs_FullMethodName = "/ztcp.audit.v1.AuditService/ListAuditLogs"
+	AuditService_ListAuditLogs_FullMethodName     = "/ztcp.audit.v1.AuditService/ListAuditLogs"
+	AuditService_StreamAuditEvents_FullMethodName = "/ztcp.audit.v1.AuditService/StreamAuditEvents"
 )
 
 // AuditServiceClient is the client API for AuditService service.
@@ -29,6 +30,9 @@ const (
 // AuditService handles compliance and security trail.
 type AuditServiceClient interface {
 	ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error)
+	// StreamAuditEvents sends audit events of the org as they are written, starting when the stream opens, until the
+	// client cancels. Org admins only.
+	StreamAuditEvents(ctx context.Context, in *StreamAuditEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditEvent], error)
 }
 
 type auditServiceClient struct {
@@ -49,6 +53,25 @@ func (c *auditServiceClient) ListAuditLogs(ctx context.Context, in *ListAuditLog
 	return out, nil
 }
 
+func (c *auditServiceClient) StreamAuditEvents(ctx context.Context, in *StreamAuditEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuditService_ServiceDesc.Streams[0], AuditService_StreamAuditEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamAuditEventsRequest, AuditEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuditService_StreamAuditEventsClient = grpc.ServerStreamingClient[AuditEvent]
+
 // AuditServiceServer is the server API for AuditService service.
 // All implementations must embed UnimplementedAuditServiceServer
 // for forward compatibility.
@@ -56,6 +79,9 @@ func (c *auditServiceClient) ListAuditLogs(ctx context.Context, in *ListAuditLog
 // AuditService handles compliance and security trail.
 type AuditServiceServer interface {
 	ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error)
+	// StreamAuditEvents sends audit events of the org as they are written, starting when the stream opens, until the
+	// client cancels. Org admins only.
+	StreamAuditEvents(*StreamAuditEventsRequest, grpc.ServerStreamingServer[AuditEvent]) error
 	mustEmbedUnimplementedAuditServiceServer()
 }
 
@@ -69,6 +95,9 @@ type UnimplementedAuditServiceServer struct{}
 func (UnimplementedAuditServiceServer) ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListAuditLogs not implemented")
 }
+func (UnimplementedAuditServiceServer) StreamAuditEvents(*StreamAuditEventsRequest, grpc.ServerStreamingServer[AuditEvent]) error {
+	return status.Error(codes.Unimplemented, "method StreamAuditEvents not implemented")
+}
 func (UnimplementedAuditServiceServer) mustEmbedUnimplementedAuditServiceServer() {}
 func (UnimplementedAuditServiceServer) testEmbeddedByValue()                      {}
 
@@ -108,6 +137,17 @@ func _AuditService_ListAuditLogs_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuditService_StreamAuditEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAuditEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuditServiceServer).StreamAuditEvents(m, &grpc.GenericServerStream[StreamAuditEventsRequest, AuditEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuditService_StreamAuditEventsServer = grpc.ServerStreamingServer[AuditEvent]
+
 // AuditService_ServiceDesc is the grpc.ServiceDesc for AuditService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -120,6 +160,12 @@ var AuditService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _AuditService_ListAuditLogs_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAuditEvents",
+			Handler:       _AuditService_StreamAuditEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "audit/audit.proto",
 }
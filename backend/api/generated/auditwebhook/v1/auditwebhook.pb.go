@@ -0,0 +1,804 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: auditwebhook/auditwebhook.proto
+
+package auditwebhookv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuditWebhook is an org's subscription to its own audit log: each audit event of the org that matches filter is
+// POSTed to url as JSON, signed with the webhook's secret (HMAC-SHA256 in X-ZTCP-Signature).
+type AuditWebhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Url   string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"` // https
+	// Filter expression evaluated before delivery; empty delivers every event. Example:
+	// action in ["login_failure", "session_revoked"] AND role == "admin"
+	Filter        string                 `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled       bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditWebhook) Reset() {
+	*x = AuditWebhook{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditWebhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditWebhook) ProtoMessage() {}
+
+func (x *AuditWebhook) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditWebhook.ProtoReflect.Descriptor instead.
+func (*AuditWebhook) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditWebhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditWebhook) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AuditWebhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AuditWebhook) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *AuditWebhook) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AuditWebhook) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *AuditWebhook) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *AuditWebhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AuditWebhook) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateAuditWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                 // required; https, max 2048 characters
+	Filter        string                 `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`           // optional, max 2000 characters
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // optional, max 200 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuditWebhookRequest) Reset() {
+	*x = CreateAuditWebhookRequest{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuditWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuditWebhookRequest) ProtoMessage() {}
+
+func (x *CreateAuditWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuditWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuditWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAuditWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateAuditWebhookRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *CreateAuditWebhookRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CreateAuditWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *AuditWebhook          `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // signing secret; returned only here and by UpdateAuditWebhook with rotate_secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuditWebhookResponse) Reset() {
+	*x = CreateAuditWebhookResponse{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuditWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuditWebhookResponse) ProtoMessage() {}
+
+func (x *CreateAuditWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuditWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateAuditWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAuditWebhookResponse) GetWebhook() *AuditWebhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *CreateAuditWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListAuditWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditWebhooksRequest) Reset() {
+	*x = ListAuditWebhooksRequest{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditWebhooksRequest) ProtoMessage() {}
+
+func (x *ListAuditWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{3}
+}
+
+type ListAuditWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*AuditWebhook        `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditWebhooksResponse) Reset() {
+	*x = ListAuditWebhooksResponse{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditWebhooksResponse) ProtoMessage() {}
+
+func (x *ListAuditWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAuditWebhooksResponse) GetWebhooks() []*AuditWebhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+// UpdateAuditWebhookRequest replaces the url, filter, description and enabled flag of a webhook.
+type UpdateAuditWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"` // required
+	Filter        string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled       bool                   `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	RotateSecret  bool                   `protobuf:"varint,6,opt,name=rotate_secret,json=rotateSecret,proto3" json:"rotate_secret,omitempty"` // issue a new signing secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuditWebhookRequest) Reset() {
+	*x = UpdateAuditWebhookRequest{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuditWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuditWebhookRequest) ProtoMessage() {}
+
+func (x *UpdateAuditWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuditWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAuditWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateAuditWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAuditWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UpdateAuditWebhookRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *UpdateAuditWebhookRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateAuditWebhookRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpdateAuditWebhookRequest) GetRotateSecret() bool {
+	if x != nil {
+		return x.RotateSecret
+	}
+	return false
+}
+
+type UpdateAuditWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *AuditWebhook          `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // the new secret when rotate_secret was set; empty otherwise
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuditWebhookResponse) Reset() {
+	*x = UpdateAuditWebhookResponse{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuditWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuditWebhookResponse) ProtoMessage() {}
+
+func (x *UpdateAuditWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuditWebhookResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAuditWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateAuditWebhookResponse) GetWebhook() *AuditWebhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *UpdateAuditWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type DeleteAuditWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAuditWebhookRequest) Reset() {
+	*x = DeleteAuditWebhookRequest{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAuditWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAuditWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteAuditWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAuditWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAuditWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteAuditWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAuditWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAuditWebhookResponse) Reset() {
+	*x = DeleteAuditWebhookResponse{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAuditWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAuditWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteAuditWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAuditWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAuditWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{8}
+}
+
+// TestAuditWebhookFilterRequest evaluates a filter against a sample event.
+type TestAuditWebhookFilterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        string                 `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Resource      string                 `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestAuditWebhookFilterRequest) Reset() {
+	*x = TestAuditWebhookFilterRequest{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestAuditWebhookFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestAuditWebhookFilterRequest) ProtoMessage() {}
+
+func (x *TestAuditWebhookFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestAuditWebhookFilterRequest.ProtoReflect.Descriptor instead.
+func (*TestAuditWebhookFilterRequest) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TestAuditWebhookFilterRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *TestAuditWebhookFilterRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TestAuditWebhookFilterRequest) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *TestAuditWebhookFilterRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TestAuditWebhookFilterRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *TestAuditWebhookFilterRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type TestAuditWebhookFilterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       bool                   `protobuf:"varint,1,opt,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestAuditWebhookFilterResponse) Reset() {
+	*x = TestAuditWebhookFilterResponse{}
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestAuditWebhookFilterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestAuditWebhookFilterResponse) ProtoMessage() {}
+
+func (x *TestAuditWebhookFilterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auditwebhook_auditwebhook_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestAuditWebhookFilterResponse.ProtoReflect.Descriptor instead.
+func (*TestAuditWebhookFilterResponse) Descriptor() ([]byte, []int) {
+	return file_auditwebhook_auditwebhook_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TestAuditWebhookFilterResponse) GetMatches() bool {
+	if x != nil {
+		return x.Matches
+	}
+	return false
+}
+
+var File_auditwebhook_auditwebhook_proto protoreflect.FileDescriptor
+
+const file_auditwebhook_auditwebhook_proto_rawDesc = "" +
+	"\n" +
+	"\x1fauditwebhook/auditwebhook.proto\x12\x14ztcp.auditwebhook.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb0\x02\n" +
+	"\fAuditWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x18\n" +
+	"\aenabled\x18\x06 \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"g\n" +
+	"\x19CreateAuditWebhookRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"r\n" +
+	"\x1aCreateAuditWebhookResponse\x12<\n" +
+	"\awebhook\x18\x01 \x01(\v2\".ztcp.auditwebhook.v1.AuditWebhookR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\x1a\n" +
+	"\x18ListAuditWebhooksRequest\"[\n" +
+	"\x19ListAuditWebhooksResponse\x12>\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\".ztcp.auditwebhook.v1.AuditWebhookR\bwebhooks\"\xb6\x01\n" +
+	"\x19UpdateAuditWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\aenabled\x18\x05 \x01(\bR\aenabled\x12#\n" +
+	"\rrotate_secret\x18\x06 \x01(\bR\frotateSecret\"r\n" +
+	"\x1aUpdateAuditWebhookResponse\x12<\n" +
+	"\awebhook\x18\x01 \x01(\v2\".ztcp.auditwebhook.v1.AuditWebhookR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"+\n" +
+	"\x19DeleteAuditWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1c\n" +
+	"\x1aDeleteAuditWebhookResponse\"\xa8\x01\n" +
+	"\x1dTestAuditWebhookFilterRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1a\n" +
+	"\bresource\x18\x03 \x01(\tR\bresource\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\":\n" +
+	"\x1eTestAuditWebhookFilterResponse\x12\x18\n" +
+	"\amatches\x18\x01 \x01(\bR\amatches2\x86\x05\n" +
+	"\x13AuditWebhookService\x12w\n" +
+	"\x12CreateAuditWebhook\x12/.ztcp.auditwebhook.v1.CreateAuditWebhookRequest\x1a0.ztcp.auditwebhook.v1.CreateAuditWebhookResponse\x12y\n" +
+	"\x11ListAuditWebhooks\x12..ztcp.auditwebhook.v1.ListAuditWebhooksRequest\x1a/.ztcp.auditwebhook.v1.ListAuditWebhooksResponse\"\x03\x90\x02\x01\x12w\n" +
+	"\x12UpdateAuditWebhook\x12/.ztcp.auditwebhook.v1.UpdateAuditWebhookRequest\x1a0.ztcp.auditwebhook.v1.UpdateAuditWebhookResponse\x12w\n" +
+	"\x12DeleteAuditWebhook\x12/.ztcp.auditwebhook.v1.DeleteAuditWebhookRequest\x1a0.ztcp.auditwebhook.v1.DeleteAuditWebhookResponse\x12\x88\x01\n" +
+	"\x16TestAuditWebhookFilter\x123.ztcp.auditwebhook.v1.TestAuditWebhookFilterRequest\x1a4.ztcp.auditwebhook.v1.TestAuditWebhookFilterResponse\"\x03\x90\x02\x01BOZMzero-trust-control-plane/backend/api/generated/auditwebhook/v1;auditwebhookv1b\x06proto3"
+
+var (
+	file_auditwebhook_auditwebhook_proto_rawDescOnce sync.Once
+	file_auditwebhook_auditwebhook_proto_rawDescData []byte
+)
+
+func file_auditwebhook_auditwebhook_proto_rawDescGZIP() []byte {
+	file_auditwebhook_auditwebhook_proto_rawDescOnce.Do(func() {
+		file_auditwebhook_auditwebhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auditwebhook_auditwebhook_proto_rawDesc), len(file_auditwebhook_auditwebhook_proto_rawDesc)))
+	})
+	return file_auditwebhook_auditwebhook_proto_rawDescData
+}
+
+var file_auditwebhook_auditwebhook_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_auditwebhook_auditwebhook_proto_goTypes = []any{
+	(*AuditWebhook)(nil),                   // 0: ztcp.auditwebhook.v1.AuditWebhook
+	(*CreateAuditWebhookRequest)(nil),      // 1: ztcp.auditwebhook.v1.CreateAuditWebhookRequest
+	(*CreateAuditWebhookResponse)(nil),     // 2: ztcp.auditwebhook.v1.CreateAuditWebhookResponse
+	(*ListAuditWebhooksRequest)(nil),       // 3: ztcp.auditwebhook.v1.ListAuditWebhooksRequest
+	(*ListAuditWebhooksResponse)(nil),      // 4: ztcp.auditwebhook.v1.ListAuditWebhooksResponse
+	(*UpdateAuditWebhookRequest)(nil),      // 5: ztcp.auditwebhook.v1.UpdateAuditWebhookRequest
+	(*UpdateAuditWebhookResponse)(nil),     // 6: ztcp.auditwebhook.v1.UpdateAuditWebhookResponse
+	(*DeleteAuditWebhookRequest)(nil),      // 7: ztcp.auditwebhook.v1.DeleteAuditWebhookRequest
+	(*DeleteAuditWebhookResponse)(nil),     // 8: ztcp.auditwebhook.v1.DeleteAuditWebhookResponse
+	(*TestAuditWebhookFilterRequest)(nil),  // 9: ztcp.auditwebhook.v1.TestAuditWebhookFilterRequest
+	(*TestAuditWebhookFilterResponse)(nil), // 10: ztcp.auditwebhook.v1.TestAuditWebhookFilterResponse
+	(*timestamppb.Timestamp)(nil),          // 11: google.protobuf.Timestamp
+}
+var file_auditwebhook_auditwebhook_proto_depIdxs = []int32{
+	11, // 0: ztcp.auditwebhook.v1.AuditWebhook.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: ztcp.auditwebhook.v1.AuditWebhook.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: ztcp.auditwebhook.v1.CreateAuditWebhookResponse.webhook:type_name -> ztcp.auditwebhook.v1.AuditWebhook
+	0,  // 3: ztcp.auditwebhook.v1.ListAuditWebhooksResponse.webhooks:type_name -> ztcp.auditwebhook.v1.AuditWebhook
+	0,  // 4: ztcp.auditwebhook.v1.UpdateAuditWebhookResponse.webhook:type_name -> ztcp.auditwebhook.v1.AuditWebhook
+	1,  // 5: ztcp.auditwebhook.v1.AuditWebhookService.CreateAuditWebhook:input_type -> ztcp.auditwebhook.v1.CreateAuditWebhookRequest
+	3,  // 6: ztcp.auditwebhook.v1.AuditWebhookService.ListAuditWebhooks:input_type -> ztcp.auditwebhook.v1.ListAuditWebhooksRequest
+	5,  // 7: ztcp.auditwebhook.v1.AuditWebhookService.UpdateAuditWebhook:input_type -> ztcp.auditwebhook.v1.UpdateAuditWebhookRequest
+	7,  // 8: ztcp.auditwebhook.v1.AuditWebhookService.DeleteAuditWebhook:input_type -> ztcp.auditwebhook.v1.DeleteAuditWebhookRequest
+	9,  // 9: ztcp.auditwebhook.v1.AuditWebhookService.TestAuditWebhookFilter:input_type -> ztcp.auditwebhook.v1.TestAuditWebhookFilterRequest
+	2,  // 10: ztcp.auditwebhook.v1.AuditWebhookService.CreateAuditWebhook:output_type -> ztcp.auditwebhook.v1.CreateAuditWebhookResponse
+	4,  // 11: ztcp.auditwebhook.v1.AuditWebhookService.ListAuditWebhooks:output_type -> ztcp.auditwebhook.v1.ListAuditWebhooksResponse
+	6,  // 12: ztcp.auditwebhook.v1.AuditWebhookService.UpdateAuditWebhook:output_type -> ztcp.auditwebhook.v1.UpdateAuditWebhookResponse
+	8,  // 13: ztcp.auditwebhook.v1.AuditWebhookService.DeleteAuditWebhook:output_type -> ztcp.auditwebhook.v1.DeleteAuditWebhookResponse
+	10, // 14: ztcp.auditwebhook.v1.AuditWebhookService.TestAuditWebhookFilter:output_type -> ztcp.auditwebhook.v1.TestAuditWebhookFilterResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_auditwebhook_auditwebhook_proto_init() }
+func file_auditwebhook_auditwebhook_proto_init() {
+	if File_auditwebhook_auditwebhook_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auditwebhook_auditwebhook_proto_rawDesc), len(file_auditwebhook_auditwebhook_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_auditwebhook_auditwebhook_proto_goTypes,
+		DependencyIndexes: file_auditwebhook_auditwebhook_proto_depIdxs,
+		MessageInfos:      file_auditwebhook_auditwebhook_proto_msgTypes,
+	}.Build()
+	File_auditwebhook_auditwebhook_proto = out.File
+	file_auditwebhook_auditwebhook_proto_goTypes = nil
+	file_auditwebhook_auditwebhook_proto_depIdxs = nil
+}
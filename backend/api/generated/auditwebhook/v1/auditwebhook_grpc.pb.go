@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: auditwebhook/auditwebhook.proto
+
+package auditwebhookv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AuditWebhookService_CreateAuditWebhook_FullMethodName     = "/ztcp.auditwebhook.v1.AuditWebhookService/CreateAuditWebhook"
+	AuditWebhookService_ListAuditWebhooks_FullMethodName      = "/ztcp.auditwebhook.v1.AuditWebhookService/ListAuditWebhooks"
+	AuditWebhookService_UpdateAuditWebhook_FullMethodName     = "/ztcp.auditwebhook.v1.AuditWebhookService/UpdateAuditWebhook"
+	AuditWebhookService_DeleteAuditWebhook_FullMethodName     = "/ztcp.auditwebhook.v1.AuditWebhookService/DeleteAuditWebhook"
+	AuditWebhookService_TestAuditWebhookFilter_FullMethodName = "/ztcp.auditwebhook.v1.AuditWebhookService/TestAuditWebhookFilter"
+)
+
+// AuditWebhookServiceClient is the client API for AuditWebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuditWebhookService manages an org's audit webhooks. Every RPC requires an org admin or owner. Creating and
+// updating webhooks requires a plan with audit streaming. Invalid filters fail with InvalidArgument and the position
+// of the error.
+type AuditWebhookServiceClient interface {
+	CreateAuditWebhook(ctx context.Context, in *CreateAuditWebhookRequest, opts ...grpc.CallOption) (*CreateAuditWebhookResponse, error)
+	ListAuditWebhooks(ctx context.Context, in *ListAuditWebhooksRequest, opts ...grpc.CallOption) (*ListAuditWebhooksResponse, error)
+	UpdateAuditWebhook(ctx context.Context, in *UpdateAuditWebhookRequest, opts ...grpc.CallOption) (*UpdateAuditWebhookResponse, error)
+	DeleteAuditWebhook(ctx context.Context, in *DeleteAuditWebhookRequest, opts ...grpc.CallOption) (*DeleteAuditWebhookResponse, error)
+	// TestAuditWebhookFilter reports whether a filter matches a sample event, for trying out filters before saving
+	// them. It does not deliver anything.
+	TestAuditWebhookFilter(ctx context.Context, in *TestAuditWebhookFilterRequest, opts ...grpc.CallOption) (*TestAuditWebhookFilterResponse, error)
+}
+
+type auditWebhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditWebhookServiceClient(cc grpc.ClientConnInterface) AuditWebhookServiceClient {
+	return &auditWebhookServiceClient{cc}
+}
+
+func (c *auditWebhookServiceClient) CreateAuditWebhook(ctx context.Context, in *CreateAuditWebhookRequest, opts ...grpc.CallOption) (*CreateAuditWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAuditWebhookResponse)
+	err := c.cc.Invoke(ctx, AuditWebhookService_CreateAuditWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditWebhookServiceClient) ListAuditWebhooks(ctx context.Context, in *ListAuditWebhooksRequest, opts ...grpc.CallOption) (*ListAuditWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditWebhooksResponse)
+	err := c.cc.Invoke(ctx, AuditWebhookService_ListAuditWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditWebhookServiceClient) UpdateAuditWebhook(ctx context.Context, in *UpdateAuditWebhookRequest, opts ...grpc.CallOption) (*UpdateAuditWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAuditWebhookResponse)
+	err := c.cc.Invoke(ctx, AuditWebhookService_UpdateAuditWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditWebhookServiceClient) DeleteAuditWebhook(ctx context.Context, in *DeleteAuditWebhookRequest, opts ...grpc.CallOption) (*DeleteAuditWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAuditWebhookResponse)
+	err := c.cc.Invoke(ctx, AuditWebhookService_DeleteAuditWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auditWebhookServiceClient) TestAuditWebhookFilter(ctx context.Context, in *TestAuditWebhookFilterRequest, opts ...grpc.CallOption) (*TestAuditWebhookFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestAuditWebhookFilterResponse)
+	err := c.cc.Invoke(ctx, AuditWebhookService_TestAuditWebhookFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditWebhookServiceServer is the server API for AuditWebhookService service.
+// All implementations must embed UnimplementedAuditWebhookServiceServer
+// for forward compatibility.
+//
+// AuditWebhookService manages an org's audit webhooks. Every RPC requires an org admin or owner. Creating and
+// updating webhooks requires a plan with audit streaming. Invalid filters fail with InvalidArgument and the position
+// of the error.
+type AuditWebhookServiceServer interface {
+	CreateAuditWebhook(context.Context, *CreateAuditWebhookRequest) (*CreateAuditWebhookResponse, error)
+	ListAuditWebhooks(context.Context, *ListAuditWebhooksRequest) (*ListAuditWebhooksResponse, error)
+	UpdateAuditWebhook(context.Context, *UpdateAuditWebhookRequest) (*UpdateAuditWebhookResponse, error)
+	DeleteAuditWebhook(context.Context, *DeleteAuditWebhookRequest) (*DeleteAuditWebhookResponse, error)
+	// TestAuditWebhookFilter reports whether a filter matches a sample event, for trying out filters before saving
+	// them. It does not deliver anything.
+	TestAuditWebhookFilter(context.Context, *TestAuditWebhookFilterRequest) (*TestAuditWebhookFilterResponse, error)
+	mustEmbedUnimplementedAuditWebhookServiceServer()
+}
+
+// UnimplementedAuditWebhookServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuditWebhookServiceServer struct{}
+
+func (UnimplementedAuditWebhookServiceServer) CreateAuditWebhook(context.Context, *CreateAuditWebhookRequest) (*CreateAuditWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAuditWebhook not implemented")
+}
+func (UnimplementedAuditWebhookServiceServer) ListAuditWebhooks(context.Context, *ListAuditWebhooksRequest) (*ListAuditWebhooksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAuditWebhooks not implemented")
+}
+func (UnimplementedAuditWebhookServiceServer) UpdateAuditWebhook(context.Context, *UpdateAuditWebhookRequest) (*UpdateAuditWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateAuditWebhook not implemented")
+}
+func (UnimplementedAuditWebhookServiceServer) DeleteAuditWebhook(context.Context, *DeleteAuditWebhookRequest) (*DeleteAuditWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAuditWebhook not implemented")
+}
+func (UnimplementedAuditWebhookServiceServer) TestAuditWebhookFilter(context.Context, *TestAuditWebhookFilterRequest) (*TestAuditWebhookFilterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TestAuditWebhookFilter not implemented")
+}
+func (UnimplementedAuditWebhookServiceServer) mustEmbedUnimplementedAuditWebhookServiceServer() {}
+func (UnimplementedAuditWebhookServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeAuditWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditWebhookServiceServer will
+// result in compilation errors.
+type UnsafeAuditWebhookServiceServer interface {
+	mustEmbedUnimplementedAuditWebhookServiceServer()
+}
+
+func RegisterAuditWebhookServiceServer(s grpc.ServiceRegistrar, srv AuditWebhookServiceServer) {
+	// If the following call panics, it indicates UnimplementedAuditWebhookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuditWebhookService_ServiceDesc, srv)
+}
+
+func _AuditWebhookService_CreateAuditWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuditWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditWebhookServiceServer).CreateAuditWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditWebhookService_CreateAuditWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditWebhookServiceServer).CreateAuditWebhook(ctx, req.(*CreateAuditWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditWebhookService_ListAuditWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditWebhookServiceServer).ListAuditWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditWebhookService_ListAuditWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditWebhookServiceServer).ListAuditWebhooks(ctx, req.(*ListAuditWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditWebhookService_UpdateAuditWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAuditWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditWebhookServiceServer).UpdateAuditWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditWebhookService_UpdateAuditWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditWebhookServiceServer).UpdateAuditWebhook(ctx, req.(*UpdateAuditWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditWebhookService_DeleteAuditWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAuditWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditWebhookServiceServer).DeleteAuditWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditWebhookService_DeleteAuditWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditWebhookServiceServer).DeleteAuditWebhook(ctx, req.(*DeleteAuditWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuditWebhookService_TestAuditWebhookFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestAuditWebhookFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditWebhookServiceServer).TestAuditWebhookFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditWebhookService_TestAuditWebhookFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditWebhookServiceServer).TestAuditWebhookFilter(ctx, req.(*TestAuditWebhookFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuditWebhookService_ServiceDesc is the grpc.ServiceDesc for AuditWebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditWebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.auditwebhook.v1.AuditWebhookService",
+	HandlerType: (*AuditWebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAuditWebhook",
+			Handler:    _AuditWebhookService_CreateAuditWebhook_Handler,
+		},
+		{
+			MethodName: "ListAuditWebhooks",
+			Handler:    _AuditWebhookService_ListAuditWebhooks_Handler,
+		},
+		{
+			MethodName: "UpdateAuditWebhook",
+			Handler:    _AuditWebhookService_UpdateAuditWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteAuditWebhook",
+			Handler:    _AuditWebhookService_DeleteAuditWebhook_Handler,
+		},
+		{
+			MethodName: "TestAuditWebhookFilter",
+			Handler:    _AuditWebhookService_TestAuditWebhookFilter_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auditwebhook/auditwebhook.proto",
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
 )
 
 const (
@@ -25,10 +26,16 @@ const (
 
 // RegisterRequest carries email, password, and optional name for new user registration.
 type RegisterRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // optional
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Email    string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // optional
+	// Invitation token from InvitationService.CreateInvitation; required while registration is invite-only. The
+	// user is added to the invitation's org (org_id in the response).
+	InviteToken string `protobuf:"bytes,4,opt,name=invite_token,json=inviteToken,proto3" json:"invite_token,omitempty"`
+	// CAPTCHA response token (e.g. Cloudflare Turnstile); required for open registration without an invitation when
+	// the server verifies CAPTCHAs (TURNSTILE_SECRET_KEY).
+	CaptchaToken  string `protobuf:"bytes,5,opt,name=captcha_token,json=captchaToken,proto3" json:"captcha_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -84,15 +91,35 @@ func (x *RegisterRequest) GetName() string {
 	return ""
 }
 
+func (x *RegisterRequest) GetInviteToken() string {
+	if x != nil {
+		return x.InviteToken
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetCaptchaToken() string {
+	if x != nil {
+		return x.CaptchaToken
+	}
+	return ""
+}
+
 // LoginRequest carries credentials for authentication.
 type LoginRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
-	Email             string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Email             string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // or the user's username when the server enables usernames (USERNAME_LOGIN_ENABLED)
 	Password          string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	OrgId             string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`                                     // required; org-scoped login
 	DeviceFingerprint string                 `protobuf:"bytes,4,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"` // optional; used to get-or-create device for session
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	PopPublicKey      string                 `protobuf:"bytes,5,opt,name=pop_public_key,json=popPublicKey,proto3" json:"pop_public_key,omitempty"`              // optional; public JWK (EC P-256 or RSA) that the session's refresh tokens are bound to
+	MfaMethod         string                 `protobuf:"bytes,6,opt,name=mfa_method,json=mfaMethod,proto3" json:"mfa_method,omitempty"`                         // optional; MFA method to use if MFA is required (one of MFARequired.available_methods); default is the org's preferred method
+	TrustDays         int32                  `protobuf:"varint,7,opt,name=trust_days,json=trustDays,proto3" json:"trust_days,omitempty"`                        // optional; remember the device for this many days after MFA, at most the org's trust TTL; 0 = the org's trust TTL
+	// optional; a public or shared device: no device is registered or trusted (device_fingerprint and trust_days are
+	// ignored), MFA is always required and the session is ephemeral (short-lived, revoked when idle)
+	PublicDevice  bool `protobuf:"varint,8,opt,name=public_device,json=publicDevice,proto3" json:"public_device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LoginRequest) Reset() {
@@ -153,11 +180,41 @@ func (x *LoginRequest) GetDeviceFingerprint() string {
 	return ""
 }
 
+func (x *LoginRequest) GetPopPublicKey() string {
+	if x != nil {
+		return x.PopPublicKey
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetMfaMethod() string {
+	if x != nil {
+		return x.MfaMethod
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetTrustDays() int32 {
+	if x != nil {
+		return x.TrustDays
+	}
+	return 0
+}
+
+func (x *LoginRequest) GetPublicDevice() bool {
+	if x != nil {
+		return x.PublicDevice
+	}
+	return false
+}
+
 // RefreshRequest carries the refresh token and optional device fingerprint for device-trust policy.
 type RefreshRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	RefreshToken      string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	DeviceFingerprint string                 `protobuf:"bytes,2,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"` // optional; used to evaluate device-trust policy (same as Login)
+	PopProof          string                 `protobuf:"bytes,3,opt,name=pop_proof,json=popProof,proto3" json:"pop_proof,omitempty"`                            // required when the session is key-bound; "pop+jwt" proof over a CreateRefreshNonce nonce
+	MfaMethod         string                 `protobuf:"bytes,4,opt,name=mfa_method,json=mfaMethod,proto3" json:"mfa_method,omitempty"`                         // optional; same as LoginRequest.mfa_method
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -206,6 +263,118 @@ func (x *RefreshRequest) GetDeviceFingerprint() string {
 	return ""
 }
 
+func (x *RefreshRequest) GetPopProof() string {
+	if x != nil {
+		return x.PopProof
+	}
+	return ""
+}
+
+func (x *RefreshRequest) GetMfaMethod() string {
+	if x != nil {
+		return x.MfaMethod
+	}
+	return ""
+}
+
+// CreateRefreshNonceRequest asks for a nonce to sign in the proof for the next Refresh of a key-bound session.
+type CreateRefreshNonceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRefreshNonceRequest) Reset() {
+	*x = CreateRefreshNonceRequest{}
+	mi := &file_auth_auth_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRefreshNonceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRefreshNonceRequest) ProtoMessage() {}
+
+func (x *CreateRefreshNonceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRefreshNonceRequest.ProtoReflect.Descriptor instead.
+func (*CreateRefreshNonceRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateRefreshNonceRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// CreateRefreshNonceResponse returns a short-lived, single-session nonce.
+type CreateRefreshNonceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nonce         string                 `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRefreshNonceResponse) Reset() {
+	*x = CreateRefreshNonceResponse{}
+	mi := &file_auth_auth_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRefreshNonceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRefreshNonceResponse) ProtoMessage() {}
+
+func (x *CreateRefreshNonceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRefreshNonceResponse.ProtoReflect.Descriptor instead.
+func (*CreateRefreshNonceResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateRefreshNonceResponse) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *CreateRefreshNonceResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 // RefreshResponse is the result of Refresh: either tokens, MFA required, or phone required (device-trust policy).
 type RefreshResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -221,7 +390,7 @@ type RefreshResponse struct {
 
 func (x *RefreshResponse) Reset() {
 	*x = RefreshResponse{}
-	mi := &file_auth_auth_proto_msgTypes[3]
+	mi := &file_auth_auth_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -233,7 +402,7 @@ func (x *RefreshResponse) String() string {
 func (*RefreshResponse) ProtoMessage() {}
 
 func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[3]
+	mi := &file_auth_auth_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -246,7 +415,7 @@ func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshResponse.ProtoReflect.Descriptor instead.
 func (*RefreshResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{3}
+	return file_auth_auth_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RefreshResponse) GetResult() isRefreshResponse_Result {
@@ -315,7 +484,7 @@ type LogoutRequest struct {
 
 func (x *LogoutRequest) Reset() {
 	*x = LogoutRequest{}
-	mi := &file_auth_auth_proto_msgTypes[4]
+	mi := &file_auth_auth_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -327,7 +496,7 @@ func (x *LogoutRequest) String() string {
 func (*LogoutRequest) ProtoMessage() {}
 
 func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[4]
+	mi := &file_auth_auth_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,7 +509,7 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
 func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{4}
+	return file_auth_auth_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *LogoutRequest) GetRefreshToken() string {
@@ -351,17 +520,20 @@ func (x *LogoutRequest) GetRefreshToken() string {
 }
 
 // VerifyCredentialsRequest carries email and password for credential verification only (no session).
+// Optional org_id and device_fingerprint let the caller learn whether a Login would require MFA.
 type VerifyCredentialsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Email             string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password          string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	OrgId             string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`                                     // optional; when set, the user must be a member and MFA policy is evaluated
+	DeviceFingerprint string                 `protobuf:"bytes,4,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"` // optional; used with org_id for the MFA evaluation
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *VerifyCredentialsRequest) Reset() {
 	*x = VerifyCredentialsRequest{}
-	mi := &file_auth_auth_proto_msgTypes[5]
+	mi := &file_auth_auth_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -373,7 +545,7 @@ func (x *VerifyCredentialsRequest) String() string {
 func (*VerifyCredentialsRequest) ProtoMessage() {}
 
 func (x *VerifyCredentialsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[5]
+	mi := &file_auth_auth_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,7 +558,7 @@ func (x *VerifyCredentialsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyCredentialsRequest.ProtoReflect.Descriptor instead.
 func (*VerifyCredentialsRequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{5}
+	return file_auth_auth_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *VerifyCredentialsRequest) GetEmail() string {
@@ -403,17 +575,36 @@ func (x *VerifyCredentialsRequest) GetPassword() string {
 	return ""
 }
 
-// VerifyCredentialsResponse returns user_id when credentials are valid. Used for org-creation flow.
+func (x *VerifyCredentialsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *VerifyCredentialsRequest) GetDeviceFingerprint() string {
+	if x != nil {
+		return x.DeviceFingerprint
+	}
+	return ""
+}
+
+// VerifyCredentialsResponse reports the result of a credential check without issuing tokens. Used for org-creation flow.
+// Unknown email or wrong password returns UNAUTHENTICATED; a correct password on a disabled account returns
+// valid=false with account_status. Rate-limited per client IP and per email (RESOURCE_EXHAUSTED).
 type VerifyCredentialsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UserId             string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Valid              bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`                                                         // password correct and account active
+	MfaWouldBeRequired bool                   `protobuf:"varint,3,opt,name=mfa_would_be_required,json=mfaWouldBeRequired,proto3" json:"mfa_would_be_required,omitempty"` // only evaluated when org_id is set
+	AccountStatus      string                 `protobuf:"bytes,4,opt,name=account_status,json=accountStatus,proto3" json:"account_status,omitempty"`                     // "active" or "disabled"
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *VerifyCredentialsResponse) Reset() {
 	*x = VerifyCredentialsResponse{}
-	mi := &file_auth_auth_proto_msgTypes[6]
+	mi := &file_auth_auth_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -425,7 +616,7 @@ func (x *VerifyCredentialsResponse) String() string {
 func (*VerifyCredentialsResponse) ProtoMessage() {}
 
 func (x *VerifyCredentialsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[6]
+	mi := &file_auth_auth_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -438,7 +629,7 @@ func (x *VerifyCredentialsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyCredentialsResponse.ProtoReflect.Descriptor instead.
 func (*VerifyCredentialsResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{6}
+	return file_auth_auth_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VerifyCredentialsResponse) GetUserId() string {
@@ -448,33 +639,53 @@ func (x *VerifyCredentialsResponse) GetUserId() string {
 	return ""
 }
 
-// AuthResponse returns session tokens and user/org context. Used by Register, Login, Refresh, and VerifyMFA.
-type AuthResponse struct {
+func (x *VerifyCredentialsResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyCredentialsResponse) GetMfaWouldBeRequired() bool {
+	if x != nil {
+		return x.MfaWouldBeRequired
+	}
+	return false
+}
+
+func (x *VerifyCredentialsResponse) GetAccountStatus() string {
+	if x != nil {
+		return x.AccountStatus
+	}
+	return ""
+}
+
+// DiscoverOrganizationsRequest asks which orgs an email (or username) can sign in to, before Login. Needs no access
+// token. Without a password the answer depends only on the email's domain; with the password it lists the account's
+// orgs.
+type DiscoverOrganizationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	OrgId         string                 `protobuf:"bytes,5,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // optional
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuthResponse) Reset() {
-	*x = AuthResponse{}
-	mi := &file_auth_auth_proto_msgTypes[7]
+func (x *DiscoverOrganizationsRequest) Reset() {
+	*x = DiscoverOrganizationsRequest{}
+	mi := &file_auth_auth_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuthResponse) String() string {
+func (x *DiscoverOrganizationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuthResponse) ProtoMessage() {}
+func (*DiscoverOrganizationsRequest) ProtoMessage() {}
 
-func (x *AuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[7]
+func (x *DiscoverOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,70 +696,49 @@ func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
-func (*AuthResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *AuthResponse) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-func (x *AuthResponse) GetRefreshToken() string {
-	if x != nil {
-		return x.RefreshToken
-	}
-	return ""
-}
-
-func (x *AuthResponse) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
+// Deprecated: Use DiscoverOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *AuthResponse) GetUserId() string {
+func (x *DiscoverOrganizationsRequest) GetEmail() string {
 	if x != nil {
-		return x.UserId
+		return x.Email
 	}
 	return ""
 }
 
-func (x *AuthResponse) GetOrgId() string {
+func (x *DiscoverOrganizationsRequest) GetPassword() string {
 	if x != nil {
-		return x.OrgId
+		return x.Password
 	}
 	return ""
 }
 
-// MFARequired is returned when Login requires MFA before issuing a session (risk-based device trust).
-type MFARequired struct {
+// DiscoveredOrganization is an org offered at sign-in.
+type DiscoveredOrganization struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
-	PhoneMask     string                 `protobuf:"bytes,2,opt,name=phone_mask,json=phoneMask,proto3" json:"phone_mask,omitempty"` // e.g. last 4 digits for display
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MFARequired) Reset() {
-	*x = MFARequired{}
-	mi := &file_auth_auth_proto_msgTypes[8]
+func (x *DiscoveredOrganization) Reset() {
+	*x = DiscoveredOrganization{}
+	mi := &file_auth_auth_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MFARequired) String() string {
+func (x *DiscoveredOrganization) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MFARequired) ProtoMessage() {}
+func (*DiscoveredOrganization) ProtoMessage() {}
 
-func (x *MFARequired) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[8]
+func (x *DiscoveredOrganization) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -559,48 +749,274 @@ func (x *MFARequired) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MFARequired.ProtoReflect.Descriptor instead.
-func (*MFARequired) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use DiscoveredOrganization.ProtoReflect.Descriptor instead.
+func (*DiscoveredOrganization) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *MFARequired) GetChallengeId() string {
+func (x *DiscoveredOrganization) GetOrgId() string {
 	if x != nil {
-		return x.ChallengeId
+		return x.OrgId
 	}
 	return ""
 }
 
-func (x *MFARequired) GetPhoneMask() string {
+func (x *DiscoveredOrganization) GetName() string {
 	if x != nil {
-		return x.PhoneMask
+		return x.Name
 	}
 	return ""
 }
 
-// PhoneRequired is returned when Login requires MFA but the user has no phone; client collects phone then calls SubmitPhoneAndRequestMFA.
-type PhoneRequired struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	IntentId      string                 `protobuf:"bytes,1,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
+// DiscoverOrganizationsResponse tells the sign-in screen what to ask next: "password" (organizations holds the org
+// that verified the email's domain, if any) or "choose_org" (organizations holds the orgs the account may sign in to;
+// pass one as org_id to Login).
+type DiscoverOrganizationsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	NextStep      string                    `protobuf:"bytes,1,opt,name=next_step,json=nextStep,proto3" json:"next_step,omitempty"`
+	Organizations []*DiscoveredOrganization `protobuf:"bytes,2,rep,name=organizations,proto3" json:"organizations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PhoneRequired) Reset() {
-	*x = PhoneRequired{}
-	mi := &file_auth_auth_proto_msgTypes[9]
+func (x *DiscoverOrganizationsResponse) Reset() {
+	*x = DiscoverOrganizationsResponse{}
+	mi := &file_auth_auth_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PhoneRequired) String() string {
+func (x *DiscoverOrganizationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PhoneRequired) ProtoMessage() {}
+func (*DiscoverOrganizationsResponse) ProtoMessage() {}
 
-func (x *PhoneRequired) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[9]
+func (x *DiscoverOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*DiscoverOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DiscoverOrganizationsResponse) GetNextStep() string {
+	if x != nil {
+		return x.NextStep
+	}
+	return ""
+}
+
+func (x *DiscoverOrganizationsResponse) GetOrganizations() []*DiscoveredOrganization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+// AuthResponse returns session tokens and user/org context. Used by Register, Login, Refresh, VerifyMFA and
+// PollDeviceAuthorization.
+type AuthResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken      string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken     string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UserId           string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId            string                 `protobuf:"bytes,5,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	PasswordBreached bool                   `protobuf:"varint,6,opt,name=password_breached,json=passwordBreached,proto3" json:"password_breached,omitempty"` // Register only: the password appears in a breach corpus and policy is warn
+	RecoveryCodes    []string               `protobuf:"bytes,7,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`           // VerifyMFA only, when it enrolled the user's first MFA factor; shown once
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
+	mi := &file_auth_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthResponse) ProtoMessage() {}
+
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AuthResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AuthResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetPasswordBreached() bool {
+	if x != nil {
+		return x.PasswordBreached
+	}
+	return false
+}
+
+func (x *AuthResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+// MFARequired is returned when Login requires MFA before issuing a session (risk-based device trust).
+type MFARequired struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId      string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	PhoneMask        string                 `protobuf:"bytes,2,opt,name=phone_mask,json=phoneMask,proto3" json:"phone_mask,omitempty"`                      // e.g. last 4 digits for display
+	Method           string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`                                             // MFA method that issued the challenge, e.g. "sms_otp"
+	AvailableMethods []string               `protobuf:"bytes,4,rep,name=available_methods,json=availableMethods,proto3" json:"available_methods,omitempty"` // methods the user can choose from (org preference order); pass one as mfa_method to switch
+	CodeNotSent      bool                   `protobuf:"varint,5,opt,name=code_not_sent,json=codeNotSent,proto3" json:"code_not_sent,omitempty"`             // the code could not be sent; call ResendMFACode for this challenge (after the resend cooldown)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MFARequired) Reset() {
+	*x = MFARequired{}
+	mi := &file_auth_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MFARequired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFARequired) ProtoMessage() {}
+
+func (x *MFARequired) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MFARequired.ProtoReflect.Descriptor instead.
+func (*MFARequired) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MFARequired) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *MFARequired) GetPhoneMask() string {
+	if x != nil {
+		return x.PhoneMask
+	}
+	return ""
+}
+
+func (x *MFARequired) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *MFARequired) GetAvailableMethods() []string {
+	if x != nil {
+		return x.AvailableMethods
+	}
+	return nil
+}
+
+func (x *MFARequired) GetCodeNotSent() bool {
+	if x != nil {
+		return x.CodeNotSent
+	}
+	return false
+}
+
+// PhoneRequired is returned when Login requires MFA but the user has no phone; client collects phone then calls SubmitPhoneAndRequestMFA.
+type PhoneRequired struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntentId      string                 `protobuf:"bytes,1,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhoneRequired) Reset() {
+	*x = PhoneRequired{}
+	mi := &file_auth_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhoneRequired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhoneRequired) ProtoMessage() {}
+
+func (x *PhoneRequired) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -613,7 +1029,7 @@ func (x *PhoneRequired) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PhoneRequired.ProtoReflect.Descriptor instead.
 func (*PhoneRequired) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{9}
+	return file_auth_auth_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PhoneRequired) GetIntentId() string {
@@ -623,7 +1039,71 @@ func (x *PhoneRequired) GetIntentId() string {
 	return ""
 }
 
-// LoginResponse is the result of Login: either tokens (success / trusted device), MFA required (challenge_id), or phone required (intent_id).
+// ApprovalRequired is returned when Login is flagged as high-risk and held until an org admin approves it (login
+// holds). The client tells the user to wait and polls ResumeLogin with hold_id and hold_token until it stops failing
+// with FAILED_PRECONDITION.
+type ApprovalRequired struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	HoldToken     string                 `protobuf:"bytes,2,opt,name=hold_token,json=holdToken,proto3" json:"hold_token,omitempty"` // shown once; proves ResumeLogin comes from the client that signed in
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // the sign-in is dropped if no admin approves it by then
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalRequired) Reset() {
+	*x = ApprovalRequired{}
+	mi := &file_auth_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalRequired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalRequired) ProtoMessage() {}
+
+func (x *ApprovalRequired) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalRequired.ProtoReflect.Descriptor instead.
+func (*ApprovalRequired) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ApprovalRequired) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *ApprovalRequired) GetHoldToken() string {
+	if x != nil {
+		return x.HoldToken
+	}
+	return ""
+}
+
+func (x *ApprovalRequired) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// LoginResponse is the result of Login: either tokens (success / trusted device), MFA required (challenge_id), phone
+// required (intent_id), or approval required (hold_id).
 type LoginResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Result:
@@ -631,6 +1111,7 @@ type LoginResponse struct {
 	//	*LoginResponse_Tokens
 	//	*LoginResponse_MfaRequired
 	//	*LoginResponse_PhoneRequired
+	//	*LoginResponse_ApprovalRequired
 	Result        isLoginResponse_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -638,7 +1119,7 @@ type LoginResponse struct {
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_auth_auth_proto_msgTypes[10]
+	mi := &file_auth_auth_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -650,7 +1131,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[10]
+	mi := &file_auth_auth_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -663,7 +1144,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{10}
+	return file_auth_auth_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *LoginResponse) GetResult() isLoginResponse_Result {
@@ -682,70 +1163,2738 @@ func (x *LoginResponse) GetTokens() *AuthResponse {
 	return nil
 }
 
-func (x *LoginResponse) GetMfaRequired() *MFARequired {
+func (x *LoginResponse) GetMfaRequired() *MFARequired {
+	if x != nil {
+		if x, ok := x.Result.(*LoginResponse_MfaRequired); ok {
+			return x.MfaRequired
+		}
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetPhoneRequired() *PhoneRequired {
+	if x != nil {
+		if x, ok := x.Result.(*LoginResponse_PhoneRequired); ok {
+			return x.PhoneRequired
+		}
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetApprovalRequired() *ApprovalRequired {
+	if x != nil {
+		if x, ok := x.Result.(*LoginResponse_ApprovalRequired); ok {
+			return x.ApprovalRequired
+		}
+	}
+	return nil
+}
+
+type isLoginResponse_Result interface {
+	isLoginResponse_Result()
+}
+
+type LoginResponse_Tokens struct {
+	Tokens *AuthResponse `protobuf:"bytes,1,opt,name=tokens,proto3,oneof"`
+}
+
+type LoginResponse_MfaRequired struct {
+	MfaRequired *MFARequired `protobuf:"bytes,2,opt,name=mfa_required,json=mfaRequired,proto3,oneof"`
+}
+
+type LoginResponse_PhoneRequired struct {
+	PhoneRequired *PhoneRequired `protobuf:"bytes,3,opt,name=phone_required,json=phoneRequired,proto3,oneof"`
+}
+
+type LoginResponse_ApprovalRequired struct {
+	ApprovalRequired *ApprovalRequired `protobuf:"bytes,4,opt,name=approval_required,json=approvalRequired,proto3,oneof"`
+}
+
+func (*LoginResponse_Tokens) isLoginResponse_Result() {}
+
+func (*LoginResponse_MfaRequired) isLoginResponse_Result() {}
+
+func (*LoginResponse_PhoneRequired) isLoginResponse_Result() {}
+
+func (*LoginResponse_ApprovalRequired) isLoginResponse_Result() {}
+
+// ResumeLoginRequest completes a sign-in held by Login (approval_required).
+type ResumeLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	HoldToken     string                 `protobuf:"bytes,2,opt,name=hold_token,json=holdToken,proto3" json:"hold_token,omitempty"`
+	PopPublicKey  string                 `protobuf:"bytes,3,opt,name=pop_public_key,json=popPublicKey,proto3" json:"pop_public_key,omitempty"` // optional; same as LoginRequest.pop_public_key
+	MfaMethod     string                 `protobuf:"bytes,4,opt,name=mfa_method,json=mfaMethod,proto3" json:"mfa_method,omitempty"`            // optional; same as LoginRequest.mfa_method
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeLoginRequest) Reset() {
+	*x = ResumeLoginRequest{}
+	mi := &file_auth_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeLoginRequest) ProtoMessage() {}
+
+func (x *ResumeLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeLoginRequest.ProtoReflect.Descriptor instead.
+func (*ResumeLoginRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResumeLoginRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *ResumeLoginRequest) GetHoldToken() string {
+	if x != nil {
+		return x.HoldToken
+	}
+	return ""
+}
+
+func (x *ResumeLoginRequest) GetPopPublicKey() string {
+	if x != nil {
+		return x.PopPublicKey
+	}
+	return ""
+}
+
+func (x *ResumeLoginRequest) GetMfaMethod() string {
+	if x != nil {
+		return x.MfaMethod
+	}
+	return ""
+}
+
+// LoginHold is a held sign-in as seen by org admins.
+type LoginHold struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Reasons       []string               `protobuf:"bytes,5,rep,name=reasons,proto3" json:"reasons,omitempty"` // risk signals that held the sign-in, e.g. "ip_blocked"
+	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // pending, approved, denied, completed, or expired (pending or approved past expires_at)
+	DecidedBy     string                 `protobuf:"bytes,8,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	DecidedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // pending: decision deadline; approved: ResumeLogin deadline
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginHold) Reset() {
+	*x = LoginHold{}
+	mi := &file_auth_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginHold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginHold) ProtoMessage() {}
+
+func (x *LoginHold) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginHold.ProtoReflect.Descriptor instead.
+func (*LoginHold) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LoginHold) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LoginHold) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *LoginHold) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginHold) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *LoginHold) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *LoginHold) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LoginHold) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LoginHold) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *LoginHold) GetDecidedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return nil
+}
+
+func (x *LoginHold) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LoginHold) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ApproveLoginRequest lets a held sign-in of the caller's org complete. Requires a Bearer access token of an org
+// owner or admin other than the user signing in.
+type ApproveLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveLoginRequest) Reset() {
+	*x = ApproveLoginRequest{}
+	mi := &file_auth_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveLoginRequest) ProtoMessage() {}
+
+func (x *ApproveLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveLoginRequest.ProtoReflect.Descriptor instead.
+func (*ApproveLoginRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ApproveLoginRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+// ApproveLoginResponse returns the approved hold.
+type ApproveLoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *LoginHold             `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveLoginResponse) Reset() {
+	*x = ApproveLoginResponse{}
+	mi := &file_auth_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveLoginResponse) ProtoMessage() {}
+
+func (x *ApproveLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveLoginResponse.ProtoReflect.Descriptor instead.
+func (*ApproveLoginResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApproveLoginResponse) GetHold() *LoginHold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+// DenyLoginRequest ends a held sign-in of the caller's org. Same caller rules as ApproveLoginRequest.
+type DenyLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyLoginRequest) Reset() {
+	*x = DenyLoginRequest{}
+	mi := &file_auth_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyLoginRequest) ProtoMessage() {}
+
+func (x *DenyLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyLoginRequest.ProtoReflect.Descriptor instead.
+func (*DenyLoginRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DenyLoginRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+// DenyLoginResponse returns the denied hold.
+type DenyLoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *LoginHold             `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyLoginResponse) Reset() {
+	*x = DenyLoginResponse{}
+	mi := &file_auth_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyLoginResponse) ProtoMessage() {}
+
+func (x *DenyLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyLoginResponse.ProtoReflect.Descriptor instead.
+func (*DenyLoginResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DenyLoginResponse) GetHold() *LoginHold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+// ListLoginHoldsRequest lists the pending holds of the caller's org. Requires a Bearer access token of an org owner
+// or admin.
+type ListLoginHoldsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *v1.Pagination         `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoginHoldsRequest) Reset() {
+	*x = ListLoginHoldsRequest{}
+	mi := &file_auth_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoginHoldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoginHoldsRequest) ProtoMessage() {}
+
+func (x *ListLoginHoldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoginHoldsRequest.ProtoReflect.Descriptor instead.
+func (*ListLoginHoldsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListLoginHoldsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// ListLoginHoldsResponse returns pending holds, newest first.
+type ListLoginHoldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holds         []*LoginHold           `protobuf:"bytes,1,rep,name=holds,proto3" json:"holds,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoginHoldsResponse) Reset() {
+	*x = ListLoginHoldsResponse{}
+	mi := &file_auth_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoginHoldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoginHoldsResponse) ProtoMessage() {}
+
+func (x *ListLoginHoldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoginHoldsResponse.ProtoReflect.Descriptor instead.
+func (*ListLoginHoldsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListLoginHoldsResponse) GetHolds() []*LoginHold {
+	if x != nil {
+		return x.Holds
+	}
+	return nil
+}
+
+func (x *ListLoginHoldsResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// StartDeviceAuthorizationRequest starts a device code sign-in for a client that cannot sign in itself (e.g. a CLI or
+// kiosk). Public.
+type StartDeviceAuthorizationRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ClientName        string                 `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`                      // optional; e.g. "ztcp-cli on build-01", reported to the approver (max 100 characters)
+	DeviceFingerprint string                 `protobuf:"bytes,2,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"` // optional; identifies the device once it signs in
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StartDeviceAuthorizationRequest) Reset() {
+	*x = StartDeviceAuthorizationRequest{}
+	mi := &file_auth_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDeviceAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDeviceAuthorizationRequest) ProtoMessage() {}
+
+func (x *StartDeviceAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDeviceAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*StartDeviceAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StartDeviceAuthorizationRequest) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthorizationRequest) GetDeviceFingerprint() string {
+	if x != nil {
+		return x.DeviceFingerprint
+	}
+	return ""
+}
+
+// StartDeviceAuthorizationResponse tells the client what to show the user and how to poll. The user enters user_code
+// at verification_uri (or scans verification_uri_complete as a QR code) on a trusted session and approves it; the
+// client polls PollDeviceAuthorization with device_code every interval_seconds until it gets tokens.
+type StartDeviceAuthorizationResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	DeviceCode              string                 `protobuf:"bytes,1,opt,name=device_code,json=deviceCode,proto3" json:"device_code,omitempty"`                                          // shown once; keep it on the device
+	UserCode                string                 `protobuf:"bytes,2,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`                                                // e.g. "BCDF-GHJK"
+	VerificationUri         string                 `protobuf:"bytes,3,opt,name=verification_uri,json=verificationUri,proto3" json:"verification_uri,omitempty"`                           // empty unless DEVICE_CODE_VERIFICATION_URL is set
+	VerificationUriComplete string                 `protobuf:"bytes,4,opt,name=verification_uri_complete,json=verificationUriComplete,proto3" json:"verification_uri_complete,omitempty"` // verification_uri with user_code, for QR codes
+	ExpiresAt               *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                             // the code must be approved and exchanged by then
+	IntervalSeconds         int32                  `protobuf:"varint,6,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *StartDeviceAuthorizationResponse) Reset() {
+	*x = StartDeviceAuthorizationResponse{}
+	mi := &file_auth_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDeviceAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDeviceAuthorizationResponse) ProtoMessage() {}
+
+func (x *StartDeviceAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDeviceAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*StartDeviceAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StartDeviceAuthorizationResponse) GetDeviceCode() string {
+	if x != nil {
+		return x.DeviceCode
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthorizationResponse) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthorizationResponse) GetVerificationUri() string {
+	if x != nil {
+		return x.VerificationUri
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthorizationResponse) GetVerificationUriComplete() string {
+	if x != nil {
+		return x.VerificationUriComplete
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthorizationResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StartDeviceAuthorizationResponse) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+// PollDeviceAuthorizationRequest exchanges an approved device code for tokens. Fails with FAILED_PRECONDITION while
+// the code awaits approval and PERMISSION_DENIED once it is denied. Public.
+type PollDeviceAuthorizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceCode    string                 `protobuf:"bytes,1,opt,name=device_code,json=deviceCode,proto3" json:"device_code,omitempty"`
+	PopPublicKey  string                 `protobuf:"bytes,2,opt,name=pop_public_key,json=popPublicKey,proto3" json:"pop_public_key,omitempty"` // optional; same as LoginRequest.pop_public_key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollDeviceAuthorizationRequest) Reset() {
+	*x = PollDeviceAuthorizationRequest{}
+	mi := &file_auth_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollDeviceAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollDeviceAuthorizationRequest) ProtoMessage() {}
+
+func (x *PollDeviceAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollDeviceAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*PollDeviceAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PollDeviceAuthorizationRequest) GetDeviceCode() string {
+	if x != nil {
+		return x.DeviceCode
+	}
+	return ""
+}
+
+func (x *PollDeviceAuthorizationRequest) GetPopPublicKey() string {
+	if x != nil {
+		return x.PopPublicKey
+	}
+	return ""
+}
+
+// DeviceAuthorization is a device code sign-in as seen by the user who approved or denied it.
+type DeviceAuthorization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientName    string                 `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`                       // client IP that started the sign-in
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`               // approved or denied
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // who decided; the device signs in as them
+	OrgId         string                 `protobuf:"bytes,6,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceAuthorization) Reset() {
+	*x = DeviceAuthorization{}
+	mi := &file_auth_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceAuthorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceAuthorization) ProtoMessage() {}
+
+func (x *DeviceAuthorization) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceAuthorization.ProtoReflect.Descriptor instead.
+func (*DeviceAuthorization) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeviceAuthorization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeviceAuthorization) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ApproveDeviceCodeRequest lets the device that shows user_code sign in as the caller, in the caller's org. Requires a
+// Bearer access token of a session on a trusted device.
+type ApproveDeviceCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserCode      string                 `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"` // case-insensitive; the dash is optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceCodeRequest) Reset() {
+	*x = ApproveDeviceCodeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceCodeRequest) ProtoMessage() {}
+
+func (x *ApproveDeviceCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceCodeRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceCodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ApproveDeviceCodeRequest) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+// ApproveDeviceCodeResponse returns the approved device authorization.
+type ApproveDeviceCodeResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DeviceAuthorization *DeviceAuthorization   `protobuf:"bytes,1,opt,name=device_authorization,json=deviceAuthorization,proto3" json:"device_authorization,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceCodeResponse) Reset() {
+	*x = ApproveDeviceCodeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceCodeResponse) ProtoMessage() {}
+
+func (x *ApproveDeviceCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceCodeResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceCodeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ApproveDeviceCodeResponse) GetDeviceAuthorization() *DeviceAuthorization {
+	if x != nil {
+		return x.DeviceAuthorization
+	}
+	return nil
+}
+
+// DenyDeviceCodeRequest ends the device code sign-in showing user_code. Requires a Bearer access token.
+type DenyDeviceCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserCode      string                 `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyDeviceCodeRequest) Reset() {
+	*x = DenyDeviceCodeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyDeviceCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyDeviceCodeRequest) ProtoMessage() {}
+
+func (x *DenyDeviceCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyDeviceCodeRequest.ProtoReflect.Descriptor instead.
+func (*DenyDeviceCodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DenyDeviceCodeRequest) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+// DenyDeviceCodeResponse returns the denied device authorization.
+type DenyDeviceCodeResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DeviceAuthorization *DeviceAuthorization   `protobuf:"bytes,1,opt,name=device_authorization,json=deviceAuthorization,proto3" json:"device_authorization,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DenyDeviceCodeResponse) Reset() {
+	*x = DenyDeviceCodeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyDeviceCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyDeviceCodeResponse) ProtoMessage() {}
+
+func (x *DenyDeviceCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyDeviceCodeResponse.ProtoReflect.Descriptor instead.
+func (*DenyDeviceCodeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DenyDeviceCodeResponse) GetDeviceAuthorization() *DeviceAuthorization {
+	if x != nil {
+		return x.DeviceAuthorization
+	}
+	return nil
+}
+
+// RequestMagicLinkRequest asks for a one-time sign-in link by email, for orgs that allow magic links.
+type RequestMagicLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"` // required; the link signs in to this org
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMagicLinkRequest) Reset() {
+	*x = RequestMagicLinkRequest{}
+	mi := &file_auth_auth_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMagicLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMagicLinkRequest) ProtoMessage() {}
+
+func (x *RequestMagicLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RequestMagicLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RequestMagicLinkRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// RequestMagicLinkResponse is the same whether or not a link was sent, so it does not reveal which emails are members.
+type RequestMagicLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMagicLinkResponse) Reset() {
+	*x = RequestMagicLinkResponse{}
+	mi := &file_auth_auth_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMagicLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMagicLinkResponse) ProtoMessage() {}
+
+func (x *RequestMagicLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMagicLinkResponse.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{34}
+}
+
+// CompleteMagicLinkRequest redeems the token from a magic link; it can be redeemed once.
+type CompleteMagicLinkRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Token             string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DeviceFingerprint string                 `protobuf:"bytes,2,opt,name=device_fingerprint,json=deviceFingerprint,proto3" json:"device_fingerprint,omitempty"` // optional; same as LoginRequest.device_fingerprint
+	PopPublicKey      string                 `protobuf:"bytes,3,opt,name=pop_public_key,json=popPublicKey,proto3" json:"pop_public_key,omitempty"`              // optional; same as LoginRequest.pop_public_key
+	MfaMethod         string                 `protobuf:"bytes,4,opt,name=mfa_method,json=mfaMethod,proto3" json:"mfa_method,omitempty"`                         // optional; same as LoginRequest.mfa_method
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CompleteMagicLinkRequest) Reset() {
+	*x = CompleteMagicLinkRequest{}
+	mi := &file_auth_auth_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMagicLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMagicLinkRequest) ProtoMessage() {}
+
+func (x *CompleteMagicLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*CompleteMagicLinkRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CompleteMagicLinkRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CompleteMagicLinkRequest) GetDeviceFingerprint() string {
+	if x != nil {
+		return x.DeviceFingerprint
+	}
+	return ""
+}
+
+func (x *CompleteMagicLinkRequest) GetPopPublicKey() string {
+	if x != nil {
+		return x.PopPublicKey
+	}
+	return ""
+}
+
+func (x *CompleteMagicLinkRequest) GetMfaMethod() string {
+	if x != nil {
+		return x.MfaMethod
+	}
+	return ""
+}
+
+// VerifyMFARequest carries the MFA challenge id and the code from the user; the method that issued the challenge checks it.
+type VerifyMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Otp           string                 `protobuf:"bytes,2,opt,name=otp,proto3" json:"otp,omitempty"`                                         // OTP or other code, per MFARequired.method
+	PopPublicKey  string                 `protobuf:"bytes,3,opt,name=pop_public_key,json=popPublicKey,proto3" json:"pop_public_key,omitempty"` // optional; same as LoginRequest.pop_public_key, for the session VerifyMFA creates
+	TrustDays     int32                  `protobuf:"varint,4,opt,name=trust_days,json=trustDays,proto3" json:"trust_days,omitempty"`           // optional; same as LoginRequest.trust_days, overrides the value given to Login
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_auth_auth_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *VerifyMFARequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetOtp() string {
+	if x != nil {
+		return x.Otp
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetPopPublicKey() string {
+	if x != nil {
+		return x.PopPublicKey
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetTrustDays() int32 {
+	if x != nil {
+		return x.TrustDays
+	}
+	return 0
+}
+
+// SubmitPhoneAndRequestMFARequest carries the intent_id from Login(phone_required) and the user-entered phone.
+type SubmitPhoneAndRequestMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntentId      string                 `protobuf:"bytes,1,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitPhoneAndRequestMFARequest) Reset() {
+	*x = SubmitPhoneAndRequestMFARequest{}
+	mi := &file_auth_auth_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitPhoneAndRequestMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPhoneAndRequestMFARequest) ProtoMessage() {}
+
+func (x *SubmitPhoneAndRequestMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPhoneAndRequestMFARequest.ProtoReflect.Descriptor instead.
+func (*SubmitPhoneAndRequestMFARequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubmitPhoneAndRequestMFARequest) GetIntentId() string {
+	if x != nil {
+		return x.IntentId
+	}
+	return ""
+}
+
+func (x *SubmitPhoneAndRequestMFARequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+// SubmitPhoneAndRequestMFAResponse returns challenge_id and phone_mask after creating the MFA challenge and sending OTP.
+type SubmitPhoneAndRequestMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	PhoneMask     string                 `protobuf:"bytes,2,opt,name=phone_mask,json=phoneMask,proto3" json:"phone_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitPhoneAndRequestMFAResponse) Reset() {
+	*x = SubmitPhoneAndRequestMFAResponse{}
+	mi := &file_auth_auth_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitPhoneAndRequestMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPhoneAndRequestMFAResponse) ProtoMessage() {}
+
+func (x *SubmitPhoneAndRequestMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPhoneAndRequestMFAResponse.ProtoReflect.Descriptor instead.
+func (*SubmitPhoneAndRequestMFAResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SubmitPhoneAndRequestMFAResponse) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *SubmitPhoneAndRequestMFAResponse) GetPhoneMask() string {
+	if x != nil {
+		return x.PhoneMask
+	}
+	return ""
+}
+
+// ResendMFACodeRequest asks for a new code for a pending MFA challenge (from mfa_required or SubmitPhoneAndRequestMFA).
+type ResendMFACodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendMFACodeRequest) Reset() {
+	*x = ResendMFACodeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendMFACodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendMFACodeRequest) ProtoMessage() {}
+
+func (x *ResendMFACodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendMFACodeRequest.ProtoReflect.Descriptor instead.
+func (*ResendMFACodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResendMFACodeRequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+// ResendMFACodeResponse confirms a new code was sent; the previous code no longer works.
+type ResendMFACodeResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId      string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	PhoneMask        string                 `protobuf:"bytes,2,opt,name=phone_mask,json=phoneMask,proto3" json:"phone_mask,omitempty"`
+	ResendsRemaining int32                  `protobuf:"varint,3,opt,name=resends_remaining,json=resendsRemaining,proto3" json:"resends_remaining,omitempty"`
+	NextResendAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_resend_at,json=nextResendAt,proto3" json:"next_resend_at,omitempty"` // earliest time another resend is accepted
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ResendMFACodeResponse) Reset() {
+	*x = ResendMFACodeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendMFACodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendMFACodeResponse) ProtoMessage() {}
+
+func (x *ResendMFACodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendMFACodeResponse.ProtoReflect.Descriptor instead.
+func (*ResendMFACodeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ResendMFACodeResponse) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *ResendMFACodeResponse) GetPhoneMask() string {
+	if x != nil {
+		return x.PhoneMask
+	}
+	return ""
+}
+
+func (x *ResendMFACodeResponse) GetResendsRemaining() int32 {
+	if x != nil {
+		return x.ResendsRemaining
+	}
+	return 0
+}
+
+func (x *ResendMFACodeResponse) GetNextResendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextResendAt
+	}
+	return nil
+}
+
+// LinkIdentityRequest links an external identity (OIDC/SAML) to a user.
+type LinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // e.g. "oidc", "saml"
+	ProviderId    string                 `protobuf:"bytes,3,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	IdToken       string                 `protobuf:"bytes,4,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"` // or SAML assertion; server validates and stores
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityRequest) Reset() {
+	*x = LinkIdentityRequest{}
+	mi := &file_auth_auth_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityRequest) ProtoMessage() {}
+
+func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *LinkIdentityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
+}
+
+// LinkIdentityResponse confirms the identity was linked.
+type LinkIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IdentityId    string                 `protobuf:"bytes,1,opt,name=identity_id,json=identityId,proto3" json:"identity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityResponse) Reset() {
+	*x = LinkIdentityResponse{}
+	mi := &file_auth_auth_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityResponse) ProtoMessage() {}
+
+func (x *LinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*LinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *LinkIdentityResponse) GetIdentityId() string {
+	if x != nil {
+		return x.IdentityId
+	}
+	return ""
+}
+
+// TokenExchangeRequest asks for a short-lived token scoped to one target service (RFC 8693-style token exchange).
+// The subject token is the caller's access token (Authorization header); the session must still be active.
+type TokenExchangeRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Audience            string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`                                                     // required; target service, must be in the server's TOKEN_EXCHANGE_AUDIENCES
+	Scope               string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`                                                           // optional; space-separated scopes for the target service
+	RequestedTtlSeconds int32                  `protobuf:"varint,3,opt,name=requested_ttl_seconds,json=requestedTtlSeconds,proto3" json:"requested_ttl_seconds,omitempty"` // optional; capped at TOKEN_EXCHANGE_TTL
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TokenExchangeRequest) Reset() {
+	*x = TokenExchangeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenExchangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenExchangeRequest) ProtoMessage() {}
+
+func (x *TokenExchangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenExchangeRequest.ProtoReflect.Descriptor instead.
+func (*TokenExchangeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TokenExchangeRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *TokenExchangeRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *TokenExchangeRequest) GetRequestedTtlSeconds() int32 {
+	if x != nil {
+		return x.RequestedTtlSeconds
+	}
+	return 0
+}
+
+// TokenExchangeResponse returns the resource token. Its aud is only the requested audience, so the control plane
+// (and any other service) rejects it.
+type TokenExchangeResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken     string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	IssuedTokenType string                 `protobuf:"bytes,2,opt,name=issued_token_type,json=issuedTokenType,proto3" json:"issued_token_type,omitempty"` // "urn:ietf:params:oauth:token-type:jwt"
+	TokenType       string                 `protobuf:"bytes,3,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`                     // "Bearer"
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Audience        string                 `protobuf:"bytes,5,opt,name=audience,proto3" json:"audience,omitempty"`
+	Scope           string                 `protobuf:"bytes,6,opt,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TokenExchangeResponse) Reset() {
+	*x = TokenExchangeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenExchangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenExchangeResponse) ProtoMessage() {}
+
+func (x *TokenExchangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenExchangeResponse.ProtoReflect.Descriptor instead.
+func (*TokenExchangeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TokenExchangeResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *TokenExchangeResponse) GetIssuedTokenType() string {
+	if x != nil {
+		return x.IssuedTokenType
+	}
+	return ""
+}
+
+func (x *TokenExchangeResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *TokenExchangeResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *TokenExchangeResponse) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *TokenExchangeResponse) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+// ChangePasswordRequest changes the caller's local password. Requires a Bearer access token.
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_auth_auth_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// ChangePasswordResponse reports whether the new (accepted) password appears in a breach corpus (policy warn).
+type ChangePasswordResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PasswordBreached bool                   `protobuf:"varint,1,opt,name=password_breached,json=passwordBreached,proto3" json:"password_breached,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_auth_auth_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ChangePasswordResponse) GetPasswordBreached() bool {
+	if x != nil {
+		return x.PasswordBreached
+	}
+	return false
+}
+
+// RegenerateRecoveryCodesRequest replaces the caller's MFA recovery codes. Requires a Bearer access token.
+type RegenerateRecoveryCodesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesRequest) Reset() {
+	*x = RegenerateRecoveryCodesRequest{}
+	mi := &file_auth_auth_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesRequest) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RegenerateRecoveryCodesRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+// RegenerateRecoveryCodesResponse returns the new one-time codes; previous codes no longer work.
+type RegenerateRecoveryCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesResponse) Reset() {
+	*x = RegenerateRecoveryCodesResponse{}
+	mi := &file_auth_auth_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesResponse) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RegenerateRecoveryCodesResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+// StartPhoneChangeRequest starts changing the caller's MFA phone. Requires a Bearer access token.
+type StartPhoneChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewPhone      string                 `protobuf:"bytes,1,opt,name=new_phone,json=newPhone,proto3" json:"new_phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartPhoneChangeRequest) Reset() {
+	*x = StartPhoneChangeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPhoneChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPhoneChangeRequest) ProtoMessage() {}
+
+func (x *StartPhoneChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPhoneChangeRequest.ProtoReflect.Descriptor instead.
+func (*StartPhoneChangeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *StartPhoneChangeRequest) GetNewPhone() string {
+	if x != nil {
+		return x.NewPhone
+	}
+	return ""
+}
+
+// StartPhoneChangeResponse identifies the challenge whose code was sent to the new phone. When the org requires
+// step-up for sensitive actions, a second code was sent to the current phone (current_phone_challenge_id is set).
+type StartPhoneChangeResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId             string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	PhoneMask               string                 `protobuf:"bytes,2,opt,name=phone_mask,json=phoneMask,proto3" json:"phone_mask,omitempty"`
+	CurrentPhoneChallengeId string                 `protobuf:"bytes,3,opt,name=current_phone_challenge_id,json=currentPhoneChallengeId,proto3" json:"current_phone_challenge_id,omitempty"` // empty unless step-up is required
+	CurrentPhoneMask        string                 `protobuf:"bytes,4,opt,name=current_phone_mask,json=currentPhoneMask,proto3" json:"current_phone_mask,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *StartPhoneChangeResponse) Reset() {
+	*x = StartPhoneChangeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPhoneChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPhoneChangeResponse) ProtoMessage() {}
+
+func (x *StartPhoneChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPhoneChangeResponse.ProtoReflect.Descriptor instead.
+func (*StartPhoneChangeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *StartPhoneChangeResponse) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *StartPhoneChangeResponse) GetPhoneMask() string {
+	if x != nil {
+		return x.PhoneMask
+	}
+	return ""
+}
+
+func (x *StartPhoneChangeResponse) GetCurrentPhoneChallengeId() string {
+	if x != nil {
+		return x.CurrentPhoneChallengeId
+	}
+	return ""
+}
+
+func (x *StartPhoneChangeResponse) GetCurrentPhoneMask() string {
+	if x != nil {
+		return x.CurrentPhoneMask
+	}
+	return ""
+}
+
+// ConfirmPhoneChangeRequest carries the codes sent by StartPhoneChange. Requires a Bearer access token.
+type ConfirmPhoneChangeRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId             string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Otp                     string                 `protobuf:"bytes,2,opt,name=otp,proto3" json:"otp,omitempty"`
+	CurrentPhoneChallengeId string                 `protobuf:"bytes,3,opt,name=current_phone_challenge_id,json=currentPhoneChallengeId,proto3" json:"current_phone_challenge_id,omitempty"` // required when StartPhoneChange returned one
+	CurrentPhoneOtp         string                 `protobuf:"bytes,4,opt,name=current_phone_otp,json=currentPhoneOtp,proto3" json:"current_phone_otp,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ConfirmPhoneChangeRequest) Reset() {
+	*x = ConfirmPhoneChangeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPhoneChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPhoneChangeRequest) ProtoMessage() {}
+
+func (x *ConfirmPhoneChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPhoneChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPhoneChangeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ConfirmPhoneChangeRequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *ConfirmPhoneChangeRequest) GetOtp() string {
+	if x != nil {
+		return x.Otp
+	}
+	return ""
+}
+
+func (x *ConfirmPhoneChangeRequest) GetCurrentPhoneChallengeId() string {
+	if x != nil {
+		return x.CurrentPhoneChallengeId
+	}
+	return ""
+}
+
+func (x *ConfirmPhoneChangeRequest) GetCurrentPhoneOtp() string {
+	if x != nil {
+		return x.CurrentPhoneOtp
+	}
+	return ""
+}
+
+// ConfirmPhoneChangeResponse confirms the new phone is the caller's verified MFA phone.
+type ConfirmPhoneChangeResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PhoneMask        string                 `protobuf:"bytes,1,opt,name=phone_mask,json=phoneMask,proto3" json:"phone_mask,omitempty"`
+	DevicesUntrusted int32                  `protobuf:"varint,2,opt,name=devices_untrusted,json=devicesUntrusted,proto3" json:"devices_untrusted,omitempty"` // trusted devices that now need MFA again (device_trust.keep_trust_on_factor_change)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConfirmPhoneChangeResponse) Reset() {
+	*x = ConfirmPhoneChangeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPhoneChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPhoneChangeResponse) ProtoMessage() {}
+
+func (x *ConfirmPhoneChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPhoneChangeResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPhoneChangeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ConfirmPhoneChangeResponse) GetPhoneMask() string {
+	if x != nil {
+		return x.PhoneMask
+	}
+	return ""
+}
+
+func (x *ConfirmPhoneChangeResponse) GetDevicesUntrusted() int32 {
+	if x != nil {
+		return x.DevicesUntrusted
+	}
+	return 0
+}
+
+// StartEmailChangeRequest starts changing the caller's email. Requires a Bearer access token.
+type StartEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewEmail      string                 `protobuf:"bytes,1,opt,name=new_email,json=newEmail,proto3" json:"new_email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartEmailChangeRequest) Reset() {
+	*x = StartEmailChangeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartEmailChangeRequest) ProtoMessage() {}
+
+func (x *StartEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*StartEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *StartEmailChangeRequest) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+// StartEmailChangeResponse identifies the change. A confirmation link was emailed to the current and the new
+// address; the email changes once both were opened.
+type StartEmailChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailChangeId string                 `protobuf:"bytes,1,opt,name=email_change_id,json=emailChangeId,proto3" json:"email_change_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartEmailChangeResponse) Reset() {
+	*x = StartEmailChangeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartEmailChangeResponse) ProtoMessage() {}
+
+func (x *StartEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*StartEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *StartEmailChangeResponse) GetEmailChangeId() string {
+	if x != nil {
+		return x.EmailChangeId
+	}
+	return ""
+}
+
+func (x *StartEmailChangeResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ConfirmEmailChangeRequest carries the token of a link sent by StartEmailChange. Needs no access token.
+type ConfirmEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeRequest) Reset() {
+	*x = ConfirmEmailChangeRequest{}
+	mi := &file_auth_auth_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeRequest) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ConfirmEmailChangeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// ConfirmEmailChangeResponse reports which addresses have been confirmed. When both have, the email was changed and
+// all of the user's sessions were revoked.
+type ConfirmEmailChangeResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	CurrentEmailConfirmed bool                   `protobuf:"varint,1,opt,name=current_email_confirmed,json=currentEmailConfirmed,proto3" json:"current_email_confirmed,omitempty"`
+	NewEmailConfirmed     bool                   `protobuf:"varint,2,opt,name=new_email_confirmed,json=newEmailConfirmed,proto3" json:"new_email_confirmed,omitempty"`
+	Completed             bool                   `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	SessionsRevoked       int32                  `protobuf:"varint,4,opt,name=sessions_revoked,json=sessionsRevoked,proto3" json:"sessions_revoked,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeResponse) Reset() {
+	*x = ConfirmEmailChangeResponse{}
+	mi := &file_auth_auth_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeResponse) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ConfirmEmailChangeResponse) GetCurrentEmailConfirmed() bool {
+	if x != nil {
+		return x.CurrentEmailConfirmed
+	}
+	return false
+}
+
+func (x *ConfirmEmailChangeResponse) GetNewEmailConfirmed() bool {
+	if x != nil {
+		return x.NewEmailConfirmed
+	}
+	return false
+}
+
+func (x *ConfirmEmailChangeResponse) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *ConfirmEmailChangeResponse) GetSessionsRevoked() int32 {
+	if x != nil {
+		return x.SessionsRevoked
+	}
+	return 0
+}
+
+// CheckUsernameAvailabilityRequest asks whether the caller can take a username. Requires a Bearer access token.
+type CheckUsernameAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUsernameAvailabilityRequest) Reset() {
+	*x = CheckUsernameAvailabilityRequest{}
+	mi := &file_auth_auth_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUsernameAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUsernameAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckUsernameAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUsernameAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckUsernameAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CheckUsernameAvailabilityRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// CheckUsernameAvailabilityResponse reports whether the username is available. When it is not, reason is
+// "invalid", "reserved" or "taken" and message explains it. The caller's own username is available.
+type CheckUsernameAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"` // normalized (lower case)
+	Available     bool                   `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUsernameAvailabilityResponse) Reset() {
+	*x = CheckUsernameAvailabilityResponse{}
+	mi := &file_auth_auth_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUsernameAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUsernameAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckUsernameAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUsernameAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckUsernameAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CheckUsernameAvailabilityResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CheckUsernameAvailabilityResponse) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CheckUsernameAvailabilityResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CheckUsernameAvailabilityResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SetUsernameRequest sets, changes or (with an empty username) removes the caller's username. Requires a Bearer
+// access token.
+type SetUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUsernameRequest) Reset() {
+	*x = SetUsernameRequest{}
+	mi := &file_auth_auth_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUsernameRequest) ProtoMessage() {}
+
+func (x *SetUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUsernameRequest.ProtoReflect.Descriptor instead.
+func (*SetUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SetUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// SetUsernameResponse holds the caller's normalized username; empty when it was removed.
+type SetUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUsernameResponse) Reset() {
+	*x = SetUsernameResponse{}
+	mi := &file_auth_auth_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUsernameResponse) ProtoMessage() {}
+
+func (x *SetUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUsernameResponse.ProtoReflect.Descriptor instead.
+func (*SetUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SetUsernameResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// AdminResetMFARequest resets the MFA of a member of the caller's org. Requires a Bearer access token of an org
+// owner or admin.
+type AdminResetMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // optional; recorded in the audit event (e.g. support ticket)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminResetMFARequest) Reset() {
+	*x = AdminResetMFARequest{}
+	mi := &file_auth_auth_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminResetMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminResetMFARequest) ProtoMessage() {}
+
+func (x *AdminResetMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminResetMFARequest.ProtoReflect.Descriptor instead.
+func (*AdminResetMFARequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AdminResetMFARequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdminResetMFARequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// AdminResetMFAResponse reports what was reset. The user's phone is always cleared and all their sessions revoked.
+type AdminResetMFAResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	DevicesUntrusted     int32                  `protobuf:"varint,1,opt,name=devices_untrusted,json=devicesUntrusted,proto3" json:"devices_untrusted,omitempty"`
+	RecoveryCodesCleared bool                   `protobuf:"varint,2,opt,name=recovery_codes_cleared,json=recoveryCodesCleared,proto3" json:"recovery_codes_cleared,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *AdminResetMFAResponse) Reset() {
+	*x = AdminResetMFAResponse{}
+	mi := &file_auth_auth_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminResetMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminResetMFAResponse) ProtoMessage() {}
+
+func (x *AdminResetMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[62]
 	if x != nil {
-		if x, ok := x.Result.(*LoginResponse_MfaRequired); ok {
-			return x.MfaRequired
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *LoginResponse) GetPhoneRequired() *PhoneRequired {
+// Deprecated: Use AdminResetMFAResponse.ProtoReflect.Descriptor instead.
+func (*AdminResetMFAResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AdminResetMFAResponse) GetDevicesUntrusted() int32 {
 	if x != nil {
-		if x, ok := x.Result.(*LoginResponse_PhoneRequired); ok {
-			return x.PhoneRequired
-		}
+		return x.DevicesUntrusted
 	}
-	return nil
+	return 0
 }
 
-type isLoginResponse_Result interface {
-	isLoginResponse_Result()
+func (x *AdminResetMFAResponse) GetRecoveryCodesCleared() bool {
+	if x != nil {
+		return x.RecoveryCodesCleared
+	}
+	return false
 }
 
-type LoginResponse_Tokens struct {
-	Tokens *AuthResponse `protobuf:"bytes,1,opt,name=tokens,proto3,oneof"`
+// GetJWKSRequest asks for the keys that verify the control plane's tokens. Public; no Bearer token needed.
+type GetJWKSRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type LoginResponse_MfaRequired struct {
-	MfaRequired *MFARequired `protobuf:"bytes,2,opt,name=mfa_required,json=mfaRequired,proto3,oneof"`
+func (x *GetJWKSRequest) Reset() {
+	*x = GetJWKSRequest{}
+	mi := &file_auth_auth_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type LoginResponse_PhoneRequired struct {
-	PhoneRequired *PhoneRequired `protobuf:"bytes,3,opt,name=phone_required,json=phoneRequired,proto3,oneof"`
+func (x *GetJWKSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResponse_Tokens) isLoginResponse_Result() {}
+func (*GetJWKSRequest) ProtoMessage() {}
 
-func (*LoginResponse_MfaRequired) isLoginResponse_Result() {}
+func (x *GetJWKSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*LoginResponse_PhoneRequired) isLoginResponse_Result() {}
+// Deprecated: Use GetJWKSRequest.ProtoReflect.Descriptor instead.
+func (*GetJWKSRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{63}
+}
 
-// VerifyMFARequest carries the MFA challenge id and OTP from the user.
-type VerifyMFARequest struct {
+// GetJWKSResponse returns the JSON Web Key Set of the token signing keys (current and, during a rotation, previous)
+// and the iss and aud of access tokens. Services that validate tokens themselves (see pkg/enforcer) pick the key by
+// the token's kid header.
+type GetJWKSResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
-	Otp           string                 `protobuf:"bytes,2,opt,name=otp,proto3" json:"otp,omitempty"`
+	Jwks          string                 `protobuf:"bytes,1,opt,name=jwks,proto3" json:"jwks,omitempty"` // JWKS JSON, {"keys":[...]}
+	Issuer        string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Audience      string                 `protobuf:"bytes,3,opt,name=audience,proto3" json:"audience,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyMFARequest) Reset() {
-	*x = VerifyMFARequest{}
-	mi := &file_auth_auth_proto_msgTypes[11]
+func (x *GetJWKSResponse) Reset() {
+	*x = GetJWKSResponse{}
+	mi := &file_auth_auth_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyMFARequest) String() string {
+func (x *GetJWKSResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyMFARequest) ProtoMessage() {}
+func (*GetJWKSResponse) ProtoMessage() {}
 
-func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[11]
+func (x *GetJWKSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,49 +3905,56 @@ func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
-func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetJWKSResponse.ProtoReflect.Descriptor instead.
+func (*GetJWKSResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *VerifyMFARequest) GetChallengeId() string {
+func (x *GetJWKSResponse) GetJwks() string {
 	if x != nil {
-		return x.ChallengeId
+		return x.Jwks
 	}
 	return ""
 }
 
-func (x *VerifyMFARequest) GetOtp() string {
+func (x *GetJWKSResponse) GetIssuer() string {
 	if x != nil {
-		return x.Otp
+		return x.Issuer
 	}
 	return ""
 }
 
-// SubmitPhoneAndRequestMFARequest carries the intent_id from Login(phone_required) and the user-entered phone.
-type SubmitPhoneAndRequestMFARequest struct {
+func (x *GetJWKSResponse) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+// IntrospectRequest asks whether an access token is valid right now (RFC 7662-style). Requires a Bearer access token
+// of a service account (SERVICE_ACCOUNT_USER_IDS).
+type IntrospectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	IntentId      string                 `protobuf:"bytes,1,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
-	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // the access token to check, without the "Bearer " prefix
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitPhoneAndRequestMFARequest) Reset() {
-	*x = SubmitPhoneAndRequestMFARequest{}
-	mi := &file_auth_auth_proto_msgTypes[12]
+func (x *IntrospectRequest) Reset() {
+	*x = IntrospectRequest{}
+	mi := &file_auth_auth_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitPhoneAndRequestMFARequest) String() string {
+func (x *IntrospectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitPhoneAndRequestMFARequest) ProtoMessage() {}
+func (*IntrospectRequest) ProtoMessage() {}
 
-func (x *SubmitPhoneAndRequestMFARequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[12]
+func (x *IntrospectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,49 +3965,53 @@ func (x *SubmitPhoneAndRequestMFARequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitPhoneAndRequestMFARequest.ProtoReflect.Descriptor instead.
-func (*SubmitPhoneAndRequestMFARequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *SubmitPhoneAndRequestMFARequest) GetIntentId() string {
-	if x != nil {
-		return x.IntentId
-	}
-	return ""
+// Deprecated: Use IntrospectRequest.ProtoReflect.Descriptor instead.
+func (*IntrospectRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *SubmitPhoneAndRequestMFARequest) GetPhone() string {
+func (x *IntrospectRequest) GetToken() string {
 	if x != nil {
-		return x.Phone
+		return x.Token
 	}
 	return ""
 }
 
-// SubmitPhoneAndRequestMFAResponse returns challenge_id and phone_mask after creating the MFA challenge and sending OTP.
-type SubmitPhoneAndRequestMFAResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
-	PhoneMask     string                 `protobuf:"bytes,2,opt,name=phone_mask,json=phoneMask,proto3" json:"phone_mask,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SubmitPhoneAndRequestMFAResponse) Reset() {
-	*x = SubmitPhoneAndRequestMFAResponse{}
-	mi := &file_auth_auth_proto_msgTypes[13]
+// IntrospectResponse describes the token. When active is false the token must be rejected and only
+// cache_ttl_seconds is set; the reason (bad signature, expired, revoked session, disabled user) is not disclosed.
+type IntrospectResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Active             bool                   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId              string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	SessionId          string                 `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Jti                string                 `protobuf:"bytes,5,opt,name=jti,proto3" json:"jti,omitempty"`
+	IssuedAt           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	DeviceId           string                 `protobuf:"bytes,8,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceTrusted      bool                   `protobuf:"varint,9,opt,name=device_trusted,json=deviceTrusted,proto3" json:"device_trusted,omitempty"`                  // effective trust now: trusted, not revoked and not expired
+	DeviceTrustedUntil *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=device_trusted_until,json=deviceTrustedUntil,proto3" json:"device_trusted_until,omitempty"` // unset when trust does not expire or the device is untrusted
+	AuthMethods        []string               `protobuf:"bytes,11,rep,name=auth_methods,json=authMethods,proto3" json:"auth_methods,omitempty"`                        // factors the session was signed in with, e.g. ["password", "sms_otp"]
+	CacheTtlSeconds    int32                  `protobuf:"varint,12,opt,name=cache_ttl_seconds,json=cacheTtlSeconds,proto3" json:"cache_ttl_seconds,omitempty"`         // reuse this result for at most this long; 0 means do not cache
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *IntrospectResponse) Reset() {
+	*x = IntrospectResponse{}
+	mi := &file_auth_auth_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitPhoneAndRequestMFAResponse) String() string {
+func (x *IntrospectResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitPhoneAndRequestMFAResponse) ProtoMessage() {}
+func (*IntrospectResponse) ProtoMessage() {}
 
-func (x *SubmitPhoneAndRequestMFAResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[13]
+func (x *IntrospectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -862,51 +4022,121 @@ func (x *SubmitPhoneAndRequestMFAResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitPhoneAndRequestMFAResponse.ProtoReflect.Descriptor instead.
-func (*SubmitPhoneAndRequestMFAResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use IntrospectResponse.ProtoReflect.Descriptor instead.
+func (*IntrospectResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *SubmitPhoneAndRequestMFAResponse) GetChallengeId() string {
+func (x *IntrospectResponse) GetActive() bool {
 	if x != nil {
-		return x.ChallengeId
+		return x.Active
+	}
+	return false
+}
+
+func (x *IntrospectResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *SubmitPhoneAndRequestMFAResponse) GetPhoneMask() string {
+func (x *IntrospectResponse) GetOrgId() string {
 	if x != nil {
-		return x.PhoneMask
+		return x.OrgId
 	}
 	return ""
 }
 
-// LinkIdentityRequest links an external identity (OIDC/SAML) to a user.
-type LinkIdentityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // e.g. "oidc", "saml"
-	ProviderId    string                 `protobuf:"bytes,3,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
-	IdToken       string                 `protobuf:"bytes,4,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"` // or SAML assertion; server validates and stores
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *IntrospectResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
 }
 
-func (x *LinkIdentityRequest) Reset() {
-	*x = LinkIdentityRequest{}
-	mi := &file_auth_auth_proto_msgTypes[14]
+func (x *IntrospectResponse) GetJti() string {
+	if x != nil {
+		return x.Jti
+	}
+	return ""
+}
+
+func (x *IntrospectResponse) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *IntrospectResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *IntrospectResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *IntrospectResponse) GetDeviceTrusted() bool {
+	if x != nil {
+		return x.DeviceTrusted
+	}
+	return false
+}
+
+func (x *IntrospectResponse) GetDeviceTrustedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeviceTrustedUntil
+	}
+	return nil
+}
+
+func (x *IntrospectResponse) GetAuthMethods() []string {
+	if x != nil {
+		return x.AuthMethods
+	}
+	return nil
+}
+
+func (x *IntrospectResponse) GetCacheTtlSeconds() int32 {
+	if x != nil {
+		return x.CacheTtlSeconds
+	}
+	return 0
+}
+
+// LogoutAllMySessionsRequest signs the caller out on every device and in every org. Requires a Bearer access token.
+// When the org requires step-up for sensitive actions, current_password must be set unless the session was signed
+// in with MFA in the last 10 minutes.
+type LogoutAllMySessionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	KeepCurrent     bool                   `protobuf:"varint,1,opt,name=keep_current,json=keepCurrent,proto3" json:"keep_current,omitempty"`            // keep the session making the call signed in
+	CurrentPassword string                 `protobuf:"bytes,2,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"` // optional; checked whenever it is set
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LogoutAllMySessionsRequest) Reset() {
+	*x = LogoutAllMySessionsRequest{}
+	mi := &file_auth_auth_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LinkIdentityRequest) String() string {
+func (x *LogoutAllMySessionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinkIdentityRequest) ProtoMessage() {}
+func (*LogoutAllMySessionsRequest) ProtoMessage() {}
 
-func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[14]
+func (x *LogoutAllMySessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -917,62 +4147,47 @@ func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinkIdentityRequest.ProtoReflect.Descriptor instead.
-func (*LinkIdentityRequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *LinkIdentityRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *LinkIdentityRequest) GetProvider() string {
-	if x != nil {
-		return x.Provider
-	}
-	return ""
+// Deprecated: Use LogoutAllMySessionsRequest.ProtoReflect.Descriptor instead.
+func (*LogoutAllMySessionsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *LinkIdentityRequest) GetProviderId() string {
+func (x *LogoutAllMySessionsRequest) GetKeepCurrent() bool {
 	if x != nil {
-		return x.ProviderId
+		return x.KeepCurrent
 	}
-	return ""
+	return false
 }
 
-func (x *LinkIdentityRequest) GetIdToken() string {
+func (x *LogoutAllMySessionsRequest) GetCurrentPassword() string {
 	if x != nil {
-		return x.IdToken
+		return x.CurrentPassword
 	}
 	return ""
 }
 
-// LinkIdentityResponse confirms the identity was linked.
-type LinkIdentityResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	IdentityId    string                 `protobuf:"bytes,1,opt,name=identity_id,json=identityId,proto3" json:"identity_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type LogoutAllMySessionsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SessionsRevoked int32                  `protobuf:"varint,1,opt,name=sessions_revoked,json=sessionsRevoked,proto3" json:"sessions_revoked,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *LinkIdentityResponse) Reset() {
-	*x = LinkIdentityResponse{}
-	mi := &file_auth_auth_proto_msgTypes[15]
+func (x *LogoutAllMySessionsResponse) Reset() {
+	*x = LogoutAllMySessionsResponse{}
+	mi := &file_auth_auth_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LinkIdentityResponse) String() string {
+func (x *LogoutAllMySessionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinkIdentityResponse) ProtoMessage() {}
+func (*LogoutAllMySessionsResponse) ProtoMessage() {}
 
-func (x *LinkIdentityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_proto_msgTypes[15]
+func (x *LogoutAllMySessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,75 +4198,217 @@ func (x *LinkIdentityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinkIdentityResponse.ProtoReflect.Descriptor instead.
-func (*LinkIdentityResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use LogoutAllMySessionsResponse.ProtoReflect.Descriptor instead.
+func (*LogoutAllMySessionsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *LinkIdentityResponse) GetIdentityId() string {
+func (x *LogoutAllMySessionsResponse) GetSessionsRevoked() int32 {
 	if x != nil {
-		return x.IdentityId
+		return x.SessionsRevoked
 	}
-	return ""
+	return 0
 }
 
 var File_auth_auth_proto protoreflect.FileDescriptor
 
 const file_auth_auth_proto_rawDesc = "" +
 	"\n" +
-	"\x0fauth/auth.proto\x12\fztcp.auth.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"W\n" +
+	"\x0fauth/auth.proto\x12\fztcp.auth.v1\x1a\x13common/common.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9f\x01\n" +
 	"\x0fRegisterRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"\x86\x01\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12!\n" +
+	"\finvite_token\x18\x04 \x01(\tR\vinviteToken\x12#\n" +
+	"\rcaptcha_token\x18\x05 \x01(\tR\fcaptchaToken\"\x8f\x02\n" +
 	"\fLoginRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x15\n" +
 	"\x06org_id\x18\x03 \x01(\tR\x05orgId\x12-\n" +
-	"\x12device_fingerprint\x18\x04 \x01(\tR\x11deviceFingerprint\"d\n" +
+	"\x12device_fingerprint\x18\x04 \x01(\tR\x11deviceFingerprint\x12$\n" +
+	"\x0epop_public_key\x18\x05 \x01(\tR\fpopPublicKey\x12\x1d\n" +
+	"\n" +
+	"mfa_method\x18\x06 \x01(\tR\tmfaMethod\x12\x1d\n" +
+	"\n" +
+	"trust_days\x18\a \x01(\x05R\ttrustDays\x12#\n" +
+	"\rpublic_device\x18\b \x01(\bR\fpublicDevice\"\xa0\x01\n" +
 	"\x0eRefreshRequest\x12#\n" +
 	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\x12-\n" +
-	"\x12device_fingerprint\x18\x02 \x01(\tR\x11deviceFingerprint\"\xd7\x01\n" +
+	"\x12device_fingerprint\x18\x02 \x01(\tR\x11deviceFingerprint\x12\x1b\n" +
+	"\tpop_proof\x18\x03 \x01(\tR\bpopProof\x12\x1d\n" +
+	"\n" +
+	"mfa_method\x18\x04 \x01(\tR\tmfaMethod\"@\n" +
+	"\x19CreateRefreshNonceRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"m\n" +
+	"\x1aCreateRefreshNonceResponse\x12\x14\n" +
+	"\x05nonce\x18\x01 \x01(\tR\x05nonce\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xd7\x01\n" +
 	"\x0fRefreshResponse\x124\n" +
 	"\x06tokens\x18\x01 \x01(\v2\x1a.ztcp.auth.v1.AuthResponseH\x00R\x06tokens\x12>\n" +
 	"\fmfa_required\x18\x02 \x01(\v2\x19.ztcp.auth.v1.MFARequiredH\x00R\vmfaRequired\x12D\n" +
 	"\x0ephone_required\x18\x03 \x01(\v2\x1b.ztcp.auth.v1.PhoneRequiredH\x00R\rphoneRequiredB\b\n" +
 	"\x06result\"4\n" +
 	"\rLogoutRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"L\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x92\x01\n" +
 	"\x18VerifyCredentialsRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"4\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x15\n" +
+	"\x06org_id\x18\x03 \x01(\tR\x05orgId\x12-\n" +
+	"\x12device_fingerprint\x18\x04 \x01(\tR\x11deviceFingerprint\"\xa4\x01\n" +
 	"\x19VerifyCredentialsResponse\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc1\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x121\n" +
+	"\x15mfa_would_be_required\x18\x03 \x01(\bR\x12mfaWouldBeRequired\x12%\n" +
+	"\x0eaccount_status\x18\x04 \x01(\tR\raccountStatus\"P\n" +
+	"\x1cDiscoverOrganizationsRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"C\n" +
+	"\x16DiscoveredOrganization\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x88\x01\n" +
+	"\x1dDiscoverOrganizationsResponse\x12\x1b\n" +
+	"\tnext_step\x18\x01 \x01(\tR\bnextStep\x12J\n" +
+	"\rorganizations\x18\x02 \x03(\v2$.ztcp.auth.v1.DiscoveredOrganizationR\rorganizations\"\x95\x02\n" +
 	"\fAuthResponse\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x129\n" +
 	"\n" +
 	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x17\n" +
 	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x15\n" +
-	"\x06org_id\x18\x05 \x01(\tR\x05orgId\"O\n" +
+	"\x06org_id\x18\x05 \x01(\tR\x05orgId\x12+\n" +
+	"\x11password_breached\x18\x06 \x01(\bR\x10passwordBreached\x12%\n" +
+	"\x0erecovery_codes\x18\a \x03(\tR\rrecoveryCodes\"\xb8\x01\n" +
 	"\vMFARequired\x12!\n" +
 	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x1d\n" +
 	"\n" +
-	"phone_mask\x18\x02 \x01(\tR\tphoneMask\",\n" +
+	"phone_mask\x18\x02 \x01(\tR\tphoneMask\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12+\n" +
+	"\x11available_methods\x18\x04 \x03(\tR\x10availableMethods\x12\"\n" +
+	"\rcode_not_sent\x18\x05 \x01(\bR\vcodeNotSent\",\n" +
 	"\rPhoneRequired\x12\x1b\n" +
-	"\tintent_id\x18\x01 \x01(\tR\bintentId\"\xd5\x01\n" +
+	"\tintent_id\x18\x01 \x01(\tR\bintentId\"\x85\x01\n" +
+	"\x10ApprovalRequired\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12\x1d\n" +
+	"\n" +
+	"hold_token\x18\x02 \x01(\tR\tholdToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xa4\x02\n" +
 	"\rLoginResponse\x124\n" +
 	"\x06tokens\x18\x01 \x01(\v2\x1a.ztcp.auth.v1.AuthResponseH\x00R\x06tokens\x12>\n" +
 	"\fmfa_required\x18\x02 \x01(\v2\x19.ztcp.auth.v1.MFARequiredH\x00R\vmfaRequired\x12D\n" +
-	"\x0ephone_required\x18\x03 \x01(\v2\x1b.ztcp.auth.v1.PhoneRequiredH\x00R\rphoneRequiredB\b\n" +
-	"\x06result\"G\n" +
+	"\x0ephone_required\x18\x03 \x01(\v2\x1b.ztcp.auth.v1.PhoneRequiredH\x00R\rphoneRequired\x12M\n" +
+	"\x11approval_required\x18\x04 \x01(\v2\x1e.ztcp.auth.v1.ApprovalRequiredH\x00R\x10approvalRequiredB\b\n" +
+	"\x06result\"\x91\x01\n" +
+	"\x12ResumeLoginRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12\x1d\n" +
+	"\n" +
+	"hold_token\x18\x02 \x01(\tR\tholdToken\x12$\n" +
+	"\x0epop_public_key\x18\x03 \x01(\tR\fpopPublicKey\x12\x1d\n" +
+	"\n" +
+	"mfa_method\x18\x04 \x01(\tR\tmfaMethod\"\xfa\x02\n" +
+	"\tLoginHold\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x12\x18\n" +
+	"\areasons\x18\x05 \x03(\tR\areasons\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\b \x01(\tR\tdecidedBy\x129\n" +
+	"\n" +
+	"decided_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdecidedAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\".\n" +
+	"\x13ApproveLoginRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\"C\n" +
+	"\x14ApproveLoginResponse\x12+\n" +
+	"\x04hold\x18\x01 \x01(\v2\x17.ztcp.auth.v1.LoginHoldR\x04hold\"+\n" +
+	"\x10DenyLoginRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\"@\n" +
+	"\x11DenyLoginResponse\x12+\n" +
+	"\x04hold\x18\x01 \x01(\v2\x17.ztcp.auth.v1.LoginHoldR\x04hold\"S\n" +
+	"\x15ListLoginHoldsRequest\x12:\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\x89\x01\n" +
+	"\x16ListLoginHoldsResponse\x12-\n" +
+	"\x05holds\x18\x01 \x03(\v2\x17.ztcp.auth.v1.LoginHoldR\x05holds\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"q\n" +
+	"\x1fStartDeviceAuthorizationRequest\x12\x1f\n" +
+	"\vclient_name\x18\x01 \x01(\tR\n" +
+	"clientName\x12-\n" +
+	"\x12device_fingerprint\x18\x02 \x01(\tR\x11deviceFingerprint\"\xad\x02\n" +
+	" StartDeviceAuthorizationResponse\x12\x1f\n" +
+	"\vdevice_code\x18\x01 \x01(\tR\n" +
+	"deviceCode\x12\x1b\n" +
+	"\tuser_code\x18\x02 \x01(\tR\buserCode\x12)\n" +
+	"\x10verification_uri\x18\x03 \x01(\tR\x0fverificationUri\x12:\n" +
+	"\x19verification_uri_complete\x18\x04 \x01(\tR\x17verificationUriComplete\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12)\n" +
+	"\x10interval_seconds\x18\x06 \x01(\x05R\x0fintervalSeconds\"g\n" +
+	"\x1ePollDeviceAuthorizationRequest\x12\x1f\n" +
+	"\vdevice_code\x18\x01 \x01(\tR\n" +
+	"deviceCode\x12$\n" +
+	"\x0epop_public_key\x18\x02 \x01(\tR\fpopPublicKey\"\x94\x02\n" +
+	"\x13DeviceAuthorization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vclient_name\x18\x02 \x01(\tR\n" +
+	"clientName\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x06 \x01(\tR\x05orgId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"7\n" +
+	"\x18ApproveDeviceCodeRequest\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\"q\n" +
+	"\x19ApproveDeviceCodeResponse\x12T\n" +
+	"\x14device_authorization\x18\x01 \x01(\v2!.ztcp.auth.v1.DeviceAuthorizationR\x13deviceAuthorization\"4\n" +
+	"\x15DenyDeviceCodeRequest\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\"n\n" +
+	"\x16DenyDeviceCodeResponse\x12T\n" +
+	"\x14device_authorization\x18\x01 \x01(\v2!.ztcp.auth.v1.DeviceAuthorizationR\x13deviceAuthorization\"F\n" +
+	"\x17RequestMagicLinkRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\"\x1a\n" +
+	"\x18RequestMagicLinkResponse\"\xa4\x01\n" +
+	"\x18CompleteMagicLinkRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12-\n" +
+	"\x12device_fingerprint\x18\x02 \x01(\tR\x11deviceFingerprint\x12$\n" +
+	"\x0epop_public_key\x18\x03 \x01(\tR\fpopPublicKey\x12\x1d\n" +
+	"\n" +
+	"mfa_method\x18\x04 \x01(\tR\tmfaMethod\"\x8c\x01\n" +
 	"\x10VerifyMFARequest\x12!\n" +
 	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x10\n" +
-	"\x03otp\x18\x02 \x01(\tR\x03otp\"T\n" +
+	"\x03otp\x18\x02 \x01(\tR\x03otp\x12$\n" +
+	"\x0epop_public_key\x18\x03 \x01(\tR\fpopPublicKey\x12\x1d\n" +
+	"\n" +
+	"trust_days\x18\x04 \x01(\x05R\ttrustDays\"T\n" +
 	"\x1fSubmitPhoneAndRequestMFARequest\x12\x1b\n" +
 	"\tintent_id\x18\x01 \x01(\tR\bintentId\x12\x14\n" +
 	"\x05phone\x18\x02 \x01(\tR\x05phone\"d\n" +
 	" SubmitPhoneAndRequestMFAResponse\x12!\n" +
 	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x1d\n" +
 	"\n" +
-	"phone_mask\x18\x02 \x01(\tR\tphoneMask\"\x86\x01\n" +
+	"phone_mask\x18\x02 \x01(\tR\tphoneMask\"9\n" +
+	"\x14ResendMFACodeRequest\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\"\xc8\x01\n" +
+	"\x15ResendMFACodeResponse\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x1d\n" +
+	"\n" +
+	"phone_mask\x18\x02 \x01(\tR\tphoneMask\x12+\n" +
+	"\x11resends_remaining\x18\x03 \x01(\x05R\x10resendsRemaining\x12@\n" +
+	"\x0enext_resend_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fnextResendAt\"\x86\x01\n" +
 	"\x13LinkIdentityRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x1f\n" +
@@ -1060,16 +4417,140 @@ const file_auth_auth_proto_rawDesc = "" +
 	"\bid_token\x18\x04 \x01(\tR\aidToken\"7\n" +
 	"\x14LinkIdentityResponse\x12\x1f\n" +
 	"\videntity_id\x18\x01 \x01(\tR\n" +
-	"identityId2\x9e\x05\n" +
+	"identityId\"|\n" +
+	"\x14TokenExchangeRequest\x12\x1a\n" +
+	"\baudience\x18\x01 \x01(\tR\baudience\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x122\n" +
+	"\x15requested_ttl_seconds\x18\x03 \x01(\x05R\x13requestedTtlSeconds\"\xf2\x01\n" +
+	"\x15TokenExchangeResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12*\n" +
+	"\x11issued_token_type\x18\x02 \x01(\tR\x0fissuedTokenType\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x03 \x01(\tR\ttokenType\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\baudience\x18\x05 \x01(\tR\baudience\x12\x14\n" +
+	"\x05scope\x18\x06 \x01(\tR\x05scope\"e\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"E\n" +
+	"\x16ChangePasswordResponse\x12+\n" +
+	"\x11password_breached\x18\x01 \x01(\bR\x10passwordBreached\"K\n" +
+	"\x1eRegenerateRecoveryCodesRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\"H\n" +
+	"\x1fRegenerateRecoveryCodesResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"6\n" +
+	"\x17StartPhoneChangeRequest\x12\x1b\n" +
+	"\tnew_phone\x18\x01 \x01(\tR\bnewPhone\"\xc7\x01\n" +
+	"\x18StartPhoneChangeResponse\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x1d\n" +
+	"\n" +
+	"phone_mask\x18\x02 \x01(\tR\tphoneMask\x12;\n" +
+	"\x1acurrent_phone_challenge_id\x18\x03 \x01(\tR\x17currentPhoneChallengeId\x12,\n" +
+	"\x12current_phone_mask\x18\x04 \x01(\tR\x10currentPhoneMask\"\xb9\x01\n" +
+	"\x19ConfirmPhoneChangeRequest\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x10\n" +
+	"\x03otp\x18\x02 \x01(\tR\x03otp\x12;\n" +
+	"\x1acurrent_phone_challenge_id\x18\x03 \x01(\tR\x17currentPhoneChallengeId\x12*\n" +
+	"\x11current_phone_otp\x18\x04 \x01(\tR\x0fcurrentPhoneOtp\"h\n" +
+	"\x1aConfirmPhoneChangeResponse\x12\x1d\n" +
+	"\n" +
+	"phone_mask\x18\x01 \x01(\tR\tphoneMask\x12+\n" +
+	"\x11devices_untrusted\x18\x02 \x01(\x05R\x10devicesUntrusted\"6\n" +
+	"\x17StartEmailChangeRequest\x12\x1b\n" +
+	"\tnew_email\x18\x01 \x01(\tR\bnewEmail\"}\n" +
+	"\x18StartEmailChangeResponse\x12&\n" +
+	"\x0femail_change_id\x18\x01 \x01(\tR\remailChangeId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"1\n" +
+	"\x19ConfirmEmailChangeRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xcd\x01\n" +
+	"\x1aConfirmEmailChangeResponse\x126\n" +
+	"\x17current_email_confirmed\x18\x01 \x01(\bR\x15currentEmailConfirmed\x12.\n" +
+	"\x13new_email_confirmed\x18\x02 \x01(\bR\x11newEmailConfirmed\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\bR\tcompleted\x12)\n" +
+	"\x10sessions_revoked\x18\x04 \x01(\x05R\x0fsessionsRevoked\">\n" +
+	" CheckUsernameAvailabilityRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x8f\x01\n" +
+	"!CheckUsernameAvailabilityResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"0\n" +
+	"\x12SetUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"1\n" +
+	"\x13SetUsernameResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"G\n" +
+	"\x14AdminResetMFARequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"z\n" +
+	"\x15AdminResetMFAResponse\x12+\n" +
+	"\x11devices_untrusted\x18\x01 \x01(\x05R\x10devicesUntrusted\x124\n" +
+	"\x16recovery_codes_cleared\x18\x02 \x01(\bR\x14recoveryCodesCleared\"\x10\n" +
+	"\x0eGetJWKSRequest\"Y\n" +
+	"\x0fGetJWKSResponse\x12\x12\n" +
+	"\x04jwks\x18\x01 \x01(\tR\x04jwks\x12\x16\n" +
+	"\x06issuer\x18\x02 \x01(\tR\x06issuer\x12\x1a\n" +
+	"\baudience\x18\x03 \x01(\tR\baudience\")\n" +
+	"\x11IntrospectRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xe2\x03\n" +
+	"\x12IntrospectResponse\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x03 \x01(\tR\x05orgId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\x12\x10\n" +
+	"\x03jti\x18\x05 \x01(\tR\x03jti\x127\n" +
+	"\tissued_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tdevice_id\x18\b \x01(\tR\bdeviceId\x12%\n" +
+	"\x0edevice_trusted\x18\t \x01(\bR\rdeviceTrusted\x12L\n" +
+	"\x14device_trusted_until\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x12deviceTrustedUntil\x12!\n" +
+	"\fauth_methods\x18\v \x03(\tR\vauthMethods\x12*\n" +
+	"\x11cache_ttl_seconds\x18\f \x01(\x05R\x0fcacheTtlSeconds\"j\n" +
+	"\x1aLogoutAllMySessionsRequest\x12!\n" +
+	"\fkeep_current\x18\x01 \x01(\bR\vkeepCurrent\x12)\n" +
+	"\x10current_password\x18\x02 \x01(\tR\x0fcurrentPassword\"H\n" +
+	"\x1bLogoutAllMySessionsResponse\x12)\n" +
+	"\x10sessions_revoked\x18\x01 \x01(\x05R\x0fsessionsRevoked2\x95\x19\n" +
 	"\vAuthService\x12E\n" +
 	"\bRegister\x12\x1d.ztcp.auth.v1.RegisterRequest\x1a\x1a.ztcp.auth.v1.AuthResponse\x12@\n" +
 	"\x05Login\x12\x1a.ztcp.auth.v1.LoginRequest\x1a\x1b.ztcp.auth.v1.LoginResponse\x12G\n" +
 	"\tVerifyMFA\x12\x1e.ztcp.auth.v1.VerifyMFARequest\x1a\x1a.ztcp.auth.v1.AuthResponse\x12y\n" +
-	"\x18SubmitPhoneAndRequestMFA\x12-.ztcp.auth.v1.SubmitPhoneAndRequestMFARequest\x1a..ztcp.auth.v1.SubmitPhoneAndRequestMFAResponse\x12F\n" +
+	"\x18SubmitPhoneAndRequestMFA\x12-.ztcp.auth.v1.SubmitPhoneAndRequestMFARequest\x1a..ztcp.auth.v1.SubmitPhoneAndRequestMFAResponse\x12X\n" +
+	"\rResendMFACode\x12\".ztcp.auth.v1.ResendMFACodeRequest\x1a#.ztcp.auth.v1.ResendMFACodeResponse\x12F\n" +
 	"\aRefresh\x12\x1c.ztcp.auth.v1.RefreshRequest\x1a\x1d.ztcp.auth.v1.RefreshResponse\x12=\n" +
 	"\x06Logout\x12\x1b.ztcp.auth.v1.LogoutRequest\x1a\x16.google.protobuf.Empty\x12d\n" +
-	"\x11VerifyCredentials\x12&.ztcp.auth.v1.VerifyCredentialsRequest\x1a'.ztcp.auth.v1.VerifyCredentialsResponse\x12U\n" +
-	"\fLinkIdentity\x12!.ztcp.auth.v1.LinkIdentityRequest\x1a\".ztcp.auth.v1.LinkIdentityResponseB?Z=zero-trust-control-plane/backend/api/generated/auth/v1;authv1b\x06proto3"
+	"\x11VerifyCredentials\x12&.ztcp.auth.v1.VerifyCredentialsRequest\x1a'.ztcp.auth.v1.VerifyCredentialsResponse\x12u\n" +
+	"\x15DiscoverOrganizations\x12*.ztcp.auth.v1.DiscoverOrganizationsRequest\x1a+.ztcp.auth.v1.DiscoverOrganizationsResponse\"\x03\x90\x02\x01\x12U\n" +
+	"\fLinkIdentity\x12!.ztcp.auth.v1.LinkIdentityRequest\x1a\".ztcp.auth.v1.LinkIdentityResponse\x12X\n" +
+	"\rTokenExchange\x12\".ztcp.auth.v1.TokenExchangeRequest\x1a#.ztcp.auth.v1.TokenExchangeResponse\x12g\n" +
+	"\x12CreateRefreshNonce\x12'.ztcp.auth.v1.CreateRefreshNonceRequest\x1a(.ztcp.auth.v1.CreateRefreshNonceResponse\x12[\n" +
+	"\x0eChangePassword\x12#.ztcp.auth.v1.ChangePasswordRequest\x1a$.ztcp.auth.v1.ChangePasswordResponse\x12v\n" +
+	"\x17RegenerateRecoveryCodes\x12,.ztcp.auth.v1.RegenerateRecoveryCodesRequest\x1a-.ztcp.auth.v1.RegenerateRecoveryCodesResponse\x12a\n" +
+	"\x10StartPhoneChange\x12%.ztcp.auth.v1.StartPhoneChangeRequest\x1a&.ztcp.auth.v1.StartPhoneChangeResponse\x12g\n" +
+	"\x12ConfirmPhoneChange\x12'.ztcp.auth.v1.ConfirmPhoneChangeRequest\x1a(.ztcp.auth.v1.ConfirmPhoneChangeResponse\x12a\n" +
+	"\x10StartEmailChange\x12%.ztcp.auth.v1.StartEmailChangeRequest\x1a&.ztcp.auth.v1.StartEmailChangeResponse\x12g\n" +
+	"\x12ConfirmEmailChange\x12'.ztcp.auth.v1.ConfirmEmailChangeRequest\x1a(.ztcp.auth.v1.ConfirmEmailChangeResponse\x12\x81\x01\n" +
+	"\x19CheckUsernameAvailability\x12..ztcp.auth.v1.CheckUsernameAvailabilityRequest\x1a/.ztcp.auth.v1.CheckUsernameAvailabilityResponse\"\x03\x90\x02\x01\x12R\n" +
+	"\vSetUsername\x12 .ztcp.auth.v1.SetUsernameRequest\x1a!.ztcp.auth.v1.SetUsernameResponse\x12X\n" +
+	"\rAdminResetMFA\x12\".ztcp.auth.v1.AdminResetMFARequest\x1a#.ztcp.auth.v1.AdminResetMFAResponse\x12L\n" +
+	"\vResumeLogin\x12 .ztcp.auth.v1.ResumeLoginRequest\x1a\x1b.ztcp.auth.v1.LoginResponse\x12U\n" +
+	"\fApproveLogin\x12!.ztcp.auth.v1.ApproveLoginRequest\x1a\".ztcp.auth.v1.ApproveLoginResponse\x12L\n" +
+	"\tDenyLogin\x12\x1e.ztcp.auth.v1.DenyLoginRequest\x1a\x1f.ztcp.auth.v1.DenyLoginResponse\x12`\n" +
+	"\x0eListLoginHolds\x12#.ztcp.auth.v1.ListLoginHoldsRequest\x1a$.ztcp.auth.v1.ListLoginHoldsResponse\"\x03\x90\x02\x01\x12y\n" +
+	"\x18StartDeviceAuthorization\x12-.ztcp.auth.v1.StartDeviceAuthorizationRequest\x1a..ztcp.auth.v1.StartDeviceAuthorizationResponse\x12c\n" +
+	"\x17PollDeviceAuthorization\x12,.ztcp.auth.v1.PollDeviceAuthorizationRequest\x1a\x1a.ztcp.auth.v1.AuthResponse\x12d\n" +
+	"\x11ApproveDeviceCode\x12&.ztcp.auth.v1.ApproveDeviceCodeRequest\x1a'.ztcp.auth.v1.ApproveDeviceCodeResponse\x12[\n" +
+	"\x0eDenyDeviceCode\x12#.ztcp.auth.v1.DenyDeviceCodeRequest\x1a$.ztcp.auth.v1.DenyDeviceCodeResponse\x12a\n" +
+	"\x10RequestMagicLink\x12%.ztcp.auth.v1.RequestMagicLinkRequest\x1a&.ztcp.auth.v1.RequestMagicLinkResponse\x12X\n" +
+	"\x11CompleteMagicLink\x12&.ztcp.auth.v1.CompleteMagicLinkRequest\x1a\x1b.ztcp.auth.v1.LoginResponse\x12K\n" +
+	"\aGetJWKS\x12\x1c.ztcp.auth.v1.GetJWKSRequest\x1a\x1d.ztcp.auth.v1.GetJWKSResponse\"\x03\x90\x02\x01\x12T\n" +
+	"\n" +
+	"Introspect\x12\x1f.ztcp.auth.v1.IntrospectRequest\x1a .ztcp.auth.v1.IntrospectResponse\"\x03\x90\x02\x01\x12j\n" +
+	"\x13LogoutAllMySessions\x12(.ztcp.auth.v1.LogoutAllMySessionsRequest\x1a).ztcp.auth.v1.LogoutAllMySessionsResponseB?Z=zero-trust-control-plane/backend/api/generated/auth/v1;authv1b\x06proto3"
 
 var (
 	file_auth_auth_proto_rawDescOnce sync.Once
@@ -1083,56 +4564,186 @@ func file_auth_auth_proto_rawDescGZIP() []byte {
 	return file_auth_auth_proto_rawDescData
 }
 
-var file_auth_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_auth_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
 var file_auth_auth_proto_goTypes = []any{
-	(*RegisterRequest)(nil),                  // 0: ztcp.auth.v1.RegisterRequest
-	(*LoginRequest)(nil),                     // 1: ztcp.auth.v1.LoginRequest
-	(*RefreshRequest)(nil),                   // 2: ztcp.auth.v1.RefreshRequest
-	(*RefreshResponse)(nil),                  // 3: ztcp.auth.v1.RefreshResponse
-	(*LogoutRequest)(nil),                    // 4: ztcp.auth.v1.LogoutRequest
-	(*VerifyCredentialsRequest)(nil),         // 5: ztcp.auth.v1.VerifyCredentialsRequest
-	(*VerifyCredentialsResponse)(nil),        // 6: ztcp.auth.v1.VerifyCredentialsResponse
-	(*AuthResponse)(nil),                     // 7: ztcp.auth.v1.AuthResponse
-	(*MFARequired)(nil),                      // 8: ztcp.auth.v1.MFARequired
-	(*PhoneRequired)(nil),                    // 9: ztcp.auth.v1.PhoneRequired
-	(*LoginResponse)(nil),                    // 10: ztcp.auth.v1.LoginResponse
-	(*VerifyMFARequest)(nil),                 // 11: ztcp.auth.v1.VerifyMFARequest
-	(*SubmitPhoneAndRequestMFARequest)(nil),  // 12: ztcp.auth.v1.SubmitPhoneAndRequestMFARequest
-	(*SubmitPhoneAndRequestMFAResponse)(nil), // 13: ztcp.auth.v1.SubmitPhoneAndRequestMFAResponse
-	(*LinkIdentityRequest)(nil),              // 14: ztcp.auth.v1.LinkIdentityRequest
-	(*LinkIdentityResponse)(nil),             // 15: ztcp.auth.v1.LinkIdentityResponse
-	(*timestamppb.Timestamp)(nil),            // 16: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                    // 17: google.protobuf.Empty
+	(*RegisterRequest)(nil),                   // 0: ztcp.auth.v1.RegisterRequest
+	(*LoginRequest)(nil),                      // 1: ztcp.auth.v1.LoginRequest
+	(*RefreshRequest)(nil),                    // 2: ztcp.auth.v1.RefreshRequest
+	(*CreateRefreshNonceRequest)(nil),         // 3: ztcp.auth.v1.CreateRefreshNonceRequest
+	(*CreateRefreshNonceResponse)(nil),        // 4: ztcp.auth.v1.CreateRefreshNonceResponse
+	(*RefreshResponse)(nil),                   // 5: ztcp.auth.v1.RefreshResponse
+	(*LogoutRequest)(nil),                     // 6: ztcp.auth.v1.LogoutRequest
+	(*VerifyCredentialsRequest)(nil),          // 7: ztcp.auth.v1.VerifyCredentialsRequest
+	(*VerifyCredentialsResponse)(nil),         // 8: ztcp.auth.v1.VerifyCredentialsResponse
+	(*DiscoverOrganizationsRequest)(nil),      // 9: ztcp.auth.v1.DiscoverOrganizationsRequest
+	(*DiscoveredOrganization)(nil),            // 10: ztcp.auth.v1.DiscoveredOrganization
+	(*DiscoverOrganizationsResponse)(nil),     // 11: ztcp.auth.v1.DiscoverOrganizationsResponse
+	(*AuthResponse)(nil),                      // 12: ztcp.auth.v1.AuthResponse
+	(*MFARequired)(nil),                       // 13: ztcp.auth.v1.MFARequired
+	(*PhoneRequired)(nil),                     // 14: ztcp.auth.v1.PhoneRequired
+	(*ApprovalRequired)(nil),                  // 15: ztcp.auth.v1.ApprovalRequired
+	(*LoginResponse)(nil),                     // 16: ztcp.auth.v1.LoginResponse
+	(*ResumeLoginRequest)(nil),                // 17: ztcp.auth.v1.ResumeLoginRequest
+	(*LoginHold)(nil),                         // 18: ztcp.auth.v1.LoginHold
+	(*ApproveLoginRequest)(nil),               // 19: ztcp.auth.v1.ApproveLoginRequest
+	(*ApproveLoginResponse)(nil),              // 20: ztcp.auth.v1.ApproveLoginResponse
+	(*DenyLoginRequest)(nil),                  // 21: ztcp.auth.v1.DenyLoginRequest
+	(*DenyLoginResponse)(nil),                 // 22: ztcp.auth.v1.DenyLoginResponse
+	(*ListLoginHoldsRequest)(nil),             // 23: ztcp.auth.v1.ListLoginHoldsRequest
+	(*ListLoginHoldsResponse)(nil),            // 24: ztcp.auth.v1.ListLoginHoldsResponse
+	(*StartDeviceAuthorizationRequest)(nil),   // 25: ztcp.auth.v1.StartDeviceAuthorizationRequest
+	(*StartDeviceAuthorizationResponse)(nil),  // 26: ztcp.auth.v1.StartDeviceAuthorizationResponse
+	(*PollDeviceAuthorizationRequest)(nil),    // 27: ztcp.auth.v1.PollDeviceAuthorizationRequest
+	(*DeviceAuthorization)(nil),               // 28: ztcp.auth.v1.DeviceAuthorization
+	(*ApproveDeviceCodeRequest)(nil),          // 29: ztcp.auth.v1.ApproveDeviceCodeRequest
+	(*ApproveDeviceCodeResponse)(nil),         // 30: ztcp.auth.v1.ApproveDeviceCodeResponse
+	(*DenyDeviceCodeRequest)(nil),             // 31: ztcp.auth.v1.DenyDeviceCodeRequest
+	(*DenyDeviceCodeResponse)(nil),            // 32: ztcp.auth.v1.DenyDeviceCodeResponse
+	(*RequestMagicLinkRequest)(nil),           // 33: ztcp.auth.v1.RequestMagicLinkRequest
+	(*RequestMagicLinkResponse)(nil),          // 34: ztcp.auth.v1.RequestMagicLinkResponse
+	(*CompleteMagicLinkRequest)(nil),          // 35: ztcp.auth.v1.CompleteMagicLinkRequest
+	(*VerifyMFARequest)(nil),                  // 36: ztcp.auth.v1.VerifyMFARequest
+	(*SubmitPhoneAndRequestMFARequest)(nil),   // 37: ztcp.auth.v1.SubmitPhoneAndRequestMFARequest
+	(*SubmitPhoneAndRequestMFAResponse)(nil),  // 38: ztcp.auth.v1.SubmitPhoneAndRequestMFAResponse
+	(*ResendMFACodeRequest)(nil),              // 39: ztcp.auth.v1.ResendMFACodeRequest
+	(*ResendMFACodeResponse)(nil),             // 40: ztcp.auth.v1.ResendMFACodeResponse
+	(*LinkIdentityRequest)(nil),               // 41: ztcp.auth.v1.LinkIdentityRequest
+	(*LinkIdentityResponse)(nil),              // 42: ztcp.auth.v1.LinkIdentityResponse
+	(*TokenExchangeRequest)(nil),              // 43: ztcp.auth.v1.TokenExchangeRequest
+	(*TokenExchangeResponse)(nil),             // 44: ztcp.auth.v1.TokenExchangeResponse
+	(*ChangePasswordRequest)(nil),             // 45: ztcp.auth.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),            // 46: ztcp.auth.v1.ChangePasswordResponse
+	(*RegenerateRecoveryCodesRequest)(nil),    // 47: ztcp.auth.v1.RegenerateRecoveryCodesRequest
+	(*RegenerateRecoveryCodesResponse)(nil),   // 48: ztcp.auth.v1.RegenerateRecoveryCodesResponse
+	(*StartPhoneChangeRequest)(nil),           // 49: ztcp.auth.v1.StartPhoneChangeRequest
+	(*StartPhoneChangeResponse)(nil),          // 50: ztcp.auth.v1.StartPhoneChangeResponse
+	(*ConfirmPhoneChangeRequest)(nil),         // 51: ztcp.auth.v1.ConfirmPhoneChangeRequest
+	(*ConfirmPhoneChangeResponse)(nil),        // 52: ztcp.auth.v1.ConfirmPhoneChangeResponse
+	(*StartEmailChangeRequest)(nil),           // 53: ztcp.auth.v1.StartEmailChangeRequest
+	(*StartEmailChangeResponse)(nil),          // 54: ztcp.auth.v1.StartEmailChangeResponse
+	(*ConfirmEmailChangeRequest)(nil),         // 55: ztcp.auth.v1.ConfirmEmailChangeRequest
+	(*ConfirmEmailChangeResponse)(nil),        // 56: ztcp.auth.v1.ConfirmEmailChangeResponse
+	(*CheckUsernameAvailabilityRequest)(nil),  // 57: ztcp.auth.v1.CheckUsernameAvailabilityRequest
+	(*CheckUsernameAvailabilityResponse)(nil), // 58: ztcp.auth.v1.CheckUsernameAvailabilityResponse
+	(*SetUsernameRequest)(nil),                // 59: ztcp.auth.v1.SetUsernameRequest
+	(*SetUsernameResponse)(nil),               // 60: ztcp.auth.v1.SetUsernameResponse
+	(*AdminResetMFARequest)(nil),              // 61: ztcp.auth.v1.AdminResetMFARequest
+	(*AdminResetMFAResponse)(nil),             // 62: ztcp.auth.v1.AdminResetMFAResponse
+	(*GetJWKSRequest)(nil),                    // 63: ztcp.auth.v1.GetJWKSRequest
+	(*GetJWKSResponse)(nil),                   // 64: ztcp.auth.v1.GetJWKSResponse
+	(*IntrospectRequest)(nil),                 // 65: ztcp.auth.v1.IntrospectRequest
+	(*IntrospectResponse)(nil),                // 66: ztcp.auth.v1.IntrospectResponse
+	(*LogoutAllMySessionsRequest)(nil),        // 67: ztcp.auth.v1.LogoutAllMySessionsRequest
+	(*LogoutAllMySessionsResponse)(nil),       // 68: ztcp.auth.v1.LogoutAllMySessionsResponse
+	(*timestamppb.Timestamp)(nil),             // 69: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),                     // 70: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),               // 71: ztcp.common.v1.PaginationResult
+	(*emptypb.Empty)(nil),                     // 72: google.protobuf.Empty
 }
 var file_auth_auth_proto_depIdxs = []int32{
-	7,  // 0: ztcp.auth.v1.RefreshResponse.tokens:type_name -> ztcp.auth.v1.AuthResponse
-	8,  // 1: ztcp.auth.v1.RefreshResponse.mfa_required:type_name -> ztcp.auth.v1.MFARequired
-	9,  // 2: ztcp.auth.v1.RefreshResponse.phone_required:type_name -> ztcp.auth.v1.PhoneRequired
-	16, // 3: ztcp.auth.v1.AuthResponse.expires_at:type_name -> google.protobuf.Timestamp
-	7,  // 4: ztcp.auth.v1.LoginResponse.tokens:type_name -> ztcp.auth.v1.AuthResponse
-	8,  // 5: ztcp.auth.v1.LoginResponse.mfa_required:type_name -> ztcp.auth.v1.MFARequired
-	9,  // 6: ztcp.auth.v1.LoginResponse.phone_required:type_name -> ztcp.auth.v1.PhoneRequired
-	0,  // 7: ztcp.auth.v1.AuthService.Register:input_type -> ztcp.auth.v1.RegisterRequest
-	1,  // 8: ztcp.auth.v1.AuthService.Login:input_type -> ztcp.auth.v1.LoginRequest
-	11, // 9: ztcp.auth.v1.AuthService.VerifyMFA:input_type -> ztcp.auth.v1.VerifyMFARequest
-	12, // 10: ztcp.auth.v1.AuthService.SubmitPhoneAndRequestMFA:input_type -> ztcp.auth.v1.SubmitPhoneAndRequestMFARequest
-	2,  // 11: ztcp.auth.v1.AuthService.Refresh:input_type -> ztcp.auth.v1.RefreshRequest
-	4,  // 12: ztcp.auth.v1.AuthService.Logout:input_type -> ztcp.auth.v1.LogoutRequest
-	5,  // 13: ztcp.auth.v1.AuthService.VerifyCredentials:input_type -> ztcp.auth.v1.VerifyCredentialsRequest
-	14, // 14: ztcp.auth.v1.AuthService.LinkIdentity:input_type -> ztcp.auth.v1.LinkIdentityRequest
-	7,  // 15: ztcp.auth.v1.AuthService.Register:output_type -> ztcp.auth.v1.AuthResponse
-	10, // 16: ztcp.auth.v1.AuthService.Login:output_type -> ztcp.auth.v1.LoginResponse
-	7,  // 17: ztcp.auth.v1.AuthService.VerifyMFA:output_type -> ztcp.auth.v1.AuthResponse
-	13, // 18: ztcp.auth.v1.AuthService.SubmitPhoneAndRequestMFA:output_type -> ztcp.auth.v1.SubmitPhoneAndRequestMFAResponse
-	3,  // 19: ztcp.auth.v1.AuthService.Refresh:output_type -> ztcp.auth.v1.RefreshResponse
-	17, // 20: ztcp.auth.v1.AuthService.Logout:output_type -> google.protobuf.Empty
-	6,  // 21: ztcp.auth.v1.AuthService.VerifyCredentials:output_type -> ztcp.auth.v1.VerifyCredentialsResponse
-	15, // 22: ztcp.auth.v1.AuthService.LinkIdentity:output_type -> ztcp.auth.v1.LinkIdentityResponse
-	15, // [15:23] is the sub-list for method output_type
-	7,  // [7:15] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	69, // 0: ztcp.auth.v1.CreateRefreshNonceResponse.expires_at:type_name -> google.protobuf.Timestamp
+	12, // 1: ztcp.auth.v1.RefreshResponse.tokens:type_name -> ztcp.auth.v1.AuthResponse
+	13, // 2: ztcp.auth.v1.RefreshResponse.mfa_required:type_name -> ztcp.auth.v1.MFARequired
+	14, // 3: ztcp.auth.v1.RefreshResponse.phone_required:type_name -> ztcp.auth.v1.PhoneRequired
+	10, // 4: ztcp.auth.v1.DiscoverOrganizationsResponse.organizations:type_name -> ztcp.auth.v1.DiscoveredOrganization
+	69, // 5: ztcp.auth.v1.AuthResponse.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 6: ztcp.auth.v1.ApprovalRequired.expires_at:type_name -> google.protobuf.Timestamp
+	12, // 7: ztcp.auth.v1.LoginResponse.tokens:type_name -> ztcp.auth.v1.AuthResponse
+	13, // 8: ztcp.auth.v1.LoginResponse.mfa_required:type_name -> ztcp.auth.v1.MFARequired
+	14, // 9: ztcp.auth.v1.LoginResponse.phone_required:type_name -> ztcp.auth.v1.PhoneRequired
+	15, // 10: ztcp.auth.v1.LoginResponse.approval_required:type_name -> ztcp.auth.v1.ApprovalRequired
+	69, // 11: ztcp.auth.v1.LoginHold.decided_at:type_name -> google.protobuf.Timestamp
+	69, // 12: ztcp.auth.v1.LoginHold.created_at:type_name -> google.protobuf.Timestamp
+	69, // 13: ztcp.auth.v1.LoginHold.expires_at:type_name -> google.protobuf.Timestamp
+	18, // 14: ztcp.auth.v1.ApproveLoginResponse.hold:type_name -> ztcp.auth.v1.LoginHold
+	18, // 15: ztcp.auth.v1.DenyLoginResponse.hold:type_name -> ztcp.auth.v1.LoginHold
+	70, // 16: ztcp.auth.v1.ListLoginHoldsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	18, // 17: ztcp.auth.v1.ListLoginHoldsResponse.holds:type_name -> ztcp.auth.v1.LoginHold
+	71, // 18: ztcp.auth.v1.ListLoginHoldsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	69, // 19: ztcp.auth.v1.StartDeviceAuthorizationResponse.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 20: ztcp.auth.v1.DeviceAuthorization.created_at:type_name -> google.protobuf.Timestamp
+	69, // 21: ztcp.auth.v1.DeviceAuthorization.expires_at:type_name -> google.protobuf.Timestamp
+	28, // 22: ztcp.auth.v1.ApproveDeviceCodeResponse.device_authorization:type_name -> ztcp.auth.v1.DeviceAuthorization
+	28, // 23: ztcp.auth.v1.DenyDeviceCodeResponse.device_authorization:type_name -> ztcp.auth.v1.DeviceAuthorization
+	69, // 24: ztcp.auth.v1.ResendMFACodeResponse.next_resend_at:type_name -> google.protobuf.Timestamp
+	69, // 25: ztcp.auth.v1.TokenExchangeResponse.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 26: ztcp.auth.v1.StartEmailChangeResponse.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 27: ztcp.auth.v1.IntrospectResponse.issued_at:type_name -> google.protobuf.Timestamp
+	69, // 28: ztcp.auth.v1.IntrospectResponse.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 29: ztcp.auth.v1.IntrospectResponse.device_trusted_until:type_name -> google.protobuf.Timestamp
+	0,  // 30: ztcp.auth.v1.AuthService.Register:input_type -> ztcp.auth.v1.RegisterRequest
+	1,  // 31: ztcp.auth.v1.AuthService.Login:input_type -> ztcp.auth.v1.LoginRequest
+	36, // 32: ztcp.auth.v1.AuthService.VerifyMFA:input_type -> ztcp.auth.v1.VerifyMFARequest
+	37, // 33: ztcp.auth.v1.AuthService.SubmitPhoneAndRequestMFA:input_type -> ztcp.auth.v1.SubmitPhoneAndRequestMFARequest
+	39, // 34: ztcp.auth.v1.AuthService.ResendMFACode:input_type -> ztcp.auth.v1.ResendMFACodeRequest
+	2,  // 35: ztcp.auth.v1.AuthService.Refresh:input_type -> ztcp.auth.v1.RefreshRequest
+	6,  // 36: ztcp.auth.v1.AuthService.Logout:input_type -> ztcp.auth.v1.LogoutRequest
+	7,  // 37: ztcp.auth.v1.AuthService.VerifyCredentials:input_type -> ztcp.auth.v1.VerifyCredentialsRequest
+	9,  // 38: ztcp.auth.v1.AuthService.DiscoverOrganizations:input_type -> ztcp.auth.v1.DiscoverOrganizationsRequest
+	41, // 39: ztcp.auth.v1.AuthService.LinkIdentity:input_type -> ztcp.auth.v1.LinkIdentityRequest
+	43, // 40: ztcp.auth.v1.AuthService.TokenExchange:input_type -> ztcp.auth.v1.TokenExchangeRequest
+	3,  // 41: ztcp.auth.v1.AuthService.CreateRefreshNonce:input_type -> ztcp.auth.v1.CreateRefreshNonceRequest
+	45, // 42: ztcp.auth.v1.AuthService.ChangePassword:input_type -> ztcp.auth.v1.ChangePasswordRequest
+	47, // 43: ztcp.auth.v1.AuthService.RegenerateRecoveryCodes:input_type -> ztcp.auth.v1.RegenerateRecoveryCodesRequest
+	49, // 44: ztcp.auth.v1.AuthService.StartPhoneChange:input_type -> ztcp.auth.v1.StartPhoneChangeRequest
+	51, // 45: ztcp.auth.v1.AuthService.ConfirmPhoneChange:input_type -> ztcp.auth.v1.ConfirmPhoneChangeRequest
+	53, // 46: ztcp.auth.v1.AuthService.StartEmailChange:input_type -> ztcp.auth.v1.StartEmailChangeRequest
+	55, // 47: ztcp.auth.v1.AuthService.ConfirmEmailChange:input_type -> ztcp.auth.v1.ConfirmEmailChangeRequest
+	57, // 48: ztcp.auth.v1.AuthService.CheckUsernameAvailability:input_type -> ztcp.auth.v1.CheckUsernameAvailabilityRequest
+	59, // 49: ztcp.auth.v1.AuthService.SetUsername:input_type -> ztcp.auth.v1.SetUsernameRequest
+	61, // 50: ztcp.auth.v1.AuthService.AdminResetMFA:input_type -> ztcp.auth.v1.AdminResetMFARequest
+	17, // 51: ztcp.auth.v1.AuthService.ResumeLogin:input_type -> ztcp.auth.v1.ResumeLoginRequest
+	19, // 52: ztcp.auth.v1.AuthService.ApproveLogin:input_type -> ztcp.auth.v1.ApproveLoginRequest
+	21, // 53: ztcp.auth.v1.AuthService.DenyLogin:input_type -> ztcp.auth.v1.DenyLoginRequest
+	23, // 54: ztcp.auth.v1.AuthService.ListLoginHolds:input_type -> ztcp.auth.v1.ListLoginHoldsRequest
+	25, // 55: ztcp.auth.v1.AuthService.StartDeviceAuthorization:input_type -> ztcp.auth.v1.StartDeviceAuthorizationRequest
+	27, // 56: ztcp.auth.v1.AuthService.PollDeviceAuthorization:input_type -> ztcp.auth.v1.PollDeviceAuthorizationRequest
+	29, // 57: ztcp.auth.v1.AuthService.ApproveDeviceCode:input_type -> ztcp.auth.v1.ApproveDeviceCodeRequest
+	31, // 58: ztcp.auth.v1.AuthService.DenyDeviceCode:input_type -> ztcp.auth.v1.DenyDeviceCodeRequest
+	33, // 59: ztcp.auth.v1.AuthService.RequestMagicLink:input_type -> ztcp.auth.v1.RequestMagicLinkRequest
+	35, // 60: ztcp.auth.v1.AuthService.CompleteMagicLink:input_type -> ztcp.auth.v1.CompleteMagicLinkRequest
+	63, // 61: ztcp.auth.v1.AuthService.GetJWKS:input_type -> ztcp.auth.v1.GetJWKSRequest
+	65, // 62: ztcp.auth.v1.AuthService.Introspect:input_type -> ztcp.auth.v1.IntrospectRequest
+	67, // 63: ztcp.auth.v1.AuthService.LogoutAllMySessions:input_type -> ztcp.auth.v1.LogoutAllMySessionsRequest
+	12, // 64: ztcp.auth.v1.AuthService.Register:output_type -> ztcp.auth.v1.AuthResponse
+	16, // 65: ztcp.auth.v1.AuthService.Login:output_type -> ztcp.auth.v1.LoginResponse
+	12, // 66: ztcp.auth.v1.AuthService.VerifyMFA:output_type -> ztcp.auth.v1.AuthResponse
+	38, // 67: ztcp.auth.v1.AuthService.SubmitPhoneAndRequestMFA:output_type -> ztcp.auth.v1.SubmitPhoneAndRequestMFAResponse
+	40, // 68: ztcp.auth.v1.AuthService.ResendMFACode:output_type -> ztcp.auth.v1.ResendMFACodeResponse
+	5,  // 69: ztcp.auth.v1.AuthService.Refresh:output_type -> ztcp.auth.v1.RefreshResponse
+	72, // 70: ztcp.auth.v1.AuthService.Logout:output_type -> google.protobuf.Empty
+	8,  // 71: ztcp.auth.v1.AuthService.VerifyCredentials:output_type -> ztcp.auth.v1.VerifyCredentialsResponse
+	11, // 72: ztcp.auth.v1.AuthService.DiscoverOrganizations:output_type -> ztcp.auth.v1.DiscoverOrganizationsResponse
+	42, // 73: ztcp.auth.v1.AuthService.LinkIdentity:output_type -> ztcp.auth.v1.LinkIdentityResponse
+	44, // 74: ztcp.auth.v1.AuthService.TokenExchange:output_type -> ztcp.auth.v1.TokenExchangeResponse
+	4,  // 75: ztcp.auth.v1.AuthService.CreateRefreshNonce:output_type -> ztcp.auth.v1.CreateRefreshNonceResponse
+	46, // 76: ztcp.auth.v1.AuthService.ChangePassword:output_type -> ztcp.auth.v1.ChangePasswordResponse
+	48, // 77: ztcp.auth.v1.AuthService.RegenerateRecoveryCodes:output_type -> ztcp.auth.v1.RegenerateRecoveryCodesResponse
+	50, // 78: ztcp.auth.v1.AuthService.StartPhoneChange:output_type -> ztcp.auth.v1.StartPhoneChangeResponse
+	52, // 79: ztcp.auth.v1.AuthService.ConfirmPhoneChange:output_type -> ztcp.auth.v1.ConfirmPhoneChangeResponse
+	54, // 80: ztcp.auth.v1.AuthService.StartEmailChange:output_type -> ztcp.auth.v1.StartEmailChangeResponse
+	56, // 81: ztcp.auth.v1.AuthService.ConfirmEmailChange:output_type -> ztcp.auth.v1.ConfirmEmailChangeResponse
+	58, // 82: ztcp.auth.v1.AuthService.CheckUsernameAvailability:output_type -> ztcp.auth.v1.CheckUsernameAvailabilityResponse
+	60, // 83: ztcp.auth.v1.AuthService.SetUsername:output_type -> ztcp.auth.v1.SetUsernameResponse
+	62, // 84: ztcp.auth.v1.AuthService.AdminResetMFA:output_type -> ztcp.auth.v1.AdminResetMFAResponse
+	16, // 85: ztcp.auth.v1.AuthService.ResumeLogin:output_type -> ztcp.auth.v1.LoginResponse
+	20, // 86: ztcp.auth.v1.AuthService.ApproveLogin:output_type -> ztcp.auth.v1.ApproveLoginResponse
+	22, // 87: ztcp.auth.v1.AuthService.DenyLogin:output_type -> ztcp.auth.v1.DenyLoginResponse
+	24, // 88: ztcp.auth.v1.AuthService.ListLoginHolds:output_type -> ztcp.auth.v1.ListLoginHoldsResponse
+	26, // 89: ztcp.auth.v1.AuthService.StartDeviceAuthorization:output_type -> ztcp.auth.v1.StartDeviceAuthorizationResponse
+	12, // 90: ztcp.auth.v1.AuthService.PollDeviceAuthorization:output_type -> ztcp.auth.v1.AuthResponse
+	30, // 91: ztcp.auth.v1.AuthService.ApproveDeviceCode:output_type -> ztcp.auth.v1.ApproveDeviceCodeResponse
+	32, // 92: ztcp.auth.v1.AuthService.DenyDeviceCode:output_type -> ztcp.auth.v1.DenyDeviceCodeResponse
+	34, // 93: ztcp.auth.v1.AuthService.RequestMagicLink:output_type -> ztcp.auth.v1.RequestMagicLinkResponse
+	16, // 94: ztcp.auth.v1.AuthService.CompleteMagicLink:output_type -> ztcp.auth.v1.LoginResponse
+	64, // 95: ztcp.auth.v1.AuthService.GetJWKS:output_type -> ztcp.auth.v1.GetJWKSResponse
+	66, // 96: ztcp.auth.v1.AuthService.Introspect:output_type -> ztcp.auth.v1.IntrospectResponse
+	68, // 97: ztcp.auth.v1.AuthService.LogoutAllMySessions:output_type -> ztcp.auth.v1.LogoutAllMySessionsResponse
+	64, // [64:98] is the sub-list for method output_type
+	30, // [30:64] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_auth_auth_proto_init() }
@@ -1140,15 +4751,16 @@ func file_auth_auth_proto_init() {
 	if File_auth_auth_proto != nil {
 		return
 	}
-	file_auth_auth_proto_msgTypes[3].OneofWrappers = []any{
+	file_auth_auth_proto_msgTypes[5].OneofWrappers = []any{
 		(*RefreshResponse_Tokens)(nil),
 		(*RefreshResponse_MfaRequired)(nil),
 		(*RefreshResponse_PhoneRequired)(nil),
 	}
-	file_auth_auth_proto_msgTypes[10].OneofWrappers = []any{
+	file_auth_auth_proto_msgTypes[16].OneofWrappers = []any{
 		(*LoginResponse_Tokens)(nil),
 		(*LoginResponse_MfaRequired)(nil),
 		(*LoginResponse_PhoneRequired)(nil),
+		(*LoginResponse_ApprovalRequired)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1156,7 +4768,7 @@ func file_auth_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_auth_proto_rawDesc), len(file_auth_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   69,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
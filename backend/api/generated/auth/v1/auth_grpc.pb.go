@@ -20,14 +20,40 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Register_FullMethodName                 = "/ztcp.auth.v1.AuthService/Register"
-	AuthService_Login_FullMethodName                    = "/ztcp.auth.v1.AuthService/Login"
-	AuthService_VerifyMFA_FullMethodName                = "/ztcp.auth.v1.AuthService/VerifyMFA"
-	AuthService_SubmitPhoneAndRequestMFA_FullMethodName = "/ztcp.auth.v1.AuthService/SubmitPhoneAndRequestMFA"
-	AuthService_Refresh_FullMethodName                  = "/ztcp.auth.v1.AuthService/Refresh"
-	AuthService_Logout_FullMethodName                   = "/ztcp.auth.v1.AuthService/Logout"
-	AuthService_VerifyCredentials_FullMethodName        = "/ztcp.auth.v1.AuthService/VerifyCredentials"
-	AuthService_LinkIdentity_FullMethodName             = "/ztcp.auth.v1.AuthService/LinkIdentity"
+	AuthService_Register_FullMethodName                  = "/ztcp.auth.v1.AuthService/Register"
+	AuthService_Login_FullMethodName                     = "/ztcp.auth.v1.AuthService/Login"
+	AuthService_VerifyMFA_FullMethodName                 = "/ztcp.auth.v1.AuthService/VerifyMFA"
+	AuthService_SubmitPhoneAndRequestMFA_FullMethodName  = "/ztcp.auth.v1.AuthService/SubmitPhoneAndRequestMFA"
+	AuthService_ResendMFACode_FullMethodName             = "/ztcp.auth.v1.AuthService/ResendMFACode"
+	AuthService_Refresh_FullMethodName                   = "/ztcp.auth.v1.AuthService/Refresh"
+	AuthService_Logout_FullMethodName                    = "/ztcp.auth.v1.AuthService/Logout"
+	AuthService_VerifyCredentials_FullMethodName         = "/ztcp.auth.v1.AuthService/VerifyCredentials"
+	AuthService_DiscoverOrganizations_FullMethodName     = "/ztcp.auth.v1.AuthService/DiscoverOrganizations"
+	AuthService_LinkIdentity_FullMethodName              = "/ztcp.auth.v1.AuthService/LinkIdentity"
+	AuthService_TokenExchange_FullMethodName             = "/ztcp.auth.v1.AuthService/TokenExchange"
+	AuthService_CreateRefreshNonce_FullMethodName        = "/ztcp.auth.v1.AuthService/CreateRefreshNonce"
+	AuthService_ChangePassword_FullMethodName            = "/ztcp.auth.v1.AuthService/ChangePassword"
+	AuthService_RegenerateRecoveryCodes_FullMethodName   = "/ztcp.auth.v1.AuthService/RegenerateRecoveryCodes"
+	AuthService_StartPhoneChange_FullMethodName          = "/ztcp.auth.v1.AuthService/StartPhoneChange"
+	AuthService_ConfirmPhoneChange_FullMethodName        = "/ztcp.auth.v1.AuthService/ConfirmPhoneChange"
+	AuthService_StartEmailChange_FullMethodName          = "/ztcp.auth.v1.AuthService/StartEmailChange"
+	AuthService_ConfirmEmailChange_FullMethodName        = "/ztcp.auth.v1.AuthService/ConfirmEmailChange"
+	AuthService_CheckUsernameAvailability_FullMethodName = "/ztcp.auth.v1.AuthService/CheckUsernameAvailability"
+	AuthService_SetUsername_FullMethodName               = "/ztcp.auth.v1.AuthService/SetUsername"
+	AuthService_AdminResetMFA_FullMethodName             = "/ztcp.auth.v1.AuthService/AdminResetMFA"
+	AuthService_ResumeLogin_FullMethodName               = "/ztcp.auth.v1.AuthService/ResumeLogin"
+	AuthService_ApproveLogin_FullMethodName              = "/ztcp.auth.v1.AuthService/ApproveLogin"
+	AuthService_DenyLogin_FullMethodName                 = "/ztcp.auth.v1.AuthService/DenyLogin"
+	AuthService_ListLoginHolds_FullMethodName            = "/ztcp.auth.v1.AuthService/ListLoginHolds"
+	AuthService_StartDeviceAuthorization_FullMethodName  = "/ztcp.auth.v1.AuthService/StartDeviceAuthorization"
+	AuthService_PollDeviceAuthorization_FullMethodName   = "/ztcp.auth.v1.AuthService/PollDeviceAuthorization"
+	AuthService_ApproveDeviceCode_FullMethodName         = "/ztcp.auth.v1.AuthService/ApproveDeviceCode"
+	AuthService_DenyDeviceCode_FullMethodName            = "/ztcp.auth.v1.AuthService/DenyDeviceCode"
+	AuthService_RequestMagicLink_FullMethodName          = "/ztcp.auth.v1.AuthService/RequestMagicLink"
+	AuthService_CompleteMagicLink_FullMethodName         = "/ztcp.auth.v1.AuthService/CompleteMagicLink"
+	AuthService_GetJWKS_FullMethodName                   = "/ztcp.auth.v1.AuthService/GetJWKS"
+	AuthService_Introspect_FullMethodName                = "/ztcp.auth.v1.AuthService/Introspect"
+	AuthService_LogoutAllMySessions_FullMethodName       = "/ztcp.auth.v1.AuthService/LogoutAllMySessions"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -40,10 +66,36 @@ type AuthServiceClient interface {
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	SubmitPhoneAndRequestMFA(ctx context.Context, in *SubmitPhoneAndRequestMFARequest, opts ...grpc.CallOption) (*SubmitPhoneAndRequestMFAResponse, error)
+	ResendMFACode(ctx context.Context, in *ResendMFACodeRequest, opts ...grpc.CallOption) (*ResendMFACodeResponse, error)
 	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	VerifyCredentials(ctx context.Context, in *VerifyCredentialsRequest, opts ...grpc.CallOption) (*VerifyCredentialsResponse, error)
+	DiscoverOrganizations(ctx context.Context, in *DiscoverOrganizationsRequest, opts ...grpc.CallOption) (*DiscoverOrganizationsResponse, error)
 	LinkIdentity(ctx context.Context, in *LinkIdentityRequest, opts ...grpc.CallOption) (*LinkIdentityResponse, error)
+	TokenExchange(ctx context.Context, in *TokenExchangeRequest, opts ...grpc.CallOption) (*TokenExchangeResponse, error)
+	CreateRefreshNonce(ctx context.Context, in *CreateRefreshNonceRequest, opts ...grpc.CallOption) (*CreateRefreshNonceResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error)
+	StartPhoneChange(ctx context.Context, in *StartPhoneChangeRequest, opts ...grpc.CallOption) (*StartPhoneChangeResponse, error)
+	ConfirmPhoneChange(ctx context.Context, in *ConfirmPhoneChangeRequest, opts ...grpc.CallOption) (*ConfirmPhoneChangeResponse, error)
+	StartEmailChange(ctx context.Context, in *StartEmailChangeRequest, opts ...grpc.CallOption) (*StartEmailChangeResponse, error)
+	ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error)
+	CheckUsernameAvailability(ctx context.Context, in *CheckUsernameAvailabilityRequest, opts ...grpc.CallOption) (*CheckUsernameAvailabilityResponse, error)
+	SetUsername(ctx context.Context, in *SetUsernameRequest, opts ...grpc.CallOption) (*SetUsernameResponse, error)
+	AdminResetMFA(ctx context.Context, in *AdminResetMFARequest, opts ...grpc.CallOption) (*AdminResetMFAResponse, error)
+	ResumeLogin(ctx context.Context, in *ResumeLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	ApproveLogin(ctx context.Context, in *ApproveLoginRequest, opts ...grpc.CallOption) (*ApproveLoginResponse, error)
+	DenyLogin(ctx context.Context, in *DenyLoginRequest, opts ...grpc.CallOption) (*DenyLoginResponse, error)
+	ListLoginHolds(ctx context.Context, in *ListLoginHoldsRequest, opts ...grpc.CallOption) (*ListLoginHoldsResponse, error)
+	StartDeviceAuthorization(ctx context.Context, in *StartDeviceAuthorizationRequest, opts ...grpc.CallOption) (*StartDeviceAuthorizationResponse, error)
+	PollDeviceAuthorization(ctx context.Context, in *PollDeviceAuthorizationRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	ApproveDeviceCode(ctx context.Context, in *ApproveDeviceCodeRequest, opts ...grpc.CallOption) (*ApproveDeviceCodeResponse, error)
+	DenyDeviceCode(ctx context.Context, in *DenyDeviceCodeRequest, opts ...grpc.CallOption) (*DenyDeviceCodeResponse, error)
+	RequestMagicLink(ctx context.Context, in *RequestMagicLinkRequest, opts ...grpc.CallOption) (*RequestMagicLinkResponse, error)
+	CompleteMagicLink(ctx context.Context, in *CompleteMagicLinkRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	GetJWKS(ctx context.Context, in *GetJWKSRequest, opts ...grpc.CallOption) (*GetJWKSResponse, error)
+	Introspect(ctx context.Context, in *IntrospectRequest, opts ...grpc.CallOption) (*IntrospectResponse, error)
+	LogoutAllMySessions(ctx context.Context, in *LogoutAllMySessionsRequest, opts ...grpc.CallOption) (*LogoutAllMySessionsResponse, error)
 }
 
 type authServiceClient struct {
@@ -94,6 +146,16 @@ func (c *authServiceClient) SubmitPhoneAndRequestMFA(ctx context.Context, in *Su
 	return out, nil
 }
 
+func (c *authServiceClient) ResendMFACode(ctx context.Context, in *ResendMFACodeRequest, opts ...grpc.CallOption) (*ResendMFACodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendMFACodeResponse)
+	err := c.cc.Invoke(ctx, AuthService_ResendMFACode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RefreshResponse)
@@ -124,6 +186,16 @@ func (c *authServiceClient) VerifyCredentials(ctx context.Context, in *VerifyCre
 	return out, nil
 }
 
+func (c *authServiceClient) DiscoverOrganizations(ctx context.Context, in *DiscoverOrganizationsRequest, opts ...grpc.CallOption) (*DiscoverOrganizationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverOrganizationsResponse)
+	err := c.cc.Invoke(ctx, AuthService_DiscoverOrganizations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) LinkIdentity(ctx context.Context, in *LinkIdentityRequest, opts ...grpc.CallOption) (*LinkIdentityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LinkIdentityResponse)
@@ -134,6 +206,246 @@ func (c *authServiceClient) LinkIdentity(ctx context.Context, in *LinkIdentityRe
 	return out, nil
 }
 
+func (c *authServiceClient) TokenExchange(ctx context.Context, in *TokenExchangeRequest, opts ...grpc.CallOption) (*TokenExchangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenExchangeResponse)
+	err := c.cc.Invoke(ctx, AuthService_TokenExchange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CreateRefreshNonce(ctx context.Context, in *CreateRefreshNonceRequest, opts ...grpc.CallOption) (*CreateRefreshNonceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRefreshNonceResponse)
+	err := c.cc.Invoke(ctx, AuthService_CreateRefreshNonce_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, AuthService_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateRecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, AuthService_RegenerateRecoveryCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartPhoneChange(ctx context.Context, in *StartPhoneChangeRequest, opts ...grpc.CallOption) (*StartPhoneChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartPhoneChangeResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartPhoneChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ConfirmPhoneChange(ctx context.Context, in *ConfirmPhoneChangeRequest, opts ...grpc.CallOption) (*ConfirmPhoneChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmPhoneChangeResponse)
+	err := c.cc.Invoke(ctx, AuthService_ConfirmPhoneChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartEmailChange(ctx context.Context, in *StartEmailChangeRequest, opts ...grpc.CallOption) (*StartEmailChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartEmailChangeResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartEmailChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*ConfirmEmailChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmEmailChangeResponse)
+	err := c.cc.Invoke(ctx, AuthService_ConfirmEmailChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CheckUsernameAvailability(ctx context.Context, in *CheckUsernameAvailabilityRequest, opts ...grpc.CallOption) (*CheckUsernameAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckUsernameAvailabilityResponse)
+	err := c.cc.Invoke(ctx, AuthService_CheckUsernameAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetUsername(ctx context.Context, in *SetUsernameRequest, opts ...grpc.CallOption) (*SetUsernameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUsernameResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetUsername_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AdminResetMFA(ctx context.Context, in *AdminResetMFARequest, opts ...grpc.CallOption) (*AdminResetMFAResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminResetMFAResponse)
+	err := c.cc.Invoke(ctx, AuthService_AdminResetMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResumeLogin(ctx context.Context, in *ResumeLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_ResumeLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ApproveLogin(ctx context.Context, in *ApproveLoginRequest, opts ...grpc.CallOption) (*ApproveLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveLoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_ApproveLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DenyLogin(ctx context.Context, in *DenyLoginRequest, opts ...grpc.CallOption) (*DenyLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DenyLoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_DenyLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListLoginHolds(ctx context.Context, in *ListLoginHoldsRequest, opts ...grpc.CallOption) (*ListLoginHoldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLoginHoldsResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListLoginHolds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartDeviceAuthorization(ctx context.Context, in *StartDeviceAuthorizationRequest, opts ...grpc.CallOption) (*StartDeviceAuthorizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartDeviceAuthorizationResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartDeviceAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) PollDeviceAuthorization(ctx context.Context, in *PollDeviceAuthorizationRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_PollDeviceAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ApproveDeviceCode(ctx context.Context, in *ApproveDeviceCodeRequest, opts ...grpc.CallOption) (*ApproveDeviceCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeviceCodeResponse)
+	err := c.cc.Invoke(ctx, AuthService_ApproveDeviceCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DenyDeviceCode(ctx context.Context, in *DenyDeviceCodeRequest, opts ...grpc.CallOption) (*DenyDeviceCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DenyDeviceCodeResponse)
+	err := c.cc.Invoke(ctx, AuthService_DenyDeviceCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestMagicLink(ctx context.Context, in *RequestMagicLinkRequest, opts ...grpc.CallOption) (*RequestMagicLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestMagicLinkResponse)
+	err := c.cc.Invoke(ctx, AuthService_RequestMagicLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CompleteMagicLink(ctx context.Context, in *CompleteMagicLinkRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_CompleteMagicLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetJWKS(ctx context.Context, in *GetJWKSRequest, opts ...grpc.CallOption) (*GetJWKSResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJWKSResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetJWKS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Introspect(ctx context.Context, in *IntrospectRequest, opts ...grpc.CallOption) (*IntrospectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IntrospectResponse)
+	err := c.cc.Invoke(ctx, AuthService_Introspect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LogoutAllMySessions(ctx context.Context, in *LogoutAllMySessionsRequest, opts ...grpc.CallOption) (*LogoutAllMySessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutAllMySessionsResponse)
+	err := c.cc.Invoke(ctx, AuthService_LogoutAllMySessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -144,10 +456,36 @@ type AuthServiceServer interface {
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	VerifyMFA(context.Context, *VerifyMFARequest) (*AuthResponse, error)
 	SubmitPhoneAndRequestMFA(context.Context, *SubmitPhoneAndRequestMFARequest) (*SubmitPhoneAndRequestMFAResponse, error)
+	ResendMFACode(context.Context, *ResendMFACodeRequest) (*ResendMFACodeResponse, error)
 	Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error)
 	Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error)
 	VerifyCredentials(context.Context, *VerifyCredentialsRequest) (*VerifyCredentialsResponse, error)
+	DiscoverOrganizations(context.Context, *DiscoverOrganizationsRequest) (*DiscoverOrganizationsResponse, error)
 	LinkIdentity(context.Context, *LinkIdentityRequest) (*LinkIdentityResponse, error)
+	TokenExchange(context.Context, *TokenExchangeRequest) (*TokenExchangeResponse, error)
+	CreateRefreshNonce(context.Context, *CreateRefreshNonceRequest) (*CreateRefreshNonceResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error)
+	StartPhoneChange(context.Context, *StartPhoneChangeRequest) (*StartPhoneChangeResponse, error)
+	ConfirmPhoneChange(context.Context, *ConfirmPhoneChangeRequest) (*ConfirmPhoneChangeResponse, error)
+	StartEmailChange(context.Context, *StartEmailChangeRequest) (*StartEmailChangeResponse, error)
+	ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error)
+	CheckUsernameAvailability(context.Context, *CheckUsernameAvailabilityRequest) (*CheckUsernameAvailabilityResponse, error)
+	SetUsername(context.Context, *SetUsernameRequest) (*SetUsernameResponse, error)
+	AdminResetMFA(context.Context, *AdminResetMFARequest) (*AdminResetMFAResponse, error)
+	ResumeLogin(context.Context, *ResumeLoginRequest) (*LoginResponse, error)
+	ApproveLogin(context.Context, *ApproveLoginRequest) (*ApproveLoginResponse, error)
+	DenyLogin(context.Context, *DenyLoginRequest) (*DenyLoginResponse, error)
+	ListLoginHolds(context.Context, *ListLoginHoldsRequest) (*ListLoginHoldsResponse, error)
+	StartDeviceAuthorization(context.Context, *StartDeviceAuthorizationRequest) (*StartDeviceAuthorizationResponse, error)
+	PollDeviceAuthorization(context.Context, *PollDeviceAuthorizationRequest) (*AuthResponse, error)
+	ApproveDeviceCode(context.Context, *ApproveDeviceCodeRequest) (*ApproveDeviceCodeResponse, error)
+	DenyDeviceCode(context.Context, *DenyDeviceCodeRequest) (*DenyDeviceCodeResponse, error)
+	RequestMagicLink(context.Context, *RequestMagicLinkRequest) (*RequestMagicLinkResponse, error)
+	CompleteMagicLink(context.Context, *CompleteMagicLinkRequest) (*LoginResponse, error)
+	GetJWKS(context.Context, *GetJWKSRequest) (*GetJWKSResponse, error)
+	Introspect(context.Context, *IntrospectRequest) (*IntrospectResponse, error)
+	LogoutAllMySessions(context.Context, *LogoutAllMySessionsRequest) (*LogoutAllMySessionsResponse, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -170,6 +508,9 @@ func (UnimplementedAuthServiceServer) VerifyMFA(context.Context, *VerifyMFAReque
 func (UnimplementedAuthServiceServer) SubmitPhoneAndRequestMFA(context.Context, *SubmitPhoneAndRequestMFARequest) (*SubmitPhoneAndRequestMFAResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitPhoneAndRequestMFA not implemented")
 }
+func (UnimplementedAuthServiceServer) ResendMFACode(context.Context, *ResendMFACodeRequest) (*ResendMFACodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResendMFACode not implemented")
+}
 func (UnimplementedAuthServiceServer) Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Refresh not implemented")
 }
@@ -179,9 +520,84 @@ func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*
 func (UnimplementedAuthServiceServer) VerifyCredentials(context.Context, *VerifyCredentialsRequest) (*VerifyCredentialsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method VerifyCredentials not implemented")
 }
+func (UnimplementedAuthServiceServer) DiscoverOrganizations(context.Context, *DiscoverOrganizationsRequest) (*DiscoverOrganizationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiscoverOrganizations not implemented")
+}
 func (UnimplementedAuthServiceServer) LinkIdentity(context.Context, *LinkIdentityRequest) (*LinkIdentityResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method LinkIdentity not implemented")
 }
+func (UnimplementedAuthServiceServer) TokenExchange(context.Context, *TokenExchangeRequest) (*TokenExchangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TokenExchange not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateRefreshNonce(context.Context, *CreateRefreshNonceRequest) (*CreateRefreshNonceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateRefreshNonce not implemented")
+}
+func (UnimplementedAuthServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedAuthServiceServer) RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
+func (UnimplementedAuthServiceServer) StartPhoneChange(context.Context, *StartPhoneChangeRequest) (*StartPhoneChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartPhoneChange not implemented")
+}
+func (UnimplementedAuthServiceServer) ConfirmPhoneChange(context.Context, *ConfirmPhoneChangeRequest) (*ConfirmPhoneChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmPhoneChange not implemented")
+}
+func (UnimplementedAuthServiceServer) StartEmailChange(context.Context, *StartEmailChangeRequest) (*StartEmailChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartEmailChange not implemented")
+}
+func (UnimplementedAuthServiceServer) ConfirmEmailChange(context.Context, *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmEmailChange not implemented")
+}
+func (UnimplementedAuthServiceServer) CheckUsernameAvailability(context.Context, *CheckUsernameAvailabilityRequest) (*CheckUsernameAvailabilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckUsernameAvailability not implemented")
+}
+func (UnimplementedAuthServiceServer) SetUsername(context.Context, *SetUsernameRequest) (*SetUsernameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUsername not implemented")
+}
+func (UnimplementedAuthServiceServer) AdminResetMFA(context.Context, *AdminResetMFARequest) (*AdminResetMFAResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdminResetMFA not implemented")
+}
+func (UnimplementedAuthServiceServer) ResumeLogin(context.Context, *ResumeLoginRequest) (*LoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) ApproveLogin(context.Context, *ApproveLoginRequest) (*ApproveLoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) DenyLogin(context.Context, *DenyLoginRequest) (*DenyLoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DenyLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) ListLoginHolds(context.Context, *ListLoginHoldsRequest) (*ListLoginHoldsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLoginHolds not implemented")
+}
+func (UnimplementedAuthServiceServer) StartDeviceAuthorization(context.Context, *StartDeviceAuthorizationRequest) (*StartDeviceAuthorizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartDeviceAuthorization not implemented")
+}
+func (UnimplementedAuthServiceServer) PollDeviceAuthorization(context.Context, *PollDeviceAuthorizationRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PollDeviceAuthorization not implemented")
+}
+func (UnimplementedAuthServiceServer) ApproveDeviceCode(context.Context, *ApproveDeviceCodeRequest) (*ApproveDeviceCodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveDeviceCode not implemented")
+}
+func (UnimplementedAuthServiceServer) DenyDeviceCode(context.Context, *DenyDeviceCodeRequest) (*DenyDeviceCodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DenyDeviceCode not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestMagicLink(context.Context, *RequestMagicLinkRequest) (*RequestMagicLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestMagicLink not implemented")
+}
+func (UnimplementedAuthServiceServer) CompleteMagicLink(context.Context, *CompleteMagicLinkRequest) (*LoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteMagicLink not implemented")
+}
+func (UnimplementedAuthServiceServer) GetJWKS(context.Context, *GetJWKSRequest) (*GetJWKSResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJWKS not implemented")
+}
+func (UnimplementedAuthServiceServer) Introspect(context.Context, *IntrospectRequest) (*IntrospectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Introspect not implemented")
+}
+func (UnimplementedAuthServiceServer) LogoutAllMySessions(context.Context, *LogoutAllMySessionsRequest) (*LogoutAllMySessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LogoutAllMySessions not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -275,6 +691,24 @@ func _AuthService_SubmitPhoneAndRequestMFA_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_ResendMFACode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendMFACodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResendMFACode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResendMFACode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResendMFACode(ctx, req.(*ResendMFACodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RefreshRequest)
 	if err := dec(in); err != nil {
@@ -329,6 +763,24 @@ func _AuthService_VerifyCredentials_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_DiscoverOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverOrganizationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DiscoverOrganizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DiscoverOrganizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DiscoverOrganizations(ctx, req.(*DiscoverOrganizationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_LinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LinkIdentityRequest)
 	if err := dec(in); err != nil {
@@ -347,29 +799,465 @@ func _AuthService_LinkIdentity_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var AuthService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "ztcp.auth.v1.AuthService",
-	HandlerType: (*AuthServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _AuthService_Register_Handler,
-		},
-		{
-			MethodName: "Login",
-			Handler:    _AuthService_Login_Handler,
-		},
-		{
-			MethodName: "VerifyMFA",
-			Handler:    _AuthService_VerifyMFA_Handler,
-		},
+func _AuthService_TokenExchange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenExchangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).TokenExchange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_TokenExchange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).TokenExchange(ctx, req.(*TokenExchangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateRefreshNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRefreshNonceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateRefreshNonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateRefreshNonce_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateRefreshNonce(ctx, req.(*CreateRefreshNonceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateRecoveryCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RegenerateRecoveryCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, req.(*RegenerateRecoveryCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartPhoneChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPhoneChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartPhoneChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartPhoneChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartPhoneChange(ctx, req.(*StartPhoneChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ConfirmPhoneChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPhoneChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ConfirmPhoneChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ConfirmPhoneChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ConfirmPhoneChange(ctx, req.(*ConfirmPhoneChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartEmailChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartEmailChange(ctx, req.(*StartEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ConfirmEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmEmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ConfirmEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ConfirmEmailChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ConfirmEmailChange(ctx, req.(*ConfirmEmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CheckUsernameAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckUsernameAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CheckUsernameAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CheckUsernameAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CheckUsernameAvailability(ctx, req.(*CheckUsernameAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetUsername(ctx, req.(*SetUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AdminResetMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminResetMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AdminResetMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AdminResetMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AdminResetMFA(ctx, req.(*AdminResetMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResumeLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResumeLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResumeLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResumeLogin(ctx, req.(*ResumeLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ApproveLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ApproveLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ApproveLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ApproveLogin(ctx, req.(*ApproveLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DenyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DenyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DenyLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DenyLogin(ctx, req.(*DenyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListLoginHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLoginHoldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListLoginHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListLoginHolds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListLoginHolds(ctx, req.(*ListLoginHoldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartDeviceAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartDeviceAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartDeviceAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartDeviceAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartDeviceAuthorization(ctx, req.(*StartDeviceAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_PollDeviceAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollDeviceAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).PollDeviceAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_PollDeviceAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).PollDeviceAuthorization(ctx, req.(*PollDeviceAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ApproveDeviceCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ApproveDeviceCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ApproveDeviceCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ApproveDeviceCode(ctx, req.(*ApproveDeviceCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DenyDeviceCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyDeviceCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DenyDeviceCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DenyDeviceCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DenyDeviceCode(ctx, req.(*DenyDeviceCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RequestMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestMagicLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestMagicLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestMagicLink(ctx, req.(*RequestMagicLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CompleteMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteMagicLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CompleteMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CompleteMagicLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CompleteMagicLink(ctx, req.(*CompleteMagicLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJWKSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetJWKS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetJWKS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetJWKS(ctx, req.(*GetJWKSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Introspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Introspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Introspect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Introspect(ctx, req.(*IntrospectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LogoutAllMySessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutAllMySessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LogoutAllMySessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LogoutAllMySessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LogoutAllMySessions(ctx, req.(*LogoutAllMySessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.auth.v1.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _AuthService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+		{
+			MethodName: "VerifyMFA",
+			Handler:    _AuthService_VerifyMFA_Handler,
+		},
 		{
 			MethodName: "SubmitPhoneAndRequestMFA",
 			Handler:    _AuthService_SubmitPhoneAndRequestMFA_Handler,
 		},
+		{
+			MethodName: "ResendMFACode",
+			Handler:    _AuthService_ResendMFACode_Handler,
+		},
 		{
 			MethodName: "Refresh",
 			Handler:    _AuthService_Refresh_Handler,
@@ -382,10 +1270,110 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyCredentials",
 			Handler:    _AuthService_VerifyCredentials_Handler,
 		},
+		{
+			MethodName: "DiscoverOrganizations",
+			Handler:    _AuthService_DiscoverOrganizations_Handler,
+		},
 		{
 			MethodName: "LinkIdentity",
 			Handler:    _AuthService_LinkIdentity_Handler,
 		},
+		{
+			MethodName: "TokenExchange",
+			Handler:    _AuthService_TokenExchange_Handler,
+		},
+		{
+			MethodName: "CreateRefreshNonce",
+			Handler:    _AuthService_CreateRefreshNonce_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _AuthService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _AuthService_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "StartPhoneChange",
+			Handler:    _AuthService_StartPhoneChange_Handler,
+		},
+		{
+			MethodName: "ConfirmPhoneChange",
+			Handler:    _AuthService_ConfirmPhoneChange_Handler,
+		},
+		{
+			MethodName: "StartEmailChange",
+			Handler:    _AuthService_StartEmailChange_Handler,
+		},
+		{
+			MethodName: "ConfirmEmailChange",
+			Handler:    _AuthService_ConfirmEmailChange_Handler,
+		},
+		{
+			MethodName: "CheckUsernameAvailability",
+			Handler:    _AuthService_CheckUsernameAvailability_Handler,
+		},
+		{
+			MethodName: "SetUsername",
+			Handler:    _AuthService_SetUsername_Handler,
+		},
+		{
+			MethodName: "AdminResetMFA",
+			Handler:    _AuthService_AdminResetMFA_Handler,
+		},
+		{
+			MethodName: "ResumeLogin",
+			Handler:    _AuthService_ResumeLogin_Handler,
+		},
+		{
+			MethodName: "ApproveLogin",
+			Handler:    _AuthService_ApproveLogin_Handler,
+		},
+		{
+			MethodName: "DenyLogin",
+			Handler:    _AuthService_DenyLogin_Handler,
+		},
+		{
+			MethodName: "ListLoginHolds",
+			Handler:    _AuthService_ListLoginHolds_Handler,
+		},
+		{
+			MethodName: "StartDeviceAuthorization",
+			Handler:    _AuthService_StartDeviceAuthorization_Handler,
+		},
+		{
+			MethodName: "PollDeviceAuthorization",
+			Handler:    _AuthService_PollDeviceAuthorization_Handler,
+		},
+		{
+			MethodName: "ApproveDeviceCode",
+			Handler:    _AuthService_ApproveDeviceCode_Handler,
+		},
+		{
+			MethodName: "DenyDeviceCode",
+			Handler:    _AuthService_DenyDeviceCode_Handler,
+		},
+		{
+			MethodName: "RequestMagicLink",
+			Handler:    _AuthService_RequestMagicLink_Handler,
+		},
+		{
+			MethodName: "CompleteMagicLink",
+			Handler:    _AuthService_CompleteMagicLink_Handler,
+		},
+		{
+			MethodName: "GetJWKS",
+			Handler:    _AuthService_GetJWKS_Handler,
+		},
+		{
+			MethodName: "Introspect",
+			Handler:    _AuthService_Introspect_Handler,
+		},
+		{
+			MethodName: "LogoutAllMySessions",
+			Handler:    _AuthService_LogoutAllMySessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/auth.proto",
@@ -0,0 +1,1354 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: breakglass/breakglass.proto
+
+package breakglassv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BreakGlassAccount is an org's break-glass account. The credential itself is returned only by
+// ProvisionBreakGlassAccount.
+type BreakGlassAccount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                      // org owner with no password; signs in only through SignIn
+	ProvisionedBy string                 `protobuf:"bytes,3,opt,name=provisioned_by,json=provisionedBy,proto3" json:"provisioned_by,omitempty"` // platform admin who (re)issued the credential
+	ProvisionedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=provisioned_at,json=provisionedAt,proto3" json:"provisioned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreakGlassAccount) Reset() {
+	*x = BreakGlassAccount{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreakGlassAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakGlassAccount) ProtoMessage() {}
+
+func (x *BreakGlassAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakGlassAccount.ProtoReflect.Descriptor instead.
+func (*BreakGlassAccount) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BreakGlassAccount) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *BreakGlassAccount) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BreakGlassAccount) GetProvisionedBy() string {
+	if x != nil {
+		return x.ProvisionedBy
+	}
+	return ""
+}
+
+func (x *BreakGlassAccount) GetProvisionedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProvisionedAt
+	}
+	return nil
+}
+
+// BreakGlassActivation is one use of an org's break-glass account.
+type BreakGlassActivation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the break-glass account's user
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`               // pending, active, ended, closed; lapsed for pending requests nobody approved in time
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,6,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	ApprovedBy    string                 `protobuf:"bytes,8,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"` // empty until approved
+	ApprovedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=approved_at,json=approvedAt,proto3" json:"approved_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // end of the access window; set on approval
+	EndedBy       string                 `protobuf:"bytes,11,opt,name=ended_by,json=endedBy,proto3" json:"ended_by,omitempty"`       // platform admin who ended the window early; empty when it ran out
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	ReportSummary string                 `protobuf:"bytes,13,opt,name=report_summary,json=reportSummary,proto3" json:"report_summary,omitempty"` // post-incident report; set when closed
+	ReportedBy    string                 `protobuf:"bytes,14,opt,name=reported_by,json=reportedBy,proto3" json:"reported_by,omitempty"`
+	ReportedAt    *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreakGlassActivation) Reset() {
+	*x = BreakGlassActivation{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreakGlassActivation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakGlassActivation) ProtoMessage() {}
+
+func (x *BreakGlassActivation) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakGlassActivation.ProtoReflect.Descriptor instead.
+func (*BreakGlassActivation) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BreakGlassActivation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *BreakGlassActivation) GetApprovedBy() string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetApprovedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ApprovedAt
+	}
+	return nil
+}
+
+func (x *BreakGlassActivation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *BreakGlassActivation) GetEndedBy() string {
+	if x != nil {
+		return x.EndedBy
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *BreakGlassActivation) GetReportSummary() string {
+	if x != nil {
+		return x.ReportSummary
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetReportedBy() string {
+	if x != nil {
+		return x.ReportedBy
+	}
+	return ""
+}
+
+func (x *BreakGlassActivation) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+// ReportEntry is an audit log entry written while the break-glass account was in use.
+type ReportEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Resource      string                 `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Metadata      string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	RequestId     string                 `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportEntry) Reset() {
+	*x = ReportEntry{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportEntry) ProtoMessage() {}
+
+func (x *ReportEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportEntry.ProtoReflect.Descriptor instead.
+func (*ReportEntry) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReportEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ReportEntry) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *ReportEntry) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ReportEntry) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *ReportEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ReportEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ProvisionBreakGlassAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProvisionBreakGlassAccountRequest) Reset() {
+	*x = ProvisionBreakGlassAccountRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisionBreakGlassAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisionBreakGlassAccountRequest) ProtoMessage() {}
+
+func (x *ProvisionBreakGlassAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisionBreakGlassAccountRequest.ProtoReflect.Descriptor instead.
+func (*ProvisionBreakGlassAccountRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProvisionBreakGlassAccountRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ProvisionBreakGlassAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *BreakGlassAccount     `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // the sealed credential; shown once, store it offline
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProvisionBreakGlassAccountResponse) Reset() {
+	*x = ProvisionBreakGlassAccountResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvisionBreakGlassAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisionBreakGlassAccountResponse) ProtoMessage() {}
+
+func (x *ProvisionBreakGlassAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisionBreakGlassAccountResponse.ProtoReflect.Descriptor instead.
+func (*ProvisionBreakGlassAccountResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProvisionBreakGlassAccountResponse) GetAccount() *BreakGlassAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *ProvisionBreakGlassAccountResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type RequestUnlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // required, max 2000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestUnlockRequest) Reset() {
+	*x = RequestUnlockRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestUnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestUnlockRequest) ProtoMessage() {}
+
+func (x *RequestUnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestUnlockRequest.ProtoReflect.Descriptor instead.
+func (*RequestUnlockRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RequestUnlockRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RequestUnlockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RequestUnlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activation    *BreakGlassActivation  `protobuf:"bytes,1,opt,name=activation,proto3" json:"activation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestUnlockResponse) Reset() {
+	*x = RequestUnlockResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestUnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestUnlockResponse) ProtoMessage() {}
+
+func (x *RequestUnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestUnlockResponse.ProtoReflect.Descriptor instead.
+func (*RequestUnlockResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RequestUnlockResponse) GetActivation() *BreakGlassActivation {
+	if x != nil {
+		return x.Activation
+	}
+	return nil
+}
+
+type ApproveUnlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveUnlockRequest) Reset() {
+	*x = ApproveUnlockRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveUnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveUnlockRequest) ProtoMessage() {}
+
+func (x *ApproveUnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveUnlockRequest.ProtoReflect.Descriptor instead.
+func (*ApproveUnlockRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ApproveUnlockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ApproveUnlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activation    *BreakGlassActivation  `protobuf:"bytes,1,opt,name=activation,proto3" json:"activation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveUnlockResponse) Reset() {
+	*x = ApproveUnlockResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveUnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveUnlockResponse) ProtoMessage() {}
+
+func (x *ApproveUnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveUnlockResponse.ProtoReflect.Descriptor instead.
+func (*ApproveUnlockResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ApproveUnlockResponse) GetActivation() *BreakGlassActivation {
+	if x != nil {
+		return x.Activation
+	}
+	return nil
+}
+
+type EndAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndAccessRequest) Reset() {
+	*x = EndAccessRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndAccessRequest) ProtoMessage() {}
+
+func (x *EndAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndAccessRequest.ProtoReflect.Descriptor instead.
+func (*EndAccessRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EndAccessRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EndAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activation    *BreakGlassActivation  `protobuf:"bytes,1,opt,name=activation,proto3" json:"activation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndAccessResponse) Reset() {
+	*x = EndAccessResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndAccessResponse) ProtoMessage() {}
+
+func (x *EndAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndAccessResponse.ProtoReflect.Descriptor instead.
+func (*EndAccessResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EndAccessResponse) GetActivation() *BreakGlassActivation {
+	if x != nil {
+		return x.Activation
+	}
+	return nil
+}
+
+// ListActivationsRequest lists the org's activations, newest first.
+type ListActivationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Pagination    *v1.Pagination         `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivationsRequest) Reset() {
+	*x = ListActivationsRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivationsRequest) ProtoMessage() {}
+
+func (x *ListActivationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivationsRequest.ProtoReflect.Descriptor instead.
+func (*ListActivationsRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListActivationsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListActivationsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListActivationsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Activations   []*BreakGlassActivation `protobuf:"bytes,1,rep,name=activations,proto3" json:"activations,omitempty"`
+	Pagination    *v1.PaginationResult    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivationsResponse) Reset() {
+	*x = ListActivationsResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivationsResponse) ProtoMessage() {}
+
+func (x *ListActivationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivationsResponse.ProtoReflect.Descriptor instead.
+func (*ListActivationsResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListActivationsResponse) GetActivations() []*BreakGlassActivation {
+	if x != nil {
+		return x.Activations
+	}
+	return nil
+}
+
+func (x *ListActivationsResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SignInRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignInRequest) Reset() {
+	*x = SignInRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInRequest) ProtoMessage() {}
+
+func (x *SignInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInRequest.ProtoReflect.Descriptor instead.
+func (*SignInRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SignInRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SignInRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// SignInResponse carries an access token for a session that ends with the access window. There is no refresh token.
+type SignInResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,4,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignInResponse) Reset() {
+	*x = SignInResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInResponse) ProtoMessage() {}
+
+func (x *SignInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInResponse.ProtoReflect.Descriptor instead.
+func (*SignInResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignInResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *SignInResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SignInResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SignInResponse) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SignInResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type GetReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportRequest) Reset() {
+	*x = GetReportRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportRequest) ProtoMessage() {}
+
+func (x *GetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReportRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetReportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetReportResponse is the post-incident report of an activation: the activation and everything the break-glass
+// account did from approval until the window ended, oldest first.
+type GetReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activation    *BreakGlassActivation  `protobuf:"bytes,1,opt,name=activation,proto3" json:"activation,omitempty"`
+	Entries       []*ReportEntry         `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Truncated     bool                   `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"` // more entries exist than were returned; read them from AuditService
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportResponse) Reset() {
+	*x = GetReportResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportResponse) ProtoMessage() {}
+
+func (x *GetReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportResponse.ProtoReflect.Descriptor instead.
+func (*GetReportResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetReportResponse) GetActivation() *BreakGlassActivation {
+	if x != nil {
+		return x.Activation
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetEntries() []*ReportEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type SubmitReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"` // required, max 2000 characters: what happened and why the account was needed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitReportRequest) Reset() {
+	*x = SubmitReportRequest{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReportRequest) ProtoMessage() {}
+
+func (x *SubmitReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReportRequest.ProtoReflect.Descriptor instead.
+func (*SubmitReportRequest) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubmitReportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitReportRequest) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+type SubmitReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activation    *BreakGlassActivation  `protobuf:"bytes,1,opt,name=activation,proto3" json:"activation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitReportResponse) Reset() {
+	*x = SubmitReportResponse{}
+	mi := &file_breakglass_breakglass_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReportResponse) ProtoMessage() {}
+
+func (x *SubmitReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breakglass_breakglass_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReportResponse.ProtoReflect.Descriptor instead.
+func (*SubmitReportResponse) Descriptor() ([]byte, []int) {
+	return file_breakglass_breakglass_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SubmitReportResponse) GetActivation() *BreakGlassActivation {
+	if x != nil {
+		return x.Activation
+	}
+	return nil
+}
+
+var File_breakglass_breakglass_proto protoreflect.FileDescriptor
+
+const file_breakglass_breakglass_proto_rawDesc = "" +
+	"\n" +
+	"\x1bbreakglass/breakglass.proto\x12\x12ztcp.breakglass.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xad\x01\n" +
+	"\x11BreakGlassAccount\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
+	"\x0eprovisioned_by\x18\x03 \x01(\tR\rprovisionedBy\x12A\n" +
+	"\x0eprovisioned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rprovisionedAt\"\xd8\x04\n" +
+	"\x14BreakGlassActivation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12!\n" +
+	"\frequested_by\x18\x06 \x01(\tR\vrequestedBy\x12=\n" +
+	"\frequested_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12\x1f\n" +
+	"\vapproved_by\x18\b \x01(\tR\n" +
+	"approvedBy\x12;\n" +
+	"\vapproved_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"approvedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x19\n" +
+	"\bended_by\x18\v \x01(\tR\aendedBy\x125\n" +
+	"\bended_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12%\n" +
+	"\x0ereport_summary\x18\r \x01(\tR\rreportSummary\x12\x1f\n" +
+	"\vreported_by\x18\x0e \x01(\tR\n" +
+	"reportedBy\x12;\n" +
+	"\vreported_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reportedAt\"\xc7\x01\n" +
+	"\vReportEntry\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x1a\n" +
+	"\bresource\x18\x02 \x01(\tR\bresource\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x05 \x01(\tR\trequestId\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\":\n" +
+	"!ProvisionBreakGlassAccountRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"}\n" +
+	"\"ProvisionBreakGlassAccountResponse\x12?\n" +
+	"\aaccount\x18\x01 \x01(\v2%.ztcp.breakglass.v1.BreakGlassAccountR\aaccount\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"E\n" +
+	"\x14RequestUnlockRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"a\n" +
+	"\x15RequestUnlockResponse\x12H\n" +
+	"\n" +
+	"activation\x18\x01 \x01(\v2(.ztcp.breakglass.v1.BreakGlassActivationR\n" +
+	"activation\"&\n" +
+	"\x14ApproveUnlockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"a\n" +
+	"\x15ApproveUnlockResponse\x12H\n" +
+	"\n" +
+	"activation\x18\x01 \x01(\v2(.ztcp.breakglass.v1.BreakGlassActivationR\n" +
+	"activation\"\"\n" +
+	"\x10EndAccessRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"]\n" +
+	"\x11EndAccessResponse\x12H\n" +
+	"\n" +
+	"activation\x18\x01 \x01(\v2(.ztcp.breakglass.v1.BreakGlassActivationR\n" +
+	"activation\"k\n" +
+	"\x16ListActivationsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12:\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\xa7\x01\n" +
+	"\x17ListActivationsResponse\x12J\n" +
+	"\vactivations\x18\x01 \x03(\v2(.ztcp.breakglass.v1.BreakGlassActivationR\vactivations\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\">\n" +
+	"\rSignInRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\xbd\x01\n" +
+	"\x0eSignInResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x04 \x01(\tR\x05orgId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x05 \x01(\tR\tsessionId\"\"\n" +
+	"\x10GetReportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb6\x01\n" +
+	"\x11GetReportResponse\x12H\n" +
+	"\n" +
+	"activation\x18\x01 \x01(\v2(.ztcp.breakglass.v1.BreakGlassActivationR\n" +
+	"activation\x129\n" +
+	"\aentries\x18\x02 \x03(\v2\x1f.ztcp.breakglass.v1.ReportEntryR\aentries\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\"?\n" +
+	"\x13SubmitReportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\"`\n" +
+	"\x14SubmitReportResponse\x12H\n" +
+	"\n" +
+	"activation\x18\x01 \x01(\v2(.ztcp.breakglass.v1.BreakGlassActivationR\n" +
+	"activation2\xcb\x06\n" +
+	"\x11BreakGlassService\x12\x8b\x01\n" +
+	"\x1aProvisionBreakGlassAccount\x125.ztcp.breakglass.v1.ProvisionBreakGlassAccountRequest\x1a6.ztcp.breakglass.v1.ProvisionBreakGlassAccountResponse\x12d\n" +
+	"\rRequestUnlock\x12(.ztcp.breakglass.v1.RequestUnlockRequest\x1a).ztcp.breakglass.v1.RequestUnlockResponse\x12d\n" +
+	"\rApproveUnlock\x12(.ztcp.breakglass.v1.ApproveUnlockRequest\x1a).ztcp.breakglass.v1.ApproveUnlockResponse\x12X\n" +
+	"\tEndAccess\x12$.ztcp.breakglass.v1.EndAccessRequest\x1a%.ztcp.breakglass.v1.EndAccessResponse\x12o\n" +
+	"\x0fListActivations\x12*.ztcp.breakglass.v1.ListActivationsRequest\x1a+.ztcp.breakglass.v1.ListActivationsResponse\"\x03\x90\x02\x01\x12O\n" +
+	"\x06SignIn\x12!.ztcp.breakglass.v1.SignInRequest\x1a\".ztcp.breakglass.v1.SignInResponse\x12]\n" +
+	"\tGetReport\x12$.ztcp.breakglass.v1.GetReportRequest\x1a%.ztcp.breakglass.v1.GetReportResponse\"\x03\x90\x02\x01\x12a\n" +
+	"\fSubmitReport\x12'.ztcp.breakglass.v1.SubmitReportRequest\x1a(.ztcp.breakglass.v1.SubmitReportResponseBKZIzero-trust-control-plane/backend/api/generated/breakglass/v1;breakglassv1b\x06proto3"
+
+var (
+	file_breakglass_breakglass_proto_rawDescOnce sync.Once
+	file_breakglass_breakglass_proto_rawDescData []byte
+)
+
+func file_breakglass_breakglass_proto_rawDescGZIP() []byte {
+	file_breakglass_breakglass_proto_rawDescOnce.Do(func() {
+		file_breakglass_breakglass_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_breakglass_breakglass_proto_rawDesc), len(file_breakglass_breakglass_proto_rawDesc)))
+	})
+	return file_breakglass_breakglass_proto_rawDescData
+}
+
+var file_breakglass_breakglass_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_breakglass_breakglass_proto_goTypes = []any{
+	(*BreakGlassAccount)(nil),                  // 0: ztcp.breakglass.v1.BreakGlassAccount
+	(*BreakGlassActivation)(nil),               // 1: ztcp.breakglass.v1.BreakGlassActivation
+	(*ReportEntry)(nil),                        // 2: ztcp.breakglass.v1.ReportEntry
+	(*ProvisionBreakGlassAccountRequest)(nil),  // 3: ztcp.breakglass.v1.ProvisionBreakGlassAccountRequest
+	(*ProvisionBreakGlassAccountResponse)(nil), // 4: ztcp.breakglass.v1.ProvisionBreakGlassAccountResponse
+	(*RequestUnlockRequest)(nil),               // 5: ztcp.breakglass.v1.RequestUnlockRequest
+	(*RequestUnlockResponse)(nil),              // 6: ztcp.breakglass.v1.RequestUnlockResponse
+	(*ApproveUnlockRequest)(nil),               // 7: ztcp.breakglass.v1.ApproveUnlockRequest
+	(*ApproveUnlockResponse)(nil),              // 8: ztcp.breakglass.v1.ApproveUnlockResponse
+	(*EndAccessRequest)(nil),                   // 9: ztcp.breakglass.v1.EndAccessRequest
+	(*EndAccessResponse)(nil),                  // 10: ztcp.breakglass.v1.EndAccessResponse
+	(*ListActivationsRequest)(nil),             // 11: ztcp.breakglass.v1.ListActivationsRequest
+	(*ListActivationsResponse)(nil),            // 12: ztcp.breakglass.v1.ListActivationsResponse
+	(*SignInRequest)(nil),                      // 13: ztcp.breakglass.v1.SignInRequest
+	(*SignInResponse)(nil),                     // 14: ztcp.breakglass.v1.SignInResponse
+	(*GetReportRequest)(nil),                   // 15: ztcp.breakglass.v1.GetReportRequest
+	(*GetReportResponse)(nil),                  // 16: ztcp.breakglass.v1.GetReportResponse
+	(*SubmitReportRequest)(nil),                // 17: ztcp.breakglass.v1.SubmitReportRequest
+	(*SubmitReportResponse)(nil),               // 18: ztcp.breakglass.v1.SubmitReportResponse
+	(*timestamppb.Timestamp)(nil),              // 19: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),                      // 20: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),                // 21: ztcp.common.v1.PaginationResult
+}
+var file_breakglass_breakglass_proto_depIdxs = []int32{
+	19, // 0: ztcp.breakglass.v1.BreakGlassAccount.provisioned_at:type_name -> google.protobuf.Timestamp
+	19, // 1: ztcp.breakglass.v1.BreakGlassActivation.requested_at:type_name -> google.protobuf.Timestamp
+	19, // 2: ztcp.breakglass.v1.BreakGlassActivation.approved_at:type_name -> google.protobuf.Timestamp
+	19, // 3: ztcp.breakglass.v1.BreakGlassActivation.expires_at:type_name -> google.protobuf.Timestamp
+	19, // 4: ztcp.breakglass.v1.BreakGlassActivation.ended_at:type_name -> google.protobuf.Timestamp
+	19, // 5: ztcp.breakglass.v1.BreakGlassActivation.reported_at:type_name -> google.protobuf.Timestamp
+	19, // 6: ztcp.breakglass.v1.ReportEntry.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: ztcp.breakglass.v1.ProvisionBreakGlassAccountResponse.account:type_name -> ztcp.breakglass.v1.BreakGlassAccount
+	1,  // 8: ztcp.breakglass.v1.RequestUnlockResponse.activation:type_name -> ztcp.breakglass.v1.BreakGlassActivation
+	1,  // 9: ztcp.breakglass.v1.ApproveUnlockResponse.activation:type_name -> ztcp.breakglass.v1.BreakGlassActivation
+	1,  // 10: ztcp.breakglass.v1.EndAccessResponse.activation:type_name -> ztcp.breakglass.v1.BreakGlassActivation
+	20, // 11: ztcp.breakglass.v1.ListActivationsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	1,  // 12: ztcp.breakglass.v1.ListActivationsResponse.activations:type_name -> ztcp.breakglass.v1.BreakGlassActivation
+	21, // 13: ztcp.breakglass.v1.ListActivationsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	19, // 14: ztcp.breakglass.v1.SignInResponse.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 15: ztcp.breakglass.v1.GetReportResponse.activation:type_name -> ztcp.breakglass.v1.BreakGlassActivation
+	2,  // 16: ztcp.breakglass.v1.GetReportResponse.entries:type_name -> ztcp.breakglass.v1.ReportEntry
+	1,  // 17: ztcp.breakglass.v1.SubmitReportResponse.activation:type_name -> ztcp.breakglass.v1.BreakGlassActivation
+	3,  // 18: ztcp.breakglass.v1.BreakGlassService.ProvisionBreakGlassAccount:input_type -> ztcp.breakglass.v1.ProvisionBreakGlassAccountRequest
+	5,  // 19: ztcp.breakglass.v1.BreakGlassService.RequestUnlock:input_type -> ztcp.breakglass.v1.RequestUnlockRequest
+	7,  // 20: ztcp.breakglass.v1.BreakGlassService.ApproveUnlock:input_type -> ztcp.breakglass.v1.ApproveUnlockRequest
+	9,  // 21: ztcp.breakglass.v1.BreakGlassService.EndAccess:input_type -> ztcp.breakglass.v1.EndAccessRequest
+	11, // 22: ztcp.breakglass.v1.BreakGlassService.ListActivations:input_type -> ztcp.breakglass.v1.ListActivationsRequest
+	13, // 23: ztcp.breakglass.v1.BreakGlassService.SignIn:input_type -> ztcp.breakglass.v1.SignInRequest
+	15, // 24: ztcp.breakglass.v1.BreakGlassService.GetReport:input_type -> ztcp.breakglass.v1.GetReportRequest
+	17, // 25: ztcp.breakglass.v1.BreakGlassService.SubmitReport:input_type -> ztcp.breakglass.v1.SubmitReportRequest
+	4,  // 26: ztcp.breakglass.v1.BreakGlassService.ProvisionBreakGlassAccount:output_type -> ztcp.breakglass.v1.ProvisionBreakGlassAccountResponse
+	6,  // 27: ztcp.breakglass.v1.BreakGlassService.RequestUnlock:output_type -> ztcp.breakglass.v1.RequestUnlockResponse
+	8,  // 28: ztcp.breakglass.v1.BreakGlassService.ApproveUnlock:output_type -> ztcp.breakglass.v1.ApproveUnlockResponse
+	10, // 29: ztcp.breakglass.v1.BreakGlassService.EndAccess:output_type -> ztcp.breakglass.v1.EndAccessResponse
+	12, // 30: ztcp.breakglass.v1.BreakGlassService.ListActivations:output_type -> ztcp.breakglass.v1.ListActivationsResponse
+	14, // 31: ztcp.breakglass.v1.BreakGlassService.SignIn:output_type -> ztcp.breakglass.v1.SignInResponse
+	16, // 32: ztcp.breakglass.v1.BreakGlassService.GetReport:output_type -> ztcp.breakglass.v1.GetReportResponse
+	18, // 33: ztcp.breakglass.v1.BreakGlassService.SubmitReport:output_type -> ztcp.breakglass.v1.SubmitReportResponse
+	26, // [26:34] is the sub-list for method output_type
+	18, // [18:26] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_breakglass_breakglass_proto_init() }
+func file_breakglass_breakglass_proto_init() {
+	if File_breakglass_breakglass_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_breakglass_breakglass_proto_rawDesc), len(file_breakglass_breakglass_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_breakglass_breakglass_proto_goTypes,
+		DependencyIndexes: file_breakglass_breakglass_proto_depIdxs,
+		MessageInfos:      file_breakglass_breakglass_proto_msgTypes,
+	}.Build()
+	File_breakglass_breakglass_proto = out.File
+	file_breakglass_breakglass_proto_goTypes = nil
+	file_breakglass_breakglass_proto_depIdxs = nil
+}
@@ -0,0 +1,417 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: breakglass/breakglass.proto
+
+package breakglassv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BreakGlassService_ProvisionBreakGlassAccount_FullMethodName = "/ztcp.breakglass.v1.BreakGlassService/ProvisionBreakGlassAccount"
+	BreakGlassService_RequestUnlock_FullMethodName              = "/ztcp.breakglass.v1.BreakGlassService/RequestUnlock"
+	BreakGlassService_ApproveUnlock_FullMethodName              = "/ztcp.breakglass.v1.BreakGlassService/ApproveUnlock"
+	BreakGlassService_EndAccess_FullMethodName                  = "/ztcp.breakglass.v1.BreakGlassService/EndAccess"
+	BreakGlassService_ListActivations_FullMethodName            = "/ztcp.breakglass.v1.BreakGlassService/ListActivations"
+	BreakGlassService_SignIn_FullMethodName                     = "/ztcp.breakglass.v1.BreakGlassService/SignIn"
+	BreakGlassService_GetReport_FullMethodName                  = "/ztcp.breakglass.v1.BreakGlassService/GetReport"
+	BreakGlassService_SubmitReport_FullMethodName               = "/ztcp.breakglass.v1.BreakGlassService/SubmitReport"
+)
+
+// BreakGlassServiceClient is the client API for BreakGlassService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BreakGlassService is emergency access to an org when its owners and admins are locked out. Each org can have one
+// platform-managed break-glass account: an org owner whose sealed credential only signs in while a second platform
+// admin has approved an unlock. Break-glass sign-ins skip MFA and org policy and get a session that ends with the
+// access window. Every step is audited and alerted (webhook, email), and the org cannot be unlocked again until the
+// last activation has its post-incident report. All RPCs except SignIn are for platform admins.
+type BreakGlassServiceClient interface {
+	// ProvisionBreakGlassAccount creates the org's break-glass account, or rotates its credential. Rotation is refused
+	// while the account is unlocked.
+	ProvisionBreakGlassAccount(ctx context.Context, in *ProvisionBreakGlassAccountRequest, opts ...grpc.CallOption) (*ProvisionBreakGlassAccountResponse, error)
+	// RequestUnlock asks for an access window. Refused while the org has a pending, active or unreported activation.
+	RequestUnlock(ctx context.Context, in *RequestUnlockRequest, opts ...grpc.CallOption) (*RequestUnlockResponse, error)
+	// ApproveUnlock starts the access window. The approver must be a different platform admin than the requester, and
+	// pending requests lapse after an hour.
+	ApproveUnlock(ctx context.Context, in *ApproveUnlockRequest, opts ...grpc.CallOption) (*ApproveUnlockResponse, error)
+	// EndAccess ends the access window early and revokes the break-glass sessions.
+	EndAccess(ctx context.Context, in *EndAccessRequest, opts ...grpc.CallOption) (*EndAccessResponse, error)
+	ListActivations(ctx context.Context, in *ListActivationsRequest, opts ...grpc.CallOption) (*ListActivationsResponse, error)
+	// SignIn exchanges the sealed credential for a session while the account is unlocked. Unauthenticated.
+	SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error)
+	// GetReport returns the audit trail of an activation for the post-incident report.
+	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
+	// SubmitReport closes an ended activation with its post-incident report.
+	SubmitReport(ctx context.Context, in *SubmitReportRequest, opts ...grpc.CallOption) (*SubmitReportResponse, error)
+}
+
+type breakGlassServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBreakGlassServiceClient(cc grpc.ClientConnInterface) BreakGlassServiceClient {
+	return &breakGlassServiceClient{cc}
+}
+
+func (c *breakGlassServiceClient) ProvisionBreakGlassAccount(ctx context.Context, in *ProvisionBreakGlassAccountRequest, opts ...grpc.CallOption) (*ProvisionBreakGlassAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProvisionBreakGlassAccountResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_ProvisionBreakGlassAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakGlassServiceClient) RequestUnlock(ctx context.Context, in *RequestUnlockRequest, opts ...grpc.CallOption) (*RequestUnlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestUnlockResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_RequestUnlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakGlassServiceClient) ApproveUnlock(ctx context.Context, in *ApproveUnlockRequest, opts ...grpc.CallOption) (*ApproveUnlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveUnlockResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_ApproveUnlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakGlassServiceClient) EndAccess(ctx context.Context, in *EndAccessRequest, opts ...grpc.CallOption) (*EndAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EndAccessResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_EndAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakGlassServiceClient) ListActivations(ctx context.Context, in *ListActivationsRequest, opts ...grpc.CallOption) (*ListActivationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActivationsResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_ListActivations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakGlassServiceClient) SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignInResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_SignIn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakGlassServiceClient) GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReportResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_GetReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakGlassServiceClient) SubmitReport(ctx context.Context, in *SubmitReportRequest, opts ...grpc.CallOption) (*SubmitReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitReportResponse)
+	err := c.cc.Invoke(ctx, BreakGlassService_SubmitReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BreakGlassServiceServer is the server API for BreakGlassService service.
+// All implementations must embed UnimplementedBreakGlassServiceServer
+// for forward compatibility.
+//
+// BreakGlassService is emergency access to an org when its owners and admins are locked out. Each org can have one
+// platform-managed break-glass account: an org owner whose sealed credential only signs in while a second platform
+// admin has approved an unlock. Break-glass sign-ins skip MFA and org policy and get a session that ends with the
+// access window. Every step is audited and alerted (webhook, email), and the org cannot be unlocked again until the
+// last activation has its post-incident report. All RPCs except SignIn are for platform admins.
+type BreakGlassServiceServer interface {
+	// ProvisionBreakGlassAccount creates the org's break-glass account, or rotates its credential. Rotation is refused
+	// while the account is unlocked.
+	ProvisionBreakGlassAccount(context.Context, *ProvisionBreakGlassAccountRequest) (*ProvisionBreakGlassAccountResponse, error)
+	// RequestUnlock asks for an access window. Refused while the org has a pending, active or unreported activation.
+	RequestUnlock(context.Context, *RequestUnlockRequest) (*RequestUnlockResponse, error)
+	// ApproveUnlock starts the access window. The approver must be a different platform admin than the requester, and
+	// pending requests lapse after an hour.
+	ApproveUnlock(context.Context, *ApproveUnlockRequest) (*ApproveUnlockResponse, error)
+	// EndAccess ends the access window early and revokes the break-glass sessions.
+	EndAccess(context.Context, *EndAccessRequest) (*EndAccessResponse, error)
+	ListActivations(context.Context, *ListActivationsRequest) (*ListActivationsResponse, error)
+	// SignIn exchanges the sealed credential for a session while the account is unlocked. Unauthenticated.
+	SignIn(context.Context, *SignInRequest) (*SignInResponse, error)
+	// GetReport returns the audit trail of an activation for the post-incident report.
+	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
+	// SubmitReport closes an ended activation with its post-incident report.
+	SubmitReport(context.Context, *SubmitReportRequest) (*SubmitReportResponse, error)
+	mustEmbedUnimplementedBreakGlassServiceServer()
+}
+
+// UnimplementedBreakGlassServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBreakGlassServiceServer struct{}
+
+func (UnimplementedBreakGlassServiceServer) ProvisionBreakGlassAccount(context.Context, *ProvisionBreakGlassAccountRequest) (*ProvisionBreakGlassAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProvisionBreakGlassAccount not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) RequestUnlock(context.Context, *RequestUnlockRequest) (*RequestUnlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestUnlock not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) ApproveUnlock(context.Context, *ApproveUnlockRequest) (*ApproveUnlockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveUnlock not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) EndAccess(context.Context, *EndAccessRequest) (*EndAccessResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EndAccess not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) ListActivations(context.Context, *ListActivationsRequest) (*ListActivationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListActivations not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) SignIn(context.Context, *SignInRequest) (*SignInResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SignIn not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) SubmitReport(context.Context, *SubmitReportRequest) (*SubmitReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitReport not implemented")
+}
+func (UnimplementedBreakGlassServiceServer) mustEmbedUnimplementedBreakGlassServiceServer() {}
+func (UnimplementedBreakGlassServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeBreakGlassServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BreakGlassServiceServer will
+// result in compilation errors.
+type UnsafeBreakGlassServiceServer interface {
+	mustEmbedUnimplementedBreakGlassServiceServer()
+}
+
+func RegisterBreakGlassServiceServer(s grpc.ServiceRegistrar, srv BreakGlassServiceServer) {
+	// If the following call panics, it indicates UnimplementedBreakGlassServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BreakGlassService_ServiceDesc, srv)
+}
+
+func _BreakGlassService_ProvisionBreakGlassAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionBreakGlassAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).ProvisionBreakGlassAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_ProvisionBreakGlassAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).ProvisionBreakGlassAccount(ctx, req.(*ProvisionBreakGlassAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakGlassService_RequestUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestUnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).RequestUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_RequestUnlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).RequestUnlock(ctx, req.(*RequestUnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakGlassService_ApproveUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveUnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).ApproveUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_ApproveUnlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).ApproveUnlock(ctx, req.(*ApproveUnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakGlassService_EndAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).EndAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_EndAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).EndAccess(ctx, req.(*EndAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakGlassService_ListActivations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActivationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).ListActivations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_ListActivations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).ListActivations(ctx, req.(*ListActivationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakGlassService_SignIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).SignIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_SignIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).SignIn(ctx, req.(*SignInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakGlassService_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_GetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).GetReport(ctx, req.(*GetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakGlassService_SubmitReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakGlassServiceServer).SubmitReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakGlassService_SubmitReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakGlassServiceServer).SubmitReport(ctx, req.(*SubmitReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BreakGlassService_ServiceDesc is the grpc.ServiceDesc for BreakGlassService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BreakGlassService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.breakglass.v1.BreakGlassService",
+	HandlerType: (*BreakGlassServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProvisionBreakGlassAccount",
+			Handler:    _BreakGlassService_ProvisionBreakGlassAccount_Handler,
+		},
+		{
+			MethodName: "RequestUnlock",
+			Handler:    _BreakGlassService_RequestUnlock_Handler,
+		},
+		{
+			MethodName: "ApproveUnlock",
+			Handler:    _BreakGlassService_ApproveUnlock_Handler,
+		},
+		{
+			MethodName: "EndAccess",
+			Handler:    _BreakGlassService_EndAccess_Handler,
+		},
+		{
+			MethodName: "ListActivations",
+			Handler:    _BreakGlassService_ListActivations_Handler,
+		},
+		{
+			MethodName: "SignIn",
+			Handler:    _BreakGlassService_SignIn_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _BreakGlassService_GetReport_Handler,
+		},
+		{
+			MethodName: "SubmitReport",
+			Handler:    _BreakGlassService_SubmitReport_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "breakglass/breakglass.proto",
+}
@@ -0,0 +1,1005 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: changerequest/changerequest.proto
+
+package changerequestv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v11 "zero-trust-control-plane/backend/api/generated/common/v1"
+	v1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ProposedPolicyConfig is an org policy config update, as in UpdateOrgPolicyConfigRequest.
+type ProposedPolicyConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *v1.OrgPolicyConfig    `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // top-level section names; empty = replace the whole config
+	Etag          string                 `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`                               // optional; the proposal fails if the config has changed since
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProposedPolicyConfig) Reset() {
+	*x = ProposedPolicyConfig{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposedPolicyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposedPolicyConfig) ProtoMessage() {}
+
+func (x *ProposedPolicyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposedPolicyConfig.ProtoReflect.Descriptor instead.
+func (*ProposedPolicyConfig) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProposedPolicyConfig) GetConfig() *v1.OrgPolicyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ProposedPolicyConfig) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *ProposedPolicyConfig) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// PolicyChange is a Rego policy write.
+type PolicyChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`               // create, update, delete
+	PolicyId      string                 `protobuf:"bytes,2,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"` // required for update and delete
+	Rules         string                 `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`                       // Rego; required for create and update
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyChange) Reset() {
+	*x = PolicyChange{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyChange) ProtoMessage() {}
+
+func (x *PolicyChange) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyChange.ProtoReflect.Descriptor instead.
+func (*PolicyChange) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PolicyChange) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *PolicyChange) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *PolicyChange) GetRules() string {
+	if x != nil {
+		return x.Rules
+	}
+	return ""
+}
+
+func (x *PolicyChange) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// ChangeRequest is a proposed policy change that takes effect only when a second admin approves it.
+type ChangeRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId      string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Kind       string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                               // org_policy_config, policy
+	Status     string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                           // pending, approved, rejected
+	ProposedBy string                 `protobuf:"bytes,5,opt,name=proposed_by,json=proposedBy,proto3" json:"proposed_by,omitempty"` // user ID
+	Reason     string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	// For kind org_policy_config: the config approval stores (merged with defaults), and the settings it changes
+	// relative to the config it was proposed against.
+	PolicyConfig        *v1.OrgPolicyConfig      `protobuf:"bytes,7,opt,name=policy_config,json=policyConfig,proto3" json:"policy_config,omitempty"`
+	PolicyConfigChanges []*v1.PolicyConfigChange `protobuf:"bytes,8,rep,name=policy_config_changes,json=policyConfigChanges,proto3" json:"policy_config_changes,omitempty"`
+	Policy              *PolicyChange            `protobuf:"bytes,9,opt,name=policy,proto3" json:"policy,omitempty"`                            // for kind policy
+	ReviewedBy          string                   `protobuf:"bytes,10,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"` // user ID; empty while pending
+	ReviewComment       string                   `protobuf:"bytes,11,opt,name=review_comment,json=reviewComment,proto3" json:"review_comment,omitempty"`
+	ReviewedAt          *timestamppb.Timestamp   `protobuf:"bytes,12,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	CreatedAt           *timestamppb.Timestamp   `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ChangeRequest) Reset() {
+	*x = ChangeRequest{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRequest) ProtoMessage() {}
+
+func (x *ChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRequest.ProtoReflect.Descriptor instead.
+func (*ChangeRequest) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetProposedBy() string {
+	if x != nil {
+		return x.ProposedBy
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetPolicyConfig() *v1.OrgPolicyConfig {
+	if x != nil {
+		return x.PolicyConfig
+	}
+	return nil
+}
+
+func (x *ChangeRequest) GetPolicyConfigChanges() []*v1.PolicyConfigChange {
+	if x != nil {
+		return x.PolicyConfigChanges
+	}
+	return nil
+}
+
+func (x *ChangeRequest) GetPolicy() *PolicyChange {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *ChangeRequest) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetReviewComment() string {
+	if x != nil {
+		return x.ReviewComment
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *ChangeRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ProposeChangeRequest proposes exactly one of policy_config and policy.
+type ProposeChangeRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	OrgId  string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // optional, max 1000 characters
+	// Types that are valid to be assigned to Change:
+	//
+	//	*ProposeChangeRequest_PolicyConfig
+	//	*ProposeChangeRequest_Policy
+	Change        isProposeChangeRequest_Change `protobuf_oneof:"change"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProposeChangeRequest) Reset() {
+	*x = ProposeChangeRequest{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposeChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposeChangeRequest) ProtoMessage() {}
+
+func (x *ProposeChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposeChangeRequest.ProtoReflect.Descriptor instead.
+func (*ProposeChangeRequest) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProposeChangeRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ProposeChangeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ProposeChangeRequest) GetChange() isProposeChangeRequest_Change {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *ProposeChangeRequest) GetPolicyConfig() *ProposedPolicyConfig {
+	if x != nil {
+		if x, ok := x.Change.(*ProposeChangeRequest_PolicyConfig); ok {
+			return x.PolicyConfig
+		}
+	}
+	return nil
+}
+
+func (x *ProposeChangeRequest) GetPolicy() *PolicyChange {
+	if x != nil {
+		if x, ok := x.Change.(*ProposeChangeRequest_Policy); ok {
+			return x.Policy
+		}
+	}
+	return nil
+}
+
+type isProposeChangeRequest_Change interface {
+	isProposeChangeRequest_Change()
+}
+
+type ProposeChangeRequest_PolicyConfig struct {
+	PolicyConfig *ProposedPolicyConfig `protobuf:"bytes,3,opt,name=policy_config,json=policyConfig,proto3,oneof"`
+}
+
+type ProposeChangeRequest_Policy struct {
+	Policy *PolicyChange `protobuf:"bytes,4,opt,name=policy,proto3,oneof"`
+}
+
+func (*ProposeChangeRequest_PolicyConfig) isProposeChangeRequest_Change() {}
+
+func (*ProposeChangeRequest_Policy) isProposeChangeRequest_Change() {}
+
+type ProposeChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeRequest *ChangeRequest         `protobuf:"bytes,1,opt,name=change_request,json=changeRequest,proto3" json:"change_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProposeChangeResponse) Reset() {
+	*x = ProposeChangeResponse{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposeChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposeChangeResponse) ProtoMessage() {}
+
+func (x *ProposeChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposeChangeResponse.ProtoReflect.Descriptor instead.
+func (*ProposeChangeResponse) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProposeChangeResponse) GetChangeRequest() *ChangeRequest {
+	if x != nil {
+		return x.ChangeRequest
+	}
+	return nil
+}
+
+type GetChangeRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChangeRequestRequest) Reset() {
+	*x = GetChangeRequestRequest{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangeRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangeRequestRequest) ProtoMessage() {}
+
+func (x *GetChangeRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangeRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetChangeRequestRequest) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetChangeRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetChangeRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeRequest *ChangeRequest         `protobuf:"bytes,1,opt,name=change_request,json=changeRequest,proto3" json:"change_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChangeRequestResponse) Reset() {
+	*x = GetChangeRequestResponse{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangeRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangeRequestResponse) ProtoMessage() {}
+
+func (x *GetChangeRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangeRequestResponse.ProtoReflect.Descriptor instead.
+func (*GetChangeRequestResponse) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetChangeRequestResponse) GetChangeRequest() *ChangeRequest {
+	if x != nil {
+		return x.ChangeRequest
+	}
+	return nil
+}
+
+// ListChangeRequestsRequest lists the org's change requests, newest first.
+type ListChangeRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // optional: pending, approved, rejected
+	Pagination    *v11.Pagination        `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangeRequestsRequest) Reset() {
+	*x = ListChangeRequestsRequest{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangeRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangeRequestsRequest) ProtoMessage() {}
+
+func (x *ListChangeRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangeRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListChangeRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListChangeRequestsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListChangeRequestsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListChangeRequestsRequest) GetPagination() *v11.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListChangeRequestsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChangeRequests []*ChangeRequest       `protobuf:"bytes,1,rep,name=change_requests,json=changeRequests,proto3" json:"change_requests,omitempty"`
+	Pagination     *v11.PaginationResult  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListChangeRequestsResponse) Reset() {
+	*x = ListChangeRequestsResponse{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangeRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangeRequestsResponse) ProtoMessage() {}
+
+func (x *ListChangeRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangeRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListChangeRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListChangeRequestsResponse) GetChangeRequests() []*ChangeRequest {
+	if x != nil {
+		return x.ChangeRequests
+	}
+	return nil
+}
+
+func (x *ListChangeRequestsResponse) GetPagination() *v11.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ApproveChangeRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // optional, max 1000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveChangeRequestRequest) Reset() {
+	*x = ApproveChangeRequestRequest{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveChangeRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveChangeRequestRequest) ProtoMessage() {}
+
+func (x *ApproveChangeRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveChangeRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveChangeRequestRequest) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApproveChangeRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApproveChangeRequestRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ApproveChangeRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeRequest *ChangeRequest         `protobuf:"bytes,1,opt,name=change_request,json=changeRequest,proto3" json:"change_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveChangeRequestResponse) Reset() {
+	*x = ApproveChangeRequestResponse{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveChangeRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveChangeRequestResponse) ProtoMessage() {}
+
+func (x *ApproveChangeRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveChangeRequestResponse.ProtoReflect.Descriptor instead.
+func (*ApproveChangeRequestResponse) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ApproveChangeRequestResponse) GetChangeRequest() *ChangeRequest {
+	if x != nil {
+		return x.ChangeRequest
+	}
+	return nil
+}
+
+type RejectChangeRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // optional, max 1000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectChangeRequestRequest) Reset() {
+	*x = RejectChangeRequestRequest{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectChangeRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectChangeRequestRequest) ProtoMessage() {}
+
+func (x *RejectChangeRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectChangeRequestRequest.ProtoReflect.Descriptor instead.
+func (*RejectChangeRequestRequest) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RejectChangeRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RejectChangeRequestRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RejectChangeRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangeRequest *ChangeRequest         `protobuf:"bytes,1,opt,name=change_request,json=changeRequest,proto3" json:"change_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectChangeRequestResponse) Reset() {
+	*x = RejectChangeRequestResponse{}
+	mi := &file_changerequest_changerequest_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectChangeRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectChangeRequestResponse) ProtoMessage() {}
+
+func (x *RejectChangeRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_changerequest_changerequest_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectChangeRequestResponse.ProtoReflect.Descriptor instead.
+func (*RejectChangeRequestResponse) Descriptor() ([]byte, []int) {
+	return file_changerequest_changerequest_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RejectChangeRequestResponse) GetChangeRequest() *ChangeRequest {
+	if x != nil {
+		return x.ChangeRequest
+	}
+	return nil
+}
+
+var File_changerequest_changerequest_proto protoreflect.FileDescriptor
+
+const file_changerequest_changerequest_proto_rawDesc = "" +
+	"\n" +
+	"!changerequest/changerequest.proto\x12\x15ztcp.changerequest.v1\x1a\x13common/common.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a%orgpolicyconfig/orgpolicyconfig.proto\"\xa9\x01\n" +
+	"\x14ProposedPolicyConfig\x12@\n" +
+	"\x06config\x18\x01 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"y\n" +
+	"\fPolicyChange\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x1b\n" +
+	"\tpolicy_id\x18\x02 \x01(\tR\bpolicyId\x12\x14\n" +
+	"\x05rules\x18\x03 \x01(\tR\x05rules\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\"\xc8\x04\n" +
+	"\rChangeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
+	"\vproposed_by\x18\x05 \x01(\tR\n" +
+	"proposedBy\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12M\n" +
+	"\rpolicy_config\x18\a \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\fpolicyConfig\x12_\n" +
+	"\x15policy_config_changes\x18\b \x03(\v2+.ztcp.orgpolicyconfig.v1.PolicyConfigChangeR\x13policyConfigChanges\x12;\n" +
+	"\x06policy\x18\t \x01(\v2#.ztcp.changerequest.v1.PolicyChangeR\x06policy\x12\x1f\n" +
+	"\vreviewed_by\x18\n" +
+	" \x01(\tR\n" +
+	"reviewedBy\x12%\n" +
+	"\x0ereview_comment\x18\v \x01(\tR\rreviewComment\x12;\n" +
+	"\vreviewed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xe2\x01\n" +
+	"\x14ProposeChangeRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12R\n" +
+	"\rpolicy_config\x18\x03 \x01(\v2+.ztcp.changerequest.v1.ProposedPolicyConfigH\x00R\fpolicyConfig\x12=\n" +
+	"\x06policy\x18\x04 \x01(\v2#.ztcp.changerequest.v1.PolicyChangeH\x00R\x06policyB\b\n" +
+	"\x06change\"d\n" +
+	"\x15ProposeChangeResponse\x12K\n" +
+	"\x0echange_request\x18\x01 \x01(\v2$.ztcp.changerequest.v1.ChangeRequestR\rchangeRequest\")\n" +
+	"\x17GetChangeRequestRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"g\n" +
+	"\x18GetChangeRequestResponse\x12K\n" +
+	"\x0echange_request\x18\x01 \x01(\v2$.ztcp.changerequest.v1.ChangeRequestR\rchangeRequest\"\x86\x01\n" +
+	"\x19ListChangeRequestsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12:\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\xad\x01\n" +
+	"\x1aListChangeRequestsResponse\x12M\n" +
+	"\x0fchange_requests\x18\x01 \x03(\v2$.ztcp.changerequest.v1.ChangeRequestR\x0echangeRequests\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"G\n" +
+	"\x1bApproveChangeRequestRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"k\n" +
+	"\x1cApproveChangeRequestResponse\x12K\n" +
+	"\x0echange_request\x18\x01 \x01(\v2$.ztcp.changerequest.v1.ChangeRequestR\rchangeRequest\"F\n" +
+	"\x1aRejectChangeRequestRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"j\n" +
+	"\x1bRejectChangeRequestResponse\x12K\n" +
+	"\x0echange_request\x18\x01 \x01(\v2$.ztcp.changerequest.v1.ChangeRequestR\rchangeRequest2\xfb\x04\n" +
+	"\x14ChangeRequestService\x12j\n" +
+	"\rProposeChange\x12+.ztcp.changerequest.v1.ProposeChangeRequest\x1a,.ztcp.changerequest.v1.ProposeChangeResponse\x12x\n" +
+	"\x10GetChangeRequest\x12..ztcp.changerequest.v1.GetChangeRequestRequest\x1a/.ztcp.changerequest.v1.GetChangeRequestResponse\"\x03\x90\x02\x01\x12~\n" +
+	"\x12ListChangeRequests\x120.ztcp.changerequest.v1.ListChangeRequestsRequest\x1a1.ztcp.changerequest.v1.ListChangeRequestsResponse\"\x03\x90\x02\x01\x12\x7f\n" +
+	"\x14ApproveChangeRequest\x122.ztcp.changerequest.v1.ApproveChangeRequestRequest\x1a3.ztcp.changerequest.v1.ApproveChangeRequestResponse\x12|\n" +
+	"\x13RejectChangeRequest\x121.ztcp.changerequest.v1.RejectChangeRequestRequest\x1a2.ztcp.changerequest.v1.RejectChangeRequestResponseBQZOzero-trust-control-plane/backend/api/generated/changerequest/v1;changerequestv1b\x06proto3"
+
+var (
+	file_changerequest_changerequest_proto_rawDescOnce sync.Once
+	file_changerequest_changerequest_proto_rawDescData []byte
+)
+
+func file_changerequest_changerequest_proto_rawDescGZIP() []byte {
+	file_changerequest_changerequest_proto_rawDescOnce.Do(func() {
+		file_changerequest_changerequest_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_changerequest_changerequest_proto_rawDesc), len(file_changerequest_changerequest_proto_rawDesc)))
+	})
+	return file_changerequest_changerequest_proto_rawDescData
+}
+
+var file_changerequest_changerequest_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_changerequest_changerequest_proto_goTypes = []any{
+	(*ProposedPolicyConfig)(nil),         // 0: ztcp.changerequest.v1.ProposedPolicyConfig
+	(*PolicyChange)(nil),                 // 1: ztcp.changerequest.v1.PolicyChange
+	(*ChangeRequest)(nil),                // 2: ztcp.changerequest.v1.ChangeRequest
+	(*ProposeChangeRequest)(nil),         // 3: ztcp.changerequest.v1.ProposeChangeRequest
+	(*ProposeChangeResponse)(nil),        // 4: ztcp.changerequest.v1.ProposeChangeResponse
+	(*GetChangeRequestRequest)(nil),      // 5: ztcp.changerequest.v1.GetChangeRequestRequest
+	(*GetChangeRequestResponse)(nil),     // 6: ztcp.changerequest.v1.GetChangeRequestResponse
+	(*ListChangeRequestsRequest)(nil),    // 7: ztcp.changerequest.v1.ListChangeRequestsRequest
+	(*ListChangeRequestsResponse)(nil),   // 8: ztcp.changerequest.v1.ListChangeRequestsResponse
+	(*ApproveChangeRequestRequest)(nil),  // 9: ztcp.changerequest.v1.ApproveChangeRequestRequest
+	(*ApproveChangeRequestResponse)(nil), // 10: ztcp.changerequest.v1.ApproveChangeRequestResponse
+	(*RejectChangeRequestRequest)(nil),   // 11: ztcp.changerequest.v1.RejectChangeRequestRequest
+	(*RejectChangeRequestResponse)(nil),  // 12: ztcp.changerequest.v1.RejectChangeRequestResponse
+	(*v1.OrgPolicyConfig)(nil),           // 13: ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	(*fieldmaskpb.FieldMask)(nil),        // 14: google.protobuf.FieldMask
+	(*v1.PolicyConfigChange)(nil),        // 15: ztcp.orgpolicyconfig.v1.PolicyConfigChange
+	(*timestamppb.Timestamp)(nil),        // 16: google.protobuf.Timestamp
+	(*v11.Pagination)(nil),               // 17: ztcp.common.v1.Pagination
+	(*v11.PaginationResult)(nil),         // 18: ztcp.common.v1.PaginationResult
+}
+var file_changerequest_changerequest_proto_depIdxs = []int32{
+	13, // 0: ztcp.changerequest.v1.ProposedPolicyConfig.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	14, // 1: ztcp.changerequest.v1.ProposedPolicyConfig.update_mask:type_name -> google.protobuf.FieldMask
+	13, // 2: ztcp.changerequest.v1.ChangeRequest.policy_config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	15, // 3: ztcp.changerequest.v1.ChangeRequest.policy_config_changes:type_name -> ztcp.orgpolicyconfig.v1.PolicyConfigChange
+	1,  // 4: ztcp.changerequest.v1.ChangeRequest.policy:type_name -> ztcp.changerequest.v1.PolicyChange
+	16, // 5: ztcp.changerequest.v1.ChangeRequest.reviewed_at:type_name -> google.protobuf.Timestamp
+	16, // 6: ztcp.changerequest.v1.ChangeRequest.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: ztcp.changerequest.v1.ProposeChangeRequest.policy_config:type_name -> ztcp.changerequest.v1.ProposedPolicyConfig
+	1,  // 8: ztcp.changerequest.v1.ProposeChangeRequest.policy:type_name -> ztcp.changerequest.v1.PolicyChange
+	2,  // 9: ztcp.changerequest.v1.ProposeChangeResponse.change_request:type_name -> ztcp.changerequest.v1.ChangeRequest
+	2,  // 10: ztcp.changerequest.v1.GetChangeRequestResponse.change_request:type_name -> ztcp.changerequest.v1.ChangeRequest
+	17, // 11: ztcp.changerequest.v1.ListChangeRequestsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	2,  // 12: ztcp.changerequest.v1.ListChangeRequestsResponse.change_requests:type_name -> ztcp.changerequest.v1.ChangeRequest
+	18, // 13: ztcp.changerequest.v1.ListChangeRequestsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	2,  // 14: ztcp.changerequest.v1.ApproveChangeRequestResponse.change_request:type_name -> ztcp.changerequest.v1.ChangeRequest
+	2,  // 15: ztcp.changerequest.v1.RejectChangeRequestResponse.change_request:type_name -> ztcp.changerequest.v1.ChangeRequest
+	3,  // 16: ztcp.changerequest.v1.ChangeRequestService.ProposeChange:input_type -> ztcp.changerequest.v1.ProposeChangeRequest
+	5,  // 17: ztcp.changerequest.v1.ChangeRequestService.GetChangeRequest:input_type -> ztcp.changerequest.v1.GetChangeRequestRequest
+	7,  // 18: ztcp.changerequest.v1.ChangeRequestService.ListChangeRequests:input_type -> ztcp.changerequest.v1.ListChangeRequestsRequest
+	9,  // 19: ztcp.changerequest.v1.ChangeRequestService.ApproveChangeRequest:input_type -> ztcp.changerequest.v1.ApproveChangeRequestRequest
+	11, // 20: ztcp.changerequest.v1.ChangeRequestService.RejectChangeRequest:input_type -> ztcp.changerequest.v1.RejectChangeRequestRequest
+	4,  // 21: ztcp.changerequest.v1.ChangeRequestService.ProposeChange:output_type -> ztcp.changerequest.v1.ProposeChangeResponse
+	6,  // 22: ztcp.changerequest.v1.ChangeRequestService.GetChangeRequest:output_type -> ztcp.changerequest.v1.GetChangeRequestResponse
+	8,  // 23: ztcp.changerequest.v1.ChangeRequestService.ListChangeRequests:output_type -> ztcp.changerequest.v1.ListChangeRequestsResponse
+	10, // 24: ztcp.changerequest.v1.ChangeRequestService.ApproveChangeRequest:output_type -> ztcp.changerequest.v1.ApproveChangeRequestResponse
+	12, // 25: ztcp.changerequest.v1.ChangeRequestService.RejectChangeRequest:output_type -> ztcp.changerequest.v1.RejectChangeRequestResponse
+	21, // [21:26] is the sub-list for method output_type
+	16, // [16:21] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_changerequest_changerequest_proto_init() }
+func file_changerequest_changerequest_proto_init() {
+	if File_changerequest_changerequest_proto != nil {
+		return
+	}
+	file_changerequest_changerequest_proto_msgTypes[3].OneofWrappers = []any{
+		(*ProposeChangeRequest_PolicyConfig)(nil),
+		(*ProposeChangeRequest_Policy)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_changerequest_changerequest_proto_rawDesc), len(file_changerequest_changerequest_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_changerequest_changerequest_proto_goTypes,
+		DependencyIndexes: file_changerequest_changerequest_proto_depIdxs,
+		MessageInfos:      file_changerequest_changerequest_proto_msgTypes,
+	}.Build()
+	File_changerequest_changerequest_proto = out.File
+	file_changerequest_changerequest_proto_goTypes = nil
+	file_changerequest_changerequest_proto_depIdxs = nil
+}
@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: changerequest/changerequest.proto
+
+package changerequestv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChangeRequestService_ProposeChange_FullMethodName        = "/ztcp.changerequest.v1.ChangeRequestService/ProposeChange"
+	ChangeRequestService_GetChangeRequest_FullMethodName     = "/ztcp.changerequest.v1.ChangeRequestService/GetChangeRequest"
+	ChangeRequestService_ListChangeRequests_FullMethodName   = "/ztcp.changerequest.v1.ChangeRequestService/ListChangeRequests"
+	ChangeRequestService_ApproveChangeRequest_FullMethodName = "/ztcp.changerequest.v1.ChangeRequestService/ApproveChangeRequest"
+	ChangeRequestService_RejectChangeRequest_FullMethodName  = "/ztcp.changerequest.v1.ChangeRequestService/RejectChangeRequest"
+)
+
+// ChangeRequestServiceClient is the client API for ChangeRequestService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChangeRequestService is the four-eyes workflow for org policy config and Rego policy changes. An admin proposes a
+// change; a different admin approves it, which applies it, or rejects it. Orgs that set change_approval.required
+// can change policy only this way. All RPCs require org admin or owner. Every step is audited and sent to the
+// change request webhook when one is configured.
+type ChangeRequestServiceClient interface {
+	// ProposeChange validates the change and stores it as a pending request. Nothing is applied.
+	ProposeChange(ctx context.Context, in *ProposeChangeRequest, opts ...grpc.CallOption) (*ProposeChangeResponse, error)
+	GetChangeRequest(ctx context.Context, in *GetChangeRequestRequest, opts ...grpc.CallOption) (*GetChangeRequestResponse, error)
+	ListChangeRequests(ctx context.Context, in *ListChangeRequestsRequest, opts ...grpc.CallOption) (*ListChangeRequestsResponse, error)
+	// ApproveChangeRequest applies a pending request. The proposer cannot approve their own request.
+	ApproveChangeRequest(ctx context.Context, in *ApproveChangeRequestRequest, opts ...grpc.CallOption) (*ApproveChangeRequestResponse, error)
+	// RejectChangeRequest closes a pending request without applying it. The proposer may reject (withdraw) their own.
+	RejectChangeRequest(ctx context.Context, in *RejectChangeRequestRequest, opts ...grpc.CallOption) (*RejectChangeRequestResponse, error)
+}
+
+type changeRequestServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChangeRequestServiceClient(cc grpc.ClientConnInterface) ChangeRequestServiceClient {
+	return &changeRequestServiceClient{cc}
+}
+
+func (c *changeRequestServiceClient) ProposeChange(ctx context.Context, in *ProposeChangeRequest, opts ...grpc.CallOption) (*ProposeChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProposeChangeResponse)
+	err := c.cc.Invoke(ctx, ChangeRequestService_ProposeChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *changeRequestServiceClient) GetChangeRequest(ctx context.Context, in *GetChangeRequestRequest, opts ...grpc.CallOption) (*GetChangeRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChangeRequestResponse)
+	err := c.cc.Invoke(ctx, ChangeRequestService_GetChangeRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *changeRequestServiceClient) ListChangeRequests(ctx context.Context, in *ListChangeRequestsRequest, opts ...grpc.CallOption) (*ListChangeRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChangeRequestsResponse)
+	err := c.cc.Invoke(ctx, ChangeRequestService_ListChangeRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *changeRequestServiceClient) ApproveChangeRequest(ctx context.Context, in *ApproveChangeRequestRequest, opts ...grpc.CallOption) (*ApproveChangeRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveChangeRequestResponse)
+	err := c.cc.Invoke(ctx, ChangeRequestService_ApproveChangeRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *changeRequestServiceClient) RejectChangeRequest(ctx context.Context, in *RejectChangeRequestRequest, opts ...grpc.CallOption) (*RejectChangeRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectChangeRequestResponse)
+	err := c.cc.Invoke(ctx, ChangeRequestService_RejectChangeRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChangeRequestServiceServer is the server API for ChangeRequestService service.
+// All implementations must embed UnimplementedChangeRequestServiceServer
+// for forward compatibility.
+//
+// ChangeRequestService is the four-eyes workflow for org policy config and Rego policy changes. An admin proposes a
+// change; a different admin approves it, which applies it, or rejects it. Orgs that set change_approval.required
+// can change policy only this way. All RPCs require org admin or owner. Every step is audited and sent to the
+// change request webhook when one is configured.
+type ChangeRequestServiceServer interface {
+	// ProposeChange validates the change and stores it as a pending request. Nothing is applied.
+	ProposeChange(context.Context, *ProposeChangeRequest) (*ProposeChangeResponse, error)
+	GetChangeRequest(context.Context, *GetChangeRequestRequest) (*GetChangeRequestResponse, error)
+	ListChangeRequests(context.Context, *ListChangeRequestsRequest) (*ListChangeRequestsResponse, error)
+	// ApproveChangeRequest applies a pending request. The proposer cannot approve their own request.
+	ApproveChangeRequest(context.Context, *ApproveChangeRequestRequest) (*ApproveChangeRequestResponse, error)
+	// RejectChangeRequest closes a pending request without applying it. The proposer may reject (withdraw) their own.
+	RejectChangeRequest(context.Context, *RejectChangeRequestRequest) (*RejectChangeRequestResponse, error)
+	mustEmbedUnimplementedChangeRequestServiceServer()
+}
+
+// UnimplementedChangeRequestServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChangeRequestServiceServer struct{}
+
+func (UnimplementedChangeRequestServiceServer) ProposeChange(context.Context, *ProposeChangeRequest) (*ProposeChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProposeChange not implemented")
+}
+func (UnimplementedChangeRequestServiceServer) GetChangeRequest(context.Context, *GetChangeRequestRequest) (*GetChangeRequestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChangeRequest not implemented")
+}
+func (UnimplementedChangeRequestServiceServer) ListChangeRequests(context.Context, *ListChangeRequestsRequest) (*ListChangeRequestsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListChangeRequests not implemented")
+}
+func (UnimplementedChangeRequestServiceServer) ApproveChangeRequest(context.Context, *ApproveChangeRequestRequest) (*ApproveChangeRequestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveChangeRequest not implemented")
+}
+func (UnimplementedChangeRequestServiceServer) RejectChangeRequest(context.Context, *RejectChangeRequestRequest) (*RejectChangeRequestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectChangeRequest not implemented")
+}
+func (UnimplementedChangeRequestServiceServer) mustEmbedUnimplementedChangeRequestServiceServer() {}
+func (UnimplementedChangeRequestServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeChangeRequestServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChangeRequestServiceServer will
+// result in compilation errors.
+type UnsafeChangeRequestServiceServer interface {
+	mustEmbedUnimplementedChangeRequestServiceServer()
+}
+
+func RegisterChangeRequestServiceServer(s grpc.ServiceRegistrar, srv ChangeRequestServiceServer) {
+	// If the following call panics, it indicates UnimplementedChangeRequestServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChangeRequestService_ServiceDesc, srv)
+}
+
+func _ChangeRequestService_ProposeChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChangeRequestServiceServer).ProposeChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChangeRequestService_ProposeChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChangeRequestServiceServer).ProposeChange(ctx, req.(*ProposeChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChangeRequestService_GetChangeRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChangeRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChangeRequestServiceServer).GetChangeRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChangeRequestService_GetChangeRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChangeRequestServiceServer).GetChangeRequest(ctx, req.(*GetChangeRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChangeRequestService_ListChangeRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangeRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChangeRequestServiceServer).ListChangeRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChangeRequestService_ListChangeRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChangeRequestServiceServer).ListChangeRequests(ctx, req.(*ListChangeRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChangeRequestService_ApproveChangeRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveChangeRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChangeRequestServiceServer).ApproveChangeRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChangeRequestService_ApproveChangeRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChangeRequestServiceServer).ApproveChangeRequest(ctx, req.(*ApproveChangeRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChangeRequestService_RejectChangeRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectChangeRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChangeRequestServiceServer).RejectChangeRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChangeRequestService_RejectChangeRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChangeRequestServiceServer).RejectChangeRequest(ctx, req.(*RejectChangeRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChangeRequestService_ServiceDesc is the grpc.ServiceDesc for ChangeRequestService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChangeRequestService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.changerequest.v1.ChangeRequestService",
+	HandlerType: (*ChangeRequestServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProposeChange",
+			Handler:    _ChangeRequestService_ProposeChange_Handler,
+		},
+		{
+			MethodName: "GetChangeRequest",
+			Handler:    _ChangeRequestService_GetChangeRequest_Handler,
+		},
+		{
+			MethodName: "ListChangeRequests",
+			Handler:    _ChangeRequestService_ListChangeRequests_Handler,
+		},
+		{
+			MethodName: "ApproveChangeRequest",
+			Handler:    _ChangeRequestService_ApproveChangeRequest_Handler,
+		},
+		{
+			MethodName: "RejectChangeRequest",
+			Handler:    _ChangeRequestService_RejectChangeRequest_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "changerequest/changerequest.proto",
+}
@@ -128,10 +128,10 @@ const file_dev_dev_proto_rawDesc = "" +
 	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\"6\n" +
 	"\x0eGetOTPResponse\x12\x10\n" +
 	"\x03otp\x18\x01 \x01(\tR\x03otp\x12\x12\n" +
-	"\x04note\x18\x02 \x01(\tR\x04note2O\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note2T\n" +
 	"\n" +
-	"DevService\x12A\n" +
-	"\x06GetOTP\x12\x1a.ztcp.dev.v1.GetOTPRequest\x1a\x1b.ztcp.dev.v1.GetOTPResponseB=Z;zero-trust-control-plane/backend/api/generated/dev/v1;devv1b\x06proto3"
+	"DevService\x12F\n" +
+	"\x06GetOTP\x12\x1a.ztcp.dev.v1.GetOTPRequest\x1a\x1b.ztcp.dev.v1.GetOTPResponse\"\x03\x90\x02\x01B=Z;zero-trust-control-plane/backend/api/generated/dev/v1;devv1b\x06proto3"
 
 var (
 	file_dev_dev_proto_rawDescOnce sync.Once
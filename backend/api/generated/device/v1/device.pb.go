@@ -25,18 +25,22 @@ const (
 
 // Device represents a registered device for a user in an org.
 type Device struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	OrgId         string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
-	Fingerprint   string                 `protobuf:"bytes,4,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
-	Trusted       bool                   `protobuf:"varint,5,opt,name=trusted,proto3" json:"trusted,omitempty"`
-	TrustedUntil  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=trusted_until,json=trustedUntil,proto3" json:"trusted_until,omitempty"`
-	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
-	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId            string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Fingerprint      string                 `protobuf:"bytes,4,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	Trusted          bool                   `protobuf:"varint,5,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	TrustedUntil     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=trusted_until,json=trustedUntil,proto3" json:"trusted_until,omitempty"`
+	RevokedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	LastSeenAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TrustDays        int32                  `protobuf:"varint,10,opt,name=trust_days,json=trustDays,proto3" json:"trust_days,omitempty"`            // remember-device duration the user chose when trusting the device; 0 = the org's trust TTL
+	QuarantinedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=quarantined_at,json=quarantinedAt,proto3" json:"quarantined_at,omitempty"` // set while quarantined; every sign-in from the device requires MFA
+	QuarantineReason string                 `protobuf:"bytes,12,opt,name=quarantine_reason,json=quarantineReason,proto3" json:"quarantine_reason,omitempty"`
+	QuarantinedBy    string                 `protobuf:"bytes,13,opt,name=quarantined_by,json=quarantinedBy,proto3" json:"quarantined_by,omitempty"` // user id of the admin who quarantined the device
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Device) Reset() {
@@ -132,6 +136,34 @@ func (x *Device) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Device) GetTrustDays() int32 {
+	if x != nil {
+		return x.TrustDays
+	}
+	return 0
+}
+
+func (x *Device) GetQuarantinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.QuarantinedAt
+	}
+	return nil
+}
+
+func (x *Device) GetQuarantineReason() string {
+	if x != nil {
+		return x.QuarantineReason
+	}
+	return ""
+}
+
+func (x *Device) GetQuarantinedBy() string {
+	if x != nil {
+		return x.QuarantinedBy
+	}
+	return ""
+}
+
 // RegisterDeviceRequest registers a new device.
 type RegisterDeviceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -524,11 +556,199 @@ func (*RevokeDeviceResponse) Descriptor() ([]byte, []int) {
 	return file_device_device_proto_rawDescGZIP(), []int{8}
 }
 
+// QuarantineDeviceRequest identifies the device to quarantine (suspected compromise) and why.
+type QuarantineDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // optional; shown to admins and in the security event
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuarantineDeviceRequest) Reset() {
+	*x = QuarantineDeviceRequest{}
+	mi := &file_device_device_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuarantineDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuarantineDeviceRequest) ProtoMessage() {}
+
+func (x *QuarantineDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_device_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuarantineDeviceRequest.ProtoReflect.Descriptor instead.
+func (*QuarantineDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_device_device_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QuarantineDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *QuarantineDeviceRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// QuarantineDeviceResponse returns the quarantined device.
+type QuarantineDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuarantineDeviceResponse) Reset() {
+	*x = QuarantineDeviceResponse{}
+	mi := &file_device_device_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuarantineDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuarantineDeviceResponse) ProtoMessage() {}
+
+func (x *QuarantineDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_device_device_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuarantineDeviceResponse.ProtoReflect.Descriptor instead.
+func (*QuarantineDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_device_device_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QuarantineDeviceResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+// ReleaseDeviceRequest identifies the quarantined device to release.
+type ReleaseDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDeviceRequest) Reset() {
+	*x = ReleaseDeviceRequest{}
+	mi := &file_device_device_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDeviceRequest) ProtoMessage() {}
+
+func (x *ReleaseDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_device_device_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDeviceRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_device_device_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReleaseDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+// ReleaseDeviceResponse returns the released device.
+type ReleaseDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDeviceResponse) Reset() {
+	*x = ReleaseDeviceResponse{}
+	mi := &file_device_device_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDeviceResponse) ProtoMessage() {}
+
+func (x *ReleaseDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_device_device_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDeviceResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_device_device_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReleaseDeviceResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
 var File_device_device_proto protoreflect.FileDescriptor
 
 const file_device_device_proto_rawDesc = "" +
 	"\n" +
-	"\x13device/device.proto\x12\x0eztcp.device.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x02\n" +
+	"\x13device/device.proto\x12\x0eztcp.device.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x04\n" +
 	"\x06Device\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x15\n" +
@@ -541,7 +761,13 @@ const file_device_device_proto_rawDesc = "" +
 	"\flast_seen_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"lastSeenAt\x129\n" +
 	"\n" +
-	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"i\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"trust_days\x18\n" +
+	" \x01(\x05R\ttrustDays\x12A\n" +
+	"\x0equarantined_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\rquarantinedAt\x12+\n" +
+	"\x11quarantine_reason\x18\f \x01(\tR\x10quarantineReason\x12%\n" +
+	"\x0equarantined_by\x18\r \x01(\tR\rquarantinedBy\"i\n" +
 	"\x15RegisterDeviceRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
 	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12 \n" +
@@ -565,12 +791,23 @@ const file_device_device_proto_rawDesc = "" +
 	"pagination\"2\n" +
 	"\x13RevokeDeviceRequest\x12\x1b\n" +
 	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\x16\n" +
-	"\x14RevokeDeviceResponse2\xf5\x02\n" +
+	"\x14RevokeDeviceResponse\"N\n" +
+	"\x17QuarantineDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"J\n" +
+	"\x18QuarantineDeviceResponse\x12.\n" +
+	"\x06device\x18\x01 \x01(\v2\x16.ztcp.device.v1.DeviceR\x06device\"3\n" +
+	"\x14ReleaseDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"G\n" +
+	"\x15ReleaseDeviceResponse\x12.\n" +
+	"\x06device\x18\x01 \x01(\v2\x16.ztcp.device.v1.DeviceR\x06device2\xc4\x04\n" +
 	"\rDeviceService\x12_\n" +
-	"\x0eRegisterDevice\x12%.ztcp.device.v1.RegisterDeviceRequest\x1a&.ztcp.device.v1.RegisterDeviceResponse\x12P\n" +
-	"\tGetDevice\x12 .ztcp.device.v1.GetDeviceRequest\x1a!.ztcp.device.v1.GetDeviceResponse\x12V\n" +
-	"\vListDevices\x12\".ztcp.device.v1.ListDevicesRequest\x1a#.ztcp.device.v1.ListDevicesResponse\x12Y\n" +
-	"\fRevokeDevice\x12#.ztcp.device.v1.RevokeDeviceRequest\x1a$.ztcp.device.v1.RevokeDeviceResponseBCZAzero-trust-control-plane/backend/api/generated/device/v1;devicev1b\x06proto3"
+	"\x0eRegisterDevice\x12%.ztcp.device.v1.RegisterDeviceRequest\x1a&.ztcp.device.v1.RegisterDeviceResponse\x12U\n" +
+	"\tGetDevice\x12 .ztcp.device.v1.GetDeviceRequest\x1a!.ztcp.device.v1.GetDeviceResponse\"\x03\x90\x02\x01\x12[\n" +
+	"\vListDevices\x12\".ztcp.device.v1.ListDevicesRequest\x1a#.ztcp.device.v1.ListDevicesResponse\"\x03\x90\x02\x01\x12Y\n" +
+	"\fRevokeDevice\x12#.ztcp.device.v1.RevokeDeviceRequest\x1a$.ztcp.device.v1.RevokeDeviceResponse\x12e\n" +
+	"\x10QuarantineDevice\x12'.ztcp.device.v1.QuarantineDeviceRequest\x1a(.ztcp.device.v1.QuarantineDeviceResponse\x12\\\n" +
+	"\rReleaseDevice\x12$.ztcp.device.v1.ReleaseDeviceRequest\x1a%.ztcp.device.v1.ReleaseDeviceResponseBCZAzero-trust-control-plane/backend/api/generated/device/v1;devicev1b\x06proto3"
 
 var (
 	file_device_device_proto_rawDescOnce sync.Once
@@ -584,44 +821,55 @@ func file_device_device_proto_rawDescGZIP() []byte {
 	return file_device_device_proto_rawDescData
 }
 
-var file_device_device_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_device_device_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_device_device_proto_goTypes = []any{
-	(*Device)(nil),                 // 0: ztcp.device.v1.Device
-	(*RegisterDeviceRequest)(nil),  // 1: ztcp.device.v1.RegisterDeviceRequest
-	(*RegisterDeviceResponse)(nil), // 2: ztcp.device.v1.RegisterDeviceResponse
-	(*GetDeviceRequest)(nil),       // 3: ztcp.device.v1.GetDeviceRequest
-	(*GetDeviceResponse)(nil),      // 4: ztcp.device.v1.GetDeviceResponse
-	(*ListDevicesRequest)(nil),     // 5: ztcp.device.v1.ListDevicesRequest
-	(*ListDevicesResponse)(nil),    // 6: ztcp.device.v1.ListDevicesResponse
-	(*RevokeDeviceRequest)(nil),    // 7: ztcp.device.v1.RevokeDeviceRequest
-	(*RevokeDeviceResponse)(nil),   // 8: ztcp.device.v1.RevokeDeviceResponse
-	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
-	(*v1.Pagination)(nil),          // 10: ztcp.common.v1.Pagination
-	(*v1.PaginationResult)(nil),    // 11: ztcp.common.v1.PaginationResult
+	(*Device)(nil),                   // 0: ztcp.device.v1.Device
+	(*RegisterDeviceRequest)(nil),    // 1: ztcp.device.v1.RegisterDeviceRequest
+	(*RegisterDeviceResponse)(nil),   // 2: ztcp.device.v1.RegisterDeviceResponse
+	(*GetDeviceRequest)(nil),         // 3: ztcp.device.v1.GetDeviceRequest
+	(*GetDeviceResponse)(nil),        // 4: ztcp.device.v1.GetDeviceResponse
+	(*ListDevicesRequest)(nil),       // 5: ztcp.device.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),      // 6: ztcp.device.v1.ListDevicesResponse
+	(*RevokeDeviceRequest)(nil),      // 7: ztcp.device.v1.RevokeDeviceRequest
+	(*RevokeDeviceResponse)(nil),     // 8: ztcp.device.v1.RevokeDeviceResponse
+	(*QuarantineDeviceRequest)(nil),  // 9: ztcp.device.v1.QuarantineDeviceRequest
+	(*QuarantineDeviceResponse)(nil), // 10: ztcp.device.v1.QuarantineDeviceResponse
+	(*ReleaseDeviceRequest)(nil),     // 11: ztcp.device.v1.ReleaseDeviceRequest
+	(*ReleaseDeviceResponse)(nil),    // 12: ztcp.device.v1.ReleaseDeviceResponse
+	(*timestamppb.Timestamp)(nil),    // 13: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),            // 14: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),      // 15: ztcp.common.v1.PaginationResult
 }
 var file_device_device_proto_depIdxs = []int32{
-	9,  // 0: ztcp.device.v1.Device.trusted_until:type_name -> google.protobuf.Timestamp
-	9,  // 1: ztcp.device.v1.Device.revoked_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: ztcp.device.v1.Device.last_seen_at:type_name -> google.protobuf.Timestamp
-	9,  // 3: ztcp.device.v1.Device.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: ztcp.device.v1.RegisterDeviceResponse.device:type_name -> ztcp.device.v1.Device
-	0,  // 5: ztcp.device.v1.GetDeviceResponse.device:type_name -> ztcp.device.v1.Device
-	10, // 6: ztcp.device.v1.ListDevicesRequest.pagination:type_name -> ztcp.common.v1.Pagination
-	0,  // 7: ztcp.device.v1.ListDevicesResponse.devices:type_name -> ztcp.device.v1.Device
-	11, // 8: ztcp.device.v1.ListDevicesResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
-	1,  // 9: ztcp.device.v1.DeviceService.RegisterDevice:input_type -> ztcp.device.v1.RegisterDeviceRequest
-	3,  // 10: ztcp.device.v1.DeviceService.GetDevice:input_type -> ztcp.device.v1.GetDeviceRequest
-	5,  // 11: ztcp.device.v1.DeviceService.ListDevices:input_type -> ztcp.device.v1.ListDevicesRequest
-	7,  // 12: ztcp.device.v1.DeviceService.RevokeDevice:input_type -> ztcp.device.v1.RevokeDeviceRequest
-	2,  // 13: ztcp.device.v1.DeviceService.RegisterDevice:output_type -> ztcp.device.v1.RegisterDeviceResponse
-	4,  // 14: ztcp.device.v1.DeviceService.GetDevice:output_type -> ztcp.device.v1.GetDeviceResponse
-	6,  // 15: ztcp.device.v1.DeviceService.ListDevices:output_type -> ztcp.device.v1.ListDevicesResponse
-	8,  // 16: ztcp.device.v1.DeviceService.RevokeDevice:output_type -> ztcp.device.v1.RevokeDeviceResponse
-	13, // [13:17] is the sub-list for method output_type
-	9,  // [9:13] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	13, // 0: ztcp.device.v1.Device.trusted_until:type_name -> google.protobuf.Timestamp
+	13, // 1: ztcp.device.v1.Device.revoked_at:type_name -> google.protobuf.Timestamp
+	13, // 2: ztcp.device.v1.Device.last_seen_at:type_name -> google.protobuf.Timestamp
+	13, // 3: ztcp.device.v1.Device.created_at:type_name -> google.protobuf.Timestamp
+	13, // 4: ztcp.device.v1.Device.quarantined_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: ztcp.device.v1.RegisterDeviceResponse.device:type_name -> ztcp.device.v1.Device
+	0,  // 6: ztcp.device.v1.GetDeviceResponse.device:type_name -> ztcp.device.v1.Device
+	14, // 7: ztcp.device.v1.ListDevicesRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	0,  // 8: ztcp.device.v1.ListDevicesResponse.devices:type_name -> ztcp.device.v1.Device
+	15, // 9: ztcp.device.v1.ListDevicesResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	0,  // 10: ztcp.device.v1.QuarantineDeviceResponse.device:type_name -> ztcp.device.v1.Device
+	0,  // 11: ztcp.device.v1.ReleaseDeviceResponse.device:type_name -> ztcp.device.v1.Device
+	1,  // 12: ztcp.device.v1.DeviceService.RegisterDevice:input_type -> ztcp.device.v1.RegisterDeviceRequest
+	3,  // 13: ztcp.device.v1.DeviceService.GetDevice:input_type -> ztcp.device.v1.GetDeviceRequest
+	5,  // 14: ztcp.device.v1.DeviceService.ListDevices:input_type -> ztcp.device.v1.ListDevicesRequest
+	7,  // 15: ztcp.device.v1.DeviceService.RevokeDevice:input_type -> ztcp.device.v1.RevokeDeviceRequest
+	9,  // 16: ztcp.device.v1.DeviceService.QuarantineDevice:input_type -> ztcp.device.v1.QuarantineDeviceRequest
+	11, // 17: ztcp.device.v1.DeviceService.ReleaseDevice:input_type -> ztcp.device.v1.ReleaseDeviceRequest
+	2,  // 18: ztcp.device.v1.DeviceService.RegisterDevice:output_type -> ztcp.device.v1.RegisterDeviceResponse
+	4,  // 19: ztcp.device.v1.DeviceService.GetDevice:output_type -> ztcp.device.v1.GetDeviceResponse
+	6,  // 20: ztcp.device.v1.DeviceService.ListDevices:output_type -> ztcp.device.v1.ListDevicesResponse
+	8,  // 21: ztcp.device.v1.DeviceService.RevokeDevice:output_type -> ztcp.device.v1.RevokeDeviceResponse
+	10, // 22: ztcp.device.v1.DeviceService.QuarantineDevice:output_type -> ztcp.device.v1.QuarantineDeviceResponse
+	12, // 23: ztcp.device.v1.DeviceService.ReleaseDevice:output_type -> ztcp.device.v1.ReleaseDeviceResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_device_device_proto_init() }
@@ -635,7 +883,7 @@ func file_device_device_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_device_device_proto_rawDesc), len(file_device_device_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
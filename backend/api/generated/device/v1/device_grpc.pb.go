@@ -19,10 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DeviceService_RegisterDevice_FullMethodName = "/ztcp.device.v1.DeviceService/RegisterDevice"
-	DeviceService_GetDevice_FullMethodName      = "/ztcp.device.v1.DeviceService/GetDevice"
-	DeviceService_ListDevices_FullMethodName    = "/ztcp.device.v1.DeviceService/ListDevices"
-	DeviceService_RevokeDevice_FullMethodName   = "/ztcp.device.v1.DeviceService/RevokeDevice"
+	DeviceService_RegisterDevice_FullMethodName   = "/ztcp.device.v1.DeviceService/RegisterDevice"
+	DeviceService_GetDevice_FullMethodName        = "/ztcp.device.v1.DeviceService/GetDevice"
+	DeviceService_ListDevices_FullMethodName      = "/ztcp.device.v1.DeviceService/ListDevices"
+	DeviceService_RevokeDevice_FullMethodName     = "/ztcp.device.v1.DeviceService/RevokeDevice"
+	DeviceService_QuarantineDevice_FullMethodName = "/ztcp.device.v1.DeviceService/QuarantineDevice"
+	DeviceService_ReleaseDevice_FullMethodName    = "/ztcp.device.v1.DeviceService/ReleaseDevice"
 )
 
 // DeviceServiceClient is the client API for DeviceService service.
@@ -35,6 +37,8 @@ type DeviceServiceClient interface {
 	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
 	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
 	RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*RevokeDeviceResponse, error)
+	QuarantineDevice(ctx context.Context, in *QuarantineDeviceRequest, opts ...grpc.CallOption) (*QuarantineDeviceResponse, error)
+	ReleaseDevice(ctx context.Context, in *ReleaseDeviceRequest, opts ...grpc.CallOption) (*ReleaseDeviceResponse, error)
 }
 
 type deviceServiceClient struct {
@@ -85,6 +89,26 @@ func (c *deviceServiceClient) RevokeDevice(ctx context.Context, in *RevokeDevice
 	return out, nil
 }
 
+func (c *deviceServiceClient) QuarantineDevice(ctx context.Context, in *QuarantineDeviceRequest, opts ...grpc.CallOption) (*QuarantineDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuarantineDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceService_QuarantineDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ReleaseDevice(ctx context.Context, in *ReleaseDeviceRequest, opts ...grpc.CallOption) (*ReleaseDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ReleaseDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeviceServiceServer is the server API for DeviceService service.
 // All implementations must embed UnimplementedDeviceServiceServer
 // for forward compatibility.
@@ -95,6 +119,8 @@ type DeviceServiceServer interface {
 	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
 	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
 	RevokeDevice(context.Context, *RevokeDeviceRequest) (*RevokeDeviceResponse, error)
+	QuarantineDevice(context.Context, *QuarantineDeviceRequest) (*QuarantineDeviceResponse, error)
+	ReleaseDevice(context.Context, *ReleaseDeviceRequest) (*ReleaseDeviceResponse, error)
 	mustEmbedUnimplementedDeviceServiceServer()
 }
 
@@ -117,6 +143,12 @@ func (UnimplementedDeviceServiceServer) ListDevices(context.Context, *ListDevice
 func (UnimplementedDeviceServiceServer) RevokeDevice(context.Context, *RevokeDeviceRequest) (*RevokeDeviceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RevokeDevice not implemented")
 }
+func (UnimplementedDeviceServiceServer) QuarantineDevice(context.Context, *QuarantineDeviceRequest) (*QuarantineDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QuarantineDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) ReleaseDevice(context.Context, *ReleaseDeviceRequest) (*ReleaseDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseDevice not implemented")
+}
 func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
 func (UnimplementedDeviceServiceServer) testEmbeddedByValue()                       {}
 
@@ -210,6 +242,42 @@ func _DeviceService_RevokeDevice_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceService_QuarantineDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuarantineDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).QuarantineDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_QuarantineDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).QuarantineDevice(ctx, req.(*QuarantineDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ReleaseDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ReleaseDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ReleaseDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ReleaseDevice(ctx, req.(*ReleaseDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeviceService_ServiceDesc is the grpc.ServiceDesc for DeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -233,6 +301,14 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeDevice",
 			Handler:    _DeviceService_RevokeDevice_Handler,
 		},
+		{
+			MethodName: "QuarantineDevice",
+			Handler:    _DeviceService_QuarantineDevice_Handler,
+		},
+		{
+			MethodName: "ReleaseDevice",
+			Handler:    _DeviceService_ReleaseDevice_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "device/device.proto",
@@ -0,0 +1,810 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: elevation/elevation.proto
+
+package elevationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Elevation is a member's request for temporary org admin rights.
+type Elevation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId           string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the member asking for admin rights
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`               // pending, approved, rejected, revoked, expired
+	Justification   string                 `protobuf:"bytes,5,opt,name=justification,proto3" json:"justification,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,6,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // requested window; starts at approval
+	ReviewedBy      string                 `protobuf:"bytes,7,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`                 // user ID of the owner who approved or rejected; empty while pending
+	ReviewComment   string                 `protobuf:"bytes,8,opt,name=review_comment,json=reviewComment,proto3" json:"review_comment,omitempty"`
+	ReviewedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // set on approval
+	RevokedBy       string                 `protobuf:"bytes,11,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	RevokedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Elevation) Reset() {
+	*x = Elevation{}
+	mi := &file_elevation_elevation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Elevation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Elevation) ProtoMessage() {}
+
+func (x *Elevation) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Elevation.ProtoReflect.Descriptor instead.
+func (*Elevation) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Elevation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Elevation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Elevation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Elevation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Elevation) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *Elevation) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *Elevation) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *Elevation) GetReviewComment() string {
+	if x != nil {
+		return x.ReviewComment
+	}
+	return ""
+}
+
+func (x *Elevation) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *Elevation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Elevation) GetRevokedBy() string {
+	if x != nil {
+		return x.RevokedBy
+	}
+	return ""
+}
+
+func (x *Elevation) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *Elevation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RequestElevationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Justification   string                 `protobuf:"bytes,1,opt,name=justification,proto3" json:"justification,omitempty"`                             // required, max 1000 characters
+	DurationSeconds int64                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // optional; default and maximum are set by the platform
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RequestElevationRequest) Reset() {
+	*x = RequestElevationRequest{}
+	mi := &file_elevation_elevation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestElevationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestElevationRequest) ProtoMessage() {}
+
+func (x *RequestElevationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestElevationRequest.ProtoReflect.Descriptor instead.
+func (*RequestElevationRequest) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RequestElevationRequest) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *RequestElevationRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type RequestElevationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elevation     *Elevation             `protobuf:"bytes,1,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestElevationResponse) Reset() {
+	*x = RequestElevationResponse{}
+	mi := &file_elevation_elevation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestElevationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestElevationResponse) ProtoMessage() {}
+
+func (x *RequestElevationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestElevationResponse.ProtoReflect.Descriptor instead.
+func (*RequestElevationResponse) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RequestElevationResponse) GetElevation() *Elevation {
+	if x != nil {
+		return x.Elevation
+	}
+	return nil
+}
+
+// ListElevationsRequest lists the org's elevations, newest first. Members see only their own.
+type ListElevationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`               // optional: pending, approved, rejected, revoked, expired
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional; org admins and owners only
+	Pagination    *v1.Pagination         `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListElevationsRequest) Reset() {
+	*x = ListElevationsRequest{}
+	mi := &file_elevation_elevation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListElevationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListElevationsRequest) ProtoMessage() {}
+
+func (x *ListElevationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListElevationsRequest.ProtoReflect.Descriptor instead.
+func (*ListElevationsRequest) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListElevationsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListElevationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListElevationsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListElevationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elevations    []*Elevation           `protobuf:"bytes,1,rep,name=elevations,proto3" json:"elevations,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListElevationsResponse) Reset() {
+	*x = ListElevationsResponse{}
+	mi := &file_elevation_elevation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListElevationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListElevationsResponse) ProtoMessage() {}
+
+func (x *ListElevationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListElevationsResponse.ProtoReflect.Descriptor instead.
+func (*ListElevationsResponse) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListElevationsResponse) GetElevations() []*Elevation {
+	if x != nil {
+		return x.Elevations
+	}
+	return nil
+}
+
+func (x *ListElevationsResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ApproveElevationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // optional, max 1000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveElevationRequest) Reset() {
+	*x = ApproveElevationRequest{}
+	mi := &file_elevation_elevation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveElevationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveElevationRequest) ProtoMessage() {}
+
+func (x *ApproveElevationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveElevationRequest.ProtoReflect.Descriptor instead.
+func (*ApproveElevationRequest) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ApproveElevationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApproveElevationRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ApproveElevationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elevation     *Elevation             `protobuf:"bytes,1,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveElevationResponse) Reset() {
+	*x = ApproveElevationResponse{}
+	mi := &file_elevation_elevation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveElevationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveElevationResponse) ProtoMessage() {}
+
+func (x *ApproveElevationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveElevationResponse.ProtoReflect.Descriptor instead.
+func (*ApproveElevationResponse) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ApproveElevationResponse) GetElevation() *Elevation {
+	if x != nil {
+		return x.Elevation
+	}
+	return nil
+}
+
+type RejectElevationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // optional, max 1000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectElevationRequest) Reset() {
+	*x = RejectElevationRequest{}
+	mi := &file_elevation_elevation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectElevationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectElevationRequest) ProtoMessage() {}
+
+func (x *RejectElevationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectElevationRequest.ProtoReflect.Descriptor instead.
+func (*RejectElevationRequest) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RejectElevationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RejectElevationRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RejectElevationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elevation     *Elevation             `protobuf:"bytes,1,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectElevationResponse) Reset() {
+	*x = RejectElevationResponse{}
+	mi := &file_elevation_elevation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectElevationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectElevationResponse) ProtoMessage() {}
+
+func (x *RejectElevationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectElevationResponse.ProtoReflect.Descriptor instead.
+func (*RejectElevationResponse) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RejectElevationResponse) GetElevation() *Elevation {
+	if x != nil {
+		return x.Elevation
+	}
+	return nil
+}
+
+type RevokeElevationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // optional, max 1000 characters; audited only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeElevationRequest) Reset() {
+	*x = RevokeElevationRequest{}
+	mi := &file_elevation_elevation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeElevationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeElevationRequest) ProtoMessage() {}
+
+func (x *RevokeElevationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeElevationRequest.ProtoReflect.Descriptor instead.
+func (*RevokeElevationRequest) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RevokeElevationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RevokeElevationRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RevokeElevationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elevation     *Elevation             `protobuf:"bytes,1,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeElevationResponse) Reset() {
+	*x = RevokeElevationResponse{}
+	mi := &file_elevation_elevation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeElevationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeElevationResponse) ProtoMessage() {}
+
+func (x *RevokeElevationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elevation_elevation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeElevationResponse.ProtoReflect.Descriptor instead.
+func (*RevokeElevationResponse) Descriptor() ([]byte, []int) {
+	return file_elevation_elevation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RevokeElevationResponse) GetElevation() *Elevation {
+	if x != nil {
+		return x.Elevation
+	}
+	return nil
+}
+
+var File_elevation_elevation_proto protoreflect.FileDescriptor
+
+const file_elevation_elevation_proto_rawDesc = "" +
+	"\n" +
+	"\x19elevation/elevation.proto\x12\x11ztcp.elevation.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x04\n" +
+	"\tElevation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12$\n" +
+	"\rjustification\x18\x05 \x01(\tR\rjustification\x12)\n" +
+	"\x10duration_seconds\x18\x06 \x01(\x03R\x0fdurationSeconds\x12\x1f\n" +
+	"\vreviewed_by\x18\a \x01(\tR\n" +
+	"reviewedBy\x12%\n" +
+	"\x0ereview_comment\x18\b \x01(\tR\rreviewComment\x12;\n" +
+	"\vreviewed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"revoked_by\x18\v \x01(\tR\trevokedBy\x129\n" +
+	"\n" +
+	"revoked_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"j\n" +
+	"\x17RequestElevationRequest\x12$\n" +
+	"\rjustification\x18\x01 \x01(\tR\rjustification\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x03R\x0fdurationSeconds\"V\n" +
+	"\x18RequestElevationResponse\x12:\n" +
+	"\televation\x18\x01 \x01(\v2\x1c.ztcp.elevation.v1.ElevationR\televation\"\x84\x01\n" +
+	"\x15ListElevationsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12:\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\x98\x01\n" +
+	"\x16ListElevationsResponse\x12<\n" +
+	"\n" +
+	"elevations\x18\x01 \x03(\v2\x1c.ztcp.elevation.v1.ElevationR\n" +
+	"elevations\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"C\n" +
+	"\x17ApproveElevationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"V\n" +
+	"\x18ApproveElevationResponse\x12:\n" +
+	"\televation\x18\x01 \x01(\v2\x1c.ztcp.elevation.v1.ElevationR\televation\"B\n" +
+	"\x16RejectElevationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"U\n" +
+	"\x17RejectElevationResponse\x12:\n" +
+	"\televation\x18\x01 \x01(\v2\x1c.ztcp.elevation.v1.ElevationR\televation\"B\n" +
+	"\x16RevokeElevationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"U\n" +
+	"\x17RevokeElevationResponse\x12:\n" +
+	"\televation\x18\x01 \x01(\v2\x1c.ztcp.elevation.v1.ElevationR\televation2\xac\x04\n" +
+	"\x10ElevationService\x12k\n" +
+	"\x10RequestElevation\x12*.ztcp.elevation.v1.RequestElevationRequest\x1a+.ztcp.elevation.v1.RequestElevationResponse\x12j\n" +
+	"\x0eListElevations\x12(.ztcp.elevation.v1.ListElevationsRequest\x1a).ztcp.elevation.v1.ListElevationsResponse\"\x03\x90\x02\x01\x12k\n" +
+	"\x10ApproveElevation\x12*.ztcp.elevation.v1.ApproveElevationRequest\x1a+.ztcp.elevation.v1.ApproveElevationResponse\x12h\n" +
+	"\x0fRejectElevation\x12).ztcp.elevation.v1.RejectElevationRequest\x1a*.ztcp.elevation.v1.RejectElevationResponse\x12h\n" +
+	"\x0fRevokeElevation\x12).ztcp.elevation.v1.RevokeElevationRequest\x1a*.ztcp.elevation.v1.RevokeElevationResponseBIZGzero-trust-control-plane/backend/api/generated/elevation/v1;elevationv1b\x06proto3"
+
+var (
+	file_elevation_elevation_proto_rawDescOnce sync.Once
+	file_elevation_elevation_proto_rawDescData []byte
+)
+
+func file_elevation_elevation_proto_rawDescGZIP() []byte {
+	file_elevation_elevation_proto_rawDescOnce.Do(func() {
+		file_elevation_elevation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_elevation_elevation_proto_rawDesc), len(file_elevation_elevation_proto_rawDesc)))
+	})
+	return file_elevation_elevation_proto_rawDescData
+}
+
+var file_elevation_elevation_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_elevation_elevation_proto_goTypes = []any{
+	(*Elevation)(nil),                // 0: ztcp.elevation.v1.Elevation
+	(*RequestElevationRequest)(nil),  // 1: ztcp.elevation.v1.RequestElevationRequest
+	(*RequestElevationResponse)(nil), // 2: ztcp.elevation.v1.RequestElevationResponse
+	(*ListElevationsRequest)(nil),    // 3: ztcp.elevation.v1.ListElevationsRequest
+	(*ListElevationsResponse)(nil),   // 4: ztcp.elevation.v1.ListElevationsResponse
+	(*ApproveElevationRequest)(nil),  // 5: ztcp.elevation.v1.ApproveElevationRequest
+	(*ApproveElevationResponse)(nil), // 6: ztcp.elevation.v1.ApproveElevationResponse
+	(*RejectElevationRequest)(nil),   // 7: ztcp.elevation.v1.RejectElevationRequest
+	(*RejectElevationResponse)(nil),  // 8: ztcp.elevation.v1.RejectElevationResponse
+	(*RevokeElevationRequest)(nil),   // 9: ztcp.elevation.v1.RevokeElevationRequest
+	(*RevokeElevationResponse)(nil),  // 10: ztcp.elevation.v1.RevokeElevationResponse
+	(*timestamppb.Timestamp)(nil),    // 11: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),            // 12: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),      // 13: ztcp.common.v1.PaginationResult
+}
+var file_elevation_elevation_proto_depIdxs = []int32{
+	11, // 0: ztcp.elevation.v1.Elevation.reviewed_at:type_name -> google.protobuf.Timestamp
+	11, // 1: ztcp.elevation.v1.Elevation.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 2: ztcp.elevation.v1.Elevation.revoked_at:type_name -> google.protobuf.Timestamp
+	11, // 3: ztcp.elevation.v1.Elevation.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: ztcp.elevation.v1.RequestElevationResponse.elevation:type_name -> ztcp.elevation.v1.Elevation
+	12, // 5: ztcp.elevation.v1.ListElevationsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	0,  // 6: ztcp.elevation.v1.ListElevationsResponse.elevations:type_name -> ztcp.elevation.v1.Elevation
+	13, // 7: ztcp.elevation.v1.ListElevationsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	0,  // 8: ztcp.elevation.v1.ApproveElevationResponse.elevation:type_name -> ztcp.elevation.v1.Elevation
+	0,  // 9: ztcp.elevation.v1.RejectElevationResponse.elevation:type_name -> ztcp.elevation.v1.Elevation
+	0,  // 10: ztcp.elevation.v1.RevokeElevationResponse.elevation:type_name -> ztcp.elevation.v1.Elevation
+	1,  // 11: ztcp.elevation.v1.ElevationService.RequestElevation:input_type -> ztcp.elevation.v1.RequestElevationRequest
+	3,  // 12: ztcp.elevation.v1.ElevationService.ListElevations:input_type -> ztcp.elevation.v1.ListElevationsRequest
+	5,  // 13: ztcp.elevation.v1.ElevationService.ApproveElevation:input_type -> ztcp.elevation.v1.ApproveElevationRequest
+	7,  // 14: ztcp.elevation.v1.ElevationService.RejectElevation:input_type -> ztcp.elevation.v1.RejectElevationRequest
+	9,  // 15: ztcp.elevation.v1.ElevationService.RevokeElevation:input_type -> ztcp.elevation.v1.RevokeElevationRequest
+	2,  // 16: ztcp.elevation.v1.ElevationService.RequestElevation:output_type -> ztcp.elevation.v1.RequestElevationResponse
+	4,  // 17: ztcp.elevation.v1.ElevationService.ListElevations:output_type -> ztcp.elevation.v1.ListElevationsResponse
+	6,  // 18: ztcp.elevation.v1.ElevationService.ApproveElevation:output_type -> ztcp.elevation.v1.ApproveElevationResponse
+	8,  // 19: ztcp.elevation.v1.ElevationService.RejectElevation:output_type -> ztcp.elevation.v1.RejectElevationResponse
+	10, // 20: ztcp.elevation.v1.ElevationService.RevokeElevation:output_type -> ztcp.elevation.v1.RevokeElevationResponse
+	16, // [16:21] is the sub-list for method output_type
+	11, // [11:16] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_elevation_elevation_proto_init() }
+func file_elevation_elevation_proto_init() {
+	if File_elevation_elevation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_elevation_elevation_proto_rawDesc), len(file_elevation_elevation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_elevation_elevation_proto_goTypes,
+		DependencyIndexes: file_elevation_elevation_proto_depIdxs,
+		MessageInfos:      file_elevation_elevation_proto_msgTypes,
+	}.Build()
+	File_elevation_elevation_proto = out.File
+	file_elevation_elevation_proto_goTypes = nil
+	file_elevation_elevation_proto_depIdxs = nil
+}
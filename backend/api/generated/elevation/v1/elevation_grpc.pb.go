@@ -0,0 +1,293 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: elevation/elevation.proto
+
+package elevationv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ElevationService_RequestElevation_FullMethodName = "/ztcp.elevation.v1.ElevationService/RequestElevation"
+	ElevationService_ListElevations_FullMethodName   = "/ztcp.elevation.v1.ElevationService/ListElevations"
+	ElevationService_ApproveElevation_FullMethodName = "/ztcp.elevation.v1.ElevationService/ApproveElevation"
+	ElevationService_RejectElevation_FullMethodName  = "/ztcp.elevation.v1.ElevationService/RejectElevation"
+	ElevationService_RevokeElevation_FullMethodName  = "/ztcp.elevation.v1.ElevationService/RevokeElevation"
+)
+
+// ElevationServiceClient is the client API for ElevationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ElevationService is just-in-time privilege elevation. A member requests temporary org admin rights with a
+// justification; an org owner approves or rejects the request. An approved elevation makes the member an org admin
+// until it expires or is revoked, and is reflected in access-token claims from the next refresh. Every step is
+// audited.
+type ElevationServiceClient interface {
+	// RequestElevation stores a pending request. Caller must be an org member with role member and no pending or
+	// active elevation.
+	RequestElevation(ctx context.Context, in *RequestElevationRequest, opts ...grpc.CallOption) (*RequestElevationResponse, error)
+	ListElevations(ctx context.Context, in *ListElevationsRequest, opts ...grpc.CallOption) (*ListElevationsResponse, error)
+	// ApproveElevation starts the elevation window. Caller must be an org owner.
+	ApproveElevation(ctx context.Context, in *ApproveElevationRequest, opts ...grpc.CallOption) (*ApproveElevationResponse, error)
+	// RejectElevation closes a pending request. Caller must be an org owner, or the requester (withdrawing it).
+	RejectElevation(ctx context.Context, in *RejectElevationRequest, opts ...grpc.CallOption) (*RejectElevationResponse, error)
+	// RevokeElevation ends an active elevation early. Caller must be an org owner, or the elevated member.
+	RevokeElevation(ctx context.Context, in *RevokeElevationRequest, opts ...grpc.CallOption) (*RevokeElevationResponse, error)
+}
+
+type elevationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewElevationServiceClient(cc grpc.ClientConnInterface) ElevationServiceClient {
+	return &elevationServiceClient{cc}
+}
+
+func (c *elevationServiceClient) RequestElevation(ctx context.Context, in *RequestElevationRequest, opts ...grpc.CallOption) (*RequestElevationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestElevationResponse)
+	err := c.cc.Invoke(ctx, ElevationService_RequestElevation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevationServiceClient) ListElevations(ctx context.Context, in *ListElevationsRequest, opts ...grpc.CallOption) (*ListElevationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListElevationsResponse)
+	err := c.cc.Invoke(ctx, ElevationService_ListElevations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevationServiceClient) ApproveElevation(ctx context.Context, in *ApproveElevationRequest, opts ...grpc.CallOption) (*ApproveElevationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveElevationResponse)
+	err := c.cc.Invoke(ctx, ElevationService_ApproveElevation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevationServiceClient) RejectElevation(ctx context.Context, in *RejectElevationRequest, opts ...grpc.CallOption) (*RejectElevationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectElevationResponse)
+	err := c.cc.Invoke(ctx, ElevationService_RejectElevation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevationServiceClient) RevokeElevation(ctx context.Context, in *RevokeElevationRequest, opts ...grpc.CallOption) (*RevokeElevationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeElevationResponse)
+	err := c.cc.Invoke(ctx, ElevationService_RevokeElevation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ElevationServiceServer is the server API for ElevationService service.
+// All implementations must embed UnimplementedElevationServiceServer
+// for forward compatibility.
+//
+// ElevationService is just-in-time privilege elevation. A member requests temporary org admin rights with a
+// justification; an org owner approves or rejects the request. An approved elevation makes the member an org admin
+// until it expires or is revoked, and is reflected in access-token claims from the next refresh. Every step is
+// audited.
+type ElevationServiceServer interface {
+	// RequestElevation stores a pending request. Caller must be an org member with role member and no pending or
+	// active elevation.
+	RequestElevation(context.Context, *RequestElevationRequest) (*RequestElevationResponse, error)
+	ListElevations(context.Context, *ListElevationsRequest) (*ListElevationsResponse, error)
+	// ApproveElevation starts the elevation window. Caller must be an org owner.
+	ApproveElevation(context.Context, *ApproveElevationRequest) (*ApproveElevationResponse, error)
+	// RejectElevation closes a pending request. Caller must be an org owner, or the requester (withdrawing it).
+	RejectElevation(context.Context, *RejectElevationRequest) (*RejectElevationResponse, error)
+	// RevokeElevation ends an active elevation early. Caller must be an org owner, or the elevated member.
+	RevokeElevation(context.Context, *RevokeElevationRequest) (*RevokeElevationResponse, error)
+	mustEmbedUnimplementedElevationServiceServer()
+}
+
+// UnimplementedElevationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedElevationServiceServer struct{}
+
+func (UnimplementedElevationServiceServer) RequestElevation(context.Context, *RequestElevationRequest) (*RequestElevationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestElevation not implemented")
+}
+func (UnimplementedElevationServiceServer) ListElevations(context.Context, *ListElevationsRequest) (*ListElevationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListElevations not implemented")
+}
+func (UnimplementedElevationServiceServer) ApproveElevation(context.Context, *ApproveElevationRequest) (*ApproveElevationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveElevation not implemented")
+}
+func (UnimplementedElevationServiceServer) RejectElevation(context.Context, *RejectElevationRequest) (*RejectElevationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectElevation not implemented")
+}
+func (UnimplementedElevationServiceServer) RevokeElevation(context.Context, *RevokeElevationRequest) (*RevokeElevationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeElevation not implemented")
+}
+func (UnimplementedElevationServiceServer) mustEmbedUnimplementedElevationServiceServer() {}
+func (UnimplementedElevationServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeElevationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ElevationServiceServer will
+// result in compilation errors.
+type UnsafeElevationServiceServer interface {
+	mustEmbedUnimplementedElevationServiceServer()
+}
+
+func RegisterElevationServiceServer(s grpc.ServiceRegistrar, srv ElevationServiceServer) {
+	// If the following call panics, it indicates UnimplementedElevationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ElevationService_ServiceDesc, srv)
+}
+
+func _ElevationService_RequestElevation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestElevationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevationServiceServer).RequestElevation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevationService_RequestElevation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevationServiceServer).RequestElevation(ctx, req.(*RequestElevationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevationService_ListElevations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListElevationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevationServiceServer).ListElevations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevationService_ListElevations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevationServiceServer).ListElevations(ctx, req.(*ListElevationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevationService_ApproveElevation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveElevationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevationServiceServer).ApproveElevation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevationService_ApproveElevation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevationServiceServer).ApproveElevation(ctx, req.(*ApproveElevationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevationService_RejectElevation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectElevationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevationServiceServer).RejectElevation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevationService_RejectElevation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevationServiceServer).RejectElevation(ctx, req.(*RejectElevationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevationService_RevokeElevation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeElevationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevationServiceServer).RevokeElevation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevationService_RevokeElevation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevationServiceServer).RevokeElevation(ctx, req.(*RevokeElevationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ElevationService_ServiceDesc is the grpc.ServiceDesc for ElevationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ElevationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.elevation.v1.ElevationService",
+	HandlerType: (*ElevationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestElevation",
+			Handler:    _ElevationService_RequestElevation_Handler,
+		},
+		{
+			MethodName: "ListElevations",
+			Handler:    _ElevationService_ListElevations_Handler,
+		},
+		{
+			MethodName: "ApproveElevation",
+			Handler:    _ElevationService_ApproveElevation_Handler,
+		},
+		{
+			MethodName: "RejectElevation",
+			Handler:    _ElevationService_RejectElevation_Handler,
+		},
+		{
+			MethodName: "RevokeElevation",
+			Handler:    _ElevationService_RevokeElevation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "elevation/elevation.proto",
+}
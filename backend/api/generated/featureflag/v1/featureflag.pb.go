@@ -0,0 +1,866 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: featureflag/featureflag.proto
+
+package featureflagv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FeatureFlag gates a feature during rollout. A flag is on for an org when the org has an override that says so,
+// or when enabled is true and the org falls within rollout_percentage (a stable per-org bucket).
+type FeatureFlag struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Key               string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // e.g. auth.refresh_pop; lowercase letters, digits, '.', '_' and '-'
+	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled           bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`                                              // false turns the flag off for every org without an override
+	RolloutPercentage int32                  `protobuf:"varint,4,opt,name=rollout_percentage,json=rolloutPercentage,proto3" json:"rollout_percentage,omitempty"` // 0-100
+	OrgOverrides      []*OrgOverride         `protobuf:"bytes,5,rep,name=org_overrides,json=orgOverrides,proto3" json:"org_overrides,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlag) ProtoMessage() {}
+
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FeatureFlag) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetRolloutPercentage() int32 {
+	if x != nil {
+		return x.RolloutPercentage
+	}
+	return 0
+}
+
+func (x *FeatureFlag) GetOrgOverrides() []*OrgOverride {
+	if x != nil {
+		return x.OrgOverrides
+	}
+	return nil
+}
+
+func (x *FeatureFlag) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FeatureFlag) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// OrgOverride forces a flag on or off for one org, regardless of enabled and rollout_percentage.
+type OrgOverride struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgOverride) Reset() {
+	*x = OrgOverride{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgOverride) ProtoMessage() {}
+
+func (x *OrgOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgOverride.ProtoReflect.Descriptor instead.
+func (*OrgOverride) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrgOverride) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrgOverride) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *OrgOverride) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ListFeatureFlagsRequest is empty.
+type ListFeatureFlagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsRequest) Reset() {
+	*x = ListFeatureFlagsRequest{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsRequest) ProtoMessage() {}
+
+func (x *ListFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{2}
+}
+
+type ListFeatureFlagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         []*FeatureFlag         `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsResponse) Reset() {
+	*x = ListFeatureFlagsResponse{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsResponse) ProtoMessage() {}
+
+func (x *ListFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListFeatureFlagsResponse) GetFlags() []*FeatureFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+// UpsertFeatureFlagRequest creates the flag or replaces its settings. Overrides are kept.
+type UpsertFeatureFlagRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Key               string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled           bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	RolloutPercentage int32                  `protobuf:"varint,4,opt,name=rollout_percentage,json=rolloutPercentage,proto3" json:"rollout_percentage,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpsertFeatureFlagRequest) Reset() {
+	*x = UpsertFeatureFlagRequest{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertFeatureFlagRequest) ProtoMessage() {}
+
+func (x *UpsertFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*UpsertFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpsertFeatureFlagRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UpsertFeatureFlagRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpsertFeatureFlagRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpsertFeatureFlagRequest) GetRolloutPercentage() int32 {
+	if x != nil {
+		return x.RolloutPercentage
+	}
+	return 0
+}
+
+type UpsertFeatureFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flag          *FeatureFlag           `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertFeatureFlagResponse) Reset() {
+	*x = UpsertFeatureFlagResponse{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertFeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertFeatureFlagResponse) ProtoMessage() {}
+
+func (x *UpsertFeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertFeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*UpsertFeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpsertFeatureFlagResponse) GetFlag() *FeatureFlag {
+	if x != nil {
+		return x.Flag
+	}
+	return nil
+}
+
+// DeleteFeatureFlagRequest deletes the flag and its overrides. The flag reverts to its built-in default.
+type DeleteFeatureFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeatureFlagRequest) Reset() {
+	*x = DeleteFeatureFlagRequest{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeatureFlagRequest) ProtoMessage() {}
+
+func (x *DeleteFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteFeatureFlagRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type DeleteFeatureFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeatureFlagResponse) Reset() {
+	*x = DeleteFeatureFlagResponse{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeatureFlagResponse) ProtoMessage() {}
+
+func (x *DeleteFeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{7}
+}
+
+type SetOrgOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgOverrideRequest) Reset() {
+	*x = SetOrgOverrideRequest{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgOverrideRequest) ProtoMessage() {}
+
+func (x *SetOrgOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetOrgOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetOrgOverrideRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetOrgOverrideRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SetOrgOverrideRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetOrgOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrgOverrideResponse) Reset() {
+	*x = SetOrgOverrideResponse{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrgOverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrgOverrideResponse) ProtoMessage() {}
+
+func (x *SetOrgOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrgOverrideResponse.ProtoReflect.Descriptor instead.
+func (*SetOrgOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{9}
+}
+
+type ClearOrgOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearOrgOverrideRequest) Reset() {
+	*x = ClearOrgOverrideRequest{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearOrgOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearOrgOverrideRequest) ProtoMessage() {}
+
+func (x *ClearOrgOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearOrgOverrideRequest.ProtoReflect.Descriptor instead.
+func (*ClearOrgOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClearOrgOverrideRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ClearOrgOverrideRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ClearOrgOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearOrgOverrideResponse) Reset() {
+	*x = ClearOrgOverrideResponse{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearOrgOverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearOrgOverrideResponse) ProtoMessage() {}
+
+func (x *ClearOrgOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearOrgOverrideResponse.ProtoReflect.Descriptor instead.
+func (*ClearOrgOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{11}
+}
+
+// EvaluateFeatureFlagsRequest evaluates flags for the caller's org. Empty keys evaluates every known flag.
+type EvaluateFeatureFlagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateFeatureFlagsRequest) Reset() {
+	*x = EvaluateFeatureFlagsRequest{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateFeatureFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateFeatureFlagsRequest) ProtoMessage() {}
+
+func (x *EvaluateFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EvaluateFeatureFlagsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type EvaluateFeatureFlagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         map[string]bool        `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateFeatureFlagsResponse) Reset() {
+	*x = EvaluateFeatureFlagsResponse{}
+	mi := &file_featureflag_featureflag_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateFeatureFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateFeatureFlagsResponse) ProtoMessage() {}
+
+func (x *EvaluateFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_featureflag_featureflag_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_featureflag_featureflag_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EvaluateFeatureFlagsResponse) GetFlags() map[string]bool {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+var File_featureflag_featureflag_proto protoreflect.FileDescriptor
+
+const file_featureflag_featureflag_proto_rawDesc = "" +
+	"\n" +
+	"\x1dfeatureflag/featureflag.proto\x12\x13ztcp.featureflag.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc7\x02\n" +
+	"\vFeatureFlag\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12-\n" +
+	"\x12rollout_percentage\x18\x04 \x01(\x05R\x11rolloutPercentage\x12E\n" +
+	"\rorg_overrides\x18\x05 \x03(\v2 .ztcp.featureflag.v1.OrgOverrideR\forgOverrides\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"y\n" +
+	"\vOrgOverride\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x19\n" +
+	"\x17ListFeatureFlagsRequest\"R\n" +
+	"\x18ListFeatureFlagsResponse\x126\n" +
+	"\x05flags\x18\x01 \x03(\v2 .ztcp.featureflag.v1.FeatureFlagR\x05flags\"\x97\x01\n" +
+	"\x18UpsertFeatureFlagRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12-\n" +
+	"\x12rollout_percentage\x18\x04 \x01(\x05R\x11rolloutPercentage\"Q\n" +
+	"\x19UpsertFeatureFlagResponse\x124\n" +
+	"\x04flag\x18\x01 \x01(\v2 .ztcp.featureflag.v1.FeatureFlagR\x04flag\",\n" +
+	"\x18DeleteFeatureFlagRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"\x1b\n" +
+	"\x19DeleteFeatureFlagResponse\"Z\n" +
+	"\x15SetOrgOverrideRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\"\x18\n" +
+	"\x16SetOrgOverrideResponse\"B\n" +
+	"\x17ClearOrgOverrideRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\"\x1a\n" +
+	"\x18ClearOrgOverrideResponse\"1\n" +
+	"\x1bEvaluateFeatureFlagsRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"\xac\x01\n" +
+	"\x1cEvaluateFeatureFlagsResponse\x12R\n" +
+	"\x05flags\x18\x01 \x03(\v2<.ztcp.featureflag.v1.EvaluateFeatureFlagsResponse.FlagsEntryR\x05flags\x1a8\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x012\xd1\x05\n" +
+	"\x12FeatureFlagService\x12t\n" +
+	"\x10ListFeatureFlags\x12,.ztcp.featureflag.v1.ListFeatureFlagsRequest\x1a-.ztcp.featureflag.v1.ListFeatureFlagsResponse\"\x03\x90\x02\x01\x12r\n" +
+	"\x11UpsertFeatureFlag\x12-.ztcp.featureflag.v1.UpsertFeatureFlagRequest\x1a..ztcp.featureflag.v1.UpsertFeatureFlagResponse\x12r\n" +
+	"\x11DeleteFeatureFlag\x12-.ztcp.featureflag.v1.DeleteFeatureFlagRequest\x1a..ztcp.featureflag.v1.DeleteFeatureFlagResponse\x12i\n" +
+	"\x0eSetOrgOverride\x12*.ztcp.featureflag.v1.SetOrgOverrideRequest\x1a+.ztcp.featureflag.v1.SetOrgOverrideResponse\x12o\n" +
+	"\x10ClearOrgOverride\x12,.ztcp.featureflag.v1.ClearOrgOverrideRequest\x1a-.ztcp.featureflag.v1.ClearOrgOverrideResponse\x12\x80\x01\n" +
+	"\x14EvaluateFeatureFlags\x120.ztcp.featureflag.v1.EvaluateFeatureFlagsRequest\x1a1.ztcp.featureflag.v1.EvaluateFeatureFlagsResponse\"\x03\x90\x02\x01BMZKzero-trust-control-plane/backend/api/generated/featureflag/v1;featureflagv1b\x06proto3"
+
+var (
+	file_featureflag_featureflag_proto_rawDescOnce sync.Once
+	file_featureflag_featureflag_proto_rawDescData []byte
+)
+
+func file_featureflag_featureflag_proto_rawDescGZIP() []byte {
+	file_featureflag_featureflag_proto_rawDescOnce.Do(func() {
+		file_featureflag_featureflag_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_featureflag_featureflag_proto_rawDesc), len(file_featureflag_featureflag_proto_rawDesc)))
+	})
+	return file_featureflag_featureflag_proto_rawDescData
+}
+
+var file_featureflag_featureflag_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_featureflag_featureflag_proto_goTypes = []any{
+	(*FeatureFlag)(nil),                  // 0: ztcp.featureflag.v1.FeatureFlag
+	(*OrgOverride)(nil),                  // 1: ztcp.featureflag.v1.OrgOverride
+	(*ListFeatureFlagsRequest)(nil),      // 2: ztcp.featureflag.v1.ListFeatureFlagsRequest
+	(*ListFeatureFlagsResponse)(nil),     // 3: ztcp.featureflag.v1.ListFeatureFlagsResponse
+	(*UpsertFeatureFlagRequest)(nil),     // 4: ztcp.featureflag.v1.UpsertFeatureFlagRequest
+	(*UpsertFeatureFlagResponse)(nil),    // 5: ztcp.featureflag.v1.UpsertFeatureFlagResponse
+	(*DeleteFeatureFlagRequest)(nil),     // 6: ztcp.featureflag.v1.DeleteFeatureFlagRequest
+	(*DeleteFeatureFlagResponse)(nil),    // 7: ztcp.featureflag.v1.DeleteFeatureFlagResponse
+	(*SetOrgOverrideRequest)(nil),        // 8: ztcp.featureflag.v1.SetOrgOverrideRequest
+	(*SetOrgOverrideResponse)(nil),       // 9: ztcp.featureflag.v1.SetOrgOverrideResponse
+	(*ClearOrgOverrideRequest)(nil),      // 10: ztcp.featureflag.v1.ClearOrgOverrideRequest
+	(*ClearOrgOverrideResponse)(nil),     // 11: ztcp.featureflag.v1.ClearOrgOverrideResponse
+	(*EvaluateFeatureFlagsRequest)(nil),  // 12: ztcp.featureflag.v1.EvaluateFeatureFlagsRequest
+	(*EvaluateFeatureFlagsResponse)(nil), // 13: ztcp.featureflag.v1.EvaluateFeatureFlagsResponse
+	nil,                                  // 14: ztcp.featureflag.v1.EvaluateFeatureFlagsResponse.FlagsEntry
+	(*timestamppb.Timestamp)(nil),        // 15: google.protobuf.Timestamp
+}
+var file_featureflag_featureflag_proto_depIdxs = []int32{
+	1,  // 0: ztcp.featureflag.v1.FeatureFlag.org_overrides:type_name -> ztcp.featureflag.v1.OrgOverride
+	15, // 1: ztcp.featureflag.v1.FeatureFlag.created_at:type_name -> google.protobuf.Timestamp
+	15, // 2: ztcp.featureflag.v1.FeatureFlag.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 3: ztcp.featureflag.v1.OrgOverride.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: ztcp.featureflag.v1.ListFeatureFlagsResponse.flags:type_name -> ztcp.featureflag.v1.FeatureFlag
+	0,  // 5: ztcp.featureflag.v1.UpsertFeatureFlagResponse.flag:type_name -> ztcp.featureflag.v1.FeatureFlag
+	14, // 6: ztcp.featureflag.v1.EvaluateFeatureFlagsResponse.flags:type_name -> ztcp.featureflag.v1.EvaluateFeatureFlagsResponse.FlagsEntry
+	2,  // 7: ztcp.featureflag.v1.FeatureFlagService.ListFeatureFlags:input_type -> ztcp.featureflag.v1.ListFeatureFlagsRequest
+	4,  // 8: ztcp.featureflag.v1.FeatureFlagService.UpsertFeatureFlag:input_type -> ztcp.featureflag.v1.UpsertFeatureFlagRequest
+	6,  // 9: ztcp.featureflag.v1.FeatureFlagService.DeleteFeatureFlag:input_type -> ztcp.featureflag.v1.DeleteFeatureFlagRequest
+	8,  // 10: ztcp.featureflag.v1.FeatureFlagService.SetOrgOverride:input_type -> ztcp.featureflag.v1.SetOrgOverrideRequest
+	10, // 11: ztcp.featureflag.v1.FeatureFlagService.ClearOrgOverride:input_type -> ztcp.featureflag.v1.ClearOrgOverrideRequest
+	12, // 12: ztcp.featureflag.v1.FeatureFlagService.EvaluateFeatureFlags:input_type -> ztcp.featureflag.v1.EvaluateFeatureFlagsRequest
+	3,  // 13: ztcp.featureflag.v1.FeatureFlagService.ListFeatureFlags:output_type -> ztcp.featureflag.v1.ListFeatureFlagsResponse
+	5,  // 14: ztcp.featureflag.v1.FeatureFlagService.UpsertFeatureFlag:output_type -> ztcp.featureflag.v1.UpsertFeatureFlagResponse
+	7,  // 15: ztcp.featureflag.v1.FeatureFlagService.DeleteFeatureFlag:output_type -> ztcp.featureflag.v1.DeleteFeatureFlagResponse
+	9,  // 16: ztcp.featureflag.v1.FeatureFlagService.SetOrgOverride:output_type -> ztcp.featureflag.v1.SetOrgOverrideResponse
+	11, // 17: ztcp.featureflag.v1.FeatureFlagService.ClearOrgOverride:output_type -> ztcp.featureflag.v1.ClearOrgOverrideResponse
+	13, // 18: ztcp.featureflag.v1.FeatureFlagService.EvaluateFeatureFlags:output_type -> ztcp.featureflag.v1.EvaluateFeatureFlagsResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_featureflag_featureflag_proto_init() }
+func file_featureflag_featureflag_proto_init() {
+	if File_featureflag_featureflag_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_featureflag_featureflag_proto_rawDesc), len(file_featureflag_featureflag_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_featureflag_featureflag_proto_goTypes,
+		DependencyIndexes: file_featureflag_featureflag_proto_depIdxs,
+		MessageInfos:      file_featureflag_featureflag_proto_msgTypes,
+	}.Build()
+	File_featureflag_featureflag_proto = out.File
+	file_featureflag_featureflag_proto_goTypes = nil
+	file_featureflag_featureflag_proto_depIdxs = nil
+}
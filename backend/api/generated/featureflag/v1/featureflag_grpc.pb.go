@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: featureflag/featureflag.proto
+
+package featureflagv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FeatureFlagService_ListFeatureFlags_FullMethodName     = "/ztcp.featureflag.v1.FeatureFlagService/ListFeatureFlags"
+	FeatureFlagService_UpsertFeatureFlag_FullMethodName    = "/ztcp.featureflag.v1.FeatureFlagService/UpsertFeatureFlag"
+	FeatureFlagService_DeleteFeatureFlag_FullMethodName    = "/ztcp.featureflag.v1.FeatureFlagService/DeleteFeatureFlag"
+	FeatureFlagService_SetOrgOverride_FullMethodName       = "/ztcp.featureflag.v1.FeatureFlagService/SetOrgOverride"
+	FeatureFlagService_ClearOrgOverride_FullMethodName     = "/ztcp.featureflag.v1.FeatureFlagService/ClearOrgOverride"
+	FeatureFlagService_EvaluateFeatureFlags_FullMethodName = "/ztcp.featureflag.v1.FeatureFlagService/EvaluateFeatureFlags"
+)
+
+// FeatureFlagServiceClient is the client API for FeatureFlagService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FeatureFlagService manages feature flags for gradual rollouts. Management RPCs are for platform admins
+// (PLATFORM_ADMIN_USER_IDS); EvaluateFeatureFlags is for any org member.
+type FeatureFlagServiceClient interface {
+	ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error)
+	UpsertFeatureFlag(ctx context.Context, in *UpsertFeatureFlagRequest, opts ...grpc.CallOption) (*UpsertFeatureFlagResponse, error)
+	DeleteFeatureFlag(ctx context.Context, in *DeleteFeatureFlagRequest, opts ...grpc.CallOption) (*DeleteFeatureFlagResponse, error)
+	SetOrgOverride(ctx context.Context, in *SetOrgOverrideRequest, opts ...grpc.CallOption) (*SetOrgOverrideResponse, error)
+	ClearOrgOverride(ctx context.Context, in *ClearOrgOverrideRequest, opts ...grpc.CallOption) (*ClearOrgOverrideResponse, error)
+	// EvaluateFeatureFlags returns which flags are on for the caller's org, so clients can gate UI the same way.
+	EvaluateFeatureFlags(ctx context.Context, in *EvaluateFeatureFlagsRequest, opts ...grpc.CallOption) (*EvaluateFeatureFlagsResponse, error)
+}
+
+type featureFlagServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeatureFlagServiceClient(cc grpc.ClientConnInterface) FeatureFlagServiceClient {
+	return &featureFlagServiceClient{cc}
+}
+
+func (c *featureFlagServiceClient) ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_ListFeatureFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) UpsertFeatureFlag(ctx context.Context, in *UpsertFeatureFlagRequest, opts ...grpc.CallOption) (*UpsertFeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertFeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_UpsertFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) DeleteFeatureFlag(ctx context.Context, in *DeleteFeatureFlagRequest, opts ...grpc.CallOption) (*DeleteFeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_DeleteFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) SetOrgOverride(ctx context.Context, in *SetOrgOverrideRequest, opts ...grpc.CallOption) (*SetOrgOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOrgOverrideResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_SetOrgOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) ClearOrgOverride(ctx context.Context, in *ClearOrgOverrideRequest, opts ...grpc.CallOption) (*ClearOrgOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearOrgOverrideResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_ClearOrgOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) EvaluateFeatureFlags(ctx context.Context, in *EvaluateFeatureFlagsRequest, opts ...grpc.CallOption) (*EvaluateFeatureFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_EvaluateFeatureFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeatureFlagServiceServer is the server API for FeatureFlagService service.
+// All implementations must embed UnimplementedFeatureFlagServiceServer
+// for forward compatibility.
+//
+// FeatureFlagService manages feature flags for gradual rollouts. Management RPCs are for platform admins
+// (PLATFORM_ADMIN_USER_IDS); EvaluateFeatureFlags is for any org member.
+type FeatureFlagServiceServer interface {
+	ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error)
+	UpsertFeatureFlag(context.Context, *UpsertFeatureFlagRequest) (*UpsertFeatureFlagResponse, error)
+	DeleteFeatureFlag(context.Context, *DeleteFeatureFlagRequest) (*DeleteFeatureFlagResponse, error)
+	SetOrgOverride(context.Context, *SetOrgOverrideRequest) (*SetOrgOverrideResponse, error)
+	ClearOrgOverride(context.Context, *ClearOrgOverrideRequest) (*ClearOrgOverrideResponse, error)
+	// EvaluateFeatureFlags returns which flags are on for the caller's org, so clients can gate UI the same way.
+	EvaluateFeatureFlags(context.Context, *EvaluateFeatureFlagsRequest) (*EvaluateFeatureFlagsResponse, error)
+	mustEmbedUnimplementedFeatureFlagServiceServer()
+}
+
+// UnimplementedFeatureFlagServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFeatureFlagServiceServer struct{}
+
+func (UnimplementedFeatureFlagServiceServer) ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFeatureFlags not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) UpsertFeatureFlag(context.Context, *UpsertFeatureFlagRequest) (*UpsertFeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertFeatureFlag not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) DeleteFeatureFlag(context.Context, *DeleteFeatureFlagRequest) (*DeleteFeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFeatureFlag not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) SetOrgOverride(context.Context, *SetOrgOverrideRequest) (*SetOrgOverrideResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOrgOverride not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) ClearOrgOverride(context.Context, *ClearOrgOverrideRequest) (*ClearOrgOverrideResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearOrgOverride not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) EvaluateFeatureFlags(context.Context, *EvaluateFeatureFlagsRequest) (*EvaluateFeatureFlagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EvaluateFeatureFlags not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) mustEmbedUnimplementedFeatureFlagServiceServer() {}
+func (UnimplementedFeatureFlagServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeFeatureFlagServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeatureFlagServiceServer will
+// result in compilation errors.
+type UnsafeFeatureFlagServiceServer interface {
+	mustEmbedUnimplementedFeatureFlagServiceServer()
+}
+
+func RegisterFeatureFlagServiceServer(s grpc.ServiceRegistrar, srv FeatureFlagServiceServer) {
+	// If the following call panics, it indicates UnimplementedFeatureFlagServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FeatureFlagService_ServiceDesc, srv)
+}
+
+func _FeatureFlagService_ListFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeatureFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).ListFeatureFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_ListFeatureFlags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).ListFeatureFlags(ctx, req.(*ListFeatureFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_UpsertFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).UpsertFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_UpsertFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).UpsertFeatureFlag(ctx, req.(*UpsertFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_DeleteFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).DeleteFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_DeleteFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).DeleteFeatureFlag(ctx, req.(*DeleteFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_SetOrgOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrgOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).SetOrgOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_SetOrgOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).SetOrgOverride(ctx, req.(*SetOrgOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_ClearOrgOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearOrgOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).ClearOrgOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_ClearOrgOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).ClearOrgOverride(ctx, req.(*ClearOrgOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_EvaluateFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateFeatureFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).EvaluateFeatureFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_EvaluateFeatureFlags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).EvaluateFeatureFlags(ctx, req.(*EvaluateFeatureFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FeatureFlagService_ServiceDesc is the grpc.ServiceDesc for FeatureFlagService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeatureFlagService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.featureflag.v1.FeatureFlagService",
+	HandlerType: (*FeatureFlagServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFeatureFlags",
+			Handler:    _FeatureFlagService_ListFeatureFlags_Handler,
+		},
+		{
+			MethodName: "UpsertFeatureFlag",
+			Handler:    _FeatureFlagService_UpsertFeatureFlag_Handler,
+		},
+		{
+			MethodName: "DeleteFeatureFlag",
+			Handler:    _FeatureFlagService_DeleteFeatureFlag_Handler,
+		},
+		{
+			MethodName: "SetOrgOverride",
+			Handler:    _FeatureFlagService_SetOrgOverride_Handler,
+		},
+		{
+			MethodName: "ClearOrgOverride",
+			Handler:    _FeatureFlagService_ClearOrgOverride_Handler,
+		},
+		{
+			MethodName: "EvaluateFeatureFlags",
+			Handler:    _FeatureFlagService_EvaluateFeatureFlags_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "featureflag/featureflag.proto",
+}
@@ -164,9 +164,9 @@ const file_health_health_proto_rawDesc = "" +
 	"\rServingStatus\x12\x1e\n" +
 	"\x1aSERVING_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16SERVING_STATUS_SERVING\x10\x01\x12\x1e\n" +
-	"\x1aSERVING_STATUS_NOT_SERVING\x10\x022g\n" +
-	"\rHealthService\x12V\n" +
-	"\vHealthCheck\x12\".ztcp.health.v1.HealthCheckRequest\x1a#.ztcp.health.v1.HealthCheckResponseBCZAzero-trust-control-plane/backend/api/generated/health/v1;healthv1b\x06proto3"
+	"\x1aSERVING_STATUS_NOT_SERVING\x10\x022l\n" +
+	"\rHealthService\x12[\n" +
+	"\vHealthCheck\x12\".ztcp.health.v1.HealthCheckRequest\x1a#.ztcp.health.v1.HealthCheckResponse\"\x03\x90\x02\x01BCZAzero-trust-control-plane/backend/api/generated/health/v1;healthv1b\x06proto3"
 
 var (
 	file_health_health_proto_rawDescOnce sync.Once
@@ -605,13 +605,13 @@ const file_membership_membership_proto_rawDesc = "" +
 	"ROLE_OWNER\x10\x01\x12\x0e\n" +
 	"\n" +
 	"ROLE_ADMIN\x10\x02\x12\x0f\n" +
-	"\vROLE_MEMBER\x10\x032\x8d\x03\n" +
+	"\vROLE_MEMBER\x10\x032\x92\x03\n" +
 	"\x11MembershipService\x12X\n" +
 	"\tAddMember\x12$.ztcp.membership.v1.AddMemberRequest\x1a%.ztcp.membership.v1.AddMemberResponse\x12a\n" +
 	"\fRemoveMember\x12'.ztcp.membership.v1.RemoveMemberRequest\x1a(.ztcp.membership.v1.RemoveMemberResponse\x12[\n" +
 	"\n" +
-	"UpdateRole\x12%.ztcp.membership.v1.UpdateRoleRequest\x1a&.ztcp.membership.v1.UpdateRoleResponse\x12^\n" +
-	"\vListMembers\x12&.ztcp.membership.v1.ListMembersRequest\x1a'.ztcp.membership.v1.ListMembersResponseBKZIzero-trust-control-plane/backend/api/generated/membership/v1;membershipv1b\x06proto3"
+	"UpdateRole\x12%.ztcp.membership.v1.UpdateRoleRequest\x1a&.ztcp.membership.v1.UpdateRoleResponse\x12c\n" +
+	"\vListMembers\x12&.ztcp.membership.v1.ListMembersRequest\x1a'.ztcp.membership.v1.ListMembersResponse\"\x03\x90\x02\x01BKZIzero-trust-control-plane/backend/api/generated/membership/v1;membershipv1b\x06proto3"
 
 var (
 	file_membership_membership_proto_rawDescOnce sync.Once
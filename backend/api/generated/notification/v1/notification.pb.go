@@ -0,0 +1,1505 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: notification/notification.proto
+
+package notificationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NotificationPreferences holds the caller's notification settings.
+type NotificationPreferences struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	LoginAlertsEnabled       bool                   `protobuf:"varint,1,opt,name=login_alerts_enabled,json=loginAlertsEnabled,proto3" json:"login_alerts_enabled,omitempty"`                       // alert on sign-in from a new device or location
+	LoginAlertsEnforcedByOrg bool                   `protobuf:"varint,2,opt,name=login_alerts_enforced_by_org,json=loginAlertsEnforcedByOrg,proto3" json:"login_alerts_enforced_by_org,omitempty"` // read-only; true when the org does not allow opting out
+	Locale                   string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`                                                                            // language of notifications, e.g. "de" or "pt-BR"; empty for the org default
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_notification_notification_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NotificationPreferences) GetLoginAlertsEnabled() bool {
+	if x != nil {
+		return x.LoginAlertsEnabled
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetLoginAlertsEnforcedByOrg() bool {
+	if x != nil {
+		return x.LoginAlertsEnforcedByOrg
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesRequest) Reset() {
+	*x = GetNotificationPreferencesRequest{}
+	mi := &file_notification_notification_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{1}
+}
+
+type GetNotificationPreferencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesResponse) Reset() {
+	*x = GetNotificationPreferencesResponse{}
+	mi := &file_notification_notification_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetNotificationPreferencesResponse) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	LoginAlertsEnabled bool                   `protobuf:"varint,1,opt,name=login_alerts_enabled,json=loginAlertsEnabled,proto3" json:"login_alerts_enabled,omitempty"`
+	Locale             *string                `protobuf:"bytes,2,opt,name=locale,proto3,oneof" json:"locale,omitempty"` // unset keeps the current locale; empty clears it
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesRequest) Reset() {
+	*x = UpdateNotificationPreferencesRequest{}
+	mi := &file_notification_notification_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetLoginAlertsEnabled() bool {
+	if x != nil {
+		return x.LoginAlertsEnabled
+	}
+	return false
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
+	}
+	return ""
+}
+
+type UpdateNotificationPreferencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesResponse) Reset() {
+	*x = UpdateNotificationPreferencesResponse{}
+	mi := &file_notification_notification_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateNotificationPreferencesResponse) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// OrgSMTPSettings is the org's own SMTP server for the emails sent to its users.
+type OrgSMTPSettings struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Host     string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port     int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// password_secret is a secrets provider reference to the password, under ORG_SMTP_SECRET_PREFIX<org_id>/; empty
+	// when the server needs no password. The password itself is never returned.
+	PasswordSecret string                 `protobuf:"bytes,4,opt,name=password_secret,json=passwordSecret,proto3" json:"password_secret,omitempty"`
+	FromAddress    string                 `protobuf:"bytes,5,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"` // e.g. "Acme <no-reply@acme.example>"
+	UpdatedBy      string                 `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrgSMTPSettings) Reset() {
+	*x = OrgSMTPSettings{}
+	mi := &file_notification_notification_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgSMTPSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgSMTPSettings) ProtoMessage() {}
+
+func (x *OrgSMTPSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgSMTPSettings.ProtoReflect.Descriptor instead.
+func (*OrgSMTPSettings) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OrgSMTPSettings) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *OrgSMTPSettings) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *OrgSMTPSettings) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *OrgSMTPSettings) GetPasswordSecret() string {
+	if x != nil {
+		return x.PasswordSecret
+	}
+	return ""
+}
+
+func (x *OrgSMTPSettings) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+func (x *OrgSMTPSettings) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *OrgSMTPSettings) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetOrgSMTPSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgSMTPSettingsRequest) Reset() {
+	*x = GetOrgSMTPSettingsRequest{}
+	mi := &file_notification_notification_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgSMTPSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgSMTPSettingsRequest) ProtoMessage() {}
+
+func (x *GetOrgSMTPSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgSMTPSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgSMTPSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{6}
+}
+
+type GetOrgSMTPSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *OrgSMTPSettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"` // unset when the org uses the platform SMTP server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgSMTPSettingsResponse) Reset() {
+	*x = GetOrgSMTPSettingsResponse{}
+	mi := &file_notification_notification_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgSMTPSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgSMTPSettingsResponse) ProtoMessage() {}
+
+func (x *GetOrgSMTPSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgSMTPSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrgSMTPSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrgSMTPSettingsResponse) GetSettings() *OrgSMTPSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// UpdateOrgSMTPSettingsRequest replaces the org's SMTP settings. port defaults to 587.
+type UpdateOrgSMTPSettingsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Host           string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port           int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username       string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	PasswordSecret string                 `protobuf:"bytes,4,opt,name=password_secret,json=passwordSecret,proto3" json:"password_secret,omitempty"`
+	FromAddress    string                 `protobuf:"bytes,5,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateOrgSMTPSettingsRequest) Reset() {
+	*x = UpdateOrgSMTPSettingsRequest{}
+	mi := &file_notification_notification_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgSMTPSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgSMTPSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateOrgSMTPSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgSMTPSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrgSMTPSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateOrgSMTPSettingsRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *UpdateOrgSMTPSettingsRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *UpdateOrgSMTPSettingsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UpdateOrgSMTPSettingsRequest) GetPasswordSecret() string {
+	if x != nil {
+		return x.PasswordSecret
+	}
+	return ""
+}
+
+func (x *UpdateOrgSMTPSettingsRequest) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+type UpdateOrgSMTPSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *OrgSMTPSettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrgSMTPSettingsResponse) Reset() {
+	*x = UpdateOrgSMTPSettingsResponse{}
+	mi := &file_notification_notification_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgSMTPSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgSMTPSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateOrgSMTPSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgSMTPSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrgSMTPSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOrgSMTPSettingsResponse) GetSettings() *OrgSMTPSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type DeleteOrgSMTPSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgSMTPSettingsRequest) Reset() {
+	*x = DeleteOrgSMTPSettingsRequest{}
+	mi := &file_notification_notification_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgSMTPSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgSMTPSettingsRequest) ProtoMessage() {}
+
+func (x *DeleteOrgSMTPSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgSMTPSettingsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrgSMTPSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{10}
+}
+
+type DeleteOrgSMTPSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgSMTPSettingsResponse) Reset() {
+	*x = DeleteOrgSMTPSettingsResponse{}
+	mi := &file_notification_notification_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgSMTPSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgSMTPSettingsResponse) ProtoMessage() {}
+
+func (x *DeleteOrgSMTPSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgSMTPSettingsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrgSMTPSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{11}
+}
+
+type SendTestEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTestEmailRequest) Reset() {
+	*x = SendTestEmailRequest{}
+	mi := &file_notification_notification_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTestEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTestEmailRequest) ProtoMessage() {}
+
+func (x *SendTestEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTestEmailRequest.ProtoReflect.Descriptor instead.
+func (*SendTestEmailRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{12}
+}
+
+type SendTestEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	To            string                 `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"` // the caller's email address the test was sent to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTestEmailResponse) Reset() {
+	*x = SendTestEmailResponse{}
+	mi := &file_notification_notification_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTestEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTestEmailResponse) ProtoMessage() {}
+
+func (x *SendTestEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTestEmailResponse.ProtoReflect.Descriptor instead.
+func (*SendTestEmailResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SendTestEmailResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// NotificationTemplate is an org's text for one kind of notification in one locale. subject and body may use the kind's
+// {{variable}} placeholders.
+type NotificationTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`       // "login_alert" or "otp_sms"
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`   // e.g. "de" or "pt-BR"; empty for users whose locale has no template
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"` // email subject; empty for SMS-only kinds
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,5,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationTemplate) Reset() {
+	*x = NotificationTemplate{}
+	mi := &file_notification_notification_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationTemplate) ProtoMessage() {}
+
+func (x *NotificationTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationTemplate.ProtoReflect.Descriptor instead.
+func (*NotificationTemplate) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NotificationTemplate) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// NotificationTemplateKind describes a kind of notification orgs can override.
+type NotificationTemplateKind struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Kind              string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Email             bool                   `protobuf:"varint,2,opt,name=email,proto3" json:"email,omitempty"` // sent by email, so templates need a subject
+	Variables         []string               `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
+	RequiredVariables []string               `protobuf:"bytes,4,rep,name=required_variables,json=requiredVariables,proto3" json:"required_variables,omitempty"` // every template body must use these
+	BuiltinSubject    string                 `protobuf:"bytes,5,opt,name=builtin_subject,json=builtinSubject,proto3" json:"builtin_subject,omitempty"`
+	BuiltinBody       string                 `protobuf:"bytes,6,opt,name=builtin_body,json=builtinBody,proto3" json:"builtin_body,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *NotificationTemplateKind) Reset() {
+	*x = NotificationTemplateKind{}
+	mi := &file_notification_notification_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationTemplateKind) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationTemplateKind) ProtoMessage() {}
+
+func (x *NotificationTemplateKind) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationTemplateKind.ProtoReflect.Descriptor instead.
+func (*NotificationTemplateKind) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NotificationTemplateKind) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *NotificationTemplateKind) GetEmail() bool {
+	if x != nil {
+		return x.Email
+	}
+	return false
+}
+
+func (x *NotificationTemplateKind) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *NotificationTemplateKind) GetRequiredVariables() []string {
+	if x != nil {
+		return x.RequiredVariables
+	}
+	return nil
+}
+
+func (x *NotificationTemplateKind) GetBuiltinSubject() string {
+	if x != nil {
+		return x.BuiltinSubject
+	}
+	return ""
+}
+
+func (x *NotificationTemplateKind) GetBuiltinBody() string {
+	if x != nil {
+		return x.BuiltinBody
+	}
+	return ""
+}
+
+type ListNotificationTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationTemplatesRequest) Reset() {
+	*x = ListNotificationTemplatesRequest{}
+	mi := &file_notification_notification_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationTemplatesRequest) ProtoMessage() {}
+
+func (x *ListNotificationTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{16}
+}
+
+type ListNotificationTemplatesResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Templates     []*NotificationTemplate     `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	Kinds         []*NotificationTemplateKind `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationTemplatesResponse) Reset() {
+	*x = ListNotificationTemplatesResponse{}
+	mi := &file_notification_notification_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationTemplatesResponse) ProtoMessage() {}
+
+func (x *ListNotificationTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListNotificationTemplatesResponse) GetTemplates() []*NotificationTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+func (x *ListNotificationTemplatesResponse) GetKinds() []*NotificationTemplateKind {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+// UpdateNotificationTemplateRequest creates or replaces the org's template for kind in locale.
+type UpdateNotificationTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationTemplateRequest) Reset() {
+	*x = UpdateNotificationTemplateRequest{}
+	mi := &file_notification_notification_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateNotificationTemplateRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *UpdateNotificationTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *UpdateNotificationTemplateRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *UpdateNotificationTemplateRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type UpdateNotificationTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *NotificationTemplate  `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationTemplateResponse) Reset() {
+	*x = UpdateNotificationTemplateResponse{}
+	mi := &file_notification_notification_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationTemplateResponse) ProtoMessage() {}
+
+func (x *UpdateNotificationTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationTemplateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateNotificationTemplateResponse) GetTemplate() *NotificationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type DeleteNotificationTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationTemplateRequest) Reset() {
+	*x = DeleteNotificationTemplateRequest{}
+	mi := &file_notification_notification_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteNotificationTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteNotificationTemplateRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DeleteNotificationTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type DeleteNotificationTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationTemplateResponse) Reset() {
+	*x = DeleteNotificationTemplateResponse{}
+	mi := &file_notification_notification_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteNotificationTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{21}
+}
+
+// PreviewNotificationTemplateRequest renders kind with sample values: the given subject and body when body is set
+// (a draft, validated as UpdateNotificationTemplate would), otherwise what a user with locale gets.
+type PreviewNotificationTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewNotificationTemplateRequest) Reset() {
+	*x = PreviewNotificationTemplateRequest{}
+	mi := &file_notification_notification_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewNotificationTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewNotificationTemplateRequest) ProtoMessage() {}
+
+func (x *PreviewNotificationTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewNotificationTemplateRequest.ProtoReflect.Descriptor instead.
+func (*PreviewNotificationTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PreviewNotificationTemplateRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PreviewNotificationTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PreviewNotificationTemplateRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PreviewNotificationTemplateRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type PreviewNotificationTemplateResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Subject        string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Body           string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Builtin        bool                   `protobuf:"varint,3,opt,name=builtin,proto3" json:"builtin,omitempty"`                                    // the built-in text was rendered (the org has no template for the locale)
+	TemplateLocale string                 `protobuf:"bytes,4,opt,name=template_locale,json=templateLocale,proto3" json:"template_locale,omitempty"` // locale of the rendered template; empty for the org default or built-in text
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PreviewNotificationTemplateResponse) Reset() {
+	*x = PreviewNotificationTemplateResponse{}
+	mi := &file_notification_notification_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewNotificationTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewNotificationTemplateResponse) ProtoMessage() {}
+
+func (x *PreviewNotificationTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewNotificationTemplateResponse.ProtoReflect.Descriptor instead.
+func (*PreviewNotificationTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PreviewNotificationTemplateResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PreviewNotificationTemplateResponse) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *PreviewNotificationTemplateResponse) GetBuiltin() bool {
+	if x != nil {
+		return x.Builtin
+	}
+	return false
+}
+
+func (x *PreviewNotificationTemplateResponse) GetTemplateLocale() string {
+	if x != nil {
+		return x.TemplateLocale
+	}
+	return ""
+}
+
+var File_notification_notification_proto protoreflect.FileDescriptor
+
+const file_notification_notification_proto_rawDesc = "" +
+	"\n" +
+	"\x1fnotification/notification.proto\x12\x14ztcp.notification.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa3\x01\n" +
+	"\x17NotificationPreferences\x120\n" +
+	"\x14login_alerts_enabled\x18\x01 \x01(\bR\x12loginAlertsEnabled\x12>\n" +
+	"\x1clogin_alerts_enforced_by_org\x18\x02 \x01(\bR\x18loginAlertsEnforcedByOrg\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"#\n" +
+	"!GetNotificationPreferencesRequest\"u\n" +
+	"\"GetNotificationPreferencesResponse\x12O\n" +
+	"\vpreferences\x18\x01 \x01(\v2-.ztcp.notification.v1.NotificationPreferencesR\vpreferences\"\x80\x01\n" +
+	"$UpdateNotificationPreferencesRequest\x120\n" +
+	"\x14login_alerts_enabled\x18\x01 \x01(\bR\x12loginAlertsEnabled\x12\x1b\n" +
+	"\x06locale\x18\x02 \x01(\tH\x00R\x06locale\x88\x01\x01B\t\n" +
+	"\a_locale\"x\n" +
+	"%UpdateNotificationPreferencesResponse\x12O\n" +
+	"\vpreferences\x18\x01 \x01(\v2-.ztcp.notification.v1.NotificationPreferencesR\vpreferences\"\xfb\x01\n" +
+	"\x0fOrgSMTPSettings\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12'\n" +
+	"\x0fpassword_secret\x18\x04 \x01(\tR\x0epasswordSecret\x12!\n" +
+	"\ffrom_address\x18\x05 \x01(\tR\vfromAddress\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x06 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x1b\n" +
+	"\x19GetOrgSMTPSettingsRequest\"_\n" +
+	"\x1aGetOrgSMTPSettingsResponse\x12A\n" +
+	"\bsettings\x18\x01 \x01(\v2%.ztcp.notification.v1.OrgSMTPSettingsR\bsettings\"\xae\x01\n" +
+	"\x1cUpdateOrgSMTPSettingsRequest\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12'\n" +
+	"\x0fpassword_secret\x18\x04 \x01(\tR\x0epasswordSecret\x12!\n" +
+	"\ffrom_address\x18\x05 \x01(\tR\vfromAddress\"b\n" +
+	"\x1dUpdateOrgSMTPSettingsResponse\x12A\n" +
+	"\bsettings\x18\x01 \x01(\v2%.ztcp.notification.v1.OrgSMTPSettingsR\bsettings\"\x1e\n" +
+	"\x1cDeleteOrgSMTPSettingsRequest\"\x1f\n" +
+	"\x1dDeleteOrgSMTPSettingsResponse\"\x16\n" +
+	"\x14SendTestEmailRequest\"'\n" +
+	"\x15SendTestEmailResponse\x12\x0e\n" +
+	"\x02to\x18\x01 \x01(\tR\x02to\"\xca\x01\n" +
+	"\x14NotificationTemplate\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x05 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdd\x01\n" +
+	"\x18NotificationTemplateKind\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\bR\x05email\x12\x1c\n" +
+	"\tvariables\x18\x03 \x03(\tR\tvariables\x12-\n" +
+	"\x12required_variables\x18\x04 \x03(\tR\x11requiredVariables\x12'\n" +
+	"\x0fbuiltin_subject\x18\x05 \x01(\tR\x0ebuiltinSubject\x12!\n" +
+	"\fbuiltin_body\x18\x06 \x01(\tR\vbuiltinBody\"\"\n" +
+	" ListNotificationTemplatesRequest\"\xb3\x01\n" +
+	"!ListNotificationTemplatesResponse\x12H\n" +
+	"\ttemplates\x18\x01 \x03(\v2*.ztcp.notification.v1.NotificationTemplateR\ttemplates\x12D\n" +
+	"\x05kinds\x18\x02 \x03(\v2..ztcp.notification.v1.NotificationTemplateKindR\x05kinds\"}\n" +
+	"!UpdateNotificationTemplateRequest\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\"l\n" +
+	"\"UpdateNotificationTemplateResponse\x12F\n" +
+	"\btemplate\x18\x01 \x01(\v2*.ztcp.notification.v1.NotificationTemplateR\btemplate\"O\n" +
+	"!DeleteNotificationTemplateRequest\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"$\n" +
+	"\"DeleteNotificationTemplateResponse\"~\n" +
+	"\"PreviewNotificationTemplateRequest\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\"\x96\x01\n" +
+	"#PreviewNotificationTemplateResponse\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12\x18\n" +
+	"\abuiltin\x18\x03 \x01(\bR\abuiltin\x12'\n" +
+	"\x0ftemplate_locale\x18\x04 \x01(\tR\x0etemplateLocale2\x87\v\n" +
+	"\x13NotificationService\x12\x94\x01\n" +
+	"\x1aGetNotificationPreferences\x127.ztcp.notification.v1.GetNotificationPreferencesRequest\x1a8.ztcp.notification.v1.GetNotificationPreferencesResponse\"\x03\x90\x02\x01\x12\x98\x01\n" +
+	"\x1dUpdateNotificationPreferences\x12:.ztcp.notification.v1.UpdateNotificationPreferencesRequest\x1a;.ztcp.notification.v1.UpdateNotificationPreferencesResponse\x12|\n" +
+	"\x12GetOrgSMTPSettings\x12/.ztcp.notification.v1.GetOrgSMTPSettingsRequest\x1a0.ztcp.notification.v1.GetOrgSMTPSettingsResponse\"\x03\x90\x02\x01\x12\x80\x01\n" +
+	"\x15UpdateOrgSMTPSettings\x122.ztcp.notification.v1.UpdateOrgSMTPSettingsRequest\x1a3.ztcp.notification.v1.UpdateOrgSMTPSettingsResponse\x12\x80\x01\n" +
+	"\x15DeleteOrgSMTPSettings\x122.ztcp.notification.v1.DeleteOrgSMTPSettingsRequest\x1a3.ztcp.notification.v1.DeleteOrgSMTPSettingsResponse\x12h\n" +
+	"\rSendTestEmail\x12*.ztcp.notification.v1.SendTestEmailRequest\x1a+.ztcp.notification.v1.SendTestEmailResponse\x12\x91\x01\n" +
+	"\x19ListNotificationTemplates\x126.ztcp.notification.v1.ListNotificationTemplatesRequest\x1a7.ztcp.notification.v1.ListNotificationTemplatesResponse\"\x03\x90\x02\x01\x12\x8f\x01\n" +
+	"\x1aUpdateNotificationTemplate\x127.ztcp.notification.v1.UpdateNotificationTemplateRequest\x1a8.ztcp.notification.v1.UpdateNotificationTemplateResponse\x12\x8f\x01\n" +
+	"\x1aDeleteNotificationTemplate\x127.ztcp.notification.v1.DeleteNotificationTemplateRequest\x1a8.ztcp.notification.v1.DeleteNotificationTemplateResponse\x12\x97\x01\n" +
+	"\x1bPreviewNotificationTemplate\x128.ztcp.notification.v1.PreviewNotificationTemplateRequest\x1a9.ztcp.notification.v1.PreviewNotificationTemplateResponse\"\x03\x90\x02\x01BOZMzero-trust-control-plane/backend/api/generated/notification/v1;notificationv1b\x06proto3"
+
+var (
+	file_notification_notification_proto_rawDescOnce sync.Once
+	file_notification_notification_proto_rawDescData []byte
+)
+
+func file_notification_notification_proto_rawDescGZIP() []byte {
+	file_notification_notification_proto_rawDescOnce.Do(func() {
+		file_notification_notification_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_notification_notification_proto_rawDesc), len(file_notification_notification_proto_rawDesc)))
+	})
+	return file_notification_notification_proto_rawDescData
+}
+
+var file_notification_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_notification_notification_proto_goTypes = []any{
+	(*NotificationPreferences)(nil),               // 0: ztcp.notification.v1.NotificationPreferences
+	(*GetNotificationPreferencesRequest)(nil),     // 1: ztcp.notification.v1.GetNotificationPreferencesRequest
+	(*GetNotificationPreferencesResponse)(nil),    // 2: ztcp.notification.v1.GetNotificationPreferencesResponse
+	(*UpdateNotificationPreferencesRequest)(nil),  // 3: ztcp.notification.v1.UpdateNotificationPreferencesRequest
+	(*UpdateNotificationPreferencesResponse)(nil), // 4: ztcp.notification.v1.UpdateNotificationPreferencesResponse
+	(*OrgSMTPSettings)(nil),                       // 5: ztcp.notification.v1.OrgSMTPSettings
+	(*GetOrgSMTPSettingsRequest)(nil),             // 6: ztcp.notification.v1.GetOrgSMTPSettingsRequest
+	(*GetOrgSMTPSettingsResponse)(nil),            // 7: ztcp.notification.v1.GetOrgSMTPSettingsResponse
+	(*UpdateOrgSMTPSettingsRequest)(nil),          // 8: ztcp.notification.v1.UpdateOrgSMTPSettingsRequest
+	(*UpdateOrgSMTPSettingsResponse)(nil),         // 9: ztcp.notification.v1.UpdateOrgSMTPSettingsResponse
+	(*DeleteOrgSMTPSettingsRequest)(nil),          // 10: ztcp.notification.v1.DeleteOrgSMTPSettingsRequest
+	(*DeleteOrgSMTPSettingsResponse)(nil),         // 11: ztcp.notification.v1.DeleteOrgSMTPSettingsResponse
+	(*SendTestEmailRequest)(nil),                  // 12: ztcp.notification.v1.SendTestEmailRequest
+	(*SendTestEmailResponse)(nil),                 // 13: ztcp.notification.v1.SendTestEmailResponse
+	(*NotificationTemplate)(nil),                  // 14: ztcp.notification.v1.NotificationTemplate
+	(*NotificationTemplateKind)(nil),              // 15: ztcp.notification.v1.NotificationTemplateKind
+	(*ListNotificationTemplatesRequest)(nil),      // 16: ztcp.notification.v1.ListNotificationTemplatesRequest
+	(*ListNotificationTemplatesResponse)(nil),     // 17: ztcp.notification.v1.ListNotificationTemplatesResponse
+	(*UpdateNotificationTemplateRequest)(nil),     // 18: ztcp.notification.v1.UpdateNotificationTemplateRequest
+	(*UpdateNotificationTemplateResponse)(nil),    // 19: ztcp.notification.v1.UpdateNotificationTemplateResponse
+	(*DeleteNotificationTemplateRequest)(nil),     // 20: ztcp.notification.v1.DeleteNotificationTemplateRequest
+	(*DeleteNotificationTemplateResponse)(nil),    // 21: ztcp.notification.v1.DeleteNotificationTemplateResponse
+	(*PreviewNotificationTemplateRequest)(nil),    // 22: ztcp.notification.v1.PreviewNotificationTemplateRequest
+	(*PreviewNotificationTemplateResponse)(nil),   // 23: ztcp.notification.v1.PreviewNotificationTemplateResponse
+	(*timestamppb.Timestamp)(nil),                 // 24: google.protobuf.Timestamp
+}
+var file_notification_notification_proto_depIdxs = []int32{
+	0,  // 0: ztcp.notification.v1.GetNotificationPreferencesResponse.preferences:type_name -> ztcp.notification.v1.NotificationPreferences
+	0,  // 1: ztcp.notification.v1.UpdateNotificationPreferencesResponse.preferences:type_name -> ztcp.notification.v1.NotificationPreferences
+	24, // 2: ztcp.notification.v1.OrgSMTPSettings.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 3: ztcp.notification.v1.GetOrgSMTPSettingsResponse.settings:type_name -> ztcp.notification.v1.OrgSMTPSettings
+	5,  // 4: ztcp.notification.v1.UpdateOrgSMTPSettingsResponse.settings:type_name -> ztcp.notification.v1.OrgSMTPSettings
+	24, // 5: ztcp.notification.v1.NotificationTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 6: ztcp.notification.v1.ListNotificationTemplatesResponse.templates:type_name -> ztcp.notification.v1.NotificationTemplate
+	15, // 7: ztcp.notification.v1.ListNotificationTemplatesResponse.kinds:type_name -> ztcp.notification.v1.NotificationTemplateKind
+	14, // 8: ztcp.notification.v1.UpdateNotificationTemplateResponse.template:type_name -> ztcp.notification.v1.NotificationTemplate
+	1,  // 9: ztcp.notification.v1.NotificationService.GetNotificationPreferences:input_type -> ztcp.notification.v1.GetNotificationPreferencesRequest
+	3,  // 10: ztcp.notification.v1.NotificationService.UpdateNotificationPreferences:input_type -> ztcp.notification.v1.UpdateNotificationPreferencesRequest
+	6,  // 11: ztcp.notification.v1.NotificationService.GetOrgSMTPSettings:input_type -> ztcp.notification.v1.GetOrgSMTPSettingsRequest
+	8,  // 12: ztcp.notification.v1.NotificationService.UpdateOrgSMTPSettings:input_type -> ztcp.notification.v1.UpdateOrgSMTPSettingsRequest
+	10, // 13: ztcp.notification.v1.NotificationService.DeleteOrgSMTPSettings:input_type -> ztcp.notification.v1.DeleteOrgSMTPSettingsRequest
+	12, // 14: ztcp.notification.v1.NotificationService.SendTestEmail:input_type -> ztcp.notification.v1.SendTestEmailRequest
+	16, // 15: ztcp.notification.v1.NotificationService.ListNotificationTemplates:input_type -> ztcp.notification.v1.ListNotificationTemplatesRequest
+	18, // 16: ztcp.notification.v1.NotificationService.UpdateNotificationTemplate:input_type -> ztcp.notification.v1.UpdateNotificationTemplateRequest
+	20, // 17: ztcp.notification.v1.NotificationService.DeleteNotificationTemplate:input_type -> ztcp.notification.v1.DeleteNotificationTemplateRequest
+	22, // 18: ztcp.notification.v1.NotificationService.PreviewNotificationTemplate:input_type -> ztcp.notification.v1.PreviewNotificationTemplateRequest
+	2,  // 19: ztcp.notification.v1.NotificationService.GetNotificationPreferences:output_type -> ztcp.notification.v1.GetNotificationPreferencesResponse
+	4,  // 20: ztcp.notification.v1.NotificationService.UpdateNotificationPreferences:output_type -> ztcp.notification.v1.UpdateNotificationPreferencesResponse
+	7,  // 21: ztcp.notification.v1.NotificationService.GetOrgSMTPSettings:output_type -> ztcp.notification.v1.GetOrgSMTPSettingsResponse
+	9,  // 22: ztcp.notification.v1.NotificationService.UpdateOrgSMTPSettings:output_type -> ztcp.notification.v1.UpdateOrgSMTPSettingsResponse
+	11, // 23: ztcp.notification.v1.NotificationService.DeleteOrgSMTPSettings:output_type -> ztcp.notification.v1.DeleteOrgSMTPSettingsResponse
+	13, // 24: ztcp.notification.v1.NotificationService.SendTestEmail:output_type -> ztcp.notification.v1.SendTestEmailResponse
+	17, // 25: ztcp.notification.v1.NotificationService.ListNotificationTemplates:output_type -> ztcp.notification.v1.ListNotificationTemplatesResponse
+	19, // 26: ztcp.notification.v1.NotificationService.UpdateNotificationTemplate:output_type -> ztcp.notification.v1.UpdateNotificationTemplateResponse
+	21, // 27: ztcp.notification.v1.NotificationService.DeleteNotificationTemplate:output_type -> ztcp.notification.v1.DeleteNotificationTemplateResponse
+	23, // 28: ztcp.notification.v1.NotificationService.PreviewNotificationTemplate:output_type -> ztcp.notification.v1.PreviewNotificationTemplateResponse
+	19, // [19:29] is the sub-list for method output_type
+	9,  // [9:19] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_notification_notification_proto_init() }
+func file_notification_notification_proto_init() {
+	if File_notification_notification_proto != nil {
+		return
+	}
+	file_notification_notification_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_notification_proto_rawDesc), len(file_notification_notification_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_notification_notification_proto_goTypes,
+		DependencyIndexes: file_notification_notification_proto_depIdxs,
+		MessageInfos:      file_notification_notification_proto_msgTypes,
+	}.Build()
+	File_notification_notification_proto = out.File
+	file_notification_notification_proto_goTypes = nil
+	file_notification_notification_proto_depIdxs = nil
+}
@@ -13,7 +13,10 @@ import (
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
-	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	v13 "zero-trust-control-plane/backend/api/generated/common/v1"
+	v1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+	v12 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	v11 "zero-trust-control-plane/backend/api/generated/policy/v1"
 )
 
 const (
@@ -73,13 +76,73 @@ func (OrganizationStatus) EnumDescriptor() ([]byte, []int) {
 	return file_organization_organization_proto_rawDescGZIP(), []int{0}
 }
 
+// OrgDomainStatus is the verification status of a claimed email domain.
+type OrgDomainStatus int32
+
+const (
+	OrgDomainStatus_ORG_DOMAIN_STATUS_UNSPECIFIED OrgDomainStatus = 0
+	OrgDomainStatus_ORG_DOMAIN_STATUS_PENDING     OrgDomainStatus = 1
+	OrgDomainStatus_ORG_DOMAIN_STATUS_VERIFIED    OrgDomainStatus = 2
+)
+
+// Enum value maps for OrgDomainStatus.
+var (
+	OrgDomainStatus_name = map[int32]string{
+		0: "ORG_DOMAIN_STATUS_UNSPECIFIED",
+		1: "ORG_DOMAIN_STATUS_PENDING",
+		2: "ORG_DOMAIN_STATUS_VERIFIED",
+	}
+	OrgDomainStatus_value = map[string]int32{
+		"ORG_DOMAIN_STATUS_UNSPECIFIED": 0,
+		"ORG_DOMAIN_STATUS_PENDING":     1,
+		"ORG_DOMAIN_STATUS_VERIFIED":    2,
+	}
+)
+
+func (x OrgDomainStatus) Enum() *OrgDomainStatus {
+	p := new(OrgDomainStatus)
+	*p = x
+	return p
+}
+
+func (x OrgDomainStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrgDomainStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_organization_organization_proto_enumTypes[1].Descriptor()
+}
+
+func (OrgDomainStatus) Type() protoreflect.EnumType {
+	return &file_organization_organization_proto_enumTypes[1]
+}
+
+func (x OrgDomainStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrgDomainStatus.Descriptor instead.
+func (OrgDomainStatus) EnumDescriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{1}
+}
+
 // Organization represents an organization/tenant.
 type Organization struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Status        OrganizationStatus     `protobuf:"varint,3,opt,name=status,proto3,enum=ztcp.organization.v1.OrganizationStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status    OrganizationStatus     `protobuf:"varint,3,opt,name=status,proto3,enum=ztcp.organization.v1.OrganizationStatus" json:"status,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// data_region is the region whose database stores the org's activity data (audit logs, policy violations,
+	// security events, telemetry, analytics and usage); empty means the primary database.
+	DataRegion string `protobuf:"bytes,5,opt,name=data_region,json=dataRegion,proto3" json:"data_region,omitempty"`
+	// max_users and max_devices are the org's quotas: how many members and active (not revoked) devices it may have.
+	// 0 is unlimited. Set by platform admins with UpdateOrganization.
+	MaxUsers   int32 `protobuf:"varint,6,opt,name=max_users,json=maxUsers,proto3" json:"max_users,omitempty"`
+	MaxDevices int32 `protobuf:"varint,7,opt,name=max_devices,json=maxDevices,proto3" json:"max_devices,omitempty"`
+	// plan is the org's billing plan (free, team or enterprise), set by platform admins with
+	// AdminService.SetOrgBillingPlan. It decides which gated features the org may use.
+	Plan          string `protobuf:"bytes,8,opt,name=plan,proto3" json:"plan,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -142,11 +205,42 @@ func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Organization) GetDataRegion() string {
+	if x != nil {
+		return x.DataRegion
+	}
+	return ""
+}
+
+func (x *Organization) GetMaxUsers() int32 {
+	if x != nil {
+		return x.MaxUsers
+	}
+	return 0
+}
+
+func (x *Organization) GetMaxDevices() int32 {
+	if x != nil {
+		return x.MaxDevices
+	}
+	return 0
+}
+
+func (x *Organization) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
 // CreateOrganizationRequest creates a new organization.
 type CreateOrganizationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// data_region pins the org's data to a region configured in DATA_REGION_DSNS (e.g. "eu"); empty uses the primary
+	// database. Cannot be changed later.
+	DataRegion    string `protobuf:"bytes,3,opt,name=data_region,json=dataRegion,proto3" json:"data_region,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -195,6 +289,13 @@ func (x *CreateOrganizationRequest) GetUserId() string {
 	return ""
 }
 
+func (x *CreateOrganizationRequest) GetDataRegion() string {
+	if x != nil {
+		return x.DataRegion
+	}
+	return ""
+}
+
 // CreateOrganizationResponse returns the created organization.
 type CreateOrganizationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -240,28 +341,32 @@ func (x *CreateOrganizationResponse) GetOrganization() *Organization {
 	return nil
 }
 
-// GetOrganizationRequest identifies the organization by ID.
-type GetOrganizationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+// SetupOrganizationRequest creates an organization with its owner and initial settings from a template.
+type SetupOrganizationRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the owner
+	// data_region as in CreateOrganizationRequest.
+	DataRegion    string `protobuf:"bytes,3,opt,name=data_region,json=dataRegion,proto3" json:"data_region,omitempty"`
+	Template      string `protobuf:"bytes,4,opt,name=template,proto3" json:"template,omitempty"` // "default" (when empty) or a policy preset name (PolicyPresetService.ListPresets)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrganizationRequest) Reset() {
-	*x = GetOrganizationRequest{}
+func (x *SetupOrganizationRequest) Reset() {
+	*x = SetupOrganizationRequest{}
 	mi := &file_organization_organization_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrganizationRequest) String() string {
+func (x *SetupOrganizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrganizationRequest) ProtoMessage() {}
+func (*SetupOrganizationRequest) ProtoMessage() {}
 
-func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+func (x *SetupOrganizationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_organization_organization_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -273,40 +378,66 @@ func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
-func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetupOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*SetupOrganizationRequest) Descriptor() ([]byte, []int) {
 	return file_organization_organization_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetOrganizationRequest) GetOrgId() string {
+func (x *SetupOrganizationRequest) GetName() string {
 	if x != nil {
-		return x.OrgId
+		return x.Name
 	}
 	return ""
 }
 
-// GetOrganizationResponse returns the organization.
-type GetOrganizationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SetupOrganizationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *GetOrganizationResponse) Reset() {
-	*x = GetOrganizationResponse{}
+func (x *SetupOrganizationRequest) GetDataRegion() string {
+	if x != nil {
+		return x.DataRegion
+	}
+	return ""
+}
+
+func (x *SetupOrganizationRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+// MFASettings are the org's MFA settings used by MFA evaluation, mirrored from the policy config's auth_mfa and
+// device_trust.
+type MFASettings struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	MfaRequiredForNewDevice bool                   `protobuf:"varint,1,opt,name=mfa_required_for_new_device,json=mfaRequiredForNewDevice,proto3" json:"mfa_required_for_new_device,omitempty"`
+	MfaRequiredForUntrusted bool                   `protobuf:"varint,2,opt,name=mfa_required_for_untrusted,json=mfaRequiredForUntrusted,proto3" json:"mfa_required_for_untrusted,omitempty"`
+	MfaRequiredAlways       bool                   `protobuf:"varint,3,opt,name=mfa_required_always,json=mfaRequiredAlways,proto3" json:"mfa_required_always,omitempty"`
+	RegisterTrustAfterMfa   bool                   `protobuf:"varint,4,opt,name=register_trust_after_mfa,json=registerTrustAfterMfa,proto3" json:"register_trust_after_mfa,omitempty"`
+	TrustTtlDays            int32                  `protobuf:"varint,5,opt,name=trust_ttl_days,json=trustTtlDays,proto3" json:"trust_ttl_days,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *MFASettings) Reset() {
+	*x = MFASettings{}
 	mi := &file_organization_organization_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrganizationResponse) String() string {
+func (x *MFASettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrganizationResponse) ProtoMessage() {}
+func (*MFASettings) ProtoMessage() {}
 
-func (x *GetOrganizationResponse) ProtoReflect() protoreflect.Message {
+func (x *MFASettings) ProtoReflect() protoreflect.Message {
 	mi := &file_organization_organization_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -318,40 +449,74 @@ func (x *GetOrganizationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrganizationResponse.ProtoReflect.Descriptor instead.
-func (*GetOrganizationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MFASettings.ProtoReflect.Descriptor instead.
+func (*MFASettings) Descriptor() ([]byte, []int) {
 	return file_organization_organization_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetOrganizationResponse) GetOrganization() *Organization {
+func (x *MFASettings) GetMfaRequiredForNewDevice() bool {
 	if x != nil {
-		return x.Organization
+		return x.MfaRequiredForNewDevice
 	}
-	return nil
+	return false
 }
 
-// ListOrganizationsRequest lists organizations with pagination.
-type ListOrganizationsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pagination    *v1.Pagination         `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MFASettings) GetMfaRequiredForUntrusted() bool {
+	if x != nil {
+		return x.MfaRequiredForUntrusted
+	}
+	return false
 }
 
-func (x *ListOrganizationsRequest) Reset() {
-	*x = ListOrganizationsRequest{}
+func (x *MFASettings) GetMfaRequiredAlways() bool {
+	if x != nil {
+		return x.MfaRequiredAlways
+	}
+	return false
+}
+
+func (x *MFASettings) GetRegisterTrustAfterMfa() bool {
+	if x != nil {
+		return x.RegisterTrustAfterMfa
+	}
+	return false
+}
+
+func (x *MFASettings) GetTrustTtlDays() int32 {
+	if x != nil {
+		return x.TrustTtlDays
+	}
+	return 0
+}
+
+// SetupOrganizationResponse returns everything SetupOrganization created.
+type SetupOrganizationResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Organization        *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	Owner               *v1.Member             `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	MfaSettings         *MFASettings           `protobuf:"bytes,3,opt,name=mfa_settings,json=mfaSettings,proto3" json:"mfa_settings,omitempty"`
+	Policy              *v11.Policy            `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`                                 // unset when the template has no policy
+	PolicyConfig        *v12.OrgPolicyConfig   `protobuf:"bytes,5,opt,name=policy_config,json=policyConfig,proto3" json:"policy_config,omitempty"` // merged with defaults
+	PolicyConfigVersion int64                  `protobuf:"varint,6,opt,name=policy_config_version,json=policyConfigVersion,proto3" json:"policy_config_version,omitempty"`
+	Template            string                 `protobuf:"bytes,7,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SetupOrganizationResponse) Reset() {
+	*x = SetupOrganizationResponse{}
 	mi := &file_organization_organization_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrganizationsRequest) String() string {
+func (x *SetupOrganizationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrganizationsRequest) ProtoMessage() {}
+func (*SetupOrganizationResponse) ProtoMessage() {}
 
-func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+func (x *SetupOrganizationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_organization_organization_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -363,41 +528,82 @@ func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
-func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetupOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*SetupOrganizationResponse) Descriptor() ([]byte, []int) {
 	return file_organization_organization_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListOrganizationsRequest) GetPagination() *v1.Pagination {
+func (x *SetupOrganizationResponse) GetOrganization() *Organization {
 	if x != nil {
-		return x.Pagination
+		return x.Organization
 	}
 	return nil
 }
 
-// ListOrganizationsResponse returns a page of organizations.
-type ListOrganizationsResponse struct {
+func (x *SetupOrganizationResponse) GetOwner() *v1.Member {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *SetupOrganizationResponse) GetMfaSettings() *MFASettings {
+	if x != nil {
+		return x.MfaSettings
+	}
+	return nil
+}
+
+func (x *SetupOrganizationResponse) GetPolicy() *v11.Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SetupOrganizationResponse) GetPolicyConfig() *v12.OrgPolicyConfig {
+	if x != nil {
+		return x.PolicyConfig
+	}
+	return nil
+}
+
+func (x *SetupOrganizationResponse) GetPolicyConfigVersion() int64 {
+	if x != nil {
+		return x.PolicyConfigVersion
+	}
+	return 0
+}
+
+func (x *SetupOrganizationResponse) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+// GetOrganizationRequest identifies the organization by ID.
+type GetOrganizationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
-	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrganizationsResponse) Reset() {
-	*x = ListOrganizationsResponse{}
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
 	mi := &file_organization_organization_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrganizationsResponse) String() string {
+func (x *GetOrganizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrganizationsResponse) ProtoMessage() {}
+func (*GetOrganizationRequest) ProtoMessage() {}
 
-func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_organization_organization_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,47 +615,40 @@ func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
-func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
 	return file_organization_organization_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
-	if x != nil {
-		return x.Organizations
-	}
-	return nil
-}
-
-func (x *ListOrganizationsResponse) GetPagination() *v1.PaginationResult {
+func (x *GetOrganizationRequest) GetOrgId() string {
 	if x != nil {
-		return x.Pagination
+		return x.OrgId
 	}
-	return nil
+	return ""
 }
 
-// SuspendOrganizationRequest identifies the organization to suspend.
-type SuspendOrganizationRequest struct {
+// GetOrganizationResponse returns the organization.
+type GetOrganizationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuspendOrganizationRequest) Reset() {
-	*x = SuspendOrganizationRequest{}
+func (x *GetOrganizationResponse) Reset() {
+	*x = GetOrganizationResponse{}
 	mi := &file_organization_organization_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuspendOrganizationRequest) String() string {
+func (x *GetOrganizationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuspendOrganizationRequest) ProtoMessage() {}
+func (*GetOrganizationResponse) ProtoMessage() {}
 
-func (x *SuspendOrganizationRequest) ProtoReflect() protoreflect.Message {
+func (x *GetOrganizationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_organization_organization_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -461,39 +660,45 @@ func (x *SuspendOrganizationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuspendOrganizationRequest.ProtoReflect.Descriptor instead.
-func (*SuspendOrganizationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*GetOrganizationResponse) Descriptor() ([]byte, []int) {
 	return file_organization_organization_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SuspendOrganizationRequest) GetOrgId() string {
+func (x *GetOrganizationResponse) GetOrganization() *Organization {
 	if x != nil {
-		return x.OrgId
+		return x.Organization
 	}
-	return ""
+	return nil
 }
 
-// SuspendOrganizationResponse is empty on success.
-type SuspendOrganizationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// UpdateOrganizationRequest changes an organization's name and quotas. Only the fields that are set change.
+type UpdateOrganizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	OrgId string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	// name, when not empty, renames the organization.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// max_users and max_devices, when set, replace the quotas (0 = unlimited). Platform admins only.
+	MaxUsers      *int32 `protobuf:"varint,3,opt,name=max_users,json=maxUsers,proto3,oneof" json:"max_users,omitempty"`
+	MaxDevices    *int32 `protobuf:"varint,4,opt,name=max_devices,json=maxDevices,proto3,oneof" json:"max_devices,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuspendOrganizationResponse) Reset() {
-	*x = SuspendOrganizationResponse{}
+func (x *UpdateOrganizationRequest) Reset() {
+	*x = UpdateOrganizationRequest{}
 	mi := &file_organization_organization_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuspendOrganizationResponse) String() string {
+func (x *UpdateOrganizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuspendOrganizationResponse) ProtoMessage() {}
+func (*UpdateOrganizationRequest) ProtoMessage() {}
 
-func (x *SuspendOrganizationResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateOrganizationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_organization_organization_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -505,52 +710,731 @@ func (x *SuspendOrganizationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuspendOrganizationResponse.ProtoReflect.Descriptor instead.
-func (*SuspendOrganizationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrganizationRequest) Descriptor() ([]byte, []int) {
 	return file_organization_organization_proto_rawDescGZIP(), []int{8}
 }
 
-var File_organization_organization_proto protoreflect.FileDescriptor
+func (x *UpdateOrganizationRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
 
-const file_organization_organization_proto_rawDesc = "" +
-	"\n" +
-	"\x1forganization/organization.proto\x12\x14ztcp.organization.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x01\n" +
-	"\fOrganization\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12@\n" +
-	"\x06status\x18\x03 \x01(\x0e2(.ztcp.organization.v1.OrganizationStatusR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"H\n" +
-	"\x19CreateOrganizationRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"d\n" +
-	"\x1aCreateOrganizationResponse\x12F\n" +
-	"\forganization\x18\x01 \x01(\v2\".ztcp.organization.v1.OrganizationR\forganization\"/\n" +
-	"\x16GetOrganizationRequest\x12\x15\n" +
-	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"a\n" +
-	"\x17GetOrganizationResponse\x12F\n" +
-	"\forganization\x18\x01 \x01(\v2\".ztcp.organization.v1.OrganizationR\forganization\"V\n" +
-	"\x18ListOrganizationsRequest\x12:\n" +
-	"\n" +
-	"pagination\x18\x01 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
-	"pagination\"\xa7\x01\n" +
-	"\x19ListOrganizationsResponse\x12H\n" +
-	"\rorganizations\x18\x01 \x03(\v2\".ztcp.organization.v1.OrganizationR\rorganizations\x12@\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
-	"pagination\"3\n" +
-	"\x1aSuspendOrganizationRequest\x12\x15\n" +
-	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"\x1d\n" +
-	"\x1bSuspendOrganizationResponse*|\n" +
+func (x *UpdateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateOrganizationRequest) GetMaxUsers() int32 {
+	if x != nil && x.MaxUsers != nil {
+		return *x.MaxUsers
+	}
+	return 0
+}
+
+func (x *UpdateOrganizationRequest) GetMaxDevices() int32 {
+	if x != nil && x.MaxDevices != nil {
+		return *x.MaxDevices
+	}
+	return 0
+}
+
+// UpdateOrganizationResponse returns the updated organization.
+type UpdateOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrganizationResponse) Reset() {
+	*x = UpdateOrganizationResponse{}
+	mi := &file_organization_organization_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganizationResponse) ProtoMessage() {}
+
+func (x *UpdateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+// ListOrganizationsRequest lists organizations with pagination.
+type ListOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *v13.Pagination        `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_organization_organization_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListOrganizationsRequest) GetPagination() *v13.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// ListOrganizationsResponse returns a page of organizations.
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	Pagination    *v13.PaginationResult  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_organization_organization_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+func (x *ListOrganizationsResponse) GetPagination() *v13.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// SuspendOrganizationRequest identifies the organization to suspend.
+type SuspendOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendOrganizationRequest) Reset() {
+	*x = SuspendOrganizationRequest{}
+	mi := &file_organization_organization_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendOrganizationRequest) ProtoMessage() {}
+
+func (x *SuspendOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*SuspendOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SuspendOrganizationRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// SuspendOrganizationResponse is empty on success.
+type SuspendOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendOrganizationResponse) Reset() {
+	*x = SuspendOrganizationResponse{}
+	mi := &file_organization_organization_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendOrganizationResponse) ProtoMessage() {}
+
+func (x *SuspendOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*SuspendOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{13}
+}
+
+// OrgDomain is an email domain claimed by the org. The org proves ownership by publishing a TXT record named
+// txt_record_name with the value txt_record_value.
+type OrgDomain struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Domain         string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Status         OrgDomainStatus        `protobuf:"varint,2,opt,name=status,proto3,enum=ztcp.organization.v1.OrgDomainStatus" json:"status,omitempty"`
+	TxtRecordName  string                 `protobuf:"bytes,3,opt,name=txt_record_name,json=txtRecordName,proto3" json:"txt_record_name,omitempty"`
+	TxtRecordValue string                 `protobuf:"bytes,4,opt,name=txt_record_value,json=txtRecordValue,proto3" json:"txt_record_value,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	VerifiedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	// last_checked_at is the last VerifyDomain lookup, successful or not.
+	LastCheckedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_checked_at,json=lastCheckedAt,proto3" json:"last_checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgDomain) Reset() {
+	*x = OrgDomain{}
+	mi := &file_organization_organization_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgDomain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgDomain) ProtoMessage() {}
+
+func (x *OrgDomain) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgDomain.ProtoReflect.Descriptor instead.
+func (*OrgDomain) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OrgDomain) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *OrgDomain) GetStatus() OrgDomainStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrgDomainStatus_ORG_DOMAIN_STATUS_UNSPECIFIED
+}
+
+func (x *OrgDomain) GetTxtRecordName() string {
+	if x != nil {
+		return x.TxtRecordName
+	}
+	return ""
+}
+
+func (x *OrgDomain) GetTxtRecordValue() string {
+	if x != nil {
+		return x.TxtRecordValue
+	}
+	return ""
+}
+
+func (x *OrgDomain) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrgDomain) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *OrgDomain) GetLastCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheckedAt
+	}
+	return nil
+}
+
+// StartDomainVerificationRequest claims a domain for the caller's org.
+type StartDomainVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDomainVerificationRequest) Reset() {
+	*x = StartDomainVerificationRequest{}
+	mi := &file_organization_organization_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDomainVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDomainVerificationRequest) ProtoMessage() {}
+
+func (x *StartDomainVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDomainVerificationRequest.ProtoReflect.Descriptor instead.
+func (*StartDomainVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StartDomainVerificationRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// StartDomainVerificationResponse returns the claim with the TXT record to publish.
+type StartDomainVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        *OrgDomain             `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDomainVerificationResponse) Reset() {
+	*x = StartDomainVerificationResponse{}
+	mi := &file_organization_organization_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDomainVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDomainVerificationResponse) ProtoMessage() {}
+
+func (x *StartDomainVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDomainVerificationResponse.ProtoReflect.Descriptor instead.
+func (*StartDomainVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StartDomainVerificationResponse) GetDomain() *OrgDomain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+// VerifyDomainRequest checks the TXT record of a domain claimed by the caller's org.
+type VerifyDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainRequest) Reset() {
+	*x = VerifyDomainRequest{}
+	mi := &file_organization_organization_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainRequest) ProtoMessage() {}
+
+func (x *VerifyDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainRequest) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VerifyDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// VerifyDomainResponse returns the verified domain.
+type VerifyDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        *OrgDomain             `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainResponse) Reset() {
+	*x = VerifyDomainResponse{}
+	mi := &file_organization_organization_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainResponse) ProtoMessage() {}
+
+func (x *VerifyDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDomainResponse) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *VerifyDomainResponse) GetDomain() *OrgDomain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+// ListDomainsRequest lists the caller's org's domains.
+type ListDomainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsRequest) Reset() {
+	*x = ListDomainsRequest{}
+	mi := &file_organization_organization_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsRequest) ProtoMessage() {}
+
+func (x *ListDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsRequest.ProtoReflect.Descriptor instead.
+func (*ListDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{19}
+}
+
+// ListDomainsResponse returns the org's domains, by name.
+type ListDomainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domains       []*OrgDomain           `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsResponse) Reset() {
+	*x = ListDomainsResponse{}
+	mi := &file_organization_organization_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsResponse) ProtoMessage() {}
+
+func (x *ListDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_organization_organization_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsResponse.ProtoReflect.Descriptor instead.
+func (*ListDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_organization_organization_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListDomainsResponse) GetDomains() []*OrgDomain {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+var File_organization_organization_proto protoreflect.FileDescriptor
+
+const file_organization_organization_proto_rawDesc = "" +
+	"\n" +
+	"\x1forganization/organization.proto\x12\x14ztcp.organization.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bmembership/membership.proto\x1a%orgpolicyconfig/orgpolicyconfig.proto\x1a\x13policy/policy.proto\"\xa2\x02\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12@\n" +
+	"\x06status\x18\x03 \x01(\x0e2(.ztcp.organization.v1.OrganizationStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vdata_region\x18\x05 \x01(\tR\n" +
+	"dataRegion\x12\x1b\n" +
+	"\tmax_users\x18\x06 \x01(\x05R\bmaxUsers\x12\x1f\n" +
+	"\vmax_devices\x18\a \x01(\x05R\n" +
+	"maxDevices\x12\x12\n" +
+	"\x04plan\x18\b \x01(\tR\x04plan\"i\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vdata_region\x18\x03 \x01(\tR\n" +
+	"dataRegion\"d\n" +
+	"\x1aCreateOrganizationResponse\x12F\n" +
+	"\forganization\x18\x01 \x01(\v2\".ztcp.organization.v1.OrganizationR\forganization\"\x84\x01\n" +
+	"\x18SetupOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vdata_region\x18\x03 \x01(\tR\n" +
+	"dataRegion\x12\x1a\n" +
+	"\btemplate\x18\x04 \x01(\tR\btemplate\"\x97\x02\n" +
+	"\vMFASettings\x12<\n" +
+	"\x1bmfa_required_for_new_device\x18\x01 \x01(\bR\x17mfaRequiredForNewDevice\x12;\n" +
+	"\x1amfa_required_for_untrusted\x18\x02 \x01(\bR\x17mfaRequiredForUntrusted\x12.\n" +
+	"\x13mfa_required_always\x18\x03 \x01(\bR\x11mfaRequiredAlways\x127\n" +
+	"\x18register_trust_after_mfa\x18\x04 \x01(\bR\x15registerTrustAfterMfa\x12$\n" +
+	"\x0etrust_ttl_days\x18\x05 \x01(\x05R\ftrustTtlDays\"\xaa\x03\n" +
+	"\x19SetupOrganizationResponse\x12F\n" +
+	"\forganization\x18\x01 \x01(\v2\".ztcp.organization.v1.OrganizationR\forganization\x120\n" +
+	"\x05owner\x18\x02 \x01(\v2\x1a.ztcp.membership.v1.MemberR\x05owner\x12D\n" +
+	"\fmfa_settings\x18\x03 \x01(\v2!.ztcp.organization.v1.MFASettingsR\vmfaSettings\x12.\n" +
+	"\x06policy\x18\x04 \x01(\v2\x16.ztcp.policy.v1.PolicyR\x06policy\x12M\n" +
+	"\rpolicy_config\x18\x05 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\fpolicyConfig\x122\n" +
+	"\x15policy_config_version\x18\x06 \x01(\x03R\x13policyConfigVersion\x12\x1a\n" +
+	"\btemplate\x18\a \x01(\tR\btemplate\"/\n" +
+	"\x16GetOrganizationRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"a\n" +
+	"\x17GetOrganizationResponse\x12F\n" +
+	"\forganization\x18\x01 \x01(\v2\".ztcp.organization.v1.OrganizationR\forganization\"\xac\x01\n" +
+	"\x19UpdateOrganizationRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\tmax_users\x18\x03 \x01(\x05H\x00R\bmaxUsers\x88\x01\x01\x12$\n" +
+	"\vmax_devices\x18\x04 \x01(\x05H\x01R\n" +
+	"maxDevices\x88\x01\x01B\f\n" +
+	"\n" +
+	"_max_usersB\x0e\n" +
+	"\f_max_devices\"d\n" +
+	"\x1aUpdateOrganizationResponse\x12F\n" +
+	"\forganization\x18\x01 \x01(\v2\".ztcp.organization.v1.OrganizationR\forganization\"V\n" +
+	"\x18ListOrganizationsRequest\x12:\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\xa7\x01\n" +
+	"\x19ListOrganizationsResponse\x12H\n" +
+	"\rorganizations\x18\x01 \x03(\v2\".ztcp.organization.v1.OrganizationR\rorganizations\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"3\n" +
+	"\x1aSuspendOrganizationRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"\x1d\n" +
+	"\x1bSuspendOrganizationResponse\"\xf0\x02\n" +
+	"\tOrgDomain\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12=\n" +
+	"\x06status\x18\x02 \x01(\x0e2%.ztcp.organization.v1.OrgDomainStatusR\x06status\x12&\n" +
+	"\x0ftxt_record_name\x18\x03 \x01(\tR\rtxtRecordName\x12(\n" +
+	"\x10txt_record_value\x18\x04 \x01(\tR\x0etxtRecordValue\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vverified_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\x12B\n" +
+	"\x0flast_checked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rlastCheckedAt\"8\n" +
+	"\x1eStartDomainVerificationRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\"Z\n" +
+	"\x1fStartDomainVerificationResponse\x127\n" +
+	"\x06domain\x18\x01 \x01(\v2\x1f.ztcp.organization.v1.OrgDomainR\x06domain\"-\n" +
+	"\x13VerifyDomainRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\"O\n" +
+	"\x14VerifyDomainResponse\x127\n" +
+	"\x06domain\x18\x01 \x01(\v2\x1f.ztcp.organization.v1.OrgDomainR\x06domain\"\x14\n" +
+	"\x12ListDomainsRequest\"P\n" +
+	"\x13ListDomainsResponse\x129\n" +
+	"\adomains\x18\x01 \x03(\v2\x1f.ztcp.organization.v1.OrgDomainR\adomains*|\n" +
 	"\x12OrganizationStatus\x12#\n" +
 	"\x1fORGANIZATION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aORGANIZATION_STATUS_ACTIVE\x10\x01\x12!\n" +
-	"\x1dORGANIZATION_STATUS_SUSPENDED\x10\x022\xf0\x03\n" +
+	"\x1dORGANIZATION_STATUS_SUSPENDED\x10\x02*s\n" +
+	"\x0fOrgDomainStatus\x12!\n" +
+	"\x1dORG_DOMAIN_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ORG_DOMAIN_STATUS_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aORG_DOMAIN_STATUS_VERIFIED\x10\x022\xc2\b\n" +
 	"\x13OrganizationService\x12w\n" +
-	"\x12CreateOrganization\x12/.ztcp.organization.v1.CreateOrganizationRequest\x1a0.ztcp.organization.v1.CreateOrganizationResponse\x12n\n" +
-	"\x0fGetOrganization\x12,.ztcp.organization.v1.GetOrganizationRequest\x1a-.ztcp.organization.v1.GetOrganizationResponse\x12t\n" +
-	"\x11ListOrganizations\x12..ztcp.organization.v1.ListOrganizationsRequest\x1a/.ztcp.organization.v1.ListOrganizationsResponse\x12z\n" +
-	"\x13SuspendOrganization\x120.ztcp.organization.v1.SuspendOrganizationRequest\x1a1.ztcp.organization.v1.SuspendOrganizationResponseBOZMzero-trust-control-plane/backend/api/generated/organization/v1;organizationv1b\x06proto3"
+	"\x12CreateOrganization\x12/.ztcp.organization.v1.CreateOrganizationRequest\x1a0.ztcp.organization.v1.CreateOrganizationResponse\x12t\n" +
+	"\x11SetupOrganization\x12..ztcp.organization.v1.SetupOrganizationRequest\x1a/.ztcp.organization.v1.SetupOrganizationResponse\x12s\n" +
+	"\x0fGetOrganization\x12,.ztcp.organization.v1.GetOrganizationRequest\x1a-.ztcp.organization.v1.GetOrganizationResponse\"\x03\x90\x02\x01\x12w\n" +
+	"\x12UpdateOrganization\x12/.ztcp.organization.v1.UpdateOrganizationRequest\x1a0.ztcp.organization.v1.UpdateOrganizationResponse\x12y\n" +
+	"\x11ListOrganizations\x12..ztcp.organization.v1.ListOrganizationsRequest\x1a/.ztcp.organization.v1.ListOrganizationsResponse\"\x03\x90\x02\x01\x12z\n" +
+	"\x13SuspendOrganization\x120.ztcp.organization.v1.SuspendOrganizationRequest\x1a1.ztcp.organization.v1.SuspendOrganizationResponse\x12\x86\x01\n" +
+	"\x17StartDomainVerification\x124.ztcp.organization.v1.StartDomainVerificationRequest\x1a5.ztcp.organization.v1.StartDomainVerificationResponse\x12e\n" +
+	"\fVerifyDomain\x12).ztcp.organization.v1.VerifyDomainRequest\x1a*.ztcp.organization.v1.VerifyDomainResponse\x12g\n" +
+	"\vListDomains\x12(.ztcp.organization.v1.ListDomainsRequest\x1a).ztcp.organization.v1.ListDomainsResponse\"\x03\x90\x02\x01BOZMzero-trust-control-plane/backend/api/generated/organization/v1;organizationv1b\x06proto3"
 
 var (
 	file_organization_organization_proto_rawDescOnce sync.Once
@@ -564,44 +1448,83 @@ func file_organization_organization_proto_rawDescGZIP() []byte {
 	return file_organization_organization_proto_rawDescData
 }
 
-var file_organization_organization_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_organization_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_organization_organization_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_organization_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_organization_organization_proto_goTypes = []any{
-	(OrganizationStatus)(0),             // 0: ztcp.organization.v1.OrganizationStatus
-	(*Organization)(nil),                // 1: ztcp.organization.v1.Organization
-	(*CreateOrganizationRequest)(nil),   // 2: ztcp.organization.v1.CreateOrganizationRequest
-	(*CreateOrganizationResponse)(nil),  // 3: ztcp.organization.v1.CreateOrganizationResponse
-	(*GetOrganizationRequest)(nil),      // 4: ztcp.organization.v1.GetOrganizationRequest
-	(*GetOrganizationResponse)(nil),     // 5: ztcp.organization.v1.GetOrganizationResponse
-	(*ListOrganizationsRequest)(nil),    // 6: ztcp.organization.v1.ListOrganizationsRequest
-	(*ListOrganizationsResponse)(nil),   // 7: ztcp.organization.v1.ListOrganizationsResponse
-	(*SuspendOrganizationRequest)(nil),  // 8: ztcp.organization.v1.SuspendOrganizationRequest
-	(*SuspendOrganizationResponse)(nil), // 9: ztcp.organization.v1.SuspendOrganizationResponse
-	(*timestamppb.Timestamp)(nil),       // 10: google.protobuf.Timestamp
-	(*v1.Pagination)(nil),               // 11: ztcp.common.v1.Pagination
-	(*v1.PaginationResult)(nil),         // 12: ztcp.common.v1.PaginationResult
+	(OrganizationStatus)(0),                 // 0: ztcp.organization.v1.OrganizationStatus
+	(OrgDomainStatus)(0),                    // 1: ztcp.organization.v1.OrgDomainStatus
+	(*Organization)(nil),                    // 2: ztcp.organization.v1.Organization
+	(*CreateOrganizationRequest)(nil),       // 3: ztcp.organization.v1.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil),      // 4: ztcp.organization.v1.CreateOrganizationResponse
+	(*SetupOrganizationRequest)(nil),        // 5: ztcp.organization.v1.SetupOrganizationRequest
+	(*MFASettings)(nil),                     // 6: ztcp.organization.v1.MFASettings
+	(*SetupOrganizationResponse)(nil),       // 7: ztcp.organization.v1.SetupOrganizationResponse
+	(*GetOrganizationRequest)(nil),          // 8: ztcp.organization.v1.GetOrganizationRequest
+	(*GetOrganizationResponse)(nil),         // 9: ztcp.organization.v1.GetOrganizationResponse
+	(*UpdateOrganizationRequest)(nil),       // 10: ztcp.organization.v1.UpdateOrganizationRequest
+	(*UpdateOrganizationResponse)(nil),      // 11: ztcp.organization.v1.UpdateOrganizationResponse
+	(*ListOrganizationsRequest)(nil),        // 12: ztcp.organization.v1.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil),       // 13: ztcp.organization.v1.ListOrganizationsResponse
+	(*SuspendOrganizationRequest)(nil),      // 14: ztcp.organization.v1.SuspendOrganizationRequest
+	(*SuspendOrganizationResponse)(nil),     // 15: ztcp.organization.v1.SuspendOrganizationResponse
+	(*OrgDomain)(nil),                       // 16: ztcp.organization.v1.OrgDomain
+	(*StartDomainVerificationRequest)(nil),  // 17: ztcp.organization.v1.StartDomainVerificationRequest
+	(*StartDomainVerificationResponse)(nil), // 18: ztcp.organization.v1.StartDomainVerificationResponse
+	(*VerifyDomainRequest)(nil),             // 19: ztcp.organization.v1.VerifyDomainRequest
+	(*VerifyDomainResponse)(nil),            // 20: ztcp.organization.v1.VerifyDomainResponse
+	(*ListDomainsRequest)(nil),              // 21: ztcp.organization.v1.ListDomainsRequest
+	(*ListDomainsResponse)(nil),             // 22: ztcp.organization.v1.ListDomainsResponse
+	(*timestamppb.Timestamp)(nil),           // 23: google.protobuf.Timestamp
+	(*v1.Member)(nil),                       // 24: ztcp.membership.v1.Member
+	(*v11.Policy)(nil),                      // 25: ztcp.policy.v1.Policy
+	(*v12.OrgPolicyConfig)(nil),             // 26: ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	(*v13.Pagination)(nil),                  // 27: ztcp.common.v1.Pagination
+	(*v13.PaginationResult)(nil),            // 28: ztcp.common.v1.PaginationResult
 }
 var file_organization_organization_proto_depIdxs = []int32{
 	0,  // 0: ztcp.organization.v1.Organization.status:type_name -> ztcp.organization.v1.OrganizationStatus
-	10, // 1: ztcp.organization.v1.Organization.created_at:type_name -> google.protobuf.Timestamp
-	1,  // 2: ztcp.organization.v1.CreateOrganizationResponse.organization:type_name -> ztcp.organization.v1.Organization
-	1,  // 3: ztcp.organization.v1.GetOrganizationResponse.organization:type_name -> ztcp.organization.v1.Organization
-	11, // 4: ztcp.organization.v1.ListOrganizationsRequest.pagination:type_name -> ztcp.common.v1.Pagination
-	1,  // 5: ztcp.organization.v1.ListOrganizationsResponse.organizations:type_name -> ztcp.organization.v1.Organization
-	12, // 6: ztcp.organization.v1.ListOrganizationsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
-	2,  // 7: ztcp.organization.v1.OrganizationService.CreateOrganization:input_type -> ztcp.organization.v1.CreateOrganizationRequest
-	4,  // 8: ztcp.organization.v1.OrganizationService.GetOrganization:input_type -> ztcp.organization.v1.GetOrganizationRequest
-	6,  // 9: ztcp.organization.v1.OrganizationService.ListOrganizations:input_type -> ztcp.organization.v1.ListOrganizationsRequest
-	8,  // 10: ztcp.organization.v1.OrganizationService.SuspendOrganization:input_type -> ztcp.organization.v1.SuspendOrganizationRequest
-	3,  // 11: ztcp.organization.v1.OrganizationService.CreateOrganization:output_type -> ztcp.organization.v1.CreateOrganizationResponse
-	5,  // 12: ztcp.organization.v1.OrganizationService.GetOrganization:output_type -> ztcp.organization.v1.GetOrganizationResponse
-	7,  // 13: ztcp.organization.v1.OrganizationService.ListOrganizations:output_type -> ztcp.organization.v1.ListOrganizationsResponse
-	9,  // 14: ztcp.organization.v1.OrganizationService.SuspendOrganization:output_type -> ztcp.organization.v1.SuspendOrganizationResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	23, // 1: ztcp.organization.v1.Organization.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: ztcp.organization.v1.CreateOrganizationResponse.organization:type_name -> ztcp.organization.v1.Organization
+	2,  // 3: ztcp.organization.v1.SetupOrganizationResponse.organization:type_name -> ztcp.organization.v1.Organization
+	24, // 4: ztcp.organization.v1.SetupOrganizationResponse.owner:type_name -> ztcp.membership.v1.Member
+	6,  // 5: ztcp.organization.v1.SetupOrganizationResponse.mfa_settings:type_name -> ztcp.organization.v1.MFASettings
+	25, // 6: ztcp.organization.v1.SetupOrganizationResponse.policy:type_name -> ztcp.policy.v1.Policy
+	26, // 7: ztcp.organization.v1.SetupOrganizationResponse.policy_config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	2,  // 8: ztcp.organization.v1.GetOrganizationResponse.organization:type_name -> ztcp.organization.v1.Organization
+	2,  // 9: ztcp.organization.v1.UpdateOrganizationResponse.organization:type_name -> ztcp.organization.v1.Organization
+	27, // 10: ztcp.organization.v1.ListOrganizationsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	2,  // 11: ztcp.organization.v1.ListOrganizationsResponse.organizations:type_name -> ztcp.organization.v1.Organization
+	28, // 12: ztcp.organization.v1.ListOrganizationsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	1,  // 13: ztcp.organization.v1.OrgDomain.status:type_name -> ztcp.organization.v1.OrgDomainStatus
+	23, // 14: ztcp.organization.v1.OrgDomain.created_at:type_name -> google.protobuf.Timestamp
+	23, // 15: ztcp.organization.v1.OrgDomain.verified_at:type_name -> google.protobuf.Timestamp
+	23, // 16: ztcp.organization.v1.OrgDomain.last_checked_at:type_name -> google.protobuf.Timestamp
+	16, // 17: ztcp.organization.v1.StartDomainVerificationResponse.domain:type_name -> ztcp.organization.v1.OrgDomain
+	16, // 18: ztcp.organization.v1.VerifyDomainResponse.domain:type_name -> ztcp.organization.v1.OrgDomain
+	16, // 19: ztcp.organization.v1.ListDomainsResponse.domains:type_name -> ztcp.organization.v1.OrgDomain
+	3,  // 20: ztcp.organization.v1.OrganizationService.CreateOrganization:input_type -> ztcp.organization.v1.CreateOrganizationRequest
+	5,  // 21: ztcp.organization.v1.OrganizationService.SetupOrganization:input_type -> ztcp.organization.v1.SetupOrganizationRequest
+	8,  // 22: ztcp.organization.v1.OrganizationService.GetOrganization:input_type -> ztcp.organization.v1.GetOrganizationRequest
+	10, // 23: ztcp.organization.v1.OrganizationService.UpdateOrganization:input_type -> ztcp.organization.v1.UpdateOrganizationRequest
+	12, // 24: ztcp.organization.v1.OrganizationService.ListOrganizations:input_type -> ztcp.organization.v1.ListOrganizationsRequest
+	14, // 25: ztcp.organization.v1.OrganizationService.SuspendOrganization:input_type -> ztcp.organization.v1.SuspendOrganizationRequest
+	17, // 26: ztcp.organization.v1.OrganizationService.StartDomainVerification:input_type -> ztcp.organization.v1.StartDomainVerificationRequest
+	19, // 27: ztcp.organization.v1.OrganizationService.VerifyDomain:input_type -> ztcp.organization.v1.VerifyDomainRequest
+	21, // 28: ztcp.organization.v1.OrganizationService.ListDomains:input_type -> ztcp.organization.v1.ListDomainsRequest
+	4,  // 29: ztcp.organization.v1.OrganizationService.CreateOrganization:output_type -> ztcp.organization.v1.CreateOrganizationResponse
+	7,  // 30: ztcp.organization.v1.OrganizationService.SetupOrganization:output_type -> ztcp.organization.v1.SetupOrganizationResponse
+	9,  // 31: ztcp.organization.v1.OrganizationService.GetOrganization:output_type -> ztcp.organization.v1.GetOrganizationResponse
+	11, // 32: ztcp.organization.v1.OrganizationService.UpdateOrganization:output_type -> ztcp.organization.v1.UpdateOrganizationResponse
+	13, // 33: ztcp.organization.v1.OrganizationService.ListOrganizations:output_type -> ztcp.organization.v1.ListOrganizationsResponse
+	15, // 34: ztcp.organization.v1.OrganizationService.SuspendOrganization:output_type -> ztcp.organization.v1.SuspendOrganizationResponse
+	18, // 35: ztcp.organization.v1.OrganizationService.StartDomainVerification:output_type -> ztcp.organization.v1.StartDomainVerificationResponse
+	20, // 36: ztcp.organization.v1.OrganizationService.VerifyDomain:output_type -> ztcp.organization.v1.VerifyDomainResponse
+	22, // 37: ztcp.organization.v1.OrganizationService.ListDomains:output_type -> ztcp.organization.v1.ListDomainsResponse
+	29, // [29:38] is the sub-list for method output_type
+	20, // [20:29] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_organization_organization_proto_init() }
@@ -609,13 +1532,14 @@ func file_organization_organization_proto_init() {
 	if File_organization_organization_proto != nil {
 		return
 	}
+	file_organization_organization_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_organization_organization_proto_rawDesc), len(file_organization_organization_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      2,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
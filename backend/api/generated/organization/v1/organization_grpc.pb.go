@@ -19,10 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrganizationService_CreateOrganization_FullMethodName  = "/ztcp.organization.v1.OrganizationService/CreateOrganization"
-	OrganizationService_GetOrganization_FullMethodName     = "/ztcp.organization.v1.OrganizationService/GetOrganization"
-	OrganizationService_ListOrganizations_FullMethodName   = "/ztcp.organization.v1.OrganizationService/ListOrganizations"
-	OrganizationService_SuspendOrganization_FullMethodName = "/ztcp.organization.v1.OrganizationService/SuspendOrganization"
+	OrganizationService_CreateOrganization_FullMethodName      = "/ztcp.organization.v1.OrganizationService/CreateOrganization"
+	OrganizationService_SetupOrganization_FullMethodName       = "/ztcp.organization.v1.OrganizationService/SetupOrganization"
+	OrganizationService_GetOrganization_FullMethodName         = "/ztcp.organization.v1.OrganizationService/GetOrganization"
+	OrganizationService_UpdateOrganization_FullMethodName      = "/ztcp.organization.v1.OrganizationService/UpdateOrganization"
+	OrganizationService_ListOrganizations_FullMethodName       = "/ztcp.organization.v1.OrganizationService/ListOrganizations"
+	OrganizationService_SuspendOrganization_FullMethodName     = "/ztcp.organization.v1.OrganizationService/SuspendOrganization"
+	OrganizationService_StartDomainVerification_FullMethodName = "/ztcp.organization.v1.OrganizationService/StartDomainVerification"
+	OrganizationService_VerifyDomain_FullMethodName            = "/ztcp.organization.v1.OrganizationService/VerifyDomain"
+	OrganizationService_ListDomains_FullMethodName             = "/ztcp.organization.v1.OrganizationService/ListDomains"
 )
 
 // OrganizationServiceClient is the client API for OrganizationService service.
@@ -32,9 +37,24 @@ const (
 // OrganizationService handles multi-tenancy and organization management.
 type OrganizationServiceClient interface {
 	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	// SetupOrganization creates an organization, its owner membership, MFA settings, a first Rego policy and policy
+	// config from a template, in one transaction: either all of it is created or none.
+	SetupOrganization(ctx context.Context, in *SetupOrganizationRequest, opts ...grpc.CallOption) (*SetupOrganizationResponse, error)
+	// GetOrganization returns an organization to its members and to platform admins.
 	GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error)
+	// UpdateOrganization renames an organization (its owners and admins, or platform admins) and sets its quotas
+	// (platform admins only).
+	UpdateOrganization(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*UpdateOrganizationResponse, error)
+	// ListOrganizations lists all organizations, oldest first (platform admins only).
 	ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
 	SuspendOrganization(ctx context.Context, in *SuspendOrganizationRequest, opts ...grpc.CallOption) (*SuspendOrganizationResponse, error)
+	// StartDomainVerification claims an email domain for the caller's org (owner or admin) and returns the TXT record
+	// that proves ownership. Calling it again returns the same record.
+	StartDomainVerification(ctx context.Context, in *StartDomainVerificationRequest, opts ...grpc.CallOption) (*StartDomainVerificationResponse, error)
+	// VerifyDomain looks up the domain's TXT record and marks the domain verified when it matches.
+	VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error)
+	// ListDomains lists the caller's org's claimed domains (owner or admin).
+	ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error)
 }
 
 type organizationServiceClient struct {
@@ -55,6 +75,16 @@ func (c *organizationServiceClient) CreateOrganization(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *organizationServiceClient) SetupOrganization(ctx context.Context, in *SetupOrganizationRequest, opts ...grpc.CallOption) (*SetupOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetupOrganizationResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_SetupOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *organizationServiceClient) GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetOrganizationResponse)
@@ -65,6 +95,16 @@ func (c *organizationServiceClient) GetOrganization(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *organizationServiceClient) UpdateOrganization(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*UpdateOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateOrganizationResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_UpdateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *organizationServiceClient) ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListOrganizationsResponse)
@@ -85,6 +125,36 @@ func (c *organizationServiceClient) SuspendOrganization(ctx context.Context, in
 	return out, nil
 }
 
+func (c *organizationServiceClient) StartDomainVerification(ctx context.Context, in *StartDomainVerificationRequest, opts ...grpc.CallOption) (*StartDomainVerificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartDomainVerificationResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_StartDomainVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyDomainResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_VerifyDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDomainsResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_ListDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrganizationServiceServer is the server API for OrganizationService service.
 // All implementations must embed UnimplementedOrganizationServiceServer
 // for forward compatibility.
@@ -92,9 +162,24 @@ func (c *organizationServiceClient) SuspendOrganization(ctx context.Context, in
 // OrganizationService handles multi-tenancy and organization management.
 type OrganizationServiceServer interface {
 	CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	// SetupOrganization creates an organization, its owner membership, MFA settings, a first Rego policy and policy
+	// config from a template, in one transaction: either all of it is created or none.
+	SetupOrganization(context.Context, *SetupOrganizationRequest) (*SetupOrganizationResponse, error)
+	// GetOrganization returns an organization to its members and to platform admins.
 	GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error)
+	// UpdateOrganization renames an organization (its owners and admins, or platform admins) and sets its quotas
+	// (platform admins only).
+	UpdateOrganization(context.Context, *UpdateOrganizationRequest) (*UpdateOrganizationResponse, error)
+	// ListOrganizations lists all organizations, oldest first (platform admins only).
 	ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error)
 	SuspendOrganization(context.Context, *SuspendOrganizationRequest) (*SuspendOrganizationResponse, error)
+	// StartDomainVerification claims an email domain for the caller's org (owner or admin) and returns the TXT record
+	// that proves ownership. Calling it again returns the same record.
+	StartDomainVerification(context.Context, *StartDomainVerificationRequest) (*StartDomainVerificationResponse, error)
+	// VerifyDomain looks up the domain's TXT record and marks the domain verified when it matches.
+	VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error)
+	// ListDomains lists the caller's org's claimed domains (owner or admin).
+	ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error)
 	mustEmbedUnimplementedOrganizationServiceServer()
 }
 
@@ -108,15 +193,30 @@ type UnimplementedOrganizationServiceServer struct{}
 func (UnimplementedOrganizationServiceServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateOrganization not implemented")
 }
+func (UnimplementedOrganizationServiceServer) SetupOrganization(context.Context, *SetupOrganizationRequest) (*SetupOrganizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetupOrganization not implemented")
+}
 func (UnimplementedOrganizationServiceServer) GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOrganization not implemented")
 }
+func (UnimplementedOrganizationServiceServer) UpdateOrganization(context.Context, *UpdateOrganizationRequest) (*UpdateOrganizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateOrganization not implemented")
+}
 func (UnimplementedOrganizationServiceServer) ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListOrganizations not implemented")
 }
 func (UnimplementedOrganizationServiceServer) SuspendOrganization(context.Context, *SuspendOrganizationRequest) (*SuspendOrganizationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SuspendOrganization not implemented")
 }
+func (UnimplementedOrganizationServiceServer) StartDomainVerification(context.Context, *StartDomainVerificationRequest) (*StartDomainVerificationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartDomainVerification not implemented")
+}
+func (UnimplementedOrganizationServiceServer) VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyDomain not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDomains not implemented")
+}
 func (UnimplementedOrganizationServiceServer) mustEmbedUnimplementedOrganizationServiceServer() {}
 func (UnimplementedOrganizationServiceServer) testEmbeddedByValue()                             {}
 
@@ -156,6 +256,24 @@ func _OrganizationService_CreateOrganization_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrganizationService_SetupOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetupOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).SetupOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_SetupOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).SetupOrganization(ctx, req.(*SetupOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrganizationService_GetOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOrganizationRequest)
 	if err := dec(in); err != nil {
@@ -174,6 +292,24 @@ func _OrganizationService_GetOrganization_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrganizationService_UpdateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).UpdateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_UpdateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).UpdateOrganization(ctx, req.(*UpdateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrganizationService_ListOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListOrganizationsRequest)
 	if err := dec(in); err != nil {
@@ -210,6 +346,60 @@ func _OrganizationService_SuspendOrganization_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrganizationService_StartDomainVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartDomainVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).StartDomainVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_StartDomainVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).StartDomainVerification(ctx, req.(*StartDomainVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_VerifyDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).VerifyDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_VerifyDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).VerifyDomain(ctx, req.(*VerifyDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListDomains(ctx, req.(*ListDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrganizationService_ServiceDesc is the grpc.ServiceDesc for OrganizationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,10 +411,18 @@ var OrganizationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateOrganization",
 			Handler:    _OrganizationService_CreateOrganization_Handler,
 		},
+		{
+			MethodName: "SetupOrganization",
+			Handler:    _OrganizationService_SetupOrganization_Handler,
+		},
 		{
 			MethodName: "GetOrganization",
 			Handler:    _OrganizationService_GetOrganization_Handler,
 		},
+		{
+			MethodName: "UpdateOrganization",
+			Handler:    _OrganizationService_UpdateOrganization_Handler,
+		},
 		{
 			MethodName: "ListOrganizations",
 			Handler:    _OrganizationService_ListOrganizations_Handler,
@@ -233,6 +431,18 @@ var OrganizationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SuspendOrganization",
 			Handler:    _OrganizationService_SuspendOrganization_Handler,
 		},
+		{
+			MethodName: "StartDomainVerification",
+			Handler:    _OrganizationService_StartDomainVerification_Handler,
+		},
+		{
+			MethodName: "VerifyDomain",
+			Handler:    _OrganizationService_VerifyDomain_Handler,
+		},
+		{
+			MethodName: "ListDomains",
+			Handler:    _OrganizationService_ListDomains_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "organization/organization.proto",
@@ -9,9 +9,12 @@ package orgpolicyconfigv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
 )
 
 const (
@@ -124,6 +127,215 @@ func (DefaultAction) EnumDescriptor() ([]byte, []int) {
 	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{1}
 }
 
+type UrlRuleAction int32
+
+const (
+	UrlRuleAction_URL_RULE_ACTION_UNSPECIFIED UrlRuleAction = 0
+	UrlRuleAction_URL_RULE_ACTION_ALLOW       UrlRuleAction = 1
+	UrlRuleAction_URL_RULE_ACTION_BLOCK       UrlRuleAction = 2
+)
+
+// Enum value maps for UrlRuleAction.
+var (
+	UrlRuleAction_name = map[int32]string{
+		0: "URL_RULE_ACTION_UNSPECIFIED",
+		1: "URL_RULE_ACTION_ALLOW",
+		2: "URL_RULE_ACTION_BLOCK",
+	}
+	UrlRuleAction_value = map[string]int32{
+		"URL_RULE_ACTION_UNSPECIFIED": 0,
+		"URL_RULE_ACTION_ALLOW":       1,
+		"URL_RULE_ACTION_BLOCK":       2,
+	}
+)
+
+func (x UrlRuleAction) Enum() *UrlRuleAction {
+	p := new(UrlRuleAction)
+	*p = x
+	return p
+}
+
+func (x UrlRuleAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UrlRuleAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[2].Descriptor()
+}
+
+func (UrlRuleAction) Type() protoreflect.EnumType {
+	return &file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[2]
+}
+
+func (x UrlRuleAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UrlRuleAction.Descriptor instead.
+func (UrlRuleAction) EnumDescriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{2}
+}
+
+// What Register/ChangePassword do with a password found in a breach corpus.
+type BreachedPasswordMode int32
+
+const (
+	BreachedPasswordMode_BREACHED_PASSWORD_MODE_UNSPECIFIED BreachedPasswordMode = 0 // platform default (BREACHED_PASSWORD_MODE)
+	BreachedPasswordMode_BREACHED_PASSWORD_MODE_OFF         BreachedPasswordMode = 1
+	BreachedPasswordMode_BREACHED_PASSWORD_MODE_WARN        BreachedPasswordMode = 2
+	BreachedPasswordMode_BREACHED_PASSWORD_MODE_BLOCK       BreachedPasswordMode = 3
+)
+
+// Enum value maps for BreachedPasswordMode.
+var (
+	BreachedPasswordMode_name = map[int32]string{
+		0: "BREACHED_PASSWORD_MODE_UNSPECIFIED",
+		1: "BREACHED_PASSWORD_MODE_OFF",
+		2: "BREACHED_PASSWORD_MODE_WARN",
+		3: "BREACHED_PASSWORD_MODE_BLOCK",
+	}
+	BreachedPasswordMode_value = map[string]int32{
+		"BREACHED_PASSWORD_MODE_UNSPECIFIED": 0,
+		"BREACHED_PASSWORD_MODE_OFF":         1,
+		"BREACHED_PASSWORD_MODE_WARN":        2,
+		"BREACHED_PASSWORD_MODE_BLOCK":       3,
+	}
+)
+
+func (x BreachedPasswordMode) Enum() *BreachedPasswordMode {
+	p := new(BreachedPasswordMode)
+	*p = x
+	return p
+}
+
+func (x BreachedPasswordMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BreachedPasswordMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[3].Descriptor()
+}
+
+func (BreachedPasswordMode) Type() protoreflect.EnumType {
+	return &file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[3]
+}
+
+func (x BreachedPasswordMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BreachedPasswordMode.Descriptor instead.
+func (BreachedPasswordMode) EnumDescriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{3}
+}
+
+// Self-service sign-up mode for emails in the org's verified domains. The stricter of this and the platform
+// registration_mode applies.
+type RegistrationMode int32
+
+const (
+	RegistrationMode_REGISTRATION_MODE_UNSPECIFIED RegistrationMode = 0 // platform registration_mode
+	RegistrationMode_REGISTRATION_MODE_OPEN        RegistrationMode = 1
+	RegistrationMode_REGISTRATION_MODE_INVITE_ONLY RegistrationMode = 2 // an unused invitation from the org is required (InvitationService)
+	RegistrationMode_REGISTRATION_MODE_CLOSED      RegistrationMode = 3
+)
+
+// Enum value maps for RegistrationMode.
+var (
+	RegistrationMode_name = map[int32]string{
+		0: "REGISTRATION_MODE_UNSPECIFIED",
+		1: "REGISTRATION_MODE_OPEN",
+		2: "REGISTRATION_MODE_INVITE_ONLY",
+		3: "REGISTRATION_MODE_CLOSED",
+	}
+	RegistrationMode_value = map[string]int32{
+		"REGISTRATION_MODE_UNSPECIFIED": 0,
+		"REGISTRATION_MODE_OPEN":        1,
+		"REGISTRATION_MODE_INVITE_ONLY": 2,
+		"REGISTRATION_MODE_CLOSED":      3,
+	}
+)
+
+func (x RegistrationMode) Enum() *RegistrationMode {
+	p := new(RegistrationMode)
+	*p = x
+	return p
+}
+
+func (x RegistrationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegistrationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[4].Descriptor()
+}
+
+func (RegistrationMode) Type() protoreflect.EnumType {
+	return &file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[4]
+}
+
+func (x RegistrationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegistrationMode.Descriptor instead.
+func (RegistrationMode) EnumDescriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{4}
+}
+
+// DomainList selects the access control list changed by BulkUpdateDomains.
+type DomainList int32
+
+const (
+	DomainList_DOMAIN_LIST_UNSPECIFIED     DomainList = 0
+	DomainList_DOMAIN_LIST_ALLOWED         DomainList = 1
+	DomainList_DOMAIN_LIST_BLOCKED         DomainList = 2
+	DomainList_DOMAIN_LIST_CUSTOM_CATEGORY DomainList = 3 // the custom category named by category (created if missing)
+)
+
+// Enum value maps for DomainList.
+var (
+	DomainList_name = map[int32]string{
+		0: "DOMAIN_LIST_UNSPECIFIED",
+		1: "DOMAIN_LIST_ALLOWED",
+		2: "DOMAIN_LIST_BLOCKED",
+		3: "DOMAIN_LIST_CUSTOM_CATEGORY",
+	}
+	DomainList_value = map[string]int32{
+		"DOMAIN_LIST_UNSPECIFIED":     0,
+		"DOMAIN_LIST_ALLOWED":         1,
+		"DOMAIN_LIST_BLOCKED":         2,
+		"DOMAIN_LIST_CUSTOM_CATEGORY": 3,
+	}
+)
+
+func (x DomainList) Enum() *DomainList {
+	p := new(DomainList)
+	*p = x
+	return p
+}
+
+func (x DomainList) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DomainList) Descriptor() protoreflect.EnumDescriptor {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[5].Descriptor()
+}
+
+func (DomainList) Type() protoreflect.EnumType {
+	return &file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes[5]
+}
+
+func (x DomainList) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DomainList.Descriptor instead.
+func (DomainList) EnumDescriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{5}
+}
+
 // Authentication & MFA section.
 type AuthMfa struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
@@ -131,8 +343,13 @@ type AuthMfa struct {
 	AllowedMfaMethods      []string               `protobuf:"bytes,2,rep,name=allowed_mfa_methods,json=allowedMfaMethods,proto3" json:"allowed_mfa_methods,omitempty"` // e.g. "sms_otp"
 	StepUpSensitiveActions bool                   `protobuf:"varint,3,opt,name=step_up_sensitive_actions,json=stepUpSensitiveActions,proto3" json:"step_up_sensitive_actions,omitempty"`
 	StepUpPolicyViolation  bool                   `protobuf:"varint,4,opt,name=step_up_policy_violation,json=stepUpPolicyViolation,proto3" json:"step_up_policy_violation,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	GroupRequirements      []*GroupMfaRequirement `protobuf:"bytes,5,rep,name=group_requirements,json=groupRequirements,proto3" json:"group_requirements,omitempty"` // max 100; on top of mfa_requirement
+	MagicLinkEnabled       bool                   `protobuf:"varint,6,opt,name=magic_link_enabled,json=magicLinkEnabled,proto3" json:"magic_link_enabled,omitempty"` // members may sign in with a one-time link sent by email (AuthService.RequestMagicLink)
+	// What MFA evaluation does while the org's policies cannot be loaded: "open" evaluates the built-in default policy,
+	// "closed" requires MFA for every sign-in. Empty uses the server default (POLICY_FAILURE_MODE).
+	PolicyFailureMode string `protobuf:"bytes,7,opt,name=policy_failure_mode,json=policyFailureMode,proto3" json:"policy_failure_mode,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *AuthMfa) Reset() {
@@ -193,6 +410,80 @@ func (x *AuthMfa) GetStepUpPolicyViolation() bool {
 	return false
 }
 
+func (x *AuthMfa) GetGroupRequirements() []*GroupMfaRequirement {
+	if x != nil {
+		return x.GroupRequirements
+	}
+	return nil
+}
+
+func (x *AuthMfa) GetMagicLinkEnabled() bool {
+	if x != nil {
+		return x.MagicLinkEnabled
+	}
+	return false
+}
+
+func (x *AuthMfa) GetPolicyFailureMode() string {
+	if x != nil {
+		return x.PolicyFailureMode
+	}
+	return ""
+}
+
+// MFA requirement for the members of one group (GroupService). Can only add MFA to the org's requirement.
+type GroupMfaRequirement struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Group          string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"` // group name
+	MfaRequirement MfaRequirement         `protobuf:"varint,2,opt,name=mfa_requirement,json=mfaRequirement,proto3,enum=ztcp.orgpolicyconfig.v1.MfaRequirement" json:"mfa_requirement,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupMfaRequirement) Reset() {
+	*x = GroupMfaRequirement{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMfaRequirement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMfaRequirement) ProtoMessage() {}
+
+func (x *GroupMfaRequirement) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMfaRequirement.ProtoReflect.Descriptor instead.
+func (*GroupMfaRequirement) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GroupMfaRequirement) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupMfaRequirement) GetMfaRequirement() MfaRequirement {
+	if x != nil {
+		return x.MfaRequirement
+	}
+	return MfaRequirement_MFA_REQUIREMENT_UNSPECIFIED
+}
+
 // Device Trust section.
 type DeviceTrust struct {
 	state                     protoimpl.MessageState `protogen:"open.v1"`
@@ -201,13 +492,16 @@ type DeviceTrust struct {
 	MaxTrustedDevicesPerUser  int32                  `protobuf:"varint,3,opt,name=max_trusted_devices_per_user,json=maxTrustedDevicesPerUser,proto3" json:"max_trusted_devices_per_user,omitempty"` // 0 = unlimited
 	ReverifyIntervalDays      int32                  `protobuf:"varint,4,opt,name=reverify_interval_days,json=reverifyIntervalDays,proto3" json:"reverify_interval_days,omitempty"`
 	AdminRevokeAllowed        bool                   `protobuf:"varint,5,opt,name=admin_revoke_allowed,json=adminRevokeAllowed,proto3" json:"admin_revoke_allowed,omitempty"`
+	KeepTrustOnFactorChange   bool                   `protobuf:"varint,6,opt,name=keep_trust_on_factor_change,json=keepTrustOnFactorChange,proto3" json:"keep_trust_on_factor_change,omitempty"` // false = untrust the user's devices when their MFA phone changes
+	TrustRenewal              string                 `protobuf:"bytes,7,opt,name=trust_renewal,json=trustRenewal,proto3" json:"trust_renewal,omitempty"`                                         // fixed, sliding (signing in or refreshing extends trust); empty = fixed
+	ExpiryNoticeDays          int32                  `protobuf:"varint,8,opt,name=expiry_notice_days,json=expiryNoticeDays,proto3" json:"expiry_notice_days,omitempty"`                          // notify users this many days before trust expires (0 = off, at most 30)
 	unknownFields             protoimpl.UnknownFields
 	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *DeviceTrust) Reset() {
 	*x = DeviceTrust{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[1]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -219,7 +513,7 @@ func (x *DeviceTrust) String() string {
 func (*DeviceTrust) ProtoMessage() {}
 
 func (x *DeviceTrust) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[1]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -232,7 +526,7 @@ func (x *DeviceTrust) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeviceTrust.ProtoReflect.Descriptor instead.
 func (*DeviceTrust) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{1}
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *DeviceTrust) GetDeviceRegistrationAllowed() bool {
@@ -270,6 +564,27 @@ func (x *DeviceTrust) GetAdminRevokeAllowed() bool {
 	return false
 }
 
+func (x *DeviceTrust) GetKeepTrustOnFactorChange() bool {
+	if x != nil {
+		return x.KeepTrustOnFactorChange
+	}
+	return false
+}
+
+func (x *DeviceTrust) GetTrustRenewal() string {
+	if x != nil {
+		return x.TrustRenewal
+	}
+	return ""
+}
+
+func (x *DeviceTrust) GetExpiryNoticeDays() int32 {
+	if x != nil {
+		return x.ExpiryNoticeDays
+	}
+	return 0
+}
+
 // Session Management section.
 type SessionMgmt struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
@@ -278,13 +593,15 @@ type SessionMgmt struct {
 	ConcurrentSessionLimit int32                  `protobuf:"varint,3,opt,name=concurrent_session_limit,json=concurrentSessionLimit,proto3" json:"concurrent_session_limit,omitempty"` // 0 = unlimited
 	AdminForcedLogout      bool                   `protobuf:"varint,4,opt,name=admin_forced_logout,json=adminForcedLogout,proto3" json:"admin_forced_logout,omitempty"`
 	ReauthOnPolicyChange   bool                   `protobuf:"varint,5,opt,name=reauth_on_policy_change,json=reauthOnPolicyChange,proto3" json:"reauth_on_policy_change,omitempty"`
+	RefreshExpiry          string                 `protobuf:"bytes,6,opt,name=refresh_expiry,json=refreshExpiry,proto3" json:"refresh_expiry,omitempty"`   // rolling (each refresh extends the session), absolute (fixed since sign-in); empty = rolling
+	MaxSessionAge          string                 `protobuf:"bytes,7,opt,name=max_session_age,json=maxSessionAge,proto3" json:"max_session_age,omitempty"` // duration e.g. "720h" since sign-in, enforced on Refresh; empty = no cap
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
 func (x *SessionMgmt) Reset() {
 	*x = SessionMgmt{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[2]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -296,7 +613,7 @@ func (x *SessionMgmt) String() string {
 func (*SessionMgmt) ProtoMessage() {}
 
 func (x *SessionMgmt) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[2]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,7 +626,7 @@ func (x *SessionMgmt) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SessionMgmt.ProtoReflect.Descriptor instead.
 func (*SessionMgmt) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{2}
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SessionMgmt) GetSessionMaxTtl() string {
@@ -347,32 +664,45 @@ func (x *SessionMgmt) GetReauthOnPolicyChange() bool {
 	return false
 }
 
-// Access Control (browser) section.
-type AccessControl struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	AllowedDomains    []string               `protobuf:"bytes,1,rep,name=allowed_domains,json=allowedDomains,proto3" json:"allowed_domains,omitempty"`
-	BlockedDomains    []string               `protobuf:"bytes,2,rep,name=blocked_domains,json=blockedDomains,proto3" json:"blocked_domains,omitempty"`
-	WildcardSupported bool                   `protobuf:"varint,3,opt,name=wildcard_supported,json=wildcardSupported,proto3" json:"wildcard_supported,omitempty"`
-	DefaultAction     DefaultAction          `protobuf:"varint,4,opt,name=default_action,json=defaultAction,proto3,enum=ztcp.orgpolicyconfig.v1.DefaultAction" json:"default_action,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *SessionMgmt) GetRefreshExpiry() string {
+	if x != nil {
+		return x.RefreshExpiry
+	}
+	return ""
 }
 
-func (x *AccessControl) Reset() {
-	*x = AccessControl{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[3]
+func (x *SessionMgmt) GetMaxSessionAge() string {
+	if x != nil {
+		return x.MaxSessionAge
+	}
+	return ""
+}
+
+// UrlCategory is a named domain list (e.g. "social"); a domain matches itself and its subdomains.
+type UrlCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Domains       []string               `protobuf:"bytes,2,rep,name=domains,proto3" json:"domains,omitempty"`
+	Managed       bool                   `protobuf:"varint,3,opt,name=managed,proto3" json:"managed,omitempty"` // built-in list (read-only); false for org custom categories
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UrlCategory) Reset() {
+	*x = UrlCategory{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AccessControl) String() string {
+func (x *UrlCategory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AccessControl) ProtoMessage() {}
+func (*UrlCategory) ProtoMessage() {}
 
-func (x *AccessControl) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[3]
+func (x *UrlCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -383,63 +713,59 @@ func (x *AccessControl) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AccessControl.ProtoReflect.Descriptor instead.
-func (*AccessControl) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UrlCategory.ProtoReflect.Descriptor instead.
+func (*UrlCategory) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AccessControl) GetAllowedDomains() []string {
+func (x *UrlCategory) GetName() string {
 	if x != nil {
-		return x.AllowedDomains
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *AccessControl) GetBlockedDomains() []string {
+func (x *UrlCategory) GetDomains() []string {
 	if x != nil {
-		return x.BlockedDomains
+		return x.Domains
 	}
 	return nil
 }
 
-func (x *AccessControl) GetWildcardSupported() bool {
+func (x *UrlCategory) GetManaged() bool {
 	if x != nil {
-		return x.WildcardSupported
+		return x.Managed
 	}
 	return false
 }
 
-func (x *AccessControl) GetDefaultAction() DefaultAction {
-	if x != nil {
-		return x.DefaultAction
-	}
-	return DefaultAction_DEFAULT_ACTION_UNSPECIFIED
-}
-
-// Action Restrictions section.
-type ActionRestrictions struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AllowedActions []string               `protobuf:"bytes,1,rep,name=allowed_actions,json=allowedActions,proto3" json:"allowed_actions,omitempty"` // navigate, download, upload, copy_paste
-	ReadOnlyMode   bool                   `protobuf:"varint,2,opt,name=read_only_mode,json=readOnlyMode,proto3" json:"read_only_mode,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// UrlRule allows or blocks URLs by path prefix or regex. Exactly one of path_prefix or regex is set.
+// Rules are checked before the domain and category lists; a matching block rule wins over a matching allow rule.
+type UrlRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        UrlRuleAction          `protobuf:"varint,1,opt,name=action,proto3,enum=ztcp.orgpolicyconfig.v1.UrlRuleAction" json:"action,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`                               // required with path_prefix; optional scope for regex ("*.example.com" includes subdomains)
+	PathPrefix    string                 `protobuf:"bytes,3,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"` // e.g. "/admin": matches /admin and /admin/..., not /administrator
+	Regex         string                 `protobuf:"bytes,4,opt,name=regex,proto3" json:"regex,omitempty"`                             // RE2 (max 512 chars), matched against "scheme://host/path?query"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ActionRestrictions) Reset() {
-	*x = ActionRestrictions{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[4]
+func (x *UrlRule) Reset() {
+	*x = UrlRule{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ActionRestrictions) String() string {
+func (x *UrlRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionRestrictions) ProtoMessage() {}
+func (*UrlRule) ProtoMessage() {}
 
-func (x *ActionRestrictions) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[4]
+func (x *UrlRule) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,52 +776,1362 @@ func (x *ActionRestrictions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionRestrictions.ProtoReflect.Descriptor instead.
+// Deprecated: Use UrlRule.ProtoReflect.Descriptor instead.
+func (*UrlRule) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UrlRule) GetAction() UrlRuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return UrlRuleAction_URL_RULE_ACTION_UNSPECIFIED
+}
+
+func (x *UrlRule) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *UrlRule) GetPathPrefix() string {
+	if x != nil {
+		return x.PathPrefix
+	}
+	return ""
+}
+
+func (x *UrlRule) GetRegex() string {
+	if x != nil {
+		return x.Regex
+	}
+	return ""
+}
+
+// Access Control (browser) section.
+type AccessControl struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AllowedDomains    []string               `protobuf:"bytes,1,rep,name=allowed_domains,json=allowedDomains,proto3" json:"allowed_domains,omitempty"`
+	BlockedDomains    []string               `protobuf:"bytes,2,rep,name=blocked_domains,json=blockedDomains,proto3" json:"blocked_domains,omitempty"`
+	WildcardSupported bool                   `protobuf:"varint,3,opt,name=wildcard_supported,json=wildcardSupported,proto3" json:"wildcard_supported,omitempty"`
+	DefaultAction     DefaultAction          `protobuf:"varint,4,opt,name=default_action,json=defaultAction,proto3,enum=ztcp.orgpolicyconfig.v1.DefaultAction" json:"default_action,omitempty"`
+	AllowedCategories []string               `protobuf:"bytes,5,rep,name=allowed_categories,json=allowedCategories,proto3" json:"allowed_categories,omitempty"` // managed or custom category names
+	BlockedCategories []string               `protobuf:"bytes,6,rep,name=blocked_categories,json=blockedCategories,proto3" json:"blocked_categories,omitempty"`
+	CustomCategories  []*UrlCategory         `protobuf:"bytes,7,rep,name=custom_categories,json=customCategories,proto3" json:"custom_categories,omitempty"` // org-imported lists; extend a managed category of the same name
+	UrlRules          []*UrlRule             `protobuf:"bytes,8,rep,name=url_rules,json=urlRules,proto3" json:"url_rules,omitempty"`                         // max 500
+	GroupRules        []*GroupAccessRule     `protobuf:"bytes,9,rep,name=group_rules,json=groupRules,proto3" json:"group_rules,omitempty"`                   // max 100; GetBrowserPolicy returns only the caller's groups' rules
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AccessControl) Reset() {
+	*x = AccessControl{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessControl) ProtoMessage() {}
+
+func (x *AccessControl) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessControl.ProtoReflect.Descriptor instead.
+func (*AccessControl) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AccessControl) GetAllowedDomains() []string {
+	if x != nil {
+		return x.AllowedDomains
+	}
+	return nil
+}
+
+func (x *AccessControl) GetBlockedDomains() []string {
+	if x != nil {
+		return x.BlockedDomains
+	}
+	return nil
+}
+
+func (x *AccessControl) GetWildcardSupported() bool {
+	if x != nil {
+		return x.WildcardSupported
+	}
+	return false
+}
+
+func (x *AccessControl) GetDefaultAction() DefaultAction {
+	if x != nil {
+		return x.DefaultAction
+	}
+	return DefaultAction_DEFAULT_ACTION_UNSPECIFIED
+}
+
+func (x *AccessControl) GetAllowedCategories() []string {
+	if x != nil {
+		return x.AllowedCategories
+	}
+	return nil
+}
+
+func (x *AccessControl) GetBlockedCategories() []string {
+	if x != nil {
+		return x.BlockedCategories
+	}
+	return nil
+}
+
+func (x *AccessControl) GetCustomCategories() []*UrlCategory {
+	if x != nil {
+		return x.CustomCategories
+	}
+	return nil
+}
+
+func (x *AccessControl) GetUrlRules() []*UrlRule {
+	if x != nil {
+		return x.UrlRules
+	}
+	return nil
+}
+
+func (x *AccessControl) GetGroupRules() []*GroupAccessRule {
+	if x != nil {
+		return x.GroupRules
+	}
+	return nil
+}
+
+// Domain lists for the members of one group (GroupService), checked after url_rules and before the org's lists.
+type GroupAccessRule struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Group          string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"` // group name
+	AllowedDomains []string               `protobuf:"bytes,2,rep,name=allowed_domains,json=allowedDomains,proto3" json:"allowed_domains,omitempty"`
+	BlockedDomains []string               `protobuf:"bytes,3,rep,name=blocked_domains,json=blockedDomains,proto3" json:"blocked_domains,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupAccessRule) Reset() {
+	*x = GroupAccessRule{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAccessRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAccessRule) ProtoMessage() {}
+
+func (x *GroupAccessRule) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAccessRule.ProtoReflect.Descriptor instead.
+func (*GroupAccessRule) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GroupAccessRule) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupAccessRule) GetAllowedDomains() []string {
+	if x != nil {
+		return x.AllowedDomains
+	}
+	return nil
+}
+
+func (x *GroupAccessRule) GetBlockedDomains() []string {
+	if x != nil {
+		return x.BlockedDomains
+	}
+	return nil
+}
+
+// Action Restrictions section: allowed actions and data-loss-prevention (DLP) rules, enforced by the browser agent.
+type ActionRestrictions struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	AllowedActions          []string               `protobuf:"bytes,1,rep,name=allowed_actions,json=allowedActions,proto3" json:"allowed_actions,omitempty"` // navigate, download, upload, copy_paste
+	ReadOnlyMode            bool                   `protobuf:"varint,2,opt,name=read_only_mode,json=readOnlyMode,proto3" json:"read_only_mode,omitempty"`
+	MaxUploadBytes          int64                  `protobuf:"varint,3,opt,name=max_upload_bytes,json=maxUploadBytes,proto3" json:"max_upload_bytes,omitempty"`                           // 0 = no limit
+	BlockedFileExtensions   []string               `protobuf:"bytes,4,rep,name=blocked_file_extensions,json=blockedFileExtensions,proto3" json:"blocked_file_extensions,omitempty"`       // uploads and downloads; "pdf" or ".pdf", case-insensitive
+	ClipboardBlockedDomains []string               `protobuf:"bytes,5,rep,name=clipboard_blocked_domains,json=clipboardBlockedDomains,proto3" json:"clipboard_blocked_domains,omitempty"` // copy_paste blocked on these domains and their subdomains
+	WatermarkPages          bool                   `protobuf:"varint,6,opt,name=watermark_pages,json=watermarkPages,proto3" json:"watermark_pages,omitempty"`                             // overlay the user's identity on pages
+	WatermarkDownloads      bool                   `protobuf:"varint,7,opt,name=watermark_downloads,json=watermarkDownloads,proto3" json:"watermark_downloads,omitempty"`                 // stamp downloaded documents with the user's identity
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ActionRestrictions) Reset() {
+	*x = ActionRestrictions{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionRestrictions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionRestrictions) ProtoMessage() {}
+
+func (x *ActionRestrictions) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionRestrictions.ProtoReflect.Descriptor instead.
 func (*ActionRestrictions) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{4}
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ActionRestrictions) GetAllowedActions() []string {
+	if x != nil {
+		return x.AllowedActions
+	}
+	return nil
+}
+
+func (x *ActionRestrictions) GetReadOnlyMode() bool {
+	if x != nil {
+		return x.ReadOnlyMode
+	}
+	return false
+}
+
+func (x *ActionRestrictions) GetMaxUploadBytes() int64 {
+	if x != nil {
+		return x.MaxUploadBytes
+	}
+	return 0
+}
+
+func (x *ActionRestrictions) GetBlockedFileExtensions() []string {
+	if x != nil {
+		return x.BlockedFileExtensions
+	}
+	return nil
+}
+
+func (x *ActionRestrictions) GetClipboardBlockedDomains() []string {
+	if x != nil {
+		return x.ClipboardBlockedDomains
+	}
+	return nil
+}
+
+func (x *ActionRestrictions) GetWatermarkPages() bool {
+	if x != nil {
+		return x.WatermarkPages
+	}
+	return false
+}
+
+func (x *ActionRestrictions) GetWatermarkDownloads() bool {
+	if x != nil {
+		return x.WatermarkDownloads
+	}
+	return false
+}
+
+// Notifications section.
+type Notifications struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	NewLoginAlerts        bool                   `protobuf:"varint,1,opt,name=new_login_alerts,json=newLoginAlerts,proto3" json:"new_login_alerts,omitempty"`                        // alert users on sign-in from a new device or location
+	EnforceNewLoginAlerts bool                   `protobuf:"varint,2,opt,name=enforce_new_login_alerts,json=enforceNewLoginAlerts,proto3" json:"enforce_new_login_alerts,omitempty"` // users cannot opt out
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Notifications) Reset() {
+	*x = Notifications{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notifications) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notifications) ProtoMessage() {}
+
+func (x *Notifications) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notifications.ProtoReflect.Descriptor instead.
+func (*Notifications) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Notifications) GetNewLoginAlerts() bool {
+	if x != nil {
+		return x.NewLoginAlerts
+	}
+	return false
+}
+
+func (x *Notifications) GetEnforceNewLoginAlerts() bool {
+	if x != nil {
+		return x.EnforceNewLoginAlerts
+	}
+	return false
+}
+
+// Network Access section: source-IP restrictions enforced at Login and Refresh.
+type NetworkAccess struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AllowedCidrs        []string               `protobuf:"bytes,1,rep,name=allowed_cidrs,json=allowedCidrs,proto3" json:"allowed_cidrs,omitempty"`                         // empty = any network; bare IPs allowed
+	BlockedCidrs        []string               `protobuf:"bytes,2,rep,name=blocked_cidrs,json=blockedCidrs,proto3" json:"blocked_cidrs,omitempty"`                         // checked before allowed_cidrs
+	OwnerBypass         bool                   `protobuf:"varint,3,opt,name=owner_bypass,json=ownerBypass,proto3" json:"owner_bypass,omitempty"`                           // break-glass: org owners may sign in from any network (audited)
+	TrustedCidrs        []string               `protobuf:"bytes,4,rep,name=trusted_cidrs,json=trustedCidrs,proto3" json:"trusted_cidrs,omitempty"`                         // corporate networks; MFA policies see input.network.on_trusted_network
+	ForbidMfaRelaxation bool                   `protobuf:"varint,5,opt,name=forbid_mfa_relaxation,json=forbidMfaRelaxation,proto3" json:"forbid_mfa_relaxation,omitempty"` // a trusted network may tighten MFA policy, never relax it
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *NetworkAccess) Reset() {
+	*x = NetworkAccess{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkAccess) ProtoMessage() {}
+
+func (x *NetworkAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkAccess.ProtoReflect.Descriptor instead.
+func (*NetworkAccess) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NetworkAccess) GetAllowedCidrs() []string {
+	if x != nil {
+		return x.AllowedCidrs
+	}
+	return nil
+}
+
+func (x *NetworkAccess) GetBlockedCidrs() []string {
+	if x != nil {
+		return x.BlockedCidrs
+	}
+	return nil
+}
+
+func (x *NetworkAccess) GetOwnerBypass() bool {
+	if x != nil {
+		return x.OwnerBypass
+	}
+	return false
+}
+
+func (x *NetworkAccess) GetTrustedCidrs() []string {
+	if x != nil {
+		return x.TrustedCidrs
+	}
+	return nil
+}
+
+func (x *NetworkAccess) GetForbidMfaRelaxation() bool {
+	if x != nil {
+		return x.ForbidMfaRelaxation
+	}
+	return false
+}
+
+// AccessWindow is a recurring period during which members with one of roles may sign in.
+type AccessWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []string               `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`                          // owner, admin, member; empty = all roles
+	Days          []string               `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`                            // mon..sun; empty = every day
+	StartTime     string                 `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // "HH:MM" in the schedule timezone
+	EndTime       string                 `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // "HH:MM" (exclusive; "24:00" = end of day); before start_time wraps past midnight
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessWindow) Reset() {
+	*x = AccessWindow{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessWindow) ProtoMessage() {}
+
+func (x *AccessWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessWindow.ProtoReflect.Descriptor instead.
+func (*AccessWindow) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AccessWindow) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *AccessWindow) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *AccessWindow) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *AccessWindow) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+// Access Schedule section: time-of-day / day-of-week sign-in restrictions enforced at Login and Refresh.
+type AccessSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Timezone      string                 `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA name e.g. "Europe/Berlin"; empty = UTC
+	Windows       []*AccessWindow        `protobuf:"bytes,3,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessSchedule) Reset() {
+	*x = AccessSchedule{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessSchedule) ProtoMessage() {}
+
+func (x *AccessSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessSchedule.ProtoReflect.Descriptor instead.
+func (*AccessSchedule) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AccessSchedule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *AccessSchedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *AccessSchedule) GetWindows() []*AccessWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+// Token Claims section: access-token customization for downstream services. Reserved claims (iss, sub, aud, exp,
+// nbf, iat, jti, org_id, session_id) are rejected.
+type TokenClaims struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Audiences     []string               `protobuf:"bytes,1,rep,name=audiences,proto3" json:"audiences,omitempty"`                                                                     // added to the platform audience, which is always present
+	Claims        map[string]string      `protobuf:"bytes,2,rep,name=claims,proto3" json:"claims,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // static claims added to every access token for the org
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenClaims) Reset() {
+	*x = TokenClaims{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenClaims) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenClaims) ProtoMessage() {}
+
+func (x *TokenClaims) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenClaims.ProtoReflect.Descriptor instead.
+func (*TokenClaims) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TokenClaims) GetAudiences() []string {
+	if x != nil {
+		return x.Audiences
+	}
+	return nil
+}
+
+func (x *TokenClaims) GetClaims() map[string]string {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+// Password Policy section: breached-password check on ChangePassword (Register uses the platform default).
+type PasswordPolicy struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	BreachedPasswordMode BreachedPasswordMode   `protobuf:"varint,1,opt,name=breached_password_mode,json=breachedPasswordMode,proto3,enum=ztcp.orgpolicyconfig.v1.BreachedPasswordMode" json:"breached_password_mode,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PasswordPolicy) Reset() {
+	*x = PasswordPolicy{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordPolicy) ProtoMessage() {}
+
+func (x *PasswordPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordPolicy.ProtoReflect.Descriptor instead.
+func (*PasswordPolicy) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PasswordPolicy) GetBreachedPasswordMode() BreachedPasswordMode {
+	if x != nil {
+		return x.BreachedPasswordMode
+	}
+	return BreachedPasswordMode_BREACHED_PASSWORD_MODE_UNSPECIFIED
+}
+
+// Change Approval section: when required, policy config and Rego policy changes must be proposed through
+// ChangeRequestService and approved by a second admin; direct updates fail with FAILED_PRECONDITION.
+type ChangeApproval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Required      bool                   `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeApproval) Reset() {
+	*x = ChangeApproval{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeApproval) ProtoMessage() {}
+
+func (x *ChangeApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeApproval.ProtoReflect.Descriptor instead.
+func (*ChangeApproval) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChangeApproval) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// Registration section: who may register with an email in one of the org's verified domains.
+type Registration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          RegistrationMode       `protobuf:"varint,1,opt,name=mode,proto3,enum=ztcp.orgpolicyconfig.v1.RegistrationMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Registration) Reset() {
+	*x = Registration{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Registration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Registration) ProtoMessage() {}
+
+func (x *Registration) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Registration.ProtoReflect.Descriptor instead.
+func (*Registration) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Registration) GetMode() RegistrationMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RegistrationMode_REGISTRATION_MODE_UNSPECIFIED
+}
+
+// Org policy config: all sections. Stored per org.
+type OrgPolicyConfig struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AuthMfa            *AuthMfa               `protobuf:"bytes,1,opt,name=auth_mfa,json=authMfa,proto3" json:"auth_mfa,omitempty"`
+	DeviceTrust        *DeviceTrust           `protobuf:"bytes,2,opt,name=device_trust,json=deviceTrust,proto3" json:"device_trust,omitempty"`
+	SessionMgmt        *SessionMgmt           `protobuf:"bytes,3,opt,name=session_mgmt,json=sessionMgmt,proto3" json:"session_mgmt,omitempty"`
+	AccessControl      *AccessControl         `protobuf:"bytes,4,opt,name=access_control,json=accessControl,proto3" json:"access_control,omitempty"`
+	ActionRestrictions *ActionRestrictions    `protobuf:"bytes,5,opt,name=action_restrictions,json=actionRestrictions,proto3" json:"action_restrictions,omitempty"`
+	Notifications      *Notifications         `protobuf:"bytes,6,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	NetworkAccess      *NetworkAccess         `protobuf:"bytes,7,opt,name=network_access,json=networkAccess,proto3" json:"network_access,omitempty"`
+	AccessSchedule     *AccessSchedule        `protobuf:"bytes,8,opt,name=access_schedule,json=accessSchedule,proto3" json:"access_schedule,omitempty"`
+	TokenClaims        *TokenClaims           `protobuf:"bytes,9,opt,name=token_claims,json=tokenClaims,proto3" json:"token_claims,omitempty"`
+	PasswordPolicy     *PasswordPolicy        `protobuf:"bytes,10,opt,name=password_policy,json=passwordPolicy,proto3" json:"password_policy,omitempty"`
+	ChangeApproval     *ChangeApproval        `protobuf:"bytes,11,opt,name=change_approval,json=changeApproval,proto3" json:"change_approval,omitempty"`
+	Registration       *Registration          `protobuf:"bytes,12,opt,name=registration,proto3" json:"registration,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *OrgPolicyConfig) Reset() {
+	*x = OrgPolicyConfig{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgPolicyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgPolicyConfig) ProtoMessage() {}
+
+func (x *OrgPolicyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgPolicyConfig.ProtoReflect.Descriptor instead.
+func (*OrgPolicyConfig) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OrgPolicyConfig) GetAuthMfa() *AuthMfa {
+	if x != nil {
+		return x.AuthMfa
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetDeviceTrust() *DeviceTrust {
+	if x != nil {
+		return x.DeviceTrust
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetSessionMgmt() *SessionMgmt {
+	if x != nil {
+		return x.SessionMgmt
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetAccessControl() *AccessControl {
+	if x != nil {
+		return x.AccessControl
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetActionRestrictions() *ActionRestrictions {
+	if x != nil {
+		return x.ActionRestrictions
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetNotifications() *Notifications {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetNetworkAccess() *NetworkAccess {
+	if x != nil {
+		return x.NetworkAccess
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetAccessSchedule() *AccessSchedule {
+	if x != nil {
+		return x.AccessSchedule
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetTokenClaims() *TokenClaims {
+	if x != nil {
+		return x.TokenClaims
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetPasswordPolicy() *PasswordPolicy {
+	if x != nil {
+		return x.PasswordPolicy
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetChangeApproval() *ChangeApproval {
+	if x != nil {
+		return x.ChangeApproval
+	}
+	return nil
+}
+
+func (x *OrgPolicyConfig) GetRegistration() *Registration {
+	if x != nil {
+		return x.Registration
+	}
+	return nil
+}
+
+type GetOrgPolicyConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgPolicyConfigRequest) Reset() {
+	*x = GetOrgPolicyConfigRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgPolicyConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgPolicyConfigRequest) ProtoMessage() {}
+
+func (x *GetOrgPolicyConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgPolicyConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgPolicyConfigRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetOrgPolicyConfigRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type GetOrgPolicyConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *OrgPolicyConfig       `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"` // opaque; send in UpdateOrgPolicyConfigRequest to update only this version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgPolicyConfigResponse) Reset() {
+	*x = GetOrgPolicyConfigResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgPolicyConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgPolicyConfigResponse) ProtoMessage() {}
+
+func (x *GetOrgPolicyConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgPolicyConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetOrgPolicyConfigResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetOrgPolicyConfigResponse) GetConfig() *OrgPolicyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *GetOrgPolicyConfigResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// UpdateOrgPolicyConfigRequest replaces the whole config, or only the sections named in update_mask.
+type UpdateOrgPolicyConfigRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	OrgId  string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Config *OrgPolicyConfig       `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// Top-level section names, e.g. "auth_mfa" or "access_control". Named sections are replaced by those in config
+	// (reset to defaults when config leaves them unset); other sections are kept. Empty = replace the whole config.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Optional etag from GetOrgPolicyConfig or a previous update. When set and the config has changed since, the
+	// update fails with FAILED_PRECONDITION.
+	Etag string `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional future time at which to apply the update. When set nothing changes now: the update is validated and
+	// stored as a pending ScheduledPolicyConfigChange, and the response carries the current config and etag.
+	EffectiveAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrgPolicyConfigRequest) Reset() {
+	*x = UpdateOrgPolicyConfigRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgPolicyConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgPolicyConfigRequest) ProtoMessage() {}
+
+func (x *UpdateOrgPolicyConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgPolicyConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrgPolicyConfigRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateOrgPolicyConfigRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UpdateOrgPolicyConfigRequest) GetConfig() *OrgPolicyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *UpdateOrgPolicyConfigRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateOrgPolicyConfigRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UpdateOrgPolicyConfigRequest) GetEffectiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return nil
+}
+
+type UpdateOrgPolicyConfigResponse struct {
+	state           protoimpl.MessageState       `protogen:"open.v1"`
+	Config          *OrgPolicyConfig             `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Etag            string                       `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	ScheduledChange *ScheduledPolicyConfigChange `protobuf:"bytes,3,opt,name=scheduled_change,json=scheduledChange,proto3" json:"scheduled_change,omitempty"` // set when the update was scheduled (effective_at)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateOrgPolicyConfigResponse) Reset() {
+	*x = UpdateOrgPolicyConfigResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrgPolicyConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrgPolicyConfigResponse) ProtoMessage() {}
+
+func (x *UpdateOrgPolicyConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrgPolicyConfigResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrgPolicyConfigResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateOrgPolicyConfigResponse) GetConfig() *OrgPolicyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *UpdateOrgPolicyConfigResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UpdateOrgPolicyConfigResponse) GetScheduledChange() *ScheduledPolicyConfigChange {
+	if x != nil {
+		return x.ScheduledChange
+	}
+	return nil
+}
+
+// PolicyConfigChange is a setting that differs from the previous version. Lists are reported whole.
+type PolicyConfigChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                         // dotted, e.g. "auth_mfa.mfa_requirement"
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"` // JSON
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"` // JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyConfigChange) Reset() {
+	*x = PolicyConfigChange{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyConfigChange) ProtoMessage() {}
+
+func (x *PolicyConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyConfigChange.ProtoReflect.Descriptor instead.
+func (*PolicyConfigChange) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PolicyConfigChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PolicyConfigChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *PolicyConfigChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// PolicyConfigVersion is one stored version of the org policy config.
+type PolicyConfigVersion struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Version         int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	ChangedBy       string                 `protobuf:"bytes,2,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"` // user ID of the admin; empty for versions recorded before history was kept
+	ChangedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Source          string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                                           // update, bulk_update_domains, rollback, change_request, scheduled, setup, preset
+	RestoredVersion int64                  `protobuf:"varint,5,opt,name=restored_version,json=restoredVersion,proto3" json:"restored_version,omitempty"` // for rollback, the version that was restored
+	Changes         []*PolicyConfigChange  `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`                                         // relative to the previous version (to defaults for the first one)
+	Config          *OrgPolicyConfig       `protobuf:"bytes,7,opt,name=config,proto3" json:"config,omitempty"`                                           // set only when requested with include_config
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PolicyConfigVersion) Reset() {
+	*x = PolicyConfigVersion{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyConfigVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyConfigVersion) ProtoMessage() {}
+
+func (x *PolicyConfigVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyConfigVersion.ProtoReflect.Descriptor instead.
+func (*PolicyConfigVersion) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PolicyConfigVersion) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PolicyConfigVersion) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *PolicyConfigVersion) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *PolicyConfigVersion) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PolicyConfigVersion) GetRestoredVersion() int64 {
+	if x != nil {
+		return x.RestoredVersion
+	}
+	return 0
+}
+
+func (x *PolicyConfigVersion) GetChanges() []*PolicyConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *PolicyConfigVersion) GetConfig() *OrgPolicyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type ListPolicyConfigHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Pagination    *v1.Pagination         `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	IncludeConfig bool                   `protobuf:"varint,3,opt,name=include_config,json=includeConfig,proto3" json:"include_config,omitempty"` // also return each version's full config
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ActionRestrictions) GetAllowedActions() []string {
+func (x *ListPolicyConfigHistoryRequest) Reset() {
+	*x = ListPolicyConfigHistoryRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPolicyConfigHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPolicyConfigHistoryRequest) ProtoMessage() {}
+
+func (x *ListPolicyConfigHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[24]
 	if x != nil {
-		return x.AllowedActions
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPolicyConfigHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListPolicyConfigHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListPolicyConfigHistoryRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListPolicyConfigHistoryRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
 	}
 	return nil
 }
 
-func (x *ActionRestrictions) GetReadOnlyMode() bool {
+func (x *ListPolicyConfigHistoryRequest) GetIncludeConfig() bool {
 	if x != nil {
-		return x.ReadOnlyMode
+		return x.IncludeConfig
 	}
 	return false
 }
 
-// Org policy config: all five sections. Stored per org.
-type OrgPolicyConfig struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	AuthMfa            *AuthMfa               `protobuf:"bytes,1,opt,name=auth_mfa,json=authMfa,proto3" json:"auth_mfa,omitempty"`
-	DeviceTrust        *DeviceTrust           `protobuf:"bytes,2,opt,name=device_trust,json=deviceTrust,proto3" json:"device_trust,omitempty"`
-	SessionMgmt        *SessionMgmt           `protobuf:"bytes,3,opt,name=session_mgmt,json=sessionMgmt,proto3" json:"session_mgmt,omitempty"`
-	AccessControl      *AccessControl         `protobuf:"bytes,4,opt,name=access_control,json=accessControl,proto3" json:"access_control,omitempty"`
-	ActionRestrictions *ActionRestrictions    `protobuf:"bytes,5,opt,name=action_restrictions,json=actionRestrictions,proto3" json:"action_restrictions,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// ListPolicyConfigHistoryResponse returns versions newest first.
+type ListPolicyConfigHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*PolicyConfigVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrgPolicyConfig) Reset() {
-	*x = OrgPolicyConfig{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[5]
+func (x *ListPolicyConfigHistoryResponse) Reset() {
+	*x = ListPolicyConfigHistoryResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrgPolicyConfig) String() string {
+func (x *ListPolicyConfigHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrgPolicyConfig) ProtoMessage() {}
+func (*ListPolicyConfigHistoryResponse) ProtoMessage() {}
 
-func (x *OrgPolicyConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[5]
+func (x *ListPolicyConfigHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,68 +2142,297 @@ func (x *OrgPolicyConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrgPolicyConfig.ProtoReflect.Descriptor instead.
-func (*OrgPolicyConfig) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListPolicyConfigHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListPolicyConfigHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *OrgPolicyConfig) GetAuthMfa() *AuthMfa {
+func (x *ListPolicyConfigHistoryResponse) GetVersions() []*PolicyConfigVersion {
 	if x != nil {
-		return x.AuthMfa
+		return x.Versions
 	}
 	return nil
 }
 
-func (x *OrgPolicyConfig) GetDeviceTrust() *DeviceTrust {
+func (x *ListPolicyConfigHistoryResponse) GetPagination() *v1.PaginationResult {
 	if x != nil {
-		return x.DeviceTrust
+		return x.Pagination
 	}
 	return nil
 }
 
-func (x *OrgPolicyConfig) GetSessionMgmt() *SessionMgmt {
+// RollbackPolicyConfigRequest restores a prior version. The restore is stored as a new version.
+type RollbackPolicyConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Etag          string                 `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"` // optional; as in UpdateOrgPolicyConfigRequest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackPolicyConfigRequest) Reset() {
+	*x = RollbackPolicyConfigRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackPolicyConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackPolicyConfigRequest) ProtoMessage() {}
+
+func (x *RollbackPolicyConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[26]
 	if x != nil {
-		return x.SessionMgmt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackPolicyConfigRequest.ProtoReflect.Descriptor instead.
+func (*RollbackPolicyConfigRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RollbackPolicyConfigRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RollbackPolicyConfigRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RollbackPolicyConfigRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type RollbackPolicyConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *OrgPolicyConfig       `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackPolicyConfigResponse) Reset() {
+	*x = RollbackPolicyConfigResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackPolicyConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackPolicyConfigResponse) ProtoMessage() {}
+
+func (x *RollbackPolicyConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackPolicyConfigResponse.ProtoReflect.Descriptor instead.
+func (*RollbackPolicyConfigResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RollbackPolicyConfigResponse) GetConfig() *OrgPolicyConfig {
+	if x != nil {
+		return x.Config
 	}
 	return nil
 }
 
-func (x *OrgPolicyConfig) GetAccessControl() *AccessControl {
+func (x *RollbackPolicyConfigResponse) GetEtag() string {
 	if x != nil {
-		return x.AccessControl
+		return x.Etag
+	}
+	return ""
+}
+
+// ScheduledPolicyConfigChange is an update to apply at effective_at. At that time the named sections (update_mask)
+// are applied on top of the then-current config, or the whole config is replaced when update_mask is empty.
+type ScheduledPolicyConfigChange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId          string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Config         *OrgPolicyConfig       `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	UpdateMask     *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	EffectiveAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                        // pending, applied, cancelled, failed
+	CreatedBy      string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // user ID of the admin who scheduled it
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedBy     string                 `protobuf:"bytes,9,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`               // user ID of the admin who cancelled it
+	ResolvedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`              // when it was applied, cancelled or failed
+	AppliedVersion int64                  `protobuf:"varint,11,opt,name=applied_version,json=appliedVersion,proto3" json:"applied_version,omitempty"` // config version it was stored as (applied)
+	Error          string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`                                          // why it could not be applied (failed)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScheduledPolicyConfigChange) Reset() {
+	*x = ScheduledPolicyConfigChange{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPolicyConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPolicyConfigChange) ProtoMessage() {}
+
+func (x *ScheduledPolicyConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPolicyConfigChange.ProtoReflect.Descriptor instead.
+func (*ScheduledPolicyConfigChange) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ScheduledPolicyConfigChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledPolicyConfigChange) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ScheduledPolicyConfigChange) GetConfig() *OrgPolicyConfig {
+	if x != nil {
+		return x.Config
 	}
 	return nil
 }
 
-func (x *OrgPolicyConfig) GetActionRestrictions() *ActionRestrictions {
+func (x *ScheduledPolicyConfigChange) GetUpdateMask() *fieldmaskpb.FieldMask {
 	if x != nil {
-		return x.ActionRestrictions
+		return x.UpdateMask
 	}
 	return nil
 }
 
-type GetOrgPolicyConfigRequest struct {
+func (x *ScheduledPolicyConfigChange) GetEffectiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return nil
+}
+
+func (x *ScheduledPolicyConfigChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledPolicyConfigChange) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ScheduledPolicyConfigChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ScheduledPolicyConfigChange) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *ScheduledPolicyConfigChange) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *ScheduledPolicyConfigChange) GetAppliedVersion() int64 {
+	if x != nil {
+		return x.AppliedVersion
+	}
+	return 0
+}
+
+func (x *ScheduledPolicyConfigChange) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ListScheduledPolicyConfigChangesRequest lists the org's scheduled changes by effective_at, soonest first.
+type ListScheduledPolicyConfigChangesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // optional filter: pending, applied, cancelled, failed
+	Pagination    *v1.Pagination         `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrgPolicyConfigRequest) Reset() {
-	*x = GetOrgPolicyConfigRequest{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[6]
+func (x *ListScheduledPolicyConfigChangesRequest) Reset() {
+	*x = ListScheduledPolicyConfigChangesRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrgPolicyConfigRequest) String() string {
+func (x *ListScheduledPolicyConfigChangesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrgPolicyConfigRequest) ProtoMessage() {}
+func (*ListScheduledPolicyConfigChangesRequest) ProtoMessage() {}
 
-func (x *GetOrgPolicyConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[6]
+func (x *ListScheduledPolicyConfigChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,40 +2443,55 @@ func (x *GetOrgPolicyConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrgPolicyConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetOrgPolicyConfigRequest) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListScheduledPolicyConfigChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledPolicyConfigChangesRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GetOrgPolicyConfigRequest) GetOrgId() string {
+func (x *ListScheduledPolicyConfigChangesRequest) GetOrgId() string {
 	if x != nil {
 		return x.OrgId
 	}
 	return ""
 }
 
-type GetOrgPolicyConfigResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Config        *OrgPolicyConfig       `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+func (x *ListScheduledPolicyConfigChangesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListScheduledPolicyConfigChangesRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListScheduledPolicyConfigChangesResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Changes       []*ScheduledPolicyConfigChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Pagination    *v1.PaginationResult           `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrgPolicyConfigResponse) Reset() {
-	*x = GetOrgPolicyConfigResponse{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[7]
+func (x *ListScheduledPolicyConfigChangesResponse) Reset() {
+	*x = ListScheduledPolicyConfigChangesResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrgPolicyConfigResponse) String() string {
+func (x *ListScheduledPolicyConfigChangesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrgPolicyConfigResponse) ProtoMessage() {}
+func (*ListScheduledPolicyConfigChangesResponse) ProtoMessage() {}
 
-func (x *GetOrgPolicyConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[7]
+func (x *ListScheduledPolicyConfigChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,41 +2502,49 @@ func (x *GetOrgPolicyConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrgPolicyConfigResponse.ProtoReflect.Descriptor instead.
-func (*GetOrgPolicyConfigResponse) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListScheduledPolicyConfigChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledPolicyConfigChangesResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *GetOrgPolicyConfigResponse) GetConfig() *OrgPolicyConfig {
+func (x *ListScheduledPolicyConfigChangesResponse) GetChanges() []*ScheduledPolicyConfigChange {
 	if x != nil {
-		return x.Config
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListScheduledPolicyConfigChangesResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
 	}
 	return nil
 }
 
-type UpdateOrgPolicyConfigRequest struct {
+// CancelScheduledPolicyConfigChangeRequest cancels a pending scheduled change.
+type CancelScheduledPolicyConfigChangeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
-	Config        *OrgPolicyConfig       `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrgPolicyConfigRequest) Reset() {
-	*x = UpdateOrgPolicyConfigRequest{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[8]
+func (x *CancelScheduledPolicyConfigChangeRequest) Reset() {
+	*x = CancelScheduledPolicyConfigChangeRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrgPolicyConfigRequest) String() string {
+func (x *CancelScheduledPolicyConfigChangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrgPolicyConfigRequest) ProtoMessage() {}
+func (*CancelScheduledPolicyConfigChangeRequest) ProtoMessage() {}
 
-func (x *UpdateOrgPolicyConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[8]
+func (x *CancelScheduledPolicyConfigChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,47 +2555,47 @@ func (x *UpdateOrgPolicyConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrgPolicyConfigRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrgPolicyConfigRequest) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CancelScheduledPolicyConfigChangeRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledPolicyConfigChangeRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *UpdateOrgPolicyConfigRequest) GetOrgId() string {
+func (x *CancelScheduledPolicyConfigChangeRequest) GetOrgId() string {
 	if x != nil {
 		return x.OrgId
 	}
 	return ""
 }
 
-func (x *UpdateOrgPolicyConfigRequest) GetConfig() *OrgPolicyConfig {
+func (x *CancelScheduledPolicyConfigChangeRequest) GetId() string {
 	if x != nil {
-		return x.Config
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type UpdateOrgPolicyConfigResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Config        *OrgPolicyConfig       `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+type CancelScheduledPolicyConfigChangeResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Change        *ScheduledPolicyConfigChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrgPolicyConfigResponse) Reset() {
-	*x = UpdateOrgPolicyConfigResponse{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[9]
+func (x *CancelScheduledPolicyConfigChangeResponse) Reset() {
+	*x = CancelScheduledPolicyConfigChangeResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrgPolicyConfigResponse) String() string {
+func (x *CancelScheduledPolicyConfigChangeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrgPolicyConfigResponse) ProtoMessage() {}
+func (*CancelScheduledPolicyConfigChangeResponse) ProtoMessage() {}
 
-func (x *UpdateOrgPolicyConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[9]
+func (x *CancelScheduledPolicyConfigChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -718,14 +2606,14 @@ func (x *UpdateOrgPolicyConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrgPolicyConfigResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOrgPolicyConfigResponse) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CancelScheduledPolicyConfigChangeResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledPolicyConfigChangeResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *UpdateOrgPolicyConfigResponse) GetConfig() *OrgPolicyConfig {
+func (x *CancelScheduledPolicyConfigChangeResponse) GetChange() *ScheduledPolicyConfigChange {
 	if x != nil {
-		return x.Config
+		return x.Change
 	}
 	return nil
 }
@@ -740,7 +2628,7 @@ type GetBrowserPolicyRequest struct {
 
 func (x *GetBrowserPolicyRequest) Reset() {
 	*x = GetBrowserPolicyRequest{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[10]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -752,7 +2640,7 @@ func (x *GetBrowserPolicyRequest) String() string {
 func (*GetBrowserPolicyRequest) ProtoMessage() {}
 
 func (x *GetBrowserPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[10]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,7 +2653,7 @@ func (x *GetBrowserPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBrowserPolicyRequest.ProtoReflect.Descriptor instead.
 func (*GetBrowserPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{10}
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetBrowserPolicyRequest) GetOrgId() string {
@@ -786,7 +2674,7 @@ type GetBrowserPolicyResponse struct {
 
 func (x *GetBrowserPolicyResponse) Reset() {
 	*x = GetBrowserPolicyResponse{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[11]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -798,7 +2686,7 @@ func (x *GetBrowserPolicyResponse) String() string {
 func (*GetBrowserPolicyResponse) ProtoMessage() {}
 
 func (x *GetBrowserPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[11]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,7 +2699,7 @@ func (x *GetBrowserPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBrowserPolicyResponse.ProtoReflect.Descriptor instead.
 func (*GetBrowserPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{11}
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetBrowserPolicyResponse) GetAccessControl() *AccessControl {
@@ -828,6 +2716,51 @@ func (x *GetBrowserPolicyResponse) GetActionRestrictions() *ActionRestrictions {
 	return nil
 }
 
+// SubscribeBrowserPolicyRequest opens a stream of browser policy for the caller's org.
+type SubscribeBrowserPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeBrowserPolicyRequest) Reset() {
+	*x = SubscribeBrowserPolicyRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeBrowserPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBrowserPolicyRequest) ProtoMessage() {}
+
+func (x *SubscribeBrowserPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBrowserPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBrowserPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SubscribeBrowserPolicyRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
 // CheckUrlAccessRequest asks whether a URL is allowed by org access control policy.
 type CheckUrlAccessRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -839,7 +2772,7 @@ type CheckUrlAccessRequest struct {
 
 func (x *CheckUrlAccessRequest) Reset() {
 	*x = CheckUrlAccessRequest{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[12]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -851,7 +2784,7 @@ func (x *CheckUrlAccessRequest) String() string {
 func (*CheckUrlAccessRequest) ProtoMessage() {}
 
 func (x *CheckUrlAccessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[12]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -864,7 +2797,7 @@ func (x *CheckUrlAccessRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckUrlAccessRequest.ProtoReflect.Descriptor instead.
 func (*CheckUrlAccessRequest) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{12}
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *CheckUrlAccessRequest) GetOrgId() string {
@@ -881,18 +2814,22 @@ func (x *CheckUrlAccessRequest) GetUrl() string {
 	return ""
 }
 
-// CheckUrlAccessResponse returns whether the URL is allowed and an optional reason when denied.
+// CheckUrlAccessResponse returns whether the URL is allowed and an optional reason when denied. When policy denies
+// the URL but the caller has an active URL exception for its domain (see UrlExceptionService), allowed is true and
+// exception_id and exception_expires_at name the exception.
 type CheckUrlAccessResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Allowed            bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Reason             string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExceptionId        string                 `protobuf:"bytes,3,opt,name=exception_id,json=exceptionId,proto3" json:"exception_id,omitempty"`
+	ExceptionExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=exception_expires_at,json=exceptionExpiresAt,proto3" json:"exception_expires_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *CheckUrlAccessResponse) Reset() {
 	*x = CheckUrlAccessResponse{}
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[13]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -904,7 +2841,7 @@ func (x *CheckUrlAccessResponse) String() string {
 func (*CheckUrlAccessResponse) ProtoMessage() {}
 
 func (x *CheckUrlAccessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[13]
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -917,7 +2854,7 @@ func (x *CheckUrlAccessResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckUrlAccessResponse.ProtoReflect.Descriptor instead.
 func (*CheckUrlAccessResponse) Descriptor() ([]byte, []int) {
-	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{13}
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *CheckUrlAccessResponse) GetAllowed() bool {
@@ -934,62 +2871,471 @@ func (x *CheckUrlAccessResponse) GetReason() string {
 	return ""
 }
 
+func (x *CheckUrlAccessResponse) GetExceptionId() string {
+	if x != nil {
+		return x.ExceptionId
+	}
+	return ""
+}
+
+func (x *CheckUrlAccessResponse) GetExceptionExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExceptionExpiresAt
+	}
+	return nil
+}
+
+// BulkUpdateDomainsRequest adds and removes domains in one access control list without resending the whole config.
+type BulkUpdateDomainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	List          DomainList             `protobuf:"varint,2,opt,name=list,proto3,enum=ztcp.orgpolicyconfig.v1.DomainList" json:"list,omitempty"`
+	Add           []string               `protobuf:"bytes,3,rep,name=add,proto3" json:"add,omitempty"` // URLs are accepted and reduced to their host
+	Remove        []string               `protobuf:"bytes,4,rep,name=remove,proto3" json:"remove,omitempty"`
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"` // required for DOMAIN_LIST_CUSTOM_CATEGORY
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateDomainsRequest) Reset() {
+	*x = BulkUpdateDomainsRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateDomainsRequest) ProtoMessage() {}
+
+func (x *BulkUpdateDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateDomainsRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BulkUpdateDomainsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *BulkUpdateDomainsRequest) GetList() DomainList {
+	if x != nil {
+		return x.List
+	}
+	return DomainList_DOMAIN_LIST_UNSPECIFIED
+}
+
+func (x *BulkUpdateDomainsRequest) GetAdd() []string {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *BulkUpdateDomainsRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+func (x *BulkUpdateDomainsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type BulkUpdateDomainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessControl *AccessControl         `protobuf:"bytes,1,opt,name=access_control,json=accessControl,proto3" json:"access_control,omitempty"`
+	Added         int32                  `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`     // entries not already present
+	Removed       int32                  `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"` // entries that were present
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateDomainsResponse) Reset() {
+	*x = BulkUpdateDomainsResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateDomainsResponse) ProtoMessage() {}
+
+func (x *BulkUpdateDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateDomainsResponse.ProtoReflect.Descriptor instead.
+func (*BulkUpdateDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BulkUpdateDomainsResponse) GetAccessControl() *AccessControl {
+	if x != nil {
+		return x.AccessControl
+	}
+	return nil
+}
+
+func (x *BulkUpdateDomainsResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *BulkUpdateDomainsResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type ListUrlCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUrlCategoriesRequest) Reset() {
+	*x = ListUrlCategoriesRequest{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUrlCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUrlCategoriesRequest) ProtoMessage() {}
+
+func (x *ListUrlCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUrlCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListUrlCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListUrlCategoriesRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+// ListUrlCategoriesResponse returns managed categories followed by the org's custom categories.
+type ListUrlCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*UrlCategory         `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUrlCategoriesResponse) Reset() {
+	*x = ListUrlCategoriesResponse{}
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUrlCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUrlCategoriesResponse) ProtoMessage() {}
+
+func (x *ListUrlCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUrlCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListUrlCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListUrlCategoriesResponse) GetCategories() []*UrlCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
 var File_orgpolicyconfig_orgpolicyconfig_proto protoreflect.FileDescriptor
 
 const file_orgpolicyconfig_orgpolicyconfig_proto_rawDesc = "" +
 	"\n" +
-	"%orgpolicyconfig/orgpolicyconfig.proto\x12\x17ztcp.orgpolicyconfig.v1\"\xff\x01\n" +
+	"%orgpolicyconfig/orgpolicyconfig.proto\x12\x17ztcp.orgpolicyconfig.v1\x1a\x13common/common.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xba\x03\n" +
 	"\aAuthMfa\x12P\n" +
 	"\x0fmfa_requirement\x18\x01 \x01(\x0e2'.ztcp.orgpolicyconfig.v1.MfaRequirementR\x0emfaRequirement\x12.\n" +
 	"\x13allowed_mfa_methods\x18\x02 \x03(\tR\x11allowedMfaMethods\x129\n" +
 	"\x19step_up_sensitive_actions\x18\x03 \x01(\bR\x16stepUpSensitiveActions\x127\n" +
-	"\x18step_up_policy_violation\x18\x04 \x01(\bR\x15stepUpPolicyViolation\"\xa6\x02\n" +
+	"\x18step_up_policy_violation\x18\x04 \x01(\bR\x15stepUpPolicyViolation\x12[\n" +
+	"\x12group_requirements\x18\x05 \x03(\v2,.ztcp.orgpolicyconfig.v1.GroupMfaRequirementR\x11groupRequirements\x12,\n" +
+	"\x12magic_link_enabled\x18\x06 \x01(\bR\x10magicLinkEnabled\x12.\n" +
+	"\x13policy_failure_mode\x18\a \x01(\tR\x11policyFailureMode\"}\n" +
+	"\x13GroupMfaRequirement\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12P\n" +
+	"\x0fmfa_requirement\x18\x02 \x01(\x0e2'.ztcp.orgpolicyconfig.v1.MfaRequirementR\x0emfaRequirement\"\xb7\x03\n" +
 	"\vDeviceTrust\x12>\n" +
 	"\x1bdevice_registration_allowed\x18\x01 \x01(\bR\x19deviceRegistrationAllowed\x12/\n" +
 	"\x14auto_trust_after_mfa\x18\x02 \x01(\bR\x11autoTrustAfterMfa\x12>\n" +
 	"\x1cmax_trusted_devices_per_user\x18\x03 \x01(\x05R\x18maxTrustedDevicesPerUser\x124\n" +
 	"\x16reverify_interval_days\x18\x04 \x01(\x05R\x14reverifyIntervalDays\x120\n" +
-	"\x14admin_revoke_allowed\x18\x05 \x01(\bR\x12adminRevokeAllowed\"\xf9\x01\n" +
+	"\x14admin_revoke_allowed\x18\x05 \x01(\bR\x12adminRevokeAllowed\x12<\n" +
+	"\x1bkeep_trust_on_factor_change\x18\x06 \x01(\bR\x17keepTrustOnFactorChange\x12#\n" +
+	"\rtrust_renewal\x18\a \x01(\tR\ftrustRenewal\x12,\n" +
+	"\x12expiry_notice_days\x18\b \x01(\x05R\x10expiryNoticeDays\"\xc8\x02\n" +
 	"\vSessionMgmt\x12&\n" +
 	"\x0fsession_max_ttl\x18\x01 \x01(\tR\rsessionMaxTtl\x12!\n" +
 	"\fidle_timeout\x18\x02 \x01(\tR\vidleTimeout\x128\n" +
 	"\x18concurrent_session_limit\x18\x03 \x01(\x05R\x16concurrentSessionLimit\x12.\n" +
 	"\x13admin_forced_logout\x18\x04 \x01(\bR\x11adminForcedLogout\x125\n" +
-	"\x17reauth_on_policy_change\x18\x05 \x01(\bR\x14reauthOnPolicyChange\"\xdf\x01\n" +
+	"\x17reauth_on_policy_change\x18\x05 \x01(\bR\x14reauthOnPolicyChange\x12%\n" +
+	"\x0erefresh_expiry\x18\x06 \x01(\tR\rrefreshExpiry\x12&\n" +
+	"\x0fmax_session_age\x18\a \x01(\tR\rmaxSessionAge\"U\n" +
+	"\vUrlCategory\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\adomains\x18\x02 \x03(\tR\adomains\x12\x18\n" +
+	"\amanaged\x18\x03 \x01(\bR\amanaged\"\x94\x01\n" +
+	"\aUrlRule\x12>\n" +
+	"\x06action\x18\x01 \x01(\x0e2&.ztcp.orgpolicyconfig.v1.UrlRuleActionR\x06action\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x1f\n" +
+	"\vpath_prefix\x18\x03 \x01(\tR\n" +
+	"pathPrefix\x12\x14\n" +
+	"\x05regex\x18\x04 \x01(\tR\x05regex\"\x9a\x04\n" +
 	"\rAccessControl\x12'\n" +
 	"\x0fallowed_domains\x18\x01 \x03(\tR\x0eallowedDomains\x12'\n" +
 	"\x0fblocked_domains\x18\x02 \x03(\tR\x0eblockedDomains\x12-\n" +
 	"\x12wildcard_supported\x18\x03 \x01(\bR\x11wildcardSupported\x12M\n" +
-	"\x0edefault_action\x18\x04 \x01(\x0e2&.ztcp.orgpolicyconfig.v1.DefaultActionR\rdefaultAction\"c\n" +
+	"\x0edefault_action\x18\x04 \x01(\x0e2&.ztcp.orgpolicyconfig.v1.DefaultActionR\rdefaultAction\x12-\n" +
+	"\x12allowed_categories\x18\x05 \x03(\tR\x11allowedCategories\x12-\n" +
+	"\x12blocked_categories\x18\x06 \x03(\tR\x11blockedCategories\x12Q\n" +
+	"\x11custom_categories\x18\a \x03(\v2$.ztcp.orgpolicyconfig.v1.UrlCategoryR\x10customCategories\x12=\n" +
+	"\turl_rules\x18\b \x03(\v2 .ztcp.orgpolicyconfig.v1.UrlRuleR\burlRules\x12I\n" +
+	"\vgroup_rules\x18\t \x03(\v2(.ztcp.orgpolicyconfig.v1.GroupAccessRuleR\n" +
+	"groupRules\"y\n" +
+	"\x0fGroupAccessRule\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12'\n" +
+	"\x0fallowed_domains\x18\x02 \x03(\tR\x0eallowedDomains\x12'\n" +
+	"\x0fblocked_domains\x18\x03 \x03(\tR\x0eblockedDomains\"\xdb\x02\n" +
 	"\x12ActionRestrictions\x12'\n" +
 	"\x0fallowed_actions\x18\x01 \x03(\tR\x0eallowedActions\x12$\n" +
-	"\x0eread_only_mode\x18\x02 \x01(\bR\freadOnlyMode\"\x8d\x03\n" +
+	"\x0eread_only_mode\x18\x02 \x01(\bR\freadOnlyMode\x12(\n" +
+	"\x10max_upload_bytes\x18\x03 \x01(\x03R\x0emaxUploadBytes\x126\n" +
+	"\x17blocked_file_extensions\x18\x04 \x03(\tR\x15blockedFileExtensions\x12:\n" +
+	"\x19clipboard_blocked_domains\x18\x05 \x03(\tR\x17clipboardBlockedDomains\x12'\n" +
+	"\x0fwatermark_pages\x18\x06 \x01(\bR\x0ewatermarkPages\x12/\n" +
+	"\x13watermark_downloads\x18\a \x01(\bR\x12watermarkDownloads\"r\n" +
+	"\rNotifications\x12(\n" +
+	"\x10new_login_alerts\x18\x01 \x01(\bR\x0enewLoginAlerts\x127\n" +
+	"\x18enforce_new_login_alerts\x18\x02 \x01(\bR\x15enforceNewLoginAlerts\"\xd5\x01\n" +
+	"\rNetworkAccess\x12#\n" +
+	"\rallowed_cidrs\x18\x01 \x03(\tR\fallowedCidrs\x12#\n" +
+	"\rblocked_cidrs\x18\x02 \x03(\tR\fblockedCidrs\x12!\n" +
+	"\fowner_bypass\x18\x03 \x01(\bR\vownerBypass\x12#\n" +
+	"\rtrusted_cidrs\x18\x04 \x03(\tR\ftrustedCidrs\x122\n" +
+	"\x15forbid_mfa_relaxation\x18\x05 \x01(\bR\x13forbidMfaRelaxation\"r\n" +
+	"\fAccessWindow\x12\x14\n" +
+	"\x05roles\x18\x01 \x03(\tR\x05roles\x12\x12\n" +
+	"\x04days\x18\x02 \x03(\tR\x04days\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\tR\aendTime\"\x87\x01\n" +
+	"\x0eAccessSchedule\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\x12?\n" +
+	"\awindows\x18\x03 \x03(\v2%.ztcp.orgpolicyconfig.v1.AccessWindowR\awindows\"\xb0\x01\n" +
+	"\vTokenClaims\x12\x1c\n" +
+	"\taudiences\x18\x01 \x03(\tR\taudiences\x12H\n" +
+	"\x06claims\x18\x02 \x03(\v20.ztcp.orgpolicyconfig.v1.TokenClaims.ClaimsEntryR\x06claims\x1a9\n" +
+	"\vClaimsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"u\n" +
+	"\x0ePasswordPolicy\x12c\n" +
+	"\x16breached_password_mode\x18\x01 \x01(\x0e2-.ztcp.orgpolicyconfig.v1.BreachedPasswordModeR\x14breachedPasswordMode\",\n" +
+	"\x0eChangeApproval\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\"M\n" +
+	"\fRegistration\x12=\n" +
+	"\x04mode\x18\x01 \x01(\x0e2).ztcp.orgpolicyconfig.v1.RegistrationModeR\x04mode\"\xb4\a\n" +
 	"\x0fOrgPolicyConfig\x12;\n" +
 	"\bauth_mfa\x18\x01 \x01(\v2 .ztcp.orgpolicyconfig.v1.AuthMfaR\aauthMfa\x12G\n" +
 	"\fdevice_trust\x18\x02 \x01(\v2$.ztcp.orgpolicyconfig.v1.DeviceTrustR\vdeviceTrust\x12G\n" +
 	"\fsession_mgmt\x18\x03 \x01(\v2$.ztcp.orgpolicyconfig.v1.SessionMgmtR\vsessionMgmt\x12M\n" +
 	"\x0eaccess_control\x18\x04 \x01(\v2&.ztcp.orgpolicyconfig.v1.AccessControlR\raccessControl\x12\\\n" +
-	"\x13action_restrictions\x18\x05 \x01(\v2+.ztcp.orgpolicyconfig.v1.ActionRestrictionsR\x12actionRestrictions\"2\n" +
+	"\x13action_restrictions\x18\x05 \x01(\v2+.ztcp.orgpolicyconfig.v1.ActionRestrictionsR\x12actionRestrictions\x12L\n" +
+	"\rnotifications\x18\x06 \x01(\v2&.ztcp.orgpolicyconfig.v1.NotificationsR\rnotifications\x12M\n" +
+	"\x0enetwork_access\x18\a \x01(\v2&.ztcp.orgpolicyconfig.v1.NetworkAccessR\rnetworkAccess\x12P\n" +
+	"\x0faccess_schedule\x18\b \x01(\v2'.ztcp.orgpolicyconfig.v1.AccessScheduleR\x0eaccessSchedule\x12G\n" +
+	"\ftoken_claims\x18\t \x01(\v2$.ztcp.orgpolicyconfig.v1.TokenClaimsR\vtokenClaims\x12P\n" +
+	"\x0fpassword_policy\x18\n" +
+	" \x01(\v2'.ztcp.orgpolicyconfig.v1.PasswordPolicyR\x0epasswordPolicy\x12P\n" +
+	"\x0fchange_approval\x18\v \x01(\v2'.ztcp.orgpolicyconfig.v1.ChangeApprovalR\x0echangeApproval\x12I\n" +
+	"\fregistration\x18\f \x01(\v2%.ztcp.orgpolicyconfig.v1.RegistrationR\fregistration\"2\n" +
 	"\x19GetOrgPolicyConfigRequest\x12\x15\n" +
-	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"^\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"r\n" +
 	"\x1aGetOrgPolicyConfigResponse\x12@\n" +
-	"\x06config\x18\x01 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\"w\n" +
+	"\x06config\x18\x01 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"\x87\x02\n" +
 	"\x1cUpdateOrgPolicyConfigRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12@\n" +
-	"\x06config\x18\x02 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\"a\n" +
+	"\x06config\x18\x02 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04etag\x18\x04 \x01(\tR\x04etag\x12=\n" +
+	"\feffective_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveAt\"\xd6\x01\n" +
 	"\x1dUpdateOrgPolicyConfigResponse\x12@\n" +
-	"\x06config\x18\x01 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\"0\n" +
+	"\x06config\x18\x01 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12_\n" +
+	"\x10scheduled_change\x18\x03 \x01(\v24.ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChangeR\x0fscheduledChange\"b\n" +
+	"\x12PolicyConfigChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"\xd5\x02\n" +
+	"\x13PolicyConfigVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x02 \x01(\tR\tchangedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12)\n" +
+	"\x10restored_version\x18\x05 \x01(\x03R\x0frestoredVersion\x12E\n" +
+	"\achanges\x18\x06 \x03(\v2+.ztcp.orgpolicyconfig.v1.PolicyConfigChangeR\achanges\x12@\n" +
+	"\x06config\x18\a \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\"\x9a\x01\n" +
+	"\x1eListPolicyConfigHistoryRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12:\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\x12%\n" +
+	"\x0einclude_config\x18\x03 \x01(\bR\rincludeConfig\"\xad\x01\n" +
+	"\x1fListPolicyConfigHistoryResponse\x12H\n" +
+	"\bversions\x18\x01 \x03(\v2,.ztcp.orgpolicyconfig.v1.PolicyConfigVersionR\bversions\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"b\n" +
+	"\x1bRollbackPolicyConfigRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"t\n" +
+	"\x1cRollbackPolicyConfigResponse\x12@\n" +
+	"\x06config\x18\x01 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"\x91\x04\n" +
+	"\x1bScheduledPolicyConfigChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12@\n" +
+	"\x06config\x18\x03 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12=\n" +
+	"\feffective_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vresolved_by\x18\t \x01(\tR\n" +
+	"resolvedBy\x12;\n" +
+	"\vresolved_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12'\n" +
+	"\x0fapplied_version\x18\v \x01(\x03R\x0eappliedVersion\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\"\x94\x01\n" +
+	"'ListScheduledPolicyConfigChangesRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12:\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\xbc\x01\n" +
+	"(ListScheduledPolicyConfigChangesResponse\x12N\n" +
+	"\achanges\x18\x01 \x03(\v24.ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChangeR\achanges\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"Q\n" +
+	"(CancelScheduledPolicyConfigChangeRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"y\n" +
+	")CancelScheduledPolicyConfigChangeResponse\x12L\n" +
+	"\x06change\x18\x01 \x01(\v24.ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChangeR\x06change\"0\n" +
 	"\x17GetBrowserPolicyRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"\xc7\x01\n" +
 	"\x18GetBrowserPolicyResponse\x12M\n" +
 	"\x0eaccess_control\x18\x01 \x01(\v2&.ztcp.orgpolicyconfig.v1.AccessControlR\raccessControl\x12\\\n" +
-	"\x13action_restrictions\x18\x02 \x01(\v2+.ztcp.orgpolicyconfig.v1.ActionRestrictionsR\x12actionRestrictions\"@\n" +
+	"\x13action_restrictions\x18\x02 \x01(\v2+.ztcp.orgpolicyconfig.v1.ActionRestrictionsR\x12actionRestrictions\"6\n" +
+	"\x1dSubscribeBrowserPolicyRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"@\n" +
 	"\x15CheckUrlAccessRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x10\n" +
-	"\x03url\x18\x02 \x01(\tR\x03url\"J\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\xbb\x01\n" +
 	"\x16CheckUrlAccessResponse\x12\x18\n" +
 	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason*\x8c\x01\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12!\n" +
+	"\fexception_id\x18\x03 \x01(\tR\vexceptionId\x12L\n" +
+	"\x14exception_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x12exceptionExpiresAt\"\xb0\x01\n" +
+	"\x18BulkUpdateDomainsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x127\n" +
+	"\x04list\x18\x02 \x01(\x0e2#.ztcp.orgpolicyconfig.v1.DomainListR\x04list\x12\x10\n" +
+	"\x03add\x18\x03 \x03(\tR\x03add\x12\x16\n" +
+	"\x06remove\x18\x04 \x03(\tR\x06remove\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\"\x9a\x01\n" +
+	"\x19BulkUpdateDomainsResponse\x12M\n" +
+	"\x0eaccess_control\x18\x01 \x01(\v2&.ztcp.orgpolicyconfig.v1.AccessControlR\raccessControl\x12\x14\n" +
+	"\x05added\x18\x02 \x01(\x05R\x05added\x12\x18\n" +
+	"\aremoved\x18\x03 \x01(\x05R\aremoved\"1\n" +
+	"\x18ListUrlCategoriesRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"a\n" +
+	"\x19ListUrlCategoriesResponse\x12D\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2$.ztcp.orgpolicyconfig.v1.UrlCategoryR\n" +
+	"categories*\x8c\x01\n" +
 	"\x0eMfaRequirement\x12\x1f\n" +
 	"\x1bMFA_REQUIREMENT_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16MFA_REQUIREMENT_ALWAYS\x10\x01\x12\x1e\n" +
@@ -998,12 +3344,39 @@ const file_orgpolicyconfig_orgpolicyconfig_proto_rawDesc = "" +
 	"\rDefaultAction\x12\x1e\n" +
 	"\x1aDEFAULT_ACTION_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14DEFAULT_ACTION_ALLOW\x10\x01\x12\x17\n" +
-	"\x13DEFAULT_ACTION_DENY\x10\x022\x8c\x04\n" +
-	"\x16OrgPolicyConfigService\x12}\n" +
-	"\x12GetOrgPolicyConfig\x122.ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigRequest\x1a3.ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse\x12\x86\x01\n" +
-	"\x15UpdateOrgPolicyConfig\x125.ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest\x1a6.ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse\x12w\n" +
-	"\x10GetBrowserPolicy\x120.ztcp.orgpolicyconfig.v1.GetBrowserPolicyRequest\x1a1.ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse\x12q\n" +
-	"\x0eCheckUrlAccess\x12..ztcp.orgpolicyconfig.v1.CheckUrlAccessRequest\x1a/.ztcp.orgpolicyconfig.v1.CheckUrlAccessResponseBUZSzero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1;orgpolicyconfigv1b\x06proto3"
+	"\x13DEFAULT_ACTION_DENY\x10\x02*f\n" +
+	"\rUrlRuleAction\x12\x1f\n" +
+	"\x1bURL_RULE_ACTION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15URL_RULE_ACTION_ALLOW\x10\x01\x12\x19\n" +
+	"\x15URL_RULE_ACTION_BLOCK\x10\x02*\xa1\x01\n" +
+	"\x14BreachedPasswordMode\x12&\n" +
+	"\"BREACHED_PASSWORD_MODE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aBREACHED_PASSWORD_MODE_OFF\x10\x01\x12\x1f\n" +
+	"\x1bBREACHED_PASSWORD_MODE_WARN\x10\x02\x12 \n" +
+	"\x1cBREACHED_PASSWORD_MODE_BLOCK\x10\x03*\x92\x01\n" +
+	"\x10RegistrationMode\x12!\n" +
+	"\x1dREGISTRATION_MODE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16REGISTRATION_MODE_OPEN\x10\x01\x12!\n" +
+	"\x1dREGISTRATION_MODE_INVITE_ONLY\x10\x02\x12\x1c\n" +
+	"\x18REGISTRATION_MODE_CLOSED\x10\x03*|\n" +
+	"\n" +
+	"DomainList\x12\x1b\n" +
+	"\x17DOMAIN_LIST_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13DOMAIN_LIST_ALLOWED\x10\x01\x12\x17\n" +
+	"\x13DOMAIN_LIST_BLOCKED\x10\x02\x12\x1f\n" +
+	"\x1bDOMAIN_LIST_CUSTOM_CATEGORY\x10\x032\x9c\f\n" +
+	"\x16OrgPolicyConfigService\x12\x82\x01\n" +
+	"\x12GetOrgPolicyConfig\x122.ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigRequest\x1a3.ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse\"\x03\x90\x02\x01\x12\x86\x01\n" +
+	"\x15UpdateOrgPolicyConfig\x125.ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest\x1a6.ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse\x12\x91\x01\n" +
+	"\x17ListPolicyConfigHistory\x127.ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryRequest\x1a8.ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryResponse\"\x03\x90\x02\x01\x12\x83\x01\n" +
+	"\x14RollbackPolicyConfig\x124.ztcp.orgpolicyconfig.v1.RollbackPolicyConfigRequest\x1a5.ztcp.orgpolicyconfig.v1.RollbackPolicyConfigResponse\x12\xac\x01\n" +
+	" ListScheduledPolicyConfigChanges\x12@.ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesRequest\x1aA.ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesResponse\"\x03\x90\x02\x01\x12\xaa\x01\n" +
+	"!CancelScheduledPolicyConfigChange\x12A.ztcp.orgpolicyconfig.v1.CancelScheduledPolicyConfigChangeRequest\x1aB.ztcp.orgpolicyconfig.v1.CancelScheduledPolicyConfigChangeResponse\x12|\n" +
+	"\x10GetBrowserPolicy\x120.ztcp.orgpolicyconfig.v1.GetBrowserPolicyRequest\x1a1.ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse\"\x03\x90\x02\x01\x12\x8a\x01\n" +
+	"\x16SubscribeBrowserPolicy\x126.ztcp.orgpolicyconfig.v1.SubscribeBrowserPolicyRequest\x1a1.ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse\"\x03\x90\x02\x010\x01\x12v\n" +
+	"\x0eCheckUrlAccess\x12..ztcp.orgpolicyconfig.v1.CheckUrlAccessRequest\x1a/.ztcp.orgpolicyconfig.v1.CheckUrlAccessResponse\"\x03\x90\x02\x01\x12z\n" +
+	"\x11BulkUpdateDomains\x121.ztcp.orgpolicyconfig.v1.BulkUpdateDomainsRequest\x1a2.ztcp.orgpolicyconfig.v1.BulkUpdateDomainsResponse\x12\x7f\n" +
+	"\x11ListUrlCategories\x121.ztcp.orgpolicyconfig.v1.ListUrlCategoriesRequest\x1a2.ztcp.orgpolicyconfig.v1.ListUrlCategoriesResponse\"\x03\x90\x02\x01BUZSzero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1;orgpolicyconfigv1b\x06proto3"
 
 var (
 	file_orgpolicyconfig_orgpolicyconfig_proto_rawDescOnce sync.Once
@@ -1017,52 +3390,143 @@ func file_orgpolicyconfig_orgpolicyconfig_proto_rawDescGZIP() []byte {
 	return file_orgpolicyconfig_orgpolicyconfig_proto_rawDescData
 }
 
-var file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_orgpolicyconfig_orgpolicyconfig_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_orgpolicyconfig_orgpolicyconfig_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_orgpolicyconfig_orgpolicyconfig_proto_goTypes = []any{
-	(MfaRequirement)(0),                   // 0: ztcp.orgpolicyconfig.v1.MfaRequirement
-	(DefaultAction)(0),                    // 1: ztcp.orgpolicyconfig.v1.DefaultAction
-	(*AuthMfa)(nil),                       // 2: ztcp.orgpolicyconfig.v1.AuthMfa
-	(*DeviceTrust)(nil),                   // 3: ztcp.orgpolicyconfig.v1.DeviceTrust
-	(*SessionMgmt)(nil),                   // 4: ztcp.orgpolicyconfig.v1.SessionMgmt
-	(*AccessControl)(nil),                 // 5: ztcp.orgpolicyconfig.v1.AccessControl
-	(*ActionRestrictions)(nil),            // 6: ztcp.orgpolicyconfig.v1.ActionRestrictions
-	(*OrgPolicyConfig)(nil),               // 7: ztcp.orgpolicyconfig.v1.OrgPolicyConfig
-	(*GetOrgPolicyConfigRequest)(nil),     // 8: ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigRequest
-	(*GetOrgPolicyConfigResponse)(nil),    // 9: ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse
-	(*UpdateOrgPolicyConfigRequest)(nil),  // 10: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest
-	(*UpdateOrgPolicyConfigResponse)(nil), // 11: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse
-	(*GetBrowserPolicyRequest)(nil),       // 12: ztcp.orgpolicyconfig.v1.GetBrowserPolicyRequest
-	(*GetBrowserPolicyResponse)(nil),      // 13: ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse
-	(*CheckUrlAccessRequest)(nil),         // 14: ztcp.orgpolicyconfig.v1.CheckUrlAccessRequest
-	(*CheckUrlAccessResponse)(nil),        // 15: ztcp.orgpolicyconfig.v1.CheckUrlAccessResponse
+	(MfaRequirement)(0),                               // 0: ztcp.orgpolicyconfig.v1.MfaRequirement
+	(DefaultAction)(0),                                // 1: ztcp.orgpolicyconfig.v1.DefaultAction
+	(UrlRuleAction)(0),                                // 2: ztcp.orgpolicyconfig.v1.UrlRuleAction
+	(BreachedPasswordMode)(0),                         // 3: ztcp.orgpolicyconfig.v1.BreachedPasswordMode
+	(RegistrationMode)(0),                             // 4: ztcp.orgpolicyconfig.v1.RegistrationMode
+	(DomainList)(0),                                   // 5: ztcp.orgpolicyconfig.v1.DomainList
+	(*AuthMfa)(nil),                                   // 6: ztcp.orgpolicyconfig.v1.AuthMfa
+	(*GroupMfaRequirement)(nil),                       // 7: ztcp.orgpolicyconfig.v1.GroupMfaRequirement
+	(*DeviceTrust)(nil),                               // 8: ztcp.orgpolicyconfig.v1.DeviceTrust
+	(*SessionMgmt)(nil),                               // 9: ztcp.orgpolicyconfig.v1.SessionMgmt
+	(*UrlCategory)(nil),                               // 10: ztcp.orgpolicyconfig.v1.UrlCategory
+	(*UrlRule)(nil),                                   // 11: ztcp.orgpolicyconfig.v1.UrlRule
+	(*AccessControl)(nil),                             // 12: ztcp.orgpolicyconfig.v1.AccessControl
+	(*GroupAccessRule)(nil),                           // 13: ztcp.orgpolicyconfig.v1.GroupAccessRule
+	(*ActionRestrictions)(nil),                        // 14: ztcp.orgpolicyconfig.v1.ActionRestrictions
+	(*Notifications)(nil),                             // 15: ztcp.orgpolicyconfig.v1.Notifications
+	(*NetworkAccess)(nil),                             // 16: ztcp.orgpolicyconfig.v1.NetworkAccess
+	(*AccessWindow)(nil),                              // 17: ztcp.orgpolicyconfig.v1.AccessWindow
+	(*AccessSchedule)(nil),                            // 18: ztcp.orgpolicyconfig.v1.AccessSchedule
+	(*TokenClaims)(nil),                               // 19: ztcp.orgpolicyconfig.v1.TokenClaims
+	(*PasswordPolicy)(nil),                            // 20: ztcp.orgpolicyconfig.v1.PasswordPolicy
+	(*ChangeApproval)(nil),                            // 21: ztcp.orgpolicyconfig.v1.ChangeApproval
+	(*Registration)(nil),                              // 22: ztcp.orgpolicyconfig.v1.Registration
+	(*OrgPolicyConfig)(nil),                           // 23: ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	(*GetOrgPolicyConfigRequest)(nil),                 // 24: ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigRequest
+	(*GetOrgPolicyConfigResponse)(nil),                // 25: ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse
+	(*UpdateOrgPolicyConfigRequest)(nil),              // 26: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest
+	(*UpdateOrgPolicyConfigResponse)(nil),             // 27: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse
+	(*PolicyConfigChange)(nil),                        // 28: ztcp.orgpolicyconfig.v1.PolicyConfigChange
+	(*PolicyConfigVersion)(nil),                       // 29: ztcp.orgpolicyconfig.v1.PolicyConfigVersion
+	(*ListPolicyConfigHistoryRequest)(nil),            // 30: ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryRequest
+	(*ListPolicyConfigHistoryResponse)(nil),           // 31: ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryResponse
+	(*RollbackPolicyConfigRequest)(nil),               // 32: ztcp.orgpolicyconfig.v1.RollbackPolicyConfigRequest
+	(*RollbackPolicyConfigResponse)(nil),              // 33: ztcp.orgpolicyconfig.v1.RollbackPolicyConfigResponse
+	(*ScheduledPolicyConfigChange)(nil),               // 34: ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange
+	(*ListScheduledPolicyConfigChangesRequest)(nil),   // 35: ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesRequest
+	(*ListScheduledPolicyConfigChangesResponse)(nil),  // 36: ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesResponse
+	(*CancelScheduledPolicyConfigChangeRequest)(nil),  // 37: ztcp.orgpolicyconfig.v1.CancelScheduledPolicyConfigChangeRequest
+	(*CancelScheduledPolicyConfigChangeResponse)(nil), // 38: ztcp.orgpolicyconfig.v1.CancelScheduledPolicyConfigChangeResponse
+	(*GetBrowserPolicyRequest)(nil),                   // 39: ztcp.orgpolicyconfig.v1.GetBrowserPolicyRequest
+	(*GetBrowserPolicyResponse)(nil),                  // 40: ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse
+	(*SubscribeBrowserPolicyRequest)(nil),             // 41: ztcp.orgpolicyconfig.v1.SubscribeBrowserPolicyRequest
+	(*CheckUrlAccessRequest)(nil),                     // 42: ztcp.orgpolicyconfig.v1.CheckUrlAccessRequest
+	(*CheckUrlAccessResponse)(nil),                    // 43: ztcp.orgpolicyconfig.v1.CheckUrlAccessResponse
+	(*BulkUpdateDomainsRequest)(nil),                  // 44: ztcp.orgpolicyconfig.v1.BulkUpdateDomainsRequest
+	(*BulkUpdateDomainsResponse)(nil),                 // 45: ztcp.orgpolicyconfig.v1.BulkUpdateDomainsResponse
+	(*ListUrlCategoriesRequest)(nil),                  // 46: ztcp.orgpolicyconfig.v1.ListUrlCategoriesRequest
+	(*ListUrlCategoriesResponse)(nil),                 // 47: ztcp.orgpolicyconfig.v1.ListUrlCategoriesResponse
+	nil,                                               // 48: ztcp.orgpolicyconfig.v1.TokenClaims.ClaimsEntry
+	(*fieldmaskpb.FieldMask)(nil),                     // 49: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil),                     // 50: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),                             // 51: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),                       // 52: ztcp.common.v1.PaginationResult
 }
 var file_orgpolicyconfig_orgpolicyconfig_proto_depIdxs = []int32{
 	0,  // 0: ztcp.orgpolicyconfig.v1.AuthMfa.mfa_requirement:type_name -> ztcp.orgpolicyconfig.v1.MfaRequirement
-	1,  // 1: ztcp.orgpolicyconfig.v1.AccessControl.default_action:type_name -> ztcp.orgpolicyconfig.v1.DefaultAction
-	2,  // 2: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.auth_mfa:type_name -> ztcp.orgpolicyconfig.v1.AuthMfa
-	3,  // 3: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.device_trust:type_name -> ztcp.orgpolicyconfig.v1.DeviceTrust
-	4,  // 4: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.session_mgmt:type_name -> ztcp.orgpolicyconfig.v1.SessionMgmt
-	5,  // 5: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.access_control:type_name -> ztcp.orgpolicyconfig.v1.AccessControl
-	6,  // 6: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.action_restrictions:type_name -> ztcp.orgpolicyconfig.v1.ActionRestrictions
-	7,  // 7: ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
-	7,  // 8: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
-	7,  // 9: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
-	5,  // 10: ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse.access_control:type_name -> ztcp.orgpolicyconfig.v1.AccessControl
-	6,  // 11: ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse.action_restrictions:type_name -> ztcp.orgpolicyconfig.v1.ActionRestrictions
-	8,  // 12: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetOrgPolicyConfig:input_type -> ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigRequest
-	10, // 13: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.UpdateOrgPolicyConfig:input_type -> ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest
-	12, // 14: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetBrowserPolicy:input_type -> ztcp.orgpolicyconfig.v1.GetBrowserPolicyRequest
-	14, // 15: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.CheckUrlAccess:input_type -> ztcp.orgpolicyconfig.v1.CheckUrlAccessRequest
-	9,  // 16: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetOrgPolicyConfig:output_type -> ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse
-	11, // 17: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.UpdateOrgPolicyConfig:output_type -> ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse
-	13, // 18: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetBrowserPolicy:output_type -> ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse
-	15, // 19: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.CheckUrlAccess:output_type -> ztcp.orgpolicyconfig.v1.CheckUrlAccessResponse
-	16, // [16:20] is the sub-list for method output_type
-	12, // [12:16] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	7,  // 1: ztcp.orgpolicyconfig.v1.AuthMfa.group_requirements:type_name -> ztcp.orgpolicyconfig.v1.GroupMfaRequirement
+	0,  // 2: ztcp.orgpolicyconfig.v1.GroupMfaRequirement.mfa_requirement:type_name -> ztcp.orgpolicyconfig.v1.MfaRequirement
+	2,  // 3: ztcp.orgpolicyconfig.v1.UrlRule.action:type_name -> ztcp.orgpolicyconfig.v1.UrlRuleAction
+	1,  // 4: ztcp.orgpolicyconfig.v1.AccessControl.default_action:type_name -> ztcp.orgpolicyconfig.v1.DefaultAction
+	10, // 5: ztcp.orgpolicyconfig.v1.AccessControl.custom_categories:type_name -> ztcp.orgpolicyconfig.v1.UrlCategory
+	11, // 6: ztcp.orgpolicyconfig.v1.AccessControl.url_rules:type_name -> ztcp.orgpolicyconfig.v1.UrlRule
+	13, // 7: ztcp.orgpolicyconfig.v1.AccessControl.group_rules:type_name -> ztcp.orgpolicyconfig.v1.GroupAccessRule
+	17, // 8: ztcp.orgpolicyconfig.v1.AccessSchedule.windows:type_name -> ztcp.orgpolicyconfig.v1.AccessWindow
+	48, // 9: ztcp.orgpolicyconfig.v1.TokenClaims.claims:type_name -> ztcp.orgpolicyconfig.v1.TokenClaims.ClaimsEntry
+	3,  // 10: ztcp.orgpolicyconfig.v1.PasswordPolicy.breached_password_mode:type_name -> ztcp.orgpolicyconfig.v1.BreachedPasswordMode
+	4,  // 11: ztcp.orgpolicyconfig.v1.Registration.mode:type_name -> ztcp.orgpolicyconfig.v1.RegistrationMode
+	6,  // 12: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.auth_mfa:type_name -> ztcp.orgpolicyconfig.v1.AuthMfa
+	8,  // 13: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.device_trust:type_name -> ztcp.orgpolicyconfig.v1.DeviceTrust
+	9,  // 14: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.session_mgmt:type_name -> ztcp.orgpolicyconfig.v1.SessionMgmt
+	12, // 15: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.access_control:type_name -> ztcp.orgpolicyconfig.v1.AccessControl
+	14, // 16: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.action_restrictions:type_name -> ztcp.orgpolicyconfig.v1.ActionRestrictions
+	15, // 17: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.notifications:type_name -> ztcp.orgpolicyconfig.v1.Notifications
+	16, // 18: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.network_access:type_name -> ztcp.orgpolicyconfig.v1.NetworkAccess
+	18, // 19: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.access_schedule:type_name -> ztcp.orgpolicyconfig.v1.AccessSchedule
+	19, // 20: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.token_claims:type_name -> ztcp.orgpolicyconfig.v1.TokenClaims
+	20, // 21: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.password_policy:type_name -> ztcp.orgpolicyconfig.v1.PasswordPolicy
+	21, // 22: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.change_approval:type_name -> ztcp.orgpolicyconfig.v1.ChangeApproval
+	22, // 23: ztcp.orgpolicyconfig.v1.OrgPolicyConfig.registration:type_name -> ztcp.orgpolicyconfig.v1.Registration
+	23, // 24: ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	23, // 25: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	49, // 26: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest.update_mask:type_name -> google.protobuf.FieldMask
+	50, // 27: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest.effective_at:type_name -> google.protobuf.Timestamp
+	23, // 28: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	34, // 29: ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse.scheduled_change:type_name -> ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange
+	50, // 30: ztcp.orgpolicyconfig.v1.PolicyConfigVersion.changed_at:type_name -> google.protobuf.Timestamp
+	28, // 31: ztcp.orgpolicyconfig.v1.PolicyConfigVersion.changes:type_name -> ztcp.orgpolicyconfig.v1.PolicyConfigChange
+	23, // 32: ztcp.orgpolicyconfig.v1.PolicyConfigVersion.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	51, // 33: ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	29, // 34: ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryResponse.versions:type_name -> ztcp.orgpolicyconfig.v1.PolicyConfigVersion
+	52, // 35: ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	23, // 36: ztcp.orgpolicyconfig.v1.RollbackPolicyConfigResponse.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	23, // 37: ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	49, // 38: ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange.update_mask:type_name -> google.protobuf.FieldMask
+	50, // 39: ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange.effective_at:type_name -> google.protobuf.Timestamp
+	50, // 40: ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange.created_at:type_name -> google.protobuf.Timestamp
+	50, // 41: ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange.resolved_at:type_name -> google.protobuf.Timestamp
+	51, // 42: ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	34, // 43: ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesResponse.changes:type_name -> ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange
+	52, // 44: ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	34, // 45: ztcp.orgpolicyconfig.v1.CancelScheduledPolicyConfigChangeResponse.change:type_name -> ztcp.orgpolicyconfig.v1.ScheduledPolicyConfigChange
+	12, // 46: ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse.access_control:type_name -> ztcp.orgpolicyconfig.v1.AccessControl
+	14, // 47: ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse.action_restrictions:type_name -> ztcp.orgpolicyconfig.v1.ActionRestrictions
+	50, // 48: ztcp.orgpolicyconfig.v1.CheckUrlAccessResponse.exception_expires_at:type_name -> google.protobuf.Timestamp
+	5,  // 49: ztcp.orgpolicyconfig.v1.BulkUpdateDomainsRequest.list:type_name -> ztcp.orgpolicyconfig.v1.DomainList
+	12, // 50: ztcp.orgpolicyconfig.v1.BulkUpdateDomainsResponse.access_control:type_name -> ztcp.orgpolicyconfig.v1.AccessControl
+	10, // 51: ztcp.orgpolicyconfig.v1.ListUrlCategoriesResponse.categories:type_name -> ztcp.orgpolicyconfig.v1.UrlCategory
+	24, // 52: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetOrgPolicyConfig:input_type -> ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigRequest
+	26, // 53: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.UpdateOrgPolicyConfig:input_type -> ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigRequest
+	30, // 54: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.ListPolicyConfigHistory:input_type -> ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryRequest
+	32, // 55: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.RollbackPolicyConfig:input_type -> ztcp.orgpolicyconfig.v1.RollbackPolicyConfigRequest
+	35, // 56: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.ListScheduledPolicyConfigChanges:input_type -> ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesRequest
+	37, // 57: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.CancelScheduledPolicyConfigChange:input_type -> ztcp.orgpolicyconfig.v1.CancelScheduledPolicyConfigChangeRequest
+	39, // 58: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetBrowserPolicy:input_type -> ztcp.orgpolicyconfig.v1.GetBrowserPolicyRequest
+	41, // 59: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.SubscribeBrowserPolicy:input_type -> ztcp.orgpolicyconfig.v1.SubscribeBrowserPolicyRequest
+	42, // 60: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.CheckUrlAccess:input_type -> ztcp.orgpolicyconfig.v1.CheckUrlAccessRequest
+	44, // 61: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.BulkUpdateDomains:input_type -> ztcp.orgpolicyconfig.v1.BulkUpdateDomainsRequest
+	46, // 62: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.ListUrlCategories:input_type -> ztcp.orgpolicyconfig.v1.ListUrlCategoriesRequest
+	25, // 63: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetOrgPolicyConfig:output_type -> ztcp.orgpolicyconfig.v1.GetOrgPolicyConfigResponse
+	27, // 64: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.UpdateOrgPolicyConfig:output_type -> ztcp.orgpolicyconfig.v1.UpdateOrgPolicyConfigResponse
+	31, // 65: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.ListPolicyConfigHistory:output_type -> ztcp.orgpolicyconfig.v1.ListPolicyConfigHistoryResponse
+	33, // 66: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.RollbackPolicyConfig:output_type -> ztcp.orgpolicyconfig.v1.RollbackPolicyConfigResponse
+	36, // 67: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.ListScheduledPolicyConfigChanges:output_type -> ztcp.orgpolicyconfig.v1.ListScheduledPolicyConfigChangesResponse
+	38, // 68: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.CancelScheduledPolicyConfigChange:output_type -> ztcp.orgpolicyconfig.v1.CancelScheduledPolicyConfigChangeResponse
+	40, // 69: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.GetBrowserPolicy:output_type -> ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse
+	40, // 70: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.SubscribeBrowserPolicy:output_type -> ztcp.orgpolicyconfig.v1.GetBrowserPolicyResponse
+	43, // 71: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.CheckUrlAccess:output_type -> ztcp.orgpolicyconfig.v1.CheckUrlAccessResponse
+	45, // 72: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.BulkUpdateDomains:output_type -> ztcp.orgpolicyconfig.v1.BulkUpdateDomainsResponse
+	47, // 73: ztcp.orgpolicyconfig.v1.OrgPolicyConfigService.ListUrlCategories:output_type -> ztcp.orgpolicyconfig.v1.ListUrlCategoriesResponse
+	63, // [63:74] is the sub-list for method output_type
+	52, // [52:63] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_orgpolicyconfig_orgpolicyconfig_proto_init() }
@@ -1075,8 +3539,8 @@ func file_orgpolicyconfig_orgpolicyconfig_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orgpolicyconfig_orgpolicyconfig_proto_rawDesc), len(file_orgpolicyconfig_orgpolicyconfig_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   14,
+			NumEnums:      6,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
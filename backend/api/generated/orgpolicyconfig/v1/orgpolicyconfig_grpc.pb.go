@@ -19,10 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrgPolicyConfigService_GetOrgPolicyConfig_FullMethodName    = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/GetOrgPolicyConfig"
-	OrgPolicyConfigService_UpdateOrgPolicyConfig_FullMethodName = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/UpdateOrgPolicyConfig"
-	OrgPolicyConfigService_GetBrowserPolicy_FullMethodName      = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/GetBrowserPolicy"
-	OrgPolicyConfigService_CheckUrlAccess_FullMethodName        = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/CheckUrlAccess"
+	OrgPolicyConfigService_GetOrgPolicyConfig_FullMethodName                = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/GetOrgPolicyConfig"
+	OrgPolicyConfigService_UpdateOrgPolicyConfig_FullMethodName             = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/UpdateOrgPolicyConfig"
+	OrgPolicyConfigService_ListPolicyConfigHistory_FullMethodName           = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/ListPolicyConfigHistory"
+	OrgPolicyConfigService_RollbackPolicyConfig_FullMethodName              = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/RollbackPolicyConfig"
+	OrgPolicyConfigService_ListScheduledPolicyConfigChanges_FullMethodName  = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/ListScheduledPolicyConfigChanges"
+	OrgPolicyConfigService_CancelScheduledPolicyConfigChange_FullMethodName = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/CancelScheduledPolicyConfigChange"
+	OrgPolicyConfigService_GetBrowserPolicy_FullMethodName                  = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/GetBrowserPolicy"
+	OrgPolicyConfigService_SubscribeBrowserPolicy_FullMethodName            = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/SubscribeBrowserPolicy"
+	OrgPolicyConfigService_CheckUrlAccess_FullMethodName                    = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/CheckUrlAccess"
+	OrgPolicyConfigService_BulkUpdateDomains_FullMethodName                 = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/BulkUpdateDomains"
+	OrgPolicyConfigService_ListUrlCategories_FullMethodName                 = "/ztcp.orgpolicyconfig.v1.OrgPolicyConfigService/ListUrlCategories"
 )
 
 // OrgPolicyConfigServiceClient is the client API for OrgPolicyConfigService service.
@@ -30,12 +37,21 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
 // OrgPolicyConfigService allows org admins to get/update org policy config.
-// GetBrowserPolicy and CheckUrlAccess are callable by any org member.
+// GetBrowserPolicy, SubscribeBrowserPolicy, CheckUrlAccess, and ListUrlCategories are callable by any org member.
 type OrgPolicyConfigServiceClient interface {
 	GetOrgPolicyConfig(ctx context.Context, in *GetOrgPolicyConfigRequest, opts ...grpc.CallOption) (*GetOrgPolicyConfigResponse, error)
 	UpdateOrgPolicyConfig(ctx context.Context, in *UpdateOrgPolicyConfigRequest, opts ...grpc.CallOption) (*UpdateOrgPolicyConfigResponse, error)
+	ListPolicyConfigHistory(ctx context.Context, in *ListPolicyConfigHistoryRequest, opts ...grpc.CallOption) (*ListPolicyConfigHistoryResponse, error)
+	RollbackPolicyConfig(ctx context.Context, in *RollbackPolicyConfigRequest, opts ...grpc.CallOption) (*RollbackPolicyConfigResponse, error)
+	ListScheduledPolicyConfigChanges(ctx context.Context, in *ListScheduledPolicyConfigChangesRequest, opts ...grpc.CallOption) (*ListScheduledPolicyConfigChangesResponse, error)
+	CancelScheduledPolicyConfigChange(ctx context.Context, in *CancelScheduledPolicyConfigChangeRequest, opts ...grpc.CallOption) (*CancelScheduledPolicyConfigChangeResponse, error)
 	GetBrowserPolicy(ctx context.Context, in *GetBrowserPolicyRequest, opts ...grpc.CallOption) (*GetBrowserPolicyResponse, error)
+	// SubscribeBrowserPolicy sends the current browser policy, then a new message whenever access_control or
+	// action_restrictions change, so browser agents need not poll.
+	SubscribeBrowserPolicy(ctx context.Context, in *SubscribeBrowserPolicyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetBrowserPolicyResponse], error)
 	CheckUrlAccess(ctx context.Context, in *CheckUrlAccessRequest, opts ...grpc.CallOption) (*CheckUrlAccessResponse, error)
+	BulkUpdateDomains(ctx context.Context, in *BulkUpdateDomainsRequest, opts ...grpc.CallOption) (*BulkUpdateDomainsResponse, error)
+	ListUrlCategories(ctx context.Context, in *ListUrlCategoriesRequest, opts ...grpc.CallOption) (*ListUrlCategoriesResponse, error)
 }
 
 type orgPolicyConfigServiceClient struct {
@@ -66,6 +82,46 @@ func (c *orgPolicyConfigServiceClient) UpdateOrgPolicyConfig(ctx context.Context
 	return out, nil
 }
 
+func (c *orgPolicyConfigServiceClient) ListPolicyConfigHistory(ctx context.Context, in *ListPolicyConfigHistoryRequest, opts ...grpc.CallOption) (*ListPolicyConfigHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPolicyConfigHistoryResponse)
+	err := c.cc.Invoke(ctx, OrgPolicyConfigService_ListPolicyConfigHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgPolicyConfigServiceClient) RollbackPolicyConfig(ctx context.Context, in *RollbackPolicyConfigRequest, opts ...grpc.CallOption) (*RollbackPolicyConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackPolicyConfigResponse)
+	err := c.cc.Invoke(ctx, OrgPolicyConfigService_RollbackPolicyConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgPolicyConfigServiceClient) ListScheduledPolicyConfigChanges(ctx context.Context, in *ListScheduledPolicyConfigChangesRequest, opts ...grpc.CallOption) (*ListScheduledPolicyConfigChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledPolicyConfigChangesResponse)
+	err := c.cc.Invoke(ctx, OrgPolicyConfigService_ListScheduledPolicyConfigChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgPolicyConfigServiceClient) CancelScheduledPolicyConfigChange(ctx context.Context, in *CancelScheduledPolicyConfigChangeRequest, opts ...grpc.CallOption) (*CancelScheduledPolicyConfigChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledPolicyConfigChangeResponse)
+	err := c.cc.Invoke(ctx, OrgPolicyConfigService_CancelScheduledPolicyConfigChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orgPolicyConfigServiceClient) GetBrowserPolicy(ctx context.Context, in *GetBrowserPolicyRequest, opts ...grpc.CallOption) (*GetBrowserPolicyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBrowserPolicyResponse)
@@ -76,6 +132,25 @@ func (c *orgPolicyConfigServiceClient) GetBrowserPolicy(ctx context.Context, in
 	return out, nil
 }
 
+func (c *orgPolicyConfigServiceClient) SubscribeBrowserPolicy(ctx context.Context, in *SubscribeBrowserPolicyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetBrowserPolicyResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrgPolicyConfigService_ServiceDesc.Streams[0], OrgPolicyConfigService_SubscribeBrowserPolicy_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeBrowserPolicyRequest, GetBrowserPolicyResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrgPolicyConfigService_SubscribeBrowserPolicyClient = grpc.ServerStreamingClient[GetBrowserPolicyResponse]
+
 func (c *orgPolicyConfigServiceClient) CheckUrlAccess(ctx context.Context, in *CheckUrlAccessRequest, opts ...grpc.CallOption) (*CheckUrlAccessResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckUrlAccessResponse)
@@ -86,17 +161,46 @@ func (c *orgPolicyConfigServiceClient) CheckUrlAccess(ctx context.Context, in *C
 	return out, nil
 }
 
+func (c *orgPolicyConfigServiceClient) BulkUpdateDomains(ctx context.Context, in *BulkUpdateDomainsRequest, opts ...grpc.CallOption) (*BulkUpdateDomainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkUpdateDomainsResponse)
+	err := c.cc.Invoke(ctx, OrgPolicyConfigService_BulkUpdateDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgPolicyConfigServiceClient) ListUrlCategories(ctx context.Context, in *ListUrlCategoriesRequest, opts ...grpc.CallOption) (*ListUrlCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUrlCategoriesResponse)
+	err := c.cc.Invoke(ctx, OrgPolicyConfigService_ListUrlCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrgPolicyConfigServiceServer is the server API for OrgPolicyConfigService service.
 // All implementations must embed UnimplementedOrgPolicyConfigServiceServer
 // for forward compatibility.
 //
 // OrgPolicyConfigService allows org admins to get/update org policy config.
-// GetBrowserPolicy and CheckUrlAccess are callable by any org member.
+// GetBrowserPolicy, SubscribeBrowserPolicy, CheckUrlAccess, and ListUrlCategories are callable by any org member.
 type OrgPolicyConfigServiceServer interface {
 	GetOrgPolicyConfig(context.Context, *GetOrgPolicyConfigRequest) (*GetOrgPolicyConfigResponse, error)
 	UpdateOrgPolicyConfig(context.Context, *UpdateOrgPolicyConfigRequest) (*UpdateOrgPolicyConfigResponse, error)
+	ListPolicyConfigHistory(context.Context, *ListPolicyConfigHistoryRequest) (*ListPolicyConfigHistoryResponse, error)
+	RollbackPolicyConfig(context.Context, *RollbackPolicyConfigRequest) (*RollbackPolicyConfigResponse, error)
+	ListScheduledPolicyConfigChanges(context.Context, *ListScheduledPolicyConfigChangesRequest) (*ListScheduledPolicyConfigChangesResponse, error)
+	CancelScheduledPolicyConfigChange(context.Context, *CancelScheduledPolicyConfigChangeRequest) (*CancelScheduledPolicyConfigChangeResponse, error)
 	GetBrowserPolicy(context.Context, *GetBrowserPolicyRequest) (*GetBrowserPolicyResponse, error)
+	// SubscribeBrowserPolicy sends the current browser policy, then a new message whenever access_control or
+	// action_restrictions change, so browser agents need not poll.
+	SubscribeBrowserPolicy(*SubscribeBrowserPolicyRequest, grpc.ServerStreamingServer[GetBrowserPolicyResponse]) error
 	CheckUrlAccess(context.Context, *CheckUrlAccessRequest) (*CheckUrlAccessResponse, error)
+	BulkUpdateDomains(context.Context, *BulkUpdateDomainsRequest) (*BulkUpdateDomainsResponse, error)
+	ListUrlCategories(context.Context, *ListUrlCategoriesRequest) (*ListUrlCategoriesResponse, error)
 	mustEmbedUnimplementedOrgPolicyConfigServiceServer()
 }
 
@@ -113,12 +217,33 @@ func (UnimplementedOrgPolicyConfigServiceServer) GetOrgPolicyConfig(context.Cont
 func (UnimplementedOrgPolicyConfigServiceServer) UpdateOrgPolicyConfig(context.Context, *UpdateOrgPolicyConfigRequest) (*UpdateOrgPolicyConfigResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateOrgPolicyConfig not implemented")
 }
+func (UnimplementedOrgPolicyConfigServiceServer) ListPolicyConfigHistory(context.Context, *ListPolicyConfigHistoryRequest) (*ListPolicyConfigHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPolicyConfigHistory not implemented")
+}
+func (UnimplementedOrgPolicyConfigServiceServer) RollbackPolicyConfig(context.Context, *RollbackPolicyConfigRequest) (*RollbackPolicyConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackPolicyConfig not implemented")
+}
+func (UnimplementedOrgPolicyConfigServiceServer) ListScheduledPolicyConfigChanges(context.Context, *ListScheduledPolicyConfigChangesRequest) (*ListScheduledPolicyConfigChangesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScheduledPolicyConfigChanges not implemented")
+}
+func (UnimplementedOrgPolicyConfigServiceServer) CancelScheduledPolicyConfigChange(context.Context, *CancelScheduledPolicyConfigChangeRequest) (*CancelScheduledPolicyConfigChangeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelScheduledPolicyConfigChange not implemented")
+}
 func (UnimplementedOrgPolicyConfigServiceServer) GetBrowserPolicy(context.Context, *GetBrowserPolicyRequest) (*GetBrowserPolicyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetBrowserPolicy not implemented")
 }
+func (UnimplementedOrgPolicyConfigServiceServer) SubscribeBrowserPolicy(*SubscribeBrowserPolicyRequest, grpc.ServerStreamingServer[GetBrowserPolicyResponse]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeBrowserPolicy not implemented")
+}
 func (UnimplementedOrgPolicyConfigServiceServer) CheckUrlAccess(context.Context, *CheckUrlAccessRequest) (*CheckUrlAccessResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CheckUrlAccess not implemented")
 }
+func (UnimplementedOrgPolicyConfigServiceServer) BulkUpdateDomains(context.Context, *BulkUpdateDomainsRequest) (*BulkUpdateDomainsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkUpdateDomains not implemented")
+}
+func (UnimplementedOrgPolicyConfigServiceServer) ListUrlCategories(context.Context, *ListUrlCategoriesRequest) (*ListUrlCategoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUrlCategories not implemented")
+}
 func (UnimplementedOrgPolicyConfigServiceServer) mustEmbedUnimplementedOrgPolicyConfigServiceServer() {
 }
 func (UnimplementedOrgPolicyConfigServiceServer) testEmbeddedByValue() {}
@@ -177,6 +302,78 @@ func _OrgPolicyConfigService_UpdateOrgPolicyConfig_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrgPolicyConfigService_ListPolicyConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPolicyConfigHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgPolicyConfigServiceServer).ListPolicyConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgPolicyConfigService_ListPolicyConfigHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgPolicyConfigServiceServer).ListPolicyConfigHistory(ctx, req.(*ListPolicyConfigHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgPolicyConfigService_RollbackPolicyConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackPolicyConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgPolicyConfigServiceServer).RollbackPolicyConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgPolicyConfigService_RollbackPolicyConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgPolicyConfigServiceServer).RollbackPolicyConfig(ctx, req.(*RollbackPolicyConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgPolicyConfigService_ListScheduledPolicyConfigChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledPolicyConfigChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgPolicyConfigServiceServer).ListScheduledPolicyConfigChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgPolicyConfigService_ListScheduledPolicyConfigChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgPolicyConfigServiceServer).ListScheduledPolicyConfigChanges(ctx, req.(*ListScheduledPolicyConfigChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgPolicyConfigService_CancelScheduledPolicyConfigChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledPolicyConfigChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgPolicyConfigServiceServer).CancelScheduledPolicyConfigChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgPolicyConfigService_CancelScheduledPolicyConfigChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgPolicyConfigServiceServer).CancelScheduledPolicyConfigChange(ctx, req.(*CancelScheduledPolicyConfigChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrgPolicyConfigService_GetBrowserPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBrowserPolicyRequest)
 	if err := dec(in); err != nil {
@@ -195,6 +392,17 @@ func _OrgPolicyConfigService_GetBrowserPolicy_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrgPolicyConfigService_SubscribeBrowserPolicy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBrowserPolicyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrgPolicyConfigServiceServer).SubscribeBrowserPolicy(m, &grpc.GenericServerStream[SubscribeBrowserPolicyRequest, GetBrowserPolicyResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrgPolicyConfigService_SubscribeBrowserPolicyServer = grpc.ServerStreamingServer[GetBrowserPolicyResponse]
+
 func _OrgPolicyConfigService_CheckUrlAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckUrlAccessRequest)
 	if err := dec(in); err != nil {
@@ -213,6 +421,42 @@ func _OrgPolicyConfigService_CheckUrlAccess_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrgPolicyConfigService_BulkUpdateDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgPolicyConfigServiceServer).BulkUpdateDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgPolicyConfigService_BulkUpdateDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgPolicyConfigServiceServer).BulkUpdateDomains(ctx, req.(*BulkUpdateDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgPolicyConfigService_ListUrlCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUrlCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgPolicyConfigServiceServer).ListUrlCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgPolicyConfigService_ListUrlCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgPolicyConfigServiceServer).ListUrlCategories(ctx, req.(*ListUrlCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrgPolicyConfigService_ServiceDesc is the grpc.ServiceDesc for OrgPolicyConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -228,6 +472,22 @@ var OrgPolicyConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateOrgPolicyConfig",
 			Handler:    _OrgPolicyConfigService_UpdateOrgPolicyConfig_Handler,
 		},
+		{
+			MethodName: "ListPolicyConfigHistory",
+			Handler:    _OrgPolicyConfigService_ListPolicyConfigHistory_Handler,
+		},
+		{
+			MethodName: "RollbackPolicyConfig",
+			Handler:    _OrgPolicyConfigService_RollbackPolicyConfig_Handler,
+		},
+		{
+			MethodName: "ListScheduledPolicyConfigChanges",
+			Handler:    _OrgPolicyConfigService_ListScheduledPolicyConfigChanges_Handler,
+		},
+		{
+			MethodName: "CancelScheduledPolicyConfigChange",
+			Handler:    _OrgPolicyConfigService_CancelScheduledPolicyConfigChange_Handler,
+		},
 		{
 			MethodName: "GetBrowserPolicy",
 			Handler:    _OrgPolicyConfigService_GetBrowserPolicy_Handler,
@@ -236,7 +496,21 @@ var OrgPolicyConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckUrlAccess",
 			Handler:    _OrgPolicyConfigService_CheckUrlAccess_Handler,
 		},
+		{
+			MethodName: "BulkUpdateDomains",
+			Handler:    _OrgPolicyConfigService_BulkUpdateDomains_Handler,
+		},
+		{
+			MethodName: "ListUrlCategories",
+			Handler:    _OrgPolicyConfigService_ListUrlCategories_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBrowserPolicy",
+			Handler:       _OrgPolicyConfigService_SubscribeBrowserPolicy_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "orgpolicyconfig/orgpolicyconfig.proto",
 }
@@ -0,0 +1,423 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: platformsettings/platformsettings.proto
+
+package platformsettingsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Self-service sign-up mode for AuthService.Register.
+type RegistrationMode int32
+
+const (
+	RegistrationMode_REGISTRATION_MODE_UNSPECIFIED RegistrationMode = 0
+	RegistrationMode_REGISTRATION_MODE_OPEN        RegistrationMode = 1 // anyone may register
+	RegistrationMode_REGISTRATION_MODE_INVITE_ONLY RegistrationMode = 2 // an unused invitation (InvitationService) is required
+	RegistrationMode_REGISTRATION_MODE_CLOSED      RegistrationMode = 3 // nobody may register
+)
+
+// Enum value maps for RegistrationMode.
+var (
+	RegistrationMode_name = map[int32]string{
+		0: "REGISTRATION_MODE_UNSPECIFIED",
+		1: "REGISTRATION_MODE_OPEN",
+		2: "REGISTRATION_MODE_INVITE_ONLY",
+		3: "REGISTRATION_MODE_CLOSED",
+	}
+	RegistrationMode_value = map[string]int32{
+		"REGISTRATION_MODE_UNSPECIFIED": 0,
+		"REGISTRATION_MODE_OPEN":        1,
+		"REGISTRATION_MODE_INVITE_ONLY": 2,
+		"REGISTRATION_MODE_CLOSED":      3,
+	}
+)
+
+func (x RegistrationMode) Enum() *RegistrationMode {
+	p := new(RegistrationMode)
+	*p = x
+	return p
+}
+
+func (x RegistrationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegistrationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_platformsettings_platformsettings_proto_enumTypes[0].Descriptor()
+}
+
+func (RegistrationMode) Type() protoreflect.EnumType {
+	return &file_platformsettings_platformsettings_proto_enumTypes[0]
+}
+
+func (x RegistrationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegistrationMode.Descriptor instead.
+func (RegistrationMode) EnumDescriptor() ([]byte, []int) {
+	return file_platformsettings_platformsettings_proto_rawDescGZIP(), []int{0}
+}
+
+// PlatformSettings are the platform-wide settings in platform_settings. Settings that are not stored take their
+// defaults: default_trust_ttl_days from DEFAULT_TRUST_TTL_DAYS, mfa_required_always false, registration_mode open.
+type PlatformSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// default_trust_ttl_days is how long devices stay trusted after MFA in orgs without their own trust TTL; 1-365.
+	DefaultTrustTtlDays int32 `protobuf:"varint,1,opt,name=default_trust_ttl_days,json=defaultTrustTtlDays,proto3" json:"default_trust_ttl_days,omitempty"`
+	// mfa_required_always requires MFA on every sign-in in every org.
+	MfaRequiredAlways bool `protobuf:"varint,2,opt,name=mfa_required_always,json=mfaRequiredAlways,proto3" json:"mfa_required_always,omitempty"`
+	// registration_mode controls self-service sign-up with AuthService.Register. Orgs can only tighten it for emails
+	// in their verified domains (org policy config section registration).
+	RegistrationMode RegistrationMode `protobuf:"varint,4,opt,name=registration_mode,json=registrationMode,proto3,enum=ztcp.platformsettings.v1.RegistrationMode" json:"registration_mode,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PlatformSettings) Reset() {
+	*x = PlatformSettings{}
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlatformSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlatformSettings) ProtoMessage() {}
+
+func (x *PlatformSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlatformSettings.ProtoReflect.Descriptor instead.
+func (*PlatformSettings) Descriptor() ([]byte, []int) {
+	return file_platformsettings_platformsettings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlatformSettings) GetDefaultTrustTtlDays() int32 {
+	if x != nil {
+		return x.DefaultTrustTtlDays
+	}
+	return 0
+}
+
+func (x *PlatformSettings) GetMfaRequiredAlways() bool {
+	if x != nil {
+		return x.MfaRequiredAlways
+	}
+	return false
+}
+
+func (x *PlatformSettings) GetRegistrationMode() RegistrationMode {
+	if x != nil {
+		return x.RegistrationMode
+	}
+	return RegistrationMode_REGISTRATION_MODE_UNSPECIFIED
+}
+
+// GetPlatformSettingsRequest is empty.
+type GetPlatformSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlatformSettingsRequest) Reset() {
+	*x = GetPlatformSettingsRequest{}
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlatformSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlatformSettingsRequest) ProtoMessage() {}
+
+func (x *GetPlatformSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlatformSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetPlatformSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_platformsettings_platformsettings_proto_rawDescGZIP(), []int{1}
+}
+
+type GetPlatformSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *PlatformSettings      `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlatformSettingsResponse) Reset() {
+	*x = GetPlatformSettingsResponse{}
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlatformSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlatformSettingsResponse) ProtoMessage() {}
+
+func (x *GetPlatformSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlatformSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetPlatformSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_platformsettings_platformsettings_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPlatformSettingsResponse) GetSettings() *PlatformSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// SetPlatformSettingsRequest changes the settings that are set; the others are kept. At least one is required.
+type SetPlatformSettingsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DefaultTrustTtlDays *int32                 `protobuf:"varint,1,opt,name=default_trust_ttl_days,json=defaultTrustTtlDays,proto3,oneof" json:"default_trust_ttl_days,omitempty"`
+	MfaRequiredAlways   *bool                  `protobuf:"varint,2,opt,name=mfa_required_always,json=mfaRequiredAlways,proto3,oneof" json:"mfa_required_always,omitempty"`
+	// registration_mode must not be REGISTRATION_MODE_UNSPECIFIED when set.
+	RegistrationMode *RegistrationMode `protobuf:"varint,4,opt,name=registration_mode,json=registrationMode,proto3,enum=ztcp.platformsettings.v1.RegistrationMode,oneof" json:"registration_mode,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetPlatformSettingsRequest) Reset() {
+	*x = SetPlatformSettingsRequest{}
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPlatformSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPlatformSettingsRequest) ProtoMessage() {}
+
+func (x *SetPlatformSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPlatformSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetPlatformSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_platformsettings_platformsettings_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetPlatformSettingsRequest) GetDefaultTrustTtlDays() int32 {
+	if x != nil && x.DefaultTrustTtlDays != nil {
+		return *x.DefaultTrustTtlDays
+	}
+	return 0
+}
+
+func (x *SetPlatformSettingsRequest) GetMfaRequiredAlways() bool {
+	if x != nil && x.MfaRequiredAlways != nil {
+		return *x.MfaRequiredAlways
+	}
+	return false
+}
+
+func (x *SetPlatformSettingsRequest) GetRegistrationMode() RegistrationMode {
+	if x != nil && x.RegistrationMode != nil {
+		return *x.RegistrationMode
+	}
+	return RegistrationMode_REGISTRATION_MODE_UNSPECIFIED
+}
+
+type SetPlatformSettingsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// settings are the settings after the change.
+	Settings      *PlatformSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPlatformSettingsResponse) Reset() {
+	*x = SetPlatformSettingsResponse{}
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPlatformSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPlatformSettingsResponse) ProtoMessage() {}
+
+func (x *SetPlatformSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_platformsettings_platformsettings_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPlatformSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetPlatformSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_platformsettings_platformsettings_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetPlatformSettingsResponse) GetSettings() *PlatformSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+var File_platformsettings_platformsettings_proto protoreflect.FileDescriptor
+
+const file_platformsettings_platformsettings_proto_rawDesc = "" +
+	"\n" +
+	"'platformsettings/platformsettings.proto\x12\x18ztcp.platformsettings.v1\"\xe9\x01\n" +
+	"\x10PlatformSettings\x123\n" +
+	"\x16default_trust_ttl_days\x18\x01 \x01(\x05R\x13defaultTrustTtlDays\x12.\n" +
+	"\x13mfa_required_always\x18\x02 \x01(\bR\x11mfaRequiredAlways\x12W\n" +
+	"\x11registration_mode\x18\x04 \x01(\x0e2*.ztcp.platformsettings.v1.RegistrationModeR\x10registrationModeJ\x04\b\x03\x10\x04R\x11registration_open\"\x1c\n" +
+	"\x1aGetPlatformSettingsRequest\"e\n" +
+	"\x1bGetPlatformSettingsResponse\x12F\n" +
+	"\bsettings\x18\x01 \x01(\v2*.ztcp.platformsettings.v1.PlatformSettingsR\bsettings\"\xcb\x02\n" +
+	"\x1aSetPlatformSettingsRequest\x128\n" +
+	"\x16default_trust_ttl_days\x18\x01 \x01(\x05H\x00R\x13defaultTrustTtlDays\x88\x01\x01\x123\n" +
+	"\x13mfa_required_always\x18\x02 \x01(\bH\x01R\x11mfaRequiredAlways\x88\x01\x01\x12\\\n" +
+	"\x11registration_mode\x18\x04 \x01(\x0e2*.ztcp.platformsettings.v1.RegistrationModeH\x02R\x10registrationMode\x88\x01\x01B\x19\n" +
+	"\x17_default_trust_ttl_daysB\x16\n" +
+	"\x14_mfa_required_alwaysB\x14\n" +
+	"\x12_registration_modeJ\x04\b\x03\x10\x04R\x11registration_open\"e\n" +
+	"\x1bSetPlatformSettingsResponse\x12F\n" +
+	"\bsettings\x18\x01 \x01(\v2*.ztcp.platformsettings.v1.PlatformSettingsR\bsettings*\x92\x01\n" +
+	"\x10RegistrationMode\x12!\n" +
+	"\x1dREGISTRATION_MODE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16REGISTRATION_MODE_OPEN\x10\x01\x12!\n" +
+	"\x1dREGISTRATION_MODE_INVITE_ONLY\x10\x02\x12\x1c\n" +
+	"\x18REGISTRATION_MODE_CLOSED\x10\x032\xa8\x02\n" +
+	"\x17PlatformSettingsService\x12\x87\x01\n" +
+	"\x13GetPlatformSettings\x124.ztcp.platformsettings.v1.GetPlatformSettingsRequest\x1a5.ztcp.platformsettings.v1.GetPlatformSettingsResponse\"\x03\x90\x02\x01\x12\x82\x01\n" +
+	"\x13SetPlatformSettings\x124.ztcp.platformsettings.v1.SetPlatformSettingsRequest\x1a5.ztcp.platformsettings.v1.SetPlatformSettingsResponseBWZUzero-trust-control-plane/backend/api/generated/platformsettings/v1;platformsettingsv1b\x06proto3"
+
+var (
+	file_platformsettings_platformsettings_proto_rawDescOnce sync.Once
+	file_platformsettings_platformsettings_proto_rawDescData []byte
+)
+
+func file_platformsettings_platformsettings_proto_rawDescGZIP() []byte {
+	file_platformsettings_platformsettings_proto_rawDescOnce.Do(func() {
+		file_platformsettings_platformsettings_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_platformsettings_platformsettings_proto_rawDesc), len(file_platformsettings_platformsettings_proto_rawDesc)))
+	})
+	return file_platformsettings_platformsettings_proto_rawDescData
+}
+
+var file_platformsettings_platformsettings_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_platformsettings_platformsettings_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_platformsettings_platformsettings_proto_goTypes = []any{
+	(RegistrationMode)(0),               // 0: ztcp.platformsettings.v1.RegistrationMode
+	(*PlatformSettings)(nil),            // 1: ztcp.platformsettings.v1.PlatformSettings
+	(*GetPlatformSettingsRequest)(nil),  // 2: ztcp.platformsettings.v1.GetPlatformSettingsRequest
+	(*GetPlatformSettingsResponse)(nil), // 3: ztcp.platformsettings.v1.GetPlatformSettingsResponse
+	(*SetPlatformSettingsRequest)(nil),  // 4: ztcp.platformsettings.v1.SetPlatformSettingsRequest
+	(*SetPlatformSettingsResponse)(nil), // 5: ztcp.platformsettings.v1.SetPlatformSettingsResponse
+}
+var file_platformsettings_platformsettings_proto_depIdxs = []int32{
+	0, // 0: ztcp.platformsettings.v1.PlatformSettings.registration_mode:type_name -> ztcp.platformsettings.v1.RegistrationMode
+	1, // 1: ztcp.platformsettings.v1.GetPlatformSettingsResponse.settings:type_name -> ztcp.platformsettings.v1.PlatformSettings
+	0, // 2: ztcp.platformsettings.v1.SetPlatformSettingsRequest.registration_mode:type_name -> ztcp.platformsettings.v1.RegistrationMode
+	1, // 3: ztcp.platformsettings.v1.SetPlatformSettingsResponse.settings:type_name -> ztcp.platformsettings.v1.PlatformSettings
+	2, // 4: ztcp.platformsettings.v1.PlatformSettingsService.GetPlatformSettings:input_type -> ztcp.platformsettings.v1.GetPlatformSettingsRequest
+	4, // 5: ztcp.platformsettings.v1.PlatformSettingsService.SetPlatformSettings:input_type -> ztcp.platformsettings.v1.SetPlatformSettingsRequest
+	3, // 6: ztcp.platformsettings.v1.PlatformSettingsService.GetPlatformSettings:output_type -> ztcp.platformsettings.v1.GetPlatformSettingsResponse
+	5, // 7: ztcp.platformsettings.v1.PlatformSettingsService.SetPlatformSettings:output_type -> ztcp.platformsettings.v1.SetPlatformSettingsResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_platformsettings_platformsettings_proto_init() }
+func file_platformsettings_platformsettings_proto_init() {
+	if File_platformsettings_platformsettings_proto != nil {
+		return
+	}
+	file_platformsettings_platformsettings_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_platformsettings_platformsettings_proto_rawDesc), len(file_platformsettings_platformsettings_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_platformsettings_platformsettings_proto_goTypes,
+		DependencyIndexes: file_platformsettings_platformsettings_proto_depIdxs,
+		EnumInfos:         file_platformsettings_platformsettings_proto_enumTypes,
+		MessageInfos:      file_platformsettings_platformsettings_proto_msgTypes,
+	}.Build()
+	File_platformsettings_platformsettings_proto = out.File
+	file_platformsettings_platformsettings_proto_goTypes = nil
+	file_platformsettings_platformsettings_proto_depIdxs = nil
+}
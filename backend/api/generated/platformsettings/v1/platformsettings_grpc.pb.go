@@ -0,0 +1,168 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: platformsettings/platformsettings.proto
+
+package platformsettingsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PlatformSettingsService_GetPlatformSettings_FullMethodName = "/ztcp.platformsettings.v1.PlatformSettingsService/GetPlatformSettings"
+	PlatformSettingsService_SetPlatformSettings_FullMethodName = "/ztcp.platformsettings.v1.PlatformSettingsService/SetPlatformSettings"
+)
+
+// PlatformSettingsServiceClient is the client API for PlatformSettingsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PlatformSettingsService reads and changes the platform settings. Platform admins (PLATFORM_ADMIN_USER_IDS) only.
+type PlatformSettingsServiceClient interface {
+	GetPlatformSettings(ctx context.Context, in *GetPlatformSettingsRequest, opts ...grpc.CallOption) (*GetPlatformSettingsResponse, error)
+	// SetPlatformSettings stores the changed settings, audits each change as platform_setting_changed and applies them
+	// on every server instance at once.
+	SetPlatformSettings(ctx context.Context, in *SetPlatformSettingsRequest, opts ...grpc.CallOption) (*SetPlatformSettingsResponse, error)
+}
+
+type platformSettingsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlatformSettingsServiceClient(cc grpc.ClientConnInterface) PlatformSettingsServiceClient {
+	return &platformSettingsServiceClient{cc}
+}
+
+func (c *platformSettingsServiceClient) GetPlatformSettings(ctx context.Context, in *GetPlatformSettingsRequest, opts ...grpc.CallOption) (*GetPlatformSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlatformSettingsResponse)
+	err := c.cc.Invoke(ctx, PlatformSettingsService_GetPlatformSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformSettingsServiceClient) SetPlatformSettings(ctx context.Context, in *SetPlatformSettingsRequest, opts ...grpc.CallOption) (*SetPlatformSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPlatformSettingsResponse)
+	err := c.cc.Invoke(ctx, PlatformSettingsService_SetPlatformSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlatformSettingsServiceServer is the server API for PlatformSettingsService service.
+// All implementations must embed UnimplementedPlatformSettingsServiceServer
+// for forward compatibility.
+//
+// PlatformSettingsService reads and changes the platform settings. Platform admins (PLATFORM_ADMIN_USER_IDS) only.
+type PlatformSettingsServiceServer interface {
+	GetPlatformSettings(context.Context, *GetPlatformSettingsRequest) (*GetPlatformSettingsResponse, error)
+	// SetPlatformSettings stores the changed settings, audits each change as platform_setting_changed and applies them
+	// on every server instance at once.
+	SetPlatformSettings(context.Context, *SetPlatformSettingsRequest) (*SetPlatformSettingsResponse, error)
+	mustEmbedUnimplementedPlatformSettingsServiceServer()
+}
+
+// UnimplementedPlatformSettingsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPlatformSettingsServiceServer struct{}
+
+func (UnimplementedPlatformSettingsServiceServer) GetPlatformSettings(context.Context, *GetPlatformSettingsRequest) (*GetPlatformSettingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlatformSettings not implemented")
+}
+func (UnimplementedPlatformSettingsServiceServer) SetPlatformSettings(context.Context, *SetPlatformSettingsRequest) (*SetPlatformSettingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPlatformSettings not implemented")
+}
+func (UnimplementedPlatformSettingsServiceServer) mustEmbedUnimplementedPlatformSettingsServiceServer() {
+}
+func (UnimplementedPlatformSettingsServiceServer) testEmbeddedByValue() {}
+
+// UnsafePlatformSettingsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlatformSettingsServiceServer will
+// result in compilation errors.
+type UnsafePlatformSettingsServiceServer interface {
+	mustEmbedUnimplementedPlatformSettingsServiceServer()
+}
+
+func RegisterPlatformSettingsServiceServer(s grpc.ServiceRegistrar, srv PlatformSettingsServiceServer) {
+	// If the following call panics, it indicates UnimplementedPlatformSettingsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PlatformSettingsService_ServiceDesc, srv)
+}
+
+func _PlatformSettingsService_GetPlatformSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlatformSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformSettingsServiceServer).GetPlatformSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlatformSettingsService_GetPlatformSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformSettingsServiceServer).GetPlatformSettings(ctx, req.(*GetPlatformSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlatformSettingsService_SetPlatformSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPlatformSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformSettingsServiceServer).SetPlatformSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlatformSettingsService_SetPlatformSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformSettingsServiceServer).SetPlatformSettings(ctx, req.(*SetPlatformSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlatformSettingsService_ServiceDesc is the grpc.ServiceDesc for PlatformSettingsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlatformSettingsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.platformsettings.v1.PlatformSettingsService",
+	HandlerType: (*PlatformSettingsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPlatformSettings",
+			Handler:    _PlatformSettingsService_GetPlatformSettings_Handler,
+		},
+		{
+			MethodName: "SetPlatformSettings",
+			Handler:    _PlatformSettingsService_SetPlatformSettings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "platformsettings/platformsettings.proto",
+}
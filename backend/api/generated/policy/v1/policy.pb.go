@@ -536,12 +536,12 @@ const file_policy_policy_proto_rawDesc = "" +
 	"\bpolicies\x18\x01 \x03(\v2\x16.ztcp.policy.v1.PolicyR\bpolicies\x12@\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
-	"pagination2\xfb\x02\n" +
+	"pagination2\x80\x03\n" +
 	"\rPolicyService\x12Y\n" +
 	"\fCreatePolicy\x12#.ztcp.policy.v1.CreatePolicyRequest\x1a$.ztcp.policy.v1.CreatePolicyResponse\x12Y\n" +
 	"\fUpdatePolicy\x12#.ztcp.policy.v1.UpdatePolicyRequest\x1a$.ztcp.policy.v1.UpdatePolicyResponse\x12Y\n" +
-	"\fDeletePolicy\x12#.ztcp.policy.v1.DeletePolicyRequest\x1a$.ztcp.policy.v1.DeletePolicyResponse\x12Y\n" +
-	"\fListPolicies\x12#.ztcp.policy.v1.ListPoliciesRequest\x1a$.ztcp.policy.v1.ListPoliciesResponseBCZAzero-trust-control-plane/backend/api/generated/policy/v1;policyv1b\x06proto3"
+	"\fDeletePolicy\x12#.ztcp.policy.v1.DeletePolicyRequest\x1a$.ztcp.policy.v1.DeletePolicyResponse\x12^\n" +
+	"\fListPolicies\x12#.ztcp.policy.v1.ListPoliciesRequest\x1a$.ztcp.policy.v1.ListPoliciesResponse\"\x03\x90\x02\x01BCZAzero-trust-control-plane/backend/api/generated/policy/v1;policyv1b\x06proto3"
 
 var (
 	file_policy_policy_proto_rawDescOnce sync.Once
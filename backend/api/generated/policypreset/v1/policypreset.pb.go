@@ -0,0 +1,408 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: policypreset/policypreset.proto
+
+package policypresetv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	v11 "zero-trust-control-plane/backend/api/generated/policy/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Preset is a named bundle of org policy settings shared by all orgs.
+type Preset struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                  // e.g. "strict"; what ApplyPreset and SetupOrganization take
+	DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"` // e.g. "Strict"
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The sections of the org policy config the preset sets (listed in sections); the others are not changed.
+	Config        *v1.OrgPolicyConfig `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	Sections      []string            `protobuf:"bytes,5,rep,name=sections,proto3" json:"sections,omitempty"`
+	PolicyRules   string              `protobuf:"bytes,6,opt,name=policy_rules,json=policyRules,proto3" json:"policy_rules,omitempty"` // Rego of the policy the preset creates; empty = the built-in default policy
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preset) Reset() {
+	*x = Preset{}
+	mi := &file_policypreset_policypreset_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preset) ProtoMessage() {}
+
+func (x *Preset) ProtoReflect() protoreflect.Message {
+	mi := &file_policypreset_policypreset_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preset.ProtoReflect.Descriptor instead.
+func (*Preset) Descriptor() ([]byte, []int) {
+	return file_policypreset_policypreset_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Preset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Preset) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Preset) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Preset) GetConfig() *v1.OrgPolicyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Preset) GetSections() []string {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *Preset) GetPolicyRules() string {
+	if x != nil {
+		return x.PolicyRules
+	}
+	return ""
+}
+
+type ListPresetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPresetsRequest) Reset() {
+	*x = ListPresetsRequest{}
+	mi := &file_policypreset_policypreset_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPresetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPresetsRequest) ProtoMessage() {}
+
+func (x *ListPresetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policypreset_policypreset_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPresetsRequest.ProtoReflect.Descriptor instead.
+func (*ListPresetsRequest) Descriptor() ([]byte, []int) {
+	return file_policypreset_policypreset_proto_rawDescGZIP(), []int{1}
+}
+
+type ListPresetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Presets       []*Preset              `protobuf:"bytes,1,rep,name=presets,proto3" json:"presets,omitempty"` // by name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPresetsResponse) Reset() {
+	*x = ListPresetsResponse{}
+	mi := &file_policypreset_policypreset_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPresetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPresetsResponse) ProtoMessage() {}
+
+func (x *ListPresetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policypreset_policypreset_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPresetsResponse.ProtoReflect.Descriptor instead.
+func (*ListPresetsResponse) Descriptor() ([]byte, []int) {
+	return file_policypreset_policypreset_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPresetsResponse) GetPresets() []*Preset {
+	if x != nil {
+		return x.Presets
+	}
+	return nil
+}
+
+type ApplyPresetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"` // optional; must be the caller's org
+	Preset        string                 `protobuf:"bytes,2,opt,name=preset,proto3" json:"preset,omitempty"`            // preset name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyPresetRequest) Reset() {
+	*x = ApplyPresetRequest{}
+	mi := &file_policypreset_policypreset_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPresetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPresetRequest) ProtoMessage() {}
+
+func (x *ApplyPresetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policypreset_policypreset_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPresetRequest.ProtoReflect.Descriptor instead.
+func (*ApplyPresetRequest) Descriptor() ([]byte, []int) {
+	return file_policypreset_policypreset_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ApplyPresetRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ApplyPresetRequest) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+type ApplyPresetResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Preset           string                 `protobuf:"bytes,1,opt,name=preset,proto3" json:"preset,omitempty"`
+	Config           *v1.OrgPolicyConfig    `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`                                              // the org's config after the preset (merged with defaults)
+	Etag             string                 `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`                                                  // as in GetOrgPolicyConfig
+	Policy           *v11.Policy            `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`                                              // the preset's policy; unset when the preset has none
+	DisabledPolicies int32                  `protobuf:"varint,5,opt,name=disabled_policies,json=disabledPolicies,proto3" json:"disabled_policies,omitempty"` // enabled policies of the org the preset disabled
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ApplyPresetResponse) Reset() {
+	*x = ApplyPresetResponse{}
+	mi := &file_policypreset_policypreset_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPresetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPresetResponse) ProtoMessage() {}
+
+func (x *ApplyPresetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policypreset_policypreset_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPresetResponse.ProtoReflect.Descriptor instead.
+func (*ApplyPresetResponse) Descriptor() ([]byte, []int) {
+	return file_policypreset_policypreset_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ApplyPresetResponse) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+func (x *ApplyPresetResponse) GetConfig() *v1.OrgPolicyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ApplyPresetResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *ApplyPresetResponse) GetPolicy() *v11.Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *ApplyPresetResponse) GetDisabledPolicies() int32 {
+	if x != nil {
+		return x.DisabledPolicies
+	}
+	return 0
+}
+
+var File_policypreset_policypreset_proto protoreflect.FileDescriptor
+
+const file_policypreset_policypreset_proto_rawDesc = "" +
+	"\n" +
+	"\x1fpolicypreset/policypreset.proto\x12\x14ztcp.policypreset.v1\x1a%orgpolicyconfig/orgpolicyconfig.proto\x1a\x13policy/policy.proto\"\xe2\x01\n" +
+	"\x06Preset\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12@\n" +
+	"\x06config\x18\x04 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12\x1a\n" +
+	"\bsections\x18\x05 \x03(\tR\bsections\x12!\n" +
+	"\fpolicy_rules\x18\x06 \x01(\tR\vpolicyRules\"\x14\n" +
+	"\x12ListPresetsRequest\"M\n" +
+	"\x13ListPresetsResponse\x126\n" +
+	"\apresets\x18\x01 \x03(\v2\x1c.ztcp.policypreset.v1.PresetR\apresets\"C\n" +
+	"\x12ApplyPresetRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06preset\x18\x02 \x01(\tR\x06preset\"\xe0\x01\n" +
+	"\x13ApplyPresetResponse\x12\x16\n" +
+	"\x06preset\x18\x01 \x01(\tR\x06preset\x12@\n" +
+	"\x06config\x18\x02 \x01(\v2(.ztcp.orgpolicyconfig.v1.OrgPolicyConfigR\x06config\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\x12.\n" +
+	"\x06policy\x18\x04 \x01(\v2\x16.ztcp.policy.v1.PolicyR\x06policy\x12+\n" +
+	"\x11disabled_policies\x18\x05 \x01(\x05R\x10disabledPolicies2\xe2\x01\n" +
+	"\x13PolicyPresetService\x12g\n" +
+	"\vListPresets\x12(.ztcp.policypreset.v1.ListPresetsRequest\x1a).ztcp.policypreset.v1.ListPresetsResponse\"\x03\x90\x02\x01\x12b\n" +
+	"\vApplyPreset\x12(.ztcp.policypreset.v1.ApplyPresetRequest\x1a).ztcp.policypreset.v1.ApplyPresetResponseBOZMzero-trust-control-plane/backend/api/generated/policypreset/v1;policypresetv1b\x06proto3"
+
+var (
+	file_policypreset_policypreset_proto_rawDescOnce sync.Once
+	file_policypreset_policypreset_proto_rawDescData []byte
+)
+
+func file_policypreset_policypreset_proto_rawDescGZIP() []byte {
+	file_policypreset_policypreset_proto_rawDescOnce.Do(func() {
+		file_policypreset_policypreset_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_policypreset_policypreset_proto_rawDesc), len(file_policypreset_policypreset_proto_rawDesc)))
+	})
+	return file_policypreset_policypreset_proto_rawDescData
+}
+
+var file_policypreset_policypreset_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_policypreset_policypreset_proto_goTypes = []any{
+	(*Preset)(nil),              // 0: ztcp.policypreset.v1.Preset
+	(*ListPresetsRequest)(nil),  // 1: ztcp.policypreset.v1.ListPresetsRequest
+	(*ListPresetsResponse)(nil), // 2: ztcp.policypreset.v1.ListPresetsResponse
+	(*ApplyPresetRequest)(nil),  // 3: ztcp.policypreset.v1.ApplyPresetRequest
+	(*ApplyPresetResponse)(nil), // 4: ztcp.policypreset.v1.ApplyPresetResponse
+	(*v1.OrgPolicyConfig)(nil),  // 5: ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	(*v11.Policy)(nil),          // 6: ztcp.policy.v1.Policy
+}
+var file_policypreset_policypreset_proto_depIdxs = []int32{
+	5, // 0: ztcp.policypreset.v1.Preset.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	0, // 1: ztcp.policypreset.v1.ListPresetsResponse.presets:type_name -> ztcp.policypreset.v1.Preset
+	5, // 2: ztcp.policypreset.v1.ApplyPresetResponse.config:type_name -> ztcp.orgpolicyconfig.v1.OrgPolicyConfig
+	6, // 3: ztcp.policypreset.v1.ApplyPresetResponse.policy:type_name -> ztcp.policy.v1.Policy
+	1, // 4: ztcp.policypreset.v1.PolicyPresetService.ListPresets:input_type -> ztcp.policypreset.v1.ListPresetsRequest
+	3, // 5: ztcp.policypreset.v1.PolicyPresetService.ApplyPreset:input_type -> ztcp.policypreset.v1.ApplyPresetRequest
+	2, // 6: ztcp.policypreset.v1.PolicyPresetService.ListPresets:output_type -> ztcp.policypreset.v1.ListPresetsResponse
+	4, // 7: ztcp.policypreset.v1.PolicyPresetService.ApplyPreset:output_type -> ztcp.policypreset.v1.ApplyPresetResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_policypreset_policypreset_proto_init() }
+func file_policypreset_policypreset_proto_init() {
+	if File_policypreset_policypreset_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_policypreset_policypreset_proto_rawDesc), len(file_policypreset_policypreset_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_policypreset_policypreset_proto_goTypes,
+		DependencyIndexes: file_policypreset_policypreset_proto_depIdxs,
+		MessageInfos:      file_policypreset_policypreset_proto_msgTypes,
+	}.Build()
+	File_policypreset_policypreset_proto = out.File
+	file_policypreset_policypreset_proto_goTypes = nil
+	file_policypreset_policypreset_proto_depIdxs = nil
+}
@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: policypreset/policypreset.proto
+
+package policypresetv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PolicyPresetService_ListPresets_FullMethodName = "/ztcp.policypreset.v1.PolicyPresetService/ListPresets"
+	PolicyPresetService_ApplyPreset_FullMethodName = "/ztcp.policypreset.v1.PolicyPresetService/ApplyPreset"
+)
+
+// PolicyPresetServiceClient is the client API for PolicyPresetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PolicyPresetService applies named presets ("strict", "balanced", "byod_friendly", ...) to an org's MFA settings,
+// Rego policy and policy config in one step.
+type PolicyPresetServiceClient interface {
+	// ListPresets returns the presets with their descriptions, for UIs. Any signed-in user may call it.
+	ListPresets(ctx context.Context, in *ListPresetsRequest, opts ...grpc.CallOption) (*ListPresetsResponse, error)
+	// ApplyPreset replaces the caller's org's config sections the preset sets, recorded as a config version with source
+	// preset, disables the org's enabled Rego policies and creates the preset's policy, in one transaction, then syncs
+	// the org's MFA settings. Caller must be org admin or owner. Fails with FailedPrecondition while the org requires
+	// change approval.
+	ApplyPreset(ctx context.Context, in *ApplyPresetRequest, opts ...grpc.CallOption) (*ApplyPresetResponse, error)
+}
+
+type policyPresetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPolicyPresetServiceClient(cc grpc.ClientConnInterface) PolicyPresetServiceClient {
+	return &policyPresetServiceClient{cc}
+}
+
+func (c *policyPresetServiceClient) ListPresets(ctx context.Context, in *ListPresetsRequest, opts ...grpc.CallOption) (*ListPresetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPresetsResponse)
+	err := c.cc.Invoke(ctx, PolicyPresetService_ListPresets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyPresetServiceClient) ApplyPreset(ctx context.Context, in *ApplyPresetRequest, opts ...grpc.CallOption) (*ApplyPresetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyPresetResponse)
+	err := c.cc.Invoke(ctx, PolicyPresetService_ApplyPreset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PolicyPresetServiceServer is the server API for PolicyPresetService service.
+// All implementations must embed UnimplementedPolicyPresetServiceServer
+// for forward compatibility.
+//
+// PolicyPresetService applies named presets ("strict", "balanced", "byod_friendly", ...) to an org's MFA settings,
+// Rego policy and policy config in one step.
+type PolicyPresetServiceServer interface {
+	// ListPresets returns the presets with their descriptions, for UIs. Any signed-in user may call it.
+	ListPresets(context.Context, *ListPresetsRequest) (*ListPresetsResponse, error)
+	// ApplyPreset replaces the caller's org's config sections the preset sets, recorded as a config version with source
+	// preset, disables the org's enabled Rego policies and creates the preset's policy, in one transaction, then syncs
+	// the org's MFA settings. Caller must be org admin or owner. Fails with FailedPrecondition while the org requires
+	// change approval.
+	ApplyPreset(context.Context, *ApplyPresetRequest) (*ApplyPresetResponse, error)
+	mustEmbedUnimplementedPolicyPresetServiceServer()
+}
+
+// UnimplementedPolicyPresetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPolicyPresetServiceServer struct{}
+
+func (UnimplementedPolicyPresetServiceServer) ListPresets(context.Context, *ListPresetsRequest) (*ListPresetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPresets not implemented")
+}
+func (UnimplementedPolicyPresetServiceServer) ApplyPreset(context.Context, *ApplyPresetRequest) (*ApplyPresetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApplyPreset not implemented")
+}
+func (UnimplementedPolicyPresetServiceServer) mustEmbedUnimplementedPolicyPresetServiceServer() {}
+func (UnimplementedPolicyPresetServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafePolicyPresetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PolicyPresetServiceServer will
+// result in compilation errors.
+type UnsafePolicyPresetServiceServer interface {
+	mustEmbedUnimplementedPolicyPresetServiceServer()
+}
+
+func RegisterPolicyPresetServiceServer(s grpc.ServiceRegistrar, srv PolicyPresetServiceServer) {
+	// If the following call panics, it indicates UnimplementedPolicyPresetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PolicyPresetService_ServiceDesc, srv)
+}
+
+func _PolicyPresetService_ListPresets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPresetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyPresetServiceServer).ListPresets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyPresetService_ListPresets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyPresetServiceServer).ListPresets(ctx, req.(*ListPresetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyPresetService_ApplyPreset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyPresetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyPresetServiceServer).ApplyPreset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyPresetService_ApplyPreset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyPresetServiceServer).ApplyPreset(ctx, req.(*ApplyPresetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PolicyPresetService_ServiceDesc is the grpc.ServiceDesc for PolicyPresetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PolicyPresetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.policypreset.v1.PolicyPresetService",
+	HandlerType: (*PolicyPresetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPresets",
+			Handler:    _PolicyPresetService_ListPresets_Handler,
+		},
+		{
+			MethodName: "ApplyPreset",
+			Handler:    _PolicyPresetService_ApplyPreset_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "policypreset/policypreset.proto",
+}
@@ -0,0 +1,515 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: policyviolation/policyviolation.proto
+
+package policyviolationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PolicyViolation is one action an agent blocked because of the org's action_restrictions.
+type PolicyViolation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId           string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId        string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // device of the reporting session
+	SessionId       string                 `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Action          string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"` // navigate, download, upload, copy_paste
+	Target          string                 `protobuf:"bytes,7,opt,name=target,proto3" json:"target,omitempty"` // URL or resource the action was attempted on; may be empty
+	StepUpTriggered bool                   `protobuf:"varint,8,opt,name=step_up_triggered,json=stepUpTriggered,proto3" json:"step_up_triggered,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Rule            string                 `protobuf:"bytes,10,opt,name=rule,proto3" json:"rule,omitempty"` // DLP rule that blocked the action; empty for allowed_actions and read_only_mode
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PolicyViolation) Reset() {
+	*x = PolicyViolation{}
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyViolation) ProtoMessage() {}
+
+func (x *PolicyViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyViolation.ProtoReflect.Descriptor instead.
+func (*PolicyViolation) Descriptor() ([]byte, []int) {
+	return file_policyviolation_policyviolation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PolicyViolation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *PolicyViolation) GetStepUpTriggered() bool {
+	if x != nil {
+		return x.StepUpTriggered
+	}
+	return false
+}
+
+func (x *PolicyViolation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PolicyViolation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+// ReportPolicyViolationRequest is sent by a browser agent after it blocked an action.
+// org, user, session and device are taken from the caller's access token.
+type ReportPolicyViolationRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	OrgId  string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"` // optional; must match the caller's org when set
+	Action string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`            // required: navigate, download, upload, copy_paste
+	Target string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`            // optional, max 2048 characters
+	// optional: the DLP rule of action_restrictions that blocked the action. max_upload_bytes (upload),
+	// blocked_file_extensions (upload, download) or clipboard_blocked_domains (copy_paste). Empty when the action
+	// is not in allowed_actions or read_only_mode is on.
+	Rule          string `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportPolicyViolationRequest) Reset() {
+	*x = ReportPolicyViolationRequest{}
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPolicyViolationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPolicyViolationRequest) ProtoMessage() {}
+
+func (x *ReportPolicyViolationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPolicyViolationRequest.ProtoReflect.Descriptor instead.
+func (*ReportPolicyViolationRequest) Descriptor() ([]byte, []int) {
+	return file_policyviolation_policyviolation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReportPolicyViolationRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ReportPolicyViolationRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ReportPolicyViolationRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ReportPolicyViolationRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+type ReportPolicyViolationResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ViolationId string                 `protobuf:"bytes,1,opt,name=violation_id,json=violationId,proto3" json:"violation_id,omitempty"`
+	// True when the org has auth_mfa.step_up_policy_violation enabled: the session was revoked and the device's
+	// trust cleared, so the agent must send the user through sign-in (with MFA) again.
+	StepUpRequired bool `protobuf:"varint,2,opt,name=step_up_required,json=stepUpRequired,proto3" json:"step_up_required,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReportPolicyViolationResponse) Reset() {
+	*x = ReportPolicyViolationResponse{}
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPolicyViolationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPolicyViolationResponse) ProtoMessage() {}
+
+func (x *ReportPolicyViolationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPolicyViolationResponse.ProtoReflect.Descriptor instead.
+func (*ReportPolicyViolationResponse) Descriptor() ([]byte, []int) {
+	return file_policyviolation_policyviolation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReportPolicyViolationResponse) GetViolationId() string {
+	if x != nil {
+		return x.ViolationId
+	}
+	return ""
+}
+
+func (x *ReportPolicyViolationResponse) GetStepUpRequired() bool {
+	if x != nil {
+		return x.StepUpRequired
+	}
+	return false
+}
+
+// ListPolicyViolationsRequest lists the org's violations, newest first. Filters are optional.
+type ListPolicyViolationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Pagination    *v1.Pagination         `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Rule          string                 `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPolicyViolationsRequest) Reset() {
+	*x = ListPolicyViolationsRequest{}
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPolicyViolationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPolicyViolationsRequest) ProtoMessage() {}
+
+func (x *ListPolicyViolationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPolicyViolationsRequest.ProtoReflect.Descriptor instead.
+func (*ListPolicyViolationsRequest) Descriptor() ([]byte, []int) {
+	return file_policyviolation_policyviolation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPolicyViolationsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ListPolicyViolationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPolicyViolationsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ListPolicyViolationsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListPolicyViolationsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListPolicyViolationsRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+type ListPolicyViolationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Violations    []*PolicyViolation     `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPolicyViolationsResponse) Reset() {
+	*x = ListPolicyViolationsResponse{}
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPolicyViolationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPolicyViolationsResponse) ProtoMessage() {}
+
+func (x *ListPolicyViolationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_policyviolation_policyviolation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPolicyViolationsResponse.ProtoReflect.Descriptor instead.
+func (*ListPolicyViolationsResponse) Descriptor() ([]byte, []int) {
+	return file_policyviolation_policyviolation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPolicyViolationsResponse) GetViolations() []*PolicyViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ListPolicyViolationsResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_policyviolation_policyviolation_proto protoreflect.FileDescriptor
+
+const file_policyviolation_policyviolation_proto_rawDesc = "" +
+	"\n" +
+	"%policyviolation/policyviolation.proto\x12\x17ztcp.policyviolation.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb8\x02\n" +
+	"\x0fPolicyViolation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x05 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\a \x01(\tR\x06target\x12*\n" +
+	"\x11step_up_triggered\x18\b \x01(\bR\x0fstepUpTriggered\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04rule\x18\n" +
+	" \x01(\tR\x04rule\"y\n" +
+	"\x1cReportPolicyViolationRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x12\n" +
+	"\x04rule\x18\x04 \x01(\tR\x04rule\"l\n" +
+	"\x1dReportPolicyViolationResponse\x12!\n" +
+	"\fviolation_id\x18\x01 \x01(\tR\vviolationId\x12(\n" +
+	"\x10step_up_required\x18\x02 \x01(\bR\x0estepUpRequired\"\xd2\x01\n" +
+	"\x1bListPolicyViolationsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12:\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\x12\x12\n" +
+	"\x04rule\x18\x06 \x01(\tR\x04rule\"\xaa\x01\n" +
+	"\x1cListPolicyViolationsResponse\x12H\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2(.ztcp.policyviolation.v1.PolicyViolationR\n" +
+	"violations\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination2\xac\x02\n" +
+	"\x16PolicyViolationService\x12\x86\x01\n" +
+	"\x15ReportPolicyViolation\x125.ztcp.policyviolation.v1.ReportPolicyViolationRequest\x1a6.ztcp.policyviolation.v1.ReportPolicyViolationResponse\x12\x88\x01\n" +
+	"\x14ListPolicyViolations\x124.ztcp.policyviolation.v1.ListPolicyViolationsRequest\x1a5.ztcp.policyviolation.v1.ListPolicyViolationsResponse\"\x03\x90\x02\x01BUZSzero-trust-control-plane/backend/api/generated/policyviolation/v1;policyviolationv1b\x06proto3"
+
+var (
+	file_policyviolation_policyviolation_proto_rawDescOnce sync.Once
+	file_policyviolation_policyviolation_proto_rawDescData []byte
+)
+
+func file_policyviolation_policyviolation_proto_rawDescGZIP() []byte {
+	file_policyviolation_policyviolation_proto_rawDescOnce.Do(func() {
+		file_policyviolation_policyviolation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_policyviolation_policyviolation_proto_rawDesc), len(file_policyviolation_policyviolation_proto_rawDesc)))
+	})
+	return file_policyviolation_policyviolation_proto_rawDescData
+}
+
+var file_policyviolation_policyviolation_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_policyviolation_policyviolation_proto_goTypes = []any{
+	(*PolicyViolation)(nil),               // 0: ztcp.policyviolation.v1.PolicyViolation
+	(*ReportPolicyViolationRequest)(nil),  // 1: ztcp.policyviolation.v1.ReportPolicyViolationRequest
+	(*ReportPolicyViolationResponse)(nil), // 2: ztcp.policyviolation.v1.ReportPolicyViolationResponse
+	(*ListPolicyViolationsRequest)(nil),   // 3: ztcp.policyviolation.v1.ListPolicyViolationsRequest
+	(*ListPolicyViolationsResponse)(nil),  // 4: ztcp.policyviolation.v1.ListPolicyViolationsResponse
+	(*timestamppb.Timestamp)(nil),         // 5: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),                 // 6: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),           // 7: ztcp.common.v1.PaginationResult
+}
+var file_policyviolation_policyviolation_proto_depIdxs = []int32{
+	5, // 0: ztcp.policyviolation.v1.PolicyViolation.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: ztcp.policyviolation.v1.ListPolicyViolationsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	0, // 2: ztcp.policyviolation.v1.ListPolicyViolationsResponse.violations:type_name -> ztcp.policyviolation.v1.PolicyViolation
+	7, // 3: ztcp.policyviolation.v1.ListPolicyViolationsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	1, // 4: ztcp.policyviolation.v1.PolicyViolationService.ReportPolicyViolation:input_type -> ztcp.policyviolation.v1.ReportPolicyViolationRequest
+	3, // 5: ztcp.policyviolation.v1.PolicyViolationService.ListPolicyViolations:input_type -> ztcp.policyviolation.v1.ListPolicyViolationsRequest
+	2, // 6: ztcp.policyviolation.v1.PolicyViolationService.ReportPolicyViolation:output_type -> ztcp.policyviolation.v1.ReportPolicyViolationResponse
+	4, // 7: ztcp.policyviolation.v1.PolicyViolationService.ListPolicyViolations:output_type -> ztcp.policyviolation.v1.ListPolicyViolationsResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_policyviolation_policyviolation_proto_init() }
+func file_policyviolation_policyviolation_proto_init() {
+	if File_policyviolation_policyviolation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_policyviolation_policyviolation_proto_rawDesc), len(file_policyviolation_policyviolation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_policyviolation_policyviolation_proto_goTypes,
+		DependencyIndexes: file_policyviolation_policyviolation_proto_depIdxs,
+		MessageInfos:      file_policyviolation_policyviolation_proto_msgTypes,
+	}.Build()
+	File_policyviolation_policyviolation_proto = out.File
+	file_policyviolation_policyviolation_proto_goTypes = nil
+	file_policyviolation_policyviolation_proto_depIdxs = nil
+}
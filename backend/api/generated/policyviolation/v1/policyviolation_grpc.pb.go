@@ -0,0 +1,170 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: policyviolation/policyviolation.proto
+
+package policyviolationv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PolicyViolationService_ReportPolicyViolation_FullMethodName = "/ztcp.policyviolation.v1.PolicyViolationService/ReportPolicyViolation"
+	PolicyViolationService_ListPolicyViolations_FullMethodName  = "/ztcp.policyviolation.v1.PolicyViolationService/ListPolicyViolations"
+)
+
+// PolicyViolationServiceClient is the client API for PolicyViolationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PolicyViolationService closes the loop on action_restrictions: agents report blocked actions and org admins
+// review them. Daily counts are rolled up into AnalyticsService (GetPolicyViolationStats).
+type PolicyViolationServiceClient interface {
+	// ReportPolicyViolation records a blocked action for the calling session. Any org member.
+	ReportPolicyViolation(ctx context.Context, in *ReportPolicyViolationRequest, opts ...grpc.CallOption) (*ReportPolicyViolationResponse, error)
+	// ListPolicyViolations returns the org's reported violations. Org admin or owner.
+	ListPolicyViolations(ctx context.Context, in *ListPolicyViolationsRequest, opts ...grpc.CallOption) (*ListPolicyViolationsResponse, error)
+}
+
+type policyViolationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPolicyViolationServiceClient(cc grpc.ClientConnInterface) PolicyViolationServiceClient {
+	return &policyViolationServiceClient{cc}
+}
+
+func (c *policyViolationServiceClient) ReportPolicyViolation(ctx context.Context, in *ReportPolicyViolationRequest, opts ...grpc.CallOption) (*ReportPolicyViolationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportPolicyViolationResponse)
+	err := c.cc.Invoke(ctx, PolicyViolationService_ReportPolicyViolation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyViolationServiceClient) ListPolicyViolations(ctx context.Context, in *ListPolicyViolationsRequest, opts ...grpc.CallOption) (*ListPolicyViolationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPolicyViolationsResponse)
+	err := c.cc.Invoke(ctx, PolicyViolationService_ListPolicyViolations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PolicyViolationServiceServer is the server API for PolicyViolationService service.
+// All implementations must embed UnimplementedPolicyViolationServiceServer
+// for forward compatibility.
+//
+// PolicyViolationService closes the loop on action_restrictions: agents report blocked actions and org admins
+// review them. Daily counts are rolled up into AnalyticsService (GetPolicyViolationStats).
+type PolicyViolationServiceServer interface {
+	// ReportPolicyViolation records a blocked action for the calling session. Any org member.
+	ReportPolicyViolation(context.Context, *ReportPolicyViolationRequest) (*ReportPolicyViolationResponse, error)
+	// ListPolicyViolations returns the org's reported violations. Org admin or owner.
+	ListPolicyViolations(context.Context, *ListPolicyViolationsRequest) (*ListPolicyViolationsResponse, error)
+	mustEmbedUnimplementedPolicyViolationServiceServer()
+}
+
+// UnimplementedPolicyViolationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPolicyViolationServiceServer struct{}
+
+func (UnimplementedPolicyViolationServiceServer) ReportPolicyViolation(context.Context, *ReportPolicyViolationRequest) (*ReportPolicyViolationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportPolicyViolation not implemented")
+}
+func (UnimplementedPolicyViolationServiceServer) ListPolicyViolations(context.Context, *ListPolicyViolationsRequest) (*ListPolicyViolationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPolicyViolations not implemented")
+}
+func (UnimplementedPolicyViolationServiceServer) mustEmbedUnimplementedPolicyViolationServiceServer() {
+}
+func (UnimplementedPolicyViolationServiceServer) testEmbeddedByValue() {}
+
+// UnsafePolicyViolationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PolicyViolationServiceServer will
+// result in compilation errors.
+type UnsafePolicyViolationServiceServer interface {
+	mustEmbedUnimplementedPolicyViolationServiceServer()
+}
+
+func RegisterPolicyViolationServiceServer(s grpc.ServiceRegistrar, srv PolicyViolationServiceServer) {
+	// If the following call panics, it indicates UnimplementedPolicyViolationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PolicyViolationService_ServiceDesc, srv)
+}
+
+func _PolicyViolationService_ReportPolicyViolation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportPolicyViolationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyViolationServiceServer).ReportPolicyViolation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyViolationService_ReportPolicyViolation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyViolationServiceServer).ReportPolicyViolation(ctx, req.(*ReportPolicyViolationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyViolationService_ListPolicyViolations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPolicyViolationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyViolationServiceServer).ListPolicyViolations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyViolationService_ListPolicyViolations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyViolationServiceServer).ListPolicyViolations(ctx, req.(*ListPolicyViolationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PolicyViolationService_ServiceDesc is the grpc.ServiceDesc for PolicyViolationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PolicyViolationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.policyviolation.v1.PolicyViolationService",
+	HandlerType: (*PolicyViolationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportPolicyViolation",
+			Handler:    _PolicyViolationService_ReportPolicyViolation_Handler,
+		},
+		{
+			MethodName: "ListPolicyViolations",
+			Handler:    _PolicyViolationService_ListPolicyViolations_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "policyviolation/policyviolation.proto",
+}
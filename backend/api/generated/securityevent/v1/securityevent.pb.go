@@ -0,0 +1,534 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: securityevent/securityevent.proto
+
+package securityeventv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SecurityEvent is one entry in a user's security activity feed.
+type SecurityEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId          string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type           string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`         // login_failure, refresh_token_reuse, impossible_travel, device_revoked
+	Severity       string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"` // low, medium, high
+	Ip             string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	Metadata       string                 `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON object with type-specific details
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AcknowledgedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	DismissedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=dismissed_at,json=dismissedAt,proto3" json:"dismissed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SecurityEvent) Reset() {
+	*x = SecurityEvent{}
+	mi := &file_securityevent_securityevent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityEvent) ProtoMessage() {}
+
+func (x *SecurityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_securityevent_securityevent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityEvent.ProtoReflect.Descriptor instead.
+func (*SecurityEvent) Descriptor() ([]byte, []int) {
+	return file_securityevent_securityevent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SecurityEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SecurityEvent) GetAcknowledgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return nil
+}
+
+func (x *SecurityEvent) GetDismissedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DismissedAt
+	}
+	return nil
+}
+
+// ListSecurityEventsRequest lists the caller's security events, newest first.
+type ListSecurityEventsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Pagination       *v1.Pagination         `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	IncludeDismissed bool                   `protobuf:"varint,2,opt,name=include_dismissed,json=includeDismissed,proto3" json:"include_dismissed,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsRequest) Reset() {
+	*x = ListSecurityEventsRequest{}
+	mi := &file_securityevent_securityevent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsRequest) ProtoMessage() {}
+
+func (x *ListSecurityEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_securityevent_securityevent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsRequest) Descriptor() ([]byte, []int) {
+	return file_securityevent_securityevent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSecurityEventsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListSecurityEventsRequest) GetIncludeDismissed() bool {
+	if x != nil {
+		return x.IncludeDismissed
+	}
+	return false
+}
+
+type ListSecurityEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SecurityEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsResponse) Reset() {
+	*x = ListSecurityEventsResponse{}
+	mi := &file_securityevent_securityevent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsResponse) ProtoMessage() {}
+
+func (x *ListSecurityEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_securityevent_securityevent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsResponse) Descriptor() ([]byte, []int) {
+	return file_securityevent_securityevent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSecurityEventsResponse) GetEvents() []*SecurityEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListSecurityEventsResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type AcknowledgeSecurityEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeSecurityEventRequest) Reset() {
+	*x = AcknowledgeSecurityEventRequest{}
+	mi := &file_securityevent_securityevent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeSecurityEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeSecurityEventRequest) ProtoMessage() {}
+
+func (x *AcknowledgeSecurityEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_securityevent_securityevent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeSecurityEventRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeSecurityEventRequest) Descriptor() ([]byte, []int) {
+	return file_securityevent_securityevent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AcknowledgeSecurityEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type AcknowledgeSecurityEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *SecurityEvent         `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeSecurityEventResponse) Reset() {
+	*x = AcknowledgeSecurityEventResponse{}
+	mi := &file_securityevent_securityevent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeSecurityEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeSecurityEventResponse) ProtoMessage() {}
+
+func (x *AcknowledgeSecurityEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_securityevent_securityevent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeSecurityEventResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeSecurityEventResponse) Descriptor() ([]byte, []int) {
+	return file_securityevent_securityevent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AcknowledgeSecurityEventResponse) GetEvent() *SecurityEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type DismissSecurityEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DismissSecurityEventRequest) Reset() {
+	*x = DismissSecurityEventRequest{}
+	mi := &file_securityevent_securityevent_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DismissSecurityEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DismissSecurityEventRequest) ProtoMessage() {}
+
+func (x *DismissSecurityEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_securityevent_securityevent_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DismissSecurityEventRequest.ProtoReflect.Descriptor instead.
+func (*DismissSecurityEventRequest) Descriptor() ([]byte, []int) {
+	return file_securityevent_securityevent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DismissSecurityEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type DismissSecurityEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *SecurityEvent         `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DismissSecurityEventResponse) Reset() {
+	*x = DismissSecurityEventResponse{}
+	mi := &file_securityevent_securityevent_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DismissSecurityEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DismissSecurityEventResponse) ProtoMessage() {}
+
+func (x *DismissSecurityEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_securityevent_securityevent_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DismissSecurityEventResponse.ProtoReflect.Descriptor instead.
+func (*DismissSecurityEventResponse) Descriptor() ([]byte, []int) {
+	return file_securityevent_securityevent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DismissSecurityEventResponse) GetEvent() *SecurityEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_securityevent_securityevent_proto protoreflect.FileDescriptor
+
+const file_securityevent_securityevent_proto_rawDesc = "" +
+	"\n" +
+	"!securityevent/securityevent.proto\x12\x15ztcp.securityevent.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xea\x02\n" +
+	"\rSecurityEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x1a\n" +
+	"\bmetadata\x18\a \x01(\tR\bmetadata\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12C\n" +
+	"\x0facknowledged_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0eacknowledgedAt\x12=\n" +
+	"\fdismissed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vdismissedAt\"\x84\x01\n" +
+	"\x19ListSecurityEventsRequest\x12:\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\x12+\n" +
+	"\x11include_dismissed\x18\x02 \x01(\bR\x10includeDismissed\"\x9c\x01\n" +
+	"\x1aListSecurityEventsResponse\x12<\n" +
+	"\x06events\x18\x01 \x03(\v2$.ztcp.securityevent.v1.SecurityEventR\x06events\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"<\n" +
+	"\x1fAcknowledgeSecurityEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\"^\n" +
+	" AcknowledgeSecurityEventResponse\x12:\n" +
+	"\x05event\x18\x01 \x01(\v2$.ztcp.securityevent.v1.SecurityEventR\x05event\"8\n" +
+	"\x1bDismissSecurityEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\"Z\n" +
+	"\x1cDismissSecurityEventResponse\x12:\n" +
+	"\x05event\x18\x01 \x01(\v2$.ztcp.securityevent.v1.SecurityEventR\x05event2\xa6\x03\n" +
+	"\x15SecurityEventsService\x12~\n" +
+	"\x12ListSecurityEvents\x120.ztcp.securityevent.v1.ListSecurityEventsRequest\x1a1.ztcp.securityevent.v1.ListSecurityEventsResponse\"\x03\x90\x02\x01\x12\x8b\x01\n" +
+	"\x18AcknowledgeSecurityEvent\x126.ztcp.securityevent.v1.AcknowledgeSecurityEventRequest\x1a7.ztcp.securityevent.v1.AcknowledgeSecurityEventResponse\x12\x7f\n" +
+	"\x14DismissSecurityEvent\x122.ztcp.securityevent.v1.DismissSecurityEventRequest\x1a3.ztcp.securityevent.v1.DismissSecurityEventResponseBQZOzero-trust-control-plane/backend/api/generated/securityevent/v1;securityeventv1b\x06proto3"
+
+var (
+	file_securityevent_securityevent_proto_rawDescOnce sync.Once
+	file_securityevent_securityevent_proto_rawDescData []byte
+)
+
+func file_securityevent_securityevent_proto_rawDescGZIP() []byte {
+	file_securityevent_securityevent_proto_rawDescOnce.Do(func() {
+		file_securityevent_securityevent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_securityevent_securityevent_proto_rawDesc), len(file_securityevent_securityevent_proto_rawDesc)))
+	})
+	return file_securityevent_securityevent_proto_rawDescData
+}
+
+var file_securityevent_securityevent_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_securityevent_securityevent_proto_goTypes = []any{
+	(*SecurityEvent)(nil),                    // 0: ztcp.securityevent.v1.SecurityEvent
+	(*ListSecurityEventsRequest)(nil),        // 1: ztcp.securityevent.v1.ListSecurityEventsRequest
+	(*ListSecurityEventsResponse)(nil),       // 2: ztcp.securityevent.v1.ListSecurityEventsResponse
+	(*AcknowledgeSecurityEventRequest)(nil),  // 3: ztcp.securityevent.v1.AcknowledgeSecurityEventRequest
+	(*AcknowledgeSecurityEventResponse)(nil), // 4: ztcp.securityevent.v1.AcknowledgeSecurityEventResponse
+	(*DismissSecurityEventRequest)(nil),      // 5: ztcp.securityevent.v1.DismissSecurityEventRequest
+	(*DismissSecurityEventResponse)(nil),     // 6: ztcp.securityevent.v1.DismissSecurityEventResponse
+	(*timestamppb.Timestamp)(nil),            // 7: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),                    // 8: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),              // 9: ztcp.common.v1.PaginationResult
+}
+var file_securityevent_securityevent_proto_depIdxs = []int32{
+	7,  // 0: ztcp.securityevent.v1.SecurityEvent.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 1: ztcp.securityevent.v1.SecurityEvent.acknowledged_at:type_name -> google.protobuf.Timestamp
+	7,  // 2: ztcp.securityevent.v1.SecurityEvent.dismissed_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: ztcp.securityevent.v1.ListSecurityEventsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	0,  // 4: ztcp.securityevent.v1.ListSecurityEventsResponse.events:type_name -> ztcp.securityevent.v1.SecurityEvent
+	9,  // 5: ztcp.securityevent.v1.ListSecurityEventsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	0,  // 6: ztcp.securityevent.v1.AcknowledgeSecurityEventResponse.event:type_name -> ztcp.securityevent.v1.SecurityEvent
+	0,  // 7: ztcp.securityevent.v1.DismissSecurityEventResponse.event:type_name -> ztcp.securityevent.v1.SecurityEvent
+	1,  // 8: ztcp.securityevent.v1.SecurityEventsService.ListSecurityEvents:input_type -> ztcp.securityevent.v1.ListSecurityEventsRequest
+	3,  // 9: ztcp.securityevent.v1.SecurityEventsService.AcknowledgeSecurityEvent:input_type -> ztcp.securityevent.v1.AcknowledgeSecurityEventRequest
+	5,  // 10: ztcp.securityevent.v1.SecurityEventsService.DismissSecurityEvent:input_type -> ztcp.securityevent.v1.DismissSecurityEventRequest
+	2,  // 11: ztcp.securityevent.v1.SecurityEventsService.ListSecurityEvents:output_type -> ztcp.securityevent.v1.ListSecurityEventsResponse
+	4,  // 12: ztcp.securityevent.v1.SecurityEventsService.AcknowledgeSecurityEvent:output_type -> ztcp.securityevent.v1.AcknowledgeSecurityEventResponse
+	6,  // 13: ztcp.securityevent.v1.SecurityEventsService.DismissSecurityEvent:output_type -> ztcp.securityevent.v1.DismissSecurityEventResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_securityevent_securityevent_proto_init() }
+func file_securityevent_securityevent_proto_init() {
+	if File_securityevent_securityevent_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_securityevent_securityevent_proto_rawDesc), len(file_securityevent_securityevent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_securityevent_securityevent_proto_goTypes,
+		DependencyIndexes: file_securityevent_securityevent_proto_depIdxs,
+		MessageInfos:      file_securityevent_securityevent_proto_msgTypes,
+	}.Build()
+	File_securityevent_securityevent_proto = out.File
+	file_securityevent_securityevent_proto_goTypes = nil
+	file_securityevent_securityevent_proto_depIdxs = nil
+}
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: securityevent/securityevent.proto
+
+package securityeventv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SecurityEventsService_ListSecurityEvents_FullMethodName       = "/ztcp.securityevent.v1.SecurityEventsService/ListSecurityEvents"
+	SecurityEventsService_AcknowledgeSecurityEvent_FullMethodName = "/ztcp.securityevent.v1.SecurityEventsService/AcknowledgeSecurityEvent"
+	SecurityEventsService_DismissSecurityEvent_FullMethodName     = "/ztcp.securityevent.v1.SecurityEventsService/DismissSecurityEvent"
+)
+
+// SecurityEventsServiceClient is the client API for SecurityEventsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SecurityEventsService exposes the per-user "recent security activity" feed.
+// Users can list, acknowledge, and dismiss their own events.
+type SecurityEventsServiceClient interface {
+	ListSecurityEvents(ctx context.Context, in *ListSecurityEventsRequest, opts ...grpc.CallOption) (*ListSecurityEventsResponse, error)
+	AcknowledgeSecurityEvent(ctx context.Context, in *AcknowledgeSecurityEventRequest, opts ...grpc.CallOption) (*AcknowledgeSecurityEventResponse, error)
+	DismissSecurityEvent(ctx context.Context, in *DismissSecurityEventRequest, opts ...grpc.CallOption) (*DismissSecurityEventResponse, error)
+}
+
+type securityEventsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSecurityEventsServiceClient(cc grpc.ClientConnInterface) SecurityEventsServiceClient {
+	return &securityEventsServiceClient{cc}
+}
+
+func (c *securityEventsServiceClient) ListSecurityEvents(ctx context.Context, in *ListSecurityEventsRequest, opts ...grpc.CallOption) (*ListSecurityEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecurityEventsResponse)
+	err := c.cc.Invoke(ctx, SecurityEventsService_ListSecurityEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *securityEventsServiceClient) AcknowledgeSecurityEvent(ctx context.Context, in *AcknowledgeSecurityEventRequest, opts ...grpc.CallOption) (*AcknowledgeSecurityEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeSecurityEventResponse)
+	err := c.cc.Invoke(ctx, SecurityEventsService_AcknowledgeSecurityEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *securityEventsServiceClient) DismissSecurityEvent(ctx context.Context, in *DismissSecurityEventRequest, opts ...grpc.CallOption) (*DismissSecurityEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DismissSecurityEventResponse)
+	err := c.cc.Invoke(ctx, SecurityEventsService_DismissSecurityEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SecurityEventsServiceServer is the server API for SecurityEventsService service.
+// All implementations must embed UnimplementedSecurityEventsServiceServer
+// for forward compatibility.
+//
+// SecurityEventsService exposes the per-user "recent security activity" feed.
+// Users can list, acknowledge, and dismiss their own events.
+type SecurityEventsServiceServer interface {
+	ListSecurityEvents(context.Context, *ListSecurityEventsRequest) (*ListSecurityEventsResponse, error)
+	AcknowledgeSecurityEvent(context.Context, *AcknowledgeSecurityEventRequest) (*AcknowledgeSecurityEventResponse, error)
+	DismissSecurityEvent(context.Context, *DismissSecurityEventRequest) (*DismissSecurityEventResponse, error)
+	mustEmbedUnimplementedSecurityEventsServiceServer()
+}
+
+// UnimplementedSecurityEventsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSecurityEventsServiceServer struct{}
+
+func (UnimplementedSecurityEventsServiceServer) ListSecurityEvents(context.Context, *ListSecurityEventsRequest) (*ListSecurityEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSecurityEvents not implemented")
+}
+func (UnimplementedSecurityEventsServiceServer) AcknowledgeSecurityEvent(context.Context, *AcknowledgeSecurityEventRequest) (*AcknowledgeSecurityEventResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeSecurityEvent not implemented")
+}
+func (UnimplementedSecurityEventsServiceServer) DismissSecurityEvent(context.Context, *DismissSecurityEventRequest) (*DismissSecurityEventResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DismissSecurityEvent not implemented")
+}
+func (UnimplementedSecurityEventsServiceServer) mustEmbedUnimplementedSecurityEventsServiceServer() {}
+func (UnimplementedSecurityEventsServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeSecurityEventsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SecurityEventsServiceServer will
+// result in compilation errors.
+type UnsafeSecurityEventsServiceServer interface {
+	mustEmbedUnimplementedSecurityEventsServiceServer()
+}
+
+func RegisterSecurityEventsServiceServer(s grpc.ServiceRegistrar, srv SecurityEventsServiceServer) {
+	// If the following call panics, it indicates UnimplementedSecurityEventsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SecurityEventsService_ServiceDesc, srv)
+}
+
+func _SecurityEventsService_ListSecurityEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecurityEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityEventsServiceServer).ListSecurityEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityEventsService_ListSecurityEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityEventsServiceServer).ListSecurityEvents(ctx, req.(*ListSecurityEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecurityEventsService_AcknowledgeSecurityEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeSecurityEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityEventsServiceServer).AcknowledgeSecurityEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityEventsService_AcknowledgeSecurityEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityEventsServiceServer).AcknowledgeSecurityEvent(ctx, req.(*AcknowledgeSecurityEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecurityEventsService_DismissSecurityEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DismissSecurityEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityEventsServiceServer).DismissSecurityEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecurityEventsService_DismissSecurityEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityEventsServiceServer).DismissSecurityEvent(ctx, req.(*DismissSecurityEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SecurityEventsService_ServiceDesc is the grpc.ServiceDesc for SecurityEventsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SecurityEventsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.securityevent.v1.SecurityEventsService",
+	HandlerType: (*SecurityEventsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSecurityEvents",
+			Handler:    _SecurityEventsService_ListSecurityEvents_Handler,
+		},
+		{
+			MethodName: "AcknowledgeSecurityEvent",
+			Handler:    _SecurityEventsService_AcknowledgeSecurityEvent_Handler,
+		},
+		{
+			MethodName: "DismissSecurityEvent",
+			Handler:    _SecurityEventsService_DismissSecurityEvent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "securityevent/securityevent.proto",
+}
@@ -35,6 +35,19 @@ type Session struct {
 	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
 	IpAddress     string                 `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,10,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`             // client User-Agent at sign-in
+	ClientVersion string                 `protobuf:"bytes,11,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"` // client app version at sign-in (x-client-version metadata)
+	AuthMethod    string                 `protobuf:"bytes,12,opt,name=auth_method,json=authMethod,proto3" json:"auth_method,omitempty"`          // primary factor, e.g. "password"; empty for sessions created before it was recorded
+	MfaMethod     string                 `protobuf:"bytes,13,opt,name=mfa_method,json=mfaMethod,proto3" json:"mfa_method,omitempty"`             // second factor, e.g. "sms_otp" or "recovery_code"; empty when none was used
+	User          *SessionUser           `protobuf:"bytes,14,opt,name=user,proto3" json:"user,omitempty"`                                        // set only when requested with include_user
+	Device        *SessionDevice         `protobuf:"bytes,15,opt,name=device,proto3" json:"device,omitempty"`                                    // set only when requested with include_device
+	// revocation_reason is why the session was revoked: logout, admin_revoke, reuse_detected, policy_change,
+	// idle_timeout or break_glass. Empty while active, and for sessions revoked before reasons were recorded.
+	RevocationReason string `protobuf:"bytes,16,opt,name=revocation_reason,json=revocationReason,proto3" json:"revocation_reason,omitempty"`
+	RevokedBy        string `protobuf:"bytes,17,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"` // user ID of who revoked the session; empty when the system did (e.g. reuse_detected)
+	// ephemeral marks a public/shared device sign-in (Login public_device): no device_id or device, a short lifetime,
+	// and revoked with idle_timeout when left unused.
+	Ephemeral     bool `protobuf:"varint,18,opt,name=ephemeral,proto3" json:"ephemeral,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -132,6 +145,191 @@ func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *Session) GetAuthMethod() string {
+	if x != nil {
+		return x.AuthMethod
+	}
+	return ""
+}
+
+func (x *Session) GetMfaMethod() string {
+	if x != nil {
+		return x.MfaMethod
+	}
+	return ""
+}
+
+func (x *Session) GetUser() *SessionUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Session) GetDevice() *SessionDevice {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *Session) GetRevocationReason() string {
+	if x != nil {
+		return x.RevocationReason
+	}
+	return ""
+}
+
+func (x *Session) GetRevokedBy() string {
+	if x != nil {
+		return x.RevokedBy
+	}
+	return ""
+}
+
+func (x *Session) GetEphemeral() bool {
+	if x != nil {
+		return x.Ephemeral
+	}
+	return false
+}
+
+// SessionUser is the user a session belongs to.
+type SessionUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionUser) Reset() {
+	*x = SessionUser{}
+	mi := &file_session_session_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionUser) ProtoMessage() {}
+
+func (x *SessionUser) ProtoReflect() protoreflect.Message {
+	mi := &file_session_session_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionUser.ProtoReflect.Descriptor instead.
+func (*SessionUser) Descriptor() ([]byte, []int) {
+	return file_session_session_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SessionUser) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SessionUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SessionUser) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// SessionDevice is the device a session was created on. Devices have no display name; the fingerprint identifies them.
+type SessionDevice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	Trusted       bool                   `protobuf:"varint,3,opt,name=trusted,proto3" json:"trusted,omitempty"` // effective trust: trusted, not revoked and trust not expired
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionDevice) Reset() {
+	*x = SessionDevice{}
+	mi := &file_session_session_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionDevice) ProtoMessage() {}
+
+func (x *SessionDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_session_session_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionDevice.ProtoReflect.Descriptor instead.
+func (*SessionDevice) Descriptor() ([]byte, []int) {
+	return file_session_session_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SessionDevice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SessionDevice) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *SessionDevice) GetTrusted() bool {
+	if x != nil {
+		return x.Trusted
+	}
+	return false
+}
+
 // RevokeSessionRequest identifies the session to revoke.
 type RevokeSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -142,7 +340,7 @@ type RevokeSessionRequest struct {
 
 func (x *RevokeSessionRequest) Reset() {
 	*x = RevokeSessionRequest{}
-	mi := &file_session_session_proto_msgTypes[1]
+	mi := &file_session_session_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +352,7 @@ func (x *RevokeSessionRequest) String() string {
 func (*RevokeSessionRequest) ProtoMessage() {}
 
 func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[1]
+	mi := &file_session_session_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,7 +365,7 @@ func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
 func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{1}
+	return file_session_session_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RevokeSessionRequest) GetSessionId() string {
@@ -186,7 +384,7 @@ type RevokeSessionResponse struct {
 
 func (x *RevokeSessionResponse) Reset() {
 	*x = RevokeSessionResponse{}
-	mi := &file_session_session_proto_msgTypes[2]
+	mi := &file_session_session_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -198,7 +396,7 @@ func (x *RevokeSessionResponse) String() string {
 func (*RevokeSessionResponse) ProtoMessage() {}
 
 func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[2]
+	mi := &file_session_session_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,20 +409,22 @@ func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
 func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{2}
+	return file_session_session_proto_rawDescGZIP(), []int{4}
 }
 
 // GetSessionRequest identifies the session by ID.
 type GetSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	IncludeUser   bool                   `protobuf:"varint,2,opt,name=include_user,json=includeUser,proto3" json:"include_user,omitempty"`       // embed the session's user
+	IncludeDevice bool                   `protobuf:"varint,3,opt,name=include_device,json=includeDevice,proto3" json:"include_device,omitempty"` // embed the session's device
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetSessionRequest) Reset() {
 	*x = GetSessionRequest{}
-	mi := &file_session_session_proto_msgTypes[3]
+	mi := &file_session_session_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -236,7 +436,7 @@ func (x *GetSessionRequest) String() string {
 func (*GetSessionRequest) ProtoMessage() {}
 
 func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[3]
+	mi := &file_session_session_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +449,7 @@ func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSessionRequest.ProtoReflect.Descriptor instead.
 func (*GetSessionRequest) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{3}
+	return file_session_session_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetSessionRequest) GetSessionId() string {
@@ -259,6 +459,20 @@ func (x *GetSessionRequest) GetSessionId() string {
 	return ""
 }
 
+func (x *GetSessionRequest) GetIncludeUser() bool {
+	if x != nil {
+		return x.IncludeUser
+	}
+	return false
+}
+
+func (x *GetSessionRequest) GetIncludeDevice() bool {
+	if x != nil {
+		return x.IncludeDevice
+	}
+	return false
+}
+
 // GetSessionResponse returns the session.
 type GetSessionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -269,7 +483,7 @@ type GetSessionResponse struct {
 
 func (x *GetSessionResponse) Reset() {
 	*x = GetSessionResponse{}
-	mi := &file_session_session_proto_msgTypes[4]
+	mi := &file_session_session_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -281,7 +495,7 @@ func (x *GetSessionResponse) String() string {
 func (*GetSessionResponse) ProtoMessage() {}
 
 func (x *GetSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[4]
+	mi := &file_session_session_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -294,7 +508,7 @@ func (x *GetSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSessionResponse.ProtoReflect.Descriptor instead.
 func (*GetSessionResponse) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{4}
+	return file_session_session_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetSessionResponse) GetSession() *Session {
@@ -310,13 +524,15 @@ type ListSessionsRequest struct {
 	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional
 	Pagination    *v1.Pagination         `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	IncludeUser   bool                   `protobuf:"varint,4,opt,name=include_user,json=includeUser,proto3" json:"include_user,omitempty"`       // embed each session's user
+	IncludeDevice bool                   `protobuf:"varint,5,opt,name=include_device,json=includeDevice,proto3" json:"include_device,omitempty"` // embed each session's device
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListSessionsRequest) Reset() {
 	*x = ListSessionsRequest{}
-	mi := &file_session_session_proto_msgTypes[5]
+	mi := &file_session_session_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -328,7 +544,7 @@ func (x *ListSessionsRequest) String() string {
 func (*ListSessionsRequest) ProtoMessage() {}
 
 func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[5]
+	mi := &file_session_session_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,7 +557,7 @@ func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
 func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{5}
+	return file_session_session_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListSessionsRequest) GetOrgId() string {
@@ -365,6 +581,20 @@ func (x *ListSessionsRequest) GetPagination() *v1.Pagination {
 	return nil
 }
 
+func (x *ListSessionsRequest) GetIncludeUser() bool {
+	if x != nil {
+		return x.IncludeUser
+	}
+	return false
+}
+
+func (x *ListSessionsRequest) GetIncludeDevice() bool {
+	if x != nil {
+		return x.IncludeDevice
+	}
+	return false
+}
+
 // ListSessionsResponse returns a page of sessions.
 type ListSessionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -376,7 +606,7 @@ type ListSessionsResponse struct {
 
 func (x *ListSessionsResponse) Reset() {
 	*x = ListSessionsResponse{}
-	mi := &file_session_session_proto_msgTypes[6]
+	mi := &file_session_session_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -388,7 +618,7 @@ func (x *ListSessionsResponse) String() string {
 func (*ListSessionsResponse) ProtoMessage() {}
 
 func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[6]
+	mi := &file_session_session_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,7 +631,7 @@ func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
 func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{6}
+	return file_session_session_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListSessionsResponse) GetSessions() []*Session {
@@ -429,7 +659,7 @@ type RevokeAllSessionsForUserRequest struct {
 
 func (x *RevokeAllSessionsForUserRequest) Reset() {
 	*x = RevokeAllSessionsForUserRequest{}
-	mi := &file_session_session_proto_msgTypes[7]
+	mi := &file_session_session_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -441,7 +671,7 @@ func (x *RevokeAllSessionsForUserRequest) String() string {
 func (*RevokeAllSessionsForUserRequest) ProtoMessage() {}
 
 func (x *RevokeAllSessionsForUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[7]
+	mi := &file_session_session_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -454,7 +684,7 @@ func (x *RevokeAllSessionsForUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeAllSessionsForUserRequest.ProtoReflect.Descriptor instead.
 func (*RevokeAllSessionsForUserRequest) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{7}
+	return file_session_session_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RevokeAllSessionsForUserRequest) GetOrgId() string {
@@ -480,7 +710,7 @@ type RevokeAllSessionsForUserResponse struct {
 
 func (x *RevokeAllSessionsForUserResponse) Reset() {
 	*x = RevokeAllSessionsForUserResponse{}
-	mi := &file_session_session_proto_msgTypes[8]
+	mi := &file_session_session_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -492,7 +722,7 @@ func (x *RevokeAllSessionsForUserResponse) String() string {
 func (*RevokeAllSessionsForUserResponse) ProtoMessage() {}
 
 func (x *RevokeAllSessionsForUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_session_session_proto_msgTypes[8]
+	mi := &file_session_session_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,14 +735,261 @@ func (x *RevokeAllSessionsForUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeAllSessionsForUserResponse.ProtoReflect.Descriptor instead.
 func (*RevokeAllSessionsForUserResponse) Descriptor() ([]byte, []int) {
-	return file_session_session_proto_rawDescGZIP(), []int{8}
+	return file_session_session_proto_rawDescGZIP(), []int{10}
+}
+
+// RevokeAllSessionsForOrgRequest signs everyone out of the caller's org. Without confirmation_token nothing is
+// revoked: the reply carries a token to send back, together with the number of sessions that would be revoked.
+type RevokeAllSessionsForOrgRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ConfirmationToken string                 `protobuf:"bytes,1,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsForOrgRequest) Reset() {
+	*x = RevokeAllSessionsForOrgRequest{}
+	mi := &file_session_session_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsForOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsForOrgRequest) ProtoMessage() {}
+
+func (x *RevokeAllSessionsForOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_session_session_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsForOrgRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsForOrgRequest) Descriptor() ([]byte, []int) {
+	return file_session_session_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokeAllSessionsForOrgRequest) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+// RevokeAllSessionsForOrgProgress reports a RevokeAllSessionsForOrg call. A confirmed call sends one message per
+// batch of revoked sessions; the last has done set.
+type RevokeAllSessionsForOrgProgress struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ConfirmationToken     string                 `protobuf:"bytes,1,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"` // set only in reply to a request without one
+	ConfirmationExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=confirmation_expires_at,json=confirmationExpiresAt,proto3" json:"confirmation_expires_at,omitempty"`
+	Total                 int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // active sessions in the org, other than the caller's
+	Revoked               int32                  `protobuf:"varint,4,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	Done                  bool                   `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsForOrgProgress) Reset() {
+	*x = RevokeAllSessionsForOrgProgress{}
+	mi := &file_session_session_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsForOrgProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsForOrgProgress) ProtoMessage() {}
+
+func (x *RevokeAllSessionsForOrgProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_session_session_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsForOrgProgress.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsForOrgProgress) Descriptor() ([]byte, []int) {
+	return file_session_session_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RevokeAllSessionsForOrgProgress) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+func (x *RevokeAllSessionsForOrgProgress) GetConfirmationExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmationExpiresAt
+	}
+	return nil
+}
+
+func (x *RevokeAllSessionsForOrgProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *RevokeAllSessionsForOrgProgress) GetRevoked() int32 {
+	if x != nil {
+		return x.Revoked
+	}
+	return 0
+}
+
+func (x *RevokeAllSessionsForOrgProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// SubscribeRevocationsRequest subscribes to the revocations of the caller's org.
+type SubscribeRevocationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"` // optional; also replay revocations from this time (at most 24h back); default now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRevocationsRequest) Reset() {
+	*x = SubscribeRevocationsRequest{}
+	mi := &file_session_session_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRevocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRevocationsRequest) ProtoMessage() {}
+
+func (x *SubscribeRevocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_session_session_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRevocationsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRevocationsRequest) Descriptor() ([]byte, []int) {
+	return file_session_session_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SubscribeRevocationsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+// SessionRevocation is a revoked session. Services that validate access tokens themselves reject the session's tokens
+// from then on instead of waiting for them to expire.
+type SessionRevocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // revocation reason, e.g. logout, admin_revoke, reuse_detected; empty when not recorded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRevocation) Reset() {
+	*x = SessionRevocation{}
+	mi := &file_session_session_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRevocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRevocation) ProtoMessage() {}
+
+func (x *SessionRevocation) ProtoReflect() protoreflect.Message {
+	mi := &file_session_session_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRevocation.ProtoReflect.Descriptor instead.
+func (*SessionRevocation) Descriptor() ([]byte, []int) {
+	return file_session_session_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionRevocation) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionRevocation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SessionRevocation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SessionRevocation) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *SessionRevocation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
 var File_session_session_proto protoreflect.FileDescriptor
 
 const file_session_session_proto_rawDesc = "" +
 	"\n" +
-	"\x15session/session.proto\x12\x0fztcp.session.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x02\n" +
+	"\x15session/session.proto\x12\x0fztcp.session.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xce\x05\n" +
 	"\aSession\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x15\n" +
@@ -527,22 +1004,48 @@ const file_session_session_proto_rawDesc = "" +
 	"\n" +
 	"ip_address\x18\b \x01(\tR\tipAddress\x129\n" +
 	"\n" +
-	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"5\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\n" +
+	" \x01(\tR\tuserAgent\x12%\n" +
+	"\x0eclient_version\x18\v \x01(\tR\rclientVersion\x12\x1f\n" +
+	"\vauth_method\x18\f \x01(\tR\n" +
+	"authMethod\x12\x1d\n" +
+	"\n" +
+	"mfa_method\x18\r \x01(\tR\tmfaMethod\x120\n" +
+	"\x04user\x18\x0e \x01(\v2\x1c.ztcp.session.v1.SessionUserR\x04user\x126\n" +
+	"\x06device\x18\x0f \x01(\v2\x1e.ztcp.session.v1.SessionDeviceR\x06device\x12+\n" +
+	"\x11revocation_reason\x18\x10 \x01(\tR\x10revocationReason\x12\x1d\n" +
+	"\n" +
+	"revoked_by\x18\x11 \x01(\tR\trevokedBy\x12\x1c\n" +
+	"\tephemeral\x18\x12 \x01(\bR\tephemeral\"G\n" +
+	"\vSessionUser\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"[\n" +
+	"\rSessionDevice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vfingerprint\x18\x02 \x01(\tR\vfingerprint\x12\x18\n" +
+	"\atrusted\x18\x03 \x01(\bR\atrusted\"5\n" +
 	"\x14RevokeSessionRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\"\x17\n" +
-	"\x15RevokeSessionResponse\"2\n" +
+	"\x15RevokeSessionResponse\"|\n" +
 	"\x11GetSessionRequest\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\"H\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
+	"\finclude_user\x18\x02 \x01(\bR\vincludeUser\x12%\n" +
+	"\x0einclude_device\x18\x03 \x01(\bR\rincludeDevice\"H\n" +
 	"\x12GetSessionResponse\x122\n" +
-	"\asession\x18\x01 \x01(\v2\x18.ztcp.session.v1.SessionR\asession\"\x81\x01\n" +
+	"\asession\x18\x01 \x01(\v2\x18.ztcp.session.v1.SessionR\asession\"\xcb\x01\n" +
 	"\x13ListSessionsRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12:\n" +
 	"\n" +
 	"pagination\x18\x03 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
-	"pagination\"\x8e\x01\n" +
+	"pagination\x12!\n" +
+	"\finclude_user\x18\x04 \x01(\bR\vincludeUser\x12%\n" +
+	"\x0einclude_device\x18\x05 \x01(\bR\rincludeDevice\"\x8e\x01\n" +
 	"\x14ListSessionsResponse\x124\n" +
 	"\bsessions\x18\x01 \x03(\v2\x18.ztcp.session.v1.SessionR\bsessions\x12@\n" +
 	"\n" +
@@ -551,13 +1054,33 @@ const file_session_session_proto_rawDesc = "" +
 	"\x1fRevokeAllSessionsForUserRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\"\"\n" +
-	" RevokeAllSessionsForUserResponse2\xa5\x03\n" +
+	" RevokeAllSessionsForUserResponse\"O\n" +
+	"\x1eRevokeAllSessionsForOrgRequest\x12-\n" +
+	"\x12confirmation_token\x18\x01 \x01(\tR\x11confirmationToken\"\xe8\x01\n" +
+	"\x1fRevokeAllSessionsForOrgProgress\x12-\n" +
+	"\x12confirmation_token\x18\x01 \x01(\tR\x11confirmationToken\x12R\n" +
+	"\x17confirmation_expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x15confirmationExpiresAt\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x18\n" +
+	"\arevoked\x18\x04 \x01(\x05R\arevoked\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\"O\n" +
+	"\x1bSubscribeRevocationsRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\xb5\x01\n" +
+	"\x11SessionRevocation\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x03 \x01(\tR\x05orgId\x129\n" +
+	"\n" +
+	"revoked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason2\xa0\x05\n" +
 	"\x0eSessionService\x12^\n" +
-	"\rRevokeSession\x12%.ztcp.session.v1.RevokeSessionRequest\x1a&.ztcp.session.v1.RevokeSessionResponse\x12[\n" +
-	"\fListSessions\x12$.ztcp.session.v1.ListSessionsRequest\x1a%.ztcp.session.v1.ListSessionsResponse\x12U\n" +
+	"\rRevokeSession\x12%.ztcp.session.v1.RevokeSessionRequest\x1a&.ztcp.session.v1.RevokeSessionResponse\x12`\n" +
+	"\fListSessions\x12$.ztcp.session.v1.ListSessionsRequest\x1a%.ztcp.session.v1.ListSessionsResponse\"\x03\x90\x02\x01\x12Z\n" +
 	"\n" +
-	"GetSession\x12\".ztcp.session.v1.GetSessionRequest\x1a#.ztcp.session.v1.GetSessionResponse\x12\x7f\n" +
-	"\x18RevokeAllSessionsForUser\x120.ztcp.session.v1.RevokeAllSessionsForUserRequest\x1a1.ztcp.session.v1.RevokeAllSessionsForUserResponseBEZCzero-trust-control-plane/backend/api/generated/session/v1;sessionv1b\x06proto3"
+	"GetSession\x12\".ztcp.session.v1.GetSessionRequest\x1a#.ztcp.session.v1.GetSessionResponse\"\x03\x90\x02\x01\x12\x7f\n" +
+	"\x18RevokeAllSessionsForUser\x120.ztcp.session.v1.RevokeAllSessionsForUserRequest\x1a1.ztcp.session.v1.RevokeAllSessionsForUserResponse\x12~\n" +
+	"\x17RevokeAllSessionsForOrg\x12/.ztcp.session.v1.RevokeAllSessionsForOrgRequest\x1a0.ztcp.session.v1.RevokeAllSessionsForOrgProgress0\x01\x12o\n" +
+	"\x14SubscribeRevocations\x12,.ztcp.session.v1.SubscribeRevocationsRequest\x1a\".ztcp.session.v1.SessionRevocation\"\x03\x90\x02\x010\x01BEZCzero-trust-control-plane/backend/api/generated/session/v1;sessionv1b\x06proto3"
 
 var (
 	file_session_session_proto_rawDescOnce sync.Once
@@ -571,43 +1094,58 @@ func file_session_session_proto_rawDescGZIP() []byte {
 	return file_session_session_proto_rawDescData
 }
 
-var file_session_session_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_session_session_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_session_session_proto_goTypes = []any{
 	(*Session)(nil),                          // 0: ztcp.session.v1.Session
-	(*RevokeSessionRequest)(nil),             // 1: ztcp.session.v1.RevokeSessionRequest
-	(*RevokeSessionResponse)(nil),            // 2: ztcp.session.v1.RevokeSessionResponse
-	(*GetSessionRequest)(nil),                // 3: ztcp.session.v1.GetSessionRequest
-	(*GetSessionResponse)(nil),               // 4: ztcp.session.v1.GetSessionResponse
-	(*ListSessionsRequest)(nil),              // 5: ztcp.session.v1.ListSessionsRequest
-	(*ListSessionsResponse)(nil),             // 6: ztcp.session.v1.ListSessionsResponse
-	(*RevokeAllSessionsForUserRequest)(nil),  // 7: ztcp.session.v1.RevokeAllSessionsForUserRequest
-	(*RevokeAllSessionsForUserResponse)(nil), // 8: ztcp.session.v1.RevokeAllSessionsForUserResponse
-	(*timestamppb.Timestamp)(nil),            // 9: google.protobuf.Timestamp
-	(*v1.Pagination)(nil),                    // 10: ztcp.common.v1.Pagination
-	(*v1.PaginationResult)(nil),              // 11: ztcp.common.v1.PaginationResult
+	(*SessionUser)(nil),                      // 1: ztcp.session.v1.SessionUser
+	(*SessionDevice)(nil),                    // 2: ztcp.session.v1.SessionDevice
+	(*RevokeSessionRequest)(nil),             // 3: ztcp.session.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),            // 4: ztcp.session.v1.RevokeSessionResponse
+	(*GetSessionRequest)(nil),                // 5: ztcp.session.v1.GetSessionRequest
+	(*GetSessionResponse)(nil),               // 6: ztcp.session.v1.GetSessionResponse
+	(*ListSessionsRequest)(nil),              // 7: ztcp.session.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),             // 8: ztcp.session.v1.ListSessionsResponse
+	(*RevokeAllSessionsForUserRequest)(nil),  // 9: ztcp.session.v1.RevokeAllSessionsForUserRequest
+	(*RevokeAllSessionsForUserResponse)(nil), // 10: ztcp.session.v1.RevokeAllSessionsForUserResponse
+	(*RevokeAllSessionsForOrgRequest)(nil),   // 11: ztcp.session.v1.RevokeAllSessionsForOrgRequest
+	(*RevokeAllSessionsForOrgProgress)(nil),  // 12: ztcp.session.v1.RevokeAllSessionsForOrgProgress
+	(*SubscribeRevocationsRequest)(nil),      // 13: ztcp.session.v1.SubscribeRevocationsRequest
+	(*SessionRevocation)(nil),                // 14: ztcp.session.v1.SessionRevocation
+	(*timestamppb.Timestamp)(nil),            // 15: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),                    // 16: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),              // 17: ztcp.common.v1.PaginationResult
 }
 var file_session_session_proto_depIdxs = []int32{
-	9,  // 0: ztcp.session.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
-	9,  // 1: ztcp.session.v1.Session.revoked_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: ztcp.session.v1.Session.last_seen_at:type_name -> google.protobuf.Timestamp
-	9,  // 3: ztcp.session.v1.Session.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: ztcp.session.v1.GetSessionResponse.session:type_name -> ztcp.session.v1.Session
-	10, // 5: ztcp.session.v1.ListSessionsRequest.pagination:type_name -> ztcp.common.v1.Pagination
-	0,  // 6: ztcp.session.v1.ListSessionsResponse.sessions:type_name -> ztcp.session.v1.Session
-	11, // 7: ztcp.session.v1.ListSessionsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
-	1,  // 8: ztcp.session.v1.SessionService.RevokeSession:input_type -> ztcp.session.v1.RevokeSessionRequest
-	5,  // 9: ztcp.session.v1.SessionService.ListSessions:input_type -> ztcp.session.v1.ListSessionsRequest
-	3,  // 10: ztcp.session.v1.SessionService.GetSession:input_type -> ztcp.session.v1.GetSessionRequest
-	7,  // 11: ztcp.session.v1.SessionService.RevokeAllSessionsForUser:input_type -> ztcp.session.v1.RevokeAllSessionsForUserRequest
-	2,  // 12: ztcp.session.v1.SessionService.RevokeSession:output_type -> ztcp.session.v1.RevokeSessionResponse
-	6,  // 13: ztcp.session.v1.SessionService.ListSessions:output_type -> ztcp.session.v1.ListSessionsResponse
-	4,  // 14: ztcp.session.v1.SessionService.GetSession:output_type -> ztcp.session.v1.GetSessionResponse
-	8,  // 15: ztcp.session.v1.SessionService.RevokeAllSessionsForUser:output_type -> ztcp.session.v1.RevokeAllSessionsForUserResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	15, // 0: ztcp.session.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 1: ztcp.session.v1.Session.revoked_at:type_name -> google.protobuf.Timestamp
+	15, // 2: ztcp.session.v1.Session.last_seen_at:type_name -> google.protobuf.Timestamp
+	15, // 3: ztcp.session.v1.Session.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 4: ztcp.session.v1.Session.user:type_name -> ztcp.session.v1.SessionUser
+	2,  // 5: ztcp.session.v1.Session.device:type_name -> ztcp.session.v1.SessionDevice
+	0,  // 6: ztcp.session.v1.GetSessionResponse.session:type_name -> ztcp.session.v1.Session
+	16, // 7: ztcp.session.v1.ListSessionsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	0,  // 8: ztcp.session.v1.ListSessionsResponse.sessions:type_name -> ztcp.session.v1.Session
+	17, // 9: ztcp.session.v1.ListSessionsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	15, // 10: ztcp.session.v1.RevokeAllSessionsForOrgProgress.confirmation_expires_at:type_name -> google.protobuf.Timestamp
+	15, // 11: ztcp.session.v1.SubscribeRevocationsRequest.since:type_name -> google.protobuf.Timestamp
+	15, // 12: ztcp.session.v1.SessionRevocation.revoked_at:type_name -> google.protobuf.Timestamp
+	3,  // 13: ztcp.session.v1.SessionService.RevokeSession:input_type -> ztcp.session.v1.RevokeSessionRequest
+	7,  // 14: ztcp.session.v1.SessionService.ListSessions:input_type -> ztcp.session.v1.ListSessionsRequest
+	5,  // 15: ztcp.session.v1.SessionService.GetSession:input_type -> ztcp.session.v1.GetSessionRequest
+	9,  // 16: ztcp.session.v1.SessionService.RevokeAllSessionsForUser:input_type -> ztcp.session.v1.RevokeAllSessionsForUserRequest
+	11, // 17: ztcp.session.v1.SessionService.RevokeAllSessionsForOrg:input_type -> ztcp.session.v1.RevokeAllSessionsForOrgRequest
+	13, // 18: ztcp.session.v1.SessionService.SubscribeRevocations:input_type -> ztcp.session.v1.SubscribeRevocationsRequest
+	4,  // 19: ztcp.session.v1.SessionService.RevokeSession:output_type -> ztcp.session.v1.RevokeSessionResponse
+	8,  // 20: ztcp.session.v1.SessionService.ListSessions:output_type -> ztcp.session.v1.ListSessionsResponse
+	6,  // 21: ztcp.session.v1.SessionService.GetSession:output_type -> ztcp.session.v1.GetSessionResponse
+	10, // 22: ztcp.session.v1.SessionService.RevokeAllSessionsForUser:output_type -> ztcp.session.v1.RevokeAllSessionsForUserResponse
+	12, // 23: ztcp.session.v1.SessionService.RevokeAllSessionsForOrg:output_type -> ztcp.session.v1.RevokeAllSessionsForOrgProgress
+	14, // 24: ztcp.session.v1.SessionService.SubscribeRevocations:output_type -> ztcp.session.v1.SessionRevocation
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_session_session_proto_init() }
@@ -621,7 +1159,7 @@ func file_session_session_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_session_session_proto_rawDesc), len(file_session_session_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
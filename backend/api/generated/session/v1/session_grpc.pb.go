@@ -23,6 +23,8 @@ const (
 	SessionService_ListSessions_FullMethodName             = "/ztcp.session.v1.SessionService/ListSessions"
 	SessionService_GetSession_FullMethodName               = "/ztcp.session.v1.SessionService/GetSession"
 	SessionService_RevokeAllSessionsForUser_FullMethodName = "/ztcp.session.v1.SessionService/RevokeAllSessionsForUser"
+	SessionService_RevokeAllSessionsForOrg_FullMethodName  = "/ztcp.session.v1.SessionService/RevokeAllSessionsForOrg"
+	SessionService_SubscribeRevocations_FullMethodName     = "/ztcp.session.v1.SessionService/SubscribeRevocations"
 )
 
 // SessionServiceClient is the client API for SessionService service.
@@ -35,6 +37,8 @@ type SessionServiceClient interface {
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
 	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error)
 	RevokeAllSessionsForUser(ctx context.Context, in *RevokeAllSessionsForUserRequest, opts ...grpc.CallOption) (*RevokeAllSessionsForUserResponse, error)
+	RevokeAllSessionsForOrg(ctx context.Context, in *RevokeAllSessionsForOrgRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RevokeAllSessionsForOrgProgress], error)
+	SubscribeRevocations(ctx context.Context, in *SubscribeRevocationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SessionRevocation], error)
 }
 
 type sessionServiceClient struct {
@@ -85,6 +89,44 @@ func (c *sessionServiceClient) RevokeAllSessionsForUser(ctx context.Context, in
 	return out, nil
 }
 
+func (c *sessionServiceClient) RevokeAllSessionsForOrg(ctx context.Context, in *RevokeAllSessionsForOrgRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RevokeAllSessionsForOrgProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SessionService_ServiceDesc.Streams[0], SessionService_RevokeAllSessionsForOrg_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RevokeAllSessionsForOrgRequest, RevokeAllSessionsForOrgProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SessionService_RevokeAllSessionsForOrgClient = grpc.ServerStreamingClient[RevokeAllSessionsForOrgProgress]
+
+func (c *sessionServiceClient) SubscribeRevocations(ctx context.Context, in *SubscribeRevocationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SessionRevocation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SessionService_ServiceDesc.Streams[1], SessionService_SubscribeRevocations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRevocationsRequest, SessionRevocation]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SessionService_SubscribeRevocationsClient = grpc.ServerStreamingClient[SessionRevocation]
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility.
@@ -95,6 +137,8 @@ type SessionServiceServer interface {
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
 	GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error)
 	RevokeAllSessionsForUser(context.Context, *RevokeAllSessionsForUserRequest) (*RevokeAllSessionsForUserResponse, error)
+	RevokeAllSessionsForOrg(*RevokeAllSessionsForOrgRequest, grpc.ServerStreamingServer[RevokeAllSessionsForOrgProgress]) error
+	SubscribeRevocations(*SubscribeRevocationsRequest, grpc.ServerStreamingServer[SessionRevocation]) error
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -117,6 +161,12 @@ func (UnimplementedSessionServiceServer) GetSession(context.Context, *GetSession
 func (UnimplementedSessionServiceServer) RevokeAllSessionsForUser(context.Context, *RevokeAllSessionsForUserRequest) (*RevokeAllSessionsForUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RevokeAllSessionsForUser not implemented")
 }
+func (UnimplementedSessionServiceServer) RevokeAllSessionsForOrg(*RevokeAllSessionsForOrgRequest, grpc.ServerStreamingServer[RevokeAllSessionsForOrgProgress]) error {
+	return status.Error(codes.Unimplemented, "method RevokeAllSessionsForOrg not implemented")
+}
+func (UnimplementedSessionServiceServer) SubscribeRevocations(*SubscribeRevocationsRequest, grpc.ServerStreamingServer[SessionRevocation]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeRevocations not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 func (UnimplementedSessionServiceServer) testEmbeddedByValue()                        {}
 
@@ -210,6 +260,28 @@ func _SessionService_RevokeAllSessionsForUser_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_RevokeAllSessionsForOrg_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RevokeAllSessionsForOrgRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SessionServiceServer).RevokeAllSessionsForOrg(m, &grpc.GenericServerStream[RevokeAllSessionsForOrgRequest, RevokeAllSessionsForOrgProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SessionService_RevokeAllSessionsForOrgServer = grpc.ServerStreamingServer[RevokeAllSessionsForOrgProgress]
+
+func _SessionService_SubscribeRevocations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRevocationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SessionServiceServer).SubscribeRevocations(m, &grpc.GenericServerStream[SubscribeRevocationsRequest, SessionRevocation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SessionService_SubscribeRevocationsServer = grpc.ServerStreamingServer[SessionRevocation]
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -234,6 +306,17 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _SessionService_RevokeAllSessionsForUser_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RevokeAllSessionsForOrg",
+			Handler:       _SessionService_RevokeAllSessionsForOrg_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeRevocations",
+			Handler:       _SessionService_SubscribeRevocations_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "session/session.proto",
 }
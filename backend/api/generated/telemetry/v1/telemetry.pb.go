@@ -0,0 +1,610 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: telemetry/telemetry.proto
+
+package telemetryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TelemetryEvent is one event observed by an agent.
+type TelemetryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                                                                       // required: lowercase letters, digits, '_' and '.', max 64 characters (e.g. page_view, process.start)
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`                                                         // required; at most 7 days old and 5 minutes ahead of the server clock
+	Attributes    map[string]string      `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // optional; at most 64 entries, keys max 64 and values max 1024 characters
+	EventId       string                 `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                                                                  // optional client ID for deduplication by consumers, max 64 characters; generated when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryEvent) Reset() {
+	*x = TelemetryEvent{}
+	mi := &file_telemetry_telemetry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryEvent) ProtoMessage() {}
+
+func (x *TelemetryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_telemetry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryEvent.ProtoReflect.Descriptor instead.
+func (*TelemetryEvent) Descriptor() ([]byte, []int) {
+	return file_telemetry_telemetry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TelemetryEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TelemetryEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *TelemetryEvent) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *TelemetryEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+// IngestTelemetryRequest is a batch of events from the caller's agent. org, user, session and device are taken from
+// the caller's access token.
+type IngestTelemetryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*TelemetryEvent      `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`                                     // 1 to 500 events
+	SchemaVersion int32                  `protobuf:"varint,2,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"` // schema of the events; 0 means 1, the only version supported today
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestTelemetryRequest) Reset() {
+	*x = IngestTelemetryRequest{}
+	mi := &file_telemetry_telemetry_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestTelemetryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestTelemetryRequest) ProtoMessage() {}
+
+func (x *IngestTelemetryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_telemetry_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestTelemetryRequest.ProtoReflect.Descriptor instead.
+func (*IngestTelemetryRequest) Descriptor() ([]byte, []int) {
+	return file_telemetry_telemetry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IngestTelemetryRequest) GetEvents() []*TelemetryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+type IngestTelemetryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"` // events published to the telemetry topic
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestTelemetryResponse) Reset() {
+	*x = IngestTelemetryResponse{}
+	mi := &file_telemetry_telemetry_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestTelemetryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestTelemetryResponse) ProtoMessage() {}
+
+func (x *IngestTelemetryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_telemetry_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestTelemetryResponse.ProtoReflect.Descriptor instead.
+func (*IngestTelemetryResponse) Descriptor() ([]byte, []int) {
+	return file_telemetry_telemetry_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IngestTelemetryResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+// TelemetryEnvelope is the value of each message on the telemetry topic, in the proto3 JSON mapping (field names as
+// below; consumers may also accept the lowerCamelCase names). The schema-version and event-type message headers repeat
+// schema_version and type. Consumers look schema_version up in the versions they support and dead-letter the rest.
+type TelemetryEnvelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"` // version of this envelope and of the event; 1 today
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                    // from the agent, or generated
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // when the agent saw the event
+	ReceivedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"` // when the control plane accepted the batch
+	OrgId         string                 `protobuf:"bytes,6,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the actor: the user of the agent's session
+	SessionId     string                 `protobuf:"bytes,8,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,9,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                                                                // empty when the session has no device
+	Region        string                 `protobuf:"bytes,10,opt,name=region,proto3" json:"region,omitempty"`                                                                                   // REGION of the receiving instance, when set
+	Attributes    map[string]string      `protobuf:"bytes,11,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // the event's payload
+	RequestId     string                 `protobuf:"bytes,12,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                                                            // x-request-id of the IngestTelemetry or StreamTelemetry call that delivered it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryEnvelope) Reset() {
+	*x = TelemetryEnvelope{}
+	mi := &file_telemetry_telemetry_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryEnvelope) ProtoMessage() {}
+
+func (x *TelemetryEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_telemetry_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryEnvelope.ProtoReflect.Descriptor instead.
+func (*TelemetryEnvelope) Descriptor() ([]byte, []int) {
+	return file_telemetry_telemetry_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TelemetryEnvelope) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *TelemetryEnvelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *TelemetryEnvelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TelemetryEnvelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *TelemetryEnvelope) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+func (x *TelemetryEnvelope) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *TelemetryEnvelope) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TelemetryEnvelope) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TelemetryEnvelope) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *TelemetryEnvelope) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *TelemetryEnvelope) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *TelemetryEnvelope) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// QueryTelemetryRequest lists the org's stored telemetry, newest first. Filters are optional.
+type QueryTelemetryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"` // received at or after
+	Until         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=until,proto3" json:"until,omitempty"` // received before
+	Pagination    *v1.Pagination         `protobuf:"bytes,7,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryTelemetryRequest) Reset() {
+	*x = QueryTelemetryRequest{}
+	mi := &file_telemetry_telemetry_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryTelemetryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryTelemetryRequest) ProtoMessage() {}
+
+func (x *QueryTelemetryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_telemetry_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryTelemetryRequest.ProtoReflect.Descriptor instead.
+func (*QueryTelemetryRequest) Descriptor() ([]byte, []int) {
+	return file_telemetry_telemetry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryTelemetryRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *QueryTelemetryRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *QueryTelemetryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *QueryTelemetryRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *QueryTelemetryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *QueryTelemetryRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *QueryTelemetryRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type QueryTelemetryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*TelemetryEnvelope   `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryTelemetryResponse) Reset() {
+	*x = QueryTelemetryResponse{}
+	mi := &file_telemetry_telemetry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryTelemetryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryTelemetryResponse) ProtoMessage() {}
+
+func (x *QueryTelemetryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_telemetry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryTelemetryResponse.ProtoReflect.Descriptor instead.
+func (*QueryTelemetryResponse) Descriptor() ([]byte, []int) {
+	return file_telemetry_telemetry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueryTelemetryResponse) GetEvents() []*TelemetryEnvelope {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *QueryTelemetryResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_telemetry_telemetry_proto protoreflect.FileDescriptor
+
+const file_telemetry_telemetry_proto_rawDesc = "" +
+	"\n" +
+	"\x19telemetry/telemetry.proto\x12\x11ztcp.telemetry.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x02\n" +
+	"\x0eTelemetryEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12;\n" +
+	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12Q\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v21.ztcp.telemetry.v1.TelemetryEvent.AttributesEntryR\n" +
+	"attributes\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"z\n" +
+	"\x16IngestTelemetryRequest\x129\n" +
+	"\x06events\x18\x01 \x03(\v2!.ztcp.telemetry.v1.TelemetryEventR\x06events\x12%\n" +
+	"\x0eschema_version\x18\x02 \x01(\x05R\rschemaVersion\"5\n" +
+	"\x17IngestTelemetryResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\"\x9b\x04\n" +
+	"\x11TelemetryEnvelope\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12;\n" +
+	"\vreceived_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\x12\x15\n" +
+	"\x06org_id\x18\x06 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\a \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\b \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tdevice_id\x18\t \x01(\tR\bdeviceId\x12\x16\n" +
+	"\x06region\x18\n" +
+	" \x01(\tR\x06region\x12T\n" +
+	"\n" +
+	"attributes\x18\v \x03(\v24.ztcp.telemetry.v1.TelemetryEnvelope.AttributesEntryR\n" +
+	"attributes\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\f \x01(\tR\trequestId\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x98\x02\n" +
+	"\x15QueryTelemetryRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x120\n" +
+	"\x05since\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12:\n" +
+	"\n" +
+	"pagination\x18\a \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\x98\x01\n" +
+	"\x16QueryTelemetryResponse\x12<\n" +
+	"\x06events\x18\x01 \x03(\v2$.ztcp.telemetry.v1.TelemetryEnvelopeR\x06events\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination2\xd4\x02\n" +
+	"\x10TelemetryService\x12h\n" +
+	"\x0fIngestTelemetry\x12).ztcp.telemetry.v1.IngestTelemetryRequest\x1a*.ztcp.telemetry.v1.IngestTelemetryResponse\x12j\n" +
+	"\x0fStreamTelemetry\x12).ztcp.telemetry.v1.IngestTelemetryRequest\x1a*.ztcp.telemetry.v1.IngestTelemetryResponse(\x01\x12j\n" +
+	"\x0eQueryTelemetry\x12(.ztcp.telemetry.v1.QueryTelemetryRequest\x1a).ztcp.telemetry.v1.QueryTelemetryResponse\"\x03\x90\x02\x01BIZGzero-trust-control-plane/backend/api/generated/telemetry/v1;telemetryv1b\x06proto3"
+
+var (
+	file_telemetry_telemetry_proto_rawDescOnce sync.Once
+	file_telemetry_telemetry_proto_rawDescData []byte
+)
+
+func file_telemetry_telemetry_proto_rawDescGZIP() []byte {
+	file_telemetry_telemetry_proto_rawDescOnce.Do(func() {
+		file_telemetry_telemetry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_telemetry_telemetry_proto_rawDesc), len(file_telemetry_telemetry_proto_rawDesc)))
+	})
+	return file_telemetry_telemetry_proto_rawDescData
+}
+
+var file_telemetry_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_telemetry_telemetry_proto_goTypes = []any{
+	(*TelemetryEvent)(nil),          // 0: ztcp.telemetry.v1.TelemetryEvent
+	(*IngestTelemetryRequest)(nil),  // 1: ztcp.telemetry.v1.IngestTelemetryRequest
+	(*IngestTelemetryResponse)(nil), // 2: ztcp.telemetry.v1.IngestTelemetryResponse
+	(*TelemetryEnvelope)(nil),       // 3: ztcp.telemetry.v1.TelemetryEnvelope
+	(*QueryTelemetryRequest)(nil),   // 4: ztcp.telemetry.v1.QueryTelemetryRequest
+	(*QueryTelemetryResponse)(nil),  // 5: ztcp.telemetry.v1.QueryTelemetryResponse
+	nil,                             // 6: ztcp.telemetry.v1.TelemetryEvent.AttributesEntry
+	nil,                             // 7: ztcp.telemetry.v1.TelemetryEnvelope.AttributesEntry
+	(*timestamppb.Timestamp)(nil),   // 8: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),           // 9: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),     // 10: ztcp.common.v1.PaginationResult
+}
+var file_telemetry_telemetry_proto_depIdxs = []int32{
+	8,  // 0: ztcp.telemetry.v1.TelemetryEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	6,  // 1: ztcp.telemetry.v1.TelemetryEvent.attributes:type_name -> ztcp.telemetry.v1.TelemetryEvent.AttributesEntry
+	0,  // 2: ztcp.telemetry.v1.IngestTelemetryRequest.events:type_name -> ztcp.telemetry.v1.TelemetryEvent
+	8,  // 3: ztcp.telemetry.v1.TelemetryEnvelope.occurred_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: ztcp.telemetry.v1.TelemetryEnvelope.received_at:type_name -> google.protobuf.Timestamp
+	7,  // 5: ztcp.telemetry.v1.TelemetryEnvelope.attributes:type_name -> ztcp.telemetry.v1.TelemetryEnvelope.AttributesEntry
+	8,  // 6: ztcp.telemetry.v1.QueryTelemetryRequest.since:type_name -> google.protobuf.Timestamp
+	8,  // 7: ztcp.telemetry.v1.QueryTelemetryRequest.until:type_name -> google.protobuf.Timestamp
+	9,  // 8: ztcp.telemetry.v1.QueryTelemetryRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	3,  // 9: ztcp.telemetry.v1.QueryTelemetryResponse.events:type_name -> ztcp.telemetry.v1.TelemetryEnvelope
+	10, // 10: ztcp.telemetry.v1.QueryTelemetryResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	1,  // 11: ztcp.telemetry.v1.TelemetryService.IngestTelemetry:input_type -> ztcp.telemetry.v1.IngestTelemetryRequest
+	1,  // 12: ztcp.telemetry.v1.TelemetryService.StreamTelemetry:input_type -> ztcp.telemetry.v1.IngestTelemetryRequest
+	4,  // 13: ztcp.telemetry.v1.TelemetryService.QueryTelemetry:input_type -> ztcp.telemetry.v1.QueryTelemetryRequest
+	2,  // 14: ztcp.telemetry.v1.TelemetryService.IngestTelemetry:output_type -> ztcp.telemetry.v1.IngestTelemetryResponse
+	2,  // 15: ztcp.telemetry.v1.TelemetryService.StreamTelemetry:output_type -> ztcp.telemetry.v1.IngestTelemetryResponse
+	5,  // 16: ztcp.telemetry.v1.TelemetryService.QueryTelemetry:output_type -> ztcp.telemetry.v1.QueryTelemetryResponse
+	14, // [14:17] is the sub-list for method output_type
+	11, // [11:14] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_telemetry_telemetry_proto_init() }
+func file_telemetry_telemetry_proto_init() {
+	if File_telemetry_telemetry_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_telemetry_telemetry_proto_rawDesc), len(file_telemetry_telemetry_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_telemetry_telemetry_proto_goTypes,
+		DependencyIndexes: file_telemetry_telemetry_proto_depIdxs,
+		MessageInfos:      file_telemetry_telemetry_proto_msgTypes,
+	}.Build()
+	File_telemetry_telemetry_proto = out.File
+	file_telemetry_telemetry_proto_goTypes = nil
+	file_telemetry_telemetry_proto_depIdxs = nil
+}
@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: telemetry/telemetry.proto
+
+package telemetryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TelemetryService_IngestTelemetry_FullMethodName = "/ztcp.telemetry.v1.TelemetryService/IngestTelemetry"
+	TelemetryService_StreamTelemetry_FullMethodName = "/ztcp.telemetry.v1.TelemetryService/StreamTelemetry"
+	TelemetryService_QueryTelemetry_FullMethodName  = "/ztcp.telemetry.v1.TelemetryService/QueryTelemetry"
+)
+
+// TelemetryServiceClient is the client API for TelemetryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TelemetryService takes telemetry from browser and endpoint agents, validates it, adds the caller's org, user,
+// session and device, and publishes it to the Kafka telemetry topic (TELEMETRY_KAFKA_TOPIC) for downstream consumers.
+// A batch that cannot be published fails with UNAVAILABLE and should be sent again; part of it may already have been
+// published, so consumers deduplicate by event_id. With the embedded transport (TELEMETRY_TRANSPORT=embedded) events
+// are stored in the control plane's database instead and read back with QueryTelemetry.
+type TelemetryServiceClient interface {
+	// IngestTelemetry publishes one batch. Any org member.
+	IngestTelemetry(ctx context.Context, in *IngestTelemetryRequest, opts ...grpc.CallOption) (*IngestTelemetryResponse, error)
+	// StreamTelemetry publishes each batch as it arrives, for agents that keep a stream open. The response, sent when
+	// the client closes the stream, counts the events of every batch. A rejected batch ends the stream with its error;
+	// batches published before it stay published.
+	StreamTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestTelemetryRequest, IngestTelemetryResponse], error)
+	// QueryTelemetry lists stored events. Only with the embedded transport; otherwise UNIMPLEMENTED (query Loki). Events
+	// appear about a second after they are accepted. Caller must be org admin or owner.
+	QueryTelemetry(ctx context.Context, in *QueryTelemetryRequest, opts ...grpc.CallOption) (*QueryTelemetryResponse, error)
+}
+
+type telemetryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTelemetryServiceClient(cc grpc.ClientConnInterface) TelemetryServiceClient {
+	return &telemetryServiceClient{cc}
+}
+
+func (c *telemetryServiceClient) IngestTelemetry(ctx context.Context, in *IngestTelemetryRequest, opts ...grpc.CallOption) (*IngestTelemetryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestTelemetryResponse)
+	err := c.cc.Invoke(ctx, TelemetryService_IngestTelemetry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) StreamTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestTelemetryRequest, IngestTelemetryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TelemetryService_ServiceDesc.Streams[0], TelemetryService_StreamTelemetry_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IngestTelemetryRequest, IngestTelemetryResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TelemetryService_StreamTelemetryClient = grpc.ClientStreamingClient[IngestTelemetryRequest, IngestTelemetryResponse]
+
+func (c *telemetryServiceClient) QueryTelemetry(ctx context.Context, in *QueryTelemetryRequest, opts ...grpc.CallOption) (*QueryTelemetryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryTelemetryResponse)
+	err := c.cc.Invoke(ctx, TelemetryService_QueryTelemetry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TelemetryServiceServer is the server API for TelemetryService service.
+// All implementations must embed UnimplementedTelemetryServiceServer
+// for forward compatibility.
+//
+// TelemetryService takes telemetry from browser and endpoint agents, validates it, adds the caller's org, user,
+// session and device, and publishes it to the Kafka telemetry topic (TELEMETRY_KAFKA_TOPIC) for downstream consumers.
+// A batch that cannot be published fails with UNAVAILABLE and should be sent again; part of it may already have been
+// published, so consumers deduplicate by event_id. With the embedded transport (TELEMETRY_TRANSPORT=embedded) events
+// are stored in the control plane's database instead and read back with QueryTelemetry.
+type TelemetryServiceServer interface {
+	// IngestTelemetry publishes one batch. Any org member.
+	IngestTelemetry(context.Context, *IngestTelemetryRequest) (*IngestTelemetryResponse, error)
+	// StreamTelemetry publishes each batch as it arrives, for agents that keep a stream open. The response, sent when
+	// the client closes the stream, counts the events of every batch. A rejected batch ends the stream with its error;
+	// batches published before it stay published.
+	StreamTelemetry(grpc.ClientStreamingServer[IngestTelemetryRequest, IngestTelemetryResponse]) error
+	// QueryTelemetry lists stored events. Only with the embedded transport; otherwise UNIMPLEMENTED (query Loki). Events
+	// appear about a second after they are accepted. Caller must be org admin or owner.
+	QueryTelemetry(context.Context, *QueryTelemetryRequest) (*QueryTelemetryResponse, error)
+	mustEmbedUnimplementedTelemetryServiceServer()
+}
+
+// UnimplementedTelemetryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTelemetryServiceServer struct{}
+
+func (UnimplementedTelemetryServiceServer) IngestTelemetry(context.Context, *IngestTelemetryRequest) (*IngestTelemetryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IngestTelemetry not implemented")
+}
+func (UnimplementedTelemetryServiceServer) StreamTelemetry(grpc.ClientStreamingServer[IngestTelemetryRequest, IngestTelemetryResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamTelemetry not implemented")
+}
+func (UnimplementedTelemetryServiceServer) QueryTelemetry(context.Context, *QueryTelemetryRequest) (*QueryTelemetryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QueryTelemetry not implemented")
+}
+func (UnimplementedTelemetryServiceServer) mustEmbedUnimplementedTelemetryServiceServer() {}
+func (UnimplementedTelemetryServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeTelemetryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TelemetryServiceServer will
+// result in compilation errors.
+type UnsafeTelemetryServiceServer interface {
+	mustEmbedUnimplementedTelemetryServiceServer()
+}
+
+func RegisterTelemetryServiceServer(s grpc.ServiceRegistrar, srv TelemetryServiceServer) {
+	// If the following call panics, it indicates UnimplementedTelemetryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TelemetryService_ServiceDesc, srv)
+}
+
+func _TelemetryService_IngestTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestTelemetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).IngestTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelemetryService_IngestTelemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).IngestTelemetry(ctx, req.(*IngestTelemetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_StreamTelemetry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TelemetryServiceServer).StreamTelemetry(&grpc.GenericServerStream[IngestTelemetryRequest, IngestTelemetryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TelemetryService_StreamTelemetryServer = grpc.ClientStreamingServer[IngestTelemetryRequest, IngestTelemetryResponse]
+
+func _TelemetryService_QueryTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTelemetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).QueryTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelemetryService_QueryTelemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).QueryTelemetry(ctx, req.(*QueryTelemetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TelemetryService_ServiceDesc is the grpc.ServiceDesc for TelemetryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TelemetryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.telemetry.v1.TelemetryService",
+	HandlerType: (*TelemetryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IngestTelemetry",
+			Handler:    _TelemetryService_IngestTelemetry_Handler,
+		},
+		{
+			MethodName: "QueryTelemetry",
+			Handler:    _TelemetryService_QueryTelemetry_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTelemetry",
+			Handler:       _TelemetryService_StreamTelemetry_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "telemetry/telemetry.proto",
+}
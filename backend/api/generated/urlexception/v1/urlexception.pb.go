@@ -0,0 +1,846 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: urlexception/urlexception.proto
+
+package urlexceptionv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v1 "zero-trust-control-plane/backend/api/generated/common/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UrlException is a member's request to reach a domain the org's access control blocks for them.
+type UrlException struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId           string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the member the exception is for
+	Domain          string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`               // normalized host; subdomains are not covered
+	Url             string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`                     // the URL the member was blocked on
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`               // pending, approved, rejected, revoked, expired
+	Justification   string                 `protobuf:"bytes,7,opt,name=justification,proto3" json:"justification,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,8,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // window; starts at approval
+	ReviewedBy      string                 `protobuf:"bytes,9,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`                 // user ID of the admin who approved or rejected; empty while pending
+	ReviewComment   string                 `protobuf:"bytes,10,opt,name=review_comment,json=reviewComment,proto3" json:"review_comment,omitempty"`
+	ReviewedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // set on approval
+	RevokedBy       string                 `protobuf:"bytes,13,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	RevokedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UrlException) Reset() {
+	*x = UrlException{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UrlException) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UrlException) ProtoMessage() {}
+
+func (x *UrlException) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UrlException.ProtoReflect.Descriptor instead.
+func (*UrlException) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UrlException) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UrlException) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UrlException) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UrlException) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *UrlException) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UrlException) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UrlException) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *UrlException) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *UrlException) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *UrlException) GetReviewComment() string {
+	if x != nil {
+		return x.ReviewComment
+	}
+	return ""
+}
+
+func (x *UrlException) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *UrlException) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *UrlException) GetRevokedBy() string {
+	if x != nil {
+		return x.RevokedBy
+	}
+	return ""
+}
+
+func (x *UrlException) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *UrlException) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RequestUrlExceptionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Url             string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                                                 // required; a URL that CheckUrlAccess denies for the caller
+	Justification   string                 `protobuf:"bytes,2,opt,name=justification,proto3" json:"justification,omitempty"`                             // required, max 1000 characters
+	DurationSeconds int64                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // optional; default 1 day, max 30 days
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RequestUrlExceptionRequest) Reset() {
+	*x = RequestUrlExceptionRequest{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestUrlExceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestUrlExceptionRequest) ProtoMessage() {}
+
+func (x *RequestUrlExceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestUrlExceptionRequest.ProtoReflect.Descriptor instead.
+func (*RequestUrlExceptionRequest) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RequestUrlExceptionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RequestUrlExceptionRequest) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *RequestUrlExceptionRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type RequestUrlExceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exception     *UrlException          `protobuf:"bytes,1,opt,name=exception,proto3" json:"exception,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestUrlExceptionResponse) Reset() {
+	*x = RequestUrlExceptionResponse{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestUrlExceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestUrlExceptionResponse) ProtoMessage() {}
+
+func (x *RequestUrlExceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestUrlExceptionResponse.ProtoReflect.Descriptor instead.
+func (*RequestUrlExceptionResponse) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RequestUrlExceptionResponse) GetException() *UrlException {
+	if x != nil {
+		return x.Exception
+	}
+	return nil
+}
+
+// ListUrlExceptionsRequest lists the org's exceptions, newest first. Members see only their own.
+type ListUrlExceptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`               // optional: pending, approved, rejected, revoked
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional; org admins and owners only
+	Pagination    *v1.Pagination         `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUrlExceptionsRequest) Reset() {
+	*x = ListUrlExceptionsRequest{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUrlExceptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUrlExceptionsRequest) ProtoMessage() {}
+
+func (x *ListUrlExceptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUrlExceptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListUrlExceptionsRequest) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListUrlExceptionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListUrlExceptionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUrlExceptionsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListUrlExceptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exceptions    []*UrlException        `protobuf:"bytes,1,rep,name=exceptions,proto3" json:"exceptions,omitempty"`
+	Pagination    *v1.PaginationResult   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUrlExceptionsResponse) Reset() {
+	*x = ListUrlExceptionsResponse{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUrlExceptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUrlExceptionsResponse) ProtoMessage() {}
+
+func (x *ListUrlExceptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUrlExceptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListUrlExceptionsResponse) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListUrlExceptionsResponse) GetExceptions() []*UrlException {
+	if x != nil {
+		return x.Exceptions
+	}
+	return nil
+}
+
+func (x *ListUrlExceptionsResponse) GetPagination() *v1.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ApproveUrlExceptionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment         string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`                                         // optional, max 1000 characters
+	DurationSeconds int64                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // optional; overrides the requested duration, max 30 days
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ApproveUrlExceptionRequest) Reset() {
+	*x = ApproveUrlExceptionRequest{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveUrlExceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveUrlExceptionRequest) ProtoMessage() {}
+
+func (x *ApproveUrlExceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveUrlExceptionRequest.ProtoReflect.Descriptor instead.
+func (*ApproveUrlExceptionRequest) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ApproveUrlExceptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApproveUrlExceptionRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ApproveUrlExceptionRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type ApproveUrlExceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exception     *UrlException          `protobuf:"bytes,1,opt,name=exception,proto3" json:"exception,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveUrlExceptionResponse) Reset() {
+	*x = ApproveUrlExceptionResponse{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveUrlExceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveUrlExceptionResponse) ProtoMessage() {}
+
+func (x *ApproveUrlExceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveUrlExceptionResponse.ProtoReflect.Descriptor instead.
+func (*ApproveUrlExceptionResponse) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ApproveUrlExceptionResponse) GetException() *UrlException {
+	if x != nil {
+		return x.Exception
+	}
+	return nil
+}
+
+type RejectUrlExceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // optional, max 1000 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectUrlExceptionRequest) Reset() {
+	*x = RejectUrlExceptionRequest{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectUrlExceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectUrlExceptionRequest) ProtoMessage() {}
+
+func (x *RejectUrlExceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectUrlExceptionRequest.ProtoReflect.Descriptor instead.
+func (*RejectUrlExceptionRequest) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RejectUrlExceptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RejectUrlExceptionRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RejectUrlExceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exception     *UrlException          `protobuf:"bytes,1,opt,name=exception,proto3" json:"exception,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectUrlExceptionResponse) Reset() {
+	*x = RejectUrlExceptionResponse{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectUrlExceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectUrlExceptionResponse) ProtoMessage() {}
+
+func (x *RejectUrlExceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectUrlExceptionResponse.ProtoReflect.Descriptor instead.
+func (*RejectUrlExceptionResponse) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RejectUrlExceptionResponse) GetException() *UrlException {
+	if x != nil {
+		return x.Exception
+	}
+	return nil
+}
+
+type RevokeUrlExceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // optional, max 1000 characters; audited only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUrlExceptionRequest) Reset() {
+	*x = RevokeUrlExceptionRequest{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUrlExceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUrlExceptionRequest) ProtoMessage() {}
+
+func (x *RevokeUrlExceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUrlExceptionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeUrlExceptionRequest) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RevokeUrlExceptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RevokeUrlExceptionRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RevokeUrlExceptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exception     *UrlException          `protobuf:"bytes,1,opt,name=exception,proto3" json:"exception,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUrlExceptionResponse) Reset() {
+	*x = RevokeUrlExceptionResponse{}
+	mi := &file_urlexception_urlexception_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUrlExceptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUrlExceptionResponse) ProtoMessage() {}
+
+func (x *RevokeUrlExceptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_urlexception_urlexception_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUrlExceptionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeUrlExceptionResponse) Descriptor() ([]byte, []int) {
+	return file_urlexception_urlexception_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RevokeUrlExceptionResponse) GetException() *UrlException {
+	if x != nil {
+		return x.Exception
+	}
+	return nil
+}
+
+var File_urlexception_urlexception_proto protoreflect.FileDescriptor
+
+const file_urlexception_urlexception_proto_rawDesc = "" +
+	"\n" +
+	"\x1furlexception/urlexception.proto\x12\x14ztcp.urlexception.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x04\n" +
+	"\fUrlException\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12$\n" +
+	"\rjustification\x18\a \x01(\tR\rjustification\x12)\n" +
+	"\x10duration_seconds\x18\b \x01(\x03R\x0fdurationSeconds\x12\x1f\n" +
+	"\vreviewed_by\x18\t \x01(\tR\n" +
+	"reviewedBy\x12%\n" +
+	"\x0ereview_comment\x18\n" +
+	" \x01(\tR\rreviewComment\x12;\n" +
+	"\vreviewed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"revoked_by\x18\r \x01(\tR\trevokedBy\x129\n" +
+	"\n" +
+	"revoked_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x7f\n" +
+	"\x1aRequestUrlExceptionRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12$\n" +
+	"\rjustification\x18\x02 \x01(\tR\rjustification\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x03R\x0fdurationSeconds\"_\n" +
+	"\x1bRequestUrlExceptionResponse\x12@\n" +
+	"\texception\x18\x01 \x01(\v2\".ztcp.urlexception.v1.UrlExceptionR\texception\"\x87\x01\n" +
+	"\x18ListUrlExceptionsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12:\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\xa1\x01\n" +
+	"\x19ListUrlExceptionsResponse\x12B\n" +
+	"\n" +
+	"exceptions\x18\x01 \x03(\v2\".ztcp.urlexception.v1.UrlExceptionR\n" +
+	"exceptions\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination\"q\n" +
+	"\x1aApproveUrlExceptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x03R\x0fdurationSeconds\"_\n" +
+	"\x1bApproveUrlExceptionResponse\x12@\n" +
+	"\texception\x18\x01 \x01(\v2\".ztcp.urlexception.v1.UrlExceptionR\texception\"E\n" +
+	"\x19RejectUrlExceptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"^\n" +
+	"\x1aRejectUrlExceptionResponse\x12@\n" +
+	"\texception\x18\x01 \x01(\v2\".ztcp.urlexception.v1.UrlExceptionR\texception\"E\n" +
+	"\x19RevokeUrlExceptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"^\n" +
+	"\x1aRevokeUrlExceptionResponse\x12@\n" +
+	"\texception\x18\x01 \x01(\v2\".ztcp.urlexception.v1.UrlExceptionR\texception2\xfa\x04\n" +
+	"\x13UrlExceptionService\x12z\n" +
+	"\x13RequestUrlException\x120.ztcp.urlexception.v1.RequestUrlExceptionRequest\x1a1.ztcp.urlexception.v1.RequestUrlExceptionResponse\x12y\n" +
+	"\x11ListUrlExceptions\x12..ztcp.urlexception.v1.ListUrlExceptionsRequest\x1a/.ztcp.urlexception.v1.ListUrlExceptionsResponse\"\x03\x90\x02\x01\x12z\n" +
+	"\x13ApproveUrlException\x120.ztcp.urlexception.v1.ApproveUrlExceptionRequest\x1a1.ztcp.urlexception.v1.ApproveUrlExceptionResponse\x12w\n" +
+	"\x12RejectUrlException\x12/.ztcp.urlexception.v1.RejectUrlExceptionRequest\x1a0.ztcp.urlexception.v1.RejectUrlExceptionResponse\x12w\n" +
+	"\x12RevokeUrlException\x12/.ztcp.urlexception.v1.RevokeUrlExceptionRequest\x1a0.ztcp.urlexception.v1.RevokeUrlExceptionResponseBOZMzero-trust-control-plane/backend/api/generated/urlexception/v1;urlexceptionv1b\x06proto3"
+
+var (
+	file_urlexception_urlexception_proto_rawDescOnce sync.Once
+	file_urlexception_urlexception_proto_rawDescData []byte
+)
+
+func file_urlexception_urlexception_proto_rawDescGZIP() []byte {
+	file_urlexception_urlexception_proto_rawDescOnce.Do(func() {
+		file_urlexception_urlexception_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_urlexception_urlexception_proto_rawDesc), len(file_urlexception_urlexception_proto_rawDesc)))
+	})
+	return file_urlexception_urlexception_proto_rawDescData
+}
+
+var file_urlexception_urlexception_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_urlexception_urlexception_proto_goTypes = []any{
+	(*UrlException)(nil),                // 0: ztcp.urlexception.v1.UrlException
+	(*RequestUrlExceptionRequest)(nil),  // 1: ztcp.urlexception.v1.RequestUrlExceptionRequest
+	(*RequestUrlExceptionResponse)(nil), // 2: ztcp.urlexception.v1.RequestUrlExceptionResponse
+	(*ListUrlExceptionsRequest)(nil),    // 3: ztcp.urlexception.v1.ListUrlExceptionsRequest
+	(*ListUrlExceptionsResponse)(nil),   // 4: ztcp.urlexception.v1.ListUrlExceptionsResponse
+	(*ApproveUrlExceptionRequest)(nil),  // 5: ztcp.urlexception.v1.ApproveUrlExceptionRequest
+	(*ApproveUrlExceptionResponse)(nil), // 6: ztcp.urlexception.v1.ApproveUrlExceptionResponse
+	(*RejectUrlExceptionRequest)(nil),   // 7: ztcp.urlexception.v1.RejectUrlExceptionRequest
+	(*RejectUrlExceptionResponse)(nil),  // 8: ztcp.urlexception.v1.RejectUrlExceptionResponse
+	(*RevokeUrlExceptionRequest)(nil),   // 9: ztcp.urlexception.v1.RevokeUrlExceptionRequest
+	(*RevokeUrlExceptionResponse)(nil),  // 10: ztcp.urlexception.v1.RevokeUrlExceptionResponse
+	(*timestamppb.Timestamp)(nil),       // 11: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),               // 12: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),         // 13: ztcp.common.v1.PaginationResult
+}
+var file_urlexception_urlexception_proto_depIdxs = []int32{
+	11, // 0: ztcp.urlexception.v1.UrlException.reviewed_at:type_name -> google.protobuf.Timestamp
+	11, // 1: ztcp.urlexception.v1.UrlException.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 2: ztcp.urlexception.v1.UrlException.revoked_at:type_name -> google.protobuf.Timestamp
+	11, // 3: ztcp.urlexception.v1.UrlException.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: ztcp.urlexception.v1.RequestUrlExceptionResponse.exception:type_name -> ztcp.urlexception.v1.UrlException
+	12, // 5: ztcp.urlexception.v1.ListUrlExceptionsRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	0,  // 6: ztcp.urlexception.v1.ListUrlExceptionsResponse.exceptions:type_name -> ztcp.urlexception.v1.UrlException
+	13, // 7: ztcp.urlexception.v1.ListUrlExceptionsResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	0,  // 8: ztcp.urlexception.v1.ApproveUrlExceptionResponse.exception:type_name -> ztcp.urlexception.v1.UrlException
+	0,  // 9: ztcp.urlexception.v1.RejectUrlExceptionResponse.exception:type_name -> ztcp.urlexception.v1.UrlException
+	0,  // 10: ztcp.urlexception.v1.RevokeUrlExceptionResponse.exception:type_name -> ztcp.urlexception.v1.UrlException
+	1,  // 11: ztcp.urlexception.v1.UrlExceptionService.RequestUrlException:input_type -> ztcp.urlexception.v1.RequestUrlExceptionRequest
+	3,  // 12: ztcp.urlexception.v1.UrlExceptionService.ListUrlExceptions:input_type -> ztcp.urlexception.v1.ListUrlExceptionsRequest
+	5,  // 13: ztcp.urlexception.v1.UrlExceptionService.ApproveUrlException:input_type -> ztcp.urlexception.v1.ApproveUrlExceptionRequest
+	7,  // 14: ztcp.urlexception.v1.UrlExceptionService.RejectUrlException:input_type -> ztcp.urlexception.v1.RejectUrlExceptionRequest
+	9,  // 15: ztcp.urlexception.v1.UrlExceptionService.RevokeUrlException:input_type -> ztcp.urlexception.v1.RevokeUrlExceptionRequest
+	2,  // 16: ztcp.urlexception.v1.UrlExceptionService.RequestUrlException:output_type -> ztcp.urlexception.v1.RequestUrlExceptionResponse
+	4,  // 17: ztcp.urlexception.v1.UrlExceptionService.ListUrlExceptions:output_type -> ztcp.urlexception.v1.ListUrlExceptionsResponse
+	6,  // 18: ztcp.urlexception.v1.UrlExceptionService.ApproveUrlException:output_type -> ztcp.urlexception.v1.ApproveUrlExceptionResponse
+	8,  // 19: ztcp.urlexception.v1.UrlExceptionService.RejectUrlException:output_type -> ztcp.urlexception.v1.RejectUrlExceptionResponse
+	10, // 20: ztcp.urlexception.v1.UrlExceptionService.RevokeUrlException:output_type -> ztcp.urlexception.v1.RevokeUrlExceptionResponse
+	16, // [16:21] is the sub-list for method output_type
+	11, // [11:16] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_urlexception_urlexception_proto_init() }
+func file_urlexception_urlexception_proto_init() {
+	if File_urlexception_urlexception_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_urlexception_urlexception_proto_rawDesc), len(file_urlexception_urlexception_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_urlexception_urlexception_proto_goTypes,
+		DependencyIndexes: file_urlexception_urlexception_proto_depIdxs,
+		MessageInfos:      file_urlexception_urlexception_proto_msgTypes,
+	}.Build()
+	File_urlexception_urlexception_proto = out.File
+	file_urlexception_urlexception_proto_goTypes = nil
+	file_urlexception_urlexception_proto_depIdxs = nil
+}
@@ -0,0 +1,293 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: urlexception/urlexception.proto
+
+package urlexceptionv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UrlExceptionService_RequestUrlException_FullMethodName = "/ztcp.urlexception.v1.UrlExceptionService/RequestUrlException"
+	UrlExceptionService_ListUrlExceptions_FullMethodName   = "/ztcp.urlexception.v1.UrlExceptionService/ListUrlExceptions"
+	UrlExceptionService_ApproveUrlException_FullMethodName = "/ztcp.urlexception.v1.UrlExceptionService/ApproveUrlException"
+	UrlExceptionService_RejectUrlException_FullMethodName  = "/ztcp.urlexception.v1.UrlExceptionService/RejectUrlException"
+	UrlExceptionService_RevokeUrlException_FullMethodName  = "/ztcp.urlexception.v1.UrlExceptionService/RevokeUrlException"
+)
+
+// UrlExceptionServiceClient is the client API for UrlExceptionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UrlExceptionService lets members ask for access to a domain that the org's access control blocks. A member
+// submits the blocked URL with a justification; an org admin approves a time-boxed exception or rejects the request.
+// While an exception is active, CheckUrlAccess allows its domain for that member. Every step is audited.
+type UrlExceptionServiceClient interface {
+	// RequestUrlException stores a pending request. Caller must be an org member; the URL must be denied for them, and
+	// they must have no pending or active exception for its domain.
+	RequestUrlException(ctx context.Context, in *RequestUrlExceptionRequest, opts ...grpc.CallOption) (*RequestUrlExceptionResponse, error)
+	ListUrlExceptions(ctx context.Context, in *ListUrlExceptionsRequest, opts ...grpc.CallOption) (*ListUrlExceptionsResponse, error)
+	// ApproveUrlException starts the exception window. Caller must be an org admin or owner other than the requester.
+	ApproveUrlException(ctx context.Context, in *ApproveUrlExceptionRequest, opts ...grpc.CallOption) (*ApproveUrlExceptionResponse, error)
+	// RejectUrlException closes a pending request. Caller must be an org admin or owner, or the requester
+	// (withdrawing it).
+	RejectUrlException(ctx context.Context, in *RejectUrlExceptionRequest, opts ...grpc.CallOption) (*RejectUrlExceptionResponse, error)
+	// RevokeUrlException ends an active exception early. Caller must be an org admin or owner, or the member it is for.
+	RevokeUrlException(ctx context.Context, in *RevokeUrlExceptionRequest, opts ...grpc.CallOption) (*RevokeUrlExceptionResponse, error)
+}
+
+type urlExceptionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUrlExceptionServiceClient(cc grpc.ClientConnInterface) UrlExceptionServiceClient {
+	return &urlExceptionServiceClient{cc}
+}
+
+func (c *urlExceptionServiceClient) RequestUrlException(ctx context.Context, in *RequestUrlExceptionRequest, opts ...grpc.CallOption) (*RequestUrlExceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestUrlExceptionResponse)
+	err := c.cc.Invoke(ctx, UrlExceptionService_RequestUrlException_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlExceptionServiceClient) ListUrlExceptions(ctx context.Context, in *ListUrlExceptionsRequest, opts ...grpc.CallOption) (*ListUrlExceptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUrlExceptionsResponse)
+	err := c.cc.Invoke(ctx, UrlExceptionService_ListUrlExceptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlExceptionServiceClient) ApproveUrlException(ctx context.Context, in *ApproveUrlExceptionRequest, opts ...grpc.CallOption) (*ApproveUrlExceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveUrlExceptionResponse)
+	err := c.cc.Invoke(ctx, UrlExceptionService_ApproveUrlException_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlExceptionServiceClient) RejectUrlException(ctx context.Context, in *RejectUrlExceptionRequest, opts ...grpc.CallOption) (*RejectUrlExceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectUrlExceptionResponse)
+	err := c.cc.Invoke(ctx, UrlExceptionService_RejectUrlException_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlExceptionServiceClient) RevokeUrlException(ctx context.Context, in *RevokeUrlExceptionRequest, opts ...grpc.CallOption) (*RevokeUrlExceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeUrlExceptionResponse)
+	err := c.cc.Invoke(ctx, UrlExceptionService_RevokeUrlException_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UrlExceptionServiceServer is the server API for UrlExceptionService service.
+// All implementations must embed UnimplementedUrlExceptionServiceServer
+// for forward compatibility.
+//
+// UrlExceptionService lets members ask for access to a domain that the org's access control blocks. A member
+// submits the blocked URL with a justification; an org admin approves a time-boxed exception or rejects the request.
+// While an exception is active, CheckUrlAccess allows its domain for that member. Every step is audited.
+type UrlExceptionServiceServer interface {
+	// RequestUrlException stores a pending request. Caller must be an org member; the URL must be denied for them, and
+	// they must have no pending or active exception for its domain.
+	RequestUrlException(context.Context, *RequestUrlExceptionRequest) (*RequestUrlExceptionResponse, error)
+	ListUrlExceptions(context.Context, *ListUrlExceptionsRequest) (*ListUrlExceptionsResponse, error)
+	// ApproveUrlException starts the exception window. Caller must be an org admin or owner other than the requester.
+	ApproveUrlException(context.Context, *ApproveUrlExceptionRequest) (*ApproveUrlExceptionResponse, error)
+	// RejectUrlException closes a pending request. Caller must be an org admin or owner, or the requester
+	// (withdrawing it).
+	RejectUrlException(context.Context, *RejectUrlExceptionRequest) (*RejectUrlExceptionResponse, error)
+	// RevokeUrlException ends an active exception early. Caller must be an org admin or owner, or the member it is for.
+	RevokeUrlException(context.Context, *RevokeUrlExceptionRequest) (*RevokeUrlExceptionResponse, error)
+	mustEmbedUnimplementedUrlExceptionServiceServer()
+}
+
+// UnimplementedUrlExceptionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUrlExceptionServiceServer struct{}
+
+func (UnimplementedUrlExceptionServiceServer) RequestUrlException(context.Context, *RequestUrlExceptionRequest) (*RequestUrlExceptionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestUrlException not implemented")
+}
+func (UnimplementedUrlExceptionServiceServer) ListUrlExceptions(context.Context, *ListUrlExceptionsRequest) (*ListUrlExceptionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUrlExceptions not implemented")
+}
+func (UnimplementedUrlExceptionServiceServer) ApproveUrlException(context.Context, *ApproveUrlExceptionRequest) (*ApproveUrlExceptionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveUrlException not implemented")
+}
+func (UnimplementedUrlExceptionServiceServer) RejectUrlException(context.Context, *RejectUrlExceptionRequest) (*RejectUrlExceptionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectUrlException not implemented")
+}
+func (UnimplementedUrlExceptionServiceServer) RevokeUrlException(context.Context, *RevokeUrlExceptionRequest) (*RevokeUrlExceptionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeUrlException not implemented")
+}
+func (UnimplementedUrlExceptionServiceServer) mustEmbedUnimplementedUrlExceptionServiceServer() {}
+func (UnimplementedUrlExceptionServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeUrlExceptionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UrlExceptionServiceServer will
+// result in compilation errors.
+type UnsafeUrlExceptionServiceServer interface {
+	mustEmbedUnimplementedUrlExceptionServiceServer()
+}
+
+func RegisterUrlExceptionServiceServer(s grpc.ServiceRegistrar, srv UrlExceptionServiceServer) {
+	// If the following call panics, it indicates UnimplementedUrlExceptionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UrlExceptionService_ServiceDesc, srv)
+}
+
+func _UrlExceptionService_RequestUrlException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestUrlExceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlExceptionServiceServer).RequestUrlException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlExceptionService_RequestUrlException_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlExceptionServiceServer).RequestUrlException(ctx, req.(*RequestUrlExceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlExceptionService_ListUrlExceptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUrlExceptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlExceptionServiceServer).ListUrlExceptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlExceptionService_ListUrlExceptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlExceptionServiceServer).ListUrlExceptions(ctx, req.(*ListUrlExceptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlExceptionService_ApproveUrlException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveUrlExceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlExceptionServiceServer).ApproveUrlException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlExceptionService_ApproveUrlException_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlExceptionServiceServer).ApproveUrlException(ctx, req.(*ApproveUrlExceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlExceptionService_RejectUrlException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectUrlExceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlExceptionServiceServer).RejectUrlException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlExceptionService_RejectUrlException_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlExceptionServiceServer).RejectUrlException(ctx, req.(*RejectUrlExceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlExceptionService_RevokeUrlException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUrlExceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlExceptionServiceServer).RevokeUrlException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlExceptionService_RevokeUrlException_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlExceptionServiceServer).RevokeUrlException(ctx, req.(*RevokeUrlExceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UrlExceptionService_ServiceDesc is the grpc.ServiceDesc for UrlExceptionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UrlExceptionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.urlexception.v1.UrlExceptionService",
+	HandlerType: (*UrlExceptionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestUrlException",
+			Handler:    _UrlExceptionService_RequestUrlException_Handler,
+		},
+		{
+			MethodName: "ListUrlExceptions",
+			Handler:    _UrlExceptionService_ListUrlExceptions_Handler,
+		},
+		{
+			MethodName: "ApproveUrlException",
+			Handler:    _UrlExceptionService_ApproveUrlException_Handler,
+		},
+		{
+			MethodName: "RejectUrlException",
+			Handler:    _UrlExceptionService_RejectUrlException_Handler,
+		},
+		{
+			MethodName: "RevokeUrlException",
+			Handler:    _UrlExceptionService_RevokeUrlException_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "urlexception/urlexception.proto",
+}
@@ -73,6 +73,59 @@ func (UserStatus) EnumDescriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{0}
 }
 
+// DataExportStatus is the state of a data export.
+type DataExportStatus int32
+
+const (
+	DataExportStatus_DATA_EXPORT_STATUS_UNSPECIFIED DataExportStatus = 0
+	DataExportStatus_DATA_EXPORT_STATUS_PENDING     DataExportStatus = 1 // being generated; download again later
+	DataExportStatus_DATA_EXPORT_STATUS_READY       DataExportStatus = 2
+	DataExportStatus_DATA_EXPORT_STATUS_FAILED      DataExportStatus = 3 // generation failed; request a new export
+)
+
+// Enum value maps for DataExportStatus.
+var (
+	DataExportStatus_name = map[int32]string{
+		0: "DATA_EXPORT_STATUS_UNSPECIFIED",
+		1: "DATA_EXPORT_STATUS_PENDING",
+		2: "DATA_EXPORT_STATUS_READY",
+		3: "DATA_EXPORT_STATUS_FAILED",
+	}
+	DataExportStatus_value = map[string]int32{
+		"DATA_EXPORT_STATUS_UNSPECIFIED": 0,
+		"DATA_EXPORT_STATUS_PENDING":     1,
+		"DATA_EXPORT_STATUS_READY":       2,
+		"DATA_EXPORT_STATUS_FAILED":      3,
+	}
+)
+
+func (x DataExportStatus) Enum() *DataExportStatus {
+	p := new(DataExportStatus)
+	*p = x
+	return p
+}
+
+func (x DataExportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataExportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_user_user_proto_enumTypes[1].Descriptor()
+}
+
+func (DataExportStatus) Type() protoreflect.EnumType {
+	return &file_user_user_proto_enumTypes[1]
+}
+
+func (x DataExportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataExportStatus.Descriptor instead.
+func (DataExportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{1}
+}
+
 // User is the core user resource (global, not org-scoped).
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -600,6 +653,234 @@ func (*EnableUserResponse) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{10}
 }
 
+// ExportMyDataRequest requests an archive of the caller's personal data.
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_user_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{11}
+}
+
+// ExportMyDataResponse returns the download token of the export. The token is shown only once.
+type ExportMyDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	DownloadToken string                 `protobuf:"bytes,2,opt,name=download_token,json=downloadToken,proto3" json:"download_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataResponse) Reset() {
+	*x = ExportMyDataResponse{}
+	mi := &file_user_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataResponse) ProtoMessage() {}
+
+func (x *ExportMyDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportMyDataResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportMyDataResponse) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+func (x *ExportMyDataResponse) GetDownloadToken() string {
+	if x != nil {
+		return x.DownloadToken
+	}
+	return ""
+}
+
+func (x *ExportMyDataResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// DownloadDataExportRequest identifies the export by the token returned when it was requested.
+type DownloadDataExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadToken string                 `protobuf:"bytes,1,opt,name=download_token,json=downloadToken,proto3" json:"download_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadDataExportRequest) Reset() {
+	*x = DownloadDataExportRequest{}
+	mi := &file_user_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadDataExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadDataExportRequest) ProtoMessage() {}
+
+func (x *DownloadDataExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadDataExportRequest.ProtoReflect.Descriptor instead.
+func (*DownloadDataExportRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DownloadDataExportRequest) GetDownloadToken() string {
+	if x != nil {
+		return x.DownloadToken
+	}
+	return ""
+}
+
+// DownloadDataExportResponse returns the export's status and, when ready, its archive: gzipped JSON.
+type DownloadDataExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        DataExportStatus       `protobuf:"varint,1,opt,name=status,proto3,enum=ztcp.user.v1.DataExportStatus" json:"status,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Archive       []byte                 `protobuf:"bytes,3,opt,name=archive,proto3" json:"archive,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadDataExportResponse) Reset() {
+	*x = DownloadDataExportResponse{}
+	mi := &file_user_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadDataExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadDataExportResponse) ProtoMessage() {}
+
+func (x *DownloadDataExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadDataExportResponse.ProtoReflect.Descriptor instead.
+func (*DownloadDataExportResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DownloadDataExportResponse) GetStatus() DataExportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DataExportStatus_DATA_EXPORT_STATUS_UNSPECIFIED
+}
+
+func (x *DownloadDataExportResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DownloadDataExportResponse) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *DownloadDataExportResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DownloadDataExportResponse) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *DownloadDataExportResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 var File_user_user_proto protoreflect.FileDescriptor
 
 const file_user_user_proto_rawDesc = "" +
@@ -636,19 +917,43 @@ const file_user_user_proto_rawDesc = "" +
 	"\x13DisableUserResponse\",\n" +
 	"\x11EnableUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x14\n" +
-	"\x12EnableUserResponse*[\n" +
+	"\x12EnableUserResponse\"\x15\n" +
+	"\x13ExportMyDataRequest\"\x95\x01\n" +
+	"\x14ExportMyDataResponse\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\x12%\n" +
+	"\x0edownload_token\x18\x02 \x01(\tR\rdownloadToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"B\n" +
+	"\x19DownloadDataExportRequest\x12%\n" +
+	"\x0edownload_token\x18\x01 \x01(\tR\rdownloadToken\"\xc0\x02\n" +
+	"\x1aDownloadDataExportResponse\x126\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1e.ztcp.user.v1.DataExportStatusR\x06status\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x18\n" +
+	"\aarchive\x18\x03 \x01(\fR\aarchive\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt*[\n" +
 	"\n" +
 	"UserStatus\x12\x1b\n" +
 	"\x17USER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12USER_STATUS_ACTIVE\x10\x01\x12\x18\n" +
-	"\x14USER_STATUS_DISABLED\x10\x022\xa5\x03\n" +
-	"\vUserService\x12F\n" +
-	"\aGetUser\x12\x1c.ztcp.user.v1.GetUserRequest\x1a\x1d.ztcp.user.v1.GetUserResponse\x12[\n" +
-	"\x0eGetUserByEmail\x12#.ztcp.user.v1.GetUserByEmailRequest\x1a$.ztcp.user.v1.GetUserByEmailResponse\x12L\n" +
-	"\tListUsers\x12\x1e.ztcp.user.v1.ListUsersRequest\x1a\x1f.ztcp.user.v1.ListUsersResponse\x12R\n" +
+	"\x14USER_STATUS_DISABLED\x10\x02*\x93\x01\n" +
+	"\x10DataExportStatus\x12\"\n" +
+	"\x1eDATA_EXPORT_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aDATA_EXPORT_STATUS_PENDING\x10\x01\x12\x1c\n" +
+	"\x18DATA_EXPORT_STATUS_READY\x10\x02\x12\x1d\n" +
+	"\x19DATA_EXPORT_STATUS_FAILED\x10\x032\xf9\x04\n" +
+	"\vUserService\x12K\n" +
+	"\aGetUser\x12\x1c.ztcp.user.v1.GetUserRequest\x1a\x1d.ztcp.user.v1.GetUserResponse\"\x03\x90\x02\x01\x12`\n" +
+	"\x0eGetUserByEmail\x12#.ztcp.user.v1.GetUserByEmailRequest\x1a$.ztcp.user.v1.GetUserByEmailResponse\"\x03\x90\x02\x01\x12Q\n" +
+	"\tListUsers\x12\x1e.ztcp.user.v1.ListUsersRequest\x1a\x1f.ztcp.user.v1.ListUsersResponse\"\x03\x90\x02\x01\x12R\n" +
 	"\vDisableUser\x12 .ztcp.user.v1.DisableUserRequest\x1a!.ztcp.user.v1.DisableUserResponse\x12O\n" +
 	"\n" +
-	"EnableUser\x12\x1f.ztcp.user.v1.EnableUserRequest\x1a .ztcp.user.v1.EnableUserResponseB?Z=zero-trust-control-plane/backend/api/generated/user/v1;userv1b\x06proto3"
+	"EnableUser\x12\x1f.ztcp.user.v1.EnableUserRequest\x1a .ztcp.user.v1.EnableUserResponse\x12U\n" +
+	"\fExportMyData\x12!.ztcp.user.v1.ExportMyDataRequest\x1a\".ztcp.user.v1.ExportMyDataResponse\x12l\n" +
+	"\x12DownloadDataExport\x12'.ztcp.user.v1.DownloadDataExportRequest\x1a(.ztcp.user.v1.DownloadDataExportResponse\"\x03\x90\x02\x01B?Z=zero-trust-control-plane/backend/api/generated/user/v1;userv1b\x06proto3"
 
 var (
 	file_user_user_proto_rawDescOnce sync.Once
@@ -662,49 +967,63 @@ func file_user_user_proto_rawDescGZIP() []byte {
 	return file_user_user_proto_rawDescData
 }
 
-var file_user_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_user_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_user_user_proto_goTypes = []any{
-	(UserStatus)(0),                // 0: ztcp.user.v1.UserStatus
-	(*User)(nil),                   // 1: ztcp.user.v1.User
-	(*GetUserRequest)(nil),         // 2: ztcp.user.v1.GetUserRequest
-	(*GetUserResponse)(nil),        // 3: ztcp.user.v1.GetUserResponse
-	(*GetUserByEmailRequest)(nil),  // 4: ztcp.user.v1.GetUserByEmailRequest
-	(*GetUserByEmailResponse)(nil), // 5: ztcp.user.v1.GetUserByEmailResponse
-	(*ListUsersRequest)(nil),       // 6: ztcp.user.v1.ListUsersRequest
-	(*ListUsersResponse)(nil),      // 7: ztcp.user.v1.ListUsersResponse
-	(*DisableUserRequest)(nil),     // 8: ztcp.user.v1.DisableUserRequest
-	(*DisableUserResponse)(nil),    // 9: ztcp.user.v1.DisableUserResponse
-	(*EnableUserRequest)(nil),      // 10: ztcp.user.v1.EnableUserRequest
-	(*EnableUserResponse)(nil),     // 11: ztcp.user.v1.EnableUserResponse
-	(*timestamppb.Timestamp)(nil),  // 12: google.protobuf.Timestamp
-	(*v1.Pagination)(nil),          // 13: ztcp.common.v1.Pagination
-	(*v1.PaginationResult)(nil),    // 14: ztcp.common.v1.PaginationResult
+	(UserStatus)(0),                    // 0: ztcp.user.v1.UserStatus
+	(DataExportStatus)(0),              // 1: ztcp.user.v1.DataExportStatus
+	(*User)(nil),                       // 2: ztcp.user.v1.User
+	(*GetUserRequest)(nil),             // 3: ztcp.user.v1.GetUserRequest
+	(*GetUserResponse)(nil),            // 4: ztcp.user.v1.GetUserResponse
+	(*GetUserByEmailRequest)(nil),      // 5: ztcp.user.v1.GetUserByEmailRequest
+	(*GetUserByEmailResponse)(nil),     // 6: ztcp.user.v1.GetUserByEmailResponse
+	(*ListUsersRequest)(nil),           // 7: ztcp.user.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),          // 8: ztcp.user.v1.ListUsersResponse
+	(*DisableUserRequest)(nil),         // 9: ztcp.user.v1.DisableUserRequest
+	(*DisableUserResponse)(nil),        // 10: ztcp.user.v1.DisableUserResponse
+	(*EnableUserRequest)(nil),          // 11: ztcp.user.v1.EnableUserRequest
+	(*EnableUserResponse)(nil),         // 12: ztcp.user.v1.EnableUserResponse
+	(*ExportMyDataRequest)(nil),        // 13: ztcp.user.v1.ExportMyDataRequest
+	(*ExportMyDataResponse)(nil),       // 14: ztcp.user.v1.ExportMyDataResponse
+	(*DownloadDataExportRequest)(nil),  // 15: ztcp.user.v1.DownloadDataExportRequest
+	(*DownloadDataExportResponse)(nil), // 16: ztcp.user.v1.DownloadDataExportResponse
+	(*timestamppb.Timestamp)(nil),      // 17: google.protobuf.Timestamp
+	(*v1.Pagination)(nil),              // 18: ztcp.common.v1.Pagination
+	(*v1.PaginationResult)(nil),        // 19: ztcp.common.v1.PaginationResult
 }
 var file_user_user_proto_depIdxs = []int32{
 	0,  // 0: ztcp.user.v1.User.status:type_name -> ztcp.user.v1.UserStatus
-	12, // 1: ztcp.user.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	12, // 2: ztcp.user.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 3: ztcp.user.v1.GetUserResponse.user:type_name -> ztcp.user.v1.User
-	1,  // 4: ztcp.user.v1.GetUserByEmailResponse.user:type_name -> ztcp.user.v1.User
-	13, // 5: ztcp.user.v1.ListUsersRequest.pagination:type_name -> ztcp.common.v1.Pagination
-	1,  // 6: ztcp.user.v1.ListUsersResponse.users:type_name -> ztcp.user.v1.User
-	14, // 7: ztcp.user.v1.ListUsersResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
-	2,  // 8: ztcp.user.v1.UserService.GetUser:input_type -> ztcp.user.v1.GetUserRequest
-	4,  // 9: ztcp.user.v1.UserService.GetUserByEmail:input_type -> ztcp.user.v1.GetUserByEmailRequest
-	6,  // 10: ztcp.user.v1.UserService.ListUsers:input_type -> ztcp.user.v1.ListUsersRequest
-	8,  // 11: ztcp.user.v1.UserService.DisableUser:input_type -> ztcp.user.v1.DisableUserRequest
-	10, // 12: ztcp.user.v1.UserService.EnableUser:input_type -> ztcp.user.v1.EnableUserRequest
-	3,  // 13: ztcp.user.v1.UserService.GetUser:output_type -> ztcp.user.v1.GetUserResponse
-	5,  // 14: ztcp.user.v1.UserService.GetUserByEmail:output_type -> ztcp.user.v1.GetUserByEmailResponse
-	7,  // 15: ztcp.user.v1.UserService.ListUsers:output_type -> ztcp.user.v1.ListUsersResponse
-	9,  // 16: ztcp.user.v1.UserService.DisableUser:output_type -> ztcp.user.v1.DisableUserResponse
-	11, // 17: ztcp.user.v1.UserService.EnableUser:output_type -> ztcp.user.v1.EnableUserResponse
-	13, // [13:18] is the sub-list for method output_type
-	8,  // [8:13] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	17, // 1: ztcp.user.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	17, // 2: ztcp.user.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: ztcp.user.v1.GetUserResponse.user:type_name -> ztcp.user.v1.User
+	2,  // 4: ztcp.user.v1.GetUserByEmailResponse.user:type_name -> ztcp.user.v1.User
+	18, // 5: ztcp.user.v1.ListUsersRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	2,  // 6: ztcp.user.v1.ListUsersResponse.users:type_name -> ztcp.user.v1.User
+	19, // 7: ztcp.user.v1.ListUsersResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	17, // 8: ztcp.user.v1.ExportMyDataResponse.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: ztcp.user.v1.DownloadDataExportResponse.status:type_name -> ztcp.user.v1.DataExportStatus
+	17, // 10: ztcp.user.v1.DownloadDataExportResponse.created_at:type_name -> google.protobuf.Timestamp
+	17, // 11: ztcp.user.v1.DownloadDataExportResponse.completed_at:type_name -> google.protobuf.Timestamp
+	17, // 12: ztcp.user.v1.DownloadDataExportResponse.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 13: ztcp.user.v1.UserService.GetUser:input_type -> ztcp.user.v1.GetUserRequest
+	5,  // 14: ztcp.user.v1.UserService.GetUserByEmail:input_type -> ztcp.user.v1.GetUserByEmailRequest
+	7,  // 15: ztcp.user.v1.UserService.ListUsers:input_type -> ztcp.user.v1.ListUsersRequest
+	9,  // 16: ztcp.user.v1.UserService.DisableUser:input_type -> ztcp.user.v1.DisableUserRequest
+	11, // 17: ztcp.user.v1.UserService.EnableUser:input_type -> ztcp.user.v1.EnableUserRequest
+	13, // 18: ztcp.user.v1.UserService.ExportMyData:input_type -> ztcp.user.v1.ExportMyDataRequest
+	15, // 19: ztcp.user.v1.UserService.DownloadDataExport:input_type -> ztcp.user.v1.DownloadDataExportRequest
+	4,  // 20: ztcp.user.v1.UserService.GetUser:output_type -> ztcp.user.v1.GetUserResponse
+	6,  // 21: ztcp.user.v1.UserService.GetUserByEmail:output_type -> ztcp.user.v1.GetUserByEmailResponse
+	8,  // 22: ztcp.user.v1.UserService.ListUsers:output_type -> ztcp.user.v1.ListUsersResponse
+	10, // 23: ztcp.user.v1.UserService.DisableUser:output_type -> ztcp.user.v1.DisableUserResponse
+	12, // 24: ztcp.user.v1.UserService.EnableUser:output_type -> ztcp.user.v1.EnableUserResponse
+	14, // 25: ztcp.user.v1.UserService.ExportMyData:output_type -> ztcp.user.v1.ExportMyDataResponse
+	16, // 26: ztcp.user.v1.UserService.DownloadDataExport:output_type -> ztcp.user.v1.DownloadDataExportResponse
+	20, // [20:27] is the sub-list for method output_type
+	13, // [13:20] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_user_user_proto_init() }
@@ -717,8 +1036,8 @@ func file_user_user_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_user_proto_rawDesc), len(file_user_user_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetUser_FullMethodName        = "/ztcp.user.v1.UserService/GetUser"
-	UserService_GetUserByEmail_FullMethodName = "/ztcp.user.v1.UserService/GetUserByEmail"
-	UserService_ListUsers_FullMethodName      = "/ztcp.user.v1.UserService/ListUsers"
-	UserService_DisableUser_FullMethodName    = "/ztcp.user.v1.UserService/DisableUser"
-	UserService_EnableUser_FullMethodName     = "/ztcp.user.v1.UserService/EnableUser"
+	UserService_GetUser_FullMethodName            = "/ztcp.user.v1.UserService/GetUser"
+	UserService_GetUserByEmail_FullMethodName     = "/ztcp.user.v1.UserService/GetUserByEmail"
+	UserService_ListUsers_FullMethodName          = "/ztcp.user.v1.UserService/ListUsers"
+	UserService_DisableUser_FullMethodName        = "/ztcp.user.v1.UserService/DisableUser"
+	UserService_EnableUser_FullMethodName         = "/ztcp.user.v1.UserService/EnableUser"
+	UserService_ExportMyData_FullMethodName       = "/ztcp.user.v1.UserService/ExportMyData"
+	UserService_DownloadDataExport_FullMethodName = "/ztcp.user.v1.UserService/DownloadDataExport"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -37,6 +39,11 @@ type UserServiceClient interface {
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	DisableUser(ctx context.Context, in *DisableUserRequest, opts ...grpc.CallOption) (*DisableUserResponse, error)
 	EnableUser(ctx context.Context, in *EnableUserRequest, opts ...grpc.CallOption) (*EnableUserResponse, error)
+	// ExportMyData requests an archive of the caller's personal data (data-subject access request). The archive is
+	// generated asynchronously; download it with DownloadDataExport and the returned token.
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*ExportMyDataResponse, error)
+	// DownloadDataExport returns an export requested by the caller, here or with AdminService.ExportUserData.
+	DownloadDataExport(ctx context.Context, in *DownloadDataExportRequest, opts ...grpc.CallOption) (*DownloadDataExportResponse, error)
 }
 
 type userServiceClient struct {
@@ -97,6 +104,26 @@ func (c *userServiceClient) EnableUser(ctx context.Context, in *EnableUserReques
 	return out, nil
 }
 
+func (c *userServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*ExportMyDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportMyDataResponse)
+	err := c.cc.Invoke(ctx, UserService_ExportMyData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DownloadDataExport(ctx context.Context, in *DownloadDataExportRequest, opts ...grpc.CallOption) (*DownloadDataExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DownloadDataExportResponse)
+	err := c.cc.Invoke(ctx, UserService_DownloadDataExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -108,6 +135,11 @@ type UserServiceServer interface {
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	DisableUser(context.Context, *DisableUserRequest) (*DisableUserResponse, error)
 	EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error)
+	// ExportMyData requests an archive of the caller's personal data (data-subject access request). The archive is
+	// generated asynchronously; download it with DownloadDataExport and the returned token.
+	ExportMyData(context.Context, *ExportMyDataRequest) (*ExportMyDataResponse, error)
+	// DownloadDataExport returns an export requested by the caller, here or with AdminService.ExportUserData.
+	DownloadDataExport(context.Context, *DownloadDataExportRequest) (*DownloadDataExportResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -133,6 +165,12 @@ func (UnimplementedUserServiceServer) DisableUser(context.Context, *DisableUserR
 func (UnimplementedUserServiceServer) EnableUser(context.Context, *EnableUserRequest) (*EnableUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method EnableUser not implemented")
 }
+func (UnimplementedUserServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*ExportMyDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedUserServiceServer) DownloadDataExport(context.Context, *DownloadDataExportRequest) (*DownloadDataExportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DownloadDataExport not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -244,6 +282,42 @@ func _UserService_EnableUser_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DownloadDataExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadDataExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DownloadDataExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DownloadDataExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DownloadDataExport(ctx, req.(*DownloadDataExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,6 +345,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EnableUser",
 			Handler:    _UserService_EnableUser_Handler,
 		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _UserService_ExportMyData_Handler,
+		},
+		{
+			MethodName: "DownloadDataExport",
+			Handler:    _UserService_DownloadDataExport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/user.proto",
@@ -0,0 +1,1013 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.2
+// source: userattribute/userattribute.proto
+
+package userattributev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	v11 "zero-trust-control-plane/backend/api/generated/common/v1"
+	v1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AttributeType is the value type of a custom attribute. It decides which values are accepted and how they appear
+// in policy input (input.user.attributes).
+type AttributeType int32
+
+const (
+	AttributeType_ATTRIBUTE_TYPE_UNSPECIFIED AttributeType = 0
+	AttributeType_ATTRIBUTE_TYPE_STRING      AttributeType = 1
+	AttributeType_ATTRIBUTE_TYPE_NUMBER      AttributeType = 2 // a JSON number in policy input
+	AttributeType_ATTRIBUTE_TYPE_BOOLEAN     AttributeType = 3 // true or false; a JSON boolean in policy input
+	AttributeType_ATTRIBUTE_TYPE_ENUM        AttributeType = 4 // one of allowed_values
+)
+
+// Enum value maps for AttributeType.
+var (
+	AttributeType_name = map[int32]string{
+		0: "ATTRIBUTE_TYPE_UNSPECIFIED",
+		1: "ATTRIBUTE_TYPE_STRING",
+		2: "ATTRIBUTE_TYPE_NUMBER",
+		3: "ATTRIBUTE_TYPE_BOOLEAN",
+		4: "ATTRIBUTE_TYPE_ENUM",
+	}
+	AttributeType_value = map[string]int32{
+		"ATTRIBUTE_TYPE_UNSPECIFIED": 0,
+		"ATTRIBUTE_TYPE_STRING":      1,
+		"ATTRIBUTE_TYPE_NUMBER":      2,
+		"ATTRIBUTE_TYPE_BOOLEAN":     3,
+		"ATTRIBUTE_TYPE_ENUM":        4,
+	}
+)
+
+func (x AttributeType) Enum() *AttributeType {
+	p := new(AttributeType)
+	*p = x
+	return p
+}
+
+func (x AttributeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttributeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_userattribute_userattribute_proto_enumTypes[0].Descriptor()
+}
+
+func (AttributeType) Type() protoreflect.EnumType {
+	return &file_userattribute_userattribute_proto_enumTypes[0]
+}
+
+func (x AttributeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttributeType.Descriptor instead.
+func (AttributeType) EnumDescriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{0}
+}
+
+// AttributeDefinition is a custom attribute an org defines for its members (e.g. department, employee_id,
+// cost_center).
+type AttributeDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                                    // lowercase letters, digits and underscores, starting with a letter; at most 63 characters
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"` // at most 100 characters
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                    // at most 500 characters
+	Type          AttributeType          `protobuf:"varint,4,opt,name=type,proto3,enum=ztcp.userattribute.v1.AttributeType" json:"type,omitempty"`
+	AllowedValues []string               `protobuf:"bytes,5,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"` // enum only; at most 100 values of at most 256 characters
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeDefinition) Reset() {
+	*x = AttributeDefinition{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeDefinition) ProtoMessage() {}
+
+func (x *AttributeDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeDefinition.ProtoReflect.Descriptor instead.
+func (*AttributeDefinition) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AttributeDefinition) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttributeDefinition) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *AttributeDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AttributeDefinition) GetType() AttributeType {
+	if x != nil {
+		return x.Type
+	}
+	return AttributeType_ATTRIBUTE_TYPE_UNSPECIFIED
+}
+
+func (x *AttributeDefinition) GetAllowedValues() []string {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
+func (x *AttributeDefinition) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AttributeDefinition) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// MemberAttributes is a member of the org with their attribute values.
+type MemberAttributes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          v1.Role                `protobuf:"varint,2,opt,name=role,proto3,enum=ztcp.membership.v1.Role" json:"role,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // key → value
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberAttributes) Reset() {
+	*x = MemberAttributes{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberAttributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberAttributes) ProtoMessage() {}
+
+func (x *MemberAttributes) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberAttributes.ProtoReflect.Descriptor instead.
+func (*MemberAttributes) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MemberAttributes) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MemberAttributes) GetRole() v1.Role {
+	if x != nil {
+		return x.Role
+	}
+	return v1.Role(0)
+}
+
+func (x *MemberAttributes) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// AttributeFilter matches members whose attribute key has exactly value (normalized like stored values).
+type AttributeFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeFilter) Reset() {
+	*x = AttributeFilter{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeFilter) ProtoMessage() {}
+
+func (x *AttributeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeFilter.ProtoReflect.Descriptor instead.
+func (*AttributeFilter) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AttributeFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ListAttributeDefinitionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttributeDefinitionsRequest) Reset() {
+	*x = ListAttributeDefinitionsRequest{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttributeDefinitionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttributeDefinitionsRequest) ProtoMessage() {}
+
+func (x *ListAttributeDefinitionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttributeDefinitionsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttributeDefinitionsRequest) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{3}
+}
+
+type ListAttributeDefinitionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Definitions   []*AttributeDefinition `protobuf:"bytes,1,rep,name=definitions,proto3" json:"definitions,omitempty"` // ordered by key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttributeDefinitionsResponse) Reset() {
+	*x = ListAttributeDefinitionsResponse{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttributeDefinitionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttributeDefinitionsResponse) ProtoMessage() {}
+
+func (x *ListAttributeDefinitionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttributeDefinitionsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttributeDefinitionsResponse) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAttributeDefinitionsResponse) GetDefinitions() []*AttributeDefinition {
+	if x != nil {
+		return x.Definitions
+	}
+	return nil
+}
+
+type UpsertAttributeDefinitionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Definition    *AttributeDefinition   `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"` // created_at and updated_at are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertAttributeDefinitionRequest) Reset() {
+	*x = UpsertAttributeDefinitionRequest{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertAttributeDefinitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertAttributeDefinitionRequest) ProtoMessage() {}
+
+func (x *UpsertAttributeDefinitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertAttributeDefinitionRequest.ProtoReflect.Descriptor instead.
+func (*UpsertAttributeDefinitionRequest) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpsertAttributeDefinitionRequest) GetDefinition() *AttributeDefinition {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+type UpsertAttributeDefinitionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Definition    *AttributeDefinition   `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertAttributeDefinitionResponse) Reset() {
+	*x = UpsertAttributeDefinitionResponse{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertAttributeDefinitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertAttributeDefinitionResponse) ProtoMessage() {}
+
+func (x *UpsertAttributeDefinitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertAttributeDefinitionResponse.ProtoReflect.Descriptor instead.
+func (*UpsertAttributeDefinitionResponse) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpsertAttributeDefinitionResponse) GetDefinition() *AttributeDefinition {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+type DeleteAttributeDefinitionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttributeDefinitionRequest) Reset() {
+	*x = DeleteAttributeDefinitionRequest{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttributeDefinitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttributeDefinitionRequest) ProtoMessage() {}
+
+func (x *DeleteAttributeDefinitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttributeDefinitionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttributeDefinitionRequest) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteAttributeDefinitionRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type DeleteAttributeDefinitionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttributeDefinitionResponse) Reset() {
+	*x = DeleteAttributeDefinitionResponse{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttributeDefinitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttributeDefinitionResponse) ProtoMessage() {}
+
+func (x *DeleteAttributeDefinitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttributeDefinitionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttributeDefinitionResponse) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{8}
+}
+
+type GetMemberAttributesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional; empty = the caller
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberAttributesRequest) Reset() {
+	*x = GetMemberAttributesRequest{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberAttributesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberAttributesRequest) ProtoMessage() {}
+
+func (x *GetMemberAttributesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberAttributesRequest.ProtoReflect.Descriptor instead.
+func (*GetMemberAttributesRequest) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetMemberAttributesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetMemberAttributesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *MemberAttributes      `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemberAttributesResponse) Reset() {
+	*x = GetMemberAttributesResponse{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemberAttributesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemberAttributesResponse) ProtoMessage() {}
+
+func (x *GetMemberAttributesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemberAttributesResponse.ProtoReflect.Descriptor instead.
+func (*GetMemberAttributesResponse) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetMemberAttributesResponse) GetMember() *MemberAttributes {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type SetMemberAttributesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // values to set; each key must be defined
+	RemoveKeys    []string               `protobuf:"bytes,3,rep,name=remove_keys,json=removeKeys,proto3" json:"remove_keys,omitempty"`                                                         // values to remove
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberAttributesRequest) Reset() {
+	*x = SetMemberAttributesRequest{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberAttributesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberAttributesRequest) ProtoMessage() {}
+
+func (x *SetMemberAttributesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberAttributesRequest.ProtoReflect.Descriptor instead.
+func (*SetMemberAttributesRequest) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetMemberAttributesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetMemberAttributesRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *SetMemberAttributesRequest) GetRemoveKeys() []string {
+	if x != nil {
+		return x.RemoveKeys
+	}
+	return nil
+}
+
+type SetMemberAttributesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *MemberAttributes      `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberAttributesResponse) Reset() {
+	*x = SetMemberAttributesResponse{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberAttributesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberAttributesResponse) ProtoMessage() {}
+
+func (x *SetMemberAttributesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberAttributesResponse.ProtoReflect.Descriptor instead.
+func (*SetMemberAttributesResponse) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetMemberAttributesResponse) GetMember() *MemberAttributes {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type SearchMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filters       []*AttributeFilter     `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"` // all must match; at most 10; empty lists every member
+	Pagination    *v11.Pagination        `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMembersRequest) Reset() {
+	*x = SearchMembersRequest{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMembersRequest) ProtoMessage() {}
+
+func (x *SearchMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMembersRequest.ProtoReflect.Descriptor instead.
+func (*SearchMembersRequest) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchMembersRequest) GetFilters() []*AttributeFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *SearchMembersRequest) GetPagination() *v11.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*MemberAttributes    `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"` // oldest membership first
+	Pagination    *v11.PaginationResult  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMembersResponse) Reset() {
+	*x = SearchMembersResponse{}
+	mi := &file_userattribute_userattribute_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMembersResponse) ProtoMessage() {}
+
+func (x *SearchMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userattribute_userattribute_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMembersResponse.ProtoReflect.Descriptor instead.
+func (*SearchMembersResponse) Descriptor() ([]byte, []int) {
+	return file_userattribute_userattribute_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchMembersResponse) GetMembers() []*MemberAttributes {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *SearchMembersResponse) GetPagination() *v11.PaginationResult {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_userattribute_userattribute_proto protoreflect.FileDescriptor
+
+const file_userattribute_userattribute_proto_rawDesc = "" +
+	"\n" +
+	"!userattribute/userattribute.proto\x12\x15ztcp.userattribute.v1\x1a\x13common/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bmembership/membership.proto\"\xc3\x02\n" +
+	"\x13AttributeDefinition\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x128\n" +
+	"\x04type\x18\x04 \x01(\x0e2$.ztcp.userattribute.v1.AttributeTypeR\x04type\x12%\n" +
+	"\x0eallowed_values\x18\x05 \x03(\tR\rallowedValues\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf1\x01\n" +
+	"\x10MemberAttributes\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12,\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x18.ztcp.membership.v1.RoleR\x04role\x12W\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v27.ztcp.userattribute.v1.MemberAttributes.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"9\n" +
+	"\x0fAttributeFilter\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"!\n" +
+	"\x1fListAttributeDefinitionsRequest\"p\n" +
+	" ListAttributeDefinitionsResponse\x12L\n" +
+	"\vdefinitions\x18\x01 \x03(\v2*.ztcp.userattribute.v1.AttributeDefinitionR\vdefinitions\"n\n" +
+	" UpsertAttributeDefinitionRequest\x12J\n" +
+	"\n" +
+	"definition\x18\x01 \x01(\v2*.ztcp.userattribute.v1.AttributeDefinitionR\n" +
+	"definition\"o\n" +
+	"!UpsertAttributeDefinitionResponse\x12J\n" +
+	"\n" +
+	"definition\x18\x01 \x01(\v2*.ztcp.userattribute.v1.AttributeDefinitionR\n" +
+	"definition\"4\n" +
+	" DeleteAttributeDefinitionRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"#\n" +
+	"!DeleteAttributeDefinitionResponse\"5\n" +
+	"\x1aGetMemberAttributesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"^\n" +
+	"\x1bGetMemberAttributesResponse\x12?\n" +
+	"\x06member\x18\x01 \x01(\v2'.ztcp.userattribute.v1.MemberAttributesR\x06member\"\xf8\x01\n" +
+	"\x1aSetMemberAttributesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12a\n" +
+	"\n" +
+	"attributes\x18\x02 \x03(\v2A.ztcp.userattribute.v1.SetMemberAttributesRequest.AttributesEntryR\n" +
+	"attributes\x12\x1f\n" +
+	"\vremove_keys\x18\x03 \x03(\tR\n" +
+	"removeKeys\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"^\n" +
+	"\x1bSetMemberAttributesResponse\x12?\n" +
+	"\x06member\x18\x01 \x01(\v2'.ztcp.userattribute.v1.MemberAttributesR\x06member\"\x94\x01\n" +
+	"\x14SearchMembersRequest\x12@\n" +
+	"\afilters\x18\x01 \x03(\v2&.ztcp.userattribute.v1.AttributeFilterR\afilters\x12:\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1a.ztcp.common.v1.PaginationR\n" +
+	"pagination\"\x9c\x01\n" +
+	"\x15SearchMembersResponse\x12A\n" +
+	"\amembers\x18\x01 \x03(\v2'.ztcp.userattribute.v1.MemberAttributesR\amembers\x12@\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2 .ztcp.common.v1.PaginationResultR\n" +
+	"pagination*\x9a\x01\n" +
+	"\rAttributeType\x12\x1e\n" +
+	"\x1aATTRIBUTE_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ATTRIBUTE_TYPE_STRING\x10\x01\x12\x19\n" +
+	"\x15ATTRIBUTE_TYPE_NUMBER\x10\x02\x12\x1a\n" +
+	"\x16ATTRIBUTE_TYPE_BOOLEAN\x10\x03\x12\x17\n" +
+	"\x13ATTRIBUTE_TYPE_ENUM\x10\x042\xbe\x06\n" +
+	"\x14UserAttributeService\x12\x90\x01\n" +
+	"\x18ListAttributeDefinitions\x126.ztcp.userattribute.v1.ListAttributeDefinitionsRequest\x1a7.ztcp.userattribute.v1.ListAttributeDefinitionsResponse\"\x03\x90\x02\x01\x12\x8e\x01\n" +
+	"\x19UpsertAttributeDefinition\x127.ztcp.userattribute.v1.UpsertAttributeDefinitionRequest\x1a8.ztcp.userattribute.v1.UpsertAttributeDefinitionResponse\x12\x8e\x01\n" +
+	"\x19DeleteAttributeDefinition\x127.ztcp.userattribute.v1.DeleteAttributeDefinitionRequest\x1a8.ztcp.userattribute.v1.DeleteAttributeDefinitionResponse\x12\x81\x01\n" +
+	"\x13GetMemberAttributes\x121.ztcp.userattribute.v1.GetMemberAttributesRequest\x1a2.ztcp.userattribute.v1.GetMemberAttributesResponse\"\x03\x90\x02\x01\x12|\n" +
+	"\x13SetMemberAttributes\x121.ztcp.userattribute.v1.SetMemberAttributesRequest\x1a2.ztcp.userattribute.v1.SetMemberAttributesResponse\x12o\n" +
+	"\rSearchMembers\x12+.ztcp.userattribute.v1.SearchMembersRequest\x1a,.ztcp.userattribute.v1.SearchMembersResponse\"\x03\x90\x02\x01BQZOzero-trust-control-plane/backend/api/generated/userattribute/v1;userattributev1b\x06proto3"
+
+var (
+	file_userattribute_userattribute_proto_rawDescOnce sync.Once
+	file_userattribute_userattribute_proto_rawDescData []byte
+)
+
+func file_userattribute_userattribute_proto_rawDescGZIP() []byte {
+	file_userattribute_userattribute_proto_rawDescOnce.Do(func() {
+		file_userattribute_userattribute_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_userattribute_userattribute_proto_rawDesc), len(file_userattribute_userattribute_proto_rawDesc)))
+	})
+	return file_userattribute_userattribute_proto_rawDescData
+}
+
+var file_userattribute_userattribute_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_userattribute_userattribute_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_userattribute_userattribute_proto_goTypes = []any{
+	(AttributeType)(0),                        // 0: ztcp.userattribute.v1.AttributeType
+	(*AttributeDefinition)(nil),               // 1: ztcp.userattribute.v1.AttributeDefinition
+	(*MemberAttributes)(nil),                  // 2: ztcp.userattribute.v1.MemberAttributes
+	(*AttributeFilter)(nil),                   // 3: ztcp.userattribute.v1.AttributeFilter
+	(*ListAttributeDefinitionsRequest)(nil),   // 4: ztcp.userattribute.v1.ListAttributeDefinitionsRequest
+	(*ListAttributeDefinitionsResponse)(nil),  // 5: ztcp.userattribute.v1.ListAttributeDefinitionsResponse
+	(*UpsertAttributeDefinitionRequest)(nil),  // 6: ztcp.userattribute.v1.UpsertAttributeDefinitionRequest
+	(*UpsertAttributeDefinitionResponse)(nil), // 7: ztcp.userattribute.v1.UpsertAttributeDefinitionResponse
+	(*DeleteAttributeDefinitionRequest)(nil),  // 8: ztcp.userattribute.v1.DeleteAttributeDefinitionRequest
+	(*DeleteAttributeDefinitionResponse)(nil), // 9: ztcp.userattribute.v1.DeleteAttributeDefinitionResponse
+	(*GetMemberAttributesRequest)(nil),        // 10: ztcp.userattribute.v1.GetMemberAttributesRequest
+	(*GetMemberAttributesResponse)(nil),       // 11: ztcp.userattribute.v1.GetMemberAttributesResponse
+	(*SetMemberAttributesRequest)(nil),        // 12: ztcp.userattribute.v1.SetMemberAttributesRequest
+	(*SetMemberAttributesResponse)(nil),       // 13: ztcp.userattribute.v1.SetMemberAttributesResponse
+	(*SearchMembersRequest)(nil),              // 14: ztcp.userattribute.v1.SearchMembersRequest
+	(*SearchMembersResponse)(nil),             // 15: ztcp.userattribute.v1.SearchMembersResponse
+	nil,                                       // 16: ztcp.userattribute.v1.MemberAttributes.AttributesEntry
+	nil,                                       // 17: ztcp.userattribute.v1.SetMemberAttributesRequest.AttributesEntry
+	(*timestamppb.Timestamp)(nil),             // 18: google.protobuf.Timestamp
+	(v1.Role)(0),                              // 19: ztcp.membership.v1.Role
+	(*v11.Pagination)(nil),                    // 20: ztcp.common.v1.Pagination
+	(*v11.PaginationResult)(nil),              // 21: ztcp.common.v1.PaginationResult
+}
+var file_userattribute_userattribute_proto_depIdxs = []int32{
+	0,  // 0: ztcp.userattribute.v1.AttributeDefinition.type:type_name -> ztcp.userattribute.v1.AttributeType
+	18, // 1: ztcp.userattribute.v1.AttributeDefinition.created_at:type_name -> google.protobuf.Timestamp
+	18, // 2: ztcp.userattribute.v1.AttributeDefinition.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 3: ztcp.userattribute.v1.MemberAttributes.role:type_name -> ztcp.membership.v1.Role
+	16, // 4: ztcp.userattribute.v1.MemberAttributes.attributes:type_name -> ztcp.userattribute.v1.MemberAttributes.AttributesEntry
+	1,  // 5: ztcp.userattribute.v1.ListAttributeDefinitionsResponse.definitions:type_name -> ztcp.userattribute.v1.AttributeDefinition
+	1,  // 6: ztcp.userattribute.v1.UpsertAttributeDefinitionRequest.definition:type_name -> ztcp.userattribute.v1.AttributeDefinition
+	1,  // 7: ztcp.userattribute.v1.UpsertAttributeDefinitionResponse.definition:type_name -> ztcp.userattribute.v1.AttributeDefinition
+	2,  // 8: ztcp.userattribute.v1.GetMemberAttributesResponse.member:type_name -> ztcp.userattribute.v1.MemberAttributes
+	17, // 9: ztcp.userattribute.v1.SetMemberAttributesRequest.attributes:type_name -> ztcp.userattribute.v1.SetMemberAttributesRequest.AttributesEntry
+	2,  // 10: ztcp.userattribute.v1.SetMemberAttributesResponse.member:type_name -> ztcp.userattribute.v1.MemberAttributes
+	3,  // 11: ztcp.userattribute.v1.SearchMembersRequest.filters:type_name -> ztcp.userattribute.v1.AttributeFilter
+	20, // 12: ztcp.userattribute.v1.SearchMembersRequest.pagination:type_name -> ztcp.common.v1.Pagination
+	2,  // 13: ztcp.userattribute.v1.SearchMembersResponse.members:type_name -> ztcp.userattribute.v1.MemberAttributes
+	21, // 14: ztcp.userattribute.v1.SearchMembersResponse.pagination:type_name -> ztcp.common.v1.PaginationResult
+	4,  // 15: ztcp.userattribute.v1.UserAttributeService.ListAttributeDefinitions:input_type -> ztcp.userattribute.v1.ListAttributeDefinitionsRequest
+	6,  // 16: ztcp.userattribute.v1.UserAttributeService.UpsertAttributeDefinition:input_type -> ztcp.userattribute.v1.UpsertAttributeDefinitionRequest
+	8,  // 17: ztcp.userattribute.v1.UserAttributeService.DeleteAttributeDefinition:input_type -> ztcp.userattribute.v1.DeleteAttributeDefinitionRequest
+	10, // 18: ztcp.userattribute.v1.UserAttributeService.GetMemberAttributes:input_type -> ztcp.userattribute.v1.GetMemberAttributesRequest
+	12, // 19: ztcp.userattribute.v1.UserAttributeService.SetMemberAttributes:input_type -> ztcp.userattribute.v1.SetMemberAttributesRequest
+	14, // 20: ztcp.userattribute.v1.UserAttributeService.SearchMembers:input_type -> ztcp.userattribute.v1.SearchMembersRequest
+	5,  // 21: ztcp.userattribute.v1.UserAttributeService.ListAttributeDefinitions:output_type -> ztcp.userattribute.v1.ListAttributeDefinitionsResponse
+	7,  // 22: ztcp.userattribute.v1.UserAttributeService.UpsertAttributeDefinition:output_type -> ztcp.userattribute.v1.UpsertAttributeDefinitionResponse
+	9,  // 23: ztcp.userattribute.v1.UserAttributeService.DeleteAttributeDefinition:output_type -> ztcp.userattribute.v1.DeleteAttributeDefinitionResponse
+	11, // 24: ztcp.userattribute.v1.UserAttributeService.GetMemberAttributes:output_type -> ztcp.userattribute.v1.GetMemberAttributesResponse
+	13, // 25: ztcp.userattribute.v1.UserAttributeService.SetMemberAttributes:output_type -> ztcp.userattribute.v1.SetMemberAttributesResponse
+	15, // 26: ztcp.userattribute.v1.UserAttributeService.SearchMembers:output_type -> ztcp.userattribute.v1.SearchMembersResponse
+	21, // [21:27] is the sub-list for method output_type
+	15, // [15:21] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_userattribute_userattribute_proto_init() }
+func file_userattribute_userattribute_proto_init() {
+	if File_userattribute_userattribute_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_userattribute_userattribute_proto_rawDesc), len(file_userattribute_userattribute_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_userattribute_userattribute_proto_goTypes,
+		DependencyIndexes: file_userattribute_userattribute_proto_depIdxs,
+		EnumInfos:         file_userattribute_userattribute_proto_enumTypes,
+		MessageInfos:      file_userattribute_userattribute_proto_msgTypes,
+	}.Build()
+	File_userattribute_userattribute_proto = out.File
+	file_userattribute_userattribute_proto_goTypes = nil
+	file_userattribute_userattribute_proto_depIdxs = nil
+}
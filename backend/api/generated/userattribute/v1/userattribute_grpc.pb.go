@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.2
+// source: userattribute/userattribute.proto
+
+package userattributev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserAttributeService_ListAttributeDefinitions_FullMethodName  = "/ztcp.userattribute.v1.UserAttributeService/ListAttributeDefinitions"
+	UserAttributeService_UpsertAttributeDefinition_FullMethodName = "/ztcp.userattribute.v1.UserAttributeService/UpsertAttributeDefinition"
+	UserAttributeService_DeleteAttributeDefinition_FullMethodName = "/ztcp.userattribute.v1.UserAttributeService/DeleteAttributeDefinition"
+	UserAttributeService_GetMemberAttributes_FullMethodName       = "/ztcp.userattribute.v1.UserAttributeService/GetMemberAttributes"
+	UserAttributeService_SetMemberAttributes_FullMethodName       = "/ztcp.userattribute.v1.UserAttributeService/SetMemberAttributes"
+	UserAttributeService_SearchMembers_FullMethodName             = "/ztcp.userattribute.v1.UserAttributeService/SearchMembers"
+)
+
+// UserAttributeServiceClient is the client API for UserAttributeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UserAttributeService manages the custom attributes of the caller's org and their values per member. Values are
+// exposed to MFA policy evaluation as input.user.attributes. Org admins and owners only, except where noted.
+type UserAttributeServiceClient interface {
+	// ListAttributeDefinitions is open to every member of the org.
+	ListAttributeDefinitions(ctx context.Context, in *ListAttributeDefinitionsRequest, opts ...grpc.CallOption) (*ListAttributeDefinitionsResponse, error)
+	// UpsertAttributeDefinition creates or updates a definition; FAILED_PRECONDITION when it would change the type of
+	// an existing attribute or exceed 50 attributes. Audited as attribute_definition_upserted.
+	UpsertAttributeDefinition(ctx context.Context, in *UpsertAttributeDefinitionRequest, opts ...grpc.CallOption) (*UpsertAttributeDefinitionResponse, error)
+	// DeleteAttributeDefinition deletes a definition and every member's value for it; NOT_FOUND if there is none.
+	// Audited as attribute_definition_deleted.
+	DeleteAttributeDefinition(ctx context.Context, in *DeleteAttributeDefinitionRequest, opts ...grpc.CallOption) (*DeleteAttributeDefinitionResponse, error)
+	// GetMemberAttributes returns a member's values. Members may read their own.
+	GetMemberAttributes(ctx context.Context, in *GetMemberAttributesRequest, opts ...grpc.CallOption) (*GetMemberAttributesResponse, error)
+	// SetMemberAttributes sets and removes a member's values. Audited as member_attributes_updated.
+	SetMemberAttributes(ctx context.Context, in *SetMemberAttributesRequest, opts ...grpc.CallOption) (*SetMemberAttributesResponse, error)
+	// SearchMembers returns the members whose values match every filter.
+	SearchMembers(ctx context.Context, in *SearchMembersRequest, opts ...grpc.CallOption) (*SearchMembersResponse, error)
+}
+
+type userAttributeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserAttributeServiceClient(cc grpc.ClientConnInterface) UserAttributeServiceClient {
+	return &userAttributeServiceClient{cc}
+}
+
+func (c *userAttributeServiceClient) ListAttributeDefinitions(ctx context.Context, in *ListAttributeDefinitionsRequest, opts ...grpc.CallOption) (*ListAttributeDefinitionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttributeDefinitionsResponse)
+	err := c.cc.Invoke(ctx, UserAttributeService_ListAttributeDefinitions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAttributeServiceClient) UpsertAttributeDefinition(ctx context.Context, in *UpsertAttributeDefinitionRequest, opts ...grpc.CallOption) (*UpsertAttributeDefinitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertAttributeDefinitionResponse)
+	err := c.cc.Invoke(ctx, UserAttributeService_UpsertAttributeDefinition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAttributeServiceClient) DeleteAttributeDefinition(ctx context.Context, in *DeleteAttributeDefinitionRequest, opts ...grpc.CallOption) (*DeleteAttributeDefinitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAttributeDefinitionResponse)
+	err := c.cc.Invoke(ctx, UserAttributeService_DeleteAttributeDefinition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAttributeServiceClient) GetMemberAttributes(ctx context.Context, in *GetMemberAttributesRequest, opts ...grpc.CallOption) (*GetMemberAttributesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMemberAttributesResponse)
+	err := c.cc.Invoke(ctx, UserAttributeService_GetMemberAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAttributeServiceClient) SetMemberAttributes(ctx context.Context, in *SetMemberAttributesRequest, opts ...grpc.CallOption) (*SetMemberAttributesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMemberAttributesResponse)
+	err := c.cc.Invoke(ctx, UserAttributeService_SetMemberAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAttributeServiceClient) SearchMembers(ctx context.Context, in *SearchMembersRequest, opts ...grpc.CallOption) (*SearchMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMembersResponse)
+	err := c.cc.Invoke(ctx, UserAttributeService_SearchMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserAttributeServiceServer is the server API for UserAttributeService service.
+// All implementations must embed UnimplementedUserAttributeServiceServer
+// for forward compatibility.
+//
+// UserAttributeService manages the custom attributes of the caller's org and their values per member. Values are
+// exposed to MFA policy evaluation as input.user.attributes. Org admins and owners only, except where noted.
+type UserAttributeServiceServer interface {
+	// ListAttributeDefinitions is open to every member of the org.
+	ListAttributeDefinitions(context.Context, *ListAttributeDefinitionsRequest) (*ListAttributeDefinitionsResponse, error)
+	// UpsertAttributeDefinition creates or updates a definition; FAILED_PRECONDITION when it would change the type of
+	// an existing attribute or exceed 50 attributes. Audited as attribute_definition_upserted.
+	UpsertAttributeDefinition(context.Context, *UpsertAttributeDefinitionRequest) (*UpsertAttributeDefinitionResponse, error)
+	// DeleteAttributeDefinition deletes a definition and every member's value for it; NOT_FOUND if there is none.
+	// Audited as attribute_definition_deleted.
+	DeleteAttributeDefinition(context.Context, *DeleteAttributeDefinitionRequest) (*DeleteAttributeDefinitionResponse, error)
+	// GetMemberAttributes returns a member's values. Members may read their own.
+	GetMemberAttributes(context.Context, *GetMemberAttributesRequest) (*GetMemberAttributesResponse, error)
+	// SetMemberAttributes sets and removes a member's values. Audited as member_attributes_updated.
+	SetMemberAttributes(context.Context, *SetMemberAttributesRequest) (*SetMemberAttributesResponse, error)
+	// SearchMembers returns the members whose values match every filter.
+	SearchMembers(context.Context, *SearchMembersRequest) (*SearchMembersResponse, error)
+	mustEmbedUnimplementedUserAttributeServiceServer()
+}
+
+// UnimplementedUserAttributeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserAttributeServiceServer struct{}
+
+func (UnimplementedUserAttributeServiceServer) ListAttributeDefinitions(context.Context, *ListAttributeDefinitionsRequest) (*ListAttributeDefinitionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAttributeDefinitions not implemented")
+}
+func (UnimplementedUserAttributeServiceServer) UpsertAttributeDefinition(context.Context, *UpsertAttributeDefinitionRequest) (*UpsertAttributeDefinitionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertAttributeDefinition not implemented")
+}
+func (UnimplementedUserAttributeServiceServer) DeleteAttributeDefinition(context.Context, *DeleteAttributeDefinitionRequest) (*DeleteAttributeDefinitionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAttributeDefinition not implemented")
+}
+func (UnimplementedUserAttributeServiceServer) GetMemberAttributes(context.Context, *GetMemberAttributesRequest) (*GetMemberAttributesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMemberAttributes not implemented")
+}
+func (UnimplementedUserAttributeServiceServer) SetMemberAttributes(context.Context, *SetMemberAttributesRequest) (*SetMemberAttributesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMemberAttributes not implemented")
+}
+func (UnimplementedUserAttributeServiceServer) SearchMembers(context.Context, *SearchMembersRequest) (*SearchMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchMembers not implemented")
+}
+func (UnimplementedUserAttributeServiceServer) mustEmbedUnimplementedUserAttributeServiceServer() {}
+func (UnimplementedUserAttributeServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeUserAttributeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserAttributeServiceServer will
+// result in compilation errors.
+type UnsafeUserAttributeServiceServer interface {
+	mustEmbedUnimplementedUserAttributeServiceServer()
+}
+
+func RegisterUserAttributeServiceServer(s grpc.ServiceRegistrar, srv UserAttributeServiceServer) {
+	// If the following call panics, it indicates UnimplementedUserAttributeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserAttributeService_ServiceDesc, srv)
+}
+
+func _UserAttributeService_ListAttributeDefinitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttributeDefinitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAttributeServiceServer).ListAttributeDefinitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAttributeService_ListAttributeDefinitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAttributeServiceServer).ListAttributeDefinitions(ctx, req.(*ListAttributeDefinitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAttributeService_UpsertAttributeDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertAttributeDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAttributeServiceServer).UpsertAttributeDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAttributeService_UpsertAttributeDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAttributeServiceServer).UpsertAttributeDefinition(ctx, req.(*UpsertAttributeDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAttributeService_DeleteAttributeDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttributeDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAttributeServiceServer).DeleteAttributeDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAttributeService_DeleteAttributeDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAttributeServiceServer).DeleteAttributeDefinition(ctx, req.(*DeleteAttributeDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAttributeService_GetMemberAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemberAttributesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAttributeServiceServer).GetMemberAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAttributeService_GetMemberAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAttributeServiceServer).GetMemberAttributes(ctx, req.(*GetMemberAttributesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAttributeService_SetMemberAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberAttributesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAttributeServiceServer).SetMemberAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAttributeService_SetMemberAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAttributeServiceServer).SetMemberAttributes(ctx, req.(*SetMemberAttributesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserAttributeService_SearchMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAttributeServiceServer).SearchMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserAttributeService_SearchMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAttributeServiceServer).SearchMembers(ctx, req.(*SearchMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserAttributeService_ServiceDesc is the grpc.ServiceDesc for UserAttributeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserAttributeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ztcp.userattribute.v1.UserAttributeService",
+	HandlerType: (*UserAttributeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAttributeDefinitions",
+			Handler:    _UserAttributeService_ListAttributeDefinitions_Handler,
+		},
+		{
+			MethodName: "UpsertAttributeDefinition",
+			Handler:    _UserAttributeService_UpsertAttributeDefinition_Handler,
+		},
+		{
+			MethodName: "DeleteAttributeDefinition",
+			Handler:    _UserAttributeService_DeleteAttributeDefinition_Handler,
+		},
+		{
+			MethodName: "GetMemberAttributes",
+			Handler:    _UserAttributeService_GetMemberAttributes_Handler,
+		},
+		{
+			MethodName: "SetMemberAttributes",
+			Handler:    _UserAttributeService_SetMemberAttributes_Handler,
+		},
+		{
+			MethodName: "SearchMembers",
+			Handler:    _UserAttributeService_SearchMembers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "userattribute/userattribute.proto",
+}
@@ -0,0 +1,63 @@
+// breachfilter builds the Bloom filter file used by BREACHED_PASSWORD_CHECK=bloom from a SHA-1 hash list such as
+// the Have I Been Pwned download ("HASH:COUNT" per line; bare hashes also work):
+// go run ./cmd/breachfilter -in pwned-passwords-sha1.txt -out breached.bloom -n 900000000
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"zero-trust-control-plane/backend/internal/breachedpassword"
+)
+
+func main() {
+	in := flag.String("in", "", "SHA-1 hash list, one hash per line (optionally followed by :count)")
+	out := flag.String("out", "", "output Bloom filter file")
+	n := flag.Uint64("n", 0, "expected number of hashes (sizes the filter)")
+	fp := flag.Float64("fp", 0.001, "target false-positive rate")
+	flag.Parse()
+	if *in == "" || *out == "" || *n == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("open %s: %v", *in, err)
+	}
+	defer src.Close()
+
+	filter := breachedpassword.NewBloomFilter(*n, *fp)
+	var added, skipped uint64
+	scanner := bufio.NewScanner(src)
+	for scanner.Scan() {
+		hash, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if err := filter.AddHash(hash); err != nil {
+			skipped++
+			continue
+		}
+		added++
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("read %s: %v", *in, err)
+	}
+
+	dst, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("create %s: %v", *out, err)
+	}
+	w := bufio.NewWriter(dst)
+	if _, err := filter.WriteTo(w); err != nil {
+		log.Fatalf("write %s: %v", *out, err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("write %s: %v", *out, err)
+	}
+	if err := dst.Close(); err != nil {
+		log.Fatalf("close %s: %v", *out, err)
+	}
+	log.Printf("breachfilter: wrote %s with %d hashes (%d lines skipped)", *out, added, skipped)
+}
@@ -0,0 +1,75 @@
+// detector scans the audit log for credential attacks (credential stuffing, distributed brute force), raises
+// security events for the targeted users, and optionally auto-blocks offending IPs. Run alongside cmd/server
+// against the same database: go run ./cmd/detector.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/db"
+	"zero-trust-control-plane/backend/internal/detector"
+	ipblockrepo "zero-trust-control-plane/backend/internal/ipblock/repository"
+	organizationrepo "zero-trust-control-plane/backend/internal/organization/repository"
+	"zero-trust-control-plane/backend/internal/residency"
+	securityeventrepo "zero-trust-control-plane/backend/internal/securityevent/repository"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+	if cfg.DatabaseURL == "" {
+		log.Fatal("DATABASE_URL is not set; create a .env from .env.example or set DATABASE_URL")
+	}
+	database, err := db.Open(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("db: %v", err)
+	}
+	defer database.Close()
+	// Orgs with a data region keep their audit logs and security events in that region's database, so every
+	// database is scanned and each event is raised where the org's data lives.
+	var dataRouter *residency.Router
+	if dsns := cfg.DataRegionDSNMap(); len(dsns) > 0 {
+		regional := make(map[string]*sql.DB, len(dsns))
+		for region, dsn := range dsns {
+			regionDB, err := db.Open(dsn)
+			if err != nil {
+				log.Fatalf("db (data region %s): %v", region, err)
+			}
+			defer regionDB.Close()
+			regional[region] = regionDB
+		}
+		dataRouter = residency.NewRouter(database, regional, organizationrepo.NewPostgresRepository(database))
+	}
+
+	rules := detector.Rules{
+		Window:             cfg.DetectorScanWindow(),
+		IPFailureThreshold: cfg.DetectorIPFailureThreshold,
+		IPAccountThreshold: cfg.DetectorIPAccountThreshold,
+		AccountIPThreshold: cfg.DetectorAccountIPThreshold,
+		AutoBlock:          cfg.DetectorAutoBlock,
+		BlockDuration:      cfg.DetectorIPBlockDuration(),
+	}
+	d := detector.New(
+		detector.NewPostgresSource(database, dataRouter),
+		securityeventrepo.NewPostgresRepository(database, dataRouter),
+		ipblockrepo.NewPostgresRepository(database),
+		rules,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	interval := cfg.DetectorScanInterval()
+	log.Printf("detector running: interval=%s window=%s ip_failures>=%d ip_accounts>=%d account_ips>=%d auto_block=%t",
+		interval, rules.Window, rules.IPFailureThreshold, rules.IPAccountThreshold, rules.AccountIPThreshold, rules.AutoBlock)
+	d.Run(ctx, interval)
+	log.Println("detector stopped")
+}
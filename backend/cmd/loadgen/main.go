@@ -0,0 +1,98 @@
+// loadgen drives a Login/Refresh/VerifyMFA mix against a running server and prints per-call latency and
+// throughput. Seed the users first (go run ./cmd/seed -loadtest-orgs 5 -loadtest-users 200), then e.g.:
+// go run ./cmd/loadgen -addr localhost:8080 -orgs 5 -users 200 -concurrency 50 -duration 1m -json report.json
+// With -baseline, exits non-zero when a call regressed against an earlier -json report by more than -tolerance.
+// verify_mfa needs the server in dev OTP mode (OTP_RETURN_TO_CLIENT=true) to read the codes.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	devv1 "zero-trust-control-plane/backend/api/generated/dev/v1"
+	"zero-trust-control-plane/backend/internal/loadgen"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "server gRPC address")
+	concurrency := flag.Int("concurrency", 20, "concurrent workers")
+	duration := flag.Duration("duration", 0, "run for this long (default 30s unless -requests is set)")
+	requests := flag.Int64("requests", 0, "stop after this many iterations (0 = no limit)")
+	mix := flag.String("mix", loadgen.DefaultMix, "relative weights of login, refresh and verify_mfa")
+	orgs := flag.Int("orgs", 1, "load-test orgs to use (at most -loadtest-orgs of the seed)")
+	users := flag.Int("users", 50, "load-test users per org to use (at most -loadtest-users of the seed)")
+	password := flag.String("password", loadgen.Password, "password of the load-test users")
+	callTimeout := flag.Duration("timeout", loadgen.DefaultCallTimeout, "timeout of each call")
+	jsonOut := flag.String("json", "", "also write the report as JSON to this file")
+	baselinePath := flag.String("baseline", "", "JSON report of an earlier run to compare against")
+	tolerance := flag.Float64("tolerance", 0.2, "allowed regression against -baseline (0.2 = 20%)")
+	flag.Parse()
+
+	m, err := loadgen.ParseMix(*mix)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *duration == 0 && *requests == 0 {
+		*duration = 30 * time.Second
+	}
+	var baseline *loadgen.Report
+	if *baselinePath != "" {
+		if baseline, err = loadgen.ReadReportFile(*baselinePath); err != nil {
+			log.Fatalf("baseline: %v", err)
+		}
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("dial %s: %v", *addr, err)
+	}
+	defer conn.Close()
+	var otp loadgen.OTPSource
+	if m[loadgen.OpVerifyMFA] > 0 {
+		otp = devv1.NewDevServiceClient(conn)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("loadgen: %s, concurrency %d, mix %s, %d orgs x %d users", *addr, *concurrency, m, *orgs, *users)
+	report, err := loadgen.Run(ctx, authv1.NewAuthServiceClient(conn), otp, loadgen.Options{
+		Concurrency: *concurrency,
+		Duration:    *duration,
+		Requests:    *requests,
+		Mix:         m,
+		Orgs:        *orgs,
+		Users:       *users,
+		Password:    *password,
+		CallTimeout: *callTimeout,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := report.WriteText(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+	if *jsonOut != "" {
+		if err := report.WriteJSONFile(*jsonOut); err != nil {
+			log.Fatalf("write %s: %v", *jsonOut, err)
+		}
+	}
+	if baseline != nil {
+		if regressions := report.Regressions(baseline, *tolerance); len(regressions) > 0 {
+			for _, r := range regressions {
+				log.Printf("regression: %s", r)
+			}
+			os.Exit(1)
+		}
+		log.Printf("loadgen: no regressions against %s (tolerance %.0f%%)", *baselinePath, *tolerance*100)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/loadgen"
+)
+
+// seedLoadTest inserts the load-test scenario used by cmd/loadgen: orgs orgs with users members each, every user
+// with a phone and one trusted device, and org MFA settings that require MFA on new devices. Names come from
+// internal/loadgen. Idempotent per org and per user, so a run with larger counts adds the missing ones.
+func seedLoadTest(ctx context.Context, queries *gen.Queries, passwordHash string, orgs, users int) error {
+	now := time.Now().UTC()
+	trustedUntil := now.AddDate(1, 0, 0)
+	var createdOrgs, createdUsers int
+	for o := 0; o < orgs; o++ {
+		orgID := loadgen.OrgID(o)
+		_, err := queries.GetOrganization(ctx, orgID)
+		if errors.Is(err, sql.ErrNoRows) {
+			if err := seedLoadTestOrg(ctx, queries, orgID, now); err != nil {
+				return err
+			}
+			createdOrgs++
+		} else if err != nil {
+			return fmt.Errorf("check org %s: %w", orgID, err)
+		}
+		for u := 0; u < users; u++ {
+			email := loadgen.UserEmail(o, u)
+			_, err := queries.GetUserByEmail(ctx, gen.GetUserByEmailParams{Email: email})
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("check user %s: %w", email, err)
+			}
+			if err := seedLoadTestUser(ctx, queries, passwordHash, o, u, now, trustedUntil); err != nil {
+				return err
+			}
+			createdUsers++
+		}
+	}
+	log.Printf("Load-test seed: %d orgs and %d users created (%d orgs x %d users requested).", createdOrgs, createdUsers, orgs, users)
+	return nil
+}
+
+func seedLoadTestOrg(ctx context.Context, queries *gen.Queries, orgID string, now time.Time) error {
+	if _, err := queries.CreateOrganization(ctx, gen.CreateOrganizationParams{
+		ID:        orgID,
+		Name:      "Load Test " + orgID,
+		Status:    gen.OrgStatusActive,
+		CreatedAt: now,
+	}); err != nil {
+		return fmt.Errorf("create org %s: %w", orgID, err)
+	}
+	if _, err := queries.UpsertOrgMFASettings(ctx, gen.UpsertOrgMFASettingsParams{
+		OrgID:                   orgID,
+		MfaRequiredForNewDevice: true,
+		MfaRequiredForUntrusted: true,
+		MfaRequiredAlways:       false,
+		RegisterTrustAfterMfa:   true,
+		TrustTtlDays:            30,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}); err != nil {
+		return fmt.Errorf("upsert org mfa settings %s: %w", orgID, err)
+	}
+	if _, err := queries.CreatePolicy(ctx, gen.CreatePolicyParams{
+		ID:        "loadtest-policy-" + orgID,
+		OrgID:     orgID,
+		Rules:     defaultRegoPolicy,
+		Enabled:   true,
+		CreatedAt: now,
+	}); err != nil {
+		return fmt.Errorf("create policy %s: %w", orgID, err)
+	}
+	return nil
+}
+
+func seedLoadTestUser(ctx context.Context, queries *gen.Queries, passwordHash string, org, user int, now, trustedUntil time.Time) error {
+	userID := loadgen.UserID(org, user)
+	email := loadgen.UserEmail(org, user)
+	if _, err := queries.CreateUser(ctx, gen.CreateUserParams{
+		ID:            userID,
+		Email:         email,
+		Name:          sql.NullString{String: fmt.Sprintf("Load Test User %d", user), Valid: true},
+		Phone:         sql.NullString{String: loadgen.UserPhone(org, user), Valid: true},
+		PhoneVerified: true,
+		Status:        gen.UserStatusActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}); err != nil {
+		return fmt.Errorf("create user %s: %w", email, err)
+	}
+	if _, err := queries.CreateIdentity(ctx, gen.CreateIdentityParams{
+		ID:           "loadtest-identity-" + userID,
+		UserID:       userID,
+		Provider:     gen.IdentityProviderLocal,
+		ProviderID:   email,
+		PasswordHash: sql.NullString{String: passwordHash, Valid: true},
+		CreatedAt:    now,
+	}); err != nil {
+		return fmt.Errorf("create identity %s: %w", email, err)
+	}
+	role := gen.RoleMember
+	if user == 0 {
+		role = gen.RoleOwner
+	}
+	if _, err := queries.CreateMembership(ctx, gen.CreateMembershipParams{
+		ID:        "loadtest-membership-" + userID,
+		UserID:    userID,
+		OrgID:     loadgen.OrgID(org),
+		Role:      role,
+		CreatedAt: now,
+	}); err != nil {
+		return fmt.Errorf("create membership %s: %w", email, err)
+	}
+	if _, err := queries.CreateDevice(ctx, gen.CreateDeviceParams{
+		ID:           "loadtest-device-" + userID,
+		UserID:       userID,
+		OrgID:        loadgen.OrgID(org),
+		Fingerprint:  loadgen.TrustedFingerprint(org, user),
+		Trusted:      true,
+		TrustedUntil: sql.NullTime{Time: trustedUntil, Valid: true},
+		RevokedAt:    sql.NullTime{},
+		LastSeenAt:   sql.NullTime{Time: now, Valid: true},
+		CreatedAt:    now,
+	}); err != nil {
+		return fmt.Errorf("create device %s: %w", email, err)
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 // seed inserts development sample data for local testing. Run via ./scripts/seed.sh.
 // Idempotent: skips inserts if the dev user (dev@example.com) already exists.
+// With -loadtest-orgs and -loadtest-users it instead inserts the load-test scenario used by cmd/loadgen.
 package main
 
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -13,6 +15,7 @@ import (
 	"zero-trust-control-plane/backend/internal/config"
 	"zero-trust-control-plane/backend/internal/db"
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/loadgen"
 	"zero-trust-control-plane/backend/internal/security"
 )
 
@@ -69,6 +72,10 @@ const (
 )
 
 func main() {
+	loadTestOrgs := flag.Int("loadtest-orgs", 0, "seed this many load-test orgs instead of the dev data")
+	loadTestUsers := flag.Int("loadtest-users", 100, "load-test users per org (with -loadtest-orgs)")
+	flag.Parse()
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("config: %v", err)
@@ -86,7 +93,19 @@ func main() {
 	queries := gen.New(conn)
 	ctx := context.Background()
 
-	_, err = queries.GetUserByEmail(ctx, devUserEmail)
+	if *loadTestOrgs > 0 {
+		hash, err := security.NewHasher(cfg.BcryptCost).Hash([]byte(loadgen.Password))
+		if err != nil {
+			log.Fatalf("hash password: %v", err)
+		}
+		if err := seedLoadTest(ctx, queries, hash, *loadTestOrgs, *loadTestUsers); err != nil {
+			log.Fatalf("load-test seed: %v", err)
+		}
+		fmt.Printf("Load-test users: loadtest-OOO-UUUU@example.com / %s\n", loadgen.Password)
+		return
+	}
+
+	_, err = queries.GetUserByEmail(ctx, gen.GetUserByEmailParams{Email: devUserEmail})
 	if err == nil {
 		log.Println("Seed already applied (dev@example.com exists). Skipping.")
 		os.Exit(0)
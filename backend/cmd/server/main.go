@@ -2,43 +2,150 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log"
+	"log/slog"
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // org access_schedule timezones on images without zoneinfo (alpine)
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	adminv1 "zero-trust-control-plane/backend/api/generated/admin/v1"
+	agentv1 "zero-trust-control-plane/backend/api/generated/agent/v1"
+	auditwebhookv1 "zero-trust-control-plane/backend/api/generated/auditwebhook/v1"
 	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	breakglassv1 "zero-trust-control-plane/backend/api/generated/breakglass/v1"
+	changerequestv1 "zero-trust-control-plane/backend/api/generated/changerequest/v1"
 	devv1 "zero-trust-control-plane/backend/api/generated/dev/v1"
+	elevationv1 "zero-trust-control-plane/backend/api/generated/elevation/v1"
+	featureflagv1 "zero-trust-control-plane/backend/api/generated/featureflag/v1"
 	healthv1 "zero-trust-control-plane/backend/api/generated/health/v1"
+	invitationv1 "zero-trust-control-plane/backend/api/generated/invitation/v1"
+	notificationv1 "zero-trust-control-plane/backend/api/generated/notification/v1"
 	organizationv1 "zero-trust-control-plane/backend/api/generated/organization/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	platformsettingsv1 "zero-trust-control-plane/backend/api/generated/platformsettings/v1"
+	policypresetv1 "zero-trust-control-plane/backend/api/generated/policypreset/v1"
+	telemetryv1 "zero-trust-control-plane/backend/api/generated/telemetry/v1"
+	urlexceptionv1 "zero-trust-control-plane/backend/api/generated/urlexception/v1"
+	userv1 "zero-trust-control-plane/backend/api/generated/user/v1"
+	userattributev1 "zero-trust-control-plane/backend/api/generated/userattribute/v1"
+	"zero-trust-control-plane/backend/internal/agent"
+	agentrepo "zero-trust-control-plane/backend/internal/agent/repository"
+	"zero-trust-control-plane/backend/internal/analytics"
+	analyticsrepo "zero-trust-control-plane/backend/internal/analytics/repository"
 	"zero-trust-control-plane/backend/internal/audit"
 	auditrepo "zero-trust-control-plane/backend/internal/audit/repository"
+	"zero-trust-control-plane/backend/internal/auditwebhook"
+	auditwebhookrepo "zero-trust-control-plane/backend/internal/auditwebhook/repository"
+	"zero-trust-control-plane/backend/internal/backup"
+	backuprepo "zero-trust-control-plane/backend/internal/backup/repository"
+	"zero-trust-control-plane/backend/internal/billing"
+	"zero-trust-control-plane/backend/internal/breakglass"
+	breakglassrepo "zero-trust-control-plane/backend/internal/breakglass/repository"
+	"zero-trust-control-plane/backend/internal/breachedpassword"
+	"zero-trust-control-plane/backend/internal/captcha"
+	"zero-trust-control-plane/backend/internal/changerequest"
+	changerequestrepo "zero-trust-control-plane/backend/internal/changerequest/repository"
 	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/dataexport"
+	dataexportrepo "zero-trust-control-plane/backend/internal/dataexport/repository"
 	"zero-trust-control-plane/backend/internal/db"
+	"zero-trust-control-plane/backend/internal/db/migrate"
 	devicerepo "zero-trust-control-plane/backend/internal/device/repository"
+	devicecoderepo "zero-trust-control-plane/backend/internal/devicecode/repository"
+	"zero-trust-control-plane/backend/internal/devicesharing"
+	devicesharingrepo "zero-trust-control-plane/backend/internal/devicesharing/repository"
 	"zero-trust-control-plane/backend/internal/devotp"
 	devotphandler "zero-trust-control-plane/backend/internal/devotp/handler"
+	"zero-trust-control-plane/backend/internal/elevation"
+	elevationrepo "zero-trust-control-plane/backend/internal/elevation/repository"
+	"zero-trust-control-plane/backend/internal/emailchange"
+	emailchangerepo "zero-trust-control-plane/backend/internal/emailchange/repository"
+	"zero-trust-control-plane/backend/internal/featureflag"
+	featureflagrepo "zero-trust-control-plane/backend/internal/featureflag/repository"
+	grouprepo "zero-trust-control-plane/backend/internal/group/repository"
+	"zero-trust-control-plane/backend/internal/honeytoken"
+	honeytokenrepo "zero-trust-control-plane/backend/internal/honeytoken/repository"
 	identityrepo "zero-trust-control-plane/backend/internal/identity/repository"
 	identityservice "zero-trust-control-plane/backend/internal/identity/service"
+	invitationrepo "zero-trust-control-plane/backend/internal/invitation/repository"
+	ipblockrepo "zero-trust-control-plane/backend/internal/ipblock/repository"
+	"zero-trust-control-plane/backend/internal/license"
+	"zero-trust-control-plane/backend/internal/loginhold"
+	loginholdrepo "zero-trust-control-plane/backend/internal/loginhold/repository"
+	"zero-trust-control-plane/backend/internal/magiclink"
+	magiclinkrepo "zero-trust-control-plane/backend/internal/magiclink/repository"
+	"zero-trust-control-plane/backend/internal/maintenance"
 	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	"zero-trust-control-plane/backend/internal/metering"
+	meteringrepo "zero-trust-control-plane/backend/internal/metering/repository"
 	mfarepo "zero-trust-control-plane/backend/internal/mfa/repository"
 	"zero-trust-control-plane/backend/internal/mfa/sms"
 	mfaintentrepo "zero-trust-control-plane/backend/internal/mfaintent/repository"
+	mfarecoveryrepo "zero-trust-control-plane/backend/internal/mfarecovery/repository"
+	"zero-trust-control-plane/backend/internal/notification"
+	"zero-trust-control-plane/backend/internal/notification/email"
+	notificationrepo "zero-trust-control-plane/backend/internal/notification/repository"
+	"zero-trust-control-plane/backend/internal/notiftemplate"
+	notiftemplaterepo "zero-trust-control-plane/backend/internal/notiftemplate/repository"
 	organizationrepo "zero-trust-control-plane/backend/internal/organization/repository"
+	orgdiscoveryrepo "zero-trust-control-plane/backend/internal/orgdiscovery/repository"
+	"zero-trust-control-plane/backend/internal/orgdomain"
+	orgdomainrepo "zero-trust-control-plane/backend/internal/orgdomain/repository"
+	"zero-trust-control-plane/backend/internal/orgsetup"
+	orgsetuprepo "zero-trust-control-plane/backend/internal/orgsetup/repository"
+	"zero-trust-control-plane/backend/internal/orgsmtp"
+	orgsmtprepo "zero-trust-control-plane/backend/internal/orgsmtp/repository"
 	orgmfasettingsrepo "zero-trust-control-plane/backend/internal/orgmfasettings/repository"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig"
+	orgpolicyconfighandler "zero-trust-control-plane/backend/internal/orgpolicyconfig/handler"
 	orgpolicyconfigrepo "zero-trust-control-plane/backend/internal/orgpolicyconfig/repository"
+	"zero-trust-control-plane/backend/internal/pii"
+	piirepo "zero-trust-control-plane/backend/internal/pii/repository"
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+	"zero-trust-control-plane/backend/internal/platform/confirm"
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+	"zero-trust-control-plane/backend/internal/platform/ratelimit"
+	"zero-trust-control-plane/backend/internal/platform/replay"
 	platformsettingsrepo "zero-trust-control-plane/backend/internal/platformsettings/repository"
 	policyengine "zero-trust-control-plane/backend/internal/policy/engine"
 	policyrepo "zero-trust-control-plane/backend/internal/policy/repository"
+	"zero-trust-control-plane/backend/internal/policypreset"
+	policypresetrepo "zero-trust-control-plane/backend/internal/policypreset/repository"
+	policyviolationrepo "zero-trust-control-plane/backend/internal/policyviolation/repository"
+	"zero-trust-control-plane/backend/internal/quota"
+	quotarepo "zero-trust-control-plane/backend/internal/quota/repository"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/secrets"
+	"zero-trust-control-plane/backend/internal/securityevent"
+	securityeventrepo "zero-trust-control-plane/backend/internal/securityevent/repository"
 	"zero-trust-control-plane/backend/internal/security"
 	"zero-trust-control-plane/backend/internal/server"
 	"zero-trust-control-plane/backend/internal/server/interceptors"
+	"zero-trust-control-plane/backend/internal/session"
+	"zero-trust-control-plane/backend/internal/session/replication"
 	sessionrepo "zero-trust-control-plane/backend/internal/session/repository"
+	"zero-trust-control-plane/backend/internal/settingscache"
+	"zero-trust-control-plane/backend/internal/signupguard"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/internal/telemetry/embedded"
+	telemetrytransports "zero-trust-control-plane/backend/internal/telemetry/transports"
+	urlexceptionrepo "zero-trust-control-plane/backend/internal/urlexception/repository"
 	userrepo "zero-trust-control-plane/backend/internal/user/repository"
+	userattributerepo "zero-trust-control-plane/backend/internal/userattribute/repository"
+	"zero-trust-control-plane/backend/internal/usermerge"
+	"zero-trust-control-plane/backend/internal/username"
+	usernamerepo "zero-trust-control-plane/backend/internal/username/repository"
+	usermergerepo "zero-trust-control-plane/backend/internal/usermerge/repository"
 )
 
 func main() {
@@ -46,6 +153,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("config: %v", err)
 	}
+	// The watcher keeps its own copy taken before secrets are resolved into cfg, so a reload compares like with like.
+	cfgWatcher := config.NewWatcher(cfg)
+	slog.SetLogLoggerLevel(cfg.SlogLevel())
 
 	lis, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
@@ -55,7 +165,52 @@ func main() {
 
 	var s *grpc.Server
 	var tokens *security.TokenProvider
-	deps := server.Deps{}
+	// revocationFreshness is set for SESSION_REVOCATION_CONSISTENCY=strict.
+	var revocationFreshness *replication.Freshness
+	deps := server.Deps{ConfigWatcher: cfgWatcher, Confirmations: confirm.NewSigner(cfg.ConfirmationTokenSecret)}
+	if cfg.ConfirmationTokenSecret == "" {
+		log.Print("confirm: CONFIRMATION_TOKEN_SECRET is empty; confirmation tokens are only valid on the instance that issued them")
+	}
+	// jobsCtx stops background jobs (e.g. analytics rollups) on shutdown.
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	// auditWriter is set when AUDIT_BUFFER_SIZE > 0.
+	var auditWriter *audit.BufferedWriter
+	// telemetryRecorder is set when TELEMETRY_TRANSPORT=embedded.
+	var telemetryRecorder *embedded.Recorder
+	// usageMeter is set when usage metering is enabled (METERING_FLUSH_INTERVAL > 0).
+	var usageMeter *metering.Meter
+
+	// Secrets referenced by *_SECRET are read from the secrets provider instead of the environment and re-fetched
+	// every SECRETS_REFRESH_INTERVAL, so rotated JWT keys and SMS API keys apply without a redeploy.
+	var secretStore *secrets.Store
+	if cfg.SecretsProvider != "" {
+		secretStore = secrets.NewStore(secrets.NewProvider(cfg.SecretsSettings()))
+		for _, ref := range []struct {
+			name string
+			ref  string
+			dst  *string
+		}{
+			{"JWT_PRIVATE_KEY_SECRET", cfg.JWTPrivateKeySecret, &cfg.JWTPrivateKey},
+			{"JWT_PUBLIC_KEY_SECRET", cfg.JWTPublicKeySecret, &cfg.JWTPublicKey},
+			{"SMS_LOCAL_API_KEY_SECRET", cfg.SMSLocalAPIKeySecret, &cfg.SMSLocalAPIKey},
+			{"PII_MASTER_KEY_SECRET", cfg.PIIMasterKeySecret, &cfg.PIIMasterKey},
+			{"BACKUP_ENCRYPTION_KEY_SECRET", cfg.BackupEncryptionKeySecret, &cfg.BackupEncryptionKey},
+		} {
+			if ref.ref == "" {
+				continue
+			}
+			v, err := secretStore.Get(jobsCtx, ref.ref)
+			if err != nil {
+				log.Fatalf("secrets: %s: %v", ref.name, err)
+			}
+			*ref.dst = v
+		}
+		if interval := cfg.SecretsRefreshEvery(); interval > 0 {
+			go secretStore.Run(jobsCtx, interval)
+		}
+		log.Printf("secrets: loaded from %s", cfg.SecretsProvider)
+	}
 
 	authEnabled := cfg.DatabaseURL != "" && cfg.JWTPrivateKey != "" && cfg.JWTPublicKey != ""
 	if !authEnabled {
@@ -74,7 +229,9 @@ func main() {
 		log.Print("auth enabled: DATABASE_URL and JWT keys set; Register/Login/Refresh available")
 	}
 	if authEnabled {
-		database, err := db.Open(cfg.DatabaseURL)
+		// DB_QUERY_TIMEOUT bounds every statement; DB_STATEMENT_TIMEOUT makes Postgres cancel what the client cannot.
+		dbOptions := db.Options{QueryTimeout: cfg.DBQueryTimeoutDuration(), StatementTimeout: cfg.DBStatementTimeoutDuration()}
+		database, err := db.OpenWithOptions(cfg.DatabaseURL, dbOptions)
 		if err != nil {
 			log.Fatalf("db: %v", err)
 		}
@@ -90,41 +247,352 @@ func main() {
 			log.Fatalf("jwt public key: %v", err)
 		}
 		tokens = security.NewTokenProvider(signer, pub, cfg.JWTIssuer, cfg.JWTAudience, cfg.AccessTTL(), cfg.RefreshTTL())
+		tokens.SetClockLeeway(cfg.ClockLeeway())
+		if secretStore != nil && (cfg.JWTPrivateKeySecret != "" || cfg.JWTPublicKeySecret != "") {
+			rotateJWTKeys := func(string) {
+				privateKey, publicKey := cfg.JWTPrivateKey, cfg.JWTPublicKey
+				if cfg.JWTPrivateKeySecret != "" {
+					privateKey, _ = secretStore.Get(jobsCtx, cfg.JWTPrivateKeySecret)
+				}
+				if cfg.JWTPublicKeySecret != "" {
+					publicKey, _ = secretStore.Get(jobsCtx, cfg.JWTPublicKeySecret)
+				}
+				signer, pub, err := security.ParseKeyPair(privateKey, publicKey)
+				if err != nil {
+					log.Printf("secrets: JWT key rotation skipped: %v", err)
+					return
+				}
+				if tokens.SetKeys(signer, pub) {
+					log.Print("secrets: JWT signing keys rotated")
+				}
+			}
+			for _, ref := range []string{cfg.JWTPrivateKeySecret, cfg.JWTPublicKeySecret} {
+				if ref != "" {
+					secretStore.Watch(ref, rotateJWTKeys)
+				}
+			}
+		}
 
-		userRepo := userrepo.NewPostgresRepository(database)
+		// piiKeyring encrypts user emails and phones when PII_MASTER_KEY is set; nil stores them in plaintext.
+		var piiKeyring *pii.Keyring
+		if cfg.PIIEncryptionEnabled() {
+			master, err := pii.ParseMasterKey(cfg.PIIMasterKey)
+			if err != nil {
+				log.Fatalf("PII_MASTER_KEY: %v", err)
+			}
+			var previous [][]byte
+			if cfg.PIIPreviousMasterKey != "" {
+				key, err := pii.ParseMasterKey(cfg.PIIPreviousMasterKey)
+				if err != nil {
+					log.Fatalf("PII_PREVIOUS_MASTER_KEY: %v", err)
+				}
+				previous = append(previous, key)
+			}
+			piiKeyring, err = pii.NewKeyring(piirepo.NewPostgresRepository(database), master, previous...)
+			if err != nil {
+				log.Fatalf("pii: %v", err)
+			}
+			if secretStore != nil && cfg.PIIMasterKeySecret != "" {
+				secretStore.Watch(cfg.PIIMasterKeySecret, func(value string) {
+					key, err := pii.ParseMasterKey(value)
+					if err == nil {
+						err = piiKeyring.SetMasterKey(key)
+					}
+					if err != nil {
+						log.Printf("secrets: PII master key rotation skipped: %v", err)
+						return
+					}
+					log.Printf("secrets: PII master key rotated to %s", pii.MasterKeyID(key))
+				})
+			}
+			log.Printf("pii: encrypting emails and phones with master key %s", pii.MasterKeyID(master))
+		}
+		userRepo := userrepo.NewPostgresRepository(database, piiKeyring)
+		// LICENSE_FILE limits the users and features of a self-hosted deployment; without it nothing is enforced.
+		licenseManager := license.NewManager("", nil, userRepo)
+		if cfg.LicenseFile != "" {
+			keyPEM := license.PublicKey
+			if keyPEM == "" {
+				keyPEM = cfg.LicensePublicKey
+			}
+			licenseKey, err := security.ParsePublicKey(keyPEM)
+			if err != nil {
+				log.Fatalf("license public key (embedded or LICENSE_PUBLIC_KEY): %v", err)
+			}
+			licenseManager = license.NewManager(cfg.LicenseFile, licenseKey, userRepo)
+			if err := licenseManager.Load(); err != nil {
+				log.Fatalf("license: %v", err)
+			}
+			l := licenseManager.License()
+			log.Printf("license: %s for %s, %d seats (0 = unlimited), expires %s, %s", l.ID, l.Customer, l.Seats, l.ExpiresAt.Format("2006-01-02"), licenseManager.State())
+		}
 		identityRepo := identityrepo.NewPostgresRepository(database)
-		sessionRepo := sessionrepo.NewPostgresRepository(database)
+		sessionRepo := sessionrepo.NewPostgresRepository(database, piiKeyring)
+		// sessions revokes through the replication stream in multi-region deployments; reads go to sessionRepo.
+		var sessions sessionrepo.Repository = sessionRepo
+		if consistency := replication.Consistency(cfg.SessionRevocationConsistency); consistency != replication.ConsistencyLocal {
+			brokers := cfg.SessionRevocationKafkaBrokerList()
+			revocationPublisher := replication.NewKafkaPublisher(brokers, cfg.SessionRevocationKafkaTopic)
+			sessions = replication.NewPublishingRepository(sessionRepo, revocationPublisher, cfg.Region)
+			applier := replication.NewApplier(sessionRepo, cfg.Region, cfg.RefreshTTL())
+			go replication.NewKafkaConsumer(brokers, cfg.SessionRevocationKafkaTopic, cfg.SessionRevocationConsumerGroup(), applier).Run(jobsCtx)
+			go replication.RunHeartbeat(jobsCtx, revocationPublisher, cfg.Region, cfg.SessionRevocationHeartbeatEvery())
+			if consistency == replication.ConsistencyStrict {
+				revocationFreshness = replication.NewFreshness(sessionRepo, cfg.SessionRevocationStaleAfter())
+			}
+			log.Printf("session revocation replication: %s consistency, region %s, topic %s", consistency, cfg.Region, cfg.SessionRevocationKafkaTopic)
+		}
 		deviceRepo := devicerepo.NewPostgresRepository(database)
-		membershipRepo := membershiprepo.NewPostgresRepository(database)
+		elevationRepo := elevationrepo.NewPostgresRepository(database)
+		// Members with an active admin elevation are admins to every RBAC check until it ends.
+		membershipStore := membershiprepo.NewPostgresRepository(database)
+		membershipRepo := elevation.NewMembershipRepository(membershipStore, elevationRepo)
+		groupRepo := grouprepo.NewPostgresRepository(database)
+		userAttributeRepo := userattributerepo.NewPostgresRepository(database)
 		orgRepo := organizationrepo.NewPostgresRepository(database)
-		platformSettingsRepo := platformsettingsrepo.NewPostgresRepository(database)
-		orgMFASettingsRepo := orgmfasettingsrepo.NewPostgresRepository(database)
+		// DATA_REGION_DSNS routes the org activity data (audit logs, policy violations, security events, telemetry,
+		// analytics and usage) of orgs with a data region to that region's database; orgs in a region without a DSN
+		// are rejected rather than stored in DATABASE_URL.
+		var dataRouter *residency.Router
+		if dsns := cfg.DataRegionDSNMap(); len(dsns) > 0 {
+			regional := make(map[string]*sql.DB, len(dsns))
+			for region, dsn := range dsns {
+				regionDB, err := db.OpenWithOptions(dsn, dbOptions)
+				if err != nil {
+					log.Fatalf("db (data region %s): %v", region, err)
+				}
+				defer regionDB.Close()
+				regional[region] = regionDB
+			}
+			dataRouter = residency.NewRouter(database, regional, orgRepo)
+			log.Printf("data residency enabled: regions %s", strings.Join(dataRouter.Regions(), ", "))
+		}
+		var platformSettingsRepo platformsettingsrepo.Repository = platformsettingsrepo.NewPostgresRepository(database)
+		var orgMFASettingsRepo orgmfasettingsrepo.Repository = orgmfasettingsrepo.NewPostgresRepository(database)
+		if ttl := cfg.SettingsCacheDuration(); ttl > 0 {
+			settingsCache := settingscache.New(orgMFASettingsRepo, platformSettingsRepo, settingscache.NewPGBroadcaster(database), ttl)
+			platformSettingsRepo = settingsCache.PlatformSettings()
+			orgMFASettingsRepo = settingsCache.OrgMFASettings()
+			// A SQLite database has one server, whose cache invalidates itself; there is no NOTIFY to listen for.
+			if !db.IsSQLite(cfg.DatabaseURL) {
+				go settingscache.Listen(jobsCtx, cfg.DatabaseURL, settingsCache)
+			}
+		}
 		orgPolicyConfigRepo := orgpolicyconfigrepo.NewPostgresRepository(database)
-		mfaChallengeRepo := mfarepo.NewPostgresRepository(database)
+		tokens.SetClaimsProviders(orgpolicyconfig.NewTokenClaimsProvider(orgPolicyConfigRepo), elevation.NewClaimsProvider(elevationRepo))
+		mfaChallengeRepo := mfarepo.NewPostgresRepository(database, piiKeyring)
 		mfaIntentRepo := mfaintentrepo.NewPostgresRepository(database)
 		policyRepo := policyrepo.NewPostgresRepository(database)
 		policyEvaluator := policyengine.NewOPAEvaluator(policyRepo)
+		// Circuit breakers fail calls to a dependency that keeps failing at once: SMS is queued for retry and MFA
+		// evaluation falls back per POLICY_FAILURE_MODE instead of every sign-in waiting for a timeout.
+		breakers := breaker.NewRegistry(breaker.Config{FailureThreshold: cfg.BreakerFailureThreshold, OpenTimeout: cfg.BreakerOpenDuration()})
+		deps.Breakers = breakers
+		policyEvaluator.SetBreaker(breakers.Get("policy"), policyengine.FailureMode(cfg.PolicyFailureMode))
+		mfaChallengeTTL := 10 * time.Minute
 		defaultTrustTTLDays := cfg.DefaultTrustTTLDays
 		if defaultTrustTTLDays <= 0 {
 			defaultTrustTTLDays = 30
 		}
 		var smsSender identityservice.OTPSender
+		var alertSMSSender notification.SMSSender
 		if cfg.SMSLocalAPIKey != "" {
-			smsSender = sms.NewSMSLocalClient(cfg.SMSLocalAPIKey, cfg.SMSLocalBaseURL, cfg.SMSLocalSender)
+			smsClient := sms.NewSMSLocalClient(cfg.SMSLocalAPIKey, cfg.SMSLocalBaseURL, cfg.SMSLocalSender)
+			if secretStore != nil && cfg.SMSLocalAPIKeySecret != "" {
+				secretStore.Watch(cfg.SMSLocalAPIKeySecret, smsClient.SetAPIKey)
+			}
+			var provider sms.Sender = smsClient
+			providerBreaker := breakers.Get("sms")
+			if cfg.SMSLocalFailoverAPIKey != "" {
+				// Each account has its own breaker; the failover sender checks them, so the retry sender needs none.
+				failoverURL := cfg.SMSLocalFailoverBaseURL
+				if failoverURL == "" {
+					failoverURL = cfg.SMSLocalBaseURL
+				}
+				secondary := sms.NewSMSLocalClient(cfg.SMSLocalFailoverAPIKey, failoverURL, cfg.SMSLocalFailoverSender)
+				provider = sms.NewFailoverSender(smsClient, providerBreaker, secondary, breakers.Get("sms_failover"))
+				providerBreaker = nil
+			}
+			// Queued OTPs are useless once their challenge has expired, so they are retried for its lifetime only.
+			smsRetry := sms.NewRetrySender(provider, providerBreaker, sms.RetryOptions{
+				QueueSize:   cfg.SMSRetryQueueSize,
+				MaxAge:      mfaChallengeTTL,
+				MaxAttempts: cfg.SMSRetryMaxAttempts,
+			})
+			go smsRetry.Run(jobsCtx, sms.DefaultRetryInterval)
+			deps.SMSRetryQueue = smsRetry
+			smsSender = smsRetry
+			alertSMSSender = smsRetry
+		}
+		// Emails to an org's users go through the org's own SMTP server when it has one, through SMTP_HOST otherwise.
+		// Org servers are only used while SMTP_HOST is set, as the emails themselves require it.
+		var emailSender notification.EmailSender
+		var platformEmail notification.EmailSender
+		if cfg.SMTPHost != "" {
+			platformEmail = email.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+		}
+		var smtpSecrets orgsmtp.SecretGetter
+		if secretStore != nil {
+			smtpSecrets = secretStore
 		}
+		deps.OrgSMTP = orgsmtp.NewRouter(orgsmtprepo.NewPostgresRepository(database), platformEmail, smtpSecrets, cfg.OrgSMTPSecretPrefix)
+		if platformEmail != nil {
+			emailSender = deps.OrgSMTP
+		}
+		securityEventRepo := securityeventrepo.NewPostgresRepository(database, dataRouter)
+		securityEvents := securityevent.NewRecorder(securityEventRepo, interceptors.ClientIP)
+		notificationRepo := notificationrepo.NewPostgresRepository(database)
+		deps.NotificationTemplates = notiftemplate.New(notiftemplaterepo.NewPostgresRepository(database), notificationRepo)
+		loginNotifier := notification.NewLoginNotifier(notificationRepo, orgPolicyConfigRepo, emailSender, alertSMSSender, notification.HeaderGeoLocator{}, securityEvents)
+		loginNotifier.SetTemplates(deps.NotificationTemplates)
 		var devOTPStore identityservice.DevOTPStore
 		if cfg.OTPReturnToClient {
 			devStore := devotp.NewMemoryStore()
 			devOTPStore = devStore
 			deps.DevOTPHandler = devotphandler.NewServer(devStore)
 		}
-		auditRepo := auditrepo.NewPostgresRepository(database)
+		var breachChecker breachedpassword.Checker
+		switch cfg.BreachedPasswordCheck {
+		case "hibp":
+			breachChecker = breachedpassword.NewHIBPClient(cfg.BreachedPasswordHIBPURL)
+		case "bloom":
+			filter, err := breachedpassword.LoadBloomFilter(cfg.BreachedPasswordBloomFile)
+			if err != nil {
+				log.Fatalf("breached password bloom filter: %v", err)
+			}
+			breachChecker = filter
+		}
+		var disposableDomains signupguard.DomainList
+		if cfg.SignupBlockDisposableEmail {
+			list := signupguard.BuiltinList()
+			if cfg.SignupDisposableDomainsFile != "" {
+				if list, err = signupguard.LoadListFile(cfg.SignupDisposableDomainsFile); err != nil {
+					log.Fatalf("disposable email domains: %v", err)
+				}
+			}
+			disposableDomains = list
+		}
+		signupIPLimiter := ratelimit.NewLimiter(cfg.SignupIPLimit, cfg.SignupRateWindow())
+		signupGuard := signupguard.NewGuard(disposableDomains, signupIPLimiter, cfg.SignupAllowedEmailDomainList())
+		verifyCredentialsIPLimiter := ratelimit.NewLimiter(cfg.VerifyCredentialsIPLimit, cfg.VerifyCredentialsRateWindow())
+		verifyCredentialsEmailLimiter := ratelimit.NewLimiter(cfg.VerifyCredentialsEmailLimit, cfg.VerifyCredentialsRateWindow())
+		orgDiscoveryIPLimiter := ratelimit.NewLimiter(cfg.OrgDiscoveryIPLimit, cfg.VerifyCredentialsRateWindow())
+		orgDiscoveryEmailLimiter := ratelimit.NewLimiter(cfg.OrgDiscoveryEmailLimit, cfg.VerifyCredentialsRateWindow())
+		featureFlagRepo := featureflagrepo.NewPostgresRepository(database)
+		featureFlags := featureflag.NewEvaluator(featureFlagRepo, featureflag.DefaultCacheTTL)
+		auditStore := auditrepo.NewPostgresRepository(database, dataRouter)
+		if interval := cfg.AuditPartitionEvery(); interval > 0 {
+			go audit.NewPartitionJob(auditStore, cfg.AuditRetention()).Run(jobsCtx, interval)
+		}
+		var auditRepo auditrepo.Repository = auditStore
+		// AUDIT_BUFFER_SIZE > 0 moves audit inserts off the request path; the queue is flushed on shutdown.
+		if cfg.AuditBufferSize > 0 {
+			auditWriter = audit.NewBufferedWriter(auditRepo, audit.BufferOptions{
+				QueueSize:     cfg.AuditBufferSize,
+				BatchSize:     cfg.AuditBatchSize,
+				FlushInterval: cfg.AuditFlushEvery(),
+				Overflow:      audit.OverflowPolicy(cfg.AuditOverflowPolicy),
+			})
+			auditRepo = auditWriter
+		}
+		// AUDIT_WEBHOOKS_ENABLED delivers each org's audit events to the webhooks its admins subscribe, filtered per webhook.
+		if cfg.AuditWebhooksEnabled {
+			auditWebhookRepo := auditwebhookrepo.NewPostgresRepository(database)
+			auditWebhooks := auditwebhook.NewDispatcher(auditWebhookRepo, membershipRepo, cfg.AuditWebhookQueueSize)
+			go auditWebhooks.Run(jobsCtx)
+			auditRepo = auditwebhook.NewTap(auditRepo, auditWebhooks)
+			deps.AuditWebhookRepo = auditWebhookRepo
+			deps.AuditWebhooks = auditWebhooks
+		}
 		deps.AuditRepo = auditRepo
-		auditLogger := audit.NewLogger(auditRepo, interceptors.ClientIP)
+		auditLogger := audit.NewLogger(auditRepo, interceptors.ClientIP, interceptors.RequestID)
+		// LOGIN_HOLD_ENABLED holds sign-ins from blocked IPs for an org admin's approval; otherwise they are rejected.
+		var loginHolds identityservice.LoginHoldRepo
+		var loginHoldNotifier identityservice.LoginHoldNotifier
+		if cfg.LoginHoldEnabled {
+			loginHolds = loginholdrepo.NewPostgresRepository(database)
+			if cfg.LoginHoldWebhookURL != "" {
+				loginHoldNotifier = loginhold.NewWebhookNotifier(cfg.LoginHoldWebhookURL, cfg.LoginHoldWebhookSecret)
+			}
+		}
+		// Sign-ins against honeytoken accounts always fail and are alerted (HONEYTOKEN_WEBHOOK_URL).
+		honeytokenRepo := honeytokenrepo.NewPostgresRepository(database)
+		var honeytokenNotifier identityservice.HoneytokenNotifier
+		if cfg.HoneytokenWebhookURL != "" {
+			honeytokenNotifier = honeytoken.NewWebhookNotifier(cfg.HoneytokenWebhookURL, cfg.HoneytokenWebhookSecret)
+		}
+		// DEVICE_CODE_ENABLED lets CLIs and kiosks sign in with a code approved from a trusted session.
+		var deviceCodes identityservice.DeviceCodeRepo
+		if cfg.DeviceCodeEnabled {
+			deviceCodes = devicecoderepo.NewPostgresRepository(database)
+		}
+		// MAGIC_LINK_ENABLED emails one-time sign-in links to members of orgs that allow them; links need SMTP.
+		var magicLinks identityservice.MagicLinkRepo
+		var magicLinkMailer identityservice.MagicLinkMailer
+		if cfg.MagicLinkEnabled {
+			if emailSender != nil {
+				magicLinks = magiclinkrepo.NewPostgresRepository(database)
+				magicLinkMailer = magiclink.NewEmailMailer(emailSender)
+			} else {
+				log.Print("MAGIC_LINK_ENABLED is set but SMTP_HOST is not; magic link sign-in is disabled")
+			}
+		}
+		// EMAIL_CHANGE_ENABLED changes a user's email once links to the current and new address were opened.
+		var emailChanges identityservice.EmailChangeRepo
+		var emailChangeMailer identityservice.EmailChangeMailer
+		if cfg.EmailChangeEnabled {
+			if emailSender != nil {
+				emailChanges = emailchangerepo.NewPostgresRepository(database, piiKeyring)
+				emailChangeMailer = emailchange.NewEmailMailer(emailSender)
+			} else {
+				log.Print("EMAIL_CHANGE_ENABLED is set but SMTP_HOST is not; email change is disabled")
+			}
+		}
+		// USERNAME_LOGIN_ENABLED lets users set a username and sign in with it in place of their email.
+		var usernames identityservice.UsernameRepo
+		if cfg.UsernameLoginEnabled {
+			usernames = usernamerepo.NewPostgresRepository(database)
+		}
+		// ORG_DISCOVERY_ENABLED lets a sign-in screen ask for the email first and find the orgs to sign in to.
+		var orgDirectory identityservice.OrgDirectory
+		if cfg.OrgDiscoveryEnabled {
+			orgDirectory = orgdiscoveryrepo.NewPostgresRepository(database)
+		}
+		// Shared fingerprints are always reported to platform admins; SHARED_DEVICE_DETECTION also passes them to MFA
+		// policies at sign-in.
+		sharedDevices := devicesharing.NewDetector(devicesharingrepo.NewPostgresRepository(database), cfg.SharedDeviceUserThreshold)
+		cfgWatcher.Subscribe(func(c *config.Config) { sharedDevices.SetThreshold(c.SharedDeviceUserThreshold) })
+		deps.SharedDevices = sharedDevices
+		var deviceSharing identityservice.DeviceSharingDetector
+		if cfg.SharedDeviceDetection {
+			deviceSharing = sharedDevices
+		}
+		// Invitations and org registration modes always apply; TURNSTILE_SECRET_KEY adds a CAPTCHA to open registration.
+		orgDomainRepo := orgdomainrepo.NewPostgresRepository(database)
+		invitationRepo := invitationrepo.NewPostgresRepository(database)
+		var captchaVerifier identityservice.CaptchaVerifier
+		if cfg.TurnstileSecretKey != "" {
+			captchaVerifier = captcha.NewTurnstileVerifier(cfg.TurnstileSecretKey, cfg.TurnstileVerifyURL)
+		}
+		// A refresh token presented twice within REFRESH_REPLAY_WINDOW fails as reuse; REFRESH_REPLAY_REDIS_URL shares
+		// the window between instances.
+		var refreshReplays identityservice.ReplayCache
+		if window := cfg.RefreshReplayDuration(); window > 0 {
+			if cfg.RefreshReplayRedisURL != "" {
+				redisReplays, err := replay.NewRedis(cfg.RefreshReplayRedisURL, "ztcp:refresh-replay:", window)
+				if err != nil {
+					log.Fatalf("config: REFRESH_REPLAY_REDIS_URL: %v", err)
+				}
+				defer redisReplays.Close()
+				refreshReplays = redisReplays
+			} else {
+				refreshReplays = replay.NewMemory(window)
+			}
+		}
 		authService := identityservice.NewAuthService(
 			userRepo,
 			identityRepo,
-			sessionRepo,
+			sessions,
 			deviceRepo,
 			membershipRepo,
 			platformSettingsRepo,
@@ -138,45 +606,392 @@ func main() {
 			cfg.AccessTTL(),
 			cfg.RefreshTTL(),
 			defaultTrustTTLDays,
-			10*time.Minute,
+			mfaChallengeTTL,
 			cfg.OTPReturnToClient,
 			devOTPStore,
 			auditLogger,
+			identityservice.WithLoginNotifier(loginNotifier),
+			identityservice.WithOTPTemplates(deps.NotificationTemplates),
+			identityservice.WithSecurityEventRecorder(securityEvents),
+			identityservice.WithIPBlockChecker(ipblockrepo.NewPostgresRepository(database)),
+			identityservice.WithOrgPolicyConfigRepo(orgPolicyConfigRepo),
+			identityservice.WithGroupLister(groupRepo),
+			identityservice.WithUserAttributes(userAttributeRepo),
+			identityservice.WithDeviceSharing(deviceSharing),
+			identityservice.WithVerifyCredentialsLimiters(verifyCredentialsIPLimiter, verifyCredentialsEmailLimiter),
+			identityservice.WithTokenExchange(cfg.TokenExchangeAudienceList(), cfg.ResourceTokenTTL()),
+			identityservice.WithBreachedPasswordCheck(breachChecker, breachedpassword.Mode(cfg.BreachedPasswordMode)),
+			identityservice.WithFeatureFlags(featureFlags),
+			identityservice.WithRecoveryCodes(mfarecoveryrepo.NewPostgresRepository(database)),
+			identityservice.WithMFAChallengeLimits(cfg.MFAMaxOTPAttempts, cfg.MFAMaxResends, cfg.MFAResendCooldownDuration()),
+			identityservice.WithLoginHolds(loginHolds, cfg.LoginHoldExpiry(), loginHoldNotifier),
+			identityservice.WithPublicDeviceSessions(cfg.PublicSessionLifetime(), cfg.PublicSessionIdle()),
+			identityservice.WithHoneytokens(honeytokenRepo, honeytokenNotifier),
+			identityservice.WithDeviceCodes(deviceCodes, cfg.DeviceCodeExpiry(), cfg.DeviceCodeVerificationURL),
+			identityservice.WithMagicLinks(magicLinks, magicLinkMailer, cfg.MagicLinkExpiry(), cfg.MagicLinkURL, ratelimit.NewLimiter(cfg.MagicLinkEmailLimit, time.Hour)),
+			identityservice.WithEmailChange(emailChanges, emailChangeMailer, cfg.EmailChangeExpiry(), cfg.EmailChangeURL),
+			identityservice.WithUsernames(usernames, username.Scope(cfg.UsernameScope)),
+			identityservice.WithOrgDiscovery(orgDirectory, orgDiscoveryIPLimiter, orgDiscoveryEmailLimiter),
+			identityservice.WithRegistrationControls(invitationRepo, orgDomainRepo, captchaVerifier),
+			identityservice.WithSignupGuard(signupGuard),
+			identityservice.WithSeatLimit(licenseManager),
+			identityservice.WithIntrospection(cfgWatcher, cfg.IntrospectionCacheMaxTTL()),
+			identityservice.WithRefreshReplayCache(refreshReplays),
+			identityservice.WithFlowTimings(func() bool { return cfgWatcher.Current().SlogLevel() <= slog.LevelDebug }),
 		)
 		deps.Auth = authService
+		cfgWatcher.Subscribe(func(c *config.Config) {
+			verifyCredentialsIPLimiter.SetLimit(c.VerifyCredentialsIPLimit, c.VerifyCredentialsRateWindow())
+			verifyCredentialsEmailLimiter.SetLimit(c.VerifyCredentialsEmailLimit, c.VerifyCredentialsRateWindow())
+			orgDiscoveryIPLimiter.SetLimit(c.OrgDiscoveryIPLimit, c.VerifyCredentialsRateWindow())
+			orgDiscoveryEmailLimiter.SetLimit(c.OrgDiscoveryEmailLimit, c.VerifyCredentialsRateWindow())
+			signupIPLimiter.SetLimit(c.SignupIPLimit, c.SignupRateWindow())
+			signupGuard.SetAllowedDomains(c.SignupAllowedEmailDomainList())
+			tokens.SetTTLs(c.AccessTTL(), c.RefreshTTL())
+			tokens.SetClockLeeway(c.ClockLeeway())
+			authService.SetRuntimeSettings(identityservice.RuntimeSettings{
+				AccessTTL:           c.AccessTTL(),
+				RefreshTTL:          c.RefreshTTL(),
+				DefaultTrustTTLDays: c.DefaultTrustTTLDays,
+				ResourceTokenTTL:    c.ResourceTokenTTL(),
+			})
+		})
 		deps.DeviceRepo = deviceRepo
 		deps.PolicyRepo = policyRepo
 		deps.HealthPinger = database
 		deps.HealthPolicyChecker = policyEvaluator
 		deps.MembershipRepo = membershipRepo
-		deps.SessionRepo = sessionRepo
+		deps.InvitationRepo = invitationRepo
+		deps.GroupRepo = groupRepo
+		deps.UserAttributeRepo = userAttributeRepo
+		deps.ElevationRepo = elevationRepo
+		deps.ElevationDefaultDuration = cfg.ElevationDefault()
+		deps.ElevationMaxDuration = cfg.ElevationMax()
+		breakGlassRepo := breakglassrepo.NewPostgresRepository(database)
+		deps.BreakGlassRepo = breakGlassRepo
+		deps.BreakGlassTokens = tokens
+		deps.BreakGlassSessionTTL = cfg.BreakGlassTTL()
+		// Break-glass alerts go to every configured channel; none configured means they are only audited.
+		var breakGlassAlerters breakglass.Alerters
+		if cfg.BreakGlassWebhookURL != "" {
+			breakGlassAlerters = append(breakGlassAlerters, breakglass.NewWebhookAlerter(cfg.BreakGlassWebhookURL, cfg.BreakGlassWebhookSecret))
+		}
+		if recipients := cfg.BreakGlassAlertEmailList(); len(recipients) > 0 {
+			if emailSender != nil {
+				breakGlassAlerters = append(breakGlassAlerters, breakglass.NewEmailAlerter(emailSender, recipients))
+			} else {
+				log.Print("BREAK_GLASS_ALERT_EMAILS is set but SMTP_HOST is not; break-glass alert emails are not sent")
+			}
+		}
+		if len(breakGlassAlerters) > 0 {
+			deps.BreakGlassAlerter = breakGlassAlerters
+		}
+		deps.SessionRepo = sessions
 		deps.UserRepo = userRepo
 		deps.OrgRepo = orgRepo
+		deps.Plans = billing.NewGate(orgRepo, func() bool { return cfgWatcher.Current().PlanEnforcementEnabled }, licenseManager)
+		deps.License = licenseManager
+		// BACKUP_ENCRYPTION_KEY and BACKUP_DIR enable AdminService CreateBackup; cmd/backup needs only the key.
+		if cfg.BackupsEnabled() && cfg.BackupDir != "" {
+			backupKey, err := backup.ParseKey(cfg.BackupEncryptionKey)
+			if err != nil {
+				log.Fatalf("BACKUP_ENCRYPTION_KEY: %v", err)
+			}
+			schemaVersion, err := migrate.Version(cfg.DatabaseURL)
+			if err != nil {
+				log.Fatalf("backup: %v", err)
+			}
+			deps.Backups = backup.NewService(backuprepo.NewPostgresRepository(database), backupKey, schemaVersion, auditLogger)
+			deps.BackupDir = cfg.BackupDir
+			log.Printf("backup: CreateBackup writes to %s with key %s", cfg.BackupDir, backup.KeyID(backupKey))
+		}
+		deps.DataRegions = dataRouter.Regions()
 		deps.AuditLogger = auditLogger
 		deps.OrgPolicyConfigRepo = orgPolicyConfigRepo
+		deps.PolicyHub = orgpolicyconfig.NewHub()
 		deps.OrgMFASettingsRepo = orgMFASettingsRepo
+		deps.NotificationRepo = notificationRepo
+		deps.SecurityEventRepo = securityEventRepo
+		deps.SecurityEvents = securityEvents
+		deps.PolicyViolationRepo = policyviolationrepo.NewPostgresRepository(database, dataRouter)
+		agentRepo := agentrepo.NewPostgresRepository(database)
+		deps.AgentRepo = agentRepo
+		deps.AgentStaleAfter = cfg.AgentStale()
+		if cfg.TelemetryTransport == telemetry.TransportEmbedded {
+			// Embedded mode: no broker or Loki; events are buffered in memory and stored in telemetry_events.
+			telemetryStore := embedded.NewPostgresStore(database, dataRouter)
+			telemetryRecorder = embedded.NewRecorder(telemetryStore, embedded.Options{BufferSize: cfg.TelemetryEmbeddedBufferSize})
+			deps.TelemetryPublisher = telemetryRecorder
+			deps.TelemetryEvents = telemetryStore
+			deps.Region = cfg.Region
+			if retention := cfg.TelemetryEmbeddedRetention(); retention > 0 {
+				go embedded.NewRetentionJob(telemetryStore, retention).Run(jobsCtx, embedded.RetentionInterval)
+			}
+			log.Printf("telemetry: storing in %s", telemetrytransports.Destination(cfg))
+		} else if cfg.TelemetryEnabled() {
+			telemetryPublisher, err := telemetrytransports.Publisher(cfg)
+			if err != nil {
+				log.Fatalf("telemetry: %v", err)
+			}
+			deps.TelemetryPublisher = telemetryPublisher
+			deps.Region = cfg.Region
+			log.Printf("telemetry: publishing to %s", telemetrytransports.Destination(cfg))
+		} else {
+			log.Printf("telemetry ingestion disabled (%s empty); TelemetryService returns Unimplemented", telemetrytransports.SettingName(cfg))
+		}
+		deps.FeatureFlagRepo = featureFlagRepo
+		deps.FeatureFlags = featureFlags
+		deps.ChangeRequestRepo = changerequestrepo.NewPostgresRepository(database)
+		deps.UrlExceptionRepo = urlexceptionrepo.NewPostgresRepository(database)
+		deps.Maintenance = maintenance.NewSwitch(platformSettingsRepo, maintenance.DefaultCacheTTL)
+		deps.PlatformSettingsRepo = platformSettingsRepo
+		quotas := quota.NewEnforcer(quotarepo.NewPostgresRepository(database), cfg.QuotaPlanTable(), cfg.QuotaDefaultPlan, quota.DefaultCacheTTL)
+		cfgWatcher.Subscribe(func(c *config.Config) { quotas.SetPlans(c.QuotaPlanTable(), c.QuotaDefaultPlan) })
+		deps.Quotas = quotas
+		deps.Honeytokens = honeytoken.NewRegistry(honeytokenRepo, userRepo, sessionRepo)
+		deps.UserMerger = usermerge.NewMerger(usermergerepo.NewPostgresRepository(database, dataRouter), userRepo, sessionRepo, deps.Confirmations)
+		if interval := cfg.DataExportEvery(); interval > 0 {
+			dataExports := dataexport.NewService(dataexportrepo.NewPostgresRepository(database, dataRouter), userRepo, auditStore, cfg.DataExportLifetime())
+			deps.DataExports = dataExports
+			go dataExports.Run(jobsCtx, interval)
+		} else {
+			log.Print("data exports disabled (DATA_EXPORT_INTERVAL=0); ExportMyData, DownloadDataExport and ExportUserData return Unimplemented")
+		}
+		deps.OrgDomains = orgdomain.NewVerifier(orgDomainRepo, nil)
+		policyPresetRepo := policypresetrepo.NewPostgresRepository(database)
+		deps.OrgSetup = orgsetup.NewSetuper(orgsetuprepo.NewPostgresRepository(database), userRepo, policyPresetRepo)
+		deps.PolicyPresets = policypreset.NewLibrary(policyPresetRepo, orgPolicyConfigRepo, orgMFASettingsRepo, deps.PolicyHub)
+		if cfg.ChangeRequestWebhookURL != "" {
+			deps.ChangeRequestNotifier = changerequest.NewWebhookNotifier(cfg.ChangeRequestWebhookURL, cfg.ChangeRequestWebhookSecret)
+		}
+
+		analyticsRepo := analyticsrepo.NewPostgresRepository(database, dataRouter)
+		deps.AnalyticsRepo = analyticsRepo
+		if interval := cfg.RollupInterval(); interval > 0 {
+			go analytics.NewRollupJob(analyticsRepo).Run(jobsCtx, interval)
+		} else {
+			log.Print("analytics rollup job disabled (ANALYTICS_ROLLUP_INTERVAL=0); AnalyticsService serves existing rollups only")
+		}
+		usageRepo := meteringrepo.NewPostgresRepository(database, dataRouter)
+		deps.UsageRepo = usageRepo
+		if interval := cfg.MeteringInterval(); interval > 0 {
+			usageMeter = metering.NewMeter(usageRepo)
+			go metering.NewJob(usageMeter, usageRepo).Run(jobsCtx, interval, cfg.RollupInterval())
+		} else {
+			log.Print("usage metering disabled (METERING_FLUSH_INTERVAL=0); GetUsage and ExportUsage serve existing usage only")
+		}
+		if interval := cfg.LicenseInterval(); interval > 0 && cfg.LicenseFile != "" {
+			go license.NewJob(licenseManager, auditLogger).Run(jobsCtx, interval)
+		}
+		if interval := cfg.SchedulerInterval(); interval > 0 {
+			// Shares PolicyHub with the gRPC handler so applied changes reach SubscribeBrowserPolicy streams.
+			activator := orgpolicyconfighandler.NewServer(orgPolicyConfigRepo, membershipRepo, orgMFASettingsRepo, deps.PolicyHub, nil, nil, nil)
+			go orgpolicyconfig.NewScheduler(activator).Run(jobsCtx, interval)
+		} else {
+			log.Print("org policy config scheduler disabled (POLICY_SCHEDULER_INTERVAL=0); scheduled changes stay pending")
+		}
+		if interval := cfg.TrustExpiryInterval(); interval > 0 {
+			go notification.NewTrustExpiryJob(deviceRepo, userRepo, orgPolicyConfigRepo, emailSender, securityEvents).Run(jobsCtx, interval)
+		} else {
+			log.Print("device trust expiry notices disabled (TRUST_EXPIRY_NOTICE_INTERVAL=0)")
+		}
+		if silentFor := cfg.AgentTrustDegradeAfter(); silentFor > 0 {
+			go agent.NewTrustDegradeJob(agentRepo, deviceRepo, auditLogger, silentFor).Run(jobsCtx, agent.DegradeInterval)
+		} else {
+			log.Print("agent trust degradation disabled (AGENT_TRUST_DEGRADE_DAYS=0); devices keep their trust when their agent stops reporting")
+		}
+		if interval := cfg.PublicSessionSweepEvery(); interval > 0 {
+			go session.NewEphemeralSweepJob(sessionRepo, sessions, auditLogger, cfg.PublicSessionIdle()).Run(jobsCtx, interval)
+		} else {
+			log.Print("public device session sweeper disabled (PUBLIC_SESSION_SWEEP_INTERVAL=0); idle public sessions end at their next refresh")
+		}
+		if interval := cfg.ElevationExpiryEvery(); interval > 0 {
+			go elevation.NewExpiryJob(elevationRepo, auditLogger).Run(jobsCtx, interval)
+		} else {
+			log.Print("admin elevation expiry job disabled (ELEVATION_EXPIRY_INTERVAL=0); ended elevations stay approved but grant nothing")
+		}
+		if interval := cfg.BreakGlassExpiryEvery(); interval > 0 {
+			go breakglass.NewExpiryJob(breakGlassRepo, sessions, auditLogger, deps.BreakGlassAlerter).Run(jobsCtx, interval)
+		} else {
+			log.Print("break-glass expiry job disabled (BREAK_GLASS_EXPIRY_INTERVAL=0); ended windows keep their sessions until the access tokens expire")
+		}
+		if piiKeyring != nil {
+			if interval := cfg.PIIReencryptEvery(); interval > 0 {
+				go pii.NewReencryptJob(piiKeyring, cfg.PIIDataKeyMaxAgeDuration(), userRepo).Run(jobsCtx, interval)
+			} else {
+				log.Print("PII re-encryption job disabled (PII_REENCRYPT_INTERVAL=0); rotated keys keep decrypting old rows")
+			}
+		}
 	}
 
+	// SIGHUP (or CONFIG_RELOAD_INTERVAL) re-reads the config; settings tagged reload:"true" apply without a restart.
+	cfgWatcher.Subscribe(func(c *config.Config) { slog.SetLogLoggerLevel(c.SlogLevel()) })
+	go cfgWatcher.Run(jobsCtx, cfg.ReloadInterval())
+
 	if authEnabled {
 		publicMethods := map[string]bool{
 			authv1.AuthService_Register_FullMethodName:                 true,
 			authv1.AuthService_Login_FullMethodName:                    true,
 			authv1.AuthService_VerifyMFA_FullMethodName:                true,
+			authv1.AuthService_ResendMFACode_FullMethodName:            true,
 			authv1.AuthService_SubmitPhoneAndRequestMFA_FullMethodName: true,
 			authv1.AuthService_Refresh_FullMethodName:                  true,
+			authv1.AuthService_CreateRefreshNonce_FullMethodName:       true,
 			authv1.AuthService_VerifyCredentials_FullMethodName:        true,
+			authv1.AuthService_DiscoverOrganizations_FullMethodName:    true,
+			authv1.AuthService_ResumeLogin_FullMethodName:              true,
+			authv1.AuthService_StartDeviceAuthorization_FullMethodName: true,
+			authv1.AuthService_PollDeviceAuthorization_FullMethodName:  true,
+			authv1.AuthService_RequestMagicLink_FullMethodName:         true,
+			authv1.AuthService_CompleteMagicLink_FullMethodName:        true,
+			authv1.AuthService_ConfirmEmailChange_FullMethodName:       true,
+			authv1.AuthService_GetJWKS_FullMethodName:                  true,
 			healthv1.HealthService_HealthCheck_FullMethodName:          true,
+			breakglassv1.BreakGlassService_SignIn_FullMethodName:       true,
 			organizationv1.OrganizationService_CreateOrganization_FullMethodName: true,
+			organizationv1.OrganizationService_SetupOrganization_FullMethodName:  true,
 		}
 		if deps.DevOTPHandler != nil {
 			publicMethods[devv1.DevService_GetOTP_FullMethodName] = true
 		}
 		auditSkipMethods := map[string]bool{
 			healthv1.HealthService_HealthCheck_FullMethodName: true,
+			// Polled by services that verify tokens with the JWKS; returns only public keys.
+			authv1.AuthService_GetJWKS_FullMethodName: true,
+			// Called by service accounts for every token they see; read-only and cacheable.
+			authv1.AuthService_Introspect_FullMethodName: true,
+			// Audited by AuthService as resource_token_issued / resource_token_denied with the audience.
+			authv1.AuthService_TokenExchange_FullMethodName: true,
+			// Audited by AuthService as login_hold_approved / login_hold_denied with the hold ID.
+			authv1.AuthService_ApproveLogin_FullMethodName: true,
+			authv1.AuthService_DenyLogin_FullMethodName:    true,
+			// Audited by AuthService as device_code_approved / device_code_denied with the device code ID.
+			authv1.AuthService_ApproveDeviceCode_FullMethodName: true,
+			authv1.AuthService_DenyDeviceCode_FullMethodName:    true,
+			// Audited by AuthService as logout_all with the number of sessions revoked.
+			authv1.AuthService_LogoutAllMySessions_FullMethodName: true,
+			// Audited by AuthService as email_change_started / email_change_confirmed / email_changed with the change ID.
+			authv1.AuthService_StartEmailChange_FullMethodName:   true,
+			authv1.AuthService_ConfirmEmailChange_FullMethodName: true,
+			// Audited by AuthService as username_set / username_removed; the availability check is read-only.
+			authv1.AuthService_CheckUsernameAvailability_FullMethodName: true,
+			authv1.AuthService_SetUsername_FullMethodName:               true,
+			// Audited by FeatureFlagService with the flag key and target org.
+			featureflagv1.FeatureFlagService_UpsertFeatureFlag_FullMethodName: true,
+			featureflagv1.FeatureFlagService_DeleteFeatureFlag_FullMethodName: true,
+			featureflagv1.FeatureFlagService_SetOrgOverride_FullMethodName:    true,
+			featureflagv1.FeatureFlagService_ClearOrgOverride_FullMethodName:  true,
+			// Read-only and polled by clients.
+			featureflagv1.FeatureFlagService_EvaluateFeatureFlags_FullMethodName: true,
+			// Audited by ChangeRequestService with the change request ID and kind.
+			changerequestv1.ChangeRequestService_ProposeChange_FullMethodName:        true,
+			changerequestv1.ChangeRequestService_ApproveChangeRequest_FullMethodName: true,
+			changerequestv1.ChangeRequestService_RejectChangeRequest_FullMethodName:  true,
+			// Audited by ElevationService as elevation_requested / _approved / _rejected / _revoked with the elevation ID.
+			elevationv1.ElevationService_RequestElevation_FullMethodName: true,
+			elevationv1.ElevationService_ApproveElevation_FullMethodName: true,
+			elevationv1.ElevationService_RejectElevation_FullMethodName:  true,
+			elevationv1.ElevationService_RevokeElevation_FullMethodName:  true,
+			// Only denials are audited, by OrgPolicyConfigService as url_access_denied with the domain; checks are polled by
+			// the browser for every navigation.
+			orgpolicyconfigv1.OrgPolicyConfigService_CheckUrlAccess_FullMethodName: true,
+			// Audited by UrlExceptionService as url_exception_requested / _approved / _rejected / _revoked with the domain.
+			urlexceptionv1.UrlExceptionService_RequestUrlException_FullMethodName: true,
+			urlexceptionv1.UrlExceptionService_ApproveUrlException_FullMethodName: true,
+			urlexceptionv1.UrlExceptionService_RejectUrlException_FullMethodName:  true,
+			urlexceptionv1.UrlExceptionService_RevokeUrlException_FullMethodName:  true,
+			// Audited by AuditWebhookService as audit_webhook_created / _updated / _deleted with the URL and filter.
+			auditwebhookv1.AuditWebhookService_CreateAuditWebhook_FullMethodName: true,
+			auditwebhookv1.AuditWebhookService_UpdateAuditWebhook_FullMethodName: true,
+			auditwebhookv1.AuditWebhookService_DeleteAuditWebhook_FullMethodName: true,
+			// Audited by BreakGlassService as break_glass_* on the target org with the activation ID.
+			breakglassv1.BreakGlassService_ProvisionBreakGlassAccount_FullMethodName: true,
+			breakglassv1.BreakGlassService_RequestUnlock_FullMethodName:              true,
+			breakglassv1.BreakGlassService_ApproveUnlock_FullMethodName:              true,
+			breakglassv1.BreakGlassService_EndAccess_FullMethodName:                  true,
+			breakglassv1.BreakGlassService_SignIn_FullMethodName:                     true,
+			breakglassv1.BreakGlassService_SubmitReport_FullMethodName:               true,
+			// Audited by InvitationService as invitation_created / invitation_revoked with the invitation ID.
+			invitationv1.InvitationService_CreateInvitation_FullMethodName: true,
+			invitationv1.InvitationService_RevokeInvitation_FullMethodName: true,
+			// Audited by UserAttributeService as attribute_definition_upserted / _deleted and member_attributes_updated.
+			userattributev1.UserAttributeService_UpsertAttributeDefinition_FullMethodName: true,
+			userattributev1.UserAttributeService_DeleteAttributeDefinition_FullMethodName: true,
+			userattributev1.UserAttributeService_SetMemberAttributes_FullMethodName:       true,
+			// Audited by PolicyPresetService as policy_preset_applied with the preset and config version.
+			policypresetv1.PolicyPresetService_ApplyPreset_FullMethodName: true,
+			// Audited by PlatformSettingsService as platform_setting_changed with the old and new values.
+			platformsettingsv1.PlatformSettingsService_SetPlatformSettings_FullMethodName: true,
+			// Audited by AdminService as maintenance_mode_changed with the mode.
+			adminv1.AdminService_SetMaintenanceMode_FullMethodName: true,
+			// Audited by AdminService as org_quota_changed with the org and its plan.
+			adminv1.AdminService_SetOrgQuota_FullMethodName: true,
+			// Audited by AdminService as org_plan_changed with the new plan.
+			adminv1.AdminService_SetOrgBillingPlan_FullMethodName: true,
+			// Audited by AdminService as honeytoken_marked / honeytoken_unmarked with the target user.
+			adminv1.AdminService_MarkHoneytoken_FullMethodName:   true,
+			adminv1.AdminService_UnmarkHoneytoken_FullMethodName: true,
+			// Audited by AdminService as users_merged with the diff; dry runs change nothing and are not audited.
+			adminv1.AdminService_MergeUsers_FullMethodName: true,
+			// Audited by AdminService and UserService as data_export_requested / data_export_downloaded with the export ID.
+			adminv1.AdminService_ExportUserData_FullMethodName:   true,
+			userv1.UserService_ExportMyData_FullMethodName:       true,
+			userv1.UserService_DownloadDataExport_FullMethodName: true,
+			// Audited by OrganizationService as domain_verification_started / domain_verified with the domain.
+			organizationv1.OrganizationService_StartDomainVerification_FullMethodName: true,
+			organizationv1.OrganizationService_VerifyDomain_FullMethodName:            true,
+			// Audited by OrganizationService as organization_updated with the changed fields.
+			organizationv1.OrganizationService_UpdateOrganization_FullMethodName: true,
+			// Audited by NotificationService as org_smtp_settings_updated / org_smtp_settings_deleted with the settings.
+			notificationv1.NotificationService_UpdateOrgSMTPSettings_FullMethodName: true,
+			notificationv1.NotificationService_DeleteOrgSMTPSettings_FullMethodName: true,
+			// Audited by NotificationService as notification_template_updated / notification_template_deleted with the kind and locale.
+			notificationv1.NotificationService_UpdateNotificationTemplate_FullMethodName: true,
+			notificationv1.NotificationService_DeleteNotificationTemplate_FullMethodName: true,
+			// Audited by AgentService as agent_registered with the agent and device.
+			agentv1.AgentService_RegisterAgent_FullMethodName: true,
+			// Sent by every agent every few minutes; silence is audited as agent_trust_degraded instead.
+			agentv1.AgentService_Heartbeat_FullMethodName: true,
+			// High-volume agent telemetry; the events themselves go to the telemetry topic.
+			telemetryv1.TelemetryService_IngestTelemetry_FullMethodName: true,
+		}
+		// Served in read-only and maintenance mode: existing sessions keep refreshing, and admins can switch back.
+		maintenanceExemptMethods := map[string]bool{
+			healthv1.HealthService_HealthCheck_FullMethodName:      true,
+			authv1.AuthService_Refresh_FullMethodName:              true,
+			authv1.AuthService_CreateRefreshNonce_FullMethodName:   true,
+			adminv1.AdminService_GetMaintenanceMode_FullMethodName: true,
+			adminv1.AdminService_SetMaintenanceMode_FullMethodName: true,
+		}
+		// Never counted against API quotas, so admins can inspect and raise the quota of a throttled org.
+		quotaExemptMethods := map[string]bool{
+			healthv1.HealthService_HealthCheck_FullMethodName:  true,
+			adminv1.AdminService_ListQuotaPlans_FullMethodName: true,
+			adminv1.AdminService_GetOrgQuota_FullMethodName:    true,
+			adminv1.AdminService_SetOrgQuota_FullMethodName:    true,
+		}
+		var quotaGate interceptors.QuotaGate
+		if cfg.QuotaEnabled && deps.Quotas != nil {
+			quotaGate = deps.Quotas
+		}
+		// Not counted as API calls for usage metering.
+		meteringExemptMethods := map[string]bool{
+			healthv1.HealthService_HealthCheck_FullMethodName: true,
+		}
+		var meter interceptors.UsageMeter
+		if usageMeter != nil {
+			meter = usageMeter
 		}
 		var sessionValidator interceptors.SessionValidator
 		if deps.SessionRepo != nil {
 			sessionValidator = func(ctx context.Context, sessionID string) (bool, error) {
+				if revocationFreshness != nil {
+					if err := revocationFreshness.Check(ctx); errors.Is(err, replication.ErrStale) {
+						return false, status.Error(codes.Unavailable, "session revocation state is stale; retry later")
+					} else if err != nil {
+						return false, err
+					}
+				}
 				sess, err := deps.SessionRepo.GetByID(ctx, sessionID)
 				if err != nil {
 					return false, err
@@ -186,12 +1001,30 @@ func main() {
 		}
 		s = grpc.NewServer(
 			grpc.ChainUnaryInterceptor(
+				interceptors.RequestIDUnary(),
+				interceptors.LocalizeErrorsUnary(i18n.Default()),
+				interceptors.DBTimeoutUnary(),
+				interceptors.MaintenanceUnary(deps.Maintenance, maintenanceExemptMethods),
 				interceptors.AuthUnary(tokens, publicMethods, sessionValidator),
+				interceptors.QuotaUnary(quotaGate, quotaExemptMethods),
+				interceptors.MeteringUnary(meter, meteringExemptMethods),
 				interceptors.AuditUnary(deps.AuditRepo, auditSkipMethods),
 			),
+			grpc.ChainStreamInterceptor(
+				interceptors.RequestIDStream(),
+				interceptors.LocalizeErrorsStream(i18n.Default()),
+				interceptors.DBTimeoutStream(),
+				interceptors.MaintenanceStream(deps.Maintenance, maintenanceExemptMethods),
+				interceptors.AuthStream(tokens, publicMethods, sessionValidator),
+				interceptors.QuotaStream(quotaGate, quotaExemptMethods),
+				interceptors.MeteringStream(meter, meteringExemptMethods),
+			),
 		)
 	} else {
-		s = grpc.NewServer()
+		s = grpc.NewServer(
+			grpc.ChainUnaryInterceptor(interceptors.RequestIDUnary(), interceptors.LocalizeErrorsUnary(i18n.Default())),
+			grpc.ChainStreamInterceptor(interceptors.RequestIDStream(), interceptors.LocalizeErrorsStream(i18n.Default())),
+		)
 	}
 
 	server.RegisterServices(s, deps)
@@ -208,6 +1041,28 @@ func main() {
 	<-quit
 
 	log.Println("shutting down gRPC server...")
+	stopJobs()
 	s.GracefulStop()
+	if auditWriter != nil {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := auditWriter.Close(flushCtx); err != nil {
+			log.Printf("audit: flush on shutdown: %v (%d entries lost)", err, auditWriter.Stats().Queued)
+		}
+		cancel()
+	}
+	if usageMeter != nil {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := usageMeter.Flush(flushCtx); err != nil {
+			log.Printf("metering: flush on shutdown: %v", err)
+		}
+		cancel()
+	}
+	if telemetryRecorder != nil {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := telemetryRecorder.Close(flushCtx); err != nil {
+			log.Printf("telemetry: flush on shutdown: %v (%d events lost)", err, telemetryRecorder.Stats().Buffered)
+		}
+		cancel()
+	}
 	log.Println("gRPC server stopped")
 }
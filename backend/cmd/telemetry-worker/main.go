@@ -0,0 +1,85 @@
+// telemetry-worker consumes agent telemetry and pushes each event to Grafana Loki, with stream labels taken from the
+// event and, optionally, one Loki tenant per org. Events of unknown schema versions and events Loki refuses go to the
+// dead-letter topic. Run it next to cmd/server with the same transport settings (Kafka, NATS JetStream or RabbitMQ):
+// go run ./cmd/telemetry-worker.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/internal/telemetry/loki"
+	"zero-trust-control-plane/backend/internal/telemetry/transports"
+	"zero-trust-control-plane/backend/pkg/telemetryconsumer"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+	if cfg.TelemetryTransport == telemetry.TransportEmbedded {
+		log.Fatal("TELEMETRY_TRANSPORT=embedded stores telemetry in the server's database; there is no worker to run")
+	}
+	if !cfg.TelemetryEnabled() || cfg.LokiURL == "" {
+		log.Fatalf("%s and LOKI_URL must be set", transports.SettingName(cfg))
+	}
+	labels, err := loki.ParseLabels(cfg.LokiLabels)
+	if err != nil {
+		log.Fatalf("config: LOKI_LABELS: %v", err)
+	}
+	pusher := loki.New(loki.Config{
+		URL:            cfg.LokiURL,
+		Labels:         labels,
+		MaxLabelValues: cfg.LokiLabelMaxValues,
+		TenantPerOrg:   cfg.LokiTenantPerOrg,
+		TenantID:       cfg.LokiTenantID,
+		// While Loki is down, pushes fail at once and the consumer retries every few seconds instead of queueing
+		// requests behind the client timeout.
+		Breaker: breaker.New("loki", breaker.Config{FailureThreshold: cfg.BreakerFailureThreshold, OpenTimeout: cfg.BreakerOpenDuration()}),
+	})
+	var dedup telemetryconsumer.Dedup
+	if window := cfg.TelemetryDedupDuration(); window > 0 {
+		var shared telemetryconsumer.Dedup
+		if cfg.TelemetryDedupRedisURL != "" {
+			redisDedup, err := telemetryconsumer.NewRedisDedup(cfg.TelemetryDedupRedisURL, "ztcp:telemetry-dedup:"+cfg.TelemetryWorkerGroup+":", window)
+			if err != nil {
+				log.Fatalf("config: TELEMETRY_DEDUP_REDIS_URL: %v", err)
+			}
+			defer redisDedup.Close()
+			shared = redisDedup
+		}
+		dedup = telemetryconsumer.NewMemoryDedup(cfg.TelemetryDedupSize, window, shared)
+	}
+	sub, deadLetter, err := transports.Consumer(cfg)
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+	consumer := telemetryconsumer.New(telemetryconsumer.Config{
+		Subscription: sub,
+		DeadLetter:   deadLetter,
+		Handler: func(ctx context.Context, e telemetry.Event) error {
+			return pusher.Push(ctx, e)
+		},
+		Dedup: dedup,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	log.Printf("telemetry worker running: transport=%s source=%s group=%s dead_letter=%q labels=%v max_label_values=%d tenant_per_org=%t dedup_window=%s shared_dedup=%t",
+		cfg.TelemetryTransport, transports.Destination(cfg), cfg.TelemetryWorkerGroup, cfg.TelemetryDeadLetterTopic, names, cfg.LokiLabelMaxValues, cfg.LokiTenantPerOrg,
+		cfg.TelemetryDedupDuration(), cfg.TelemetryDedupRedisURL != "")
+	consumer.Run(ctx)
+	log.Println("telemetry worker stopped")
+}
@@ -2,22 +2,113 @@ package handler
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	adminv1 "zero-trust-control-plane/backend/api/generated/admin/v1"
+	analyticsv1 "zero-trust-control-plane/backend/api/generated/analytics/v1"
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	analyticshandler "zero-trust-control-plane/backend/internal/analytics/handler"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/backup"
+	backuprepo "zero-trust-control-plane/backend/internal/backup/repository"
+	"zero-trust-control-plane/backend/internal/billing"
+	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/dataexport"
+	"zero-trust-control-plane/backend/internal/devicesharing"
+	"zero-trust-control-plane/backend/internal/honeytoken"
+	honeytokendomain "zero-trust-control-plane/backend/internal/honeytoken/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	"zero-trust-control-plane/backend/internal/maintenance"
+	meteringdomain "zero-trust-control-plane/backend/internal/metering/domain"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	"zero-trust-control-plane/backend/internal/quota"
+	quotadomain "zero-trust-control-plane/backend/internal/quota/domain"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	"zero-trust-control-plane/backend/internal/usermerge"
+	usermergedomain "zero-trust-control-plane/backend/internal/usermerge/domain"
 )
 
+// Limits for SetMaintenanceMode.
+const (
+	maxMaintenanceMessageLength = 500
+	maxMaintenanceRetryAfter    = 24 * time.Hour
+)
+
+// maxQuotaRequestsPerMinute caps the quota overrides of SetOrgQuota.
+const maxQuotaRequestsPerMinute = 10_000_000
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// Page sizes of ExportUsage.
+const (
+	defaultExportPageSize = 100
+	maxExportPageSize     = 1000
+)
+
+// OrgGetter resolves orgs so quotas are only set for orgs that exist.
+type OrgGetter interface {
+	GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error)
+}
+
+// UsageExporter lists every org's metered usage in a month (the metering repository).
+type UsageExporter interface {
+	ListUsageForMonth(ctx context.Context, month time.Time, afterOrgID string, limit int32) ([]*meteringdomain.Usage, error)
+}
+
+// SMSRetryQueue reports the SMS messages waiting for retry (*sms.RetrySender).
+type SMSRetryQueue interface {
+	Queued() (queued int, dropped int64)
+}
+
 // Server implements AdminService (proto server) for system-level admin operations.
 // Proto: admin/admin.proto → internal/admin/handler.
 type Server struct {
 	adminv1.UnimplementedAdminServiceServer
+	config      *config.Watcher
+	maintenance *maintenance.Switch
+	quotas      *quota.Enforcer
+	honeytokens *honeytoken.Registry
+	userMerger  *usermerge.Merger
+	breakers    *breaker.Registry
+	smsQueue    SMSRetryQueue
+	orgs        OrgGetter
+	plans       *billing.Gate
+	usage       UsageExporter
+	license     *license.Manager
+	backups     *backup.Service
+	backupDir   string
+	dataExports *dataexport.Service
+	sharing     *devicesharing.Detector
+	auditLogger audit.AuditLogger
 }
 
-// NewServer returns a new Admin gRPC server.
-func NewServer() *Server {
-	return &Server{}
+// NewServer returns a new Admin gRPC server. If configWatcher is nil, GetEffectiveConfig returns Unimplemented; if
+// maintenanceSwitch is nil, the maintenance RPCs return Unimplemented; if quotas is nil, the quota RPCs return
+// Unimplemented; if honeytokens is nil, the honeytoken RPCs return Unimplemented; if userMerger is nil, MergeUsers
+// returns Unimplemented; if breakers is nil, ListCircuitBreakers returns Unimplemented; if plans is nil, the billing
+// plan RPCs return Unimplemented; if usage is nil, ExportUsage returns Unimplemented; if licenseManager is nil,
+// GetLicenseStatus returns Unimplemented; if backups is nil or backupDir is empty, CreateBackup returns Unimplemented;
+// if dataExports is nil, ExportUserData returns Unimplemented; if sharedDevices is nil, ListSharedDevices returns
+// Unimplemented. smsQueue, orgs and auditLogger may be nil. Platform admins are the users
+// listed in PLATFORM_ADMIN_USER_IDS of the current configuration.
+func NewServer(configWatcher *config.Watcher, maintenanceSwitch *maintenance.Switch, quotas *quota.Enforcer, honeytokens *honeytoken.Registry, userMerger *usermerge.Merger, breakers *breaker.Registry, smsQueue SMSRetryQueue, orgs OrgGetter, plans *billing.Gate, usage UsageExporter, licenseManager *license.Manager, backups *backup.Service, backupDir string, dataExports *dataexport.Service, sharedDevices *devicesharing.Detector, auditLogger audit.AuditLogger) *Server {
+	return &Server{config: configWatcher, maintenance: maintenanceSwitch, quotas: quotas, honeytokens: honeytokens, userMerger: userMerger, breakers: breakers, smsQueue: smsQueue, orgs: orgs, plans: plans, usage: usage, license: licenseManager, backups: backups, backupDir: backupDir, dataExports: dataExports, sharing: sharedDevices, auditLogger: auditLogger}
 }
 
 // GetSystemStats returns system-wide stats for platform admins.
@@ -25,3 +116,810 @@ func NewServer() *Server {
 func (s *Server) GetSystemStats(ctx context.Context, req *adminv1.GetSystemStatsRequest) (*adminv1.GetSystemStatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetSystemStats not implemented")
 }
+
+// GetEffectiveConfig returns the running configuration with secrets redacted. Platform admins only.
+func (s *Server) GetEffectiveConfig(ctx context.Context, req *adminv1.GetEffectiveConfigRequest) (*adminv1.GetEffectiveConfigResponse, error) {
+	if s.config == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetEffectiveConfig not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.config); err != nil {
+		return nil, err
+	}
+	entries := s.config.Current().Entries()
+	out := make([]*adminv1.ConfigEntry, len(entries))
+	for i, e := range entries {
+		out[i] = &adminv1.ConfigEntry{Key: e.Key, Value: e.Value, Reloadable: e.Reloadable, Redacted: e.Redacted}
+	}
+	return &adminv1.GetEffectiveConfigResponse{
+		Entries:  out,
+		LoadedAt: timestamppb.New(s.config.LoadedAt()),
+	}, nil
+}
+
+// GetMaintenanceMode returns the current maintenance mode. Platform admins only.
+func (s *Server) GetMaintenanceMode(ctx context.Context, req *adminv1.GetMaintenanceModeRequest) (*adminv1.GetMaintenanceModeResponse, error) {
+	if s.maintenance == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetMaintenanceMode not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	return &adminv1.GetMaintenanceModeResponse{Status: maintenanceToProto(s.maintenance.Current(ctx))}, nil
+}
+
+// SetMaintenanceMode switches the platform between normal operation, read-only mode and maintenance mode. The new
+// mode applies on this instance immediately and on other instances within maintenance.DefaultCacheTTL. Platform
+// admins only; audited as maintenance_mode_changed.
+func (s *Server) SetMaintenanceMode(ctx context.Context, req *adminv1.SetMaintenanceModeRequest) (*adminv1.SetMaintenanceModeResponse, error) {
+	if s.maintenance == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetMaintenanceMode not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	mode, ok := maintenanceModeToDomain(req.GetMode())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "mode required")
+	}
+	if len(req.GetMessage()) > maxMaintenanceMessageLength {
+		return nil, status.Error(codes.InvalidArgument, "message exceeds 500 characters")
+	}
+	retryAfter := time.Duration(req.GetRetryAfterSeconds()) * time.Second
+	if retryAfter < 0 || retryAfter > maxMaintenanceRetryAfter {
+		return nil, status.Error(codes.InvalidArgument, "retry_after_seconds must be between 0 and 86400")
+	}
+	state := platformsettingsdomain.MaintenanceState{Mode: mode, UpdatedBy: userID, UpdatedAt: time.Now().UTC()}
+	if mode != platformsettingsdomain.MaintenanceOff {
+		state.Message = req.GetMessage()
+		state.RetryAfter = retryAfter
+		if state.RetryAfter == 0 {
+			state.RetryAfter = platformsettingsdomain.DefaultMaintenanceRetryAfter
+		}
+	}
+	if err := s.maintenance.Set(ctx, state); err != nil {
+		return nil, status.Error(codes.Internal, "failed to store maintenance mode")
+	}
+	if s.auditLogger != nil {
+		orgID, _ := interceptors.GetOrgID(ctx)
+		meta, _ := json.Marshal(map[string]string{"mode": string(mode), "message": state.Message})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "maintenance_mode_changed", "platform", string(meta))
+	}
+	return &adminv1.SetMaintenanceModeResponse{Status: maintenanceToProto(state)}, nil
+}
+
+// ListQuotaPlans returns the configured quota plans and the default plan. Platform admins only.
+func (s *Server) ListQuotaPlans(ctx context.Context, req *adminv1.ListQuotaPlansRequest) (*adminv1.ListQuotaPlansResponse, error) {
+	if s.quotas == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListQuotaPlans not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	planList, defaultPlan := s.quotas.Plans()
+	out := make([]*adminv1.QuotaPlan, len(planList))
+	for i, p := range planList {
+		out[i] = &adminv1.QuotaPlan{
+			Name:                   p.Name,
+			OrgRequestsPerMinute:   int32(p.OrgRequestsPerMinute),
+			TokenRequestsPerMinute: int32(p.TokenRequestsPerMinute),
+		}
+	}
+	return &adminv1.ListQuotaPlansResponse{Plans: out, DefaultPlan: defaultPlan, Enforced: s.quotasEnforced()}, nil
+}
+
+// GetOrgQuota returns the org's quotas in effect, its override and its usage on this instance. Platform admins only.
+func (s *Server) GetOrgQuota(ctx context.Context, req *adminv1.GetOrgQuotaRequest) (*adminv1.GetOrgQuotaResponse, error) {
+	if s.quotas == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetOrgQuota not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	override, err := s.quotas.Override(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load org quota")
+	}
+	return &adminv1.GetOrgQuotaResponse{Quota: s.orgQuotaToProto(ctx, req.GetOrgId(), override)}, nil
+}
+
+// SetOrgQuota assigns the org's plan and quota overrides, or clears its assignment. The change applies on this
+// instance immediately and on other instances within quota.DefaultCacheTTL. Platform admins only; audited as
+// org_quota_changed.
+func (s *Server) SetOrgQuota(ctx context.Context, req *adminv1.SetOrgQuotaRequest) (*adminv1.SetOrgQuotaResponse, error) {
+	if s.quotas == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetOrgQuota not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	orgID := req.GetOrgId()
+	if orgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	if req.GetClear() {
+		if req.GetPlan() != "" || req.OrgRequestsPerMinute != nil || req.TokenRequestsPerMinute != nil {
+			return nil, status.Error(codes.InvalidArgument, "clear must not be combined with plan or quota overrides")
+		}
+		if err := s.quotas.ClearOverride(ctx, orgID); err != nil {
+			return nil, status.Error(codes.Internal, "failed to clear org quota")
+		}
+		s.auditQuota(ctx, userID, orgID, map[string]interface{}{"org_id": orgID, "cleared": true})
+		return &adminv1.SetOrgQuotaResponse{Quota: s.orgQuotaToProto(ctx, orgID, nil)}, nil
+	}
+	if req.GetPlan() != "" && !s.quotas.HasPlan(req.GetPlan()) {
+		return nil, status.Error(codes.InvalidArgument, "plan is not one of the configured quota plans")
+	}
+	orgLimit, ok := quotaOverride(req.OrgRequestsPerMinute)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "org_requests_per_minute must be between 0 and 10000000")
+	}
+	tokenLimit, ok := quotaOverride(req.TokenRequestsPerMinute)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "token_requests_per_minute must be between 0 and 10000000")
+	}
+	if s.orgs != nil {
+		org, err := s.orgs.GetOrganizationByID(ctx, orgID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to load organization")
+		}
+		if org == nil {
+			return nil, status.Error(codes.NotFound, "organization not found")
+		}
+	}
+	override := &quotadomain.Override{
+		OrgID:                  orgID,
+		Plan:                   req.GetPlan(),
+		OrgRequestsPerMinute:   orgLimit,
+		TokenRequestsPerMinute: tokenLimit,
+		UpdatedBy:              userID,
+		UpdatedAt:              time.Now().UTC(),
+	}
+	if err := s.quotas.SetOverride(ctx, override); err != nil {
+		return nil, status.Error(codes.Internal, "failed to store org quota")
+	}
+	meta := map[string]interface{}{"org_id": orgID, "plan": override.Plan}
+	if orgLimit != nil {
+		meta["org_requests_per_minute"] = *orgLimit
+	}
+	if tokenLimit != nil {
+		meta["token_requests_per_minute"] = *tokenLimit
+	}
+	s.auditQuota(ctx, userID, orgID, meta)
+	return &adminv1.SetOrgQuotaResponse{Quota: s.orgQuotaToProto(ctx, orgID, override)}, nil
+}
+
+// ListBillingPlans returns the billing plans, lowest first, with the features each includes. Platform admins only.
+func (s *Server) ListBillingPlans(ctx context.Context, req *adminv1.ListBillingPlansRequest) (*adminv1.ListBillingPlansResponse, error) {
+	if s.plans == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListBillingPlans not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	out := make([]*adminv1.BillingPlan, len(billing.Plans))
+	for i, p := range billing.Plans {
+		out[i] = billingPlanToProto(p)
+	}
+	return &adminv1.ListBillingPlansResponse{Plans: out, Enforced: s.plans.Enforced()}, nil
+}
+
+// SetOrgBillingPlan assigns the org's billing plan. The new plan applies to the next gated call. Platform admins only;
+// audited as org_plan_changed.
+func (s *Server) SetOrgBillingPlan(ctx context.Context, req *adminv1.SetOrgBillingPlanRequest) (*adminv1.SetOrgBillingPlanResponse, error) {
+	if s.plans == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetOrgBillingPlan not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	orgID := req.GetOrgId()
+	if orgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	plan := organizationdomain.Plan(req.GetPlan())
+	if !plan.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "plan must be free, team or enterprise")
+	}
+	org, err := s.plans.SetPlan(ctx, orgID, plan)
+	if err != nil {
+		if errors.Is(err, billing.ErrOrgNotFound) {
+			return nil, status.Error(codes.NotFound, "organization not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to set billing plan")
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"plan": string(org.Plan)})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "org_plan_changed", "organization", string(meta))
+	}
+	return &adminv1.SetOrgBillingPlanResponse{OrgId: orgID, Plan: billingPlanToProto(org.Plan)}, nil
+}
+
+func billingPlanToProto(plan organizationdomain.Plan) *adminv1.BillingPlan {
+	features := billing.FeaturesOf(plan)
+	names := make([]string, len(features))
+	for i, f := range features {
+		names[i] = string(f)
+	}
+	return &adminv1.BillingPlan{Name: string(plan), Features: names}
+}
+
+// ExportUsage returns one page of every org's metered usage in a month, ordered by org ID, optionally as CSV for
+// billing systems. The month defaults to the previous one, the last complete month. Platform admins only.
+func (s *Server) ExportUsage(ctx context.Context, req *adminv1.ExportUsageRequest) (*adminv1.ExportUsageResponse, error) {
+	if s.usage == nil {
+		return nil, status.Error(codes.Unimplemented, "method ExportUsage not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	month := meteringdomain.MonthOf(time.Now()).AddDate(0, -1, 0)
+	if m := req.GetMonth(); m != "" {
+		parsed, err := time.Parse("2006-01", m)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "month must be YYYY-MM")
+		}
+		month = parsed
+	}
+	pageSize := req.GetPageSize()
+	if pageSize < 0 || pageSize > maxExportPageSize {
+		return nil, status.Error(codes.InvalidArgument, "page_size must be between 0 and 1000")
+	}
+	if pageSize == 0 {
+		pageSize = defaultExportPageSize
+	}
+	// Fetch one extra row to know whether there is a next page.
+	list, err := s.usage.ListUsageForMonth(ctx, month, req.GetPageToken(), pageSize+1)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load usage")
+	}
+	resp := &adminv1.ExportUsageResponse{}
+	if len(list) > int(pageSize) {
+		list = list[:pageSize]
+		resp.NextPageToken = list[len(list)-1].OrgID
+	}
+	resp.Usage = make([]*analyticsv1.OrgUsage, len(list))
+	for i, u := range list {
+		resp.Usage[i] = analyticshandler.UsageToProto(u)
+	}
+	if req.GetCsv() {
+		resp.Csv = usageCSV(resp.Usage)
+	}
+	return resp, nil
+}
+
+// usageCSV renders usage as CSV with a header row.
+func usageCSV(usage []*analyticsv1.OrgUsage) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	_ = w.Write([]string{"org_id", "month", "active_users", "logins", "mfa_challenges", "mfa_resends", "sms_messages", "api_calls"})
+	for _, u := range usage {
+		_ = w.Write([]string{
+			u.GetOrgId(), u.GetMonth(),
+			strconv.FormatInt(u.GetActiveUsers(), 10), strconv.FormatInt(u.GetLogins(), 10),
+			strconv.FormatInt(u.GetMfaChallenges(), 10), strconv.FormatInt(u.GetMfaResends(), 10),
+			strconv.FormatInt(u.GetSmsMessages(), 10), strconv.FormatInt(u.GetApiCalls(), 10),
+		})
+	}
+	w.Flush()
+	return b.String()
+}
+
+// GetLicenseStatus returns the license of the deployment, its state and the seats in use. Platform admins only.
+func (s *Server) GetLicenseStatus(ctx context.Context, req *adminv1.GetLicenseStatusRequest) (*adminv1.GetLicenseStatusResponse, error) {
+	if s.license == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetLicenseStatus not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	st, err := s.license.Status(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to count license seats")
+	}
+	out := &adminv1.LicenseStatus{State: licenseStateToProto(st.State)}
+	if l := st.License; l != nil {
+		now := time.Now()
+		out.LicenseId = l.ID
+		out.Customer = l.Customer
+		out.Seats = int32(l.Seats)
+		out.SeatsUsed = st.SeatsUsed
+		out.Features = l.Features
+		out.ExpiresAt = timestamppb.New(l.ExpiresAt)
+		out.GraceEndsAt = timestamppb.New(l.GraceEndsAt())
+		out.DaysRemaining = int32(l.DaysRemaining(now))
+		if !l.IssuedAt.IsZero() {
+			out.IssuedAt = timestamppb.New(l.IssuedAt)
+		}
+	}
+	return &adminv1.GetLicenseStatusResponse{Status: out}, nil
+}
+
+func licenseStateToProto(st license.State) adminv1.LicenseState {
+	switch st {
+	case license.StateUnlicensed:
+		return adminv1.LicenseState_LICENSE_STATE_UNLICENSED
+	case license.StateValid:
+		return adminv1.LicenseState_LICENSE_STATE_VALID
+	case license.StateExpiring:
+		return adminv1.LicenseState_LICENSE_STATE_EXPIRING
+	case license.StateGrace:
+		return adminv1.LicenseState_LICENSE_STATE_GRACE
+	case license.StateExpired:
+		return adminv1.LicenseState_LICENSE_STATE_EXPIRED
+	default:
+		return adminv1.LicenseState_LICENSE_STATE_UNSPECIFIED
+	}
+}
+
+// CreateBackup writes an encrypted backup of an org to BACKUP_DIR and returns its file name. The backup is audited
+// under the org as backup_created. Platform admins only.
+func (s *Server) CreateBackup(ctx context.Context, req *adminv1.CreateBackupRequest) (*adminv1.CreateBackupResponse, error) {
+	if s.backups == nil || s.backupDir == "" {
+		return nil, status.Error(codes.Unimplemented, "method CreateBackup not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	orgID := strings.TrimSpace(req.GetOrgId())
+	if orgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	name, snap, size, err := s.backups.CreateFile(ctx, s.backupDir, orgID, userID)
+	if errors.Is(err, backup.ErrOrgNotFound) {
+		return nil, status.Error(codes.NotFound, "organization not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create backup")
+	}
+	counts := snap.RowCounts()
+	tables := make([]*adminv1.BackupTable, len(backuprepo.Tables))
+	for i, name := range backuprepo.Tables {
+		tables[i] = &adminv1.BackupTable{Name: name, Rows: int64(counts[name])}
+	}
+	return &adminv1.CreateBackupResponse{
+		FileName:      name,
+		CreatedAt:     timestamppb.New(snap.CreatedAt),
+		SizeBytes:     int64(size),
+		SchemaVersion: int64(snap.SchemaVersion),
+		Tables:        tables,
+	}, nil
+}
+
+// MarkHoneytoken makes the user a honeytoken, or relabels one, and revokes the user's sessions. Platform admins only,
+// and not on their own account; audited as honeytoken_marked.
+func (s *Server) MarkHoneytoken(ctx context.Context, req *adminv1.MarkHoneytokenRequest) (*adminv1.MarkHoneytokenResponse, error) {
+	if s.honeytokens == nil {
+		return nil, status.Error(codes.Unimplemented, "method MarkHoneytoken not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if req.GetUserId() == userID {
+		return nil, status.Error(codes.InvalidArgument, "cannot mark your own account as a honeytoken")
+	}
+	h, err := s.honeytokens.Mark(ctx, req.GetUserId(), req.GetLabel(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, honeytoken.ErrLabelTooLong):
+			return nil, status.Error(codes.InvalidArgument, "label exceeds 200 characters")
+		case errors.Is(err, honeytoken.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to mark honeytoken")
+	}
+	s.auditHoneytoken(ctx, userID, "honeytoken_marked", map[string]string{"target_user_id": h.UserID, "label": h.Label})
+	return &adminv1.MarkHoneytokenResponse{Honeytoken: honeytokenToProto(h)}, nil
+}
+
+// UnmarkHoneytoken turns a honeytoken back into an ordinary user. Platform admins only; audited as
+// honeytoken_unmarked.
+func (s *Server) UnmarkHoneytoken(ctx context.Context, req *adminv1.UnmarkHoneytokenRequest) (*adminv1.UnmarkHoneytokenResponse, error) {
+	if s.honeytokens == nil {
+		return nil, status.Error(codes.Unimplemented, "method UnmarkHoneytoken not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if err := s.honeytokens.Unmark(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, honeytoken.ErrNotHoneytoken) {
+			return nil, status.Error(codes.NotFound, "honeytoken not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to unmark honeytoken")
+	}
+	s.auditHoneytoken(ctx, userID, "honeytoken_unmarked", map[string]string{"target_user_id": req.GetUserId()})
+	return &adminv1.UnmarkHoneytokenResponse{}, nil
+}
+
+// ListHoneytokens returns the honeytokens, most recently marked first. Platform admins only.
+func (s *Server) ListHoneytokens(ctx context.Context, req *adminv1.ListHoneytokensRequest) (*adminv1.ListHoneytokensResponse, error) {
+	if s.honeytokens == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListHoneytokens not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.honeytokens.List(ctx, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list honeytokens")
+	}
+	out := make([]*adminv1.Honeytoken, len(list))
+	for i, h := range list {
+		out[i] = honeytokenToProto(h)
+	}
+	result := &adminv1.ListHoneytokensResponse{
+		Honeytokens: out,
+		Pagination:  &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// auditHoneytoken records a honeytoken change in the caller's org, like maintenance mode changes.
+func (s *Server) auditHoneytoken(ctx context.Context, userID, action string, meta map[string]string) {
+	if s.auditLogger == nil {
+		return
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	b, _ := json.Marshal(meta)
+	s.auditLogger.LogEvent(ctx, orgID, userID, action, "honeytoken", string(b))
+}
+
+func honeytokenToProto(h *honeytokendomain.Honeytoken) *adminv1.Honeytoken {
+	out := &adminv1.Honeytoken{
+		UserId:       h.UserID,
+		Label:        h.Label,
+		CreatedBy:    h.CreatedBy,
+		CreatedAt:    timestamppb.New(h.CreatedAt),
+		TriggerCount: h.TriggerCount,
+	}
+	if h.LastTriggeredAt != nil {
+		out.LastTriggeredAt = timestamppb.New(*h.LastTriggeredAt)
+	}
+	return out
+}
+
+// MergeUsers merges a duplicate user into the primary user. Without a confirmation token it is a dry run: nothing
+// changes, and the reply carries the diff and a token valid for usermerge.ConfirmationTTL. Sent back by the same
+// admin, the token applies the merge, unless the diff has changed since. Platform admins only, and not to merge away
+// their own account; applied merges are audited as users_merged.
+func (s *Server) MergeUsers(ctx context.Context, req *adminv1.MergeUsersRequest) (*adminv1.MergeUsersResponse, error) {
+	if s.userMerger == nil {
+		return nil, status.Error(codes.Unimplemented, "method MergeUsers not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	primaryID, duplicateID := req.GetPrimaryUserId(), req.GetDuplicateUserId()
+	if primaryID == "" || duplicateID == "" {
+		return nil, status.Error(codes.InvalidArgument, "primary_user_id and duplicate_user_id required")
+	}
+	if duplicateID == userID {
+		return nil, status.Error(codes.InvalidArgument, "cannot merge away your own account")
+	}
+	now := time.Now().UTC()
+	token := strings.TrimSpace(req.GetConfirmationToken())
+	if token == "" {
+		d, err := s.userMerger.Plan(ctx, primaryID, duplicateID)
+		if err != nil {
+			return nil, mergeErr(err)
+		}
+		expiresAt := now.Add(usermerge.ConfirmationTTL)
+		return &adminv1.MergeUsersResponse{
+			Diff:                  userMergeDiffToProto(d),
+			ConfirmationToken:     s.userMerger.ConfirmationToken(d, userID, expiresAt),
+			ConfirmationExpiresAt: timestamppb.New(expiresAt),
+		}, nil
+	}
+	d, err := s.userMerger.Merge(ctx, primaryID, duplicateID, userID, token, now)
+	if err != nil {
+		return nil, mergeErr(err)
+	}
+	if s.auditLogger != nil {
+		orgID, _ := interceptors.GetOrgID(ctx)
+		b, _ := json.Marshal(map[string]interface{}{
+			"primary_user_id":          d.PrimaryUserID,
+			"duplicate_user_id":        d.DuplicateUserID,
+			"identities":               d.Identities,
+			"identities_dropped":       d.IdentitiesDropped,
+			"memberships":              d.Memberships,
+			"memberships_merged":       d.MembershipsMerged,
+			"group_memberships":        d.GroupMemberships,
+			"group_memberships_merged": d.GroupMembershipsMerged,
+			"devices":                  d.Devices,
+			"sessions":                 d.Sessions,
+			"audit_logs":               d.AuditLogs,
+		})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "users_merged", "user", string(b))
+	}
+	return &adminv1.MergeUsersResponse{Diff: userMergeDiffToProto(d), Merged: true}, nil
+}
+
+// mergeErr maps usermerge errors to gRPC status errors.
+func mergeErr(err error) error {
+	switch {
+	case errors.Is(err, usermerge.ErrSameUser):
+		return status.Error(codes.InvalidArgument, "primary and duplicate must be different users")
+	case errors.Is(err, usermerge.ErrInvalidConfirmation):
+		return status.Error(codes.InvalidArgument, "invalid or expired confirmation_token")
+	case errors.Is(err, usermerge.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, usermerge.ErrPrimaryDisabled):
+		return status.Error(codes.FailedPrecondition, "primary user is disabled")
+	case errors.Is(err, usermerge.ErrProtectedUser):
+		return status.Error(codes.FailedPrecondition, "honeytoken and break-glass users cannot be merged")
+	}
+	return status.Error(codes.Internal, "failed to merge users")
+}
+
+// ExportUserData requests an archive of a user's personal data for the calling platform admin, who downloads it with
+// UserService.DownloadDataExport and the returned token.
+func (s *Server) ExportUserData(ctx context.Context, req *adminv1.ExportUserDataRequest) (*adminv1.ExportUserDataResponse, error) {
+	if s.dataExports == nil {
+		return nil, status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+	}
+	adminID, err := rbac.RequirePlatformAdmin(ctx, s.admins())
+	if err != nil {
+		return nil, err
+	}
+	userID := strings.TrimSpace(req.GetUserId())
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	e, token, err := s.dataExports.Request(ctx, userID, adminID)
+	switch {
+	case errors.Is(err, dataexport.ErrUserNotFound):
+		return nil, status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, dataexport.ErrExportInProgress):
+		return nil, status.Error(codes.FailedPrecondition, "an export of this user is already being generated")
+	case errors.Is(err, residency.ErrRegionUnavailable):
+		return nil, status.Error(codes.FailedPrecondition, "org data region is not available")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "failed to request data export")
+	}
+	if s.auditLogger != nil {
+		orgID, _ := interceptors.GetOrgID(ctx)
+		b, _ := json.Marshal(map[string]string{"export_id": e.ID, "user_id": e.UserID})
+		s.auditLogger.LogEvent(ctx, orgID, adminID, "data_export_requested", "user", string(b))
+	}
+	return &adminv1.ExportUserDataResponse{
+		ExportId:      e.ID,
+		DownloadToken: token,
+		ExpiresAt:     timestamppb.New(e.ExpiresAt),
+	}, nil
+}
+
+func userMergeDiffToProto(d *usermergedomain.Diff) *adminv1.UserMergeDiff {
+	return &adminv1.UserMergeDiff{
+		PrimaryUserId:          d.PrimaryUserID,
+		PrimaryEmail:           d.PrimaryEmail,
+		DuplicateUserId:        d.DuplicateUserID,
+		DuplicateEmail:         d.DuplicateEmail,
+		Identities:             d.Identities,
+		IdentitiesDropped:      d.IdentitiesDropped,
+		Memberships:            d.Memberships,
+		MembershipsMerged:      d.MembershipsMerged,
+		GroupMemberships:       d.GroupMemberships,
+		GroupMembershipsMerged: d.GroupMembershipsMerged,
+		Devices:                d.Devices,
+		Sessions:               d.Sessions,
+		AuditLogs:              d.AuditLogs,
+	}
+}
+
+// quotasEnforced reports whether QUOTA_ENABLED is set; without a config watcher quotas are reported as enforced.
+func (s *Server) quotasEnforced() bool {
+	if s.config == nil {
+		return true
+	}
+	return s.config.Current().QuotaEnabled
+}
+
+// auditQuota records an org_quota_changed event in the org whose quota changed.
+func (s *Server) auditQuota(ctx context.Context, userID, orgID string, meta map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	b, _ := json.Marshal(meta)
+	s.auditLogger.LogEvent(ctx, orgID, userID, "org_quota_changed", "org_quota", string(b))
+}
+
+func (s *Server) orgQuotaToProto(ctx context.Context, orgID string, override *quotadomain.Override) *adminv1.OrgQuota {
+	limits := s.quotas.Limits(ctx, orgID)
+	usage := s.quotas.Usage(orgID)
+	out := &adminv1.OrgQuota{
+		OrgId: orgID,
+		Limits: &adminv1.QuotaPlan{
+			Name:                   limits.Plan,
+			OrgRequestsPerMinute:   int32(limits.OrgRequestsPerMinute),
+			TokenRequestsPerMinute: int32(limits.TokenRequestsPerMinute),
+		},
+		Usage: &adminv1.OrgQuotaUsage{
+			OrgRequests:   int32(usage.OrgRequests),
+			Allowed:       int64(usage.Allowed),
+			RejectedOrg:   int64(usage.RejectedOrg),
+			RejectedToken: int64(usage.RejectedToken),
+		},
+	}
+	if override != nil {
+		out.Override = &adminv1.OrgQuotaOverride{
+			Plan:                   override.Plan,
+			OrgRequestsPerMinute:   int32Ptr(override.OrgRequestsPerMinute),
+			TokenRequestsPerMinute: int32Ptr(override.TokenRequestsPerMinute),
+			UpdatedBy:              override.UpdatedBy,
+		}
+		if !override.UpdatedAt.IsZero() {
+			out.Override.UpdatedAt = timestamppb.New(override.UpdatedAt)
+		}
+	}
+	return out
+}
+
+// quotaOverride converts an optional request quota; ok is false when it is out of range.
+func quotaOverride(v *int32) (limit *int, ok bool) {
+	if v == nil {
+		return nil, true
+	}
+	if *v < 0 || *v > maxQuotaRequestsPerMinute {
+		return nil, false
+	}
+	n := int(*v)
+	return &n, true
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+// ListCircuitBreakers returns the state and counters of this instance's circuit breakers and its SMS retry queue.
+// Breakers are per instance, so each instance may report a different state. Platform admins only.
+func (s *Server) ListCircuitBreakers(ctx context.Context, req *adminv1.ListCircuitBreakersRequest) (*adminv1.ListCircuitBreakersResponse, error) {
+	if s.breakers == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListCircuitBreakers not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	resp := &adminv1.ListCircuitBreakersResponse{}
+	for _, b := range s.breakers.Snapshots() {
+		out := &adminv1.CircuitBreaker{
+			Name:                b.Name,
+			State:               breakerStateToProto(b.State),
+			ConsecutiveFailures: int32(b.ConsecutiveFailures),
+			Opens:               b.Opens,
+			Rejected:            b.Rejected,
+		}
+		if !b.Since.IsZero() {
+			out.StateSince = timestamppb.New(b.Since)
+		}
+		resp.Breakers = append(resp.Breakers, out)
+	}
+	if s.smsQueue != nil {
+		queued, dropped := s.smsQueue.Queued()
+		resp.SmsRetryQueued = int32(queued)
+		resp.SmsRetryDropped = dropped
+	}
+	return resp, nil
+}
+
+// ListSharedDevices returns the device fingerprints of unrevoked devices used by at least min_users users (default
+// SHARED_DEVICE_USER_THRESHOLD), most widely shared first. Each user gets their own device and trust on such a
+// machine, so these are the fingerprints to review or to target with a policy on input.device.shared_device.
+// Platform admins only.
+func (s *Server) ListSharedDevices(ctx context.Context, req *adminv1.ListSharedDevicesRequest) (*adminv1.ListSharedDevicesResponse, error) {
+	if s.sharing == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListSharedDevices not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins()); err != nil {
+		return nil, err
+	}
+	if req.GetMinUsers() < 0 || req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_users and limit must not be negative")
+	}
+	minUsers := int(req.GetMinUsers())
+	if minUsers == 0 {
+		minUsers = s.sharing.Threshold()
+	}
+	list, err := s.sharing.ListShared(ctx, minUsers, req.GetLimit())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list shared devices")
+	}
+	resp := &adminv1.ListSharedDevicesResponse{MinUsers: int32(minUsers), Devices: make([]*adminv1.SharedDevice, len(list))}
+	for i, f := range list {
+		resp.Devices[i] = &adminv1.SharedDevice{
+			Fingerprint: f.Fingerprint,
+			UserCount:   int32(f.Users),
+			OrgCount:    int32(f.Orgs),
+			DeviceCount: int32(f.Devices),
+			LastSeenAt:  timestamppb.New(f.LastSeenAt),
+		}
+	}
+	return resp, nil
+}
+
+func breakerStateToProto(state breaker.State) adminv1.CircuitBreakerState {
+	switch state {
+	case breaker.StateOpen:
+		return adminv1.CircuitBreakerState_CIRCUIT_BREAKER_STATE_OPEN
+	case breaker.StateHalfOpen:
+		return adminv1.CircuitBreakerState_CIRCUIT_BREAKER_STATE_HALF_OPEN
+	default:
+		return adminv1.CircuitBreakerState_CIRCUIT_BREAKER_STATE_CLOSED
+	}
+}
+
+// admins returns the platform admin checker, or nil (no caller is a platform admin) without a config watcher.
+func (s *Server) admins() rbac.PlatformAdminChecker {
+	if s.config == nil {
+		return nil
+	}
+	return s.config
+}
+
+func maintenanceToProto(state platformsettingsdomain.MaintenanceState) *adminv1.MaintenanceStatus {
+	out := &adminv1.MaintenanceStatus{
+		Mode:              adminv1.MaintenanceMode_MAINTENANCE_MODE_OFF,
+		Message:           state.Message,
+		RetryAfterSeconds: int32(state.RetryAfter / time.Second),
+		UpdatedBy:         state.UpdatedBy,
+	}
+	switch state.Mode {
+	case platformsettingsdomain.MaintenanceReadOnly:
+		out.Mode = adminv1.MaintenanceMode_MAINTENANCE_MODE_READ_ONLY
+	case platformsettingsdomain.MaintenanceFull:
+		out.Mode = adminv1.MaintenanceMode_MAINTENANCE_MODE_MAINTENANCE
+	}
+	if !state.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(state.UpdatedAt)
+	}
+	return out
+}
+
+func maintenanceModeToDomain(m adminv1.MaintenanceMode) (platformsettingsdomain.MaintenanceMode, bool) {
+	switch m {
+	case adminv1.MaintenanceMode_MAINTENANCE_MODE_OFF:
+		return platformsettingsdomain.MaintenanceOff, true
+	case adminv1.MaintenanceMode_MAINTENANCE_MODE_READ_ONLY:
+		return platformsettingsdomain.MaintenanceReadOnly, true
+	case adminv1.MaintenanceMode_MAINTENANCE_MODE_MAINTENANCE:
+		return platformsettingsdomain.MaintenanceFull, true
+	default:
+		return "", false
+	}
+}
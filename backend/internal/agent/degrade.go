@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/agent/domain"
+	"zero-trust-control-plane/backend/internal/audit"
+)
+
+const (
+	// DegradeInterval is how often silent agents are looked for.
+	DegradeInterval = time.Hour
+	// degradeBatchSize bounds the agents handled per query.
+	degradeBatchSize = 100
+)
+
+// SilentAgents finds agents that stopped reporting and marks them. Implemented by the agent repository.
+type SilentAgents interface {
+	ListSilent(ctx context.Context, before time.Time, limit int32) ([]*domain.Agent, error)
+	MarkTrustDegraded(ctx context.Context, id string, before, at time.Time) (bool, error)
+}
+
+// DeviceTrustClearer clears a device's trust. Implemented by the device repository.
+type DeviceTrustClearer interface {
+	UpdateTrustedWithExpiry(ctx context.Context, id string, trusted bool, trustedUntil *time.Time) error
+}
+
+// TrustDegradeJob clears the trust of devices whose agent has not sent a heartbeat for silentFor, and audits each as
+// agent_trust_degraded. The agent is marked so each silence degrades the device once; the next heartbeat clears the
+// mark, but the device is trusted again only after the next sign-in with MFA.
+type TrustDegradeJob struct {
+	agents      SilentAgents
+	devices     DeviceTrustClearer
+	auditLogger audit.AuditLogger
+	silentFor   time.Duration
+	now         func() time.Time
+}
+
+// NewTrustDegradeJob returns a TrustDegradeJob. auditLogger may be nil.
+func NewTrustDegradeJob(agents SilentAgents, devices DeviceTrustClearer, auditLogger audit.AuditLogger, silentFor time.Duration) *TrustDegradeJob {
+	return &TrustDegradeJob{agents: agents, devices: devices, auditLogger: auditLogger, silentFor: silentFor, now: time.Now}
+}
+
+// RunOnce degrades the trust of every device whose agent is silent and returns how many it degraded.
+func (j *TrustDegradeJob) RunOnce(ctx context.Context) (int, error) {
+	now := j.now().UTC()
+	before := now.Add(-j.silentFor)
+	degraded := 0
+	for {
+		silent, err := j.agents.ListSilent(ctx, before, degradeBatchSize)
+		if err != nil {
+			return degraded, err
+		}
+		for _, a := range silent {
+			// Marking first means a heartbeat that arrives meanwhile wins, and a failed trust update is not retried
+			// for the same silence; the device then keeps its trust until it expires.
+			ok, err := j.agents.MarkTrustDegraded(ctx, a.ID, before, now)
+			if err != nil {
+				return degraded, err
+			}
+			if !ok {
+				continue
+			}
+			if err := j.devices.UpdateTrustedWithExpiry(ctx, a.DeviceID, false, nil); err != nil {
+				log.Printf("agent: clear trust for device %s of silent agent %s: %v", a.DeviceID, a.ID, err)
+				continue
+			}
+			degraded++
+			if j.auditLogger != nil {
+				meta, _ := json.Marshal(map[string]interface{}{"agent_id": a.ID, "device_id": a.DeviceID, "last_heartbeat_at": a.LastHeartbeatAt})
+				j.auditLogger.LogEvent(ctx, a.OrgID, a.UserID, "agent_trust_degraded", "device", string(meta))
+			}
+		}
+		if len(silent) < degradeBatchSize {
+			return degraded, nil
+		}
+	}
+}
+
+// Run calls RunOnce on start and then every interval until ctx is cancelled.
+func (j *TrustDegradeJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := j.RunOnce(ctx); err != nil {
+			log.Printf("agent: trust degrade run failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
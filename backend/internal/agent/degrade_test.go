@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/agent/domain"
+)
+
+type memoryAgents struct {
+	agents []*domain.Agent
+}
+
+func (m *memoryAgents) ListSilent(ctx context.Context, before time.Time, limit int32) ([]*domain.Agent, error) {
+	var out []*domain.Agent
+	for _, a := range m.agents {
+		if a.TrustDegradedAt == nil && a.LastHeartbeatAt.Before(before) && len(out) < int(limit) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryAgents) MarkTrustDegraded(ctx context.Context, id string, before, at time.Time) (bool, error) {
+	for _, a := range m.agents {
+		if a.ID == id && a.TrustDegradedAt == nil && a.LastHeartbeatAt.Before(before) {
+			a.TrustDegradedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type recordingDevices struct {
+	untrusted []string
+}
+
+func (d *recordingDevices) UpdateTrustedWithExpiry(ctx context.Context, id string, trusted bool, trustedUntil *time.Time) error {
+	if !trusted {
+		d.untrusted = append(d.untrusted, id)
+	}
+	return nil
+}
+
+type recordingAuditLogger struct {
+	actions []string
+}
+
+func (l *recordingAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	l.actions = append(l.actions, action)
+}
+
+func TestTrustDegradeJob(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	agents := &memoryAgents{agents: []*domain.Agent{
+		{ID: "silent", OrgID: "org-1", UserID: "user-1", DeviceID: "device-1", LastHeartbeatAt: now.Add(-8 * 24 * time.Hour)},
+		{ID: "recent", OrgID: "org-1", UserID: "user-2", DeviceID: "device-2", LastHeartbeatAt: now.Add(-time.Hour)},
+	}}
+	for i := 0; i < degradeBatchSize; i++ {
+		agents.agents = append(agents.agents, &domain.Agent{ID: fmt.Sprintf("bulk-%d", i), DeviceID: fmt.Sprintf("device-bulk-%d", i), LastHeartbeatAt: now.Add(-30 * 24 * time.Hour)})
+	}
+	devices := &recordingDevices{}
+	audit := &recordingAuditLogger{}
+	job := NewTrustDegradeJob(agents, devices, audit, 7*24*time.Hour)
+	job.now = func() time.Time { return now }
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	// More silent agents than one batch: the job keeps paging until all are degraded.
+	want := degradeBatchSize + 1
+	if n != want || len(devices.untrusted) != want || devices.untrusted[0] != "device-1" {
+		t.Fatalf("degraded %d (%d devices untrusted), want %d with device-1 first", n, len(devices.untrusted), want)
+	}
+	for _, id := range devices.untrusted {
+		if id == "device-2" {
+			t.Error("device-2 reported an hour ago and must keep its trust")
+		}
+	}
+	if len(audit.actions) != want || audit.actions[0] != "agent_trust_degraded" {
+		t.Errorf("audit actions = %d, want %d agent_trust_degraded", len(audit.actions), want)
+	}
+	if n, _ := job.RunOnce(context.Background()); n != 0 {
+		t.Errorf("second RunOnce degraded %d, want 0", n)
+	}
+}
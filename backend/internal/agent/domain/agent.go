@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Limits on what agents report, so they cannot store arbitrary blobs.
+const (
+	MaxPlatformLength     = 64
+	MaxVersionLength      = 64
+	MaxPostureEntries     = 32
+	MaxPostureKeyLength   = 64
+	MaxPostureValueLength = 256
+)
+
+// Agent is a browser or endpoint agent registered on a device. A device has at most one agent; registering again
+// from the device updates it.
+type Agent struct {
+	ID              string
+	OrgID           string
+	UserID          string // user of the session that last registered the agent
+	DeviceID        string
+	Platform        string
+	Version         string
+	Posture         map[string]string // posture summary as last reported
+	PolicyVersion   int64             // org policy config version in effect on the agent; 0 if unknown
+	RegisteredAt    time.Time
+	LastHeartbeatAt time.Time
+	TrustDegradedAt *time.Time // device trust cleared because the agent stopped reporting; nil after a heartbeat
+}
+
+// IsStale reports whether the agent has not sent a heartbeat within staleAfter of now.
+func (a *Agent) IsStale(now time.Time, staleAfter time.Duration) bool {
+	return now.Sub(a.LastHeartbeatAt) > staleAfter
+}
+
+// Heartbeat is one check-in of an agent.
+type Heartbeat struct {
+	Version       string
+	Posture       map[string]string
+	PolicyVersion int64
+	At            time.Time
+}
+
+// ListFilter narrows ListByOrg. Empty fields match everything.
+type ListFilter struct {
+	UserID       string
+	SilentBefore *time.Time // only agents whose last heartbeat is before this time
+}
+
+// ValidatePosture checks the size of a reported posture summary.
+func ValidatePosture(posture map[string]string) error {
+	if len(posture) > MaxPostureEntries {
+		return fmt.Errorf("posture must have at most %d entries", MaxPostureEntries)
+	}
+	for k, v := range posture {
+		if k == "" {
+			return errors.New("posture keys must not be empty")
+		}
+		if len(k) > MaxPostureKeyLength {
+			return fmt.Errorf("posture keys must be at most %d characters", MaxPostureKeyLength)
+		}
+		if len(v) > MaxPostureValueLength {
+			return fmt.Errorf("posture values must be at most %d characters", MaxPostureValueLength)
+		}
+	}
+	return nil
+}
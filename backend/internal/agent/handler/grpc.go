@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "zero-trust-control-plane/backend/api/generated/agent/v1"
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	"zero-trust-control-plane/backend/internal/agent/domain"
+	"zero-trust-control-plane/backend/internal/agent/repository"
+	"zero-trust-control-plane/backend/internal/audit"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// SessionGetter resolves the caller's session to the device the agent runs on.
+type SessionGetter interface {
+	GetByID(ctx context.Context, id string) (*sessiondomain.Session, error)
+}
+
+// PolicyVersionGetter returns the org's current policy config version, to tell agents running an older one.
+type PolicyVersionGetter interface {
+	GetVersioned(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, int64, error)
+}
+
+// Server implements AgentService (proto server).
+// Proto: agent/agent.proto → internal/agent/handler.
+type Server struct {
+	agentv1.UnimplementedAgentServiceServer
+	repo           repository.Repository
+	membershipRepo rbac.OrgMembershipGetter
+	sessions       SessionGetter
+	policies       PolicyVersionGetter
+	auditLogger    audit.AuditLogger
+	staleAfter     time.Duration
+	now            func() time.Time
+}
+
+// NewServer returns a new Agent gRPC server. If repo is nil, all RPCs return Unimplemented; if sessions is nil,
+// RegisterAgent does. Agents without a heartbeat within staleAfter are flagged stale and are asked to send one every
+// third of it. policies and auditLogger may be nil; without policies, policy versions are not compared.
+func NewServer(repo repository.Repository, membershipRepo rbac.OrgMembershipGetter, sessions SessionGetter, policies PolicyVersionGetter, auditLogger audit.AuditLogger, staleAfter time.Duration) *Server {
+	return &Server{
+		repo:           repo,
+		membershipRepo: membershipRepo,
+		sessions:       sessions,
+		policies:       policies,
+		auditLogger:    auditLogger,
+		staleAfter:     staleAfter,
+		now:            time.Now,
+	}
+}
+
+// RegisterAgent registers the agent of the caller's device, or updates the one already registered there. Org, user
+// and device come from the access token. Registering also counts as a heartbeat.
+func (s *Server) RegisterAgent(ctx context.Context, req *agentv1.RegisterAgentRequest) (*agentv1.RegisterAgentResponse, error) {
+	if s.repo == nil || s.sessions == nil {
+		return nil, status.Error(codes.Unimplemented, "method RegisterAgent not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPlatform() == "" || len(req.GetPlatform()) > domain.MaxPlatformLength {
+		return nil, status.Errorf(codes.InvalidArgument, "platform is required and must be at most %d characters", domain.MaxPlatformLength)
+	}
+	if req.GetVersion() == "" || len(req.GetVersion()) > domain.MaxVersionLength {
+		return nil, status.Errorf(codes.InvalidArgument, "version is required and must be at most %d characters", domain.MaxVersionLength)
+	}
+	if err := domain.ValidatePosture(req.GetPosture()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sessionID, _ := interceptors.GetSessionID(ctx)
+	if sessionID == "" {
+		return nil, status.Error(codes.Unauthenticated, "session context required")
+	}
+	sess, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load session")
+	}
+	if sess == nil || sess.DeviceID == "" {
+		return nil, status.Error(codes.FailedPrecondition, "session has no device")
+	}
+	now := s.now().UTC()
+	a, err := s.repo.Register(ctx, &domain.Agent{
+		ID:              uuid.New().String(),
+		OrgID:           orgID,
+		UserID:          userID,
+		DeviceID:        sess.DeviceID,
+		Platform:        req.GetPlatform(),
+		Version:         req.GetVersion(),
+		Posture:         req.GetPosture(),
+		RegisteredAt:    now,
+		LastHeartbeatAt: now,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to register agent")
+	}
+	current, err := s.currentPolicyVersion(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"agent_id": a.ID, "device_id": a.DeviceID, "platform": a.Platform, "version": a.Version})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "agent_registered", "agent", string(meta))
+	}
+	return &agentv1.RegisterAgentResponse{
+		Agent:                    s.agentToProto(a, now, current),
+		HeartbeatIntervalSeconds: s.heartbeatIntervalSeconds(),
+	}, nil
+}
+
+// Heartbeat records a check-in of the caller's agent and tells it whether its policy config is outdated. An empty
+// version or posture keeps the last reported one.
+func (s *Server) Heartbeat(ctx context.Context, req *agentv1.HeartbeatRequest) (*agentv1.HeartbeatResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method Heartbeat not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetAgentId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "agent_id is required")
+	}
+	if len(req.GetVersion()) > domain.MaxVersionLength {
+		return nil, status.Errorf(codes.InvalidArgument, "version must be at most %d characters", domain.MaxVersionLength)
+	}
+	if err := domain.ValidatePosture(req.GetPosture()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetPolicyVersion() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "policy_version must not be negative")
+	}
+	a, err := s.repo.GetByID(ctx, req.GetAgentId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load agent")
+	}
+	// Another user's agent is reported as missing, so agent IDs cannot be probed.
+	if a == nil || a.OrgID != orgID || a.UserID != userID {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+	hb := domain.Heartbeat{Version: a.Version, Posture: a.Posture, PolicyVersion: req.GetPolicyVersion(), At: s.now().UTC()}
+	if req.GetVersion() != "" {
+		hb.Version = req.GetVersion()
+	}
+	if len(req.GetPosture()) > 0 {
+		hb.Posture = req.GetPosture()
+	}
+	ok, err := s.repo.RecordHeartbeat(ctx, a.ID, hb)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to record heartbeat")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+	current, err := s.currentPolicyVersion(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &agentv1.HeartbeatResponse{
+		PolicyVersion:            current,
+		PolicyOutdated:           policyOutdated(hb.PolicyVersion, current),
+		HeartbeatIntervalSeconds: s.heartbeatIntervalSeconds(),
+	}, nil
+}
+
+// ListAgents returns the org's agents, longest silent first, with staleness flags. Caller must be org admin or owner.
+func (s *Server) ListAgents(ctx context.Context, req *agentv1.ListAgentsRequest) (*agentv1.ListAgentsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListAgents not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	now := s.now().UTC()
+	filter := domain.ListFilter{UserID: req.GetUserId()}
+	if req.GetStaleOnly() {
+		before := now.Add(-s.staleAfter)
+		filter.SilentBefore = &before
+	}
+	list, err := s.repo.ListByOrg(ctx, orgID, filter, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list agents")
+	}
+	current, err := s.currentPolicyVersion(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	agents := make([]*agentv1.Agent, len(list))
+	for i, a := range list {
+		agents[i] = s.agentToProto(a, now, current)
+	}
+	result := &agentv1.ListAgentsResponse{
+		Agents:     agents,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// currentPolicyVersion returns the org's policy config version, or 0 when the org has no stored config or versions
+// are not compared.
+func (s *Server) currentPolicyVersion(ctx context.Context, orgID string) (int64, error) {
+	if s.policies == nil {
+		return 0, nil
+	}
+	_, version, err := s.policies.GetVersioned(ctx, orgID)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "failed to load org policy config")
+	}
+	return version, nil
+}
+
+// heartbeatIntervalSeconds asks agents to check in three times per staleness window, so one lost heartbeat does not
+// flag them stale.
+func (s *Server) heartbeatIntervalSeconds() int64 {
+	return max(int64(s.staleAfter/3/time.Second), 1)
+}
+
+// policyOutdated reports whether an agent running reported should fetch the policy config again. Orgs without a
+// stored config (current 0) run the defaults, which every agent has.
+func policyOutdated(reported, current int64) bool {
+	return current > 0 && reported != current
+}
+
+func (s *Server) agentToProto(a *domain.Agent, now time.Time, currentPolicyVersion int64) *agentv1.Agent {
+	out := &agentv1.Agent{
+		Id:              a.ID,
+		OrgId:           a.OrgID,
+		UserId:          a.UserID,
+		DeviceId:        a.DeviceID,
+		Platform:        a.Platform,
+		Version:         a.Version,
+		Posture:         a.Posture,
+		PolicyVersion:   a.PolicyVersion,
+		RegisteredAt:    timestamppb.New(a.RegisteredAt),
+		LastHeartbeatAt: timestamppb.New(a.LastHeartbeatAt),
+		Stale:           a.IsStale(now, s.staleAfter),
+		PolicyOutdated:  policyOutdated(a.PolicyVersion, currentPolicyVersion),
+	}
+	if a.TrustDegradedAt != nil {
+		out.TrustDegradedAt = timestamppb.New(*a.TrustDegradedAt)
+	}
+	return out
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	agentv1 "zero-trust-control-plane/backend/api/generated/agent/v1"
+	"zero-trust-control-plane/backend/internal/agent/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// mockAgentRepo keeps one agent per device, as the agents table does.
+type mockAgentRepo struct {
+	agents    map[string]*domain.Agent
+	gotFilter domain.ListFilter
+}
+
+func (m *mockAgentRepo) Register(ctx context.Context, a *domain.Agent) (*domain.Agent, error) {
+	for _, existing := range m.agents {
+		if existing.DeviceID == a.DeviceID {
+			a.ID, a.RegisteredAt = existing.ID, existing.RegisteredAt
+		}
+	}
+	stored := *a
+	m.agents[a.ID] = &stored
+	return &stored, nil
+}
+
+func (m *mockAgentRepo) GetByID(ctx context.Context, id string) (*domain.Agent, error) {
+	return m.agents[id], nil
+}
+
+func (m *mockAgentRepo) RecordHeartbeat(ctx context.Context, id string, hb domain.Heartbeat) (bool, error) {
+	a, ok := m.agents[id]
+	if !ok {
+		return false, nil
+	}
+	a.Version, a.Posture, a.PolicyVersion, a.LastHeartbeatAt, a.TrustDegradedAt = hb.Version, hb.Posture, hb.PolicyVersion, hb.At, nil
+	return true, nil
+}
+
+func (m *mockAgentRepo) ListByOrg(ctx context.Context, orgID string, filter domain.ListFilter, limit, offset int32) ([]*domain.Agent, error) {
+	m.gotFilter = filter
+	var out []*domain.Agent
+	for _, a := range m.agents {
+		if a.OrgID != orgID || (filter.UserID != "" && a.UserID != filter.UserID) {
+			continue
+		}
+		if filter.SilentBefore != nil && !a.LastHeartbeatAt.Before(*filter.SilentBefore) {
+			continue
+		}
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].LastHeartbeatAt.Before(out[j].LastHeartbeatAt) })
+	return out, nil
+}
+
+func (m *mockAgentRepo) ListSilent(ctx context.Context, before time.Time, limit int32) ([]*domain.Agent, error) {
+	return nil, nil
+}
+
+func (m *mockAgentRepo) MarkTrustDegraded(ctx context.Context, id string, before, at time.Time) (bool, error) {
+	return false, nil
+}
+
+type mockMembershipRepo struct {
+	memberships map[string]*membershipdomain.Membership
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	return m.memberships[userID+":"+orgID], nil
+}
+
+type mockSessionGetter struct {
+	sessions map[string]*sessiondomain.Session
+}
+
+func (m *mockSessionGetter) GetByID(ctx context.Context, id string) (*sessiondomain.Session, error) {
+	return m.sessions[id], nil
+}
+
+type staticPolicyVersion int64
+
+func (v staticPolicyVersion) GetVersioned(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, int64, error) {
+	return nil, int64(v), nil
+}
+
+type mockAuditLogger struct {
+	actions []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+}
+
+type testEnv struct {
+	srv   *Server
+	repo  *mockAgentRepo
+	audit *mockAuditLogger
+	now   time.Time
+}
+
+func newTestEnv() *testEnv {
+	env := &testEnv{
+		repo:  &mockAgentRepo{agents: map[string]*domain.Agent{}},
+		audit: &mockAuditLogger{},
+		now:   time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		"member-2:org-1": {ID: "m3", UserID: "member-2", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}}
+	sessions := &mockSessionGetter{sessions: map[string]*sessiondomain.Session{
+		"session-1": {ID: "session-1", UserID: "member-1", OrgID: "org-1", DeviceID: "device-1"},
+		"session-2": {ID: "session-2", UserID: "member-2", OrgID: "org-1"},
+	}}
+	env.srv = NewServer(env.repo, membershipRepo, sessions, staticPolicyVersion(3), env.audit, 15*time.Minute)
+	env.srv.now = func() time.Time { return env.now }
+	return env
+}
+
+func memberCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-1")
+}
+
+func adminCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-admin")
+}
+
+func TestAgentService_NilRepo(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, time.Minute)
+	if _, err := srv.RegisterAgent(memberCtx(), &agentv1.RegisterAgentRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("RegisterAgent code = %v, want Unimplemented", status.Code(err))
+	}
+	if _, err := srv.Heartbeat(memberCtx(), &agentv1.HeartbeatRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Heartbeat code = %v, want Unimplemented", status.Code(err))
+	}
+	if _, err := srv.ListAgents(adminCtx(), &agentv1.ListAgentsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ListAgents code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+func TestRegisterAgent_UsesSessionDevice(t *testing.T) {
+	env := newTestEnv()
+	resp, err := env.srv.RegisterAgent(memberCtx(), &agentv1.RegisterAgentRequest{
+		Platform: "chrome", Version: "1.4.0", Posture: map[string]string{"disk_encryption": "on"},
+	})
+	if err != nil {
+		t.Fatalf("RegisterAgent: %v", err)
+	}
+	a := resp.GetAgent()
+	if a.GetDeviceId() != "device-1" || a.GetUserId() != "member-1" || a.GetOrgId() != "org-1" || a.GetPosture()["disk_encryption"] != "on" {
+		t.Errorf("agent = %+v", a)
+	}
+	if a.GetStale() || !a.GetPolicyOutdated() {
+		t.Errorf("stale = %v, policy_outdated = %v; want false, true (policy version 0 of 3)", a.GetStale(), a.GetPolicyOutdated())
+	}
+	if resp.GetHeartbeatIntervalSeconds() != 300 {
+		t.Errorf("heartbeat_interval_seconds = %d, want 300", resp.GetHeartbeatIntervalSeconds())
+	}
+	if len(env.audit.actions) != 1 || env.audit.actions[0] != "agent_registered" {
+		t.Errorf("audit actions = %v, want [agent_registered]", env.audit.actions)
+	}
+
+	// Registering again from the device keeps the agent.
+	again, err := env.srv.RegisterAgent(memberCtx(), &agentv1.RegisterAgentRequest{Platform: "chrome", Version: "1.5.0"})
+	if err != nil {
+		t.Fatalf("RegisterAgent again: %v", err)
+	}
+	if again.GetAgent().GetId() != a.GetId() || len(env.repo.agents) != 1 {
+		t.Errorf("re-registration created a new agent: %q, want %q", again.GetAgent().GetId(), a.GetId())
+	}
+}
+
+func TestRegisterAgent_Validation(t *testing.T) {
+	env := newTestEnv()
+	tooMany := map[string]string{}
+	for i := 0; i <= domain.MaxPostureEntries; i++ {
+		tooMany[string(rune('a'+i%26))+string(rune('a'+i/26))] = "x"
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *agentv1.RegisterAgentRequest
+		want codes.Code
+	}{
+		{"missing platform", memberCtx(), &agentv1.RegisterAgentRequest{Version: "1.0"}, codes.InvalidArgument},
+		{"missing version", memberCtx(), &agentv1.RegisterAgentRequest{Platform: "chrome"}, codes.InvalidArgument},
+		{"too much posture", memberCtx(), &agentv1.RegisterAgentRequest{Platform: "chrome", Version: "1.0", Posture: tooMany}, codes.InvalidArgument},
+		{"session without device", interceptors.WithIdentity(context.Background(), "member-2", "org-1", "session-2"), &agentv1.RegisterAgentRequest{Platform: "chrome", Version: "1.0"}, codes.FailedPrecondition},
+		{"not a member", interceptors.WithIdentity(context.Background(), "stranger", "org-1", "session-x"), &agentv1.RegisterAgentRequest{Platform: "chrome", Version: "1.0"}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		if _, err := env.srv.RegisterAgent(tt.ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, status.Code(err), tt.want)
+		}
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	env := newTestEnv()
+	reg, err := env.srv.RegisterAgent(memberCtx(), &agentv1.RegisterAgentRequest{Platform: "chrome", Version: "1.4.0", Posture: map[string]string{"screen_lock": "on"}})
+	if err != nil {
+		t.Fatalf("RegisterAgent: %v", err)
+	}
+	id := reg.GetAgent().GetId()
+	degradedAt := env.now
+	env.repo.agents[id].TrustDegradedAt = &degradedAt
+
+	env.now = env.now.Add(time.Hour)
+	resp, err := env.srv.Heartbeat(memberCtx(), &agentv1.HeartbeatRequest{AgentId: id, PolicyVersion: 3})
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if resp.GetPolicyVersion() != 3 || resp.GetPolicyOutdated() {
+		t.Errorf("policy_version = %d, outdated = %v; want 3, false", resp.GetPolicyVersion(), resp.GetPolicyOutdated())
+	}
+	a := env.repo.agents[id]
+	if !a.LastHeartbeatAt.Equal(env.now) || a.TrustDegradedAt != nil {
+		t.Errorf("last heartbeat = %v, trust degraded at = %v; want %v, nil", a.LastHeartbeatAt, a.TrustDegradedAt, env.now)
+	}
+	if a.Version != "1.4.0" || a.Posture["screen_lock"] != "on" {
+		t.Errorf("empty version and posture should keep the last reported ones, got %q, %v", a.Version, a.Posture)
+	}
+
+	resp, err = env.srv.Heartbeat(memberCtx(), &agentv1.HeartbeatRequest{AgentId: id, PolicyVersion: 2})
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if !resp.GetPolicyOutdated() {
+		t.Error("policy_outdated should be true for an older policy version")
+	}
+
+	other := interceptors.WithIdentity(context.Background(), "member-2", "org-1", "session-2")
+	if _, err := env.srv.Heartbeat(other, &agentv1.HeartbeatRequest{AgentId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("another user's heartbeat code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := env.srv.Heartbeat(memberCtx(), &agentv1.HeartbeatRequest{AgentId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown agent code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestListAgents_StaleFlags(t *testing.T) {
+	env := newTestEnv()
+	env.repo.agents["fresh"] = &domain.Agent{ID: "fresh", OrgID: "org-1", UserID: "member-1", DeviceID: "device-1", PolicyVersion: 3, LastHeartbeatAt: env.now.Add(-time.Minute)}
+	env.repo.agents["silent"] = &domain.Agent{ID: "silent", OrgID: "org-1", UserID: "member-2", DeviceID: "device-2", PolicyVersion: 2, LastHeartbeatAt: env.now.Add(-time.Hour)}
+	env.repo.agents["other-org"] = &domain.Agent{ID: "other-org", OrgID: "org-2", UserID: "member-3", DeviceID: "device-3", LastHeartbeatAt: env.now}
+
+	resp, err := env.srv.ListAgents(adminCtx(), &agentv1.ListAgentsRequest{})
+	if err != nil {
+		t.Fatalf("ListAgents: %v", err)
+	}
+	agents := resp.GetAgents()
+	if len(agents) != 2 || agents[0].GetId() != "silent" || agents[1].GetId() != "fresh" {
+		t.Fatalf("agents = %v, want [silent fresh]", agents)
+	}
+	if !agents[0].GetStale() || !agents[0].GetPolicyOutdated() || agents[1].GetStale() || agents[1].GetPolicyOutdated() {
+		t.Errorf("flags = %+v", agents)
+	}
+
+	resp, err = env.srv.ListAgents(adminCtx(), &agentv1.ListAgentsRequest{StaleOnly: true})
+	if err != nil {
+		t.Fatalf("ListAgents stale_only: %v", err)
+	}
+	if len(resp.GetAgents()) != 1 || resp.GetAgents()[0].GetId() != "silent" {
+		t.Errorf("stale_only agents = %v, want [silent]", resp.GetAgents())
+	}
+	if f := env.repo.gotFilter.SilentBefore; f == nil || !f.Equal(env.now.Add(-15*time.Minute)) {
+		t.Errorf("silent before = %v, want %v", f, env.now.Add(-15*time.Minute))
+	}
+
+	if _, err := env.srv.ListAgents(memberCtx(), &agentv1.ListAgentsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member ListAgents code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := env.srv.ListAgents(adminCtx(), &agentv1.ListAgentsRequest{OrgId: "org-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("other org ListAgents code = %v, want PermissionDenied", status.Code(err))
+	}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/agent/domain"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an agent repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Register stores the agent, or updates the agent already registered on its device, and returns the stored agent.
+func (r *PostgresRepository) Register(ctx context.Context, a *domain.Agent) (*domain.Agent, error) {
+	posture, err := marshalPosture(a.Posture)
+	if err != nil {
+		return nil, err
+	}
+	row, err := r.queries.UpsertAgent(ctx, gen.UpsertAgentParams{
+		ID:           a.ID,
+		OrgID:        a.OrgID,
+		UserID:       a.UserID,
+		DeviceID:     a.DeviceID,
+		Platform:     a.Platform,
+		Version:      a.Version,
+		PostureJson:  posture,
+		RegisteredAt: a.RegisteredAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return genAgentToDomain(&row), nil
+}
+
+// GetByID returns the agent, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Agent, error) {
+	row, err := r.queries.GetAgent(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genAgentToDomain(&row), nil
+}
+
+// RecordHeartbeat stores the check-in and clears TrustDegradedAt. Returns false if the agent does not exist.
+func (r *PostgresRepository) RecordHeartbeat(ctx context.Context, id string, hb domain.Heartbeat) (bool, error) {
+	posture, err := marshalPosture(hb.Posture)
+	if err != nil {
+		return false, err
+	}
+	n, err := r.queries.RecordAgentHeartbeat(ctx, gen.RecordAgentHeartbeatParams{
+		ID:              id,
+		Version:         hb.Version,
+		PostureJson:     posture,
+		PolicyVersion:   hb.PolicyVersion,
+		LastHeartbeatAt: hb.At,
+	})
+	return n > 0, err
+}
+
+// ListByOrg returns the org's agents matching filter, longest silent first, paginated by limit and offset.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, filter domain.ListFilter, limit, offset int32) ([]*domain.Agent, error) {
+	params := gen.ListAgentsByOrgParams{
+		OrgID:        orgID,
+		Limit:        limit,
+		Offset:       offset,
+		FilterUserID: sql.NullString{String: filter.UserID, Valid: filter.UserID != ""},
+	}
+	if filter.SilentBefore != nil {
+		params.SilentBefore = sql.NullTime{Time: *filter.SilentBefore, Valid: true}
+	}
+	rows, err := r.queries.ListAgentsByOrg(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return genAgentsToDomain(rows), nil
+}
+
+// ListSilent returns agents without a heartbeat since before whose device trust has not been degraded yet, longest
+// silent first.
+func (r *PostgresRepository) ListSilent(ctx context.Context, before time.Time, limit int32) ([]*domain.Agent, error) {
+	rows, err := r.queries.ListSilentAgents(ctx, gen.ListSilentAgentsParams{LastHeartbeatAt: before, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return genAgentsToDomain(rows), nil
+}
+
+// MarkTrustDegraded sets TrustDegradedAt to at if the agent is still silent since before and not yet marked.
+func (r *PostgresRepository) MarkTrustDegraded(ctx context.Context, id string, before, at time.Time) (bool, error) {
+	n, err := r.queries.MarkAgentTrustDegraded(ctx, gen.MarkAgentTrustDegradedParams{
+		DegradedAt:   sql.NullTime{Time: at, Valid: true},
+		ID:           id,
+		SilentBefore: before,
+	})
+	return n > 0, err
+}
+
+func marshalPosture(posture map[string]string) (string, error) {
+	if len(posture) == 0 {
+		return "{}", nil
+	}
+	raw, err := json.Marshal(posture)
+	return string(raw), err
+}
+
+func genAgentsToDomain(rows []gen.Agent) []*domain.Agent {
+	out := make([]*domain.Agent, len(rows))
+	for i := range rows {
+		out[i] = genAgentToDomain(&rows[i])
+	}
+	return out
+}
+
+func genAgentToDomain(a *gen.Agent) *domain.Agent {
+	out := &domain.Agent{
+		ID:              a.ID,
+		OrgID:           a.OrgID,
+		UserID:          a.UserID,
+		DeviceID:        a.DeviceID,
+		Platform:        a.Platform,
+		Version:         a.Version,
+		PolicyVersion:   a.PolicyVersion,
+		RegisteredAt:    a.RegisteredAt,
+		LastHeartbeatAt: a.LastHeartbeatAt,
+	}
+	// Stored by this package; an unreadable posture is reported as empty rather than failing the read.
+	_ = json.Unmarshal([]byte(a.PostureJson), &out.Posture)
+	if a.TrustDegradedAt.Valid {
+		t := a.TrustDegradedAt.Time
+		out.TrustDegradedAt = &t
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/agent/domain"
+)
+
+// Repository persists agents and their heartbeats.
+type Repository interface {
+	// Register stores the agent, or updates the agent already registered on its device (keeping that agent's ID and
+	// registered time), and returns the stored agent. The agent must have ID set.
+	Register(ctx context.Context, a *domain.Agent) (*domain.Agent, error)
+	// GetByID returns the agent, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.Agent, error)
+	// RecordHeartbeat stores the check-in and clears TrustDegradedAt. Returns false if the agent does not exist.
+	RecordHeartbeat(ctx context.Context, id string, hb domain.Heartbeat) (bool, error)
+	// ListByOrg returns the org's agents matching filter, longest silent first.
+	ListByOrg(ctx context.Context, orgID string, filter domain.ListFilter, limit, offset int32) ([]*domain.Agent, error)
+	// ListSilent returns agents without a heartbeat since before whose device trust has not been degraded yet.
+	ListSilent(ctx context.Context, before time.Time, limit int32) ([]*domain.Agent, error)
+	// MarkTrustDegraded sets TrustDegradedAt to at if the agent is still silent since before and not yet marked.
+	// Returns false otherwise.
+	MarkTrustDegraded(ctx context.Context, id string, before, at time.Time) (bool, error)
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// DailyLoginStats holds one org's login counters for one UTC day, as rolled up from audit logs.
+type DailyLoginStats struct {
+	Day             time.Time
+	LoginSuccesses  int64 // password checks that passed (with or without MFA)
+	LoginFailures   int64
+	MFAChallenges   int64 // OTP challenges sent
+	SessionsCreated int64 // completed sign-ins
+}
+
+// FailureRate returns failures / (successes + failures), or 0 when there were no attempts.
+func (s DailyLoginStats) FailureRate() float64 {
+	attempts := s.LoginSuccesses + s.LoginFailures
+	if attempts == 0 {
+		return 0
+	}
+	return float64(s.LoginFailures) / float64(attempts)
+}
+
+// MFAChallengeRate returns challenges / successful password checks, or 0 when there were none.
+func (s DailyLoginStats) MFAChallengeRate() float64 {
+	if s.LoginSuccesses == 0 {
+		return 0
+	}
+	return float64(s.MFAChallenges) / float64(s.LoginSuccesses)
+}
+
+// Add accumulates other's counters into s (used for range totals).
+func (s *DailyLoginStats) Add(other DailyLoginStats) {
+	s.LoginSuccesses += other.LoginSuccesses
+	s.LoginFailures += other.LoginFailures
+	s.MFAChallenges += other.MFAChallenges
+	s.SessionsCreated += other.SessionsCreated
+}
+
+// DeviceSessionCount is the number of sessions created from one device over a date range.
+type DeviceSessionCount struct {
+	DeviceID string
+	UserID   string
+	Sessions int64
+}
+
+// CountrySessionCount is the number of sessions created from one country over a date range.
+// Country is an ISO code, or "unknown" when no geo header was present at sign-in.
+type CountrySessionCount struct {
+	Country  string
+	Sessions int64
+}
+
+// PolicyViolationCount is the number of blocked actions of one kind reported by agents over a date range.
+type PolicyViolationCount struct {
+	Action     string
+	Violations int64
+}
+
+// BlockedDomainCount is the number of URLs on one domain denied by CheckUrlAccess over a date range.
+type BlockedDomainCount struct {
+	Domain string
+	Blocks int64
+}
+
+// UserViolationCount is the number of policy violations reported for one user over a date range.
+type UserViolationCount struct {
+	UserID     string
+	Violations int64
+}
+
+// DailyPolicyTrend holds one org's URL denials and reported policy violations for one UTC day.
+type DailyPolicyTrend struct {
+	Day        time.Time
+	URLBlocks  int64
+	Violations int64
+}
@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	analyticsv1 "zero-trust-control-plane/backend/api/generated/analytics/v1"
+	"zero-trust-control-plane/backend/internal/analytics/domain"
+	"zero-trust-control-plane/backend/internal/analytics/repository"
+	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	meteringdomain "zero-trust-control-plane/backend/internal/metering/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/residency"
+)
+
+const (
+	dateLayout       = "2006-01-02"
+	defaultRangeDays = 30
+	maxRangeDays     = 366
+	defaultTopLimit  = 10
+	maxTopLimit      = 100
+
+	monthLayout        = "2006-01"
+	defaultRangeMonths = 12
+	maxRangeMonths     = 36
+)
+
+// UsageReader reads an org's metered monthly usage (the metering repository).
+type UsageReader interface {
+	ListMonthlyUsage(ctx context.Context, orgID string, from, to time.Time) ([]*meteringdomain.Usage, error)
+}
+
+// Server implements AnalyticsService (proto server) for org login, policy violation and policy effectiveness dashboards.
+// Proto: analytics/analytics.proto → internal/analytics/handler.
+type Server struct {
+	analyticsv1.UnimplementedAnalyticsServiceServer
+	repo           repository.Repository
+	membershipRepo membershiprepo.Repository
+	usage          UsageReader
+	now            func() time.Time
+}
+
+// NewServer returns a new Analytics gRPC server. If repo is nil, the login and policy violation RPCs return
+// Unimplemented; if usage is nil, GetUsage returns Unimplemented. All RPCs require the caller to be org admin or owner.
+func NewServer(repo repository.Repository, membershipRepo membershiprepo.Repository, usage UsageReader) *Server {
+	return &Server{repo: repo, membershipRepo: membershipRepo, usage: usage, now: time.Now}
+}
+
+// GetLoginStats returns per-day login counters and range totals for the caller's org.
+func (s *Server) GetLoginStats(ctx context.Context, req *analyticsv1.GetLoginStatsRequest) (*analyticsv1.GetLoginStatsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetLoginStats not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListDailyLoginStats(ctx, orgID, from, to)
+	if err != nil {
+		return nil, loadError(err, "failed to load login stats")
+	}
+	var totals domain.DailyLoginStats
+	days := make([]*analyticsv1.LoginStats, len(list))
+	for i, d := range list {
+		days[i] = loginStatsToProto(*d, d.Day.UTC().Format(dateLayout))
+		totals.Add(*d)
+	}
+	return &analyticsv1.GetLoginStatsResponse{Days: days, Totals: loginStatsToProto(totals, "")}, nil
+}
+
+// ListTopDevices returns the devices with the most sessions in the caller's org.
+func (s *Server) ListTopDevices(ctx context.Context, req *analyticsv1.ListTopDevicesRequest) (*analyticsv1.ListTopDevicesResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListTopDevices not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListTopDevices(ctx, orgID, from, to, topLimit(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load top devices")
+	}
+	devices := make([]*analyticsv1.DeviceSessionCount, len(list))
+	for i, d := range list {
+		devices[i] = &analyticsv1.DeviceSessionCount{DeviceId: d.DeviceID, UserId: d.UserID, Sessions: d.Sessions}
+	}
+	return &analyticsv1.ListTopDevicesResponse{Devices: devices}, nil
+}
+
+// ListSessionsByCountry returns session counts per country in the caller's org.
+func (s *Server) ListSessionsByCountry(ctx context.Context, req *analyticsv1.ListSessionsByCountryRequest) (*analyticsv1.ListSessionsByCountryResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListSessionsByCountry not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListSessionsByCountry(ctx, orgID, from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load sessions by country")
+	}
+	countries := make([]*analyticsv1.CountrySessionCount, len(list))
+	for i, c := range list {
+		countries[i] = &analyticsv1.CountrySessionCount{Country: c.Country, Sessions: c.Sessions}
+	}
+	return &analyticsv1.ListSessionsByCountryResponse{Countries: countries}, nil
+}
+
+// GetPolicyViolationStats returns agent-reported policy violation counts per action in the caller's org.
+func (s *Server) GetPolicyViolationStats(ctx context.Context, req *analyticsv1.GetPolicyViolationStatsRequest) (*analyticsv1.GetPolicyViolationStatsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetPolicyViolationStats not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListPolicyViolationsByAction(ctx, orgID, from, to)
+	if err != nil {
+		return nil, loadError(err, "failed to load policy violation stats")
+	}
+	resp := &analyticsv1.GetPolicyViolationStatsResponse{Actions: make([]*analyticsv1.PolicyViolationCount, len(list))}
+	for i, c := range list {
+		resp.Actions[i] = &analyticsv1.PolicyViolationCount{Action: c.Action, Violations: c.Violations}
+		resp.Total += c.Violations
+	}
+	return resp, nil
+}
+
+// ListTopBlockedDomains returns the domains with the most URLs denied by CheckUrlAccess in the caller's org.
+func (s *Server) ListTopBlockedDomains(ctx context.Context, req *analyticsv1.ListTopBlockedDomainsRequest) (*analyticsv1.ListTopBlockedDomainsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListTopBlockedDomains not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListTopBlockedDomains(ctx, orgID, from, to, topLimit(req.GetLimit()))
+	if err != nil {
+		return nil, loadError(err, "failed to load blocked domains")
+	}
+	resp := &analyticsv1.ListTopBlockedDomainsResponse{Domains: make([]*analyticsv1.BlockedDomainCount, len(list))}
+	rows := make([][]string, len(list))
+	for i, d := range list {
+		resp.Domains[i] = &analyticsv1.BlockedDomainCount{Domain: d.Domain, Blocks: d.Blocks}
+		rows[i] = []string{d.Domain, strconv.FormatInt(d.Blocks, 10)}
+	}
+	if req.GetCsv() {
+		resp.Csv = toCSV([]string{"domain", "blocks"}, rows)
+	}
+	return resp, nil
+}
+
+// ListTopViolators returns the users with the most reported policy violations in the caller's org.
+func (s *Server) ListTopViolators(ctx context.Context, req *analyticsv1.ListTopViolatorsRequest) (*analyticsv1.ListTopViolatorsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListTopViolators not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListTopViolators(ctx, orgID, from, to, topLimit(req.GetLimit()))
+	if err != nil {
+		return nil, loadError(err, "failed to load top violators")
+	}
+	resp := &analyticsv1.ListTopViolatorsResponse{Users: make([]*analyticsv1.UserViolationCount, len(list))}
+	rows := make([][]string, len(list))
+	for i, u := range list {
+		resp.Users[i] = &analyticsv1.UserViolationCount{UserId: u.UserID, Violations: u.Violations}
+		rows[i] = []string{u.UserID, strconv.FormatInt(u.Violations, 10)}
+	}
+	if req.GetCsv() {
+		resp.Csv = toCSV([]string{"user_id", "violations"}, rows)
+	}
+	return resp, nil
+}
+
+// GetPolicyTrend returns URL denials and reported policy violations per day, with range totals, in the caller's org.
+func (s *Server) GetPolicyTrend(ctx context.Context, req *analyticsv1.GetPolicyTrendRequest) (*analyticsv1.GetPolicyTrendResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetPolicyTrend not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListDailyPolicyTrend(ctx, orgID, from, to)
+	if err != nil {
+		return nil, loadError(err, "failed to load policy trend")
+	}
+	resp := &analyticsv1.GetPolicyTrendResponse{Days: make([]*analyticsv1.PolicyTrendDay, len(list)), Totals: &analyticsv1.PolicyTrendDay{}}
+	rows := make([][]string, len(list))
+	for i, d := range list {
+		date := d.Day.UTC().Format(dateLayout)
+		resp.Days[i] = &analyticsv1.PolicyTrendDay{Date: date, UrlBlocks: d.URLBlocks, Violations: d.Violations}
+		resp.Totals.UrlBlocks += d.URLBlocks
+		resp.Totals.Violations += d.Violations
+		rows[i] = []string{date, strconv.FormatInt(d.URLBlocks, 10), strconv.FormatInt(d.Violations, 10)}
+	}
+	if req.GetCsv() {
+		resp.Csv = toCSV([]string{"date", "url_blocks", "violations"}, rows)
+	}
+	return resp, nil
+}
+
+// GetUsage returns the caller's org's metered usage per month, oldest first.
+func (s *Server) GetUsage(ctx context.Context, req *analyticsv1.GetUsageRequest) (*analyticsv1.GetUsageResponse, error) {
+	if s.usage == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetUsage not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := s.parseMonthRange(req.GetFromMonth(), req.GetToMonth())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.usage.ListMonthlyUsage(ctx, orgID, from, to)
+	if err != nil {
+		return nil, loadError(err, "failed to load usage")
+	}
+	months := make([]*analyticsv1.OrgUsage, len(list))
+	for i, u := range list {
+		months[i] = UsageToProto(u)
+	}
+	return &analyticsv1.GetUsageResponse{Months: months}, nil
+}
+
+// UsageToProto converts metered usage to its proto message (also used by AdminService ExportUsage).
+func UsageToProto(u *meteringdomain.Usage) *analyticsv1.OrgUsage {
+	out := &analyticsv1.OrgUsage{
+		OrgId:         u.OrgID,
+		Month:         u.Month.UTC().Format(monthLayout),
+		ActiveUsers:   u.ActiveUsers,
+		Logins:        u.Logins,
+		MfaChallenges: u.MFAChallenges,
+		MfaResends:    u.MFAResends,
+		SmsMessages:   u.SMSMessages(),
+		ApiCalls:      u.APICalls,
+	}
+	if !u.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(u.UpdatedAt)
+	}
+	return out
+}
+
+// parseMonthRange parses YYYY-MM bounds. Empty to defaults to the current month (UTC); empty from defaults to
+// defaultRangeMonths ending at to.
+func (s *Server) parseMonthRange(fromStr, toStr string) (from, to time.Time, err error) {
+	to = meteringdomain.MonthOf(s.now())
+	if toStr != "" {
+		if to, err = time.Parse(monthLayout, toStr); err != nil {
+			return from, to, status.Error(codes.InvalidArgument, "to_month must be YYYY-MM")
+		}
+	}
+	from = to.AddDate(0, -(defaultRangeMonths - 1), 0)
+	if fromStr != "" {
+		if from, err = time.Parse(monthLayout, fromStr); err != nil {
+			return from, to, status.Error(codes.InvalidArgument, "from_month must be YYYY-MM")
+		}
+	}
+	if from.After(to) {
+		return from, to, status.Error(codes.InvalidArgument, "from_month must not be after to_month")
+	}
+	if !from.AddDate(0, maxRangeMonths, 0).After(to) {
+		return from, to, status.Error(codes.InvalidArgument, "month range must not exceed 36 months")
+	}
+	return from, to, nil
+}
+
+// parseRange parses YYYY-MM-DD bounds. Empty to defaults to today (UTC); empty from defaults to
+// defaultRangeDays ending at to.
+func (s *Server) parseRange(fromStr, toStr string) (from, to time.Time, err error) {
+	now := s.now().UTC()
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if toStr != "" {
+		if to, err = time.Parse(dateLayout, toStr); err != nil {
+			return from, to, status.Error(codes.InvalidArgument, "to_date must be YYYY-MM-DD")
+		}
+	}
+	from = to.AddDate(0, 0, -(defaultRangeDays - 1))
+	if fromStr != "" {
+		if from, err = time.Parse(dateLayout, fromStr); err != nil {
+			return from, to, status.Error(codes.InvalidArgument, "from_date must be YYYY-MM-DD")
+		}
+	}
+	if from.After(to) {
+		return from, to, status.Error(codes.InvalidArgument, "from_date must not be after to_date")
+	}
+	if to.Sub(from) >= maxRangeDays*24*time.Hour {
+		return from, to, status.Error(codes.InvalidArgument, "date range must not exceed 366 days")
+	}
+	return from, to, nil
+}
+
+// topLimit returns limit, or defaultTopLimit when it is not positive, capped at maxTopLimit.
+func topLimit(limit int32) int32 {
+	if limit <= 0 {
+		return defaultTopLimit
+	}
+	if limit > maxTopLimit {
+		return maxTopLimit
+	}
+	return limit
+}
+
+// toCSV renders rows as CSV with a header row.
+func toCSV(header []string, rows [][]string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	_ = w.Write(header)
+	_ = w.WriteAll(rows)
+	return b.String()
+}
+
+func loginStatsToProto(d domain.DailyLoginStats, date string) *analyticsv1.LoginStats {
+	return &analyticsv1.LoginStats{
+		Date:             date,
+		LoginSuccesses:   d.LoginSuccesses,
+		LoginFailures:    d.LoginFailures,
+		MfaChallenges:    d.MFAChallenges,
+		SessionsCreated:  d.SessionsCreated,
+		FailureRate:      d.FailureRate(),
+		MfaChallengeRate: d.MFAChallengeRate(),
+	}
+}
+
+// loadError maps a repository error to a gRPC status: FailedPrecondition when the org's data region has no database
+// in this deployment (see residency.ErrRegionUnavailable), Internal with msg otherwise.
+func loadError(err error, msg string) error {
+	if errors.Is(err, residency.ErrRegionUnavailable) {
+		return status.Error(codes.FailedPrecondition, "org data region is not available")
+	}
+	return status.Error(codes.Internal, msg)
+}
@@ -0,0 +1,387 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	analyticsv1 "zero-trust-control-plane/backend/api/generated/analytics/v1"
+	"zero-trust-control-plane/backend/internal/analytics/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	meteringdomain "zero-trust-control-plane/backend/internal/metering/domain"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type mockAnalyticsRepo struct {
+	daily     []*domain.DailyLoginStats
+	devices   []*domain.DeviceSessionCount
+	countries []*domain.CountrySessionCount
+	actions   []*domain.PolicyViolationCount
+	blocked   []*domain.BlockedDomainCount
+	violators []*domain.UserViolationCount
+	trend     []*domain.DailyPolicyTrend
+	usage     []*meteringdomain.Usage
+	err       error
+
+	gotOrgID string
+	gotFrom  time.Time
+	gotTo    time.Time
+	gotLimit int32
+}
+
+func (m *mockAnalyticsRepo) ListMonthlyUsage(ctx context.Context, orgID string, from, to time.Time) ([]*meteringdomain.Usage, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo = orgID, from, to
+	return m.usage, m.err
+}
+
+func (m *mockAnalyticsRepo) RollupDay(ctx context.Context, day time.Time) error {
+	return nil
+}
+
+func (m *mockAnalyticsRepo) ListDailyLoginStats(ctx context.Context, orgID string, from, to time.Time) ([]*domain.DailyLoginStats, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo = orgID, from, to
+	return m.daily, m.err
+}
+
+func (m *mockAnalyticsRepo) ListTopDevices(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.DeviceSessionCount, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo, m.gotLimit = orgID, from, to, limit
+	return m.devices, nil
+}
+
+func (m *mockAnalyticsRepo) ListSessionsByCountry(ctx context.Context, orgID string, from, to time.Time) ([]*domain.CountrySessionCount, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo = orgID, from, to
+	return m.countries, nil
+}
+
+func (m *mockAnalyticsRepo) ListPolicyViolationsByAction(ctx context.Context, orgID string, from, to time.Time) ([]*domain.PolicyViolationCount, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo = orgID, from, to
+	return m.actions, nil
+}
+
+func (m *mockAnalyticsRepo) ListTopBlockedDomains(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.BlockedDomainCount, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo, m.gotLimit = orgID, from, to, limit
+	return m.blocked, nil
+}
+
+func (m *mockAnalyticsRepo) ListTopViolators(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.UserViolationCount, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo, m.gotLimit = orgID, from, to, limit
+	return m.violators, nil
+}
+
+func (m *mockAnalyticsRepo) ListDailyPolicyTrend(ctx context.Context, orgID string, from, to time.Time) ([]*domain.DailyPolicyTrend, error) {
+	m.gotOrgID, m.gotFrom, m.gotTo = orgID, from, to
+	return m.trend, nil
+}
+
+// mockMembershipRepo implements membershiprepo.Repository for analytics handler tests.
+type mockMembershipRepo struct {
+	memberships map[string]*membershipdomain.Membership
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	return m.memberships[userID+":"+orgID], nil
+}
+
+func (m *mockMembershipRepo) GetMembershipByID(ctx context.Context, id string) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) ListMembershipsByOrg(ctx context.Context, orgID string) ([]*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) CreateMembership(ctx context.Context, mem *membershipdomain.Membership) error {
+	return nil
+}
+
+func (m *mockMembershipRepo) DeleteByUserAndOrg(ctx context.Context, userID, orgID string) error {
+	return nil
+}
+
+func (m *mockMembershipRepo) UpdateRole(ctx context.Context, userID, orgID string, role membershipdomain.Role) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) CountOwnersByOrg(ctx context.Context, orgID string) (int64, error) {
+	return 0, nil
+}
+
+func newTestServer(repo *mockAnalyticsRepo) *Server {
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}}
+	srv := NewServer(repo, membershipRepo, repo)
+	srv.now = func() time.Time { return time.Date(2026, 3, 15, 13, 0, 0, 0, time.UTC) }
+	return srv
+}
+
+func adminCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+}
+
+func TestGetLoginStats_DaysAndTotals(t *testing.T) {
+	repo := &mockAnalyticsRepo{daily: []*domain.DailyLoginStats{
+		{Day: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), LoginSuccesses: 8, LoginFailures: 2, MFAChallenges: 4, SessionsCreated: 7},
+		{Day: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), LoginSuccesses: 2, LoginFailures: 8, MFAChallenges: 1, SessionsCreated: 2},
+	}}
+	srv := newTestServer(repo)
+	resp, err := srv.GetLoginStats(adminCtx(), &analyticsv1.GetLoginStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetLoginStats: %v", err)
+	}
+	if repo.gotOrgID != "org-1" {
+		t.Errorf("org = %q, want org-1", repo.gotOrgID)
+	}
+	if want := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC); !repo.gotFrom.Equal(want) {
+		t.Errorf("default from = %v, want %v", repo.gotFrom, want)
+	}
+	if want := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC); !repo.gotTo.Equal(want) {
+		t.Errorf("default to = %v, want %v", repo.gotTo, want)
+	}
+	if len(resp.Days) != 2 || resp.Days[0].Date != "2026-03-14" {
+		t.Fatalf("days = %v", resp.Days)
+	}
+	if resp.Days[0].FailureRate != 0.2 || resp.Days[0].MfaChallengeRate != 0.5 {
+		t.Errorf("day rates = %v/%v, want 0.2/0.5", resp.Days[0].FailureRate, resp.Days[0].MfaChallengeRate)
+	}
+	tot := resp.Totals
+	if tot.LoginSuccesses != 10 || tot.LoginFailures != 10 || tot.MfaChallenges != 5 || tot.SessionsCreated != 9 {
+		t.Errorf("totals = %+v", tot)
+	}
+	if tot.FailureRate != 0.5 || tot.MfaChallengeRate != 0.5 {
+		t.Errorf("total rates = %v/%v, want 0.5/0.5", tot.FailureRate, tot.MfaChallengeRate)
+	}
+}
+
+func TestGetLoginStats_NoActivity(t *testing.T) {
+	srv := newTestServer(&mockAnalyticsRepo{})
+	resp, err := srv.GetLoginStats(adminCtx(), &analyticsv1.GetLoginStatsRequest{FromDate: "2026-03-01", ToDate: "2026-03-02"})
+	if err != nil {
+		t.Fatalf("GetLoginStats: %v", err)
+	}
+	if len(resp.Days) != 0 || resp.Totals.FailureRate != 0 || resp.Totals.MfaChallengeRate != 0 {
+		t.Errorf("response = %+v, want empty with zero rates", resp)
+	}
+}
+
+func TestGetLoginStats_InvalidRange(t *testing.T) {
+	srv := newTestServer(&mockAnalyticsRepo{})
+	for _, req := range []*analyticsv1.GetLoginStatsRequest{
+		{FromDate: "03/01/2026"},
+		{ToDate: "2026-13-01"},
+		{FromDate: "2026-03-10", ToDate: "2026-03-01"},
+		{FromDate: "2024-01-01", ToDate: "2026-03-01"},
+	} {
+		_, err := srv.GetLoginStats(adminCtx(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%+v: code = %v, want InvalidArgument", req, status.Code(err))
+		}
+	}
+}
+
+func TestListTopDevices_Limit(t *testing.T) {
+	repo := &mockAnalyticsRepo{devices: []*domain.DeviceSessionCount{{DeviceID: "d1", UserID: "u1", Sessions: 5}}}
+	srv := newTestServer(repo)
+	resp, err := srv.ListTopDevices(adminCtx(), &analyticsv1.ListTopDevicesRequest{})
+	if err != nil {
+		t.Fatalf("ListTopDevices: %v", err)
+	}
+	if repo.gotLimit != defaultTopLimit {
+		t.Errorf("limit = %d, want %d", repo.gotLimit, defaultTopLimit)
+	}
+	if len(resp.Devices) != 1 || resp.Devices[0].DeviceId != "d1" || resp.Devices[0].Sessions != 5 {
+		t.Errorf("devices = %v", resp.Devices)
+	}
+	if _, err := srv.ListTopDevices(adminCtx(), &analyticsv1.ListTopDevicesRequest{Limit: 1000}); err != nil {
+		t.Fatalf("ListTopDevices: %v", err)
+	}
+	if repo.gotLimit != maxTopLimit {
+		t.Errorf("limit = %d, want capped %d", repo.gotLimit, maxTopLimit)
+	}
+}
+
+func TestListSessionsByCountry(t *testing.T) {
+	repo := &mockAnalyticsRepo{countries: []*domain.CountrySessionCount{{Country: "DE", Sessions: 3}, {Country: "unknown", Sessions: 1}}}
+	srv := newTestServer(repo)
+	resp, err := srv.ListSessionsByCountry(adminCtx(), &analyticsv1.ListSessionsByCountryRequest{FromDate: "2026-03-01", ToDate: "2026-03-15"})
+	if err != nil {
+		t.Fatalf("ListSessionsByCountry: %v", err)
+	}
+	if len(resp.Countries) != 2 || resp.Countries[0].Country != "DE" {
+		t.Errorf("countries = %v", resp.Countries)
+	}
+}
+
+func TestGetPolicyViolationStats_Total(t *testing.T) {
+	repo := &mockAnalyticsRepo{actions: []*domain.PolicyViolationCount{{Action: "download", Violations: 7}, {Action: "copy_paste", Violations: 2}}}
+	srv := newTestServer(repo)
+	resp, err := srv.GetPolicyViolationStats(adminCtx(), &analyticsv1.GetPolicyViolationStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetPolicyViolationStats: %v", err)
+	}
+	if repo.gotOrgID != "org-1" {
+		t.Errorf("org = %q, want org-1", repo.gotOrgID)
+	}
+	if len(resp.Actions) != 2 || resp.Actions[0].Action != "download" || resp.Actions[0].Violations != 7 {
+		t.Errorf("actions = %v", resp.Actions)
+	}
+	if resp.Total != 9 {
+		t.Errorf("total = %d, want 9", resp.Total)
+	}
+}
+
+func TestListTopBlockedDomains_CSV(t *testing.T) {
+	repo := &mockAnalyticsRepo{blocked: []*domain.BlockedDomainCount{{Domain: "pastebin.com", Blocks: 12}, {Domain: "dropbox.com", Blocks: 3}}}
+	srv := newTestServer(repo)
+	resp, err := srv.ListTopBlockedDomains(adminCtx(), &analyticsv1.ListTopBlockedDomainsRequest{Limit: 500})
+	if err != nil {
+		t.Fatalf("ListTopBlockedDomains: %v", err)
+	}
+	if repo.gotLimit != maxTopLimit {
+		t.Errorf("limit = %d, want %d", repo.gotLimit, maxTopLimit)
+	}
+	if len(resp.Domains) != 2 || resp.Domains[0].Domain != "pastebin.com" || resp.Domains[0].Blocks != 12 {
+		t.Errorf("domains = %v", resp.Domains)
+	}
+	if resp.Csv != "" {
+		t.Errorf("csv = %q without csv requested", resp.Csv)
+	}
+	resp, err = srv.ListTopBlockedDomains(adminCtx(), &analyticsv1.ListTopBlockedDomainsRequest{Csv: true})
+	if err != nil {
+		t.Fatalf("ListTopBlockedDomains csv: %v", err)
+	}
+	if want := "domain,blocks\npastebin.com,12\ndropbox.com,3\n"; resp.Csv != want {
+		t.Errorf("csv = %q, want %q", resp.Csv, want)
+	}
+}
+
+func TestListTopViolators(t *testing.T) {
+	repo := &mockAnalyticsRepo{violators: []*domain.UserViolationCount{{UserID: "user-2", Violations: 9}}}
+	srv := newTestServer(repo)
+	resp, err := srv.ListTopViolators(adminCtx(), &analyticsv1.ListTopViolatorsRequest{Csv: true})
+	if err != nil {
+		t.Fatalf("ListTopViolators: %v", err)
+	}
+	if repo.gotLimit != defaultTopLimit {
+		t.Errorf("limit = %d, want %d", repo.gotLimit, defaultTopLimit)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].UserId != "user-2" || resp.Users[0].Violations != 9 {
+		t.Errorf("users = %v", resp.Users)
+	}
+	if want := "user_id,violations\nuser-2,9\n"; resp.Csv != want {
+		t.Errorf("csv = %q, want %q", resp.Csv, want)
+	}
+}
+
+func TestGetPolicyTrend_DaysAndTotals(t *testing.T) {
+	repo := &mockAnalyticsRepo{trend: []*domain.DailyPolicyTrend{
+		{Day: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC), URLBlocks: 5},
+		{Day: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), URLBlocks: 2, Violations: 4},
+	}}
+	srv := newTestServer(repo)
+	resp, err := srv.GetPolicyTrend(adminCtx(), &analyticsv1.GetPolicyTrendRequest{FromDate: "2026-03-01", ToDate: "2026-03-14", Csv: true})
+	if err != nil {
+		t.Fatalf("GetPolicyTrend: %v", err)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !repo.gotFrom.Equal(want) {
+		t.Errorf("from = %v, want %v", repo.gotFrom, want)
+	}
+	if len(resp.Days) != 2 || resp.Days[0].Date != "2026-03-13" || resp.Days[1].Violations != 4 {
+		t.Errorf("days = %v", resp.Days)
+	}
+	if resp.Totals.UrlBlocks != 7 || resp.Totals.Violations != 4 || resp.Totals.Date != "" {
+		t.Errorf("totals = %v", resp.Totals)
+	}
+	if want := "date,url_blocks,violations\n2026-03-13,5,0\n2026-03-14,2,4\n"; resp.Csv != want {
+		t.Errorf("csv = %q, want %q", resp.Csv, want)
+	}
+	if _, err := srv.GetPolicyTrend(adminCtx(), &analyticsv1.GetPolicyTrendRequest{FromDate: "2026-03-15", ToDate: "2026-03-01"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("inverted range: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestAnalytics_RequiresOrgAdmin(t *testing.T) {
+	srv := newTestServer(&mockAnalyticsRepo{})
+	ctx := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-1")
+	_, err := srv.GetLoginStats(ctx, &analyticsv1.GetLoginStatsRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestAnalytics_RegionUnavailable(t *testing.T) {
+	repo := &mockAnalyticsRepo{err: fmt.Errorf("%w: org org-1 requires region \"eu\"", residency.ErrRegionUnavailable)}
+	srv := newTestServer(repo)
+	if _, err := srv.GetLoginStats(adminCtx(), &analyticsv1.GetLoginStatsRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetLoginStats code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.GetUsage(adminCtx(), &analyticsv1.GetUsageRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetUsage code = %v, want FailedPrecondition", status.Code(err))
+	}
+	repo.err = errors.New("connection reset")
+	if _, err := srv.GetLoginStats(adminCtx(), &analyticsv1.GetLoginStatsRequest{}); status.Code(err) != codes.Internal {
+		t.Errorf("GetLoginStats code = %v, want Internal", status.Code(err))
+	}
+}
+
+func TestAnalytics_NilRepo(t *testing.T) {
+	srv := NewServer(nil, nil, nil)
+	_, err := srv.ListSessionsByCountry(adminCtx(), &analyticsv1.ListSessionsByCountryRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
+	}
+	if _, err := srv.ListTopBlockedDomains(adminCtx(), &analyticsv1.ListTopBlockedDomainsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ListTopBlockedDomains code = %v, want Unimplemented", status.Code(err))
+	}
+	if _, err := srv.GetUsage(adminCtx(), &analyticsv1.GetUsageRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("GetUsage code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+func TestGetUsage(t *testing.T) {
+	repo := &mockAnalyticsRepo{usage: []*meteringdomain.Usage{
+		{OrgID: "org-1", Month: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), ActiveUsers: 12, Logins: 40, MFAChallenges: 9, MFAResends: 2, APICalls: 5000},
+	}}
+	srv := newTestServer(repo)
+	resp, err := srv.GetUsage(adminCtx(), &analyticsv1.GetUsageRequest{})
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if want := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC); repo.gotOrgID != "org-1" || !repo.gotFrom.Equal(want) {
+		t.Errorf("org, from = %q, %v; want org-1, %v", repo.gotOrgID, repo.gotFrom, want)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !repo.gotTo.Equal(want) {
+		t.Errorf("default to = %v, want %v", repo.gotTo, want)
+	}
+	if len(resp.Months) != 1 {
+		t.Fatalf("months = %v", resp.Months)
+	}
+	if m := resp.Months[0]; m.Month != "2026-02" || m.ActiveUsers != 12 || m.SmsMessages != 11 || m.ApiCalls != 5000 {
+		t.Errorf("month = %+v", m)
+	}
+
+	for _, req := range []*analyticsv1.GetUsageRequest{
+		{FromMonth: "2026-3"},
+		{ToMonth: "2026-13"},
+		{FromMonth: "2026-03", ToMonth: "2026-01"},
+		{FromMonth: "2023-03", ToMonth: "2026-03"},
+	} {
+		if _, err := srv.GetUsage(adminCtx(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%+v: code = %v, want InvalidArgument", req, status.Code(err))
+		}
+	}
+	if _, err := srv.GetUsage(adminCtx(), &analyticsv1.GetUsageRequest{FromMonth: "2023-04", ToMonth: "2026-03"}); err != nil {
+		t.Errorf("36 months: %v", err)
+	}
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-1")
+	if _, err := srv.GetUsage(member, &analyticsv1.GetUsageRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member code = %v, want PermissionDenied", status.Code(err))
+	}
+}
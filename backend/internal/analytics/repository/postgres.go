@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/analytics/domain"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/residency"
+)
+
+// PostgresRepository implements Repository using Postgres rollup tables.
+type PostgresRepository struct {
+	queries *gen.Queries
+	router  *residency.Router
+}
+
+// NewPostgresRepository returns an analytics repository that uses the given db for persistence. When router is not
+// nil, the rollups built from audit logs and policy violations are computed in, and read from, the database holding
+// them: the regional database of an org with a data region. Session rollups stay in db with the sessions.
+func NewPostgresRepository(db *sql.DB, router *residency.Router) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), router: router}
+}
+
+// queriesFor returns the queries for the database holding orgID's audit log and policy violation rollups.
+func (r *PostgresRepository) queriesFor(ctx context.Context, orgID string) (*gen.Queries, error) {
+	if r.router == nil {
+		return r.queries, nil
+	}
+	db, err := r.router.DB(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return gen.New(db), nil
+}
+
+// eachDatabase calls fn with the queries of every database holding audit logs and policy violations.
+func (r *PostgresRepository) eachDatabase(fn func(q *gen.Queries) error) error {
+	if r.router == nil {
+		return fn(r.queries)
+	}
+	for _, db := range r.router.Databases() {
+		if err := fn(gen.New(db)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollupDay recomputes the login, device, country, policy violation, blocked domain and per-user violation rollups
+// for the UTC day containing day. The rollups of audit logs and policy violations run in every database.
+func (r *PostgresRepository) RollupDay(ctx context.Context, day time.Time) error {
+	start := truncateDay(day)
+	now := time.Now().UTC()
+	if err := r.queries.RollupDailyDeviceSessions(ctx, gen.RollupDailyDeviceSessionsParams{
+		Day: start, UpdatedAt: now, StartAt: start, EndAt: start.AddDate(0, 0, 1),
+	}); err != nil {
+		return err
+	}
+	if err := r.queries.RollupDailyCountrySessions(ctx, gen.RollupDailyCountrySessionsParams{
+		Day: start, UpdatedAt: now, StartAt: start, EndAt: start.AddDate(0, 0, 1),
+	}); err != nil {
+		return err
+	}
+	return r.eachDatabase(func(q *gen.Queries) error {
+		if err := q.RollupDailyLogins(ctx, gen.RollupDailyLoginsParams{
+			Day: start, UpdatedAt: now, StartAt: start, EndAt: start.AddDate(0, 0, 1),
+		}); err != nil {
+			return err
+		}
+		if err := q.RollupDailyPolicyViolations(ctx, gen.RollupDailyPolicyViolationsParams{
+			Day: start, UpdatedAt: now, StartAt: start, EndAt: start.AddDate(0, 0, 1),
+		}); err != nil {
+			return err
+		}
+		if err := q.RollupDailyBlockedDomains(ctx, gen.RollupDailyBlockedDomainsParams{
+			Day: start, UpdatedAt: now, StartAt: start, EndAt: start.AddDate(0, 0, 1),
+		}); err != nil {
+			return err
+		}
+		return q.RollupDailyUserViolations(ctx, gen.RollupDailyUserViolationsParams{
+			Day: start, UpdatedAt: now, StartAt: start, EndAt: start.AddDate(0, 0, 1),
+		})
+	})
+}
+
+// ListDailyLoginStats returns the org's per-day login counters between from and to, oldest first.
+func (r *PostgresRepository) ListDailyLoginStats(ctx context.Context, orgID string, from, to time.Time) ([]*domain.DailyLoginStats, error) {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListDailyLogins(ctx, gen.ListDailyLoginsParams{OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to)})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.DailyLoginStats, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.DailyLoginStats{
+			Day:             row.Day,
+			LoginSuccesses:  row.LoginSuccesses,
+			LoginFailures:   row.LoginFailures,
+			MFAChallenges:   row.MfaChallenges,
+			SessionsCreated: row.SessionsCreated,
+		}
+	}
+	return out, nil
+}
+
+// ListTopDevices returns up to limit devices with the most sessions between from and to.
+func (r *PostgresRepository) ListTopDevices(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.DeviceSessionCount, error) {
+	rows, err := r.queries.ListTopDevicesBySessions(ctx, gen.ListTopDevicesBySessionsParams{
+		OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to), MaxResults: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.DeviceSessionCount, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.DeviceSessionCount{DeviceID: row.DeviceID, UserID: row.UserID, Sessions: row.Sessions}
+	}
+	return out, nil
+}
+
+// ListSessionsByCountry returns session counts per country between from and to.
+func (r *PostgresRepository) ListSessionsByCountry(ctx context.Context, orgID string, from, to time.Time) ([]*domain.CountrySessionCount, error) {
+	rows, err := r.queries.ListSessionsByCountry(ctx, gen.ListSessionsByCountryParams{OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to)})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.CountrySessionCount, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.CountrySessionCount{Country: row.Country, Sessions: row.Sessions}
+	}
+	return out, nil
+}
+
+// ListPolicyViolationsByAction returns policy violation counts per action between from and to.
+func (r *PostgresRepository) ListPolicyViolationsByAction(ctx context.Context, orgID string, from, to time.Time) ([]*domain.PolicyViolationCount, error) {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListPolicyViolationsByAction(ctx, gen.ListPolicyViolationsByActionParams{OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to)})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.PolicyViolationCount, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.PolicyViolationCount{Action: row.Action, Violations: row.Violations}
+	}
+	return out, nil
+}
+
+// ListTopBlockedDomains returns up to limit domains with the most URLs denied between from and to.
+func (r *PostgresRepository) ListTopBlockedDomains(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.BlockedDomainCount, error) {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListTopBlockedDomains(ctx, gen.ListTopBlockedDomainsParams{
+		OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to), MaxResults: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.BlockedDomainCount, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.BlockedDomainCount{Domain: row.Domain, Blocks: row.Blocks}
+	}
+	return out, nil
+}
+
+// ListTopViolators returns up to limit users with the most policy violations between from and to.
+func (r *PostgresRepository) ListTopViolators(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.UserViolationCount, error) {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListTopViolators(ctx, gen.ListTopViolatorsParams{
+		OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to), MaxResults: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.UserViolationCount, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.UserViolationCount{UserID: row.UserID, Violations: row.Violations}
+	}
+	return out, nil
+}
+
+// ListDailyPolicyTrend merges the per-day URL denial and policy violation totals between from and to.
+func (r *PostgresRepository) ListDailyPolicyTrend(ctx context.Context, orgID string, from, to time.Time) ([]*domain.DailyPolicyTrend, error) {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := q.ListDailyBlockTotals(ctx, gen.ListDailyBlockTotalsParams{OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to)})
+	if err != nil {
+		return nil, err
+	}
+	violations, err := q.ListDailyViolationTotals(ctx, gen.ListDailyViolationTotalsParams{OrgID: orgID, FromDay: truncateDay(from), ToDay: truncateDay(to)})
+	if err != nil {
+		return nil, err
+	}
+	byDay := make(map[time.Time]*domain.DailyPolicyTrend, len(blocks)+len(violations))
+	day := func(t time.Time) *domain.DailyPolicyTrend {
+		t = truncateDay(t)
+		d, ok := byDay[t]
+		if !ok {
+			d = &domain.DailyPolicyTrend{Day: t}
+			byDay[t] = d
+		}
+		return d
+	}
+	for _, row := range blocks {
+		day(row.Day).URLBlocks = row.Blocks
+	}
+	for _, row := range violations {
+		day(row.Day).Violations = row.Violations
+	}
+	out := make([]*domain.DailyPolicyTrend, 0, len(byDay))
+	for _, d := range byDay {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Day.Before(out[j].Day) })
+	return out, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/analytics/domain"
+)
+
+// Repository reads and maintains the pre-aggregated login, policy violation and URL denial analytics rollups.
+// Day arguments are UTC calendar days (time of day is ignored); from and to are inclusive.
+type Repository interface {
+	// RollupDay recomputes all rollups for day from audit logs, sessions, and policy violations. Idempotent.
+	RollupDay(ctx context.Context, day time.Time) error
+	// ListDailyLoginStats returns the org's per-day login counters, oldest first. Days without activity are omitted.
+	ListDailyLoginStats(ctx context.Context, orgID string, from, to time.Time) ([]*domain.DailyLoginStats, error)
+	// ListTopDevices returns the org's devices with the most sessions, most first.
+	ListTopDevices(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.DeviceSessionCount, error)
+	// ListSessionsByCountry returns the org's session counts per country, most first.
+	ListSessionsByCountry(ctx context.Context, orgID string, from, to time.Time) ([]*domain.CountrySessionCount, error)
+	// ListPolicyViolationsByAction returns the org's reported policy violation counts per action, most first.
+	ListPolicyViolationsByAction(ctx context.Context, orgID string, from, to time.Time) ([]*domain.PolicyViolationCount, error)
+	// ListTopBlockedDomains returns the domains with the most URLs denied by CheckUrlAccess, most first.
+	ListTopBlockedDomains(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.BlockedDomainCount, error)
+	// ListTopViolators returns the users with the most reported policy violations, most first.
+	ListTopViolators(ctx context.Context, orgID string, from, to time.Time, limit int32) ([]*domain.UserViolationCount, error)
+	// ListDailyPolicyTrend returns the org's URL denials and policy violations per day, oldest first. Days with
+	// neither are omitted.
+	ListDailyPolicyTrend(ctx context.Context, orgID string, from, to time.Time) ([]*domain.DailyPolicyTrend, error)
+}
@@ -0,0 +1,61 @@
+// Package analytics maintains the pre-aggregated login rollups served by AnalyticsService.
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// BackfillDays is how many past days the rollup job recomputes on startup, so dashboards are populated
+// after a deploy or an outage of the job.
+const BackfillDays = 30
+
+// DayRoller recomputes the rollups for one UTC day. Implemented by the analytics repository.
+type DayRoller interface {
+	RollupDay(ctx context.Context, day time.Time) error
+}
+
+// RollupJob periodically recomputes the daily rollups from audit logs and sessions.
+type RollupJob struct {
+	roller DayRoller
+	now    func() time.Time
+}
+
+// NewRollupJob returns a rollup job that writes through roller.
+func NewRollupJob(roller DayRoller) *RollupJob {
+	return &RollupJob{roller: roller, now: time.Now}
+}
+
+// RunOnce recomputes the rollups for today and the previous days-1 days (UTC), oldest first.
+// Yesterday is always included when days >= 2 so events written just before midnight are counted.
+// Returns the first error; later days are still attempted.
+func (j *RollupJob) RunOnce(ctx context.Context, days int) error {
+	today := j.now().UTC()
+	var firstErr error
+	for i := days - 1; i >= 0; i-- {
+		if err := j.roller.RollupDay(ctx, today.AddDate(0, 0, -i)); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Run backfills BackfillDays, then recomputes today and yesterday every interval until ctx is done.
+func (j *RollupJob) Run(ctx context.Context, interval time.Duration) {
+	if err := j.RunOnce(ctx, BackfillDays); err != nil {
+		log.Printf("analytics: rollup backfill failed: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.RunOnce(ctx, 2); err != nil {
+				log.Printf("analytics: rollup failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type recordingRoller struct {
+	days []time.Time
+	fail map[string]bool
+}
+
+func (r *recordingRoller) RollupDay(ctx context.Context, day time.Time) error {
+	r.days = append(r.days, day)
+	if r.fail[day.Format("2006-01-02")] {
+		return errors.New("rollup failed")
+	}
+	return nil
+}
+
+func TestRollupJob_RunOnce_OldestFirst(t *testing.T) {
+	roller := &recordingRoller{}
+	job := NewRollupJob(roller)
+	job.now = func() time.Time { return time.Date(2026, 3, 1, 0, 5, 0, 0, time.UTC) }
+
+	if err := job.RunOnce(context.Background(), 2); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(roller.days) != 2 {
+		t.Fatalf("days = %d, want 2", len(roller.days))
+	}
+	if got := roller.days[0].Format("2006-01-02"); got != "2026-02-28" {
+		t.Errorf("first day = %s, want 2026-02-28", got)
+	}
+	if got := roller.days[1].Format("2006-01-02"); got != "2026-03-01" {
+		t.Errorf("second day = %s, want 2026-03-01", got)
+	}
+}
+
+func TestRollupJob_RunOnce_ContinuesAfterError(t *testing.T) {
+	roller := &recordingRoller{fail: map[string]bool{"2026-02-27": true}}
+	job := NewRollupJob(roller)
+	job.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	if err := job.RunOnce(context.Background(), 3); err == nil {
+		t.Fatal("RunOnce should return the rollup error")
+	}
+	if len(roller.days) != 3 {
+		t.Errorf("days = %d, want 3 (later days still rolled up)", len(roller.days))
+	}
+}
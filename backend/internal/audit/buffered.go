@@ -0,0 +1,230 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit/domain"
+	auditrepo "zero-trust-control-plane/backend/internal/audit/repository"
+)
+
+// OverflowPolicy selects what BufferedWriter.Create does when the queue is full.
+type OverflowPolicy string
+
+const (
+	// OverflowBlock makes Create wait for room in the queue until the request context is done.
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDrop makes Create discard the entry immediately; drops are counted in Stats.
+	OverflowDrop OverflowPolicy = "drop"
+)
+
+// Defaults for BufferOptions fields left zero.
+const (
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = time.Second
+	// batchWriteTimeout bounds one batch insert, so a hung database cannot stall the writer forever.
+	batchWriteTimeout = 10 * time.Second
+	// dropLogInterval is the minimum time between two "entries dropped" log lines.
+	dropLogInterval = time.Minute
+)
+
+// ErrWriterClosed is returned by Create after Close.
+var ErrWriterClosed = errors.New("audit: buffered writer closed")
+
+// BatchCreator is implemented by repositories that can insert several audit logs at once. BufferedWriter uses it
+// when available and falls back to one Create per entry otherwise.
+type BatchCreator interface {
+	CreateBatch(ctx context.Context, logs []*domain.AuditLog) error
+}
+
+// BufferOptions configures a BufferedWriter.
+type BufferOptions struct {
+	// QueueSize is how many entries may wait to be written. Must be positive.
+	QueueSize int
+	// BatchSize is the most entries written in one insert (default DefaultBatchSize).
+	BatchSize int
+	// FlushInterval is how long a partial batch waits before it is written (default DefaultFlushInterval).
+	FlushInterval time.Duration
+	// Overflow selects the behaviour when the queue is full (default OverflowBlock).
+	Overflow OverflowPolicy
+}
+
+// BufferStats are the counters of a BufferedWriter since it started.
+type BufferStats struct {
+	Queued  int   // entries currently waiting to be written
+	Written int64 // entries persisted
+	Dropped int64 // entries discarded because the queue was full (or, with OverflowBlock, the caller gave up)
+	Failed  int64 // entries lost because their batch insert failed
+}
+
+// BufferedWriter is an audit repository whose Create enqueues the entry and returns; a background goroutine writes
+// queued entries in batches. Reads go straight to the wrapped repository, so entries still in the queue are not
+// visible to them yet. Call Close on shutdown to flush the queue.
+type BufferedWriter struct {
+	auditrepo.Repository
+	batch    BatchCreator
+	opts     BufferOptions
+	queue    chan *domain.AuditLog
+	done     chan struct{}
+	closing  chan struct{}
+	closeMu  sync.RWMutex
+	closed   bool
+	written  atomic.Int64
+	dropped  atomic.Int64
+	failed   atomic.Int64
+	lastDrop atomic.Int64 // unix nanos of the last drop log line
+}
+
+// NewBufferedWriter returns a BufferedWriter over repo and starts its background goroutine.
+func NewBufferedWriter(repo auditrepo.Repository, opts BufferOptions) *BufferedWriter {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultFlushInterval
+	}
+	if opts.Overflow == "" {
+		opts.Overflow = OverflowBlock
+	}
+	w := &BufferedWriter{
+		Repository: repo,
+		opts:       opts,
+		queue:      make(chan *domain.AuditLog, opts.QueueSize),
+		done:       make(chan struct{}),
+		closing:    make(chan struct{}),
+	}
+	w.batch, _ = repo.(BatchCreator)
+	go w.run()
+	return w
+}
+
+// Create enqueues a for writing. With OverflowDrop a full queue drops the entry; with OverflowBlock Create waits for
+// room until ctx is done. Both cases count the entry as dropped and return nil, so audit backpressure never fails
+// the caller's RPC. Returns ErrWriterClosed after Close.
+func (w *BufferedWriter) Create(ctx context.Context, a *domain.AuditLog) error {
+	w.closeMu.RLock()
+	defer w.closeMu.RUnlock()
+	if w.closed {
+		return ErrWriterClosed
+	}
+	select {
+	case w.queue <- a:
+		return nil
+	default:
+	}
+	if w.opts.Overflow == OverflowBlock {
+		select {
+		case w.queue <- a:
+			return nil
+		case <-ctx.Done():
+		}
+	}
+	w.drop(a)
+	return nil
+}
+
+// Stats returns the writer's counters.
+func (w *BufferedWriter) Stats() BufferStats {
+	return BufferStats{
+		Queued:  len(w.queue),
+		Written: w.written.Load(),
+		Dropped: w.dropped.Load(),
+		Failed:  w.failed.Load(),
+	}
+}
+
+// Close stops accepting entries and waits until the queued ones are written or ctx is done. Entries still queued
+// when ctx is done are lost; Close then returns ctx.Err().
+func (w *BufferedWriter) Close(ctx context.Context) error {
+	w.closeMu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.closing)
+	}
+	w.closeMu.Unlock()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *BufferedWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.opts.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]*domain.AuditLog, 0, w.opts.BatchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			w.write(batch)
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case a := <-w.queue:
+			batch = append(batch, a)
+			if len(batch) >= w.opts.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-w.closing:
+			// No Create can enqueue once closing is closed, so draining until empty flushes everything.
+			for {
+				select {
+				case a := <-w.queue:
+					batch = append(batch, a)
+					if len(batch) >= w.opts.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// write persists batch with a fresh context: the requests that produced the entries may long be finished.
+func (w *BufferedWriter) write(batch []*domain.AuditLog) {
+	ctx, cancel := context.WithTimeout(context.Background(), batchWriteTimeout)
+	defer cancel()
+	if w.batch != nil {
+		if err := w.batch.CreateBatch(ctx, batch); err != nil {
+			w.failed.Add(int64(len(batch)))
+			log.Printf("audit: failed to write batch of %d entries: %v", len(batch), err)
+			return
+		}
+		w.written.Add(int64(len(batch)))
+		return
+	}
+	for _, a := range batch {
+		if err := w.Repository.Create(ctx, a); err != nil {
+			w.failed.Add(1)
+			log.Printf("audit: request_id=%s failed to log event %s/%s: %v", a.RequestID, a.Action, a.Resource, err)
+			continue
+		}
+		w.written.Add(1)
+	}
+}
+
+// drop counts a discarded entry and logs at most once per dropLogInterval.
+func (w *BufferedWriter) drop(a *domain.AuditLog) {
+	n := w.dropped.Add(1)
+	now := time.Now().UnixNano()
+	last := w.lastDrop.Load()
+	if now-last < int64(dropLogInterval) || !w.lastDrop.CompareAndSwap(last, now) {
+		return
+	}
+	log.Printf("audit: queue full, dropped event %s/%s (request_id=%s); %d dropped in total", a.Action, a.Resource, a.RequestID, n)
+}
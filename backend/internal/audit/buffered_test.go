@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit/domain"
+)
+
+// batchAuditRepo is a concurrency-safe audit repository with CreateBatch. While gate is non-nil, writes wait for it
+// to be closed.
+type batchAuditRepo struct {
+	mockAuditRepo
+	mu       sync.Mutex
+	batches  [][]*domain.AuditLog
+	batchErr error
+	gate     chan struct{}
+}
+
+func (m *batchAuditRepo) CreateBatch(ctx context.Context, logs []*domain.AuditLog) error {
+	if m.gate != nil {
+		<-m.gate
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.batchErr != nil {
+		return m.batchErr
+	}
+	m.batches = append(m.batches, append([]*domain.AuditLog(nil), logs...))
+	return nil
+}
+
+func (m *batchAuditRepo) count() (batches, entries int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, b := range m.batches {
+		entries += len(b)
+	}
+	return len(m.batches), entries
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 2s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBufferedWriter_WritesFullBatches(t *testing.T) {
+	repo := &batchAuditRepo{}
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 10, BatchSize: 3, FlushInterval: time.Hour})
+	for i := 0; i < 6; i++ {
+		if err := w.Create(context.Background(), &domain.AuditLog{ID: "a"}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	waitFor(t, func() bool { _, n := repo.count(); return n == 6 })
+	if b, _ := repo.count(); b != 2 {
+		t.Errorf("batches = %d, want 2", b)
+	}
+	if err := w.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if s := w.Stats(); s.Written != 6 || s.Dropped != 0 || s.Failed != 0 {
+		t.Errorf("Stats = %+v, want 6 written", s)
+	}
+}
+
+func TestBufferedWriter_FlushesPartialBatchOnInterval(t *testing.T) {
+	repo := &batchAuditRepo{}
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 10, BatchSize: 100, FlushInterval: 10 * time.Millisecond})
+	defer w.Close(context.Background())
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "a"})
+	waitFor(t, func() bool { _, n := repo.count(); return n == 1 })
+}
+
+func TestBufferedWriter_CloseFlushesQueue(t *testing.T) {
+	repo := &batchAuditRepo{}
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 10, BatchSize: 100, FlushInterval: time.Hour})
+	for i := 0; i < 5; i++ {
+		_ = w.Create(context.Background(), &domain.AuditLog{ID: "a"})
+	}
+	if err := w.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, n := repo.count(); n != 5 {
+		t.Errorf("entries written = %d, want 5", n)
+	}
+	if err := w.Create(context.Background(), &domain.AuditLog{ID: "late"}); !errors.Is(err, ErrWriterClosed) {
+		t.Errorf("Create after Close = %v, want ErrWriterClosed", err)
+	}
+}
+
+func TestBufferedWriter_DropPolicy(t *testing.T) {
+	repo := &batchAuditRepo{gate: make(chan struct{})}
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 2, BatchSize: 1, FlushInterval: time.Hour, Overflow: OverflowDrop})
+	// The first entry is taken by the writer, which then waits on the gate; two more fill the queue.
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "1"})
+	waitFor(t, func() bool { return w.Stats().Queued == 0 })
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "2"})
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "3"})
+	if err := w.Create(context.Background(), &domain.AuditLog{ID: "4"}); err != nil {
+		t.Fatalf("Create on full queue = %v, want nil", err)
+	}
+	if s := w.Stats(); s.Dropped != 1 || s.Queued != 2 {
+		t.Errorf("Stats = %+v, want 1 dropped and 2 queued", s)
+	}
+	close(repo.gate)
+	if err := w.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if s := w.Stats(); s.Written != 3 {
+		t.Errorf("Written = %d, want 3", s.Written)
+	}
+}
+
+func TestBufferedWriter_BlockPolicy(t *testing.T) {
+	repo := &batchAuditRepo{gate: make(chan struct{})}
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 1, BatchSize: 1, FlushInterval: time.Hour})
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "1"})
+	waitFor(t, func() bool { return w.Stats().Queued == 0 })
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "2"})
+
+	// A full queue blocks until the caller's context is done; the entry is then dropped.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := w.Create(ctx, &domain.AuditLog{ID: "3"}); err != nil {
+		t.Fatalf("Create = %v, want nil", err)
+	}
+	if s := w.Stats(); s.Dropped != 1 {
+		t.Errorf("Dropped = %d, want 1", s.Dropped)
+	}
+
+	// Once the writer makes progress, a blocked Create gets its entry in.
+	done := make(chan error, 1)
+	go func() { done <- w.Create(context.Background(), &domain.AuditLog{ID: "4"}) }()
+	close(repo.gate)
+	if err := <-done; err != nil {
+		t.Fatalf("blocked Create = %v", err)
+	}
+	if err := w.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if s := w.Stats(); s.Written != 3 || s.Dropped != 1 {
+		t.Errorf("Stats = %+v, want 3 written and 1 dropped", s)
+	}
+}
+
+func TestBufferedWriter_FailedBatchIsCounted(t *testing.T) {
+	repo := &batchAuditRepo{batchErr: errors.New("db down")}
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 10, BatchSize: 2, FlushInterval: time.Hour})
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "1"})
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "2"})
+	if err := w.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if s := w.Stats(); s.Failed != 2 || s.Written != 0 {
+		t.Errorf("Stats = %+v, want 2 failed", s)
+	}
+}
+
+func TestBufferedWriter_FallsBackToCreate(t *testing.T) {
+	repo := &mockAuditRepo{}
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 10, BatchSize: 5, FlushInterval: time.Hour})
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "1"})
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "2"})
+	if err := w.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(repo.entries) != 2 {
+		t.Errorf("entries = %d, want 2", len(repo.entries))
+	}
+}
+
+func TestBufferedWriter_CloseHonoursDeadline(t *testing.T) {
+	repo := &batchAuditRepo{gate: make(chan struct{})}
+	defer close(repo.gate)
+	w := NewBufferedWriter(repo, BufferOptions{QueueSize: 10, BatchSize: 1, FlushInterval: time.Hour})
+	_ = w.Create(context.Background(), &domain.AuditLog{ID: "1"})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := w.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close = %v, want DeadlineExceeded", err)
+	}
+}
@@ -11,5 +11,6 @@ type AuditLog struct {
 	Resource  string
 	IP        string
 	Metadata  string
+	RequestID string // correlation ID from x-request-id; empty for entries written outside an RPC
 	CreatedAt time.Time
 }
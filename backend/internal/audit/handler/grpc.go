@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -11,7 +13,9 @@ import (
 	auditv1 "zero-trust-control-plane/backend/api/generated/audit/v1"
 	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
 	"zero-trust-control-plane/backend/internal/audit/domain"
+	"zero-trust-control-plane/backend/internal/billing"
 	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/residency"
 	"zero-trust-control-plane/backend/internal/server/interceptors"
 )
 
@@ -20,23 +24,40 @@ const (
 	maxPageSize     = 100
 )
 
+// StreamAuditEvents tailing: the stream polls the database, so it sees events written by every server instance.
+const (
+	// tailPollInterval is how often a stream looks for new events.
+	tailPollInterval = time.Second
+	// tailLookback is how late an event may be written after its created_at and still be sent (e.g. when the
+	// buffered audit writer holds it for AUDIT_FLUSH_INTERVAL).
+	tailLookback = 10 * time.Second
+	// tailBatchSize is the most events read per query.
+	tailBatchSize = 500
+	// maxTailActions caps the actions filter of StreamAuditEvents.
+	maxTailActions = 50
+)
+
 // Server implements AuditService (proto server) for audit logs.
 // Proto: audit/audit.proto → internal/audit/handler.
 type Server struct {
 	auditv1.UnimplementedAuditServiceServer
 	repo            Repository
 	orgAdminChecker rbac.OrgMembershipGetter
+	plans           *billing.Gate
+	pollInterval    time.Duration
 }
 
-// Repository is the minimal interface needed by the audit handler for listing logs.
+// Repository is the minimal interface needed by the audit handler for listing and tailing logs.
 type Repository interface {
-	ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource *string) ([]*domain.AuditLog, error)
+	ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource, requestID *string) ([]*domain.AuditLog, error)
+	ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*domain.AuditLog, error)
 }
 
 // NewServer returns a new Audit gRPC server that uses repo for listing audit logs.
-// If orgAdminChecker is non-nil, ListAuditLogs requires the caller to be org admin or owner.
-func NewServer(repo Repository, orgAdminChecker rbac.OrgMembershipGetter) *Server {
-	return &Server{repo: repo, orgAdminChecker: orgAdminChecker}
+// If orgAdminChecker is non-nil, ListAuditLogs and StreamAuditEvents require the caller to be org admin or owner.
+// If plans is non-nil, StreamAuditEvents requires a plan with audit streaming.
+func NewServer(repo Repository, orgAdminChecker rbac.OrgMembershipGetter, plans *billing.Gate) *Server {
+	return &Server{repo: repo, orgAdminChecker: orgAdminChecker, plans: plans, pollInterval: tailPollInterval}
 }
 
 // ListAuditLogs returns a paginated list of audit logs for the caller's org, with optional filters.
@@ -45,22 +66,9 @@ func (s *Server) ListAuditLogs(ctx context.Context, req *auditv1.ListAuditLogsRe
 	if s.repo == nil {
 		return nil, status.Error(codes.Unimplemented, "method ListAuditLogs not implemented")
 	}
-	var orgID string
-	if s.orgAdminChecker != nil {
-		var err error
-		orgID, _, err = rbac.RequireOrgAdmin(ctx, s.orgAdminChecker)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		var ok bool
-		orgID, ok = interceptors.GetOrgID(ctx)
-		if !ok || orgID == "" {
-			return nil, status.Error(codes.Unauthenticated, "org context required")
-		}
-	}
-	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
-		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
+	orgID, err := s.callerOrg(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
 	}
 	pageSize := int32(defaultPageSize)
 	if pag := req.GetPagination(); pag != nil {
@@ -79,7 +87,7 @@ func (s *Server) ListAuditLogs(ctx context.Context, req *auditv1.ListAuditLogsRe
 			}
 		}
 	}
-	var userID, action, resource *string
+	var userID, action, resource, requestID *string
 	if req.GetUserId() != "" {
 		userID = &req.UserId
 	}
@@ -89,8 +97,14 @@ func (s *Server) ListAuditLogs(ctx context.Context, req *auditv1.ListAuditLogsRe
 	if req.GetResource() != "" {
 		resource = &req.Resource
 	}
-	logs, err := s.repo.ListByOrgFiltered(ctx, orgID, pageSize, offset, userID, action, resource)
+	if req.GetRequestId() != "" {
+		requestID = &req.RequestId
+	}
+	logs, err := s.repo.ListByOrgFiltered(ctx, orgID, pageSize, offset, userID, action, resource, requestID)
 	if err != nil {
+		if errors.Is(err, residency.ErrRegionUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, "org data region is not available")
+		}
 		return nil, status.Error(codes.Internal, "failed to list audit logs")
 	}
 	events := make([]*auditv1.AuditEvent, len(logs))
@@ -109,6 +123,105 @@ func (s *Server) ListAuditLogs(ctx context.Context, req *auditv1.ListAuditLogsRe
 	return result, nil
 }
 
+// StreamAuditEvents sends the org's audit events as they are written, from when the stream opens until the client
+// cancels, optionally only those of one user or of the given actions. Events are sent in created_at order within
+// each poll; an event written more than tailLookback after its created_at is not sent. Same authorization as
+// ListAuditLogs.
+func (s *Server) StreamAuditEvents(req *auditv1.StreamAuditEventsRequest, stream auditv1.AuditService_StreamAuditEventsServer) error {
+	if s.repo == nil {
+		return status.Error(codes.Unimplemented, "method StreamAuditEvents not implemented")
+	}
+	ctx := stream.Context()
+	orgID, err := s.callerOrg(ctx, req.GetOrgId())
+	if err != nil {
+		return err
+	}
+	if len(req.GetActions()) > maxTailActions {
+		return status.Error(codes.InvalidArgument, "at most 50 actions")
+	}
+	if err := s.plans.Require(ctx, orgID, billing.FeatureAuditStreaming); err != nil {
+		return err
+	}
+	var userID *string
+	if req.GetUserId() != "" {
+		userID = &req.UserId
+	}
+	start := time.Now()
+	cursor := start
+	// seen holds the IDs sent within the lookback window, which every poll reads again.
+	seen := make(map[string]time.Time)
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		since := cursor.Add(-tailLookback)
+		if since.Before(start) {
+			since = start
+		}
+		for {
+			logs, err := s.repo.ListByOrgSince(ctx, orgID, since, tailBatchSize, userID, req.GetActions())
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if errors.Is(err, residency.ErrRegionUnavailable) {
+					return status.Error(codes.FailedPrecondition, "org data region is not available")
+				}
+				return status.Error(codes.Internal, "failed to read audit logs")
+			}
+			for _, l := range logs {
+				if _, ok := seen[l.ID]; ok {
+					continue
+				}
+				if err := stream.Send(auditLogToProto(l)); err != nil {
+					return err
+				}
+				seen[l.ID] = l.CreatedAt
+				if l.CreatedAt.After(cursor) {
+					cursor = l.CreatedAt
+				}
+			}
+			// A full batch may have more behind it; read on from its last event unless that does not advance.
+			if len(logs) < tailBatchSize || !logs[len(logs)-1].CreatedAt.After(since) {
+				break
+			}
+			since = logs[len(logs)-1].CreatedAt
+		}
+		for id, createdAt := range seen {
+			if createdAt.Before(cursor.Add(-tailLookback)) {
+				delete(seen, id)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// callerOrg returns the caller's org, requiring an org admin or owner when orgAdminChecker is set. requestOrgID, if
+// set, must match it.
+func (s *Server) callerOrg(ctx context.Context, requestOrgID string) (string, error) {
+	var orgID string
+	if s.orgAdminChecker != nil {
+		var err error
+		orgID, _, err = rbac.RequireOrgAdmin(ctx, s.orgAdminChecker)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		var ok bool
+		orgID, ok = interceptors.GetOrgID(ctx)
+		if !ok || orgID == "" {
+			return "", status.Error(codes.Unauthenticated, "org context required")
+		}
+	}
+	if requestOrgID != "" && requestOrgID != orgID {
+		return "", status.Error(codes.PermissionDenied, "org_id does not match context")
+	}
+	return orgID, nil
+}
+
 func auditLogToProto(l *domain.AuditLog) *auditv1.AuditEvent {
 	if l == nil {
 		return nil
@@ -122,5 +235,6 @@ func auditLogToProto(l *domain.AuditLog) *auditv1.AuditEvent {
 		Ip:        l.IP,
 		Metadata:  l.Metadata,
 		CreatedAt: timestamppb.New(l.CreatedAt),
+		RequestId: l.RequestID,
 	}
 }
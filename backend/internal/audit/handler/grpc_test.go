@@ -3,7 +3,10 @@ package handler
 import (
 	"context"
 	"errors"
+	"slices"
+	"sort"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,17 +16,52 @@ import (
 	auditv1 "zero-trust-control-plane/backend/api/generated/audit/v1"
 	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
 	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+	"zero-trust-control-plane/backend/internal/billing"
 	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
 	"zero-trust-control-plane/backend/internal/server/interceptors"
 )
 
 // mockAuditRepo implements Repository for tests.
 type mockAuditRepo struct {
+	mu      sync.Mutex
 	logs    map[string][]*auditdomain.AuditLog
 	listErr error
 }
 
-func (m *mockAuditRepo) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource *string) ([]*auditdomain.AuditLog, error) {
+// add stores logs while a stream may be reading.
+func (m *mockAuditRepo) add(logs ...*auditdomain.AuditLog) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, l := range logs {
+		m.logs[l.OrgID] = append(m.logs[l.OrgID], l)
+	}
+}
+
+func (m *mockAuditRepo) ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*auditdomain.AuditLog, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	var out []*auditdomain.AuditLog
+	for _, l := range m.logs[orgID] {
+		if l.CreatedAt.Before(since) || (userID != nil && l.UserID != *userID) {
+			continue
+		}
+		if len(actions) > 0 && !slices.Contains(actions, l.Action) {
+			continue
+		}
+		out = append(out, l)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *mockAuditRepo) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource, requestID *string) ([]*auditdomain.AuditLog, error) {
 	if m.listErr != nil {
 		return nil, m.listErr
 	}
@@ -42,6 +80,9 @@ func (m *mockAuditRepo) ListByOrgFiltered(ctx context.Context, orgID string, lim
 		if resource != nil && log.Resource != *resource {
 			continue
 		}
+		if requestID != nil && log.RequestID != *requestID {
+			continue
+		}
 		filtered = append(filtered, log)
 	}
 	start := int(offset)
@@ -90,7 +131,7 @@ func TestListAuditLogs_Success(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{OrgId: "org-1"})
@@ -117,7 +158,7 @@ func TestListAuditLogs_FilterByUserID(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{
@@ -152,7 +193,7 @@ func TestListAuditLogs_FilterByAction(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{
@@ -187,7 +228,7 @@ func TestListAuditLogs_FilterByResource(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{
@@ -207,6 +248,41 @@ func TestListAuditLogs_FilterByResource(t *testing.T) {
 	}
 }
 
+func TestListAuditLogs_FilterByRequestID(t *testing.T) {
+	now := time.Now().UTC()
+	logs := []*auditdomain.AuditLog{
+		{ID: "log-1", OrgID: "org-1", UserID: "user-1", Action: "create", Resource: "policy", RequestID: "req-a", CreatedAt: now},
+		{ID: "log-2", OrgID: "org-1", UserID: "user-1", Action: "update", Resource: "policy", RequestID: "req-b", CreatedAt: now},
+		{ID: "log-3", OrgID: "org-1", UserID: "user-2", Action: "delete", Resource: "user", RequestID: "req-a", CreatedAt: now},
+	}
+	repo := &mockAuditRepo{
+		logs: map[string][]*auditdomain.AuditLog{"org-1": logs},
+	}
+	membershipRepo := &mockMembershipRepoForAudit{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil)
+	ctx := ctxWithAdminForAudit("org-1", "admin-1")
+
+	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{
+		OrgId:     "org-1",
+		RequestId: "req-a",
+	})
+	if err != nil {
+		t.Fatalf("ListAuditLogs: %v", err)
+	}
+	if len(resp.Logs) != 2 {
+		t.Errorf("logs count = %d, want 2", len(resp.Logs))
+	}
+	for _, log := range resp.Logs {
+		if log.RequestId != "req-a" {
+			t.Errorf("log request_id = %q, want %q", log.RequestId, "req-a")
+		}
+	}
+}
+
 func TestListAuditLogs_Pagination(t *testing.T) {
 	now := time.Now().UTC()
 	logs := make([]*auditdomain.AuditLog, 60)
@@ -229,7 +305,7 @@ func TestListAuditLogs_Pagination(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{
@@ -272,7 +348,7 @@ func TestListAuditLogs_MaxPageSize(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{
@@ -299,7 +375,7 @@ func TestListAuditLogs_NonAdminCaller(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithMemberForAudit("org-1", "member-1")
 
 	_, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{OrgId: "org-1"})
@@ -324,7 +400,7 @@ func TestListAuditLogs_OrgIDMismatch(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	_, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{OrgId: "org-2"})
@@ -350,7 +426,7 @@ func TestListAuditLogs_RepositoryError(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo)
+	srv := NewServer(repo, membershipRepo, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	_, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{OrgId: "org-1"})
@@ -367,7 +443,7 @@ func TestListAuditLogs_RepositoryError(t *testing.T) {
 }
 
 func TestListAuditLogs_NilRepo(t *testing.T) {
-	srv := NewServer(nil, nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := ctxWithAdminForAudit("org-1", "admin-1")
 
 	_, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{OrgId: "org-1"})
@@ -391,7 +467,7 @@ func TestListAuditLogs_NoOrgAdminChecker(t *testing.T) {
 	repo := &mockAuditRepo{
 		logs: map[string][]*auditdomain.AuditLog{"org-1": logs},
 	}
-	srv := NewServer(repo, nil)
+	srv := NewServer(repo, nil, nil)
 	ctx := ctxWithAdminForAudit("org-1", "user-1")
 
 	resp, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{OrgId: "org-1"})
@@ -407,7 +483,7 @@ func TestListAuditLogs_NoOrgContext(t *testing.T) {
 	repo := &mockAuditRepo{
 		logs: map[string][]*auditdomain.AuditLog{"org-1": {}},
 	}
-	srv := NewServer(repo, nil)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListAuditLogs(ctx, &auditv1.ListAuditLogsRequest{OrgId: "org-1"})
@@ -422,3 +498,128 @@ func TestListAuditLogs_NoOrgContext(t *testing.T) {
 		t.Errorf("status code = %v, want %v", st.Code(), codes.Unauthenticated)
 	}
 }
+
+// fakeAuditEventStream implements auditv1.AuditService_StreamAuditEventsServer, collecting sent events.
+type fakeAuditEventStream struct {
+	auditv1.AuditService_StreamAuditEventsServer
+	ctx  context.Context
+	sent chan *auditv1.AuditEvent
+}
+
+func (f *fakeAuditEventStream) Context() context.Context { return f.ctx }
+
+func (f *fakeAuditEventStream) Send(e *auditv1.AuditEvent) error {
+	f.sent <- e
+	return nil
+}
+
+func TestStreamAuditEvents_TailsNewEvents(t *testing.T) {
+	before := time.Now().Add(-time.Minute)
+	repo := &mockAuditRepo{logs: map[string][]*auditdomain.AuditLog{
+		"org-1": {{ID: "old", OrgID: "org-1", UserID: "user-1", Action: "login_success", CreatedAt: before}},
+	}}
+	membershipRepo := &mockMembershipRepoForAudit{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil)
+	srv.pollInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(ctxWithAdminForAudit("org-1", "admin-1"))
+	stream := &fakeAuditEventStream{ctx: ctx, sent: make(chan *auditv1.AuditEvent, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.StreamAuditEvents(&auditv1.StreamAuditEventsRequest{
+			UserId:  "user-1",
+			Actions: []string{"login_success", "login_failure"},
+		}, stream)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	now := time.Now()
+	repo.add(
+		&auditdomain.AuditLog{ID: "log-1", OrgID: "org-1", UserID: "user-1", Action: "login_failure", CreatedAt: now},
+		&auditdomain.AuditLog{ID: "log-2", OrgID: "org-1", UserID: "user-2", Action: "login_success", CreatedAt: now},
+		&auditdomain.AuditLog{ID: "log-3", OrgID: "org-1", UserID: "user-1", Action: "logout", CreatedAt: now},
+		&auditdomain.AuditLog{ID: "log-4", OrgID: "org-2", UserID: "user-1", Action: "login_success", CreatedAt: now},
+	)
+	// Written late, e.g. by the buffered writer, with a created_at before the previous event.
+	time.Sleep(20 * time.Millisecond)
+	repo.add(&auditdomain.AuditLog{ID: "log-5", OrgID: "org-1", UserID: "user-1", Action: "login_success", CreatedAt: now.Add(-time.Millisecond)})
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case e := <-stream.sent:
+			got = append(got, e.GetId())
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out; received %v", got)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("StreamAuditEvents after cancel = %v, want nil", err)
+	}
+	close(stream.sent)
+	for e := range stream.sent {
+		got = append(got, e.GetId())
+	}
+	if len(got) != 2 || got[0] != "log-1" || got[1] != "log-5" {
+		t.Errorf("streamed = %v, want [log-1 log-5] once each", got)
+	}
+}
+
+func TestStreamAuditEvents_Authorization(t *testing.T) {
+	repo := &mockAuditRepo{logs: map[string][]*auditdomain.AuditLog{}}
+	membershipRepo := &mockMembershipRepoForAudit{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+			"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil)
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *auditv1.StreamAuditEventsRequest
+		code codes.Code
+	}{
+		{"member", ctxWithMemberForAudit("org-1", "member-1"), &auditv1.StreamAuditEventsRequest{}, codes.PermissionDenied},
+		{"other org", ctxWithAdminForAudit("org-1", "admin-1"), &auditv1.StreamAuditEventsRequest{OrgId: "org-2"}, codes.PermissionDenied},
+		{"too many actions", ctxWithAdminForAudit("org-1", "admin-1"), &auditv1.StreamAuditEventsRequest{Actions: make([]string, 51)}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		stream := &fakeAuditEventStream{ctx: tt.ctx, sent: make(chan *auditv1.AuditEvent, 1)}
+		if err := srv.StreamAuditEvents(tt.req, stream); status.Code(err) != tt.code {
+			t.Errorf("%s: code = %v, want %v", tt.name, status.Code(err), tt.code)
+		}
+	}
+
+	teamPlan := planOrgs{"org-1": {ID: "org-1", Plan: organizationdomain.PlanTeam}}
+	gated := NewServer(repo, membershipRepo, billing.NewGate(teamPlan, nil, nil))
+	stream := &fakeAuditEventStream{ctx: ctxWithAdminForAudit("org-1", "admin-1"), sent: make(chan *auditv1.AuditEvent, 1)}
+	if err := gated.StreamAuditEvents(&auditv1.StreamAuditEventsRequest{}, stream); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("team plan: code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	repo.listErr = errors.New("db down")
+	if err := srv.StreamAuditEvents(&auditv1.StreamAuditEventsRequest{}, stream); status.Code(err) != codes.Internal {
+		t.Errorf("repository error: code = %v, want Internal", status.Code(err))
+	}
+	if err := NewServer(nil, nil, nil).StreamAuditEvents(&auditv1.StreamAuditEventsRequest{}, stream); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil repo: code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+type planOrgs map[string]*organizationdomain.Org
+
+func (o planOrgs) GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error) {
+	return o[id], nil
+}
+
+func (o planOrgs) UpdateOrganization(ctx context.Context, org *organizationdomain.Org) error {
+	o[org.ID] = org
+	return nil
+}
@@ -17,22 +17,27 @@ const SentinelOrgID = "_system"
 // IPExtractor returns the client IP from the request context (e.g. gRPC metadata or peer).
 type IPExtractor func(context.Context) string
 
+// RequestIDExtractor returns the request correlation ID from the request context (e.g. x-request-id), or "".
+type RequestIDExtractor func(context.Context) string
+
 // AuditLogger writes a single audit event with explicit action/resource. Used by auth and session code paths.
 // LogEvent is best-effort: failures are logged and do not affect the caller.
 type AuditLogger interface {
 	LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string)
 }
 
-// Logger implements AuditLogger using the audit repository and optional IP extractor.
+// Logger implements AuditLogger using the audit repository and optional IP and request ID extractors.
 type Logger struct {
-	repo        auditrepo.Repository
-	ipExtractor IPExtractor
+	repo               auditrepo.Repository
+	ipExtractor        IPExtractor
+	requestIDExtractor RequestIDExtractor
 }
 
-// NewLogger returns an AuditLogger that persists to repo and uses ipExtractor for client IP.
-// ipExtractor may be nil; then IP is recorded as "unknown".
-func NewLogger(repo auditrepo.Repository, ipExtractor IPExtractor) *Logger {
-	return &Logger{repo: repo, ipExtractor: ipExtractor}
+// NewLogger returns an AuditLogger that persists to repo and uses ipExtractor for client IP
+// and requestIDExtractor for the correlation ID. ipExtractor may be nil; then IP is recorded as "unknown".
+// requestIDExtractor may be nil; then request_id is left empty.
+func NewLogger(repo auditrepo.Repository, ipExtractor IPExtractor, requestIDExtractor RequestIDExtractor) *Logger {
+	return &Logger{repo: repo, ipExtractor: ipExtractor, requestIDExtractor: requestIDExtractor}
 }
 
 // LogEvent writes one audit log entry. Best-effort: errors are logged and not returned.
@@ -44,6 +49,10 @@ func (l *Logger) LogEvent(ctx context.Context, orgID, userID, action, resource,
 	if l.ipExtractor != nil {
 		ip = l.ipExtractor(ctx)
 	}
+	requestID := ""
+	if l.requestIDExtractor != nil {
+		requestID = l.requestIDExtractor(ctx)
+	}
 	if orgID == "" {
 		orgID = SentinelOrgID
 	}
@@ -55,9 +64,10 @@ func (l *Logger) LogEvent(ctx context.Context, orgID, userID, action, resource,
 		Resource:  resource,
 		IP:        ip,
 		Metadata:  metadata,
+		RequestID: requestID,
 		CreatedAt: time.Now().UTC(),
 	}
 	if err := l.repo.Create(ctx, entry); err != nil {
-		log.Printf("audit: failed to log event %s/%s: %v", action, resource, err)
+		log.Printf("audit: request_id=%s failed to log event %s/%s: %v", requestID, action, resource, err)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"zero-trust-control-plane/backend/internal/audit/domain"
 )
@@ -33,7 +34,11 @@ func (m *mockAuditRepo) ListByOrg(ctx context.Context, orgID string, limit, offs
 	return nil, nil
 }
 
-func (m *mockAuditRepo) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource *string) ([]*domain.AuditLog, error) {
+func (m *mockAuditRepo) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource, requestID *string) ([]*domain.AuditLog, error) {
+	return nil, nil
+}
+
+func (m *mockAuditRepo) ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*domain.AuditLog, error) {
 	return nil, nil
 }
 
@@ -43,7 +48,7 @@ func TestLogger_LogEvent_Success(t *testing.T) {
 	ipExtractor := func(ctx context.Context) string {
 		return "192.168.1.1"
 	}
-	logger := NewLogger(repo, ipExtractor)
+	logger := NewLogger(repo, ipExtractor, nil)
 	ctx := context.Background()
 
 	logger.LogEvent(ctx, "org-1", "user-1", "test_action", "test_resource", "metadata")
@@ -83,7 +88,7 @@ func TestLogger_LogEvent_UsesIPExtractor(t *testing.T) {
 	ipExtractor := func(ctx context.Context) string {
 		return "10.0.0.1"
 	}
-	logger := NewLogger(repo, ipExtractor)
+	logger := NewLogger(repo, ipExtractor, nil)
 	ctx := context.Background()
 
 	logger.LogEvent(ctx, "org-1", "user-1", "action", "resource", "")
@@ -98,7 +103,7 @@ func TestLogger_LogEvent_UsesIPExtractor(t *testing.T) {
 
 func TestLogger_LogEvent_NilIPExtractor(t *testing.T) {
 	repo := &mockAuditRepo{}
-	logger := NewLogger(repo, nil)
+	logger := NewLogger(repo, nil, nil)
 	ctx := context.Background()
 
 	logger.LogEvent(ctx, "org-1", "user-1", "action", "resource", "")
@@ -111,9 +116,27 @@ func TestLogger_LogEvent_NilIPExtractor(t *testing.T) {
 	}
 }
 
+func TestLogger_LogEvent_RequestID(t *testing.T) {
+	repo := &mockAuditRepo{}
+	requestIDExtractor := func(ctx context.Context) string {
+		return "req-123"
+	}
+	logger := NewLogger(repo, nil, requestIDExtractor)
+	ctx := context.Background()
+
+	logger.LogEvent(ctx, "org-1", "user-1", "login_success", "authentication", "")
+
+	if len(repo.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(repo.entries))
+	}
+	if repo.entries[0].RequestID != "req-123" {
+		t.Errorf("request_id = %q, want %q", repo.entries[0].RequestID, "req-123")
+	}
+}
+
 func TestLogger_LogEvent_SentinelOrgID(t *testing.T) {
 	repo := &mockAuditRepo{}
-	logger := NewLogger(repo, nil)
+	logger := NewLogger(repo, nil, nil)
 	ctx := context.Background()
 
 	logger.LogEvent(ctx, "", "user-1", "action", "resource", "")
@@ -131,7 +154,7 @@ func TestLogger_LogEvent_RepositoryError(t *testing.T) {
 	repo := &mockAuditRepo{
 		createErr: errors.New("database error"),
 	}
-	logger := NewLogger(repo, nil)
+	logger := NewLogger(repo, nil, nil)
 	ctx := context.Background()
 
 	// Should not panic or return error - best-effort logging
@@ -139,7 +162,7 @@ func TestLogger_LogEvent_RepositoryError(t *testing.T) {
 }
 
 func TestLogger_LogEvent_NilRepo(t *testing.T) {
-	logger := NewLogger(nil, nil)
+	logger := NewLogger(nil, nil, nil)
 	ctx := context.Background()
 
 	// Should not panic - no-op when repo is nil
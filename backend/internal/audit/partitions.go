@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PartitionsAhead is how many months after the current one PartitionJob keeps partitions for, so a stopped job
+// does not push new entries into the default partition right away.
+const PartitionsAhead = 2
+
+// PartitionStore manages the monthly audit_logs partitions. Implemented by the audit repository.
+type PartitionStore interface {
+	// EnsurePartition creates the partition for the UTC month containing month if missing and reports whether it did.
+	EnsurePartition(ctx context.Context, month time.Time) (bool, error)
+	// DropPartitionsBefore drops the partitions that end at or before cutoff and returns their names.
+	DropPartitionsBefore(ctx context.Context, cutoff time.Time) ([]string, error)
+}
+
+// PartitionJob periodically creates upcoming audit_logs partitions and drops those past the retention period.
+type PartitionJob struct {
+	store     PartitionStore
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewPartitionJob returns a partition job over store. retention 0 keeps entries forever.
+func NewPartitionJob(store PartitionStore, retention time.Duration) *PartitionJob {
+	return &PartitionJob{store: store, retention: retention, now: time.Now}
+}
+
+// RunOnce creates the partitions for the current month and the next PartitionsAhead months, then drops the
+// partitions whose whole month is older than the retention period. A month is only dropped once its last entry
+// expired, so entries are kept up to a month longer than the retention. Returns the first error; dropping is
+// still attempted when creating fails.
+func (j *PartitionJob) RunOnce(ctx context.Context) error {
+	now := j.now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var firstErr error
+	for i := 0; i <= PartitionsAhead; i++ {
+		m := month.AddDate(0, i, 0)
+		created, err := j.store.EnsurePartition(ctx, m)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if created {
+			log.Printf("audit: created partition for %s", m.Format("2006-01"))
+		}
+	}
+	if j.retention > 0 {
+		dropped, err := j.store.DropPartitionsBefore(ctx, now.Add(-j.retention))
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		for _, name := range dropped {
+			log.Printf("audit: dropped expired partition %s", name)
+		}
+	}
+	return firstErr
+}
+
+// Run runs the job on start and then every interval until ctx is done.
+func (j *PartitionJob) Run(ctx context.Context, interval time.Duration) {
+	if err := j.RunOnce(ctx); err != nil {
+		log.Printf("audit: partition maintenance failed: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.RunOnce(ctx); err != nil {
+				log.Printf("audit: partition maintenance failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakePartitionStore struct {
+	existing   map[string]bool
+	ensureErr  error
+	dropCutoff time.Time
+	dropCalls  int
+	dropped    []string
+}
+
+func (f *fakePartitionStore) EnsurePartition(ctx context.Context, month time.Time) (bool, error) {
+	if f.ensureErr != nil {
+		return false, f.ensureErr
+	}
+	key := month.Format("2006-01")
+	if f.existing[key] {
+		return false, nil
+	}
+	f.existing[key] = true
+	return true, nil
+}
+
+func (f *fakePartitionStore) DropPartitionsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	f.dropCalls++
+	f.dropCutoff = cutoff
+	return f.dropped, nil
+}
+
+func TestPartitionJob_CreatesCurrentAndUpcomingMonths(t *testing.T) {
+	store := &fakePartitionStore{existing: map[string]bool{"2026-11": true}}
+	job := NewPartitionJob(store, 0)
+	job.now = func() time.Time { return time.Date(2026, 11, 30, 23, 0, 0, 0, time.UTC) }
+
+	if err := job.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	for _, m := range []string{"2026-11", "2026-12", "2027-01"} {
+		if !store.existing[m] {
+			t.Errorf("partition %s not created", m)
+		}
+	}
+	if len(store.existing) != 1+PartitionsAhead {
+		t.Errorf("partitions = %v, want current month and %d ahead", store.existing, PartitionsAhead)
+	}
+	if store.dropCalls != 0 {
+		t.Error("retention 0 must not drop partitions")
+	}
+}
+
+func TestPartitionJob_DropsPastRetention(t *testing.T) {
+	store := &fakePartitionStore{existing: map[string]bool{}, dropped: []string{"audit_logs_p202601"}}
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	job := NewPartitionJob(store, 90*24*time.Hour)
+	job.now = func() time.Time { return now }
+
+	if err := job.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if want := now.Add(-90 * 24 * time.Hour); !store.dropCutoff.Equal(want) {
+		t.Errorf("cutoff = %v, want %v", store.dropCutoff, want)
+	}
+}
+
+func TestPartitionJob_DropsEvenWhenCreateFails(t *testing.T) {
+	store := &fakePartitionStore{existing: map[string]bool{}, ensureErr: errors.New("db down")}
+	job := NewPartitionJob(store, 24*time.Hour)
+
+	if err := job.RunOnce(context.Background()); err == nil {
+		t.Fatal("RunOnce should return the create error")
+	}
+	if store.dropCalls != 1 {
+		t.Errorf("drop calls = %d, want 1", store.dropCalls)
+	}
+}
@@ -4,37 +4,82 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sort"
+	"time"
 
 	"zero-trust-control-plane/backend/internal/audit/domain"
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/residency"
 )
 
 type PostgresRepository struct {
 	queries *gen.Queries
+	router  *residency.Router
 }
 
-// NewPostgresRepository returns an audit log repository that uses the given db for persistence.
-func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+// NewPostgresRepository returns an audit log repository that uses the given db for persistence. When router is not
+// nil, an org's audit logs are stored in the database of its data region.
+func NewPostgresRepository(db *sql.DB, router *residency.Router) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), router: router}
 }
 
-// GetByID returns the audit log for id, or nil if not found.
+// queriesFor returns the queries for the database holding orgID's audit logs.
+func (r *PostgresRepository) queriesFor(ctx context.Context, orgID string) (*gen.Queries, error) {
+	if r.router == nil {
+		return r.queries, nil
+	}
+	db, err := r.router.DB(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return gen.New(db), nil
+}
+
+// eachDatabase calls fn with the queries of every database holding audit logs.
+func (r *PostgresRepository) eachDatabase(fn func(q *gen.Queries) error) error {
+	if r.router == nil {
+		return fn(r.queries)
+	}
+	for _, db := range r.router.Databases() {
+		if err := fn(gen.New(db)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID returns the audit log for id from whichever database holds it, or nil if not found.
 // It returns an error only for database failures, not for missing rows.
 func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.AuditLog, error) {
-	a, err := r.queries.GetAuditLog(ctx, id)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
+	var out *domain.AuditLog
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		if out != nil {
+			return nil
+		}
+		a, err := q.GetAuditLog(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
 		}
+		out = genAuditLogToDomain(&a)
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
-	return genAuditLogToDomain(&a), nil
+	return out, nil
 }
 
 // ListByOrg returns audit logs for the given org, paginated by limit and offset.
 // Returns (nil, error) only on database errors.
 func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, limit, offset int32) ([]*domain.AuditLog, error) {
-	list, err := r.queries.ListAuditLogsByOrg(ctx, gen.ListAuditLogsByOrgParams{OrgID: orgID, Limit: limit, Offset: offset})
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := q.ListAuditLogsByOrg(ctx, gen.ListAuditLogsByOrgParams{OrgID: orgID, Limit: limit, Offset: offset})
 	if err != nil {
 		return nil, err
 	}
@@ -46,17 +91,49 @@ func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, limit,
 }
 
 // ListByOrgFiltered returns audit logs for the given org with optional filters, paginated by limit and offset.
-// userID, action, resource may be nil to omit that filter. Returns (nil, error) only on database errors.
-func (r *PostgresRepository) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource *string) ([]*domain.AuditLog, error) {
+// userID, action, resource, requestID may be nil to omit that filter. Returns (nil, error) only on database errors.
+func (r *PostgresRepository) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource, requestID *string) ([]*domain.AuditLog, error) {
 	arg := gen.ListAuditLogsByOrgFilteredParams{
-		OrgID:          orgID,
-		Limit:          limit,
-		Offset:         offset,
-		FilterUserID:   toNullString(userID),
-		FilterAction:   toNullString(action),
-		FilterResource: toNullString(resource),
-	}
-	list, err := r.queries.ListAuditLogsByOrgFiltered(ctx, arg)
+		OrgID:           orgID,
+		Limit:           limit,
+		Offset:          offset,
+		FilterUserID:    toNullString(userID),
+		FilterAction:    toNullString(action),
+		FilterResource:  toNullString(resource),
+		FilterRequestID: toNullString(requestID),
+	}
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := q.ListAuditLogsByOrgFiltered(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.AuditLog, len(list))
+	for i := range list {
+		out[i] = genAuditLogToDomain(&list[i])
+	}
+	return out, nil
+}
+
+// ListByOrgSince returns up to limit audit logs for the given org created at or after since, oldest first.
+// userID may be nil and actions empty to omit that filter. Returns (nil, error) only on database errors.
+func (r *PostgresRepository) ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*domain.AuditLog, error) {
+	if actions == nil {
+		actions = []string{}
+	}
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := q.ListAuditLogsByOrgSince(ctx, gen.ListAuditLogsByOrgSinceParams{
+		OrgID:         orgID,
+		CreatedAt:     since,
+		Limit:         limit,
+		FilterUserID:  toNullString(userID),
+		FilterActions: actions,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +144,30 @@ func (r *PostgresRepository) ListByOrgFiltered(ctx context.Context, orgID string
 	return out, nil
 }
 
+// ListByUser returns up to limit of the latest audit logs with userID as the actor, across every org and data region,
+// newest first. Returns (nil, error) only on database errors.
+func (r *PostgresRepository) ListByUser(ctx context.Context, userID string, limit int32) ([]*domain.AuditLog, error) {
+	var out []*domain.AuditLog
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		list, err := q.ListAuditLogsByUser(ctx, gen.ListAuditLogsByUserParams{UserID: sql.NullString{String: userID, Valid: true}, Limit: limit})
+		if err != nil {
+			return err
+		}
+		for i := range list {
+			out = append(out, genAuditLogToDomain(&list[i]))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
 func toNullString(s *string) sql.NullString {
 	if s == nil || *s == "" {
 		return sql.NullString{}
@@ -76,13 +177,101 @@ func toNullString(s *string) sql.NullString {
 
 // Create persists the audit log to the database. The audit log must have ID set.
 func (r *PostgresRepository) Create(ctx context.Context, a *domain.AuditLog) error {
+	q, err := r.queriesFor(ctx, a.OrgID)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateAuditLog(ctx, createAuditLogParams(a))
+	return err
+}
+
+// CreateBatch persists the audit logs with one multi-row insert per database: either all logs of a database are
+// stored or none. Each must have ID set.
+func (r *PostgresRepository) CreateBatch(ctx context.Context, logs []*domain.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	if r.router == nil {
+		return r.queries.CreateAuditLogs(ctx, createAuditLogsParams(logs))
+	}
+	groups := make(map[gen.DBTX][]*domain.AuditLog)
+	var order []gen.DBTX
+	for _, a := range logs {
+		db, err := r.router.DB(ctx, a.OrgID)
+		if err != nil {
+			return err
+		}
+		if _, ok := groups[db]; !ok {
+			order = append(order, db)
+		}
+		groups[db] = append(groups[db], a)
+	}
+	for _, db := range order {
+		if err := gen.New(db).CreateAuditLogs(ctx, createAuditLogsParams(groups[db])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnsurePartition creates the audit_logs partition for the UTC month containing month in every database, if it does
+// not exist yet. It reports whether a partition was created.
+func (r *PostgresRepository) EnsurePartition(ctx context.Context, month time.Time) (bool, error) {
+	created := false
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		ok, err := q.CreateAuditLogPartition(ctx, month.UTC())
+		created = created || ok
+		return err
+	})
+	return created, err
+}
+
+// DropPartitionsBefore drops the monthly audit_logs partitions that end at or before cutoff in every database,
+// deletes older entries from the default partitions, and returns the names of the dropped partitions.
+func (r *PostgresRepository) DropPartitionsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	var dropped []string
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		names, err := q.DropAuditLogPartitionsBefore(ctx, cutoff)
+		dropped = append(dropped, names...)
+		return err
+	})
+	return dropped, err
+}
+
+func createAuditLogsParams(logs []*domain.AuditLog) gen.CreateAuditLogsParams {
+	arg := gen.CreateAuditLogsParams{
+		Ids:        make([]string, len(logs)),
+		OrgIds:     make([]string, len(logs)),
+		UserIds:    make([]string, len(logs)),
+		Actions:    make([]string, len(logs)),
+		Resources:  make([]string, len(logs)),
+		Ips:        make([]string, len(logs)),
+		Metadata:   make([]string, len(logs)),
+		CreatedAts: make([]time.Time, len(logs)),
+		RequestIds: make([]string, len(logs)),
+	}
+	for i, a := range logs {
+		arg.Ids[i] = a.ID
+		arg.OrgIds[i] = a.OrgID
+		arg.UserIds[i] = a.UserID
+		arg.Actions[i] = a.Action
+		arg.Resources[i] = a.Resource
+		arg.Ips[i] = a.IP
+		arg.Metadata[i] = a.Metadata
+		arg.CreatedAts[i] = a.CreatedAt
+		arg.RequestIds[i] = a.RequestID
+	}
+	return arg
+}
+
+func createAuditLogParams(a *domain.AuditLog) gen.CreateAuditLogParams {
 	uid := sql.NullString{String: a.UserID, Valid: a.UserID != ""}
 	meta := sql.NullString{String: a.Metadata, Valid: a.Metadata != ""}
-	_, err := r.queries.CreateAuditLog(ctx, gen.CreateAuditLogParams{
+	reqID := sql.NullString{String: a.RequestID, Valid: a.RequestID != ""}
+	return gen.CreateAuditLogParams{
 		ID: a.ID, OrgID: a.OrgID, UserID: uid, Action: a.Action, Resource: a.Resource,
-		Ip: a.IP, Metadata: meta, CreatedAt: a.CreatedAt,
-	})
-	return err
+		Ip: a.IP, Metadata: meta, CreatedAt: a.CreatedAt, RequestID: reqID,
+	}
 }
 
 func genAuditLogToDomain(a *gen.AuditLog) *domain.AuditLog {
@@ -97,8 +286,12 @@ func genAuditLogToDomain(a *gen.AuditLog) *domain.AuditLog {
 	if a.Metadata.Valid {
 		meta = a.Metadata.String
 	}
+	reqID := ""
+	if a.RequestID.Valid {
+		reqID = a.RequestID.String
+	}
 	return &domain.AuditLog{
 		ID: a.ID, OrgID: a.OrgID, UserID: uid, Action: a.Action, Resource: a.Resource,
-		IP: a.Ip, Metadata: meta, CreatedAt: a.CreatedAt,
+		IP: a.Ip, Metadata: meta, RequestID: reqID, CreatedAt: a.CreatedAt,
 	}
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"zero-trust-control-plane/backend/internal/audit/domain"
 )
@@ -11,6 +12,9 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*domain.AuditLog, error)
 	ListByOrg(ctx context.Context, orgID string, limit, offset int32) ([]*domain.AuditLog, error)
 	// ListByOrgFiltered returns audit logs for the org with optional filters; nil filter means no filter.
-	ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource *string) ([]*domain.AuditLog, error)
+	ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource, requestID *string) ([]*domain.AuditLog, error)
+	// ListByOrgSince returns up to limit audit logs for the org created at or after since, oldest first (ties ordered
+	// by ID), with optional filters: userID nil means any user, empty actions means any action.
+	ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*domain.AuditLog, error)
 	Create(ctx context.Context, a *domain.AuditLog) error
 }
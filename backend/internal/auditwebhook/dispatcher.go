@@ -0,0 +1,267 @@
+// Package auditwebhook delivers an org's audit events to the webhooks its admins subscribe (AuditWebhookService).
+// Each webhook has a filter expression (see ParseFilter) evaluated before delivery, so an org only ships the events
+// it cares about.
+package auditwebhook
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit"
+	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+	auditrepo "zero-trust-control-plane/backend/internal/audit/repository"
+	"zero-trust-control-plane/backend/internal/auditwebhook/domain"
+	"zero-trust-control-plane/backend/internal/platform/netguard"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+// EventAuditLog is the type of every delivered event.
+const EventAuditLog = "audit_log.created"
+
+const (
+	// DefaultQueueSize is how many events may wait for delivery when NewDispatcher gets no size.
+	DefaultQueueSize = 1000
+	// DefaultCacheTTL is how long an org's webhooks are cached; changes made on another instance apply within it.
+	DefaultCacheTTL = 30 * time.Second
+	// defaultWorkers is how many events are delivered at once.
+	defaultWorkers = 4
+	// deliveryTimeout bounds one POST.
+	deliveryTimeout = 5 * time.Second
+	// dropLogInterval is the minimum time between two "events dropped" log lines.
+	dropLogInterval = time.Minute
+)
+
+// Event is the JSON body of a delivery: one audit log entry. Role is the user's role in the org when the event was
+// delivered, empty when they are not a member.
+type Event struct {
+	Type      string    `json:"type"`
+	WebhookID string    `json:"webhook_id"`
+	ID        string    `json:"id"`
+	OrgID     string    `json:"org_id"`
+	UserID    string    `json:"user_id,omitempty"`
+	Role      string    `json:"role,omitempty"`
+	Action    string    `json:"action"`
+	Resource  string    `json:"resource"`
+	IP        string    `json:"ip"`
+	Metadata  string    `json:"metadata,omitempty"` // JSON, as stored in the audit log
+	RequestID string    `json:"request_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookLister returns the webhooks an org's events are delivered to (e.g. *repository.PostgresRepository).
+type WebhookLister interface {
+	ListEnabledByOrg(ctx context.Context, orgID string) ([]*domain.Webhook, error)
+}
+
+// subscription is a webhook with its parsed filter.
+type subscription struct {
+	webhook *domain.Webhook
+	filter  *Filter
+}
+
+type cachedOrg struct {
+	subs     []subscription
+	loadedAt time.Time
+}
+
+// Dispatcher delivers audit events to the webhooks of their org. Enqueue takes events as they are written (see
+// Tap); Run delivers them in the background. Delivery is best-effort: events are dropped when the queue is full or
+// the server stops, and failed POSTs are logged and not retried. Safe for concurrent use.
+type Dispatcher struct {
+	webhooks WebhookLister
+	roles    rbac.OrgMembershipGetter
+	// HTTPClient posts the events. Webhook URLs come from org admins, so the default only connects to public
+	// addresses and does not follow redirects (netguard.NewHTTPClient).
+	HTTPClient *http.Client
+	queue      chan *auditdomain.AuditLog
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedOrg
+
+	dropped  atomic.Int64
+	lastDrop atomic.Int64 // unix nanos of the last drop log line
+}
+
+// NewDispatcher returns a dispatcher that reads webhooks from webhooks and users' roles from roles. queueSize <= 0
+// uses DefaultQueueSize. roles may be nil; then the role of every event is empty.
+func NewDispatcher(webhooks WebhookLister, roles rbac.OrgMembershipGetter, queueSize int) *Dispatcher {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &Dispatcher{
+		webhooks:   webhooks,
+		roles:      roles,
+		HTTPClient: netguard.NewHTTPClient(deliveryTimeout),
+		queue:      make(chan *auditdomain.AuditLog, queueSize),
+		ttl:        DefaultCacheTTL,
+		now:        time.Now,
+		cache:      make(map[string]cachedOrg),
+	}
+}
+
+// Enqueue queues e for delivery without blocking. Events without an org are ignored; events that find the queue
+// full are dropped and counted (see Dropped).
+func (d *Dispatcher) Enqueue(e *auditdomain.AuditLog) {
+	if e == nil || e.OrgID == "" || e.OrgID == audit.SentinelOrgID {
+		return
+	}
+	select {
+	case d.queue <- e:
+	default:
+		n := d.dropped.Add(1)
+		now := d.now().UnixNano()
+		if last := d.lastDrop.Load(); now-last >= int64(dropLogInterval) && d.lastDrop.CompareAndSwap(last, now) {
+			log.Printf("auditwebhook: delivery queue full, %d events dropped so far", n)
+		}
+	}
+}
+
+// Dropped returns how many events were dropped because the queue was full.
+func (d *Dispatcher) Dropped() int64 {
+	return d.dropped.Load()
+}
+
+// Run delivers queued events until ctx is done. Events still queued then are dropped.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < defaultWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case e := <-d.queue:
+					d.Deliver(ctx, e)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Deliver POSTs e to every enabled webhook of its org whose filter matches it, one after the other.
+func (d *Dispatcher) Deliver(ctx context.Context, e *auditdomain.AuditLog) {
+	subs := d.subscriptions(ctx, e.OrgID)
+	if len(subs) == 0 {
+		return
+	}
+	fields := Fields{Action: e.Action, Resource: e.Resource, UserID: e.UserID, IP: e.IP}
+	roleLoaded := false
+	loadRole := func() {
+		if !roleLoaded {
+			fields.Role, roleLoaded = d.role(ctx, e.OrgID, e.UserID), true
+		}
+	}
+	for _, sub := range subs {
+		if sub.filter.UsesRole() {
+			loadRole()
+		}
+		if !sub.filter.Match(fields) {
+			continue
+		}
+		loadRole()
+		ev := Event{
+			Type:      EventAuditLog,
+			WebhookID: sub.webhook.ID,
+			ID:        e.ID,
+			OrgID:     e.OrgID,
+			UserID:    e.UserID,
+			Role:      fields.Role,
+			Action:    e.Action,
+			Resource:  e.Resource,
+			IP:        e.IP,
+			Metadata:  e.Metadata,
+			RequestID: e.RequestID,
+			CreatedAt: e.CreatedAt,
+		}
+		if err := d.send(ctx, sub.webhook, ev); err != nil {
+			log.Printf("auditwebhook: webhook %s for %s %s failed: %v", sub.webhook.ID, e.Action, e.ID, err)
+		}
+	}
+}
+
+// send posts ev to w, signed with the webhook's secret, within deliveryTimeout.
+func (d *Dispatcher) send(ctx context.Context, w *domain.Webhook, ev Event) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	return webhook.Post(ctx, d.HTTPClient, w.URL, w.Secret, ev)
+}
+
+// subscriptions returns the org's enabled webhooks, from the cache when it is fresh. Webhooks whose stored filter no
+// longer parses are skipped. Failed reads are not cached.
+func (d *Dispatcher) subscriptions(ctx context.Context, orgID string) []subscription {
+	now := d.now()
+	d.mu.Lock()
+	c, ok := d.cache[orgID]
+	d.mu.Unlock()
+	if ok && now.Sub(c.loadedAt) < d.ttl {
+		return c.subs
+	}
+	hooks, err := d.webhooks.ListEnabledByOrg(ctx, orgID)
+	if err != nil {
+		log.Printf("auditwebhook: list webhooks of org %s: %v", orgID, err)
+		return nil
+	}
+	subs := make([]subscription, 0, len(hooks))
+	for _, w := range hooks {
+		f, err := ParseFilter(w.Filter)
+		if err != nil {
+			log.Printf("auditwebhook: webhook %s skipped: %v", w.ID, err)
+			continue
+		}
+		subs = append(subs, subscription{webhook: w, filter: f})
+	}
+	d.mu.Lock()
+	d.cache[orgID] = cachedOrg{subs: subs, loadedAt: now}
+	d.mu.Unlock()
+	return subs
+}
+
+// Invalidate drops the cached webhooks of orgID, so changes made through this instance apply at once.
+func (d *Dispatcher) Invalidate(orgID string) {
+	d.mu.Lock()
+	delete(d.cache, orgID)
+	d.mu.Unlock()
+}
+
+// role returns the user's role in the org, or "" when they are not a member or it cannot be read.
+func (d *Dispatcher) role(ctx context.Context, orgID, userID string) string {
+	if d.roles == nil || userID == "" {
+		return ""
+	}
+	m, err := d.roles.GetMembershipByUserAndOrg(ctx, userID, orgID)
+	if err != nil || m == nil {
+		return ""
+	}
+	return string(m.Role)
+}
+
+// Tap is an audit repository that queues every entry it writes for delivery to the org's webhooks. Reads and
+// everything else go to the wrapped repository.
+type Tap struct {
+	auditrepo.Repository
+	dispatcher *Dispatcher
+}
+
+// NewTap returns repo with writes tapped for d.
+func NewTap(repo auditrepo.Repository, d *Dispatcher) *Tap {
+	return &Tap{Repository: repo, dispatcher: d}
+}
+
+// Create writes a to the wrapped repository and, when that succeeds, queues it for delivery.
+func (t *Tap) Create(ctx context.Context, a *auditdomain.AuditLog) error {
+	if err := t.Repository.Create(ctx, a); err != nil {
+		return err
+	}
+	t.dispatcher.Enqueue(a)
+	return nil
+}
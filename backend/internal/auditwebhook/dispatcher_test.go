@@ -0,0 +1,203 @@
+package auditwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+	auditrepo "zero-trust-control-plane/backend/internal/audit/repository"
+	"zero-trust-control-plane/backend/internal/auditwebhook/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/platform/netguard"
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+// fakeLister implements WebhookLister, counting reads.
+type fakeLister struct {
+	mu    sync.Mutex
+	hooks map[string][]*domain.Webhook
+	reads int
+}
+
+func (f *fakeLister) ListEnabledByOrg(ctx context.Context, orgID string) ([]*domain.Webhook, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reads++
+	return f.hooks[orgID], nil
+}
+
+// fakeRoles implements rbac.OrgMembershipGetter, counting lookups.
+type fakeRoles struct {
+	roles   map[string]membershipdomain.Role // key: userID
+	lookups int
+}
+
+func (f *fakeRoles) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	f.lookups++
+	role, ok := f.roles[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+// receiver records the deliveries of an httptest server.
+type receiver struct {
+	mu       sync.Mutex
+	events   []Event
+	unsigned int // deliveries without a valid signature
+}
+
+func (r *receiver) handler(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	var e Event
+	_ = json.Unmarshal(body, &e)
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	if req.Header.Get(webhook.SignatureHeader) != webhook.Sign("s3cret", body) {
+		r.unsigned++
+	}
+	r.mu.Unlock()
+}
+
+func TestDispatcher_DeliversMatchingEvents(t *testing.T) {
+	rec := &receiver{}
+	srv := httptest.NewServer(http.HandlerFunc(rec.handler))
+	defer srv.Close()
+	lister := &fakeLister{hooks: map[string][]*domain.Webhook{"org-1": {
+		{ID: "wh-admins", OrgID: "org-1", URL: srv.URL, Secret: "s3cret", Filter: `action in ["login_failure", "session_revoked"] AND role == "admin"`},
+		{ID: "wh-all", OrgID: "org-1", URL: srv.URL, Secret: "s3cret"},
+	}}}
+	roles := &fakeRoles{roles: map[string]membershipdomain.Role{"admin-1": membershipdomain.RoleAdmin, "user-1": membershipdomain.RoleMember}}
+	d := NewDispatcher(lister, roles, 0)
+	d.HTTPClient = srv.Client() // the test server listens on loopback
+	ctx := context.Background()
+
+	d.Deliver(ctx, &auditdomain.AuditLog{ID: "a1", OrgID: "org-1", UserID: "admin-1", Action: "login_failure", Resource: "authentication", IP: "10.0.0.1", Metadata: `{"reason":"bad_password"}`})
+	d.Deliver(ctx, &auditdomain.AuditLog{ID: "a2", OrgID: "org-1", UserID: "user-1", Action: "login_failure", Resource: "authentication"})
+	d.Deliver(ctx, &auditdomain.AuditLog{ID: "a3", OrgID: "org-2", UserID: "admin-1", Action: "login_failure"})
+
+	got := map[string]bool{}
+	for _, e := range rec.events {
+		got[e.WebhookID+"/"+e.ID] = true
+	}
+	want := map[string]bool{"wh-admins/a1": true, "wh-all/a1": true, "wh-all/a2": true}
+	if len(got) != len(want) || len(rec.events) != len(want) {
+		t.Fatalf("deliveries = %v, want %v", got, want)
+	}
+	for k := range want {
+		if !got[k] {
+			t.Errorf("missing delivery %s", k)
+		}
+	}
+	first := rec.events[0]
+	if first.Type != EventAuditLog || first.Role != "admin" || first.IP != "10.0.0.1" || first.Metadata != `{"reason":"bad_password"}` {
+		t.Errorf("event = %+v", first)
+	}
+	if rec.unsigned != 0 {
+		t.Errorf("%d deliveries without a valid signature", rec.unsigned)
+	}
+	if lister.reads != 2 {
+		t.Errorf("webhook reads = %d, want 2 (one per org, then cached)", lister.reads)
+	}
+}
+
+func TestDispatcher_RoleLookedUpOnlyWhenNeeded(t *testing.T) {
+	lister := &fakeLister{hooks: map[string][]*domain.Webhook{"org-1": {
+		{ID: "wh-1", OrgID: "org-1", URL: "http://127.0.0.1:0", Filter: `action == "session_revoked"`},
+	}}}
+	roles := &fakeRoles{roles: map[string]membershipdomain.Role{"user-1": membershipdomain.RoleMember}}
+	d := NewDispatcher(lister, roles, 0)
+	d.Deliver(context.Background(), &auditdomain.AuditLog{ID: "a1", OrgID: "org-1", UserID: "user-1", Action: "login_success"})
+	if roles.lookups != 0 {
+		t.Errorf("role lookups = %d for a filtered-out event, want 0", roles.lookups)
+	}
+}
+
+func TestDispatcher_CacheTTLAndInvalidate(t *testing.T) {
+	lister := &fakeLister{hooks: map[string][]*domain.Webhook{}}
+	d := NewDispatcher(lister, nil, 0)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	ctx := context.Background()
+	d.subscriptions(ctx, "org-1")
+	d.subscriptions(ctx, "org-1")
+	if lister.reads != 1 {
+		t.Fatalf("reads = %d, want 1", lister.reads)
+	}
+	d.Invalidate("org-1")
+	d.subscriptions(ctx, "org-1")
+	now = now.Add(DefaultCacheTTL)
+	d.subscriptions(ctx, "org-1")
+	if lister.reads != 3 {
+		t.Errorf("reads = %d, want 3 (after invalidation and after the TTL)", lister.reads)
+	}
+}
+
+func TestDispatcher_SkipsWebhooksWithInvalidFilter(t *testing.T) {
+	lister := &fakeLister{hooks: map[string][]*domain.Webhook{"org-1": {
+		{ID: "wh-bad", OrgID: "org-1", URL: "https://example.com", Filter: `action ==`},
+		{ID: "wh-ok", OrgID: "org-1", URL: "https://example.com"},
+	}}}
+	subs := NewDispatcher(lister, nil, 0).subscriptions(context.Background(), "org-1")
+	if len(subs) != 1 || subs[0].webhook.ID != "wh-ok" {
+		t.Errorf("subscriptions = %+v, want only wh-ok", subs)
+	}
+}
+
+func TestDispatcher_RefusesNonPublicAddresses(t *testing.T) {
+	rec := &receiver{}
+	srv := httptest.NewServer(http.HandlerFunc(rec.handler))
+	defer srv.Close()
+	d := NewDispatcher(&fakeLister{}, nil, 0)
+	err := d.send(context.Background(), &domain.Webhook{ID: "wh-1", URL: srv.URL}, Event{ID: "a1"})
+	if !errors.Is(err, netguard.ErrNonPublicAddress) {
+		t.Errorf("send to a loopback webhook: err = %v, want ErrNonPublicAddress", err)
+	}
+	if len(rec.events) != 0 {
+		t.Errorf("loopback webhook received %d events", len(rec.events))
+	}
+}
+
+func TestDispatcher_EnqueueDropsWhenFull(t *testing.T) {
+	d := NewDispatcher(&fakeLister{}, nil, 1)
+	d.Enqueue(&auditdomain.AuditLog{ID: "a1", OrgID: "org-1"})
+	d.Enqueue(&auditdomain.AuditLog{ID: "a2", OrgID: "org-1"})
+	d.Enqueue(&auditdomain.AuditLog{ID: "a3", OrgID: "_system"})
+	if got := d.Dropped(); got != 1 {
+		t.Errorf("Dropped() = %d, want 1", got)
+	}
+	if len(d.queue) != 1 {
+		t.Errorf("queued = %d, want 1", len(d.queue))
+	}
+}
+
+// memAuditRepo records created audit logs; other methods come from the nil embedded interface.
+type memAuditRepo struct {
+	auditrepo.Repository
+	created []*auditdomain.AuditLog
+}
+
+func (m *memAuditRepo) Create(ctx context.Context, a *auditdomain.AuditLog) error {
+	m.created = append(m.created, a)
+	return nil
+}
+
+func TestTap_QueuesWrittenEntries(t *testing.T) {
+	repo := &memAuditRepo{}
+	d := NewDispatcher(&fakeLister{}, nil, 10)
+	tap := NewTap(repo, d)
+	if err := tap.Create(context.Background(), &auditdomain.AuditLog{ID: "a1", OrgID: "org-1"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(repo.created) != 1 || len(d.queue) != 1 {
+		t.Errorf("created %d, queued %d; want 1 and 1", len(repo.created), len(d.queue))
+	}
+}
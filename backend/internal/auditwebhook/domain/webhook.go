@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+const (
+	// MaxPerOrg caps the webhooks of one org.
+	MaxPerOrg = 10
+	// MaxURLLength caps the delivery URL.
+	MaxURLLength = 2048
+	// MaxDescriptionLength caps the admin's description of a webhook.
+	MaxDescriptionLength = 200
+)
+
+// Webhook is an org's subscription to its own audit log. Every audit entry of the org that matches Filter (see
+// auditwebhook.ParseFilter; empty matches every entry) is POSTed to URL while the webhook is enabled.
+type Webhook struct {
+	ID          string
+	OrgID       string
+	URL         string
+	Secret      string // signs deliveries (X-ZTCP-Signature); shown to the admin only when created or rotated
+	Filter      string
+	Description string
+	Enabled     bool
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,380 @@
+package auditwebhook
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Filter expression limits.
+const (
+	// MaxFilterLength caps the source of a filter expression.
+	MaxFilterLength = 2000
+	// maxFilterDepth caps the nesting of parentheses and NOT, so a hostile expression cannot exhaust the stack.
+	maxFilterDepth = 32
+)
+
+// Filter fields: the parts of an audit event a filter can test.
+const (
+	FieldAction   = "action"
+	FieldResource = "resource"
+	FieldUserID   = "user_id"
+	FieldRole     = "role" // the user's role in the org (owner, admin, member); empty when they are not a member
+	FieldIP       = "ip"
+)
+
+var filterFields = []string{FieldAction, FieldResource, FieldUserID, FieldRole, FieldIP}
+
+// ErrInvalidFilter is wrapped by every error ParseFilter returns.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Fields are the values of an audit event that a Filter is matched against.
+type Fields struct {
+	Action   string
+	Resource string
+	UserID   string
+	Role     string
+	IP       string
+}
+
+func (f *Fields) get(name string) string {
+	switch name {
+	case FieldAction:
+		return f.Action
+	case FieldResource:
+		return f.Resource
+	case FieldUserID:
+		return f.UserID
+	case FieldRole:
+		return f.Role
+	default:
+		return f.IP
+	}
+}
+
+// Filter is a parsed filter expression. The zero Filter (from an empty expression) matches every event.
+//
+// Grammar, with keywords in any case:
+//
+//	expr       = term { "OR" term }
+//	term       = factor { "AND" factor }
+//	factor     = "NOT" factor | "(" expr ")" | comparison
+//	comparison = field ( "==" | "!=" ) string
+//	           | field [ "NOT" ] "IN" "[" string { "," string } "]"
+//	field      = "action" | "resource" | "user_id" | "role" | "ip"
+//	string     = a double-quoted string; \" and \\ are escapes
+//
+// For example: action in ["login_failure", "session_revoked"] AND role == "admin". Comparisons are exact and case
+// sensitive.
+type Filter struct {
+	root     filterNode
+	usesRole bool
+}
+
+// ParseFilter parses a filter expression. An empty (or blank) expression returns a Filter that matches every event.
+// Errors wrap ErrInvalidFilter and name the offending position.
+func ParseFilter(expr string) (*Filter, error) {
+	if len(expr) > MaxFilterLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidFilter, MaxFilterLength)
+	}
+	if strings.TrimSpace(expr) == "" {
+		return &Filter{}, nil
+	}
+	tokens, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.expr(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	return &Filter{root: root, usesRole: p.usesRole}, nil
+}
+
+// Match reports whether an event with fields f passes the filter.
+func (flt *Filter) Match(f Fields) bool {
+	if flt == nil || flt.root == nil {
+		return true
+	}
+	return flt.root.match(&f)
+}
+
+// UsesRole reports whether the filter tests the role field, so the caller knows to look up the user's role first.
+func (flt *Filter) UsesRole() bool {
+	return flt != nil && flt.usesRole
+}
+
+type filterNode interface {
+	match(f *Fields) bool
+}
+
+type andNode struct{ left, right filterNode }
+
+func (n andNode) match(f *Fields) bool { return n.left.match(f) && n.right.match(f) }
+
+type orNode struct{ left, right filterNode }
+
+func (n orNode) match(f *Fields) bool { return n.left.match(f) || n.right.match(f) }
+
+type notNode struct{ inner filterNode }
+
+func (n notNode) match(f *Fields) bool { return !n.inner.match(f) }
+
+// inNode is field IN [values]; field == value is an inNode with one value.
+type inNode struct {
+	field  string
+	values []string
+}
+
+func (n inNode) match(f *Fields) bool {
+	v := f.get(n.field)
+	for _, want := range n.values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokEq
+	tokNeq
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string // identifier or unquoted string value
+	pos  int    // byte offset in the expression, for errors
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokString:
+		return fmt.Sprintf("string %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// is reports whether t is the keyword kw, in any case.
+func (t token) is(kw string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, kw)
+}
+
+var punctuation = map[byte]tokenKind{'(': tokLParen, ')': tokRParen, '[': tokLBracket, ']': tokRBracket, ',': tokComma}
+
+func lexFilter(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case punctuation[c] != tokEOF:
+			tokens = append(tokens, token{kind: punctuation[c], text: string(c), pos: i})
+			i++
+		case c == '=' || c == '!':
+			if i+1 >= len(expr) || expr[i+1] != '=' {
+				return nil, fmt.Errorf("%w: at %d: expected %q", ErrInvalidFilter, i, string(c)+"=")
+			}
+			kind := tokEq
+			if c == '!' {
+				kind = tokNeq
+			}
+			tokens = append(tokens, token{kind: kind, text: expr[i : i+2], pos: i})
+			i += 2
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(expr) && expr[j] != '"'; j++ {
+				if expr[j] == '\\' {
+					if j+1 >= len(expr) || (expr[j+1] != '"' && expr[j+1] != '\\') {
+						return nil, fmt.Errorf("%w: at %d: only \\\" and \\\\ may be escaped", ErrInvalidFilter, j)
+					}
+					j++
+				}
+				b.WriteByte(expr[j])
+			}
+			if j >= len(expr) {
+				return nil, fmt.Errorf("%w: at %d: unterminated string", ErrInvalidFilter, i)
+			}
+			tokens = append(tokens, token{kind: tokString, text: b.String(), pos: i})
+			i = j + 1
+		case isIdentByte(c, true):
+			j := i + 1
+			for j < len(expr) && isIdentByte(expr[j], false) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: expr[i:j], pos: i})
+			i = j
+		default:
+			return nil, fmt.Errorf("%w: at %d: unexpected character %q", ErrInvalidFilter, i, c)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(expr)}), nil
+}
+
+func isIdentByte(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
+
+type filterParser struct {
+	tokens   []token
+	pos      int
+	usesRole bool
+}
+
+func (p *filterParser) peek() token { return p.tokens[p.pos] }
+
+func (p *filterParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) errorf(t token, format string, args ...any) error {
+	return fmt.Errorf("%w: at %d: %s", ErrInvalidFilter, t.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *filterParser) expr(depth int) (filterNode, error) {
+	left, err := p.term(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.next()
+		right, err := p.term(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) term(depth int) (filterNode, error) {
+	left, err := p.factor(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.next()
+		right, err := p.factor(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) factor(depth int) (filterNode, error) {
+	t := p.peek()
+	if depth >= maxFilterDepth {
+		return nil, p.errorf(t, "nested deeper than %d", maxFilterDepth)
+	}
+	switch {
+	case t.is("not"):
+		p.next()
+		inner, err := p.factor(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	case t.kind == tokLParen:
+		p.next()
+		inner, err := p.expr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorf(closing, "expected \")\", got %s", closing)
+		}
+		return inner, nil
+	default:
+		return p.comparison()
+	}
+}
+
+func (p *filterParser) comparison() (filterNode, error) {
+	t := p.next()
+	if t.kind != tokIdent {
+		return nil, p.errorf(t, "expected a field, got %s", t)
+	}
+	field := strings.ToLower(t.text)
+	known := false
+	for _, f := range filterFields {
+		known = known || f == field
+	}
+	if !known {
+		return nil, p.errorf(t, "unknown field %q (fields: %s)", t.text, strings.Join(filterFields, ", "))
+	}
+	if field == FieldRole {
+		p.usesRole = true
+	}
+	op := p.next()
+	switch {
+	case op.kind == tokEq || op.kind == tokNeq:
+		v := p.next()
+		if v.kind != tokString {
+			return nil, p.errorf(v, "expected a quoted string after %s, got %s", op.text, v)
+		}
+		var n filterNode = inNode{field: field, values: []string{v.text}}
+		if op.kind == tokNeq {
+			n = notNode{n}
+		}
+		return n, nil
+	case op.is("in"):
+		return p.list(field)
+	case op.is("not"):
+		if in := p.next(); !in.is("in") {
+			return nil, p.errorf(in, "expected \"in\" after \"not\", got %s", in)
+		}
+		n, err := p.list(field)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	default:
+		return nil, p.errorf(op, "expected ==, !=, in or not in after %q, got %s", t.text, op)
+	}
+}
+
+func (p *filterParser) list(field string) (filterNode, error) {
+	if open := p.next(); open.kind != tokLBracket {
+		return nil, p.errorf(open, "expected \"[\", got %s", open)
+	}
+	n := inNode{field: field}
+	for {
+		v := p.next()
+		if v.kind != tokString {
+			return nil, p.errorf(v, "expected a quoted string, got %s", v)
+		}
+		n.values = append(n.values, v.text)
+		sep := p.next()
+		if sep.kind == tokRBracket {
+			return n, nil
+		}
+		if sep.kind != tokComma {
+			return nil, p.errorf(sep, "expected \",\" or \"]\", got %s", sep)
+		}
+	}
+}
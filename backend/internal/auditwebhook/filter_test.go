@@ -0,0 +1,98 @@
+package auditwebhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseFilter_Match(t *testing.T) {
+	adminFailure := Fields{Action: "login_failure", Resource: "authentication", UserID: "u1", Role: "admin", IP: "10.0.0.1"}
+	memberRevoke := Fields{Action: "session_revoked", Resource: "session", UserID: "u2", Role: "member", IP: "10.0.0.2"}
+	ownerPolicy := Fields{Action: "policy_updated", Resource: "policy", UserID: "u3", Role: "owner", IP: "10.0.0.3"}
+	tests := []struct {
+		expr string
+		want [3]bool // adminFailure, memberRevoke, ownerPolicy
+	}{
+		{"", [3]bool{true, true, true}},
+		{`action == "login_failure"`, [3]bool{true, false, false}},
+		{`action != "login_failure"`, [3]bool{false, true, true}},
+		{`action in ["login_failure", "session_revoked"]`, [3]bool{true, true, false}},
+		{`action in ["login_failure","session_revoked"] AND role == "admin"`, [3]bool{true, false, false}},
+		{`action not in ["login_failure"]`, [3]bool{false, true, true}},
+		{`role == "owner" or resource == "session"`, [3]bool{false, true, true}},
+		{`NOT role == "member"`, [3]bool{true, false, true}},
+		{`not (role == "member" or role == "owner")`, [3]bool{true, false, false}},
+		// AND binds tighter than OR.
+		{`role == "owner" OR role == "admin" AND action == "session_revoked"`, [3]bool{false, false, true}},
+		{`(role == "owner" OR role == "admin") AND action == "login_failure"`, [3]bool{true, false, false}},
+		{`user_id == "u2" and ip == "10.0.0.2"`, [3]bool{false, true, false}},
+		{`Action == "LOGIN_FAILURE"`, [3]bool{false, false, false}}, // fields and keywords are case insensitive, values are not
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.expr, err)
+			continue
+		}
+		for i, fields := range []Fields{adminFailure, memberRevoke, ownerPolicy} {
+			if got := f.Match(fields); got != tt.want[i] {
+				t.Errorf("ParseFilter(%q).Match(%s) = %v, want %v", tt.expr, fields.Action, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseFilter_Escapes(t *testing.T) {
+	f, err := ParseFilter(`resource == "a\"b\\c"`)
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	if !f.Match(Fields{Resource: `a"b\c`}) {
+		t.Error("escaped string did not match")
+	}
+}
+
+func TestParseFilter_UsesRole(t *testing.T) {
+	for expr, want := range map[string]bool{
+		"":                                       false,
+		`action == "login_failure"`:              false,
+		`action == "x" or not role in ["admin"]`: true,
+	} {
+		f, err := ParseFilter(expr)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", expr, err)
+		}
+		if got := f.UsesRole(); got != want {
+			t.Errorf("ParseFilter(%q).UsesRole() = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	for _, expr := range []string{
+		`action`,
+		`action ==`,
+		`action == login_failure`,
+		`action = "x"`,
+		`action in []`,
+		`action in ["x",]`,
+		`action in ["x"`,
+		`action not ["x"]`,
+		`country == "DE"`,
+		`action == "x" and`,
+		`(action == "x"`,
+		`action == "x")`,
+		`action == "x" role == "admin"`,
+		`action == "unterminated`,
+		`action == "bad \n escape"`,
+		`action == "x" && role == "admin"`,
+		strings.Repeat("not ", maxFilterDepth+1) + `action == "x"`,
+		strings.Repeat("(", maxFilterDepth+1) + `action == "x"` + strings.Repeat(")", maxFilterDepth+1),
+		`action == "` + strings.Repeat("x", MaxFilterLength) + `"`,
+	} {
+		if _, err := ParseFilter(expr); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("ParseFilter(%q) error = %v, want ErrInvalidFilter", expr, err)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditwebhookv1 "zero-trust-control-plane/backend/api/generated/auditwebhook/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/auditwebhook"
+	"zero-trust-control-plane/backend/internal/auditwebhook/domain"
+	"zero-trust-control-plane/backend/internal/auditwebhook/repository"
+	"zero-trust-control-plane/backend/internal/billing"
+	"zero-trust-control-plane/backend/internal/platform/netguard"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+)
+
+// CacheInvalidator drops the cached webhooks of an org after a change (*auditwebhook.Dispatcher).
+type CacheInvalidator interface {
+	Invalidate(orgID string)
+}
+
+// Server implements AuditWebhookService (proto server).
+// Proto: auditwebhook/auditwebhook.proto → internal/auditwebhook/handler.
+type Server struct {
+	auditwebhookv1.UnimplementedAuditWebhookServiceServer
+	repo           repository.Repository
+	membershipRepo rbac.OrgMembershipGetter
+	cache          CacheInvalidator
+	plans          *billing.Gate
+	auditLogger    audit.AuditLogger
+	now            func() time.Time
+}
+
+// NewServer returns a new AuditWebhook gRPC server. If repo or membershipRepo is nil, all RPCs return Unimplemented.
+// cache may be nil; then changes reach deliveries within auditwebhook.DefaultCacheTTL. If plans is non-nil,
+// CreateAuditWebhook and UpdateAuditWebhook require a plan with audit streaming. auditLogger may be nil.
+func NewServer(repo repository.Repository, membershipRepo rbac.OrgMembershipGetter, cache CacheInvalidator, plans *billing.Gate, auditLogger audit.AuditLogger) *Server {
+	return &Server{
+		repo:           repo,
+		membershipRepo: membershipRepo,
+		cache:          cache,
+		plans:          plans,
+		auditLogger:    auditLogger,
+		now:            time.Now,
+	}
+}
+
+// CreateAuditWebhook subscribes a URL to the org's audit events that match the filter. The response carries the
+// signing secret, which is not shown again.
+func (s *Server) CreateAuditWebhook(ctx context.Context, req *auditwebhookv1.CreateAuditWebhookRequest) (*auditwebhookv1.CreateAuditWebhookResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method CreateAuditWebhook not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.plans.Require(ctx, orgID, billing.FeatureAuditStreaming); err != nil {
+		return nil, err
+	}
+	w := &domain.Webhook{OrgID: orgID, Enabled: true, CreatedBy: userID}
+	if err := applySettings(w, req.GetUrl(), req.GetFilter(), req.GetDescription()); err != nil {
+		return nil, err
+	}
+	n, err := s.repo.CountByOrg(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to count webhooks")
+	}
+	if n >= domain.MaxPerOrg {
+		return nil, status.Errorf(codes.ResourceExhausted, "an org can have at most %d audit webhooks", domain.MaxPerOrg)
+	}
+	if w.Secret, err = newSecret(); err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate secret")
+	}
+	w.ID = uuid.New().String()
+	w.CreatedAt = s.now().UTC()
+	w.UpdatedAt = w.CreatedAt
+	if err := s.repo.Create(ctx, w); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create webhook")
+	}
+	s.changed(ctx, w, "audit_webhook_created", userID)
+	return &auditwebhookv1.CreateAuditWebhookResponse{Webhook: webhookToProto(w), Secret: w.Secret}, nil
+}
+
+// ListAuditWebhooks returns the org's webhooks, oldest first, without their secrets.
+func (s *Server) ListAuditWebhooks(ctx context.Context, req *auditwebhookv1.ListAuditWebhooksRequest) (*auditwebhookv1.ListAuditWebhooksResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListAuditWebhooks not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListByOrg(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list webhooks")
+	}
+	out := make([]*auditwebhookv1.AuditWebhook, len(list))
+	for i, w := range list {
+		out[i] = webhookToProto(w)
+	}
+	return &auditwebhookv1.ListAuditWebhooksResponse{Webhooks: out}, nil
+}
+
+// UpdateAuditWebhook replaces the URL, filter, description and enabled flag of a webhook, and with rotate_secret
+// issues a new signing secret.
+func (s *Server) UpdateAuditWebhook(ctx context.Context, req *auditwebhookv1.UpdateAuditWebhookRequest) (*auditwebhookv1.UpdateAuditWebhookResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method UpdateAuditWebhook not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.plans.Require(ctx, orgID, billing.FeatureAuditStreaming); err != nil {
+		return nil, err
+	}
+	w, err := s.load(ctx, orgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := applySettings(w, req.GetUrl(), req.GetFilter(), req.GetDescription()); err != nil {
+		return nil, err
+	}
+	w.Enabled = req.GetEnabled()
+	resp := &auditwebhookv1.UpdateAuditWebhookResponse{}
+	if req.GetRotateSecret() {
+		if w.Secret, err = newSecret(); err != nil {
+			return nil, status.Error(codes.Internal, "failed to generate secret")
+		}
+		resp.Secret = w.Secret
+	}
+	w.UpdatedAt = s.now().UTC()
+	ok, err := s.repo.Update(ctx, w)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update webhook")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	}
+	s.changed(ctx, w, "audit_webhook_updated", userID)
+	resp.Webhook = webhookToProto(w)
+	return resp, nil
+}
+
+// DeleteAuditWebhook removes a webhook; deliveries to it stop.
+func (s *Server) DeleteAuditWebhook(ctx context.Context, req *auditwebhookv1.DeleteAuditWebhookRequest) (*auditwebhookv1.DeleteAuditWebhookResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method DeleteAuditWebhook not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.load(ctx, orgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.repo.Delete(ctx, w.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete webhook")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	}
+	s.changed(ctx, w, "audit_webhook_deleted", userID)
+	return &auditwebhookv1.DeleteAuditWebhookResponse{}, nil
+}
+
+// TestAuditWebhookFilter reports whether the filter matches the sample event in the request.
+func (s *Server) TestAuditWebhookFilter(ctx context.Context, req *auditwebhookv1.TestAuditWebhookFilterRequest) (*auditwebhookv1.TestAuditWebhookFilterResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method TestAuditWebhookFilter not implemented")
+	}
+	if _, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo); err != nil {
+		return nil, err
+	}
+	f, err := parseFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	matches := f.Match(auditwebhook.Fields{
+		Action:   req.GetAction(),
+		Resource: req.GetResource(),
+		UserID:   req.GetUserId(),
+		Role:     req.GetRole(),
+		IP:       req.GetIp(),
+	})
+	return &auditwebhookv1.TestAuditWebhookFilterResponse{Matches: matches}, nil
+}
+
+// load returns the org's webhook id, or NotFound for a webhook of another org.
+func (s *Server) load(ctx context.Context, orgID, id string) (*domain.Webhook, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	w, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load webhook")
+	}
+	if w == nil || w.OrgID != orgID {
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	}
+	return w, nil
+}
+
+// changed drops the cached webhooks of the org and audits the change under resource audit_webhook. The secret is
+// never audited.
+func (s *Server) changed(ctx context.Context, w *domain.Webhook, action, actor string) {
+	if s.cache != nil {
+		s.cache.Invalidate(w.OrgID)
+	}
+	if s.auditLogger == nil {
+		return
+	}
+	meta, _ := json.Marshal(map[string]interface{}{
+		"webhook_id": w.ID,
+		"url":        w.URL,
+		"filter":     w.Filter,
+		"enabled":    w.Enabled,
+	})
+	s.auditLogger.LogEvent(ctx, w.OrgID, actor, action, "audit_webhook", string(meta))
+}
+
+// applySettings validates the URL, filter and description and sets them on w.
+func applySettings(w *domain.Webhook, rawURL, filter, description string) error {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return status.Error(codes.InvalidArgument, "url required")
+	}
+	if len(rawURL) > domain.MaxURLLength {
+		return status.Errorf(codes.InvalidArgument, "url must be at most %d characters", domain.MaxURLLength)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return status.Error(codes.InvalidArgument, "url must be an https URL without credentials")
+	}
+	// Names are only resolved when delivering, where the dialer refuses non-public addresses; obviously internal
+	// hosts are rejected here already.
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); host == "localhost" || strings.HasSuffix(host, ".localhost") || (ip != nil && !netguard.PublicIP(ip)) {
+		return status.Error(codes.InvalidArgument, "url must point to a public host")
+	}
+	filter = strings.TrimSpace(filter)
+	if _, err := parseFilter(filter); err != nil {
+		return err
+	}
+	description = strings.TrimSpace(description)
+	if len(description) > domain.MaxDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description must be at most %d characters", domain.MaxDescriptionLength)
+	}
+	w.URL, w.Filter, w.Description = rawURL, filter, description
+	return nil
+}
+
+func parseFilter(filter string) (*auditwebhook.Filter, error) {
+	f, err := auditwebhook.ParseFilter(filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return f, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func webhookToProto(w *domain.Webhook) *auditwebhookv1.AuditWebhook {
+	return &auditwebhookv1.AuditWebhook{
+		Id:          w.ID,
+		OrgId:       w.OrgID,
+		Url:         w.URL,
+		Filter:      w.Filter,
+		Description: w.Description,
+		Enabled:     w.Enabled,
+		CreatedBy:   w.CreatedBy,
+		CreatedAt:   timestamppb.New(w.CreatedAt),
+		UpdatedAt:   timestamppb.New(w.UpdatedAt),
+	}
+}
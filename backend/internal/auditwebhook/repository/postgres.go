@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"zero-trust-control-plane/backend/internal/auditwebhook/domain"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an audit webhook repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists a new webhook.
+func (r *PostgresRepository) Create(ctx context.Context, w *domain.Webhook) error {
+	return r.queries.CreateAuditWebhook(ctx, gen.CreateAuditWebhookParams{
+		ID:          w.ID,
+		OrgID:       w.OrgID,
+		Url:         w.URL,
+		Secret:      w.Secret,
+		Filter:      w.Filter,
+		Description: w.Description,
+		Enabled:     w.Enabled,
+		CreatedBy:   w.CreatedBy,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	})
+}
+
+// GetByID returns the webhook, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	row, err := r.queries.GetAuditWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genWebhookToDomain(&row), nil
+}
+
+// ListByOrg returns the org's webhooks, oldest first.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string) ([]*domain.Webhook, error) {
+	rows, err := r.queries.ListAuditWebhooksByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return genWebhooksToDomain(rows), nil
+}
+
+// ListEnabledByOrg returns the org's enabled webhooks, oldest first.
+func (r *PostgresRepository) ListEnabledByOrg(ctx context.Context, orgID string) ([]*domain.Webhook, error) {
+	rows, err := r.queries.ListEnabledAuditWebhooksByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return genWebhooksToDomain(rows), nil
+}
+
+// CountByOrg returns how many webhooks the org has.
+func (r *PostgresRepository) CountByOrg(ctx context.Context, orgID string) (int64, error) {
+	return r.queries.CountAuditWebhooksByOrg(ctx, orgID)
+}
+
+// Update saves the mutable fields of w.
+func (r *PostgresRepository) Update(ctx context.Context, w *domain.Webhook) (bool, error) {
+	n, err := r.queries.UpdateAuditWebhook(ctx, gen.UpdateAuditWebhookParams{
+		Url:         w.URL,
+		Secret:      w.Secret,
+		Filter:      w.Filter,
+		Description: w.Description,
+		Enabled:     w.Enabled,
+		UpdatedAt:   w.UpdatedAt,
+		ID:          w.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Delete removes the webhook.
+func (r *PostgresRepository) Delete(ctx context.Context, id string) (bool, error) {
+	n, err := r.queries.DeleteAuditWebhook(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func genWebhooksToDomain(rows []gen.AuditWebhook) []*domain.Webhook {
+	out := make([]*domain.Webhook, len(rows))
+	for i := range rows {
+		out[i] = genWebhookToDomain(&rows[i])
+	}
+	return out
+}
+
+func genWebhookToDomain(row *gen.AuditWebhook) *domain.Webhook {
+	return &domain.Webhook{
+		ID:          row.ID,
+		OrgID:       row.OrgID,
+		URL:         row.Url,
+		Secret:      row.Secret,
+		Filter:      row.Filter,
+		Description: row.Description,
+		Enabled:     row.Enabled,
+		CreatedBy:   row.CreatedBy,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
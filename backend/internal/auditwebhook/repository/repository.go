@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/auditwebhook/domain"
+)
+
+// Repository persists org audit webhooks.
+type Repository interface {
+	// Create persists a new webhook. The webhook must have ID set.
+	Create(ctx context.Context, w *domain.Webhook) error
+	// GetByID returns the webhook, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.Webhook, error)
+	// ListByOrg returns the org's webhooks, oldest first.
+	ListByOrg(ctx context.Context, orgID string) ([]*domain.Webhook, error)
+	// ListEnabledByOrg returns the org's enabled webhooks, oldest first.
+	ListEnabledByOrg(ctx context.Context, orgID string) ([]*domain.Webhook, error)
+	// CountByOrg returns how many webhooks the org has, enabled or not.
+	CountByOrg(ctx context.Context, orgID string) (int64, error)
+	// Update saves the URL, secret, filter, description, enabled flag and UpdatedAt of w. It reports false when the
+	// webhook does not exist.
+	Update(ctx context.Context, w *domain.Webhook) (bool, error)
+	// Delete removes the webhook. It reports false when the webhook does not exist.
+	Delete(ctx context.Context, id string) (bool, error)
+}
@@ -0,0 +1,253 @@
+// Package backup makes logical backups of an org's data and restores them. A backup holds the org-scoped rows of one
+// org (the org, its users and their identities, memberships, groups, devices, policies and settings; not sessions,
+// audit logs or telemetry), read from one database snapshot and encrypted with a key from the secrets provider
+// (BACKUP_ENCRYPTION_KEY). Backups are made with cmd/backup or AdminService.CreateBackup and restored with
+// cmd/backup; every backup and restore is audited under the org.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/backup/domain"
+	"zero-trust-control-plane/backend/internal/backup/repository"
+)
+
+// FileExtension is the extension of backup files written by CreateFile.
+const FileExtension = ".ztcpbak"
+
+var (
+	// ErrOrgNotFound is returned by Create for an org that does not exist.
+	ErrOrgNotFound = errors.New("backup: org not found")
+	// ErrOrgExists is returned by Restore when the org of the backup already exists. Restore recreates a lost org;
+	// it does not roll an existing one back.
+	ErrOrgExists = errors.New("backup: org already exists")
+	// ErrInvalidBackup is returned for data that is not a backup, or a backup that was modified or truncated.
+	ErrInvalidBackup = errors.New("backup: not a valid backup")
+	// ErrKeyMismatch is returned for a backup encrypted with another key than the one configured.
+	ErrKeyMismatch = errors.New("backup: encrypted with another key")
+	// ErrSchemaMismatch is returned for a backup taken at another database schema version than the current one.
+	ErrSchemaMismatch = errors.New("backup: schema version does not match")
+	// ErrVerificationFailed is returned by Restore when the rows read back differ from the backup; nothing is
+	// written.
+	ErrVerificationFailed = errors.New("backup: restored rows do not match the backup")
+)
+
+// Service creates, verifies and restores backups.
+type Service struct {
+	repo          repository.Repository
+	key           []byte
+	schemaVersion uint
+	auditLogger   audit.AuditLogger
+	now           func() time.Time
+}
+
+// NewService returns a Service that reads and writes org data through repo and encrypts backups with key.
+// schemaVersion is the migration version of the database (see migrate.Version); backups of other versions are not
+// restored. auditLogger may be nil.
+func NewService(repo repository.Repository, key []byte, schemaVersion uint, auditLogger audit.AuditLogger) *Service {
+	return &Service{repo: repo, key: key, schemaVersion: schemaVersion, auditLogger: auditLogger, now: time.Now}
+}
+
+// Create backs up the org and returns the encrypted backup and its snapshot. by is the user who asked for it; empty
+// for cmd/backup.
+func (s *Service) Create(ctx context.Context, orgID, by string) ([]byte, *domain.Snapshot, error) {
+	data, snap, err := s.create(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.audit(ctx, orgID, by, "backup_created", createdMetadata(snap, len(data), ""))
+	return data, snap, nil
+}
+
+// CreateFile backs up the org to a new file in dir, readable only by its owner, and returns the file name.
+func (s *Service) CreateFile(ctx context.Context, dir, orgID, by string) (string, *domain.Snapshot, int, error) {
+	data, snap, err := s.create(ctx, orgID)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	name := FileName(orgID, snap.CreatedAt)
+	tmp, err := os.CreateTemp(dir, name+".tmp*")
+	if err != nil {
+		return "", nil, 0, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", nil, 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", nil, 0, err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return "", nil, 0, err
+	}
+	s.audit(ctx, orgID, by, "backup_created", createdMetadata(snap, len(data), name))
+	return name, snap, len(data), nil
+}
+
+// FileName returns the name of the backup file of orgID taken at t: "<org ID>-<UTC time>.ztcpbak".
+func FileName(orgID string, t time.Time) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, orgID)
+	return safe + "-" + t.UTC().Format("20060102T150405Z") + FileExtension
+}
+
+func (s *Service) create(ctx context.Context, orgID string) ([]byte, *domain.Snapshot, error) {
+	tables, err := s.repo.Dump(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tables == nil {
+		return nil, nil, ErrOrgNotFound
+	}
+	snap := &domain.Snapshot{
+		FormatVersion: FormatVersion,
+		SchemaVersion: s.schemaVersion,
+		OrgID:         orgID,
+		CreatedAt:     s.now().UTC(),
+		Tables:        tables,
+	}
+	data, err := Encode(snap, s.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, snap, nil
+}
+
+// Verify decrypts data and checks that it can be restored into this database, without writing anything: the key,
+// the format and schema versions, and that it holds one org.
+func (s *Service) Verify(data []byte) (*domain.Snapshot, error) {
+	snap, err := Decode(data, s.key)
+	if err != nil {
+		return nil, err
+	}
+	if snap.FormatVersion != FormatVersion || snap.OrgID == "" {
+		return nil, ErrInvalidBackup
+	}
+	if snap.SchemaVersion != s.schemaVersion {
+		return nil, fmt.Errorf("%w: backup is at version %d, database at %d", ErrSchemaMismatch, snap.SchemaVersion, s.schemaVersion)
+	}
+	known := make(map[string]bool, len(repository.Tables))
+	for _, name := range repository.Tables {
+		known[name] = true
+	}
+	for _, t := range snap.Tables {
+		if !known[t.Name] {
+			return nil, fmt.Errorf("%w: unknown table %q", ErrInvalidBackup, t.Name)
+		}
+	}
+	if snap.RowCounts()["organizations"] != 1 {
+		return nil, fmt.Errorf("%w: no organization row", ErrInvalidBackup)
+	}
+	return snap, nil
+}
+
+// Restore verifies data and recreates its org in one transaction. Before committing, the org's rows are read back
+// and compared with the backup; on any difference nothing is written and ErrVerificationFailed is returned.
+// Returns ErrOrgExists when the org exists. Successful and failed restores are audited; by is the user who asked for
+// it, empty for cmd/backup.
+func (s *Service) Restore(ctx context.Context, data []byte, by string) (*domain.Snapshot, error) {
+	snap, err := s.Verify(data)
+	if err == nil {
+		var restored bool
+		restored, err = s.repo.Restore(ctx, snap.OrgID, snap.Tables, func(tables []domain.Table) error {
+			return compareRows(snap, tables)
+		})
+		if err == nil && !restored {
+			err = ErrOrgExists
+		}
+	}
+	orgID := ""
+	if snap != nil {
+		orgID = snap.OrgID
+	}
+	if err != nil {
+		s.audit(ctx, orgID, by, "backup_restore_failed", map[string]interface{}{"error": err.Error()})
+		return nil, err
+	}
+	s.audit(ctx, orgID, by, "backup_restored", map[string]interface{}{
+		"backup_created_at": snap.CreatedAt.Format(time.RFC3339),
+		"schema_version":    snap.SchemaVersion,
+		"rows":              snap.RowCounts(),
+	})
+	return snap, nil
+}
+
+// compareRows returns ErrVerificationFailed when the restored tables do not hold the rows of snap. Users and
+// identities that already existed are kept as they were, so only their number is compared.
+func compareRows(snap *domain.Snapshot, restored []domain.Table) error {
+	got := (&domain.Snapshot{Tables: restored}).RowCounts()
+	var diffs []string
+	for name, n := range snap.RowCounts() {
+		if got[name] != n {
+			diffs = append(diffs, fmt.Sprintf("%s: %d of %d rows", name, got[name], n))
+		}
+	}
+	for _, t := range snap.Tables {
+		if t.Name == "users" || t.Name == "identities" || len(diffs) > 0 {
+			continue
+		}
+		have := make(map[string]bool, len(t.Rows))
+		for _, r := range restored {
+			if r.Name == t.Name {
+				for _, row := range r.Rows {
+					have[canonical(row)] = true
+				}
+			}
+		}
+		for _, row := range t.Rows {
+			if !have[canonical(row)] {
+				diffs = append(diffs, t.Name+": rows differ")
+				break
+			}
+		}
+	}
+	if len(diffs) > 0 {
+		sort.Strings(diffs)
+		return fmt.Errorf("%w: %s", ErrVerificationFailed, strings.Join(diffs, ", "))
+	}
+	return nil
+}
+
+// canonical re-encodes a JSON row so that rows differing only in key order or spacing compare equal.
+func canonical(row json.RawMessage) string {
+	var v map[string]interface{}
+	if err := json.Unmarshal(row, &v); err != nil {
+		return string(row)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func createdMetadata(snap *domain.Snapshot, size int, file string) map[string]interface{} {
+	meta := map[string]interface{}{
+		"schema_version": snap.SchemaVersion,
+		"size_bytes":     size,
+		"rows":           snap.RowCounts(),
+	}
+	if file != "" {
+		meta["file"] = file
+	}
+	return meta
+}
+
+func (s *Service) audit(ctx context.Context, orgID, by, action string, meta map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	b, _ := json.Marshal(meta)
+	s.auditLogger.LogEvent(ctx, orgID, by, action, "backup", string(b))
+}
@@ -0,0 +1,169 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/backup/domain"
+)
+
+// memRepo holds the tables of one org; Restore replaces them unless the org exists.
+type memRepo struct {
+	orgID  string
+	tables []domain.Table
+	// alter changes the rows read back after a restore, to simulate a restore that lost data.
+	alter func([]domain.Table) []domain.Table
+}
+
+func (m *memRepo) Dump(ctx context.Context, orgID string) ([]domain.Table, error) {
+	if orgID != m.orgID {
+		return nil, nil
+	}
+	return m.tables, nil
+}
+
+func (m *memRepo) Restore(ctx context.Context, orgID string, tables []domain.Table, verify func([]domain.Table) error) (bool, error) {
+	if orgID == m.orgID {
+		return false, nil
+	}
+	restored := tables
+	if m.alter != nil {
+		restored = m.alter(tables)
+	}
+	if err := verify(restored); err != nil {
+		return false, err
+	}
+	m.orgID, m.tables = orgID, tables
+	return true, nil
+}
+
+type recordingAudit struct{ actions []string }
+
+func (r *recordingAudit) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	r.actions = append(r.actions, orgID+":"+action)
+}
+
+func testTables() []domain.Table {
+	return []domain.Table{
+		{Name: "organizations", Rows: []json.RawMessage{json.RawMessage(`{"id":"org-1","name":"Acme"}`)}},
+		{Name: "users", Rows: []json.RawMessage{json.RawMessage(`{"id":"u1","email":"a@example.com"}`)}},
+		{Name: "memberships", Rows: []json.RawMessage{json.RawMessage(`{"id":"m1","user_id":"u1","org_id":"org-1","role":"owner"}`)}},
+	}
+}
+
+func testKey(b byte) []byte { return bytes.Repeat([]byte{b}, KeySize) }
+
+func TestEncodeDecode(t *testing.T) {
+	snap := &domain.Snapshot{FormatVersion: FormatVersion, SchemaVersion: 53, OrgID: "org-1", CreatedAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Tables: testTables()}
+	data, err := Encode(snap, testKey(1))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if bytes.Contains(data, []byte("a@example.com")) {
+		t.Error("backup must not contain plaintext rows")
+	}
+	got, err := Decode(data, testKey(1))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got.OrgID != "org-1" || got.SchemaVersion != 53 || got.RowCounts()["memberships"] != 1 {
+		t.Errorf("decoded snapshot = %+v", got)
+	}
+	if _, err := Decode(data, testKey(2)); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("other key: want ErrKeyMismatch, got %v", err)
+	}
+	tampered := bytes.Clone(data)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := Decode(tampered, testKey(1)); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("tampered: want ErrInvalidBackup, got %v", err)
+	}
+	if _, err := Decode([]byte("not a backup"), testKey(1)); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("garbage: want ErrInvalidBackup, got %v", err)
+	}
+	if _, err := ParseKey("c2hvcnQ="); err == nil {
+		t.Error("ParseKey must reject a short key")
+	}
+}
+
+func TestService_CreateAndRestore(t *testing.T) {
+	source := &memRepo{orgID: "org-1", tables: testTables()}
+	logger := &recordingAudit{}
+	svc := NewService(source, testKey(1), 53, logger)
+	if _, _, err := svc.Create(context.Background(), "org-2", "admin"); !errors.Is(err, ErrOrgNotFound) {
+		t.Errorf("unknown org: want ErrOrgNotFound, got %v", err)
+	}
+	data, snap, err := svc.Create(context.Background(), "org-1", "admin")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if snap.SchemaVersion != 53 || snap.RowCounts()["users"] != 1 {
+		t.Errorf("snapshot = %+v", snap)
+	}
+
+	if _, err := svc.Restore(context.Background(), data, ""); !errors.Is(err, ErrOrgExists) {
+		t.Errorf("existing org: want ErrOrgExists, got %v", err)
+	}
+	target := &memRepo{}
+	restoreSvc := NewService(target, testKey(1), 53, logger)
+	if _, err := NewService(target, testKey(1), 54, nil).Restore(context.Background(), data, ""); !errors.Is(err, ErrSchemaMismatch) {
+		t.Errorf("other schema version: want ErrSchemaMismatch, got %v", err)
+	}
+	target.alter = func(tables []domain.Table) []domain.Table { return tables[:2] }
+	if _, err := restoreSvc.Restore(context.Background(), data, ""); !errors.Is(err, ErrVerificationFailed) || target.orgID != "" {
+		t.Errorf("lost rows: want ErrVerificationFailed and nothing restored, got %v", err)
+	}
+	target.alter = func(tables []domain.Table) []domain.Table {
+		changed := append([]domain.Table(nil), tables...)
+		changed[2] = domain.Table{Name: "memberships", Rows: []json.RawMessage{json.RawMessage(`{"id":"m1","user_id":"u1","org_id":"org-1","role":"member"}`)}}
+		return changed
+	}
+	if _, err := restoreSvc.Restore(context.Background(), data, ""); !errors.Is(err, ErrVerificationFailed) {
+		t.Errorf("changed row: want ErrVerificationFailed, got %v", err)
+	}
+	target.alter = nil
+	if _, err := restoreSvc.Restore(context.Background(), data, ""); err != nil || target.orgID != "org-1" {
+		t.Fatalf("Restore: %v (org %q)", err, target.orgID)
+	}
+
+	want := []string{"org-1:backup_created", "org-1:backup_restore_failed", "org-1:backup_restore_failed", "org-1:backup_restore_failed", "org-1:backup_restored"}
+	if len(logger.actions) != len(want) {
+		t.Fatalf("audited %v, want %v", logger.actions, want)
+	}
+	for i := range want {
+		if logger.actions[i] != want[i] {
+			t.Errorf("audit %d = %s, want %s", i, logger.actions[i], want[i])
+		}
+	}
+}
+
+func TestService_CreateFile(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewService(&memRepo{orgID: "org-1", tables: testTables()}, testKey(1), 53, nil)
+	svc.now = func() time.Time { return time.Date(2026, 6, 1, 12, 30, 0, 0, time.UTC) }
+	name, _, size, err := svc.CreateFile(context.Background(), dir, "org-1", "admin")
+	if err != nil {
+		t.Fatalf("CreateFile: %v", err)
+	}
+	if name != "org-1-20260601T123000Z.ztcpbak" {
+		t.Errorf("file name = %s", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil || len(data) != size {
+		t.Fatalf("ReadFile: %v (size %d, want %d)", err, len(data), size)
+	}
+	if info, _ := os.Stat(filepath.Join(dir, name)); info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+	if _, err := svc.Verify(data); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("dir has %d entries, want only the backup", len(entries))
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Table is the backed-up rows of one table, each the JSON object of a row as Postgres row_to_json renders it.
+type Table struct {
+	Name string            `json:"name"`
+	Rows []json.RawMessage `json:"rows"`
+}
+
+// Snapshot is the org-scoped data of one org at one point in time: the content of a backup.
+type Snapshot struct {
+	FormatVersion int       `json:"format_version"`
+	SchemaVersion uint      `json:"schema_version"` // database migration version the rows were read at
+	OrgID         string    `json:"org_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	Tables        []Table   `json:"tables"`
+}
+
+// RowCounts returns the number of rows of each table, keyed by table name.
+func (s *Snapshot) RowCounts() map[string]int {
+	counts := make(map[string]int, len(s.Tables))
+	for _, t := range s.Tables {
+		counts[t.Name] = len(t.Rows)
+	}
+	return counts
+}
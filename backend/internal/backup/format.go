@@ -0,0 +1,113 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"zero-trust-control-plane/backend/internal/backup/domain"
+)
+
+// KeySize is the size in bytes of backup encryption keys (AES-256).
+const KeySize = 32
+
+// FormatVersion is the version of the backup file format written by Encode.
+const FormatVersion = 1
+
+// headerPrefix starts every backup file: "ztcp-backup:v1:<key ID>\n", then the nonce and the AES-GCM sealed gzip of
+// the snapshot JSON. The header line is authenticated with the ciphertext.
+const headerPrefix = "ztcp-backup:v1:"
+
+// ParseKey decodes a base64 backup encryption key and checks its size.
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("backup: key is not base64: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("backup: key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// KeyID returns the ID written in the header of backups encrypted with key: the hex of the first 8 bytes of its
+// SHA-256. It tells which key a backup needs without revealing the key.
+func KeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Encode serializes s and encrypts it with key.
+func Encode(s *domain.Snapshot, key []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	var plain bytes.Buffer
+	zw := gzip.NewWriter(&plain)
+	if err := json.NewEncoder(zw).Encode(s); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	header := headerPrefix + KeyID(key) + "\n"
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte(header), nonce...)
+	return aead.Seal(out, nonce, plain.Bytes(), []byte(header)), nil
+}
+
+// Decode decrypts a backup written by Encode and returns its snapshot. Returns ErrKeyMismatch when the backup was
+// encrypted with another key and ErrInvalidBackup when it is not a backup or was modified.
+func Decode(data []byte, key []byte) (*domain.Snapshot, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	line, rest, ok := bytes.Cut(data, []byte("\n"))
+	if !ok || !bytes.HasPrefix(line, []byte(headerPrefix)) {
+		return nil, ErrInvalidBackup
+	}
+	if keyID := string(line[len(headerPrefix):]); keyID != KeyID(key) {
+		return nil, fmt.Errorf("%w: backup needs key %s, have %s", ErrKeyMismatch, keyID, KeyID(key))
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrInvalidBackup
+	}
+	header := string(line) + "\n"
+	plain, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], []byte(header))
+	if err != nil {
+		return nil, ErrInvalidBackup
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(plain))
+	if err != nil {
+		return nil, ErrInvalidBackup
+	}
+	var s domain.Snapshot
+	if err := json.NewDecoder(zr).Decode(&s); err != nil {
+		return nil, ErrInvalidBackup
+	}
+	return &s, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("backup: key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/backup/domain"
+)
+
+// Tables are the backed-up tables, in the order they are restored (referenced tables first).
+var Tables = []string{
+	"organizations",
+	"users",
+	"identities",
+	"memberships",
+	"groups",
+	"group_members",
+	"org_attribute_definitions",
+	"membership_attributes",
+	"devices",
+	"policies",
+	"org_policy_config",
+	"org_policy_config_versions",
+	"org_mfa_settings",
+	"org_domains",
+	"org_smtp_settings",
+	"notification_templates",
+}
+
+// Repository reads and writes the org-scoped rows of backups.
+type Repository interface {
+	// Dump returns the org's rows of every table in Tables, in that order, read from one snapshot. Returns nil when
+	// the org does not exist.
+	Dump(ctx context.Context, orgID string) ([]domain.Table, error)
+	// Restore inserts tables in one transaction and calls verify with the org's rows as read back in that
+	// transaction; an error from verify rolls the restore back. Users and identities that already exist are kept,
+	// as they may belong to other orgs too. Returns false without writing anything when the org already exists.
+	Restore(ctx context.Context, orgID string, tables []domain.Table, verify func(restored []domain.Table) error) (bool, error)
+}
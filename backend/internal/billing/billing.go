@@ -0,0 +1,165 @@
+// Package billing gates features by an org's billing plan (free, team or enterprise; see organization/domain.Plan).
+// The feature matrix maps each gated feature to the lowest plan that includes it; handlers call Gate.Require before
+// serving a gated feature. Plans are assigned by platform admins with AdminService.SetOrgBillingPlan. Billing plans
+// are separate from the API quota plans of internal/quota. On self-hosted deployments the license file must also
+// include the feature (internal/license).
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/organization/domain"
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+)
+
+// Feature is a feature gated by plan.
+type Feature string
+
+const (
+	// FeatureCustomSMTP is sending the org's email through its own SMTP server (NotificationService
+	// UpdateOrgSMTPSettings).
+	FeatureCustomSMTP Feature = "custom_smtp"
+	// FeatureCustomRego is writing the org's own Rego policies (PolicyService CreatePolicy and UpdatePolicy, and
+	// policy change requests).
+	FeatureCustomRego Feature = "custom_rego"
+	// FeatureAuditStreaming is tailing the audit log, e.g. for export to a SIEM (AuditService StreamAuditEvents).
+	FeatureAuditStreaming Feature = "audit_streaming"
+)
+
+// ErrorInfo reasons of the errors of Require.
+const (
+	ReasonUpgradeRequired    = "UPGRADE_REQUIRED"
+	ReasonFeatureNotLicensed = "FEATURE_NOT_LICENSED"
+)
+
+// ErrOrgNotFound is returned by SetPlan for an org that does not exist.
+var ErrOrgNotFound = errors.New("billing: organization not found")
+
+// Plans lists the billing plans from the lowest to the highest.
+var Plans = []domain.Plan{domain.PlanFree, domain.PlanTeam, domain.PlanEnterprise}
+
+// Features lists the gated features in the order of the plan that includes them.
+var Features = []Feature{FeatureCustomSMTP, FeatureCustomRego, FeatureAuditStreaming}
+
+// requiredPlan is the feature matrix: the lowest plan that includes each feature.
+var requiredPlan = map[Feature]domain.Plan{
+	FeatureCustomSMTP:     domain.PlanTeam,
+	FeatureCustomRego:     domain.PlanEnterprise,
+	FeatureAuditStreaming: domain.PlanEnterprise,
+}
+
+// RequiredPlan returns the lowest plan that includes f.
+func RequiredPlan(f Feature) domain.Plan {
+	return requiredPlan[f]
+}
+
+// Includes reports whether plan includes f. An empty plan is free; unknown plans include nothing.
+func Includes(plan domain.Plan, f Feature) bool {
+	if plan == "" {
+		plan = domain.PlanFree
+	}
+	have, need := rank(plan), rank(requiredPlan[f])
+	return have >= 0 && need >= 0 && have >= need
+}
+
+// FeaturesOf returns the features plan includes, in the order of Features.
+func FeaturesOf(plan domain.Plan) []Feature {
+	var out []Feature
+	for _, f := range Features {
+		if Includes(plan, f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func rank(plan domain.Plan) int {
+	for i, p := range Plans {
+		if p == plan {
+			return i
+		}
+	}
+	return -1
+}
+
+// OrgStore reads and updates orgs (e.g. the organization repository).
+type OrgStore interface {
+	GetOrganizationByID(ctx context.Context, id string) (*domain.Org, error)
+	UpdateOrganization(ctx context.Context, o *domain.Org) error
+}
+
+// Licenser reports whether the deployment's license includes a feature (e.g. *license.Manager).
+type Licenser interface {
+	Allows(feature string) bool
+}
+
+// Gate refuses gated features to orgs whose plan does not include them. A nil *Gate allows everything.
+type Gate struct {
+	orgs     OrgStore
+	enforced func() bool
+	license  Licenser
+}
+
+// NewGate returns a Gate that reads and assigns plans with orgs. Features are refused only while enforced returns
+// true (PLAN_ENFORCEMENT_ENABLED), so plans can be assigned before enforcement is switched on; a nil enforced always
+// enforces. Features the license does not include are refused to every org whether plans are enforced or not;
+// license may be nil.
+func NewGate(orgs OrgStore, enforced func() bool, license Licenser) *Gate {
+	return &Gate{orgs: orgs, enforced: enforced, license: license}
+}
+
+// Enforced reports whether features are currently refused by plan.
+func (g *Gate) Enforced() bool {
+	return g != nil && (g.enforced == nil || g.enforced())
+}
+
+// Require returns nil when orgID's plan includes f or plans are not enforced. Otherwise it returns a PermissionDenied
+// status error with reason UPGRADE_REQUIRED and the feature, the required plan and the org's plan in its metadata.
+// Unknown orgs are allowed, leaving the not-found error to the handler. A feature the license does not include is
+// refused first, with reason FEATURE_NOT_LICENSED and the feature in its metadata.
+func (g *Gate) Require(ctx context.Context, orgID string, f Feature) error {
+	if g != nil && g.license != nil && !g.license.Allows(string(f)) {
+		msg := fmt.Sprintf("feature not licensed: %s", f)
+		return i18n.Error(codes.PermissionDenied, ReasonFeatureNotLicensed, msg, map[string]string{"feature": string(f)}).Err()
+	}
+	if !g.Enforced() || orgID == "" {
+		return nil
+	}
+	org, err := g.orgs.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to load organization plan")
+	}
+	if org == nil || Includes(org.Plan, f) {
+		return nil
+	}
+	plan := org.Plan
+	if plan == "" {
+		plan = domain.PlanFree
+	}
+	need := RequiredPlan(f)
+	msg := fmt.Sprintf("upgrade required: %s needs the %s plan", f, need)
+	return i18n.Error(codes.PermissionDenied, ReasonUpgradeRequired, msg, map[string]string{
+		"feature": string(f), "plan": string(need), "current_plan": string(plan),
+	}).Err()
+}
+
+// SetPlan assigns plan to orgID and returns the updated org, or ErrOrgNotFound.
+func (g *Gate) SetPlan(ctx context.Context, orgID string, plan domain.Plan) (*domain.Org, error) {
+	org, err := g.orgs.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, ErrOrgNotFound
+	}
+	org.Plan = plan
+	if err := g.orgs.UpdateOrganization(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
@@ -0,0 +1,125 @@
+package billing
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/organization/domain"
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+)
+
+type memOrgs map[string]*domain.Org
+
+func (m memOrgs) GetOrganizationByID(ctx context.Context, id string) (*domain.Org, error) {
+	if o, ok := m[id]; ok {
+		cp := *o
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (m memOrgs) UpdateOrganization(ctx context.Context, o *domain.Org) error {
+	cp := *o
+	m[o.ID] = &cp
+	return nil
+}
+
+func TestIncludes(t *testing.T) {
+	cases := []struct {
+		plan domain.Plan
+		want []Feature
+	}{
+		{domain.PlanFree, nil},
+		{"", nil},
+		{domain.PlanTeam, []Feature{FeatureCustomSMTP}},
+		{domain.PlanEnterprise, []Feature{FeatureCustomSMTP, FeatureCustomRego, FeatureAuditStreaming}},
+		{"platinum", nil},
+	}
+	for _, tc := range cases {
+		got := FeaturesOf(tc.plan)
+		if len(got) != len(tc.want) {
+			t.Errorf("FeaturesOf(%q) = %v, want %v", tc.plan, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("FeaturesOf(%q) = %v, want %v", tc.plan, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestGate_Require(t *testing.T) {
+	orgs := memOrgs{
+		"org-free": {ID: "org-free", Plan: domain.PlanFree},
+		"org-team": {ID: "org-team", Plan: domain.PlanTeam},
+	}
+	enforced := true
+	g := NewGate(orgs, func() bool { return enforced }, nil)
+	ctx := context.Background()
+
+	if err := g.Require(ctx, "org-team", FeatureCustomSMTP); err != nil {
+		t.Errorf("team custom_smtp: %v", err)
+	}
+	if err := g.Require(ctx, "unknown", FeatureCustomRego); err != nil {
+		t.Errorf("unknown org: %v", err)
+	}
+	err := g.Require(ctx, "org-team", FeatureCustomRego)
+	st := status.Convert(err)
+	if st.Code() != codes.PermissionDenied {
+		t.Fatalf("team custom_rego code = %v, want PermissionDenied", st.Code())
+	}
+	info := i18n.ErrorInfo(st)
+	if info == nil || info.GetReason() != ReasonUpgradeRequired || info.GetMetadata()["plan"] != "enterprise" || info.GetMetadata()["current_plan"] != "team" {
+		t.Errorf("ErrorInfo = %v, want UPGRADE_REQUIRED for enterprise from team", info)
+	}
+
+	enforced = false
+	if err := g.Require(ctx, "org-free", FeatureAuditStreaming); err != nil {
+		t.Errorf("not enforced: %v", err)
+	}
+	var nilGate *Gate
+	if err := nilGate.Require(ctx, "org-free", FeatureAuditStreaming); err != nil {
+		t.Errorf("nil gate: %v", err)
+	}
+}
+
+type licensed map[string]bool
+
+func (l licensed) Allows(feature string) bool { return l[feature] }
+
+func TestGate_RequireLicense(t *testing.T) {
+	orgs := memOrgs{"org-1": {ID: "org-1", Plan: domain.PlanEnterprise}}
+	g := NewGate(orgs, func() bool { return false }, licensed{"custom_smtp": true})
+	ctx := context.Background()
+
+	if err := g.Require(ctx, "org-1", FeatureCustomSMTP); err != nil {
+		t.Errorf("licensed custom_smtp: %v", err)
+	}
+	st := status.Convert(g.Require(ctx, "org-1", FeatureCustomRego))
+	info := i18n.ErrorInfo(st)
+	if st.Code() != codes.PermissionDenied || info == nil || info.GetReason() != ReasonFeatureNotLicensed || info.GetMetadata()["feature"] != "custom_rego" {
+		t.Errorf("unlicensed custom_rego = %v %v, want PermissionDenied with FEATURE_NOT_LICENSED even without plan enforcement", st.Code(), info)
+	}
+}
+
+func TestGate_SetPlan(t *testing.T) {
+	orgs := memOrgs{"org-1": {ID: "org-1", Name: "Acme", Plan: domain.PlanFree}}
+	g := NewGate(orgs, nil, nil)
+	org, err := g.SetPlan(context.Background(), "org-1", domain.PlanEnterprise)
+	if err != nil {
+		t.Fatalf("SetPlan: %v", err)
+	}
+	if org.Plan != domain.PlanEnterprise || orgs["org-1"].Plan != domain.PlanEnterprise || orgs["org-1"].Name != "Acme" {
+		t.Errorf("stored org = %+v", orgs["org-1"])
+	}
+	if err := g.Require(context.Background(), "org-1", FeatureCustomRego); err != nil {
+		t.Errorf("enterprise custom_rego after SetPlan: %v", err)
+	}
+	if _, err := g.SetPlan(context.Background(), "missing", domain.PlanTeam); err != ErrOrgNotFound {
+		t.Errorf("SetPlan(missing) = %v, want ErrOrgNotFound", err)
+	}
+}
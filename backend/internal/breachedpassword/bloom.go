@@ -0,0 +1,124 @@
+package breachedpassword
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"os"
+)
+
+// bloomMagic starts every Bloom filter file; the header is followed by the bit array.
+var bloomMagic = [4]byte{'Z', 'T', 'B', 'F'}
+
+const maxBloomBits = 1 << 36 // 8 GiB of bits; guards against corrupt headers
+
+// ErrInvalidBloomFile is returned by ReadBloomFilter for files not written by BloomFilter.WriteTo.
+var ErrInvalidBloomFile = errors.New("breachedpassword: invalid bloom filter file")
+
+// BloomFilter is a Bloom filter over SHA-1 password hashes. Lookups never leave the process; false positives
+// (a clean password reported breached) occur at the rate the filter was sized for, false negatives never.
+type BloomFilter struct {
+	bits []byte
+	m    uint64 // number of bits
+	k    uint32 // number of hash functions
+}
+
+// NewBloomFilter returns an empty filter sized for n hashes at false-positive rate p (e.g. 0.001).
+func NewBloomFilter(n uint64, p float64) *BloomFilter {
+	if n == 0 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = 0.001
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := uint32(math.Max(1, math.Round(float64(m)/float64(n)*math.Ln2)))
+	return &BloomFilter{bits: make([]byte, (m+7)/8), m: m, k: k}
+}
+
+// AddHash adds a 40-character hex SHA-1 hash (either case), as found in HIBP hash lists.
+func (f *BloomFilter) AddHash(hexHash string) error {
+	sum, err := hex.DecodeString(hexHash)
+	if err != nil || len(sum) != 20 {
+		return errors.New("breachedpassword: hash must be 40 hex characters")
+	}
+	f.add(sum)
+	return nil
+}
+
+// Breached reports whether the password's SHA-1 hash may be in the filter.
+func (f *BloomFilter) Breached(_ context.Context, password string) (bool, error) {
+	sum, _ := hex.DecodeString(sha1Hex(password))
+	for _, bit := range f.positions(sum) {
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (f *BloomFilter) add(sum []byte) {
+	for _, bit := range f.positions(sum) {
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// positions derives k bit positions from the (already uniformly distributed) SHA-1 by double hashing.
+func (f *BloomFilter) positions(sum []byte) []uint64 {
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16]) | 1
+	out := make([]uint64, f.k)
+	for i := range out {
+		out[i] = (h1 + uint64(i)*h2) % f.m
+	}
+	return out
+}
+
+// WriteTo writes the filter as magic, bit count (uint64), hash count (uint32), then the bit array.
+func (f *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	header := make([]byte, 16)
+	copy(header, bloomMagic[:])
+	binary.BigEndian.PutUint64(header[4:12], f.m)
+	binary.BigEndian.PutUint32(header[12:16], f.k)
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(f.bits)
+	return int64(n + m), err
+}
+
+// ReadBloomFilter reads a filter written by WriteTo.
+func ReadBloomFilter(r io.Reader) (*BloomFilter, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrInvalidBloomFile
+	}
+	if [4]byte(header[0:4]) != bloomMagic {
+		return nil, ErrInvalidBloomFile
+	}
+	m := binary.BigEndian.Uint64(header[4:12])
+	k := binary.BigEndian.Uint32(header[12:16])
+	if m == 0 || m > maxBloomBits || k == 0 || k > 64 {
+		return nil, ErrInvalidBloomFile
+	}
+	f := &BloomFilter{bits: make([]byte, (m+7)/8), m: m, k: k}
+	if _, err := io.ReadFull(r, f.bits); err != nil {
+		return nil, ErrInvalidBloomFile
+	}
+	return f, nil
+}
+
+// LoadBloomFilter reads a filter file from path.
+func LoadBloomFilter(path string) (*BloomFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadBloomFilter(bufio.NewReader(file))
+}
@@ -0,0 +1,50 @@
+package breachedpassword
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	f := NewBloomFilter(100, 0.001)
+	if err := f.AddHash(sha1Hex("password123")); err != nil {
+		t.Fatalf("AddHash: %v", err)
+	}
+	if err := f.AddHash("not-a-hash"); err == nil {
+		t.Error("AddHash should reject non-hex input")
+	}
+	ctx := context.Background()
+	if breached, _ := f.Breached(ctx, "password123"); !breached {
+		t.Error("added password should be reported breached")
+	}
+	if breached, _ := f.Breached(ctx, "Correct-Horse-Battery-Staple-42"); breached {
+		t.Error("password not in the filter should be reported clean")
+	}
+
+	var buf bytes.Buffer
+	if _, err := f.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	loaded, err := ReadBloomFilter(&buf)
+	if err != nil {
+		t.Fatalf("ReadBloomFilter: %v", err)
+	}
+	if breached, _ := loaded.Breached(ctx, "password123"); !breached {
+		t.Error("loaded filter should report the added password breached")
+	}
+	if _, err := ReadBloomFilter(bytes.NewReader([]byte("ZTBF\x00"))); err != ErrInvalidBloomFile {
+		t.Errorf("truncated file: want ErrInvalidBloomFile, got %v", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"off": ModeOff, " Warn ": ModeWarn, "BLOCK": ModeBlock} {
+		if got, err := ParseMode(in); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseMode("deny"); err == nil {
+		t.Error("ParseMode should reject unknown modes")
+	}
+}
@@ -0,0 +1,41 @@
+// Package breachedpassword checks passwords against known breach corpora without sending or storing the password:
+// a local Bloom filter of SHA-1 hashes, or a k-anonymity range API in the style of Have I Been Pwned.
+package breachedpassword
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Checker reports whether a password appears in a breach corpus.
+type Checker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// Mode is what a flow does with a breached password.
+type Mode string
+
+const (
+	ModeOff   Mode = "off"   // do not check
+	ModeWarn  Mode = "warn"  // accept the password and tell the client it is breached
+	ModeBlock Mode = "block" // reject the password
+)
+
+// ParseMode parses "off", "warn" or "block" (case-insensitive).
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case ModeOff, ModeWarn, ModeBlock:
+		return m, nil
+	default:
+		return "", fmt.Errorf("breached password mode must be off, warn or block, got %q", s)
+	}
+}
+
+// sha1Hex returns the uppercase hex SHA-1 of password, the form used by HIBP range queries and hash lists.
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
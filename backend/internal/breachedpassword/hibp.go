@@ -0,0 +1,60 @@
+package breachedpassword
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultHIBPTimeout = 5 * time.Second
+
+// HIBPClient queries a k-anonymity password range API (https://haveibeenpwned.com/API/v3#PwnedPasswords).
+// Only the first 5 hex characters of the password's SHA-1 leave the process; the suffix is matched locally.
+type HIBPClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewHIBPClient returns a client for the range API at baseURL (default https://api.pwnedpasswords.com).
+func NewHIBPClient(baseURL string) *HIBPClient {
+	if baseURL == "" {
+		baseURL = "https://api.pwnedpasswords.com"
+	}
+	return &HIBPClient{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: defaultHIBPTimeout},
+	}
+}
+
+// Breached fetches the hash range for the password's prefix and reports whether its suffix is listed with a
+// non-zero count. Padding entries (count 0) are ignored.
+func (c *HIBPClient) Breached(ctx context.Context, password string) (bool, error) {
+	hash := sha1Hex(password)
+	prefix, suffix := hash[:5], hash[5:]
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Add-Padding", "true")
+	req.Header.Set("User-Agent", "zero-trust-control-plane")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("breachedpassword: range request failed status=%d", resp.StatusCode)
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		s, count, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(s, suffix) {
+			return strings.TrimLeft(count, "0") != "", nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,45 @@
+package breachedpassword
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHIBPClient_Breached(t *testing.T) {
+	hash := sha1Hex("password123")
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Error("request should ask for padding")
+		}
+		fmt.Fprintf(w, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n%s:42\r\n%s:0\r\n", hash[5:], sha1Hex("padded")[5:])
+	}))
+	defer srv.Close()
+	c := NewHIBPClient(srv.URL)
+	ctx := context.Background()
+
+	breached, err := c.Breached(ctx, "password123")
+	if err != nil || !breached {
+		t.Fatalf("Breached = %v, %v; want true", breached, err)
+	}
+	if gotPath != "/range/"+hash[:5] {
+		t.Errorf("path = %q, want only the 5-character prefix", gotPath)
+	}
+	if breached, _ := c.Breached(ctx, "padded"); breached {
+		t.Error("padding entry (count 0) should not count as breached")
+	}
+}
+
+func TestHIBPClient_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	if _, err := NewHIBPClient(srv.URL).Breached(context.Background(), "x"); err == nil {
+		t.Error("non-200 response should be an error")
+	}
+}
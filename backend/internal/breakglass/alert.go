@@ -0,0 +1,125 @@
+// Package breakglass provisions per-org break-glass accounts, alerts on every step of their use and ends their
+// access windows.
+package breakglass
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/notification"
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+// Event types.
+const (
+	EventUnlockRequested = "break_glass.unlock_requested"
+	EventUnlocked        = "break_glass.unlocked"
+	EventSignedIn        = "break_glass.signed_in"
+	EventSignInRejected  = "break_glass.sign_in_rejected"
+	EventEnded           = "break_glass.ended"
+)
+
+// Event is a break-glass alert. Actor is the user ID of the platform admin who acted, or of the break-glass user
+// for sign-ins; empty when the system ended the window.
+type Event struct {
+	Type         string     `json:"type"`
+	OrgID        string     `json:"org_id"`
+	ActivationID string     `json:"activation_id,omitempty"` // empty for sign-ins rejected before an activation was found
+	Actor        string     `json:"actor,omitempty"`
+	Reason       string     `json:"reason,omitempty"` // unlock reason, or why a sign-in was rejected
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	IP           string     `json:"ip,omitempty"` // client IP of sign-ins
+	OccurredAt   time.Time  `json:"occurred_at"`
+}
+
+// Alerter delivers break-glass events. Alert is best-effort and must not block the caller.
+type Alerter interface {
+	Alert(ctx context.Context, e Event)
+}
+
+// Alerters delivers each event to every alerter in the list.
+type Alerters []Alerter
+
+// Alert sends e to every alerter.
+func (a Alerters) Alert(ctx context.Context, e Event) {
+	for _, alerter := range a {
+		alerter.Alert(ctx, e)
+	}
+}
+
+// WebhookAlerter POSTs events as JSON to a URL. When Secret is set each request is signed (webhook.SignatureHeader).
+type WebhookAlerter struct {
+	URL        string
+	Secret     string
+	HTTPClient *http.Client
+}
+
+// NewWebhookAlerter returns an alerter that posts to url, signing with secret when it is non-empty.
+func NewWebhookAlerter(url, secret string) *WebhookAlerter {
+	return &WebhookAlerter{
+		URL:        url,
+		Secret:     secret,
+		HTTPClient: webhook.NewHTTPClient(),
+	}
+}
+
+// Alert sends e in the background. Failures are logged and not retried.
+func (w *WebhookAlerter) Alert(ctx context.Context, e Event) {
+	webhook.Go(fmt.Sprintf("breakglass: webhook %s for org %s", e.Type, e.OrgID), func(ctx context.Context) error { return w.Send(ctx, e) })
+}
+
+// Send posts the break-glass event e and waits for the response. Any non-2xx status is an error.
+func (w *WebhookAlerter) Send(ctx context.Context, e Event) error {
+	return webhook.Post(ctx, w.HTTPClient, w.URL, w.Secret, e)
+}
+
+// EmailAlerter emails every event to a fixed list of recipients (e.g. the security team).
+type EmailAlerter struct {
+	sender     notification.EmailSender
+	recipients []string
+}
+
+// NewEmailAlerter returns an alerter that emails recipients through sender.
+func NewEmailAlerter(sender notification.EmailSender, recipients []string) *EmailAlerter {
+	return &EmailAlerter{sender: sender, recipients: recipients}
+}
+
+// Alert emails e in the background. Failures are logged and not retried.
+func (m *EmailAlerter) Alert(ctx context.Context, e Event) {
+	subject, body := FormatEmail(e)
+	go func() {
+		for _, to := range m.recipients {
+			if err := m.sender.SendEmail(to, subject, body); err != nil {
+				log.Printf("breakglass: email %s for org %s to %s failed: %v", e.Type, e.OrgID, to, err)
+			}
+		}
+	}()
+}
+
+// FormatEmail returns the subject and plain-text body of the alert email for e.
+func FormatEmail(e Event) (subject, body string) {
+	what := strings.TrimPrefix(e.Type, "break_glass.")
+	subject = fmt.Sprintf("[break-glass] %s for org %s", strings.ReplaceAll(what, "_", " "), e.OrgID)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Break-glass event %s in org %s at %s.\n\n", e.Type, e.OrgID, e.OccurredAt.UTC().Format(time.RFC3339))
+	if e.ActivationID != "" {
+		fmt.Fprintf(&b, "Activation: %s\n", e.ActivationID)
+	}
+	if e.Actor != "" {
+		fmt.Fprintf(&b, "Actor: %s\n", e.Actor)
+	}
+	if e.Reason != "" {
+		fmt.Fprintf(&b, "Reason: %s\n", e.Reason)
+	}
+	if e.ExpiresAt != nil {
+		fmt.Fprintf(&b, "Access ends: %s\n", e.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if e.IP != "" {
+		fmt.Fprintf(&b, "Client IP: %s\n", e.IP)
+	}
+	return subject, b.String()
+}
@@ -0,0 +1,77 @@
+package breakglass
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+func TestWebhookAlerter_Send(t *testing.T) {
+	var got Event
+	var body []byte
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(webhook.SignatureHeader)
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	e := Event{Type: EventSignedIn, OrgID: "org-1", ActivationID: "a1", Actor: "bg-user", IP: "203.0.113.7", OccurredAt: time.Now().UTC()}
+	if err := NewWebhookAlerter(srv.URL, "s3cret").Send(context.Background(), e); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Type != EventSignedIn || got.ActivationID != "a1" || got.IP != "203.0.113.7" {
+		t.Errorf("delivered event = %+v", got)
+	}
+	if signature != webhook.Sign("s3cret", body) {
+		t.Errorf("signature = %q, want HMAC of body", signature)
+	}
+}
+
+func TestWebhookAlerter_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	if err := NewWebhookAlerter(srv.URL, "").Send(context.Background(), Event{Type: EventEnded}); err == nil {
+		t.Error("non-2xx response should be an error")
+	}
+}
+
+type recordingEmail struct {
+	sent chan string
+}
+
+func (r *recordingEmail) SendEmail(to, subject, body string) error {
+	r.sent <- to + "|" + subject
+	return nil
+}
+
+func TestEmailAlerter(t *testing.T) {
+	email := &recordingEmail{sent: make(chan string, 2)}
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	NewEmailAlerter(email, []string{"sec@example.com", "oncall@example.com"}).Alert(context.Background(), Event{
+		Type: EventUnlocked, OrgID: "org-1", ActivationID: "a1", Actor: "admin-2", ExpiresAt: &expires, OccurredAt: expires.Add(-time.Hour),
+	})
+	for _, want := range []string{"sec@example.com|[break-glass] unlocked for org org-1", "oncall@example.com|[break-glass] unlocked for org org-1"} {
+		select {
+		case got := <-email.sent:
+			if got != want {
+				t.Errorf("sent %q, want %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("email to %s not sent", strings.SplitN(want, "|", 2)[0])
+		}
+	}
+	if _, body := FormatEmail(Event{Type: EventUnlocked, OrgID: "org-1", ExpiresAt: &expires}); !strings.Contains(body, "Access ends: 2026-01-02T03:04:05Z") {
+		t.Errorf("body = %q", body)
+	}
+}
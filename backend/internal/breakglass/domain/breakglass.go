@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// Status is where an activation is in its lifecycle.
+type Status string
+
+const (
+	// StatusPending awaits a second platform admin's approval. It lapses after PendingTTL.
+	StatusPending Status = "pending"
+	// StatusActive has the account unlocked until ExpiresAt.
+	StatusActive Status = "active"
+	// StatusEnded is over (window ran out or ended early) and awaits its post-incident report. While an org has an
+	// ended activation, the account cannot be unlocked again.
+	StatusEnded Status = "ended"
+	// StatusClosed has its post-incident report.
+	StatusClosed Status = "closed"
+	// StatusLapsed is reported for pending activations older than PendingTTL; it is never stored.
+	StatusLapsed Status = "lapsed"
+)
+
+const (
+	// PendingTTL is how long an unlock request waits for its second approver.
+	PendingTTL = time.Hour
+	// MaxReasonLength caps the unlock reason and the post-incident report summary.
+	MaxReasonLength = 2000
+	// SecretPrefix starts every break-glass credential, so leaked credentials are easy to recognise.
+	SecretPrefix = "ztcp_bg_"
+)
+
+// Account is an org's break-glass account: a user with the org owner role and no password, who can only sign in with
+// the sealed credential while an activation is active.
+type Account struct {
+	OrgID         string
+	UserID        string
+	DeviceID      string // the device recorded on break-glass sessions
+	SecretHash    string
+	ProvisionedBy string // platform admin who (re)issued the credential
+	ProvisionedAt time.Time
+}
+
+// Activation is one use of an org's break-glass account: requested by a platform admin, unlocked by another, ended
+// when its window runs out or early, and closed with a post-incident report.
+type Activation struct {
+	ID            string
+	OrgID         string
+	UserID        string // the break-glass account's user
+	Status        Status
+	Reason        string
+	RequestedBy   string
+	RequestedAt   time.Time
+	ApprovedBy    string
+	ApprovedAt    *time.Time
+	ExpiresAt     *time.Time // end of the unlock window; set on approval
+	EndedBy       string     // empty when the window ran out
+	EndedAt       *time.Time
+	ReportSummary string
+	ReportedBy    string
+	ReportedAt    *time.Time
+}
+
+// Active reports whether the account is unlocked by this activation at now.
+func (a *Activation) Active(now time.Time) bool {
+	return a.Status == StatusActive && a.ExpiresAt != nil && now.Before(*a.ExpiresAt)
+}
+
+// EffectiveStatus is Status, except that a pending activation older than PendingTTL is lapsed and an active one whose
+// window has ended is ended, even before the expiry job has marked it.
+func (a *Activation) EffectiveStatus(now time.Time) Status {
+	switch {
+	case a.Status == StatusPending && !now.Before(a.RequestedAt.Add(PendingTTL)):
+		return StatusLapsed
+	case a.Status == StatusActive && !a.Active(now):
+		return StatusEnded
+	}
+	return a.Status
+}
+
+// NewSecret returns a new random break-glass credential and its hash.
+func NewSecret() (secret, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	secret = SecretPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return secret, HashSecret(secret), nil
+}
+
+// HashSecret returns the stored form of a credential. The credential is random, so a plain SHA-256 suffices.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// SecretMatches reports whether secret hashes to hash, in constant time.
+func SecretMatches(secret, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(hash)) == 1
+}
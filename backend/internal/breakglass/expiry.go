@@ -0,0 +1,78 @@
+package breakglass
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/breakglass/domain"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// EndedExpirer marks activations whose window has ended as ended. Implemented by the break-glass repository.
+type EndedExpirer interface {
+	ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Activation, error)
+}
+
+// SessionRevoker revokes the break-glass user's sessions when an access window ends. Implemented by the session
+// repository.
+type SessionRevoker interface {
+	RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev sessiondomain.Revocation) error
+}
+
+// ExpiryJob ends activations whose window ran out: it marks them ended, revokes the break-glass user's sessions,
+// audits each as break_glass_ended and alerts. Break-glass access tokens never outlive the window whether or not the
+// job has run; the job revokes the sessions and starts the post-incident report.
+type ExpiryJob struct {
+	repo        EndedExpirer
+	sessions    SessionRevoker
+	auditLogger audit.AuditLogger
+	alerter     Alerter
+	now         func() time.Time
+}
+
+// NewExpiryJob returns an ExpiryJob. auditLogger and alerter may be nil.
+func NewExpiryJob(repo EndedExpirer, sessions SessionRevoker, auditLogger audit.AuditLogger, alerter Alerter) *ExpiryJob {
+	return &ExpiryJob{repo: repo, sessions: sessions, auditLogger: auditLogger, alerter: alerter, now: time.Now}
+}
+
+// RunOnce ends every activation whose window ran out and returns how many it ended. A failed session revocation is
+// logged and does not stop the run; the sessions' access tokens have expired anyway.
+func (j *ExpiryJob) RunOnce(ctx context.Context) (int, error) {
+	now := j.now().UTC()
+	ended, err := j.repo.ExpireEnded(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, a := range ended {
+		if err := j.sessions.RevokeAllSessionsByUserAndOrg(ctx, a.UserID, a.OrgID, sessiondomain.Revocation{Reason: sessiondomain.RevocationBreakGlass}); err != nil {
+			log.Printf("breakglass: revoke sessions of activation %s: %v", a.ID, err)
+		}
+		if j.auditLogger != nil {
+			meta, _ := json.Marshal(map[string]interface{}{"activation_id": a.ID, "expires_at": a.ExpiresAt})
+			j.auditLogger.LogEvent(ctx, a.OrgID, a.UserID, "break_glass_ended", "break_glass", string(meta))
+		}
+		if j.alerter != nil {
+			j.alerter.Alert(ctx, Event{Type: EventEnded, OrgID: a.OrgID, ActivationID: a.ID, ExpiresAt: a.ExpiresAt, OccurredAt: now})
+		}
+	}
+	return len(ended), nil
+}
+
+// Run calls RunOnce on start and then every interval until ctx is cancelled.
+func (j *ExpiryJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := j.RunOnce(ctx); err != nil {
+			log.Printf("breakglass: expiry run failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package breakglass
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/breakglass/domain"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// staticActivations implements EndedExpirer over a fixed list, ending each activation at most once.
+type staticActivations []*domain.Activation
+
+func (s staticActivations) ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Activation, error) {
+	var out []*domain.Activation
+	for _, a := range s {
+		if a.Status == domain.StatusActive && !now.Before(*a.ExpiresAt) {
+			a.Status = domain.StatusEnded
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+type recordingRevoker struct {
+	revoked []string
+}
+
+func (r *recordingRevoker) RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev sessiondomain.Revocation) error {
+	r.revoked = append(r.revoked, userID+":"+orgID+":"+string(rev.Reason))
+	return nil
+}
+
+type recordingAuditLogger struct {
+	actions []string
+}
+
+func (l *recordingAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	l.actions = append(l.actions, action)
+}
+
+type recordingAlerter struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (a *recordingAlerter) Alert(ctx context.Context, e Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events = append(a.events, e)
+}
+
+func active(id, orgID string, expiresAt time.Time) *domain.Activation {
+	return &domain.Activation{ID: id, OrgID: orgID, UserID: "bg-" + orgID, Status: domain.StatusActive, ExpiresAt: &expiresAt}
+}
+
+func TestExpiryJob(t *testing.T) {
+	now := time.Now()
+	activations := staticActivations{
+		active("a1", "org-1", now.Add(-time.Minute)),
+		active("a2", "org-2", now.Add(time.Hour)),
+	}
+	sessions, audit, alerts := &recordingRevoker{}, &recordingAuditLogger{}, &recordingAlerter{}
+	job := NewExpiryJob(activations, sessions, audit, alerts)
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("RunOnce = %d, %v; want 1", n, err)
+	}
+	if len(sessions.revoked) != 1 || sessions.revoked[0] != "bg-org-1:org-1:break_glass" {
+		t.Errorf("revoked = %v", sessions.revoked)
+	}
+	if len(audit.actions) != 1 || audit.actions[0] != "break_glass_ended" {
+		t.Errorf("audit actions = %v", audit.actions)
+	}
+	if len(alerts.events) != 1 || alerts.events[0].Type != EventEnded || alerts.events[0].ActivationID != "a1" {
+		t.Errorf("alerts = %+v", alerts.events)
+	}
+	if n, _ := job.RunOnce(context.Background()); n != 0 {
+		t.Errorf("second RunOnce ended %d, want 0", n)
+	}
+}
@@ -0,0 +1,570 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	breakglassv1 "zero-trust-control-plane/backend/api/generated/breakglass/v1"
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+	"zero-trust-control-plane/backend/internal/breakglass"
+	"zero-trust-control-plane/backend/internal/breakglass/domain"
+	"zero-trust-control-plane/backend/internal/breakglass/repository"
+	"zero-trust-control-plane/backend/internal/license"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+	// maxReportEntries caps the audit entries of GetReport; longer trails are marked truncated.
+	maxReportEntries = 1000
+)
+
+// Provisioner creates an org's break-glass user and device (breakglass.Provisioner).
+type Provisioner interface {
+	Provision(ctx context.Context, orgID string) (userID, deviceID string, err error)
+}
+
+// OrgGetter resolves orgs so accounts are only provisioned for orgs that exist.
+type OrgGetter interface {
+	GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error)
+}
+
+// SessionStore is the subset of the session repository used to create break-glass sessions and revoke them when
+// the access window ends.
+type SessionStore interface {
+	Create(ctx context.Context, s *sessiondomain.Session) error
+	RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev sessiondomain.Revocation) error
+}
+
+// AccessIssuer issues access tokens that expire with the access window (e.g. *security.TokenProvider).
+type AccessIssuer interface {
+	IssueAccessUntil(ctx context.Context, sessionID, userID, orgID string, notAfter time.Time) (token string, jti string, expiresAt time.Time, err error)
+}
+
+// AuditReader reads the audit trail of the post-incident report.
+type AuditReader interface {
+	ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*auditdomain.AuditLog, error)
+}
+
+// Server implements BreakGlassService (proto server).
+// Proto: breakglass/breakglass.proto → internal/breakglass/handler.
+type Server struct {
+	breakglassv1.UnimplementedBreakGlassServiceServer
+	repo        repository.Repository
+	provisioner Provisioner
+	orgs        OrgGetter
+	sessions    SessionStore
+	tokens      AccessIssuer
+	auditLogs   AuditReader
+	admins      rbac.PlatformAdminChecker
+	auditLogger audit.AuditLogger
+	alerter     breakglass.Alerter
+	sessionTTL  time.Duration
+	now         func() time.Time
+}
+
+// NewServer returns a new BreakGlass gRPC server. If repo is nil, all RPCs return Unimplemented; if provisioner is
+// nil, ProvisionBreakGlassAccount does; if sessions or tokens is nil, SignIn does; if auditLogs is nil, GetReport
+// does. Approved unlocks last sessionTTL. orgs, auditLogger and alerter may be nil.
+func NewServer(repo repository.Repository, provisioner Provisioner, orgs OrgGetter, sessions SessionStore, tokens AccessIssuer, auditLogs AuditReader, admins rbac.PlatformAdminChecker, auditLogger audit.AuditLogger, alerter breakglass.Alerter, sessionTTL time.Duration) *Server {
+	return &Server{
+		repo:        repo,
+		provisioner: provisioner,
+		orgs:        orgs,
+		sessions:    sessions,
+		tokens:      tokens,
+		auditLogs:   auditLogs,
+		admins:      admins,
+		auditLogger: auditLogger,
+		alerter:     alerter,
+		sessionTTL:  sessionTTL,
+		now:         time.Now,
+	}
+}
+
+// ProvisionBreakGlassAccount creates the org's break-glass account, or rotates its credential keeping its user and
+// device. The credential is returned once. A new account needs a free license seat (ResourceExhausted, or
+// FailedPrecondition once the license has expired) and room in the org's quotas (ResourceExhausted). Platform admins
+// only; audited as break_glass_provisioned or break_glass_rotated.
+func (s *Server) ProvisionBreakGlassAccount(ctx context.Context, req *breakglassv1.ProvisionBreakGlassAccountRequest) (*breakglassv1.ProvisionBreakGlassAccountResponse, error) {
+	if s.repo == nil || s.provisioner == nil {
+		return nil, status.Error(codes.Unimplemented, "method ProvisionBreakGlassAccount not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	orgID := req.GetOrgId()
+	if orgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	if s.orgs != nil {
+		org, err := s.orgs.GetOrganizationByID(ctx, orgID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to load organization")
+		}
+		if org == nil {
+			return nil, status.Error(codes.NotFound, "organization not found")
+		}
+	}
+	now := s.now().UTC()
+	account, err := s.repo.GetAccount(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load break-glass account")
+	}
+	action := "break_glass_rotated"
+	if account != nil {
+		active, err := s.repo.GetActiveActivation(ctx, orgID, now)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to check break-glass activations")
+		}
+		if active != nil {
+			return nil, status.Error(codes.FailedPrecondition, "cannot rotate the credential while the account is unlocked")
+		}
+	} else {
+		action = "break_glass_provisioned"
+		breakGlassUserID, deviceID, err := s.provisioner.Provision(ctx, orgID)
+		switch {
+		case errors.Is(err, orgquota.ErrUserQuotaExceeded) || errors.Is(err, orgquota.ErrDeviceQuotaExceeded):
+			return nil, status.Error(codes.ResourceExhausted, "organization has reached its user or device quota")
+		case errors.Is(err, license.ErrSeatLimitReached):
+			return nil, status.Error(codes.ResourceExhausted, "every seat of the license is taken")
+		case errors.Is(err, license.ErrLicenseExpired):
+			return nil, status.Error(codes.FailedPrecondition, "the license has expired")
+		case err != nil:
+			return nil, status.Error(codes.Internal, "failed to provision break-glass user")
+		}
+		account = &domain.Account{OrgID: orgID, UserID: breakGlassUserID, DeviceID: deviceID}
+	}
+	secret, hash, err := domain.NewSecret()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate credential")
+	}
+	account.SecretHash, account.ProvisionedBy, account.ProvisionedAt = hash, userID, now
+	if err := s.repo.SaveAccount(ctx, account); err != nil {
+		return nil, status.Error(codes.Internal, "failed to store break-glass account")
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"user_id": account.UserID})
+		s.auditLogger.LogEvent(ctx, orgID, userID, action, "break_glass", string(meta))
+	}
+	return &breakglassv1.ProvisionBreakGlassAccountResponse{Account: accountToProto(account), Secret: secret}, nil
+}
+
+// RequestUnlock stores a pending unlock request for the org's break-glass account. Refused while the org has a
+// pending, active or unreported activation. Platform admins only; audited as break_glass_unlock_requested and
+// alerted.
+func (s *Server) RequestUnlock(ctx context.Context, req *breakglassv1.RequestUnlockRequest) (*breakglassv1.RequestUnlockResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RequestUnlock not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	orgID := req.GetOrgId()
+	if orgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	reason, err := validateText("reason", req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.repo.GetAccount(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load break-glass account")
+	}
+	if account == nil {
+		return nil, status.Error(codes.FailedPrecondition, "org has no break-glass account")
+	}
+	now := s.now().UTC()
+	open, err := s.repo.HasOpenActivation(ctx, orgID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check break-glass activations")
+	}
+	if open {
+		return nil, status.Error(codes.FailedPrecondition, "org has a pending, active or unreported break-glass activation")
+	}
+	a := &domain.Activation{
+		ID:          uuid.New().String(),
+		OrgID:       orgID,
+		UserID:      account.UserID,
+		Status:      domain.StatusPending,
+		Reason:      reason,
+		RequestedBy: userID,
+		RequestedAt: now,
+	}
+	if err := s.repo.CreateActivation(ctx, a); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create break-glass activation")
+	}
+	s.record(ctx, a, "break_glass_unlock_requested", userID, map[string]interface{}{"reason": reason})
+	s.alert(ctx, breakglass.Event{Type: breakglass.EventUnlockRequested, OrgID: orgID, ActivationID: a.ID, Actor: userID, Reason: reason, OccurredAt: now})
+	return &breakglassv1.RequestUnlockResponse{Activation: s.activationToProto(a)}, nil
+}
+
+// ApproveUnlock unlocks the account for the configured session TTL. The approver must be a different platform
+// admin than the requester. Audited as break_glass_unlocked and alerted.
+func (s *Server) ApproveUnlock(ctx context.Context, req *breakglassv1.ApproveUnlockRequest) (*breakglassv1.ApproveUnlockResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ApproveUnlock not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.load(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if a.RequestedBy == userID {
+		return nil, status.Error(codes.PermissionDenied, "unlock must be approved by a different platform admin")
+	}
+	now := s.now().UTC()
+	expiresAt := now.Add(s.sessionTTL)
+	ok, err := s.repo.Approve(ctx, a.ID, userID, now, expiresAt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to approve unlock")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "activation is not pending")
+	}
+	a.Status, a.ApprovedBy, a.ApprovedAt, a.ExpiresAt = domain.StatusActive, userID, &now, &expiresAt
+	s.record(ctx, a, "break_glass_unlocked", userID, nil)
+	s.alert(ctx, breakglass.Event{Type: breakglass.EventUnlocked, OrgID: a.OrgID, ActivationID: a.ID, Actor: userID, Reason: a.Reason, ExpiresAt: &expiresAt, OccurredAt: now})
+	return &breakglassv1.ApproveUnlockResponse{Activation: s.activationToProto(a)}, nil
+}
+
+// EndAccess ends an active access window early and revokes the break-glass user's sessions in the org. Platform
+// admins only; audited as break_glass_ended and alerted.
+func (s *Server) EndAccess(ctx context.Context, req *breakglassv1.EndAccessRequest) (*breakglassv1.EndAccessResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method EndAccess not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.load(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	ok, err := s.repo.End(ctx, a.ID, userID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to end break-glass access")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "activation is not active")
+	}
+	a.Status, a.EndedBy, a.EndedAt = domain.StatusEnded, userID, &now
+	if s.sessions != nil {
+		rev := sessiondomain.Revocation{Reason: sessiondomain.RevocationBreakGlass, By: userID}
+		if err := s.sessions.RevokeAllSessionsByUserAndOrg(ctx, a.UserID, a.OrgID, rev); err != nil {
+			return nil, status.Error(codes.Internal, "failed to revoke break-glass sessions")
+		}
+	}
+	s.record(ctx, a, "break_glass_ended", userID, nil)
+	s.alert(ctx, breakglass.Event{Type: breakglass.EventEnded, OrgID: a.OrgID, ActivationID: a.ID, Actor: userID, ExpiresAt: a.ExpiresAt, OccurredAt: now})
+	return &breakglassv1.EndAccessResponse{Activation: s.activationToProto(a)}, nil
+}
+
+// ListActivations returns the org's activations, newest first. Platform admins only.
+func (s *Server) ListActivations(ctx context.Context, req *breakglassv1.ListActivationsRequest) (*breakglassv1.ListActivationsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListActivations not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins); err != nil {
+		return nil, err
+	}
+	orgID := req.GetOrgId()
+	if orgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.repo.ListActivations(ctx, orgID, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list break-glass activations")
+	}
+	out := make([]*breakglassv1.BreakGlassActivation, len(list))
+	for i, a := range list {
+		out[i] = s.activationToProto(a)
+	}
+	result := &breakglassv1.ListActivationsResponse{
+		Activations: out,
+		Pagination:  &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// SignIn exchanges the org's break-glass credential for a session while the account is unlocked. It skips MFA and
+// org policy. The session and its access token end with the access window, and no refresh token is issued.
+// Unauthenticated; every attempt against a provisioned account is audited and alerted.
+func (s *Server) SignIn(ctx context.Context, req *breakglassv1.SignInRequest) (*breakglassv1.SignInResponse, error) {
+	if s.repo == nil || s.sessions == nil || s.tokens == nil {
+		return nil, status.Error(codes.Unimplemented, "method SignIn not implemented")
+	}
+	orgID := req.GetOrgId()
+	if orgID == "" || req.GetSecret() == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id and secret required")
+	}
+	account, err := s.repo.GetAccount(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load break-glass account")
+	}
+	if account == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid break-glass credential")
+	}
+	now := s.now().UTC()
+	ip := interceptors.ClientIP(ctx)
+	if !domain.SecretMatches(req.GetSecret(), account.SecretHash) {
+		s.rejectSignIn(ctx, account, "invalid credential", ip, now)
+		return nil, status.Error(codes.Unauthenticated, "invalid break-glass credential")
+	}
+	a, err := s.repo.GetActiveActivation(ctx, orgID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check break-glass activations")
+	}
+	if a == nil {
+		s.rejectSignIn(ctx, account, "account is sealed", ip, now)
+		return nil, status.Error(codes.FailedPrecondition, "break-glass account is sealed")
+	}
+	sess := &sessiondomain.Session{
+		ID:         uuid.New().String(),
+		UserID:     account.UserID,
+		OrgID:      orgID,
+		DeviceID:   account.DeviceID,
+		ExpiresAt:  *a.ExpiresAt,
+		IPAddress:  ip,
+		CreatedAt:  now,
+		AuthMethod: sessiondomain.AuthMethodBreakGlass,
+	}
+	if err := s.sessions.Create(ctx, sess); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create session")
+	}
+	token, _, expiresAt, err := s.tokens.IssueAccessUntil(ctx, sess.ID, account.UserID, orgID, *a.ExpiresAt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to issue access token")
+	}
+	s.record(ctx, a, "break_glass_sign_in", account.UserID, map[string]interface{}{"session_id": sess.ID})
+	s.alert(ctx, breakglass.Event{Type: breakglass.EventSignedIn, OrgID: orgID, ActivationID: a.ID, Actor: account.UserID, ExpiresAt: a.ExpiresAt, IP: ip, OccurredAt: now})
+	return &breakglassv1.SignInResponse{
+		AccessToken: token,
+		ExpiresAt:   timestamppb.New(expiresAt),
+		UserId:      account.UserID,
+		OrgId:       orgID,
+		SessionId:   sess.ID,
+	}, nil
+}
+
+// GetReport returns the activation and the audit entries the break-glass user wrote from approval until the access
+// window ended (or now, while it is still open), oldest first. Platform admins only.
+func (s *Server) GetReport(ctx context.Context, req *breakglassv1.GetReportRequest) (*breakglassv1.GetReportResponse, error) {
+	if s.repo == nil || s.auditLogs == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetReport not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins); err != nil {
+		return nil, err
+	}
+	a, err := s.load(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &breakglassv1.GetReportResponse{Activation: s.activationToProto(a)}
+	if a.ApprovedAt == nil {
+		return resp, nil
+	}
+	until := s.now().UTC()
+	switch {
+	case a.EndedAt != nil:
+		until = *a.EndedAt
+	case a.ExpiresAt != nil && a.ExpiresAt.Before(until):
+		until = *a.ExpiresAt
+	}
+	userID := a.UserID
+	logs, err := s.auditLogs.ListByOrgSince(ctx, a.OrgID, *a.ApprovedAt, maxReportEntries+1, &userID, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load audit logs")
+	}
+	for _, l := range logs {
+		if l.CreatedAt.After(until) {
+			break
+		}
+		if len(resp.Entries) == maxReportEntries {
+			resp.Truncated = true
+			break
+		}
+		resp.Entries = append(resp.Entries, &breakglassv1.ReportEntry{
+			Action:    l.Action,
+			Resource:  l.Resource,
+			Ip:        l.IP,
+			Metadata:  l.Metadata,
+			RequestId: l.RequestID,
+			CreatedAt: timestamppb.New(l.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// SubmitReport closes an ended activation with its post-incident report; until then the org cannot be unlocked
+// again. Platform admins only; audited as break_glass_report_submitted.
+func (s *Server) SubmitReport(ctx context.Context, req *breakglassv1.SubmitReportRequest) (*breakglassv1.SubmitReportResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method SubmitReport not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := validateText("summary", req.GetSummary())
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.load(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	ok, err := s.repo.Close(ctx, a.ID, summary, userID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to store report")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "activation has not ended or already has a report")
+	}
+	if a.EndedAt == nil {
+		a.EndedAt = a.ExpiresAt
+	}
+	a.Status, a.ReportSummary, a.ReportedBy, a.ReportedAt = domain.StatusClosed, summary, userID, &now
+	s.record(ctx, a, "break_glass_report_submitted", userID, nil)
+	return &breakglassv1.SubmitReportResponse{Activation: s.activationToProto(a)}, nil
+}
+
+// load returns the activation, or NotFound.
+func (s *Server) load(ctx context.Context, id string) (*domain.Activation, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	a, err := s.repo.GetActivation(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load break-glass activation")
+	}
+	if a == nil {
+		return nil, status.Error(codes.NotFound, "break-glass activation not found")
+	}
+	return a, nil
+}
+
+// rejectSignIn audits and alerts a sign-in refused for a provisioned account.
+func (s *Server) rejectSignIn(ctx context.Context, account *domain.Account, reason, ip string, now time.Time) {
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"reason": reason})
+		s.auditLogger.LogEvent(ctx, account.OrgID, account.UserID, "break_glass_sign_in_failed", "break_glass", string(meta))
+	}
+	s.alert(ctx, breakglass.Event{Type: breakglass.EventSignInRejected, OrgID: account.OrgID, Actor: account.UserID, Reason: reason, IP: ip, OccurredAt: now})
+}
+
+// record audits a break-glass step on the activation's org under resource break_glass, with the actor as user.
+func (s *Server) record(ctx context.Context, a *domain.Activation, action, actor string, extra map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	metadata := map[string]interface{}{"activation_id": a.ID}
+	if a.ExpiresAt != nil {
+		metadata["expires_at"] = a.ExpiresAt
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	meta, _ := json.Marshal(metadata)
+	s.auditLogger.LogEvent(ctx, a.OrgID, actor, action, "break_glass", string(meta))
+}
+
+func (s *Server) alert(ctx context.Context, e breakglass.Event) {
+	if s.alerter != nil {
+		s.alerter.Alert(ctx, e)
+	}
+}
+
+// validateText trims s and requires it to be non-empty and at most domain.MaxReasonLength characters.
+func validateText(field, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", status.Errorf(codes.InvalidArgument, "%s required", field)
+	}
+	if len(s) > domain.MaxReasonLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", field, domain.MaxReasonLength)
+	}
+	return s, nil
+}
+
+func accountToProto(a *domain.Account) *breakglassv1.BreakGlassAccount {
+	return &breakglassv1.BreakGlassAccount{
+		OrgId:         a.OrgID,
+		UserId:        a.UserID,
+		ProvisionedBy: a.ProvisionedBy,
+		ProvisionedAt: timestamppb.New(a.ProvisionedAt),
+	}
+}
+
+// activationToProto converts a, reporting its effective status (lapsed or ended) even before it is stored.
+func (s *Server) activationToProto(a *domain.Activation) *breakglassv1.BreakGlassActivation {
+	out := &breakglassv1.BreakGlassActivation{
+		Id:            a.ID,
+		OrgId:         a.OrgID,
+		UserId:        a.UserID,
+		Status:        string(a.EffectiveStatus(s.now())),
+		Reason:        a.Reason,
+		RequestedBy:   a.RequestedBy,
+		RequestedAt:   timestamppb.New(a.RequestedAt),
+		ApprovedBy:    a.ApprovedBy,
+		EndedBy:       a.EndedBy,
+		ReportSummary: a.ReportSummary,
+		ReportedBy:    a.ReportedBy,
+	}
+	if a.ApprovedAt != nil {
+		out.ApprovedAt = timestamppb.New(*a.ApprovedAt)
+	}
+	if a.ExpiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*a.ExpiresAt)
+	}
+	if a.EndedAt != nil {
+		out.EndedAt = timestamppb.New(*a.EndedAt)
+	}
+	if a.ReportedAt != nil {
+		out.ReportedAt = timestamppb.New(*a.ReportedAt)
+	}
+	return out
+}
@@ -0,0 +1,407 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breakglassv1 "zero-trust-control-plane/backend/api/generated/breakglass/v1"
+	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+	"zero-trust-control-plane/backend/internal/breakglass"
+	"zero-trust-control-plane/backend/internal/breakglass/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	"zero-trust-control-plane/backend/internal/orgquota"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// memBreakGlass implements repository.Repository in memory.
+type memBreakGlass struct {
+	accounts    map[string]*domain.Account
+	activations map[string]*domain.Activation
+}
+
+func (m *memBreakGlass) GetAccount(ctx context.Context, orgID string) (*domain.Account, error) {
+	a, ok := m.accounts[orgID]
+	if !ok {
+		return nil, nil
+	}
+	c := *a
+	return &c, nil
+}
+
+func (m *memBreakGlass) SaveAccount(ctx context.Context, a *domain.Account) error {
+	c := *a
+	m.accounts[a.OrgID] = &c
+	return nil
+}
+
+func (m *memBreakGlass) CreateActivation(ctx context.Context, a *domain.Activation) error {
+	c := *a
+	m.activations[a.ID] = &c
+	return nil
+}
+
+func (m *memBreakGlass) GetActivation(ctx context.Context, id string) (*domain.Activation, error) {
+	a, ok := m.activations[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *a
+	return &c, nil
+}
+
+func (m *memBreakGlass) GetActiveActivation(ctx context.Context, orgID string, now time.Time) (*domain.Activation, error) {
+	for _, a := range m.activations {
+		if a.OrgID == orgID && a.Active(now) {
+			c := *a
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memBreakGlass) HasOpenActivation(ctx context.Context, orgID string, now time.Time) (bool, error) {
+	for _, a := range m.activations {
+		if a.OrgID != orgID {
+			continue
+		}
+		switch a.EffectiveStatus(now) {
+		case domain.StatusPending, domain.StatusActive, domain.StatusEnded:
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memBreakGlass) ListActivations(ctx context.Context, orgID string, limit, offset int32) ([]*domain.Activation, error) {
+	var out []*domain.Activation
+	for _, a := range m.activations {
+		if a.OrgID == orgID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *memBreakGlass) Approve(ctx context.Context, id, by string, approvedAt, expiresAt time.Time) (bool, error) {
+	a, ok := m.activations[id]
+	if !ok || a.EffectiveStatus(approvedAt) != domain.StatusPending {
+		return false, nil
+	}
+	a.Status, a.ApprovedBy, a.ApprovedAt, a.ExpiresAt = domain.StatusActive, by, &approvedAt, &expiresAt
+	return true, nil
+}
+
+func (m *memBreakGlass) End(ctx context.Context, id, by string, at time.Time) (bool, error) {
+	a, ok := m.activations[id]
+	if !ok || !a.Active(at) {
+		return false, nil
+	}
+	a.Status, a.EndedBy, a.EndedAt = domain.StatusEnded, by, &at
+	return true, nil
+}
+
+func (m *memBreakGlass) Close(ctx context.Context, id, summary, by string, at time.Time) (bool, error) {
+	a, ok := m.activations[id]
+	if !ok || a.EffectiveStatus(at) != domain.StatusEnded {
+		return false, nil
+	}
+	if a.EndedAt == nil {
+		a.EndedAt = a.ExpiresAt
+	}
+	a.Status, a.ReportSummary, a.ReportedBy, a.ReportedAt = domain.StatusClosed, summary, by, &at
+	return true, nil
+}
+
+func (m *memBreakGlass) ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Activation, error) {
+	return nil, nil
+}
+
+type platformAdmins map[string]bool
+
+func (p platformAdmins) IsPlatformAdmin(userID string) bool { return p[userID] }
+
+type fakeProvisioner struct {
+	calls int
+	err   error
+}
+
+func (p *fakeProvisioner) Provision(ctx context.Context, orgID string) (string, string, error) {
+	p.calls++
+	if p.err != nil {
+		return "", "", p.err
+	}
+	return "bg-user-" + orgID, "bg-device-" + orgID, nil
+}
+
+type memSessions struct {
+	created []*sessiondomain.Session
+	revoked []string // userID:orgID
+}
+
+func (m *memSessions) Create(ctx context.Context, s *sessiondomain.Session) error {
+	m.created = append(m.created, s)
+	return nil
+}
+
+func (m *memSessions) RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev sessiondomain.Revocation) error {
+	m.revoked = append(m.revoked, userID+":"+orgID)
+	return nil
+}
+
+type fakeTokens struct{}
+
+func (fakeTokens) IssueAccessUntil(ctx context.Context, sessionID, userID, orgID string, notAfter time.Time) (string, string, time.Time, error) {
+	return "access-" + sessionID, "jti", notAfter, nil
+}
+
+type memAuditLogs []*auditdomain.AuditLog
+
+func (m memAuditLogs) ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*auditdomain.AuditLog, error) {
+	var out []*auditdomain.AuditLog
+	for _, l := range m {
+		if l.OrgID == orgID && !l.CreatedAt.Before(since) && (userID == nil || l.UserID == *userID) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+type recordingAuditLogger struct {
+	actions []string
+}
+
+func (l *recordingAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	l.actions = append(l.actions, action)
+}
+
+type recordingAlerter struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (a *recordingAlerter) Alert(ctx context.Context, e breakglass.Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events = append(a.events, e.Type)
+}
+
+type testEnv struct {
+	srv      *Server
+	repo     *memBreakGlass
+	sessions *memSessions
+	audit    *recordingAuditLogger
+	alerts   *recordingAlerter
+	now      time.Time
+}
+
+func newTestEnv(auditLogs memAuditLogs) *testEnv {
+	env := &testEnv{
+		repo:     &memBreakGlass{accounts: map[string]*domain.Account{}, activations: map[string]*domain.Activation{}},
+		sessions: &memSessions{},
+		audit:    &recordingAuditLogger{},
+		alerts:   &recordingAlerter{},
+		now:      time.Now().UTC(),
+	}
+	admins := platformAdmins{"admin-1": true, "admin-2": true}
+	env.srv = NewServer(env.repo, &fakeProvisioner{}, nil, env.sessions, fakeTokens{}, auditLogs, admins, env.audit, env.alerts, time.Hour)
+	env.srv.now = func() time.Time { return env.now }
+	return env
+}
+
+func as(userID string) context.Context {
+	return interceptors.WithIdentity(context.Background(), userID, "", "session-1")
+}
+
+// unlock provisions org-1 and unlocks it with admin-1 requesting and admin-2 approving.
+func (env *testEnv) unlock(t *testing.T) (secret string, activation *breakglassv1.BreakGlassActivation) {
+	t.Helper()
+	prov, err := env.srv.ProvisionBreakGlassAccount(as("admin-1"), &breakglassv1.ProvisionBreakGlassAccountRequest{OrgId: "org-1"})
+	if err != nil {
+		t.Fatalf("ProvisionBreakGlassAccount: %v", err)
+	}
+	req, err := env.srv.RequestUnlock(as("admin-1"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "all owners locked out"})
+	if err != nil {
+		t.Fatalf("RequestUnlock: %v", err)
+	}
+	approved, err := env.srv.ApproveUnlock(as("admin-2"), &breakglassv1.ApproveUnlockRequest{Id: req.GetActivation().GetId()})
+	if err != nil {
+		t.Fatalf("ApproveUnlock: %v", err)
+	}
+	return prov.GetSecret(), approved.GetActivation()
+}
+
+func TestBreakGlass_ProvisionOverQuota(t *testing.T) {
+	env := newTestEnv(nil)
+	env.srv.provisioner = &fakeProvisioner{err: orgquota.ErrUserQuotaExceeded}
+	if _, err := env.srv.ProvisionBreakGlassAccount(as("admin-1"), &breakglassv1.ProvisionBreakGlassAccountRequest{OrgId: "org-1"}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("ProvisionBreakGlassAccount in a full org: got %v, want ResourceExhausted", err)
+	}
+	if acc, _ := env.repo.GetAccount(context.Background(), "org-1"); acc != nil {
+		t.Errorf("account stored for a full org: %+v", acc)
+	}
+	for err, want := range map[error]codes.Code{license.ErrSeatLimitReached: codes.ResourceExhausted, license.ErrLicenseExpired: codes.FailedPrecondition} {
+		env.srv.provisioner = &fakeProvisioner{err: err}
+		if _, got := env.srv.ProvisionBreakGlassAccount(as("admin-1"), &breakglassv1.ProvisionBreakGlassAccountRequest{OrgId: "org-1"}); status.Code(got) != want {
+			t.Errorf("ProvisionBreakGlassAccount with %v: got %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestBreakGlass_DualControlUnlock(t *testing.T) {
+	env := newTestEnv(nil)
+	prov, err := env.srv.ProvisionBreakGlassAccount(as("admin-1"), &breakglassv1.ProvisionBreakGlassAccountRequest{OrgId: "org-1"})
+	if err != nil {
+		t.Fatalf("ProvisionBreakGlassAccount: %v", err)
+	}
+	secret := prov.GetSecret()
+
+	if _, err := env.srv.SignIn(context.Background(), &breakglassv1.SignInRequest{OrgId: "org-1", Secret: secret}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("SignIn while sealed: got %v, want FailedPrecondition", err)
+	}
+	if _, err := env.srv.RequestUnlock(as("user-1"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "x"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RequestUnlock by non-admin: got %v, want PermissionDenied", err)
+	}
+	req, err := env.srv.RequestUnlock(as("admin-1"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "all owners locked out"})
+	if err != nil {
+		t.Fatalf("RequestUnlock: %v", err)
+	}
+	if _, err := env.srv.RequestUnlock(as("admin-2"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "again"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second RequestUnlock: got %v, want FailedPrecondition", err)
+	}
+	id := req.GetActivation().GetId()
+	if _, err := env.srv.ApproveUnlock(as("admin-1"), &breakglassv1.ApproveUnlockRequest{Id: id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("self-approval: got %v, want PermissionDenied", err)
+	}
+	approved, err := env.srv.ApproveUnlock(as("admin-2"), &breakglassv1.ApproveUnlockRequest{Id: id})
+	if err != nil {
+		t.Fatalf("ApproveUnlock: %v", err)
+	}
+	if approved.GetActivation().GetStatus() != "active" || !approved.GetActivation().GetExpiresAt().AsTime().Equal(env.now.Add(time.Hour)) {
+		t.Errorf("approved activation = %+v", approved.GetActivation())
+	}
+
+	if _, err := env.srv.SignIn(context.Background(), &breakglassv1.SignInRequest{OrgId: "org-1", Secret: "ztcp_bg_wrong"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("SignIn with wrong secret: got %v, want Unauthenticated", err)
+	}
+	resp, err := env.srv.SignIn(context.Background(), &breakglassv1.SignInRequest{OrgId: "org-1", Secret: secret})
+	if err != nil {
+		t.Fatalf("SignIn: %v", err)
+	}
+	if resp.GetUserId() != "bg-user-org-1" || resp.GetAccessToken() == "" || !resp.GetExpiresAt().AsTime().Equal(env.now.Add(time.Hour)) {
+		t.Errorf("SignIn response = %+v", resp)
+	}
+	if len(env.sessions.created) != 1 {
+		t.Fatalf("sessions created = %d, want 1", len(env.sessions.created))
+	}
+	sess := env.sessions.created[0]
+	if sess.AuthMethod != sessiondomain.AuthMethodBreakGlass || sess.DeviceID != "bg-device-org-1" || !sess.ExpiresAt.Equal(env.now.Add(time.Hour)) || sess.RefreshTokenHash != "" {
+		t.Errorf("session = %+v", sess)
+	}
+
+	wantAudit := []string{"break_glass_provisioned", "break_glass_sign_in_failed", "break_glass_unlock_requested", "break_glass_unlocked", "break_glass_sign_in_failed", "break_glass_sign_in"}
+	if len(env.audit.actions) != len(wantAudit) {
+		t.Fatalf("audit actions = %v, want %v", env.audit.actions, wantAudit)
+	}
+	for i, a := range wantAudit {
+		if env.audit.actions[i] != a {
+			t.Errorf("audit action %d = %q, want %q", i, env.audit.actions[i], a)
+		}
+	}
+	wantAlerts := []string{breakglass.EventSignInRejected, breakglass.EventUnlockRequested, breakglass.EventUnlocked, breakglass.EventSignInRejected, breakglass.EventSignedIn}
+	if len(env.alerts.events) != len(wantAlerts) {
+		t.Errorf("alerts = %v, want %v", env.alerts.events, wantAlerts)
+	}
+}
+
+func TestBreakGlass_PendingRequestLapses(t *testing.T) {
+	env := newTestEnv(nil)
+	if _, err := env.srv.ProvisionBreakGlassAccount(as("admin-1"), &breakglassv1.ProvisionBreakGlassAccountRequest{OrgId: "org-1"}); err != nil {
+		t.Fatalf("ProvisionBreakGlassAccount: %v", err)
+	}
+	req, err := env.srv.RequestUnlock(as("admin-1"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "incident"})
+	if err != nil {
+		t.Fatalf("RequestUnlock: %v", err)
+	}
+	env.now = env.now.Add(domain.PendingTTL)
+	if _, err := env.srv.ApproveUnlock(as("admin-2"), &breakglassv1.ApproveUnlockRequest{Id: req.GetActivation().GetId()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ApproveUnlock after PendingTTL: got %v, want FailedPrecondition", err)
+	}
+	if _, err := env.srv.RequestUnlock(as("admin-2"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "incident"}); err != nil {
+		t.Errorf("RequestUnlock after the previous one lapsed: %v", err)
+	}
+}
+
+func TestBreakGlass_EndAccessAndReport(t *testing.T) {
+	start := time.Now().UTC()
+	logs := memAuditLogs{
+		{OrgID: "org-1", UserID: "bg-user-org-1", Action: "membership_removed", CreatedAt: start.Add(10 * time.Minute)},
+		{OrgID: "org-1", UserID: "user-1", Action: "login_success", CreatedAt: start.Add(11 * time.Minute)},
+		{OrgID: "org-1", UserID: "bg-user-org-1", Action: "session_revoked", CreatedAt: start.Add(40 * time.Minute)},
+	}
+	env := newTestEnv(logs)
+	env.now = start
+	secret, activation := env.unlock(t)
+	id := activation.GetId()
+
+	if _, err := env.srv.ProvisionBreakGlassAccount(as("admin-1"), &breakglassv1.ProvisionBreakGlassAccountRequest{OrgId: "org-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("rotation while unlocked: got %v, want FailedPrecondition", err)
+	}
+	if _, err := env.srv.SubmitReport(as("admin-1"), &breakglassv1.SubmitReportRequest{Id: id, Summary: "done"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SubmitReport while active: got %v, want FailedPrecondition", err)
+	}
+	env.now = start.Add(30 * time.Minute)
+	ended, err := env.srv.EndAccess(as("admin-1"), &breakglassv1.EndAccessRequest{Id: id})
+	if err != nil {
+		t.Fatalf("EndAccess: %v", err)
+	}
+	if ended.GetActivation().GetStatus() != "ended" || ended.GetActivation().GetEndedBy() != "admin-1" {
+		t.Errorf("ended activation = %+v", ended.GetActivation())
+	}
+	if len(env.sessions.revoked) != 1 || env.sessions.revoked[0] != "bg-user-org-1:org-1" {
+		t.Errorf("revoked sessions = %v", env.sessions.revoked)
+	}
+	if _, err := env.srv.SignIn(context.Background(), &breakglassv1.SignInRequest{OrgId: "org-1", Secret: secret}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SignIn after EndAccess: got %v, want FailedPrecondition", err)
+	}
+	if _, err := env.srv.RequestUnlock(as("admin-1"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "again"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RequestUnlock before the report: got %v, want FailedPrecondition", err)
+	}
+
+	report, err := env.srv.GetReport(as("admin-2"), &breakglassv1.GetReportRequest{Id: id})
+	if err != nil {
+		t.Fatalf("GetReport: %v", err)
+	}
+	if len(report.GetEntries()) != 1 || report.GetEntries()[0].GetAction() != "membership_removed" || report.GetTruncated() {
+		t.Errorf("report entries = %+v; want only the break-glass user's entries inside the window", report.GetEntries())
+	}
+
+	if _, err := env.srv.SubmitReport(as("admin-2"), &breakglassv1.SubmitReportRequest{Id: id, Summary: " "}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SubmitReport without summary: got %v, want InvalidArgument", err)
+	}
+	closed, err := env.srv.SubmitReport(as("admin-2"), &breakglassv1.SubmitReportRequest{Id: id, Summary: "restored owner access"})
+	if err != nil {
+		t.Fatalf("SubmitReport: %v", err)
+	}
+	if closed.GetActivation().GetStatus() != "closed" || closed.GetActivation().GetReportedBy() != "admin-2" {
+		t.Errorf("closed activation = %+v", closed.GetActivation())
+	}
+	if _, err := env.srv.RequestUnlock(as("admin-1"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1", Reason: "again"}); err != nil {
+		t.Errorf("RequestUnlock after the report: %v", err)
+	}
+}
+
+func TestBreakGlass_Unimplemented(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil, nil, nil, time.Hour)
+	if _, err := srv.SignIn(context.Background(), &breakglassv1.SignInRequest{OrgId: "org-1", Secret: "s"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("SignIn: got %v, want Unimplemented", err)
+	}
+	if _, err := srv.RequestUnlock(as("admin-1"), &breakglassv1.RequestUnlockRequest{OrgId: "org-1"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("RequestUnlock: got %v, want Unimplemented", err)
+	}
+}
@@ -0,0 +1,76 @@
+package breakglass
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// DeviceFingerprint is the fingerprint of the device recorded on break-glass sessions.
+const DeviceFingerprint = "break-glass"
+
+// UserCreator creates the break-glass user with its membership and device in one transaction, after seats reserved
+// a license seat in it. Implemented by the user repository.
+type UserCreator interface {
+	CreateWithMembership(ctx context.Context, u *userdomain.User, m *membershipdomain.Membership, d *devicedomain.Device, seats license.SeatReserver) error
+}
+
+// Provisioner creates break-glass users.
+type Provisioner struct {
+	users UserCreator
+	seats license.SeatReserver
+	now   func() time.Time
+}
+
+// NewProvisioner returns a Provisioner that stores the user, its membership and its device with users. seats (e.g.
+// *license.Manager) must have a free license seat for each new user; nil checks none.
+func NewProvisioner(users UserCreator, seats license.SeatReserver) *Provisioner {
+	return &Provisioner{users: users, seats: seats, now: time.Now}
+}
+
+// Provision creates the break-glass user of orgID with the owner role, and the device its sessions are recorded on,
+// in one transaction: on error none of them is left behind. The user has no identity or password, so it can never
+// sign in through AuthService and takes no seat once created, but like every new user it is refused when the license
+// has no free seat (license.ErrSeatLimitReached) or has expired (license.ErrLicenseExpired). The org's quotas return
+// orgquota.ErrUserQuotaExceeded and orgquota.ErrDeviceQuotaExceeded.
+func (p *Provisioner) Provision(ctx context.Context, orgID string) (userID, deviceID string, err error) {
+	now := p.now().UTC()
+	user := &userdomain.User{
+		ID:        uuid.New().String(),
+		Email:     Email(orgID),
+		Name:      "Break-glass account",
+		Status:    userdomain.UserStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	membership := &membershipdomain.Membership{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		OrgID:     orgID,
+		Role:      membershipdomain.RoleOwner,
+		CreatedAt: now,
+	}
+	device := &devicedomain.Device{
+		ID:          uuid.New().String(),
+		UserID:      user.ID,
+		OrgID:       orgID,
+		Fingerprint: DeviceFingerprint,
+		CreatedAt:   now,
+	}
+	if err := p.users.CreateWithMembership(ctx, user, membership, device, p.seats); err != nil {
+		return "", "", err
+	}
+	return user.ID, device.ID, nil
+}
+
+// Email is the address of an org's break-glass user. It uses the reserved .invalid TLD, so no mail is ever
+// delivered to it and no real user can register it.
+func Email(orgID string) string {
+	return "break-glass+" + orgID + "@ztcp.invalid"
+}
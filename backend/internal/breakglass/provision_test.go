@@ -0,0 +1,53 @@
+package breakglass
+
+import (
+	"context"
+	"testing"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// recordingCreator implements UserCreator, recording each call and failing with err.
+type recordingCreator struct {
+	calls      int
+	user       *userdomain.User
+	membership *membershipdomain.Membership
+	device     *devicedomain.Device
+	seats      license.SeatReserver
+	err        error
+}
+
+func (c *recordingCreator) CreateWithMembership(ctx context.Context, u *userdomain.User, m *membershipdomain.Membership, d *devicedomain.Device, seats license.SeatReserver) error {
+	c.calls++
+	c.user, c.membership, c.device, c.seats = u, m, d, seats
+	return c.err
+}
+
+func TestProvisioner_Provision(t *testing.T) {
+	creator := &recordingCreator{}
+	seats := &license.Manager{}
+	userID, deviceID, err := NewProvisioner(creator, seats).Provision(context.Background(), "org-1")
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if creator.calls != 1 || creator.seats != seats {
+		t.Fatalf("CreateWithMembership calls = %d, seats = %v; want one call with the seats", creator.calls, creator.seats)
+	}
+	if creator.user.ID != userID || creator.user.Email != Email("org-1") {
+		t.Errorf("user = %+v, want %s with %s", creator.user, userID, Email("org-1"))
+	}
+	if m := creator.membership; m.UserID != userID || m.OrgID != "org-1" || m.Role != membershipdomain.RoleOwner {
+		t.Errorf("membership = %+v, want owner of org-1", m)
+	}
+	if d := creator.device; d.ID != deviceID || d.UserID != userID || d.OrgID != "org-1" || d.Fingerprint != DeviceFingerprint {
+		t.Errorf("device = %+v", d)
+	}
+
+	creator.err = license.ErrSeatLimitReached
+	if userID, deviceID, err := NewProvisioner(creator, seats).Provision(context.Background(), "org-1"); err != license.ErrSeatLimitReached || userID != "" || deviceID != "" {
+		t.Errorf("Provision without a seat = %q, %q, %v; want ErrSeatLimitReached", userID, deviceID, err)
+	}
+}
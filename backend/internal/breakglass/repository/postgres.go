@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/breakglass/domain"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a break-glass repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// GetAccount returns the org's break-glass account, or nil if none is provisioned.
+func (r *PostgresRepository) GetAccount(ctx context.Context, orgID string) (*domain.Account, error) {
+	row, err := r.queries.GetBreakGlassAccount(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.Account{
+		OrgID:         row.OrgID,
+		UserID:        row.UserID,
+		DeviceID:      row.DeviceID,
+		SecretHash:    row.SecretHash,
+		ProvisionedBy: row.ProvisionedBy,
+		ProvisionedAt: row.ProvisionedAt,
+	}, nil
+}
+
+// SaveAccount creates the org's account or rotates its credential.
+func (r *PostgresRepository) SaveAccount(ctx context.Context, a *domain.Account) error {
+	return r.queries.UpsertBreakGlassAccount(ctx, gen.UpsertBreakGlassAccountParams{
+		OrgID:         a.OrgID,
+		UserID:        a.UserID,
+		DeviceID:      a.DeviceID,
+		SecretHash:    a.SecretHash,
+		ProvisionedBy: a.ProvisionedBy,
+		ProvisionedAt: a.ProvisionedAt,
+	})
+}
+
+// CreateActivation persists a new unlock request.
+func (r *PostgresRepository) CreateActivation(ctx context.Context, a *domain.Activation) error {
+	return r.queries.CreateBreakGlassActivation(ctx, gen.CreateBreakGlassActivationParams{
+		ID:          a.ID,
+		OrgID:       a.OrgID,
+		UserID:      a.UserID,
+		Status:      string(a.Status),
+		Reason:      a.Reason,
+		RequestedBy: a.RequestedBy,
+		RequestedAt: a.RequestedAt,
+	})
+}
+
+// GetActivation returns the activation, or nil if not found.
+func (r *PostgresRepository) GetActivation(ctx context.Context, id string) (*domain.Activation, error) {
+	row, err := r.queries.GetBreakGlassActivation(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genActivationToDomain(&row), nil
+}
+
+// GetActiveActivation returns the org's active activation, or nil if none.
+func (r *PostgresRepository) GetActiveActivation(ctx context.Context, orgID string, now time.Time) (*domain.Activation, error) {
+	row, err := r.queries.GetActiveBreakGlassActivation(ctx, gen.GetActiveBreakGlassActivationParams{OrgID: orgID, Now: now})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genActivationToDomain(&row), nil
+}
+
+// HasOpenActivation reports whether the org has an activation that blocks a new unlock request.
+func (r *PostgresRepository) HasOpenActivation(ctx context.Context, orgID string, now time.Time) (bool, error) {
+	return r.queries.HasOpenBreakGlassActivation(ctx, gen.HasOpenBreakGlassActivationParams{
+		OrgID:        orgID,
+		PendingSince: now.Add(-domain.PendingTTL),
+	})
+}
+
+// ListActivations returns the org's activations, newest first.
+func (r *PostgresRepository) ListActivations(ctx context.Context, orgID string, limit, offset int32) ([]*domain.Activation, error) {
+	rows, err := r.queries.ListBreakGlassActivationsByOrg(ctx, gen.ListBreakGlassActivationsByOrgParams{OrgID: orgID, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return genActivationsToDomain(rows), nil
+}
+
+// Approve unlocks a pending activation.
+func (r *PostgresRepository) Approve(ctx context.Context, id, by string, approvedAt, expiresAt time.Time) (bool, error) {
+	n, err := r.queries.ApproveBreakGlassActivation(ctx, gen.ApproveBreakGlassActivationParams{
+		ApprovedBy:   sql.NullString{String: by, Valid: true},
+		ApprovedAt:   sql.NullTime{Time: approvedAt, Valid: true},
+		ExpiresAt:    sql.NullTime{Time: expiresAt, Valid: true},
+		ID:           id,
+		PendingSince: approvedAt.Add(-domain.PendingTTL),
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// End ends an active activation early.
+func (r *PostgresRepository) End(ctx context.Context, id, by string, at time.Time) (bool, error) {
+	n, err := r.queries.EndBreakGlassActivation(ctx, gen.EndBreakGlassActivationParams{
+		EndedBy: sql.NullString{String: by, Valid: by != ""},
+		EndedAt: sql.NullTime{Time: at, Valid: true},
+		ID:      id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Close records the post-incident report of an ended activation.
+func (r *PostgresRepository) Close(ctx context.Context, id, summary, by string, at time.Time) (bool, error) {
+	n, err := r.queries.CloseBreakGlassActivation(ctx, gen.CloseBreakGlassActivationParams{
+		ReportSummary: summary,
+		ReportedBy:    sql.NullString{String: by, Valid: true},
+		ReportedAt:    sql.NullTime{Time: at, Valid: true},
+		ID:            id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ExpireEnded marks active activations whose window ended as ended and returns them.
+func (r *PostgresRepository) ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Activation, error) {
+	rows, err := r.queries.ExpireBreakGlassActivations(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	return genActivationsToDomain(rows), nil
+}
+
+func genActivationsToDomain(rows []gen.BreakGlassActivation) []*domain.Activation {
+	out := make([]*domain.Activation, len(rows))
+	for i := range rows {
+		out[i] = genActivationToDomain(&rows[i])
+	}
+	return out
+}
+
+func genActivationToDomain(row *gen.BreakGlassActivation) *domain.Activation {
+	a := &domain.Activation{
+		ID:            row.ID,
+		OrgID:         row.OrgID,
+		UserID:        row.UserID,
+		Status:        domain.Status(row.Status),
+		Reason:        row.Reason,
+		RequestedBy:   row.RequestedBy,
+		RequestedAt:   row.RequestedAt,
+		ApprovedBy:    row.ApprovedBy.String,
+		EndedBy:       row.EndedBy.String,
+		ReportSummary: row.ReportSummary,
+		ReportedBy:    row.ReportedBy.String,
+	}
+	a.ApprovedAt = nullTime(row.ApprovedAt)
+	a.ExpiresAt = nullTime(row.ExpiresAt)
+	a.EndedAt = nullTime(row.EndedAt)
+	a.ReportedAt = nullTime(row.ReportedAt)
+	return a
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
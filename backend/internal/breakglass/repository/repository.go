@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/breakglass/domain"
+)
+
+// Repository persists break-glass accounts and their activations.
+type Repository interface {
+	// GetAccount returns the org's break-glass account, or nil if none is provisioned.
+	GetAccount(ctx context.Context, orgID string) (*domain.Account, error)
+	// SaveAccount creates the org's account, or replaces its credential keeping its user and device.
+	SaveAccount(ctx context.Context, a *domain.Account) error
+	// CreateActivation persists a new pending activation. The activation must have ID set.
+	CreateActivation(ctx context.Context, a *domain.Activation) error
+	// GetActivation returns the activation, or nil if not found.
+	GetActivation(ctx context.Context, id string) (*domain.Activation, error)
+	// GetActiveActivation returns the org's activation that has the account unlocked at now, or nil if none.
+	GetActiveActivation(ctx context.Context, orgID string, now time.Time) (*domain.Activation, error)
+	// HasOpenActivation reports whether the org has a pending activation that has not lapsed at now, an active one,
+	// or an ended one without a post-incident report.
+	HasOpenActivation(ctx context.Context, orgID string, now time.Time) (bool, error)
+	// ListActivations returns the org's activations, newest first.
+	ListActivations(ctx context.Context, orgID string, limit, offset int32) ([]*domain.Activation, error)
+	// Approve unlocks a pending activation until expiresAt. It reports false, without changing anything, when the
+	// activation is no longer pending or has lapsed at approvedAt.
+	Approve(ctx context.Context, id, by string, approvedAt, expiresAt time.Time) (bool, error)
+	// End ends an active activation at the given time. It reports false when the activation is not active.
+	End(ctx context.Context, id, by string, at time.Time) (bool, error)
+	// Close records the post-incident report of an ended activation. It reports false when the activation has not
+	// ended or already has a report.
+	Close(ctx context.Context, id, summary, by string, at time.Time) (bool, error)
+	// ExpireEnded marks active activations whose window ended by now as ended and returns them.
+	ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Activation, error)
+}
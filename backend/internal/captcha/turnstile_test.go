@@ -0,0 +1,49 @@
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTurnstileVerifier_Verify(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("secret") != "secret-1" {
+			t.Errorf("secret = %q", r.Form.Get("secret"))
+		}
+		if requests == 1 && r.Form.Get("remoteip") != "203.0.113.7" {
+			t.Errorf("remoteip = %q", r.Form.Get("remoteip"))
+		}
+		fmt.Fprintf(w, `{"success":%t}`, r.Form.Get("response") == "good")
+	}))
+	defer srv.Close()
+	v := NewTurnstileVerifier("secret-1", srv.URL)
+	ctx := context.Background()
+
+	if ok, err := v.Verify(ctx, "good", "203.0.113.7"); err != nil || !ok {
+		t.Errorf("good token: ok=%v err=%v, want true", ok, err)
+	}
+	if ok, err := v.Verify(ctx, "bad", ""); err != nil || ok {
+		t.Errorf("bad token: ok=%v err=%v, want false", ok, err)
+	}
+	if ok, err := v.Verify(ctx, " ", ""); err != nil || ok || requests != 2 {
+		t.Errorf("empty token: ok=%v err=%v after %d requests, want false without a request", ok, err, requests)
+	}
+}
+
+func TestTurnstileVerifier_Unavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	if _, err := NewTurnstileVerifier("secret-1", srv.URL).Verify(context.Background(), "good", ""); err == nil {
+		t.Error("expected an error when siteverify fails")
+	}
+}
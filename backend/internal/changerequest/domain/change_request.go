@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+)
+
+// Kind is what a change request changes.
+type Kind string
+
+const (
+	KindOrgPolicyConfig Kind = "org_policy_config"
+	KindPolicy          Kind = "policy"
+)
+
+// Status is where a change request is in review. Only pending requests can be approved or rejected.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// PolicyOperation is the Rego policy write a KindPolicy request makes.
+type PolicyOperation string
+
+const (
+	PolicyOperationCreate PolicyOperation = "create"
+	PolicyOperationUpdate PolicyOperation = "update"
+	PolicyOperationDelete PolicyOperation = "delete"
+)
+
+// MaxReasonLength caps the proposer's reason and the reviewer's comment.
+const MaxReasonLength = 1000
+
+// PolicyConfigChange is a proposed org policy config: the full config approval stores, the config version it was
+// proposed against, and the settings it changes relative to that version.
+type PolicyConfigChange struct {
+	Config      *orgpolicyconfigdomain.OrgPolicyConfig `json:"config"`
+	BaseVersion int64                                  `json:"base_version"`
+	Changes     []orgpolicyconfigdomain.FieldChange    `json:"changes"`
+}
+
+// PolicyChange is a proposed Rego policy write. PolicyID is empty for create; Rules and Enabled are unused for delete.
+type PolicyChange struct {
+	Operation PolicyOperation `json:"operation"`
+	PolicyID  string          `json:"policy_id,omitempty"`
+	Rules     string          `json:"rules,omitempty"`
+	Enabled   bool            `json:"enabled"`
+}
+
+// Review is an admin's decision on a change request.
+type Review struct {
+	By      string
+	Comment string
+	At      time.Time
+}
+
+// ChangeRequest is a proposed policy change that takes effect only when a second admin approves it.
+// Exactly one of PolicyConfig and Policy is set, according to Kind.
+type ChangeRequest struct {
+	ID           string
+	OrgID        string
+	Kind         Kind
+	Status       Status
+	ProposedBy   string // user ID of the proposing admin; cannot approve
+	Reason       string
+	PolicyConfig *PolicyConfigChange
+	Policy       *PolicyChange
+	Review       *Review // nil while pending
+	CreatedAt    time.Time
+}
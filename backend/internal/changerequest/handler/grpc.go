@@ -0,0 +1,478 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-policy-agent/opa/v1/ast"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	changerequestv1 "zero-trust-control-plane/backend/api/generated/changerequest/v1"
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/billing"
+	"zero-trust-control-plane/backend/internal/changerequest"
+	"zero-trust-control-plane/backend/internal/changerequest/domain"
+	"zero-trust-control-plane/backend/internal/changerequest/repository"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	orgpolicyconfighandler "zero-trust-control-plane/backend/internal/orgpolicyconfig/handler"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// PolicyConfigStore validates and applies org policy config proposals (orgpolicyconfig handler.Server).
+type PolicyConfigStore interface {
+	ProposeConfig(ctx context.Context, orgID string, update *orgpolicyconfigv1.OrgPolicyConfig, sections []string, etag string) (*orgpolicyconfighandler.ProposedConfig, error)
+	ApplyProposedConfig(ctx context.Context, orgID string, config *orgpolicyconfigdomain.OrgPolicyConfig, baseVersion int64, change orgpolicyconfigdomain.Change) (int64, error)
+}
+
+// PolicyStore is the subset of the policy repository used to validate and apply Rego policy proposals.
+type PolicyStore interface {
+	GetByID(ctx context.Context, id string) (*policydomain.Policy, error)
+	Create(ctx context.Context, p *policydomain.Policy) error
+	Update(ctx context.Context, p *policydomain.Policy) error
+	Delete(ctx context.Context, id string) error
+}
+
+// Server implements ChangeRequestService (proto server). Caller must be org admin or owner.
+// Proto: changerequest/changerequest.proto → internal/changerequest/handler.
+type Server struct {
+	changerequestv1.UnimplementedChangeRequestServiceServer
+	repo           repository.Repository
+	membershipRepo rbac.OrgMembershipGetter
+	policyConfigs  PolicyConfigStore
+	policies       PolicyStore
+	auditLogger    audit.AuditLogger
+	notifier       changerequest.Notifier
+	plans          *billing.Gate
+}
+
+// NewServer returns a new ChangeRequest gRPC server. If repo is nil, all RPCs return Unimplemented. Proposals of a
+// kind whose store (policyConfigs or policies) is nil return Unimplemented. auditLogger, notifier and plans may be nil;
+// with plans, proposing and applying Rego policy creates and updates require a plan with custom Rego.
+func NewServer(
+	repo repository.Repository,
+	membershipRepo rbac.OrgMembershipGetter,
+	policyConfigs PolicyConfigStore,
+	policies PolicyStore,
+	auditLogger audit.AuditLogger,
+	notifier changerequest.Notifier,
+	plans *billing.Gate,
+) *Server {
+	return &Server{
+		repo:           repo,
+		membershipRepo: membershipRepo,
+		policyConfigs:  policyConfigs,
+		policies:       policies,
+		auditLogger:    auditLogger,
+		notifier:       notifier,
+		plans:          plans,
+	}
+}
+
+// ProposeChange validates the proposed change and stores it as a pending request without applying it.
+func (s *Server) ProposeChange(ctx context.Context, req *changerequestv1.ProposeChangeRequest) (*changerequestv1.ProposeChangeResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ProposeChange not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	reason, err := validateText("reason", req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	cr := &domain.ChangeRequest{
+		ID:         uuid.New().String(),
+		OrgID:      orgID,
+		Status:     domain.StatusPending,
+		ProposedBy: userID,
+		Reason:     reason,
+		CreatedAt:  time.Now().UTC(),
+	}
+	switch change := req.GetChange().(type) {
+	case *changerequestv1.ProposeChangeRequest_PolicyConfig:
+		if s.policyConfigs == nil {
+			return nil, status.Error(codes.Unimplemented, "org policy config changes not configured")
+		}
+		pc := change.PolicyConfig
+		proposed, err := s.policyConfigs.ProposeConfig(ctx, orgID, pc.GetConfig(), pc.GetUpdateMask().GetPaths(), pc.GetEtag())
+		if err != nil {
+			return nil, err
+		}
+		cr.Kind = domain.KindOrgPolicyConfig
+		cr.PolicyConfig = &domain.PolicyConfigChange{
+			Config:      proposed.Config,
+			BaseVersion: proposed.BaseVersion,
+			Changes:     proposed.Changes,
+		}
+	case *changerequestv1.ProposeChangeRequest_Policy:
+		if s.policies == nil {
+			return nil, status.Error(codes.Unimplemented, "policy changes not configured")
+		}
+		policy, err := s.validatePolicyChange(ctx, orgID, change.Policy)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.requireCustomRego(ctx, orgID, policy); err != nil {
+			return nil, err
+		}
+		cr.Kind = domain.KindPolicy
+		cr.Policy = policy
+	default:
+		return nil, status.Error(codes.InvalidArgument, "policy_config or policy required")
+	}
+	if err := s.repo.Create(ctx, cr); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.record(ctx, cr, changerequest.EventProposed, userID, reason)
+	return &changerequestv1.ProposeChangeResponse{ChangeRequest: changeRequestToProto(cr)}, nil
+}
+
+// GetChangeRequest returns one of the caller's org's change requests.
+func (s *Server) GetChangeRequest(ctx context.Context, req *changerequestv1.GetChangeRequestRequest) (*changerequestv1.GetChangeRequestResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetChangeRequest not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := s.load(ctx, orgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &changerequestv1.GetChangeRequestResponse{ChangeRequest: changeRequestToProto(cr)}, nil
+}
+
+// ListChangeRequests returns the org's change requests, newest first, optionally only those with one status.
+func (s *Server) ListChangeRequests(ctx context.Context, req *changerequestv1.ListChangeRequestsRequest) (*changerequestv1.ListChangeRequestsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListChangeRequests not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	filter := domain.Status(req.GetStatus())
+	switch filter {
+	case "", domain.StatusPending, domain.StatusApproved, domain.StatusRejected:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be one of pending, approved, rejected")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.repo.ListByOrg(ctx, orgID, filter, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list change requests")
+	}
+	out := make([]*changerequestv1.ChangeRequest, len(list))
+	for i, cr := range list {
+		out[i] = changeRequestToProto(cr)
+	}
+	result := &changerequestv1.ListChangeRequestsResponse{
+		ChangeRequests: out,
+		Pagination:     &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// ApproveChangeRequest applies a pending request and marks it approved. The proposer cannot approve it. When the
+// change can no longer be applied (e.g. the config changed since the proposal) the request stays pending.
+func (s *Server) ApproveChangeRequest(ctx context.Context, req *changerequestv1.ApproveChangeRequestRequest) (*changerequestv1.ApproveChangeRequestResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ApproveChangeRequest not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := validateText("comment", req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	cr, err := s.load(ctx, orgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if cr.Status != domain.StatusPending {
+		return nil, status.Error(codes.FailedPrecondition, "change request is not pending")
+	}
+	if cr.ProposedBy == userID {
+		return nil, status.Error(codes.PermissionDenied, "change request must be approved by an admin other than the proposer")
+	}
+	// Claim the request first so two approvers cannot both apply it.
+	review := &domain.Review{By: userID, Comment: comment, At: time.Now().UTC()}
+	ok, err := s.repo.Transition(ctx, cr.ID, domain.StatusPending, domain.StatusApproved, review)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "change request is not pending")
+	}
+	if err := s.apply(ctx, cr); err != nil {
+		if _, rerr := s.repo.Transition(ctx, cr.ID, domain.StatusApproved, domain.StatusPending, nil); rerr != nil {
+			log.Printf("changerequest: failed to reopen %s after failed apply: %v", cr.ID, rerr)
+		}
+		return nil, err
+	}
+	cr.Status = domain.StatusApproved
+	cr.Review = review
+	s.record(ctx, cr, changerequest.EventApproved, userID, comment)
+	return &changerequestv1.ApproveChangeRequestResponse{ChangeRequest: changeRequestToProto(cr)}, nil
+}
+
+// RejectChangeRequest marks a pending request rejected without applying it. Any org admin may reject, including
+// the proposer (withdrawing it).
+func (s *Server) RejectChangeRequest(ctx context.Context, req *changerequestv1.RejectChangeRequestRequest) (*changerequestv1.RejectChangeRequestResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RejectChangeRequest not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := validateText("comment", req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	cr, err := s.load(ctx, orgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	review := &domain.Review{By: userID, Comment: comment, At: time.Now().UTC()}
+	ok, err := s.repo.Transition(ctx, cr.ID, domain.StatusPending, domain.StatusRejected, review)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "change request is not pending")
+	}
+	cr.Status = domain.StatusRejected
+	cr.Review = review
+	s.record(ctx, cr, changerequest.EventRejected, userID, comment)
+	return &changerequestv1.RejectChangeRequestResponse{ChangeRequest: changeRequestToProto(cr)}, nil
+}
+
+// load returns the change request if it belongs to orgID; otherwise NotFound, so other orgs' IDs are not revealed.
+func (s *Server) load(ctx context.Context, orgID, id string) (*domain.ChangeRequest, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	cr, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if cr == nil || cr.OrgID != orgID {
+		return nil, status.Error(codes.NotFound, "change request not found")
+	}
+	return cr, nil
+}
+
+// validatePolicyChange checks a proposed Rego policy write against the org's policies.
+func (s *Server) validatePolicyChange(ctx context.Context, orgID string, p *changerequestv1.PolicyChange) (*domain.PolicyChange, error) {
+	out := &domain.PolicyChange{
+		Operation: domain.PolicyOperation(strings.ToLower(strings.TrimSpace(p.GetOperation()))),
+		PolicyID:  p.GetPolicyId(),
+		Rules:     p.GetRules(),
+		Enabled:   p.GetEnabled(),
+	}
+	switch out.Operation {
+	case domain.PolicyOperationCreate:
+		out.PolicyID = ""
+	case domain.PolicyOperationUpdate, domain.PolicyOperationDelete:
+		if out.PolicyID == "" {
+			return nil, status.Error(codes.InvalidArgument, "policy_id required")
+		}
+		existing, err := s.policies.GetByID(ctx, out.PolicyID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if existing == nil || existing.OrgID != orgID {
+			return nil, status.Error(codes.NotFound, "policy not found")
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "operation must be one of create, update, delete")
+	}
+	if out.Operation == domain.PolicyOperationDelete {
+		out.Rules, out.Enabled = "", false
+		return out, nil
+	}
+	if out.Rules == "" {
+		return nil, status.Error(codes.InvalidArgument, "rules (Rego policy) is required")
+	}
+	if _, err := ast.ParseModule("", out.Rules); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid Rego syntax: "+err.Error())
+	}
+	return out, nil
+}
+
+// apply makes the approved change. The config is stored only if it is still at the version it was proposed against.
+func (s *Server) apply(ctx context.Context, cr *domain.ChangeRequest) error {
+	switch cr.Kind {
+	case domain.KindOrgPolicyConfig:
+		if s.policyConfigs == nil || cr.PolicyConfig == nil {
+			return status.Error(codes.Unimplemented, "org policy config changes not configured")
+		}
+		change := orgpolicyconfigdomain.Change{By: cr.ProposedBy, Source: orgpolicyconfigdomain.ChangeSourceChangeRequest}
+		_, err := s.policyConfigs.ApplyProposedConfig(ctx, cr.OrgID, cr.PolicyConfig.Config, cr.PolicyConfig.BaseVersion, change)
+		return err
+	case domain.KindPolicy:
+		if s.policies == nil || cr.Policy == nil {
+			return status.Error(codes.Unimplemented, "policy changes not configured")
+		}
+		return s.applyPolicy(ctx, cr.OrgID, cr.Policy)
+	default:
+		return status.Errorf(codes.Internal, "unknown change request kind %q", cr.Kind)
+	}
+}
+
+// requireCustomRego checks that the org's plan includes custom Rego for a policy create or update; deletes are
+// always allowed, so an org that lost the feature can still remove its policies.
+func (s *Server) requireCustomRego(ctx context.Context, orgID string, p *domain.PolicyChange) error {
+	if p.Operation == domain.PolicyOperationDelete {
+		return nil
+	}
+	return s.plans.Require(ctx, orgID, billing.FeatureCustomRego)
+}
+
+func (s *Server) applyPolicy(ctx context.Context, orgID string, p *domain.PolicyChange) error {
+	if err := s.requireCustomRego(ctx, orgID, p); err != nil {
+		return err
+	}
+	if p.Operation == domain.PolicyOperationCreate {
+		policy := &policydomain.Policy{
+			ID:        uuid.New().String(),
+			OrgID:     orgID,
+			Rules:     p.Rules,
+			Enabled:   p.Enabled,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := s.policies.Create(ctx, policy); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return nil
+	}
+	existing, err := s.policies.GetByID(ctx, p.PolicyID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if existing == nil || existing.OrgID != orgID {
+		return status.Error(codes.FailedPrecondition, "policy no longer exists; reject the change request")
+	}
+	if p.Operation == domain.PolicyOperationDelete {
+		err = s.policies.Delete(ctx, p.PolicyID)
+	} else {
+		existing.Rules = p.Rules
+		existing.Enabled = p.Enabled
+		err = s.policies.Update(ctx, existing)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// record audits a change request step as change_request_<proposed|approved|rejected> and sends it to the webhook.
+func (s *Server) record(ctx context.Context, cr *domain.ChangeRequest, eventType, actor, comment string) {
+	if s.auditLogger != nil {
+		metadata := map[string]interface{}{"change_request_id": cr.ID, "kind": cr.Kind}
+		if cr.Policy != nil {
+			metadata["operation"] = cr.Policy.Operation
+			if cr.Policy.PolicyID != "" {
+				metadata["policy_id"] = cr.Policy.PolicyID
+			}
+		}
+		meta, _ := json.Marshal(metadata)
+		action := "change_request_" + strings.TrimPrefix(eventType, "change_request.")
+		s.auditLogger.LogEvent(ctx, cr.OrgID, actor, action, "change_request", string(meta))
+	}
+	if s.notifier != nil {
+		s.notifier.Notify(ctx, changerequest.Event{
+			Type:            eventType,
+			ChangeRequestID: cr.ID,
+			OrgID:           cr.OrgID,
+			Kind:            string(cr.Kind),
+			Status:          string(cr.Status),
+			Actor:           actor,
+			Comment:         comment,
+			OccurredAt:      time.Now().UTC(),
+		})
+	}
+}
+
+func validateText(field, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > domain.MaxReasonLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", field, domain.MaxReasonLength)
+	}
+	return s, nil
+}
+
+func changeRequestToProto(cr *domain.ChangeRequest) *changerequestv1.ChangeRequest {
+	out := &changerequestv1.ChangeRequest{
+		Id:         cr.ID,
+		OrgId:      cr.OrgID,
+		Kind:       string(cr.Kind),
+		Status:     string(cr.Status),
+		ProposedBy: cr.ProposedBy,
+		Reason:     cr.Reason,
+		CreatedAt:  timestamppb.New(cr.CreatedAt),
+	}
+	if pc := cr.PolicyConfig; pc != nil {
+		out.PolicyConfig = orgpolicyconfighandler.ConfigToProto(pc.Config)
+		for _, c := range pc.Changes {
+			out.PolicyConfigChanges = append(out.PolicyConfigChanges, &orgpolicyconfigv1.PolicyConfigChange{Path: c.Path, OldValue: c.OldValue, NewValue: c.NewValue})
+		}
+	}
+	if p := cr.Policy; p != nil {
+		out.Policy = &changerequestv1.PolicyChange{
+			Operation: string(p.Operation),
+			PolicyId:  p.PolicyID,
+			Rules:     p.Rules,
+			Enabled:   p.Enabled,
+		}
+	}
+	if r := cr.Review; r != nil {
+		out.ReviewedBy = r.By
+		out.ReviewComment = r.Comment
+		out.ReviewedAt = timestamppb.New(r.At)
+	}
+	return out
+}
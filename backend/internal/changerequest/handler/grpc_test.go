@@ -0,0 +1,454 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	changerequestv1 "zero-trust-control-plane/backend/api/generated/changerequest/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	"zero-trust-control-plane/backend/internal/billing"
+	"zero-trust-control-plane/backend/internal/changerequest"
+	"zero-trust-control-plane/backend/internal/changerequest/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	orgpolicyconfighandler "zero-trust-control-plane/backend/internal/orgpolicyconfig/handler"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+const validRego = "package ztcp.device_trust\ndefault allow = true"
+
+type mockRepo struct {
+	requests map[string]*domain.ChangeRequest
+	gotLimit int32
+}
+
+func (m *mockRepo) Create(ctx context.Context, cr *domain.ChangeRequest) error {
+	c := *cr
+	m.requests[cr.ID] = &c
+	return nil
+}
+
+func (m *mockRepo) GetByID(ctx context.Context, id string) (*domain.ChangeRequest, error) {
+	cr, ok := m.requests[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *cr
+	return &c, nil
+}
+
+func (m *mockRepo) ListByOrg(ctx context.Context, orgID string, st domain.Status, limit, offset int32) ([]*domain.ChangeRequest, error) {
+	m.gotLimit = limit
+	var out []*domain.ChangeRequest
+	for _, cr := range m.requests {
+		if cr.OrgID == orgID && (st == "" || cr.Status == st) {
+			out = append(out, cr)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) Transition(ctx context.Context, id string, from, to domain.Status, review *domain.Review) (bool, error) {
+	cr, ok := m.requests[id]
+	if !ok || cr.Status != from {
+		return false, nil
+	}
+	cr.Status, cr.Review = to, review
+	return true, nil
+}
+
+type mockMembershipRepo struct {
+	memberships map[string]*membershipdomain.Membership
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	return m.memberships[userID+":"+orgID], nil
+}
+
+type mockPolicyConfigStore struct {
+	applied  []orgpolicyconfigdomain.Change
+	applyErr error
+}
+
+func (m *mockPolicyConfigStore) ProposeConfig(ctx context.Context, orgID string, update *orgpolicyconfigv1.OrgPolicyConfig, sections []string, etag string) (*orgpolicyconfighandler.ProposedConfig, error) {
+	cfg := orgpolicyconfigdomain.MergeWithDefaults(nil)
+	cfg.SessionMgmt.ConcurrentSessionLimit = int(update.GetSessionMgmt().GetConcurrentSessionLimit())
+	return &orgpolicyconfighandler.ProposedConfig{
+		Config:      cfg,
+		BaseVersion: 3,
+		Changes:     []orgpolicyconfigdomain.FieldChange{{Path: "session_mgmt.concurrent_session_limit", OldValue: "0", NewValue: "2"}},
+	}, nil
+}
+
+func (m *mockPolicyConfigStore) ApplyProposedConfig(ctx context.Context, orgID string, config *orgpolicyconfigdomain.OrgPolicyConfig, baseVersion int64, change orgpolicyconfigdomain.Change) (int64, error) {
+	if m.applyErr != nil {
+		return 0, m.applyErr
+	}
+	m.applied = append(m.applied, change)
+	return baseVersion + 1, nil
+}
+
+type mockPolicyStore struct {
+	policies map[string]*policydomain.Policy
+}
+
+func (m *mockPolicyStore) GetByID(ctx context.Context, id string) (*policydomain.Policy, error) {
+	return m.policies[id], nil
+}
+
+func (m *mockPolicyStore) Create(ctx context.Context, p *policydomain.Policy) error {
+	m.policies[p.ID] = p
+	return nil
+}
+
+func (m *mockPolicyStore) Update(ctx context.Context, p *policydomain.Policy) error {
+	m.policies[p.ID] = p
+	return nil
+}
+
+func (m *mockPolicyStore) Delete(ctx context.Context, id string) error {
+	delete(m.policies, id)
+	return nil
+}
+
+type mockAuditLogger struct {
+	actions []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+}
+
+type mockNotifier struct {
+	mu     sync.Mutex
+	events []changerequest.Event
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, e changerequest.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, e)
+}
+
+type testEnv struct {
+	srv      *Server
+	repo     *mockRepo
+	configs  *mockPolicyConfigStore
+	policies *mockPolicyStore
+	audit    *mockAuditLogger
+	notifier *mockNotifier
+}
+
+func newTestEnv() *testEnv {
+	env := &testEnv{
+		repo:    &mockRepo{requests: map[string]*domain.ChangeRequest{}},
+		configs: &mockPolicyConfigStore{},
+		policies: &mockPolicyStore{policies: map[string]*policydomain.Policy{
+			"policy-1": {ID: "policy-1", OrgID: "org-1", Rules: validRego, Enabled: true},
+			"policy-2": {ID: "policy-2", OrgID: "org-2", Rules: validRego, Enabled: true},
+		}},
+		audit:    &mockAuditLogger{},
+		notifier: &mockNotifier{},
+	}
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"admin-2:org-1":  {ID: "m2", UserID: "admin-2", OrgID: "org-1", Role: membershipdomain.RoleOwner},
+		"member-1:org-1": {ID: "m3", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		"admin-3:org-2":  {ID: "m4", UserID: "admin-3", OrgID: "org-2", Role: membershipdomain.RoleAdmin},
+	}}
+	env.srv = NewServer(env.repo, membershipRepo, env.configs, env.policies, env.audit, env.notifier, nil)
+	return env
+}
+
+func ctxFor(userID, orgID string) context.Context {
+	return interceptors.WithIdentity(context.Background(), userID, orgID, "session-"+userID)
+}
+
+func proposeConfig(t *testing.T, env *testEnv) *changerequestv1.ChangeRequest {
+	t.Helper()
+	resp, err := env.srv.ProposeChange(ctxFor("admin-1", "org-1"), &changerequestv1.ProposeChangeRequest{
+		Reason: "  tighten session limits ",
+		Change: &changerequestv1.ProposeChangeRequest_PolicyConfig{PolicyConfig: &changerequestv1.ProposedPolicyConfig{
+			Config: &orgpolicyconfigv1.OrgPolicyConfig{SessionMgmt: &orgpolicyconfigv1.SessionMgmt{ConcurrentSessionLimit: 2}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("ProposeChange: %v", err)
+	}
+	return resp.GetChangeRequest()
+}
+
+func TestProposeChange_PolicyConfig(t *testing.T) {
+	env := newTestEnv()
+	cr := proposeConfig(t, env)
+	if cr.GetStatus() != "pending" || cr.GetKind() != "org_policy_config" || cr.GetProposedBy() != "admin-1" {
+		t.Errorf("change request = %+v", cr)
+	}
+	if cr.GetReason() != "tighten session limits" {
+		t.Errorf("reason = %q", cr.GetReason())
+	}
+	if cr.GetPolicyConfig().GetSessionMgmt().GetConcurrentSessionLimit() != 2 || len(cr.GetPolicyConfigChanges()) != 1 {
+		t.Errorf("proposed config/changes not returned: %+v", cr)
+	}
+	if len(env.configs.applied) != 0 {
+		t.Error("proposing must not apply the change")
+	}
+	if len(env.audit.actions) != 1 || env.audit.actions[0] != "change_request_proposed" {
+		t.Errorf("audit actions = %v", env.audit.actions)
+	}
+	if len(env.notifier.events) != 1 || env.notifier.events[0].Type != changerequest.EventProposed {
+		t.Errorf("notifier events = %+v", env.notifier.events)
+	}
+}
+
+func TestProposeChange_Policy(t *testing.T) {
+	env := newTestEnv()
+	ctx := ctxFor("admin-1", "org-1")
+	propose := func(p *changerequestv1.PolicyChange) error {
+		_, err := env.srv.ProposeChange(ctx, &changerequestv1.ProposeChangeRequest{
+			Change: &changerequestv1.ProposeChangeRequest_Policy{Policy: p},
+		})
+		return err
+	}
+	if err := propose(&changerequestv1.PolicyChange{Operation: "create", Rules: validRego, Enabled: true}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := propose(&changerequestv1.PolicyChange{Operation: "delete", PolicyId: "policy-1"}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	cases := []struct {
+		name string
+		p    *changerequestv1.PolicyChange
+		code codes.Code
+	}{
+		{"bad operation", &changerequestv1.PolicyChange{Operation: "disable"}, codes.InvalidArgument},
+		{"invalid rego", &changerequestv1.PolicyChange{Operation: "create", Rules: "package"}, codes.InvalidArgument},
+		{"missing rules", &changerequestv1.PolicyChange{Operation: "create"}, codes.InvalidArgument},
+		{"missing policy_id", &changerequestv1.PolicyChange{Operation: "update", Rules: validRego}, codes.InvalidArgument},
+		{"other org's policy", &changerequestv1.PolicyChange{Operation: "update", PolicyId: "policy-2", Rules: validRego}, codes.NotFound},
+	}
+	for _, tc := range cases {
+		if err := propose(tc.p); status.Code(err) != tc.code {
+			t.Errorf("%s: code = %v, want %v", tc.name, status.Code(err), tc.code)
+		}
+	}
+	if _, err := env.srv.ProposeChange(ctx, &changerequestv1.ProposeChangeRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("no change: code = %v, want InvalidArgument", status.Code(err))
+	}
+	if _, err := env.srv.ProposeChange(ctxFor("member-1", "org-1"), &changerequestv1.ProposeChangeRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if len(env.repo.requests) != 2 {
+		t.Errorf("stored %d requests, want 2", len(env.repo.requests))
+	}
+}
+
+func TestApproveChangeRequest(t *testing.T) {
+	env := newTestEnv()
+	cr := proposeConfig(t, env)
+
+	_, err := env.srv.ApproveChangeRequest(ctxFor("admin-1", "org-1"), &changerequestv1.ApproveChangeRequestRequest{Id: cr.GetId()})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("proposer approval: code = %v, want PermissionDenied", status.Code(err))
+	}
+	_, err = env.srv.ApproveChangeRequest(ctxFor("admin-3", "org-2"), &changerequestv1.ApproveChangeRequestRequest{Id: cr.GetId()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("other org approval: code = %v, want NotFound", status.Code(err))
+	}
+
+	resp, err := env.srv.ApproveChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.ApproveChangeRequestRequest{Id: cr.GetId(), Comment: "lgtm"})
+	if err != nil {
+		t.Fatalf("ApproveChangeRequest: %v", err)
+	}
+	got := resp.GetChangeRequest()
+	if got.GetStatus() != "approved" || got.GetReviewedBy() != "admin-2" || got.GetReviewComment() != "lgtm" || got.GetReviewedAt() == nil {
+		t.Errorf("approved request = %+v", got)
+	}
+	if len(env.configs.applied) != 1 {
+		t.Fatalf("applied %d times, want 1", len(env.configs.applied))
+	}
+	if c := env.configs.applied[0]; c.By != "admin-1" || c.Source != orgpolicyconfigdomain.ChangeSourceChangeRequest {
+		t.Errorf("applied change = %+v, want by proposer from change_request", c)
+	}
+	if env.audit.actions[len(env.audit.actions)-1] != "change_request_approved" {
+		t.Errorf("audit actions = %v", env.audit.actions)
+	}
+	if e := env.notifier.events[len(env.notifier.events)-1]; e.Type != changerequest.EventApproved || e.Actor != "admin-2" {
+		t.Errorf("last event = %+v", e)
+	}
+
+	_, err = env.srv.ApproveChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.ApproveChangeRequestRequest{Id: cr.GetId()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second approval: code = %v, want FailedPrecondition", status.Code(err))
+	}
+}
+
+func TestApproveChangeRequest_ApplyFailureKeepsPending(t *testing.T) {
+	env := newTestEnv()
+	cr := proposeConfig(t, env)
+	env.configs.applyErr = status.Error(codes.FailedPrecondition, "org policy config has changed since the change was proposed")
+
+	_, err := env.srv.ApproveChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.ApproveChangeRequestRequest{Id: cr.GetId()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("code = %v, want FailedPrecondition", status.Code(err))
+	}
+	stored := env.repo.requests[cr.GetId()]
+	if stored.Status != domain.StatusPending || stored.Review != nil {
+		t.Errorf("stored request = %+v, want pending without review", stored)
+	}
+	for _, a := range env.audit.actions {
+		if a == "change_request_approved" {
+			t.Error("failed approval was audited as approved")
+		}
+	}
+}
+
+func TestApproveChangeRequest_Policy(t *testing.T) {
+	env := newTestEnv()
+	resp, err := env.srv.ProposeChange(ctxFor("admin-1", "org-1"), &changerequestv1.ProposeChangeRequest{
+		Change: &changerequestv1.ProposeChangeRequest_Policy{Policy: &changerequestv1.PolicyChange{
+			Operation: "update", PolicyId: "policy-1", Rules: validRego + "\n", Enabled: false,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("ProposeChange: %v", err)
+	}
+	if !env.policies.policies["policy-1"].Enabled {
+		t.Fatal("proposing must not update the policy")
+	}
+	if _, err := env.srv.ApproveChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.ApproveChangeRequestRequest{Id: resp.GetChangeRequest().GetId()}); err != nil {
+		t.Fatalf("ApproveChangeRequest: %v", err)
+	}
+	if p := env.policies.policies["policy-1"]; p.Enabled || p.Rules != validRego+"\n" {
+		t.Errorf("policy after approval = %+v", p)
+	}
+}
+
+type planOrgs map[string]*organizationdomain.Org
+
+func (o planOrgs) GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error) {
+	return o[id], nil
+}
+
+func (o planOrgs) UpdateOrganization(ctx context.Context, org *organizationdomain.Org) error {
+	o[org.ID] = org
+	return nil
+}
+
+func TestPolicyChange_PlanRequired(t *testing.T) {
+	env := newTestEnv()
+	orgs := planOrgs{"org-1": {ID: "org-1", Plan: organizationdomain.PlanEnterprise}}
+	env.srv.plans = billing.NewGate(orgs, nil, nil)
+	propose := func(p *changerequestv1.PolicyChange) (*changerequestv1.ChangeRequest, error) {
+		resp, err := env.srv.ProposeChange(ctxFor("admin-1", "org-1"), &changerequestv1.ProposeChangeRequest{
+			Change: &changerequestv1.ProposeChangeRequest_Policy{Policy: p},
+		})
+		return resp.GetChangeRequest(), err
+	}
+	cr, err := propose(&changerequestv1.PolicyChange{Operation: "update", PolicyId: "policy-1", Rules: validRego})
+	if err != nil {
+		t.Fatalf("propose on enterprise: %v", err)
+	}
+
+	orgs["org-1"].Plan = organizationdomain.PlanTeam
+	if _, err := propose(&changerequestv1.PolicyChange{Operation: "create", Rules: validRego}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("create on team: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := propose(&changerequestv1.PolicyChange{Operation: "delete", PolicyId: "policy-1"}); err != nil {
+		t.Errorf("delete on team: %v", err)
+	}
+	_, err = env.srv.ApproveChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.ApproveChangeRequestRequest{Id: cr.GetId()})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("approve after downgrade: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if env.repo.requests[cr.GetId()].Status != domain.StatusPending {
+		t.Error("request refused by plan should stay pending")
+	}
+}
+
+func TestRejectChangeRequest(t *testing.T) {
+	env := newTestEnv()
+	cr := proposeConfig(t, env)
+
+	// The proposer may withdraw their own request.
+	resp, err := env.srv.RejectChangeRequest(ctxFor("admin-1", "org-1"), &changerequestv1.RejectChangeRequestRequest{Id: cr.GetId(), Comment: "not needed"})
+	if err != nil {
+		t.Fatalf("RejectChangeRequest: %v", err)
+	}
+	if resp.GetChangeRequest().GetStatus() != "rejected" {
+		t.Errorf("status = %q, want rejected", resp.GetChangeRequest().GetStatus())
+	}
+	if len(env.configs.applied) != 0 {
+		t.Error("rejected change was applied")
+	}
+	if env.audit.actions[len(env.audit.actions)-1] != "change_request_rejected" {
+		t.Errorf("audit actions = %v", env.audit.actions)
+	}
+	_, err = env.srv.ApproveChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.ApproveChangeRequestRequest{Id: cr.GetId()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("approve after reject: code = %v, want FailedPrecondition", status.Code(err))
+	}
+}
+
+func TestListChangeRequests(t *testing.T) {
+	env := newTestEnv()
+	first := proposeConfig(t, env)
+	proposeConfig(t, env)
+	if _, err := env.srv.RejectChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.RejectChangeRequestRequest{Id: first.GetId()}); err != nil {
+		t.Fatalf("RejectChangeRequest: %v", err)
+	}
+	ctx := ctxFor("admin-2", "org-1")
+
+	resp, err := env.srv.ListChangeRequests(ctx, &changerequestv1.ListChangeRequestsRequest{})
+	if err != nil {
+		t.Fatalf("ListChangeRequests: %v", err)
+	}
+	if len(resp.GetChangeRequests()) != 2 || env.repo.gotLimit != defaultPageSize {
+		t.Errorf("got %d requests (limit %d), want 2 (limit %d)", len(resp.GetChangeRequests()), env.repo.gotLimit, defaultPageSize)
+	}
+	resp, err = env.srv.ListChangeRequests(ctx, &changerequestv1.ListChangeRequestsRequest{Status: "pending"})
+	if err != nil {
+		t.Fatalf("ListChangeRequests(pending): %v", err)
+	}
+	if len(resp.GetChangeRequests()) != 1 || resp.GetChangeRequests()[0].GetId() == first.GetId() {
+		t.Errorf("pending filter returned %+v", resp.GetChangeRequests())
+	}
+	if _, err := env.srv.ListChangeRequests(ctx, &changerequestv1.ListChangeRequestsRequest{Status: "merged"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad status: code = %v, want InvalidArgument", status.Code(err))
+	}
+	resp, err = env.srv.ListChangeRequests(ctxFor("admin-3", "org-2"), &changerequestv1.ListChangeRequestsRequest{})
+	if err != nil {
+		t.Fatalf("ListChangeRequests(org-2): %v", err)
+	}
+	if len(resp.GetChangeRequests()) != 0 {
+		t.Errorf("other org sees %d requests", len(resp.GetChangeRequests()))
+	}
+}
+
+func TestGetChangeRequest(t *testing.T) {
+	env := newTestEnv()
+	cr := proposeConfig(t, env)
+	resp, err := env.srv.GetChangeRequest(ctxFor("admin-2", "org-1"), &changerequestv1.GetChangeRequestRequest{Id: cr.GetId()})
+	if err != nil {
+		t.Fatalf("GetChangeRequest: %v", err)
+	}
+	if resp.GetChangeRequest().GetId() != cr.GetId() {
+		t.Errorf("id = %q", resp.GetChangeRequest().GetId())
+	}
+	if _, err := env.srv.GetChangeRequest(ctxFor("admin-3", "org-2"), &changerequestv1.GetChangeRequestRequest{Id: cr.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("other org: code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestNilRepo_Unimplemented(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil)
+	if _, err := srv.ProposeChange(context.Background(), &changerequestv1.ProposeChangeRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
+	}
+}
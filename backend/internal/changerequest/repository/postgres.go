@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"zero-trust-control-plane/backend/internal/changerequest/domain"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+// payload is the JSON form of the proposed change stored in payload_json.
+type payload struct {
+	PolicyConfig *domain.PolicyConfigChange `json:"policy_config,omitempty"`
+	Policy       *domain.PolicyChange       `json:"policy,omitempty"`
+}
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a change request repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists a new change request. The proposed change is stored as JSON.
+func (r *PostgresRepository) Create(ctx context.Context, cr *domain.ChangeRequest) error {
+	raw, err := json.Marshal(payload{PolicyConfig: cr.PolicyConfig, Policy: cr.Policy})
+	if err != nil {
+		return err
+	}
+	return r.queries.CreateChangeRequest(ctx, gen.CreateChangeRequestParams{
+		ID:          cr.ID,
+		OrgID:       cr.OrgID,
+		Kind:        string(cr.Kind),
+		Status:      string(cr.Status),
+		ProposedBy:  cr.ProposedBy,
+		Reason:      cr.Reason,
+		PayloadJson: string(raw),
+		CreatedAt:   cr.CreatedAt,
+	})
+}
+
+// GetByID returns the change request, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.ChangeRequest, error) {
+	row, err := r.queries.GetChangeRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genChangeRequestToDomain(&row)
+}
+
+// ListByOrg returns the org's change requests, newest first, optionally only those in status.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, status domain.Status, limit, offset int32) ([]*domain.ChangeRequest, error) {
+	rows, err := r.queries.ListChangeRequestsByOrg(ctx, gen.ListChangeRequestsByOrgParams{
+		OrgID:        orgID,
+		Limit:        limit,
+		Offset:       offset,
+		FilterStatus: sql.NullString{String: string(status), Valid: status != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.ChangeRequest, len(rows))
+	for i := range rows {
+		if out[i], err = genChangeRequestToDomain(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Transition moves the request from one status to another if it is still in from, recording review.
+func (r *PostgresRepository) Transition(ctx context.Context, id string, from, to domain.Status, review *domain.Review) (bool, error) {
+	arg := gen.TransitionChangeRequestParams{
+		Status:     string(to),
+		ID:         id,
+		FromStatus: string(from),
+	}
+	if review != nil {
+		arg.ReviewedBy = sql.NullString{String: review.By, Valid: true}
+		arg.ReviewComment = review.Comment
+		arg.ReviewedAt = sql.NullTime{Time: review.At, Valid: true}
+	}
+	n, err := r.queries.TransitionChangeRequest(ctx, arg)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func genChangeRequestToDomain(row *gen.ChangeRequest) (*domain.ChangeRequest, error) {
+	var p payload
+	if err := json.Unmarshal([]byte(row.PayloadJson), &p); err != nil {
+		return nil, err
+	}
+	cr := &domain.ChangeRequest{
+		ID:           row.ID,
+		OrgID:        row.OrgID,
+		Kind:         domain.Kind(row.Kind),
+		Status:       domain.Status(row.Status),
+		ProposedBy:   row.ProposedBy,
+		Reason:       row.Reason,
+		PolicyConfig: p.PolicyConfig,
+		Policy:       p.Policy,
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.ReviewedBy.Valid {
+		cr.Review = &domain.Review{By: row.ReviewedBy.String, Comment: row.ReviewComment, At: row.ReviewedAt.Time}
+	}
+	return cr, nil
+}
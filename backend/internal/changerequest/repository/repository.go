@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/changerequest/domain"
+)
+
+// Repository persists change requests.
+type Repository interface {
+	// Create persists a new change request. The request must have ID set.
+	Create(ctx context.Context, cr *domain.ChangeRequest) error
+	// GetByID returns the change request, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.ChangeRequest, error)
+	// ListByOrg returns the org's change requests, newest first. An empty status matches every status.
+	ListByOrg(ctx context.Context, orgID string, status domain.Status, limit, offset int32) ([]*domain.ChangeRequest, error)
+	// Transition moves the request from one status to another and records review (nil clears it). It reports false,
+	// without changing anything, when the request is no longer in from.
+	Transition(ctx context.Context, id string, from, to domain.Status, review *domain.Review) (bool, error)
+}
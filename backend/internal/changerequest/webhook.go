@@ -0,0 +1,62 @@
+// Package changerequest notifies external systems (chat, ticketing) of four-eyes change request events over an
+// outbound webhook.
+package changerequest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+// Event types.
+const (
+	EventProposed = "change_request.proposed"
+	EventApproved = "change_request.approved"
+	EventRejected = "change_request.rejected"
+)
+
+// Event is a change request lifecycle notification. Actor is the user ID of the proposer or reviewer.
+type Event struct {
+	Type            string    `json:"type"`
+	ChangeRequestID string    `json:"change_request_id"`
+	OrgID           string    `json:"org_id"`
+	Kind            string    `json:"kind"`
+	Status          string    `json:"status"`
+	Actor           string    `json:"actor"`
+	Comment         string    `json:"comment,omitempty"` // proposer's reason or reviewer's comment
+	OccurredAt      time.Time `json:"occurred_at"`
+}
+
+// Notifier delivers change request events. Notify is best-effort and must not block the caller.
+type Notifier interface {
+	Notify(ctx context.Context, e Event)
+}
+
+// WebhookNotifier POSTs events as JSON to a URL. When Secret is set each request is signed (webhook.SignatureHeader).
+type WebhookNotifier struct {
+	URL        string
+	Secret     string
+	HTTPClient *http.Client
+}
+
+// NewWebhookNotifier returns a notifier that posts to url, signing with secret when it is non-empty.
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:        url,
+		Secret:     secret,
+		HTTPClient: webhook.NewHTTPClient(),
+	}
+}
+
+// Notify sends e in the background. Failures are logged and not retried.
+func (w *WebhookNotifier) Notify(ctx context.Context, e Event) {
+	webhook.Go(fmt.Sprintf("changerequest: webhook %s for %s", e.Type, e.ChangeRequestID), func(ctx context.Context) error { return w.Send(ctx, e) })
+}
+
+// Send posts the change request event e and waits for the response. Any non-2xx status is an error.
+func (w *WebhookNotifier) Send(ctx context.Context, e Event) error {
+	return webhook.Post(ctx, w.HTTPClient, w.URL, w.Secret, e)
+}
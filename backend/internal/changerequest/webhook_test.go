@@ -0,0 +1,56 @@
+package changerequest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var got Event
+	var body []byte
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(webhook.SignatureHeader)
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	e := Event{Type: EventApproved, ChangeRequestID: "cr-1", OrgID: "org-1", Kind: "policy", Status: "approved", Actor: "admin-2", OccurredAt: time.Now().UTC()}
+	if err := NewWebhookNotifier(srv.URL, "s3cret").Send(context.Background(), e); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Type != EventApproved || got.ChangeRequestID != "cr-1" || got.Actor != "admin-2" {
+		t.Errorf("delivered event = %+v", got)
+	}
+	if signature != webhook.Sign("s3cret", body) {
+		t.Errorf("signature = %q, want HMAC of body", signature)
+	}
+
+	if err := NewWebhookNotifier(srv.URL, "").Send(context.Background(), e); err != nil {
+		t.Fatalf("Send without secret: %v", err)
+	}
+	if signature != "" {
+		t.Errorf("unsigned delivery has signature %q", signature)
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	if err := NewWebhookNotifier(srv.URL, "").Send(context.Background(), Event{Type: EventProposed}); err == nil {
+		t.Error("non-2xx response should be an error")
+	}
+}
@@ -1,13 +1,22 @@
 // Package config loads and validates app config from env and an optional .env file using Viper.
+//
+// Fields tagged reload:"true" can change at runtime through Watcher (SIGHUP or CONFIG_RELOAD_INTERVAL); all other
+// fields are read once at startup. Fields tagged secret:"true" are redacted by Entries.
 package config
 
 import (
+	"encoding/base64"
 	"errors"
+	"log/slog"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+
+	"zero-trust-control-plane/backend/internal/platform/plans"
+	"zero-trust-control-plane/backend/internal/secrets"
 )
 
 // Config holds application configuration loaded from the environment.
@@ -15,9 +24,15 @@ type Config struct {
 	// GRPCAddr is the address the gRPC server listens on (e.g. :8080).
 	GRPCAddr string `mapstructure:"GRPC_ADDR"`
 	// DatabaseURL is the Postgres DSN; empty until DB is wired.
-	DatabaseURL string `mapstructure:"DATABASE_URL"`
+	DatabaseURL string `mapstructure:"DATABASE_URL" secret:"true"`
+	// DBQueryTimeout bounds each database statement of the server (e.g. "10s"), so a hung query fails the request
+	// with DeadlineExceeded instead of stalling it. "0" disables it.
+	DBQueryTimeout string `mapstructure:"DB_QUERY_TIMEOUT"`
+	// DBStatementTimeout is set as statement_timeout on the server's database connections (e.g. "30s"), a backstop
+	// for statements the client could not cancel. "0" disables it.
+	DBStatementTimeout string `mapstructure:"DB_STATEMENT_TIMEOUT"`
 	// JWTPrivateKey is the PEM-encoded private key (RSA or ECDSA) or path to file; used with JWT_PUBLIC_KEY for RS256/ES256.
-	JWTPrivateKey string `mapstructure:"JWT_PRIVATE_KEY"`
+	JWTPrivateKey string `mapstructure:"JWT_PRIVATE_KEY" secret:"true"`
 	// JWTPublicKey is the PEM-encoded public key or path to file; used with JWT_PRIVATE_KEY.
 	JWTPublicKey string `mapstructure:"JWT_PUBLIC_KEY"`
 	// JWTIssuer is the iss claim (e.g. "ztcp-auth"); required when auth is enabled.
@@ -25,32 +40,433 @@ type Config struct {
 	// JWTAudience is the aud claim (e.g. "ztcp-api"); required when auth is enabled.
 	JWTAudience string `mapstructure:"JWT_AUDIENCE"`
 	// JWTAccessTTL is the access token lifetime (e.g. "15m"). Used when auth is enabled.
-	JWTAccessTTL string `mapstructure:"JWT_ACCESS_TTL"`
+	JWTAccessTTL string `mapstructure:"JWT_ACCESS_TTL" reload:"true"`
 	// JWTRefreshTTL is the refresh token lifetime (e.g. "7d"). Used when auth is enabled.
-	JWTRefreshTTL string `mapstructure:"JWT_REFRESH_TTL"`
+	JWTRefreshTTL string `mapstructure:"JWT_REFRESH_TTL" reload:"true"`
+	// JWTClockLeeway is the clock skew tolerated on exp, nbf and iat when validating tokens, so clients and instances
+	// with slightly drifted clocks are not rejected (e.g. "30s"). "0" allows none.
+	JWTClockLeeway string `mapstructure:"JWT_CLOCK_LEEWAY" reload:"true"`
+	// RefreshReplayWindow is how long a refresh token's jti stays claimed after it is presented, so a second Refresh
+	// with the same token fails as reuse even while the first is still rotating it (e.g. "1m"). "0" disables the
+	// replay cache; the session-based reuse check always applies.
+	RefreshReplayWindow string `mapstructure:"REFRESH_REPLAY_WINDOW"`
+	// RefreshReplayRedisURL shares the refresh replay cache between server instances (redis://[:password@]host:port[/db]).
+	// Empty keeps it in memory, per instance.
+	RefreshReplayRedisURL string `mapstructure:"REFRESH_REPLAY_REDIS_URL" secret:"true"`
 	// BcryptCost is the bcrypt cost factor (4–31); default 12. Used when auth is enabled.
 	BcryptCost int `mapstructure:"BCRYPT_COST"`
 	// SMSLocalAPIKey is the API key for SMS Local (PoC MFA OTP). Required when MFA is required and no fallback.
-	SMSLocalAPIKey string `mapstructure:"SMS_LOCAL_API_KEY"`
+	SMSLocalAPIKey string `mapstructure:"SMS_LOCAL_API_KEY" secret:"true"`
 	// SMSLocalSender is the optional sender ID for SMS Local.
 	SMSLocalSender string `mapstructure:"SMS_LOCAL_SENDER"`
 	// SMSLocalBaseURL is the SMS Local API base URL (default https://www.smslocal.com/dev/bulkV2).
 	SMSLocalBaseURL string `mapstructure:"SMS_LOCAL_BASE_URL"`
+	// SMSLocalFailoverAPIKey is the API key of a secondary SMS Local account used while the primary fails. Empty
+	// disables failover.
+	SMSLocalFailoverAPIKey string `mapstructure:"SMS_LOCAL_FAILOVER_API_KEY" secret:"true"`
+	// SMSLocalFailoverSender is the optional sender ID of the secondary SMS Local account.
+	SMSLocalFailoverSender string `mapstructure:"SMS_LOCAL_FAILOVER_SENDER"`
+	// SMSLocalFailoverBaseURL is the API base URL of the secondary SMS Local account (default SMS_LOCAL_BASE_URL).
+	SMSLocalFailoverBaseURL string `mapstructure:"SMS_LOCAL_FAILOVER_BASE_URL"`
+	// SMTPHost is the SMTP server host for outbound email (login alerts). Empty disables email notifications.
+	SMTPHost string `mapstructure:"SMTP_HOST"`
+	// SMTPPort is the SMTP server port (default 587).
+	SMTPPort int `mapstructure:"SMTP_PORT"`
+	// SMTPUsername is the optional SMTP auth username (PLAIN auth when set).
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	// SMTPPassword is the optional SMTP auth password.
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD" secret:"true"`
+	// SMTPFrom is the From address for outbound email (e.g. "ZTCP <no-reply@example.com>").
+	SMTPFrom string `mapstructure:"SMTP_FROM"`
+	// OrgSMTPSecretPrefix is the secrets provider path under which orgs keep the passwords of their own SMTP servers:
+	// an org's password reference must start with <prefix><org_id>/. Empty means orgs can only configure SMTP servers
+	// without a password. Requires SECRETS_PROVIDER.
+	OrgSMTPSecretPrefix string `mapstructure:"ORG_SMTP_SECRET_PREFIX"`
+	// AnalyticsRollupInterval is how often the login analytics rollup job runs (e.g. "15m"). "0" disables the job.
+	AnalyticsRollupInterval string `mapstructure:"ANALYTICS_ROLLUP_INTERVAL"`
+	// MeteringFlushInterval is how often the API calls and active users counted for usage metering are written to the
+	// database (e.g. "1m"). The monthly usage rollup runs every ANALYTICS_ROLLUP_INTERVAL. "0" disables metering.
+	MeteringFlushInterval string `mapstructure:"METERING_FLUSH_INTERVAL"`
+	// PolicySchedulerInterval is how often scheduled org policy config changes are checked and applied (e.g. "30s").
+	// "0" disables the scheduler; scheduled changes then stay pending.
+	PolicySchedulerInterval string `mapstructure:"POLICY_SCHEDULER_INTERVAL"`
+	// TrustExpiryNoticeInterval is how often devices are checked for upcoming trust expiry notices (org
+	// device_trust.expiry_notice_days). "0" disables the notices.
+	TrustExpiryNoticeInterval string `mapstructure:"TRUST_EXPIRY_NOTICE_INTERVAL"`
+	// AgentStaleAfter is how long an agent may go without a heartbeat before ListAgents flags it stale (e.g. "15m").
+	// Agents are asked to send a heartbeat every third of it.
+	AgentStaleAfter string `mapstructure:"AGENT_STALE_AFTER"`
+	// AgentTrustDegradeDays clears the trust of devices whose agent has not sent a heartbeat for this many days
+	// (e.g. 7). 0 disables the degradation.
+	AgentTrustDegradeDays int `mapstructure:"AGENT_TRUST_DEGRADE_DAYS"`
+	// ElevationDefaultDuration is how long an approved admin elevation lasts when the request names no duration
+	// (e.g. "1h").
+	ElevationDefaultDuration string `mapstructure:"ELEVATION_DEFAULT_DURATION"`
+	// ElevationMaxDuration is the longest admin elevation a member may request (e.g. "8h").
+	ElevationMaxDuration string `mapstructure:"ELEVATION_MAX_DURATION"`
+	// ElevationExpiryInterval is how often ended admin elevations are marked expired and audited (e.g. "1m"). "0"
+	// disables the job; elevations still stop granting admin rights when they end.
+	ElevationExpiryInterval string `mapstructure:"ELEVATION_EXPIRY_INTERVAL"`
+	// BreakGlassSessionTTL is how long an approved break-glass unlock lasts; break-glass sessions end with it
+	// (e.g. "1h", at most 24h).
+	BreakGlassSessionTTL string `mapstructure:"BREAK_GLASS_SESSION_TTL"`
+	// BreakGlassExpiryInterval is how often ended break-glass windows are closed: sessions revoked, audited and
+	// alerted (e.g. "1m"). "0" disables the job; break-glass access tokens still expire with the window.
+	BreakGlassExpiryInterval string `mapstructure:"BREAK_GLASS_EXPIRY_INTERVAL"`
+	// BreakGlassWebhookURL receives break-glass alerts (unlock requested, unlocked, sign-ins, ended) as JSON POSTs.
+	// Empty disables the webhook.
+	BreakGlassWebhookURL string `mapstructure:"BREAK_GLASS_WEBHOOK_URL"`
+	// BreakGlassWebhookSecret signs break-glass webhook bodies (HMAC-SHA256 in X-ZTCP-Signature); optional.
+	BreakGlassWebhookSecret string `mapstructure:"BREAK_GLASS_WEBHOOK_SECRET" secret:"true"`
+	// BreakGlassAlertEmails is a comma-separated list of addresses emailed every break-glass alert (requires
+	// SMTP_HOST). Empty disables the emails.
+	BreakGlassAlertEmails string `mapstructure:"BREAK_GLASS_ALERT_EMAILS"`
+	// LoginHoldEnabled holds high-risk sign-ins (e.g. from an IP blocked by the anomaly detector) for an org admin's
+	// approval instead of rejecting them. Default false.
+	LoginHoldEnabled bool `mapstructure:"LOGIN_HOLD_ENABLED"`
+	// LoginHoldTTL is how long a held sign-in waits for an admin's decision, and how long an approved one can then be
+	// resumed (e.g. "15m", at most 24h).
+	LoginHoldTTL string `mapstructure:"LOGIN_HOLD_TTL"`
+	// LoginHoldWebhookURL receives a JSON POST for every held sign-in. Empty disables the webhook.
+	LoginHoldWebhookURL string `mapstructure:"LOGIN_HOLD_WEBHOOK_URL"`
+	// LoginHoldWebhookSecret signs login hold webhook bodies (HMAC-SHA256 in X-ZTCP-Signature); optional.
+	LoginHoldWebhookSecret string `mapstructure:"LOGIN_HOLD_WEBHOOK_SECRET" secret:"true"`
+	// PublicSessionTTL is the longest a session signed in on a public or shared device (Login public_device) lasts;
+	// refresh never extends it (e.g. "1h", at most 24h).
+	PublicSessionTTL string `mapstructure:"PUBLIC_SESSION_TTL"`
+	// PublicSessionIdleTimeout is how long a public device session may go without a refresh before it is revoked
+	// (e.g. "15m").
+	PublicSessionIdleTimeout string `mapstructure:"PUBLIC_SESSION_IDLE_TIMEOUT"`
+	// PublicSessionSweepInterval is how often idle public device sessions are revoked and audited (e.g. "1m"). "0"
+	// disables the job; refresh still refuses an idle public device session.
+	PublicSessionSweepInterval string `mapstructure:"PUBLIC_SESSION_SWEEP_INTERVAL"`
+	// HoneytokenWebhookURL receives a JSON POST for every sign-in attempt against a honeytoken. Empty disables the
+	// webhook; attempts are still audited and recorded as security events.
+	HoneytokenWebhookURL string `mapstructure:"HONEYTOKEN_WEBHOOK_URL"`
+	// HoneytokenWebhookSecret signs honeytoken webhook bodies (HMAC-SHA256 in X-ZTCP-Signature); optional.
+	HoneytokenWebhookSecret string `mapstructure:"HONEYTOKEN_WEBHOOK_SECRET" secret:"true"`
+	// DeviceCodeEnabled enables device code sign-in (AuthService StartDeviceAuthorization and friends) for clients
+	// that cannot sign in themselves, such as CLIs and kiosks. Default false.
+	DeviceCodeEnabled bool `mapstructure:"DEVICE_CODE_ENABLED"`
+	// DeviceCodeTTL is how long a device code can be approved and exchanged for tokens (e.g. "10m", at most 1h).
+	DeviceCodeTTL string `mapstructure:"DEVICE_CODE_TTL"`
+	// DeviceCodeVerificationURL is the page where users enter a device's user code, returned to the device to show
+	// (and, with the code, to render as a QR code). Empty leaves it to the client.
+	DeviceCodeVerificationURL string `mapstructure:"DEVICE_CODE_VERIFICATION_URL"`
+	// MagicLinkEnabled enables passwordless sign-in by emailed link (AuthService RequestMagicLink) for orgs whose
+	// auth_mfa policy allows it. Requires MAGIC_LINK_URL and SMTP. Default false.
+	MagicLinkEnabled bool `mapstructure:"MAGIC_LINK_ENABLED"`
+	// MagicLinkTTL is how long a magic link can be redeemed (e.g. "15m", at most 1h).
+	MagicLinkTTL string `mapstructure:"MAGIC_LINK_TTL"`
+	// MagicLinkURL is the sign-in page that redeems links; the token is added as the token query parameter.
+	MagicLinkURL string `mapstructure:"MAGIC_LINK_URL"`
+	// MagicLinkEmailLimit caps RequestMagicLink per email per hour. 0 disables.
+	MagicLinkEmailLimit int `mapstructure:"MAGIC_LINK_EMAIL_LIMIT"`
+	// EmailChangeEnabled enables changing a user's email (AuthService StartEmailChange) after confirmation links to
+	// the current and new address were opened. Requires EMAIL_CHANGE_URL and SMTP. Default false.
+	EmailChangeEnabled bool `mapstructure:"EMAIL_CHANGE_ENABLED"`
+	// EmailChangeTTL is how long the links of an email change can be opened (e.g. "24h", at most 72h).
+	EmailChangeTTL string `mapstructure:"EMAIL_CHANGE_TTL"`
+	// EmailChangeURL is the page that confirms email changes; the token is added as the token query parameter.
+	EmailChangeURL string `mapstructure:"EMAIL_CHANGE_URL"`
+	// UsernameLoginEnabled lets users set a username (AuthService SetUsername) and sign in with it in place of their
+	// email. Default false.
+	UsernameLoginEnabled bool `mapstructure:"USERNAME_LOGIN_ENABLED"`
+	// UsernameScope is where usernames must be unique: "global" (default) or "org" (resolved in the org signed in to).
+	UsernameScope string `mapstructure:"USERNAME_SCOPE"`
+	// AuditBufferSize is how many audit entries may wait to be written in the background. 0 (default) writes each
+	// entry synchronously within the RPC.
+	AuditBufferSize int `mapstructure:"AUDIT_BUFFER_SIZE"`
+	// AuditBatchSize is the most buffered audit entries written in one insert (default 100).
+	AuditBatchSize int `mapstructure:"AUDIT_BATCH_SIZE"`
+	// AuditFlushInterval is how long a partial batch of buffered audit entries waits before it is written (e.g. "1s").
+	AuditFlushInterval string `mapstructure:"AUDIT_FLUSH_INTERVAL"`
+	// AuditOverflowPolicy is what happens when the audit buffer is full: "block" (default; the RPC waits for room)
+	// or "drop" (the entry is discarded and counted).
+	AuditOverflowPolicy string `mapstructure:"AUDIT_OVERFLOW_POLICY"`
+	// AuditRetentionDays is how many days audit logs are kept; older monthly partitions are dropped. 0 (default)
+	// keeps them forever.
+	AuditRetentionDays int `mapstructure:"AUDIT_RETENTION_DAYS"`
+	// AuditPartitionInterval is how often audit_logs partitions are created and pruned (e.g. "1h"). "0" disables the
+	// job.
+	AuditPartitionInterval string `mapstructure:"AUDIT_PARTITION_INTERVAL"`
+	// AuditWebhooksEnabled lets org admins subscribe webhooks to their org's audit events (AuditWebhookService).
+	// Default false.
+	AuditWebhooksEnabled bool `mapstructure:"AUDIT_WEBHOOKS_ENABLED"`
+	// AuditWebhookQueueSize is how many audit events may wait for webhook delivery; further events are dropped
+	// (default 1000).
+	AuditWebhookQueueSize int `mapstructure:"AUDIT_WEBHOOK_QUEUE_SIZE"`
+	// SettingsCacheTTL is how long org MFA and platform settings are cached in memory (e.g. "30s"). "0" disables the
+	// cache.
+	SettingsCacheTTL string `mapstructure:"SETTINGS_CACHE_TTL"`
+	// DetectorInterval is how often cmd/detector scans the audit log (e.g. "1m").
+	DetectorInterval string `mapstructure:"DETECTOR_INTERVAL"`
+	// DetectorWindow is the sliding window of login failures the detector evaluates (e.g. "15m").
+	DetectorWindow string `mapstructure:"DETECTOR_WINDOW"`
+	// DetectorIPFailureThreshold flags an IP with at least this many login failures in the window.
+	DetectorIPFailureThreshold int64 `mapstructure:"DETECTOR_IP_FAILURE_THRESHOLD"`
+	// DetectorIPAccountThreshold flags an IP whose login failures span at least this many accounts (credential stuffing).
+	DetectorIPAccountThreshold int64 `mapstructure:"DETECTOR_IP_ACCOUNT_THRESHOLD"`
+	// DetectorAccountIPThreshold flags an account with login failures from at least this many IPs (distributed brute force).
+	DetectorAccountIPThreshold int64 `mapstructure:"DETECTOR_ACCOUNT_IP_THRESHOLD"`
+	// DetectorAutoBlock when true blocks flagged IPs from signing in for DetectorBlockDuration.
+	DetectorAutoBlock bool `mapstructure:"DETECTOR_AUTO_BLOCK"`
+	// DetectorBlockDuration is how long an auto-blocked IP is denied sign-in (e.g. "1h").
+	DetectorBlockDuration string `mapstructure:"DETECTOR_BLOCK_DURATION"`
+	// VerifyCredentialsIPLimit caps VerifyCredentials attempts per client IP per VerifyCredentialsWindow. 0 disables.
+	VerifyCredentialsIPLimit int `mapstructure:"VERIFY_CREDENTIALS_IP_LIMIT" reload:"true"`
+	// VerifyCredentialsEmailLimit caps VerifyCredentials attempts per email per VerifyCredentialsWindow. 0 disables.
+	VerifyCredentialsEmailLimit int `mapstructure:"VERIFY_CREDENTIALS_EMAIL_LIMIT" reload:"true"`
+	// VerifyCredentialsWindow is the fixed window for the VerifyCredentials limits (e.g. "15m").
+	VerifyCredentialsWindow string `mapstructure:"VERIFY_CREDENTIALS_WINDOW" reload:"true"`
+	// OrgDiscoveryEnabled enables AuthService DiscoverOrganizations, which finds the orgs an email can sign in to
+	// before Login. Default false.
+	OrgDiscoveryEnabled bool `mapstructure:"ORG_DISCOVERY_ENABLED"`
+	// OrgDiscoveryIPLimit caps DiscoverOrganizations calls per client IP per VerifyCredentialsWindow. 0 disables.
+	OrgDiscoveryIPLimit int `mapstructure:"ORG_DISCOVERY_IP_LIMIT" reload:"true"`
+	// OrgDiscoveryEmailLimit caps DiscoverOrganizations calls per email per VerifyCredentialsWindow. 0 disables.
+	OrgDiscoveryEmailLimit int `mapstructure:"ORG_DISCOVERY_EMAIL_LIMIT" reload:"true"`
+	// QuotaEnabled when true enforces per-org and per-session API quotas (requests per minute) on authenticated RPCs.
+	QuotaEnabled bool `mapstructure:"QUOTA_ENABLED"`
+	// QuotaPlans is the plan table "name=org/token,..." of requests per minute per org and per session (0 = unlimited).
+	QuotaPlans string `mapstructure:"QUOTA_PLANS" reload:"true"`
+	// QuotaDefaultPlan is the plan of orgs without an assigned plan; must be one of QuotaPlans.
+	QuotaDefaultPlan string `mapstructure:"QUOTA_DEFAULT_PLAN" reload:"true"`
+	// PlanEnforcementEnabled when true refuses features outside an org's billing plan (free, team, enterprise) with
+	// PermissionDenied and reason UPGRADE_REQUIRED; see internal/billing.
+	PlanEnforcementEnabled bool `mapstructure:"PLAN_ENFORCEMENT_ENABLED" reload:"true"`
+	// LicenseFile is the path of the license file of a self-hosted deployment. Empty runs unlicensed: no seat or
+	// feature limits; see internal/license.
+	LicenseFile string `mapstructure:"LICENSE_FILE"`
+	// LicensePublicKey is the PEM-encoded vendor license public key or path to file, used when the build does not
+	// embed one. Required with LICENSE_FILE in such builds.
+	LicensePublicKey string `mapstructure:"LICENSE_PUBLIC_KEY"`
+	// LicenseCheckInterval is how often the license file is reloaded and checked for expiry and seat warnings
+	// (e.g. "1h"). "0" disables the job; the license loaded at startup is still enforced.
+	LicenseCheckInterval string `mapstructure:"LICENSE_CHECK_INTERVAL"`
+	// TokenExchangeAudiences is a comma-separated list of target services TokenExchange may issue resource tokens for.
+	// Empty disables token exchange.
+	TokenExchangeAudiences string `mapstructure:"TOKEN_EXCHANGE_AUDIENCES"`
+	// TokenExchangeTTL is the maximum lifetime of a resource token (e.g. "5m").
+	TokenExchangeTTL string `mapstructure:"TOKEN_EXCHANGE_TTL" reload:"true"`
+	// ServiceAccountUserIDs is a comma-separated list of user IDs allowed to call AuthService.Introspect. Empty
+	// denies everyone.
+	ServiceAccountUserIDs string `mapstructure:"SERVICE_ACCOUNT_USER_IDS" reload:"true"`
+	// IntrospectionCacheTTL is the longest Introspect tells callers to cache a result (e.g. "30s").
+	IntrospectionCacheTTL string `mapstructure:"INTROSPECTION_CACHE_TTL"`
+	// BreachedPasswordCheck selects the breached-password source for Register/ChangePassword: "" (disabled),
+	// "hibp" (k-anonymity range API) or "bloom" (local Bloom filter file).
+	BreachedPasswordCheck string `mapstructure:"BREACHED_PASSWORD_CHECK"`
+	// BreachedPasswordHIBPURL is the range API base URL for "hibp" (default https://api.pwnedpasswords.com).
+	BreachedPasswordHIBPURL string `mapstructure:"BREACHED_PASSWORD_HIBP_URL"`
+	// BreachedPasswordBloomFile is the Bloom filter file for "bloom" (see cmd/breachfilter).
+	BreachedPasswordBloomFile string `mapstructure:"BREACHED_PASSWORD_BLOOM_FILE"`
+	// BreachedPasswordMode is off, warn or block; applies to Register and to orgs without a password_policy mode.
+	BreachedPasswordMode string `mapstructure:"BREACHED_PASSWORD_MODE"`
+	// TurnstileSecretKey is the Cloudflare Turnstile secret key. When set, open registration without an invitation
+	// must carry a Turnstile token (RegisterRequest.captcha_token).
+	TurnstileSecretKey string `mapstructure:"TURNSTILE_SECRET_KEY" secret:"true"`
+	// TurnstileVerifyURL is the Turnstile siteverify endpoint (default Cloudflare's).
+	TurnstileVerifyURL string `mapstructure:"TURNSTILE_VERIFY_URL"`
+	// SignupBlockDisposableEmail when true rejects Register for addresses at disposable email providers.
+	SignupBlockDisposableEmail bool `mapstructure:"SIGNUP_BLOCK_DISPOSABLE_EMAIL"`
+	// SignupDisposableDomainsFile is an optional file of disposable domains (one per line) added to the built-in list.
+	SignupDisposableDomainsFile string `mapstructure:"SIGNUP_DISPOSABLE_DOMAINS_FILE"`
+	// SignupAllowedEmailDomains is a comma-separated allowlist of email domains (and their subdomains) Register
+	// accepts. Empty accepts every domain.
+	SignupAllowedEmailDomains string `mapstructure:"SIGNUP_ALLOWED_EMAIL_DOMAINS" reload:"true"`
+	// SignupIPLimit caps Register attempts per client IP per SignupWindow. 0 disables.
+	SignupIPLimit int `mapstructure:"SIGNUP_IP_LIMIT" reload:"true"`
+	// SignupWindow is the fixed window for SignupIPLimit (e.g. "1h").
+	SignupWindow string `mapstructure:"SIGNUP_WINDOW" reload:"true"`
+	// SecretsProvider selects where *_SECRET references are resolved: "" (none; secrets come from the plain env vars),
+	// "vault" (HashiCorp Vault KV v2), "aws-secretsmanager" or "aws-kms" (decrypt KMS ciphertext).
+	SecretsProvider string `mapstructure:"SECRETS_PROVIDER"`
+	// SecretsRefreshInterval is how often referenced secrets are re-fetched to pick up rotations (e.g. "5m"). "0" disables.
+	SecretsRefreshInterval string `mapstructure:"SECRETS_REFRESH_INTERVAL"`
+	// JWTPrivateKeySecret is a secrets-provider reference for the JWT private key; overrides JWT_PRIVATE_KEY.
+	JWTPrivateKeySecret string `mapstructure:"JWT_PRIVATE_KEY_SECRET"`
+	// JWTPublicKeySecret is a secrets-provider reference for the JWT public key; overrides JWT_PUBLIC_KEY.
+	JWTPublicKeySecret string `mapstructure:"JWT_PUBLIC_KEY_SECRET"`
+	// SMSLocalAPIKeySecret is a secrets-provider reference for the SMS Local API key; overrides SMS_LOCAL_API_KEY.
+	SMSLocalAPIKeySecret string `mapstructure:"SMS_LOCAL_API_KEY_SECRET"`
+	// VaultAddr is the Vault server address (e.g. https://vault.internal:8200); required for SECRETS_PROVIDER=vault.
+	VaultAddr string `mapstructure:"VAULT_ADDR"`
+	// VaultToken is the Vault token. Prefer VaultTokenFile so the token is not in the environment either.
+	VaultToken string `mapstructure:"VAULT_TOKEN" secret:"true"`
+	// VaultTokenFile is a file holding the Vault token (e.g. a Vault Agent sink); re-read on every request.
+	VaultTokenFile string `mapstructure:"VAULT_TOKEN_FILE"`
+	// VaultKVMount is the KV v2 mount path (default "secret").
+	VaultKVMount string `mapstructure:"VAULT_KV_MOUNT"`
+	// VaultNamespace is the Vault Enterprise namespace; optional.
+	VaultNamespace string `mapstructure:"VAULT_NAMESPACE"`
+	// AWSRegion is the region for SECRETS_PROVIDER=aws-secretsmanager or aws-kms.
+	AWSRegion string `mapstructure:"AWS_REGION"`
+	// AWSAccessKeyID, AWSSecretAccessKey and AWSSessionToken are the credentials used to sign AWS requests.
+	AWSAccessKeyID     string `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey string `mapstructure:"AWS_SECRET_ACCESS_KEY" secret:"true"`
+	AWSSessionToken    string `mapstructure:"AWS_SESSION_TOKEN" secret:"true"`
+	// SecretsAWSEndpoint overrides the regional Secrets Manager/KMS endpoint (e.g. a VPC endpoint); optional.
+	SecretsAWSEndpoint string `mapstructure:"SECRETS_AWS_ENDPOINT"`
 	// DefaultTrustTTLDays is the default device trust TTL in days when platform_settings has no value (e.g. 30).
-	DefaultTrustTTLDays int `mapstructure:"DEFAULT_TRUST_TTL_DAYS"`
+	DefaultTrustTTLDays int `mapstructure:"DEFAULT_TRUST_TTL_DAYS" reload:"true"`
+	// SharedDeviceDetection when true counts the users and orgs of a device's fingerprint at sign-in, for MFA policies
+	// (input.device.shared_device) and AdminService ListSharedDevices. Default false.
+	SharedDeviceDetection bool `mapstructure:"SHARED_DEVICE_DETECTION"`
+	// SharedDeviceUserThreshold is the number of users from which a fingerprint is shared (at least 2, default 2).
+	SharedDeviceUserThreshold int `mapstructure:"SHARED_DEVICE_USER_THRESHOLD" reload:"true"`
 	// OTPReturnToClient when true enables PoC OTP mode: no SMS, OTP stored for GET /dev/mfa/otp.
 	// Allowed in all environments including production for PoC purposes.
 	OTPReturnToClient bool `mapstructure:"OTP_RETURN_TO_CLIENT"`
+	// MFAMaxOTPAttempts is how many wrong codes invalidate an MFA challenge. 0 disables the limit.
+	MFAMaxOTPAttempts int `mapstructure:"MFA_MAX_OTP_ATTEMPTS"`
+	// MFAMaxResends caps ResendMFACode per MFA challenge. 0 disables resends.
+	MFAMaxResends int `mapstructure:"MFA_MAX_RESENDS"`
+	// MFAResendCooldown is the minimum time between sending codes for one challenge (e.g. "30s").
+	MFAResendCooldown string `mapstructure:"MFA_RESEND_COOLDOWN"`
+	// BreakerFailureThreshold is how many consecutive failures open the circuit breaker of an external dependency
+	// (SMS provider, policy store, Loki). 0 disables the breakers.
+	BreakerFailureThreshold int `mapstructure:"BREAKER_FAILURE_THRESHOLD"`
+	// BreakerOpenTimeout is how long an open breaker rejects calls before letting a probe through (e.g. "30s").
+	BreakerOpenTimeout string `mapstructure:"BREAKER_OPEN_TIMEOUT"`
+	// PolicyFailureMode is what MFA evaluation does while an org's policies cannot be loaded: "open" (default;
+	// evaluate the built-in default policy) or "closed" (require MFA). Orgs may override it
+	// (auth_mfa.policy_failure_mode).
+	PolicyFailureMode string `mapstructure:"POLICY_FAILURE_MODE"`
+	// SMSRetryQueueSize is how many SMS messages wait in memory for retry while the SMS provider is failing. 0 fails
+	// the send instead.
+	SMSRetryQueueSize int `mapstructure:"SMS_RETRY_QUEUE_SIZE"`
+	// SMSRetryMaxAttempts is how many times a queued SMS message is sent at most before it is dead-lettered. 0
+	// retries until the message is older than the MFA challenge lifetime.
+	SMSRetryMaxAttempts int `mapstructure:"SMS_RETRY_MAX_ATTEMPTS"`
 	// Env is the application environment (e.g. "development", "production").
 	Env string `mapstructure:"APP_ENV"`
+	// LogLevel is the minimum level for structured (slog) logs: debug, info, warn or error (default info).
+	LogLevel string `mapstructure:"LOG_LEVEL" reload:"true"`
+	// ConfigReloadInterval is how often the config file is re-read for reloadable settings (e.g. "1m"). "0" (default)
+	// reloads only on SIGHUP.
+	ConfigReloadInterval string `mapstructure:"CONFIG_RELOAD_INTERVAL"`
+	// PlatformAdminUserIDs is a comma-separated list of user IDs allowed to call AdminService. Empty denies everyone.
+	PlatformAdminUserIDs string `mapstructure:"PLATFORM_ADMIN_USER_IDS" reload:"true"`
+	// SessionRevocationConsistency selects how session revocations reach other regions: "local" (default; this
+	// region's database only), "eventual" (replicated over Kafka) or "strict" (replicated, and authenticated requests
+	// fail with Unavailable while nothing was received from the stream within SESSION_REVOCATION_MAX_LAG).
+	SessionRevocationConsistency string `mapstructure:"SESSION_REVOCATION_CONSISTENCY"`
+	// Region names this deployment in replicated revocations (e.g. eu-west-1); required unless consistency is local.
+	Region string `mapstructure:"REGION"`
+	// SessionRevocationKafkaBrokers is a comma-separated list of Kafka bootstrap brokers; required unless consistency is local.
+	SessionRevocationKafkaBrokers string `mapstructure:"SESSION_REVOCATION_KAFKA_BROKERS"`
+	// SessionRevocationKafkaTopic is the revocation topic shared by all regions (default ztcp.session-revocations).
+	SessionRevocationKafkaTopic string `mapstructure:"SESSION_REVOCATION_KAFKA_TOPIC"`
+	// SessionRevocationKafkaGroup is this region's consumer group (default ztcp-session-revocations-<REGION>).
+	SessionRevocationKafkaGroup string `mapstructure:"SESSION_REVOCATION_KAFKA_GROUP"`
+	// TelemetryTransport carries agent telemetry: kafka (default), nats (JetStream), rabbitmq, or embedded (stored in
+	// the telemetry_events table and queried with QueryTelemetry; no external services). TelemetryService is disabled
+	// until the transport's connection setting is set; embedded needs none.
+	TelemetryTransport string `mapstructure:"TELEMETRY_TRANSPORT"`
+	// TelemetryEmbeddedBufferSize is how many events the embedded transport buffers in memory before the oldest
+	// unwritten ones are dropped (default 10000).
+	TelemetryEmbeddedBufferSize int `mapstructure:"TELEMETRY_EMBEDDED_BUFFER_SIZE"`
+	// TelemetryEmbeddedRetentionDays is how many days the embedded transport keeps events (default 7). 0 keeps them
+	// forever.
+	TelemetryEmbeddedRetentionDays int `mapstructure:"TELEMETRY_EMBEDDED_RETENTION_DAYS"`
+	// TelemetryKafkaBrokers is a comma-separated list of Kafka bootstrap brokers for agent telemetry.
+	TelemetryKafkaBrokers string `mapstructure:"TELEMETRY_KAFKA_BROKERS"`
+	// TelemetryKafkaTopic is the topic agent telemetry is published to (default ztcp.telemetry).
+	TelemetryKafkaTopic string `mapstructure:"TELEMETRY_KAFKA_TOPIC"`
+	// TelemetryNATSURL is the NATS server for the nats transport (nats://[user:pass@]host:port, tls:// for TLS).
+	TelemetryNATSURL string `mapstructure:"TELEMETRY_NATS_URL" secret:"true"`
+	// TelemetryNATSStream is the JetStream stream capturing the telemetry and dead-letter subjects (default
+	// ZTCP_TELEMETRY).
+	TelemetryNATSStream string `mapstructure:"TELEMETRY_NATS_STREAM"`
+	// TelemetryNATSSubject is the subject agent telemetry is published to (default ztcp.telemetry).
+	TelemetryNATSSubject string `mapstructure:"TELEMETRY_NATS_SUBJECT"`
+	// TelemetryRabbitMQURL is the broker for the rabbitmq transport (amqp://[user:pass@]host:port/vhost, amqps:// for
+	// TLS).
+	TelemetryRabbitMQURL string `mapstructure:"TELEMETRY_RABBITMQ_URL" secret:"true"`
+	// TelemetryRabbitMQExchange is the topic exchange agent telemetry is published to (default ztcp.telemetry).
+	TelemetryRabbitMQExchange string `mapstructure:"TELEMETRY_RABBITMQ_EXCHANGE"`
+	// TelemetryWorkerGroup is the consumer group of cmd/telemetry-worker (default ztcp-telemetry-loki): the Kafka group,
+	// JetStream durable consumer or RabbitMQ queue.
+	TelemetryWorkerGroup string `mapstructure:"TELEMETRY_WORKER_GROUP"`
+	// TelemetryDeadLetterTopic receives the telemetry messages the worker cannot use (default
+	// ztcp.telemetry.dead-letter). Empty drops them.
+	TelemetryDeadLetterTopic string `mapstructure:"TELEMETRY_DEAD_LETTER_TOPIC"`
+	// TelemetryDedupWindow is how long the worker remembers handled event IDs to skip redeliveries (e.g. "1h").
+	// "0" disables deduplication.
+	TelemetryDedupWindow string `mapstructure:"TELEMETRY_DEDUP_WINDOW"`
+	// TelemetryDedupSize is how many event IDs the worker keeps in memory (default 100000).
+	TelemetryDedupSize int `mapstructure:"TELEMETRY_DEDUP_SIZE"`
+	// TelemetryDedupRedisURL shares the dedup window between worker instances (redis://[:password@]host:port[/db]).
+	// Empty keeps it in memory only.
+	TelemetryDedupRedisURL string `mapstructure:"TELEMETRY_DEDUP_REDIS_URL" secret:"true"`
+	// LokiURL is the base URL of the Loki that cmd/telemetry-worker pushes to (e.g. http://loki:3100).
+	LokiURL string `mapstructure:"LOKI_URL"`
+	// LokiLabels is the comma-separated spec of the stream labels taken from each event (default
+	// org_id,event_type,severity); see internal/telemetry/loki.ParseLabels.
+	LokiLabels string `mapstructure:"LOKI_LABELS"`
+	// LokiLabelMaxValues is how many distinct values each label keeps before new ones become _overflow (default 100).
+	LokiLabelMaxValues int `mapstructure:"LOKI_LABEL_MAX_VALUES"`
+	// LokiTenantPerOrg pushes each event as the Loki tenant of its org (X-Scope-OrgID = org_id).
+	LokiTenantPerOrg bool `mapstructure:"LOKI_TENANT_PER_ORG"`
+	// LokiTenantID is the X-Scope-OrgID sent for every event when LokiTenantPerOrg is false. Empty sends none.
+	LokiTenantID string `mapstructure:"LOKI_TENANT_ID"`
+	// SessionRevocationHeartbeatInterval is how often each instance publishes a heartbeat (default 5s).
+	SessionRevocationHeartbeatInterval string `mapstructure:"SESSION_REVOCATION_HEARTBEAT_INTERVAL"`
+	// SessionRevocationMaxLag is how long strict consistency tolerates receiving nothing from the stream (default 30s).
+	SessionRevocationMaxLag string `mapstructure:"SESSION_REVOCATION_MAX_LAG"`
+	// ChangeRequestWebhookURL receives change request events (proposed, approved, rejected) as JSON POSTs.
+	// Empty disables the webhook.
+	ChangeRequestWebhookURL string `mapstructure:"CHANGE_REQUEST_WEBHOOK_URL"`
+	// ChangeRequestWebhookSecret signs webhook bodies (HMAC-SHA256 in X-ZTCP-Signature); optional.
+	ChangeRequestWebhookSecret string `mapstructure:"CHANGE_REQUEST_WEBHOOK_SECRET" secret:"true"`
+	// PIIMasterKey is the base64 AES-256 key that wraps the data keys encrypting user emails and phones. Empty stores
+	// them in plaintext.
+	PIIMasterKey string `mapstructure:"PII_MASTER_KEY" secret:"true"`
+	// PIIMasterKeySecret is a secrets-provider reference for the PII master key; overrides PII_MASTER_KEY.
+	PIIMasterKeySecret string `mapstructure:"PII_MASTER_KEY_SECRET"`
+	// PIIPreviousMasterKey is the master key before the last rotation; only used to unwrap data keys until they are
+	// rewrapped with PII_MASTER_KEY.
+	PIIPreviousMasterKey string `mapstructure:"PII_PREVIOUS_MASTER_KEY" secret:"true"`
+	// PIIDataKeyMaxAge is the age after which data keys are retired and their rows re-encrypted (e.g. "2160h").
+	// "0" (default) keeps data keys until they are retired by hand.
+	PIIDataKeyMaxAge string `mapstructure:"PII_DATA_KEY_MAX_AGE"`
+	// PIIReencryptInterval is how often the PII key rotation and re-encryption job runs (e.g. "1h"). "0" disables it.
+	PIIReencryptInterval string `mapstructure:"PII_REENCRYPT_INTERVAL"`
+	// BackupEncryptionKey is the base64 AES-256 key that encrypts org backups (cmd/backup, AdminService.CreateBackup).
+	// Empty disables backups.
+	BackupEncryptionKey string `mapstructure:"BACKUP_ENCRYPTION_KEY" secret:"true"`
+	// BackupEncryptionKeySecret is a secrets-provider reference for the backup key; overrides BACKUP_ENCRYPTION_KEY.
+	BackupEncryptionKeySecret string `mapstructure:"BACKUP_ENCRYPTION_KEY_SECRET"`
+	// BackupDir is the directory AdminService.CreateBackup writes backups to. Empty disables the RPC; cmd/backup
+	// writes wherever it is told.
+	BackupDir string `mapstructure:"BACKUP_DIR"`
+	// DataExportInterval is how often the data export job generates requested exports of users' personal data and
+	// deletes expired ones (e.g. "30s"). "0" disables data exports; their RPCs then return Unimplemented.
+	DataExportInterval string `mapstructure:"DATA_EXPORT_INTERVAL"`
+	// DataExportTTL is how long a data export can be downloaded after it was requested (e.g. "72h").
+	DataExportTTL string `mapstructure:"DATA_EXPORT_TTL"`
+	// DataRegionDSNs maps data regions to the Postgres DSNs storing the activity data (audit logs, policy violations,
+	// security events, telemetry, analytics and usage) of orgs in that region, as comma-separated region=dsn pairs (e.g. "eu=postgres://...,us=postgres://..."). Empty stores
+	// all org data in DATABASE_URL; orgs in a region missing here are rejected.
+	DataRegionDSNs string `mapstructure:"DATA_REGION_DSNS" secret:"true"`
+	// ConfirmationTokenSecret keys the confirmation tokens of two-step destructive RPCs (RevokeAllSessionsForOrg,
+	// MergeUsers).
+	// Empty uses a random key per process, so a token is only accepted by the instance that issued it.
+	ConfirmationTokenSecret string `mapstructure:"CONFIRMATION_TOKEN_SECRET" secret:"true"`
 }
 
-// Load reads .env (if present), then builds and validates Config from the environment via Viper.
+// Load reads the config file (CONFIG_FILE, default .env; if present), then builds and validates Config from the
+// environment via Viper. Env vars override the file.
 // Missing .env is ignored (e.g. in CI). Env vars override .env. Returns an error if required fields are invalid.
 func Load() (*Config, error) {
 	v := viper.New()
 
-	v.SetConfigFile(".env")
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		path = ".env"
+	}
+	v.SetConfigFile(path)
 	v.SetConfigType("env")
 	_ = v.ReadInConfig() // ignore ErrConfigFileNotFound
 
@@ -63,15 +479,178 @@ func Load() (*Config, error) {
 
 	v.SetDefault("GRPC_ADDR", ":8080")
 	v.SetDefault("DATABASE_URL", "")
+	v.SetDefault("DB_QUERY_TIMEOUT", "10s")
+	v.SetDefault("DB_STATEMENT_TIMEOUT", "30s")
 	v.SetDefault("JWT_ISSUER", "ztcp-auth")
 	v.SetDefault("JWT_AUDIENCE", "ztcp-api")
 	v.SetDefault("JWT_ACCESS_TTL", "15m")
 	v.SetDefault("JWT_REFRESH_TTL", "168h") // 7d
+	v.SetDefault("JWT_CLOCK_LEEWAY", "30s")
+	v.SetDefault("REFRESH_REPLAY_WINDOW", "1m")
+	v.SetDefault("REFRESH_REPLAY_REDIS_URL", "")
 	v.SetDefault("BCRYPT_COST", 12)
 	v.SetDefault("SMS_LOCAL_BASE_URL", "https://app.smslocal.in/api/smsapi")
+	v.SetDefault("SMS_LOCAL_FAILOVER_API_KEY", "")
+	v.SetDefault("SMS_LOCAL_FAILOVER_SENDER", "")
+	v.SetDefault("SMS_LOCAL_FAILOVER_BASE_URL", "")
+	v.SetDefault("SMTP_HOST", "")
+	v.SetDefault("SMTP_PORT", 587)
+	v.SetDefault("SMTP_USERNAME", "")
+	v.SetDefault("SMTP_PASSWORD", "")
+	v.SetDefault("SMTP_FROM", "")
+	v.SetDefault("ORG_SMTP_SECRET_PREFIX", "")
+	v.SetDefault("ANALYTICS_ROLLUP_INTERVAL", "15m")
+	v.SetDefault("METERING_FLUSH_INTERVAL", "1m")
+	v.SetDefault("POLICY_SCHEDULER_INTERVAL", "30s")
+	v.SetDefault("TRUST_EXPIRY_NOTICE_INTERVAL", "1h")
+	v.SetDefault("AGENT_STALE_AFTER", "15m")
+	v.SetDefault("AGENT_TRUST_DEGRADE_DAYS", 7)
+	v.SetDefault("ELEVATION_DEFAULT_DURATION", "1h")
+	v.SetDefault("ELEVATION_MAX_DURATION", "8h")
+	v.SetDefault("ELEVATION_EXPIRY_INTERVAL", "1m")
+	v.SetDefault("BREAK_GLASS_SESSION_TTL", "1h")
+	v.SetDefault("BREAK_GLASS_EXPIRY_INTERVAL", "1m")
+	v.SetDefault("BREAK_GLASS_WEBHOOK_URL", "")
+	v.SetDefault("BREAK_GLASS_WEBHOOK_SECRET", "")
+	v.SetDefault("BREAK_GLASS_ALERT_EMAILS", "")
+	v.SetDefault("LOGIN_HOLD_ENABLED", false)
+	v.SetDefault("LOGIN_HOLD_TTL", "15m")
+	v.SetDefault("LOGIN_HOLD_WEBHOOK_URL", "")
+	v.SetDefault("LOGIN_HOLD_WEBHOOK_SECRET", "")
+	v.SetDefault("PUBLIC_SESSION_TTL", "1h")
+	v.SetDefault("PUBLIC_SESSION_IDLE_TIMEOUT", "15m")
+	v.SetDefault("PUBLIC_SESSION_SWEEP_INTERVAL", "1m")
+	v.SetDefault("HONEYTOKEN_WEBHOOK_URL", "")
+	v.SetDefault("HONEYTOKEN_WEBHOOK_SECRET", "")
+	v.SetDefault("DEVICE_CODE_ENABLED", false)
+	v.SetDefault("DEVICE_CODE_TTL", "10m")
+	v.SetDefault("DEVICE_CODE_VERIFICATION_URL", "")
+	v.SetDefault("MAGIC_LINK_ENABLED", false)
+	v.SetDefault("MAGIC_LINK_TTL", "15m")
+	v.SetDefault("MAGIC_LINK_URL", "")
+	v.SetDefault("MAGIC_LINK_EMAIL_LIMIT", 5)
+	v.SetDefault("EMAIL_CHANGE_ENABLED", false)
+	v.SetDefault("EMAIL_CHANGE_TTL", "24h")
+	v.SetDefault("EMAIL_CHANGE_URL", "")
+	v.SetDefault("USERNAME_LOGIN_ENABLED", false)
+	v.SetDefault("USERNAME_SCOPE", "global")
+	v.SetDefault("AUDIT_BUFFER_SIZE", 0)
+	v.SetDefault("AUDIT_BATCH_SIZE", 100)
+	v.SetDefault("AUDIT_FLUSH_INTERVAL", "1s")
+	v.SetDefault("AUDIT_OVERFLOW_POLICY", "block")
+	v.SetDefault("AUDIT_RETENTION_DAYS", 0)
+	v.SetDefault("AUDIT_PARTITION_INTERVAL", "1h")
+	v.SetDefault("AUDIT_WEBHOOKS_ENABLED", false)
+	v.SetDefault("AUDIT_WEBHOOK_QUEUE_SIZE", 1000)
+	v.SetDefault("SETTINGS_CACHE_TTL", "30s")
+	v.SetDefault("DETECTOR_INTERVAL", "1m")
+	v.SetDefault("DETECTOR_WINDOW", "15m")
+	v.SetDefault("DETECTOR_IP_FAILURE_THRESHOLD", 30)
+	v.SetDefault("DETECTOR_IP_ACCOUNT_THRESHOLD", 5)
+	v.SetDefault("DETECTOR_ACCOUNT_IP_THRESHOLD", 5)
+	v.SetDefault("DETECTOR_AUTO_BLOCK", false)
+	v.SetDefault("DETECTOR_BLOCK_DURATION", "1h")
+	v.SetDefault("VERIFY_CREDENTIALS_IP_LIMIT", 20)
+	v.SetDefault("VERIFY_CREDENTIALS_EMAIL_LIMIT", 5)
+	v.SetDefault("VERIFY_CREDENTIALS_WINDOW", "15m")
+	v.SetDefault("ORG_DISCOVERY_ENABLED", false)
+	v.SetDefault("ORG_DISCOVERY_IP_LIMIT", 30)
+	v.SetDefault("ORG_DISCOVERY_EMAIL_LIMIT", 10)
+	v.SetDefault("QUOTA_ENABLED", false)
+	v.SetDefault("QUOTA_PLANS", plans.DefaultPlans)
+	v.SetDefault("QUOTA_DEFAULT_PLAN", plans.Default)
+	v.SetDefault("PLAN_ENFORCEMENT_ENABLED", false)
+	v.SetDefault("LICENSE_FILE", "")
+	v.SetDefault("LICENSE_PUBLIC_KEY", "")
+	v.SetDefault("LICENSE_CHECK_INTERVAL", "1h")
+	v.SetDefault("TOKEN_EXCHANGE_AUDIENCES", "")
+	v.SetDefault("TOKEN_EXCHANGE_TTL", "5m")
+	v.SetDefault("SERVICE_ACCOUNT_USER_IDS", "")
+	v.SetDefault("INTROSPECTION_CACHE_TTL", "30s")
+	v.SetDefault("BREACHED_PASSWORD_CHECK", "")
+	v.SetDefault("BREACHED_PASSWORD_HIBP_URL", "https://api.pwnedpasswords.com")
+	v.SetDefault("BREACHED_PASSWORD_BLOOM_FILE", "")
+	v.SetDefault("BREACHED_PASSWORD_MODE", "warn")
+	v.SetDefault("TURNSTILE_SECRET_KEY", "")
+	v.SetDefault("TURNSTILE_VERIFY_URL", "https://challenges.cloudflare.com/turnstile/v0/siteverify")
+	v.SetDefault("SIGNUP_BLOCK_DISPOSABLE_EMAIL", true)
+	v.SetDefault("SIGNUP_DISPOSABLE_DOMAINS_FILE", "")
+	v.SetDefault("SIGNUP_ALLOWED_EMAIL_DOMAINS", "")
+	v.SetDefault("SIGNUP_IP_LIMIT", 10)
+	v.SetDefault("SIGNUP_WINDOW", "1h")
+	v.SetDefault("SMS_LOCAL_API_KEY", "")
+	v.SetDefault("PII_MASTER_KEY", "")
+	v.SetDefault("PII_MASTER_KEY_SECRET", "")
+	v.SetDefault("PII_PREVIOUS_MASTER_KEY", "")
+	v.SetDefault("PII_DATA_KEY_MAX_AGE", "0")
+	v.SetDefault("PII_REENCRYPT_INTERVAL", "1h")
+	v.SetDefault("BACKUP_ENCRYPTION_KEY", "")
+	v.SetDefault("BACKUP_ENCRYPTION_KEY_SECRET", "")
+	v.SetDefault("BACKUP_DIR", "")
+	v.SetDefault("DATA_EXPORT_INTERVAL", "30s")
+	v.SetDefault("DATA_EXPORT_TTL", "72h")
+	v.SetDefault("DATA_REGION_DSNS", "")
+	v.SetDefault("CONFIRMATION_TOKEN_SECRET", "")
+	v.SetDefault("SECRETS_PROVIDER", "")
+	v.SetDefault("SECRETS_REFRESH_INTERVAL", "5m")
+	v.SetDefault("JWT_PRIVATE_KEY_SECRET", "")
+	v.SetDefault("JWT_PUBLIC_KEY_SECRET", "")
+	v.SetDefault("SMS_LOCAL_API_KEY_SECRET", "")
+	v.SetDefault("VAULT_ADDR", "")
+	v.SetDefault("VAULT_TOKEN", "")
+	v.SetDefault("VAULT_TOKEN_FILE", "")
+	v.SetDefault("VAULT_KV_MOUNT", "secret")
+	v.SetDefault("VAULT_NAMESPACE", "")
+	v.SetDefault("AWS_REGION", "")
+	v.SetDefault("AWS_ACCESS_KEY_ID", "")
+	v.SetDefault("AWS_SECRET_ACCESS_KEY", "")
+	v.SetDefault("AWS_SESSION_TOKEN", "")
+	v.SetDefault("SECRETS_AWS_ENDPOINT", "")
 	v.SetDefault("DEFAULT_TRUST_TTL_DAYS", 30)
+	v.SetDefault("SHARED_DEVICE_DETECTION", false)
+	v.SetDefault("SHARED_DEVICE_USER_THRESHOLD", 2)
 	v.SetDefault("OTP_RETURN_TO_CLIENT", false)
+	v.SetDefault("MFA_MAX_OTP_ATTEMPTS", 5)
+	v.SetDefault("MFA_MAX_RESENDS", 3)
+	v.SetDefault("MFA_RESEND_COOLDOWN", "30s")
+	v.SetDefault("BREAKER_FAILURE_THRESHOLD", 5)
+	v.SetDefault("BREAKER_OPEN_TIMEOUT", "30s")
+	v.SetDefault("POLICY_FAILURE_MODE", "open")
+	v.SetDefault("SMS_RETRY_QUEUE_SIZE", 1000)
+	v.SetDefault("SMS_RETRY_MAX_ATTEMPTS", 5)
 	v.SetDefault("APP_ENV", "")
+	v.SetDefault("LOG_LEVEL", "info")
+	v.SetDefault("CONFIG_RELOAD_INTERVAL", "0")
+	v.SetDefault("PLATFORM_ADMIN_USER_IDS", "")
+	v.SetDefault("SESSION_REVOCATION_CONSISTENCY", "local")
+	v.SetDefault("REGION", "")
+	v.SetDefault("SESSION_REVOCATION_KAFKA_BROKERS", "")
+	v.SetDefault("SESSION_REVOCATION_KAFKA_TOPIC", "ztcp.session-revocations")
+	v.SetDefault("SESSION_REVOCATION_KAFKA_GROUP", "")
+	v.SetDefault("TELEMETRY_TRANSPORT", "kafka")
+	v.SetDefault("TELEMETRY_EMBEDDED_BUFFER_SIZE", 10000)
+	v.SetDefault("TELEMETRY_EMBEDDED_RETENTION_DAYS", 7)
+	v.SetDefault("TELEMETRY_KAFKA_BROKERS", "")
+	v.SetDefault("TELEMETRY_KAFKA_TOPIC", "ztcp.telemetry")
+	v.SetDefault("TELEMETRY_NATS_URL", "")
+	v.SetDefault("TELEMETRY_NATS_STREAM", "ZTCP_TELEMETRY")
+	v.SetDefault("TELEMETRY_NATS_SUBJECT", "ztcp.telemetry")
+	v.SetDefault("TELEMETRY_RABBITMQ_URL", "")
+	v.SetDefault("TELEMETRY_RABBITMQ_EXCHANGE", "ztcp.telemetry")
+	v.SetDefault("TELEMETRY_WORKER_GROUP", "ztcp-telemetry-loki")
+	v.SetDefault("TELEMETRY_DEAD_LETTER_TOPIC", "ztcp.telemetry.dead-letter")
+	v.SetDefault("TELEMETRY_DEDUP_WINDOW", "1h")
+	v.SetDefault("TELEMETRY_DEDUP_SIZE", 100000)
+	v.SetDefault("TELEMETRY_DEDUP_REDIS_URL", "")
+	v.SetDefault("LOKI_URL", "")
+	v.SetDefault("LOKI_LABELS", "org_id,event_type,severity")
+	v.SetDefault("LOKI_LABEL_MAX_VALUES", 100)
+	v.SetDefault("LOKI_TENANT_PER_ORG", false)
+	v.SetDefault("LOKI_TENANT_ID", "")
+	v.SetDefault("SESSION_REVOCATION_HEARTBEAT_INTERVAL", "5s")
+	v.SetDefault("SESSION_REVOCATION_MAX_LAG", "30s")
+	v.SetDefault("CHANGE_REQUEST_WEBHOOK_URL", "")
+	v.SetDefault("CHANGE_REQUEST_WEBHOOK_SECRET", "")
 
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
@@ -103,6 +682,236 @@ func Load() (*Config, error) {
 		return nil, errors.New("config: BCRYPT_COST must be between 4 and 31")
 	}
 
+	switch cfg.BreachedPasswordCheck {
+	case "", "hibp":
+	case "bloom":
+		if cfg.BreachedPasswordBloomFile == "" {
+			return nil, errors.New("config: BREACHED_PASSWORD_BLOOM_FILE must be set when BREACHED_PASSWORD_CHECK=bloom")
+		}
+	default:
+		return nil, errors.New("config: BREACHED_PASSWORD_CHECK must be empty, hibp or bloom")
+	}
+	switch cfg.BreachedPasswordMode {
+	case "off", "warn", "block":
+	default:
+		return nil, errors.New("config: BREACHED_PASSWORD_MODE must be off, warn or block")
+	}
+	if cfg.AuditBufferSize < 0 || cfg.AuditBatchSize < 0 || cfg.AuditRetentionDays < 0 {
+		return nil, errors.New("config: AUDIT_BUFFER_SIZE, AUDIT_BATCH_SIZE and AUDIT_RETENTION_DAYS must not be negative")
+	}
+	if cfg.AuditWebhookQueueSize <= 0 {
+		return nil, errors.New("config: AUDIT_WEBHOOK_QUEUE_SIZE must be positive")
+	}
+	switch cfg.AuditOverflowPolicy {
+	case "block", "drop":
+	default:
+		return nil, errors.New("config: AUDIT_OVERFLOW_POLICY must be block or drop")
+	}
+	if cfg.AgentTrustDegradeDays < 0 {
+		return nil, errors.New("config: AGENT_TRUST_DEGRADE_DAYS must not be negative")
+	}
+	if cfg.SharedDeviceUserThreshold < 2 {
+		return nil, errors.New("config: SHARED_DEVICE_USER_THRESHOLD must be at least 2")
+	}
+	if cfg.BreakerFailureThreshold < 0 || cfg.SMSRetryQueueSize < 0 || cfg.SMSRetryMaxAttempts < 0 {
+		return nil, errors.New("config: BREAKER_FAILURE_THRESHOLD, SMS_RETRY_QUEUE_SIZE and SMS_RETRY_MAX_ATTEMPTS must not be negative")
+	}
+	switch cfg.PolicyFailureMode {
+	case "open", "closed":
+	default:
+		return nil, errors.New("config: POLICY_FAILURE_MODE must be open or closed")
+	}
+
+	if cfg.ElevationDefault() > cfg.ElevationMax() {
+		return nil, errors.New("config: ELEVATION_DEFAULT_DURATION must not exceed ELEVATION_MAX_DURATION")
+	}
+	if cfg.BreakGlassTTL() > 24*time.Hour {
+		return nil, errors.New("config: BREAK_GLASS_SESSION_TTL must be at most 24h")
+	}
+	if cfg.LoginHoldExpiry() > 24*time.Hour {
+		return nil, errors.New("config: LOGIN_HOLD_TTL must be at most 24h")
+	}
+	if cfg.PublicSessionLifetime() > 24*time.Hour {
+		return nil, errors.New("config: PUBLIC_SESSION_TTL must be at most 24h")
+	}
+	if cfg.DeviceCodeExpiry() > time.Hour {
+		return nil, errors.New("config: DEVICE_CODE_TTL must be at most 1h")
+	}
+	if cfg.MagicLinkExpiry() > time.Hour {
+		return nil, errors.New("config: MAGIC_LINK_TTL must be at most 1h")
+	}
+	if cfg.MagicLinkEnabled && cfg.MagicLinkURL == "" {
+		return nil, errors.New("config: MAGIC_LINK_ENABLED requires MAGIC_LINK_URL")
+	}
+	if cfg.EmailChangeExpiry() > 72*time.Hour {
+		return nil, errors.New("config: EMAIL_CHANGE_TTL must be at most 72h")
+	}
+	if cfg.EmailChangeEnabled && cfg.EmailChangeURL == "" {
+		return nil, errors.New("config: EMAIL_CHANGE_ENABLED requires EMAIL_CHANGE_URL")
+	}
+	if cfg.UsernameScope != "global" && cfg.UsernameScope != "org" {
+		return nil, errors.New("config: USERNAME_SCOPE must be global or org")
+	}
+
+	quotaPlans, err := plans.Parse(cfg.QuotaPlans)
+	if err != nil {
+		return nil, errors.New("config: QUOTA_PLANS: " + err.Error())
+	}
+	if _, ok := quotaPlans[cfg.QuotaDefaultPlan]; !ok {
+		return nil, errors.New("config: QUOTA_DEFAULT_PLAN must be one of the QUOTA_PLANS")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		return nil, errors.New("config: LOG_LEVEL must be debug, info, warn or error")
+	}
+
+	switch cfg.SecretsProvider {
+	case "":
+		if cfg.JWTPrivateKeySecret != "" || cfg.JWTPublicKeySecret != "" || cfg.SMSLocalAPIKeySecret != "" || cfg.PIIMasterKeySecret != "" || cfg.BackupEncryptionKeySecret != "" {
+			return nil, errors.New("config: SECRETS_PROVIDER must be set when a *_SECRET reference is used")
+		}
+		if cfg.OrgSMTPSecretPrefix != "" {
+			return nil, errors.New("config: ORG_SMTP_SECRET_PREFIX requires SECRETS_PROVIDER")
+		}
+	case "vault":
+		if cfg.VaultAddr == "" || (cfg.VaultToken == "" && cfg.VaultTokenFile == "") {
+			return nil, errors.New("config: VAULT_ADDR and VAULT_TOKEN or VAULT_TOKEN_FILE must be set when SECRETS_PROVIDER=vault")
+		}
+	case "aws-secretsmanager", "aws-kms":
+		if cfg.AWSRegion == "" || cfg.AWSAccessKeyID == "" || cfg.AWSSecretAccessKey == "" {
+			return nil, errors.New("config: AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set when SECRETS_PROVIDER=" + cfg.SecretsProvider)
+		}
+	default:
+		return nil, errors.New("config: SECRETS_PROVIDER must be empty, vault, aws-secretsmanager or aws-kms")
+	}
+
+	for _, key := range []string{cfg.PIIMasterKey, cfg.PIIPreviousMasterKey} {
+		if key != "" && !validAES256Key(key) {
+			return nil, errors.New("config: PII_MASTER_KEY and PII_PREVIOUS_MASTER_KEY must be base64 of 32 bytes")
+		}
+	}
+	if cfg.BackupEncryptionKey != "" && !validAES256Key(cfg.BackupEncryptionKey) {
+		return nil, errors.New("config: BACKUP_ENCRYPTION_KEY must be base64 of 32 bytes")
+	}
+	if cfg.PIIPreviousMasterKey != "" && cfg.PIIMasterKey == "" && cfg.PIIMasterKeySecret == "" {
+		return nil, errors.New("config: PII_PREVIOUS_MASTER_KEY requires PII_MASTER_KEY or PII_MASTER_KEY_SECRET")
+	}
+	if strings.TrimSpace(cfg.PIIDataKeyMaxAge) != "0" {
+		if d, err := time.ParseDuration(cfg.PIIDataKeyMaxAge); err != nil || d <= 0 {
+			return nil, errors.New("config: PII_DATA_KEY_MAX_AGE must be 0 or a positive duration")
+		}
+	}
+	if _, err := parseDataRegionDSNs(cfg.DataRegionDSNs); err != nil {
+		return nil, err
+	}
+
+	switch cfg.SessionRevocationConsistency {
+	case "local":
+	case "eventual", "strict":
+		if cfg.Region == "" || len(cfg.SessionRevocationKafkaBrokerList()) == 0 {
+			return nil, errors.New("config: REGION and SESSION_REVOCATION_KAFKA_BROKERS must be set when SESSION_REVOCATION_CONSISTENCY=" + cfg.SessionRevocationConsistency)
+		}
+		if cfg.SessionRevocationHeartbeatEvery() >= cfg.SessionRevocationStaleAfter() {
+			return nil, errors.New("config: SESSION_REVOCATION_HEARTBEAT_INTERVAL must be shorter than SESSION_REVOCATION_MAX_LAG")
+		}
+	default:
+		return nil, errors.New("config: SESSION_REVOCATION_CONSISTENCY must be local, eventual or strict")
+	}
+
+	if cfg.ChangeRequestWebhookURL != "" {
+		u, err := url.Parse(cfg.ChangeRequestWebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: CHANGE_REQUEST_WEBHOOK_URL must be an http or https URL")
+		}
+	}
+	if cfg.BreakGlassWebhookURL != "" {
+		u, err := url.Parse(cfg.BreakGlassWebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: BREAK_GLASS_WEBHOOK_URL must be an http or https URL")
+		}
+	}
+	if cfg.LoginHoldWebhookURL != "" {
+		u, err := url.Parse(cfg.LoginHoldWebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: LOGIN_HOLD_WEBHOOK_URL must be an http or https URL")
+		}
+	}
+	if cfg.HoneytokenWebhookURL != "" {
+		u, err := url.Parse(cfg.HoneytokenWebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: HONEYTOKEN_WEBHOOK_URL must be an http or https URL")
+		}
+	}
+	if cfg.DeviceCodeVerificationURL != "" {
+		u, err := url.Parse(cfg.DeviceCodeVerificationURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: DEVICE_CODE_VERIFICATION_URL must be an http or https URL")
+		}
+	}
+	if cfg.MagicLinkURL != "" {
+		u, err := url.Parse(cfg.MagicLinkURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: MAGIC_LINK_URL must be an http or https URL")
+		}
+	}
+	if cfg.EmailChangeURL != "" {
+		u, err := url.Parse(cfg.EmailChangeURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: EMAIL_CHANGE_URL must be an http or https URL")
+		}
+	}
+	if cfg.LokiURL != "" {
+		u, err := url.Parse(cfg.LokiURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, errors.New("config: LOKI_URL must be an http or https URL")
+		}
+	}
+	switch cfg.TelemetryTransport {
+	case "kafka", "nats", "rabbitmq", "embedded":
+	default:
+		return nil, errors.New("config: TELEMETRY_TRANSPORT must be kafka, nats, rabbitmq or embedded")
+	}
+	if cfg.TelemetryEmbeddedBufferSize < 1 {
+		return nil, errors.New("config: TELEMETRY_EMBEDDED_BUFFER_SIZE must be at least 1")
+	}
+	if cfg.TelemetryEmbeddedRetentionDays < 0 {
+		return nil, errors.New("config: TELEMETRY_EMBEDDED_RETENTION_DAYS must not be negative")
+	}
+	if cfg.TelemetryNATSURL != "" {
+		u, err := url.Parse(cfg.TelemetryNATSURL)
+		if err != nil || (u.Scheme != "nats" && u.Scheme != "tls") || u.Host == "" {
+			return nil, errors.New("config: TELEMETRY_NATS_URL must be a nats or tls URL")
+		}
+	}
+	if cfg.TelemetryRabbitMQURL != "" {
+		u, err := url.Parse(cfg.TelemetryRabbitMQURL)
+		if err != nil || (u.Scheme != "amqp" && u.Scheme != "amqps") || u.Host == "" {
+			return nil, errors.New("config: TELEMETRY_RABBITMQ_URL must be an amqp or amqps URL")
+		}
+	}
+	if cfg.RefreshReplayRedisURL != "" {
+		u, err := url.Parse(cfg.RefreshReplayRedisURL)
+		if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+			return nil, errors.New("config: REFRESH_REPLAY_REDIS_URL must be a redis or rediss URL")
+		}
+	}
+	if cfg.TelemetryDedupSize < 1 {
+		return nil, errors.New("config: TELEMETRY_DEDUP_SIZE must be at least 1")
+	}
+	if cfg.TelemetryDedupRedisURL != "" {
+		u, err := url.Parse(cfg.TelemetryDedupRedisURL)
+		if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+			return nil, errors.New("config: TELEMETRY_DEDUP_REDIS_URL must be a redis or rediss URL")
+		}
+	}
+	if cfg.LokiLabelMaxValues < 1 {
+		return nil, errors.New("config: LOKI_LABEL_MAX_VALUES must be at least 1")
+	}
+	if cfg.LokiTenantPerOrg && cfg.LokiTenantID != "" {
+		return nil, errors.New("config: LOKI_TENANT_ID cannot be set with LOKI_TENANT_PER_ORG=true")
+	}
+
 	return &cfg, nil
 }
 
@@ -123,3 +932,479 @@ func (c *Config) RefreshTTL() time.Duration {
 	}
 	return d
 }
+
+// ClockLeeway parses JWTClockLeeway as a time.Duration. Returns 0 (no leeway) for "0", and 30s if unset or invalid.
+func (c *Config) ClockLeeway() time.Duration {
+	if strings.TrimSpace(c.JWTClockLeeway) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.JWTClockLeeway, 30*time.Second)
+}
+
+// RefreshReplayDuration parses RefreshReplayWindow as a time.Duration. Returns 0 (disabled) for "0", and 1m if unset
+// or invalid.
+func (c *Config) RefreshReplayDuration() time.Duration {
+	if strings.TrimSpace(c.RefreshReplayWindow) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.RefreshReplayWindow, time.Minute)
+}
+
+// RollupInterval parses AnalyticsRollupInterval as a time.Duration. Returns 0 (disabled) for "0",
+// and 15m if unset or invalid.
+func (c *Config) RollupInterval() time.Duration {
+	if strings.TrimSpace(c.AnalyticsRollupInterval) == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.AnalyticsRollupInterval)
+	if err != nil || d <= 0 {
+		return 15 * time.Minute
+	}
+	return d
+}
+
+// MeteringInterval parses MeteringFlushInterval as a time.Duration. Returns 0 (disabled) for "0",
+// and 1m if unset or invalid.
+func (c *Config) MeteringInterval() time.Duration {
+	if strings.TrimSpace(c.MeteringFlushInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.MeteringFlushInterval, time.Minute)
+}
+
+// LicenseInterval parses LicenseCheckInterval as a time.Duration. Returns 0 (disabled) for "0",
+// and 1h if unset or invalid.
+func (c *Config) LicenseInterval() time.Duration {
+	if strings.TrimSpace(c.LicenseCheckInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.LicenseCheckInterval, time.Hour)
+}
+
+// SchedulerInterval parses PolicySchedulerInterval as a time.Duration. Returns 0 (disabled) for "0",
+// and 30s if unset or invalid.
+func (c *Config) SchedulerInterval() time.Duration {
+	if strings.TrimSpace(c.PolicySchedulerInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.PolicySchedulerInterval, 30*time.Second)
+}
+
+// TrustExpiryInterval parses TrustExpiryNoticeInterval as a time.Duration. Returns 0 (disabled) for "0",
+// and 1h if unset or invalid.
+func (c *Config) TrustExpiryInterval() time.Duration {
+	if strings.TrimSpace(c.TrustExpiryNoticeInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.TrustExpiryNoticeInterval, time.Hour)
+}
+
+// AgentStale parses AgentStaleAfter as a time.Duration. Returns 15m if unset or invalid.
+func (c *Config) AgentStale() time.Duration {
+	return durationOrDefault(c.AgentStaleAfter, 15*time.Minute)
+}
+
+// AgentTrustDegradeAfter returns AgentTrustDegradeDays as a time.Duration; 0 disables the degradation.
+func (c *Config) AgentTrustDegradeAfter() time.Duration {
+	return time.Duration(c.AgentTrustDegradeDays) * 24 * time.Hour
+}
+
+// ElevationDefault parses ElevationDefaultDuration as a time.Duration. Returns 1h if unset or invalid.
+func (c *Config) ElevationDefault() time.Duration {
+	return durationOrDefault(c.ElevationDefaultDuration, time.Hour)
+}
+
+// ElevationMax parses ElevationMaxDuration as a time.Duration. Returns 8h if unset or invalid.
+func (c *Config) ElevationMax() time.Duration {
+	return durationOrDefault(c.ElevationMaxDuration, 8*time.Hour)
+}
+
+// ElevationExpiryEvery parses ElevationExpiryInterval as a time.Duration. Returns 0 (disabled) for "0", and 1m if
+// unset or invalid.
+func (c *Config) ElevationExpiryEvery() time.Duration {
+	if strings.TrimSpace(c.ElevationExpiryInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.ElevationExpiryInterval, time.Minute)
+}
+
+// BreakGlassTTL parses BreakGlassSessionTTL as a time.Duration. Returns 1h if unset or invalid.
+func (c *Config) BreakGlassTTL() time.Duration {
+	return durationOrDefault(c.BreakGlassSessionTTL, time.Hour)
+}
+
+// BreakGlassExpiryEvery parses BreakGlassExpiryInterval as a time.Duration. Returns 0 (disabled) for "0", and 1m
+// if unset or invalid.
+func (c *Config) BreakGlassExpiryEvery() time.Duration {
+	if strings.TrimSpace(c.BreakGlassExpiryInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.BreakGlassExpiryInterval, time.Minute)
+}
+
+// BreakGlassAlertEmailList splits BreakGlassAlertEmails on commas, dropping empty entries.
+func (c *Config) BreakGlassAlertEmailList() []string {
+	return splitList(c.BreakGlassAlertEmails)
+}
+
+// LoginHoldExpiry parses LoginHoldTTL as a time.Duration. Returns 15m if unset or invalid.
+func (c *Config) LoginHoldExpiry() time.Duration {
+	return durationOrDefault(c.LoginHoldTTL, 15*time.Minute)
+}
+
+// PublicSessionLifetime parses PublicSessionTTL as a time.Duration. Returns 1h if unset or invalid.
+func (c *Config) PublicSessionLifetime() time.Duration {
+	return durationOrDefault(c.PublicSessionTTL, time.Hour)
+}
+
+// PublicSessionIdle parses PublicSessionIdleTimeout as a time.Duration. Returns 15m if unset or invalid.
+func (c *Config) PublicSessionIdle() time.Duration {
+	return durationOrDefault(c.PublicSessionIdleTimeout, 15*time.Minute)
+}
+
+// PublicSessionSweepEvery parses PublicSessionSweepInterval as a time.Duration. Returns 0 (disabled) for "0", and
+// 1m if unset or invalid.
+func (c *Config) PublicSessionSweepEvery() time.Duration {
+	if strings.TrimSpace(c.PublicSessionSweepInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.PublicSessionSweepInterval, time.Minute)
+}
+
+// DeviceCodeExpiry parses DeviceCodeTTL as a time.Duration. Returns 10m if unset or invalid.
+func (c *Config) DeviceCodeExpiry() time.Duration {
+	return durationOrDefault(c.DeviceCodeTTL, 10*time.Minute)
+}
+
+// MagicLinkExpiry parses MagicLinkTTL as a time.Duration. Returns 15m if unset or invalid.
+func (c *Config) MagicLinkExpiry() time.Duration {
+	return durationOrDefault(c.MagicLinkTTL, 15*time.Minute)
+}
+
+// EmailChangeExpiry parses EmailChangeTTL as a time.Duration. Returns 24h if unset or invalid.
+func (c *Config) EmailChangeExpiry() time.Duration {
+	return durationOrDefault(c.EmailChangeTTL, 24*time.Hour)
+}
+
+// PIIEncryptionEnabled reports whether a PII master key is configured, directly or as a secrets-provider reference.
+func (c *Config) PIIEncryptionEnabled() bool {
+	return c.PIIMasterKey != "" || c.PIIMasterKeySecret != ""
+}
+
+// PIIDataKeyMaxAgeDuration parses PIIDataKeyMaxAge as a time.Duration. Returns 0 (never retire) for "0", unset or
+// invalid values.
+func (c *Config) PIIDataKeyMaxAgeDuration() time.Duration {
+	return durationOrDefault(c.PIIDataKeyMaxAge, 0)
+}
+
+// PIIReencryptEvery parses PIIReencryptInterval as a time.Duration. Returns 0 (disabled) for "0", and 1h if unset or
+// invalid.
+func (c *Config) PIIReencryptEvery() time.Duration {
+	if strings.TrimSpace(c.PIIReencryptInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.PIIReencryptInterval, time.Hour)
+}
+
+// BackupsEnabled reports whether a backup encryption key is configured, directly or as a secrets-provider reference.
+func (c *Config) BackupsEnabled() bool {
+	return c.BackupEncryptionKey != "" || c.BackupEncryptionKeySecret != ""
+}
+
+// DataExportEvery parses DataExportInterval as a time.Duration. Returns 0 (disabled) for "0", and 30s if unset or
+// invalid.
+func (c *Config) DataExportEvery() time.Duration {
+	if strings.TrimSpace(c.DataExportInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.DataExportInterval, 30*time.Second)
+}
+
+// DataExportLifetime parses DataExportTTL as a time.Duration. Returns 72h if unset or invalid.
+func (c *Config) DataExportLifetime() time.Duration {
+	return durationOrDefault(c.DataExportTTL, 72*time.Hour)
+}
+
+// AuditFlushEvery parses AuditFlushInterval as a time.Duration. Returns 1s if unset or invalid.
+func (c *Config) AuditFlushEvery() time.Duration {
+	return durationOrDefault(c.AuditFlushInterval, time.Second)
+}
+
+// TelemetryEmbeddedRetention returns TelemetryEmbeddedRetentionDays as a time.Duration; 0 keeps events forever.
+func (c *Config) TelemetryEmbeddedRetention() time.Duration {
+	return time.Duration(c.TelemetryEmbeddedRetentionDays) * 24 * time.Hour
+}
+
+// AuditRetention returns AuditRetentionDays as a time.Duration; 0 keeps audit logs forever.
+func (c *Config) AuditRetention() time.Duration {
+	return time.Duration(c.AuditRetentionDays) * 24 * time.Hour
+}
+
+// AuditPartitionEvery parses AuditPartitionInterval as a time.Duration. Returns 1h if unset or invalid, 0 for "0"
+// (job disabled).
+func (c *Config) AuditPartitionEvery() time.Duration {
+	if strings.TrimSpace(c.AuditPartitionInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.AuditPartitionInterval, time.Hour)
+}
+
+// SettingsCacheDuration parses SettingsCacheTTL as a time.Duration. Returns 30s if unset or invalid, 0 for "0"
+// (cache disabled).
+func (c *Config) SettingsCacheDuration() time.Duration {
+	if strings.TrimSpace(c.SettingsCacheTTL) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.SettingsCacheTTL, 30*time.Second)
+}
+
+// QuotaPlanTable parses QuotaPlans. Returns the built-in plans.DefaultPlans if invalid (Load rejects invalid plans).
+func (c *Config) QuotaPlanTable() map[string]plans.Plan {
+	table, err := plans.Parse(c.QuotaPlans)
+	if err != nil {
+		table, _ = plans.Parse(plans.DefaultPlans)
+	}
+	return table
+}
+
+// DataRegionDSNMap parses DataRegionDSNs into a region → DSN map. Returns nil if unset or invalid (Load rejects
+// invalid values).
+func (c *Config) DataRegionDSNMap() map[string]string {
+	m, err := parseDataRegionDSNs(c.DataRegionDSNs)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// DetectorScanInterval parses DetectorInterval as a time.Duration. Returns 1m if unset or invalid.
+func (c *Config) DetectorScanInterval() time.Duration {
+	return durationOrDefault(c.DetectorInterval, time.Minute)
+}
+
+// DetectorScanWindow parses DetectorWindow as a time.Duration. Returns 15m if unset or invalid.
+func (c *Config) DetectorScanWindow() time.Duration {
+	return durationOrDefault(c.DetectorWindow, 15*time.Minute)
+}
+
+// DetectorIPBlockDuration parses DetectorBlockDuration as a time.Duration. Returns 1h if unset or invalid.
+func (c *Config) DetectorIPBlockDuration() time.Duration {
+	return durationOrDefault(c.DetectorBlockDuration, time.Hour)
+}
+
+// VerifyCredentialsRateWindow parses VerifyCredentialsWindow as a time.Duration. Returns 15m if unset or invalid.
+func (c *Config) VerifyCredentialsRateWindow() time.Duration {
+	return durationOrDefault(c.VerifyCredentialsWindow, 15*time.Minute)
+}
+
+// SignupRateWindow parses SignupWindow as a time.Duration. Returns 1h if unset or invalid.
+func (c *Config) SignupRateWindow() time.Duration {
+	return durationOrDefault(c.SignupWindow, time.Hour)
+}
+
+// SignupAllowedEmailDomainList splits SignupAllowedEmailDomains on commas, dropping empty entries.
+func (c *Config) SignupAllowedEmailDomainList() []string {
+	return splitList(c.SignupAllowedEmailDomains)
+}
+
+// TokenExchangeAudienceList splits TokenExchangeAudiences on commas, dropping empty entries.
+func (c *Config) TokenExchangeAudienceList() []string {
+	return splitList(c.TokenExchangeAudiences)
+}
+
+// ResourceTokenTTL parses TokenExchangeTTL as a time.Duration. Returns 5m if unset or invalid.
+func (c *Config) ResourceTokenTTL() time.Duration {
+	return durationOrDefault(c.TokenExchangeTTL, 5*time.Minute)
+}
+
+// ServiceAccountUserIDList splits ServiceAccountUserIDs on commas, dropping empty entries.
+func (c *Config) ServiceAccountUserIDList() []string {
+	return splitList(c.ServiceAccountUserIDs)
+}
+
+// IntrospectionCacheMaxTTL parses IntrospectionCacheTTL as a time.Duration. Returns 30s if unset or invalid.
+func (c *Config) IntrospectionCacheMaxTTL() time.Duration {
+	return durationOrDefault(c.IntrospectionCacheTTL, 30*time.Second)
+}
+
+// MFAResendCooldownDuration parses MFAResendCooldown as a time.Duration. Returns 30s if unset or invalid.
+func (c *Config) MFAResendCooldownDuration() time.Duration {
+	return durationOrDefault(c.MFAResendCooldown, 30*time.Second)
+}
+
+// BreakerOpenDuration parses BreakerOpenTimeout as a time.Duration. Returns 30s if unset or invalid.
+func (c *Config) BreakerOpenDuration() time.Duration {
+	return durationOrDefault(c.BreakerOpenTimeout, 30*time.Second)
+}
+
+// SlogLevel parses LogLevel. Returns slog.LevelInfo if unset or invalid.
+func (c *Config) SlogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// ReloadInterval parses ConfigReloadInterval as a time.Duration. Returns 0 (SIGHUP only) if unset, "0" or invalid.
+func (c *Config) ReloadInterval() time.Duration {
+	return durationOrDefault(c.ConfigReloadInterval, 0)
+}
+
+// PlatformAdminUserIDList splits PlatformAdminUserIDs on commas, dropping empty entries.
+func (c *Config) PlatformAdminUserIDList() []string {
+	return splitList(c.PlatformAdminUserIDs)
+}
+
+// SecretsRefreshEvery parses SecretsRefreshInterval as a time.Duration. Returns 0 (no refresh) for "0",
+// and 5m if unset or invalid.
+func (c *Config) SecretsRefreshEvery() time.Duration {
+	if strings.TrimSpace(c.SecretsRefreshInterval) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.SecretsRefreshInterval, 5*time.Minute)
+}
+
+// SecretsSettings returns the SECRETS_PROVIDER settings for secrets.NewProvider.
+func (c *Config) SecretsSettings() secrets.Settings {
+	return secrets.Settings{
+		Provider:       c.SecretsProvider,
+		VaultAddr:      c.VaultAddr,
+		VaultKVMount:   c.VaultKVMount,
+		VaultToken:     c.VaultToken,
+		VaultTokenFile: c.VaultTokenFile,
+		VaultNamespace: c.VaultNamespace,
+		AWSRegion:      c.AWSRegion,
+		AWSEndpoint:    c.SecretsAWSEndpoint,
+		AWSCredentials: secrets.AWSCredentials{AccessKeyID: c.AWSAccessKeyID, SecretAccessKey: c.AWSSecretAccessKey, SessionToken: c.AWSSessionToken},
+	}
+}
+
+// DBQueryTimeoutDuration parses DBQueryTimeout as a time.Duration. Returns 0 (disabled) for "0", and 10s if unset or
+// invalid.
+func (c *Config) DBQueryTimeoutDuration() time.Duration {
+	if strings.TrimSpace(c.DBQueryTimeout) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.DBQueryTimeout, 10*time.Second)
+}
+
+// DBStatementTimeoutDuration parses DBStatementTimeout as a time.Duration. Returns 0 (disabled) for "0", and 30s if
+// unset or invalid.
+func (c *Config) DBStatementTimeoutDuration() time.Duration {
+	if strings.TrimSpace(c.DBStatementTimeout) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.DBStatementTimeout, 30*time.Second)
+}
+
+// TelemetryDedupDuration parses TelemetryDedupWindow as a time.Duration. Returns 0 (disabled) for "0", and 1h if
+// unset or invalid.
+func (c *Config) TelemetryDedupDuration() time.Duration {
+	if strings.TrimSpace(c.TelemetryDedupWindow) == "0" {
+		return 0
+	}
+	return durationOrDefault(c.TelemetryDedupWindow, time.Hour)
+}
+
+// TelemetryEnabled reports whether the connection setting of TelemetryTransport is set: TELEMETRY_KAFKA_BROKERS,
+// TELEMETRY_NATS_URL or TELEMETRY_RABBITMQ_URL. The embedded transport is always enabled.
+func (c *Config) TelemetryEnabled() bool {
+	switch c.TelemetryTransport {
+	case "embedded":
+		return true
+	case "nats":
+		return c.TelemetryNATSURL != ""
+	case "rabbitmq":
+		return c.TelemetryRabbitMQURL != ""
+	default:
+		return len(c.TelemetryKafkaBrokerList()) > 0
+	}
+}
+
+// TelemetryKafkaBrokerList splits TelemetryKafkaBrokers on commas, dropping empty entries.
+func (c *Config) TelemetryKafkaBrokerList() []string {
+	return splitList(c.TelemetryKafkaBrokers)
+}
+
+// SessionRevocationKafkaBrokerList splits SessionRevocationKafkaBrokers on commas, dropping empty entries.
+func (c *Config) SessionRevocationKafkaBrokerList() []string {
+	return splitList(c.SessionRevocationKafkaBrokers)
+}
+
+// SessionRevocationConsumerGroup returns SessionRevocationKafkaGroup, or ztcp-session-revocations-<REGION> if unset.
+// Each region must use its own group so that every region receives every event.
+func (c *Config) SessionRevocationConsumerGroup() string {
+	if c.SessionRevocationKafkaGroup != "" {
+		return c.SessionRevocationKafkaGroup
+	}
+	return "ztcp-session-revocations-" + c.Region
+}
+
+// SessionRevocationHeartbeatEvery parses SessionRevocationHeartbeatInterval as a time.Duration. Returns 5s if unset or invalid.
+func (c *Config) SessionRevocationHeartbeatEvery() time.Duration {
+	return durationOrDefault(c.SessionRevocationHeartbeatInterval, 5*time.Second)
+}
+
+// SessionRevocationStaleAfter parses SessionRevocationMaxLag as a time.Duration. Returns 30s if unset or invalid.
+func (c *Config) SessionRevocationStaleAfter() time.Duration {
+	return durationOrDefault(c.SessionRevocationMaxLag, 30*time.Second)
+}
+
+func durationOrDefault(s string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// parseDataRegionDSNs parses comma-separated region=dsn pairs. Region names are lowercase letters, digits and dashes,
+// and each region may appear once.
+func parseDataRegionDSNs(s string) (map[string]string, error) {
+	pairs := splitList(s)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		region, dsn, ok := strings.Cut(p, "=")
+		region, dsn = strings.TrimSpace(region), strings.TrimSpace(dsn)
+		if !ok || !validRegionName(region) || dsn == "" {
+			return nil, errors.New("config: DATA_REGION_DSNS must be comma-separated region=dsn pairs")
+		}
+		if _, dup := out[region]; dup {
+			return nil, errors.New("config: DATA_REGION_DSNS lists region " + region + " more than once")
+		}
+		out[region] = dsn
+	}
+	return out, nil
+}
+
+func validRegionName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// validAES256Key reports whether s is the base64 encoding of an AES-256 key.
+func validAES256Key(s string) bool {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	return err == nil && len(key) == 32
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Entry is one setting of the effective configuration, as returned by Config.Entries.
+type Entry struct {
+	Key        string // env var name, e.g. JWT_ACCESS_TTL
+	Value      string // empty when Redacted
+	Reloadable bool   // applied at runtime by Watcher
+	Redacted   bool   // secret value that is set but not shown
+}
+
+// Entries lists every setting in declaration order. Secret values are redacted.
+func (c *Config) Entries() []Entry {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	entries := make([]Entry, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		e := Entry{
+			Key:        f.Tag.Get("mapstructure"),
+			Value:      fmt.Sprint(v.Field(i).Interface()),
+			Reloadable: f.Tag.Get("reload") == "true",
+		}
+		if f.Tag.Get("secret") == "true" && !v.Field(i).IsZero() {
+			e.Value, e.Redacted = "", true
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// Watcher holds the effective configuration and re-reads it on demand. Only fields tagged reload:"true" are applied
+// at runtime; changes to other fields are logged as requiring a restart and ignored. Safe for concurrent use.
+type Watcher struct {
+	load func() (*Config, error)
+
+	mu          sync.RWMutex
+	current     *Config
+	loadedAt    time.Time
+	subscribers []func(cfg *Config)
+}
+
+// NewWatcher returns a Watcher whose effective configuration starts as a copy of initial (the result of Load).
+func NewWatcher(initial *Config) *Watcher {
+	c := *initial
+	return &Watcher{load: Load, current: &c, loadedAt: time.Now().UTC()}
+}
+
+// Current returns the effective configuration. The result must not be modified; each reload replaces it.
+func (w *Watcher) Current() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// LoadedAt returns when the effective configuration was loaded or last changed by a reload.
+func (w *Watcher) LoadedAt() time.Time {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.loadedAt
+}
+
+// IsPlatformAdmin reports whether userID is listed in PLATFORM_ADMIN_USER_IDS of the effective configuration.
+func (w *Watcher) IsPlatformAdmin(userID string) bool {
+	return userID != "" && slices.Contains(w.Current().PlatformAdminUserIDList(), userID)
+}
+
+// IsServiceAccount reports whether userID is listed in SERVICE_ACCOUNT_USER_IDS of the effective configuration.
+func (w *Watcher) IsServiceAccount(userID string) bool {
+	return userID != "" && slices.Contains(w.Current().ServiceAccountUserIDList(), userID)
+}
+
+// Subscribe registers fn to be called with the new effective configuration after a reload changes a reloadable
+// setting. Subscribers apply the settings they own and should be idempotent.
+func (w *Watcher) Subscribe(fn func(cfg *Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload re-reads the configuration and applies changed reloadable settings. It returns the keys that changed.
+// An invalid configuration is rejected as a whole and the effective configuration is kept.
+func (w *Watcher) Reload() ([]string, error) {
+	next, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.mu.Lock()
+	merged := *w.current
+	mv := reflect.ValueOf(&merged).Elem()
+	nv := reflect.ValueOf(next).Elem()
+	var changed []string
+	for i := 0; i < mv.NumField(); i++ {
+		f := mv.Type().Field(i)
+		if reflect.DeepEqual(mv.Field(i).Interface(), nv.Field(i).Interface()) {
+			continue
+		}
+		key := f.Tag.Get("mapstructure")
+		if f.Tag.Get("reload") != "true" {
+			log.Printf("config: %s changed; restart required to apply", key)
+			continue
+		}
+		mv.Field(i).Set(nv.Field(i))
+		changed = append(changed, key)
+	}
+	if len(changed) == 0 {
+		w.mu.Unlock()
+		return nil, nil
+	}
+	w.current = &merged
+	w.loadedAt = time.Now().UTC()
+	subscribers := slices.Clone(w.subscribers)
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(&merged)
+	}
+	return changed, nil
+}
+
+// Run reloads on SIGHUP and, when interval is positive, every interval until ctx is done.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		case <-tick:
+		}
+		changed, err := w.Reload()
+		if err != nil {
+			log.Printf("config: reload failed, keeping current config: %v", err)
+		} else if len(changed) > 0 {
+			log.Printf("config: reloaded %v", changed)
+		}
+	}
+}
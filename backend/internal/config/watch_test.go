@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWatcher_ReloadAppliesOnlyReloadableFields(t *testing.T) {
+	initial := &Config{GRPCAddr: ":8080", JWTAccessTTL: "15m", VerifyCredentialsIPLimit: 20}
+	w := NewWatcher(initial)
+	next := &Config{GRPCAddr: ":9090", JWTAccessTTL: "5m", VerifyCredentialsIPLimit: 50}
+	w.load = func() (*Config, error) { return next, nil }
+	var notified []*Config
+	w.Subscribe(func(cfg *Config) { notified = append(notified, cfg) })
+	before := w.LoadedAt()
+
+	changed, err := w.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if len(changed) != 2 || changed[0] != "JWT_ACCESS_TTL" || changed[1] != "VERIFY_CREDENTIALS_IP_LIMIT" {
+		t.Errorf("changed = %v, want [JWT_ACCESS_TTL VERIFY_CREDENTIALS_IP_LIMIT]", changed)
+	}
+	cur := w.Current()
+	if cur.JWTAccessTTL != "5m" || cur.VerifyCredentialsIPLimit != 50 {
+		t.Errorf("reloadable fields not applied: %+v", cur)
+	}
+	if cur.GRPCAddr != ":8080" {
+		t.Errorf("GRPCAddr = %q; non-reloadable fields must keep their startup value", cur.GRPCAddr)
+	}
+	if len(notified) != 1 || notified[0] != cur {
+		t.Errorf("subscribers notified %d times, want once with the new config", len(notified))
+	}
+	if w.LoadedAt().Before(before) {
+		t.Error("LoadedAt should advance on change")
+	}
+	if initial.JWTAccessTTL != "15m" {
+		t.Error("Reload must not modify the initial config")
+	}
+
+	changed, err = w.Reload()
+	if err != nil || len(changed) != 0 || len(notified) != 1 {
+		t.Errorf("second Reload with no changes: changed=%v err=%v notified=%d", changed, err, len(notified))
+	}
+}
+
+func TestWatcher_ReloadErrorKeepsConfig(t *testing.T) {
+	w := NewWatcher(&Config{JWTAccessTTL: "15m"})
+	w.load = func() (*Config, error) {
+		return nil, errors.New("config: LOG_LEVEL must be debug, info, warn or error")
+	}
+	if _, err := w.Reload(); err == nil {
+		t.Fatal("Reload: expected error")
+	}
+	if w.Current().JWTAccessTTL != "15m" {
+		t.Error("invalid config must not replace the effective config")
+	}
+}
+
+func TestWatcher_ReloadFromConfigFile(t *testing.T) {
+	os.Clearenv()
+	path := filepath.Join(t.TempDir(), "ztcp.env")
+	if err := os.WriteFile(path, []byte("GRPC_ADDR=:8080\nLOG_LEVEL=info\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("CONFIG_FILE", path)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	w := NewWatcher(cfg)
+
+	if err := os.WriteFile(path, []byte("GRPC_ADDR=:8080\nLOG_LEVEL=debug\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if w.Current().LogLevel != "debug" {
+		t.Errorf("LogLevel = %q, want debug from the rewritten file", w.Current().LogLevel)
+	}
+}
+
+func TestConfig_Entries(t *testing.T) {
+	cfg := &Config{GRPCAddr: ":8080", DatabaseURL: "postgres://u:p@db/ztcp", JWTAccessTTL: "15m"}
+	byKey := map[string]Entry{}
+	for _, e := range cfg.Entries() {
+		byKey[e.Key] = e
+	}
+	if e := byKey["GRPC_ADDR"]; e.Value != ":8080" || e.Reloadable || e.Redacted {
+		t.Errorf("GRPC_ADDR = %+v", e)
+	}
+	if e := byKey["DATABASE_URL"]; e.Value != "" || !e.Redacted {
+		t.Errorf("DATABASE_URL should be redacted: %+v", e)
+	}
+	if e := byKey["SMTP_PASSWORD"]; e.Redacted {
+		t.Errorf("unset secret should not be reported as redacted: %+v", e)
+	}
+	if e := byKey["JWT_ACCESS_TTL"]; e.Value != "15m" || !e.Reloadable {
+		t.Errorf("JWT_ACCESS_TTL = %+v", e)
+	}
+}
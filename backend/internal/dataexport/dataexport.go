@@ -0,0 +1,261 @@
+// Package dataexport assembles machine-readable archives of a user's personal data for data-subject access requests
+// (GDPR Art. 15 and 20). An export is requested by the user (UserService.ExportMyData) or a platform admin
+// (AdminService.ExportUserData), generated asynchronously by the data export job, and downloaded with the token
+// returned by the request (UserService.DownloadDataExport) until it expires.
+package dataexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+	"zero-trust-control-plane/backend/internal/dataexport/domain"
+	"zero-trust-control-plane/backend/internal/dataexport/repository"
+	"zero-trust-control-plane/backend/internal/security"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// FormatVersion is the version of the archive format written by the job.
+const FormatVersion = 1
+
+// MaxAuditEvents is how many of the user's latest audit events an archive holds.
+const MaxAuditEvents = 10000
+
+// GenerateTimeout is how long a job may take to generate an export before another job claims it again.
+const GenerateTimeout = 15 * time.Minute
+
+var (
+	// ErrUserNotFound is returned by Request for a user that does not exist.
+	ErrUserNotFound = errors.New("dataexport: user not found")
+	// ErrExportInProgress is returned by Request while an export of the user is pending or being generated.
+	ErrExportInProgress = errors.New("dataexport: an export of this user is already being generated")
+	// ErrExportNotFound is returned by Download for an unknown or expired token, or one issued to someone else.
+	ErrExportNotFound = errors.New("dataexport: export not found")
+)
+
+// UserGetter reads the user's profile, with encrypted PII decrypted.
+type UserGetter interface {
+	GetByID(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+// AuditSource lists the audit events a user is the actor of (e.g. *auditrepo.PostgresRepository, which reads every
+// data region).
+type AuditSource interface {
+	ListByUser(ctx context.Context, userID string, limit int32) ([]*auditdomain.AuditLog, error)
+}
+
+// Service requests, generates and serves data exports.
+type Service struct {
+	repo   repository.Repository
+	users  UserGetter
+	audits AuditSource
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewService returns a Service backed by repo. Exports can be downloaded for ttl after they are requested. audits may
+// be nil; archives then hold no audit events.
+func NewService(repo repository.Repository, users UserGetter, audits AuditSource, ttl time.Duration) *Service {
+	return &Service{repo: repo, users: users, audits: audits, ttl: ttl, now: time.Now}
+}
+
+// Request creates a pending export of userID's data for by and returns it with its download token, which is not
+// stored and cannot be shown again. The export is stored in the data region of the user's home org (the org of their
+// first membership), or the primary database for a user without one. Returns ErrUserNotFound, ErrExportInProgress or
+// residency.ErrRegionUnavailable.
+func (s *Service) Request(ctx context.Context, userID, by string) (*domain.Export, string, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if u == nil {
+		return nil, "", ErrUserNotFound
+	}
+	active, err := s.repo.HasActive(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if active {
+		return nil, "", ErrExportInProgress
+	}
+	orgID, err := s.repo.HomeOrg(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	token, hash, err := domain.NewToken()
+	if err != nil {
+		return nil, "", err
+	}
+	now := s.now().UTC()
+	e := &domain.Export{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		RequestedBy: by,
+		OrgID:       orgID,
+		TokenHash:   hash,
+		Status:      domain.StatusPending,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	if err := s.repo.Create(ctx, e); err != nil {
+		return nil, "", err
+	}
+	return e, token, nil
+}
+
+// Download returns the export of token, with its archive when ready. Only by, who requested it, can download it;
+// for anyone else, and for unknown or expired tokens, it returns ErrExportNotFound.
+func (s *Service) Download(ctx context.Context, token, by string) (*domain.Export, error) {
+	e, err := s.repo.GetByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if e == nil || e.RequestedBy != by || !s.now().Before(e.ExpiresAt) {
+		return nil, ErrExportNotFound
+	}
+	if e.Status == domain.StatusGenerating {
+		e.Status = domain.StatusPending
+	}
+	return e, nil
+}
+
+// FileName returns the name to save the archive of e as.
+func FileName(e *domain.Export) string {
+	return "data-export-" + e.UserID + "-" + e.CreatedAt.UTC().Format("20060102T150405Z") + ".json.gz"
+}
+
+// RunOnce deletes expired exports and generates the pending ones. Returns how many were generated; an export whose
+// archive cannot be built is marked failed and does not stop the run.
+func (s *Service) RunOnce(ctx context.Context) (int, error) {
+	now := s.now().UTC()
+	if n, err := s.repo.DeleteExpired(ctx, now); err != nil {
+		return 0, err
+	} else if n > 0 {
+		log.Printf("dataexport: deleted %d expired exports", n)
+	}
+	generated := 0
+	for ctx.Err() == nil {
+		now := s.now().UTC()
+		e, err := s.repo.Claim(ctx, now, now.Add(-GenerateTimeout))
+		if err != nil {
+			return generated, err
+		}
+		if e == nil {
+			break
+		}
+		archive, err := s.build(ctx, e.UserID)
+		if err != nil {
+			log.Printf("dataexport: export %s failed: %v", e.ID, err)
+			if err := s.repo.Finish(ctx, e.OrgID, e.ID, domain.StatusFailed, nil, err.Error(), s.now().UTC()); err != nil {
+				return generated, err
+			}
+			continue
+		}
+		if err := s.repo.Finish(ctx, e.OrgID, e.ID, domain.StatusReady, archive, "", s.now().UTC()); err != nil {
+			return generated, err
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// Run calls RunOnce every interval until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.RunOnce(ctx); err != nil {
+			log.Printf("dataexport: run failed after %d exports: %v", n, err)
+		} else if n > 0 {
+			log.Printf("dataexport: generated %d exports", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// build reads the user's data and returns it as a gzipped JSON Archive.
+func (s *Service) build(ctx context.Context, userID string) ([]byte, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	rec, err := s.repo.Records(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	a := &domain.Archive{
+		FormatVersion: FormatVersion,
+		UserID:        userID,
+		GeneratedAt:   s.now().UTC(),
+		Profile: domain.Profile{
+			ID:            u.ID,
+			Email:         u.Email,
+			Name:          u.Name,
+			Phone:         u.Phone,
+			PhoneVerified: u.PhoneVerified,
+			Status:        string(u.Status),
+			CreatedAt:     u.CreatedAt,
+			UpdatedAt:     u.UpdatedAt,
+		},
+		Identities:           nonNil(rec.Identities),
+		Memberships:          nonNil(rec.Memberships),
+		MembershipAttributes: nonNil(rec.MembershipAttributes),
+		GroupMemberships:     nonNil(rec.GroupMemberships),
+		Devices:              nonNil(rec.Devices),
+		Sessions:             nonNil(rec.Sessions),
+		Usernames:            nonNil(rec.Usernames),
+		AuditEvents:          []domain.AuditEvent{},
+	}
+	if s.audits != nil {
+		logs, err := s.audits.ListByUser(ctx, userID, MaxAuditEvents+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(logs) > MaxAuditEvents {
+			logs, a.AuditEventsTruncated = logs[:MaxAuditEvents], true
+		}
+		for _, l := range logs {
+			a.AuditEvents = append(a.AuditEvents, domain.AuditEvent{
+				ID:        l.ID,
+				OrgID:     l.OrgID,
+				Action:    l.Action,
+				Resource:  l.Resource,
+				IP:        l.IP,
+				Metadata:  l.Metadata,
+				RequestID: l.RequestID,
+				CreatedAt: l.CreatedAt,
+			})
+		}
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(a); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// nonNil returns rows, or an empty slice for nil so that empty sections are written as [] rather than null.
+func nonNil(rows []json.RawMessage) []json.RawMessage {
+	if rows == nil {
+		return []json.RawMessage{}
+	}
+	return rows
+}
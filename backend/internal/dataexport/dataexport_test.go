@@ -0,0 +1,217 @@
+package dataexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+	"zero-trust-control-plane/backend/internal/dataexport/domain"
+	"zero-trust-control-plane/backend/internal/security"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// memRepo keeps exports in memory, in creation order. finishedIn records the org each export was finished in.
+type memRepo struct {
+	exports    []*domain.Export
+	records    *domain.Records
+	recordsErr error
+	homeOrgs   map[string]string
+	finishedIn map[string]string
+}
+
+func (m *memRepo) Create(ctx context.Context, e *domain.Export) error {
+	c := *e
+	m.exports = append(m.exports, &c)
+	return nil
+}
+
+func (m *memRepo) HasActive(ctx context.Context, userID string) (bool, error) {
+	for _, e := range m.exports {
+		if e.UserID == userID && (e.Status == domain.StatusPending || e.Status == domain.StatusGenerating) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memRepo) GetByTokenHash(ctx context.Context, hash string) (*domain.Export, error) {
+	for _, e := range m.exports {
+		if e.TokenHash == hash {
+			c := *e
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRepo) Claim(ctx context.Context, now, staleBefore time.Time) (*domain.Export, error) {
+	for _, e := range m.exports {
+		if e.Status == domain.StatusPending {
+			e.Status = domain.StatusGenerating
+			c := *e
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRepo) Finish(ctx context.Context, orgID, id string, status domain.Status, archive []byte, reason string, now time.Time) error {
+	if m.finishedIn == nil {
+		m.finishedIn = make(map[string]string)
+	}
+	m.finishedIn[id] = orgID
+	for _, e := range m.exports {
+		if e.ID == id {
+			e.Status, e.Archive, e.Error, e.CompletedAt = status, archive, reason, &now
+		}
+	}
+	return nil
+}
+
+func (m *memRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	kept := m.exports[:0]
+	for _, e := range m.exports {
+		if now.Before(e.ExpiresAt) {
+			kept = append(kept, e)
+		}
+	}
+	n := int64(len(m.exports) - len(kept))
+	m.exports = kept
+	return n, nil
+}
+
+func (m *memRepo) HomeOrg(ctx context.Context, userID string) (string, error) {
+	return m.homeOrgs[userID], nil
+}
+
+func (m *memRepo) Records(ctx context.Context, userID string) (*domain.Records, error) {
+	if m.recordsErr != nil {
+		return nil, m.recordsErr
+	}
+	return m.records, nil
+}
+
+type memUsers map[string]*userdomain.User
+
+func (m memUsers) GetByID(ctx context.Context, id string) (*userdomain.User, error) {
+	return m[id], nil
+}
+
+type memAudits []*auditdomain.AuditLog
+
+func (m memAudits) ListByUser(ctx context.Context, userID string, limit int32) ([]*auditdomain.AuditLog, error) {
+	return m, nil
+}
+
+func testService(repo *memRepo, now *time.Time) *Service {
+	users := memUsers{"u1": {ID: "u1", Email: "ada@example.com", Name: "Ada", Status: userdomain.UserStatusActive}}
+	audits := memAudits{{ID: "a1", OrgID: "org-1", UserID: "u1", Action: "login_success", Resource: "session", IP: "10.0.0.1"}}
+	svc := NewService(repo, users, audits, 72*time.Hour)
+	svc.now = func() time.Time { return *now }
+	return svc
+}
+
+func readArchive(t *testing.T, data []byte) *domain.Archive {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	var a domain.Archive
+	if err := json.NewDecoder(zr).Decode(&a); err != nil {
+		t.Fatalf("decode archive: %v", err)
+	}
+	return &a
+}
+
+func TestService_RequestAndDownload(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &memRepo{records: &domain.Records{
+		Identities: []json.RawMessage{json.RawMessage(`{"id":"i1","provider":"local"}`)},
+		Devices:    []json.RawMessage{json.RawMessage(`{"id":"d1","fingerprint":"fp"}`)},
+	}, homeOrgs: map[string]string{"u1": "org-eu"}}
+	svc := testService(repo, &now)
+	ctx := context.Background()
+
+	if _, _, err := svc.Request(ctx, "nobody", "nobody"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("unknown user: want ErrUserNotFound, got %v", err)
+	}
+	e, token, err := svc.Request(ctx, "u1", "u1")
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if !strings.HasPrefix(token, domain.TokenPrefix) || e.TokenHash != security.HashToken(token) || !e.ExpiresAt.Equal(now.Add(72*time.Hour)) {
+		t.Errorf("export = %+v, token %q", e, token)
+	}
+	if e.OrgID != "org-eu" {
+		t.Errorf("OrgID = %q, want the user's home org, whose region stores the export", e.OrgID)
+	}
+	if _, _, err := svc.Request(ctx, "u1", "admin"); !errors.Is(err, ErrExportInProgress) {
+		t.Errorf("second request: want ErrExportInProgress, got %v", err)
+	}
+	got, err := svc.Download(ctx, token, "u1")
+	if err != nil || got.Status != domain.StatusPending || got.Archive != nil {
+		t.Fatalf("Download before the job: %+v, %v", got, err)
+	}
+	if _, err := svc.Download(ctx, token, "admin"); !errors.Is(err, ErrExportNotFound) {
+		t.Errorf("other requester: want ErrExportNotFound, got %v", err)
+	}
+	if _, err := svc.Download(ctx, "ztcp_dx_unknown", "u1"); !errors.Is(err, ErrExportNotFound) {
+		t.Errorf("unknown token: want ErrExportNotFound, got %v", err)
+	}
+
+	if n, err := svc.RunOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("RunOnce = %d, %v; want 1 export", n, err)
+	}
+	if repo.finishedIn[e.ID] != "org-eu" {
+		t.Errorf("finished in %q, want the home org's region", repo.finishedIn[e.ID])
+	}
+	got, err = svc.Download(ctx, token, "u1")
+	if err != nil || got.Status != domain.StatusReady {
+		t.Fatalf("Download after the job: %+v, %v", got, err)
+	}
+	a := readArchive(t, got.Archive)
+	if a.Profile.Email != "ada@example.com" || len(a.Identities) != 1 || len(a.Devices) != 1 || len(a.AuditEvents) != 1 {
+		t.Errorf("archive = %+v", a)
+	}
+	if a.Sessions == nil || a.AuditEventsTruncated {
+		t.Errorf("empty sections must be [] and audit events complete: %+v", a)
+	}
+	if FileName(got) != "data-export-u1-20260601T120000Z.json.gz" {
+		t.Errorf("FileName = %s", FileName(got))
+	}
+
+	now = now.Add(72 * time.Hour)
+	if _, err := svc.Download(ctx, token, "u1"); !errors.Is(err, ErrExportNotFound) {
+		t.Errorf("expired: want ErrExportNotFound, got %v", err)
+	}
+	if _, err := svc.RunOnce(ctx); err != nil || len(repo.exports) != 0 {
+		t.Errorf("RunOnce must delete expired exports: %v, %d left", err, len(repo.exports))
+	}
+}
+
+func TestService_RunOnceFailure(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &memRepo{recordsErr: errors.New("connection reset")}
+	svc := testService(repo, &now)
+	_, token, err := svc.Request(context.Background(), "u1", "admin")
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if n, err := svc.RunOnce(context.Background()); err != nil || n != 0 {
+		t.Fatalf("RunOnce = %d, %v; want 0 exports and no error", n, err)
+	}
+	got, err := svc.Download(context.Background(), token, "admin")
+	if err != nil || got.Status != domain.StatusFailed || got.Error != "connection reset" {
+		t.Errorf("failed export = %+v, %v", got, err)
+	}
+	if _, _, err := svc.Request(context.Background(), "u1", "admin"); err != nil {
+		t.Errorf("a failed export must not block a new request: %v", err)
+	}
+}
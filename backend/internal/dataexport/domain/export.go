@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// TokenPrefix starts every download token, so leaked tokens are easy to recognise.
+const TokenPrefix = "ztcp_dx_"
+
+// Status is the state of a data export.
+type Status string
+
+const (
+	StatusPending    Status = "pending"    // waiting for the data export job
+	StatusGenerating Status = "generating" // claimed by a data export job
+	StatusReady      Status = "ready"      // the archive can be downloaded
+	StatusFailed     Status = "failed"     // generation failed; request a new export
+)
+
+// Export is a requested archive of UserID's personal data. Only the hash of its download token is stored.
+type Export struct {
+	ID          string
+	UserID      string
+	RequestedBy string // the user themselves, or the platform admin who asked for it
+	OrgID       string // the user's home org, whose data region stores the export; empty for the primary database
+	TokenHash   string
+	Status      Status
+	Archive     []byte // gzipped JSON Archive; set when ready
+	Error       string // why generation failed; empty unless failed
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   time.Time
+}
+
+// Records are the user's rows read for an archive, each the JSON object of a row as Postgres renders it. Secrets
+// (password hashes, refresh token hashes) are left out.
+type Records struct {
+	Identities           []json.RawMessage
+	Memberships          []json.RawMessage
+	MembershipAttributes []json.RawMessage
+	GroupMemberships     []json.RawMessage
+	Devices              []json.RawMessage
+	Sessions             []json.RawMessage
+	Usernames            []json.RawMessage
+}
+
+// Profile is the user's own record, with encrypted PII decrypted.
+type Profile struct {
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	Name          string    `json:"name"`
+	Phone         string    `json:"phone"`
+	PhoneVerified bool      `json:"phone_verified"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AuditEvent is an audit log entry the user is the actor of.
+type AuditEvent struct {
+	ID        string    `json:"id"`
+	OrgID     string    `json:"org_id"`
+	Action    string    `json:"action"`
+	Resource  string    `json:"resource"`
+	IP        string    `json:"ip"`
+	Metadata  string    `json:"metadata,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Archive is the content of a data export.
+type Archive struct {
+	FormatVersion        int               `json:"format_version"`
+	UserID               string            `json:"user_id"`
+	GeneratedAt          time.Time         `json:"generated_at"`
+	Profile              Profile           `json:"profile"`
+	Identities           []json.RawMessage `json:"identities"`
+	Memberships          []json.RawMessage `json:"memberships"`
+	MembershipAttributes []json.RawMessage `json:"membership_attributes"`
+	GroupMemberships     []json.RawMessage `json:"group_memberships"`
+	Devices              []json.RawMessage `json:"devices"`
+	Sessions             []json.RawMessage `json:"sessions"`
+	Usernames            []json.RawMessage `json:"usernames"`
+	AuditEvents          []AuditEvent      `json:"audit_events"`
+	// AuditEventsTruncated is true when the user has more audit events than an archive holds; the latest are kept.
+	AuditEventsTruncated bool `json:"audit_events_truncated"`
+}
+
+// NewToken returns a new random download token and its hash.
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, security.HashToken(token), nil
+}
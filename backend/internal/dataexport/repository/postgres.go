@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/dataexport/domain"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/residency"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+	router  *residency.Router
+}
+
+// NewPostgresRepository returns a data export repository that uses the given db for persistence. When router is not
+// nil, an export is stored in the database of its org's data region, so the regional audit events in its archive do
+// not leave the region.
+func NewPostgresRepository(db *sql.DB, router *residency.Router) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), router: router}
+}
+
+// queriesFor returns the queries for the database holding orgID's exports; the primary database for "".
+func (r *PostgresRepository) queriesFor(ctx context.Context, orgID string) (*gen.Queries, error) {
+	if r.router == nil || orgID == "" {
+		return r.queries, nil
+	}
+	db, err := r.router.DB(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return gen.New(db), nil
+}
+
+// eachDatabase calls fn with the queries of every database holding exports.
+func (r *PostgresRepository) eachDatabase(fn func(q *gen.Queries) error) error {
+	if r.router == nil {
+		return fn(r.queries)
+	}
+	for _, db := range r.router.Databases() {
+		if err := fn(gen.New(db)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create persists a new pending export in the database of its org's data region.
+func (r *PostgresRepository) Create(ctx context.Context, e *domain.Export) error {
+	q, err := r.queriesFor(ctx, e.OrgID)
+	if err != nil {
+		return err
+	}
+	return q.CreateDataExport(ctx, gen.CreateDataExportParams{
+		ID:          e.ID,
+		UserID:      e.UserID,
+		RequestedBy: e.RequestedBy,
+		TokenHash:   e.TokenHash,
+		CreatedAt:   e.CreatedAt,
+		ExpiresAt:   e.ExpiresAt,
+		OrgID:       e.OrgID,
+	})
+}
+
+// HasActive reports whether userID has a pending or generating export in any database.
+func (r *PostgresRepository) HasActive(ctx context.Context, userID string) (bool, error) {
+	var total int64
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		n, err := q.CountActiveDataExportsByUser(ctx, userID)
+		total += n
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// GetByTokenHash returns the export from whichever database holds it, or nil if not found.
+func (r *PostgresRepository) GetByTokenHash(ctx context.Context, hash string) (*domain.Export, error) {
+	var row *gen.DataExport
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		if row != nil {
+			return nil
+		}
+		found, err := q.GetDataExportByTokenHash(ctx, hash)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		row = &found
+		return nil
+	})
+	if err != nil || row == nil {
+		return nil, err
+	}
+	e := &domain.Export{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		RequestedBy: row.RequestedBy,
+		OrgID:       row.OrgID,
+		TokenHash:   row.TokenHash,
+		Status:      domain.Status(row.Status),
+		Archive:     row.Archive,
+		Error:       row.Error,
+		CreatedAt:   row.CreatedAt,
+		ExpiresAt:   row.ExpiresAt,
+	}
+	if row.CompletedAt.Valid {
+		t := row.CompletedAt.Time
+		e.CompletedAt = &t
+	}
+	return e, nil
+}
+
+// Claim claims the next export to generate from the first database that has one, or returns nil when there is none.
+func (r *PostgresRepository) Claim(ctx context.Context, now, staleBefore time.Time) (*domain.Export, error) {
+	var out *domain.Export
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		if out != nil {
+			return nil
+		}
+		row, err := q.ClaimDataExport(ctx, gen.ClaimDataExportParams{Now: now, StaleBefore: staleBefore})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		out = &domain.Export{
+			ID:          row.ID,
+			UserID:      row.UserID,
+			RequestedBy: row.RequestedBy,
+			OrgID:       row.OrgID,
+			Status:      domain.StatusGenerating,
+			CreatedAt:   row.CreatedAt,
+			ExpiresAt:   row.ExpiresAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Finish stores the outcome of generating an export in the database of orgID's data region.
+func (r *PostgresRepository) Finish(ctx context.Context, orgID, id string, status domain.Status, archive []byte, reason string, now time.Time) error {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	return q.FinishDataExport(ctx, gen.FinishDataExportParams{
+		Status:      string(status),
+		Archive:     archive,
+		Error:       reason,
+		CompletedAt: sql.NullTime{Time: now, Valid: true},
+		ID:          id,
+	})
+}
+
+// DeleteExpired deletes expired exports in every database.
+func (r *PostgresRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var total int64
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		n, err := q.DeleteExpiredDataExports(ctx, now)
+		total += n
+		return err
+	})
+	return total, err
+}
+
+// HomeOrg returns the org of userID's first membership, or "" when the user has none.
+func (r *PostgresRepository) HomeOrg(ctx context.Context, userID string) (string, error) {
+	orgID, err := r.queries.GetDataExportHomeOrg(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return orgID, err
+}
+
+// Records reads the user's rows, one query per table.
+func (r *PostgresRepository) Records(ctx context.Context, userID string) (*domain.Records, error) {
+	rec := &domain.Records{}
+	reads := []struct {
+		dst  *[]json.RawMessage
+		list func(context.Context, string) ([]string, error)
+	}{
+		{&rec.Identities, r.queries.ListDataExportIdentities},
+		{&rec.Memberships, r.queries.ListDataExportMemberships},
+		{&rec.MembershipAttributes, r.queries.ListDataExportMembershipAttributes},
+		{&rec.GroupMemberships, r.queries.ListDataExportGroupMemberships},
+		{&rec.Devices, r.queries.ListDataExportDevices},
+		{&rec.Sessions, r.queries.ListDataExportSessions},
+		{&rec.Usernames, r.queries.ListDataExportUsernames},
+	}
+	for _, read := range reads {
+		rows, err := read.list(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		*read.dst = make([]json.RawMessage, len(rows))
+		for i, row := range rows {
+			(*read.dst)[i] = json.RawMessage(row)
+		}
+	}
+	return rec, nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/dataexport/domain"
+)
+
+// Repository persists data exports and reads the user data they hold. Exports are stored in the data region of their
+// OrgID; lookups by user or token, Claim and DeleteExpired cover every region.
+type Repository interface {
+	// Create persists a new pending export in the database of e.OrgID's data region. The export must have ID set.
+	Create(ctx context.Context, e *domain.Export) error
+	// HasActive reports whether userID has an export that is pending or being generated.
+	HasActive(ctx context.Context, userID string) (bool, error)
+	// GetByTokenHash returns the export with this download token hash, with its archive, or nil if not found.
+	GetByTokenHash(ctx context.Context, hash string) (*domain.Export, error)
+	// Claim marks the oldest pending export, or one claimed before staleBefore that never finished, as being
+	// generated at now and returns it without its archive; nil when there is none. Concurrent callers claim different
+	// exports.
+	Claim(ctx context.Context, now, staleBefore time.Time) (*domain.Export, error)
+	// Finish stores the outcome of generating an export of orgID: ready with its archive, or failed with the reason.
+	Finish(ctx context.Context, orgID, id string, status domain.Status, archive []byte, reason string, now time.Time) error
+	// DeleteExpired deletes the exports that expired at or before now, with their archives, and returns how many.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	// HomeOrg returns the org of userID's first membership, or "" when the user has none.
+	HomeOrg(ctx context.Context, userID string) (string, error)
+	// Records reads userID's identities, memberships, membership attributes, group memberships, devices, sessions and usernames.
+	Records(ctx context.Context, userID string) (*domain.Records, error)
+}
@@ -2,6 +2,7 @@
 package migrate
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -15,7 +16,8 @@ import (
 // ErrNoChange is returned when Up/Down has nothing to do (already at target version).
 var ErrNoChange = migrate.ErrNoChange
 
-// Run applies migrations in the given direction using the provided DSN.
+// Run applies migrations in the given direction using the provided DSN; a SQLite DSN gets the schema of the latest
+// migration at once (see runSQLite).
 // direction must be "up" or "down". Returns nil on success; ErrNoChange when already
 // at latest (up) or no migrations to downgrade (down); other errors for DB or I/O failures.
 func Run(dsn string, direction string) error {
@@ -25,6 +27,9 @@ func Run(dsn string, direction string) error {
 	if direction != "up" && direction != "down" {
 		return fmt.Errorf("direction must be up or down, got %q", direction)
 	}
+	if db.IsSQLite(dsn) {
+		return runSQLite(dsn, direction)
+	}
 
 	sourceDriver, err := iofs.New(db.MigrationFS, "migrations")
 	if err != nil {
@@ -49,3 +54,39 @@ func Run(dsn string, direction string) error {
 	}
 	return nil
 }
+
+// Version returns the migration version the database at dsn is at; 0 when no migration has been applied. Returns an
+// error when the last migration failed halfway (dirty), since the schema is then neither version.
+func Version(dsn string) (uint, error) {
+	if dsn == "" {
+		return 0, errors.New("DATABASE_URL is not set; create a .env from .env.example or set DATABASE_URL")
+	}
+	if db.IsSQLite(dsn) {
+		conn, err := db.Open(dsn)
+		if err != nil {
+			return 0, fmt.Errorf("migrate: %w", err)
+		}
+		defer conn.Close()
+		return sqliteVersion(context.Background(), conn)
+	}
+	sourceDriver, err := iofs.New(db.MigrationFS, "migrations")
+	if err != nil {
+		return 0, fmt.Errorf("migrate source: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", sourceDriver, dsn)
+	if err != nil {
+		return 0, fmt.Errorf("migrate: %w", err)
+	}
+	defer func() { _, _ = m.Close() }()
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("migrate: database is dirty at version %d; fix it before continuing", version)
+	}
+	return version, nil
+}
@@ -2,7 +2,10 @@ package migrate
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
+
+	"zero-trust-control-plane/backend/internal/db"
 )
 
 func TestRun_EmptyDSN(t *testing.T) {
@@ -188,3 +191,33 @@ func TestRun_DSNWithSpaces(t *testing.T) {
 		t.Error("Run with DSN containing spaces should return error")
 	}
 }
+
+func TestVersion_InvalidDSN(t *testing.T) {
+	if _, err := Version(""); err == nil {
+		t.Error("Version with empty DSN should return error")
+	}
+	if _, err := Version("invalid-dsn"); err == nil {
+		t.Error("Version with invalid DSN should return error")
+	}
+}
+
+func TestRun_SQLite(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "ztcp.db")
+	for i := 0; i < 2; i++ {
+		if err := Run(dsn, "up"); err != nil {
+			t.Fatalf("Run up (%d): %v", i+1, err)
+		}
+		if v, err := Version(dsn); err != nil || v != db.SQLiteSchemaVersion {
+			t.Fatalf("Version after up = %d, %v; want %d", v, err, db.SQLiteSchemaVersion)
+		}
+	}
+	if err := Run(dsn, "down"); err != nil {
+		t.Fatalf("Run down: %v", err)
+	}
+	if v, err := Version(dsn); err != nil || v != 0 {
+		t.Fatalf("Version after down = %d, %v; want 0", v, err)
+	}
+	if err := Run(dsn, "up"); err != nil {
+		t.Fatalf("Run up after down: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"zero-trust-control-plane/backend/internal/db"
+)
+
+// SQLite databases are not migrated step by step: up creates the schema of the latest migration (db.SQLiteSchema) in
+// one transaction, and down drops every table. The version is kept in schema_migrations, as golang-migrate does.
+
+// runSQLite is Run for a SQLite DSN.
+func runSQLite(dsn string, direction string) error {
+	conn, err := db.Open(dsn)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	version, err := sqliteVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if direction == "down" {
+		if version == 0 {
+			return nil
+		}
+		return dropSQLiteTables(ctx, conn)
+	}
+	switch version {
+	case db.SQLiteSchemaVersion:
+		return nil
+	case 0:
+	default:
+		return fmt.Errorf("migrate: sqlite database is at version %d, not %d; migrate down and up to recreate it", version, db.SQLiteSchemaVersion)
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, db.SQLiteSchema); err != nil {
+		return fmt.Errorf("migrate: create sqlite schema: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		"CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL);"+
+			"INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", db.SQLiteSchemaVersion); err != nil {
+		return fmt.Errorf("migrate: record sqlite version: %w", err)
+	}
+	return tx.Commit()
+}
+
+// sqliteVersion returns the version recorded in the SQLite database; 0 when the schema has not been created.
+func sqliteVersion(ctx context.Context, conn *sql.DB) (uint, error) {
+	var exists bool
+	if err := conn.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')").Scan(&exists); err != nil {
+		return 0, fmt.Errorf("migrate: read sqlite version: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+	var version uint
+	err := conn.QueryRowContext(ctx, "SELECT version FROM schema_migrations").Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("migrate: read sqlite version: %w", err)
+	}
+	return version, nil
+}
+
+// dropSQLiteTables drops every table, with foreign keys off so the order does not matter.
+func dropSQLiteTables(ctx context.Context, conn *sql.DB) error {
+	c, err := conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	rows, err := c.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		return err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if _, err := c.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return err
+	}
+	defer func() { _, _ = c.ExecContext(ctx, "PRAGMA foreign_keys = ON") }()
+	for _, table := range tables {
+		if _, err := c.ExecContext(ctx, `DROP TABLE "`+table+`"`); err != nil {
+			return fmt.Errorf("migrate: drop %s: %w", table, err)
+		}
+	}
+	return nil
+}
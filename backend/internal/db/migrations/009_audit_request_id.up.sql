@@ -0,0 +1,4 @@
+-- Request correlation ID (x-request-id) so one login attempt can be traced across audit rows and logs.
+ALTER TABLE audit_logs ADD COLUMN request_id VARCHAR;
+
+CREATE INDEX idx_audit_logs_request_id ON audit_logs(request_id);
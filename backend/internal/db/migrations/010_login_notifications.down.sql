@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS known_login_contexts;
+DROP TABLE IF EXISTS notification_preferences;
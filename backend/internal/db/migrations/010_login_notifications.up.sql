@@ -0,0 +1,16 @@
+-- Per-user notification preferences (login alerts opt-out).
+CREATE TABLE notification_preferences (
+    user_id              VARCHAR PRIMARY KEY REFERENCES users(id),
+    login_alerts_opt_out BOOLEAN NOT NULL DEFAULT false,
+    updated_at           TIMESTAMPTZ NOT NULL
+);
+
+-- Devices and locations a user has signed in from; used to detect "new sign-in" events.
+CREATE TABLE known_login_contexts (
+    user_id       VARCHAR NOT NULL REFERENCES users(id),
+    kind          VARCHAR NOT NULL, -- device, location
+    value         VARCHAR NOT NULL,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, kind, value)
+);
@@ -0,0 +1,16 @@
+-- Per-user security activity feed (failed logins, token reuse, impossible travel, device revocations).
+-- org_id has no FK: failed logins may name an org that does not exist.
+CREATE TABLE security_events (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR,
+    user_id         VARCHAR NOT NULL REFERENCES users(id),
+    event_type      VARCHAR NOT NULL,
+    severity        VARCHAR NOT NULL,
+    ip              VARCHAR NOT NULL,
+    metadata        TEXT,
+    created_at      TIMESTAMPTZ NOT NULL,
+    acknowledged_at TIMESTAMPTZ,
+    dismissed_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_security_events_user_created ON security_events(user_id, created_at DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_sessions_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP TABLE IF EXISTS analytics_daily_country_sessions;
+DROP TABLE IF EXISTS analytics_daily_device_sessions;
+DROP TABLE IF EXISTS analytics_daily_logins;
+ALTER TABLE sessions DROP COLUMN IF EXISTS country;
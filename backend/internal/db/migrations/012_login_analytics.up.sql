@@ -0,0 +1,37 @@
+-- Country of the client at sign-in (from edge geo headers); NULL when unknown.
+ALTER TABLE sessions ADD COLUMN country VARCHAR;
+
+-- Daily per-org login rollups for AnalyticsService, recomputed by the analytics rollup job from audit_logs.
+CREATE TABLE analytics_daily_logins (
+    org_id           VARCHAR NOT NULL,
+    day              DATE NOT NULL,
+    login_successes  BIGINT NOT NULL,
+    login_failures   BIGINT NOT NULL,
+    mfa_challenges   BIGINT NOT NULL,
+    sessions_created BIGINT NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day)
+);
+
+-- Daily per-org session counts by device, recomputed from sessions.
+CREATE TABLE analytics_daily_device_sessions (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    device_id  VARCHAR NOT NULL,
+    sessions   BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, device_id)
+);
+
+-- Daily per-org session counts by country, recomputed from sessions.
+CREATE TABLE analytics_daily_country_sessions (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    country    VARCHAR NOT NULL,
+    sessions   BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, country)
+);
+
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+CREATE INDEX idx_sessions_created_at ON sessions(created_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_audit_logs_action_created_at;
+DROP TABLE IF EXISTS ip_blocks;
@@ -0,0 +1,10 @@
+-- Temporary login blocks for client IPs, created by the anomaly detector (cmd/detector) when auto-blocking is enabled.
+CREATE TABLE ip_blocks (
+    ip         VARCHAR PRIMARY KEY,
+    reason     VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- Detector scans recent login failures by action and time.
+CREATE INDEX idx_audit_logs_action_created_at ON audit_logs(action, created_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS analytics_daily_policy_violations;
+DROP INDEX IF EXISTS idx_policy_violations_created_at;
+DROP INDEX IF EXISTS idx_policy_violations_org_created;
+DROP TABLE IF EXISTS policy_violations;
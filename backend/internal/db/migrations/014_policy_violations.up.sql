@@ -0,0 +1,25 @@
+-- Actions blocked by browser agents under the org's action_restrictions (PolicyViolationService).
+CREATE TABLE policy_violations (
+    id                VARCHAR PRIMARY KEY,
+    org_id            VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id           VARCHAR NOT NULL REFERENCES users(id),
+    device_id         VARCHAR NOT NULL,
+    session_id        VARCHAR NOT NULL,
+    action            VARCHAR NOT NULL,
+    target            TEXT,
+    step_up_triggered BOOLEAN NOT NULL DEFAULT false,
+    created_at        TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_policy_violations_org_created ON policy_violations(org_id, created_at DESC);
+CREATE INDEX idx_policy_violations_created_at ON policy_violations(created_at);
+
+-- Daily per-org violation counts by action, recomputed by the analytics rollup job from policy_violations.
+CREATE TABLE analytics_daily_policy_violations (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    action     VARCHAR NOT NULL,
+    violations BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, action)
+);
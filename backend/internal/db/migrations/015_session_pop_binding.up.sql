@@ -0,0 +1,2 @@
+-- RFC 7638 thumbprint of the client key a session's refresh tokens are bound to; NULL for bearer sessions.
+ALTER TABLE sessions ADD COLUMN pop_jkt VARCHAR;
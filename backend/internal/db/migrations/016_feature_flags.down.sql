@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS feature_flag_org_overrides;
+DROP TABLE IF EXISTS feature_flags;
@@ -0,0 +1,19 @@
+-- Feature flags for gradual rollouts (FeatureFlagService). A flag is on for an org when the org has an override
+-- that says so, or the flag is enabled and the org falls within rollout_percentage.
+CREATE TABLE feature_flags (
+    key                VARCHAR PRIMARY KEY,
+    description        TEXT NOT NULL DEFAULT '',
+    enabled            BOOLEAN NOT NULL DEFAULT false,
+    rollout_percentage INTEGER NOT NULL DEFAULT 0 CHECK (rollout_percentage BETWEEN 0 AND 100),
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL
+);
+
+-- Per-org overrides win over enabled and rollout_percentage.
+CREATE TABLE feature_flag_org_overrides (
+    flag_key   VARCHAR NOT NULL REFERENCES feature_flags(key) ON DELETE CASCADE,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    enabled    BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (flag_key, org_id)
+);
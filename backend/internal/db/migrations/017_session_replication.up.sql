@@ -0,0 +1,9 @@
+-- Session revocation replication (active-active regions). Each region's consumer records the latest event it has
+-- received per origin region; strict consistency rejects requests when no event or heartbeat arrived recently.
+CREATE TABLE session_replication_watermarks (
+    origin_region VARCHAR PRIMARY KEY,
+    last_event_at TIMESTAMPTZ NOT NULL,
+    received_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
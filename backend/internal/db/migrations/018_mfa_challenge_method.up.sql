@@ -0,0 +1,2 @@
+-- MFA challenges record the method that issued them (sms_otp, ...) so VerifyMFA checks the code with that method.
+ALTER TABLE mfa_challenges ADD COLUMN method VARCHAR NOT NULL DEFAULT 'sms_otp';
@@ -0,0 +1,9 @@
+-- One-time MFA recovery codes. Only SHA-256 hashes are stored; a code is consumed by setting used_at.
+CREATE TABLE mfa_recovery_codes (
+    id         VARCHAR PRIMARY KEY,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    code_hash  VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE mfa_challenges DROP COLUMN IF EXISTS last_sent_at;
+ALTER TABLE mfa_challenges DROP COLUMN IF EXISTS resend_count;
+ALTER TABLE mfa_challenges DROP COLUMN IF EXISTS attempts;
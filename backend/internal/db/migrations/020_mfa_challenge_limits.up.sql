@@ -0,0 +1,5 @@
+-- MFA challenges count wrong codes (the challenge is invalidated after too many) and resends (capped, with a cooldown
+-- from last_sent_at; NULL means the code has only been sent at created_at).
+ALTER TABLE mfa_challenges ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE mfa_challenges ADD COLUMN resend_count INT NOT NULL DEFAULT 0;
+ALTER TABLE mfa_challenges ADD COLUMN last_sent_at TIMESTAMPTZ;
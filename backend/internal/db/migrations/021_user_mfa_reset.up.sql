@@ -0,0 +1,3 @@
+-- Set when an admin resets the user's MFA; the next sign-in requires MFA (re-enrolling a phone) until the user
+-- verifies a new phone.
+ALTER TABLE users ADD COLUMN mfa_reset_required BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS mfa_method;
+ALTER TABLE sessions DROP COLUMN IF EXISTS auth_method;
+ALTER TABLE sessions DROP COLUMN IF EXISTS client_version;
+ALTER TABLE sessions DROP COLUMN IF EXISTS user_agent;
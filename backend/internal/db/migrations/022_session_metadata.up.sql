@@ -0,0 +1,5 @@
+-- Client and authentication details captured when a session is created, shown to admins by ListSessions.
+ALTER TABLE sessions ADD COLUMN user_agent VARCHAR;
+ALTER TABLE sessions ADD COLUMN client_version VARCHAR;
+ALTER TABLE sessions ADD COLUMN auth_method VARCHAR; -- primary factor, e.g. password
+ALTER TABLE sessions ADD COLUMN mfa_method VARCHAR;  -- second factor (e.g. sms_otp, recovery_code); NULL when none was used
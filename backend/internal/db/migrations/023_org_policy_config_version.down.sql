@@ -0,0 +1 @@
+ALTER TABLE org_policy_config DROP COLUMN IF EXISTS version;
@@ -0,0 +1,3 @@
+-- Org policy config version, bumped on every write. UpdateOrgPolicyConfig exposes it as an etag for optimistic
+-- concurrency; 0 is reserved for an org with no stored config.
+ALTER TABLE org_policy_config ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,17 @@
+-- Org policy config history: one row per stored version, written with the config in the same transaction.
+-- Backs ListPolicyConfigHistory and RollbackPolicyConfig.
+CREATE TABLE org_policy_config_versions (
+    org_id           VARCHAR NOT NULL REFERENCES organizations(id),
+    version          BIGINT NOT NULL,
+    config_json      TEXT NOT NULL,
+    changed_by       VARCHAR,          -- user id of the admin who made the change
+    changed_at       TIMESTAMPTZ NOT NULL,
+    source           VARCHAR NOT NULL, -- update, bulk_update_domains, rollback
+    restored_version BIGINT,           -- for rollback, the version that was restored
+    PRIMARY KEY (org_id, version)
+);
+
+-- Existing configs become the first recorded version; their author is unknown.
+INSERT INTO org_policy_config_versions (org_id, version, config_json, changed_by, changed_at, source)
+SELECT org_id, version, config_json, NULL, updated_at, 'update'
+FROM org_policy_config;
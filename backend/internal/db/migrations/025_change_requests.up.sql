@@ -0,0 +1,17 @@
+-- Four-eyes change requests: proposed org policy config or Rego policy changes, applied only when a second admin
+-- approves. Backs ChangeRequestService.
+CREATE TABLE change_requests (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    kind           VARCHAR NOT NULL, -- org_policy_config, policy
+    status         VARCHAR NOT NULL, -- pending, approved, rejected
+    proposed_by    VARCHAR NOT NULL REFERENCES users(id),
+    reason         TEXT NOT NULL DEFAULT '',
+    payload_json   TEXT NOT NULL,    -- the proposed change; shape depends on kind
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_change_requests_org_created ON change_requests(org_id, created_at DESC);
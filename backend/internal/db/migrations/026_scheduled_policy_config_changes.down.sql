@@ -0,0 +1 @@
+DROP TABLE IF EXISTS scheduled_policy_config_changes;
@@ -0,0 +1,19 @@
+-- Org policy config updates scheduled to take effect at a future time. The server's scheduler applies pending rows
+-- once effective_at has passed. Backs UpdateOrgPolicyConfig.effective_at and the scheduled change RPCs.
+CREATE TABLE scheduled_policy_config_changes (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR NOT NULL REFERENCES organizations(id),
+    config_json     TEXT NOT NULL,               -- the update, as in UpdateOrgPolicyConfigRequest.config
+    update_mask     TEXT NOT NULL DEFAULT '',    -- comma-separated section names; empty replaces the whole config
+    effective_at    TIMESTAMPTZ NOT NULL,
+    status          VARCHAR NOT NULL,            -- pending, applied, cancelled, failed
+    created_by      VARCHAR NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMPTZ NOT NULL,
+    resolved_by     VARCHAR REFERENCES users(id), -- admin who cancelled it
+    resolved_at     TIMESTAMPTZ,                 -- when it was applied, cancelled or failed
+    applied_version BIGINT,                      -- org_policy_config version it was stored as
+    error           TEXT NOT NULL DEFAULT ''     -- why it could not be applied
+);
+
+CREATE INDEX idx_scheduled_policy_config_changes_org ON scheduled_policy_config_changes(org_id, effective_at);
+CREATE INDEX idx_scheduled_policy_config_changes_due ON scheduled_policy_config_changes(effective_at) WHERE status = 'pending';
@@ -0,0 +1,24 @@
+CREATE TABLE audit_logs_unpartitioned (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id    VARCHAR REFERENCES users(id),
+    action     VARCHAR NOT NULL,
+    resource   VARCHAR NOT NULL,
+    ip         VARCHAR NOT NULL,
+    metadata   TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    request_id VARCHAR
+);
+
+INSERT INTO audit_logs_unpartitioned (id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id FROM audit_logs;
+
+DROP TABLE audit_logs;
+DROP FUNCTION IF EXISTS audit_logs_drop_partitions_before(TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS audit_logs_create_partition(DATE);
+
+ALTER TABLE audit_logs_unpartitioned RENAME TO audit_logs;
+ALTER INDEX audit_logs_unpartitioned_pkey RENAME TO audit_logs_pkey;
+CREATE INDEX idx_audit_logs_request_id ON audit_logs(request_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+CREATE INDEX idx_audit_logs_action_created_at ON audit_logs(action, created_at);
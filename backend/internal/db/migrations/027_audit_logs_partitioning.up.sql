@@ -0,0 +1,89 @@
+-- Partition audit_logs by UTC month of created_at, so time-range queries only scan the months they cover and
+-- expired months are dropped whole instead of deleted row by row. Partitions are named audit_logs_pYYYYMM; rows
+-- outside every monthly partition land in audit_logs_default. The server's partition job calls the functions below
+-- to create upcoming months and to drop months past AUDIT_RETENTION_DAYS.
+ALTER TABLE audit_logs RENAME TO audit_logs_unpartitioned;
+ALTER INDEX audit_logs_pkey RENAME TO audit_logs_unpartitioned_pkey;
+DROP INDEX idx_audit_logs_request_id;
+DROP INDEX idx_audit_logs_created_at;
+DROP INDEX idx_audit_logs_action_created_at;
+
+-- The partition key must be part of the primary key; ids stay unique as UUIDs.
+CREATE TABLE audit_logs (
+    id         VARCHAR NOT NULL,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id    VARCHAR REFERENCES users(id),
+    action     VARCHAR NOT NULL,
+    resource   VARCHAR NOT NULL,
+    ip         VARCHAR NOT NULL,
+    metadata   TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    request_id VARCHAR,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX idx_audit_logs_request_id ON audit_logs(request_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+CREATE INDEX idx_audit_logs_action_created_at ON audit_logs(action, created_at);
+CREATE INDEX idx_audit_logs_org_created_at ON audit_logs(org_id, created_at);
+
+CREATE TABLE audit_logs_default PARTITION OF audit_logs DEFAULT;
+
+-- audit_logs_create_partition creates the partition for the UTC month containing month, moving any rows of that
+-- month out of audit_logs_default first. Returns false when the partition already exists.
+CREATE FUNCTION audit_logs_create_partition(month DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    start_at TIMESTAMPTZ := date_trunc('month', month::timestamp) AT TIME ZONE 'UTC';
+    end_at   TIMESTAMPTZ := (date_trunc('month', month::timestamp) + INTERVAL '1 month') AT TIME ZONE 'UTC';
+    name     TEXT := 'audit_logs_p' || to_char(month, 'YYYYMM');
+BEGIN
+    IF to_regclass(name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+    EXECUTE format('CREATE TABLE %I (LIKE audit_logs INCLUDING DEFAULTS)', name);
+    EXECUTE format('WITH moved AS (DELETE FROM audit_logs_default WHERE created_at >= %L AND created_at < %L RETURNING *) '
+                   'INSERT INTO %I SELECT * FROM moved', start_at, end_at, name);
+    EXECUTE format('ALTER TABLE audit_logs ATTACH PARTITION %I FOR VALUES FROM (%L) TO (%L)', name, start_at, end_at);
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+-- audit_logs_drop_partitions_before drops the monthly partitions that end at or before cutoff, deletes older rows
+-- from audit_logs_default, and returns the names of the dropped partitions.
+CREATE FUNCTION audit_logs_drop_partitions_before(cutoff TIMESTAMPTZ) RETURNS SETOF TEXT AS $$
+DECLARE
+    part TEXT;
+BEGIN
+    FOR part IN
+        SELECT c.relname::text
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        WHERE i.inhparent = 'audit_logs'::regclass
+          AND c.relname ~ '^audit_logs_p[0-9]{6}$'
+          AND (to_date(substr(c.relname, 13), 'YYYYMM')::timestamp + INTERVAL '1 month') AT TIME ZONE 'UTC' <= cutoff
+        ORDER BY c.relname
+    LOOP
+        EXECUTE format('DROP TABLE %I', part);
+        RETURN NEXT part;
+    END LOOP;
+    DELETE FROM audit_logs_default WHERE created_at < cutoff;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create a partition for every month with existing entries, plus the current and next two months, then move the
+-- entries over.
+DO $$
+DECLARE
+    m DATE := date_trunc('month', COALESCE((SELECT MIN(created_at) FROM audit_logs_unpartitioned), now()) AT TIME ZONE 'UTC')::date;
+BEGIN
+    WHILE m <= (date_trunc('month', now() AT TIME ZONE 'UTC') + INTERVAL '2 months')::date LOOP
+        PERFORM audit_logs_create_partition(m);
+        m := (m + INTERVAL '1 month')::date;
+    END LOOP;
+END;
+$$;
+
+INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id FROM audit_logs_unpartitioned;
+
+DROP TABLE audit_logs_unpartitioned;
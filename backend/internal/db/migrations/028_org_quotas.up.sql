@@ -0,0 +1,10 @@
+-- Per-org API quota assignments: the org's plan and optional per-org overrides of the plan's quotas. Orgs without a
+-- row use the default plan (QUOTA_DEFAULT_PLAN). Managed with AdminService SetOrgQuota.
+CREATE TABLE org_quotas (
+    org_id                    VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    plan                      VARCHAR NOT NULL DEFAULT '', -- plan name from QUOTA_PLANS; empty uses the default plan
+    org_requests_per_minute   INTEGER,                     -- overrides the plan's org quota; 0 = unlimited
+    token_requests_per_minute INTEGER,                     -- overrides the plan's per-session quota; 0 = unlimited
+    updated_by                VARCHAR NOT NULL REFERENCES users(id),
+    updated_at                TIMESTAMPTZ NOT NULL
+);
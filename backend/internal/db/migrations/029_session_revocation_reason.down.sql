@@ -0,0 +1,2 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS revoked_by;
+ALTER TABLE sessions DROP COLUMN IF EXISTS revocation_reason;
@@ -0,0 +1,4 @@
+-- Why and by whom a session was revoked, for investigations. NULL for active sessions and for sessions revoked
+-- before this migration.
+ALTER TABLE sessions ADD COLUMN revocation_reason VARCHAR; -- logout, admin_revoke, reuse_detected, policy_change or idle_timeout
+ALTER TABLE sessions ADD COLUMN revoked_by VARCHAR;        -- user ID of the actor; NULL when the system revoked the session
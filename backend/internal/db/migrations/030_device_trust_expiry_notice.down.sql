@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_devices_trusted_until;
+ALTER TABLE devices DROP COLUMN IF EXISTS trust_expiry_notified_at;
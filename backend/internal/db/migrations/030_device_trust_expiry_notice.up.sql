@@ -0,0 +1,6 @@
+-- When the pre-expiry notice for the device's current trust period was sent; cleared whenever trust is granted
+-- or renewed, so each trust period is notified at most once.
+ALTER TABLE devices ADD COLUMN trust_expiry_notified_at TIMESTAMPTZ;
+
+-- Trusted devices by expiry, for the trust expiry notice job.
+CREATE INDEX idx_devices_trusted_until ON devices (trusted_until, id) WHERE trusted = true AND revoked_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_email_hash;
+ALTER TABLE users DROP COLUMN IF EXISTS email_hash;
+DROP INDEX IF EXISTS idx_data_keys_active_scope;
+DROP TABLE IF EXISTS data_keys;
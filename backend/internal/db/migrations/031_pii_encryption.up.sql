@@ -0,0 +1,19 @@
+-- Data encryption keys for PII columns (envelope encryption). Each key is a random AES-256 key wrapped (encrypted)
+-- with the master key from PII_MASTER_KEY; scope is "platform" (users), "blind_index" (email lookup hashes) or an
+-- org ID (org-owned rows). Retired keys are kept so older ciphertext stays readable until it is re-encrypted.
+CREATE TABLE data_keys (
+    id            VARCHAR PRIMARY KEY,
+    scope         VARCHAR NOT NULL,
+    wrapped_key   BYTEA NOT NULL,
+    master_key_id VARCHAR NOT NULL, -- identifies the master key that wrapped wrapped_key
+    created_at    TIMESTAMPTZ NOT NULL,
+    retired_at    TIMESTAMPTZ      -- no longer used for new ciphertext
+);
+
+-- At most one active key per scope.
+CREATE UNIQUE INDEX idx_data_keys_active_scope ON data_keys (scope) WHERE retired_at IS NULL;
+
+-- Keyed hash of the email, so users can be found by email while users.email is encrypted. NULL for rows written
+-- without encryption, which are still looked up by the plaintext email.
+ALTER TABLE users ADD COLUMN email_hash VARCHAR;
+CREATE UNIQUE INDEX idx_users_email_hash ON users (email_hash) WHERE email_hash IS NOT NULL;
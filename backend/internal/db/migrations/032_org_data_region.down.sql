@@ -0,0 +1,5 @@
+ALTER TABLE policy_violations ADD CONSTRAINT policy_violations_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE policy_violations ADD CONSTRAINT policy_violations_org_id_fkey FOREIGN KEY (org_id) REFERENCES organizations(id);
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_org_id_fkey FOREIGN KEY (org_id) REFERENCES organizations(id);
+ALTER TABLE organizations DROP COLUMN IF EXISTS data_region;
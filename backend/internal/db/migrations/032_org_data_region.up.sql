@@ -0,0 +1,10 @@
+-- Data residency: the region whose database stores the org's audit logs and policy violations ('' = the primary
+-- database). Set when the org is created; see DATA_REGION_DSNS.
+ALTER TABLE organizations ADD COLUMN data_region VARCHAR NOT NULL DEFAULT '';
+
+-- Rows of orgs with a data region live in a regional database that holds no organizations or users rows, so these
+-- tables cannot reference them.
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_org_id_fkey;
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_user_id_fkey;
+ALTER TABLE policy_violations DROP CONSTRAINT IF EXISTS policy_violations_org_id_fkey;
+ALTER TABLE policy_violations DROP CONSTRAINT IF EXISTS policy_violations_user_id_fkey;
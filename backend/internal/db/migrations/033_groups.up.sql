@@ -0,0 +1,18 @@
+-- Groups: named sets of an org's users. A group admin (group_members.role = 'admin') may manage the group's users'
+-- memberships, sessions and devices without being an org admin.
+CREATE TABLE groups (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    name       VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (org_id, name)
+);
+
+CREATE TABLE group_members (
+    group_id   VARCHAR NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    role       VARCHAR NOT NULL DEFAULT 'member', -- member or admin
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+CREATE INDEX idx_group_members_user_id ON group_members(user_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_privilege_elevations_approved_expires;
+DROP INDEX IF EXISTS idx_privilege_elevations_user_org;
+DROP INDEX IF EXISTS idx_privilege_elevations_org_created;
+DROP TABLE IF EXISTS privilege_elevations;
@@ -0,0 +1,21 @@
+-- Just-in-time privilege elevation: a member asks for temporary admin rights with a justification, an org owner
+-- approves, and the elevation ends at expires_at (or when revoked). Backs ElevationService.
+CREATE TABLE privilege_elevations (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    status         VARCHAR NOT NULL, -- pending, approved, rejected, revoked, expired
+    justification  TEXT NOT NULL,
+    duration_secs  INTEGER NOT NULL, -- requested window; starts at approval
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    expires_at     TIMESTAMPTZ,      -- set on approval
+    revoked_by     VARCHAR REFERENCES users(id),
+    revoked_at     TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_privilege_elevations_org_created ON privilege_elevations(org_id, created_at DESC);
+CREATE INDEX idx_privilege_elevations_user_org ON privilege_elevations(user_id, org_id) WHERE status IN ('pending', 'approved');
+CREATE INDEX idx_privilege_elevations_approved_expires ON privilege_elevations(expires_at) WHERE status = 'approved';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_break_glass_activations_active_expires;
+DROP INDEX IF EXISTS idx_break_glass_activations_org_requested;
+DROP TABLE IF EXISTS break_glass_activations;
+DROP TABLE IF EXISTS break_glass_accounts;
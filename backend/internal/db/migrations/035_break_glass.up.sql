@@ -0,0 +1,32 @@
+-- Break-glass emergency access: one platform-managed account per org whose credential is sealed until two platform
+-- admins unlock it (dual control). Each unlock is an activation that must be closed with a post-incident report.
+-- Backs BreakGlassService.
+CREATE TABLE break_glass_accounts (
+    org_id         VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    device_id      VARCHAR NOT NULL REFERENCES devices(id),
+    secret_hash    VARCHAR NOT NULL, -- SHA-256 of the credential; the credential is shown once at provisioning
+    provisioned_by VARCHAR NOT NULL REFERENCES users(id),
+    provisioned_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE break_glass_activations (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id), -- the break-glass account's user
+    status         VARCHAR NOT NULL,                      -- pending, active, ended, closed
+    reason         TEXT NOT NULL,
+    requested_by   VARCHAR NOT NULL REFERENCES users(id),
+    requested_at   TIMESTAMPTZ NOT NULL,
+    approved_by    VARCHAR REFERENCES users(id),
+    approved_at    TIMESTAMPTZ,
+    expires_at     TIMESTAMPTZ,                           -- end of the unlock window; set on approval
+    ended_by       VARCHAR REFERENCES users(id),          -- empty when the window ran out
+    ended_at       TIMESTAMPTZ,
+    report_summary TEXT NOT NULL DEFAULT '',
+    reported_by    VARCHAR REFERENCES users(id),
+    reported_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_break_glass_activations_org_requested ON break_glass_activations(org_id, requested_at DESC);
+CREATE INDEX idx_break_glass_activations_active_expires ON break_glass_activations(expires_at) WHERE status = 'active';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_login_holds_org_pending;
+DROP TABLE IF EXISTS login_holds;
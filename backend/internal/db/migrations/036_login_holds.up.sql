@@ -0,0 +1,19 @@
+-- Login holds: sign-ins flagged as high-risk (e.g. from an IP blocked by the anomaly detector) wait for an org admin
+-- to approve or deny them instead of being rejected outright. Backs AuthService ResumeLogin, ApproveLogin, DenyLogin
+-- and ListLoginHolds.
+CREATE TABLE login_holds (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    device_id  VARCHAR NOT NULL REFERENCES devices(id),
+    token_hash VARCHAR NOT NULL,              -- SHA-256 of the hold token; only the client that signed in has the token
+    reasons    VARCHAR NOT NULL,              -- comma-separated risk signals, e.g. ip_blocked
+    ip         VARCHAR NOT NULL DEFAULT '',
+    status     VARCHAR NOT NULL,              -- pending, approved, denied, completed
+    decided_by VARCHAR REFERENCES users(id),  -- org admin who approved or denied
+    decided_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL           -- pending: deadline for a decision; approved: deadline for ResumeLogin
+);
+
+CREATE INDEX idx_login_holds_org_pending ON login_holds(org_id, created_at DESC) WHERE status = 'pending';
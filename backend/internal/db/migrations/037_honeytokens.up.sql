@@ -0,0 +1,11 @@
+-- Honeytokens: decoy accounts whose credentials are planted where an attacker would find them. Every sign-in
+-- attempt against one is rejected and raises an alert, an early warning of credential stuffing or a leak. Backs
+-- AdminService MarkHoneytoken, UnmarkHoneytoken and ListHoneytokens.
+CREATE TABLE honeytokens (
+    user_id           VARCHAR PRIMARY KEY REFERENCES users(id),
+    label             VARCHAR NOT NULL DEFAULT '',  -- where the credential was planted, e.g. "wiki backup page"
+    created_by        VARCHAR NOT NULL REFERENCES users(id),
+    created_at        TIMESTAMPTZ NOT NULL,
+    trigger_count     BIGINT NOT NULL DEFAULT 0,
+    last_triggered_at TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_device_codes_user_code_pending;
+DROP TABLE IF EXISTS device_codes;
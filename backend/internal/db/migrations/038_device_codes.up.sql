@@ -0,0 +1,20 @@
+-- Device codes: cross-device sign-in for clients that cannot type a password or receive an SMS (CLIs, kiosks). The
+-- client gets a device code and a short user code; the user enters the user code (or scans it as a QR code) on an
+-- already-trusted session and approves it, and the client polls until it gets tokens. Backs AuthService
+-- StartDeviceAuthorization, PollDeviceAuthorization, ApproveDeviceCode and DenyDeviceCode.
+CREATE TABLE device_codes (
+    id                 VARCHAR PRIMARY KEY,
+    device_code_hash   VARCHAR NOT NULL UNIQUE,       -- SHA-256 of the device code; only the polling client has the code
+    user_code          VARCHAR NOT NULL,              -- normalized (upper case, no dash); shown to the user as XXXX-XXXX
+    client_name        VARCHAR NOT NULL DEFAULT '',   -- as reported by the client, e.g. "ztcp-cli on build-01"
+    device_fingerprint VARCHAR NOT NULL DEFAULT '',
+    ip                 VARCHAR NOT NULL DEFAULT '',
+    status             VARCHAR NOT NULL,              -- pending, approved, denied, completed
+    user_id            VARCHAR REFERENCES users(id),  -- who approved or denied; the client signs in as them
+    org_id             VARCHAR REFERENCES organizations(id), -- org of the approving session
+    decided_at         TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL,
+    expires_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_device_codes_user_code_pending ON device_codes(user_code) WHERE status = 'pending';
@@ -0,0 +1,13 @@
+-- Magic links: passwordless sign-in by email for orgs that enable auth_mfa.magic_link_enabled. RequestMagicLink
+-- emails a one-time link; CompleteMagicLink redeems it once, before expires_at, and continues as Login would
+-- (org access policy, device trust and MFA policy, session).
+CREATE TABLE magic_links (
+    id          VARCHAR PRIMARY KEY,
+    token_hash  VARCHAR NOT NULL UNIQUE,            -- SHA-256 of the link token; the token is only in the email
+    user_id     VARCHAR NOT NULL REFERENCES users(id),
+    org_id      VARCHAR NOT NULL REFERENCES organizations(id),
+    ip          VARCHAR NOT NULL DEFAULT '',        -- client IP of RequestMagicLink
+    created_at  TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    used_at     TIMESTAMPTZ                         -- set when redeemed; a link is redeemed at most once
+);
@@ -0,0 +1,17 @@
+-- Org domains: email domains an org has claimed. StartDomainVerification issues a token to publish as a DNS TXT
+-- record; VerifyDomain looks the record up and marks the domain verified. Features that act on every address of a
+-- domain (auto-join, SSO configuration) must require a verified domain.
+CREATE TABLE org_domains (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR NOT NULL REFERENCES organizations(id),
+    domain          VARCHAR NOT NULL,                   -- lower case, no trailing dot
+    token           VARCHAR NOT NULL,                   -- published as ztcp-domain-verification=<token>
+    status          VARCHAR NOT NULL DEFAULT 'pending', -- pending, verified
+    created_by      VARCHAR NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMPTZ NOT NULL,
+    verified_at     TIMESTAMPTZ,
+    last_checked_at TIMESTAMPTZ,                        -- last VerifyDomain lookup, successful or not
+    UNIQUE (org_id, domain)
+);
+-- A domain can be verified by one org only.
+CREATE UNIQUE INDEX idx_org_domains_verified ON org_domains(domain) WHERE status = 'verified';
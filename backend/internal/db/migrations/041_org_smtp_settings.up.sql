@@ -0,0 +1,13 @@
+-- Org SMTP settings: an org's own mail server for the emails sent to its users (login alerts, trust expiry notices,
+-- magic links). Orgs without settings use the platform SMTP server. The password is not stored: password_secret is a
+-- reference into the secrets provider.
+CREATE TABLE org_smtp_settings (
+    org_id          VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    host            VARCHAR NOT NULL,
+    port            INTEGER NOT NULL,
+    username        VARCHAR NOT NULL DEFAULT '',
+    password_secret VARCHAR NOT NULL DEFAULT '',   -- secrets provider reference; empty when the server needs no password
+    from_address    VARCHAR NOT NULL,
+    updated_by      VARCHAR NOT NULL REFERENCES users(id),
+    updated_at      TIMESTAMPTZ NOT NULL
+);
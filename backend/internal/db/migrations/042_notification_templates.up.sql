@@ -0,0 +1,15 @@
+-- Notification templates: org overrides of the built-in texts of user notifications (login alerts, OTP SMS), per
+-- locale. locale '' is the org's template for users whose locale has no template of its own.
+CREATE TABLE notification_templates (
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    kind       VARCHAR NOT NULL,
+    locale     VARCHAR NOT NULL DEFAULT '',
+    subject    VARCHAR NOT NULL DEFAULT '',   -- email subject; empty for SMS-only kinds
+    body       VARCHAR NOT NULL,
+    updated_by VARCHAR NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, kind, locale)
+);
+
+-- The user's locale (e.g. "de", "pt-BR") picks the template; empty uses the org's default template.
+ALTER TABLE notification_preferences ADD COLUMN locale VARCHAR NOT NULL DEFAULT '';
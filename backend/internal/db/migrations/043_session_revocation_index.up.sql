@@ -0,0 +1,2 @@
+-- Serves SessionService.SubscribeRevocations, which polls each org's sessions by revocation time.
+CREATE INDEX idx_sessions_org_revoked_at ON sessions(org_id, revoked_at) WHERE revoked_at IS NOT NULL;
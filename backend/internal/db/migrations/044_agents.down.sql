@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_agents_silent;
+DROP INDEX IF EXISTS idx_agents_org_heartbeat;
+DROP TABLE IF EXISTS agents;
@@ -0,0 +1,18 @@
+-- Agents: browser and endpoint agents registered on devices, with their last heartbeat (version, posture summary and
+-- the org policy config version in effect). Backs AgentService; devices whose agent stops reporting lose their trust.
+CREATE TABLE agents (
+    id                VARCHAR PRIMARY KEY,
+    org_id            VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id           VARCHAR NOT NULL REFERENCES users(id),     -- user of the session that last registered the agent
+    device_id         VARCHAR NOT NULL UNIQUE REFERENCES devices(id),
+    platform          VARCHAR NOT NULL,
+    version           VARCHAR NOT NULL,
+    posture_json      TEXT NOT NULL DEFAULT '{}',                -- posture summary as a JSON object of strings
+    policy_version    BIGINT NOT NULL DEFAULT 0,                 -- org policy config version in effect; 0 if unknown
+    registered_at     TIMESTAMPTZ NOT NULL,
+    last_heartbeat_at TIMESTAMPTZ NOT NULL,
+    trust_degraded_at TIMESTAMPTZ                                -- device trust cleared for silence; cleared on heartbeat
+);
+
+CREATE INDEX idx_agents_org_heartbeat ON agents(org_id, last_heartbeat_at, id);
+CREATE INDEX idx_agents_silent ON agents(last_heartbeat_at) WHERE trust_degraded_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_telemetry_events_received;
+DROP INDEX IF EXISTS idx_telemetry_events_org_received;
+DROP TABLE IF EXISTS telemetry_events;
@@ -0,0 +1,20 @@
+-- Telemetry events: agent telemetry stored by the embedded transport (TELEMETRY_TRANSPORT=embedded), for deployments
+-- without Kafka, NATS, RabbitMQ or Loki. Backs TelemetryService.QueryTelemetry; rows older than
+-- TELEMETRY_EMBEDDED_RETENTION_DAYS are deleted. No foreign keys: like audit logs, events outlive their users.
+CREATE TABLE telemetry_events (
+    org_id          VARCHAR NOT NULL,
+    event_id        VARCHAR NOT NULL,                          -- from the agent, or generated; repeats are ignored
+    schema_version  INTEGER NOT NULL,
+    type            VARCHAR NOT NULL,
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    received_at     TIMESTAMPTZ NOT NULL,
+    user_id         VARCHAR NOT NULL,
+    session_id      VARCHAR NOT NULL,
+    device_id       VARCHAR NOT NULL DEFAULT '',               -- empty when the session has no device
+    region          VARCHAR NOT NULL DEFAULT '',
+    attributes_json TEXT NOT NULL DEFAULT '{}',                -- attributes as a JSON object of strings
+    PRIMARY KEY (org_id, event_id)
+);
+
+CREATE INDEX idx_telemetry_events_org_received ON telemetry_events(org_id, received_at DESC, event_id);
+CREATE INDEX idx_telemetry_events_received ON telemetry_events(received_at);
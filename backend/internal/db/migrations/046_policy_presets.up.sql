@@ -0,0 +1,49 @@
+-- Policy presets: named bundles of an org policy config and an optional Rego policy that PolicyPresetService
+-- ApplyPreset materializes for an org (policy config, MFA settings and policies) and SetupOrganization accepts as a
+-- template. Platform data shared by all orgs; the presets below are built in and can be edited or extended in place.
+CREATE TABLE policy_presets (
+    name         VARCHAR PRIMARY KEY,
+    display_name VARCHAR NOT NULL,
+    description  TEXT NOT NULL,
+    policy_rules TEXT NOT NULL DEFAULT '', -- Rego; empty = the built-in default policy
+    config_json  TEXT NOT NULL,            -- the sections of the org policy config the preset sets
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO policy_presets (name, display_name, description, policy_rules, config_json, created_at, updated_at) VALUES
+('strict', 'Strict',
+ 'MFA on every sign-in and no automatic device trust. Short sessions, step-up for sensitive actions, enforced new sign-in alerts, breached passwords blocked, and MFA required while policies cannot be loaded.',
+ $rego$package ztcp.device_trust
+
+default mfa_required = true
+default register_trust_after_mfa = false
+default trust_ttl_days = 7
+
+trust_ttl_days = input.org.trust_ttl_days if {
+	input.org.trust_ttl_days > 0
+}
+$rego$,
+ '{"auth_mfa":{"mfa_requirement":"always","allowed_mfa_methods":["sms_otp"],"step_up_sensitive_actions":true,"step_up_policy_violation":true,"policy_failure_mode":"closed"},'
+ '"device_trust":{"device_registration_allowed":true,"auto_trust_after_mfa":false,"max_trusted_devices_per_user":2,"reverify_interval_days":7,"admin_revoke_allowed":true,"keep_trust_on_factor_change":false,"trust_renewal":"fixed","expiry_notice_days":2},'
+ '"session_mgmt":{"session_max_ttl":"8h","idle_timeout":"15m","concurrent_session_limit":3,"admin_forced_logout":true,"reauth_on_policy_change":true},'
+ '"notifications":{"new_login_alerts":true,"enforce_new_login_alerts":true},'
+ '"password_policy":{"breached_password_mode":"block"}}',
+ NOW(), NOW()),
+('balanced', 'Balanced',
+ 'MFA on new devices, which are trusted for 30 days after MFA. Step-up for sensitive actions, day-long sessions, new sign-in alerts, and a warning for breached passwords. Uses the built-in device trust policy.',
+ '',
+ '{"auth_mfa":{"mfa_requirement":"new_device","allowed_mfa_methods":["sms_otp"],"step_up_sensitive_actions":true,"step_up_policy_violation":false},'
+ '"device_trust":{"device_registration_allowed":true,"auto_trust_after_mfa":true,"max_trusted_devices_per_user":5,"reverify_interval_days":30,"admin_revoke_allowed":true,"keep_trust_on_factor_change":false,"trust_renewal":"fixed","expiry_notice_days":3},'
+ '"session_mgmt":{"session_max_ttl":"24h","idle_timeout":"30m","concurrent_session_limit":0,"admin_forced_logout":true,"reauth_on_policy_change":false},'
+ '"notifications":{"new_login_alerts":true,"enforce_new_login_alerts":false},'
+ '"password_policy":{"breached_password_mode":"warn"}}',
+ NOW(), NOW()),
+('byod_friendly', 'BYOD-friendly',
+ 'For personal devices: MFA only on untrusted devices, trust kept for 90 days and renewed by use, several devices per user, sign-in links by email, and week-long sessions. Uses the built-in device trust policy.',
+ '',
+ '{"auth_mfa":{"mfa_requirement":"untrusted","allowed_mfa_methods":["sms_otp"],"step_up_sensitive_actions":false,"step_up_policy_violation":false,"magic_link_enabled":true},'
+ '"device_trust":{"device_registration_allowed":true,"auto_trust_after_mfa":true,"max_trusted_devices_per_user":10,"reverify_interval_days":90,"admin_revoke_allowed":true,"keep_trust_on_factor_change":true,"trust_renewal":"sliding","expiry_notice_days":7},'
+ '"session_mgmt":{"session_max_ttl":"168h","idle_timeout":"8h","concurrent_session_limit":0,"admin_forced_logout":true,"reauth_on_policy_change":false},'
+ '"notifications":{"new_login_alerts":true,"enforce_new_login_alerts":false}}',
+ NOW(), NOW());
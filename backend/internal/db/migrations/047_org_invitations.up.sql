@@ -0,0 +1,19 @@
+-- Org invitations: one-time tokens with which a new user registers while registration is invite-only (platform
+-- registration_mode or the org's registration section). Created by org admins with InvitationService; Register
+-- accepts one at most once, before expires_at, for the invited email, and adds the user to the org with the invited
+-- role. Only the hash of the token is stored.
+CREATE TABLE org_invitations (
+    id          VARCHAR PRIMARY KEY,
+    org_id      VARCHAR NOT NULL REFERENCES organizations(id),
+    email       VARCHAR NOT NULL,                   -- lower-cased; Register must use this email
+    role        role NOT NULL,
+    token_hash  VARCHAR NOT NULL UNIQUE,            -- SHA-256 of the invitation token
+    invited_by  VARCHAR NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,                        -- set by Register; an invitation is accepted at most once
+    accepted_by VARCHAR REFERENCES users(id),
+    revoked_at  TIMESTAMPTZ                         -- set by RevokeInvitation
+);
+
+CREATE INDEX idx_org_invitations_org_created ON org_invitations(org_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS membership_attributes;
+DROP TABLE IF EXISTS org_attribute_definitions;
@@ -0,0 +1,27 @@
+-- Org-defined user attributes (department, employee ID, cost center, ...). An org declares each attribute with
+-- UserAttributeService; values are stored per membership, so a user has separate values in each org. Values are
+-- exposed to MFA policy evaluation as input.user.attributes and searchable with SearchMembers.
+CREATE TABLE org_attribute_definitions (
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    key            VARCHAR NOT NULL,                 -- lower-case identifier, e.g. cost_center
+    display_name   VARCHAR NOT NULL,
+    description    TEXT NOT NULL DEFAULT '',
+    type           VARCHAR NOT NULL,                 -- string, number, boolean or enum
+    allowed_values VARCHAR[] NOT NULL DEFAULT '{}',  -- enum only
+    created_at     TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, key)
+);
+
+-- Values in canonical text form (numbers without trailing zeros, booleans as true/false). Deleting the membership or
+-- the definition deletes its values.
+CREATE TABLE membership_attributes (
+    membership_id VARCHAR NOT NULL REFERENCES memberships(id) ON DELETE CASCADE,
+    org_id        VARCHAR NOT NULL,
+    key           VARCHAR NOT NULL,
+    value         VARCHAR NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (membership_id, key),
+    FOREIGN KEY (org_id, key) REFERENCES org_attribute_definitions(org_id, key) ON DELETE CASCADE
+);
+CREATE INDEX idx_membership_attributes_search ON membership_attributes(org_id, key, value);
@@ -0,0 +1,2 @@
+ALTER TABLE mfa_challenges DROP COLUMN IF EXISTS trust_days;
+ALTER TABLE devices DROP COLUMN IF EXISTS trust_days;
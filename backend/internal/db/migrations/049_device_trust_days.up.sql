@@ -0,0 +1,5 @@
+-- Remember-device duration the user chose when the device was trusted (Login or VerifyMFA trust_days), at most the
+-- org's trust TTL. 0 means the org's trust TTL; sliding renewal extends trust by this duration.
+ALTER TABLE devices ADD COLUMN trust_days INT NOT NULL DEFAULT 0;
+-- The duration asked for at Login, carried to the VerifyMFA that trusts the device.
+ALTER TABLE mfa_challenges ADD COLUMN trust_days INT NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_sessions_ephemeral_active;
+DELETE FROM login_holds WHERE device_id IS NULL;
+ALTER TABLE login_holds ALTER COLUMN device_id SET NOT NULL;
+DELETE FROM mfa_challenges WHERE device_id IS NULL;
+ALTER TABLE mfa_challenges ALTER COLUMN device_id SET NOT NULL;
+DELETE FROM sessions WHERE device_id IS NULL;
+ALTER TABLE sessions DROP COLUMN IF EXISTS ephemeral;
+ALTER TABLE sessions ALTER COLUMN device_id SET NOT NULL;
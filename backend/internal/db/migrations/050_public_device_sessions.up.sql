@@ -0,0 +1,9 @@
+-- Public/shared device sign-ins (Login public_device) create no device: their sessions, SMS challenges and login holds
+-- have no device_id. Such sessions are ephemeral: shorter-lived, never trusted and revoked when idle.
+ALTER TABLE sessions ALTER COLUMN device_id DROP NOT NULL;
+ALTER TABLE sessions ADD COLUMN ephemeral BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE mfa_challenges ALTER COLUMN device_id DROP NOT NULL;
+ALTER TABLE login_holds ALTER COLUMN device_id DROP NOT NULL;
+
+-- Feeds the ephemeral session sweeper.
+CREATE INDEX idx_sessions_ephemeral_active ON sessions (last_seen_at) WHERE ephemeral AND revoked_at IS NULL;
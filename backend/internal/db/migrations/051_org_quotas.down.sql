@@ -0,0 +1,2 @@
+ALTER TABLE organizations DROP COLUMN max_devices;
+ALTER TABLE organizations DROP COLUMN max_users;
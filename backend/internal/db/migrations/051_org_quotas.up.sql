@@ -0,0 +1,4 @@
+-- Per-org quotas set by platform admins: how many members (AddMember) and active devices (device registration at
+-- sign-in) the org may have. 0 means unlimited.
+ALTER TABLE organizations ADD COLUMN max_users INT NOT NULL DEFAULT 0;
+ALTER TABLE organizations ADD COLUMN max_devices INT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Billing plan of each org (free, team or enterprise), assigned by platform admins with
+-- AdminService.SetOrgBillingPlan. The plan decides which gated features the org may use once PLAN_ENFORCEMENT_ENABLED
+-- is set.
+ALTER TABLE organizations ADD COLUMN plan VARCHAR NOT NULL DEFAULT 'free';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS usage_active_users;
+DROP INDEX IF EXISTS idx_usage_monthly_month;
+DROP TABLE IF EXISTS usage_monthly;
@@ -0,0 +1,24 @@
+-- Monthly per-org usage for billing, served by AnalyticsService GetUsage and AdminService ExportUsage.
+-- api_calls is incremented by the metering flush; the other counters are recomputed by the metering job from
+-- audit_logs and usage_active_users.
+CREATE TABLE usage_monthly (
+    org_id         VARCHAR NOT NULL,
+    month          DATE NOT NULL,
+    active_users   BIGINT NOT NULL DEFAULT 0,
+    logins         BIGINT NOT NULL DEFAULT 0,
+    mfa_challenges BIGINT NOT NULL DEFAULT 0,
+    mfa_resends    BIGINT NOT NULL DEFAULT 0,
+    api_calls      BIGINT NOT NULL DEFAULT 0,
+    updated_at     TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, month)
+);
+
+CREATE INDEX idx_usage_monthly_month ON usage_monthly(month);
+
+-- Users that made an authenticated call in a month, written by the metering flush; counted as monthly active users.
+CREATE TABLE usage_active_users (
+    org_id  VARCHAR NOT NULL,
+    month   DATE NOT NULL,
+    user_id VARCHAR NOT NULL,
+    PRIMARY KEY (org_id, month, user_id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_audit_logs_user_created_at;
+DROP INDEX IF EXISTS idx_data_exports_status_created;
+DROP INDEX IF EXISTS idx_data_exports_user;
+DROP TABLE IF EXISTS data_exports;
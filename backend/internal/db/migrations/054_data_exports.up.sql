@@ -0,0 +1,22 @@
+-- Data exports: machine-readable archives of a user's personal data for data-subject access requests.
+-- UserService.ExportMyData and AdminService.ExportUserData create a pending export and return its download token;
+-- the data export job generates the archive and UserService.DownloadDataExport returns it until expires_at.
+CREATE TABLE data_exports (
+    id           VARCHAR PRIMARY KEY,
+    user_id      VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_by VARCHAR NOT NULL,                    -- the user, or the platform admin who asked for it
+    token_hash   VARCHAR NOT NULL UNIQUE,             -- SHA-256 of the download token; the token is only returned once
+    status       VARCHAR NOT NULL DEFAULT 'pending',  -- pending, generating, ready or failed
+    archive      BYTEA,                               -- gzipped JSON, set when ready
+    error        TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL,
+    claimed_at   TIMESTAMPTZ,                         -- when the job started generating it
+    completed_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_data_exports_user ON data_exports(user_id, status);
+CREATE INDEX idx_data_exports_status_created ON data_exports(status, created_at);
+
+-- Audit events are exported by the user who performed them.
+CREATE INDEX idx_audit_logs_user_created_at ON audit_logs(user_id, created_at);
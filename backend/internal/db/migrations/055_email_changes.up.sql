@@ -0,0 +1,21 @@
+-- Email changes: StartEmailChange emails a confirmation link to the user's current address and one to the new
+-- address. The email (and the provider_id of the local identity) changes only once both links are opened with
+-- ConfirmEmailChange before expires_at; the user's sessions are then revoked. Starting a new change replaces an
+-- unfinished one.
+CREATE TABLE email_changes (
+    id               VARCHAR PRIMARY KEY,
+    user_id          VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    org_id           VARCHAR NOT NULL REFERENCES organizations(id), -- org of the session that started it
+    old_email        VARCHAR NOT NULL,                 -- encrypted like users.email when PII encryption is enabled
+    new_email        VARCHAR NOT NULL,
+    old_token_hash   VARCHAR NOT NULL UNIQUE,          -- SHA-256 of the token sent to the current address
+    new_token_hash   VARCHAR NOT NULL UNIQUE,          -- SHA-256 of the token sent to the new address
+    ip               VARCHAR NOT NULL DEFAULT '',      -- client IP of StartEmailChange
+    created_at       TIMESTAMPTZ NOT NULL,
+    expires_at       TIMESTAMPTZ NOT NULL,
+    old_confirmed_at TIMESTAMPTZ,
+    new_confirmed_at TIMESTAMPTZ,
+    completed_at     TIMESTAMPTZ                       -- set when the email was changed
+);
+
+CREATE INDEX idx_email_changes_user ON email_changes(user_id);
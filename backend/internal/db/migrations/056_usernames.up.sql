@@ -0,0 +1,12 @@
+-- Usernames: optional sign-in names users can use in place of their email. With USERNAME_SCOPE=global a user has at
+-- most one username (org_id ''), unique across the platform; with USERNAME_SCOPE=org a user has at most one per org,
+-- unique within the org. Usernames are stored normalized (lower case).
+CREATE TABLE usernames (
+    user_id    VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    org_id     VARCHAR NOT NULL DEFAULT '', -- '' for global usernames
+    username   VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, org_id),
+    UNIQUE (org_id, username)
+);
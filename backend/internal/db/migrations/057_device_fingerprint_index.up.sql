@@ -0,0 +1,2 @@
+-- Looks up the devices of a fingerprint across users and orgs, to detect machines shared by several users.
+CREATE INDEX idx_devices_fingerprint ON devices(fingerprint) WHERE revoked_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE devices DROP COLUMN IF EXISTS quarantined_by;
+ALTER TABLE devices DROP COLUMN IF EXISTS quarantine_reason;
+ALTER TABLE devices DROP COLUMN IF EXISTS quarantined_at;
@@ -0,0 +1,5 @@
+-- Quarantine of a device suspected to be compromised: unlike revocation, the record and its trust are kept, but
+-- every sign-in from it requires MFA and raises a security event until an admin releases it.
+ALTER TABLE devices ADD COLUMN quarantined_at TIMESTAMPTZ;
+ALTER TABLE devices ADD COLUMN quarantine_reason VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE devices ADD COLUMN quarantined_by VARCHAR NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_url_exceptions_user_org_domain;
+DROP INDEX IF EXISTS idx_url_exceptions_org_created;
+DROP TABLE IF EXISTS url_exceptions;
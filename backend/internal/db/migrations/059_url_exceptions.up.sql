@@ -0,0 +1,23 @@
+-- URL access exceptions: a member blocked by access control asks for one domain with a justification, an org admin
+-- approves it for a limited time, and CheckUrlAccess allows the domain for that member until expires_at (or until
+-- revoked). Backs UrlExceptionService.
+CREATE TABLE url_exceptions (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    domain         VARCHAR NOT NULL, -- normalized host the exception allows
+    url            TEXT NOT NULL,    -- the URL the member was blocked on
+    status         VARCHAR NOT NULL, -- pending, approved, rejected, revoked
+    justification  TEXT NOT NULL,
+    duration_secs  INTEGER NOT NULL, -- window; starts at approval
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    expires_at     TIMESTAMPTZ,      -- set on approval
+    revoked_by     VARCHAR REFERENCES users(id),
+    revoked_at     TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_url_exceptions_org_created ON url_exceptions(org_id, created_at DESC);
+CREATE INDEX idx_url_exceptions_user_org_domain ON url_exceptions(user_id, org_id, domain) WHERE status IN ('pending', 'approved');
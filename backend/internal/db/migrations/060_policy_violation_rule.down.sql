@@ -0,0 +1 @@
+ALTER TABLE policy_violations DROP COLUMN IF EXISTS rule;
@@ -0,0 +1,3 @@
+-- The action_restrictions rule an agent enforced when it blocked an action: empty for allowed_actions and
+-- read_only_mode, or one of the DLP rules (max_upload_bytes, blocked_file_extensions, clipboard_blocked_domains).
+ALTER TABLE policy_violations ADD COLUMN rule VARCHAR NOT NULL DEFAULT '';
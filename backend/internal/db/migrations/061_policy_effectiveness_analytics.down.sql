@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS analytics_daily_user_violations;
+DROP TABLE IF EXISTS analytics_daily_blocked_domains;
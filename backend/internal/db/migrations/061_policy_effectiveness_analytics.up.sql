@@ -0,0 +1,19 @@
+-- Daily per-org rollups for the policy effectiveness reports of AnalyticsService, recomputed by the analytics rollup
+-- job: URLs denied by CheckUrlAccess per domain (from url_access_denied audit entries) and policy violations per user.
+CREATE TABLE analytics_daily_blocked_domains (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    domain     VARCHAR NOT NULL,
+    blocks     BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, domain)
+);
+
+CREATE TABLE analytics_daily_user_violations (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    user_id    VARCHAR NOT NULL,
+    violations BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, user_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_audit_webhooks_org;
+DROP TABLE IF EXISTS audit_webhooks;
@@ -0,0 +1,16 @@
+-- Org audit webhooks: each row is an org's subscription to its own audit log. Every audit entry of the org that
+-- matches the webhook's filter expression is POSTed to url, signed with secret. Backs AuditWebhookService.
+CREATE TABLE audit_webhooks (
+    id          VARCHAR PRIMARY KEY,
+    org_id      VARCHAR NOT NULL REFERENCES organizations(id),
+    url         TEXT NOT NULL,
+    secret      VARCHAR NOT NULL,          -- HMAC-SHA256 key for X-ZTCP-Signature
+    filter      TEXT NOT NULL DEFAULT '',  -- filter expression; empty matches every event
+    description VARCHAR NOT NULL DEFAULT '',
+    enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by  VARCHAR NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_audit_webhooks_org ON audit_webhooks(org_id, created_at);
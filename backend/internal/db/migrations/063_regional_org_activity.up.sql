@@ -0,0 +1,3 @@
+-- Data residency now also routes security events, telemetry events, analytics rollups and usage metering to the
+-- org's region. A regional database holds no users rows, so security_events cannot reference them.
+ALTER TABLE security_events DROP CONSTRAINT IF EXISTS security_events_user_id_fkey;
@@ -0,0 +1 @@
+ALTER TABLE telemetry_events DROP COLUMN IF EXISTS request_id;
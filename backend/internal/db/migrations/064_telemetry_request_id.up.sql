@@ -0,0 +1,3 @@
+-- The request ID of the IngestTelemetry or StreamTelemetry call that delivered an event, to correlate it with the
+-- server's logs and audit entries. Empty for events stored before this migration.
+ALTER TABLE telemetry_events ADD COLUMN IF NOT EXISTS request_id VARCHAR NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE data_exports DROP COLUMN IF EXISTS org_id;
+ALTER TABLE data_exports ADD CONSTRAINT data_exports_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+-- Data exports are stored in the data region of the user's home org (the org of their first membership), since the
+-- archive holds that region's audit events. A regional database holds no users rows, so data_exports cannot reference
+-- them; expired exports are deleted by the data export job.
+ALTER TABLE data_exports DROP CONSTRAINT IF EXISTS data_exports_user_id_fkey;
+ALTER TABLE data_exports ADD COLUMN IF NOT EXISTS org_id VARCHAR NOT NULL DEFAULT '';
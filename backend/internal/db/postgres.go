@@ -1,20 +1,85 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
 )
 
-// Open opens a Postgres connection using the given DSN. Caller must call Close when done.
+// pgUniqueViolation is the SQLSTATE of a unique or primary key constraint failure.
+const pgUniqueViolation = "23505"
+
+// Options bounds the time spent in the database. Zero fields disable their limit.
+type Options struct {
+	// QueryTimeout bounds each statement run through the returned *sql.DB, including those in transactions: the
+	// caller's context is given this deadline unless it already has an earlier one. A query that runs out fails with
+	// an error matching ErrQueryTimeout.
+	QueryTimeout time.Duration
+	// StatementTimeout is set as statement_timeout on every connection, so the server also cancels statements that
+	// the client could not (e.g. when the cancel request is lost).
+	StatementTimeout time.Duration
+}
+
+// Open opens a database connection using the given DSN, without timeouts: SQLite for a sqlite: DSN (see IsSQLite),
+// Postgres otherwise. Caller must call Close when done.
 func Open(dsn string) (*sql.DB, error) {
-	db, err := sql.Open("pgx", dsn)
-	if err != nil {
-		return nil, err
+	return OpenWithOptions(dsn, Options{})
+}
+
+// OpenWithOptions opens a database connection using the given DSN with the query and statement timeouts of opts.
+// SQLite has no statement_timeout, so only QueryTimeout applies to it. Caller must call Close when done.
+func OpenWithOptions(dsn string, opts Options) (*sql.DB, error) {
+	var connector driver.Connector
+	memory := false
+	if IsSQLite(dsn) {
+		name, inMemory, err := sqliteName(dsn)
+		if err != nil {
+			return nil, err
+		}
+		connector, memory = &sqliteConnector{name: name}, inMemory
+	} else {
+		config, err := pgx.ParseConfig(dsn)
+		if err != nil {
+			return nil, err
+		}
+		if opts.StatementTimeout > 0 {
+			config.RuntimeParams["statement_timeout"] = strconv.FormatInt(opts.StatementTimeout.Milliseconds(), 10)
+		}
+		connector = stdlib.GetConnector(*config)
 	}
-	if err := db.Ping(); err != nil {
+	if opts.QueryTimeout > 0 {
+		connector = &timeoutConnector{Connector: connector, timeout: opts.QueryTimeout}
+	}
+	db := sql.OpenDB(connector)
+	if memory {
+		// Every connection to :memory: is a database of its own.
+		db.SetMaxOpenConns(1)
+	}
+	pingCtx := context.Background()
+	if opts.QueryTimeout > 0 {
+		var cancel context.CancelFunc
+		pingCtx, cancel = context.WithTimeout(pingCtx, opts.QueryTimeout)
+		defer cancel()
+	}
+	if err := db.PingContext(pingCtx); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 	return db, nil
 }
+
+// IsUniqueViolation reports whether err is a unique or primary key constraint failure, on Postgres or SQLite.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+	return isSQLiteUniqueViolation(err)
+}
@@ -9,12 +9,25 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
+const countAuditLogsByUser = `-- name: CountAuditLogsByUser :one
+SELECT COUNT(*) FROM audit_logs WHERE user_id = $1::text
+`
+
+func (q *Queries) CountAuditLogsByUser(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuditLogsByUser, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAuditLog = `-- name: CreateAuditLog :one
-INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, org_id, user_id, action, resource, ip, metadata, created_at
+INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
 `
 
 type CreateAuditLogParams struct {
@@ -26,6 +39,7 @@ type CreateAuditLogParams struct {
 	Ip        string
 	Metadata  sql.NullString
 	CreatedAt time.Time
+	RequestID sql.NullString
 }
 
 func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error) {
@@ -38,6 +52,7 @@ func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams)
 		arg.Ip,
 		arg.Metadata,
 		arg.CreatedAt,
+		arg.RequestID,
 	)
 	var i AuditLog
 	err := row.Scan(
@@ -49,12 +64,88 @@ func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams)
 		&i.Ip,
 		&i.Metadata,
 		&i.CreatedAt,
+		&i.RequestID,
 	)
 	return i, err
 }
 
+const createAuditLogPartition = `-- name: CreateAuditLogPartition :one
+SELECT audit_logs_create_partition($1::date) AS created
+`
+
+func (q *Queries) CreateAuditLogPartition(ctx context.Context, month time.Time) (bool, error) {
+	row := q.db.QueryRowContext(ctx, createAuditLogPartition, month)
+	var created bool
+	err := row.Scan(&created)
+	return created, err
+}
+
+const createAuditLogs = `-- name: CreateAuditLogs :exec
+INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+SELECT id, org_id, NULLIF(user_id, ''), action, resource, ip, NULLIF(metadata, ''), created_at, NULLIF(request_id, '')
+FROM unnest(
+    $1::varchar[], $2::varchar[], $3::varchar[],
+    $4::varchar[], $5::varchar[], $6::varchar[],
+    $7::text[], $8::timestamptz[], $9::varchar[]
+) AS t(id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+`
+
+type CreateAuditLogsParams struct {
+	Ids        []string
+	OrgIds     []string
+	UserIds    []string
+	Actions    []string
+	Resources  []string
+	Ips        []string
+	Metadata   []string
+	CreatedAts []time.Time
+	RequestIds []string
+}
+
+func (q *Queries) CreateAuditLogs(ctx context.Context, arg CreateAuditLogsParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditLogs,
+		pq.Array(arg.Ids),
+		pq.Array(arg.OrgIds),
+		pq.Array(arg.UserIds),
+		pq.Array(arg.Actions),
+		pq.Array(arg.Resources),
+		pq.Array(arg.Ips),
+		pq.Array(arg.Metadata),
+		pq.Array(arg.CreatedAts),
+		pq.Array(arg.RequestIds),
+	)
+	return err
+}
+
+const dropAuditLogPartitionsBefore = `-- name: DropAuditLogPartitionsBefore :many
+SELECT audit_logs_drop_partitions_before($1::timestamptz)::text AS name
+`
+
+func (q *Queries) DropAuditLogPartitionsBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, dropAuditLogPartitionsBefore, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAuditLog = `-- name: GetAuditLog :one
-SELECT id, org_id, user_id, action, resource, ip, metadata, created_at
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
 FROM audit_logs
 WHERE id = $1
 `
@@ -71,12 +162,13 @@ func (q *Queries) GetAuditLog(ctx context.Context, id string) (AuditLog, error)
 		&i.Ip,
 		&i.Metadata,
 		&i.CreatedAt,
+		&i.RequestID,
 	)
 	return i, err
 }
 
 const listAuditLogsByOrg = `-- name: ListAuditLogsByOrg :many
-SELECT id, org_id, user_id, action, resource, ip, metadata, created_at
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
 FROM audit_logs
 WHERE org_id = $1
 ORDER BY created_at DESC
@@ -107,6 +199,7 @@ func (q *Queries) ListAuditLogsByOrg(ctx context.Context, arg ListAuditLogsByOrg
 			&i.Ip,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.RequestID,
 		); err != nil {
 			return nil, err
 		}
@@ -122,23 +215,25 @@ func (q *Queries) ListAuditLogsByOrg(ctx context.Context, arg ListAuditLogsByOrg
 }
 
 const listAuditLogsByOrgFiltered = `-- name: ListAuditLogsByOrgFiltered :many
-SELECT id, org_id, user_id, action, resource, ip, metadata, created_at
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
 FROM audit_logs
 WHERE org_id = $1
   AND ($4::text IS NULL OR user_id = $4)
   AND ($5::text IS NULL OR action = $5)
   AND ($6::text IS NULL OR resource = $6)
+  AND ($7::text IS NULL OR request_id = $7)
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
 
 type ListAuditLogsByOrgFilteredParams struct {
-	OrgID          string
-	Limit          int32
-	Offset         int32
-	FilterUserID   sql.NullString
-	FilterAction   sql.NullString
-	FilterResource sql.NullString
+	OrgID           string
+	Limit           int32
+	Offset          int32
+	FilterUserID    sql.NullString
+	FilterAction    sql.NullString
+	FilterResource  sql.NullString
+	FilterRequestID sql.NullString
 }
 
 func (q *Queries) ListAuditLogsByOrgFiltered(ctx context.Context, arg ListAuditLogsByOrgFilteredParams) ([]AuditLog, error) {
@@ -149,6 +244,65 @@ func (q *Queries) ListAuditLogsByOrgFiltered(ctx context.Context, arg ListAuditL
 		arg.FilterUserID,
 		arg.FilterAction,
 		arg.FilterResource,
+		arg.FilterRequestID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrgID,
+			&i.UserID,
+			&i.Action,
+			&i.Resource,
+			&i.Ip,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.RequestID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditLogsByOrgSince = `-- name: ListAuditLogsByOrgSince :many
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
+FROM audit_logs
+WHERE org_id = $1
+  AND created_at >= $2
+  AND ($4::text IS NULL OR user_id = $4)
+  AND (cardinality($5::varchar[]) = 0 OR action = ANY($5::varchar[]))
+ORDER BY created_at, id
+LIMIT $3
+`
+
+type ListAuditLogsByOrgSinceParams struct {
+	OrgID         string
+	CreatedAt     time.Time
+	Limit         int32
+	FilterUserID  sql.NullString
+	FilterActions []string
+}
+
+func (q *Queries) ListAuditLogsByOrgSince(ctx context.Context, arg ListAuditLogsByOrgSinceParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditLogsByOrgSince,
+		arg.OrgID,
+		arg.CreatedAt,
+		arg.Limit,
+		arg.FilterUserID,
+		pq.Array(arg.FilterActions),
 	)
 	if err != nil {
 		return nil, err
@@ -166,6 +320,219 @@ func (q *Queries) ListAuditLogsByOrgFiltered(ctx context.Context, arg ListAuditL
 			&i.Ip,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.RequestID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditLogsByUser = `-- name: ListAuditLogsByUser :many
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
+FROM audit_logs
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2
+`
+
+type ListAuditLogsByUserParams struct {
+	UserID sql.NullString
+	Limit  int32
+}
+
+// The latest audit logs written with the user as the actor, newest first.
+func (q *Queries) ListAuditLogsByUser(ctx context.Context, arg ListAuditLogsByUserParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditLogsByUser, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrgID,
+			&i.UserID,
+			&i.Action,
+			&i.Resource,
+			&i.Ip,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.RequestID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLoginFailureAccountsByIP = `-- name: ListLoginFailureAccountsByIP :many
+SELECT user_id::text AS user_id, MAX(org_id)::text AS org_id
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND ip = $1 AND created_at >= $2 AND user_id IS NOT NULL
+GROUP BY user_id
+`
+
+type ListLoginFailureAccountsByIPParams struct {
+	Ip    string
+	Since time.Time
+}
+
+type ListLoginFailureAccountsByIPRow struct {
+	UserID string
+	OrgID  string
+}
+
+func (q *Queries) ListLoginFailureAccountsByIP(ctx context.Context, arg ListLoginFailureAccountsByIPParams) ([]ListLoginFailureAccountsByIPRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLoginFailureAccountsByIP, arg.Ip, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLoginFailureAccountsByIPRow
+	for rows.Next() {
+		var i ListLoginFailureAccountsByIPRow
+		if err := rows.Scan(&i.UserID, &i.OrgID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLoginFailureIPsByUser = `-- name: ListLoginFailureIPsByUser :many
+SELECT DISTINCT ip
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND user_id = $1::text AND created_at >= $2 AND ip <> 'unknown'
+`
+
+type ListLoginFailureIPsByUserParams struct {
+	UserID string
+	Since  time.Time
+}
+
+func (q *Queries) ListLoginFailureIPsByUser(ctx context.Context, arg ListLoginFailureIPsByUserParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listLoginFailureIPsByUser, arg.UserID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var ip string
+		if err := rows.Scan(&ip); err != nil {
+			return nil, err
+		}
+		items = append(items, ip)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLoginFailureStatsByIP = `-- name: ListLoginFailureStatsByIP :many
+SELECT ip, COUNT(*)::bigint AS failures, COUNT(DISTINCT user_id)::bigint AS accounts
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND created_at >= $1 AND ip <> 'unknown'
+GROUP BY ip
+HAVING COUNT(*) >= $2::bigint OR COUNT(DISTINCT user_id) >= $3::bigint
+`
+
+type ListLoginFailureStatsByIPParams struct {
+	Since       time.Time
+	MinFailures int64
+	MinAccounts int64
+}
+
+type ListLoginFailureStatsByIPRow struct {
+	Ip       string
+	Failures int64
+	Accounts int64
+}
+
+func (q *Queries) ListLoginFailureStatsByIP(ctx context.Context, arg ListLoginFailureStatsByIPParams) ([]ListLoginFailureStatsByIPRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLoginFailureStatsByIP, arg.Since, arg.MinFailures, arg.MinAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLoginFailureStatsByIPRow
+	for rows.Next() {
+		var i ListLoginFailureStatsByIPRow
+		if err := rows.Scan(&i.Ip, &i.Failures, &i.Accounts); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLoginFailureStatsByUser = `-- name: ListLoginFailureStatsByUser :many
+SELECT user_id::text AS user_id, MAX(org_id)::text AS org_id, COUNT(*)::bigint AS failures, COUNT(DISTINCT ip)::bigint AS ips
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND created_at >= $1 AND user_id IS NOT NULL AND ip <> 'unknown'
+GROUP BY user_id
+HAVING COUNT(DISTINCT ip) >= $2::bigint
+`
+
+type ListLoginFailureStatsByUserParams struct {
+	Since  time.Time
+	MinIps int64
+}
+
+type ListLoginFailureStatsByUserRow struct {
+	UserID   string
+	OrgID    string
+	Failures int64
+	Ips      int64
+}
+
+func (q *Queries) ListLoginFailureStatsByUser(ctx context.Context, arg ListLoginFailureStatsByUserParams) ([]ListLoginFailureStatsByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLoginFailureStatsByUser, arg.Since, arg.MinIps)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLoginFailureStatsByUserRow
+	for rows.Next() {
+		var i ListLoginFailureStatsByUserRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.OrgID,
+			&i.Failures,
+			&i.Ips,
 		); err != nil {
 			return nil, err
 		}
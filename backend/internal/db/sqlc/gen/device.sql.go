@@ -11,10 +11,22 @@ import (
 	"time"
 )
 
+const countActiveDevicesByOrg = `-- name: CountActiveDevicesByOrg :one
+SELECT COUNT(*) FROM devices
+WHERE org_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) CountActiveDevicesByOrg(ctx context.Context, orgID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveDevicesByOrg, orgID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createDevice = `-- name: CreateDevice :one
 INSERT INTO devices (id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 `
 
 type CreateDeviceParams struct {
@@ -52,12 +64,17 @@ func (q *Queries) CreateDevice(ctx context.Context, arg CreateDeviceParams) (Dev
 		&i.RevokedAt,
 		&i.LastSeenAt,
 		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
 	)
 	return i, err
 }
 
 const getDevice = `-- name: GetDevice :one
-SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 FROM devices
 WHERE id = $1
 `
@@ -75,12 +92,17 @@ func (q *Queries) GetDevice(ctx context.Context, id string) (Device, error) {
 		&i.RevokedAt,
 		&i.LastSeenAt,
 		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
 	)
 	return i, err
 }
 
 const getDeviceByUserAndFingerprint = `-- name: GetDeviceByUserAndFingerprint :one
-SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 FROM devices
 WHERE user_id = $1 AND org_id = $2 AND fingerprint = $3
 `
@@ -104,12 +126,17 @@ func (q *Queries) GetDeviceByUserAndFingerprint(ctx context.Context, arg GetDevi
 		&i.RevokedAt,
 		&i.LastSeenAt,
 		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
 	)
 	return i, err
 }
 
 const listDevicesByOrg = `-- name: ListDevicesByOrg :many
-SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 FROM devices
 WHERE org_id = $1
 ORDER BY created_at
@@ -134,6 +161,118 @@ func (q *Queries) ListDevicesByOrg(ctx context.Context, orgID string) ([]Device,
 			&i.RevokedAt,
 			&i.LastSeenAt,
 			&i.CreatedAt,
+			&i.TrustExpiryNotifiedAt,
+			&i.TrustDays,
+			&i.QuarantinedAt,
+			&i.QuarantineReason,
+			&i.QuarantinedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDevicesWithTrustExpiring = `-- name: ListDevicesWithTrustExpiring :many
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
+FROM devices
+WHERE trusted = true AND revoked_at IS NULL AND trust_expiry_notified_at IS NULL
+  AND trusted_until <= $1::timestamptz
+  AND (trusted_until, id) > ($2::timestamptz, $3::varchar)
+ORDER BY trusted_until, id
+LIMIT $4
+`
+
+type ListDevicesWithTrustExpiringParams struct {
+	ExpiringBefore time.Time
+	AfterUntil     time.Time
+	AfterID        string
+	RowLimit       int32
+}
+
+// Trusted, unrevoked devices whose trust expires after the (trusted_until, id) cursor and at or before
+// expiring_before, and whose expiry notice has not been sent, ordered by expiry.
+func (q *Queries) ListDevicesWithTrustExpiring(ctx context.Context, arg ListDevicesWithTrustExpiringParams) ([]Device, error) {
+	rows, err := q.db.QueryContext(ctx, listDevicesWithTrustExpiring,
+		arg.ExpiringBefore,
+		arg.AfterUntil,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Device
+	for rows.Next() {
+		var i Device
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrgID,
+			&i.Fingerprint,
+			&i.Trusted,
+			&i.TrustedUntil,
+			&i.RevokedAt,
+			&i.LastSeenAt,
+			&i.CreatedAt,
+			&i.TrustExpiryNotifiedAt,
+			&i.TrustDays,
+			&i.QuarantinedAt,
+			&i.QuarantineReason,
+			&i.QuarantinedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrustedDevicesByUser = `-- name: ListTrustedDevicesByUser :many
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
+FROM devices
+WHERE user_id = $1 AND trusted = true
+ORDER BY created_at
+`
+
+func (q *Queries) ListTrustedDevicesByUser(ctx context.Context, userID string) ([]Device, error) {
+	rows, err := q.db.QueryContext(ctx, listTrustedDevicesByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Device
+	for rows.Next() {
+		var i Device
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrgID,
+			&i.Fingerprint,
+			&i.Trusted,
+			&i.TrustedUntil,
+			&i.RevokedAt,
+			&i.LastSeenAt,
+			&i.CreatedAt,
+			&i.TrustExpiryNotifiedAt,
+			&i.TrustDays,
+			&i.QuarantinedAt,
+			&i.QuarantineReason,
+			&i.QuarantinedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -148,11 +287,103 @@ func (q *Queries) ListDevicesByOrg(ctx context.Context, orgID string) ([]Device,
 	return items, nil
 }
 
+const markDeviceTrustExpiryNotified = `-- name: MarkDeviceTrustExpiryNotified :execrows
+UPDATE devices
+SET trust_expiry_notified_at = $2
+WHERE id = $1 AND trust_expiry_notified_at IS NULL
+`
+
+type MarkDeviceTrustExpiryNotifiedParams struct {
+	ID                    string
+	TrustExpiryNotifiedAt sql.NullTime
+}
+
+// Claims the expiry notice of the device's current trust period. Affects no row when another instance already
+// claimed it.
+func (q *Queries) MarkDeviceTrustExpiryNotified(ctx context.Context, arg MarkDeviceTrustExpiryNotifiedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markDeviceTrustExpiryNotified, arg.ID, arg.TrustExpiryNotifiedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const quarantineDevice = `-- name: QuarantineDevice :one
+UPDATE devices
+SET quarantined_at = $2, quarantine_reason = $3, quarantined_by = $4
+WHERE id = $1
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
+`
+
+type QuarantineDeviceParams struct {
+	ID               string
+	QuarantinedAt    sql.NullTime
+	QuarantineReason string
+	QuarantinedBy    string
+}
+
+// Quarantines the device; trust and history are kept.
+func (q *Queries) QuarantineDevice(ctx context.Context, arg QuarantineDeviceParams) (Device, error) {
+	row := q.db.QueryRowContext(ctx, quarantineDevice,
+		arg.ID,
+		arg.QuarantinedAt,
+		arg.QuarantineReason,
+		arg.QuarantinedBy,
+	)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrgID,
+		&i.Fingerprint,
+		&i.Trusted,
+		&i.TrustedUntil,
+		&i.RevokedAt,
+		&i.LastSeenAt,
+		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
+	)
+	return i, err
+}
+
+const releaseDevice = `-- name: ReleaseDevice :one
+UPDATE devices
+SET quarantined_at = NULL, quarantine_reason = '', quarantined_by = ''
+WHERE id = $1
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
+`
+
+func (q *Queries) ReleaseDevice(ctx context.Context, id string) (Device, error) {
+	row := q.db.QueryRowContext(ctx, releaseDevice, id)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrgID,
+		&i.Fingerprint,
+		&i.Trusted,
+		&i.TrustedUntil,
+		&i.RevokedAt,
+		&i.LastSeenAt,
+		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
+	)
+	return i, err
+}
+
 const revokeDevice = `-- name: RevokeDevice :one
 UPDATE devices
 SET trusted = false, trusted_until = NULL, revoked_at = $2
 WHERE id = $1
-RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 `
 
 type RevokeDeviceParams struct {
@@ -173,6 +404,48 @@ func (q *Queries) RevokeDevice(ctx context.Context, arg RevokeDeviceParams) (Dev
 		&i.RevokedAt,
 		&i.LastSeenAt,
 		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
+	)
+	return i, err
+}
+
+const trustDevice = `-- name: TrustDevice :one
+UPDATE devices
+SET trusted = true, trusted_until = $2, trust_days = $3, revoked_at = NULL, trust_expiry_notified_at = NULL
+WHERE id = $1
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
+`
+
+type TrustDeviceParams struct {
+	ID           string
+	TrustedUntil sql.NullTime
+	TrustDays    int32
+}
+
+// Trusts the device until trusted_until for the remember-device duration the user chose (0 = the org's trust TTL);
+// clears revoked_at and the trust expiry notice mark.
+func (q *Queries) TrustDevice(ctx context.Context, arg TrustDeviceParams) (Device, error) {
+	row := q.db.QueryRowContext(ctx, trustDevice, arg.ID, arg.TrustedUntil, arg.TrustDays)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrgID,
+		&i.Fingerprint,
+		&i.Trusted,
+		&i.TrustedUntil,
+		&i.RevokedAt,
+		&i.LastSeenAt,
+		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
 	)
 	return i, err
 }
@@ -181,7 +454,7 @@ const updateDeviceLastSeen = `-- name: UpdateDeviceLastSeen :one
 UPDATE devices
 SET last_seen_at = $2
 WHERE id = $1
-RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 `
 
 type UpdateDeviceLastSeenParams struct {
@@ -202,6 +475,11 @@ func (q *Queries) UpdateDeviceLastSeen(ctx context.Context, arg UpdateDeviceLast
 		&i.RevokedAt,
 		&i.LastSeenAt,
 		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
 	)
 	return i, err
 }
@@ -210,7 +488,7 @@ const updateDeviceTrusted = `-- name: UpdateDeviceTrusted :one
 UPDATE devices
 SET trusted = $2
 WHERE id = $1
-RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 `
 
 type UpdateDeviceTrustedParams struct {
@@ -231,15 +509,20 @@ func (q *Queries) UpdateDeviceTrusted(ctx context.Context, arg UpdateDeviceTrust
 		&i.RevokedAt,
 		&i.LastSeenAt,
 		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
 	)
 	return i, err
 }
 
 const updateDeviceTrustedWithExpiry = `-- name: UpdateDeviceTrustedWithExpiry :one
 UPDATE devices
-SET trusted = $2, trusted_until = $3, revoked_at = NULL
+SET trusted = $2, trusted_until = $3, revoked_at = NULL, trust_expiry_notified_at = NULL
 WHERE id = $1
-RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+RETURNING id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 `
 
 type UpdateDeviceTrustedWithExpiryParams struct {
@@ -261,6 +544,11 @@ func (q *Queries) UpdateDeviceTrustedWithExpiry(ctx context.Context, arg UpdateD
 		&i.RevokedAt,
 		&i.LastSeenAt,
 		&i.CreatedAt,
+		&i.TrustExpiryNotifiedAt,
+		&i.TrustDays,
+		&i.QuarantinedAt,
+		&i.QuarantineReason,
+		&i.QuarantinedBy,
 	)
 	return i, err
 }
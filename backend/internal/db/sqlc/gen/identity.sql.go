@@ -143,3 +143,23 @@ func (q *Queries) UpdateIdentityPasswordHash(ctx context.Context, arg UpdateIden
 	)
 	return i, err
 }
+
+const updateLocalIdentityProviderID = `-- name: UpdateLocalIdentityProviderID :execrows
+UPDATE identities
+SET provider_id = $1
+WHERE user_id = $2 AND provider = 'local'
+`
+
+type UpdateLocalIdentityProviderIDParams struct {
+	ProviderID string
+	UserID     string
+}
+
+// Sets the provider_id of the user's local identity, which is their email.
+func (q *Queries) UpdateLocalIdentityProviderID(ctx context.Context, arg UpdateLocalIdentityProviderIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateLocalIdentityProviderID, arg.ProviderID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
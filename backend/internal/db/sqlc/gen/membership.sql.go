@@ -10,6 +10,18 @@ import (
 	"time"
 )
 
+const countMembershipsByOrg = `-- name: CountMembershipsByOrg :one
+SELECT COUNT(*) FROM memberships
+WHERE org_id = $1
+`
+
+func (q *Queries) CountMembershipsByOrg(ctx context.Context, orgID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMembershipsByOrg, orgID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countOwnersByOrg = `-- name: CountOwnersByOrg :one
 SELECT COUNT(*) FROM memberships
 WHERE org_id = $1 AND role = 'owner'
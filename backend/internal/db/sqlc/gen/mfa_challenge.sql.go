@@ -7,24 +7,27 @@ package gen
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
 const createMFAChallenge = `-- name: CreateMFAChallenge :one
-INSERT INTO mfa_challenges (id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at
+INSERT INTO mfa_challenges (id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at, method, trust_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at, method, attempts, resend_count, last_sent_at, trust_days
 `
 
 type CreateMFAChallengeParams struct {
 	ID        string
 	UserID    string
 	OrgID     string
-	DeviceID  string
+	DeviceID  sql.NullString
 	Phone     string
 	CodeHash  string
 	ExpiresAt time.Time
 	CreatedAt time.Time
+	Method    string
+	TrustDays int32
 }
 
 func (q *Queries) CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) (MfaChallenge, error) {
@@ -37,6 +40,8 @@ func (q *Queries) CreateMFAChallenge(ctx context.Context, arg CreateMFAChallenge
 		arg.CodeHash,
 		arg.ExpiresAt,
 		arg.CreatedAt,
+		arg.Method,
+		arg.TrustDays,
 	)
 	var i MfaChallenge
 	err := row.Scan(
@@ -48,6 +53,11 @@ func (q *Queries) CreateMFAChallenge(ctx context.Context, arg CreateMFAChallenge
 		&i.CodeHash,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.Method,
+		&i.Attempts,
+		&i.ResendCount,
+		&i.LastSentAt,
+		&i.TrustDays,
 	)
 	return i, err
 }
@@ -62,8 +72,18 @@ func (q *Queries) DeleteMFAChallenge(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteMFAChallengesByUser = `-- name: DeleteMFAChallengesByUser :exec
+DELETE FROM mfa_challenges
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteMFAChallengesByUser(ctx context.Context, userID string) error {
+	_, err := q.db.ExecContext(ctx, deleteMFAChallengesByUser, userID)
+	return err
+}
+
 const getMFAChallenge = `-- name: GetMFAChallenge :one
-SELECT id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at
+SELECT id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at, method, attempts, resend_count, last_sent_at, trust_days
 FROM mfa_challenges
 WHERE id = $1
 `
@@ -80,6 +100,56 @@ func (q *Queries) GetMFAChallenge(ctx context.Context, id string) (MfaChallenge,
 		&i.CodeHash,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.Method,
+		&i.Attempts,
+		&i.ResendCount,
+		&i.LastSentAt,
+		&i.TrustDays,
 	)
 	return i, err
 }
+
+const incrementMFAChallengeAttempts = `-- name: IncrementMFAChallengeAttempts :one
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING attempts
+`
+
+// Counts a wrong code and returns the new total.
+func (q *Queries) IncrementMFAChallengeAttempts(ctx context.Context, id string) (int32, error) {
+	row := q.db.QueryRowContext(ctx, incrementMFAChallengeAttempts, id)
+	var attempts int32
+	err := row.Scan(&attempts)
+	return attempts, err
+}
+
+const resendMFAChallenge = `-- name: ResendMFAChallenge :execrows
+UPDATE mfa_challenges
+SET code_hash = $2, expires_at = $3, last_sent_at = $4, resend_count = resend_count + 1
+WHERE id = $1 AND resend_count = $5
+`
+
+type ResendMFAChallengeParams struct {
+	ID          string
+	CodeHash    string
+	ExpiresAt   time.Time
+	LastSentAt  sql.NullTime
+	ResendCount int32
+}
+
+// Replaces the code after a resend. Only applies if resend_count is still the value the caller checked, so concurrent
+// resends cannot exceed the cap.
+func (q *Queries) ResendMFAChallenge(ctx context.Context, arg ResendMFAChallengeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resendMFAChallenge,
+		arg.ID,
+		arg.CodeHash,
+		arg.ExpiresAt,
+		arg.LastSentAt,
+		arg.ResendCount,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
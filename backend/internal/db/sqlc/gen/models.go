@@ -181,6 +181,70 @@ func (ns NullUserStatus) Value() (driver.Value, error) {
 	return string(ns.UserStatus), nil
 }
 
+type Agent struct {
+	ID              string
+	OrgID           string
+	UserID          string
+	DeviceID        string
+	Platform        string
+	Version         string
+	PostureJson     string
+	PolicyVersion   int64
+	RegisteredAt    time.Time
+	LastHeartbeatAt time.Time
+	TrustDegradedAt sql.NullTime
+}
+
+type AnalyticsDailyBlockedDomain struct {
+	OrgID     string
+	Day       time.Time
+	Domain    string
+	Blocks    int64
+	UpdatedAt time.Time
+}
+
+type AnalyticsDailyCountrySession struct {
+	OrgID     string
+	Day       time.Time
+	Country   string
+	Sessions  int64
+	UpdatedAt time.Time
+}
+
+type AnalyticsDailyDeviceSession struct {
+	OrgID     string
+	Day       time.Time
+	DeviceID  string
+	Sessions  int64
+	UpdatedAt time.Time
+}
+
+type AnalyticsDailyLogin struct {
+	OrgID           string
+	Day             time.Time
+	LoginSuccesses  int64
+	LoginFailures   int64
+	MfaChallenges   int64
+	SessionsCreated int64
+	UpdatedAt       time.Time
+}
+
+type AnalyticsDailyPolicyViolation struct {
+	OrgID      string
+	Day        time.Time
+	Action     string
+	Violations int64
+	UpdatedAt  time.Time
+}
+
+type AnalyticsDailyUserViolation struct {
+	OrgID      string
+	Day        time.Time
+	UserID     string
+	Violations int64
+	UpdatedAt  time.Time
+}
+
 type AuditLog struct {
 	ID        string
 	OrgID     string
@@ -190,18 +254,172 @@ type AuditLog struct {
 	Ip        string
 	Metadata  sql.NullString
 	CreatedAt time.Time
+	RequestID sql.NullString
+}
+
+type AuditWebhook struct {
+	ID          string
+	OrgID       string
+	Url         string
+	Secret      string
+	Filter      string
+	Description string
+	Enabled     bool
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type BreakGlassAccount struct {
+	OrgID         string
+	UserID        string
+	DeviceID      string
+	SecretHash    string
+	ProvisionedBy string
+	ProvisionedAt time.Time
+}
+
+type BreakGlassActivation struct {
+	ID            string
+	OrgID         string
+	UserID        string
+	Status        string
+	Reason        string
+	RequestedBy   string
+	RequestedAt   time.Time
+	ApprovedBy    sql.NullString
+	ApprovedAt    sql.NullTime
+	ExpiresAt     sql.NullTime
+	EndedBy       sql.NullString
+	EndedAt       sql.NullTime
+	ReportSummary string
+	ReportedBy    sql.NullString
+	ReportedAt    sql.NullTime
+}
+
+type ChangeRequest struct {
+	ID            string
+	OrgID         string
+	Kind          string
+	Status        string
+	ProposedBy    string
+	Reason        string
+	PayloadJson   string
+	ReviewedBy    sql.NullString
+	ReviewComment string
+	ReviewedAt    sql.NullTime
+	CreatedAt     time.Time
+}
+
+type DataExport struct {
+	ID          string
+	UserID      string
+	RequestedBy string
+	TokenHash   string
+	Status      string
+	Archive     []byte
+	Error       string
+	CreatedAt   time.Time
+	ClaimedAt   sql.NullTime
+	CompletedAt sql.NullTime
+	ExpiresAt   time.Time
+	OrgID       string
+}
+
+type DataKey struct {
+	ID          string
+	Scope       string
+	WrappedKey  []byte
+	MasterKeyID string
+	CreatedAt   time.Time
+	RetiredAt   sql.NullTime
 }
 
 type Device struct {
-	ID           string
-	UserID       string
-	OrgID        string
-	Fingerprint  string
-	Trusted      bool
-	TrustedUntil sql.NullTime
-	RevokedAt    sql.NullTime
-	LastSeenAt   sql.NullTime
-	CreatedAt    time.Time
+	ID                    string
+	UserID                string
+	OrgID                 string
+	Fingerprint           string
+	Trusted               bool
+	TrustedUntil          sql.NullTime
+	RevokedAt             sql.NullTime
+	LastSeenAt            sql.NullTime
+	CreatedAt             time.Time
+	TrustExpiryNotifiedAt sql.NullTime
+	TrustDays             int32
+	QuarantinedAt         sql.NullTime
+	QuarantineReason      string
+	QuarantinedBy         string
+}
+
+type DeviceCode struct {
+	ID                string
+	DeviceCodeHash    string
+	UserCode          string
+	ClientName        string
+	DeviceFingerprint string
+	Ip                string
+	Status            string
+	UserID            sql.NullString
+	OrgID             sql.NullString
+	DecidedAt         sql.NullTime
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+}
+
+type EmailChange struct {
+	ID             string
+	UserID         string
+	OrgID          string
+	OldEmail       string
+	NewEmail       string
+	OldTokenHash   string
+	NewTokenHash   string
+	Ip             string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	OldConfirmedAt sql.NullTime
+	NewConfirmedAt sql.NullTime
+	CompletedAt    sql.NullTime
+}
+
+type FeatureFlag struct {
+	Key               string
+	Description       string
+	Enabled           bool
+	RolloutPercentage int32
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type FeatureFlagOrgOverride struct {
+	FlagKey   string
+	OrgID     string
+	Enabled   bool
+	UpdatedAt time.Time
+}
+
+type Group struct {
+	ID        string
+	OrgID     string
+	Name      string
+	CreatedAt time.Time
+}
+
+type GroupMember struct {
+	GroupID   string
+	UserID    string
+	Role      string
+	CreatedAt time.Time
+}
+
+type Honeytoken struct {
+	UserID          string
+	Label           string
+	CreatedBy       string
+	CreatedAt       time.Time
+	TriggerCount    int64
+	LastTriggeredAt sql.NullTime
 }
 
 type Identity struct {
@@ -213,6 +431,47 @@ type Identity struct {
 	CreatedAt    time.Time
 }
 
+type IpBlock struct {
+	Ip        string
+	Reason    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type KnownLoginContext struct {
+	UserID      string
+	Kind        string
+	Value       string
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+type LoginHold struct {
+	ID        string
+	OrgID     string
+	UserID    string
+	DeviceID  sql.NullString
+	TokenHash string
+	Reasons   string
+	Ip        string
+	Status    string
+	DecidedBy sql.NullString
+	DecidedAt sql.NullTime
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type MagicLink struct {
+	ID        string
+	TokenHash string
+	UserID    string
+	OrgID     string
+	Ip        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+}
+
 type Membership struct {
 	ID        string
 	UserID    string
@@ -221,23 +480,98 @@ type Membership struct {
 	CreatedAt time.Time
 }
 
+type MembershipAttribute struct {
+	MembershipID string
+	OrgID        string
+	Key          string
+	Value        string
+	UpdatedAt    time.Time
+}
+
 type MfaChallenge struct {
+	ID          string
+	UserID      string
+	OrgID       string
+	DeviceID    sql.NullString
+	Phone       string
+	CodeHash    string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	Method      string
+	Attempts    int32
+	ResendCount int32
+	LastSentAt  sql.NullTime
+	TrustDays   int32
+}
+
+type MfaIntent struct {
 	ID        string
 	UserID    string
 	OrgID     string
 	DeviceID  string
-	Phone     string
-	CodeHash  string
 	ExpiresAt time.Time
-	CreatedAt time.Time
 }
 
-type MfaIntent struct {
+type MfaRecoveryCode struct {
 	ID        string
 	UserID    string
+	CodeHash  string
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type NotificationPreference struct {
+	UserID            string
+	LoginAlertsOptOut bool
+	UpdatedAt         time.Time
+	Locale            string
+}
+
+type NotificationTemplate struct {
 	OrgID     string
-	DeviceID  string
-	ExpiresAt time.Time
+	Kind      string
+	Locale    string
+	Subject   string
+	Body      string
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+type OrgAttributeDefinition struct {
+	OrgID         string
+	Key           string
+	DisplayName   string
+	Description   string
+	Type          string
+	AllowedValues []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type OrgDomain struct {
+	ID            string
+	OrgID         string
+	Domain        string
+	Token         string
+	Status        string
+	CreatedBy     string
+	CreatedAt     time.Time
+	VerifiedAt    sql.NullTime
+	LastCheckedAt sql.NullTime
+}
+
+type OrgInvitation struct {
+	ID         string
+	OrgID      string
+	Email      string
+	Role       Role
+	TokenHash  string
+	InvitedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedAt sql.NullTime
+	AcceptedBy sql.NullString
+	RevokedAt  sql.NullTime
 }
 
 type OrgMfaSetting struct {
@@ -255,13 +589,48 @@ type OrgPolicyConfig struct {
 	OrgID      string
 	ConfigJson string
 	UpdatedAt  time.Time
+	Version    int64
+}
+
+type OrgPolicyConfigVersion struct {
+	OrgID           string
+	Version         int64
+	ConfigJson      string
+	ChangedBy       sql.NullString
+	ChangedAt       time.Time
+	Source          string
+	RestoredVersion sql.NullInt64
+}
+
+type OrgQuota struct {
+	OrgID                  string
+	Plan                   string
+	OrgRequestsPerMinute   sql.NullInt32
+	TokenRequestsPerMinute sql.NullInt32
+	UpdatedBy              string
+	UpdatedAt              time.Time
+}
+
+type OrgSmtpSetting struct {
+	OrgID          string
+	Host           string
+	Port           int32
+	Username       string
+	PasswordSecret string
+	FromAddress    string
+	UpdatedBy      string
+	UpdatedAt      time.Time
 }
 
 type Organization struct {
-	ID        string
-	Name      string
-	Status    OrgStatus
-	CreatedAt time.Time
+	ID         string
+	Name       string
+	Status     OrgStatus
+	CreatedAt  time.Time
+	DataRegion string
+	MaxUsers   int32
+	MaxDevices int32
+	Plan       string
 }
 
 type PlatformSetting struct {
@@ -277,11 +646,78 @@ type Policy struct {
 	CreatedAt time.Time
 }
 
+type PolicyPreset struct {
+	Name        string
+	DisplayName string
+	Description string
+	PolicyRules string
+	ConfigJson  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type PolicyViolation struct {
+	ID              string
+	OrgID           string
+	UserID          string
+	DeviceID        string
+	SessionID       string
+	Action          string
+	Target          sql.NullString
+	StepUpTriggered bool
+	CreatedAt       time.Time
+	Rule            string
+}
+
+type PrivilegeElevation struct {
+	ID            string
+	OrgID         string
+	UserID        string
+	Status        string
+	Justification string
+	DurationSecs  int32
+	ReviewedBy    sql.NullString
+	ReviewComment string
+	ReviewedAt    sql.NullTime
+	ExpiresAt     sql.NullTime
+	RevokedBy     sql.NullString
+	RevokedAt     sql.NullTime
+	CreatedAt     time.Time
+}
+
+type ScheduledPolicyConfigChange struct {
+	ID             string
+	OrgID          string
+	ConfigJson     string
+	UpdateMask     string
+	EffectiveAt    time.Time
+	Status         string
+	CreatedBy      string
+	CreatedAt      time.Time
+	ResolvedBy     sql.NullString
+	ResolvedAt     sql.NullTime
+	AppliedVersion sql.NullInt64
+	Error          string
+}
+
+type SecurityEvent struct {
+	ID             string
+	OrgID          sql.NullString
+	UserID         string
+	EventType      string
+	Severity       string
+	Ip             string
+	Metadata       sql.NullString
+	CreatedAt      time.Time
+	AcknowledgedAt sql.NullTime
+	DismissedAt    sql.NullTime
+}
+
 type Session struct {
 	ID               string
 	UserID           string
 	OrgID            string
-	DeviceID         string
+	DeviceID         sql.NullString
 	ExpiresAt        time.Time
 	RevokedAt        sql.NullTime
 	LastSeenAt       sql.NullTime
@@ -289,15 +725,90 @@ type Session struct {
 	RefreshJti       sql.NullString
 	RefreshTokenHash sql.NullString
 	CreatedAt        time.Time
+	Country          sql.NullString
+	PopJkt           sql.NullString
+	UserAgent        sql.NullString
+	ClientVersion    sql.NullString
+	AuthMethod       sql.NullString
+	MfaMethod        sql.NullString
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
+	Ephemeral        bool
 }
 
-type User struct {
+type SessionReplicationWatermark struct {
+	OriginRegion string
+	LastEventAt  time.Time
+	ReceivedAt   time.Time
+}
+
+type TelemetryEvent struct {
+	OrgID          string
+	EventID        string
+	SchemaVersion  int32
+	Type           string
+	OccurredAt     time.Time
+	ReceivedAt     time.Time
+	UserID         string
+	SessionID      string
+	DeviceID       string
+	Region         string
+	AttributesJson string
+	RequestID      string
+}
+
+type UrlException struct {
 	ID            string
-	Email         string
-	Name          sql.NullString
-	Phone         sql.NullString
-	PhoneVerified bool
-	Status        UserStatus
+	OrgID         string
+	UserID        string
+	Domain        string
+	Url           string
+	Status        string
+	Justification string
+	DurationSecs  int32
+	ReviewedBy    sql.NullString
+	ReviewComment string
+	ReviewedAt    sql.NullTime
+	ExpiresAt     sql.NullTime
+	RevokedBy     sql.NullString
+	RevokedAt     sql.NullTime
 	CreatedAt     time.Time
+}
+
+type UsageActiveUser struct {
+	OrgID  string
+	Month  time.Time
+	UserID string
+}
+
+type UsageMonthly struct {
+	OrgID         string
+	Month         time.Time
+	ActiveUsers   int64
+	Logins        int64
+	MfaChallenges int64
+	MfaResends    int64
+	ApiCalls      int64
 	UpdatedAt     time.Time
 }
+
+type User struct {
+	ID               string
+	Email            string
+	Name             sql.NullString
+	Phone            sql.NullString
+	PhoneVerified    bool
+	Status           UserStatus
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	MfaResetRequired bool
+	EmailHash        sql.NullString
+}
+
+type Username struct {
+	UserID    string
+	OrgID     string
+	Username  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -7,11 +7,40 @@ package gen
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const createOrgPolicyConfigVersion = `-- name: CreateOrgPolicyConfigVersion :exec
+INSERT INTO org_policy_config_versions (org_id, version, config_json, changed_by, changed_at, source, restored_version)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateOrgPolicyConfigVersionParams struct {
+	OrgID           string
+	Version         int64
+	ConfigJson      string
+	ChangedBy       sql.NullString
+	ChangedAt       time.Time
+	Source          string
+	RestoredVersion sql.NullInt64
+}
+
+func (q *Queries) CreateOrgPolicyConfigVersion(ctx context.Context, arg CreateOrgPolicyConfigVersionParams) error {
+	_, err := q.db.ExecContext(ctx, createOrgPolicyConfigVersion,
+		arg.OrgID,
+		arg.Version,
+		arg.ConfigJson,
+		arg.ChangedBy,
+		arg.ChangedAt,
+		arg.Source,
+		arg.RestoredVersion,
+	)
+	return err
+}
+
 const getOrgPolicyConfig = `-- name: GetOrgPolicyConfig :one
-SELECT org_id, config_json, updated_at
+SELECT org_id, config_json, updated_at, version
 FROM org_policy_config
 WHERE org_id = $1
 `
@@ -19,17 +48,142 @@ WHERE org_id = $1
 func (q *Queries) GetOrgPolicyConfig(ctx context.Context, orgID string) (OrgPolicyConfig, error) {
 	row := q.db.QueryRowContext(ctx, getOrgPolicyConfig, orgID)
 	var i OrgPolicyConfig
-	err := row.Scan(&i.OrgID, &i.ConfigJson, &i.UpdatedAt)
+	err := row.Scan(
+		&i.OrgID,
+		&i.ConfigJson,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getOrgPolicyConfigVersion = `-- name: GetOrgPolicyConfigVersion :one
+SELECT org_id, version, config_json, changed_by, changed_at, source, restored_version
+FROM org_policy_config_versions
+WHERE org_id = $1 AND version = $2
+`
+
+type GetOrgPolicyConfigVersionParams struct {
+	OrgID   string
+	Version int64
+}
+
+func (q *Queries) GetOrgPolicyConfigVersion(ctx context.Context, arg GetOrgPolicyConfigVersionParams) (OrgPolicyConfigVersion, error) {
+	row := q.db.QueryRowContext(ctx, getOrgPolicyConfigVersion, arg.OrgID, arg.Version)
+	var i OrgPolicyConfigVersion
+	err := row.Scan(
+		&i.OrgID,
+		&i.Version,
+		&i.ConfigJson,
+		&i.ChangedBy,
+		&i.ChangedAt,
+		&i.Source,
+		&i.RestoredVersion,
+	)
 	return i, err
 }
 
+const insertOrgPolicyConfigIfAbsent = `-- name: InsertOrgPolicyConfigIfAbsent :one
+INSERT INTO org_policy_config (org_id, config_json, updated_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (org_id) DO NOTHING
+RETURNING version
+`
+
+type InsertOrgPolicyConfigIfAbsentParams struct {
+	OrgID      string
+	ConfigJson string
+	UpdatedAt  time.Time
+}
+
+// Stores the org's first config; returns no row if the org already has one.
+func (q *Queries) InsertOrgPolicyConfigIfAbsent(ctx context.Context, arg InsertOrgPolicyConfigIfAbsentParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertOrgPolicyConfigIfAbsent, arg.OrgID, arg.ConfigJson, arg.UpdatedAt)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const listOrgPolicyConfigVersions = `-- name: ListOrgPolicyConfigVersions :many
+SELECT org_id, version, config_json, changed_by, changed_at, source, restored_version
+FROM org_policy_config_versions
+WHERE org_id = $1
+ORDER BY version DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListOrgPolicyConfigVersionsParams struct {
+	OrgID  string
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) ListOrgPolicyConfigVersions(ctx context.Context, arg ListOrgPolicyConfigVersionsParams) ([]OrgPolicyConfigVersion, error) {
+	rows, err := q.db.QueryContext(ctx, listOrgPolicyConfigVersions, arg.OrgID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrgPolicyConfigVersion
+	for rows.Next() {
+		var i OrgPolicyConfigVersion
+		if err := rows.Scan(
+			&i.OrgID,
+			&i.Version,
+			&i.ConfigJson,
+			&i.ChangedBy,
+			&i.ChangedAt,
+			&i.Source,
+			&i.RestoredVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateOrgPolicyConfigIfVersion = `-- name: UpdateOrgPolicyConfigIfVersion :one
+UPDATE org_policy_config
+SET config_json = $2, updated_at = $3, version = version + 1
+WHERE org_id = $1 AND version = $4
+RETURNING version
+`
+
+type UpdateOrgPolicyConfigIfVersionParams struct {
+	OrgID      string
+	ConfigJson string
+	UpdatedAt  time.Time
+	Version    int64
+}
+
+// Replaces the config only if its version is still $4; returns no row otherwise.
+func (q *Queries) UpdateOrgPolicyConfigIfVersion(ctx context.Context, arg UpdateOrgPolicyConfigIfVersionParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, updateOrgPolicyConfigIfVersion,
+		arg.OrgID,
+		arg.ConfigJson,
+		arg.UpdatedAt,
+		arg.Version,
+	)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
 const upsertOrgPolicyConfig = `-- name: UpsertOrgPolicyConfig :one
 INSERT INTO org_policy_config (org_id, config_json, updated_at)
 VALUES ($1, $2, $3)
 ON CONFLICT (org_id) DO UPDATE SET
     config_json = EXCLUDED.config_json,
-    updated_at = EXCLUDED.updated_at
-RETURNING org_id, config_json, updated_at
+    updated_at = EXCLUDED.updated_at,
+    version = org_policy_config.version + 1
+RETURNING org_id, config_json, updated_at, version
 `
 
 type UpsertOrgPolicyConfigParams struct {
@@ -41,6 +195,11 @@ type UpsertOrgPolicyConfigParams struct {
 func (q *Queries) UpsertOrgPolicyConfig(ctx context.Context, arg UpsertOrgPolicyConfigParams) (OrgPolicyConfig, error) {
 	row := q.db.QueryRowContext(ctx, upsertOrgPolicyConfig, arg.OrgID, arg.ConfigJson, arg.UpdatedAt)
 	var i OrgPolicyConfig
-	err := row.Scan(&i.OrgID, &i.ConfigJson, &i.UpdatedAt)
+	err := row.Scan(
+		&i.OrgID,
+		&i.ConfigJson,
+		&i.UpdatedAt,
+		&i.Version,
+	)
 	return i, err
 }
@@ -11,16 +11,17 @@ import (
 )
 
 const createOrganization = `-- name: CreateOrganization :one
-INSERT INTO organizations (id, name, status, created_at)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, status, created_at
+INSERT INTO organizations (id, name, status, created_at, data_region)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, status, created_at, data_region, max_users, max_devices, plan
 `
 
 type CreateOrganizationParams struct {
-	ID        string
-	Name      string
-	Status    OrgStatus
-	CreatedAt time.Time
+	ID         string
+	Name       string
+	Status     OrgStatus
+	CreatedAt  time.Time
+	DataRegion string
 }
 
 func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error) {
@@ -29,6 +30,7 @@ func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganization
 		arg.Name,
 		arg.Status,
 		arg.CreatedAt,
+		arg.DataRegion,
 	)
 	var i Organization
 	err := row.Scan(
@@ -36,12 +38,16 @@ func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganization
 		&i.Name,
 		&i.Status,
 		&i.CreatedAt,
+		&i.DataRegion,
+		&i.MaxUsers,
+		&i.MaxDevices,
+		&i.Plan,
 	)
 	return i, err
 }
 
 const getOrganization = `-- name: GetOrganization :one
-SELECT id, name, status, created_at
+SELECT id, name, status, created_at, data_region, max_users, max_devices, plan
 FROM organizations
 WHERE id = $1
 `
@@ -54,31 +60,133 @@ func (q *Queries) GetOrganization(ctx context.Context, id string) (Organization,
 		&i.Name,
 		&i.Status,
 		&i.CreatedAt,
+		&i.DataRegion,
+		&i.MaxUsers,
+		&i.MaxDevices,
+		&i.Plan,
 	)
 	return i, err
 }
 
+const getOrganizationQuotas = `-- name: GetOrganizationQuotas :one
+SELECT max_users, max_devices
+FROM organizations
+WHERE id = $1
+`
+
+type GetOrganizationQuotasRow struct {
+	MaxUsers   int32
+	MaxDevices int32
+}
+
+// Reads the quotas without a lock; quota checks lock the row only when a quota is set.
+func (q *Queries) GetOrganizationQuotas(ctx context.Context, id string) (GetOrganizationQuotasRow, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationQuotas, id)
+	var i GetOrganizationQuotasRow
+	err := row.Scan(&i.MaxUsers, &i.MaxDevices)
+	return i, err
+}
+
+const listOrganizations = `-- name: ListOrganizations :many
+SELECT id, name, status, created_at, data_region, max_users, max_devices, plan
+FROM organizations
+WHERE id <> '_system'
+ORDER BY created_at, id
+LIMIT $1 OFFSET $2
+`
+
+type ListOrganizationsParams struct {
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) ListOrganizations(ctx context.Context, arg ListOrganizationsParams) ([]Organization, error) {
+	rows, err := q.db.QueryContext(ctx, listOrganizations, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Organization
+	for rows.Next() {
+		var i Organization
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Status,
+			&i.CreatedAt,
+			&i.DataRegion,
+			&i.MaxUsers,
+			&i.MaxDevices,
+			&i.Plan,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockOrganizationQuotas = `-- name: LockOrganizationQuotas :one
+SELECT max_users, max_devices
+FROM organizations
+WHERE id = $1
+FOR UPDATE
+`
+
+type LockOrganizationQuotasRow struct {
+	MaxUsers   int32
+	MaxDevices int32
+}
+
+// Locks the org row until the transaction ends, so quota checks and inserts into one org run one at a time.
+func (q *Queries) LockOrganizationQuotas(ctx context.Context, id string) (LockOrganizationQuotasRow, error) {
+	row := q.db.QueryRowContext(ctx, lockOrganizationQuotas, id)
+	var i LockOrganizationQuotasRow
+	err := row.Scan(&i.MaxUsers, &i.MaxDevices)
+	return i, err
+}
+
 const updateOrganization = `-- name: UpdateOrganization :one
 UPDATE organizations
-SET name = $2, status = $3
+SET name = $2, status = $3, max_users = $4, max_devices = $5, plan = $6
 WHERE id = $1
-RETURNING id, name, status, created_at
+RETURNING id, name, status, created_at, data_region, max_users, max_devices, plan
 `
 
 type UpdateOrganizationParams struct {
-	ID     string
-	Name   string
-	Status OrgStatus
+	ID         string
+	Name       string
+	Status     OrgStatus
+	MaxUsers   int32
+	MaxDevices int32
+	Plan       string
 }
 
 func (q *Queries) UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error) {
-	row := q.db.QueryRowContext(ctx, updateOrganization, arg.ID, arg.Name, arg.Status)
+	row := q.db.QueryRowContext(ctx, updateOrganization,
+		arg.ID,
+		arg.Name,
+		arg.Status,
+		arg.MaxUsers,
+		arg.MaxDevices,
+		arg.Plan,
+	)
 	var i Organization
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Status,
 		&i.CreatedAt,
+		&i.DataRegion,
+		&i.MaxUsers,
+		&i.MaxDevices,
+		&i.Plan,
 	)
 	return i, err
 }
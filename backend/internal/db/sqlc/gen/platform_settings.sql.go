@@ -51,6 +51,20 @@ func (q *Queries) ListPlatformSettings(ctx context.Context) ([]PlatformSetting,
 	return items, nil
 }
 
+const notifySettingsChanged = `-- name: NotifySettingsChanged :exec
+SELECT pg_notify($1::text, $2::text)
+`
+
+type NotifySettingsChangedParams struct {
+	Channel string
+	Payload string
+}
+
+func (q *Queries) NotifySettingsChanged(ctx context.Context, arg NotifySettingsChangedParams) error {
+	_, err := q.db.ExecContext(ctx, notifySettingsChanged, arg.Channel, arg.Payload)
+	return err
+}
+
 const setPlatformSetting = `-- name: SetPlatformSetting :one
 INSERT INTO platform_settings (key, value_json)
 VALUES ($1, $2)
@@ -53,6 +53,21 @@ func (q *Queries) DeletePolicy(ctx context.Context, id string) error {
 	return err
 }
 
+const disableOrgPolicies = `-- name: DisableOrgPolicies :execrows
+UPDATE policies
+SET enabled = false
+WHERE org_id = $1 AND enabled = true
+`
+
+// Disables all of the org's enabled policies, e.g. before a preset's policy replaces them.
+func (q *Queries) DisableOrgPolicies(ctx context.Context, orgID string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, disableOrgPolicies, orgID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getEnabledPoliciesByOrg = `-- name: GetEnabledPoliciesByOrg :many
 SELECT id, org_id, rules, enabled, created_at
 FROM policies
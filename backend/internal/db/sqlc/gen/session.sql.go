@@ -11,17 +11,34 @@ import (
 	"time"
 )
 
+const countActiveSessionsByOrg = `-- name: CountActiveSessionsByOrg :one
+SELECT COUNT(*) FROM sessions
+WHERE org_id = $1 AND revoked_at IS NULL AND id <> $2
+`
+
+type CountActiveSessionsByOrgParams struct {
+	OrgID string
+	ID    string
+}
+
+func (q *Queries) CountActiveSessionsByOrg(ctx context.Context, arg CountActiveSessionsByOrgParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveSessionsByOrg, arg.OrgID, arg.ID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createSession = `-- name: CreateSession :one
-INSERT INTO sessions (id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+INSERT INTO sessions (id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, ephemeral)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 `
 
 type CreateSessionParams struct {
 	ID               string
 	UserID           string
 	OrgID            string
-	DeviceID         string
+	DeviceID         sql.NullString
 	ExpiresAt        time.Time
 	RevokedAt        sql.NullTime
 	LastSeenAt       sql.NullTime
@@ -29,6 +46,13 @@ type CreateSessionParams struct {
 	RefreshJti       sql.NullString
 	RefreshTokenHash sql.NullString
 	CreatedAt        time.Time
+	Country          sql.NullString
+	PopJkt           sql.NullString
+	UserAgent        sql.NullString
+	ClientVersion    sql.NullString
+	AuthMethod       sql.NullString
+	MfaMethod        sql.NullString
+	Ephemeral        bool
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -44,6 +68,13 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.RefreshJti,
 		arg.RefreshTokenHash,
 		arg.CreatedAt,
+		arg.Country,
+		arg.PopJkt,
+		arg.UserAgent,
+		arg.ClientVersion,
+		arg.AuthMethod,
+		arg.MfaMethod,
+		arg.Ephemeral,
 	)
 	var i Session
 	err := row.Scan(
@@ -58,12 +89,33 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.RefreshJti,
 		&i.RefreshTokenHash,
 		&i.CreatedAt,
+		&i.Country,
+		&i.PopJkt,
+		&i.UserAgent,
+		&i.ClientVersion,
+		&i.AuthMethod,
+		&i.MfaMethod,
+		&i.RevocationReason,
+		&i.RevokedBy,
+		&i.Ephemeral,
 	)
 	return i, err
 }
 
+const getLatestSessionReplicationReceivedAt = `-- name: GetLatestSessionReplicationReceivedAt :one
+SELECT COALESCE(MAX(received_at), 'epoch'::timestamptz)::timestamptz AS received_at
+FROM session_replication_watermarks
+`
+
+func (q *Queries) GetLatestSessionReplicationReceivedAt(ctx context.Context) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getLatestSessionReplicationReceivedAt)
+	var received_at time.Time
+	err := row.Scan(&received_at)
+	return received_at, err
+}
+
 const getSession = `-- name: GetSession :one
-SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 FROM sessions
 WHERE id = $1
 `
@@ -83,12 +135,226 @@ func (q *Queries) GetSession(ctx context.Context, id string) (Session, error) {
 		&i.RefreshJti,
 		&i.RefreshTokenHash,
 		&i.CreatedAt,
+		&i.Country,
+		&i.PopJkt,
+		&i.UserAgent,
+		&i.ClientVersion,
+		&i.AuthMethod,
+		&i.MfaMethod,
+		&i.RevocationReason,
+		&i.RevokedBy,
+		&i.Ephemeral,
+	)
+	return i, err
+}
+
+const getSessionDetails = `-- name: GetSessionDetails :one
+SELECT s.id, s.user_id, s.org_id, s.device_id, s.expires_at, s.revoked_at, s.last_seen_at, s.ip_address, s.created_at, s.user_agent, s.client_version, s.auth_method, s.mfa_method, s.revocation_reason, s.revoked_by, s.ephemeral,
+       u.email AS user_email, u.name AS user_name, d.fingerprint AS device_fingerprint, d.trusted AS device_trusted, d.trusted_until AS device_trusted_until, d.revoked_at AS device_revoked_at
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+LEFT JOIN devices d ON d.id = s.device_id
+WHERE s.id = $1
+`
+
+type GetSessionDetailsRow struct {
+	ID                 string
+	UserID             string
+	OrgID              string
+	DeviceID           sql.NullString
+	ExpiresAt          time.Time
+	RevokedAt          sql.NullTime
+	LastSeenAt         sql.NullTime
+	IpAddress          sql.NullString
+	CreatedAt          time.Time
+	UserAgent          sql.NullString
+	ClientVersion      sql.NullString
+	AuthMethod         sql.NullString
+	MfaMethod          sql.NullString
+	RevocationReason   sql.NullString
+	RevokedBy          sql.NullString
+	Ephemeral          bool
+	UserEmail          string
+	UserName           sql.NullString
+	DeviceFingerprint  sql.NullString
+	DeviceTrusted      sql.NullBool
+	DeviceTrustedUntil sql.NullTime
+	DeviceRevokedAt    sql.NullTime
+}
+
+// Returns the session with its user and device.
+func (q *Queries) GetSessionDetails(ctx context.Context, id string) (GetSessionDetailsRow, error) {
+	row := q.db.QueryRowContext(ctx, getSessionDetails, id)
+	var i GetSessionDetailsRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrgID,
+		&i.DeviceID,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.LastSeenAt,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.UserAgent,
+		&i.ClientVersion,
+		&i.AuthMethod,
+		&i.MfaMethod,
+		&i.RevocationReason,
+		&i.RevokedBy,
+		&i.Ephemeral,
+		&i.UserEmail,
+		&i.UserName,
+		&i.DeviceFingerprint,
+		&i.DeviceTrusted,
+		&i.DeviceTrustedUntil,
+		&i.DeviceRevokedAt,
 	)
 	return i, err
 }
 
+const listIdleEphemeralSessions = `-- name: ListIdleEphemeralSessions :many
+SELECT id, user_id, org_id
+FROM sessions
+WHERE ephemeral AND revoked_at IS NULL AND COALESCE(last_seen_at, created_at) < $2
+ORDER BY COALESCE(last_seen_at, created_at)
+LIMIT $1
+`
+
+type ListIdleEphemeralSessionsParams struct {
+	Limit     int32
+	IdleSince time.Time
+}
+
+type ListIdleEphemeralSessionsRow struct {
+	ID     string
+	UserID string
+	OrgID  string
+}
+
+// Active ephemeral (public device) sessions not refreshed since idle_since, longest idle first; feeds the ephemeral
+// session sweeper.
+func (q *Queries) ListIdleEphemeralSessions(ctx context.Context, arg ListIdleEphemeralSessionsParams) ([]ListIdleEphemeralSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listIdleEphemeralSessions, arg.Limit, arg.IdleSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListIdleEphemeralSessionsRow
+	for rows.Next() {
+		var i ListIdleEphemeralSessionsRow
+		if err := rows.Scan(&i.ID, &i.UserID, &i.OrgID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionDetailsByOrg = `-- name: ListSessionDetailsByOrg :many
+SELECT s.id, s.user_id, s.org_id, s.device_id, s.expires_at, s.revoked_at, s.last_seen_at, s.ip_address, s.created_at, s.user_agent, s.client_version, s.auth_method, s.mfa_method, s.revocation_reason, s.revoked_by, s.ephemeral,
+       u.email AS user_email, u.name AS user_name, d.fingerprint AS device_fingerprint, d.trusted AS device_trusted, d.trusted_until AS device_trusted_until, d.revoked_at AS device_revoked_at
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+LEFT JOIN devices d ON d.id = s.device_id
+WHERE s.org_id = $1 AND s.revoked_at IS NULL
+  AND ($4::text IS NULL OR s.user_id = $4)
+ORDER BY s.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListSessionDetailsByOrgParams struct {
+	OrgID  string
+	Limit  int32
+	Offset int32
+	UserID sql.NullString
+}
+
+type ListSessionDetailsByOrgRow struct {
+	ID                 string
+	UserID             string
+	OrgID              string
+	DeviceID           sql.NullString
+	ExpiresAt          time.Time
+	RevokedAt          sql.NullTime
+	LastSeenAt         sql.NullTime
+	IpAddress          sql.NullString
+	CreatedAt          time.Time
+	UserAgent          sql.NullString
+	ClientVersion      sql.NullString
+	AuthMethod         sql.NullString
+	MfaMethod          sql.NullString
+	RevocationReason   sql.NullString
+	RevokedBy          sql.NullString
+	Ephemeral          bool
+	UserEmail          string
+	UserName           sql.NullString
+	DeviceFingerprint  sql.NullString
+	DeviceTrusted      sql.NullBool
+	DeviceTrustedUntil sql.NullTime
+	DeviceRevokedAt    sql.NullTime
+}
+
+// Like ListSessionsByOrg, with each session's user and device.
+func (q *Queries) ListSessionDetailsByOrg(ctx context.Context, arg ListSessionDetailsByOrgParams) ([]ListSessionDetailsByOrgRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionDetailsByOrg,
+		arg.OrgID,
+		arg.Limit,
+		arg.Offset,
+		arg.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSessionDetailsByOrgRow
+	for rows.Next() {
+		var i ListSessionDetailsByOrgRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrgID,
+			&i.DeviceID,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+			&i.LastSeenAt,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.UserAgent,
+			&i.ClientVersion,
+			&i.AuthMethod,
+			&i.MfaMethod,
+			&i.RevocationReason,
+			&i.RevokedBy,
+			&i.Ephemeral,
+			&i.UserEmail,
+			&i.UserName,
+			&i.DeviceFingerprint,
+			&i.DeviceTrusted,
+			&i.DeviceTrustedUntil,
+			&i.DeviceRevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSessionsByOrg = `-- name: ListSessionsByOrg :many
-SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, created_at
+SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, created_at, user_agent, client_version, auth_method, mfa_method, ephemeral
 FROM sessions
 WHERE org_id = $1 AND revoked_at IS NULL
   AND ($4::text IS NULL OR user_id = $4)
@@ -104,15 +370,20 @@ type ListSessionsByOrgParams struct {
 }
 
 type ListSessionsByOrgRow struct {
-	ID         string
-	UserID     string
-	OrgID      string
-	DeviceID   string
-	ExpiresAt  time.Time
-	RevokedAt  sql.NullTime
-	LastSeenAt sql.NullTime
-	IpAddress  sql.NullString
-	CreatedAt  time.Time
+	ID            string
+	UserID        string
+	OrgID         string
+	DeviceID      sql.NullString
+	ExpiresAt     time.Time
+	RevokedAt     sql.NullTime
+	LastSeenAt    sql.NullTime
+	IpAddress     sql.NullString
+	CreatedAt     time.Time
+	UserAgent     sql.NullString
+	ClientVersion sql.NullString
+	AuthMethod    sql.NullString
+	MfaMethod     sql.NullString
+	Ephemeral     bool
 }
 
 func (q *Queries) ListSessionsByOrg(ctx context.Context, arg ListSessionsByOrgParams) ([]ListSessionsByOrgRow, error) {
@@ -139,6 +410,11 @@ func (q *Queries) ListSessionsByOrg(ctx context.Context, arg ListSessionsByOrgPa
 			&i.LastSeenAt,
 			&i.IpAddress,
 			&i.CreatedAt,
+			&i.UserAgent,
+			&i.ClientVersion,
+			&i.AuthMethod,
+			&i.MfaMethod,
+			&i.Ephemeral,
 		); err != nil {
 			return nil, err
 		}
@@ -154,7 +430,7 @@ func (q *Queries) ListSessionsByOrg(ctx context.Context, arg ListSessionsByOrgPa
 }
 
 const listSessionsByUserAndOrg = `-- name: ListSessionsByUserAndOrg :many
-SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 FROM sessions
 WHERE user_id = $1 AND org_id = $2 AND revoked_at IS NULL
 ORDER BY created_at
@@ -186,6 +462,67 @@ func (q *Queries) ListSessionsByUserAndOrg(ctx context.Context, arg ListSessions
 			&i.RefreshJti,
 			&i.RefreshTokenHash,
 			&i.CreatedAt,
+			&i.Country,
+			&i.PopJkt,
+			&i.UserAgent,
+			&i.ClientVersion,
+			&i.AuthMethod,
+			&i.MfaMethod,
+			&i.RevocationReason,
+			&i.RevokedBy,
+			&i.Ephemeral,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionsRevokedSince = `-- name: ListSessionsRevokedSince :many
+SELECT id, user_id, org_id, revoked_at, revocation_reason
+FROM sessions
+WHERE org_id = $1 AND revoked_at >= $2
+ORDER BY revoked_at, id
+LIMIT $3
+`
+
+type ListSessionsRevokedSinceParams struct {
+	OrgID     string
+	RevokedAt sql.NullTime
+	Limit     int32
+}
+
+type ListSessionsRevokedSinceRow struct {
+	ID               string
+	UserID           string
+	OrgID            string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+}
+
+// Sessions of the org revoked at or after $2, oldest revocation first; feeds SessionService.SubscribeRevocations.
+func (q *Queries) ListSessionsRevokedSince(ctx context.Context, arg ListSessionsRevokedSinceParams) ([]ListSessionsRevokedSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionsRevokedSince, arg.OrgID, arg.RevokedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSessionsRevokedSinceRow
+	for rows.Next() {
+		var i ListSessionsRevokedSinceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrgID,
+			&i.RevokedAt,
+			&i.RevocationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -202,51 +539,105 @@ func (q *Queries) ListSessionsByUserAndOrg(ctx context.Context, arg ListSessions
 
 const revokeAllSessionsByUser = `-- name: RevokeAllSessionsByUser :exec
 UPDATE sessions
-SET revoked_at = $2
-WHERE user_id = $1
+SET revoked_at = $2, revocation_reason = $3, revoked_by = $4
+WHERE user_id = $1 AND revoked_at IS NULL
 `
 
 type RevokeAllSessionsByUserParams struct {
-	UserID    string
-	RevokedAt sql.NullTime
+	UserID           string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
 }
 
 func (q *Queries) RevokeAllSessionsByUser(ctx context.Context, arg RevokeAllSessionsByUserParams) error {
-	_, err := q.db.ExecContext(ctx, revokeAllSessionsByUser, arg.UserID, arg.RevokedAt)
+	_, err := q.db.ExecContext(ctx, revokeAllSessionsByUser,
+		arg.UserID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
 	return err
 }
 
 const revokeAllSessionsByUserAndOrg = `-- name: RevokeAllSessionsByUserAndOrg :exec
 UPDATE sessions
-SET revoked_at = $3
-WHERE user_id = $1 AND org_id = $2
+SET revoked_at = $3, revocation_reason = $4, revoked_by = $5
+WHERE user_id = $1 AND org_id = $2 AND revoked_at IS NULL
 `
 
 type RevokeAllSessionsByUserAndOrgParams struct {
-	UserID    string
-	OrgID     string
-	RevokedAt sql.NullTime
+	UserID           string
+	OrgID            string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
 }
 
 func (q *Queries) RevokeAllSessionsByUserAndOrg(ctx context.Context, arg RevokeAllSessionsByUserAndOrgParams) error {
-	_, err := q.db.ExecContext(ctx, revokeAllSessionsByUserAndOrg, arg.UserID, arg.OrgID, arg.RevokedAt)
+	_, err := q.db.ExecContext(ctx, revokeAllSessionsByUserAndOrg,
+		arg.UserID,
+		arg.OrgID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
 	return err
 }
 
+const revokeOtherSessionsByUser = `-- name: RevokeOtherSessionsByUser :execrows
+UPDATE sessions
+SET revoked_at = $3, revocation_reason = $4, revoked_by = $5
+WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+`
+
+type RevokeOtherSessionsByUserParams struct {
+	UserID           string
+	ID               string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
+}
+
+// Revokes the user's active sessions in every org except session $2 (all of them when $2 is empty).
+func (q *Queries) RevokeOtherSessionsByUser(ctx context.Context, arg RevokeOtherSessionsByUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeOtherSessionsByUser,
+		arg.UserID,
+		arg.ID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const revokeSession = `-- name: RevokeSession :one
 UPDATE sessions
-SET revoked_at = $2
+SET revoked_at = COALESCE(revoked_at, $2),
+    revocation_reason = CASE WHEN revoked_at IS NULL THEN $3::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL THEN $4::varchar ELSE revoked_by END
 WHERE id = $1
-RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 `
 
 type RevokeSessionParams struct {
-	ID        string
-	RevokedAt sql.NullTime
+	ID               string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
 }
 
+// A session that is already revoked keeps its first revocation time, reason and actor.
 func (q *Queries) RevokeSession(ctx context.Context, arg RevokeSessionParams) (Session, error) {
-	row := q.db.QueryRowContext(ctx, revokeSession, arg.ID, arg.RevokedAt)
+	row := q.db.QueryRowContext(ctx, revokeSession,
+		arg.ID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
@@ -260,15 +651,196 @@ func (q *Queries) RevokeSession(ctx context.Context, arg RevokeSessionParams) (S
 		&i.RefreshJti,
 		&i.RefreshTokenHash,
 		&i.CreatedAt,
+		&i.Country,
+		&i.PopJkt,
+		&i.UserAgent,
+		&i.ClientVersion,
+		&i.AuthMethod,
+		&i.MfaMethod,
+		&i.RevocationReason,
+		&i.RevokedBy,
+		&i.Ephemeral,
 	)
 	return i, err
 }
 
+const revokeSessionAt = `-- name: RevokeSessionAt :execrows
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $2),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $2 THEN $3::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $2 THEN $4::varchar ELSE revoked_by END
+WHERE id = $1
+`
+
+type RevokeSessionAtParams struct {
+	ID               string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
+}
+
+// Applies a replicated revocation: the earliest revocation time wins, together with its reason and actor.
+func (q *Queries) RevokeSessionAt(ctx context.Context, arg RevokeSessionAtParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeSessionAt,
+		arg.ID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeSessionsByOrgBatch = `-- name: RevokeSessionsByOrgBatch :many
+UPDATE sessions
+SET revoked_at = $2, revocation_reason = $6, revoked_by = $7
+WHERE id IN (
+    SELECT id FROM sessions
+    WHERE org_id = $1 AND revoked_at IS NULL AND id <> $3 AND created_at <= $4
+    ORDER BY created_at
+    LIMIT $5
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id
+`
+
+type RevokeSessionsByOrgBatchParams struct {
+	OrgID            string
+	RevokedAt        sql.NullTime
+	ID               string
+	CreatedAt        time.Time
+	Limit            int32
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
+}
+
+type RevokeSessionsByOrgBatchRow struct {
+	ID     string
+	UserID string
+}
+
+// Revokes up to $5 active sessions in the org created at or before $4, except session $3.
+func (q *Queries) RevokeSessionsByOrgBatch(ctx context.Context, arg RevokeSessionsByOrgBatchParams) ([]RevokeSessionsByOrgBatchRow, error) {
+	rows, err := q.db.QueryContext(ctx, revokeSessionsByOrgBatch,
+		arg.OrgID,
+		arg.RevokedAt,
+		arg.ID,
+		arg.CreatedAt,
+		arg.Limit,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RevokeSessionsByOrgBatchRow
+	for rows.Next() {
+		var i RevokeSessionsByOrgBatchRow
+		if err := rows.Scan(&i.ID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeSessionsByOrgBefore = `-- name: RevokeSessionsByOrgBefore :exec
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $3),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN $4::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN $5::varchar ELSE revoked_by END
+WHERE org_id = $1 AND id <> $2 AND created_at <= $3
+`
+
+type RevokeSessionsByOrgBeforeParams struct {
+	OrgID            string
+	ID               string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
+}
+
+// Applies a replicated org-wide revocation to sessions created before it, except session $2.
+func (q *Queries) RevokeSessionsByOrgBefore(ctx context.Context, arg RevokeSessionsByOrgBeforeParams) error {
+	_, err := q.db.ExecContext(ctx, revokeSessionsByOrgBefore,
+		arg.OrgID,
+		arg.ID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
+	return err
+}
+
+const revokeSessionsByUserAndOrgBefore = `-- name: RevokeSessionsByUserAndOrgBefore :exec
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $3),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN $4::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN $5::varchar ELSE revoked_by END
+WHERE user_id = $1 AND org_id = $2 AND created_at <= $3
+`
+
+type RevokeSessionsByUserAndOrgBeforeParams struct {
+	UserID           string
+	OrgID            string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
+}
+
+func (q *Queries) RevokeSessionsByUserAndOrgBefore(ctx context.Context, arg RevokeSessionsByUserAndOrgBeforeParams) error {
+	_, err := q.db.ExecContext(ctx, revokeSessionsByUserAndOrgBefore,
+		arg.UserID,
+		arg.OrgID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
+	return err
+}
+
+const revokeSessionsByUserBefore = `-- name: RevokeSessionsByUserBefore :exec
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $3),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN $4::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN $5::varchar ELSE revoked_by END
+WHERE user_id = $1 AND id <> $2 AND created_at <= $3
+`
+
+type RevokeSessionsByUserBeforeParams struct {
+	UserID           string
+	ID               string
+	RevokedAt        sql.NullTime
+	RevocationReason sql.NullString
+	RevokedBy        sql.NullString
+}
+
+// Applies a replicated user-wide revocation to sessions created before it, except session $2, so later logins survive.
+func (q *Queries) RevokeSessionsByUserBefore(ctx context.Context, arg RevokeSessionsByUserBeforeParams) error {
+	_, err := q.db.ExecContext(ctx, revokeSessionsByUserBefore,
+		arg.UserID,
+		arg.ID,
+		arg.RevokedAt,
+		arg.RevocationReason,
+		arg.RevokedBy,
+	)
+	return err
+}
+
 const updateSessionLastSeen = `-- name: UpdateSessionLastSeen :one
 UPDATE sessions
 SET last_seen_at = $2
 WHERE id = $1
-RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 `
 
 type UpdateSessionLastSeenParams struct {
@@ -291,25 +863,40 @@ func (q *Queries) UpdateSessionLastSeen(ctx context.Context, arg UpdateSessionLa
 		&i.RefreshJti,
 		&i.RefreshTokenHash,
 		&i.CreatedAt,
+		&i.Country,
+		&i.PopJkt,
+		&i.UserAgent,
+		&i.ClientVersion,
+		&i.AuthMethod,
+		&i.MfaMethod,
+		&i.RevocationReason,
+		&i.RevokedBy,
+		&i.Ephemeral,
 	)
 	return i, err
 }
 
 const updateSessionRefreshToken = `-- name: UpdateSessionRefreshToken :one
 UPDATE sessions
-SET refresh_jti = $2, refresh_token_hash = $3
+SET refresh_jti = $2, refresh_token_hash = $3, expires_at = $4
 WHERE id = $1
-RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+RETURNING id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 `
 
 type UpdateSessionRefreshTokenParams struct {
 	ID               string
 	RefreshJti       sql.NullString
 	RefreshTokenHash sql.NullString
+	ExpiresAt        time.Time
 }
 
 func (q *Queries) UpdateSessionRefreshToken(ctx context.Context, arg UpdateSessionRefreshTokenParams) (Session, error) {
-	row := q.db.QueryRowContext(ctx, updateSessionRefreshToken, arg.ID, arg.RefreshJti, arg.RefreshTokenHash)
+	row := q.db.QueryRowContext(ctx, updateSessionRefreshToken,
+		arg.ID,
+		arg.RefreshJti,
+		arg.RefreshTokenHash,
+		arg.ExpiresAt,
+	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
@@ -323,6 +910,34 @@ func (q *Queries) UpdateSessionRefreshToken(ctx context.Context, arg UpdateSessi
 		&i.RefreshJti,
 		&i.RefreshTokenHash,
 		&i.CreatedAt,
+		&i.Country,
+		&i.PopJkt,
+		&i.UserAgent,
+		&i.ClientVersion,
+		&i.AuthMethod,
+		&i.MfaMethod,
+		&i.RevocationReason,
+		&i.RevokedBy,
+		&i.Ephemeral,
 	)
 	return i, err
 }
+
+const upsertSessionReplicationWatermark = `-- name: UpsertSessionReplicationWatermark :exec
+INSERT INTO session_replication_watermarks (origin_region, last_event_at, received_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (origin_region) DO UPDATE
+SET last_event_at = GREATEST(session_replication_watermarks.last_event_at, EXCLUDED.last_event_at),
+    received_at = EXCLUDED.received_at
+`
+
+type UpsertSessionReplicationWatermarkParams struct {
+	OriginRegion string
+	LastEventAt  time.Time
+	ReceivedAt   time.Time
+}
+
+func (q *Queries) UpsertSessionReplicationWatermark(ctx context.Context, arg UpsertSessionReplicationWatermarkParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSessionReplicationWatermark, arg.OriginRegion, arg.LastEventAt, arg.ReceivedAt)
+	return err
+}
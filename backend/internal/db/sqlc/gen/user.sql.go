@@ -11,10 +11,83 @@ import (
 	"time"
 )
 
+const changeUserEmail = `-- name: ChangeUserEmail :execrows
+UPDATE users
+SET email = $1, email_hash = $2, updated_at = $3
+WHERE id = $4 AND email = $5
+`
+
+type ChangeUserEmailParams struct {
+	NewEmail  string
+	EmailHash sql.NullString
+	UpdatedAt time.Time
+	ID        string
+	OldEmail  string
+}
+
+// Replaces the user's stored email and email_hash only if the stored email is still old_email; no rows if it changed
+// meanwhile.
+func (q *Queries) ChangeUserEmail(ctx context.Context, arg ChangeUserEmailParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, changeUserEmail,
+		arg.NewEmail,
+		arg.EmailHash,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.OldEmail,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const changeUserPhone = `-- name: ChangeUserPhone :execrows
+UPDATE users
+SET phone = $1, phone_verified = true, mfa_reset_required = false, updated_at = $2
+WHERE id = $3 AND COALESCE(phone, '') = $4::VARCHAR
+`
+
+type ChangeUserPhoneParams struct {
+	NewPhone  sql.NullString
+	UpdatedAt time.Time
+	ID        string
+	OldPhone  string
+}
+
+// Replaces the user's phone, marking it verified, only if it is still old_phone; no rows if it changed meanwhile.
+func (q *Queries) ChangeUserPhone(ctx context.Context, arg ChangeUserPhoneParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, changeUserPhone,
+		arg.NewPhone,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.OldPhone,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countLicensedUsers = `-- name: CountLicensedUsers :one
+SELECT COUNT(*) FROM users u
+WHERE u.status = 'active'
+  AND EXISTS (SELECT 1 FROM identities i WHERE i.user_id = u.id)
+  AND NOT EXISTS (SELECT 1 FROM honeytokens h WHERE h.user_id = u.id)
+`
+
+// Active users that can sign in, the seats of a self-hosted license. Accounts without an identity (break-glass
+// accounts) and honeytokens take no seat.
+func (q *Queries) CountLicensedUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLicensedUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (id, email, name, phone, phone_verified, status, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, email, name, phone, phone_verified, status, created_at, updated_at
+INSERT INTO users (id, email, name, phone, phone_verified, status, created_at, updated_at, email_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
 `
 
 type CreateUserParams struct {
@@ -26,6 +99,7 @@ type CreateUserParams struct {
 	Status        UserStatus
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	EmailHash     sql.NullString
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -38,6 +112,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Status,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.EmailHash,
 	)
 	var i User
 	err := row.Scan(
@@ -49,12 +124,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MfaResetRequired,
+		&i.EmailHash,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, email, name, phone, phone_verified, status, created_at, updated_at
+SELECT id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
 FROM users
 WHERE id = $1
 `
@@ -71,18 +148,26 @@ func (q *Queries) GetUser(ctx context.Context, id string) (User, error) {
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MfaResetRequired,
+		&i.EmailHash,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, name, phone, phone_verified, status, created_at, updated_at
+SELECT id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
 FROM users
-WHERE email = $1
+WHERE email_hash = $1 OR (email_hash IS NULL AND email = $2)
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+type GetUserByEmailParams struct {
+	EmailHash sql.NullString
+	Email     string
+}
+
+// Matches email_hash, or the plaintext email on rows written before PII encryption was enabled (no email_hash).
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, arg.EmailHash, arg.Email)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -93,13 +178,97 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MfaResetRequired,
+		&i.EmailHash,
 	)
 	return i, err
 }
 
+const listUsersForPIIReencryption = `-- name: ListUsersForPIIReencryption :many
+SELECT id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
+FROM users
+WHERE id > $1::VARCHAR
+  AND (email NOT LIKE $2::VARCHAR || '%'
+    OR (COALESCE(phone, '') <> '' AND phone NOT LIKE $2::VARCHAR || '%')
+    OR email_hash IS NULL)
+ORDER BY id
+LIMIT $3
+`
+
+type ListUsersForPIIReencryptionParams struct {
+	AfterID   string
+	KeyPrefix string
+	RowLimit  int32
+}
+
+// Users after after_id whose email or phone is not encrypted with the key of key_prefix, or that have no email_hash.
+func (q *Queries) ListUsersForPIIReencryption(ctx context.Context, arg ListUsersForPIIReencryptionParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersForPIIReencryption, arg.AfterID, arg.KeyPrefix, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.Phone,
+			&i.PhoneVerified,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MfaResetRequired,
+			&i.EmailHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockLicenseSeats = `-- name: LockLicenseSeats :exec
+SELECT pg_advisory_xact_lock(hashtext('license_seats'))
+`
+
+// Locks the license seats until the transaction ends, so seat checks and the users they admit run one at a time.
+func (q *Queries) LockLicenseSeats(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockLicenseSeats)
+	return err
+}
+
+const resetUserMFA = `-- name: ResetUserMFA :execrows
+UPDATE users
+SET phone = NULL, phone_verified = false, mfa_reset_required = true, updated_at = $2
+WHERE id = $1
+`
+
+type ResetUserMFAParams struct {
+	ID        string
+	UpdatedAt time.Time
+}
+
+// Clears the user's phone and requires MFA with a newly enrolled phone at next sign-in.
+func (q *Queries) ResetUserMFA(ctx context.Context, arg ResetUserMFAParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resetUserMFA, arg.ID, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const setPhoneVerified = `-- name: SetPhoneVerified :one
 UPDATE users
-SET phone = $2, phone_verified = true, updated_at = $3
+SET phone = $2, phone_verified = true, mfa_reset_required = false, updated_at = $3
 WHERE id = $1 AND (phone IS NULL OR phone = '') AND phone_verified = false
 RETURNING id
 `
@@ -119,9 +288,9 @@ func (q *Queries) SetPhoneVerified(ctx context.Context, arg SetPhoneVerifiedPara
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET email = $2, name = $3, phone = $4, phone_verified = $5, status = $6, updated_at = $7
+SET email = $2, name = $3, phone = $4, phone_verified = $5, status = $6, updated_at = $7, email_hash = $8
 WHERE id = $1
-RETURNING id, email, name, phone, phone_verified, status, created_at, updated_at
+RETURNING id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
 `
 
 type UpdateUserParams struct {
@@ -132,6 +301,7 @@ type UpdateUserParams struct {
 	PhoneVerified bool
 	Status        UserStatus
 	UpdatedAt     time.Time
+	EmailHash     sql.NullString
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -143,6 +313,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.PhoneVerified,
 		arg.Status,
 		arg.UpdatedAt,
+		arg.EmailHash,
 	)
 	var i User
 	err := row.Scan(
@@ -154,6 +325,39 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MfaResetRequired,
+		&i.EmailHash,
 	)
 	return i, err
 }
+
+const updateUserPII = `-- name: UpdateUserPII :execrows
+UPDATE users
+SET email = $1, phone = $2, email_hash = $3
+WHERE id = $4 AND email = $5 AND COALESCE(phone, '') = $6::VARCHAR
+`
+
+type UpdateUserPIIParams struct {
+	Email     string
+	Phone     sql.NullString
+	EmailHash sql.NullString
+	ID        string
+	OldEmail  string
+	OldPhone  string
+}
+
+// Replaces the stored email, phone and email_hash only if email and phone are still old_email and old_phone.
+func (q *Queries) UpdateUserPII(ctx context.Context, arg UpdateUserPIIParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserPII,
+		arg.Email,
+		arg.Phone,
+		arg.EmailHash,
+		arg.ID,
+		arg.OldEmail,
+		arg.OldPhone,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
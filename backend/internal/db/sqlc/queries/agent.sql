@@ -0,0 +1,39 @@
+-- name: GetAgent :one
+SELECT * FROM agents WHERE id = $1;
+
+-- name: ListAgentsByOrg :many
+SELECT * FROM agents
+WHERE org_id = $1
+  AND (sqlc.narg('filter_user_id')::text IS NULL OR user_id = sqlc.narg('filter_user_id'))
+  AND (sqlc.narg('silent_before')::timestamptz IS NULL OR last_heartbeat_at < sqlc.narg('silent_before'))
+ORDER BY last_heartbeat_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: ListSilentAgents :many
+-- Returns agents without a heartbeat since the given time whose device trust has not been degraded yet, longest
+-- silent first.
+SELECT * FROM agents
+WHERE trust_degraded_at IS NULL AND last_heartbeat_at < $1
+ORDER BY last_heartbeat_at, id
+LIMIT $2;
+
+-- name: MarkAgentTrustDegraded :execrows
+-- Marks a silent agent's device trust as degraded; no rows if it sent a heartbeat since silent_before or was already
+-- marked.
+UPDATE agents
+SET trust_degraded_at = sqlc.arg(degraded_at)
+WHERE id = sqlc.arg(id) AND trust_degraded_at IS NULL AND last_heartbeat_at < sqlc.arg(silent_before);
+
+-- name: RecordAgentHeartbeat :execrows
+UPDATE agents
+SET version = $2, posture_json = $3, policy_version = $4, last_heartbeat_at = $5, trust_degraded_at = NULL
+WHERE id = $1;
+
+-- name: UpsertAgent :one
+-- Registers the device's agent, or updates the existing one, keeping its ID and registered_at.
+INSERT INTO agents (id, org_id, user_id, device_id, platform, version, posture_json, registered_at, last_heartbeat_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+ON CONFLICT (device_id) DO UPDATE
+SET org_id = EXCLUDED.org_id, user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, version = EXCLUDED.version,
+    posture_json = EXCLUDED.posture_json, last_heartbeat_at = EXCLUDED.last_heartbeat_at, trust_degraded_at = NULL
+RETURNING *;
@@ -0,0 +1,133 @@
+-- name: RollupDailyLogins :exec
+INSERT INTO analytics_daily_logins (org_id, day, login_successes, login_failures, mfa_challenges, sessions_created, updated_at)
+SELECT a.org_id, sqlc.arg('day')::date,
+       COUNT(*) FILTER (WHERE a.action = 'login_success'),
+       COUNT(*) FILTER (WHERE a.action = 'login_failure'),
+       COUNT(*) FILTER (WHERE a.action = 'mfa_challenge_issued'),
+       COUNT(*) FILTER (WHERE a.action = 'session_created'),
+       sqlc.arg('updated_at')
+FROM audit_logs a
+WHERE a.created_at >= sqlc.arg('start_at') AND a.created_at < sqlc.arg('end_at')
+  AND a.action IN ('login_success', 'login_failure', 'mfa_challenge_issued', 'session_created')
+GROUP BY a.org_id
+ON CONFLICT (org_id, day) DO UPDATE
+SET login_successes = EXCLUDED.login_successes,
+    login_failures = EXCLUDED.login_failures,
+    mfa_challenges = EXCLUDED.mfa_challenges,
+    sessions_created = EXCLUDED.sessions_created,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RollupDailyDeviceSessions :exec
+INSERT INTO analytics_daily_device_sessions (org_id, day, device_id, sessions, updated_at)
+SELECT s.org_id, sqlc.arg('day')::date, s.device_id, COUNT(*), sqlc.arg('updated_at')
+FROM sessions s
+WHERE s.created_at >= sqlc.arg('start_at') AND s.created_at < sqlc.arg('end_at') AND s.device_id IS NOT NULL
+GROUP BY s.org_id, s.device_id
+ON CONFLICT (org_id, day, device_id) DO UPDATE
+SET sessions = EXCLUDED.sessions,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RollupDailyCountrySessions :exec
+INSERT INTO analytics_daily_country_sessions (org_id, day, country, sessions, updated_at)
+SELECT s.org_id, sqlc.arg('day')::date, COALESCE(NULLIF(s.country, ''), 'unknown'), COUNT(*), sqlc.arg('updated_at')
+FROM sessions s
+WHERE s.created_at >= sqlc.arg('start_at') AND s.created_at < sqlc.arg('end_at')
+GROUP BY s.org_id, COALESCE(NULLIF(s.country, ''), 'unknown')
+ON CONFLICT (org_id, day, country) DO UPDATE
+SET sessions = EXCLUDED.sessions,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RollupDailyPolicyViolations :exec
+INSERT INTO analytics_daily_policy_violations (org_id, day, action, violations, updated_at)
+SELECT v.org_id, sqlc.arg('day')::date, v.action, COUNT(*), sqlc.arg('updated_at')
+FROM policy_violations v
+WHERE v.created_at >= sqlc.arg('start_at') AND v.created_at < sqlc.arg('end_at')
+GROUP BY v.org_id, v.action
+ON CONFLICT (org_id, day, action) DO UPDATE
+SET violations = EXCLUDED.violations,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RollupDailyBlockedDomains :exec
+-- The CASE keeps the JSON cast to url_access_denied entries: other audit entries may have non-JSON metadata.
+INSERT INTO analytics_daily_blocked_domains (org_id, day, domain, blocks, updated_at)
+SELECT d.org_id, sqlc.arg('day')::date, d.domain, COUNT(*), sqlc.arg('updated_at')
+FROM (
+    SELECT a.org_id,
+           COALESCE(CASE WHEN a.action = 'url_access_denied' THEN a.metadata::jsonb ->> 'domain' END, '') AS domain
+    FROM audit_logs a
+    WHERE a.created_at >= sqlc.arg('start_at') AND a.created_at < sqlc.arg('end_at')
+      AND a.action = 'url_access_denied'
+) d
+GROUP BY d.org_id, d.domain
+ON CONFLICT (org_id, day, domain) DO UPDATE
+SET blocks = EXCLUDED.blocks,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RollupDailyUserViolations :exec
+INSERT INTO analytics_daily_user_violations (org_id, day, user_id, violations, updated_at)
+SELECT v.org_id, sqlc.arg('day')::date, v.user_id, COUNT(*), sqlc.arg('updated_at')
+FROM policy_violations v
+WHERE v.created_at >= sqlc.arg('start_at') AND v.created_at < sqlc.arg('end_at')
+GROUP BY v.org_id, v.user_id
+ON CONFLICT (org_id, day, user_id) DO UPDATE
+SET violations = EXCLUDED.violations,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: ListDailyLogins :many
+SELECT org_id, day, login_successes, login_failures, mfa_challenges, sessions_created, updated_at
+FROM analytics_daily_logins
+WHERE org_id = sqlc.arg('org_id') AND day >= sqlc.arg('from_day')::date AND day <= sqlc.arg('to_day')::date
+ORDER BY day;
+
+-- name: ListTopDevicesBySessions :many
+SELECT a.device_id, COALESCE(d.user_id, '')::text AS user_id, SUM(a.sessions)::bigint AS sessions
+FROM analytics_daily_device_sessions a
+LEFT JOIN devices d ON d.id = a.device_id
+WHERE a.org_id = sqlc.arg('org_id') AND a.day >= sqlc.arg('from_day')::date AND a.day <= sqlc.arg('to_day')::date
+GROUP BY a.device_id, d.user_id
+ORDER BY sessions DESC, a.device_id
+LIMIT sqlc.arg('max_results');
+
+-- name: ListSessionsByCountry :many
+SELECT country, SUM(sessions)::bigint AS sessions
+FROM analytics_daily_country_sessions
+WHERE org_id = sqlc.arg('org_id') AND day >= sqlc.arg('from_day')::date AND day <= sqlc.arg('to_day')::date
+GROUP BY country
+ORDER BY sessions DESC, country;
+
+-- name: ListPolicyViolationsByAction :many
+SELECT action, SUM(violations)::bigint AS violations
+FROM analytics_daily_policy_violations
+WHERE org_id = sqlc.arg('org_id') AND day >= sqlc.arg('from_day')::date AND day <= sqlc.arg('to_day')::date
+GROUP BY action
+ORDER BY violations DESC, action;
+
+-- name: ListTopBlockedDomains :many
+SELECT domain, SUM(blocks)::bigint AS blocks
+FROM analytics_daily_blocked_domains
+WHERE org_id = sqlc.arg('org_id') AND day >= sqlc.arg('from_day')::date AND day <= sqlc.arg('to_day')::date
+GROUP BY domain
+ORDER BY blocks DESC, domain
+LIMIT sqlc.arg('max_results');
+
+-- name: ListTopViolators :many
+SELECT user_id, SUM(violations)::bigint AS violations
+FROM analytics_daily_user_violations
+WHERE org_id = sqlc.arg('org_id') AND day >= sqlc.arg('from_day')::date AND day <= sqlc.arg('to_day')::date
+GROUP BY user_id
+ORDER BY violations DESC, user_id
+LIMIT sqlc.arg('max_results');
+
+-- name: ListDailyBlockTotals :many
+SELECT day, SUM(blocks)::bigint AS blocks
+FROM analytics_daily_blocked_domains
+WHERE org_id = sqlc.arg('org_id') AND day >= sqlc.arg('from_day')::date AND day <= sqlc.arg('to_day')::date
+GROUP BY day
+ORDER BY day;
+
+-- name: ListDailyViolationTotals :many
+SELECT day, SUM(violations)::bigint AS violations
+FROM analytics_daily_policy_violations
+WHERE org_id = sqlc.arg('org_id') AND day >= sqlc.arg('from_day')::date AND day <= sqlc.arg('to_day')::date
+GROUP BY day
+ORDER BY day;
@@ -1,26 +1,88 @@
+-- name: CountAuditLogsByUser :one
+SELECT COUNT(*) FROM audit_logs WHERE user_id = sqlc.arg('user_id')::text;
+
 -- name: GetAuditLog :one
-SELECT id, org_id, user_id, action, resource, ip, metadata, created_at
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
 FROM audit_logs
 WHERE id = $1;
 
 -- name: ListAuditLogsByOrg :many
-SELECT id, org_id, user_id, action, resource, ip, metadata, created_at
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
 FROM audit_logs
 WHERE org_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListAuditLogsByOrgFiltered :many
-SELECT id, org_id, user_id, action, resource, ip, metadata, created_at
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
 FROM audit_logs
 WHERE org_id = $1
   AND (sqlc.narg('filter_user_id')::text IS NULL OR user_id = sqlc.narg('filter_user_id'))
   AND (sqlc.narg('filter_action')::text IS NULL OR action = sqlc.narg('filter_action'))
   AND (sqlc.narg('filter_resource')::text IS NULL OR resource = sqlc.narg('filter_resource'))
+  AND (sqlc.narg('filter_request_id')::text IS NULL OR request_id = sqlc.narg('filter_request_id'))
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ListAuditLogsByOrgSince :many
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
+FROM audit_logs
+WHERE org_id = $1
+  AND created_at >= $2
+  AND (sqlc.narg('filter_user_id')::text IS NULL OR user_id = sqlc.narg('filter_user_id'))
+  AND (cardinality(sqlc.arg('filter_actions')::varchar[]) = 0 OR action = ANY(sqlc.arg('filter_actions')::varchar[]))
+ORDER BY created_at, id
+LIMIT $3;
+
+-- name: ListAuditLogsByUser :many
+-- The latest audit logs written with the user as the actor, newest first.
+SELECT id, org_id, user_id, action, resource, ip, metadata, created_at, request_id
+FROM audit_logs
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2;
+
 -- name: CreateAuditLog :one
-INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
+
+-- name: CreateAuditLogs :exec
+INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+SELECT id, org_id, NULLIF(user_id, ''), action, resource, ip, NULLIF(metadata, ''), created_at, NULLIF(request_id, '')
+FROM unnest(
+    sqlc.arg('ids')::varchar[], sqlc.arg('org_ids')::varchar[], sqlc.arg('user_ids')::varchar[],
+    sqlc.arg('actions')::varchar[], sqlc.arg('resources')::varchar[], sqlc.arg('ips')::varchar[],
+    sqlc.arg('metadata')::text[], sqlc.arg('created_ats')::timestamptz[], sqlc.arg('request_ids')::varchar[]
+) AS t(id, org_id, user_id, action, resource, ip, metadata, created_at, request_id);
+
+-- name: CreateAuditLogPartition :one
+SELECT audit_logs_create_partition(sqlc.arg('month')::date) AS created;
+
+-- name: DropAuditLogPartitionsBefore :many
+SELECT audit_logs_drop_partitions_before(sqlc.arg('cutoff')::timestamptz)::text AS name;
+
+-- name: ListLoginFailureStatsByIP :many
+SELECT ip, COUNT(*)::bigint AS failures, COUNT(DISTINCT user_id)::bigint AS accounts
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND created_at >= sqlc.arg('since') AND ip <> 'unknown'
+GROUP BY ip
+HAVING COUNT(*) >= sqlc.arg('min_failures')::bigint OR COUNT(DISTINCT user_id) >= sqlc.arg('min_accounts')::bigint;
+
+-- name: ListLoginFailureStatsByUser :many
+SELECT user_id::text AS user_id, MAX(org_id)::text AS org_id, COUNT(*)::bigint AS failures, COUNT(DISTINCT ip)::bigint AS ips
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND created_at >= sqlc.arg('since') AND user_id IS NOT NULL AND ip <> 'unknown'
+GROUP BY user_id
+HAVING COUNT(DISTINCT ip) >= sqlc.arg('min_ips')::bigint;
+
+-- name: ListLoginFailureAccountsByIP :many
+SELECT user_id::text AS user_id, MAX(org_id)::text AS org_id
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND ip = sqlc.arg('ip') AND created_at >= sqlc.arg('since') AND user_id IS NOT NULL
+GROUP BY user_id;
+
+-- name: ListLoginFailureIPsByUser :many
+SELECT DISTINCT ip
+FROM audit_logs
+WHERE action IN ('login_failure', 'credentials_verify_failure') AND user_id = sqlc.arg('user_id')::text AND created_at >= sqlc.arg('since') AND ip <> 'unknown';
@@ -0,0 +1,25 @@
+-- name: CountAuditWebhooksByOrg :one
+SELECT COUNT(*) FROM audit_webhooks WHERE org_id = $1;
+
+-- name: CreateAuditWebhook :exec
+INSERT INTO audit_webhooks (id, org_id, url, secret, filter, description, enabled, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: DeleteAuditWebhook :execrows
+DELETE FROM audit_webhooks WHERE id = $1;
+
+-- name: GetAuditWebhook :one
+SELECT * FROM audit_webhooks WHERE id = $1;
+
+-- name: ListAuditWebhooksByOrg :many
+SELECT * FROM audit_webhooks WHERE org_id = $1 ORDER BY created_at, id;
+
+-- name: ListEnabledAuditWebhooksByOrg :many
+-- Returns the webhooks the org's audit events are delivered to.
+SELECT * FROM audit_webhooks WHERE org_id = $1 AND enabled ORDER BY created_at, id;
+
+-- name: UpdateAuditWebhook :execrows
+UPDATE audit_webhooks
+SET url = sqlc.arg(url), secret = sqlc.arg(secret), filter = sqlc.arg(filter), description = sqlc.arg(description),
+    enabled = sqlc.arg(enabled), updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,97 @@
+-- name: DumpOrgBackup :many
+-- Every backed-up row of an org, as JSON, in one statement so the rows come from one snapshot. Users are the org's
+-- members and the users its devices and settings refer to. Sessions are not backed up.
+WITH org_users AS (
+    SELECT user_id AS id FROM memberships WHERE org_id = sqlc.arg(org_id)
+    UNION SELECT user_id FROM devices WHERE org_id = sqlc.arg(org_id)
+    UNION SELECT gm.user_id FROM group_members gm JOIN groups g ON g.id = gm.group_id WHERE g.org_id = sqlc.arg(org_id)
+    UNION SELECT created_by FROM org_domains WHERE org_id = sqlc.arg(org_id)
+    UNION SELECT updated_by FROM org_smtp_settings WHERE org_id = sqlc.arg(org_id)
+    UNION SELECT updated_by FROM notification_templates WHERE org_id = sqlc.arg(org_id)
+)
+SELECT table_name, data FROM (
+    SELECT 'organizations' AS table_name, row_to_json(t)::text AS data FROM organizations t WHERE t.id = sqlc.arg(org_id)
+    UNION ALL SELECT 'users', row_to_json(t)::text FROM users t WHERE t.id IN (SELECT id FROM org_users)
+    UNION ALL SELECT 'identities', row_to_json(t)::text FROM identities t WHERE t.user_id IN (SELECT id FROM org_users)
+    UNION ALL SELECT 'memberships', row_to_json(t)::text FROM memberships t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'groups', row_to_json(t)::text FROM groups t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'group_members', row_to_json(t)::text FROM group_members t
+        WHERE t.group_id IN (SELECT id FROM groups WHERE org_id = sqlc.arg(org_id))
+    UNION ALL SELECT 'org_attribute_definitions', row_to_json(t)::text FROM org_attribute_definitions t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'membership_attributes', row_to_json(t)::text FROM membership_attributes t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'devices', row_to_json(t)::text FROM devices t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'policies', row_to_json(t)::text FROM policies t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'org_policy_config', row_to_json(t)::text FROM org_policy_config t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'org_policy_config_versions', row_to_json(t)::text FROM org_policy_config_versions t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'org_mfa_settings', row_to_json(t)::text FROM org_mfa_settings t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'org_domains', row_to_json(t)::text FROM org_domains t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'org_smtp_settings', row_to_json(t)::text FROM org_smtp_settings t WHERE t.org_id = sqlc.arg(org_id)
+    UNION ALL SELECT 'notification_templates', row_to_json(t)::text FROM notification_templates t WHERE t.org_id = sqlc.arg(org_id)
+) backup
+ORDER BY table_name, data;
+
+-- name: RestoreBackupDevices :execrows
+INSERT INTO devices
+SELECT * FROM json_populate_recordset(NULL::devices, sqlc.arg(data)::json);
+
+-- name: RestoreBackupGroupMembers :execrows
+INSERT INTO group_members
+SELECT * FROM json_populate_recordset(NULL::group_members, sqlc.arg(data)::json);
+
+-- name: RestoreBackupGroups :execrows
+INSERT INTO groups
+SELECT * FROM json_populate_recordset(NULL::groups, sqlc.arg(data)::json);
+
+-- name: RestoreBackupIdentities :execrows
+-- Inserts the backed-up rows; rows that already exist (shared with other orgs) are kept.
+INSERT INTO identities
+SELECT * FROM json_populate_recordset(NULL::identities, sqlc.arg(data)::json) ON CONFLICT DO NOTHING;
+
+-- name: RestoreBackupMembershipAttributes :execrows
+INSERT INTO membership_attributes
+SELECT * FROM json_populate_recordset(NULL::membership_attributes, sqlc.arg(data)::json);
+
+-- name: RestoreBackupMemberships :execrows
+INSERT INTO memberships
+SELECT * FROM json_populate_recordset(NULL::memberships, sqlc.arg(data)::json);
+
+-- name: RestoreBackupNotificationTemplates :execrows
+INSERT INTO notification_templates
+SELECT * FROM json_populate_recordset(NULL::notification_templates, sqlc.arg(data)::json);
+
+-- name: RestoreBackupOrgAttributeDefinitions :execrows
+INSERT INTO org_attribute_definitions
+SELECT * FROM json_populate_recordset(NULL::org_attribute_definitions, sqlc.arg(data)::json);
+
+-- name: RestoreBackupOrgDomains :execrows
+INSERT INTO org_domains
+SELECT * FROM json_populate_recordset(NULL::org_domains, sqlc.arg(data)::json);
+
+-- name: RestoreBackupOrgMfaSettings :execrows
+INSERT INTO org_mfa_settings
+SELECT * FROM json_populate_recordset(NULL::org_mfa_settings, sqlc.arg(data)::json);
+
+-- name: RestoreBackupOrgPolicyConfig :execrows
+INSERT INTO org_policy_config
+SELECT * FROM json_populate_recordset(NULL::org_policy_config, sqlc.arg(data)::json);
+
+-- name: RestoreBackupOrgPolicyConfigVersions :execrows
+INSERT INTO org_policy_config_versions
+SELECT * FROM json_populate_recordset(NULL::org_policy_config_versions, sqlc.arg(data)::json);
+
+-- name: RestoreBackupOrgSmtpSettings :execrows
+INSERT INTO org_smtp_settings
+SELECT * FROM json_populate_recordset(NULL::org_smtp_settings, sqlc.arg(data)::json);
+
+-- name: RestoreBackupOrganizations :execrows
+INSERT INTO organizations
+SELECT * FROM json_populate_recordset(NULL::organizations, sqlc.arg(data)::json);
+
+-- name: RestoreBackupPolicies :execrows
+INSERT INTO policies
+SELECT * FROM json_populate_recordset(NULL::policies, sqlc.arg(data)::json);
+
+-- name: RestoreBackupUsers :execrows
+-- Inserts the backed-up rows; rows that already exist (shared with other orgs) are kept.
+INSERT INTO users
+SELECT * FROM json_populate_recordset(NULL::users, sqlc.arg(data)::json) ON CONFLICT DO NOTHING;
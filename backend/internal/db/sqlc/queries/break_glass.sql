@@ -0,0 +1,64 @@
+-- name: ApproveBreakGlassActivation :execrows
+-- Unlocks a pending activation requested after pending_since; no rows if it is no longer pending or has lapsed.
+UPDATE break_glass_activations
+SET status = 'active', approved_by = sqlc.arg(approved_by), approved_at = sqlc.arg(approved_at), expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id) AND status = 'pending' AND requested_at > sqlc.arg(pending_since)::timestamptz;
+
+-- name: CloseBreakGlassActivation :execrows
+-- Records the post-incident report of an activation whose window has ended; no rows otherwise.
+UPDATE break_glass_activations
+SET status = 'closed', report_summary = sqlc.arg(report_summary), reported_by = sqlc.arg(reported_by),
+    reported_at = sqlc.arg(reported_at), ended_at = COALESCE(ended_at, expires_at)
+WHERE id = sqlc.arg(id) AND (status = 'ended' OR (status = 'active' AND expires_at <= sqlc.arg(reported_at)));
+
+-- name: CreateBreakGlassActivation :exec
+INSERT INTO break_glass_activations (id, org_id, user_id, status, reason, requested_by, requested_at, report_summary)
+VALUES ($1, $2, $3, $4, $5, $6, $7, '');
+
+-- name: EndBreakGlassActivation :execrows
+-- Ends an active activation early; no rows if it is not active or its window has already ended.
+UPDATE break_glass_activations
+SET status = 'ended', ended_by = sqlc.arg(ended_by), ended_at = sqlc.arg(ended_at)
+WHERE id = sqlc.arg(id) AND status = 'active' AND expires_at > sqlc.arg(ended_at);
+
+-- name: ExpireBreakGlassActivations :many
+-- Marks active activations whose window ended by now as ended and returns them.
+UPDATE break_glass_activations
+SET status = 'ended', ended_at = expires_at
+WHERE status = 'active' AND expires_at <= sqlc.arg(now)::timestamptz
+RETURNING *;
+
+-- name: GetActiveBreakGlassActivation :one
+-- Returns the org's active activation whose window has not ended by now, if any.
+SELECT * FROM break_glass_activations
+WHERE org_id = sqlc.arg(org_id) AND status = 'active' AND expires_at > sqlc.arg(now)::timestamptz
+ORDER BY expires_at DESC
+LIMIT 1;
+
+-- name: GetBreakGlassAccount :one
+SELECT * FROM break_glass_accounts WHERE org_id = $1;
+
+-- name: GetBreakGlassActivation :one
+SELECT * FROM break_glass_activations WHERE id = $1;
+
+-- name: HasOpenBreakGlassActivation :one
+-- Reports whether the org has an activation that blocks a new unlock request: one pending since pending_since, one
+-- active, or one ended without a post-incident report.
+SELECT EXISTS (
+    SELECT 1 FROM break_glass_activations
+    WHERE org_id = sqlc.arg(org_id)
+      AND ((status = 'pending' AND requested_at > sqlc.arg(pending_since)::timestamptz) OR status IN ('active', 'ended'))
+);
+
+-- name: ListBreakGlassActivationsByOrg :many
+SELECT * FROM break_glass_activations
+WHERE org_id = $1
+ORDER BY requested_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpsertBreakGlassAccount :exec
+-- Creates the org's break-glass account, or replaces its credential (rotation) keeping its user and device.
+INSERT INTO break_glass_accounts (org_id, user_id, device_id, secret_hash, provisioned_by, provisioned_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (org_id) DO UPDATE
+SET secret_hash = EXCLUDED.secret_hash, provisioned_by = EXCLUDED.provisioned_by, provisioned_at = EXCLUDED.provisioned_at;
@@ -0,0 +1,19 @@
+-- name: CreateChangeRequest :exec
+INSERT INTO change_requests (id, org_id, kind, status, proposed_by, reason, payload_json, review_comment, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, '', $8);
+
+-- name: GetChangeRequest :one
+SELECT * FROM change_requests WHERE id = $1;
+
+-- name: ListChangeRequestsByOrg :many
+SELECT * FROM change_requests
+WHERE org_id = $1
+  AND (sqlc.narg('filter_status')::text IS NULL OR status = sqlc.narg('filter_status'))
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: TransitionChangeRequest :execrows
+-- Moves the request from from_status to status, recording the review; no rows if its status is no longer from_status.
+UPDATE change_requests
+SET status = sqlc.arg(status), reviewed_by = sqlc.arg(reviewed_by), review_comment = sqlc.arg(review_comment), reviewed_at = sqlc.arg(reviewed_at)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status);
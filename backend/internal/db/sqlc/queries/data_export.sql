@@ -0,0 +1,69 @@
+-- name: ClaimDataExport :one
+-- Marks the oldest pending export, or one claimed before stale_before that never finished, as being generated at now
+-- and returns it. Concurrent jobs claim different exports.
+UPDATE data_exports
+SET status = 'generating', claimed_at = sqlc.arg(now)::timestamptz
+WHERE id = (
+    SELECT id FROM data_exports
+    WHERE status = 'pending' OR (status = 'generating' AND claimed_at < sqlc.arg(stale_before)::timestamptz)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id, requested_by, created_at, expires_at, org_id;
+
+-- name: CountActiveDataExportsByUser :one
+SELECT COUNT(*) FROM data_exports WHERE user_id = $1 AND status IN ('pending', 'generating');
+
+-- name: CreateDataExport :exec
+INSERT INTO data_exports (id, user_id, requested_by, token_hash, created_at, expires_at, org_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: DeleteExpiredDataExports :execrows
+DELETE FROM data_exports WHERE expires_at <= $1;
+
+-- name: FinishDataExport :exec
+UPDATE data_exports
+SET status = sqlc.arg(status), archive = sqlc.arg(archive), error = sqlc.arg(error), completed_at = sqlc.arg(completed_at)
+WHERE id = sqlc.arg(id);
+
+-- name: GetDataExportByTokenHash :one
+SELECT * FROM data_exports WHERE token_hash = $1;
+
+-- name: GetDataExportHomeOrg :one
+-- The org of the user's first membership, whose data region stores the user's exports.
+SELECT org_id FROM memberships WHERE user_id = $1 ORDER BY created_at, id LIMIT 1;
+
+-- name: ListDataExportDevices :many
+SELECT row_to_json(d)::text AS data FROM devices d WHERE d.user_id = $1 ORDER BY d.created_at, d.id;
+
+-- name: ListDataExportGroupMemberships :many
+SELECT json_build_object('group_id', g.id, 'org_id', g.org_id, 'group_name', g.name, 'role', gm.role, 'created_at', gm.created_at)::text AS data
+FROM group_members gm
+JOIN groups g ON g.id = gm.group_id
+WHERE gm.user_id = $1
+ORDER BY gm.created_at, g.id;
+
+-- name: ListDataExportIdentities :many
+-- The user's identities without their password hashes.
+SELECT (to_jsonb(i) - 'password_hash')::text AS data FROM identities i WHERE i.user_id = $1 ORDER BY i.created_at, i.id;
+
+-- name: ListDataExportMembershipAttributes :many
+SELECT row_to_json(a)::text AS data
+FROM membership_attributes a
+JOIN memberships m ON m.id = a.membership_id
+WHERE m.user_id = $1
+ORDER BY a.org_id, a.key;
+
+-- name: ListDataExportMemberships :many
+SELECT row_to_json(m)::text AS data FROM memberships m WHERE m.user_id = $1 ORDER BY m.created_at, m.id;
+
+-- name: ListDataExportSessions :many
+-- The user's sessions without their refresh token hashes and proof-of-possession key thumbprints.
+SELECT (to_jsonb(s) - 'refresh_jti' - 'refresh_token_hash' - 'pop_jkt')::text AS data
+FROM sessions s
+WHERE s.user_id = $1
+ORDER BY s.created_at, s.id;
+
+-- name: ListDataExportUsernames :many
+SELECT row_to_json(u)::text AS data FROM usernames u WHERE u.user_id = $1 ORDER BY u.org_id;
@@ -0,0 +1,34 @@
+-- name: GetActiveDataKey :one
+SELECT id, scope, wrapped_key, master_key_id, created_at, retired_at
+FROM data_keys
+WHERE scope = $1 AND retired_at IS NULL;
+
+-- name: GetDataKey :one
+SELECT id, scope, wrapped_key, master_key_id, created_at, retired_at
+FROM data_keys
+WHERE id = $1;
+
+-- name: CreateDataKey :execrows
+-- Inserts the active key of its scope; no rows if the scope already has an active key.
+INSERT INTO data_keys (id, scope, wrapped_key, master_key_id, created_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (scope) WHERE retired_at IS NULL DO NOTHING;
+
+-- name: ListDataKeysNotWrappedBy :many
+-- Keys wrapped by a master key other than master_key_id, oldest first.
+SELECT id, scope, wrapped_key, master_key_id, created_at, retired_at
+FROM data_keys
+WHERE master_key_id <> $1
+ORDER BY created_at, id;
+
+-- name: RewrapDataKey :execrows
+-- Replaces the wrapped key only if it is still wrapped by old_master_key_id; no rows if it was rewrapped meanwhile.
+UPDATE data_keys
+SET wrapped_key = sqlc.arg(wrapped_key), master_key_id = sqlc.arg(master_key_id)
+WHERE id = sqlc.arg(id) AND master_key_id = sqlc.arg(old_master_key_id);
+
+-- name: RetireDataKeysCreatedBefore :execrows
+-- Retires the active keys created before created_before in every scope except keep_scope.
+UPDATE data_keys
+SET retired_at = sqlc.arg(retired_at)
+WHERE retired_at IS NULL AND created_at < sqlc.arg(created_before) AND scope <> sqlc.arg(keep_scope);
@@ -1,19 +1,43 @@
 -- name: GetDevice :one
-SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 FROM devices
 WHERE id = $1;
 
 -- name: GetDeviceByUserAndFingerprint :one
-SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 FROM devices
 WHERE user_id = $1 AND org_id = $2 AND fingerprint = $3;
 
 -- name: ListDevicesByOrg :many
-SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
 FROM devices
 WHERE org_id = $1
 ORDER BY created_at;
 
+-- name: ListTrustedDevicesByUser :many
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
+FROM devices
+WHERE user_id = $1 AND trusted = true
+ORDER BY created_at;
+
+-- name: ListDevicesWithTrustExpiring :many
+-- Trusted, unrevoked devices whose trust expires after the (trusted_until, id) cursor and at or before
+-- expiring_before, and whose expiry notice has not been sent, ordered by expiry.
+SELECT id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at, trust_expiry_notified_at, trust_days, quarantined_at, quarantine_reason, quarantined_by
+FROM devices
+WHERE trusted = true AND revoked_at IS NULL AND trust_expiry_notified_at IS NULL
+  AND trusted_until <= sqlc.arg('expiring_before')::timestamptz
+  AND (trusted_until, id) > (sqlc.arg('after_until')::timestamptz, sqlc.arg('after_id')::varchar)
+ORDER BY trusted_until, id
+LIMIT sqlc.arg('row_limit');
+
+-- name: MarkDeviceTrustExpiryNotified :execrows
+-- Claims the expiry notice of the device's current trust period. Affects no row when another instance already
+-- claimed it.
+UPDATE devices
+SET trust_expiry_notified_at = $2
+WHERE id = $1 AND trust_expiry_notified_at IS NULL;
+
 -- name: CreateDevice :one
 INSERT INTO devices (id, user_id, org_id, fingerprint, trusted, trusted_until, revoked_at, last_seen_at, created_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
@@ -27,7 +51,15 @@ RETURNING *;
 
 -- name: UpdateDeviceTrustedWithExpiry :one
 UPDATE devices
-SET trusted = $2, trusted_until = $3, revoked_at = NULL
+SET trusted = $2, trusted_until = $3, revoked_at = NULL, trust_expiry_notified_at = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: TrustDevice :one
+-- Trusts the device until trusted_until for the remember-device duration the user chose (0 = the org's trust TTL);
+-- clears revoked_at and the trust expiry notice mark.
+UPDATE devices
+SET trusted = true, trusted_until = $2, trust_days = $3, revoked_at = NULL, trust_expiry_notified_at = NULL
 WHERE id = $1
 RETURNING *;
 
@@ -37,8 +69,25 @@ SET trusted = false, trusted_until = NULL, revoked_at = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: QuarantineDevice :one
+-- Quarantines the device; trust and history are kept.
+UPDATE devices
+SET quarantined_at = $2, quarantine_reason = $3, quarantined_by = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ReleaseDevice :one
+UPDATE devices
+SET quarantined_at = NULL, quarantine_reason = '', quarantined_by = ''
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdateDeviceLastSeen :one
 UPDATE devices
 SET last_seen_at = $2
 WHERE id = $1
 RETURNING *;
+
+-- name: CountActiveDevicesByOrg :one
+SELECT COUNT(*) FROM devices
+WHERE org_id = $1 AND revoked_at IS NULL;
@@ -0,0 +1,25 @@
+-- name: CompleteDeviceCode :execrows
+-- Consumes an approved device code that has not expired by now; no rows if it was already used, denied or has expired.
+UPDATE device_codes
+SET status = 'completed'
+WHERE id = sqlc.arg(id) AND status = 'approved' AND expires_at > sqlc.arg(now)::timestamptz;
+
+-- name: CreateDeviceCode :exec
+INSERT INTO device_codes (id, device_code_hash, user_code, client_name, device_fingerprint, ip, status, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: DecideDeviceCode :execrows
+-- Approves or denies a pending device code that has not expired by decided_at; no rows otherwise.
+UPDATE device_codes
+SET status = sqlc.arg(status), user_id = sqlc.arg(user_id), org_id = sqlc.arg(org_id), decided_at = sqlc.arg(decided_at)
+WHERE id = sqlc.arg(id) AND status = 'pending' AND expires_at > sqlc.arg(decided_at);
+
+-- name: GetDeviceCodeByHash :one
+SELECT * FROM device_codes WHERE device_code_hash = $1;
+
+-- name: GetPendingDeviceCodeByUserCode :one
+-- Returns the pending device code with this user code that has not expired by now.
+SELECT * FROM device_codes
+WHERE user_code = sqlc.arg(user_code) AND status = 'pending' AND expires_at > sqlc.arg(now)::timestamptz
+ORDER BY created_at DESC
+LIMIT 1;
@@ -0,0 +1,21 @@
+-- name: GetFingerprintUsage :one
+-- Distinct users and orgs other than the given ones with an unrevoked device of the fingerprint.
+SELECT COUNT(DISTINCT user_id) FILTER (WHERE user_id <> sqlc.arg('user_id'))::bigint AS other_users,
+       COUNT(DISTINCT org_id) FILTER (WHERE org_id <> sqlc.arg('org_id'))::bigint AS other_orgs
+FROM devices
+WHERE fingerprint = sqlc.arg('fingerprint') AND revoked_at IS NULL;
+
+-- name: ListSharedFingerprints :many
+-- Fingerprints with unrevoked devices of at least min_users distinct users, most widely shared first. Fingerprints
+-- in excluded are skipped.
+SELECT fingerprint,
+       COUNT(DISTINCT user_id)::bigint AS users,
+       COUNT(DISTINCT org_id)::bigint AS orgs,
+       COUNT(*)::bigint AS devices,
+       MAX(COALESCE(last_seen_at, created_at))::timestamptz AS last_seen_at
+FROM devices
+WHERE revoked_at IS NULL AND NOT (fingerprint = ANY(sqlc.arg('excluded')::varchar[]))
+GROUP BY fingerprint
+HAVING COUNT(DISTINCT user_id) >= sqlc.arg('min_users')::bigint
+ORDER BY users DESC, orgs DESC, fingerprint
+LIMIT sqlc.arg('row_limit');
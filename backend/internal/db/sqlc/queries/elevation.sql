@@ -0,0 +1,50 @@
+-- name: CreateElevation :exec
+INSERT INTO privilege_elevations (id, org_id, user_id, status, justification, duration_secs, review_comment, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, '', $7);
+
+-- name: ExpireElevations :many
+-- Marks approved elevations that ended by now as expired and returns them.
+UPDATE privilege_elevations
+SET status = 'expired'
+WHERE status = 'approved' AND expires_at <= sqlc.arg(now)::timestamptz
+RETURNING *;
+
+-- name: GetActiveElevation :one
+-- Returns the user's approved elevation in the org that has not ended by now, if any.
+SELECT * FROM privilege_elevations
+WHERE user_id = sqlc.arg(user_id) AND org_id = sqlc.arg(org_id)
+  AND status = 'approved' AND expires_at > sqlc.arg(now)::timestamptz
+ORDER BY expires_at DESC
+LIMIT 1;
+
+-- name: GetElevation :one
+SELECT * FROM privilege_elevations WHERE id = $1;
+
+-- name: HasOpenElevation :one
+-- Reports whether the user has a pending elevation, or an approved one that has not ended by now, in the org.
+SELECT EXISTS (
+    SELECT 1 FROM privilege_elevations
+    WHERE user_id = sqlc.arg(user_id) AND org_id = sqlc.arg(org_id)
+      AND (status = 'pending' OR (status = 'approved' AND expires_at > sqlc.arg(now)::timestamptz))
+);
+
+-- name: ListElevationsByOrg :many
+SELECT * FROM privilege_elevations
+WHERE org_id = $1
+  AND (sqlc.narg('filter_status')::text IS NULL OR status = sqlc.narg('filter_status'))
+  AND (sqlc.narg('filter_user_id')::text IS NULL OR user_id = sqlc.narg('filter_user_id'))
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ReviewElevation :execrows
+-- Approves or rejects a pending elevation; no rows if it is no longer pending.
+UPDATE privilege_elevations
+SET status = sqlc.arg(status), reviewed_by = sqlc.arg(reviewed_by), review_comment = sqlc.arg(review_comment),
+    reviewed_at = sqlc.arg(reviewed_at), expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id) AND status = 'pending';
+
+-- name: RevokeElevation :execrows
+-- Ends an approved elevation early; no rows if it is not approved or has already ended.
+UPDATE privilege_elevations
+SET status = 'revoked', revoked_by = sqlc.arg(revoked_by), revoked_at = sqlc.arg(revoked_at)
+WHERE id = sqlc.arg(id) AND status = 'approved' AND expires_at > sqlc.arg(revoked_at);
@@ -0,0 +1,31 @@
+-- name: CompleteEmailChange :execrows
+-- Marks an email change completed; no rows if it already was.
+UPDATE email_changes
+SET completed_at = sqlc.arg(now)::timestamptz
+WHERE id = sqlc.arg(id) AND completed_at IS NULL;
+
+-- name: ConfirmEmailChangeNew :one
+-- Records that the link sent to the new address was opened, if the change is unfinished and has not expired by now.
+UPDATE email_changes
+SET new_confirmed_at = COALESCE(new_confirmed_at, sqlc.arg(now)::timestamptz)
+WHERE id = sqlc.arg(id) AND completed_at IS NULL AND expires_at > sqlc.arg(now)::timestamptz
+RETURNING *;
+
+-- name: ConfirmEmailChangeOld :one
+-- Records that the link sent to the current address was opened, if the change is unfinished and has not expired by now.
+UPDATE email_changes
+SET old_confirmed_at = COALESCE(old_confirmed_at, sqlc.arg(now)::timestamptz)
+WHERE id = sqlc.arg(id) AND completed_at IS NULL AND expires_at > sqlc.arg(now)::timestamptz
+RETURNING *;
+
+-- name: CreateEmailChange :exec
+INSERT INTO email_changes (id, user_id, org_id, old_email, new_email, old_token_hash, new_token_hash, ip, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: DeleteUnfinishedEmailChangesByUser :exec
+DELETE FROM email_changes WHERE user_id = $1 AND completed_at IS NULL;
+
+-- name: GetEmailChangeByTokenHash :one
+-- Finds the email change whose link to either address carries this token.
+SELECT * FROM email_changes
+WHERE old_token_hash = sqlc.arg(token_hash) OR new_token_hash = sqlc.arg(token_hash);
@@ -0,0 +1,37 @@
+-- name: UpsertFeatureFlag :one
+INSERT INTO feature_flags (key, description, enabled, rollout_percentage, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $5)
+ON CONFLICT (key) DO UPDATE
+SET description = EXCLUDED.description,
+    enabled = EXCLUDED.enabled,
+    rollout_percentage = EXCLUDED.rollout_percentage,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetFeatureFlag :one
+SELECT key, description, enabled, rollout_percentage, created_at, updated_at
+FROM feature_flags
+WHERE key = $1;
+
+-- name: ListFeatureFlags :many
+SELECT key, description, enabled, rollout_percentage, created_at, updated_at
+FROM feature_flags
+ORDER BY key;
+
+-- name: DeleteFeatureFlag :execrows
+DELETE FROM feature_flags WHERE key = $1;
+
+-- name: UpsertFeatureFlagOrgOverride :exec
+INSERT INTO feature_flag_org_overrides (flag_key, org_id, enabled, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (flag_key, org_id) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteFeatureFlagOrgOverride :execrows
+DELETE FROM feature_flag_org_overrides WHERE flag_key = $1 AND org_id = $2;
+
+-- name: ListFeatureFlagOrgOverrides :many
+SELECT flag_key, org_id, enabled, updated_at
+FROM feature_flag_org_overrides
+ORDER BY flag_key, org_id;
@@ -0,0 +1,56 @@
+-- name: CreateGroup :one
+INSERT INTO groups (id, org_id, name, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetGroup :one
+SELECT id, org_id, name, created_at
+FROM groups
+WHERE id = $1;
+
+-- name: ListGroupsByOrg :many
+SELECT id, org_id, name, created_at
+FROM groups
+WHERE org_id = $1
+ORDER BY name;
+
+-- name: DeleteGroup :execrows
+DELETE FROM groups
+WHERE id = $1;
+
+-- name: UpsertGroupMember :exec
+INSERT INTO group_members (group_id, user_id, role, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (group_id, user_id) DO UPDATE
+SET role = EXCLUDED.role;
+
+-- name: DeleteGroupMember :execrows
+DELETE FROM group_members
+WHERE group_id = $1 AND user_id = $2;
+
+-- name: ListGroupMembers :many
+SELECT group_id, user_id, role, created_at
+FROM group_members
+WHERE group_id = $1
+ORDER BY created_at, user_id;
+
+-- name: ListUsersManagedByGroupAdmin :many
+-- Members of the org's groups that admin_user_id administers (including the admin), for group-scoped authorization.
+SELECT DISTINCT m.user_id
+FROM group_members a
+JOIN groups g ON g.id = a.group_id
+JOIN group_members m ON m.group_id = a.group_id
+WHERE a.user_id = sqlc.arg(admin_user_id) AND a.role = 'admin' AND g.org_id = sqlc.arg(org_id)
+ORDER BY m.user_id;
+
+-- name: DeleteGroupMembershipsByUserAndOrg :exec
+DELETE FROM group_members
+WHERE user_id = $1 AND group_id IN (SELECT id FROM groups WHERE org_id = $2);
+
+-- name: ListGroupNamesByUserAndOrg :many
+-- Names of the org's groups that user_id belongs to (any group role), for policy targeting.
+SELECT g.name
+FROM groups g
+JOIN group_members gm ON gm.group_id = g.id
+WHERE gm.user_id = sqlc.arg(user_id) AND g.org_id = sqlc.arg(org_id)
+ORDER BY g.name;
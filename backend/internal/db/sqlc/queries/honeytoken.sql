@@ -0,0 +1,23 @@
+-- name: DeleteHoneytoken :execrows
+DELETE FROM honeytokens WHERE user_id = $1;
+
+-- name: GetHoneytoken :one
+SELECT * FROM honeytokens WHERE user_id = $1;
+
+-- name: ListHoneytokens :many
+-- Lists honeytokens, most recently marked first.
+SELECT * FROM honeytokens
+ORDER BY created_at DESC, user_id
+LIMIT $1 OFFSET $2;
+
+-- name: RecordHoneytokenTrigger :exec
+UPDATE honeytokens
+SET trigger_count = trigger_count + 1, last_triggered_at = $2
+WHERE user_id = $1;
+
+-- name: UpsertHoneytoken :one
+-- Marks the user as a honeytoken, or updates the label of one already marked (keeping its trigger history).
+INSERT INTO honeytokens (user_id, label, created_by, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE SET label = EXCLUDED.label
+RETURNING *;
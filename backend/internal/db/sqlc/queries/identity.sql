@@ -23,3 +23,9 @@ UPDATE identities
 SET password_hash = $2
 WHERE id = $1
 RETURNING *;
+
+-- name: UpdateLocalIdentityProviderID :execrows
+-- Sets the provider_id of the user's local identity, which is their email.
+UPDATE identities
+SET provider_id = sqlc.arg(provider_id)
+WHERE user_id = sqlc.arg(user_id) AND provider = 'local';
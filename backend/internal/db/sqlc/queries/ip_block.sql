@@ -0,0 +1,11 @@
+-- name: UpsertIPBlock :exec
+INSERT INTO ip_blocks (ip, reason, created_at, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (ip) DO UPDATE
+SET reason = EXCLUDED.reason,
+    expires_at = GREATEST(ip_blocks.expires_at, EXCLUDED.expires_at);
+
+-- name: GetActiveIPBlock :one
+SELECT ip, reason, created_at, expires_at
+FROM ip_blocks
+WHERE ip = $1 AND expires_at > $2;
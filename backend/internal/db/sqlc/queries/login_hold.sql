@@ -0,0 +1,25 @@
+-- name: CompleteLoginHold :execrows
+-- Consumes an approved hold that has not expired by now; no rows if it was already resumed, denied or has expired.
+UPDATE login_holds
+SET status = 'completed'
+WHERE id = sqlc.arg(id) AND status = 'approved' AND expires_at > sqlc.arg(now)::timestamptz;
+
+-- name: CreateLoginHold :exec
+INSERT INTO login_holds (id, org_id, user_id, device_id, token_hash, reasons, ip, status, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: DecideLoginHold :execrows
+-- Approves or denies a pending hold that has not expired by decided_at; no rows otherwise.
+UPDATE login_holds
+SET status = sqlc.arg(status), decided_by = sqlc.arg(decided_by), decided_at = sqlc.arg(decided_at), expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id) AND status = 'pending' AND expires_at > sqlc.arg(decided_at);
+
+-- name: GetLoginHold :one
+SELECT * FROM login_holds WHERE id = $1;
+
+-- name: ListPendingLoginHolds :many
+-- Lists the org's pending holds that have not expired by expires_at, newest first.
+SELECT * FROM login_holds
+WHERE org_id = $1 AND status = 'pending' AND expires_at > $2
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $4;
@@ -0,0 +1,12 @@
+-- name: ConsumeMagicLink :execrows
+-- Marks an unused magic link that has not expired by now as used; no rows if it was already used or has expired.
+UPDATE magic_links
+SET used_at = sqlc.arg(now)::timestamptz
+WHERE id = sqlc.arg(id) AND used_at IS NULL AND expires_at > sqlc.arg(now)::timestamptz;
+
+-- name: CreateMagicLink :exec
+INSERT INTO magic_links (id, token_hash, user_id, org_id, ip, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetMagicLinkByTokenHash :one
+SELECT * FROM magic_links WHERE token_hash = $1;
@@ -0,0 +1,56 @@
+-- name: UpsertOrgAttributeDefinition :exec
+INSERT INTO org_attribute_definitions (org_id, key, display_name, description, type, allowed_values, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+ON CONFLICT (org_id, key) DO UPDATE
+SET display_name = EXCLUDED.display_name,
+    description = EXCLUDED.description,
+    type = EXCLUDED.type,
+    allowed_values = EXCLUDED.allowed_values,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetOrgAttributeDefinition :one
+SELECT * FROM org_attribute_definitions WHERE org_id = $1 AND key = $2;
+
+-- name: ListOrgAttributeDefinitions :many
+SELECT * FROM org_attribute_definitions WHERE org_id = $1 ORDER BY key;
+
+-- name: DeleteOrgAttributeDefinition :execrows
+-- Deletes the definition; the members' values go with it (ON DELETE CASCADE).
+DELETE FROM org_attribute_definitions WHERE org_id = $1 AND key = $2;
+
+-- name: UpsertMembershipAttribute :exec
+INSERT INTO membership_attributes (membership_id, org_id, key, value, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (membership_id, key) DO UPDATE
+SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteMembershipAttribute :exec
+DELETE FROM membership_attributes WHERE membership_id = $1 AND key = $2;
+
+-- name: ListMemberAttributesByUserAndOrg :many
+SELECT a.key, a.value
+FROM membership_attributes a
+JOIN memberships m ON m.id = a.membership_id
+WHERE m.org_id = $1 AND m.user_id = $2
+ORDER BY a.key;
+
+-- name: ListMembershipAttributesByMemberships :many
+SELECT membership_id, key, value
+FROM membership_attributes
+WHERE membership_id = ANY(sqlc.arg('membership_ids')::varchar[])
+ORDER BY membership_id, key;
+
+-- name: SearchMembershipsByAttributes :many
+-- Members of the org that have every (key, value) pair; no pairs lists all members. Oldest membership first.
+SELECT m.id, m.user_id, m.org_id, m.role, m.created_at
+FROM memberships m
+WHERE m.org_id = sqlc.arg('org_id')
+  AND (
+    SELECT count(*)
+    FROM membership_attributes a
+    JOIN unnest(sqlc.arg('keys')::varchar[], sqlc.arg('values')::varchar[]) AS f(key, value)
+      ON a.key = f.key AND a.value = f.value
+    WHERE a.membership_id = m.id
+  ) = cardinality(sqlc.arg('keys')::varchar[])
+ORDER BY m.created_at, m.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -32,3 +32,7 @@ RETURNING *;
 -- name: CountOwnersByOrg :one
 SELECT COUNT(*) FROM memberships
 WHERE org_id = $1 AND role = 'owner';
+
+-- name: CountMembershipsByOrg :one
+SELECT COUNT(*) FROM memberships
+WHERE org_id = $1;
@@ -1,13 +1,31 @@
 -- name: CreateMFAChallenge :one
-INSERT INTO mfa_challenges (id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO mfa_challenges (id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at, method, trust_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING *;
 
 -- name: GetMFAChallenge :one
-SELECT id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at
+SELECT id, user_id, org_id, device_id, phone, code_hash, expires_at, created_at, method, attempts, resend_count, last_sent_at, trust_days
 FROM mfa_challenges
 WHERE id = $1;
 
 -- name: DeleteMFAChallenge :exec
 DELETE FROM mfa_challenges
 WHERE id = $1;
+
+-- name: DeleteMFAChallengesByUser :exec
+DELETE FROM mfa_challenges
+WHERE user_id = $1;
+
+-- name: IncrementMFAChallengeAttempts :one
+-- Counts a wrong code and returns the new total.
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING attempts;
+
+-- name: ResendMFAChallenge :execrows
+-- Replaces the code after a resend. Only applies if resend_count is still the value the caller checked, so concurrent
+-- resends cannot exceed the cap.
+UPDATE mfa_challenges
+SET code_hash = $2, expires_at = $3, last_sent_at = $4, resend_count = resend_count + 1
+WHERE id = $1 AND resend_count = $5;
@@ -0,0 +1,31 @@
+-- name: GetNotificationPreferences :one
+SELECT user_id, login_alerts_opt_out, updated_at, locale
+FROM notification_preferences
+WHERE user_id = $1;
+
+-- name: UpsertNotificationPreferences :one
+INSERT INTO notification_preferences (user_id, login_alerts_opt_out, updated_at, locale)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE SET
+    login_alerts_opt_out = EXCLUDED.login_alerts_opt_out,
+    updated_at = EXCLUDED.updated_at,
+    locale = EXCLUDED.locale
+RETURNING *;
+
+-- name: CountKnownLoginContexts :one
+SELECT COUNT(*) FROM known_login_contexts
+WHERE user_id = $1 AND kind = $2;
+
+-- name: TouchKnownLoginContext :one
+INSERT INTO known_login_contexts (user_id, kind, value, first_seen_at, last_seen_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (user_id, kind, value) DO UPDATE SET
+    last_seen_at = EXCLUDED.last_seen_at
+RETURNING *;
+
+-- name: GetLatestKnownLoginContext :one
+SELECT user_id, kind, value, first_seen_at, last_seen_at
+FROM known_login_contexts
+WHERE user_id = $1 AND kind = $2
+ORDER BY last_seen_at DESC
+LIMIT 1;
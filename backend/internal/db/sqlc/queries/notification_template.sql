@@ -0,0 +1,23 @@
+-- name: GetNotificationTemplate :one
+SELECT org_id, kind, locale, subject, body, updated_by, updated_at
+FROM notification_templates
+WHERE org_id = $1 AND kind = $2 AND locale = $3;
+
+-- name: ListNotificationTemplates :many
+SELECT org_id, kind, locale, subject, body, updated_by, updated_at
+FROM notification_templates
+WHERE org_id = $1
+ORDER BY kind, locale;
+
+-- name: UpsertNotificationTemplate :exec
+INSERT INTO notification_templates (org_id, kind, locale, subject, body, updated_by, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (org_id, kind, locale) DO UPDATE
+SET subject = EXCLUDED.subject,
+    body = EXCLUDED.body,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteNotificationTemplate :execrows
+DELETE FROM notification_templates
+WHERE org_id = $1 AND kind = $2 AND locale = $3;
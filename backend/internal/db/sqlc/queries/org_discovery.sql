@@ -0,0 +1,12 @@
+-- name: GetDiscoverableOrg :one
+-- The org if it is active, for orgs found by the verified domain of an email.
+SELECT id, name FROM organizations
+WHERE id = $1 AND status = 'active';
+
+-- name: ListDiscoverableOrgsByUser :many
+-- Active orgs the user is a member of, by name.
+SELECT o.id, o.name
+FROM memberships m
+JOIN organizations o ON o.id = m.org_id
+WHERE m.user_id = $1 AND o.status = 'active'
+ORDER BY o.name, o.id;
@@ -0,0 +1,25 @@
+-- name: CreateOrgDomain :one
+-- Claims the domain for the org, or returns the org's existing claim (keeping its token and status).
+INSERT INTO org_domains (id, org_id, domain, token, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (org_id, domain) DO UPDATE SET domain = EXCLUDED.domain
+RETURNING *;
+
+-- name: GetOrgDomain :one
+SELECT * FROM org_domains WHERE org_id = $1 AND domain = $2;
+
+-- name: GetVerifiedOrgDomain :one
+-- Returns the claim that verified the domain, whichever org made it.
+SELECT * FROM org_domains WHERE domain = $1 AND status = 'verified';
+
+-- name: ListOrgDomains :many
+SELECT * FROM org_domains WHERE org_id = $1 ORDER BY domain;
+
+-- name: MarkOrgDomainVerified :one
+UPDATE org_domains
+SET status = 'verified', verified_at = $2, last_checked_at = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: RecordOrgDomainCheck :exec
+UPDATE org_domains SET last_checked_at = $2 WHERE id = $1;
@@ -0,0 +1,21 @@
+-- name: AcceptOrgInvitation :execrows
+-- Marks a pending invitation (not accepted, revoked or expired by now) accepted by user_id; no rows otherwise.
+UPDATE org_invitations
+SET accepted_at = sqlc.arg(now)::timestamptz, accepted_by = sqlc.arg(user_id)
+WHERE id = sqlc.arg(id) AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > sqlc.arg(now)::timestamptz;
+
+-- name: CreateOrgInvitation :exec
+INSERT INTO org_invitations (id, org_id, email, role, token_hash, invited_by, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetOrgInvitationByTokenHash :one
+SELECT * FROM org_invitations WHERE token_hash = $1;
+
+-- name: ListOrgInvitationsByOrg :many
+SELECT * FROM org_invitations WHERE org_id = $1 ORDER BY created_at DESC, id LIMIT $2 OFFSET $3;
+
+-- name: RevokeOrgInvitation :execrows
+-- Revokes a pending invitation of the org; no rows if it is not the org's or was already accepted or revoked.
+UPDATE org_invitations
+SET revoked_at = sqlc.arg(now)::timestamptz
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id) AND accepted_at IS NULL AND revoked_at IS NULL;
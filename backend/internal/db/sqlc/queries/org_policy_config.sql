@@ -1,5 +1,5 @@
 -- name: GetOrgPolicyConfig :one
-SELECT org_id, config_json, updated_at
+SELECT org_id, config_json, updated_at, version
 FROM org_policy_config
 WHERE org_id = $1;
 
@@ -8,5 +8,36 @@ INSERT INTO org_policy_config (org_id, config_json, updated_at)
 VALUES ($1, $2, $3)
 ON CONFLICT (org_id) DO UPDATE SET
     config_json = EXCLUDED.config_json,
-    updated_at = EXCLUDED.updated_at
+    updated_at = EXCLUDED.updated_at,
+    version = org_policy_config.version + 1
 RETURNING *;
+
+-- name: InsertOrgPolicyConfigIfAbsent :one
+-- Stores the org's first config; returns no row if the org already has one.
+INSERT INTO org_policy_config (org_id, config_json, updated_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (org_id) DO NOTHING
+RETURNING version;
+
+-- name: UpdateOrgPolicyConfigIfVersion :one
+-- Replaces the config only if its version is still $4; returns no row otherwise.
+UPDATE org_policy_config
+SET config_json = $2, updated_at = $3, version = version + 1
+WHERE org_id = $1 AND version = $4
+RETURNING version;
+
+-- name: CreateOrgPolicyConfigVersion :exec
+INSERT INTO org_policy_config_versions (org_id, version, config_json, changed_by, changed_at, source, restored_version)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetOrgPolicyConfigVersion :one
+SELECT org_id, version, config_json, changed_by, changed_at, source, restored_version
+FROM org_policy_config_versions
+WHERE org_id = $1 AND version = $2;
+
+-- name: ListOrgPolicyConfigVersions :many
+SELECT org_id, version, config_json, changed_by, changed_at, source, restored_version
+FROM org_policy_config_versions
+WHERE org_id = $1
+ORDER BY version DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,23 @@
+-- name: GetOrgQuota :one
+SELECT org_id, plan, org_requests_per_minute, token_requests_per_minute, updated_by, updated_at
+FROM org_quotas
+WHERE org_id = $1;
+
+-- name: ListOrgQuotas :many
+SELECT org_id, plan, org_requests_per_minute, token_requests_per_minute, updated_by, updated_at
+FROM org_quotas
+ORDER BY org_id;
+
+-- name: UpsertOrgQuota :exec
+INSERT INTO org_quotas (org_id, plan, org_requests_per_minute, token_requests_per_minute, updated_by, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (org_id) DO UPDATE
+SET plan = EXCLUDED.plan,
+    org_requests_per_minute = EXCLUDED.org_requests_per_minute,
+    token_requests_per_minute = EXCLUDED.token_requests_per_minute,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteOrgQuota :exec
+DELETE FROM org_quotas
+WHERE org_id = $1;
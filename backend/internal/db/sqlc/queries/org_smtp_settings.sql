@@ -0,0 +1,20 @@
+-- name: GetOrgSMTPSettings :one
+SELECT org_id, host, port, username, password_secret, from_address, updated_by, updated_at
+FROM org_smtp_settings
+WHERE org_id = $1;
+
+-- name: UpsertOrgSMTPSettings :exec
+INSERT INTO org_smtp_settings (org_id, host, port, username, password_secret, from_address, updated_by, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (org_id) DO UPDATE
+SET host = EXCLUDED.host,
+    port = EXCLUDED.port,
+    username = EXCLUDED.username,
+    password_secret = EXCLUDED.password_secret,
+    from_address = EXCLUDED.from_address,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteOrgSMTPSettings :execrows
+DELETE FROM org_smtp_settings
+WHERE org_id = $1;
@@ -1,15 +1,35 @@
 -- name: GetOrganization :one
-SELECT id, name, status, created_at
+SELECT id, name, status, created_at, data_region, max_users, max_devices, plan
 FROM organizations
 WHERE id = $1;
 
+-- name: ListOrganizations :many
+SELECT id, name, status, created_at, data_region, max_users, max_devices, plan
+FROM organizations
+WHERE id <> '_system'
+ORDER BY created_at, id
+LIMIT $1 OFFSET $2;
+
+-- name: GetOrganizationQuotas :one
+-- Reads the quotas without a lock; quota checks lock the row only when a quota is set.
+SELECT max_users, max_devices
+FROM organizations
+WHERE id = $1;
+
+-- name: LockOrganizationQuotas :one
+-- Locks the org row until the transaction ends, so quota checks and inserts into one org run one at a time.
+SELECT max_users, max_devices
+FROM organizations
+WHERE id = $1
+FOR UPDATE;
+
 -- name: CreateOrganization :one
-INSERT INTO organizations (id, name, status, created_at)
-VALUES ($1, $2, $3, $4)
+INSERT INTO organizations (id, name, status, created_at, data_region)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: UpdateOrganization :one
 UPDATE organizations
-SET name = $2, status = $3
+SET name = $2, status = $3, max_users = $4, max_devices = $5, plan = $6
 WHERE id = $1
 RETURNING *;
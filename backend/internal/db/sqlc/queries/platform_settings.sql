@@ -13,3 +13,6 @@ RETURNING *;
 SELECT key, value_json
 FROM platform_settings
 ORDER BY key;
+
+-- name: NotifySettingsChanged :exec
+SELECT pg_notify(sqlc.arg('channel')::text, sqlc.arg('payload')::text);
@@ -29,3 +29,9 @@ RETURNING id, org_id, rules, enabled, created_at;
 -- name: DeletePolicy :exec
 DELETE FROM policies
 WHERE id = $1;
+
+-- name: DisableOrgPolicies :execrows
+-- Disables all of the org's enabled policies, e.g. before a preset's policy replaces them.
+UPDATE policies
+SET enabled = false
+WHERE org_id = $1 AND enabled = true;
@@ -0,0 +1,9 @@
+-- name: ListPolicyPresets :many
+SELECT name, display_name, description, policy_rules, config_json, created_at, updated_at
+FROM policy_presets
+ORDER BY name;
+
+-- name: GetPolicyPreset :one
+SELECT name, display_name, description, policy_rules, config_json, created_at, updated_at
+FROM policy_presets
+WHERE name = $1;
@@ -0,0 +1,15 @@
+-- name: CreatePolicyViolation :one
+INSERT INTO policy_violations (id, org_id, user_id, device_id, session_id, action, target, step_up_triggered, created_at, rule)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: ListPolicyViolationsByOrg :many
+SELECT id, org_id, user_id, device_id, session_id, action, target, step_up_triggered, created_at, rule
+FROM policy_violations
+WHERE org_id = $1
+  AND (sqlc.narg('filter_user_id')::text IS NULL OR user_id = sqlc.narg('filter_user_id'))
+  AND (sqlc.narg('filter_device_id')::text IS NULL OR device_id = sqlc.narg('filter_device_id'))
+  AND (sqlc.narg('filter_action')::text IS NULL OR action = sqlc.narg('filter_action'))
+  AND (sqlc.narg('filter_rule')::text IS NULL OR rule = sqlc.narg('filter_rule'))
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
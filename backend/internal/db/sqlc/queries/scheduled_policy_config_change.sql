@@ -0,0 +1,27 @@
+-- name: CreateScheduledPolicyConfigChange :exec
+INSERT INTO scheduled_policy_config_changes (id, org_id, config_json, update_mask, effective_at, status, created_by, created_at, error)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, '');
+
+-- name: GetScheduledPolicyConfigChange :one
+SELECT * FROM scheduled_policy_config_changes WHERE id = $1;
+
+-- name: ListScheduledPolicyConfigChangesByOrg :many
+SELECT * FROM scheduled_policy_config_changes
+WHERE org_id = $1
+  AND (sqlc.narg('filter_status')::text IS NULL OR status = sqlc.narg('filter_status'))
+ORDER BY effective_at, created_at
+LIMIT $2 OFFSET $3;
+
+-- name: ListDueScheduledPolicyConfigChanges :many
+-- Pending changes whose effective_at is at or before $1, oldest first.
+SELECT * FROM scheduled_policy_config_changes
+WHERE status = 'pending' AND effective_at <= $1
+ORDER BY effective_at, created_at
+LIMIT $2;
+
+-- name: TransitionScheduledPolicyConfigChange :execrows
+-- Moves the change from from_status to status, recording the outcome; no rows if its status is no longer from_status.
+UPDATE scheduled_policy_config_changes
+SET status = sqlc.arg(status), resolved_by = sqlc.arg(resolved_by), resolved_at = sqlc.arg(resolved_at),
+    applied_version = sqlc.arg(applied_version), error = sqlc.arg(error)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status);
@@ -0,0 +1,32 @@
+-- name: CreateSecurityEvent :one
+INSERT INTO security_events (id, org_id, user_id, event_type, severity, ip, metadata, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetSecurityEvent :one
+SELECT id, org_id, user_id, event_type, severity, ip, metadata, created_at, acknowledged_at, dismissed_at
+FROM security_events
+WHERE id = $1;
+
+-- name: ListSecurityEventsByUser :many
+SELECT id, org_id, user_id, event_type, severity, ip, metadata, created_at, acknowledged_at, dismissed_at
+FROM security_events
+WHERE user_id = $1
+  AND (sqlc.arg('include_dismissed')::boolean OR dismissed_at IS NULL)
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: AcknowledgeSecurityEvent :exec
+UPDATE security_events
+SET acknowledged_at = $2
+WHERE id = $1 AND acknowledged_at IS NULL;
+
+-- name: DismissSecurityEvent :exec
+UPDATE security_events
+SET dismissed_at = $2
+WHERE id = $1 AND dismissed_at IS NULL;
+
+-- name: CountSecurityEventsSince :one
+SELECT COUNT(*)
+FROM security_events
+WHERE user_id = $1 AND event_type = $2 AND created_at >= $3;
@@ -1,42 +1,106 @@
 -- name: GetSession :one
-SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 FROM sessions
 WHERE id = $1;
 
 -- name: ListSessionsByUserAndOrg :many
-SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at
+SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, revocation_reason, revoked_by, ephemeral
 FROM sessions
 WHERE user_id = $1 AND org_id = $2 AND revoked_at IS NULL
 ORDER BY created_at;
 
 -- name: ListSessionsByOrg :many
-SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, created_at
+SELECT id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, created_at, user_agent, client_version, auth_method, mfa_method, ephemeral
 FROM sessions
 WHERE org_id = $1 AND revoked_at IS NULL
   AND (sqlc.narg('user_id')::text IS NULL OR user_id = sqlc.narg('user_id'))
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: GetSessionDetails :one
+-- Returns the session with its user and device (device columns are NULL for a device-less public session).
+SELECT s.id, s.user_id, s.org_id, s.device_id, s.expires_at, s.revoked_at, s.last_seen_at, s.ip_address, s.created_at, s.user_agent, s.client_version, s.auth_method, s.mfa_method, s.revocation_reason, s.revoked_by, s.ephemeral,
+       u.email AS user_email, u.name AS user_name, d.fingerprint AS device_fingerprint, d.trusted AS device_trusted, d.trusted_until AS device_trusted_until, d.revoked_at AS device_revoked_at
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+LEFT JOIN devices d ON d.id = s.device_id
+WHERE s.id = $1;
+
+-- name: ListSessionDetailsByOrg :many
+-- Like ListSessionsByOrg, with each session's user and device.
+SELECT s.id, s.user_id, s.org_id, s.device_id, s.expires_at, s.revoked_at, s.last_seen_at, s.ip_address, s.created_at, s.user_agent, s.client_version, s.auth_method, s.mfa_method, s.revocation_reason, s.revoked_by, s.ephemeral,
+       u.email AS user_email, u.name AS user_name, d.fingerprint AS device_fingerprint, d.trusted AS device_trusted, d.trusted_until AS device_trusted_until, d.revoked_at AS device_revoked_at
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+LEFT JOIN devices d ON d.id = s.device_id
+WHERE s.org_id = $1 AND s.revoked_at IS NULL
+  AND (sqlc.narg('user_id')::text IS NULL OR s.user_id = sqlc.narg('user_id'))
+ORDER BY s.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListSessionsRevokedSince :many
+-- Sessions of the org revoked at or after $2, oldest revocation first; feeds SessionService.SubscribeRevocations.
+SELECT id, user_id, org_id, revoked_at, revocation_reason
+FROM sessions
+WHERE org_id = $1 AND revoked_at >= $2
+ORDER BY revoked_at, id
+LIMIT $3;
+
+-- name: ListIdleEphemeralSessions :many
+-- Active ephemeral (public device) sessions not refreshed since idle_since, longest idle first; feeds the ephemeral
+-- session sweeper.
+SELECT id, user_id, org_id
+FROM sessions
+WHERE ephemeral AND revoked_at IS NULL AND COALESCE(last_seen_at, created_at) < sqlc.arg('idle_since')
+ORDER BY COALESCE(last_seen_at, created_at)
+LIMIT $1;
+
+-- name: CountActiveSessionsByOrg :one
+SELECT COUNT(*) FROM sessions
+WHERE org_id = $1 AND revoked_at IS NULL AND id <> $2;
+
+-- name: RevokeSessionsByOrgBatch :many
+-- Revokes up to $5 active sessions in the org created at or before $4, except session $3.
+UPDATE sessions
+SET revoked_at = $2, revocation_reason = $6, revoked_by = $7
+WHERE id IN (
+    SELECT id FROM sessions
+    WHERE org_id = $1 AND revoked_at IS NULL AND id <> $3 AND created_at <= $4
+    ORDER BY created_at
+    LIMIT $5
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id;
+
 -- name: RevokeAllSessionsByUserAndOrg :exec
 UPDATE sessions
-SET revoked_at = $3
-WHERE user_id = $1 AND org_id = $2;
+SET revoked_at = $3, revocation_reason = $4, revoked_by = $5
+WHERE user_id = $1 AND org_id = $2 AND revoked_at IS NULL;
 
 -- name: CreateSession :one
-INSERT INTO sessions (id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+INSERT INTO sessions (id, user_id, org_id, device_id, expires_at, revoked_at, last_seen_at, ip_address, refresh_jti, refresh_token_hash, created_at, country, pop_jkt, user_agent, client_version, auth_method, mfa_method, ephemeral)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 RETURNING *;
 
 -- name: RevokeSession :one
+-- A session that is already revoked keeps its first revocation time, reason and actor.
 UPDATE sessions
-SET revoked_at = $2
+SET revoked_at = COALESCE(revoked_at, $2),
+    revocation_reason = CASE WHEN revoked_at IS NULL THEN sqlc.narg('revocation_reason')::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL THEN sqlc.narg('revoked_by')::varchar ELSE revoked_by END
 WHERE id = $1
 RETURNING *;
 
 -- name: RevokeAllSessionsByUser :exec
 UPDATE sessions
-SET revoked_at = $2
-WHERE user_id = $1;
+SET revoked_at = $2, revocation_reason = $3, revoked_by = $4
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeOtherSessionsByUser :execrows
+-- Revokes the user's active sessions in every org except session $2 (all of them when $2 is empty).
+UPDATE sessions
+SET revoked_at = $3, revocation_reason = $4, revoked_by = $5
+WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL;
 
 -- name: UpdateSessionLastSeen :one
 UPDATE sessions
@@ -46,6 +110,48 @@ RETURNING *;
 
 -- name: UpdateSessionRefreshToken :one
 UPDATE sessions
-SET refresh_jti = $2, refresh_token_hash = $3
+SET refresh_jti = $2, refresh_token_hash = $3, expires_at = $4
 WHERE id = $1
 RETURNING *;
+
+-- name: RevokeSessionAt :execrows
+-- Applies a replicated revocation: the earliest revocation time wins, together with its reason and actor.
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $2),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $2 THEN sqlc.narg('revocation_reason')::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $2 THEN sqlc.narg('revoked_by')::varchar ELSE revoked_by END
+WHERE id = $1;
+
+-- name: RevokeSessionsByUserBefore :exec
+-- Applies a replicated user-wide revocation to sessions created before it, except session $2, so later logins survive.
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $3),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN sqlc.narg('revocation_reason')::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN sqlc.narg('revoked_by')::varchar ELSE revoked_by END
+WHERE user_id = $1 AND id <> $2 AND created_at <= $3;
+
+-- name: RevokeSessionsByUserAndOrgBefore :exec
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $3),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN sqlc.narg('revocation_reason')::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN sqlc.narg('revoked_by')::varchar ELSE revoked_by END
+WHERE user_id = $1 AND org_id = $2 AND created_at <= $3;
+
+-- name: RevokeSessionsByOrgBefore :exec
+-- Applies a replicated org-wide revocation to sessions created before it, except session $2.
+UPDATE sessions
+SET revoked_at = LEAST(revoked_at, $3),
+    revocation_reason = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN sqlc.narg('revocation_reason')::varchar ELSE revocation_reason END,
+    revoked_by = CASE WHEN revoked_at IS NULL OR revoked_at > $3 THEN sqlc.narg('revoked_by')::varchar ELSE revoked_by END
+WHERE org_id = $1 AND id <> $2 AND created_at <= $3;
+
+-- name: UpsertSessionReplicationWatermark :exec
+INSERT INTO session_replication_watermarks (origin_region, last_event_at, received_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (origin_region) DO UPDATE
+SET last_event_at = GREATEST(session_replication_watermarks.last_event_at, EXCLUDED.last_event_at),
+    received_at = EXCLUDED.received_at;
+
+-- name: GetLatestSessionReplicationReceivedAt :one
+SELECT COALESCE(MAX(received_at), 'epoch'::timestamptz)::timestamptz AS received_at
+FROM session_replication_watermarks;
@@ -0,0 +1,25 @@
+-- name: CreateTelemetryEvents :execrows
+-- Stores a batch of events; events already stored for their org (same event_id) are skipped.
+INSERT INTO telemetry_events (org_id, event_id, schema_version, type, occurred_at, received_at, user_id, session_id, device_id, region, attributes_json, request_id)
+SELECT org_id, event_id, schema_version, type, occurred_at, received_at, user_id, session_id, device_id, region, attributes_json, request_id
+FROM unnest(
+    sqlc.arg('org_ids')::varchar[], sqlc.arg('event_ids')::varchar[], sqlc.arg('schema_versions')::integer[],
+    sqlc.arg('types')::varchar[], sqlc.arg('occurred_ats')::timestamptz[], sqlc.arg('received_ats')::timestamptz[],
+    sqlc.arg('user_ids')::varchar[], sqlc.arg('session_ids')::varchar[], sqlc.arg('device_ids')::varchar[],
+    sqlc.arg('regions')::varchar[], sqlc.arg('attributes_jsons')::text[], sqlc.arg('request_ids')::varchar[]
+) AS t(org_id, event_id, schema_version, type, occurred_at, received_at, user_id, session_id, device_id, region, attributes_json, request_id)
+ON CONFLICT (org_id, event_id) DO NOTHING;
+
+-- name: DeleteTelemetryEventsBefore :execrows
+DELETE FROM telemetry_events WHERE received_at < $1;
+
+-- name: ListTelemetryEventsByOrg :many
+SELECT * FROM telemetry_events
+WHERE org_id = $1
+  AND (sqlc.narg('filter_type')::text IS NULL OR type = sqlc.narg('filter_type'))
+  AND (sqlc.narg('filter_user_id')::text IS NULL OR user_id = sqlc.narg('filter_user_id'))
+  AND (sqlc.narg('filter_device_id')::text IS NULL OR device_id = sqlc.narg('filter_device_id'))
+  AND (sqlc.narg('since')::timestamptz IS NULL OR received_at >= sqlc.narg('since'))
+  AND (sqlc.narg('until')::timestamptz IS NULL OR received_at < sqlc.narg('until'))
+ORDER BY received_at DESC, event_id
+LIMIT $2 OFFSET $3;
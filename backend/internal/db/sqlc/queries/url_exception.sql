@@ -0,0 +1,44 @@
+-- name: CreateUrlException :exec
+INSERT INTO url_exceptions (id, org_id, user_id, domain, url, status, justification, duration_secs, review_comment, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, '', $9);
+
+-- name: GetActiveUrlException :one
+-- Returns the user's approved exception for the domain in the org that has not ended by now, if any.
+SELECT * FROM url_exceptions
+WHERE user_id = sqlc.arg(user_id) AND org_id = sqlc.arg(org_id) AND domain = sqlc.arg(domain)
+  AND status = 'approved' AND expires_at > sqlc.arg(now)::timestamptz
+ORDER BY expires_at DESC
+LIMIT 1;
+
+-- name: GetUrlException :one
+SELECT * FROM url_exceptions WHERE id = $1;
+
+-- name: HasOpenUrlException :one
+-- Reports whether the user has a pending exception, or an approved one that has not ended by now, for the domain in
+-- the org.
+SELECT EXISTS (
+    SELECT 1 FROM url_exceptions
+    WHERE user_id = sqlc.arg(user_id) AND org_id = sqlc.arg(org_id) AND domain = sqlc.arg(domain)
+      AND (status = 'pending' OR (status = 'approved' AND expires_at > sqlc.arg(now)::timestamptz))
+);
+
+-- name: ListUrlExceptionsByOrg :many
+SELECT * FROM url_exceptions
+WHERE org_id = $1
+  AND (sqlc.narg('filter_status')::text IS NULL OR status = sqlc.narg('filter_status'))
+  AND (sqlc.narg('filter_user_id')::text IS NULL OR user_id = sqlc.narg('filter_user_id'))
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ReviewUrlException :execrows
+-- Approves or rejects a pending exception; no rows if it is no longer pending.
+UPDATE url_exceptions
+SET status = sqlc.arg(status), duration_secs = sqlc.arg(duration_secs), reviewed_by = sqlc.arg(reviewed_by),
+    review_comment = sqlc.arg(review_comment), reviewed_at = sqlc.arg(reviewed_at), expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id) AND status = 'pending';
+
+-- name: RevokeUrlException :execrows
+-- Ends an approved exception early; no rows if it is not approved or has already ended.
+UPDATE url_exceptions
+SET status = 'revoked', revoked_by = sqlc.arg(revoked_by), revoked_at = sqlc.arg(revoked_at)
+WHERE id = sqlc.arg(id) AND status = 'approved' AND expires_at > sqlc.arg(revoked_at);
@@ -0,0 +1,62 @@
+-- name: AddMonthlyAPICalls :exec
+INSERT INTO usage_monthly (org_id, month, api_calls, updated_at)
+VALUES (sqlc.arg('org_id'), sqlc.arg('month')::date, sqlc.arg('api_calls'), sqlc.arg('updated_at'))
+ON CONFLICT (org_id, month) DO UPDATE
+SET api_calls = usage_monthly.api_calls + EXCLUDED.api_calls,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: AddActiveUsers :exec
+INSERT INTO usage_active_users (org_id, month, user_id)
+SELECT sqlc.arg('org_id')::text, sqlc.arg('month')::date, unnest(sqlc.arg('user_ids')::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: RollupMonthlyUsage :exec
+WITH events AS (
+    SELECT a.org_id,
+           COUNT(*) FILTER (WHERE a.action = 'session_created') AS logins,
+           COUNT(*) FILTER (WHERE a.action = 'mfa_challenge_issued') AS mfa_challenges,
+           COUNT(*) FILTER (WHERE a.action = 'mfa_code_resent') AS mfa_resends
+    FROM audit_logs a
+    WHERE a.created_at >= sqlc.arg('start_at') AND a.created_at < sqlc.arg('end_at')
+      AND a.action IN ('session_created', 'mfa_challenge_issued', 'mfa_code_resent')
+    GROUP BY a.org_id
+), users AS (
+    SELECT u.org_id, COUNT(DISTINCT u.user_id) AS active_users
+    FROM (
+        SELECT au.org_id, au.user_id FROM usage_active_users au WHERE au.month = sqlc.arg('month')::date
+        UNION
+        SELECT a.org_id, a.user_id FROM audit_logs a
+        WHERE a.created_at >= sqlc.arg('start_at') AND a.created_at < sqlc.arg('end_at')
+          AND a.action = 'session_created' AND a.user_id IS NOT NULL AND a.user_id <> ''
+    ) u
+    GROUP BY u.org_id
+), orgs AS (
+    SELECT org_id FROM events UNION SELECT org_id FROM users
+)
+INSERT INTO usage_monthly (org_id, month, active_users, logins, mfa_challenges, mfa_resends, updated_at)
+SELECT o.org_id, sqlc.arg('month')::date,
+       COALESCE(u.active_users, 0), COALESCE(e.logins, 0), COALESCE(e.mfa_challenges, 0), COALESCE(e.mfa_resends, 0),
+       sqlc.arg('updated_at')
+FROM orgs o
+LEFT JOIN events e ON e.org_id = o.org_id
+LEFT JOIN users u ON u.org_id = o.org_id
+WHERE o.org_id <> '_system'
+ON CONFLICT (org_id, month) DO UPDATE
+SET active_users = EXCLUDED.active_users,
+    logins = EXCLUDED.logins,
+    mfa_challenges = EXCLUDED.mfa_challenges,
+    mfa_resends = EXCLUDED.mfa_resends,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: ListMonthlyUsage :many
+SELECT org_id, month, active_users, logins, mfa_challenges, mfa_resends, api_calls, updated_at
+FROM usage_monthly
+WHERE org_id = sqlc.arg('org_id') AND month >= sqlc.arg('from_month')::date AND month <= sqlc.arg('to_month')::date
+ORDER BY month;
+
+-- name: ListUsageForMonth :many
+SELECT org_id, month, active_users, logins, mfa_challenges, mfa_resends, api_calls, updated_at
+FROM usage_monthly
+WHERE month = sqlc.arg('month')::date AND org_id > sqlc.arg('after_org_id')
+ORDER BY org_id
+LIMIT sqlc.arg('max_results');
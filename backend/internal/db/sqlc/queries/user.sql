@@ -1,26 +1,75 @@
 -- name: GetUser :one
-SELECT id, email, name, phone, phone_verified, status, created_at, updated_at
+SELECT id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
 FROM users
 WHERE id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, email, name, phone, phone_verified, status, created_at, updated_at
+-- Matches email_hash, or the plaintext email on rows written before PII encryption was enabled (no email_hash).
+SELECT id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
 FROM users
-WHERE email = $1;
+WHERE email_hash = sqlc.narg(email_hash) OR (email_hash IS NULL AND email = sqlc.arg(email));
 
 -- name: CreateUser :one
-INSERT INTO users (id, email, name, phone, phone_verified, status, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO users (id, email, name, phone, phone_verified, status, created_at, updated_at, email_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: UpdateUser :one
 UPDATE users
-SET email = $2, name = $3, phone = $4, phone_verified = $5, status = $6, updated_at = $7
+SET email = $2, name = $3, phone = $4, phone_verified = $5, status = $6, updated_at = $7, email_hash = $8
 WHERE id = $1
 RETURNING *;
 
+-- name: ChangeUserEmail :execrows
+-- Replaces the user's stored email and email_hash only if the stored email is still old_email; no rows if it changed
+-- meanwhile.
+UPDATE users
+SET email = sqlc.arg(new_email), email_hash = sqlc.narg(email_hash), updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id) AND email = sqlc.arg(old_email);
+
+-- name: ChangeUserPhone :execrows
+-- Replaces the user's phone, marking it verified, only if it is still old_phone; no rows if it changed meanwhile.
+UPDATE users
+SET phone = sqlc.arg(new_phone), phone_verified = true, mfa_reset_required = false, updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id) AND COALESCE(phone, '') = sqlc.arg(old_phone)::VARCHAR;
+
+-- name: CountLicensedUsers :one
+-- Active users that can sign in, the seats of a self-hosted license. Accounts without an identity (break-glass
+-- accounts) and honeytokens take no seat.
+SELECT COUNT(*) FROM users u
+WHERE u.status = 'active'
+  AND EXISTS (SELECT 1 FROM identities i WHERE i.user_id = u.id)
+  AND NOT EXISTS (SELECT 1 FROM honeytokens h WHERE h.user_id = u.id);
+
+-- name: LockLicenseSeats :exec
+-- Locks the license seats until the transaction ends, so seat checks and the users they admit run one at a time.
+SELECT pg_advisory_xact_lock(hashtext('license_seats'));
+
+-- name: ResetUserMFA :execrows
+-- Clears the user's phone and requires MFA with a newly enrolled phone at next sign-in.
+UPDATE users
+SET phone = NULL, phone_verified = false, mfa_reset_required = true, updated_at = $2
+WHERE id = $1;
+
 -- name: SetPhoneVerified :one
 UPDATE users
-SET phone = $2, phone_verified = true, updated_at = $3
+SET phone = $2, phone_verified = true, mfa_reset_required = false, updated_at = $3
 WHERE id = $1 AND (phone IS NULL OR phone = '') AND phone_verified = false
 RETURNING id;
+
+-- name: ListUsersForPIIReencryption :many
+-- Users after after_id whose email or phone is not encrypted with the key of key_prefix, or that have no email_hash.
+SELECT id, email, name, phone, phone_verified, status, created_at, updated_at, mfa_reset_required, email_hash
+FROM users
+WHERE id > sqlc.arg(after_id)::VARCHAR
+  AND (email NOT LIKE sqlc.arg(key_prefix)::VARCHAR || '%'
+    OR (COALESCE(phone, '') <> '' AND phone NOT LIKE sqlc.arg(key_prefix)::VARCHAR || '%')
+    OR email_hash IS NULL)
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateUserPII :execrows
+-- Replaces the stored email, phone and email_hash only if email and phone are still old_email and old_phone.
+UPDATE users
+SET email = sqlc.arg(email), phone = sqlc.narg(phone), email_hash = sqlc.narg(email_hash)
+WHERE id = sqlc.arg(id) AND email = sqlc.arg(old_email) AND COALESCE(phone, '') = sqlc.arg(old_phone)::VARCHAR;
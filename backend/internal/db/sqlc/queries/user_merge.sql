@@ -0,0 +1,64 @@
+-- name: DeleteMergedGroupMembers :execrows
+-- Drops the duplicate's memberships of groups the primary is already in.
+DELETE FROM group_members d
+USING group_members p
+WHERE d.user_id = sqlc.arg(duplicate_id) AND p.user_id = sqlc.arg(primary_id) AND p.group_id = d.group_id;
+
+-- name: DeleteMergedLocalIdentity :execrows
+-- Drops the duplicate's local (password) identity when the primary has one, so the primary keeps a single password.
+DELETE FROM identities
+WHERE user_id = sqlc.arg(duplicate_id) AND provider = 'local'
+  AND EXISTS (SELECT 1 FROM identities p WHERE p.user_id = sqlc.arg(primary_id) AND p.provider = 'local');
+
+-- name: DeleteMergedMemberships :execrows
+-- Drops the duplicate's memberships of orgs the primary already belongs to (run MergeMembershipRoles first).
+DELETE FROM memberships d
+USING memberships p
+WHERE d.user_id = sqlc.arg(duplicate_id) AND p.user_id = sqlc.arg(primary_id) AND p.org_id = d.org_id;
+
+-- name: DisableMergedUser :exec
+UPDATE users SET status = 'disabled', updated_at = $2 WHERE id = $1;
+
+-- name: GetUserMergeDiff :one
+-- Counts the duplicate's rows a merge into the primary moves or drops, and whether either user is protected.
+SELECT
+    (SELECT count(*) FROM identities i WHERE i.user_id = sqlc.arg(duplicate_id)) AS identities,
+    (SELECT count(*) FROM identities i WHERE i.user_id = sqlc.arg(duplicate_id) AND i.provider = 'local'
+        AND EXISTS (SELECT 1 FROM identities p WHERE p.user_id = sqlc.arg(primary_id) AND p.provider = 'local')) AS identities_dropped,
+    (SELECT count(*) FROM memberships m WHERE m.user_id = sqlc.arg(duplicate_id)) AS memberships,
+    (SELECT count(*) FROM memberships d JOIN memberships p ON p.org_id = d.org_id AND p.user_id = sqlc.arg(primary_id)
+        WHERE d.user_id = sqlc.arg(duplicate_id)) AS memberships_merged,
+    (SELECT count(*) FROM group_members g WHERE g.user_id = sqlc.arg(duplicate_id)) AS group_memberships,
+    (SELECT count(*) FROM group_members d JOIN group_members p ON p.group_id = d.group_id AND p.user_id = sqlc.arg(primary_id)
+        WHERE d.user_id = sqlc.arg(duplicate_id)) AS group_memberships_merged,
+    (SELECT count(*) FROM devices v WHERE v.user_id = sqlc.arg(duplicate_id)) AS devices,
+    (SELECT count(*) FROM sessions s WHERE s.user_id = sqlc.arg(duplicate_id)) AS sessions,
+    (SELECT count(*) FROM audit_logs a WHERE a.user_id = sqlc.arg(duplicate_id)) AS audit_logs,
+    EXISTS (SELECT 1 FROM honeytokens h WHERE h.user_id IN (sqlc.arg(primary_id), sqlc.arg(duplicate_id))) AS honeytoken,
+    EXISTS (SELECT 1 FROM break_glass_accounts b WHERE b.user_id IN (sqlc.arg(primary_id), sqlc.arg(duplicate_id))) AS break_glass;
+
+-- name: MergeMembershipRoles :execrows
+-- In orgs both users belong to, gives the primary the duplicate's role when it is higher. The role enum is declared
+-- owner, admin, member, so a lower value is a higher role.
+UPDATE memberships p
+SET role = d.role
+FROM memberships d
+WHERE p.user_id = sqlc.arg(primary_id) AND d.user_id = sqlc.arg(duplicate_id) AND d.org_id = p.org_id AND d.role < p.role;
+
+-- name: MoveAuditLogs :execrows
+UPDATE audit_logs SET user_id = sqlc.arg(primary_id) WHERE user_id = sqlc.arg(duplicate_id);
+
+-- name: MoveDevices :execrows
+UPDATE devices SET user_id = sqlc.arg(primary_id) WHERE user_id = sqlc.arg(duplicate_id);
+
+-- name: MoveGroupMembers :execrows
+UPDATE group_members SET user_id = sqlc.arg(primary_id) WHERE user_id = sqlc.arg(duplicate_id);
+
+-- name: MoveIdentities :execrows
+UPDATE identities SET user_id = sqlc.arg(primary_id) WHERE user_id = sqlc.arg(duplicate_id);
+
+-- name: MoveMemberships :execrows
+UPDATE memberships SET user_id = sqlc.arg(primary_id) WHERE user_id = sqlc.arg(duplicate_id);
+
+-- name: MoveSessions :execrows
+UPDATE sessions SET user_id = sqlc.arg(primary_id) WHERE user_id = sqlc.arg(duplicate_id);
@@ -0,0 +1,24 @@
+-- name: DeleteStaleOrgUsername :exec
+-- Frees an org-scoped username held by a user who is no longer a member of the org.
+DELETE FROM usernames u
+WHERE u.org_id = sqlc.arg(org_id) AND u.username = sqlc.arg(username) AND u.org_id <> ''
+  AND NOT EXISTS (SELECT 1 FROM memberships m WHERE m.user_id = u.user_id AND m.org_id = u.org_id);
+
+-- name: DeleteUsername :execrows
+DELETE FROM usernames WHERE user_id = $1 AND org_id = $2;
+
+-- name: GetUsername :one
+SELECT username FROM usernames WHERE user_id = $1 AND org_id = $2;
+
+-- name: GetUsernameUserID :one
+-- Resolves a username; an org-scoped username counts only while its user is a member of the org.
+SELECT u.user_id FROM usernames u
+WHERE u.org_id = sqlc.arg(org_id) AND u.username = sqlc.arg(username)
+  AND (u.org_id = '' OR EXISTS (SELECT 1 FROM memberships m WHERE m.user_id = u.user_id AND m.org_id = u.org_id));
+
+-- name: UpsertUsername :exec
+-- Sets the user's username in the scope, replacing the one they had.
+INSERT INTO usernames (user_id, org_id, username, created_at, updated_at)
+VALUES (sqlc.arg(user_id), sqlc.arg(org_id), sqlc.arg(username), sqlc.arg(now), sqlc.arg(now))
+ON CONFLICT (user_id, org_id) DO UPDATE
+SET username = EXCLUDED.username, updated_at = EXCLUDED.updated_at;
@@ -13,8 +13,11 @@ CREATE TABLE users (
     phone_verified BOOLEAN NOT NULL DEFAULT false,
     status         user_status NOT NULL,
     created_at     TIMESTAMPTZ NOT NULL,
-    updated_at     TIMESTAMPTZ NOT NULL
+    updated_at     TIMESTAMPTZ NOT NULL,
+    mfa_reset_required BOOLEAN NOT NULL DEFAULT false,
+    email_hash     VARCHAR -- keyed hash of the email for lookups when email is encrypted; NULL for plaintext rows
 );
+CREATE UNIQUE INDEX idx_users_email_hash ON users(email_hash) WHERE email_hash IS NOT NULL;
 
 -- Identities (ref users)
 CREATE TABLE identities (
@@ -28,10 +31,14 @@ CREATE TABLE identities (
 
 -- Organizations
 CREATE TABLE organizations (
-    id         VARCHAR PRIMARY KEY,
-    name       VARCHAR NOT NULL,
-    status     org_status NOT NULL,
-    created_at TIMESTAMPTZ NOT NULL
+    id          VARCHAR PRIMARY KEY,
+    name        VARCHAR NOT NULL,
+    status      org_status NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    data_region VARCHAR NOT NULL DEFAULT '', -- region storing org-scoped data ('' = primary database)
+    max_users   INT NOT NULL DEFAULT 0, -- member quota (0 = unlimited)
+    max_devices INT NOT NULL DEFAULT 0, -- active device quota (0 = unlimited)
+    plan        VARCHAR NOT NULL DEFAULT 'free' -- billing plan: free, team or enterprise
 );
 
 -- Memberships (ref users, organizations)
@@ -53,24 +60,45 @@ CREATE TABLE devices (
     trusted_until TIMESTAMPTZ,
     revoked_at    TIMESTAMPTZ,
     last_seen_at  TIMESTAMPTZ,
-    created_at    TIMESTAMPTZ NOT NULL
+    created_at    TIMESTAMPTZ NOT NULL,
+    trust_expiry_notified_at TIMESTAMPTZ, -- pre-expiry notice sent for the current trust period; cleared on (re)trust
+    trust_days    INT NOT NULL DEFAULT 0, -- remember-device duration chosen by the user; 0 = the org's trust TTL
+    quarantined_at    TIMESTAMPTZ, -- set while quarantined (suspected compromise); cleared on release
+    quarantine_reason VARCHAR NOT NULL DEFAULT '',
+    quarantined_by    VARCHAR NOT NULL DEFAULT '' -- user id of the admin who quarantined the device
 );
+CREATE INDEX idx_devices_trusted_until ON devices(trusted_until, id) WHERE trusted = true AND revoked_at IS NULL;
+CREATE INDEX idx_devices_fingerprint ON devices(fingerprint) WHERE revoked_at IS NULL;
 
 -- Sessions (ref users, organizations, devices)
 CREATE TABLE sessions (
     id                 VARCHAR PRIMARY KEY,
     user_id            VARCHAR NOT NULL REFERENCES users(id),
     org_id             VARCHAR NOT NULL REFERENCES organizations(id),
-    device_id          VARCHAR NOT NULL REFERENCES devices(id),
+    device_id          VARCHAR REFERENCES devices(id), -- NULL for an ephemeral (public device) session
     expires_at         TIMESTAMPTZ NOT NULL,
     revoked_at         TIMESTAMPTZ,
     last_seen_at       TIMESTAMPTZ,
     ip_address         VARCHAR,
     refresh_jti         VARCHAR,
     refresh_token_hash VARCHAR,
-    created_at         TIMESTAMPTZ NOT NULL
+    created_at         TIMESTAMPTZ NOT NULL,
+    country            VARCHAR,
+    pop_jkt            VARCHAR,
+    user_agent         VARCHAR,
+    client_version     VARCHAR,
+    auth_method        VARCHAR,
+    mfa_method         VARCHAR,
+    revocation_reason  VARCHAR,
+    revoked_by         VARCHAR,
+    ephemeral          BOOLEAN NOT NULL DEFAULT false -- signed in on a public device: no device, short-lived, revoked when idle
 );
 
+CREATE INDEX idx_sessions_created_at ON sessions(created_at);
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX idx_sessions_org_revoked_at ON sessions(org_id, revoked_at) WHERE revoked_at IS NOT NULL;
+CREATE INDEX idx_sessions_ephemeral_active ON sessions(last_seen_at) WHERE ephemeral AND revoked_at IS NULL;
+
 -- Policies (ref organizations)
 CREATE TABLE policies (
     id         VARCHAR PRIMARY KEY,
@@ -100,14 +128,19 @@ CREATE TABLE org_mfa_settings (
 
 -- MFA challenges (OTP flow)
 CREATE TABLE mfa_challenges (
-    id         VARCHAR PRIMARY KEY,
-    user_id    VARCHAR NOT NULL REFERENCES users(id),
-    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
-    device_id  VARCHAR NOT NULL REFERENCES devices(id),
-    phone      VARCHAR NOT NULL,
-    code_hash  VARCHAR NOT NULL,
-    expires_at TIMESTAMPTZ NOT NULL,
-    created_at TIMESTAMPTZ NOT NULL
+    id           VARCHAR PRIMARY KEY,
+    user_id      VARCHAR NOT NULL REFERENCES users(id),
+    org_id       VARCHAR NOT NULL REFERENCES organizations(id),
+    device_id    VARCHAR REFERENCES devices(id), -- NULL for a public device sign-in
+    phone        VARCHAR NOT NULL,
+    code_hash    VARCHAR NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    method       VARCHAR NOT NULL DEFAULT 'sms_otp',
+    attempts     INT NOT NULL DEFAULT 0,
+    resend_count INT NOT NULL DEFAULT 0,
+    last_sent_at TIMESTAMPTZ,
+    trust_days   INT NOT NULL DEFAULT 0
 );
 
 CREATE INDEX idx_mfa_challenges_expires_at ON mfa_challenges(expires_at);
@@ -123,21 +156,654 @@ CREATE TABLE mfa_intents (
 
 CREATE INDEX idx_mfa_intents_expires_at ON mfa_intents(expires_at);
 
+-- MFA recovery codes (one-time; hashed at rest; used_at set when consumed)
+CREATE TABLE mfa_recovery_codes (
+    id         VARCHAR PRIMARY KEY,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    code_hash  VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
+
 -- Org policy config (structured JSON per org; one row per org)
 CREATE TABLE org_policy_config (
     org_id      VARCHAR PRIMARY KEY REFERENCES organizations(id),
     config_json TEXT NOT NULL DEFAULT '{}',
-    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    version     BIGINT NOT NULL DEFAULT 1  -- bumped on every write; etag for UpdateOrgPolicyConfig
 );
 
--- Audit logs (ref organizations, users)
+-- Org policy config history (one row per stored version; ref organizations)
+CREATE TABLE org_policy_config_versions (
+    org_id           VARCHAR NOT NULL REFERENCES organizations(id),
+    version          BIGINT NOT NULL,
+    config_json      TEXT NOT NULL,
+    changed_by       VARCHAR,          -- user id of the admin who made the change
+    changed_at       TIMESTAMPTZ NOT NULL,
+    source           VARCHAR NOT NULL, -- update, bulk_update_domains, rollback, change_request, scheduled
+    restored_version BIGINT,           -- for rollback, the version that was restored
+    PRIMARY KEY (org_id, version)
+);
+
+-- Org policy config updates scheduled for a future time (ref organizations, users)
+CREATE TABLE scheduled_policy_config_changes (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR NOT NULL REFERENCES organizations(id),
+    config_json     TEXT NOT NULL,               -- the update, as in UpdateOrgPolicyConfigRequest.config
+    update_mask     TEXT NOT NULL DEFAULT '',    -- comma-separated section names; empty replaces the whole config
+    effective_at    TIMESTAMPTZ NOT NULL,
+    status          VARCHAR NOT NULL,            -- pending, applied, cancelled, failed
+    created_by      VARCHAR NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMPTZ NOT NULL,
+    resolved_by     VARCHAR REFERENCES users(id), -- admin who cancelled it
+    resolved_at     TIMESTAMPTZ,                 -- when it was applied, cancelled or failed
+    applied_version BIGINT,                      -- org_policy_config version it was stored as
+    error           TEXT NOT NULL DEFAULT ''     -- why it could not be applied
+);
+
+CREATE INDEX idx_scheduled_policy_config_changes_org ON scheduled_policy_config_changes(org_id, effective_at);
+CREATE INDEX idx_scheduled_policy_config_changes_due ON scheduled_policy_config_changes(effective_at) WHERE status = 'pending';
+
+-- Four-eyes change requests for org policy config and Rego policies (ref organizations, users)
+CREATE TABLE change_requests (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    kind           VARCHAR NOT NULL, -- org_policy_config, policy
+    status         VARCHAR NOT NULL, -- pending, approved, rejected
+    proposed_by    VARCHAR NOT NULL REFERENCES users(id),
+    reason         TEXT NOT NULL DEFAULT '',
+    payload_json   TEXT NOT NULL,    -- the proposed change; shape depends on kind
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_change_requests_org_created ON change_requests(org_id, created_at DESC);
+
+-- Audit logs, partitioned by UTC month of created_at (audit_logs_pYYYYMM, plus audit_logs_default). Partitions are
+-- managed with the functions below; see migration 027. No foreign keys: logs of orgs with a data region are stored
+-- in the region's database (migration 032).
 CREATE TABLE audit_logs (
-    id         VARCHAR PRIMARY KEY,
-    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
-    user_id    VARCHAR REFERENCES users(id),
+    id         VARCHAR NOT NULL,
+    org_id     VARCHAR NOT NULL,
+    user_id    VARCHAR,
     action     VARCHAR NOT NULL,
     resource   VARCHAR NOT NULL,
     ip         VARCHAR NOT NULL,
     metadata   TEXT,
-    created_at TIMESTAMPTZ NOT NULL
+    created_at TIMESTAMPTZ NOT NULL,
+    request_id VARCHAR,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX idx_audit_logs_request_id ON audit_logs(request_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+CREATE INDEX idx_audit_logs_action_created_at ON audit_logs(action, created_at);
+CREATE INDEX idx_audit_logs_org_created_at ON audit_logs(org_id, created_at);
+CREATE INDEX idx_audit_logs_user_created_at ON audit_logs(user_id, created_at);
+
+CREATE TABLE audit_logs_default PARTITION OF audit_logs DEFAULT;
+
+CREATE FUNCTION audit_logs_create_partition(month DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    start_at TIMESTAMPTZ := date_trunc('month', month::timestamp) AT TIME ZONE 'UTC';
+    end_at   TIMESTAMPTZ := (date_trunc('month', month::timestamp) + INTERVAL '1 month') AT TIME ZONE 'UTC';
+    name     TEXT := 'audit_logs_p' || to_char(month, 'YYYYMM');
+BEGIN
+    IF to_regclass(name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+    EXECUTE format('CREATE TABLE %I (LIKE audit_logs INCLUDING DEFAULTS)', name);
+    EXECUTE format('WITH moved AS (DELETE FROM audit_logs_default WHERE created_at >= %L AND created_at < %L RETURNING *) '
+                   'INSERT INTO %I SELECT * FROM moved', start_at, end_at, name);
+    EXECUTE format('ALTER TABLE audit_logs ATTACH PARTITION %I FOR VALUES FROM (%L) TO (%L)', name, start_at, end_at);
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION audit_logs_drop_partitions_before(cutoff TIMESTAMPTZ) RETURNS SETOF TEXT AS $$
+DECLARE
+    part TEXT;
+BEGIN
+    FOR part IN
+        SELECT c.relname::text
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        WHERE i.inhparent = 'audit_logs'::regclass
+          AND c.relname ~ '^audit_logs_p[0-9]{6}$'
+          AND (to_date(substr(c.relname, 13), 'YYYYMM')::timestamp + INTERVAL '1 month') AT TIME ZONE 'UTC' <= cutoff
+        ORDER BY c.relname
+    LOOP
+        EXECUTE format('DROP TABLE %I', part);
+        RETURN NEXT part;
+    END LOOP;
+    DELETE FROM audit_logs_default WHERE created_at < cutoff;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Per-user notification preferences (login alerts opt-out)
+CREATE TABLE notification_preferences (
+    user_id              VARCHAR PRIMARY KEY REFERENCES users(id),
+    login_alerts_opt_out BOOLEAN NOT NULL DEFAULT false,
+    updated_at           TIMESTAMPTZ NOT NULL,
+    locale               VARCHAR NOT NULL DEFAULT '' -- e.g. "de", "pt-BR"; picks notification templates
+);
+
+-- Devices and locations a user has signed in from (new sign-in detection)
+CREATE TABLE known_login_contexts (
+    user_id       VARCHAR NOT NULL REFERENCES users(id),
+    kind          VARCHAR NOT NULL,
+    value         VARCHAR NOT NULL,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, kind, value)
+);
+
+-- Per-user security activity feed (user_id and org_id unconstrained: rows of region-pinned orgs live in a regional
+-- database)
+CREATE TABLE security_events (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR,
+    user_id         VARCHAR NOT NULL,
+    event_type      VARCHAR NOT NULL,
+    severity        VARCHAR NOT NULL,
+    ip              VARCHAR NOT NULL,
+    metadata        TEXT,
+    created_at      TIMESTAMPTZ NOT NULL,
+    acknowledged_at TIMESTAMPTZ,
+    dismissed_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_security_events_user_created ON security_events(user_id, created_at DESC);
+
+-- Login analytics rollups (AnalyticsService), recomputed per day by the analytics rollup job
+CREATE TABLE analytics_daily_logins (
+    org_id           VARCHAR NOT NULL,
+    day              DATE NOT NULL,
+    login_successes  BIGINT NOT NULL,
+    login_failures   BIGINT NOT NULL,
+    mfa_challenges   BIGINT NOT NULL,
+    sessions_created BIGINT NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day)
+);
+
+CREATE TABLE analytics_daily_device_sessions (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    device_id  VARCHAR NOT NULL,
+    sessions   BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, device_id)
+);
+
+CREATE TABLE analytics_daily_country_sessions (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    country    VARCHAR NOT NULL,
+    sessions   BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, country)
+);
+
+CREATE TABLE analytics_daily_policy_violations (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    action     VARCHAR NOT NULL,
+    violations BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, action)
+);
+
+-- Policy effectiveness rollups: URLs denied by CheckUrlAccess per domain and policy violations per user
+CREATE TABLE analytics_daily_blocked_domains (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    domain     VARCHAR NOT NULL,
+    blocks     BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, domain)
+);
+
+CREATE TABLE analytics_daily_user_violations (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    user_id    VARCHAR NOT NULL,
+    violations BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, day, user_id)
+);
+
+-- Monthly usage for billing (AnalyticsService GetUsage, AdminService ExportUsage); api_calls is added by the metering
+-- flush, the other counters are recomputed by the metering job
+CREATE TABLE usage_monthly (
+    org_id         VARCHAR NOT NULL,
+    month          DATE NOT NULL,
+    active_users   BIGINT NOT NULL DEFAULT 0,
+    logins         BIGINT NOT NULL DEFAULT 0,
+    mfa_challenges BIGINT NOT NULL DEFAULT 0,
+    mfa_resends    BIGINT NOT NULL DEFAULT 0,
+    api_calls      BIGINT NOT NULL DEFAULT 0,
+    updated_at     TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, month)
+);
+CREATE INDEX idx_usage_monthly_month ON usage_monthly(month);
+
+CREATE TABLE usage_active_users (
+    org_id  VARCHAR NOT NULL,
+    month   DATE NOT NULL,
+    user_id VARCHAR NOT NULL,
+    PRIMARY KEY (org_id, month, user_id)
+);
+
+-- Data exports of a user's personal data (UserService ExportMyData, AdminService ExportUserData); generated by the
+-- data export job and downloaded with the token whose hash is stored. No foreign keys, since exports are stored in the
+-- data region of the user's home org
+CREATE TABLE data_exports (
+    id           VARCHAR PRIMARY KEY,
+    user_id      VARCHAR NOT NULL,
+    requested_by VARCHAR NOT NULL,
+    token_hash   VARCHAR NOT NULL UNIQUE,
+    status       VARCHAR NOT NULL DEFAULT 'pending',
+    archive      BYTEA,
+    error        TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL,
+    claimed_at   TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    org_id       VARCHAR NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_data_exports_user ON data_exports(user_id, status);
+CREATE INDEX idx_data_exports_status_created ON data_exports(status, created_at);
+
+-- Temporary login blocks for client IPs (anomaly detector auto-blocks)
+CREATE TABLE ip_blocks (
+    ip         VARCHAR PRIMARY KEY,
+    reason     VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- Actions blocked by browser agents under org action_restrictions (PolicyViolationService); no foreign keys, like
+-- audit_logs, since rows of orgs with a data region are stored in the region's database
+CREATE TABLE policy_violations (
+    id                VARCHAR PRIMARY KEY,
+    org_id            VARCHAR NOT NULL,
+    user_id           VARCHAR NOT NULL,
+    device_id         VARCHAR NOT NULL,
+    session_id        VARCHAR NOT NULL,
+    action            VARCHAR NOT NULL,
+    target            TEXT,
+    step_up_triggered BOOLEAN NOT NULL DEFAULT false,
+    created_at        TIMESTAMPTZ NOT NULL,
+    rule              VARCHAR NOT NULL DEFAULT '' -- DLP rule that blocked the action; empty for allowed_actions
+);
+
+CREATE INDEX idx_policy_violations_org_created ON policy_violations(org_id, created_at DESC);
+CREATE INDEX idx_policy_violations_created_at ON policy_violations(created_at);
+
+-- Feature flags for gradual rollouts (FeatureFlagService)
+CREATE TABLE feature_flags (
+    key                VARCHAR PRIMARY KEY,
+    description        TEXT NOT NULL DEFAULT '',
+    enabled            BOOLEAN NOT NULL DEFAULT false,
+    rollout_percentage INTEGER NOT NULL DEFAULT 0 CHECK (rollout_percentage BETWEEN 0 AND 100),
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE feature_flag_org_overrides (
+    flag_key   VARCHAR NOT NULL REFERENCES feature_flags(key) ON DELETE CASCADE,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    enabled    BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (flag_key, org_id)
+);
+
+-- Session revocation replication: latest event received per origin region
+CREATE TABLE session_replication_watermarks (
+    origin_region VARCHAR PRIMARY KEY,
+    last_event_at TIMESTAMPTZ NOT NULL,
+    received_at   TIMESTAMPTZ NOT NULL
+);
+
+-- Per-org API quota assignments (ref organizations, users); orgs without a row use the default plan
+CREATE TABLE org_quotas (
+    org_id                    VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    plan                      VARCHAR NOT NULL DEFAULT '', -- plan name from QUOTA_PLANS; empty uses the default plan
+    org_requests_per_minute   INTEGER,                     -- overrides the plan's org quota; 0 = unlimited
+    token_requests_per_minute INTEGER,                     -- overrides the plan's per-session quota; 0 = unlimited
+    updated_by                VARCHAR NOT NULL REFERENCES users(id),
+    updated_at                TIMESTAMPTZ NOT NULL
+);
+
+-- Data encryption keys for PII columns, wrapped with the PII master key; scope is platform, blind_index or an org ID
+CREATE TABLE data_keys (
+    id            VARCHAR PRIMARY KEY,
+    scope         VARCHAR NOT NULL,
+    wrapped_key   BYTEA NOT NULL,
+    master_key_id VARCHAR NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL,
+    retired_at    TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX idx_data_keys_active_scope ON data_keys(scope) WHERE retired_at IS NULL;
+
+-- Groups of an org's users (ref organizations); group admins manage the users of their groups
+CREATE TABLE groups (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    name       VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (org_id, name)
+);
+
+-- Group membership (ref groups, users); role is member or admin
+CREATE TABLE group_members (
+    group_id   VARCHAR NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    role       VARCHAR NOT NULL DEFAULT 'member',
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+CREATE INDEX idx_group_members_user_id ON group_members(user_id);
+
+-- Just-in-time admin elevations (ref organizations, users); status is pending, approved, rejected, revoked or expired
+CREATE TABLE privilege_elevations (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    status         VARCHAR NOT NULL,
+    justification  TEXT NOT NULL,
+    duration_secs  INTEGER NOT NULL,
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    expires_at     TIMESTAMPTZ,
+    revoked_by     VARCHAR REFERENCES users(id),
+    revoked_at     TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_privilege_elevations_org_created ON privilege_elevations(org_id, created_at DESC);
+CREATE INDEX idx_privilege_elevations_user_org ON privilege_elevations(user_id, org_id) WHERE status IN ('pending', 'approved');
+CREATE INDEX idx_privilege_elevations_approved_expires ON privilege_elevations(expires_at) WHERE status = 'approved';
+
+-- Break-glass accounts (ref organizations, users, devices); one per org, credential stored as a SHA-256 hash
+CREATE TABLE break_glass_accounts (
+    org_id         VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    device_id      VARCHAR NOT NULL REFERENCES devices(id),
+    secret_hash    VARCHAR NOT NULL,
+    provisioned_by VARCHAR NOT NULL REFERENCES users(id),
+    provisioned_at TIMESTAMPTZ NOT NULL
+);
+
+-- Break-glass activations (ref organizations, users); status is pending, active, ended or closed
+CREATE TABLE break_glass_activations (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    status         VARCHAR NOT NULL,
+    reason         TEXT NOT NULL,
+    requested_by   VARCHAR NOT NULL REFERENCES users(id),
+    requested_at   TIMESTAMPTZ NOT NULL,
+    approved_by    VARCHAR REFERENCES users(id),
+    approved_at    TIMESTAMPTZ,
+    expires_at     TIMESTAMPTZ,
+    ended_by       VARCHAR REFERENCES users(id),
+    ended_at       TIMESTAMPTZ,
+    report_summary TEXT NOT NULL DEFAULT '',
+    reported_by    VARCHAR REFERENCES users(id),
+    reported_at    TIMESTAMPTZ
+);
+CREATE INDEX idx_break_glass_activations_org_requested ON break_glass_activations(org_id, requested_at DESC);
+CREATE INDEX idx_break_glass_activations_active_expires ON break_glass_activations(expires_at) WHERE status = 'active';
+
+-- Login holds (ref organizations, users, devices); status is pending, approved, denied or completed
+CREATE TABLE login_holds (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    device_id  VARCHAR REFERENCES devices(id), -- NULL for a public device sign-in
+    token_hash VARCHAR NOT NULL,
+    reasons    VARCHAR NOT NULL,
+    ip         VARCHAR NOT NULL DEFAULT '',
+    status     VARCHAR NOT NULL,
+    decided_by VARCHAR REFERENCES users(id),
+    decided_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_login_holds_org_pending ON login_holds(org_id, created_at DESC) WHERE status = 'pending';
+
+-- Honeytokens (ref users); decoy accounts, every sign-in attempt against one is rejected and alerted
+CREATE TABLE honeytokens (
+    user_id           VARCHAR PRIMARY KEY REFERENCES users(id),
+    label             VARCHAR NOT NULL DEFAULT '',
+    created_by        VARCHAR NOT NULL REFERENCES users(id),
+    created_at        TIMESTAMPTZ NOT NULL,
+    trigger_count     BIGINT NOT NULL DEFAULT 0,
+    last_triggered_at TIMESTAMPTZ
+);
+
+-- Device codes (ref users, organizations); cross-device sign-in approved from a trusted session
+CREATE TABLE device_codes (
+    id                 VARCHAR PRIMARY KEY,
+    device_code_hash   VARCHAR NOT NULL UNIQUE,
+    user_code          VARCHAR NOT NULL,
+    client_name        VARCHAR NOT NULL DEFAULT '',
+    device_fingerprint VARCHAR NOT NULL DEFAULT '',
+    ip                 VARCHAR NOT NULL DEFAULT '',
+    status             VARCHAR NOT NULL,
+    user_id            VARCHAR REFERENCES users(id),
+    org_id             VARCHAR REFERENCES organizations(id),
+    decided_at         TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL,
+    expires_at         TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_device_codes_user_code_pending ON device_codes(user_code) WHERE status = 'pending';
+
+-- Magic links (ref users, organizations); one-time passwordless sign-in links sent by email
+CREATE TABLE magic_links (
+    id         VARCHAR PRIMARY KEY,
+    token_hash VARCHAR NOT NULL UNIQUE,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    ip         VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+-- Org domains (ref organizations, users); email domains claimed by an org, verified by a DNS TXT record
+CREATE TABLE org_domains (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR NOT NULL REFERENCES organizations(id),
+    domain          VARCHAR NOT NULL,
+    token           VARCHAR NOT NULL,
+    status          VARCHAR NOT NULL DEFAULT 'pending',
+    created_by      VARCHAR NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMPTZ NOT NULL,
+    verified_at     TIMESTAMPTZ,
+    last_checked_at TIMESTAMPTZ,
+    UNIQUE (org_id, domain)
+);
+CREATE UNIQUE INDEX idx_org_domains_verified ON org_domains(domain) WHERE status = 'verified';
+
+-- Org SMTP settings (ref organizations, users); an org's own mail server, password held in the secrets provider
+CREATE TABLE org_smtp_settings (
+    org_id          VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    host            VARCHAR NOT NULL,
+    port            INTEGER NOT NULL,
+    username        VARCHAR NOT NULL DEFAULT '',
+    password_secret VARCHAR NOT NULL DEFAULT '',
+    from_address    VARCHAR NOT NULL,
+    updated_by      VARCHAR NOT NULL REFERENCES users(id),
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+-- Notification templates (ref organizations, users); org overrides of notification texts per kind and locale
+CREATE TABLE notification_templates (
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    kind       VARCHAR NOT NULL,
+    locale     VARCHAR NOT NULL DEFAULT '',
+    subject    VARCHAR NOT NULL DEFAULT '',
+    body       VARCHAR NOT NULL,
+    updated_by VARCHAR NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, kind, locale)
+);
+
+-- Agents (ref organizations, users, devices); browser and endpoint agents, one per device, with their last heartbeat
+CREATE TABLE agents (
+    id                VARCHAR PRIMARY KEY,
+    org_id            VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id           VARCHAR NOT NULL REFERENCES users(id),
+    device_id         VARCHAR NOT NULL UNIQUE REFERENCES devices(id),
+    platform          VARCHAR NOT NULL,
+    version           VARCHAR NOT NULL,
+    posture_json      TEXT NOT NULL DEFAULT '{}',
+    policy_version    BIGINT NOT NULL DEFAULT 0,
+    registered_at     TIMESTAMPTZ NOT NULL,
+    last_heartbeat_at TIMESTAMPTZ NOT NULL,
+    trust_degraded_at TIMESTAMPTZ
+);
+CREATE INDEX idx_agents_org_heartbeat ON agents(org_id, last_heartbeat_at, id);
+CREATE INDEX idx_agents_silent ON agents(last_heartbeat_at) WHERE trust_degraded_at IS NULL;
+
+-- Telemetry events; agent telemetry stored by the embedded transport, deduplicated by (org_id, event_id)
+CREATE TABLE telemetry_events (
+    org_id          VARCHAR NOT NULL,
+    event_id        VARCHAR NOT NULL,
+    schema_version  INTEGER NOT NULL,
+    type            VARCHAR NOT NULL,
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    received_at     TIMESTAMPTZ NOT NULL,
+    user_id         VARCHAR NOT NULL,
+    session_id      VARCHAR NOT NULL,
+    device_id       VARCHAR NOT NULL DEFAULT '',
+    region          VARCHAR NOT NULL DEFAULT '',
+    attributes_json TEXT NOT NULL DEFAULT '{}',
+    request_id      VARCHAR NOT NULL DEFAULT '',
+    PRIMARY KEY (org_id, event_id)
+);
+CREATE INDEX idx_telemetry_events_org_received ON telemetry_events(org_id, received_at DESC, event_id);
+CREATE INDEX idx_telemetry_events_received ON telemetry_events(received_at);
+
+-- Policy presets; named org policy config and Rego bundles applied with PolicyPresetService.ApplyPreset
+CREATE TABLE policy_presets (
+    name         VARCHAR PRIMARY KEY,
+    display_name VARCHAR NOT NULL,
+    description  TEXT NOT NULL,
+    policy_rules TEXT NOT NULL DEFAULT '',
+    config_json  TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+-- Org invitations (ref organizations, users); one-time registration tokens for invite-only registration
+CREATE TABLE org_invitations (
+    id          VARCHAR PRIMARY KEY,
+    org_id      VARCHAR NOT NULL REFERENCES organizations(id),
+    email       VARCHAR NOT NULL,
+    role        role NOT NULL,
+    token_hash  VARCHAR NOT NULL UNIQUE,
+    invited_by  VARCHAR NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    accepted_by VARCHAR REFERENCES users(id),
+    revoked_at  TIMESTAMPTZ
+);
+CREATE INDEX idx_org_invitations_org_created ON org_invitations(org_id, created_at DESC);
+
+-- Org attribute definitions (ref organizations); custom user attributes declared with UserAttributeService
+CREATE TABLE org_attribute_definitions (
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    key            VARCHAR NOT NULL,
+    display_name   VARCHAR NOT NULL,
+    description    TEXT NOT NULL DEFAULT '',
+    type           VARCHAR NOT NULL,
+    allowed_values VARCHAR[] NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, key)
+);
+
+-- Membership attribute values (ref memberships, org_attribute_definitions)
+CREATE TABLE membership_attributes (
+    membership_id VARCHAR NOT NULL REFERENCES memberships(id) ON DELETE CASCADE,
+    org_id        VARCHAR NOT NULL,
+    key           VARCHAR NOT NULL,
+    value         VARCHAR NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (membership_id, key),
+    FOREIGN KEY (org_id, key) REFERENCES org_attribute_definitions(org_id, key) ON DELETE CASCADE
+);
+CREATE INDEX idx_membership_attributes_search ON membership_attributes(org_id, key, value);
+
+-- Email changes (ref users, organizations); confirmed from both the current and the new address
+CREATE TABLE email_changes (
+    id               VARCHAR PRIMARY KEY,
+    user_id          VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    org_id           VARCHAR NOT NULL REFERENCES organizations(id),
+    old_email        VARCHAR NOT NULL,
+    new_email        VARCHAR NOT NULL,
+    old_token_hash   VARCHAR NOT NULL UNIQUE,
+    new_token_hash   VARCHAR NOT NULL UNIQUE,
+    ip               VARCHAR NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL,
+    expires_at       TIMESTAMPTZ NOT NULL,
+    old_confirmed_at TIMESTAMPTZ,
+    new_confirmed_at TIMESTAMPTZ,
+    completed_at     TIMESTAMPTZ
+);
+CREATE INDEX idx_email_changes_user ON email_changes(user_id);
+
+-- Usernames (ref users); org_id is '' for global usernames, else the org the username is unique in
+CREATE TABLE usernames (
+    user_id    VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    org_id     VARCHAR NOT NULL DEFAULT '',
+    username   VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, org_id),
+    UNIQUE (org_id, username)
+);
+
+-- URL access exceptions (ref organizations, users); approved exceptions allow one domain for one member until expires_at
+CREATE TABLE url_exceptions (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    domain         VARCHAR NOT NULL,
+    url            TEXT NOT NULL,
+    status         VARCHAR NOT NULL,
+    justification  TEXT NOT NULL,
+    duration_secs  INTEGER NOT NULL,
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    expires_at     TIMESTAMPTZ,
+    revoked_by     VARCHAR REFERENCES users(id),
+    revoked_at     TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_url_exceptions_org_created ON url_exceptions(org_id, created_at DESC);
+CREATE INDEX idx_url_exceptions_user_org_domain ON url_exceptions(user_id, org_id, domain) WHERE status IN ('pending', 'approved');
+
+-- Org audit webhooks (ref organizations, users); audit entries matching filter are POSTed to url
+CREATE TABLE audit_webhooks (
+    id          VARCHAR PRIMARY KEY,
+    org_id      VARCHAR NOT NULL REFERENCES organizations(id),
+    url         TEXT NOT NULL,
+    secret      VARCHAR NOT NULL,
+    filter      TEXT NOT NULL DEFAULT '',
+    description VARCHAR NOT NULL DEFAULT '',
+    enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by  VARCHAR NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
 );
+CREATE INDEX idx_audit_webhooks_org ON audit_webhooks(org_id, created_at);
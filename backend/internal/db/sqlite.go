@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteBusyTimeout is how long a statement waits for another connection's write transaction before failing.
+const sqliteBusyTimeout = 5 * time.Second
+
+// sqliteTimeFormat is how times are stored: the driver's "sqlite" format, always in UTC, so stored times compare in
+// time order as text.
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// sqliteDriver opens the SQLite connections; pg_array_json lets the translated queries read the Postgres array
+// literals lib/pq sends for array parameters.
+var sqliteDriver = newSQLiteDriver()
+
+func newSQLiteDriver() *sqlite.Driver {
+	d := &sqlite.Driver{}
+	d.MustRegisterDeterministicScalarFunction("pg_array_json", 1, pgArrayJSON)
+	return d
+}
+
+// IsSQLite reports whether dsn selects the SQLite backend instead of Postgres: a sqlite: (or sqlite3:) URL naming
+// the database file, e.g. sqlite:///var/lib/ztcp/ztcp.db, sqlite://ztcp.db or sqlite::memory:.
+func IsSQLite(dsn string) bool {
+	scheme, _, ok := strings.Cut(dsn, ":")
+	return ok && (strings.EqualFold(scheme, "sqlite") || strings.EqualFold(scheme, "sqlite3"))
+}
+
+// sqliteName returns the modernc.org/sqlite data source name for a sqlite DSN, and whether the database lives in
+// memory. Query parameters of dsn are passed on and take precedence over the defaults: foreign keys on, WAL, a busy
+// timeout, and write-locking transactions so that concurrent transactions queue instead of failing on upgrade.
+func sqliteName(dsn string) (string, bool, error) {
+	_, rest, _ := strings.Cut(dsn, ":")
+	rest = strings.TrimPrefix(rest, "//")
+	path, rawQuery, _ := strings.Cut(rest, "?")
+	if path == "" {
+		return "", false, errors.New("db: sqlite DSN has no database path")
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", false, fmt.Errorf("db: sqlite DSN: %w", err)
+	}
+	defaults := url.Values{
+		"_busy_timeout": {fmt.Sprint(sqliteBusyTimeout.Milliseconds())},
+		"_txlock":       {"immediate"},
+		"_time_format":  {"sqlite"},
+		"_timezone":     {"UTC"},
+		"_texttotime":   {"1"},
+	}
+	for key, values := range defaults {
+		if !query.Has(key) {
+			query[key] = values
+		}
+	}
+	query["_pragma"] = append([]string{"foreign_keys(1)", "journal_mode(WAL)"}, query["_pragma"]...)
+	memory := path == ":memory:" || query.Get("mode") == "memory"
+	return path + "?" + query.Encode(), memory, nil
+}
+
+// sqliteConnector opens connections that run the Postgres queries of the sqlc layer on SQLite.
+type sqliteConnector struct {
+	name string
+}
+
+func (c *sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	dc, err := sqliteDriver.Open(c.name)
+	if err != nil {
+		return nil, err
+	}
+	inner, ok := dc.(sqliteDriverConn)
+	if !ok {
+		_ = dc.Close()
+		return nil, fmt.Errorf("db: unexpected sqlite connection type %T", dc)
+	}
+	return &sqliteConn{sqliteDriverConn: inner}, nil
+}
+
+func (c *sqliteConnector) Driver() driver.Driver { return sqliteDriver }
+
+// sqliteDriverConn is what the modernc.org/sqlite connection implements and sqliteConn forwards.
+type sqliteDriverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+}
+
+// sqliteConn translates every statement to SQLite (see sqliteQuery) and converts the arguments SQLite stores
+// differently from Postgres.
+type sqliteConn struct {
+	sqliteDriverConn
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.sqliteDriverConn.Prepare(sqliteQuery(query))
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.sqliteDriverConn.PrepareContext(ctx, sqliteQuery(query))
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.sqliteDriverConn.ExecContext(ctx, sqliteQuery(query), args)
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.sqliteDriverConn.QueryContext(ctx, sqliteQuery(query), args)
+}
+
+// CheckNamedValue stores JSON as text, which the JSON functions read (a blob is taken for JSONB), and time arrays in
+// the stored time format. Everything else gets the default conversion.
+func (c *sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case json.RawMessage:
+		if v == nil {
+			nv.Value = nil
+		} else {
+			nv.Value = string(v)
+		}
+		return nil
+	case pq.GenericArray:
+		times, ok := v.A.([]time.Time)
+		if !ok {
+			return driver.ErrSkip
+		}
+		a := make(pq.StringArray, len(times))
+		for i, t := range times {
+			a[i] = t.UTC().Format(sqliteTimeFormat)
+		}
+		value, err := a.Value()
+		nv.Value = value
+		return err
+	}
+	return driver.ErrSkip
+}
+
+// pgArrayJSON is the SQL function pg_array_json(literal): the elements of a Postgres array literal as a JSON array
+// of strings, for json_each.
+func pgArrayJSON(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	var a pq.StringArray
+	if err := a.Scan(args[0]); err != nil {
+		return nil, err
+	}
+	if a == nil {
+		a = pq.StringArray{}
+	}
+	b, err := json.Marshal([]string(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// isSQLiteUniqueViolation reports whether err is a SQLite unique or primary key constraint failure.
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code()
+	return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
+
+// SQLiteSchema creates the SQLite schema: the Postgres schema of migration SQLiteSchemaVersion, translated.
+//
+//go:embed sqlite/schema.sql
+var SQLiteSchema string
+
+// SQLiteSchemaVersion is the migration SQLiteSchema matches.
+const SQLiteSchemaVersion = 65
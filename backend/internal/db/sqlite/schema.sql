@@ -0,0 +1,787 @@
+-- SQLite schema: the Postgres schema after the latest migration in ../migrations, for DATABASE_URLs with the sqlite
+-- scheme. Applied whole by the migrate runner; keep sqliteSchemaVersion in sync when adding a migration.
+--
+-- Enums are VARCHAR with a CHECK, VARCHAR[] columns hold the Postgres array literal (what lib/pq reads and writes),
+-- TIMESTAMPTZ is TIMESTAMP so the driver returns time.Time, and audit_logs is a single table instead of partitions.
+
+-- Users (no FKs)
+CREATE TABLE users (
+    id                 VARCHAR PRIMARY KEY,
+    email              VARCHAR NOT NULL UNIQUE,
+    name               VARCHAR,
+    status             VARCHAR NOT NULL CHECK (status IN ('active', 'disabled')),
+    created_at         TIMESTAMP NOT NULL,
+    updated_at         TIMESTAMP NOT NULL,
+    phone              VARCHAR,
+    phone_verified     BOOLEAN NOT NULL DEFAULT false,
+    mfa_reset_required BOOLEAN NOT NULL DEFAULT false,
+    email_hash         VARCHAR
+);
+CREATE UNIQUE INDEX idx_users_email_hash ON users (email_hash) WHERE email_hash IS NOT NULL;
+
+-- Identities (ref users)
+CREATE TABLE identities (
+    id            VARCHAR PRIMARY KEY,
+    user_id       VARCHAR NOT NULL REFERENCES users(id),
+    provider      VARCHAR NOT NULL CHECK (provider IN ('local', 'oidc', 'saml')),
+    provider_id   VARCHAR NOT NULL,
+    password_hash VARCHAR,
+    created_at    TIMESTAMP NOT NULL
+);
+
+-- Organizations
+CREATE TABLE organizations (
+    id          VARCHAR PRIMARY KEY,
+    name        VARCHAR NOT NULL,
+    status      VARCHAR NOT NULL CHECK (status IN ('active', 'suspended')),
+    created_at  TIMESTAMP NOT NULL,
+    data_region VARCHAR NOT NULL DEFAULT '',
+    max_users   INT NOT NULL DEFAULT 0,
+    max_devices INT NOT NULL DEFAULT 0,
+    plan        VARCHAR NOT NULL DEFAULT 'free'
+);
+
+-- Sentinel organization for audit events that have no org
+INSERT INTO organizations (id, name, status, created_at)
+VALUES ('_system', 'System', 'active', strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'));
+
+-- Memberships (ref users, organizations)
+CREATE TABLE memberships (
+    id         VARCHAR PRIMARY KEY,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    role       VARCHAR NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Devices (ref users, organizations)
+CREATE TABLE devices (
+    id                       VARCHAR PRIMARY KEY,
+    user_id                  VARCHAR NOT NULL REFERENCES users(id),
+    org_id                   VARCHAR NOT NULL REFERENCES organizations(id),
+    fingerprint              VARCHAR NOT NULL,
+    trusted                  BOOLEAN NOT NULL,
+    last_seen_at             TIMESTAMP,
+    created_at               TIMESTAMP NOT NULL,
+    trusted_until            TIMESTAMP,
+    revoked_at               TIMESTAMP,
+    trust_expiry_notified_at TIMESTAMP,
+    trust_days               INT NOT NULL DEFAULT 0,
+    quarantined_at           TIMESTAMP,
+    quarantine_reason        VARCHAR NOT NULL DEFAULT '',
+    quarantined_by           VARCHAR NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_devices_trusted_until ON devices (trusted_until, id) WHERE trusted = true AND revoked_at IS NULL;
+CREATE INDEX idx_devices_fingerprint ON devices (fingerprint) WHERE revoked_at IS NULL;
+
+-- Sessions (ref users, organizations, devices)
+CREATE TABLE sessions (
+    id                 VARCHAR PRIMARY KEY,
+    user_id            VARCHAR NOT NULL REFERENCES users(id),
+    org_id             VARCHAR NOT NULL REFERENCES organizations(id),
+    device_id          VARCHAR REFERENCES devices(id),
+    expires_at         TIMESTAMP NOT NULL,
+    revoked_at         TIMESTAMP,
+    last_seen_at       TIMESTAMP,
+    ip_address         VARCHAR,
+    created_at         TIMESTAMP NOT NULL,
+    refresh_jti        VARCHAR,
+    refresh_token_hash VARCHAR,
+    country            VARCHAR,
+    pop_jkt            VARCHAR,
+    user_agent         VARCHAR,
+    client_version     VARCHAR,
+    auth_method        VARCHAR,
+    mfa_method         VARCHAR,
+    revocation_reason  VARCHAR,
+    revoked_by         VARCHAR,
+    ephemeral          BOOLEAN NOT NULL DEFAULT false
+);
+CREATE INDEX idx_sessions_created_at ON sessions (created_at);
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+CREATE INDEX idx_sessions_org_revoked_at ON sessions (org_id, revoked_at) WHERE revoked_at IS NOT NULL;
+CREATE INDEX idx_sessions_ephemeral_active ON sessions (last_seen_at) WHERE ephemeral AND revoked_at IS NULL;
+
+-- Policies (ref organizations)
+CREATE TABLE policies (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    rules      TEXT NOT NULL,
+    enabled    BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Audit logs (org_id and user_id may name rows of another region's database, so no FKs)
+CREATE TABLE audit_logs (
+    id         VARCHAR NOT NULL,
+    org_id     VARCHAR NOT NULL,
+    user_id    VARCHAR,
+    action     VARCHAR NOT NULL,
+    resource   VARCHAR NOT NULL,
+    ip         VARCHAR NOT NULL,
+    metadata   TEXT,
+    created_at TIMESTAMP NOT NULL,
+    request_id VARCHAR,
+    PRIMARY KEY (id, created_at)
+);
+CREATE INDEX idx_audit_logs_request_id ON audit_logs (request_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
+CREATE INDEX idx_audit_logs_action_created_at ON audit_logs (action, created_at);
+CREATE INDEX idx_audit_logs_org_created_at ON audit_logs (org_id, created_at);
+CREATE INDEX idx_audit_logs_user_created_at ON audit_logs (user_id, created_at);
+
+-- Platform settings (key/value)
+CREATE TABLE platform_settings (
+    key        VARCHAR PRIMARY KEY,
+    value_json TEXT NOT NULL
+);
+
+-- Org MFA settings (ref organizations)
+CREATE TABLE org_mfa_settings (
+    org_id                      VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    mfa_required_for_new_device BOOLEAN NOT NULL DEFAULT true,
+    mfa_required_for_untrusted  BOOLEAN NOT NULL DEFAULT true,
+    mfa_required_always         BOOLEAN NOT NULL DEFAULT false,
+    register_trust_after_mfa    BOOLEAN NOT NULL DEFAULT true,
+    trust_ttl_days              INTEGER NOT NULL DEFAULT 30,
+    created_at                  TIMESTAMP NOT NULL,
+    updated_at                  TIMESTAMP NOT NULL
+);
+
+-- MFA challenges (ref users, organizations, devices)
+CREATE TABLE mfa_challenges (
+    id           VARCHAR PRIMARY KEY,
+    user_id      VARCHAR NOT NULL REFERENCES users(id),
+    org_id       VARCHAR NOT NULL REFERENCES organizations(id),
+    device_id    VARCHAR REFERENCES devices(id),
+    phone        VARCHAR NOT NULL,
+    code_hash    VARCHAR NOT NULL,
+    expires_at   TIMESTAMP NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    method       VARCHAR NOT NULL DEFAULT 'sms_otp',
+    attempts     INT NOT NULL DEFAULT 0,
+    resend_count INT NOT NULL DEFAULT 0,
+    last_sent_at TIMESTAMP,
+    trust_days   INT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_mfa_challenges_expires_at ON mfa_challenges (expires_at);
+
+-- MFA intents (ref users, organizations, devices)
+CREATE TABLE mfa_intents (
+    id         VARCHAR PRIMARY KEY,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    device_id  VARCHAR NOT NULL REFERENCES devices(id),
+    expires_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_mfa_intents_expires_at ON mfa_intents (expires_at);
+
+-- MFA recovery codes (ref users)
+CREATE TABLE mfa_recovery_codes (
+    id         VARCHAR PRIMARY KEY,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    code_hash  VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
+
+-- Org policy config (ref organizations) and its history
+CREATE TABLE org_policy_config (
+    org_id      VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    config_json TEXT NOT NULL DEFAULT '{}',
+    updated_at  TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    version     BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE TABLE org_policy_config_versions (
+    org_id           VARCHAR NOT NULL REFERENCES organizations(id),
+    version          BIGINT NOT NULL,
+    config_json      TEXT NOT NULL,
+    changed_by       VARCHAR,
+    changed_at       TIMESTAMP NOT NULL,
+    source           VARCHAR NOT NULL,
+    restored_version BIGINT,
+    PRIMARY KEY (org_id, version)
+);
+
+-- Login notifications (ref users)
+CREATE TABLE notification_preferences (
+    user_id              VARCHAR PRIMARY KEY REFERENCES users(id),
+    login_alerts_opt_out BOOLEAN NOT NULL DEFAULT false,
+    updated_at           TIMESTAMP NOT NULL,
+    locale               VARCHAR NOT NULL DEFAULT ''
+);
+
+CREATE TABLE known_login_contexts (
+    user_id       VARCHAR NOT NULL REFERENCES users(id),
+    kind          VARCHAR NOT NULL,
+    value         VARCHAR NOT NULL,
+    first_seen_at TIMESTAMP NOT NULL,
+    last_seen_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, kind, value)
+);
+
+-- Security events (user_id may name a user of another region's database, so no FK)
+CREATE TABLE security_events (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR,
+    user_id         VARCHAR NOT NULL,
+    event_type      VARCHAR NOT NULL,
+    severity        VARCHAR NOT NULL,
+    ip              VARCHAR NOT NULL,
+    metadata        TEXT,
+    created_at      TIMESTAMP NOT NULL,
+    acknowledged_at TIMESTAMP,
+    dismissed_at    TIMESTAMP
+);
+CREATE INDEX idx_security_events_user_created ON security_events (user_id, created_at DESC);
+
+-- Analytics rollups
+CREATE TABLE analytics_daily_logins (
+    org_id           VARCHAR NOT NULL,
+    day              DATE NOT NULL,
+    login_successes  BIGINT NOT NULL,
+    login_failures   BIGINT NOT NULL,
+    mfa_challenges   BIGINT NOT NULL,
+    sessions_created BIGINT NOT NULL,
+    updated_at       TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, day)
+);
+
+CREATE TABLE analytics_daily_device_sessions (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    device_id  VARCHAR NOT NULL,
+    sessions   BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, day, device_id)
+);
+
+CREATE TABLE analytics_daily_country_sessions (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    country    VARCHAR NOT NULL,
+    sessions   BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, day, country)
+);
+
+CREATE TABLE analytics_daily_policy_violations (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    action     VARCHAR NOT NULL,
+    violations BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, day, action)
+);
+
+CREATE TABLE analytics_daily_blocked_domains (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    domain     VARCHAR NOT NULL,
+    blocks     BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, day, domain)
+);
+
+CREATE TABLE analytics_daily_user_violations (
+    org_id     VARCHAR NOT NULL,
+    day        DATE NOT NULL,
+    user_id    VARCHAR NOT NULL,
+    violations BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, day, user_id)
+);
+
+-- Anomaly detection
+CREATE TABLE ip_blocks (
+    ip         VARCHAR PRIMARY KEY,
+    reason     VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Policy violations (org_id and user_id may name rows of another region's database, so no FKs)
+CREATE TABLE policy_violations (
+    id                VARCHAR PRIMARY KEY,
+    org_id            VARCHAR NOT NULL,
+    user_id           VARCHAR NOT NULL,
+    device_id         VARCHAR NOT NULL,
+    session_id        VARCHAR NOT NULL,
+    action            VARCHAR NOT NULL,
+    target            TEXT,
+    step_up_triggered BOOLEAN NOT NULL DEFAULT false,
+    created_at        TIMESTAMP NOT NULL,
+    rule              VARCHAR NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_policy_violations_org_created ON policy_violations (org_id, created_at DESC);
+CREATE INDEX idx_policy_violations_created_at ON policy_violations (created_at);
+
+-- Feature flags
+CREATE TABLE feature_flags (
+    key                VARCHAR PRIMARY KEY,
+    description        TEXT NOT NULL DEFAULT '',
+    enabled            BOOLEAN NOT NULL DEFAULT false,
+    rollout_percentage INTEGER NOT NULL DEFAULT 0 CHECK (rollout_percentage BETWEEN 0 AND 100),
+    created_at         TIMESTAMP NOT NULL,
+    updated_at         TIMESTAMP NOT NULL
+);
+
+CREATE TABLE feature_flag_org_overrides (
+    flag_key   VARCHAR NOT NULL REFERENCES feature_flags(key) ON DELETE CASCADE,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    enabled    BOOLEAN NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (flag_key, org_id)
+);
+
+-- Session replication
+CREATE TABLE session_replication_watermarks (
+    origin_region VARCHAR PRIMARY KEY,
+    last_event_at TIMESTAMP NOT NULL,
+    received_at   TIMESTAMP NOT NULL
+);
+
+-- Change requests (ref organizations, users)
+CREATE TABLE change_requests (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    kind           VARCHAR NOT NULL,
+    status         VARCHAR NOT NULL,
+    proposed_by    VARCHAR NOT NULL REFERENCES users(id),
+    reason         TEXT NOT NULL DEFAULT '',
+    payload_json   TEXT NOT NULL,
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMP,
+    created_at     TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_change_requests_org_created ON change_requests (org_id, created_at DESC);
+
+-- Scheduled policy config changes (ref organizations, users)
+CREATE TABLE scheduled_policy_config_changes (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR NOT NULL REFERENCES organizations(id),
+    config_json     TEXT NOT NULL,
+    update_mask     TEXT NOT NULL DEFAULT '',
+    effective_at    TIMESTAMP NOT NULL,
+    status          VARCHAR NOT NULL,
+    created_by      VARCHAR NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMP NOT NULL,
+    resolved_by     VARCHAR REFERENCES users(id),
+    resolved_at     TIMESTAMP,
+    applied_version BIGINT,
+    error           TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_scheduled_policy_config_changes_org ON scheduled_policy_config_changes (org_id, effective_at);
+CREATE INDEX idx_scheduled_policy_config_changes_due ON scheduled_policy_config_changes (effective_at) WHERE status = 'pending';
+
+-- Org rate limit quotas (ref organizations, users)
+CREATE TABLE org_quotas (
+    org_id                    VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    plan                      VARCHAR NOT NULL DEFAULT '',
+    org_requests_per_minute   INTEGER,
+    token_requests_per_minute INTEGER,
+    updated_by                VARCHAR NOT NULL REFERENCES users(id),
+    updated_at                TIMESTAMP NOT NULL
+);
+
+-- PII encryption data keys
+CREATE TABLE data_keys (
+    id            VARCHAR PRIMARY KEY,
+    scope         VARCHAR NOT NULL,
+    wrapped_key   BLOB NOT NULL,
+    master_key_id VARCHAR NOT NULL,
+    created_at    TIMESTAMP NOT NULL,
+    retired_at    TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_data_keys_active_scope ON data_keys (scope) WHERE retired_at IS NULL;
+
+-- Groups (ref organizations, users)
+CREATE TABLE groups (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    name       VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (org_id, name)
+);
+
+CREATE TABLE group_members (
+    group_id   VARCHAR NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    role       VARCHAR NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+CREATE INDEX idx_group_members_user_id ON group_members (user_id);
+
+-- Privilege elevations (ref organizations, users)
+CREATE TABLE privilege_elevations (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    status         VARCHAR NOT NULL,
+    justification  TEXT NOT NULL,
+    duration_secs  INTEGER NOT NULL,
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMP,
+    expires_at     TIMESTAMP,
+    revoked_by     VARCHAR REFERENCES users(id),
+    revoked_at     TIMESTAMP,
+    created_at     TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_privilege_elevations_org_created ON privilege_elevations (org_id, created_at DESC);
+CREATE INDEX idx_privilege_elevations_user_org ON privilege_elevations (user_id, org_id) WHERE status IN ('pending', 'approved');
+CREATE INDEX idx_privilege_elevations_approved_expires ON privilege_elevations (expires_at) WHERE status = 'approved';
+
+-- Break-glass accounts (ref organizations, users, devices)
+CREATE TABLE break_glass_accounts (
+    org_id         VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    device_id      VARCHAR NOT NULL REFERENCES devices(id),
+    secret_hash    VARCHAR NOT NULL,
+    provisioned_by VARCHAR NOT NULL REFERENCES users(id),
+    provisioned_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE break_glass_activations (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    status         VARCHAR NOT NULL,
+    reason         TEXT NOT NULL,
+    requested_by   VARCHAR NOT NULL REFERENCES users(id),
+    requested_at   TIMESTAMP NOT NULL,
+    approved_by    VARCHAR REFERENCES users(id),
+    approved_at    TIMESTAMP,
+    expires_at     TIMESTAMP,
+    ended_by       VARCHAR REFERENCES users(id),
+    ended_at       TIMESTAMP,
+    report_summary TEXT NOT NULL DEFAULT '',
+    reported_by    VARCHAR REFERENCES users(id),
+    reported_at    TIMESTAMP
+);
+CREATE INDEX idx_break_glass_activations_org_requested ON break_glass_activations (org_id, requested_at DESC);
+CREATE INDEX idx_break_glass_activations_active_expires ON break_glass_activations (expires_at) WHERE status = 'active';
+
+-- Login holds (ref organizations, users, devices)
+CREATE TABLE login_holds (
+    id         VARCHAR PRIMARY KEY,
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    device_id  VARCHAR REFERENCES devices(id),
+    token_hash VARCHAR NOT NULL,
+    reasons    VARCHAR NOT NULL,
+    ip         VARCHAR NOT NULL DEFAULT '',
+    status     VARCHAR NOT NULL,
+    decided_by VARCHAR REFERENCES users(id),
+    decided_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_login_holds_org_pending ON login_holds (org_id, created_at DESC) WHERE status = 'pending';
+
+-- Honeytokens (ref users)
+CREATE TABLE honeytokens (
+    user_id           VARCHAR PRIMARY KEY REFERENCES users(id),
+    label             VARCHAR NOT NULL DEFAULT '',
+    created_by        VARCHAR NOT NULL REFERENCES users(id),
+    created_at        TIMESTAMP NOT NULL,
+    trigger_count     BIGINT NOT NULL DEFAULT 0,
+    last_triggered_at TIMESTAMP
+);
+
+-- Device authorization codes (ref users, organizations)
+CREATE TABLE device_codes (
+    id                 VARCHAR PRIMARY KEY,
+    device_code_hash   VARCHAR NOT NULL UNIQUE,
+    user_code          VARCHAR NOT NULL,
+    client_name        VARCHAR NOT NULL DEFAULT '',
+    device_fingerprint VARCHAR NOT NULL DEFAULT '',
+    ip                 VARCHAR NOT NULL DEFAULT '',
+    status             VARCHAR NOT NULL,
+    user_id            VARCHAR REFERENCES users(id),
+    org_id             VARCHAR REFERENCES organizations(id),
+    decided_at         TIMESTAMP,
+    created_at         TIMESTAMP NOT NULL,
+    expires_at         TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_device_codes_user_code_pending ON device_codes (user_code) WHERE status = 'pending';
+
+-- Magic links (ref users, organizations)
+CREATE TABLE magic_links (
+    id         VARCHAR PRIMARY KEY,
+    token_hash VARCHAR NOT NULL UNIQUE,
+    user_id    VARCHAR NOT NULL REFERENCES users(id),
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    ip         VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP
+);
+
+-- Org domains (ref organizations, users)
+CREATE TABLE org_domains (
+    id              VARCHAR PRIMARY KEY,
+    org_id          VARCHAR NOT NULL REFERENCES organizations(id),
+    domain          VARCHAR NOT NULL,
+    token           VARCHAR NOT NULL,
+    status          VARCHAR NOT NULL DEFAULT 'pending',
+    created_by      VARCHAR NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMP NOT NULL,
+    verified_at     TIMESTAMP,
+    last_checked_at TIMESTAMP,
+    UNIQUE (org_id, domain)
+);
+CREATE UNIQUE INDEX idx_org_domains_verified ON org_domains (domain) WHERE status = 'verified';
+
+-- Org SMTP settings and notification templates (ref organizations, users)
+CREATE TABLE org_smtp_settings (
+    org_id          VARCHAR PRIMARY KEY REFERENCES organizations(id),
+    host            VARCHAR NOT NULL,
+    port            INTEGER NOT NULL,
+    username        VARCHAR NOT NULL DEFAULT '',
+    password_secret VARCHAR NOT NULL DEFAULT '',
+    from_address    VARCHAR NOT NULL,
+    updated_by      VARCHAR NOT NULL REFERENCES users(id),
+    updated_at      TIMESTAMP NOT NULL
+);
+
+CREATE TABLE notification_templates (
+    org_id     VARCHAR NOT NULL REFERENCES organizations(id),
+    kind       VARCHAR NOT NULL,
+    locale     VARCHAR NOT NULL DEFAULT '',
+    subject    VARCHAR NOT NULL DEFAULT '',
+    body       VARCHAR NOT NULL,
+    updated_by VARCHAR NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, kind, locale)
+);
+
+-- Endpoint agents (ref organizations, users, devices)
+CREATE TABLE agents (
+    id                VARCHAR PRIMARY KEY,
+    org_id            VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id           VARCHAR NOT NULL REFERENCES users(id),
+    device_id         VARCHAR NOT NULL UNIQUE REFERENCES devices(id),
+    platform          VARCHAR NOT NULL,
+    version           VARCHAR NOT NULL,
+    posture_json      TEXT NOT NULL DEFAULT '{}',
+    policy_version    BIGINT NOT NULL DEFAULT 0,
+    registered_at     TIMESTAMP NOT NULL,
+    last_heartbeat_at TIMESTAMP NOT NULL,
+    trust_degraded_at TIMESTAMP
+);
+CREATE INDEX idx_agents_org_heartbeat ON agents (org_id, last_heartbeat_at, id);
+CREATE INDEX idx_agents_silent ON agents (last_heartbeat_at) WHERE trust_degraded_at IS NULL;
+
+-- Telemetry events
+CREATE TABLE telemetry_events (
+    org_id          VARCHAR NOT NULL,
+    event_id        VARCHAR NOT NULL,
+    schema_version  INTEGER NOT NULL,
+    type            VARCHAR NOT NULL,
+    occurred_at     TIMESTAMP NOT NULL,
+    received_at     TIMESTAMP NOT NULL,
+    user_id         VARCHAR NOT NULL,
+    session_id      VARCHAR NOT NULL,
+    device_id       VARCHAR NOT NULL DEFAULT '',
+    region          VARCHAR NOT NULL DEFAULT '',
+    attributes_json TEXT NOT NULL DEFAULT '{}',
+    request_id      VARCHAR NOT NULL DEFAULT '',
+    PRIMARY KEY (org_id, event_id)
+);
+CREATE INDEX idx_telemetry_events_org_received ON telemetry_events (org_id, received_at DESC, event_id);
+CREATE INDEX idx_telemetry_events_received ON telemetry_events (received_at);
+
+-- Policy presets
+CREATE TABLE policy_presets (
+    name         VARCHAR PRIMARY KEY,
+    display_name VARCHAR NOT NULL,
+    description  TEXT NOT NULL,
+    policy_rules TEXT NOT NULL DEFAULT '',
+    config_json  TEXT NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    updated_at   TIMESTAMP NOT NULL
+);
+
+INSERT INTO policy_presets (name, display_name, description, policy_rules, config_json, created_at, updated_at) VALUES
+('strict', 'Strict',
+ 'MFA on every sign-in and no automatic device trust. Short sessions, step-up for sensitive actions, enforced new sign-in alerts, breached passwords blocked, and MFA required while policies cannot be loaded.',
+ 'package ztcp.device_trust
+default mfa_required = true
+default register_trust_after_mfa = false
+default trust_ttl_days = 7
+trust_ttl_days = input.org.trust_ttl_days if {
+	input.org.trust_ttl_days > 0
+}
+',
+ '{"auth_mfa":{"mfa_requirement":"always","allowed_mfa_methods":["sms_otp"],"step_up_sensitive_actions":true,"step_up_policy_violation":true,"policy_failure_mode":"closed"},'
+ || '"device_trust":{"device_registration_allowed":true,"auto_trust_after_mfa":false,"max_trusted_devices_per_user":2,"reverify_interval_days":7,"admin_revoke_allowed":true,"keep_trust_on_factor_change":false,"trust_renewal":"fixed","expiry_notice_days":2},'
+ || '"session_mgmt":{"session_max_ttl":"8h","idle_timeout":"15m","concurrent_session_limit":3,"admin_forced_logout":true,"reauth_on_policy_change":true},'
+ || '"notifications":{"new_login_alerts":true,"enforce_new_login_alerts":true},'
+ || '"password_policy":{"breached_password_mode":"block"}}',
+ strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+('balanced', 'Balanced',
+ 'MFA on new devices, which are trusted for 30 days after MFA. Step-up for sensitive actions, day-long sessions, new sign-in alerts, and a warning for breached passwords. Uses the built-in device trust policy.',
+ '',
+ '{"auth_mfa":{"mfa_requirement":"new_device","allowed_mfa_methods":["sms_otp"],"step_up_sensitive_actions":true,"step_up_policy_violation":false},'
+ || '"device_trust":{"device_registration_allowed":true,"auto_trust_after_mfa":true,"max_trusted_devices_per_user":5,"reverify_interval_days":30,"admin_revoke_allowed":true,"keep_trust_on_factor_change":false,"trust_renewal":"fixed","expiry_notice_days":3},'
+ || '"session_mgmt":{"session_max_ttl":"24h","idle_timeout":"30m","concurrent_session_limit":0,"admin_forced_logout":true,"reauth_on_policy_change":false},'
+ || '"notifications":{"new_login_alerts":true,"enforce_new_login_alerts":false},'
+ || '"password_policy":{"breached_password_mode":"warn"}}',
+ strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+('byod_friendly', 'BYOD-friendly',
+ 'For personal devices: MFA only on untrusted devices, trust kept for 90 days and renewed by use, several devices per user, sign-in links by email, and week-long sessions. Uses the built-in device trust policy.',
+ '',
+ '{"auth_mfa":{"mfa_requirement":"untrusted","allowed_mfa_methods":["sms_otp"],"step_up_sensitive_actions":false,"step_up_policy_violation":false,"magic_link_enabled":true},'
+ || '"device_trust":{"device_registration_allowed":true,"auto_trust_after_mfa":true,"max_trusted_devices_per_user":10,"reverify_interval_days":90,"admin_revoke_allowed":true,"keep_trust_on_factor_change":true,"trust_renewal":"sliding","expiry_notice_days":7},'
+ || '"session_mgmt":{"session_max_ttl":"168h","idle_timeout":"8h","concurrent_session_limit":0,"admin_forced_logout":true,"reauth_on_policy_change":false},'
+ || '"notifications":{"new_login_alerts":true,"enforce_new_login_alerts":false}}',
+ strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'));
+
+-- Org invitations (ref organizations, users)
+CREATE TABLE org_invitations (
+    id          VARCHAR PRIMARY KEY,
+    org_id      VARCHAR NOT NULL REFERENCES organizations(id),
+    email       VARCHAR NOT NULL,
+    role        VARCHAR NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    token_hash  VARCHAR NOT NULL UNIQUE,
+    invited_by  VARCHAR NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMP NOT NULL,
+    expires_at  TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    accepted_by VARCHAR REFERENCES users(id),
+    revoked_at  TIMESTAMP
+);
+CREATE INDEX idx_org_invitations_org_created ON org_invitations (org_id, created_at DESC);
+
+-- Member attributes (ref organizations, memberships)
+CREATE TABLE org_attribute_definitions (
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    key            VARCHAR NOT NULL,
+    display_name   VARCHAR NOT NULL,
+    description    TEXT NOT NULL DEFAULT '',
+    type           VARCHAR NOT NULL,
+    allowed_values TEXT NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMP NOT NULL,
+    updated_at     TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, key)
+);
+
+CREATE TABLE membership_attributes (
+    membership_id VARCHAR NOT NULL REFERENCES memberships(id) ON DELETE CASCADE,
+    org_id        VARCHAR NOT NULL,
+    key           VARCHAR NOT NULL,
+    value         VARCHAR NOT NULL,
+    updated_at    TIMESTAMP NOT NULL,
+    PRIMARY KEY (membership_id, key),
+    FOREIGN KEY (org_id, key) REFERENCES org_attribute_definitions(org_id, key) ON DELETE CASCADE
+);
+CREATE INDEX idx_membership_attributes_search ON membership_attributes (org_id, key, value);
+
+-- Usage metering
+CREATE TABLE usage_monthly (
+    org_id         VARCHAR NOT NULL,
+    month          DATE NOT NULL,
+    active_users   BIGINT NOT NULL DEFAULT 0,
+    logins         BIGINT NOT NULL DEFAULT 0,
+    mfa_challenges BIGINT NOT NULL DEFAULT 0,
+    mfa_resends    BIGINT NOT NULL DEFAULT 0,
+    api_calls      BIGINT NOT NULL DEFAULT 0,
+    updated_at     TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, month)
+);
+CREATE INDEX idx_usage_monthly_month ON usage_monthly (month);
+
+CREATE TABLE usage_active_users (
+    org_id  VARCHAR NOT NULL,
+    month   DATE NOT NULL,
+    user_id VARCHAR NOT NULL,
+    PRIMARY KEY (org_id, month, user_id)
+);
+
+-- Data exports (user_id may name a user of another region's database, so no FK)
+CREATE TABLE data_exports (
+    id           VARCHAR PRIMARY KEY,
+    user_id      VARCHAR NOT NULL,
+    requested_by VARCHAR NOT NULL,
+    token_hash   VARCHAR NOT NULL UNIQUE,
+    status       VARCHAR NOT NULL DEFAULT 'pending',
+    archive      BLOB,
+    error        TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL,
+    claimed_at   TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at   TIMESTAMP NOT NULL,
+    org_id       VARCHAR NOT NULL DEFAULT ''
+);
+CREATE INDEX idx_data_exports_user ON data_exports (user_id, status);
+CREATE INDEX idx_data_exports_status_created ON data_exports (status, created_at);
+
+-- Email changes (ref users, organizations)
+CREATE TABLE email_changes (
+    id               VARCHAR PRIMARY KEY,
+    user_id          VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    org_id           VARCHAR NOT NULL REFERENCES organizations(id),
+    old_email        VARCHAR NOT NULL,
+    new_email        VARCHAR NOT NULL,
+    old_token_hash   VARCHAR NOT NULL UNIQUE,
+    new_token_hash   VARCHAR NOT NULL UNIQUE,
+    ip               VARCHAR NOT NULL DEFAULT '',
+    created_at       TIMESTAMP NOT NULL,
+    expires_at       TIMESTAMP NOT NULL,
+    old_confirmed_at TIMESTAMP,
+    new_confirmed_at TIMESTAMP,
+    completed_at     TIMESTAMP
+);
+CREATE INDEX idx_email_changes_user ON email_changes (user_id);
+
+-- Usernames (ref users)
+CREATE TABLE usernames (
+    user_id    VARCHAR NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    org_id     VARCHAR NOT NULL DEFAULT '',
+    username   VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, org_id),
+    UNIQUE (org_id, username)
+);
+
+-- URL exceptions (ref organizations, users)
+CREATE TABLE url_exceptions (
+    id             VARCHAR PRIMARY KEY,
+    org_id         VARCHAR NOT NULL REFERENCES organizations(id),
+    user_id        VARCHAR NOT NULL REFERENCES users(id),
+    domain         VARCHAR NOT NULL,
+    url            TEXT NOT NULL,
+    status         VARCHAR NOT NULL,
+    justification  TEXT NOT NULL,
+    duration_secs  INTEGER NOT NULL,
+    reviewed_by    VARCHAR REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMP,
+    expires_at     TIMESTAMP,
+    revoked_by     VARCHAR REFERENCES users(id),
+    revoked_at     TIMESTAMP,
+    created_at     TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_url_exceptions_org_created ON url_exceptions (org_id, created_at DESC);
+CREATE INDEX idx_url_exceptions_user_org_domain ON url_exceptions (user_id, org_id, domain) WHERE status IN ('pending', 'approved');
+
+-- Audit webhooks (ref organizations, users)
+CREATE TABLE audit_webhooks (
+    id          VARCHAR PRIMARY KEY,
+    org_id      VARCHAR NOT NULL REFERENCES organizations(id),
+    url         TEXT NOT NULL,
+    secret      VARCHAR NOT NULL,
+    filter      TEXT NOT NULL DEFAULT '',
+    description VARCHAR NOT NULL DEFAULT '',
+    enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by  VARCHAR NOT NULL REFERENCES users(id),
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_audit_webhooks_org ON audit_webhooks (org_id, created_at);
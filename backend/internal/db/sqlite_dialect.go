@@ -0,0 +1,267 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// sqliteQueries caches the SQLite translation of each query text run through a SQLite connection.
+var sqliteQueries sync.Map
+
+// sqliteRewrites turn the Postgres constructs the sqlc queries use into SQLite, in order. Queries they cannot
+// translate have a hand-written version in sqliteOverrides.
+var sqliteRewrites = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// SQLite transactions take the write lock when they begin (_txlock=immediate), so rows need no lock.
+	{regexp.MustCompile(`(?i)\s*FOR UPDATE( SKIP LOCKED)?`), ""},
+	// Array parameters arrive as Postgres array literals; pg_array_json makes them JSON for json_each.
+	{regexp.MustCompile(`(?i)cardinality\((\$\d+)::\w+\[\]\)`), "json_array_length(pg_array_json($1))"},
+	{regexp.MustCompile(`(?i)= ANY\((\$\d+)::\w+\[\]\)`), "IN (SELECT value FROM json_each(pg_array_json($1)))"},
+	// Dates are stored as YYYY-MM-DD.
+	{regexp.MustCompile(`(\$\d+)::date\b`), "date($1)"},
+	{regexp.MustCompile(`(?i)::(bigint|integer|int|text|varchar|boolean|timestamptz|jsonb|json)\b`), ""},
+	{regexp.MustCompile(`(?i)\bgreatest\(`), "max("},
+	{regexp.MustCompile(`(?i)\bleast\(`), "min("},
+	{regexp.MustCompile(`\$(\d+)`), "?$1"},
+}
+
+// sqliteQueryName matches the name comment sqlc puts on the first line of every query.
+var sqliteQueryName = regexp.MustCompile(`^-- name: (\w+)`)
+
+// sqliteQuery returns query translated from Postgres to SQLite: the hand-written version of a named sqlc query in
+// sqliteOverrides, or else query with sqliteRewrites applied.
+func sqliteQuery(query string) string {
+	if translated, ok := sqliteQueries.Load(query); ok {
+		return translated.(string)
+	}
+	translated := translateSQLiteQuery(query)
+	sqliteQueries.Store(query, translated)
+	return translated
+}
+
+func translateSQLiteQuery(query string) string {
+	if m := sqliteQueryName.FindStringSubmatch(query); m != nil {
+		if override, ok := sqliteOverrides[m[1]]; ok {
+			return override
+		}
+	}
+	for _, r := range sqliteRewrites {
+		query = r.re.ReplaceAllString(query, r.repl)
+	}
+	return strings.TrimSpace(query)
+}
+
+// sqliteOverrides are the SQLite versions of the sqlc queries (by name) that sqliteRewrites cannot translate.
+var sqliteOverrides = newSQLiteOverrides()
+
+func newSQLiteOverrides() map[string]string {
+	overrides := map[string]string{
+		// Batch inserts: the nth element of every array parameter makes the nth row.
+		"CreateAuditLogs": `WITH a AS (
+    SELECT pg_array_json(?2) AS org_ids, pg_array_json(?3) AS user_ids, pg_array_json(?4) AS actions,
+           pg_array_json(?5) AS resources, pg_array_json(?6) AS ips, pg_array_json(?7) AS metadata,
+           pg_array_json(?8) AS created_ats, pg_array_json(?9) AS request_ids
+)
+INSERT INTO audit_logs (id, org_id, user_id, action, resource, ip, metadata, created_at, request_id)
+SELECT t.value, ` + sqliteElement("a.org_ids") + `, NULLIF(` + sqliteElement("a.user_ids") + `, ''),
+       ` + sqliteElement("a.actions") + `, ` + sqliteElement("a.resources") + `, ` + sqliteElement("a.ips") + `,
+       NULLIF(` + sqliteElement("a.metadata") + `, ''), ` + sqliteElement("a.created_ats") + `,
+       NULLIF(` + sqliteElement("a.request_ids") + `, '')
+FROM a, json_each(pg_array_json(?1)) AS t`,
+		"CreateTelemetryEvents": `WITH a AS (
+    SELECT pg_array_json(?2) AS event_ids, pg_array_json(?3) AS schema_versions, pg_array_json(?4) AS types,
+           pg_array_json(?5) AS occurred_ats, pg_array_json(?6) AS received_ats, pg_array_json(?7) AS user_ids,
+           pg_array_json(?8) AS session_ids, pg_array_json(?9) AS device_ids, pg_array_json(?10) AS regions,
+           pg_array_json(?11) AS attributes_jsons, pg_array_json(?12) AS request_ids
+)
+INSERT INTO telemetry_events (org_id, event_id, schema_version, type, occurred_at, received_at, user_id, session_id, device_id, region, attributes_json, request_id)
+SELECT t.value, ` + sqliteElement("a.event_ids") + `, ` + sqliteElement("a.schema_versions") + `, ` + sqliteElement("a.types") + `,
+       ` + sqliteElement("a.occurred_ats") + `, ` + sqliteElement("a.received_ats") + `, ` + sqliteElement("a.user_ids") + `,
+       ` + sqliteElement("a.session_ids") + `, ` + sqliteElement("a.device_ids") + `, ` + sqliteElement("a.regions") + `,
+       ` + sqliteElement("a.attributes_jsons") + `, ` + sqliteElement("a.request_ids") + `
+FROM a, json_each(pg_array_json(?1)) AS t
+WHERE true
+ON CONFLICT (org_id, event_id) DO NOTHING`,
+		"AddActiveUsers": `INSERT INTO usage_active_users (org_id, month, user_id)
+SELECT ?1, date(?2), u.value FROM json_each(pg_array_json(?3)) AS u
+WHERE true
+ON CONFLICT DO NOTHING`,
+		"SearchMembershipsByAttributes": `SELECT m.id, m.user_id, m.org_id, m.role, m.created_at
+FROM memberships m
+WHERE m.org_id = ?1
+  AND (
+    SELECT count(*)
+    FROM membership_attributes a
+    JOIN json_each(pg_array_json(?2)) AS f
+      ON a.key = f.value AND a.value = json_extract(pg_array_json(?3), '$[' || f.key || ']')
+    WHERE a.membership_id = m.id
+  ) = json_array_length(pg_array_json(?2))
+ORDER BY m.created_at, m.id
+LIMIT ?4 OFFSET ?5`,
+
+		// Postgres-only statements: SQLite has no advisory locks (transactions already take the write lock), no
+		// NOTIFY (a SQLite database has one server, which invalidates its own cache) and no partitions, so
+		// retention deletes the old rows and reports no dropped partition.
+		"LockLicenseSeats":             `SELECT 1`,
+		"NotifySettingsChanged":        `SELECT ?1, ?2 WHERE false`,
+		"CreateAuditLogPartition":      `SELECT false AS created WHERE ?1 IS NOT NULL`,
+		"DropAuditLogPartitionsBefore": `DELETE FROM audit_logs WHERE created_at < ?1; SELECT '' AS name WHERE false`,
+
+		// No DELETE ... USING; enums compare in declaration order in Postgres.
+		"DeleteStaleOrgUsername": `DELETE FROM usernames AS u
+WHERE u.org_id = ?1 AND u.username = ?2 AND u.org_id <> ''
+  AND NOT EXISTS (SELECT 1 FROM memberships m WHERE m.user_id = u.user_id AND m.org_id = u.org_id)`,
+		"DeleteMergedMemberships": `DELETE FROM memberships AS d
+WHERE d.user_id = ?1 AND EXISTS (SELECT 1 FROM memberships p WHERE p.user_id = ?2 AND p.org_id = d.org_id)`,
+		"DeleteMergedGroupMembers": `DELETE FROM group_members AS d
+WHERE d.user_id = ?1 AND EXISTS (SELECT 1 FROM group_members p WHERE p.user_id = ?2 AND p.group_id = d.group_id)`,
+		"MergeMembershipRoles": `UPDATE memberships AS p
+SET role = d.role
+FROM memberships AS d
+WHERE p.user_id = ?1 AND d.user_id = ?2 AND d.org_id = p.org_id
+  AND ` + sqliteRoleRank("d.role") + ` < ` + sqliteRoleRank("p.role"),
+
+		// Rows as JSON.
+		"ListDataExportDevices": `SELECT ` + sqliteRowJSON("devices", "d") + ` AS data FROM devices d WHERE d.user_id = ?1 ORDER BY d.created_at, d.id`,
+		"ListDataExportGroupMemberships": `SELECT json_object('group_id', g.id, 'org_id', g.org_id, 'group_name', g.name, 'role', gm.role, 'created_at', gm.created_at) AS data
+FROM group_members gm
+JOIN groups g ON g.id = gm.group_id
+WHERE gm.user_id = ?1
+ORDER BY gm.created_at, g.id`,
+		"ListDataExportIdentities": `SELECT ` + sqliteRowJSON("identities", "i", "password_hash") + ` AS data FROM identities i WHERE i.user_id = ?1 ORDER BY i.created_at, i.id`,
+		"ListDataExportMembershipAttributes": `SELECT ` + sqliteRowJSON("membership_attributes", "a") + ` AS data
+FROM membership_attributes a
+JOIN memberships m ON m.id = a.membership_id
+WHERE m.user_id = ?1
+ORDER BY a.org_id, a.key`,
+		"ListDataExportMemberships": `SELECT ` + sqliteRowJSON("memberships", "m") + ` AS data FROM memberships m WHERE m.user_id = ?1 ORDER BY m.created_at, m.id`,
+		"ListDataExportSessions": `SELECT ` + sqliteRowJSON("sessions", "s", "refresh_jti", "refresh_token_hash", "pop_jkt") + ` AS data
+FROM sessions s
+WHERE s.user_id = ?1
+ORDER BY s.created_at, s.id`,
+		"ListDataExportUsernames": `SELECT ` + sqliteRowJSON("usernames", "u") + ` AS data FROM usernames u WHERE u.user_id = ?1 ORDER BY u.org_id`,
+	}
+
+	// Backups: DumpOrgBackup writes every row as a JSON object, RestoreBackup<Table> inserts a JSON array of them.
+	dump := `WITH org_users AS (
+    SELECT user_id AS id FROM memberships WHERE org_id = ?1
+    UNION SELECT user_id FROM devices WHERE org_id = ?1
+    UNION SELECT gm.user_id FROM group_members gm JOIN groups g ON g.id = gm.group_id WHERE g.org_id = ?1
+    UNION SELECT created_by FROM org_domains WHERE org_id = ?1
+    UNION SELECT updated_by FROM org_smtp_settings WHERE org_id = ?1
+    UNION SELECT updated_by FROM notification_templates WHERE org_id = ?1
+)
+SELECT table_name, data FROM (`
+	for i, table := range sqliteBackupTables {
+		if i > 0 {
+			dump += "\n    UNION ALL"
+		}
+		dump += fmt.Sprintf("\n    SELECT '%s' AS table_name, %s AS data FROM %s t WHERE %s", table.name, sqliteRowJSON(table.name, "t"), table.name, table.where)
+		conflict := ""
+		if table.skipExisting {
+			conflict = "\nON CONFLICT DO NOTHING"
+		}
+		overrides["RestoreBackup"+table.query] = sqliteRestore(table.name) + conflict
+	}
+	overrides["DumpOrgBackup"] = dump + "\n) backup\nORDER BY table_name, data"
+	return overrides
+}
+
+// sqliteBackupTables are the tables of an org backup: the filter DumpOrgBackup selects the org's rows with (t is the
+// table, ?1 the org), and whether restoring skips rows that exist (users shared with other orgs).
+var sqliteBackupTables = []struct {
+	name, query, where string
+	skipExisting       bool
+}{
+	{"organizations", "Organizations", "t.id = ?1", false},
+	{"users", "Users", "t.id IN (SELECT id FROM org_users)", true},
+	{"identities", "Identities", "t.user_id IN (SELECT id FROM org_users)", true},
+	{"memberships", "Memberships", "t.org_id = ?1", false},
+	{"groups", "Groups", "t.org_id = ?1", false},
+	{"group_members", "GroupMembers", "t.group_id IN (SELECT id FROM groups WHERE org_id = ?1)", false},
+	{"org_attribute_definitions", "OrgAttributeDefinitions", "t.org_id = ?1", false},
+	{"membership_attributes", "MembershipAttributes", "t.org_id = ?1", false},
+	{"devices", "Devices", "t.org_id = ?1", false},
+	{"policies", "Policies", "t.org_id = ?1", false},
+	{"org_policy_config", "OrgPolicyConfig", "t.org_id = ?1", false},
+	{"org_policy_config_versions", "OrgPolicyConfigVersions", "t.org_id = ?1", false},
+	{"org_mfa_settings", "OrgMfaSettings", "t.org_id = ?1", false},
+	{"org_domains", "OrgDomains", "t.org_id = ?1", false},
+	{"org_smtp_settings", "OrgSmtpSettings", "t.org_id = ?1", false},
+	{"notification_templates", "NotificationTemplates", "t.org_id = ?1", false},
+}
+
+// sqliteElement is the element of the JSON array column at the position of the json_each row t.
+func sqliteElement(array string) string {
+	return "json_extract(" + array + ", '$[' || t.key || ']')"
+}
+
+// sqliteRoleRank orders roles as the Postgres role enum does: owner, admin, member.
+func sqliteRoleRank(role string) string {
+	return "CASE " + role + " WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END"
+}
+
+// sqliteRowJSON is the SQLite version of row_to_json(alias) for a row of table, without the omitted columns.
+// Booleans are written as JSON booleans, like Postgres does.
+func sqliteRowJSON(table, alias string, omit ...string) string {
+	var fields []string
+	for _, c := range sqliteColumns(table) {
+		if slices.Contains(omit, c.name) {
+			continue
+		}
+		value := alias + "." + c.name
+		if c.typ == "BOOLEAN" {
+			value = "json(CASE " + value + " WHEN 1 THEN 'true' WHEN 0 THEN 'false' END)"
+		}
+		fields = append(fields, "'"+c.name+"', "+value)
+	}
+	return "json_object(" + strings.Join(fields, ", ") + ")"
+}
+
+// sqliteRestore is the SQLite version of inserting json_populate_recordset(NULL::table, ?1): one row of table per
+// object of the JSON array ?1, with the columns missing from an object NULL.
+func sqliteRestore(table string) string {
+	var names, values []string
+	for _, c := range sqliteColumns(table) {
+		names = append(names, c.name)
+		values = append(values, "json_extract(r.value, '$."+c.name+"')")
+	}
+	return "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ")\nSELECT " + strings.Join(values, ", ") +
+		"\nFROM json_each(?1) AS r\nWHERE true"
+}
+
+type sqliteColumn struct {
+	name, typ string
+}
+
+// sqliteCreateTable matches the CREATE TABLE statements of SQLiteSchema, one column or constraint per line.
+var sqliteCreateTable = regexp.MustCompile(`(?s)CREATE TABLE (\w+) \((.*?)\n\);`)
+
+// sqliteColumns returns the columns of table in SQLiteSchema, in order. It panics if there is no such table, which
+// the tests catch.
+func sqliteColumns(table string) []sqliteColumn {
+	for _, m := range sqliteCreateTable.FindAllStringSubmatch(SQLiteSchema, -1) {
+		if m[1] != table {
+			continue
+		}
+		var columns []sqliteColumn
+		for _, line := range strings.Split(m[2], "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			switch fields[0] {
+			case "PRIMARY", "UNIQUE", "FOREIGN", "CHECK":
+				continue
+			}
+			columns = append(columns, sqliteColumn{name: fields[0], typ: strings.TrimSuffix(fields[1], ",")})
+		}
+		return columns
+	}
+	panic("db: no table " + table + " in the SQLite schema")
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrQueryTimeout is matched (errors.Is) by errors of statements that ran out of Options.QueryTimeout or were
+// cancelled by the server's statement_timeout. They also match context.DeadlineExceeded.
+var ErrQueryTimeout = errors.New("db: query timed out")
+
+// pgQueryCanceled is the SQLSTATE of a statement cancelled by statement_timeout (or a cancel request).
+const pgQueryCanceled = "57014"
+
+type timeoutTrackerKey struct{}
+
+// TrackTimeouts returns a context that records query timeouts of the statements run with it, and a function that
+// reports whether one happened. Used by the gRPC layer to tell a slow database from other internal errors, since
+// handlers do not pass repository errors through.
+func TrackTimeouts(ctx context.Context) (context.Context, func() bool) {
+	var timedOut atomic.Bool
+	return context.WithValue(ctx, timeoutTrackerKey{}, &timedOut), timedOut.Load
+}
+
+// RecordTimeout marks a query timeout in the tracker of ctx, if it has one (see TrackTimeouts). The wrapped
+// connections call it; code running queries some other way can too.
+func RecordTimeout(ctx context.Context) {
+	if timedOut, ok := ctx.Value(timeoutTrackerKey{}).(*atomic.Bool); ok {
+		timedOut.Store(true)
+	}
+}
+
+// IsTimeout reports whether err is a query timeout: ErrQueryTimeout, or a statement cancelled by the server.
+func IsTimeout(err error) bool {
+	if errors.Is(err, ErrQueryTimeout) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgQueryCanceled
+}
+
+// timeoutConnector gives every connection's statements a deadline.
+type timeoutConnector struct {
+	driver.Connector
+	timeout time.Duration
+}
+
+// conn is what the pgx stdlib connection implements and timeoutConn forwards.
+type conn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.NamedValueChecker
+	driver.SessionResetter
+}
+
+func (c *timeoutConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inner, ok := dc.(conn)
+	if !ok {
+		return dc, nil
+	}
+	return &timeoutConn{conn: inner, timeout: c.timeout}, nil
+}
+
+// timeoutConn runs Exec and Query with a deadline. Transactions keep the caller's context, which database/sql
+// watches for the transaction's whole life; their statements get the deadline through Exec and Query. Prepared
+// statements (unused by the sqlc queries) run without it.
+type timeoutConn struct {
+	conn
+	timeout time.Duration
+}
+
+func (c *timeoutConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	qctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	res, err := c.conn.ExecContext(qctx, query, args)
+	return res, timeoutError(ctx, qctx, err)
+}
+
+func (c *timeoutConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	qctx, cancel := context.WithTimeout(ctx, c.timeout)
+	rows, err := c.conn.QueryContext(qctx, query, args)
+	if err != nil {
+		cancel()
+		return nil, timeoutError(ctx, qctx, err)
+	}
+	// Rows are read after QueryContext returns, so the deadline is released when they are closed.
+	return &timeoutRows{Rows: rows, ctx: ctx, qctx: qctx, cancel: cancel}, nil
+}
+
+// timeoutRows releases the query's deadline on Close.
+type timeoutRows struct {
+	driver.Rows
+	ctx, qctx context.Context
+	cancel    context.CancelFunc
+}
+
+func (r *timeoutRows) Next(dest []driver.Value) error {
+	return timeoutError(r.ctx, r.qctx, r.Rows.Next(dest))
+}
+
+func (r *timeoutRows) Close() error {
+	defer r.cancel()
+	return r.Rows.Close()
+}
+
+// timeoutError wraps err with ErrQueryTimeout when the statement ran out of its deadline (qctx) or was cancelled by
+// the server, and records that in the tracker of ctx. Errors caused by the caller's own context are returned as is.
+func timeoutError(ctx, qctx context.Context, err error) error {
+	if err == nil || err == io.EOF || errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+	var pgErr *pgconn.PgError
+	serverCancelled := errors.As(err, &pgErr) && pgErr.Code == pgQueryCanceled
+	if !serverCancelled && (qctx.Err() == nil || ctx.Err() != nil) {
+		return err
+	}
+	RecordTimeout(ctx)
+	if serverCancelled {
+		return fmt.Errorf("%w: %w", ErrQueryTimeout, err)
+	}
+	return fmt.Errorf("%w: %w (%w)", ErrQueryTimeout, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeConn implements conn. Statements wait for their context unless err or a row is set.
+type fakeConn struct {
+	err      error // returned by Exec and Query instead of waiting
+	rows     int   // Query returns this many rows immediately
+	queryCtx context.Context
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeConn) PrepareContext(context.Context, string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeConn) Ping(context.Context) error               { return nil }
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+func (c *fakeConn) ResetSession(ctx context.Context) error   { return nil }
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.queryCtx = ctx
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.rows == 0 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &fakeRows{n: c.rows}, nil
+}
+
+type fakeRows struct{ n int }
+
+func (r *fakeRows) Columns() []string { return []string{"n"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.n == 0 {
+		return io.EOF
+	}
+	dest[0] = int64(r.n)
+	r.n--
+	return nil
+}
+
+type fakeConnector struct{ conn driver.Conn }
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return nil }
+
+func openFake(t *testing.T, c *fakeConn, timeout time.Duration) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(&timeoutConnector{Connector: &fakeConnector{conn: c}, timeout: timeout})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestTimeoutConn_ExecRunsOutOfTimeout(t *testing.T) {
+	db := openFake(t, &fakeConn{}, 20*time.Millisecond)
+	ctx, timedOut := TrackTimeouts(context.Background())
+	_, err := db.ExecContext(ctx, "SELECT pg_sleep(10)")
+	if !errors.Is(err, ErrQueryTimeout) || !errors.Is(err, context.DeadlineExceeded) || !IsTimeout(err) {
+		t.Fatalf("err = %v, want ErrQueryTimeout and DeadlineExceeded", err)
+	}
+	if !timedOut() {
+		t.Error("tracker did not record the timeout")
+	}
+}
+
+func TestTimeoutConn_CallerCancelIsNotATimeout(t *testing.T) {
+	db := openFake(t, &fakeConn{}, time.Hour)
+	ctx, timedOut := TrackTimeouts(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err := db.ExecContext(ctx, "SELECT pg_sleep(10)")
+	if err == nil || errors.Is(err, ErrQueryTimeout) {
+		t.Fatalf("err = %v, want the caller's deadline without ErrQueryTimeout", err)
+	}
+	if timedOut() {
+		t.Error("tracker recorded the caller's own deadline")
+	}
+}
+
+func TestTimeoutConn_ServerStatementTimeout(t *testing.T) {
+	db := openFake(t, &fakeConn{err: &pgconn.PgError{Code: pgQueryCanceled, Message: "canceling statement due to statement timeout"}}, time.Hour)
+	ctx, timedOut := TrackTimeouts(context.Background())
+	_, err := db.ExecContext(ctx, "SELECT 1")
+	if !errors.Is(err, ErrQueryTimeout) || !timedOut() {
+		t.Fatalf("err = %v (tracked %t), want ErrQueryTimeout", err, timedOut())
+	}
+}
+
+func TestTimeoutConn_OtherErrorsUnchanged(t *testing.T) {
+	db := openFake(t, &fakeConn{err: &pgconn.PgError{Code: "23505"}}, time.Hour)
+	_, err := db.ExecContext(context.Background(), "INSERT")
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || errors.Is(err, ErrQueryTimeout) || IsTimeout(err) {
+		t.Fatalf("err = %v, want the unique violation as is", err)
+	}
+}
+
+func TestTimeoutConn_QueryDeadlineReleasedOnClose(t *testing.T) {
+	c := &fakeConn{rows: 2}
+	db := openFake(t, c, time.Hour)
+	rows, err := db.QueryContext(context.Background(), "SELECT n")
+	if err != nil {
+		t.Fatalf("QueryContext: %v", err)
+	}
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	if err := rows.Err(); err != nil || n != 2 {
+		t.Fatalf("read %d rows, err %v", n, err)
+	}
+	if _, ok := c.queryCtx.Deadline(); !ok {
+		t.Error("query ran without a deadline")
+	}
+	rows.Close()
+	if c.queryCtx.Err() == nil {
+		t.Error("query deadline not released on Close")
+	}
+}
+
+func TestTimeoutConn_QueryRunsOutOfTimeout(t *testing.T) {
+	db := openFake(t, &fakeConn{}, 20*time.Millisecond)
+	err := db.QueryRowContext(context.Background(), "SELECT pg_sleep(10)").Scan(new(int))
+	if !errors.Is(err, ErrQueryTimeout) {
+		t.Fatalf("err = %v, want ErrQueryTimeout", err)
+	}
+}
@@ -0,0 +1,160 @@
+package detector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+type fakeSource struct {
+	byIP      []IPFailureStats
+	accounts  map[string][]Account
+	byAccount []AccountFailureStats
+}
+
+func (f *fakeSource) FailuresByIP(ctx context.Context, since time.Time, minFailures, minAccounts int64) ([]IPFailureStats, error) {
+	return f.byIP, nil
+}
+
+func (f *fakeSource) AccountsFailedFromIP(ctx context.Context, ip string, since time.Time) ([]Account, error) {
+	return f.accounts[ip], nil
+}
+
+func (f *fakeSource) FailuresByAccount(ctx context.Context, since time.Time, minIPs int64) ([]AccountFailureStats, error) {
+	return f.byAccount, nil
+}
+
+type memEventStore struct {
+	events []*domain.SecurityEvent
+}
+
+func (m *memEventStore) Create(ctx context.Context, e *domain.SecurityEvent) error {
+	m.events = append(m.events, e)
+	return nil
+}
+
+func (m *memEventStore) CountSince(ctx context.Context, userID string, eventType domain.EventType, since time.Time) (int64, error) {
+	var n int64
+	for _, e := range m.events {
+		if e.UserID == userID && e.Type == eventType && !e.CreatedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type memBlocker struct {
+	blocked map[string]time.Time
+}
+
+func (m *memBlocker) Block(ctx context.Context, ip, reason string, until time.Time) error {
+	m.blocked[ip] = until
+	return nil
+}
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDetector(src Source, events EventStore, blocker Blocker, rules Rules) *Detector {
+	d := New(src, events, blocker, rules)
+	d.now = func() time.Time { return testNow }
+	return d
+}
+
+func TestRunOnce_CredentialStuffing(t *testing.T) {
+	src := &fakeSource{
+		byIP:     []IPFailureStats{{IP: "198.51.100.9", Failures: 40, Accounts: 2}},
+		accounts: map[string][]Account{"198.51.100.9": {{UserID: "u1", OrgID: "org-1"}, {UserID: "u2", OrgID: "org-2"}}},
+	}
+	events := &memEventStore{}
+	d := newTestDetector(src, events, nil, DefaultRules())
+
+	res, err := d.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if res.FlaggedIPs != 1 || res.EventsCreated != 2 || res.IPsBlocked != 0 {
+		t.Errorf("result = %+v, want 1 flagged IP, 2 events, no blocks", res)
+	}
+	for _, e := range events.events {
+		if e.Type != domain.EventCredentialStuffing || e.Severity != domain.SeverityHigh || e.IP != "198.51.100.9" {
+			t.Errorf("event = %+v", e)
+		}
+	}
+	if events.events[1].OrgID != "org-2" {
+		t.Errorf("org = %q, want org-2", events.events[1].OrgID)
+	}
+}
+
+func TestRunOnce_DistributedBruteForce(t *testing.T) {
+	src := &fakeSource{byAccount: []AccountFailureStats{{UserID: "u1", OrgID: "org-1", Failures: 12, IPs: 6}}}
+	events := &memEventStore{}
+	d := newTestDetector(src, events, nil, DefaultRules())
+
+	res, err := d.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if res.FlaggedAccounts != 1 || len(events.events) != 1 {
+		t.Fatalf("result = %+v, events = %d", res, len(events.events))
+	}
+	e := events.events[0]
+	if e.Type != domain.EventDistributedBruteForce || e.UserID != "u1" {
+		t.Errorf("event = %+v", e)
+	}
+	if e.Metadata != `{"failures":12,"ips":6,"window_minutes":15}` {
+		t.Errorf("metadata = %s", e.Metadata)
+	}
+}
+
+func TestRunOnce_SuppressesDuplicatesWithinWindow(t *testing.T) {
+	src := &fakeSource{byAccount: []AccountFailureStats{{UserID: "u1", Failures: 12, IPs: 6}}}
+	events := &memEventStore{}
+	d := newTestDetector(src, events, nil, DefaultRules())
+
+	for i := 0; i < 3; i++ {
+		if _, err := d.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce: %v", err)
+		}
+	}
+	if len(events.events) != 1 {
+		t.Errorf("events = %d, want 1 (duplicates suppressed)", len(events.events))
+	}
+
+	d.now = func() time.Time { return testNow.Add(DefaultRules().Window + time.Minute) }
+	if _, err := d.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(events.events) != 2 {
+		t.Errorf("events = %d, want 2 after the window passed", len(events.events))
+	}
+}
+
+func TestRunOnce_AutoBlock(t *testing.T) {
+	src := &fakeSource{byIP: []IPFailureStats{{IP: "198.51.100.9", Failures: 40, Accounts: 0}}}
+	blocker := &memBlocker{blocked: make(map[string]time.Time)}
+	rules := DefaultRules()
+
+	d := newTestDetector(src, &memEventStore{}, blocker, rules)
+	if _, err := d.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(blocker.blocked) != 0 {
+		t.Fatal("IP should not be blocked when AutoBlock is off")
+	}
+
+	rules.AutoBlock = true
+	d = newTestDetector(src, &memEventStore{}, blocker, rules)
+	res, err := d.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	until, ok := blocker.blocked["198.51.100.9"]
+	if !ok || res.IPsBlocked != 1 {
+		t.Fatalf("IP should be blocked, result = %+v", res)
+	}
+	if want := testNow.Add(rules.BlockDuration); !until.Equal(want) {
+		t.Errorf("blocked until %v, want %v", until, want)
+	}
+}
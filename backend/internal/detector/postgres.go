@@ -0,0 +1,196 @@
+package detector
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/residency"
+)
+
+// auditQueries is the part of *gen.Queries PostgresSource reads from one database.
+type auditQueries interface {
+	ListLoginFailureStatsByIP(ctx context.Context, arg gen.ListLoginFailureStatsByIPParams) ([]gen.ListLoginFailureStatsByIPRow, error)
+	ListLoginFailureAccountsByIP(ctx context.Context, arg gen.ListLoginFailureAccountsByIPParams) ([]gen.ListLoginFailureAccountsByIPRow, error)
+	ListLoginFailureStatsByUser(ctx context.Context, arg gen.ListLoginFailureStatsByUserParams) ([]gen.ListLoginFailureStatsByUserRow, error)
+	ListLoginFailureIPsByUser(ctx context.Context, arg gen.ListLoginFailureIPsByUserParams) ([]string, error)
+}
+
+// PostgresSource implements Source over the audit_logs table. With data residency configured, orgs pinned to a
+// region keep their audit log in that region's database, so every database is read and the aggregates are combined:
+// failures are summed, and distinct accounts and IPs are recounted across databases before the thresholds apply.
+type PostgresSource struct {
+	queries []auditQueries
+}
+
+// NewPostgresSource returns a Source that reads login failures (login_failure and credentials_verify_failure audit
+// events) from the given db and, when router is non-nil, from every regional database it holds.
+func NewPostgresSource(db *sql.DB, router *residency.Router) *PostgresSource {
+	if router == nil {
+		return &PostgresSource{queries: []auditQueries{gen.New(db)}}
+	}
+	dbs := router.Databases()
+	regions := make([]string, 0, len(dbs))
+	for region := range dbs {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	s := &PostgresSource{}
+	for _, region := range regions {
+		s.queries = append(s.queries, gen.New(dbs[region]))
+	}
+	return s
+}
+
+// FailuresByIP returns IPs over either failure threshold since the given time.
+func (s *PostgresSource) FailuresByIP(ctx context.Context, since time.Time, minFailures, minAccounts int64) ([]IPFailureStats, error) {
+	if len(s.queries) == 1 {
+		return failuresByIP(ctx, s.queries[0], since, minFailures, minAccounts)
+	}
+	// An IP under the thresholds in each database can be over them in total, so collect every failing IP.
+	var order []string
+	totals := make(map[string]*IPFailureStats)
+	seenIn := make(map[string]int)
+	for _, q := range s.queries {
+		rows, err := failuresByIP(ctx, q, since, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			t, ok := totals[r.IP]
+			if !ok {
+				t = &IPFailureStats{IP: r.IP}
+				totals[r.IP] = t
+				order = append(order, r.IP)
+			}
+			t.Failures += r.Failures
+			t.Accounts += r.Accounts
+			seenIn[r.IP]++
+		}
+	}
+	var out []IPFailureStats
+	for _, ip := range order {
+		t := totals[ip]
+		if seenIn[ip] > 1 {
+			// The same account can have failed from this IP in several databases; count it once.
+			accounts, err := s.AccountsFailedFromIP(ctx, ip, since)
+			if err != nil {
+				return nil, err
+			}
+			t.Accounts = int64(len(accounts))
+		}
+		if t.Failures >= minFailures || t.Accounts >= minAccounts {
+			out = append(out, *t)
+		}
+	}
+	return out, nil
+}
+
+// AccountsFailedFromIP returns the known accounts that failed sign-in from ip since the given time.
+func (s *PostgresSource) AccountsFailedFromIP(ctx context.Context, ip string, since time.Time) ([]Account, error) {
+	var out []Account
+	seen := make(map[string]bool)
+	for _, q := range s.queries {
+		rows, err := q.ListLoginFailureAccountsByIP(ctx, gen.ListLoginFailureAccountsByIPParams{Ip: ip, Since: since})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			if seen[r.UserID] {
+				continue
+			}
+			seen[r.UserID] = true
+			out = append(out, Account{UserID: r.UserID, OrgID: orgOrEmpty(r.OrgID)})
+		}
+	}
+	return out, nil
+}
+
+// FailuresByAccount returns accounts with failures from at least minIPs distinct IPs since the given time.
+func (s *PostgresSource) FailuresByAccount(ctx context.Context, since time.Time, minIPs int64) ([]AccountFailureStats, error) {
+	if len(s.queries) == 1 {
+		return failuresByAccount(ctx, s.queries[0], since, minIPs)
+	}
+	var order []string
+	totals := make(map[string]*AccountFailureStats)
+	seenIn := make(map[string]int)
+	for _, q := range s.queries {
+		rows, err := failuresByAccount(ctx, q, since, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			t, ok := totals[r.UserID]
+			if !ok {
+				t = &AccountFailureStats{UserID: r.UserID}
+				totals[r.UserID] = t
+				order = append(order, r.UserID)
+			}
+			if t.OrgID == "" {
+				t.OrgID = r.OrgID
+			}
+			t.Failures += r.Failures
+			t.IPs += r.IPs
+			seenIn[r.UserID]++
+		}
+	}
+	var out []AccountFailureStats
+	for _, userID := range order {
+		t := totals[userID]
+		if seenIn[userID] > 1 {
+			// The same IP can appear in several databases; count it once.
+			ips := make(map[string]bool)
+			for _, q := range s.queries {
+				rows, err := q.ListLoginFailureIPsByUser(ctx, gen.ListLoginFailureIPsByUserParams{UserID: userID, Since: since})
+				if err != nil {
+					return nil, err
+				}
+				for _, ip := range rows {
+					ips[ip] = true
+				}
+			}
+			t.IPs = int64(len(ips))
+		}
+		if t.IPs >= minIPs {
+			out = append(out, *t)
+		}
+	}
+	return out, nil
+}
+
+func failuresByIP(ctx context.Context, q auditQueries, since time.Time, minFailures, minAccounts int64) ([]IPFailureStats, error) {
+	rows, err := q.ListLoginFailureStatsByIP(ctx, gen.ListLoginFailureStatsByIPParams{
+		Since: since, MinFailures: minFailures, MinAccounts: minAccounts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]IPFailureStats, len(rows))
+	for i, r := range rows {
+		out[i] = IPFailureStats{IP: r.Ip, Failures: r.Failures, Accounts: r.Accounts}
+	}
+	return out, nil
+}
+
+func failuresByAccount(ctx context.Context, q auditQueries, since time.Time, minIPs int64) ([]AccountFailureStats, error) {
+	rows, err := q.ListLoginFailureStatsByUser(ctx, gen.ListLoginFailureStatsByUserParams{Since: since, MinIps: minIPs})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AccountFailureStats, len(rows))
+	for i, r := range rows {
+		out[i] = AccountFailureStats{UserID: r.UserID, OrgID: orgOrEmpty(r.OrgID), Failures: r.Failures, IPs: r.Ips}
+	}
+	return out, nil
+}
+
+// orgOrEmpty maps the audit sentinel org to "" so events are not attributed to it.
+func orgOrEmpty(orgID string) string {
+	if orgID == audit.SentinelOrgID {
+		return ""
+	}
+	return orgID
+}
@@ -0,0 +1,129 @@
+package detector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+// failure is one login_failure audit row.
+type failure struct {
+	ip, userID, orgID string
+}
+
+// fakeAuditDB aggregates failures the way the audit_log queries do.
+type fakeAuditDB struct {
+	failures []failure
+}
+
+func (f *fakeAuditDB) ListLoginFailureStatsByIP(ctx context.Context, arg gen.ListLoginFailureStatsByIPParams) ([]gen.ListLoginFailureStatsByIPRow, error) {
+	var order []string
+	failures := make(map[string]int64)
+	accounts := make(map[string]map[string]bool)
+	for _, r := range f.failures {
+		if _, ok := failures[r.ip]; !ok {
+			order = append(order, r.ip)
+			accounts[r.ip] = make(map[string]bool)
+		}
+		failures[r.ip]++
+		accounts[r.ip][r.userID] = true
+	}
+	var out []gen.ListLoginFailureStatsByIPRow
+	for _, ip := range order {
+		if failures[ip] >= arg.MinFailures || int64(len(accounts[ip])) >= arg.MinAccounts {
+			out = append(out, gen.ListLoginFailureStatsByIPRow{Ip: ip, Failures: failures[ip], Accounts: int64(len(accounts[ip]))})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeAuditDB) ListLoginFailureAccountsByIP(ctx context.Context, arg gen.ListLoginFailureAccountsByIPParams) ([]gen.ListLoginFailureAccountsByIPRow, error) {
+	var out []gen.ListLoginFailureAccountsByIPRow
+	seen := make(map[string]bool)
+	for _, r := range f.failures {
+		if r.ip == arg.Ip && !seen[r.userID] {
+			seen[r.userID] = true
+			out = append(out, gen.ListLoginFailureAccountsByIPRow{UserID: r.userID, OrgID: r.orgID})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeAuditDB) ListLoginFailureStatsByUser(ctx context.Context, arg gen.ListLoginFailureStatsByUserParams) ([]gen.ListLoginFailureStatsByUserRow, error) {
+	var order []string
+	failures := make(map[string]int64)
+	ips := make(map[string]map[string]bool)
+	orgs := make(map[string]string)
+	for _, r := range f.failures {
+		if _, ok := failures[r.userID]; !ok {
+			order = append(order, r.userID)
+			ips[r.userID] = make(map[string]bool)
+		}
+		failures[r.userID]++
+		ips[r.userID][r.ip] = true
+		orgs[r.userID] = r.orgID
+	}
+	var out []gen.ListLoginFailureStatsByUserRow
+	for _, u := range order {
+		if int64(len(ips[u])) >= arg.MinIps {
+			out = append(out, gen.ListLoginFailureStatsByUserRow{UserID: u, OrgID: orgs[u], Failures: failures[u], Ips: int64(len(ips[u]))})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeAuditDB) ListLoginFailureIPsByUser(ctx context.Context, arg gen.ListLoginFailureIPsByUserParams) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+	for _, r := range f.failures {
+		if r.userID == arg.UserID && !seen[r.ip] {
+			seen[r.ip] = true
+			out = append(out, r.ip)
+		}
+	}
+	return out, nil
+}
+
+func TestPostgresSource_CombinesRegionalDatabases(t *testing.T) {
+	// Each database alone is under every threshold; together they are over them.
+	primary := &fakeAuditDB{failures: []failure{
+		{"198.51.100.9", "u1", "org-1"}, {"198.51.100.9", "u2", "org-1"},
+		{"203.0.113.1", "u3", "org-1"}, {"203.0.113.2", "u3", "org-1"},
+	}}
+	eu := &fakeAuditDB{failures: []failure{
+		{"198.51.100.9", "u2", "org-eu"}, {"198.51.100.9", "u4", "org-eu"},
+		{"203.0.113.2", "u3", "org-eu"}, {"203.0.113.3", "u3", "org-eu"},
+	}}
+	s := &PostgresSource{queries: []auditQueries{primary, eu}}
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	ips, err := s.FailuresByIP(ctx, since, 100, 3)
+	if err != nil {
+		t.Fatalf("FailuresByIP: %v", err)
+	}
+	if len(ips) != 1 || ips[0].IP != "198.51.100.9" || ips[0].Failures != 4 || ips[0].Accounts != 3 {
+		t.Errorf("FailuresByIP = %+v, want 198.51.100.9 with 4 failures from 3 distinct accounts", ips)
+	}
+	if ips, _ := s.FailuresByIP(ctx, since, 100, 4); len(ips) != 0 {
+		t.Errorf("FailuresByIP over 4 accounts = %+v, want none: u2 failed in both databases", ips)
+	}
+
+	accounts, err := s.AccountsFailedFromIP(ctx, "198.51.100.9", since)
+	if err != nil || len(accounts) != 3 {
+		t.Errorf("AccountsFailedFromIP = %+v (%v), want u1, u2 and u4 once each", accounts, err)
+	}
+
+	byAccount, err := s.FailuresByAccount(ctx, since, 3)
+	if err != nil {
+		t.Fatalf("FailuresByAccount: %v", err)
+	}
+	if len(byAccount) != 1 || byAccount[0].UserID != "u3" || byAccount[0].Failures != 4 || byAccount[0].IPs != 3 {
+		t.Errorf("FailuresByAccount = %+v, want u3 with 4 failures from 3 distinct IPs", byAccount)
+	}
+	if byAccount, _ := s.FailuresByAccount(ctx, since, 4); len(byAccount) != 0 {
+		t.Errorf("FailuresByAccount over 4 IPs = %+v, want none: 203.0.113.2 failed in both databases", byAccount)
+	}
+}
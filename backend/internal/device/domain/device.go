@@ -2,8 +2,11 @@ package domain
 
 import "time"
 
+// MaxQuarantineReasonLength bounds the reason an admin gives for quarantining a device.
+const MaxQuarantineReasonLength = 500
+
 // Device represents a registered device for a user in an org.
-// Effective trust is Trusted && (TrustedUntil == nil || TrustedUntil.After(now)) && RevokedAt == nil.
+// Effective trust is Trusted && (TrustedUntil == nil || TrustedUntil.After(now)) && RevokedAt == nil && not quarantined.
 type Device struct {
 	ID           string
 	UserID       string
@@ -14,11 +17,24 @@ type Device struct {
 	RevokedAt    *time.Time
 	LastSeenAt   *time.Time
 	CreatedAt    time.Time
+	// TrustDays is the remember-device duration the user chose when the device was last trusted, at most the org's
+	// trust TTL; 0 means the org's trust TTL. Sliding renewal extends trust by this duration.
+	TrustDays int
+	// QuarantinedAt is set while an admin has quarantined the device (suspected compromise). Unlike revocation, the
+	// record and its trust are kept; every sign-in from the device requires MFA until it is released.
+	QuarantinedAt    *time.Time
+	QuarantineReason string
+	QuarantinedBy    string
+}
+
+// IsQuarantined returns true if the device is quarantined.
+func (d *Device) IsQuarantined() bool {
+	return d.QuarantinedAt != nil
 }
 
-// IsEffectivelyTrusted returns true if the device is trusted, not revoked, and trust has not expired.
+// IsEffectivelyTrusted returns true if the device is trusted, not revoked, not quarantined, and trust has not expired.
 func (d *Device) IsEffectivelyTrusted(now time.Time) bool {
-	if !d.Trusted || d.RevokedAt != nil {
+	if !d.Trusted || d.RevokedAt != nil || d.IsQuarantined() {
 		return false
 	}
 	if d.TrustedUntil != nil && !d.TrustedUntil.After(now) {
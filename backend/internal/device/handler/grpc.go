@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -10,18 +12,30 @@ import (
 	devicev1 "zero-trust-control-plane/backend/api/generated/device/v1"
 	"zero-trust-control-plane/backend/internal/device/domain"
 	"zero-trust-control-plane/backend/internal/device/repository"
+	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/securityevent"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 )
 
 // Server implements DeviceService (proto server) for device trust and posture.
 // Proto: device/device.proto → internal/device/handler.
 type Server struct {
 	devicev1.UnimplementedDeviceServiceServer
-	repo repository.Repository
+	repo           repository.Repository
+	securityEvents securityevent.Recorder
+	membershipRepo membershiprepo.Repository
+	groups         rbac.GroupAdminScoper
 }
 
 // NewServer returns a new Device gRPC server. Pass nil repo for stub (Unimplemented).
-func NewServer(repo repository.Repository) *Server {
-	return &Server{repo: repo}
+// securityEvents is optional; when non-nil, revocations, quarantines and releases are added to the device owner's
+// security feed.
+// membershipRepo is optional; when non-nil, callers must be org admin or owner (or, when groups is non-nil, a group
+// admin) and only see devices of their org and scope.
+func NewServer(repo repository.Repository, securityEvents securityevent.Recorder, membershipRepo membershiprepo.Repository, groups rbac.GroupAdminScoper) *Server {
+	return &Server{repo: repo, securityEvents: securityEvents, membershipRepo: membershipRepo, groups: groups}
 }
 
 // RegisterDevice registers a device. TODO: implement (auth creates device on login).
@@ -34,6 +48,10 @@ func (s *Server) GetDevice(ctx context.Context, req *devicev1.GetDeviceRequest)
 	if s.repo == nil {
 		return nil, status.Error(codes.Unimplemented, "method GetDevice not implemented")
 	}
+	scope, err := s.adminScope(ctx)
+	if err != nil {
+		return nil, err
+	}
 	dev, err := s.repo.GetByID(ctx, req.GetDeviceId())
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -41,15 +59,30 @@ func (s *Server) GetDevice(ctx context.Context, req *devicev1.GetDeviceRequest)
 	if dev == nil {
 		return nil, status.Error(codes.NotFound, "device not found")
 	}
+	if err := checkScope(scope, dev); err != nil {
+		return nil, err
+	}
 	return &devicev1.GetDeviceResponse{Device: deviceToProto(dev)}, nil
 }
 
-// ListDevices returns a paginated list of devices for the org (and optional user filter).
+// ListDevices returns a paginated list of devices for the org (and optional user filter). With authorization enabled,
+// org_id defaults to the caller's org and group admins only see the devices of their groups' users.
 func (s *Server) ListDevices(ctx context.Context, req *devicev1.ListDevicesRequest) (*devicev1.ListDevicesResponse, error) {
 	if s.repo == nil {
 		return nil, status.Error(codes.Unimplemented, "method ListDevices not implemented")
 	}
-	list, err := s.repo.ListByOrg(ctx, req.GetOrgId())
+	scope, err := s.adminScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orgID := req.GetOrgId()
+	if scope != nil {
+		if orgID != "" && orgID != scope.OrgID {
+			return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+		}
+		orgID = scope.OrgID
+	}
+	list, err := s.repo.ListByOrg(ctx, orgID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -58,6 +91,9 @@ func (s *Server) ListDevices(ctx context.Context, req *devicev1.ListDevicesReque
 		if req.GetUserId() != "" && d.UserID != req.GetUserId() {
 			continue
 		}
+		if scope != nil && !scope.Allows(d.UserID) {
+			continue
+		}
 		devices = append(devices, deviceToProto(d))
 	}
 	return &devicev1.ListDevicesResponse{Devices: devices}, nil
@@ -68,12 +104,134 @@ func (s *Server) RevokeDevice(ctx context.Context, req *devicev1.RevokeDeviceReq
 	if s.repo == nil {
 		return nil, status.Error(codes.Unimplemented, "method RevokeDevice not implemented")
 	}
+	scope, err := s.adminScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scope != nil {
+		dev, err := s.repo.GetByID(ctx, req.GetDeviceId())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if dev == nil {
+			return nil, status.Error(codes.NotFound, "device not found")
+		}
+		if err := checkScope(scope, dev); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.repo.Revoke(ctx, req.GetDeviceId()); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if s.securityEvents != nil {
+		if dev, err := s.repo.GetByID(ctx, req.GetDeviceId()); err == nil && dev != nil {
+			s.securityEvents.Record(ctx, dev.OrgID, dev.UserID, securityeventdomain.EventDeviceRevoked, `{"device_id":"`+dev.ID+`"}`)
+		}
+	}
 	return &devicev1.RevokeDeviceResponse{}, nil
 }
 
+// QuarantineDevice quarantines a device suspected to be compromised. Unlike RevokeDevice, the device keeps its record,
+// trust and history, but every sign-in from it requires MFA and raises a security event until it is released.
+func (s *Server) QuarantineDevice(ctx context.Context, req *devicev1.QuarantineDeviceRequest) (*devicev1.QuarantineDeviceResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method QuarantineDevice not implemented")
+	}
+	reason := strings.TrimSpace(req.GetReason())
+	if len(reason) > domain.MaxQuarantineReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be at most %d characters", domain.MaxQuarantineReasonLength)
+	}
+	dev, err := s.scopedDevice(ctx, req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	if dev.RevokedAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "device is revoked")
+	}
+	if dev.IsQuarantined() {
+		return nil, status.Error(codes.FailedPrecondition, "device is already quarantined")
+	}
+	callerID, _ := interceptors.GetUserID(ctx)
+	if err := s.repo.Quarantine(ctx, dev.ID, reason, callerID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if s.securityEvents != nil {
+		metadata, _ := json.Marshal(map[string]string{"device_id": dev.ID, "reason": reason})
+		s.securityEvents.Record(ctx, dev.OrgID, dev.UserID, securityeventdomain.EventDeviceQuarantined, string(metadata))
+	}
+	dev, err = s.repo.GetByID(ctx, dev.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &devicev1.QuarantineDeviceResponse{Device: deviceToProto(dev)}, nil
+}
+
+// ReleaseDevice releases a quarantined device. Its trust, if still valid, applies again.
+func (s *Server) ReleaseDevice(ctx context.Context, req *devicev1.ReleaseDeviceRequest) (*devicev1.ReleaseDeviceResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ReleaseDevice not implemented")
+	}
+	dev, err := s.scopedDevice(ctx, req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	if !dev.IsQuarantined() {
+		return nil, status.Error(codes.FailedPrecondition, "device is not quarantined")
+	}
+	if err := s.repo.Release(ctx, dev.ID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if s.securityEvents != nil {
+		s.securityEvents.Record(ctx, dev.OrgID, dev.UserID, securityeventdomain.EventDeviceReleased, `{"device_id":"`+dev.ID+`"}`)
+	}
+	dev, err = s.repo.GetByID(ctx, dev.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &devicev1.ReleaseDeviceResponse{Device: deviceToProto(dev)}, nil
+}
+
+// scopedDevice returns the device with the given id, or NotFound, after checking the caller's admin scope.
+func (s *Server) scopedDevice(ctx context.Context, id string) (*domain.Device, error) {
+	scope, err := s.adminScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dev, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if dev == nil {
+		return nil, status.Error(codes.NotFound, "device not found")
+	}
+	if err := checkScope(scope, dev); err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+// adminScope returns the caller's admin scope, or nil when authorization is disabled (no membershipRepo).
+func (s *Server) adminScope(ctx context.Context) (*rbac.AdminScope, error) {
+	if s.membershipRepo == nil {
+		return nil, nil
+	}
+	return rbac.RequireAdminScope(ctx, s.membershipRepo, s.groups)
+}
+
+// checkScope returns PermissionDenied if the device is outside the scope. A nil scope allows every device.
+func checkScope(scope *rbac.AdminScope, dev *domain.Device) error {
+	if scope == nil {
+		return nil
+	}
+	if dev.OrgID != scope.OrgID {
+		return status.Error(codes.PermissionDenied, "device does not belong to your organization")
+	}
+	if !scope.Allows(dev.UserID) {
+		return status.Error(codes.PermissionDenied, "device owner is not in a group you administer")
+	}
+	return nil
+}
+
 func deviceToProto(d *domain.Device) *devicev1.Device {
 	if d == nil {
 		return nil
@@ -84,6 +242,7 @@ func deviceToProto(d *domain.Device) *devicev1.Device {
 		OrgId:       d.OrgID,
 		Fingerprint: d.Fingerprint,
 		Trusted:     d.Trusted,
+		TrustDays:   int32(d.TrustDays),
 	}
 	if d.LastSeenAt != nil {
 		out.LastSeenAt = timestamppb.New(*d.LastSeenAt)
@@ -94,6 +253,11 @@ func deviceToProto(d *domain.Device) *devicev1.Device {
 	if d.RevokedAt != nil {
 		out.RevokedAt = timestamppb.New(*d.RevokedAt)
 	}
+	if d.QuarantinedAt != nil {
+		out.QuarantinedAt = timestamppb.New(*d.QuarantinedAt)
+		out.QuarantineReason = d.QuarantineReason
+		out.QuarantinedBy = d.QuarantinedBy
+	}
 	out.CreatedAt = timestamppb.New(d.CreatedAt)
 	return out
 }
@@ -11,6 +11,9 @@ import (
 
 	devicev1 "zero-trust-control-plane/backend/api/generated/device/v1"
 	"zero-trust-control-plane/backend/internal/device/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 )
 
 // mockDeviceRepo implements repository.Repository for tests.
@@ -40,6 +43,10 @@ func (m *mockDeviceRepo) ListByOrg(ctx context.Context, orgID string) ([]*domain
 	return m.byOrg[orgID], nil
 }
 
+func (m *mockDeviceRepo) ListTrustedByUser(ctx context.Context, userID string) ([]*domain.Device, error) {
+	return nil, nil
+}
+
 func (m *mockDeviceRepo) Create(ctx context.Context, d *domain.Device) error {
 	return nil
 }
@@ -59,6 +66,21 @@ func (m *mockDeviceRepo) Revoke(ctx context.Context, id string) error {
 	return nil
 }
 
+func (m *mockDeviceRepo) Quarantine(ctx context.Context, id, reason, by string) error {
+	if d := m.devices[id]; d != nil {
+		now := time.Now().UTC()
+		d.QuarantinedAt, d.QuarantineReason, d.QuarantinedBy = &now, reason, by
+	}
+	return nil
+}
+
+func (m *mockDeviceRepo) Release(ctx context.Context, id string) error {
+	if d := m.devices[id]; d != nil {
+		d.QuarantinedAt, d.QuarantineReason, d.QuarantinedBy = nil, "", ""
+	}
+	return nil
+}
+
 func (m *mockDeviceRepo) UpdateLastSeen(ctx context.Context, id string, at time.Time) error {
 	return nil
 }
@@ -77,7 +99,7 @@ func TestGetDevice_Success(t *testing.T) {
 		devices: map[string]*domain.Device{"device-1": device},
 		byOrg:   make(map[string][]*domain.Device),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.GetDevice(ctx, &devicev1.GetDeviceRequest{DeviceId: "device-1"})
@@ -103,7 +125,7 @@ func TestGetDevice_NotFound(t *testing.T) {
 		devices: make(map[string]*domain.Device),
 		byOrg:   make(map[string][]*domain.Device),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetDevice(ctx, &devicev1.GetDeviceRequest{DeviceId: "nonexistent"})
@@ -125,7 +147,7 @@ func TestGetDevice_RepositoryError(t *testing.T) {
 		byOrg:       make(map[string][]*domain.Device),
 		getByIDErr: errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetDevice(ctx, &devicev1.GetDeviceRequest{DeviceId: "device-1"})
@@ -142,7 +164,7 @@ func TestGetDevice_RepositoryError(t *testing.T) {
 }
 
 func TestGetDevice_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetDevice(ctx, &devicev1.GetDeviceRequest{DeviceId: "device-1"})
@@ -168,7 +190,7 @@ func TestListDevices_Success(t *testing.T) {
 		devices: make(map[string]*domain.Device),
 		byOrg:   map[string][]*domain.Device{"org-1": devices},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.ListDevices(ctx, &devicev1.ListDevicesRequest{OrgId: "org-1"})
@@ -191,7 +213,7 @@ func TestListDevices_FilteredByUserID(t *testing.T) {
 		devices: make(map[string]*domain.Device),
 		byOrg:   map[string][]*domain.Device{"org-1": devices},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.ListDevices(ctx, &devicev1.ListDevicesRequest{
@@ -216,7 +238,7 @@ func TestListDevices_EmptyList(t *testing.T) {
 		devices: make(map[string]*domain.Device),
 		byOrg:   map[string][]*domain.Device{"org-1": {}},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.ListDevices(ctx, &devicev1.ListDevicesRequest{OrgId: "org-1"})
@@ -234,7 +256,7 @@ func TestListDevices_RepositoryError(t *testing.T) {
 		byOrg:   make(map[string][]*domain.Device),
 		listErr: errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListDevices(ctx, &devicev1.ListDevicesRequest{OrgId: "org-1"})
@@ -251,7 +273,7 @@ func TestListDevices_RepositoryError(t *testing.T) {
 }
 
 func TestListDevices_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListDevices(ctx, &devicev1.ListDevicesRequest{OrgId: "org-1"})
@@ -272,7 +294,7 @@ func TestRevokeDevice_Success(t *testing.T) {
 		devices: make(map[string]*domain.Device),
 		byOrg:   make(map[string][]*domain.Device),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.RevokeDevice(ctx, &devicev1.RevokeDeviceRequest{DeviceId: "device-1"})
@@ -287,7 +309,7 @@ func TestRevokeDevice_RepositoryError(t *testing.T) {
 		byOrg:     make(map[string][]*domain.Device),
 		revokeErr: errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.RevokeDevice(ctx, &devicev1.RevokeDeviceRequest{DeviceId: "device-1"})
@@ -304,7 +326,7 @@ func TestRevokeDevice_RepositoryError(t *testing.T) {
 }
 
 func TestRevokeDevice_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.RevokeDevice(ctx, &devicev1.RevokeDeviceRequest{DeviceId: "device-1"})
@@ -340,7 +362,7 @@ func TestGetDevice_WithTimestamps(t *testing.T) {
 		devices: map[string]*domain.Device{"device-1": device},
 		byOrg:   make(map[string][]*domain.Device),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.GetDevice(ctx, &devicev1.GetDeviceRequest{DeviceId: "device-1"})
@@ -363,7 +385,7 @@ func TestRegisterDevice_Unimplemented(t *testing.T) {
 		devices: make(map[string]*domain.Device),
 		byOrg:   make(map[string][]*domain.Device),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.RegisterDevice(ctx, &devicev1.RegisterDeviceRequest{})
@@ -518,3 +540,205 @@ func TestDeviceToProto_AllFields(t *testing.T) {
 		t.Error("Trusted should be true")
 	}
 }
+
+type recordedSecurityEvent struct {
+	orgID, userID string
+	eventType     securityeventdomain.EventType
+	metadata      string
+}
+
+type mockSecurityEventRecorder struct {
+	events []recordedSecurityEvent
+}
+
+func (m *mockSecurityEventRecorder) Record(ctx context.Context, orgID, userID string, eventType securityeventdomain.EventType, metadata string) {
+	m.events = append(m.events, recordedSecurityEvent{orgID, userID, eventType, metadata})
+}
+
+func TestRevokeDevice_RecordsSecurityEvent(t *testing.T) {
+	repo := &mockDeviceRepo{
+		devices: map[string]*domain.Device{
+			"device-1": {ID: "device-1", UserID: "user-1", OrgID: "org-1", Fingerprint: "fp-1"},
+		},
+		byOrg: make(map[string][]*domain.Device),
+	}
+	recorder := &mockSecurityEventRecorder{}
+	srv := NewServer(repo, recorder, nil, nil)
+
+	if _, err := srv.RevokeDevice(context.Background(), &devicev1.RevokeDeviceRequest{DeviceId: "device-1"}); err != nil {
+		t.Fatalf("RevokeDevice: %v", err)
+	}
+	if len(recorder.events) != 1 {
+		t.Fatalf("recorded events = %d, want 1", len(recorder.events))
+	}
+	ev := recorder.events[0]
+	if ev.userID != "user-1" || ev.orgID != "org-1" || ev.eventType != securityeventdomain.EventDeviceRevoked {
+		t.Errorf("event = %+v, want device_revoked for user-1/org-1", ev)
+	}
+	if ev.metadata != `{"device_id":"device-1"}` {
+		t.Errorf("metadata = %q", ev.metadata)
+	}
+}
+
+// mockMembershipRepo implements membershiprepo.Repository; only GetMembershipByUserAndOrg is used.
+type mockMembershipRepo struct {
+	roles map[string]membershipdomain.Role // key: userID:orgID
+}
+
+func (m *mockMembershipRepo) GetMembershipByID(ctx context.Context, id string) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	role, ok := m.roles[userID+":"+orgID]
+	if !ok {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+func (m *mockMembershipRepo) ListMembershipsByOrg(ctx context.Context, orgID string) ([]*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) CreateMembership(ctx context.Context, mem *membershipdomain.Membership) error {
+	return nil
+}
+
+func (m *mockMembershipRepo) DeleteByUserAndOrg(ctx context.Context, userID, orgID string) error {
+	return nil
+}
+
+func (m *mockMembershipRepo) UpdateRole(ctx context.Context, userID, orgID string, role membershipdomain.Role) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) CountOwnersByOrg(ctx context.Context, orgID string) (int64, error) {
+	return 0, nil
+}
+
+// mockGroupScoper implements rbac.GroupAdminScoper.
+type mockGroupScoper struct {
+	managed map[string][]string // key: adminUserID:orgID
+}
+
+func (m *mockGroupScoper) ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error) {
+	return m.managed[adminUserID+":"+orgID], nil
+}
+
+func TestDeviceAuthorization(t *testing.T) {
+	dev1 := &domain.Device{ID: "device-1", UserID: "user-1", OrgID: "org-1"}
+	dev2 := &domain.Device{ID: "device-2", UserID: "user-2", OrgID: "org-1"}
+	dev3 := &domain.Device{ID: "device-3", UserID: "user-3", OrgID: "org-2"}
+	repo := &mockDeviceRepo{
+		devices: map[string]*domain.Device{"device-1": dev1, "device-2": dev2, "device-3": dev3},
+		byOrg:   map[string][]*domain.Device{"org-1": {dev1, dev2}, "org-2": {dev3}},
+	}
+	memberships := &mockMembershipRepo{roles: map[string]membershipdomain.Role{
+		"admin-1:org-1": membershipdomain.RoleAdmin,
+		"lead-1:org-1":  membershipdomain.RoleMember,
+		"user-1:org-1":  membershipdomain.RoleMember,
+	}}
+	groups := &mockGroupScoper{managed: map[string][]string{"lead-1:org-1": {"lead-1", "user-1"}}}
+	srv := NewServer(repo, nil, memberships, groups)
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	lead := interceptors.WithIdentity(context.Background(), "lead-1", "org-1", "session-2")
+
+	list, err := srv.ListDevices(admin, &devicev1.ListDevicesRequest{})
+	if err != nil || len(list.GetDevices()) != 2 {
+		t.Fatalf("ListDevices as org admin = %v, %v; want 2 devices", list, err)
+	}
+	list, err = srv.ListDevices(lead, &devicev1.ListDevicesRequest{})
+	if err != nil || len(list.GetDevices()) != 1 || list.GetDevices()[0].GetId() != "device-1" {
+		t.Fatalf("ListDevices as group admin = %v, %v; want device-1 only", list, err)
+	}
+	if _, err := srv.ListDevices(admin, &devicev1.ListDevicesRequest{OrgId: "org-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListDevices for another org: code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		deviceID string
+		wantCode codes.Code
+	}{
+		{"group admin, device in scope", lead, "device-1", codes.OK},
+		{"group admin, device outside scope", lead, "device-2", codes.PermissionDenied},
+		{"org admin, device of another org", admin, "device-3", codes.PermissionDenied},
+		{"member", interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-3"), "device-1", codes.PermissionDenied},
+		{"no identity", context.Background(), "device-1", codes.Unauthenticated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := srv.GetDevice(tc.ctx, &devicev1.GetDeviceRequest{DeviceId: tc.deviceID}); status.Code(err) != tc.wantCode {
+				t.Errorf("GetDevice: code = %v, want %v", status.Code(err), tc.wantCode)
+			}
+			if _, err := srv.RevokeDevice(tc.ctx, &devicev1.RevokeDeviceRequest{DeviceId: tc.deviceID}); status.Code(err) != tc.wantCode {
+				t.Errorf("RevokeDevice: code = %v, want %v", status.Code(err), tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestQuarantineAndReleaseDevice(t *testing.T) {
+	trustedUntil := time.Now().Add(24 * time.Hour)
+	dev := &domain.Device{ID: "device-1", UserID: "user-1", OrgID: "org-1", Trusted: true, TrustedUntil: &trustedUntil}
+	revoked := time.Now()
+	repo := &mockDeviceRepo{
+		devices: map[string]*domain.Device{"device-1": dev, "device-2": {ID: "device-2", UserID: "user-2", OrgID: "org-1", RevokedAt: &revoked}},
+		byOrg:   make(map[string][]*domain.Device),
+	}
+	memberships := &mockMembershipRepo{roles: map[string]membershipdomain.Role{
+		"admin-1:org-1": membershipdomain.RoleAdmin,
+		"user-1:org-1":  membershipdomain.RoleMember,
+	}}
+	recorder := &mockSecurityEventRecorder{}
+	srv := NewServer(repo, recorder, memberships, nil)
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	member := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-2")
+
+	if _, err := srv.QuarantineDevice(member, &devicev1.QuarantineDeviceRequest{DeviceId: "device-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("QuarantineDevice as member: code = %v, want PermissionDenied", status.Code(err))
+	}
+	resp, err := srv.QuarantineDevice(admin, &devicev1.QuarantineDeviceRequest{DeviceId: "device-1", Reason: " malware report "})
+	if err != nil {
+		t.Fatalf("QuarantineDevice: %v", err)
+	}
+	got := resp.GetDevice()
+	if got.GetQuarantinedAt() == nil || got.GetQuarantineReason() != "malware report" || got.GetQuarantinedBy() != "admin-1" {
+		t.Errorf("device = %+v, want quarantined by admin-1 for \"malware report\"", got)
+	}
+	if !got.GetTrusted() || got.GetRevokedAt() != nil {
+		t.Error("quarantine must keep the device's trust and not revoke it")
+	}
+	if dev.IsEffectivelyTrusted(time.Now()) {
+		t.Error("a quarantined device must not be effectively trusted")
+	}
+	if _, err := srv.QuarantineDevice(admin, &devicev1.QuarantineDeviceRequest{DeviceId: "device-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("QuarantineDevice twice: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.QuarantineDevice(admin, &devicev1.QuarantineDeviceRequest{DeviceId: "device-2"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("QuarantineDevice of a revoked device: code = %v, want FailedPrecondition", status.Code(err))
+	}
+
+	rel, err := srv.ReleaseDevice(admin, &devicev1.ReleaseDeviceRequest{DeviceId: "device-1"})
+	if err != nil {
+		t.Fatalf("ReleaseDevice: %v", err)
+	}
+	if rel.GetDevice().GetQuarantinedAt() != nil || !dev.IsEffectivelyTrusted(time.Now()) {
+		t.Error("release must clear the quarantine and restore the device's trust")
+	}
+	if _, err := srv.ReleaseDevice(admin, &devicev1.ReleaseDeviceRequest{DeviceId: "device-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ReleaseDevice of a device not quarantined: code = %v, want FailedPrecondition", status.Code(err))
+	}
+
+	if len(recorder.events) != 2 {
+		t.Fatalf("recorded events = %d, want 2", len(recorder.events))
+	}
+	if ev := recorder.events[0]; ev.eventType != securityeventdomain.EventDeviceQuarantined || ev.userID != "user-1" || ev.metadata != `{"device_id":"device-1","reason":"malware report"}` {
+		t.Errorf("first event = %+v, want device_quarantined for user-1", ev)
+	}
+	if ev := recorder.events[1]; ev.eventType != securityeventdomain.EventDeviceReleased {
+		t.Errorf("second event = %+v, want device_released", ev)
+	}
+}
@@ -8,15 +8,17 @@ import (
 
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
 	"zero-trust-control-plane/backend/internal/device/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
 )
 
 type PostgresRepository struct {
+	db      *sql.DB
 	queries *gen.Queries
 }
 
 // NewPostgresRepository returns a device repository that uses the given db for persistence.
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+	return &PostgresRepository{db: db, queries: gen.New(db)}
 }
 
 // GetByID returns the device for id, or nil if not found.
@@ -58,7 +60,48 @@ func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string) ([]*do
 	return out, nil
 }
 
-// Create persists the device to the database. The device must have ID set.
+// ListTrustedByUser returns the user's trusted devices across all orgs.
+func (r *PostgresRepository) ListTrustedByUser(ctx context.Context, userID string) ([]*domain.Device, error) {
+	list, err := r.queries.ListTrustedDevicesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Device, len(list))
+	for i := range list {
+		out[i] = genDeviceToDomain(&list[i])
+	}
+	return out, nil
+}
+
+// ListTrustExpiring returns up to limit trusted, unrevoked devices whose trust expires after the (afterUntil,
+// afterID) cursor and at or before before, and whose expiry notice has not been sent, ordered by expiry then id.
+// Pass the last device's TrustedUntil and ID as the cursor to read the next page.
+func (r *PostgresRepository) ListTrustExpiring(ctx context.Context, afterUntil time.Time, afterID string, before time.Time, limit int) ([]*domain.Device, error) {
+	list, err := r.queries.ListDevicesWithTrustExpiring(ctx, gen.ListDevicesWithTrustExpiringParams{
+		ExpiringBefore: before, AfterUntil: afterUntil, AfterID: afterID, RowLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Device, len(list))
+	for i := range list {
+		out[i] = genDeviceToDomain(&list[i])
+	}
+	return out, nil
+}
+
+// MarkTrustExpiryNotified records that the expiry notice of the device's current trust period was sent. It
+// returns false when the notice was already claimed (e.g. by another server instance); granting or renewing
+// trust (UpdateTrustedWithExpiry) clears the mark.
+func (r *PostgresRepository) MarkTrustExpiryNotified(ctx context.Context, id string, at time.Time) (bool, error) {
+	n, err := r.queries.MarkDeviceTrustExpiryNotified(ctx, gen.MarkDeviceTrustExpiryNotifiedParams{
+		ID: id, TrustExpiryNotifiedAt: sql.NullTime{Time: at, Valid: true},
+	})
+	return n > 0, err
+}
+
+// Create persists the device to the database. The device must have ID set. Returns orgquota.ErrDeviceQuotaExceeded
+// when the org has reached max_devices; the check and the insert run in one transaction.
 func (r *PostgresRepository) Create(ctx context.Context, d *domain.Device) error {
 	lastSeen := sql.NullTime{}
 	if d.LastSeenAt != nil {
@@ -72,12 +115,23 @@ func (r *PostgresRepository) Create(ctx context.Context, d *domain.Device) error
 	if d.RevokedAt != nil {
 		revokedAt = sql.NullTime{Time: *d.RevokedAt, Valid: true}
 	}
-	_, err := r.queries.CreateDevice(ctx, gen.CreateDeviceParams{
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	if err := orgquota.ReserveDevice(ctx, q, d.OrgID); err != nil {
+		return err
+	}
+	if _, err := q.CreateDevice(ctx, gen.CreateDeviceParams{
 		ID: d.ID, UserID: d.UserID, OrgID: d.OrgID, Fingerprint: d.Fingerprint,
 		Trusted: d.Trusted, TrustedUntil: trustedUntil, RevokedAt: revokedAt,
 		LastSeenAt: lastSeen, CreatedAt: d.CreatedAt,
-	})
-	return err
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // UpdateTrusted sets the device's trusted flag for the given id. Returns an error if the update fails.
@@ -86,8 +140,8 @@ func (r *PostgresRepository) UpdateTrusted(ctx context.Context, id string, trust
 	return err
 }
 
-// UpdateTrustedWithExpiry sets the device's trusted flag and trusted_until for the given id; clears revoked_at.
-// Pass nil for trustedUntil to set no expiry.
+// UpdateTrustedWithExpiry sets the device's trusted flag and trusted_until for the given id; clears revoked_at
+// and the trust expiry notice mark. Pass nil for trustedUntil to set no expiry.
 func (r *PostgresRepository) UpdateTrustedWithExpiry(ctx context.Context, id string, trusted bool, trustedUntil *time.Time) error {
 	tu := sql.NullTime{}
 	if trustedUntil != nil {
@@ -99,6 +153,15 @@ func (r *PostgresRepository) UpdateTrustedWithExpiry(ctx context.Context, id str
 	return err
 }
 
+// Trust trusts the device until trustedUntil, recording trustDays as the remember-device duration the user chose
+// (0 for the org's trust TTL); clears revoked_at and the trust expiry notice mark.
+func (r *PostgresRepository) Trust(ctx context.Context, id string, trustedUntil time.Time, trustDays int) error {
+	_, err := r.queries.TrustDevice(ctx, gen.TrustDeviceParams{
+		ID: id, TrustedUntil: sql.NullTime{Time: trustedUntil, Valid: true}, TrustDays: int32(trustDays),
+	})
+	return err
+}
+
 // Revoke sets revoked_at to now and clears trusted and trusted_until for the given device id.
 func (r *PostgresRepository) Revoke(ctx context.Context, id string) error {
 	now := time.Now().UTC()
@@ -106,6 +169,22 @@ func (r *PostgresRepository) Revoke(ctx context.Context, id string) error {
 	return err
 }
 
+// Quarantine marks the device quarantined now, with the reason and the user id of the admin who quarantined it.
+// Trust, revocation and history are left unchanged.
+func (r *PostgresRepository) Quarantine(ctx context.Context, id, reason, by string) error {
+	now := time.Now().UTC()
+	_, err := r.queries.QuarantineDevice(ctx, gen.QuarantineDeviceParams{
+		ID: id, QuarantinedAt: sql.NullTime{Time: now, Valid: true}, QuarantineReason: reason, QuarantinedBy: by,
+	})
+	return err
+}
+
+// Release clears the device's quarantine.
+func (r *PostgresRepository) Release(ctx context.Context, id string) error {
+	_, err := r.queries.ReleaseDevice(ctx, id)
+	return err
+}
+
 // UpdateLastSeen sets the device's last-seen timestamp for the given id. Returns an error if the update fails.
 func (r *PostgresRepository) UpdateLastSeen(ctx context.Context, id string, at time.Time) error {
 	_, err := r.queries.UpdateDeviceLastSeen(ctx, gen.UpdateDeviceLastSeenParams{ID: id, LastSeenAt: sql.NullTime{Time: at, Valid: true}})
@@ -116,7 +195,7 @@ func genDeviceToDomain(d *gen.Device) *domain.Device {
 	if d == nil {
 		return nil
 	}
-	var lastSeen, trustedUntil, revokedAt *time.Time
+	var lastSeen, trustedUntil, revokedAt, quarantinedAt *time.Time
 	if d.LastSeenAt.Valid {
 		lastSeen = &d.LastSeenAt.Time
 	}
@@ -126,9 +205,13 @@ func genDeviceToDomain(d *gen.Device) *domain.Device {
 	if d.RevokedAt.Valid {
 		revokedAt = &d.RevokedAt.Time
 	}
+	if d.QuarantinedAt.Valid {
+		quarantinedAt = &d.QuarantinedAt.Time
+	}
 	return &domain.Device{
 		ID: d.ID, UserID: d.UserID, OrgID: d.OrgID, Fingerprint: d.Fingerprint,
 		Trusted: d.Trusted, TrustedUntil: trustedUntil, RevokedAt: revokedAt,
-		LastSeenAt: lastSeen, CreatedAt: d.CreatedAt,
+		LastSeenAt: lastSeen, CreatedAt: d.CreatedAt, TrustDays: int(d.TrustDays),
+		QuarantinedAt: quarantinedAt, QuarantineReason: d.QuarantineReason, QuarantinedBy: d.QuarantinedBy,
 	}
 }
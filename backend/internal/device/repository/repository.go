@@ -12,9 +12,12 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*domain.Device, error)
 	GetByUserOrgAndFingerprint(ctx context.Context, userID, orgID, fingerprint string) (*domain.Device, error)
 	ListByOrg(ctx context.Context, orgID string) ([]*domain.Device, error)
+	ListTrustedByUser(ctx context.Context, userID string) ([]*domain.Device, error)
 	Create(ctx context.Context, d *domain.Device) error
 	UpdateTrusted(ctx context.Context, id string, trusted bool) error
 	UpdateTrustedWithExpiry(ctx context.Context, id string, trusted bool, trustedUntil *time.Time) error
 	Revoke(ctx context.Context, id string) error
+	Quarantine(ctx context.Context, id, reason, by string) error
+	Release(ctx context.Context, id string) error
 	UpdateLastSeen(ctx context.Context, id string, at time.Time) error
 }
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Status is where a device code is in its lifecycle.
+type Status string
+
+const (
+	// StatusPending awaits approval from a trusted session until ExpiresAt.
+	StatusPending Status = "pending"
+	// StatusApproved lets the polling client get tokens for UserID in OrgID until ExpiresAt.
+	StatusApproved Status = "approved"
+	// StatusDenied ends the sign-in; polling is refused.
+	StatusDenied Status = "denied"
+	// StatusCompleted was approved and exchanged for tokens; the code cannot be used again.
+	StatusCompleted Status = "completed"
+	// StatusExpired is reported for pending and approved codes past ExpiresAt; it is never stored.
+	StatusExpired Status = "expired"
+)
+
+// DeviceCodePrefix starts every device code, so leaked codes are easy to recognise.
+const DeviceCodePrefix = "ztcp_dc_"
+
+// userCodeAlphabet has no vowels (no words) and no digits (no 0/O or 1/I confusion), as RFC 8628 suggests.
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// UserCodeLength is the number of characters of a user code, not counting the dash it is shown with.
+const UserCodeLength = 8
+
+// DeviceCode is a sign-in started on a device that cannot sign in itself. The device keeps the device code and
+// polls with it; the user approves the user code from a trusted session, and the device then signs in as that user.
+type DeviceCode struct {
+	ID                string
+	DeviceCodeHash    string
+	UserCode          string // normalized, see NormalizeUserCode
+	ClientName        string
+	DeviceFingerprint string
+	IP                string
+	Status            Status
+	UserID            string // who approved or denied the code; empty while pending
+	OrgID             string
+	DecidedAt         *time.Time
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+}
+
+// EffectiveStatus is Status, except that a pending or approved code past ExpiresAt is expired, whether or not
+// anything has marked it.
+func (d *DeviceCode) EffectiveStatus(now time.Time) Status {
+	if (d.Status == StatusPending || d.Status == StatusApproved) && !now.Before(d.ExpiresAt) {
+		return StatusExpired
+	}
+	return d.Status
+}
+
+// NewDeviceCode returns a new random device code and its hash.
+func NewDeviceCode() (code, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	code = DeviceCodePrefix + base64.RawURLEncoding.EncodeToString(b)
+	return code, HashDeviceCode(code), nil
+}
+
+// HashDeviceCode returns the stored form of a device code, by which it is looked up. The code is random, so a plain
+// SHA-256 suffices.
+func HashDeviceCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewUserCode returns a new random user code in normalized form.
+func NewUserCode() (string, error) {
+	b := make([]byte, UserCodeLength)
+	max := big.NewInt(int64(len(userCodeAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = userCodeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// NormalizeUserCode returns code as stored: upper case, without the dash and any spaces users type.
+func NormalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// FormatUserCode returns a normalized user code as shown to users, e.g. "BCDF-GHJK".
+func FormatUserCode(code string) string {
+	if len(code) != UserCodeLength {
+		return code
+	}
+	return code[:UserCodeLength/2] + "-" + code[UserCodeLength/2:]
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/devicecode/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a device code repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists a new pending device code.
+func (r *PostgresRepository) Create(ctx context.Context, d *domain.DeviceCode) error {
+	return r.queries.CreateDeviceCode(ctx, gen.CreateDeviceCodeParams{
+		ID:                d.ID,
+		DeviceCodeHash:    d.DeviceCodeHash,
+		UserCode:          d.UserCode,
+		ClientName:        d.ClientName,
+		DeviceFingerprint: d.DeviceFingerprint,
+		Ip:                d.IP,
+		Status:            string(d.Status),
+		CreatedAt:         d.CreatedAt,
+		ExpiresAt:         d.ExpiresAt,
+	})
+}
+
+// GetByDeviceCodeHash returns the device code, or nil if not found.
+func (r *PostgresRepository) GetByDeviceCodeHash(ctx context.Context, hash string) (*domain.DeviceCode, error) {
+	row, err := r.queries.GetDeviceCodeByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genDeviceCodeToDomain(&row), nil
+}
+
+// GetPendingByUserCode returns the pending device code with this user code, or nil if there is none.
+func (r *PostgresRepository) GetPendingByUserCode(ctx context.Context, userCode string, now time.Time) (*domain.DeviceCode, error) {
+	row, err := r.queries.GetPendingDeviceCodeByUserCode(ctx, gen.GetPendingDeviceCodeByUserCodeParams{UserCode: userCode, Now: now})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genDeviceCodeToDomain(&row), nil
+}
+
+// Decide approves or denies a pending device code.
+func (r *PostgresRepository) Decide(ctx context.Context, id string, status domain.Status, userID, orgID string, decidedAt time.Time) (bool, error) {
+	n, err := r.queries.DecideDeviceCode(ctx, gen.DecideDeviceCodeParams{
+		Status:    string(status),
+		UserID:    sql.NullString{String: userID, Valid: true},
+		OrgID:     sql.NullString{String: orgID, Valid: true},
+		DecidedAt: sql.NullTime{Time: decidedAt, Valid: true},
+		ID:        id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Complete consumes an approved device code.
+func (r *PostgresRepository) Complete(ctx context.Context, id string, now time.Time) (bool, error) {
+	n, err := r.queries.CompleteDeviceCode(ctx, gen.CompleteDeviceCodeParams{ID: id, Now: now})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func genDeviceCodeToDomain(row *gen.DeviceCode) *domain.DeviceCode {
+	d := &domain.DeviceCode{
+		ID:                row.ID,
+		DeviceCodeHash:    row.DeviceCodeHash,
+		UserCode:          row.UserCode,
+		ClientName:        row.ClientName,
+		DeviceFingerprint: row.DeviceFingerprint,
+		IP:                row.Ip,
+		Status:            domain.Status(row.Status),
+		UserID:            row.UserID.String,
+		OrgID:             row.OrgID.String,
+		CreatedAt:         row.CreatedAt,
+		ExpiresAt:         row.ExpiresAt,
+	}
+	if row.DecidedAt.Valid {
+		t := row.DecidedAt.Time
+		d.DecidedAt = &t
+	}
+	return d
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/devicecode/domain"
+)
+
+// Repository persists device codes.
+type Repository interface {
+	// Create persists a new pending device code. The code must have ID set.
+	Create(ctx context.Context, d *domain.DeviceCode) error
+	// GetByDeviceCodeHash returns the device code with this hash, or nil if not found.
+	GetByDeviceCodeHash(ctx context.Context, hash string) (*domain.DeviceCode, error)
+	// GetPendingByUserCode returns the pending device code with this normalized user code that has not expired at
+	// now, or nil if there is none.
+	GetPendingByUserCode(ctx context.Context, userCode string, now time.Time) (*domain.DeviceCode, error)
+	// Decide approves or denies a pending device code for userID in orgID. It reports false, without changing
+	// anything, when the code is no longer pending or has expired at decidedAt.
+	Decide(ctx context.Context, id string, status domain.Status, userID, orgID string, decidedAt time.Time) (bool, error)
+	// Complete consumes an approved device code. It reports false when the code is not approved, was already used,
+	// or has expired at now.
+	Complete(ctx context.Context, id string, now time.Time) (bool, error)
+}
@@ -0,0 +1,97 @@
+// Package devicesharing detects device fingerprints shared by several users. Devices are unique per user, org and
+// fingerprint, so two people signing in on the same machine each get their own device record and their own trust.
+// This package counts, across users and orgs, who else has a device with the same fingerprint; AuthService
+// (internal/identity/service) passes the result to MFA policies as input.device.shared_device, and AdminService lists
+// the most widely shared fingerprints.
+package devicesharing
+
+import (
+	"context"
+	"sync/atomic"
+
+	"zero-trust-control-plane/backend/internal/breakglass"
+	"zero-trust-control-plane/backend/internal/devicesharing/domain"
+	"zero-trust-control-plane/backend/internal/devicesharing/repository"
+)
+
+// DefaultThreshold is the number of users from which a fingerprint is shared: two people on one machine.
+const DefaultThreshold = 2
+
+// MaxListLimit bounds the fingerprints returned by ListShared.
+const MaxListLimit = 500
+
+// placeholders are the fingerprints the server records when the client sent none (password logins, device codes,
+// break-glass sessions). Every user has them, so they say nothing about a shared machine.
+var placeholders = []string{"password-login", "device-code", breakglass.DeviceFingerprint}
+
+// IsPlaceholder reports whether fingerprint is one the server records for clients that sent none.
+func IsPlaceholder(fingerprint string) bool {
+	for _, p := range placeholders {
+		if fingerprint == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage is how widely a fingerprint is used when a user signs in with it to an org. Users and Orgs count the user and
+// org signing in, whether or not their device exists yet.
+type Usage struct {
+	Users  int
+	Orgs   int
+	Shared bool // Users reached the threshold
+}
+
+// Detector finds fingerprints shared by at least a threshold of users.
+type Detector struct {
+	repo      repository.Repository
+	threshold atomic.Int64
+}
+
+// NewDetector returns a detector backed by repo. A threshold below 2 is replaced by DefaultThreshold.
+func NewDetector(repo repository.Repository, threshold int) *Detector {
+	d := &Detector{repo: repo}
+	d.SetThreshold(threshold)
+	return d
+}
+
+// SetThreshold changes the number of users from which a fingerprint is shared (for config reload). A threshold below
+// 2 is replaced by DefaultThreshold.
+func (d *Detector) SetThreshold(threshold int) {
+	if threshold < 2 {
+		threshold = DefaultThreshold
+	}
+	d.threshold.Store(int64(threshold))
+}
+
+// Threshold returns the number of users from which a fingerprint is shared.
+func (d *Detector) Threshold() int {
+	return int(d.threshold.Load())
+}
+
+// Usage returns how many users and orgs have an unrevoked device of fingerprint, counting userID and orgID. Returns
+// the zero Usage for an empty or placeholder fingerprint.
+func (d *Detector) Usage(ctx context.Context, fingerprint, userID, orgID string) (Usage, error) {
+	if fingerprint == "" || IsPlaceholder(fingerprint) {
+		return Usage{}, nil
+	}
+	users, orgs, err := d.repo.CountOthers(ctx, fingerprint, userID, orgID)
+	if err != nil {
+		return Usage{}, err
+	}
+	u := Usage{Users: users + 1, Orgs: orgs + 1}
+	u.Shared = u.Users >= d.Threshold()
+	return u, nil
+}
+
+// ListShared returns up to limit fingerprints used by at least minUsers users (the threshold when minUsers is 0 or
+// less), most widely shared first. Placeholder fingerprints are never listed. limit is capped at MaxListLimit.
+func (d *Detector) ListShared(ctx context.Context, minUsers int, limit int32) ([]*domain.SharedFingerprint, error) {
+	if minUsers <= 0 {
+		minUsers = d.Threshold()
+	}
+	if limit <= 0 || limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	return d.repo.ListShared(ctx, minUsers, placeholders, limit)
+}
@@ -0,0 +1,120 @@
+package devicesharing
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/devicesharing/domain"
+)
+
+type device struct{ fingerprint, userID, orgID string }
+
+type memRepo struct {
+	devices []device
+}
+
+func (m *memRepo) CountOthers(ctx context.Context, fingerprint, userID, orgID string) (int, int, error) {
+	users, orgs := map[string]bool{}, map[string]bool{}
+	for _, d := range m.devices {
+		if d.fingerprint != fingerprint {
+			continue
+		}
+		if d.userID != userID {
+			users[d.userID] = true
+		}
+		if d.orgID != orgID {
+			orgs[d.orgID] = true
+		}
+	}
+	return len(users), len(orgs), nil
+}
+
+func (m *memRepo) ListShared(ctx context.Context, minUsers int, excluded []string, limit int32) ([]*domain.SharedFingerprint, error) {
+	skip := map[string]bool{}
+	for _, fp := range excluded {
+		skip[fp] = true
+	}
+	byFP := map[string]map[string]bool{}
+	for _, d := range m.devices {
+		if skip[d.fingerprint] {
+			continue
+		}
+		if byFP[d.fingerprint] == nil {
+			byFP[d.fingerprint] = map[string]bool{}
+		}
+		byFP[d.fingerprint][d.userID] = true
+	}
+	var out []*domain.SharedFingerprint
+	for fp, users := range byFP {
+		if len(users) >= minUsers {
+			out = append(out, &domain.SharedFingerprint{Fingerprint: fp, Users: len(users), LastSeenAt: time.Now()})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Users > out[j].Users })
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func TestDetector_Usage(t *testing.T) {
+	repo := &memRepo{devices: []device{
+		{"fp-kiosk", "u1", "org-a"}, {"fp-kiosk", "u2", "org-a"}, {"fp-kiosk", "u2", "org-b"},
+		{"fp-laptop", "u1", "org-a"}, {"fp-laptop", "u1", "org-b"},
+		{"password-login", "u1", "org-a"}, {"password-login", "u2", "org-a"},
+	}}
+	d := NewDetector(repo, 3)
+	ctx := context.Background()
+
+	u, err := d.Usage(ctx, "fp-kiosk", "u3", "org-a")
+	if err != nil {
+		t.Fatalf("Usage: %v", err)
+	}
+	if u != (Usage{Users: 3, Orgs: 2, Shared: true}) {
+		t.Errorf("kiosk for a new user = %+v, want 3 users, 2 orgs, shared", u)
+	}
+	if u, _ := d.Usage(ctx, "fp-kiosk", "u1", "org-a"); u != (Usage{Users: 2, Orgs: 2}) {
+		t.Errorf("kiosk for u1 = %+v, want 2 users, 2 orgs, not shared at threshold 3", u)
+	}
+	if u, _ := d.Usage(ctx, "fp-laptop", "u1", "org-b"); u.Users != 1 || u.Orgs != 2 || u.Shared {
+		t.Errorf("one user in two orgs = %+v, want 1 user, 2 orgs, not shared", u)
+	}
+	if u, _ := d.Usage(ctx, "password-login", "u3", "org-a"); u != (Usage{}) {
+		t.Errorf("placeholder fingerprint = %+v, want zero", u)
+	}
+
+	d.SetThreshold(0)
+	if d.Threshold() != DefaultThreshold {
+		t.Errorf("Threshold = %d, want default %d", d.Threshold(), DefaultThreshold)
+	}
+	if u, _ := d.Usage(ctx, "fp-kiosk", "u1", "org-a"); !u.Shared {
+		t.Error("two users must be shared at the default threshold")
+	}
+}
+
+func TestDetector_ListShared(t *testing.T) {
+	repo := &memRepo{devices: []device{
+		{"fp-kiosk", "u1", "org-a"}, {"fp-kiosk", "u2", "org-a"}, {"fp-kiosk", "u3", "org-b"},
+		{"fp-home", "u4", "org-a"}, {"fp-home", "u5", "org-a"},
+		{"fp-laptop", "u1", "org-a"},
+		{"device-code", "u1", "org-a"}, {"device-code", "u2", "org-a"}, {"break-glass", "u6", "org-a"}, {"break-glass", "u7", "org-a"},
+	}}
+	d := NewDetector(repo, 2)
+	ctx := context.Background()
+
+	list, err := d.ListShared(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ListShared: %v", err)
+	}
+	if len(list) != 2 || list[0].Fingerprint != "fp-kiosk" || list[1].Fingerprint != "fp-home" {
+		t.Fatalf("ListShared = %+v, want fp-kiosk then fp-home without placeholders", list)
+	}
+	if list, _ := d.ListShared(ctx, 3, 10); len(list) != 1 || list[0].Users != 3 {
+		t.Errorf("ListShared(minUsers 3) = %+v, want fp-kiosk only", list)
+	}
+	if list, _ := d.ListShared(ctx, 0, 1); len(list) != 1 {
+		t.Errorf("ListShared(limit 1) returned %d fingerprints", len(list))
+	}
+}
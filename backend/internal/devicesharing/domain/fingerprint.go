@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// SharedFingerprint is a device fingerprint registered by several users: one machine used by many people, or a
+// fingerprint that is not unique (e.g. a fixed value sent by a client build). Counts cover unrevoked devices only.
+type SharedFingerprint struct {
+	Fingerprint string
+	Users       int
+	Orgs        int
+	Devices     int
+	LastSeenAt  time.Time // latest last_seen_at (or created_at, for devices never seen since) of its devices
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/devicesharing/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a device sharing repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// CountOthers returns how many users other than userID and orgs other than orgID have an unrevoked device of the
+// fingerprint.
+func (r *PostgresRepository) CountOthers(ctx context.Context, fingerprint, userID, orgID string) (int, int, error) {
+	row, err := r.queries.GetFingerprintUsage(ctx, gen.GetFingerprintUsageParams{UserID: userID, OrgID: orgID, Fingerprint: fingerprint})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(row.OtherUsers), int(row.OtherOrgs), nil
+}
+
+// ListShared returns up to limit fingerprints with unrevoked devices of at least minUsers users, most widely shared
+// first. Fingerprints in excluded are skipped.
+func (r *PostgresRepository) ListShared(ctx context.Context, minUsers int, excluded []string, limit int32) ([]*domain.SharedFingerprint, error) {
+	if excluded == nil {
+		excluded = []string{}
+	}
+	rows, err := r.queries.ListSharedFingerprints(ctx, gen.ListSharedFingerprintsParams{Excluded: excluded, MinUsers: int64(minUsers), RowLimit: limit})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.SharedFingerprint, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.SharedFingerprint{
+			Fingerprint: row.Fingerprint,
+			Users:       int(row.Users),
+			Orgs:        int(row.Orgs),
+			Devices:     int(row.Devices),
+			LastSeenAt:  row.LastSeenAt.UTC(),
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/devicesharing/domain"
+)
+
+// Repository counts the users and orgs with unrevoked devices of a fingerprint.
+type Repository interface {
+	// CountOthers returns how many users other than userID and orgs other than orgID have an unrevoked device of the
+	// fingerprint.
+	CountOthers(ctx context.Context, fingerprint, userID, orgID string) (users, orgs int, err error)
+	// ListShared returns up to limit fingerprints with unrevoked devices of at least minUsers users, most widely shared
+	// first. Fingerprints in excluded are skipped.
+	ListShared(ctx context.Context, minUsers int, excluded []string, limit int32) ([]*domain.SharedFingerprint, error)
+}
@@ -0,0 +1,47 @@
+package elevation
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// Access-token claims set for users with an active elevation.
+const (
+	ClaimElevatedRole  = "elevated_role"  // always "admin"
+	ClaimElevatedUntil = "elevated_until" // Unix seconds when the elevation ends
+	ClaimElevationID   = "elevation_id"
+)
+
+// ClaimsProvider is a security.ClaimsProvider that marks access tokens of users with an active elevation, so
+// services reading the token can see the temporary admin rights. Tokens are re-issued on refresh, so an approval or
+// revocation shows in the claims from the next refresh on.
+type ClaimsProvider struct {
+	elevations ActiveGetter
+	now        func() time.Time
+}
+
+// NewClaimsProvider returns a provider reading active elevations from elevations.
+func NewClaimsProvider(elevations ActiveGetter) *ClaimsProvider {
+	return &ClaimsProvider{elevations: elevations, now: time.Now}
+}
+
+// AccessTokenClaims returns the elevation claims, or nil when the user has no active elevation in the org.
+func (p *ClaimsProvider) AccessTokenClaims(ctx context.Context, userID, orgID string) (*security.CustomClaims, error) {
+	if userID == "" || orgID == "" {
+		return nil, nil
+	}
+	e, err := p.elevations.GetActive(ctx, userID, orgID, p.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if e == nil || e.ExpiresAt == nil {
+		return nil, nil
+	}
+	return &security.CustomClaims{Claims: map[string]any{
+		ClaimElevatedRole:  "admin",
+		ClaimElevatedUntil: e.ExpiresAt.Unix(),
+		ClaimElevationID:   e.ID,
+	}}, nil
+}
@@ -0,0 +1,25 @@
+package elevation
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClaimsProvider(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	p := NewClaimsProvider(staticElevations{approved("e1", "user-1", until)})
+
+	cc, err := p.AccessTokenClaims(context.Background(), "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("AccessTokenClaims: %v", err)
+	}
+	if cc == nil || cc.Claims[ClaimElevatedRole] != "admin" || cc.Claims[ClaimElevatedUntil] != until.Unix() || cc.Claims[ClaimElevationID] != "e1" {
+		t.Errorf("claims = %+v", cc)
+	}
+	for _, ids := range [][2]string{{"user-2", "org-1"}, {"user-1", "org-2"}, {"user-1", ""}} {
+		if cc, err := p.AccessTokenClaims(context.Background(), ids[0], ids[1]); err != nil || cc != nil {
+			t.Errorf("%v: claims = %+v, err = %v, want nil", ids, cc, err)
+		}
+	}
+}
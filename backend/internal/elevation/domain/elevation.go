@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// Status is where an elevation is in its lifecycle. Only pending elevations can be approved or rejected, and only
+// approved ones revoked.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+	StatusRevoked  Status = "revoked"
+	// StatusExpired is set by the expiry job once an approved elevation's window has ended. Until then the elevation
+	// is stored as approved but no longer grants anything (see EffectiveStatus).
+	StatusExpired Status = "expired"
+)
+
+// MaxJustificationLength caps the requester's justification and the reviewer's comment.
+const MaxJustificationLength = 1000
+
+// Review is an org owner's decision on an elevation request.
+type Review struct {
+	By      string
+	Comment string
+	At      time.Time
+}
+
+// Elevation is a member's request for temporary org admin rights. Once an owner approves it, the member is treated
+// as an org admin until ExpiresAt, unless it is revoked first.
+type Elevation struct {
+	ID            string
+	OrgID         string
+	UserID        string // the member asking for admin rights
+	Status        Status
+	Justification string
+	Duration      time.Duration // requested window; starts at approval
+	Review        *Review       // nil while pending
+	ExpiresAt     *time.Time    // set on approval
+	RevokedBy     string
+	RevokedAt     *time.Time
+	CreatedAt     time.Time
+}
+
+// Active reports whether the elevation grants admin rights at now.
+func (e *Elevation) Active(now time.Time) bool {
+	return e.Status == StatusApproved && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+// EffectiveStatus is Status, except that an approved elevation whose window has ended is reported as expired even
+// before the expiry job has marked it.
+func (e *Elevation) EffectiveStatus(now time.Time) Status {
+	if e.Status == StatusApproved && !e.Active(now) {
+		return StatusExpired
+	}
+	return e.Status
+}
@@ -0,0 +1,60 @@
+package elevation
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/elevation/domain"
+)
+
+// EndedExpirer marks elevations whose window has ended as expired. Implemented by the elevation repository.
+type EndedExpirer interface {
+	ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Elevation, error)
+}
+
+// ExpiryJob marks ended elevations as expired and audits each as elevation_expired. Elevations stop granting admin
+// rights at expires_at whether or not the job has run; the job keeps the stored status and the audit log in step.
+type ExpiryJob struct {
+	repo        EndedExpirer
+	auditLogger audit.AuditLogger
+	now         func() time.Time
+}
+
+// NewExpiryJob returns an ExpiryJob. auditLogger may be nil.
+func NewExpiryJob(repo EndedExpirer, auditLogger audit.AuditLogger) *ExpiryJob {
+	return &ExpiryJob{repo: repo, auditLogger: auditLogger, now: time.Now}
+}
+
+// RunOnce expires every ended elevation and returns how many it expired.
+func (j *ExpiryJob) RunOnce(ctx context.Context) (int, error) {
+	ended, err := j.repo.ExpireEnded(ctx, j.now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	if j.auditLogger != nil {
+		for _, e := range ended {
+			meta, _ := json.Marshal(map[string]interface{}{"elevation_id": e.ID, "expires_at": e.ExpiresAt})
+			j.auditLogger.LogEvent(ctx, e.OrgID, e.UserID, "elevation_expired", "elevation", string(meta))
+		}
+	}
+	return len(ended), nil
+}
+
+// Run calls RunOnce on start and then every interval until ctx is cancelled.
+func (j *ExpiryJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := j.RunOnce(ctx); err != nil {
+			log.Printf("elevation: expiry run failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
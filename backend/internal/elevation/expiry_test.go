@@ -0,0 +1,41 @@
+package elevation
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type recordedEvent struct {
+	orgID, userID, action, resource string
+}
+
+type recordingAuditLogger struct {
+	events []recordedEvent
+}
+
+func (l *recordingAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	l.events = append(l.events, recordedEvent{orgID, userID, action, resource})
+}
+
+func TestExpiryJob(t *testing.T) {
+	now := time.Now()
+	elevations := staticElevations{
+		approved("e1", "user-1", now.Add(-time.Minute)),
+		approved("e2", "user-2", now.Add(time.Hour)),
+	}
+	audit := &recordingAuditLogger{}
+	job := NewExpiryJob(elevations, audit)
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("RunOnce = %d, %v; want 1", n, err)
+	}
+	want := recordedEvent{"org-1", "user-1", "elevation_expired", "elevation"}
+	if len(audit.events) != 1 || audit.events[0] != want {
+		t.Errorf("audit events = %+v, want [%+v]", audit.events, want)
+	}
+	if n, _ := job.RunOnce(context.Background()); n != 0 {
+		t.Errorf("second RunOnce expired %d, want 0", n)
+	}
+}
@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	elevationv1 "zero-trust-control-plane/backend/api/generated/elevation/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/elevation/domain"
+	"zero-trust-control-plane/backend/internal/elevation/repository"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// Server implements ElevationService (proto server).
+// Proto: elevation/elevation.proto → internal/elevation/handler.
+type Server struct {
+	elevationv1.UnimplementedElevationServiceServer
+	repo            repository.Repository
+	membershipRepo  rbac.OrgMembershipGetter
+	auditLogger     audit.AuditLogger
+	defaultDuration time.Duration
+	maxDuration     time.Duration
+	now             func() time.Time
+}
+
+// NewServer returns a new Elevation gRPC server. If repo or membershipRepo is nil, all RPCs return Unimplemented.
+// Requests without a duration get defaultDuration; longer requests than maxDuration are rejected. auditLogger may
+// be nil.
+func NewServer(repo repository.Repository, membershipRepo rbac.OrgMembershipGetter, auditLogger audit.AuditLogger, defaultDuration, maxDuration time.Duration) *Server {
+	return &Server{
+		repo:            repo,
+		membershipRepo:  membershipRepo,
+		auditLogger:     auditLogger,
+		defaultDuration: defaultDuration,
+		maxDuration:     maxDuration,
+		now:             time.Now,
+	}
+}
+
+// RequestElevation stores a pending request for temporary admin rights. The caller must be a member with role
+// member (owners and admins already have the rights) and have no pending or active elevation.
+func (s *Server) RequestElevation(ctx context.Context, req *elevationv1.RequestElevationRequest) (*elevationv1.RequestElevationResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RequestElevation not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, userID, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to resolve membership")
+	}
+	if m == nil || m.Role != membershipdomain.RoleMember {
+		return nil, status.Error(codes.FailedPrecondition, "only members without admin rights can request elevation")
+	}
+	justification, err := validateText("justification", req.GetJustification())
+	if err != nil {
+		return nil, err
+	}
+	if justification == "" {
+		return nil, status.Error(codes.InvalidArgument, "justification required")
+	}
+	duration := s.defaultDuration
+	if secs := req.GetDurationSeconds(); secs != 0 {
+		duration = time.Duration(secs) * time.Second
+		if secs < 0 || duration < time.Minute || duration > s.maxDuration {
+			return nil, status.Errorf(codes.InvalidArgument, "duration_seconds must be between 60 and %d", int64(s.maxDuration/time.Second))
+		}
+	}
+	now := s.now().UTC()
+	open, err := s.repo.HasOpen(ctx, userID, orgID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check existing elevations")
+	}
+	if open {
+		return nil, status.Error(codes.FailedPrecondition, "a pending or active elevation already exists")
+	}
+	e := &domain.Elevation{
+		ID:            uuid.New().String(),
+		OrgID:         orgID,
+		UserID:        userID,
+		Status:        domain.StatusPending,
+		Justification: justification,
+		Duration:      duration,
+		CreatedAt:     now,
+	}
+	if err := s.repo.Create(ctx, e); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create elevation")
+	}
+	s.record(ctx, e, "elevation_requested", userID, justification)
+	return &elevationv1.RequestElevationResponse{Elevation: s.elevationToProto(e)}, nil
+}
+
+// ListElevations returns the org's elevations, newest first. Org admins and owners see every elevation and may
+// filter by user; members see only their own.
+func (s *Server) ListElevations(ctx context.Context, req *elevationv1.ListElevationsRequest) (*elevationv1.ListElevationsResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListElevations not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	filterUser := req.GetUserId()
+	if _, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo); err != nil {
+		if status.Code(err) != codes.PermissionDenied {
+			return nil, err
+		}
+		if filterUser != "" && filterUser != userID {
+			return nil, status.Error(codes.PermissionDenied, "members can only list their own elevations")
+		}
+		filterUser = userID
+	}
+	filter := domain.Status(req.GetStatus())
+	switch filter {
+	case "", domain.StatusPending, domain.StatusApproved, domain.StatusRejected, domain.StatusRevoked, domain.StatusExpired:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be one of pending, approved, rejected, revoked, expired")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.repo.ListByOrg(ctx, orgID, filter, filterUser, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list elevations")
+	}
+	out := make([]*elevationv1.Elevation, len(list))
+	for i, e := range list {
+		out[i] = s.elevationToProto(e)
+	}
+	result := &elevationv1.ListElevationsResponse{
+		Elevations: out,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// ApproveElevation approves a pending request; the elevation window starts now. Caller must be an org owner.
+func (s *Server) ApproveElevation(ctx context.Context, req *elevationv1.ApproveElevationRequest) (*elevationv1.ApproveElevationResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ApproveElevation not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgOwner(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := validateText("comment", req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.load(ctx, orgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	review := &domain.Review{By: userID, Comment: comment, At: now}
+	expiresAt := now.Add(e.Duration)
+	ok, err := s.repo.Review(ctx, e.ID, domain.StatusApproved, review, &expiresAt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to approve elevation")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "elevation is not pending")
+	}
+	e.Status, e.Review, e.ExpiresAt = domain.StatusApproved, review, &expiresAt
+	s.record(ctx, e, "elevation_approved", userID, comment)
+	return &elevationv1.ApproveElevationResponse{Elevation: s.elevationToProto(e)}, nil
+}
+
+// RejectElevation rejects a pending request. Caller must be an org owner, or the requester (withdrawing it).
+func (s *Server) RejectElevation(ctx context.Context, req *elevationv1.RejectElevationRequest) (*elevationv1.RejectElevationResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RejectElevation not implemented")
+	}
+	e, userID, comment, err := s.loadForOwnerOrSubject(ctx, req.GetId(), req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	review := &domain.Review{By: userID, Comment: comment, At: s.now().UTC()}
+	ok, err := s.repo.Review(ctx, e.ID, domain.StatusRejected, review, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to reject elevation")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "elevation is not pending")
+	}
+	e.Status, e.Review = domain.StatusRejected, review
+	s.record(ctx, e, "elevation_rejected", userID, comment)
+	return &elevationv1.RejectElevationResponse{Elevation: s.elevationToProto(e)}, nil
+}
+
+// RevokeElevation ends an active elevation. Caller must be an org owner, or the elevated member (giving the rights
+// up early).
+func (s *Server) RevokeElevation(ctx context.Context, req *elevationv1.RevokeElevationRequest) (*elevationv1.RevokeElevationResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RevokeElevation not implemented")
+	}
+	e, userID, comment, err := s.loadForOwnerOrSubject(ctx, req.GetId(), req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	ok, err := s.repo.Revoke(ctx, e.ID, userID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke elevation")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "elevation is not active")
+	}
+	e.Status, e.RevokedBy, e.RevokedAt = domain.StatusRevoked, userID, &now
+	s.record(ctx, e, "elevation_revoked", userID, comment)
+	return &elevationv1.RevokeElevationResponse{Elevation: s.elevationToProto(e)}, nil
+}
+
+// loadForOwnerOrSubject authorizes an org owner or the elevation's own member and returns the elevation, the
+// caller and the validated comment. Other members get NotFound, so they cannot probe other users' elevations.
+func (s *Server) loadForOwnerOrSubject(ctx context.Context, id, comment string) (*domain.Elevation, string, string, error) {
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, "", "", err
+	}
+	comment, err = validateText("comment", comment)
+	if err != nil {
+		return nil, "", "", err
+	}
+	e, err := s.load(ctx, orgID, id)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if e.UserID != userID {
+		if _, _, err := rbac.RequireOrgOwner(ctx, s.membershipRepo); err != nil {
+			if status.Code(err) == codes.PermissionDenied {
+				return nil, "", "", status.Error(codes.NotFound, "elevation not found")
+			}
+			return nil, "", "", err
+		}
+	}
+	return e, userID, comment, nil
+}
+
+// load returns the elevation if it belongs to orgID; otherwise NotFound, so other orgs' IDs are not revealed.
+func (s *Server) load(ctx context.Context, orgID, id string) (*domain.Elevation, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	e, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load elevation")
+	}
+	if e == nil || e.OrgID != orgID {
+		return nil, status.Error(codes.NotFound, "elevation not found")
+	}
+	return e, nil
+}
+
+// record audits an elevation step under resource elevation, with the actor as user and the elevated member in
+// the metadata.
+func (s *Server) record(ctx context.Context, e *domain.Elevation, action, actor, comment string) {
+	if s.auditLogger == nil {
+		return
+	}
+	metadata := map[string]interface{}{
+		"elevation_id":     e.ID,
+		"user_id":          e.UserID,
+		"duration_seconds": int64(e.Duration / time.Second),
+	}
+	if comment != "" {
+		metadata["comment"] = comment
+	}
+	if e.ExpiresAt != nil {
+		metadata["expires_at"] = e.ExpiresAt
+	}
+	meta, _ := json.Marshal(metadata)
+	s.auditLogger.LogEvent(ctx, e.OrgID, actor, action, "elevation", string(meta))
+}
+
+func validateText(field, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > domain.MaxJustificationLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", field, domain.MaxJustificationLength)
+	}
+	return s, nil
+}
+
+// elevationToProto converts e, reporting approved elevations whose window has ended as expired.
+func (s *Server) elevationToProto(e *domain.Elevation) *elevationv1.Elevation {
+	out := &elevationv1.Elevation{
+		Id:              e.ID,
+		OrgId:           e.OrgID,
+		UserId:          e.UserID,
+		Status:          string(e.EffectiveStatus(s.now())),
+		Justification:   e.Justification,
+		DurationSeconds: int64(e.Duration / time.Second),
+		RevokedBy:       e.RevokedBy,
+		CreatedAt:       timestamppb.New(e.CreatedAt),
+	}
+	if r := e.Review; r != nil {
+		out.ReviewedBy = r.By
+		out.ReviewComment = r.Comment
+		out.ReviewedAt = timestamppb.New(r.At)
+	}
+	if e.ExpiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*e.ExpiresAt)
+	}
+	if e.RevokedAt != nil {
+		out.RevokedAt = timestamppb.New(*e.RevokedAt)
+	}
+	return out
+}
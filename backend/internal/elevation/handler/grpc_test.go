@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	elevationv1 "zero-trust-control-plane/backend/api/generated/elevation/v1"
+	"zero-trust-control-plane/backend/internal/elevation/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// memElevations implements repository.Repository in memory.
+type memElevations struct {
+	byID map[string]*domain.Elevation
+}
+
+func (m *memElevations) Create(ctx context.Context, e *domain.Elevation) error {
+	c := *e
+	m.byID[e.ID] = &c
+	return nil
+}
+
+func (m *memElevations) GetByID(ctx context.Context, id string) (*domain.Elevation, error) {
+	e, ok := m.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *e
+	return &c, nil
+}
+
+func (m *memElevations) GetActive(ctx context.Context, userID, orgID string, now time.Time) (*domain.Elevation, error) {
+	for _, e := range m.byID {
+		if e.UserID == userID && e.OrgID == orgID && e.Active(now) {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memElevations) HasOpen(ctx context.Context, userID, orgID string, now time.Time) (bool, error) {
+	for _, e := range m.byID {
+		if e.UserID == userID && e.OrgID == orgID && (e.Status == domain.StatusPending || e.Active(now)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memElevations) ListByOrg(ctx context.Context, orgID string, st domain.Status, userID string, limit, offset int32) ([]*domain.Elevation, error) {
+	var out []*domain.Elevation
+	for _, e := range m.byID {
+		if e.OrgID == orgID && (st == "" || e.Status == st) && (userID == "" || e.UserID == userID) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memElevations) Review(ctx context.Context, id string, to domain.Status, review *domain.Review, expiresAt *time.Time) (bool, error) {
+	e, ok := m.byID[id]
+	if !ok || e.Status != domain.StatusPending {
+		return false, nil
+	}
+	e.Status, e.Review, e.ExpiresAt = to, review, expiresAt
+	return true, nil
+}
+
+func (m *memElevations) Revoke(ctx context.Context, id, by string, at time.Time) (bool, error) {
+	e, ok := m.byID[id]
+	if !ok || !e.Active(at) {
+		return false, nil
+	}
+	e.Status, e.RevokedBy, e.RevokedAt = domain.StatusRevoked, by, &at
+	return true, nil
+}
+
+func (m *memElevations) ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Elevation, error) {
+	return nil, nil
+}
+
+// memMemberships implements rbac.OrgMembershipGetter.
+type memMemberships map[string]membershipdomain.Role // key: userID:orgID
+
+func (m memMemberships) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	role, ok := m[userID+":"+orgID]
+	if !ok {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+func testServer() (*Server, *memElevations) {
+	repo := &memElevations{byID: map[string]*domain.Elevation{}}
+	memberships := memMemberships{
+		"owner-1:org-1": membershipdomain.RoleOwner,
+		"admin-1:org-1": membershipdomain.RoleAdmin,
+		"user-1:org-1":  membershipdomain.RoleMember,
+		"user-2:org-1":  membershipdomain.RoleMember,
+		"owner-2:org-2": membershipdomain.RoleOwner,
+	}
+	return NewServer(repo, memberships, nil, time.Hour, 8*time.Hour), repo
+}
+
+func as(userID, orgID string) context.Context {
+	return interceptors.WithIdentity(context.Background(), userID, orgID, "session-1")
+}
+
+func TestElevationLifecycle(t *testing.T) {
+	srv, _ := testServer()
+	user, owner := as("user-1", "org-1"), as("owner-1", "org-1")
+
+	req, err := srv.RequestElevation(user, &elevationv1.RequestElevationRequest{Justification: " incident INC-42 "})
+	if err != nil {
+		t.Fatalf("RequestElevation: %v", err)
+	}
+	e := req.GetElevation()
+	if e.GetStatus() != "pending" || e.GetJustification() != "incident INC-42" || e.GetDurationSeconds() != 3600 {
+		t.Errorf("requested = %+v", e)
+	}
+	if _, err := srv.RequestElevation(user, &elevationv1.RequestElevationRequest{Justification: "again"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second request: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.ApproveElevation(as("admin-1", "org-1"), &elevationv1.ApproveElevationRequest{Id: e.GetId()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("admin approve: code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	approved, err := srv.ApproveElevation(owner, &elevationv1.ApproveElevationRequest{Id: e.GetId(), Comment: "ok"})
+	if err != nil {
+		t.Fatalf("ApproveElevation: %v", err)
+	}
+	if a := approved.GetElevation(); a.GetStatus() != "approved" || a.GetReviewedBy() != "owner-1" || a.GetExpiresAt() == nil {
+		t.Errorf("approved = %+v", a)
+	}
+	if _, err := srv.RejectElevation(owner, &elevationv1.RejectElevationRequest{Id: e.GetId()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("reject approved: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.RevokeElevation(as("user-2", "org-1"), &elevationv1.RevokeElevationRequest{Id: e.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("other member revoke: code = %v, want NotFound", status.Code(err))
+	}
+
+	revoked, err := srv.RevokeElevation(user, &elevationv1.RevokeElevationRequest{Id: e.GetId()})
+	if err != nil {
+		t.Fatalf("RevokeElevation: %v", err)
+	}
+	if r := revoked.GetElevation(); r.GetStatus() != "revoked" || r.GetRevokedBy() != "user-1" {
+		t.Errorf("revoked = %+v", r)
+	}
+	if _, err := srv.RevokeElevation(owner, &elevationv1.RevokeElevationRequest{Id: e.GetId()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second revoke: code = %v, want FailedPrecondition", status.Code(err))
+	}
+}
+
+func TestElevationReportsExpired(t *testing.T) {
+	srv, repo := testServer()
+	ended := time.Now().Add(-time.Minute)
+	repo.byID["e-1"] = &domain.Elevation{ID: "e-1", OrgID: "org-1", UserID: "user-1", Status: domain.StatusApproved, Duration: time.Hour, ExpiresAt: &ended}
+
+	list, err := srv.ListElevations(as("user-1", "org-1"), &elevationv1.ListElevationsRequest{})
+	if err != nil || len(list.GetElevations()) != 1 || list.GetElevations()[0].GetStatus() != "expired" {
+		t.Fatalf("ListElevations = %v, %v; want one expired", list, err)
+	}
+	if _, err := srv.RevokeElevation(as("owner-1", "org-1"), &elevationv1.RevokeElevationRequest{Id: "e-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("revoke expired: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.RequestElevation(as("user-1", "org-1"), &elevationv1.RequestElevationRequest{Justification: "again"}); err != nil {
+		t.Errorf("request after expiry: %v", err)
+	}
+}
+
+func TestListElevationsVisibility(t *testing.T) {
+	srv, repo := testServer()
+	repo.byID["e-1"] = &domain.Elevation{ID: "e-1", OrgID: "org-1", UserID: "user-1", Status: domain.StatusPending}
+	repo.byID["e-2"] = &domain.Elevation{ID: "e-2", OrgID: "org-1", UserID: "user-2", Status: domain.StatusRejected}
+	repo.byID["e-3"] = &domain.Elevation{ID: "e-3", OrgID: "org-2", UserID: "user-3", Status: domain.StatusPending}
+
+	list, err := srv.ListElevations(as("admin-1", "org-1"), &elevationv1.ListElevationsRequest{})
+	if err != nil || len(list.GetElevations()) != 2 {
+		t.Errorf("admin ListElevations = %v, %v; want 2", list, err)
+	}
+	list, err = srv.ListElevations(as("admin-1", "org-1"), &elevationv1.ListElevationsRequest{Status: "pending"})
+	if err != nil || len(list.GetElevations()) != 1 {
+		t.Errorf("admin ListElevations(pending) = %v, %v; want 1", list, err)
+	}
+	list, err = srv.ListElevations(as("user-2", "org-1"), &elevationv1.ListElevationsRequest{})
+	if err != nil || len(list.GetElevations()) != 1 || list.GetElevations()[0].GetId() != "e-2" {
+		t.Errorf("member ListElevations = %v, %v; want own only", list, err)
+	}
+	if _, err := srv.ListElevations(as("user-2", "org-1"), &elevationv1.ListElevationsRequest{UserId: "user-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member listing another user: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.ApproveElevation(as("owner-2", "org-2"), &elevationv1.ApproveElevationRequest{Id: "e-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("approve from another org: code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestRequestElevationValidation(t *testing.T) {
+	srv, _ := testServer()
+	user := as("user-1", "org-1")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *elevationv1.RequestElevationRequest
+		code codes.Code
+	}{
+		{"no justification", user, &elevationv1.RequestElevationRequest{Justification: " "}, codes.InvalidArgument},
+		{"too long", user, &elevationv1.RequestElevationRequest{Justification: "x", DurationSeconds: 9 * 3600}, codes.InvalidArgument},
+		{"too short", user, &elevationv1.RequestElevationRequest{Justification: "x", DurationSeconds: 30}, codes.InvalidArgument},
+		{"negative", user, &elevationv1.RequestElevationRequest{Justification: "x", DurationSeconds: -60}, codes.InvalidArgument},
+		{"already admin", as("admin-1", "org-1"), &elevationv1.RequestElevationRequest{Justification: "x"}, codes.FailedPrecondition},
+		{"not a member", as("user-3", "org-1"), &elevationv1.RequestElevationRequest{Justification: "x"}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := srv.RequestElevation(tt.ctx, tt.req); status.Code(err) != tt.code {
+				t.Errorf("code = %v, want %v", status.Code(err), tt.code)
+			}
+		})
+	}
+	if _, err := NewServer(nil, nil, nil, time.Hour, time.Hour).ListElevations(user, &elevationv1.ListElevationsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil repos: code = %v, want Unimplemented", status.Code(err))
+	}
+}
@@ -0,0 +1,50 @@
+package elevation
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/elevation/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+)
+
+// ActiveGetter returns a user's active elevation in an org, or nil. Implemented by the elevation repository.
+type ActiveGetter interface {
+	GetActive(ctx context.Context, userID, orgID string, now time.Time) (*domain.Elevation, error)
+}
+
+// MembershipRepository wraps a membership repository so that members with an active elevation are reported as org
+// admins by GetMembershipByUserAndOrg, which every role check goes through. The returned membership has
+// ElevatedUntil set; the stored role is unchanged, so ListMembershipsByOrg still lists them as members.
+type MembershipRepository struct {
+	membershiprepo.Repository
+	elevations ActiveGetter
+	now        func() time.Time
+}
+
+// NewMembershipRepository returns base with elevations applied.
+func NewMembershipRepository(base membershiprepo.Repository, elevations ActiveGetter) *MembershipRepository {
+	return &MembershipRepository{Repository: base, elevations: elevations, now: time.Now}
+}
+
+// GetMembershipByUserAndOrg returns the stored membership, with role admin while a member's elevation is active.
+// Owners and admins are returned as stored without looking up elevations.
+func (r *MembershipRepository) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	m, err := r.Repository.GetMembershipByUserAndOrg(ctx, userID, orgID)
+	if err != nil || m == nil || m.Role != membershipdomain.RoleMember {
+		return m, err
+	}
+	e, err := r.elevations.GetActive(ctx, userID, orgID, r.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if e == nil || e.ExpiresAt == nil {
+		return m, nil
+	}
+	elevated := *m
+	elevated.Role = membershipdomain.RoleAdmin
+	until := *e.ExpiresAt
+	elevated.ElevatedUntil = &until
+	return &elevated, nil
+}
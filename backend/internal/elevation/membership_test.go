@@ -0,0 +1,89 @@
+package elevation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/elevation/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+)
+
+// staticElevations implements ActiveGetter and EndedExpirer over a fixed list.
+type staticElevations []*domain.Elevation
+
+func (s staticElevations) GetActive(ctx context.Context, userID, orgID string, now time.Time) (*domain.Elevation, error) {
+	for _, e := range s {
+		if e.UserID == userID && e.OrgID == orgID && e.Active(now) {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s staticElevations) ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Elevation, error) {
+	var out []*domain.Elevation
+	for _, e := range s {
+		if e.Status == domain.StatusApproved && !e.Active(now) {
+			e.Status = domain.StatusExpired
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func approved(id, userID string, expiresAt time.Time) *domain.Elevation {
+	return &domain.Elevation{ID: id, OrgID: "org-1", UserID: userID, Status: domain.StatusApproved, ExpiresAt: &expiresAt}
+}
+
+// staticMemberships implements membershiprepo.Repository; only GetMembershipByUserAndOrg is used.
+type staticMemberships struct {
+	membershiprepo.Repository
+	roles map[string]membershipdomain.Role // key: userID
+}
+
+func (m staticMemberships) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	role, ok := m.roles[userID]
+	if !ok || orgID != "org-1" {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+func TestMembershipRepository(t *testing.T) {
+	now := time.Now()
+	repo := NewMembershipRepository(staticMemberships{roles: map[string]membershipdomain.Role{
+		"elevated": membershipdomain.RoleMember,
+		"ended":    membershipdomain.RoleMember,
+		"member":   membershipdomain.RoleMember,
+		"owner":    membershipdomain.RoleOwner,
+	}}, staticElevations{
+		approved("e1", "elevated", now.Add(time.Hour)),
+		approved("e2", "ended", now.Add(-time.Minute)),
+		approved("e3", "owner", now.Add(time.Hour)),
+	})
+
+	tests := []struct {
+		userID   string
+		role     membershipdomain.Role
+		elevated bool
+	}{
+		{"elevated", membershipdomain.RoleAdmin, true},
+		{"ended", membershipdomain.RoleMember, false},
+		{"member", membershipdomain.RoleMember, false},
+		{"owner", membershipdomain.RoleOwner, false},
+	}
+	for _, tt := range tests {
+		m, err := repo.GetMembershipByUserAndOrg(context.Background(), tt.userID, "org-1")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.userID, err)
+		}
+		if m.Role != tt.role || (m.ElevatedUntil != nil) != tt.elevated {
+			t.Errorf("%s: role = %s, elevated until %v; want %s, elevated %v", tt.userID, m.Role, m.ElevatedUntil, tt.role, tt.elevated)
+		}
+	}
+	if m, err := repo.GetMembershipByUserAndOrg(context.Background(), "elevated", "org-2"); err != nil || m != nil {
+		t.Errorf("other org = %v, %v; want nil", m, err)
+	}
+}
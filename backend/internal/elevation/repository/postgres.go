@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/elevation/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an elevation repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists a new elevation request.
+func (r *PostgresRepository) Create(ctx context.Context, e *domain.Elevation) error {
+	return r.queries.CreateElevation(ctx, gen.CreateElevationParams{
+		ID:            e.ID,
+		OrgID:         e.OrgID,
+		UserID:        e.UserID,
+		Status:        string(e.Status),
+		Justification: e.Justification,
+		DurationSecs:  int32(e.Duration / time.Second),
+		CreatedAt:     e.CreatedAt,
+	})
+}
+
+// GetByID returns the elevation, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Elevation, error) {
+	row, err := r.queries.GetElevation(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genElevationToDomain(&row), nil
+}
+
+// GetActive returns the user's active elevation in the org, or nil if none.
+func (r *PostgresRepository) GetActive(ctx context.Context, userID, orgID string, now time.Time) (*domain.Elevation, error) {
+	row, err := r.queries.GetActiveElevation(ctx, gen.GetActiveElevationParams{UserID: userID, OrgID: orgID, Now: now})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genElevationToDomain(&row), nil
+}
+
+// HasOpen reports whether the user has a pending or active elevation in the org.
+func (r *PostgresRepository) HasOpen(ctx context.Context, userID, orgID string, now time.Time) (bool, error) {
+	return r.queries.HasOpenElevation(ctx, gen.HasOpenElevationParams{UserID: userID, OrgID: orgID, Now: now})
+}
+
+// ListByOrg returns the org's elevations, newest first, optionally only those in status or of userID.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, status domain.Status, userID string, limit, offset int32) ([]*domain.Elevation, error) {
+	rows, err := r.queries.ListElevationsByOrg(ctx, gen.ListElevationsByOrgParams{
+		OrgID:        orgID,
+		Limit:        limit,
+		Offset:       offset,
+		FilterStatus: sql.NullString{String: string(status), Valid: status != ""},
+		FilterUserID: sql.NullString{String: userID, Valid: userID != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return genElevationsToDomain(rows), nil
+}
+
+// Review approves or rejects a pending elevation.
+func (r *PostgresRepository) Review(ctx context.Context, id string, to domain.Status, review *domain.Review, expiresAt *time.Time) (bool, error) {
+	arg := gen.ReviewElevationParams{
+		Status:        string(to),
+		ReviewedBy:    sql.NullString{String: review.By, Valid: true},
+		ReviewComment: review.Comment,
+		ReviewedAt:    sql.NullTime{Time: review.At, Valid: true},
+		ID:            id,
+	}
+	if expiresAt != nil {
+		arg.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+	n, err := r.queries.ReviewElevation(ctx, arg)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Revoke ends an active elevation.
+func (r *PostgresRepository) Revoke(ctx context.Context, id, by string, at time.Time) (bool, error) {
+	n, err := r.queries.RevokeElevation(ctx, gen.RevokeElevationParams{
+		RevokedBy: sql.NullString{String: by, Valid: true},
+		RevokedAt: sql.NullTime{Time: at, Valid: true},
+		ID:        id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ExpireEnded marks ended elevations as expired and returns them.
+func (r *PostgresRepository) ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Elevation, error) {
+	rows, err := r.queries.ExpireElevations(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	return genElevationsToDomain(rows), nil
+}
+
+func genElevationsToDomain(rows []gen.PrivilegeElevation) []*domain.Elevation {
+	out := make([]*domain.Elevation, len(rows))
+	for i := range rows {
+		out[i] = genElevationToDomain(&rows[i])
+	}
+	return out
+}
+
+func genElevationToDomain(row *gen.PrivilegeElevation) *domain.Elevation {
+	e := &domain.Elevation{
+		ID:            row.ID,
+		OrgID:         row.OrgID,
+		UserID:        row.UserID,
+		Status:        domain.Status(row.Status),
+		Justification: row.Justification,
+		Duration:      time.Duration(row.DurationSecs) * time.Second,
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.ReviewedBy.Valid {
+		e.Review = &domain.Review{By: row.ReviewedBy.String, Comment: row.ReviewComment, At: row.ReviewedAt.Time}
+	}
+	if row.ExpiresAt.Valid {
+		t := row.ExpiresAt.Time
+		e.ExpiresAt = &t
+	}
+	if row.RevokedBy.Valid {
+		e.RevokedBy = row.RevokedBy.String
+	}
+	if row.RevokedAt.Valid {
+		t := row.RevokedAt.Time
+		e.RevokedAt = &t
+	}
+	return e
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/elevation/domain"
+)
+
+// Repository persists privilege elevations.
+type Repository interface {
+	// Create persists a new pending elevation. The elevation must have ID set.
+	Create(ctx context.Context, e *domain.Elevation) error
+	// GetByID returns the elevation, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.Elevation, error)
+	// GetActive returns the user's elevation in the org that grants admin rights at now, or nil if none.
+	GetActive(ctx context.Context, userID, orgID string, now time.Time) (*domain.Elevation, error)
+	// HasOpen reports whether the user has a pending elevation, or one that is active at now, in the org.
+	HasOpen(ctx context.Context, userID, orgID string, now time.Time) (bool, error)
+	// ListByOrg returns the org's elevations, newest first. An empty status or userID matches every status or user.
+	ListByOrg(ctx context.Context, orgID string, status domain.Status, userID string, limit, offset int32) ([]*domain.Elevation, error)
+	// Review moves a pending elevation to approved (with expiresAt) or rejected. It reports false, without changing
+	// anything, when the elevation is no longer pending.
+	Review(ctx context.Context, id string, to domain.Status, review *domain.Review, expiresAt *time.Time) (bool, error)
+	// Revoke ends an active elevation at the given time. It reports false when the elevation is not active.
+	Revoke(ctx context.Context, id, by string, at time.Time) (bool, error)
+	// ExpireEnded marks approved elevations whose window ended by now as expired and returns them.
+	ExpireEnded(ctx context.Context, now time.Time) ([]*domain.Elevation, error)
+}
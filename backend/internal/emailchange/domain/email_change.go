@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// TokenPrefix starts every email change token, so leaked tokens are easy to recognise.
+const TokenPrefix = "ztcp_ec_"
+
+// Address is one of the two addresses of an email change.
+type Address string
+
+const (
+	AddressCurrent Address = "current" // the user's email when the change was started
+	AddressNew     Address = "new"     // the email the user asked to change to
+)
+
+// EmailChange is a request to change UserID's email from OldEmail to NewEmail. A confirmation link is sent to each
+// address and the email changes once both were opened. Only the hashes of the link tokens are stored.
+type EmailChange struct {
+	ID             string
+	UserID         string
+	OrgID          string // org of the session that started it; its SMTP server sends the links
+	OldEmail       string
+	NewEmail       string
+	OldTokenHash   string
+	NewTokenHash   string
+	IP             string // client IP of the request
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	OldConfirmedAt *time.Time
+	NewConfirmedAt *time.Time
+	CompletedAt    *time.Time
+}
+
+// Pending reports whether the change can still be confirmed at now: not completed and not expired.
+func (c *EmailChange) Pending(now time.Time) bool {
+	return c.CompletedAt == nil && now.Before(c.ExpiresAt)
+}
+
+// Confirmed reports whether the links to both addresses were opened.
+func (c *EmailChange) Confirmed() bool {
+	return c.OldConfirmedAt != nil && c.NewConfirmedAt != nil
+}
+
+// AddressOf returns the address whose link carries the token with this hash, or "" for neither.
+func (c *EmailChange) AddressOf(hash string) Address {
+	switch hash {
+	case c.OldTokenHash:
+		return AddressCurrent
+	case c.NewTokenHash:
+		return AddressNew
+	}
+	return ""
+}
+
+// NewToken returns a new random email change token and its hash.
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, security.HashToken(token), nil
+}
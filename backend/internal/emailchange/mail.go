@@ -0,0 +1,68 @@
+// Package emailchange emails the confirmation links of email changes. The changes themselves are started and
+// confirmed by AuthService (internal/identity/service).
+package emailchange
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/emailchange/domain"
+	"zero-trust-control-plane/backend/internal/notification"
+)
+
+// Message is the confirmation link for one address of an email change.
+type Message struct {
+	OrgID     string // org of the session that started the change; the email is sent through its SMTP server when it has one
+	To        string
+	Address   domain.Address // which address To is
+	NewEmail  string
+	Link      string
+	ExpiresAt time.Time
+	IP        string // client IP of the request, so users can tell changes they did not start
+}
+
+// EmailMailer sends email change links by email.
+type EmailMailer struct {
+	sender notification.EmailSender
+}
+
+// NewEmailMailer returns a mailer that sends through sender.
+func NewEmailMailer(sender notification.EmailSender) *EmailMailer {
+	return &EmailMailer{sender: sender}
+}
+
+// Send emails msg in the background. Failures are logged and not retried; the user can start the change again.
+func (m *EmailMailer) Send(ctx context.Context, msg Message) {
+	subject, body := FormatEmail(msg)
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := notification.SendOrgEmail(ctx, m.sender, msg.OrgID, msg.To, subject, body); err != nil {
+			log.Printf("emailchange: confirmation email failed: %v", err)
+		}
+	}()
+}
+
+// FormatEmail returns the subject and plain-text body of the confirmation email for msg.
+func FormatEmail(msg Message) (subject, body string) {
+	var b strings.Builder
+	if msg.Address == domain.AddressCurrent {
+		subject = "Confirm the change of your email address"
+		fmt.Fprintf(&b, "Someone asked to change the email address of your account to %s.\n\n", msg.NewEmail)
+		b.WriteString("Open this link to confirm the change:\n\n")
+	} else {
+		subject = "Confirm your new email address"
+		b.WriteString("Open this link to confirm this address as the new email address of your account:\n\n")
+	}
+	fmt.Fprintf(&b, "%s\n\n", msg.Link)
+	fmt.Fprintf(&b, "The link expires at %s. The email address changes only once the links sent to both the current and the new address are opened, and every session is then signed out.\n", msg.ExpiresAt.UTC().Format(time.RFC3339))
+	if msg.IP != "" {
+		fmt.Fprintf(&b, "Requested from: %s\n", msg.IP)
+	}
+	if msg.Address == domain.AddressCurrent {
+		b.WriteString("If you did not ask for this, do not open the link and change your password; your email address stays as it is.\n")
+	}
+	return subject, b.String()
+}
@@ -0,0 +1,49 @@
+package emailchange
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/emailchange/domain"
+)
+
+type recordingEmail struct {
+	sent chan string
+}
+
+func (r *recordingEmail) SendEmail(to, subject, body string) error {
+	r.sent <- to + "|" + subject + "|" + body
+	return nil
+}
+
+func TestEmailMailer(t *testing.T) {
+	email := &recordingEmail{sent: make(chan string, 1)}
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	NewEmailMailer(email).Send(context.Background(), Message{
+		To: "old@example.com", Address: domain.AddressCurrent, NewEmail: "new@example.com",
+		Link: "https://app.example.com/email-change?token=ztcp_ec_abc", ExpiresAt: expires, IP: "203.0.113.7",
+	})
+	select {
+	case got := <-email.sent:
+		parts := strings.SplitN(got, "|", 3)
+		if parts[0] != "old@example.com" || parts[1] != "Confirm the change of your email address" {
+			t.Errorf("sent to %q with subject %q", parts[0], parts[1])
+		}
+		for _, want := range []string{"new@example.com", "https://app.example.com/email-change?token=ztcp_ec_abc", "2026-01-02T03:04:05Z", "Requested from: 203.0.113.7", "If you did not ask"} {
+			if !strings.Contains(parts[2], want) {
+				t.Errorf("body %q does not contain %q", parts[2], want)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("email not sent")
+	}
+}
+
+func TestFormatEmail_NewAddress(t *testing.T) {
+	subject, body := FormatEmail(Message{To: "new@example.com", Address: domain.AddressNew, NewEmail: "new@example.com", Link: "https://app.example.com/email-change?token=ztcp_ec_def"})
+	if subject != "Confirm your new email address" || !strings.Contains(body, "ztcp_ec_def") || strings.Contains(body, "If you did not ask") {
+		t.Errorf("subject %q, body %q", subject, body)
+	}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/emailchange/domain"
+	"zero-trust-control-plane/backend/internal/pii"
+	piidomain "zero-trust-control-plane/backend/internal/pii/domain"
+)
+
+// Encrypted columns; the column name is authenticated with each value.
+const (
+	columnOldEmail = "email_changes.old_email"
+	columnNewEmail = "email_changes.new_email"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	keyring *pii.Keyring
+}
+
+// NewPostgresRepository returns an email change repository that uses the given db for persistence. Both emails are
+// encrypted with the platform data key of keyring, like users.email; with a nil keyring they are stored in plaintext.
+func NewPostgresRepository(db *sql.DB, keyring *pii.Keyring) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db), keyring: keyring}
+}
+
+// Create persists a new email change and deletes the user's unfinished ones in one transaction.
+func (r *PostgresRepository) Create(ctx context.Context, c *domain.EmailChange) error {
+	oldEmail, err := r.keyring.Encrypt(ctx, piidomain.ScopePlatform, columnOldEmail, c.OldEmail)
+	if err != nil {
+		return err
+	}
+	newEmail, err := r.keyring.Encrypt(ctx, piidomain.ScopePlatform, columnNewEmail, c.NewEmail)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	if err := q.DeleteUnfinishedEmailChangesByUser(ctx, c.UserID); err != nil {
+		return err
+	}
+	if err := q.CreateEmailChange(ctx, gen.CreateEmailChangeParams{
+		ID:           c.ID,
+		UserID:       c.UserID,
+		OrgID:        c.OrgID,
+		OldEmail:     oldEmail,
+		NewEmail:     newEmail,
+		OldTokenHash: c.OldTokenHash,
+		NewTokenHash: c.NewTokenHash,
+		Ip:           c.IP,
+		CreatedAt:    c.CreatedAt,
+		ExpiresAt:    c.ExpiresAt,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetByTokenHash returns the email change, or nil if not found.
+func (r *PostgresRepository) GetByTokenHash(ctx context.Context, hash string) (*domain.EmailChange, error) {
+	row, err := r.queries.GetEmailChangeByTokenHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.toDomain(ctx, &row)
+}
+
+// Confirm records that the link to addr was opened, if the change is unfinished and unexpired.
+func (r *PostgresRepository) Confirm(ctx context.Context, id string, addr domain.Address, now time.Time) (*domain.EmailChange, error) {
+	var row gen.EmailChange
+	var err error
+	switch addr {
+	case domain.AddressCurrent:
+		row, err = r.queries.ConfirmEmailChangeOld(ctx, gen.ConfirmEmailChangeOldParams{Now: now, ID: id})
+	case domain.AddressNew:
+		row, err = r.queries.ConfirmEmailChangeNew(ctx, gen.ConfirmEmailChangeNewParams{Now: now, ID: id})
+	default:
+		return nil, errors.New("emailchange: unknown address " + string(addr))
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.toDomain(ctx, &row)
+}
+
+// Complete marks an unfinished email change completed.
+func (r *PostgresRepository) Complete(ctx context.Context, id string, now time.Time) (bool, error) {
+	n, err := r.queries.CompleteEmailChange(ctx, gen.CompleteEmailChangeParams{Now: now, ID: id})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// toDomain decrypts both emails and converts the row to the domain type.
+func (r *PostgresRepository) toDomain(ctx context.Context, row *gen.EmailChange) (*domain.EmailChange, error) {
+	oldEmail, err := r.keyring.Decrypt(ctx, columnOldEmail, row.OldEmail)
+	if err != nil {
+		return nil, err
+	}
+	newEmail, err := r.keyring.Decrypt(ctx, columnNewEmail, row.NewEmail)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EmailChange{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		OrgID:          row.OrgID,
+		OldEmail:       oldEmail,
+		NewEmail:       newEmail,
+		OldTokenHash:   row.OldTokenHash,
+		NewTokenHash:   row.NewTokenHash,
+		IP:             row.Ip,
+		CreatedAt:      row.CreatedAt,
+		ExpiresAt:      row.ExpiresAt,
+		OldConfirmedAt: timePtr(row.OldConfirmedAt),
+		NewConfirmedAt: timePtr(row.NewConfirmedAt),
+		CompletedAt:    timePtr(row.CompletedAt),
+	}, nil
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
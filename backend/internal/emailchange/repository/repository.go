@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/emailchange/domain"
+)
+
+// Repository persists email changes.
+type Repository interface {
+	// Create persists a new email change and deletes the user's unfinished ones, so only the latest links work. The
+	// change must have ID set.
+	Create(ctx context.Context, c *domain.EmailChange) error
+	// GetByTokenHash returns the email change whose link to either address has this token hash, or nil if not found.
+	GetByTokenHash(ctx context.Context, hash string) (*domain.EmailChange, error)
+	// Confirm records that the link to addr was opened at now and returns the change. It returns nil, without
+	// changing anything, when the change was completed or has expired at now. Confirming an address twice keeps the
+	// first time.
+	Confirm(ctx context.Context, id string, addr domain.Address, now time.Time) (*domain.EmailChange, error)
+	// Complete marks the change completed at now. It reports false when it already was, so a change completes at most
+	// once even under concurrent requests.
+	Complete(ctx context.Context, id string, now time.Time) (bool, error)
+}
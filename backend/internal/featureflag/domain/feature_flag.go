@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// MaxKeyLength caps flag keys.
+const MaxKeyLength = 64
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// IsValidKey reports whether key is a well-formed flag key (e.g. auth.refresh_pop).
+func IsValidKey(key string) bool {
+	return len(key) <= MaxKeyLength && keyPattern.MatchString(key)
+}
+
+// Flag gates a feature during rollout. Orgs without an override get the flag when Enabled is true and their
+// bucket (0-99) is below RolloutPercentage.
+type Flag struct {
+	Key               string
+	Description       string
+	Enabled           bool
+	RolloutPercentage int // 0-100
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// OrgOverride forces a flag on or off for one org, regardless of Enabled and RolloutPercentage.
+type OrgOverride struct {
+	FlagKey   string
+	OrgID     string
+	Enabled   bool
+	UpdatedAt time.Time
+}
@@ -0,0 +1,137 @@
+package featureflag
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/featureflag/domain"
+)
+
+// DefaultCacheTTL is how long an Evaluator serves flags from memory before reloading them.
+const DefaultCacheTTL = 30 * time.Second
+
+// Source loads all flags and overrides (e.g. the feature flag repository).
+type Source interface {
+	ListFlags(ctx context.Context) ([]*domain.Flag, error)
+	ListOrgOverrides(ctx context.Context) ([]*domain.OrgOverride, error)
+}
+
+// Evaluator answers "is this flag on for this org" from an in-memory copy of the flags, reloaded from the source
+// at most every ttl. Changes made on another instance therefore apply within ttl. A nil *Evaluator serves
+// defaults. Safe for concurrent use.
+type Evaluator struct {
+	source Source
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	flags    map[string]*domain.Flag
+	override map[string]map[string]bool // flag key → org ID → enabled
+	loadedAt time.Time
+}
+
+// NewEvaluator returns an Evaluator over source. ttl <= 0 uses DefaultCacheTTL.
+func NewEvaluator(source Source, ttl time.Duration) *Evaluator {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Evaluator{source: source, ttl: ttl, now: time.Now}
+}
+
+// Enabled reports whether key is on for orgID: the org's override if any; otherwise, for a stored flag, whether it
+// is enabled and the org's bucket is within the rollout percentage; otherwise the flag's Default.
+func (e *Evaluator) Enabled(ctx context.Context, key, orgID string) bool {
+	if e == nil {
+		return Default(key)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.refreshLocked(ctx)
+	return e.evaluateLocked(key, orgID)
+}
+
+// EvaluateAll returns the value of every known and stored flag for orgID.
+func (e *Evaluator) EvaluateAll(ctx context.Context, orgID string) map[string]bool {
+	out := make(map[string]bool)
+	for _, k := range KnownKeys() {
+		out[k] = Default(k)
+	}
+	if e == nil {
+		return out
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.refreshLocked(ctx)
+	for k := range e.flags {
+		out[k] = false
+	}
+	for k := range out {
+		out[k] = e.evaluateLocked(k, orgID)
+	}
+	return out
+}
+
+// Invalidate drops the cached flags so the next evaluation reloads them. Called after flags are changed through
+// this instance.
+func (e *Evaluator) Invalidate() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.loadedAt = time.Time{}
+}
+
+func (e *Evaluator) refreshLocked(ctx context.Context) {
+	now := e.now()
+	if !e.loadedAt.IsZero() && now.Sub(e.loadedAt) < e.ttl {
+		return
+	}
+	// Retry no sooner than ttl even on failure, so a database outage does not add a query to every sign-in.
+	e.loadedAt = now
+	flags, err := e.source.ListFlags(ctx)
+	if err != nil {
+		log.Printf("featureflag: load flags: %v", err)
+		return
+	}
+	overrides, err := e.source.ListOrgOverrides(ctx)
+	if err != nil {
+		log.Printf("featureflag: load overrides: %v", err)
+		return
+	}
+	e.flags = make(map[string]*domain.Flag, len(flags))
+	for _, f := range flags {
+		e.flags[f.Key] = f
+	}
+	e.override = make(map[string]map[string]bool)
+	for _, o := range overrides {
+		if e.override[o.FlagKey] == nil {
+			e.override[o.FlagKey] = make(map[string]bool)
+		}
+		e.override[o.FlagKey][o.OrgID] = o.Enabled
+	}
+}
+
+func (e *Evaluator) evaluateLocked(key, orgID string) bool {
+	if on, ok := e.override[key][orgID]; ok {
+		return on
+	}
+	f, ok := e.flags[key]
+	if !ok {
+		return Default(key)
+	}
+	return f.Enabled && Bucket(key, orgID) < f.RolloutPercentage
+}
+
+// Bucket maps (key, orgID) to a stable bucket in [0, 100). Hashing the key with the org means each flag rolls out
+// to a different subset of orgs, and raising the percentage only adds orgs.
+func Bucket(key, orgID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(orgID))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,144 @@
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/featureflag/domain"
+)
+
+type fakeSource struct {
+	flags     []*domain.Flag
+	overrides []*domain.OrgOverride
+	err       error
+	loads     int
+}
+
+func (s *fakeSource) ListFlags(ctx context.Context) ([]*domain.Flag, error) {
+	s.loads++
+	return s.flags, s.err
+}
+
+func (s *fakeSource) ListOrgOverrides(ctx context.Context) ([]*domain.OrgOverride, error) {
+	return s.overrides, s.err
+}
+
+func TestEvaluator_Enabled(t *testing.T) {
+	src := &fakeSource{
+		flags: []*domain.Flag{
+			{Key: "all", Enabled: true, RolloutPercentage: 100},
+			{Key: "none", Enabled: true, RolloutPercentage: 0},
+			{Key: "killed", Enabled: false, RolloutPercentage: 100},
+			{Key: TokenExchange, Enabled: false},
+		},
+		overrides: []*domain.OrgOverride{
+			{FlagKey: "none", OrgID: "org-beta", Enabled: true},
+			{FlagKey: "all", OrgID: "org-opt-out", Enabled: false},
+		},
+	}
+	e := NewEvaluator(src, time.Minute)
+	ctx := context.Background()
+	tests := []struct {
+		key, org string
+		want     bool
+	}{
+		{"all", "org-1", true},
+		{"all", "org-opt-out", false},
+		{"none", "org-1", false},
+		{"none", "org-beta", true},
+		{"killed", "org-1", false},
+		{"unknown", "org-1", false},
+		{RefreshPoP, "org-1", true},     // not stored: default
+		{TokenExchange, "org-1", false}, // stored flag wins over default
+	}
+	for _, tt := range tests {
+		if got := e.Enabled(ctx, tt.key, tt.org); got != tt.want {
+			t.Errorf("Enabled(%q, %q) = %v, want %v", tt.key, tt.org, got, tt.want)
+		}
+	}
+	if src.loads != 1 {
+		t.Errorf("loads = %d, want 1 (cached)", src.loads)
+	}
+}
+
+func TestEvaluator_RolloutPercentage(t *testing.T) {
+	src := &fakeSource{flags: []*domain.Flag{{Key: "half", Enabled: true, RolloutPercentage: 50}}}
+	e := NewEvaluator(src, time.Minute)
+	on := 0
+	for i := 0; i < 1000; i++ {
+		org := fmt.Sprintf("org-%d", i)
+		got := e.Enabled(context.Background(), "half", org)
+		if got != (Bucket("half", org) < 50) {
+			t.Fatalf("Enabled(half, %s) = %v, inconsistent with bucket %d", org, got, Bucket("half", org))
+		}
+		if got {
+			on++
+		}
+	}
+	if on < 400 || on > 600 {
+		t.Errorf("%d of 1000 orgs enabled at 50%%, want about 500", on)
+	}
+}
+
+func TestEvaluator_CacheAndInvalidate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	src := &fakeSource{flags: []*domain.Flag{{Key: "f", Enabled: true, RolloutPercentage: 100}}}
+	e := NewEvaluator(src, time.Minute)
+	e.now = func() time.Time { return now }
+	ctx := context.Background()
+	if !e.Enabled(ctx, "f", "org-1") {
+		t.Fatal("flag should be on")
+	}
+	src.flags[0] = &domain.Flag{Key: "f", Enabled: false}
+	if !e.Enabled(ctx, "f", "org-1") {
+		t.Error("cached flag should still be on within ttl")
+	}
+	e.Invalidate()
+	if e.Enabled(ctx, "f", "org-1") {
+		t.Error("flag should be off after Invalidate")
+	}
+	src.flags[0] = &domain.Flag{Key: "f", Enabled: true, RolloutPercentage: 100}
+	now = now.Add(2 * time.Minute)
+	if !e.Enabled(ctx, "f", "org-1") {
+		t.Error("flag should be reloaded after ttl")
+	}
+}
+
+func TestEvaluator_LoadErrorKeepsPrevious(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	src := &fakeSource{flags: []*domain.Flag{{Key: RefreshPoP, Enabled: false}}}
+	e := NewEvaluator(src, time.Minute)
+	e.now = func() time.Time { return now }
+	ctx := context.Background()
+	if e.Enabled(ctx, RefreshPoP, "org-1") {
+		t.Fatal("stored flag should be off")
+	}
+	src.err = errors.New("db down")
+	now = now.Add(2 * time.Minute)
+	if e.Enabled(ctx, RefreshPoP, "org-1") {
+		t.Error("failed reload should keep the previous flags")
+	}
+}
+
+func TestEvaluator_Nil(t *testing.T) {
+	var e *Evaluator
+	if !e.Enabled(context.Background(), RefreshPoP, "org-1") {
+		t.Error("nil evaluator should serve defaults")
+	}
+	e.Invalidate()
+	all := e.EvaluateAll(context.Background(), "org-1")
+	if len(all) != len(KnownKeys()) || !all[TokenExchange] {
+		t.Errorf("EvaluateAll = %v, want known defaults", all)
+	}
+}
+
+func TestEvaluator_EvaluateAll(t *testing.T) {
+	src := &fakeSource{flags: []*domain.Flag{{Key: "new_mfa", Enabled: true, RolloutPercentage: 100}}}
+	all := NewEvaluator(src, time.Minute).EvaluateAll(context.Background(), "org-1")
+	if !all["new_mfa"] || !all[RefreshPoP] || !all[TokenExchange] {
+		t.Errorf("EvaluateAll = %v", all)
+	}
+}
@@ -0,0 +1,34 @@
+// Package featureflag evaluates per-org feature flags for gradual rollouts (FeatureFlagService).
+package featureflag
+
+import "sort"
+
+// Flags checked by the backend. A flag with no row in feature_flags evaluates to its default here, so shipping
+// a flag does not change behavior until a platform admin creates it.
+const (
+	// RefreshPoP binds new sessions to the client key sent at sign-in (refresh proof-of-possession).
+	// When off, the key is ignored and sessions use bearer refresh tokens.
+	RefreshPoP = "auth.refresh_pop"
+	// TokenExchange allows TokenExchange to issue resource tokens for the org.
+	TokenExchange = "auth.token_exchange"
+)
+
+var defaults = map[string]bool{
+	RefreshPoP:    true,
+	TokenExchange: true,
+}
+
+// Default returns the value of key when it has no row in feature_flags. Unknown keys default to off.
+func Default(key string) bool {
+	return defaults[key]
+}
+
+// KnownKeys returns the keys of the flags checked by the backend, sorted.
+func KnownKeys() []string {
+	keys := make([]string, 0, len(defaults))
+	for k := range defaults {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	featureflagv1 "zero-trust-control-plane/backend/api/generated/featureflag/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/featureflag"
+	"zero-trust-control-plane/backend/internal/featureflag/domain"
+	"zero-trust-control-plane/backend/internal/featureflag/repository"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// maxEvaluateKeys caps EvaluateFeatureFlags requests.
+const maxEvaluateKeys = 100
+
+// OrgGetter resolves orgs so overrides are only set for orgs that exist.
+type OrgGetter interface {
+	GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error)
+}
+
+// Server implements FeatureFlagService (proto server).
+// Proto: featureflag/featureflag.proto → internal/featureflag/handler.
+type Server struct {
+	featureflagv1.UnimplementedFeatureFlagServiceServer
+	repo           repository.Repository
+	evaluator      *featureflag.Evaluator
+	membershipRepo rbac.OrgMembershipGetter
+	admins         rbac.PlatformAdminChecker
+	orgs           OrgGetter
+	auditLogger    audit.AuditLogger
+}
+
+// NewServer returns a new FeatureFlag gRPC server. If repo is nil, all RPCs return Unimplemented.
+// Management RPCs require a platform admin (admins); EvaluateFeatureFlags requires an org member. evaluator is
+// invalidated after each change so this instance applies it immediately; orgs and auditLogger may be nil.
+func NewServer(repo repository.Repository, evaluator *featureflag.Evaluator, membershipRepo rbac.OrgMembershipGetter, admins rbac.PlatformAdminChecker, orgs OrgGetter, auditLogger audit.AuditLogger) *Server {
+	return &Server{
+		repo:           repo,
+		evaluator:      evaluator,
+		membershipRepo: membershipRepo,
+		admins:         admins,
+		orgs:           orgs,
+		auditLogger:    auditLogger,
+	}
+}
+
+// ListFeatureFlags returns all stored flags with their org overrides. Platform admin only.
+func (s *Server) ListFeatureFlags(ctx context.Context, req *featureflagv1.ListFeatureFlagsRequest) (*featureflagv1.ListFeatureFlagsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListFeatureFlags not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.admins); err != nil {
+		return nil, err
+	}
+	flags, err := s.repo.ListFlags(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list feature flags")
+	}
+	overrides, err := s.repo.ListOrgOverrides(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list feature flag overrides")
+	}
+	byFlag := make(map[string][]*domain.OrgOverride)
+	for _, o := range overrides {
+		byFlag[o.FlagKey] = append(byFlag[o.FlagKey], o)
+	}
+	out := make([]*featureflagv1.FeatureFlag, len(flags))
+	for i, f := range flags {
+		out[i] = flagToProto(f, byFlag[f.Key])
+	}
+	return &featureflagv1.ListFeatureFlagsResponse{Flags: out}, nil
+}
+
+// UpsertFeatureFlag creates a flag or replaces its settings; overrides are kept. Platform admin only.
+func (s *Server) UpsertFeatureFlag(ctx context.Context, req *featureflagv1.UpsertFeatureFlagRequest) (*featureflagv1.UpsertFeatureFlagResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method UpsertFeatureFlag not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsValidKey(req.GetKey()) {
+		return nil, status.Errorf(codes.InvalidArgument, "key must be 1-%d lowercase letters, digits, '.', '_' or '-'", domain.MaxKeyLength)
+	}
+	if p := req.GetRolloutPercentage(); p < 0 || p > 100 {
+		return nil, status.Error(codes.InvalidArgument, "rollout_percentage must be between 0 and 100")
+	}
+	f, err := s.repo.UpsertFlag(ctx, &domain.Flag{
+		Key:               req.GetKey(),
+		Description:       req.GetDescription(),
+		Enabled:           req.GetEnabled(),
+		RolloutPercentage: int(req.GetRolloutPercentage()),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to save feature flag")
+	}
+	s.evaluator.Invalidate()
+	s.audit(ctx, userID, "feature_flag_updated", map[string]interface{}{
+		"key": f.Key, "enabled": f.Enabled, "rollout_percentage": f.RolloutPercentage,
+	})
+	return &featureflagv1.UpsertFeatureFlagResponse{Flag: flagToProto(f, nil)}, nil
+}
+
+// DeleteFeatureFlag deletes a flag and its overrides; the flag reverts to its built-in default. Platform admin only.
+func (s *Server) DeleteFeatureFlag(ctx context.Context, req *featureflagv1.DeleteFeatureFlagRequest) (*featureflagv1.DeleteFeatureFlagResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method DeleteFeatureFlag not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+	deleted, err := s.repo.DeleteFlag(ctx, req.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete feature flag")
+	}
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "feature flag not found")
+	}
+	s.evaluator.Invalidate()
+	s.audit(ctx, userID, "feature_flag_deleted", map[string]interface{}{"key": req.GetKey()})
+	return &featureflagv1.DeleteFeatureFlagResponse{}, nil
+}
+
+// SetOrgOverride forces a stored flag on or off for one org. Platform admin only.
+func (s *Server) SetOrgOverride(ctx context.Context, req *featureflagv1.SetOrgOverrideRequest) (*featureflagv1.SetOrgOverrideResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetOrgOverride not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetKey() == "" || req.GetOrgId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key and org_id are required")
+	}
+	f, err := s.repo.GetFlag(ctx, req.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load feature flag")
+	}
+	if f == nil {
+		return nil, status.Error(codes.NotFound, "feature flag not found")
+	}
+	if s.orgs != nil {
+		org, err := s.orgs.GetOrganizationByID(ctx, req.GetOrgId())
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to load organization")
+		}
+		if org == nil {
+			return nil, status.Error(codes.NotFound, "organization not found")
+		}
+	}
+	if err := s.repo.SetOrgOverride(ctx, &domain.OrgOverride{FlagKey: f.Key, OrgID: req.GetOrgId(), Enabled: req.GetEnabled()}); err != nil {
+		return nil, status.Error(codes.Internal, "failed to save feature flag override")
+	}
+	s.evaluator.Invalidate()
+	s.audit(ctx, userID, "feature_flag_override_set", map[string]interface{}{"key": f.Key, "org_id": req.GetOrgId(), "enabled": req.GetEnabled()})
+	return &featureflagv1.SetOrgOverrideResponse{}, nil
+}
+
+// ClearOrgOverride removes an org's override so the flag's rollout applies again. Platform admin only.
+func (s *Server) ClearOrgOverride(ctx context.Context, req *featureflagv1.ClearOrgOverrideRequest) (*featureflagv1.ClearOrgOverrideResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ClearOrgOverride not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetKey() == "" || req.GetOrgId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key and org_id are required")
+	}
+	deleted, err := s.repo.DeleteOrgOverride(ctx, req.GetKey(), req.GetOrgId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete feature flag override")
+	}
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "feature flag override not found")
+	}
+	s.evaluator.Invalidate()
+	s.audit(ctx, userID, "feature_flag_override_cleared", map[string]interface{}{"key": req.GetKey(), "org_id": req.GetOrgId()})
+	return &featureflagv1.ClearOrgOverrideResponse{}, nil
+}
+
+// EvaluateFeatureFlags returns which flags are on for the caller's org. Any org member.
+func (s *Server) EvaluateFeatureFlags(ctx context.Context, req *featureflagv1.EvaluateFeatureFlagsRequest) (*featureflagv1.EvaluateFeatureFlagsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method EvaluateFeatureFlags not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	keys := req.GetKeys()
+	if len(keys) > maxEvaluateKeys {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d keys", maxEvaluateKeys)
+	}
+	if len(keys) == 0 {
+		return &featureflagv1.EvaluateFeatureFlagsResponse{Flags: s.evaluator.EvaluateAll(ctx, orgID)}, nil
+	}
+	flags := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		flags[k] = s.evaluator.Enabled(ctx, k, orgID)
+	}
+	return &featureflagv1.EvaluateFeatureFlagsResponse{Flags: flags}, nil
+}
+
+// audit logs a flag change under the caller's org. Flags are platform-wide, so metadata names the flag and any
+// target org.
+func (s *Server) audit(ctx context.Context, userID, action string, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	meta, _ := json.Marshal(metadata)
+	s.auditLogger.LogEvent(ctx, orgID, userID, action, "feature_flag", string(meta))
+}
+
+func flagToProto(f *domain.Flag, overrides []*domain.OrgOverride) *featureflagv1.FeatureFlag {
+	out := &featureflagv1.FeatureFlag{
+		Key:               f.Key,
+		Description:       f.Description,
+		Enabled:           f.Enabled,
+		RolloutPercentage: int32(f.RolloutPercentage),
+		CreatedAt:         timestamppb.New(f.CreatedAt),
+		UpdatedAt:         timestamppb.New(f.UpdatedAt),
+	}
+	for _, o := range overrides {
+		out.OrgOverrides = append(out.OrgOverrides, &featureflagv1.OrgOverride{
+			OrgId:     o.OrgID,
+			Enabled:   o.Enabled,
+			UpdatedAt: timestamppb.New(o.UpdatedAt),
+		})
+	}
+	return out
+}
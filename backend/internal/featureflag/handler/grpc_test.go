@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	featureflagv1 "zero-trust-control-plane/backend/api/generated/featureflag/v1"
+	"zero-trust-control-plane/backend/internal/featureflag"
+	"zero-trust-control-plane/backend/internal/featureflag/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type memFlagRepo struct {
+	flags     map[string]*domain.Flag
+	overrides map[[2]string]*domain.OrgOverride
+}
+
+func newMemFlagRepo() *memFlagRepo {
+	return &memFlagRepo{flags: map[string]*domain.Flag{}, overrides: map[[2]string]*domain.OrgOverride{}}
+}
+
+func (m *memFlagRepo) UpsertFlag(ctx context.Context, f *domain.Flag) (*domain.Flag, error) {
+	c := *f
+	c.UpdatedAt = time.Now().UTC()
+	if old := m.flags[f.Key]; old != nil {
+		c.CreatedAt = old.CreatedAt
+	} else {
+		c.CreatedAt = c.UpdatedAt
+	}
+	m.flags[f.Key] = &c
+	return &c, nil
+}
+
+func (m *memFlagRepo) GetFlag(ctx context.Context, key string) (*domain.Flag, error) {
+	return m.flags[key], nil
+}
+
+func (m *memFlagRepo) ListFlags(ctx context.Context) ([]*domain.Flag, error) {
+	var out []*domain.Flag
+	for _, f := range m.flags {
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+func (m *memFlagRepo) DeleteFlag(ctx context.Context, key string) (bool, error) {
+	if m.flags[key] == nil {
+		return false, nil
+	}
+	delete(m.flags, key)
+	for k := range m.overrides {
+		if k[0] == key {
+			delete(m.overrides, k)
+		}
+	}
+	return true, nil
+}
+
+func (m *memFlagRepo) SetOrgOverride(ctx context.Context, o *domain.OrgOverride) error {
+	m.overrides[[2]string{o.FlagKey, o.OrgID}] = o
+	return nil
+}
+
+func (m *memFlagRepo) DeleteOrgOverride(ctx context.Context, flagKey, orgID string) (bool, error) {
+	k := [2]string{flagKey, orgID}
+	if m.overrides[k] == nil {
+		return false, nil
+	}
+	delete(m.overrides, k)
+	return true, nil
+}
+
+func (m *memFlagRepo) ListOrgOverrides(ctx context.Context) ([]*domain.OrgOverride, error) {
+	var out []*domain.OrgOverride
+	for _, o := range m.overrides {
+		out = append(out, o)
+	}
+	return out, nil
+}
+
+type platformAdmins map[string]bool
+
+func (p platformAdmins) IsPlatformAdmin(userID string) bool { return p[userID] }
+
+type mockMembershipRepo struct {
+	memberships map[string]*membershipdomain.Membership
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	return m.memberships[userID+":"+orgID], nil
+}
+
+type mockOrgs map[string]*organizationdomain.Org
+
+func (m mockOrgs) GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error) {
+	return m[id], nil
+}
+
+type mockAuditLogger struct {
+	actions []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+}
+
+type testEnv struct {
+	srv   *Server
+	repo  *memFlagRepo
+	audit *mockAuditLogger
+}
+
+func newTestEnv() *testEnv {
+	env := &testEnv{repo: newMemFlagRepo(), audit: &mockAuditLogger{}}
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}}
+	orgs := mockOrgs{"org-1": {ID: "org-1"}, "org-2": {ID: "org-2"}}
+	evaluator := featureflag.NewEvaluator(env.repo, time.Hour)
+	env.srv = NewServer(env.repo, evaluator, membershipRepo, platformAdmins{"admin-1": true}, orgs, env.audit)
+	return env
+}
+
+func adminCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "admin-1", "org-admin", "session-1")
+}
+
+func memberCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-2")
+}
+
+func TestFeatureFlagLifecycle(t *testing.T) {
+	env := newTestEnv()
+	ctx := adminCtx()
+
+	if _, err := env.srv.UpsertFeatureFlag(ctx, &featureflagv1.UpsertFeatureFlagRequest{Key: "auth.webauthn", Enabled: true, RolloutPercentage: 0}); err != nil {
+		t.Fatalf("UpsertFeatureFlag: %v", err)
+	}
+	eval := func() bool {
+		t.Helper()
+		resp, err := env.srv.EvaluateFeatureFlags(memberCtx(), &featureflagv1.EvaluateFeatureFlagsRequest{Keys: []string{"auth.webauthn"}})
+		if err != nil {
+			t.Fatalf("EvaluateFeatureFlags: %v", err)
+		}
+		return resp.GetFlags()["auth.webauthn"]
+	}
+	if eval() {
+		t.Error("flag at 0% should be off")
+	}
+
+	if _, err := env.srv.SetOrgOverride(ctx, &featureflagv1.SetOrgOverrideRequest{Key: "auth.webauthn", OrgId: "org-1", Enabled: true}); err != nil {
+		t.Fatalf("SetOrgOverride: %v", err)
+	}
+	if !eval() {
+		t.Error("override should turn the flag on for org-1 immediately")
+	}
+
+	list, err := env.srv.ListFeatureFlags(ctx, &featureflagv1.ListFeatureFlagsRequest{})
+	if err != nil {
+		t.Fatalf("ListFeatureFlags: %v", err)
+	}
+	if len(list.GetFlags()) != 1 || len(list.GetFlags()[0].GetOrgOverrides()) != 1 {
+		t.Fatalf("ListFeatureFlags = %v, want one flag with one override", list.GetFlags())
+	}
+
+	if _, err := env.srv.ClearOrgOverride(ctx, &featureflagv1.ClearOrgOverrideRequest{Key: "auth.webauthn", OrgId: "org-1"}); err != nil {
+		t.Fatalf("ClearOrgOverride: %v", err)
+	}
+	if eval() {
+		t.Error("flag should be off again after the override is cleared")
+	}
+
+	if _, err := env.srv.DeleteFeatureFlag(ctx, &featureflagv1.DeleteFeatureFlagRequest{Key: "auth.webauthn"}); err != nil {
+		t.Fatalf("DeleteFeatureFlag: %v", err)
+	}
+	want := []string{"feature_flag_updated", "feature_flag_override_set", "feature_flag_override_cleared", "feature_flag_deleted"}
+	if len(env.audit.actions) != len(want) {
+		t.Fatalf("audit actions = %v, want %v", env.audit.actions, want)
+	}
+	for i := range want {
+		if env.audit.actions[i] != want[i] {
+			t.Errorf("audit action[%d] = %q, want %q", i, env.audit.actions[i], want[i])
+		}
+	}
+}
+
+func TestManagementRequiresPlatformAdmin(t *testing.T) {
+	env := newTestEnv()
+	ctx := memberCtx()
+	if _, err := env.srv.ListFeatureFlags(ctx, &featureflagv1.ListFeatureFlagsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListFeatureFlags code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := env.srv.UpsertFeatureFlag(ctx, &featureflagv1.UpsertFeatureFlagRequest{Key: "x"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("UpsertFeatureFlag code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := env.srv.SetOrgOverride(context.Background(), &featureflagv1.SetOrgOverrideRequest{Key: "x", OrgId: "org-1"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("SetOrgOverride code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+func TestUpsertFeatureFlag_Validation(t *testing.T) {
+	env := newTestEnv()
+	for _, req := range []*featureflagv1.UpsertFeatureFlagRequest{
+		{Key: ""},
+		{Key: "Has Spaces"},
+		{Key: "ok", RolloutPercentage: 101},
+		{Key: "ok", RolloutPercentage: -1},
+	} {
+		if _, err := env.srv.UpsertFeatureFlag(adminCtx(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("UpsertFeatureFlag(%v) code = %v, want InvalidArgument", req, status.Code(err))
+		}
+	}
+}
+
+func TestSetOrgOverride_NotFound(t *testing.T) {
+	env := newTestEnv()
+	ctx := adminCtx()
+	if _, err := env.srv.SetOrgOverride(ctx, &featureflagv1.SetOrgOverrideRequest{Key: "missing", OrgId: "org-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown flag code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := env.srv.UpsertFeatureFlag(ctx, &featureflagv1.UpsertFeatureFlagRequest{Key: "f"}); err != nil {
+		t.Fatalf("UpsertFeatureFlag: %v", err)
+	}
+	if _, err := env.srv.SetOrgOverride(ctx, &featureflagv1.SetOrgOverrideRequest{Key: "f", OrgId: "org-missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown org code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := env.srv.ClearOrgOverride(ctx, &featureflagv1.ClearOrgOverrideRequest{Key: "f", OrgId: "org-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("ClearOrgOverride without override code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := env.srv.DeleteFeatureFlag(ctx, &featureflagv1.DeleteFeatureFlagRequest{Key: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteFeatureFlag code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestEvaluateFeatureFlags_AllKnown(t *testing.T) {
+	env := newTestEnv()
+	resp, err := env.srv.EvaluateFeatureFlags(memberCtx(), &featureflagv1.EvaluateFeatureFlagsRequest{})
+	if err != nil {
+		t.Fatalf("EvaluateFeatureFlags: %v", err)
+	}
+	for _, k := range featureflag.KnownKeys() {
+		if got, ok := resp.GetFlags()[k]; !ok || got != featureflag.Default(k) {
+			t.Errorf("flag %s = %v (present %v), want default %v", k, got, ok, featureflag.Default(k))
+		}
+	}
+	outsider := interceptors.WithIdentity(context.Background(), "user-9", "org-1", "session-9")
+	if _, err := env.srv.EvaluateFeatureFlags(outsider, &featureflagv1.EvaluateFeatureFlagsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("non-member code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestNilRepo_Unimplemented(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, nil)
+	if _, err := srv.ListFeatureFlags(adminCtx(), &featureflagv1.ListFeatureFlagsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
+	}
+	if _, err := srv.EvaluateFeatureFlags(memberCtx(), &featureflagv1.EvaluateFeatureFlagsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
+	}
+}
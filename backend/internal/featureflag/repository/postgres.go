@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/featureflag/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a feature flag repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// UpsertFlag creates the flag or replaces its settings. CreatedAt is kept for existing flags.
+func (r *PostgresRepository) UpsertFlag(ctx context.Context, f *domain.Flag) (*domain.Flag, error) {
+	row, err := r.queries.UpsertFeatureFlag(ctx, gen.UpsertFeatureFlagParams{
+		Key:               f.Key,
+		Description:       f.Description,
+		Enabled:           f.Enabled,
+		RolloutPercentage: int32(f.RolloutPercentage),
+		CreatedAt:         time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return genFlagToDomain(&row), nil
+}
+
+// GetFlag returns the flag, or nil if it does not exist.
+func (r *PostgresRepository) GetFlag(ctx context.Context, key string) (*domain.Flag, error) {
+	row, err := r.queries.GetFeatureFlag(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genFlagToDomain(&row), nil
+}
+
+// ListFlags returns all flags ordered by key.
+func (r *PostgresRepository) ListFlags(ctx context.Context) ([]*domain.Flag, error) {
+	rows, err := r.queries.ListFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Flag, len(rows))
+	for i := range rows {
+		out[i] = genFlagToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+// DeleteFlag deletes the flag; its overrides are removed by the foreign key cascade.
+func (r *PostgresRepository) DeleteFlag(ctx context.Context, key string) (bool, error) {
+	n, err := r.queries.DeleteFeatureFlag(ctx, key)
+	return n > 0, err
+}
+
+// SetOrgOverride creates or replaces the override for (FlagKey, OrgID).
+func (r *PostgresRepository) SetOrgOverride(ctx context.Context, o *domain.OrgOverride) error {
+	return r.queries.UpsertFeatureFlagOrgOverride(ctx, gen.UpsertFeatureFlagOrgOverrideParams{
+		FlagKey:   o.FlagKey,
+		OrgID:     o.OrgID,
+		Enabled:   o.Enabled,
+		UpdatedAt: time.Now().UTC(),
+	})
+}
+
+// DeleteOrgOverride removes the override for (flagKey, orgID).
+func (r *PostgresRepository) DeleteOrgOverride(ctx context.Context, flagKey, orgID string) (bool, error) {
+	n, err := r.queries.DeleteFeatureFlagOrgOverride(ctx, gen.DeleteFeatureFlagOrgOverrideParams{FlagKey: flagKey, OrgID: orgID})
+	return n > 0, err
+}
+
+// ListOrgOverrides returns all overrides ordered by flag key and org.
+func (r *PostgresRepository) ListOrgOverrides(ctx context.Context) ([]*domain.OrgOverride, error) {
+	rows, err := r.queries.ListFeatureFlagOrgOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.OrgOverride, len(rows))
+	for i, o := range rows {
+		out[i] = &domain.OrgOverride{FlagKey: o.FlagKey, OrgID: o.OrgID, Enabled: o.Enabled, UpdatedAt: o.UpdatedAt}
+	}
+	return out, nil
+}
+
+func genFlagToDomain(f *gen.FeatureFlag) *domain.Flag {
+	return &domain.Flag{
+		Key:               f.Key,
+		Description:       f.Description,
+		Enabled:           f.Enabled,
+		RolloutPercentage: int(f.RolloutPercentage),
+		CreatedAt:         f.CreatedAt,
+		UpdatedAt:         f.UpdatedAt,
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/featureflag/domain"
+)
+
+// Repository persists feature flags and their per-org overrides.
+type Repository interface {
+	// UpsertFlag creates the flag or replaces its description, enabled and rollout percentage.
+	UpsertFlag(ctx context.Context, f *domain.Flag) (*domain.Flag, error)
+	// GetFlag returns the flag, or nil if it does not exist.
+	GetFlag(ctx context.Context, key string) (*domain.Flag, error)
+	// ListFlags returns all flags ordered by key.
+	ListFlags(ctx context.Context) ([]*domain.Flag, error)
+	// DeleteFlag deletes the flag and its overrides. Returns false if the flag did not exist.
+	DeleteFlag(ctx context.Context, key string) (bool, error)
+	// SetOrgOverride creates or replaces the override for (FlagKey, OrgID). The flag must exist.
+	SetOrgOverride(ctx context.Context, o *domain.OrgOverride) error
+	// DeleteOrgOverride removes the override. Returns false if there was none.
+	DeleteOrgOverride(ctx context.Context, flagKey, orgID string) (bool, error)
+	// ListOrgOverrides returns all overrides ordered by flag key and org.
+	ListOrgOverrides(ctx context.Context) ([]*domain.OrgOverride, error)
+}
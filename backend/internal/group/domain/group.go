@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Group is a named set of an org's users. Group admins manage the memberships, sessions and devices of the group's
+// users without being org admins.
+type Group struct {
+	ID        string
+	OrgID     string
+	Name      string
+	CreatedAt time.Time
+}
+
+// Member is a user's membership in a group.
+type Member struct {
+	GroupID   string
+	UserID    string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Role is a member's role in a group.
+type Role string
+
+const (
+	RoleMember Role = "member"
+	// RoleAdmin manages the users of the group (delegated administration); it grants nothing on other users.
+	RoleAdmin Role = "admin"
+)
+
+// MaxNameLength is the maximum length of a group name.
+const MaxNameLength = 100
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	groupv1 "zero-trust-control-plane/backend/api/generated/group/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/group/domain"
+	grouprepo "zero-trust-control-plane/backend/internal/group/repository"
+	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+)
+
+// Server implements GroupService (proto server) for groups and group-scoped admins.
+// Proto: group/group.proto → internal/group/handler.
+type Server struct {
+	groupv1.UnimplementedGroupServiceServer
+	groupRepo      grouprepo.Repository
+	membershipRepo membershiprepo.Repository
+	auditLogger    audit.AuditLogger
+}
+
+// NewServer returns a new Group gRPC server. If groupRepo or membershipRepo is nil, all RPCs return Unimplemented.
+func NewServer(groupRepo grouprepo.Repository, membershipRepo membershiprepo.Repository, auditLogger audit.AuditLogger) *Server {
+	return &Server{
+		groupRepo:      groupRepo,
+		membershipRepo: membershipRepo,
+		auditLogger:    auditLogger,
+	}
+}
+
+// CreateGroup creates a group in the caller's org. Caller must be org admin or owner.
+func (s *Server) CreateGroup(ctx context.Context, req *groupv1.CreateGroupRequest) (*groupv1.CreateGroupResponse, error) {
+	if s.groupRepo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method CreateGroup not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name required")
+	}
+	if len(name) > domain.MaxNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be at most %d characters", domain.MaxNameLength)
+	}
+	existing, err := s.groupRepo.ListByOrg(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list groups")
+	}
+	for _, g := range existing {
+		if g.Name == name {
+			return nil, status.Error(codes.AlreadyExists, "a group with this name already exists")
+		}
+	}
+	g := &domain.Group{
+		ID:        uuid.New().String(),
+		OrgID:     orgID,
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.groupRepo.Create(ctx, g); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create group")
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "create", "group", g.ID)
+	}
+	return &groupv1.CreateGroupResponse{Group: groupToProto(g)}, nil
+}
+
+// DeleteGroup deletes a group of the caller's org and its memberships. Caller must be org admin or owner.
+func (s *Server) DeleteGroup(ctx context.Context, req *groupv1.DeleteGroupRequest) (*groupv1.DeleteGroupResponse, error) {
+	if s.groupRepo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method DeleteGroup not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.orgGroup(ctx, orgID, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.groupRepo.Delete(ctx, g.ID); err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete group")
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "delete", "group", g.ID)
+	}
+	return &groupv1.DeleteGroupResponse{}, nil
+}
+
+// ListGroups returns the groups of the caller's org ordered by name. Caller must be org admin or owner.
+func (s *Server) ListGroups(ctx context.Context, req *groupv1.ListGroupsRequest) (*groupv1.ListGroupsResponse, error) {
+	if s.groupRepo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListGroups not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.groupRepo.ListByOrg(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list groups")
+	}
+	groups := make([]*groupv1.Group, len(list))
+	for i, g := range list {
+		groups[i] = groupToProto(g)
+	}
+	return &groupv1.ListGroupsResponse{Groups: groups}, nil
+}
+
+// SetGroupMember adds a member of the caller's org to a group, or changes their group role. Caller must be org admin
+// or owner: group admins cannot add users to their scope or appoint other group admins.
+func (s *Server) SetGroupMember(ctx context.Context, req *groupv1.SetGroupMemberRequest) (*groupv1.SetGroupMemberResponse, error) {
+	if s.groupRepo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetGroupMember not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID := req.GetUserId()
+	if targetUserID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	role := protoRoleToDomain(req.GetRole())
+	if role == "" {
+		return nil, status.Error(codes.InvalidArgument, "role must be member or admin")
+	}
+	if role == domain.RoleAdmin {
+		// Group admin rights outlive an elevation, so elevated admins cannot grant them.
+		if _, _, err := rbac.RequireStandingOrgAdmin(ctx, s.membershipRepo); err != nil {
+			return nil, err
+		}
+	}
+	g, err := s.orgGroup(ctx, orgID, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, targetUserID, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up membership")
+	}
+	if m == nil {
+		return nil, status.Error(codes.FailedPrecondition, "user is not a member of this organization")
+	}
+	member := &domain.Member{GroupID: g.ID, UserID: targetUserID, Role: role, CreatedAt: time.Now().UTC()}
+	if err := s.groupRepo.SetMember(ctx, member); err != nil {
+		return nil, status.Error(codes.Internal, "failed to set group member")
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "update", "group_member", g.ID+":"+targetUserID+":"+string(role))
+	}
+	return &groupv1.SetGroupMemberResponse{Member: memberToProto(member)}, nil
+}
+
+// RemoveGroupMember removes a user from a group of the caller's org. Caller must be org admin or owner.
+func (s *Server) RemoveGroupMember(ctx context.Context, req *groupv1.RemoveGroupMemberRequest) (*groupv1.RemoveGroupMemberResponse, error) {
+	if s.groupRepo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RemoveGroupMember not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID := req.GetUserId()
+	if targetUserID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	g, err := s.orgGroup(ctx, orgID, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	removed, err := s.groupRepo.RemoveMember(ctx, g.ID, targetUserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to remove group member")
+	}
+	if !removed {
+		return nil, status.Error(codes.NotFound, "user is not in this group")
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "remove", "group_member", g.ID+":"+targetUserID)
+	}
+	return &groupv1.RemoveGroupMemberResponse{}, nil
+}
+
+// ListGroupMembers returns the members of a group of the caller's org. Caller must be org admin or owner, or an
+// admin of the group.
+func (s *Server) ListGroupMembers(ctx context.Context, req *groupv1.ListGroupMembersRequest) (*groupv1.ListGroupMembersResponse, error) {
+	if s.groupRepo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListGroupMembers not implemented")
+	}
+	scope, err := rbac.RequireAdminScope(ctx, s.membershipRepo, s.groupRepo)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.orgGroup(ctx, scope.OrgID, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.groupRepo.ListMembers(ctx, g.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list group members")
+	}
+	if !scope.OrgWide() && !isGroupAdmin(list, scope.UserID) {
+		return nil, status.Error(codes.PermissionDenied, "organization admin, owner or group admin required")
+	}
+	members := make([]*groupv1.GroupMember, len(list))
+	for i, m := range list {
+		members[i] = memberToProto(m)
+	}
+	return &groupv1.ListGroupMembersResponse{Members: members}, nil
+}
+
+// orgGroup returns the group, or NotFound if it does not exist or belongs to another org.
+func (s *Server) orgGroup(ctx context.Context, orgID, groupID string) (*domain.Group, error) {
+	if groupID == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_id required")
+	}
+	g, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up group")
+	}
+	if g == nil || g.OrgID != orgID {
+		return nil, status.Error(codes.NotFound, "group not found")
+	}
+	return g, nil
+}
+
+func isGroupAdmin(members []*domain.Member, userID string) bool {
+	for _, m := range members {
+		if m.UserID == userID && m.Role == domain.RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+func protoRoleToDomain(r groupv1.GroupRole) domain.Role {
+	switch r {
+	case groupv1.GroupRole_GROUP_ROLE_UNSPECIFIED, groupv1.GroupRole_GROUP_ROLE_MEMBER:
+		return domain.RoleMember
+	case groupv1.GroupRole_GROUP_ROLE_ADMIN:
+		return domain.RoleAdmin
+	default:
+		return ""
+	}
+}
+
+func groupToProto(g *domain.Group) *groupv1.Group {
+	return &groupv1.Group{
+		Id:        g.ID,
+		OrgId:     g.OrgID,
+		Name:      g.Name,
+		CreatedAt: timestamppb.New(g.CreatedAt),
+	}
+}
+
+func memberToProto(m *domain.Member) *groupv1.GroupMember {
+	role := groupv1.GroupRole_GROUP_ROLE_MEMBER
+	if m.Role == domain.RoleAdmin {
+		role = groupv1.GroupRole_GROUP_ROLE_ADMIN
+	}
+	return &groupv1.GroupMember{
+		GroupId:   m.GroupID,
+		UserId:    m.UserID,
+		Role:      role,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+	}
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	groupv1 "zero-trust-control-plane/backend/api/generated/group/v1"
+	"zero-trust-control-plane/backend/internal/group/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// memGroupRepo implements grouprepo.Repository in memory.
+type memGroupRepo struct {
+	groups  map[string]*domain.Group
+	members map[string][]*domain.Member
+}
+
+func newMemGroupRepo() *memGroupRepo {
+	return &memGroupRepo{groups: map[string]*domain.Group{}, members: map[string][]*domain.Member{}}
+}
+
+func (m *memGroupRepo) Create(ctx context.Context, g *domain.Group) error {
+	m.groups[g.ID] = g
+	return nil
+}
+
+func (m *memGroupRepo) GetByID(ctx context.Context, id string) (*domain.Group, error) {
+	return m.groups[id], nil
+}
+
+func (m *memGroupRepo) ListByOrg(ctx context.Context, orgID string) ([]*domain.Group, error) {
+	var out []*domain.Group
+	for _, g := range m.groups {
+		if g.OrgID == orgID {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+func (m *memGroupRepo) Delete(ctx context.Context, id string) (bool, error) {
+	_, ok := m.groups[id]
+	delete(m.groups, id)
+	delete(m.members, id)
+	return ok, nil
+}
+
+func (m *memGroupRepo) SetMember(ctx context.Context, mem *domain.Member) error {
+	for _, existing := range m.members[mem.GroupID] {
+		if existing.UserID == mem.UserID {
+			existing.Role = mem.Role
+			return nil
+		}
+	}
+	m.members[mem.GroupID] = append(m.members[mem.GroupID], mem)
+	return nil
+}
+
+func (m *memGroupRepo) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	list := m.members[groupID]
+	for i, mem := range list {
+		if mem.UserID == userID {
+			m.members[groupID] = append(list[:i], list[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memGroupRepo) ListMembers(ctx context.Context, groupID string) ([]*domain.Member, error) {
+	return m.members[groupID], nil
+}
+
+func (m *memGroupRepo) RemoveUserFromOrgGroups(ctx context.Context, userID, orgID string) error {
+	return nil
+}
+
+func (m *memGroupRepo) ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error) {
+	var out []string
+	for id, list := range m.members {
+		if m.groups[id].OrgID != orgID || !isGroupAdmin(list, adminUserID) {
+			continue
+		}
+		for _, mem := range list {
+			out = append(out, mem.UserID)
+		}
+	}
+	return out, nil
+}
+
+func (m *memGroupRepo) ListUserGroupNames(ctx context.Context, orgID, userID string) ([]string, error) {
+	return nil, nil
+}
+
+// memMemberships implements membershiprepo.Repository; only GetMembershipByUserAndOrg is used.
+type memMemberships struct {
+	roles map[string]membershipdomain.Role // key: userID:orgID
+}
+
+func (m *memMemberships) GetMembershipByID(ctx context.Context, id string) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *memMemberships) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	role, ok := m.roles[userID+":"+orgID]
+	if !ok {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+func (m *memMemberships) ListMembershipsByOrg(ctx context.Context, orgID string) ([]*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *memMemberships) CreateMembership(ctx context.Context, mem *membershipdomain.Membership) error {
+	return nil
+}
+
+func (m *memMemberships) DeleteByUserAndOrg(ctx context.Context, userID, orgID string) error {
+	return nil
+}
+
+func (m *memMemberships) UpdateRole(ctx context.Context, userID, orgID string, role membershipdomain.Role) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *memMemberships) CountOwnersByOrg(ctx context.Context, orgID string) (int64, error) {
+	return 0, nil
+}
+
+func testServer() (*Server, *memGroupRepo) {
+	groups := newMemGroupRepo()
+	memberships := &memMemberships{roles: map[string]membershipdomain.Role{
+		"admin-1:org-1": membershipdomain.RoleAdmin,
+		"lead-1:org-1":  membershipdomain.RoleMember,
+		"user-1:org-1":  membershipdomain.RoleMember,
+		"user-2:org-1":  membershipdomain.RoleMember,
+		"admin-2:org-2": membershipdomain.RoleAdmin,
+	}}
+	return NewServer(groups, memberships, nil), groups
+}
+
+func as(userID, orgID string) context.Context {
+	return interceptors.WithIdentity(context.Background(), userID, orgID, "session-1")
+}
+
+func TestGroupLifecycle(t *testing.T) {
+	srv, groups := testServer()
+	admin := as("admin-1", "org-1")
+
+	created, err := srv.CreateGroup(admin, &groupv1.CreateGroupRequest{Name: " Engineering "})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	g := created.GetGroup()
+	if g.GetName() != "Engineering" || g.GetOrgId() != "org-1" {
+		t.Errorf("group = %+v", g)
+	}
+	if _, err := srv.CreateGroup(admin, &groupv1.CreateGroupRequest{Name: "Engineering"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("duplicate name: code = %v, want AlreadyExists", status.Code(err))
+	}
+
+	if _, err := srv.SetGroupMember(admin, &groupv1.SetGroupMemberRequest{GroupId: g.GetId(), UserId: "lead-1", Role: groupv1.GroupRole_GROUP_ROLE_ADMIN}); err != nil {
+		t.Fatalf("SetGroupMember(lead-1): %v", err)
+	}
+	resp, err := srv.SetGroupMember(admin, &groupv1.SetGroupMemberRequest{GroupId: g.GetId(), UserId: "user-1"})
+	if err != nil || resp.GetMember().GetRole() != groupv1.GroupRole_GROUP_ROLE_MEMBER {
+		t.Fatalf("SetGroupMember(user-1) = %v, %v; want member", resp, err)
+	}
+	if _, err := srv.SetGroupMember(admin, &groupv1.SetGroupMemberRequest{GroupId: g.GetId(), UserId: "admin-2"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("non-member of the org: code = %v, want FailedPrecondition", status.Code(err))
+	}
+
+	// The group admin may list the group but not manage it.
+	lead := as("lead-1", "org-1")
+	list, err := srv.ListGroupMembers(lead, &groupv1.ListGroupMembersRequest{GroupId: g.GetId()})
+	if err != nil || len(list.GetMembers()) != 2 {
+		t.Fatalf("ListGroupMembers as group admin = %v, %v; want 2 members", list, err)
+	}
+	if _, err := srv.SetGroupMember(lead, &groupv1.SetGroupMemberRequest{GroupId: g.GetId(), UserId: "user-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("group admin SetGroupMember: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.ListGroupMembers(as("user-1", "org-1"), &groupv1.ListGroupMembersRequest{GroupId: g.GetId()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("group member ListGroupMembers: code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	if _, err := srv.RemoveGroupMember(admin, &groupv1.RemoveGroupMemberRequest{GroupId: g.GetId(), UserId: "user-1"}); err != nil {
+		t.Fatalf("RemoveGroupMember: %v", err)
+	}
+	if _, err := srv.RemoveGroupMember(admin, &groupv1.RemoveGroupMemberRequest{GroupId: g.GetId(), UserId: "user-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("second RemoveGroupMember: code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.DeleteGroup(admin, &groupv1.DeleteGroupRequest{GroupId: g.GetId()}); err != nil {
+		t.Fatalf("DeleteGroup: %v", err)
+	}
+	if len(groups.groups) != 0 {
+		t.Errorf("groups after delete = %v", groups.groups)
+	}
+}
+
+func TestGroupOtherOrg(t *testing.T) {
+	srv, groups := testServer()
+	groups.groups["g-1"] = &domain.Group{ID: "g-1", OrgID: "org-1", Name: "Engineering"}
+	other := as("admin-2", "org-2")
+
+	if _, err := srv.DeleteGroup(other, &groupv1.DeleteGroupRequest{GroupId: "g-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteGroup from another org: code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.ListGroupMembers(other, &groupv1.ListGroupMembersRequest{GroupId: "g-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("ListGroupMembers from another org: code = %v, want NotFound", status.Code(err))
+	}
+	list, err := srv.ListGroups(other, &groupv1.ListGroupsRequest{})
+	if err != nil || len(list.GetGroups()) != 0 {
+		t.Errorf("ListGroups from another org = %v, %v; want none", list, err)
+	}
+}
+
+func TestGroupValidation(t *testing.T) {
+	srv, _ := testServer()
+	admin := as("admin-1", "org-1")
+	if _, err := srv.CreateGroup(admin, &groupv1.CreateGroupRequest{Name: " "}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty name: code = %v, want InvalidArgument", status.Code(err))
+	}
+	if _, err := srv.CreateGroup(as("user-1", "org-1"), &groupv1.CreateGroupRequest{Name: "Eng"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member CreateGroup: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := NewServer(nil, nil, nil).ListGroups(admin, &groupv1.ListGroupsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil repos: code = %v, want Unimplemented", status.Code(err))
+	}
+}
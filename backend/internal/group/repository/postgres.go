@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/group/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a group repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists the group. The group must have ID set.
+func (r *PostgresRepository) Create(ctx context.Context, g *domain.Group) error {
+	_, err := r.queries.CreateGroup(ctx, gen.CreateGroupParams{
+		ID:        g.ID,
+		OrgID:     g.OrgID,
+		Name:      g.Name,
+		CreatedAt: g.CreatedAt,
+	})
+	return err
+}
+
+// GetByID returns the group for id, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Group, error) {
+	g, err := r.queries.GetGroup(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genGroupToDomain(&g), nil
+}
+
+// ListByOrg returns the org's groups ordered by name.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string) ([]*domain.Group, error) {
+	list, err := r.queries.ListGroupsByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Group, len(list))
+	for i := range list {
+		out[i] = genGroupToDomain(&list[i])
+	}
+	return out, nil
+}
+
+// Delete deletes the group; its memberships are deleted with it.
+func (r *PostgresRepository) Delete(ctx context.Context, id string) (bool, error) {
+	n, err := r.queries.DeleteGroup(ctx, id)
+	return n > 0, err
+}
+
+// SetMember adds the user to the group with m.Role, or updates the role of an existing member.
+func (r *PostgresRepository) SetMember(ctx context.Context, m *domain.Member) error {
+	return r.queries.UpsertGroupMember(ctx, gen.UpsertGroupMemberParams{
+		GroupID:   m.GroupID,
+		UserID:    m.UserID,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt,
+	})
+}
+
+// RemoveMember removes the user from the group.
+func (r *PostgresRepository) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	n, err := r.queries.DeleteGroupMember(ctx, gen.DeleteGroupMemberParams{GroupID: groupID, UserID: userID})
+	return n > 0, err
+}
+
+// ListMembers returns the group's members, oldest first.
+func (r *PostgresRepository) ListMembers(ctx context.Context, groupID string) ([]*domain.Member, error) {
+	list, err := r.queries.ListGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Member, len(list))
+	for i := range list {
+		out[i] = &domain.Member{
+			GroupID:   list[i].GroupID,
+			UserID:    list[i].UserID,
+			Role:      domain.Role(list[i].Role),
+			CreatedAt: list[i].CreatedAt,
+		}
+	}
+	return out, nil
+}
+
+// RemoveUserFromOrgGroups removes the user from every group of the org.
+func (r *PostgresRepository) RemoveUserFromOrgGroups(ctx context.Context, userID, orgID string) error {
+	return r.queries.DeleteGroupMembershipsByUserAndOrg(ctx, gen.DeleteGroupMembershipsByUserAndOrgParams{UserID: userID, OrgID: orgID})
+}
+
+// ListManagedUserIDs returns the members of the org's groups administered by adminUserID.
+func (r *PostgresRepository) ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error) {
+	return r.queries.ListUsersManagedByGroupAdmin(ctx, gen.ListUsersManagedByGroupAdminParams{AdminUserID: adminUserID, OrgID: orgID})
+}
+
+// ListUserGroupNames returns the names of the org's groups that userID belongs to.
+func (r *PostgresRepository) ListUserGroupNames(ctx context.Context, orgID, userID string) ([]string, error) {
+	return r.queries.ListGroupNamesByUserAndOrg(ctx, gen.ListGroupNamesByUserAndOrgParams{UserID: userID, OrgID: orgID})
+}
+
+func genGroupToDomain(g *gen.Group) *domain.Group {
+	return &domain.Group{
+		ID:        g.ID,
+		OrgID:     g.OrgID,
+		Name:      g.Name,
+		CreatedAt: g.CreatedAt,
+	}
+}
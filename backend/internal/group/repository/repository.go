@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/group/domain"
+)
+
+// Repository defines persistence for groups and group membership.
+type Repository interface {
+	Create(ctx context.Context, g *domain.Group) error
+	// GetByID returns the group, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.Group, error)
+	ListByOrg(ctx context.Context, orgID string) ([]*domain.Group, error)
+	// Delete deletes the group and its memberships. Reports whether the group existed.
+	Delete(ctx context.Context, id string) (bool, error)
+	// SetMember adds the user to the group, or changes their role if they are a member.
+	SetMember(ctx context.Context, m *domain.Member) error
+	// RemoveMember reports whether the user was a member.
+	RemoveMember(ctx context.Context, groupID, userID string) (bool, error)
+	ListMembers(ctx context.Context, groupID string) ([]*domain.Member, error)
+	// RemoveUserFromOrgGroups removes the user from all of the org's groups (e.g. when they leave the org).
+	RemoveUserFromOrgGroups(ctx context.Context, userID, orgID string) error
+	// ListManagedUserIDs returns the users in the org's groups that adminUserID is a group admin of, including
+	// adminUserID itself; empty if they administer no group.
+	ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error)
+	// ListUserGroupNames returns the names of the org's groups that userID belongs to, ordered by name.
+	ListUserGroupNames(ctx context.Context, orgID, userID string) ([]string, error)
+}
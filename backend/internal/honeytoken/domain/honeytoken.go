@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Honeytoken marks a decoy user. Its credentials are planted where only an attacker would find and use them, so
+// every sign-in attempt against it is rejected and alerted.
+type Honeytoken struct {
+	UserID          string
+	Label           string // where the credential was planted, for whoever answers the alert
+	CreatedBy       string
+	CreatedAt       time.Time
+	TriggerCount    int64
+	LastTriggeredAt *time.Time
+}
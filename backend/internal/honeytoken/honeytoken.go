@@ -0,0 +1,93 @@
+// Package honeytoken manages honeytokens: decoy users whose credentials are planted as canaries. AuthService
+// (internal/identity/service) rejects and alerts every sign-in attempt against them; this package marks and unmarks
+// them and delivers the alerts.
+package honeytoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/honeytoken/domain"
+	"zero-trust-control-plane/backend/internal/honeytoken/repository"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// MaxLabelLength bounds Honeytoken.Label.
+const MaxLabelLength = 200
+
+var (
+	// ErrUserNotFound is returned by Mark for an unknown user.
+	ErrUserNotFound = errors.New("honeytoken: user not found")
+	// ErrNotHoneytoken is returned by Unmark for a user that is not a honeytoken.
+	ErrNotHoneytoken = errors.New("honeytoken: user is not a honeytoken")
+	// ErrLabelTooLong is returned by Mark when the label exceeds MaxLabelLength.
+	ErrLabelTooLong = errors.New("honeytoken: label exceeds 200 characters")
+)
+
+// UserGetter resolves users so only existing users are marked.
+type UserGetter interface {
+	GetByID(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+// SessionRevoker ends a user's sessions when they become a honeytoken.
+type SessionRevoker interface {
+	RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error
+}
+
+// Registry marks and unmarks honeytokens.
+type Registry struct {
+	repo     repository.Repository
+	users    UserGetter
+	sessions SessionRevoker
+}
+
+// NewRegistry returns a registry backed by repo. sessions may be nil; the users' sessions are then left alone.
+func NewRegistry(repo repository.Repository, users UserGetter, sessions SessionRevoker) *Registry {
+	return &Registry{repo: repo, users: users, sessions: sessions}
+}
+
+// Mark makes userID a honeytoken, or relabels one. Any sessions the user has are revoked as admin_revoke by by, so
+// from now on the account cannot be used at all.
+func (r *Registry) Mark(ctx context.Context, userID, label, by string) (*domain.Honeytoken, error) {
+	label = strings.TrimSpace(label)
+	if len(label) > MaxLabelLength {
+		return nil, ErrLabelTooLong
+	}
+	user, err := r.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	h, err := r.repo.Upsert(ctx, &domain.Honeytoken{UserID: userID, Label: label, CreatedBy: by, CreatedAt: time.Now().UTC()})
+	if err != nil {
+		return nil, err
+	}
+	if r.sessions != nil {
+		if err := r.sessions.RevokeAllSessionsByUser(ctx, userID, sessiondomain.Revocation{Reason: sessiondomain.RevocationAdminRevoke, By: by}); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Unmark turns the honeytoken back into an ordinary user.
+func (r *Registry) Unmark(ctx context.Context, userID string) error {
+	ok, err := r.repo.Delete(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotHoneytoken
+	}
+	return nil
+}
+
+// List returns honeytokens, most recently marked first.
+func (r *Registry) List(ctx context.Context, limit, offset int32) ([]*domain.Honeytoken, error) {
+	return r.repo.List(ctx, limit, offset)
+}
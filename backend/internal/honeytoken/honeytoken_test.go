@@ -0,0 +1,117 @@
+package honeytoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/honeytoken/domain"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+type memRepo struct {
+	byUser map[string]*domain.Honeytoken
+}
+
+func (m *memRepo) Upsert(ctx context.Context, h *domain.Honeytoken) (*domain.Honeytoken, error) {
+	if cur, ok := m.byUser[h.UserID]; ok {
+		cur.Label = h.Label
+		return cur, nil
+	}
+	c := *h
+	m.byUser[h.UserID] = &c
+	return &c, nil
+}
+
+func (m *memRepo) Delete(ctx context.Context, userID string) (bool, error) {
+	_, ok := m.byUser[userID]
+	delete(m.byUser, userID)
+	return ok, nil
+}
+
+func (m *memRepo) Get(ctx context.Context, userID string) (*domain.Honeytoken, error) {
+	return m.byUser[userID], nil
+}
+
+func (m *memRepo) List(ctx context.Context, limit, offset int32) ([]*domain.Honeytoken, error) {
+	var out []*domain.Honeytoken
+	for _, h := range m.byUser {
+		out = append(out, h)
+	}
+	return out, nil
+}
+
+func (m *memRepo) RecordTrigger(ctx context.Context, userID string, at time.Time) error {
+	if h := m.byUser[userID]; h != nil {
+		h.TriggerCount++
+		h.LastTriggeredAt = &at
+	}
+	return nil
+}
+
+type memUsers map[string]bool
+
+func (m memUsers) GetByID(ctx context.Context, id string) (*userdomain.User, error) {
+	if !m[id] {
+		return nil, nil
+	}
+	return &userdomain.User{ID: id}, nil
+}
+
+type recordingRevoker struct {
+	userID string
+	rev    sessiondomain.Revocation
+}
+
+func (r *recordingRevoker) RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error {
+	r.userID, r.rev = userID, rev
+	return nil
+}
+
+func TestRegistry_MarkAndUnmark(t *testing.T) {
+	repo := &memRepo{byUser: map[string]*domain.Honeytoken{}}
+	revoker := &recordingRevoker{}
+	r := NewRegistry(repo, memUsers{"u1": true}, revoker)
+	ctx := context.Background()
+
+	h, err := r.Mark(ctx, "u1", "  payroll share  ", "admin-1")
+	if err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+	if h.UserID != "u1" || h.Label != "payroll share" || h.CreatedBy != "admin-1" {
+		t.Errorf("honeytoken = %+v", h)
+	}
+	if revoker.userID != "u1" || revoker.rev.Reason != sessiondomain.RevocationAdminRevoke || revoker.rev.By != "admin-1" {
+		t.Errorf("revocation = %s %+v, want u1 admin_revoke by admin-1", revoker.userID, revoker.rev)
+	}
+
+	if h, err = r.Mark(ctx, "u1", "wiki", "admin-2"); err != nil || h.Label != "wiki" || h.CreatedBy != "admin-1" {
+		t.Errorf("relabel = %+v, %v; want label wiki, creator kept", h, err)
+	}
+
+	if err := r.Unmark(ctx, "u1"); err != nil {
+		t.Fatalf("Unmark: %v", err)
+	}
+	if err := r.Unmark(ctx, "u1"); !errors.Is(err, ErrNotHoneytoken) {
+		t.Errorf("second Unmark = %v, want ErrNotHoneytoken", err)
+	}
+}
+
+func TestRegistry_MarkValidation(t *testing.T) {
+	repo := &memRepo{byUser: map[string]*domain.Honeytoken{}}
+	r := NewRegistry(repo, memUsers{"u1": true}, nil)
+	ctx := context.Background()
+
+	if _, err := r.Mark(ctx, "u2", "", "admin-1"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("unknown user = %v, want ErrUserNotFound", err)
+	}
+	if _, err := r.Mark(ctx, "u1", strings.Repeat("x", MaxLabelLength+1), "admin-1"); !errors.Is(err, ErrLabelTooLong) {
+		t.Errorf("long label = %v, want ErrLabelTooLong", err)
+	}
+	if len(repo.byUser) != 0 {
+		t.Errorf("stored = %v, want nothing", repo.byUser)
+	}
+}
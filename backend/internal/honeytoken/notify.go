@@ -0,0 +1,55 @@
+package honeytoken
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+// EventTriggered is the type of the event sent for a sign-in attempt against a honeytoken.
+const EventTriggered = "honeytoken.triggered"
+
+// Event reports a sign-in attempt against a honeytoken. PasswordValid is true when the planted credential itself
+// was used, i.e. the place it was planted (Label) has leaked.
+type Event struct {
+	Type              string    `json:"type"`
+	UserID            string    `json:"user_id"`
+	Label             string    `json:"label,omitempty"`
+	Flow              string    `json:"flow"`             // login or verify_credentials
+	OrgID             string    `json:"org_id,omitempty"` // org the sign-in asked for
+	IP                string    `json:"ip,omitempty"`
+	UserAgent         string    `json:"user_agent,omitempty"`
+	DeviceFingerprint string    `json:"device_fingerprint,omitempty"`
+	PasswordValid     bool      `json:"password_valid"`
+	OccurredAt        time.Time `json:"occurred_at"`
+}
+
+// WebhookNotifier POSTs events as JSON to a URL. When Secret is set each request is signed (webhook.SignatureHeader).
+type WebhookNotifier struct {
+	URL        string
+	Secret     string
+	HTTPClient *http.Client
+}
+
+// NewWebhookNotifier returns a notifier that posts to url, signing with secret when it is non-empty.
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:        url,
+		Secret:     secret,
+		HTTPClient: webhook.NewHTTPClient(),
+	}
+}
+
+// Notify sends e in the background, so the rejected sign-in answers as fast as any other. Failures are logged and
+// not retried.
+func (w *WebhookNotifier) Notify(ctx context.Context, e Event) {
+	webhook.Go(fmt.Sprintf("honeytoken: webhook for user %s", e.UserID), func(ctx context.Context) error { return w.Send(ctx, e) })
+}
+
+// Send posts the honeytoken event e and waits for the response. Any non-2xx status is an error.
+func (w *WebhookNotifier) Send(ctx context.Context, e Event) error {
+	return webhook.Post(ctx, w.HTTPClient, w.URL, w.Secret, e)
+}
@@ -0,0 +1,46 @@
+package honeytoken
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var got Event
+	var body []byte
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(webhook.SignatureHeader)
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	e := Event{Type: EventTriggered, UserID: "u1", Label: "payroll share", Flow: "login", IP: "203.0.113.7", PasswordValid: true, OccurredAt: time.Now().UTC()}
+	if err := NewWebhookNotifier(srv.URL, "s3cret").Send(context.Background(), e); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Type != EventTriggered || got.UserID != "u1" || got.Label != "payroll share" || !got.PasswordValid {
+		t.Errorf("delivered event = %+v", got)
+	}
+	if signature != webhook.Sign("s3cret", body) {
+		t.Errorf("signature = %q, want HMAC of body", signature)
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	if err := NewWebhookNotifier(srv.URL, "").Send(context.Background(), Event{Type: EventTriggered}); err == nil {
+		t.Error("non-2xx response should be an error")
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/honeytoken/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a honeytoken repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Upsert marks the user as a honeytoken or updates its label.
+func (r *PostgresRepository) Upsert(ctx context.Context, h *domain.Honeytoken) (*domain.Honeytoken, error) {
+	row, err := r.queries.UpsertHoneytoken(ctx, gen.UpsertHoneytokenParams{
+		UserID:    h.UserID,
+		Label:     h.Label,
+		CreatedBy: h.CreatedBy,
+		CreatedAt: h.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return genHoneytokenToDomain(&row), nil
+}
+
+// Delete unmarks the user.
+func (r *PostgresRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	n, err := r.queries.DeleteHoneytoken(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Get returns the user's honeytoken, or nil if the user is not one.
+func (r *PostgresRepository) Get(ctx context.Context, userID string) (*domain.Honeytoken, error) {
+	row, err := r.queries.GetHoneytoken(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genHoneytokenToDomain(&row), nil
+}
+
+// List returns honeytokens, most recently marked first.
+func (r *PostgresRepository) List(ctx context.Context, limit, offset int32) ([]*domain.Honeytoken, error) {
+	rows, err := r.queries.ListHoneytokens(ctx, gen.ListHoneytokensParams{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Honeytoken, len(rows))
+	for i := range rows {
+		out[i] = genHoneytokenToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+// RecordTrigger counts a sign-in attempt against the honeytoken.
+func (r *PostgresRepository) RecordTrigger(ctx context.Context, userID string, at time.Time) error {
+	return r.queries.RecordHoneytokenTrigger(ctx, gen.RecordHoneytokenTriggerParams{
+		UserID:          userID,
+		LastTriggeredAt: sql.NullTime{Time: at, Valid: true},
+	})
+}
+
+func genHoneytokenToDomain(row *gen.Honeytoken) *domain.Honeytoken {
+	h := &domain.Honeytoken{
+		UserID:       row.UserID,
+		Label:        row.Label,
+		CreatedBy:    row.CreatedBy,
+		CreatedAt:    row.CreatedAt,
+		TriggerCount: row.TriggerCount,
+	}
+	if row.LastTriggeredAt.Valid {
+		t := row.LastTriggeredAt.Time
+		h.LastTriggeredAt = &t
+	}
+	return h
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/honeytoken/domain"
+)
+
+// Repository persists honeytokens.
+type Repository interface {
+	// Upsert marks h.UserID as a honeytoken, or updates the label of one already marked. Returns the stored
+	// honeytoken, whose creator, creation time and trigger history are kept on update.
+	Upsert(ctx context.Context, h *domain.Honeytoken) (*domain.Honeytoken, error)
+	// Delete unmarks the user. It reports false when the user was not a honeytoken.
+	Delete(ctx context.Context, userID string) (bool, error)
+	// Get returns the user's honeytoken, or nil if the user is not one.
+	Get(ctx context.Context, userID string) (*domain.Honeytoken, error)
+	// List returns honeytokens, most recently marked first.
+	List(ctx context.Context, limit, offset int32) ([]*domain.Honeytoken, error)
+	// RecordTrigger counts a sign-in attempt against the honeytoken at at.
+	RecordTrigger(ctx context.Context, userID string, at time.Time) error
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -10,7 +12,16 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	devicecodedomain "zero-trust-control-plane/backend/internal/devicecode/domain"
 	"zero-trust-control-plane/backend/internal/identity/service"
+	loginholddomain "zero-trust-control-plane/backend/internal/loginhold/domain"
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
 )
 
 // AuthServer implements AuthService (proto server) for register, login, refresh, logout, and identity linking.
@@ -25,11 +36,13 @@ func NewAuthServer(auth *service.AuthService) *AuthServer {
 	return &AuthServer{auth: auth}
 }
 
-// Register creates a new user and local identity.
+// Register creates a new user and local identity, accepting the invitation in invite_token if given.
 func (s *AuthServer) Register(ctx context.Context, req *authv1.RegisterRequest) (*authv1.AuthResponse, error) {
 	if s.auth == nil {
 		return nil, status.Error(codes.Unimplemented, "method Register not implemented")
 	}
+	ctx = service.ContextWithInviteToken(ctx, req.GetInviteToken())
+	ctx = service.ContextWithCaptchaToken(ctx, req.GetCaptchaToken())
 	res, err := s.auth.Register(ctx, req.GetEmail(), req.GetPassword(), req.GetName())
 	if err != nil {
 		return nil, authErr(err)
@@ -42,6 +55,10 @@ func (s *AuthServer) Login(ctx context.Context, req *authv1.LoginRequest) (*auth
 	if s.auth == nil {
 		return nil, status.Error(codes.Unimplemented, "method Login not implemented")
 	}
+	ctx = service.ContextWithPoPKey(ctx, req.GetPopPublicKey())
+	ctx = service.ContextWithMFAMethod(ctx, req.GetMfaMethod())
+	ctx = service.ContextWithTrustDays(ctx, int(req.GetTrustDays()))
+	ctx = service.ContextWithPublicDevice(ctx, req.GetPublicDevice())
 	res, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword(), req.GetOrgId(), req.GetDeviceFingerprint())
 	if err != nil {
 		return nil, authErr(err)
@@ -54,6 +71,8 @@ func (s *AuthServer) VerifyMFA(ctx context.Context, req *authv1.VerifyMFARequest
 	if s.auth == nil {
 		return nil, status.Error(codes.Unimplemented, "method VerifyMFA not implemented")
 	}
+	ctx = service.ContextWithPoPKey(ctx, req.GetPopPublicKey())
+	ctx = service.ContextWithTrustDays(ctx, int(req.GetTrustDays()))
 	res, err := s.auth.VerifyMFA(ctx, req.GetChallengeId(), req.GetOtp())
 	if err != nil {
 		return nil, authErr(err)
@@ -76,11 +95,30 @@ func (s *AuthServer) SubmitPhoneAndRequestMFA(ctx context.Context, req *authv1.S
 	}, nil
 }
 
+// ResendMFACode sends a new code for a pending MFA challenge, subject to the per-challenge resend cap and cooldown.
+func (s *AuthServer) ResendMFACode(ctx context.Context, req *authv1.ResendMFACodeRequest) (*authv1.ResendMFACodeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ResendMFACode not implemented")
+	}
+	res, err := s.auth.ResendMFACode(ctx, req.GetChallengeId())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.ResendMFACodeResponse{
+		ChallengeId:      res.ChallengeID,
+		PhoneMask:        res.PhoneMask,
+		ResendsRemaining: int32(res.ResendsRemaining),
+		NextResendAt:     timestamppb.New(res.NextResendAt),
+	}, nil
+}
+
 // Refresh issues new access and refresh tokens, or returns MFA required / phone required when device-trust policy requires it.
 func (s *AuthServer) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.RefreshResponse, error) {
 	if s.auth == nil {
 		return nil, status.Error(codes.Unimplemented, "method Refresh not implemented")
 	}
+	ctx = service.ContextWithPoPProof(ctx, req.GetPopProof())
+	ctx = service.ContextWithMFAMethod(ctx, req.GetMfaMethod())
 	res, err := s.auth.Refresh(ctx, req.GetRefreshToken(), req.GetDeviceFingerprint())
 	if err != nil {
 		return nil, authErr(err)
@@ -88,6 +126,340 @@ func (s *AuthServer) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*
 	return refreshResultToProto(res), nil
 }
 
+// CreateRefreshNonce returns a nonce the client signs into the pop_proof of its next Refresh of a key-bound session.
+func (s *AuthServer) CreateRefreshNonce(ctx context.Context, req *authv1.CreateRefreshNonceRequest) (*authv1.CreateRefreshNonceResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method CreateRefreshNonce not implemented")
+	}
+	nonce, expiresAt, err := s.auth.CreateRefreshNonce(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.CreateRefreshNonceResponse{Nonce: nonce, ExpiresAt: timestamppb.New(expiresAt)}, nil
+}
+
+// ChangePassword changes the caller's password after verifying the current one.
+func (s *AuthServer) ChangePassword(ctx context.Context, req *authv1.ChangePasswordRequest) (*authv1.ChangePasswordResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ChangePassword not implemented")
+	}
+	res, err := s.auth.ChangePassword(ctx, req.GetCurrentPassword(), req.GetNewPassword())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.ChangePasswordResponse{PasswordBreached: res.PasswordBreached}, nil
+}
+
+// RegenerateRecoveryCodes replaces the caller's MFA recovery codes after verifying the current password.
+func (s *AuthServer) RegenerateRecoveryCodes(ctx context.Context, req *authv1.RegenerateRecoveryCodesRequest) (*authv1.RegenerateRecoveryCodesResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+	}
+	recoveryCodes, err := s.auth.RegenerateRecoveryCodes(ctx, req.GetCurrentPassword())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.RegenerateRecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// StartPhoneChange sends a code to the caller's new phone (and to the current phone when the org requires step-up).
+func (s *AuthServer) StartPhoneChange(ctx context.Context, req *authv1.StartPhoneChangeRequest) (*authv1.StartPhoneChangeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method StartPhoneChange not implemented")
+	}
+	res, err := s.auth.StartPhoneChange(ctx, req.GetNewPhone())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.StartPhoneChangeResponse{
+		ChallengeId:             res.ChallengeID,
+		PhoneMask:               res.PhoneMask,
+		CurrentPhoneChallengeId: res.CurrentPhoneChallengeID,
+		CurrentPhoneMask:        res.CurrentPhoneMask,
+	}, nil
+}
+
+// ConfirmPhoneChange verifies the codes from StartPhoneChange and makes the new phone the caller's MFA phone.
+func (s *AuthServer) ConfirmPhoneChange(ctx context.Context, req *authv1.ConfirmPhoneChangeRequest) (*authv1.ConfirmPhoneChangeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ConfirmPhoneChange not implemented")
+	}
+	res, err := s.auth.ConfirmPhoneChange(ctx, req.GetChallengeId(), req.GetOtp(), req.GetCurrentPhoneChallengeId(), req.GetCurrentPhoneOtp())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.ConfirmPhoneChangeResponse{PhoneMask: res.PhoneMask, DevicesUntrusted: int32(res.DevicesUntrusted)}, nil
+}
+
+// StartEmailChange emails a confirmation link to the caller's current and new address.
+func (s *AuthServer) StartEmailChange(ctx context.Context, req *authv1.StartEmailChangeRequest) (*authv1.StartEmailChangeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method StartEmailChange not implemented")
+	}
+	res, err := s.auth.StartEmailChange(ctx, req.GetNewEmail())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.StartEmailChangeResponse{EmailChangeId: res.EmailChangeID, ExpiresAt: timestamppb.New(res.ExpiresAt)}, nil
+}
+
+// ConfirmEmailChange records that an email change link was opened and changes the email once both were. Public.
+func (s *AuthServer) ConfirmEmailChange(ctx context.Context, req *authv1.ConfirmEmailChangeRequest) (*authv1.ConfirmEmailChangeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ConfirmEmailChange not implemented")
+	}
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token required")
+	}
+	res, err := s.auth.ConfirmEmailChange(ctx, req.GetToken())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.ConfirmEmailChangeResponse{
+		CurrentEmailConfirmed: res.CurrentEmailConfirmed,
+		NewEmailConfirmed:     res.NewEmailConfirmed,
+		Completed:             res.Completed,
+		SessionsRevoked:       int32(res.SessionsRevoked),
+	}, nil
+}
+
+// CheckUsernameAvailability reports whether the caller can take a username.
+func (s *AuthServer) CheckUsernameAvailability(ctx context.Context, req *authv1.CheckUsernameAvailabilityRequest) (*authv1.CheckUsernameAvailabilityResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method CheckUsernameAvailability not implemented")
+	}
+	if req.GetUsername() == "" {
+		return nil, status.Error(codes.InvalidArgument, "username required")
+	}
+	res, err := s.auth.CheckUsernameAvailability(ctx, req.GetUsername())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.CheckUsernameAvailabilityResponse{
+		Username:  res.Username,
+		Available: res.Available,
+		Reason:    res.Reason,
+		Message:   res.Message,
+	}, nil
+}
+
+// SetUsername sets, changes or, with an empty username, removes the caller's username.
+func (s *AuthServer) SetUsername(ctx context.Context, req *authv1.SetUsernameRequest) (*authv1.SetUsernameResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetUsername not implemented")
+	}
+	name, err := s.auth.SetUsername(ctx, req.GetUsername())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.SetUsernameResponse{Username: name}, nil
+}
+
+// AdminResetMFA clears a member's MFA phone and recovery codes, revokes their sessions and device trust, and requires
+// MFA enrollment at their next sign-in. Caller must be org admin or owner.
+func (s *AuthServer) AdminResetMFA(ctx context.Context, req *authv1.AdminResetMFARequest) (*authv1.AdminResetMFAResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method AdminResetMFA not implemented")
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	res, err := s.auth.AdminResetMFA(ctx, req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.AdminResetMFAResponse{
+		DevicesUntrusted:     int32(res.DevicesUntrusted),
+		RecoveryCodesCleared: res.RecoveryCodesCleared,
+	}, nil
+}
+
+// ResumeLogin completes a sign-in held by Login once an org admin has approved it. Returns FailedPrecondition while
+// the hold is pending, so clients poll it.
+func (s *AuthServer) ResumeLogin(ctx context.Context, req *authv1.ResumeLoginRequest) (*authv1.LoginResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ResumeLogin not implemented")
+	}
+	if req.GetHoldId() == "" || req.GetHoldToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "hold_id and hold_token required")
+	}
+	ctx = service.ContextWithPoPKey(ctx, req.GetPopPublicKey())
+	ctx = service.ContextWithMFAMethod(ctx, req.GetMfaMethod())
+	res, err := s.auth.ResumeLogin(ctx, req.GetHoldId(), req.GetHoldToken())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return loginResultToProto(res), nil
+}
+
+// ApproveLogin lets a held sign-in of the caller's org complete. Caller must be org admin or owner, and not the user
+// signing in.
+func (s *AuthServer) ApproveLogin(ctx context.Context, req *authv1.ApproveLoginRequest) (*authv1.ApproveLoginResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ApproveLogin not implemented")
+	}
+	if req.GetHoldId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "hold_id required")
+	}
+	hold, err := s.auth.ApproveLogin(ctx, req.GetHoldId())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.ApproveLoginResponse{Hold: loginHoldToProto(hold)}, nil
+}
+
+// DenyLogin ends a held sign-in of the caller's org. Same caller rules as ApproveLogin.
+func (s *AuthServer) DenyLogin(ctx context.Context, req *authv1.DenyLoginRequest) (*authv1.DenyLoginResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method DenyLogin not implemented")
+	}
+	if req.GetHoldId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "hold_id required")
+	}
+	hold, err := s.auth.DenyLogin(ctx, req.GetHoldId())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.DenyLoginResponse{Hold: loginHoldToProto(hold)}, nil
+}
+
+// ListLoginHolds returns the pending holds of the caller's org, newest first. Caller must be org admin or owner.
+func (s *AuthServer) ListLoginHolds(ctx context.Context, req *authv1.ListLoginHoldsRequest) (*authv1.ListLoginHoldsResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListLoginHolds not implemented")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	holds, err := s.auth.ListLoginHolds(ctx, pageSize, offset)
+	if err != nil {
+		return nil, authErr(err)
+	}
+	out := make([]*authv1.LoginHold, len(holds))
+	for i, h := range holds {
+		out[i] = loginHoldToProto(h)
+	}
+	result := &authv1.ListLoginHoldsResponse{
+		Holds:      out,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(holds) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// StartDeviceAuthorization starts a device code sign-in for a client that cannot sign in itself. Public.
+func (s *AuthServer) StartDeviceAuthorization(ctx context.Context, req *authv1.StartDeviceAuthorizationRequest) (*authv1.StartDeviceAuthorizationResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method StartDeviceAuthorization not implemented")
+	}
+	res, err := s.auth.StartDeviceAuthorization(ctx, req.GetClientName(), req.GetDeviceFingerprint())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.StartDeviceAuthorizationResponse{
+		DeviceCode:              res.DeviceCode,
+		UserCode:                res.UserCode,
+		VerificationUri:         res.VerificationURI,
+		VerificationUriComplete: res.VerificationURIComplete,
+		ExpiresAt:               timestamppb.New(res.ExpiresAt),
+		IntervalSeconds:         int32(res.Interval / time.Second),
+	}, nil
+}
+
+// PollDeviceAuthorization exchanges an approved device code for tokens. Returns FailedPrecondition while the code
+// awaits approval, so clients poll it.
+func (s *AuthServer) PollDeviceAuthorization(ctx context.Context, req *authv1.PollDeviceAuthorizationRequest) (*authv1.AuthResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method PollDeviceAuthorization not implemented")
+	}
+	if req.GetDeviceCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_code required")
+	}
+	ctx = service.ContextWithPoPKey(ctx, req.GetPopPublicKey())
+	res, err := s.auth.PollDeviceAuthorization(ctx, req.GetDeviceCode())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return authResultToProto(res.Tokens), nil
+}
+
+// ApproveDeviceCode lets the device showing the user code sign in as the caller. The caller's session must be on a
+// trusted device.
+func (s *AuthServer) ApproveDeviceCode(ctx context.Context, req *authv1.ApproveDeviceCodeRequest) (*authv1.ApproveDeviceCodeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method ApproveDeviceCode not implemented")
+	}
+	if req.GetUserCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_code required")
+	}
+	dc, err := s.auth.ApproveDeviceCode(ctx, req.GetUserCode())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.ApproveDeviceCodeResponse{DeviceAuthorization: deviceAuthorizationToProto(dc)}, nil
+}
+
+// DenyDeviceCode ends the device code sign-in showing the user code.
+func (s *AuthServer) DenyDeviceCode(ctx context.Context, req *authv1.DenyDeviceCodeRequest) (*authv1.DenyDeviceCodeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method DenyDeviceCode not implemented")
+	}
+	if req.GetUserCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_code required")
+	}
+	dc, err := s.auth.DenyDeviceCode(ctx, req.GetUserCode())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.DenyDeviceCodeResponse{DeviceAuthorization: deviceAuthorizationToProto(dc)}, nil
+}
+
+// RequestMagicLink emails a one-time sign-in link if email belongs to a member of the org. Public; the response does
+// not say whether a link was sent.
+func (s *AuthServer) RequestMagicLink(ctx context.Context, req *authv1.RequestMagicLinkRequest) (*authv1.RequestMagicLinkResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method RequestMagicLink not implemented")
+	}
+	if req.GetEmail() == "" || req.GetOrgId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and org_id required")
+	}
+	if err := s.auth.RequestMagicLink(ctx, req.GetEmail(), req.GetOrgId()); err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.RequestMagicLinkResponse{}, nil
+}
+
+// CompleteMagicLink redeems a magic link and continues the sign-in as Login does. Public.
+func (s *AuthServer) CompleteMagicLink(ctx context.Context, req *authv1.CompleteMagicLinkRequest) (*authv1.LoginResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method CompleteMagicLink not implemented")
+	}
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token required")
+	}
+	ctx = service.ContextWithPoPKey(ctx, req.GetPopPublicKey())
+	ctx = service.ContextWithMFAMethod(ctx, req.GetMfaMethod())
+	res, err := s.auth.CompleteMagicLink(ctx, req.GetToken(), req.GetDeviceFingerprint())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return loginResultToProto(res), nil
+}
+
 // Logout invalidates the session identified by the refresh token.
 func (s *AuthServer) Logout(ctx context.Context, req *authv1.LogoutRequest) (*emptypb.Empty, error) {
 	if s.auth == nil {
@@ -99,16 +471,122 @@ func (s *AuthServer) Logout(ctx context.Context, req *authv1.LogoutRequest) (*em
 	return &emptypb.Empty{}, nil
 }
 
-// VerifyCredentials validates email/password and returns user_id. Used for org-creation flow.
+// LogoutAllMySessions revokes the caller's sessions on every device, optionally keeping the current one.
+func (s *AuthServer) LogoutAllMySessions(ctx context.Context, req *authv1.LogoutAllMySessionsRequest) (*authv1.LogoutAllMySessionsResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method LogoutAllMySessions not implemented")
+	}
+	revoked, err := s.auth.LogoutAllMySessions(ctx, req.GetKeepCurrent(), req.GetCurrentPassword())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.LogoutAllMySessionsResponse{SessionsRevoked: int32(revoked)}, nil
+}
+
+// VerifyCredentials checks email/password without issuing tokens and reports account status and, when org_id is
+// set, whether Login would require MFA. Used for org-creation flow.
 func (s *AuthServer) VerifyCredentials(ctx context.Context, req *authv1.VerifyCredentialsRequest) (*authv1.VerifyCredentialsResponse, error) {
 	if s.auth == nil {
 		return nil, status.Error(codes.Unimplemented, "method VerifyCredentials not implemented")
 	}
-	userID, err := s.auth.VerifyCredentials(ctx, req.GetEmail(), req.GetPassword())
+	res, err := s.auth.VerifyCredentials(ctx, req.GetEmail(), req.GetPassword(), req.GetOrgId(), req.GetDeviceFingerprint())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.VerifyCredentialsResponse{
+		UserId:             res.UserID,
+		Valid:              res.Valid,
+		MfaWouldBeRequired: res.MFAWouldBeRequired,
+		AccountStatus:      string(res.AccountStatus),
+	}, nil
+}
+
+// DiscoverOrganizations returns what a one-field sign-in screen asks next and the orgs to offer. Public.
+func (s *AuthServer) DiscoverOrganizations(ctx context.Context, req *authv1.DiscoverOrganizationsRequest) (*authv1.DiscoverOrganizationsResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method DiscoverOrganizations not implemented")
+	}
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email required")
+	}
+	res, err := s.auth.DiscoverOrganizations(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, authErr(err)
+	}
+	orgs := make([]*authv1.DiscoveredOrganization, len(res.Organizations))
+	for i, o := range res.Organizations {
+		orgs[i] = &authv1.DiscoveredOrganization{OrgId: o.ID, Name: o.Name}
+	}
+	return &authv1.DiscoverOrganizationsResponse{NextStep: res.NextStep, Organizations: orgs}, nil
+}
+
+// TokenExchange swaps the caller's access token for a short-lived token scoped to one target service.
+func (s *AuthServer) TokenExchange(ctx context.Context, req *authv1.TokenExchangeRequest) (*authv1.TokenExchangeResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method TokenExchange not implemented")
+	}
+	ttl := time.Duration(req.GetRequestedTtlSeconds()) * time.Second
+	res, err := s.auth.ExchangeToken(ctx, req.GetAudience(), req.GetScope(), ttl)
+	if err != nil {
+		return nil, authErr(err)
+	}
+	return &authv1.TokenExchangeResponse{
+		AccessToken:     res.Token,
+		IssuedTokenType: security.IssuedTokenTypeJWT,
+		TokenType:       "Bearer",
+		ExpiresAt:       timestamppb.New(res.ExpiresAt),
+		Audience:        res.Audience,
+		Scope:           res.Scope,
+	}, nil
+}
+
+// GetJWKS returns the keys that verify access and resource tokens. Public, so services can validate tokens without
+// calling the control plane per request.
+func (s *AuthServer) GetJWKS(ctx context.Context, req *authv1.GetJWKSRequest) (*authv1.GetJWKSResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetJWKS not implemented")
+	}
+	res, err := s.auth.JWKS()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to encode signing keys")
+	}
+	return &authv1.GetJWKSResponse{Jwks: string(res.JWKS), Issuer: res.Issuer, Audience: res.Audience}, nil
+}
+
+// Introspect reports whether an access token is valid right now, with its user, org, session, device trust and
+// sign-in factors. Only service accounts may call it (PermissionDenied otherwise).
+func (s *AuthServer) Introspect(ctx context.Context, req *authv1.IntrospectRequest) (*authv1.IntrospectResponse, error) {
+	if s.auth == nil {
+		return nil, status.Error(codes.Unimplemented, "method Introspect not implemented")
+	}
+	res, err := s.auth.Introspect(ctx, req.GetToken())
 	if err != nil {
 		return nil, authErr(err)
 	}
-	return &authv1.VerifyCredentialsResponse{UserId: userID}, nil
+	resp := &authv1.IntrospectResponse{
+		Active:          res.Active,
+		CacheTtlSeconds: int32(res.CacheTTL / time.Second),
+	}
+	if !res.Active {
+		return resp, nil
+	}
+	resp.UserId = res.UserID
+	resp.OrgId = res.OrgID
+	resp.SessionId = res.SessionID
+	resp.Jti = res.TokenID
+	resp.DeviceId = res.DeviceID
+	resp.DeviceTrusted = res.DeviceTrusted
+	resp.AuthMethods = res.AuthMethods
+	if !res.IssuedAt.IsZero() {
+		resp.IssuedAt = timestamppb.New(res.IssuedAt)
+	}
+	if !res.ExpiresAt.IsZero() {
+		resp.ExpiresAt = timestamppb.New(res.ExpiresAt)
+	}
+	if res.DeviceTrustedUntil != nil {
+		resp.DeviceTrustedUntil = timestamppb.New(*res.DeviceTrustedUntil)
+	}
+	return resp, nil
 }
 
 // LinkIdentity associates an external identity with the current user. Not implemented for password-only auth.
@@ -120,22 +598,120 @@ func authErr(err error) error {
 	switch {
 	case errors.Is(err, service.ErrEmailAlreadyRegistered):
 		return status.Error(codes.AlreadyExists, "email already registered")
+	case errors.Is(err, service.ErrRegistrationClosed):
+		return status.Error(codes.PermissionDenied, "registration is closed")
+	case errors.Is(err, service.ErrInvitationRequired):
+		return status.Error(codes.PermissionDenied, "registration requires an invitation")
+	case errors.Is(err, service.ErrInvalidInvitation):
+		return status.Error(codes.PermissionDenied, "invalid, used or expired invitation")
+	case errors.Is(err, service.ErrCaptchaFailed):
+		return status.Error(codes.PermissionDenied, "CAPTCHA verification failed")
+	case errors.Is(err, service.ErrEmailNotAllowed):
+		return status.Error(codes.PermissionDenied, "this email address cannot be used to register")
+	case errors.Is(err, service.ErrSeatLimitReached):
+		return status.Error(codes.ResourceExhausted, "every seat of the license is taken")
+	case errors.Is(err, service.ErrLicenseExpired):
+		return status.Error(codes.FailedPrecondition, "the license has expired")
 	case errors.Is(err, service.ErrInvalidCredentials):
 		return status.Error(codes.Unauthenticated, "invalid credentials")
 	case errors.Is(err, service.ErrInvalidRefreshToken):
 		return status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+	case errors.Is(err, service.ErrInvalidPoPProof):
+		return status.Error(codes.Unauthenticated, "missing or invalid proof-of-possession proof")
+	case errors.Is(err, service.ErrInvalidPoPKey):
+		return status.Error(codes.InvalidArgument, "invalid proof-of-possession key")
 	case errors.Is(err, service.ErrRefreshTokenReuse):
 		return status.Error(codes.Unauthenticated, "refresh token reuse detected; all sessions revoked")
 	case errors.Is(err, service.ErrNotOrgMember):
 		return status.Error(codes.PermissionDenied, "user is not a member of the organization")
+	case errors.Is(err, service.ErrServiceAccountRequired):
+		return status.Error(codes.PermissionDenied, "service account required")
+	case errors.Is(err, service.ErrOrgAdminRequired):
+		return status.Error(codes.PermissionDenied, "organization admin or owner required")
 	case errors.Is(err, service.ErrPhoneRequiredForMFA):
 		return status.Error(codes.FailedPrecondition, "phone number required for MFA; add in profile")
 	case errors.Is(err, service.ErrInvalidMFAChallenge), errors.Is(err, service.ErrInvalidOTP):
 		return status.Error(codes.Unauthenticated, "invalid or expired MFA challenge")
+	case errors.Is(err, service.ErrMFAMethodUnavailable):
+		return status.Error(codes.FailedPrecondition, "requested MFA method is not available")
+	case errors.Is(err, service.ErrRecoveryCodesDisabled):
+		return status.Error(codes.FailedPrecondition, "MFA recovery codes are not enabled")
+	case errors.Is(err, service.ErrMFAAttemptsExceeded):
+		return status.Error(codes.FailedPrecondition, "too many incorrect codes; sign in again")
+	case errors.Is(err, service.ErrMFAResendCooldown):
+		return status.Error(codes.ResourceExhausted, "a code was sent recently; wait before requesting another")
+	case errors.Is(err, service.ErrMFAResendLimit):
+		return status.Error(codes.ResourceExhausted, "no more codes can be sent for this MFA challenge; sign in again")
 	case errors.Is(err, service.ErrInvalidMFAIntent):
 		return status.Error(codes.Unauthenticated, "invalid or expired MFA intent")
 	case errors.Is(err, service.ErrChallengeExpired):
 		return status.Error(codes.FailedPrecondition, "MFA challenge expired")
+	case errors.Is(err, service.ErrIPBlocked):
+		return status.Error(codes.PermissionDenied, "sign-in from this network is temporarily blocked")
+	case errors.Is(err, service.ErrNetworkNotAllowed):
+		return status.Error(codes.PermissionDenied, "sign-in from this network is not allowed by organization policy")
+	case errors.Is(err, service.ErrOutsideAccessWindow):
+		return status.Error(codes.FailedPrecondition, "sign-in is not allowed at this time by organization policy")
+	case errors.Is(err, service.ErrAudienceNotAllowed):
+		return status.Error(codes.PermissionDenied, "token exchange is not allowed for this audience")
+	case errors.Is(err, service.ErrFeatureDisabled):
+		return status.Error(codes.FailedPrecondition, "this feature is not enabled for the organization")
+	case errors.Is(err, service.ErrBreachedPassword):
+		return status.Error(codes.InvalidArgument, "password has appeared in a data breach; choose a different password")
+	case errors.Is(err, service.ErrPhoneUnchanged):
+		return status.Error(codes.InvalidArgument, "new phone number is the same as the current one")
+	case errors.Is(err, service.ErrRateLimited):
+		return status.Error(codes.ResourceExhausted, "too many attempts; try again later")
+	case errors.Is(err, service.ErrLoginHoldsDisabled):
+		return status.Error(codes.FailedPrecondition, "login holds are not enabled")
+	case errors.Is(err, service.ErrInvalidLoginHold):
+		return status.Error(codes.Unauthenticated, "invalid or expired login hold")
+	case errors.Is(err, service.ErrLoginHoldPending):
+		return status.Error(codes.FailedPrecondition, "sign-in is waiting for an administrator's approval")
+	case errors.Is(err, service.ErrLoginHoldDenied):
+		return status.Error(codes.PermissionDenied, "sign-in was denied by an administrator")
+	case errors.Is(err, service.ErrLoginHoldNotFound):
+		return status.Error(codes.NotFound, "login hold not found")
+	case errors.Is(err, service.ErrLoginHoldNotPending):
+		return status.Error(codes.FailedPrecondition, "login hold is no longer pending")
+	case errors.Is(err, service.ErrLoginHoldSelfDecision):
+		return status.Error(codes.PermissionDenied, "cannot approve or deny your own sign-in")
+	case errors.Is(err, service.ErrDeviceCodesDisabled):
+		return status.Error(codes.FailedPrecondition, "device code sign-in is not enabled")
+	case errors.Is(err, service.ErrInvalidDeviceCode):
+		return status.Error(codes.Unauthenticated, "invalid or expired device code")
+	case errors.Is(err, service.ErrDeviceCodePending):
+		return status.Error(codes.FailedPrecondition, "device code is waiting for approval")
+	case errors.Is(err, service.ErrDeviceCodeDenied):
+		return status.Error(codes.PermissionDenied, "device code sign-in was denied")
+	case errors.Is(err, service.ErrDeviceCodeNotFound):
+		return status.Error(codes.NotFound, "device code not found or expired")
+	case errors.Is(err, service.ErrStepUpRequired):
+		return status.Error(codes.FailedPrecondition, "confirm with your current password or sign in again with MFA")
+	case errors.Is(err, service.ErrSessionExpired):
+		return status.Error(codes.Unauthenticated, "session expired; sign in again")
+	case errors.Is(err, service.ErrDeviceQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, "organization has reached its device quota")
+	case errors.Is(err, service.ErrUserQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, "organization has reached its user quota")
+	case errors.Is(err, service.ErrTrustedDeviceRequired):
+		return status.Error(codes.PermissionDenied, "this action requires a session on a trusted device")
+	case errors.Is(err, service.ErrMagicLinksDisabled):
+		return status.Error(codes.FailedPrecondition, "magic link sign-in is not enabled for this organization")
+	case errors.Is(err, service.ErrInvalidMagicLink):
+		return status.Error(codes.Unauthenticated, "invalid, used or expired magic link")
+	case errors.Is(err, service.ErrEmailChangeDisabled):
+		return status.Error(codes.FailedPrecondition, "email change is not enabled")
+	case errors.Is(err, service.ErrInvalidEmailChange):
+		return status.Error(codes.Unauthenticated, "invalid, replaced or expired email change link")
+	case errors.Is(err, service.ErrEmailUnchanged):
+		return status.Error(codes.InvalidArgument, "new email is the same as the current one")
+	case errors.Is(err, service.ErrUsernamesDisabled):
+		return status.Error(codes.FailedPrecondition, "usernames are not enabled")
+	case errors.Is(err, service.ErrUsernameTaken):
+		return status.Error(codes.AlreadyExists, "username is taken")
+	case errors.Is(err, service.ErrOrgDiscoveryDisabled):
+		return status.Error(codes.FailedPrecondition, "organization discovery is not enabled")
 	default:
 		if err != nil {
 			return status.Error(codes.InvalidArgument, err.Error())
@@ -144,6 +720,16 @@ func authErr(err error) error {
 	}
 }
 
+func mfaRequiredToProto(r *service.MFARequiredResult) *authv1.MFARequired {
+	return &authv1.MFARequired{
+		ChallengeId:      r.ChallengeID,
+		PhoneMask:        r.PhoneMask,
+		Method:           r.Method,
+		AvailableMethods: r.AvailableMethods,
+		CodeNotSent:      r.CodeNotSent,
+	}
+}
+
 func loginResultToProto(r *service.LoginResult) *authv1.LoginResponse {
 	if r == nil {
 		return &authv1.LoginResponse{}
@@ -156,10 +742,7 @@ func loginResultToProto(r *service.LoginResult) *authv1.LoginResponse {
 	if r.MFARequired != nil {
 		return &authv1.LoginResponse{
 			Result: &authv1.LoginResponse_MfaRequired{
-				MfaRequired: &authv1.MFARequired{
-					ChallengeId: r.MFARequired.ChallengeID,
-					PhoneMask:   r.MFARequired.PhoneMask,
-				},
+				MfaRequired: mfaRequiredToProto(r.MFARequired),
 			},
 		}
 	}
@@ -172,9 +755,52 @@ func loginResultToProto(r *service.LoginResult) *authv1.LoginResponse {
 			},
 		}
 	}
+	if r.ApprovalRequired != nil {
+		return &authv1.LoginResponse{
+			Result: &authv1.LoginResponse_ApprovalRequired{
+				ApprovalRequired: &authv1.ApprovalRequired{
+					HoldId:    r.ApprovalRequired.HoldID,
+					HoldToken: r.ApprovalRequired.HoldToken,
+					ExpiresAt: timestamppb.New(r.ApprovalRequired.ExpiresAt),
+				},
+			},
+		}
+	}
 	return &authv1.LoginResponse{}
 }
 
+func loginHoldToProto(h *loginholddomain.Hold) *authv1.LoginHold {
+	out := &authv1.LoginHold{
+		Id:        h.ID,
+		OrgId:     h.OrgID,
+		UserId:    h.UserID,
+		DeviceId:  h.DeviceID,
+		Reasons:   h.Reasons,
+		Ip:        h.IP,
+		Status:    string(h.EffectiveStatus(time.Now().UTC())),
+		DecidedBy: h.DecidedBy,
+		CreatedAt: timestamppb.New(h.CreatedAt),
+		ExpiresAt: timestamppb.New(h.ExpiresAt),
+	}
+	if h.DecidedAt != nil {
+		out.DecidedAt = timestamppb.New(*h.DecidedAt)
+	}
+	return out
+}
+
+func deviceAuthorizationToProto(d *devicecodedomain.DeviceCode) *authv1.DeviceAuthorization {
+	return &authv1.DeviceAuthorization{
+		Id:         d.ID,
+		ClientName: d.ClientName,
+		Ip:         d.IP,
+		Status:     string(d.Status),
+		UserId:     d.UserID,
+		OrgId:      d.OrgID,
+		CreatedAt:  timestamppb.New(d.CreatedAt),
+		ExpiresAt:  timestamppb.New(d.ExpiresAt),
+	}
+}
+
 func refreshResultToProto(r *service.RefreshResult) *authv1.RefreshResponse {
 	if r == nil {
 		return &authv1.RefreshResponse{}
@@ -187,10 +813,7 @@ func refreshResultToProto(r *service.RefreshResult) *authv1.RefreshResponse {
 	if r.MFARequired != nil {
 		return &authv1.RefreshResponse{
 			Result: &authv1.RefreshResponse_MfaRequired{
-				MfaRequired: &authv1.MFARequired{
-					ChallengeId: r.MFARequired.ChallengeID,
-					PhoneMask:   r.MFARequired.PhoneMask,
-				},
+				MfaRequired: mfaRequiredToProto(r.MFARequired),
 			},
 		}
 	}
@@ -211,10 +834,12 @@ func authResultToProto(r *service.AuthResult) *authv1.AuthResponse {
 		return &authv1.AuthResponse{}
 	}
 	out := &authv1.AuthResponse{
-		AccessToken:  r.AccessToken,
-		RefreshToken: r.RefreshToken,
-		UserId:       r.UserID,
-		OrgId:        r.OrgID,
+		AccessToken:      r.AccessToken,
+		RefreshToken:     r.RefreshToken,
+		UserId:           r.UserID,
+		OrgId:            r.OrgID,
+		PasswordBreached: r.PasswordBreached,
+		RecoveryCodes:    r.RecoveryCodes,
 	}
 	if !r.ExpiresAt.IsZero() {
 		out.ExpiresAt = timestamppb.New(r.ExpiresAt)
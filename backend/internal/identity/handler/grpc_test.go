@@ -11,7 +11,9 @@ import (
 
 	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
 	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	devicecodedomain "zero-trust-control-plane/backend/internal/devicecode/domain"
 	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/license"
 	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
 	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
 	mfaintentdomain "zero-trust-control-plane/backend/internal/mfaintent/domain"
@@ -20,6 +22,7 @@ import (
 	policyengine "zero-trust-control-plane/backend/internal/policy/engine"
 	"zero-trust-control-plane/backend/internal/security"
 	"zero-trust-control-plane/backend/internal/identity/service"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
 	userdomain "zero-trust-control-plane/backend/internal/user/domain"
 )
@@ -44,6 +47,78 @@ func TestRegister_NilAuthService(t *testing.T) {
 	}
 }
 
+func TestTokenExchange_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.TokenExchange(context.Background(), &authv1.TokenExchangeRequest{Audience: "payroll-gateway"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestGetJWKS_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.GetJWKS(context.Background(), &authv1.GetJWKSRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestCreateRefreshNonce_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.CreateRefreshNonce(context.Background(), &authv1.CreateRefreshNonceRequest{RefreshToken: "rt"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestChangePassword_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.ChangePassword(context.Background(), &authv1.ChangePasswordRequest{CurrentPassword: "old", NewPassword: "new"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestRegenerateRecoveryCodes_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.RegenerateRecoveryCodes(context.Background(), &authv1.RegenerateRecoveryCodesRequest{CurrentPassword: "pw"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestResendMFACode_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.ResendMFACode(context.Background(), &authv1.ResendMFACodeRequest{ChallengeId: "challenge-1"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestAdminResetMFA_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.AdminResetMFA(context.Background(), &authv1.AdminResetMFARequest{UserId: "user-1"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestStartPhoneChange_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.StartPhoneChange(context.Background(), &authv1.StartPhoneChangeRequest{NewPhone: "15559876543"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestConfirmPhoneChange_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	_, err := srv.ConfirmPhoneChange(context.Background(), &authv1.ConfirmPhoneChangeRequest{ChallengeId: "challenge-1", Otp: "123456"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
 func TestLogin_NilAuthService(t *testing.T) {
 	srv := NewAuthServer(nil)
 	ctx := context.Background()
@@ -187,6 +262,111 @@ func TestAuthErr_EmailAlreadyRegistered(t *testing.T) {
 	}
 }
 
+func TestAuthErr_IPBlocked(t *testing.T) {
+	err := authErr(service.ErrIPBlocked)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.PermissionDenied)
+	}
+}
+
+func TestAuthErr_NetworkNotAllowed(t *testing.T) {
+	err := authErr(service.ErrNetworkNotAllowed)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.PermissionDenied)
+	}
+}
+
+func TestAuthErr_OutsideAccessWindow(t *testing.T) {
+	err := authErr(service.ErrOutsideAccessWindow)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.FailedPrecondition)
+	}
+}
+
+func TestAuthErr_AudienceNotAllowed(t *testing.T) {
+	err := authErr(service.ErrAudienceNotAllowed)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.PermissionDenied)
+	}
+}
+
+func TestAuthErr_InvalidPoPProof(t *testing.T) {
+	err := authErr(service.ErrInvalidPoPProof)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestAuthErr_FeatureDisabled(t *testing.T) {
+	err := authErr(service.ErrFeatureDisabled)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.FailedPrecondition)
+	}
+}
+
+func TestAuthErr_MFAMethodUnavailable(t *testing.T) {
+	err := authErr(service.ErrMFAMethodUnavailable)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.FailedPrecondition)
+	}
+}
+
+func TestAuthErr_RecoveryCodesDisabled(t *testing.T) {
+	err := authErr(service.ErrRecoveryCodesDisabled)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.FailedPrecondition)
+	}
+}
+
+func TestAuthErr_MFAAttemptsExceeded(t *testing.T) {
+	err := authErr(service.ErrMFAAttemptsExceeded)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.FailedPrecondition)
+	}
+}
+
+func TestAuthErr_MFAResendCooldown(t *testing.T) {
+	err := authErr(service.ErrMFAResendCooldown)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+}
+
+func TestAuthErr_MFAResendLimit(t *testing.T) {
+	err := authErr(service.ErrMFAResendLimit)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+}
+
+func TestAuthErr_OrgAdminRequired(t *testing.T) {
+	err := authErr(service.ErrOrgAdminRequired)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.PermissionDenied)
+	}
+}
+
+func TestAuthErr_PhoneUnchanged(t *testing.T) {
+	err := authErr(service.ErrPhoneUnchanged)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestAuthErr_BreachedPassword(t *testing.T) {
+	err := authErr(service.ErrBreachedPassword)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestAuthErr_RateLimited(t *testing.T) {
+	err := authErr(service.ErrRateLimited)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+}
+
 func TestAuthErr_InvalidCredentials(t *testing.T) {
 	err := authErr(service.ErrInvalidCredentials)
 	st, ok := status.FromError(err)
@@ -322,8 +502,10 @@ func TestLoginResultToProto_Tokens(t *testing.T) {
 func TestLoginResultToProto_MFARequired(t *testing.T) {
 	result := &service.LoginResult{
 		MFARequired: &service.MFARequiredResult{
-			ChallengeID: "challenge-1",
-			PhoneMask:   "***-1234",
+			ChallengeID:      "challenge-1",
+			PhoneMask:        "***-1234",
+			Method:           "sms_otp",
+			AvailableMethods: []string{"sms_otp", "push"},
 		},
 	}
 	proto := loginResultToProto(result)
@@ -333,6 +515,9 @@ func TestLoginResultToProto_MFARequired(t *testing.T) {
 	if proto.GetMfaRequired().ChallengeId != "challenge-1" {
 		t.Errorf("challenge_id = %q, want %q", proto.GetMfaRequired().ChallengeId, "challenge-1")
 	}
+	if m := proto.GetMfaRequired(); m.Method != "sms_otp" || len(m.AvailableMethods) != 2 || m.AvailableMethods[1] != "push" {
+		t.Errorf("method/available_methods = %q/%v, want sms_otp/[sms_otp push]", m.Method, m.AvailableMethods)
+	}
 }
 
 func TestLoginResultToProto_PhoneRequired(t *testing.T) {
@@ -384,6 +569,13 @@ func TestAuthResultToProto(t *testing.T) {
 	if proto.ExpiresAt == nil {
 		t.Error("expires_at should be set")
 	}
+	if len(proto.RecoveryCodes) != 0 {
+		t.Errorf("recovery_codes = %v, want none", proto.RecoveryCodes)
+	}
+	result.RecoveryCodes = []string{"abcde-fghjk"}
+	if got := authResultToProto(result).RecoveryCodes; len(got) != 1 || got[0] != "abcde-fghjk" {
+		t.Errorf("recovery_codes = %v, want [abcde-fghjk]", got)
+	}
 }
 
 // Test helper struct to hold repositories for test setup
@@ -392,6 +584,7 @@ type testAuthServiceSetup struct {
 	userRepo       *memUserRepo
 	membershipRepo *memMembershipRepo
 	deviceRepo     *memDeviceRepo
+	sessionRepo    *memSessionRepo
 	mfaChallengeRepo *memMFAChallengeRepo
 	mfaIntentRepo  *memMFAIntentRepo
 }
@@ -401,6 +594,7 @@ func newTestAuthServiceForHandler(t *testing.T) *testAuthServiceSetup {
 	t.Helper()
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -442,6 +636,7 @@ func newTestAuthServiceForHandler(t *testing.T) *testAuthServiceSetup {
 		userRepo:       userRepo,
 		membershipRepo: membershipRepo,
 		deviceRepo:     deviceRepo,
+		sessionRepo:    sessionRepo,
 		mfaChallengeRepo: mfaChallengeRepo,
 		mfaIntentRepo:  mfaIntentRepo,
 	}
@@ -449,9 +644,10 @@ func newTestAuthServiceForHandler(t *testing.T) *testAuthServiceSetup {
 
 // Mock repository types (simplified versions for handler tests)
 type memUserRepo struct {
-	mu      sync.Mutex
-	byID    map[string]*userdomain.User
-	byEmail map[string]*userdomain.User
+	mu         sync.Mutex
+	byID       map[string]*userdomain.User
+	byEmail    map[string]*userdomain.User
+	identities *memIdentityRepo // receives the identities of CreateWithIdentity
 }
 
 func (r *memUserRepo) GetByID(ctx context.Context, id string) (*userdomain.User, error) {
@@ -474,6 +670,18 @@ func (r *memUserRepo) Create(ctx context.Context, u *userdomain.User) error {
 	return nil
 }
 
+func (r *memUserRepo) CreateWithIdentity(ctx context.Context, u *userdomain.User, i *identitydomain.Identity, seats license.SeatReserver) error {
+	if seats != nil {
+		if err := seats.ReserveSeat(ctx, nil); err != nil {
+			return err
+		}
+	}
+	if err := r.Create(ctx, u); err != nil {
+		return err
+	}
+	return r.identities.Create(ctx, i)
+}
+
 func (r *memUserRepo) SetPhoneVerified(ctx context.Context, userID, phone string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -487,6 +695,50 @@ func (r *memUserRepo) SetPhoneVerified(ctx context.Context, userID, phone string
 	return nil
 }
 
+func (r *memUserRepo) ResetMFA(ctx context.Context, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.byID[userID]
+	if !ok {
+		return false, nil
+	}
+	u2 := *u
+	u2.Phone, u2.PhoneVerified, u2.MFAResetRequired = "", false, true
+	r.byID[userID] = &u2
+	r.byEmail[u.Email] = &u2
+	return true, nil
+}
+
+func (r *memUserRepo) ChangeEmail(ctx context.Context, userID, oldEmail, newEmail string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.byID[userID]
+	if !ok || u.Email != oldEmail {
+		return false, nil
+	}
+	u2 := *u
+	u2.Email = newEmail
+	r.byID[userID] = &u2
+	delete(r.byEmail, oldEmail)
+	r.byEmail[newEmail] = &u2
+	return true, nil
+}
+
+func (r *memUserRepo) ChangePhone(ctx context.Context, userID, oldPhone, newPhone string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.byID[userID]
+	if !ok || u.Phone != oldPhone {
+		return false, nil
+	}
+	u2 := *u
+	u2.Phone = newPhone
+	u2.PhoneVerified = true
+	r.byID[userID] = &u2
+	r.byEmail[u.Email] = &u2
+	return true, nil
+}
+
 type memIdentityRepo struct {
 	mu sync.Mutex
 	m  map[string]*identitydomain.Identity
@@ -510,6 +762,15 @@ func (r *memIdentityRepo) Create(ctx context.Context, i *identitydomain.Identity
 	return nil
 }
 
+func (r *memIdentityRepo) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i, ok := r.m[id]; ok {
+		i.PasswordHash = passwordHash
+	}
+	return nil
+}
+
 type memSessionRepo struct {
 	mu sync.Mutex
 	m  map[string]*sessiondomain.Session
@@ -529,34 +790,49 @@ func (r *memSessionRepo) Create(ctx context.Context, s *sessiondomain.Session) e
 	return nil
 }
 
-func (r *memSessionRepo) Revoke(ctx context.Context, id string) error {
+func (r *memSessionRepo) Revoke(ctx context.Context, id string, rev sessiondomain.Revocation) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if s, ok := r.m[id]; ok {
+	if s, ok := r.m[id]; ok && s.RevokedAt == nil {
 		t := time.Now()
-		s.RevokedAt = &t
+		s.RevokedAt, s.RevocationReason, s.RevokedBy = &t, rev.Reason, rev.By
 	}
 	return nil
 }
 
-func (r *memSessionRepo) RevokeAllSessionsByUser(ctx context.Context, userID string) error {
+func (r *memSessionRepo) RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	t := time.Now()
 	for _, s := range r.m {
-		if s.UserID == userID {
-			s.RevokedAt = &t
+		if s.UserID == userID && s.RevokedAt == nil {
+			s.RevokedAt, s.RevocationReason, s.RevokedBy = &t, rev.Reason, rev.By
 		}
 	}
 	return nil
 }
 
-func (r *memSessionRepo) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string) error {
+func (r *memSessionRepo) RevokeOtherSessionsByUser(ctx context.Context, userID, exceptSessionID string, rev sessiondomain.Revocation) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := time.Now()
+	var n int64
+	for _, s := range r.m {
+		if s.UserID == userID && s.ID != exceptSessionID && s.RevokedAt == nil {
+			s.RevokedAt, s.RevocationReason, s.RevokedBy = &t, rev.Reason, rev.By
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *memSessionRepo) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string, expiresAt time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if s, ok := r.m[sessionID]; ok {
 		s.RefreshJti = jti
 		s.RefreshTokenHash = refreshTokenHash
+		s.ExpiresAt = expiresAt
 	}
 	return nil
 }
@@ -587,6 +863,18 @@ func (r *memDeviceRepo) GetByUserOrgAndFingerprint(ctx context.Context, userID,
 	return nil, nil
 }
 
+func (r *memDeviceRepo) ListTrustedByUser(ctx context.Context, userID string) ([]*devicedomain.Device, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*devicedomain.Device
+	for _, d := range r.m {
+		if d.UserID == userID && d.Trusted {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
 func (r *memDeviceRepo) Create(ctx context.Context, d *devicedomain.Device) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -605,6 +893,18 @@ func (r *memDeviceRepo) UpdateTrustedWithExpiry(ctx context.Context, id string,
 	return nil
 }
 
+func (r *memDeviceRepo) Trust(ctx context.Context, id string, trustedUntil time.Time, trustDays int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d, ok := r.m[id]; ok {
+		d.Trusted = true
+		d.TrustedUntil = &trustedUntil
+		d.TrustDays = trustDays
+		d.RevokedAt = nil
+	}
+	return nil
+}
+
 type memMembershipRepo struct {
 	mu sync.Mutex
 	m  map[string]*membershipdomain.Membership
@@ -623,6 +923,11 @@ func (r *memMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userI
 
 type memPlatformSettingsRepo struct{}
 
+func (r *memPlatformSettingsRepo) GetSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.Settings, error) {
+	s := platformsettingsdomain.DefaultSettings(defaultTrustTTLDays)
+	return &s, nil
+}
+
 func (r *memPlatformSettingsRepo) GetDeviceTrustSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.PlatformDeviceTrustSettings, error) {
 	return &platformsettingsdomain.PlatformDeviceTrustSettings{
 		MFARequiredAlways:   false,
@@ -662,6 +967,25 @@ func (r *memMFAChallengeRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *memMFAChallengeRepo) DeleteByUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, c := range r.m {
+		if c.UserID == userID {
+			delete(r.m, id)
+		}
+	}
+	return nil
+}
+
+func (r *memMFAChallengeRepo) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	return 0, nil
+}
+
+func (r *memMFAChallengeRepo) Resend(ctx context.Context, id string, resendCount int, codeHash string, sentAt, expiresAt time.Time) (bool, error) {
+	return false, nil
+}
+
 type memMFAIntentRepo struct {
 	mu sync.Mutex
 	m  map[string]*mfaintentdomain.Intent
@@ -1266,6 +1590,39 @@ func TestLogout_Success_WithAuthService(t *testing.T) {
 	}
 }
 
+func TestLogoutAllMySessions(t *testing.T) {
+	if _, err := NewAuthServer(nil).LogoutAllMySessions(context.Background(), &authv1.LogoutAllMySessionsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil auth service: status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+
+	setup := newTestAuthServiceForHandler(t)
+	srv := NewAuthServer(setup.authSvc)
+	regResp, err := srv.Register(context.Background(), &authv1.RegisterRequest{Email: "user@example.com", Password: "Password123!abc"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	setup.sessionRepo.mu.Lock()
+	for _, id := range []string{"s1", "s2", "s3"} {
+		setup.sessionRepo.m[id] = &sessiondomain.Session{ID: id, UserID: regResp.UserId, OrgID: "org-1", CreatedAt: time.Now()}
+	}
+	setup.sessionRepo.mu.Unlock()
+
+	if _, err := srv.LogoutAllMySessions(context.Background(), &authv1.LogoutAllMySessionsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("no caller: status code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+	ctx := interceptors.WithIdentity(context.Background(), regResp.UserId, "org-1", "s1")
+	if _, err := srv.LogoutAllMySessions(ctx, &authv1.LogoutAllMySessionsRequest{KeepCurrent: true, CurrentPassword: "Wrong123!abc"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong password: status code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+	resp, err := srv.LogoutAllMySessions(ctx, &authv1.LogoutAllMySessionsRequest{KeepCurrent: true})
+	if err != nil {
+		t.Fatalf("LogoutAllMySessions: %v", err)
+	}
+	if resp.GetSessionsRevoked() != 2 {
+		t.Errorf("sessions_revoked = %d, want 2", resp.GetSessionsRevoked())
+	}
+}
+
 func TestRefreshResultToProto_MFARequired(t *testing.T) {
 	result := &service.RefreshResult{
 		MFARequired: &service.MFARequiredResult{
@@ -1296,3 +1653,245 @@ func TestRefreshResultToProto_PhoneRequired(t *testing.T) {
 		t.Errorf("intent_id = %q, want %q", proto.GetPhoneRequired().IntentId, "intent-1")
 	}
 }
+
+func TestAdminResetMFA_WithAuthService(t *testing.T) {
+	setup := newTestAuthServiceForHandler(t)
+	srv := NewAuthServer(setup.authSvc)
+	ctx := context.Background()
+
+	member, err := srv.Register(ctx, &authv1.RegisterRequest{Email: "user@example.com", Password: "Password123!abc"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	admin, err := srv.Register(ctx, &authv1.RegisterRequest{Email: "admin@example.com", Password: "Password123!abc"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	setup.membershipRepo.mu.Lock()
+	setup.membershipRepo.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: member.UserId, OrgID: "org-1", Role: membershipdomain.RoleMember, CreatedAt: time.Now()}
+	setup.membershipRepo.m["m2"] = &membershipdomain.Membership{ID: "m2", UserID: admin.UserId, OrgID: "org-1", Role: membershipdomain.RoleAdmin, CreatedAt: time.Now()}
+	setup.membershipRepo.mu.Unlock()
+	memberCtx := interceptors.WithIdentity(ctx, member.UserId, "org-1", "s1")
+	adminCtx := interceptors.WithIdentity(ctx, admin.UserId, "org-1", "s2")
+
+	if _, err := srv.AdminResetMFA(adminCtx, &authv1.AdminResetMFARequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("missing user_id: status code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+	if _, err := srv.AdminResetMFA(memberCtx, &authv1.AdminResetMFARequest{UserId: admin.UserId}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member caller: status code = %v, want %v", status.Code(err), codes.PermissionDenied)
+	}
+	if _, err := srv.AdminResetMFA(adminCtx, &authv1.AdminResetMFARequest{UserId: member.UserId, Reason: "lost phone"}); err != nil {
+		t.Fatalf("AdminResetMFA: %v", err)
+	}
+	if u, _ := setup.userRepo.GetByID(ctx, member.UserId); !u.MFAResetRequired {
+		t.Error("MFAResetRequired not set")
+	}
+}
+
+func TestLoginHoldRPCs_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	ctx := context.Background()
+	if _, err := srv.ResumeLogin(ctx, &authv1.ResumeLoginRequest{HoldId: "h1", HoldToken: "ztcp_lh_x"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ResumeLogin status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.ApproveLogin(ctx, &authv1.ApproveLoginRequest{HoldId: "h1"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ApproveLogin status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.DenyLogin(ctx, &authv1.DenyLoginRequest{HoldId: "h1"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("DenyLogin status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.ListLoginHolds(ctx, &authv1.ListLoginHoldsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ListLoginHolds status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestLoginResultToProto_ApprovalRequired(t *testing.T) {
+	expiresAt := time.Now().Add(15 * time.Minute).UTC()
+	proto := loginResultToProto(&service.LoginResult{
+		ApprovalRequired: &service.ApprovalRequiredResult{HoldID: "hold-1", HoldToken: "ztcp_lh_abc", ExpiresAt: expiresAt},
+	})
+	ar := proto.GetApprovalRequired()
+	if ar == nil {
+		t.Fatal("approval_required should be set")
+	}
+	if ar.HoldId != "hold-1" || ar.HoldToken != "ztcp_lh_abc" || !ar.ExpiresAt.AsTime().Equal(expiresAt) {
+		t.Errorf("approval_required = %+v", ar)
+	}
+}
+
+func TestAuthErr_LoginHold(t *testing.T) {
+	for err, want := range map[error]codes.Code{
+		service.ErrLoginHoldPending:      codes.FailedPrecondition,
+		service.ErrLoginHoldsDisabled:    codes.FailedPrecondition,
+		service.ErrInvalidLoginHold:      codes.Unauthenticated,
+		service.ErrLoginHoldDenied:       codes.PermissionDenied,
+		service.ErrLoginHoldSelfDecision: codes.PermissionDenied,
+		service.ErrLoginHoldNotFound:     codes.NotFound,
+	} {
+		if got := status.Code(authErr(err)); got != want {
+			t.Errorf("authErr(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestDeviceCodeRPCs_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	ctx := context.Background()
+	if _, err := srv.StartDeviceAuthorization(ctx, &authv1.StartDeviceAuthorizationRequest{ClientName: "ztcp-cli"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("StartDeviceAuthorization status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.PollDeviceAuthorization(ctx, &authv1.PollDeviceAuthorizationRequest{DeviceCode: "ztcp_dc_x"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("PollDeviceAuthorization status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.ApproveDeviceCode(ctx, &authv1.ApproveDeviceCodeRequest{UserCode: "BCDF-GHJK"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ApproveDeviceCode status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.DenyDeviceCode(ctx, &authv1.DenyDeviceCodeRequest{UserCode: "BCDF-GHJK"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("DenyDeviceCode status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestDeviceAuthorizationToProto(t *testing.T) {
+	expiresAt := time.Now().Add(10 * time.Minute).UTC()
+	proto := deviceAuthorizationToProto(&devicecodedomain.DeviceCode{
+		ID: "dc-1", ClientName: "ztcp-cli", IP: "203.0.113.7", Status: devicecodedomain.StatusApproved,
+		UserID: "u1", OrgID: "org-1", CreatedAt: expiresAt.Add(-time.Minute), ExpiresAt: expiresAt,
+	})
+	if proto.Id != "dc-1" || proto.ClientName != "ztcp-cli" || proto.Ip != "203.0.113.7" || proto.Status != "approved" ||
+		proto.UserId != "u1" || proto.OrgId != "org-1" || !proto.ExpiresAt.AsTime().Equal(expiresAt) {
+		t.Errorf("device_authorization = %+v", proto)
+	}
+}
+
+func TestAuthErr_DeviceCode(t *testing.T) {
+	for err, want := range map[error]codes.Code{
+		service.ErrDeviceCodesDisabled:   codes.FailedPrecondition,
+		service.ErrDeviceCodePending:     codes.FailedPrecondition,
+		service.ErrInvalidDeviceCode:     codes.Unauthenticated,
+		service.ErrDeviceCodeDenied:      codes.PermissionDenied,
+		service.ErrTrustedDeviceRequired: codes.PermissionDenied,
+		service.ErrDeviceCodeNotFound:    codes.NotFound,
+	} {
+		if got := status.Code(authErr(err)); got != want {
+			t.Errorf("authErr(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestMagicLinkRPCs_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	ctx := context.Background()
+	if _, err := srv.RequestMagicLink(ctx, &authv1.RequestMagicLinkRequest{Email: "user@example.com", OrgId: "org-1"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("RequestMagicLink status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.CompleteMagicLink(ctx, &authv1.CompleteMagicLinkRequest{Token: "ztcp_ml_x"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("CompleteMagicLink status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestAuthErr_MagicLink(t *testing.T) {
+	for err, want := range map[error]codes.Code{
+		service.ErrMagicLinksDisabled: codes.FailedPrecondition,
+		service.ErrInvalidMagicLink:   codes.Unauthenticated,
+	} {
+		if got := status.Code(authErr(err)); got != want {
+			t.Errorf("authErr(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestEmailChangeRPCs_NilAuthService(t *testing.T) {
+	srv := NewAuthServer(nil)
+	ctx := context.Background()
+	if _, err := srv.StartEmailChange(ctx, &authv1.StartEmailChangeRequest{NewEmail: "new@example.com"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("StartEmailChange status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := srv.ConfirmEmailChange(ctx, &authv1.ConfirmEmailChangeRequest{Token: "ztcp_ec_x"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ConfirmEmailChange status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+}
+
+func TestConfirmEmailChange_TokenRequired(t *testing.T) {
+	srv := NewAuthServer(newTestAuthServiceForHandler(t).authSvc)
+	if _, err := srv.ConfirmEmailChange(context.Background(), &authv1.ConfirmEmailChangeRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestAuthErr_EmailChange(t *testing.T) {
+	for err, want := range map[error]codes.Code{
+		service.ErrEmailChangeDisabled: codes.FailedPrecondition,
+		service.ErrInvalidEmailChange:  codes.Unauthenticated,
+		service.ErrEmailUnchanged:      codes.InvalidArgument,
+	} {
+		if got := status.Code(authErr(err)); got != want {
+			t.Errorf("authErr(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestUsernameRPCs(t *testing.T) {
+	nilSrv := NewAuthServer(nil)
+	if _, err := nilSrv.CheckUsernameAvailability(context.Background(), &authv1.CheckUsernameAvailabilityRequest{Username: "ada"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("CheckUsernameAvailability status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	if _, err := nilSrv.SetUsername(context.Background(), &authv1.SetUsernameRequest{Username: "ada"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("SetUsername status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+
+	srv := NewAuthServer(newTestAuthServiceForHandler(t).authSvc)
+	ctx := interceptors.WithIdentity(context.Background(), "u1", "org-1", "s1")
+	if _, err := srv.CheckUsernameAvailability(ctx, &authv1.CheckUsernameAvailabilityRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty username: status code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+	if _, err := srv.SetUsername(ctx, &authv1.SetUsernameRequest{Username: "ada"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("usernames disabled: status code = %v, want %v", status.Code(err), codes.FailedPrecondition)
+	}
+	if got := status.Code(authErr(service.ErrUsernameTaken)); got != codes.AlreadyExists {
+		t.Errorf("authErr(ErrUsernameTaken) = %v, want %v", got, codes.AlreadyExists)
+	}
+}
+
+func TestDiscoverOrganizations(t *testing.T) {
+	if _, err := NewAuthServer(nil).DiscoverOrganizations(context.Background(), &authv1.DiscoverOrganizationsRequest{Email: "a@b.c"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil auth service: status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+	srv := NewAuthServer(newTestAuthServiceForHandler(t).authSvc)
+	if _, err := srv.DiscoverOrganizations(context.Background(), &authv1.DiscoverOrganizationsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty email: status code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+	if _, err := srv.DiscoverOrganizations(context.Background(), &authv1.DiscoverOrganizationsRequest{Email: "a@b.c"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("discovery disabled: status code = %v, want %v", status.Code(err), codes.FailedPrecondition)
+	}
+}
+
+type serviceAccounts map[string]bool
+
+func (a serviceAccounts) IsServiceAccount(userID string) bool { return a[userID] }
+
+func TestIntrospect(t *testing.T) {
+	if _, err := NewAuthServer(nil).Introspect(context.Background(), &authv1.IntrospectRequest{Token: "t"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil auth service: status code = %v, want %v", status.Code(err), codes.Unimplemented)
+	}
+
+	setup := newTestAuthServiceForHandler(t)
+	service.WithIntrospection(serviceAccounts{"svc-1": true}, 15*time.Second)(setup.authSvc)
+	srv := NewAuthServer(setup.authSvc)
+
+	userCtx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+	if _, err := srv.Introspect(userCtx, &authv1.IntrospectRequest{Token: "t"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("non-service account: status code = %v, want %v", status.Code(err), codes.PermissionDenied)
+	}
+
+	svcCtx := interceptors.WithIdentity(context.Background(), "svc-1", "org-1", "session-svc")
+	resp, err := srv.Introspect(svcCtx, &authv1.IntrospectRequest{Token: "not-a-jwt"})
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+	if resp.GetActive() || resp.GetUserId() != "" || resp.GetExpiresAt() != nil {
+		t.Errorf("response = %v, want inactive with no details", resp)
+	}
+	if resp.GetCacheTtlSeconds() != 15 {
+		t.Errorf("cache_ttl_seconds = %d, want 15", resp.GetCacheTtlSeconds())
+	}
+}
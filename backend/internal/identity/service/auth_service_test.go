@@ -3,21 +3,28 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc/metadata"
+
 	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
 	"zero-trust-control-plane/backend/internal/devotp"
 	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/license"
 	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
 	"zero-trust-control-plane/backend/internal/mfa"
 	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
 	mfaintentdomain "zero-trust-control-plane/backend/internal/mfaintent/domain"
+	"zero-trust-control-plane/backend/internal/notification"
 	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	"zero-trust-control-plane/backend/internal/platform/ratelimit"
 	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
 	policyengine "zero-trust-control-plane/backend/internal/policy/engine"
 	"zero-trust-control-plane/backend/internal/security"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
 	"zero-trust-control-plane/backend/internal/server/interceptors"
 	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
 	userdomain "zero-trust-control-plane/backend/internal/user/domain"
@@ -31,6 +38,7 @@ type memUserRepo struct {
 	getByEmailErr error
 	createErr     error
 	setPhoneErr   error
+	identities    *memIdentityRepo // receives the identities of CreateWithIdentity
 }
 
 func (r *memUserRepo) GetByID(ctx context.Context, id string) (*userdomain.User, error) {
@@ -62,6 +70,24 @@ func (r *memUserRepo) Create(ctx context.Context, u *userdomain.User) error {
 	return nil
 }
 
+func (r *memUserRepo) CreateWithIdentity(ctx context.Context, u *userdomain.User, i *identitydomain.Identity, seats license.SeatReserver) error {
+	if seats != nil {
+		if err := seats.ReserveSeat(ctx, nil); err != nil {
+			return err
+		}
+	}
+	if r.identities != nil && r.identities.createErr != nil {
+		return r.identities.createErr
+	}
+	if err := r.Create(ctx, u); err != nil {
+		return err
+	}
+	if r.identities == nil {
+		return nil
+	}
+	return r.identities.Create(ctx, i)
+}
+
 func (r *memUserRepo) SetPhoneVerified(ctx context.Context, userID, phone string) error {
 	if r.setPhoneErr != nil {
 		return r.setPhoneErr
@@ -72,12 +98,64 @@ func (r *memUserRepo) SetPhoneVerified(ctx context.Context, userID, phone string
 		u2 := *u
 		u2.Phone = phone
 		u2.PhoneVerified = true
+		u2.MFAResetRequired = false
 		r.byID[userID] = &u2
 		r.byEmail[u.Email] = &u2
 	}
 	return nil
 }
 
+func (r *memUserRepo) ResetMFA(ctx context.Context, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.byID[userID]
+	if !ok {
+		return false, nil
+	}
+	u2 := *u
+	u2.Phone, u2.PhoneVerified, u2.MFAResetRequired = "", false, true
+	r.byID[userID] = &u2
+	r.byEmail[u.Email] = &u2
+	return true, nil
+}
+
+func (r *memUserRepo) ChangeEmail(ctx context.Context, userID, oldEmail, newEmail string) (bool, error) {
+	if r.setPhoneErr != nil {
+		return false, r.setPhoneErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.byID[userID]
+	if !ok || u.Email != oldEmail {
+		return false, nil
+	}
+	u2 := *u
+	u2.Email = newEmail
+	r.byID[userID] = &u2
+	delete(r.byEmail, oldEmail)
+	r.byEmail[newEmail] = &u2
+	return true, nil
+}
+
+func (r *memUserRepo) ChangePhone(ctx context.Context, userID, oldPhone, newPhone string) (bool, error) {
+	if r.setPhoneErr != nil {
+		return false, r.setPhoneErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.byID[userID]
+	if !ok || u.Phone != oldPhone {
+		return false, nil
+	}
+	u2 := *u
+	u2.Phone = newPhone
+	u2.PhoneVerified = true
+	u2.MFAResetRequired = false
+	r.byID[userID] = &u2
+	r.byEmail[u.Email] = &u2
+	return true, nil
+}
+
 type memIdentityRepo struct {
 	mu                sync.Mutex
 	m                 map[string]*identitydomain.Identity
@@ -109,6 +187,15 @@ func (r *memIdentityRepo) Create(ctx context.Context, i *identitydomain.Identity
 	return nil
 }
 
+func (r *memIdentityRepo) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i, ok := r.m[id]; ok {
+		i.PasswordHash = passwordHash
+	}
+	return nil
+}
+
 type memSessionRepo struct {
 	mu                sync.Mutex
 	m                 map[string]*sessiondomain.Session
@@ -139,32 +226,49 @@ func (r *memSessionRepo) Create(ctx context.Context, s *sessiondomain.Session) e
 	return nil
 }
 
-func (r *memSessionRepo) Revoke(ctx context.Context, id string) error {
+func (r *memSessionRepo) Revoke(ctx context.Context, id string, rev sessiondomain.Revocation) error {
 	if r.revokeErr != nil {
 		return r.revokeErr
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if s, ok := r.m[id]; ok {
+	if s, ok := r.m[id]; ok && s.RevokedAt == nil {
 		t := time.Now()
-		s.RevokedAt = &t
+		s.RevokedAt, s.RevocationReason, s.RevokedBy = &t, rev.Reason, rev.By
 	}
 	return nil
 }
 
-func (r *memSessionRepo) RevokeAllSessionsByUser(ctx context.Context, userID string) error {
+func (r *memSessionRepo) RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	t := time.Now()
 	for _, s := range r.m {
-		if s.UserID == userID {
-			s.RevokedAt = &t
+		if s.UserID == userID && s.RevokedAt == nil {
+			s.RevokedAt, s.RevocationReason, s.RevokedBy = &t, rev.Reason, rev.By
 		}
 	}
 	return nil
 }
 
-func (r *memSessionRepo) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string) error {
+func (r *memSessionRepo) RevokeOtherSessionsByUser(ctx context.Context, userID, exceptSessionID string, rev sessiondomain.Revocation) (int64, error) {
+	if r.revokeErr != nil {
+		return 0, r.revokeErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := time.Now()
+	var n int64
+	for _, s := range r.m {
+		if s.UserID == userID && s.ID != exceptSessionID && s.RevokedAt == nil {
+			s.RevokedAt, s.RevocationReason, s.RevokedBy = &t, rev.Reason, rev.By
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *memSessionRepo) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string, expiresAt time.Time) error {
 	if r.updateRefreshErr != nil {
 		return r.updateRefreshErr
 	}
@@ -173,6 +277,7 @@ func (r *memSessionRepo) UpdateRefreshToken(ctx context.Context, sessionID, jti,
 	if s, ok := r.m[sessionID]; ok {
 		s.RefreshJti = jti
 		s.RefreshTokenHash = refreshTokenHash
+		s.ExpiresAt = expiresAt
 	}
 	return nil
 }
@@ -216,6 +321,18 @@ func (r *memDeviceRepo) GetByUserOrgAndFingerprint(ctx context.Context, userID,
 	return nil, nil
 }
 
+func (r *memDeviceRepo) ListTrustedByUser(ctx context.Context, userID string) ([]*devicedomain.Device, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*devicedomain.Device
+	for _, d := range r.m {
+		if d.UserID == userID && d.Trusted {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
 func (r *memDeviceRepo) Create(ctx context.Context, d *devicedomain.Device) error {
 	if r.createErr != nil {
 		return r.createErr
@@ -243,8 +360,32 @@ func (r *memDeviceRepo) UpdateTrustedWithExpiry(ctx context.Context, id string,
 	return nil
 }
 
+func (r *memDeviceRepo) Trust(ctx context.Context, id string, trustedUntil time.Time, trustDays int) error {
+	if r.updateTrustedErr != nil {
+		return r.updateTrustedErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d, ok := r.m[id]; ok {
+		d.Trusted = true
+		d.TrustedUntil = &trustedUntil
+		d.TrustDays = trustDays
+		d.RevokedAt = nil
+	}
+	return nil
+}
+
 type memPlatformSettingsRepo struct {
 	getDeviceTrustErr error
+	registrationMode  platformsettingsdomain.RegistrationMode // empty = default (open)
+}
+
+func (r *memPlatformSettingsRepo) GetSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.Settings, error) {
+	s := platformsettingsdomain.DefaultSettings(defaultTrustTTLDays)
+	if r.registrationMode != "" {
+		s.RegistrationMode = r.registrationMode
+	}
+	return &s, nil
 }
 
 func (r *memPlatformSettingsRepo) GetDeviceTrustSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.PlatformDeviceTrustSettings, error) {
@@ -306,6 +447,40 @@ func (r *memMFAChallengeRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *memMFAChallengeRepo) DeleteByUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, c := range r.m {
+		if c.UserID == userID {
+			delete(r.m, id)
+		}
+	}
+	return nil
+}
+
+func (r *memMFAChallengeRepo) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.m[id]
+	if !ok {
+		return 0, nil
+	}
+	c.Attempts++
+	return c.Attempts, nil
+}
+
+func (r *memMFAChallengeRepo) Resend(ctx context.Context, id string, resendCount int, codeHash string, sentAt, expiresAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.m[id]
+	if !ok || c.ResendCount != resendCount {
+		return false, nil
+	}
+	c.CodeHash, c.LastSentAt, c.ExpiresAt = codeHash, sentAt, expiresAt
+	c.ResendCount++
+	return true, nil
+}
+
 type memMFAIntentRepo struct {
 	mu        sync.Mutex
 	m         map[string]*mfaintentdomain.Intent
@@ -389,6 +564,7 @@ type auditEvent struct {
 	userID  string
 	action  string
 	resource string
+	metadata string
 }
 
 func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
@@ -399,6 +575,7 @@ func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, r
 		userID:   userID,
 		action:   action,
 		resource: resource,
+		metadata: metadata,
 	})
 }
 
@@ -477,49 +654,12 @@ func (r *memMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userI
 
 func newTestAuthServiceOpt(t *testing.T, otpReturnToClient bool) (*AuthService, *memSessionRepo, *devotp.MemoryStore) {
 	t.Helper()
-	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
-	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
-	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
-	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
-	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
-	platformSettingsRepo := &memPlatformSettingsRepo{}
-	orgMFASettingsRepo := &memOrgMFASettingsRepo{}
-	mfaChallengeRepo := &memMFAChallengeRepo{m: make(map[string]*mfadomain.Challenge)}
-	mfaIntentRepo := &memMFAIntentRepo{m: make(map[string]*mfaintentdomain.Intent)}
-	policyEvaluator := &memPolicyEvaluator{}
-	smsSender := &memOTPSender{}
-	hasher := security.NewHasher(10)
-	tokens, err := security.NewTestTokenProvider()
-	if err != nil {
-		t.Fatalf("NewTestTokenProvider: %v", err)
-	}
-	var devStore *devotp.MemoryStore
+	var opts []fixtureOption
 	if otpReturnToClient {
-		devStore = devotp.NewMemoryStore()
+		opts = append(opts, withDevOTP())
 	}
-	svc := NewAuthService(
-		userRepo,
-		identityRepo,
-		sessionRepo,
-		deviceRepo,
-		membershipRepo,
-		platformSettingsRepo,
-		orgMFASettingsRepo,
-		mfaChallengeRepo,
-		mfaIntentRepo,
-		policyEvaluator,
-		smsSender,
-		hasher,
-		tokens,
-		15*time.Minute,
-		24*time.Hour,
-		30,             // defaultTrustTTLDays
-		10*time.Minute, // mfaChallengeTTL
-		otpReturnToClient,
-		devStore, // devOTPStore
-		nil,      // auditLogger
-	)
-	return svc, sessionRepo, devStore
+	f := newTestFixture(t, opts...)
+	return f.svc, f.sessions, f.devOTP
 }
 
 func newTestAuthService(t *testing.T) (*AuthService, *memSessionRepo) {
@@ -548,6 +688,19 @@ func TestAuthService_Register(t *testing.T) {
 	}
 }
 
+func TestAuthService_Register_Closed(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	ctx := context.Background()
+	svc.platformSettingsRepo.(*memPlatformSettingsRepo).registrationMode = platformsettingsdomain.RegistrationClosed
+
+	if _, err := svc.Register(ctx, "user@example.com", "Password123!abc", ""); !errors.Is(err, ErrRegistrationClosed) {
+		t.Fatalf("Register with registration closed: err = %v, want ErrRegistrationClosed", err)
+	}
+	if u, _ := svc.userRepo.GetByEmail(ctx, "user@example.com"); u != nil {
+		t.Error("no user should be created while registration is closed")
+	}
+}
+
 func TestAuthService_RegisterValidation(t *testing.T) {
 	svc, _ := newTestAuthService(t)
 	ctx := context.Background()
@@ -827,12 +980,16 @@ func TestAuthService_LogoutFromContext(t *testing.T) {
 	if s.RevokedAt == nil {
 		t.Error("Logout with session in context should have revoked the session")
 	}
+	if s.RevocationReason != sessiondomain.RevocationLogout || s.RevokedBy != "u1" {
+		t.Errorf("revocation = %q by %q, want logout by u1", s.RevocationReason, s.RevokedBy)
+	}
 }
 
 // TestAuthService_LoginOTPReturnToClient asserts that when otpReturnToClient is true and devOTPStore is set, Login stores OTP in dev store (retrievable via Get), does not call SendOTP, and MFARequired has no OTP in response.
 func TestAuthService_LoginOTPReturnToClient(t *testing.T) {
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -967,6 +1124,9 @@ func TestAuthService_RefreshTokenReuseDetection(t *testing.T) {
 			allRevoked = false
 			break
 		}
+		if s.RevocationReason != sessiondomain.RevocationReuseDetected || s.RevokedBy != "" {
+			t.Errorf("session %s revocation = %q by %q, want reuse_detected by the system", s.ID, s.RevocationReason, s.RevokedBy)
+		}
 	}
 	sessionRepo.mu.Unlock()
 	if !allRevoked {
@@ -974,6 +1134,84 @@ func TestAuthService_RefreshTokenReuseDetection(t *testing.T) {
 	}
 }
 
+func TestAuthService_RefreshReplayCache_InFlightToken(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withNetworkAccess(false), withReplayCache(), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc, cache, auditLogger := f.svc, f.replays, f.audit
+	ctx := ctxFromIP("10.2.3.4")
+	tokens := f.login(t, ctx)
+	_, jti, _, _, err := svc.tokens.ValidateRefresh(tokens.RefreshToken)
+	if err != nil {
+		t.Fatalf("ValidateRefresh: %v", err)
+	}
+	// Another refresh of the same token has claimed it and not yet rotated the session.
+	cache.Claim(ctx, jti)
+	if _, err := svc.Refresh(ctx, tokens.RefreshToken, "fp-1"); err != ErrRefreshTokenReuse {
+		t.Fatalf("replayed refresh: want ErrRefreshTokenReuse, got %v", err)
+	}
+	auditLogger.mu.Lock()
+	var metadata string
+	for _, e := range auditLogger.events {
+		if e.action == "refresh_token_reuse" {
+			metadata = e.metadata
+		}
+	}
+	auditLogger.mu.Unlock()
+	if !strings.Contains(metadata, `"detected_by":"replay_cache"`) {
+		t.Errorf("refresh_token_reuse metadata = %q, want detected_by replay_cache", metadata)
+	}
+	sessionRepo := svc.sessionRepo.(*memSessionRepo)
+	sessionRepo.mu.Lock()
+	defer sessionRepo.mu.Unlock()
+	for _, s := range sessionRepo.m {
+		if s.RevokedAt == nil || s.RevocationReason != sessiondomain.RevocationReuseDetected {
+			t.Errorf("session %s revocation = %q, want reuse_detected", s.ID, s.RevocationReason)
+		}
+	}
+}
+
+func TestAuthService_RefreshReplayCache_ConcurrentRefreshes(t *testing.T) {
+	f := newTestFixture(t, withNetworkAccess(false), withReplayCache(), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc := f.svc
+	ctx := ctxFromIP("10.2.3.4")
+	tokens := f.login(t, ctx)
+	errs := make([]error, 8)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = svc.Refresh(ctx, tokens.RefreshToken, "fp-1")
+		}(i)
+	}
+	wg.Wait()
+	succeeded, reused := 0, 0
+	for _, err := range errs {
+		switch err {
+		case nil:
+			succeeded++
+		case ErrRefreshTokenReuse:
+			reused++
+		}
+	}
+	if succeeded > 1 || reused == 0 {
+		t.Errorf("concurrent refreshes: %d succeeded and %d reported reuse (errors %v), want at most 1 success", succeeded, reused, errs)
+	}
+}
+
+func TestAuthService_RefreshReplayCache_ReleasedOnFailure(t *testing.T) {
+	f := newTestFixture(t, withNetworkAccess(false), withReplayCache(), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc := f.svc
+	tokens := f.login(t, ctxFromIP("10.2.3.4"))
+	if _, err := svc.Refresh(ctxFromIP("198.51.100.9"), tokens.RefreshToken, "fp-1"); err != ErrNetworkNotAllowed {
+		t.Fatalf("Refresh from outside allowed CIDRs: want ErrNetworkNotAllowed, got %v", err)
+	}
+	// The token was not rotated, so the client may retry it.
+	res, err := svc.Refresh(ctxFromIP("10.2.3.4"), tokens.RefreshToken, "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("retried Refresh: res=%+v err=%v", res, err)
+	}
+}
+
 func TestAuthService_RefreshWithUntrustedDevice(t *testing.T) {
 	svc, _ := newTestAuthService(t)
 	ctx := context.Background()
@@ -1358,6 +1596,7 @@ func TestValidatePhone_Invalid(t *testing.T) {
 func TestAuthService_LoginFailure_LogsAudit(t *testing.T) {
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -1478,6 +1717,7 @@ func TestAuthService_Login_UserRepoGetByEmailError(t *testing.T) {
 		getByEmailErr: errors.New("database error"),
 	}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -1772,22 +2012,37 @@ func TestAuthService_Login_SMSSendError(t *testing.T) {
 	}
 	membershipRepo.mu.Unlock()
 
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
 	smsSender := svc.smsSender.(*memOTPSender)
 	smsSender.sendErr = errors.New("SMS service error")
 
-	// Login with new device requiring MFA
-	_, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "new-device-fp")
-	if err == nil {
-		t.Fatal("expected error when SMS sending fails")
+	// Login with new device requiring MFA: the challenge is kept so the code can be resent.
+	res, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "new-device-fp")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if res.MFARequired == nil || res.MFARequired.ChallengeID == "" || !res.MFARequired.CodeNotSent {
+		t.Fatalf("MFARequired = %+v, want a challenge with code_not_sent", res.MFARequired)
+	}
+	if !auditLogger.hasAction("mfa_code_send_failed") {
+		t.Error("mfa_code_send_failed not audited")
 	}
 
-	// Verify challenge was cleaned up
 	mfaChallengeRepo := svc.mfaChallengeRepo.(*memMFAChallengeRepo)
 	mfaChallengeRepo.mu.Lock()
-	challengeCount := len(mfaChallengeRepo.m)
+	c := mfaChallengeRepo.m[res.MFARequired.ChallengeID]
+	if c != nil {
+		c.CreatedAt = c.CreatedAt.Add(-time.Hour)
+	}
 	mfaChallengeRepo.mu.Unlock()
-	if challengeCount > 0 {
-		t.Error("challenge should be deleted when SMS sending fails")
+	if c == nil {
+		t.Fatal("challenge should be kept when SMS sending fails")
+	}
+
+	smsSender.sendErr = nil
+	if _, err := svc.ResendMFACode(ctx, res.MFARequired.ChallengeID); err != nil {
+		t.Errorf("ResendMFACode after the provider recovered: %v", err)
 	}
 }
 
@@ -2308,18 +2563,20 @@ func TestAuthService_Refresh_SMSSendError(t *testing.T) {
 	smsSender.sendErr = errors.New("SMS service error")
 
 	// Refresh with new device requiring MFA
-	_, err = svc.Refresh(ctx, loginRes.Tokens.RefreshToken, "new-device-fp")
-	if err == nil {
-		t.Fatal("expected error when SMS sending fails")
+	res, err := svc.Refresh(ctx, loginRes.Tokens.RefreshToken, "new-device-fp")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if res.MFARequired == nil || !res.MFARequired.CodeNotSent {
+		t.Fatalf("MFARequired = %+v, want code_not_sent", res.MFARequired)
 	}
 
-	// Verify challenge was cleaned up
 	mfaChallengeRepo := svc.mfaChallengeRepo.(*memMFAChallengeRepo)
 	mfaChallengeRepo.mu.Lock()
-	challengeCount := len(mfaChallengeRepo.m)
+	_, kept := mfaChallengeRepo.m[res.MFARequired.ChallengeID]
 	mfaChallengeRepo.mu.Unlock()
-	if challengeCount > 0 {
-		t.Error("challenge should be deleted when SMS sending fails")
+	if !kept {
+		t.Error("challenge should be kept when SMS sending fails")
 	}
 }
 
@@ -2427,7 +2684,7 @@ func TestAuthService_CreateSessionAndResult_DeviceTrustUpdateError(t *testing.T)
 
 	_, err = svc.VerifyMFA(ctx, challengeID, otp)
 	if err != nil {
-		t.Fatalf("VerifyMFA should succeed even if UpdateTrustedWithExpiry fails: %v", err)
+		t.Fatalf("VerifyMFA should succeed even if Trust fails: %v", err)
 	}
 }
 
@@ -2783,6 +3040,7 @@ func TestAuthService_SubmitPhoneAndRequestMFA_Success_NoSMS(t *testing.T) {
 	// Create service without SMS sender
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -2914,6 +3172,7 @@ func TestAuthService_VerifyMFA_Success_WithoutPolicyEvaluator(t *testing.T) {
 	// Create service without policy evaluator
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -3094,6 +3353,7 @@ func TestAuthService_VerifyMFA_Success_NoDeviceTrust(t *testing.T) {
 	// Create service with policy evaluator that doesn't register trust
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -3226,6 +3486,7 @@ func TestNewAuthService_WithNilDependencies(t *testing.T) {
 	// Some dependencies are required, so we test with minimal valid ones
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -3276,6 +3537,7 @@ func TestNewAuthService_WithNilDependencies(t *testing.T) {
 func TestNewAuthService_WithZeroTTLs(t *testing.T) {
 	userRepo := &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)}
 	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	userRepo.identities = identityRepo
 	sessionRepo := &memSessionRepo{m: make(map[string]*sessiondomain.Session)}
 	deviceRepo := &memDeviceRepo{m: make(map[string]*devicedomain.Device)}
 	membershipRepo := &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)}
@@ -3367,3 +3629,352 @@ func TestNewAuthService_WithZeroTTLs(t *testing.T) {
 		t.Errorf("challenge expiry should be ~10 minutes from now, got %v", challenge.ExpiresAt.Sub(now))
 	}
 }
+
+type memLoginNotifier struct {
+	events []notification.LoginEvent
+}
+
+func (m *memLoginNotifier) NotifyLogin(ctx context.Context, ev notification.LoginEvent) {
+	m.events = append(m.events, ev)
+}
+
+func TestAuthService_Login_NotifiesLoginNotifier(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	notifier := &memLoginNotifier{}
+	WithLoginNotifier(notifier)(svc)
+	ctx := context.Background()
+	reg, _ := svc.Register(ctx, "user@example.com", "Password123!abc", "")
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: reg.UserID, OrgID: "org-1", Role: membershipdomain.RoleMember, CreatedAt: time.Now()}
+	membershipRepo.mu.Unlock()
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d1"] = &devicedomain.Device{ID: "d1", UserID: reg.UserID, OrgID: "org-1", Fingerprint: "fp-1", Trusted: true, CreatedAt: time.Now()}
+	deviceRepo.mu.Unlock()
+
+	if _, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1"); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if len(notifier.events) != 1 {
+		t.Fatalf("notifier events = %d, want 1", len(notifier.events))
+	}
+	ev := notifier.events[0]
+	if ev.UserID != reg.UserID || ev.OrgID != "org-1" || ev.DeviceID != "d1" {
+		t.Errorf("event = %+v, want user/org/device set", ev)
+	}
+	if ev.Email != "user@example.com" {
+		t.Errorf("event email = %q, want user@example.com", ev.Email)
+	}
+}
+
+type memSecurityEventRecorder struct {
+	types []securityeventdomain.EventType
+}
+
+func (m *memSecurityEventRecorder) Record(ctx context.Context, orgID, userID string, eventType securityeventdomain.EventType, metadata string) {
+	m.types = append(m.types, eventType)
+}
+
+func TestAuthService_RecordsSecurityEvents(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	recorder := &memSecurityEventRecorder{}
+	WithSecurityEventRecorder(recorder)(svc)
+	ctx := context.Background()
+	reg, _ := svc.Register(ctx, "user@example.com", "Password123!abc", "")
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: reg.UserID, OrgID: "org-1", Role: membershipdomain.RoleMember, CreatedAt: time.Now()}
+	membershipRepo.mu.Unlock()
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d1"] = &devicedomain.Device{ID: "d1", UserID: reg.UserID, OrgID: "org-1", Fingerprint: "fp-1", Trusted: true, CreatedAt: time.Now()}
+	deviceRepo.mu.Unlock()
+
+	if _, err := svc.Login(ctx, "user@example.com", "WrongPassword123!", "org-1", "fp-1"); err != ErrInvalidCredentials {
+		t.Fatalf("Login wrong password: want ErrInvalidCredentials, got %v", err)
+	}
+	loginRes, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if _, err := svc.Refresh(ctx, loginRes.Tokens.RefreshToken, "fp-1"); err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+	if _, err := svc.Refresh(ctx, loginRes.Tokens.RefreshToken, "fp-1"); err != ErrRefreshTokenReuse {
+		t.Fatalf("reuse: want ErrRefreshTokenReuse, got %v", err)
+	}
+	want := []securityeventdomain.EventType{securityeventdomain.EventLoginFailure, securityeventdomain.EventRefreshTokenReuse}
+	if len(recorder.types) != len(want) {
+		t.Fatalf("recorded = %v, want %v", recorder.types, want)
+	}
+	for i := range want {
+		if recorder.types[i] != want[i] {
+			t.Errorf("recorded[%d] = %s, want %s", i, recorder.types[i], want[i])
+		}
+	}
+}
+
+type staticIPBlockChecker struct {
+	blocked map[string]bool
+}
+
+func (c staticIPBlockChecker) IsBlocked(ctx context.Context, ip string) (bool, error) {
+	return c.blocked[ip], nil
+}
+
+func TestAuthService_Login_BlockedIP(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithIPBlockChecker(staticIPBlockChecker{blocked: map[string]bool{"198.51.100.9": true}})(svc)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	reg, _ := svc.Register(context.Background(), "user@example.com", "Password123!abc", "")
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: reg.UserID, OrgID: "org-1", Role: membershipdomain.RoleMember, CreatedAt: time.Now()}
+	membershipRepo.mu.Unlock()
+
+	blockedCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.9"))
+	if _, err := svc.Login(blockedCtx, "user@example.com", "Password123!abc", "org-1", "fp-1"); err != ErrIPBlocked {
+		t.Fatalf("Login from blocked IP: want ErrIPBlocked, got %v", err)
+	}
+	auditLogger.mu.Lock()
+	if n := len(auditLogger.events); n != 2 || auditLogger.events[0].action != "login_blocked" || auditLogger.events[1].action != "auth_flow" {
+		t.Errorf("audit events = %+v, want login_blocked then auth_flow", auditLogger.events)
+	}
+	auditLogger.mu.Unlock()
+
+	otherCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "203.0.113.5"))
+	if _, err := svc.Login(otherCtx, "user@example.com", "Password123!abc", "org-1", "fp-1"); err == ErrIPBlocked {
+		t.Error("Login from another IP should not be blocked")
+	}
+}
+
+func TestAuthService_VerifyCredentials(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	ctx := context.Background()
+	reg, _ := svc.Register(ctx, "user@example.com", "Password123!abc", "")
+
+	res, err := svc.VerifyCredentials(ctx, " User@Example.com ", "Password123!abc", "", "")
+	if err != nil {
+		t.Fatalf("VerifyCredentials: %v", err)
+	}
+	if !res.Valid || res.UserID != reg.UserID || res.AccountStatus != userdomain.UserStatusActive || res.MFAWouldBeRequired {
+		t.Errorf("result = %+v, want valid active user without MFA evaluation", res)
+	}
+	if !auditLogger.hasAction("credentials_verified") {
+		t.Error("successful verification should be audited as credentials_verified")
+	}
+	if _, err := svc.VerifyCredentials(ctx, "user@example.com", "WrongPassword123!", "", ""); err != ErrInvalidCredentials {
+		t.Errorf("wrong password: want ErrInvalidCredentials, got %v", err)
+	}
+	if _, err := svc.VerifyCredentials(ctx, "nobody@example.com", "Password123!abc", "", ""); err != ErrInvalidCredentials {
+		t.Errorf("unknown email: want ErrInvalidCredentials, got %v", err)
+	}
+	if !auditLogger.hasAction("credentials_verify_failure") || auditLogger.hasAction("login_failure") {
+		t.Error("failed verification should be audited as credentials_verify_failure, not login_failure")
+	}
+}
+
+func TestAuthService_VerifyCredentials_DisabledAccount(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	ctx := context.Background()
+	reg, _ := svc.Register(ctx, "user@example.com", "Password123!abc", "")
+	userRepo := svc.userRepo.(*memUserRepo)
+	userRepo.mu.Lock()
+	userRepo.byID[reg.UserID].Status = userdomain.UserStatusDisabled
+	userRepo.mu.Unlock()
+
+	res, err := svc.VerifyCredentials(ctx, "user@example.com", "Password123!abc", "", "")
+	if err != nil {
+		t.Fatalf("VerifyCredentials: %v", err)
+	}
+	if res.Valid || res.AccountStatus != userdomain.UserStatusDisabled {
+		t.Errorf("result = %+v, want invalid with disabled status", res)
+	}
+	if _, err := svc.VerifyCredentials(ctx, "user@example.com", "WrongPassword123!", "", ""); err != ErrInvalidCredentials {
+		t.Errorf("wrong password on disabled account: want ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestAuthService_VerifyCredentials_MFAWouldBeRequired(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	ctx := context.Background()
+	reg, _ := svc.Register(ctx, "user@example.com", "Password123!abc", "")
+	if _, err := svc.VerifyCredentials(ctx, "user@example.com", "Password123!abc", "org-1", ""); err != ErrNotOrgMember {
+		t.Fatalf("non-member: want ErrNotOrgMember, got %v", err)
+	}
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: reg.UserID, OrgID: "org-1", Role: membershipdomain.RoleMember, CreatedAt: time.Now()}
+	membershipRepo.mu.Unlock()
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d1"] = &devicedomain.Device{ID: "d1", UserID: reg.UserID, OrgID: "org-1", Fingerprint: "fp-1", Trusted: true, CreatedAt: time.Now()}
+	deviceRepo.mu.Unlock()
+
+	res, err := svc.VerifyCredentials(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil {
+		t.Fatalf("trusted device: %v", err)
+	}
+	if !res.Valid || res.MFAWouldBeRequired {
+		t.Errorf("trusted device: result = %+v, want valid without MFA", res)
+	}
+	res, err = svc.VerifyCredentials(ctx, "user@example.com", "Password123!abc", "org-1", "fp-new")
+	if err != nil {
+		t.Fatalf("new device: %v", err)
+	}
+	if !res.MFAWouldBeRequired {
+		t.Error("new device: MFAWouldBeRequired should be true")
+	}
+	deviceRepo.mu.Lock()
+	n := len(deviceRepo.m)
+	deviceRepo.mu.Unlock()
+	if n != 1 {
+		t.Errorf("devices = %d, want 1: VerifyCredentials must not register devices", n)
+	}
+}
+
+func TestAuthService_VerifyCredentials_RateLimited(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithVerifyCredentialsLimiters(ratelimit.NewLimiter(2, time.Minute), ratelimit.NewLimiter(2, time.Minute))(svc)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	ctx := ctxFromIP("198.51.100.9")
+	_, _ = svc.Register(ctx, "user@example.com", "Password123!abc", "")
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.VerifyCredentials(ctx, "user@example.com", "WrongPassword123!", "", ""); err != ErrInvalidCredentials {
+			t.Fatalf("attempt %d: want ErrInvalidCredentials, got %v", i+1, err)
+		}
+	}
+	if _, err := svc.VerifyCredentials(ctx, "user@example.com", "Password123!abc", "", ""); err != ErrRateLimited {
+		t.Fatalf("third attempt from same IP: want ErrRateLimited, got %v", err)
+	}
+	if _, err := svc.VerifyCredentials(ctxFromIP("203.0.113.5"), "user@example.com", "Password123!abc", "", ""); err != ErrRateLimited {
+		t.Errorf("same email from another IP: want ErrRateLimited, got %v", err)
+	}
+	if _, err := svc.VerifyCredentials(ctxFromIP("203.0.113.6"), "other@example.com", "Password123!abc", "", ""); err == ErrRateLimited {
+		t.Error("another email from another IP should not be rate-limited")
+	}
+	if !auditLogger.hasAction("credentials_verify_rate_limited") {
+		t.Error("rate-limited attempt should be audited")
+	}
+}
+
+func TestAuthService_ExchangeToken(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithTokenExchange([]string{"payroll-gateway"}, 5*time.Minute)(svc)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	ctx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+
+	res, err := svc.ExchangeToken(ctx, " payroll-gateway ", "read:payslips  write:notes", time.Hour)
+	if err != nil {
+		t.Fatalf("ExchangeToken: %v", err)
+	}
+	if res.Audience != "payroll-gateway" || res.Scope != "read:payslips write:notes" {
+		t.Errorf("result = %+v", res)
+	}
+	if d := time.Until(res.ExpiresAt); d > 5*time.Minute || d < 4*time.Minute {
+		t.Errorf("expires in %v, want capped at 5m", d)
+	}
+	claims, err := svc.tokens.ValidateResource(res.Token, "payroll-gateway")
+	if err != nil {
+		t.Fatalf("ValidateResource: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.OrgID != "org-1" || claims.SessionID != "session-1" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if !auditLogger.hasAction("resource_token_issued") {
+		t.Error("exchange should be audited as resource_token_issued")
+	}
+
+	res, err = svc.ExchangeToken(ctx, "payroll-gateway", "", 30*time.Second)
+	if err != nil {
+		t.Fatalf("ExchangeToken short TTL: %v", err)
+	}
+	if d := time.Until(res.ExpiresAt); d > 30*time.Second {
+		t.Errorf("expires in %v, want requested 30s", d)
+	}
+}
+
+func TestAuthService_ExchangeToken_Rejected(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithTokenExchange([]string{"payroll-gateway"}, 5*time.Minute)(svc)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	ctx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+
+	if _, err := svc.ExchangeToken(ctx, "reports-api", "", 0); err != ErrAudienceNotAllowed {
+		t.Errorf("unlisted audience: want ErrAudienceNotAllowed, got %v", err)
+	}
+	if !auditLogger.hasAction("resource_token_denied") {
+		t.Error("denied exchange should be audited")
+	}
+	if _, err := svc.ExchangeToken(context.Background(), "payroll-gateway", "", 0); err != ErrInvalidCredentials {
+		t.Errorf("no identity: want ErrInvalidCredentials, got %v", err)
+	}
+	for _, scope := range []string{`read "all"`, strings.Repeat("a", maxScopeLength+1)} {
+		if _, err := svc.ExchangeToken(ctx, "payroll-gateway", scope, 0); err == nil {
+			t.Errorf("scope %.20q should be rejected", scope)
+		}
+	}
+	if _, err := svc.ExchangeToken(ctx, "", "", 0); err == nil {
+		t.Error("empty audience should be rejected")
+	}
+}
+
+func TestAuthService_SessionMetadata(t *testing.T) {
+	svc, sessionRepo, devStore := newTestAuthServiceOpt(t, true)
+	challengeID := smsChallengeFixture(t, svc)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"user-agent", strings.Repeat("a", maxSessionUserAgentLength+10),
+		"x-client-version", "web/2.1.0",
+	))
+	otp, _ := devStore.Get(ctx, challengeID)
+	if _, err := svc.VerifyMFA(ctx, challengeID, otp); err != nil {
+		t.Fatalf("VerifyMFA: %v", err)
+	}
+	sessionRepo.mu.Lock()
+	defer sessionRepo.mu.Unlock()
+	if len(sessionRepo.m) != 1 {
+		t.Fatalf("sessions = %d, want 1", len(sessionRepo.m))
+	}
+	for _, sess := range sessionRepo.m {
+		if len(sess.UserAgent) != maxSessionUserAgentLength {
+			t.Errorf("UserAgent length = %d, want %d", len(sess.UserAgent), maxSessionUserAgentLength)
+		}
+		if sess.ClientVersion != "web/2.1.0" {
+			t.Errorf("ClientVersion = %q, want web/2.1.0", sess.ClientVersion)
+		}
+		if sess.AuthMethod != sessiondomain.AuthMethodPassword {
+			t.Errorf("AuthMethod = %q, want %q", sess.AuthMethod, sessiondomain.AuthMethodPassword)
+		}
+		if sess.MFAMethod != "sms_otp" {
+			t.Errorf("MFAMethod = %q, want sms_otp", sess.MFAMethod)
+		}
+	}
+}
+
+func TestAuthService_SessionMetadata_TrustedDevice(t *testing.T) {
+	svc, sessionRepo, _ := newTestAuthServiceOpt(t, true)
+	loginFlowFixture(t, svc, "fp-1")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "Mozilla/5.0"))
+	res, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login = %+v, %v; want tokens", res, err)
+	}
+	sessionRepo.mu.Lock()
+	defer sessionRepo.mu.Unlock()
+	for _, sess := range sessionRepo.m {
+		if sess.UserAgent != "Mozilla/5.0" || sess.ClientVersion != "" {
+			t.Errorf("UserAgent, ClientVersion = %q, %q; want Mozilla/5.0 and empty", sess.UserAgent, sess.ClientVersion)
+		}
+		if sess.AuthMethod != sessiondomain.AuthMethodPassword || sess.MFAMethod != "" {
+			t.Errorf("AuthMethod, MFAMethod = %q, %q; want password and empty", sess.AuthMethod, sess.MFAMethod)
+		}
+	}
+}
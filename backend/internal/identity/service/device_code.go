@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	devicecodedomain "zero-trust-control-plane/backend/internal/devicecode/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// DefaultDeviceCodeTTL is how long a device code can be approved and then exchanged for tokens, when
+// WithDeviceCodes is given no TTL.
+const DefaultDeviceCodeTTL = 10 * time.Minute
+
+// DeviceCodePollInterval is how often clients are asked to poll PollDeviceAuthorization.
+const DeviceCodePollInterval = 5 * time.Second
+
+// maxDeviceClientNameLength bounds the client name stored with a device code.
+const maxDeviceClientNameLength = 100
+
+// DeviceCodeRepo persists device codes (e.g. *devicecoderepo.PostgresRepository).
+type DeviceCodeRepo interface {
+	Create(ctx context.Context, d *devicecodedomain.DeviceCode) error
+	GetByDeviceCodeHash(ctx context.Context, hash string) (*devicecodedomain.DeviceCode, error)
+	GetPendingByUserCode(ctx context.Context, userCode string, now time.Time) (*devicecodedomain.DeviceCode, error)
+	Decide(ctx context.Context, id string, status devicecodedomain.Status, userID, orgID string, decidedAt time.Time) (bool, error)
+	Complete(ctx context.Context, id string, now time.Time) (bool, error)
+}
+
+// WithDeviceCodes enables cross-device sign-in for clients that cannot sign in themselves (CLIs, kiosks), in the
+// style of the OAuth device authorization grant (RFC 8628). Codes expire after ttl (DefaultDeviceCodeTTL when
+// ttl <= 0). verificationURL is the page where users enter the user code; it is returned to clients, with the code
+// appended as user_code for QR codes. It may be empty.
+func WithDeviceCodes(repo DeviceCodeRepo, ttl time.Duration, verificationURL string) Option {
+	return func(s *AuthService) {
+		if ttl <= 0 {
+			ttl = DefaultDeviceCodeTTL
+		}
+		s.deviceCodes, s.deviceCodeTTL, s.deviceCodeURL = repo, ttl, verificationURL
+	}
+}
+
+// DeviceAuthorization is returned by StartDeviceAuthorization. DeviceCode is shown once and stays on the device,
+// which polls with it; UserCode is what the user enters on a trusted session.
+type DeviceAuthorization struct {
+	DeviceCode              string
+	UserCode                string // formatted, e.g. "BCDF-GHJK"
+	VerificationURI         string // empty unless configured
+	VerificationURIComplete string // VerificationURI with the user code, for QR codes; empty unless configured
+	ExpiresAt               time.Time
+	Interval                time.Duration
+}
+
+// StartDeviceAuthorization starts a device code sign-in for the calling device. clientName (e.g. "ztcp-cli on
+// build-01") is stored with the code and reported to the approver; deviceFingerprint identifies the device once it
+// signs in.
+func (s *AuthService) StartDeviceAuthorization(ctx context.Context, clientName, deviceFingerprint string) (*DeviceAuthorization, error) {
+	if s.deviceCodes == nil {
+		return nil, ErrDeviceCodesDisabled
+	}
+	code, hash, err := devicecodedomain.NewDeviceCode()
+	if err != nil {
+		return nil, err
+	}
+	userCode, err := devicecodedomain.NewUserCode()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	dc := &devicecodedomain.DeviceCode{
+		ID:                uuid.New().String(),
+		DeviceCodeHash:    hash,
+		UserCode:          userCode,
+		ClientName:        truncate(strings.TrimSpace(clientName), maxDeviceClientNameLength),
+		DeviceFingerprint: strings.TrimSpace(deviceFingerprint),
+		IP:                interceptors.ClientIP(ctx),
+		Status:            devicecodedomain.StatusPending,
+		CreatedAt:         now,
+		ExpiresAt:         now.Add(s.deviceCodeTTL),
+	}
+	if err := s.deviceCodes.Create(ctx, dc); err != nil {
+		return nil, err
+	}
+	out := &DeviceAuthorization{
+		DeviceCode: code,
+		UserCode:   devicecodedomain.FormatUserCode(userCode),
+		ExpiresAt:  dc.ExpiresAt,
+		Interval:   DeviceCodePollInterval,
+	}
+	if s.deviceCodeURL != "" {
+		out.VerificationURI = s.deviceCodeURL
+		out.VerificationURIComplete = withQueryParam(s.deviceCodeURL, "user_code", out.UserCode)
+	}
+	return out, nil
+}
+
+// withQueryParam returns base with the query parameter key set to value, or "" if base is not a URL.
+func withQueryParam(base, key, value string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// PollDeviceAuthorization exchanges an approved device code for tokens. Until the code is approved it fails with
+// ErrDeviceCodePending, so clients poll it every DeviceCodePollInterval; a denied code fails with
+// ErrDeviceCodeDenied. An approved code can be exchanged once, for a session of the approver in the approver's org
+// on the polling device. The steps run are the device_code flow (see deviceCodeSteps).
+func (s *AuthService) PollDeviceAuthorization(ctx context.Context, deviceCode string) (*LoginResult, error) {
+	return s.runFlow(ctx, &FlowState{
+		Flow:       FlowDeviceCode,
+		DeviceCode: strings.TrimSpace(deviceCode),
+	})
+}
+
+// stepDeviceCodeGrant checks the device code and consumes it once it is approved. The approver must still be
+// active and a member of the org.
+func (s *AuthService) stepDeviceCodeGrant(ctx context.Context, st *FlowState) (context.Context, error) {
+	if s.deviceCodes == nil || st.DeviceCode == "" {
+		return ctx, ErrInvalidDeviceCode
+	}
+	dc, err := s.deviceCodes.GetByDeviceCodeHash(ctx, devicecodedomain.HashDeviceCode(st.DeviceCode))
+	if err != nil {
+		return ctx, err
+	}
+	if dc == nil {
+		return ctx, ErrInvalidDeviceCode
+	}
+	now := time.Now().UTC()
+	switch dc.EffectiveStatus(now) {
+	case devicecodedomain.StatusApproved:
+	case devicecodedomain.StatusPending:
+		return ctx, ErrDeviceCodePending
+	case devicecodedomain.StatusDenied:
+		return ctx, ErrDeviceCodeDenied
+	default:
+		return ctx, ErrInvalidDeviceCode
+	}
+	st.OrgID, st.UserID = dc.OrgID, dc.UserID
+	membership, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, dc.UserID, dc.OrgID)
+	if err != nil {
+		return ctx, err
+	}
+	if membership == nil {
+		return ctx, ErrNotOrgMember
+	}
+	user, err := s.userRepo.GetByID(ctx, dc.UserID)
+	if err != nil {
+		return ctx, err
+	}
+	if user == nil || user.Status != userdomain.UserStatusActive {
+		return ctx, ErrInvalidCredentials
+	}
+	ok, err := s.deviceCodes.Complete(ctx, dc.ID, now)
+	if err != nil {
+		return ctx, err
+	}
+	if !ok {
+		return ctx, ErrInvalidDeviceCode
+	}
+	st.User, st.Role = user, membership.Role
+	st.DeviceFingerprint = dc.DeviceFingerprint
+	if st.DeviceFingerprint == "" {
+		st.DeviceFingerprint = "device-code"
+	}
+	return ctx, nil
+}
+
+// ApproveDeviceCode lets the device that started the device code with userCode sign in as the caller, in the org
+// of the caller's session. The caller's session must be on a device that is currently trusted.
+func (s *AuthService) ApproveDeviceCode(ctx context.Context, userCode string) (*devicecodedomain.DeviceCode, error) {
+	return s.decideDeviceCode(ctx, userCode, devicecodedomain.StatusApproved)
+}
+
+// DenyDeviceCode ends the device code sign-in with userCode; polling then fails with ErrDeviceCodeDenied. Any
+// signed-in user who has the code may deny it.
+func (s *AuthService) DenyDeviceCode(ctx context.Context, userCode string) (*devicecodedomain.DeviceCode, error) {
+	return s.decideDeviceCode(ctx, userCode, devicecodedomain.StatusDenied)
+}
+
+// decideDeviceCode approves or denies a pending device code and audits it as device_code_approved or
+// device_code_denied.
+func (s *AuthService) decideDeviceCode(ctx context.Context, userCode string, status devicecodedomain.Status) (*devicecodedomain.DeviceCode, error) {
+	if s.deviceCodes == nil {
+		return nil, ErrDeviceCodesDisabled
+	}
+	callerID, okUser := interceptors.GetUserID(ctx)
+	orgID, okOrg := interceptors.GetOrgID(ctx)
+	if !okUser || callerID == "" || !okOrg || orgID == "" {
+		return nil, ErrInvalidCredentials
+	}
+	if status == devicecodedomain.StatusApproved {
+		if err := s.requireTrustedSession(ctx, callerID); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now().UTC()
+	dc, err := s.deviceCodes.GetPendingByUserCode(ctx, devicecodedomain.NormalizeUserCode(userCode), now)
+	if err != nil {
+		return nil, err
+	}
+	if dc == nil {
+		return nil, ErrDeviceCodeNotFound
+	}
+	ok, err := s.deviceCodes.Decide(ctx, dc.ID, status, callerID, orgID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrDeviceCodeNotFound
+	}
+	dc.Status, dc.UserID, dc.OrgID, dc.DecidedAt = status, callerID, orgID, &now
+	if s.auditLogger != nil {
+		metadata, _ := json.Marshal(struct {
+			DeviceCodeID string `json:"device_code_id"`
+			ClientName   string `json:"client_name,omitempty"`
+			IP           string `json:"ip,omitempty"`
+		}{dc.ID, dc.ClientName, dc.IP})
+		s.auditLogger.LogEvent(ctx, orgID, callerID, "device_code_"+string(status), "authentication", string(metadata))
+	}
+	return dc, nil
+}
+
+// requireTrustedSession returns ErrTrustedDeviceRequired unless the session in ctx is the caller's, active, and on
+// a device that is effectively trusted now.
+func (s *AuthService) requireTrustedSession(ctx context.Context, callerID string) error {
+	sessionID, ok := interceptors.GetSessionID(ctx)
+	if !ok || sessionID == "" {
+		return ErrTrustedDeviceRequired
+	}
+	sess, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if sess == nil || sess.RevokedAt != nil || sess.UserID != callerID {
+		return ErrTrustedDeviceRequired
+	}
+	dev, err := s.deviceRepo.GetByID(ctx, sess.DeviceID)
+	if err != nil {
+		return err
+	}
+	if dev == nil || !dev.IsEffectivelyTrusted(time.Now().UTC()) {
+		return ErrTrustedDeviceRequired
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	devicecodedomain "zero-trust-control-plane/backend/internal/devicecode/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+type memDeviceCodeRepo struct {
+	mu sync.Mutex
+	m  map[string]*devicecodedomain.DeviceCode
+}
+
+func (r *memDeviceCodeRepo) Create(ctx context.Context, d *devicecodedomain.DeviceCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *d
+	r.m[d.ID] = &c
+	return nil
+}
+
+func (r *memDeviceCodeRepo) GetByDeviceCodeHash(ctx context.Context, hash string) (*devicecodedomain.DeviceCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range r.m {
+		if d.DeviceCodeHash == hash {
+			c := *d
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memDeviceCodeRepo) GetPendingByUserCode(ctx context.Context, userCode string, now time.Time) (*devicecodedomain.DeviceCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range r.m {
+		if d.UserCode == userCode && d.Status == devicecodedomain.StatusPending && d.ExpiresAt.After(now) {
+			c := *d
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memDeviceCodeRepo) Decide(ctx context.Context, id string, status devicecodedomain.Status, userID, orgID string, decidedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := r.m[id]
+	if d == nil || d.Status != devicecodedomain.StatusPending || !d.ExpiresAt.After(decidedAt) {
+		return false, nil
+	}
+	d.Status, d.UserID, d.OrgID, d.DecidedAt = status, userID, orgID, &decidedAt
+	return true, nil
+}
+
+func (r *memDeviceCodeRepo) Complete(ctx context.Context, id string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := r.m[id]
+	if d == nil || d.Status != devicecodedomain.StatusApproved || !d.ExpiresAt.After(now) {
+		return false, nil
+	}
+	d.Status = devicecodedomain.StatusCompleted
+	return true, nil
+}
+
+// newDeviceCodeTestService returns a service with device codes enabled and a member of org-1 signed in twice: on
+// a trusted device (trustedCtx) and on an untrusted one (untrustedCtx).
+// withDeviceCodes enables the device authorization grant.
+func withDeviceCodes() fixtureOption {
+	return withOptions(WithDeviceCodes(&memDeviceCodeRepo{m: make(map[string]*devicecodedomain.DeviceCode)}, 0, "https://app.example.com/device"))
+}
+
+// deviceCodeCallers gives withMember's user a session s1 on their trusted device and a session s2 on an untrusted
+// device "phone", and returns a ctx for each.
+func deviceCodeCallers(f *testFixture) (trustedCtx, untrustedCtx context.Context) {
+	phone := f.addDevice(f.userID, "org-1", "phone", false)
+	trustedCtx = interceptors.WithIdentity(context.Background(), f.userID, "org-1", f.addSession(f.userID, "org-1", "d1"))
+	untrustedCtx = interceptors.WithIdentity(context.Background(), f.userID, "org-1", f.addSession(f.userID, "org-1", phone))
+	return trustedCtx, untrustedCtx
+}
+
+func TestAuthService_DeviceCode_ApproveAndPoll(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withDeviceCodes(), withMember("user@example.com", membershipdomain.RoleMember, "laptop"))
+	trustedCtx, untrustedCtx := deviceCodeCallers(f)
+	svc, sessionRepo, auditLogger, userID := f.svc, f.sessions, f.audit, f.userID
+
+	auth, err := svc.StartDeviceAuthorization(context.Background(), "ztcp-cli on build-01", "build-01")
+	if err != nil {
+		t.Fatalf("StartDeviceAuthorization: %v", err)
+	}
+	if !strings.HasPrefix(auth.DeviceCode, devicecodedomain.DeviceCodePrefix) || len(auth.UserCode) != 9 || auth.UserCode[4] != '-' {
+		t.Errorf("codes = %q, %q; want a prefixed device code and XXXX-XXXX", auth.DeviceCode, auth.UserCode)
+	}
+	if auth.VerificationURIComplete != "https://app.example.com/device?user_code="+auth.UserCode || auth.Interval != DeviceCodePollInterval {
+		t.Errorf("verification_uri_complete = %q, interval = %v", auth.VerificationURIComplete, auth.Interval)
+	}
+
+	if _, err := svc.PollDeviceAuthorization(context.Background(), auth.DeviceCode); err != ErrDeviceCodePending {
+		t.Errorf("Poll before approval: want ErrDeviceCodePending, got %v", err)
+	}
+	if _, err := svc.PollDeviceAuthorization(context.Background(), "ztcp_dc_wrong"); err != ErrInvalidDeviceCode {
+		t.Errorf("Poll with unknown code: want ErrInvalidDeviceCode, got %v", err)
+	}
+	if _, err := svc.ApproveDeviceCode(untrustedCtx, auth.UserCode); err != ErrTrustedDeviceRequired {
+		t.Errorf("Approve from untrusted device: want ErrTrustedDeviceRequired, got %v", err)
+	}
+	if _, err := svc.ApproveDeviceCode(trustedCtx, "BCDF-GHJK"); err != ErrDeviceCodeNotFound && auth.UserCode != "BCDF-GHJK" {
+		t.Errorf("Approve unknown user code: want ErrDeviceCodeNotFound, got %v", err)
+	}
+	// User codes are accepted in any case, with or without the dash.
+	dc, err := svc.ApproveDeviceCode(trustedCtx, strings.ToLower(strings.ReplaceAll(auth.UserCode, "-", "")))
+	if err != nil {
+		t.Fatalf("ApproveDeviceCode: %v", err)
+	}
+	if dc.Status != devicecodedomain.StatusApproved || dc.UserID != userID || dc.OrgID != "org-1" || dc.ClientName != "ztcp-cli on build-01" {
+		t.Errorf("approved = %+v", dc)
+	}
+
+	res, err := svc.PollDeviceAuthorization(context.Background(), auth.DeviceCode)
+	if err != nil {
+		t.Fatalf("PollDeviceAuthorization: %v", err)
+	}
+	if res.Tokens == nil || res.Tokens.UserID != userID || res.Tokens.OrgID != "org-1" {
+		t.Fatalf("Poll result = %+v, want tokens for the approver in org-1", res)
+	}
+	var found bool
+	sessionRepo.mu.Lock()
+	for _, sess := range sessionRepo.m {
+		if sess.AuthMethod == sessiondomain.AuthMethodDeviceCode {
+			found = true
+			if sess.DeviceID == "d1" || sess.DeviceID == "d2" {
+				t.Errorf("device code session on device %s, want a new device", sess.DeviceID)
+			}
+		}
+	}
+	sessionRepo.mu.Unlock()
+	if !found {
+		t.Error("no session with auth method device_code")
+	}
+	if _, err := svc.PollDeviceAuthorization(context.Background(), auth.DeviceCode); err != ErrInvalidDeviceCode {
+		t.Errorf("second Poll: want ErrInvalidDeviceCode, got %v", err)
+	}
+
+	auditLogger.mu.Lock()
+	defer auditLogger.mu.Unlock()
+	var approved bool
+	for _, e := range auditLogger.events {
+		if e.action == "device_code_approved" && strings.Contains(e.metadata, dc.ID) {
+			approved = true
+		}
+	}
+	if !approved {
+		t.Errorf("audit events = %+v, want device_code_approved", auditLogger.events)
+	}
+}
+
+func TestAuthService_DeviceCode_Deny(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withDeviceCodes(), withMember("user@example.com", membershipdomain.RoleMember, "laptop"))
+	trustedCtx, untrustedCtx := deviceCodeCallers(f)
+	svc := f.svc
+
+	auth, err := svc.StartDeviceAuthorization(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("StartDeviceAuthorization: %v", err)
+	}
+	// Denying does not need a trusted device.
+	if _, err := svc.DenyDeviceCode(untrustedCtx, auth.UserCode); err != nil {
+		t.Fatalf("DenyDeviceCode: %v", err)
+	}
+	if _, err := svc.PollDeviceAuthorization(context.Background(), auth.DeviceCode); err != ErrDeviceCodeDenied {
+		t.Errorf("Poll after deny: want ErrDeviceCodeDenied, got %v", err)
+	}
+	if _, err := svc.ApproveDeviceCode(trustedCtx, auth.UserCode); err != ErrDeviceCodeNotFound {
+		t.Errorf("Approve after deny: want ErrDeviceCodeNotFound, got %v", err)
+	}
+}
+
+func TestAuthService_DeviceCode_Disabled(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	if _, err := svc.StartDeviceAuthorization(context.Background(), "", ""); err != ErrDeviceCodesDisabled {
+		t.Errorf("StartDeviceAuthorization: want ErrDeviceCodesDisabled, got %v", err)
+	}
+	if _, err := svc.PollDeviceAuthorization(context.Background(), "ztcp_dc_x"); err != ErrInvalidDeviceCode {
+		t.Errorf("PollDeviceAuthorization: want ErrInvalidDeviceCode, got %v", err)
+	}
+	if _, err := svc.ApproveDeviceCode(interceptors.WithIdentity(context.Background(), "u1", "org-1", "s1"), "BCDF-GHJK"); err != ErrDeviceCodesDisabled {
+		t.Errorf("ApproveDeviceCode: want ErrDeviceCodesDisabled, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+// trustRenewalStep is the least a sliding renewal moves a device's trust expiry forward, so a device that refreshes
+// every few minutes writes its trust at most about once a day.
+const trustRenewalStep = 24 * time.Hour
+
+// renewDeviceTrust extends the trust of a device that signs in or refreshes without MFA while trusted to the
+// policy's trust TTL from now (or the shorter remember-device duration the user chose for it), when the org's
+// device_trust.trust_renewal is sliding. Devices without an expiry are left alone. Best-effort: failures are logged
+// and do not affect the flow.
+func (s *AuthService) renewDeviceTrust(ctx context.Context, st *FlowState) {
+	dev := st.Device
+	if dev == nil || dev.TrustedUntil == nil || st.MFA.TrustTTLDays <= 0 {
+		return
+	}
+	now := time.Now().UTC()
+	if !dev.IsEffectivelyTrusted(now) {
+		return
+	}
+	until := now.AddDate(0, 0, st.MFA.TrustTTLDays)
+	if days := boundTrustDays(dev.TrustDays, st.MFA.TrustTTLDays); days > 0 {
+		until = now.AddDate(0, 0, days)
+	}
+	if until.Sub(*dev.TrustedUntil) < trustRenewalStep {
+		return
+	}
+	cfg, err := s.flowOrgPolicy(ctx, st)
+	if err != nil {
+		log.Printf("auth: org_id=%s policy lookup for trust renewal failed: %v", st.OrgID, err)
+		return
+	}
+	if !orgpolicyconfigdomain.MergeWithDefaults(cfg).DeviceTrust.SlidingRenewal() {
+		return
+	}
+	if err := s.deviceRepo.UpdateTrustedWithExpiry(ctx, dev.ID, true, &until); err != nil {
+		log.Printf("auth: device_id=%s failed to renew device trust: %v", dev.ID, err)
+		return
+	}
+	dev.TrustedUntil = &until
+}
+
+type trustDaysContextKey struct{}
+
+// ContextWithTrustDays returns ctx carrying the remember-device duration, in days, the user chose on Login or
+// VerifyMFA (e.g. 7 for "trust this device for 7 days"). 0 or less means the org's trust TTL; longer durations are
+// cut to it.
+func ContextWithTrustDays(ctx context.Context, days int) context.Context {
+	return context.WithValue(ctx, trustDaysContextKey{}, days)
+}
+
+func trustDaysFromContext(ctx context.Context) int {
+	days, _ := ctx.Value(trustDaysContextKey{}).(int)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// boundTrustDays returns the remember-device duration the user chose when it is shorter than the policy's trust
+// TTL, and 0 (use the trust TTL) otherwise.
+func boundTrustDays(chosen, trustTTLDays int) int {
+	if chosen <= 0 || chosen >= trustTTLDays {
+		return 0
+	}
+	return chosen
+}
+
+// flowOrgPolicy returns the org policy config of the flow's org, from the flow's prefetched reads when available.
+// Returns nil (defaults apply) without an org policy config repo.
+func (s *AuthService) flowOrgPolicy(ctx context.Context, st *FlowState) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	if l := st.lookups; l != nil && l.orgPolicy.loaded {
+		return l.orgPolicy.value, l.orgPolicy.err
+	}
+	if s.orgPolicyConfigRepo == nil {
+		return nil, nil
+	}
+	return s.orgPolicyConfigRepo.GetByOrgID(ctx, st.OrgID)
+}
+
+// recordQuarantinedLogin audits a sign-in or refresh from a quarantined device as quarantined_device_login and records
+// it as a security event for the user, with the device, the flow and the admin's reason for the quarantine.
+func (s *AuthService) recordQuarantinedLogin(ctx context.Context, st *FlowState) {
+	dev := st.Device
+	metadata, _ := json.Marshal(map[string]string{"device_id": dev.ID, "flow": st.Flow, "reason": dev.QuarantineReason})
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, st.OrgID, st.UserID, "quarantined_device_login", "authentication", string(metadata))
+	}
+	s.recordSecurityEvent(ctx, st.OrgID, st.UserID, securityeventdomain.EventQuarantinedLogin, string(metadata))
+}
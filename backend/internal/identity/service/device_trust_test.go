@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+)
+
+func TestAuthService_SlidingTrustRenewal(t *testing.T) {
+	for _, tt := range []struct {
+		renewal string
+		renewed bool
+	}{
+		{orgpolicyconfigdomain.TrustRenewalSliding, true},
+		{orgpolicyconfigdomain.TrustRenewalFixed, false},
+		{"", false},
+	} {
+		t.Run("renewal="+tt.renewal, func(t *testing.T) {
+			svc, _ := newTestAuthService(t)
+			WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+				DeviceTrust: &orgpolicyconfigdomain.DeviceTrust{TrustRenewal: tt.renewal},
+			}})(svc)
+			loginFlowFixture(t, svc, "fp-1")
+			deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+			soon := time.Now().UTC().Add(48 * time.Hour)
+			deviceRepo.mu.Lock()
+			deviceRepo.m["d1"].TrustedUntil = &soon
+			deviceRepo.mu.Unlock()
+			trustedUntil := func() time.Time {
+				deviceRepo.mu.Lock()
+				defer deviceRepo.mu.Unlock()
+				return *deviceRepo.m["d1"].TrustedUntil
+			}
+
+			res, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1")
+			if err != nil || res.Tokens == nil {
+				t.Fatalf("Login = %+v, %v; want tokens", res, err)
+			}
+			want := soon
+			if tt.renewed {
+				want = time.Now().UTC().AddDate(0, 0, 30)
+			}
+			if got := trustedUntil(); got.Sub(want).Abs() > time.Minute {
+				t.Fatalf("after Login trusted_until = %v, want ~%v", got, want)
+			}
+
+			// A refresh soon after does not move the expiry again (renewals are at least a day apart).
+			renewed := trustedUntil()
+			if _, err := svc.Refresh(context.Background(), res.Tokens.RefreshToken, "fp-1"); err != nil {
+				t.Fatalf("Refresh: %v", err)
+			}
+			if got := trustedUntil(); !got.Equal(renewed) {
+				t.Errorf("after Refresh trusted_until = %v, want %v", got, renewed)
+			}
+		})
+	}
+}
+
+func TestAuthService_SlidingTrustRenewal_Refresh(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		DeviceTrust: &orgpolicyconfigdomain.DeviceTrust{TrustRenewal: orgpolicyconfigdomain.TrustRenewalSliding},
+	}})(svc)
+	loginFlowFixture(t, svc, "fp-1")
+	res, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login = %+v, %v; want tokens", res, err)
+	}
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	soon := time.Now().UTC().Add(time.Hour)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d1"].TrustedUntil = &soon
+	deviceRepo.mu.Unlock()
+
+	if _, err := svc.Refresh(context.Background(), res.Tokens.RefreshToken, "fp-1"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	deviceRepo.mu.Lock()
+	got := *deviceRepo.m["d1"].TrustedUntil
+	deviceRepo.mu.Unlock()
+	if want := time.Now().UTC().AddDate(0, 0, 30); got.Sub(want).Abs() > time.Minute {
+		t.Errorf("trusted_until = %v, want ~%v", got, want)
+	}
+}
+
+func TestAuthService_SlidingTrustRenewal_ChosenTrustDays(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		DeviceTrust: &orgpolicyconfigdomain.DeviceTrust{TrustRenewal: orgpolicyconfigdomain.TrustRenewalSliding},
+	}})(svc)
+	loginFlowFixture(t, svc, "fp-1")
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	soon := time.Now().UTC().Add(time.Hour)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d1"].TrustedUntil = &soon
+	deviceRepo.m["d1"].TrustDays = 7
+	deviceRepo.mu.Unlock()
+
+	if _, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	deviceRepo.mu.Lock()
+	got := *deviceRepo.m["d1"].TrustedUntil
+	deviceRepo.mu.Unlock()
+	if want := time.Now().UTC().AddDate(0, 0, 7); got.Sub(want).Abs() > time.Minute {
+		t.Errorf("trusted_until = %v, want ~%v (the chosen 7 days)", got, want)
+	}
+}
+
+func TestAuthService_VerifyMFA_ChosenTrustDays(t *testing.T) {
+	for _, tt := range []struct {
+		name                  string
+		loginDays, verifyDays int
+		wantDays              int // recorded on the device
+		wantUntilDays         int
+	}{
+		{"none", 0, 0, 0, 30},
+		{"login", 7, 0, 7, 7},
+		{"verify overrides login", 7, 3, 3, 3},
+		{"longer than the trust TTL", 90, 0, 0, 30},
+		{"negative", -1, 0, 0, 30},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, devStore := newTestAuthServiceOpt(t, true)
+			ctx := context.Background()
+			reg, _ := svc.Register(ctx, "user@example.com", "Password123!abc", "")
+			userRepo := svc.userRepo.(*memUserRepo)
+			userRepo.mu.Lock()
+			userRepo.byID[reg.UserID].Phone = "15551234567"
+			userRepo.mu.Unlock()
+			membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+			membershipRepo.mu.Lock()
+			membershipRepo.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: reg.UserID, OrgID: "org-1", Role: membershipdomain.RoleMember, CreatedAt: time.Now()}
+			membershipRepo.mu.Unlock()
+
+			loginRes, err := svc.Login(ContextWithTrustDays(ctx, tt.loginDays), "user@example.com", "Password123!abc", "org-1", "new-device-fp")
+			if err != nil || loginRes.MFARequired == nil {
+				t.Fatalf("Login = %+v, %v; want MFA required", loginRes, err)
+			}
+			otp, _ := devStore.Get(ctx, loginRes.MFARequired.ChallengeID)
+			if _, err := svc.VerifyMFA(ContextWithTrustDays(ctx, tt.verifyDays), loginRes.MFARequired.ChallengeID, otp); err != nil {
+				t.Fatalf("VerifyMFA: %v", err)
+			}
+
+			deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+			deviceRepo.mu.Lock()
+			defer deviceRepo.mu.Unlock()
+			for _, d := range deviceRepo.m {
+				if !d.Trusted || d.TrustedUntil == nil {
+					t.Fatalf("device = %+v, want trusted with an expiry", d)
+				}
+				if d.TrustDays != tt.wantDays {
+					t.Errorf("TrustDays = %d, want %d", d.TrustDays, tt.wantDays)
+				}
+				if want := time.Now().UTC().AddDate(0, 0, tt.wantUntilDays); d.TrustedUntil.Sub(want).Abs() > time.Minute {
+					t.Errorf("trusted_until = %v, want ~%v", *d.TrustedUntil, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/emailchange"
+	emailchangedomain "zero-trust-control-plane/backend/internal/emailchange/domain"
+	"zero-trust-control-plane/backend/internal/security"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// DefaultEmailChangeTTL is how long the links of an email change can be opened, when WithEmailChange is given no TTL.
+const DefaultEmailChangeTTL = 24 * time.Hour
+
+// EmailChangeRepo persists email changes (e.g. *emailchangerepo.PostgresRepository).
+type EmailChangeRepo interface {
+	Create(ctx context.Context, c *emailchangedomain.EmailChange) error
+	GetByTokenHash(ctx context.Context, hash string) (*emailchangedomain.EmailChange, error)
+	Confirm(ctx context.Context, id string, addr emailchangedomain.Address, now time.Time) (*emailchangedomain.EmailChange, error)
+	Complete(ctx context.Context, id string, now time.Time) (bool, error)
+}
+
+// EmailChangeMailer delivers the confirmation links of email changes (e.g. *emailchange.EmailMailer). Send must not
+// block the caller.
+type EmailChangeMailer interface {
+	Send(ctx context.Context, msg emailchange.Message)
+}
+
+// WithEmailChange enables StartEmailChange and ConfirmEmailChange. Links are linkURL with the token as the token
+// query parameter, sent through mailer, and can be opened within ttl (DefaultEmailChangeTTL when ttl <= 0).
+func WithEmailChange(repo EmailChangeRepo, mailer EmailChangeMailer, ttl time.Duration, linkURL string) Option {
+	return func(s *AuthService) {
+		if ttl <= 0 {
+			ttl = DefaultEmailChangeTTL
+		}
+		s.emailChanges, s.emailChangeMailer, s.emailChangeTTL, s.emailChangeURL = repo, mailer, ttl, linkURL
+	}
+}
+
+// EmailChangeResult is returned by StartEmailChange.
+type EmailChangeResult struct {
+	EmailChangeID string
+	ExpiresAt     time.Time
+}
+
+// ConfirmEmailChangeResult is returned by ConfirmEmailChange: which addresses have been confirmed, and whether the
+// email was changed (once both were).
+type ConfirmEmailChangeResult struct {
+	CurrentEmailConfirmed bool
+	NewEmailConfirmed     bool
+	Completed             bool
+	SessionsRevoked       int64
+}
+
+// StartEmailChange emails a confirmation link to the caller's current address and one to newEmail. The email does
+// not change, and the current address keeps working for sign-in, until both links are opened (ConfirmEmailChange).
+// Starting again replaces an unfinished change. It returns ErrEmailAlreadyRegistered when newEmail belongs to
+// another user and ErrEmailUnchanged when it is the current email. The caller is identified by the access token in
+// ctx. Audited as email_change_started.
+func (s *AuthService) StartEmailChange(ctx context.Context, newEmail string) (*EmailChangeResult, error) {
+	if s.emailChanges == nil || s.emailChangeMailer == nil {
+		return nil, ErrEmailChangeDisabled
+	}
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, ErrInvalidCredentials
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	newEmail = strings.TrimSpace(strings.ToLower(newEmail))
+	if err := validateEmail(newEmail); err != nil {
+		return nil, err
+	}
+	usr, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if usr == nil || usr.Status != userdomain.UserStatusActive {
+		return nil, ErrInvalidCredentials
+	}
+	if newEmail == strings.ToLower(usr.Email) {
+		return nil, ErrEmailUnchanged
+	}
+	if err := s.checkEmailAvailable(ctx, userID, newEmail); err != nil {
+		return nil, err
+	}
+	oldToken, oldHash, err := emailchangedomain.NewToken()
+	if err != nil {
+		return nil, err
+	}
+	newToken, newHash, err := emailchangedomain.NewToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	c := &emailchangedomain.EmailChange{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		OrgID:        orgID,
+		OldEmail:     usr.Email,
+		NewEmail:     newEmail,
+		OldTokenHash: oldHash,
+		NewTokenHash: newHash,
+		IP:           interceptors.ClientIP(ctx),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(s.emailChangeTTL),
+	}
+	if err := s.emailChanges.Create(ctx, c); err != nil {
+		return nil, err
+	}
+	for _, msg := range []emailchange.Message{
+		{To: c.OldEmail, Address: emailchangedomain.AddressCurrent, Link: withQueryParam(s.emailChangeURL, "token", oldToken)},
+		{To: c.NewEmail, Address: emailchangedomain.AddressNew, Link: withQueryParam(s.emailChangeURL, "token", newToken)},
+	} {
+		msg.OrgID, msg.NewEmail, msg.ExpiresAt, msg.IP = orgID, c.NewEmail, c.ExpiresAt, c.IP
+		s.emailChangeMailer.Send(ctx, msg)
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "email_change_started", "authentication", `{"email_change_id":"`+c.ID+`"}`)
+	}
+	return &EmailChangeResult{EmailChangeID: c.ID, ExpiresAt: c.ExpiresAt}, nil
+}
+
+// ConfirmEmailChange records that the link carrying token was opened. Once the links to both addresses have been
+// opened, the user's email and the provider_id of their local identity are changed in one transaction, and all of
+// the user's sessions are revoked as email_changed. It needs no access token: the token alone identifies the change,
+// so links can be opened on any device. A used-up, replaced, expired or unknown token fails with
+// ErrInvalidEmailChange; the change also fails with it when the user's email changed meanwhile, and with
+// ErrEmailAlreadyRegistered when another user took the new email. Opening the same link twice is harmless.
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, token string) (*ConfirmEmailChangeResult, error) {
+	if s.emailChanges == nil {
+		return nil, ErrEmailChangeDisabled
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrInvalidEmailChange
+	}
+	hash := security.HashToken(token)
+	c, err := s.emailChanges.GetByTokenHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if c == nil || !c.Pending(now) {
+		return nil, ErrInvalidEmailChange
+	}
+	addr := c.AddressOf(hash)
+	if c, err = s.emailChanges.Confirm(ctx, c.ID, addr, now); err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrInvalidEmailChange
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(c.OrgID), c.UserID, "email_change_confirmed", "authentication", `{"email_change_id":"`+c.ID+`","address":"`+string(addr)+`"}`)
+	}
+	res := &ConfirmEmailChangeResult{CurrentEmailConfirmed: c.OldConfirmedAt != nil, NewEmailConfirmed: c.NewConfirmedAt != nil}
+	if !c.Confirmed() {
+		return res, nil
+	}
+	revoked, err := s.completeEmailChange(ctx, c, now)
+	if err != nil {
+		return nil, err
+	}
+	res.Completed, res.SessionsRevoked = true, revoked
+	return res, nil
+}
+
+// completeEmailChange applies a confirmed email change and revokes the user's sessions. It returns how many
+// sessions were revoked.
+func (s *AuthService) completeEmailChange(ctx context.Context, c *emailchangedomain.EmailChange, now time.Time) (int64, error) {
+	if err := s.checkEmailAvailable(ctx, c.UserID, c.NewEmail); err != nil {
+		return 0, err
+	}
+	changed, err := s.userRepo.ChangeEmail(ctx, c.UserID, c.OldEmail, c.NewEmail)
+	if err != nil {
+		return 0, err
+	}
+	if !changed {
+		return 0, ErrInvalidEmailChange
+	}
+	if _, err := s.emailChanges.Complete(ctx, c.ID, now); err != nil {
+		return 0, err
+	}
+	revoked, err := s.sessionRepo.RevokeOtherSessionsByUser(ctx, c.UserID, "", sessiondomain.Revocation{Reason: sessiondomain.RevocationEmailChanged, By: c.UserID})
+	if err != nil {
+		return 0, err
+	}
+	metadata := `{"email_change_id":"` + c.ID + `","sessions_revoked":` + strconv.FormatInt(revoked, 10) + `}`
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(c.OrgID), c.UserID, "email_changed", "authentication", metadata)
+	}
+	s.recordSecurityEvent(ctx, c.OrgID, c.UserID, securityeventdomain.EventEmailChanged, metadata)
+	return revoked, nil
+}
+
+// checkEmailAvailable returns ErrEmailAlreadyRegistered when email belongs to a user other than userID.
+func (s *AuthService) checkEmailAvailable(ctx context.Context, userID, email string) error {
+	other, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if other != nil && other.ID != userID {
+		return ErrEmailAlreadyRegistered
+	}
+	return nil
+}
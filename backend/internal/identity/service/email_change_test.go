@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/emailchange"
+	emailchangedomain "zero-trust-control-plane/backend/internal/emailchange/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+type memEmailChangeRepo struct {
+	mu sync.Mutex
+	m  map[string]*emailchangedomain.EmailChange
+}
+
+func (r *memEmailChangeRepo) Create(ctx context.Context, c *emailchangedomain.EmailChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, old := range r.m {
+		if old.UserID == c.UserID && old.CompletedAt == nil {
+			delete(r.m, id)
+		}
+	}
+	cp := *c
+	r.m[c.ID] = &cp
+	return nil
+}
+
+func (r *memEmailChangeRepo) GetByTokenHash(ctx context.Context, hash string) (*emailchangedomain.EmailChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.m {
+		if c.OldTokenHash == hash || c.NewTokenHash == hash {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memEmailChangeRepo) Confirm(ctx context.Context, id string, addr emailchangedomain.Address, now time.Time) (*emailchangedomain.EmailChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.m[id]
+	if c == nil || !c.Pending(now) {
+		return nil, nil
+	}
+	switch {
+	case addr == emailchangedomain.AddressCurrent && c.OldConfirmedAt == nil:
+		c.OldConfirmedAt = &now
+	case addr == emailchangedomain.AddressNew && c.NewConfirmedAt == nil:
+		c.NewConfirmedAt = &now
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (r *memEmailChangeRepo) Complete(ctx context.Context, id string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.m[id]
+	if c == nil || c.CompletedAt != nil {
+		return false, nil
+	}
+	c.CompletedAt = &now
+	return true, nil
+}
+
+type recordingEmailChangeMailer struct {
+	mu   sync.Mutex
+	sent []emailchange.Message
+}
+
+func (m *recordingEmailChangeMailer) Send(ctx context.Context, msg emailchange.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+}
+
+// token returns the token from the last link sent to addr, or "" if none was sent.
+func (m *recordingEmailChangeMailer) token(t *testing.T, addr emailchangedomain.Address) string {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.sent) - 1; i >= 0; i-- {
+		if m.sent[i].Address != addr {
+			continue
+		}
+		u, err := url.Parse(m.sent[i].Link)
+		if err != nil {
+			t.Fatalf("parse link: %v", err)
+		}
+		return u.Query().Get("token")
+	}
+	return ""
+}
+
+// withEmailChange enables email change.
+func withEmailChange() fixtureOption {
+	return func(f *testFixture) {
+		f.emailChanges = &memEmailChangeRepo{m: make(map[string]*emailchangedomain.EmailChange)}
+		f.emailChangeMailer = &recordingEmailChangeMailer{}
+		f.opts = append(f.opts, WithEmailChange(f.emailChanges, f.emailChangeMailer, 0, "https://app.example.com/email-change"))
+	}
+}
+
+func TestAuthService_EmailChange_StartAndConfirm(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withEmailChange(), withUser("user@example.com"))
+	ctx := interceptors.WithIdentity(context.Background(), f.userID, "org-1", f.addSession(f.userID, "org-1", ""))
+	svc, sessionRepo, mailer, auditLogger, userID := f.svc, f.sessions, f.emailChangeMailer, f.audit, f.userID
+	recorder := &memSecurityEventRecorder{}
+	WithSecurityEventRecorder(recorder)(svc)
+
+	if _, err := svc.StartEmailChange(context.Background(), "new@example.com"); err != ErrInvalidCredentials {
+		t.Errorf("no caller: want ErrInvalidCredentials, got %v", err)
+	}
+	res, err := svc.StartEmailChange(ctx, " New@Example.com ")
+	if err != nil {
+		t.Fatalf("StartEmailChange: %v", err)
+	}
+	if res.EmailChangeID == "" || time.Until(res.ExpiresAt) <= 23*time.Hour {
+		t.Errorf("result = %+v, want an id and a 24h expiry", res)
+	}
+	if len(mailer.sent) != 2 || mailer.sent[0].To != "user@example.com" || mailer.sent[1].To != "new@example.com" {
+		t.Fatalf("sent = %+v, want one link to each address", mailer.sent)
+	}
+	oldToken, newToken := mailer.token(t, emailchangedomain.AddressCurrent), mailer.token(t, emailchangedomain.AddressNew)
+	if !strings.HasPrefix(oldToken, emailchangedomain.TokenPrefix) || !strings.HasPrefix(newToken, emailchangedomain.TokenPrefix) || oldToken == newToken {
+		t.Fatalf("tokens %q and %q, want two different prefixed tokens", oldToken, newToken)
+	}
+	if !auditLogger.hasAction("email_change_started") {
+		t.Errorf("audit events = %+v, want email_change_started", auditLogger.events)
+	}
+
+	got, err := svc.ConfirmEmailChange(context.Background(), newToken)
+	if err != nil || !got.NewEmailConfirmed || got.CurrentEmailConfirmed || got.Completed {
+		t.Fatalf("ConfirmEmailChange(new) = %+v, %v; want only the new address confirmed", got, err)
+	}
+	if u, _ := svc.userRepo.GetByID(ctx, userID); u.Email != "user@example.com" {
+		t.Errorf("email = %s before both addresses were confirmed", u.Email)
+	}
+	if got, err := svc.ConfirmEmailChange(context.Background(), newToken); err != nil || got.Completed {
+		t.Errorf("opening the same link twice = %+v, %v; want no change", got, err)
+	}
+
+	got, err = svc.ConfirmEmailChange(context.Background(), oldToken)
+	if err != nil || !got.CurrentEmailConfirmed || !got.NewEmailConfirmed || !got.Completed || got.SessionsRevoked != 1 {
+		t.Fatalf("ConfirmEmailChange(current) = %+v, %v; want the change completed and 1 session revoked", got, err)
+	}
+	if u, _ := svc.userRepo.GetByEmail(ctx, "new@example.com"); u == nil || u.ID != userID {
+		t.Errorf("user by new email = %+v", u)
+	}
+	if u, _ := svc.userRepo.GetByEmail(ctx, "user@example.com"); u != nil {
+		t.Errorf("old email still resolves to %+v", u)
+	}
+	if s1, _ := sessionRepo.GetByID(ctx, "s1"); s1.RevokedAt == nil || s1.RevocationReason != sessiondomain.RevocationEmailChanged {
+		t.Errorf("session = %+v, want revoked as email_changed", s1)
+	}
+	if !auditLogger.hasAction("email_change_confirmed") || !auditLogger.hasAction("email_changed") {
+		t.Errorf("audit events = %+v, want email_change_confirmed and email_changed", auditLogger.events)
+	}
+	if n := len(recorder.types); n == 0 || recorder.types[n-1] != securityeventdomain.EventEmailChanged {
+		t.Errorf("security events = %v, want last email_changed", recorder.types)
+	}
+	if _, err := svc.ConfirmEmailChange(context.Background(), oldToken); err != ErrInvalidEmailChange {
+		t.Errorf("link of a completed change: want ErrInvalidEmailChange, got %v", err)
+	}
+}
+
+func TestAuthService_EmailChange_Rejected(t *testing.T) {
+	f := newTestFixture(t, withEmailChange(), withUser("user@example.com"))
+	ctx := interceptors.WithIdentity(context.Background(), f.userID, "org-1", f.addSession(f.userID, "org-1", ""))
+	svc := f.svc
+	if _, err := svc.Register(context.Background(), "taken@example.com", "Password123!abc", ""); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	for email, want := range map[string]error{
+		"USER@example.com":  ErrEmailUnchanged,
+		"taken@example.com": ErrEmailAlreadyRegistered,
+	} {
+		if _, err := svc.StartEmailChange(ctx, email); err != want {
+			t.Errorf("StartEmailChange(%q): want %v, got %v", email, want, err)
+		}
+	}
+	if _, err := svc.StartEmailChange(ctx, "not-an-email"); err == nil {
+		t.Error("StartEmailChange with an invalid email: want an error")
+	}
+}
+
+func TestAuthService_EmailChange_TakenBeforeCompletion(t *testing.T) {
+	f := newTestFixture(t, withEmailChange(), withUser("user@example.com"))
+	ctx := interceptors.WithIdentity(context.Background(), f.userID, "org-1", f.addSession(f.userID, "org-1", ""))
+	svc, mailer, userID := f.svc, f.emailChangeMailer, f.userID
+	if _, err := svc.StartEmailChange(ctx, "new@example.com"); err != nil {
+		t.Fatalf("StartEmailChange: %v", err)
+	}
+	if _, err := svc.Register(context.Background(), "new@example.com", "Password123!abc", ""); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := svc.ConfirmEmailChange(context.Background(), mailer.token(t, emailchangedomain.AddressCurrent)); err != nil {
+		t.Fatalf("ConfirmEmailChange(current): %v", err)
+	}
+	if _, err := svc.ConfirmEmailChange(context.Background(), mailer.token(t, emailchangedomain.AddressNew)); err != ErrEmailAlreadyRegistered {
+		t.Errorf("ConfirmEmailChange(new): want ErrEmailAlreadyRegistered, got %v", err)
+	}
+	if u, _ := svc.userRepo.GetByID(ctx, userID); u.Email != "user@example.com" {
+		t.Errorf("email = %s, want unchanged", u.Email)
+	}
+}
+
+func TestAuthService_EmailChange_ReplacedOrExpired(t *testing.T) {
+	f := newTestFixture(t, withEmailChange(), withUser("user@example.com"))
+	ctx := interceptors.WithIdentity(context.Background(), f.userID, "org-1", f.addSession(f.userID, "org-1", ""))
+	svc, repo, mailer := f.svc, f.emailChanges, f.emailChangeMailer
+	if _, err := svc.StartEmailChange(ctx, "first@example.com"); err != nil {
+		t.Fatalf("StartEmailChange: %v", err)
+	}
+	replaced := mailer.token(t, emailchangedomain.AddressNew)
+	if _, err := svc.StartEmailChange(ctx, "second@example.com"); err != nil {
+		t.Fatalf("second StartEmailChange: %v", err)
+	}
+	if _, err := svc.ConfirmEmailChange(context.Background(), replaced); err != ErrInvalidEmailChange {
+		t.Errorf("replaced link: want ErrInvalidEmailChange, got %v", err)
+	}
+	if _, err := svc.ConfirmEmailChange(context.Background(), "ztcp_ec_unknown"); err != ErrInvalidEmailChange {
+		t.Errorf("unknown token: want ErrInvalidEmailChange, got %v", err)
+	}
+	repo.mu.Lock()
+	for _, c := range repo.m {
+		c.ExpiresAt = time.Now().Add(-time.Second)
+	}
+	repo.mu.Unlock()
+	if _, err := svc.ConfirmEmailChange(context.Background(), mailer.token(t, emailchangedomain.AddressNew)); err != ErrInvalidEmailChange {
+		t.Errorf("expired link: want ErrInvalidEmailChange, got %v", err)
+	}
+}
+
+func TestAuthService_EmailChange_Disabled(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	ctx := interceptors.WithIdentity(context.Background(), "u1", "org-1", "s1")
+	if _, err := svc.StartEmailChange(ctx, "new@example.com"); err != ErrEmailChangeDisabled {
+		t.Errorf("StartEmailChange: want ErrEmailChangeDisabled, got %v", err)
+	}
+	if _, err := svc.ConfirmEmailChange(context.Background(), "ztcp_ec_x"); err != ErrEmailChangeDisabled {
+		t.Errorf("ConfirmEmailChange: want ErrEmailChangeDisabled, got %v", err)
+	}
+}
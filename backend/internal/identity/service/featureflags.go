@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/featureflag"
+)
+
+// FeatureFlagEvaluator reports whether a feature flag is on for an org (e.g. *featureflag.Evaluator).
+type FeatureFlagEvaluator interface {
+	Enabled(ctx context.Context, key, orgID string) bool
+}
+
+// WithFeatureFlags gates rolled-out features per org (featureflag.RefreshPoP, featureflag.TokenExchange). Without
+// it, every flag takes its featureflag.Default.
+func WithFeatureFlags(f FeatureFlagEvaluator) Option {
+	return func(s *AuthService) { s.featureFlags = f }
+}
+
+func (s *AuthService) featureEnabled(ctx context.Context, key, orgID string) bool {
+	if s.featureFlags == nil {
+		return featureflag.Default(key)
+	}
+	return s.featureFlags.Enabled(ctx, key, orgID)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/featureflag"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/security"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// stubFlags turns off the listed flags for every org; other flags take their defaults.
+type stubFlags map[string]bool
+
+func (f stubFlags) Enabled(ctx context.Context, key, orgID string) bool {
+	if on, ok := f[key]; ok {
+		return on
+	}
+	return featureflag.Default(key)
+}
+
+func TestAuthService_FeatureFlags(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withNetworkAccess(false), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc, auditLogger := f.svc, f.audit
+	WithTokenExchange([]string{"payroll-gateway"}, 5*time.Minute)(svc)
+	WithFeatureFlags(stubFlags{featureflag.RefreshPoP: false, featureflag.TokenExchange: false})(svc)
+
+	idCtx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+	if _, err := svc.ExchangeToken(idCtx, "payroll-gateway", "", 0); err != ErrFeatureDisabled {
+		t.Errorf("ExchangeToken with flag off: want ErrFeatureDisabled, got %v", err)
+	}
+	if !auditLogger.hasAction("resource_token_denied") {
+		t.Error("exchange blocked by flag should be audited")
+	}
+
+	_, jwk, err := security.NewTestPoPKey()
+	if err != nil {
+		t.Fatalf("NewTestPoPKey: %v", err)
+	}
+	ctx := ctxFromIP("10.0.0.1")
+	res, err := svc.Login(ContextWithPoPKey(ctx, jwk), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login: res=%+v err=%v", res, err)
+	}
+	if _, err := svc.Refresh(ctx, res.Tokens.RefreshToken, "fp-1"); err != nil {
+		t.Errorf("with auth.refresh_pop off the session should not be key-bound; Refresh: %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"zero-trust-control-plane/backend/internal/audit"
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	"zero-trust-control-plane/backend/internal/devotp"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
+	mfaintentdomain "zero-trust-control-plane/backend/internal/mfaintent/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/replay"
+	"zero-trust-control-plane/backend/internal/security"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// testFixture is an AuthService over in-memory repositories, built by newTestFixture, with its repositories, the
+// users it seeded and the test doubles its options installed.
+type testFixture struct {
+	svc         *AuthService
+	users       *memUserRepo
+	sessions    *memSessionRepo
+	devices     *memDeviceRepo
+	memberships *memMembershipRepo
+	devOTP      *devotp.MemoryStore
+	audit       *mockAuditLogger
+	events      *memSecurityEventRecorder
+	// userID is the first user seeded by withUser or withMember; userIDs has every seeded user by email.
+	userID  string
+	userIDs map[string]string
+
+	replays            *replay.Memory
+	honeytokens        *memHoneytokenRepo
+	honeytokenNotifier *recordingHoneytokenNotifier
+	magicLinks         *memMagicLinkRepo
+	magicLinkMailer    *recordingMagicLinkMailer
+	emailChanges       *memEmailChangeRepo
+	emailChangeMailer  *recordingEmailChangeMailer
+	invites            *memInvitationRepo
+	captcha            *fakeCaptcha
+
+	opts  []Option
+	seeds []func(t *testing.T)
+}
+
+// fixtureOption configures a testFixture. Options add service options, applied by NewAuthService, and seeds, run in
+// order once the service is built.
+type fixtureOption func(f *testFixture)
+
+// newTestFixture returns an AuthService over empty in-memory repositories with opts applied. Without options there
+// is no audit logger, no dev OTP store and no optional feature.
+func newTestFixture(t *testing.T, opts ...fixtureOption) *testFixture {
+	t.Helper()
+	f := &testFixture{
+		users:       &memUserRepo{byID: make(map[string]*userdomain.User), byEmail: make(map[string]*userdomain.User)},
+		sessions:    &memSessionRepo{m: make(map[string]*sessiondomain.Session)},
+		devices:     &memDeviceRepo{m: make(map[string]*devicedomain.Device)},
+		memberships: &memMembershipRepo{m: make(map[string]*membershipdomain.Membership)},
+		userIDs:     make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	identityRepo := &memIdentityRepo{m: make(map[string]*identitydomain.Identity)}
+	f.users.identities = identityRepo
+	tokens, err := security.NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	var devStore DevOTPStore
+	if f.devOTP != nil {
+		devStore = f.devOTP
+	}
+	var auditLogger audit.AuditLogger
+	if f.audit != nil {
+		auditLogger = f.audit
+	}
+	f.svc = NewAuthService(
+		f.users,
+		identityRepo,
+		f.sessions,
+		f.devices,
+		f.memberships,
+		&memPlatformSettingsRepo{},
+		&memOrgMFASettingsRepo{},
+		&memMFAChallengeRepo{m: make(map[string]*mfadomain.Challenge)},
+		&memMFAIntentRepo{m: make(map[string]*mfaintentdomain.Intent)},
+		&memPolicyEvaluator{},
+		&memOTPSender{},
+		security.NewHasher(10),
+		tokens,
+		15*time.Minute,
+		24*time.Hour,
+		30,             // defaultTrustTTLDays
+		10*time.Minute, // mfaChallengeTTL
+		f.devOTP != nil,
+		devStore,
+		auditLogger,
+		f.opts...,
+	)
+	for _, seed := range f.seeds {
+		seed(t)
+	}
+	return f
+}
+
+// withDevOTP returns OTPs to the client and keeps them in f.devOTP.
+func withDevOTP() fixtureOption {
+	return func(f *testFixture) { f.devOTP = devotp.NewMemoryStore() }
+}
+
+// withAuditLogger records audit events in f.audit.
+func withAuditLogger() fixtureOption {
+	return func(f *testFixture) { f.audit = &mockAuditLogger{} }
+}
+
+// withSecurityEvents records security events in f.events.
+func withSecurityEvents() fixtureOption {
+	return func(f *testFixture) {
+		f.events = &memSecurityEventRecorder{}
+		f.opts = append(f.opts, WithSecurityEventRecorder(f.events))
+	}
+}
+
+// withOptions applies service options.
+func withOptions(opts ...Option) fixtureOption {
+	return func(f *testFixture) { f.opts = append(f.opts, opts...) }
+}
+
+// withOrgPolicy gives every org the policy config cfg.
+func withOrgPolicy(cfg *orgpolicyconfigdomain.OrgPolicyConfig) fixtureOption {
+	return withOptions(WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: cfg}))
+}
+
+// withNetworkAccess restricts every org to 10.0.0.0/8, letting owners bypass the restriction when ownerBypass is set.
+func withNetworkAccess(ownerBypass bool) fixtureOption {
+	return withOrgPolicy(&orgpolicyconfigdomain.OrgPolicyConfig{
+		NetworkAccess: &orgpolicyconfigdomain.NetworkAccess{AllowedCidrs: []string{"10.0.0.0/8"}, OwnerBypass: ownerBypass},
+	})
+}
+
+// withReplayCache claims refresh tokens in f.replays.
+func withReplayCache() fixtureOption {
+	return func(f *testFixture) {
+		f.replays = replay.NewMemory(time.Minute)
+		f.opts = append(f.opts, WithRefreshReplayCache(f.replays))
+	}
+}
+
+// withUser registers email with password Password123!abc.
+func withUser(email string) fixtureOption {
+	return func(f *testFixture) {
+		f.seeds = append(f.seeds, func(t *testing.T) { f.register(t, email) })
+	}
+}
+
+// withMember registers email with password Password123!abc and makes them a member of org-1 with role. Unless
+// fingerprint is empty, they also get a trusted device in org-1 with that fingerprint, so Login needs no MFA there.
+func withMember(email string, role membershipdomain.Role, fingerprint string) fixtureOption {
+	return func(f *testFixture) {
+		f.seeds = append(f.seeds, func(t *testing.T) {
+			userID := f.register(t, email)
+			f.addMembership(userID, "org-1", role)
+			if fingerprint != "" {
+				f.addDevice(userID, "org-1", fingerprint, true)
+			}
+		})
+	}
+}
+
+// withPhone sets the phone number of the seeded user email.
+func withPhone(email, phone string) fixtureOption {
+	return func(f *testFixture) {
+		f.seeds = append(f.seeds, func(t *testing.T) {
+			f.users.mu.Lock()
+			defer f.users.mu.Unlock()
+			f.users.byID[f.userIDs[email]].Phone = phone
+		})
+	}
+}
+
+// register registers email with password Password123!abc and returns the user ID.
+func (f *testFixture) register(t *testing.T, email string) string {
+	t.Helper()
+	reg, err := f.svc.Register(context.Background(), email, "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register %s: %v", email, err)
+	}
+	if f.userID == "" {
+		f.userID = reg.UserID
+	}
+	f.userIDs[email] = reg.UserID
+	return reg.UserID
+}
+
+// addMembership makes userID a member of orgID with role, as membership m<n> for the fixture's nth membership.
+func (f *testFixture) addMembership(userID, orgID string, role membershipdomain.Role) string {
+	f.memberships.mu.Lock()
+	defer f.memberships.mu.Unlock()
+	id := fmt.Sprintf("m%d", len(f.memberships.m)+1)
+	f.memberships.m[id] = &membershipdomain.Membership{ID: id, UserID: userID, OrgID: orgID, Role: role, CreatedAt: time.Now()}
+	return id
+}
+
+// addDevice adds a device of userID in orgID with fingerprint, as device d<n> for the fixture's nth device.
+func (f *testFixture) addDevice(userID, orgID, fingerprint string, trusted bool) string {
+	f.devices.mu.Lock()
+	defer f.devices.mu.Unlock()
+	id := fmt.Sprintf("d%d", len(f.devices.m)+1)
+	f.devices.m[id] = &devicedomain.Device{ID: id, UserID: userID, OrgID: orgID, Fingerprint: fingerprint, Trusted: trusted, CreatedAt: time.Now()}
+	return id
+}
+
+// addSession adds a session of userID in orgID on deviceID, expiring in an hour, as session s<n> for the fixture's
+// nth session.
+func (f *testFixture) addSession(userID, orgID, deviceID string) string {
+	f.sessions.mu.Lock()
+	defer f.sessions.mu.Unlock()
+	id := fmt.Sprintf("s%d", len(f.sessions.m)+1)
+	f.sessions.m[id] = &sessiondomain.Session{ID: id, UserID: userID, OrgID: orgID, DeviceID: deviceID, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	return id
+}
+
+// login signs in as user@example.com to org-1 on device "fp-1" and returns the tokens.
+func (f *testFixture) login(t *testing.T, ctx context.Context) *AuthResult {
+	t.Helper()
+	res, err := f.svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login: res=%+v err=%v", res, err)
+	}
+	return res.Tokens
+}
+
+// loginSession signs in like login and returns the refresh token and the new session.
+func (f *testFixture) loginSession(t *testing.T) (string, *sessiondomain.Session) {
+	t.Helper()
+	tokens := f.login(t, context.Background())
+	sessionID, _, _, _, err := f.svc.tokens.ValidateRefresh(tokens.RefreshToken)
+	if err != nil {
+		t.Fatalf("ValidateRefresh: %v", err)
+	}
+	f.sessions.mu.Lock()
+	defer f.sessions.mu.Unlock()
+	return tokens.RefreshToken, f.sessions.m[sessionID]
+}
+
+type staticOrgPolicyConfigRepo struct {
+	cfg *orgpolicyconfigdomain.OrgPolicyConfig
+}
+
+func (r staticOrgPolicyConfigRepo) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	return r.cfg, nil
+}
+
+type orgPolicyConfigsByOrg map[string]*orgpolicyconfigdomain.OrgPolicyConfig
+
+func (r orgPolicyConfigsByOrg) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	return r[orgID], nil
+}
+
+func ctxFromIP(ip string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", ip))
+}
+
+func (m *mockAuditLogger) hasAction(action string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.events {
+		if e.action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// addTrustedMember makes userID a member of orgID with a trusted password-login device, so Login needs no MFA.
+func addTrustedMember(t *testing.T, svc *AuthService, userID, orgID string) {
+	t.Helper()
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m-"+userID+"-"+orgID] = &membershipdomain.Membership{
+		ID: "m-" + userID + "-" + orgID, UserID: userID, OrgID: orgID, Role: membershipdomain.RoleMember, CreatedAt: time.Now(),
+	}
+	membershipRepo.mu.Unlock()
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d-"+userID+"-"+orgID] = &devicedomain.Device{
+		ID: "d-" + userID + "-" + orgID, UserID: userID, OrgID: orgID, Fingerprint: "password-login", Trusted: true, CreatedAt: time.Now(),
+	}
+	deviceRepo.mu.Unlock()
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
+	"zero-trust-control-plane/backend/internal/policy/engine"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// Flow names. Each names an ordered list of steps run by Login, Refresh, VerifyMFA, ResumeLogin,
+// PollDeviceAuthorization or CompleteMagicLink.
+const (
+	FlowLogin       = "login"
+	FlowRefresh     = "refresh"
+	FlowVerifyMFA   = "verify_mfa"
+	FlowResumeLogin = "resume_login"
+	FlowDeviceCode  = "device_code"
+	FlowMagicLink   = "magic_link"
+)
+
+// Built-in step names, usable as the before argument of WithFlowStep.
+const (
+	StepIPCheck         = "ip_check"          // login, magic_link: reject blocked client IPs
+	StepPassword        = "password"          // login: email and password
+	StepMembership      = "membership"        // login: user must belong to the org
+	StepRefreshToken    = "refresh_token"     // refresh: token, session, reuse detection, proof of possession
+	StepSessionLifetime = "session_lifetime"  // refresh: absolute refresh expiry, max_session_age and ephemeral session limits
+	StepOrgAccessPolicy = "org_access_policy" // all but verify_mfa: network_access and access_schedule
+	StepDeviceCheck     = "device_check"      // login, refresh, device_code, magic_link: find or register the device
+	StepRiskCheck       = "risk_check"        // login, refresh, resume_login, magic_link: device-trust/MFA policy
+	StepLoginHold       = "login_hold"        // login: hold a flagged sign-in for admin approval; ends the flow
+	StepMFA             = "mfa"               // login, refresh, resume_login, magic_link: select an MFA method and challenge; ends the flow
+	StepOTP             = "otp"               // verify_mfa: check the challenge and code
+	StepDeviceTrust     = "device_trust"      // verify_mfa: whether and how long to trust the device
+	StepSession         = "session"           // all but refresh: create the session and issue tokens
+	StepRotateTokens    = "rotate_tokens"     // refresh: rotate the refresh token and issue an access token
+	StepHoldRelease     = "hold_release"      // resume_login: the hold must be approved; consumes it
+	StepDeviceCodeGrant = "device_code_grant" // device_code: the device code must be approved; consumes it
+	StepMagicLinkToken  = "magic_link_token"  // magic_link: the link must be unused and unexpired; consumes it
+)
+
+// FlowState is what an authentication flow has established so far. Inputs are set before the first step; each
+// step reads what earlier steps set and fills in its part.
+type FlowState struct {
+	Flow string
+
+	// Inputs.
+	Email             string // login
+	Password          string // login
+	RefreshToken      string // refresh
+	ChallengeID       string // verify_mfa
+	OTP               string // verify_mfa
+	DeviceFingerprint string // login, refresh, magic_link; device_code: set by device_code_grant
+	HoldID            string // resume_login
+	HoldToken         string // resume_login
+	DeviceCode        string // device_code
+	MagicLinkToken    string // magic_link
+	// PublicDevice marks a public or shared device (login: ContextWithPublicDevice; refresh: an ephemeral session;
+	// verify_mfa and resume_login: a challenge or hold without a device). No device is registered or trusted.
+	PublicDevice bool
+
+	// Established by steps.
+	OrgID     string
+	User      *userdomain.User // login: after password; refresh: after risk_check
+	UserID    string
+	Role      membershipdomain.Role // sign-ins only
+	SessionID string                // refresh: the session being refreshed
+	Device    *devicedomain.Device
+	NewDevice bool
+	MFA       engine.MFAResult     // risk_check (login, refresh) or device_trust (verify_mfa)
+	Challenge *mfadomain.Challenge // verify_mfa
+	MFAMethod string               // name of the method StepMFA used, or that verified the code in verify_mfa
+	// RecoveryCodes are issued when verify_mfa enrolls the user's first factor.
+	RecoveryCodes []string
+	// HoldReasons are the risk signals that hold a login for admin approval (login_hold, see WithLoginHolds), e.g.
+	// ip_check on a blocked client IP. Steps added with WithFlowStep before StepLoginHold may append to it.
+	HoldReasons []string
+	// Session is the session being refreshed (refresh: set by refresh_token). SessionExpiresAt is the expiry
+	// rotate_tokens records for it (set by session_lifetime); zero extends it by the session TTL.
+	Session          *sessiondomain.Session
+	SessionExpiresAt time.Time
+
+	// Result ends the flow successfully when a step sets it; later steps do not run.
+	Result *LoginResult
+
+	// replayClaim is the refresh token jti claimed in the replay cache (refresh: set by refresh_token); "" for none.
+	replayClaim string
+	// lookups are the reads prefetched by the password (login) or refresh_token (refresh) step; nil before.
+	lookups *authLookups
+}
+
+// Step is one step of an authentication flow. Run returns the (possibly derived) context for later steps, or an
+// error that fails the flow. When, if set, is checked first; the step is skipped when it returns false.
+type Step struct {
+	Name string
+	When func(st *FlowState) bool
+	Run  func(ctx context.Context, st *FlowState) (context.Context, error)
+}
+
+// FlowTransition records the outcome of one step: "passed", "skipped", "failed" or "completed" (the step ended the
+// flow with a result), and how long the step ran.
+type FlowTransition struct {
+	Step       string  `json:"step"`
+	Outcome    string  `json:"outcome"`
+	DurationMS float64 `json:"duration_ms,omitempty"`
+}
+
+// flowInsert is a step added by WithFlowStep, applied after the built-in flows are assembled.
+type flowInsert struct {
+	flow, before string
+	step         Step
+}
+
+// WithFlowStep adds step to flow ahead of the built-in step named before (e.g. a geo-velocity check before
+// StepMFA). If flow has no such step, step runs just before the flow's last step. Steps added for the same
+// position run in the order given.
+func WithFlowStep(flow, before string, step Step) Option {
+	return func(s *AuthService) {
+		s.flowInserts = append(s.flowInserts, flowInsert{flow: flow, before: before, step: step})
+	}
+}
+
+// buildFlows assembles the built-in flows and applies WithFlowStep insertions.
+func (s *AuthService) buildFlows() {
+	s.flows = map[string][]Step{
+		FlowLogin:       s.loginSteps(),
+		FlowRefresh:     s.refreshSteps(),
+		FlowVerifyMFA:   s.verifyMFASteps(),
+		FlowResumeLogin: s.resumeLoginSteps(),
+		FlowDeviceCode:  s.deviceCodeSteps(),
+		FlowMagicLink:   s.magicLinkSteps(),
+	}
+	for _, ins := range s.flowInserts {
+		steps, ok := s.flows[ins.flow]
+		if !ok {
+			log.Printf("auth: WithFlowStep: unknown flow %q; step %q ignored", ins.flow, ins.step.Name)
+			continue
+		}
+		at := len(steps) - 1
+		for i, st := range steps {
+			if st.Name == ins.before {
+				at = i
+				break
+			}
+		}
+		steps = append(steps[:at], append([]Step{ins.step}, steps[at:]...)...)
+		s.flows[ins.flow] = steps
+	}
+}
+
+// runFlow runs the steps of st.Flow in order until one fails or sets st.Result, then audits the transitions as
+// auth_flow and logs their timings at debug level. A flow that runs out of steps without a result fails with ErrInvalidCredentials.
+func (s *AuthService) runFlow(ctx context.Context, st *FlowState) (*LoginResult, error) {
+	steps := s.flows[st.Flow]
+	transitions := make([]FlowTransition, 0, len(steps))
+	var err error
+	for _, step := range steps {
+		if step.When != nil && !step.When(st) {
+			transitions = append(transitions, FlowTransition{Step: step.Name, Outcome: "skipped"})
+			continue
+		}
+		start := time.Now()
+		next, stepErr := step.Run(ctx, st)
+		took := durationMS(time.Since(start))
+		if stepErr != nil {
+			transitions = append(transitions, FlowTransition{Step: step.Name, Outcome: "failed", DurationMS: took})
+			err = stepErr
+			break
+		}
+		if next != nil {
+			ctx = next
+		}
+		if st.Result != nil {
+			transitions = append(transitions, FlowTransition{Step: step.Name, Outcome: "completed", DurationMS: took})
+			break
+		}
+		transitions = append(transitions, FlowTransition{Step: step.Name, Outcome: "passed", DurationMS: took})
+	}
+	if err == nil && st.Result == nil {
+		err = ErrInvalidCredentials
+	}
+	s.logTimings(st, transitions)
+	s.logFlow(ctx, st, transitions, err)
+	if err != nil {
+		return nil, err
+	}
+	return st.Result, nil
+}
+
+// logFlow audits one run of a flow as auth_flow with its transitions and result (tokens, mfa_required,
+// phone_required, approval_required or failed).
+func (s *AuthService) logFlow(ctx context.Context, st *FlowState, transitions []FlowTransition, err error) {
+	if s.auditLogger == nil {
+		return
+	}
+	result := "failed"
+	switch {
+	case err != nil:
+	case st.Result.Tokens != nil:
+		result = "tokens"
+	case st.Result.MFARequired != nil:
+		result = "mfa_required"
+	case st.Result.PhoneRequired != nil:
+		result = "phone_required"
+	case st.Result.ApprovalRequired != nil:
+		result = "approval_required"
+	}
+	metadata, _ := json.Marshal(struct {
+		Flow      string           `json:"flow"`
+		Result    string           `json:"result"`
+		MFAMethod string           `json:"mfa_method,omitempty"`
+		Steps     []FlowTransition `json:"steps"`
+	}{st.Flow, result, st.MFAMethod, transitions})
+	s.auditLogger.LogEvent(ctx, orgOrSentinel(st.OrgID), st.UserID, "auth_flow", "authentication", string(metadata))
+}
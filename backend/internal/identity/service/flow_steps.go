@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	loginholddomain "zero-trust-control-plane/backend/internal/loginhold/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/mfa"
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	"zero-trust-control-plane/backend/internal/policy/engine"
+	"zero-trust-control-plane/backend/internal/security"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// loginSteps: password → membership → org policy → device (not on a public device) → risk → hold (with
+// WithLoginHolds), MFA or session.
+func (s *AuthService) loginSteps() []Step {
+	steps := []Step{
+		{Name: StepIPCheck, Run: s.stepIPCheck},
+		{Name: StepPassword, Run: s.stepPassword},
+		{Name: StepMembership, Run: s.stepMembership},
+		{Name: StepOrgAccessPolicy, Run: s.stepOrgAccessPolicy},
+		{Name: StepDeviceCheck, When: notPublicDevice, Run: s.stepDeviceCheck},
+		{Name: StepRiskCheck, Run: s.stepRiskCheck},
+	}
+	if s.loginHolds != nil {
+		steps = append(steps, Step{Name: StepLoginHold, When: holdRequired, Run: s.stepLoginHold})
+	}
+	return append(steps,
+		Step{Name: StepMFA, When: mfaRequired, Run: s.stepMFA},
+		Step{Name: StepSession, Run: s.stepSession},
+	)
+}
+
+// refreshSteps: refresh token → org policy → device → risk → MFA (revoking the session) or rotated tokens.
+func (s *AuthService) refreshSteps() []Step {
+	return []Step{
+		{Name: StepRefreshToken, Run: s.stepRefreshToken},
+		{Name: StepSessionLifetime, Run: s.stepSessionLifetime},
+		{Name: StepOrgAccessPolicy, Run: s.stepOrgAccessPolicy},
+		{Name: StepDeviceCheck, When: notPublicDevice, Run: s.stepDeviceCheck},
+		{Name: StepRiskCheck, Run: s.stepRiskCheck},
+		{Name: StepMFA, When: mfaRequired, Run: s.stepMFA},
+		{Name: StepRotateTokens, Run: s.stepRotateTokens},
+	}
+}
+
+// verifyMFASteps: OTP → device trust → session.
+func (s *AuthService) verifyMFASteps() []Step {
+	return []Step{
+		{Name: StepOTP, Run: s.stepOTP},
+		{Name: StepDeviceTrust, When: notPublicDevice, Run: s.stepDeviceTrust},
+		{Name: StepSession, Run: s.stepSession},
+	}
+}
+
+// resumeLoginSteps: approved hold → org policy → risk → MFA or session. Policy is evaluated again, since it may
+// have changed while the sign-in was held.
+func (s *AuthService) resumeLoginSteps() []Step {
+	return []Step{
+		{Name: StepHoldRelease, Run: s.stepHoldRelease},
+		{Name: StepOrgAccessPolicy, Run: s.stepOrgAccessPolicy},
+		{Name: StepRiskCheck, Run: s.stepRiskCheck},
+		{Name: StepMFA, When: mfaRequired, Run: s.stepMFA},
+		{Name: StepSession, Run: s.stepSession},
+	}
+}
+
+// deviceCodeSteps: approved device code → org policy → device → session. There is no risk check or MFA: approval
+// from a session on a trusted device stands in for both.
+func (s *AuthService) deviceCodeSteps() []Step {
+	return []Step{
+		{Name: StepDeviceCodeGrant, Run: s.stepDeviceCodeGrant},
+		{Name: StepOrgAccessPolicy, Run: s.stepOrgAccessPolicy},
+		{Name: StepDeviceCheck, Run: s.stepDeviceCheck},
+		{Name: StepSession, Run: s.stepSession},
+	}
+}
+
+// magicLinkSteps: IP → link → org policy → device → risk → MFA or session, as login with the link in place of the
+// password.
+func (s *AuthService) magicLinkSteps() []Step {
+	return []Step{
+		{Name: StepIPCheck, Run: s.stepIPCheck},
+		{Name: StepMagicLinkToken, Run: s.stepMagicLinkToken},
+		{Name: StepOrgAccessPolicy, Run: s.stepOrgAccessPolicy},
+		{Name: StepDeviceCheck, Run: s.stepDeviceCheck},
+		{Name: StepRiskCheck, Run: s.stepRiskCheck},
+		{Name: StepMFA, When: mfaRequired, Run: s.stepMFA},
+		{Name: StepSession, Run: s.stepSession},
+	}
+}
+
+func mfaRequired(st *FlowState) bool { return st.MFA.MFARequired }
+
+// signIn reports whether st is a sign-in (login, a held login resumed, an approved device code or a magic link)
+// rather than a refresh or VerifyMFA.
+func signIn(st *FlowState) bool {
+	return st.Flow == FlowLogin || st.Flow == FlowResumeLogin || st.Flow == FlowDeviceCode || st.Flow == FlowMagicLink
+}
+
+// stepIPCheck rejects blocked client IPs with ErrIPBlocked. With WithLoginHolds a login continues instead, flagged
+// for login_hold; other flows have no login_hold step and are rejected.
+
+func (s *AuthService) stepIPCheck(ctx context.Context, st *FlowState) (context.Context, error) {
+	if s.ipBlocked(ctx) {
+		if s.loginHolds != nil && st.Flow == FlowLogin {
+			st.HoldReasons = append(st.HoldReasons, loginholddomain.ReasonIPBlocked)
+			return ctx, nil
+		}
+		if s.auditLogger != nil {
+			s.auditLogger.LogEvent(ctx, orgOrSentinel(st.OrgID), "", "login_blocked", "authentication", "")
+		}
+		return ctx, ErrIPBlocked
+	}
+	return ctx, nil
+}
+
+// stepPassword checks email and password. It first loads the reads of the whole login concurrently (prefetchLogin).
+// Honeytoken users always fail (see WithHoneytokens).
+func (s *AuthService) stepPassword(ctx context.Context, st *FlowState) (context.Context, error) {
+	if st.Email == "" || st.Password == "" || st.OrgID == "" {
+		s.logLoginFailure(ctx, st.OrgID, "")
+		return ctx, ErrInvalidCredentials
+	}
+	st.lookups = s.prefetchLogin(ctx, st)
+	user, err := st.lookups.user.value, st.lookups.user.err
+	if err != nil {
+		s.logLoginFailure(ctx, st.OrgID, "")
+		return ctx, err
+	}
+	if user != nil {
+		if h := s.honeytokenFor(ctx, st.lookups, user.ID); h != nil {
+			valid := s.honeytokenPasswordValid(ctx, st.lookups.identity.value, user.ID, st.Password)
+			s.triggerHoneytoken(ctx, h, FlowLogin, st.OrgID, st.DeviceFingerprint, valid)
+			s.logLoginFailure(ctx, st.OrgID, user.ID)
+			return ctx, ErrInvalidCredentials
+		}
+	}
+	if user == nil || user.Status != userdomain.UserStatusActive {
+		userID := ""
+		if user != nil {
+			userID = user.ID
+		}
+		s.logLoginFailure(ctx, st.OrgID, userID)
+		return ctx, ErrInvalidCredentials
+	}
+	ident, err := st.lookups.identity.value, st.lookups.identity.err
+	if err != nil {
+		s.logLoginFailure(ctx, st.OrgID, user.ID)
+		return ctx, err
+	}
+	if ident == nil || ident.PasswordHash == "" {
+		s.logLoginFailure(ctx, st.OrgID, user.ID)
+		return ctx, ErrInvalidCredentials
+	}
+	if err := s.hasher.Compare(ident.PasswordHash, []byte(st.Password)); err != nil {
+		s.logLoginFailure(ctx, st.OrgID, user.ID)
+		s.recordSecurityEvent(ctx, st.OrgID, user.ID, securityeventdomain.EventLoginFailure, `{"reason":"invalid_password"}`)
+		return ctx, ErrInvalidCredentials
+	}
+	st.User, st.UserID = user, user.ID
+	return ctx, nil
+}
+
+func (s *AuthService) stepMembership(ctx context.Context, st *FlowState) (context.Context, error) {
+	var membership *membershipdomain.Membership
+	var err error
+	if l := st.lookups; l != nil && l.membership.loaded {
+		membership, err = l.membership.value, l.membership.err
+	} else {
+		membership, err = s.membershipRepo.GetMembershipByUserAndOrg(ctx, st.UserID, st.OrgID)
+	}
+	if err != nil {
+		s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		return ctx, err
+	}
+	if membership == nil {
+		s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		return ctx, ErrNotOrgMember
+	}
+	st.Role = membership.Role
+	return ctx, nil
+}
+
+func (s *AuthService) stepOrgAccessPolicy(ctx context.Context, st *FlowState) (context.Context, error) {
+	if l := st.lookups; l != nil && l.orgPolicy.loaded {
+		if l.orgPolicy.err != nil {
+			return ctx, l.orgPolicy.err
+		}
+		return s.applyOrgAccessPolicy(ctx, l.orgPolicy.value, st.OrgID, st.UserID, st.Role, st.Flow)
+	}
+	return s.enforceOrgAccessPolicy(ctx, st.OrgID, st.UserID, st.Role, st.Flow)
+}
+
+// stepDeviceCheck finds the device by fingerprint, registering it as untrusted if it is new. A new device needs room
+// in the org's device quota (max_devices); the device repository checks it when inserting, and a full org fails the
+// flow with ErrDeviceQuotaExceeded. Known devices and public device sign-ins, which register none, are not affected.
+func (s *AuthService) stepDeviceCheck(ctx context.Context, st *FlowState) (context.Context, error) {
+	fp := loginFingerprint(st)
+	var dev *devicedomain.Device
+	var err error
+	if l := st.lookups; l != nil && l.device.loaded {
+		dev, err = l.device.value, l.device.err
+	} else {
+		dev, err = s.deviceRepo.GetByUserOrgAndFingerprint(ctx, st.UserID, st.OrgID, fp)
+	}
+	if err != nil {
+		return ctx, err
+	}
+	st.NewDevice = dev == nil
+	if dev == nil {
+		dev = &devicedomain.Device{
+			ID:          uuid.New().String(),
+			UserID:      st.UserID,
+			OrgID:       st.OrgID,
+			Fingerprint: fp,
+			Trusted:     false,
+			CreatedAt:   time.Now().UTC(),
+		}
+		if err := s.deviceRepo.Create(ctx, dev); errors.Is(err, orgquota.ErrDeviceQuotaExceeded) {
+			return ctx, ErrDeviceQuotaExceeded
+		} else if err != nil {
+			return ctx, err
+		}
+	}
+	st.Device = dev
+	return ctx, nil
+}
+
+// stepRiskCheck evaluates device-trust/MFA policy. Refresh loads the user here; a missing user invalidates the
+// refresh token. A public device is never trusted, so signing in on one always requires MFA; refreshing its
+// ephemeral session does not, as session_lifetime bounds it instead. Signing in or refreshing from a quarantined
+// device always requires MFA and records a quarantined_device_login security event.
+func (s *AuthService) stepRiskCheck(ctx context.Context, st *FlowState) (context.Context, error) {
+	l := st.lookups
+	if st.User == nil {
+		var user *userdomain.User
+		var err error
+		if l != nil && l.user.loaded {
+			user, err = l.user.value, l.user.err
+		} else {
+			user, err = s.userRepo.GetByID(ctx, st.UserID)
+		}
+		if err != nil || user == nil {
+			return ctx, ErrInvalidRefreshToken
+		}
+		st.User = user
+	}
+	quarantined := st.Device != nil && st.Device.IsQuarantined()
+	if quarantined {
+		s.recordQuarantinedLogin(ctx, st)
+	}
+	if st.PublicDevice {
+		st.MFA = engine.MFAResult{MFARequired: signIn(st) || st.User.MFAResetRequired || quarantined}
+		return ctx, nil
+	}
+	if l != nil && l.settings.loaded {
+		st.MFA = s.evaluateMFAWith(ctx, l.settings.value, st.Device, st.User, st.NewDevice)
+		return ctx, nil
+	}
+	st.MFA = s.evaluateMFA(ctx, st.OrgID, st.Device, st.User, st.NewDevice)
+	return ctx, nil
+}
+
+// stepMFA challenges the user with the MFA method they asked for (ContextWithMFAMethod) or, failing that, the
+// org's preferred available method (see mfaCandidates). On refresh the current session is revoked first, so its
+// refresh token cannot be reused until VerifyMFA completes. On login the outcome is audited as login_success
+// (credentials were valid) or login_failure.
+func (s *AuthService) stepMFA(ctx context.Context, st *FlowState) (context.Context, error) {
+	if st.Flow == FlowRefresh {
+		_ = s.sessionRepo.Revoke(ctx, st.SessionID, sessiondomain.Revocation{Reason: sessiondomain.RevocationPolicyChange})
+	}
+	result, err := s.startMFA(ctx, st)
+	if err != nil {
+		if signIn(st) {
+			s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		}
+		return ctx, err
+	}
+	if signIn(st) {
+		s.logLoginSuccess(ctx, st.OrgID, st.UserID, st.Role)
+		if result.MFARequired != nil {
+			s.logMFAChallengeIssued(ctx, st.OrgID, st.UserID)
+		}
+	}
+	st.Result = result
+	return ctx, nil
+}
+
+// stepSession creates the session. After login (or a resumed login) it only renews the trust of an already trusted device (sliding
+// trust_renewal, see renewDeviceTrust); after VerifyMFA it trusts the device as device_trust decided, for the
+// remember-device duration the user chose at Login or VerifyMFA when it is shorter than the policy's trust TTL. A
+// public device flow creates an ephemeral session and trusts nothing.
+func (s *AuthService) stepSession(ctx context.Context, st *FlowState) (context.Context, error) {
+	if signIn(st) {
+		s.logLoginSuccess(ctx, st.OrgID, st.UserID, st.Role)
+		authMethod := sessiondomain.AuthMethodPassword
+		switch st.Flow {
+		case FlowDeviceCode:
+			authMethod = sessiondomain.AuthMethodDeviceCode
+		case FlowMagicLink:
+			authMethod = sessiondomain.AuthMethodMagicLink
+		}
+		result, err := s.createSessionAndResult(ctx, st.UserID, st.OrgID, flowDeviceID(st), authMethod, "", false, 0, 0)
+		if err != nil {
+			return ctx, err
+		}
+		s.renewDeviceTrust(ctx, st)
+		st.Result = result
+		return ctx, nil
+	}
+	chosen := trustDaysFromContext(ctx)
+	if chosen == 0 {
+		chosen = st.Challenge.TrustDays
+	}
+	result, err := s.createSessionAndResult(ctx, st.UserID, st.OrgID, st.Challenge.DeviceID, sessiondomain.AuthMethodPassword, st.MFAMethod, st.MFA.RegisterTrustAfterMFA, st.MFA.TrustTTLDays, boundTrustDays(chosen, st.MFA.TrustTTLDays))
+	if err != nil {
+		return ctx, err
+	}
+	_ = s.mfaChallengeRepo.Delete(ctx, st.Challenge.ID)
+	if result.Tokens == nil {
+		return ctx, ErrInvalidMFAChallenge
+	}
+	result.Tokens.RecoveryCodes = st.RecoveryCodes
+	st.Result = result
+	return ctx, nil
+}
+
+// stepRefreshToken validates the refresh token against its session. A token whose jti is not the session's current
+// one has been reused: all of the user's sessions are revoked (ErrRefreshTokenReuse). With a replay cache
+// (WithRefreshReplayCache), the jti is claimed first, so a token presented twice within the cache window is caught
+// the same way before the session is read. Once the token is parsed, the reads of the whole refresh are loaded
+// concurrently (prefetchRefresh).
+func (s *AuthService) stepRefreshToken(ctx context.Context, st *FlowState) (context.Context, error) {
+	if st.RefreshToken == "" {
+		return ctx, ErrInvalidRefreshToken
+	}
+	sessionID, jti, userID, orgID, err := s.tokens.ValidateRefresh(st.RefreshToken)
+	if err != nil {
+		return ctx, ErrInvalidRefreshToken
+	}
+	st.SessionID, st.UserID, st.OrgID = sessionID, userID, orgID
+	if s.refreshReplays != nil {
+		claimed, err := s.refreshReplays.Claim(ctx, jti)
+		switch {
+		case err != nil:
+			log.Printf("auth: refresh replay cache claim failed: %v", err)
+		case !claimed:
+			return ctx, s.refreshTokenReused(ctx, st, "replay_cache")
+		default:
+			st.replayClaim = jti
+		}
+	}
+	st.lookups = s.prefetchRefresh(ctx, st)
+	sess, err := st.lookups.session.value, st.lookups.session.err
+	if err != nil {
+		return ctx, err
+	}
+	if sess == nil || sess.RevokedAt != nil {
+		return ctx, ErrInvalidRefreshToken
+	}
+	st.Session, st.PublicDevice = sess, sess.Ephemeral
+	if sess.RefreshJti != jti {
+		return ctx, s.refreshTokenReused(ctx, st, "session")
+	}
+	if sess.RefreshTokenHash != "" && !security.RefreshTokenHashEqual(st.RefreshToken, sess.RefreshTokenHash) {
+		return ctx, ErrInvalidRefreshToken
+	}
+	if sess.PoPKeyThumbprint != "" {
+		if err := s.verifyRefreshProof(ctx, st.RefreshToken, sessionID, sess.PoPKeyThumbprint, orgID, userID); err != nil {
+			return ctx, err
+		}
+	}
+	return ctx, nil
+}
+
+// refreshTokenReused revokes all of the user's sessions after a reused refresh token, audits refresh_token_reuse
+// with what caught it (detectedBy: session or replay_cache) and returns ErrRefreshTokenReuse.
+func (s *AuthService) refreshTokenReused(ctx context.Context, st *FlowState, detectedBy string) error {
+	_ = s.sessionRepo.RevokeAllSessionsByUser(ctx, st.UserID, sessiondomain.Revocation{Reason: sessiondomain.RevocationReuseDetected})
+	if s.auditLogger != nil {
+		metadata := `{"session_id":"` + st.SessionID + `","reason":"` + string(sessiondomain.RevocationReuseDetected) + `","detected_by":"` + detectedBy + `"}`
+		s.auditLogger.LogEvent(ctx, st.OrgID, st.UserID, "refresh_token_reuse", "authentication", metadata)
+	}
+	s.recordSecurityEvent(ctx, st.OrgID, st.UserID, securityeventdomain.EventRefreshTokenReuse, `{"session_id":"`+st.SessionID+`"}`)
+	return ErrRefreshTokenReuse
+}
+
+// stepRotateTokens issues the refreshed tokens, records the session's new expiry (see stepSessionLifetime) and
+// renews the trust of a trusted device (sliding trust_renewal).
+func (s *AuthService) stepRotateTokens(ctx context.Context, st *FlowState) (context.Context, error) {
+	now := time.Now().UTC()
+	_ = s.sessionRepo.UpdateLastSeen(ctx, st.SessionID, now)
+	newRefresh, newJti, _, err := s.tokens.IssueRefresh(st.SessionID, st.UserID, st.OrgID)
+	if err != nil {
+		return ctx, err
+	}
+	expiresAt := st.SessionExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(s.sessionTTL())
+	}
+	if err := s.sessionRepo.UpdateRefreshToken(ctx, st.SessionID, newJti, security.HashRefreshToken(newRefresh), expiresAt); err != nil {
+		return ctx, err
+	}
+	accessToken, _, accessExp, err := s.tokens.IssueAccessContext(ctx, st.SessionID, st.UserID, st.OrgID)
+	if err != nil {
+		return ctx, err
+	}
+	st.Result = &RefreshResult{
+		Tokens: &AuthResult{
+			AccessToken:  accessToken,
+			RefreshToken: newRefresh,
+			ExpiresAt:    accessExp,
+			UserID:       st.UserID,
+			OrgID:        st.OrgID,
+		},
+	}
+	s.renewDeviceTrust(ctx, st)
+	return ctx, nil
+}
+
+// stepOTP checks the challenge and has the method that issued it verify the code. A recovery code is accepted in
+// place of the code when recovery codes are enabled. Wrong codes count towards the challenge's attempt limit.
+func (s *AuthService) stepOTP(ctx context.Context, st *FlowState) (context.Context, error) {
+	if st.ChallengeID == "" || st.OTP == "" {
+		return ctx, ErrInvalidMFAChallenge
+	}
+	challenge, err := s.mfaChallengeRepo.GetByID(ctx, st.ChallengeID)
+	if err != nil {
+		return ctx, err
+	}
+	if challenge == nil {
+		return ctx, ErrInvalidMFAChallenge
+	}
+	st.OrgID, st.UserID = challenge.OrgID, challenge.UserID
+	if !challenge.ExpiresAt.After(time.Now().UTC()) {
+		return ctx, ErrChallengeExpired
+	}
+	if s.mfaMaxAttempts > 0 && challenge.Attempts >= s.mfaMaxAttempts {
+		return ctx, ErrMFAAttemptsExceeded
+	}
+	st.Challenge, st.PublicDevice = challenge, challenge.DeviceID == ""
+	st.User, _ = s.userRepo.GetByID(ctx, challenge.UserID)
+	if code := mfa.NormalizeRecoveryCode(st.OTP); code != "" && s.recoveryCodes != nil {
+		err = s.useRecoveryCode(ctx, st, code)
+	} else {
+		verifier := s.mfaVerifier(challenge.Method)
+		if verifier == nil {
+			return ctx, ErrInvalidMFAChallenge
+		}
+		st.MFAMethod = verifier.Name()
+		err = verifier.Verify(ctx, st, st.OTP)
+	}
+	if errors.Is(err, ErrInvalidOTP) {
+		return ctx, s.wrongMFACode(ctx, challenge)
+	}
+	return ctx, err
+}
+
+// stepDeviceTrust decides whether to trust the device after MFA and for how long, from the policy evaluator or,
+// without one, the org and platform settings. A quarantined device is never trusted.
+func (s *AuthService) stepDeviceTrust(ctx context.Context, st *FlowState) (context.Context, error) {
+	challenge := st.Challenge
+	dev, _ := s.deviceRepo.GetByID(ctx, challenge.DeviceID)
+	if dev != nil && dev.IsQuarantined() {
+		st.MFA = engine.MFAResult{}
+		return ctx, nil
+	}
+	if s.policyEvaluator != nil {
+		var platformSettings *platformsettingsdomain.PlatformDeviceTrustSettings
+		if s.platformSettingsRepo != nil {
+			platformSettings, _ = s.platformSettingsRepo.GetDeviceTrustSettings(ctx, s.trustTTLDays())
+		}
+		var orgSettings *orgmfasettingsdomain.OrgMFASettings
+		if s.orgMFASettingsRepo != nil {
+			orgSettings, _ = s.orgMFASettingsRepo.GetByOrgID(ctx, challenge.OrgID)
+		}
+		st.MFA, _ = s.policyEvaluator.EvaluateMFA(s.withDeviceSharing(ctx, dev), platformSettings, orgSettings, dev, st.User, false)
+		return ctx, nil
+	}
+	result := engine.MFAResult{RegisterTrustAfterMFA: true, TrustTTLDays: s.trustTTLDays()}
+	if s.platformSettingsRepo != nil {
+		platformSettings, _ := s.platformSettingsRepo.GetDeviceTrustSettings(ctx, s.trustTTLDays())
+		if platformSettings != nil {
+			result.TrustTTLDays = platformSettings.DefaultTrustTTLDays
+		}
+	}
+	if s.orgMFASettingsRepo != nil {
+		orgSettings, _ := s.orgMFASettingsRepo.GetByOrgID(ctx, challenge.OrgID)
+		if orgSettings != nil {
+			result.RegisterTrustAfterMFA = orgSettings.RegisterTrustAfterMFA
+			result.TrustTTLDays = orgSettings.TrustTTLDays
+			if result.TrustTTLDays <= 0 {
+				result.TrustTTLDays = s.trustTTLDays()
+			}
+		}
+	}
+	st.MFA = result
+	return ctx, nil
+}
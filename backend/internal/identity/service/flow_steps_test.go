@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	"zero-trust-control-plane/backend/internal/devicesharing"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
+	policyengine "zero-trust-control-plane/backend/internal/policy/engine"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+func TestAuthService_Login_NetworkAccess(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withNetworkAccess(true), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc, auditLogger := f.svc, f.audit
+	if _, err := svc.Login(ctxFromIP("198.51.100.9"), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != ErrNetworkNotAllowed {
+		t.Fatalf("Login from outside allowed CIDRs: want ErrNetworkNotAllowed, got %v", err)
+	}
+	if !auditLogger.hasAction("login_network_denied") {
+		t.Error("denied login should be audited as login_network_denied")
+	}
+	res, err := svc.Login(ctxFromIP("10.2.3.4"), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login from allowed CIDR: res=%+v err=%v", res, err)
+	}
+}
+
+func TestAuthService_Login_NetworkAccessOwnerBypass(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withNetworkAccess(true), withMember("user@example.com", membershipdomain.RoleOwner, "fp-1"))
+	svc, auditLogger := f.svc, f.audit
+	res, err := svc.Login(ctxFromIP("198.51.100.9"), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("owner break-glass login: res=%+v err=%v", res, err)
+	}
+	if !auditLogger.hasAction("network_policy_override") {
+		t.Error("owner bypass should be audited as network_policy_override")
+	}
+
+	svc = newTestFixture(t, withNetworkAccess(false), withMember("user@example.com", membershipdomain.RoleOwner, "fp-1")).svc
+	if _, err := svc.Login(ctxFromIP("198.51.100.9"), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != ErrNetworkNotAllowed {
+		t.Errorf("owner without bypass: want ErrNetworkNotAllowed, got %v", err)
+	}
+}
+
+func TestAuthService_Refresh_NetworkAccess(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withNetworkAccess(true), withMember("user@example.com", membershipdomain.RoleAdmin, "fp-1"))
+	svc, auditLogger := f.svc, f.audit
+	res, err := svc.Login(ctxFromIP("10.2.3.4"), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login: res=%+v err=%v", res, err)
+	}
+	if _, err := svc.Refresh(ctxFromIP("198.51.100.9"), res.Tokens.RefreshToken, "fp-1"); err != ErrNetworkNotAllowed {
+		t.Fatalf("Refresh from outside allowed CIDRs: want ErrNetworkNotAllowed, got %v", err)
+	}
+	if !auditLogger.hasAction("login_network_denied") {
+		t.Error("denied refresh should be audited as login_network_denied")
+	}
+	if _, err := svc.Refresh(ctxFromIP("10.9.9.9"), res.Tokens.RefreshToken, "fp-1"); err != nil {
+		t.Errorf("Refresh from allowed CIDR after a denied attempt: %v", err)
+	}
+}
+
+// withAccessSchedule only lets members sign in on the given days.
+func withAccessSchedule(days []string) fixtureOption {
+	return withOrgPolicy(&orgpolicyconfigdomain.OrgPolicyConfig{
+		AccessSchedule: &orgpolicyconfigdomain.AccessSchedule{
+			Enabled: true,
+			Windows: []orgpolicyconfigdomain.AccessWindow{{Roles: []string{"member"}, Days: days, StartTime: "00:00", EndTime: "24:00"}},
+		},
+	})
+}
+
+func TestAuthService_Login_AccessSchedule(t *testing.T) {
+	yesterday := orgpolicyconfigdomain.WeekdayName(time.Now().UTC().AddDate(0, 0, -1).Weekday())
+	f := newTestFixture(t, withAuditLogger(), withAccessSchedule([]string{yesterday}), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc, auditLogger := f.svc, f.audit
+	if _, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != ErrOutsideAccessWindow {
+		t.Fatalf("Login outside window: want ErrOutsideAccessWindow, got %v", err)
+	}
+	if !auditLogger.hasAction("login_outside_access_window") {
+		t.Error("login outside window should be audited as login_outside_access_window")
+	}
+
+	svc = newTestFixture(t, withAccessSchedule([]string{yesterday}), withMember("user@example.com", membershipdomain.RoleAdmin, "fp-1")).svc
+	if res, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != nil || res.Tokens == nil {
+		t.Errorf("role without windows should be unrestricted: res=%+v err=%v", res, err)
+	}
+}
+
+func TestAuthService_Refresh_AccessSchedule(t *testing.T) {
+	today := orgpolicyconfigdomain.WeekdayName(time.Now().UTC().Weekday())
+	svc := newTestFixture(t, withAccessSchedule([]string{today}), withMember("user@example.com", membershipdomain.RoleMember, "fp-1")).svc
+	res, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login inside window: res=%+v err=%v", res, err)
+	}
+	yesterday := orgpolicyconfigdomain.WeekdayName(time.Now().UTC().AddDate(0, 0, -1).Weekday())
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		AccessSchedule: &orgpolicyconfigdomain.AccessSchedule{
+			Enabled: true,
+			Windows: []orgpolicyconfigdomain.AccessWindow{{Roles: []string{"member"}, Days: []string{yesterday}, StartTime: "00:00", EndTime: "24:00"}},
+		},
+	}})(svc)
+	if _, err := svc.Refresh(context.Background(), res.Tokens.RefreshToken, "fp-1"); err != ErrOutsideAccessWindow {
+		t.Errorf("Refresh outside window: want ErrOutsideAccessWindow, got %v", err)
+	}
+}
+
+func TestAuthService_Login_DeviceQuota(t *testing.T) {
+	svc := newTestFixture(t, withPublicDeviceUser()).svc
+	// The device repository checks max_devices in the insert transaction.
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.createErr = orgquota.ErrDeviceQuotaExceeded
+	ctx := context.Background()
+	if _, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-new"); !errors.Is(err, ErrDeviceQuotaExceeded) {
+		t.Fatalf("Login from a new device over quota: err = %v, want ErrDeviceQuotaExceeded", err)
+	}
+	deviceRepo.mu.Lock()
+	n := len(deviceRepo.m)
+	deviceRepo.mu.Unlock()
+	if n != 1 {
+		t.Errorf("devices = %d, want only the existing one", n)
+	}
+	if _, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1"); err != nil {
+		t.Errorf("Login from a known device over quota: %v", err)
+	}
+	if res, err := svc.Login(ContextWithPublicDevice(ctx, true), "user@example.com", "Password123!abc", "org-1", ""); err != nil || res.MFARequired == nil {
+		t.Errorf("public device Login over quota = %+v, %v; want MFA required", res, err)
+	}
+}
+
+// memDeviceSharing reports the fingerprints in shared as used by 3 users in 2 orgs.
+type memDeviceSharing struct {
+	shared map[string]bool
+	err    error
+	calls  []string // fingerprint/user/org of each lookup
+}
+
+func (m *memDeviceSharing) Usage(ctx context.Context, fingerprint, userID, orgID string) (devicesharing.Usage, error) {
+	m.calls = append(m.calls, fingerprint+"/"+userID+"/"+orgID)
+	if m.err != nil {
+		return devicesharing.Usage{}, m.err
+	}
+	if m.shared[fingerprint] {
+		return devicesharing.Usage{Users: 3, Orgs: 2, Shared: true}, nil
+	}
+	return devicesharing.Usage{Users: 1, Orgs: 1}, nil
+}
+
+// memPolicyRepo serves the same enabled policies for every org.
+type memPolicyRepo struct {
+	rules string
+}
+
+func (m *memPolicyRepo) GetByID(ctx context.Context, id string) (*policydomain.Policy, error) {
+	return nil, nil
+}
+func (m *memPolicyRepo) ListByOrg(ctx context.Context, orgID string) ([]*policydomain.Policy, error) {
+	return m.GetEnabledPoliciesByOrg(ctx, orgID)
+}
+func (m *memPolicyRepo) GetEnabledPoliciesByOrg(ctx context.Context, orgID string) ([]*policydomain.Policy, error) {
+	return []*policydomain.Policy{{ID: "p1", OrgID: orgID, Enabled: true, Rules: m.rules}}, nil
+}
+func (m *memPolicyRepo) Create(ctx context.Context, p *policydomain.Policy) error { return nil }
+func (m *memPolicyRepo) Update(ctx context.Context, p *policydomain.Policy) error { return nil }
+func (m *memPolicyRepo) Delete(ctx context.Context, id string) error              { return nil }
+
+// orgMFASettingsEverywhere returns settings for every org, so its policies are evaluated.
+type orgMFASettingsEverywhere struct{}
+
+func (orgMFASettingsEverywhere) GetByOrgID(ctx context.Context, orgID string) (*orgmfasettingsdomain.OrgMFASettings, error) {
+	return &orgmfasettingsdomain.OrgMFASettings{OrgID: orgID, RegisterTrustAfterMFA: true}, nil
+}
+
+func TestAuthService_DeviceSharing_PolicyInput(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	svc.orgMFASettingsRepo = orgMFASettingsEverywhere{}
+	svc.policyEvaluator = policyengine.NewOPAEvaluator(&memPolicyRepo{rules: `package ztcp.device_trust
+
+default mfa_required = false
+
+mfa_required if {
+	input.device.shared_device
+	input.device.fingerprint_user_count >= 3
+}
+`})
+	ctx := context.Background()
+	reg, err := svc.Register(ctx, "ada@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	addTrustedMember(t, svc, reg.UserID, "org-1")
+
+	// Without WithDeviceSharing policies see no device as shared.
+	res, err := svc.VerifyCredentials(ctx, "ada@example.com", "Password123!abc", "org-1", "kiosk")
+	if err != nil || res.MFAWouldBeRequired {
+		t.Fatalf("without detection: MFAWouldBeRequired = %v, %v; want false", res != nil && res.MFAWouldBeRequired, err)
+	}
+
+	sharing := &memDeviceSharing{shared: map[string]bool{"kiosk": true}}
+	WithDeviceSharing(sharing)(svc)
+	res, err = svc.VerifyCredentials(ctx, "ada@example.com", "Password123!abc", "org-1", "kiosk")
+	if err != nil || !res.MFAWouldBeRequired {
+		t.Errorf("shared fingerprint: MFAWouldBeRequired = %v, %v; want true", res != nil && res.MFAWouldBeRequired, err)
+	}
+	if len(sharing.calls) != 1 || sharing.calls[0] != "kiosk/"+reg.UserID+"/org-1" {
+		t.Errorf("lookups = %v, want kiosk for the user in org-1", sharing.calls)
+	}
+	res, err = svc.VerifyCredentials(ctx, "ada@example.com", "Password123!abc", "org-1", "laptop")
+	if err != nil || res.MFAWouldBeRequired {
+		t.Errorf("own fingerprint: MFAWouldBeRequired = %v, %v; want false", res != nil && res.MFAWouldBeRequired, err)
+	}
+
+	sharing.err = errors.New("db down")
+	res, err = svc.VerifyCredentials(ctx, "ada@example.com", "Password123!abc", "org-1", "kiosk")
+	if err != nil || res.MFAWouldBeRequired {
+		t.Errorf("failed lookup: MFAWouldBeRequired = %v, %v; want false (not shared)", res != nil && res.MFAWouldBeRequired, err)
+	}
+}
+
+func TestAuthService_QuarantinedDevice(t *testing.T) {
+	svc, _, devStore := newTestAuthServiceOpt(t, true)
+	recorder := &memSecurityEventRecorder{}
+	WithSecurityEventRecorder(recorder)(svc)
+	ctx := context.Background()
+	reg, _ := svc.Register(ctx, "user@example.com", "Password123!abc", "")
+	userRepo := svc.userRepo.(*memUserRepo)
+	userRepo.mu.Lock()
+	u := *userRepo.byID[reg.UserID]
+	u.Phone = "15551234567"
+	userRepo.byID[reg.UserID], userRepo.byEmail[u.Email] = &u, &u
+	userRepo.mu.Unlock()
+	addTrustedMember(t, svc, reg.UserID, "org-1")
+	trustedUntil := time.Now().Add(7 * 24 * time.Hour).UTC()
+	quarantinedAt := time.Now().UTC()
+	dev := &devicedomain.Device{ID: "d1", UserID: reg.UserID, OrgID: "org-1", Fingerprint: "fp-1", Trusted: true, TrustedUntil: &trustedUntil, CreatedAt: time.Now(), QuarantinedAt: &quarantinedAt}
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d1"] = dev
+	deviceRepo.mu.Unlock()
+
+	loginRes, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || loginRes.MFARequired == nil {
+		t.Fatalf("Login from a quarantined trusted device = %+v, %v; want MFA required", loginRes, err)
+	}
+	if len(recorder.types) != 1 || recorder.types[0] != securityeventdomain.EventQuarantinedLogin {
+		t.Errorf("recorded = %v, want quarantined_device_login", recorder.types)
+	}
+	otp, _ := devStore.Get(ctx, loginRes.MFARequired.ChallengeID)
+	if _, err := svc.VerifyMFA(ctx, loginRes.MFARequired.ChallengeID, otp); err != nil {
+		t.Fatalf("VerifyMFA: %v", err)
+	}
+	if !dev.IsQuarantined() || !dev.TrustedUntil.Equal(trustedUntil) {
+		t.Error("VerifyMFA must neither release nor re-trust a quarantined device")
+	}
+
+	dev.QuarantinedAt = nil
+	loginRes, err = svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || loginRes.MFARequired != nil {
+		t.Fatalf("Login after release = %+v, %v; want the kept trust to skip MFA", loginRes, err)
+	}
+}
+
+func TestAuthService_TrustedNetwork_PolicyInput(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	svc.orgMFASettingsRepo = orgMFASettingsEverywhere{}
+	svc.policyEvaluator = policyengine.NewOPAEvaluator(&memPolicyRepo{rules: `package ztcp.device_trust
+
+default mfa_required = true
+
+mfa_required = false if {
+	input.network.on_trusted_network
+}
+`})
+	na := &orgpolicyconfigdomain.NetworkAccess{TrustedCidrs: []string{"10.0.0.0/8"}}
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{NetworkAccess: na}})(svc)
+	reg, err := svc.Register(context.Background(), "ada@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	addTrustedMember(t, svc, reg.UserID, "org-1")
+
+	res, err := svc.Login(ctxFromIP("10.2.3.4"), "ada@example.com", "Password123!abc", "org-1", "")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login on the trusted network = %+v, %v; want tokens without MFA", res, err)
+	}
+	res, err = svc.Login(ctxFromIP("198.51.100.9"), "ada@example.com", "Password123!abc", "org-1", "")
+	if err != nil || res.Tokens != nil {
+		t.Fatalf("Login off the trusted network = %+v, %v; want MFA", res, err)
+	}
+	na.ForbidMfaRelaxation = true
+	res, err = svc.Login(ctxFromIP("10.2.3.4"), "ada@example.com", "Password123!abc", "org-1", "")
+	if err != nil || res.Tokens != nil {
+		t.Fatalf("Login on the trusted network with relaxation forbidden = %+v, %v; want MFA", res, err)
+	}
+}
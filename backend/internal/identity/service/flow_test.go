@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+)
+
+// loginFlowFixture registers user@example.com as a member of org-1 and returns its user ID; trustedFP, if set,
+// is pre-registered as a trusted device so Login does not require MFA.
+func loginFlowFixture(t *testing.T, svc *AuthService, trustedFP string) string {
+	t.Helper()
+	reg, err := svc.Register(context.Background(), "user@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: reg.UserID, OrgID: "org-1", Role: membershipdomain.RoleMember, CreatedAt: time.Now()}
+	membershipRepo.mu.Unlock()
+	if trustedFP != "" {
+		deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+		deviceRepo.mu.Lock()
+		deviceRepo.m["d1"] = &devicedomain.Device{ID: "d1", UserID: reg.UserID, OrgID: "org-1", Fingerprint: trustedFP, Trusted: true, CreatedAt: time.Now()}
+		deviceRepo.mu.Unlock()
+	}
+	return reg.UserID
+}
+
+type flowAudit struct {
+	Flow      string           `json:"flow"`
+	Result    string           `json:"result"`
+	MFAMethod string           `json:"mfa_method"`
+	Steps     []FlowTransition `json:"steps"`
+}
+
+// lastFlowAudit returns the metadata of the last auth_flow event.
+func lastFlowAudit(t *testing.T, l *mockAuditLogger) flowAudit {
+	t.Helper()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := len(l.events) - 1; i >= 0; i-- {
+		if l.events[i].action == "auth_flow" {
+			var fa flowAudit
+			if err := json.Unmarshal([]byte(l.events[i].metadata), &fa); err != nil {
+				t.Fatalf("auth_flow metadata %q: %v", l.events[i].metadata, err)
+			}
+			return fa
+		}
+	}
+	t.Fatalf("no auth_flow event in %+v", l.events)
+	return flowAudit{}
+}
+
+func flowOutcomes(fa flowAudit) string {
+	parts := make([]string, len(fa.Steps))
+	for i, tr := range fa.Steps {
+		parts[i] = tr.Step + ":" + tr.Outcome
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestAuthService_Login_AuditsFlowTransitions(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	userID := loginFlowFixture(t, svc, "fp-1")
+	ctx := context.Background()
+
+	if _, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1"); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	auditLogger.mu.Lock()
+	last := auditLogger.events[len(auditLogger.events)-1]
+	auditLogger.mu.Unlock()
+	if last.action != "auth_flow" || last.orgID != "org-1" || last.userID != userID {
+		t.Errorf("last audit event = %+v, want auth_flow for org-1 and the user", last)
+	}
+	fa := lastFlowAudit(t, auditLogger)
+	want := "ip_check:passed,password:passed,membership:passed,org_access_policy:passed,device_check:passed,risk_check:passed,mfa:skipped,session:completed"
+	if fa.Flow != FlowLogin || fa.Result != "tokens" || flowOutcomes(fa) != want {
+		t.Errorf("auth_flow = %+v (%s), want login/tokens (%s)", fa, flowOutcomes(fa), want)
+	}
+
+	// New device, no phone: MFA falls through to phone enrollment.
+	res, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-new")
+	if err != nil || res.PhoneRequired == nil {
+		t.Fatalf("Login on new device = %+v, %v; want PhoneRequired", res, err)
+	}
+	fa = lastFlowAudit(t, auditLogger)
+	if fa.Result != "phone_required" || fa.MFAMethod != "phone_enrollment" || !strings.HasSuffix(flowOutcomes(fa), "mfa:completed") {
+		t.Errorf("auth_flow = %+v, want phone_required via phone_enrollment ending at mfa", fa)
+	}
+
+	if _, err := svc.Login(ctx, "user@example.com", "wrong-password", "org-1", "fp-1"); err != ErrInvalidCredentials {
+		t.Fatalf("Login wrong password: want ErrInvalidCredentials, got %v", err)
+	}
+	fa = lastFlowAudit(t, auditLogger)
+	if fa.Result != "failed" || flowOutcomes(fa) != "ip_check:passed,password:failed" {
+		t.Errorf("auth_flow = %+v, want failed at password", fa)
+	}
+}
+
+func TestAuthService_WithFlowStep(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	errGeoVelocity := errors.New("impossible travel")
+	var sawDevice bool
+	WithFlowStep(FlowLogin, StepMFA, Step{
+		Name: "geo_velocity",
+		Run: func(ctx context.Context, st *FlowState) (context.Context, error) {
+			sawDevice = st.Device != nil && st.UserID != ""
+			return ctx, errGeoVelocity
+		},
+	})(svc)
+	svc.buildFlows()
+	loginFlowFixture(t, svc, "fp-1")
+
+	if _, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != errGeoVelocity {
+		t.Fatalf("Login: want step error, got %v", err)
+	}
+	if !sawDevice {
+		t.Error("inserted step should run after device_check")
+	}
+	fa := lastFlowAudit(t, auditLogger)
+	if !strings.HasSuffix(flowOutcomes(fa), "risk_check:passed,geo_velocity:failed") {
+		t.Errorf("transitions = %s, want geo_velocity failing after risk_check", flowOutcomes(fa))
+	}
+}
+
+func TestAuthService_WithFlowStep_UnknownBeforeRunsBeforeLastStep(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithFlowStep(FlowRefresh, "no_such_step", Step{Name: "extra", Run: func(ctx context.Context, _ *FlowState) (context.Context, error) { return ctx, nil }})(svc)
+	WithFlowStep("no_such_flow", StepMFA, Step{Name: "ignored"})(svc)
+	svc.buildFlows()
+
+	steps := svc.flows[FlowRefresh]
+	if n := len(steps); n < 2 || steps[n-2].Name != "extra" || steps[n-1].Name != StepRotateTokens {
+		t.Errorf("refresh steps end with %v, want extra then %s", steps[len(steps)-2:], StepRotateTokens)
+	}
+	if _, ok := svc.flows["no_such_flow"]; ok {
+		t.Error("unknown flow should not be created")
+	}
+}
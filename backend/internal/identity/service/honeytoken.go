@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/honeytoken"
+	honeytokendomain "zero-trust-control-plane/backend/internal/honeytoken/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// HoneytokenRepo looks up honeytokens and counts their triggers (e.g. *honeytokenrepo.PostgresRepository).
+type HoneytokenRepo interface {
+	Get(ctx context.Context, userID string) (*honeytokendomain.Honeytoken, error)
+	RecordTrigger(ctx context.Context, userID string, at time.Time) error
+}
+
+// HoneytokenNotifier alerts on honeytoken triggers (e.g. *honeytoken.WebhookNotifier). Notify must not block the
+// caller.
+type HoneytokenNotifier interface {
+	Notify(ctx context.Context, e honeytoken.Event)
+}
+
+// WithHoneytokens rejects every Login and VerifyCredentials attempt against a honeytoken user with
+// ErrInvalidCredentials, whatever the password, and raises an alert: a honeytoken_triggered audit event and security
+// event, and the notifier's webhook. The caller sees an ordinary failed sign-in. notifier may be nil.
+func WithHoneytokens(repo HoneytokenRepo, notifier HoneytokenNotifier) Option {
+	return func(s *AuthService) {
+		s.honeytokens, s.honeytokenNotifier = repo, notifier
+	}
+}
+
+// honeytokenFor returns the user's honeytoken, or nil if the user is not one. Lookup errors are logged and treated
+// as not a honeytoken, like IP block lookups.
+func (s *AuthService) honeytokenFor(ctx context.Context, l *authLookups, userID string) *honeytokendomain.Honeytoken {
+	if s.honeytokens == nil {
+		return nil
+	}
+	var h *honeytokendomain.Honeytoken
+	var err error
+	if l != nil && l.honeytoken.loaded {
+		h, err = l.honeytoken.value, l.honeytoken.err
+	} else {
+		h, err = s.honeytokens.Get(ctx, userID)
+	}
+	if err != nil {
+		log.Printf("auth: honeytoken lookup for user_id=%s failed: %v", userID, err)
+		return nil
+	}
+	return h
+}
+
+// honeytokenPasswordValid reports whether password is the honeytoken's planted credential. It does the same bcrypt
+// work either way, so the rejection takes as long as any other.
+func (s *AuthService) honeytokenPasswordValid(ctx context.Context, ident *identitydomain.Identity, userID, password string) bool {
+	if ident == nil {
+		var err error
+		if ident, err = s.identityRepo.GetByUserAndProvider(ctx, userID, identitydomain.IdentityProviderLocal); err != nil {
+			ident = nil
+		}
+	}
+	if ident == nil || ident.PasswordHash == "" || password == "" {
+		s.compareDummyHash(password)
+		return false
+	}
+	return s.hasher.Compare(ident.PasswordHash, []byte(password)) == nil
+}
+
+// triggerHoneytoken records a sign-in attempt against h: it counts the trigger, audits honeytoken_triggered with
+// the attempt's context, records the security event on the honeytoken user and notifies.
+func (s *AuthService) triggerHoneytoken(ctx context.Context, h *honeytokendomain.Honeytoken, flow, orgID, deviceFingerprint string, passwordValid bool) {
+	now := time.Now().UTC()
+	if err := s.honeytokens.RecordTrigger(ctx, h.UserID, now); err != nil {
+		log.Printf("auth: failed to record honeytoken trigger for user_id=%s: %v", h.UserID, err)
+	}
+	e := honeytoken.Event{
+		Type:              honeytoken.EventTriggered,
+		UserID:            h.UserID,
+		Label:             h.Label,
+		Flow:              flow,
+		OrgID:             orgID,
+		IP:                interceptors.ClientIP(ctx),
+		UserAgent:         interceptors.UserAgent(ctx),
+		DeviceFingerprint: deviceFingerprint,
+		PasswordValid:     passwordValid,
+		OccurredAt:        now,
+	}
+	metadata, _ := json.Marshal(struct {
+		Flow              string `json:"flow"`
+		Label             string `json:"label,omitempty"`
+		IP                string `json:"ip,omitempty"`
+		UserAgent         string `json:"user_agent,omitempty"`
+		DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+		PasswordValid     bool   `json:"password_valid"`
+	}{e.Flow, e.Label, e.IP, e.UserAgent, e.DeviceFingerprint, e.PasswordValid})
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), h.UserID, "honeytoken_triggered", "authentication", string(metadata))
+	}
+	s.recordSecurityEvent(ctx, orgID, h.UserID, securityeventdomain.EventHoneytokenTriggered, string(metadata))
+	if s.honeytokenNotifier != nil {
+		s.honeytokenNotifier.Notify(ctx, e)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/honeytoken"
+	honeytokendomain "zero-trust-control-plane/backend/internal/honeytoken/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+type memHoneytokenRepo struct {
+	mu     sync.Mutex
+	byUser map[string]*honeytokendomain.Honeytoken
+}
+
+func (m *memHoneytokenRepo) Get(ctx context.Context, userID string) (*honeytokendomain.Honeytoken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.byUser[userID], nil
+}
+
+func (m *memHoneytokenRepo) RecordTrigger(ctx context.Context, userID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h := m.byUser[userID]; h != nil {
+		h.TriggerCount++
+		h.LastTriggeredAt = &at
+	}
+	return nil
+}
+
+type recordingHoneytokenNotifier struct {
+	events []honeytoken.Event
+}
+
+func (r *recordingHoneytokenNotifier) Notify(ctx context.Context, e honeytoken.Event) {
+	r.events = append(r.events, e)
+}
+
+// withHoneytoken registers email with password Password123!abc and marks them as a honeytoken account.
+func withHoneytoken(email string) fixtureOption {
+	return func(f *testFixture) {
+		f.honeytokens = &memHoneytokenRepo{byUser: make(map[string]*honeytokendomain.Honeytoken)}
+		f.honeytokenNotifier = &recordingHoneytokenNotifier{}
+		f.opts = append(f.opts, WithHoneytokens(f.honeytokens, f.honeytokenNotifier))
+		f.seeds = append(f.seeds, func(t *testing.T) {
+			userID := f.register(t, email)
+			f.honeytokens.byUser[userID] = &honeytokendomain.Honeytoken{UserID: userID, Label: "payroll share"}
+		})
+	}
+}
+
+func TestAuthService_Honeytoken_Login(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withSecurityEvents(), withHoneytoken("decoy@example.com"))
+	svc, repo, notifier, auditLogger, recorder := f.svc, f.honeytokens, f.honeytokenNotifier, f.audit, f.events
+
+	if _, err := svc.Login(ctxFromIP("203.0.113.7"), "decoy@example.com", "Password123!abc", "org-1", "fp-1"); err != ErrInvalidCredentials {
+		t.Fatalf("Login with the planted password: want ErrInvalidCredentials, got %v", err)
+	}
+	if _, err := svc.Login(context.Background(), "decoy@example.com", "wrong-password", "org-1", ""); err != ErrInvalidCredentials {
+		t.Fatalf("Login with a wrong password: want ErrInvalidCredentials, got %v", err)
+	}
+	if len(notifier.events) != 2 {
+		t.Fatalf("notified %d events, want 2", len(notifier.events))
+	}
+	if e := notifier.events[0]; e.Type != honeytoken.EventTriggered || e.Flow != string(FlowLogin) || e.Label != "payroll share" || !e.PasswordValid || e.IP != "203.0.113.7" || e.DeviceFingerprint != "fp-1" {
+		t.Errorf("first event = %+v", e)
+	}
+	if notifier.events[1].PasswordValid {
+		t.Error("second event: password_valid should be false")
+	}
+	for _, h := range repo.byUser {
+		if h.TriggerCount != 2 || h.LastTriggeredAt == nil {
+			t.Errorf("trigger count = %d, last = %v; want 2 and set", h.TriggerCount, h.LastTriggeredAt)
+		}
+	}
+	var triggered, failures int
+	auditLogger.mu.Lock()
+	for _, e := range auditLogger.events {
+		switch e.action {
+		case "honeytoken_triggered":
+			triggered++
+		case "login_failure":
+			failures++
+		}
+	}
+	auditLogger.mu.Unlock()
+	if triggered != 2 || failures != 2 {
+		t.Errorf("audited %d honeytoken_triggered and %d login_failure, want 2 each", triggered, failures)
+	}
+	if len(recorder.types) == 0 || recorder.types[0] != securityeventdomain.EventHoneytokenTriggered {
+		t.Errorf("security events = %v, want honeytoken_triggered", recorder.types)
+	}
+}
+
+func TestAuthService_Honeytoken_VerifyCredentials(t *testing.T) {
+	f := newTestFixture(t, withHoneytoken("decoy@example.com"))
+	svc, notifier := f.svc, f.honeytokenNotifier
+
+	if _, err := svc.VerifyCredentials(context.Background(), "decoy@example.com", "Password123!abc", "org-1", ""); err != ErrInvalidCredentials {
+		t.Fatalf("VerifyCredentials: want ErrInvalidCredentials, got %v", err)
+	}
+	if len(notifier.events) != 1 || notifier.events[0].Flow != "verify_credentials" || !notifier.events[0].PasswordValid {
+		t.Errorf("events = %+v, want one verify_credentials trigger with a valid password", notifier.events)
+	}
+
+	if _, err := svc.Register(context.Background(), "real@example.com", "Password123!abc", ""); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := svc.Login(context.Background(), "real@example.com", "Password123!abc", "org-1", ""); err == ErrInvalidCredentials {
+		t.Error("ordinary user should not be rejected as a honeytoken")
+	}
+	if len(notifier.events) != 1 {
+		t.Errorf("ordinary sign-in notified: %+v", notifier.events)
+	}
+}
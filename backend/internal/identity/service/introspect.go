@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// DefaultIntrospectionCacheTTL is the longest Introspect tells callers to cache a result, when WithIntrospection is
+// given no TTL.
+const DefaultIntrospectionCacheTTL = 30 * time.Second
+
+// ServiceAccountChecker reports whether a user is a service account (e.g. *config.Watcher, backed by
+// SERVICE_ACCOUNT_USER_IDS).
+type ServiceAccountChecker interface {
+	IsServiceAccount(userID string) bool
+}
+
+// WithIntrospection enables Introspect for the service accounts accounts reports. Results may be cached for at most
+// maxCacheTTL (DefaultIntrospectionCacheTTL when maxCacheTTL <= 0).
+func WithIntrospection(accounts ServiceAccountChecker, maxCacheTTL time.Duration) Option {
+	return func(s *AuthService) {
+		if maxCacheTTL <= 0 {
+			maxCacheTTL = DefaultIntrospectionCacheTTL
+		}
+		s.serviceAccounts, s.introspectionCacheTTL = accounts, maxCacheTTL
+	}
+}
+
+// IntrospectionResult describes an access token (RFC 7662-style). When Active is false the token must be rejected
+// and only CacheTTL is set.
+type IntrospectionResult struct {
+	Active    bool
+	UserID    string
+	OrgID     string
+	SessionID string
+	TokenID   string // jti
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	// DeviceID is the session's device; DeviceTrusted is its effective trust now (trusted, not revoked and not
+	// expired) and DeviceTrustedUntil the trust expiry, nil when trust does not expire or the device is untrusted.
+	DeviceID           string
+	DeviceTrusted      bool
+	DeviceTrustedUntil *time.Time
+	// AuthMethods are the factors the session was signed in with: the primary factor, then the second factor if any
+	// (e.g. password, sms_otp).
+	AuthMethods []string
+	// CacheTTL is how long the caller may reuse this result: at most the configured maximum, and for an active
+	// token at most its remaining lifetime.
+	CacheTTL time.Duration
+}
+
+// Introspect reports whether token is an access token that is valid right now, for services that need an
+// authoritative answer instead of checking the signature alone: the signature, iss, aud, typ and exp must be valid
+// (exp strictly, without the clock leeway ValidateAccess allows), the session must exist, belong to the token's user
+// and org and be neither revoked nor expired, and the user must be active and still a member of the org. Refresh and
+// resource tokens are inactive. The caller (identity from ctx) must be a service account (ErrServiceAccountRequired).
+// Lookup errors are returned, so callers never mistake an outage for a revocation.
+func (s *AuthService) Introspect(ctx context.Context, token string) (*IntrospectionResult, error) {
+	callerID, _ := interceptors.GetUserID(ctx)
+	if s.serviceAccounts == nil || !s.serviceAccounts.IsServiceAccount(callerID) {
+		return nil, ErrServiceAccountRequired
+	}
+	inactive := &IntrospectionResult{CacheTTL: s.introspectionCacheTTL}
+	claims, err := s.tokens.ParseAccess(token)
+	if err != nil {
+		return inactive, nil
+	}
+	now := time.Now().UTC()
+	// ParseAccess tolerates clock skew; an authoritative answer does not, so a token past exp is inactive here.
+	if claims.ExpiresAt == nil || !claims.ExpiresAt.Time.After(now) {
+		return inactive, nil
+	}
+	sess, err := s.sessionRepo.GetByID(ctx, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sess == nil || sess.RevokedAt != nil || !sess.ExpiresAt.After(now) ||
+		sess.UserID != claims.Subject || sess.OrgID != claims.OrgID {
+		return inactive, nil
+	}
+	user, err := s.userRepo.GetByID(ctx, sess.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != userdomain.UserStatusActive {
+		return inactive, nil
+	}
+	membership, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, sess.UserID, sess.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return inactive, nil
+	}
+	res := &IntrospectionResult{
+		Active:    true,
+		UserID:    sess.UserID,
+		OrgID:     sess.OrgID,
+		SessionID: sess.ID,
+		TokenID:   claims.ID,
+		DeviceID:  sess.DeviceID,
+		CacheTTL:  s.introspectionCacheTTL,
+	}
+	if claims.IssuedAt != nil {
+		res.IssuedAt = claims.IssuedAt.Time.UTC()
+	}
+	if claims.ExpiresAt != nil {
+		res.ExpiresAt = claims.ExpiresAt.Time.UTC()
+	}
+	// The token stops being valid at its exp or at the end of its session, whichever comes first.
+	validUntil := sess.ExpiresAt
+	if !res.ExpiresAt.IsZero() && res.ExpiresAt.Before(validUntil) {
+		validUntil = res.ExpiresAt
+	}
+	remaining := validUntil.Sub(now).Truncate(time.Second)
+	if remaining <= 0 {
+		return inactive, nil
+	}
+	if remaining < res.CacheTTL {
+		res.CacheTTL = remaining
+	}
+	if sess.AuthMethod != "" {
+		res.AuthMethods = append(res.AuthMethods, sess.AuthMethod)
+	}
+	if sess.MFAMethod != "" {
+		res.AuthMethods = append(res.AuthMethods, sess.MFAMethod)
+	}
+	if sess.DeviceID != "" {
+		dev, err := s.deviceRepo.GetByID(ctx, sess.DeviceID)
+		if err != nil {
+			return nil, err
+		}
+		if dev != nil && dev.IsEffectivelyTrusted(now) {
+			res.DeviceTrusted = true
+			res.DeviceTrustedUntil = dev.TrustedUntil
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+type serviceAccounts map[string]bool
+
+func (a serviceAccounts) IsServiceAccount(userID string) bool { return a[userID] }
+
+// withIntrospection enables introspection for svc-1 and seeds an active member user-1 of org-1 with session
+// session-1 on trusted device d1; introspectionToken is an access token for that session.
+func withIntrospection() fixtureOption {
+	return func(f *testFixture) {
+		f.opts = append(f.opts, WithIntrospection(serviceAccounts{"svc-1": true}, 20*time.Second))
+		f.seeds = append(f.seeds, func(t *testing.T) {
+			now := time.Now().UTC()
+			f.users.byID["user-1"] = &userdomain.User{ID: "user-1", Email: "user@example.com", Status: userdomain.UserStatusActive}
+			f.memberships.m["m1"] = &membershipdomain.Membership{ID: "m1", UserID: "user-1", OrgID: "org-1", Role: membershipdomain.RoleMember}
+			trustedUntil := now.Add(24 * time.Hour)
+			f.devices.m["d1"] = &devicedomain.Device{ID: "d1", UserID: "user-1", OrgID: "org-1", Trusted: true, TrustedUntil: &trustedUntil}
+			f.sessions.m["session-1"] = &sessiondomain.Session{
+				ID: "session-1", UserID: "user-1", OrgID: "org-1", DeviceID: "d1", ExpiresAt: now.Add(time.Hour),
+				AuthMethod: sessiondomain.AuthMethodPassword, MFAMethod: "sms_otp",
+			}
+		})
+	}
+}
+
+// introspectionToken issues an access token for withIntrospection's session-1.
+func introspectionToken(t *testing.T, f *testFixture) string {
+	t.Helper()
+	token, _, _, err := f.svc.tokens.IssueAccess("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+	return token
+}
+
+func TestAuthService_Introspect(t *testing.T) {
+	f := newTestFixture(t, withIntrospection())
+	svc, token := f.svc, introspectionToken(t, f)
+	ctx := interceptors.WithIdentity(context.Background(), "svc-1", "org-svc", "session-svc")
+
+	res, err := svc.Introspect(ctx, token)
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+	if !res.Active || res.UserID != "user-1" || res.OrgID != "org-1" || res.SessionID != "session-1" || res.TokenID == "" {
+		t.Errorf("result = %+v", res)
+	}
+	if res.DeviceID != "d1" || !res.DeviceTrusted || res.DeviceTrustedUntil == nil {
+		t.Errorf("device = %q trusted=%v until=%v, want d1 trusted with expiry", res.DeviceID, res.DeviceTrusted, res.DeviceTrustedUntil)
+	}
+	if len(res.AuthMethods) != 2 || res.AuthMethods[0] != "password" || res.AuthMethods[1] != "sms_otp" {
+		t.Errorf("AuthMethods = %v", res.AuthMethods)
+	}
+	if res.IssuedAt.IsZero() || !res.ExpiresAt.After(time.Now()) {
+		t.Errorf("iat = %v, exp = %v", res.IssuedAt, res.ExpiresAt)
+	}
+	if res.CacheTTL != 20*time.Second {
+		t.Errorf("CacheTTL = %v, want the configured 20s", res.CacheTTL)
+	}
+}
+
+func TestAuthService_Introspect_CacheTTLCappedAtExpiry(t *testing.T) {
+	f := newTestFixture(t, withIntrospection())
+	svc, sessionRepo, token := f.svc, f.sessions, introspectionToken(t, f)
+	ctx := interceptors.WithIdentity(context.Background(), "svc-1", "org-svc", "session-svc")
+	sessionRepo.m["session-1"].ExpiresAt = time.Now().Add(5500 * time.Millisecond)
+
+	res, err := svc.Introspect(ctx, token)
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+	if !res.Active || res.CacheTTL > 5*time.Second || res.CacheTTL < 4*time.Second {
+		t.Errorf("active = %v, CacheTTL = %v, want about 5s (session end)", res.Active, res.CacheTTL)
+	}
+}
+
+func TestAuthService_Introspect_Inactive(t *testing.T) {
+	ctx := interceptors.WithIdentity(context.Background(), "svc-1", "org-svc", "session-svc")
+	tests := []struct {
+		name  string
+		setup func(svc *AuthService, sessions *memSessionRepo, token *string)
+	}{
+		{"malformed token", func(_ *AuthService, _ *memSessionRepo, token *string) { *token = "not-a-jwt" }},
+		{"revoked session", func(_ *AuthService, sessions *memSessionRepo, _ *string) {
+			now := time.Now()
+			sessions.m["session-1"].RevokedAt = &now
+		}},
+		{"expired session", func(_ *AuthService, sessions *memSessionRepo, _ *string) {
+			sessions.m["session-1"].ExpiresAt = time.Now().Add(-time.Minute)
+		}},
+		{"unknown session", func(_ *AuthService, sessions *memSessionRepo, _ *string) { delete(sessions.m, "session-1") }},
+		{"disabled user", func(svc *AuthService, _ *memSessionRepo, _ *string) {
+			svc.userRepo.(*memUserRepo).byID["user-1"].Status = userdomain.UserStatusDisabled
+		}},
+		{"removed member", func(svc *AuthService, _ *memSessionRepo, _ *string) {
+			delete(svc.membershipRepo.(*memMembershipRepo).m, "m1")
+		}},
+		{"session of another org", func(svc *AuthService, _ *memSessionRepo, token *string) {
+			*token, _, _, _ = svc.tokens.IssueAccess("session-1", "user-1", "org-2")
+		}},
+		{"refresh token", func(svc *AuthService, _ *memSessionRepo, token *string) {
+			*token, _, _, _ = svc.tokens.IssueRefresh("session-1", "user-1", "org-1")
+		}},
+		{"resource token", func(svc *AuthService, _ *memSessionRepo, token *string) {
+			*token, _, _, _ = svc.tokens.IssueResource("session-1", "user-1", "org-1", "https://api.example.com", "", time.Minute)
+		}},
+		{"token expired within the clock leeway", func(svc *AuthService, _ *memSessionRepo, token *string) {
+			svc.tokens.SetClockLeeway(time.Minute)
+			*token, _, _, _ = svc.tokens.IssueAccessUntil(context.Background(), "session-1", "user-1", "org-1", time.Now().Add(-10*time.Second))
+			if _, _, _, err := svc.tokens.ValidateAccess(*token); err != nil {
+				t.Fatalf("ValidateAccess within the leeway: %v", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFixture(t, withIntrospection())
+			svc, sessions, token := f.svc, f.sessions, introspectionToken(t, f)
+			tt.setup(svc, sessions, &token)
+			res, err := svc.Introspect(ctx, token)
+			if err != nil {
+				t.Fatalf("Introspect: %v", err)
+			}
+			if res.Active || res.UserID != "" || res.SessionID != "" {
+				t.Errorf("result = %+v, want inactive with no details", res)
+			}
+			if res.CacheTTL != 20*time.Second {
+				t.Errorf("CacheTTL = %v, want 20s", res.CacheTTL)
+			}
+		})
+	}
+}
+
+func TestAuthService_Introspect_Errors(t *testing.T) {
+	f := newTestFixture(t, withIntrospection())
+	svc, sessions, token := f.svc, f.sessions, introspectionToken(t, f)
+	for _, caller := range []string{"user-1", ""} {
+		ctx := interceptors.WithIdentity(context.Background(), caller, "org-1", "session-1")
+		if _, err := svc.Introspect(ctx, token); err != ErrServiceAccountRequired {
+			t.Errorf("caller %q: want ErrServiceAccountRequired, got %v", caller, err)
+		}
+	}
+	ctx := interceptors.WithIdentity(context.Background(), "svc-1", "org-svc", "session-svc")
+	sessions.getByIDErr = errors.New("db down")
+	if _, err := svc.Introspect(ctx, token); err == nil || err == ErrServiceAccountRequired {
+		t.Errorf("session lookup error: want it returned, got %v", err)
+	}
+
+	disabled, _ := newTestAuthService(t)
+	if _, err := disabled.Introspect(ctx, token); err != ErrServiceAccountRequired {
+		t.Errorf("without WithIntrospection: want ErrServiceAccountRequired, got %v", err)
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	"zero-trust-control-plane/backend/internal/loginhold"
+	loginholddomain "zero-trust-control-plane/backend/internal/loginhold/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// DefaultLoginHoldTTL is how long a held sign-in waits for a decision, and how long an approved one can be resumed,
+// when WithLoginHolds is given no TTL.
+const DefaultLoginHoldTTL = 15 * time.Minute
+
+// LoginHoldRepo persists sign-ins held for admin approval (e.g. *loginholdrepo.PostgresRepository).
+type LoginHoldRepo interface {
+	Create(ctx context.Context, h *loginholddomain.Hold) error
+	GetByID(ctx context.Context, id string) (*loginholddomain.Hold, error)
+	ListPending(ctx context.Context, orgID string, now time.Time, limit, offset int32) ([]*loginholddomain.Hold, error)
+	Decide(ctx context.Context, id string, status loginholddomain.Status, by string, decidedAt, expiresAt time.Time) (bool, error)
+	Complete(ctx context.Context, id string, now time.Time) (bool, error)
+}
+
+// LoginHoldNotifier tells org admins that a sign-in is waiting for them (e.g. *loginhold.WebhookNotifier). Notify
+// must not block the caller.
+type LoginHoldNotifier interface {
+	Notify(ctx context.Context, e loginhold.Event)
+}
+
+// WithLoginHolds holds high-risk sign-ins for an org admin's approval instead of rejecting them. A Login from a
+// blocked client IP, or one that a step added with WithFlowStep before StepLoginHold flags in FlowState.HoldReasons, returns
+// ApprovalRequired once its credentials, membership and org policy check out; the client waits, then completes the
+// sign-in with ResumeLogin. Holds expire after ttl (DefaultLoginHoldTTL when ttl <= 0). notifier may be nil.
+func WithLoginHolds(repo LoginHoldRepo, ttl time.Duration, notifier LoginHoldNotifier) Option {
+	return func(s *AuthService) {
+		if ttl <= 0 {
+			ttl = DefaultLoginHoldTTL
+		}
+		s.loginHolds, s.loginHoldTTL, s.loginHoldNotifier = repo, ttl, notifier
+	}
+}
+
+// ApprovalRequiredResult is returned by Login when the sign-in is held for an org admin's approval. HoldToken is
+// shown once; ResumeLogin needs it with HoldID.
+type ApprovalRequiredResult struct {
+	HoldID    string
+	HoldToken string
+	ExpiresAt time.Time
+}
+
+func holdRequired(st *FlowState) bool { return len(st.HoldReasons) > 0 }
+
+// stepLoginHold holds the sign-in: it stores a pending hold, audits it as login_held, records a login_held security
+// event for the user, notifies admins and ends the flow with ApprovalRequired.
+func (s *AuthService) stepLoginHold(ctx context.Context, st *FlowState) (context.Context, error) {
+	token, hash, err := loginholddomain.NewToken()
+	if err != nil {
+		return ctx, err
+	}
+	now := time.Now().UTC()
+	hold := &loginholddomain.Hold{
+		ID:        uuid.New().String(),
+		OrgID:     st.OrgID,
+		UserID:    st.UserID,
+		DeviceID:  flowDeviceID(st),
+		TokenHash: hash,
+		Reasons:   st.HoldReasons,
+		IP:        interceptors.ClientIP(ctx),
+		Status:    loginholddomain.StatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.loginHoldTTL),
+	}
+	if err := s.loginHolds.Create(ctx, hold); err != nil {
+		return ctx, err
+	}
+	metadata, _ := json.Marshal(struct {
+		HoldID  string   `json:"hold_id"`
+		Reasons []string `json:"reasons"`
+	}{hold.ID, hold.Reasons})
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, st.OrgID, st.UserID, "login_held", "authentication", string(metadata))
+	}
+	s.recordSecurityEvent(ctx, st.OrgID, st.UserID, securityeventdomain.EventLoginHeld, string(metadata))
+	if s.loginHoldNotifier != nil {
+		s.loginHoldNotifier.Notify(ctx, loginhold.Event{
+			Type:       loginhold.EventHeld,
+			HoldID:     hold.ID,
+			OrgID:      hold.OrgID,
+			UserID:     hold.UserID,
+			DeviceID:   hold.DeviceID,
+			Reasons:    hold.Reasons,
+			IP:         hold.IP,
+			ExpiresAt:  hold.ExpiresAt,
+			OccurredAt: now,
+		})
+	}
+	st.Result = &LoginResult{ApprovalRequired: &ApprovalRequiredResult{HoldID: hold.ID, HoldToken: token, ExpiresAt: hold.ExpiresAt}}
+	return ctx, nil
+}
+
+// ResumeLogin completes a held sign-in once an org admin has approved it. Until then it fails with
+// ErrLoginHoldPending, so clients poll it; a denied hold fails with ErrLoginHoldDenied. An approved hold can be
+// resumed once: org policy and MFA policy are evaluated again and the result is that of Login (tokens, or MFA
+// required). The steps run are the resume_login flow (see resumeLoginSteps).
+func (s *AuthService) ResumeLogin(ctx context.Context, holdID, holdToken string) (*LoginResult, error) {
+	return s.runFlow(ctx, &FlowState{
+		Flow:      FlowResumeLogin,
+		HoldID:    strings.TrimSpace(holdID),
+		HoldToken: strings.TrimSpace(holdToken),
+	})
+}
+
+// stepHoldRelease checks the hold and its token and consumes the hold once it is approved. The user must still be
+// active and a member of the org. A hold without a device resumes a public device sign-in.
+func (s *AuthService) stepHoldRelease(ctx context.Context, st *FlowState) (context.Context, error) {
+	if s.loginHolds == nil || st.HoldID == "" || st.HoldToken == "" {
+		return ctx, ErrInvalidLoginHold
+	}
+	hold, err := s.loginHolds.GetByID(ctx, st.HoldID)
+	if err != nil {
+		return ctx, err
+	}
+	if hold == nil || !loginholddomain.TokenMatches(st.HoldToken, hold.TokenHash) {
+		return ctx, ErrInvalidLoginHold
+	}
+	st.OrgID, st.UserID = hold.OrgID, hold.UserID
+	now := time.Now().UTC()
+	switch hold.EffectiveStatus(now) {
+	case loginholddomain.StatusApproved:
+	case loginholddomain.StatusPending:
+		return ctx, ErrLoginHoldPending
+	case loginholddomain.StatusDenied:
+		return ctx, ErrLoginHoldDenied
+	default:
+		return ctx, ErrInvalidLoginHold
+	}
+	membership, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, hold.UserID, hold.OrgID)
+	if err != nil {
+		return ctx, err
+	}
+	if membership == nil {
+		return ctx, ErrNotOrgMember
+	}
+	user, err := s.userRepo.GetByID(ctx, hold.UserID)
+	if err != nil {
+		return ctx, err
+	}
+	if user == nil || user.Status != userdomain.UserStatusActive {
+		return ctx, ErrInvalidCredentials
+	}
+	var dev *devicedomain.Device
+	if hold.DeviceID != "" {
+		if dev, err = s.deviceRepo.GetByID(ctx, hold.DeviceID); err != nil {
+			return ctx, err
+		}
+		if dev == nil {
+			return ctx, ErrInvalidLoginHold
+		}
+	}
+	ok, err := s.loginHolds.Complete(ctx, hold.ID, now)
+	if err != nil {
+		return ctx, err
+	}
+	if !ok {
+		return ctx, ErrInvalidLoginHold
+	}
+	st.User, st.Role, st.Device, st.PublicDevice = user, membership.Role, dev, dev == nil
+	return ctx, nil
+}
+
+// ApproveLogin lets a held sign-in of the caller's org be resumed for the hold TTL from now. The caller must be an
+// owner or admin of the org in ctx, and not the user who is signing in.
+func (s *AuthService) ApproveLogin(ctx context.Context, holdID string) (*loginholddomain.Hold, error) {
+	return s.decideLoginHold(ctx, holdID, loginholddomain.StatusApproved)
+}
+
+// DenyLogin ends a held sign-in of the caller's org; ResumeLogin then fails with ErrLoginHoldDenied. Same caller
+// rules as ApproveLogin.
+func (s *AuthService) DenyLogin(ctx context.Context, holdID string) (*loginholddomain.Hold, error) {
+	return s.decideLoginHold(ctx, holdID, loginholddomain.StatusDenied)
+}
+
+// decideLoginHold approves or denies a pending hold and audits it as login_hold_approved or login_hold_denied.
+func (s *AuthService) decideLoginHold(ctx context.Context, holdID string, status loginholddomain.Status) (*loginholddomain.Hold, error) {
+	if s.loginHolds == nil {
+		return nil, ErrLoginHoldsDisabled
+	}
+	callerID, orgID, err := s.requireOrgAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hold, err := s.loginHolds.GetByID(ctx, strings.TrimSpace(holdID))
+	if err != nil {
+		return nil, err
+	}
+	if hold == nil || hold.OrgID != orgID {
+		return nil, ErrLoginHoldNotFound
+	}
+	if hold.UserID == callerID {
+		return nil, ErrLoginHoldSelfDecision
+	}
+	now := time.Now().UTC()
+	expiresAt := hold.ExpiresAt
+	if status == loginholddomain.StatusApproved {
+		expiresAt = now.Add(s.loginHoldTTL)
+	}
+	ok, err := s.loginHolds.Decide(ctx, hold.ID, status, callerID, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLoginHoldNotPending
+	}
+	hold.Status, hold.DecidedBy, hold.DecidedAt, hold.ExpiresAt = status, callerID, &now, expiresAt
+	if s.auditLogger != nil {
+		metadata, _ := json.Marshal(struct {
+			HoldID       string `json:"hold_id"`
+			TargetUserID string `json:"target_user_id"`
+		}{hold.ID, hold.UserID})
+		s.auditLogger.LogEvent(ctx, orgID, callerID, "login_hold_"+string(status), "authentication", string(metadata))
+	}
+	return hold, nil
+}
+
+// ListLoginHolds returns the pending holds of the caller's org, newest first. The caller must be an owner or admin
+// of the org in ctx.
+func (s *AuthService) ListLoginHolds(ctx context.Context, limit, offset int32) ([]*loginholddomain.Hold, error) {
+	if s.loginHolds == nil {
+		return nil, ErrLoginHoldsDisabled
+	}
+	_, orgID, err := s.requireOrgAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.loginHolds.ListPending(ctx, orgID, time.Now().UTC(), limit, offset)
+}
+
+// requireOrgAdmin returns the caller and the org in ctx, or ErrOrgAdminRequired when the caller is not an owner or
+// admin of that org.
+func (s *AuthService) requireOrgAdmin(ctx context.Context) (callerID, orgID string, err error) {
+	callerID, okUser := interceptors.GetUserID(ctx)
+	orgID, okOrg := interceptors.GetOrgID(ctx)
+	if !okUser || callerID == "" || !okOrg || orgID == "" {
+		return "", "", ErrInvalidCredentials
+	}
+	caller, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, callerID, orgID)
+	if err != nil {
+		return "", "", err
+	}
+	if caller == nil || (caller.Role != membershipdomain.RoleOwner && caller.Role != membershipdomain.RoleAdmin) {
+		return "", "", ErrOrgAdminRequired
+	}
+	return callerID, orgID, nil
+}
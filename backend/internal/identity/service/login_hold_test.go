@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	loginholddomain "zero-trust-control-plane/backend/internal/loginhold/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type memLoginHoldRepo struct {
+	mu sync.Mutex
+	m  map[string]*loginholddomain.Hold
+}
+
+func (r *memLoginHoldRepo) Create(ctx context.Context, h *loginholddomain.Hold) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *h
+	r.m[h.ID] = &c
+	return nil
+}
+
+func (r *memLoginHoldRepo) GetByID(ctx context.Context, id string) (*loginholddomain.Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.m[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *h
+	return &c, nil
+}
+
+func (r *memLoginHoldRepo) ListPending(ctx context.Context, orgID string, now time.Time, limit, offset int32) ([]*loginholddomain.Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*loginholddomain.Hold
+	for _, h := range r.m {
+		if h.OrgID == orgID && h.Status == loginholddomain.StatusPending && h.ExpiresAt.After(now) {
+			c := *h
+			out = append(out, &c)
+		}
+	}
+	return out, nil
+}
+
+func (r *memLoginHoldRepo) Decide(ctx context.Context, id string, status loginholddomain.Status, by string, decidedAt, expiresAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.m[id]
+	if !ok || h.Status != loginholddomain.StatusPending || !h.ExpiresAt.After(decidedAt) {
+		return false, nil
+	}
+	h.Status, h.DecidedBy, h.DecidedAt, h.ExpiresAt = status, by, &decidedAt, expiresAt
+	return true, nil
+}
+
+func (r *memLoginHoldRepo) Complete(ctx context.Context, id string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.m[id]
+	if !ok || h.Status != loginholddomain.StatusApproved || !h.ExpiresAt.After(now) {
+		return false, nil
+	}
+	h.Status = loginholddomain.StatusCompleted
+	return true, nil
+}
+
+// withLoginHolds holds sign-ins from 198.51.100.9 for approval.
+func withLoginHolds() fixtureOption {
+	return withOptions(
+		WithIPBlockChecker(staticIPBlockChecker{blocked: map[string]bool{"198.51.100.9": true}}),
+		WithLoginHolds(&memLoginHoldRepo{m: make(map[string]*loginholddomain.Hold)}, 0, nil),
+	)
+}
+
+func TestAuthService_LoginHold_ApproveAndResume(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withLoginHolds(), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"),
+		withMember("admin@example.com", membershipdomain.RoleAdmin, ""))
+	svc, auditLogger, userID, adminID := f.svc, f.audit, f.userID, f.userIDs["admin@example.com"]
+	recorder := &memSecurityEventRecorder{}
+	WithSecurityEventRecorder(recorder)(svc)
+
+	res, err := svc.Login(ctxFromIP("198.51.100.9"), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if res.ApprovalRequired == nil || res.Tokens != nil {
+		t.Fatalf("Login from blocked IP: want ApprovalRequired and no tokens, got %+v", res)
+	}
+	hold := res.ApprovalRequired
+	auditLogger.mu.Lock()
+	if auditLogger.events[0].action != "login_held" || !strings.Contains(auditLogger.events[0].metadata, loginholddomain.ReasonIPBlocked) {
+		t.Errorf("first audit event = %+v, want login_held with reason %s", auditLogger.events[0], loginholddomain.ReasonIPBlocked)
+	}
+	auditLogger.mu.Unlock()
+	if len(recorder.types) == 0 || recorder.types[len(recorder.types)-1] != securityeventdomain.EventLoginHeld {
+		t.Errorf("security events = %v, want login_held", recorder.types)
+	}
+
+	if _, err := svc.ResumeLogin(context.Background(), hold.HoldID, hold.HoldToken); err != ErrLoginHoldPending {
+		t.Errorf("ResumeLogin before approval: want ErrLoginHoldPending, got %v", err)
+	}
+	if _, err := svc.ResumeLogin(context.Background(), hold.HoldID, "ztcp_lh_wrong"); err != ErrInvalidLoginHold {
+		t.Errorf("ResumeLogin with wrong token: want ErrInvalidLoginHold, got %v", err)
+	}
+	if _, err := svc.ApproveLogin(interceptors.WithIdentity(context.Background(), userID, "org-1", "s1"), hold.HoldID); err != ErrOrgAdminRequired {
+		t.Errorf("ApproveLogin by member: want ErrOrgAdminRequired, got %v", err)
+	}
+	adminCtx := interceptors.WithIdentity(context.Background(), adminID, "org-1", "s2")
+	pending, err := svc.ListLoginHolds(adminCtx, 50, 0)
+	if err != nil || len(pending) != 1 || pending[0].ID != hold.HoldID {
+		t.Fatalf("ListLoginHolds = %v, %v; want the held sign-in", pending, err)
+	}
+	approved, err := svc.ApproveLogin(adminCtx, hold.HoldID)
+	if err != nil {
+		t.Fatalf("ApproveLogin: %v", err)
+	}
+	if approved.Status != loginholddomain.StatusApproved || approved.DecidedBy != adminID {
+		t.Errorf("approved hold = %+v", approved)
+	}
+	if _, err := svc.DenyLogin(adminCtx, hold.HoldID); err != ErrLoginHoldNotPending {
+		t.Errorf("DenyLogin after approval: want ErrLoginHoldNotPending, got %v", err)
+	}
+
+	resumed, err := svc.ResumeLogin(context.Background(), hold.HoldID, hold.HoldToken)
+	if err != nil {
+		t.Fatalf("ResumeLogin: %v", err)
+	}
+	if resumed.Tokens == nil || resumed.Tokens.UserID != userID {
+		t.Errorf("ResumeLogin result = %+v, want tokens for %s", resumed, userID)
+	}
+	if _, err := svc.ResumeLogin(context.Background(), hold.HoldID, hold.HoldToken); err != ErrInvalidLoginHold {
+		t.Errorf("second ResumeLogin: want ErrInvalidLoginHold, got %v", err)
+	}
+}
+
+func TestAuthService_LoginHold_Deny(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withLoginHolds(), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"),
+		withMember("admin@example.com", membershipdomain.RoleAdmin, ""))
+	svc, userID, adminID := f.svc, f.userID, f.userIDs["admin@example.com"]
+	res, err := svc.Login(ctxFromIP("198.51.100.9"), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.ApprovalRequired == nil {
+		t.Fatalf("Login = %+v, %v; want ApprovalRequired", res, err)
+	}
+	hold := res.ApprovalRequired
+	if _, err := svc.DenyLogin(interceptors.WithIdentity(context.Background(), userID, "org-2", "s1"), hold.HoldID); err != ErrOrgAdminRequired {
+		t.Errorf("DenyLogin from another org: want ErrOrgAdminRequired, got %v", err)
+	}
+	if _, err := svc.DenyLogin(interceptors.WithIdentity(context.Background(), adminID, "org-1", "s2"), hold.HoldID); err != nil {
+		t.Fatalf("DenyLogin: %v", err)
+	}
+	if _, err := svc.ResumeLogin(context.Background(), hold.HoldID, hold.HoldToken); err != ErrLoginHoldDenied {
+		t.Errorf("ResumeLogin after deny: want ErrLoginHoldDenied, got %v", err)
+	}
+}
+
+func TestAuthService_LoginHold_SelfDecision(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withLoginHolds(), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"),
+		withMember("admin@example.com", membershipdomain.RoleAdmin, ""))
+	svc, userID := f.svc, f.userID
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m1"].Role = membershipdomain.RoleAdmin
+	membershipRepo.mu.Unlock()
+	res, err := svc.Login(ctxFromIP("198.51.100.9"), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.ApprovalRequired == nil {
+		t.Fatalf("Login = %+v, %v; want ApprovalRequired", res, err)
+	}
+	if _, err := svc.ApproveLogin(interceptors.WithIdentity(context.Background(), userID, "org-1", "s1"), res.ApprovalRequired.HoldID); err != ErrLoginHoldSelfDecision {
+		t.Errorf("ApproveLogin of own sign-in: want ErrLoginHoldSelfDecision, got %v", err)
+	}
+}
+
+func TestAuthService_LoginHold_Disabled(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	if _, err := svc.ApproveLogin(interceptors.WithIdentity(context.Background(), "u1", "org-1", "s1"), "h1"); err != ErrLoginHoldsDisabled {
+		t.Errorf("ApproveLogin: want ErrLoginHoldsDisabled, got %v", err)
+	}
+	if _, err := svc.ResumeLogin(context.Background(), "h1", "ztcp_lh_x"); err != ErrInvalidLoginHold {
+		t.Errorf("ResumeLogin: want ErrInvalidLoginHold, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// StepUpMaxAge is how recently a session must have been signed in with MFA to confirm LogoutAllMySessions without
+// the current password, when the org requires step-up.
+const StepUpMaxAge = 10 * time.Minute
+
+// Step-up confirmations recorded in the logout_all audit event.
+const (
+	stepUpPassword = "password"
+	stepUpMFA      = "mfa"
+	stepUpNone     = "none"
+)
+
+// LogoutAllMySessions revokes the caller's sessions on every device and in every org, except the current session
+// when keepCurrent is set, and returns how many were revoked. When the caller's org has step_up_sensitive_actions
+// enabled the caller must confirm with currentPassword or a session signed in with MFA within StepUpMaxAge
+// (ErrStepUpRequired otherwise). A currentPassword that is given is always checked (ErrInvalidCredentials when
+// wrong). The sessions are revoked as logout_all, which other regions receive as a user-wide revocation.
+func (s *AuthService) LogoutAllMySessions(ctx context.Context, keepCurrent bool, currentPassword string) (int64, error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return 0, ErrInvalidCredentials
+	}
+	sessionID, _ := interceptors.GetSessionID(ctx)
+	sess, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return 0, err
+	}
+	if sess == nil || sess.UserID != userID {
+		return 0, ErrInvalidCredentials
+	}
+	stepUp, err := s.logoutAllStepUp(ctx, sess, currentPassword)
+	if err != nil {
+		return 0, err
+	}
+	exceptSessionID := ""
+	if keepCurrent {
+		exceptSessionID = sess.ID
+	}
+	revoked, err := s.sessionRepo.RevokeOtherSessionsByUser(ctx, userID, exceptSessionID, sessiondomain.Revocation{Reason: sessiondomain.RevocationLogoutAll, By: userID})
+	if err != nil {
+		return 0, err
+	}
+	counts := `"sessions_revoked":` + strconv.FormatInt(revoked, 10) + `,"kept_current":` + strconv.FormatBool(keepCurrent)
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(sess.OrgID), userID, "logout_all", "authentication", `{`+counts+`,"step_up":"`+stepUp+`"}`)
+	}
+	s.recordSecurityEvent(ctx, sess.OrgID, userID, securityeventdomain.EventLogoutAll, `{`+counts+`}`)
+	return revoked, nil
+}
+
+// logoutAllStepUp checks the confirmation for LogoutAllMySessions and returns how it was given: stepUpPassword,
+// stepUpMFA, or stepUpNone when the org does not require step-up.
+func (s *AuthService) logoutAllStepUp(ctx context.Context, sess *sessiondomain.Session, currentPassword string) (string, error) {
+	if currentPassword != "" {
+		ident, err := s.identityRepo.GetByUserAndProvider(ctx, sess.UserID, identitydomain.IdentityProviderLocal)
+		if err != nil {
+			return "", err
+		}
+		if ident == nil || ident.PasswordHash == "" || s.hasher.Compare(ident.PasswordHash, []byte(currentPassword)) != nil {
+			if s.auditLogger != nil {
+				s.auditLogger.LogEvent(ctx, orgOrSentinel(sess.OrgID), sess.UserID, "logout_all_failure", "authentication", `{"reason":"invalid_password"}`)
+			}
+			return "", ErrInvalidCredentials
+		}
+		return stepUpPassword, nil
+	}
+	if !s.stepUpRequired(ctx, sess.OrgID, "logout all") {
+		return stepUpNone, nil
+	}
+	if sess.MFAMethod != "" && time.Since(sess.CreatedAt) <= StepUpMaxAge {
+		return stepUpMFA, nil
+	}
+	return "", ErrStepUpRequired
+}
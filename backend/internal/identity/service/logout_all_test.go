@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// logoutAllFixture registers a user with sessions s1 (current, org-1) and s2 (org-2), and s3 of another user.
+func logoutAllFixture(t *testing.T, svc *AuthService, sessionRepo *memSessionRepo) (context.Context, string) {
+	t.Helper()
+	reg, err := svc.Register(context.Background(), "user@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	now := time.Now()
+	sessionRepo.mu.Lock()
+	sessionRepo.m["s1"] = &sessiondomain.Session{ID: "s1", UserID: reg.UserID, OrgID: "org-1", CreatedAt: now.Add(-time.Hour)}
+	sessionRepo.m["s2"] = &sessiondomain.Session{ID: "s2", UserID: reg.UserID, OrgID: "org-2", CreatedAt: now}
+	sessionRepo.m["s3"] = &sessiondomain.Session{ID: "s3", UserID: "other-user", OrgID: "org-1", CreatedAt: now}
+	sessionRepo.mu.Unlock()
+	return interceptors.WithIdentity(context.Background(), reg.UserID, "org-1", "s1"), reg.UserID
+}
+
+func TestAuthService_LogoutAllMySessions(t *testing.T) {
+	svc, sessionRepo := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	recorder := &memSecurityEventRecorder{}
+	WithSecurityEventRecorder(recorder)(svc)
+	ctx, userID := logoutAllFixture(t, svc, sessionRepo)
+
+	if _, err := svc.LogoutAllMySessions(context.Background(), true, ""); err != ErrInvalidCredentials {
+		t.Errorf("no caller: want ErrInvalidCredentials, got %v", err)
+	}
+	n, err := svc.LogoutAllMySessions(ctx, true, "")
+	if err != nil || n != 1 {
+		t.Fatalf("LogoutAllMySessions(keep current) = %d, %v; want 1", n, err)
+	}
+	if s1, _ := sessionRepo.GetByID(ctx, "s1"); s1.RevokedAt != nil {
+		t.Error("current session revoked with keep_current")
+	}
+	if s2, _ := sessionRepo.GetByID(ctx, "s2"); s2.RevokedAt == nil || s2.RevocationReason != sessiondomain.RevocationLogoutAll || s2.RevokedBy != userID {
+		t.Errorf("session in other org = %+v, want revoked as logout_all by the user", s2)
+	}
+	if s3, _ := sessionRepo.GetByID(ctx, "s3"); s3.RevokedAt != nil {
+		t.Error("another user's session was revoked")
+	}
+	if !auditLogger.hasAction("logout_all") {
+		t.Error("logout_all not audited")
+	}
+	if n := len(recorder.types); n == 0 || recorder.types[n-1] != securityeventdomain.EventLogoutAll {
+		t.Errorf("security events = %v, want last logout_all", recorder.types)
+	}
+
+	n, err = svc.LogoutAllMySessions(ctx, false, "")
+	if err != nil || n != 1 {
+		t.Fatalf("LogoutAllMySessions = %d, %v; want the current session revoked", n, err)
+	}
+	if s1, _ := sessionRepo.GetByID(ctx, "s1"); s1.RevokedAt == nil {
+		t.Error("current session not revoked without keep_current")
+	}
+}
+
+func TestAuthService_LogoutAllMySessions_StepUp(t *testing.T) {
+	svc, sessionRepo := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		AuthMfa: &orgpolicyconfigdomain.AuthMfa{StepUpSensitiveActions: true},
+	}})(svc)
+	ctx, _ := logoutAllFixture(t, svc, sessionRepo)
+
+	if _, err := svc.LogoutAllMySessions(ctx, true, ""); err != ErrStepUpRequired {
+		t.Errorf("no confirmation: want ErrStepUpRequired, got %v", err)
+	}
+	if _, err := svc.LogoutAllMySessions(ctx, true, "Wrong123!abc"); err != ErrInvalidCredentials {
+		t.Errorf("wrong password: want ErrInvalidCredentials, got %v", err)
+	}
+	if !auditLogger.hasAction("logout_all_failure") {
+		t.Error("wrong password should be audited as logout_all_failure")
+	}
+	if s2, _ := sessionRepo.GetByID(ctx, "s2"); s2.RevokedAt != nil {
+		t.Fatal("sessions revoked without step-up")
+	}
+
+	sessionRepo.mu.Lock()
+	sessionRepo.m["s1"].MFAMethod = "sms_otp"
+	sessionRepo.mu.Unlock()
+	if _, err := svc.LogoutAllMySessions(ctx, true, ""); err != ErrStepUpRequired {
+		t.Errorf("MFA sign-in older than StepUpMaxAge: want ErrStepUpRequired, got %v", err)
+	}
+	sessionRepo.mu.Lock()
+	sessionRepo.m["s1"].CreatedAt = time.Now().Add(-time.Minute)
+	sessionRepo.mu.Unlock()
+	if n, err := svc.LogoutAllMySessions(ctx, true, ""); err != nil || n != 1 {
+		t.Errorf("recent MFA sign-in: got %d, %v; want 1 revoked", n, err)
+	}
+	if n, err := svc.LogoutAllMySessions(ctx, false, "Password123!abc"); err != nil || n != 1 {
+		t.Errorf("current password: got %d, %v; want 1 revoked", n, err)
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	honeytokendomain "zero-trust-control-plane/backend/internal/honeytoken/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// lookup is the result of one repository read made ahead of the step that uses it.
+type lookup[T any] struct {
+	loaded bool
+	value  T
+	err    error
+	took   time.Duration
+}
+
+// load runs fn and records its result and duration. Returns nil so it can be passed to errgroup.Group.Go.
+func (l *lookup[T]) load(fn func() (T, error)) error {
+	start := time.Now()
+	l.value, l.err = fn()
+	l.took = time.Since(start)
+	l.loaded = true
+	return nil
+}
+
+// mfaSettings are the platform and org settings MFA policy is evaluated with.
+type mfaSettings struct {
+	platform *platformsettingsdomain.PlatformDeviceTrustSettings
+	org      *orgmfasettingsdomain.OrgMFASettings
+}
+
+// authLookups are the repository reads of a login or refresh. They do not depend on each other, so the first step
+// that needs one loads them all concurrently (prefetchLogin, prefetchRefresh) and later steps use the results; on a
+// slow database the flow then waits for the slowest read instead of for each read in turn. Every read keeps its
+// own error, so a step fails exactly as if it had made the read itself; one failed read does not cancel the others.
+type authLookups struct {
+	user       lookup[*userdomain.User]
+	identity   lookup[*identitydomain.Identity]
+	membership lookup[*membershipdomain.Membership]
+	session    lookup[*sessiondomain.Session]
+	device     lookup[*devicedomain.Device]
+	orgPolicy  lookup[*orgpolicyconfigdomain.OrgPolicyConfig]
+	settings   lookup[mfaSettings]
+	honeytoken lookup[*honeytokendomain.Honeytoken]
+}
+
+// loginFingerprint is the device fingerprint of a login or refresh; logins without one share a placeholder device.
+func loginFingerprint(st *FlowState) string {
+	if fp := strings.TrimSpace(st.DeviceFingerprint); fp != "" {
+		return fp
+	}
+	return "password-login"
+}
+
+// prefetchLogin loads the user by email (or username, see loginUser), then their local identity, membership, device and honeytoken mark, and
+// alongside them the org policy config and MFA settings of st.OrgID. Nothing past the user is read for an unknown or
+// inactive user.
+func (s *AuthService) prefetchLogin(ctx context.Context, st *FlowState) *authLookups {
+	l := &authLookups{}
+	var g errgroup.Group
+	s.prefetchOrg(ctx, &g, l, st.OrgID)
+	g.Go(func() error {
+		l.user.load(func() (*userdomain.User, error) { return s.loginUser(ctx, st.Email, st.OrgID) })
+		user := l.user.value
+		if l.user.err != nil || user == nil || user.Status != userdomain.UserStatusActive {
+			return nil
+		}
+		var ug errgroup.Group
+		ug.Go(func() error {
+			return l.identity.load(func() (*identitydomain.Identity, error) {
+				return s.identityRepo.GetByUserAndProvider(ctx, user.ID, identitydomain.IdentityProviderLocal)
+			})
+		})
+		ug.Go(func() error {
+			return l.membership.load(func() (*membershipdomain.Membership, error) {
+				return s.membershipRepo.GetMembershipByUserAndOrg(ctx, user.ID, st.OrgID)
+			})
+		})
+		if !st.PublicDevice {
+			ug.Go(func() error {
+				return l.device.load(func() (*devicedomain.Device, error) {
+					return s.deviceRepo.GetByUserOrgAndFingerprint(ctx, user.ID, st.OrgID, loginFingerprint(st))
+				})
+			})
+		}
+		if s.honeytokens != nil {
+			ug.Go(func() error {
+				return l.honeytoken.load(func() (*honeytokendomain.Honeytoken, error) { return s.honeytokens.Get(ctx, user.ID) })
+			})
+		}
+		return ug.Wait()
+	})
+	_ = g.Wait()
+	return l
+}
+
+// prefetchRefresh loads the session being refreshed, its user and device, and the org policy config and MFA
+// settings of st.OrgID. st.SessionID, st.UserID and st.OrgID come from the validated refresh token.
+func (s *AuthService) prefetchRefresh(ctx context.Context, st *FlowState) *authLookups {
+	l := &authLookups{}
+	var g errgroup.Group
+	s.prefetchOrg(ctx, &g, l, st.OrgID)
+	g.Go(func() error {
+		return l.session.load(func() (*sessiondomain.Session, error) { return s.sessionRepo.GetByID(ctx, st.SessionID) })
+	})
+	g.Go(func() error {
+		return l.user.load(func() (*userdomain.User, error) { return s.userRepo.GetByID(ctx, st.UserID) })
+	})
+	g.Go(func() error {
+		return l.device.load(func() (*devicedomain.Device, error) {
+			return s.deviceRepo.GetByUserOrgAndFingerprint(ctx, st.UserID, st.OrgID, loginFingerprint(st))
+		})
+	})
+	_ = g.Wait()
+	return l
+}
+
+// prefetchOrg adds the org-level reads to g: the org policy config and the MFA settings.
+func (s *AuthService) prefetchOrg(ctx context.Context, g *errgroup.Group, l *authLookups, orgID string) {
+	if s.orgPolicyConfigRepo != nil {
+		g.Go(func() error {
+			return l.orgPolicy.load(func() (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+				return s.orgPolicyConfigRepo.GetByOrgID(ctx, orgID)
+			})
+		})
+	}
+	g.Go(func() error {
+		return l.settings.load(func() (mfaSettings, error) { return s.loadMFASettings(ctx, orgID), nil })
+	})
+}
+
+// WithFlowTimings logs the per-step and per-read durations of every login and refresh while enabled reports true
+// (e.g. while LOG_LEVEL is debug), for finding the slow stage of a flow. Without it no timings are logged.
+func WithFlowTimings(enabled func() bool) Option {
+	return func(s *AuthService) { s.flowTimings = enabled }
+}
+
+// logTimings logs the flow's per-step and per-read durations as key=value pairs when WithFlowTimings is enabled.
+func (s *AuthService) logTimings(st *FlowState, transitions []FlowTransition) {
+	if s.flowTimings == nil || !s.flowTimings() {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "flow=%s org_id=%s", st.Flow, st.OrgID)
+	for _, tr := range transitions {
+		if tr.Outcome != "skipped" {
+			fmt.Fprintf(&b, " step.%s_ms=%g", tr.Step, tr.DurationMS)
+		}
+	}
+	if l := st.lookups; l != nil {
+		for _, r := range []struct {
+			name   string
+			loaded bool
+			took   time.Duration
+		}{
+			{"user", l.user.loaded, l.user.took},
+			{"identity", l.identity.loaded, l.identity.took},
+			{"membership", l.membership.loaded, l.membership.took},
+			{"session", l.session.loaded, l.session.took},
+			{"device", l.device.loaded, l.device.took},
+			{"org_policy", l.orgPolicy.loaded, l.orgPolicy.took},
+			{"mfa_settings", l.settings.loaded, l.settings.took},
+			{"honeytoken", l.honeytoken.loaded, l.honeytoken.took},
+		} {
+			if r.loaded {
+				fmt.Fprintf(&b, " lookup.%s_ms=%g", r.name, durationMS(r.took))
+			}
+		}
+	}
+	log.Printf("auth: flow timings %s", b.String())
+}
+
+// durationMS is d in milliseconds, rounded to microseconds.
+func durationMS(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+)
+
+// rendezvous lets n concurrent callers meet: arrive blocks until all n have arrived and reports false if they did
+// not within a second, i.e. when the calls were made one after another.
+type rendezvous struct {
+	mu      sync.Mutex
+	n       int
+	arrived int
+	all     chan struct{}
+	missed  bool
+}
+
+func newRendezvous(n int) *rendezvous { return &rendezvous{n: n, all: make(chan struct{})} }
+
+func (r *rendezvous) arrive() {
+	r.mu.Lock()
+	r.arrived++
+	if r.arrived == r.n {
+		close(r.all)
+	}
+	r.mu.Unlock()
+	select {
+	case <-r.all:
+	case <-time.After(time.Second):
+		r.mu.Lock()
+		r.missed = true
+		r.mu.Unlock()
+	}
+}
+
+type meetingIdentityRepo struct {
+	IdentityRepo
+	r *rendezvous
+}
+
+func (m meetingIdentityRepo) GetByUserAndProvider(ctx context.Context, userID string, provider identitydomain.IdentityProvider) (*identitydomain.Identity, error) {
+	m.r.arrive()
+	return m.IdentityRepo.GetByUserAndProvider(ctx, userID, provider)
+}
+
+type meetingMembershipRepo struct {
+	MembershipRepo
+	r *rendezvous
+}
+
+func (m meetingMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	m.r.arrive()
+	return m.MembershipRepo.GetMembershipByUserAndOrg(ctx, userID, orgID)
+}
+
+type meetingDeviceRepo struct {
+	DeviceRepo
+	r *rendezvous
+}
+
+func (m meetingDeviceRepo) GetByUserOrgAndFingerprint(ctx context.Context, userID, orgID, fingerprint string) (*devicedomain.Device, error) {
+	m.r.arrive()
+	return m.DeviceRepo.GetByUserOrgAndFingerprint(ctx, userID, orgID, fingerprint)
+}
+
+func TestAuthService_Login_LoadsLookupsConcurrently(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	loginFlowFixture(t, svc, "fp-1")
+	meet := newRendezvous(3)
+	svc.identityRepo = meetingIdentityRepo{svc.identityRepo, meet}
+	svc.membershipRepo = meetingMembershipRepo{svc.membershipRepo, meet}
+	svc.deviceRepo = meetingDeviceRepo{svc.deviceRepo, meet}
+
+	res, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login = %+v, %v; want tokens", res, err)
+	}
+	if meet.missed {
+		t.Error("identity, membership and device lookups did not run concurrently")
+	}
+	fa := lastFlowAudit(t, auditLogger)
+	if len(fa.Steps) < 2 || fa.Steps[1].Step != StepPassword || fa.Steps[1].DurationMS <= 0 {
+		t.Errorf("auth_flow steps = %+v, want the password step with its duration", fa.Steps)
+	}
+}
+
+func TestAuthService_Login_PrefetchErrorsFailTheirStep(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	loginFlowFixture(t, svc, "fp-1")
+	errDevice := errors.New("device store down")
+	svc.deviceRepo.(*memDeviceRepo).getByUserOrgFpErr = errDevice
+
+	// The device lookup fails during the password step's prefetch, but the flow only fails at device_check.
+	if _, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1"); !errors.Is(err, errDevice) {
+		t.Fatalf("Login = %v, want the device lookup error", err)
+	}
+	fa := lastFlowAudit(t, auditLogger)
+	if want := "ip_check:passed,password:passed,membership:passed,org_access_policy:passed,device_check:failed"; flowOutcomes(fa) != want {
+		t.Errorf("auth_flow = %s, want %s", flowOutcomes(fa), want)
+	}
+
+	// A wrong password still fails at the password step, whatever the other lookups returned.
+	if _, err := svc.Login(context.Background(), "user@example.com", "wrong-password", "org-1", "fp-1"); err != ErrInvalidCredentials {
+		t.Fatalf("Login wrong password = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestAuthService_Login_FlowTimings(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	loginFlowFixture(t, svc, "fp-1")
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	// Without WithFlowTimings nothing is logged; with it disabled neither.
+	enabled := false
+	for _, opt := range []Option{nil, WithFlowTimings(func() bool { return enabled })} {
+		if opt != nil {
+			opt(svc)
+		}
+		if _, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != nil {
+			t.Fatalf("Login: %v", err)
+		}
+		if strings.Contains(buf.String(), "auth: flow timings") {
+			t.Fatalf("flow timings logged while disabled: %s", buf.String())
+		}
+	}
+
+	enabled = true
+	if _, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	for _, want := range []string{"auth: flow timings flow=login org_id=org-1", " step.password_ms=", " lookup.user_ms=", " lookup.device_ms="} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log = %q, want %q", buf.String(), want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/magiclink"
+	magiclinkdomain "zero-trust-control-plane/backend/internal/magiclink/domain"
+	"zero-trust-control-plane/backend/internal/security"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// DefaultMagicLinkTTL is how long a magic link can be redeemed, when WithMagicLinks is given no TTL.
+const DefaultMagicLinkTTL = 15 * time.Minute
+
+// MagicLinkRepo persists magic links (e.g. *magiclinkrepo.PostgresRepository).
+type MagicLinkRepo interface {
+	Create(ctx context.Context, m *magiclinkdomain.MagicLink) error
+	GetByTokenHash(ctx context.Context, hash string) (*magiclinkdomain.MagicLink, error)
+	Consume(ctx context.Context, id string, now time.Time) (bool, error)
+}
+
+// MagicLinkMailer delivers magic links to users (e.g. *magiclink.EmailMailer). Send must not block the caller.
+type MagicLinkMailer interface {
+	Send(ctx context.Context, msg magiclink.Message)
+}
+
+// WithMagicLinks enables passwordless sign-in by email for orgs whose auth_mfa policy sets magic_link_enabled (read
+// through WithOrgPolicyConfigRepo). Links are linkURL with the token as the token query parameter, sent through
+// mailer, and can be redeemed once within ttl (DefaultMagicLinkTTL when ttl <= 0). perEmail limits RequestMagicLink
+// per normalized email and may be nil.
+func WithMagicLinks(repo MagicLinkRepo, mailer MagicLinkMailer, ttl time.Duration, linkURL string, perEmail RateLimiter) Option {
+	return func(s *AuthService) {
+		if ttl <= 0 {
+			ttl = DefaultMagicLinkTTL
+		}
+		s.magicLinks, s.magicLinkMailer, s.magicLinkTTL, s.magicLinkURL = repo, mailer, ttl, linkURL
+		if perEmail != nil {
+			s.magicLinkLimiter = perEmail
+		}
+	}
+}
+
+// RequestMagicLink emails a one-time sign-in link for orgID to email. It returns ErrMagicLinksDisabled when the org
+// does not allow magic links, and otherwise succeeds whether or not a link was sent, so callers cannot tell which
+// emails belong to members: no link is sent to unknown or inactive users or to non-members. Honeytoken users get no
+// link and raise an alert (see WithHoneytokens). Sent links are audited as magic_link_sent.
+func (s *AuthService) RequestMagicLink(ctx context.Context, email, orgID string) error {
+	if s.magicLinks == nil || s.magicLinkMailer == nil {
+		return ErrMagicLinksDisabled
+	}
+	email = strings.TrimSpace(strings.ToLower(email))
+	orgID = strings.TrimSpace(orgID)
+	if s.ipBlocked(ctx) {
+		return ErrIPBlocked
+	}
+	if !s.magicLinkLimiter.Allow(email) {
+		return ErrRateLimited
+	}
+	allowed, err := s.magicLinksAllowed(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrMagicLinksDisabled
+	}
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Status != userdomain.UserStatusActive {
+		return nil
+	}
+	if h := s.honeytokenFor(ctx, nil, user.ID); h != nil {
+		s.triggerHoneytoken(ctx, h, FlowMagicLink, orgID, "", false)
+		return nil
+	}
+	membership, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, user.ID, orgID)
+	if err != nil {
+		return err
+	}
+	if membership == nil {
+		return nil
+	}
+	token, hash, err := magiclinkdomain.NewToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	link := &magiclinkdomain.MagicLink{
+		ID:        uuid.New().String(),
+		TokenHash: hash,
+		UserID:    user.ID,
+		OrgID:     orgID,
+		IP:        interceptors.ClientIP(ctx),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.magicLinkTTL),
+	}
+	if err := s.magicLinks.Create(ctx, link); err != nil {
+		return err
+	}
+	s.magicLinkMailer.Send(ctx, magiclink.Message{
+		OrgID:     orgID,
+		To:        user.Email,
+		Link:      withQueryParam(s.magicLinkURL, "token", token),
+		ExpiresAt: link.ExpiresAt,
+		IP:        link.IP,
+	})
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, user.ID, "magic_link_sent", "authentication", `{"magic_link_id":"`+link.ID+`"}`)
+	}
+	return nil
+}
+
+// CompleteMagicLink redeems a magic link and signs its user in from deviceFingerprint. It runs the magic_link flow
+// (see magicLinkSteps): the org access policy, device trust and MFA policy apply as for Login, so the result may be
+// tokens, mfa_required or phone_required. A link is redeemed once; a used, expired or unknown link fails with
+// ErrInvalidMagicLink.
+func (s *AuthService) CompleteMagicLink(ctx context.Context, token, deviceFingerprint string) (*LoginResult, error) {
+	return s.runFlow(ctx, &FlowState{
+		Flow:              FlowMagicLink,
+		MagicLinkToken:    strings.TrimSpace(token),
+		DeviceFingerprint: deviceFingerprint,
+	})
+}
+
+// stepMagicLinkToken checks the link and consumes it. The org must still allow magic links, and the user must still
+// be active, a member of the org and not a honeytoken.
+func (s *AuthService) stepMagicLinkToken(ctx context.Context, st *FlowState) (context.Context, error) {
+	if s.magicLinks == nil || st.MagicLinkToken == "" {
+		return ctx, ErrInvalidMagicLink
+	}
+	link, err := s.magicLinks.GetByTokenHash(ctx, security.HashToken(st.MagicLinkToken))
+	if err != nil {
+		return ctx, err
+	}
+	if link == nil {
+		s.logLoginFailure(ctx, "", "")
+		return ctx, ErrInvalidMagicLink
+	}
+	st.OrgID, st.UserID = link.OrgID, link.UserID
+	now := time.Now().UTC()
+	if !link.Usable(now) {
+		s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		return ctx, ErrInvalidMagicLink
+	}
+	allowed, err := s.magicLinksAllowed(ctx, st.OrgID)
+	if err != nil {
+		return ctx, err
+	}
+	if !allowed {
+		s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		return ctx, ErrMagicLinksDisabled
+	}
+	user, err := s.userRepo.GetByID(ctx, st.UserID)
+	if err != nil {
+		return ctx, err
+	}
+	if user == nil || user.Status != userdomain.UserStatusActive {
+		s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		return ctx, ErrInvalidCredentials
+	}
+	if h := s.honeytokenFor(ctx, nil, user.ID); h != nil {
+		s.triggerHoneytoken(ctx, h, FlowMagicLink, st.OrgID, st.DeviceFingerprint, false)
+		s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		return ctx, ErrInvalidCredentials
+	}
+	st.User = user
+	if _, err := s.stepMembership(ctx, st); err != nil {
+		return ctx, err
+	}
+	ok, err := s.magicLinks.Consume(ctx, link.ID, now)
+	if err != nil {
+		return ctx, err
+	}
+	if !ok {
+		s.logLoginFailure(ctx, st.OrgID, st.UserID)
+		return ctx, ErrInvalidMagicLink
+	}
+	return ctx, nil
+}
+
+// magicLinksAllowed reports whether orgID's auth_mfa policy allows magic links. Without an org policy config repo,
+// or when the org has no auth_mfa section, they are not allowed.
+func (s *AuthService) magicLinksAllowed(ctx context.Context, orgID string) (bool, error) {
+	if orgID == "" || s.orgPolicyConfigRepo == nil {
+		return false, nil
+	}
+	cfg, err := s.orgPolicyConfigRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return false, err
+	}
+	return cfg != nil && cfg.AuthMfa != nil && cfg.AuthMfa.MagicLinkEnabled, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/magiclink"
+	magiclinkdomain "zero-trust-control-plane/backend/internal/magiclink/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/ratelimit"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+type memMagicLinkRepo struct {
+	mu sync.Mutex
+	m  map[string]*magiclinkdomain.MagicLink
+}
+
+func (r *memMagicLinkRepo) Create(ctx context.Context, m *magiclinkdomain.MagicLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *m
+	r.m[m.ID] = &c
+	return nil
+}
+
+func (r *memMagicLinkRepo) GetByTokenHash(ctx context.Context, hash string) (*magiclinkdomain.MagicLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.m {
+		if m.TokenHash == hash {
+			c := *m
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memMagicLinkRepo) Consume(ctx context.Context, id string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.m[id]
+	if m == nil || !m.Usable(now) {
+		return false, nil
+	}
+	m.UsedAt = &now
+	return true, nil
+}
+
+type recordingMagicLinkMailer struct {
+	mu   sync.Mutex
+	sent []magiclink.Message
+}
+
+func (m *recordingMagicLinkMailer) Send(ctx context.Context, msg magiclink.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+}
+
+// token returns the token from the last link sent, or "" if none was sent.
+func (m *recordingMagicLinkMailer) token(t *testing.T) string {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.sent) == 0 {
+		return ""
+	}
+	u, err := url.Parse(m.sent[len(m.sent)-1].Link)
+	if err != nil {
+		t.Fatalf("parse link: %v", err)
+	}
+	return u.Query().Get("token")
+}
+
+// withMagicLinks enables magic links, for org-1 and limited to limit requests per email an hour.
+func withMagicLinks(limit int) fixtureOption {
+	return func(f *testFixture) {
+		f.magicLinks = &memMagicLinkRepo{m: make(map[string]*magiclinkdomain.MagicLink)}
+		f.magicLinkMailer = &recordingMagicLinkMailer{}
+		f.opts = append(f.opts,
+			WithMagicLinks(f.magicLinks, f.magicLinkMailer, 0, "https://app.example.com/magic", ratelimit.NewLimiter(limit, time.Hour)),
+			WithOrgPolicyConfigRepo(orgPolicyConfigsByOrg{"org-1": {AuthMfa: &orgpolicyconfigdomain.AuthMfa{MagicLinkEnabled: true}}}),
+		)
+	}
+}
+
+func TestAuthService_MagicLink_RequestAndComplete(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withMagicLinks(5), withMember("user@example.com", membershipdomain.RoleMember, "laptop"))
+	svc, sessionRepo, mailer, auditLogger, userID := f.svc, f.sessions, f.magicLinkMailer, f.audit, f.userID
+
+	if err := svc.RequestMagicLink(context.Background(), " User@Example.com ", "org-1"); err != nil {
+		t.Fatalf("RequestMagicLink: %v", err)
+	}
+	token := mailer.token(t)
+	if !strings.HasPrefix(token, magiclinkdomain.TokenPrefix) {
+		t.Fatalf("sent = %+v, want a link with a prefixed token", mailer.sent)
+	}
+	if mailer.sent[0].To != "user@example.com" || !strings.HasPrefix(mailer.sent[0].Link, "https://app.example.com/magic?token=") {
+		t.Errorf("sent = %+v", mailer.sent[0])
+	}
+	if !auditLogger.hasAction("magic_link_sent") {
+		t.Errorf("audit events = %+v, want magic_link_sent", auditLogger.events)
+	}
+
+	if _, err := svc.CompleteMagicLink(context.Background(), "ztcp_ml_wrong", "laptop"); err != ErrInvalidMagicLink {
+		t.Errorf("Complete with unknown token: want ErrInvalidMagicLink, got %v", err)
+	}
+	res, err := svc.CompleteMagicLink(context.Background(), token, "laptop")
+	if err != nil {
+		t.Fatalf("CompleteMagicLink: %v", err)
+	}
+	if res.Tokens == nil || res.Tokens.UserID != userID || res.Tokens.OrgID != "org-1" {
+		t.Fatalf("Complete result = %+v, want tokens in org-1", res)
+	}
+	var found bool
+	sessionRepo.mu.Lock()
+	for _, sess := range sessionRepo.m {
+		if sess.AuthMethod == sessiondomain.AuthMethodMagicLink && sess.DeviceID == "d1" {
+			found = true
+		}
+	}
+	sessionRepo.mu.Unlock()
+	if !found {
+		t.Error("no session with auth method magic_link on the trusted device")
+	}
+	if _, err := svc.CompleteMagicLink(context.Background(), token, "laptop"); err != ErrInvalidMagicLink {
+		t.Errorf("second Complete: want ErrInvalidMagicLink, got %v", err)
+	}
+}
+
+func TestAuthService_MagicLink_NoLinkForUnknownOrNonMember(t *testing.T) {
+	f := newTestFixture(t, withMagicLinks(5), withMember("user@example.com", membershipdomain.RoleMember, "laptop"))
+	svc, mailer := f.svc, f.magicLinkMailer
+	WithOrgPolicyConfigRepo(orgPolicyConfigsByOrg{
+		"org-1": {AuthMfa: &orgpolicyconfigdomain.AuthMfa{MagicLinkEnabled: true}},
+		"org-2": {AuthMfa: &orgpolicyconfigdomain.AuthMfa{MagicLinkEnabled: true}},
+	})(svc)
+
+	if err := svc.RequestMagicLink(context.Background(), "nobody@example.com", "org-1"); err != nil {
+		t.Errorf("unknown email: want nil, got %v", err)
+	}
+	if err := svc.RequestMagicLink(context.Background(), "user@example.com", "org-2"); err != nil {
+		t.Errorf("non-member: want nil, got %v", err)
+	}
+	if len(mailer.sent) != 0 {
+		t.Errorf("sent = %+v, want no links", mailer.sent)
+	}
+}
+
+func TestAuthService_MagicLink_OrgDisabled(t *testing.T) {
+	f := newTestFixture(t, withMagicLinks(5), withMember("user@example.com", membershipdomain.RoleMember, "laptop"))
+	svc, mailer := f.svc, f.magicLinkMailer
+	if err := svc.RequestMagicLink(context.Background(), "user@example.com", "org-1"); err != nil {
+		t.Fatalf("RequestMagicLink: %v", err)
+	}
+	token := mailer.token(t)
+
+	// Turning magic links off also stops links that were already sent.
+	WithOrgPolicyConfigRepo(orgPolicyConfigsByOrg{"org-1": {AuthMfa: &orgpolicyconfigdomain.AuthMfa{}}})(svc)
+	if err := svc.RequestMagicLink(context.Background(), "user@example.com", "org-1"); err != ErrMagicLinksDisabled {
+		t.Errorf("RequestMagicLink: want ErrMagicLinksDisabled, got %v", err)
+	}
+	if _, err := svc.CompleteMagicLink(context.Background(), token, "laptop"); err != ErrMagicLinksDisabled {
+		t.Errorf("CompleteMagicLink: want ErrMagicLinksDisabled, got %v", err)
+	}
+}
+
+func TestAuthService_MagicLink_Expired(t *testing.T) {
+	f := newTestFixture(t, withMagicLinks(5), withMember("user@example.com", membershipdomain.RoleMember, "laptop"))
+	svc, repo, mailer := f.svc, f.magicLinks, f.magicLinkMailer
+	if err := svc.RequestMagicLink(context.Background(), "user@example.com", "org-1"); err != nil {
+		t.Fatalf("RequestMagicLink: %v", err)
+	}
+	repo.mu.Lock()
+	for _, m := range repo.m {
+		m.ExpiresAt = time.Now().Add(-time.Second)
+	}
+	repo.mu.Unlock()
+	if _, err := svc.CompleteMagicLink(context.Background(), mailer.token(t), "laptop"); err != ErrInvalidMagicLink {
+		t.Errorf("Complete expired link: want ErrInvalidMagicLink, got %v", err)
+	}
+}
+
+func TestAuthService_MagicLink_RateLimited(t *testing.T) {
+	svc := newTestFixture(t, withMagicLinks(1), withMember("user@example.com", membershipdomain.RoleMember, "laptop")).svc
+	if err := svc.RequestMagicLink(context.Background(), "user@example.com", "org-1"); err != nil {
+		t.Fatalf("first RequestMagicLink: %v", err)
+	}
+	if err := svc.RequestMagicLink(context.Background(), "USER@example.com", "org-1"); err != ErrRateLimited {
+		t.Errorf("second RequestMagicLink: want ErrRateLimited, got %v", err)
+	}
+}
+
+func TestAuthService_MagicLink_Disabled(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	if err := svc.RequestMagicLink(context.Background(), "user@example.com", "org-1"); err != ErrMagicLinksDisabled {
+		t.Errorf("RequestMagicLink: want ErrMagicLinksDisabled, got %v", err)
+	}
+	if _, err := svc.CompleteMagicLink(context.Background(), "ztcp_ml_x", ""); err != ErrInvalidMagicLink {
+		t.Errorf("CompleteMagicLink: want ErrInvalidMagicLink, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
+)
+
+// Default limits on an MFA challenge; see WithMFAChallengeLimits.
+const (
+	DefaultMFAMaxAttempts    = 5
+	DefaultMFAMaxResends     = 3
+	DefaultMFAResendCooldown = 30 * time.Second
+)
+
+// WithMFAChallengeLimits sets how many wrong codes invalidate a challenge (0 means no limit), how many times
+// ResendMFACode may send a new code for one challenge (0 disables resends), and how long after a code was sent
+// another may be requested.
+func WithMFAChallengeLimits(maxAttempts, maxResends int, resendCooldown time.Duration) Option {
+	return func(s *AuthService) {
+		s.mfaMaxAttempts = maxAttempts
+		s.mfaMaxResends = maxResends
+		s.mfaResendCooldown = resendCooldown
+	}
+}
+
+// MFAResender is implemented by MFA methods that can send a challenge's code again (e.g. sms_otp). Resend replaces
+// the code of c, which has been resent c.ResendCount times, and delivers it; it returns ErrMFAResendCooldown when a
+// concurrent resend already replaced it.
+type MFAResender interface {
+	MFAVerifier
+	Resend(ctx context.Context, c *mfadomain.Challenge) error
+}
+
+// ResendMFAResult is returned by ResendMFACode.
+type ResendMFAResult struct {
+	ChallengeID      string
+	PhoneMask        string
+	ResendsRemaining int
+	NextResendAt     time.Time // earliest time another resend is accepted
+}
+
+// ResendMFACode sends a new code for the challenge, replacing the previous one and restarting the challenge's
+// expiry. Resends are capped per challenge and must be at least the resend cooldown apart.
+func (s *AuthService) ResendMFACode(ctx context.Context, challengeID string) (*ResendMFAResult, error) {
+	challengeID = strings.TrimSpace(challengeID)
+	if challengeID == "" {
+		return nil, ErrInvalidMFAChallenge
+	}
+	c, err := s.mfaChallengeRepo.GetByID(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+	now := time.Now().UTC()
+	if !c.ExpiresAt.After(now) {
+		return nil, ErrChallengeExpired
+	}
+	if s.mfaMaxAttempts > 0 && c.Attempts >= s.mfaMaxAttempts {
+		return nil, ErrMFAAttemptsExceeded
+	}
+	method := c.Method
+	if method == "" {
+		method = MFAMethodSMSOTP
+	}
+	resender, ok := s.mfaVerifier(method).(MFAResender)
+	if method == MFAMethodPhoneChange {
+		// Phone change codes are sent by SMS but confirmed by ConfirmPhoneChange, not a registered method.
+		resender, ok = smsOTPMethod{s}, true
+	}
+	if !ok {
+		return nil, ErrMFAMethodUnavailable
+	}
+	if c.ResendCount >= s.mfaMaxResends {
+		return nil, ErrMFAResendLimit
+	}
+	if now.Before(c.SentAt().Add(s.mfaResendCooldown)) {
+		return nil, ErrMFAResendCooldown
+	}
+	resends := c.ResendCount + 1
+	if err := resender.Resend(ctx, c); err != nil {
+		return nil, err
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, c.OrgID, c.UserID, "mfa_code_resent", "authentication", `{"method":"`+method+`","resend_count":`+strconv.Itoa(resends)+`}`)
+	}
+	return &ResendMFAResult{
+		ChallengeID:      c.ID,
+		PhoneMask:        maskPhone(c.Phone),
+		ResendsRemaining: s.mfaMaxResends - resends,
+		NextResendAt:     now.Add(s.mfaResendCooldown),
+	}, nil
+}
+
+// wrongMFACode counts a wrong code against the challenge. It returns ErrInvalidOTP, or ErrMFAAttemptsExceeded once
+// the attempt limit is reached, in which case the challenge is deleted so it cannot be guessed further.
+func (s *AuthService) wrongMFACode(ctx context.Context, c *mfadomain.Challenge) error {
+	if s.mfaMaxAttempts <= 0 {
+		return ErrInvalidOTP
+	}
+	attempts, err := s.mfaChallengeRepo.IncrementAttempts(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if attempts < s.mfaMaxAttempts {
+		return ErrInvalidOTP
+	}
+	if err := s.mfaChallengeRepo.Delete(ctx, c.ID); err != nil {
+		log.Printf("auth: challenge_id=%s failed to delete locked MFA challenge: %v", c.ID, err)
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, c.OrgID, c.UserID, "mfa_challenge_locked", "authentication", `{"attempts":`+strconv.Itoa(attempts)+`}`)
+	}
+	return ErrMFAAttemptsExceeded
+}
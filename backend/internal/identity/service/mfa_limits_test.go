@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// smsChallengeFixture signs in a user with a phone on a new device and returns the sms_otp challenge id.
+func smsChallengeFixture(t *testing.T, svc *AuthService) string {
+	t.Helper()
+	userID := loginFlowFixture(t, svc, "")
+	userRepo := svc.userRepo.(*memUserRepo)
+	userRepo.mu.Lock()
+	u := *userRepo.byID[userID]
+	u.Phone = "15551234567"
+	userRepo.byID[userID], userRepo.byEmail[u.Email] = &u, &u
+	userRepo.mu.Unlock()
+	res, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-new")
+	if err != nil || res.MFARequired == nil {
+		t.Fatalf("Login = %+v, %v; want MFARequired", res, err)
+	}
+	return res.MFARequired.ChallengeID
+}
+
+func TestAuthService_VerifyMFA_AttemptLimit(t *testing.T) {
+	svc, _, devStore := newTestAuthServiceOpt(t, true)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	WithMFAChallengeLimits(3, DefaultMFAMaxResends, DefaultMFAResendCooldown)(svc)
+	challengeID := smsChallengeFixture(t, svc)
+	ctx := context.Background()
+	otp, _ := devStore.Get(ctx, challengeID)
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.VerifyMFA(ctx, challengeID, "000000"); err != ErrInvalidOTP {
+			t.Fatalf("wrong code %d: want ErrInvalidOTP, got %v", i+1, err)
+		}
+	}
+	if _, err := svc.VerifyMFA(ctx, challengeID, "000000"); err != ErrMFAAttemptsExceeded {
+		t.Fatalf("third wrong code: want ErrMFAAttemptsExceeded, got %v", err)
+	}
+	if !auditLogger.hasAction("mfa_challenge_locked") {
+		t.Error("mfa_challenge_locked not audited")
+	}
+	if _, err := svc.VerifyMFA(ctx, challengeID, otp); err != ErrInvalidMFAChallenge {
+		t.Errorf("correct code after lock: want ErrInvalidMFAChallenge, got %v", err)
+	}
+}
+
+func TestAuthService_ResendMFACode(t *testing.T) {
+	svc, _, devStore := newTestAuthServiceOpt(t, true)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	WithMFAChallengeLimits(DefaultMFAMaxAttempts, 2, time.Minute)(svc)
+	challengeID := smsChallengeFixture(t, svc)
+	ctx := context.Background()
+	repo := svc.mfaChallengeRepo.(*memMFAChallengeRepo)
+	backdate := func() {
+		repo.mu.Lock()
+		c := repo.m[challengeID]
+		c.CreatedAt, c.LastSentAt = c.CreatedAt.Add(-2*time.Minute), c.LastSentAt.Add(-2*time.Minute)
+		repo.mu.Unlock()
+	}
+
+	if _, err := svc.ResendMFACode(ctx, "unknown"); err != ErrInvalidMFAChallenge {
+		t.Errorf("unknown challenge: want ErrInvalidMFAChallenge, got %v", err)
+	}
+	if _, err := svc.ResendMFACode(ctx, challengeID); err != ErrMFAResendCooldown {
+		t.Fatalf("within cooldown: want ErrMFAResendCooldown, got %v", err)
+	}
+	first, _ := devStore.Get(ctx, challengeID)
+	backdate()
+	res, err := svc.ResendMFACode(ctx, challengeID)
+	if err != nil {
+		t.Fatalf("ResendMFACode: %v", err)
+	}
+	if res.ResendsRemaining != 1 || res.PhoneMask == "" || !res.NextResendAt.After(time.Now()) {
+		t.Errorf("result = %+v, want 1 resend remaining, phone mask and a future next_resend_at", res)
+	}
+	if !auditLogger.hasAction("mfa_code_resent") {
+		t.Error("mfa_code_resent not audited")
+	}
+	if _, err := svc.ResendMFACode(ctx, challengeID); err != ErrMFAResendCooldown {
+		t.Errorf("within cooldown after resend: want ErrMFAResendCooldown, got %v", err)
+	}
+	backdate()
+	if _, err := svc.ResendMFACode(ctx, challengeID); err != nil {
+		t.Fatalf("second ResendMFACode: %v", err)
+	}
+	backdate()
+	if _, err := svc.ResendMFACode(ctx, challengeID); err != ErrMFAResendLimit {
+		t.Errorf("past the cap: want ErrMFAResendLimit, got %v", err)
+	}
+
+	latest, _ := devStore.Get(ctx, challengeID)
+	if latest == first {
+		t.Skip("resent code matched the original by chance")
+	}
+	if _, err := svc.VerifyMFA(ctx, challengeID, first); err != ErrInvalidOTP {
+		t.Errorf("replaced code: want ErrInvalidOTP, got %v", err)
+	}
+	if tokens, err := svc.VerifyMFA(ctx, challengeID, latest); err != nil || tokens.AccessToken == "" {
+		t.Errorf("VerifyMFA(latest code) = %+v, %v; want tokens", tokens, err)
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/mfa"
+	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
+	mfaintentdomain "zero-trust-control-plane/backend/internal/mfaintent/domain"
+	"zero-trust-control-plane/backend/internal/notification"
+	notiftemplatedomain "zero-trust-control-plane/backend/internal/notiftemplate/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+)
+
+// Built-in MFA method names, as listed in the org's allowed_mfa_methods.
+const (
+	MFAMethodSMSOTP          = mfadomain.MethodSMSOTP
+	MFAMethodPhoneEnrollment = "phone_enrollment" // allowed whenever sms_otp is
+)
+
+// MFAMethod is a second factor StepMFA can challenge the user with once risk_check requires MFA.
+type MFAMethod interface {
+	Name() string
+	// Available reports whether the method can challenge the user in st (e.g. the user has a phone).
+	Available(st *FlowState) bool
+	// Start issues the challenge and returns what the client needs to complete it.
+	Start(ctx context.Context, st *FlowState) (*LoginResult, error)
+}
+
+// MFAVerifier is implemented by MFA methods whose challenges are completed with VerifyMFA. Challenges record the
+// method that issued them; Verify checks code against st.Challenge.
+type MFAVerifier interface {
+	MFAMethod
+	Verify(ctx context.Context, st *FlowState, code string) error
+}
+
+// WithMFAMethod adds m ahead of the built-in MFA methods, so it is preferred whenever it is available and the org
+// does not set its own order.
+func WithMFAMethod(m MFAMethod) Option {
+	return func(s *AuthService) { s.mfaMethods = append([]MFAMethod{m}, s.mfaMethods...) }
+}
+
+type mfaMethodContextKey struct{}
+
+// ContextWithMFAMethod returns ctx carrying the MFA method the client asked Login or Refresh to use when MFA is
+// required. Empty means the org's preferred method.
+func ContextWithMFAMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, mfaMethodContextKey{}, strings.TrimSpace(method))
+}
+
+// mfaCandidates returns the methods available for st in preference order. When the org policy config lists
+// allowed_mfa_methods, only those methods are used, in that order; otherwise all registered methods are, in
+// registration order.
+func (s *AuthService) mfaCandidates(ctx context.Context, st *FlowState) ([]MFAMethod, error) {
+	var allowed []string
+	if s.orgPolicyConfigRepo != nil {
+		cfg, err := s.orgPolicyConfigRepo.GetByOrgID(ctx, st.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		allowed = orgpolicyconfigdomain.MergeWithDefaults(cfg).AuthMfa.AllowedMfaMethods
+	}
+	var out []MFAMethod
+	if len(allowed) == 0 {
+		for _, m := range s.mfaMethods {
+			if m.Available(st) {
+				out = append(out, m)
+			}
+		}
+		return out, nil
+	}
+	for _, name := range allowed {
+		for _, m := range s.mfaMethods {
+			if mfaMethodAllows(name, m.Name()) && m.Available(st) {
+				out = append(out, m)
+			}
+		}
+	}
+	return out, nil
+}
+
+// mfaMethodAllows reports whether the allowed_mfa_methods entry allowed covers method. Phone enrollment is how a
+// user without a phone gets to sms_otp, so it is allowed with it.
+func mfaMethodAllows(allowed, method string) bool {
+	return allowed == method || (allowed == MFAMethodSMSOTP && method == MFAMethodPhoneEnrollment)
+}
+
+// startMFA starts the requested method, or the first candidate when none was requested. An MFARequired result
+// lists every candidate the client could switch to with a new Login.
+func (s *AuthService) startMFA(ctx context.Context, st *FlowState) (*LoginResult, error) {
+	candidates, err := s.mfaCandidates(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, ErrPhoneRequiredForMFA
+	}
+	method := candidates[0]
+	if requested, _ := ctx.Value(mfaMethodContextKey{}).(string); requested != "" {
+		method = nil
+		for _, m := range candidates {
+			if m.Name() == requested {
+				method = m
+				break
+			}
+		}
+		if method == nil {
+			return nil, ErrMFAMethodUnavailable
+		}
+	}
+	st.MFAMethod = method.Name()
+	result, err := method.Start(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	if result.MFARequired != nil {
+		if result.MFARequired.Method == "" {
+			result.MFARequired.Method = method.Name()
+		}
+		for _, m := range candidates {
+			if _, ok := m.(MFAVerifier); ok {
+				result.MFARequired.AvailableMethods = append(result.MFARequired.AvailableMethods, m.Name())
+			}
+		}
+	}
+	return result, nil
+}
+
+// mfaVerifier returns the registered method that verifies challenges issued by name ("" for challenges created
+// before challenges recorded their method), or nil.
+func (s *AuthService) mfaVerifier(name string) MFAVerifier {
+	if name == "" {
+		name = MFAMethodSMSOTP
+	}
+	for _, m := range s.mfaMethods {
+		if v, ok := m.(MFAVerifier); ok && m.Name() == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// smsOTPMethod sends a one-time code to the user's phone; completed with VerifyMFA.
+type smsOTPMethod struct{ s *AuthService }
+
+func (smsOTPMethod) Name() string { return MFAMethodSMSOTP }
+
+func (smsOTPMethod) Available(st *FlowState) bool { return strings.TrimSpace(st.User.Phone) != "" }
+
+func (m smsOTPMethod) Start(ctx context.Context, st *FlowState) (*LoginResult, error) {
+	phone := strings.TrimSpace(st.User.Phone)
+	challenge, err := m.s.sendSMSChallenge(ctx, st.UserID, st.OrgID, flowDeviceID(st), phone, MFAMethodSMSOTP)
+	if challenge == nil {
+		return nil, err
+	}
+	return &LoginResult{MFARequired: &MFARequiredResult{ChallengeID: challenge.ID, PhoneMask: maskPhone(phone), CodeNotSent: err != nil}}, nil
+}
+
+// Verify checks the code against the challenge. A user without a phone gets the challenge's phone as verified,
+// which enrolls SMS as their MFA factor and issues their first recovery codes.
+func (m smsOTPMethod) Verify(ctx context.Context, st *FlowState, code string) error {
+	if !mfa.OTPEqual(code, st.Challenge.CodeHash) {
+		return ErrInvalidOTP
+	}
+	if st.User != nil && st.User.Phone == "" {
+		_ = m.s.userRepo.SetPhoneVerified(ctx, st.Challenge.UserID, st.Challenge.Phone)
+		m.s.enrollRecoveryCodes(ctx, st)
+	}
+	return nil
+}
+
+// sendSMSChallenge creates a challenge of the given method with a new OTP and sends the OTP to phone (or puts it in
+// the dev OTP store). If sending fails the challenge is kept and returned along with the send error, so the caller
+// can either delete it or let the client send the code again with ResendMFACode; the failure is logged and audited
+// as mfa_code_send_failed. A nil challenge means it was not created.
+func (s *AuthService) sendSMSChallenge(ctx context.Context, userID, orgID, deviceID, phone, method string) (*mfadomain.Challenge, error) {
+	otp, err := mfa.GenerateOTP()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	challenge := &mfadomain.Challenge{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		OrgID:     orgID,
+		DeviceID:  deviceID,
+		Phone:     phone,
+		CodeHash:  mfa.HashOTP(otp),
+		ExpiresAt: now.Add(s.mfaChallengeTTL),
+		CreatedAt: now,
+		Method:    method,
+		TrustDays: trustDaysFromContext(ctx),
+	}
+	if err := s.mfaChallengeRepo.Create(ctx, challenge); err != nil {
+		return nil, err
+	}
+	if s.otpReturnToClient && s.devOTPStore != nil {
+		s.devOTPStore.Put(ctx, challenge.ID, otp, challenge.ExpiresAt)
+	} else if s.smsSender != nil {
+		if err := s.sendOTP(ctx, orgID, userID, phone, otp, challenge.ExpiresAt.Sub(now)); err != nil {
+			log.Printf("auth: challenge_id=%s failed to send MFA code: %v", challenge.ID, err)
+			if s.auditLogger != nil {
+				s.auditLogger.LogEvent(ctx, orgID, userID, "mfa_code_send_failed", "authentication", `{"method":"`+method+`"}`)
+			}
+			return challenge, err
+		}
+	}
+	return challenge, nil
+}
+
+// Resend sends a new code to the challenge's phone.
+func (m smsOTPMethod) Resend(ctx context.Context, c *mfadomain.Challenge) error {
+	s := m.s
+	otp, err := mfa.GenerateOTP()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	expiresAt := now.Add(s.mfaChallengeTTL)
+	ok, err := s.mfaChallengeRepo.Resend(ctx, c.ID, c.ResendCount, mfa.HashOTP(otp), now, expiresAt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrMFAResendCooldown
+	}
+	if s.otpReturnToClient && s.devOTPStore != nil {
+		s.devOTPStore.Put(ctx, c.ID, otp, expiresAt)
+	} else if s.smsSender != nil {
+		return s.sendOTP(ctx, c.OrgID, c.UserID, c.Phone, otp, expiresAt.Sub(now))
+	}
+	return nil
+}
+
+// sendOTP texts otp to phone, with the org's otp_sms template when it has one (see WithOTPTemplates) and through the
+// SMS provider's OTP route otherwise.
+func (s *AuthService) sendOTP(ctx context.Context, orgID, userID, phone, otp string, ttl time.Duration) error {
+	if sms, ok := s.smsSender.(notification.SMSSender); ok && s.otpTemplates != nil {
+		msg, custom := s.otpTemplates.Render(ctx, orgID, userID, notiftemplatedomain.KindOTPSMS, map[string]string{
+			"code":    otp,
+			"minutes": strconv.Itoa(int(math.Ceil(ttl.Minutes()))),
+		})
+		if custom {
+			return sms.SendSMS(phone, msg.Body)
+		}
+	}
+	return s.smsSender.SendOTP(phone, otp)
+}
+
+// phoneEnrollmentMethod asks a user without a phone to add one: it returns an intent the client completes with
+// SubmitPhoneAndRequestMFA, which then sends an OTP. It is not offered on a public device, where enrolling a phone
+// would leave a stranger's number as the user's factor and the intent has no device to carry.
+type phoneEnrollmentMethod struct{ s *AuthService }
+
+func (phoneEnrollmentMethod) Name() string { return MFAMethodPhoneEnrollment }
+
+func (m phoneEnrollmentMethod) Available(st *FlowState) bool {
+	return m.s.mfaIntentRepo != nil && !st.PublicDevice && strings.TrimSpace(st.User.Phone) == ""
+}
+
+func (m phoneEnrollmentMethod) Start(ctx context.Context, st *FlowState) (*LoginResult, error) {
+	intent := &mfaintentdomain.Intent{
+		ID:        uuid.New().String(),
+		UserID:    st.UserID,
+		OrgID:     st.OrgID,
+		DeviceID:  st.Device.ID,
+		ExpiresAt: time.Now().UTC().Add(m.s.mfaChallengeTTL),
+	}
+	if err := m.s.mfaIntentRepo.Create(ctx, intent); err != nil {
+		return nil, err
+	}
+	return &LoginResult{PhoneRequired: &PhoneRequiredResult{IntentID: intent.ID}}, nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
+	notiftemplatedomain "zero-trust-control-plane/backend/internal/notiftemplate/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+)
+
+type fixedMFAMethod struct{ started int }
+
+func (*fixedMFAMethod) Name() string { return "push" }
+
+func (*fixedMFAMethod) Available(*FlowState) bool { return true }
+
+func (m *fixedMFAMethod) Start(ctx context.Context, st *FlowState) (*LoginResult, error) {
+	m.started++
+	return &LoginResult{MFARequired: &MFARequiredResult{ChallengeID: "push-" + st.Device.ID}}, nil
+}
+
+func TestAuthService_WithMFAMethod(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	push := &fixedMFAMethod{}
+	WithMFAMethod(push)(svc)
+	loginFlowFixture(t, svc, "")
+
+	res, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-new")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if push.started != 1 || res.MFARequired == nil || !strings.HasPrefix(res.MFARequired.ChallengeID, "push-") {
+		t.Errorf("Login = %+v (started %d), want the push method's challenge", res, push.started)
+	}
+	if fa := lastFlowAudit(t, auditLogger); fa.MFAMethod != "push" || fa.Result != "mfa_required" {
+		t.Errorf("auth_flow = %+v, want mfa_required via push", fa)
+	}
+}
+
+// codeMFAMethod is a challenge-issuing method whose code is always "246810".
+type codeMFAMethod struct{ s *AuthService }
+
+func (codeMFAMethod) Name() string { return "push" }
+
+func (codeMFAMethod) Available(*FlowState) bool { return true }
+
+func (m codeMFAMethod) Start(ctx context.Context, st *FlowState) (*LoginResult, error) {
+	c := &mfadomain.Challenge{ID: "push-challenge", UserID: st.UserID, OrgID: st.OrgID, DeviceID: st.Device.ID, ExpiresAt: time.Now().Add(time.Minute), Method: "push"}
+	if err := m.s.mfaChallengeRepo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+	return &LoginResult{MFARequired: &MFARequiredResult{ChallengeID: c.ID}}, nil
+}
+
+func (codeMFAMethod) Verify(_ context.Context, _ *FlowState, code string) error {
+	if code != "246810" {
+		return ErrInvalidOTP
+	}
+	return nil
+}
+
+func TestAuthService_MFAMethodSelection(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithMFAMethod(codeMFAMethod{svc})(svc)
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		AuthMfa: &orgpolicyconfigdomain.AuthMfa{AllowedMfaMethods: []string{"sms_otp", "push"}},
+	}})(svc)
+	userID := loginFlowFixture(t, svc, "")
+	userRepo := svc.userRepo.(*memUserRepo)
+	userRepo.mu.Lock()
+	u := *userRepo.byID[userID]
+	u.Phone = "15551234567"
+	userRepo.byID[userID], userRepo.byEmail[u.Email] = &u, &u
+	userRepo.mu.Unlock()
+	ctx := context.Background()
+
+	// Org order puts sms_otp first even though push was registered ahead of it.
+	res, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-new")
+	if err != nil || res.MFARequired == nil {
+		t.Fatalf("Login = %+v, %v; want MFARequired", res, err)
+	}
+	if m := res.MFARequired; m.Method != "sms_otp" || strings.Join(m.AvailableMethods, ",") != "sms_otp,push" {
+		t.Errorf("method/available = %q/%v, want sms_otp/[sms_otp push]", m.Method, m.AvailableMethods)
+	}
+
+	// The user's choice wins, and VerifyMFA checks the code with the method that issued the challenge.
+	res, err = svc.Login(ContextWithMFAMethod(ctx, "push"), "user@example.com", "Password123!abc", "org-1", "fp-new")
+	if err != nil || res.MFARequired == nil || res.MFARequired.Method != "push" {
+		t.Fatalf("Login(push) = %+v, %v; want push challenge", res, err)
+	}
+	if _, err := svc.VerifyMFA(ctx, res.MFARequired.ChallengeID, "000000"); err != ErrInvalidOTP {
+		t.Errorf("VerifyMFA wrong code: want ErrInvalidOTP, got %v", err)
+	}
+	tokens, err := svc.VerifyMFA(ctx, res.MFARequired.ChallengeID, "246810")
+	if err != nil || tokens == nil || tokens.AccessToken == "" {
+		t.Fatalf("VerifyMFA(push) = %+v, %v; want tokens", tokens, err)
+	}
+
+	if _, err := svc.Login(ContextWithMFAMethod(ctx, "totp"), "user@example.com", "Password123!abc", "org-1", "fp-other"); err != ErrMFAMethodUnavailable {
+		t.Errorf("Login(totp): want ErrMFAMethodUnavailable, got %v", err)
+	}
+}
+
+func TestAuthService_MFAMethodSelection_OrgRestricts(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	WithMFAMethod(codeMFAMethod{svc})(svc)
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		AuthMfa: &orgpolicyconfigdomain.AuthMfa{AllowedMfaMethods: []string{"sms_otp"}},
+	}})(svc)
+	loginFlowFixture(t, svc, "")
+	ctx := context.Background()
+
+	// No phone: phone enrollment is allowed along with sms_otp; push is not allowed at all.
+	res, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-new")
+	if err != nil || res.PhoneRequired == nil {
+		t.Fatalf("Login = %+v, %v; want PhoneRequired", res, err)
+	}
+	if _, err := svc.Login(ContextWithMFAMethod(ctx, "push"), "user@example.com", "Password123!abc", "org-1", "fp-new"); err != ErrMFAMethodUnavailable {
+		t.Errorf("Login(push): want ErrMFAMethodUnavailable, got %v", err)
+	}
+}
+
+func TestAuthService_VerifyMFA_UnknownChallengeMethod(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	repo := svc.mfaChallengeRepo.(*memMFAChallengeRepo)
+	_ = repo.Create(context.Background(), &mfadomain.Challenge{ID: "c1", UserID: "u1", OrgID: "org-1", ExpiresAt: time.Now().Add(time.Minute), Method: "webauthn"})
+	if _, err := svc.VerifyMFA(context.Background(), "c1", "123456"); err != ErrInvalidMFAChallenge {
+		t.Errorf("VerifyMFA: want ErrInvalidMFAChallenge for a method this server cannot verify, got %v", err)
+	}
+}
+
+// smsOTPSender records both OTP-route codes and plain SMS.
+type smsOTPSender struct {
+	recordingOTPSender
+	texts []string
+}
+
+func (s *smsOTPSender) SendSMS(phone, message string) error {
+	s.texts = append(s.texts, message)
+	return nil
+}
+
+// orgOTPTemplates has an otp_sms template for org-1 only.
+type orgOTPTemplates struct{}
+
+func (orgOTPTemplates) Render(ctx context.Context, orgID, userID string, kind notiftemplatedomain.Kind, vars map[string]string) (notiftemplatedomain.Message, bool) {
+	if orgID != "org-1" || kind != notiftemplatedomain.KindOTPSMS {
+		return notiftemplatedomain.Message{}, false
+	}
+	return notiftemplatedomain.Message{Body: "Acme code " + vars["code"] + ", valid " + vars["minutes"] + " min"}, true
+}
+
+func TestSendOTP_Template(t *testing.T) {
+	sender := &smsOTPSender{}
+	s := &AuthService{smsSender: sender}
+	WithOTPTemplates(orgOTPTemplates{})(s)
+	ctx := context.Background()
+
+	if err := s.sendOTP(ctx, "org-1", "user-1", "15551234567", "123456", 4*time.Minute+time.Second); err != nil {
+		t.Fatalf("sendOTP: %v", err)
+	}
+	if len(sender.texts) != 1 || sender.texts[0] != "Acme code 123456, valid 5 min" || sender.callCount() != 0 {
+		t.Errorf("texts = %v, OTP route calls = %d; want the template as plain SMS", sender.texts, sender.callCount())
+	}
+	if err := s.sendOTP(ctx, "org-2", "user-2", "15551234567", "654321", 5*time.Minute); err != nil {
+		t.Fatalf("sendOTP: %v", err)
+	}
+	if sender.callCount() != 1 || len(sender.texts) != 1 {
+		t.Errorf("org without template: OTP route calls = %d, texts = %v; want the OTP route", sender.callCount(), sender.texts)
+	}
+
+	// A sender without plain SMS always uses the OTP route.
+	otpOnly := &recordingOTPSender{}
+	s.smsSender = otpOnly
+	if err := s.sendOTP(ctx, "org-1", "user-1", "15551234567", "123456", 5*time.Minute); err != nil || otpOnly.callCount() != 1 {
+		t.Errorf("OTP-only sender: err = %v, calls = %d", err, otpOnly.callCount())
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// maxMFAResetReasonLength bounds the reason copied into the mfa_reset audit event.
+const maxMFAResetReasonLength = 500
+
+// AdminResetMFAResult is returned by AdminResetMFA.
+type AdminResetMFAResult struct {
+	DevicesUntrusted     int
+	RecoveryCodesCleared bool
+}
+
+// AdminResetMFA resets the MFA of a member of the caller's org, e.g. after they lost their phone. The caller must be
+// an owner or admin of the org in ctx. The user's phone and phone verification are cleared, along with their
+// recovery codes and pending MFA challenges; all their sessions are revoked and all their devices untrusted, and
+// their next sign-in requires MFA with a newly enrolled phone. Because the phone is shared across orgs, sessions
+// and devices in every org are affected. reason is recorded in the audit event.
+func (s *AuthService) AdminResetMFA(ctx context.Context, targetUserID, reason string) (*AdminResetMFAResult, error) {
+	callerID, orgID, err := s.requireOrgAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID = strings.TrimSpace(targetUserID)
+	if targetUserID == "" {
+		return nil, ErrNotOrgMember
+	}
+	target, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, targetUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrNotOrgMember
+	}
+	usr, err := s.userRepo.GetByID(ctx, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if usr == nil {
+		return nil, ErrNotOrgMember
+	}
+	if _, err := s.userRepo.ResetMFA(ctx, targetUserID); err != nil {
+		return nil, err
+	}
+	if err := s.mfaChallengeRepo.DeleteByUser(ctx, targetUserID); err != nil {
+		log.Printf("auth: user_id=%s failed to delete MFA challenges on reset: %v", targetUserID, err)
+	}
+	result := &AdminResetMFAResult{}
+	if s.recoveryCodes != nil {
+		if err := s.recoveryCodes.Replace(ctx, targetUserID, nil, time.Now().UTC()); err != nil {
+			log.Printf("auth: user_id=%s failed to clear recovery codes on MFA reset: %v", targetUserID, err)
+		} else {
+			result.RecoveryCodesCleared = true
+		}
+	}
+	if err := s.sessionRepo.RevokeAllSessionsByUser(ctx, targetUserID, sessiondomain.Revocation{Reason: sessiondomain.RevocationAdminRevoke, By: callerID}); err != nil {
+		return nil, err
+	}
+	result.DevicesUntrusted = s.untrustDevices(ctx, targetUserID, nil)
+
+	reason = strings.TrimSpace(reason)
+	reason = truncate(reason, maxMFAResetReasonLength)
+	metadata, _ := json.Marshal(struct {
+		TargetUserID         string `json:"target_user_id"`
+		TargetRole           string `json:"target_role"`
+		Reason               string `json:"reason,omitempty"`
+		HadPhone             bool   `json:"had_phone"`
+		PhoneWasVerified     bool   `json:"phone_was_verified"`
+		RecoveryCodesCleared bool   `json:"recovery_codes_cleared"`
+		SessionsRevoked      bool   `json:"sessions_revoked"`
+		DevicesUntrusted     int    `json:"devices_untrusted"`
+	}{targetUserID, string(target.Role), reason, usr.Phone != "", usr.PhoneVerified, result.RecoveryCodesCleared, true, result.DevicesUntrusted})
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, callerID, "mfa_reset", "authentication", string(metadata))
+	}
+	s.recordSecurityEvent(ctx, orgID, targetUserID, securityeventdomain.EventMFAReset, `{"reset_by":"`+callerID+`"}`)
+	return result, nil
+}
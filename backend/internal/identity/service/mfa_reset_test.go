@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+func TestAuthService_AdminResetMFA(t *testing.T) {
+	svc, sessionRepo, devStore := newTestAuthServiceOpt(t, true)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	recorder := &memSecurityEventRecorder{}
+	WithSecurityEventRecorder(recorder)(svc)
+	recoveryRepo := &memRecoveryCodeRepo{}
+	WithRecoveryCodes(recoveryRepo)(svc)
+	memberCtx := phoneChangeFixture(t, svc, sessionRepo)
+	userID, _ := interceptors.GetUserID(memberCtx)
+	_ = recoveryRepo.Replace(memberCtx, userID, []string{"hash-1"}, time.Now())
+	pending, err := svc.StartPhoneChange(memberCtx, "15559876543")
+	if err != nil {
+		t.Fatalf("StartPhoneChange: %v", err)
+	}
+
+	admin, err := svc.Register(context.Background(), "admin@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register admin: %v", err)
+	}
+	membershipRepo := svc.membershipRepo.(*memMembershipRepo)
+	membershipRepo.mu.Lock()
+	membershipRepo.m["m2"] = &membershipdomain.Membership{ID: "m2", UserID: admin.UserID, OrgID: "org-1", Role: membershipdomain.RoleAdmin, CreatedAt: time.Now()}
+	membershipRepo.mu.Unlock()
+	adminCtx := interceptors.WithIdentity(context.Background(), admin.UserID, "org-1", "admin-session")
+
+	if _, err := svc.AdminResetMFA(memberCtx, admin.UserID, ""); err != ErrOrgAdminRequired {
+		t.Errorf("member caller: want ErrOrgAdminRequired, got %v", err)
+	}
+	if _, err := svc.AdminResetMFA(interceptors.WithIdentity(context.Background(), admin.UserID, "org-2", "s"), userID, ""); err != ErrOrgAdminRequired {
+		t.Errorf("admin of another org: want ErrOrgAdminRequired, got %v", err)
+	}
+	if _, err := svc.AdminResetMFA(adminCtx, "unknown-user", ""); err != ErrNotOrgMember {
+		t.Errorf("non-member target: want ErrNotOrgMember, got %v", err)
+	}
+
+	res, err := svc.AdminResetMFA(adminCtx, userID, "ticket 42: lost phone")
+	if err != nil {
+		t.Fatalf("AdminResetMFA: %v", err)
+	}
+	if res.DevicesUntrusted != 2 || !res.RecoveryCodesCleared {
+		t.Errorf("AdminResetMFA = %+v, want 2 devices untrusted and recovery codes cleared", res)
+	}
+	u, _ := svc.userRepo.GetByID(context.Background(), userID)
+	if u.Phone != "" || u.PhoneVerified || !u.MFAResetRequired {
+		t.Errorf("user = %+v, want phone cleared and MFA reset required", u)
+	}
+	if sess, _ := sessionRepo.GetByID(context.Background(), "s1"); sess.RevokedAt == nil {
+		t.Error("session not revoked")
+	}
+	if n, _ := recoveryRepo.CountUnused(context.Background(), userID); n != 0 {
+		t.Errorf("%d recovery codes left, want 0", n)
+	}
+	if c, _ := svc.mfaChallengeRepo.GetByID(context.Background(), pending.ChallengeID); c != nil {
+		t.Error("pending challenge not deleted")
+	}
+	auditLogger.mu.Lock()
+	var metadata string
+	for _, e := range auditLogger.events {
+		if e.action == "mfa_reset" {
+			metadata = e.metadata
+		}
+	}
+	auditLogger.mu.Unlock()
+	if !strings.Contains(metadata, `"target_user_id":"`+userID+`"`) || !strings.Contains(metadata, `"reason":"ticket 42: lost phone"`) {
+		t.Errorf("mfa_reset metadata = %q, want target user and reason", metadata)
+	}
+	if n := len(recorder.types); n == 0 || recorder.types[n-1] != securityeventdomain.EventMFAReset {
+		t.Errorf("security events = %v, want last mfa_reset", recorder.types)
+	}
+
+	// The next sign-in, even on a known device, requires enrolling a phone; verifying it clears the reset.
+	login, err := svc.Login(context.Background(), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || login.PhoneRequired == nil {
+		t.Fatalf("Login after reset = %+v, %v; want PhoneRequired", login, err)
+	}
+	mfaRes, err := svc.SubmitPhoneAndRequestMFA(context.Background(), login.PhoneRequired.IntentID, "15559876543")
+	if err != nil {
+		t.Fatalf("SubmitPhoneAndRequestMFA: %v", err)
+	}
+	otp, _ := devStore.Get(context.Background(), mfaRes.ChallengeID)
+	if _, err := svc.VerifyMFA(context.Background(), mfaRes.ChallengeID, otp); err != nil {
+		t.Fatalf("VerifyMFA: %v", err)
+	}
+	if u, _ := svc.userRepo.GetByID(context.Background(), userID); u.Phone != "15559876543" || u.MFAResetRequired {
+		t.Errorf("user after re-enrollment = %+v, want new phone and reset cleared", u)
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	orgdiscoverydomain "zero-trust-control-plane/backend/internal/orgdiscovery/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// Next steps of a sign-in screen after DiscoverOrganizations (OrgDiscoveryResult.NextStep).
+const (
+	// DiscoveryNextPassword asks for the password; Organizations holds the org that verified the email's domain, if any.
+	DiscoveryNextPassword = "password"
+	// DiscoveryNextChooseOrg lets the user pick one of Organizations, the orgs the account may sign in to, for Login.
+	DiscoveryNextChooseOrg = "choose_org"
+)
+
+// OrgDirectory returns the orgs offered by DiscoverOrganizations (e.g. *orgdiscoveryrepo.PostgresRepository).
+// Suspended orgs are never returned.
+type OrgDirectory interface {
+	Get(ctx context.Context, orgID string) (*orgdiscoverydomain.Org, error)
+	ListByUser(ctx context.Context, userID string) ([]*orgdiscoverydomain.Org, error)
+}
+
+// WithOrgDiscovery enables DiscoverOrganizations, rate-limited per client IP and per normalized email; rejected
+// attempts return ErrRateLimited. Either limiter may be nil. The org of an email's verified domain is only found
+// with WithRegistrationControls.
+func WithOrgDiscovery(orgs OrgDirectory, perIP, perEmail RateLimiter) Option {
+	return func(s *AuthService) {
+		s.discoveryOrgs, s.discoveryIPLimiter, s.discoveryEmailLimiter = orgs, perIP, perEmail
+		if perIP == nil {
+			s.discoveryIPLimiter = noLimit{}
+		}
+		if perEmail == nil {
+			s.discoveryEmailLimiter = noLimit{}
+		}
+	}
+}
+
+// OrgDiscoveryResult is the result of DiscoverOrganizations.
+type OrgDiscoveryResult struct {
+	NextStep      string
+	Organizations []*orgdiscoverydomain.Org
+}
+
+// DiscoverOrganizations finds the orgs to offer a sign-in screen that asks only for the email (or username) first.
+// Without a password, it does not look at the account: the result is DiscoveryNextPassword with the org that
+// verified the email's domain, the same for every email of the domain, registered or not. With a password, the
+// credentials are checked like VerifyCredentials (with its limits, audit events and ErrInvalidCredentials for any
+// failure, including a disabled account) and the result is DiscoveryNextChooseOrg with the orgs the user is a member
+// of. Attempts are rate-limited per client IP and per email (ErrRateLimited).
+func (s *AuthService) DiscoverOrganizations(ctx context.Context, email, password string) (*OrgDiscoveryResult, error) {
+	if s.discoveryOrgs == nil {
+		return nil, ErrOrgDiscoveryDisabled
+	}
+	email = strings.TrimSpace(strings.ToLower(email))
+	if email == "" {
+		return nil, ErrInvalidCredentials
+	}
+	if s.ipBlocked(ctx) {
+		return nil, ErrIPBlocked
+	}
+	if !s.discoveryIPLimiter.Allow(interceptors.ClientIP(ctx)) || !s.discoveryEmailLimiter.Allow(email) {
+		return nil, ErrRateLimited
+	}
+	if password == "" {
+		orgs, err := s.domainOrgs(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		return &OrgDiscoveryResult{NextStep: DiscoveryNextPassword, Organizations: orgs}, nil
+	}
+	res, err := s.VerifyCredentials(ctx, email, password, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if !res.Valid {
+		return nil, ErrInvalidCredentials
+	}
+	orgs, err := s.discoveryOrgs.ListByUser(ctx, res.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &OrgDiscoveryResult{NextStep: DiscoveryNextChooseOrg, Organizations: orgs}, nil
+}
+
+// domainOrgs returns the org that verified the domain of email, if it is active, or none. It depends only on the
+// domain, so it reveals nothing about the account.
+func (s *AuthService) domainOrgs(ctx context.Context, email string) ([]*orgdiscoverydomain.Org, error) {
+	_, domainName, ok := strings.Cut(email, "@")
+	if !ok || s.registrationDomains == nil {
+		return []*orgdiscoverydomain.Org{}, nil
+	}
+	claim, err := s.registrationDomains.GetVerified(ctx, domainName)
+	if err != nil || claim == nil {
+		return []*orgdiscoverydomain.Org{}, err
+	}
+	org, err := s.discoveryOrgs.Get(ctx, claim.OrgID)
+	if err != nil || org == nil {
+		return []*orgdiscoverydomain.Org{}, err
+	}
+	return []*orgdiscoverydomain.Org{org}, nil
+}
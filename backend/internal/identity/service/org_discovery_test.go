@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	orgdiscoverydomain "zero-trust-control-plane/backend/internal/orgdiscovery/domain"
+	"zero-trust-control-plane/backend/internal/platform/ratelimit"
+)
+
+// memOrgDirectory holds active orgs and the org IDs of each user's memberships.
+type memOrgDirectory struct {
+	orgs    map[string]*orgdiscoverydomain.Org
+	members map[string][]string
+}
+
+func (d *memOrgDirectory) Get(ctx context.Context, orgID string) (*orgdiscoverydomain.Org, error) {
+	return d.orgs[orgID], nil
+}
+
+func (d *memOrgDirectory) ListByUser(ctx context.Context, userID string) ([]*orgdiscoverydomain.Org, error) {
+	var out []*orgdiscoverydomain.Org
+	for _, id := range d.members[userID] {
+		if o := d.orgs[id]; o != nil {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+func TestAuthService_DiscoverOrganizations(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	reg, err := svc.Register(context.Background(), "ada@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	dir := &memOrgDirectory{
+		orgs: map[string]*orgdiscoverydomain.Org{
+			"org-acme": {ID: "org-acme", Name: "Acme"},
+			"org-1":    {ID: "org-1", Name: "One"},
+		},
+		members: map[string][]string{reg.UserID: {"org-1", "org-suspended"}},
+	}
+	WithOrgDiscovery(dir, nil, nil)(svc)
+	WithRegistrationControls(nil, verifiedDomains{"acme.test": "org-acme", "example.com": "org-suspended"}, nil)(svc)
+	ctx := context.Background()
+
+	// Without a password the result depends only on the domain.
+	for email, want := range map[string]int{"ada@example.com": 0, "nobody@example.com": 0, "ada@acme.test": 1, "nobody@acme.test": 1, "ada": 0} {
+		res, err := svc.DiscoverOrganizations(ctx, email, "")
+		if err != nil || res.NextStep != DiscoveryNextPassword || len(res.Organizations) != want {
+			t.Errorf("DiscoverOrganizations(%q) = %+v, %v; want %s with %d orgs", email, res, err, DiscoveryNextPassword, want)
+		}
+	}
+
+	res, err := svc.DiscoverOrganizations(ctx, " ADA@example.com ", "Password123!abc")
+	if err != nil || res.NextStep != DiscoveryNextChooseOrg || len(res.Organizations) != 1 || res.Organizations[0].ID != "org-1" {
+		t.Fatalf("with the password = %+v, %v; want org-1 to choose", res, err)
+	}
+	for _, email := range []string{"ada@example.com", "nobody@example.com"} {
+		if _, err := svc.DiscoverOrganizations(ctx, email, "WrongPassword123!"); err != ErrInvalidCredentials {
+			t.Errorf("wrong password for %s: want ErrInvalidCredentials, got %v", email, err)
+		}
+	}
+	if _, err := svc.DiscoverOrganizations(ctx, " ", ""); err != ErrInvalidCredentials {
+		t.Errorf("empty email: want ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestAuthService_DiscoverOrganizations_RateLimitedAndDisabled(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	if _, err := svc.DiscoverOrganizations(context.Background(), "ada@example.com", ""); err != ErrOrgDiscoveryDisabled {
+		t.Errorf("disabled: want ErrOrgDiscoveryDisabled, got %v", err)
+	}
+	WithOrgDiscovery(&memOrgDirectory{}, nil, ratelimit.NewLimiter(2, time.Minute))(svc)
+	for i := 0; i < 2; i++ {
+		if _, err := svc.DiscoverOrganizations(context.Background(), "ada@example.com", ""); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+	}
+	if _, err := svc.DiscoverOrganizations(context.Background(), "ADA@example.com", ""); err != ErrRateLimited {
+		t.Errorf("third attempt: want ErrRateLimited, got %v", err)
+	}
+	if _, err := svc.DiscoverOrganizations(context.Background(), "grace@example.com", ""); err != nil {
+		t.Errorf("another email: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"zero-trust-control-plane/backend/internal/breachedpassword"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// WithBreachedPasswordCheck checks new passwords (Register, ChangePassword) against checker. defaultMode applies to
+// Register and to orgs whose password_policy does not set a mode.
+func WithBreachedPasswordCheck(checker breachedpassword.Checker, defaultMode breachedpassword.Mode) Option {
+	return func(s *AuthService) {
+		s.breachChecker = checker
+		s.breachDefaultMode = defaultMode
+	}
+}
+
+// ChangePasswordResult is the outcome of ChangePassword.
+type ChangePasswordResult struct {
+	PasswordBreached bool // the new password is in a breach corpus and policy is warn
+}
+
+// ChangePassword replaces the caller's local password. The caller is identified by the access token in ctx and must
+// supply the current password. The new password must meet the strength policy and, per the org's password_policy,
+// may be rejected (ErrBreachedPassword) or accepted with a warning when it appears in a breach corpus.
+func (s *AuthService) ChangePassword(ctx context.Context, currentPassword, newPassword string) (*ChangePasswordResult, error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, ErrInvalidCredentials
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	ident, err := s.identityRepo.GetByUserAndProvider(ctx, userID, identitydomain.IdentityProviderLocal)
+	if err != nil {
+		return nil, err
+	}
+	if ident == nil || ident.PasswordHash == "" {
+		return nil, ErrInvalidCredentials
+	}
+	if err := s.hasher.Compare(ident.PasswordHash, []byte(currentPassword)); err != nil {
+		if s.auditLogger != nil {
+			s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "password_change_failure", "authentication", "")
+		}
+		return nil, ErrInvalidCredentials
+	}
+	if err := validatePassword(newPassword); err != nil {
+		return nil, err
+	}
+	breached, err := s.checkBreachedPassword(ctx, orgID, userID, "change_password", newPassword)
+	if err != nil {
+		return nil, err
+	}
+	hashed, err := s.hasher.Hash([]byte(newPassword))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.identityRepo.UpdatePasswordHash(ctx, ident.ID, hashed); err != nil {
+		return nil, err
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "password_changed", "authentication", "")
+	}
+	return &ChangePasswordResult{PasswordBreached: breached}, nil
+}
+
+// checkBreachedPassword runs the breached-password check for flow under the org's mode (the platform default when
+// orgID is empty or the org sets none). It returns ErrBreachedPassword when the password is breached and the mode is
+// block, and breached=true when it is breached and the mode is warn. Checker errors fail open. Every check is
+// audited as password_breach_check with the outcome only; the password and its hash are never logged.
+func (s *AuthService) checkBreachedPassword(ctx context.Context, orgID, userID, flow, password string) (bool, error) {
+	if s.breachChecker == nil {
+		return false, nil
+	}
+	mode := s.breachedPasswordMode(ctx, orgID)
+	if mode == breachedpassword.ModeOff {
+		return false, nil
+	}
+	breached, err := s.breachChecker.Breached(ctx, password)
+	result := strconv.FormatBool(breached)
+	if err != nil {
+		log.Printf("auth: breached password check failed: %v", err)
+		breached, result = false, `"error"`
+	}
+	if s.auditLogger != nil {
+		metadata := `{"flow":"` + flow + `","mode":"` + string(mode) + `","breached":` + result + `}`
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "password_breach_check", "authentication", metadata)
+	}
+	if breached && mode == breachedpassword.ModeBlock {
+		return false, ErrBreachedPassword
+	}
+	return breached, nil
+}
+
+// breachedPasswordMode returns the org's password_policy mode, falling back to the platform default.
+func (s *AuthService) breachedPasswordMode(ctx context.Context, orgID string) breachedpassword.Mode {
+	if orgID != "" && s.orgPolicyConfigRepo != nil {
+		cfg, err := s.orgPolicyConfigRepo.GetByOrgID(ctx, orgID)
+		if err == nil && cfg != nil && cfg.PasswordPolicy != nil {
+			if mode, err := breachedpassword.ParseMode(cfg.PasswordPolicy.BreachedPasswordMode); err == nil {
+				return mode
+			}
+		}
+	}
+	if s.breachDefaultMode == "" {
+		return breachedpassword.ModeWarn
+	}
+	return s.breachDefaultMode
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zero-trust-control-plane/backend/internal/breachedpassword"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type stubBreachChecker struct {
+	breached map[string]bool
+	err      error
+}
+
+func (c stubBreachChecker) Breached(ctx context.Context, password string) (bool, error) {
+	return c.breached[password], c.err
+}
+
+func TestAuthService_Register_BreachedPassword(t *testing.T) {
+	checker := stubBreachChecker{breached: map[string]bool{"Password123!abc": true}}
+
+	svc, _ := newTestAuthService(t)
+	WithBreachedPasswordCheck(checker, breachedpassword.ModeWarn)(svc)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	res, err := svc.Register(context.Background(), "warn@example.com", "Password123!abc", "")
+	if err != nil || !res.PasswordBreached {
+		t.Fatalf("Register under warn: want PasswordBreached, got res=%+v err=%v", res, err)
+	}
+	if !auditLogger.hasAction("password_breach_check") {
+		t.Error("breach check should be audited as password_breach_check")
+	}
+	res, err = svc.Register(context.Background(), "clean@example.com", "Different123!abc", "")
+	if err != nil || res.PasswordBreached {
+		t.Errorf("Register with clean password: res=%+v err=%v", res, err)
+	}
+
+	svc, _ = newTestAuthService(t)
+	WithBreachedPasswordCheck(checker, breachedpassword.ModeBlock)(svc)
+	if _, err := svc.Register(context.Background(), "block@example.com", "Password123!abc", ""); err != ErrBreachedPassword {
+		t.Errorf("Register under block: want ErrBreachedPassword, got %v", err)
+	}
+
+	svc, _ = newTestAuthService(t)
+	WithBreachedPasswordCheck(stubBreachChecker{err: errors.New("unavailable")}, breachedpassword.ModeBlock)(svc)
+	if _, err := svc.Register(context.Background(), "failopen@example.com", "Password123!abc", ""); err != nil {
+		t.Errorf("Register with checker error should fail open, got %v", err)
+	}
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	reg, err := svc.Register(context.Background(), "user@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	ctx := interceptors.WithIdentity(context.Background(), reg.UserID, "org-1", "session-1")
+
+	if _, err := svc.ChangePassword(context.Background(), "Password123!abc", "Changed123!abc"); err != ErrInvalidCredentials {
+		t.Errorf("ChangePassword without identity: want ErrInvalidCredentials, got %v", err)
+	}
+	if _, err := svc.ChangePassword(ctx, "Wrong123!abc", "Changed123!abc"); err != ErrInvalidCredentials {
+		t.Errorf("ChangePassword with wrong current password: want ErrInvalidCredentials, got %v", err)
+	}
+	if !auditLogger.hasAction("password_change_failure") {
+		t.Error("wrong current password should be audited as password_change_failure")
+	}
+	if _, err := svc.ChangePassword(ctx, "Password123!abc", "short"); err == nil {
+		t.Error("ChangePassword with weak password: expected validation error")
+	}
+	res, err := svc.ChangePassword(ctx, "Password123!abc", "Changed123!abc")
+	if err != nil || res.PasswordBreached {
+		t.Fatalf("ChangePassword: res=%+v err=%v", res, err)
+	}
+	if !auditLogger.hasAction("password_changed") {
+		t.Error("successful change should be audited as password_changed")
+	}
+	if _, err := svc.ChangePassword(ctx, "Password123!abc", "Another123!abc"); err != ErrInvalidCredentials {
+		t.Errorf("old password should no longer be accepted, got %v", err)
+	}
+}
+
+func TestAuthService_ChangePassword_OrgBreachedPasswordMode(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	reg, err := svc.Register(context.Background(), "user@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	WithBreachedPasswordCheck(stubBreachChecker{breached: map[string]bool{"Breached123!abc": true}}, breachedpassword.ModeWarn)(svc)
+	ctx := interceptors.WithIdentity(context.Background(), reg.UserID, "org-1", "session-1")
+
+	res, err := svc.ChangePassword(ctx, "Password123!abc", "Breached123!abc")
+	if err != nil || !res.PasswordBreached {
+		t.Fatalf("ChangePassword under platform warn: want PasswordBreached, got res=%+v err=%v", res, err)
+	}
+
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		PasswordPolicy: &orgpolicyconfigdomain.PasswordPolicy{BreachedPasswordMode: "block"},
+	}})(svc)
+	if _, err := svc.ChangePassword(ctx, "Breached123!abc", "Breached123!abc"); err != ErrBreachedPassword {
+		t.Errorf("ChangePassword under org block: want ErrBreachedPassword, got %v", err)
+	}
+
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		PasswordPolicy: &orgpolicyconfigdomain.PasswordPolicy{BreachedPasswordMode: "off"},
+	}})(svc)
+	res, err = svc.ChangePassword(ctx, "Breached123!abc", "Breached123!abc")
+	if err != nil || res.PasswordBreached {
+		t.Errorf("ChangePassword under org off: res=%+v err=%v", res, err)
+	}
+}
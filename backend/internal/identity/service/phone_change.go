@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/mfa"
+	mfadomain "zero-trust-control-plane/backend/internal/mfa/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// MFAMethodPhoneChange is the method of the challenges StartPhoneChange issues. They are completed only by
+// ConfirmPhoneChange; VerifyMFA rejects them.
+const MFAMethodPhoneChange = "phone_change"
+
+// PhoneChangeResult is returned by StartPhoneChange. CurrentPhoneChallengeID is set when the org requires step-up
+// for sensitive actions and the user has a phone: a code was then also sent to the current number.
+type PhoneChangeResult struct {
+	ChallengeID             string
+	PhoneMask               string
+	CurrentPhoneChallengeID string
+	CurrentPhoneMask        string
+}
+
+// ConfirmPhoneChangeResult is returned by ConfirmPhoneChange.
+type ConfirmPhoneChangeResult struct {
+	PhoneMask        string
+	DevicesUntrusted int
+}
+
+// StartPhoneChange sends a code to newPhone, which ConfirmPhoneChange must be given to make it the caller's phone.
+// When the caller's org has step_up_sensitive_actions enabled, a code is also sent to the current phone. The caller
+// is identified by the access token in ctx.
+func (s *AuthService) StartPhoneChange(ctx context.Context, newPhone string) (*PhoneChangeResult, error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, ErrInvalidCredentials
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	sessionID, _ := interceptors.GetSessionID(ctx)
+	newPhone = strings.TrimSpace(newPhone)
+	if err := validatePhone(newPhone); err != nil {
+		return nil, err
+	}
+	usr, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if usr == nil {
+		return nil, ErrInvalidCredentials
+	}
+	currentPhone := strings.TrimSpace(usr.Phone)
+	if newPhone == currentPhone {
+		return nil, ErrPhoneUnchanged
+	}
+	sess, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sess == nil || sess.UserID != userID {
+		return nil, ErrInvalidCredentials
+	}
+	challenge, err := s.sendSMSChallenge(ctx, userID, sess.OrgID, sess.DeviceID, newPhone, MFAMethodPhoneChange)
+	if err != nil {
+		if challenge != nil {
+			_ = s.mfaChallengeRepo.Delete(ctx, challenge.ID)
+		}
+		return nil, err
+	}
+	result := &PhoneChangeResult{ChallengeID: challenge.ID, PhoneMask: maskPhone(newPhone)}
+	if currentPhone != "" && s.stepUpRequired(ctx, sess.OrgID, "phone change") {
+		current, err := s.sendSMSChallenge(ctx, userID, sess.OrgID, sess.DeviceID, currentPhone, MFAMethodPhoneChange)
+		if err != nil {
+			if current != nil {
+				_ = s.mfaChallengeRepo.Delete(ctx, current.ID)
+			}
+			_ = s.mfaChallengeRepo.Delete(ctx, challenge.ID)
+			return nil, err
+		}
+		result.CurrentPhoneChallengeID, result.CurrentPhoneMask = current.ID, maskPhone(currentPhone)
+	}
+	if s.auditLogger != nil {
+		metadata := `{"step_up":` + strconv.FormatBool(result.CurrentPhoneChallengeID != "") + `}`
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "phone_change_started", "authentication", metadata)
+	}
+	return result, nil
+}
+
+// ConfirmPhoneChange checks the code sent to the new phone (and to the current phone when StartPhoneChange sent
+// one) and makes the new phone the caller's verified MFA phone. The update only applies if the phone has not
+// changed since StartPhoneChange. Afterwards the user's trusted devices are untrusted in every org whose
+// device_trust policy does not set keep_trust_on_factor_change, so they need MFA with the new phone at next sign-in.
+func (s *AuthService) ConfirmPhoneChange(ctx context.Context, challengeID, otp, currentPhoneChallengeID, currentPhoneOTP string) (*ConfirmPhoneChangeResult, error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, ErrInvalidCredentials
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	usr, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if usr == nil {
+		return nil, ErrInvalidCredentials
+	}
+	oldPhone := strings.TrimSpace(usr.Phone)
+	challenge, err := s.phoneChangeChallenge(ctx, userID, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Phone == oldPhone {
+		return nil, ErrInvalidMFAChallenge
+	}
+	var current *mfadomain.Challenge
+	if oldPhone != "" && s.stepUpRequired(ctx, challenge.OrgID, "phone change") {
+		if current, err = s.phoneChangeChallenge(ctx, userID, currentPhoneChallengeID); err != nil {
+			return nil, err
+		}
+		if current.Phone != oldPhone {
+			return nil, ErrInvalidMFAChallenge
+		}
+	}
+	if !mfa.OTPEqual(strings.TrimSpace(otp), challenge.CodeHash) {
+		return nil, s.wrongMFACode(ctx, challenge)
+	}
+	if current != nil && !mfa.OTPEqual(strings.TrimSpace(currentPhoneOTP), current.CodeHash) {
+		return nil, s.wrongMFACode(ctx, current)
+	}
+	changed, err := s.userRepo.ChangePhone(ctx, userID, oldPhone, challenge.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return nil, ErrInvalidMFAChallenge
+	}
+	_ = s.mfaChallengeRepo.Delete(ctx, challenge.ID)
+	if current != nil {
+		_ = s.mfaChallengeRepo.Delete(ctx, current.ID)
+	}
+	untrusted := 0
+	if oldPhone != "" {
+		untrusted = s.untrustDevicesOnFactorChange(ctx, userID)
+	}
+	metadata := `{"devices_untrusted":` + strconv.Itoa(untrusted) + `,"step_up":` + strconv.FormatBool(current != nil) + `}`
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "phone_changed", "authentication", metadata)
+	}
+	s.recordSecurityEvent(ctx, orgID, userID, securityeventdomain.EventPhoneChanged, metadata)
+	return &ConfirmPhoneChangeResult{PhoneMask: maskPhone(challenge.Phone), DevicesUntrusted: untrusted}, nil
+}
+
+// phoneChangeChallenge returns the caller's unexpired phone_change challenge with the given id.
+func (s *AuthService) phoneChangeChallenge(ctx context.Context, userID, challengeID string) (*mfadomain.Challenge, error) {
+	challengeID = strings.TrimSpace(challengeID)
+	if challengeID == "" {
+		return nil, ErrInvalidMFAChallenge
+	}
+	c, err := s.mfaChallengeRepo.GetByID(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.UserID != userID || c.Method != MFAMethodPhoneChange {
+		return nil, ErrInvalidMFAChallenge
+	}
+	if !c.ExpiresAt.After(time.Now().UTC()) {
+		return nil, ErrChallengeExpired
+	}
+	if s.mfaMaxAttempts > 0 && c.Attempts >= s.mfaMaxAttempts {
+		return nil, ErrMFAAttemptsExceeded
+	}
+	return c, nil
+}
+
+// stepUpRequired reports whether the org's auth_mfa policy requires step-up for sensitive actions, in which case
+// a phone change must also be confirmed from the current phone and LogoutAllMySessions needs a recent confirmation.
+// Step-up is required when the policy cannot be read; action names the caller in the log.
+func (s *AuthService) stepUpRequired(ctx context.Context, orgID, action string) bool {
+	if s.orgPolicyConfigRepo == nil {
+		return false
+	}
+	cfg, err := s.orgPolicyConfigRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		log.Printf("auth: org_id=%s policy lookup for %s failed: %v", orgID, action, err)
+		return true
+	}
+	return orgpolicyconfigdomain.MergeWithDefaults(cfg).AuthMfa.StepUpSensitiveActions
+}
+
+// untrustDevicesOnFactorChange untrusts the user's trusted devices in orgs whose device_trust policy does not set
+// keep_trust_on_factor_change (devices are also untrusted when the policy cannot be read). It returns how many
+// devices were untrusted.
+func (s *AuthService) untrustDevicesOnFactorChange(ctx context.Context, userID string) int {
+	revoke := make(map[string]bool)
+	return s.untrustDevices(ctx, userID, func(orgID string) bool {
+		r, ok := revoke[orgID]
+		if !ok {
+			r = true
+			if s.orgPolicyConfigRepo != nil {
+				if cfg, err := s.orgPolicyConfigRepo.GetByOrgID(ctx, orgID); err == nil {
+					r = !orgpolicyconfigdomain.MergeWithDefaults(cfg).DeviceTrust.KeepTrustOnFactorChange
+				}
+			}
+			revoke[orgID] = r
+		}
+		return r
+	})
+}
+
+// untrustDevices untrusts the user's trusted devices in every org for which untrustIn returns true (all orgs when
+// untrustIn is nil) and returns how many devices were untrusted.
+func (s *AuthService) untrustDevices(ctx context.Context, userID string, untrustIn func(orgID string) bool) int {
+	devices, err := s.deviceRepo.ListTrustedByUser(ctx, userID)
+	if err != nil {
+		log.Printf("auth: user_id=%s failed to list trusted devices: %v", userID, err)
+		return 0
+	}
+	untrusted := 0
+	for _, d := range devices {
+		if untrustIn != nil && !untrustIn(d.OrgID) {
+			continue
+		}
+		if err := s.deviceRepo.UpdateTrustedWithExpiry(ctx, d.ID, false, nil); err != nil {
+			log.Printf("auth: device_id=%s failed to untrust device: %v", d.ID, err)
+			continue
+		}
+		untrusted++
+	}
+	return untrusted
+}
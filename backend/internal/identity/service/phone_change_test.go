@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// phoneChangeFixture registers a user with phone 15551234567, a session "s1" on trusted device d1 in org-1 and a
+// trusted device d2 in org-2, and returns a context identifying the caller.
+func phoneChangeFixture(t *testing.T, svc *AuthService, sessionRepo *memSessionRepo) context.Context {
+	t.Helper()
+	userID := loginFlowFixture(t, svc, "fp-1")
+	userRepo := svc.userRepo.(*memUserRepo)
+	userRepo.mu.Lock()
+	u := *userRepo.byID[userID]
+	u.Phone, u.PhoneVerified = "15551234567", true
+	userRepo.byID[userID], userRepo.byEmail[u.Email] = &u, &u
+	userRepo.mu.Unlock()
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.mu.Lock()
+	deviceRepo.m["d2"] = &devicedomain.Device{ID: "d2", UserID: userID, OrgID: "org-2", Fingerprint: "fp-2", Trusted: true, CreatedAt: time.Now()}
+	deviceRepo.mu.Unlock()
+	sessionRepo.mu.Lock()
+	sessionRepo.m["s1"] = &sessiondomain.Session{ID: "s1", UserID: userID, OrgID: "org-1", DeviceID: "d1", CreatedAt: time.Now()}
+	sessionRepo.mu.Unlock()
+	return interceptors.WithIdentity(context.Background(), userID, "org-1", "s1")
+}
+
+// orgPolicyConfigsByOrg returns the config stored for each org (nil when there is none).
+func TestAuthService_PhoneChange(t *testing.T) {
+	svc, sessionRepo, devStore := newTestAuthServiceOpt(t, true)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	recorder := &memSecurityEventRecorder{}
+	WithSecurityEventRecorder(recorder)(svc)
+	ctx := phoneChangeFixture(t, svc, sessionRepo)
+
+	if _, err := svc.StartPhoneChange(context.Background(), "15559876543"); err != ErrInvalidCredentials {
+		t.Errorf("no caller: want ErrInvalidCredentials, got %v", err)
+	}
+	if _, err := svc.StartPhoneChange(ctx, "15551234567"); err != ErrPhoneUnchanged {
+		t.Errorf("same phone: want ErrPhoneUnchanged, got %v", err)
+	}
+	if _, err := svc.StartPhoneChange(ctx, "12ab"); err == nil {
+		t.Error("invalid phone: want error")
+	}
+	res, err := svc.StartPhoneChange(ctx, "15559876543")
+	if err != nil {
+		t.Fatalf("StartPhoneChange: %v", err)
+	}
+	if res.PhoneMask != "****6543" || res.CurrentPhoneChallengeID != "" {
+		t.Errorf("StartPhoneChange = %+v, want new phone mask and no step-up challenge", res)
+	}
+	if !auditLogger.hasAction("phone_change_started") {
+		t.Error("phone_change_started not audited")
+	}
+	otp, _ := devStore.Get(ctx, res.ChallengeID)
+	if _, err := svc.VerifyMFA(context.Background(), res.ChallengeID, otp); err != ErrInvalidMFAChallenge {
+		t.Errorf("VerifyMFA on phone change challenge: want ErrInvalidMFAChallenge, got %v", err)
+	}
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, "000000", "", ""); err != ErrInvalidOTP {
+		t.Errorf("wrong code: want ErrInvalidOTP, got %v", err)
+	}
+
+	confirmed, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, otp, "", "")
+	if err != nil {
+		t.Fatalf("ConfirmPhoneChange: %v", err)
+	}
+	if confirmed.PhoneMask != "****6543" || confirmed.DevicesUntrusted != 2 {
+		t.Errorf("ConfirmPhoneChange = %+v, want new phone mask and 2 devices untrusted", confirmed)
+	}
+	userID, _ := interceptors.GetUserID(ctx)
+	if u, _ := svc.userRepo.GetByID(ctx, userID); u.Phone != "15559876543" || !u.PhoneVerified {
+		t.Errorf("user phone = %q (verified %v), want 15559876543 verified", u.Phone, u.PhoneVerified)
+	}
+	if trusted, _ := svc.deviceRepo.ListTrustedByUser(ctx, userID); len(trusted) != 0 {
+		t.Errorf("%d devices still trusted, want 0", len(trusted))
+	}
+	if !auditLogger.hasAction("phone_changed") {
+		t.Error("phone_changed not audited")
+	}
+	if n := len(recorder.types); n == 0 || recorder.types[n-1] != securityeventdomain.EventPhoneChanged {
+		t.Errorf("security events = %v, want last phone_changed", recorder.types)
+	}
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, otp, "", ""); err != ErrInvalidMFAChallenge {
+		t.Errorf("reused challenge: want ErrInvalidMFAChallenge, got %v", err)
+	}
+}
+
+func TestAuthService_PhoneChange_StepUp(t *testing.T) {
+	svc, sessionRepo, devStore := newTestAuthServiceOpt(t, true)
+	WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		AuthMfa: &orgpolicyconfigdomain.AuthMfa{StepUpSensitiveActions: true},
+	}})(svc)
+	ctx := phoneChangeFixture(t, svc, sessionRepo)
+
+	res, err := svc.StartPhoneChange(ctx, "15559876543")
+	if err != nil {
+		t.Fatalf("StartPhoneChange: %v", err)
+	}
+	if res.CurrentPhoneChallengeID == "" || res.CurrentPhoneMask != "****4567" {
+		t.Fatalf("StartPhoneChange = %+v, want a challenge for the current phone", res)
+	}
+	otp, _ := devStore.Get(ctx, res.ChallengeID)
+	currentOTP, _ := devStore.Get(ctx, res.CurrentPhoneChallengeID)
+
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, otp, "", ""); err != ErrInvalidMFAChallenge {
+		t.Errorf("without current phone code: want ErrInvalidMFAChallenge, got %v", err)
+	}
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, otp, res.CurrentPhoneChallengeID, "000000"); err != ErrInvalidOTP {
+		t.Errorf("wrong current phone code: want ErrInvalidOTP, got %v", err)
+	}
+	if _, err := svc.ConfirmPhoneChange(ctx, res.CurrentPhoneChallengeID, currentOTP, res.ChallengeID, otp); err != ErrInvalidMFAChallenge {
+		t.Errorf("swapped challenges: want ErrInvalidMFAChallenge, got %v", err)
+	}
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, otp, res.CurrentPhoneChallengeID, currentOTP); err != nil {
+		t.Fatalf("ConfirmPhoneChange: %v", err)
+	}
+}
+
+func TestAuthService_PhoneChange_KeepTrustOnFactorChange(t *testing.T) {
+	svc, sessionRepo, devStore := newTestAuthServiceOpt(t, true)
+	keep := orgpolicyconfigdomain.DefaultDeviceTrust()
+	keep.KeepTrustOnFactorChange = true
+	WithOrgPolicyConfigRepo(orgPolicyConfigsByOrg{"org-2": {DeviceTrust: &keep}})(svc)
+	ctx := phoneChangeFixture(t, svc, sessionRepo)
+
+	res, err := svc.StartPhoneChange(ctx, "15559876543")
+	if err != nil {
+		t.Fatalf("StartPhoneChange: %v", err)
+	}
+	otp, _ := devStore.Get(ctx, res.ChallengeID)
+	confirmed, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, otp, "", "")
+	if err != nil {
+		t.Fatalf("ConfirmPhoneChange: %v", err)
+	}
+	if confirmed.DevicesUntrusted != 1 {
+		t.Errorf("DevicesUntrusted = %d, want 1", confirmed.DevicesUntrusted)
+	}
+	if d, _ := svc.deviceRepo.GetByID(ctx, "d2"); !d.Trusted {
+		t.Error("device in org keeping trust on factor change was untrusted")
+	}
+	if d, _ := svc.deviceRepo.GetByID(ctx, "d1"); d.Trusted {
+		t.Error("device in org-1 still trusted")
+	}
+}
+
+func TestAuthService_PhoneChange_AttemptLimit(t *testing.T) {
+	svc, sessionRepo, devStore := newTestAuthServiceOpt(t, true)
+	WithMFAChallengeLimits(2, DefaultMFAMaxResends, DefaultMFAResendCooldown)(svc)
+	ctx := phoneChangeFixture(t, svc, sessionRepo)
+
+	res, err := svc.StartPhoneChange(ctx, "15559876543")
+	if err != nil {
+		t.Fatalf("StartPhoneChange: %v", err)
+	}
+	otp, _ := devStore.Get(ctx, res.ChallengeID)
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, "000000", "", ""); err != ErrInvalidOTP {
+		t.Fatalf("first wrong code: want ErrInvalidOTP, got %v", err)
+	}
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, "000000", "", ""); err != ErrMFAAttemptsExceeded {
+		t.Fatalf("second wrong code: want ErrMFAAttemptsExceeded, got %v", err)
+	}
+	if _, err := svc.ConfirmPhoneChange(ctx, res.ChallengeID, otp, "", ""); err != ErrInvalidMFAChallenge {
+		t.Errorf("locked challenge: want ErrInvalidMFAChallenge, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+type popKeyContextKey struct{}
+
+type popProofContextKey struct{}
+
+// ContextWithPoPKey returns ctx carrying the client's public JWK. Login and VerifyMFA bind the session they create
+// to the key, after which every Refresh of that session must carry a proof signed with the private key.
+func ContextWithPoPKey(ctx context.Context, jwk string) context.Context {
+	return context.WithValue(ctx, popKeyContextKey{}, strings.TrimSpace(jwk))
+}
+
+// ContextWithPoPProof returns ctx carrying the proof-of-possession JWT that Refresh checks for key-bound sessions.
+func ContextWithPoPProof(ctx context.Context, proof string) context.Context {
+	return context.WithValue(ctx, popProofContextKey{}, strings.TrimSpace(proof))
+}
+
+// popThumbprint returns the thumbprint of the key in ctx, or "" when the client did not register one.
+func popThumbprint(ctx context.Context) (string, error) {
+	jwk, _ := ctx.Value(popKeyContextKey{}).(string)
+	if jwk == "" {
+		return "", nil
+	}
+	return security.PoPKeyThumbprint(jwk)
+}
+
+// CreateRefreshNonce returns a nonce for the proof of the next Refresh of the session the refresh token belongs
+// to. The refresh token must be current; a rotated token returns ErrInvalidRefreshToken (reuse is handled by Refresh).
+func (s *AuthService) CreateRefreshNonce(ctx context.Context, refreshToken string) (string, time.Time, error) {
+	if refreshToken == "" {
+		return "", time.Time{}, ErrInvalidRefreshToken
+	}
+	sessionID, jti, _, _, err := s.tokens.ValidateRefresh(refreshToken)
+	if err != nil {
+		return "", time.Time{}, ErrInvalidRefreshToken
+	}
+	sess, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if sess == nil || sess.RevokedAt != nil || sess.RefreshJti != jti {
+		return "", time.Time{}, ErrInvalidRefreshToken
+	}
+	return s.tokens.IssuePoPNonce(sessionID)
+}
+
+// verifyRefreshProof checks the proof in ctx against the session's bound key. Failures are audited.
+func (s *AuthService) verifyRefreshProof(ctx context.Context, refreshToken, sessionID, thumbprint, orgID, userID string) error {
+	proof, _ := ctx.Value(popProofContextKey{}).(string)
+	if err := s.tokens.VerifyPoPProof(proof, refreshToken, sessionID, thumbprint); err != nil {
+		if s.auditLogger != nil {
+			s.auditLogger.LogEvent(ctx, orgID, userID, "refresh_pop_failure", "authentication", `{"session_id":"`+sessionID+`"}`)
+		}
+		return ErrInvalidPoPProof
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+func TestAuthService_Refresh_ProofOfPossession(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withNetworkAccess(false), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc, auditLogger := f.svc, f.audit
+	key, jwk, err := security.NewTestPoPKey()
+	if err != nil {
+		t.Fatalf("NewTestPoPKey: %v", err)
+	}
+	ctx := ctxFromIP("10.0.0.1")
+	if _, err := svc.Login(ContextWithPoPKey(ctx, `{"kty":"oct"}`), "user@example.com", "Password123!abc", "org-1", "fp-1"); err != ErrInvalidPoPKey {
+		t.Fatalf("Login with unsupported key: want ErrInvalidPoPKey, got %v", err)
+	}
+	res, err := svc.Login(ContextWithPoPKey(ctx, jwk), "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Login: res=%+v err=%v", res, err)
+	}
+	refreshToken := res.Tokens.RefreshToken
+
+	if _, err := svc.Refresh(ctx, refreshToken, "fp-1"); err != ErrInvalidPoPProof {
+		t.Fatalf("Refresh without proof: want ErrInvalidPoPProof, got %v", err)
+	}
+	if !auditLogger.hasAction("refresh_pop_failure") {
+		t.Error("missing proof should be audited as refresh_pop_failure")
+	}
+	nonce, _, err := svc.CreateRefreshNonce(ctx, refreshToken)
+	if err != nil {
+		t.Fatalf("CreateRefreshNonce: %v", err)
+	}
+	otherKey, _, _ := security.NewTestPoPKey()
+	forged, _ := security.SignTestPoPProof(otherKey, nonce, refreshToken)
+	if _, err := svc.Refresh(ContextWithPoPProof(ctx, forged), refreshToken, "fp-1"); err != ErrInvalidPoPProof {
+		t.Fatalf("Refresh with proof from another key: want ErrInvalidPoPProof, got %v", err)
+	}
+	proof, _ := security.SignTestPoPProof(key, nonce, refreshToken)
+	refreshed, err := svc.Refresh(ContextWithPoPProof(ctx, proof), refreshToken, "fp-1")
+	if err != nil || refreshed.Tokens == nil {
+		t.Fatalf("Refresh with proof: res=%+v err=%v", refreshed, err)
+	}
+
+	// The binding survives rotation; the old proof does not match the new refresh token.
+	if _, err := svc.Refresh(ContextWithPoPProof(ctx, proof), refreshed.Tokens.RefreshToken, "fp-1"); err != ErrInvalidPoPProof {
+		t.Errorf("Refresh of rotated token with stale proof: want ErrInvalidPoPProof, got %v", err)
+	}
+	if _, _, err := svc.CreateRefreshNonce(ctx, refreshToken); err != ErrInvalidRefreshToken {
+		t.Errorf("CreateRefreshNonce with rotated token: want ErrInvalidRefreshToken, got %v", err)
+	}
+}
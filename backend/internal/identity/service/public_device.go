@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// Default lifetimes of an ephemeral (public device) session; see WithPublicDeviceSessions.
+const (
+	DefaultPublicSessionTTL         = time.Hour
+	DefaultPublicSessionIdleTimeout = 15 * time.Minute
+)
+
+// WithPublicDeviceSessions sets how long a session signed in on a public or shared device lasts at most (it is
+// never extended by refresh) and how long it may go without a refresh before it is revoked. 0 or less keeps the
+// default. The session TTL and the org's max_session_age still cap the lifetime.
+func WithPublicDeviceSessions(ttl, idleTimeout time.Duration) Option {
+	return func(s *AuthService) {
+		if ttl > 0 {
+			s.publicSessionTTL = ttl
+		}
+		if idleTimeout > 0 {
+			s.publicSessionIdle = idleTimeout
+		}
+	}
+}
+
+type publicDeviceContextKey struct{}
+
+// ContextWithPublicDevice returns ctx marking a Login as made on a public or shared device. Such a login registers,
+// looks up and trusts no device, always requires MFA (once the org's access policy allows the login at all) and
+// creates an ephemeral session: one without a device, with the shorter public session lifetime, revoked when idle.
+func ContextWithPublicDevice(ctx context.Context, public bool) context.Context {
+	return context.WithValue(ctx, publicDeviceContextKey{}, public)
+}
+
+func publicDeviceFromContext(ctx context.Context) bool {
+	public, _ := ctx.Value(publicDeviceContextKey{}).(bool)
+	return public
+}
+
+// notPublicDevice reports whether st has (or may register) a device; public device flows skip device_check and
+// device_trust.
+func notPublicDevice(st *FlowState) bool { return !st.PublicDevice }
+
+// flowDeviceID returns the ID of the flow's device, or "" for a public device flow, which has none.
+func flowDeviceID(st *FlowState) string {
+	if st.Device == nil {
+		return ""
+	}
+	return st.Device.ID
+}
+
+// publicSessionExpiry caps expiresAt, the expiry of a session created at now, at the public session TTL.
+func (s *AuthService) publicSessionExpiry(expiresAt, now time.Time) time.Time {
+	if limit := now.Add(s.publicSessionTTL); limit.Before(expiresAt) {
+		return limit
+	}
+	return expiresAt
+}
+
+// checkEphemeralSession ends an ephemeral session on refresh once it has gone unrefreshed past the public session
+// idle timeout (revoking it as idle_timeout) or reached the expiry set at sign-in; refresh never extends it.
+// Otherwise it keeps the session's expiry for rotate_tokens.
+func (s *AuthService) checkEphemeralSession(ctx context.Context, st *FlowState, now time.Time) error {
+	sess := st.Session
+	lastUsed := sess.CreatedAt
+	if sess.LastSeenAt != nil && sess.LastSeenAt.After(lastUsed) {
+		lastUsed = *sess.LastSeenAt
+	}
+	if now.Sub(lastUsed) > s.publicSessionIdle {
+		_ = s.sessionRepo.Revoke(ctx, sess.ID, sessiondomain.Revocation{Reason: sessiondomain.RevocationIdleTimeout})
+		return s.sessionExpired(ctx, st, "idle_timeout")
+	}
+	if !sess.ExpiresAt.After(now) {
+		return s.sessionExpired(ctx, st, "absolute")
+	}
+	st.SessionExpiresAt = sess.ExpiresAt
+	return nil
+}
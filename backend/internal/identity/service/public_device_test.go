@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/devotp"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// withPublicDeviceUser seeds user@example.com with a phone as a member of org-1 with a trusted device "fp-1", and
+// keeps the OTPs sent to them in f.devOTP.
+func withPublicDeviceUser() fixtureOption {
+	return func(f *testFixture) {
+		withDevOTP()(f)
+		withMember("user@example.com", membershipdomain.RoleMember, "fp-1")(f)
+		withPhone("user@example.com", "15551234567")(f)
+	}
+}
+
+// publicDeviceSession signs in on a public device as withPublicDeviceUser's user and returns the refresh
+// token and the new session.
+func publicDeviceSession(t *testing.T, svc *AuthService, repo *memSessionRepo, devStore *devotp.MemoryStore) (string, *sessiondomain.Session) {
+	t.Helper()
+	ctx := ContextWithPublicDevice(context.Background(), true)
+	loginRes, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || loginRes.MFARequired == nil {
+		t.Fatalf("public device Login = %+v, %v; want MFA required even on a trusted device", loginRes, err)
+	}
+	otp, _ := devStore.Get(ctx, loginRes.MFARequired.ChallengeID)
+	res, err := svc.VerifyMFA(ContextWithTrustDays(context.Background(), 7), loginRes.MFARequired.ChallengeID, otp)
+	if err != nil || res.RefreshToken == "" {
+		t.Fatalf("VerifyMFA: res=%+v err=%v", res, err)
+	}
+	sessionID, _, _, _, err := svc.tokens.ValidateRefresh(res.RefreshToken)
+	if err != nil {
+		t.Fatalf("ValidateRefresh: %v", err)
+	}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return res.RefreshToken, repo.m[sessionID]
+}
+
+func TestAuthService_Login_PublicDevice(t *testing.T) {
+	f := newTestFixture(t, withPublicDeviceUser())
+	svc, repo, devStore := f.svc, f.sessions, f.devOTP
+	_, sess := publicDeviceSession(t, svc, repo, devStore)
+	if !sess.Ephemeral || sess.DeviceID != "" {
+		t.Errorf("session Ephemeral = %v, DeviceID = %q; want an ephemeral session without a device", sess.Ephemeral, sess.DeviceID)
+	}
+	if until := time.Until(sess.ExpiresAt); until > DefaultPublicSessionTTL {
+		t.Errorf("ephemeral session expires in %v, want at most %v", until, DefaultPublicSessionTTL)
+	}
+	deviceRepo := svc.deviceRepo.(*memDeviceRepo)
+	deviceRepo.mu.Lock()
+	defer deviceRepo.mu.Unlock()
+	if len(deviceRepo.m) != 1 {
+		t.Errorf("devices = %d, want 1: a public device sign-in must not register a device", len(deviceRepo.m))
+	}
+	if d := deviceRepo.m["d1"]; d.TrustedUntil != nil || d.TrustDays != 0 {
+		t.Errorf("device d1 = %+v; a public device sign-in must not trust a device", d)
+	}
+}
+
+func TestAuthService_Login_PublicDeviceWithoutPhone(t *testing.T) {
+	svc := newTestFixture(t, withPublicDeviceUser()).svc
+	userRepo := svc.userRepo.(*memUserRepo)
+	userRepo.mu.Lock()
+	for _, u := range userRepo.byID {
+		u.Phone = ""
+	}
+	userRepo.mu.Unlock()
+	ctx := ContextWithPublicDevice(context.Background(), true)
+	if _, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1"); !errors.Is(err, ErrPhoneRequiredForMFA) {
+		t.Errorf("public device Login without a phone: err = %v, want ErrPhoneRequiredForMFA (no phone enrollment)", err)
+	}
+}
+
+func TestAuthService_Refresh_EphemeralSession(t *testing.T) {
+	f := newTestFixture(t, withPublicDeviceUser())
+	svc, repo, devStore := f.svc, f.sessions, f.devOTP
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	refreshToken, sess := publicDeviceSession(t, svc, repo, devStore)
+	expiresAt := sess.ExpiresAt
+	res, err := svc.Refresh(context.Background(), refreshToken, "")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Refresh: res=%+v err=%v", res, err)
+	}
+	if !sess.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("refresh moved the ephemeral session expiry from %v to %v", expiresAt, sess.ExpiresAt)
+	}
+
+	sess.CreatedAt = time.Now().UTC().Add(-DefaultPublicSessionIdleTimeout - time.Minute)
+	if _, err := svc.Refresh(context.Background(), res.Tokens.RefreshToken, ""); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("Refresh of an idle ephemeral session: err = %v, want ErrSessionExpired", err)
+	}
+	if sess.RevokedAt == nil || sess.RevocationReason != sessiondomain.RevocationIdleTimeout {
+		t.Errorf("idle ephemeral session revoked = %v, reason %q; want revoked as idle_timeout", sess.RevokedAt, sess.RevocationReason)
+	}
+	if !auditLogger.hasAction("session_expired") {
+		t.Error("idle ephemeral session should be audited as session_expired")
+	}
+}
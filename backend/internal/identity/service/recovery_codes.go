@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/mfa"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// MFAMethodRecoveryCode is recorded as the MFA method of a VerifyMFA completed with a recovery code.
+const MFAMethodRecoveryCode = "recovery_code"
+
+// RecoveryCodeRepo stores hashed one-time MFA recovery codes (e.g. *mfarecoveryrepo.PostgresRepository).
+type RecoveryCodeRepo interface {
+	Replace(ctx context.Context, userID string, codeHashes []string, createdAt time.Time) error
+	Consume(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error)
+	CountUnused(ctx context.Context, userID string) (int, error)
+}
+
+// WithRecoveryCodes enables MFA recovery codes: they are issued when VerifyMFA enrolls the user's phone, can be
+// regenerated with RegenerateRecoveryCodes, and are accepted by VerifyMFA in place of the challenge's code.
+func WithRecoveryCodes(repo RecoveryCodeRepo) Option {
+	return func(s *AuthService) { s.recoveryCodes = repo }
+}
+
+// RegenerateRecoveryCodes replaces the caller's recovery codes with a new set and returns it; the old codes stop
+// working. The caller is identified by the access token in ctx and must supply the current password.
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, currentPassword string) ([]string, error) {
+	if s.recoveryCodes == nil {
+		return nil, ErrRecoveryCodesDisabled
+	}
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, ErrInvalidCredentials
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	ident, err := s.identityRepo.GetByUserAndProvider(ctx, userID, identitydomain.IdentityProviderLocal)
+	if err != nil {
+		return nil, err
+	}
+	if ident == nil || ident.PasswordHash == "" || s.hasher.Compare(ident.PasswordHash, []byte(currentPassword)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+	codes, err := s.issueRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "mfa_recovery_codes_regenerated", "authentication", "")
+	}
+	return codes, nil
+}
+
+// issueRecoveryCodes generates a new set of codes for the user and stores their hashes.
+func (s *AuthService) issueRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes, err := mfa.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = mfa.HashRecoveryCode(c)
+	}
+	if err := s.recoveryCodes.Replace(ctx, userID, hashes, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// enrollRecoveryCodes issues the first set of codes when VerifyMFA enrolls the user's first factor. Failure does not
+// fail the sign-in; the user can regenerate codes later.
+func (s *AuthService) enrollRecoveryCodes(ctx context.Context, st *FlowState) {
+	if s.recoveryCodes == nil {
+		return
+	}
+	codes, err := s.issueRecoveryCodes(ctx, st.UserID)
+	if err != nil {
+		log.Printf("auth: user_id=%s failed to issue recovery codes: %v", st.UserID, err)
+		return
+	}
+	st.RecoveryCodes = codes
+}
+
+// useRecoveryCode consumes code (normalized) for the challenge's user in place of the challenge's own code, then
+// alerts the user through their security feed with the number of codes left.
+func (s *AuthService) useRecoveryCode(ctx context.Context, st *FlowState, code string) error {
+	ok, err := s.recoveryCodes.Consume(ctx, st.UserID, mfa.HashRecoveryCode(code), time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidOTP
+	}
+	st.MFAMethod = MFAMethodRecoveryCode
+	remaining, _ := s.recoveryCodes.CountUnused(ctx, st.UserID)
+	metadata := `{"remaining":` + strconv.Itoa(remaining) + `}`
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, st.OrgID, st.UserID, "mfa_recovery_code_used", "authentication", metadata)
+	}
+	s.recordSecurityEvent(ctx, st.OrgID, st.UserID, securityeventdomain.EventRecoveryCodeUsed, metadata)
+	return nil
+}
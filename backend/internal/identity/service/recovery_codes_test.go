@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/mfa"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type memRecoveryCodeRepo struct {
+	mu     sync.Mutex
+	hashes map[string]map[string]bool // user -> hash -> used
+}
+
+func (r *memRecoveryCodeRepo) Replace(ctx context.Context, userID string, codeHashes []string, createdAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hashes == nil {
+		r.hashes = make(map[string]map[string]bool)
+	}
+	r.hashes[userID] = make(map[string]bool)
+	for _, h := range codeHashes {
+		r.hashes[userID][h] = false
+	}
+	return nil
+}
+
+func (r *memRecoveryCodeRepo) Consume(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	used, ok := r.hashes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	r.hashes[userID][codeHash] = true
+	return true, nil
+}
+
+func (r *memRecoveryCodeRepo) CountUnused(ctx context.Context, userID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, used := range r.hashes[userID] {
+		if !used {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestAuthService_RecoveryCodes(t *testing.T) {
+	svc, _, devStore := newTestAuthServiceOpt(t, true)
+	repo := &memRecoveryCodeRepo{}
+	auditLogger := &mockAuditLogger{}
+	recorder := &memSecurityEventRecorder{}
+	WithRecoveryCodes(repo)(svc)
+	WithSecurityEventRecorder(recorder)(svc)
+	svc.auditLogger = auditLogger
+	loginFlowFixture(t, svc, "")
+	ctx := context.Background()
+
+	// Enrolling the first phone through VerifyMFA issues the first set of codes.
+	res, err := svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-1")
+	if err != nil || res.PhoneRequired == nil {
+		t.Fatalf("Login = %+v, %v; want PhoneRequired", res, err)
+	}
+	challenge, err := svc.SubmitPhoneAndRequestMFA(ctx, res.PhoneRequired.IntentID, "15551234567")
+	if err != nil {
+		t.Fatalf("SubmitPhoneAndRequestMFA: %v", err)
+	}
+	otp, _ := devStore.Get(ctx, challenge.ChallengeID)
+	tokens, err := svc.VerifyMFA(ctx, challenge.ChallengeID, otp)
+	if err != nil {
+		t.Fatalf("VerifyMFA: %v", err)
+	}
+	if len(tokens.RecoveryCodes) != mfa.RecoveryCodeCount {
+		t.Fatalf("recovery codes = %v, want %d", tokens.RecoveryCodes, mfa.RecoveryCodeCount)
+	}
+
+	// A recovery code (in any case) completes a later challenge once.
+	code := strings.ToUpper(tokens.RecoveryCodes[0])
+	res, err = svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-2")
+	if err != nil || res.MFARequired == nil {
+		t.Fatalf("Login = %+v, %v; want MFARequired", res, err)
+	}
+	tokens, err = svc.VerifyMFA(ctx, res.MFARequired.ChallengeID, code)
+	if err != nil || tokens.AccessToken == "" {
+		t.Fatalf("VerifyMFA(recovery code) = %+v, %v; want tokens", tokens, err)
+	}
+	if len(tokens.RecoveryCodes) != 0 {
+		t.Errorf("recovery codes reissued on a recovery sign-in: %v", tokens.RecoveryCodes)
+	}
+	if fa := lastFlowAudit(t, auditLogger); fa.MFAMethod != MFAMethodRecoveryCode {
+		t.Errorf("auth_flow mfa_method = %q, want %q", fa.MFAMethod, MFAMethodRecoveryCode)
+	}
+	if !auditLogger.hasAction("mfa_recovery_code_used") {
+		t.Error("mfa_recovery_code_used not audited")
+	}
+	if n := len(recorder.types); n == 0 || recorder.types[n-1] != securityeventdomain.EventRecoveryCodeUsed {
+		t.Errorf("security events = %v, want recovery_code_used last", recorder.types)
+	}
+	if n, _ := repo.CountUnused(ctx, tokens.UserID); n != mfa.RecoveryCodeCount-1 {
+		t.Errorf("unused codes = %d, want %d", n, mfa.RecoveryCodeCount-1)
+	}
+
+	res, err = svc.Login(ctx, "user@example.com", "Password123!abc", "org-1", "fp-3")
+	if err != nil || res.MFARequired == nil {
+		t.Fatalf("Login = %+v, %v; want MFARequired", res, err)
+	}
+	if _, err := svc.VerifyMFA(ctx, res.MFARequired.ChallengeID, code); err != ErrInvalidOTP {
+		t.Errorf("VerifyMFA(used recovery code): want ErrInvalidOTP, got %v", err)
+	}
+}
+
+func TestAuthService_RegenerateRecoveryCodes(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	userID := loginFlowFixture(t, svc, "")
+	ctx := interceptors.WithIdentity(context.Background(), userID, "org-1", "session-1")
+
+	if _, err := svc.RegenerateRecoveryCodes(ctx, "Password123!abc"); err != ErrRecoveryCodesDisabled {
+		t.Fatalf("disabled: want ErrRecoveryCodesDisabled, got %v", err)
+	}
+	repo := &memRecoveryCodeRepo{}
+	WithRecoveryCodes(repo)(svc)
+	if _, err := svc.RegenerateRecoveryCodes(ctx, "WrongPassword123!"); err != ErrInvalidCredentials {
+		t.Fatalf("wrong password: want ErrInvalidCredentials, got %v", err)
+	}
+	if _, err := svc.RegenerateRecoveryCodes(context.Background(), "Password123!abc"); err != ErrInvalidCredentials {
+		t.Fatalf("no caller: want ErrInvalidCredentials, got %v", err)
+	}
+	first, err := svc.RegenerateRecoveryCodes(ctx, "Password123!abc")
+	if err != nil || len(first) != mfa.RecoveryCodeCount {
+		t.Fatalf("RegenerateRecoveryCodes = %v, %v", first, err)
+	}
+	second, err := svc.RegenerateRecoveryCodes(ctx, "Password123!abc")
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes: %v", err)
+	}
+	if ok, _ := repo.Consume(ctx, userID, mfa.HashRecoveryCode(first[0]), time.Now()); ok {
+		t.Error("code from the replaced set still consumable")
+	}
+	if ok, _ := repo.Consume(ctx, userID, mfa.HashRecoveryCode(second[0]), time.Now()); !ok {
+		t.Error("code from the new set not consumable")
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	invitationdomain "zero-trust-control-plane/backend/internal/invitation/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgdomaindomain "zero-trust-control-plane/backend/internal/orgdomain/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	"zero-trust-control-plane/backend/internal/security"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	"zero-trust-control-plane/backend/internal/signupguard"
+)
+
+// InvitationRepo looks up and accepts org invitations (e.g. *invitationrepo.PostgresRepository).
+type InvitationRepo interface {
+	GetByTokenHash(ctx context.Context, hash string) (*invitationdomain.Invitation, error)
+	Accept(ctx context.Context, inv *invitationdomain.Invitation, userID string, now time.Time) (*membershipdomain.Membership, bool, error)
+}
+
+// VerifiedDomainGetter returns the org that verified an email domain, or nil (e.g. orgdomainrepo.Repository).
+type VerifiedDomainGetter interface {
+	GetVerified(ctx context.Context, domainName string) (*orgdomaindomain.OrgDomain, error)
+}
+
+// CaptchaVerifier checks a CAPTCHA response token (e.g. *captcha.TurnstileVerifier).
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// WithRegistrationControls enables invitations and org registration modes in Register. invites accepts invitation
+// tokens; without it Register refuses every invitation, so invite-only registration admits nobody. orgDomains
+// resolves the org whose registration section (read through WithOrgPolicyConfigRepo) applies to an email; nil
+// applies the platform mode only. captcha, when non-nil, must accept a CAPTCHA token for registrations without an
+// invitation while registration is open.
+func WithRegistrationControls(invites InvitationRepo, orgDomains VerifiedDomainGetter, captcha CaptchaVerifier) Option {
+	return func(s *AuthService) { s.invitations, s.registrationDomains, s.captcha = invites, orgDomains, captcha }
+}
+
+// SignupGuard screens a registration for abuse (e.g. *signupguard.Guard): disposable addresses, domains outside the
+// platform allowlist and sign-up velocity per client IP.
+type SignupGuard interface {
+	Check(ctx context.Context, email, clientIP string) error
+}
+
+// WithSignupGuard screens every Register with guard before the registration mode is applied.
+func WithSignupGuard(guard SignupGuard) Option {
+	return func(s *AuthService) { s.signupGuard = guard }
+}
+
+// WithSeatLimit refuses Register when seats has no seat for the new user (e.g. *license.Manager). The seat is
+// reserved in the transaction that creates the user and its identity.
+func WithSeatLimit(seats license.SeatReserver) Option {
+	return func(s *AuthService) { s.seats = seats }
+}
+
+type inviteTokenContextKey struct{}
+
+type captchaTokenContextKey struct{}
+
+// ContextWithInviteToken returns ctx carrying the invitation token Register accepts (InvitationService).
+func ContextWithInviteToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, inviteTokenContextKey{}, strings.TrimSpace(token))
+}
+
+// ContextWithCaptchaToken returns ctx carrying the client's CAPTCHA response token for Register.
+func ContextWithCaptchaToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, captchaTokenContextKey{}, strings.TrimSpace(token))
+}
+
+// checkRegistration applies the registration mode to email: the stricter of the platform registration_mode and the
+// registration section of the org that verified the email's domain. It returns the invitation from ctx, checked
+// against email, or nil when none was given. Errors are ErrRegistrationClosed, ErrInvitationRequired,
+// ErrInvalidInvitation and ErrCaptchaFailed.
+func (s *AuthService) checkRegistration(ctx context.Context, email string) (*invitationdomain.Invitation, error) {
+	inv, err := s.registrationInvitation(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	platformMode := platformsettingsdomain.RegistrationOpen
+	if s.platformSettingsRepo != nil {
+		settings, err := s.platformSettingsRepo.GetSettings(ctx, s.trustTTLDays())
+		if err != nil {
+			return nil, err
+		}
+		platformMode = settings.RegistrationMode
+	}
+	domainOrgID, orgMode, err := s.domainRegistrationMode(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	switch platformMode.Stricter(orgMode) {
+	case platformsettingsdomain.RegistrationClosed:
+		return nil, ErrRegistrationClosed
+	case platformsettingsdomain.RegistrationInviteOnly:
+		if inv == nil {
+			return nil, ErrInvitationRequired
+		}
+		// The org's own mode admits only its own invitations.
+		if orgMode == platformsettingsdomain.RegistrationInviteOnly && inv.OrgID != domainOrgID {
+			return nil, ErrInvitationRequired
+		}
+		return inv, nil
+	}
+	if inv == nil && s.captcha != nil {
+		token, _ := ctx.Value(captchaTokenContextKey{}).(string)
+		ok, err := s.captcha.Verify(ctx, token, interceptors.ClientIP(ctx))
+		if err != nil {
+			log.Printf("auth: captcha verification failed: %v", err)
+		}
+		if !ok {
+			return nil, ErrCaptchaFailed
+		}
+	}
+	return inv, nil
+}
+
+// checkSignupAbuse runs the signup guard. Rejections are audited as signup_rejected with the signupguard reason and
+// the email's domain; velocity rejections return ErrRateLimited, the others ErrEmailNotAllowed.
+func (s *AuthService) checkSignupAbuse(ctx context.Context, email string) error {
+	if s.signupGuard == nil {
+		return nil
+	}
+	err := s.signupGuard.Check(ctx, email, interceptors.ClientIP(ctx))
+	reason := signupguard.Reason(err)
+	if err == nil || reason == "" {
+		return err
+	}
+	if s.auditLogger != nil {
+		_, domainName, _ := strings.Cut(email, "@")
+		meta, _ := json.Marshal(map[string]string{"reason": reason, "domain": domainName})
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(""), "", "signup_rejected", "authentication", string(meta))
+	}
+	if reason == signupguard.ReasonVelocity {
+		return ErrRateLimited
+	}
+	return ErrEmailNotAllowed
+}
+
+// seatRejected maps the license errors of creating the user for email to ErrSeatLimitReached and ErrLicenseExpired,
+// audited as signup_rejected with reason license_seats or license_expired and the email's domain. Other errors are
+// returned as they are.
+func (s *AuthService) seatRejected(ctx context.Context, email string, err error) error {
+	reason, rejected := "", err
+	switch {
+	case errors.Is(err, license.ErrSeatLimitReached):
+		reason, rejected = "license_seats", ErrSeatLimitReached
+	case errors.Is(err, license.ErrLicenseExpired):
+		reason, rejected = "license_expired", ErrLicenseExpired
+	}
+	if reason != "" && s.auditLogger != nil {
+		_, domainName, _ := strings.Cut(email, "@")
+		meta, _ := json.Marshal(map[string]string{"reason": reason, "domain": domainName})
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(""), "", "signup_rejected", "authentication", string(meta))
+	}
+	return rejected
+}
+
+// registrationInvitation returns the usable invitation for email named by the token in ctx, or nil without a token.
+func (s *AuthService) registrationInvitation(ctx context.Context, email string) (*invitationdomain.Invitation, error) {
+	token, _ := ctx.Value(inviteTokenContextKey{}).(string)
+	if token == "" {
+		return nil, nil
+	}
+	if s.invitations == nil {
+		return nil, ErrInvalidInvitation
+	}
+	inv, err := s.invitations.GetByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if inv == nil || !inv.Usable(time.Now().UTC()) || inv.Email != email {
+		return nil, ErrInvalidInvitation
+	}
+	return inv, nil
+}
+
+// domainRegistrationMode returns the org that verified email's domain and its registration mode, or "" for both when
+// no org has verified it or the org leaves registration to the platform.
+func (s *AuthService) domainRegistrationMode(ctx context.Context, email string) (string, platformsettingsdomain.RegistrationMode, error) {
+	if s.registrationDomains == nil || s.orgPolicyConfigRepo == nil {
+		return "", "", nil
+	}
+	_, domainName, ok := strings.Cut(email, "@")
+	if !ok {
+		return "", "", nil
+	}
+	claim, err := s.registrationDomains.GetVerified(ctx, domainName)
+	if err != nil || claim == nil {
+		return "", "", err
+	}
+	cfg, err := s.orgPolicyConfigRepo.GetByOrgID(ctx, claim.OrgID)
+	if err != nil {
+		return "", "", err
+	}
+	mode := orgpolicyconfigdomain.MergeWithDefaults(cfg).Registration.Mode
+	return claim.OrgID, platformsettingsdomain.RegistrationMode(mode), nil
+}
+
+// acceptInvitation adds the new user to the invitation's org. It returns ErrInvalidInvitation when the invitation
+// was accepted, revoked or expired since checkRegistration, and ErrUserQuotaExceeded when the org has reached
+// max_users (the invitation stays pending); the account is kept.
+func (s *AuthService) acceptInvitation(ctx context.Context, inv *invitationdomain.Invitation, userID string) error {
+	_, ok, err := s.invitations.Accept(ctx, inv, userID, time.Now().UTC())
+	if errors.Is(err, orgquota.ErrUserQuotaExceeded) {
+		return ErrUserQuotaExceeded
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidInvitation
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, inv.OrgID, userID, "invitation_accepted", "invitation", inv.ID)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	invitationdomain "zero-trust-control-plane/backend/internal/invitation/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgdomaindomain "zero-trust-control-plane/backend/internal/orgdomain/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
+	"zero-trust-control-plane/backend/internal/platform/ratelimit"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	"zero-trust-control-plane/backend/internal/signupguard"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// memInvitationRepo holds invitations by token hash; Accept adds the membership to members, or fails with acceptErr.
+type memInvitationRepo struct {
+	byHash    map[string]*invitationdomain.Invitation
+	members   *memMembershipRepo
+	acceptErr error
+}
+
+func (r *memInvitationRepo) GetByTokenHash(ctx context.Context, hash string) (*invitationdomain.Invitation, error) {
+	return r.byHash[hash], nil
+}
+
+func (r *memInvitationRepo) Accept(ctx context.Context, inv *invitationdomain.Invitation, userID string, now time.Time) (*membershipdomain.Membership, bool, error) {
+	if r.acceptErr != nil {
+		return nil, false, r.acceptErr
+	}
+	if !inv.Usable(now) {
+		return nil, false, nil
+	}
+	inv.AcceptedAt, inv.AcceptedBy = &now, userID
+	m := &membershipdomain.Membership{ID: "m-" + userID, UserID: userID, OrgID: inv.OrgID, Role: inv.Role, CreatedAt: now}
+	r.members.mu.Lock()
+	r.members.m[m.ID] = m
+	r.members.mu.Unlock()
+	return m, true, nil
+}
+
+// add stores a pending invitation and returns its token.
+func (r *memInvitationRepo) add(t *testing.T, orgID, email string) string {
+	t.Helper()
+	token, hash, err := invitationdomain.NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.byHash[hash] = &invitationdomain.Invitation{
+		ID:        "inv-" + email,
+		OrgID:     orgID,
+		Email:     email,
+		Role:      membershipdomain.RoleMember,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	return token
+}
+
+// verifiedDomains maps email domains to the orgs that verified them.
+type verifiedDomains map[string]string
+
+func (d verifiedDomains) GetVerified(ctx context.Context, domainName string) (*orgdomaindomain.OrgDomain, error) {
+	orgID, ok := d[domainName]
+	if !ok {
+		return nil, nil
+	}
+	return &orgdomaindomain.OrgDomain{OrgID: orgID, Domain: domainName, Status: orgdomaindomain.StatusVerified}, nil
+}
+
+type fakeCaptcha struct {
+	calls int
+	err   error
+}
+
+func (c *fakeCaptcha) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	c.calls++
+	return token == "human", c.err
+}
+
+// withRegistrationControls enables invitations, captcha and org domain registration for acme.test (org-acme),
+// with every org's registration mode set to orgMode.
+func withRegistrationControls(orgMode string) fixtureOption {
+	return func(f *testFixture) {
+		f.invites = &memInvitationRepo{byHash: map[string]*invitationdomain.Invitation{}, members: f.memberships}
+		f.captcha = &fakeCaptcha{}
+		f.opts = append(f.opts,
+			WithRegistrationControls(f.invites, verifiedDomains{"acme.test": "org-acme"}, f.captcha),
+			WithOrgPolicyConfigRepo(staticOrgPolicyConfigRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+				Registration: &orgpolicyconfigdomain.Registration{Mode: orgMode},
+			}}),
+		)
+	}
+}
+
+func TestAuthService_Register_InviteOnly(t *testing.T) {
+	f := newTestFixture(t, withRegistrationControls(""))
+	svc, invites, captcha := f.svc, f.invites, f.captcha
+	svc.platformSettingsRepo.(*memPlatformSettingsRepo).registrationMode = platformsettingsdomain.RegistrationInviteOnly
+	ctx := context.Background()
+
+	if _, err := svc.Register(ctx, "new@example.com", "Password123!abc", ""); !errors.Is(err, ErrInvitationRequired) {
+		t.Fatalf("Register without invitation: err = %v, want ErrInvitationRequired", err)
+	}
+	token := invites.add(t, "org-1", "new@example.com")
+	if _, err := svc.Register(ContextWithInviteToken(ctx, token), "other@example.com", "Password123!abc", ""); !errors.Is(err, ErrInvalidInvitation) {
+		t.Fatalf("Register with another email's invitation: err = %v, want ErrInvalidInvitation", err)
+	}
+	if _, err := svc.Register(ContextWithInviteToken(ctx, "ztcp_inv_unknown"), "new@example.com", "Password123!abc", ""); !errors.Is(err, ErrInvalidInvitation) {
+		t.Fatalf("Register with unknown invitation: err = %v, want ErrInvalidInvitation", err)
+	}
+
+	res, err := svc.Register(ContextWithInviteToken(ctx, token), "New@Example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register with invitation: %v", err)
+	}
+	if res.OrgID != "org-1" {
+		t.Errorf("OrgID = %q, want org-1", res.OrgID)
+	}
+	if m, _ := svc.membershipRepo.GetMembershipByUserAndOrg(ctx, res.UserID, "org-1"); m == nil || m.Role != membershipdomain.RoleMember {
+		t.Errorf("membership = %+v, want member of org-1", m)
+	}
+	if captcha.calls != 0 {
+		t.Error("registration with an invitation should not need a CAPTCHA")
+	}
+
+	svc.userRepo.(*memUserRepo).byEmail = map[string]*userdomain.User{}
+	if _, err := svc.Register(ContextWithInviteToken(ctx, token), "new@example.com", "Password123!abc", ""); !errors.Is(err, ErrInvalidInvitation) {
+		t.Fatalf("Register with used invitation: err = %v, want ErrInvalidInvitation", err)
+	}
+}
+
+func TestAuthService_Register_InvitationUserQuota(t *testing.T) {
+	f := newTestFixture(t, withRegistrationControls(""))
+	svc, invites := f.svc, f.invites
+	ctx := context.Background()
+	token := invites.add(t, "org-1", "new@example.com")
+	// The invitation repository checks max_users in the accept transaction.
+	invites.acceptErr = orgquota.ErrUserQuotaExceeded
+	if _, err := svc.Register(ContextWithInviteToken(ctx, token), "new@example.com", "Password123!abc", ""); !errors.Is(err, ErrUserQuotaExceeded) {
+		t.Fatalf("Register with an invitation to a full org: err = %v, want ErrUserQuotaExceeded", err)
+	}
+	for _, inv := range invites.byHash {
+		if inv.AcceptedAt != nil {
+			t.Error("invitation to a full org should stay pending")
+		}
+	}
+}
+
+func TestAuthService_Register_OrgMode(t *testing.T) {
+	ctx := context.Background()
+
+	svc := newTestFixture(t, withRegistrationControls(orgpolicyconfigdomain.RegistrationModeClosed)).svc
+	if _, err := svc.Register(ctx, "dev@acme.test", "Password123!abc", ""); !errors.Is(err, ErrRegistrationClosed) {
+		t.Errorf("Register in closed org domain: err = %v, want ErrRegistrationClosed", err)
+	}
+	if _, err := svc.Register(ContextWithCaptchaToken(ctx, "human"), "dev@example.com", "Password123!abc", ""); err != nil {
+		t.Errorf("Register outside the org's domains: %v", err)
+	}
+
+	f := newTestFixture(t, withRegistrationControls(orgpolicyconfigdomain.RegistrationModeInviteOnly))
+	svc, invites := f.svc, f.invites
+	if _, err := svc.Register(ctx, "dev@acme.test", "Password123!abc", ""); !errors.Is(err, ErrInvitationRequired) {
+		t.Errorf("Register in invite-only org domain: err = %v, want ErrInvitationRequired", err)
+	}
+	other := invites.add(t, "org-other", "dev@acme.test")
+	if _, err := svc.Register(ContextWithInviteToken(ctx, other), "dev@acme.test", "Password123!abc", ""); !errors.Is(err, ErrInvitationRequired) {
+		t.Errorf("Register with another org's invitation: err = %v, want ErrInvitationRequired", err)
+	}
+	own := invites.add(t, "org-acme", "lead@acme.test")
+	if res, err := svc.Register(ContextWithInviteToken(ctx, own), "lead@acme.test", "Password123!abc", ""); err != nil || res.OrgID != "org-acme" {
+		t.Errorf("Register with the org's invitation: res = %+v, err = %v", res, err)
+	}
+
+	// An open org cannot loosen a closed platform.
+	svc = newTestFixture(t, withRegistrationControls(orgpolicyconfigdomain.RegistrationModeOpen)).svc
+	svc.platformSettingsRepo.(*memPlatformSettingsRepo).registrationMode = platformsettingsdomain.RegistrationClosed
+	if _, err := svc.Register(ContextWithCaptchaToken(ctx, "human"), "dev@acme.test", "Password123!abc", ""); !errors.Is(err, ErrRegistrationClosed) {
+		t.Errorf("Register with closed platform: err = %v, want ErrRegistrationClosed", err)
+	}
+}
+
+func TestAuthService_Register_Captcha(t *testing.T) {
+	f := newTestFixture(t, withRegistrationControls(""))
+	svc, captcha := f.svc, f.captcha
+	ctx := context.Background()
+
+	if _, err := svc.Register(ctx, "bot@example.com", "Password123!abc", ""); !errors.Is(err, ErrCaptchaFailed) {
+		t.Fatalf("Register without CAPTCHA: err = %v, want ErrCaptchaFailed", err)
+	}
+	if u, _ := svc.userRepo.GetByEmail(ctx, "bot@example.com"); u != nil {
+		t.Error("no user should be created without a CAPTCHA")
+	}
+	if _, err := svc.Register(ContextWithCaptchaToken(ctx, "human"), "human@example.com", "Password123!abc", ""); err != nil {
+		t.Fatalf("Register with CAPTCHA: %v", err)
+	}
+	captcha.err = errors.New("siteverify unavailable")
+	if _, err := svc.Register(ContextWithCaptchaToken(ctx, "robot"), "other@example.com", "Password123!abc", ""); !errors.Is(err, ErrCaptchaFailed) {
+		t.Fatalf("Register while the verifier fails: err = %v, want ErrCaptchaFailed", err)
+	}
+}
+
+func TestAuthService_Register_SignupGuard(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	guard := signupguard.NewGuard(signupguard.NewStaticList("mailinator.com"), ratelimit.NewLimiter(2, time.Hour), nil)
+	WithSignupGuard(guard)(svc)
+	ctx := ctxFromIP("203.0.113.7")
+
+	if _, err := svc.Register(ctx, "bot@mailinator.com", "Password123!abc", ""); !errors.Is(err, ErrEmailNotAllowed) {
+		t.Fatalf("Register with disposable email: err = %v, want ErrEmailNotAllowed", err)
+	}
+	if u, _ := svc.userRepo.GetByEmail(ctx, "bot@mailinator.com"); u != nil {
+		t.Error("no user should be created for a disposable email")
+	}
+	if _, err := svc.Register(ctx, "user@example.com", "Password123!abc", ""); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := svc.Register(ctx, "other@example.com", "Password123!abc", ""); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("third Register from one IP: err = %v, want ErrRateLimited", err)
+	}
+
+	guard.SetAllowedDomains([]string{"corp.example"})
+	if _, err := svc.Register(ctxFromIP("198.51.100.1"), "user@gmail.com", "Password123!abc", ""); !errors.Is(err, ErrEmailNotAllowed) {
+		t.Fatalf("Register outside the allowlist: err = %v, want ErrEmailNotAllowed", err)
+	}
+
+	var reasons []string
+	for _, e := range auditLogger.events {
+		if e.action == "signup_rejected" {
+			reasons = append(reasons, e.metadata)
+		}
+	}
+	want := []string{
+		`{"domain":"mailinator.com","reason":"disposable_email"}`,
+		`{"domain":"example.com","reason":"velocity"}`,
+		`{"domain":"gmail.com","reason":"domain_not_allowed"}`,
+	}
+	if strings.Join(reasons, "\n") != strings.Join(want, "\n") {
+		t.Errorf("signup_rejected metadata = %v, want %v", reasons, want)
+	}
+}
+
+type seatStub struct{ err error }
+
+func (s *seatStub) ReserveSeat(ctx context.Context, q license.SeatQueries) error { return s.err }
+
+func TestAuthService_Register_SeatLimit(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	auditLogger := &mockAuditLogger{}
+	svc.auditLogger = auditLogger
+	seats := &seatStub{}
+	WithSeatLimit(seats)(svc)
+	ctx := context.Background()
+
+	if _, err := svc.Register(ctx, "first@example.com", "Password123!abc", ""); err != nil {
+		t.Fatalf("Register with a free seat: %v", err)
+	}
+	seats.err = license.ErrSeatLimitReached
+	if _, err := svc.Register(ctx, "second@example.com", "Password123!abc", ""); !errors.Is(err, ErrSeatLimitReached) {
+		t.Fatalf("Register when every seat is taken: err = %v, want ErrSeatLimitReached", err)
+	}
+	if u, _ := svc.userRepo.GetByEmail(ctx, "second@example.com"); u != nil {
+		t.Error("no user should be created without a seat")
+	}
+	seats.err = license.ErrLicenseExpired
+	if _, err := svc.Register(ctx, "third@example.com", "Password123!abc", ""); !errors.Is(err, ErrLicenseExpired) {
+		t.Fatalf("Register after the grace period: err = %v, want ErrLicenseExpired", err)
+	}
+	var reasons []string
+	for _, e := range auditLogger.events {
+		if e.action == "signup_rejected" {
+			reasons = append(reasons, e.metadata)
+		}
+	}
+	want := `{"domain":"example.com","reason":"license_seats"},{"domain":"example.com","reason":"license_expired"}`
+	if got := strings.Join(reasons, ","); got != want {
+		t.Errorf("signup_rejected metadata = %s, want %s", got, want)
+	}
+}
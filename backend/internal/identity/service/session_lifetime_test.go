@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+)
+
+func TestAuthService_Refresh_RollingExpiry(t *testing.T) {
+	f := newTestFixture(t, withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc := f.svc
+	refreshToken, sess := f.loginSession(t)
+	sess.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+	if _, err := svc.Refresh(context.Background(), refreshToken, "fp-1"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if until := time.Until(sess.ExpiresAt); until < 23*time.Hour {
+		t.Errorf("rolling refresh should extend the session by the session TTL, expires in %v", until)
+	}
+}
+
+func TestAuthService_Refresh_AbsoluteExpiry(t *testing.T) {
+	mgmt := &orgpolicyconfigdomain.SessionMgmt{RefreshExpiry: orgpolicyconfigdomain.RefreshExpiryAbsolute}
+	f := newTestFixture(t, withAuditLogger(), withOrgPolicy(&orgpolicyconfigdomain.OrgPolicyConfig{SessionMgmt: mgmt}), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc, auditLogger := f.svc, f.audit
+	refreshToken, sess := f.loginSession(t)
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	sess.ExpiresAt = expiresAt
+	res, err := svc.Refresh(context.Background(), refreshToken, "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Refresh before the absolute expiry: res=%+v err=%v", res, err)
+	}
+	if !sess.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("absolute refresh moved the session expiry from %v to %v", expiresAt, sess.ExpiresAt)
+	}
+
+	sess.ExpiresAt = time.Now().UTC().Add(-time.Second)
+	if _, err := svc.Refresh(context.Background(), res.Tokens.RefreshToken, "fp-1"); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("Refresh after the absolute expiry: err = %v, want ErrSessionExpired", err)
+	}
+	if !auditLogger.hasAction("session_expired") {
+		t.Error("expired refresh should be audited as session_expired")
+	}
+}
+
+func TestAuthService_Refresh_MaxSessionAge(t *testing.T) {
+	mgmt := &orgpolicyconfigdomain.SessionMgmt{MaxSessionAge: "1h"}
+	f := newTestFixture(t, withOrgPolicy(&orgpolicyconfigdomain.OrgPolicyConfig{SessionMgmt: mgmt}), withMember("user@example.com", membershipdomain.RoleMember, "fp-1"))
+	svc := f.svc
+	refreshToken, sess := f.loginSession(t)
+	if until := time.Until(sess.ExpiresAt); until > time.Hour {
+		t.Errorf("new session should expire within max_session_age, expires in %v", until)
+	}
+	res, err := svc.Refresh(context.Background(), refreshToken, "fp-1")
+	if err != nil || res.Tokens == nil {
+		t.Fatalf("Refresh: res=%+v err=%v", res, err)
+	}
+	if limit := sess.CreatedAt.Add(time.Hour); sess.ExpiresAt.After(limit) {
+		t.Errorf("rolling refresh extended the session to %v, past max_session_age (%v)", sess.ExpiresAt, limit)
+	}
+
+	sess.CreatedAt = time.Now().UTC().Add(-2 * time.Hour)
+	if _, err := svc.Refresh(context.Background(), res.Tokens.RefreshToken, "fp-1"); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Refresh past max_session_age: err = %v, want ErrSessionExpired", err)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import "time"
+
+// RuntimeSettings are the AuthService settings that can change without a restart (config reload).
+type RuntimeSettings struct {
+	AccessTTL           time.Duration
+	RefreshTTL          time.Duration // session lifetime
+	DefaultTrustTTLDays int           // device trust TTL when platform settings have none
+	ResourceTokenTTL    time.Duration // maximum TokenExchange lifetime
+}
+
+// SetRuntimeSettings applies rs to new logins, refreshes and token exchanges; existing sessions and tokens keep
+// their expiry. Zero values keep the current setting. The token provider's TTLs are set separately.
+func (s *AuthService) SetRuntimeSettings(rs RuntimeSettings) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	if rs.AccessTTL > 0 {
+		s.accessTTL = rs.AccessTTL
+	}
+	if rs.RefreshTTL > 0 {
+		s.refreshTTL = rs.RefreshTTL
+	}
+	if rs.DefaultTrustTTLDays > 0 {
+		s.defaultTrustTTLDays = rs.DefaultTrustTTLDays
+	}
+	if rs.ResourceTokenTTL > 0 {
+		s.exchangeMaxTTL = rs.ResourceTokenTTL
+	}
+}
+
+func (s *AuthService) trustTTLDays() int {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.defaultTrustTTLDays
+}
+
+func (s *AuthService) sessionTTL() time.Duration {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.refreshTTL
+}
+
+func (s *AuthService) resourceTokenTTL() time.Duration {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.exchangeMaxTTL
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+	"zero-trust-control-plane/backend/internal/username"
+)
+
+// Reasons a username is not available (UsernameAvailability.Reason).
+const (
+	UsernameReasonInvalid  = "invalid"
+	UsernameReasonReserved = "reserved"
+	UsernameReasonTaken    = "taken"
+)
+
+// UsernameRepo persists usernames (e.g. *usernamerepo.PostgresRepository). orgID is "" for global usernames.
+type UsernameRepo interface {
+	GetUserID(ctx context.Context, orgID, name string) (string, error)
+	Get(ctx context.Context, userID, orgID string) (string, error)
+	Set(ctx context.Context, userID, orgID, name string, now time.Time) error
+	Delete(ctx context.Context, userID, orgID string) (bool, error)
+}
+
+// WithUsernames enables usernames: SetUsername and CheckUsernameAvailability, and Login and VerifyCredentials with a
+// username in place of the email. With username.ScopeOrg, usernames are unique within each org and resolved in the
+// org signed in to; otherwise (username.ScopeGlobal or "") they are unique across the platform.
+func WithUsernames(repo UsernameRepo, scope username.Scope) Option {
+	return func(s *AuthService) {
+		if scope != username.ScopeOrg {
+			scope = username.ScopeGlobal
+		}
+		s.usernames, s.usernameScope = repo, scope
+	}
+}
+
+// UsernameAvailability is returned by CheckUsernameAvailability. When the username is not available, Reason is
+// UsernameReasonInvalid, UsernameReasonReserved or UsernameReasonTaken and Message explains it.
+type UsernameAvailability struct {
+	Username  string // normalized
+	Available bool
+	Reason    string
+	Message   string
+}
+
+// CheckUsernameAvailability reports whether the caller can take name as their username: it follows the rules of
+// username.Validate and no other user holds it in the caller's scope. The caller's own username is available. The
+// caller is identified by the access token in ctx.
+func (s *AuthService) CheckUsernameAvailability(ctx context.Context, name string) (*UsernameAvailability, error) {
+	if s.usernames == nil {
+		return nil, ErrUsernamesDisabled
+	}
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, ErrInvalidCredentials
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	res := &UsernameAvailability{Username: username.Normalize(name)}
+	if err := username.Validate(res.Username); err != nil {
+		res.Reason, res.Message = UsernameReasonInvalid, err.Error()
+		if errors.Is(err, username.ErrReserved) {
+			res.Reason = UsernameReasonReserved
+		}
+		return res, nil
+	}
+	holder, err := s.usernames.GetUserID(ctx, s.usernameOrg(orgID), res.Username)
+	if err != nil {
+		return nil, err
+	}
+	if holder != "" && holder != userID {
+		res.Reason, res.Message = UsernameReasonTaken, ErrUsernameTaken.Error()
+		return res, nil
+	}
+	res.Available = true
+	return res, nil
+}
+
+// SetUsername sets, changes or (with an empty name) removes the caller's username, in the caller's org with
+// username.ScopeOrg. It returns the normalized username. It fails with an error wrapping username.ErrInvalid or
+// username.ErrReserved for names that break the rules, and ErrUsernameTaken when another user holds the name.
+// Audited as username_set or username_removed.
+func (s *AuthService) SetUsername(ctx context.Context, name string) (string, error) {
+	if s.usernames == nil {
+		return "", ErrUsernamesDisabled
+	}
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return "", ErrInvalidCredentials
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	scopeOrg := s.usernameOrg(orgID)
+	previous, err := s.usernames.Get(ctx, userID, scopeOrg)
+	if err != nil {
+		return "", err
+	}
+	name = username.Normalize(name)
+	if name == "" {
+		removed, err := s.usernames.Delete(ctx, userID, scopeOrg)
+		if err != nil {
+			return "", err
+		}
+		if removed && s.auditLogger != nil {
+			s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "username_removed", "authentication", `{"previous":"`+previous+`"}`)
+		}
+		return "", nil
+	}
+	if err := username.Validate(name); err != nil {
+		return "", err
+	}
+	if name == previous {
+		return name, nil
+	}
+	if err := s.usernames.Set(ctx, userID, scopeOrg, name, time.Now().UTC()); err != nil {
+		if errors.Is(err, username.ErrTaken) {
+			return "", ErrUsernameTaken
+		}
+		return "", err
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgOrSentinel(orgID), userID, "username_set", "authentication", `{"username":"`+name+`","previous":"`+previous+`"}`)
+	}
+	return name, nil
+}
+
+// loginUser returns the user signing in with identifier: the user with that email, or with WithUsernames, the user
+// holding that username (an identifier without '@') in the scope of orgID. Returns nil for an unknown identifier.
+func (s *AuthService) loginUser(ctx context.Context, identifier, orgID string) (*userdomain.User, error) {
+	if s.usernames == nil || !username.IsUsername(identifier) {
+		return s.userRepo.GetByEmail(ctx, identifier)
+	}
+	if s.usernameScope == username.ScopeOrg && orgID == "" {
+		return nil, nil
+	}
+	userID, err := s.usernames.GetUserID(ctx, s.usernameOrg(orgID), identifier)
+	if err != nil || userID == "" {
+		return nil, err
+	}
+	return s.userRepo.GetByID(ctx, userID)
+}
+
+// usernameOrg returns the org usernames are unique in for a caller in orgID: orgID with username.ScopeOrg, else "".
+func (s *AuthService) usernameOrg(orgID string) string {
+	if s.usernameScope == username.ScopeOrg {
+		return orgID
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	"zero-trust-control-plane/backend/internal/username"
+)
+
+// memUsernameRepo keys usernames by user ID and org ID ("" for global usernames).
+type memUsernameRepo struct {
+	mu sync.Mutex
+	m  map[[2]string]string
+}
+
+func (r *memUsernameRepo) GetUserID(ctx context.Context, orgID, name string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, v := range r.m {
+		if k[1] == orgID && v == name {
+			return k[0], nil
+		}
+	}
+	return "", nil
+}
+
+func (r *memUsernameRepo) Get(ctx context.Context, userID, orgID string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.m[[2]string{userID, orgID}], nil
+}
+
+func (r *memUsernameRepo) Set(ctx context.Context, userID, orgID, name string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, v := range r.m {
+		if k[1] == orgID && v == name && k[0] != userID {
+			return username.ErrTaken
+		}
+	}
+	r.m[[2]string{userID, orgID}] = name
+	return nil
+}
+
+func (r *memUsernameRepo) Delete(ctx context.Context, userID, orgID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := [2]string{userID, orgID}
+	_, ok := r.m[k]
+	delete(r.m, k)
+	return ok, nil
+}
+
+// withUsernames enables usernames in scope.
+func withUsernames(scope username.Scope) fixtureOption {
+	return withOptions(WithUsernames(&memUsernameRepo{m: make(map[[2]string]string)}, scope))
+}
+
+func TestAuthService_Username_SetAndLogin(t *testing.T) {
+	f := newTestFixture(t, withAuditLogger(), withUsernames(username.ScopeGlobal))
+	svc, auditLogger := f.svc, f.audit
+	reg, err := svc.Register(context.Background(), "ada@example.com", "Password123!abc", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	addTrustedMember(t, svc, reg.UserID, "org-1")
+	ctx := interceptors.WithIdentity(context.Background(), reg.UserID, "org-1", "s1")
+
+	if _, err := svc.SetUsername(context.Background(), "ada"); err != ErrInvalidCredentials {
+		t.Errorf("no caller: want ErrInvalidCredentials, got %v", err)
+	}
+	name, err := svc.SetUsername(ctx, " Ada.Lovelace ")
+	if err != nil || name != "ada.lovelace" {
+		t.Fatalf("SetUsername = %q, %v; want ada.lovelace", name, err)
+	}
+	if !auditLogger.hasAction("username_set") {
+		t.Errorf("audit events = %+v, want username_set", auditLogger.events)
+	}
+	if res, err := svc.CheckUsernameAvailability(ctx, "ada.lovelace"); err != nil || !res.Available {
+		t.Errorf("own username: %+v, %v; want available", res, err)
+	}
+
+	for _, identifier := range []string{"ada.lovelace", "ADA.Lovelace", "ada@example.com"} {
+		res, err := svc.Login(context.Background(), identifier, "Password123!abc", "org-1", "")
+		if err != nil || res.Tokens == nil || res.Tokens.UserID != reg.UserID {
+			t.Errorf("Login(%q) = %+v, %v; want tokens for the user", identifier, res, err)
+		}
+	}
+	if _, err := svc.Login(context.Background(), "nobody", "Password123!abc", "org-1", ""); err != ErrInvalidCredentials {
+		t.Errorf("Login with an unknown username: want ErrInvalidCredentials, got %v", err)
+	}
+	if res, err := svc.VerifyCredentials(context.Background(), "ada.lovelace", "Password123!abc", "", ""); err != nil || !res.Valid {
+		t.Errorf("VerifyCredentials by username = %+v, %v", res, err)
+	}
+
+	if name, err := svc.SetUsername(ctx, "lovelace"); err != nil || name != "lovelace" {
+		t.Fatalf("change: %q, %v", name, err)
+	}
+	if _, err := svc.Login(context.Background(), "ada.lovelace", "Password123!abc", "org-1", ""); err != ErrInvalidCredentials {
+		t.Errorf("Login with the previous username: want ErrInvalidCredentials, got %v", err)
+	}
+	if name, err := svc.SetUsername(ctx, ""); err != nil || name != "" {
+		t.Fatalf("clear: %q, %v", name, err)
+	}
+	if !auditLogger.hasAction("username_removed") {
+		t.Errorf("audit events = %+v, want username_removed", auditLogger.events)
+	}
+	if _, err := svc.Login(context.Background(), "lovelace", "Password123!abc", "org-1", ""); err != ErrInvalidCredentials {
+		t.Errorf("Login after clearing: want ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestAuthService_Username_Rejected(t *testing.T) {
+	svc := newTestFixture(t, withUsernames(username.ScopeGlobal)).svc
+	first, _ := svc.Register(context.Background(), "first@example.com", "Password123!abc", "")
+	second, _ := svc.Register(context.Background(), "second@example.com", "Password123!abc", "")
+	ctx1 := interceptors.WithIdentity(context.Background(), first.UserID, "org-1", "s1")
+	ctx2 := interceptors.WithIdentity(context.Background(), second.UserID, "org-2", "s2")
+	if _, err := svc.SetUsername(ctx1, "ada"); err != nil {
+		t.Fatalf("SetUsername: %v", err)
+	}
+
+	if _, err := svc.SetUsername(ctx2, "ADA"); err != ErrUsernameTaken {
+		t.Errorf("taken: want ErrUsernameTaken, got %v", err)
+	}
+	if _, err := svc.SetUsername(ctx2, "a"); !errors.Is(err, username.ErrInvalid) {
+		t.Errorf("too short: want ErrInvalid, got %v", err)
+	}
+	if _, err := svc.SetUsername(ctx2, "admin"); !errors.Is(err, username.ErrReserved) {
+		t.Errorf("reserved: want ErrReserved, got %v", err)
+	}
+	for name, reason := range map[string]string{
+		"ada":       UsernameReasonTaken,
+		"admin":     UsernameReasonReserved,
+		"ada@x.com": UsernameReasonInvalid,
+		"grace":     "",
+	} {
+		res, err := svc.CheckUsernameAvailability(ctx2, name)
+		if err != nil || res.Reason != reason || res.Available != (reason == "") || (reason != "" && res.Message == "") {
+			t.Errorf("CheckUsernameAvailability(%q) = %+v, %v; want reason %q", name, res, err, reason)
+		}
+	}
+}
+
+func TestAuthService_Username_OrgScope(t *testing.T) {
+	svc := newTestFixture(t, withUsernames(username.ScopeOrg)).svc
+	first, _ := svc.Register(context.Background(), "first@example.com", "Password123!abc", "")
+	second, _ := svc.Register(context.Background(), "second@example.com", "Password123!abc", "")
+	addTrustedMember(t, svc, first.UserID, "org-1")
+	addTrustedMember(t, svc, second.UserID, "org-2")
+	if _, err := svc.SetUsername(interceptors.WithIdentity(context.Background(), first.UserID, "org-1", "s1"), "ada"); err != nil {
+		t.Fatalf("SetUsername in org-1: %v", err)
+	}
+	if _, err := svc.SetUsername(interceptors.WithIdentity(context.Background(), second.UserID, "org-2", "s2"), "ada"); err != nil {
+		t.Fatalf("the same username in org-2: %v", err)
+	}
+	for orgID, userID := range map[string]string{"org-1": first.UserID, "org-2": second.UserID} {
+		res, err := svc.Login(context.Background(), "ada", "Password123!abc", orgID, "")
+		if err != nil || res.Tokens == nil || res.Tokens.UserID != userID {
+			t.Errorf("Login(ada, %s) = %+v, %v; want tokens for %s", orgID, res, err, userID)
+		}
+	}
+	if _, err := svc.Login(context.Background(), "ada", "Password123!abc", "", ""); err != ErrInvalidCredentials {
+		t.Errorf("Login by username without an org: want ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestAuthService_Username_Disabled(t *testing.T) {
+	svc, _ := newTestAuthService(t)
+	ctx := interceptors.WithIdentity(context.Background(), "u1", "org-1", "s1")
+	if _, err := svc.SetUsername(ctx, "ada"); err != ErrUsernamesDisabled {
+		t.Errorf("SetUsername: want ErrUsernamesDisabled, got %v", err)
+	}
+	if _, err := svc.CheckUsernameAvailability(ctx, "ada"); err != ErrUsernamesDisabled {
+		t.Errorf("CheckUsernameAvailability: want ErrUsernamesDisabled, got %v", err)
+	}
+}
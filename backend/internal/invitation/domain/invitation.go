@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// TokenPrefix starts every invitation token, so leaked tokens are easy to recognise.
+const TokenPrefix = "ztcp_inv_"
+
+// DefaultTTL is how long an invitation can be accepted when its creator sets no expiry.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// MaxTTL caps the expiry of an invitation.
+const MaxTTL = 30 * 24 * time.Hour
+
+// Status is the state of an invitation, derived from its timestamps.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusAccepted Status = "accepted"
+	StatusRevoked  Status = "revoked"
+	StatusExpired  Status = "expired"
+)
+
+// Invitation lets the holder of its token register with Email while registration is invite-only, and adds the new
+// user to OrgID with Role. Only the hash of its token is stored.
+type Invitation struct {
+	ID         string
+	OrgID      string
+	Email      string // lower-cased
+	Role       membershipdomain.Role
+	TokenHash  string
+	InvitedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	AcceptedBy string
+	RevokedAt  *time.Time
+}
+
+// Status returns the state of the invitation at now.
+func (i *Invitation) Status(now time.Time) Status {
+	switch {
+	case i.AcceptedAt != nil:
+		return StatusAccepted
+	case i.RevokedAt != nil:
+		return StatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return StatusExpired
+	default:
+		return StatusPending
+	}
+}
+
+// Usable reports whether the invitation can still be accepted at now.
+func (i *Invitation) Usable(now time.Time) bool {
+	return i.Status(now) == StatusPending
+}
+
+// NewToken returns a new random invitation token and its hash.
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, security.HashToken(token), nil
+}
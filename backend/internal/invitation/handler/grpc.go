@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	invitationv1 "zero-trust-control-plane/backend/api/generated/invitation/v1"
+	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/invitation/domain"
+	"zero-trust-control-plane/backend/internal/invitation/repository"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// Server implements InvitationService (proto server).
+// Proto: invitation/invitation.proto → internal/invitation/handler.
+type Server struct {
+	invitationv1.UnimplementedInvitationServiceServer
+	repo           repository.Repository
+	membershipRepo rbac.OrgMembershipGetter
+	auditLogger    audit.AuditLogger
+}
+
+// NewServer returns a new Invitation gRPC server. If repo or membershipRepo is nil, all RPCs return Unimplemented.
+// auditLogger may be nil.
+func NewServer(repo repository.Repository, membershipRepo rbac.OrgMembershipGetter, auditLogger audit.AuditLogger) *Server {
+	return &Server{repo: repo, membershipRepo: membershipRepo, auditLogger: auditLogger}
+}
+
+// CreateInvitation creates a pending invitation to the caller's org and returns its token once. Caller must be org
+// admin or owner; inviting an admin needs a standing admin, as for AddMember.
+func (s *Server) CreateInvitation(ctx context.Context, req *invitationv1.CreateInvitationRequest) (*invitationv1.CreateInvitationResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method CreateInvitation not implemented")
+	}
+	orgID, userID, err := s.requireAdmin(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+	email := strings.TrimSpace(strings.ToLower(req.GetEmail()))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return nil, status.Error(codes.InvalidArgument, "a valid email is required")
+	}
+	var role membershipdomain.Role
+	switch req.GetRole() {
+	case membershipv1.Role_ROLE_UNSPECIFIED, membershipv1.Role_ROLE_MEMBER:
+		role = membershipdomain.RoleMember
+	case membershipv1.Role_ROLE_ADMIN:
+		if _, _, err := rbac.RequireStandingOrgAdmin(ctx, s.membershipRepo); err != nil {
+			return nil, err
+		}
+		role = membershipdomain.RoleAdmin
+	default:
+		return nil, status.Error(codes.InvalidArgument, "role must be admin or member")
+	}
+	ttl := domain.DefaultTTL
+	if h := req.GetTtlHours(); h != 0 {
+		ttl = time.Duration(h) * time.Hour
+		if ttl < time.Hour || ttl > domain.MaxTTL {
+			return nil, status.Errorf(codes.InvalidArgument, "ttl_hours must be between 1 and %d", int(domain.MaxTTL/time.Hour))
+		}
+	}
+	token, hash, err := domain.NewToken()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create invitation")
+	}
+	now := time.Now().UTC()
+	inv := &domain.Invitation{
+		ID:        uuid.New().String(),
+		OrgID:     orgID,
+		Email:     email,
+		Role:      role,
+		TokenHash: hash,
+		InvitedBy: userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.repo.Create(ctx, inv); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create invitation")
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "invitation_created", "invitation", inv.ID)
+	}
+	return &invitationv1.CreateInvitationResponse{Invitation: invitationToProto(inv, now), Token: token}, nil
+}
+
+// ListInvitations returns the invitations of the caller's org, newest first. Caller must be org admin or owner.
+func (s *Server) ListInvitations(ctx context.Context, req *invitationv1.ListInvitationsRequest) (*invitationv1.ListInvitationsResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListInvitations not implemented")
+	}
+	orgID, _, err := s.requireAdmin(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.repo.ListByOrg(ctx, orgID, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list invitations")
+	}
+	now := time.Now().UTC()
+	out := make([]*invitationv1.Invitation, len(list))
+	for i, inv := range list {
+		out[i] = invitationToProto(inv, now)
+	}
+	result := &invitationv1.ListInvitationsResponse{
+		Invitations: out,
+		Pagination:  &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// RevokeInvitation revokes a pending invitation of the caller's org. Caller must be org admin or owner.
+func (s *Server) RevokeInvitation(ctx context.Context, req *invitationv1.RevokeInvitationRequest) (*invitationv1.RevokeInvitationResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RevokeInvitation not implemented")
+	}
+	orgID, userID, err := s.requireAdmin(ctx, req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	ok, err := s.repo.Revoke(ctx, orgID, req.GetId(), time.Now().UTC())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke invitation")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no pending invitation with this id")
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "invitation_revoked", "invitation", req.GetId())
+	}
+	return &invitationv1.RevokeInvitationResponse{}, nil
+}
+
+// requireAdmin checks that the caller is an admin of the context org and that reqOrgID, when set, is that org.
+func (s *Server) requireAdmin(ctx context.Context, reqOrgID string) (orgID, userID string, err error) {
+	orgID, userID, err = rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return "", "", err
+	}
+	if reqOrgID != "" && reqOrgID != orgID {
+		return "", "", status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	return orgID, userID, nil
+}
+
+func invitationToProto(inv *domain.Invitation, now time.Time) *invitationv1.Invitation {
+	out := &invitationv1.Invitation{
+		Id:         inv.ID,
+		OrgId:      inv.OrgID,
+		Email:      inv.Email,
+		Role:       membershipv1.Role_ROLE_MEMBER,
+		Status:     statusToProto(inv.Status(now)),
+		InvitedBy:  inv.InvitedBy,
+		CreatedAt:  timestamppb.New(inv.CreatedAt),
+		ExpiresAt:  timestamppb.New(inv.ExpiresAt),
+		AcceptedBy: inv.AcceptedBy,
+	}
+	if inv.Role == membershipdomain.RoleAdmin {
+		out.Role = membershipv1.Role_ROLE_ADMIN
+	}
+	if inv.AcceptedAt != nil {
+		out.AcceptedAt = timestamppb.New(*inv.AcceptedAt)
+	}
+	if inv.RevokedAt != nil {
+		out.RevokedAt = timestamppb.New(*inv.RevokedAt)
+	}
+	return out
+}
+
+func statusToProto(st domain.Status) invitationv1.InvitationStatus {
+	switch st {
+	case domain.StatusPending:
+		return invitationv1.InvitationStatus_INVITATION_STATUS_PENDING
+	case domain.StatusAccepted:
+		return invitationv1.InvitationStatus_INVITATION_STATUS_ACCEPTED
+	case domain.StatusRevoked:
+		return invitationv1.InvitationStatus_INVITATION_STATUS_REVOKED
+	case domain.StatusExpired:
+		return invitationv1.InvitationStatus_INVITATION_STATUS_EXPIRED
+	default:
+		return invitationv1.InvitationStatus_INVITATION_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	invitationv1 "zero-trust-control-plane/backend/api/generated/invitation/v1"
+	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+	"zero-trust-control-plane/backend/internal/invitation/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/security"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type memRepo struct {
+	list []*domain.Invitation
+}
+
+func (m *memRepo) Create(ctx context.Context, inv *domain.Invitation) error {
+	m.list = append([]*domain.Invitation{inv}, m.list...)
+	return nil
+}
+
+func (m *memRepo) GetByTokenHash(ctx context.Context, hash string) (*domain.Invitation, error) {
+	for _, inv := range m.list {
+		if inv.TokenHash == hash {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRepo) ListByOrg(ctx context.Context, orgID string, limit, offset int32) ([]*domain.Invitation, error) {
+	var out []*domain.Invitation
+	for _, inv := range m.list {
+		if inv.OrgID == orgID {
+			out = append(out, inv)
+		}
+	}
+	if int(offset) >= len(out) {
+		return nil, nil
+	}
+	out = out[offset:]
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memRepo) Revoke(ctx context.Context, orgID, id string, now time.Time) (bool, error) {
+	for _, inv := range m.list {
+		if inv.ID == id && inv.OrgID == orgID && inv.AcceptedAt == nil && inv.RevokedAt == nil {
+			inv.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memRepo) Accept(ctx context.Context, inv *domain.Invitation, userID string, now time.Time) (*membershipdomain.Membership, bool, error) {
+	return nil, false, nil
+}
+
+type mockMembershipRepo map[string]*membershipdomain.Membership
+
+func (m mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	mem, ok := m[userID]
+	if !ok || orgID != "org-1" {
+		return nil, nil
+	}
+	return mem, nil
+}
+
+type mockAuditLogger struct {
+	actions []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+}
+
+func newTestServer() (*Server, *memRepo, *mockAuditLogger) {
+	repo := &memRepo{}
+	auditLogger := &mockAuditLogger{}
+	elevated := time.Now().Add(time.Hour)
+	members := mockMembershipRepo{
+		"admin-1":    {UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"elevated-1": {UserID: "elevated-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin, ElevatedUntil: &elevated},
+		"member-1":   {UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}
+	return NewServer(repo, members, auditLogger), repo, auditLogger
+}
+
+func TestInvitations_CreateListRevoke(t *testing.T) {
+	srv, repo, auditLogger := newTestServer()
+	ctx := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+
+	created, err := srv.CreateInvitation(ctx, &invitationv1.CreateInvitationRequest{Email: " New@Example.com ", TtlHours: 48})
+	if err != nil {
+		t.Fatalf("CreateInvitation: %v", err)
+	}
+	inv := created.GetInvitation()
+	if inv.GetEmail() != "new@example.com" || inv.GetRole() != membershipv1.Role_ROLE_MEMBER || inv.GetStatus() != invitationv1.InvitationStatus_INVITATION_STATUS_PENDING {
+		t.Errorf("invitation = %+v", inv)
+	}
+	if got := inv.GetExpiresAt().AsTime().Sub(inv.GetCreatedAt().AsTime()); got != 48*time.Hour {
+		t.Errorf("expiry after %v, want 48h", got)
+	}
+	if stored, _ := repo.GetByTokenHash(ctx, security.HashToken(created.GetToken())); stored == nil || stored.ID != inv.GetId() {
+		t.Error("the returned token should hash to the stored invitation")
+	}
+
+	list, err := srv.ListInvitations(ctx, &invitationv1.ListInvitationsRequest{})
+	if err != nil {
+		t.Fatalf("ListInvitations: %v", err)
+	}
+	if len(list.GetInvitations()) != 1 || list.GetInvitations()[0].GetId() != inv.GetId() {
+		t.Errorf("list = %+v", list.GetInvitations())
+	}
+
+	if _, err := srv.RevokeInvitation(ctx, &invitationv1.RevokeInvitationRequest{Id: inv.GetId()}); err != nil {
+		t.Fatalf("RevokeInvitation: %v", err)
+	}
+	if _, err := srv.RevokeInvitation(ctx, &invitationv1.RevokeInvitationRequest{Id: inv.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("second revoke: code = %v, want NotFound", status.Code(err))
+	}
+	list, _ = srv.ListInvitations(ctx, &invitationv1.ListInvitationsRequest{})
+	if st := list.GetInvitations()[0].GetStatus(); st != invitationv1.InvitationStatus_INVITATION_STATUS_REVOKED {
+		t.Errorf("status after revoke = %v", st)
+	}
+	if want := []string{"invitation_created", "invitation_revoked"}; len(auditLogger.actions) != 2 || auditLogger.actions[0] != want[0] || auditLogger.actions[1] != want[1] {
+		t.Errorf("audit actions = %v, want %v", auditLogger.actions, want)
+	}
+}
+
+func TestInvitations_Errors(t *testing.T) {
+	srv, repo, _ := newTestServer()
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	elevated := interceptors.WithIdentity(context.Background(), "elevated-1", "org-1", "session-2")
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-3")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *invitationv1.CreateInvitationRequest
+		code codes.Code
+	}{
+		{"member", member, &invitationv1.CreateInvitationRequest{Email: "a@example.com"}, codes.PermissionDenied},
+		{"other org", admin, &invitationv1.CreateInvitationRequest{OrgId: "org-2", Email: "a@example.com"}, codes.PermissionDenied},
+		{"bad email", admin, &invitationv1.CreateInvitationRequest{Email: "not-an-email"}, codes.InvalidArgument},
+		{"display name", admin, &invitationv1.CreateInvitationRequest{Email: "A <a@example.com>"}, codes.InvalidArgument},
+		{"owner role", admin, &invitationv1.CreateInvitationRequest{Email: "a@example.com", Role: membershipv1.Role_ROLE_OWNER}, codes.InvalidArgument},
+		{"admin by elevated admin", elevated, &invitationv1.CreateInvitationRequest{Email: "a@example.com", Role: membershipv1.Role_ROLE_ADMIN}, codes.PermissionDenied},
+		{"ttl too long", admin, &invitationv1.CreateInvitationRequest{Email: "a@example.com", TtlHours: 721}, codes.InvalidArgument},
+		{"negative ttl", admin, &invitationv1.CreateInvitationRequest{Email: "a@example.com", TtlHours: -1}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if _, err := srv.CreateInvitation(tt.ctx, tt.req); status.Code(err) != tt.code {
+			t.Errorf("%s: code = %v, want %v (%v)", tt.name, status.Code(err), tt.code, err)
+		}
+	}
+	if len(repo.list) != 0 {
+		t.Errorf("rejected requests stored %d invitations", len(repo.list))
+	}
+	if _, err := srv.CreateInvitation(admin, &invitationv1.CreateInvitationRequest{Email: "a@example.com", Role: membershipv1.Role_ROLE_ADMIN}); err != nil {
+		t.Errorf("admin invitation by standing admin: %v", err)
+	}
+	if _, err := srv.RevokeInvitation(admin, &invitationv1.RevokeInvitationRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("revoke without id: code = %v, want InvalidArgument", status.Code(err))
+	}
+	if _, err := NewServer(nil, nil, nil).ListInvitations(admin, &invitationv1.ListInvitationsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("without repo: code = %v, want Unimplemented", status.Code(err))
+	}
+}
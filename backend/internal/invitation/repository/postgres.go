@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/invitation/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an invitation repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db)}
+}
+
+// Create persists a new pending invitation.
+func (r *PostgresRepository) Create(ctx context.Context, inv *domain.Invitation) error {
+	return r.queries.CreateOrgInvitation(ctx, gen.CreateOrgInvitationParams{
+		ID:        inv.ID,
+		OrgID:     inv.OrgID,
+		Email:     inv.Email,
+		Role:      gen.Role(inv.Role),
+		TokenHash: inv.TokenHash,
+		InvitedBy: inv.InvitedBy,
+		CreatedAt: inv.CreatedAt,
+		ExpiresAt: inv.ExpiresAt,
+	})
+}
+
+// GetByTokenHash returns the invitation, or nil if not found.
+func (r *PostgresRepository) GetByTokenHash(ctx context.Context, hash string) (*domain.Invitation, error) {
+	row, err := r.queries.GetOrgInvitationByTokenHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genInvitationToDomain(row), nil
+}
+
+// ListByOrg returns the org's invitations, newest first.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, limit, offset int32) ([]*domain.Invitation, error) {
+	rows, err := r.queries.ListOrgInvitationsByOrg(ctx, gen.ListOrgInvitationsByOrgParams{OrgID: orgID, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Invitation, len(rows))
+	for i, row := range rows {
+		out[i] = genInvitationToDomain(row)
+	}
+	return out, nil
+}
+
+// Revoke revokes a pending invitation of the org.
+func (r *PostgresRepository) Revoke(ctx context.Context, orgID, id string, now time.Time) (bool, error) {
+	n, err := r.queries.RevokeOrgInvitation(ctx, gen.RevokeOrgInvitationParams{Now: now, ID: id, OrgID: orgID})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Accept marks the invitation accepted and creates the membership in one transaction. Returns
+// orgquota.ErrUserQuotaExceeded, leaving the invitation pending, when the org has reached max_users.
+func (r *PostgresRepository) Accept(ctx context.Context, inv *domain.Invitation, userID string, now time.Time) (*membershipdomain.Membership, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	n, err := q.AcceptOrgInvitation(ctx, gen.AcceptOrgInvitationParams{
+		Now:    now,
+		UserID: sql.NullString{String: userID, Valid: true},
+		ID:     inv.ID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if n == 0 {
+		return nil, false, nil
+	}
+	if err := orgquota.ReserveUser(ctx, q, inv.OrgID); err != nil {
+		return nil, false, err
+	}
+	m := &membershipdomain.Membership{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		OrgID:     inv.OrgID,
+		Role:      inv.Role,
+		CreatedAt: now,
+	}
+	if _, err := q.CreateMembership(ctx, gen.CreateMembershipParams{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		OrgID:     m.OrgID,
+		Role:      gen.Role(m.Role),
+		CreatedAt: m.CreatedAt,
+	}); err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return m, true, nil
+}
+
+func genInvitationToDomain(row gen.OrgInvitation) *domain.Invitation {
+	inv := &domain.Invitation{
+		ID:         row.ID,
+		OrgID:      row.OrgID,
+		Email:      row.Email,
+		Role:       membershipdomain.Role(row.Role),
+		TokenHash:  row.TokenHash,
+		InvitedBy:  row.InvitedBy,
+		CreatedAt:  row.CreatedAt,
+		ExpiresAt:  row.ExpiresAt,
+		AcceptedBy: row.AcceptedBy.String,
+	}
+	if row.AcceptedAt.Valid {
+		t := row.AcceptedAt.Time
+		inv.AcceptedAt = &t
+	}
+	if row.RevokedAt.Valid {
+		t := row.RevokedAt.Time
+		inv.RevokedAt = &t
+	}
+	return inv
+}
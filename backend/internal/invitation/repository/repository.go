@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/invitation/domain"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+)
+
+// Repository persists org invitations.
+type Repository interface {
+	// Create persists a new pending invitation. The invitation must have ID set.
+	Create(ctx context.Context, inv *domain.Invitation) error
+	// GetByTokenHash returns the invitation with this token hash, or nil if not found.
+	GetByTokenHash(ctx context.Context, hash string) (*domain.Invitation, error)
+	// ListByOrg returns the org's invitations, newest first.
+	ListByOrg(ctx context.Context, orgID string, limit, offset int32) ([]*domain.Invitation, error)
+	// Revoke revokes a pending invitation of the org. It reports false when there is none with this ID.
+	Revoke(ctx context.Context, orgID, id string, now time.Time) (bool, error)
+	// Accept marks the invitation accepted by userID and adds the user to the invitation's org with its role, in
+	// one transaction. It reports false, without changing anything, when the invitation was already accepted, was
+	// revoked or has expired at now, so an invitation is accepted at most once even under concurrent requests.
+	Accept(ctx context.Context, inv *domain.Invitation, userID string, now time.Time) (*membershipdomain.Membership, bool, error)
+}
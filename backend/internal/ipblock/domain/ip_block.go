@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// IPBlock temporarily denies sign-in from a client IP (e.g. after the anomaly detector flags credential stuffing).
+type IPBlock struct {
+	IP        string
+	Reason    string // detector rule that triggered the block, e.g. "credential_stuffing"
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/ipblock/domain"
+)
+
+// PostgresRepository implements Repository using Postgres.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an IP block repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Block inserts or extends the block for ip.
+func (r *PostgresRepository) Block(ctx context.Context, ip, reason string, until time.Time) error {
+	return r.queries.UpsertIPBlock(ctx, gen.UpsertIPBlockParams{
+		Ip:        ip,
+		Reason:    reason,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: until.UTC(),
+	})
+}
+
+// GetActive returns the block for ip that is still in effect at the given time, or nil if none.
+func (r *PostgresRepository) GetActive(ctx context.Context, ip string, at time.Time) (*domain.IPBlock, error) {
+	b, err := r.queries.GetActiveIPBlock(ctx, gen.GetActiveIPBlockParams{Ip: ip, ExpiresAt: at.UTC()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.IPBlock{IP: b.Ip, Reason: b.Reason, CreatedAt: b.CreatedAt, ExpiresAt: b.ExpiresAt}, nil
+}
+
+// IsBlocked reports whether ip is blocked now.
+func (r *PostgresRepository) IsBlocked(ctx context.Context, ip string) (bool, error) {
+	b, err := r.GetActive(ctx, ip, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return b != nil, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/ipblock/domain"
+)
+
+// Repository persists temporary IP blocks.
+type Repository interface {
+	// Block denies sign-in from ip until the given time. If ip is already blocked, the later expiry wins.
+	Block(ctx context.Context, ip, reason string, until time.Time) error
+	// GetActive returns the unexpired block for ip at the given time, or nil if ip is not blocked.
+	GetActive(ctx context.Context, ip string, at time.Time) (*domain.IPBlock, error)
+	// IsBlocked reports whether ip currently has an unexpired block.
+	IsBlocked(ctx context.Context, ip string) (bool, error)
+}
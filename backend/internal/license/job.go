@@ -0,0 +1,108 @@
+package license
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit"
+)
+
+// WarningDays are the days before expiry at which the Job warns that the license is expiring, longest first.
+var WarningDays = []int{30, 14, 7, 1}
+
+// Job reloads the license file, so a renewed license applies without a restart, and audits warnings under the
+// _system org as the license approaches expiry, enters and leaves its grace period, and runs out of seats. Each
+// warning is audited once per server instance; a restart audits the current one again.
+type Job struct {
+	manager     *Manager
+	auditLogger audit.AuditLogger
+
+	expiryStage string // license ID and last expiry warning
+	seatsFull   bool
+}
+
+// NewJob returns a Job for manager. auditLogger may be nil; warnings are then only logged.
+func NewJob(manager *Manager, auditLogger audit.AuditLogger) *Job {
+	return &Job{manager: manager, auditLogger: auditLogger}
+}
+
+// RunOnce reloads the license file and emits the warnings that became due. Returns the reload error, if any; the
+// warnings of the license loaded before are still checked.
+func (j *Job) RunOnce(ctx context.Context) error {
+	loadErr := j.manager.Load()
+	st, err := j.manager.Status(ctx)
+	if err != nil {
+		log.Printf("license: failed to count seats: %v", err)
+		return loadErr
+	}
+	l := st.License
+	if l == nil {
+		return loadErr
+	}
+	now := j.manager.now().UTC()
+	stage, action, meta := expiryWarning(l, now)
+	if stage == "" {
+		j.expiryStage = ""
+	} else if key := l.ID + "/" + stage; key != j.expiryStage {
+		j.expiryStage = key
+		j.warn(ctx, action, meta)
+	}
+	full := l.Seats > 0 && st.SeatsUsed >= int64(l.Seats)
+	if full && !j.seatsFull {
+		j.warn(ctx, "license_seats_exhausted", map[string]interface{}{
+			"license_id": l.ID, "seats": l.Seats, "seats_used": st.SeatsUsed,
+		})
+	}
+	j.seatsFull = full
+	return loadErr
+}
+
+// Run calls RunOnce every interval until ctx is done.
+func (j *Job) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := j.RunOnce(ctx); err != nil {
+			log.Printf("license: failed to reload license file, keeping the loaded license: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expiryWarning returns the expiry warning due for l at now: its stage, audit action and metadata. The stage is ""
+// while no warning is due.
+func expiryWarning(l *License, now time.Time) (stage, action string, meta map[string]interface{}) {
+	meta = map[string]interface{}{"license_id": l.ID, "expires_at": l.ExpiresAt.Format(time.RFC3339)}
+	switch l.StateAt(now) {
+	case StateExpired:
+		meta["grace_ended_at"] = l.GraceEndsAt().Format(time.RFC3339)
+		return "expired", "license_expired", meta
+	case StateGrace:
+		meta["grace_ends_at"] = l.GraceEndsAt().Format(time.RFC3339)
+		return "grace", "license_grace_period", meta
+	}
+	days := l.DaysRemaining(now)
+	stage = ""
+	for _, w := range WarningDays {
+		if days <= w {
+			stage = fmt.Sprintf("expiring:%d", w)
+		}
+	}
+	meta["days_left"] = days
+	return stage, "license_expiring", meta
+}
+
+func (j *Job) warn(ctx context.Context, action string, meta map[string]interface{}) {
+	b, _ := json.Marshal(meta)
+	log.Printf("license: %s %s", action, b)
+	if j.auditLogger != nil {
+		j.auditLogger.LogEvent(ctx, audit.SentinelOrgID, "", action, "license", string(b))
+	}
+}
@@ -0,0 +1,261 @@
+// Package license enforces the license file of a self-hosted deployment. The file is a JWT signed with the vendor's
+// license key and verified with its public key (security.ParseLicense). It limits the users of the deployment
+// (seats) and names the licensed features; an expired license keeps working for a grace period. Without a license
+// file nothing is enforced, as on the hosted service; a license file that is configured but not loaded fails closed,
+// as if it had expired. Status is served by AdminService.GetLicenseStatus and warnings are audited by the Job as
+// expiry approaches.
+package license
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// DefaultGraceDays is the grace period of licenses that do not set grace_days.
+const DefaultGraceDays = 14
+
+// PublicKey is the vendor's license public key (PEM). Release builds set it with
+// -ldflags "-X zero-trust-control-plane/backend/internal/license.PublicKey=...", so the key cannot be swapped by
+// configuration; LICENSE_PUBLIC_KEY is used only when it is empty.
+var PublicKey string
+
+// State is where a license is in its lifetime.
+type State string
+
+const (
+	// StateUnlicensed is a deployment without a license file; nothing is enforced.
+	StateUnlicensed State = "unlicensed"
+	// StateValid is a license that does not expire within the first warning (WarningDays).
+	StateValid State = "valid"
+	// StateExpiring is a valid license that expires within the first warning.
+	StateExpiring State = "expiring"
+	// StateGrace is an expired license within its grace period; everything keeps working.
+	StateGrace State = "grace"
+	// StateExpired is a license past its grace period, or a license file that has not loaded: licensed features are
+	// refused and no users can be added.
+	StateExpired State = "expired"
+)
+
+var (
+	// ErrSeatLimitReached is returned by ReserveSeat when the deployment already has as many users as the license seats.
+	ErrSeatLimitReached = errors.New("license: seat limit reached")
+	// ErrLicenseExpired is returned by ReserveSeat when the license is past its grace period or has not loaded.
+	ErrLicenseExpired = errors.New("license: license expired")
+)
+
+// License is a verified license file.
+type License struct {
+	ID        string
+	Customer  string
+	Seats     int // 0 is unlimited
+	Features  []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	GraceDays int
+}
+
+// GraceEndsAt returns when the grace period after expiry ends.
+func (l *License) GraceEndsAt() time.Time {
+	return l.ExpiresAt.Add(time.Duration(l.GraceDays) * 24 * time.Hour)
+}
+
+// Includes reports whether feature is licensed.
+func (l *License) Includes(feature string) bool {
+	for _, f := range l.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// StateAt returns the state of l at now.
+func (l *License) StateAt(now time.Time) State {
+	switch {
+	case !now.Before(l.GraceEndsAt()):
+		return StateExpired
+	case !now.Before(l.ExpiresAt):
+		return StateGrace
+	case l.ExpiresAt.Sub(now) <= time.Duration(WarningDays[0])*24*time.Hour:
+		return StateExpiring
+	default:
+		return StateValid
+	}
+}
+
+// DaysRemaining returns the whole days until l expires at now, rounded up; 0 once it has expired.
+func (l *License) DaysRemaining(now time.Time) int {
+	if !now.Before(l.ExpiresAt) {
+		return 0
+	}
+	return int(math.Ceil(l.ExpiresAt.Sub(now).Hours() / 24))
+}
+
+// UserCounter counts the users that take a seat. Implemented by the user Postgres repository.
+type UserCounter interface {
+	CountLicensed(ctx context.Context) (int64, error)
+}
+
+// SeatQueries is the part of *gen.Queries ReserveSeat uses. Pass the queries of the transaction that creates the user.
+type SeatQueries interface {
+	LockLicenseSeats(ctx context.Context) error
+	CountLicensedUsers(ctx context.Context) (int64, error)
+}
+
+// SeatReserver reserves a license seat inside the transaction that creates a user (e.g. *license.Manager).
+type SeatReserver interface {
+	ReserveSeat(ctx context.Context, q SeatQueries) error
+}
+
+// Status is a snapshot of the license and its seats.
+type Status struct {
+	State     State
+	License   *License // nil when unlicensed
+	SeatsUsed int64
+}
+
+// Manager holds the loaded license and enforces it. A nil *Manager, or one without a license file, enforces nothing.
+// One with a license file fails closed until Load succeeds: it is expired, so no users are added and every licensed
+// feature is refused. Safe for concurrent use.
+type Manager struct {
+	path  string
+	key   crypto.PublicKey
+	users UserCounter
+	now   func() time.Time
+
+	mu      sync.RWMutex
+	license *License
+}
+
+// NewManager returns a Manager for the license file at path, verified with key. An empty path is an unlicensed
+// deployment. Call Load before serving.
+func NewManager(path string, key crypto.PublicKey, users UserCounter) *Manager {
+	return &Manager{path: path, key: key, users: users, now: time.Now}
+}
+
+// Load reads and verifies the license file. On error the license loaded before is kept, so a bad file dropped in
+// place of a good one does not take the deployment down.
+func (m *Manager) Load() error {
+	if m == nil || m.path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(m.path)
+	if err != nil {
+		return err
+	}
+	claims, err := security.ParseLicense(string(content), m.key)
+	if err != nil {
+		return err
+	}
+	l := &License{
+		ID:        claims.ID,
+		Customer:  claims.Subject,
+		Seats:     claims.Seats,
+		Features:  claims.Features,
+		ExpiresAt: claims.ExpiresAt.Time.UTC(),
+		GraceDays: claims.GraceDays,
+	}
+	if claims.IssuedAt != nil {
+		l.IssuedAt = claims.IssuedAt.Time.UTC()
+	}
+	if l.GraceDays == 0 {
+		l.GraceDays = DefaultGraceDays
+	}
+	m.mu.Lock()
+	m.license = l
+	m.mu.Unlock()
+	return nil
+}
+
+// License returns the loaded license, or nil when unlicensed.
+func (m *Manager) License() *License {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.license
+}
+
+// State returns the current state of the license.
+func (m *Manager) State() State {
+	l := m.License()
+	if l == nil {
+		return m.unloadedState()
+	}
+	return l.StateAt(m.now())
+}
+
+// unloadedState is the state without a loaded license: unlicensed without a license file, and expired when the file
+// has not loaded.
+func (m *Manager) unloadedState() State {
+	if m == nil || m.path == "" {
+		return StateUnlicensed
+	}
+	return StateExpired
+}
+
+// Status returns the license, its state and the seats in use.
+func (m *Manager) Status(ctx context.Context) (*Status, error) {
+	l := m.License()
+	if l == nil {
+		return &Status{State: m.unloadedState()}, nil
+	}
+	st := &Status{State: l.StateAt(m.now()), License: l}
+	if m.users != nil {
+		n, err := m.users.CountLicensed(ctx)
+		if err != nil {
+			return nil, err
+		}
+		st.SeatsUsed = n
+	}
+	return st, nil
+}
+
+// ReserveSeat returns nil when one more user may be added: ErrLicenseExpired past the grace period or while the
+// license file has not loaded, and ErrSeatLimitReached when every seat is taken. It locks the seats until q's
+// transaction ends, so create the user in that transaction and two concurrent sign-ups cannot both take the last
+// seat. Unlicensed deployments have no limit and take no lock.
+func (m *Manager) ReserveSeat(ctx context.Context, q SeatQueries) error {
+	l := m.License()
+	if l == nil {
+		if m.unloadedState() == StateExpired {
+			return ErrLicenseExpired
+		}
+		return nil
+	}
+	if l.StateAt(m.now()) == StateExpired {
+		return ErrLicenseExpired
+	}
+	if l.Seats <= 0 {
+		return nil
+	}
+	if err := q.LockLicenseSeats(ctx); err != nil {
+		return err
+	}
+	n, err := q.CountLicensedUsers(ctx)
+	if err != nil {
+		return err
+	}
+	if n >= int64(l.Seats) {
+		return ErrSeatLimitReached
+	}
+	return nil
+}
+
+// Allows reports whether feature may be used: always when unlicensed, never past the grace period or while the
+// license file has not loaded, and otherwise when the license includes it.
+func (m *Manager) Allows(feature string) bool {
+	l := m.License()
+	if l == nil {
+		return m.unloadedState() == StateUnlicensed
+	}
+	return l.StateAt(m.now()) != StateExpired && l.Includes(feature)
+}
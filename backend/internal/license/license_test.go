@@ -0,0 +1,180 @@
+package license
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// countUsers is the user counter of a Manager and the seat queries of ReserveSeat.
+type countUsers struct {
+	n     int64
+	locks int
+}
+
+func (c *countUsers) CountLicensed(ctx context.Context) (int64, error) { return c.n, nil }
+
+func (c *countUsers) CountLicensedUsers(ctx context.Context) (int64, error) { return c.n, nil }
+
+func (c *countUsers) LockLicenseSeats(ctx context.Context) error {
+	c.locks++
+	return nil
+}
+
+type recordingAudit struct{ actions []string }
+
+func (r *recordingAudit) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	r.actions = append(r.actions, orgID+":"+action)
+}
+
+// writeLicense signs a license expiring at expires with key and writes it to a file in dir.
+func writeLicense(t *testing.T, key *ecdsa.PrivateKey, dir, id string, seats int, expires time.Time) string {
+	t.Helper()
+	content, err := security.SignLicense(key, &security.LicenseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ID: id, Subject: "Acme", ExpiresAt: jwt.NewNumericDate(expires)},
+		Seats:            seats,
+		Features:         []string{"custom_rego"},
+	})
+	if err != nil {
+		t.Fatalf("SignLicense: %v", err)
+	}
+	path := filepath.Join(dir, "license.jwt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestManager(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	path := writeLicense(t, key, t.TempDir(), "lic-1", 3, now.Add(60*24*time.Hour))
+	users := &countUsers{n: 2}
+	m := NewManager(path, &key.PublicKey, users)
+	m.now = func() time.Time { return now }
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if l := m.License(); l.ID != "lic-1" || l.Customer != "Acme" || l.GraceDays != DefaultGraceDays {
+		t.Errorf("license = %+v", l)
+	}
+	if m.State() != StateValid || !m.Allows("custom_rego") || m.Allows("audit_streaming") {
+		t.Errorf("state = %s, custom_rego allowed = %v, audit_streaming allowed = %v", m.State(), m.Allows("custom_rego"), m.Allows("audit_streaming"))
+	}
+	if err := m.ReserveSeat(context.Background(), users); err != nil {
+		t.Errorf("ReserveSeat with a free seat: %v", err)
+	}
+	users.n = 3
+	if err := m.ReserveSeat(context.Background(), users); err != ErrSeatLimitReached {
+		t.Errorf("ReserveSeat when full: want ErrSeatLimitReached, got %v", err)
+	}
+	if users.locks != 2 {
+		t.Errorf("ReserveSeat locked the seats %d times, want 2", users.locks)
+	}
+
+	steps := []struct {
+		after time.Duration
+		state State
+	}{
+		{40 * 24 * time.Hour, StateExpiring},
+		{61 * 24 * time.Hour, StateGrace},
+		{(60 + DefaultGraceDays) * 24 * time.Hour, StateExpired},
+	}
+	for _, s := range steps {
+		now = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC).Add(s.after)
+		if got := m.State(); got != s.state {
+			t.Errorf("after %v: state = %s, want %s", s.after, got, s.state)
+		}
+	}
+	users.n = 0
+	if err := m.ReserveSeat(context.Background(), users); err != ErrLicenseExpired || m.Allows("custom_rego") {
+		t.Errorf("expired: ReserveSeat = %v, custom_rego allowed = %v; want ErrLicenseExpired and refused", err, m.Allows("custom_rego"))
+	}
+
+	if err := os.WriteFile(path, []byte("tampered"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Load(); err == nil || m.License().ID != "lic-1" {
+		t.Errorf("bad file: Load = %v, license = %v; want an error and the license kept", err, m.License())
+	}
+
+	users.locks = 0
+	unlicensed := NewManager("", nil, users)
+	if err := unlicensed.Load(); err != nil || unlicensed.State() != StateUnlicensed || !unlicensed.Allows("custom_rego") || unlicensed.ReserveSeat(context.Background(), users) != nil {
+		t.Error("a deployment without a license file must enforce nothing")
+	}
+	var nilManager *Manager
+	if nilManager.State() != StateUnlicensed || !nilManager.Allows("custom_rego") || nilManager.ReserveSeat(context.Background(), users) != nil {
+		t.Error("a nil manager must enforce nothing")
+	}
+	if users.locks != 0 {
+		t.Errorf("unlicensed ReserveSeat locked the seats %d times, want 0", users.locks)
+	}
+}
+
+func TestManager_FailsClosedWithoutLoadedLicense(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	users := &countUsers{}
+	missing := NewManager(filepath.Join(t.TempDir(), "license.jwt"), &key.PublicKey, users)
+	if err := missing.Load(); err == nil {
+		t.Fatal("Load of a missing file: want an error")
+	}
+	notLoaded := NewManager(writeLicense(t, key, t.TempDir(), "lic-1", 3, time.Now().Add(time.Hour)), &key.PublicKey, users)
+	for name, m := range map[string]*Manager{"missing file": missing, "not loaded": notLoaded} {
+		st, err := m.Status(context.Background())
+		if err != nil || st.State != StateExpired || st.License != nil || m.State() != StateExpired {
+			t.Errorf("%s: Status = %+v, %v; want expired without a license", name, st, err)
+		}
+		if err := m.ReserveSeat(context.Background(), users); err != ErrLicenseExpired || m.Allows("custom_rego") {
+			t.Errorf("%s: ReserveSeat = %v, custom_rego allowed = %v; want ErrLicenseExpired and refused", name, err, m.Allows("custom_rego"))
+		}
+	}
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	dir := t.TempDir()
+	start := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	expires := start.Add(20 * 24 * time.Hour)
+	path := writeLicense(t, key, dir, "lic-1", 2, expires)
+	users := &countUsers{n: 1}
+	m := NewManager(path, &key.PublicKey, users)
+	now := start
+	m.now = func() time.Time { return now }
+	logger := &recordingAudit{}
+	job := NewJob(m, logger)
+
+	run := func(at time.Time) {
+		t.Helper()
+		now = at
+		if err := job.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce at %v: %v", at, err)
+		}
+	}
+	run(start)                            // 20 days left: the 30-day warning
+	run(start.Add(time.Hour))             // same warning, not repeated
+	run(expires.Add(-6 * 24 * time.Hour)) // 6 days left: the 7-day warning
+	users.n = 2
+	run(expires.Add(time.Hour))           // grace period, and every seat taken
+	run(expires.Add(15 * 24 * time.Hour)) // grace over
+	writeLicense(t, key, dir, "lic-2", 5, now.Add(365*24*time.Hour))
+	run(now.Add(time.Hour)) // renewed: no warning
+
+	want := "_system:license_expiring,_system:license_expiring,_system:license_grace_period,_system:license_seats_exhausted,_system:license_expired"
+	if got := strings.Join(logger.actions, ","); got != want {
+		t.Errorf("warnings = %s, want %s", got, want)
+	}
+	if m.License().ID != "lic-2" || m.State() != StateValid {
+		t.Errorf("after renewal license = %s in state %s, want lic-2 valid", m.License().ID, m.State())
+	}
+}
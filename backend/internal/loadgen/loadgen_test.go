@@ -0,0 +1,272 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	devv1 "zero-trust-control-plane/backend/api/generated/dev/v1"
+)
+
+// fakeServer answers like a server seeded with the load-test scenario: trusted fingerprints sign in directly, other
+// fingerprints get an MFA challenge whose OTP is "123456".
+type fakeServer struct {
+	mu         sync.Mutex
+	refreshes  map[string]bool // live refresh tokens
+	challenges map[string]bool
+	next       int
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{refreshes: map[string]bool{}, challenges: map[string]bool{}}
+}
+
+func (f *fakeServer) tokens() *authv1.AuthResponse {
+	f.next++
+	tok := fmt.Sprintf("refresh-%d", f.next)
+	f.refreshes[tok] = true
+	return &authv1.AuthResponse{AccessToken: "access", RefreshToken: tok}
+}
+
+func (f *fakeServer) Login(ctx context.Context, in *authv1.LoginRequest, opts ...grpc.CallOption) (*authv1.LoginResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if in.GetPassword() != Password || !strings.HasPrefix(in.GetOrgId(), "loadtest-org-") {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	if strings.HasPrefix(in.GetDeviceFingerprint(), "loadtest-fp-") {
+		return &authv1.LoginResponse{Result: &authv1.LoginResponse_Tokens{Tokens: f.tokens()}}, nil
+	}
+	id := "challenge-" + in.GetDeviceFingerprint()
+	f.challenges[id] = true
+	return &authv1.LoginResponse{Result: &authv1.LoginResponse_MfaRequired{MfaRequired: &authv1.MFARequired{ChallengeId: id}}}, nil
+}
+
+func (f *fakeServer) Refresh(ctx context.Context, in *authv1.RefreshRequest, opts ...grpc.CallOption) (*authv1.RefreshResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.refreshes[in.GetRefreshToken()] {
+		return nil, status.Error(codes.Unauthenticated, "refresh token reused")
+	}
+	delete(f.refreshes, in.GetRefreshToken())
+	return &authv1.RefreshResponse{Result: &authv1.RefreshResponse_Tokens{Tokens: f.tokens()}}, nil
+}
+
+func (f *fakeServer) VerifyMFA(ctx context.Context, in *authv1.VerifyMFARequest, opts ...grpc.CallOption) (*authv1.AuthResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.challenges[in.GetChallengeId()] || in.GetOtp() != "123456" {
+		return nil, status.Error(codes.Unauthenticated, "invalid otp")
+	}
+	delete(f.challenges, in.GetChallengeId())
+	return f.tokens(), nil
+}
+
+func (f *fakeServer) GetOTP(ctx context.Context, in *devv1.GetOTPRequest, opts ...grpc.CallOption) (*devv1.GetOTPResponse, error) {
+	return &devv1.GetOTPResponse{Otp: "123456"}, nil
+}
+
+func TestParseMix(t *testing.T) {
+	m, err := ParseMix(DefaultMix)
+	if err != nil {
+		t.Fatalf("ParseMix: %v", err)
+	}
+	if m[OpLogin] != 30 || m[OpRefresh] != 65 || m[OpVerifyMFA] != 5 {
+		t.Errorf("mix = %v", m)
+	}
+	if got := m.String(); got != DefaultMix {
+		t.Errorf("String() = %q, want %q", got, DefaultMix)
+	}
+	for _, bad := range []string{"", "login", "logout=1", "login=-1", "login=x", "login=0,refresh=0"} {
+		if _, err := ParseMix(bad); err == nil {
+			t.Errorf("ParseMix(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMix_PickFollowsWeights(t *testing.T) {
+	m := Mix{OpLogin: 1, OpRefresh: 3}
+	r := rand.New(rand.NewSource(1))
+	counts := map[Op]int{}
+	for i := 0; i < 4000; i++ {
+		counts[m.pick(r)]++
+	}
+	if counts[OpVerifyMFA] != 0 {
+		t.Error("op with weight 0 picked")
+	}
+	if counts[OpLogin] < 850 || counts[OpLogin] > 1150 {
+		t.Errorf("login picked %d of 4000 times, want about 1000", counts[OpLogin])
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var lat []time.Duration
+	for i := 1; i <= 100; i++ {
+		lat = append(lat, time.Duration(i)*time.Millisecond)
+	}
+	for _, tc := range []struct {
+		p    float64
+		want time.Duration
+	}{{0.5, 50 * time.Millisecond}, {0.9, 90 * time.Millisecond}, {0.99, 99 * time.Millisecond}, {1, 100 * time.Millisecond}} {
+		if got := percentile(lat, tc.p); got != tc.want {
+			t.Errorf("percentile(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+	if percentile(nil, 0.5) != 0 {
+		t.Error("percentile of no samples should be 0")
+	}
+}
+
+func TestRun_MixAgainstFakeServer(t *testing.T) {
+	srv := newFakeServer()
+	report, err := Run(context.Background(), srv, srv, Options{
+		Concurrency: 4,
+		Requests:    400,
+		Mix:         Mix{OpLogin: 2, OpRefresh: 6, OpVerifyMFA: 2},
+		Orgs:        2,
+		Users:       10,
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	total := 0
+	for _, call := range []string{CallLogin, CallRefresh, CallMFALogin, CallVerifyMFA} {
+		st := report.Calls[call]
+		if st.Count == 0 {
+			t.Errorf("no successful %s calls", call)
+		}
+		if st.Errors != 0 {
+			t.Errorf("%s errors = %d (%v), want 0", call, st.Errors, st.ErrorCodes)
+		}
+		if st.P50MS > st.P99MS || st.P99MS > st.MaxMS {
+			t.Errorf("%s percentiles out of order: %+v", call, st)
+		}
+		total += st.Count
+	}
+	// Each iteration is one call, except verify_mfa which is two.
+	if iterations := total - report.Calls[CallVerifyMFA].Count; iterations != 400 {
+		t.Errorf("iterations = %d, want 400", iterations)
+	}
+	if report.Concurrency != 4 || report.Mix != "login=2,refresh=6,verify_mfa=2" {
+		t.Errorf("report header = %d %q", report.Concurrency, report.Mix)
+	}
+}
+
+func TestRun_RecordsErrorsByCode(t *testing.T) {
+	srv := newFakeServer()
+	report, err := Run(context.Background(), srv, nil, Options{
+		Concurrency: 2,
+		Requests:    20,
+		Mix:         Mix{OpLogin: 1},
+		Orgs:        1,
+		Users:       1,
+		Password:    "wrong",
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	st := report.Calls[CallLogin]
+	if st.Count != 0 || st.Errors != 20 || st.ErrorCodes["Unauthenticated"] != 20 {
+		t.Errorf("login stats = %+v, want 20 Unauthenticated errors", st)
+	}
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	if !strings.Contains(buf.String(), "login errors: Unauthenticated=20") {
+		t.Errorf("text report misses error codes:\n%s", buf.String())
+	}
+}
+
+func TestRun_StopsAfterDuration(t *testing.T) {
+	srv := newFakeServer()
+	start := time.Now()
+	report, err := Run(context.Background(), srv, nil, Options{
+		Concurrency: 2,
+		Duration:    50 * time.Millisecond,
+		Mix:         Mix{OpRefresh: 1},
+		Orgs:        1,
+		Users:       3,
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if took := time.Since(start); took > 2*time.Second {
+		t.Errorf("Run took %v, want about 50ms", took)
+	}
+	if report.Calls[CallRefresh].Count == 0 {
+		t.Error("no refreshes ran")
+	}
+}
+
+func TestRun_RejectsInvalidOptions(t *testing.T) {
+	srv := newFakeServer()
+	base := Options{Concurrency: 1, Requests: 1, Mix: Mix{OpLogin: 1}, Orgs: 1, Users: 1}
+	for name, mutate := range map[string]func(*Options){
+		"no concurrency":         func(o *Options) { o.Concurrency = 0 },
+		"no stop condition":      func(o *Options) { o.Requests = 0 },
+		"no users":               func(o *Options) { o.Users = 0 },
+		"verify_mfa without otp": func(o *Options) { o.Mix = Mix{OpVerifyMFA: 1} },
+		"empty mix":              func(o *Options) { o.Mix = nil },
+	} {
+		opts := base
+		mutate(&opts)
+		if _, err := Run(context.Background(), srv, nil, opts); err == nil {
+			t.Errorf("%s: Run should fail", name)
+		}
+	}
+}
+
+func TestReport_Regressions(t *testing.T) {
+	baseline := &Report{Calls: map[string]CallStats{
+		CallLogin:   {Count: 100, Throughput: 50, P50MS: 10, P99MS: 40},
+		CallRefresh: {Count: 100, Throughput: 100, P50MS: 2, P99MS: 8},
+	}}
+	current := &Report{Calls: map[string]CallStats{
+		CallLogin:     {Count: 100, Throughput: 48, P50MS: 11, P99MS: 60},
+		CallRefresh:   {Count: 50, Errors: 50, Throughput: 50, P50MS: 2, P99MS: 8},
+		CallVerifyMFA: {Count: 10, Throughput: 1, P50MS: 100, P99MS: 300},
+	}}
+	got := current.Regressions(baseline, 0.2)
+	want := []string{
+		"login: p99 60.00ms, baseline 40.00ms",
+		"refresh: 50.0/s, baseline 100.0/s",
+		"refresh: 50 errors in 100 calls, baseline 0 in 100",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Regressions =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if r := baseline.Regressions(baseline, 0); len(r) != 0 {
+		t.Errorf("report regresses against itself: %v", r)
+	}
+}
+
+func TestReport_JSONRoundTrip(t *testing.T) {
+	path := t.TempDir() + "/report.json"
+	in := &Report{Concurrency: 3, Mix: "login=1", DurationS: 1.5, Calls: map[string]CallStats{
+		CallLogin: {Count: 2, Errors: 1, P50MS: 1.25, ErrorCodes: map[string]int{"Unavailable": 1}},
+	}}
+	if err := in.WriteJSONFile(path); err != nil {
+		t.Fatalf("WriteJSONFile: %v", err)
+	}
+	out, err := ReadReportFile(path)
+	if err != nil {
+		t.Fatalf("ReadReportFile: %v", err)
+	}
+	if out.Concurrency != 3 || out.Calls[CallLogin].ErrorCodes["Unavailable"] != 1 || out.Calls[CallLogin].P50MS != 1.25 {
+		t.Errorf("round trip = %+v", out)
+	}
+	if _, err := ReadReportFile(t.TempDir() + "/missing.json"); err == nil {
+		t.Error("missing file should fail")
+	}
+}
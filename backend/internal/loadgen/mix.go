@@ -0,0 +1,92 @@
+package loadgen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Op is a kind of iteration a worker runs.
+type Op string
+
+const (
+	// OpLogin signs in on the user's trusted device and keeps the session for later refreshes.
+	OpLogin Op = "login"
+	// OpRefresh refreshes one of the worker's sessions (signing in first when it has none).
+	OpRefresh Op = "refresh"
+	// OpVerifyMFA signs in on a new device, reads the OTP from the dev endpoint and completes the challenge.
+	OpVerifyMFA Op = "verify_mfa"
+)
+
+// DefaultMix is the mix used when none is given: mostly refreshes and trusted-device logins, few MFA sign-ins.
+const DefaultMix = "login=30,refresh=65,verify_mfa=5"
+
+// Mix is the relative weight of each op.
+type Mix map[Op]int
+
+// ParseMix parses "op=weight,..." (e.g. DefaultMix). Ops left out have weight 0; at least one weight must be
+// positive.
+func ParseMix(s string) (Mix, error) {
+	m := Mix{}
+	total := 0
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, weight, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("loadgen: mix entry %q must be op=weight", part)
+		}
+		op := Op(strings.TrimSpace(name))
+		switch op {
+		case OpLogin, OpRefresh, OpVerifyMFA:
+		default:
+			return nil, fmt.Errorf("loadgen: unknown op %q in mix (want login, refresh or verify_mfa)", op)
+		}
+		w, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("loadgen: weight of %s must be a non-negative integer", op)
+		}
+		m[op] += w
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("loadgen: mix %q has no positive weight", s)
+	}
+	return m, nil
+}
+
+// pick returns a random op with probability proportional to its weight.
+func (m Mix) pick(r *rand.Rand) Op {
+	ops := make([]Op, 0, len(m))
+	total := 0
+	for op, w := range m {
+		if w > 0 {
+			ops = append(ops, op)
+			total += w
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i] < ops[j] })
+	n := r.Intn(total)
+	for _, op := range ops {
+		if n < m[op] {
+			return op
+		}
+		n -= m[op]
+	}
+	return ops[len(ops)-1]
+}
+
+// String formats m like ParseMix input, ops in a fixed order.
+func (m Mix) String() string {
+	var parts []string
+	for _, op := range []Op{OpLogin, OpRefresh, OpVerifyMFA} {
+		if w := m[op]; w > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", op, w))
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,218 @@
+package loadgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// Call names recorded in a report. A verify_mfa iteration records its challenge-issuing Login as CallMFALogin.
+const (
+	CallLogin     = "login"
+	CallRefresh   = "refresh"
+	CallMFALogin  = "mfa_login"
+	CallVerifyMFA = "verify_mfa"
+)
+
+// Report is the result of a run: per-call latency percentiles, throughput and errors.
+type Report struct {
+	Concurrency int                  `json:"concurrency"`
+	Mix         string               `json:"mix"`
+	DurationS   float64              `json:"duration_s"`
+	Calls       map[string]CallStats `json:"calls"`
+}
+
+// CallStats summarizes one call. Latencies are in milliseconds and only cover successful calls.
+type CallStats struct {
+	Count      int            `json:"count"`
+	Errors     int            `json:"errors"`
+	Throughput float64        `json:"throughput_per_s"`
+	P50MS      float64        `json:"p50_ms"`
+	P90MS      float64        `json:"p90_ms"`
+	P99MS      float64        `json:"p99_ms"`
+	MaxMS      float64        `json:"max_ms"`
+	ErrorCodes map[string]int `json:"error_codes,omitempty"`
+}
+
+// recorder collects call results from all workers.
+type recorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{latencies: map[string][]time.Duration{}, errors: map[string]map[string]int{}}
+}
+
+// record adds one call result. err is classified by its gRPC status code, other errors as unexpected_result.
+func (r *recorder) record(call string, took time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		if r.errors[call] == nil {
+			r.errors[call] = map[string]int{}
+		}
+		code := "unexpected_result"
+		if st, ok := status.FromError(err); ok {
+			code = st.Code().String()
+		}
+		r.errors[call][code]++
+		return
+	}
+	r.latencies[call] = append(r.latencies[call], took)
+}
+
+// report summarizes the recorded calls over elapsed.
+func (r *recorder) report(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := &Report{DurationS: elapsed.Seconds(), Calls: map[string]CallStats{}}
+	for call, lat := range r.latencies {
+		sort.Slice(lat, func(i, j int) bool { return lat[i] < lat[j] })
+		st := out.Calls[call]
+		st.Count = len(lat)
+		st.P50MS = durationMS(percentile(lat, 0.50))
+		st.P90MS = durationMS(percentile(lat, 0.90))
+		st.P99MS = durationMS(percentile(lat, 0.99))
+		st.MaxMS = durationMS(lat[len(lat)-1])
+		out.Calls[call] = st
+	}
+	for call, codes := range r.errors {
+		st := out.Calls[call]
+		st.ErrorCodes = codes
+		for _, n := range codes {
+			st.Errors += n
+		}
+		out.Calls[call] = st
+	}
+	for call, st := range out.Calls {
+		if elapsed > 0 {
+			st.Throughput = float64(st.Count) / elapsed.Seconds()
+		}
+		out.Calls[call] = st
+	}
+	return out
+}
+
+// percentile returns the nearest-rank percentile p (0..1] of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+// durationMS is d in milliseconds, rounded to microseconds.
+func durationMS(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// WriteText writes r as a table, one row per call.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "concurrency %d, mix %s, %.1fs\n\n", r.Concurrency, r.Mix, r.DurationS)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "call\tok\terrors\tper s\tp50 ms\tp90 ms\tp99 ms\tmax ms\t")
+	for _, call := range r.callNames() {
+		st := r.Calls[call]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%.2f\t%.2f\t%.2f\t%.2f\t\n",
+			call, st.Count, st.Errors, st.Throughput, st.P50MS, st.P90MS, st.P99MS, st.MaxMS)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, call := range r.callNames() {
+		codes := r.Calls[call].ErrorCodes
+		if len(codes) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(codes))
+		for code := range codes {
+			names = append(names, code)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "\n%s errors:", call)
+		for _, code := range names {
+			fmt.Fprintf(w, " %s=%d", code, codes[code])
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func (r *Report) callNames() []string {
+	names := make([]string, 0, len(r.Calls))
+	for call := range r.Calls {
+		names = append(names, call)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteJSONFile writes r as indented JSON to path, for use as a later run's baseline.
+func (r *Report) WriteJSONFile(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// ReadReportFile reads a report written by WriteJSONFile.
+func ReadReportFile(path string) (*Report, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("loadgen: parse report %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// Regressions compares r with baseline and describes every call whose p50 or p99 latency grew, or whose throughput
+// or success ratio fell, by more than tolerance (e.g. 0.2 for 20%). Calls missing from either report are skipped.
+func (r *Report) Regressions(baseline *Report, tolerance float64) []string {
+	var out []string
+	for _, call := range r.callNames() {
+		cur := r.Calls[call]
+		base, ok := baseline.Calls[call]
+		if !ok || base.Count == 0 {
+			continue
+		}
+		if cur.P50MS > base.P50MS*(1+tolerance) {
+			out = append(out, fmt.Sprintf("%s: p50 %.2fms, baseline %.2fms", call, cur.P50MS, base.P50MS))
+		}
+		if cur.P99MS > base.P99MS*(1+tolerance) {
+			out = append(out, fmt.Sprintf("%s: p99 %.2fms, baseline %.2fms", call, cur.P99MS, base.P99MS))
+		}
+		if cur.Throughput < base.Throughput*(1-tolerance) {
+			out = append(out, fmt.Sprintf("%s: %.1f/s, baseline %.1f/s", call, cur.Throughput, base.Throughput))
+		}
+		if successRatio(cur) < successRatio(base)*(1-tolerance) {
+			out = append(out, fmt.Sprintf("%s: %d errors in %d calls, baseline %d in %d",
+				call, cur.Errors, cur.Count+cur.Errors, base.Errors, base.Count+base.Errors))
+		}
+	}
+	return out
+}
+
+func successRatio(st CallStats) float64 {
+	if st.Count+st.Errors == 0 {
+		return 1
+	}
+	return float64(st.Count) / float64(st.Count+st.Errors)
+}
@@ -0,0 +1,259 @@
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	devv1 "zero-trust-control-plane/backend/api/generated/dev/v1"
+)
+
+// DefaultCallTimeout bounds each call when Options.CallTimeout is not set.
+const DefaultCallTimeout = 10 * time.Second
+
+// maxSessions is how many sessions a worker keeps for refreshes; older ones are forgotten.
+const maxSessions = 8
+
+// AuthClient is the part of authv1.AuthServiceClient the load generator calls.
+type AuthClient interface {
+	Login(ctx context.Context, in *authv1.LoginRequest, opts ...grpc.CallOption) (*authv1.LoginResponse, error)
+	Refresh(ctx context.Context, in *authv1.RefreshRequest, opts ...grpc.CallOption) (*authv1.RefreshResponse, error)
+	VerifyMFA(ctx context.Context, in *authv1.VerifyMFARequest, opts ...grpc.CallOption) (*authv1.AuthResponse, error)
+}
+
+// OTPSource reads the OTP of an MFA challenge. Implemented by devv1.DevServiceClient, which the server only
+// registers in dev OTP mode (OTP_RETURN_TO_CLIENT=true).
+type OTPSource interface {
+	GetOTP(ctx context.Context, in *devv1.GetOTPRequest, opts ...grpc.CallOption) (*devv1.GetOTPResponse, error)
+}
+
+// Options configure a run.
+type Options struct {
+	// Concurrency is the number of workers, each running one iteration at a time.
+	Concurrency int
+	// Duration stops the run after this long; 0 runs until Requests iterations or ctx is done.
+	Duration time.Duration
+	// Requests stops the run after this many iterations; 0 means no limit.
+	Requests int64
+	Mix      Mix
+	// Orgs and Users (per org) must not exceed the counts the load-test scenario was seeded with.
+	Orgs  int
+	Users int
+	// Password defaults to Password.
+	Password string
+	// CallTimeout bounds each call; defaults to DefaultCallTimeout.
+	CallTimeout time.Duration
+}
+
+// errUnexpectedResult is recorded when a call succeeds with another outcome than the iteration needs, e.g. a
+// trusted-device Login that asks for MFA.
+var errUnexpectedResult = errors.New("loadgen: unexpected result")
+
+// Run runs the mix with opts.Concurrency workers until opts.Duration elapses, opts.Requests iterations ran or ctx is
+// done, then reports every call made. In-flight calls finish (within CallTimeout) and are counted. otp may be nil
+// when the mix has no verify_mfa.
+func Run(ctx context.Context, auth AuthClient, otp OTPSource, opts Options) (*Report, error) {
+	if opts.Concurrency <= 0 || opts.Orgs <= 0 || opts.Users <= 0 {
+		return nil, fmt.Errorf("loadgen: concurrency, orgs and users must be positive")
+	}
+	if opts.Duration <= 0 && opts.Requests <= 0 {
+		return nil, fmt.Errorf("loadgen: set a duration or a request count")
+	}
+	if len(opts.Mix) == 0 {
+		return nil, fmt.Errorf("loadgen: empty mix")
+	}
+	if opts.Mix[OpVerifyMFA] > 0 && otp == nil {
+		return nil, fmt.Errorf("loadgen: verify_mfa needs an OTP source")
+	}
+	if opts.Password == "" {
+		opts.Password = Password
+	}
+	if opts.CallTimeout <= 0 {
+		opts.CallTimeout = DefaultCallTimeout
+	}
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	rec := newRecorder()
+	runID := time.Now().UnixNano()
+	var issued atomic.Int64
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < opts.Concurrency; i++ {
+		w := &worker{
+			auth:   auth,
+			otp:    otp,
+			opts:   opts,
+			rec:    rec,
+			rand:   rand.New(rand.NewSource(runID + int64(i))),
+			prefix: fmt.Sprintf("loadgen-%d-%d", runID, i),
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				if opts.Requests > 0 && issued.Add(1) > opts.Requests {
+					return
+				}
+				w.iterate(context.WithoutCancel(ctx))
+			}
+		}()
+	}
+	wg.Wait()
+	report := rec.report(time.Since(start))
+	report.Concurrency = opts.Concurrency
+	report.Mix = opts.Mix.String()
+	return report, nil
+}
+
+// session is a signed-in session a worker can refresh.
+type session struct {
+	refreshToken string
+	fingerprint  string
+}
+
+// worker runs iterations one at a time. Not safe for concurrent use.
+type worker struct {
+	auth     AuthClient
+	otp      OTPSource
+	opts     Options
+	rec      *recorder
+	rand     *rand.Rand
+	prefix   string // unique per run and worker; new-device fingerprints start with it
+	devices  int
+	sessions []session
+}
+
+func (w *worker) iterate(ctx context.Context) {
+	switch w.opts.Mix.pick(w.rand) {
+	case OpLogin:
+		w.login(ctx)
+	case OpRefresh:
+		w.refresh(ctx)
+	case OpVerifyMFA:
+		w.verifyMFA(ctx)
+	}
+}
+
+// call runs fn with the call timeout and records its latency or error under name.
+func (w *worker) call(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, w.opts.CallTimeout)
+	defer cancel()
+	start := time.Now()
+	err := fn(ctx)
+	w.rec.record(name, time.Since(start), err)
+	return err
+}
+
+func (w *worker) user() (org, user int) {
+	return w.rand.Intn(w.opts.Orgs), w.rand.Intn(w.opts.Users)
+}
+
+func (w *worker) keep(s session) {
+	if len(w.sessions) == maxSessions {
+		w.sessions = w.sessions[1:]
+	}
+	w.sessions = append(w.sessions, s)
+}
+
+func (w *worker) login(ctx context.Context) {
+	org, user := w.user()
+	fp := TrustedFingerprint(org, user)
+	var tokens *authv1.AuthResponse
+	err := w.call(ctx, CallLogin, func(ctx context.Context) error {
+		resp, err := w.auth.Login(ctx, &authv1.LoginRequest{
+			Email:             UserEmail(org, user),
+			Password:          w.opts.Password,
+			OrgId:             OrgID(org),
+			DeviceFingerprint: fp,
+		})
+		if err != nil {
+			return err
+		}
+		if tokens = resp.GetTokens(); tokens == nil {
+			return errUnexpectedResult
+		}
+		return nil
+	})
+	if err == nil {
+		w.keep(session{refreshToken: tokens.GetRefreshToken(), fingerprint: fp})
+	}
+}
+
+// refresh refreshes a random kept session, or signs in when the worker has none.
+func (w *worker) refresh(ctx context.Context) {
+	if len(w.sessions) == 0 {
+		w.login(ctx)
+		return
+	}
+	i := w.rand.Intn(len(w.sessions))
+	s := w.sessions[i]
+	var tokens *authv1.AuthResponse
+	err := w.call(ctx, CallRefresh, func(ctx context.Context) error {
+		resp, err := w.auth.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: s.refreshToken, DeviceFingerprint: s.fingerprint})
+		if err != nil {
+			return err
+		}
+		if tokens = resp.GetTokens(); tokens == nil {
+			return errUnexpectedResult
+		}
+		return nil
+	})
+	if err != nil {
+		w.sessions = append(w.sessions[:i], w.sessions[i+1:]...)
+		return
+	}
+	w.sessions[i].refreshToken = tokens.GetRefreshToken()
+}
+
+// verifyMFA signs in on a device the server has not seen, which requires MFA, then completes the challenge with
+// the OTP from the dev endpoint. The OTP lookup is not timed; its failure is recorded as a verify_mfa error.
+func (w *worker) verifyMFA(ctx context.Context) {
+	org, user := w.user()
+	w.devices++
+	fp := fmt.Sprintf("%s-%d", w.prefix, w.devices)
+	var challenge *authv1.MFARequired
+	err := w.call(ctx, CallMFALogin, func(ctx context.Context) error {
+		resp, err := w.auth.Login(ctx, &authv1.LoginRequest{
+			Email:             UserEmail(org, user),
+			Password:          w.opts.Password,
+			OrgId:             OrgID(org),
+			DeviceFingerprint: fp,
+		})
+		if err != nil {
+			return err
+		}
+		if challenge = resp.GetMfaRequired(); challenge == nil {
+			return errUnexpectedResult
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	otpCtx, cancel := context.WithTimeout(ctx, w.opts.CallTimeout)
+	otp, err := w.otp.GetOTP(otpCtx, &devv1.GetOTPRequest{ChallengeId: challenge.GetChallengeId()})
+	cancel()
+	if err != nil {
+		w.rec.record(CallVerifyMFA, 0, err)
+		return
+	}
+	var tokens *authv1.AuthResponse
+	err = w.call(ctx, CallVerifyMFA, func(ctx context.Context) error {
+		tokens, err = w.auth.VerifyMFA(ctx, &authv1.VerifyMFARequest{ChallengeId: challenge.GetChallengeId(), Otp: otp.GetOtp()})
+		return err
+	})
+	if err == nil {
+		w.keep(session{refreshToken: tokens.GetRefreshToken(), fingerprint: fp})
+	}
+}
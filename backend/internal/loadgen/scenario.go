@@ -0,0 +1,36 @@
+// Package loadgen drives a mix of Login, Refresh and VerifyMFA calls against a running server and reports latency
+// and throughput per call (see cmd/loadgen). It signs in as the users of the load-test seed scenario
+// (go run ./cmd/seed -loadtest-orgs N -loadtest-users M); the naming helpers below are shared by both sides.
+package loadgen
+
+import "fmt"
+
+// Password is the password of every load-test user.
+const Password = "loadtest-password"
+
+// OrgID returns the ID of load-test org i (0-based).
+func OrgID(org int) string {
+	return fmt.Sprintf("loadtest-org-%03d", org)
+}
+
+// UserID returns the ID of load-test user u of org (0-based).
+func UserID(org, user int) string {
+	return fmt.Sprintf("loadtest-user-%03d-%04d", org, user)
+}
+
+// UserEmail returns the email of load-test user u of org. Each user is a member of that org only.
+func UserEmail(org, user int) string {
+	return fmt.Sprintf("loadtest-%03d-%04d@example.com", org, user)
+}
+
+// UserPhone returns the phone number of load-test user u of org, so MFA challenges can be issued without
+// collecting a phone first.
+func UserPhone(org, user int) string {
+	return fmt.Sprintf("+1555%03d%04d", org, user)
+}
+
+// TrustedFingerprint returns the fingerprint of the user's seeded trusted device. Logins and refreshes with it do
+// not require MFA; any other fingerprint is a new device and does.
+func TrustedFingerprint(org, user int) string {
+	return fmt.Sprintf("loadtest-fp-%03d-%04d", org, user)
+}
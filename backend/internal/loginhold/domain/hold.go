@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// Status is where a hold is in its lifecycle.
+type Status string
+
+const (
+	// StatusPending awaits an org admin's decision until ExpiresAt.
+	StatusPending Status = "pending"
+	// StatusApproved lets the held client finish signing in with ResumeLogin until ExpiresAt.
+	StatusApproved Status = "approved"
+	// StatusDenied ends the sign-in; ResumeLogin is refused.
+	StatusDenied Status = "denied"
+	// StatusCompleted was approved and resumed; the hold cannot be used again.
+	StatusCompleted Status = "completed"
+	// StatusExpired is reported for pending and approved holds past ExpiresAt; it is never stored.
+	StatusExpired Status = "expired"
+)
+
+// Risk signals that hold a sign-in.
+const (
+	// ReasonIPBlocked: the client IP is blocked (e.g. auto-blocked by the anomaly detector).
+	ReasonIPBlocked = "ip_blocked"
+)
+
+// TokenPrefix starts every hold token, so leaked tokens are easy to recognise.
+const TokenPrefix = "ztcp_lh_"
+
+// Hold is a sign-in that passed its credential checks but was flagged as high-risk. No session exists until an org
+// admin approves the hold and the client that signed in resumes it with the hold token.
+type Hold struct {
+	ID        string
+	OrgID     string
+	UserID    string
+	DeviceID  string // empty for a public device sign-in
+	TokenHash string
+	Reasons   []string // risk signals that flagged the sign-in, e.g. ReasonIPBlocked
+	IP        string
+	Status    Status
+	DecidedBy string
+	DecidedAt *time.Time
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// EffectiveStatus is Status, except that a pending or approved hold past ExpiresAt is expired, whether or not
+// anything has marked it.
+func (h *Hold) EffectiveStatus(now time.Time) Status {
+	if (h.Status == StatusPending || h.Status == StatusApproved) && !now.Before(h.ExpiresAt) {
+		return StatusExpired
+	}
+	return h.Status
+}
+
+// NewToken returns a new random hold token and its hash.
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, security.HashToken(token), nil
+}
+
+// TokenMatches reports whether token hashes to hash, in constant time.
+func TokenMatches(token, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(security.HashToken(token)), []byte(hash)) == 1
+}
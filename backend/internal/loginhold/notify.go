@@ -0,0 +1,54 @@
+// Package loginhold notifies org admins of sign-ins held for their approval. The holds themselves are created and
+// decided by AuthService (internal/identity/service).
+package loginhold
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+// EventHeld is the type of the event sent when a sign-in is held.
+const EventHeld = "login_hold.created"
+
+// Event tells admins that a sign-in is waiting for ApproveLogin or DenyLogin until ExpiresAt.
+type Event struct {
+	Type       string    `json:"type"`
+	HoldID     string    `json:"hold_id"`
+	OrgID      string    `json:"org_id"`
+	UserID     string    `json:"user_id"`
+	DeviceID   string    `json:"device_id"`
+	Reasons    []string  `json:"reasons"`
+	IP         string    `json:"ip,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// WebhookNotifier POSTs events as JSON to a URL. When Secret is set each request is signed (webhook.SignatureHeader).
+type WebhookNotifier struct {
+	URL        string
+	Secret     string
+	HTTPClient *http.Client
+}
+
+// NewWebhookNotifier returns a notifier that posts to url, signing with secret when it is non-empty.
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:        url,
+		Secret:     secret,
+		HTTPClient: webhook.NewHTTPClient(),
+	}
+}
+
+// Notify sends e in the background, so the held client gets its answer at once. Failures are logged and not retried.
+func (w *WebhookNotifier) Notify(ctx context.Context, e Event) {
+	webhook.Go(fmt.Sprintf("loginhold: webhook for hold %s in org %s", e.HoldID, e.OrgID), func(ctx context.Context) error { return w.Send(ctx, e) })
+}
+
+// Send posts the login hold event e and waits for the response. Any non-2xx status is an error.
+func (w *WebhookNotifier) Send(ctx context.Context, e Event) error {
+	return webhook.Post(ctx, w.HTTPClient, w.URL, w.Secret, e)
+}
@@ -0,0 +1,47 @@
+package loginhold
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/webhook"
+)
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var got Event
+	var body []byte
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(webhook.SignatureHeader)
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	now := time.Now().UTC()
+	e := Event{Type: EventHeld, HoldID: "h1", OrgID: "org-1", UserID: "u1", Reasons: []string{"ip_blocked"}, IP: "203.0.113.7", ExpiresAt: now.Add(15 * time.Minute), OccurredAt: now}
+	if err := NewWebhookNotifier(srv.URL, "s3cret").Send(context.Background(), e); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Type != EventHeld || got.HoldID != "h1" || len(got.Reasons) != 1 || got.Reasons[0] != "ip_blocked" {
+		t.Errorf("delivered event = %+v", got)
+	}
+	if signature != webhook.Sign("s3cret", body) {
+		t.Errorf("signature = %q, want HMAC of body", signature)
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	if err := NewWebhookNotifier(srv.URL, "").Send(context.Background(), Event{Type: EventHeld}); err == nil {
+		t.Error("non-2xx response should be an error")
+	}
+}
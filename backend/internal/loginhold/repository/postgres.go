@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/loginhold/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a login hold repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists a new pending hold.
+func (r *PostgresRepository) Create(ctx context.Context, h *domain.Hold) error {
+	return r.queries.CreateLoginHold(ctx, gen.CreateLoginHoldParams{
+		ID:        h.ID,
+		OrgID:     h.OrgID,
+		UserID:    h.UserID,
+		DeviceID:  sql.NullString{String: h.DeviceID, Valid: h.DeviceID != ""},
+		TokenHash: h.TokenHash,
+		Reasons:   strings.Join(h.Reasons, ","),
+		Ip:        h.IP,
+		Status:    string(h.Status),
+		CreatedAt: h.CreatedAt,
+		ExpiresAt: h.ExpiresAt,
+	})
+}
+
+// GetByID returns the hold, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Hold, error) {
+	row, err := r.queries.GetLoginHold(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genHoldToDomain(&row), nil
+}
+
+// ListPending returns the org's pending holds, newest first.
+func (r *PostgresRepository) ListPending(ctx context.Context, orgID string, now time.Time, limit, offset int32) ([]*domain.Hold, error) {
+	rows, err := r.queries.ListPendingLoginHolds(ctx, gen.ListPendingLoginHoldsParams{OrgID: orgID, ExpiresAt: now, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Hold, len(rows))
+	for i := range rows {
+		out[i] = genHoldToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+// Decide approves or denies a pending hold.
+func (r *PostgresRepository) Decide(ctx context.Context, id string, status domain.Status, by string, decidedAt, expiresAt time.Time) (bool, error) {
+	n, err := r.queries.DecideLoginHold(ctx, gen.DecideLoginHoldParams{
+		Status:    string(status),
+		DecidedBy: sql.NullString{String: by, Valid: true},
+		DecidedAt: sql.NullTime{Time: decidedAt, Valid: true},
+		ExpiresAt: expiresAt,
+		ID:        id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Complete consumes an approved hold.
+func (r *PostgresRepository) Complete(ctx context.Context, id string, now time.Time) (bool, error) {
+	n, err := r.queries.CompleteLoginHold(ctx, gen.CompleteLoginHoldParams{ID: id, Now: now})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func genHoldToDomain(row *gen.LoginHold) *domain.Hold {
+	h := &domain.Hold{
+		ID:        row.ID,
+		OrgID:     row.OrgID,
+		UserID:    row.UserID,
+		DeviceID:  row.DeviceID.String,
+		TokenHash: row.TokenHash,
+		IP:        row.Ip,
+		Status:    domain.Status(row.Status),
+		DecidedBy: row.DecidedBy.String,
+		CreatedAt: row.CreatedAt,
+		ExpiresAt: row.ExpiresAt,
+	}
+	if row.Reasons != "" {
+		h.Reasons = strings.Split(row.Reasons, ",")
+	}
+	if row.DecidedAt.Valid {
+		t := row.DecidedAt.Time
+		h.DecidedAt = &t
+	}
+	return h
+}
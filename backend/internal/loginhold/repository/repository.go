@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/loginhold/domain"
+)
+
+// Repository persists sign-ins held for admin approval.
+type Repository interface {
+	// Create persists a new pending hold. The hold must have ID set.
+	Create(ctx context.Context, h *domain.Hold) error
+	// GetByID returns the hold, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.Hold, error)
+	// ListPending returns the org's pending holds that have not expired at now, newest first.
+	ListPending(ctx context.Context, orgID string, now time.Time, limit, offset int32) ([]*domain.Hold, error)
+	// Decide approves or denies a pending hold and moves its deadline to expiresAt. It reports false, without
+	// changing anything, when the hold is no longer pending or has expired at decidedAt.
+	Decide(ctx context.Context, id string, status domain.Status, by string, decidedAt, expiresAt time.Time) (bool, error)
+	// Complete consumes an approved hold. It reports false when the hold is not approved, was already resumed, or
+	// has expired at now.
+	Complete(ctx context.Context, id string, now time.Time) (bool, error)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// TokenPrefix starts every magic link token, so leaked tokens are easy to recognise.
+const TokenPrefix = "ztcp_ml_"
+
+// MagicLink is a one-time passwordless sign-in for UserID in OrgID, sent to the user's email. Only the hash of its
+// token is stored.
+type MagicLink struct {
+	ID        string
+	TokenHash string
+	UserID    string
+	OrgID     string
+	IP        string // client IP of the request
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// Usable reports whether the link can still be redeemed at now: not used and not expired.
+func (m *MagicLink) Usable(now time.Time) bool {
+	return m.UsedAt == nil && now.Before(m.ExpiresAt)
+}
+
+// NewToken returns a new random magic link token and its hash.
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, security.HashToken(token), nil
+}
@@ -0,0 +1,57 @@
+// Package magiclink emails passwordless sign-in links. The links themselves are created and redeemed by
+// AuthService (internal/identity/service).
+package magiclink
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/notification"
+)
+
+// Message is a sign-in link for one user.
+type Message struct {
+	OrgID     string // org the link signs in to; the email is sent through its SMTP server when it has one
+	To        string
+	Link      string
+	ExpiresAt time.Time
+	IP        string // client IP of the request, so users can tell requests they did not make
+}
+
+// EmailMailer sends magic links by email.
+type EmailMailer struct {
+	sender notification.EmailSender
+}
+
+// NewEmailMailer returns a mailer that sends through sender.
+func NewEmailMailer(sender notification.EmailSender) *EmailMailer {
+	return &EmailMailer{sender: sender}
+}
+
+// Send emails m in the background, so RequestMagicLink answers as fast for known addresses as for unknown ones.
+// Failures are logged and not retried.
+func (m *EmailMailer) Send(ctx context.Context, msg Message) {
+	subject, body := FormatEmail(msg)
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := notification.SendOrgEmail(ctx, m.sender, msg.OrgID, msg.To, subject, body); err != nil {
+			log.Printf("magiclink: sign-in email failed: %v", err)
+		}
+	}()
+}
+
+// FormatEmail returns the subject and plain-text body of the sign-in email for msg.
+func FormatEmail(msg Message) (subject, body string) {
+	subject = "Your sign-in link"
+	var b strings.Builder
+	fmt.Fprintf(&b, "Use this link to sign in. It works once and expires at %s.\n\n", msg.ExpiresAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "%s\n\n", msg.Link)
+	if msg.IP != "" {
+		fmt.Fprintf(&b, "Requested from: %s\n", msg.IP)
+	}
+	b.WriteString("If you did not ask to sign in, ignore this email; no one can use the link without access to your inbox.\n")
+	return subject, b.String()
+}
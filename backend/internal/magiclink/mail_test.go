@@ -0,0 +1,39 @@
+package magiclink
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingEmail struct {
+	sent chan string
+}
+
+func (r *recordingEmail) SendEmail(to, subject, body string) error {
+	r.sent <- to + "|" + subject + "|" + body
+	return nil
+}
+
+func TestEmailMailer(t *testing.T) {
+	email := &recordingEmail{sent: make(chan string, 1)}
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	NewEmailMailer(email).Send(context.Background(), Message{
+		To: "user@example.com", Link: "https://app.example.com/magic?token=ztcp_ml_abc", ExpiresAt: expires, IP: "203.0.113.7",
+	})
+	select {
+	case got := <-email.sent:
+		parts := strings.SplitN(got, "|", 3)
+		if parts[0] != "user@example.com" || parts[1] != "Your sign-in link" {
+			t.Errorf("sent to %q with subject %q", parts[0], parts[1])
+		}
+		for _, want := range []string{"https://app.example.com/magic?token=ztcp_ml_abc", "2026-01-02T03:04:05Z", "Requested from: 203.0.113.7"} {
+			if !strings.Contains(parts[2], want) {
+				t.Errorf("body %q does not contain %q", parts[2], want)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("email not sent")
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/magiclink/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a magic link repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists a new unused magic link.
+func (r *PostgresRepository) Create(ctx context.Context, m *domain.MagicLink) error {
+	return r.queries.CreateMagicLink(ctx, gen.CreateMagicLinkParams{
+		ID:        m.ID,
+		TokenHash: m.TokenHash,
+		UserID:    m.UserID,
+		OrgID:     m.OrgID,
+		Ip:        m.IP,
+		CreatedAt: m.CreatedAt,
+		ExpiresAt: m.ExpiresAt,
+	})
+}
+
+// GetByTokenHash returns the magic link, or nil if not found.
+func (r *PostgresRepository) GetByTokenHash(ctx context.Context, hash string) (*domain.MagicLink, error) {
+	row, err := r.queries.GetMagicLinkByTokenHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	m := &domain.MagicLink{
+		ID:        row.ID,
+		TokenHash: row.TokenHash,
+		UserID:    row.UserID,
+		OrgID:     row.OrgID,
+		IP:        row.Ip,
+		CreatedAt: row.CreatedAt,
+		ExpiresAt: row.ExpiresAt,
+	}
+	if row.UsedAt.Valid {
+		t := row.UsedAt.Time
+		m.UsedAt = &t
+	}
+	return m, nil
+}
+
+// Consume marks an unused, unexpired magic link used.
+func (r *PostgresRepository) Consume(ctx context.Context, id string, now time.Time) (bool, error) {
+	n, err := r.queries.ConsumeMagicLink(ctx, gen.ConsumeMagicLinkParams{Now: now, ID: id})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/magiclink/domain"
+)
+
+// Repository persists magic links.
+type Repository interface {
+	// Create persists a new unused magic link. The link must have ID set.
+	Create(ctx context.Context, m *domain.MagicLink) error
+	// GetByTokenHash returns the magic link with this token hash, or nil if not found.
+	GetByTokenHash(ctx context.Context, hash string) (*domain.MagicLink, error)
+	// Consume marks the link used. It reports false, without changing anything, when the link was already used or
+	// has expired at now, so a link is redeemed at most once even under concurrent requests.
+	Consume(ctx context.Context, id string, now time.Time) (bool, error)
+}
@@ -0,0 +1,75 @@
+// Package maintenance holds the platform-wide maintenance mode that the maintenance interceptor enforces.
+package maintenance
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platformsettings/domain"
+)
+
+// DefaultCacheTTL is how long a Switch serves the mode from memory before reloading it. Mode changes made on
+// another instance apply within this time.
+const DefaultCacheTTL = 10 * time.Second
+
+// Store loads and saves the maintenance mode (e.g. the platform settings repository).
+type Store interface {
+	GetMaintenanceState(ctx context.Context) (*domain.MaintenanceState, error)
+	SetMaintenanceState(ctx context.Context, state *domain.MaintenanceState) error
+}
+
+// Switch answers "which maintenance mode is the platform in" from an in-memory copy, reloaded from the store at
+// most every ttl. When the store cannot be read the last known mode is kept, so a database taken down for
+// maintenance does not switch the mode off. A nil *Switch is always off. Safe for concurrent use.
+type Switch struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu       sync.Mutex
+	state    domain.MaintenanceState
+	loadedAt time.Time
+}
+
+// NewSwitch returns a Switch over store. ttl <= 0 uses DefaultCacheTTL.
+func NewSwitch(store Store, ttl time.Duration) *Switch {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Switch{store: store, ttl: ttl, now: time.Now}
+}
+
+// Current returns the maintenance mode.
+func (s *Switch) Current(ctx context.Context) domain.MaintenanceState {
+	if s == nil {
+		return domain.MaintenanceState{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if s.loadedAt.IsZero() || now.Sub(s.loadedAt) >= s.ttl {
+		// Retry no sooner than ttl even on failure, so a database outage does not add a query to every RPC.
+		s.loadedAt = now
+		state, err := s.store.GetMaintenanceState(ctx)
+		if err != nil {
+			log.Printf("maintenance: load mode: %v", err)
+		} else if state != nil {
+			s.state = *state
+		}
+	}
+	return s.state
+}
+
+// Set stores state and applies it on this instance immediately.
+func (s *Switch) Set(ctx context.Context, state domain.MaintenanceState) error {
+	if err := s.store.SetMaintenanceState(ctx, &state); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	s.loadedAt = s.now()
+	return nil
+}
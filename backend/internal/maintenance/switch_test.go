@@ -0,0 +1,113 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platformsettings/domain"
+)
+
+type memStore struct {
+	state *domain.MaintenanceState
+	err   error
+	loads int
+}
+
+func (m *memStore) GetMaintenanceState(ctx context.Context) (*domain.MaintenanceState, error) {
+	m.loads++
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.state == nil {
+		return &domain.MaintenanceState{}, nil
+	}
+	out := *m.state
+	return &out, nil
+}
+
+func (m *memStore) SetMaintenanceState(ctx context.Context, state *domain.MaintenanceState) error {
+	if m.err != nil {
+		return m.err
+	}
+	out := *state
+	m.state = &out
+	return nil
+}
+
+func TestSwitch_NilIsOff(t *testing.T) {
+	var s *Switch
+	if s.Current(context.Background()).Active() {
+		t.Error("nil Switch should be off")
+	}
+}
+
+func TestSwitch_CachesForTTL(t *testing.T) {
+	store := &memStore{state: &domain.MaintenanceState{Mode: domain.MaintenanceReadOnly}}
+	s := NewSwitch(store, time.Minute)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if got := s.Current(ctx).Mode; got != domain.MaintenanceReadOnly {
+		t.Fatalf("mode = %q, want read_only", got)
+	}
+	// Changed on another instance: not seen until the cache expires.
+	store.state = &domain.MaintenanceState{Mode: domain.MaintenanceOff}
+	if got := s.Current(ctx).Mode; got != domain.MaintenanceReadOnly {
+		t.Errorf("cached mode = %q, want read_only", got)
+	}
+	now = now.Add(time.Minute)
+	if got := s.Current(ctx).Mode; got != domain.MaintenanceOff {
+		t.Errorf("reloaded mode = %q, want off", got)
+	}
+	if store.loads != 2 {
+		t.Errorf("loads = %d, want 2", store.loads)
+	}
+}
+
+func TestSwitch_KeepsLastModeOnLoadError(t *testing.T) {
+	store := &memStore{state: &domain.MaintenanceState{Mode: domain.MaintenanceFull}}
+	s := NewSwitch(store, time.Minute)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+	s.Current(ctx)
+
+	store.err = errors.New("database unavailable")
+	now = now.Add(time.Minute)
+	if got := s.Current(ctx).Mode; got != domain.MaintenanceFull {
+		t.Errorf("mode = %q, want maintenance (last known)", got)
+	}
+	s.Current(ctx)
+	if store.loads != 2 {
+		t.Errorf("loads = %d, want 2 (no retry before ttl)", store.loads)
+	}
+}
+
+func TestSwitch_SetAppliesImmediately(t *testing.T) {
+	store := &memStore{}
+	s := NewSwitch(store, time.Hour)
+	ctx := context.Background()
+	if s.Current(ctx).Active() {
+		t.Fatal("should start off")
+	}
+	if err := s.Set(ctx, domain.MaintenanceState{Mode: domain.MaintenanceReadOnly, RetryAfter: time.Minute}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := s.Current(ctx); got.Mode != domain.MaintenanceReadOnly || got.RetryAfter != time.Minute {
+		t.Errorf("state = %+v", got)
+	}
+	if store.state == nil || store.state.Mode != domain.MaintenanceReadOnly {
+		t.Errorf("stored = %+v", store.state)
+	}
+
+	store.err = errors.New("database unavailable")
+	if err := s.Set(ctx, domain.MaintenanceState{Mode: domain.MaintenanceOff}); err == nil {
+		t.Error("Set should return the store error")
+	}
+	if got := s.Current(ctx).Mode; got != domain.MaintenanceReadOnly {
+		t.Errorf("failed Set changed the mode to %q", got)
+	}
+}
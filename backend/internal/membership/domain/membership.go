@@ -11,6 +11,9 @@ type Membership struct {
 	OrgID     string
 	Role      Role
 	CreatedAt time.Time
+	// ElevatedUntil is set when Role is admin only because of a just-in-time elevation (see internal/elevation);
+	// the stored role is member.
+	ElevatedUntil *time.Time
 }
 
 type Role string
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"time"
 
@@ -13,8 +14,10 @@ import (
 	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
 	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
 	"zero-trust-control-plane/backend/internal/audit"
+	grouprepo "zero-trust-control-plane/backend/internal/group/repository"
 	"zero-trust-control-plane/backend/internal/membership/domain"
 	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	"zero-trust-control-plane/backend/internal/orgquota"
 	"zero-trust-control-plane/backend/internal/platform/rbac"
 	userrepo "zero-trust-control-plane/backend/internal/user/repository"
 )
@@ -31,18 +34,24 @@ type Server struct {
 	membershipRepo membershiprepo.Repository
 	userRepo       userrepo.Repository
 	auditLogger    audit.AuditLogger
+	groupRepo      grouprepo.Repository
 }
 
 // NewServer returns a new Membership gRPC server. If membershipRepo is nil, all RPCs return Unimplemented.
-func NewServer(membershipRepo membershiprepo.Repository, userRepo userrepo.Repository, auditLogger audit.AuditLogger) *Server {
+// groupRepo is optional; when non-nil, group admins may list and remove the members of their groups, and removed
+// members leave the org's groups.
+func NewServer(membershipRepo membershiprepo.Repository, userRepo userrepo.Repository, auditLogger audit.AuditLogger, groupRepo grouprepo.Repository) *Server {
 	return &Server{
 		membershipRepo: membershipRepo,
 		userRepo:       userRepo,
 		auditLogger:    auditLogger,
+		groupRepo:      groupRepo,
 	}
 }
 
-// AddMember adds a member to an organization. Caller must be org admin or owner.
+// AddMember adds a member to an organization. Caller must be org admin or owner; adding an admin also requires
+// that the caller is not an admin only through an elevation. Fails with ResourceExhausted when the org has reached its
+// user quota (max_users).
 func (s *Server) AddMember(ctx context.Context, req *membershipv1.AddMemberRequest) (*membershipv1.AddMemberResponse, error) {
 	if s.membershipRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method AddMember not implemented")
@@ -69,6 +78,11 @@ func (s *Server) AddMember(ctx context.Context, req *membershipv1.AddMemberReque
 	if role != domain.RoleAdmin && role != domain.RoleMember {
 		return nil, status.Error(codes.InvalidArgument, "role must be admin or member")
 	}
+	if role == domain.RoleAdmin {
+		if _, _, err := rbac.RequireStandingOrgAdmin(ctx, s.membershipRepo); err != nil {
+			return nil, err
+		}
+	}
 	if s.userRepo != nil {
 		u, err := s.userRepo.GetByID(ctx, targetUserID)
 		if err != nil {
@@ -92,7 +106,9 @@ func (s *Server) AddMember(ctx context.Context, req *membershipv1.AddMemberReque
 		Role:      role,
 		CreatedAt: time.Now().UTC(),
 	}
-	if err := s.membershipRepo.CreateMembership(ctx, m); err != nil {
+	if err := s.membershipRepo.CreateMembership(ctx, m); errors.Is(err, orgquota.ErrUserQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, "organization has reached its user quota")
+	} else if err != nil {
 		return nil, status.Error(codes.Internal, "failed to create membership")
 	}
 	if s.auditLogger != nil {
@@ -103,15 +119,17 @@ func (s *Server) AddMember(ctx context.Context, req *membershipv1.AddMemberReque
 	}, nil
 }
 
-// RemoveMember removes a member from an organization. Caller must be org admin or owner. Cannot remove the last owner.
+// RemoveMember removes a member from an organization. Caller must be org admin or owner, or a group admin of the
+// member (members with role member only). Cannot remove the last owner.
 func (s *Server) RemoveMember(ctx context.Context, req *membershipv1.RemoveMemberRequest) (*membershipv1.RemoveMemberResponse, error) {
 	if s.membershipRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method RemoveMember not implemented")
 	}
-	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	scope, err := rbac.RequireAdminScope(ctx, s.membershipRepo, s.groupRepo)
 	if err != nil {
 		return nil, err
 	}
+	orgID, userID := scope.OrgID, scope.UserID
 	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
 		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
 	}
@@ -127,9 +145,12 @@ func (s *Server) RemoveMember(ctx context.Context, req *membershipv1.RemoveMembe
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to look up membership")
 	}
-	if m == nil {
+	if m == nil || !scope.Allows(targetUserID) {
 		return nil, status.Error(codes.NotFound, "membership not found")
 	}
+	if !scope.OrgWide() && m.Role != domain.RoleMember {
+		return nil, status.Error(codes.PermissionDenied, "group admins can only remove members")
+	}
 	if m.Role == domain.RoleOwner {
 		count, err := s.membershipRepo.CountOwnersByOrg(ctx, targetOrgID)
 		if err != nil {
@@ -142,18 +163,24 @@ func (s *Server) RemoveMember(ctx context.Context, req *membershipv1.RemoveMembe
 	if err := s.membershipRepo.DeleteByUserAndOrg(ctx, targetUserID, targetOrgID); err != nil {
 		return nil, status.Error(codes.Internal, "failed to remove member")
 	}
+	if s.groupRepo != nil {
+		if err := s.groupRepo.RemoveUserFromOrgGroups(ctx, targetUserID, targetOrgID); err != nil {
+			return nil, status.Error(codes.Internal, "failed to remove member from groups")
+		}
+	}
 	if s.auditLogger != nil {
 		s.auditLogger.LogEvent(ctx, targetOrgID, userID, "remove", "membership", targetUserID)
 	}
 	return &membershipv1.RemoveMemberResponse{}, nil
 }
 
-// UpdateRole updates a member's role. Caller must be org admin or owner. Cannot demote the last owner.
+// UpdateRole updates a member's role. Caller must be org admin or owner, and not only through an elevation.
+// Cannot demote the last owner.
 func (s *Server) UpdateRole(ctx context.Context, req *membershipv1.UpdateRoleRequest) (*membershipv1.UpdateRoleResponse, error) {
 	if s.membershipRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method UpdateRole not implemented")
 	}
-	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	orgID, userID, err := rbac.RequireStandingOrgAdmin(ctx, s.membershipRepo)
 	if err != nil {
 		return nil, err
 	}
@@ -203,15 +230,17 @@ func (s *Server) UpdateRole(ctx context.Context, req *membershipv1.UpdateRoleReq
 	}, nil
 }
 
-// ListMembers returns a paginated list of members for the org. Caller must be org admin or owner.
+// ListMembers returns a paginated list of members for the org. Caller must be org admin or owner; group admins get
+// the members of their groups.
 func (s *Server) ListMembers(ctx context.Context, req *membershipv1.ListMembersRequest) (*membershipv1.ListMembersResponse, error) {
 	if s.membershipRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method ListMembers not implemented")
 	}
-	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	scope, err := rbac.RequireAdminScope(ctx, s.membershipRepo, s.groupRepo)
 	if err != nil {
 		return nil, err
 	}
+	orgID := scope.OrgID
 	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
 		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
 	}
@@ -240,6 +269,15 @@ func (s *Server) ListMembers(ctx context.Context, req *membershipv1.ListMembersR
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to list members")
 	}
+	if !scope.OrgWide() {
+		scoped := all[:0:0]
+		for _, m := range all {
+			if scope.Allows(m.UserID) {
+				scoped = append(scoped, m)
+			}
+		}
+		all = scoped
+	}
 	total := int32(len(all))
 	start := offset
 	if start > total {
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 	"time"
@@ -11,7 +12,12 @@ import (
 
 	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
 	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	groupdomain "zero-trust-control-plane/backend/internal/group/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/license"
 	"zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
 	"zero-trust-control-plane/backend/internal/server/interceptors"
 	userdomain "zero-trust-control-plane/backend/internal/user/domain"
 )
@@ -120,6 +126,14 @@ func (m *mockUserRepo) Create(ctx context.Context, u *userdomain.User) error {
 	return nil
 }
 
+func (m *mockUserRepo) CreateWithIdentity(ctx context.Context, u *userdomain.User, i *identitydomain.Identity, seats license.SeatReserver) error {
+	return nil
+}
+
+func (m *mockUserRepo) CreateWithMembership(ctx context.Context, u *userdomain.User, mem *domain.Membership, d *devicedomain.Device, seats license.SeatReserver) error {
+	return nil
+}
+
 func (m *mockUserRepo) Update(ctx context.Context, u *userdomain.User) error {
 	return nil
 }
@@ -128,6 +142,18 @@ func (m *mockUserRepo) SetPhoneVerified(ctx context.Context, userID, phone strin
 	return nil
 }
 
+func (m *mockUserRepo) ChangePhone(ctx context.Context, userID, oldPhone, newPhone string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockUserRepo) ChangeEmail(ctx context.Context, userID, oldEmail, newEmail string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockUserRepo) ResetMFA(ctx context.Context, userID string) (bool, error) {
+	return false, nil
+}
+
 // mockAuditLogger implements audit.AuditLogger for tests.
 type mockAuditLogger struct {
 	events []struct {
@@ -163,7 +189,7 @@ func TestAddMember_Success(t *testing.T) {
 		},
 	}
 	auditLogger := &mockAuditLogger{}
-	srv := NewServer(membershipRepo, userRepo, auditLogger)
+	srv := NewServer(membershipRepo, userRepo, auditLogger, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	resp, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -191,6 +217,32 @@ func TestAddMember_Success(t *testing.T) {
 	}
 }
 
+func TestAddMember_UserQuota(t *testing.T) {
+	newRepo := func() *mockMembershipRepo {
+		return &mockMembershipRepo{
+			memberships: map[string]*domain.Membership{
+				"admin-1:org-1": {ID: "m-admin", UserID: "admin-1", OrgID: "org-1", Role: domain.RoleAdmin},
+			},
+			byID:        make(map[string]*domain.Membership),
+			ownerCounts: make(map[string]int64),
+		}
+	}
+	ctx := ctxWithAdmin("org-1", "admin-1")
+	req := &membershipv1.AddMemberRequest{UserId: "user-2", Role: membershipv1.Role_ROLE_MEMBER}
+
+	// The repository checks max_users in the insert transaction.
+	full := newRepo()
+	full.createErr = orgquota.ErrUserQuotaExceeded
+	if _, err := NewServer(full, nil, nil, nil).AddMember(ctx, req); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("AddMember over quota = %v, want ResourceExhausted", err)
+	}
+	failing := newRepo()
+	failing.createErr = errors.New("db down")
+	if _, err := NewServer(failing, nil, nil, nil).AddMember(ctx, req); status.Code(err) != codes.Internal {
+		t.Errorf("AddMember with a failing insert = %v, want Internal", err)
+	}
+}
+
 func TestAddMember_DuplicateMember(t *testing.T) {
 	existing := &domain.Membership{
 		ID:     "m1",
@@ -206,7 +258,7 @@ func TestAddMember_DuplicateMember(t *testing.T) {
 		byID:        make(map[string]*domain.Membership),
 		ownerCounts: make(map[string]int64),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -233,7 +285,7 @@ func TestAddMember_InvalidUserID(t *testing.T) {
 		byID:        make(map[string]*domain.Membership),
 		ownerCounts: make(map[string]int64),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -267,7 +319,7 @@ func TestRemoveMember_RepositoryError(t *testing.T) {
 	userRepo := &mockUserRepo{
 		users: make(map[string]*userdomain.User),
 	}
-	srv := NewServer(membershipRepo, userRepo, nil)
+	srv := NewServer(membershipRepo, userRepo, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{
@@ -297,7 +349,7 @@ func TestUpdateRole_NotFound(t *testing.T) {
 	userRepo := &mockUserRepo{
 		users: make(map[string]*userdomain.User),
 	}
-	srv := NewServer(membershipRepo, userRepo, nil)
+	srv := NewServer(membershipRepo, userRepo, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.UpdateRole(ctx, &membershipv1.UpdateRoleRequest{
@@ -330,7 +382,7 @@ func TestUpdateRole_RepositoryError(t *testing.T) {
 	userRepo := &mockUserRepo{
 		users: make(map[string]*userdomain.User),
 	}
-	srv := NewServer(membershipRepo, userRepo, nil)
+	srv := NewServer(membershipRepo, userRepo, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.UpdateRole(ctx, &membershipv1.UpdateRoleRequest{
@@ -435,7 +487,7 @@ func TestAddMember_UserNotFound(t *testing.T) {
 		ownerCounts: make(map[string]int64),
 	}
 	userRepo := &mockUserRepo{users: make(map[string]*userdomain.User)}
-	srv := NewServer(membershipRepo, userRepo, nil)
+	srv := NewServer(membershipRepo, userRepo, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -459,7 +511,7 @@ func TestAddMember_NonAdminCaller(t *testing.T) {
 		memberships: make(map[string]*domain.Membership),
 		byID:        make(map[string]*domain.Membership),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithMember("org-1", "member-1")
 
 	_, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -483,7 +535,7 @@ func TestAddMember_OrgIDMismatch(t *testing.T) {
 		memberships: make(map[string]*domain.Membership),
 		byID:        make(map[string]*domain.Membership),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -503,7 +555,7 @@ func TestAddMember_OrgIDMismatch(t *testing.T) {
 }
 
 func TestAddMember_NilRepo(t *testing.T) {
-	srv := NewServer(nil, nil, nil)
+	srv := NewServer(nil, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -530,7 +582,7 @@ func TestAddMember_DefaultRole(t *testing.T) {
 		byID:        make(map[string]*domain.Membership),
 		ownerCounts: make(map[string]int64),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	resp, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{
@@ -562,7 +614,7 @@ func TestRemoveMember_Success(t *testing.T) {
 		ownerCounts: map[string]int64{"org-1": 1},
 	}
 	auditLogger := &mockAuditLogger{}
-	srv := NewServer(membershipRepo, nil, auditLogger)
+	srv := NewServer(membershipRepo, nil, auditLogger, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{
@@ -585,7 +637,7 @@ func TestRemoveMember_NotFound(t *testing.T) {
 		byID:        make(map[string]*domain.Membership),
 		ownerCounts: make(map[string]int64),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{
@@ -619,7 +671,7 @@ func TestRemoveMember_LastOwnerProtection(t *testing.T) {
 		byID:        make(map[string]*domain.Membership),
 		ownerCounts: map[string]int64{"org-1": 1},
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{
@@ -643,7 +695,7 @@ func TestRemoveMember_NonAdminCaller(t *testing.T) {
 		memberships: make(map[string]*domain.Membership),
 		byID:        make(map[string]*domain.Membership),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithMember("org-1", "member-1")
 
 	_, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{
@@ -678,7 +730,7 @@ func TestUpdateRole_Success(t *testing.T) {
 		ownerCounts: map[string]int64{"org-1": 1},
 	}
 	auditLogger := &mockAuditLogger{}
-	srv := NewServer(membershipRepo, nil, auditLogger)
+	srv := NewServer(membershipRepo, nil, auditLogger, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	resp, err := srv.UpdateRole(ctx, &membershipv1.UpdateRoleRequest{
@@ -712,7 +764,7 @@ func TestUpdateRole_LastOwnerDemotionProtection(t *testing.T) {
 		byID:        make(map[string]*domain.Membership),
 		ownerCounts: map[string]int64{"org-1": 1},
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.UpdateRole(ctx, &membershipv1.UpdateRoleRequest{
@@ -747,7 +799,7 @@ func TestUpdateRole_InvalidRole(t *testing.T) {
 		byID:        make(map[string]*domain.Membership),
 		ownerCounts: make(map[string]int64),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.UpdateRole(ctx, &membershipv1.UpdateRoleRequest{
@@ -783,7 +835,7 @@ func TestListMembers_Success(t *testing.T) {
 		},
 		byID: make(map[string]*domain.Membership),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	resp, err := srv.ListMembers(ctx, &membershipv1.ListMembersRequest{
@@ -818,7 +870,7 @@ func TestListMembers_Pagination(t *testing.T) {
 		memberships: membershipMap,
 		byID:        make(map[string]*domain.Membership),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	resp, err := srv.ListMembers(ctx, &membershipv1.ListMembersRequest{
@@ -860,7 +912,7 @@ func TestListMembers_MaxPageSize(t *testing.T) {
 		memberships: membershipMap,
 		byID:        make(map[string]*domain.Membership),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	resp, err := srv.ListMembers(ctx, &membershipv1.ListMembersRequest{
@@ -883,7 +935,7 @@ func TestListMembers_NonAdminCaller(t *testing.T) {
 		memberships: make(map[string]*domain.Membership),
 		byID:        make(map[string]*domain.Membership),
 	}
-	srv := NewServer(membershipRepo, nil, nil)
+	srv := NewServer(membershipRepo, nil, nil, nil)
 	ctx := ctxWithMember("org-1", "member-1")
 
 	_, err := srv.ListMembers(ctx, &membershipv1.ListMembersRequest{
@@ -902,7 +954,7 @@ func TestListMembers_NonAdminCaller(t *testing.T) {
 }
 
 func TestListMembers_NilRepo(t *testing.T) {
-	srv := NewServer(nil, nil, nil)
+	srv := NewServer(nil, nil, nil, nil)
 	ctx := ctxWithAdmin("org-1", "admin-1")
 
 	_, err := srv.ListMembers(ctx, &membershipv1.ListMembersRequest{
@@ -959,3 +1011,83 @@ func TestDomainRoleToProto(t *testing.T) {
 		}
 	}
 }
+
+// mockGroupRepo implements grouprepo.Repository; only the group-admin scope and user removal are used.
+type mockGroupRepo struct {
+	managed map[string][]string // key: adminUserID:orgID
+	removed []string            // userID:orgID per RemoveUserFromOrgGroups call
+}
+
+func (m *mockGroupRepo) Create(ctx context.Context, g *groupdomain.Group) error { return nil }
+
+func (m *mockGroupRepo) GetByID(ctx context.Context, id string) (*groupdomain.Group, error) {
+	return nil, nil
+}
+
+func (m *mockGroupRepo) ListByOrg(ctx context.Context, orgID string) ([]*groupdomain.Group, error) {
+	return nil, nil
+}
+
+func (m *mockGroupRepo) Delete(ctx context.Context, id string) (bool, error) { return false, nil }
+
+func (m *mockGroupRepo) SetMember(ctx context.Context, mem *groupdomain.Member) error { return nil }
+
+func (m *mockGroupRepo) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockGroupRepo) ListMembers(ctx context.Context, groupID string) ([]*groupdomain.Member, error) {
+	return nil, nil
+}
+
+func (m *mockGroupRepo) RemoveUserFromOrgGroups(ctx context.Context, userID, orgID string) error {
+	m.removed = append(m.removed, userID+":"+orgID)
+	return nil
+}
+
+func (m *mockGroupRepo) ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error) {
+	return m.managed[adminUserID+":"+orgID], nil
+}
+
+func (m *mockGroupRepo) ListUserGroupNames(ctx context.Context, orgID, userID string) ([]string, error) {
+	return nil, nil
+}
+
+func TestMembershipRPCs_GroupAdminScope(t *testing.T) {
+	membershipRepo := &mockMembershipRepo{
+		memberships: map[string]*domain.Membership{
+			"lead-1:org-1":  {ID: "m1", UserID: "lead-1", OrgID: "org-1", Role: domain.RoleMember},
+			"user-1:org-1":  {ID: "m2", UserID: "user-1", OrgID: "org-1", Role: domain.RoleMember},
+			"user-2:org-1":  {ID: "m3", UserID: "user-2", OrgID: "org-1", Role: domain.RoleMember},
+			"admin-1:org-1": {ID: "m4", UserID: "admin-1", OrgID: "org-1", Role: domain.RoleAdmin},
+		},
+		byID:        make(map[string]*domain.Membership),
+		ownerCounts: map[string]int64{"org-1": 1},
+	}
+	groups := &mockGroupRepo{managed: map[string][]string{"lead-1:org-1": {"lead-1", "user-1", "admin-1"}}}
+	srv := NewServer(membershipRepo, nil, nil, groups)
+	ctx := ctxWithMember("org-1", "lead-1")
+
+	resp, err := srv.ListMembers(ctx, &membershipv1.ListMembersRequest{OrgId: "org-1"})
+	if err != nil {
+		t.Fatalf("ListMembers: %v", err)
+	}
+	if len(resp.Members) != 3 {
+		t.Errorf("members count = %d, want 3 (the group admin's scope)", len(resp.Members))
+	}
+	if _, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{UserId: "user-2", OrgId: "org-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("RemoveMember outside scope: code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{UserId: "admin-1", OrgId: "org-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RemoveMember of an org admin: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.RemoveMember(ctx, &membershipv1.RemoveMemberRequest{UserId: "user-1", OrgId: "org-1"}); err != nil {
+		t.Fatalf("RemoveMember in scope: %v", err)
+	}
+	if len(groups.removed) != 1 || groups.removed[0] != "user-1:org-1" {
+		t.Errorf("removed from groups = %v, want [user-1:org-1]", groups.removed)
+	}
+	if _, err := srv.AddMember(ctx, &membershipv1.AddMemberRequest{UserId: "user-3", OrgId: "org-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("AddMember as group admin: code = %v, want PermissionDenied", status.Code(err))
+	}
+}
@@ -7,15 +7,17 @@ import (
 
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
 	"zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
 )
 
 type PostgresRepository struct {
+	db      *sql.DB
 	queries *gen.Queries
 }
 
 // NewPostgresRepository returns a membership repository that uses the given db for persistence.
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+	return &PostgresRepository{db: db, queries: gen.New(db)}
 }
 
 // GetMembershipByID returns the membership for id, or nil if not found.
@@ -57,12 +59,24 @@ func (r *PostgresRepository) ListMembershipsByOrg(ctx context.Context, orgID str
 	return out, nil
 }
 
-// CreateMembership persists the membership to the database. The membership must have ID set.
+// CreateMembership persists the membership to the database. The membership must have ID set. Returns
+// orgquota.ErrUserQuotaExceeded when the org has reached max_users; the check and the insert run in one transaction.
 func (r *PostgresRepository) CreateMembership(ctx context.Context, m *domain.Membership) error {
-	_, err := r.queries.CreateMembership(ctx, gen.CreateMembershipParams{
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	if err := orgquota.ReserveUser(ctx, q, m.OrgID); err != nil {
+		return err
+	}
+	if _, err := q.CreateMembership(ctx, gen.CreateMembershipParams{
 		ID: m.ID, UserID: m.UserID, OrgID: m.OrgID, Role: gen.Role(m.Role), CreatedAt: m.CreatedAt,
-	})
-	return err
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // DeleteByUserAndOrg removes the membership for the given user and org. Idempotent; no error if not found.
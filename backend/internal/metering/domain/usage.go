@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Usage is one org's metered usage in one UTC calendar month.
+type Usage struct {
+	OrgID         string
+	Month         time.Time // first day of the month
+	ActiveUsers   int64     // distinct users that signed in or made an authenticated call
+	Logins        int64     // completed sign-ins
+	MFAChallenges int64     // OTP challenges sent by SMS
+	MFAResends    int64     // OTP codes sent again for an open challenge
+	APICalls      int64     // authenticated RPCs
+	UpdatedAt     time.Time
+}
+
+// SMSMessages returns the OTP texts sent in the month (challenges and resends), the SMS cost driver.
+func (u Usage) SMSMessages() int64 {
+	return u.MFAChallenges + u.MFAResends
+}
+
+// MonthOf returns the first instant of t's UTC calendar month.
+func MonthOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,182 @@
+// Package metering counts per-org usage for billing: monthly active users, logins, MFA challenges (the SMS cost
+// driver) and API calls. The Meter counts authenticated RPCs and their users in memory and flushes them to the
+// usage_monthly rollups; the Job flushes it and recomputes the counters derived from audit logs. Usage is served by
+// AnalyticsService GetUsage and exported for billing systems with AdminService ExportUsage.
+package metering
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/metering/domain"
+)
+
+// Store persists metered usage. Implemented by the metering repository.
+type Store interface {
+	AddAPICalls(ctx context.Context, orgID string, month time.Time, n int64) error
+	AddActiveUsers(ctx context.Context, orgID string, month time.Time, userIDs []string) error
+	RollupMonth(ctx context.Context, month time.Time) error
+}
+
+type orgMonth struct {
+	orgID string
+	month time.Time
+}
+
+// Meter counts API calls and active users per org and month until they are flushed. Safe for concurrent use.
+// A nil *Meter counts nothing.
+type Meter struct {
+	store Store
+	now   func() time.Time
+
+	mu    sync.Mutex
+	calls map[orgMonth]int64
+	users map[orgMonth]map[string]bool // not yet flushed
+	seen  map[orgMonth]map[string]bool // recorded this month, so each user is written once per instance
+}
+
+// NewMeter returns a meter that flushes to store.
+func NewMeter(store Store) *Meter {
+	return &Meter{
+		store: store,
+		now:   time.Now,
+		calls: make(map[orgMonth]int64),
+		users: make(map[orgMonth]map[string]bool),
+		seen:  make(map[orgMonth]map[string]bool),
+	}
+}
+
+// Record counts one API call of userID in orgID. Calls without an org are not counted.
+func (m *Meter) Record(orgID, userID string) {
+	if m == nil || orgID == "" {
+		return
+	}
+	k := orgMonth{orgID: orgID, month: domain.MonthOf(m.now())}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls[k]++
+	if userID == "" || m.seen[k][userID] {
+		return
+	}
+	addUser(m.seen, k, userID)
+	addUser(m.users, k, userID)
+}
+
+// Flush writes the counts recorded since the last flush. Counts that cannot be written are kept for the next flush;
+// the first error is returned.
+func (m *Meter) Flush(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	calls, users := m.calls, m.users
+	m.calls, m.users = make(map[orgMonth]int64), make(map[orgMonth]map[string]bool)
+	current := domain.MonthOf(m.now())
+	for k := range m.seen {
+		if k.month.Before(current) {
+			delete(m.seen, k)
+		}
+	}
+	m.mu.Unlock()
+
+	var firstErr error
+	for k, n := range calls {
+		if err := m.store.AddAPICalls(ctx, k.orgID, k.month, n); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			m.mu.Lock()
+			m.calls[k] += n
+			m.mu.Unlock()
+		}
+	}
+	for k, set := range users {
+		ids := make([]string, 0, len(set))
+		for id := range set {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		if err := m.store.AddActiveUsers(ctx, k.orgID, k.month, ids); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			m.mu.Lock()
+			for _, id := range ids {
+				addUser(m.users, k, id)
+			}
+			m.mu.Unlock()
+		}
+	}
+	return firstErr
+}
+
+func addUser(sets map[orgMonth]map[string]bool, k orgMonth, userID string) {
+	if sets[k] == nil {
+		sets[k] = make(map[string]bool)
+	}
+	sets[k][userID] = true
+}
+
+// Job periodically flushes a meter and recomputes the monthly rollups.
+type Job struct {
+	meter *Meter
+	store Store
+	now   func() time.Time
+}
+
+// NewJob returns a job that flushes meter and rolls up through store.
+func NewJob(meter *Meter, store Store) *Job {
+	return &Job{meter: meter, store: store, now: time.Now}
+}
+
+// RollupOnce recomputes the current month and, during the first day of a month, the previous one, so events
+// written just before midnight are counted. Returns the first error; the other month is still attempted.
+func (j *Job) RollupOnce(ctx context.Context) error {
+	now := j.now().UTC()
+	var firstErr error
+	if prev := domain.MonthOf(now.Add(-24 * time.Hour)); !prev.Equal(domain.MonthOf(now)) {
+		firstErr = j.store.RollupMonth(ctx, prev)
+	}
+	if err := j.store.RollupMonth(ctx, now); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// Run rolls up the previous and current month, then flushes the meter every flushInterval and rolls up every
+// rollupInterval (0 disables the rollups) until ctx is done. The caller flushes the meter once more on shutdown.
+func (j *Job) Run(ctx context.Context, flushInterval, rollupInterval time.Duration) {
+	if rollupInterval > 0 {
+		if err := j.store.RollupMonth(ctx, domain.MonthOf(j.now()).AddDate(0, -1, 0)); err != nil {
+			log.Printf("metering: rollup backfill failed: %v", err)
+		}
+		if err := j.RollupOnce(ctx); err != nil {
+			log.Printf("metering: rollup failed: %v", err)
+		}
+	}
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+	var rollup <-chan time.Time
+	if rollupInterval > 0 {
+		t := time.NewTicker(rollupInterval)
+		defer t.Stop()
+		rollup = t.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-flush.C:
+			if err := j.meter.Flush(ctx); err != nil {
+				log.Printf("metering: flush failed: %v", err)
+			}
+		case <-rollup:
+			if err := j.RollupOnce(ctx); err != nil {
+				log.Printf("metering: rollup failed: %v", err)
+			}
+		}
+	}
+}
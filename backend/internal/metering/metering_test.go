@@ -0,0 +1,125 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingStore struct {
+	calls  map[string]int64
+	users  map[string][]string
+	months []string
+	fail   bool
+}
+
+func newRecordingStore() *recordingStore {
+	return &recordingStore{calls: map[string]int64{}, users: map[string][]string{}}
+}
+
+func (s *recordingStore) AddAPICalls(ctx context.Context, orgID string, month time.Time, n int64) error {
+	if s.fail {
+		return errors.New("db down")
+	}
+	s.calls[orgID+"/"+month.Format("2006-01")] += n
+	return nil
+}
+
+func (s *recordingStore) AddActiveUsers(ctx context.Context, orgID string, month time.Time, userIDs []string) error {
+	if s.fail {
+		return errors.New("db down")
+	}
+	k := orgID + "/" + month.Format("2006-01")
+	s.users[k] = append(s.users[k], userIDs...)
+	return nil
+}
+
+func (s *recordingStore) RollupMonth(ctx context.Context, month time.Time) error {
+	s.months = append(s.months, month.Format("2006-01"))
+	return nil
+}
+
+func TestMeter_Flush(t *testing.T) {
+	store := newRecordingStore()
+	m := NewMeter(store)
+	now := time.Date(2026, 3, 31, 23, 59, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	m.Record("org-1", "user-1")
+	m.Record("org-1", "user-1")
+	m.Record("org-1", "user-2")
+	m.Record("org-2", "")
+	m.Record("", "user-3")
+	now = now.Add(2 * time.Minute)
+	m.Record("org-1", "user-1")
+
+	if err := m.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if store.calls["org-1/2026-03"] != 3 || store.calls["org-2/2026-03"] != 1 || store.calls["org-1/2026-04"] != 1 {
+		t.Errorf("calls = %v, want 3 and 1 in March, 1 in April", store.calls)
+	}
+	if got := strings.Join(store.users["org-1/2026-03"], ","); got != "user-1,user-2" {
+		t.Errorf("March users = %s, want user-1,user-2", got)
+	}
+	if got := strings.Join(store.users["org-1/2026-04"], ","); got != "user-1" {
+		t.Errorf("April users = %s, want user-1 (counted again in a new month)", got)
+	}
+
+	m.Record("org-1", "user-1")
+	if err := m.Flush(context.Background()); err != nil {
+		t.Fatalf("second Flush: %v", err)
+	}
+	if store.calls["org-1/2026-04"] != 2 || len(store.users["org-1/2026-04"]) != 1 {
+		t.Errorf("after second flush calls = %v, users = %v; want the call added and the user written once", store.calls, store.users)
+	}
+}
+
+func TestMeter_FlushKeepsCountsOnError(t *testing.T) {
+	store := newRecordingStore()
+	m := NewMeter(store)
+	m.now = func() time.Time { return time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC) }
+
+	m.Record("org-1", "user-1")
+	store.fail = true
+	if err := m.Flush(context.Background()); err == nil {
+		t.Fatal("Flush should return the store error")
+	}
+	m.Record("org-1", "user-1")
+	store.fail = false
+	if err := m.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush after recovery: %v", err)
+	}
+	if store.calls["org-1/2026-03"] != 2 || len(store.users["org-1/2026-03"]) != 1 {
+		t.Errorf("calls = %v, users = %v; want both calls and the user kept for the retry", store.calls, store.users)
+	}
+
+	var nilMeter *Meter
+	nilMeter.Record("org-1", "user-1")
+	if err := nilMeter.Flush(context.Background()); err != nil {
+		t.Errorf("nil meter Flush: %v", err)
+	}
+}
+
+func TestJob_RollupOnce(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC), "2026-03,2026-04"},
+		{time.Date(2026, 4, 2, 6, 0, 0, 0, time.UTC), "2026-04"},
+	}
+	for _, tt := range tests {
+		store := newRecordingStore()
+		job := NewJob(NewMeter(store), store)
+		job.now = func() time.Time { return tt.now }
+		if err := job.RollupOnce(context.Background()); err != nil {
+			t.Fatalf("RollupOnce: %v", err)
+		}
+		if got := strings.Join(store.months, ","); got != tt.want {
+			t.Errorf("at %s rolled up %s, want %s", tt.now.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/metering/domain"
+	"zero-trust-control-plane/backend/internal/residency"
+)
+
+// PostgresRepository implements Repository using the usage_monthly and usage_active_users tables.
+type PostgresRepository struct {
+	queries *gen.Queries
+	router  *residency.Router
+}
+
+// NewPostgresRepository returns a metering repository that uses the given db for persistence. When router is not
+// nil, the usage of an org with a data region is stored in that region's database, next to the audit logs it is
+// rolled up from.
+func NewPostgresRepository(db *sql.DB, router *residency.Router) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), router: router}
+}
+
+// queriesFor returns the queries for the database holding orgID's usage.
+func (r *PostgresRepository) queriesFor(ctx context.Context, orgID string) (*gen.Queries, error) {
+	if r.router == nil {
+		return r.queries, nil
+	}
+	db, err := r.router.DB(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return gen.New(db), nil
+}
+
+// eachDatabase calls fn with the queries of every database holding usage.
+func (r *PostgresRepository) eachDatabase(fn func(q *gen.Queries) error) error {
+	if r.router == nil {
+		return fn(r.queries)
+	}
+	for _, db := range r.router.Databases() {
+		if err := fn(gen.New(db)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddAPICalls adds n to the org's API calls for the month containing month.
+func (r *PostgresRepository) AddAPICalls(ctx context.Context, orgID string, month time.Time, n int64) error {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	return q.AddMonthlyAPICalls(ctx, gen.AddMonthlyAPICallsParams{
+		OrgID: orgID, Month: domain.MonthOf(month), ApiCalls: n, UpdatedAt: time.Now().UTC(),
+	})
+}
+
+// AddActiveUsers records userIDs as active in the org in the month containing month.
+func (r *PostgresRepository) AddActiveUsers(ctx context.Context, orgID string, month time.Time, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	return q.AddActiveUsers(ctx, gen.AddActiveUsersParams{OrgID: orgID, Month: domain.MonthOf(month), UserIds: userIDs})
+}
+
+// RollupMonth recomputes the audit-derived counters and active users of the month containing month, in every
+// database.
+func (r *PostgresRepository) RollupMonth(ctx context.Context, month time.Time) error {
+	start := domain.MonthOf(month)
+	return r.eachDatabase(func(q *gen.Queries) error {
+		return q.RollupMonthlyUsage(ctx, gen.RollupMonthlyUsageParams{
+			StartAt: start, EndAt: start.AddDate(0, 1, 0), Month: start, UpdatedAt: time.Now().UTC(),
+		})
+	})
+}
+
+// ListMonthlyUsage returns the org's usage between the months of from and to, oldest first.
+func (r *PostgresRepository) ListMonthlyUsage(ctx context.Context, orgID string, from, to time.Time) ([]*domain.Usage, error) {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListMonthlyUsage(ctx, gen.ListMonthlyUsageParams{
+		OrgID: orgID, FromMonth: domain.MonthOf(from), ToMonth: domain.MonthOf(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usageToDomain(rows), nil
+}
+
+// ListUsageForMonth returns up to limit orgs' usage for the month containing month, after afterOrgID, ordered by org
+// ID across every database.
+func (r *PostgresRepository) ListUsageForMonth(ctx context.Context, month time.Time, afterOrgID string, limit int32) ([]*domain.Usage, error) {
+	var out []*domain.Usage
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		rows, err := q.ListUsageForMonth(ctx, gen.ListUsageForMonthParams{
+			Month: domain.MonthOf(month), AfterOrgID: afterOrgID, MaxResults: limit,
+		})
+		out = append(out, usageToDomain(rows)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OrgID < out[j].OrgID })
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func usageToDomain(rows []gen.UsageMonthly) []*domain.Usage {
+	out := make([]*domain.Usage, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.Usage{
+			OrgID:         row.OrgID,
+			Month:         row.Month,
+			ActiveUsers:   row.ActiveUsers,
+			Logins:        row.Logins,
+			MFAChallenges: row.MfaChallenges,
+			MFAResends:    row.MfaResends,
+			APICalls:      row.ApiCalls,
+			UpdatedAt:     row.UpdatedAt,
+		}
+	}
+	return out
+}
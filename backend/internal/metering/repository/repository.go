@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/metering/domain"
+)
+
+// Repository stores the monthly usage rollups. Month arguments are UTC calendar months (any instant in the month).
+type Repository interface {
+	// AddAPICalls adds n to the org's API calls for month.
+	AddAPICalls(ctx context.Context, orgID string, month time.Time, n int64) error
+	// AddActiveUsers records that userIDs were active in the org in month. Users already recorded are ignored.
+	AddActiveUsers(ctx context.Context, orgID string, month time.Time, userIDs []string) error
+	// RollupMonth recomputes the active users, logins and MFA counters of every org for month. Idempotent.
+	RollupMonth(ctx context.Context, month time.Time) error
+	// ListMonthlyUsage returns the org's usage from month from to month to (inclusive), oldest first. Months
+	// without usage are omitted.
+	ListMonthlyUsage(ctx context.Context, orgID string, from, to time.Time) ([]*domain.Usage, error)
+	// ListUsageForMonth returns up to limit orgs' usage for month, ordered by org ID, starting after afterOrgID.
+	ListUsageForMonth(ctx context.Context, month time.Time, afterOrgID string, limit int32) ([]*domain.Usage, error)
+}
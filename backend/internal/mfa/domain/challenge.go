@@ -2,14 +2,34 @@ package domain
 
 import "time"
 
+// MethodSMSOTP is the method of challenges whose code was sent by SMS.
+const MethodSMSOTP = "sms_otp"
+
 // Challenge represents an MFA OTP challenge (stored in mfa_challenges table).
 type Challenge struct {
 	ID        string
 	UserID    string
 	OrgID     string
-	DeviceID  string
+	DeviceID  string // empty for a public device sign-in
 	Phone     string
 	CodeHash  string
 	ExpiresAt time.Time
 	CreatedAt time.Time
+	Method    string // MFA method that issued the challenge and verifies its code; "" is treated as MethodSMSOTP
+	// Attempts counts wrong codes; the challenge is invalidated once it reaches the service's limit.
+	Attempts int
+	// ResendCount is how many times the code was replaced and sent again; LastSentAt is when the current code was
+	// sent (CreatedAt until the first resend).
+	ResendCount int
+	LastSentAt  time.Time
+	// TrustDays is the remember-device duration asked for at Login (see Device.TrustDays); 0 when none was.
+	TrustDays int
+}
+
+// SentAt returns when the challenge's current code was sent.
+func (c *Challenge) SentAt() time.Time {
+	if c.LastSentAt.IsZero() {
+		return c.CreatedAt
+	}
+	return c.LastSentAt
 }
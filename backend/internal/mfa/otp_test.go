@@ -102,3 +102,41 @@ func TestOTPEqual_EmptyInputs(t *testing.T) {
 		t.Error("OTPEqual should not match empty OTP")
 	}
 }
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes: %v", err)
+	}
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("got %d codes, want %d", len(codes), RecoveryCodeCount)
+	}
+	seen := make(map[string]bool)
+	for _, c := range codes {
+		if len(c) != 11 || c[5] != '-' || NormalizeRecoveryCode(c) == "" {
+			t.Errorf("code %q is not shaped xxxxx-xxxxx", c)
+		}
+		if seen[c] {
+			t.Errorf("duplicate code %q", c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"abcde-fghjk", "abcdefghjk"},
+		{" ABCDE FGHJK ", "abcdefghjk"},
+		{"123456", ""},      // OTP
+		{"abcde-fghj0", ""}, // 0 is not in the alphabet
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeRecoveryCode(tt.in); got != tt.want {
+			t.Errorf("NormalizeRecoveryCode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if HashRecoveryCode("ABCDE-FGHJK") != HashRecoveryCode("abcdefghjk") {
+		t.Error("HashRecoveryCode should hash the normalized code")
+	}
+}
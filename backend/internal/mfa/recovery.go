@@ -0,0 +1,55 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// RecoveryCodeCount is how many recovery codes are issued at a time.
+const RecoveryCodeCount = 10
+
+// recoveryAlphabet omits characters that are easily confused (0/o, 1/l/i).
+const recoveryAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+
+const recoveryCodeLen = 10
+
+// GenerateRecoveryCodes returns RecoveryCodeCount random codes formatted "xxxxx-xxxxx" (about 49 bits each).
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	// Bytes at or above limit are rejected so every character is equally likely.
+	limit := byte(256 - 256%len(recoveryAlphabet))
+	b := make([]byte, 1)
+	for i := range codes {
+		s := make([]byte, 0, recoveryCodeLen)
+		for len(s) < recoveryCodeLen {
+			if _, err := rand.Read(b); err != nil {
+				return nil, err
+			}
+			if b[0] < limit {
+				s = append(s, recoveryAlphabet[int(b[0])%len(recoveryAlphabet)])
+			}
+		}
+		codes[i] = string(s[:5]) + "-" + string(s[5:])
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode lower-cases code and removes spaces and dashes. Returns "" when the result is not
+// shaped like a recovery code (so a 6-digit OTP is never mistaken for one).
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != recoveryCodeLen {
+		return ""
+	}
+	for i := 0; i < len(code); i++ {
+		if !strings.ContainsRune(recoveryAlphabet, rune(code[i])) {
+			return ""
+		}
+	}
+	return code
+}
+
+// HashRecoveryCode returns the hex SHA-256 of the normalized code, as stored in mfa_recovery_codes.
+func HashRecoveryCode(code string) string {
+	return HashOTP(NormalizeRecoveryCode(code))
+}
@@ -4,25 +4,37 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
 	"zero-trust-control-plane/backend/internal/mfa/domain"
+	"zero-trust-control-plane/backend/internal/pii"
 )
 
+// columnPhone is the encrypted phone column; the column name is authenticated with each value.
+const columnPhone = "mfa_challenges.phone"
+
 type PostgresRepository struct {
 	queries *gen.Queries
+	keyring *pii.Keyring
 }
 
-// NewPostgresRepository returns an MFA challenge repository that uses the given db.
-func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+// NewPostgresRepository returns an MFA challenge repository that uses the given db. Challenge phones are encrypted
+// with the data key of the challenge's org; with a nil keyring they are stored in plaintext.
+func NewPostgresRepository(db *sql.DB, keyring *pii.Keyring) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), keyring: keyring}
 }
 
 // Create persists the MFA challenge. The challenge must have ID set.
 func (r *PostgresRepository) Create(ctx context.Context, c *domain.Challenge) error {
-	_, err := r.queries.CreateMFAChallenge(ctx, gen.CreateMFAChallengeParams{
-		ID: c.ID, UserID: c.UserID, OrgID: c.OrgID, DeviceID: c.DeviceID,
-		Phone: c.Phone, CodeHash: c.CodeHash, ExpiresAt: c.ExpiresAt, CreatedAt: c.CreatedAt,
+	phone, err := r.keyring.Encrypt(ctx, c.OrgID, columnPhone, c.Phone)
+	if err != nil {
+		return err
+	}
+	_, err = r.queries.CreateMFAChallenge(ctx, gen.CreateMFAChallengeParams{
+		ID: c.ID, UserID: c.UserID, OrgID: c.OrgID, DeviceID: sql.NullString{String: c.DeviceID, Valid: c.DeviceID != ""},
+		Phone: phone, CodeHash: c.CodeHash, ExpiresAt: c.ExpiresAt, CreatedAt: c.CreatedAt, Method: c.Method,
+		TrustDays: int32(c.TrustDays),
 	})
 	return err
 }
@@ -36,9 +48,15 @@ func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Ch
 		}
 		return nil, err
 	}
+	phone, err := r.keyring.Decrypt(ctx, columnPhone, row.Phone)
+	if err != nil {
+		return nil, err
+	}
 	return &domain.Challenge{
-		ID: row.ID, UserID: row.UserID, OrgID: row.OrgID, DeviceID: row.DeviceID,
-		Phone: row.Phone, CodeHash: row.CodeHash, ExpiresAt: row.ExpiresAt, CreatedAt: row.CreatedAt,
+		ID: row.ID, UserID: row.UserID, OrgID: row.OrgID, DeviceID: row.DeviceID.String,
+		Phone: phone, CodeHash: row.CodeHash, ExpiresAt: row.ExpiresAt, CreatedAt: row.CreatedAt,
+		Method: row.Method, Attempts: int(row.Attempts), ResendCount: int(row.ResendCount), LastSentAt: row.LastSentAt.Time,
+		TrustDays: int(row.TrustDays),
 	}, nil
 }
 
@@ -46,3 +64,32 @@ func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Ch
 func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
 	return r.queries.DeleteMFAChallenge(ctx, id)
 }
+
+// DeleteByUser deletes all pending MFA challenges of the user.
+func (r *PostgresRepository) DeleteByUser(ctx context.Context, userID string) error {
+	return r.queries.DeleteMFAChallengesByUser(ctx, userID)
+}
+
+// IncrementAttempts counts a wrong code for the challenge and returns the new number of attempts.
+func (r *PostgresRepository) IncrementAttempts(ctx context.Context, id string) (int, error) {
+	n, err := r.queries.IncrementMFAChallengeAttempts(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// Resend replaces the challenge's code and expiry if it has still been resent resendCount times.
+func (r *PostgresRepository) Resend(ctx context.Context, id string, resendCount int, codeHash string, sentAt, expiresAt time.Time) (bool, error) {
+	n, err := r.queries.ResendMFAChallenge(ctx, gen.ResendMFAChallengeParams{
+		ID: id, CodeHash: codeHash, ExpiresAt: expiresAt,
+		LastSentAt: sql.NullTime{Time: sentAt, Valid: true}, ResendCount: int32(resendCount),
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -12,6 +12,13 @@ type Repository interface {
 	Create(ctx context.Context, c *domain.Challenge) error
 	GetByID(ctx context.Context, id string) (*domain.Challenge, error)
 	Delete(ctx context.Context, id string) error
+	// DeleteByUser deletes all of the user's pending challenges.
+	DeleteByUser(ctx context.Context, userID string) error
+	// IncrementAttempts counts a wrong code and returns the new number of attempts.
+	IncrementAttempts(ctx context.Context, id string) (int, error)
+	// Resend replaces the code of a challenge that has been resent resendCount times. It returns false, without
+	// changing anything, when another resend got there first.
+	Resend(ctx context.Context, id string, resendCount int, codeHash string, sentAt, expiresAt time.Time) (bool, error)
 }
 
 // DefaultChallengeTTL is the default MFA challenge expiry (e.g. 10 minutes).
@@ -0,0 +1,53 @@
+package sms
+
+import (
+	"errors"
+	"log"
+
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+)
+
+// FailoverSender sends through a primary Sender and, when that fails or its breaker is open, through a secondary
+// one (e.g. a second SMS Local account). Each provider has its own breaker, so while the primary is down sends go
+// straight to the secondary without waiting for the primary to time out. Safe for concurrent use if both senders are.
+type FailoverSender struct {
+	primary          Sender
+	primaryBreaker   *breaker.Breaker
+	secondary        Sender
+	secondaryBreaker *breaker.Breaker
+}
+
+// NewFailoverSender returns a FailoverSender. Either breaker may be nil (no breaker).
+func NewFailoverSender(primary Sender, primaryBreaker *breaker.Breaker, secondary Sender, secondaryBreaker *breaker.Breaker) *FailoverSender {
+	return &FailoverSender{
+		primary:          primary,
+		primaryBreaker:   primaryBreaker,
+		secondary:        secondary,
+		secondaryBreaker: secondaryBreaker,
+	}
+}
+
+// SendOTP sends otp through the primary provider, falling back to the secondary.
+func (f *FailoverSender) SendOTP(phone, otp string) error {
+	return f.send(func(s Sender) error { return s.SendOTP(phone, otp) })
+}
+
+// SendSMS sends message through the primary provider, falling back to the secondary.
+func (f *FailoverSender) SendSMS(phone, message string) error {
+	return f.send(func(s Sender) error { return s.SendSMS(phone, message) })
+}
+
+func (f *FailoverSender) send(fn func(Sender) error) error {
+	primaryErr := f.primaryBreaker.Do(func() error { return fn(f.primary) })
+	if primaryErr == nil {
+		return nil
+	}
+	if !errors.Is(primaryErr, breaker.ErrOpen) {
+		log.Printf("sms: primary provider failed, trying secondary: %v", primaryErr)
+	}
+	secondaryErr := f.secondaryBreaker.Do(func() error { return fn(f.secondary) })
+	if secondaryErr == nil {
+		return nil
+	}
+	return errors.Join(primaryErr, secondaryErr)
+}
@@ -0,0 +1,229 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+)
+
+// DefaultRetryInterval is how often RetrySender.Run retries queued messages, and the backoff after a message's
+// first failed send.
+const DefaultRetryInterval = 10 * time.Second
+
+// maxRetryBackoff caps the backoff between sends of one queued message.
+const maxRetryBackoff = 5 * time.Minute
+
+// ErrQueueFull is returned by RetrySender when a send fails and the retry queue has no room for the message.
+var ErrQueueFull = errors.New("sms: retry queue full")
+
+// Sender is the SMS provider used by RetrySender. *SMSLocalClient satisfies it.
+type Sender interface {
+	SendOTP(phone, otp string) error
+	SendSMS(phone, message string) error
+}
+
+// RetryOptions configures a RetrySender.
+type RetryOptions struct {
+	// QueueSize is how many messages wait for retry at most. Zero disables the queue: failed sends return their error.
+	QueueSize int
+	// MaxAge is how long a queued message is retried before it is dropped (e.g. the MFA challenge lifetime, after
+	// which the OTP is useless).
+	MaxAge time.Duration
+	// MaxAttempts is how many times a message is sent at most, the first send included, before it is dead-lettered.
+	// Zero retries until MaxAge.
+	MaxAttempts int
+	// Backoff is the wait after a message's first failed send; it doubles with each further failure, up to five
+	// minutes. Zero means DefaultRetryInterval.
+	Backoff time.Duration
+}
+
+type queuedMessage struct {
+	phone       string
+	text        string
+	otp         bool
+	queuedAt    time.Time
+	attempts    int
+	nextAttempt time.Time
+}
+
+// RetrySender sends through a Sender guarded by a circuit breaker. While the breaker is open, sends fail at once
+// instead of waiting for the provider to time out. A send that fails or is refused by the breaker is queued and
+// reported as sent; Run retries each queued message with exponential backoff until it is sent, it is older than
+// MaxAge or MaxAttempts sends failed, after which it is dead-lettered: logged with a masked phone number and counted
+// as dropped. Queued OTPs are held in memory only. Safe for concurrent use.
+type RetrySender struct {
+	next    Sender
+	breaker *breaker.Breaker
+	opts    RetryOptions
+	now     func() time.Time
+
+	mu      sync.Mutex
+	queue   []queuedMessage
+	dropped int64
+}
+
+// NewRetrySender returns a RetrySender sending through next. b may be nil (no breaker).
+func NewRetrySender(next Sender, b *breaker.Breaker, opts RetryOptions) *RetrySender {
+	return &RetrySender{next: next, breaker: b, opts: opts, now: time.Now}
+}
+
+// SendOTP sends otp through the provider's OTP route, queueing it for retry when the provider is unavailable.
+func (s *RetrySender) SendOTP(phone, otp string) error {
+	return s.send(queuedMessage{phone: phone, text: otp, otp: true})
+}
+
+// SendSMS sends a free-text message, queueing it for retry when the provider is unavailable.
+func (s *RetrySender) SendSMS(phone, message string) error {
+	return s.send(queuedMessage{phone: phone, text: message})
+}
+
+func (s *RetrySender) send(m queuedMessage) error {
+	err := s.breaker.Do(func() error { return s.deliver(m) })
+	if err == nil {
+		return nil
+	}
+	if s.opts.QueueSize <= 0 {
+		return err
+	}
+	m.queuedAt = s.now()
+	if !s.failed(&m, m.queuedAt) {
+		s.deadLetter([]queuedMessage{m}, "out of attempts")
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) >= s.opts.QueueSize {
+		return ErrQueueFull
+	}
+	s.queue = append(s.queue, m)
+	log.Printf("sms: send failed, queued for retry (%d queued): %v", len(s.queue), err)
+	return nil
+}
+
+func (s *RetrySender) deliver(m queuedMessage) error {
+	if m.otp {
+		return s.next.SendOTP(m.phone, m.text)
+	}
+	return s.next.SendSMS(m.phone, m.text)
+}
+
+// Queued returns how many messages wait for retry and how many were dead-lettered since the process started.
+func (s *RetrySender) Queued() (queued int, dropped int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue), s.dropped
+}
+
+// Retry sends the queued messages that are due, in order. A message that fails again waits twice as long before its
+// next send; messages older than MaxAge or out of attempts are dead-lettered. It stops when the breaker refuses a
+// call and keeps the rest queued.
+func (s *RetrySender) Retry() {
+	s.mu.Lock()
+	pending := s.queue
+	s.queue = nil
+	s.mu.Unlock()
+
+	now := s.now()
+	cutoff := now.Add(-s.opts.MaxAge)
+	kept := make([]queuedMessage, 0, len(pending))
+	var dead []queuedMessage
+	for i, m := range pending {
+		if s.opts.MaxAge > 0 && m.queuedAt.Before(cutoff) {
+			dead = append(dead, m)
+			continue
+		}
+		if now.Before(m.nextAttempt) {
+			kept = append(kept, m)
+			continue
+		}
+		err := s.breaker.Do(func() error { return s.deliver(m) })
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, breaker.ErrOpen) {
+			kept = append(kept, pending[i:]...)
+			break
+		}
+		if !s.failed(&m, now) {
+			dead = append(dead, m)
+			continue
+		}
+		kept = append(kept, m)
+	}
+	s.requeue(kept, dead)
+}
+
+// failed records a failed send of m at now and schedules its next one. It returns false when m is out of attempts.
+func (s *RetrySender) failed(m *queuedMessage, now time.Time) bool {
+	m.attempts++
+	if s.opts.MaxAttempts > 0 && m.attempts >= s.opts.MaxAttempts {
+		return false
+	}
+	backoff := s.opts.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryInterval
+	}
+	for i := 1; i < m.attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	m.nextAttempt = now.Add(backoff)
+	return true
+}
+
+// requeue puts kept back ahead of messages queued since Retry took the queue and dead-letters dead, along with any
+// messages beyond the queue size.
+func (s *RetrySender) requeue(kept, dead []queuedMessage) {
+	s.mu.Lock()
+	s.queue = append(kept, s.queue...)
+	if len(s.queue) > s.opts.QueueSize {
+		dead = append(dead, s.queue[s.opts.QueueSize:]...)
+		s.queue = s.queue[:s.opts.QueueSize]
+	}
+	s.mu.Unlock()
+	s.deadLetter(dead, "expired or out of attempts")
+}
+
+// deadLetter counts and logs messages dropped unsent. The OTP or message text is not logged.
+func (s *RetrySender) deadLetter(msgs []queuedMessage, reason string) {
+	if len(msgs) == 0 {
+		return
+	}
+	s.mu.Lock()
+	s.dropped += int64(len(msgs))
+	s.mu.Unlock()
+	for _, m := range msgs {
+		log.Printf("sms: dead-lettered message to %s after %d attempts (%s)", maskPhone(m.phone), m.attempts, reason)
+	}
+}
+
+// maskPhone keeps the last four digits of phone.
+func maskPhone(phone string) string {
+	if len(phone) <= 4 {
+		return "****"
+	}
+	return "****" + phone[len(phone)-4:]
+}
+
+// Run calls Retry every interval until ctx is done. Run it in a goroutine.
+func (s *RetrySender) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Retry()
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package sms
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+)
+
+type fakeSender struct {
+	err  error
+	sent []string
+}
+
+func (f *fakeSender) SendOTP(phone, otp string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, "otp:"+phone+":"+otp)
+	return nil
+}
+
+func (f *fakeSender) SendSMS(phone, message string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, "sms:"+phone+":"+message)
+	return nil
+}
+
+func TestRetrySender_QueuesWhileProviderDown(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeSender{err: errors.New("provider down")}
+	b := breaker.New("sms", breaker.Config{FailureThreshold: 1, OpenTimeout: time.Nanosecond})
+	s := NewRetrySender(provider, b, RetryOptions{QueueSize: 10, MaxAge: 10 * time.Minute})
+	s.now = func() time.Time { return now }
+
+	if err := s.SendOTP("15550001", "123456"); err != nil {
+		t.Fatalf("SendOTP with provider down should queue, got %v", err)
+	}
+	if b.State() != breaker.StateOpen {
+		t.Fatalf("breaker state = %s, want open", b.State())
+	}
+	if err := s.SendSMS("15550002", "new sign-in"); err != nil {
+		t.Fatalf("SendSMS with breaker open should queue, got %v", err)
+	}
+	s.Retry()
+	if queued, _ := s.Queued(); queued != 2 {
+		t.Fatalf("queued = %d after failed retry, want 2", queued)
+	}
+
+	provider.err = nil
+	now = now.Add(DefaultRetryInterval)
+	s.Retry()
+	if queued, dropped := s.Queued(); queued != 0 || dropped != 0 {
+		t.Errorf("queued, dropped = %d, %d after recovery; want 0, 0", queued, dropped)
+	}
+	want := []string{"otp:15550001:123456", "sms:15550002:new sign-in"}
+	if len(provider.sent) != 2 || provider.sent[0] != want[0] || provider.sent[1] != want[1] {
+		t.Errorf("sent = %v, want %v in order", provider.sent, want)
+	}
+}
+
+func TestRetrySender_DropsExpiredMessages(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeSender{err: errors.New("provider down")}
+	s := NewRetrySender(provider, nil, RetryOptions{QueueSize: 10, MaxAge: time.Minute})
+	s.now = func() time.Time { return now }
+
+	s.SendOTP("15550001", "111111")
+	now = now.Add(2 * time.Minute)
+	s.SendOTP("15550001", "222222")
+	provider.err = nil
+	now = now.Add(DefaultRetryInterval)
+	s.Retry()
+	if queued, dropped := s.Queued(); queued != 0 || dropped != 1 {
+		t.Errorf("queued, dropped = %d, %d; want 0, 1", queued, dropped)
+	}
+	if len(provider.sent) != 1 || provider.sent[0] != "otp:15550001:222222" {
+		t.Errorf("sent = %v, want only the fresh OTP", provider.sent)
+	}
+}
+
+func TestRetrySender_QueueLimits(t *testing.T) {
+	down := errors.New("provider down")
+	full := NewRetrySender(&fakeSender{err: down}, nil, RetryOptions{QueueSize: 1})
+	if err := full.SendOTP("1", "1"); err != nil {
+		t.Fatalf("first failed send should queue, got %v", err)
+	}
+	if err := full.SendOTP("2", "2"); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("send with full queue = %v, want ErrQueueFull", err)
+	}
+
+	noQueue := NewRetrySender(&fakeSender{err: down}, nil, RetryOptions{})
+	if err := noQueue.SendOTP("1", "1"); !errors.Is(err, down) {
+		t.Errorf("send without queue = %v, want the provider error", err)
+	}
+}
+
+func TestRetrySender_BackoffAndDeadLetter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeSender{err: errors.New("provider down")}
+	s := NewRetrySender(provider, nil, RetryOptions{QueueSize: 10, MaxAttempts: 3, Backoff: time.Second})
+	s.now = func() time.Time { return now }
+
+	s.SendOTP("15550001", "111111") // attempt 1; next send after 1s
+	now = now.Add(500 * time.Millisecond)
+	provider.err = nil
+	s.Retry()
+	if len(provider.sent) != 0 {
+		t.Fatalf("sent = %v before the backoff elapsed, want none", provider.sent)
+	}
+
+	provider.err = errors.New("provider down")
+	now = now.Add(time.Second)
+	s.Retry() // attempt 2; next send after 2s
+	now = now.Add(time.Second)
+	provider.err = nil
+	s.Retry()
+	if len(provider.sent) != 0 {
+		t.Fatalf("sent = %v before the doubled backoff elapsed, want none", provider.sent)
+	}
+
+	provider.err = errors.New("provider down")
+	now = now.Add(time.Second)
+	s.Retry() // attempt 3: dead-lettered
+	if queued, dropped := s.Queued(); queued != 0 || dropped != 1 {
+		t.Errorf("queued, dropped = %d, %d after the last attempt; want 0, 1", queued, dropped)
+	}
+}
+
+func TestFailoverSender(t *testing.T) {
+	primary := &fakeSender{err: errors.New("primary down")}
+	secondary := &fakeSender{}
+	pb := breaker.New("sms", breaker.Config{FailureThreshold: 1, OpenTimeout: time.Hour})
+	f := NewFailoverSender(primary, pb, secondary, nil)
+
+	if err := f.SendOTP("15550001", "123456"); err != nil {
+		t.Fatalf("SendOTP with primary down = %v, want sent through the secondary", err)
+	}
+	if pb.State() != breaker.StateOpen {
+		t.Errorf("primary breaker state = %s, want open", pb.State())
+	}
+	primary.err = nil
+	if err := f.SendSMS("15550001", "hello"); err != nil {
+		t.Fatalf("SendSMS: %v", err)
+	}
+	if len(primary.sent) != 0 || len(secondary.sent) != 2 {
+		t.Errorf("primary sent %v, secondary sent %v; want both through the secondary while the primary breaker is open", primary.sent, secondary.sent)
+	}
+
+	secondary.err = errors.New("secondary down")
+	if err := f.SendOTP("15550001", "654321"); !errors.Is(err, breaker.ErrOpen) || !errors.Is(err, secondary.err) {
+		t.Errorf("SendOTP with both down = %v, want both errors", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -14,6 +15,7 @@ const defaultTimeout = 15 * time.Second
 // SMSLocalClient sends OTP SMS via SMS Local API (PoC).
 // See https://www.smslocal.in/help/otp-sms/ and https://www.smslocal.com/dev/bulkV2.
 type SMSLocalClient struct {
+	mu         sync.RWMutex
 	APIKey     string
 	BaseURL    string
 	Sender     string
@@ -33,16 +35,43 @@ func NewSMSLocalClient(apiKey, baseURL, sender string) *SMSLocalClient {
 	}
 }
 
+// SetAPIKey replaces the API key used for subsequent requests (e.g. after the secret is rotated). Safe for
+// concurrent use with SendOTP and SendSMS.
+func (c *SMSLocalClient) SetAPIKey(apiKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.APIKey = apiKey
+}
+
+func (c *SMSLocalClient) apiKey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.APIKey
+}
+
 // SendOTP sends the OTP to the given phone number via SMS Local (route=otp).
 // phone should be digits only (e.g. country code + number). Does not log the OTP.
 func (c *SMSLocalClient) SendOTP(phone, otp string) error {
-	if c.APIKey == "" {
-		return fmt.Errorf("sms: API key not configured")
-	}
-	body := map[string]interface{}{
+	return c.send(map[string]interface{}{
 		"route":     "otp",
 		"numbers":   phone,
 		"variables": otp,
+	})
+}
+
+// SendSMS sends a free-text message (e.g. a new sign-in alert) to the given phone number via SMS Local (route=q).
+func (c *SMSLocalClient) SendSMS(phone, message string) error {
+	return c.send(map[string]interface{}{
+		"route":   "q",
+		"numbers": phone,
+		"message": message,
+	})
+}
+
+func (c *SMSLocalClient) send(body map[string]interface{}) error {
+	apiKey := c.apiKey()
+	if apiKey == "" {
+		return fmt.Errorf("sms: API key not configured")
 	}
 	raw, err := json.Marshal(body)
 	if err != nil {
@@ -53,7 +82,7 @@ func (c *SMSLocalClient) SendOTP(phone, otp string) error {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.APIKey)
+	req.Header.Set("Authorization", apiKey)
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return err
@@ -179,3 +179,28 @@ func TestSendOTP_RequestFormat(t *testing.T) {
 		t.Errorf("variables = %v, want 654321", receivedBody["variables"])
 	}
 }
+
+func TestSendSMS_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Decode body: %v", err)
+		}
+		if body["route"] != "q" {
+			t.Errorf("route = %v, want q", body["route"])
+		}
+		if body["numbers"] != "1234567890" {
+			t.Errorf("numbers = %v, want 1234567890", body["numbers"])
+		}
+		if body["message"] != "New sign-in" {
+			t.Errorf("message = %v, want %q", body["message"], "New sign-in")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewSMSLocalClient("test-api-key", server.URL, "")
+	if err := client.SendSMS("1234567890", "New sign-in"); err != nil {
+		t.Fatalf("SendSMS: %v", err)
+	}
+}
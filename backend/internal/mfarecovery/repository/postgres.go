@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an MFA recovery code repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db)}
+}
+
+// Replace swaps the user's codes for codeHashes in one transaction, so old codes stop working exactly when the
+// new ones are stored.
+func (r *PostgresRepository) Replace(ctx context.Context, userID string, codeHashes []string, createdAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	if err := q.DeleteMFARecoveryCodesByUser(ctx, userID); err != nil {
+		return err
+	}
+	for _, h := range codeHashes {
+		if err := q.CreateMFARecoveryCode(ctx, gen.CreateMFARecoveryCodeParams{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			CodeHash:  h,
+			CreatedAt: createdAt,
+		}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Consume marks the code used. Concurrent attempts with the same code succeed at most once.
+func (r *PostgresRepository) Consume(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error) {
+	n, err := r.queries.ConsumeMFARecoveryCode(ctx, gen.ConsumeMFARecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+		UsedAt:   sql.NullTime{Time: usedAt, Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// CountUnused returns the number of unused codes for the user.
+func (r *PostgresRepository) CountUnused(ctx context.Context, userID string) (int, error) {
+	n, err := r.queries.CountUnusedMFARecoveryCodes(ctx, userID)
+	return int(n), err
+}
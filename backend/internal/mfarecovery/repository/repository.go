@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines persistence for MFA recovery codes. Codes are stored and looked up by hash only.
+type Repository interface {
+	// Replace deletes all of the user's codes and stores codeHashes as the new, unused set.
+	Replace(ctx context.Context, userID string, codeHashes []string, createdAt time.Time) error
+	// Consume marks the user's unused code with codeHash as used. Returns false when there is no such code.
+	Consume(ctx context.Context, userID, codeHash string, usedAt time.Time) (bool, error)
+	// CountUnused returns how many of the user's codes have not been used.
+	CountUnused(ctx context.Context, userID string) (int, error)
+}
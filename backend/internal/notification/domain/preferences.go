@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Preferences holds a user's notification settings (one row per user; absent row means defaults).
+type Preferences struct {
+	UserID            string
+	LoginAlertsOptOut bool
+	// Locale is the user's language for notifications (e.g. "de", "pt-BR"); empty uses the org's default template.
+	Locale    string
+	UpdatedAt time.Time
+}
+
+// KnownLoginContextKind identifies what a known login context value represents.
+type KnownLoginContextKind string
+
+const (
+	// KnownLoginContextDevice values are device IDs.
+	KnownLoginContextDevice KnownLoginContextKind = "device"
+	// KnownLoginContextLocation values are coarse locations (e.g. "Berlin, DE") from the geo locator.
+	KnownLoginContextLocation KnownLoginContextKind = "location"
+)
+
+// KnownLoginContext is a device or location a user has signed in from.
+type KnownLoginContext struct {
+	UserID      string
+	Kind        KnownLoginContextKind
+	Value       string
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
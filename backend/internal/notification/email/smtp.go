@@ -0,0 +1,74 @@
+// Package email provides an SMTP implementation of notification.EmailSender.
+package email
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// SMTPSender sends plain-text email through an SMTP relay (STARTTLS is negotiated by net/smtp when offered).
+type SMTPSender struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+
+	// sendMail is smtp.SendMail; overridden in tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPSender returns a sender for host:port. PLAIN auth is used when username is non-empty.
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	if port <= 0 {
+		port = 587
+	}
+	return &SMTPSender{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// SendEmail sends a plain-text message to a single recipient.
+func (s *SMTPSender) SendEmail(to, subject, body string) error {
+	if s.Host == "" {
+		return fmt.Errorf("email: SMTP host not configured")
+	}
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("email: invalid from address: %w", err)
+	}
+	rcpt, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("email: invalid recipient: %w", err)
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	return s.sendMail(addr, auth, from.Address, []string{rcpt.Address}, buildMessage(from.String(), rcpt.String(), subject, body))
+}
+
+// buildMessage renders RFC 5322 headers and body. CR/LF are stripped from the subject to prevent header injection.
+func buildMessage(from, to, subject, body string) []byte {
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
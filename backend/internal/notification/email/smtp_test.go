@@ -0,0 +1,58 @@
+package email
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+)
+
+func TestSMTPSender_SendEmail(t *testing.T) {
+	s := NewSMTPSender("smtp.example.com", 0, "user", "pass", "ZTCP <no-reply@example.com>")
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	s.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		if a == nil {
+			t.Error("auth should be set when username is configured")
+		}
+		return nil
+	}
+	if err := s.SendEmail("alice@example.com", "Hello\r\nBcc: evil@example.com", "line1\nline2"); err != nil {
+		t.Fatalf("SendEmail: %v", err)
+	}
+	if gotAddr != "smtp.example.com:587" {
+		t.Errorf("addr = %q, want smtp.example.com:587", gotAddr)
+	}
+	if gotFrom != "no-reply@example.com" {
+		t.Errorf("from = %q", gotFrom)
+	}
+	if len(gotTo) != 1 || gotTo[0] != "alice@example.com" {
+		t.Errorf("to = %v", gotTo)
+	}
+	msg := string(gotMsg)
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Error("subject CR/LF should not inject headers")
+	}
+	if !strings.Contains(msg, "line1\r\nline2") {
+		t.Errorf("body not normalized to CRLF: %q", msg)
+	}
+}
+
+func TestSMTPSender_InvalidRecipient(t *testing.T) {
+	s := NewSMTPSender("smtp.example.com", 25, "", "", "no-reply@example.com")
+	s.sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+		t.Fatal("sendMail should not be called")
+		return nil
+	}
+	if err := s.SendEmail("not-an-email", "s", "b"); err == nil {
+		t.Fatal("expected error for invalid recipient")
+	}
+}
+
+func TestSMTPSender_HostNotConfigured(t *testing.T) {
+	s := NewSMTPSender("", 25, "", "", "no-reply@example.com")
+	if err := s.SendEmail("alice@example.com", "s", "b"); err == nil {
+		t.Fatal("expected error when host is empty")
+	}
+}
@@ -0,0 +1,453 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	notificationv1 "zero-trust-control-plane/backend/api/generated/notification/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/billing"
+	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	"zero-trust-control-plane/backend/internal/notification/domain"
+	"zero-trust-control-plane/backend/internal/notification/repository"
+	"zero-trust-control-plane/backend/internal/notiftemplate"
+	notiftemplatedomain "zero-trust-control-plane/backend/internal/notiftemplate/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	orgpolicyconfigrepo "zero-trust-control-plane/backend/internal/orgpolicyconfig/repository"
+	"zero-trust-control-plane/backend/internal/orgsmtp"
+	orgsmtpdomain "zero-trust-control-plane/backend/internal/orgsmtp/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// OrgSMTP manages the SMTP servers of orgs. *orgsmtp.Router implements it.
+type OrgSMTP interface {
+	Settings(ctx context.Context, orgID string) (*orgsmtpdomain.Settings, error)
+	Update(ctx context.Context, s *orgsmtpdomain.Settings) (*orgsmtpdomain.Settings, error)
+	Delete(ctx context.Context, orgID string) (bool, error)
+	SendTest(ctx context.Context, orgID, to string) error
+}
+
+// NotificationTemplates manages orgs' notification templates. *notiftemplate.Templates implements it.
+type NotificationTemplates interface {
+	List(ctx context.Context, orgID string) ([]*notiftemplatedomain.Template, error)
+	Update(ctx context.Context, t *notiftemplatedomain.Template) (*notiftemplatedomain.Template, error)
+	Delete(ctx context.Context, orgID string, kind notiftemplatedomain.Kind, locale string) (bool, error)
+	Preview(ctx context.Context, orgID string, kind notiftemplatedomain.Kind, locale, subject, body string) (notiftemplatedomain.Message, *notiftemplatedomain.Template, error)
+}
+
+// UserGetter resolves the caller's email address for SendTestEmail.
+type UserGetter interface {
+	GetByID(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+// Server implements NotificationService. The preference RPCs act on the authenticated caller's own preferences; the
+// org SMTP and template RPCs on the caller's org, for owners and admins.
+// Proto: notification/notification.proto → internal/notification/handler.
+type Server struct {
+	notificationv1.UnimplementedNotificationServiceServer
+	repo           repository.Repository
+	orgPolicyRepo  orgpolicyconfigrepo.Repository
+	smtp           OrgSMTP
+	templates      NotificationTemplates
+	membershipRepo membershiprepo.Repository
+	users          UserGetter
+	auditLogger    audit.AuditLogger
+	plans          *billing.Gate
+}
+
+// NewServer returns a new Notification gRPC server. repo may be nil; then the preference RPCs return Unimplemented.
+// orgPolicyRepo may be nil; then org defaults apply (alerts on, opt-out allowed). If smtp, membershipRepo or users is
+// nil, the org SMTP RPCs return Unimplemented; if templates or membershipRepo is nil, the template RPCs do.
+// auditLogger may be nil. If plans is non-nil, UpdateOrgSMTPSettings requires a plan with custom SMTP.
+func NewServer(repo repository.Repository, orgPolicyRepo orgpolicyconfigrepo.Repository, smtp OrgSMTP, templates NotificationTemplates, membershipRepo membershiprepo.Repository, users UserGetter, auditLogger audit.AuditLogger, plans *billing.Gate) *Server {
+	return &Server{repo: repo, orgPolicyRepo: orgPolicyRepo, smtp: smtp, templates: templates, membershipRepo: membershipRepo, users: users, auditLogger: auditLogger, plans: plans}
+}
+
+// GetNotificationPreferences returns the caller's effective notification preferences.
+func (s *Server) GetNotificationPreferences(ctx context.Context, req *notificationv1.GetNotificationPreferencesRequest) (*notificationv1.GetNotificationPreferencesResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+	}
+	userID, orgID, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enforced, err := s.loginAlertsEnforced(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := s.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load notification preferences")
+	}
+	optOut := prefs != nil && prefs.LoginAlertsOptOut
+	locale := ""
+	if prefs != nil {
+		locale = prefs.Locale
+	}
+	return &notificationv1.GetNotificationPreferencesResponse{
+		Preferences: toProto(optOut, enforced, locale),
+	}, nil
+}
+
+// UpdateNotificationPreferences updates the caller's preferences. Disabling login alerts is rejected with
+// FailedPrecondition when the caller's org enforces them. An unset locale keeps the current one; an invalid one is
+// rejected with InvalidArgument.
+func (s *Server) UpdateNotificationPreferences(ctx context.Context, req *notificationv1.UpdateNotificationPreferencesRequest) (*notificationv1.UpdateNotificationPreferencesResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
+	}
+	userID, orgID, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enforced, err := s.loginAlertsEnforced(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	optOut := !req.GetLoginAlertsEnabled()
+	if optOut && enforced {
+		return nil, status.Error(codes.FailedPrecondition, "login alerts are required by your organization")
+	}
+	var locale string
+	if req.Locale != nil {
+		if locale, err = notiftemplate.NormalizeLocale(req.GetLocale()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "locale must be a language tag such as de or pt-BR")
+		}
+	} else {
+		prefs, err := s.repo.GetPreferences(ctx, userID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to load notification preferences")
+		}
+		if prefs != nil {
+			locale = prefs.Locale
+		}
+	}
+	if err := s.repo.UpsertPreferences(ctx, &domain.Preferences{
+		UserID:            userID,
+		LoginAlertsOptOut: optOut,
+		Locale:            locale,
+		UpdatedAt:         time.Now().UTC(),
+	}); err != nil {
+		return nil, status.Error(codes.Internal, "failed to save notification preferences")
+	}
+	return &notificationv1.UpdateNotificationPreferencesResponse{
+		Preferences: toProto(optOut, enforced, locale),
+	}, nil
+}
+
+// GetOrgSMTPSettings returns the caller's org's SMTP settings; settings is unset when the org uses the platform SMTP
+// server. Caller must be org owner or admin.
+func (s *Server) GetOrgSMTPSettings(ctx context.Context, req *notificationv1.GetOrgSMTPSettingsRequest) (*notificationv1.GetOrgSMTPSettingsResponse, error) {
+	if !s.smtpEnabled() {
+		return nil, status.Error(codes.Unimplemented, "method GetOrgSMTPSettings not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.smtp.Settings(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load SMTP settings")
+	}
+	return &notificationv1.GetOrgSMTPSettingsResponse{Settings: smtpSettingsToProto(settings)}, nil
+}
+
+// UpdateOrgSMTPSettings replaces the caller's org's SMTP settings, so its users' emails are sent through the org's
+// server. Caller must be org owner or admin. The host must resolve to public addresses and password_secret, when set,
+// must be under the org's secret prefix.
+func (s *Server) UpdateOrgSMTPSettings(ctx context.Context, req *notificationv1.UpdateOrgSMTPSettingsRequest) (*notificationv1.UpdateOrgSMTPSettingsResponse, error) {
+	if !s.smtpEnabled() {
+		return nil, status.Error(codes.Unimplemented, "method UpdateOrgSMTPSettings not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.plans.Require(ctx, orgID, billing.FeatureCustomSMTP); err != nil {
+		return nil, err
+	}
+	settings, err := s.smtp.Update(ctx, &orgsmtpdomain.Settings{
+		OrgID:          orgID,
+		Host:           req.GetHost(),
+		Port:           int(req.GetPort()),
+		Username:       req.GetUsername(),
+		PasswordSecret: req.GetPasswordSecret(),
+		From:           req.GetFromAddress(),
+		UpdatedBy:      userID,
+	})
+	if err != nil {
+		if st := smtpErr(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Error(codes.Internal, "failed to save SMTP settings")
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]interface{}{
+			"host":         settings.Host,
+			"port":         settings.Port,
+			"from_address": settings.From,
+			"password":     settings.PasswordSecret != "",
+		})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "org_smtp_settings_updated", "notification", string(meta))
+	}
+	return &notificationv1.UpdateOrgSMTPSettingsResponse{Settings: smtpSettingsToProto(settings)}, nil
+}
+
+// DeleteOrgSMTPSettings removes the caller's org's SMTP settings, returning it to the platform SMTP server. Caller
+// must be org owner or admin. Returns NotFound when the org has no settings.
+func (s *Server) DeleteOrgSMTPSettings(ctx context.Context, req *notificationv1.DeleteOrgSMTPSettingsRequest) (*notificationv1.DeleteOrgSMTPSettingsResponse, error) {
+	if !s.smtpEnabled() {
+		return nil, status.Error(codes.Unimplemented, "method DeleteOrgSMTPSettings not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.smtp.Delete(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete SMTP settings")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "organization has no SMTP settings")
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "org_smtp_settings_deleted", "notification", "")
+	}
+	return &notificationv1.DeleteOrgSMTPSettingsResponse{}, nil
+}
+
+// SendTestEmail sends a test email to the caller's own address through the org's SMTP server. Caller must be org
+// owner or admin. A failed delivery returns FailedPrecondition with the server's error.
+func (s *Server) SendTestEmail(ctx context.Context, req *notificationv1.SendTestEmailRequest) (*notificationv1.SendTestEmailResponse, error) {
+	if !s.smtpEnabled() {
+		return nil, status.Error(codes.Unimplemented, "method SendTestEmail not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up user")
+	}
+	if user == nil || user.Email == "" {
+		return nil, status.Error(codes.FailedPrecondition, "caller has no email address")
+	}
+	if err := s.smtp.SendTest(ctx, orgID, user.Email); err != nil {
+		if st := smtpErr(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "test email failed: %v", err)
+	}
+	return &notificationv1.SendTestEmailResponse{To: user.Email}, nil
+}
+
+// ListNotificationTemplates returns the caller's org's templates and the kinds of notification that have templates,
+// with their variables and built-in texts. Caller must be org owner or admin.
+func (s *Server) ListNotificationTemplates(ctx context.Context, req *notificationv1.ListNotificationTemplatesRequest) (*notificationv1.ListNotificationTemplatesResponse, error) {
+	if s.templates == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListNotificationTemplates not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.templates.List(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list notification templates")
+	}
+	resp := &notificationv1.ListNotificationTemplatesResponse{}
+	for _, t := range list {
+		resp.Templates = append(resp.Templates, templateToProto(t))
+	}
+	for _, spec := range notiftemplate.Kinds() {
+		resp.Kinds = append(resp.Kinds, &notificationv1.NotificationTemplateKind{
+			Kind:              string(spec.Kind),
+			Email:             spec.Email,
+			Variables:         spec.Variables,
+			RequiredVariables: spec.Required,
+			BuiltinSubject:    spec.Builtin.Subject,
+			BuiltinBody:       spec.Builtin.Body,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateNotificationTemplate creates or replaces the caller's org's template for a kind and locale. Caller must be
+// org owner or admin. Unknown kinds, invalid locales and templates with unknown or missing variables are rejected with
+// InvalidArgument.
+func (s *Server) UpdateNotificationTemplate(ctx context.Context, req *notificationv1.UpdateNotificationTemplateRequest) (*notificationv1.UpdateNotificationTemplateResponse, error) {
+	if s.templates == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method UpdateNotificationTemplate not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.templates.Update(ctx, &notiftemplatedomain.Template{
+		OrgID:     orgID,
+		Kind:      notiftemplatedomain.Kind(req.GetKind()),
+		Locale:    req.GetLocale(),
+		Subject:   req.GetSubject(),
+		Body:      req.GetBody(),
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		if st := templateErr(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Error(codes.Internal, "failed to save notification template")
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"kind": string(t.Kind), "locale": t.Locale})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "notification_template_updated", "notification", string(meta))
+	}
+	return &notificationv1.UpdateNotificationTemplateResponse{Template: templateToProto(t)}, nil
+}
+
+// DeleteNotificationTemplate removes the caller's org's template for a kind and locale. Caller must be org owner or
+// admin. Returns NotFound when there is no such template.
+func (s *Server) DeleteNotificationTemplate(ctx context.Context, req *notificationv1.DeleteNotificationTemplateRequest) (*notificationv1.DeleteNotificationTemplateResponse, error) {
+	if s.templates == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method DeleteNotificationTemplate not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	kind := notiftemplatedomain.Kind(req.GetKind())
+	ok, err := s.templates.Delete(ctx, orgID, kind, req.GetLocale())
+	if err != nil {
+		if st := templateErr(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Error(codes.Internal, "failed to delete notification template")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "notification template not found")
+	}
+	if s.auditLogger != nil {
+		locale, _ := notiftemplate.NormalizeLocale(req.GetLocale())
+		meta, _ := json.Marshal(map[string]string{"kind": string(kind), "locale": locale})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "notification_template_deleted", "notification", string(meta))
+	}
+	return &notificationv1.DeleteNotificationTemplateResponse{}, nil
+}
+
+// PreviewNotificationTemplate renders a template with sample values: the request's subject and body when body is set,
+// otherwise the template a user with the request's locale gets. Caller must be org owner or admin. Nothing is sent.
+func (s *Server) PreviewNotificationTemplate(ctx context.Context, req *notificationv1.PreviewNotificationTemplateRequest) (*notificationv1.PreviewNotificationTemplateResponse, error) {
+	if s.templates == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method PreviewNotificationTemplate not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	msg, t, err := s.templates.Preview(ctx, orgID, notiftemplatedomain.Kind(req.GetKind()), req.GetLocale(), req.GetSubject(), req.GetBody())
+	if err != nil {
+		if st := templateErr(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Error(codes.Internal, "failed to render notification template")
+	}
+	resp := &notificationv1.PreviewNotificationTemplateResponse{Subject: msg.Subject, Body: msg.Body, Builtin: t == nil}
+	if t != nil {
+		resp.TemplateLocale = t.Locale
+	}
+	return resp, nil
+}
+
+// templateErr maps notiftemplate errors to gRPC status errors. It returns nil for other errors.
+func templateErr(err error) error {
+	switch {
+	case errors.Is(err, notiftemplate.ErrUnknownKind), errors.Is(err, notiftemplate.ErrInvalidLocale),
+		errors.Is(err, notiftemplate.ErrInvalidTemplate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil
+	}
+}
+
+func templateToProto(t *notiftemplatedomain.Template) *notificationv1.NotificationTemplate {
+	return &notificationv1.NotificationTemplate{
+		Kind:      string(t.Kind),
+		Locale:    t.Locale,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		UpdatedBy: t.UpdatedBy,
+		UpdatedAt: timestamppb.New(t.UpdatedAt),
+	}
+}
+
+func (s *Server) smtpEnabled() bool {
+	return s.smtp != nil && s.membershipRepo != nil && s.users != nil
+}
+
+// smtpErr maps orgsmtp errors to gRPC status errors. It returns nil for other errors.
+func smtpErr(err error) error {
+	switch {
+	case errors.Is(err, orgsmtp.ErrInvalidHost), errors.Is(err, orgsmtp.ErrInvalidPort), errors.Is(err, orgsmtp.ErrInvalidFrom),
+		errors.Is(err, orgsmtp.ErrSecretNotAllowed):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, orgsmtp.ErrSecretsUnavailable), errors.Is(err, orgsmtp.ErrNotConfigured):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, orgsmtp.ErrSecretUnreadable):
+		return status.Error(codes.FailedPrecondition, orgsmtp.ErrSecretUnreadable.Error())
+	default:
+		return nil
+	}
+}
+
+func smtpSettingsToProto(s *orgsmtpdomain.Settings) *notificationv1.OrgSMTPSettings {
+	if s == nil {
+		return nil
+	}
+	return &notificationv1.OrgSMTPSettings{
+		Host:           s.Host,
+		Port:           int32(s.Port),
+		Username:       s.Username,
+		PasswordSecret: s.PasswordSecret,
+		FromAddress:    s.From,
+		UpdatedBy:      s.UpdatedBy,
+		UpdatedAt:      timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func callerIdentity(ctx context.Context) (userID, orgID string, err error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return "", "", status.Error(codes.Unauthenticated, "user context required")
+	}
+	orgID, _ = interceptors.GetOrgID(ctx)
+	return userID, orgID, nil
+}
+
+func (s *Server) loginAlertsEnforced(ctx context.Context, orgID string) (bool, error) {
+	var cfg *orgpolicyconfigdomain.OrgPolicyConfig
+	if s.orgPolicyRepo != nil && orgID != "" {
+		var err error
+		if cfg, err = s.orgPolicyRepo.GetByOrgID(ctx, orgID); err != nil {
+			return false, status.Error(codes.Internal, "failed to load org policy")
+		}
+	}
+	n := orgpolicyconfigdomain.MergeWithDefaults(cfg).Notifications
+	return n.NewLoginAlerts && n.EnforceNewLoginAlerts, nil
+}
+
+func toProto(optOut, enforced bool, locale string) *notificationv1.NotificationPreferences {
+	return &notificationv1.NotificationPreferences{
+		LoginAlertsEnabled:       enforced || !optOut,
+		LoginAlertsEnforcedByOrg: enforced,
+		Locale:                   locale,
+	}
+}
@@ -0,0 +1,484 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	notificationv1 "zero-trust-control-plane/backend/api/generated/notification/v1"
+	"zero-trust-control-plane/backend/internal/billing"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/notification/domain"
+	"zero-trust-control-plane/backend/internal/notiftemplate"
+	notiftemplatedomain "zero-trust-control-plane/backend/internal/notiftemplate/domain"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/orgsmtp"
+	orgsmtpdomain "zero-trust-control-plane/backend/internal/orgsmtp/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+type mockNotificationRepo struct {
+	prefs map[string]*domain.Preferences
+}
+
+func (m *mockNotificationRepo) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	return m.prefs[userID], nil
+}
+
+func (m *mockNotificationRepo) UpsertPreferences(ctx context.Context, p *domain.Preferences) error {
+	m.prefs[p.UserID] = p
+	return nil
+}
+
+func (m *mockNotificationRepo) CountKnownLoginContexts(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockNotificationRepo) GetLatestKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (*domain.KnownLoginContext, error) {
+	return nil, nil
+}
+
+func (m *mockNotificationRepo) TouchKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind, value string, at time.Time) (bool, error) {
+	return false, nil
+}
+
+type mockOrgPolicyRepo struct {
+	cfg *orgpolicyconfigdomain.OrgPolicyConfig
+}
+
+func (m *mockOrgPolicyRepo) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	return m.cfg, nil
+}
+
+func (m *mockOrgPolicyRepo) Upsert(ctx context.Context, orgID string, cfg *orgpolicyconfigdomain.OrgPolicyConfig, change orgpolicyconfigdomain.Change) error {
+	m.cfg = cfg
+	return nil
+}
+
+func (m *mockOrgPolicyRepo) GetVersioned(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, int64, error) {
+	return m.cfg, 1, nil
+}
+
+func (m *mockOrgPolicyRepo) UpdateIfVersion(ctx context.Context, orgID string, cfg *orgpolicyconfigdomain.OrgPolicyConfig, version int64, change orgpolicyconfigdomain.Change) (int64, bool, error) {
+	m.cfg = cfg
+	return version + 1, true, nil
+}
+
+func (m *mockOrgPolicyRepo) ListVersions(ctx context.Context, orgID string, limit, offset int32) ([]*orgpolicyconfigdomain.ConfigVersion, error) {
+	return nil, nil
+}
+
+func (m *mockOrgPolicyRepo) GetVersion(ctx context.Context, orgID string, version int64) (*orgpolicyconfigdomain.ConfigVersion, error) {
+	return nil, nil
+}
+
+func (m *mockOrgPolicyRepo) CreateScheduledChange(ctx context.Context, c *orgpolicyconfigdomain.ScheduledChange) error {
+	return nil
+}
+
+func (m *mockOrgPolicyRepo) GetScheduledChange(ctx context.Context, id string) (*orgpolicyconfigdomain.ScheduledChange, error) {
+	return nil, nil
+}
+
+func (m *mockOrgPolicyRepo) ListScheduledChanges(ctx context.Context, orgID string, status orgpolicyconfigdomain.ScheduledStatus, limit, offset int32) ([]*orgpolicyconfigdomain.ScheduledChange, error) {
+	return nil, nil
+}
+
+func (m *mockOrgPolicyRepo) ListDueScheduledChanges(ctx context.Context, now time.Time, limit int32) ([]*orgpolicyconfigdomain.ScheduledChange, error) {
+	return nil, nil
+}
+
+func (m *mockOrgPolicyRepo) TransitionScheduledChange(ctx context.Context, id string, from, to orgpolicyconfigdomain.ScheduledStatus, res orgpolicyconfigdomain.Resolution) (bool, error) {
+	return false, nil
+}
+
+func ctxWithUser() context.Context {
+	return interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+}
+
+func TestGetNotificationPreferences_Defaults(t *testing.T) {
+	srv := NewServer(&mockNotificationRepo{prefs: map[string]*domain.Preferences{}}, nil, nil, nil, nil, nil, nil, nil)
+	resp, err := srv.GetNotificationPreferences(ctxWithUser(), &notificationv1.GetNotificationPreferencesRequest{})
+	if err != nil {
+		t.Fatalf("GetNotificationPreferences: %v", err)
+	}
+	if !resp.Preferences.LoginAlertsEnabled || resp.Preferences.LoginAlertsEnforcedByOrg {
+		t.Errorf("preferences = %+v, want enabled and not enforced", resp.Preferences)
+	}
+}
+
+func TestUpdateNotificationPreferences_OptOut(t *testing.T) {
+	repo := &mockNotificationRepo{prefs: map[string]*domain.Preferences{}}
+	srv := NewServer(repo, nil, nil, nil, nil, nil, nil, nil)
+	resp, err := srv.UpdateNotificationPreferences(ctxWithUser(), &notificationv1.UpdateNotificationPreferencesRequest{LoginAlertsEnabled: false})
+	if err != nil {
+		t.Fatalf("UpdateNotificationPreferences: %v", err)
+	}
+	if resp.Preferences.LoginAlertsEnabled {
+		t.Error("login alerts should be disabled")
+	}
+	if p := repo.prefs["user-1"]; p == nil || !p.LoginAlertsOptOut {
+		t.Errorf("stored prefs = %+v, want opt-out", p)
+	}
+}
+
+func TestUpdateNotificationPreferences_Locale(t *testing.T) {
+	repo := &mockNotificationRepo{prefs: map[string]*domain.Preferences{}}
+	srv := NewServer(repo, nil, nil, nil, nil, nil, nil, nil)
+	ctx := ctxWithUser()
+	locale := "pt_br"
+	resp, err := srv.UpdateNotificationPreferences(ctx, &notificationv1.UpdateNotificationPreferencesRequest{LoginAlertsEnabled: true, Locale: &locale})
+	if err != nil || resp.Preferences.GetLocale() != "pt-BR" {
+		t.Fatalf("UpdateNotificationPreferences = %v, %v; want locale pt-BR", resp, err)
+	}
+	if _, err := srv.UpdateNotificationPreferences(ctx, &notificationv1.UpdateNotificationPreferencesRequest{LoginAlertsEnabled: false}); err != nil {
+		t.Fatalf("UpdateNotificationPreferences without locale: %v", err)
+	}
+	if p := repo.prefs["user-1"]; p.Locale != "pt-BR" || !p.LoginAlertsOptOut {
+		t.Errorf("stored prefs = %+v, want the locale kept", p)
+	}
+	invalid := "portuguese"
+	if _, err := srv.UpdateNotificationPreferences(ctx, &notificationv1.UpdateNotificationPreferencesRequest{Locale: &invalid}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid locale = %v, want InvalidArgument", err)
+	}
+}
+
+func TestUpdateNotificationPreferences_EnforcedByOrg(t *testing.T) {
+	org := &mockOrgPolicyRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		Notifications: &orgpolicyconfigdomain.Notifications{NewLoginAlerts: true, EnforceNewLoginAlerts: true},
+	}}
+	srv := NewServer(&mockNotificationRepo{prefs: map[string]*domain.Preferences{}}, org, nil, nil, nil, nil, nil, nil)
+	_, err := srv.UpdateNotificationPreferences(ctxWithUser(), &notificationv1.UpdateNotificationPreferencesRequest{LoginAlertsEnabled: false})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("code = %v, want FailedPrecondition", status.Code(err))
+	}
+}
+
+func TestNotificationPreferences_Unauthenticated(t *testing.T) {
+	srv := NewServer(&mockNotificationRepo{prefs: map[string]*domain.Preferences{}}, nil, nil, nil, nil, nil, nil, nil)
+	_, err := srv.GetNotificationPreferences(context.Background(), &notificationv1.GetNotificationPreferencesRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+func TestNotificationPreferences_NilRepo(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := srv.GetNotificationPreferences(ctxWithUser(), &notificationv1.GetNotificationPreferencesRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+type mockMembershipRepo struct {
+	roles map[string]membershipdomain.Role // key: userID:orgID
+}
+
+func (m *mockMembershipRepo) GetMembershipByID(ctx context.Context, id string) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	role, ok := m.roles[userID+":"+orgID]
+	if !ok {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{ID: "m-" + userID, UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+func (m *mockMembershipRepo) ListMembershipsByOrg(ctx context.Context, orgID string) ([]*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) CreateMembership(ctx context.Context, mem *membershipdomain.Membership) error {
+	return nil
+}
+
+func (m *mockMembershipRepo) DeleteByUserAndOrg(ctx context.Context, userID, orgID string) error {
+	return nil
+}
+
+func (m *mockMembershipRepo) UpdateRole(ctx context.Context, userID, orgID string, role membershipdomain.Role) (*membershipdomain.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockMembershipRepo) CountOwnersByOrg(ctx context.Context, orgID string) (int64, error) {
+	return 0, nil
+}
+
+type mockUsers map[string]*userdomain.User
+
+func (m mockUsers) GetByID(ctx context.Context, id string) (*userdomain.User, error) {
+	return m[id], nil
+}
+
+// fakeOrgSMTP implements OrgSMTP in memory.
+type fakeOrgSMTP struct {
+	settings  map[string]*orgsmtpdomain.Settings
+	updateErr error
+	sendErr   error
+	sentTo    []string
+}
+
+func (f *fakeOrgSMTP) Settings(ctx context.Context, orgID string) (*orgsmtpdomain.Settings, error) {
+	return f.settings[orgID], nil
+}
+
+func (f *fakeOrgSMTP) Update(ctx context.Context, s *orgsmtpdomain.Settings) (*orgsmtpdomain.Settings, error) {
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	c := *s
+	if c.Port == 0 {
+		c.Port = orgsmtp.DefaultPort
+	}
+	c.UpdatedAt = time.Now().UTC()
+	f.settings[s.OrgID] = &c
+	return &c, nil
+}
+
+func (f *fakeOrgSMTP) Delete(ctx context.Context, orgID string) (bool, error) {
+	_, ok := f.settings[orgID]
+	delete(f.settings, orgID)
+	return ok, nil
+}
+
+func (f *fakeOrgSMTP) SendTest(ctx context.Context, orgID, to string) error {
+	if f.settings[orgID] == nil {
+		return orgsmtp.ErrNotConfigured
+	}
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sentTo = append(f.sentTo, to)
+	return nil
+}
+
+type mockAuditLogger struct {
+	actions []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+}
+
+func newSMTPTestServer() (*Server, *fakeOrgSMTP, *mockAuditLogger) {
+	smtp := &fakeOrgSMTP{settings: map[string]*orgsmtpdomain.Settings{}}
+	auditLogger := &mockAuditLogger{}
+	members := &mockMembershipRepo{roles: map[string]membershipdomain.Role{
+		"admin-1:org-1":  membershipdomain.RoleAdmin,
+		"member-1:org-1": membershipdomain.RoleMember,
+	}}
+	users := mockUsers{"admin-1": {ID: "admin-1", Email: "admin@acme.example"}}
+	return NewServer(nil, nil, smtp, nil, members, users, auditLogger, nil), smtp, auditLogger
+}
+
+func TestOrgSMTPSettings(t *testing.T) {
+	srv, smtp, auditLogger := newSMTPTestServer()
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+
+	resp, err := srv.GetOrgSMTPSettings(admin, &notificationv1.GetOrgSMTPSettingsRequest{})
+	if err != nil || resp.GetSettings() != nil {
+		t.Fatalf("GetOrgSMTPSettings = %v, %v; want no settings", resp, err)
+	}
+	if _, err := srv.SendTestEmail(admin, &notificationv1.SendTestEmailRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SendTestEmail without settings = %v, want FailedPrecondition", err)
+	}
+
+	updated, err := srv.UpdateOrgSMTPSettings(admin, &notificationv1.UpdateOrgSMTPSettingsRequest{
+		Host:           "smtp.acme.example",
+		Username:       "mailer",
+		PasswordSecret: "secret/data/orgs/org-1/smtp#password",
+		FromAddress:    "no-reply@acme.example",
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgSMTPSettings: %v", err)
+	}
+	if s := updated.GetSettings(); s.GetHost() != "smtp.acme.example" || s.GetPort() != 587 || s.GetUpdatedBy() != "admin-1" {
+		t.Errorf("settings = %+v", s)
+	}
+	if smtp.settings["org-1"] == nil || smtp.settings["org-1"].OrgID != "org-1" {
+		t.Errorf("stored = %+v, want settings of the caller's org", smtp.settings)
+	}
+
+	sent, err := srv.SendTestEmail(admin, &notificationv1.SendTestEmailRequest{})
+	if err != nil || sent.GetTo() != "admin@acme.example" || len(smtp.sentTo) != 1 {
+		t.Errorf("SendTestEmail = %v, %v; sent to %v", sent, err, smtp.sentTo)
+	}
+	smtp.sendErr = errors.New("535 authentication failed")
+	if _, err := srv.SendTestEmail(admin, &notificationv1.SendTestEmailRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("failed delivery = %v, want FailedPrecondition", err)
+	}
+
+	if _, err := srv.DeleteOrgSMTPSettings(admin, &notificationv1.DeleteOrgSMTPSettingsRequest{}); err != nil {
+		t.Fatalf("DeleteOrgSMTPSettings: %v", err)
+	}
+	if _, err := srv.DeleteOrgSMTPSettings(admin, &notificationv1.DeleteOrgSMTPSettingsRequest{}); status.Code(err) != codes.NotFound {
+		t.Errorf("second DeleteOrgSMTPSettings = %v, want NotFound", err)
+	}
+	if len(auditLogger.actions) != 2 || auditLogger.actions[0] != "org_smtp_settings_updated" || auditLogger.actions[1] != "org_smtp_settings_deleted" {
+		t.Errorf("audit actions = %v", auditLogger.actions)
+	}
+}
+
+func TestOrgSMTPSettings_Errors(t *testing.T) {
+	srv, smtp, _ := newSMTPTestServer()
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-2")
+
+	if _, err := srv.UpdateOrgSMTPSettings(member, &notificationv1.UpdateOrgSMTPSettingsRequest{Host: "smtp.acme.example"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member Update = %v, want PermissionDenied", err)
+	}
+	if _, err := srv.SendTestEmail(member, &notificationv1.SendTestEmailRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member SendTestEmail = %v, want PermissionDenied", err)
+	}
+	for err, want := range map[error]codes.Code{
+		orgsmtp.ErrInvalidHost:        codes.InvalidArgument,
+		orgsmtp.ErrSecretNotAllowed:   codes.InvalidArgument,
+		orgsmtp.ErrSecretsUnavailable: codes.FailedPrecondition,
+		errors.New("db down"):         codes.Internal,
+	} {
+		smtp.updateErr = err
+		if _, got := srv.UpdateOrgSMTPSettings(admin, &notificationv1.UpdateOrgSMTPSettingsRequest{Host: "10.0.0.1"}); status.Code(got) != want {
+			t.Errorf("Update with %v = %v, want %v", err, got, want)
+		}
+	}
+
+	nilSrv := NewServer(nil, nil, nil, nil, nil, nil, nil, nil)
+	if _, err := nilSrv.GetOrgSMTPSettings(admin, &notificationv1.GetOrgSMTPSettingsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil smtp = %v, want Unimplemented", err)
+	}
+	if _, err := nilSrv.SendTestEmail(admin, &notificationv1.SendTestEmailRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil smtp SendTestEmail = %v, want Unimplemented", err)
+	}
+}
+
+type planOrgs map[string]*organizationdomain.Org
+
+func (o planOrgs) GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error) {
+	return o[id], nil
+}
+
+func (o planOrgs) UpdateOrganization(ctx context.Context, org *organizationdomain.Org) error {
+	o[org.ID] = org
+	return nil
+}
+
+func TestUpdateOrgSMTPSettings_PlanRequired(t *testing.T) {
+	srv, smtp, _ := newSMTPTestServer()
+	orgs := planOrgs{"org-1": {ID: "org-1", Plan: organizationdomain.PlanFree}}
+	srv.plans = billing.NewGate(orgs, nil, nil)
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	req := &notificationv1.UpdateOrgSMTPSettingsRequest{Host: "smtp.acme.example", FromAddress: "no-reply@acme.example"}
+
+	if _, err := srv.UpdateOrgSMTPSettings(admin, req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("free plan = %v, want PermissionDenied", err)
+	}
+	if len(smtp.settings) != 0 {
+		t.Errorf("stored %v on the free plan", smtp.settings)
+	}
+	orgs["org-1"].Plan = organizationdomain.PlanTeam
+	if _, err := srv.UpdateOrgSMTPSettings(admin, req); err != nil {
+		t.Errorf("team plan: %v", err)
+	}
+}
+
+type memTemplateRepo map[string]*notiftemplatedomain.Template
+
+func (m memTemplateRepo) Get(ctx context.Context, orgID string, kind notiftemplatedomain.Kind, locale string) (*notiftemplatedomain.Template, error) {
+	return m[orgID+"|"+string(kind)+"|"+locale], nil
+}
+
+func (m memTemplateRepo) List(ctx context.Context, orgID string) ([]*notiftemplatedomain.Template, error) {
+	var out []*notiftemplatedomain.Template
+	for _, t := range m {
+		if t.OrgID == orgID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m memTemplateRepo) Upsert(ctx context.Context, t *notiftemplatedomain.Template) error {
+	c := *t
+	m[t.OrgID+"|"+string(t.Kind)+"|"+t.Locale] = &c
+	return nil
+}
+
+func (m memTemplateRepo) Delete(ctx context.Context, orgID string, kind notiftemplatedomain.Kind, locale string) (bool, error) {
+	k := orgID + "|" + string(kind) + "|" + locale
+	_, ok := m[k]
+	delete(m, k)
+	return ok, nil
+}
+
+func TestNotificationTemplates(t *testing.T) {
+	repo := memTemplateRepo{}
+	auditLogger := &mockAuditLogger{}
+	members := &mockMembershipRepo{roles: map[string]membershipdomain.Role{
+		"admin-1:org-1":  membershipdomain.RoleAdmin,
+		"member-1:org-1": membershipdomain.RoleMember,
+	}}
+	srv := NewServer(nil, nil, nil, notiftemplate.New(repo, nil), members, nil, auditLogger, nil)
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-2")
+
+	list, err := srv.ListNotificationTemplates(admin, &notificationv1.ListNotificationTemplatesRequest{})
+	if err != nil || len(list.GetTemplates()) != 0 || len(list.GetKinds()) != 2 || list.GetKinds()[0].GetBuiltinSubject() == "" {
+		t.Fatalf("ListNotificationTemplates = %v, %v", list, err)
+	}
+	preview, err := srv.PreviewNotificationTemplate(admin, &notificationv1.PreviewNotificationTemplateRequest{Kind: "otp_sms", Locale: "de"})
+	if err != nil || !preview.GetBuiltin() || preview.GetBody() != "Your verification code is 123456. It expires in 5 minutes." {
+		t.Errorf("built-in preview = %v, %v", preview, err)
+	}
+	preview, err = srv.PreviewNotificationTemplate(admin, &notificationv1.PreviewNotificationTemplateRequest{Kind: "otp_sms", Body: "Code {{code}}"})
+	if err != nil || preview.GetBuiltin() || preview.GetBody() != "Code 123456" {
+		t.Errorf("draft preview = %v, %v", preview, err)
+	}
+	if _, err := srv.PreviewNotificationTemplate(admin, &notificationv1.PreviewNotificationTemplateRequest{Kind: "otp_sms", Body: "Code {{otp}}"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid draft preview = %v, want InvalidArgument", err)
+	}
+
+	updated, err := srv.UpdateNotificationTemplate(admin, &notificationv1.UpdateNotificationTemplateRequest{Kind: "otp_sms", Locale: "DE", Body: "Ihr Code: {{code}}"})
+	if err != nil || updated.GetTemplate().GetLocale() != "de" || updated.GetTemplate().GetUpdatedBy() != "admin-1" {
+		t.Fatalf("UpdateNotificationTemplate = %v, %v", updated, err)
+	}
+	preview, err = srv.PreviewNotificationTemplate(admin, &notificationv1.PreviewNotificationTemplateRequest{Kind: "otp_sms", Locale: "de-AT"})
+	if err != nil || preview.GetBuiltin() || preview.GetTemplateLocale() != "de" || preview.GetBody() != "Ihr Code: 123456" {
+		t.Errorf("preview for de-AT = %v, %v; want the de template", preview, err)
+	}
+	for _, req := range []*notificationv1.UpdateNotificationTemplateRequest{
+		{Kind: "password_reset", Body: "x"},
+		{Kind: "otp_sms", Locale: "german", Body: "{{code}}"},
+		{Kind: "otp_sms", Body: "no code"},
+		{Kind: "login_alert", Body: "{{device}} {{time}}"},
+	} {
+		if _, err := srv.UpdateNotificationTemplate(admin, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Update(%+v) = %v, want InvalidArgument", req, err)
+		}
+	}
+	if _, err := srv.UpdateNotificationTemplate(member, &notificationv1.UpdateNotificationTemplateRequest{Kind: "otp_sms", Body: "{{code}}"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member Update = %v, want PermissionDenied", err)
+	}
+
+	if _, err := srv.DeleteNotificationTemplate(admin, &notificationv1.DeleteNotificationTemplateRequest{Kind: "otp_sms", Locale: "de"}); err != nil {
+		t.Fatalf("DeleteNotificationTemplate: %v", err)
+	}
+	if _, err := srv.DeleteNotificationTemplate(admin, &notificationv1.DeleteNotificationTemplateRequest{Kind: "otp_sms", Locale: "de"}); status.Code(err) != codes.NotFound {
+		t.Errorf("second Delete = %v, want NotFound", err)
+	}
+	if len(auditLogger.actions) != 2 || auditLogger.actions[0] != "notification_template_updated" || auditLogger.actions[1] != "notification_template_deleted" {
+		t.Errorf("audit actions = %v", auditLogger.actions)
+	}
+
+	nilSrv := NewServer(nil, nil, nil, nil, members, nil, nil, nil)
+	if _, err := nilSrv.ListNotificationTemplates(admin, &notificationv1.ListNotificationTemplatesRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil templates = %v, want Unimplemented", err)
+	}
+}
@@ -0,0 +1,240 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/notification/domain"
+	"zero-trust-control-plane/backend/internal/notification/repository"
+	notiftemplatedomain "zero-trust-control-plane/backend/internal/notiftemplate/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/useragent"
+	"zero-trust-control-plane/backend/internal/securityevent"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+// ImpossibleTravelWindow is how soon after a sign-in from one country a sign-in from another country is
+// reported as impossible travel. Locations are coarse (no coordinates), so only country changes are compared.
+const ImpossibleTravelWindow = 2 * time.Hour
+
+// OrgPolicyConfigReader returns the org policy config (nil when the org has none; defaults apply).
+type OrgPolicyConfigReader interface {
+	GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error)
+}
+
+// TemplateRenderer renders a notification with the org's template for the user's locale, or the built-in text when the
+// org has none. *notiftemplate.Templates implements it.
+type TemplateRenderer interface {
+	Render(ctx context.Context, orgID, userID string, kind notiftemplatedomain.Kind, vars map[string]string) (notiftemplatedomain.Message, bool)
+}
+
+// LoginEvent describes a completed sign-in (session issued).
+type LoginEvent struct {
+	UserID    string
+	OrgID     string
+	DeviceID  string
+	Email     string
+	Phone     string
+	IP        string
+	UserAgent string
+	At        time.Time
+}
+
+// LoginNotifier records the devices and locations each user signs in from and alerts the user when a
+// sign-in comes from one not seen before. The very first sign-in for a user is recorded but not alerted.
+type LoginNotifier struct {
+	repo      repository.Repository
+	orgPolicy OrgPolicyConfigReader
+	email     EmailSender
+	sms       SMSSender
+	geo       GeoLocator
+	events    securityevent.Recorder
+	templates TemplateRenderer
+}
+
+// NewLoginNotifier returns a LoginNotifier. orgPolicy, email, sms, geo, and events may be nil: without orgPolicy the
+// org defaults apply, without geo only new devices are detected, a nil sender disables that channel, and
+// without events impossible travel is not reported.
+func NewLoginNotifier(repo repository.Repository, orgPolicy OrgPolicyConfigReader, email EmailSender, sms SMSSender, geo GeoLocator, events securityevent.Recorder) *LoginNotifier {
+	return &LoginNotifier{repo: repo, orgPolicy: orgPolicy, email: email, sms: sms, geo: geo, events: events}
+}
+
+// SetTemplates makes alerts use the org's login_alert template for the user's locale. Without templates the built-in
+// text is sent. Call before the notifier is used.
+func (n *LoginNotifier) SetTemplates(t TemplateRenderer) {
+	n.templates = t
+}
+
+// NotifyLogin records ev's device and location and sends a new sign-in alert when either is new and alerts are
+// enabled for the user. Best-effort: failures are logged and never affect the sign-in.
+func (n *LoginNotifier) NotifyLogin(ctx context.Context, ev LoginEvent) {
+	if n == nil || n.repo == nil || ev.UserID == "" {
+		return
+	}
+	if ev.At.IsZero() {
+		ev.At = time.Now().UTC()
+	}
+	newDevice := false
+	if ev.DeviceID != "" {
+		newDevice = n.observe(ctx, ev, domain.KnownLoginContextDevice, ev.DeviceID)
+	}
+	location := ""
+	newLocation := false
+	if n.geo != nil {
+		if location = n.geo.Locate(ctx, ev.IP); location != "" {
+			n.checkImpossibleTravel(ctx, ev, location)
+			newLocation = n.observe(ctx, ev, domain.KnownLoginContextLocation, location)
+		}
+	}
+	if !newDevice && !newLocation {
+		return
+	}
+	enabled, err := n.alertsEnabled(ctx, ev.UserID, ev.OrgID)
+	if err != nil {
+		log.Printf("notification: user_id=%s failed to resolve login alert settings: %v", ev.UserID, err)
+		return
+	}
+	if !enabled {
+		return
+	}
+	n.send(ctx, ev, n.message(ctx, ev, location))
+}
+
+// message returns the alert for ev, from the org's template when there is one.
+func (n *LoginNotifier) message(ctx context.Context, ev LoginEvent, location string) notiftemplatedomain.Message {
+	if n.templates == nil {
+		return notiftemplatedomain.Message{Subject: LoginAlertSubject, Body: LoginAlertMessage(ev, location)}
+	}
+	msg, _ := n.templates.Render(ctx, ev.OrgID, ev.UserID, notiftemplatedomain.KindLoginAlert, LoginAlertVariables(ev, location))
+	return msg
+}
+
+// observe records value for the user and reports whether it is new. A value is not "new" when it is the first
+// of its kind for the user (first-ever sign-in), so new accounts do not get an alert on their first login.
+func (n *LoginNotifier) observe(ctx context.Context, ev LoginEvent, kind domain.KnownLoginContextKind, value string) bool {
+	known, err := n.repo.CountKnownLoginContexts(ctx, ev.UserID, kind)
+	if err != nil {
+		log.Printf("notification: user_id=%s failed to count known %s: %v", ev.UserID, kind, err)
+		return false
+	}
+	isNew, err := n.repo.TouchKnownLoginContext(ctx, ev.UserID, kind, value, ev.At)
+	if err != nil {
+		log.Printf("notification: user_id=%s failed to record %s: %v", ev.UserID, kind, err)
+		return false
+	}
+	return isNew && known > 0
+}
+
+// checkImpossibleTravel records an impossible_travel security event when the user's previous sign-in was from a
+// different country less than ImpossibleTravelWindow ago. Must run before the current location is recorded.
+func (n *LoginNotifier) checkImpossibleTravel(ctx context.Context, ev LoginEvent, location string) {
+	if n.events == nil {
+		return
+	}
+	prev, err := n.repo.GetLatestKnownLoginContext(ctx, ev.UserID, domain.KnownLoginContextLocation)
+	if err != nil || prev == nil {
+		return
+	}
+	if country(prev.Value) == country(location) || ev.At.Sub(prev.LastSeenAt) >= ImpossibleTravelWindow {
+		return
+	}
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"from":            prev.Value,
+		"to":              location,
+		"minutes_between": int(ev.At.Sub(prev.LastSeenAt).Minutes()),
+	})
+	n.events.Record(ctx, ev.OrgID, ev.UserID, securityeventdomain.EventImpossibleTravel, string(metadata))
+}
+
+// country returns the country part of a "City, CC" location (or the whole value when there is no city).
+func country(location string) string {
+	if i := strings.LastIndex(location, ", "); i >= 0 {
+		return location[i+2:]
+	}
+	return location
+}
+
+// alertsEnabled returns true when the org has new-login alerts on and the user has not opted out
+// (or the org enforces alerts, which overrides the user's opt-out).
+func (n *LoginNotifier) alertsEnabled(ctx context.Context, userID, orgID string) (bool, error) {
+	settings, err := n.orgSettings(ctx, orgID)
+	if err != nil {
+		return false, err
+	}
+	if !settings.NewLoginAlerts {
+		return false, nil
+	}
+	if settings.EnforceNewLoginAlerts {
+		return true, nil
+	}
+	prefs, err := n.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return prefs == nil || !prefs.LoginAlertsOptOut, nil
+}
+
+func (n *LoginNotifier) orgSettings(ctx context.Context, orgID string) (*orgpolicyconfigdomain.Notifications, error) {
+	var cfg *orgpolicyconfigdomain.OrgPolicyConfig
+	if n.orgPolicy != nil && orgID != "" {
+		var err error
+		if cfg, err = n.orgPolicy.GetByOrgID(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+	return orgpolicyconfigdomain.MergeWithDefaults(cfg).Notifications, nil
+}
+
+// send delivers the alert by email when possible and falls back to SMS.
+func (n *LoginNotifier) send(ctx context.Context, ev LoginEvent, msg notiftemplatedomain.Message) {
+	if n.email != nil && ev.Email != "" {
+		err := SendOrgEmail(ctx, n.email, ev.OrgID, ev.Email, msg.Subject, msg.Body)
+		if err == nil {
+			return
+		}
+		log.Printf("notification: user_id=%s failed to send login alert email: %v", ev.UserID, err)
+	}
+	if n.sms != nil && ev.Phone != "" {
+		if err := n.sms.SendSMS(ev.Phone, msg.Body); err != nil {
+			log.Printf("notification: user_id=%s failed to send login alert sms: %v", ev.UserID, err)
+		}
+	}
+}
+
+// LoginAlertSubject is the email subject for new sign-in alerts.
+const LoginAlertSubject = "New sign-in to your account"
+
+// LoginAlertMessage returns the alert text, e.g. "New sign-in from Chrome on Windows in Berlin, DE ...".
+// location may be empty (then the IP is shown instead).
+func LoginAlertMessage(ev LoginEvent, location string) string {
+	v := LoginAlertVariables(ev, location)
+	return fmt.Sprintf("New sign-in from %s%s at %s. If this wasn't you, sign out of all sessions and change your password.",
+		v["device"], v["where"], v["time"])
+}
+
+// LoginAlertVariables returns the login_alert template variables for ev: device, time, location and ip (each may be
+// empty), and where, the English " in <location>" or " from IP <ip>" of the built-in text.
+func LoginAlertVariables(ev LoginEvent, location string) map[string]string {
+	ip := ev.IP
+	if ip == "unknown" {
+		ip = ""
+	}
+	where := ""
+	switch {
+	case location != "":
+		where = " in " + location
+	case ip != "":
+		where = " from IP " + ip
+	}
+	return map[string]string{
+		"device":   useragent.Describe(ev.UserAgent),
+		"time":     ev.At.UTC().Format("2006-01-02 15:04 MST"),
+		"location": location,
+		"ip":       ip,
+		"where":    where,
+	}
+}
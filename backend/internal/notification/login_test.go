@@ -0,0 +1,284 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"zero-trust-control-plane/backend/internal/notification/domain"
+	notiftemplatedomain "zero-trust-control-plane/backend/internal/notiftemplate/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+type memRepo struct {
+	prefs  map[string]*domain.Preferences
+	known  map[string]bool // user|kind|value
+	latest map[string]*domain.KnownLoginContext
+	err    error
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{prefs: map[string]*domain.Preferences{}, known: map[string]bool{}, latest: map[string]*domain.KnownLoginContext{}}
+}
+
+func (m *memRepo) GetLatestKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (*domain.KnownLoginContext, error) {
+	return m.latest[userID+"|"+string(kind)], m.err
+}
+
+func (m *memRepo) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	return m.prefs[userID], m.err
+}
+
+func (m *memRepo) UpsertPreferences(ctx context.Context, p *domain.Preferences) error {
+	m.prefs[p.UserID] = p
+	return m.err
+}
+
+func (m *memRepo) CountKnownLoginContexts(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (int64, error) {
+	var n int64
+	prefix := userID + "|" + string(kind) + "|"
+	for k := range m.known {
+		if strings.HasPrefix(k, prefix) {
+			n++
+		}
+	}
+	return n, m.err
+}
+
+func (m *memRepo) TouchKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind, value string, at time.Time) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	m.latest[userID+"|"+string(kind)] = &domain.KnownLoginContext{UserID: userID, Kind: kind, Value: value, LastSeenAt: at}
+	k := userID + "|" + string(kind) + "|" + value
+	if m.known[k] {
+		return false, nil
+	}
+	m.known[k] = true
+	return true, nil
+}
+
+type memOrgPolicy struct {
+	cfg *orgpolicyconfigdomain.OrgPolicyConfig
+}
+
+func (m *memOrgPolicy) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	return m.cfg, nil
+}
+
+type memEmail struct {
+	sent []string
+	err  error
+}
+
+func (m *memEmail) SendEmail(to, subject, body string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, to+": "+body)
+	return nil
+}
+
+type memSMS struct {
+	sent []string
+}
+
+func (m *memSMS) SendSMS(phone, message string) error {
+	m.sent = append(m.sent, phone+": "+message)
+	return nil
+}
+
+type fixedGeo string
+
+func (g fixedGeo) Locate(ctx context.Context, ip string) string { return string(g) }
+
+func loginEvent(deviceID string) LoginEvent {
+	return LoginEvent{
+		UserID:    "user-1",
+		OrgID:     "org-1",
+		DeviceID:  deviceID,
+		Email:     "user@example.com",
+		Phone:     "+15550001111",
+		IP:        "203.0.113.7",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0 Safari/537.36",
+	}
+}
+
+func TestNotifyLogin_FirstLoginNotAlerted(t *testing.T) {
+	email := &memEmail{}
+	n := NewLoginNotifier(newMemRepo(), nil, email, nil, nil, nil)
+	n.NotifyLogin(context.Background(), loginEvent("dev-1"))
+	if len(email.sent) != 0 {
+		t.Errorf("first login should not alert, sent %v", email.sent)
+	}
+}
+
+func TestNotifyLogin_NewDeviceAlerted(t *testing.T) {
+	email := &memEmail{}
+	n := NewLoginNotifier(newMemRepo(), nil, email, nil, nil, nil)
+	ctx := context.Background()
+	n.NotifyLogin(ctx, loginEvent("dev-1"))
+	n.NotifyLogin(ctx, loginEvent("dev-1"))
+	if len(email.sent) != 0 {
+		t.Fatalf("known device should not alert, sent %v", email.sent)
+	}
+	n.NotifyLogin(ctx, loginEvent("dev-2"))
+	if len(email.sent) != 1 {
+		t.Fatalf("new device should alert once, sent %d", len(email.sent))
+	}
+	if !strings.Contains(email.sent[0], "Chrome on Windows") {
+		t.Errorf("alert should describe the client, got %q", email.sent[0])
+	}
+}
+
+type fakeTemplates struct {
+	vars map[string]string
+}
+
+func (f *fakeTemplates) Render(ctx context.Context, orgID, userID string, kind notiftemplatedomain.Kind, vars map[string]string) (notiftemplatedomain.Message, bool) {
+	f.vars = vars
+	return notiftemplatedomain.Message{Subject: "Neue Anmeldung", Body: "Neue Anmeldung: " + vars["device"]}, true
+}
+
+func TestNotifyLogin_Template(t *testing.T) {
+	email := &memEmail{}
+	templates := &fakeTemplates{}
+	n := NewLoginNotifier(newMemRepo(), nil, email, nil, nil, nil)
+	n.SetTemplates(templates)
+	ctx := context.Background()
+	n.NotifyLogin(ctx, loginEvent("dev-1"))
+	n.NotifyLogin(ctx, loginEvent("dev-2"))
+	if len(email.sent) != 1 || email.sent[0] != "user@example.com: Neue Anmeldung: Chrome on Windows" {
+		t.Fatalf("sent %v, want the template's text", email.sent)
+	}
+	if templates.vars["ip"] != "203.0.113.7" || templates.vars["where"] != " from IP 203.0.113.7" || templates.vars["time"] == "" {
+		t.Errorf("vars = %v", templates.vars)
+	}
+}
+
+func TestNotifyLogin_NewLocationAlerted(t *testing.T) {
+	email := &memEmail{}
+	repo := newMemRepo()
+	ctx := context.Background()
+	NewLoginNotifier(repo, nil, email, nil, fixedGeo("Paris, FR"), nil).NotifyLogin(ctx, loginEvent("dev-1"))
+	NewLoginNotifier(repo, nil, email, nil, fixedGeo("Berlin, DE"), nil).NotifyLogin(ctx, loginEvent("dev-1"))
+	if len(email.sent) != 1 {
+		t.Fatalf("new location should alert once, sent %d", len(email.sent))
+	}
+	if !strings.Contains(email.sent[0], "in Berlin, DE") {
+		t.Errorf("alert should include location, got %q", email.sent[0])
+	}
+}
+
+func TestNotifyLogin_UserOptOut(t *testing.T) {
+	email := &memEmail{}
+	repo := newMemRepo()
+	repo.prefs["user-1"] = &domain.Preferences{UserID: "user-1", LoginAlertsOptOut: true}
+	n := NewLoginNotifier(repo, nil, email, nil, nil, nil)
+	ctx := context.Background()
+	n.NotifyLogin(ctx, loginEvent("dev-1"))
+	n.NotifyLogin(ctx, loginEvent("dev-2"))
+	if len(email.sent) != 0 {
+		t.Errorf("opted-out user should not be alerted, sent %v", email.sent)
+	}
+}
+
+func TestNotifyLogin_OrgEnforcedOverridesOptOut(t *testing.T) {
+	email := &memEmail{}
+	repo := newMemRepo()
+	repo.prefs["user-1"] = &domain.Preferences{UserID: "user-1", LoginAlertsOptOut: true}
+	org := &memOrgPolicy{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		Notifications: &orgpolicyconfigdomain.Notifications{NewLoginAlerts: true, EnforceNewLoginAlerts: true},
+	}}
+	n := NewLoginNotifier(repo, org, email, nil, nil, nil)
+	ctx := context.Background()
+	n.NotifyLogin(ctx, loginEvent("dev-1"))
+	n.NotifyLogin(ctx, loginEvent("dev-2"))
+	if len(email.sent) != 1 {
+		t.Errorf("org-enforced alerts should ignore opt-out, sent %d", len(email.sent))
+	}
+}
+
+func TestNotifyLogin_OrgDisabled(t *testing.T) {
+	email := &memEmail{}
+	org := &memOrgPolicy{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		Notifications: &orgpolicyconfigdomain.Notifications{NewLoginAlerts: false},
+	}}
+	n := NewLoginNotifier(newMemRepo(), org, email, nil, nil, nil)
+	ctx := context.Background()
+	n.NotifyLogin(ctx, loginEvent("dev-1"))
+	n.NotifyLogin(ctx, loginEvent("dev-2"))
+	if len(email.sent) != 0 {
+		t.Errorf("org with alerts disabled should not alert, sent %v", email.sent)
+	}
+}
+
+func TestNotifyLogin_FallsBackToSMS(t *testing.T) {
+	email := &memEmail{err: errors.New("smtp down")}
+	sms := &memSMS{}
+	n := NewLoginNotifier(newMemRepo(), nil, email, sms, nil, nil)
+	ctx := context.Background()
+	n.NotifyLogin(ctx, loginEvent("dev-1"))
+	n.NotifyLogin(ctx, loginEvent("dev-2"))
+	if len(sms.sent) != 1 || !strings.HasPrefix(sms.sent[0], "+15550001111: ") {
+		t.Errorf("expected SMS fallback, sent %v", sms.sent)
+	}
+}
+
+func TestNotifyLogin_RepoErrorIsBestEffort(t *testing.T) {
+	email := &memEmail{}
+	repo := newMemRepo()
+	repo.err = errors.New("db down")
+	NewLoginNotifier(repo, nil, email, nil, nil, nil).NotifyLogin(context.Background(), loginEvent("dev-1"))
+	if len(email.sent) != 0 {
+		t.Errorf("repo error should suppress alert, sent %v", email.sent)
+	}
+}
+
+func TestHeaderGeoLocator(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("cf-ipcity", "Berlin", "cf-ipcountry", "DE"))
+	if got := (HeaderGeoLocator{}).Locate(ctx, ""); got != "Berlin, DE" {
+		t.Errorf("Locate = %q, want %q", got, "Berlin, DE")
+	}
+	if got := (HeaderGeoLocator{}).Locate(context.Background(), ""); got != "" {
+		t.Errorf("Locate without metadata = %q, want empty", got)
+	}
+}
+
+type memRecorder struct {
+	types []securityeventdomain.EventType
+}
+
+func (m *memRecorder) Record(ctx context.Context, orgID, userID string, eventType securityeventdomain.EventType, metadata string) {
+	m.types = append(m.types, eventType)
+}
+
+func TestNotifyLogin_ImpossibleTravel(t *testing.T) {
+	repo := newMemRepo()
+	events := &memRecorder{}
+	ctx := context.Background()
+	start := time.Now().UTC()
+	ev := loginEvent("dev-1")
+	ev.At = start
+	NewLoginNotifier(repo, nil, nil, nil, fixedGeo("Paris, FR"), events).NotifyLogin(ctx, ev)
+	ev.At = start.Add(20 * time.Minute)
+	NewLoginNotifier(repo, nil, nil, nil, fixedGeo("Lyon, FR"), events).NotifyLogin(ctx, ev)
+	if len(events.types) != 0 {
+		t.Fatalf("same-country sign-in should not be impossible travel, got %v", events.types)
+	}
+	ev.At = start.Add(40 * time.Minute)
+	NewLoginNotifier(repo, nil, nil, nil, fixedGeo("Tokyo, JP"), events).NotifyLogin(ctx, ev)
+	if len(events.types) != 1 || events.types[0] != securityeventdomain.EventImpossibleTravel {
+		t.Fatalf("events = %v, want one impossible_travel", events.types)
+	}
+	ev.At = start.Add(40*time.Minute + ImpossibleTravelWindow)
+	NewLoginNotifier(repo, nil, nil, nil, fixedGeo("Berlin, DE"), events).NotifyLogin(ctx, ev)
+	if len(events.types) != 1 {
+		t.Errorf("sign-in after the window should not be impossible travel, got %v", events.types)
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/notification/domain"
+)
+
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a notification repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// GetPreferences returns the user's preferences, or nil if not found.
+func (r *PostgresRepository) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	row, err := r.queries.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.Preferences{
+		UserID:            row.UserID,
+		LoginAlertsOptOut: row.LoginAlertsOptOut,
+		Locale:            row.Locale,
+		UpdatedAt:         row.UpdatedAt,
+	}, nil
+}
+
+// UpsertPreferences creates or updates the user's preferences.
+func (r *PostgresRepository) UpsertPreferences(ctx context.Context, p *domain.Preferences) error {
+	updatedAt := p.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now().UTC()
+	}
+	_, err := r.queries.UpsertNotificationPreferences(ctx, gen.UpsertNotificationPreferencesParams{
+		UserID:            p.UserID,
+		LoginAlertsOptOut: p.LoginAlertsOptOut,
+		UpdatedAt:         updatedAt,
+		Locale:            p.Locale,
+	})
+	return err
+}
+
+// CountKnownLoginContexts returns how many distinct values of kind the user has signed in from.
+func (r *PostgresRepository) CountKnownLoginContexts(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (int64, error) {
+	return r.queries.CountKnownLoginContexts(ctx, gen.CountKnownLoginContextsParams{
+		UserID: userID,
+		Kind:   string(kind),
+	})
+}
+
+// GetLatestKnownLoginContext returns the most recently used value of kind for the user, or nil if none.
+func (r *PostgresRepository) GetLatestKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (*domain.KnownLoginContext, error) {
+	row, err := r.queries.GetLatestKnownLoginContext(ctx, gen.GetLatestKnownLoginContextParams{
+		UserID: userID,
+		Kind:   string(kind),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.KnownLoginContext{
+		UserID:      row.UserID,
+		Kind:        domain.KnownLoginContextKind(row.Kind),
+		Value:       row.Value,
+		FirstSeenAt: row.FirstSeenAt,
+		LastSeenAt:  row.LastSeenAt,
+	}, nil
+}
+
+// TouchKnownLoginContext upserts the (user, kind, value) row. The row is new when its first_seen_at equals at.
+func (r *PostgresRepository) TouchKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind, value string, at time.Time) (bool, error) {
+	// TIMESTAMPTZ stores microseconds; truncate so the round-tripped value compares equal.
+	at = at.UTC().Truncate(time.Microsecond)
+	row, err := r.queries.TouchKnownLoginContext(ctx, gen.TouchKnownLoginContextParams{
+		UserID:      userID,
+		Kind:        string(kind),
+		Value:       value,
+		FirstSeenAt: at,
+	})
+	if err != nil {
+		return false, err
+	}
+	return row.FirstSeenAt.Equal(at), nil
+}
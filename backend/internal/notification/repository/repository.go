@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/notification/domain"
+)
+
+// Repository persists notification preferences and the devices/locations users have signed in from.
+type Repository interface {
+	// GetPreferences returns the user's preferences, or nil if not set (caller uses defaults).
+	GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error)
+	// UpsertPreferences creates or updates the user's preferences.
+	UpsertPreferences(ctx context.Context, p *domain.Preferences) error
+	// CountKnownLoginContexts returns how many distinct values of kind the user has signed in from.
+	CountKnownLoginContexts(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (int64, error)
+	// GetLatestKnownLoginContext returns the most recently used value of kind for the user, or nil if none.
+	GetLatestKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind) (*domain.KnownLoginContext, error)
+	// TouchKnownLoginContext records a sign-in from value at the given time. Returns true if value was not known before.
+	TouchKnownLoginContext(ctx context.Context, userID string, kind domain.KnownLoginContextKind, value string, at time.Time) (bool, error)
+}
@@ -0,0 +1,75 @@
+// Package notification sends user-facing alerts (e.g. new sign-in, device trust expiry) over pluggable email/SMS
+// senders.
+package notification
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// EmailSender sends a plain-text email (e.g. SMTP).
+type EmailSender interface {
+	SendEmail(to, subject, body string) error
+}
+
+// OrgEmailSender is an EmailSender that can also send on behalf of an org, through the org's own SMTP server when it
+// has one configured (see internal/orgsmtp).
+type OrgEmailSender interface {
+	EmailSender
+	SendOrgEmail(ctx context.Context, orgID, to, subject, body string) error
+}
+
+// SendOrgEmail sends an email on behalf of orgID when sender is an OrgEmailSender, and through sender's own server
+// otherwise.
+func SendOrgEmail(ctx context.Context, sender EmailSender, orgID, to, subject, body string) error {
+	if s, ok := sender.(OrgEmailSender); ok && orgID != "" {
+		return s.SendOrgEmail(ctx, orgID, to, subject, body)
+	}
+	return sender.SendEmail(to, subject, body)
+}
+
+// SMSSender sends a plain-text SMS (e.g. SMS Local).
+type SMSSender interface {
+	SendSMS(phone, message string) error
+}
+
+// GeoLocator resolves the client of the current request to a coarse location such as "Berlin, DE".
+// Returns "" when the location is unknown; location-based alerts are then skipped.
+type GeoLocator interface {
+	Locate(ctx context.Context, ip string) string
+}
+
+// HeaderGeoLocator reads geo headers set by an edge proxy/CDN (x-client-city/x-client-country or
+// Cloudflare's cf-ipcity/cf-ipcountry). It does not resolve the IP itself.
+type HeaderGeoLocator struct{}
+
+// Locate returns "City, CC", "City", or "CC" from incoming metadata, or "" if no geo headers are present.
+func (HeaderGeoLocator) Locate(ctx context.Context, ip string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	city := firstHeader(md, "x-client-city", "cf-ipcity")
+	country := firstHeader(md, "x-client-country", "cf-ipcountry")
+	switch {
+	case city != "" && country != "":
+		return city + ", " + country
+	case city != "":
+		return city
+	default:
+		return country
+	}
+}
+
+func firstHeader(md metadata.MD, keys ...string) string {
+	for _, k := range keys {
+		if vals := md.Get(k); len(vals) > 0 {
+			if s := strings.TrimSpace(vals[0]); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/securityevent"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// trustExpiryPageSize is how many devices the trust expiry job reads per query.
+const trustExpiryPageSize = 200
+
+// TrustExpiryDevices lists devices whose trust is about to expire and claims their expiry notice. Implemented by
+// the device repository.
+type TrustExpiryDevices interface {
+	ListTrustExpiring(ctx context.Context, afterUntil time.Time, afterID string, before time.Time, limit int) ([]*devicedomain.Device, error)
+	MarkTrustExpiryNotified(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+// UserReader returns a user by ID (nil when not found).
+type UserReader interface {
+	GetByID(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+// TrustExpiryJob notifies users before the trust of one of their devices expires, in orgs whose
+// device_trust.expiry_notice_days is set, so MFA on the next sign-in does not come as a surprise. Each trust period
+// is notified once: the notice is claimed on the device before it is sent, so with several server instances only
+// one sends it, and granting or renewing trust clears the claim.
+type TrustExpiryJob struct {
+	devices   TrustExpiryDevices
+	users     UserReader
+	orgPolicy OrgPolicyConfigReader
+	email     EmailSender
+	events    securityevent.Recorder
+	now       func() time.Time
+}
+
+// NewTrustExpiryJob returns a TrustExpiryJob. users and email may be nil (no email is sent; the security event is
+// still recorded); without orgPolicy every org has the defaults, which send no notices.
+func NewTrustExpiryJob(devices TrustExpiryDevices, users UserReader, orgPolicy OrgPolicyConfigReader, email EmailSender, events securityevent.Recorder) *TrustExpiryJob {
+	return &TrustExpiryJob{devices: devices, users: users, orgPolicy: orgPolicy, email: email, events: events, now: time.Now}
+}
+
+// RunOnce notifies every device whose trust expires within its org's notice period and has not been notified, and
+// returns how many were notified. Orgs whose policy cannot be read are skipped until the next run. Returns the first
+// device read or claim error; the run stops at a read error.
+func (j *TrustExpiryJob) RunOnce(ctx context.Context) (int, error) {
+	now := j.now().UTC()
+	before := now.Add(time.Duration(orgpolicyconfigdomain.MaxExpiryNoticeDays) * 24 * time.Hour)
+	notice := make(map[string]time.Duration)
+	afterUntil, afterID := now, ""
+	notified := 0
+	var firstErr error
+	for {
+		page, err := j.devices.ListTrustExpiring(ctx, afterUntil, afterID, before, trustExpiryPageSize)
+		if err != nil {
+			return notified, err
+		}
+		for _, d := range page {
+			afterUntil, afterID = *d.TrustedUntil, d.ID
+			n, ok := notice[d.OrgID]
+			if !ok {
+				n = j.expiryNotice(ctx, d.OrgID)
+				notice[d.OrgID] = n
+			}
+			if n <= 0 || d.TrustedUntil.After(now.Add(n)) {
+				continue
+			}
+			claimed, err := j.devices.MarkTrustExpiryNotified(ctx, d.ID, now)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			if claimed {
+				j.notify(ctx, d, now)
+				notified++
+			}
+		}
+		if len(page) < trustExpiryPageSize {
+			return notified, firstErr
+		}
+	}
+}
+
+// Run calls RunOnce every interval until ctx is done.
+func (j *TrustExpiryJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := j.RunOnce(ctx); err != nil {
+			log.Printf("notification: trust expiry run failed after %d notices: %v", n, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expiryNotice returns the org's device_trust expiry notice period; 0 when notices are off or the policy cannot
+// be read.
+func (j *TrustExpiryJob) expiryNotice(ctx context.Context, orgID string) time.Duration {
+	var cfg *orgpolicyconfigdomain.OrgPolicyConfig
+	if j.orgPolicy != nil {
+		var err error
+		if cfg, err = j.orgPolicy.GetByOrgID(ctx, orgID); err != nil {
+			log.Printf("notification: org_id=%s failed to read device trust policy: %v", orgID, err)
+			return 0
+		}
+	}
+	return orgpolicyconfigdomain.MergeWithDefaults(cfg).DeviceTrust.ExpiryNotice()
+}
+
+// notify records a device_trust_expiring security event for the device's user and emails them when possible.
+func (j *TrustExpiryJob) notify(ctx context.Context, d *devicedomain.Device, now time.Time) {
+	if j.events != nil {
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"device_id":     d.ID,
+			"trusted_until": d.TrustedUntil.UTC().Format(time.RFC3339),
+			"days_left":     int(d.TrustedUntil.Sub(now).Hours() / 24),
+		})
+		j.events.Record(ctx, d.OrgID, d.UserID, securityeventdomain.EventDeviceTrustExpiring, string(metadata))
+	}
+	if j.email == nil || j.users == nil {
+		return
+	}
+	user, err := j.users.GetByID(ctx, d.UserID)
+	if err != nil || user == nil || user.Email == "" || user.Status != userdomain.UserStatusActive {
+		return
+	}
+	if err := SendOrgEmail(ctx, j.email, d.OrgID, user.Email, TrustExpirySubject, TrustExpiryMessage(*d.TrustedUntil)); err != nil {
+		log.Printf("notification: user_id=%s failed to send trust expiry email: %v", d.UserID, err)
+	}
+}
+
+// TrustExpirySubject is the email subject for trust expiry notices.
+const TrustExpirySubject = "Your trusted device will ask for verification soon"
+
+// TrustExpiryMessage returns the notice text for a device whose trust expires at until.
+func TrustExpiryMessage(until time.Time) string {
+	return fmt.Sprintf("One of your trusted devices stays trusted until %s. After that you will be asked to verify your sign-in with MFA on it again.",
+		until.UTC().Format("2006-01-02 15:04 MST"))
+}
@@ -0,0 +1,147 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+type memTrustDevices struct {
+	devices  []*devicedomain.Device
+	notified map[string]bool
+	listErr  error
+	pages    int
+}
+
+func (m *memTrustDevices) ListTrustExpiring(ctx context.Context, afterUntil time.Time, afterID string, before time.Time, limit int) ([]*devicedomain.Device, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	m.pages++
+	sort.Slice(m.devices, func(i, k int) bool {
+		a, b := m.devices[i], m.devices[k]
+		return a.TrustedUntil.Before(*b.TrustedUntil) || (a.TrustedUntil.Equal(*b.TrustedUntil) && a.ID < b.ID)
+	})
+	var out []*devicedomain.Device
+	for _, d := range m.devices {
+		if !d.Trusted || d.RevokedAt != nil || m.notified[d.ID] || d.TrustedUntil.After(before) {
+			continue
+		}
+		if d.TrustedUntil.Before(afterUntil) || (d.TrustedUntil.Equal(afterUntil) && d.ID <= afterID) {
+			continue
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (m *memTrustDevices) MarkTrustExpiryNotified(ctx context.Context, id string, at time.Time) (bool, error) {
+	if m.notified[id] {
+		return false, nil
+	}
+	m.notified[id] = true
+	return true, nil
+}
+
+type memUsers map[string]*userdomain.User
+
+func (m memUsers) GetByID(ctx context.Context, id string) (*userdomain.User, error) {
+	return m[id], nil
+}
+
+type orgPolicies map[string]*orgpolicyconfigdomain.OrgPolicyConfig
+
+func (m orgPolicies) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	if orgID == "org-broken" {
+		return nil, errors.New("db down")
+	}
+	return m[orgID], nil
+}
+
+func noticeDays(days int) *orgpolicyconfigdomain.OrgPolicyConfig {
+	return &orgpolicyconfigdomain.OrgPolicyConfig{DeviceTrust: &orgpolicyconfigdomain.DeviceTrust{ExpiryNoticeDays: days}}
+}
+
+func trustedDevice(id, orgID string, until time.Time) *devicedomain.Device {
+	return &devicedomain.Device{ID: id, UserID: "user-" + id, OrgID: orgID, Trusted: true, TrustedUntil: &until}
+}
+
+func TestTrustExpiryJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	devices := &memTrustDevices{notified: map[string]bool{}, devices: []*devicedomain.Device{
+		trustedDevice("d-due", "org-notice", now.Add(3*24*time.Hour)),
+		trustedDevice("d-later", "org-notice", now.Add(10*24*time.Hour)),
+		trustedDevice("d-off", "org-off", now.Add(24*time.Hour)),
+		trustedDevice("d-broken", "org-broken", now.Add(24*time.Hour)),
+		trustedDevice("d-expired", "org-notice", now.Add(-time.Hour)),
+	}}
+	users := memUsers{
+		"user-d-due": {ID: "user-d-due", Email: "due@example.com", Status: userdomain.UserStatusActive},
+	}
+	policies := orgPolicies{"org-notice": noticeDays(7)}
+	email := &memEmail{}
+	events := &memRecorder{}
+	job := NewTrustExpiryJob(devices, users, policies, email, events)
+	job.now = func() time.Time { return now }
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("RunOnce = %d, %v; want 1 notice", n, err)
+	}
+	if !devices.notified["d-due"] || len(devices.notified) != 1 {
+		t.Errorf("notified = %v, want only d-due", devices.notified)
+	}
+	if len(events.types) != 1 || events.types[0] != securityeventdomain.EventDeviceTrustExpiring {
+		t.Errorf("events = %v, want one device_trust_expiring", events.types)
+	}
+	if len(email.sent) != 1 || !strings.HasPrefix(email.sent[0], "due@example.com: ") || !strings.Contains(email.sent[0], "2026-03-04 12:00 UTC") {
+		t.Errorf("emails = %v", email.sent)
+	}
+
+	// Already notified: nothing new until d-later enters its notice period.
+	if n, _ := job.RunOnce(context.Background()); n != 0 {
+		t.Errorf("second RunOnce = %d, want 0", n)
+	}
+	job.now = func() time.Time { return now.Add(4 * 24 * time.Hour) }
+	if n, _ := job.RunOnce(context.Background()); n != 1 || !devices.notified["d-later"] {
+		t.Errorf("RunOnce four days later = %d (notified %v), want d-later", n, devices.notified)
+	}
+}
+
+func TestTrustExpiryJob_Pages(t *testing.T) {
+	now := time.Now().UTC()
+	devices := &memTrustDevices{notified: map[string]bool{}}
+	// A full page of devices in an org without notices must not hide the devices after it.
+	for i := 0; i < trustExpiryPageSize; i++ {
+		devices.devices = append(devices.devices, trustedDevice(fmt.Sprintf("off-%03d", i), "org-off", now.Add(time.Hour)))
+	}
+	devices.devices = append(devices.devices, trustedDevice("d-due", "org-notice", now.Add(2*time.Hour)))
+	job := NewTrustExpiryJob(devices, nil, orgPolicies{"org-notice": noticeDays(1)}, nil, &memRecorder{})
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil || n != 1 || !devices.notified["d-due"] {
+		t.Fatalf("RunOnce = %d, %v (notified %v); want d-due", n, err, devices.notified)
+	}
+	if devices.pages != 2 {
+		t.Errorf("pages = %d, want 2", devices.pages)
+	}
+}
+
+func TestTrustExpiryJob_ListError(t *testing.T) {
+	job := NewTrustExpiryJob(&memTrustDevices{listErr: errors.New("db down")}, nil, nil, nil, nil)
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Error("RunOnce should return the list error")
+	}
+}
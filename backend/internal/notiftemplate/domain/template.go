@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Kind identifies a notification whose text an org can override.
+type Kind string
+
+const (
+	// KindLoginAlert is the new sign-in alert, sent by email (subject and body) or SMS (body).
+	KindLoginAlert Kind = "login_alert"
+	// KindOTPSMS is the SMS with a one-time sign-in code.
+	KindOTPSMS Kind = "otp_sms"
+)
+
+// Template is an org's text for one kind of notification in one locale. Subject and Body may contain {{variable}}
+// placeholders. Locale "" is the org's template for users whose locale has no template of its own.
+type Template struct {
+	OrgID     string
+	Kind      Kind
+	Locale    string
+	Subject   string
+	Body      string
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// Message is a rendered notification. Subject is empty for SMS.
+type Message struct {
+	Subject string
+	Body    string
+}
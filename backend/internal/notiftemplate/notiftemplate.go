@@ -0,0 +1,357 @@
+// Package notiftemplate holds the texts of user notifications. Each kind of notification has a built-in English text;
+// orgs can override it per locale, and each user gets the org's template for their locale (notification preferences),
+// falling back to the language without region, then the org's default template, then the built-in text. Templates use
+// {{variable}} placeholders; each kind has a fixed set of variables, some of which every template must use.
+package notiftemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	notificationdomain "zero-trust-control-plane/backend/internal/notification/domain"
+	"zero-trust-control-plane/backend/internal/notiftemplate/domain"
+	"zero-trust-control-plane/backend/internal/notiftemplate/repository"
+)
+
+// MaxSubjectLength is the longest subject a template may have.
+const MaxSubjectLength = 200
+
+var (
+	// ErrUnknownKind is returned for a kind without a built-in template.
+	ErrUnknownKind = errors.New("notiftemplate: unknown template kind")
+	// ErrInvalidLocale is returned for a locale that is not a language tag such as "de" or "pt-BR".
+	ErrInvalidLocale = errors.New("notiftemplate: invalid locale")
+	// ErrInvalidTemplate is returned for a template with unknown or missing variables, malformed placeholders, or a
+	// subject or body that is too long. The error message says which.
+	ErrInvalidTemplate = errors.New("notiftemplate: invalid template")
+)
+
+// Spec describes a kind of notification: its variables and built-in text.
+type Spec struct {
+	Kind domain.Kind
+	// Email is true when the notification is sent by email, so templates need a subject.
+	Email bool
+	// Variables are the placeholders templates may use; Required those every template must use.
+	Variables []string
+	Required  []string
+	// MaxBodyLength limits the body, e.g. to keep SMS short.
+	MaxBodyLength int
+	// Builtin is the text used when the org has no template.
+	Builtin domain.Message
+	// Sample holds the variable values used by Preview.
+	Sample map[string]string
+}
+
+var specs = map[domain.Kind]Spec{
+	domain.KindLoginAlert: {
+		Kind:          domain.KindLoginAlert,
+		Email:         true,
+		Variables:     []string{"device", "time", "location", "ip", "where"},
+		Required:      []string{"device", "time"},
+		MaxBodyLength: 2000,
+		Builtin: domain.Message{
+			Subject: "New sign-in to your account",
+			Body:    "New sign-in from {{device}}{{where}} at {{time}}. If this wasn't you, sign out of all sessions and change your password.",
+		},
+		Sample: map[string]string{
+			"device":   "Chrome on Windows",
+			"time":     "2026-01-02 15:04 UTC",
+			"location": "Berlin, DE",
+			"ip":       "203.0.113.7",
+			"where":    " in Berlin, DE",
+		},
+	},
+	domain.KindOTPSMS: {
+		Kind:          domain.KindOTPSMS,
+		Variables:     []string{"code", "minutes"},
+		Required:      []string{"code"},
+		MaxBodyLength: 320,
+		Builtin: domain.Message{
+			Body: "Your verification code is {{code}}. It expires in {{minutes}} minutes.",
+		},
+		Sample: map[string]string{"code": "123456", "minutes": "5"},
+	},
+}
+
+// Kinds returns the kinds of notification that have templates.
+func Kinds() []Spec {
+	return []Spec{specs[domain.KindLoginAlert], specs[domain.KindOTPSMS]}
+}
+
+// SpecFor returns the spec of kind.
+func SpecFor(kind domain.Kind) (Spec, bool) {
+	s, ok := specs[kind]
+	return s, ok
+}
+
+var localePattern = regexp.MustCompile(`^([a-z]{2,3})(-[A-Z][a-z]{3})?(-(?:[A-Z]{2}|[0-9]{3}))?$`)
+
+// NormalizeLocale returns locale as a language tag with canonical case ("pt_br" → "pt-BR"). "" stays "" (the org's
+// default template).
+func NormalizeLocale(locale string) (string, error) {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return "", nil
+	}
+	parts := strings.Split(locale, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch p := parts[i]; len(p) {
+		case 4:
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		default:
+			parts[i] = strings.ToUpper(p)
+		}
+	}
+	locale = strings.Join(parts, "-")
+	if !localePattern.MatchString(locale) {
+		return "", ErrInvalidLocale
+	}
+	return locale, nil
+}
+
+// candidates returns the template locales tried for a user with locale, most specific first.
+func candidates(locale string) []string {
+	out := []string{}
+	if locale != "" {
+		out = append(out, locale)
+		if i := strings.Index(locale, "-"); i > 0 {
+			out = append(out, locale[:i])
+		}
+	}
+	return append(out, "")
+}
+
+// Validate checks subject and body for kind: every placeholder is a variable of the kind, every required variable is
+// used in the body, email kinds have a one-line subject and SMS kinds none, and both are within the length limits.
+func Validate(kind domain.Kind, subject, body string) error {
+	spec, ok := specs[kind]
+	if !ok {
+		return ErrUnknownKind
+	}
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidTemplate)
+	}
+	if len(body) > spec.MaxBodyLength {
+		return fmt.Errorf("%w: body is longer than %d characters", ErrInvalidTemplate, spec.MaxBodyLength)
+	}
+	switch {
+	case !spec.Email && subject != "":
+		return fmt.Errorf("%w: %s has no subject", ErrInvalidTemplate, kind)
+	case spec.Email && strings.TrimSpace(subject) == "":
+		return fmt.Errorf("%w: subject is required", ErrInvalidTemplate)
+	case strings.ContainsAny(subject, "\r\n"):
+		return fmt.Errorf("%w: subject must be one line", ErrInvalidTemplate)
+	case len(subject) > MaxSubjectLength:
+		return fmt.Errorf("%w: subject is longer than %d characters", ErrInvalidTemplate, MaxSubjectLength)
+	}
+	if _, err := placeholders(spec, subject); err != nil {
+		return err
+	}
+	used, err := placeholders(spec, body)
+	if err != nil {
+		return err
+	}
+	for _, v := range spec.Required {
+		if !used[v] {
+			return fmt.Errorf("%w: body must use {{%s}}", ErrInvalidTemplate, v)
+		}
+	}
+	return nil
+}
+
+// placeholders returns the variables used in text, or an error for a malformed or unknown placeholder.
+func placeholders(spec Spec, text string) (map[string]bool, error) {
+	used := map[string]bool{}
+	for {
+		i := strings.Index(text, "{{")
+		if i < 0 {
+			if strings.Contains(text, "}}") {
+				return nil, fmt.Errorf("%w: unmatched }}", ErrInvalidTemplate)
+			}
+			return used, nil
+		}
+		if strings.Contains(text[:i], "}}") {
+			return nil, fmt.Errorf("%w: unmatched }}", ErrInvalidTemplate)
+		}
+		j := strings.Index(text[i+2:], "}}")
+		if j < 0 {
+			return nil, fmt.Errorf("%w: unclosed {{", ErrInvalidTemplate)
+		}
+		name := strings.TrimSpace(text[i+2 : i+2+j])
+		if !contains(spec.Variables, name) {
+			return nil, fmt.Errorf("%w: unknown variable {{%s}}; %s can use %s", ErrInvalidTemplate, name, spec.Kind, strings.Join(spec.Variables, ", "))
+		}
+		used[name] = true
+		text = text[i+2+j+2:]
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Render replaces the {{variable}} placeholders in text with vars. Placeholders without a value are removed. Text must
+// have passed Validate.
+func Render(text string, vars map[string]string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(text, "{{")
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		j := strings.Index(text[i+2:], "}}")
+		if j < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:i])
+		b.WriteString(vars[strings.TrimSpace(text[i+2:i+2+j])])
+		text = text[i+2+j+2:]
+	}
+}
+
+// PreferencesReader reads a user's notification preferences, which hold their locale.
+type PreferencesReader interface {
+	GetPreferences(ctx context.Context, userID string) (*notificationdomain.Preferences, error)
+}
+
+// Templates manages org templates and renders notifications with them.
+type Templates struct {
+	repo  repository.Repository
+	prefs PreferencesReader
+}
+
+// New returns templates backed by repo. prefs may be nil; all users then get the org's default template.
+func New(repo repository.Repository, prefs PreferencesReader) *Templates {
+	return &Templates{repo: repo, prefs: prefs}
+}
+
+// Render renders kind for userID in orgID with vars, using the org's template for the user's locale or the built-in
+// text. custom reports whether an org template was used. Lookup failures are logged and fall back to the built-in
+// text, so a notification is never lost to a template.
+func (t *Templates) Render(ctx context.Context, orgID, userID string, kind domain.Kind, vars map[string]string) (msg domain.Message, custom bool) {
+	spec := specs[kind]
+	tpl, err := t.effective(ctx, orgID, t.locale(ctx, userID), kind)
+	if err != nil {
+		log.Printf("notiftemplate: org_id=%s kind=%s failed to load template: %v", orgID, kind, err)
+	}
+	if tpl == nil {
+		return render(spec.Builtin, vars), false
+	}
+	return render(domain.Message{Subject: tpl.Subject, Body: tpl.Body}, vars), true
+}
+
+func render(m domain.Message, vars map[string]string) domain.Message {
+	// Values can come from request headers (e.g. location); keep the subject one line.
+	subjectVars := make(map[string]string, len(vars))
+	for k, v := range vars {
+		subjectVars[k] = strings.NewReplacer("\r", " ", "\n", " ").Replace(v)
+	}
+	return domain.Message{Subject: Render(m.Subject, subjectVars), Body: Render(m.Body, vars)}
+}
+
+// locale returns the user's locale, or "" when unknown.
+func (t *Templates) locale(ctx context.Context, userID string) string {
+	if t.prefs == nil || userID == "" {
+		return ""
+	}
+	prefs, err := t.prefs.GetPreferences(ctx, userID)
+	if err != nil {
+		log.Printf("notiftemplate: user_id=%s failed to load locale: %v", userID, err)
+		return ""
+	}
+	if prefs == nil {
+		return ""
+	}
+	return prefs.Locale
+}
+
+// effective returns the org's template used for locale, or nil when the built-in text applies.
+func (t *Templates) effective(ctx context.Context, orgID, locale string, kind domain.Kind) (*domain.Template, error) {
+	if orgID == "" {
+		return nil, nil
+	}
+	for _, l := range candidates(locale) {
+		tpl, err := t.repo.Get(ctx, orgID, kind, l)
+		if err != nil || tpl != nil {
+			return tpl, err
+		}
+	}
+	return nil, nil
+}
+
+// List returns the org's templates.
+func (t *Templates) List(ctx context.Context, orgID string) ([]*domain.Template, error) {
+	return t.repo.List(ctx, orgID)
+}
+
+// Update validates tpl, normalizes its locale, and stores it as the org's template for its kind and locale.
+func (t *Templates) Update(ctx context.Context, tpl *domain.Template) (*domain.Template, error) {
+	locale, err := NormalizeLocale(tpl.Locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(tpl.Kind, tpl.Subject, tpl.Body); err != nil {
+		return nil, err
+	}
+	out := *tpl
+	out.Locale = locale
+	out.UpdatedAt = time.Now().UTC()
+	if err := t.repo.Upsert(ctx, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Delete removes the org's template for kind in locale. It reports false when there was none.
+func (t *Templates) Delete(ctx context.Context, orgID string, kind domain.Kind, locale string) (bool, error) {
+	if _, ok := specs[kind]; !ok {
+		return false, ErrUnknownKind
+	}
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return false, err
+	}
+	return t.repo.Delete(ctx, orgID, kind, locale)
+}
+
+// Preview renders kind with sample values and returns the template used, nil for the built-in text. When body is
+// empty it renders what a user with locale gets: the org's template or the built-in text. Otherwise it validates and
+// renders the given subject and body as a draft template, so it can be checked before it is saved.
+func (t *Templates) Preview(ctx context.Context, orgID string, kind domain.Kind, locale, subject, body string) (domain.Message, *domain.Template, error) {
+	spec, ok := specs[kind]
+	if !ok {
+		return domain.Message{}, nil, ErrUnknownKind
+	}
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return domain.Message{}, nil, err
+	}
+	if body != "" {
+		if err := Validate(kind, subject, body); err != nil {
+			return domain.Message{}, nil, err
+		}
+		draft := &domain.Template{OrgID: orgID, Kind: kind, Locale: locale, Subject: subject, Body: body}
+		return render(domain.Message{Subject: subject, Body: body}, spec.Sample), draft, nil
+	}
+	tpl, err := t.effective(ctx, orgID, locale, kind)
+	if err != nil {
+		return domain.Message{}, nil, err
+	}
+	if tpl == nil {
+		return render(spec.Builtin, spec.Sample), nil, nil
+	}
+	return render(domain.Message{Subject: tpl.Subject, Body: tpl.Body}, spec.Sample), tpl, nil
+}
@@ -0,0 +1,175 @@
+package notiftemplate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	notificationdomain "zero-trust-control-plane/backend/internal/notification/domain"
+	"zero-trust-control-plane/backend/internal/notiftemplate/domain"
+)
+
+type memRepo map[string]*domain.Template
+
+func key(orgID string, kind domain.Kind, locale string) string {
+	return orgID + "|" + string(kind) + "|" + locale
+}
+
+func (m memRepo) Get(ctx context.Context, orgID string, kind domain.Kind, locale string) (*domain.Template, error) {
+	return m[key(orgID, kind, locale)], nil
+}
+
+func (m memRepo) List(ctx context.Context, orgID string) ([]*domain.Template, error) {
+	var out []*domain.Template
+	for _, t := range m {
+		if t.OrgID == orgID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m memRepo) Upsert(ctx context.Context, t *domain.Template) error {
+	c := *t
+	m[key(t.OrgID, t.Kind, t.Locale)] = &c
+	return nil
+}
+
+func (m memRepo) Delete(ctx context.Context, orgID string, kind domain.Kind, locale string) (bool, error) {
+	k := key(orgID, kind, locale)
+	_, ok := m[k]
+	delete(m, k)
+	return ok, nil
+}
+
+type memPrefs map[string]string
+
+func (m memPrefs) GetPreferences(ctx context.Context, userID string) (*notificationdomain.Preferences, error) {
+	locale, ok := m[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &notificationdomain.Preferences{UserID: userID, Locale: locale}, nil
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	for in, want := range map[string]string{"": "", "DE": "de", "pt_br": "pt-BR", "zh-hant-tw": "zh-Hant-TW", "es-419": "es-419"} {
+		if got, err := NormalizeLocale(in); err != nil || got != want {
+			t.Errorf("NormalizeLocale(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"english", "d", "de-", "de-DE-x", "../de"} {
+		if _, err := NormalizeLocale(in); !errors.Is(err, ErrInvalidLocale) {
+			t.Errorf("NormalizeLocale(%q) = %v, want ErrInvalidLocale", in, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, spec := range Kinds() {
+		if err := Validate(spec.Kind, spec.Builtin.Subject, spec.Builtin.Body); err != nil {
+			t.Errorf("built-in %s: %v", spec.Kind, err)
+		}
+	}
+	cases := []struct {
+		name          string
+		kind          domain.Kind
+		subject, body string
+		want          string
+	}{
+		{"unknown variable", domain.KindOTPSMS, "", "Code {{code}} for {{user}}", "unknown variable {{user}}"},
+		{"missing required", domain.KindOTPSMS, "", "Your code", "must use {{code}}"},
+		{"unclosed", domain.KindOTPSMS, "", "Code {{code", "unclosed"},
+		{"unmatched", domain.KindOTPSMS, "", "Code code}} {{code}}", "unmatched"},
+		{"sms subject", domain.KindOTPSMS, "Code", "{{code}}", "has no subject"},
+		{"email without subject", domain.KindLoginAlert, "", "{{device}} {{time}}", "subject is required"},
+		{"multi-line subject", domain.KindLoginAlert, "a\nBcc: x", "{{device}} {{time}}", "one line"},
+		{"sms too long", domain.KindOTPSMS, "", "{{code}}" + strings.Repeat("x", 400), "longer than"},
+		{"empty body", domain.KindLoginAlert, "Hi", " ", "body is required"},
+	}
+	for _, c := range cases {
+		err := Validate(c.kind, c.subject, c.body)
+		if !errors.Is(err, ErrInvalidTemplate) || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: err = %v, want %q", c.name, err, c.want)
+		}
+	}
+	if err := Validate("password_reset", "", "x"); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("unknown kind = %v, want ErrUnknownKind", err)
+	}
+}
+
+func TestTemplates_RenderByLocale(t *testing.T) {
+	repo := memRepo{}
+	tpls := New(repo, memPrefs{"user-de": "de-AT", "user-fr": "fr", "user-en": ""})
+	ctx := context.Background()
+	vars := map[string]string{"code": "424242", "minutes": "5"}
+
+	if msg, custom := tpls.Render(ctx, "org-1", "user-de", domain.KindOTPSMS, vars); custom || msg.Body != "Your verification code is 424242. It expires in 5 minutes." {
+		t.Errorf("without templates = %+v, %v; want the built-in text", msg, custom)
+	}
+
+	for _, tpl := range []domain.Template{
+		{OrgID: "org-1", Kind: domain.KindOTPSMS, Body: "Acme code: {{code}}"},
+		{OrgID: "org-1", Kind: domain.KindOTPSMS, Locale: "DE", Body: "Ihr Code: {{ code }} ({{minutes}} Minuten)"},
+	} {
+		if _, err := tpls.Update(ctx, &tpl); err != nil {
+			t.Fatalf("Update(%q): %v", tpl.Locale, err)
+		}
+	}
+	cases := map[string]string{
+		"user-de": "Ihr Code: 424242 (5 Minuten)",
+		"user-fr": "Acme code: 424242",
+		"user-en": "Acme code: 424242",
+		"unknown": "Acme code: 424242",
+	}
+	for user, want := range cases {
+		if msg, custom := tpls.Render(ctx, "org-1", user, domain.KindOTPSMS, vars); !custom || msg.Body != want {
+			t.Errorf("%s = %q, %v; want %q", user, msg.Body, custom, want)
+		}
+	}
+	if _, custom := tpls.Render(ctx, "org-2", "user-de", domain.KindOTPSMS, vars); custom {
+		t.Error("other org used org-1's template")
+	}
+
+	if ok, err := tpls.Delete(ctx, "org-1", domain.KindOTPSMS, "de"); err != nil || !ok {
+		t.Fatalf("Delete = %v, %v", ok, err)
+	}
+	if msg, _ := tpls.Render(ctx, "org-1", "user-de", domain.KindOTPSMS, vars); msg.Body != "Acme code: 424242" {
+		t.Errorf("after delete = %q, want the org default", msg.Body)
+	}
+}
+
+func TestTemplates_RenderSubjectOneLine(t *testing.T) {
+	repo := memRepo{}
+	tpls := New(repo, nil)
+	ctx := context.Background()
+	if _, err := tpls.Update(ctx, &domain.Template{OrgID: "org-1", Kind: domain.KindLoginAlert, Subject: "Sign-in from {{location}}", Body: "{{device}} at {{time}} in {{location}}"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	msg, _ := tpls.Render(ctx, "org-1", "", domain.KindLoginAlert, map[string]string{"location": "Berlin\r\nBcc: x", "device": "Chrome", "time": "now"})
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		t.Errorf("subject = %q, want one line", msg.Subject)
+	}
+}
+
+func TestTemplates_Preview(t *testing.T) {
+	repo := memRepo{}
+	tpls := New(repo, nil)
+	ctx := context.Background()
+
+	msg, tpl, err := tpls.Preview(ctx, "org-1", domain.KindLoginAlert, "de", "", "")
+	if err != nil || tpl != nil || msg.Subject != "New sign-in to your account" || !strings.Contains(msg.Body, "Chrome on Windows in Berlin, DE") {
+		t.Errorf("built-in preview = %+v, %v, %v", msg, tpl, err)
+	}
+	msg, tpl, err = tpls.Preview(ctx, "org-1", domain.KindLoginAlert, "de", "Neue Anmeldung", "{{device}} um {{time}}")
+	if err != nil || tpl == nil || tpl.Locale != "de" || msg.Body != "Chrome on Windows um 2026-01-02 15:04 UTC" {
+		t.Errorf("draft preview = %+v, %v, %v", msg, tpl, err)
+	}
+	if _, _, err := tpls.Preview(ctx, "org-1", domain.KindLoginAlert, "de", "Neue Anmeldung", "{{device}}"); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("invalid draft = %v, want ErrInvalidTemplate", err)
+	}
+	if _, _, err := tpls.Preview(ctx, "org-1", domain.KindLoginAlert, "german", "", ""); !errors.Is(err, ErrInvalidLocale) {
+		t.Errorf("invalid locale = %v, want ErrInvalidLocale", err)
+	}
+}
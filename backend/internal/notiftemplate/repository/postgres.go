@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/notiftemplate/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a notification template repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Get returns the org's template for kind in locale, or nil if not found.
+func (r *PostgresRepository) Get(ctx context.Context, orgID string, kind domain.Kind, locale string) (*domain.Template, error) {
+	row, err := r.queries.GetNotificationTemplate(ctx, gen.GetNotificationTemplateParams{
+		OrgID:  orgID,
+		Kind:   string(kind),
+		Locale: locale,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genTemplateToDomain(row), nil
+}
+
+// List returns the org's templates ordered by kind and locale.
+func (r *PostgresRepository) List(ctx context.Context, orgID string) ([]*domain.Template, error) {
+	rows, err := r.queries.ListNotificationTemplates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Template, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, genTemplateToDomain(row))
+	}
+	return out, nil
+}
+
+// Upsert creates or replaces the org's template for the template's kind and locale.
+func (r *PostgresRepository) Upsert(ctx context.Context, t *domain.Template) error {
+	return r.queries.UpsertNotificationTemplate(ctx, gen.UpsertNotificationTemplateParams{
+		OrgID:     t.OrgID,
+		Kind:      string(t.Kind),
+		Locale:    t.Locale,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		UpdatedBy: t.UpdatedBy,
+		UpdatedAt: t.UpdatedAt,
+	})
+}
+
+// Delete removes the org's template for kind in locale.
+func (r *PostgresRepository) Delete(ctx context.Context, orgID string, kind domain.Kind, locale string) (bool, error) {
+	n, err := r.queries.DeleteNotificationTemplate(ctx, gen.DeleteNotificationTemplateParams{
+		OrgID:  orgID,
+		Kind:   string(kind),
+		Locale: locale,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func genTemplateToDomain(row gen.NotificationTemplate) *domain.Template {
+	return &domain.Template{
+		OrgID:     row.OrgID,
+		Kind:      domain.Kind(row.Kind),
+		Locale:    row.Locale,
+		Subject:   row.Subject,
+		Body:      row.Body,
+		UpdatedBy: row.UpdatedBy,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
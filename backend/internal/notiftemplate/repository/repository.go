@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/notiftemplate/domain"
+)
+
+// Repository defines access to org notification templates.
+type Repository interface {
+	// Get returns the org's template for kind in locale, or nil if there is none.
+	Get(ctx context.Context, orgID string, kind domain.Kind, locale string) (*domain.Template, error)
+	// List returns the org's templates ordered by kind and locale.
+	List(ctx context.Context, orgID string) ([]*domain.Template, error)
+	// Upsert creates or replaces the org's template for the template's kind and locale.
+	Upsert(ctx context.Context, t *domain.Template) error
+	// Delete removes the org's template for kind in locale. It reports false when there was none.
+	Delete(ctx context.Context, orgID string, kind domain.Kind, locale string) (bool, error)
+}
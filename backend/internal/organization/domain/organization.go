@@ -11,6 +11,17 @@ type Org struct {
 	Name      string
 	Status    OrgStatus
 	CreatedAt time.Time
+	// DataRegion is the region whose database stores the org's activity data (audit logs, policy violations, security
+	// events, telemetry, analytics and usage); empty is the primary database. It cannot change after the org is
+	// created.
+	DataRegion string
+	// MaxUsers and MaxDevices are the org's quotas, set by platform admins: how many members and active (not
+	// revoked) devices it may have. 0 is unlimited.
+	MaxUsers   int
+	MaxDevices int
+	// Plan is the org's billing plan, set by platform admins; it decides which gated features the org may use (see
+	// internal/billing).
+	Plan Plan
 }
 
 type OrgStatus string
@@ -20,13 +31,36 @@ const (
 	OrgStatusSuspended OrgStatus = "suspended"
 )
 
+// Plan is a billing plan. Each plan includes the features of the plans below it.
+type Plan string
+
+const (
+	PlanFree       Plan = "free"
+	PlanTeam       Plan = "team"
+	PlanEnterprise Plan = "enterprise"
+)
+
+// Valid reports whether p is one of the billing plans.
+func (p Plan) Valid() bool {
+	return p == PlanFree || p == PlanTeam || p == PlanEnterprise
+}
+
 // Validate validates the organization for persistence. Returns an error describing the first validation failure.
 func (o *Org) Validate() error {
 	if o.Name == "" {
 		return errors.New("name is required")
 	}
+	if o.MaxUsers < 0 || o.MaxDevices < 0 {
+		return errors.New("quotas must not be negative")
+	}
 	if o.Status == "" {
 		o.Status = OrgStatusActive
 	}
+	if o.Plan == "" {
+		o.Plan = PlanFree
+	}
+	if !o.Plan.Valid() {
+		return errors.New("plan must be free, team or enterprise")
+	}
 	return nil
 }
@@ -2,6 +2,10 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,14 +14,30 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
 	organizationv1 "zero-trust-control-plane/backend/api/generated/organization/v1"
+	policyv1 "zero-trust-control-plane/backend/api/generated/policy/v1"
+	"zero-trust-control-plane/backend/internal/audit"
 	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
 	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
 	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
 	organizationrepo "zero-trust-control-plane/backend/internal/organization/repository"
+	"zero-trust-control-plane/backend/internal/orgdomain"
+	orgdomaindomain "zero-trust-control-plane/backend/internal/orgdomain/domain"
+	orgpolicyconfighandler "zero-trust-control-plane/backend/internal/orgpolicyconfig/handler"
+	"zero-trust-control-plane/backend/internal/orgsetup"
+	orgsetupdomain "zero-trust-control-plane/backend/internal/orgsetup/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 	userrepo "zero-trust-control-plane/backend/internal/user/repository"
 )
 
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
 // Server implements OrganizationService (proto server) for multi-tenancy and org management.
 // Proto: organization/organization.proto → internal/organization/handler.
 type Server struct {
@@ -25,21 +45,36 @@ type Server struct {
 	orgRepo        organizationrepo.Repository
 	userRepo       userrepo.Repository
 	membershipRepo membershiprepo.Repository
+	dataRegions    []string
+	domains        *orgdomain.Verifier
+	setup          *orgsetup.Setuper
+	platformAdmins rbac.PlatformAdminChecker
+	auditLogger    audit.AuditLogger
 }
 
-// NewServer returns a new Organization gRPC server.
+// NewServer returns a new Organization gRPC server. dataRegions are the data regions configured in this deployment,
+// which CreateOrganization accepts as data_region.
 // If orgRepo, userRepo, or membershipRepo is nil, CreateOrganization returns Unimplemented.
-// Other RPCs may return Unimplemented if orgRepo is nil.
-func NewServer(orgRepo organizationrepo.Repository, userRepo userrepo.Repository, membershipRepo membershiprepo.Repository) *Server {
+// Other RPCs may return Unimplemented if orgRepo is nil. If domains or membershipRepo is nil, the domain verification
+// RPCs return Unimplemented. If setup is nil, SetupOrganization returns Unimplemented. platformAdmins names the
+// platform admins, who may read, update and list every org; if nil, no caller is a platform admin and
+// ListOrganizations is denied. auditLogger may be nil.
+func NewServer(orgRepo organizationrepo.Repository, userRepo userrepo.Repository, membershipRepo membershiprepo.Repository, dataRegions []string, domains *orgdomain.Verifier, setup *orgsetup.Setuper, platformAdmins rbac.PlatformAdminChecker, auditLogger audit.AuditLogger) *Server {
 	return &Server{
 		orgRepo:        orgRepo,
 		userRepo:       userRepo,
 		membershipRepo: membershipRepo,
+		dataRegions:    dataRegions,
+		domains:        domains,
+		setup:          setup,
+		platformAdmins: platformAdmins,
+		auditLogger:    auditLogger,
 	}
 }
 
 // CreateOrganization creates a new organization with the given name and assigns the user as owner.
-// The organization is auto-activated (status=active) for PoC. Requires user_id and name.
+// The organization is auto-activated (status=active) for PoC. Requires user_id and name. data_region, when set, must
+// be a data region configured in this deployment and cannot be changed later.
 func (s *Server) CreateOrganization(ctx context.Context, req *organizationv1.CreateOrganizationRequest) (*organizationv1.CreateOrganizationResponse, error) {
 	if s.orgRepo == nil || s.userRepo == nil || s.membershipRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method CreateOrganization not implemented")
@@ -47,6 +82,7 @@ func (s *Server) CreateOrganization(ctx context.Context, req *organizationv1.Cre
 
 	name := strings.TrimSpace(req.GetName())
 	userID := strings.TrimSpace(req.GetUserId())
+	dataRegion := strings.TrimSpace(req.GetDataRegion())
 
 	if name == "" {
 		return nil, status.Error(codes.InvalidArgument, "name is required")
@@ -54,6 +90,9 @@ func (s *Server) CreateOrganization(ctx context.Context, req *organizationv1.Cre
 	if userID == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
+	if dataRegion != "" && !slices.Contains(s.dataRegions, dataRegion) {
+		return nil, status.Errorf(codes.InvalidArgument, "data_region %q is not available", dataRegion)
+	}
 
 	// Verify user exists
 	user, err := s.userRepo.GetByID(ctx, userID)
@@ -69,9 +108,10 @@ func (s *Server) CreateOrganization(ctx context.Context, req *organizationv1.Cre
 	now := time.Now().UTC()
 	org := &organizationdomain.Org{
 		ID:        orgID,
-		Name:      name,
-		Status:    organizationdomain.OrgStatusActive,
-		CreatedAt: now,
+		Name:       name,
+		Status:     organizationdomain.OrgStatusActive,
+		DataRegion: dataRegion,
+		CreatedAt:  now,
 	}
 	if err := org.Validate(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -103,7 +143,41 @@ func (s *Server) CreateOrganization(ctx context.Context, req *organizationv1.Cre
 	}, nil
 }
 
-// GetOrganization returns an organization by ID.
+// SetupOrganization creates an organization owned by user_id together with its MFA settings, first Rego policy and
+// policy config, from the named template (empty for "default") or policy preset, in one transaction. Like
+// CreateOrganization it is called during sign-up, before the user belongs to an org. data_region is checked as in
+// CreateOrganization.
+func (s *Server) SetupOrganization(ctx context.Context, req *organizationv1.SetupOrganizationRequest) (*organizationv1.SetupOrganizationResponse, error) {
+	if s.setup == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetupOrganization not implemented")
+	}
+	userID := strings.TrimSpace(req.GetUserId())
+	dataRegion := strings.TrimSpace(req.GetDataRegion())
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dataRegion != "" && !slices.Contains(s.dataRegions, dataRegion) {
+		return nil, status.Errorf(codes.InvalidArgument, "data_region %q is not available", dataRegion)
+	}
+	setup, err := s.setup.Setup(ctx, req.GetName(), userID, dataRegion, strings.TrimSpace(req.GetTemplate()))
+	switch {
+	case errors.Is(err, orgsetup.ErrNameRequired):
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	case errors.Is(err, orgsetup.ErrUnknownTemplate):
+		return nil, status.Errorf(codes.InvalidArgument, "template %q does not exist", req.GetTemplate())
+	case errors.Is(err, orgsetup.ErrUserNotFound):
+		return nil, status.Error(codes.NotFound, "user not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "failed to set up organization")
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"template": setup.Template})
+		s.auditLogger.LogEvent(ctx, setup.Org.ID, userID, "organization_setup", "organization", string(meta))
+	}
+	return setupToProto(setup), nil
+}
+
+// GetOrganization returns an organization by ID. Caller must be a member of the org or a platform admin.
 func (s *Server) GetOrganization(ctx context.Context, req *organizationv1.GetOrganizationRequest) (*organizationv1.GetOrganizationResponse, error) {
 	if s.orgRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method GetOrganization not implemented")
@@ -112,6 +186,9 @@ func (s *Server) GetOrganization(ctx context.Context, req *organizationv1.GetOrg
 	if orgID == "" {
 		return nil, status.Error(codes.InvalidArgument, "org_id required")
 	}
+	if _, err := s.requireOrgAccess(ctx, orgID, false); err != nil {
+		return nil, err
+	}
 	o, err := s.orgRepo.GetOrganizationByID(ctx, orgID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to look up organization")
@@ -124,9 +201,136 @@ func (s *Server) GetOrganization(ctx context.Context, req *organizationv1.GetOrg
 	}, nil
 }
 
-// ListOrganizations returns a paginated list of organizations. TODO: implement.
+// UpdateOrganization renames an organization and sets its quotas; fields left unset keep their value. Renaming
+// requires the org's owner or admin (in the org of the caller's session) or a platform admin; setting max_users or
+// max_devices requires a platform admin. Lowering a quota below the current count removes no members or devices; it
+// only blocks new ones.
+func (s *Server) UpdateOrganization(ctx context.Context, req *organizationv1.UpdateOrganizationRequest) (*organizationv1.UpdateOrganizationResponse, error) {
+	if s.orgRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method UpdateOrganization not implemented")
+	}
+	orgID := strings.TrimSpace(req.GetOrgId())
+	if orgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	userID, err := s.requireOrgAccess(ctx, orgID, true)
+	if err != nil {
+		return nil, err
+	}
+	setsQuota := req.MaxUsers != nil || req.MaxDevices != nil
+	if setsQuota && !s.isPlatformAdmin(userID) {
+		return nil, status.Error(codes.PermissionDenied, "platform admin required to change quotas")
+	}
+	if req.GetMaxUsers() < 0 || req.GetMaxDevices() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_users and max_devices must not be negative")
+	}
+	o, err := s.orgRepo.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up organization")
+	}
+	if o == nil {
+		return nil, status.Error(codes.NotFound, "organization not found")
+	}
+	changes := map[string]interface{}{}
+	if name := strings.TrimSpace(req.GetName()); name != "" && name != o.Name {
+		o.Name = name
+		changes["name"] = name
+	}
+	if req.MaxUsers != nil {
+		o.MaxUsers = int(req.GetMaxUsers())
+		changes["max_users"] = o.MaxUsers
+	}
+	if req.MaxDevices != nil {
+		o.MaxDevices = int(req.GetMaxDevices())
+		changes["max_devices"] = o.MaxDevices
+	}
+	if len(changes) > 0 {
+		if err := s.orgRepo.UpdateOrganization(ctx, o); err != nil {
+			return nil, status.Error(codes.Internal, "failed to update organization")
+		}
+		if s.auditLogger != nil {
+			meta, _ := json.Marshal(changes)
+			s.auditLogger.LogEvent(ctx, orgID, userID, "organization_updated", "organization", string(meta))
+		}
+	}
+	return &organizationv1.UpdateOrganizationResponse{
+		Organization: domainOrgToProto(o),
+	}, nil
+}
+
+// ListOrganizations returns a page of all organizations, oldest first. Caller must be a platform admin.
 func (s *Server) ListOrganizations(ctx context.Context, req *organizationv1.ListOrganizationsRequest) (*organizationv1.ListOrganizationsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListOrganizations not implemented")
+	if s.orgRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListOrganizations not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.platformAdmins); err != nil {
+		return nil, err
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.orgRepo.ListOrganizations(ctx, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list organizations")
+	}
+	orgs := make([]*organizationv1.Organization, len(list))
+	for i, o := range list {
+		orgs[i] = domainOrgToProto(o)
+	}
+	nextToken := ""
+	if len(orgs) == int(pageSize) {
+		nextToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return &organizationv1.ListOrganizationsResponse{
+		Organizations: orgs,
+		Pagination:    &commonv1.PaginationResult{NextPageToken: nextToken},
+	}, nil
+}
+
+// requireOrgAccess returns the caller's user ID when they may read orgID (any member of it), or manage it when
+// manage is set (its owner or admin): in both cases in the org of the caller's session. Platform admins may access
+// every org.
+func (s *Server) requireOrgAccess(ctx context.Context, orgID string, manage bool) (string, error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return "", status.Error(codes.Unauthenticated, "user context required")
+	}
+	if s.isPlatformAdmin(userID) {
+		return userID, nil
+	}
+	if s.membershipRepo == nil {
+		return "", status.Error(codes.PermissionDenied, "not a member of the organization")
+	}
+	var ctxOrgID string
+	var err error
+	if manage {
+		ctxOrgID, _, err = rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	} else {
+		ctxOrgID, _, err = rbac.RequireOrgMember(ctx, s.membershipRepo)
+	}
+	if err != nil {
+		return "", err
+	}
+	if ctxOrgID != orgID {
+		return "", status.Error(codes.PermissionDenied, "org_id does not match context")
+	}
+	return userID, nil
+}
+
+func (s *Server) isPlatformAdmin(userID string) bool {
+	return s.platformAdmins != nil && s.platformAdmins.IsPlatformAdmin(userID)
 }
 
 // SuspendOrganization suspends an organization. TODO: implement.
@@ -134,6 +338,150 @@ func (s *Server) SuspendOrganization(ctx context.Context, req *organizationv1.Su
 	return nil, status.Error(codes.Unimplemented, "method SuspendOrganization not implemented")
 }
 
+// StartDomainVerification claims an email domain for the caller's org and returns the TXT record that proves
+// ownership. Caller must be org owner or admin. Starting again returns the existing claim, verified or not.
+func (s *Server) StartDomainVerification(ctx context.Context, req *organizationv1.StartDomainVerificationRequest) (*organizationv1.StartDomainVerificationResponse, error) {
+	if s.domains == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method StartDomainVerification not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.domains.Start(ctx, orgID, req.GetDomain(), userID)
+	if err != nil {
+		return nil, domainErr(err)
+	}
+	s.logDomainEvent(ctx, orgID, userID, "domain_verification_started", d)
+	return &organizationv1.StartDomainVerificationResponse{Domain: orgDomainToProto(d)}, nil
+}
+
+// VerifyDomain looks up the TXT record of a domain claimed by the caller's org and marks the domain verified when the
+// record holds the claim's token. Caller must be org owner or admin. Fails with FailedPrecondition while the record is
+// missing, and when another org has verified the domain.
+func (s *Server) VerifyDomain(ctx context.Context, req *organizationv1.VerifyDomainRequest) (*organizationv1.VerifyDomainResponse, error) {
+	if s.domains == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method VerifyDomain not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	wasVerified, err := s.domains.Verified(ctx, orgID, req.GetDomain())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up domain")
+	}
+	d, err := s.domains.Verify(ctx, orgID, req.GetDomain(), time.Now().UTC())
+	if err != nil {
+		return nil, domainErr(err)
+	}
+	if !wasVerified {
+		s.logDomainEvent(ctx, orgID, userID, "domain_verified", d)
+	}
+	return &organizationv1.VerifyDomainResponse{Domain: orgDomainToProto(d)}, nil
+}
+
+// ListDomains returns the caller's org's claimed domains, by name. Caller must be org owner or admin.
+func (s *Server) ListDomains(ctx context.Context, req *organizationv1.ListDomainsRequest) (*organizationv1.ListDomainsResponse, error) {
+	if s.domains == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListDomains not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.domains.List(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list domains")
+	}
+	out := make([]*organizationv1.OrgDomain, len(list))
+	for i, d := range list {
+		out[i] = orgDomainToProto(d)
+	}
+	return &organizationv1.ListDomainsResponse{Domains: out}, nil
+}
+
+func (s *Server) logDomainEvent(ctx context.Context, orgID, userID, action string, d *orgdomaindomain.OrgDomain) {
+	if s.auditLogger == nil {
+		return
+	}
+	meta, _ := json.Marshal(map[string]string{"domain": d.Domain})
+	s.auditLogger.LogEvent(ctx, orgID, userID, action, "organization", string(meta))
+}
+
+// domainErr maps orgdomain errors to gRPC status errors.
+func domainErr(err error) error {
+	switch {
+	case errors.Is(err, orgdomain.ErrInvalidDomain):
+		return status.Error(codes.InvalidArgument, "domain must be a DNS domain such as example.com")
+	case errors.Is(err, orgdomain.ErrDomainNotClaimed):
+		return status.Error(codes.NotFound, "domain verification not started")
+	case errors.Is(err, orgdomain.ErrRecordNotFound):
+		return status.Error(codes.FailedPrecondition, "verification TXT record not found")
+	case errors.Is(err, orgdomain.ErrDomainTaken):
+		return status.Error(codes.FailedPrecondition, "domain is verified by another organization")
+	case errors.Is(err, orgdomain.ErrLookupFailed):
+		return status.Error(codes.Unavailable, "DNS lookup failed")
+	default:
+		return status.Error(codes.Internal, "failed to verify domain")
+	}
+}
+
+func orgDomainToProto(d *orgdomaindomain.OrgDomain) *organizationv1.OrgDomain {
+	st := organizationv1.OrgDomainStatus_ORG_DOMAIN_STATUS_PENDING
+	if d.Verified() {
+		st = organizationv1.OrgDomainStatus_ORG_DOMAIN_STATUS_VERIFIED
+	}
+	out := &organizationv1.OrgDomain{
+		Domain:         d.Domain,
+		Status:         st,
+		TxtRecordName:  orgdomain.RecordName(d.Domain),
+		TxtRecordValue: orgdomain.RecordValue(d.Token),
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+	if d.VerifiedAt != nil {
+		out.VerifiedAt = timestamppb.New(*d.VerifiedAt)
+	}
+	if d.LastCheckedAt != nil {
+		out.LastCheckedAt = timestamppb.New(*d.LastCheckedAt)
+	}
+	return out
+}
+
+func setupToProto(s *orgsetupdomain.Setup) *organizationv1.SetupOrganizationResponse {
+	mfa := s.MFASettings
+	out := &organizationv1.SetupOrganizationResponse{
+		Organization: domainOrgToProto(s.Org),
+		Owner: &membershipv1.Member{
+			Id:        s.Owner.ID,
+			UserId:    s.Owner.UserID,
+			OrgId:     s.Owner.OrgID,
+			Role:      membershipv1.Role_ROLE_OWNER,
+			CreatedAt: timestamppb.New(s.Owner.CreatedAt),
+		},
+		MfaSettings: &organizationv1.MFASettings{
+			MfaRequiredForNewDevice: mfa.MFARequiredForNewDevice,
+			MfaRequiredForUntrusted: mfa.MFARequiredForUntrusted,
+			MfaRequiredAlways:       mfa.MFARequiredAlways,
+			RegisterTrustAfterMfa:   mfa.RegisterTrustAfterMFA,
+			TrustTtlDays:            int32(mfa.TrustTTLDays),
+		},
+		PolicyConfig:        orgpolicyconfighandler.ConfigToProto(s.PolicyConfig),
+		PolicyConfigVersion: s.PolicyConfigVersion,
+		Template:            s.Template,
+	}
+	if p := s.Policy; p != nil {
+		out.Policy = &policyv1.Policy{
+			Id:        p.ID,
+			OrgId:     p.OrgID,
+			Rules:     p.Rules,
+			Enabled:   p.Enabled,
+			CreatedAt: timestamppb.New(p.CreatedAt),
+		}
+	}
+	return out
+}
+
 func domainOrgToProto(o *organizationdomain.Org) *organizationv1.Organization {
 	if o == nil {
 		return nil
@@ -148,9 +496,13 @@ func domainOrgToProto(o *organizationdomain.Org) *organizationv1.Organization {
 		status = organizationv1.OrganizationStatus_ORGANIZATION_STATUS_UNSPECIFIED
 	}
 	return &organizationv1.Organization{
-		Id:        o.ID,
-		Name:      o.Name,
-		Status:    status,
-		CreatedAt: timestamppb.New(o.CreatedAt),
+		Id:         o.ID,
+		Name:       o.Name,
+		Status:     status,
+		CreatedAt:  timestamppb.New(o.CreatedAt),
+		DataRegion: o.DataRegion,
+		MaxUsers:   int32(o.MaxUsers),
+		MaxDevices: int32(o.MaxDevices),
+		Plan:       string(o.Plan),
 	}
 }
@@ -3,15 +3,27 @@ package handler
 import (
 	"context"
 	"errors"
+	"net"
+	"sort"
 	"testing"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
 	organizationv1 "zero-trust-control-plane/backend/api/generated/organization/v1"
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/license"
 	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
 	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	"zero-trust-control-plane/backend/internal/orgdomain"
+	orgdomaindomain "zero-trust-control-plane/backend/internal/orgdomain/domain"
+	"zero-trust-control-plane/backend/internal/orgsetup"
+	orgsetupdomain "zero-trust-control-plane/backend/internal/orgsetup/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 	userdomain "zero-trust-control-plane/backend/internal/user/domain"
 )
 
@@ -41,10 +53,35 @@ func (m *mockOrgRepo) CreateOrganization(ctx context.Context, o *organizationdom
 	return nil
 }
 
+func (m *mockOrgRepo) ListOrganizations(ctx context.Context, limit, offset int32) ([]*organizationdomain.Org, error) {
+	var all []*organizationdomain.Org
+	for _, o := range m.orgs {
+		all = append(all, o)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+	if int(offset) >= len(all) {
+		return nil, nil
+	}
+	all = all[offset:]
+	if int(limit) < len(all) {
+		all = all[:limit]
+	}
+	return all, nil
+}
+
 func (m *mockOrgRepo) UpdateOrganization(ctx context.Context, o *organizationdomain.Org) error {
+	if m.orgs != nil {
+		c := *o
+		m.orgs[o.ID] = &c
+	}
 	return nil
 }
 
+// platformAdminSet implements rbac.PlatformAdminChecker for tests.
+type platformAdminSet map[string]bool
+
+func (p platformAdminSet) IsPlatformAdmin(userID string) bool { return p[userID] }
+
 // mockUserRepo implements userrepo.Repository for tests.
 type mockUserRepo struct {
 	users map[string]*userdomain.User
@@ -66,6 +103,14 @@ func (m *mockUserRepo) Create(ctx context.Context, u *userdomain.User) error {
 	return nil
 }
 
+func (m *mockUserRepo) CreateWithIdentity(ctx context.Context, u *userdomain.User, i *identitydomain.Identity, seats license.SeatReserver) error {
+	return nil
+}
+
+func (m *mockUserRepo) CreateWithMembership(ctx context.Context, u *userdomain.User, mem *membershipdomain.Membership, d *devicedomain.Device, seats license.SeatReserver) error {
+	return nil
+}
+
 func (m *mockUserRepo) Update(ctx context.Context, u *userdomain.User) error {
 	return nil
 }
@@ -74,6 +119,18 @@ func (m *mockUserRepo) SetPhoneVerified(ctx context.Context, userID, phone strin
 	return nil
 }
 
+func (m *mockUserRepo) ChangePhone(ctx context.Context, userID, oldPhone, newPhone string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockUserRepo) ChangeEmail(ctx context.Context, userID, oldEmail, newEmail string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockUserRepo) ResetMFA(ctx context.Context, userID string) (bool, error) {
+	return false, nil
+}
+
 // mockMembershipRepo implements membershiprepo.Repository for tests.
 type mockMembershipRepo struct {
 	memberships map[string]*membershipdomain.Membership // key: userID:orgID
@@ -128,8 +185,8 @@ func TestGetOrganization_Success(t *testing.T) {
 	repo := &mockOrgRepo{
 		orgs: map[string]*organizationdomain.Org{"org-1": org},
 	}
-	srv := NewServer(repo, nil, nil)
-	ctx := context.Background()
+	srv := NewServer(repo, nil, nil, nil, nil, nil, platformAdminSet{"platform-admin": true}, nil)
+	ctx := interceptors.WithIdentity(context.Background(), "platform-admin", "", "")
 
 	resp, err := srv.GetOrganization(ctx, &organizationv1.GetOrganizationRequest{OrgId: "org-1"})
 	if err != nil {
@@ -153,8 +210,8 @@ func TestGetOrganization_NotFound(t *testing.T) {
 	repo := &mockOrgRepo{
 		orgs: make(map[string]*organizationdomain.Org),
 	}
-	srv := NewServer(repo, nil, nil)
-	ctx := context.Background()
+	srv := NewServer(repo, nil, nil, nil, nil, nil, platformAdminSet{"platform-admin": true}, nil)
+	ctx := interceptors.WithIdentity(context.Background(), "platform-admin", "", "")
 
 	_, err := srv.GetOrganization(ctx, &organizationv1.GetOrganizationRequest{OrgId: "nonexistent"})
 	if err == nil {
@@ -171,7 +228,7 @@ func TestGetOrganization_NotFound(t *testing.T) {
 
 func TestGetOrganization_InvalidOrgID(t *testing.T) {
 	repo := &mockOrgRepo{orgs: make(map[string]*organizationdomain.Org)}
-	srv := NewServer(repo, nil, nil)
+	srv := NewServer(repo, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -205,8 +262,8 @@ func TestGetOrganization_RepositoryError(t *testing.T) {
 		orgs:       make(map[string]*organizationdomain.Org),
 		getByIDErr: errors.New("database error"),
 	}
-	srv := NewServer(repo, nil, nil)
-	ctx := context.Background()
+	srv := NewServer(repo, nil, nil, nil, nil, nil, platformAdminSet{"platform-admin": true}, nil)
+	ctx := interceptors.WithIdentity(context.Background(), "platform-admin", "", "")
 
 	_, err := srv.GetOrganization(ctx, &organizationv1.GetOrganizationRequest{OrgId: "org-1"})
 	if err == nil {
@@ -222,7 +279,7 @@ func TestGetOrganization_RepositoryError(t *testing.T) {
 }
 
 func TestGetOrganization_NilRepo(t *testing.T) {
-	srv := NewServer(nil, nil, nil)
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetOrganization(ctx, &organizationv1.GetOrganizationRequest{OrgId: "org-1"})
@@ -249,8 +306,8 @@ func TestGetOrganization_SuspendedStatus(t *testing.T) {
 	repo := &mockOrgRepo{
 		orgs: map[string]*organizationdomain.Org{"org-1": org},
 	}
-	srv := NewServer(repo, nil, nil)
-	ctx := context.Background()
+	srv := NewServer(repo, nil, nil, nil, nil, nil, platformAdminSet{"platform-admin": true}, nil)
+	ctx := interceptors.WithIdentity(context.Background(), "platform-admin", "", "")
 
 	resp, err := srv.GetOrganization(ctx, &organizationv1.GetOrganizationRequest{OrgId: "org-1"})
 	if err != nil {
@@ -285,7 +342,7 @@ func TestCreateOrganization_Success(t *testing.T) {
 		memberships: make(map[string]*membershipdomain.Membership),
 	}
 
-	srv := NewServer(orgRepo, userRepo, membershipRepo)
+	srv := NewServer(orgRepo, userRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -340,12 +397,43 @@ func TestCreateOrganization_Success(t *testing.T) {
 	}
 }
 
+func TestCreateOrganization_DataRegion(t *testing.T) {
+	userID := "user-1"
+	orgRepo := &mockOrgRepo{
+		orgs:        make(map[string]*organizationdomain.Org),
+		createdOrgs: make(map[string]*organizationdomain.Org),
+	}
+	userRepo := &mockUserRepo{users: map[string]*userdomain.User{userID: {ID: userID, Status: userdomain.UserStatusActive}}}
+	membershipRepo := &mockMembershipRepo{memberships: make(map[string]*membershipdomain.Membership)}
+	srv := NewServer(orgRepo, userRepo, membershipRepo, []string{"eu"}, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{Name: "Acme", UserId: userID, DataRegion: "us"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unknown region: code = %v, want InvalidArgument", status.Code(err))
+	}
+	if len(orgRepo.createdOrgs) != 0 {
+		t.Error("organization should not be created for an unknown region")
+	}
+
+	resp, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{Name: "Acme", UserId: userID, DataRegion: "eu"})
+	if err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	if resp.Organization.DataRegion != "eu" {
+		t.Errorf("data_region = %q, want eu", resp.Organization.DataRegion)
+	}
+	if got := orgRepo.createdOrgs[resp.Organization.Id].DataRegion; got != "eu" {
+		t.Errorf("created org data region = %q, want eu", got)
+	}
+}
+
 func TestCreateOrganization_MissingName(t *testing.T) {
 	userID := "user-1"
 	userRepo := &mockUserRepo{
 		users: map[string]*userdomain.User{userID: {ID: userID}},
 	}
-	srv := NewServer(&mockOrgRepo{}, userRepo, &mockMembershipRepo{})
+	srv := NewServer(&mockOrgRepo{}, userRepo, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -375,7 +463,7 @@ func TestCreateOrganization_MissingName(t *testing.T) {
 }
 
 func TestCreateOrganization_MissingUserID(t *testing.T) {
-	srv := NewServer(&mockOrgRepo{}, &mockUserRepo{}, &mockMembershipRepo{})
+	srv := NewServer(&mockOrgRepo{}, &mockUserRepo{}, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -409,7 +497,7 @@ func TestCreateOrganization_UserNotFound(t *testing.T) {
 	userRepo := &mockUserRepo{
 		users: make(map[string]*userdomain.User),
 	}
-	srv := NewServer(&mockOrgRepo{}, userRepo, &mockMembershipRepo{})
+	srv := NewServer(&mockOrgRepo{}, userRepo, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -434,7 +522,7 @@ func TestCreateOrganization_UserRepoError(t *testing.T) {
 		users: make(map[string]*userdomain.User),
 		err:   errors.New("database error"),
 	}
-	srv := NewServer(&mockOrgRepo{}, userRepo, &mockMembershipRepo{})
+	srv := NewServer(&mockOrgRepo{}, userRepo, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -470,7 +558,7 @@ func TestCreateOrganization_OrgRepoError(t *testing.T) {
 	userRepo := &mockUserRepo{
 		users: map[string]*userdomain.User{userID: user},
 	}
-	srv := NewServer(orgRepo, userRepo, &mockMembershipRepo{})
+	srv := NewServer(orgRepo, userRepo, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -510,7 +598,7 @@ func TestCreateOrganization_MembershipRepoError(t *testing.T) {
 		memberships: make(map[string]*membershipdomain.Membership),
 		createErr:   errors.New("database error"),
 	}
-	srv := NewServer(orgRepo, userRepo, membershipRepo)
+	srv := NewServer(orgRepo, userRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -530,7 +618,7 @@ func TestCreateOrganization_MembershipRepoError(t *testing.T) {
 }
 
 func TestCreateOrganization_NilOrgRepo(t *testing.T) {
-	srv := NewServer(nil, &mockUserRepo{}, &mockMembershipRepo{})
+	srv := NewServer(nil, &mockUserRepo{}, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -550,7 +638,7 @@ func TestCreateOrganization_NilOrgRepo(t *testing.T) {
 }
 
 func TestCreateOrganization_NilUserRepo(t *testing.T) {
-	srv := NewServer(&mockOrgRepo{}, nil, &mockMembershipRepo{})
+	srv := NewServer(&mockOrgRepo{}, nil, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -579,7 +667,7 @@ func TestCreateOrganization_NilMembershipRepo(t *testing.T) {
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
-	srv := NewServer(&mockOrgRepo{}, &mockUserRepo{users: map[string]*userdomain.User{userID: user}}, nil)
+	srv := NewServer(&mockOrgRepo{}, &mockUserRepo{users: map[string]*userdomain.User{userID: user}}, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreateOrganization(ctx, &organizationv1.CreateOrganizationRequest{
@@ -598,27 +686,120 @@ func TestCreateOrganization_NilMembershipRepo(t *testing.T) {
 	}
 }
 
-func TestListOrganizations_Unimplemented(t *testing.T) {
-	repo := &mockOrgRepo{orgs: make(map[string]*organizationdomain.Org)}
-	srv := NewServer(repo, nil, nil)
-	ctx := context.Background()
+func TestGetOrganization_Access(t *testing.T) {
+	repo := &mockOrgRepo{orgs: map[string]*organizationdomain.Org{
+		"org-1": {ID: "org-1", Name: "One", MaxUsers: 10},
+		"org-2": {ID: "org-2", Name: "Two"},
+	}}
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}}
+	srv := NewServer(repo, nil, membershipRepo, nil, nil, nil, platformAdminSet{}, nil)
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-1")
+
+	resp, err := srv.GetOrganization(member, &organizationv1.GetOrganizationRequest{OrgId: "org-1"})
+	if err != nil {
+		t.Fatalf("member GetOrganization: %v", err)
+	}
+	if resp.Organization.MaxUsers != 10 {
+		t.Errorf("max_users = %d, want 10", resp.Organization.MaxUsers)
+	}
+	if _, err := srv.GetOrganization(member, &organizationv1.GetOrganizationRequest{OrgId: "org-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GetOrganization of another org = %v, want PermissionDenied", err)
+	}
+	outsider := interceptors.WithIdentity(context.Background(), "outsider", "org-1", "session-2")
+	if _, err := srv.GetOrganization(outsider, &organizationv1.GetOrganizationRequest{OrgId: "org-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("non-member GetOrganization = %v, want PermissionDenied", err)
+	}
+	if _, err := srv.GetOrganization(context.Background(), &organizationv1.GetOrganizationRequest{OrgId: "org-1"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("GetOrganization without identity = %v, want Unauthenticated", err)
+	}
+}
 
-	_, err := srv.ListOrganizations(ctx, &organizationv1.ListOrganizationsRequest{})
-	if err == nil {
-		t.Fatal("expected error for unimplemented method")
+func TestUpdateOrganization(t *testing.T) {
+	repo := &mockOrgRepo{orgs: map[string]*organizationdomain.Org{
+		"org-1": {ID: "org-1", Name: "One", Status: organizationdomain.OrgStatusActive},
+	}}
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}}
+	auditLogger := &mockAuditLogger{}
+	srv := NewServer(repo, nil, membershipRepo, nil, nil, nil, platformAdminSet{"platform-admin": true}, auditLogger)
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-2")
+	platformAdmin := interceptors.WithIdentity(context.Background(), "platform-admin", "", "")
+	limit := func(n int32) *int32 { return &n }
+
+	if _, err := srv.UpdateOrganization(member, &organizationv1.UpdateOrganizationRequest{OrgId: "org-1", Name: "Renamed"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member UpdateOrganization = %v, want PermissionDenied", err)
+	}
+	resp, err := srv.UpdateOrganization(admin, &organizationv1.UpdateOrganizationRequest{OrgId: "org-1", Name: " Renamed "})
+	if err != nil {
+		t.Fatalf("admin rename: %v", err)
 	}
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("error is not a gRPC status: %v", err)
+	if resp.Organization.Name != "Renamed" || repo.orgs["org-1"].Name != "Renamed" {
+		t.Errorf("name = %q (stored %q), want Renamed", resp.Organization.Name, repo.orgs["org-1"].Name)
 	}
-	if st.Code() != codes.Unimplemented {
-		t.Errorf("status code = %v, want %v", st.Code(), codes.Unimplemented)
+	if _, err := srv.UpdateOrganization(admin, &organizationv1.UpdateOrganizationRequest{OrgId: "org-1", MaxUsers: limit(100)}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("org admin setting a quota = %v, want PermissionDenied", err)
+	}
+	if _, err := srv.UpdateOrganization(platformAdmin, &organizationv1.UpdateOrganizationRequest{OrgId: "org-1", MaxDevices: limit(-1)}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative quota = %v, want InvalidArgument", err)
+	}
+	resp, err = srv.UpdateOrganization(platformAdmin, &organizationv1.UpdateOrganizationRequest{OrgId: "org-1", MaxUsers: limit(5), MaxDevices: limit(20)})
+	if err != nil {
+		t.Fatalf("platform admin quotas: %v", err)
+	}
+	if got := repo.orgs["org-1"]; got.MaxUsers != 5 || got.MaxDevices != 20 || got.Name != "Renamed" {
+		t.Errorf("stored org = %+v, want Renamed with quotas 5 and 20", got)
+	}
+	if resp.Organization.MaxUsers != 5 || resp.Organization.MaxDevices != 20 {
+		t.Errorf("response quotas = %d, %d; want 5, 20", resp.Organization.MaxUsers, resp.Organization.MaxDevices)
+	}
+	if _, err := srv.UpdateOrganization(platformAdmin, &organizationv1.UpdateOrganizationRequest{OrgId: "missing", Name: "X"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown org = %v, want NotFound", err)
+	}
+	if len(auditLogger.actions) != 2 || auditLogger.actions[0] != "organization_updated" || auditLogger.metadata[1] != `{"max_devices":20,"max_users":5}` {
+		t.Errorf("audit = %v %v, want two organization_updated events", auditLogger.actions, auditLogger.metadata)
+	}
+}
+
+func TestListOrganizations(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &mockOrgRepo{orgs: map[string]*organizationdomain.Org{
+		"org-1": {ID: "org-1", Name: "One", CreatedAt: base},
+		"org-2": {ID: "org-2", Name: "Two", CreatedAt: base.Add(time.Hour)},
+		"org-3": {ID: "org-3", Name: "Three", CreatedAt: base.Add(2 * time.Hour)},
+	}}
+	srv := NewServer(repo, nil, &mockMembershipRepo{}, nil, nil, nil, platformAdminSet{"platform-admin": true}, nil)
+	platformAdmin := interceptors.WithIdentity(context.Background(), "platform-admin", "", "")
+
+	if _, err := srv.ListOrganizations(interceptors.WithIdentity(context.Background(), "user-1", "org-1", "s"), &organizationv1.ListOrganizationsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("non-platform-admin ListOrganizations = %v, want PermissionDenied", err)
+	}
+	page, err := srv.ListOrganizations(platformAdmin, &organizationv1.ListOrganizationsRequest{Pagination: &commonv1.Pagination{PageSize: 2}})
+	if err != nil {
+		t.Fatalf("ListOrganizations: %v", err)
+	}
+	if len(page.Organizations) != 2 || page.Organizations[0].Id != "org-1" || page.Pagination.GetNextPageToken() != "2" {
+		t.Fatalf("first page = %v next %q, want org-1, org-2 and token 2", page.Organizations, page.Pagination.GetNextPageToken())
+	}
+	page, err = srv.ListOrganizations(platformAdmin, &organizationv1.ListOrganizationsRequest{Pagination: &commonv1.Pagination{PageSize: 2, PageToken: "2"}})
+	if err != nil {
+		t.Fatalf("ListOrganizations page 2: %v", err)
+	}
+	if len(page.Organizations) != 1 || page.Organizations[0].Id != "org-3" || page.Pagination.GetNextPageToken() != "" {
+		t.Errorf("second page = %v next %q, want only org-3", page.Organizations, page.Pagination.GetNextPageToken())
+	}
+	if _, err := NewServer(nil, nil, nil, nil, nil, nil, nil, nil).ListOrganizations(platformAdmin, &organizationv1.ListOrganizationsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil repo ListOrganizations = %v, want Unimplemented", err)
 	}
 }
 
 func TestSuspendOrganization_Unimplemented(t *testing.T) {
 	repo := &mockOrgRepo{orgs: make(map[string]*organizationdomain.Org)}
-	srv := NewServer(repo, nil, nil)
+	srv := NewServer(repo, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.SuspendOrganization(ctx, &organizationv1.SuspendOrganizationRequest{OrgId: "org-1"})
@@ -748,3 +929,223 @@ func TestDomainOrgToProto_AllFields(t *testing.T) {
 		t.Errorf("CreatedAt = %v, want %v", proto.CreatedAt.AsTime(), now)
 	}
 }
+
+// memDomainRepo implements orgdomain repository.Repository for tests.
+type memDomainRepo struct {
+	domains []*orgdomaindomain.OrgDomain
+}
+
+func (m *memDomainRepo) Create(ctx context.Context, d *orgdomaindomain.OrgDomain) (*orgdomaindomain.OrgDomain, error) {
+	if cur, _ := m.Get(ctx, d.OrgID, d.Domain); cur != nil {
+		return cur, nil
+	}
+	c := *d
+	m.domains = append(m.domains, &c)
+	return &c, nil
+}
+
+func (m *memDomainRepo) Get(ctx context.Context, orgID, domainName string) (*orgdomaindomain.OrgDomain, error) {
+	for _, d := range m.domains {
+		if d.OrgID == orgID && d.Domain == domainName {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memDomainRepo) GetVerified(ctx context.Context, domainName string) (*orgdomaindomain.OrgDomain, error) {
+	for _, d := range m.domains {
+		if d.Domain == domainName && d.Verified() {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memDomainRepo) List(ctx context.Context, orgID string) ([]*orgdomaindomain.OrgDomain, error) {
+	var out []*orgdomaindomain.OrgDomain
+	for _, d := range m.domains {
+		if d.OrgID == orgID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (m *memDomainRepo) MarkVerified(ctx context.Context, id string, at time.Time) (*orgdomaindomain.OrgDomain, error) {
+	for _, d := range m.domains {
+		if d.ID == id {
+			d.Status, d.VerifiedAt, d.LastCheckedAt = orgdomaindomain.StatusVerified, &at, &at
+			return d, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *memDomainRepo) RecordCheck(ctx context.Context, id string, at time.Time) error {
+	for _, d := range m.domains {
+		if d.ID == id {
+			d.LastCheckedAt = &at
+		}
+	}
+	return nil
+}
+
+type fakeTXTResolver map[string][]string
+
+func (f fakeTXTResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if r, ok := f[name]; ok {
+		return r, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+type mockAuditLogger struct {
+	actions  []string
+	metadata []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+	m.metadata = append(m.metadata, metadata)
+}
+
+func TestDomainRPCs_NilVerifier(t *testing.T) {
+	srv := NewServer(&mockOrgRepo{}, nil, &mockMembershipRepo{}, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+	if _, err := srv.StartDomainVerification(ctx, &organizationv1.StartDomainVerificationRequest{Domain: "example.com"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("StartDomainVerification = %v, want Unimplemented", err)
+	}
+	if _, err := srv.VerifyDomain(ctx, &organizationv1.VerifyDomainRequest{Domain: "example.com"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("VerifyDomain = %v, want Unimplemented", err)
+	}
+	if _, err := srv.ListDomains(ctx, &organizationv1.ListDomainsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ListDomains = %v, want Unimplemented", err)
+	}
+}
+
+func TestDomainVerification(t *testing.T) {
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}}
+	resolver := fakeTXTResolver{}
+	auditLogger := &mockAuditLogger{}
+	srv := NewServer(&mockOrgRepo{}, nil, membershipRepo, nil, orgdomain.NewVerifier(&memDomainRepo{}, resolver), nil, nil, auditLogger)
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-2")
+
+	if _, err := srv.StartDomainVerification(member, &organizationv1.StartDomainVerificationRequest{Domain: "example.com"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member StartDomainVerification = %v, want PermissionDenied", err)
+	}
+	if _, err := srv.StartDomainVerification(admin, &organizationv1.StartDomainVerificationRequest{Domain: "example"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid domain = %v, want InvalidArgument", err)
+	}
+	if _, err := srv.VerifyDomain(admin, &organizationv1.VerifyDomainRequest{Domain: "example.com"}); status.Code(err) != codes.NotFound {
+		t.Errorf("VerifyDomain before start = %v, want NotFound", err)
+	}
+
+	started, err := srv.StartDomainVerification(admin, &organizationv1.StartDomainVerificationRequest{Domain: "Example.com"})
+	if err != nil {
+		t.Fatalf("StartDomainVerification: %v", err)
+	}
+	d := started.GetDomain()
+	if d.GetDomain() != "example.com" || d.GetStatus() != organizationv1.OrgDomainStatus_ORG_DOMAIN_STATUS_PENDING || d.GetTxtRecordName() != "_ztcp-verification.example.com" {
+		t.Fatalf("domain = %+v", d)
+	}
+
+	if _, err := srv.VerifyDomain(admin, &organizationv1.VerifyDomainRequest{Domain: "example.com"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("VerifyDomain without record = %v, want FailedPrecondition", err)
+	}
+	resolver[d.GetTxtRecordName()] = []string{d.GetTxtRecordValue()}
+	verified, err := srv.VerifyDomain(admin, &organizationv1.VerifyDomainRequest{Domain: "example.com"})
+	if err != nil {
+		t.Fatalf("VerifyDomain: %v", err)
+	}
+	if verified.GetDomain().GetStatus() != organizationv1.OrgDomainStatus_ORG_DOMAIN_STATUS_VERIFIED || verified.GetDomain().GetVerifiedAt() == nil {
+		t.Errorf("domain = %+v, want verified", verified.GetDomain())
+	}
+	if _, err := srv.VerifyDomain(admin, &organizationv1.VerifyDomainRequest{Domain: "example.com"}); err != nil {
+		t.Errorf("second VerifyDomain: %v", err)
+	}
+
+	if len(auditLogger.actions) != 2 || auditLogger.actions[0] != "domain_verification_started" || auditLogger.actions[1] != "domain_verified" {
+		t.Errorf("audit actions = %v, want [domain_verification_started domain_verified]", auditLogger.actions)
+	}
+	if auditLogger.metadata[1] != `{"domain":"example.com"}` {
+		t.Errorf("audit metadata = %s", auditLogger.metadata[1])
+	}
+
+	list, err := srv.ListDomains(admin, &organizationv1.ListDomainsRequest{})
+	if err != nil || len(list.GetDomains()) != 1 {
+		t.Errorf("ListDomains = %v, %v; want one domain", list, err)
+	}
+	if _, err := srv.ListDomains(member, &organizationv1.ListDomainsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member ListDomains = %v, want PermissionDenied", err)
+	}
+}
+
+type memSetupRepo struct {
+	err error
+}
+
+func (m *memSetupRepo) Create(ctx context.Context, s *orgsetupdomain.Setup) error {
+	if m.err != nil {
+		return m.err
+	}
+	s.PolicyConfigVersion = 1
+	return nil
+}
+
+func TestSetupOrganization(t *testing.T) {
+	userRepo := &mockUserRepo{users: map[string]*userdomain.User{"user-1": {ID: "user-1"}}}
+	auditLogger := &mockAuditLogger{}
+	srv := NewServer(&mockOrgRepo{}, userRepo, &mockMembershipRepo{}, []string{"eu"}, nil, orgsetup.NewSetuper(&memSetupRepo{}, userRepo, nil), nil, auditLogger)
+	ctx := context.Background()
+
+	resp, err := srv.SetupOrganization(ctx, &organizationv1.SetupOrganizationRequest{Name: "Acme", UserId: "user-1", DataRegion: "eu"})
+	if err != nil {
+		t.Fatalf("SetupOrganization: %v", err)
+	}
+	orgID := resp.GetOrganization().GetId()
+	if orgID == "" || resp.GetOrganization().GetDataRegion() != "eu" || resp.GetTemplate() != orgsetup.DefaultTemplate {
+		t.Errorf("organization = %+v, template %q", resp.GetOrganization(), resp.GetTemplate())
+	}
+	if resp.GetOwner().GetUserId() != "user-1" || resp.GetOwner().GetOrgId() != orgID || resp.GetOwner().GetRole() != membershipv1.Role_ROLE_OWNER {
+		t.Errorf("owner = %+v", resp.GetOwner())
+	}
+	if resp.GetPolicy().GetOrgId() != orgID || !resp.GetPolicy().GetEnabled() || resp.GetPolicyConfig().GetAuthMfa() == nil || resp.GetPolicyConfigVersion() != 1 {
+		t.Errorf("policy = %+v, policy config = %+v (version %d)", resp.GetPolicy(), resp.GetPolicyConfig(), resp.GetPolicyConfigVersion())
+	}
+	if !resp.GetMfaSettings().GetMfaRequiredForNewDevice() || resp.GetMfaSettings().GetTrustTtlDays() != 30 {
+		t.Errorf("MFA settings = %+v", resp.GetMfaSettings())
+	}
+	if len(auditLogger.actions) != 1 || auditLogger.actions[0] != "organization_setup" {
+		t.Errorf("audited %v, want organization_setup", auditLogger.actions)
+	}
+
+	tests := []struct {
+		name string
+		req  *organizationv1.SetupOrganizationRequest
+		code codes.Code
+	}{
+		{"no name", &organizationv1.SetupOrganizationRequest{UserId: "user-1"}, codes.InvalidArgument},
+		{"no user", &organizationv1.SetupOrganizationRequest{Name: "Acme"}, codes.InvalidArgument},
+		{"unknown region", &organizationv1.SetupOrganizationRequest{Name: "Acme", UserId: "user-1", DataRegion: "us"}, codes.InvalidArgument},
+		{"unknown template", &organizationv1.SetupOrganizationRequest{Name: "Acme", UserId: "user-1", Template: "lax"}, codes.InvalidArgument},
+		{"unknown user", &organizationv1.SetupOrganizationRequest{Name: "Acme", UserId: "user-2"}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		if _, err := srv.SetupOrganization(ctx, tt.req); status.Code(err) != tt.code {
+			t.Errorf("%s: err = %v, want %s", tt.name, err, tt.code)
+		}
+	}
+
+	failing := NewServer(nil, nil, nil, nil, nil, orgsetup.NewSetuper(&memSetupRepo{err: errors.New("insert failed")}, userRepo, nil), nil, nil)
+	if _, err := failing.SetupOrganization(ctx, &organizationv1.SetupOrganizationRequest{Name: "Acme", UserId: "user-1"}); status.Code(err) != codes.Internal {
+		t.Errorf("repository failure = %v, want Internal", err)
+	}
+	if _, err := NewServer(nil, nil, nil, nil, nil, nil, nil, nil).SetupOrganization(ctx, &organizationv1.SetupOrganizationRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("without setup = %v, want Unimplemented", err)
+	}
+}
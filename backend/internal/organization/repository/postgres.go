@@ -31,10 +31,23 @@ func (r *PostgresRepository) GetOrganizationByID(ctx context.Context, id string)
 	return genOrgToDomain(&o), nil
 }
 
+// ListOrganizations returns a page of organizations, oldest first, without the _system sentinel org.
+func (r *PostgresRepository) ListOrganizations(ctx context.Context, limit, offset int32) ([]*domain.Org, error) {
+	rows, err := r.queries.ListOrganizations(ctx, gen.ListOrganizationsParams{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Org, len(rows))
+	for i := range rows {
+		out[i] = genOrgToDomain(&rows[i])
+	}
+	return out, nil
+}
+
 // CreateOrganization persists the organization to the database. The organization must have ID set.
 func (r *PostgresRepository) CreateOrganization(ctx context.Context, o *domain.Org) error {
 	_, err := r.queries.CreateOrganization(ctx, gen.CreateOrganizationParams{
-		ID: o.ID, Name: o.Name, Status: gen.OrgStatus(o.Status), CreatedAt: o.CreatedAt,
+		ID: o.ID, Name: o.Name, Status: gen.OrgStatus(o.Status), CreatedAt: o.CreatedAt, DataRegion: o.DataRegion,
 	})
 	return err
 }
@@ -42,7 +55,8 @@ func (r *PostgresRepository) CreateOrganization(ctx context.Context, o *domain.O
 // UpdateOrganization updates the existing organization record in the database. Returns an error if the update fails.
 func (r *PostgresRepository) UpdateOrganization(ctx context.Context, o *domain.Org) error {
 	_, err := r.queries.UpdateOrganization(ctx, gen.UpdateOrganizationParams{
-		ID: o.ID, Name: o.Name, Status: gen.OrgStatus(o.Status),
+		ID: o.ID, Name: o.Name, Status: gen.OrgStatus(o.Status), MaxUsers: int32(o.MaxUsers), MaxDevices: int32(o.MaxDevices),
+		Plan: string(o.Plan),
 	})
 	return err
 }
@@ -53,6 +67,7 @@ func genOrgToDomain(o *gen.Organization) *domain.Org {
 	}
 	return &domain.Org{
 		ID: o.ID, Name: o.Name,
-		Status: domain.OrgStatus(o.Status), CreatedAt: o.CreatedAt,
+		Status: domain.OrgStatus(o.Status), CreatedAt: o.CreatedAt, DataRegion: o.DataRegion,
+		MaxUsers: int(o.MaxUsers), MaxDevices: int(o.MaxDevices), Plan: domain.Plan(o.Plan),
 	}
 }
@@ -9,6 +9,7 @@ import (
 // Repository defines persistence for organizations.
 type Repository interface {
 	GetOrganizationByID(ctx context.Context, id string) (*domain.Org, error)
+	ListOrganizations(ctx context.Context, limit, offset int32) ([]*domain.Org, error)
 	CreateOrganization(ctx context.Context, o *domain.Org) error
 	UpdateOrganization(ctx context.Context, o *domain.Org) error
 }
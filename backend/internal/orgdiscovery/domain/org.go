@@ -0,0 +1,8 @@
+// Package domain holds the types of org discovery: finding the orgs an email can sign in to before sign-in.
+package domain
+
+// Org is an org offered at sign-in.
+type Org struct {
+	ID   string
+	Name string
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/orgdiscovery/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an org discovery repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Get returns the active org with the given ID, or nil if not found or suspended.
+func (r *PostgresRepository) Get(ctx context.Context, orgID string) (*domain.Org, error) {
+	row, err := r.queries.GetDiscoverableOrg(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Org{ID: row.ID, Name: row.Name}, nil
+}
+
+// ListByUser returns the active orgs the user is a member of, by name.
+func (r *PostgresRepository) ListByUser(ctx context.Context, userID string) ([]*domain.Org, error) {
+	rows, err := r.queries.ListDiscoverableOrgsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Org, len(rows))
+	for i, row := range rows {
+		out[i] = &domain.Org{ID: row.ID, Name: row.Name}
+	}
+	return out, nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/orgdiscovery/domain"
+)
+
+// Repository reads the orgs offered by org discovery. Suspended orgs are never returned.
+type Repository interface {
+	// Get returns the org, or nil if it does not exist or is suspended.
+	Get(ctx context.Context, orgID string) (*domain.Org, error)
+	// ListByUser returns the orgs the user is a member of, by name.
+	ListByUser(ctx context.Context, userID string) ([]*domain.Org, error)
+}
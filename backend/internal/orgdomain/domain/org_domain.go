@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Status is the verification status of an org domain.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusVerified Status = "verified"
+)
+
+// OrgDomain is an email domain claimed by an org. The org proves it owns the domain by publishing Token in a DNS TXT
+// record; until then the claim is pending.
+type OrgDomain struct {
+	ID            string
+	OrgID         string
+	Domain        string // lower case, no trailing dot
+	Token         string
+	Status        Status
+	CreatedBy     string
+	CreatedAt     time.Time
+	VerifiedAt    *time.Time
+	LastCheckedAt *time.Time // last verification attempt, successful or not
+}
+
+// Verified reports whether the org has proven it owns the domain.
+func (d *OrgDomain) Verified() bool {
+	return d.Status == StatusVerified
+}
@@ -0,0 +1,216 @@
+// Package orgdomain verifies that an org owns an email domain. The org claims the domain, publishes the issued token
+// in a DNS TXT record, and asks for the record to be checked. Features that act on every address of a domain, such as
+// auto-join by email domain or SSO configuration for a domain, must only be enabled for a verified domain (see
+// Verifier.Verified).
+package orgdomain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/orgdomain/domain"
+	"zero-trust-control-plane/backend/internal/orgdomain/repository"
+)
+
+const (
+	// RecordPrefix is prepended to the domain to form the name of the TXT record to publish.
+	RecordPrefix = "_ztcp-verification."
+	// RecordValuePrefix is prepended to the token to form the value of the TXT record.
+	RecordValuePrefix = "ztcp-domain-verification="
+	// LookupTimeout bounds the DNS lookup of a verification.
+	LookupTimeout = 5 * time.Second
+)
+
+var (
+	// ErrInvalidDomain is returned for a name that is not a valid DNS domain with at least two labels.
+	ErrInvalidDomain = errors.New("orgdomain: invalid domain")
+	// ErrDomainNotClaimed is returned by Verify for a domain the org has not started verifying.
+	ErrDomainNotClaimed = errors.New("orgdomain: domain verification not started")
+	// ErrDomainTaken is returned by Verify when another org has already verified the domain.
+	ErrDomainTaken = repository.ErrDomainTaken
+	// ErrRecordNotFound is returned by Verify when DNS has no TXT record with the org's token.
+	ErrRecordNotFound = errors.New("orgdomain: verification record not found")
+	// ErrLookupFailed is returned by Verify when the DNS lookup fails for another reason than a missing record.
+	ErrLookupFailed = errors.New("orgdomain: DNS lookup failed")
+)
+
+// TXTResolver looks up TXT records. *net.Resolver implements it.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Verifier claims and verifies org domains.
+type Verifier struct {
+	repo     repository.Repository
+	resolver TXTResolver
+}
+
+// NewVerifier returns a verifier backed by repo that checks records with resolver. A nil resolver uses
+// net.DefaultResolver.
+func NewVerifier(repo repository.Repository, resolver TXTResolver) *Verifier {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Verifier{repo: repo, resolver: resolver}
+}
+
+// Start claims domainName for orgID on behalf of by and returns the claim, whose token the org publishes with
+// RecordName and RecordValue. Starting again for a domain the org has already claimed returns the existing claim, so
+// the token stays the same and a verified domain stays verified.
+func (v *Verifier) Start(ctx context.Context, orgID, domainName, by string) (*domain.OrgDomain, error) {
+	name, err := NormalizeDomain(domainName)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	return v.repo.Create(ctx, &domain.OrgDomain{
+		ID:        uuid.New().String(),
+		OrgID:     orgID,
+		Domain:    name,
+		Token:     token,
+		Status:    domain.StatusPending,
+		CreatedBy: by,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// Verify looks up the TXT record of the org's claim of domainName and marks the domain verified when the record holds
+// the claim's token. A domain already verified is returned as is, without a lookup. Verification is not repeated
+// later: removing the record afterwards does not unverify the domain.
+func (v *Verifier) Verify(ctx context.Context, orgID, domainName string, now time.Time) (*domain.OrgDomain, error) {
+	name, err := NormalizeDomain(domainName)
+	if err != nil {
+		return nil, err
+	}
+	d, err := v.repo.Get(ctx, orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrDomainNotClaimed
+	}
+	if d.Verified() {
+		return d, nil
+	}
+	owner, err := v.repo.GetVerified(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if owner != nil {
+		return nil, ErrDomainTaken
+	}
+	found, err := v.lookup(ctx, d)
+	if err != nil || !found {
+		if recErr := v.repo.RecordCheck(ctx, d.ID, now); recErr != nil {
+			return nil, recErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrRecordNotFound
+	}
+	return v.repo.MarkVerified(ctx, d.ID, now)
+}
+
+// List returns the org's claimed domains, by name.
+func (v *Verifier) List(ctx context.Context, orgID string) ([]*domain.OrgDomain, error) {
+	return v.repo.List(ctx, orgID)
+}
+
+// Verified reports whether orgID has verified domainName. This is the gate for features that act on every address of
+// a domain: they must refuse to be enabled for a domain that is not verified. An invalid name is never verified.
+func (v *Verifier) Verified(ctx context.Context, orgID, domainName string) (bool, error) {
+	name, err := NormalizeDomain(domainName)
+	if err != nil {
+		return false, nil
+	}
+	d, err := v.repo.Get(ctx, orgID, name)
+	if err != nil {
+		return false, err
+	}
+	return d != nil && d.Verified(), nil
+}
+
+// lookup reports whether the TXT records of d's record name include d's token.
+func (v *Verifier) lookup(ctx context.Context, d *domain.OrgDomain) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, LookupTimeout)
+	defer cancel()
+	records, err := v.resolver.LookupTXT(ctx, RecordName(d.Domain))
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+		return false, ErrLookupFailed
+	}
+	want := RecordValue(d.Token)
+	for _, r := range records {
+		if strings.TrimSpace(r) == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RecordName returns the name of the TXT record that verifies domainName.
+func RecordName(domainName string) string {
+	return RecordPrefix + domainName
+}
+
+// RecordValue returns the TXT record value that proves ownership with token.
+func RecordValue(token string) string {
+	return RecordValuePrefix + token
+}
+
+// NormalizeDomain returns s lower-cased and without a trailing dot, or ErrInvalidDomain if it is not a DNS domain
+// with at least two labels. IP addresses, wildcards and email addresses are rejected.
+func NormalizeDomain(s string) (string, error) {
+	name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), ".")
+	if name == "" || len(name) > 253 {
+		return "", ErrInvalidDomain
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return "", ErrInvalidDomain
+	}
+	for _, l := range labels {
+		if !validLabel(l) {
+			return "", ErrInvalidDomain
+		}
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return "", ErrInvalidDomain
+	}
+	return name, nil
+}
+
+func validLabel(l string) bool {
+	if l == "" || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(l); i++ {
+		c := l[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
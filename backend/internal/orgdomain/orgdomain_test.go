@@ -0,0 +1,196 @@
+package orgdomain
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/orgdomain/domain"
+)
+
+type memRepo struct {
+	domains []*domain.OrgDomain
+}
+
+func (m *memRepo) Create(ctx context.Context, d *domain.OrgDomain) (*domain.OrgDomain, error) {
+	if cur, _ := m.Get(ctx, d.OrgID, d.Domain); cur != nil {
+		return cur, nil
+	}
+	c := *d
+	m.domains = append(m.domains, &c)
+	return &c, nil
+}
+
+func (m *memRepo) Get(ctx context.Context, orgID, domainName string) (*domain.OrgDomain, error) {
+	for _, d := range m.domains {
+		if d.OrgID == orgID && d.Domain == domainName {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRepo) GetVerified(ctx context.Context, domainName string) (*domain.OrgDomain, error) {
+	for _, d := range m.domains {
+		if d.Domain == domainName && d.Verified() {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRepo) List(ctx context.Context, orgID string) ([]*domain.OrgDomain, error) {
+	var out []*domain.OrgDomain
+	for _, d := range m.domains {
+		if d.OrgID == orgID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (m *memRepo) MarkVerified(ctx context.Context, id string, at time.Time) (*domain.OrgDomain, error) {
+	for _, d := range m.domains {
+		if d.ID == id {
+			d.Status, d.VerifiedAt, d.LastCheckedAt = domain.StatusVerified, &at, &at
+			return d, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *memRepo) RecordCheck(ctx context.Context, id string, at time.Time) error {
+	for _, d := range m.domains {
+		if d.ID == id {
+			d.LastCheckedAt = &at
+		}
+	}
+	return nil
+}
+
+type fakeResolver struct {
+	records map[string][]string
+	err     error
+	lookups []string
+}
+
+func (f *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	f.lookups = append(f.lookups, name)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if r, ok := f.records[name]; ok {
+		return r, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	valid := map[string]string{
+		"Example.COM":         "example.com",
+		" mail.example.com. ": "mail.example.com",
+		"xn--bcher-kva.de":    "xn--bcher-kva.de",
+	}
+	for in, want := range valid {
+		if got, err := NormalizeDomain(in); err != nil || got != want {
+			t.Errorf("NormalizeDomain(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "localhost", "*.example.com", "a@example.com", "-a.example.com", "example..com", "10.0.0.1", "exa mple.com"} {
+		if _, err := NormalizeDomain(in); !errors.Is(err, ErrInvalidDomain) {
+			t.Errorf("NormalizeDomain(%q) = %v, want ErrInvalidDomain", in, err)
+		}
+	}
+}
+
+func TestVerifier_StartAndVerify(t *testing.T) {
+	repo := &memRepo{}
+	resolver := &fakeResolver{records: map[string][]string{}}
+	v := NewVerifier(repo, resolver)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	d, err := v.Start(ctx, "org-1", "Example.com", "admin-1")
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if d.Domain != "example.com" || d.Status != domain.StatusPending || d.Token == "" || d.CreatedBy != "admin-1" {
+		t.Fatalf("claim = %+v", d)
+	}
+	again, err := v.Start(ctx, "org-1", "example.com", "admin-2")
+	if err != nil || again.Token != d.Token {
+		t.Errorf("second Start = %+v, %v; want the same token", again, err)
+	}
+
+	if _, err := v.Verify(ctx, "org-1", "example.com", now); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("Verify without record = %v, want ErrRecordNotFound", err)
+	}
+	if d.LastCheckedAt == nil || d.Verified() {
+		t.Errorf("after failed check = %+v, want checked and pending", d)
+	}
+	if ok, _ := v.Verified(ctx, "org-1", "example.com"); ok {
+		t.Error("Verified before record = true")
+	}
+
+	resolver.records["_ztcp-verification.example.com"] = []string{"v=spf1 -all", RecordValue(d.Token)}
+	d, err = v.Verify(ctx, "org-1", "example.com", now)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !d.Verified() || d.VerifiedAt == nil {
+		t.Errorf("claim = %+v, want verified", d)
+	}
+	if ok, err := v.Verified(ctx, "org-1", "EXAMPLE.com"); err != nil || !ok {
+		t.Errorf("Verified = %v, %v; want true", ok, err)
+	}
+	if ok, _ := v.Verified(ctx, "org-2", "example.com"); ok {
+		t.Error("Verified for another org = true")
+	}
+
+	lookups := len(resolver.lookups)
+	if _, err := v.Verify(ctx, "org-1", "example.com", now); err != nil || len(resolver.lookups) != lookups {
+		t.Errorf("re-Verify = %v with %d lookups, want no lookup", err, len(resolver.lookups)-lookups)
+	}
+}
+
+func TestVerifier_VerifyErrors(t *testing.T) {
+	repo := &memRepo{}
+	resolver := &fakeResolver{records: map[string][]string{}}
+	v := NewVerifier(repo, resolver)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	if _, err := v.Verify(ctx, "org-1", "example.com", now); !errors.Is(err, ErrDomainNotClaimed) {
+		t.Errorf("unclaimed = %v, want ErrDomainNotClaimed", err)
+	}
+	if _, err := v.Start(ctx, "org-1", "not a domain", "admin-1"); !errors.Is(err, ErrInvalidDomain) {
+		t.Errorf("invalid = %v, want ErrInvalidDomain", err)
+	}
+
+	first, _ := v.Start(ctx, "org-1", "example.com", "admin-1")
+	second, _ := v.Start(ctx, "org-2", "example.com", "admin-2")
+	if first.Token == second.Token {
+		t.Fatal("two orgs got the same token")
+	}
+	resolver.records["_ztcp-verification.example.com"] = []string{RecordValue(first.Token)}
+	if _, err := v.Verify(ctx, "org-2", "example.com", now); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("other org's token = %v, want ErrRecordNotFound", err)
+	}
+	if _, err := v.Verify(ctx, "org-1", "example.com", now); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	resolver.records["_ztcp-verification.example.com"] = append(resolver.records["_ztcp-verification.example.com"], RecordValue(second.Token))
+	if _, err := v.Verify(ctx, "org-2", "example.com", now); !errors.Is(err, ErrDomainTaken) {
+		t.Errorf("domain verified by another org = %v, want ErrDomainTaken", err)
+	}
+
+	v = NewVerifier(repo, &fakeResolver{err: errors.New("timeout")})
+	if _, err := v.Start(ctx, "org-1", "example.org", "admin-1"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if _, err := v.Verify(ctx, "org-1", "example.org", now); !errors.Is(err, ErrLookupFailed) {
+		t.Errorf("lookup error = %v, want ErrLookupFailed", err)
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/orgdomain/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an org domain repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create claims the domain for the org, or returns the org's existing claim.
+func (r *PostgresRepository) Create(ctx context.Context, d *domain.OrgDomain) (*domain.OrgDomain, error) {
+	row, err := r.queries.CreateOrgDomain(ctx, gen.CreateOrgDomainParams{
+		ID:        d.ID,
+		OrgID:     d.OrgID,
+		Domain:    d.Domain,
+		Token:     d.Token,
+		CreatedBy: d.CreatedBy,
+		CreatedAt: d.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return genOrgDomainToDomain(&row), nil
+}
+
+// Get returns the org's claim of the domain, or nil if it has none.
+func (r *PostgresRepository) Get(ctx context.Context, orgID, domainName string) (*domain.OrgDomain, error) {
+	row, err := r.queries.GetOrgDomain(ctx, gen.GetOrgDomainParams{OrgID: orgID, Domain: domainName})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genOrgDomainToDomain(&row), nil
+}
+
+// GetVerified returns the claim that verified the domain, or nil if no org has verified it.
+func (r *PostgresRepository) GetVerified(ctx context.Context, domainName string) (*domain.OrgDomain, error) {
+	row, err := r.queries.GetVerifiedOrgDomain(ctx, domainName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genOrgDomainToDomain(&row), nil
+}
+
+// List returns the org's domains, by name.
+func (r *PostgresRepository) List(ctx context.Context, orgID string) ([]*domain.OrgDomain, error) {
+	rows, err := r.queries.ListOrgDomains(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.OrgDomain, len(rows))
+	for i := range rows {
+		out[i] = genOrgDomainToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+// MarkVerified marks the claim verified. The partial unique index on verified domains makes it fail with
+// ErrDomainTaken when another org verified the domain first.
+func (r *PostgresRepository) MarkVerified(ctx context.Context, id string, at time.Time) (*domain.OrgDomain, error) {
+	row, err := r.queries.MarkOrgDomainVerified(ctx, gen.MarkOrgDomainVerifiedParams{
+		ID:         id,
+		VerifiedAt: sql.NullTime{Time: at, Valid: true},
+	})
+	if err != nil {
+		if db.IsUniqueViolation(err) {
+			return nil, ErrDomainTaken
+		}
+		return nil, err
+	}
+	return genOrgDomainToDomain(&row), nil
+}
+
+// RecordCheck records a failed verification attempt.
+func (r *PostgresRepository) RecordCheck(ctx context.Context, id string, at time.Time) error {
+	return r.queries.RecordOrgDomainCheck(ctx, gen.RecordOrgDomainCheckParams{
+		ID:            id,
+		LastCheckedAt: sql.NullTime{Time: at, Valid: true},
+	})
+}
+
+func genOrgDomainToDomain(row *gen.OrgDomain) *domain.OrgDomain {
+	d := &domain.OrgDomain{
+		ID:        row.ID,
+		OrgID:     row.OrgID,
+		Domain:    row.Domain,
+		Token:     row.Token,
+		Status:    domain.Status(row.Status),
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.VerifiedAt.Valid {
+		t := row.VerifiedAt.Time
+		d.VerifiedAt = &t
+	}
+	if row.LastCheckedAt.Valid {
+		t := row.LastCheckedAt.Time
+		d.LastCheckedAt = &t
+	}
+	return d
+}
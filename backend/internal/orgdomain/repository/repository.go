@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/orgdomain/domain"
+)
+
+// ErrDomainTaken is returned by MarkVerified when another org has verified the domain.
+var ErrDomainTaken = errors.New("orgdomain: domain is verified by another org")
+
+// Repository persists org domains.
+type Repository interface {
+	// Create claims d.Domain for d.OrgID. If the org has already claimed the domain, the existing claim is returned
+	// unchanged, with its token and status.
+	Create(ctx context.Context, d *domain.OrgDomain) (*domain.OrgDomain, error)
+	// Get returns the org's claim of the domain, or nil if it has none.
+	Get(ctx context.Context, orgID, domainName string) (*domain.OrgDomain, error)
+	// GetVerified returns the claim that verified the domain, of any org, or nil if no org has verified it.
+	GetVerified(ctx context.Context, domainName string) (*domain.OrgDomain, error)
+	// List returns the org's domains, by name.
+	List(ctx context.Context, orgID string) ([]*domain.OrgDomain, error)
+	// MarkVerified marks the claim verified at at. It returns ErrDomainTaken when another org verified the domain
+	// first.
+	MarkVerified(ctx context.Context, id string, at time.Time) (*domain.OrgDomain, error)
+	// RecordCheck records a verification attempt at at that did not find the record.
+	RecordCheck(ctx context.Context, id string, at time.Time) error
+}
@@ -0,0 +1,46 @@
+package orgpolicyconfig
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// ConfigGetter loads an org's policy config.
+type ConfigGetter interface {
+	GetByOrgID(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, error)
+}
+
+// TokenClaimsProvider is a security.ClaimsProvider that adds the org's token_claims section (custom audiences and
+// static claims) to access tokens.
+type TokenClaimsProvider struct {
+	repo ConfigGetter
+}
+
+// NewTokenClaimsProvider returns a provider reading token_claims from repo.
+func NewTokenClaimsProvider(repo ConfigGetter) *TokenClaimsProvider {
+	return &TokenClaimsProvider{repo: repo}
+}
+
+// AccessTokenClaims returns the org's configured audiences and claims, or nil when none are set.
+func (p *TokenClaimsProvider) AccessTokenClaims(ctx context.Context, userID, orgID string) (*security.CustomClaims, error) {
+	if orgID == "" {
+		return nil, nil
+	}
+	cfg, err := p.repo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil || cfg.TokenClaims == nil || (len(cfg.TokenClaims.Audiences) == 0 && len(cfg.TokenClaims.Claims) == 0) {
+		return nil, nil
+	}
+	out := &security.CustomClaims{Audiences: cfg.TokenClaims.Audiences}
+	if len(cfg.TokenClaims.Claims) > 0 {
+		out.Claims = make(map[string]any, len(cfg.TokenClaims.Claims))
+		for k, v := range cfg.TokenClaims.Claims {
+			out.Claims[k] = v
+		}
+	}
+	return out, nil
+}
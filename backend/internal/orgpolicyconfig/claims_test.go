@@ -0,0 +1,33 @@
+package orgpolicyconfig
+
+import (
+	"context"
+	"testing"
+
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+)
+
+type staticConfigGetter map[string]*domain.OrgPolicyConfig
+
+func (g staticConfigGetter) GetByOrgID(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, error) {
+	return g[orgID], nil
+}
+
+func TestTokenClaimsProvider(t *testing.T) {
+	p := NewTokenClaimsProvider(staticConfigGetter{
+		"org-1": {TokenClaims: &domain.TokenClaims{Audiences: []string{"payroll"}, Claims: map[string]string{"tier": "gold"}}},
+		"org-2": {},
+	})
+	cc, err := p.AccessTokenClaims(context.Background(), "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("AccessTokenClaims: %v", err)
+	}
+	if cc == nil || len(cc.Audiences) != 1 || cc.Audiences[0] != "payroll" || cc.Claims["tier"] != "gold" {
+		t.Errorf("claims = %+v", cc)
+	}
+	for _, orgID := range []string{"org-2", "org-unknown", ""} {
+		if cc, err := p.AccessTokenClaims(context.Background(), "user-1", orgID); err != nil || cc != nil {
+			t.Errorf("org %q: claims = %+v, err = %v, want nil", orgID, cc, err)
+		}
+	}
+}
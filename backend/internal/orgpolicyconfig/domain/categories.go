@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Size limits for access control lists; UpdateOrgPolicyConfig and BulkUpdateDomains reject configs above them.
+const (
+	MaxDomainsPerList     = 10000
+	MaxCustomCategories   = 100
+	MaxDomainsPerCategory = 10000
+	maxDomainLength       = 253
+	maxCategoryNameLength = 64
+)
+
+// URLCategory is a named list of domains (e.g. "social"). A domain matches itself and its subdomains.
+type URLCategory struct {
+	Name    string   `json:"name"`
+	Domains []string `json:"domains"`
+}
+
+// managedCategories are the built-in category lists available to every org.
+var managedCategories = map[string][]string{
+	"social": {
+		"facebook.com", "instagram.com", "linkedin.com", "pinterest.com", "reddit.com",
+		"snapchat.com", "threads.net", "tiktok.com", "twitter.com", "x.com",
+	},
+	"file_sharing": {
+		"box.com", "dropbox.com", "mediafire.com", "mega.nz", "pcloud.com",
+		"sendspace.com", "wetransfer.com",
+	},
+	"webmail": {
+		"mail.google.com", "mail.yahoo.com", "outlook.live.com", "proton.me", "zoho.com",
+	},
+	"streaming": {
+		"disneyplus.com", "hulu.com", "netflix.com", "primevideo.com", "spotify.com",
+		"twitch.tv", "youtube.com",
+	},
+	"gambling": {
+		"888.com", "bet365.com", "betway.com", "draftkings.com", "pokerstars.com",
+	},
+	"generative_ai": {
+		"bard.google.com", "chat.openai.com", "chatgpt.com", "claude.ai", "gemini.google.com",
+		"perplexity.ai",
+	},
+}
+
+var categoryNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+var domainLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// ManagedCategories returns the built-in categories sorted by name.
+func ManagedCategories() []URLCategory {
+	out := make([]URLCategory, 0, len(managedCategories))
+	for name, domains := range managedCategories {
+		out = append(out, URLCategory{Name: name, Domains: append([]string(nil), domains...)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// IsManagedCategory reports whether name is a built-in category.
+func IsManagedCategory(name string) bool {
+	_, ok := managedCategories[name]
+	return ok
+}
+
+// CategoryMatch returns the first of names whose domains (managed plus the org's custom list of that name)
+// contain host or a parent of host, or "" when none does.
+func (ac *AccessControl) CategoryMatch(host string, names []string) string {
+	host = strings.ToLower(host)
+	for _, name := range names {
+		if domainListMatches(managedCategories[name], host) {
+			return name
+		}
+		for _, c := range ac.CustomCategories {
+			if c.Name == name && domainListMatches(c.Domains, host) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func domainListMatches(domains []string, host string) bool {
+	for _, d := range domains {
+		d = strings.ToLower(d)
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeDomain lowercases d and strips a scheme, path, port, and trailing dot, so pasted URLs are accepted.
+// Returns an error when the result is not a hostname (a leading "*." wildcard label is allowed).
+func NormalizeDomain(d string) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(d))
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	if i := strings.IndexAny(s, "/?#"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSuffix(s, ".")
+	host := strings.TrimPrefix(s, "*.")
+	if host == "" || len(s) > maxDomainLength {
+		return "", fmt.Errorf("invalid domain %q", d)
+	}
+	for _, label := range strings.Split(host, ".") {
+		if len(label) > 63 || !domainLabelPattern.MatchString(label) {
+			return "", fmt.Errorf("invalid domain %q", d)
+		}
+	}
+	return s, nil
+}
+
+// Validate checks list sizes, domain syntax, custom category names, that every referenced category exists,
+// URL rules (see URLRule.Validate) and group rules.
+func (ac *AccessControl) Validate() error {
+	if ac == nil {
+		return nil
+	}
+	if err := validateDomainList("allowed_domains", ac.AllowedDomains, MaxDomainsPerList); err != nil {
+		return err
+	}
+	if err := validateDomainList("blocked_domains", ac.BlockedDomains, MaxDomainsPerList); err != nil {
+		return err
+	}
+	if len(ac.CustomCategories) > MaxCustomCategories {
+		return fmt.Errorf("custom_categories exceeds %d entries", MaxCustomCategories)
+	}
+	known := make(map[string]bool, len(managedCategories)+len(ac.CustomCategories))
+	for name := range managedCategories {
+		known[name] = true
+	}
+	for _, c := range ac.CustomCategories {
+		if len(c.Name) > maxCategoryNameLength || !categoryNamePattern.MatchString(c.Name) {
+			return fmt.Errorf("invalid category name %q (use lowercase letters, digits, and underscores)", c.Name)
+		}
+		if err := validateDomainList("category "+c.Name, c.Domains, MaxDomainsPerCategory); err != nil {
+			return err
+		}
+		known[c.Name] = true
+	}
+	for _, name := range append(append([]string(nil), ac.AllowedCategories...), ac.BlockedCategories...) {
+		if !known[name] {
+			return fmt.Errorf("unknown category %q", name)
+		}
+	}
+	if len(ac.URLRules) > MaxURLRules {
+		return fmt.Errorf("url_rules exceeds %d entries", MaxURLRules)
+	}
+	for i := range ac.URLRules {
+		if err := ac.URLRules[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return ac.validateGroupRules()
+}
+
+func validateDomainList(field string, domains []string, max int) error {
+	if len(domains) > max {
+		return fmt.Errorf("%s exceeds %d entries", field, max)
+	}
+	for _, d := range domains {
+		if _, err := NormalizeDomain(d); err != nil {
+			return fmt.Errorf("%s: %v", field, err)
+		}
+	}
+	return nil
+}
+
+// MergeDomains returns list with add appended and remove taken out, skipping duplicates, plus the number of
+// entries actually added and removed. Entries must already be normalized.
+func MergeDomains(list, add, remove []string) (out []string, added, removed int) {
+	drop := make(map[string]bool, len(remove))
+	for _, d := range remove {
+		drop[d] = true
+	}
+	seen := make(map[string]bool, len(list)+len(add))
+	for _, d := range list {
+		key := strings.ToLower(d)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if drop[key] {
+			removed++
+			continue
+		}
+		out = append(out, d)
+	}
+	for _, d := range add {
+		if seen[d] || drop[d] {
+			continue
+		}
+		seen[d] = true
+		out = append(out, d)
+		added++
+	}
+	return out, added, removed
+}
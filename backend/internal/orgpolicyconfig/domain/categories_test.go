@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := map[string]string{
+		"Example.COM":                      "example.com",
+		" https://www.example.com/path?q ": "www.example.com",
+		"example.com:8443":                 "example.com",
+		"*.Example.com":                    "*.example.com",
+		"example.com.":                     "example.com",
+	}
+	for in, want := range tests {
+		got, err := NormalizeDomain(in)
+		if err != nil || got != want {
+			t.Errorf("NormalizeDomain(%q) = (%q, %v), want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "exa mple.com", "-bad.com", "a..b", strings.Repeat("a", 64) + ".com"} {
+		if _, err := NormalizeDomain(in); err == nil {
+			t.Errorf("NormalizeDomain(%q) should fail", in)
+		}
+	}
+}
+
+func TestAccessControl_CategoryMatch(t *testing.T) {
+	ac := &AccessControl{CustomCategories: []URLCategory{
+		{Name: "social", Domains: []string{"mastodon.social"}},
+		{Name: "partners", Domains: []string{"partner.example"}},
+	}}
+	tests := []struct {
+		host  string
+		names []string
+		want  string
+	}{
+		{"www.facebook.com", []string{"social"}, "social"},
+		{"mastodon.social", []string{"social"}, "social"},
+		{"app.partner.example", []string{"file_sharing", "partners"}, "partners"},
+		{"notfacebook.com", []string{"social"}, ""},
+		{"facebook.com", []string{"file_sharing"}, ""},
+	}
+	for _, tt := range tests {
+		if got := ac.CategoryMatch(tt.host, tt.names); got != tt.want {
+			t.Errorf("CategoryMatch(%q, %v) = %q, want %q", tt.host, tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestAccessControl_Validate(t *testing.T) {
+	valid := &AccessControl{
+		AllowedDomains:    []string{"example.com", "*.example.org"},
+		BlockedCategories: []string{"social", "partners"},
+		CustomCategories:  []URLCategory{{Name: "partners", Domains: []string{"partner.example"}}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	tooMany := make([]string, MaxDomainsPerList+1)
+	for i := range tooMany {
+		tooMany[i] = "example.com"
+	}
+	invalid := []*AccessControl{
+		{AllowedDomains: tooMany},
+		{BlockedDomains: []string{"not a domain"}},
+		{AllowedCategories: []string{"unknown"}},
+		{CustomCategories: []URLCategory{{Name: "Bad Name"}}},
+	}
+	for i, ac := range invalid {
+		if err := ac.Validate(); err == nil {
+			t.Errorf("case %d: Validate should fail", i)
+		}
+	}
+}
+
+func TestMergeDomains(t *testing.T) {
+	out, added, removed := MergeDomains([]string{"a.com", "B.com", "c.com"}, []string{"d.com", "a.com", "d.com"}, []string{"b.com"})
+	if added != 1 || removed != 1 {
+		t.Errorf("added, removed = %d, %d, want 1, 1", added, removed)
+	}
+	if strings.Join(out, ",") != "a.com,c.com,d.com" {
+		t.Errorf("out = %v, want [a.com c.com d.com]", out)
+	}
+}
+
+func TestManagedCategories_Sorted(t *testing.T) {
+	cats := ManagedCategories()
+	for i := 1; i < len(cats); i++ {
+		if cats[i-1].Name >= cats[i].Name {
+			t.Fatalf("categories not sorted: %q before %q", cats[i-1].Name, cats[i].Name)
+		}
+	}
+	if !IsManagedCategory("social") || IsManagedCategory("partners") {
+		t.Error("IsManagedCategory mismatch")
+	}
+}
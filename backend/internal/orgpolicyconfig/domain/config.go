@@ -2,19 +2,25 @@ package domain
 
 // AuthMfa holds org-level auth/MFA policy.
 type AuthMfa struct {
-	MfaRequirement         string   `json:"mfa_requirement"`     // always, new_device, untrusted
-	AllowedMfaMethods      []string `json:"allowed_mfa_methods"` // e.g. sms_otp
-	StepUpSensitiveActions bool     `json:"step_up_sensitive_actions"`
-	StepUpPolicyViolation  bool     `json:"step_up_policy_violation"`
+	MfaRequirement         string                `json:"mfa_requirement"`     // always, new_device, untrusted
+	AllowedMfaMethods      []string              `json:"allowed_mfa_methods"` // e.g. sms_otp
+	StepUpSensitiveActions bool                  `json:"step_up_sensitive_actions"`
+	StepUpPolicyViolation  bool                  `json:"step_up_policy_violation"`
+	GroupRequirements      []GroupMfaRequirement `json:"group_requirements,omitempty"`  // per-group requirements, on top of mfa_requirement
+	MagicLinkEnabled       bool                  `json:"magic_link_enabled,omitempty"`  // members may sign in with an emailed link (AuthService RequestMagicLink)
+	PolicyFailureMode      string                `json:"policy_failure_mode,omitempty"` // open, closed; empty = server default (POLICY_FAILURE_MODE)
 }
 
 // DeviceTrust holds org-level device trust policy.
 type DeviceTrust struct {
-	DeviceRegistrationAllowed bool `json:"device_registration_allowed"`
-	AutoTrustAfterMfa         bool `json:"auto_trust_after_mfa"`
-	MaxTrustedDevicesPerUser  int  `json:"max_trusted_devices_per_user"` // 0 = unlimited
-	ReverifyIntervalDays      int  `json:"reverify_interval_days"`
-	AdminRevokeAllowed        bool `json:"admin_revoke_allowed"`
+	DeviceRegistrationAllowed bool   `json:"device_registration_allowed"`
+	AutoTrustAfterMfa         bool   `json:"auto_trust_after_mfa"`
+	MaxTrustedDevicesPerUser  int    `json:"max_trusted_devices_per_user"` // 0 = unlimited
+	ReverifyIntervalDays      int    `json:"reverify_interval_days"`
+	AdminRevokeAllowed        bool   `json:"admin_revoke_allowed"`
+	KeepTrustOnFactorChange   bool   `json:"keep_trust_on_factor_change"` // false = untrust the user's devices when their MFA phone changes
+	TrustRenewal              string `json:"trust_renewal"`               // fixed, sliding (active use extends trust); empty = fixed
+	ExpiryNoticeDays          int    `json:"expiry_notice_days"`          // notify users this many days before trust expires; 0 = off
 }
 
 // SessionMgmt holds org-level session policy.
@@ -24,29 +30,139 @@ type SessionMgmt struct {
 	ConcurrentSessionLimit int    `json:"concurrent_session_limit"` // 0 = unlimited
 	AdminForcedLogout      bool   `json:"admin_forced_logout"`
 	ReauthOnPolicyChange   bool   `json:"reauth_on_policy_change"`
+	RefreshExpiry          string `json:"refresh_expiry,omitempty"`  // rolling, absolute; empty = rolling
+	MaxSessionAge          string `json:"max_session_age,omitempty"` // e.g. "720h", enforced on Refresh; empty = no cap
 }
 
 // AccessControl holds org-level access control (browser) policy.
 type AccessControl struct {
-	AllowedDomains    []string `json:"allowed_domains"`
-	BlockedDomains    []string `json:"blocked_domains"`
-	WildcardSupported bool     `json:"wildcard_supported"`
-	DefaultAction     string   `json:"default_action"` // allow, deny
+	AllowedDomains    []string          `json:"allowed_domains"`
+	BlockedDomains    []string          `json:"blocked_domains"`
+	WildcardSupported bool              `json:"wildcard_supported"`
+	DefaultAction     string            `json:"default_action"`               // allow, deny
+	AllowedCategories []string          `json:"allowed_categories,omitempty"` // managed or custom category names
+	BlockedCategories []string          `json:"blocked_categories,omitempty"`
+	CustomCategories  []URLCategory     `json:"custom_categories,omitempty"` // org-imported lists; extend a managed category of the same name
+	URLRules          []URLRule         `json:"url_rules,omitempty"`         // path-prefix and regex rules, checked before the domain lists
+	GroupRules        []GroupAccessRule `json:"group_rules,omitempty"`       // per-group domain lists, checked after url_rules
 }
 
-// ActionRestrictions holds org-level action restrictions.
+// ActionRestrictions holds org-level action restrictions and data-loss-prevention (DLP) rules, enforced by the
+// browser agent. The DLP rules only narrow allowed_actions: a blocked action stays blocked.
 type ActionRestrictions struct {
-	AllowedActions []string `json:"allowed_actions"` // navigate, download, upload, copy_paste
-	ReadOnlyMode   bool     `json:"read_only_mode"`
+	AllowedActions          []string `json:"allowed_actions"` // navigate, download, upload, copy_paste
+	ReadOnlyMode            bool     `json:"read_only_mode"`
+	MaxUploadBytes          int64    `json:"max_upload_bytes,omitempty"`          // 0 = no limit
+	BlockedFileExtensions   []string `json:"blocked_file_extensions,omitempty"`   // uploads and downloads; "pdf" or ".pdf"
+	ClipboardBlockedDomains []string `json:"clipboard_blocked_domains,omitempty"` // copy_paste blocked on these sites
+	WatermarkPages          bool     `json:"watermark_pages,omitempty"`           // overlay the user's identity on pages
+	WatermarkDownloads      bool     `json:"watermark_downloads,omitempty"`       // stamp downloaded documents
 }
 
-// OrgPolicyConfig holds all five sections. Used for JSON storage and API.
+// Notifications holds org-level user notification policy.
+type Notifications struct {
+	NewLoginAlerts        bool `json:"new_login_alerts"`         // alert users on sign-in from a new device or location
+	EnforceNewLoginAlerts bool `json:"enforce_new_login_alerts"` // users cannot opt out
+}
+
+// NetworkAccess holds org-level source-IP restrictions enforced at Login and Refresh, and the org's trusted (corporate)
+// networks, reported to MFA policies as input.network.on_trusted_network.
+type NetworkAccess struct {
+	AllowedCidrs        []string `json:"allowed_cidrs"`                   // empty = any network; bare IPs are treated as /32 or /128
+	BlockedCidrs        []string `json:"blocked_cidrs"`                   // checked before allowed_cidrs
+	OwnerBypass         bool     `json:"owner_bypass"`                    // break-glass: org owners may sign in from any network (audited)
+	TrustedCidrs        []string `json:"trusted_cidrs,omitempty"`         // corporate networks; a policy signal only, not a restriction
+	ForbidMfaRelaxation bool     `json:"forbid_mfa_relaxation,omitempty"` // being on a trusted network may tighten MFA policy, never relax it
+}
+
+// AccessWindow is a recurring period during which members with one of Roles may sign in.
+type AccessWindow struct {
+	Roles     []string `json:"roles"`      // owner, admin, member; empty = all roles
+	Days      []string `json:"days"`       // mon..sun; empty = every day
+	StartTime string   `json:"start_time"` // "HH:MM" in the schedule timezone
+	EndTime   string   `json:"end_time"`   // "HH:MM" (exclusive; "24:00" = end of day); before start_time wraps past midnight
+}
+
+// AccessSchedule holds org-level time-of-day / day-of-week sign-in restrictions enforced at Login and Refresh.
+// Roles not covered by any window are unrestricted.
+type AccessSchedule struct {
+	Enabled  bool           `json:"enabled"`
+	Timezone string         `json:"timezone"` // IANA name, e.g. "Europe/Berlin"; empty = UTC
+	Windows  []AccessWindow `json:"windows"`
+}
+
+// TokenClaims holds org-level access-token customization for downstream services.
+type TokenClaims struct {
+	Audiences []string          `json:"audiences"` // added to the platform audience (which is always present)
+	Claims    map[string]string `json:"claims"`    // static claims added to every access token for the org
+}
+
+// PasswordPolicy holds org-level password policy.
+type PasswordPolicy struct {
+	BreachedPasswordMode string `json:"breached_password_mode"` // off, warn, block; empty = platform default
+}
+
+// ChangeApproval holds org-level four-eyes approval for policy changes.
+type ChangeApproval struct {
+	Required bool `json:"required"` // policy config and Rego policy changes go through ChangeRequestService
+}
+
+// Registration holds the org's self-service sign-up mode for emails in its verified domains.
+type Registration struct {
+	Mode string `json:"mode"` // open, invite_only, closed; empty = platform registration_mode
+}
+
+// OrgPolicyConfig holds all policy sections. Used for JSON storage and API.
 type OrgPolicyConfig struct {
 	AuthMfa            *AuthMfa            `json:"auth_mfa,omitempty"`
 	DeviceTrust        *DeviceTrust        `json:"device_trust,omitempty"`
 	SessionMgmt        *SessionMgmt        `json:"session_mgmt,omitempty"`
 	AccessControl      *AccessControl      `json:"access_control,omitempty"`
 	ActionRestrictions *ActionRestrictions `json:"action_restrictions,omitempty"`
+	Notifications      *Notifications      `json:"notifications,omitempty"`
+	NetworkAccess      *NetworkAccess      `json:"network_access,omitempty"`
+	AccessSchedule     *AccessSchedule     `json:"access_schedule,omitempty"`
+	TokenClaims        *TokenClaims        `json:"token_claims,omitempty"`
+	PasswordPolicy     *PasswordPolicy     `json:"password_policy,omitempty"`
+	ChangeApproval     *ChangeApproval     `json:"change_approval,omitempty"`
+	Registration       *Registration       `json:"registration,omitempty"`
+}
+
+// RequiresChangeApproval reports whether changes to the org's policy must be proposed and approved by a second admin.
+func (c *OrgPolicyConfig) RequiresChangeApproval() bool {
+	return c != nil && c.ChangeApproval != nil && c.ChangeApproval.Required
+}
+
+// Validate checks the sections of c that have constraints beyond their types.
+func (c *OrgPolicyConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := c.AuthMfa.Validate(); err != nil {
+		return err
+	}
+	if err := c.AccessControl.Validate(); err != nil {
+		return err
+	}
+	if err := c.ActionRestrictions.Validate(); err != nil {
+		return err
+	}
+	if err := c.DeviceTrust.Validate(); err != nil {
+		return err
+	}
+	if err := c.NetworkAccess.Validate(); err != nil {
+		return err
+	}
+	if err := c.SessionMgmt.Validate(); err != nil {
+		return err
+	}
+	if err := c.AccessSchedule.Validate(); err != nil {
+		return err
+	}
+	if err := c.Registration.Validate(); err != nil {
+		return err
+	}
+	return c.TokenClaims.Validate()
 }
 
 // DefaultAuthMfa returns default AuthMfa (MFA on new device, SMS OTP allowed).
@@ -56,6 +172,7 @@ func DefaultAuthMfa() AuthMfa {
 		AllowedMfaMethods:      []string{"sms_otp"},
 		StepUpSensitiveActions: false,
 		StepUpPolicyViolation:  false,
+		MagicLinkEnabled:       false,
 	}
 }
 
@@ -67,6 +184,9 @@ func DefaultDeviceTrust() DeviceTrust {
 		MaxTrustedDevicesPerUser:  0,
 		ReverifyIntervalDays:      30,
 		AdminRevokeAllowed:        true,
+		KeepTrustOnFactorChange:   false,
+		TrustRenewal:              TrustRenewalFixed,
+		ExpiryNoticeDays:          0,
 	}
 }
 
@@ -78,6 +198,8 @@ func DefaultSessionMgmt() SessionMgmt {
 		ConcurrentSessionLimit: 0,
 		AdminForcedLogout:      true,
 		ReauthOnPolicyChange:   false,
+		RefreshExpiry:          "",
+		MaxSessionAge:          "",
 	}
 }
 
@@ -99,6 +221,61 @@ func DefaultActionRestrictions() ActionRestrictions {
 	}
 }
 
+// DefaultNotifications returns default Notifications (new-login alerts on, users may opt out).
+func DefaultNotifications() Notifications {
+	return Notifications{
+		NewLoginAlerts:        true,
+		EnforceNewLoginAlerts: false,
+	}
+}
+
+// DefaultNetworkAccess returns default NetworkAccess (any network, owner bypass on).
+func DefaultNetworkAccess() NetworkAccess {
+	return NetworkAccess{
+		AllowedCidrs: nil,
+		BlockedCidrs: nil,
+		OwnerBypass:  true,
+	}
+}
+
+// DefaultAccessSchedule returns default AccessSchedule (disabled, UTC, no windows).
+func DefaultAccessSchedule() AccessSchedule {
+	return AccessSchedule{
+		Enabled:  false,
+		Timezone: "UTC",
+		Windows:  nil,
+	}
+}
+
+// DefaultTokenClaims returns default TokenClaims (no custom audiences or claims).
+func DefaultTokenClaims() TokenClaims {
+	return TokenClaims{
+		Audiences: nil,
+		Claims:    nil,
+	}
+}
+
+// DefaultPasswordPolicy returns default PasswordPolicy (platform breached-password mode).
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		BreachedPasswordMode: "",
+	}
+}
+
+// DefaultChangeApproval returns default ChangeApproval (admins change policy directly).
+func DefaultChangeApproval() ChangeApproval {
+	return ChangeApproval{
+		Required: false,
+	}
+}
+
+// DefaultRegistration returns default Registration (platform registration mode).
+func DefaultRegistration() Registration {
+	return Registration{
+		Mode: "",
+	}
+}
+
 // MergeWithDefaults returns a copy of c with nil sections replaced by defaults.
 func MergeWithDefaults(c *OrgPolicyConfig) *OrgPolicyConfig {
 	if c == nil {
@@ -108,6 +285,13 @@ func MergeWithDefaults(c *OrgPolicyConfig) *OrgPolicyConfig {
 			SessionMgmt:        ptr(DefaultSessionMgmt()),
 			AccessControl:      ptr(DefaultAccessControl()),
 			ActionRestrictions: ptr(DefaultActionRestrictions()),
+			Notifications:      ptr(DefaultNotifications()),
+			NetworkAccess:      ptr(DefaultNetworkAccess()),
+			AccessSchedule:     ptr(DefaultAccessSchedule()),
+			TokenClaims:        ptr(DefaultTokenClaims()),
+			PasswordPolicy:     ptr(DefaultPasswordPolicy()),
+			ChangeApproval:     ptr(DefaultChangeApproval()),
+			Registration:       ptr(DefaultRegistration()),
 		}
 	}
 	out := *c
@@ -126,6 +310,27 @@ func MergeWithDefaults(c *OrgPolicyConfig) *OrgPolicyConfig {
 	if out.ActionRestrictions == nil {
 		out.ActionRestrictions = ptr(DefaultActionRestrictions())
 	}
+	if out.Notifications == nil {
+		out.Notifications = ptr(DefaultNotifications())
+	}
+	if out.NetworkAccess == nil {
+		out.NetworkAccess = ptr(DefaultNetworkAccess())
+	}
+	if out.AccessSchedule == nil {
+		out.AccessSchedule = ptr(DefaultAccessSchedule())
+	}
+	if out.TokenClaims == nil {
+		out.TokenClaims = ptr(DefaultTokenClaims())
+	}
+	if out.PasswordPolicy == nil {
+		out.PasswordPolicy = ptr(DefaultPasswordPolicy())
+	}
+	if out.ChangeApproval == nil {
+		out.ChangeApproval = ptr(DefaultChangeApproval())
+	}
+	if out.Registration == nil {
+		out.Registration = ptr(DefaultRegistration())
+	}
 	return &out
 }
 
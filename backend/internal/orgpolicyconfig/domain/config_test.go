@@ -18,6 +18,9 @@ func TestDefaultAuthMfa(t *testing.T) {
 	if authMfa.StepUpPolicyViolation {
 		t.Error("StepUpPolicyViolation should be false by default")
 	}
+	if authMfa.MagicLinkEnabled {
+		t.Error("MagicLinkEnabled should be false by default")
+	}
 }
 
 func TestDefaultDeviceTrust(t *testing.T) {
@@ -37,6 +40,9 @@ func TestDefaultDeviceTrust(t *testing.T) {
 	if !deviceTrust.AdminRevokeAllowed {
 		t.Error("AdminRevokeAllowed should be true by default")
 	}
+	if deviceTrust.KeepTrustOnFactorChange {
+		t.Error("KeepTrustOnFactorChange should be false by default")
+	}
 }
 
 func TestDefaultSessionMgmt(t *testing.T) {
@@ -56,6 +62,9 @@ func TestDefaultSessionMgmt(t *testing.T) {
 	if sessionMgmt.ReauthOnPolicyChange {
 		t.Error("ReauthOnPolicyChange should be false by default")
 	}
+	if sessionMgmt.RefreshExpiry != "" || sessionMgmt.AbsoluteRefresh() || sessionMgmt.MaxSessionAge != "" {
+		t.Errorf("RefreshExpiry, MaxSessionAge = %q, %q; want rolling without a max age", sessionMgmt.RefreshExpiry, sessionMgmt.MaxSessionAge)
+	}
 }
 
 func TestDefaultAccessControl(t *testing.T) {
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Trust renewal modes (device_trust.trust_renewal).
+const (
+	// TrustRenewalFixed lets trust expire trust_ttl_days after MFA; the user then completes MFA again.
+	TrustRenewalFixed = "fixed"
+	// TrustRenewalSliding extends trust by trust_ttl_days whenever the device signs in or refreshes while trusted,
+	// so only devices left unused for a full TTL expire.
+	TrustRenewalSliding = "sliding"
+)
+
+// MaxExpiryNoticeDays bounds device_trust.expiry_notice_days.
+const MaxExpiryNoticeDays = 30
+
+// Validate checks trust_renewal and expiry_notice_days. The notice must come after trust is granted, so it must
+// be shorter than reverify_interval_days when that is set.
+func (d *DeviceTrust) Validate() error {
+	if d == nil {
+		return nil
+	}
+	switch d.TrustRenewal {
+	case "", TrustRenewalFixed, TrustRenewalSliding:
+	default:
+		return fmt.Errorf("device_trust.trust_renewal must be %q or %q", TrustRenewalFixed, TrustRenewalSliding)
+	}
+	if d.ExpiryNoticeDays < 0 || d.ExpiryNoticeDays > MaxExpiryNoticeDays {
+		return fmt.Errorf("device_trust.expiry_notice_days must be between 0 and %d", MaxExpiryNoticeDays)
+	}
+	if d.ExpiryNoticeDays > 0 && d.ReverifyIntervalDays > 0 && d.ExpiryNoticeDays >= d.ReverifyIntervalDays {
+		return errors.New("device_trust.expiry_notice_days must be less than reverify_interval_days")
+	}
+	return nil
+}
+
+// SlidingRenewal reports whether active use extends the trust of the org's devices.
+func (d *DeviceTrust) SlidingRenewal() bool {
+	return d != nil && d.TrustRenewal == TrustRenewalSliding
+}
+
+// ExpiryNotice returns how long before trust expires users are notified; 0 when notices are off.
+func (d *DeviceTrust) ExpiryNotice() time.Duration {
+	if d == nil || d.ExpiryNoticeDays <= 0 {
+		return 0
+	}
+	return time.Duration(d.ExpiryNoticeDays) * 24 * time.Hour
+}
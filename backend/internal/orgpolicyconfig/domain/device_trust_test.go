@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestDeviceTrust_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		dt      *DeviceTrust
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"defaults", ptr(DefaultDeviceTrust()), false},
+		{"empty renewal", &DeviceTrust{ReverifyIntervalDays: 30}, false},
+		{"sliding with notice", &DeviceTrust{TrustRenewal: TrustRenewalSliding, ReverifyIntervalDays: 30, ExpiryNoticeDays: 7}, false},
+		{"notice without interval", &DeviceTrust{ExpiryNoticeDays: MaxExpiryNoticeDays}, false},
+		{"unknown renewal", &DeviceTrust{TrustRenewal: "rolling"}, true},
+		{"negative notice", &DeviceTrust{ExpiryNoticeDays: -1}, true},
+		{"notice too long", &DeviceTrust{ExpiryNoticeDays: MaxExpiryNoticeDays + 1}, true},
+		{"notice not before expiry", &DeviceTrust{ReverifyIntervalDays: 7, ExpiryNoticeDays: 7}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.dt.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeviceTrust_RenewalAndNotice(t *testing.T) {
+	var nilDT *DeviceTrust
+	if nilDT.SlidingRenewal() || nilDT.ExpiryNotice() != 0 {
+		t.Error("nil DeviceTrust should be fixed renewal without notice")
+	}
+	d := &DeviceTrust{TrustRenewal: TrustRenewalSliding, ExpiryNoticeDays: 3}
+	if !d.SlidingRenewal() {
+		t.Error("SlidingRenewal = false, want true")
+	}
+	if got := d.ExpiryNotice().Hours(); got != 72 {
+		t.Errorf("ExpiryNotice = %vh, want 72h", got)
+	}
+}
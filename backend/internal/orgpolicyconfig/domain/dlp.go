@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Limits on the DLP rules of action_restrictions.
+const (
+	MaxBlockedFileExtensions = 200
+	maxFileExtensionLength   = 16
+)
+
+var fileExtensionPattern = regexp.MustCompile(`^[a-z0-9]+(\.[a-z0-9]+)*$`)
+
+// NormalizeFileExtension returns ext trimmed, lowercased and without its leading dot ("PDF", ".pdf" -> "pdf").
+// Multi-part extensions such as "tar.gz" are allowed.
+func NormalizeFileExtension(ext string) (string, error) {
+	s := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(ext)), ".")
+	if len(s) > maxFileExtensionLength || !fileExtensionPattern.MatchString(s) {
+		return "", fmt.Errorf("invalid file extension %q", ext)
+	}
+	return s, nil
+}
+
+// Validate checks the DLP rules: a non-negative max_upload_bytes, extension syntax and domain syntax.
+func (a *ActionRestrictions) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.MaxUploadBytes < 0 {
+		return fmt.Errorf("max_upload_bytes must not be negative")
+	}
+	if len(a.BlockedFileExtensions) > MaxBlockedFileExtensions {
+		return fmt.Errorf("blocked_file_extensions exceeds %d entries", MaxBlockedFileExtensions)
+	}
+	for _, ext := range a.BlockedFileExtensions {
+		if _, err := NormalizeFileExtension(ext); err != nil {
+			return fmt.Errorf("blocked_file_extensions: %v", err)
+		}
+	}
+	return validateDomainList("clipboard_blocked_domains", a.ClipboardBlockedDomains, MaxDomainsPerList)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalizeFileExtension(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"pdf", "pdf", false},
+		{".PDF", "pdf", false},
+		{" Tar.GZ ", "tar.gz", false},
+		{"", "", true},
+		{".", "", true},
+		{"exe*", "", true},
+		{"a..b", "", true},
+		{"averyveryverylongext", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeFileExtension(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeFileExtension(%q) = %q, %v; want %q, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestActionRestrictions_Validate(t *testing.T) {
+	tooMany := make([]string, MaxBlockedFileExtensions+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("x%d", i)
+	}
+	tests := []struct {
+		name    string
+		a       *ActionRestrictions
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"defaults", ptr(DefaultActionRestrictions()), false},
+		{"dlp rules", &ActionRestrictions{
+			MaxUploadBytes:          10 << 20,
+			BlockedFileExtensions:   []string{".exe", "tar.gz"},
+			ClipboardBlockedDomains: []string{"mail.example.com", "*.docs.example.com"},
+			WatermarkPages:          true,
+		}, false},
+		{"negative upload size", &ActionRestrictions{MaxUploadBytes: -1}, true},
+		{"bad extension", &ActionRestrictions{BlockedFileExtensions: []string{"e x e"}}, true},
+		{"too many extensions", &ActionRestrictions{BlockedFileExtensions: tooMany}, true},
+		{"bad clipboard domain", &ActionRestrictions{ClipboardBlockedDomains: []string{"not a domain"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.a.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := (&OrgPolicyConfig{ActionRestrictions: &ActionRestrictions{MaxUploadBytes: -1}}).Validate(); err == nil {
+		t.Error("OrgPolicyConfig.Validate should reject invalid action_restrictions")
+	}
+}
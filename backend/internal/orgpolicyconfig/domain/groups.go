@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MFA requirements (auth_mfa.mfa_requirement and group_requirements).
+const (
+	MfaRequirementAlways    = "always"
+	MfaRequirementNewDevice = "new_device"
+	MfaRequirementUntrusted = "untrusted"
+)
+
+// Policy failure modes (auth_mfa.policy_failure_mode): what MFA evaluation does while the org's policies cannot be
+// loaded. Empty uses the server's POLICY_FAILURE_MODE.
+const (
+	// PolicyFailureModeOpen evaluates the built-in default policy against the org's MFA settings.
+	PolicyFailureModeOpen = "open"
+	// PolicyFailureModeClosed requires MFA for every sign-in.
+	PolicyFailureModeClosed = "closed"
+)
+
+// MaxGroupRules bounds auth_mfa.group_requirements and access_control.group_rules.
+const MaxGroupRules = 100
+
+// GroupMfaRequirement requires MFA for the members of a group, on top of the org's mfa_requirement: a user in
+// several groups gets MFA whenever any of their requirements (or the org's) applies. It cannot relax MFA.
+type GroupMfaRequirement struct {
+	Group          string `json:"group"`           // group name (GroupService)
+	MfaRequirement string `json:"mfa_requirement"` // always, new_device, untrusted
+}
+
+// GroupAccessRule gives the members of a group their own domain lists. Rules of the caller's groups are checked
+// after url_rules and before the org's domain lists and categories: blocked domains of any of the caller's groups
+// deny, then allowed domains allow (even when the org blocks the domain or denies by default).
+type GroupAccessRule struct {
+	Group          string   `json:"group"` // group name (GroupService)
+	AllowedDomains []string `json:"allowed_domains"`
+	BlockedDomains []string `json:"blocked_domains"`
+}
+
+// Validate checks policy_failure_mode and group_requirements: each names a group once, with a known requirement.
+// Group names are not checked against GroupService, so a rule may name a group that is created later.
+func (a *AuthMfa) Validate() error {
+	if a == nil {
+		return nil
+	}
+	switch a.PolicyFailureMode {
+	case "", PolicyFailureModeOpen, PolicyFailureModeClosed:
+	default:
+		return fmt.Errorf("auth_mfa.policy_failure_mode must be %q or %q", PolicyFailureModeOpen, PolicyFailureModeClosed)
+	}
+	if len(a.GroupRequirements) > MaxGroupRules {
+		return fmt.Errorf("auth_mfa.group_requirements exceeds %d entries", MaxGroupRules)
+	}
+	seen := make(map[string]bool, len(a.GroupRequirements))
+	for _, r := range a.GroupRequirements {
+		if r.Group == "" {
+			return errors.New("auth_mfa.group_requirements: group required")
+		}
+		if seen[r.Group] {
+			return fmt.Errorf("auth_mfa.group_requirements: group %q listed twice", r.Group)
+		}
+		seen[r.Group] = true
+		switch r.MfaRequirement {
+		case MfaRequirementAlways, MfaRequirementNewDevice, MfaRequirementUntrusted:
+		default:
+			return fmt.Errorf("auth_mfa.group_requirements: group %q needs mfa_requirement always, new_device or untrusted", r.Group)
+		}
+	}
+	return nil
+}
+
+// GroupMfaRequirements returns the requirement of each of groups that has one, keyed by group name.
+func (a *AuthMfa) GroupMfaRequirements(groups []string) map[string]string {
+	out := map[string]string{}
+	if a == nil {
+		return out
+	}
+	for _, r := range a.GroupRequirements {
+		if containsString(groups, r.Group) {
+			out[r.Group] = r.MfaRequirement
+		}
+	}
+	return out
+}
+
+// validateGroupRules checks that each group rule names a group once and has valid domain lists.
+func (ac *AccessControl) validateGroupRules() error {
+	if len(ac.GroupRules) > MaxGroupRules {
+		return fmt.Errorf("group_rules exceeds %d entries", MaxGroupRules)
+	}
+	seen := make(map[string]bool, len(ac.GroupRules))
+	for _, r := range ac.GroupRules {
+		if r.Group == "" {
+			return errors.New("group_rules: group required")
+		}
+		if seen[r.Group] {
+			return fmt.Errorf("group_rules: group %q listed twice", r.Group)
+		}
+		seen[r.Group] = true
+		if err := validateDomainList("group "+r.Group+" allowed_domains", r.AllowedDomains, MaxDomainsPerList); err != nil {
+			return err
+		}
+		if err := validateDomainList("group "+r.Group+" blocked_domains", r.BlockedDomains, MaxDomainsPerList); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RulesForGroups returns the group rules of the given groups, in config order.
+func (ac *AccessControl) RulesForGroups(groups []string) []GroupAccessRule {
+	var out []GroupAccessRule
+	for _, r := range ac.GroupRules {
+		if containsString(groups, r.Group) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
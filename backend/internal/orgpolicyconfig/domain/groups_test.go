@@ -0,0 +1,71 @@
+package domain
+
+import "testing"
+
+func TestAuthMfa_Validate(t *testing.T) {
+	valid := &AuthMfa{GroupRequirements: []GroupMfaRequirement{
+		{Group: "Contractors", MfaRequirement: MfaRequirementAlways},
+		{Group: "Engineering", MfaRequirement: MfaRequirementUntrusted},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	invalid := [][]GroupMfaRequirement{
+		{{MfaRequirement: MfaRequirementAlways}},              // no group
+		{{Group: "Contractors", MfaRequirement: "sometimes"}}, // unknown requirement
+		{{Group: "Contractors", MfaRequirement: MfaRequirementAlways}, {Group: "Contractors", MfaRequirement: MfaRequirementNewDevice}},
+		make([]GroupMfaRequirement, MaxGroupRules+1),
+	}
+	for _, reqs := range invalid {
+		if err := (&AuthMfa{GroupRequirements: reqs}).Validate(); err == nil {
+			t.Errorf("Validate(%.80v) should fail", reqs)
+		}
+	}
+	for _, mode := range []string{"", PolicyFailureModeOpen, PolicyFailureModeClosed} {
+		if err := (&AuthMfa{PolicyFailureMode: mode}).Validate(); err != nil {
+			t.Errorf("Validate(policy_failure_mode %q): %v", mode, err)
+		}
+	}
+	if err := (&AuthMfa{PolicyFailureMode: "ajar"}).Validate(); err == nil {
+		t.Error("Validate should reject an unknown policy_failure_mode")
+	}
+}
+
+func TestAuthMfa_GroupMfaRequirements(t *testing.T) {
+	a := &AuthMfa{GroupRequirements: []GroupMfaRequirement{
+		{Group: "Contractors", MfaRequirement: MfaRequirementAlways},
+		{Group: "Engineering", MfaRequirement: MfaRequirementNewDevice},
+	}}
+	got := a.GroupMfaRequirements([]string{"Contractors", "Sales"})
+	if len(got) != 1 || got["Contractors"] != MfaRequirementAlways {
+		t.Errorf("GroupMfaRequirements = %v", got)
+	}
+	if got := (*AuthMfa)(nil).GroupMfaRequirements([]string{"Contractors"}); len(got) != 0 {
+		t.Errorf("nil AuthMfa = %v", got)
+	}
+}
+
+func TestAccessControl_GroupRules(t *testing.T) {
+	ac := &AccessControl{GroupRules: []GroupAccessRule{
+		{Group: "Engineering", AllowedDomains: []string{"github.com"}},
+		{Group: "Contractors", BlockedDomains: []string{"*.internal.example"}},
+	}}
+	if err := ac.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	rules := ac.RulesForGroups([]string{"Engineering"})
+	if len(rules) != 1 || rules[0].Group != "Engineering" {
+		t.Errorf("RulesForGroups = %v", rules)
+	}
+
+	invalid := [][]GroupAccessRule{
+		{{AllowedDomains: []string{"github.com"}}},
+		{{Group: "Engineering"}, {Group: "Engineering"}},
+		{{Group: "Engineering", BlockedDomains: []string{"bad domain"}}},
+	}
+	for _, rules := range invalid {
+		if err := (&AccessControl{GroupRules: rules}).Validate(); err == nil {
+			t.Errorf("Validate(%v) should fail", rules)
+		}
+	}
+}
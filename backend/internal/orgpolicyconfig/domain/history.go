@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Change sources recorded in the config history.
+const (
+	ChangeSourceUpdate            = "update"
+	ChangeSourceBulkUpdateDomains = "bulk_update_domains"
+	ChangeSourceRollback          = "rollback"
+	ChangeSourceChangeRequest     = "change_request"
+	ChangeSourceSetup             = "setup"
+	ChangeSourcePreset            = "preset"
+)
+
+// Change describes a config write for the history: who made it and how.
+type Change struct {
+	By              string // user ID of the admin
+	Source          string // one of the ChangeSource constants
+	RestoredVersion int64  // for ChangeSourceRollback, the version restored
+}
+
+// ConfigVersion is one stored version of an org's config.
+type ConfigVersion struct {
+	OrgID     string
+	Version   int64
+	Config    *OrgPolicyConfig
+	ChangedAt time.Time
+	Change
+}
+
+// FieldChange is a setting that differs between two configs. Path is dotted (e.g. "auth_mfa.mfa_requirement");
+// OldValue and NewValue are JSON. Lists are compared and reported whole.
+type FieldChange struct {
+	Path     string `json:"path"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// Diff returns the settings that differ from prev to next, sorted by path. Both are merged with defaults first,
+// so a section that is unset on one side is compared against its defaults.
+func Diff(prev, next *OrgPolicyConfig) ([]FieldChange, error) {
+	a, err := toJSONMap(MergeWithDefaults(prev))
+	if err != nil {
+		return nil, err
+	}
+	b, err := toJSONMap(MergeWithDefaults(next))
+	if err != nil {
+		return nil, err
+	}
+	var out []FieldChange
+	diffValues("", a, b, &out)
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+func toJSONMap(c *OrgPolicyConfig) (map[string]any, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	err = json.Unmarshal(raw, &m)
+	return m, err
+}
+
+// diffValues appends the leaves that differ between a and b. Objects are walked; everything else is a leaf.
+func diffValues(path string, a, b any, out *[]FieldChange) {
+	am, aIsMap := a.(map[string]any)
+	bm, bIsMap := b.(map[string]any)
+	if aIsMap && bIsMap {
+		for k, av := range am {
+			diffValues(joinPath(path, k), av, bm[k], out)
+		}
+		for k, bv := range bm {
+			if _, ok := am[k]; !ok {
+				diffValues(joinPath(path, k), nil, bv, out)
+			}
+		}
+		return
+	}
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+	*out = append(*out, FieldChange{Path: path, OldValue: jsonValue(a), NewValue: jsonValue(b)})
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonValue(v any) string {
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
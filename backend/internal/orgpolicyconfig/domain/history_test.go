@@ -0,0 +1,36 @@
+package domain
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	prev := &OrgPolicyConfig{
+		AuthMfa:       &AuthMfa{MfaRequirement: "always", AllowedMfaMethods: []string{"sms_otp"}},
+		AccessControl: &AccessControl{DefaultAction: "allow", BlockedDomains: []string{"a.com"}},
+	}
+	next := &OrgPolicyConfig{
+		AuthMfa:       &AuthMfa{MfaRequirement: "untrusted", AllowedMfaMethods: []string{"sms_otp"}},
+		AccessControl: &AccessControl{DefaultAction: "allow", BlockedDomains: []string{"a.com", "b.com"}},
+	}
+	changes, err := Diff(prev, next)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v, want 2", changes)
+	}
+	if c := changes[0]; c.Path != "access_control.blocked_domains" || c.OldValue != `["a.com"]` || c.NewValue != `["a.com","b.com"]` {
+		t.Errorf("changes[0] = %+v", c)
+	}
+	if c := changes[1]; c.Path != "auth_mfa.mfa_requirement" || c.OldValue != `"always"` || c.NewValue != `"untrusted"` {
+		t.Errorf("changes[1] = %+v", c)
+	}
+
+	// A nil prev is the defaults.
+	changes, err = Diff(nil, &OrgPolicyConfig{AuthMfa: &AuthMfa{MfaRequirement: "always", AllowedMfaMethods: []string{"sms_otp"}}})
+	if err != nil || len(changes) != 1 || changes[0].Path != "auth_mfa.mfa_requirement" || changes[0].OldValue != `"new_device"` {
+		t.Errorf("Diff(nil, ...) = %+v, %v", changes, err)
+	}
+	if changes, err := Diff(next, next); err != nil || len(changes) != 0 {
+		t.Errorf("Diff of equal configs = %+v, %v", changes, err)
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+)
+
+// MFASettings maps c's auth_mfa and device_trust to the org_mfa_settings row that mirrors them for MFA evaluation,
+// created and updated at now.
+func MFASettings(orgID string, c *OrgPolicyConfig, now time.Time) *orgmfasettingsdomain.OrgMFASettings {
+	s := &orgmfasettingsdomain.OrgMFASettings{
+		OrgID:                   orgID,
+		MFARequiredForNewDevice: true,
+		MFARequiredForUntrusted: true,
+		MFARequiredAlways:       false,
+		RegisterTrustAfterMFA:   true,
+		TrustTTLDays:            30,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}
+	if c.AuthMfa != nil {
+		switch c.AuthMfa.MfaRequirement {
+		case "always":
+			s.MFARequiredAlways = true
+			s.MFARequiredForNewDevice = false
+			s.MFARequiredForUntrusted = false
+		case "new_device":
+			s.MFARequiredForNewDevice = true
+			s.MFARequiredForUntrusted = true
+			s.MFARequiredAlways = false
+		case "untrusted":
+			s.MFARequiredForUntrusted = true
+			s.MFARequiredForNewDevice = false
+			s.MFARequiredAlways = false
+		}
+	}
+	if c.DeviceTrust != nil {
+		s.RegisterTrustAfterMFA = c.DeviceTrust.AutoTrustAfterMfa
+		if c.DeviceTrust.ReverifyIntervalDays > 0 {
+			s.TrustTTLDays = c.DeviceTrust.ReverifyIntervalDays
+		}
+	}
+	return s
+}
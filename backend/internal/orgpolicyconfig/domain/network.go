@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ParsePrefix parses a CIDR ("10.0.0.0/8") or bare IP ("203.0.113.7", treated as a single-host prefix).
+func ParsePrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return p.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Validate returns an error naming the first allowed, blocked or trusted entry that is not a valid CIDR or IP.
+func (n *NetworkAccess) Validate() error {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.AllowedCidrs {
+		if _, err := ParsePrefix(c); err != nil {
+			return fmt.Errorf("invalid allowed_cidrs entry %q", c)
+		}
+	}
+	for _, c := range n.BlockedCidrs {
+		if _, err := ParsePrefix(c); err != nil {
+			return fmt.Errorf("invalid blocked_cidrs entry %q", c)
+		}
+	}
+	for _, c := range n.TrustedCidrs {
+		if _, err := ParsePrefix(c); err != nil {
+			return fmt.Errorf("invalid trusted_cidrs entry %q", c)
+		}
+	}
+	return nil
+}
+
+// Restricted reports whether any allowed or blocked entries are configured.
+func (n *NetworkAccess) Restricted() bool {
+	return n != nil && (len(n.AllowedCidrs) > 0 || len(n.BlockedCidrs) > 0)
+}
+
+// Evaluate returns (allowed, reason) for a client IP. A blocked match denies; otherwise a non-empty
+// allowed list must contain the IP. When the policy is restricted, an unparseable or unknown IP is denied.
+// Invalid entries are skipped (Validate rejects them on update).
+func (n *NetworkAccess) Evaluate(ip string) (allowed bool, reason string) {
+	if !n.Restricted() {
+		return true, ""
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false, "client_ip_unknown"
+	}
+	addr = addr.Unmap()
+	if containsAddr(n.BlockedCidrs, addr) {
+		return false, "blocked_cidr"
+	}
+	if len(n.AllowedCidrs) > 0 && !containsAddr(n.AllowedCidrs, addr) {
+		return false, "not_in_allowed_cidrs"
+	}
+	return true, ""
+}
+
+// OnTrustedNetwork reports whether a client IP is in one of the org's trusted networks. An unparseable or unknown IP
+// is not.
+func (n *NetworkAccess) OnTrustedNetwork(ip string) bool {
+	if n == nil || len(n.TrustedCidrs) == 0 {
+		return false
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false
+	}
+	return containsAddr(n.TrustedCidrs, addr.Unmap())
+}
+
+func containsAddr(cidrs []string, addr netip.Addr) bool {
+	for _, c := range cidrs {
+		p, err := ParsePrefix(c)
+		if err != nil {
+			continue
+		}
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package domain
+
+import "testing"
+
+func TestDefaultNetworkAccess(t *testing.T) {
+	na := DefaultNetworkAccess()
+	if na.Restricted() {
+		t.Error("default network access should not be restricted")
+	}
+	if !na.OwnerBypass {
+		t.Error("OwnerBypass should be true by default")
+	}
+	if allowed, _ := na.Evaluate("unknown"); !allowed {
+		t.Error("unrestricted policy should allow any client IP")
+	}
+}
+
+func TestNetworkAccess_Evaluate(t *testing.T) {
+	na := &NetworkAccess{
+		AllowedCidrs: []string{"10.0.0.0/8", "203.0.113.7", "2001:db8::/32"},
+		BlockedCidrs: []string{"10.1.0.0/16"},
+	}
+	tests := []struct {
+		ip      string
+		allowed bool
+		reason  string
+	}{
+		{"10.2.3.4", true, ""},
+		{"203.0.113.7", true, ""},
+		{"::ffff:10.2.3.4", true, ""},
+		{"2001:db8::1", true, ""},
+		{"10.1.2.3", false, "blocked_cidr"},
+		{"198.51.100.1", false, "not_in_allowed_cidrs"},
+		{"unknown", false, "client_ip_unknown"},
+	}
+	for _, tt := range tests {
+		allowed, reason := na.Evaluate(tt.ip)
+		if allowed != tt.allowed || reason != tt.reason {
+			t.Errorf("Evaluate(%q) = (%v, %q), want (%v, %q)", tt.ip, allowed, reason, tt.allowed, tt.reason)
+		}
+	}
+}
+
+func TestNetworkAccess_BlockedOnly(t *testing.T) {
+	na := &NetworkAccess{BlockedCidrs: []string{"198.51.100.0/24"}}
+	if allowed, _ := na.Evaluate("198.51.100.9"); allowed {
+		t.Error("blocked CIDR should deny")
+	}
+	if allowed, _ := na.Evaluate("192.0.2.1"); !allowed {
+		t.Error("IP outside blocked CIDRs should be allowed when allowed_cidrs is empty")
+	}
+}
+
+func TestNetworkAccess_Validate(t *testing.T) {
+	if err := (&NetworkAccess{AllowedCidrs: []string{"10.0.0.0/8", "192.0.2.1"}}).Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if err := (&NetworkAccess{BlockedCidrs: []string{"10.0.0.0/33"}}).Validate(); err == nil {
+		t.Error("Validate should reject an invalid prefix length")
+	}
+	if err := (&NetworkAccess{AllowedCidrs: []string{"not-an-ip"}}).Validate(); err == nil {
+		t.Error("Validate should reject a non-IP entry")
+	}
+}
+
+func TestNetworkAccess_OnTrustedNetwork(t *testing.T) {
+	na := &NetworkAccess{TrustedCidrs: []string{"10.0.0.0/8", "2001:db8::/32"}}
+	for ip, want := range map[string]bool{"10.2.3.4": true, "::ffff:10.2.3.4": true, "2001:db8::1": true, "198.51.100.1": false, "unknown": false} {
+		if got := na.OnTrustedNetwork(ip); got != want {
+			t.Errorf("OnTrustedNetwork(%q) = %v, want %v", ip, got, want)
+		}
+	}
+	if na.Restricted() {
+		t.Error("trusted networks alone must not restrict sign-in")
+	}
+	if (*NetworkAccess)(nil).OnTrustedNetwork("10.2.3.4") {
+		t.Error("nil network access has no trusted networks")
+	}
+	if err := (&NetworkAccess{TrustedCidrs: []string{"10.0.0.0/99"}}).Validate(); err == nil {
+		t.Error("Validate should reject an invalid trusted_cidrs entry")
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "fmt"
+
+// Registration modes (registration.mode). They match the platform registration_mode; the stricter of the two applies.
+const (
+	// RegistrationModeOpen lets anyone with an email in the org's verified domains register.
+	RegistrationModeOpen = "open"
+	// RegistrationModeInviteOnly requires an unused invitation from the org.
+	RegistrationModeInviteOnly = "invite_only"
+	// RegistrationModeClosed refuses registration for emails in the org's verified domains.
+	RegistrationModeClosed = "closed"
+)
+
+// Validate checks mode.
+func (r *Registration) Validate() error {
+	if r == nil {
+		return nil
+	}
+	switch r.Mode {
+	case "", RegistrationModeOpen, RegistrationModeInviteOnly, RegistrationModeClosed:
+		return nil
+	}
+	return fmt.Errorf("registration.mode must be %q, %q or %q", RegistrationModeOpen, RegistrationModeInviteOnly, RegistrationModeClosed)
+}
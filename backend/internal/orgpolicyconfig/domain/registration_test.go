@@ -0,0 +1,27 @@
+package domain
+
+import "testing"
+
+func TestRegistration_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *Registration
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"defaults", ptr(DefaultRegistration()), false},
+		{"invite only", &Registration{Mode: RegistrationModeInviteOnly}, false},
+		{"closed", &Registration{Mode: RegistrationModeClosed}, false},
+		{"unknown", &Registration{Mode: "private"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := (&OrgPolicyConfig{Registration: &Registration{Mode: "private"}}).Validate(); err == nil {
+		t.Error("OrgPolicyConfig.Validate should reject an unknown registration mode")
+	}
+}
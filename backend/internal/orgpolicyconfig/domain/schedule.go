@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// WeekdayName returns the lowercase three-letter name used in AccessWindow.Days (e.g. "mon").
+func WeekdayName(d time.Weekday) string {
+	return strings.ToLower(d.String()[:3])
+}
+
+// Location returns the schedule's time zone; empty or unknown names fall back to UTC.
+func (a *AccessSchedule) Location() *time.Location {
+	if a == nil || a.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Validate returns an error for an unknown timezone or a window with invalid days or times.
+func (a *AccessSchedule) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.Timezone != "" {
+		if _, err := time.LoadLocation(a.Timezone); err != nil {
+			return fmt.Errorf("invalid access_schedule timezone %q", a.Timezone)
+		}
+	}
+	for i, w := range a.Windows {
+		for _, d := range w.Days {
+			if _, ok := weekdays[strings.ToLower(d)]; !ok {
+				return fmt.Errorf("access_schedule window %d: invalid day %q", i, d)
+			}
+		}
+		start, err := parseClock(w.StartTime)
+		if err != nil || start == 24*60 {
+			return fmt.Errorf("access_schedule window %d: invalid start_time %q", i, w.StartTime)
+		}
+		end, err := parseClock(w.EndTime)
+		if err != nil {
+			return fmt.Errorf("access_schedule window %d: invalid end_time %q", i, w.EndTime)
+		}
+		if start == end {
+			return fmt.Errorf("access_schedule window %d: start_time equals end_time", i)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether a member with role may sign in at now. Always true when the schedule is disabled
+// or no window applies to role; otherwise now (in the schedule timezone) must fall inside one of role's windows.
+func (a *AccessSchedule) Allows(role string, now time.Time) bool {
+	if a == nil || !a.Enabled {
+		return true
+	}
+	local := now.In(a.Location())
+	restricted := false
+	for _, w := range a.Windows {
+		if !w.appliesTo(role) {
+			continue
+		}
+		restricted = true
+		if w.contains(local) {
+			return true
+		}
+	}
+	return !restricted
+}
+
+func (w AccessWindow) appliesTo(role string) bool {
+	if len(w.Roles) == 0 {
+		return true
+	}
+	for _, r := range w.Roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether local falls in the window. For a window wrapping midnight, the part after
+// midnight belongs to the day the window started on.
+func (w AccessWindow) contains(local time.Time) bool {
+	start, err1 := parseClock(w.StartTime)
+	end, err2 := parseClock(w.EndTime)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+	if start < end {
+		return minute >= start && minute < end && w.onDay(day)
+	}
+	if minute >= start {
+		return w.onDay(day)
+	}
+	return minute < end && w.onDay((day+6)%7)
+}
+
+func (w AccessWindow) onDay(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if wd, ok := weekdays[strings.ToLower(name)]; ok && wd == d {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses "HH:MM" (00:00–24:00) into minutes since midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		if strings.TrimSpace(s) == "24:00" {
+			return 24 * 60, nil
+		}
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
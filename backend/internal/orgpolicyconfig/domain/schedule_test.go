@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultAccessSchedule(t *testing.T) {
+	as := DefaultAccessSchedule()
+	if as.Enabled {
+		t.Error("Enabled should be false by default")
+	}
+	if !as.Allows("member", time.Now()) {
+		t.Error("disabled schedule should allow sign-in")
+	}
+}
+
+func TestAccessSchedule_Allows(t *testing.T) {
+	as := &AccessSchedule{
+		Enabled:  true,
+		Timezone: "America/New_York",
+		Windows: []AccessWindow{
+			{Roles: []string{"member"}, Days: []string{"mon", "tue", "wed", "thu", "fri"}, StartTime: "08:00", EndTime: "18:00"},
+		},
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	tests := []struct {
+		name string
+		role string
+		at   time.Time
+		want bool
+	}{
+		{"weekday inside", "member", time.Date(2026, 3, 4, 9, 30, 0, 0, ny), true},
+		{"weekday before start", "member", time.Date(2026, 3, 4, 7, 59, 0, 0, ny), false},
+		{"weekday at end (exclusive)", "member", time.Date(2026, 3, 4, 18, 0, 0, 0, ny), false},
+		{"saturday", "member", time.Date(2026, 3, 7, 10, 0, 0, 0, ny), false},
+		{"UTC instant inside NY hours", "member", time.Date(2026, 3, 4, 14, 0, 0, 0, time.UTC), true},
+		{"role without windows", "admin", time.Date(2026, 3, 7, 3, 0, 0, 0, ny), true},
+	}
+	for _, tt := range tests {
+		if got := as.Allows(tt.role, tt.at); got != tt.want {
+			t.Errorf("%s: Allows(%q, %v) = %v, want %v", tt.name, tt.role, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestAccessSchedule_OvernightWindow(t *testing.T) {
+	as := &AccessSchedule{
+		Enabled: true,
+		Windows: []AccessWindow{{Days: []string{"fri"}, StartTime: "22:00", EndTime: "06:00"}},
+	}
+	if !as.Allows("member", time.Date(2026, 3, 6, 23, 0, 0, 0, time.UTC)) {
+		t.Error("Friday 23:00 should be inside the Friday overnight window")
+	}
+	if !as.Allows("member", time.Date(2026, 3, 7, 5, 0, 0, 0, time.UTC)) {
+		t.Error("Saturday 05:00 should be inside the Friday overnight window")
+	}
+	if as.Allows("member", time.Date(2026, 3, 6, 5, 0, 0, 0, time.UTC)) {
+		t.Error("Friday 05:00 belongs to Thursday's window, which does not exist")
+	}
+}
+
+func TestAccessSchedule_Validate(t *testing.T) {
+	valid := &AccessSchedule{Timezone: "UTC", Windows: []AccessWindow{{Days: []string{"Mon"}, StartTime: "08:00", EndTime: "24:00"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	invalid := []*AccessSchedule{
+		{Timezone: "Mars/Olympus"},
+		{Windows: []AccessWindow{{Days: []string{"funday"}, StartTime: "08:00", EndTime: "18:00"}}},
+		{Windows: []AccessWindow{{StartTime: "8am", EndTime: "18:00"}}},
+		{Windows: []AccessWindow{{StartTime: "08:00", EndTime: "08:00"}}},
+	}
+	for i, as := range invalid {
+		if err := as.Validate(); err == nil {
+			t.Errorf("case %d: Validate should fail for %+v", i, as)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// ChangeSourceScheduled is recorded in the config history for a scheduled change applied by the scheduler.
+const ChangeSourceScheduled = "scheduled"
+
+// ScheduledStatus is the state of a scheduled config change.
+type ScheduledStatus string
+
+const (
+	ScheduledPending   ScheduledStatus = "pending"
+	ScheduledApplied   ScheduledStatus = "applied"
+	ScheduledCancelled ScheduledStatus = "cancelled"
+	ScheduledFailed    ScheduledStatus = "failed"
+)
+
+// ScheduledChange is an UpdateOrgPolicyConfig update to apply at EffectiveAt. Config holds the update as sent;
+// when Sections is set only those sections are applied on top of the config current at EffectiveAt, otherwise
+// Config replaces the whole config.
+type ScheduledChange struct {
+	ID          string
+	OrgID       string
+	Config      *OrgPolicyConfig
+	Sections    []string
+	EffectiveAt time.Time
+	Status      ScheduledStatus
+	CreatedBy   string
+	CreatedAt   time.Time
+	Resolution
+}
+
+// Resolution records how a scheduled change left pending: who cancelled it, when it was applied, cancelled or
+// failed, the config version it was stored as, and why it failed.
+type Resolution struct {
+	By             string
+	At             time.Time
+	AppliedVersion int64
+	Error          string
+}
@@ -0,0 +1,89 @@
+package domain
+
+import "fmt"
+
+// Section names, as in the JSON and proto forms of OrgPolicyConfig. UpdateOrgPolicyConfig update masks name these.
+const (
+	SectionAuthMfa            = "auth_mfa"
+	SectionDeviceTrust        = "device_trust"
+	SectionSessionMgmt        = "session_mgmt"
+	SectionAccessControl      = "access_control"
+	SectionActionRestrictions = "action_restrictions"
+	SectionNotifications      = "notifications"
+	SectionNetworkAccess      = "network_access"
+	SectionAccessSchedule     = "access_schedule"
+	SectionTokenClaims        = "token_claims"
+	SectionPasswordPolicy     = "password_policy"
+	SectionChangeApproval     = "change_approval"
+	SectionRegistration       = "registration"
+)
+
+// ApplySections returns a copy of current with the named sections taken from update; the other sections are kept.
+// A named section that update leaves nil is reset to its default. It fails for a name that is not a section.
+func ApplySections(current, update *OrgPolicyConfig, sections []string) (*OrgPolicyConfig, error) {
+	out := OrgPolicyConfig{}
+	if current != nil {
+		out = *current
+	}
+	if update == nil {
+		update = &OrgPolicyConfig{}
+	}
+	for _, name := range sections {
+		switch name {
+		case SectionAuthMfa:
+			out.AuthMfa = update.AuthMfa
+		case SectionDeviceTrust:
+			out.DeviceTrust = update.DeviceTrust
+		case SectionSessionMgmt:
+			out.SessionMgmt = update.SessionMgmt
+		case SectionAccessControl:
+			out.AccessControl = update.AccessControl
+		case SectionActionRestrictions:
+			out.ActionRestrictions = update.ActionRestrictions
+		case SectionNotifications:
+			out.Notifications = update.Notifications
+		case SectionNetworkAccess:
+			out.NetworkAccess = update.NetworkAccess
+		case SectionAccessSchedule:
+			out.AccessSchedule = update.AccessSchedule
+		case SectionTokenClaims:
+			out.TokenClaims = update.TokenClaims
+		case SectionPasswordPolicy:
+			out.PasswordPolicy = update.PasswordPolicy
+		case SectionChangeApproval:
+			out.ChangeApproval = update.ChangeApproval
+		case SectionRegistration:
+			out.Registration = update.Registration
+		default:
+			return nil, fmt.Errorf("unknown policy section %q", name)
+		}
+	}
+	return &out, nil
+}
+
+// SetSections returns the names of the sections c sets (non-nil), in declaration order. ApplySections with these
+// names takes exactly c's sections, e.g. to apply a partial config such as a preset.
+func (c *OrgPolicyConfig) SetSections() []string {
+	if c == nil {
+		return nil
+	}
+	var out []string
+	add := func(set bool, name string) {
+		if set {
+			out = append(out, name)
+		}
+	}
+	add(c.AuthMfa != nil, SectionAuthMfa)
+	add(c.DeviceTrust != nil, SectionDeviceTrust)
+	add(c.SessionMgmt != nil, SectionSessionMgmt)
+	add(c.AccessControl != nil, SectionAccessControl)
+	add(c.ActionRestrictions != nil, SectionActionRestrictions)
+	add(c.Notifications != nil, SectionNotifications)
+	add(c.NetworkAccess != nil, SectionNetworkAccess)
+	add(c.AccessSchedule != nil, SectionAccessSchedule)
+	add(c.TokenClaims != nil, SectionTokenClaims)
+	add(c.PasswordPolicy != nil, SectionPasswordPolicy)
+	add(c.ChangeApproval != nil, SectionChangeApproval)
+	add(c.Registration != nil, SectionRegistration)
+	return out
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestApplySections(t *testing.T) {
+	current := &OrgPolicyConfig{
+		AuthMfa:       &AuthMfa{MfaRequirement: "always"},
+		AccessControl: &AccessControl{DefaultAction: "deny", BlockedDomains: []string{"example.com"}},
+		SessionMgmt:   &SessionMgmt{SessionMaxTtl: "8h"},
+	}
+	update := &OrgPolicyConfig{
+		AuthMfa:       &AuthMfa{MfaRequirement: "untrusted"},
+		AccessControl: &AccessControl{DefaultAction: "allow"},
+	}
+
+	out, err := ApplySections(current, update, []string{SectionAuthMfa, SectionSessionMgmt})
+	if err != nil {
+		t.Fatalf("ApplySections: %v", err)
+	}
+	if out.AuthMfa.MfaRequirement != "untrusted" {
+		t.Errorf("auth_mfa not replaced: %+v", out.AuthMfa)
+	}
+	if out.SessionMgmt != nil {
+		t.Errorf("session_mgmt unset in update should be reset, got %+v", out.SessionMgmt)
+	}
+	if out.AccessControl.DefaultAction != "deny" || len(out.AccessControl.BlockedDomains) != 1 {
+		t.Errorf("access_control not in mask should be kept, got %+v", out.AccessControl)
+	}
+	if current.AuthMfa.MfaRequirement != "always" || current.SessionMgmt == nil {
+		t.Error("current must not be modified")
+	}
+
+	if out, err := ApplySections(nil, update, []string{SectionAccessControl}); err != nil || out.AccessControl.DefaultAction != "allow" || out.AuthMfa != nil {
+		t.Errorf("ApplySections(nil current) = %+v, %v", out, err)
+	}
+	if _, err := ApplySections(current, update, []string{"auth_mfa.mfa_requirement"}); err == nil {
+		t.Error("nested path should be rejected")
+	}
+}
+
+func TestSetSections(t *testing.T) {
+	c := &OrgPolicyConfig{
+		SessionMgmt:    &SessionMgmt{SessionMaxTtl: "8h"},
+		AuthMfa:        &AuthMfa{MfaRequirement: "always"},
+		PasswordPolicy: &PasswordPolicy{},
+	}
+	got := c.SetSections()
+	want := []string{SectionAuthMfa, SectionSessionMgmt, SectionPasswordPolicy}
+	if len(got) != len(want) {
+		t.Fatalf("SetSections() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SetSections() = %v, want %v", got, want)
+		}
+	}
+	if _, err := ApplySections(nil, c, got); err != nil {
+		t.Errorf("ApplySections with SetSections: %v", err)
+	}
+	if got := (*OrgPolicyConfig)(nil).SetSections(); got != nil {
+		t.Errorf("nil config SetSections() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Refresh expiry modes (session_mgmt.refresh_expiry).
+const (
+	// RefreshExpiryRolling extends the session by the refresh token lifetime on every refresh, so only sessions left
+	// unused for a full lifetime expire.
+	RefreshExpiryRolling = "rolling"
+	// RefreshExpiryAbsolute ends the session one refresh token lifetime after sign-in, however often it refreshes.
+	RefreshExpiryAbsolute = "absolute"
+)
+
+// MinMaxSessionAge bounds session_mgmt.max_session_age from below, so a typo cannot sign everyone out at once.
+const MinMaxSessionAge = 5 * time.Minute
+
+// Validate checks refresh_expiry and max_session_age.
+func (m *SessionMgmt) Validate() error {
+	if m == nil {
+		return nil
+	}
+	switch m.RefreshExpiry {
+	case "", RefreshExpiryRolling, RefreshExpiryAbsolute:
+	default:
+		return fmt.Errorf("session_mgmt.refresh_expiry must be %q or %q", RefreshExpiryRolling, RefreshExpiryAbsolute)
+	}
+	if m.MaxSessionAge != "" {
+		if d, err := time.ParseDuration(m.MaxSessionAge); err != nil || d < MinMaxSessionAge {
+			return fmt.Errorf("session_mgmt.max_session_age must be a duration of at least %s, e.g. \"720h\"", MinMaxSessionAge)
+		}
+	}
+	return nil
+}
+
+// AbsoluteRefresh reports whether the org's sessions end a fixed time after sign-in instead of being extended by
+// each refresh.
+func (m *SessionMgmt) AbsoluteRefresh() bool {
+	return m != nil && m.RefreshExpiry == RefreshExpiryAbsolute
+}
+
+// MaxAge returns how long after sign-in the org's sessions end, however they are refreshed; 0 when there is no cap
+// (or max_session_age is invalid).
+func (m *SessionMgmt) MaxAge() time.Duration {
+	if m == nil || m.MaxSessionAge == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(m.MaxSessionAge)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionMgmt_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sm      *SessionMgmt
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"defaults", ptr(DefaultSessionMgmt()), false},
+		{"empty", &SessionMgmt{}, false},
+		{"absolute with max age", &SessionMgmt{RefreshExpiry: RefreshExpiryAbsolute, MaxSessionAge: "720h"}, false},
+		{"minimum max age", &SessionMgmt{MaxSessionAge: "5m"}, false},
+		{"unknown expiry", &SessionMgmt{RefreshExpiry: "sliding"}, true},
+		{"max age not a duration", &SessionMgmt{MaxSessionAge: "30d"}, true},
+		{"max age too short", &SessionMgmt{MaxSessionAge: "1m"}, true},
+		{"negative max age", &SessionMgmt{MaxSessionAge: "-1h"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sm.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionMgmt_ExpiryAndMaxAge(t *testing.T) {
+	var nilSM *SessionMgmt
+	if nilSM.AbsoluteRefresh() || nilSM.MaxAge() != 0 {
+		t.Error("nil SessionMgmt should be rolling without a max age")
+	}
+	if d := DefaultSessionMgmt(); d.AbsoluteRefresh() || d.MaxAge() != 0 {
+		t.Error("default SessionMgmt should be rolling without a max age")
+	}
+	m := &SessionMgmt{RefreshExpiry: RefreshExpiryAbsolute, MaxSessionAge: "720h"}
+	if !m.AbsoluteRefresh() {
+		t.Error("AbsoluteRefresh = false, want true")
+	}
+	if got := m.MaxAge(); got != 720*time.Hour {
+		t.Errorf("MaxAge = %v, want 720h", got)
+	}
+}
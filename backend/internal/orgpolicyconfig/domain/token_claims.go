@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+const maxAudienceLength = 256
+
+// Validate checks audience and claim limits and rejects reserved or malformed claim names (iss, sub, org_id, ...),
+// so an org cannot change how its tokens are validated.
+func (t *TokenClaims) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if len(t.Audiences) > security.MaxCustomAudiences {
+		return fmt.Errorf("token_claims allows at most %d audiences", security.MaxCustomAudiences)
+	}
+	for _, a := range t.Audiences {
+		if a == "" || len(a) > maxAudienceLength || strings.ContainsAny(a, " \t\r\n") {
+			return fmt.Errorf("invalid token_claims audience %q", a)
+		}
+	}
+	if len(t.Claims) > security.MaxCustomClaims {
+		return fmt.Errorf("token_claims allows at most %d claims", security.MaxCustomClaims)
+	}
+	for name, v := range t.Claims {
+		if err := security.ValidateClaimName(name); err != nil {
+			return fmt.Errorf("token_claims: %v", err)
+		}
+		if len(v) > security.MaxCustomClaimValue {
+			return fmt.Errorf("token_claims: claim %q value exceeds %d characters", name, security.MaxCustomClaimValue)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTokenClaims_Validate(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= 20; i++ {
+		tooMany[fmt.Sprintf("claim_%d", i)] = "v"
+	}
+	tests := []struct {
+		name    string
+		tc      *TokenClaims
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"valid", &TokenClaims{Audiences: []string{"https://api.example.com"}, Claims: map[string]string{"department": "eng", "employee_id": "E-42"}}, false},
+		{"reserved sub", &TokenClaims{Claims: map[string]string{"sub": "admin"}}, true},
+		{"reserved org_id", &TokenClaims{Claims: map[string]string{"org_id": "other"}}, true},
+		{"invalid name", &TokenClaims{Claims: map[string]string{"1bad name": "v"}}, true},
+		{"long value", &TokenClaims{Claims: map[string]string{"department": strings.Repeat("a", 257)}}, true},
+		{"too many claims", &TokenClaims{Claims: tooMany}, true},
+		{"empty audience", &TokenClaims{Audiences: []string{""}}, true},
+		{"audience with space", &TokenClaims{Audiences: []string{"a b"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tc.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
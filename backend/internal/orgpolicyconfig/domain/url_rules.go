@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Limits for URL rules. Regexes use Go's RE2 engine, so matching is linear in the URL length; the caps bound
+// the number of rules evaluated per check and the size of what they are matched against.
+const (
+	MaxURLRules     = 500
+	MaxURLLength    = 8192 // CheckUrlAccess denies longer URLs
+	maxRegexLength  = 512
+	maxPathLength   = 1024
+	regexCacheLimit = 4096
+)
+
+// URL rule actions.
+const (
+	URLRuleAllow = "allow"
+	URLRuleBlock = "block"
+)
+
+// URLRule allows or blocks URLs more precisely than the domain lists. Exactly one of PathPrefix or Regex is set.
+// Rules are evaluated before the domain and category lists; a matching block rule wins over a matching allow rule.
+type URLRule struct {
+	Action     string `json:"action"`                // allow, block
+	Host       string `json:"host,omitempty"`        // required with path_prefix; optional scope for regex. "*.example.com" also matches subdomains
+	PathPrefix string `json:"path_prefix,omitempty"` // e.g. "/admin"; matches "/admin" and "/admin/...", not "/administrator"
+	Regex      string `json:"regex,omitempty"`       // RE2, matched against the normalized URL "scheme://host/path?query"
+}
+
+var (
+	regexCacheMu sync.Mutex
+	regexCache   = make(map[string]*regexp.Regexp)
+)
+
+// compileRuleRegex compiles pattern, reusing earlier compilations so CheckUrlAccess does not recompile every rule
+// on every request.
+func compileRuleRegex(pattern string) (*regexp.Regexp, error) {
+	regexCacheMu.Lock()
+	defer regexCacheMu.Unlock()
+	if re, ok := regexCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(regexCache) >= regexCacheLimit {
+		regexCache = make(map[string]*regexp.Regexp)
+	}
+	regexCache[pattern] = re
+	return re, nil
+}
+
+// Validate checks the rule's action, host, and that exactly one of path_prefix or a compilable regex is set.
+func (r *URLRule) Validate() error {
+	if r.Action != URLRuleAllow && r.Action != URLRuleBlock {
+		return fmt.Errorf("url rule action must be allow or block, got %q", r.Action)
+	}
+	if r.Host != "" {
+		if _, err := NormalizeDomain(r.Host); err != nil {
+			return fmt.Errorf("url rule: %v", err)
+		}
+	}
+	switch {
+	case r.PathPrefix != "" && r.Regex != "":
+		return fmt.Errorf("url rule must set path_prefix or regex, not both")
+	case r.PathPrefix != "":
+		if r.Host == "" {
+			return fmt.Errorf("url rule with path_prefix %q requires host", r.PathPrefix)
+		}
+		if !strings.HasPrefix(r.PathPrefix, "/") || len(r.PathPrefix) > maxPathLength {
+			return fmt.Errorf("url rule path_prefix %q must start with / and be at most %d characters", r.PathPrefix, maxPathLength)
+		}
+	case r.Regex != "":
+		if len(r.Regex) > maxRegexLength {
+			return fmt.Errorf("url rule regex exceeds %d characters", maxRegexLength)
+		}
+		if _, err := compileRuleRegex(r.Regex); err != nil {
+			return fmt.Errorf("url rule regex %q: %v", r.Regex, err)
+		}
+	default:
+		return fmt.Errorf("url rule must set path_prefix or regex")
+	}
+	return nil
+}
+
+// NormalizeURL returns u as matched by URL rules: lowercase scheme and host (no port), the cleaned path
+// ("/docs/../admin" becomes "/admin"), and the raw query; the fragment is dropped. Returns the host and path too.
+func NormalizeURL(u *url.URL) (normalized, host, cleanPath string) {
+	host = strings.ToLower(u.Hostname())
+	cleanPath = "/"
+	if u.Path != "" {
+		cleanPath = path.Clean("/" + u.Path)
+	}
+	normalized = strings.ToLower(u.Scheme) + "://" + host + cleanPath
+	if u.RawQuery != "" {
+		normalized += "?" + u.RawQuery
+	}
+	return normalized, host, cleanPath
+}
+
+// MatchURLRules returns the action of the rules matching u: "block" if any block rule matches, else "allow" if any
+// allow rule matches, else "". The matching rule is returned for the denial reason.
+func (ac *AccessControl) MatchURLRules(u *url.URL) (action string, rule *URLRule) {
+	if len(ac.URLRules) == 0 {
+		return "", nil
+	}
+	normalized, host, cleanPath := NormalizeURL(u)
+	for i := range ac.URLRules {
+		r := &ac.URLRules[i]
+		if !r.matches(normalized, host, cleanPath) {
+			continue
+		}
+		if r.Action == URLRuleBlock {
+			return URLRuleBlock, r
+		}
+		if rule == nil {
+			action, rule = URLRuleAllow, r
+		}
+	}
+	return action, rule
+}
+
+func (r *URLRule) matches(normalized, host, cleanPath string) bool {
+	if r.Host != "" && !ruleHostMatches(host, strings.ToLower(r.Host)) {
+		return false
+	}
+	if r.PathPrefix != "" {
+		prefix := strings.TrimSuffix(r.PathPrefix, "/")
+		return prefix == "" || cleanPath == prefix || strings.HasPrefix(cleanPath, prefix+"/")
+	}
+	if r.Regex != "" {
+		re, err := compileRuleRegex(r.Regex)
+		return err == nil && re.MatchString(normalized)
+	}
+	return false
+}
+
+func ruleHostMatches(host, pattern string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return host == suffix || strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
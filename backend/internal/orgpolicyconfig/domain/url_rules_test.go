@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestURLRule_Validate(t *testing.T) {
+	valid := []URLRule{
+		{Action: URLRuleAllow, Host: "example.com", PathPrefix: "/docs"},
+		{Action: URLRuleBlock, Host: "*.example.com", PathPrefix: "/"},
+		{Action: URLRuleBlock, Regex: `^https://[^/]+/admin`},
+		{Action: URLRuleAllow, Host: "example.com", Regex: `\?ref=partner`},
+	}
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("Validate(%+v): %v", r, err)
+		}
+	}
+	invalid := []URLRule{
+		{Host: "example.com", PathPrefix: "/docs"},                                // no action
+		{Action: URLRuleAllow, PathPrefix: "/docs"},                               // path_prefix without host
+		{Action: URLRuleAllow, Host: "example.com", PathPrefix: "docs"},           // not absolute
+		{Action: URLRuleAllow, Host: "example.com"},                               // neither path_prefix nor regex
+		{Action: URLRuleAllow, Host: "example.com", PathPrefix: "/a", Regex: "a"}, // both
+		{Action: URLRuleBlock, Regex: "(unclosed"},
+		{Action: URLRuleBlock, Regex: strings.Repeat("a", maxRegexLength+1)},
+		{Action: URLRuleBlock, Host: "bad host", PathPrefix: "/"},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", r)
+		}
+	}
+}
+
+func TestAccessControl_ValidateURLRuleLimit(t *testing.T) {
+	ac := &AccessControl{URLRules: make([]URLRule, MaxURLRules+1)}
+	for i := range ac.URLRules {
+		ac.URLRules[i] = URLRule{Action: URLRuleBlock, Host: "example.com", PathPrefix: "/x"}
+	}
+	if err := ac.Validate(); err == nil {
+		t.Error("Validate should reject more than MaxURLRules rules")
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	u, _ := url.Parse("HTTPS://Example.COM:8443/docs/../admin/./panel?tab=1#frag")
+	normalized, host, p := NormalizeURL(u)
+	if normalized != "https://example.com/admin/panel?tab=1" || host != "example.com" || p != "/admin/panel" {
+		t.Errorf("NormalizeURL = (%q, %q, %q)", normalized, host, p)
+	}
+}
+
+func TestAccessControl_MatchURLRules(t *testing.T) {
+	ac := &AccessControl{URLRules: []URLRule{
+		{Action: URLRuleAllow, Host: "*.example.com", PathPrefix: "/docs/"},
+		{Action: URLRuleBlock, Regex: `/docs/private`},
+	}}
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/docs", URLRuleAllow},
+		{"https://www.example.com/docs/a", URLRuleAllow},
+		{"https://www.example.com/docs/private/x", URLRuleBlock},
+		{"https://example.org/docs", ""},
+		{"https://example.com/documents", ""},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		if got, _ := ac.MatchURLRules(u); got != tt.want {
+			t.Errorf("MatchURLRules(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+)
+
+// ProposedConfig is an org policy config change awaiting approval: the config approval stores, the version it was
+// proposed against, and the settings it changes relative to that version.
+type ProposedConfig struct {
+	Config      *domain.OrgPolicyConfig
+	BaseVersion int64
+	Changes     []domain.FieldChange
+}
+
+// ProposeConfig validates an update as UpdateOrgPolicyConfig would (update_mask sections, optional etag) and returns
+// the resulting config without storing it. Used by ChangeRequestService; the caller has checked org admin.
+func (s *Server) ProposeConfig(ctx context.Context, orgID string, update *orgpolicyconfigv1.OrgPolicyConfig, sections []string, etag string) (*ProposedConfig, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "org policy config not configured")
+	}
+	expected, conditional, err := parseEtag(etag)
+	if err != nil {
+		return nil, err
+	}
+	current, version, err := s.repo.GetVersioned(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if conditional && version != expected {
+		return nil, status.Error(codes.FailedPrecondition, "org policy config has changed; reload and retry")
+	}
+	config := protoToDomain(update)
+	if len(sections) > 0 {
+		if config, err = domain.ApplySections(current, config, sections); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "update_mask: "+err.Error())
+		}
+	}
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+	changes, err := domain.Diff(current, config)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(changes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "proposed config does not change any setting")
+	}
+	return &ProposedConfig{Config: config, BaseVersion: version, Changes: changes}, nil
+}
+
+// ApplyProposedConfig stores an approved config if the org's config is still at baseVersion, bypassing the
+// change_approval check, then syncs org_mfa_settings and notifies SubscribeBrowserPolicy streams. It returns the new
+// version, or FailedPrecondition when the config has changed since the proposal.
+func (s *Server) ApplyProposedConfig(ctx context.Context, orgID string, config *domain.OrgPolicyConfig, baseVersion int64, change domain.Change) (int64, error) {
+	if s.repo == nil {
+		return 0, status.Error(codes.Unimplemented, "org policy config not configured")
+	}
+	version, ok, err := s.repo.UpdateIfVersion(ctx, orgID, config, baseVersion, change)
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+	if !ok {
+		return 0, status.Error(codes.FailedPrecondition, "org policy config has changed since the change was proposed; reject it and propose again")
+	}
+	if err := s.syncMFASettings(ctx, orgID, config); err != nil {
+		return 0, err
+	}
+	s.publish(orgID)
+	return version, nil
+}
+
+// ConfigToProto returns config merged with defaults in its API form.
+func ConfigToProto(config *domain.OrgPolicyConfig) *orgpolicyconfigv1.OrgPolicyConfig {
+	return domainToProto(domain.MergeWithDefaults(config))
+}
+
+// SectionsToProto returns the sections config sets in their API form, without defaults for the others; e.g. for a
+// partial config such as a preset.
+func SectionsToProto(config *domain.OrgPolicyConfig) *orgpolicyconfigv1.OrgPolicyConfig {
+	return domainToProto(config)
+}
@@ -2,40 +2,92 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
 	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	"zero-trust-control-plane/backend/internal/audit"
 	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
 	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
 	orgmfasettingsrepo "zero-trust-control-plane/backend/internal/orgmfasettings/repository"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig"
 	"zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
 	"zero-trust-control-plane/backend/internal/orgpolicyconfig/repository"
 	"zero-trust-control-plane/backend/internal/platform/rbac"
+	urlexceptiondomain "zero-trust-control-plane/backend/internal/urlexception/domain"
 )
 
+// browserPolicyResyncInterval bounds how stale a SubscribeBrowserPolicy stream can get when a change is made on
+// another server replica (the hub is in-process).
+const browserPolicyResyncInterval = time.Minute
+
+// Page sizes for ListPolicyConfigHistory.
+const (
+	defaultHistoryPageSize = 20
+	maxHistoryPageSize     = 100
+)
+
+// errApprovalRequired is returned by direct writes when the org's change_approval section requires a second admin.
+var errApprovalRequired = status.Error(codes.FailedPrecondition, "org requires approval for policy changes; propose the change with ChangeRequestService")
+
+// maxUpdateAttempts bounds how often UpdateOrgPolicyConfig without an etag re-applies an update that lost a race
+// with another write before giving up with Aborted.
+const maxUpdateAttempts = 3
+
+// GroupLister returns the names of the groups a user belongs to in an org (e.g. grouprepo.Repository).
+type GroupLister interface {
+	ListUserGroupNames(ctx context.Context, orgID, userID string) ([]string, error)
+}
+
+// URLExceptionLookup returns a user's active URL exception for a domain (e.g. urlexception repository.Repository).
+type URLExceptionLookup interface {
+	GetActive(ctx context.Context, orgID, userID, domain string, now time.Time) (*urlexceptiondomain.Exception, error)
+}
+
 // Server implements OrgPolicyConfigService. Caller must be org admin or owner.
 type Server struct {
 	orgpolicyconfigv1.UnimplementedOrgPolicyConfigServiceServer
 	repo               repository.Repository
 	membershipRepo     membershiprepo.Repository
 	orgMfaSettingsRepo orgmfasettingsrepo.Repository
+	hub                *orgpolicyconfig.Hub
+	groups             GroupLister
+	exceptions         URLExceptionLookup
+	auditLogger        audit.AuditLogger
+	resyncInterval     time.Duration
 }
 
-// NewServer returns a new OrgPolicyConfig gRPC server.
+// NewServer returns a new OrgPolicyConfig gRPC server. hub is optional; when nil, SubscribeBrowserPolicy returns
+// Unimplemented and updates are not pushed. groups is optional; when nil, access_control.group_rules never apply.
+// exceptions is optional; when nil, CheckUrlAccess ignores URL exceptions. auditLogger is optional; when set, URLs
+// denied by CheckUrlAccess are audited as url_access_denied (the source of the blocked-domain analytics).
 func NewServer(
 	repo repository.Repository,
 	membershipRepo membershiprepo.Repository,
 	orgMfaSettingsRepo orgmfasettingsrepo.Repository,
+	hub *orgpolicyconfig.Hub,
+	groups GroupLister,
+	exceptions URLExceptionLookup,
+	auditLogger audit.AuditLogger,
 ) *Server {
 	return &Server{
 		repo:               repo,
 		membershipRepo:     membershipRepo,
 		orgMfaSettingsRepo: orgMfaSettingsRepo,
+		hub:                hub,
+		groups:             groups,
+		exceptions:         exceptions,
+		auditLogger:        auditLogger,
+		resyncInterval:     browserPolicyResyncInterval,
 	}
 }
 
@@ -59,22 +111,27 @@ func (s *Server) GetOrgPolicyConfig(ctx context.Context, req *orgpolicyconfigv1.
 	if useOrgID == "" {
 		return nil, status.Error(codes.InvalidArgument, "org_id required")
 	}
-	config, err := s.repo.GetByOrgID(ctx, useOrgID)
+	config, version, err := s.repo.GetVersioned(ctx, useOrgID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	merged := domain.MergeWithDefaults(config)
 	return &orgpolicyconfigv1.GetOrgPolicyConfigResponse{
 		Config: domainToProto(merged),
+		Etag:   formatEtag(version),
 	}, nil
 }
 
 // UpdateOrgPolicyConfig updates the org policy config. Caller must be org admin or owner. Syncs auth_mfa and device_trust to org_mfa_settings.
+// With update_mask only the named sections are replaced. With an etag the update applies only if the config has not
+// changed since the etag was read (FailedPrecondition otherwise). Fails with FailedPrecondition while the org
+// requires change approval. With effective_at the update is validated and stored as a scheduled change instead,
+// applied by the scheduler at that time (see ActivateDue).
 func (s *Server) UpdateOrgPolicyConfig(ctx context.Context, req *orgpolicyconfigv1.UpdateOrgPolicyConfigRequest) (*orgpolicyconfigv1.UpdateOrgPolicyConfigResponse, error) {
 	if s.repo == nil {
 		return nil, status.Error(codes.Unimplemented, "method UpdateOrgPolicyConfig not implemented")
 	}
-	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
 	if err != nil {
 		return nil, err
 	}
@@ -89,30 +146,252 @@ func (s *Server) UpdateOrgPolicyConfig(ctx context.Context, req *orgpolicyconfig
 	if useOrgID == "" {
 		return nil, status.Error(codes.InvalidArgument, "org_id required")
 	}
-	config := protoToDomain(req.GetConfig())
-	if err := s.repo.Upsert(ctx, useOrgID, config); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	update := protoToDomain(req.GetConfig())
+	sections := req.GetUpdateMask().GetPaths()
+	if req.GetEffectiveAt() != nil {
+		return s.scheduleUpdate(ctx, useOrgID, userID, update, sections, req.GetEtag(), req.GetEffectiveAt().AsTime())
+	}
+	change := domain.Change{By: userID, Source: domain.ChangeSourceUpdate}
+	config, version, err := s.storeConfig(ctx, useOrgID, req.GetEtag(), change, func(current *domain.OrgPolicyConfig) (*domain.OrgPolicyConfig, error) {
+		if len(sections) == 0 {
+			return update, nil
+		}
+		next, err := domain.ApplySections(current, update, sections)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "update_mask: "+err.Error())
+		}
+		return next, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	// Sync auth_mfa and device_trust to org_mfa_settings so auth_service and policy engine keep working.
-	if s.orgMfaSettingsRepo != nil && (config.AuthMfa != nil || config.DeviceTrust != nil) {
-		merged := domain.MergeWithDefaults(config)
-		settings := domainToOrgMFASettings(useOrgID, merged)
-		if err := s.orgMfaSettingsRepo.Upsert(ctx, settings); err != nil {
-			return nil, status.Error(codes.Internal, "failed to sync org MFA settings: "+err.Error())
+	if updatesMFASettings(update, sections) {
+		if err := s.syncMFASettings(ctx, useOrgID, config); err != nil {
+			return nil, err
 		}
 	}
-	updated := domain.MergeWithDefaults(config)
+	s.publish(useOrgID)
 	return &orgpolicyconfigv1.UpdateOrgPolicyConfigResponse{
-		Config: domainToProto(updated),
+		Config: domainToProto(domain.MergeWithDefaults(config)),
+		Etag:   formatEtag(version),
 	}, nil
 }
 
-// GetBrowserPolicy returns only access_control and action_restrictions for the caller's org. Caller must be an org member (any role).
+// storeConfig writes the config that build derives from the stored one and returns it with its new version. The
+// write is conditional on the version build saw. With an etag, a version other than the etag's fails with
+// FailedPrecondition. Without one, a write that loses a race is rebuilt on top of the winner, up to
+// maxUpdateAttempts times, then fails with Aborted. When the stored config requires change approval nothing is
+// written (FailedPrecondition); approved changes are stored by ApplyProposedConfig instead.
+func (s *Server) storeConfig(ctx context.Context, orgID, etag string, change domain.Change, build func(current *domain.OrgPolicyConfig) (*domain.OrgPolicyConfig, error)) (*domain.OrgPolicyConfig, int64, error) {
+	expected, conditional, err := parseEtag(etag)
+	if err != nil {
+		return nil, 0, err
+	}
+	for attempt := 1; ; attempt++ {
+		current, currentVersion, err := s.repo.GetVersioned(ctx, orgID)
+		if err != nil {
+			return nil, 0, status.Error(codes.Internal, err.Error())
+		}
+		if current.RequiresChangeApproval() {
+			return nil, 0, errApprovalRequired
+		}
+		if conditional && currentVersion != expected {
+			return nil, 0, status.Error(codes.FailedPrecondition, "org policy config has changed; reload and retry")
+		}
+		config, err := build(current)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := validateConfig(config); err != nil {
+			return nil, 0, err
+		}
+		version, ok, err := s.repo.UpdateIfVersion(ctx, orgID, config, currentVersion, change)
+		if err != nil {
+			return nil, 0, status.Error(codes.Internal, err.Error())
+		}
+		if ok {
+			return config, version, nil
+		}
+		if conditional {
+			return nil, 0, status.Error(codes.FailedPrecondition, "org policy config has changed; reload and retry")
+		}
+		if attempt == maxUpdateAttempts {
+			return nil, 0, status.Error(codes.Aborted, "org policy config is being changed concurrently; retry")
+		}
+	}
+}
+
+// syncMFASettings mirrors config's auth_mfa and device_trust (merged with defaults) into org_mfa_settings, if configured.
+func (s *Server) syncMFASettings(ctx context.Context, orgID string, config *domain.OrgPolicyConfig) error {
+	if s.orgMfaSettingsRepo == nil {
+		return nil
+	}
+	settings := domainToOrgMFASettings(orgID, domain.MergeWithDefaults(config))
+	if err := s.orgMfaSettingsRepo.Upsert(ctx, settings); err != nil {
+		return status.Error(codes.Internal, "failed to sync org MFA settings: "+err.Error())
+	}
+	return nil
+}
+
+// ListPolicyConfigHistory returns the org's config versions, newest first, each with the settings it changed.
+// Caller must be org admin or owner.
+func (s *Server) ListPolicyConfigHistory(ctx context.Context, req *orgpolicyconfigv1.ListPolicyConfigHistoryRequest) (*orgpolicyconfigv1.ListPolicyConfigHistoryResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListPolicyConfigHistory not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	requestOrgID := req.GetOrgId()
+	if requestOrgID != "" && requestOrgID != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	pageSize := int32(defaultHistoryPageSize)
+	if ps := req.GetPagination().GetPageSize(); ps > 0 {
+		pageSize = ps
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+	offset := int32(0)
+	if tok := req.GetPagination().GetPageToken(); tok != "" {
+		if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+			offset = int32(n)
+		}
+	}
+	// One extra version is read so the oldest on the page can be diffed against its predecessor.
+	list, err := s.repo.ListVersions(ctx, orgID, pageSize+1, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	more := len(list) > int(pageSize)
+	out := &orgpolicyconfigv1.ListPolicyConfigHistoryResponse{Pagination: &commonv1.PaginationResult{}}
+	for i, v := range list {
+		if i == int(pageSize) {
+			break
+		}
+		var prev *domain.OrgPolicyConfig
+		if i+1 < len(list) {
+			prev = list[i+1].Config
+		}
+		changes, err := domain.Diff(prev, v.Config)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		out.Versions = append(out.Versions, configVersionToProto(v, changes, req.GetIncludeConfig()))
+	}
+	if more {
+		out.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return out, nil
+}
+
+// RollbackPolicyConfig restores a prior version of the org's config, stored as a new version with source rollback.
+// An optional etag makes it conditional as in UpdateOrgPolicyConfig. Caller must be org admin or owner.
+func (s *Server) RollbackPolicyConfig(ctx context.Context, req *orgpolicyconfigv1.RollbackPolicyConfigRequest) (*orgpolicyconfigv1.RollbackPolicyConfigResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RollbackPolicyConfig not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	requestOrgID := req.GetOrgId()
+	if requestOrgID != "" && requestOrgID != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	if req.GetVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version required")
+	}
+	target, err := s.repo.GetVersion(ctx, orgID, req.GetVersion())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if target == nil {
+		return nil, status.Error(codes.NotFound, "policy config version not found")
+	}
+	change := domain.Change{By: userID, Source: domain.ChangeSourceRollback, RestoredVersion: target.Version}
+	config, version, err := s.storeConfig(ctx, orgID, req.GetEtag(), change, func(*domain.OrgPolicyConfig) (*domain.OrgPolicyConfig, error) {
+		return target.Config, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.syncMFASettings(ctx, orgID, config); err != nil {
+		return nil, err
+	}
+	s.publish(orgID)
+	return &orgpolicyconfigv1.RollbackPolicyConfigResponse{
+		Config: domainToProto(domain.MergeWithDefaults(config)),
+		Etag:   formatEtag(version),
+	}, nil
+}
+
+func configVersionToProto(v *domain.ConfigVersion, changes []domain.FieldChange, includeConfig bool) *orgpolicyconfigv1.PolicyConfigVersion {
+	out := &orgpolicyconfigv1.PolicyConfigVersion{
+		Version:         v.Version,
+		ChangedBy:       v.By,
+		ChangedAt:       timestamppb.New(v.ChangedAt),
+		Source:          v.Source,
+		RestoredVersion: v.RestoredVersion,
+	}
+	for _, c := range changes {
+		out.Changes = append(out.Changes, &orgpolicyconfigv1.PolicyConfigChange{Path: c.Path, OldValue: c.OldValue, NewValue: c.NewValue})
+	}
+	if includeConfig {
+		out.Config = domainToProto(domain.MergeWithDefaults(v.Config))
+	}
+	return out
+}
+
+// validateConfig checks the sections of config that have constraints beyond their types.
+func validateConfig(config *domain.OrgPolicyConfig) error {
+	if err := config.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// updatesMFASettings reports whether an update writes auth_mfa or device_trust, which are mirrored in
+// org_mfa_settings. A full replace counts only the sections it sets; a masked update counts the named sections.
+func updatesMFASettings(update *domain.OrgPolicyConfig, sections []string) bool {
+	if len(sections) == 0 {
+		return update != nil && (update.AuthMfa != nil || update.DeviceTrust != nil)
+	}
+	for _, name := range sections {
+		if name == domain.SectionAuthMfa || name == domain.SectionDeviceTrust {
+			return true
+		}
+	}
+	return false
+}
+
+// formatEtag returns the etag for a config version.
+func formatEtag(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// parseEtag returns the config version an update is conditional on; conditional is false when etag is empty.
+func parseEtag(etag string) (version int64, conditional bool, err error) {
+	if etag == "" {
+		return 0, false, nil
+	}
+	version, err = strconv.ParseInt(etag, 10, 64)
+	if err != nil || version < 0 {
+		return 0, false, status.Error(codes.InvalidArgument, "invalid etag")
+	}
+	return version, true, nil
+}
+
+// GetBrowserPolicy returns only access_control and action_restrictions for the caller's org, with the group rules
+// of the caller's groups. Caller must be an org member (any role).
 func (s *Server) GetBrowserPolicy(ctx context.Context, req *orgpolicyconfigv1.GetBrowserPolicyRequest) (*orgpolicyconfigv1.GetBrowserPolicyResponse, error) {
 	if s.repo == nil {
 		return nil, status.Error(codes.Unimplemented, "method GetBrowserPolicy not implemented")
 	}
-	orgID, _, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
 	if err != nil {
 		return nil, err
 	}
@@ -127,36 +406,95 @@ func (s *Server) GetBrowserPolicy(ctx context.Context, req *orgpolicyconfigv1.Ge
 	if useOrgID == "" {
 		return nil, status.Error(codes.InvalidArgument, "org_id required")
 	}
-	config, err := s.repo.GetByOrgID(ctx, useOrgID)
+	return s.browserPolicy(ctx, useOrgID, userID)
+}
+
+// SubscribeBrowserPolicy streams the browser policy for the caller's org: once on open, then whenever
+// UpdateOrgPolicyConfig or BulkUpdateDomains changes it, plus a periodic resync (which also picks up changes to the
+// caller's groups). Unchanged policy is not resent. Caller must be an org member (any role).
+func (s *Server) SubscribeBrowserPolicy(req *orgpolicyconfigv1.SubscribeBrowserPolicyRequest, stream orgpolicyconfigv1.OrgPolicyConfigService_SubscribeBrowserPolicyServer) error {
+	if s.repo == nil || s.hub == nil {
+		return status.Error(codes.Unimplemented, "method SubscribeBrowserPolicy not implemented")
+	}
+	ctx := stream.Context()
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return err
+	}
+	requestOrgID := req.GetOrgId()
+	if requestOrgID != "" && requestOrgID != orgID {
+		return status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	useOrgID := orgID
+	if useOrgID == "" {
+		useOrgID = requestOrgID
+	}
+	if useOrgID == "" {
+		return status.Error(codes.InvalidArgument, "org_id required")
+	}
+	// Subscribe before the first load so a change made in between is not missed.
+	changes, cancel := s.hub.Subscribe(useOrgID)
+	defer cancel()
+	resync := time.NewTicker(s.resyncInterval)
+	defer resync.Stop()
+	var last *orgpolicyconfigv1.GetBrowserPolicyResponse
+	for {
+		policy, err := s.browserPolicy(ctx, useOrgID, userID)
+		if err != nil {
+			return err
+		}
+		if last == nil || !proto.Equal(policy, last) {
+			if err := stream.Send(policy); err != nil {
+				return err
+			}
+			last = policy
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changes:
+		case <-resync.C:
+		}
+	}
+}
+
+// browserPolicy loads access_control and action_restrictions (merged with defaults) for orgID, keeping only the
+// group rules of userID's groups.
+func (s *Server) browserPolicy(ctx context.Context, orgID, userID string) (*orgpolicyconfigv1.GetBrowserPolicyResponse, error) {
+	config, err := s.repo.GetByOrgID(ctx, orgID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	merged := domain.MergeWithDefaults(config)
 	out := &orgpolicyconfigv1.GetBrowserPolicyResponse{}
 	if merged.AccessControl != nil {
-		out.AccessControl = &orgpolicyconfigv1.AccessControl{
-			AllowedDomains:    append([]string(nil), merged.AccessControl.AllowedDomains...),
-			BlockedDomains:    append([]string(nil), merged.AccessControl.BlockedDomains...),
-			WildcardSupported: merged.AccessControl.WildcardSupported,
-			DefaultAction:     defaultActionToProto(merged.AccessControl.DefaultAction),
+		ac, err := s.callerAccessControl(ctx, merged.AccessControl, orgID, userID)
+		if err != nil {
+			return nil, err
 		}
+		out.AccessControl = accessControlToProto(ac)
 	}
 	if merged.ActionRestrictions != nil {
-		out.ActionRestrictions = &orgpolicyconfigv1.ActionRestrictions{
-			AllowedActions: append([]string(nil), merged.ActionRestrictions.AllowedActions...),
-			ReadOnlyMode:   merged.ActionRestrictions.ReadOnlyMode,
-		}
+		out.ActionRestrictions = actionRestrictionsToProto(merged.ActionRestrictions)
 	}
 	return out, nil
 }
 
-// CheckUrlAccess evaluates url against the org's access control policy and returns whether access is allowed.
-// Caller must be an org member (any role).
+// publish tells SubscribeBrowserPolicy streams for orgID to reload, if a hub is configured.
+func (s *Server) publish(orgID string) {
+	if s.hub != nil {
+		s.hub.Publish(orgID)
+	}
+}
+
+// CheckUrlAccess evaluates url against the org's access control policy and returns whether access is allowed. A
+// denied URL is allowed when the caller has an active URL exception for its domain; otherwise the denial is audited
+// as url_access_denied with the domain and reason. Caller must be an org member (any role).
 func (s *Server) CheckUrlAccess(ctx context.Context, req *orgpolicyconfigv1.CheckUrlAccessRequest) (*orgpolicyconfigv1.CheckUrlAccessResponse, error) {
 	if s.repo == nil {
 		return nil, status.Error(codes.Unimplemented, "method CheckUrlAccess not implemented")
 	}
-	orgID, _, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
 	if err != nil {
 		return nil, err
 	}
@@ -175,35 +513,253 @@ func (s *Server) CheckUrlAccess(ctx context.Context, req *orgpolicyconfigv1.Chec
 	if rawURL == "" {
 		return &orgpolicyconfigv1.CheckUrlAccessResponse{Allowed: false, Reason: "URL is required."}, nil
 	}
-	config, err := s.repo.GetByOrgID(ctx, useOrgID)
+	allowed, reason, err := s.CheckURL(ctx, useOrgID, userID, rawURL)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
+	}
+	out := &orgpolicyconfigv1.CheckUrlAccessResponse{Allowed: allowed, Reason: reason}
+	if allowed || len(rawURL) > domain.MaxURLLength {
+		return out, nil
+	}
+	host, err := domain.NormalizeDomain(rawURL)
+	if err != nil {
+		return out, nil
+	}
+	if s.exceptions != nil {
+		e, err := s.exceptions.GetActive(ctx, useOrgID, userID, host, time.Now().UTC())
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to look up URL exceptions")
+		}
+		if e != nil {
+			out = &orgpolicyconfigv1.CheckUrlAccessResponse{Allowed: true, ExceptionId: e.ID}
+			if e.ExpiresAt != nil {
+				out.ExceptionExpiresAt = timestamppb.New(*e.ExpiresAt)
+			}
+			return out, nil
+		}
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"domain": host, "reason": reason})
+		s.auditLogger.LogEvent(ctx, useOrgID, userID, "url_access_denied", "url_access", string(meta))
+	}
+	return out, nil
+}
+
+// CheckURL evaluates rawURL against orgID's access control policy for userID, ignoring URL exceptions, and returns
+// whether access is allowed and the reason when it is not.
+func (s *Server) CheckURL(ctx context.Context, orgID, userID, rawURL string) (allowed bool, reason string, err error) {
+	config, err := s.repo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return false, "", status.Error(codes.Internal, err.Error())
 	}
 	merged := domain.MergeWithDefaults(config)
 	ac := merged.AccessControl
 	if ac == nil {
 		ac = ptr(domain.DefaultAccessControl())
 	}
-	allowed, reason := evaluateURLAccess(rawURL, ac)
-	return &orgpolicyconfigv1.CheckUrlAccessResponse{Allowed: allowed, Reason: reason}, nil
+	ac, err = s.callerAccessControl(ctx, ac, orgID, userID)
+	if err != nil {
+		return false, "", err
+	}
+	allowed, reason = evaluateURLAccess(rawURL, ac)
+	return allowed, reason, nil
+}
+
+// BulkUpdateDomains adds and removes domains in one access control list (allowed, blocked, or a custom category)
+// and stores the result. Entries are normalized (URLs reduced to hosts) and deduplicated. Caller must be org admin or owner.
+func (s *Server) BulkUpdateDomains(ctx context.Context, req *orgpolicyconfigv1.BulkUpdateDomainsRequest) (*orgpolicyconfigv1.BulkUpdateDomainsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method BulkUpdateDomains not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	requestOrgID := req.GetOrgId()
+	if requestOrgID != "" && requestOrgID != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	useOrgID := orgID
+	if useOrgID == "" {
+		useOrgID = requestOrgID
+	}
+	if useOrgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	if len(req.GetAdd())+len(req.GetRemove()) > domain.MaxDomainsPerList {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d entries per request", domain.MaxDomainsPerList)
+	}
+	add, err := normalizeDomains(req.GetAdd())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	remove, err := normalizeDomains(req.GetRemove())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	config, err := s.repo.GetByOrgID(ctx, useOrgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if config.RequiresChangeApproval() {
+		return nil, errApprovalRequired
+	}
+	if config == nil {
+		config = &domain.OrgPolicyConfig{}
+	}
+	if config.AccessControl == nil {
+		config.AccessControl = ptr(domain.DefaultAccessControl())
+	}
+	ac := config.AccessControl
+	var added, removed int
+	switch req.GetList() {
+	case orgpolicyconfigv1.DomainList_DOMAIN_LIST_ALLOWED:
+		ac.AllowedDomains, added, removed = domain.MergeDomains(ac.AllowedDomains, add, remove)
+	case orgpolicyconfigv1.DomainList_DOMAIN_LIST_BLOCKED:
+		ac.BlockedDomains, added, removed = domain.MergeDomains(ac.BlockedDomains, add, remove)
+	case orgpolicyconfigv1.DomainList_DOMAIN_LIST_CUSTOM_CATEGORY:
+		name := strings.TrimSpace(req.GetCategory())
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "category required for custom category list")
+		}
+		i := customCategoryIndex(ac, name)
+		if i < 0 {
+			ac.CustomCategories = append(ac.CustomCategories, domain.URLCategory{Name: name})
+			i = len(ac.CustomCategories) - 1
+		}
+		ac.CustomCategories[i].Domains, added, removed = domain.MergeDomains(ac.CustomCategories[i].Domains, add, remove)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "list must be allowed, blocked, or custom category")
+	}
+	if err := ac.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.repo.Upsert(ctx, useOrgID, config, domain.Change{By: userID, Source: domain.ChangeSourceBulkUpdateDomains}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.publish(useOrgID)
+	return &orgpolicyconfigv1.BulkUpdateDomainsResponse{
+		AccessControl: accessControlToProto(ac),
+		Added:         int32(added),
+		Removed:       int32(removed),
+	}, nil
+}
+
+// ListUrlCategories returns the managed categories followed by the org's custom categories. Caller must be an org member (any role).
+func (s *Server) ListUrlCategories(ctx context.Context, req *orgpolicyconfigv1.ListUrlCategoriesRequest) (*orgpolicyconfigv1.ListUrlCategoriesResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListUrlCategories not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	requestOrgID := req.GetOrgId()
+	if requestOrgID != "" && requestOrgID != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	useOrgID := orgID
+	if useOrgID == "" {
+		useOrgID = requestOrgID
+	}
+	if useOrgID == "" {
+		return nil, status.Error(codes.InvalidArgument, "org_id required")
+	}
+	config, err := s.repo.GetByOrgID(ctx, useOrgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	out := &orgpolicyconfigv1.ListUrlCategoriesResponse{}
+	for _, c := range domain.ManagedCategories() {
+		out.Categories = append(out.Categories, &orgpolicyconfigv1.UrlCategory{Name: c.Name, Domains: c.Domains, Managed: true})
+	}
+	if ac := domain.MergeWithDefaults(config).AccessControl; ac != nil {
+		for _, c := range ac.CustomCategories {
+			out.Categories = append(out.Categories, &orgpolicyconfigv1.UrlCategory{Name: c.Name, Domains: append([]string(nil), c.Domains...)})
+		}
+	}
+	return out, nil
+}
+
+func normalizeDomains(list []string) ([]string, error) {
+	out := make([]string, 0, len(list))
+	for _, d := range list {
+		if strings.TrimSpace(d) == "" {
+			continue
+		}
+		n, err := domain.NormalizeDomain(d)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func customCategoryIndex(ac *domain.AccessControl, name string) int {
+	for i, c := range ac.CustomCategories {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// callerAccessControl returns a copy of ac whose group_rules are only those of userID's groups in orgID.
+func (s *Server) callerAccessControl(ctx context.Context, ac *domain.AccessControl, orgID, userID string) (*domain.AccessControl, error) {
+	out := *ac
+	out.GroupRules = nil
+	if s.groups == nil || len(ac.GroupRules) == 0 {
+		return &out, nil
+	}
+	groups, err := s.groups.ListUserGroupNames(ctx, orgID, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up groups")
+	}
+	out.GroupRules = ac.RulesForGroups(groups)
+	return &out, nil
 }
 
 // evaluateURLAccess returns (allowed, reason). reason is set when allowed is false.
+// URL rules (path prefix, regex) are checked first, then group rules, then blocked domains and categories, then
+// allowed ones. ac.GroupRules must already be limited to the caller's groups (see callerAccessControl).
 func evaluateURLAccess(rawURL string, ac *domain.AccessControl) (allowed bool, reason string) {
-	host, err := extractHost(rawURL)
-	if err != nil || host == "" {
+	if len(rawURL) > domain.MaxURLLength {
+		return false, "URL is too long."
+	}
+	u, err := parseURL(rawURL)
+	if err != nil || u.Hostname() == "" {
 		return false, "Invalid URL: could not determine host."
 	}
-	host = strings.ToLower(host)
+	switch action, _ := ac.MatchURLRules(u); action {
+	case domain.URLRuleBlock:
+		return false, "Access denied by organization policy: this URL is blocked."
+	case domain.URLRuleAllow:
+		return true, ""
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, r := range ac.GroupRules {
+		if inDomainList(r.BlockedDomains, host, ac.WildcardSupported) {
+			return false, "Access denied by organization policy: this domain is blocked for group \"" + r.Group + "\"."
+		}
+	}
+	for _, r := range ac.GroupRules {
+		if inDomainList(r.AllowedDomains, host, ac.WildcardSupported) {
+			return true, ""
+		}
+	}
 	blocked := ac.BlockedDomains
 	for _, d := range blocked {
 		if strings.ToLower(d) == host || (ac.WildcardSupported && matchWildcard(host, strings.ToLower(d))) {
 			return false, "Access denied by organization policy: this domain is blocked."
 		}
 	}
+	if category := ac.CategoryMatch(host, ac.BlockedCategories); category != "" {
+		return false, "Access denied by organization policy: this domain is in the blocked category \"" + category + "\"."
+	}
 	allowedList := ac.AllowedDomains
 	defaultDeny := ac.DefaultAction == "deny"
-	if len(allowedList) == 0 {
+	if len(allowedList) == 0 && len(ac.AllowedCategories) == 0 {
 		if defaultDeny {
 			return false, "Access denied by organization policy."
 		}
@@ -214,17 +770,27 @@ func evaluateURLAccess(rawURL string, ac *domain.AccessControl) (allowed bool, r
 			return true, ""
 		}
 	}
+	if ac.CategoryMatch(host, ac.AllowedCategories) != "" {
+		return true, ""
+	}
 	if defaultDeny {
 		return false, "Access denied by organization policy: this domain is not allowed."
 	}
 	return true, ""
 }
 
-func extractHost(rawURL string) (string, error) {
-	if rawURL != "" && !strings.Contains(rawURL, "://") {
-		rawURL = "https://" + rawURL
+// inDomainList reports whether host is in domains, matching "*.example.com" patterns when wildcard is set.
+func inDomainList(domains []string, host string, wildcard bool) bool {
+	for _, d := range domains {
+		if strings.ToLower(d) == host || (wildcard && matchWildcard(host, strings.ToLower(d))) {
+			return true
+		}
 	}
-	u, err := url.Parse(rawURL)
+	return false
+}
+
+func extractHost(rawURL string) (string, error) {
+	u, err := parseURL(rawURL)
 	if err != nil {
 		return "", err
 	}
@@ -235,6 +801,14 @@ func extractHost(rawURL string) (string, error) {
 	return h, nil
 }
 
+// parseURL parses rawURL, assuming https:// when no scheme is given.
+func parseURL(rawURL string) (*url.URL, error) {
+	if rawURL != "" && !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	return url.Parse(rawURL)
+}
+
 // matchWildcard returns true if host matches pattern (e.g. "sub.example.com" matches "*.example.com").
 func matchWildcard(host, pattern string) bool {
 	if !strings.HasPrefix(pattern, "*.") {
@@ -246,42 +820,20 @@ func matchWildcard(host, pattern string) bool {
 
 func ptr[T any](v T) *T { return &v }
 
-// domainToOrgMFASettings maps policy config auth_mfa and device_trust to OrgMFASettings for upsert.
-func domainToOrgMFASettings(orgID string, c *domain.OrgPolicyConfig) *orgmfasettingsdomain.OrgMFASettings {
-	now := time.Now().UTC()
-	s := &orgmfasettingsdomain.OrgMFASettings{
-		OrgID:                   orgID,
-		MFARequiredForNewDevice: true,
-		MFARequiredForUntrusted: true,
-		MFARequiredAlways:       false,
-		RegisterTrustAfterMFA:   true,
-		TrustTTLDays:            30,
-		CreatedAt:               now,
-		UpdatedAt:               now,
-	}
-	if c.AuthMfa != nil {
-		switch c.AuthMfa.MfaRequirement {
-		case "always":
-			s.MFARequiredAlways = true
-			s.MFARequiredForNewDevice = false
-			s.MFARequiredForUntrusted = false
-		case "new_device":
-			s.MFARequiredForNewDevice = true
-			s.MFARequiredForUntrusted = true
-			s.MFARequiredAlways = false
-		case "untrusted":
-			s.MFARequiredForUntrusted = true
-			s.MFARequiredForNewDevice = false
-			s.MFARequiredAlways = false
+// trimmed returns list with surrounding whitespace removed and empty entries dropped.
+func trimmed(list []string) []string {
+	var out []string
+	for _, v := range list {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
 		}
 	}
-	if c.DeviceTrust != nil {
-		s.RegisterTrustAfterMFA = c.DeviceTrust.AutoTrustAfterMfa
-		if c.DeviceTrust.ReverifyIntervalDays > 0 {
-			s.TrustTTLDays = c.DeviceTrust.ReverifyIntervalDays
-		}
-	}
-	return s
+	return out
+}
+
+// domainToOrgMFASettings maps policy config auth_mfa and device_trust to OrgMFASettings for upsert.
+func domainToOrgMFASettings(orgID string, c *domain.OrgPolicyConfig) *orgmfasettingsdomain.OrgMFASettings {
+	return domain.MFASettings(orgID, c, time.Now().UTC())
 }
 
 func domainToProto(c *domain.OrgPolicyConfig) *orgpolicyconfigv1.OrgPolicyConfig {
@@ -295,6 +847,14 @@ func domainToProto(c *domain.OrgPolicyConfig) *orgpolicyconfigv1.OrgPolicyConfig
 			AllowedMfaMethods:      append([]string(nil), c.AuthMfa.AllowedMfaMethods...),
 			StepUpSensitiveActions: c.AuthMfa.StepUpSensitiveActions,
 			StepUpPolicyViolation:  c.AuthMfa.StepUpPolicyViolation,
+			MagicLinkEnabled:       c.AuthMfa.MagicLinkEnabled,
+			PolicyFailureMode:      c.AuthMfa.PolicyFailureMode,
+		}
+		for _, r := range c.AuthMfa.GroupRequirements {
+			out.AuthMfa.GroupRequirements = append(out.AuthMfa.GroupRequirements, &orgpolicyconfigv1.GroupMfaRequirement{
+				Group:          r.Group,
+				MfaRequirement: mfaRequirementToProto(r.MfaRequirement),
+			})
 		}
 	}
 	if c.DeviceTrust != nil {
@@ -304,6 +864,9 @@ func domainToProto(c *domain.OrgPolicyConfig) *orgpolicyconfigv1.OrgPolicyConfig
 			MaxTrustedDevicesPerUser:  int32(c.DeviceTrust.MaxTrustedDevicesPerUser),
 			ReverifyIntervalDays:      int32(c.DeviceTrust.ReverifyIntervalDays),
 			AdminRevokeAllowed:        c.DeviceTrust.AdminRevokeAllowed,
+			KeepTrustOnFactorChange:   c.DeviceTrust.KeepTrustOnFactorChange,
+			TrustRenewal:              c.DeviceTrust.TrustRenewal,
+			ExpiryNoticeDays:          int32(c.DeviceTrust.ExpiryNoticeDays),
 		}
 	}
 	if c.SessionMgmt != nil {
@@ -313,25 +876,126 @@ func domainToProto(c *domain.OrgPolicyConfig) *orgpolicyconfigv1.OrgPolicyConfig
 			ConcurrentSessionLimit: int32(c.SessionMgmt.ConcurrentSessionLimit),
 			AdminForcedLogout:      c.SessionMgmt.AdminForcedLogout,
 			ReauthOnPolicyChange:   c.SessionMgmt.ReauthOnPolicyChange,
+			RefreshExpiry:          c.SessionMgmt.RefreshExpiry,
+			MaxSessionAge:          c.SessionMgmt.MaxSessionAge,
 		}
 	}
 	if c.AccessControl != nil {
-		out.AccessControl = &orgpolicyconfigv1.AccessControl{
-			AllowedDomains:    append([]string(nil), c.AccessControl.AllowedDomains...),
-			BlockedDomains:    append([]string(nil), c.AccessControl.BlockedDomains...),
-			WildcardSupported: c.AccessControl.WildcardSupported,
-			DefaultAction:     defaultActionToProto(c.AccessControl.DefaultAction),
-		}
+		out.AccessControl = accessControlToProto(c.AccessControl)
 	}
 	if c.ActionRestrictions != nil {
-		out.ActionRestrictions = &orgpolicyconfigv1.ActionRestrictions{
-			AllowedActions: append([]string(nil), c.ActionRestrictions.AllowedActions...),
-			ReadOnlyMode:   c.ActionRestrictions.ReadOnlyMode,
+		out.ActionRestrictions = actionRestrictionsToProto(c.ActionRestrictions)
+	}
+	if c.Notifications != nil {
+		out.Notifications = &orgpolicyconfigv1.Notifications{
+			NewLoginAlerts:        c.Notifications.NewLoginAlerts,
+			EnforceNewLoginAlerts: c.Notifications.EnforceNewLoginAlerts,
+		}
+	}
+	if c.NetworkAccess != nil {
+		out.NetworkAccess = &orgpolicyconfigv1.NetworkAccess{
+			AllowedCidrs:        append([]string(nil), c.NetworkAccess.AllowedCidrs...),
+			BlockedCidrs:        append([]string(nil), c.NetworkAccess.BlockedCidrs...),
+			OwnerBypass:         c.NetworkAccess.OwnerBypass,
+			TrustedCidrs:        append([]string(nil), c.NetworkAccess.TrustedCidrs...),
+			ForbidMfaRelaxation: c.NetworkAccess.ForbidMfaRelaxation,
+		}
+	}
+	if c.AccessSchedule != nil {
+		out.AccessSchedule = &orgpolicyconfigv1.AccessSchedule{
+			Enabled:  c.AccessSchedule.Enabled,
+			Timezone: c.AccessSchedule.Timezone,
+		}
+		for _, w := range c.AccessSchedule.Windows {
+			out.AccessSchedule.Windows = append(out.AccessSchedule.Windows, &orgpolicyconfigv1.AccessWindow{
+				Roles:     append([]string(nil), w.Roles...),
+				Days:      append([]string(nil), w.Days...),
+				StartTime: w.StartTime,
+				EndTime:   w.EndTime,
+			})
+		}
+	}
+	if c.TokenClaims != nil {
+		out.TokenClaims = &orgpolicyconfigv1.TokenClaims{
+			Audiences: append([]string(nil), c.TokenClaims.Audiences...),
+		}
+		if len(c.TokenClaims.Claims) > 0 {
+			out.TokenClaims.Claims = make(map[string]string, len(c.TokenClaims.Claims))
+			for k, v := range c.TokenClaims.Claims {
+				out.TokenClaims.Claims[k] = v
+			}
+		}
+	}
+	if c.PasswordPolicy != nil {
+		out.PasswordPolicy = &orgpolicyconfigv1.PasswordPolicy{
+			BreachedPasswordMode: breachedPasswordModeToProto(c.PasswordPolicy.BreachedPasswordMode),
 		}
 	}
+	if c.ChangeApproval != nil {
+		out.ChangeApproval = &orgpolicyconfigv1.ChangeApproval{Required: c.ChangeApproval.Required}
+	}
+	if c.Registration != nil {
+		out.Registration = &orgpolicyconfigv1.Registration{Mode: registrationModeToProto(c.Registration.Mode)}
+	}
 	return out
 }
 
+func accessControlToProto(ac *domain.AccessControl) *orgpolicyconfigv1.AccessControl {
+	out := &orgpolicyconfigv1.AccessControl{
+		AllowedDomains:    append([]string(nil), ac.AllowedDomains...),
+		BlockedDomains:    append([]string(nil), ac.BlockedDomains...),
+		WildcardSupported: ac.WildcardSupported,
+		DefaultAction:     defaultActionToProto(ac.DefaultAction),
+		AllowedCategories: append([]string(nil), ac.AllowedCategories...),
+		BlockedCategories: append([]string(nil), ac.BlockedCategories...),
+	}
+	for _, c := range ac.CustomCategories {
+		out.CustomCategories = append(out.CustomCategories, &orgpolicyconfigv1.UrlCategory{
+			Name:    c.Name,
+			Domains: append([]string(nil), c.Domains...),
+		})
+	}
+	for _, r := range ac.URLRules {
+		out.UrlRules = append(out.UrlRules, &orgpolicyconfigv1.UrlRule{
+			Action:     urlRuleActionToProto(r.Action),
+			Host:       r.Host,
+			PathPrefix: r.PathPrefix,
+			Regex:      r.Regex,
+		})
+	}
+	for _, r := range ac.GroupRules {
+		out.GroupRules = append(out.GroupRules, &orgpolicyconfigv1.GroupAccessRule{
+			Group:          r.Group,
+			AllowedDomains: append([]string(nil), r.AllowedDomains...),
+			BlockedDomains: append([]string(nil), r.BlockedDomains...),
+		})
+	}
+	return out
+}
+
+func actionRestrictionsToProto(a *domain.ActionRestrictions) *orgpolicyconfigv1.ActionRestrictions {
+	return &orgpolicyconfigv1.ActionRestrictions{
+		AllowedActions:          append([]string(nil), a.AllowedActions...),
+		ReadOnlyMode:            a.ReadOnlyMode,
+		MaxUploadBytes:          a.MaxUploadBytes,
+		BlockedFileExtensions:   append([]string(nil), a.BlockedFileExtensions...),
+		ClipboardBlockedDomains: append([]string(nil), a.ClipboardBlockedDomains...),
+		WatermarkPages:          a.WatermarkPages,
+		WatermarkDownloads:      a.WatermarkDownloads,
+	}
+}
+
+func urlRuleActionToProto(s string) orgpolicyconfigv1.UrlRuleAction {
+	switch s {
+	case domain.URLRuleAllow:
+		return orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_ALLOW
+	case domain.URLRuleBlock:
+		return orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_BLOCK
+	default:
+		return orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_UNSPECIFIED
+	}
+}
+
 func mfaRequirementToProto(s string) orgpolicyconfigv1.MfaRequirement {
 	switch s {
 	case "always":
@@ -356,6 +1020,32 @@ func defaultActionToProto(s string) orgpolicyconfigv1.DefaultAction {
 	}
 }
 
+func breachedPasswordModeToProto(s string) orgpolicyconfigv1.BreachedPasswordMode {
+	switch s {
+	case "off":
+		return orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_OFF
+	case "warn":
+		return orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_WARN
+	case "block":
+		return orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_BLOCK
+	default:
+		return orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_UNSPECIFIED
+	}
+}
+
+func registrationModeToProto(s string) orgpolicyconfigv1.RegistrationMode {
+	switch s {
+	case domain.RegistrationModeOpen:
+		return orgpolicyconfigv1.RegistrationMode_REGISTRATION_MODE_OPEN
+	case domain.RegistrationModeInviteOnly:
+		return orgpolicyconfigv1.RegistrationMode_REGISTRATION_MODE_INVITE_ONLY
+	case domain.RegistrationModeClosed:
+		return orgpolicyconfigv1.RegistrationMode_REGISTRATION_MODE_CLOSED
+	default:
+		return orgpolicyconfigv1.RegistrationMode_REGISTRATION_MODE_UNSPECIFIED
+	}
+}
+
 func protoToDomain(p *orgpolicyconfigv1.OrgPolicyConfig) *domain.OrgPolicyConfig {
 	if p == nil {
 		return nil
@@ -367,6 +1057,14 @@ func protoToDomain(p *orgpolicyconfigv1.OrgPolicyConfig) *domain.OrgPolicyConfig
 			AllowedMfaMethods:      append([]string(nil), p.AuthMfa.GetAllowedMfaMethods()...),
 			StepUpSensitiveActions: p.AuthMfa.GetStepUpSensitiveActions(),
 			StepUpPolicyViolation:  p.AuthMfa.GetStepUpPolicyViolation(),
+			MagicLinkEnabled:       p.AuthMfa.GetMagicLinkEnabled(),
+			PolicyFailureMode:      strings.TrimSpace(p.AuthMfa.GetPolicyFailureMode()),
+		}
+		for _, r := range p.AuthMfa.GetGroupRequirements() {
+			out.AuthMfa.GroupRequirements = append(out.AuthMfa.GroupRequirements, domain.GroupMfaRequirement{
+				Group:          strings.TrimSpace(r.GetGroup()),
+				MfaRequirement: groupMfaRequirementToDomain(r.GetMfaRequirement()),
+			})
 		}
 	}
 	if p.DeviceTrust != nil {
@@ -376,6 +1074,9 @@ func protoToDomain(p *orgpolicyconfigv1.OrgPolicyConfig) *domain.OrgPolicyConfig
 			MaxTrustedDevicesPerUser:  int(p.DeviceTrust.GetMaxTrustedDevicesPerUser()),
 			ReverifyIntervalDays:      int(p.DeviceTrust.GetReverifyIntervalDays()),
 			AdminRevokeAllowed:        p.DeviceTrust.GetAdminRevokeAllowed(),
+			KeepTrustOnFactorChange:   p.DeviceTrust.GetKeepTrustOnFactorChange(),
+			TrustRenewal:              p.DeviceTrust.GetTrustRenewal(),
+			ExpiryNoticeDays:          int(p.DeviceTrust.GetExpiryNoticeDays()),
 		}
 	}
 	if p.SessionMgmt != nil {
@@ -385,6 +1086,8 @@ func protoToDomain(p *orgpolicyconfigv1.OrgPolicyConfig) *domain.OrgPolicyConfig
 			ConcurrentSessionLimit: int(p.SessionMgmt.GetConcurrentSessionLimit()),
 			AdminForcedLogout:      p.SessionMgmt.GetAdminForcedLogout(),
 			ReauthOnPolicyChange:   p.SessionMgmt.GetReauthOnPolicyChange(),
+			RefreshExpiry:          p.SessionMgmt.GetRefreshExpiry(),
+			MaxSessionAge:          p.SessionMgmt.GetMaxSessionAge(),
 		}
 	}
 	if p.AccessControl != nil {
@@ -393,17 +1096,104 @@ func protoToDomain(p *orgpolicyconfigv1.OrgPolicyConfig) *domain.OrgPolicyConfig
 			BlockedDomains:    append([]string(nil), p.AccessControl.GetBlockedDomains()...),
 			WildcardSupported: p.AccessControl.GetWildcardSupported(),
 			DefaultAction:     defaultActionToDomain(p.AccessControl.GetDefaultAction()),
+			AllowedCategories: trimmed(p.AccessControl.GetAllowedCategories()),
+			BlockedCategories: trimmed(p.AccessControl.GetBlockedCategories()),
+		}
+		for _, c := range p.AccessControl.GetCustomCategories() {
+			out.AccessControl.CustomCategories = append(out.AccessControl.CustomCategories, domain.URLCategory{
+				Name:    strings.TrimSpace(c.GetName()),
+				Domains: trimmed(c.GetDomains()),
+			})
+		}
+		for _, r := range p.AccessControl.GetUrlRules() {
+			out.AccessControl.URLRules = append(out.AccessControl.URLRules, domain.URLRule{
+				Action:     urlRuleActionToDomain(r.GetAction()),
+				Host:       strings.ToLower(strings.TrimSpace(r.GetHost())),
+				PathPrefix: strings.TrimSpace(r.GetPathPrefix()),
+				Regex:      r.GetRegex(),
+			})
+		}
+		for _, r := range p.AccessControl.GetGroupRules() {
+			out.AccessControl.GroupRules = append(out.AccessControl.GroupRules, domain.GroupAccessRule{
+				Group:          strings.TrimSpace(r.GetGroup()),
+				AllowedDomains: trimmed(r.GetAllowedDomains()),
+				BlockedDomains: trimmed(r.GetBlockedDomains()),
+			})
 		}
 	}
 	if p.ActionRestrictions != nil {
 		out.ActionRestrictions = &domain.ActionRestrictions{
-			AllowedActions: append([]string(nil), p.ActionRestrictions.GetAllowedActions()...),
-			ReadOnlyMode:   p.ActionRestrictions.GetReadOnlyMode(),
+			AllowedActions:          append([]string(nil), p.ActionRestrictions.GetAllowedActions()...),
+			ReadOnlyMode:            p.ActionRestrictions.GetReadOnlyMode(),
+			MaxUploadBytes:          p.ActionRestrictions.GetMaxUploadBytes(),
+			BlockedFileExtensions:   append([]string(nil), p.ActionRestrictions.GetBlockedFileExtensions()...),
+			ClipboardBlockedDomains: append([]string(nil), p.ActionRestrictions.GetClipboardBlockedDomains()...),
+			WatermarkPages:          p.ActionRestrictions.GetWatermarkPages(),
+			WatermarkDownloads:      p.ActionRestrictions.GetWatermarkDownloads(),
+		}
+	}
+	if p.Notifications != nil {
+		out.Notifications = &domain.Notifications{
+			NewLoginAlerts:        p.Notifications.GetNewLoginAlerts(),
+			EnforceNewLoginAlerts: p.Notifications.GetEnforceNewLoginAlerts(),
+		}
+	}
+	if p.NetworkAccess != nil {
+		out.NetworkAccess = &domain.NetworkAccess{
+			AllowedCidrs:        trimmed(p.NetworkAccess.GetAllowedCidrs()),
+			BlockedCidrs:        trimmed(p.NetworkAccess.GetBlockedCidrs()),
+			OwnerBypass:         p.NetworkAccess.GetOwnerBypass(),
+			TrustedCidrs:        trimmed(p.NetworkAccess.GetTrustedCidrs()),
+			ForbidMfaRelaxation: p.NetworkAccess.GetForbidMfaRelaxation(),
+		}
+	}
+	if p.AccessSchedule != nil {
+		out.AccessSchedule = &domain.AccessSchedule{
+			Enabled:  p.AccessSchedule.GetEnabled(),
+			Timezone: strings.TrimSpace(p.AccessSchedule.GetTimezone()),
+		}
+		for _, w := range p.AccessSchedule.GetWindows() {
+			out.AccessSchedule.Windows = append(out.AccessSchedule.Windows, domain.AccessWindow{
+				Roles:     trimmed(w.GetRoles()),
+				Days:      trimmed(w.GetDays()),
+				StartTime: strings.TrimSpace(w.GetStartTime()),
+				EndTime:   strings.TrimSpace(w.GetEndTime()),
+			})
+		}
+	}
+	if p.TokenClaims != nil {
+		out.TokenClaims = &domain.TokenClaims{
+			Audiences: trimmed(p.TokenClaims.GetAudiences()),
+		}
+		if claims := p.TokenClaims.GetClaims(); len(claims) > 0 {
+			out.TokenClaims.Claims = make(map[string]string, len(claims))
+			for k, v := range claims {
+				out.TokenClaims.Claims[strings.TrimSpace(k)] = v
+			}
+		}
+	}
+	if p.PasswordPolicy != nil {
+		out.PasswordPolicy = &domain.PasswordPolicy{
+			BreachedPasswordMode: breachedPasswordModeToDomain(p.PasswordPolicy.GetBreachedPasswordMode()),
 		}
 	}
+	if p.ChangeApproval != nil {
+		out.ChangeApproval = &domain.ChangeApproval{Required: p.ChangeApproval.GetRequired()}
+	}
+	if p.Registration != nil {
+		out.Registration = &domain.Registration{Mode: registrationModeToDomain(p.Registration.GetMode())}
+	}
 	return out
 }
 
+// groupMfaRequirementToDomain is mfaRequirementToDomain without the default: a group requirement must be set.
+func groupMfaRequirementToDomain(e orgpolicyconfigv1.MfaRequirement) string {
+	if e == orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_UNSPECIFIED {
+		return ""
+	}
+	return mfaRequirementToDomain(e)
+}
+
 func mfaRequirementToDomain(e orgpolicyconfigv1.MfaRequirement) string {
 	switch e {
 	case orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS:
@@ -417,6 +1207,18 @@ func mfaRequirementToDomain(e orgpolicyconfigv1.MfaRequirement) string {
 	}
 }
 
+// urlRuleActionToDomain maps UNSPECIFIED to "", which URLRule.Validate rejects.
+func urlRuleActionToDomain(e orgpolicyconfigv1.UrlRuleAction) string {
+	switch e {
+	case orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_ALLOW:
+		return domain.URLRuleAllow
+	case orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_BLOCK:
+		return domain.URLRuleBlock
+	default:
+		return ""
+	}
+}
+
 func defaultActionToDomain(e orgpolicyconfigv1.DefaultAction) string {
 	switch e {
 	case orgpolicyconfigv1.DefaultAction_DEFAULT_ACTION_DENY:
@@ -427,3 +1229,31 @@ func defaultActionToDomain(e orgpolicyconfigv1.DefaultAction) string {
 		return "allow"
 	}
 }
+
+// breachedPasswordModeToDomain maps UNSPECIFIED to "" (platform default).
+func breachedPasswordModeToDomain(e orgpolicyconfigv1.BreachedPasswordMode) string {
+	switch e {
+	case orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_OFF:
+		return "off"
+	case orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_WARN:
+		return "warn"
+	case orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_BLOCK:
+		return "block"
+	default:
+		return ""
+	}
+}
+
+// registrationModeToDomain maps UNSPECIFIED to "" (platform registration_mode).
+func registrationModeToDomain(e orgpolicyconfigv1.RegistrationMode) string {
+	switch e {
+	case orgpolicyconfigv1.RegistrationMode_REGISTRATION_MODE_OPEN:
+		return domain.RegistrationModeOpen
+	case orgpolicyconfigv1.RegistrationMode_REGISTRATION_MODE_INVITE_ONLY:
+		return domain.RegistrationModeInviteOnly
+	case orgpolicyconfigv1.RegistrationMode_REGISTRATION_MODE_CLOSED:
+		return domain.RegistrationModeClosed
+	default:
+		return ""
+	}
+}
@@ -2,22 +2,36 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
 	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
 	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
 	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig"
 	"zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
 	"zero-trust-control-plane/backend/internal/server/interceptors"
+	urlexceptiondomain "zero-trust-control-plane/backend/internal/urlexception/domain"
 )
 
 // mockOrgPolicyConfigRepo implements repository.Repository for tests.
 type mockOrgPolicyConfigRepo struct {
-	configs map[string]*domain.OrgPolicyConfig
-	err     error
+	configs   map[string]*domain.OrgPolicyConfig
+	versions  map[string]int64
+	history   []*domain.ConfigVersion // all orgs, in write order
+	scheduled []*domain.ScheduledChange
+	err       error
+	// beforeUpdate, when set, runs at the start of UpdateIfVersion (e.g. to simulate a concurrent write).
+	beforeUpdate func(orgID string)
 }
 
 func (m *mockOrgPolicyConfigRepo) GetByOrgID(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, error) {
@@ -27,7 +41,7 @@ func (m *mockOrgPolicyConfigRepo) GetByOrgID(ctx context.Context, orgID string)
 	return m.configs[orgID], nil
 }
 
-func (m *mockOrgPolicyConfigRepo) Upsert(ctx context.Context, orgID string, config *domain.OrgPolicyConfig) error {
+func (m *mockOrgPolicyConfigRepo) Upsert(ctx context.Context, orgID string, config *domain.OrgPolicyConfig, change domain.Change) error {
 	if m.err != nil {
 		return m.err
 	}
@@ -35,9 +49,173 @@ func (m *mockOrgPolicyConfigRepo) Upsert(ctx context.Context, orgID string, conf
 		m.configs = make(map[string]*domain.OrgPolicyConfig)
 	}
 	m.configs[orgID] = config
+	m.setVersion(orgID, m.version(orgID)+1)
+	m.record(orgID, config, change)
 	return nil
 }
 
+func (m *mockOrgPolicyConfigRepo) GetVersioned(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, int64, error) {
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return m.configs[orgID], m.version(orgID), nil
+}
+
+func (m *mockOrgPolicyConfigRepo) UpdateIfVersion(ctx context.Context, orgID string, config *domain.OrgPolicyConfig, version int64, change domain.Change) (int64, bool, error) {
+	if m.beforeUpdate != nil {
+		m.beforeUpdate(orgID)
+	}
+	if m.err != nil {
+		return 0, false, m.err
+	}
+	if m.version(orgID) != version {
+		return 0, false, nil
+	}
+	if m.configs == nil {
+		m.configs = make(map[string]*domain.OrgPolicyConfig)
+	}
+	m.configs[orgID] = config
+	m.setVersion(orgID, version+1)
+	m.record(orgID, config, change)
+	return version + 1, true, nil
+}
+
+func (m *mockOrgPolicyConfigRepo) ListVersions(ctx context.Context, orgID string, limit, offset int32) ([]*domain.ConfigVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var out []*domain.ConfigVersion
+	for i := len(m.history) - 1; i >= 0; i-- {
+		if m.history[i].OrgID == orgID {
+			out = append(out, m.history[i])
+		}
+	}
+	if int(offset) >= len(out) {
+		return nil, nil
+	}
+	out = out[offset:]
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *mockOrgPolicyConfigRepo) GetVersion(ctx context.Context, orgID string, version int64) (*domain.ConfigVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, v := range m.history {
+		if v.OrgID == orgID && v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockOrgPolicyConfigRepo) CreateScheduledChange(ctx context.Context, c *domain.ScheduledChange) error {
+	if m.err != nil {
+		return m.err
+	}
+	stored := *c
+	m.scheduled = append(m.scheduled, &stored)
+	return nil
+}
+
+func (m *mockOrgPolicyConfigRepo) GetScheduledChange(ctx context.Context, id string) (*domain.ScheduledChange, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, c := range m.scheduled {
+		if c.ID == id {
+			out := *c
+			return &out, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockOrgPolicyConfigRepo) ListScheduledChanges(ctx context.Context, orgID string, st domain.ScheduledStatus, limit, offset int32) ([]*domain.ScheduledChange, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var out []*domain.ScheduledChange
+	for _, c := range m.scheduled {
+		if c.OrgID == orgID && (st == "" || c.Status == st) {
+			cp := *c
+			out = append(out, &cp)
+		}
+	}
+	if int(offset) >= len(out) {
+		return nil, nil
+	}
+	out = out[offset:]
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *mockOrgPolicyConfigRepo) ListDueScheduledChanges(ctx context.Context, now time.Time, limit int32) ([]*domain.ScheduledChange, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var out []*domain.ScheduledChange
+	for _, c := range m.scheduled {
+		if c.Status == domain.ScheduledPending && !c.EffectiveAt.After(now) && len(out) < int(limit) {
+			cp := *c
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockOrgPolicyConfigRepo) TransitionScheduledChange(ctx context.Context, id string, from, to domain.ScheduledStatus, res domain.Resolution) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	for _, c := range m.scheduled {
+		if c.ID == id && c.Status == from {
+			c.Status = to
+			c.Resolution = res
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// record appends the org's current version to the history. The config is copied, as the postgres repository
+// stores it as JSON and callers may modify the stored config in place.
+func (m *mockOrgPolicyConfigRepo) record(orgID string, config *domain.OrgPolicyConfig, change domain.Change) {
+	raw, _ := json.Marshal(config)
+	var snapshot *domain.OrgPolicyConfig
+	_ = json.Unmarshal(raw, &snapshot)
+	m.history = append(m.history, &domain.ConfigVersion{
+		OrgID:     orgID,
+		Version:   m.version(orgID),
+		Config:    snapshot,
+		ChangedAt: time.Now(),
+		Change:    change,
+	})
+}
+
+// version returns the org's config version: 0 without a config, 1 for a config seeded directly into configs.
+func (m *mockOrgPolicyConfigRepo) version(orgID string) int64 {
+	if v := m.versions[orgID]; v > 0 {
+		return v
+	}
+	if m.configs[orgID] != nil {
+		return 1
+	}
+	return 0
+}
+
+func (m *mockOrgPolicyConfigRepo) setVersion(orgID string, v int64) {
+	if m.versions == nil {
+		m.versions = make(map[string]int64)
+	}
+	m.versions[orgID] = v
+}
+
 // mockMembershipRepoForOrgPolicyConfig implements membershiprepo.Repository for tests.
 type mockMembershipRepoForOrgPolicyConfig struct {
 	memberships map[string]*membershipdomain.Membership
@@ -118,7 +296,7 @@ func TestGetOrgPolicyConfig_Success(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
 
 	resp, err := srv.GetOrgPolicyConfig(ctx, &orgpolicyconfigv1.GetOrgPolicyConfigRequest{OrgId: "org-1"})
@@ -145,7 +323,7 @@ func TestGetOrgPolicyConfig_DefaultsMerging(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
 
 	resp, err := srv.GetOrgPolicyConfig(ctx, &orgpolicyconfigv1.GetOrgPolicyConfigRequest{OrgId: "org-1"})
@@ -169,7 +347,7 @@ func TestGetOrgPolicyConfig_NonAdminCaller(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
 
 	_, err := srv.GetOrgPolicyConfig(ctx, &orgpolicyconfigv1.GetOrgPolicyConfigRequest{OrgId: "org-1"})
@@ -202,7 +380,7 @@ func TestCheckUrlAccess_AllowedDomain(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
 
 	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
@@ -234,7 +412,7 @@ func TestCheckUrlAccess_BlockedDomain(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
 
 	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
@@ -269,7 +447,7 @@ func TestCheckUrlAccess_WildcardMatching(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
 
 	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
@@ -301,7 +479,7 @@ func TestCheckUrlAccess_DefaultDeny(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
 
 	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
@@ -333,7 +511,7 @@ func TestCheckUrlAccess_DefaultAllow(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
 
 	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
@@ -365,7 +543,7 @@ func TestCheckUrlAccess_InvalidURL(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
 
 	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
@@ -375,193 +553,1191 @@ func TestCheckUrlAccess_InvalidURL(t *testing.T) {
 	if err != nil {
 		t.Fatalf("CheckUrlAccess: %v", err)
 	}
-	if resp.Allowed {
-		t.Error("invalid URL should be denied")
+	if resp.Allowed {
+		t.Error("invalid URL should be denied")
+	}
+	if resp.Reason == "" {
+		t.Error("reason should be set for invalid URL")
+	}
+}
+
+func TestCheckUrlAccess_EmptyURL(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{"org-1": {}},
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
+
+	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
+		OrgId: "org-1",
+		Url:   "",
+	})
+	if err != nil {
+		t.Fatalf("CheckUrlAccess: %v", err)
+	}
+	if resp.Allowed {
+		t.Error("empty URL should be denied")
+	}
+}
+
+func TestCheckUrlAccess_URLWithoutProtocol(t *testing.T) {
+	config := &domain.OrgPolicyConfig{
+		AccessControl: &domain.AccessControl{
+			AllowedDomains:    []string{"example.com"},
+			BlockedDomains:    []string{},
+			WildcardSupported: false,
+			DefaultAction:     "deny",
+		},
+	}
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{"org-1": config},
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
+
+	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
+		OrgId: "org-1",
+		Url:   "example.com/path",
+	})
+	if err != nil {
+		t.Fatalf("CheckUrlAccess: %v", err)
+	}
+	if !resp.Allowed {
+		t.Error("URL without protocol should be handled")
+	}
+}
+
+func TestCheckUrlAccess_CaseInsensitive(t *testing.T) {
+	config := &domain.OrgPolicyConfig{
+		AccessControl: &domain.AccessControl{
+			AllowedDomains:    []string{"Example.COM"},
+			BlockedDomains:    []string{},
+			WildcardSupported: false,
+			DefaultAction:     "deny",
+		},
+	}
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{"org-1": config},
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
+
+	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
+		OrgId: "org-1",
+		Url:   "https://EXAMPLE.com",
+	})
+	if err != nil {
+		t.Fatalf("CheckUrlAccess: %v", err)
+	}
+	if !resp.Allowed {
+		t.Error("domain matching should be case insensitive")
+	}
+}
+
+func TestCheckUrlAccess_NonMemberCaller(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{"org-1": {}},
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "nonmember-1")
+
+	_, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
+		OrgId: "org-1",
+		Url:   "https://example.com",
+	})
+	if err == nil {
+		t.Fatal("expected error for non-member caller")
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("error is not a gRPC status: %v", err)
+	}
+	if st.Code() != codes.PermissionDenied {
+		t.Errorf("status code = %v, want %v", st.Code(), codes.PermissionDenied)
+	}
+}
+
+func TestGetBrowserPolicy_Success(t *testing.T) {
+	config := &domain.OrgPolicyConfig{
+		AccessControl: &domain.AccessControl{
+			AllowedDomains:    []string{"example.com"},
+			BlockedDomains:    []string{},
+			WildcardSupported: true,
+			DefaultAction:     "allow",
+		},
+		ActionRestrictions: &domain.ActionRestrictions{
+			AllowedActions:          []string{"navigate", "download"},
+			ReadOnlyMode:            false,
+			MaxUploadBytes:          5 << 20,
+			BlockedFileExtensions:   []string{"exe", "tar.gz"},
+			ClipboardBlockedDomains: []string{"mail.example.com"},
+			WatermarkPages:          true,
+		},
+	}
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{"org-1": config},
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
+
+	resp, err := srv.GetBrowserPolicy(ctx, &orgpolicyconfigv1.GetBrowserPolicyRequest{OrgId: "org-1"})
+	if err != nil {
+		t.Fatalf("GetBrowserPolicy: %v", err)
+	}
+	if resp.AccessControl == nil {
+		t.Fatal("access_control is nil")
+	}
+	if resp.ActionRestrictions == nil {
+		t.Fatal("action_restrictions is nil")
+	}
+	ar := resp.ActionRestrictions
+	if ar.MaxUploadBytes != 5<<20 || len(ar.BlockedFileExtensions) != 2 || len(ar.ClipboardBlockedDomains) != 1 || !ar.WatermarkPages || ar.WatermarkDownloads {
+		t.Errorf("action_restrictions DLP rules = %+v", ar)
+	}
+}
+
+func TestUpdateOrgPolicyConfig_SyncToMFASettings(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: make(map[string]*domain.OrgPolicyConfig),
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	mfaSettingsRepo := &mockOrgMFASettingsRepo{
+		settings: make(map[string]*orgmfasettingsdomain.OrgMFASettings),
+	}
+	srv := NewServer(repo, membershipRepo, mfaSettingsRepo, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+
+	config := &orgpolicyconfigv1.OrgPolicyConfig{
+		AuthMfa: &orgpolicyconfigv1.AuthMfa{
+			MfaRequirement: orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS,
+		},
+	}
+	_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		OrgId:  "org-1",
+		Config: config,
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	}
+	if mfaSettingsRepo.settings["org-1"] == nil {
+		t.Error("MFA settings should be synced")
+	}
+}
+
+func TestUpdateOrgPolicyConfig_UpdateMask(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{"org-1": {
+			AuthMfa:       &domain.AuthMfa{MfaRequirement: "always"},
+			AccessControl: &domain.AccessControl{DefaultAction: "deny"},
+		}},
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	mfaSettingsRepo := &mockOrgMFASettingsRepo{
+		settings: make(map[string]*orgmfasettingsdomain.OrgMFASettings),
+	}
+	srv := NewServer(repo, membershipRepo, mfaSettingsRepo, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+
+	resp, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{
+			AuthMfa: &orgpolicyconfigv1.AuthMfa{MfaRequirement: orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_UNTRUSTED},
+			AccessControl: &orgpolicyconfigv1.AccessControl{
+				DefaultAction:  orgpolicyconfigv1.DefaultAction_DEFAULT_ACTION_ALLOW,
+				BlockedDomains: []string{"example.com"},
+			},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"access_control"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	}
+	stored := repo.configs["org-1"]
+	if stored.AuthMfa.MfaRequirement != "always" {
+		t.Errorf("auth_mfa outside the mask was changed: %+v", stored.AuthMfa)
+	}
+	if stored.AccessControl.DefaultAction != "allow" || len(stored.AccessControl.BlockedDomains) != 1 {
+		t.Errorf("access_control = %+v", stored.AccessControl)
+	}
+	if resp.Etag != "2" {
+		t.Errorf("etag = %q, want 2", resp.Etag)
+	}
+	if len(mfaSettingsRepo.settings) != 0 {
+		t.Error("MFA settings should not be synced when the mask excludes auth_mfa and device_trust")
+	}
+
+	if _, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"auth_mfa"}},
+	}); err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig(auth_mfa reset): %v", err)
+	}
+	if repo.configs["org-1"].AuthMfa != nil {
+		t.Error("masked section unset in config should be reset to defaults")
+	}
+	if mfaSettingsRepo.settings["org-1"] == nil {
+		t.Error("MFA settings should be synced when the mask includes auth_mfa")
+	}
+
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"auth_mfa.mfa_requirement"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("nested path: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestUpdateOrgPolicyConfig_Etag(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+
+	got, err := srv.GetOrgPolicyConfig(ctx, &orgpolicyconfigv1.GetOrgPolicyConfigRequest{})
+	if err != nil {
+		t.Fatalf("GetOrgPolicyConfig: %v", err)
+	}
+	if got.Etag != "0" {
+		t.Errorf("etag without stored config = %q, want 0", got.Etag)
+	}
+	updated, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{Notifications: &orgpolicyconfigv1.Notifications{NewLoginAlerts: true}},
+		Etag:   got.Etag,
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig with current etag: %v", err)
+	}
+	if updated.Etag != "1" {
+		t.Errorf("etag after first write = %q, want 1", updated.Etag)
+	}
+
+	// A second admin still holding the old etag must not clobber the first update.
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{},
+		Etag:   got.Etag,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("stale etag: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if !repo.configs["org-1"].Notifications.NewLoginAlerts {
+		t.Error("stale update should not be stored")
+	}
+
+	// A write landing between the read and the conditional write is also detected.
+	repo.beforeUpdate = func(orgID string) { repo.setVersion(orgID, repo.version(orgID)+1) }
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{},
+		Etag:   updated.Etag,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("concurrent write: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	repo.beforeUpdate = nil
+
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{Etag: "not-a-version"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid etag: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestUpdateOrgPolicyConfig_ConcurrentWriteWithoutEtag(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{"org-1": {}},
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+	req := &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config:     &orgpolicyconfigv1.OrgPolicyConfig{PasswordPolicy: &orgpolicyconfigv1.PasswordPolicy{BreachedPasswordMode: orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_BLOCK}},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password_policy"}},
+	}
+
+	// Another admin changes session_mgmt while the first update is in flight: the update is re-applied on top.
+	raced := false
+	repo.beforeUpdate = func(orgID string) {
+		if !raced {
+			raced = true
+			repo.configs[orgID] = &domain.OrgPolicyConfig{SessionMgmt: &domain.SessionMgmt{SessionMaxTtl: "8h"}}
+			repo.setVersion(orgID, repo.version(orgID)+1)
+		}
+	}
+	if _, err := srv.UpdateOrgPolicyConfig(ctx, req); err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	}
+	stored := repo.configs["org-1"]
+	if stored.SessionMgmt == nil || stored.SessionMgmt.SessionMaxTtl != "8h" {
+		t.Errorf("concurrent session_mgmt change lost: %+v", stored.SessionMgmt)
+	}
+	if stored.PasswordPolicy == nil || stored.PasswordPolicy.BreachedPasswordMode != "block" {
+		t.Errorf("password_policy = %+v", stored.PasswordPolicy)
+	}
+
+	repo.beforeUpdate = func(orgID string) { repo.setVersion(orgID, repo.version(orgID)+1) }
+	if _, err := srv.UpdateOrgPolicyConfig(ctx, req); status.Code(err) != codes.Aborted {
+		t.Errorf("constant contention: code = %v, want Aborted", status.Code(err))
+	}
+}
+
+func TestUpdateOrgPolicyConfig_NetworkAccess(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: make(map[string]*domain.OrgPolicyConfig),
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+
+	resp, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		OrgId: "org-1",
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{
+			NetworkAccess: &orgpolicyconfigv1.NetworkAccess{
+				AllowedCidrs:        []string{" 10.0.0.0/8 ", ""},
+				BlockedCidrs:        []string{"10.1.0.0/16"},
+				TrustedCidrs:        []string{" 10.2.0.0/16 "},
+				ForbidMfaRelaxation: true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	}
+	na := resp.Config.GetNetworkAccess()
+	if len(na.GetAllowedCidrs()) != 1 || na.GetAllowedCidrs()[0] != "10.0.0.0/8" {
+		t.Errorf("allowed_cidrs = %v, want [10.0.0.0/8]", na.GetAllowedCidrs())
+	}
+	if na.GetOwnerBypass() {
+		t.Error("owner_bypass should be stored as sent (false)")
+	}
+	if len(na.GetTrustedCidrs()) != 1 || na.GetTrustedCidrs()[0] != "10.2.0.0/16" || !na.GetForbidMfaRelaxation() {
+		t.Errorf("trusted_cidrs = %v, forbid_mfa_relaxation = %v; want [10.2.0.0/16], true", na.GetTrustedCidrs(), na.GetForbidMfaRelaxation())
+	}
+	if stored := repo.configs["org-1"].NetworkAccess; stored == nil || len(stored.BlockedCidrs) != 1 {
+		t.Errorf("stored network_access = %+v", stored)
+	}
+}
+
+func TestUpdateOrgPolicyConfig_InvalidCIDR(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: make(map[string]*domain.OrgPolicyConfig),
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+
+	_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		OrgId: "org-1",
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{
+			NetworkAccess: &orgpolicyconfigv1.NetworkAccess{AllowedCidrs: []string{"10.0.0.0/99"}},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", status.Code(err))
+	}
+	if repo.configs["org-1"] != nil {
+		t.Error("invalid config should not be stored")
+	}
+}
+
+func TestUpdateOrgPolicyConfig_AccessSchedule(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: make(map[string]*domain.OrgPolicyConfig),
+	}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+
+	resp, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		OrgId: "org-1",
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{
+			AccessSchedule: &orgpolicyconfigv1.AccessSchedule{
+				Enabled:  true,
+				Timezone: "UTC",
+				Windows: []*orgpolicyconfigv1.AccessWindow{
+					{Roles: []string{"member"}, Days: []string{"mon", "fri"}, StartTime: "08:00", EndTime: "18:00"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	}
+	as := resp.Config.GetAccessSchedule()
+	if !as.GetEnabled() || len(as.GetWindows()) != 1 || as.GetWindows()[0].GetEndTime() != "18:00" {
+		t.Errorf("access_schedule = %+v", as)
+	}
+
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		OrgId: "org-1",
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{
+			AccessSchedule: &orgpolicyconfigv1.AccessSchedule{Enabled: true, Timezone: "Nowhere/City"},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid timezone: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func newAdminOrgPolicyConfigServer(repo *mockOrgPolicyConfigRepo) (*Server, context.Context) {
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+			"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	return NewServer(repo, membershipRepo, nil, nil, nil, nil, nil), ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+}
+
+func TestBulkUpdateDomains_AddRemove(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{
+			"org-1": {AccessControl: &domain.AccessControl{BlockedDomains: []string{"old.com", "keep.com"}, DefaultAction: "allow"}},
+		},
+	}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	resp, err := srv.BulkUpdateDomains(ctx, &orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		List:   orgpolicyconfigv1.DomainList_DOMAIN_LIST_BLOCKED,
+		Add:    []string{"https://New.com/login", "keep.com", ""},
+		Remove: []string{"old.com"},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateDomains: %v", err)
+	}
+	if resp.Added != 1 || resp.Removed != 1 {
+		t.Errorf("added, removed = %d, %d, want 1, 1", resp.Added, resp.Removed)
+	}
+	got := repo.configs["org-1"].AccessControl.BlockedDomains
+	if len(got) != 2 || got[0] != "keep.com" || got[1] != "new.com" {
+		t.Errorf("blocked_domains = %v, want [keep.com new.com]", got)
+	}
+}
+
+func TestBulkUpdateDomains_CustomCategory(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{configs: make(map[string]*domain.OrgPolicyConfig)}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	if _, err := srv.BulkUpdateDomains(ctx, &orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		List:     orgpolicyconfigv1.DomainList_DOMAIN_LIST_CUSTOM_CATEGORY,
+		Category: "partners",
+		Add:      []string{"partner.example", "vendor.example"},
+	}); err != nil {
+		t.Fatalf("BulkUpdateDomains: %v", err)
+	}
+	ac := repo.configs["org-1"].AccessControl
+	if len(ac.CustomCategories) != 1 || len(ac.CustomCategories[0].Domains) != 2 {
+		t.Fatalf("custom_categories = %+v", ac.CustomCategories)
+	}
+	_, err := srv.BulkUpdateDomains(ctx, &orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_CUSTOM_CATEGORY,
+		Add:  []string{"x.example"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("missing category: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestBulkUpdateDomains_Validation(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{configs: make(map[string]*domain.OrgPolicyConfig)}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	cases := []*orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		{List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_ALLOWED, Add: []string{"not a domain"}},
+		{List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_UNSPECIFIED, Add: []string{"example.com"}},
+		{List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_ALLOWED, Add: make([]string, domain.MaxDomainsPerList+1)},
+	}
+	for i, req := range cases {
+		if _, err := srv.BulkUpdateDomains(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("case %d: code = %v, want InvalidArgument", i, status.Code(err))
+		}
+	}
+	if repo.configs["org-1"] != nil {
+		t.Error("rejected requests should not store config")
+	}
+
+	memberCtx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
+	_, err := srv.BulkUpdateDomains(memberCtx, &orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_ALLOWED, Add: []string{"example.com"},
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member: code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestListPolicyConfigHistory(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	for _, req := range []orgpolicyconfigv1.MfaRequirement{
+		orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS,
+		orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_UNTRUSTED,
+	} {
+		_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+			Config: &orgpolicyconfigv1.OrgPolicyConfig{AuthMfa: &orgpolicyconfigv1.AuthMfa{MfaRequirement: req, AllowedMfaMethods: []string{"sms_otp"}}},
+		})
+		if err != nil {
+			t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+		}
+	}
+	if _, err := srv.BulkUpdateDomains(ctx, &orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_BLOCKED,
+		Add:  []string{"bad.com"},
+	}); err != nil {
+		t.Fatalf("BulkUpdateDomains: %v", err)
+	}
+
+	resp, err := srv.ListPolicyConfigHistory(ctx, &orgpolicyconfigv1.ListPolicyConfigHistoryRequest{
+		Pagination: &commonv1.Pagination{PageSize: 2},
+	})
+	if err != nil {
+		t.Fatalf("ListPolicyConfigHistory: %v", err)
+	}
+	if len(resp.Versions) != 2 || resp.Versions[0].Version != 3 || resp.Versions[1].Version != 2 {
+		t.Fatalf("versions = %+v, want 3, 2", resp.Versions)
+	}
+	latest := resp.Versions[0]
+	if latest.Source != domain.ChangeSourceBulkUpdateDomains || latest.ChangedBy != "admin-1" || latest.ChangedAt == nil {
+		t.Errorf("latest = %+v", latest)
+	}
+	if len(latest.Changes) != 1 || latest.Changes[0].Path != "access_control.blocked_domains" {
+		t.Errorf("latest changes = %+v", latest.Changes)
+	}
+	if c := resp.Versions[1].Changes; len(c) != 1 || c[0].Path != "auth_mfa.mfa_requirement" || c[0].OldValue != `"always"` || c[0].NewValue != `"untrusted"` {
+		t.Errorf("version 2 changes = %+v", c)
+	}
+	if latest.Config != nil {
+		t.Error("config should be omitted without include_config")
+	}
+	if resp.Pagination.GetNextPageToken() != "2" {
+		t.Errorf("next_page_token = %q, want 2", resp.Pagination.GetNextPageToken())
+	}
+
+	// The oldest version is diffed against the defaults.
+	resp, err = srv.ListPolicyConfigHistory(ctx, &orgpolicyconfigv1.ListPolicyConfigHistoryRequest{
+		Pagination:    &commonv1.Pagination{PageSize: 2, PageToken: "2"},
+		IncludeConfig: true,
+	})
+	if err != nil {
+		t.Fatalf("ListPolicyConfigHistory page 2: %v", err)
+	}
+	if len(resp.Versions) != 1 || resp.Versions[0].Version != 1 || resp.Versions[0].Source != domain.ChangeSourceUpdate {
+		t.Fatalf("page 2 = %+v", resp.Versions)
+	}
+	if c := resp.Versions[0].Changes; len(c) != 1 || c[0].OldValue != `"new_device"` {
+		t.Errorf("version 1 changes = %+v", c)
+	}
+	if resp.Versions[0].Config.GetAuthMfa().GetMfaRequirement() != orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS {
+		t.Errorf("include_config: config = %+v", resp.Versions[0].Config)
+	}
+	if resp.Pagination.GetNextPageToken() != "" {
+		t.Errorf("last page next_page_token = %q, want empty", resp.Pagination.GetNextPageToken())
+	}
+
+	_, err = srv.ListPolicyConfigHistory(ctx, &orgpolicyconfigv1.ListPolicyConfigHistoryRequest{OrgId: "org-2"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("other org: code = %v, want PermissionDenied", status.Code(err))
+	}
+	_, err = srv.ListPolicyConfigHistory(ctxWithMemberForOrgPolicyConfig("org-1", "member-1"), &orgpolicyconfigv1.ListPolicyConfigHistoryRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member: code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestRollbackPolicyConfig(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	mfaSettingsRepo := &mockOrgMFASettingsRepo{}
+	srv := NewServer(repo, membershipRepo, mfaSettingsRepo, nil, nil, nil, nil)
+	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+	for _, req := range []orgpolicyconfigv1.MfaRequirement{
+		orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS,
+		orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_UNTRUSTED,
+	} {
+		_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+			Config: &orgpolicyconfigv1.OrgPolicyConfig{AuthMfa: &orgpolicyconfigv1.AuthMfa{MfaRequirement: req}},
+		})
+		if err != nil {
+			t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+		}
+	}
+
+	// A stale etag is rejected before anything is written.
+	_, err := srv.RollbackPolicyConfig(ctx, &orgpolicyconfigv1.RollbackPolicyConfigRequest{Version: 1, Etag: "1"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("stale etag: code = %v, want FailedPrecondition", status.Code(err))
+	}
+
+	resp, err := srv.RollbackPolicyConfig(ctx, &orgpolicyconfigv1.RollbackPolicyConfigRequest{Version: 1, Etag: "2"})
+	if err != nil {
+		t.Fatalf("RollbackPolicyConfig: %v", err)
+	}
+	if resp.Etag != "3" {
+		t.Errorf("etag = %q, want 3", resp.Etag)
+	}
+	if resp.Config.GetAuthMfa().GetMfaRequirement() != orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS {
+		t.Errorf("config not restored: %+v", resp.Config.GetAuthMfa())
+	}
+	if got := repo.configs["org-1"].AuthMfa.MfaRequirement; got != "always" {
+		t.Errorf("stored mfa_requirement = %q, want always", got)
+	}
+	if got := mfaSettingsRepo.settings["org-1"]; got == nil || !got.MFARequiredAlways {
+		t.Errorf("MFA settings not synced: %+v", got)
+	}
+	latest := repo.history[len(repo.history)-1]
+	if latest.Version != 3 || latest.Source != domain.ChangeSourceRollback || latest.RestoredVersion != 1 || latest.By != "admin-1" {
+		t.Errorf("history entry = %+v", latest)
+	}
+
+	_, err = srv.RollbackPolicyConfig(ctx, &orgpolicyconfigv1.RollbackPolicyConfigRequest{Version: 9})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unknown version: code = %v, want NotFound", status.Code(err))
+	}
+	_, err = srv.RollbackPolicyConfig(ctx, &orgpolicyconfigv1.RollbackPolicyConfigRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("no version: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestChangeApprovalRequired(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{
+			"org-1": {ChangeApproval: &domain.ChangeApproval{Required: true}},
+		},
+	}
+	repo.record("org-1", repo.configs["org-1"], domain.Change{By: "admin-1"})
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	update := &orgpolicyconfigv1.OrgPolicyConfig{SessionMgmt: &orgpolicyconfigv1.SessionMgmt{
+		SessionMaxTtl: "24h", IdleTimeout: "30m", ConcurrentSessionLimit: 3, AdminForcedLogout: true,
+	}}
+
+	_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{Config: update})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("UpdateOrgPolicyConfig: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	_, err = srv.BulkUpdateDomains(ctx, &orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_BLOCKED,
+		Add:  []string{"new.com"},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("BulkUpdateDomains: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	_, err = srv.RollbackPolicyConfig(ctx, &orgpolicyconfigv1.RollbackPolicyConfigRequest{Version: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RollbackPolicyConfig: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if len(repo.history) != 1 {
+		t.Fatalf("blocked writes recorded %d versions", len(repo.history)-1)
+	}
+
+	// The change request path validates without storing, then applies against the proposed base version.
+	proposed, err := srv.ProposeConfig(ctx, "org-1", update, []string{domain.SectionSessionMgmt}, "")
+	if err != nil {
+		t.Fatalf("ProposeConfig: %v", err)
+	}
+	if proposed.BaseVersion != 1 || len(proposed.Changes) != 1 || proposed.Changes[0].Path != "session_mgmt.concurrent_session_limit" {
+		t.Errorf("proposed = %+v", proposed)
+	}
+	if !proposed.Config.RequiresChangeApproval() {
+		t.Error("update_mask proposal dropped the change_approval section")
+	}
+	if repo.configs["org-1"].SessionMgmt != nil {
+		t.Fatal("ProposeConfig stored the config")
+	}
+	if _, err := srv.ProposeConfig(ctx, "org-1", update, []string{domain.SectionSessionMgmt}, "7"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ProposeConfig stale etag: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.ProposeConfig(ctx, "org-1", nil, []string{domain.SectionSessionMgmt}, ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ProposeConfig without changes: code = %v, want InvalidArgument", status.Code(err))
+	}
+
+	change := domain.Change{By: "admin-2", Source: domain.ChangeSourceChangeRequest}
+	version, err := srv.ApplyProposedConfig(ctx, "org-1", proposed.Config, proposed.BaseVersion, change)
+	if err != nil {
+		t.Fatalf("ApplyProposedConfig: %v", err)
+	}
+	if version != 2 || repo.configs["org-1"].SessionMgmt.ConcurrentSessionLimit != 3 {
+		t.Errorf("version = %d, stored = %+v", version, repo.configs["org-1"].SessionMgmt)
+	}
+	if latest := repo.history[len(repo.history)-1]; latest.Source != domain.ChangeSourceChangeRequest || latest.By != "admin-2" {
+		t.Errorf("history entry = %+v", latest)
+	}
+	if _, err := srv.ApplyProposedConfig(ctx, "org-1", proposed.Config, proposed.BaseVersion, change); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ApplyProposedConfig stale base version: code = %v, want FailedPrecondition", status.Code(err))
+	}
+}
+
+func TestUpdateOrgPolicyConfig_Scheduled(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	update := &orgpolicyconfigv1.OrgPolicyConfig{SessionMgmt: &orgpolicyconfigv1.SessionMgmt{
+		SessionMaxTtl: "8h", IdleTimeout: "30m", AdminForcedLogout: true,
+	}}
+	effectiveAt := time.Now().Add(time.Hour)
+
+	resp, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config:      update,
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{domain.SectionSessionMgmt}},
+		EffectiveAt: timestamppb.New(effectiveAt),
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	}
+	if resp.Etag != "0" || resp.Config.GetSessionMgmt().GetSessionMaxTtl() != "24h" {
+		t.Errorf("response should carry the current config: etag %q, session_mgmt %+v", resp.Etag, resp.Config.GetSessionMgmt())
+	}
+	sc := resp.ScheduledChange
+	if sc == nil || sc.Id == "" || sc.Status != "pending" || sc.CreatedBy != "admin-1" || !sc.EffectiveAt.AsTime().Equal(effectiveAt.UTC()) {
+		t.Fatalf("scheduled_change = %+v", sc)
+	}
+	if len(sc.UpdateMask.GetPaths()) != 1 || sc.Config.GetSessionMgmt().GetSessionMaxTtl() != "8h" {
+		t.Errorf("scheduled update = %+v, mask %v", sc.Config, sc.UpdateMask)
+	}
+	if repo.configs["org-1"] != nil || len(repo.history) != 0 {
+		t.Error("scheduling should not store the config")
+	}
+
+	for name, at := range map[string]time.Time{
+		"past":     time.Now().Add(-time.Minute),
+		"too late": time.Now().Add(400 * 24 * time.Hour),
+	} {
+		_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{Config: update, EffectiveAt: timestamppb.New(at)})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: code = %v, want InvalidArgument", name, status.Code(err))
+		}
+	}
+	invalid := &orgpolicyconfigv1.OrgPolicyConfig{AccessControl: &orgpolicyconfigv1.AccessControl{BlockedCategories: []string{"no_such_category"}}}
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{Config: invalid, EffectiveAt: timestamppb.New(effectiveAt)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid config: code = %v, want InvalidArgument", status.Code(err))
+	}
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{Config: update, Etag: "3", EffectiveAt: timestamppb.New(effectiveAt)})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("stale etag: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if len(repo.scheduled) != 1 {
+		t.Errorf("scheduled = %d, want 1", len(repo.scheduled))
+	}
+
+	repo.configs = map[string]*domain.OrgPolicyConfig{"org-1": {ChangeApproval: &domain.ChangeApproval{Required: true}}}
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{Config: update, EffectiveAt: timestamppb.New(effectiveAt)})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("approval required: code = %v, want FailedPrecondition", status.Code(err))
+	}
+}
+
+func TestActivateDue(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{
+		configs: map[string]*domain.OrgPolicyConfig{
+			"org-1": {AccessControl: &domain.AccessControl{BlockedDomains: []string{"old.com"}, DefaultAction: "allow"}},
+		},
+	}
+	mfaSettingsRepo := &mockOrgMFASettingsRepo{}
+	hub := orgpolicyconfig.NewHub()
+	srv := NewServer(repo, &mockMembershipRepoForOrgPolicyConfig{}, mfaSettingsRepo, hub, nil, nil, nil)
+	changes, cancel := hub.Subscribe("org-1")
+	defer cancel()
+	now := time.Now().UTC()
+	repo.scheduled = []*domain.ScheduledChange{
+		{
+			ID: "due", OrgID: "org-1", Status: domain.ScheduledPending, CreatedBy: "admin-1", EffectiveAt: now.Add(-time.Minute),
+			Config:   &domain.OrgPolicyConfig{AuthMfa: &domain.AuthMfa{MfaRequirement: "always"}},
+			Sections: []string{domain.SectionAuthMfa},
+		},
+		{
+			ID: "later", OrgID: "org-1", Status: domain.ScheduledPending, CreatedBy: "admin-1", EffectiveAt: now.Add(time.Hour),
+			Config: &domain.OrgPolicyConfig{},
+		},
+	}
+
+	n, err := srv.ActivateDue(context.Background(), now)
+	if err != nil {
+		t.Fatalf("ActivateDue: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("applied = %d, want 1", n)
+	}
+	stored := repo.configs["org-1"]
+	if stored.AuthMfa.MfaRequirement != "always" || len(stored.AccessControl.BlockedDomains) != 1 {
+		t.Errorf("stored config = %+v", stored)
+	}
+	latest := repo.history[len(repo.history)-1]
+	if latest.Source != domain.ChangeSourceScheduled || latest.By != "admin-1" || latest.Version != 2 {
+		t.Errorf("history entry = %+v", latest)
+	}
+	if got := repo.scheduled[0]; got.Status != domain.ScheduledApplied || got.AppliedVersion != 2 || got.Error != "" {
+		t.Errorf("due change = %+v", got)
+	}
+	if repo.scheduled[1].Status != domain.ScheduledPending {
+		t.Errorf("later change status = %q, want pending", repo.scheduled[1].Status)
+	}
+	if got := mfaSettingsRepo.settings["org-1"]; got == nil || !got.MFARequiredAlways {
+		t.Errorf("MFA settings not synced: %+v", got)
+	}
+	select {
+	case <-changes:
+	default:
+		t.Error("subscribers were not notified")
+	}
+
+	// A change that can no longer be applied is marked failed and not retried.
+	repo.configs["org-1"].ChangeApproval = &domain.ChangeApproval{Required: true}
+	n, err = srv.ActivateDue(context.Background(), now.Add(2*time.Hour))
+	if err != nil || n != 0 {
+		t.Fatalf("ActivateDue = %d, %v; want 0, nil", n, err)
+	}
+	if got := repo.scheduled[1]; got.Status != domain.ScheduledFailed || !strings.Contains(got.Error, "approval") {
+		t.Errorf("blocked change = %+v", got)
+	}
+
+	// Storage errors leave nothing claimed.
+	repo.err = status.Error(codes.Internal, "db down")
+	if _, err := srv.ActivateDue(context.Background(), now); err == nil {
+		t.Error("ActivateDue should return the storage error")
+	}
+}
+
+func TestCancelScheduledPolicyConfigChange(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{scheduled: []*domain.ScheduledChange{
+		{ID: "s1", OrgID: "org-1", Status: domain.ScheduledPending, CreatedBy: "admin-1", Config: &domain.OrgPolicyConfig{}},
+		{ID: "s2", OrgID: "org-2", Status: domain.ScheduledPending, CreatedBy: "admin-9", Config: &domain.OrgPolicyConfig{}},
+	}}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+
+	resp, err := srv.CancelScheduledPolicyConfigChange(ctx, &orgpolicyconfigv1.CancelScheduledPolicyConfigChangeRequest{Id: "s1"})
+	if err != nil {
+		t.Fatalf("CancelScheduledPolicyConfigChange: %v", err)
+	}
+	if resp.Change.Status != "cancelled" || resp.Change.ResolvedBy != "admin-1" || resp.Change.ResolvedAt == nil {
+		t.Errorf("change = %+v", resp.Change)
+	}
+	if repo.scheduled[0].Status != domain.ScheduledCancelled {
+		t.Errorf("stored status = %q, want cancelled", repo.scheduled[0].Status)
+	}
+	_, err = srv.CancelScheduledPolicyConfigChange(ctx, &orgpolicyconfigv1.CancelScheduledPolicyConfigChangeRequest{Id: "s1"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("cancel twice: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	_, err = srv.CancelScheduledPolicyConfigChange(ctx, &orgpolicyconfigv1.CancelScheduledPolicyConfigChangeRequest{Id: "s2"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("other org: code = %v, want NotFound", status.Code(err))
 	}
-	if resp.Reason == "" {
-		t.Error("reason should be set for invalid URL")
+	_, err = srv.CancelScheduledPolicyConfigChange(ctxWithMemberForOrgPolicyConfig("org-1", "member-1"), &orgpolicyconfigv1.CancelScheduledPolicyConfigChangeRequest{Id: "s1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member: code = %v, want PermissionDenied", status.Code(err))
 	}
 }
 
-func TestCheckUrlAccess_EmptyURL(t *testing.T) {
-	repo := &mockOrgPolicyConfigRepo{
-		configs: map[string]*domain.OrgPolicyConfig{"org-1": {}},
+func TestListScheduledPolicyConfigChanges(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{scheduled: []*domain.ScheduledChange{
+		{ID: "s1", OrgID: "org-1", Status: domain.ScheduledApplied, Config: &domain.OrgPolicyConfig{}},
+		{ID: "s2", OrgID: "org-1", Status: domain.ScheduledPending, Config: &domain.OrgPolicyConfig{}},
+		{ID: "s3", OrgID: "org-2", Status: domain.ScheduledPending, Config: &domain.OrgPolicyConfig{}},
+	}}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+
+	resp, err := srv.ListScheduledPolicyConfigChanges(ctx, &orgpolicyconfigv1.ListScheduledPolicyConfigChangesRequest{})
+	if err != nil {
+		t.Fatalf("ListScheduledPolicyConfigChanges: %v", err)
 	}
-	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
-		memberships: map[string]*membershipdomain.Membership{
-			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
-		},
+	if len(resp.Changes) != 2 {
+		t.Errorf("changes = %d, want 2 (own org only)", len(resp.Changes))
 	}
-	srv := NewServer(repo, membershipRepo, nil)
-	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
-
-	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
-		OrgId: "org-1",
-		Url:   "",
+	resp, err = srv.ListScheduledPolicyConfigChanges(ctx, &orgpolicyconfigv1.ListScheduledPolicyConfigChangesRequest{
+		Status:     "pending",
+		Pagination: &commonv1.Pagination{PageSize: 1},
 	})
 	if err != nil {
-		t.Fatalf("CheckUrlAccess: %v", err)
+		t.Fatalf("ListScheduledPolicyConfigChanges: %v", err)
 	}
-	if resp.Allowed {
-		t.Error("empty URL should be denied")
+	if len(resp.Changes) != 1 || resp.Changes[0].Id != "s2" || resp.Pagination.NextPageToken != "1" {
+		t.Errorf("pending = %+v, next %q", resp.Changes, resp.Pagination.NextPageToken)
+	}
+	_, err = srv.ListScheduledPolicyConfigChanges(ctx, &orgpolicyconfigv1.ListScheduledPolicyConfigChangesRequest{Status: "done"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown status: code = %v, want InvalidArgument", status.Code(err))
 	}
 }
 
-func TestCheckUrlAccess_URLWithoutProtocol(t *testing.T) {
-	config := &domain.OrgPolicyConfig{
-		AccessControl: &domain.AccessControl{
-			AllowedDomains:    []string{"example.com"},
-			BlockedDomains:    []string{},
-			WildcardSupported: false,
-			DefaultAction:     "deny",
-		},
-	}
+func TestListUrlCategories(t *testing.T) {
 	repo := &mockOrgPolicyConfigRepo{
-		configs: map[string]*domain.OrgPolicyConfig{"org-1": config},
-	}
-	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
-		memberships: map[string]*membershipdomain.Membership{
-			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		configs: map[string]*domain.OrgPolicyConfig{
+			"org-1": {AccessControl: &domain.AccessControl{CustomCategories: []domain.URLCategory{{Name: "partners", Domains: []string{"partner.example"}}}}},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
-	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
-
-	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
-		OrgId: "org-1",
-		Url:   "example.com/path",
-	})
+	srv, _ := newAdminOrgPolicyConfigServer(repo)
+	resp, err := srv.ListUrlCategories(ctxWithMemberForOrgPolicyConfig("org-1", "member-1"), &orgpolicyconfigv1.ListUrlCategoriesRequest{})
 	if err != nil {
-		t.Fatalf("CheckUrlAccess: %v", err)
+		t.Fatalf("ListUrlCategories: %v", err)
 	}
-	if !resp.Allowed {
-		t.Error("URL without protocol should be handled")
+	last := resp.Categories[len(resp.Categories)-1]
+	if len(resp.Categories) != len(domain.ManagedCategories())+1 || last.Name != "partners" || last.Managed {
+		t.Errorf("categories = %d, last = %+v", len(resp.Categories), last)
+	}
+	if !resp.Categories[0].Managed {
+		t.Error("managed categories should come first")
 	}
 }
 
-func TestCheckUrlAccess_CaseInsensitive(t *testing.T) {
-	config := &domain.OrgPolicyConfig{
-		AccessControl: &domain.AccessControl{
-			AllowedDomains:    []string{"Example.COM"},
-			BlockedDomains:    []string{},
-			WildcardSupported: false,
-			DefaultAction:     "deny",
-		},
+func TestCheckUrlAccess_Categories(t *testing.T) {
+	ac := &domain.AccessControl{
+		DefaultAction:     "deny",
+		BlockedCategories: []string{"social"},
+		AllowedCategories: []string{"partners"},
+		AllowedDomains:    []string{"www.facebook.com"},
+		CustomCategories:  []domain.URLCategory{{Name: "partners", Domains: []string{"partner.example"}}},
+	}
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://www.facebook.com", false}, // blocked category wins over allowed domain
+		{"https://app.partner.example/x", true},
+		{"https://unlisted.example", false},
+	}
+	for _, tt := range tests {
+		allowed, reason := evaluateURLAccess(tt.url, ac)
+		if allowed != tt.allowed {
+			t.Errorf("evaluateURLAccess(%q) = %v (%q), want %v", tt.url, allowed, reason, tt.allowed)
+		}
 	}
-	repo := &mockOrgPolicyConfigRepo{
-		configs: map[string]*domain.OrgPolicyConfig{"org-1": config},
+}
+
+func TestUpdateOrgPolicyConfig_UnknownCategory(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{configs: make(map[string]*domain.OrgPolicyConfig)}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{
+			AccessControl: &orgpolicyconfigv1.AccessControl{BlockedCategories: []string{"no_such_category"}},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", status.Code(err))
 	}
-	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
-		memberships: map[string]*membershipdomain.Membership{
-			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+}
+
+func TestCheckUrlAccess_URLRules(t *testing.T) {
+	ac := &domain.AccessControl{
+		DefaultAction:  "deny",
+		BlockedDomains: []string{"blocked.example"},
+		URLRules: []domain.URLRule{
+			{Action: domain.URLRuleAllow, Host: "example.com", PathPrefix: "/docs"},
+			{Action: domain.URLRuleBlock, Host: "example.com", PathPrefix: "/docs/internal"},
+			{Action: domain.URLRuleAllow, Host: "blocked.example", PathPrefix: "/status"},
+			{Action: domain.URLRuleBlock, Regex: `^https://[^/]+/admin(/|$)`},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
-	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://example.com/docs", true},
+		{"https://example.com/docs/guide?x=1", true},
+		{"https://example.com/docsearch", false},             // prefix matches whole segments only
+		{"https://example.com/docs/internal/roadmap", false}, // block rule wins over allow rule
+		{"https://example.com/docs/../internal/a", false},    // cleaned to /internal/a, so the /docs allow rule does not apply
+		{"https://blocked.example/status", true},             // URL rules are checked before domain lists
+		{"https://other.example/admin", false},               // regex
+		{"https://example.com/", false},                      // falls through to default deny
+		{"https://example.com/" + strings.Repeat("a", domain.MaxURLLength), false},
+	}
+	for _, tt := range tests {
+		allowed, reason := evaluateURLAccess(tt.url, ac)
+		if allowed != tt.allowed {
+			t.Errorf("evaluateURLAccess(%.60q) = %v (%q), want %v", tt.url, allowed, reason, tt.allowed)
+		}
+	}
+}
 
-	resp, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
-		OrgId: "org-1",
-		Url:   "https://EXAMPLE.com",
+func TestUpdateOrgPolicyConfig_URLRules(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{configs: make(map[string]*domain.OrgPolicyConfig)}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	rule := &orgpolicyconfigv1.UrlRule{Action: orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_BLOCK, Host: " Example.com ", PathPrefix: "/admin"}
+	resp, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{AccessControl: &orgpolicyconfigv1.AccessControl{UrlRules: []*orgpolicyconfigv1.UrlRule{rule}}},
 	})
 	if err != nil {
-		t.Fatalf("CheckUrlAccess: %v", err)
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
 	}
-	if !resp.Allowed {
-		t.Error("domain matching should be case insensitive")
+	got := resp.GetConfig().GetAccessControl().GetUrlRules()
+	if len(got) != 1 || got[0].Host != "example.com" || got[0].Action != orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_BLOCK {
+		t.Errorf("url_rules = %v", got)
 	}
-}
 
-func TestCheckUrlAccess_NonMemberCaller(t *testing.T) {
-	repo := &mockOrgPolicyConfigRepo{
-		configs: map[string]*domain.OrgPolicyConfig{"org-1": {}},
-	}
-	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
-		memberships: map[string]*membershipdomain.Membership{},
+	for _, bad := range []*orgpolicyconfigv1.UrlRule{
+		{Action: orgpolicyconfigv1.UrlRuleAction_URL_RULE_ACTION_BLOCK, Regex: "(unclosed"},
+		{Host: "example.com", PathPrefix: "/x"}, // action unspecified
+	} {
+		_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+			Config: &orgpolicyconfigv1.OrgPolicyConfig{AccessControl: &orgpolicyconfigv1.AccessControl{UrlRules: []*orgpolicyconfigv1.UrlRule{bad}}},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: code = %v, want InvalidArgument", bad, status.Code(err))
+		}
 	}
-	srv := NewServer(repo, membershipRepo, nil)
-	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "nonmember-1")
+}
 
-	_, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{
-		OrgId: "org-1",
-		Url:   "https://example.com",
+func TestUpdateOrgPolicyConfig_TokenClaims(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{configs: make(map[string]*domain.OrgPolicyConfig)}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	resp, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{TokenClaims: &orgpolicyconfigv1.TokenClaims{
+			Audiences: []string{" https://api.example.com "},
+			Claims:    map[string]string{"department": "eng"},
+		}},
 	})
-	if err == nil {
-		t.Fatal("expected error for non-member caller")
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
 	}
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("error is not a gRPC status: %v", err)
+	got := resp.GetConfig().GetTokenClaims()
+	if len(got.GetAudiences()) != 1 || got.GetAudiences()[0] != "https://api.example.com" || got.GetClaims()["department"] != "eng" {
+		t.Errorf("token_claims = %v", got)
 	}
-	if st.Code() != codes.PermissionDenied {
-		t.Errorf("status code = %v, want %v", st.Code(), codes.PermissionDenied)
+
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{TokenClaims: &orgpolicyconfigv1.TokenClaims{Claims: map[string]string{"org_id": "other-org"}}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("reserved claim: code = %v, want InvalidArgument", status.Code(err))
 	}
 }
 
-func TestGetBrowserPolicy_Success(t *testing.T) {
-	config := &domain.OrgPolicyConfig{
-		AccessControl: &domain.AccessControl{
-			AllowedDomains:    []string{"example.com"},
-			BlockedDomains:    []string{},
-			WildcardSupported: true,
-			DefaultAction:     "allow",
-		},
-		ActionRestrictions: &domain.ActionRestrictions{
-			AllowedActions: []string{"navigate", "download"},
-			ReadOnlyMode:   false,
-		},
-	}
-	repo := &mockOrgPolicyConfigRepo{
-		configs: map[string]*domain.OrgPolicyConfig{"org-1": config},
-	}
+type fakeBrowserPolicyStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *orgpolicyconfigv1.GetBrowserPolicyResponse
+}
+
+func (s *fakeBrowserPolicyStream) Context() context.Context { return s.ctx }
+
+func (s *fakeBrowserPolicyStream) Send(m *orgpolicyconfigv1.GetBrowserPolicyResponse) error {
+	s.sent <- m
+	return nil
+}
+
+func TestSubscribeBrowserPolicy_PushesUpdates(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{configs: make(map[string]*domain.OrgPolicyConfig)}
 	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
 		memberships: map[string]*membershipdomain.Membership{
-			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+			"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+			"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(repo, membershipRepo, nil)
-	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
-
-	resp, err := srv.GetBrowserPolicy(ctx, &orgpolicyconfigv1.GetBrowserPolicyRequest{OrgId: "org-1"})
-	if err != nil {
-		t.Fatalf("GetBrowserPolicy: %v", err)
+	hub := orgpolicyconfig.NewHub()
+	srv := NewServer(repo, membershipRepo, nil, hub, nil, nil, nil)
+
+	ctx, cancel := context.WithCancel(ctxWithMemberForOrgPolicyConfig("org-1", "member-1"))
+	stream := &fakeBrowserPolicyStream{ctx: ctx, sent: make(chan *orgpolicyconfigv1.GetBrowserPolicyResponse, 4)}
+	done := make(chan error, 1)
+	go func() { done <- srv.SubscribeBrowserPolicy(&orgpolicyconfigv1.SubscribeBrowserPolicyRequest{}, stream) }()
+
+	recv := func() *orgpolicyconfigv1.GetBrowserPolicyResponse {
+		t.Helper()
+		select {
+		case m := <-stream.sent:
+			return m
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for browser policy")
+			return nil
+		}
 	}
-	if resp.AccessControl == nil {
-		t.Fatal("access_control is nil")
+	if first := recv(); first.AccessControl.GetDefaultAction() != orgpolicyconfigv1.DefaultAction_DEFAULT_ACTION_ALLOW {
+		t.Errorf("initial default_action = %v, want ALLOW", first.AccessControl.GetDefaultAction())
 	}
-	if resp.ActionRestrictions == nil {
-		t.Fatal("action_restrictions is nil")
+	for hub.Subscribers("org-1") == 0 {
+		time.Sleep(time.Millisecond)
 	}
-}
 
-func TestUpdateOrgPolicyConfig_SyncToMFASettings(t *testing.T) {
-	repo := &mockOrgPolicyConfigRepo{
-		configs: make(map[string]*domain.OrgPolicyConfig),
+	adminCtx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
+	if _, err := srv.BulkUpdateDomains(adminCtx, &orgpolicyconfigv1.BulkUpdateDomainsRequest{
+		List: orgpolicyconfigv1.DomainList_DOMAIN_LIST_BLOCKED, Add: []string{"blocked.example"},
+	}); err != nil {
+		t.Fatalf("BulkUpdateDomains: %v", err)
 	}
-	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
-		memberships: map[string]*membershipdomain.Membership{
-			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+	if got := recv().AccessControl.GetBlockedDomains(); len(got) != 1 || got[0] != "blocked.example" {
+		t.Errorf("pushed blocked_domains = %v, want [blocked.example]", got)
+	}
+
+	// A change outside access_control/action_restrictions is not resent.
+	if _, err := srv.UpdateOrgPolicyConfig(adminCtx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{
+			AccessControl: repoAccessControlProto(repo),
+			Notifications: &orgpolicyconfigv1.Notifications{NewLoginAlerts: false},
 		},
+	}); err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
 	}
-	mfaSettingsRepo := &mockOrgMFASettingsRepo{
-		settings: make(map[string]*orgmfasettingsdomain.OrgMFASettings),
+	select {
+	case m := <-stream.sent:
+		t.Errorf("unchanged browser policy should not be resent, got %v", m)
+	case <-time.After(50 * time.Millisecond):
 	}
-	srv := NewServer(repo, membershipRepo, mfaSettingsRepo)
-	ctx := ctxWithAdminForOrgPolicyConfig("org-1", "admin-1")
 
-	config := &orgpolicyconfigv1.OrgPolicyConfig{
-		AuthMfa: &orgpolicyconfigv1.AuthMfa{
-			MfaRequirement: orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS,
-		},
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("SubscribeBrowserPolicy returned %v after cancel, want nil", err)
 	}
-	_, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
-		OrgId:  "org-1",
-		Config: config,
-	})
-	if err != nil {
-		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	if n := hub.Subscribers("org-1"); n != 0 {
+		t.Errorf("subscribers after cancel = %d, want 0", n)
 	}
-	if mfaSettingsRepo.settings["org-1"] == nil {
-		t.Error("MFA settings should be synced")
+}
+
+func repoAccessControlProto(repo *mockOrgPolicyConfigRepo) *orgpolicyconfigv1.AccessControl {
+	return accessControlToProto(repo.configs["org-1"].AccessControl)
+}
+
+func TestSubscribeBrowserPolicy_NoHub(t *testing.T) {
+	srv := NewServer(&mockOrgPolicyConfigRepo{}, &mockMembershipRepoForOrgPolicyConfig{}, nil, nil, nil, nil, nil)
+	stream := &fakeBrowserPolicyStream{ctx: ctxWithMemberForOrgPolicyConfig("org-1", "member-1")}
+	err := srv.SubscribeBrowserPolicy(&orgpolicyconfigv1.SubscribeBrowserPolicyRequest{}, stream)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
 	}
 }
 
@@ -924,6 +2100,7 @@ func TestDomainToProto_AllSections(t *testing.T) {
 			AllowedMfaMethods:      []string{"sms_otp", "totp"},
 			StepUpSensitiveActions: true,
 			StepUpPolicyViolation:  true,
+			MagicLinkEnabled:       true,
 		},
 		DeviceTrust: &domain.DeviceTrust{
 			DeviceRegistrationAllowed: true,
@@ -966,6 +2143,9 @@ func TestDomainToProto_AllSections(t *testing.T) {
 	if !proto.AuthMfa.StepUpSensitiveActions {
 		t.Error("StepUpSensitiveActions should be true")
 	}
+	if !proto.AuthMfa.MagicLinkEnabled {
+		t.Error("MagicLinkEnabled should be true")
+	}
 	if proto.DeviceTrust == nil {
 		t.Fatal("DeviceTrust should not be nil")
 	}
@@ -1057,6 +2237,7 @@ func TestProtoToDomain_AllSections(t *testing.T) {
 			AllowedMfaMethods:      []string{"sms_otp"},
 			StepUpSensitiveActions: true,
 			StepUpPolicyViolation:  true,
+			MagicLinkEnabled:       true,
 		},
 		DeviceTrust: &orgpolicyconfigv1.DeviceTrust{
 			DeviceRegistrationAllowed: true,
@@ -1093,6 +2274,9 @@ func TestProtoToDomain_AllSections(t *testing.T) {
 	if domainConfig.AuthMfa.MfaRequirement != "always" {
 		t.Errorf("MfaRequirement = %q, want %q", domainConfig.AuthMfa.MfaRequirement, "always")
 	}
+	if !domainConfig.AuthMfa.MagicLinkEnabled {
+		t.Error("MagicLinkEnabled should be true")
+	}
 	if domainConfig.DeviceTrust == nil {
 		t.Fatal("DeviceTrust should not be nil")
 	}
@@ -1163,6 +2347,20 @@ func TestProtoToDomain_TypeConversions(t *testing.T) {
 	}
 }
 
+func TestPasswordPolicy_RoundTrip(t *testing.T) {
+	for _, mode := range []string{"", "off", "warn", "block"} {
+		cfg := &domain.OrgPolicyConfig{PasswordPolicy: &domain.PasswordPolicy{BreachedPasswordMode: mode}}
+		got := protoToDomain(domainToProto(cfg))
+		if got.PasswordPolicy == nil || got.PasswordPolicy.BreachedPasswordMode != mode {
+			t.Errorf("round trip of mode %q = %+v", mode, got.PasswordPolicy)
+		}
+	}
+	pb := domainToProto(&domain.OrgPolicyConfig{PasswordPolicy: &domain.PasswordPolicy{BreachedPasswordMode: "block"}})
+	if pb.GetPasswordPolicy().GetBreachedPasswordMode() != orgpolicyconfigv1.BreachedPasswordMode_BREACHED_PASSWORD_MODE_BLOCK {
+		t.Errorf("BreachedPasswordMode = %v, want BLOCK", pb.GetPasswordPolicy().GetBreachedPasswordMode())
+	}
+}
+
 func TestExtractHost_WithProtocol(t *testing.T) {
 	host, err := extractHost("https://example.com")
 	if err != nil {
@@ -1293,3 +2491,190 @@ func TestMatchWildcard_ExactDomainMatch(t *testing.T) {
 		t.Error("matchWildcard should match .example.com")
 	}
 }
+
+type staticGroupLister map[string][]string // key: userID
+
+func (l staticGroupLister) ListUserGroupNames(ctx context.Context, orgID, userID string) ([]string, error) {
+	return l[userID], nil
+}
+
+func TestCheckUrlAccess_GroupRules(t *testing.T) {
+	config := &domain.OrgPolicyConfig{
+		AccessControl: &domain.AccessControl{
+			DefaultAction:  "deny",
+			AllowedDomains: []string{"docs.example.com"},
+			BlockedDomains: []string{"pastebin.com"},
+			GroupRules: []domain.GroupAccessRule{
+				{Group: "Engineering", AllowedDomains: []string{"github.com", "pastebin.com"}},
+				{Group: "Contractors", BlockedDomains: []string{"docs.example.com"}},
+			},
+		},
+	}
+	repo := &mockOrgPolicyConfigRepo{configs: map[string]*domain.OrgPolicyConfig{"org-1": config}}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"eng-1:org-1":  {ID: "m1", UserID: "eng-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+			"con-1:org-1":  {ID: "m2", UserID: "con-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+			"both-1:org-1": {ID: "m3", UserID: "both-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	groups := staticGroupLister{
+		"eng-1":  {"Engineering"},
+		"con-1":  {"Contractors"},
+		"both-1": {"Engineering", "Contractors"},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, groups, nil, nil)
+
+	tests := []struct {
+		user    string
+		url     string
+		allowed bool
+	}{
+		{"eng-1", "https://github.com/org/repo", true},
+		{"eng-1", "https://pastebin.com/x", true}, // group allow overrides the org block
+		{"eng-1", "https://docs.example.com/", true},
+		{"con-1", "https://github.com/org/repo", false}, // another group's rule does not apply
+		{"con-1", "https://docs.example.com/", false},
+		{"both-1", "https://docs.example.com/", false}, // group blocks are checked before group allows
+		{"both-1", "https://github.com/", true},
+	}
+	for _, tt := range tests {
+		resp, err := srv.CheckUrlAccess(ctxWithMemberForOrgPolicyConfig("org-1", tt.user), &orgpolicyconfigv1.CheckUrlAccessRequest{OrgId: "org-1", Url: tt.url})
+		if err != nil {
+			t.Fatalf("CheckUrlAccess(%s, %s): %v", tt.user, tt.url, err)
+		}
+		if resp.Allowed != tt.allowed {
+			t.Errorf("CheckUrlAccess(%s, %s) = %v (%q), want %v", tt.user, tt.url, resp.Allowed, resp.Reason, tt.allowed)
+		}
+	}
+
+	policy, err := srv.GetBrowserPolicy(ctxWithMemberForOrgPolicyConfig("org-1", "eng-1"), &orgpolicyconfigv1.GetBrowserPolicyRequest{OrgId: "org-1"})
+	if err != nil {
+		t.Fatalf("GetBrowserPolicy: %v", err)
+	}
+	rules := policy.GetAccessControl().GetGroupRules()
+	if len(rules) != 1 || rules[0].GetGroup() != "Engineering" {
+		t.Errorf("browser policy group_rules = %v, want only Engineering", rules)
+	}
+}
+
+type staticURLExceptions map[string]*urlexceptiondomain.Exception // key: userID:domain
+
+func (l staticURLExceptions) GetActive(ctx context.Context, orgID, userID, domain string, now time.Time) (*urlexceptiondomain.Exception, error) {
+	if e := l[userID+":"+domain]; e != nil && e.OrgID == orgID && e.Active(now) {
+		return e, nil
+	}
+	return nil, nil
+}
+
+func TestCheckUrlAccess_URLExceptions(t *testing.T) {
+	config := &domain.OrgPolicyConfig{
+		AccessControl: &domain.AccessControl{
+			DefaultAction:     "allow",
+			BlockedDomains:    []string{"pastebin.com", "*.pastebin.com", "example.net"},
+			WildcardSupported: true,
+		},
+	}
+	repo := &mockOrgPolicyConfigRepo{configs: map[string]*domain.OrgPolicyConfig{"org-1": config}}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+			"member-2:org-1": {ID: "m2", UserID: "member-2", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Minute)
+	exceptions := staticURLExceptions{
+		"member-1:pastebin.com": {ID: "ex-1", OrgID: "org-1", UserID: "member-1", Domain: "pastebin.com", Status: urlexceptiondomain.StatusApproved, ExpiresAt: &future},
+		"member-1:example.net":  {ID: "ex-2", OrgID: "org-1", UserID: "member-1", Domain: "example.net", Status: urlexceptiondomain.StatusApproved, ExpiresAt: &past},
+	}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, exceptions, nil)
+
+	resp, err := srv.CheckUrlAccess(ctxWithMemberForOrgPolicyConfig("org-1", "member-1"), &orgpolicyconfigv1.CheckUrlAccessRequest{Url: "https://PASTEBIN.com/raw/x"})
+	if err != nil {
+		t.Fatalf("CheckUrlAccess: %v", err)
+	}
+	if !resp.GetAllowed() || resp.GetReason() != "" || resp.GetExceptionId() != "ex-1" || !resp.GetExceptionExpiresAt().AsTime().Equal(future) {
+		t.Errorf("with active exception = %+v, want allowed by ex-1", resp)
+	}
+	tests := []struct {
+		user string
+		url  string
+	}{
+		{"member-2", "https://pastebin.com/raw/x"}, // another member's exception does not apply
+		{"member-1", "https://example.net/"},       // expired
+		{"member-1", "https://sub.pastebin.com/"},  // subdomains are not covered
+	}
+	for _, tt := range tests {
+		resp, err := srv.CheckUrlAccess(ctxWithMemberForOrgPolicyConfig("org-1", tt.user), &orgpolicyconfigv1.CheckUrlAccessRequest{Url: tt.url})
+		if err != nil {
+			t.Fatalf("CheckUrlAccess(%s, %s): %v", tt.user, tt.url, err)
+		}
+		if resp.GetAllowed() || resp.GetExceptionId() != "" {
+			t.Errorf("CheckUrlAccess(%s, %s) = %+v, want denied", tt.user, tt.url, resp)
+		}
+	}
+}
+
+type recordingAuditLogger struct {
+	entries []string // action|resource|metadata
+}
+
+func (r *recordingAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	r.entries = append(r.entries, action+"|"+resource+"|"+metadata)
+}
+
+func TestCheckUrlAccess_AuditsDenials(t *testing.T) {
+	config := &domain.OrgPolicyConfig{
+		AccessControl: &domain.AccessControl{DefaultAction: "allow", BlockedDomains: []string{"pastebin.com", "example.net"}},
+	}
+	repo := &mockOrgPolicyConfigRepo{configs: map[string]*domain.OrgPolicyConfig{"org-1": config}}
+	membershipRepo := &mockMembershipRepoForOrgPolicyConfig{
+		memberships: map[string]*membershipdomain.Membership{
+			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	future := time.Now().Add(time.Hour)
+	exceptions := staticURLExceptions{
+		"member-1:example.net": {ID: "ex-1", OrgID: "org-1", UserID: "member-1", Domain: "example.net", Status: urlexceptiondomain.StatusApproved, ExpiresAt: &future},
+	}
+	auditLog := &recordingAuditLogger{}
+	srv := NewServer(repo, membershipRepo, nil, nil, nil, exceptions, auditLog)
+	ctx := ctxWithMemberForOrgPolicyConfig("org-1", "member-1")
+	for _, u := range []string{"https://PasteBin.com/raw/x", "https://example.org/", "https://example.net/"} {
+		if _, err := srv.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{Url: u}); err != nil {
+			t.Fatalf("CheckUrlAccess(%s): %v", u, err)
+		}
+	}
+	// Only the denied URL is audited; the allowed one and the one allowed by an exception are not.
+	if len(auditLog.entries) != 1 || !strings.HasPrefix(auditLog.entries[0], `url_access_denied|url_access|{"domain":"pastebin.com"`) {
+		t.Errorf("audit entries = %v, want one url_access_denied for pastebin.com", auditLog.entries)
+	}
+}
+
+func TestUpdateOrgPolicyConfig_GroupMfaRequirements(t *testing.T) {
+	repo := &mockOrgPolicyConfigRepo{configs: make(map[string]*domain.OrgPolicyConfig)}
+	srv, ctx := newAdminOrgPolicyConfigServer(repo)
+	resp, err := srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{AuthMfa: &orgpolicyconfigv1.AuthMfa{
+			GroupRequirements: []*orgpolicyconfigv1.GroupMfaRequirement{
+				{Group: "Contractors", MfaRequirement: orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateOrgPolicyConfig: %v", err)
+	}
+	got := resp.GetConfig().GetAuthMfa().GetGroupRequirements()
+	if len(got) != 1 || got[0].GetGroup() != "Contractors" || got[0].GetMfaRequirement() != orgpolicyconfigv1.MfaRequirement_MFA_REQUIREMENT_ALWAYS {
+		t.Errorf("group_requirements = %v", got)
+	}
+
+	_, err = srv.UpdateOrgPolicyConfig(ctx, &orgpolicyconfigv1.UpdateOrgPolicyConfigRequest{
+		Config: &orgpolicyconfigv1.OrgPolicyConfig{AuthMfa: &orgpolicyconfigv1.AuthMfa{
+			GroupRequirements: []*orgpolicyconfigv1.GroupMfaRequirement{{Group: "Contractors"}},
+		}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unspecified requirement: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+)
+
+// maxScheduleAhead is how far in the future an update may be scheduled.
+const maxScheduleAhead = 365 * 24 * time.Hour
+
+// dueBatchSize bounds how many scheduled changes one ActivateDue call applies; the rest wait for the next run.
+const dueBatchSize = 100
+
+// Page sizes for ListScheduledPolicyConfigChanges.
+const (
+	defaultScheduledPageSize = 50
+	maxScheduledPageSize     = 100
+)
+
+// scheduleUpdate validates an UpdateOrgPolicyConfig update against the current config and stores it as a pending
+// scheduled change instead of applying it. The response carries the current config and etag.
+func (s *Server) scheduleUpdate(ctx context.Context, orgID, userID string, update *domain.OrgPolicyConfig, sections []string, etag string, effectiveAt time.Time) (*orgpolicyconfigv1.UpdateOrgPolicyConfigResponse, error) {
+	now := time.Now().UTC()
+	if !effectiveAt.After(now) {
+		return nil, status.Error(codes.InvalidArgument, "effective_at must be in the future")
+	}
+	if effectiveAt.After(now.Add(maxScheduleAhead)) {
+		return nil, status.Error(codes.InvalidArgument, "effective_at must be within 365 days")
+	}
+	expected, conditional, err := parseEtag(etag)
+	if err != nil {
+		return nil, err
+	}
+	current, version, err := s.repo.GetVersioned(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if current.RequiresChangeApproval() {
+		return nil, errApprovalRequired
+	}
+	if conditional && version != expected {
+		return nil, status.Error(codes.FailedPrecondition, "org policy config has changed; reload and retry")
+	}
+	// Validate against the current config; the change is applied to the config current at effective_at.
+	next := update
+	if len(sections) > 0 {
+		if next, err = domain.ApplySections(current, update, sections); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "update_mask: "+err.Error())
+		}
+	}
+	if err := validateConfig(next); err != nil {
+		return nil, err
+	}
+	c := &domain.ScheduledChange{
+		ID:          uuid.New().String(),
+		OrgID:       orgID,
+		Config:      update,
+		Sections:    sections,
+		EffectiveAt: effectiveAt.UTC(),
+		Status:      domain.ScheduledPending,
+		CreatedBy:   userID,
+		CreatedAt:   now,
+	}
+	if err := s.repo.CreateScheduledChange(ctx, c); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &orgpolicyconfigv1.UpdateOrgPolicyConfigResponse{
+		Config:          domainToProto(domain.MergeWithDefaults(current)),
+		Etag:            formatEtag(version),
+		ScheduledChange: scheduledChangeToProto(c),
+	}, nil
+}
+
+// ListScheduledPolicyConfigChanges returns the org's scheduled changes, soonest first, optionally only those with
+// one status. Caller must be org admin or owner.
+func (s *Server) ListScheduledPolicyConfigChanges(ctx context.Context, req *orgpolicyconfigv1.ListScheduledPolicyConfigChangesRequest) (*orgpolicyconfigv1.ListScheduledPolicyConfigChangesResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListScheduledPolicyConfigChanges not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	requestOrgID := req.GetOrgId()
+	if requestOrgID != "" && requestOrgID != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	filter := domain.ScheduledStatus(req.GetStatus())
+	switch filter {
+	case "", domain.ScheduledPending, domain.ScheduledApplied, domain.ScheduledCancelled, domain.ScheduledFailed:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be one of pending, applied, cancelled, failed")
+	}
+	pageSize := int32(defaultScheduledPageSize)
+	if ps := req.GetPagination().GetPageSize(); ps > 0 {
+		pageSize = ps
+	}
+	if pageSize > maxScheduledPageSize {
+		pageSize = maxScheduledPageSize
+	}
+	offset := int32(0)
+	if tok := req.GetPagination().GetPageToken(); tok != "" {
+		if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+			offset = int32(n)
+		}
+	}
+	list, err := s.repo.ListScheduledChanges(ctx, orgID, filter, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	out := &orgpolicyconfigv1.ListScheduledPolicyConfigChangesResponse{Pagination: &commonv1.PaginationResult{}}
+	for _, c := range list {
+		out.Changes = append(out.Changes, scheduledChangeToProto(c))
+	}
+	if len(list) == int(pageSize) {
+		out.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return out, nil
+}
+
+// CancelScheduledPolicyConfigChange cancels a pending scheduled change of the caller's org. Caller must be org admin
+// or owner.
+func (s *Server) CancelScheduledPolicyConfigChange(ctx context.Context, req *orgpolicyconfigv1.CancelScheduledPolicyConfigChangeRequest) (*orgpolicyconfigv1.CancelScheduledPolicyConfigChangeResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method CancelScheduledPolicyConfigChange not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	requestOrgID := req.GetOrgId()
+	if requestOrgID != "" && requestOrgID != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	c, err := s.repo.GetScheduledChange(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if c == nil || c.OrgID != orgID {
+		return nil, status.Error(codes.NotFound, "scheduled change not found")
+	}
+	res := domain.Resolution{By: userID, At: time.Now().UTC()}
+	ok, err := s.repo.TransitionScheduledChange(ctx, c.ID, domain.ScheduledPending, domain.ScheduledCancelled, res)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "scheduled change is not pending")
+	}
+	c.Status = domain.ScheduledCancelled
+	c.Resolution = res
+	return &orgpolicyconfigv1.CancelScheduledPolicyConfigChangeResponse{Change: scheduledChangeToProto(c)}, nil
+}
+
+// ActivateDue applies the scheduled changes that take effect at or before now, oldest first, and returns how many
+// were applied. A change that cannot be applied to the config current by then (e.g. it no longer validates, or the
+// org now requires change approval) is marked failed with the reason. Storage errors leave the change pending for
+// the next run. Called by orgpolicyconfig.Scheduler.
+func (s *Server) ActivateDue(ctx context.Context, now time.Time) (int, error) {
+	if s.repo == nil {
+		return 0, nil
+	}
+	due, err := s.repo.ListDueScheduledChanges(ctx, now, dueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	applied := 0
+	for _, c := range due {
+		// Claim the change first so two server replicas cannot both apply it.
+		ok, err := s.repo.TransitionScheduledChange(ctx, c.ID, domain.ScheduledPending, domain.ScheduledApplied, domain.Resolution{At: now})
+		if err != nil {
+			return applied, err
+		}
+		if !ok {
+			continue
+		}
+		res, applyErr := s.applyScheduled(ctx, c)
+		to := domain.ScheduledApplied
+		if applyErr != nil {
+			if code := status.Code(applyErr); code == codes.Internal || code == codes.Aborted {
+				if _, err := s.repo.TransitionScheduledChange(ctx, c.ID, domain.ScheduledApplied, domain.ScheduledPending, domain.Resolution{}); err != nil {
+					log.Printf("orgpolicyconfig: failed to reopen scheduled change %s: %v", c.ID, err)
+				}
+				return applied, applyErr
+			}
+			to = domain.ScheduledFailed
+			res.Error = status.Convert(applyErr).Message()
+			log.Printf("orgpolicyconfig: scheduled change %s for org %s failed: %s", c.ID, c.OrgID, res.Error)
+		} else {
+			applied++
+		}
+		if _, err := s.repo.TransitionScheduledChange(ctx, c.ID, domain.ScheduledApplied, to, res); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// applyScheduled stores c on top of the org's current config, recorded as the scheduling admin with source
+// scheduled, then syncs org_mfa_settings and notifies SubscribeBrowserPolicy streams. A failed sync does not undo
+// the stored config; it is reported in the resolution's Error.
+func (s *Server) applyScheduled(ctx context.Context, c *domain.ScheduledChange) (domain.Resolution, error) {
+	change := domain.Change{By: c.CreatedBy, Source: domain.ChangeSourceScheduled}
+	config, version, err := s.storeConfig(ctx, c.OrgID, "", change, func(current *domain.OrgPolicyConfig) (*domain.OrgPolicyConfig, error) {
+		if len(c.Sections) == 0 {
+			return c.Config, nil
+		}
+		next, err := domain.ApplySections(current, c.Config, c.Sections)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "update_mask: "+err.Error())
+		}
+		return next, nil
+	})
+	if err != nil {
+		return domain.Resolution{}, err
+	}
+	res := domain.Resolution{At: time.Now().UTC(), AppliedVersion: version}
+	if updatesMFASettings(c.Config, c.Sections) {
+		if err := s.syncMFASettings(ctx, c.OrgID, config); err != nil {
+			res.Error = status.Convert(err).Message()
+		}
+	}
+	s.publish(c.OrgID)
+	return res, nil
+}
+
+func scheduledChangeToProto(c *domain.ScheduledChange) *orgpolicyconfigv1.ScheduledPolicyConfigChange {
+	out := &orgpolicyconfigv1.ScheduledPolicyConfigChange{
+		Id:             c.ID,
+		OrgId:          c.OrgID,
+		Config:         domainToProto(c.Config),
+		EffectiveAt:    timestamppb.New(c.EffectiveAt),
+		Status:         string(c.Status),
+		CreatedBy:      c.CreatedBy,
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+		ResolvedBy:     c.By,
+		AppliedVersion: c.AppliedVersion,
+		Error:          c.Error,
+	}
+	if len(c.Sections) > 0 {
+		out.UpdateMask = &fieldmaskpb.FieldMask{Paths: append([]string(nil), c.Sections...)}
+	}
+	if !c.At.IsZero() {
+		out.ResolvedAt = timestamppb.New(c.At)
+	}
+	return out
+}
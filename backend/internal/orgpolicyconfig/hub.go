@@ -0,0 +1,56 @@
+// Package orgpolicyconfig notifies in-process subscribers (SubscribeBrowserPolicy streams) when an org's policy config
+// changes, and supplies org-configured access-token claims to security.TokenProvider.
+package orgpolicyconfig
+
+import "sync"
+
+// Hub fans out per-org change notifications. Notifications carry no payload and coalesce: a subscriber that is
+// slow to reload sees one pending notification, not one per update. It is in-process only; with several server
+// replicas, subscribers on other replicas learn of changes through their periodic resync.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+// NewHub returns an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[string]map[chan struct{}]struct{})}
+}
+
+// Subscribe registers for changes to orgID. The returned cancel func unregisters and must be called when done.
+func (h *Hub) Subscribe(orgID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	if h.subs[orgID] == nil {
+		h.subs[orgID] = make(map[chan struct{}]struct{})
+	}
+	h.subs[orgID][ch] = struct{}{}
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs[orgID], ch)
+		if len(h.subs[orgID]) == 0 {
+			delete(h.subs, orgID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Publish notifies every subscriber of orgID without blocking.
+func (h *Hub) Publish(orgID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[orgID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of active subscriptions for orgID.
+func (h *Hub) Subscribers(orgID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[orgID])
+}
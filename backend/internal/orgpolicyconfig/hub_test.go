@@ -0,0 +1,42 @@
+package orgpolicyconfig
+
+import "testing"
+
+func TestHub_PublishCoalesces(t *testing.T) {
+	h := NewHub()
+	ch, cancel := h.Subscribe("org-1")
+	defer cancel()
+	other, cancelOther := h.Subscribe("org-2")
+	defer cancelOther()
+
+	h.Publish("org-1")
+	h.Publish("org-1")
+	select {
+	case <-ch:
+	default:
+		t.Fatal("subscriber should be notified")
+	}
+	select {
+	case <-ch:
+		t.Fatal("notifications should coalesce into one")
+	default:
+	}
+	select {
+	case <-other:
+		t.Fatal("other org should not be notified")
+	default:
+	}
+}
+
+func TestHub_Cancel(t *testing.T) {
+	h := NewHub()
+	_, cancel := h.Subscribe("org-1")
+	if n := h.Subscribers("org-1"); n != 1 {
+		t.Fatalf("subscribers = %d, want 1", n)
+	}
+	cancel()
+	if n := h.Subscribers("org-1"); n != 0 {
+		t.Errorf("subscribers after cancel = %d, want 0", n)
+	}
+	h.Publish("org-1")
+}
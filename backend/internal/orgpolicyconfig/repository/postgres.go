@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
@@ -12,44 +13,288 @@ import (
 )
 
 type PostgresRepository struct {
+	db      *sql.DB
 	queries *gen.Queries
 }
 
 // NewPostgresRepository returns an org policy config repository that uses the given db.
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+	return &PostgresRepository{db: db, queries: gen.New(db)}
 }
 
 // GetByOrgID returns the config for the org, or nil if not found.
 func (r *PostgresRepository) GetByOrgID(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, error) {
+	config, _, err := r.GetVersioned(ctx, orgID)
+	return config, err
+}
+
+// GetVersioned returns the config for the org and its version, or (nil, 0) if not found.
+func (r *PostgresRepository) GetVersioned(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, int64, error) {
 	row, err := r.queries.GetOrgPolicyConfig(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	var config domain.OrgPolicyConfig
+	if err := json.Unmarshal([]byte(row.ConfigJson), &config); err != nil {
+		return nil, 0, err
+	}
+	return &config, row.Version, nil
+}
+
+// Upsert saves or replaces the config for the org, bumping its version, and records the version in the history.
+func (r *PostgresRepository) Upsert(ctx context.Context, orgID string, config *domain.OrgPolicyConfig, change domain.Change) error {
+	raw, err := marshalMerged(config)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	row, err := q.UpsertOrgPolicyConfig(ctx, gen.UpsertOrgPolicyConfigParams{
+		OrgID:      orgID,
+		ConfigJson: raw,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		return err
+	}
+	if err := createVersion(ctx, q, orgID, row.Version, raw, now, change); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateIfVersion saves the config only if its stored version is still version (0 = the org has no stored config)
+// and returns the new version, or ok false if another write got there first. The new version is recorded in the
+// history in the same transaction.
+func (r *PostgresRepository) UpdateIfVersion(ctx context.Context, orgID string, config *domain.OrgPolicyConfig, version int64, change domain.Change) (int64, bool, error) {
+	raw, err := marshalMerged(config)
+	if err != nil {
+		return 0, false, err
+	}
+	now := time.Now().UTC()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	var newVersion int64
+	if version == 0 {
+		newVersion, err = q.InsertOrgPolicyConfigIfAbsent(ctx, gen.InsertOrgPolicyConfigIfAbsentParams{
+			OrgID:      orgID,
+			ConfigJson: raw,
+			UpdatedAt:  now,
+		})
+	} else {
+		newVersion, err = q.UpdateOrgPolicyConfigIfVersion(ctx, gen.UpdateOrgPolicyConfigIfVersionParams{
+			OrgID:      orgID,
+			ConfigJson: raw,
+			UpdatedAt:  now,
+			Version:    version,
+		})
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if err := createVersion(ctx, q, orgID, newVersion, raw, now, change); err != nil {
+		return 0, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, false, err
+	}
+	return newVersion, true, nil
+}
+
+// ListVersions returns the org's config history, newest first.
+func (r *PostgresRepository) ListVersions(ctx context.Context, orgID string, limit, offset int32) ([]*domain.ConfigVersion, error) {
+	rows, err := r.queries.ListOrgPolicyConfigVersions(ctx, gen.ListOrgPolicyConfigVersionsParams{
+		OrgID:  orgID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.ConfigVersion, len(rows))
+	for i := range rows {
+		if out[i], err = genVersionToDomain(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// GetVersion returns one version of the org's config, or nil if not found.
+func (r *PostgresRepository) GetVersion(ctx context.Context, orgID string, version int64) (*domain.ConfigVersion, error) {
+	row, err := r.queries.GetOrgPolicyConfigVersion(ctx, gen.GetOrgPolicyConfigVersionParams{OrgID: orgID, Version: version})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genVersionToDomain(&row)
+}
+
+// CreateScheduledChange persists a new scheduled change. The update is stored as sent (not merged with defaults).
+func (r *PostgresRepository) CreateScheduledChange(ctx context.Context, c *domain.ScheduledChange) error {
+	raw, err := json.Marshal(c.Config)
+	if err != nil {
+		return err
+	}
+	return r.queries.CreateScheduledPolicyConfigChange(ctx, gen.CreateScheduledPolicyConfigChangeParams{
+		ID:          c.ID,
+		OrgID:       c.OrgID,
+		ConfigJson:  string(raw),
+		UpdateMask:  strings.Join(c.Sections, ","),
+		EffectiveAt: c.EffectiveAt,
+		Status:      string(c.Status),
+		CreatedBy:   c.CreatedBy,
+		CreatedAt:   c.CreatedAt,
+	})
+}
+
+// GetScheduledChange returns the scheduled change, or nil if not found.
+func (r *PostgresRepository) GetScheduledChange(ctx context.Context, id string) (*domain.ScheduledChange, error) {
+	row, err := r.queries.GetScheduledPolicyConfigChange(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
+	return genScheduledChangeToDomain(&row)
+}
+
+// ListScheduledChanges returns the org's scheduled changes, soonest first, optionally only those in status.
+func (r *PostgresRepository) ListScheduledChanges(ctx context.Context, orgID string, status domain.ScheduledStatus, limit, offset int32) ([]*domain.ScheduledChange, error) {
+	rows, err := r.queries.ListScheduledPolicyConfigChangesByOrg(ctx, gen.ListScheduledPolicyConfigChangesByOrgParams{
+		OrgID:        orgID,
+		Limit:        limit,
+		Offset:       offset,
+		FilterStatus: sql.NullString{String: string(status), Valid: status != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return genScheduledChangesToDomain(rows)
+}
+
+// ListDueScheduledChanges returns pending changes that take effect at or before now, oldest first.
+func (r *PostgresRepository) ListDueScheduledChanges(ctx context.Context, now time.Time, limit int32) ([]*domain.ScheduledChange, error) {
+	rows, err := r.queries.ListDueScheduledPolicyConfigChanges(ctx, gen.ListDueScheduledPolicyConfigChangesParams{
+		EffectiveAt: now,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return genScheduledChangesToDomain(rows)
+}
+
+// TransitionScheduledChange moves the change from one status to another if it is still in from, recording res.
+func (r *PostgresRepository) TransitionScheduledChange(ctx context.Context, id string, from, to domain.ScheduledStatus, res domain.Resolution) (bool, error) {
+	n, err := r.queries.TransitionScheduledPolicyConfigChange(ctx, gen.TransitionScheduledPolicyConfigChangeParams{
+		Status:         string(to),
+		ResolvedBy:     sql.NullString{String: res.By, Valid: res.By != ""},
+		ResolvedAt:     sql.NullTime{Time: res.At, Valid: !res.At.IsZero()},
+		AppliedVersion: sql.NullInt64{Int64: res.AppliedVersion, Valid: res.AppliedVersion != 0},
+		Error:          res.Error,
+		ID:             id,
+		FromStatus:     string(from),
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func genScheduledChangesToDomain(rows []gen.ScheduledPolicyConfigChange) ([]*domain.ScheduledChange, error) {
+	out := make([]*domain.ScheduledChange, len(rows))
+	for i := range rows {
+		var err error
+		if out[i], err = genScheduledChangeToDomain(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func genScheduledChangeToDomain(row *gen.ScheduledPolicyConfigChange) (*domain.ScheduledChange, error) {
+	var config domain.OrgPolicyConfig
+	if err := json.Unmarshal([]byte(row.ConfigJson), &config); err != nil {
+		return nil, err
+	}
+	c := &domain.ScheduledChange{
+		ID:          row.ID,
+		OrgID:       row.OrgID,
+		Config:      &config,
+		EffectiveAt: row.EffectiveAt,
+		Status:      domain.ScheduledStatus(row.Status),
+		CreatedBy:   row.CreatedBy,
+		CreatedAt:   row.CreatedAt,
+		Resolution: domain.Resolution{
+			By:             row.ResolvedBy.String,
+			At:             row.ResolvedAt.Time,
+			AppliedVersion: row.AppliedVersion.Int64,
+			Error:          row.Error,
+		},
+	}
+	if row.UpdateMask != "" {
+		c.Sections = strings.Split(row.UpdateMask, ",")
+	}
+	return c, nil
+}
+
+func createVersion(ctx context.Context, q *gen.Queries, orgID string, version int64, raw string, at time.Time, change domain.Change) error {
+	return q.CreateOrgPolicyConfigVersion(ctx, gen.CreateOrgPolicyConfigVersionParams{
+		OrgID:           orgID,
+		Version:         version,
+		ConfigJson:      raw,
+		ChangedBy:       sql.NullString{String: change.By, Valid: change.By != ""},
+		ChangedAt:       at,
+		Source:          change.Source,
+		RestoredVersion: sql.NullInt64{Int64: change.RestoredVersion, Valid: change.RestoredVersion != 0},
+	})
+}
+
+func genVersionToDomain(row *gen.OrgPolicyConfigVersion) (*domain.ConfigVersion, error) {
 	var config domain.OrgPolicyConfig
 	if err := json.Unmarshal([]byte(row.ConfigJson), &config); err != nil {
 		return nil, err
 	}
-	return &config, nil
+	return &domain.ConfigVersion{
+		OrgID:     row.OrgID,
+		Version:   row.Version,
+		Config:    &config,
+		ChangedAt: row.ChangedAt,
+		Change: domain.Change{
+			By:              row.ChangedBy.String,
+			Source:          row.Source,
+			RestoredVersion: row.RestoredVersion.Int64,
+		},
+	}, nil
 }
 
-// Upsert saves or replaces the config for the org.
-func (r *PostgresRepository) Upsert(ctx context.Context, orgID string, config *domain.OrgPolicyConfig) error {
+// marshalMerged returns the JSON stored for config: config with nil sections replaced by defaults.
+func marshalMerged(config *domain.OrgPolicyConfig) (string, error) {
 	if config == nil {
 		config = &domain.OrgPolicyConfig{}
 	}
-	merged := domain.MergeWithDefaults(config)
-	raw, err := json.Marshal(merged)
+	raw, err := json.Marshal(domain.MergeWithDefaults(config))
 	if err != nil {
-		return err
+		return "", err
 	}
-	_, err = r.queries.UpsertOrgPolicyConfig(ctx, gen.UpsertOrgPolicyConfigParams{
-		OrgID:      orgID,
-		ConfigJson: string(raw),
-		UpdatedAt:  time.Now().UTC(),
-	})
-	return err
+	return string(raw), nil
 }
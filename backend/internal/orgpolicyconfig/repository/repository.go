@@ -2,14 +2,40 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
 )
 
-// Repository persists org policy config.
+// Repository persists org policy config and scheduled config changes. Every config write also records the new
+// version in the config history.
 type Repository interface {
 	// GetByOrgID returns the config for the org, or nil if not found (caller applies defaults).
 	GetByOrgID(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, error)
 	// Upsert saves or replaces the config for the org.
-	Upsert(ctx context.Context, orgID string, config *domain.OrgPolicyConfig) error
+	Upsert(ctx context.Context, orgID string, config *domain.OrgPolicyConfig, change domain.Change) error
+	// GetVersioned is GetByOrgID that also returns the config's version, which every write bumps. The version is 0
+	// when the org has no stored config.
+	GetVersioned(ctx context.Context, orgID string) (*domain.OrgPolicyConfig, int64, error)
+	// UpdateIfVersion saves the config only if its version is still version (0 = no stored config) and returns the
+	// new version. ok is false, and nothing is saved, when the version has changed.
+	UpdateIfVersion(ctx context.Context, orgID string, config *domain.OrgPolicyConfig, version int64, change domain.Change) (newVersion int64, ok bool, err error)
+	// ListVersions returns the org's config history, newest first.
+	ListVersions(ctx context.Context, orgID string, limit, offset int32) ([]*domain.ConfigVersion, error)
+	// GetVersion returns one version from the org's config history, or nil if not found.
+	GetVersion(ctx context.Context, orgID string, version int64) (*domain.ConfigVersion, error)
+
+	// CreateScheduledChange persists a new scheduled change. The change must have ID set.
+	CreateScheduledChange(ctx context.Context, c *domain.ScheduledChange) error
+	// GetScheduledChange returns the scheduled change, or nil if not found.
+	GetScheduledChange(ctx context.Context, id string) (*domain.ScheduledChange, error)
+	// ListScheduledChanges returns the org's scheduled changes by effective time, soonest first. An empty status
+	// matches every status.
+	ListScheduledChanges(ctx context.Context, orgID string, status domain.ScheduledStatus, limit, offset int32) ([]*domain.ScheduledChange, error)
+	// ListDueScheduledChanges returns up to limit pending changes of any org that take effect at or before now,
+	// oldest first.
+	ListDueScheduledChanges(ctx context.Context, now time.Time, limit int32) ([]*domain.ScheduledChange, error)
+	// TransitionScheduledChange moves the change from one status to another and records res. It reports false,
+	// without changing anything, when the change is no longer in from.
+	TransitionScheduledChange(ctx context.Context, id string, from, to domain.ScheduledStatus, res domain.Resolution) (bool, error)
 }
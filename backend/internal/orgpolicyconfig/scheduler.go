@@ -0,0 +1,55 @@
+package orgpolicyconfig
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DueActivator applies the scheduled config changes due at now and returns how many were applied. Implemented by
+// the org policy config handler.
+type DueActivator interface {
+	ActivateDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// Scheduler periodically applies scheduled org policy config changes once their effective time has passed.
+type Scheduler struct {
+	activator DueActivator
+	now       func() time.Time
+}
+
+// NewScheduler returns a scheduler that applies changes through activator.
+func NewScheduler(activator DueActivator) *Scheduler {
+	return &Scheduler{activator: activator, now: time.Now}
+}
+
+// RunOnce applies the changes due now and returns how many were applied.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	return s.activator.ActivateDue(ctx, s.now().UTC())
+}
+
+// Run applies due changes on start, so changes that fell due while the server was down are not delayed, then every
+// interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	s.runLogged(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runLogged(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) runLogged(ctx context.Context) {
+	n, err := s.RunOnce(ctx)
+	if err != nil {
+		log.Printf("orgpolicyconfig: applying scheduled changes failed: %v", err)
+	}
+	if n > 0 {
+		log.Printf("orgpolicyconfig: applied %d scheduled config change(s)", n)
+	}
+}
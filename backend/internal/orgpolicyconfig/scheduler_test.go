@@ -0,0 +1,75 @@
+package orgpolicyconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type recordingActivator struct {
+	calls []time.Time
+	n     int
+	err   error
+}
+
+func (a *recordingActivator) ActivateDue(ctx context.Context, now time.Time) (int, error) {
+	a.calls = append(a.calls, now)
+	return a.n, a.err
+}
+
+func TestScheduler_RunOnce_PassesUTCNow(t *testing.T) {
+	activator := &recordingActivator{n: 2}
+	s := NewScheduler(activator)
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	s.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, loc) }
+
+	n, err := s.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("applied = %d, want 2", n)
+	}
+	if len(activator.calls) != 1 {
+		t.Fatalf("calls = %d, want 1", len(activator.calls))
+	}
+	want := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	if got := activator.calls[0]; !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("now = %v, want %v", got, want)
+	}
+}
+
+func TestScheduler_RunOnce_ReturnsError(t *testing.T) {
+	activator := &recordingActivator{n: 1, err: errors.New("db down")}
+	s := NewScheduler(activator)
+
+	n, err := s.RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("RunOnce should return the activator error")
+	}
+	if n != 1 {
+		t.Errorf("applied = %d, want 1", n)
+	}
+}
+
+func TestScheduler_Run_RunsOnStartAndStops(t *testing.T) {
+	activator := &recordingActivator{}
+	s := NewScheduler(activator)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, time.Hour)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	if len(activator.calls) != 1 {
+		t.Errorf("calls = %d, want 1 (on start)", len(activator.calls))
+	}
+}
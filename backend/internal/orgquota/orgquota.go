@@ -0,0 +1,73 @@
+// Package orgquota enforces an org's quotas, set by platform admins with OrganizationService.UpdateOrganization:
+// max_users bounds the org's members (MembershipService.AddMember, accepted invitations, break-glass accounts) and
+// max_devices its active devices (registered on sign-in from a new device). A quota of 0 is unlimited.
+//
+// The check runs inside the transaction that inserts the member or device. When the org has the quota it locks the
+// org row first, so two concurrent inserts into a full org cannot both see room for one more; orgs without the quota
+// are never locked, so their inserts do not wait on each other.
+package orgquota
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+var (
+	// ErrUserQuotaExceeded is returned by ReserveUser when the org already has max_users members.
+	ErrUserQuotaExceeded = errors.New("orgquota: organization user quota exceeded")
+	// ErrDeviceQuotaExceeded is returned by ReserveDevice when the org already has max_devices active devices.
+	ErrDeviceQuotaExceeded = errors.New("orgquota: organization device quota exceeded")
+)
+
+// Queries is the part of *gen.Queries the checks use. Pass the queries of the transaction that does the insert.
+type Queries interface {
+	GetOrganizationQuotas(ctx context.Context, id string) (gen.GetOrganizationQuotasRow, error)
+	LockOrganizationQuotas(ctx context.Context, id string) (gen.LockOrganizationQuotasRow, error)
+	CountMembershipsByOrg(ctx context.Context, orgID string) (int64, error)
+	CountActiveDevicesByOrg(ctx context.Context, orgID string) (int64, error)
+}
+
+// ReserveUser returns ErrUserQuotaExceeded when orgID has no room for another member. When the org has a max_users
+// quota it locks the org row until q's transaction ends, so insert the membership in that transaction. An org without
+// a quota, or an unknown org, takes no lock.
+func ReserveUser(ctx context.Context, q Queries, orgID string) error {
+	return reserve(ctx, q, orgID, func(maxUsers, _ int32) int32 { return maxUsers }, q.CountMembershipsByOrg, ErrUserQuotaExceeded)
+}
+
+// ReserveDevice returns ErrDeviceQuotaExceeded when orgID has no room for another active device. When the org has a
+// max_devices quota it locks the org row until q's transaction ends, so insert the device in that transaction. An org
+// without a quota, or an unknown org, takes no lock.
+func ReserveDevice(ctx context.Context, q Queries, orgID string) error {
+	return reserve(ctx, q, orgID, func(_, maxDevices int32) int32 { return maxDevices }, q.CountActiveDevicesByOrg, ErrDeviceQuotaExceeded)
+}
+
+// reserve reads orgID's quotas without a lock and returns early when limit picks none. Otherwise it locks the org row,
+// reads the quota again under the lock (it may have changed) and compares it with count.
+func reserve(ctx context.Context, q Queries, orgID string, limit func(maxUsers, maxDevices int32) int32, count func(context.Context, string) (int64, error), exceeded error) error {
+	quotas, err := q.GetOrganizationQuotas(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil || limit(quotas.MaxUsers, quotas.MaxDevices) <= 0 {
+		return err
+	}
+	locked, err := q.LockOrganizationQuotas(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	quota := limit(locked.MaxUsers, locked.MaxDevices)
+	if err != nil || quota <= 0 {
+		return err
+	}
+	n, err := count(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if n >= int64(quota) {
+		return exceeded
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package orgquota
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+type fakeQueries struct {
+	orgs    map[string]gen.LockOrganizationQuotasRow
+	counts  map[string]int64
+	locked  []string
+	lockErr error
+}
+
+func (q *fakeQueries) GetOrganizationQuotas(ctx context.Context, id string) (gen.GetOrganizationQuotasRow, error) {
+	row, ok := q.orgs[id]
+	if !ok {
+		return gen.GetOrganizationQuotasRow{}, sql.ErrNoRows
+	}
+	return gen.GetOrganizationQuotasRow{MaxUsers: row.MaxUsers, MaxDevices: row.MaxDevices}, nil
+}
+
+func (q *fakeQueries) LockOrganizationQuotas(ctx context.Context, id string) (gen.LockOrganizationQuotasRow, error) {
+	if q.lockErr != nil {
+		return gen.LockOrganizationQuotasRow{}, q.lockErr
+	}
+	q.locked = append(q.locked, id)
+	row, ok := q.orgs[id]
+	if !ok {
+		return row, sql.ErrNoRows
+	}
+	return row, nil
+}
+
+func (q *fakeQueries) CountMembershipsByOrg(ctx context.Context, orgID string) (int64, error) {
+	return q.counts[orgID], nil
+}
+
+func (q *fakeQueries) CountActiveDevicesByOrg(ctx context.Context, orgID string) (int64, error) {
+	return q.counts[orgID], nil
+}
+
+func TestReserve(t *testing.T) {
+	q := &fakeQueries{
+		orgs: map[string]gen.LockOrganizationQuotasRow{
+			"full":      {MaxUsers: 2, MaxDevices: 3},
+			"room":      {MaxUsers: 5, MaxDevices: 5},
+			"unlimited": {},
+		},
+		counts: map[string]int64{"full": 3, "room": 2, "unlimited": 1000},
+	}
+	ctx := context.Background()
+
+	if err := ReserveUser(ctx, q, "full"); !errors.Is(err, ErrUserQuotaExceeded) {
+		t.Errorf("ReserveUser(full) = %v, want ErrUserQuotaExceeded", err)
+	}
+	if err := ReserveDevice(ctx, q, "full"); !errors.Is(err, ErrDeviceQuotaExceeded) {
+		t.Errorf("ReserveDevice(full) = %v, want ErrDeviceQuotaExceeded", err)
+	}
+	for _, orgID := range []string{"room", "unlimited", "unknown"} {
+		if err := ReserveUser(ctx, q, orgID); err != nil {
+			t.Errorf("ReserveUser(%s) = %v, want nil", orgID, err)
+		}
+		if err := ReserveDevice(ctx, q, orgID); err != nil {
+			t.Errorf("ReserveDevice(%s) = %v, want nil", orgID, err)
+		}
+	}
+	if want := "full,full,room,room"; strings.Join(q.locked, ",") != want {
+		t.Errorf("locked %v, want %s: the org row locked only by orgs with a quota", q.locked, want)
+	}
+
+	q.lockErr = errors.New("connection reset")
+	if err := ReserveUser(ctx, q, "room"); !errors.Is(err, q.lockErr) {
+		t.Errorf("ReserveUser with lock error = %v, want the error", err)
+	}
+}
+
+func TestReserve_NoQuotaTakesNoLock(t *testing.T) {
+	q := &fakeQueries{
+		orgs:    map[string]gen.LockOrganizationQuotasRow{"unlimited": {}, "users-only": {MaxUsers: 10}},
+		counts:  map[string]int64{"unlimited": 1000, "users-only": 1000},
+		lockErr: errors.New("the org row must not be locked"),
+	}
+	ctx := context.Background()
+	for _, orgID := range []string{"unlimited", "unknown"} {
+		if err := ReserveUser(ctx, q, orgID); err != nil {
+			t.Errorf("ReserveUser(%s) = %v, want nil without a lock", orgID, err)
+		}
+		if err := ReserveDevice(ctx, q, orgID); err != nil {
+			t.Errorf("ReserveDevice(%s) = %v, want nil without a lock", orgID, err)
+		}
+	}
+	if err := ReserveDevice(ctx, q, "users-only"); err != nil {
+		t.Errorf("ReserveDevice(users-only) = %v, want nil without a lock", err)
+	}
+	if err := ReserveUser(ctx, q, "users-only"); !errors.Is(err, q.lockErr) {
+		t.Errorf("ReserveUser(users-only) = %v, want the lock taken", err)
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
+)
+
+// Template is what a new org is set up with besides its owner.
+type Template struct {
+	Name        string
+	Description string
+	// PolicyRules is the Rego of the org's first policy, created enabled. Empty creates no policy, so MFA evaluation
+	// uses the built-in default policy.
+	PolicyRules string
+	// PolicyConfig is the org's first policy config; sections left nil take their defaults. Its auth_mfa and
+	// device_trust also give the org's MFA settings.
+	PolicyConfig *orgpolicyconfigdomain.OrgPolicyConfig
+}
+
+// Setup is a new org and everything created with it, in one transaction.
+type Setup struct {
+	Org         *organizationdomain.Org
+	Owner       *membershipdomain.Membership
+	MFASettings *orgmfasettingsdomain.OrgMFASettings
+	// Policy is nil when the template has no policy rules.
+	Policy *policydomain.Policy
+	// PolicyConfig is the template's config merged with defaults; PolicyConfigVersion is its version (1).
+	PolicyConfig        *orgpolicyconfigdomain.OrgPolicyConfig
+	PolicyConfigVersion int64
+	// Template is the name of the template the org was set up from.
+	Template string
+}
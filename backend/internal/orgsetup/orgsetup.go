@@ -0,0 +1,160 @@
+// Package orgsetup creates a ready-to-use org in one step: the org, its owner membership, MFA settings, a first Rego
+// policy and a policy config, all from a named template (a built-in one or a policy preset) and in one transaction.
+// Without it an org created with CreateOrganization is empty and its admin configures it with a call per setting.
+package orgsetup
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/orgsetup/domain"
+	"zero-trust-control-plane/backend/internal/orgsetup/repository"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
+	"zero-trust-control-plane/backend/internal/policy/engine"
+	policypresetdomain "zero-trust-control-plane/backend/internal/policypreset/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// DefaultTemplate is the template used when none is named.
+const DefaultTemplate = "default"
+
+var (
+	// ErrNameRequired is returned for an empty org name.
+	ErrNameRequired = errors.New("orgsetup: name is required")
+	// ErrUserNotFound is returned when the owner does not exist.
+	ErrUserNotFound = errors.New("orgsetup: user not found")
+	// ErrUnknownTemplate is returned for a template name that is neither in Templates nor a policy preset.
+	ErrUnknownTemplate = errors.New("orgsetup: unknown template")
+)
+
+// builtinTemplates are the templates an org can be set up from, by name.
+var builtinTemplates = map[string]domain.Template{
+	DefaultTemplate: {
+		Name:        DefaultTemplate,
+		Description: "MFA on new and untrusted devices, devices trusted for 30 days after MFA, and the built-in device trust policy.",
+		PolicyRules: engine.DefaultRegoPolicy,
+	},
+}
+
+// Templates returns the built-in templates, by name.
+func Templates() []domain.Template {
+	out := make([]domain.Template, 0, len(builtinTemplates))
+	for _, t := range builtinTemplates {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// UserGetter resolves the owner of a new org.
+type UserGetter interface {
+	GetByID(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+// PresetGetter resolves policy presets by name (e.g. policypresetrepo.Repository); nil if not found.
+type PresetGetter interface {
+	GetByName(ctx context.Context, name string) (*policypresetdomain.Preset, error)
+}
+
+// Setuper sets up orgs.
+type Setuper struct {
+	repo    repository.Repository
+	users   UserGetter
+	presets PresetGetter
+}
+
+// NewSetuper returns a setuper backed by repo. presets may be nil; then only the built-in templates are available.
+func NewSetuper(repo repository.Repository, users UserGetter, presets PresetGetter) *Setuper {
+	return &Setuper{repo: repo, users: users, presets: presets}
+}
+
+// Setup creates an active org named name, owned by ownerID, in dataRegion (empty for the primary database), from the
+// named template (empty for DefaultTemplate) or policy preset. Either everything is created or nothing is. Returns
+// what was created.
+func (s *Setuper) Setup(ctx context.Context, name, ownerID, dataRegion, templateName string) (*domain.Setup, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if templateName == "" {
+		templateName = DefaultTemplate
+	}
+	tmpl, err := s.template(ctx, templateName)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := s.users.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, ErrUserNotFound
+	}
+
+	now := time.Now().UTC()
+	orgID := uuid.New().String()
+	config := orgpolicyconfigdomain.MergeWithDefaults(tmpl.PolicyConfig)
+	setup := &domain.Setup{
+		Org: &organizationdomain.Org{
+			ID:         orgID,
+			Name:       name,
+			Status:     organizationdomain.OrgStatusActive,
+			DataRegion: dataRegion,
+			CreatedAt:  now,
+		},
+		Owner: &membershipdomain.Membership{
+			ID:        uuid.New().String(),
+			UserID:    ownerID,
+			OrgID:     orgID,
+			Role:      membershipdomain.RoleOwner,
+			CreatedAt: now,
+		},
+		MFASettings:  orgpolicyconfigdomain.MFASettings(orgID, config, now),
+		PolicyConfig: config,
+		Template:     tmpl.Name,
+	}
+	if tmpl.PolicyRules != "" {
+		setup.Policy = &policydomain.Policy{
+			ID:        uuid.New().String(),
+			OrgID:     orgID,
+			Rules:     tmpl.PolicyRules,
+			Enabled:   true,
+			CreatedAt: now,
+		}
+	}
+	if err := s.repo.Create(ctx, setup); err != nil {
+		return nil, err
+	}
+	return setup, nil
+}
+
+// template returns the built-in template or, failing that, the policy preset named name.
+func (s *Setuper) template(ctx context.Context, name string) (domain.Template, error) {
+	if tmpl, ok := builtinTemplates[name]; ok {
+		return tmpl, nil
+	}
+	if s.presets == nil {
+		return domain.Template{}, ErrUnknownTemplate
+	}
+	p, err := s.presets.GetByName(ctx, name)
+	if err != nil {
+		return domain.Template{}, err
+	}
+	if p == nil {
+		return domain.Template{}, ErrUnknownTemplate
+	}
+	return domain.Template{
+		Name:         p.Name,
+		Description:  p.Description,
+		PolicyRules:  p.PolicyRules,
+		PolicyConfig: p.Config,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package orgsetup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/orgsetup/domain"
+	"zero-trust-control-plane/backend/internal/policy/engine"
+	policypresetdomain "zero-trust-control-plane/backend/internal/policypreset/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+type memRepo struct {
+	created []*domain.Setup
+	err     error
+}
+
+func (m *memRepo) Create(ctx context.Context, s *domain.Setup) error {
+	if m.err != nil {
+		return m.err
+	}
+	s.PolicyConfigVersion = 1
+	m.created = append(m.created, s)
+	return nil
+}
+
+type users map[string]*userdomain.User
+
+func (u users) GetByID(ctx context.Context, id string) (*userdomain.User, error) { return u[id], nil }
+
+func TestSetup(t *testing.T) {
+	repo := &memRepo{}
+	s := NewSetuper(repo, users{"user-1": {ID: "user-1"}}, nil)
+	ctx := context.Background()
+
+	got, err := s.Setup(ctx, "  Acme ", "user-1", "eu", "")
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if len(repo.created) != 1 || repo.created[0] != got {
+		t.Fatalf("created = %v, want the returned setup stored once", repo.created)
+	}
+	orgID := got.Org.ID
+	if got.Org.Name != "Acme" || got.Org.DataRegion != "eu" || got.Template != DefaultTemplate {
+		t.Errorf("org = %+v, template %q", got.Org, got.Template)
+	}
+	if got.Owner.UserID != "user-1" || got.Owner.OrgID != orgID || got.Owner.Role != membershipdomain.RoleOwner {
+		t.Errorf("owner = %+v, want user-1 as owner of %s", got.Owner, orgID)
+	}
+	if got.Policy == nil || got.Policy.OrgID != orgID || !got.Policy.Enabled || got.Policy.Rules != engine.DefaultRegoPolicy {
+		t.Errorf("policy = %+v, want the default policy, enabled", got.Policy)
+	}
+	if got.PolicyConfig.AuthMfa == nil || got.PolicyConfig.AuthMfa.MfaRequirement != "new_device" || got.PolicyConfigVersion != 1 {
+		t.Errorf("policy config = %+v (version %d), want defaults at version 1", got.PolicyConfig, got.PolicyConfigVersion)
+	}
+	mfa := got.MFASettings
+	if mfa.OrgID != orgID || !mfa.MFARequiredForNewDevice || !mfa.MFARequiredForUntrusted || mfa.MFARequiredAlways || mfa.TrustTTLDays != 30 {
+		t.Errorf("MFA settings = %+v, want them mirrored from the default config", mfa)
+	}
+}
+
+type presets map[string]*policypresetdomain.Preset
+
+func (p presets) GetByName(ctx context.Context, name string) (*policypresetdomain.Preset, error) {
+	return p[name], nil
+}
+
+func TestSetup_Preset(t *testing.T) {
+	strict := &policypresetdomain.Preset{
+		Name:        "strict",
+		Description: "MFA on every sign-in.",
+		PolicyRules: "package ztcp.device_trust\n\ndefault mfa_required = true\n",
+		Config: &orgpolicyconfigdomain.OrgPolicyConfig{
+			AuthMfa: &orgpolicyconfigdomain.AuthMfa{MfaRequirement: "always"},
+		},
+	}
+	s := NewSetuper(&memRepo{}, users{"user-1": {ID: "user-1"}}, presets{"strict": strict})
+	ctx := context.Background()
+
+	got, err := s.Setup(ctx, "Acme", "user-1", "", "strict")
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if got.Template != "strict" || got.Policy == nil || got.Policy.Rules != strict.PolicyRules {
+		t.Errorf("template %q, policy %+v, want the preset's", got.Template, got.Policy)
+	}
+	if got.PolicyConfig.AuthMfa.MfaRequirement != "always" || got.PolicyConfig.SessionMgmt == nil || !got.MFASettings.MFARequiredAlways {
+		t.Errorf("policy config = %+v, MFA settings %+v, want the preset's merged with defaults", got.PolicyConfig, got.MFASettings)
+	}
+	if got, err := s.Setup(ctx, "Acme", "user-1", "", ""); err != nil || got.Template != DefaultTemplate {
+		t.Errorf("built-in default with presets = %v, %v", got, err)
+	}
+	if _, err := s.Setup(ctx, "Acme", "user-1", "", "lax"); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("unknown preset = %v, want ErrUnknownTemplate", err)
+	}
+}
+
+func TestSetup_Errors(t *testing.T) {
+	ctx := context.Background()
+	s := NewSetuper(&memRepo{}, users{"user-1": {ID: "user-1"}}, nil)
+	if _, err := s.Setup(ctx, " ", "user-1", "", ""); !errors.Is(err, ErrNameRequired) {
+		t.Errorf("empty name = %v, want ErrNameRequired", err)
+	}
+	if _, err := s.Setup(ctx, "Acme", "user-1", "", "lax"); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("unknown template = %v, want ErrUnknownTemplate", err)
+	}
+	if _, err := s.Setup(ctx, "Acme", "user-2", "", ""); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("unknown owner = %v, want ErrUserNotFound", err)
+	}
+	failed := errors.New("insert failed")
+	if _, err := NewSetuper(&memRepo{err: failed}, users{"user-1": {ID: "user-1"}}, nil).Setup(ctx, "Acme", "user-1", "", ""); !errors.Is(err, failed) {
+		t.Errorf("repository failure = %v, want it returned", err)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	list := Templates()
+	if len(list) == 0 || list[0].Name == "" || list[0].Description == "" {
+		t.Errorf("Templates() = %+v, want named, described templates", list)
+	}
+}
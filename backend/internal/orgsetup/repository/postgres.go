@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/orgsetup/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an org setup repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db)}
+}
+
+// Create inserts the whole setup in one transaction; nothing is left behind if any insert fails.
+func (r *PostgresRepository) Create(ctx context.Context, s *domain.Setup) error {
+	raw, err := json.Marshal(s.PolicyConfig)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	o := s.Org
+	if _, err := q.CreateOrganization(ctx, gen.CreateOrganizationParams{
+		ID: o.ID, Name: o.Name, Status: gen.OrgStatus(o.Status), CreatedAt: o.CreatedAt, DataRegion: o.DataRegion,
+	}); err != nil {
+		return err
+	}
+	m := s.Owner
+	if _, err := q.CreateMembership(ctx, gen.CreateMembershipParams{
+		ID: m.ID, UserID: m.UserID, OrgID: m.OrgID, Role: gen.Role(m.Role), CreatedAt: m.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	mfa := s.MFASettings
+	if _, err := q.UpsertOrgMFASettings(ctx, gen.UpsertOrgMFASettingsParams{
+		OrgID:                   mfa.OrgID,
+		MfaRequiredForNewDevice: mfa.MFARequiredForNewDevice,
+		MfaRequiredForUntrusted: mfa.MFARequiredForUntrusted,
+		MfaRequiredAlways:       mfa.MFARequiredAlways,
+		RegisterTrustAfterMfa:   mfa.RegisterTrustAfterMFA,
+		TrustTtlDays:            int32(mfa.TrustTTLDays),
+		CreatedAt:               mfa.CreatedAt,
+		UpdatedAt:               mfa.UpdatedAt,
+	}); err != nil {
+		return err
+	}
+	if p := s.Policy; p != nil {
+		if _, err := q.CreatePolicy(ctx, gen.CreatePolicyParams{
+			ID: p.ID, OrgID: p.OrgID, Rules: p.Rules, Enabled: p.Enabled, CreatedAt: p.CreatedAt,
+		}); err != nil {
+			return err
+		}
+	}
+	row, err := q.UpsertOrgPolicyConfig(ctx, gen.UpsertOrgPolicyConfigParams{
+		OrgID:      o.ID,
+		ConfigJson: string(raw),
+		UpdatedAt:  o.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err := q.CreateOrgPolicyConfigVersion(ctx, gen.CreateOrgPolicyConfigVersionParams{
+		OrgID:      o.ID,
+		Version:    row.Version,
+		ConfigJson: string(raw),
+		ChangedBy:  sql.NullString{String: m.UserID, Valid: true},
+		ChangedAt:  o.CreatedAt,
+		Source:     orgpolicyconfigdomain.ChangeSourceSetup,
+	}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.PolicyConfigVersion = row.Version
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/orgsetup/domain"
+)
+
+// Repository persists org setups.
+type Repository interface {
+	// Create inserts the org, its owner membership, MFA settings, policy (when set) and policy config, recording the
+	// config as version 1 in its history as changed by s.Owner.UserID, all in one transaction. It sets
+	// s.PolicyConfigVersion.
+	Create(ctx context.Context, s *domain.Setup) error
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Settings is an org's own SMTP server (one row per org). Emails to the org's users are sent through it instead of the
+// platform server.
+type Settings struct {
+	OrgID    string
+	Host     string
+	Port     int
+	Username string
+	// PasswordSecret is a secrets provider reference to the SMTP password; empty when the server needs none. The
+	// password itself is never stored.
+	PasswordSecret string
+	From           string
+	UpdatedBy      string
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,204 @@
+// Package orgsmtp sends an org's emails through the org's own SMTP server. Orgs without SMTP settings use the platform
+// server (SMTP_HOST). The password of an org's server stays in the secrets provider: the settings hold a reference
+// under the org's own prefix, so an org cannot make the server send it another secret.
+package orgsmtp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/notification"
+	"zero-trust-control-plane/backend/internal/notification/email"
+	"zero-trust-control-plane/backend/internal/orgsmtp/domain"
+	"zero-trust-control-plane/backend/internal/orgsmtp/repository"
+	"zero-trust-control-plane/backend/internal/platform/netguard"
+)
+
+// DefaultPort is used when settings have no port.
+const DefaultPort = 587
+
+// TestEmailSubject is the subject of the email sent by SendTest.
+const TestEmailSubject = "SMTP settings test"
+
+var (
+	// ErrInvalidHost is returned for a host that is empty, does not resolve, or resolves to a loopback, private,
+	// link-local or otherwise non-public address.
+	ErrInvalidHost = errors.New("orgsmtp: host must resolve to public addresses")
+	// ErrInvalidPort is returned for a port outside 1-65535.
+	ErrInvalidPort = errors.New("orgsmtp: port must be between 1 and 65535")
+	// ErrInvalidFrom is returned when the from address is not an email address.
+	ErrInvalidFrom = errors.New("orgsmtp: invalid from address")
+	// ErrSecretsUnavailable is returned for a password reference when no secrets provider or org secret prefix is
+	// configured.
+	ErrSecretsUnavailable = errors.New("orgsmtp: SMTP passwords are not available on this server")
+	// ErrSecretNotAllowed is returned for a password reference outside the org's secret prefix.
+	ErrSecretNotAllowed = errors.New("orgsmtp: password secret must be under the org's secret prefix")
+	// ErrSecretUnreadable is returned when the password secret cannot be read from the secrets provider.
+	ErrSecretUnreadable = errors.New("orgsmtp: could not read password secret")
+	// ErrNotConfigured is returned by SendTest for an org without SMTP settings.
+	ErrNotConfigured = errors.New("orgsmtp: org has no SMTP settings")
+	// ErrNoPlatformSender is returned when an email falls back to the platform server and none is configured.
+	ErrNoPlatformSender = errors.New("orgsmtp: platform SMTP server not configured")
+)
+
+// SecretGetter reads secrets from the secrets provider. *secrets.Store implements it.
+type SecretGetter interface {
+	Get(ctx context.Context, ref string) (string, error)
+}
+
+// IPResolver resolves host names. *net.Resolver implements it.
+type IPResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Router sends emails on behalf of orgs through their own SMTP server, or through the platform server for orgs
+// without one. It implements notification.OrgEmailSender.
+type Router struct {
+	repo         repository.Repository
+	platform     notification.EmailSender
+	secrets      SecretGetter
+	secretPrefix string
+	resolver     IPResolver
+	// newSender builds the sender for an org's server; overridden in tests.
+	newSender func(host string, port int, username, password, from string) notification.EmailSender
+}
+
+// NewRouter returns a router backed by repo. platform is the platform SMTP sender and may be nil; emails of orgs
+// without settings then fail. secrets may be nil, and secretPrefix empty; org servers can then not have a password.
+func NewRouter(repo repository.Repository, platform notification.EmailSender, secrets SecretGetter, secretPrefix string) *Router {
+	return &Router{
+		repo:         repo,
+		platform:     platform,
+		secrets:      secrets,
+		secretPrefix: secretPrefix,
+		resolver:     net.DefaultResolver,
+		newSender: func(host string, port int, username, password, from string) notification.EmailSender {
+			return email.NewSMTPSender(host, port, username, password, from)
+		},
+	}
+}
+
+// Settings returns the org's SMTP settings, or nil if it uses the platform server.
+func (r *Router) Settings(ctx context.Context, orgID string) (*domain.Settings, error) {
+	return r.repo.Get(ctx, orgID)
+}
+
+// Update validates and stores s as its org's SMTP settings. The host is trimmed and lower-cased and a zero port
+// becomes DefaultPort. The password reference is only checked against the org's prefix; whether it can be read shows
+// when an email is sent (see SendTest).
+func (r *Router) Update(ctx context.Context, s *domain.Settings) (*domain.Settings, error) {
+	out := *s
+	out.Host = strings.ToLower(strings.TrimSpace(s.Host))
+	out.Username = strings.TrimSpace(s.Username)
+	out.PasswordSecret = strings.TrimSpace(s.PasswordSecret)
+	out.From = strings.TrimSpace(s.From)
+	if out.Port == 0 {
+		out.Port = DefaultPort
+	}
+	if out.Port < 0 || out.Port > 65535 {
+		return nil, ErrInvalidPort
+	}
+	if _, err := mail.ParseAddress(out.From); err != nil {
+		return nil, ErrInvalidFrom
+	}
+	if out.PasswordSecret != "" {
+		if r.secrets == nil || r.secretPrefix == "" {
+			return nil, ErrSecretsUnavailable
+		}
+		if !strings.HasPrefix(out.PasswordSecret, r.secretPrefix+out.OrgID+"/") {
+			return nil, ErrSecretNotAllowed
+		}
+	}
+	if err := r.checkHost(ctx, out.Host); err != nil {
+		return nil, err
+	}
+	out.UpdatedAt = time.Now().UTC()
+	if err := r.repo.Upsert(ctx, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Delete removes the org's SMTP settings, so its emails go through the platform server again. It reports false when
+// the org had none.
+func (r *Router) Delete(ctx context.Context, orgID string) (bool, error) {
+	return r.repo.Delete(ctx, orgID)
+}
+
+// SendEmail sends a platform email through the platform server.
+func (r *Router) SendEmail(to, subject, body string) error {
+	if r.platform == nil {
+		return ErrNoPlatformSender
+	}
+	return r.platform.SendEmail(to, subject, body)
+}
+
+// SendOrgEmail sends an email on behalf of orgID: through the org's SMTP server when it has settings, through the
+// platform server otherwise. A failing org server is not bypassed.
+func (r *Router) SendOrgEmail(ctx context.Context, orgID, to, subject, body string) error {
+	s, err := r.repo.Get(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return r.SendEmail(to, subject, body)
+	}
+	sender, err := r.orgSender(ctx, s)
+	if err != nil {
+		return err
+	}
+	return sender.SendEmail(to, subject, body)
+}
+
+// SendTest sends a test email to to through the org's SMTP server, so an admin can check the settings.
+func (r *Router) SendTest(ctx context.Context, orgID, to string) error {
+	s, err := r.repo.Get(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return ErrNotConfigured
+	}
+	sender, err := r.orgSender(ctx, s)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("This is a test email sent through %s:%d. Your organization's emails will be sent this way.\n", s.Host, s.Port)
+	return sender.SendEmail(to, TestEmailSubject, body)
+}
+
+// orgSender returns a sender for s, reading its password from the secrets provider. The host is checked again
+// because its DNS records may have changed since the settings were stored.
+func (r *Router) orgSender(ctx context.Context, s *domain.Settings) (notification.EmailSender, error) {
+	if err := r.checkHost(ctx, s.Host); err != nil {
+		return nil, err
+	}
+	password := ""
+	if s.PasswordSecret != "" {
+		if r.secrets == nil || r.secretPrefix == "" {
+			return nil, ErrSecretsUnavailable
+		}
+		if !strings.HasPrefix(s.PasswordSecret, r.secretPrefix+s.OrgID+"/") {
+			return nil, ErrSecretNotAllowed
+		}
+		var err error
+		if password, err = r.secrets.Get(ctx, s.PasswordSecret); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSecretUnreadable, err)
+		}
+	}
+	return r.newSender(s.Host, s.Port, s.Username, password, s.From), nil
+}
+
+// checkHost rejects hosts that do not resolve or resolve to any non-public address, so org settings cannot reach the
+// platform's internal network.
+func (r *Router) checkHost(ctx context.Context, host string) error {
+	if netguard.CheckHost(ctx, r.resolver, host) != nil {
+		return ErrInvalidHost
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package orgsmtp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"zero-trust-control-plane/backend/internal/notification"
+	"zero-trust-control-plane/backend/internal/orgsmtp/domain"
+)
+
+type memRepo map[string]*domain.Settings
+
+func (m memRepo) Get(ctx context.Context, orgID string) (*domain.Settings, error) {
+	return m[orgID], nil
+}
+
+func (m memRepo) Upsert(ctx context.Context, s *domain.Settings) error {
+	c := *s
+	m[s.OrgID] = &c
+	return nil
+}
+
+func (m memRepo) Delete(ctx context.Context, orgID string) (bool, error) {
+	_, ok := m[orgID]
+	delete(m, orgID)
+	return ok, nil
+}
+
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := f[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	var out []net.IPAddr
+	for _, ip := range ips {
+		out = append(out, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return out, nil
+}
+
+type memSecrets map[string]string
+
+func (m memSecrets) Get(ctx context.Context, ref string) (string, error) {
+	v, ok := m[ref]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return v, nil
+}
+
+type sentEmail struct {
+	server, to, subject string
+}
+
+type recordingSender struct {
+	server string
+	sent   *[]sentEmail
+}
+
+func (s recordingSender) SendEmail(to, subject, body string) error {
+	*s.sent = append(*s.sent, sentEmail{s.server, to, subject})
+	return nil
+}
+
+func newTestRouter(secrets SecretGetter, prefix string) (*Router, memRepo, *[]sentEmail, *string) {
+	repo := memRepo{}
+	sent := &[]sentEmail{}
+	password := new(string)
+	r := NewRouter(repo, recordingSender{server: "platform", sent: sent}, secrets, prefix)
+	r.resolver = fakeResolver{
+		"smtp.example.com": {"203.0.113.10"},
+		"internal.example": {"203.0.113.11", "10.0.0.5"},
+	}
+	r.newSender = func(host string, port int, username, pw, from string) notification.EmailSender {
+		*password = pw
+		return recordingSender{server: host, sent: sent}
+	}
+	return r, repo, sent, password
+}
+
+func TestRouter_FallsBackToPlatform(t *testing.T) {
+	r, _, sent, _ := newTestRouter(nil, "")
+	ctx := context.Background()
+
+	if err := notification.SendOrgEmail(ctx, r, "org-1", "a@example.com", "hi", "body"); err != nil {
+		t.Fatalf("SendOrgEmail: %v", err)
+	}
+	if len(*sent) != 1 || (*sent)[0].server != "platform" {
+		t.Errorf("sent = %+v, want one email through the platform server", *sent)
+	}
+	if err := r.SendTest(ctx, "org-1", "a@example.com"); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("SendTest without settings = %v, want ErrNotConfigured", err)
+	}
+
+	r.platform = nil
+	if err := r.SendOrgEmail(ctx, "org-1", "a@example.com", "hi", "body"); !errors.Is(err, ErrNoPlatformSender) {
+		t.Errorf("no platform sender = %v, want ErrNoPlatformSender", err)
+	}
+}
+
+func TestRouter_OrgServer(t *testing.T) {
+	secrets := memSecrets{"secret/data/orgs/org-1/smtp#password": "s3cret", "ztcp/jwt#private_key": "jwt"}
+	r, repo, sent, password := newTestRouter(secrets, "secret/data/orgs/")
+	ctx := context.Background()
+
+	s, err := r.Update(ctx, &domain.Settings{
+		OrgID:          "org-1",
+		Host:           " SMTP.example.com ",
+		Username:       "mailer",
+		PasswordSecret: "secret/data/orgs/org-1/smtp#password",
+		From:           "Acme <no-reply@acme.example>",
+		UpdatedBy:      "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if s.Host != "smtp.example.com" || s.Port != DefaultPort || s.UpdatedAt.IsZero() || repo["org-1"] == nil {
+		t.Errorf("stored = %+v", s)
+	}
+
+	if err := r.SendOrgEmail(ctx, "org-1", "a@example.com", "hi", "body"); err != nil {
+		t.Fatalf("SendOrgEmail: %v", err)
+	}
+	if err := r.SendTest(ctx, "org-1", "admin@acme.example"); err != nil {
+		t.Fatalf("SendTest: %v", err)
+	}
+	if len(*sent) != 2 || (*sent)[0].server != "smtp.example.com" || (*sent)[1].subject != TestEmailSubject {
+		t.Errorf("sent = %+v, want both through smtp.example.com", *sent)
+	}
+	if *password != "s3cret" {
+		t.Errorf("password = %q, want the org's secret", *password)
+	}
+	if err := r.SendOrgEmail(ctx, "org-2", "b@example.com", "hi", "body"); err != nil || (*sent)[2].server != "platform" {
+		t.Errorf("other org = %v via %+v, want the platform server", err, (*sent)[2])
+	}
+
+	repo["org-1"].PasswordSecret = "secret/data/orgs/org-1/missing"
+	if err := r.SendTest(ctx, "org-1", "admin@acme.example"); !errors.Is(err, ErrSecretUnreadable) {
+		t.Errorf("missing secret = %v, want ErrSecretUnreadable", err)
+	}
+
+	if ok, err := r.Delete(ctx, "org-1"); err != nil || !ok {
+		t.Errorf("Delete = %v, %v", ok, err)
+	}
+	if ok, _ := r.Delete(ctx, "org-1"); ok {
+		t.Error("second Delete = true")
+	}
+}
+
+func TestRouter_UpdateValidation(t *testing.T) {
+	secrets := memSecrets{}
+	r, repo, _, _ := newTestRouter(secrets, "secret/data/orgs/")
+	ctx := context.Background()
+	valid := domain.Settings{OrgID: "org-1", Host: "smtp.example.com", From: "no-reply@acme.example"}
+
+	cases := []struct {
+		name   string
+		modify func(s *domain.Settings)
+		want   error
+	}{
+		{"empty host", func(s *domain.Settings) { s.Host = "" }, ErrInvalidHost},
+		{"localhost", func(s *domain.Settings) { s.Host = "localhost" }, ErrInvalidHost},
+		{"loopback address", func(s *domain.Settings) { s.Host = "127.0.0.1" }, ErrInvalidHost},
+		{"metadata address", func(s *domain.Settings) { s.Host = "169.254.169.254" }, ErrInvalidHost},
+		{"resolves to a private address", func(s *domain.Settings) { s.Host = "internal.example" }, ErrInvalidHost},
+		{"does not resolve", func(s *domain.Settings) { s.Host = "nowhere.example" }, ErrInvalidHost},
+		{"port", func(s *domain.Settings) { s.Port = 70000 }, ErrInvalidPort},
+		{"from", func(s *domain.Settings) { s.From = "not an address" }, ErrInvalidFrom},
+		{"another org's secret", func(s *domain.Settings) { s.PasswordSecret = "secret/data/orgs/org-2/smtp#password" }, ErrSecretNotAllowed},
+		{"platform secret", func(s *domain.Settings) { s.PasswordSecret = "ztcp/jwt#private_key" }, ErrSecretNotAllowed},
+		{"prefix without org", func(s *domain.Settings) { s.PasswordSecret = "secret/data/orgs/org-1x/smtp" }, ErrSecretNotAllowed},
+	}
+	for _, tc := range cases {
+		s := valid
+		tc.modify(&s)
+		if _, err := r.Update(ctx, &s); !errors.Is(err, tc.want) {
+			t.Errorf("%s: Update = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+	if len(repo) != 0 {
+		t.Errorf("stored = %v, want nothing", repo)
+	}
+
+	r, _, _, _ = newTestRouter(nil, "")
+	s := valid
+	s.PasswordSecret = "secret/data/orgs/org-1/smtp#password"
+	if _, err := r.Update(ctx, &s); !errors.Is(err, ErrSecretsUnavailable) {
+		t.Errorf("password without secrets provider = %v, want ErrSecretsUnavailable", err)
+	}
+	s.PasswordSecret = ""
+	if _, err := r.Update(ctx, &s); err != nil {
+		t.Errorf("server without password: %v", err)
+	}
+}
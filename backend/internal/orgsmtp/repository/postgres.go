@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/orgsmtp/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns an org SMTP settings repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Get returns the org's SMTP settings, or nil if not found.
+func (r *PostgresRepository) Get(ctx context.Context, orgID string) (*domain.Settings, error) {
+	row, err := r.queries.GetOrgSMTPSettings(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.Settings{
+		OrgID:          row.OrgID,
+		Host:           row.Host,
+		Port:           int(row.Port),
+		Username:       row.Username,
+		PasswordSecret: row.PasswordSecret,
+		From:           row.FromAddress,
+		UpdatedBy:      row.UpdatedBy,
+		UpdatedAt:      row.UpdatedAt,
+	}, nil
+}
+
+// Upsert creates or replaces the org's SMTP settings.
+func (r *PostgresRepository) Upsert(ctx context.Context, s *domain.Settings) error {
+	return r.queries.UpsertOrgSMTPSettings(ctx, gen.UpsertOrgSMTPSettingsParams{
+		OrgID:          s.OrgID,
+		Host:           s.Host,
+		Port:           int32(s.Port),
+		Username:       s.Username,
+		PasswordSecret: s.PasswordSecret,
+		FromAddress:    s.From,
+		UpdatedBy:      s.UpdatedBy,
+		UpdatedAt:      s.UpdatedAt,
+	})
+}
+
+// Delete removes the org's SMTP settings.
+func (r *PostgresRepository) Delete(ctx context.Context, orgID string) (bool, error) {
+	n, err := r.queries.DeleteOrgSMTPSettings(ctx, orgID)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
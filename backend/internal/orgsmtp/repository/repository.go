@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/orgsmtp/domain"
+)
+
+// Repository defines access to per-org SMTP settings.
+type Repository interface {
+	// Get returns the org's SMTP settings, or nil if the org has none (it uses the platform server).
+	Get(ctx context.Context, orgID string) (*domain.Settings, error)
+	// Upsert creates or replaces the org's SMTP settings.
+	Upsert(ctx context.Context, s *domain.Settings) error
+	// Delete removes the org's SMTP settings. It reports false when the org had none.
+	Delete(ctx context.Context, orgID string) (bool, error)
+}
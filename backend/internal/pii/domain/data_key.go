@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Key scopes. Org-owned rows use the org ID as their scope.
+const (
+	// ScopePlatform is the scope of rows that belong to no single org, such as users.
+	ScopePlatform = "platform"
+	// ScopeBlindIndex is the scope of the key that hashes values for lookups (e.g. users.email_hash). It is never
+	// retired, since every hash would have to be recomputed.
+	ScopeBlindIndex = "blind_index"
+)
+
+// DataKey is a data encryption key. WrappedKey is the AES-256 key encrypted with the master key identified by
+// MasterKeyID; the plaintext key is never stored.
+type DataKey struct {
+	ID          string
+	Scope       string
+	WrappedKey  []byte
+	MasterKeyID string
+	CreatedAt   time.Time
+	// RetiredAt is set once the key is no longer used for new ciphertext; it still decrypts existing values.
+	RetiredAt *time.Time
+}
@@ -0,0 +1,420 @@
+// Package pii encrypts personally identifiable information (emails, phone numbers) at rest with envelope
+// encryption: each value is encrypted with a data key of its scope (the platform, or the org that owns the row), and
+// data keys are stored wrapped by a master key that only the servers hold (PII_MASTER_KEY, usually from the secrets
+// provider). Repositories call Keyring.Encrypt before writing and Keyring.Decrypt after reading; ReencryptJob moves
+// old ciphertext to the current keys after a rotation.
+package pii
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/pii/domain"
+	"zero-trust-control-plane/backend/internal/pii/repository"
+)
+
+// MasterKeySize is the size in bytes of master and data keys (AES-256).
+const MasterKeySize = 32
+
+// ciphertextPrefix starts every encrypted value: "pii:v1:<data key ID>:<base64 nonce and ciphertext>".
+const ciphertextPrefix = "pii:v1:"
+
+// activeKeyTTL is how long a scope's active key is cached before it is read again, so keys retired by another
+// instance stop being used for new ciphertext.
+const activeKeyTTL = 5 * time.Minute
+
+var (
+	// ErrNotConfigured is returned when an encrypted value is read without a keyring.
+	ErrNotConfigured = errors.New("pii: value is encrypted but PII encryption is not configured")
+	// ErrUnknownDataKey is returned for ciphertext whose data key does not exist.
+	ErrUnknownDataKey = errors.New("pii: unknown data key")
+	// ErrUnknownMasterKey is returned for a data key wrapped by a master key that is neither PII_MASTER_KEY nor
+	// PII_PREVIOUS_MASTER_KEY.
+	ErrUnknownMasterKey = errors.New("pii: data key is wrapped by an unknown master key")
+)
+
+type dataKey struct {
+	id   string
+	raw  []byte
+	aead cipher.AEAD
+}
+
+type activeKey struct {
+	key      *dataKey
+	loadedAt time.Time
+}
+
+// Keyring encrypts and decrypts PII column values. A nil *Keyring stores values in plaintext: Encrypt and
+// BlindIndex return their input and "", and Decrypt returns unencrypted values as they are.
+type Keyring struct {
+	keys repository.Repository
+	now  func() time.Time
+
+	mu       sync.RWMutex
+	masterID string
+	masters  map[string]cipher.AEAD
+	active   map[string]activeKey
+	dataKeys map[string]*dataKey
+}
+
+// NewKeyring returns a Keyring that stores data keys in keys, wrapped by master. previous master keys are only used
+// to unwrap data keys until ReencryptJob has rewrapped them with master.
+func NewKeyring(keys repository.Repository, master []byte, previous ...[]byte) (*Keyring, error) {
+	k := &Keyring{
+		keys:     keys,
+		now:      time.Now,
+		masters:  make(map[string]cipher.AEAD),
+		active:   make(map[string]activeKey),
+		dataKeys: make(map[string]*dataKey),
+	}
+	for _, p := range previous {
+		if _, err := k.addMaster(p); err != nil {
+			return nil, err
+		}
+	}
+	if err := k.SetMasterKey(master); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// ParseMasterKey decodes a base64 master key and checks its size.
+func ParseMasterKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("pii: master key is not base64: %w", err)
+	}
+	if len(key) != MasterKeySize {
+		return nil, fmt.Errorf("pii: master key must be %d bytes, got %d", MasterKeySize, len(key))
+	}
+	return key, nil
+}
+
+// MasterKeyID returns the ID stored with data keys wrapped by master: the hex of the first 8 bytes of its SHA-256.
+func MasterKeyID(master []byte) string {
+	sum := sha256.Sum256(master)
+	return hex.EncodeToString(sum[:8])
+}
+
+// SetMasterKey makes master the key that wraps new data keys. The previous master key stays usable for unwrapping,
+// so a rotated PII_MASTER_KEY_SECRET applies without a restart.
+func (k *Keyring) SetMasterKey(master []byte) error {
+	id, err := k.addMaster(master)
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	k.masterID = id
+	k.mu.Unlock()
+	return nil
+}
+
+func (k *Keyring) addMaster(master []byte) (string, error) {
+	if len(master) != MasterKeySize {
+		return "", fmt.Errorf("pii: master key must be %d bytes, got %d", MasterKeySize, len(master))
+	}
+	aead, err := newAEAD(master)
+	if err != nil {
+		return "", err
+	}
+	id := MasterKeyID(master)
+	k.mu.Lock()
+	k.masters[id] = aead
+	k.mu.Unlock()
+	return id, nil
+}
+
+// Encrypt encrypts plaintext for column with the scope's active data key, creating the key on first use. column is
+// authenticated with the ciphertext, so a value cannot be copied to another column. Empty values stay empty.
+func (k *Keyring) Encrypt(ctx context.Context, scope, column, plaintext string) (string, error) {
+	if k == nil || plaintext == "" {
+		return plaintext, nil
+	}
+	dk, err := k.activeKey(ctx, scope)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, dk.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := dk.aead.Seal(nonce, nonce, []byte(plaintext), []byte(column))
+	return ciphertextPrefix + dk.id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a value read from column. Values that are not encrypted (written before
+// encryption was enabled) are returned as they are.
+func (k *Keyring) Decrypt(ctx context.Context, column, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if k == nil {
+		return "", ErrNotConfigured
+	}
+	id, body, ok := strings.Cut(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("pii: malformed ciphertext in %s", column)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(body)
+	if err != nil {
+		return "", fmt.Errorf("pii: malformed ciphertext in %s: %w", column, err)
+	}
+	dk, err := k.dataKey(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	n := dk.aead.NonceSize()
+	if len(sealed) < n {
+		return "", fmt.Errorf("pii: malformed ciphertext in %s", column)
+	}
+	plaintext, err := dk.aead.Open(nil, sealed[:n], sealed[n:], []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("pii: decrypt %s: %w", column, err)
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a keyed hash of value for column, for equality lookups on an encrypted column. Returns "" for
+// an empty value or a nil Keyring.
+func (k *Keyring) BlindIndex(ctx context.Context, column, value string) (string, error) {
+	if k == nil || value == "" {
+		return "", nil
+	}
+	dk, err := k.activeKey(ctx, domain.ScopeBlindIndex)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, dk.raw)
+	mac.Write([]byte(column))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// CiphertextPrefix returns the prefix of values encrypted with the scope's active data key; values without it need
+// re-encryption.
+func (k *Keyring) CiphertextPrefix(ctx context.Context, scope string) (string, error) {
+	if k == nil {
+		return "", errors.New("pii: encryption is not configured")
+	}
+	dk, err := k.activeKey(ctx, scope)
+	if err != nil {
+		return "", err
+	}
+	return ciphertextPrefix + dk.id + ":", nil
+}
+
+// IsEncrypted reports whether value was produced by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+// Rewrap re-wraps every data key wrapped by a previous master key with the current one and returns how many were
+// rewrapped. Keys wrapped by an unknown master key are skipped; the first such error is returned.
+func (k *Keyring) Rewrap(ctx context.Context) (int, error) {
+	k.mu.RLock()
+	masterID := k.masterID
+	master := k.masters[masterID]
+	k.mu.RUnlock()
+	stale, err := k.keys.ListNotWrappedBy(ctx, masterID)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	var firstErr error
+	for _, key := range stale {
+		ok, err := k.rewrapKey(ctx, key, master, masterID)
+		if ok {
+			n++
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("pii: rewrap data key %s: %w", key.ID, err)
+		}
+	}
+	return n, firstErr
+}
+
+func (k *Keyring) rewrapKey(ctx context.Context, key *domain.DataKey, master cipher.AEAD, masterID string) (bool, error) {
+	raw, err := k.unwrap(key)
+	if err != nil {
+		return false, err
+	}
+	wrapped, err := wrap(master, key.ID, key.Scope, raw)
+	if err != nil {
+		return false, err
+	}
+	return k.keys.Rewrap(ctx, key.ID, wrapped, masterID, key.MasterKeyID)
+}
+
+// RetireOlderThan retires the active data keys older than maxAge, except the blind index key, so the next Encrypt
+// in their scope creates a new key. Returns how many were retired.
+func (k *Keyring) RetireOlderThan(ctx context.Context, maxAge time.Duration) (int, error) {
+	now := k.now().UTC()
+	n, err := k.keys.RetireCreatedBefore(ctx, now.Add(-maxAge), domain.ScopeBlindIndex, now)
+	if err != nil || n == 0 {
+		return n, err
+	}
+	k.mu.Lock()
+	for scope := range k.active {
+		if scope != domain.ScopeBlindIndex {
+			delete(k.active, scope)
+		}
+	}
+	k.mu.Unlock()
+	return n, nil
+}
+
+// activeKey returns the scope's active data key, creating it if the scope has none.
+func (k *Keyring) activeKey(ctx context.Context, scope string) (*dataKey, error) {
+	now := k.now()
+	k.mu.RLock()
+	cached, ok := k.active[scope]
+	k.mu.RUnlock()
+	if ok && now.Sub(cached.loadedAt) < activeKeyTTL {
+		return cached.key, nil
+	}
+	key, err := k.keys.GetActive(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		if key, err = k.createKey(ctx, scope); err != nil {
+			return nil, err
+		}
+	}
+	dk, err := k.cacheDataKey(key)
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	k.active[scope] = activeKey{key: dk, loadedAt: now}
+	k.mu.Unlock()
+	return dk, nil
+}
+
+// createKey stores a new random data key as the scope's active key. When another instance created one first, that
+// key is returned instead.
+func (k *Keyring) createKey(ctx context.Context, scope string) (*domain.DataKey, error) {
+	raw := make([]byte, MasterKeySize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	k.mu.RLock()
+	masterID := k.masterID
+	master := k.masters[masterID]
+	k.mu.RUnlock()
+	key := &domain.DataKey{ID: uuid.New().String(), Scope: scope, MasterKeyID: masterID, CreatedAt: k.now().UTC()}
+	wrapped, err := wrap(master, key.ID, scope, raw)
+	if err != nil {
+		return nil, err
+	}
+	key.WrappedKey = wrapped
+	created, err := k.keys.Create(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		return key, nil
+	}
+	key, err = k.keys.GetActive(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("pii: no active data key for scope %s", scope)
+	}
+	return key, nil
+}
+
+// dataKey returns the data key for id, from the cache or the repository.
+func (k *Keyring) dataKey(ctx context.Context, id string) (*dataKey, error) {
+	k.mu.RLock()
+	dk, ok := k.dataKeys[id]
+	k.mu.RUnlock()
+	if ok {
+		return dk, nil
+	}
+	key, err := k.keys.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrUnknownDataKey
+	}
+	return k.cacheDataKey(key)
+}
+
+func (k *Keyring) cacheDataKey(key *domain.DataKey) (*dataKey, error) {
+	k.mu.RLock()
+	dk, ok := k.dataKeys[key.ID]
+	k.mu.RUnlock()
+	if ok {
+		return dk, nil
+	}
+	raw, err := k.unwrap(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+	dk = &dataKey{id: key.ID, raw: raw, aead: aead}
+	k.mu.Lock()
+	k.dataKeys[key.ID] = dk
+	k.mu.Unlock()
+	return dk, nil
+}
+
+// unwrap decrypts the data key with the master key that wrapped it.
+func (k *Keyring) unwrap(key *domain.DataKey) ([]byte, error) {
+	k.mu.RLock()
+	master, ok := k.masters[key.MasterKeyID]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownMasterKey
+	}
+	n := master.NonceSize()
+	if len(key.WrappedKey) < n {
+		return nil, fmt.Errorf("pii: malformed wrapped data key %s", key.ID)
+	}
+	raw, err := master.Open(nil, key.WrappedKey[:n], key.WrappedKey[n:], wrapAAD(key.ID, key.Scope))
+	if err != nil {
+		return nil, fmt.Errorf("pii: unwrap data key %s: %w", key.ID, err)
+	}
+	return raw, nil
+}
+
+// wrap encrypts a data key with master, binding it to its ID and scope.
+func wrap(master cipher.AEAD, id, scope string, raw []byte) ([]byte, error) {
+	nonce := make([]byte, master.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return master.Seal(nonce, nonce, raw, wrapAAD(id, scope)), nil
+}
+
+func wrapAAD(id, scope string) []byte {
+	return []byte(id + "\x00" + scope)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,241 @@
+package pii
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/pii/domain"
+)
+
+type memKeys struct {
+	mu   sync.Mutex
+	keys []*domain.DataKey
+}
+
+func (m *memKeys) GetActive(ctx context.Context, scope string) (*domain.DataKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range m.keys {
+		if k.Scope == scope && k.RetiredAt == nil {
+			c := *k
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memKeys) GetByID(ctx context.Context, id string) (*domain.DataKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range m.keys {
+		if k.ID == id {
+			c := *k
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memKeys) Create(ctx context.Context, k *domain.DataKey) (bool, error) {
+	if active, _ := m.GetActive(ctx, k.Scope); active != nil {
+		return false, nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := *k
+	m.keys = append(m.keys, &c)
+	return true, nil
+}
+
+func (m *memKeys) ListNotWrappedBy(ctx context.Context, masterKeyID string) ([]*domain.DataKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*domain.DataKey
+	for _, k := range m.keys {
+		if k.MasterKeyID != masterKeyID {
+			c := *k
+			out = append(out, &c)
+		}
+	}
+	return out, nil
+}
+
+func (m *memKeys) Rewrap(ctx context.Context, id string, wrappedKey []byte, masterKeyID, oldMasterKeyID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range m.keys {
+		if k.ID == id && k.MasterKeyID == oldMasterKeyID {
+			k.WrappedKey, k.MasterKeyID = wrappedKey, masterKeyID
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memKeys) RetireCreatedBefore(ctx context.Context, before time.Time, keepScope string, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, k := range m.keys {
+		if k.RetiredAt == nil && k.CreatedAt.Before(before) && k.Scope != keepScope {
+			k.RetiredAt = &at
+			n++
+		}
+	}
+	return n, nil
+}
+
+func testMaster(b byte) []byte {
+	return bytes.Repeat([]byte{b}, MasterKeySize)
+}
+
+func newTestKeyring(t *testing.T, keys *memKeys, master []byte, previous ...[]byte) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(keys, master, previous...)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return k
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	k := newTestKeyring(t, &memKeys{}, testMaster(1))
+
+	ct, err := k.Encrypt(ctx, domain.ScopePlatform, "users.email", "alice@example.com")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !IsEncrypted(ct) || strings.Contains(ct, "alice") {
+		t.Fatalf("ciphertext = %q", ct)
+	}
+	again, _ := k.Encrypt(ctx, domain.ScopePlatform, "users.email", "alice@example.com")
+	if again == ct {
+		t.Error("encrypting twice should use a fresh nonce")
+	}
+	if pt, err := k.Decrypt(ctx, "users.email", ct); err != nil || pt != "alice@example.com" {
+		t.Errorf("Decrypt = %q, %v", pt, err)
+	}
+	if _, err := k.Decrypt(ctx, "users.phone", ct); err == nil {
+		t.Error("ciphertext from another column should not decrypt")
+	}
+	if pt, err := k.Decrypt(ctx, "users.email", "legacy@example.com"); err != nil || pt != "legacy@example.com" {
+		t.Errorf("plaintext Decrypt = %q, %v", pt, err)
+	}
+	if ct, _ := k.Encrypt(ctx, domain.ScopePlatform, "users.phone", ""); ct != "" {
+		t.Errorf("empty value encrypted to %q", ct)
+	}
+}
+
+func TestKeyring_ScopesUseSeparateKeys(t *testing.T) {
+	ctx := context.Background()
+	keys := &memKeys{}
+	k := newTestKeyring(t, keys, testMaster(1))
+	orgA, _ := k.CiphertextPrefix(ctx, "org-a")
+	orgB, _ := k.CiphertextPrefix(ctx, "org-b")
+	if orgA == orgB || len(keys.keys) != 2 {
+		t.Errorf("prefixes %q and %q, %d keys; want a key per org", orgA, orgB, len(keys.keys))
+	}
+	// A second instance uses the key the first one created.
+	other := newTestKeyring(t, keys, testMaster(1))
+	if p, _ := other.CiphertextPrefix(ctx, "org-a"); p != orgA {
+		t.Errorf("second instance prefix = %q, want %q", p, orgA)
+	}
+}
+
+func TestKeyring_Nil(t *testing.T) {
+	ctx := context.Background()
+	var k *Keyring
+	if v, err := k.Encrypt(ctx, domain.ScopePlatform, "users.email", "a@example.com"); err != nil || v != "a@example.com" {
+		t.Errorf("nil Encrypt = %q, %v", v, err)
+	}
+	if v, err := k.BlindIndex(ctx, "users.email", "a@example.com"); err != nil || v != "" {
+		t.Errorf("nil BlindIndex = %q, %v", v, err)
+	}
+	if _, err := k.Decrypt(ctx, "users.email", "pii:v1:key:AAAA"); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("nil Decrypt of ciphertext = %v, want ErrNotConfigured", err)
+	}
+}
+
+func TestKeyring_BlindIndex(t *testing.T) {
+	ctx := context.Background()
+	k := newTestKeyring(t, &memKeys{}, testMaster(1))
+	a, _ := k.BlindIndex(ctx, "users.email", "a@example.com")
+	b, _ := k.BlindIndex(ctx, "users.email", "a@example.com")
+	c, _ := k.BlindIndex(ctx, "users.email", "b@example.com")
+	if a == "" || a != b || a == c {
+		t.Errorf("blind indexes %q, %q, %q", a, b, c)
+	}
+}
+
+func TestKeyring_MasterKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	keys := &memKeys{}
+	old := newTestKeyring(t, keys, testMaster(1))
+	ct, _ := old.Encrypt(ctx, domain.ScopePlatform, "users.email", "a@example.com")
+
+	if _, err := newTestKeyring(t, keys, testMaster(2)).Decrypt(ctx, "users.email", ct); !errors.Is(err, ErrUnknownMasterKey) {
+		t.Errorf("Decrypt without the previous master key = %v, want ErrUnknownMasterKey", err)
+	}
+	rotated := newTestKeyring(t, keys, testMaster(2), testMaster(1))
+	if n, err := rotated.Rewrap(ctx); err != nil || n != 1 {
+		t.Fatalf("Rewrap = %d, %v; want 1", n, err)
+	}
+	if keys.keys[0].MasterKeyID != MasterKeyID(testMaster(2)) {
+		t.Errorf("data key wrapped by %s after rewrap", keys.keys[0].MasterKeyID)
+	}
+	if pt, err := newTestKeyring(t, keys, testMaster(2)).Decrypt(ctx, "users.email", ct); err != nil || pt != "a@example.com" {
+		t.Errorf("Decrypt with the new master key only = %q, %v", pt, err)
+	}
+
+	// SetMasterKey keeps the replaced master key for unwrapping.
+	live := newTestKeyring(t, &memKeys{}, testMaster(3))
+	ct, _ = live.Encrypt(ctx, domain.ScopePlatform, "users.email", "b@example.com")
+	if err := live.SetMasterKey(testMaster(4)); err != nil {
+		t.Fatal(err)
+	}
+	live.dataKeys = map[string]*dataKey{}
+	if pt, err := live.Decrypt(ctx, "users.email", ct); err != nil || pt != "b@example.com" {
+		t.Errorf("Decrypt after SetMasterKey = %q, %v", pt, err)
+	}
+}
+
+func TestKeyring_RetireOlderThan(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	k := newTestKeyring(t, &memKeys{}, testMaster(1))
+	k.now = func() time.Time { return now }
+	ct, _ := k.Encrypt(ctx, domain.ScopePlatform, "users.email", "a@example.com")
+	before, _ := k.CiphertextPrefix(ctx, domain.ScopePlatform)
+	index, _ := k.BlindIndex(ctx, "users.email", "a@example.com")
+
+	now = now.Add(48 * time.Hour)
+	if n, err := k.RetireOlderThan(ctx, 24*time.Hour); err != nil || n != 1 {
+		t.Fatalf("RetireOlderThan = %d, %v; want the platform key only", n, err)
+	}
+	if after, _ := k.CiphertextPrefix(ctx, domain.ScopePlatform); after == before {
+		t.Error("retired key is still active")
+	}
+	if pt, err := k.Decrypt(ctx, "users.email", ct); err != nil || pt != "a@example.com" {
+		t.Errorf("Decrypt with retired key = %q, %v", pt, err)
+	}
+	if again, _ := k.BlindIndex(ctx, "users.email", "a@example.com"); again != index {
+		t.Error("blind index changed after retiring data keys")
+	}
+}
+
+func TestParseMasterKey(t *testing.T) {
+	if _, err := ParseMasterKey("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="); err != nil {
+		t.Errorf("valid key: %v", err)
+	}
+	for _, s := range []string{"", "not base64!", "dG9vIHNob3J0"} {
+		if _, err := ParseMasterKey(s); err == nil {
+			t.Errorf("ParseMasterKey(%q) should fail", s)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package pii
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// reencryptPageSize is how many rows a Reencrypter handles per call.
+const reencryptPageSize = 200
+
+// Reencrypter re-encrypts the PII of one table with the active data keys. Implemented by the user repository.
+type Reencrypter interface {
+	// ReencryptPII re-encrypts up to limit rows after the afterID cursor whose PII is not encrypted with the active
+	// key (including plaintext rows) and returns the cursor of the next page ("" when done) and how many rows were
+	// updated.
+	ReencryptPII(ctx context.Context, afterID string, limit int) (next string, updated int, err error)
+}
+
+// ReencryptJob rotates PII keys: it rewraps data keys still wrapped by a previous master key, retires data keys older
+// than the maximum age, and re-encrypts rows so they use the active data keys (which also encrypts rows written
+// before encryption was enabled). Every step is idempotent and compare-and-swap, so several instances may run it.
+type ReencryptJob struct {
+	keyring *Keyring
+	maxAge  time.Duration
+	targets []Reencrypter
+}
+
+// NewReencryptJob returns a ReencryptJob. maxAge 0 never retires data keys.
+func NewReencryptJob(keyring *Keyring, maxAge time.Duration, targets ...Reencrypter) *ReencryptJob {
+	return &ReencryptJob{keyring: keyring, maxAge: maxAge, targets: targets}
+}
+
+// RunOnce runs one rotation pass and returns how many rows were re-encrypted. A rewrap or retire error is logged and
+// the pass continues; a re-encryption error stops the pass and is returned.
+func (j *ReencryptJob) RunOnce(ctx context.Context) (int, error) {
+	if n, err := j.keyring.Rewrap(ctx); err != nil {
+		log.Printf("pii: rewrapped %d data keys, then failed: %v", n, err)
+	} else if n > 0 {
+		log.Printf("pii: rewrapped %d data keys with the current master key", n)
+	}
+	if j.maxAge > 0 {
+		if n, err := j.keyring.RetireOlderThan(ctx, j.maxAge); err != nil {
+			log.Printf("pii: failed to retire data keys: %v", err)
+		} else if n > 0 {
+			log.Printf("pii: retired %d data keys older than %s", n, j.maxAge)
+		}
+	}
+	total := 0
+	for _, t := range j.targets {
+		cursor := ""
+		for {
+			next, n, err := t.ReencryptPII(ctx, cursor, reencryptPageSize)
+			total += n
+			if err != nil {
+				return total, err
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+	}
+	return total, nil
+}
+
+// Run calls RunOnce every interval until ctx is done.
+func (j *ReencryptJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := j.RunOnce(ctx); err != nil {
+			log.Printf("pii: re-encryption run failed after %d rows: %v", n, err)
+		} else if n > 0 {
+			log.Printf("pii: re-encrypted %d rows", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package pii
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"zero-trust-control-plane/backend/internal/pii/domain"
+)
+
+// memTable is a table of encrypted values keyed by ID that re-encrypts like the user repository.
+type memTable struct {
+	keyring *Keyring
+	rows    map[string]string
+	ids     []string
+	calls   int
+	err     error
+}
+
+func (m *memTable) ReencryptPII(ctx context.Context, afterID string, limit int) (string, int, error) {
+	m.calls++
+	if m.err != nil {
+		return "", 0, m.err
+	}
+	prefix, err := m.keyring.CiphertextPrefix(ctx, domain.ScopePlatform)
+	if err != nil {
+		return "", 0, err
+	}
+	var page []string
+	for _, id := range m.ids {
+		if id > afterID && !strings.HasPrefix(m.rows[id], prefix) && len(page) < limit {
+			page = append(page, id)
+		}
+	}
+	for _, id := range page {
+		pt, err := m.keyring.Decrypt(ctx, "t.v", m.rows[id])
+		if err != nil {
+			return "", 0, err
+		}
+		if m.rows[id], err = m.keyring.Encrypt(ctx, domain.ScopePlatform, "t.v", pt); err != nil {
+			return "", 0, err
+		}
+	}
+	if len(page) < limit {
+		return "", len(page), nil
+	}
+	return page[len(page)-1], len(page), nil
+}
+
+func TestReencryptJob_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	keys := &memKeys{}
+	old := newTestKeyring(t, keys, testMaster(1))
+	table := &memTable{rows: map[string]string{}}
+	for i := 0; i < reencryptPageSize+5; i++ {
+		id := fmt.Sprintf("u-%04d", i)
+		table.ids = append(table.ids, id)
+		table.rows[id] = fmt.Sprintf("user%d@example.com", i)
+		if i%2 == 0 {
+			table.rows[id], _ = old.Encrypt(ctx, domain.ScopePlatform, "t.v", table.rows[id])
+		}
+	}
+
+	k := newTestKeyring(t, keys, testMaster(2), testMaster(1))
+	table.keyring = k
+	n, err := NewReencryptJob(k, 0).RunOnce(ctx)
+	if err != nil || n != 0 {
+		t.Fatalf("RunOnce without targets = %d, %v", n, err)
+	}
+	if keys.keys[0].MasterKeyID != MasterKeyID(testMaster(2)) {
+		t.Error("RunOnce did not rewrap the data key")
+	}
+
+	// Plaintext rows are encrypted; rows already on the active key are left alone.
+	job := NewReencryptJob(k, 0, table)
+	if n, err = job.RunOnce(ctx); err != nil || n != (reencryptPageSize+5)/2 {
+		t.Fatalf("RunOnce = %d, %v; want %d plaintext rows", n, err, (reencryptPageSize+5)/2)
+	}
+	for id, v := range table.rows {
+		if !IsEncrypted(v) {
+			t.Fatalf("row %s still plaintext", id)
+		}
+	}
+	if n, _ = job.RunOnce(ctx); n != 0 {
+		t.Errorf("second RunOnce = %d, want 0", n)
+	}
+}
+
+func TestReencryptJob_RunOnceError(t *testing.T) {
+	k := newTestKeyring(t, &memKeys{}, testMaster(1))
+	table := &memTable{keyring: k, err: errors.New("db down")}
+	if _, err := NewReencryptJob(k, 0, table).RunOnce(context.Background()); err == nil {
+		t.Error("RunOnce should return the re-encryption error")
+	}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/pii/domain"
+)
+
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a data key repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// GetActive returns the scope's active key, or nil if it has none.
+func (r *PostgresRepository) GetActive(ctx context.Context, scope string) (*domain.DataKey, error) {
+	row, err := r.queries.GetActiveDataKey(ctx, scope)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genDataKeyToDomain(&row), nil
+}
+
+// GetByID returns the key for id, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.DataKey, error) {
+	row, err := r.queries.GetDataKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genDataKeyToDomain(&row), nil
+}
+
+// Create stores k as its scope's active key. Returns false if the scope already has an active key.
+func (r *PostgresRepository) Create(ctx context.Context, k *domain.DataKey) (bool, error) {
+	n, err := r.queries.CreateDataKey(ctx, gen.CreateDataKeyParams{
+		ID:          k.ID,
+		Scope:       k.Scope,
+		WrappedKey:  k.WrappedKey,
+		MasterKeyID: k.MasterKeyID,
+		CreatedAt:   k.CreatedAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ListNotWrappedBy returns the keys wrapped by a master key other than masterKeyID, oldest first.
+func (r *PostgresRepository) ListNotWrappedBy(ctx context.Context, masterKeyID string) ([]*domain.DataKey, error) {
+	rows, err := r.queries.ListDataKeysNotWrappedBy(ctx, masterKeyID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.DataKey, len(rows))
+	for i := range rows {
+		out[i] = genDataKeyToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+// Rewrap replaces the key's wrapped key and master key ID, only if it is still wrapped by oldMasterKeyID.
+func (r *PostgresRepository) Rewrap(ctx context.Context, id string, wrappedKey []byte, masterKeyID, oldMasterKeyID string) (bool, error) {
+	n, err := r.queries.RewrapDataKey(ctx, gen.RewrapDataKeyParams{
+		WrappedKey:     wrappedKey,
+		MasterKeyID:    masterKeyID,
+		ID:             id,
+		OldMasterKeyID: oldMasterKeyID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// RetireCreatedBefore retires the active keys created before before in every scope except keepScope.
+func (r *PostgresRepository) RetireCreatedBefore(ctx context.Context, before time.Time, keepScope string, at time.Time) (int, error) {
+	n, err := r.queries.RetireDataKeysCreatedBefore(ctx, gen.RetireDataKeysCreatedBeforeParams{
+		RetiredAt:     sql.NullTime{Time: at, Valid: true},
+		CreatedBefore: before,
+		KeepScope:     keepScope,
+	})
+	return int(n), err
+}
+
+func genDataKeyToDomain(k *gen.DataKey) *domain.DataKey {
+	d := &domain.DataKey{
+		ID:          k.ID,
+		Scope:       k.Scope,
+		WrappedKey:  k.WrappedKey,
+		MasterKeyID: k.MasterKeyID,
+		CreatedAt:   k.CreatedAt,
+	}
+	if k.RetiredAt.Valid {
+		t := k.RetiredAt.Time
+		d.RetiredAt = &t
+	}
+	return d
+}
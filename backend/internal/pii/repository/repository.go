@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/pii/domain"
+)
+
+// Repository defines persistence for PII data encryption keys.
+type Repository interface {
+	// GetActive returns the scope's active key, or nil if it has none.
+	GetActive(ctx context.Context, scope string) (*domain.DataKey, error)
+	// GetByID returns the key for id, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.DataKey, error)
+	// Create stores k as its scope's active key. Returns false if the scope already has an active key.
+	Create(ctx context.Context, k *domain.DataKey) (bool, error)
+	// ListNotWrappedBy returns the keys wrapped by a master key other than masterKeyID, oldest first.
+	ListNotWrappedBy(ctx context.Context, masterKeyID string) ([]*domain.DataKey, error)
+	// Rewrap replaces the key's wrapped key and master key ID, only if it is still wrapped by oldMasterKeyID.
+	Rewrap(ctx context.Context, id string, wrappedKey []byte, masterKeyID, oldMasterKeyID string) (bool, error)
+	// RetireCreatedBefore retires the active keys created before before in every scope except keepScope and returns
+	// how many were retired.
+	RetireCreatedBefore(ctx context.Context, before time.Time, keepScope string, at time.Time) (int, error)
+}
@@ -0,0 +1,242 @@
+// Package breaker provides circuit breakers for calls to external dependencies (SMS provider, Loki, policy store).
+// A breaker opens after a run of consecutive failures and then rejects calls immediately with ErrOpen, so callers
+// fall back instead of waiting for a dependency that is down. After the open timeout it lets one probe call through
+// (half-open): success closes it, failure opens it again. State lives in memory, so each server instance has its own.
+package breaker
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow and Do while the breaker is open, or half-open with its probe in flight.
+var ErrOpen = errors.New("breaker: circuit open")
+
+// State is a breaker's state.
+type State int
+
+const (
+	// StateClosed lets every call through.
+	StateClosed State = iota
+	// StateOpen rejects every call until the open timeout has passed.
+	StateOpen
+	// StateHalfOpen lets one probe call through; its outcome closes or reopens the breaker.
+	StateHalfOpen
+)
+
+// String returns closed, open or half_open.
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// Config configures a Breaker.
+type Config struct {
+	// FailureThreshold is how many consecutive failures open the breaker. Zero or less disables the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting a probe through.
+	OpenTimeout time.Duration
+}
+
+// Snapshot is a breaker's state and counters at one point in time.
+type Snapshot struct {
+	Name                string
+	State               State
+	ConsecutiveFailures int
+	// Opens counts transitions to open (including failed probes) since the process started.
+	Opens int64
+	// Rejected counts calls refused with ErrOpen since the process started.
+	Rejected int64
+	// Since is when the breaker entered its current state (zero while it has never left closed).
+	Since time.Time
+}
+
+// Breaker is a circuit breaker. A nil *Breaker, or one with a FailureThreshold of zero or less, lets every call
+// through. Safe for concurrent use.
+type Breaker struct {
+	name string
+	cfg  Config
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	since    time.Time
+	probing  bool
+	opens    int64
+	rejected int64
+}
+
+// New returns a closed breaker. name identifies it in logs and snapshots (e.g. "sms").
+func New(name string, cfg Config) *Breaker {
+	return &Breaker{name: name, cfg: cfg, now: time.Now}
+}
+
+// Name returns the breaker's name.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Allow reports whether a call may go ahead: nil when closed, nil for the single probe when the open timeout has
+// passed, ErrOpen otherwise. Every call that was allowed must be followed by Record with its outcome.
+func (b *Breaker) Allow() error {
+	if b.disabled() {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.since) < b.cfg.OpenTimeout {
+			b.rejected++
+			return ErrOpen
+		}
+		b.setState(StateHalfOpen)
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			b.rejected++
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Record reports the outcome of a call allowed by Allow. A nil err is a success.
+func (b *Breaker) Record(err error) {
+	if b.disabled() {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		if b.state != StateClosed {
+			b.probing = false
+			b.setState(StateClosed)
+		}
+		return
+	}
+	b.failures++
+	switch {
+	case b.state == StateHalfOpen:
+		b.probing = false
+		b.open()
+	case b.state == StateClosed && b.failures >= b.cfg.FailureThreshold:
+		b.open()
+	}
+}
+
+// Do runs fn when Allow lets it and records its error. It returns ErrOpen without running fn while the breaker is open.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+// State returns the breaker's current state.
+func (b *Breaker) State() State {
+	if b.disabled() {
+		return StateClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Snapshot returns the breaker's state and counters.
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return Snapshot{
+		Name:                b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		Opens:               b.opens,
+		Rejected:            b.rejected,
+		Since:               b.since,
+	}
+}
+
+func (b *Breaker) disabled() bool {
+	return b == nil || b.cfg.FailureThreshold <= 0
+}
+
+func (b *Breaker) open() {
+	b.opens++
+	b.setState(StateOpen)
+}
+
+// setState must be called with b.mu held.
+func (b *Breaker) setState(s State) {
+	if b.state == s {
+		return
+	}
+	log.Printf("breaker: %s %s -> %s (consecutive failures %d)", b.name, b.state, s, b.failures)
+	b.state = s
+	b.since = b.now()
+}
+
+// Registry holds the breakers of one process so their state can be reported (AdminService ListCircuitBreakers).
+// A nil *Registry holds nothing. Safe for concurrent use.
+type Registry struct {
+	cfg Config
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry returns a registry whose breakers use cfg.
+func NewRegistry(cfg Config) *Registry {
+	return &Registry{cfg: cfg, breakers: make(map[string]*Breaker)}
+}
+
+// Get returns the breaker named name, creating it on first use. It returns nil (a breaker that lets every call
+// through) when r is nil.
+func (r *Registry) Get(name string) *Breaker {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.breakers[name]
+	if !ok {
+		b = New(name, r.cfg)
+		r.breakers[name] = b
+	}
+	return b
+}
+
+// Snapshots returns a snapshot of every breaker, sorted by name.
+func (r *Registry) Snapshots() []Snapshot {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		breakers = append(breakers, b)
+	}
+	r.mu.Unlock()
+	out := make([]Snapshot, len(breakers))
+	for i, b := range breakers {
+		out[i] = b.Snapshot()
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,117 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errDown = errors.New("down")
+
+func newTestBreaker(threshold int, timeout time.Duration) (*Breaker, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := New("test", Config{FailureThreshold: threshold, OpenTimeout: timeout})
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+	fail := func() error { return errDown }
+
+	b.Do(fail)
+	b.Do(fail)
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Fatalf("success before threshold: %v", err)
+	}
+	b.Do(fail)
+	b.Do(fail)
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s after a success reset the count, want closed", b.State())
+	}
+	b.Do(fail)
+	if b.State() != StateOpen {
+		t.Fatalf("state = %s after 3 consecutive failures, want open", b.State())
+	}
+	called := false
+	if err := b.Do(func() error { called = true; return nil }); !errors.Is(err, ErrOpen) {
+		t.Errorf("Do while open = %v, want ErrOpen", err)
+	}
+	if called {
+		t.Error("Do must not run fn while open")
+	}
+	s := b.Snapshot()
+	if s.Opens != 1 || s.Rejected != 1 || s.ConsecutiveFailures != 3 {
+		t.Errorf("snapshot = %+v, want 1 open, 1 rejected, 3 failures", s)
+	}
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+	b.Do(func() error { return errDown })
+	if b.State() != StateOpen {
+		t.Fatal("breaker should open after one failure")
+	}
+	*now = now.Add(time.Minute)
+
+	if err := b.Allow(); err != nil {
+		t.Fatalf("probe after open timeout: %v", err)
+	}
+	if b.State() != StateHalfOpen {
+		t.Fatalf("state = %s, want half_open", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("second call while probing = %v, want ErrOpen", err)
+	}
+	b.Record(errDown)
+	if b.State() != StateOpen {
+		t.Fatalf("failed probe: state = %s, want open", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("reopened breaker should restart its timeout, got %v", err)
+	}
+
+	*now = now.Add(time.Minute)
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Fatalf("successful probe: %v", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("state = %s after successful probe, want closed", b.State())
+	}
+	if got := b.Snapshot().Opens; got != 2 {
+		t.Errorf("opens = %d, want 2", got)
+	}
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	var nilBreaker *Breaker
+	for _, b := range []*Breaker{nilBreaker, New("off", Config{})} {
+		for i := 0; i < 10; i++ {
+			if err := b.Do(func() error { return errDown }); !errors.Is(err, errDown) {
+				t.Fatalf("disabled breaker should run every call, got %v", err)
+			}
+		}
+		if b.State() != StateClosed {
+			t.Errorf("disabled breaker state = %s, want closed", b.State())
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(Config{FailureThreshold: 1, OpenTimeout: time.Minute})
+	if r.Get("sms") != r.Get("sms") {
+		t.Error("Get should return the same breaker for a name")
+	}
+	r.Get("loki").Do(func() error { return errDown })
+	snaps := r.Snapshots()
+	if len(snaps) != 2 || snaps[0].Name != "loki" || snaps[1].Name != "sms" {
+		t.Fatalf("snapshots = %+v, want loki and sms sorted by name", snaps)
+	}
+	if snaps[0].State != StateOpen || snaps[1].State != StateClosed {
+		t.Errorf("states = %s, %s; want open, closed", snaps[0].State, snaps[1].State)
+	}
+	var nilRegistry *Registry
+	if nilRegistry.Get("sms") != nil || nilRegistry.Snapshots() != nil {
+		t.Error("nil registry should hold no breakers")
+	}
+}
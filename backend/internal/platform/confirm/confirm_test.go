@@ -0,0 +1,40 @@
+package confirm
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner(t *testing.T) {
+	s := NewSigner("secret")
+	now := time.Now()
+	token := s.Token("org_logout", now.Add(time.Minute), "org-1", "session-1")
+
+	if !s.Valid(token, "org_logout", now, "org-1", "session-1") {
+		t.Error("token should be valid for its purpose and fields")
+	}
+	if !NewSigner("secret").Valid(token, "org_logout", now, "org-1", "session-1") {
+		t.Error("token should be valid on another instance with the same secret")
+	}
+	for name, valid := range map[string]bool{
+		"other purpose":  s.Valid(token, "user_merge", now, "org-1", "session-1"),
+		"other field":    s.Valid(token, "org_logout", now, "org-2", "session-1"),
+		"shifted fields": s.Valid(token, "org_logout", now, "org-1session-1"),
+		"expired":        s.Valid(token, "org_logout", now.Add(time.Minute), "org-1", "session-1"),
+		"other secret":   NewSigner("other").Valid(token, "org_logout", now, "org-1", "session-1"),
+		"random key":     NewSigner("").Valid(token, "org_logout", now, "org-1", "session-1"),
+		"malformed":      s.Valid("not-a-token", "org_logout", now, "org-1", "session-1"),
+	} {
+		if valid {
+			t.Errorf("%s: token should not be valid", name)
+		}
+	}
+
+	// Moving the expiry forward invalidates the MAC.
+	extended := strconv.FormatInt(now.Add(time.Hour).Unix(), 10) + token[strings.Index(token, "."):]
+	if s.Valid(extended, "org_logout", now.Add(2*time.Minute), "org-1", "session-1") {
+		t.Error("token with an extended expiry should not be valid")
+	}
+}
@@ -0,0 +1,442 @@
+package i18n
+
+// msgs returns the messages of an entry in English, German, Spanish and French.
+func msgs(en, de, es, fr string) map[string]string {
+	return map[string]string{"en": en, "de": de, "es": es, "fr": fr}
+}
+
+// entries is the built-in catalog. Reasons are part of the API: never rename one, add a new reason instead. The code
+// reasons at the end give the generic message of errors the catalog does not know.
+var entries = []Entry{
+	// Authentication and sign-in.
+	{Reason: "AUTHENTICATION_REQUIRED", Messages: msgs(
+		"missing or invalid authorization",
+		"Fehlende oder ungültige Autorisierung.",
+		"Falta la autorización o no es válida.",
+		"Autorisation manquante ou invalide.")},
+	{Reason: "ACCESS_TOKEN_EXPIRED", Messages: msgs(
+		"access token expired",
+		"Das Zugriffstoken ist abgelaufen.",
+		"El token de acceso ha caducado.",
+		"Le jeton d'accès a expiré.")},
+	{Reason: "ACCESS_TOKEN_NOT_YET_VALID", Messages: msgs(
+		"access token not yet valid; check the clock of this device",
+		"Das Zugriffstoken ist noch nicht gültig; prüfen Sie die Uhrzeit dieses Geräts.",
+		"El token de acceso aún no es válido; compruebe la hora de este dispositivo.",
+		"Le jeton d'accès n'est pas encore valide ; vérifiez l'horloge de cet appareil.")},
+	{Reason: "INVALID_CREDENTIALS", Messages: msgs(
+		"invalid credentials",
+		"Ungültige Anmeldedaten.",
+		"Credenciales no válidas.",
+		"Identifiants invalides.")},
+	{Reason: "EMAIL_ALREADY_REGISTERED", Messages: msgs(
+		"email already registered",
+		"Diese E-Mail-Adresse ist bereits registriert.",
+		"Este correo electrónico ya está registrado.",
+		"Cette adresse e-mail est déjà enregistrée.")},
+	{Reason: "INVALID_REFRESH_TOKEN", Messages: msgs(
+		"invalid or expired refresh token",
+		"Das Aktualisierungstoken ist ungültig oder abgelaufen.",
+		"El token de actualización no es válido o ha caducado.",
+		"Le jeton d'actualisation est invalide ou a expiré.")},
+	{Reason: "REFRESH_TOKEN_REUSED", Messages: msgs(
+		"refresh token reuse detected; all sessions revoked",
+		"Wiederverwendung des Aktualisierungstokens erkannt; alle Sitzungen wurden beendet.",
+		"Se detectó la reutilización del token de actualización; se revocaron todas las sesiones.",
+		"Réutilisation du jeton d'actualisation détectée ; toutes les sessions ont été révoquées.")},
+	{Reason: "INVALID_POP_PROOF", Messages: msgs(
+		"missing or invalid proof-of-possession proof",
+		"Der Besitznachweis fehlt oder ist ungültig.",
+		"Falta la prueba de posesión o no es válida.",
+		"La preuve de possession est manquante ou invalide.")},
+	{Reason: "INVALID_POP_KEY", Messages: msgs(
+		"invalid proof-of-possession key",
+		"Ungültiger Schlüssel für den Besitznachweis.",
+		"Clave de prueba de posesión no válida.",
+		"Clé de preuve de possession invalide.")},
+	{Reason: "BREACHED_PASSWORD", Messages: msgs(
+		"password has appeared in a data breach; choose a different password",
+		"Dieses Passwort ist in einem Datenleck aufgetaucht; wählen Sie ein anderes Passwort.",
+		"Esta contraseña apareció en una filtración de datos; elija otra contraseña.",
+		"Ce mot de passe figure dans une fuite de données ; choisissez-en un autre.")},
+	{Reason: "RATE_LIMITED", Messages: msgs(
+		"too many attempts; try again later",
+		"Zu viele Versuche; versuchen Sie es später erneut.",
+		"Demasiados intentos; inténtelo de nuevo más tarde.",
+		"Trop de tentatives ; réessayez plus tard.")},
+	{Reason: "IP_BLOCKED", Messages: msgs(
+		"sign-in from this network is temporarily blocked",
+		"Die Anmeldung aus diesem Netzwerk ist vorübergehend gesperrt.",
+		"El inicio de sesión desde esta red está bloqueado temporalmente.",
+		"La connexion depuis ce réseau est temporairement bloquée.")},
+	{Reason: "NETWORK_NOT_ALLOWED", Messages: msgs(
+		"sign-in from this network is not allowed by organization policy",
+		"Die Richtlinie der Organisation erlaubt keine Anmeldung aus diesem Netzwerk.",
+		"La política de la organización no permite iniciar sesión desde esta red.",
+		"La politique de l'organisation n'autorise pas la connexion depuis ce réseau.")},
+	{Reason: "OUTSIDE_ACCESS_WINDOW", Messages: msgs(
+		"sign-in is not allowed at this time by organization policy",
+		"Die Richtlinie der Organisation erlaubt zu dieser Zeit keine Anmeldung.",
+		"La política de la organización no permite iniciar sesión en este momento.",
+		"La politique de l'organisation n'autorise pas la connexion à cette heure.")},
+	{Reason: "AUDIENCE_NOT_ALLOWED", Messages: msgs(
+		"token exchange is not allowed for this audience",
+		"Der Tokenaustausch ist für diese Zielgruppe nicht erlaubt.",
+		"El intercambio de tokens no está permitido para esta audiencia.",
+		"L'échange de jetons n'est pas autorisé pour cette audience.")},
+	{Reason: "FEATURE_DISABLED", Messages: msgs(
+		"this feature is not enabled for the organization",
+		"Diese Funktion ist für die Organisation nicht aktiviert.",
+		"Esta función no está habilitada para la organización.",
+		"Cette fonctionnalité n'est pas activée pour l'organisation.")},
+	{Reason: "TRUSTED_DEVICE_REQUIRED", Messages: msgs(
+		"this action requires a session on a trusted device",
+		"Diese Aktion erfordert eine Sitzung auf einem vertrauenswürdigen Gerät.",
+		"Esta acción requiere una sesión en un dispositivo de confianza.",
+		"Cette action nécessite une session sur un appareil de confiance.")},
+
+	// MFA.
+	{Reason: "PHONE_REQUIRED_FOR_MFA", Messages: msgs(
+		"phone number required for MFA; add in profile",
+		"Für die Mehrfaktor-Authentifizierung ist eine Telefonnummer erforderlich; fügen Sie sie in Ihrem Profil hinzu.",
+		"Se requiere un número de teléfono para la autenticación multifactor; agréguelo en su perfil.",
+		"Un numéro de téléphone est requis pour l'authentification multifacteur ; ajoutez-le dans votre profil.")},
+	{Reason: "PHONE_UNCHANGED", Messages: msgs(
+		"new phone number is the same as the current one",
+		"Die neue Telefonnummer ist identisch mit der aktuellen.",
+		"El nuevo número de teléfono es igual al actual.",
+		"Le nouveau numéro de téléphone est identique à l'actuel.")},
+	{Reason: "INVALID_MFA_CHALLENGE", Messages: msgs(
+		"invalid or expired MFA challenge",
+		"Die MFA-Anfrage ist ungültig oder abgelaufen.",
+		"El desafío de MFA no es válido o ha caducado.",
+		"Le défi MFA est invalide ou a expiré.")},
+	{Reason: "INVALID_MFA_INTENT", Messages: msgs(
+		"invalid or expired MFA intent",
+		"Die MFA-Einrichtung ist ungültig oder abgelaufen.",
+		"La configuración de MFA no es válida o ha caducado.",
+		"La configuration MFA est invalide ou a expiré.")},
+	{Reason: "MFA_CHALLENGE_EXPIRED", Messages: msgs(
+		"MFA challenge expired",
+		"Die MFA-Anfrage ist abgelaufen.",
+		"El desafío de MFA ha caducado.",
+		"Le défi MFA a expiré.")},
+	{Reason: "MFA_METHOD_UNAVAILABLE", Messages: msgs(
+		"requested MFA method is not available",
+		"Die angeforderte MFA-Methode ist nicht verfügbar.",
+		"El método de MFA solicitado no está disponible.",
+		"La méthode MFA demandée n'est pas disponible.")},
+	{Reason: "RECOVERY_CODES_DISABLED", Messages: msgs(
+		"MFA recovery codes are not enabled",
+		"MFA-Wiederherstellungscodes sind nicht aktiviert.",
+		"Los códigos de recuperación de MFA no están habilitados.",
+		"Les codes de récupération MFA ne sont pas activés.")},
+	{Reason: "MFA_ATTEMPTS_EXCEEDED", Messages: msgs(
+		"too many incorrect codes; sign in again",
+		"Zu viele falsche Codes; melden Sie sich erneut an.",
+		"Demasiados códigos incorrectos; vuelva a iniciar sesión.",
+		"Trop de codes incorrects ; reconnectez-vous.")},
+	{Reason: "MFA_RESEND_COOLDOWN", Messages: msgs(
+		"a code was sent recently; wait before requesting another",
+		"Es wurde gerade ein Code gesendet; warten Sie, bevor Sie einen neuen anfordern.",
+		"Se envió un código hace poco; espere antes de solicitar otro.",
+		"Un code vient d'être envoyé ; patientez avant d'en demander un autre.")},
+	{Reason: "MFA_RESEND_LIMIT", Messages: msgs(
+		"no more codes can be sent for this MFA challenge; sign in again",
+		"Für diese MFA-Anfrage können keine weiteren Codes gesendet werden; melden Sie sich erneut an.",
+		"No se pueden enviar más códigos para este desafío de MFA; vuelva a iniciar sesión.",
+		"Aucun autre code ne peut être envoyé pour ce défi MFA ; reconnectez-vous.")},
+
+	// Login holds, device codes and magic links.
+	{Reason: "LOGIN_HOLDS_DISABLED", Messages: msgs(
+		"login holds are not enabled",
+		"Anmeldefreigaben sind nicht aktiviert.",
+		"Las aprobaciones de inicio de sesión no están habilitadas.",
+		"Les approbations de connexion ne sont pas activées.")},
+	{Reason: "INVALID_LOGIN_HOLD", Messages: msgs(
+		"invalid or expired login hold",
+		"Die Anmeldefreigabe ist ungültig oder abgelaufen.",
+		"La aprobación de inicio de sesión no es válida o ha caducado.",
+		"L'approbation de connexion est invalide ou a expiré.")},
+	{Reason: "LOGIN_HOLD_PENDING", Messages: msgs(
+		"sign-in is waiting for an administrator's approval",
+		"Die Anmeldung wartet auf die Freigabe durch einen Administrator.",
+		"El inicio de sesión está pendiente de la aprobación de un administrador.",
+		"La connexion attend l'approbation d'un administrateur.")},
+	{Reason: "LOGIN_HOLD_DENIED", Messages: msgs(
+		"sign-in was denied by an administrator",
+		"Die Anmeldung wurde von einem Administrator abgelehnt.",
+		"Un administrador rechazó el inicio de sesión.",
+		"Un administrateur a refusé la connexion.")},
+	{Reason: "LOGIN_HOLD_NOT_FOUND", Messages: msgs(
+		"login hold not found",
+		"Anmeldefreigabe nicht gefunden.",
+		"No se encontró la aprobación de inicio de sesión.",
+		"Approbation de connexion introuvable.")},
+	{Reason: "LOGIN_HOLD_NOT_PENDING", Messages: msgs(
+		"login hold is no longer pending",
+		"Über die Anmeldefreigabe wurde bereits entschieden.",
+		"La aprobación de inicio de sesión ya no está pendiente.",
+		"L'approbation de connexion n'est plus en attente.")},
+	{Reason: "LOGIN_HOLD_SELF_DECISION", Messages: msgs(
+		"cannot approve or deny your own sign-in",
+		"Sie können Ihre eigene Anmeldung nicht freigeben oder ablehnen.",
+		"No puede aprobar ni rechazar su propio inicio de sesión.",
+		"Vous ne pouvez pas approuver ou refuser votre propre connexion.")},
+	{Reason: "DEVICE_CODES_DISABLED", Messages: msgs(
+		"device code sign-in is not enabled",
+		"Die Anmeldung per Gerätecode ist nicht aktiviert.",
+		"El inicio de sesión con código de dispositivo no está habilitado.",
+		"La connexion par code d'appareil n'est pas activée.")},
+	{Reason: "INVALID_DEVICE_CODE", Messages: msgs(
+		"invalid or expired device code",
+		"Der Gerätecode ist ungültig oder abgelaufen.",
+		"El código de dispositivo no es válido o ha caducado.",
+		"Le code d'appareil est invalide ou a expiré.")},
+	{Reason: "DEVICE_CODE_PENDING", Messages: msgs(
+		"device code is waiting for approval",
+		"Der Gerätecode wartet auf Freigabe.",
+		"El código de dispositivo está pendiente de aprobación.",
+		"Le code d'appareil attend une approbation.")},
+	{Reason: "DEVICE_CODE_DENIED", Messages: msgs(
+		"device code sign-in was denied",
+		"Die Anmeldung per Gerätecode wurde abgelehnt.",
+		"Se rechazó el inicio de sesión con código de dispositivo.",
+		"La connexion par code d'appareil a été refusée.")},
+	{Reason: "DEVICE_CODE_NOT_FOUND", Messages: msgs(
+		"device code not found or expired",
+		"Gerätecode nicht gefunden oder abgelaufen.",
+		"No se encontró el código de dispositivo o ha caducado.",
+		"Code d'appareil introuvable ou expiré.")},
+	{Reason: "MAGIC_LINKS_DISABLED", Messages: msgs(
+		"magic link sign-in is not enabled for this organization",
+		"Die Anmeldung per Link ist für diese Organisation nicht aktiviert.",
+		"El inicio de sesión con enlace mágico no está habilitado para esta organización.",
+		"La connexion par lien magique n'est pas activée pour cette organisation.")},
+	{Reason: "INVALID_MAGIC_LINK", Messages: msgs(
+		"invalid, used or expired magic link",
+		"Der Anmeldelink ist ungültig, wurde bereits verwendet oder ist abgelaufen.",
+		"El enlace mágico no es válido, ya se usó o ha caducado.",
+		"Le lien magique est invalide, déjà utilisé ou expiré.")},
+
+	// Organization access.
+	{Reason: "ORG_CONTEXT_REQUIRED", Messages: msgs(
+		"org and user context required",
+		"Organisations- und Benutzerkontext erforderlich.",
+		"Se requiere el contexto de organización y usuario.",
+		"Le contexte d'organisation et d'utilisateur est requis.")},
+	{Reason: "USER_CONTEXT_REQUIRED", Messages: msgs(
+		"user context required",
+		"Benutzerkontext erforderlich.",
+		"Se requiere el contexto de usuario.",
+		"Le contexte utilisateur est requis.")},
+	{Reason: "NOT_ORG_MEMBER", Messages: msgs(
+		"not a member of this organization",
+		"Sie sind kein Mitglied dieser Organisation.",
+		"No es miembro de esta organización.",
+		"Vous n'êtes pas membre de cette organisation."),
+		Also: []string{"user is not a member of the organization"}},
+	{Reason: "ORG_ADMIN_REQUIRED", Messages: msgs(
+		"organization admin or owner required",
+		"Nur Administratoren oder Inhaber der Organisation dürfen das.",
+		"Se requiere ser administrador o propietario de la organización.",
+		"Réservé aux administrateurs ou propriétaires de l'organisation.")},
+	{Reason: "ORG_OR_GROUP_ADMIN_REQUIRED", Messages: msgs(
+		"organization admin, owner or group admin required",
+		"Nur Administratoren oder Inhaber der Organisation oder Gruppenadministratoren dürfen das.",
+		"Se requiere ser administrador o propietario de la organización, o administrador de grupo.",
+		"Réservé aux administrateurs ou propriétaires de l'organisation et aux administrateurs de groupe.")},
+	{Reason: "ORG_OWNER_REQUIRED", Messages: msgs(
+		"organization owner required",
+		"Nur Inhaber der Organisation dürfen das.",
+		"Se requiere ser propietario de la organización.",
+		"Réservé aux propriétaires de l'organisation.")},
+	{Reason: "PLATFORM_ADMIN_REQUIRED", Messages: msgs(
+		"platform admin required",
+		"Nur Plattformadministratoren dürfen das.",
+		"Se requiere ser administrador de la plataforma.",
+		"Réservé aux administrateurs de la plateforme.")},
+	{Reason: "ORG_MISMATCH", Messages: msgs(
+		"org_id does not match your organization",
+		"Die Organisation stimmt nicht mit Ihrer Organisation überein.",
+		"La organización no coincide con la suya.",
+		"L'organisation ne correspond pas à la vôtre."),
+		Also: []string{"org_id does not match context"}},
+
+	// Common lookups and arguments.
+	{Reason: "ORG_ID_REQUIRED", Messages: msgs(
+		"org_id required",
+		"Die Organisation ist erforderlich.",
+		"Se requiere la organización.",
+		"L'organisation est requise."),
+		Also: []string{"org_id is required"}},
+	{Reason: "USER_ID_REQUIRED", Messages: msgs(
+		"user_id required",
+		"Der Benutzer ist erforderlich.",
+		"Se requiere el usuario.",
+		"L'utilisateur est requis.")},
+	{Reason: "USER_NOT_FOUND", Messages: msgs(
+		"user not found",
+		"Benutzer nicht gefunden.",
+		"No se encontró el usuario.",
+		"Utilisateur introuvable.")},
+	{Reason: "ORG_NOT_FOUND", Messages: msgs(
+		"organization not found",
+		"Organisation nicht gefunden.",
+		"No se encontró la organización.",
+		"Organisation introuvable.")},
+	{Reason: "MEMBERSHIP_NOT_FOUND", Messages: msgs(
+		"membership not found",
+		"Mitgliedschaft nicht gefunden.",
+		"No se encontró la membresía.",
+		"Adhésion introuvable.")},
+	{Reason: "SESSION_NOT_FOUND", Messages: msgs(
+		"session not found",
+		"Sitzung nicht gefunden.",
+		"No se encontró la sesión.",
+		"Session introuvable.")},
+	{Reason: "DEVICE_NOT_FOUND", Messages: msgs(
+		"device not found",
+		"Gerät nicht gefunden.",
+		"No se encontró el dispositivo.",
+		"Appareil introuvable.")},
+	{Reason: "POLICY_NOT_FOUND", Messages: msgs(
+		"policy not found",
+		"Richtlinie nicht gefunden.",
+		"No se encontró la política.",
+		"Politique introuvable.")},
+	{Reason: "ORG_POLICY_CONFIG_CONFLICT", Messages: msgs(
+		"org policy config has changed; reload and retry",
+		"Die Richtlinienkonfiguration wurde zwischenzeitlich geändert; laden Sie neu und versuchen Sie es erneut.",
+		"La configuración de políticas ha cambiado; recargue e inténtelo de nuevo.",
+		"La configuration des politiques a changé ; rechargez et réessayez.")},
+	{Reason: "ORG_REGION_UNAVAILABLE", Messages: msgs(
+		"org data region is not available",
+		"Die Datenregion der Organisation ist nicht verfügbar.",
+		"La región de datos de la organización no está disponible.",
+		"La région de données de l'organisation n'est pas disponible.")},
+
+	// Platform protection. These carry their reason in an ErrorInfo because their status messages vary.
+	{Reason: "MAINTENANCE_MODE", Messages: msgs(
+		"the control plane is in maintenance mode",
+		"Die Plattform wird gerade gewartet.",
+		"La plataforma está en mantenimiento.",
+		"La plateforme est en maintenance.")},
+	{Reason: "READ_ONLY_MODE", Messages: msgs(
+		"the control plane is in read-only mode",
+		"Die Plattform ist gerade schreibgeschützt.",
+		"La plataforma está en modo de solo lectura.",
+		"La plateforme est en lecture seule.")},
+	{Reason: "ORG_QUOTA_EXCEEDED", Messages: msgs(
+		"org quota of {limit} requests per minute exceeded",
+		"Das Kontingent der Organisation von {limit} Anfragen pro Minute ist überschritten.",
+		"Se superó la cuota de la organización de {limit} solicitudes por minuto.",
+		"Le quota de l'organisation de {limit} requêtes par minute est dépassé.")},
+	{Reason: "SESSION_QUOTA_EXCEEDED", Messages: msgs(
+		"session quota of {limit} requests per minute exceeded",
+		"Das Kontingent der Sitzung von {limit} Anfragen pro Minute ist überschritten.",
+		"Se superó la cuota de la sesión de {limit} solicitudes por minuto.",
+		"Le quota de la session de {limit} requêtes par minute est dépassé.")},
+	{Reason: "UPGRADE_REQUIRED", Messages: msgs(
+		"upgrade required: {feature} needs the {plan} plan",
+		"Upgrade erforderlich: {feature} setzt den Tarif {plan} voraus.",
+		"Se requiere una mejora: {feature} necesita el plan {plan}.",
+		"Mise à niveau requise : {feature} nécessite l'offre {plan}.")},
+	{Reason: "FEATURE_NOT_LICENSED", Messages: msgs(
+		"feature not licensed: {feature}",
+		"Funktion nicht lizenziert: {feature}.",
+		"Función sin licencia: {feature}.",
+		"Fonctionnalité non couverte par la licence : {feature}.")},
+	{Reason: "LICENSE_SEAT_LIMIT_REACHED", Messages: msgs(
+		"every seat of the license is taken",
+		"Alle Plätze der Lizenz sind belegt.",
+		"Todos los puestos de la licencia están ocupados.",
+		"Toutes les places de la licence sont occupées.")},
+	{Reason: "LICENSE_EXPIRED", Messages: msgs(
+		"the license has expired",
+		"Die Lizenz ist abgelaufen.",
+		"La licencia ha caducado.",
+		"La licence a expiré.")},
+
+	// Generic messages by status code.
+	{Reason: "CANCELLED", Messages: msgs(
+		"the request was cancelled",
+		"Die Anfrage wurde abgebrochen.",
+		"La solicitud se canceló.",
+		"La requête a été annulée.")},
+	{Reason: "UNKNOWN", Messages: msgs(
+		"an unknown error occurred",
+		"Ein unbekannter Fehler ist aufgetreten.",
+		"Se produjo un error desconocido.",
+		"Une erreur inconnue s'est produite.")},
+	{Reason: "INVALID_ARGUMENT", Messages: msgs(
+		"the request is invalid",
+		"Die Anfrage ist ungültig.",
+		"La solicitud no es válida.",
+		"La requête est invalide.")},
+	{Reason: "DEADLINE_EXCEEDED", Messages: msgs(
+		"the request timed out",
+		"Die Anfrage hat zu lange gedauert.",
+		"La solicitud agotó el tiempo de espera.",
+		"La requête a expiré.")},
+	{Reason: "NOT_FOUND", Messages: msgs(
+		"not found",
+		"Nicht gefunden.",
+		"No encontrado.",
+		"Introuvable.")},
+	{Reason: "ALREADY_EXISTS", Messages: msgs(
+		"already exists",
+		"Existiert bereits.",
+		"Ya existe.",
+		"Existe déjà.")},
+	{Reason: "PERMISSION_DENIED", Messages: msgs(
+		"permission denied",
+		"Sie haben keine Berechtigung für diese Aktion.",
+		"No tiene permiso para realizar esta acción.",
+		"Vous n'avez pas l'autorisation d'effectuer cette action.")},
+	{Reason: "RESOURCE_EXHAUSTED", Messages: msgs(
+		"too many requests",
+		"Zu viele Anfragen.",
+		"Demasiadas solicitudes.",
+		"Trop de requêtes.")},
+	{Reason: "FAILED_PRECONDITION", Messages: msgs(
+		"the request cannot be performed in the current state",
+		"Die Anfrage kann im aktuellen Zustand nicht ausgeführt werden.",
+		"La solicitud no se puede realizar en el estado actual.",
+		"La requête ne peut pas être exécutée dans l'état actuel.")},
+	{Reason: "ABORTED", Messages: msgs(
+		"the request was aborted; retry",
+		"Die Anfrage wurde abgebrochen; versuchen Sie es erneut.",
+		"La solicitud se interrumpió; inténtelo de nuevo.",
+		"La requête a été interrompue ; réessayez.")},
+	{Reason: "OUT_OF_RANGE", Messages: msgs(
+		"a value is out of range",
+		"Ein Wert liegt außerhalb des gültigen Bereichs.",
+		"Un valor está fuera de rango.",
+		"Une valeur est hors limites.")},
+	{Reason: "UNIMPLEMENTED", Messages: msgs(
+		"this operation is not available",
+		"Diese Funktion ist nicht verfügbar.",
+		"Esta operación no está disponible.",
+		"Cette opération n'est pas disponible.")},
+	{Reason: "INTERNAL", Messages: msgs(
+		"an internal error occurred",
+		"Ein interner Fehler ist aufgetreten.",
+		"Se produjo un error interno.",
+		"Une erreur interne s'est produite.")},
+	{Reason: "UNAVAILABLE", Messages: msgs(
+		"the service is unavailable; try again later",
+		"Der Dienst ist nicht verfügbar; versuchen Sie es später erneut.",
+		"El servicio no está disponible; inténtelo más tarde.",
+		"Le service est indisponible ; réessayez plus tard.")},
+	{Reason: "DATA_LOSS", Messages: msgs(
+		"data loss",
+		"Datenverlust.",
+		"Pérdida de datos.",
+		"Perte de données.")},
+	{Reason: "UNAUTHENTICATED", Messages: msgs(
+		"authentication required",
+		"Anmeldung erforderlich.",
+		"Se requiere autenticación.",
+		"Authentification requise.")},
+}
@@ -0,0 +1,224 @@
+// Package i18n localizes error messages. Each error has a stable, machine-readable reason (e.g. INVALID_CREDENTIALS)
+// and a message per locale in the catalog. Handlers keep returning English status messages; the reason is taken from
+// an ErrorInfo detail when the error carries one and otherwise looked up by the English message, falling back to the
+// status code (e.g. PERMISSION_DENIED) for messages the catalog does not know.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the ErrorInfo domain of the control plane's reasons.
+const ErrorDomain = "zero-trust-control-plane"
+
+// DefaultLocale is the locale of the status messages and the fallback of negotiation.
+const DefaultLocale = "en"
+
+// Entry is a catalog entry: the messages of one reason by locale. Messages may contain {name} placeholders filled from
+// the ErrorInfo metadata. Also lists other English status messages that mean the same.
+type Entry struct {
+	Reason   string
+	Messages map[string]string
+	Also     []string
+}
+
+// Catalog holds the localized messages of error reasons.
+type Catalog struct {
+	byReason  map[string]Entry
+	byMessage map[string]string
+	locales   map[string]bool
+}
+
+// NewCatalog returns a catalog of entries. Every entry needs an English message; it is the status message the reason
+// is recognized by.
+func NewCatalog(entries []Entry) *Catalog {
+	c := &Catalog{byReason: map[string]Entry{}, byMessage: map[string]string{}, locales: map[string]bool{DefaultLocale: true}}
+	for _, e := range entries {
+		c.byReason[e.Reason] = e
+		if en := e.Messages[DefaultLocale]; en != "" && !strings.Contains(en, "{") {
+			c.byMessage[en] = e.Reason
+		}
+		for _, m := range e.Also {
+			c.byMessage[m] = e.Reason
+		}
+		for l := range e.Messages {
+			c.locales[l] = true
+		}
+	}
+	return c
+}
+
+// Default returns the built-in catalog.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+var defaultCatalog = NewCatalog(entries)
+
+// Locales returns the locales the catalog has messages for, sorted.
+func (c *Catalog) Locales() []string {
+	out := make([]string, 0, len(c.locales))
+	for l := range c.locales {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Reason returns the reason of st: its ErrorInfo reason, the reason of its English message, or the code name.
+func (c *Catalog) Reason(st *status.Status) string {
+	if info := ErrorInfo(st); info != nil && info.GetReason() != "" {
+		return info.GetReason()
+	}
+	if r, ok := c.byMessage[st.Message()]; ok {
+		return r
+	}
+	return CodeReason(st.Code())
+}
+
+// Message returns the message of reason in locale with {name} placeholders replaced from metadata. ok is false when
+// the catalog has no message of the reason in locale.
+func (c *Catalog) Message(reason, locale string, metadata map[string]string) (msg string, ok bool) {
+	e, found := c.byReason[reason]
+	if !found {
+		return "", false
+	}
+	if msg, ok = e.Messages[locale]; !ok {
+		return "", false
+	}
+	for k, v := range metadata {
+		msg = strings.ReplaceAll(msg, "{"+k+"}", v)
+	}
+	return msg, true
+}
+
+// Localize returns the message of st for a client in locale: the status message itself in DefaultLocale, else the
+// catalog message of its reason, else the generic message of its code, else the status message.
+func (c *Catalog) Localize(st *status.Status, locale string) string {
+	if locale == DefaultLocale {
+		return st.Message()
+	}
+	var metadata map[string]string
+	if info := ErrorInfo(st); info != nil {
+		metadata = info.GetMetadata()
+	}
+	if msg, ok := c.Message(c.Reason(st), locale, metadata); ok {
+		return msg
+	}
+	if msg, ok := c.Message(CodeReason(st.Code()), locale, nil); ok {
+		return msg
+	}
+	return st.Message()
+}
+
+// Negotiate returns the catalog locale that best matches the client's preferences: explicit (e.g. an x-locale
+// header) when the catalog has it or its language, else the best match of acceptLanguage (an Accept-Language value
+// such as "de-CH, fr;q=0.8"), else DefaultLocale.
+func (c *Catalog) Negotiate(explicit, acceptLanguage string) string {
+	if l, ok := c.match(explicit); ok {
+		return l
+	}
+	type pref struct {
+		tag string
+		q   float64
+	}
+	var prefs []pref
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			if v, found := strings.CutPrefix(strings.TrimSpace(f), "q="); found {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			prefs = append(prefs, pref{tag, q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+	for _, p := range prefs {
+		if p.tag == "*" {
+			return DefaultLocale
+		}
+		if l, ok := c.match(p.tag); ok {
+			return l
+		}
+	}
+	return DefaultLocale
+}
+
+// match returns the catalog locale for tag: the tag itself, or its language.
+func (c *Catalog) match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" {
+		return "", false
+	}
+	if c.locales[tag] {
+		return tag, true
+	}
+	if i := strings.Index(tag, "-"); i > 0 && c.locales[tag[:i]] {
+		return tag[:i], true
+	}
+	return "", false
+}
+
+// Error returns a status error with code and English msg that carries reason (and metadata for the localized
+// message's placeholders) as an ErrorInfo detail. Use it where the message is not fixed, so the reason does not depend
+// on the text.
+func Error(code codes.Code, reason, msg string, metadata map[string]string) *status.Status {
+	st := status.New(code, msg)
+	if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain, Metadata: metadata}); err == nil {
+		return withInfo
+	}
+	return st
+}
+
+// ErrorInfo returns the ErrorInfo detail of st, or nil.
+func ErrorInfo(st *status.Status) *errdetails.ErrorInfo {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// CodeReason returns the reason used for errors the catalog does not know: the code's canonical name, e.g.
+// PERMISSION_DENIED.
+func CodeReason(c codes.Code) string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+var codeNames = map[codes.Code]string{
+	codes.Canceled:           "CANCELLED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
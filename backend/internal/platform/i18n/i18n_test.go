@@ -0,0 +1,71 @@
+package i18n
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCatalog_Complete(t *testing.T) {
+	c := Default()
+	for _, e := range entries {
+		for _, locale := range c.Locales() {
+			if e.Messages[locale] == "" {
+				t.Errorf("%s has no %s message", e.Reason, locale)
+			}
+		}
+	}
+	for code := codes.Canceled; code <= codes.Unauthenticated; code++ {
+		if _, ok := c.Message(CodeReason(code), "de", nil); !ok {
+			t.Errorf("no generic message for %s", code)
+		}
+	}
+}
+
+func TestCatalog_Negotiate(t *testing.T) {
+	c := Default()
+	cases := []struct {
+		explicit, accept, want string
+	}{
+		{"", "", "en"},
+		{"de", "fr", "de"},
+		{"pt-BR", "fr-CA", "fr"},
+		{"", "de-CH, fr;q=0.8", "de"},
+		{"", "it, fr;q=0.5, es;q=0.9", "es"},
+		{"", "es_MX", "es"},
+		{"", "it, *;q=0.5", "en"},
+		{"", "fr;q=0, de;q=0.1", "de"},
+		{"", "ja", "en"},
+	}
+	for _, tc := range cases {
+		if got := c.Negotiate(tc.explicit, tc.accept); got != tc.want {
+			t.Errorf("Negotiate(%q, %q) = %q, want %q", tc.explicit, tc.accept, got, tc.want)
+		}
+	}
+}
+
+func TestCatalog_Localize(t *testing.T) {
+	c := Default()
+	cases := []struct {
+		st                   *status.Status
+		locale, reason, want string
+	}{
+		{status.New(codes.Unauthenticated, "invalid credentials"), "de", "INVALID_CREDENTIALS", "Ungültige Anmeldedaten."},
+		{status.New(codes.Unauthenticated, "invalid credentials"), "en", "INVALID_CREDENTIALS", "invalid credentials"},
+		{status.New(codes.PermissionDenied, "org_id does not match context"), "fr", "ORG_MISMATCH", "L'organisation ne correspond pas à la vôtre."},
+		{status.New(codes.NotFound, "widget not found"), "es", "NOT_FOUND", "No encontrado."},
+		{status.New(codes.NotFound, "widget not found"), "en", "NOT_FOUND", "widget not found"},
+		{Error(codes.ResourceExhausted, "ORG_QUOTA_EXCEEDED", "org quota of 5 requests per minute exceeded", map[string]string{"limit": "5"}), "de", "ORG_QUOTA_EXCEEDED", "Das Kontingent der Organisation von 5 Anfragen pro Minute ist überschritten."},
+		{Error(codes.PermissionDenied, "UPGRADE_REQUIRED", "upgrade required: custom_rego needs the enterprise plan", map[string]string{"feature": "custom_rego", "plan": "enterprise"}), "fr", "UPGRADE_REQUIRED", "Mise à niveau requise : custom_rego nécessite l'offre enterprise."},
+		{Error(codes.FailedPrecondition, "SOMETHING_NEW", "something new", nil), "fr", "SOMETHING_NEW", "La requête ne peut pas être exécutée dans l'état actuel."},
+	}
+	for _, tc := range cases {
+		if got := c.Reason(tc.st); got != tc.reason {
+			t.Errorf("Reason(%q) = %q, want %q", tc.st.Message(), got, tc.reason)
+		}
+		if got := c.Localize(tc.st, tc.locale); got != tc.want {
+			t.Errorf("Localize(%q, %s) = %q, want %q", tc.st.Message(), tc.locale, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package netguard keeps outbound connections to org-supplied hosts (webhooks, SMTP servers) on the public internet,
+// so an org admin cannot make the control plane reach loopback, private, link-local or cloud metadata addresses.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrNonPublicAddress is returned when a host resolves to, or a connection is about to be made to, an address
+	// that is not public.
+	ErrNonPublicAddress = errors.New("netguard: address is not public")
+	// ErrRedirect is returned by clients from NewHTTPClient for any redirect response.
+	ErrRedirect = errors.New("netguard: redirects are not followed")
+)
+
+// sharedAddressSpace is 100.64.0.0/10 (RFC 6598, carrier-grade NAT), which some clouds use for metadata services.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// PublicIP reports whether ip is a public unicast address: not loopback, private, link-local (including the
+// 169.254.169.254 metadata address), shared address space, multicast or unspecified.
+func PublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if ip[0] == 0 || sharedAddressSpace.Contains(ip) {
+			return false
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// Resolver looks up the addresses of a host; *net.Resolver implements it.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// CheckHost returns ErrNonPublicAddress when host is localhost, does not resolve, or resolves to any non-public
+// address. It suits early validation of settings; the connection itself is only safe when dialed with Dialer,
+// because DNS records can change in between.
+func CheckHost(ctx context.Context, resolver Resolver, host string) error {
+	if host == "" || host == "localhost" {
+		return ErrNonPublicAddress
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if !PublicIP(ip) {
+			return ErrNonPublicAddress
+		}
+		return nil
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return ErrNonPublicAddress
+	}
+	for _, a := range addrs {
+		if !PublicIP(a.IP) {
+			return ErrNonPublicAddress
+		}
+	}
+	return nil
+}
+
+// Dialer returns a dialer that refuses to connect to non-public addresses. The check runs on the resolved address
+// right before each connection, so a host whose DNS changes after validation (DNS rebinding) is still refused.
+func Dialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !PublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+			}
+			return nil
+		},
+	}
+}
+
+// NewHTTPClient returns an HTTP client for org-supplied URLs: connections go only to public addresses (see Dialer),
+// environment proxies are ignored so they cannot be used to reach internal hosts, and redirects fail with ErrRedirect
+// instead of being followed.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           Dialer(timeout).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return ErrRedirect
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPublicIP(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false, // cloud metadata
+		"100.100.100.200": false, // shared address space, also used for metadata
+		"0.0.0.0":         false,
+		"0.1.2.3":         false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+		"::ffff:10.0.0.1": false,
+		"224.0.0.1":       false,
+	} {
+		if got := PublicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("PublicIP(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addrs, ok := f[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	var out []net.IPAddr
+	for _, a := range addrs {
+		out = append(out, net.IPAddr{IP: net.ParseIP(a)})
+	}
+	return out, nil
+}
+
+func TestCheckHost(t *testing.T) {
+	resolver := fakeResolver{
+		"siem.example.com":   {"93.184.216.34"},
+		"mixed.example.com":  {"93.184.216.34", "10.0.0.5"},
+		"rebind.example.com": {"127.0.0.1"},
+	}
+	ctx := context.Background()
+	for host, wantOK := range map[string]bool{
+		"siem.example.com":    true,
+		"93.184.216.34":       true,
+		"mixed.example.com":   false,
+		"rebind.example.com":  false,
+		"unknown.example.com": false,
+		"localhost":           false,
+		"169.254.169.254":     false,
+		"":                    false,
+	} {
+		err := CheckHost(ctx, resolver, host)
+		if (err == nil) != wantOK || (err != nil && !errors.Is(err, ErrNonPublicAddress)) {
+			t.Errorf("CheckHost(%q) = %v, want ok=%v", host, err, wantOK)
+		}
+	}
+}
+
+func TestNewHTTPClient_RefusesLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	_, err := NewHTTPClient(time.Second).Get(srv.URL)
+	if !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("GET loopback: err = %v, want ErrNonPublicAddress", err)
+	}
+}
+
+func TestNewHTTPClient_RefusesRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer srv.Close()
+	c := NewHTTPClient(time.Second)
+	c.Transport = srv.Client().Transport // reach the loopback test server; only CheckRedirect is under test
+	_, err := c.Get(srv.URL)
+	if !errors.Is(err, ErrRedirect) {
+		t.Errorf("GET with a redirect: err = %v, want ErrRedirect", err)
+	}
+}
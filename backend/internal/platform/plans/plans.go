@@ -1,3 +1,69 @@
 package plans
 
 // plans.go defines subscription/plan limits and features.
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Plan is a subscription plan's API quotas. A quota of 0 is unlimited.
+type Plan struct {
+	Name string
+	// OrgRequestsPerMinute caps the authenticated requests of all of an org's sessions together.
+	OrgRequestsPerMinute int
+	// TokenRequestsPerMinute caps the requests made with the access tokens of one session.
+	TokenRequestsPerMinute int
+}
+
+// Default is the plan of orgs without an assigned plan unless configured otherwise.
+const Default = "standard"
+
+// DefaultPlans is the built-in plan table, in Parse syntax.
+const DefaultPlans = "free=600/120,standard=6000/600,enterprise=60000/3000"
+
+// Parse parses a plan table "name=org/token,..." where org and token are requests per minute (0 = unlimited), e.g.
+// DefaultPlans.
+func Parse(s string) (map[string]Plan, error) {
+	out := make(map[string]Plan)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, limits, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("plan %q must be name=org/token", part)
+		}
+		orgLimit, tokenLimit, ok := strings.Cut(limits, "/")
+		if !ok {
+			return nil, fmt.Errorf("plan %s: limits %q must be org/token requests per minute", name, limits)
+		}
+		org, err1 := strconv.Atoi(strings.TrimSpace(orgLimit))
+		token, err2 := strconv.Atoi(strings.TrimSpace(tokenLimit))
+		if err1 != nil || err2 != nil || org < 0 || token < 0 {
+			return nil, fmt.Errorf("plan %s: limits must be non-negative integers", name)
+		}
+		if _, dup := out[name]; dup {
+			return nil, fmt.Errorf("plan %s defined twice", name)
+		}
+		out[name] = Plan{Name: name, OrgRequestsPerMinute: org, TokenRequestsPerMinute: token}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no plans defined")
+	}
+	return out, nil
+}
+
+// Sorted returns the plans ordered by name.
+func Sorted(plans map[string]Plan) []Plan {
+	out := make([]Plan, 0, len(plans))
+	for _, p := range plans {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
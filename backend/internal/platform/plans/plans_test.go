@@ -0,0 +1,39 @@
+package plans
+
+import "testing"
+
+func TestParse_DefaultPlans(t *testing.T) {
+	got, err := Parse(DefaultPlans)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if _, ok := got[Default]; !ok {
+		t.Errorf("default plan %q missing from %v", Default, got)
+	}
+	want := Plan{Name: "free", OrgRequestsPerMinute: 600, TokenRequestsPerMinute: 120}
+	if got["free"] != want {
+		t.Errorf("free = %+v, want %+v", got["free"], want)
+	}
+	sorted := Sorted(got)
+	if len(sorted) != 3 || sorted[0].Name != "enterprise" || sorted[2].Name != "standard" {
+		t.Errorf("Sorted = %+v", sorted)
+	}
+}
+
+func TestParse_Unlimited(t *testing.T) {
+	got, err := Parse(" internal = 0/0 ")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p := got["internal"]; p.OrgRequestsPerMinute != 0 || p.TokenRequestsPerMinute != 0 {
+		t.Errorf("internal = %+v, want unlimited", p)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{"", "free", "=1/1", "free=10", "free=a/1", "free=-1/1", "free=1/1,free=2/2"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) should fail", s)
+		}
+	}
+}
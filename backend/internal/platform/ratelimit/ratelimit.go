@@ -0,0 +1,109 @@
+// Package ratelimit provides an in-process fixed-window rate limiter keyed by arbitrary strings (client IP, email).
+// Counters live in memory, so limits apply per server instance.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows up to limit events per key in each window. A limit of zero or less allows everything.
+type Limiter struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+type counter struct {
+	start time.Time
+	count int
+}
+
+// NewLimiter returns a Limiter allowing limit events per key per window.
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:    limit,
+		window:   window,
+		now:      time.Now,
+		counters: make(map[string]*counter),
+	}
+}
+
+// SetLimit changes the limit and window (e.g. on config reload). Counters in progress are kept and judged
+// against the new values. Safe for concurrent use with Allow.
+func (l *Limiter) SetLimit(limit int, window time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+	l.window = window
+}
+
+// Allow records an event for key and reports whether it is within the limit. Rejected events are not counted,
+// so a key regains its full allowance once the window that started with its first event has passed.
+func (l *Limiter) Allow(key string) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	limit := l.limit
+	l.mu.Unlock()
+	ok, _ := l.AllowWithLimit(key, limit)
+	return ok
+}
+
+// AllowWithLimit is Allow with limit in place of the limiter's own, for limits that differ per key (e.g. quotas
+// per org). A rejected event also returns the time until the key's window ends.
+func (l *Limiter) AllowWithLimit(key string, limit int) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit <= 0 || l.window <= 0 {
+		return true, 0
+	}
+	l.sweep(now)
+	c, ok := l.counters[key]
+	if !ok || now.Sub(c.start) >= l.window {
+		l.counters[key] = &counter{start: now, count: 1}
+		return true, 0
+	}
+	if c.count >= limit {
+		return false, c.start.Add(l.window).Sub(now)
+	}
+	c.count++
+	return true, 0
+}
+
+// Count returns the events allowed for key in its current window.
+func (l *Limiter) Count(key string) int {
+	if l == nil {
+		return 0
+	}
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c, ok := l.counters[key]
+	if !ok || now.Sub(c.start) >= l.window {
+		return 0
+	}
+	return c.count
+}
+
+// sweep drops expired counters at most once per window so the map does not grow with every key ever seen.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for k, c := range l.counters {
+		if now.Sub(c.start) >= l.window {
+			delete(l.counters, k)
+		}
+	}
+	l.lastSweep = now
+}
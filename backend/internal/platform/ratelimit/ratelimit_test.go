@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter_FixedWindow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	if !l.Allow("a") || !l.Allow("a") {
+		t.Fatal("first two events should be allowed")
+	}
+	if l.Allow("a") {
+		t.Error("third event in the window should be rejected")
+	}
+	if !l.Allow("b") {
+		t.Error("keys should be limited independently")
+	}
+	now = now.Add(time.Minute)
+	if !l.Allow("a") {
+		t.Error("event after the window should be allowed")
+	}
+}
+
+func TestLimiter_SweepsExpiredKeys(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(1, time.Minute)
+	l.now = func() time.Time { return now }
+	l.Allow("a")
+	l.Allow("b")
+	now = now.Add(2 * time.Minute)
+	l.Allow("c")
+	if len(l.counters) != 1 {
+		t.Errorf("counters = %d, want 1 after sweep", len(l.counters))
+	}
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	var nilLimiter *Limiter
+	for _, l := range []*Limiter{nilLimiter, NewLimiter(0, time.Minute)} {
+		for i := 0; i < 10; i++ {
+			if !l.Allow("a") {
+				t.Fatal("disabled limiter should allow every event")
+			}
+		}
+	}
+}
+
+func TestLimiter_SetLimit(t *testing.T) {
+	l := NewLimiter(1, time.Minute)
+	if !l.Allow("a") {
+		t.Fatal("first event should be allowed")
+	}
+	if l.Allow("a") {
+		t.Fatal("second event should be rejected at limit 1")
+	}
+	l.SetLimit(3, time.Minute)
+	if !l.Allow("a") {
+		t.Error("event should be allowed after the limit was raised")
+	}
+	l.SetLimit(0, time.Minute)
+	for i := 0; i < 10; i++ {
+		if !l.Allow("a") {
+			t.Fatal("limit 0 should disable the limiter")
+		}
+	}
+}
+
+func TestLimiter_AllowWithLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(0, time.Minute)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.AllowWithLimit("big", 3); !ok {
+			t.Fatalf("event %d within the per-key limit rejected", i+1)
+		}
+	}
+	if ok, _ := l.AllowWithLimit("small", 1); !ok {
+		t.Fatal("first event of another key rejected")
+	}
+	now = now.Add(20 * time.Second)
+	ok, retryAfter := l.AllowWithLimit("small", 1)
+	if ok || retryAfter != 40*time.Second {
+		t.Errorf("over the limit = %t, retry after %v; want false, 40s", ok, retryAfter)
+	}
+	if got := l.Count("big"); got != 3 {
+		t.Errorf("Count(big) = %d, want 3 (rejected events not counted)", got)
+	}
+	if ok, _ := l.AllowWithLimit("small", 0); !ok {
+		t.Error("limit 0 should allow everything")
+	}
+	now = now.Add(time.Minute)
+	if got := l.Count("big"); got != 0 {
+		t.Errorf("Count(big) after the window = %d, want 0", got)
+	}
+}
@@ -0,0 +1,71 @@
+package rbac
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// GroupAdminScoper returns the users a group admin manages. Implemented by the group repository.
+type GroupAdminScoper interface {
+	ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error)
+}
+
+// AdminScope is the set of users the caller may administer in the context org: every user for org owners and
+// admins, the members of the groups they administer for group admins.
+type AdminScope struct {
+	OrgID  string
+	UserID string
+	// users is nil for org-wide admins.
+	users map[string]bool
+}
+
+// OrgWide reports whether the caller is an org owner or admin.
+func (s *AdminScope) OrgWide() bool {
+	return s.users == nil
+}
+
+// Allows reports whether the caller may administer userID.
+func (s *AdminScope) Allows(userID string) bool {
+	return s.users == nil || s.users[userID]
+}
+
+// RequireAdminScope ensures the caller is authenticated and is an org owner or admin, or a group admin (when groups
+// is not nil), in the context org, and returns the users they may administer. Handlers must check Allows for every
+// target user. Returns a gRPC error (Unauthenticated or PermissionDenied) on failure.
+func RequireAdminScope(ctx context.Context, getter OrgMembershipGetter, groups GroupAdminScoper) (*AdminScope, error) {
+	orgID, okOrg := interceptors.GetOrgID(ctx)
+	userID, okUser := interceptors.GetUserID(ctx)
+	if !okOrg || orgID == "" || !okUser || userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "org and user context required")
+	}
+	m, err := getter.GetMembershipByUserAndOrg(ctx, userID, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to resolve membership")
+	}
+	if m == nil {
+		return nil, status.Error(codes.PermissionDenied, "not a member of this organization")
+	}
+	if m.Role == domain.RoleOwner || m.Role == domain.RoleAdmin {
+		return &AdminScope{OrgID: orgID, UserID: userID}, nil
+	}
+	if groups == nil {
+		return nil, status.Error(codes.PermissionDenied, "organization admin or owner required")
+	}
+	ids, err := groups.ListManagedUserIDs(ctx, orgID, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to resolve group admin scope")
+	}
+	if len(ids) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "organization admin, owner or group admin required")
+	}
+	users := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		users[id] = true
+	}
+	return &AdminScope{OrgID: orgID, UserID: userID, users: users}, nil
+}
@@ -0,0 +1,95 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// mockGroupScoper implements GroupAdminScoper for tests.
+type mockGroupScoper struct {
+	managed map[string][]string
+	err     error
+}
+
+func (m *mockGroupScoper) ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.managed[adminUserID+":"+orgID], nil
+}
+
+func TestRequireAdminScope_OrgAdmin(t *testing.T) {
+	getter := &mockMembershipGetter{
+		memberships: map[string]*domain.Membership{
+			"user-1:org-1": {ID: "m1", UserID: "user-1", OrgID: "org-1", Role: domain.RoleAdmin},
+		},
+	}
+	ctx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+
+	scope, err := RequireAdminScope(ctx, getter, &mockGroupScoper{})
+	if err != nil {
+		t.Fatalf("RequireAdminScope: %v", err)
+	}
+	if scope.OrgID != "org-1" || scope.UserID != "user-1" || !scope.OrgWide() || !scope.Allows("anyone") {
+		t.Errorf("scope = %+v, want org-wide for user-1 in org-1", scope)
+	}
+}
+
+func TestRequireAdminScope_GroupAdmin(t *testing.T) {
+	getter := &mockMembershipGetter{
+		memberships: map[string]*domain.Membership{
+			"user-1:org-1": {ID: "m1", UserID: "user-1", OrgID: "org-1", Role: domain.RoleMember},
+		},
+	}
+	groups := &mockGroupScoper{managed: map[string][]string{"user-1:org-1": {"user-1", "user-2"}}}
+	ctx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+
+	scope, err := RequireAdminScope(ctx, getter, groups)
+	if err != nil {
+		t.Fatalf("RequireAdminScope: %v", err)
+	}
+	if scope.OrgWide() {
+		t.Error("group admin scope should not be org-wide")
+	}
+	if !scope.Allows("user-2") || scope.Allows("user-3") {
+		t.Errorf("Allows(user-2), Allows(user-3) = %v, %v; want true, false", scope.Allows("user-2"), scope.Allows("user-3"))
+	}
+}
+
+func TestRequireAdminScope_Failure(t *testing.T) {
+	member := &mockMembershipGetter{
+		memberships: map[string]*domain.Membership{
+			"user-1:org-1": {ID: "m1", UserID: "user-1", OrgID: "org-1", Role: domain.RoleMember},
+		},
+	}
+	identity := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+	testCases := []struct {
+		name     string
+		getter   *mockMembershipGetter
+		groups   GroupAdminScoper
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"no context", member, &mockGroupScoper{}, context.Background(), codes.Unauthenticated},
+		{"not a member", &mockMembershipGetter{memberships: map[string]*domain.Membership{}}, &mockGroupScoper{}, identity, codes.PermissionDenied},
+		{"membership error", &mockMembershipGetter{err: errors.New("db down")}, &mockGroupScoper{}, identity, codes.Internal},
+		{"member without groups", member, nil, identity, codes.PermissionDenied},
+		{"member administering no group", member, &mockGroupScoper{}, identity, codes.PermissionDenied},
+		{"group lookup error", member, &mockGroupScoper{err: errors.New("db down")}, identity, codes.Internal},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := RequireAdminScope(tc.ctx, tc.getter, tc.groups)
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("code = %v, want %v (err %v)", status.Code(err), tc.wantCode, err)
+			}
+		})
+	}
+}
@@ -18,20 +18,38 @@ type OrgMembershipGetter interface {
 // RequireOrgAdmin ensures the caller is authenticated and has role owner or admin in the context org.
 // Returns (orgID, userID, nil) on success; returns a gRPC error (Unauthenticated or PermissionDenied) on failure.
 func RequireOrgAdmin(ctx context.Context, getter OrgMembershipGetter) (orgID, userID string, err error) {
+	orgID, userID, _, err = requireOrgAdmin(ctx, getter)
+	return orgID, userID, err
+}
+
+// RequireStandingOrgAdmin is RequireOrgAdmin for actions that grant roles. Callers who are admins only through a
+// just-in-time elevation are denied, so an elevation cannot be turned into a permanent role.
+func RequireStandingOrgAdmin(ctx context.Context, getter OrgMembershipGetter) (orgID, userID string, err error) {
+	orgID, userID, m, err := requireOrgAdmin(ctx, getter)
+	if err != nil {
+		return "", "", err
+	}
+	if m.ElevatedUntil != nil {
+		return "", "", status.Error(codes.PermissionDenied, "elevated admins cannot grant roles")
+	}
+	return orgID, userID, nil
+}
+
+func requireOrgAdmin(ctx context.Context, getter OrgMembershipGetter) (orgID, userID string, m *domain.Membership, err error) {
 	orgID, okOrg := interceptors.GetOrgID(ctx)
 	userID, okUser := interceptors.GetUserID(ctx)
 	if !okOrg || orgID == "" || !okUser || userID == "" {
-		return "", "", status.Error(codes.Unauthenticated, "org and user context required")
+		return "", "", nil, status.Error(codes.Unauthenticated, "org and user context required")
 	}
-	m, err := getter.GetMembershipByUserAndOrg(ctx, userID, orgID)
+	m, err = getter.GetMembershipByUserAndOrg(ctx, userID, orgID)
 	if err != nil {
-		return "", "", status.Error(codes.Internal, "failed to resolve membership")
+		return "", "", nil, status.Error(codes.Internal, "failed to resolve membership")
 	}
 	if m == nil {
-		return "", "", status.Error(codes.PermissionDenied, "not a member of this organization")
+		return "", "", nil, status.Error(codes.PermissionDenied, "not a member of this organization")
 	}
 	if m.Role != domain.RoleOwner && m.Role != domain.RoleAdmin {
-		return "", "", status.Error(codes.PermissionDenied, "organization admin or owner required")
+		return "", "", nil, status.Error(codes.PermissionDenied, "organization admin or owner required")
 	}
-	return orgID, userID, nil
+	return orgID, userID, m, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -182,3 +183,25 @@ func TestRequireOrgAdmin_Failure_EmptyUserID(t *testing.T) {
 		t.Errorf("status code = %v, want %v", st.Code(), codes.Unauthenticated)
 	}
 }
+
+func TestRequireStandingOrgAdmin(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	getter := &mockMembershipGetter{
+		memberships: map[string]*domain.Membership{
+			"admin-1:org-1":    {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: domain.RoleAdmin},
+			"elevated-1:org-1": {ID: "m2", UserID: "elevated-1", OrgID: "org-1", Role: domain.RoleAdmin, ElevatedUntil: &until},
+		},
+	}
+	ctx := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	if _, _, err := RequireStandingOrgAdmin(ctx, getter); err != nil {
+		t.Fatalf("RequireStandingOrgAdmin(admin): %v", err)
+	}
+
+	ctx = interceptors.WithIdentity(context.Background(), "elevated-1", "org-1", "session-2")
+	if _, _, err := RequireOrgAdmin(ctx, getter); err != nil {
+		t.Fatalf("RequireOrgAdmin(elevated): %v", err)
+	}
+	if _, _, err := RequireStandingOrgAdmin(ctx, getter); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RequireStandingOrgAdmin(elevated): code = %v, want PermissionDenied", status.Code(err))
+	}
+}
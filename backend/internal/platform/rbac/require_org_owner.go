@@ -0,0 +1,33 @@
+package rbac
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// RequireOrgOwner ensures the caller is authenticated and has role owner in the context org. Used for org-wide
+// actions that admins may not take (e.g. signing everyone out).
+// Returns (orgID, userID, nil) on success; returns a gRPC error (Unauthenticated or PermissionDenied) on failure.
+func RequireOrgOwner(ctx context.Context, getter OrgMembershipGetter) (orgID, userID string, err error) {
+	orgID, okOrg := interceptors.GetOrgID(ctx)
+	userID, okUser := interceptors.GetUserID(ctx)
+	if !okOrg || orgID == "" || !okUser || userID == "" {
+		return "", "", status.Error(codes.Unauthenticated, "org and user context required")
+	}
+	m, err := getter.GetMembershipByUserAndOrg(ctx, userID, orgID)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, "failed to resolve membership")
+	}
+	if m == nil {
+		return "", "", status.Error(codes.PermissionDenied, "not a member of this organization")
+	}
+	if m.Role != domain.RoleOwner {
+		return "", "", status.Error(codes.PermissionDenied, "organization owner required")
+	}
+	return orgID, userID, nil
+}
@@ -0,0 +1,83 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+func TestRequireOrgOwner_Success(t *testing.T) {
+	getter := &mockMembershipGetter{
+		memberships: map[string]*domain.Membership{
+			"user-1:org-1": {ID: "m1", UserID: "user-1", OrgID: "org-1", Role: domain.RoleOwner},
+		},
+	}
+	ctx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+
+	orgID, userID, err := RequireOrgOwner(ctx, getter)
+	if err != nil {
+		t.Fatalf("RequireOrgOwner: %v", err)
+	}
+	if orgID != "org-1" || userID != "user-1" {
+		t.Errorf("org_id, user_id = %q, %q; want %q, %q", orgID, userID, "org-1", "user-1")
+	}
+}
+
+func TestRequireOrgOwner_Failure(t *testing.T) {
+	testCases := []struct {
+		name     string
+		getter   *mockMembershipGetter
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{
+			name: "admin",
+			getter: &mockMembershipGetter{memberships: map[string]*domain.Membership{
+				"user-1:org-1": {ID: "m1", UserID: "user-1", OrgID: "org-1", Role: domain.RoleAdmin},
+			}},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "member",
+			getter: &mockMembershipGetter{memberships: map[string]*domain.Membership{
+				"user-1:org-1": {ID: "m1", UserID: "user-1", OrgID: "org-1", Role: domain.RoleMember},
+			}},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "not member",
+			getter:   &mockMembershipGetter{memberships: map[string]*domain.Membership{}},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "repository error",
+			getter:   &mockMembershipGetter{err: errors.New("database error")},
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "no context",
+			getter:   &mockMembershipGetter{},
+			ctx:      context.Background(),
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+			}
+			_, _, err := RequireOrgOwner(ctx, tc.getter)
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("RequireOrgOwner error = %v, want code %v", err, tc.wantCode)
+			}
+		})
+	}
+}
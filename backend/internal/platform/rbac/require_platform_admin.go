@@ -0,0 +1,29 @@
+package rbac
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// PlatformAdminChecker reports whether a user is a platform (system-wide) admin, e.g. *config.Watcher, which reads
+// PLATFORM_ADMIN_USER_IDS.
+type PlatformAdminChecker interface {
+	IsPlatformAdmin(userID string) bool
+}
+
+// RequirePlatformAdmin ensures the caller is authenticated and a platform admin.
+// Returns the caller's userID on success; returns a gRPC error (Unauthenticated or PermissionDenied) on failure.
+func RequirePlatformAdmin(ctx context.Context, checker PlatformAdminChecker) (userID string, err error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return "", status.Error(codes.Unauthenticated, "user context required")
+	}
+	if checker == nil || !checker.IsPlatformAdmin(userID) {
+		return "", status.Error(codes.PermissionDenied, "platform admin required")
+	}
+	return userID, nil
+}
@@ -0,0 +1,41 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type platformAdmins map[string]bool
+
+func (p platformAdmins) IsPlatformAdmin(userID string) bool { return p[userID] }
+
+func TestRequirePlatformAdmin(t *testing.T) {
+	admins := platformAdmins{"admin-1": true}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		checker PlatformAdminChecker
+		code    codes.Code
+	}{
+		{"platform admin", interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1"), admins, codes.OK},
+		{"other user", interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1"), admins, codes.PermissionDenied},
+		{"no identity", context.Background(), admins, codes.Unauthenticated},
+		{"nil checker", interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1"), nil, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, err := RequirePlatformAdmin(tt.ctx, tt.checker)
+			if status.Code(err) != tt.code {
+				t.Fatalf("code = %v, want %v", status.Code(err), tt.code)
+			}
+			if err == nil && userID != "admin-1" {
+				t.Errorf("userID = %q, want admin-1", userID)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package replay provides short-lived claim caches that let exactly one caller use a one-time value (e.g. a refresh
+// token's jti) within a window. Memory covers one server instance; Redis shares the window between instances.
+package replay
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/pkg/redisconn"
+)
+
+// Memory is an in-process claim cache. Claims expire after the window; expired ones are swept on later claims.
+type Memory struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	claims    map[string]time.Time // key → claimed at
+	lastSweep time.Time
+}
+
+// NewMemory returns a Memory keeping claims for window.
+func NewMemory(window time.Duration) *Memory {
+	return &Memory{
+		window: window,
+		now:    time.Now,
+		claims: make(map[string]time.Time),
+	}
+}
+
+// Claim records key and returns true, or returns false when key was already claimed within the window.
+func (m *Memory) Claim(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if now.Sub(m.lastSweep) >= m.window {
+		for k, at := range m.claims {
+			if now.Sub(at) >= m.window {
+				delete(m.claims, k)
+			}
+		}
+		m.lastSweep = now
+	}
+	if at, ok := m.claims[key]; ok && now.Sub(at) < m.window {
+		return false, nil
+	}
+	m.claims[key] = now
+	return true, nil
+}
+
+// Release forgets the claim on key, so it can be claimed again.
+func (m *Memory) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.claims, key)
+	return nil
+}
+
+// Redis is a claim cache in Redis, shared by every server instance using the same prefix. A claim is one
+// SET NX PX, so concurrent claims from different instances cannot both succeed.
+type Redis struct {
+	conn   *redisconn.Conn
+	prefix string
+	window time.Duration
+}
+
+// NewRedis returns a Redis claim cache in the Redis at rawURL (redis://[:password@]host:port[/db], or rediss:// for
+// TLS) keeping claims for window under prefix.
+func NewRedis(rawURL, prefix string, window time.Duration) (*Redis, error) {
+	conn, err := redisconn.New(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Redis{conn: conn, prefix: prefix, window: window}, nil
+}
+
+// Claim sets key if it is not set and returns true, or returns false when it was already claimed within the window.
+func (r *Redis) Claim(ctx context.Context, key string) (bool, error) {
+	reply, err := r.conn.Do(ctx, "SET", r.prefix+key, "1", "NX", "PX", strconv.FormatInt(r.window.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+	return reply == "OK", nil
+}
+
+// Release deletes the claim on key.
+func (r *Redis) Release(ctx context.Context, key string) error {
+	_, err := r.conn.Do(ctx, "DEL", r.prefix+key)
+	return err
+}
+
+// Close closes the connection.
+func (r *Redis) Close() error {
+	return r.conn.Close()
+}
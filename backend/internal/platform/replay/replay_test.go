@@ -0,0 +1,95 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/pkg/redisconn/redistest"
+)
+
+func TestMemory_ClaimOncePerWindow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory(time.Minute)
+	m.now = func() time.Time { return now }
+
+	if ok, _ := m.Claim(ctx, "jti-1"); !ok {
+		t.Fatal("first claim should succeed")
+	}
+	if ok, _ := m.Claim(ctx, "jti-1"); ok {
+		t.Error("second claim within the window should fail")
+	}
+	if ok, _ := m.Claim(ctx, "jti-2"); !ok {
+		t.Error("keys should be claimed independently")
+	}
+	now = now.Add(time.Minute)
+	if ok, _ := m.Claim(ctx, "jti-1"); !ok {
+		t.Error("claim after the window should succeed")
+	}
+	if len(m.claims) != 1 {
+		t.Errorf("claims = %d, want 1 after sweep", len(m.claims))
+	}
+}
+
+func TestMemory_Release(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(time.Minute)
+	m.Claim(ctx, "jti-1")
+	_ = m.Release(ctx, "jti-1")
+	if ok, _ := m.Claim(ctx, "jti-1"); !ok {
+		t.Error("claim after Release should succeed")
+	}
+}
+
+func TestMemory_ConcurrentClaims(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(time.Minute)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	won := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := m.Claim(ctx, "jti-1"); ok {
+				mu.Lock()
+				won++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if won != 1 {
+		t.Errorf("%d concurrent claims succeeded, want 1", won)
+	}
+}
+
+func TestRedis(t *testing.T) {
+	f := redistest.NewServer(t)
+	r, err := NewRedis("redis://"+f.Addr(), "replay:", 30*time.Second)
+	if err != nil {
+		t.Fatalf("NewRedis: %v", err)
+	}
+	defer r.Close()
+	ctx := context.Background()
+
+	if ok, err := r.Claim(ctx, "jti-1"); !ok || err != nil {
+		t.Fatalf("first Claim = %v, %v", ok, err)
+	}
+	if ok, err := r.Claim(ctx, "jti-1"); ok || err != nil {
+		t.Fatalf("second Claim = %v, %v; want false", ok, err)
+	}
+	if err := r.Release(ctx, "jti-1"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if f.Has("replay:jti-1") {
+		t.Error("Release should delete the key")
+	}
+	want := []string{"SET replay:jti-1 1 NX PX 30000", "SET replay:jti-1 1 NX PX 30000", "DEL replay:jti-1"}
+	if fmt.Sprint(f.Commands()) != fmt.Sprint(want) {
+		t.Errorf("commands = %q, want %q", f.Commands(), want)
+	}
+}
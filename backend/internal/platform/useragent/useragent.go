@@ -0,0 +1,64 @@
+// Package useragent derives a short human-readable client description ("Chrome on Windows") from a User-Agent string.
+package useragent
+
+import "strings"
+
+// Describe returns "<browser> on <os>" for ua, falling back to whichever part is known, or "an unknown client".
+// Detection is substring-based and ordered so that Edge/Opera are not reported as Chrome, and Chrome is not reported as Safari.
+func Describe(ua string) string {
+	browser, os := Browser(ua), OS(ua)
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return "a device running " + os
+	default:
+		return "an unknown client"
+	}
+}
+
+// Browser returns the browser or client family for ua, or "" if unrecognized.
+func Browser(ua string) string {
+	switch {
+	case ua == "":
+		return ""
+	case strings.Contains(ua, "Edg/"), strings.Contains(ua, "Edge/"):
+		return "Edge"
+	case strings.Contains(ua, "OPR/"), strings.Contains(ua, "Opera"):
+		return "Opera"
+	case strings.Contains(ua, "Firefox/"):
+		return "Firefox"
+	case strings.Contains(ua, "Chrome/"), strings.Contains(ua, "CriOS/"):
+		return "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		return "Safari"
+	case strings.HasPrefix(ua, "grpc-"):
+		return "gRPC client"
+	default:
+		return ""
+	}
+}
+
+// OS returns the operating system for ua, or "" if unrecognized.
+func OS(ua string) string {
+	switch {
+	case ua == "":
+		return ""
+	case strings.Contains(ua, "Windows"):
+		return "Windows"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		return "iOS"
+	case strings.Contains(ua, "Android"):
+		return "Android"
+	case strings.Contains(ua, "Mac OS X"), strings.Contains(ua, "Macintosh"):
+		return "macOS"
+	case strings.Contains(ua, "CrOS"):
+		return "ChromeOS"
+	case strings.Contains(ua, "Linux"):
+		return "Linux"
+	default:
+		return ""
+	}
+}
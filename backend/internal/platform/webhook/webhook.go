@@ -0,0 +1,73 @@
+// Package webhook posts signed JSON events to outbound webhooks: change request notifications, break-glass alerts,
+// login hold and honeytoken notifications, and org audit webhooks all deliver through Post.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout bounds a delivery, from dialing to reading the status line.
+const DefaultTimeout = 5 * time.Second
+
+// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the request body, keyed with the webhook secret.
+const SignatureHeader = "X-ZTCP-Signature"
+
+// NewHTTPClient returns the client webhooks use unless a caller supplies its own.
+func NewHTTPClient() *http.Client {
+	return &http.Client{Timeout: DefaultTimeout}
+}
+
+// Sign returns the SignatureHeader value for body. Receivers recompute it to verify a delivery.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Post marshals v as JSON and POSTs it to url with client, signing the body when secret is non-empty. It waits for
+// the response; any non-2xx status is an error.
+func Post(ctx context.Context, client *http.Client, url, secret string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "zero-trust-control-plane")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: responded status=%d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Go calls send in the background with a fresh context bounded by DefaultTimeout, so a slow receiver never holds up
+// the caller, and logs a failure as "<what> failed: <error>". Failures are not retried.
+func Go(what string, send func(ctx context.Context) error) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+		defer cancel()
+		if err := send(ctx); err != nil {
+			log.Printf("%s failed: %v", what, err)
+		}
+	}()
+}
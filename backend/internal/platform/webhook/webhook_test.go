@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPost_SignsBody(t *testing.T) {
+	var body []byte
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	payload := map[string]string{"type": "test.event"}
+	if err := Post(context.Background(), srv.Client(), srv.URL, "s3cret", payload); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(body, &got); err != nil || got["type"] != "test.event" {
+		t.Fatalf("body = %s (%v), want the JSON payload", body, err)
+	}
+	if header.Get(SignatureHeader) != Sign("s3cret", body) {
+		t.Errorf("%s = %q, want %q", SignatureHeader, header.Get(SignatureHeader), Sign("s3cret", body))
+	}
+	if header.Get("Content-Type") != "application/json" || header.Get("User-Agent") != "zero-trust-control-plane" {
+		t.Errorf("headers = %v", header)
+	}
+
+	if err := Post(context.Background(), srv.Client(), srv.URL, "", payload); err != nil {
+		t.Fatalf("unsigned Post: %v", err)
+	}
+	if header.Get(SignatureHeader) != "" {
+		t.Errorf("unsigned request carries %s", SignatureHeader)
+	}
+}
+
+func TestPost_Non2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	if err := Post(context.Background(), srv.Client(), srv.URL, "", struct{}{}); err == nil {
+		t.Error("Post to a 502 receiver: want error")
+	}
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256("key", "The quick brown fox jumps over the lazy dog").
+	want := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got := Sign("key", []byte("The quick brown fox jumps over the lazy dog")); got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestGo(t *testing.T) {
+	done := make(chan time.Time, 1)
+	Go("test", func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		done <- deadline
+		return errors.New("receiver down")
+	})
+	select {
+	case deadline := <-done:
+		if time.Until(deadline) > DefaultTimeout {
+			t.Errorf("deadline %v, want within %v", deadline, DefaultTimeout)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("send not called")
+	}
+}
@@ -1,7 +1,156 @@
 package domain
 
+import (
+	"errors"
+	"time"
+)
+
+// Keys of the typed platform settings in platform_settings, managed with PlatformSettingsService.
+const (
+	KeyDefaultTrustTTLDays = "default_trust_ttl_days"
+	KeyMFARequiredAlways   = "mfa_required_always"
+	KeyRegistrationMode    = "registration_mode"
+	// KeyRegistrationOpen is the bool that registration_mode replaced; a stored false still reads as
+	// RegistrationClosed until registration_mode is set.
+	KeyRegistrationOpen = "registration_open"
+)
+
+// RegistrationMode controls self-service sign-up with AuthService.Register, platform-wide or for an org.
+type RegistrationMode string
+
+const (
+	// RegistrationOpen lets anyone register.
+	RegistrationOpen RegistrationMode = "open"
+	// RegistrationInviteOnly lets only holders of an unused invitation register.
+	RegistrationInviteOnly RegistrationMode = "invite_only"
+	// RegistrationClosed refuses every registration.
+	RegistrationClosed RegistrationMode = "closed"
+)
+
+// Valid reports whether m is a known mode.
+func (m RegistrationMode) Valid() bool {
+	return m == RegistrationOpen || m == RegistrationInviteOnly || m == RegistrationClosed
+}
+
+// Stricter returns the stricter of m and other: closed over invite_only over open. Unknown or empty modes count as
+// open.
+func (m RegistrationMode) Stricter(other RegistrationMode) RegistrationMode {
+	if m.rank() >= other.rank() {
+		return m.normalized()
+	}
+	return other.normalized()
+}
+
+func (m RegistrationMode) rank() int {
+	switch m {
+	case RegistrationClosed:
+		return 2
+	case RegistrationInviteOnly:
+		return 1
+	}
+	return 0
+}
+
+func (m RegistrationMode) normalized() RegistrationMode {
+	if !m.Valid() {
+		return RegistrationOpen
+	}
+	return m
+}
+
+// MaxDefaultTrustTTLDays caps default_trust_ttl_days.
+const MaxDefaultTrustTTLDays = 365
+
+// ErrInvalidTrustTTLDays is returned by SettingsUpdate.Validate for a default trust TTL out of range.
+var ErrInvalidTrustTTLDays = errors.New("default_trust_ttl_days must be between 1 and 365")
+
+// ErrInvalidRegistrationMode is returned by SettingsUpdate.Validate for an unknown registration mode.
+var ErrInvalidRegistrationMode = errors.New("registration_mode must be open, invite_only or closed")
+
+// Settings holds the typed platform settings. Keys missing from platform_settings take their defaults:
+// DefaultTrustTTLDays from configuration (DEFAULT_TRUST_TTL_DAYS), MFARequiredAlways false and RegistrationMode open.
+type Settings struct {
+	DefaultTrustTTLDays int
+	MFARequiredAlways   bool
+	// RegistrationMode controls self-service sign-up with AuthService.Register platform-wide; an org can only
+	// tighten it for its verified domains.
+	RegistrationMode RegistrationMode
+}
+
+// DefaultSettings returns the settings used for keys that are not stored.
+func DefaultSettings(defaultTrustTTLDays int) Settings {
+	return Settings{DefaultTrustTTLDays: defaultTrustTTLDays, RegistrationMode: RegistrationOpen}
+}
+
+// SettingsUpdate names the settings to change; nil fields are left as stored.
+type SettingsUpdate struct {
+	DefaultTrustTTLDays *int
+	MFARequiredAlways   *bool
+	RegistrationMode    *RegistrationMode
+}
+
+// Empty reports whether u changes nothing.
+func (u SettingsUpdate) Empty() bool {
+	return u.DefaultTrustTTLDays == nil && u.MFARequiredAlways == nil && u.RegistrationMode == nil
+}
+
+// Validate checks the new values before they are stored.
+func (u SettingsUpdate) Validate() error {
+	if d := u.DefaultTrustTTLDays; d != nil && (*d < 1 || *d > MaxDefaultTrustTTLDays) {
+		return ErrInvalidTrustTTLDays
+	}
+	if m := u.RegistrationMode; m != nil && !m.Valid() {
+		return ErrInvalidRegistrationMode
+	}
+	return nil
+}
+
+// Apply returns s with the fields set in u replaced.
+func (u SettingsUpdate) Apply(s Settings) Settings {
+	if u.DefaultTrustTTLDays != nil {
+		s.DefaultTrustTTLDays = *u.DefaultTrustTTLDays
+	}
+	if u.MFARequiredAlways != nil {
+		s.MFARequiredAlways = *u.MFARequiredAlways
+	}
+	if u.RegistrationMode != nil {
+		s.RegistrationMode = *u.RegistrationMode
+	}
+	return s
+}
+
 // PlatformDeviceTrustSettings holds platform-level MFA/device trust settings (from platform_settings table or defaults).
 type PlatformDeviceTrustSettings struct {
 	MFARequiredAlways   bool
 	DefaultTrustTTLDays int
 }
+
+// MaintenanceMode is the platform-wide availability of RPCs.
+type MaintenanceMode string
+
+const (
+	// MaintenanceOff serves all RPCs.
+	MaintenanceOff MaintenanceMode = "off"
+	// MaintenanceReadOnly rejects mutating RPCs; reads and token refreshes continue.
+	MaintenanceReadOnly MaintenanceMode = "read_only"
+	// MaintenanceFull also rejects reads; only health checks, token refreshes and the maintenance RPCs are served.
+	MaintenanceFull MaintenanceMode = "maintenance"
+)
+
+// DefaultMaintenanceRetryAfter is the retry hint for rejected RPCs when none is set.
+const DefaultMaintenanceRetryAfter = 5 * time.Minute
+
+// MaintenanceState is the maintenance mode stored in platform_settings (key maintenance_mode). The zero value is
+// normal operation.
+type MaintenanceState struct {
+	Mode       MaintenanceMode `json:"mode"`
+	Message    string          `json:"message,omitempty"`
+	RetryAfter time.Duration   `json:"retry_after"`
+	UpdatedBy  string          `json:"updated_by,omitempty"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// Active reports whether the state rejects any RPCs.
+func (s MaintenanceState) Active() bool {
+	return s.Mode == MaintenanceReadOnly || s.Mode == MaintenanceFull
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	platformsettingsv1 "zero-trust-control-plane/backend/api/generated/platformsettings/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/platformsettings/domain"
+	"zero-trust-control-plane/backend/internal/platformsettings/repository"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// Server implements PlatformSettingsService (proto server).
+// Proto: platformsettings/platformsettings.proto → internal/platformsettings/handler.
+type Server struct {
+	platformsettingsv1.UnimplementedPlatformSettingsServiceServer
+	repo        repository.Repository
+	config      *config.Watcher
+	auditLogger audit.AuditLogger
+}
+
+// NewServer returns a new PlatformSettings gRPC server. If repo or configWatcher is nil, all RPCs return
+// Unimplemented. Pass the settingscache repository as repo so changes reach the other instances at once.
+// auditLogger may be nil. Platform admins are the users listed in PLATFORM_ADMIN_USER_IDS of the current
+// configuration.
+func NewServer(repo repository.Repository, configWatcher *config.Watcher, auditLogger audit.AuditLogger) *Server {
+	return &Server{repo: repo, config: configWatcher, auditLogger: auditLogger}
+}
+
+// GetPlatformSettings returns the platform settings, defaults filled in. Platform admins only.
+func (s *Server) GetPlatformSettings(ctx context.Context, req *platformsettingsv1.GetPlatformSettingsRequest) (*platformsettingsv1.GetPlatformSettingsResponse, error) {
+	if s.repo == nil || s.config == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetPlatformSettings not implemented")
+	}
+	if _, err := rbac.RequirePlatformAdmin(ctx, s.config); err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetSettings(ctx, s.config.Current().DefaultTrustTTLDays)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load platform settings")
+	}
+	return &platformsettingsv1.GetPlatformSettingsResponse{Settings: settingsToProto(settings)}, nil
+}
+
+// SetPlatformSettings stores the settings set in the request and returns the result. Platform admins only; each
+// changed setting is audited as platform_setting_changed.
+func (s *Server) SetPlatformSettings(ctx context.Context, req *platformsettingsv1.SetPlatformSettingsRequest) (*platformsettingsv1.SetPlatformSettingsResponse, error) {
+	if s.repo == nil || s.config == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetPlatformSettings not implemented")
+	}
+	userID, err := rbac.RequirePlatformAdmin(ctx, s.config)
+	if err != nil {
+		return nil, err
+	}
+	var u domain.SettingsUpdate
+	if req.DefaultTrustTtlDays != nil {
+		days := int(req.GetDefaultTrustTtlDays())
+		u.DefaultTrustTTLDays = &days
+	}
+	if req.MfaRequiredAlways != nil {
+		u.MFARequiredAlways = req.MfaRequiredAlways
+	}
+	if req.RegistrationMode != nil {
+		mode := registrationModeToDomain(req.GetRegistrationMode())
+		u.RegistrationMode = &mode
+	}
+	if u.Empty() {
+		return nil, status.Error(codes.InvalidArgument, "at least one setting is required")
+	}
+	if err := u.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	defaultTTL := s.config.Current().DefaultTrustTTLDays
+	before, err := s.repo.GetSettings(ctx, defaultTTL)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load platform settings")
+	}
+	if err := s.repo.SetSettings(ctx, u); err != nil {
+		return nil, status.Error(codes.Internal, "failed to store platform settings")
+	}
+	after := u.Apply(*before)
+	s.logChanges(ctx, userID, *before, after)
+	return &platformsettingsv1.SetPlatformSettingsResponse{Settings: settingsToProto(&after)}, nil
+}
+
+// logChanges audits each setting that differs between before and after under the caller's org. Settings are
+// platform-wide, so metadata names the setting.
+func (s *Server) logChanges(ctx context.Context, userID string, before, after domain.Settings) {
+	if s.auditLogger == nil {
+		return
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	changes := []struct {
+		key      string
+		old, new string
+	}{
+		{domain.KeyDefaultTrustTTLDays, strconv.Itoa(before.DefaultTrustTTLDays), strconv.Itoa(after.DefaultTrustTTLDays)},
+		{domain.KeyMFARequiredAlways, strconv.FormatBool(before.MFARequiredAlways), strconv.FormatBool(after.MFARequiredAlways)},
+		{domain.KeyRegistrationMode, string(before.RegistrationMode), string(after.RegistrationMode)},
+	}
+	for _, c := range changes {
+		if c.old == c.new {
+			continue
+		}
+		meta, _ := json.Marshal(map[string]string{"key": c.key, "old_value": c.old, "new_value": c.new})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "platform_setting_changed", "platform_settings", string(meta))
+	}
+}
+
+func settingsToProto(s *domain.Settings) *platformsettingsv1.PlatformSettings {
+	return &platformsettingsv1.PlatformSettings{
+		DefaultTrustTtlDays: int32(s.DefaultTrustTTLDays),
+		MfaRequiredAlways:   s.MFARequiredAlways,
+		RegistrationMode:    registrationModeToProto(s.RegistrationMode),
+	}
+}
+
+func registrationModeToProto(m domain.RegistrationMode) platformsettingsv1.RegistrationMode {
+	switch m {
+	case domain.RegistrationOpen:
+		return platformsettingsv1.RegistrationMode_REGISTRATION_MODE_OPEN
+	case domain.RegistrationInviteOnly:
+		return platformsettingsv1.RegistrationMode_REGISTRATION_MODE_INVITE_ONLY
+	case domain.RegistrationClosed:
+		return platformsettingsv1.RegistrationMode_REGISTRATION_MODE_CLOSED
+	default:
+		return platformsettingsv1.RegistrationMode_REGISTRATION_MODE_UNSPECIFIED
+	}
+}
+
+// registrationModeToDomain maps UNSPECIFIED to "", which SettingsUpdate.Validate rejects.
+func registrationModeToDomain(e platformsettingsv1.RegistrationMode) domain.RegistrationMode {
+	switch e {
+	case platformsettingsv1.RegistrationMode_REGISTRATION_MODE_OPEN:
+		return domain.RegistrationOpen
+	case platformsettingsv1.RegistrationMode_REGISTRATION_MODE_INVITE_ONLY:
+		return domain.RegistrationInviteOnly
+	case platformsettingsv1.RegistrationMode_REGISTRATION_MODE_CLOSED:
+		return domain.RegistrationClosed
+	default:
+		return ""
+	}
+}
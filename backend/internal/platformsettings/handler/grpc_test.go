@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	platformsettingsv1 "zero-trust-control-plane/backend/api/generated/platformsettings/v1"
+	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/platformsettings/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// memSettingsRepo stores the typed settings; unset fields take their defaults as in the Postgres repository.
+type memSettingsRepo struct {
+	stored domain.SettingsUpdate
+	sets   int
+}
+
+func (m *memSettingsRepo) GetDeviceTrustSettings(ctx context.Context, defaultTrustTTLDays int) (*domain.PlatformDeviceTrustSettings, error) {
+	s, _ := m.GetSettings(ctx, defaultTrustTTLDays)
+	return &domain.PlatformDeviceTrustSettings{MFARequiredAlways: s.MFARequiredAlways, DefaultTrustTTLDays: s.DefaultTrustTTLDays}, nil
+}
+
+func (m *memSettingsRepo) GetSettings(ctx context.Context, defaultTrustTTLDays int) (*domain.Settings, error) {
+	s := m.stored.Apply(domain.DefaultSettings(defaultTrustTTLDays))
+	return &s, nil
+}
+
+func (m *memSettingsRepo) SetSettings(ctx context.Context, u domain.SettingsUpdate) error {
+	m.sets++
+	if u.DefaultTrustTTLDays != nil {
+		m.stored.DefaultTrustTTLDays = u.DefaultTrustTTLDays
+	}
+	if u.MFARequiredAlways != nil {
+		m.stored.MFARequiredAlways = u.MFARequiredAlways
+	}
+	if u.RegistrationMode != nil {
+		m.stored.RegistrationMode = u.RegistrationMode
+	}
+	return nil
+}
+
+func (m *memSettingsRepo) GetMaintenanceState(ctx context.Context) (*domain.MaintenanceState, error) {
+	return &domain.MaintenanceState{}, nil
+}
+
+func (m *memSettingsRepo) SetMaintenanceState(ctx context.Context, state *domain.MaintenanceState) error {
+	return nil
+}
+
+type mockAuditLogger struct {
+	actions  []string
+	metadata []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+	m.metadata = append(m.metadata, metadata)
+}
+
+func newTestServer() (*Server, *memSettingsRepo, *mockAuditLogger) {
+	repo := &memSettingsRepo{}
+	auditLogger := &mockAuditLogger{}
+	watcher := config.NewWatcher(&config.Config{PlatformAdminUserIDs: "admin-1", DefaultTrustTTLDays: 30})
+	return NewServer(repo, watcher, auditLogger), repo, auditLogger
+}
+
+func TestPlatformSettings_GetAndSet(t *testing.T) {
+	srv, repo, auditLogger := newTestServer()
+	ctx := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+
+	got, err := srv.GetPlatformSettings(ctx, &platformsettingsv1.GetPlatformSettingsRequest{})
+	if err != nil {
+		t.Fatalf("GetPlatformSettings: %v", err)
+	}
+	if s := got.GetSettings(); s.GetDefaultTrustTtlDays() != 30 || s.GetMfaRequiredAlways() || s.GetRegistrationMode() != platformsettingsv1.RegistrationMode_REGISTRATION_MODE_OPEN {
+		t.Errorf("defaults = %+v, want 30 days, MFA not always, registration open", s)
+	}
+
+	set, err := srv.SetPlatformSettings(ctx, &platformsettingsv1.SetPlatformSettingsRequest{
+		DefaultTrustTtlDays: proto.Int32(14),
+		RegistrationMode:    platformsettingsv1.RegistrationMode_REGISTRATION_MODE_INVITE_ONLY.Enum(),
+	})
+	if err != nil {
+		t.Fatalf("SetPlatformSettings: %v", err)
+	}
+	if s := set.GetSettings(); s.GetDefaultTrustTtlDays() != 14 || s.GetMfaRequiredAlways() || s.GetRegistrationMode() != platformsettingsv1.RegistrationMode_REGISTRATION_MODE_INVITE_ONLY {
+		t.Errorf("after set = %+v, want 14 days, MFA not always, registration invite only", s)
+	}
+	if repo.stored.MFARequiredAlways != nil {
+		t.Error("a setting left out of the request should not be stored")
+	}
+	if len(auditLogger.actions) != 2 || auditLogger.actions[0] != "platform_setting_changed" {
+		t.Errorf("audit actions = %v, want two platform_setting_changed", auditLogger.actions)
+	}
+	if want := `{"key":"default_trust_ttl_days","new_value":"14","old_value":"30"}`; auditLogger.metadata[0] != want {
+		t.Errorf("audit metadata = %s, want %s", auditLogger.metadata[0], want)
+	}
+	if want := `{"key":"registration_mode","new_value":"invite_only","old_value":"open"}`; auditLogger.metadata[1] != want {
+		t.Errorf("audit metadata = %s, want %s", auditLogger.metadata[1], want)
+	}
+
+	// Setting a value to what it already is stores it but audits nothing.
+	if _, err := srv.SetPlatformSettings(ctx, &platformsettingsv1.SetPlatformSettingsRequest{RegistrationMode: platformsettingsv1.RegistrationMode_REGISTRATION_MODE_INVITE_ONLY.Enum()}); err != nil {
+		t.Fatalf("SetPlatformSettings (unchanged): %v", err)
+	}
+	if repo.sets != 2 || len(auditLogger.actions) != 2 {
+		t.Errorf("unchanged set: %d writes, %d audit events, want 2 and 2", repo.sets, len(auditLogger.actions))
+	}
+}
+
+func TestPlatformSettings_Errors(t *testing.T) {
+	srv, repo, _ := newTestServer()
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	user := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-2")
+
+	if _, err := srv.GetPlatformSettings(user, &platformsettingsv1.GetPlatformSettingsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Get as non-admin: code = %v, want PermissionDenied", status.Code(err))
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *platformsettingsv1.SetPlatformSettingsRequest
+		code codes.Code
+	}{
+		{"non-admin", user, &platformsettingsv1.SetPlatformSettingsRequest{MfaRequiredAlways: proto.Bool(true)}, codes.PermissionDenied},
+		{"no identity", context.Background(), &platformsettingsv1.SetPlatformSettingsRequest{MfaRequiredAlways: proto.Bool(true)}, codes.Unauthenticated},
+		{"empty", admin, &platformsettingsv1.SetPlatformSettingsRequest{}, codes.InvalidArgument},
+		{"ttl zero", admin, &platformsettingsv1.SetPlatformSettingsRequest{DefaultTrustTtlDays: proto.Int32(0)}, codes.InvalidArgument},
+		{"ttl too long", admin, &platformsettingsv1.SetPlatformSettingsRequest{DefaultTrustTtlDays: proto.Int32(366)}, codes.InvalidArgument},
+		{"unspecified registration mode", admin, &platformsettingsv1.SetPlatformSettingsRequest{RegistrationMode: platformsettingsv1.RegistrationMode_REGISTRATION_MODE_UNSPECIFIED.Enum()}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if _, err := srv.SetPlatformSettings(tt.ctx, tt.req); status.Code(err) != tt.code {
+			t.Errorf("%s: code = %v, want %v (%v)", tt.name, status.Code(err), tt.code, err)
+		}
+	}
+	if repo.sets != 0 {
+		t.Errorf("rejected requests stored settings %d times", repo.sets)
+	}
+
+	if _, err := NewServer(nil, nil, nil).GetPlatformSettings(admin, &platformsettingsv1.GetPlatformSettingsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("without repo: code = %v, want Unimplemented", status.Code(err))
+	}
+}
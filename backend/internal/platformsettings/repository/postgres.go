@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"strconv"
 	"strings"
@@ -11,42 +12,121 @@ import (
 	"zero-trust-control-plane/backend/internal/platformsettings/domain"
 )
 
+// maintenanceKey is the platform_settings key holding the maintenance mode as JSON.
+const maintenanceKey = "maintenance_mode"
+
 type PostgresRepository struct {
+	db      *sql.DB
 	queries *gen.Queries
 }
 
 // NewPostgresRepository returns a platform settings repository that uses the given db.
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+	return &PostgresRepository{db: db, queries: gen.New(db)}
 }
 
 // GetDeviceTrustSettings returns platform-level MFA/device trust settings from DB, or defaults.
 func (r *PostgresRepository) GetDeviceTrustSettings(ctx context.Context, defaultTrustTTLDays int) (*domain.PlatformDeviceTrustSettings, error) {
-	out := &domain.PlatformDeviceTrustSettings{
-		MFARequiredAlways:   false,
-		DefaultTrustTTLDays: defaultTrustTTLDays,
+	settings, err := r.GetSettings(ctx, defaultTrustTTLDays)
+	if err != nil {
+		return nil, err
 	}
-	mfa, err := r.queries.GetPlatformSetting(ctx, "mfa_required_always")
+	return &domain.PlatformDeviceTrustSettings{
+		MFARequiredAlways:   settings.MFARequiredAlways,
+		DefaultTrustTTLDays: settings.DefaultTrustTTLDays,
+	}, nil
+}
+
+// GetSettings returns the typed platform settings. Missing keys and values that do not parse take their defaults.
+// Without registration_mode, a stored registration_open false reads as closed.
+func (r *PostgresRepository) GetSettings(ctx context.Context, defaultTrustTTLDays int) (*domain.Settings, error) {
+	rows, err := r.queries.ListPlatformSettings(ctx)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return out, nil
-		}
 		return nil, err
 	}
-	if v, err := parseBool(mfa.ValueJson); err == nil {
-		out.MFARequiredAlways = v
+	out := domain.DefaultSettings(defaultTrustTTLDays)
+	haveMode, legacyClosed := false, false
+	for _, row := range rows {
+		switch row.Key {
+		case domain.KeyDefaultTrustTTLDays:
+			if v, err := strconv.Atoi(strings.TrimSpace(row.ValueJson)); err == nil && v > 0 {
+				out.DefaultTrustTTLDays = v
+			}
+		case domain.KeyMFARequiredAlways:
+			if v, err := parseBool(row.ValueJson); err == nil {
+				out.MFARequiredAlways = v
+			}
+		case domain.KeyRegistrationMode:
+			if m := parseRegistrationMode(row.ValueJson); m.Valid() {
+				out.RegistrationMode = m
+				haveMode = true
+			}
+		case domain.KeyRegistrationOpen:
+			if v, err := parseBool(row.ValueJson); err == nil && strings.TrimSpace(row.ValueJson) != "" {
+				legacyClosed = !v
+			}
+		}
 	}
-	ttl, err := r.queries.GetPlatformSetting(ctx, "default_trust_ttl_days")
+	if !haveMode && legacyClosed {
+		out.RegistrationMode = domain.RegistrationClosed
+	}
+	return &out, nil
+}
+
+// SetSettings stores the settings named by u in one transaction.
+func (r *PostgresRepository) SetSettings(ctx context.Context, u domain.SettingsUpdate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	var values []gen.SetPlatformSettingParams
+	if u.DefaultTrustTTLDays != nil {
+		values = append(values, gen.SetPlatformSettingParams{Key: domain.KeyDefaultTrustTTLDays, ValueJson: strconv.Itoa(*u.DefaultTrustTTLDays)})
+	}
+	if u.MFARequiredAlways != nil {
+		values = append(values, gen.SetPlatformSettingParams{Key: domain.KeyMFARequiredAlways, ValueJson: strconv.FormatBool(*u.MFARequiredAlways)})
+	}
+	if u.RegistrationMode != nil {
+		raw, err := json.Marshal(string(*u.RegistrationMode))
+		if err != nil {
+			return err
+		}
+		values = append(values, gen.SetPlatformSettingParams{Key: domain.KeyRegistrationMode, ValueJson: string(raw)})
+	}
+	for _, v := range values {
+		if _, err := q.SetPlatformSetting(ctx, v); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetMaintenanceState returns the stored maintenance mode, or the zero state when the key is missing.
+func (r *PostgresRepository) GetMaintenanceState(ctx context.Context) (*domain.MaintenanceState, error) {
+	row, err := r.queries.GetPlatformSetting(ctx, maintenanceKey)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return out, nil
+			return &domain.MaintenanceState{}, nil
 		}
 		return nil, err
 	}
-	if v, err := strconv.Atoi(ttl.ValueJson); err == nil && v > 0 {
-		out.DefaultTrustTTLDays = v
+	var state domain.MaintenanceState
+	if err := json.Unmarshal([]byte(row.ValueJson), &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// SetMaintenanceState stores the maintenance mode as JSON under maintenance_mode.
+func (r *PostgresRepository) SetMaintenanceState(ctx context.Context, state *domain.MaintenanceState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
 	}
-	return out, nil
+	_, err = r.queries.SetPlatformSetting(ctx, gen.SetPlatformSettingParams{Key: maintenanceKey, ValueJson: string(raw)})
+	return err
 }
 
 func parseBool(s string) (bool, error) {
@@ -59,3 +139,12 @@ func parseBool(s string) (bool, error) {
 		return false, strconv.ErrSyntax
 	}
 }
+
+// parseRegistrationMode reads a mode stored as a JSON string, or bare.
+func parseRegistrationMode(raw string) domain.RegistrationMode {
+	var s string
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		s = strings.TrimSpace(raw)
+	}
+	return domain.RegistrationMode(strings.ToLower(s))
+}
@@ -6,9 +6,18 @@ import (
 	"zero-trust-control-plane/backend/internal/platformsettings/domain"
 )
 
-// Repository defines read access to platform settings for device trust / MFA.
+// Repository defines access to platform settings: the typed settings (device trust / MFA defaults and
+// registration) and the maintenance mode.
 type Repository interface {
 	// GetDeviceTrustSettings returns platform-level MFA/device trust settings.
 	// Uses defaults when keys are missing (MFARequiredAlways false, DefaultTrustTTLDays from config).
 	GetDeviceTrustSettings(ctx context.Context, defaultTrustTTLDays int) (*domain.PlatformDeviceTrustSettings, error)
+	// GetSettings returns the typed platform settings; missing keys take their defaults (see domain.Settings).
+	GetSettings(ctx context.Context, defaultTrustTTLDays int) (*domain.Settings, error)
+	// SetSettings stores the settings named by u; the others are left as stored.
+	SetSettings(ctx context.Context, u domain.SettingsUpdate) error
+	// GetMaintenanceState returns the stored maintenance mode, or the zero state (off) when none is stored.
+	GetMaintenanceState(ctx context.Context) (*domain.MaintenanceState, error)
+	// SetMaintenanceState stores the maintenance mode.
+	SetMaintenanceState(ctx context.Context, state *domain.MaintenanceState) error
 }
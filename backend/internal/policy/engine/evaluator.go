@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"time"
 
 	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
 	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
@@ -29,3 +30,149 @@ type Evaluator interface {
 		isNewDevice bool,
 	) (MFAResult, error)
 }
+
+type timezoneKey struct{}
+
+// WithTimezone returns ctx carrying the org's policy timezone. EvaluateMFA reports input.time in that zone (default UTC).
+func WithTimezone(ctx context.Context, loc *time.Location) context.Context {
+	if loc == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, timezoneKey{}, loc)
+}
+
+// timezoneFrom returns the timezone set by WithTimezone, or UTC.
+func timezoneFrom(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(timezoneKey{}).(*time.Location); ok {
+		return loc
+	}
+	return time.UTC
+}
+
+type groupsKey struct{}
+
+// groupsInput is the user's group names and the org's MFA requirement for each of them.
+type groupsInput struct {
+	names           []string
+	mfaRequirements map[string]string
+}
+
+// WithGroups returns ctx carrying the user's group names and the org's MFA requirement for each of those groups that
+// has one (auth_mfa.group_requirements). EvaluateMFA reports them as input.user.groups and
+// input.org.group_mfa_requirements (group name → always, new_device or untrusted).
+func WithGroups(ctx context.Context, names []string, mfaRequirements map[string]string) context.Context {
+	return context.WithValue(ctx, groupsKey{}, groupsInput{names: names, mfaRequirements: mfaRequirements})
+}
+
+// groupsFrom returns the groups set by WithGroups, or none.
+func groupsFrom(ctx context.Context) groupsInput {
+	in, _ := ctx.Value(groupsKey{}).(groupsInput)
+	if in.names == nil {
+		in.names = []string{}
+	}
+	if in.mfaRequirements == nil {
+		in.mfaRequirements = map[string]string{}
+	}
+	return in
+}
+
+type userAttributesKey struct{}
+
+// WithUserAttributes returns ctx carrying the user's custom attributes in the org (key → string, number or
+// boolean). EvaluateMFA reports them as input.user.attributes.
+func WithUserAttributes(ctx context.Context, attrs map[string]interface{}) context.Context {
+	return context.WithValue(ctx, userAttributesKey{}, attrs)
+}
+
+// userAttributesFrom returns the attributes set by WithUserAttributes, or none.
+func userAttributesFrom(ctx context.Context) map[string]interface{} {
+	if attrs, ok := ctx.Value(userAttributesKey{}).(map[string]interface{}); ok && attrs != nil {
+		return attrs
+	}
+	return map[string]interface{}{}
+}
+
+type deviceSharingKey struct{}
+
+// DeviceSharing is how widely the device's fingerprint is used: by how many users and orgs, counting the one signing
+// in, and whether that reaches the shared-device threshold.
+type DeviceSharing struct {
+	Users  int
+	Orgs   int
+	Shared bool
+}
+
+// WithDeviceSharing returns ctx carrying how widely the device's fingerprint is used. EvaluateMFA reports it as
+// input.device.shared_device, input.device.fingerprint_user_count and input.device.fingerprint_org_count.
+func WithDeviceSharing(ctx context.Context, sharing DeviceSharing) context.Context {
+	return context.WithValue(ctx, deviceSharingKey{}, sharing)
+}
+
+// deviceSharingFrom returns the sharing set by WithDeviceSharing, or the zero DeviceSharing (not shared, counts 0).
+func deviceSharingFrom(ctx context.Context) DeviceSharing {
+	sharing, _ := ctx.Value(deviceSharingKey{}).(DeviceSharing)
+	return sharing
+}
+
+type trustedNetworkKey struct{}
+
+// TrustedNetwork is whether the request comes from one of the org's trusted (corporate) networks
+// (network_access.trusted_cidrs), and whether the org forbids policies to relax MFA there
+// (network_access.forbid_mfa_relaxation).
+type TrustedNetwork struct {
+	On                  bool
+	RelaxationForbidden bool
+}
+
+// WithTrustedNetwork returns ctx carrying whether the request is on a trusted network. EvaluateMFA reports it as
+// input.network.on_trusted_network. With RelaxationForbidden, the policies are also evaluated as off the trusted
+// network and the stricter result is used, so the signal can tighten MFA but never relax it.
+func WithTrustedNetwork(ctx context.Context, tn TrustedNetwork) context.Context {
+	return context.WithValue(ctx, trustedNetworkKey{}, tn)
+}
+
+// trustedNetworkFrom returns the network set by WithTrustedNetwork, or the zero TrustedNetwork (not on a trusted
+// network).
+func trustedNetworkFrom(ctx context.Context) TrustedNetwork {
+	tn, _ := ctx.Value(trustedNetworkKey{}).(TrustedNetwork)
+	return tn
+}
+
+// FailureMode is what MFA policy evaluation does when the org's policies cannot be loaded (policy store down or
+// its circuit breaker open).
+type FailureMode string
+
+const (
+	// FailOpen evaluates the built-in default policy against the org's MFA settings.
+	FailOpen FailureMode = "open"
+	// FailClosed requires MFA for every sign-in.
+	FailClosed FailureMode = "closed"
+)
+
+// ParseFailureMode returns the mode named s ("open" or "closed"); ok is false for any other value.
+func ParseFailureMode(s string) (mode FailureMode, ok bool) {
+	switch FailureMode(s) {
+	case FailOpen, FailClosed:
+		return FailureMode(s), true
+	}
+	return "", false
+}
+
+type failureModeKey struct{}
+
+// WithFailureMode returns ctx carrying the org's policy failure mode (auth_mfa.policy_failure_mode). An empty mode
+// leaves the evaluator's default in place.
+func WithFailureMode(ctx context.Context, mode FailureMode) context.Context {
+	if mode == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, failureModeKey{}, mode)
+}
+
+// failureModeFrom returns the mode set by WithFailureMode, or def.
+func failureModeFrom(ctx context.Context, def FailureMode) FailureMode {
+	if mode, ok := ctx.Value(failureModeKey{}).(FailureMode); ok {
+		return mode
+	}
+	return def
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/open-policy-agent/opa/v1/ast"
@@ -12,15 +13,18 @@ import (
 
 	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
 	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	"zero-trust-control-plane/backend/internal/platform/breaker"
 	platformdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
 	"zero-trust-control-plane/backend/internal/policy/repository"
 	userdomain "zero-trust-control-plane/backend/internal/user/domain"
 )
 
 const defaultPolicyPackage = "ztcp.device_trust"
 
-// Default Rego policy that matches current hardcoded logic (backward compatibility).
-const defaultRegoPolicy = `package ztcp.device_trust
+// DefaultRegoPolicy is evaluated for orgs without enabled policies; it matches the hardcoded logic it replaced
+// (backward compatibility). New orgs set up from a template get it as their first policy.
+const DefaultRegoPolicy = `package ztcp.device_trust
 
 default mfa_required = false
 default register_trust_after_mfa = true
@@ -40,6 +44,23 @@ mfa_required if {
 	input.org.mfa_required_for_untrusted
 }
 
+mfa_required if {
+	some group in input.user.groups
+	input.org.group_mfa_requirements[group] == "always"
+}
+
+mfa_required if {
+	input.device.is_new
+	some group in input.user.groups
+	input.org.group_mfa_requirements[group] == "new_device"
+}
+
+mfa_required if {
+	not input.device.is_effectively_trusted
+	some group in input.user.groups
+	input.org.group_mfa_requirements[group] == "untrusted"
+}
+
 register_trust_after_mfa = input.org.register_trust_after_mfa if {
 	input.org.register_trust_after_mfa != null
 }
@@ -58,18 +79,30 @@ trust_ttl_days = input.platform.default_trust_ttl_days if {
 
 // OPAEvaluator evaluates device-trust/MFA policies using OPA Rego.
 type OPAEvaluator struct {
-	policyRepo repository.Repository
+	policyRepo  repository.Repository
+	breaker     *breaker.Breaker
+	failureMode FailureMode
 }
 
-// NewOPAEvaluator returns an OPA-based policy evaluator.
+// NewOPAEvaluator returns an OPA-based policy evaluator. It fails open (see FailureMode) until SetBreaker is called.
 func NewOPAEvaluator(policyRepo repository.Repository) *OPAEvaluator {
-	return &OPAEvaluator{policyRepo: policyRepo}
+	return &OPAEvaluator{policyRepo: policyRepo, failureMode: FailOpen}
+}
+
+// SetBreaker guards policy loading with b, so a policy store that keeps failing is skipped at once instead of
+// being waited on for every sign-in, and sets the failure mode used for orgs that have not chosen one
+// (WithFailureMode). Call it before the evaluator is used.
+func (e *OPAEvaluator) SetBreaker(b *breaker.Breaker, defaultMode FailureMode) {
+	e.breaker = b
+	if defaultMode != "" {
+		e.failureMode = defaultMode
+	}
 }
 
 // HealthCheck verifies that the in-process OPA Rego engine can compile and evaluate the default policy.
 // Does not call the policy repo or database. Returns nil on success.
 func (e *OPAEvaluator) HealthCheck(ctx context.Context) error {
-	modules := map[string]string{"policy_0.rego": defaultRegoPolicy}
+	modules := map[string]string{"policy_0.rego": DefaultRegoPolicy}
 	compiler, err := ast.CompileModules(modules)
 	if err != nil {
 		return fmt.Errorf("compile default policy: %w", err)
@@ -125,16 +158,37 @@ func (e *OPAEvaluator) EvaluateMFA(
 ) (MFAResult, error) {
 	// Build input JSON for OPA
 	input, err := e.buildInput(platformSettings, orgSettings, device, user, isNewDevice)
+	if err == nil {
+		input["time"] = timeInput(time.Now(), timezoneFrom(ctx))
+		groups := groupsFrom(ctx)
+		input["user"].(map[string]interface{})["groups"] = groups.names
+		input["user"].(map[string]interface{})["attributes"] = userAttributesFrom(ctx)
+		input["org"].(map[string]interface{})["group_mfa_requirements"] = groups.mfaRequirements
+		sharing := deviceSharingFrom(ctx)
+		input["device"].(map[string]interface{})["shared_device"] = sharing.Shared
+		input["device"].(map[string]interface{})["fingerprint_user_count"] = sharing.Users
+		input["device"].(map[string]interface{})["fingerprint_org_count"] = sharing.Orgs
+		input["network"] = map[string]interface{}{"on_trusted_network": trustedNetworkFrom(ctx).On}
+	}
 	if err != nil {
 		return e.defaultResult(platformSettings), fmt.Errorf("build input: %w", err)
 	}
 
-	// Load enabled policies for org
+	// Load enabled policies for org. When they cannot be loaded, fail closed (require MFA) or open (default policy).
 	var policies []string
 	if orgSettings != nil {
-		enabledPolicies, err := e.policyRepo.GetEnabledPoliciesByOrg(ctx, orgSettings.OrgID)
+		enabledPolicies, err := e.loadPolicies(ctx, orgSettings.OrgID)
 		if err != nil {
 			log.Printf("policy: failed to load policies for org %s: %v", orgSettings.OrgID, err)
+			if failureModeFrom(ctx, e.failureMode) == FailClosed {
+				result := e.defaultResult(platformSettings)
+				result.MFARequired = true
+				if orgSettings.TrustTTLDays > 0 {
+					result.TrustTTLDays = orgSettings.TrustTTLDays
+				}
+				result.RegisterTrustAfterMFA = orgSettings.RegisterTrustAfterMFA
+				return result, nil
+			}
 		} else {
 			for _, p := range enabledPolicies {
 				if p.Enabled && p.Rules != "" {
@@ -146,7 +200,7 @@ func (e *OPAEvaluator) EvaluateMFA(
 
 	// Use default policy if no org policies exist
 	if len(policies) == 0 {
-		policies = []string{defaultRegoPolicy}
+		policies = []string{DefaultRegoPolicy}
 	}
 
 	// Compile and evaluate policies
@@ -155,10 +209,43 @@ func (e *OPAEvaluator) EvaluateMFA(
 		log.Printf("policy: evaluation failed: %v, using defaults", err)
 		return e.defaultResult(platformSettings), nil
 	}
+	if tn := trustedNetworkFrom(ctx); tn.On && tn.RelaxationForbidden {
+		input["network"] = map[string]interface{}{"on_trusted_network": false}
+		off, err := e.evaluatePolicies(ctx, policies, input)
+		if err != nil {
+			log.Printf("policy: evaluation off the trusted network failed: %v, using defaults", err)
+			return e.defaultResult(platformSettings), nil
+		}
+		result = stricter(result, off)
+	}
 
 	return result, nil
 }
 
+// stricter returns the stricter of two results: MFA when either requires it, trust after MFA only when both register
+// it, and the shorter trust TTL.
+func stricter(a, b MFAResult) MFAResult {
+	out := MFAResult{
+		MFARequired:           a.MFARequired || b.MFARequired,
+		RegisterTrustAfterMFA: a.RegisterTrustAfterMFA && b.RegisterTrustAfterMFA,
+		TrustTTLDays:          a.TrustTTLDays,
+	}
+	if b.TrustTTLDays > 0 && (out.TrustTTLDays <= 0 || b.TrustTTLDays < out.TrustTTLDays) {
+		out.TrustTTLDays = b.TrustTTLDays
+	}
+	return out
+}
+
+// loadPolicies returns the org's enabled policies through the breaker, if any.
+func (e *OPAEvaluator) loadPolicies(ctx context.Context, orgID string) ([]*policydomain.Policy, error) {
+	if err := e.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	policies, err := e.policyRepo.GetEnabledPoliciesByOrg(ctx, orgID)
+	e.breaker.Record(err)
+	return policies, err
+}
+
 func (e *OPAEvaluator) buildInput(
 	platformSettings *platformdomain.PlatformDeviceTrustSettings,
 	orgSettings *orgmfasettingsdomain.OrgMFASettings,
@@ -201,6 +288,8 @@ func (e *OPAEvaluator) buildInput(
 		"revoked_at":             nil,
 		"is_new":                 isNewDevice,
 		"is_effectively_trusted": false,
+		"quarantined":            false,
+		"quarantined_at":         nil,
 	}
 	if device != nil {
 		deviceMap["id"] = device.ID
@@ -212,6 +301,10 @@ func (e *OPAEvaluator) buildInput(
 			deviceMap["revoked_at"] = device.RevokedAt.Format(time.RFC3339)
 		}
 		deviceMap["is_effectively_trusted"] = device.IsEffectivelyTrusted(now)
+		if device.QuarantinedAt != nil {
+			deviceMap["quarantined"] = true
+			deviceMap["quarantined_at"] = device.QuarantinedAt.Format(time.RFC3339)
+		}
 	}
 
 	userMap := map[string]interface{}{
@@ -231,6 +324,22 @@ func (e *OPAEvaluator) buildInput(
 	}, nil
 }
 
+// timeInput returns the current-time attributes exposed to policies as input.time, in the org's timezone,
+// e.g. to require MFA outside business hours:
+//
+//	mfa_required if { input.time.hour < 8 }
+func timeInput(now time.Time, loc *time.Location) map[string]interface{} {
+	local := now.In(loc)
+	return map[string]interface{}{
+		"unix":     now.Unix(),
+		"rfc3339":  local.Format(time.RFC3339),
+		"timezone": loc.String(),
+		"weekday":  strings.ToLower(local.Weekday().String()[:3]),
+		"hour":     local.Hour(),
+		"minute":   local.Minute(),
+	}
+}
+
 func (e *OPAEvaluator) evaluatePolicies(ctx context.Context, policies []string, input map[string]interface{}) (MFAResult, error) {
 	// Compile all policies
 	modules := make(map[string]string)
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	platformdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	"zero-trust-control-plane/backend/internal/policy/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+)
+
+// benchmarkEvaluateMFA evaluates a login on a trusted device of a user with a phone, as on most logins, with the
+// given org policies (none uses the default policy).
+func benchmarkEvaluateMFA(b *testing.B, policies []*domain.Policy, parallel bool) {
+	repo := &mockPolicyRepo{policies: map[string][]*domain.Policy{"org-1": policies}}
+	e := NewOPAEvaluator(repo)
+	ctx := context.Background()
+	platform := &platformdomain.PlatformDeviceTrustSettings{DefaultTrustTTLDays: 30}
+	org := &orgmfasettingsdomain.OrgMFASettings{
+		OrgID:                   "org-1",
+		MFARequiredForNewDevice: true,
+		MFARequiredForUntrusted: true,
+		RegisterTrustAfterMFA:   true,
+		TrustTTLDays:            30,
+	}
+	now := time.Now()
+	trustedUntil := now.Add(24 * time.Hour)
+	device := &devicedomain.Device{ID: "dev-1", UserID: "user-1", OrgID: "org-1", Fingerprint: "fp-1", Trusted: true, TrustedUntil: &trustedUntil, LastSeenAt: &now, CreatedAt: now}
+	user := &userdomain.User{ID: "user-1", Email: "user@example.com", Phone: "+15550001111", Status: userdomain.UserStatusActive}
+
+	eval := func() bool {
+		result, err := e.EvaluateMFA(ctx, platform, org, device, user, false)
+		if err != nil {
+			b.Errorf("EvaluateMFA: %v", err)
+			return false
+		}
+		if result.MFARequired {
+			b.Error("trusted device should not require MFA")
+			return false
+		}
+		return true
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	if !parallel {
+		for i := 0; i < b.N; i++ {
+			if !eval() {
+				return
+			}
+		}
+		return
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if !eval() {
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkOPAEvaluator_EvaluateMFA(b *testing.B) {
+	orgPolicy := &domain.Policy{ID: "policy-1", OrgID: "org-1", Enabled: true, Rules: DefaultRegoPolicy}
+	extraPolicy := &domain.Policy{ID: "policy-2", OrgID: "org-1", Enabled: true, Rules: `package ztcp.device_trust
+
+mfa_required if {
+	input.time.hour < 6
+	input.device.is_new
+}
+`}
+	b.Run("default_policy", func(b *testing.B) { benchmarkEvaluateMFA(b, nil, false) })
+	b.Run("org_policy", func(b *testing.B) { benchmarkEvaluateMFA(b, []*domain.Policy{orgPolicy}, false) })
+	b.Run("two_org_policies", func(b *testing.B) { benchmarkEvaluateMFA(b, []*domain.Policy{orgPolicy, extraPolicy}, false) })
+	b.Run("default_policy_parallel", func(b *testing.B) { benchmarkEvaluateMFA(b, nil, true) })
+}
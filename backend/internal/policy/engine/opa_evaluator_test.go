@@ -8,6 +8,7 @@ import (
 
 	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
 	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	"zero-trust-control-plane/backend/internal/platform/breaker"
 	platformdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
 	"zero-trust-control-plane/backend/internal/policy/domain"
 	"zero-trust-control-plane/backend/internal/policy/repository"
@@ -260,6 +261,57 @@ func TestOPAEvaluator_EvaluateMFA_PolicyRepoError(t *testing.T) {
 	}
 }
 
+// countingPolicyRepo counts GetEnabledPoliciesByOrg calls.
+type countingPolicyRepo struct {
+	mockPolicyRepo
+	calls int
+}
+
+func (m *countingPolicyRepo) GetEnabledPoliciesByOrg(ctx context.Context, orgID string) ([]*domain.Policy, error) {
+	m.calls++
+	return m.mockPolicyRepo.GetEnabledPoliciesByOrg(ctx, orgID)
+}
+
+func TestOPAEvaluator_EvaluateMFA_BreakerAndFailureMode(t *testing.T) {
+	repo := &countingPolicyRepo{mockPolicyRepo: mockPolicyRepo{err: errors.New("database error")}}
+	e := NewOPAEvaluator(repo)
+	e.SetBreaker(breaker.New("policy", breaker.Config{FailureThreshold: 2, OpenTimeout: time.Hour}), FailOpen)
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1", RegisterTrustAfterMFA: true, TrustTTLDays: 14}
+
+	for i := 0; i < 3; i++ {
+		result, err := e.EvaluateMFA(context.Background(), nil, orgSettings, nil, nil, false)
+		if err != nil {
+			t.Fatalf("EvaluateMFA: %v", err)
+		}
+		if result.MFARequired {
+			t.Error("failing open should evaluate the default policy (no MFA for these settings)")
+		}
+	}
+	if repo.calls != 2 {
+		t.Errorf("policy repo calls = %d, want 2 (breaker open after the threshold)", repo.calls)
+	}
+
+	ctx := WithFailureMode(context.Background(), FailClosed)
+	result, err := e.EvaluateMFA(ctx, nil, orgSettings, nil, nil, false)
+	if err != nil {
+		t.Fatalf("EvaluateMFA: %v", err)
+	}
+	if !result.MFARequired || !result.RegisterTrustAfterMFA || result.TrustTTLDays != 14 {
+		t.Errorf("fail-closed result = %+v, want MFA required with the org's trust settings", result)
+	}
+}
+
+func TestParseFailureMode(t *testing.T) {
+	for _, s := range []string{"open", "closed"} {
+		if mode, ok := ParseFailureMode(s); !ok || string(mode) != s {
+			t.Errorf("ParseFailureMode(%q) = %q, %v", s, mode, ok)
+		}
+	}
+	if _, ok := ParseFailureMode("ajar"); ok {
+		t.Error("ParseFailureMode should reject unknown modes")
+	}
+}
+
 func TestOPAEvaluator_EvaluateMFA_DeviceWithTimestamps(t *testing.T) {
 	repo := &mockPolicyRepo{
 		policies: make(map[string][]*domain.Policy),
@@ -434,3 +486,276 @@ func TestOPAEvaluator_defaultResult(t *testing.T) {
 		t.Errorf("TrustTTLDays = %d, want 60", result.TrustTTLDays)
 	}
 }
+
+func TestOPAEvaluator_EvaluateMFA_TimeInput(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	customPolicy := `package ztcp.device_trust
+
+default mfa_required = false
+
+mfa_required if {
+	input.time.timezone == "Asia/Tokyo"
+	input.time.hour >= 0
+	input.time.weekday != ""
+}
+`
+	repo := &mockPolicyRepo{
+		policies: map[string][]*domain.Policy{
+			"org-1": {{ID: "policy-1", OrgID: "org-1", Enabled: true, Rules: customPolicy}},
+		},
+	}
+	e := NewOPAEvaluator(repo)
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}
+
+	result, err := e.EvaluateMFA(WithTimezone(context.Background(), tokyo), nil, orgSettings, nil, nil, false)
+	if err != nil {
+		t.Fatalf("EvaluateMFA: %v", err)
+	}
+	if !result.MFARequired {
+		t.Error("MFARequired should be true when input.time is in the org timezone")
+	}
+	result, err = e.EvaluateMFA(context.Background(), nil, orgSettings, nil, nil, false)
+	if err != nil {
+		t.Fatalf("EvaluateMFA: %v", err)
+	}
+	if result.MFARequired {
+		t.Error("input.time should default to UTC without WithTimezone")
+	}
+}
+
+func TestOPAEvaluator_EvaluateMFA_GroupRequirements(t *testing.T) {
+	e := NewOPAEvaluator(&mockPolicyRepo{policies: make(map[string][]*domain.Policy)})
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1", RegisterTrustAfterMFA: true, TrustTTLDays: 30}
+	requirements := map[string]string{"contractors": "always", "interns": "new_device"}
+
+	for _, tc := range []struct {
+		name    string
+		ctx     context.Context
+		isNew   bool
+		wantMFA bool
+	}{
+		{"no groups", context.Background(), true, false},
+		{"group without requirement", WithGroups(context.Background(), []string{"engineering"}, map[string]string{}), true, false},
+		{"always", WithGroups(context.Background(), []string{"contractors", "engineering"}, requirements), false, true},
+		{"new_device on a new device", WithGroups(context.Background(), []string{"interns"}, requirements), true, true},
+		{"new_device on a known device", WithGroups(context.Background(), []string{"interns"}, requirements), false, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := e.EvaluateMFA(tc.ctx, nil, orgSettings, nil, nil, tc.isNew)
+			if err != nil {
+				t.Fatalf("EvaluateMFA: %v", err)
+			}
+			if result.MFARequired != tc.wantMFA {
+				t.Errorf("MFARequired = %v, want %v", result.MFARequired, tc.wantMFA)
+			}
+		})
+	}
+}
+
+func TestOPAEvaluator_EvaluateMFA_GroupsInCustomPolicy(t *testing.T) {
+	customPolicy := `package ztcp.device_trust
+
+default mfa_required = false
+
+mfa_required if {
+	"contractors" in input.user.groups
+}
+`
+	repo := &mockPolicyRepo{
+		policies: map[string][]*domain.Policy{
+			"org-1": {{ID: "policy-1", OrgID: "org-1", Enabled: true, Rules: customPolicy}},
+		},
+	}
+	e := NewOPAEvaluator(repo)
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}
+
+	result, err := e.EvaluateMFA(WithGroups(context.Background(), []string{"contractors"}, nil), nil, orgSettings, nil, nil, false)
+	if err != nil {
+		t.Fatalf("EvaluateMFA: %v", err)
+	}
+	if !result.MFARequired {
+		t.Error("MFARequired should be true for a member of contractors")
+	}
+}
+
+func TestOPAEvaluator_EvaluateMFA_UserAttributesInCustomPolicy(t *testing.T) {
+	customPolicy := `package ztcp.device_trust
+
+default mfa_required = false
+
+mfa_required if {
+	input.user.attributes.department == "finance"
+	input.user.attributes.level >= 3
+}
+`
+	repo := &mockPolicyRepo{
+		policies: map[string][]*domain.Policy{
+			"org-1": {{ID: "policy-1", OrgID: "org-1", Enabled: true, Rules: customPolicy}},
+		},
+	}
+	e := NewOPAEvaluator(repo)
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantMFA bool
+	}{
+		{"no attributes", context.Background(), false},
+		{"matching", WithUserAttributes(context.Background(), map[string]interface{}{"department": "finance", "level": float64(3)}), true},
+		{"other department", WithUserAttributes(context.Background(), map[string]interface{}{"department": "sales", "level": float64(5)}), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := e.EvaluateMFA(tc.ctx, nil, orgSettings, nil, nil, false)
+			if err != nil {
+				t.Fatalf("EvaluateMFA: %v", err)
+			}
+			if result.MFARequired != tc.wantMFA {
+				t.Errorf("MFARequired = %v, want %v", result.MFARequired, tc.wantMFA)
+			}
+		})
+	}
+}
+
+func TestOPAEvaluator_EvaluateMFA_SharedDeviceInCustomPolicy(t *testing.T) {
+	customPolicy := `package ztcp.device_trust
+
+default mfa_required = false
+default register_trust_after_mfa = true
+
+mfa_required if {
+	input.device.shared_device
+}
+
+mfa_required if {
+	input.device.fingerprint_org_count > 2
+}
+
+register_trust_after_mfa = false if {
+	input.device.shared_device
+}
+`
+	repo := &mockPolicyRepo{
+		policies: map[string][]*domain.Policy{
+			"org-1": {{ID: "policy-1", OrgID: "org-1", Enabled: true, Rules: customPolicy}},
+		},
+	}
+	e := NewOPAEvaluator(repo)
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantMFA   bool
+		wantTrust bool
+	}{
+		{"not measured", context.Background(), false, true},
+		{"one user", WithDeviceSharing(context.Background(), DeviceSharing{Users: 1, Orgs: 1}), false, true},
+		{"shared", WithDeviceSharing(context.Background(), DeviceSharing{Users: 4, Orgs: 1, Shared: true}), true, false},
+		{"one user in many orgs", WithDeviceSharing(context.Background(), DeviceSharing{Users: 1, Orgs: 3}), true, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := e.EvaluateMFA(tc.ctx, nil, orgSettings, nil, nil, false)
+			if err != nil {
+				t.Fatalf("EvaluateMFA: %v", err)
+			}
+			if result.MFARequired != tc.wantMFA || result.RegisterTrustAfterMFA != tc.wantTrust {
+				t.Errorf("MFARequired = %v, RegisterTrustAfterMFA = %v, want %v, %v", result.MFARequired, result.RegisterTrustAfterMFA, tc.wantMFA, tc.wantTrust)
+			}
+		})
+	}
+}
+
+func TestTimeInput(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	got := timeInput(time.Date(2026, 3, 7, 23, 30, 0, 0, time.UTC), loc)
+	if got["weekday"] != "sun" || got["hour"] != 1 || got["minute"] != 30 {
+		t.Errorf("timeInput = %v, want sun 01:30", got)
+	}
+	if got["unix"] != time.Date(2026, 3, 7, 23, 30, 0, 0, time.UTC).Unix() {
+		t.Errorf("unix = %v", got["unix"])
+	}
+}
+
+func TestOPAEvaluator_EvaluateMFA_QuarantinedDeviceInput(t *testing.T) {
+	customPolicy := `package ztcp.device_trust
+
+default mfa_required = false
+default register_trust_after_mfa = true
+
+mfa_required if {
+	input.device.quarantined
+	input.device.quarantined_at != null
+}
+`
+	repo := &mockPolicyRepo{
+		policies: map[string][]*domain.Policy{
+			"org-1": {{ID: "policy-1", OrgID: "org-1", Enabled: true, Rules: customPolicy}},
+		},
+	}
+	e := NewOPAEvaluator(repo)
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}
+	now := time.Now().UTC()
+	dev := &devicedomain.Device{ID: "device-1", OrgID: "org-1", Trusted: true}
+
+	result, err := e.EvaluateMFA(context.Background(), nil, orgSettings, dev, nil, false)
+	if err != nil || result.MFARequired {
+		t.Fatalf("EvaluateMFA for a device not quarantined = %+v, %v; want no MFA", result, err)
+	}
+	dev.QuarantinedAt = &now
+	result, err = e.EvaluateMFA(context.Background(), nil, orgSettings, dev, nil, false)
+	if err != nil || !result.MFARequired {
+		t.Fatalf("EvaluateMFA for a quarantined device = %+v, %v; want MFA", result, err)
+	}
+}
+
+func TestOPAEvaluator_EvaluateMFA_TrustedNetworkInCustomPolicy(t *testing.T) {
+	customPolicy := `package ztcp.device_trust
+
+default mfa_required = true
+default register_trust_after_mfa = true
+
+mfa_required = false if {
+	input.network.on_trusted_network
+}
+
+register_trust_after_mfa = false if {
+	not input.network.on_trusted_network
+}
+`
+	repo := &mockPolicyRepo{
+		policies: map[string][]*domain.Policy{
+			"org-1": {{ID: "policy-1", OrgID: "org-1", Enabled: true, Rules: customPolicy}},
+		},
+	}
+	e := NewOPAEvaluator(repo)
+	orgSettings := &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantMFA   bool
+		wantTrust bool
+	}{
+		{"not measured", context.Background(), true, false},
+		{"off the trusted network", WithTrustedNetwork(context.Background(), TrustedNetwork{}), true, false},
+		{"on the trusted network", WithTrustedNetwork(context.Background(), TrustedNetwork{On: true}), false, true},
+		{"relaxation forbidden", WithTrustedNetwork(context.Background(), TrustedNetwork{On: true, RelaxationForbidden: true}), true, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := e.EvaluateMFA(tc.ctx, nil, orgSettings, nil, nil, false)
+			if err != nil {
+				t.Fatalf("EvaluateMFA: %v", err)
+			}
+			if result.MFARequired != tc.wantMFA || result.RegisterTrustAfterMFA != tc.wantTrust {
+				t.Errorf("MFARequired = %v, RegisterTrustAfterMFA = %v, want %v, %v", result.MFARequired, result.RegisterTrustAfterMFA, tc.wantMFA, tc.wantTrust)
+			}
+		})
+	}
+}
@@ -11,20 +11,31 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	policyv1 "zero-trust-control-plane/backend/api/generated/policy/v1"
+	"zero-trust-control-plane/backend/internal/billing"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
 	"zero-trust-control-plane/backend/internal/policy/domain"
 	"zero-trust-control-plane/backend/internal/policy/repository"
 )
 
+// PolicyConfigGetter loads org policy config to check whether policy changes require approval.
+type PolicyConfigGetter interface {
+	GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error)
+}
+
 // Server implements PolicyService (proto server) for policy CRUD and evaluation.
 // Proto: policy/policy.proto → internal/policy/handler.
 type Server struct {
 	policyv1.UnimplementedPolicyServiceServer
-	repo repository.Repository
+	repo       repository.Repository
+	configRepo PolicyConfigGetter
+	plans      *billing.Gate
 }
 
-// NewServer returns a new Policy gRPC server. Pass nil repo for stub (Unimplemented).
-func NewServer(repo repository.Repository) *Server {
-	return &Server{repo: repo}
+// NewServer returns a new Policy gRPC server. Pass nil repo for stub (Unimplemented). When configRepo is set,
+// writes fail with FailedPrecondition for orgs whose change_approval section requires a second admin; such changes
+// go through ChangeRequestService. When plans is set, CreatePolicy and UpdatePolicy require a plan with custom Rego.
+func NewServer(repo repository.Repository, configRepo PolicyConfigGetter, plans *billing.Gate) *Server {
+	return &Server{repo: repo, configRepo: configRepo, plans: plans}
 }
 
 // CreatePolicy creates a new policy with Rego validation.
@@ -41,6 +52,12 @@ func (s *Server) CreatePolicy(ctx context.Context, req *policyv1.CreatePolicyReq
 	if err := validateRego(req.GetRules()); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid Rego syntax: "+err.Error())
 	}
+	if err := s.plans.Require(ctx, req.GetOrgId(), billing.FeatureCustomRego); err != nil {
+		return nil, err
+	}
+	if err := s.checkDirectChange(ctx, req.GetOrgId()); err != nil {
+		return nil, err
+	}
 	policy := &domain.Policy{
 		ID:        uuid.New().String(),
 		OrgID:     req.GetOrgId(),
@@ -74,6 +91,12 @@ func (s *Server) UpdatePolicy(ctx context.Context, req *policyv1.UpdatePolicyReq
 	if existing == nil {
 		return nil, status.Error(codes.NotFound, "policy not found")
 	}
+	if err := s.plans.Require(ctx, existing.OrgID, billing.FeatureCustomRego); err != nil {
+		return nil, err
+	}
+	if err := s.checkDirectChange(ctx, existing.OrgID); err != nil {
+		return nil, err
+	}
 	existing.Rules = req.GetRules()
 	existing.Enabled = req.GetEnabled()
 	if err := s.repo.Update(ctx, existing); err != nil {
@@ -90,6 +113,17 @@ func (s *Server) DeletePolicy(ctx context.Context, req *policyv1.DeletePolicyReq
 	if req.GetPolicyId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "policy_id is required")
 	}
+	if s.configRepo != nil {
+		existing, err := s.repo.GetByID(ctx, req.GetPolicyId())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if existing != nil {
+			if err := s.checkDirectChange(ctx, existing.OrgID); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if err := s.repo.Delete(ctx, req.GetPolicyId()); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -115,6 +149,21 @@ func (s *Server) ListPolicies(ctx context.Context, req *policyv1.ListPoliciesReq
 	return &policyv1.ListPoliciesResponse{Policies: policies}, nil
 }
 
+// checkDirectChange fails with FailedPrecondition when orgID requires approval for policy changes.
+func (s *Server) checkDirectChange(ctx context.Context, orgID string) error {
+	if s.configRepo == nil {
+		return nil
+	}
+	config, err := s.configRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if config.RequiresChangeApproval() {
+		return status.Error(codes.FailedPrecondition, "org requires approval for policy changes; propose the change with ChangeRequestService")
+	}
+	return nil
+}
+
 func validateRego(regoCode string) error {
 	_, err := ast.ParseModule("", regoCode)
 	return err
@@ -10,6 +10,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	policyv1 "zero-trust-control-plane/backend/api/generated/policy/v1"
+	"zero-trust-control-plane/backend/internal/billing"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
 	"zero-trust-control-plane/backend/internal/policy/domain"
 )
 
@@ -82,7 +85,7 @@ mfa_required if {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{
@@ -112,7 +115,7 @@ func TestCreatePolicy_InvalidOrgID(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{
@@ -136,7 +139,7 @@ func TestCreatePolicy_EmptyRules(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{
@@ -162,7 +165,7 @@ invalid syntax {`
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{
@@ -188,7 +191,7 @@ func TestCreatePolicy_RepositoryError(t *testing.T) {
 		byOrg:     make(map[string][]*domain.Policy),
 		createErr: errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{
@@ -208,7 +211,7 @@ func TestCreatePolicy_RepositoryError(t *testing.T) {
 }
 
 func TestCreatePolicy_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{
@@ -247,7 +250,7 @@ allow if {
 		policies: map[string]*domain.Policy{"policy-1": existing},
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.UpdatePolicy(ctx, &policyv1.UpdatePolicyRequest{
@@ -271,7 +274,7 @@ func TestUpdatePolicy_InvalidPolicyID(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.UpdatePolicy(ctx, &policyv1.UpdatePolicyRequest{
@@ -295,7 +298,7 @@ func TestUpdatePolicy_NotFound(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.UpdatePolicy(ctx, &policyv1.UpdatePolicyRequest{
@@ -329,7 +332,7 @@ invalid {`
 		policies: map[string]*domain.Policy{"policy-1": existing},
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.UpdatePolicy(ctx, &policyv1.UpdatePolicyRequest{
@@ -361,7 +364,7 @@ func TestUpdatePolicy_EmptyRulesAllowed(t *testing.T) {
 		policies: map[string]*domain.Policy{"policy-1": existing},
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.UpdatePolicy(ctx, &policyv1.UpdatePolicyRequest{
@@ -390,7 +393,7 @@ func TestDeletePolicy_Success(t *testing.T) {
 		policies: map[string]*domain.Policy{"policy-1": existing},
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.DeletePolicy(ctx, &policyv1.DeletePolicyRequest{PolicyId: "policy-1"})
@@ -404,7 +407,7 @@ func TestDeletePolicy_InvalidPolicyID(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.DeletePolicy(ctx, &policyv1.DeletePolicyRequest{PolicyId: ""})
@@ -426,7 +429,7 @@ func TestDeletePolicy_RepositoryError(t *testing.T) {
 		byOrg:     make(map[string][]*domain.Policy),
 		deleteErr: errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.DeletePolicy(ctx, &policyv1.DeletePolicyRequest{PolicyId: "policy-1"})
@@ -442,6 +445,85 @@ func TestDeletePolicy_RepositoryError(t *testing.T) {
 	}
 }
 
+type staticConfigRepo struct {
+	cfg *orgpolicyconfigdomain.OrgPolicyConfig
+}
+
+func (r staticConfigRepo) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	return r.cfg, nil
+}
+
+func TestPolicyWrites_ChangeApprovalRequired(t *testing.T) {
+	existing := &domain.Policy{ID: "policy-1", OrgID: "org-1", Rules: "package test", Enabled: true}
+	repo := &mockPolicyRepo{
+		policies: map[string]*domain.Policy{"policy-1": existing},
+		byOrg:    make(map[string][]*domain.Policy),
+	}
+	cfg := &orgpolicyconfigdomain.OrgPolicyConfig{ChangeApproval: &orgpolicyconfigdomain.ChangeApproval{Required: true}}
+	srv := NewServer(repo, staticConfigRepo{cfg: cfg}, nil)
+	ctx := context.Background()
+
+	_, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{OrgId: "org-1", Rules: "package test"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreatePolicy: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	_, err = srv.UpdatePolicy(ctx, &policyv1.UpdatePolicyRequest{PolicyId: "policy-1", Rules: "package test", Enabled: false})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("UpdatePolicy: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	_, err = srv.DeletePolicy(ctx, &policyv1.DeletePolicyRequest{PolicyId: "policy-1"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeletePolicy: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if len(repo.policies) != 1 || !repo.policies["policy-1"].Enabled {
+		t.Error("blocked writes must not modify policies")
+	}
+
+	cfg.ChangeApproval.Required = false
+	if _, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{OrgId: "org-1", Rules: "package test"}); err != nil {
+		t.Errorf("CreatePolicy without approval requirement: %v", err)
+	}
+}
+
+type planOrgs map[string]*organizationdomain.Org
+
+func (o planOrgs) GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error) {
+	return o[id], nil
+}
+
+func (o planOrgs) UpdateOrganization(ctx context.Context, org *organizationdomain.Org) error {
+	o[org.ID] = org
+	return nil
+}
+
+func TestPolicyWrites_PlanRequired(t *testing.T) {
+	existing := &domain.Policy{ID: "policy-1", OrgID: "org-1", Rules: "package test", Enabled: true}
+	repo := &mockPolicyRepo{
+		policies: map[string]*domain.Policy{"policy-1": existing},
+		byOrg:    make(map[string][]*domain.Policy),
+	}
+	orgs := planOrgs{"org-1": {ID: "org-1", Plan: organizationdomain.PlanTeam}}
+	srv := NewServer(repo, nil, billing.NewGate(orgs, nil, nil))
+	ctx := context.Background()
+
+	_, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{OrgId: "org-1", Rules: "package test"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreatePolicy on team: code = %v, want PermissionDenied", status.Code(err))
+	}
+	_, err = srv.UpdatePolicy(ctx, &policyv1.UpdatePolicyRequest{PolicyId: "policy-1", Rules: "package test"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("UpdatePolicy on team: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.DeletePolicy(ctx, &policyv1.DeletePolicyRequest{PolicyId: "policy-1"}); err != nil {
+		t.Errorf("DeletePolicy on team: %v", err)
+	}
+
+	orgs["org-1"].Plan = organizationdomain.PlanEnterprise
+	if _, err := srv.CreatePolicy(ctx, &policyv1.CreatePolicyRequest{OrgId: "org-1", Rules: "package test"}); err != nil {
+		t.Errorf("CreatePolicy on enterprise: %v", err)
+	}
+}
+
 func TestListPolicies_Success(t *testing.T) {
 	now := time.Now().UTC()
 	policies := []*domain.Policy{
@@ -452,7 +534,7 @@ func TestListPolicies_Success(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    map[string][]*domain.Policy{"org-1": policies},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.ListPolicies(ctx, &policyv1.ListPoliciesRequest{OrgId: "org-1"})
@@ -469,7 +551,7 @@ func TestListPolicies_EmptyList(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    map[string][]*domain.Policy{"org-1": {}},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.ListPolicies(ctx, &policyv1.ListPoliciesRequest{OrgId: "org-1"})
@@ -486,7 +568,7 @@ func TestListPolicies_InvalidOrgID(t *testing.T) {
 		policies: make(map[string]*domain.Policy),
 		byOrg:    make(map[string][]*domain.Policy),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListPolicies(ctx, &policyv1.ListPoliciesRequest{OrgId: ""})
@@ -508,7 +590,7 @@ func TestListPolicies_RepositoryError(t *testing.T) {
 		byOrg:    make(map[string][]*domain.Policy),
 		listErr:  errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListPolicies(ctx, &policyv1.ListPoliciesRequest{OrgId: "org-1"})
@@ -525,7 +607,7 @@ func TestListPolicies_RepositoryError(t *testing.T) {
 }
 
 func TestListPolicies_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListPolicies(ctx, &policyv1.ListPoliciesRequest{OrgId: "org-1"})
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
+)
+
+// Preset is a named bundle of org policy settings, stored as platform data and shared by all orgs.
+type Preset struct {
+	Name        string // e.g. "strict"
+	DisplayName string // e.g. "Strict"
+	Description string
+	// PolicyRules is the Rego of the policy that applying the preset creates. Empty creates none, so MFA evaluation
+	// uses the built-in default policy.
+	PolicyRules string
+	// Config holds the sections of the org policy config the preset sets; applying it leaves the other sections as
+	// they are.
+	Config    *orgpolicyconfigdomain.OrgPolicyConfig
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Application is a preset applied to an org and what it stored.
+type Application struct {
+	OrgID  string
+	Preset string
+	// PolicyConfig is the org's config with the preset's sections applied; PolicyConfigVersion is its new version.
+	PolicyConfig        *orgpolicyconfigdomain.OrgPolicyConfig
+	PolicyConfigVersion int64
+	// Policy is the preset's policy, created enabled; nil when the preset has no policy rules.
+	Policy *policydomain.Policy
+	// DisabledPolicies is how many of the org's enabled policies the preset's policy replaced.
+	DisabledPolicies int64
+	MFASettings      *orgmfasettingsdomain.OrgMFASettings
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	policyv1 "zero-trust-control-plane/backend/api/generated/policy/v1"
+	policypresetv1 "zero-trust-control-plane/backend/api/generated/policypreset/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	orgpolicyconfighandler "zero-trust-control-plane/backend/internal/orgpolicyconfig/handler"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/policypreset"
+	"zero-trust-control-plane/backend/internal/policypreset/domain"
+)
+
+// Server implements PolicyPresetService (proto server).
+// Proto: policypreset/policypreset.proto → internal/policypreset/handler.
+type Server struct {
+	policypresetv1.UnimplementedPolicyPresetServiceServer
+	presets        *policypreset.Library
+	membershipRepo rbac.OrgMembershipGetter
+	auditLogger    audit.AuditLogger
+}
+
+// NewServer returns a new PolicyPreset gRPC server. If presets is nil, all RPCs return Unimplemented. auditLogger
+// may be nil.
+func NewServer(presets *policypreset.Library, membershipRepo rbac.OrgMembershipGetter, auditLogger audit.AuditLogger) *Server {
+	return &Server{
+		presets:        presets,
+		membershipRepo: membershipRepo,
+		auditLogger:    auditLogger,
+	}
+}
+
+// ListPresets returns all presets, by name, with their descriptions and settings. Any signed-in user may call it,
+// e.g. to offer templates before SetupOrganization.
+func (s *Server) ListPresets(ctx context.Context, req *policypresetv1.ListPresetsRequest) (*policypresetv1.ListPresetsResponse, error) {
+	if s.presets == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListPresets not implemented")
+	}
+	list, err := s.presets.List(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list presets")
+	}
+	out := make([]*policypresetv1.Preset, len(list))
+	for i, p := range list {
+		out[i] = presetToProto(p)
+	}
+	return &policypresetv1.ListPresetsResponse{Presets: out}, nil
+}
+
+// ApplyPreset applies the named preset to the caller's org. Caller must be org admin or owner. Audited as
+// policy_preset_applied.
+func (s *Server) ApplyPreset(ctx context.Context, req *policypresetv1.ApplyPresetRequest) (*policypresetv1.ApplyPresetResponse, error) {
+	if s.presets == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ApplyPreset not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match your organization")
+	}
+	if req.GetPreset() == "" {
+		return nil, status.Error(codes.InvalidArgument, "preset is required")
+	}
+	a, err := s.presets.Apply(ctx, orgID, userID, req.GetPreset())
+	switch {
+	case errors.Is(err, policypreset.ErrUnknownPreset):
+		return nil, status.Errorf(codes.NotFound, "preset %q does not exist", req.GetPreset())
+	case errors.Is(err, policypreset.ErrApprovalRequired):
+		return nil, status.Error(codes.FailedPrecondition, "org requires approval for policy changes; propose the preset's settings with ChangeRequestService")
+	case errors.Is(err, policypreset.ErrInvalidPreset):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, policypreset.ErrConflict):
+		return nil, status.Error(codes.Aborted, "org policy config is being changed concurrently; retry")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "failed to apply preset")
+	}
+	s.logApplied(ctx, userID, a)
+	resp := &policypresetv1.ApplyPresetResponse{
+		Preset:           a.Preset,
+		Config:           orgpolicyconfighandler.ConfigToProto(a.PolicyConfig),
+		Etag:             strconv.FormatInt(a.PolicyConfigVersion, 10), // as in GetOrgPolicyConfig
+		DisabledPolicies: int32(a.DisabledPolicies),
+	}
+	if p := a.Policy; p != nil {
+		resp.Policy = &policyv1.Policy{
+			Id:        p.ID,
+			OrgId:     p.OrgID,
+			Rules:     p.Rules,
+			Enabled:   p.Enabled,
+			CreatedAt: timestamppb.New(p.CreatedAt),
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) logApplied(ctx context.Context, userID string, a *domain.Application) {
+	if s.auditLogger == nil {
+		return
+	}
+	metadata := map[string]interface{}{
+		"preset":            a.Preset,
+		"version":           a.PolicyConfigVersion,
+		"disabled_policies": a.DisabledPolicies,
+	}
+	if a.Policy != nil {
+		metadata["policy_id"] = a.Policy.ID
+	}
+	meta, _ := json.Marshal(metadata)
+	s.auditLogger.LogEvent(ctx, a.OrgID, userID, "policy_preset_applied", "org_policy_config", string(meta))
+}
+
+func presetToProto(p *domain.Preset) *policypresetv1.Preset {
+	return &policypresetv1.Preset{
+		Name:        p.Name,
+		DisplayName: p.DisplayName,
+		Description: p.Description,
+		Config:      orgpolicyconfighandler.SectionsToProto(p.Config),
+		Sections:    p.Config.SetSections(),
+		PolicyRules: p.PolicyRules,
+	}
+}
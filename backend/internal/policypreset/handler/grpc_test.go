@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	policypresetv1 "zero-trust-control-plane/backend/api/generated/policypreset/v1"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/policypreset"
+	"zero-trust-control-plane/backend/internal/policypreset/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+// memRepo holds the presets and the config of org-1.
+type memRepo struct {
+	presets []*domain.Preset
+	config  *orgpolicyconfigdomain.OrgPolicyConfig
+	version int64
+}
+
+func (m *memRepo) List(ctx context.Context) ([]*domain.Preset, error) { return m.presets, nil }
+
+func (m *memRepo) GetByName(ctx context.Context, name string) (*domain.Preset, error) {
+	for _, p := range m.presets {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRepo) Apply(ctx context.Context, a *domain.Application, baseVersion int64, change orgpolicyconfigdomain.Change) (bool, error) {
+	if baseVersion != m.version {
+		return false, nil
+	}
+	m.version++
+	m.config = a.PolicyConfig
+	a.PolicyConfigVersion = m.version
+	a.DisabledPolicies = 1
+	return true, nil
+}
+
+func (m *memRepo) GetVersioned(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, int64, error) {
+	return m.config, m.version, nil
+}
+
+type mockMembershipRepo map[string]membershipdomain.Role
+
+func (m mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	role, ok := m[userID]
+	if !ok || orgID != "org-1" {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+type mockAuditLogger struct {
+	actions  []string
+	metadata []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+	m.metadata = append(m.metadata, metadata)
+}
+
+func newTestServer() (*Server, *memRepo, *mockAuditLogger) {
+	repo := &memRepo{
+		presets: []*domain.Preset{
+			{
+				Name:        "balanced",
+				DisplayName: "Balanced",
+				Description: "MFA on new devices.",
+				Config:      &orgpolicyconfigdomain.OrgPolicyConfig{AuthMfa: &orgpolicyconfigdomain.AuthMfa{MfaRequirement: "new_device"}},
+			},
+			{
+				Name:        "strict",
+				DisplayName: "Strict",
+				Description: "MFA on every sign-in.",
+				PolicyRules: "package ztcp.device_trust\n\ndefault mfa_required = true\n",
+				Config: &orgpolicyconfigdomain.OrgPolicyConfig{
+					AuthMfa:     &orgpolicyconfigdomain.AuthMfa{MfaRequirement: "always"},
+					SessionMgmt: &orgpolicyconfigdomain.SessionMgmt{SessionMaxTtl: "8h", IdleTimeout: "15m"},
+				},
+			},
+		},
+		version: 2,
+	}
+	auditLogger := &mockAuditLogger{}
+	members := mockMembershipRepo{"admin-1": membershipdomain.RoleAdmin, "member-1": membershipdomain.RoleMember}
+	srv := NewServer(policypreset.NewLibrary(repo, repo, nil, nil), members, auditLogger)
+	return srv, repo, auditLogger
+}
+
+func TestListPresets(t *testing.T) {
+	srv, _, _ := newTestServer()
+	ctx := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-1")
+
+	resp, err := srv.ListPresets(ctx, &policypresetv1.ListPresetsRequest{})
+	if err != nil {
+		t.Fatalf("ListPresets: %v", err)
+	}
+	if len(resp.GetPresets()) != 2 {
+		t.Fatalf("presets = %d, want 2", len(resp.GetPresets()))
+	}
+	strict := resp.GetPresets()[1]
+	if strict.GetName() != "strict" || strict.GetDisplayName() != "Strict" || strict.GetDescription() == "" || strict.GetPolicyRules() == "" {
+		t.Errorf("strict = %+v", strict)
+	}
+	if got := strict.GetSections(); len(got) != 2 || got[0] != "auth_mfa" || got[1] != "session_mgmt" {
+		t.Errorf("sections = %v, want [auth_mfa session_mgmt]", got)
+	}
+	if strict.GetConfig().GetSessionMgmt().GetSessionMaxTtl() != "8h" || strict.GetConfig().GetDeviceTrust() != nil {
+		t.Errorf("config should hold only the preset's sections, got %+v", strict.GetConfig())
+	}
+
+	if _, err := NewServer(nil, nil, nil).ListPresets(ctx, &policypresetv1.ListPresetsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("without library: code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+func TestApplyPreset(t *testing.T) {
+	srv, repo, auditLogger := newTestServer()
+	ctx := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+
+	resp, err := srv.ApplyPreset(ctx, &policypresetv1.ApplyPresetRequest{Preset: "strict"})
+	if err != nil {
+		t.Fatalf("ApplyPreset: %v", err)
+	}
+	if resp.GetPreset() != "strict" || resp.GetEtag() != "3" || resp.GetDisabledPolicies() != 1 {
+		t.Errorf("response = %+v", resp)
+	}
+	if resp.GetConfig().GetSessionMgmt().GetIdleTimeout() != "15m" || resp.GetConfig().GetDeviceTrust() == nil {
+		t.Errorf("config should be the applied config merged with defaults, got %+v", resp.GetConfig())
+	}
+	if p := resp.GetPolicy(); p == nil || p.GetOrgId() != "org-1" || !p.GetEnabled() {
+		t.Errorf("policy = %+v", p)
+	}
+	if repo.config.AuthMfa.MfaRequirement != "always" {
+		t.Errorf("stored auth_mfa = %+v", repo.config.AuthMfa)
+	}
+	if len(auditLogger.actions) != 1 || auditLogger.actions[0] != "policy_preset_applied" {
+		t.Errorf("audit actions = %v", auditLogger.actions)
+	}
+
+	resp, err = srv.ApplyPreset(ctx, &policypresetv1.ApplyPresetRequest{OrgId: "org-1", Preset: "balanced"})
+	if err != nil {
+		t.Fatalf("ApplyPreset(balanced): %v", err)
+	}
+	if resp.GetPolicy() != nil {
+		t.Errorf("preset without rules returned policy %+v", resp.GetPolicy())
+	}
+}
+
+func TestApplyPreset_Errors(t *testing.T) {
+	srv, repo, auditLogger := newTestServer()
+	admin := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-1")
+	member := interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-2")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *policypresetv1.ApplyPresetRequest
+		code codes.Code
+	}{
+		{"member", member, &policypresetv1.ApplyPresetRequest{Preset: "strict"}, codes.PermissionDenied},
+		{"other org", admin, &policypresetv1.ApplyPresetRequest{OrgId: "org-2", Preset: "strict"}, codes.PermissionDenied},
+		{"no preset", admin, &policypresetv1.ApplyPresetRequest{}, codes.InvalidArgument},
+		{"unknown preset", admin, &policypresetv1.ApplyPresetRequest{Preset: "lenient"}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		if _, err := srv.ApplyPreset(tt.ctx, tt.req); status.Code(err) != tt.code {
+			t.Errorf("%s: code = %v, want %v (%v)", tt.name, status.Code(err), tt.code, err)
+		}
+	}
+
+	repo.config = &orgpolicyconfigdomain.OrgPolicyConfig{ChangeApproval: &orgpolicyconfigdomain.ChangeApproval{Required: true}}
+	if _, err := srv.ApplyPreset(admin, &policypresetv1.ApplyPresetRequest{Preset: "strict"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("change approval required: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if repo.version != 2 || len(auditLogger.actions) != 0 {
+		t.Errorf("failed applies stored version %d, audited %v", repo.version, auditLogger.actions)
+	}
+}
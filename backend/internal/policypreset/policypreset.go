@@ -0,0 +1,142 @@
+// Package policypreset applies named presets ("strict", "balanced", "byod_friendly", ...) to orgs. A preset is stored
+// as platform data (table policy_presets) and bundles sections of the org policy config with an optional Rego
+// policy; applying it materializes the config, the MFA settings mirrored from it, and the policy for one org in one
+// step instead of a call per setting.
+package policypreset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	orgmfasettingsrepo "zero-trust-control-plane/backend/internal/orgmfasettings/repository"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	policydomain "zero-trust-control-plane/backend/internal/policy/domain"
+	"zero-trust-control-plane/backend/internal/policypreset/domain"
+	"zero-trust-control-plane/backend/internal/policypreset/repository"
+)
+
+// maxApplyAttempts bounds how often Apply re-applies a preset that lost a race with another config write.
+const maxApplyAttempts = 3
+
+var (
+	// ErrUnknownPreset is returned for a preset name that is not stored.
+	ErrUnknownPreset = errors.New("policypreset: unknown preset")
+	// ErrApprovalRequired is returned when the org's change_approval section requires a second admin for policy
+	// changes; presets are applied directly, so they cannot be applied to such an org.
+	ErrApprovalRequired = errors.New("policypreset: org requires approval for policy changes")
+	// ErrInvalidPreset is returned when a stored preset's config or Rego does not validate.
+	ErrInvalidPreset = errors.New("policypreset: invalid preset")
+	// ErrConflict is returned when the org's config kept changing concurrently while the preset was applied.
+	ErrConflict = errors.New("policypreset: org policy config is being changed concurrently")
+)
+
+// ConfigReader reads an org's policy config with its version (e.g. orgpolicyconfigrepo.Repository).
+type ConfigReader interface {
+	GetVersioned(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, int64, error)
+}
+
+// Library lists presets and applies them to orgs.
+type Library struct {
+	repo        repository.Repository
+	configs     ConfigReader
+	mfaSettings orgmfasettingsrepo.Repository
+	hub         *orgpolicyconfig.Hub
+}
+
+// NewLibrary returns a library backed by repo. mfaSettings receives the MFA settings of applied presets; pass the
+// cached repository so other instances pick them up at once. mfaSettings and hub may be nil.
+func NewLibrary(repo repository.Repository, configs ConfigReader, mfaSettings orgmfasettingsrepo.Repository, hub *orgpolicyconfig.Hub) *Library {
+	return &Library{repo: repo, configs: configs, mfaSettings: mfaSettings, hub: hub}
+}
+
+// List returns all presets, by name.
+func (l *Library) List(ctx context.Context) ([]*domain.Preset, error) {
+	return l.repo.List(ctx)
+}
+
+// Get returns the named preset, or ErrUnknownPreset.
+func (l *Library) Get(ctx context.Context, name string) (*domain.Preset, error) {
+	p, err := l.repo.GetByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrUnknownPreset
+	}
+	return p, nil
+}
+
+// Apply applies the named preset to orgID on behalf of userID: the preset's config sections replace the org's (the
+// other sections are kept) as a new config version with source preset, the org's enabled policies are disabled, and
+// the preset's policy, if any, is created enabled, all in one transaction. The org's MFA settings are then synced
+// from the new config and SubscribeBrowserPolicy streams are notified, as for UpdateOrgPolicyConfig.
+func (l *Library) Apply(ctx context.Context, orgID, userID, name string) (*domain.Application, error) {
+	preset, err := l.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := preset.Config.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPreset, preset.Name, err)
+	}
+	if preset.PolicyRules != "" {
+		if _, err := ast.ParseModule("", preset.PolicyRules); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPreset, preset.Name, err)
+		}
+	}
+	change := orgpolicyconfigdomain.Change{By: userID, Source: orgpolicyconfigdomain.ChangeSourcePreset}
+	for attempt := 1; ; attempt++ {
+		current, version, err := l.configs.GetVersioned(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if current.RequiresChangeApproval() {
+			return nil, ErrApprovalRequired
+		}
+		config, err := orgpolicyconfigdomain.ApplySections(current, preset.Config, preset.Config.SetSections())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPreset, preset.Name, err)
+		}
+		config = orgpolicyconfigdomain.MergeWithDefaults(config)
+		now := time.Now().UTC()
+		a := &domain.Application{
+			OrgID:        orgID,
+			Preset:       preset.Name,
+			PolicyConfig: config,
+			MFASettings:  orgpolicyconfigdomain.MFASettings(orgID, config, now),
+		}
+		if preset.PolicyRules != "" {
+			a.Policy = &policydomain.Policy{
+				ID:        uuid.New().String(),
+				OrgID:     orgID,
+				Rules:     preset.PolicyRules,
+				Enabled:   true,
+				CreatedAt: now,
+			}
+		}
+		ok, err := l.repo.Apply(ctx, a, version, change)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if l.mfaSettings != nil {
+				if err := l.mfaSettings.Upsert(ctx, a.MFASettings); err != nil {
+					return nil, fmt.Errorf("sync org MFA settings: %w", err)
+				}
+			}
+			if l.hub != nil {
+				l.hub.Publish(orgID)
+			}
+			return a, nil
+		}
+		if attempt == maxApplyAttempts {
+			return nil, ErrConflict
+		}
+	}
+}
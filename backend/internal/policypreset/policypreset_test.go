@@ -0,0 +1,187 @@
+package policypreset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/policypreset/domain"
+)
+
+const strictRules = `package ztcp.device_trust
+
+default mfa_required = true
+`
+
+// memRepo stores presets and one org's config; Apply fails the version check races times first.
+type memRepo struct {
+	presets map[string]*domain.Preset
+	config  *orgpolicyconfigdomain.OrgPolicyConfig
+	version int64
+	races   int
+	applied []*domain.Application
+	changes []orgpolicyconfigdomain.Change
+}
+
+func (m *memRepo) List(ctx context.Context) ([]*domain.Preset, error) {
+	var out []*domain.Preset
+	for _, p := range m.presets {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (m *memRepo) GetByName(ctx context.Context, name string) (*domain.Preset, error) {
+	return m.presets[name], nil
+}
+
+func (m *memRepo) Apply(ctx context.Context, a *domain.Application, baseVersion int64, change orgpolicyconfigdomain.Change) (bool, error) {
+	if m.races > 0 {
+		m.races--
+		m.version++
+		return false, nil
+	}
+	if baseVersion != m.version {
+		return false, nil
+	}
+	m.version++
+	m.config = a.PolicyConfig
+	a.PolicyConfigVersion = m.version
+	a.DisabledPolicies = 2
+	m.applied = append(m.applied, a)
+	m.changes = append(m.changes, change)
+	return true, nil
+}
+
+func (m *memRepo) GetVersioned(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, int64, error) {
+	return m.config, m.version, nil
+}
+
+type memMFA map[string]*orgmfasettingsdomain.OrgMFASettings
+
+func (m memMFA) GetByOrgID(ctx context.Context, orgID string) (*orgmfasettingsdomain.OrgMFASettings, error) {
+	return m[orgID], nil
+}
+
+func (m memMFA) Upsert(ctx context.Context, s *orgmfasettingsdomain.OrgMFASettings) error {
+	m[s.OrgID] = s
+	return nil
+}
+
+func newRepo() *memRepo {
+	return &memRepo{
+		presets: map[string]*domain.Preset{
+			"strict": {
+				Name:        "strict",
+				DisplayName: "Strict",
+				PolicyRules: strictRules,
+				Config: &orgpolicyconfigdomain.OrgPolicyConfig{
+					AuthMfa:     &orgpolicyconfigdomain.AuthMfa{MfaRequirement: "always"},
+					DeviceTrust: &orgpolicyconfigdomain.DeviceTrust{AutoTrustAfterMfa: false, ReverifyIntervalDays: 7},
+				},
+			},
+			"balanced": {
+				Name:   "balanced",
+				Config: &orgpolicyconfigdomain.OrgPolicyConfig{AuthMfa: &orgpolicyconfigdomain.AuthMfa{MfaRequirement: "new_device"}},
+			},
+		},
+		config: &orgpolicyconfigdomain.OrgPolicyConfig{
+			AuthMfa:       &orgpolicyconfigdomain.AuthMfa{MfaRequirement: "untrusted"},
+			AccessControl: &orgpolicyconfigdomain.AccessControl{DefaultAction: "deny", BlockedDomains: []string{"example.com"}},
+		},
+		version: 4,
+	}
+}
+
+func TestApply(t *testing.T) {
+	repo := newRepo()
+	mfa := memMFA{}
+	hub := orgpolicyconfig.NewHub()
+	updates, cancel := hub.Subscribe("org-1")
+	defer cancel()
+	l := NewLibrary(repo, repo, mfa, hub)
+
+	a, err := l.Apply(context.Background(), "org-1", "admin-1", " strict ")
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if a.Preset != "strict" || a.PolicyConfigVersion != 5 || a.DisabledPolicies != 2 {
+		t.Errorf("application = %+v", a)
+	}
+	if a.PolicyConfig.AuthMfa.MfaRequirement != "always" || a.PolicyConfig.DeviceTrust.ReverifyIntervalDays != 7 {
+		t.Errorf("preset sections not applied: auth_mfa %+v, device_trust %+v", a.PolicyConfig.AuthMfa, a.PolicyConfig.DeviceTrust)
+	}
+	if ac := a.PolicyConfig.AccessControl; ac.DefaultAction != "deny" || len(ac.BlockedDomains) != 1 {
+		t.Errorf("access_control not set by the preset should be kept, got %+v", ac)
+	}
+	if a.PolicyConfig.SessionMgmt == nil {
+		t.Error("stored config should be merged with defaults")
+	}
+	if a.Policy == nil || a.Policy.OrgID != "org-1" || !a.Policy.Enabled || a.Policy.Rules != strictRules || a.Policy.ID == "" {
+		t.Errorf("policy = %+v", a.Policy)
+	}
+	if c := repo.changes[0]; c.By != "admin-1" || c.Source != orgpolicyconfigdomain.ChangeSourcePreset {
+		t.Errorf("change = %+v", c)
+	}
+	s := mfa["org-1"]
+	if s == nil || !s.MFARequiredAlways || s.RegisterTrustAfterMFA || s.TrustTTLDays != 7 {
+		t.Errorf("MFA settings = %+v", s)
+	}
+	select {
+	case <-updates:
+	default:
+		t.Error("browser policy subscribers not notified")
+	}
+
+	a, err = l.Apply(context.Background(), "org-1", "admin-1", "balanced")
+	if err != nil {
+		t.Fatalf("Apply(balanced): %v", err)
+	}
+	if a.Policy != nil {
+		t.Errorf("preset without rules created policy %+v", a.Policy)
+	}
+	if a.PolicyConfig.DeviceTrust.ReverifyIntervalDays != 7 {
+		t.Errorf("device_trust not set by balanced should be kept, got %+v", a.PolicyConfig.DeviceTrust)
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newRepo()
+	l := NewLibrary(repo, repo, nil, nil)
+	if _, err := l.Apply(ctx, "org-1", "admin-1", "lenient"); !errors.Is(err, ErrUnknownPreset) {
+		t.Errorf("unknown preset: err = %v, want ErrUnknownPreset", err)
+	}
+
+	repo.config.ChangeApproval = &orgpolicyconfigdomain.ChangeApproval{Required: true}
+	if _, err := l.Apply(ctx, "org-1", "admin-1", "strict"); !errors.Is(err, ErrApprovalRequired) {
+		t.Errorf("approval required: err = %v, want ErrApprovalRequired", err)
+	}
+	repo.config.ChangeApproval = nil
+
+	repo.presets["broken"] = &domain.Preset{Name: "broken", PolicyRules: "package", Config: &orgpolicyconfigdomain.OrgPolicyConfig{}}
+	repo.presets["bad_config"] = &domain.Preset{Name: "bad_config", Config: &orgpolicyconfigdomain.OrgPolicyConfig{
+		DeviceTrust: &orgpolicyconfigdomain.DeviceTrust{TrustRenewal: "forever"},
+	}}
+	for _, name := range []string{"broken", "bad_config"} {
+		if _, err := l.Apply(ctx, "org-1", "admin-1", name); !errors.Is(err, ErrInvalidPreset) {
+			t.Errorf("%s: err = %v, want ErrInvalidPreset", name, err)
+		}
+	}
+	if len(repo.applied) != 0 {
+		t.Errorf("failed applies stored %d applications", len(repo.applied))
+	}
+
+	repo.races = 2
+	if _, err := l.Apply(ctx, "org-1", "admin-1", "strict"); err != nil {
+		t.Errorf("Apply after two lost races: %v", err)
+	}
+	repo.races = maxApplyAttempts
+	if _, err := l.Apply(ctx, "org-1", "admin-1", "strict"); !errors.Is(err, ErrConflict) {
+		t.Errorf("Apply always losing the race: err = %v, want ErrConflict", err)
+	}
+}
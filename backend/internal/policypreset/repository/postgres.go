@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/policypreset/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a policy preset repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db)}
+}
+
+// List returns all presets, by name.
+func (r *PostgresRepository) List(ctx context.Context) ([]*domain.Preset, error) {
+	rows, err := r.queries.ListPolicyPresets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Preset, len(rows))
+	for i := range rows {
+		if out[i], err = genPresetToDomain(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// GetByName returns the preset, or nil if not found.
+func (r *PostgresRepository) GetByName(ctx context.Context, name string) (*domain.Preset, error) {
+	row, err := r.queries.GetPolicyPreset(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genPresetToDomain(&row)
+}
+
+// Apply stores the config, its history entry and the policy swap in one transaction.
+func (r *PostgresRepository) Apply(ctx context.Context, a *domain.Application, baseVersion int64, change orgpolicyconfigdomain.Change) (bool, error) {
+	raw, err := json.Marshal(orgpolicyconfigdomain.MergeWithDefaults(a.PolicyConfig))
+	if err != nil {
+		return false, err
+	}
+	now := time.Now().UTC()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	var version int64
+	if baseVersion == 0 {
+		version, err = q.InsertOrgPolicyConfigIfAbsent(ctx, gen.InsertOrgPolicyConfigIfAbsentParams{
+			OrgID:      a.OrgID,
+			ConfigJson: string(raw),
+			UpdatedAt:  now,
+		})
+	} else {
+		version, err = q.UpdateOrgPolicyConfigIfVersion(ctx, gen.UpdateOrgPolicyConfigIfVersionParams{
+			OrgID:      a.OrgID,
+			ConfigJson: string(raw),
+			UpdatedAt:  now,
+			Version:    baseVersion,
+		})
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := q.CreateOrgPolicyConfigVersion(ctx, gen.CreateOrgPolicyConfigVersionParams{
+		OrgID:      a.OrgID,
+		Version:    version,
+		ConfigJson: string(raw),
+		ChangedBy:  sql.NullString{String: change.By, Valid: change.By != ""},
+		ChangedAt:  now,
+		Source:     change.Source,
+	}); err != nil {
+		return false, err
+	}
+	disabled, err := q.DisableOrgPolicies(ctx, a.OrgID)
+	if err != nil {
+		return false, err
+	}
+	if p := a.Policy; p != nil {
+		if _, err := q.CreatePolicy(ctx, gen.CreatePolicyParams{
+			ID: p.ID, OrgID: p.OrgID, Rules: p.Rules, Enabled: p.Enabled, CreatedAt: p.CreatedAt,
+		}); err != nil {
+			return false, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	a.PolicyConfigVersion = version
+	a.DisabledPolicies = disabled
+	return true, nil
+}
+
+func genPresetToDomain(row *gen.PolicyPreset) (*domain.Preset, error) {
+	var config orgpolicyconfigdomain.OrgPolicyConfig
+	if err := json.Unmarshal([]byte(row.ConfigJson), &config); err != nil {
+		return nil, err
+	}
+	return &domain.Preset{
+		Name:        row.Name,
+		DisplayName: row.DisplayName,
+		Description: row.Description,
+		PolicyRules: row.PolicyRules,
+		Config:      &config,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}, nil
+}
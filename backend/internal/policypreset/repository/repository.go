@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/policypreset/domain"
+)
+
+// Repository reads policy presets and applies them to orgs.
+type Repository interface {
+	// List returns all presets, by name.
+	List(ctx context.Context) ([]*domain.Preset, error)
+	// GetByName returns the preset, or nil if not found.
+	GetByName(ctx context.Context, name string) (*domain.Preset, error)
+	// Apply stores a.PolicyConfig if the org's config is still at baseVersion (0 = no stored config), recording the
+	// new version with change, disables the org's enabled policies and creates a.Policy, all in one transaction. It
+	// sets a.PolicyConfigVersion and a.DisabledPolicies. ok is false, and nothing is stored, when the config's version
+	// has changed.
+	Apply(ctx context.Context, a *domain.Application, baseVersion int64, change orgpolicyconfigdomain.Change) (ok bool, err error)
+}
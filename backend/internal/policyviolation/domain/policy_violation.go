@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// Actions an agent can report as blocked. They mirror action_restrictions.allowed_actions in org policy config.
+const (
+	ActionNavigate  = "navigate"
+	ActionDownload  = "download"
+	ActionUpload    = "upload"
+	ActionCopyPaste = "copy_paste"
+)
+
+// Rules of action_restrictions an agent can report as the reason it blocked an action. An empty rule means the
+// action is not in allowed_actions or read_only_mode is on. They mirror the DLP fields in org policy config.
+const (
+	RuleMaxUploadBytes          = "max_upload_bytes"
+	RuleBlockedFileExtensions   = "blocked_file_extensions"
+	RuleClipboardBlockedDomains = "clipboard_blocked_domains"
+)
+
+// MaxTargetLength caps the reported URL or resource so agents cannot store arbitrary blobs.
+const MaxTargetLength = 2048
+
+// IsValidAction reports whether action is one of the restrictable browser actions.
+func IsValidAction(action string) bool {
+	switch action {
+	case ActionNavigate, ActionDownload, ActionUpload, ActionCopyPaste:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidRule reports whether rule is empty or a DLP rule that can block action: max_upload_bytes blocks uploads,
+// blocked_file_extensions uploads and downloads, and clipboard_blocked_domains copy_paste.
+func IsValidRule(rule, action string) bool {
+	switch rule {
+	case "":
+		return true
+	case RuleMaxUploadBytes:
+		return action == ActionUpload
+	case RuleBlockedFileExtensions:
+		return action == ActionUpload || action == ActionDownload
+	case RuleClipboardBlockedDomains:
+		return action == ActionCopyPaste
+	default:
+		return false
+	}
+}
+
+// PolicyViolation is one action an agent blocked under the org's action restrictions.
+// DeviceID and SessionID identify the reporting session, not values supplied by the agent.
+type PolicyViolation struct {
+	ID              string
+	OrgID           string
+	UserID          string
+	DeviceID        string
+	SessionID       string
+	Action          string
+	Rule            string // DLP rule that blocked the action; empty for allowed_actions and read_only_mode
+	Target          string // URL or resource; empty when the agent did not send one
+	StepUpTriggered bool   // session revoked and device trust cleared (auth_mfa.step_up_policy_violation)
+	CreatedAt       time.Time
+}
+
+// ListFilter narrows ListByOrg. Empty fields match everything.
+type ListFilter struct {
+	UserID   string
+	DeviceID string
+	Action   string
+	Rule     string
+}
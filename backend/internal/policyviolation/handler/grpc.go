@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	policyviolationv1 "zero-trust-control-plane/backend/api/generated/policyviolation/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/policyviolation/domain"
+	"zero-trust-control-plane/backend/internal/policyviolation/repository"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// SessionStore is the subset of the session repository used to resolve the reporting device and revoke on step-up.
+type SessionStore interface {
+	GetByID(ctx context.Context, id string) (*sessiondomain.Session, error)
+	Revoke(ctx context.Context, id string, rev sessiondomain.Revocation) error
+}
+
+// DeviceTrustUpdater clears device trust on step-up so the next sign-in from the device requires MFA.
+type DeviceTrustUpdater interface {
+	UpdateTrustedWithExpiry(ctx context.Context, id string, trusted bool, trustedUntil *time.Time) error
+}
+
+// PolicyConfigGetter loads org policy config to decide whether a violation triggers step-up.
+type PolicyConfigGetter interface {
+	GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error)
+}
+
+// Server implements PolicyViolationService (proto server).
+// Proto: policyviolation/policyviolation.proto → internal/policyviolation/handler.
+type Server struct {
+	policyviolationv1.UnimplementedPolicyViolationServiceServer
+	repo           repository.Repository
+	membershipRepo rbac.OrgMembershipGetter
+	sessions       SessionStore
+	devices        DeviceTrustUpdater
+	configRepo     PolicyConfigGetter
+	auditLogger    audit.AuditLogger
+}
+
+// NewServer returns a new PolicyViolation gRPC server. If repo is nil, all RPCs return Unimplemented.
+// sessions resolves the reporting device; when nil, violations are stored without a device ID and step-up is skipped.
+// configRepo enables step-up (auth_mfa.step_up_policy_violation); devices and auditLogger may be nil.
+func NewServer(repo repository.Repository, membershipRepo rbac.OrgMembershipGetter, sessions SessionStore, devices DeviceTrustUpdater, configRepo PolicyConfigGetter, auditLogger audit.AuditLogger) *Server {
+	return &Server{
+		repo:           repo,
+		membershipRepo: membershipRepo,
+		sessions:       sessions,
+		devices:        devices,
+		configRepo:     configRepo,
+		auditLogger:    auditLogger,
+	}
+}
+
+// ReportPolicyViolation records an action the caller's agent blocked. Org, user, session, and device come from
+// the access token. When the org requires step-up on policy violation, the session is revoked and the device's
+// trust cleared, so the user must sign in again with MFA.
+func (s *Server) ReportPolicyViolation(ctx context.Context, req *policyviolationv1.ReportPolicyViolationRequest) (*policyviolationv1.ReportPolicyViolationResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ReportPolicyViolation not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
+	}
+	if !domain.IsValidAction(req.GetAction()) {
+		return nil, status.Error(codes.InvalidArgument, "action must be one of navigate, download, upload, copy_paste")
+	}
+	if !domain.IsValidRule(req.GetRule(), req.GetAction()) {
+		return nil, status.Error(codes.InvalidArgument, "rule must be empty or max_upload_bytes (upload), blocked_file_extensions (upload, download) or clipboard_blocked_domains (copy_paste)")
+	}
+	if len(req.GetTarget()) > domain.MaxTargetLength {
+		return nil, status.Errorf(codes.InvalidArgument, "target must be at most %d characters", domain.MaxTargetLength)
+	}
+	sessionID, _ := interceptors.GetSessionID(ctx)
+	if sessionID == "" {
+		return nil, status.Error(codes.Unauthenticated, "session context required")
+	}
+	var deviceID string
+	if s.sessions != nil {
+		sess, err := s.sessions.GetByID(ctx, sessionID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to load session")
+		}
+		if sess != nil {
+			deviceID = sess.DeviceID
+		}
+	}
+	stepUp, err := s.stepUpRequired(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	v := &domain.PolicyViolation{
+		ID:              uuid.New().String(),
+		OrgID:           orgID,
+		UserID:          userID,
+		DeviceID:        deviceID,
+		SessionID:       sessionID,
+		Action:          req.GetAction(),
+		Rule:            req.GetRule(),
+		Target:          req.GetTarget(),
+		StepUpTriggered: stepUp,
+		CreatedAt:       time.Now().UTC(),
+	}
+	if err := s.repo.Create(ctx, v); err != nil {
+		if errors.Is(err, residency.ErrRegionUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, "org data region is not available")
+		}
+		return nil, status.Error(codes.Internal, "failed to record policy violation")
+	}
+	if stepUp {
+		s.stepUp(ctx, v)
+	}
+	return &policyviolationv1.ReportPolicyViolationResponse{ViolationId: v.ID, StepUpRequired: stepUp}, nil
+}
+
+// ListPolicyViolations returns the org's reported violations, newest first. Caller must be org admin or owner.
+func (s *Server) ListPolicyViolations(ctx context.Context, req *policyviolationv1.ListPolicyViolationsRequest) (*policyviolationv1.ListPolicyViolationsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListPolicyViolations not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
+	}
+	if a := req.GetAction(); a != "" && !domain.IsValidAction(a) {
+		return nil, status.Error(codes.InvalidArgument, "action must be one of navigate, download, upload, copy_paste")
+	}
+	switch req.GetRule() {
+	case "", domain.RuleMaxUploadBytes, domain.RuleBlockedFileExtensions, domain.RuleClipboardBlockedDomains:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "rule must be one of max_upload_bytes, blocked_file_extensions, clipboard_blocked_domains")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	filter := domain.ListFilter{UserID: req.GetUserId(), DeviceID: req.GetDeviceId(), Action: req.GetAction(), Rule: req.GetRule()}
+	list, err := s.repo.ListByOrg(ctx, orgID, filter, pageSize, offset)
+	if err != nil {
+		if errors.Is(err, residency.ErrRegionUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, "org data region is not available")
+		}
+		return nil, status.Error(codes.Internal, "failed to list policy violations")
+	}
+	violations := make([]*policyviolationv1.PolicyViolation, len(list))
+	for i, v := range list {
+		violations[i] = violationToProto(v)
+	}
+	result := &policyviolationv1.ListPolicyViolationsResponse{
+		Violations: violations,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// stepUpRequired reports whether the org has auth_mfa.step_up_policy_violation enabled.
+// Without a session store there is nothing to revoke, so step-up is never triggered.
+func (s *Server) stepUpRequired(ctx context.Context, orgID string) (bool, error) {
+	if s.configRepo == nil || s.sessions == nil {
+		return false, nil
+	}
+	cfg, err := s.configRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return false, status.Error(codes.Internal, "failed to load org policy config")
+	}
+	merged := orgpolicyconfigdomain.MergeWithDefaults(cfg)
+	return merged.AuthMfa != nil && merged.AuthMfa.StepUpPolicyViolation, nil
+}
+
+// stepUp revokes the reporting session and clears the device's trust. Best-effort: the violation is already
+// recorded, and failures are logged rather than returned to the agent.
+func (s *Server) stepUp(ctx context.Context, v *domain.PolicyViolation) {
+	rev := sessiondomain.Revocation{Reason: sessiondomain.RevocationPolicyChange}
+	if err := s.sessions.Revoke(ctx, v.SessionID, rev); err != nil {
+		log.Printf("policyviolation: step-up revoke session %s: %v", v.SessionID, err)
+	}
+	if s.devices != nil && v.DeviceID != "" {
+		if err := s.devices.UpdateTrustedWithExpiry(ctx, v.DeviceID, false, nil); err != nil {
+			log.Printf("policyviolation: step-up clear trust for device %s: %v", v.DeviceID, err)
+		}
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"action": v.Action, "rule": v.Rule, "violation_id": v.ID, "device_id": v.DeviceID, "reason": string(rev.Reason)})
+		s.auditLogger.LogEvent(ctx, v.OrgID, v.UserID, "policy_violation_step_up", "session", string(meta))
+	}
+}
+
+func violationToProto(v *domain.PolicyViolation) *policyviolationv1.PolicyViolation {
+	return &policyviolationv1.PolicyViolation{
+		Id:              v.ID,
+		OrgId:           v.OrgID,
+		UserId:          v.UserID,
+		DeviceId:        v.DeviceID,
+		SessionId:       v.SessionID,
+		Action:          v.Action,
+		Rule:            v.Rule,
+		Target:          v.Target,
+		StepUpTriggered: v.StepUpTriggered,
+		CreatedAt:       timestamppb.New(v.CreatedAt),
+	}
+}
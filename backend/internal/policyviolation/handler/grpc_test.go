@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	policyviolationv1 "zero-trust-control-plane/backend/api/generated/policyviolation/v1"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/policyviolation/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+type mockViolationRepo struct {
+	created   []*domain.PolicyViolation
+	gotFilter domain.ListFilter
+	gotLimit  int32
+}
+
+func (m *mockViolationRepo) Create(ctx context.Context, v *domain.PolicyViolation) error {
+	m.created = append(m.created, v)
+	return nil
+}
+
+func (m *mockViolationRepo) ListByOrg(ctx context.Context, orgID string, filter domain.ListFilter, limit, offset int32) ([]*domain.PolicyViolation, error) {
+	m.gotFilter, m.gotLimit = filter, limit
+	var out []*domain.PolicyViolation
+	for _, v := range m.created {
+		if v.OrgID == orgID {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+type mockMembershipRepo struct {
+	memberships map[string]*membershipdomain.Membership
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	return m.memberships[userID+":"+orgID], nil
+}
+
+type mockSessionStore struct {
+	sessions map[string]*sessiondomain.Session
+	revoked  []string
+	reasons  []sessiondomain.RevocationReason
+}
+
+func (m *mockSessionStore) GetByID(ctx context.Context, id string) (*sessiondomain.Session, error) {
+	return m.sessions[id], nil
+}
+
+func (m *mockSessionStore) Revoke(ctx context.Context, id string, rev sessiondomain.Revocation) error {
+	m.revoked = append(m.revoked, id)
+	m.reasons = append(m.reasons, rev.Reason)
+	return nil
+}
+
+type mockDeviceTrust struct {
+	untrusted []string
+}
+
+func (m *mockDeviceTrust) UpdateTrustedWithExpiry(ctx context.Context, id string, trusted bool, trustedUntil *time.Time) error {
+	if !trusted {
+		m.untrusted = append(m.untrusted, id)
+	}
+	return nil
+}
+
+type staticConfigRepo struct {
+	cfg *orgpolicyconfigdomain.OrgPolicyConfig
+}
+
+func (r staticConfigRepo) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	return r.cfg, nil
+}
+
+type mockAuditLogger struct {
+	actions []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+}
+
+type testEnv struct {
+	srv      *Server
+	repo     *mockViolationRepo
+	sessions *mockSessionStore
+	devices  *mockDeviceTrust
+	audit    *mockAuditLogger
+}
+
+func newTestEnv(stepUp bool) *testEnv {
+	env := &testEnv{
+		repo: &mockViolationRepo{},
+		sessions: &mockSessionStore{sessions: map[string]*sessiondomain.Session{
+			"session-1": {ID: "session-1", UserID: "member-1", OrgID: "org-1", DeviceID: "device-1"},
+		}},
+		devices: &mockDeviceTrust{},
+		audit:   &mockAuditLogger{},
+	}
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"admin-1:org-1":  {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"member-1:org-1": {ID: "m2", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}}
+	authMfa := orgpolicyconfigdomain.DefaultAuthMfa()
+	authMfa.StepUpPolicyViolation = stepUp
+	cfg := &orgpolicyconfigdomain.OrgPolicyConfig{AuthMfa: &authMfa}
+	env.srv = NewServer(env.repo, membershipRepo, env.sessions, env.devices, staticConfigRepo{cfg: cfg}, env.audit)
+	return env
+}
+
+func memberCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "member-1", "org-1", "session-1")
+}
+
+func TestReportPolicyViolation_RecordsSessionDevice(t *testing.T) {
+	env := newTestEnv(false)
+	resp, err := env.srv.ReportPolicyViolation(memberCtx(), &policyviolationv1.ReportPolicyViolationRequest{
+		Action: domain.ActionDownload, Target: "https://files.example.com/report.pdf",
+	})
+	if err != nil {
+		t.Fatalf("ReportPolicyViolation: %v", err)
+	}
+	if resp.StepUpRequired {
+		t.Error("step_up_required should be false when the org does not require it")
+	}
+	if len(env.repo.created) != 1 {
+		t.Fatalf("created %d violations, want 1", len(env.repo.created))
+	}
+	v := env.repo.created[0]
+	if v.ID != resp.ViolationId || v.OrgID != "org-1" || v.UserID != "member-1" || v.SessionID != "session-1" || v.DeviceID != "device-1" {
+		t.Errorf("violation = %+v", v)
+	}
+	if len(env.sessions.revoked) != 0 || len(env.devices.untrusted) != 0 {
+		t.Error("session and device should be untouched without step-up")
+	}
+}
+
+func TestReportPolicyViolation_StepUp(t *testing.T) {
+	env := newTestEnv(true)
+	resp, err := env.srv.ReportPolicyViolation(memberCtx(), &policyviolationv1.ReportPolicyViolationRequest{Action: domain.ActionCopyPaste})
+	if err != nil {
+		t.Fatalf("ReportPolicyViolation: %v", err)
+	}
+	if !resp.StepUpRequired || !env.repo.created[0].StepUpTriggered {
+		t.Error("step-up should be triggered")
+	}
+	if len(env.sessions.revoked) != 1 || env.sessions.revoked[0] != "session-1" {
+		t.Errorf("revoked sessions = %v, want [session-1]", env.sessions.revoked)
+	}
+	if len(env.sessions.reasons) != 1 || env.sessions.reasons[0] != sessiondomain.RevocationPolicyChange {
+		t.Errorf("revocation reasons = %v, want [policy_change]", env.sessions.reasons)
+	}
+	if len(env.devices.untrusted) != 1 || env.devices.untrusted[0] != "device-1" {
+		t.Errorf("untrusted devices = %v, want [device-1]", env.devices.untrusted)
+	}
+	if len(env.audit.actions) != 1 || env.audit.actions[0] != "policy_violation_step_up" {
+		t.Errorf("audit actions = %v", env.audit.actions)
+	}
+}
+
+func TestReportPolicyViolation_InvalidRequest(t *testing.T) {
+	env := newTestEnv(false)
+	for _, req := range []*policyviolationv1.ReportPolicyViolationRequest{
+		{},
+		{Action: "print"},
+		{Action: domain.ActionUpload, Target: strings.Repeat("a", domain.MaxTargetLength+1)},
+	} {
+		_, err := env.srv.ReportPolicyViolation(memberCtx(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("action %q: code = %v, want InvalidArgument", req.Action, status.Code(err))
+		}
+	}
+	_, err := env.srv.ReportPolicyViolation(memberCtx(), &policyviolationv1.ReportPolicyViolationRequest{OrgId: "org-2", Action: domain.ActionNavigate})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("org mismatch: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if len(env.repo.created) != 0 {
+		t.Errorf("created %d violations for invalid requests", len(env.repo.created))
+	}
+}
+
+func TestReportPolicyViolation_DLPRule(t *testing.T) {
+	env := newTestEnv(false)
+	resp, err := env.srv.ReportPolicyViolation(memberCtx(), &policyviolationv1.ReportPolicyViolationRequest{
+		Action: domain.ActionDownload, Rule: domain.RuleBlockedFileExtensions, Target: "https://files.example.com/setup.exe",
+	})
+	if err != nil {
+		t.Fatalf("ReportPolicyViolation: %v", err)
+	}
+	if len(env.repo.created) != 1 || env.repo.created[0].Rule != domain.RuleBlockedFileExtensions || env.repo.created[0].ID != resp.ViolationId {
+		t.Fatalf("created = %+v", env.repo.created)
+	}
+	for _, req := range []*policyviolationv1.ReportPolicyViolationRequest{
+		{Action: domain.ActionUpload, Rule: "max_file_count"},
+		{Action: domain.ActionDownload, Rule: domain.RuleMaxUploadBytes},
+		{Action: domain.ActionNavigate, Rule: domain.RuleBlockedFileExtensions},
+		{Action: domain.ActionUpload, Rule: domain.RuleClipboardBlockedDomains},
+	} {
+		_, err := env.srv.ReportPolicyViolation(memberCtx(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("action %q rule %q: code = %v, want InvalidArgument", req.Action, req.Rule, status.Code(err))
+		}
+	}
+	adminCtx := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-2")
+	list, err := env.srv.ListPolicyViolations(adminCtx, &policyviolationv1.ListPolicyViolationsRequest{Rule: domain.RuleBlockedFileExtensions})
+	if err != nil {
+		t.Fatalf("ListPolicyViolations: %v", err)
+	}
+	if env.repo.gotFilter.Rule != domain.RuleBlockedFileExtensions || len(list.Violations) != 1 || list.Violations[0].Rule != domain.RuleBlockedFileExtensions {
+		t.Errorf("filter = %+v, violations = %v", env.repo.gotFilter, list.Violations)
+	}
+	if _, err := env.srv.ListPolicyViolations(adminCtx, &policyviolationv1.ListPolicyViolationsRequest{Rule: "max_file_count"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown rule filter: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestListPolicyViolations_AdminOnly(t *testing.T) {
+	env := newTestEnv(false)
+	if _, err := env.srv.ReportPolicyViolation(memberCtx(), &policyviolationv1.ReportPolicyViolationRequest{Action: domain.ActionUpload}); err != nil {
+		t.Fatalf("ReportPolicyViolation: %v", err)
+	}
+	_, err := env.srv.ListPolicyViolations(memberCtx(), &policyviolationv1.ListPolicyViolationsRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member: code = %v, want PermissionDenied", status.Code(err))
+	}
+	adminCtx := interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "session-2")
+	resp, err := env.srv.ListPolicyViolations(adminCtx, &policyviolationv1.ListPolicyViolationsRequest{UserId: "member-1", Action: domain.ActionUpload})
+	if err != nil {
+		t.Fatalf("ListPolicyViolations: %v", err)
+	}
+	if len(resp.Violations) != 1 || resp.Violations[0].DeviceId != "device-1" {
+		t.Errorf("violations = %v", resp.Violations)
+	}
+	if env.repo.gotFilter.UserID != "member-1" || env.repo.gotFilter.Action != domain.ActionUpload || env.repo.gotLimit != defaultPageSize {
+		t.Errorf("filter = %+v, limit = %d", env.repo.gotFilter, env.repo.gotLimit)
+	}
+}
+
+func TestPolicyViolation_NilRepo(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, nil)
+	_, err := srv.ReportPolicyViolation(memberCtx(), &policyviolationv1.ReportPolicyViolationRequest{Action: domain.ActionDownload})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
+	}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/policyviolation/domain"
+	"zero-trust-control-plane/backend/internal/residency"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+	router  *residency.Router
+}
+
+// NewPostgresRepository returns a policy violation repository that uses the given db for persistence. When router
+// is not nil, an org's violations are stored in the database of its data region.
+func NewPostgresRepository(db *sql.DB, router *residency.Router) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), router: router}
+}
+
+// queriesFor returns the queries for the database holding orgID's violations.
+func (r *PostgresRepository) queriesFor(ctx context.Context, orgID string) (*gen.Queries, error) {
+	if r.router == nil {
+		return r.queries, nil
+	}
+	db, err := r.router.DB(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return gen.New(db), nil
+}
+
+// Create persists the policy violation. The violation must have ID set.
+func (r *PostgresRepository) Create(ctx context.Context, v *domain.PolicyViolation) error {
+	q, err := r.queriesFor(ctx, v.OrgID)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreatePolicyViolation(ctx, gen.CreatePolicyViolationParams{
+		ID:              v.ID,
+		OrgID:           v.OrgID,
+		UserID:          v.UserID,
+		DeviceID:        v.DeviceID,
+		SessionID:       v.SessionID,
+		Action:          v.Action,
+		Target:          nullString(v.Target),
+		StepUpTriggered: v.StepUpTriggered,
+		CreatedAt:       v.CreatedAt,
+		Rule:            v.Rule,
+	})
+	return err
+}
+
+// ListByOrg returns the org's violations matching filter, newest first, paginated by limit and offset.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, filter domain.ListFilter, limit, offset int32) ([]*domain.PolicyViolation, error) {
+	q, err := r.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListPolicyViolationsByOrg(ctx, gen.ListPolicyViolationsByOrgParams{
+		OrgID:          orgID,
+		Limit:          limit,
+		Offset:         offset,
+		FilterUserID:   nullString(filter.UserID),
+		FilterDeviceID: nullString(filter.DeviceID),
+		FilterAction:   nullString(filter.Action),
+		FilterRule:     nullString(filter.Rule),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.PolicyViolation, len(rows))
+	for i := range rows {
+		out[i] = genPolicyViolationToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+func genPolicyViolationToDomain(v *gen.PolicyViolation) *domain.PolicyViolation {
+	return &domain.PolicyViolation{
+		ID:              v.ID,
+		OrgID:           v.OrgID,
+		UserID:          v.UserID,
+		DeviceID:        v.DeviceID,
+		SessionID:       v.SessionID,
+		Action:          v.Action,
+		Target:          v.Target.String,
+		Rule:            v.Rule,
+		StepUpTriggered: v.StepUpTriggered,
+		CreatedAt:       v.CreatedAt,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
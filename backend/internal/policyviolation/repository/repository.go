@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/policyviolation/domain"
+)
+
+// Repository persists agent-reported policy violations.
+type Repository interface {
+	// Create persists the violation. The violation must have ID set.
+	Create(ctx context.Context, v *domain.PolicyViolation) error
+	// ListByOrg returns the org's violations matching filter, newest first.
+	ListByOrg(ctx context.Context, orgID string, filter domain.ListFilter, limit, offset int32) ([]*domain.PolicyViolation, error)
+}
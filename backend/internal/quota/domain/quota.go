@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// Override is an org's quota assignment (one row per org): its plan and optional overrides of the plan's quotas.
+type Override struct {
+	OrgID string
+	// Plan is the name of the org's plan; empty uses the default plan.
+	Plan string
+	// OrgRequestsPerMinute, if set, replaces the plan's org quota (0 = unlimited).
+	OrgRequestsPerMinute *int
+	// TokenRequestsPerMinute, if set, replaces the plan's per-session quota (0 = unlimited).
+	TokenRequestsPerMinute *int
+	UpdatedBy              string
+	UpdatedAt              time.Time
+}
+
+// Quota scopes: the requests of all of an org's sessions, or of one session's tokens.
+const (
+	ScopeOrg   = "org"
+	ScopeToken = "token"
+)
+
+// Limits are the quotas in effect for an org, in requests per minute (0 = unlimited).
+type Limits struct {
+	// Plan is the plan the limits come from, after falling back to the default plan.
+	Plan                   string
+	OrgRequestsPerMinute   int
+	TokenRequestsPerMinute int
+}
+
+// Decision is the outcome of counting a request against an org's quotas.
+type Decision struct {
+	Allowed bool
+	// Scope, Limit and RetryAfter describe the exceeded quota of a rejected request.
+	Scope      string
+	Limit      int
+	RetryAfter time.Duration
+}
+
+// Usage is an org's quota usage on one server instance.
+type Usage struct {
+	// OrgRequests is the number of requests counted against the org quota in the current window.
+	OrgRequests int
+	// Allowed, RejectedOrg and RejectedToken count requests since the instance started.
+	Allowed       uint64
+	RejectedOrg   uint64
+	RejectedToken uint64
+}
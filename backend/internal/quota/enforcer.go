@@ -0,0 +1,222 @@
+// Package quota enforces API quotas: requests per minute per org and per session (access token), with the limits
+// taken from the org's plan (see platform/plans) and optional per-org overrides. Counters live in memory, so quotas
+// apply per server instance, like the brute-force limits of platform/ratelimit.
+package quota
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/plans"
+	"zero-trust-control-plane/backend/internal/platform/ratelimit"
+	"zero-trust-control-plane/backend/internal/quota/domain"
+)
+
+// DefaultCacheTTL is how long an org's override is served from memory before it is reloaded. Overrides changed on
+// another instance apply within this time.
+const DefaultCacheTTL = 30 * time.Second
+
+// window is the length of a quota window; quotas are per minute.
+const window = time.Minute
+
+// Store loads and saves per-org overrides (e.g. the quota repository).
+type Store interface {
+	Get(ctx context.Context, orgID string) (*domain.Override, error)
+	Upsert(ctx context.Context, o *domain.Override) error
+	Delete(ctx context.Context, orgID string) error
+}
+
+type cachedOverride struct {
+	override *domain.Override
+	loadedAt time.Time
+}
+
+// Enforcer counts requests against the quotas of their org and session. A nil *Enforcer allows everything. Safe for
+// concurrent use.
+type Enforcer struct {
+	store  Store
+	ttl    time.Duration
+	now    func() time.Time
+	orgs   *ratelimit.Limiter
+	tokens *ratelimit.Limiter
+
+	mu          sync.Mutex
+	plans       map[string]plans.Plan
+	defaultPlan string
+	overrides   map[string]cachedOverride
+	usage       map[string]*domain.Usage
+}
+
+// NewEnforcer returns an Enforcer with the given plan table and default plan. ttl <= 0 uses DefaultCacheTTL.
+func NewEnforcer(store Store, planTable map[string]plans.Plan, defaultPlan string, ttl time.Duration) *Enforcer {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Enforcer{
+		store:       store,
+		ttl:         ttl,
+		now:         time.Now,
+		orgs:        ratelimit.NewLimiter(0, window),
+		tokens:      ratelimit.NewLimiter(0, window),
+		plans:       planTable,
+		defaultPlan: defaultPlan,
+		overrides:   make(map[string]cachedOverride),
+		usage:       make(map[string]*domain.Usage),
+	}
+}
+
+// SetPlans replaces the plan table and default plan (e.g. on config reload). Counters in progress are kept and
+// judged against the new limits.
+func (e *Enforcer) SetPlans(planTable map[string]plans.Plan, defaultPlan string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.plans = planTable
+	e.defaultPlan = defaultPlan
+}
+
+// Plans returns the plans ordered by name and the name of the default plan.
+func (e *Enforcer) Plans() ([]plans.Plan, string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return plans.Sorted(e.plans), e.defaultPlan
+}
+
+// HasPlan reports whether name is a plan of the current plan table.
+func (e *Enforcer) HasPlan(name string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.plans[name]
+	return ok
+}
+
+// Check counts a request of the session (sessionID may be empty) in orgID and reports whether it is within quota.
+// The session quota is checked first, so requests rejected for one session do not use up the org's quota.
+func (e *Enforcer) Check(ctx context.Context, orgID, sessionID string) domain.Decision {
+	if e == nil || orgID == "" {
+		return domain.Decision{Allowed: true}
+	}
+	limits := e.Limits(ctx, orgID)
+	d := domain.Decision{Allowed: true}
+	if sessionID != "" {
+		if ok, retryAfter := e.tokens.AllowWithLimit(sessionID, limits.TokenRequestsPerMinute); !ok {
+			d = domain.Decision{Scope: domain.ScopeToken, Limit: limits.TokenRequestsPerMinute, RetryAfter: retryAfter}
+		}
+	}
+	if d.Allowed {
+		if ok, retryAfter := e.orgs.AllowWithLimit(orgID, limits.OrgRequestsPerMinute); !ok {
+			d = domain.Decision{Scope: domain.ScopeOrg, Limit: limits.OrgRequestsPerMinute, RetryAfter: retryAfter}
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	u, ok := e.usage[orgID]
+	if !ok {
+		u = &domain.Usage{}
+		e.usage[orgID] = u
+	}
+	switch {
+	case d.Allowed:
+		u.Allowed++
+	case d.Scope == domain.ScopeToken:
+		u.RejectedToken++
+	default:
+		u.RejectedOrg++
+	}
+	return d
+}
+
+// Limits returns the quotas in effect for orgID: those of its plan, or of the default plan when it has none or its
+// plan is no longer configured, with the org's overrides applied. When the override cannot be loaded the last
+// known one is used.
+func (e *Enforcer) Limits(ctx context.Context, orgID string) domain.Limits {
+	o := e.cachedOverride(ctx, orgID)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	name := e.defaultPlan
+	if o != nil && o.Plan != "" {
+		if _, ok := e.plans[o.Plan]; ok {
+			name = o.Plan
+		}
+	}
+	p := e.plans[name]
+	limits := domain.Limits{Plan: name, OrgRequestsPerMinute: p.OrgRequestsPerMinute, TokenRequestsPerMinute: p.TokenRequestsPerMinute}
+	if o != nil && o.OrgRequestsPerMinute != nil {
+		limits.OrgRequestsPerMinute = *o.OrgRequestsPerMinute
+	}
+	if o != nil && o.TokenRequestsPerMinute != nil {
+		limits.TokenRequestsPerMinute = *o.TokenRequestsPerMinute
+	}
+	return limits
+}
+
+// Override returns the org's override from the store, or nil if it has none.
+func (e *Enforcer) Override(ctx context.Context, orgID string) (*domain.Override, error) {
+	o, err := e.store.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	e.remember(orgID, o)
+	return o, nil
+}
+
+// SetOverride stores o and applies it on this instance immediately.
+func (e *Enforcer) SetOverride(ctx context.Context, o *domain.Override) error {
+	if err := e.store.Upsert(ctx, o); err != nil {
+		return err
+	}
+	saved := *o
+	e.remember(o.OrgID, &saved)
+	return nil
+}
+
+// ClearOverride deletes the org's override, returning it to the default plan, and applies that on this instance
+// immediately.
+func (e *Enforcer) ClearOverride(ctx context.Context, orgID string) error {
+	if err := e.store.Delete(ctx, orgID); err != nil {
+		return err
+	}
+	e.remember(orgID, nil)
+	return nil
+}
+
+// Usage returns the org's usage on this instance.
+func (e *Enforcer) Usage(orgID string) domain.Usage {
+	e.mu.Lock()
+	var u domain.Usage
+	if got, ok := e.usage[orgID]; ok {
+		u = *got
+	}
+	e.mu.Unlock()
+	u.OrgRequests = e.orgs.Count(orgID)
+	return u
+}
+
+// cachedOverride returns the org's override, reloading it from the store at most every ttl.
+func (e *Enforcer) cachedOverride(ctx context.Context, orgID string) *domain.Override {
+	now := e.now()
+	e.mu.Lock()
+	c, ok := e.overrides[orgID]
+	e.mu.Unlock()
+	if ok && now.Sub(c.loadedAt) < e.ttl {
+		return c.override
+	}
+	o, err := e.store.Get(ctx, orgID)
+	if err != nil {
+		log.Printf("quota: load override of org %s: %v", orgID, err)
+		o = c.override
+	}
+	// Retry no sooner than ttl even on failure, so a database outage does not add a query to every RPC.
+	e.mu.Lock()
+	e.overrides[orgID] = cachedOverride{override: o, loadedAt: now}
+	e.mu.Unlock()
+	return o
+}
+
+func (e *Enforcer) remember(orgID string, o *domain.Override) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.overrides[orgID] = cachedOverride{override: o, loadedAt: e.now()}
+}
@@ -0,0 +1,166 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/plans"
+	"zero-trust-control-plane/backend/internal/quota/domain"
+)
+
+type memStore struct {
+	overrides map[string]*domain.Override
+	err       error
+	loads     int
+}
+
+func (m *memStore) Get(ctx context.Context, orgID string) (*domain.Override, error) {
+	m.loads++
+	if m.err != nil {
+		return nil, m.err
+	}
+	o, ok := m.overrides[orgID]
+	if !ok {
+		return nil, nil
+	}
+	out := *o
+	return &out, nil
+}
+
+func (m *memStore) Upsert(ctx context.Context, o *domain.Override) error {
+	if m.err != nil {
+		return m.err
+	}
+	out := *o
+	m.overrides[o.OrgID] = &out
+	return nil
+}
+
+func (m *memStore) Delete(ctx context.Context, orgID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	delete(m.overrides, orgID)
+	return nil
+}
+
+func intp(v int) *int { return &v }
+
+func testPlans() map[string]plans.Plan {
+	return map[string]plans.Plan{
+		"free":     {Name: "free", OrgRequestsPerMinute: 3, TokenRequestsPerMinute: 2},
+		"standard": {Name: "standard", OrgRequestsPerMinute: 10, TokenRequestsPerMinute: 5},
+	}
+}
+
+func TestEnforcer_NilAllows(t *testing.T) {
+	var e *Enforcer
+	if !e.Check(context.Background(), "org-1", "sess-1").Allowed {
+		t.Error("nil Enforcer should allow")
+	}
+}
+
+func TestEnforcer_TokenQuota(t *testing.T) {
+	e := NewEnforcer(&memStore{overrides: map[string]*domain.Override{"org-1": {OrgID: "org-1", Plan: "free"}}}, testPlans(), "standard", time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if d := e.Check(ctx, "org-1", "sess-1"); !d.Allowed {
+			t.Fatalf("request %d within quota rejected: %+v", i+1, d)
+		}
+	}
+	d := e.Check(ctx, "org-1", "sess-1")
+	if d.Allowed || d.Scope != domain.ScopeToken || d.Limit != 2 {
+		t.Fatalf("over the session quota = %+v, want token scope with limit 2", d)
+	}
+	if d.RetryAfter <= 0 || d.RetryAfter > time.Minute {
+		t.Errorf("RetryAfter = %v, want within the minute window", d.RetryAfter)
+	}
+	// Another session of the org still has its own allowance, until the org quota is used up.
+	if d := e.Check(ctx, "org-1", "sess-2"); !d.Allowed {
+		t.Fatalf("other session rejected: %+v", d)
+	}
+	d = e.Check(ctx, "org-1", "sess-2")
+	if d.Allowed || d.Scope != domain.ScopeOrg || d.Limit != 3 {
+		t.Fatalf("over the org quota = %+v, want org scope with limit 3", d)
+	}
+
+	u := e.Usage("org-1")
+	if u.OrgRequests != 3 || u.Allowed != 3 || u.RejectedToken != 1 || u.RejectedOrg != 1 {
+		t.Errorf("Usage = %+v, want 3 org requests, 3 allowed, 1 rejected per scope", u)
+	}
+}
+
+func TestEnforcer_Limits(t *testing.T) {
+	store := &memStore{overrides: map[string]*domain.Override{
+		"org-free":     {OrgID: "org-free", Plan: "free"},
+		"org-override": {OrgID: "org-override", Plan: "free", OrgRequestsPerMinute: intp(0), TokenRequestsPerMinute: intp(50)},
+		"org-gone":     {OrgID: "org-gone", Plan: "retired"},
+	}}
+	e := NewEnforcer(store, testPlans(), "standard", time.Minute)
+	ctx := context.Background()
+
+	tests := []struct {
+		org  string
+		want domain.Limits
+	}{
+		{"org-none", domain.Limits{Plan: "standard", OrgRequestsPerMinute: 10, TokenRequestsPerMinute: 5}},
+		{"org-free", domain.Limits{Plan: "free", OrgRequestsPerMinute: 3, TokenRequestsPerMinute: 2}},
+		{"org-override", domain.Limits{Plan: "free", OrgRequestsPerMinute: 0, TokenRequestsPerMinute: 50}},
+		{"org-gone", domain.Limits{Plan: "standard", OrgRequestsPerMinute: 10, TokenRequestsPerMinute: 5}},
+	}
+	for _, tt := range tests {
+		if got := e.Limits(ctx, tt.org); got != tt.want {
+			t.Errorf("Limits(%s) = %+v, want %+v", tt.org, got, tt.want)
+		}
+	}
+
+	e.SetPlans(map[string]plans.Plan{"free": {Name: "free", OrgRequestsPerMinute: 1, TokenRequestsPerMinute: 1}}, "free")
+	if got := e.Limits(ctx, "org-none"); got.Plan != "free" || got.OrgRequestsPerMinute != 1 {
+		t.Errorf("Limits after SetPlans = %+v, want the new default plan", got)
+	}
+}
+
+func TestEnforcer_OverrideCache(t *testing.T) {
+	store := &memStore{overrides: map[string]*domain.Override{}}
+	e := NewEnforcer(store, testPlans(), "standard", time.Minute)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	e.Limits(ctx, "org-1")
+	e.Limits(ctx, "org-1")
+	if store.loads != 1 {
+		t.Errorf("loads = %d, want 1 within the TTL", store.loads)
+	}
+
+	// Set on this instance: applied at once.
+	if err := e.SetOverride(ctx, &domain.Override{OrgID: "org-1", Plan: "free", UpdatedBy: "admin-1"}); err != nil {
+		t.Fatalf("SetOverride: %v", err)
+	}
+	if got := e.Limits(ctx, "org-1").Plan; got != "free" {
+		t.Errorf("plan after SetOverride = %q, want free", got)
+	}
+
+	// Changed on another instance: seen after the TTL; a failed reload keeps the last known override.
+	store.overrides["org-1"] = &domain.Override{OrgID: "org-1", Plan: "standard"}
+	store.err = errors.New("db down")
+	now = now.Add(2 * time.Minute)
+	if got := e.Limits(ctx, "org-1").Plan; got != "free" {
+		t.Errorf("plan on failed reload = %q, want last known free", got)
+	}
+	store.err = nil
+	now = now.Add(2 * time.Minute)
+	if got := e.Limits(ctx, "org-1").Plan; got != "standard" {
+		t.Errorf("plan after reload = %q, want standard", got)
+	}
+
+	if err := e.ClearOverride(ctx, "org-1"); err != nil {
+		t.Fatalf("ClearOverride: %v", err)
+	}
+	if o, _ := e.Override(ctx, "org-1"); o != nil {
+		t.Errorf("Override after ClearOverride = %+v, want nil", o)
+	}
+}
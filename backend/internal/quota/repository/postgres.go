@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/quota/domain"
+)
+
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a quota override repository that uses the given db.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Get returns the org's quota override, or nil if not found.
+func (r *PostgresRepository) Get(ctx context.Context, orgID string) (*domain.Override, error) {
+	row, err := r.queries.GetOrgQuota(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genOrgQuotaToDomain(&row), nil
+}
+
+// List returns all quota overrides ordered by org ID.
+func (r *PostgresRepository) List(ctx context.Context) ([]*domain.Override, error) {
+	rows, err := r.queries.ListOrgQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Override, len(rows))
+	for i := range rows {
+		out[i] = genOrgQuotaToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+// Upsert creates or replaces the org's quota override.
+func (r *PostgresRepository) Upsert(ctx context.Context, o *domain.Override) error {
+	updatedAt := o.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now().UTC()
+	}
+	return r.queries.UpsertOrgQuota(ctx, gen.UpsertOrgQuotaParams{
+		OrgID:                  o.OrgID,
+		Plan:                   o.Plan,
+		OrgRequestsPerMinute:   nullInt32(o.OrgRequestsPerMinute),
+		TokenRequestsPerMinute: nullInt32(o.TokenRequestsPerMinute),
+		UpdatedBy:              o.UpdatedBy,
+		UpdatedAt:              updatedAt,
+	})
+}
+
+// Delete removes the org's quota override.
+func (r *PostgresRepository) Delete(ctx context.Context, orgID string) error {
+	return r.queries.DeleteOrgQuota(ctx, orgID)
+}
+
+func genOrgQuotaToDomain(q *gen.OrgQuota) *domain.Override {
+	return &domain.Override{
+		OrgID:                  q.OrgID,
+		Plan:                   q.Plan,
+		OrgRequestsPerMinute:   intPtr(q.OrgRequestsPerMinute),
+		TokenRequestsPerMinute: intPtr(q.TokenRequestsPerMinute),
+		UpdatedBy:              q.UpdatedBy,
+		UpdatedAt:              q.UpdatedAt,
+	}
+}
+
+func nullInt32(v *int) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*v), Valid: true}
+}
+
+func intPtr(v sql.NullInt32) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int32)
+	return &n
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"zero-trust-control-plane/backend/internal/quota/domain"
+)
+
+// Repository defines access to per-org quota overrides.
+type Repository interface {
+	// Get returns the org's quota override, or nil if the org has none (it uses the default plan).
+	Get(ctx context.Context, orgID string) (*domain.Override, error)
+	// List returns all quota overrides ordered by org ID.
+	List(ctx context.Context) ([]*domain.Override, error)
+	// Upsert creates or replaces the org's quota override.
+	Upsert(ctx context.Context, o *domain.Override) error
+	// Delete removes the org's quota override; deleting a missing override is not an error.
+	Delete(ctx context.Context, orgID string) error
+}
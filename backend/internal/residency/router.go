@@ -0,0 +1,117 @@
+// Package residency routes org-scoped data to the database of the org's data region, for orgs that require their
+// data to stay in one region (e.g. EU-only storage). The org's activity data (audit logs, policy violations,
+// security events, telemetry, and the analytics and usage rollups built from them) is stored in the regional
+// database of orgs with a data region. Organizations, users, memberships, sessions, devices and org configuration
+// stay in the primary database: sign-in resolves them before the org is known, and a user can belong to orgs in
+// several regions.
+package residency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+)
+
+// ErrRegionUnavailable is returned for an org whose data region has no database in this deployment. Queries for
+// such orgs are rejected rather than served from (or written to) another region's database.
+var ErrRegionUnavailable = errors.New("residency: org data region is not available in this deployment")
+
+// OrgReader returns an organization by ID (nil when not found). Implemented by the organization repository.
+type OrgReader interface {
+	GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error)
+}
+
+// Router returns the database holding an org's region-scoped data. An org's data region cannot change, so it is
+// cached for the life of the process.
+type Router struct {
+	primary *sql.DB
+	regions map[string]*sql.DB
+	orgs    OrgReader
+
+	mu    sync.RWMutex
+	cache map[string]string
+}
+
+// NewRouter returns a Router over the primary database and the regional databases keyed by region name.
+func NewRouter(primary *sql.DB, regions map[string]*sql.DB, orgs OrgReader) *Router {
+	return &Router{primary: primary, regions: regions, orgs: orgs, cache: make(map[string]string)}
+}
+
+// Regions returns the configured region names, sorted.
+func (r *Router) Regions() []string {
+	if r == nil {
+		return nil
+	}
+	out := make([]string, 0, len(r.regions))
+	for name := range r.regions {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// HasRegion reports whether region is "" (the primary database) or a configured region.
+func (r *Router) HasRegion(region string) bool {
+	if region == "" {
+		return true
+	}
+	if r == nil {
+		return false
+	}
+	_, ok := r.regions[region]
+	return ok
+}
+
+// DB returns the database for the org's region-scoped data: the primary database for orgs without a data region
+// (and unknown orgs), the regional database otherwise. Returns ErrRegionUnavailable when the org's region is not
+// configured.
+func (r *Router) DB(ctx context.Context, orgID string) (gen.DBTX, error) {
+	region, err := r.orgRegion(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if region == "" {
+		return r.primary, nil
+	}
+	db, ok := r.regions[region]
+	if !ok {
+		return nil, fmt.Errorf("%w: org %s requires region %q", ErrRegionUnavailable, orgID, region)
+	}
+	return db, nil
+}
+
+// Databases returns the primary database followed by the regional ones, keyed by region ("" for the primary), for
+// per-database maintenance such as partition management. It must not be used to read or write org data.
+func (r *Router) Databases() map[string]gen.DBTX {
+	out := map[string]gen.DBTX{"": r.primary}
+	for name, db := range r.regions {
+		out[name] = db
+	}
+	return out
+}
+
+func (r *Router) orgRegion(ctx context.Context, orgID string) (string, error) {
+	r.mu.RLock()
+	region, ok := r.cache[orgID]
+	r.mu.RUnlock()
+	if ok {
+		return region, nil
+	}
+	org, err := r.orgs.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return "", err
+	}
+	if org == nil {
+		return "", nil
+	}
+	r.mu.Lock()
+	r.cache[orgID] = org.DataRegion
+	r.mu.Unlock()
+	return org.DataRegion, nil
+}
@@ -0,0 +1,81 @@
+package residency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	organizationdomain "zero-trust-control-plane/backend/internal/organization/domain"
+)
+
+type memOrgs struct {
+	orgs  map[string]*organizationdomain.Org
+	calls int
+}
+
+func (m *memOrgs) GetOrganizationByID(ctx context.Context, id string) (*organizationdomain.Org, error) {
+	m.calls++
+	if id == "org-broken" {
+		return nil, errors.New("db down")
+	}
+	return m.orgs[id], nil
+}
+
+func TestRouter_DB(t *testing.T) {
+	primary, eu := &sql.DB{}, &sql.DB{}
+	orgs := &memOrgs{orgs: map[string]*organizationdomain.Org{
+		"org-global": {ID: "org-global"},
+		"org-eu":     {ID: "org-eu", DataRegion: "eu"},
+		"org-us":     {ID: "org-us", DataRegion: "us"},
+	}}
+	r := NewRouter(primary, map[string]*sql.DB{"eu": eu}, orgs)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		orgID string
+		want  *sql.DB
+	}{
+		{"org-global", primary},
+		{"org-eu", eu},
+		{"org-missing", primary},
+	} {
+		got, err := r.DB(ctx, tc.orgID)
+		if err != nil || got != tc.want {
+			t.Errorf("DB(%s) = %p, %v; want %p", tc.orgID, got, err, tc.want)
+		}
+	}
+	if _, err := r.DB(ctx, "org-us"); !errors.Is(err, ErrRegionUnavailable) {
+		t.Errorf("DB(org-us) err = %v, want ErrRegionUnavailable", err)
+	}
+	if _, err := r.DB(ctx, "org-broken"); err == nil || errors.Is(err, ErrRegionUnavailable) {
+		t.Errorf("DB(org-broken) err = %v, want the lookup error", err)
+	}
+
+	// An org's region is cached after the first lookup.
+	calls := orgs.calls
+	if _, err := r.DB(ctx, "org-eu"); err != nil || orgs.calls != calls {
+		t.Errorf("cached DB(org-eu): err %v, lookups %d -> %d", err, calls, orgs.calls)
+	}
+}
+
+func TestRouter_Regions(t *testing.T) {
+	primary := &sql.DB{}
+	r := NewRouter(primary, map[string]*sql.DB{"us": {}, "eu": {}}, &memOrgs{})
+	if got := r.Regions(); !reflect.DeepEqual(got, []string{"eu", "us"}) {
+		t.Errorf("Regions = %v", got)
+	}
+	for region, want := range map[string]bool{"": true, "eu": true, "ap": false} {
+		if got := r.HasRegion(region); got != want {
+			t.Errorf("HasRegion(%q) = %v, want %v", region, got, want)
+		}
+	}
+	if dbs := r.Databases(); len(dbs) != 3 || dbs[""] != primary {
+		t.Errorf("Databases = %v, want primary and two regions", dbs)
+	}
+	var nilRouter *Router
+	if nilRouter.Regions() != nil || nilRouter.HasRegion("eu") || !nilRouter.HasRegion("") {
+		t.Error("nil Router should have no regions")
+	}
+}
@@ -0,0 +1,215 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultAWSTimeout = 10 * time.Second
+
+// AWSCredentials are static AWS credentials used to sign requests (Signature Version 4).
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // optional; set for temporary credentials
+}
+
+// awsClient calls an AWS JSON 1.1 API (Secrets Manager, KMS) with SigV4-signed requests.
+type awsClient struct {
+	service    string
+	region     string
+	endpoint   string
+	creds      AWSCredentials
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func newAWSClient(service, region, endpoint string, creds AWSCredentials) awsClient {
+	if endpoint == "" {
+		endpoint = "https://" + service + "." + region + ".amazonaws.com"
+	}
+	return awsClient{
+		service:    service,
+		region:     region,
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		creds:      creds,
+		httpClient: &http.Client{Timeout: defaultAWSTimeout},
+		now:        time.Now,
+	}
+}
+
+// call invokes target (e.g. "secretsmanager.GetSecretValue") with in as the JSON body and decodes the response into out.
+func (c awsClient) call(ctx context.Context, target string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+	signV4(req, body, c.creds, c.service, c.region, c.now().UTC())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", c.service, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = json.Unmarshal(b, &apiErr)
+		if strings.HasSuffix(apiErr.Type, "ResourceNotFoundException") || strings.HasSuffix(apiErr.Type, "NotFoundException") {
+			return fmt.Errorf("%s: %w", c.service, ErrNotFound)
+		}
+		return fmt.Errorf("%s: %s failed: status=%d type=%s message=%s", c.service, target, resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: decode response: %w", c.service, err)
+	}
+	return nil
+}
+
+// SecretsManagerProvider reads secrets from AWS Secrets Manager. References have the form "secret-id" (the whole
+// SecretString) or "secret-id#key" (key of a JSON SecretString), e.g. "prod/ztcp/jwt#private_key". The secret id may
+// be a name or an ARN. Only the current version (AWSCURRENT) is read.
+type SecretsManagerProvider struct {
+	client awsClient
+}
+
+// NewSecretsManagerProvider returns a provider for region. endpoint overrides the regional endpoint (e.g. a VPC
+// endpoint); leave empty for the default.
+func NewSecretsManagerProvider(region, endpoint string, creds AWSCredentials) *SecretsManagerProvider {
+	return &SecretsManagerProvider{client: newAWSClient("secretsmanager", region, endpoint, creds)}
+}
+
+// GetSecret returns the SecretString of the secret, or one key of it.
+func (p *SecretsManagerProvider) GetSecret(ctx context.Context, ref string) (string, error) {
+	id, key := splitRef(ref)
+	if id == "" {
+		return "", fmt.Errorf("secretsmanager: empty secret id")
+	}
+	var out struct {
+		SecretString string `json:"SecretString"`
+		SecretBinary []byte `json:"SecretBinary"`
+	}
+	if err := p.client.call(ctx, "secretsmanager.GetSecretValue", map[string]string{"SecretId": id}, &out); err != nil {
+		return "", err
+	}
+	value := out.SecretString
+	if value == "" && out.SecretBinary != nil {
+		value = string(out.SecretBinary)
+	}
+	if key == "" {
+		return value, nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("secretsmanager: %s is not a JSON object: %w", id, err)
+	}
+	v, ok := fields[key].(string)
+	if !ok {
+		return "", fmt.Errorf("secretsmanager: %s#%s: %w", id, key, ErrNotFound)
+	}
+	return v, nil
+}
+
+// KMSProvider decrypts secrets encrypted with AWS KMS. The reference is the base64 ciphertext blob produced by
+// `aws kms encrypt`, so only ciphertext appears in configuration and the plaintext exists only in memory. The KMS
+// key is identified by the ciphertext itself. Rotating the secret means replacing the ciphertext.
+type KMSProvider struct {
+	client awsClient
+}
+
+// NewKMSProvider returns a provider for region. endpoint overrides the regional endpoint; leave empty for the default.
+func NewKMSProvider(region, endpoint string, creds AWSCredentials) *KMSProvider {
+	return &KMSProvider{client: newAWSClient("kms", region, endpoint, creds)}
+}
+
+// GetSecret decrypts the base64 ciphertext ref and returns the plaintext.
+func (p *KMSProvider) GetSecret(ctx context.Context, ref string) (string, error) {
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ref))
+	if err != nil {
+		return "", fmt.Errorf("kms: ciphertext is not base64: %w", err)
+	}
+	var out struct {
+		Plaintext []byte `json:"Plaintext"`
+	}
+	if err := p.client.call(ctx, "TrentService.Decrypt", map[string][]byte{"CiphertextBlob": blob}, &out); err != nil {
+		return "", err
+	}
+	return string(out.Plaintext), nil
+}
+
+// signV4 adds AWS Signature Version 4 headers to req. All headers already set on req are signed.
+func signV4(req *http.Request, body []byte, creds AWSCredentials, service, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
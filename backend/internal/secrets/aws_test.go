@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSignV4_Vanilla checks the signer against the "get-vanilla" case of the AWS SigV4 test suite.
+func TestSignV4_Vanilla(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	signV4(req, nil, AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}, "service", "us-east-1", now)
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func newAWSTestServer(t *testing.T, handle func(target string, body map[string]any) (int, any)) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			t.Errorf("missing SigV4 Authorization header: %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Amz-Security-Token") != "session" {
+			t.Errorf("X-Amz-Security-Token = %q, want session", r.Header.Get("X-Amz-Security-Token"))
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		status, out := handle(r.Header.Get("X-Amz-Target"), body)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(out)
+	}))
+}
+
+var testAWSCreds = AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session"}
+
+func TestSecretsManagerProvider_GetSecret(t *testing.T) {
+	srv := newAWSTestServer(t, func(target string, body map[string]any) (int, any) {
+		if target != "secretsmanager.GetSecretValue" {
+			t.Errorf("X-Amz-Target = %q", target)
+		}
+		switch body["SecretId"] {
+		case "prod/ztcp/jwt":
+			return http.StatusOK, map[string]string{"SecretString": `{"private_key":"pem-private"}`}
+		case "prod/ztcp/sms":
+			return http.StatusOK, map[string]string{"SecretString": "sms-key"}
+		}
+		return http.StatusBadRequest, map[string]string{"__type": "ResourceNotFoundException", "message": "not found"}
+	})
+	defer srv.Close()
+	p := NewSecretsManagerProvider("us-east-1", srv.URL, testAWSCreds)
+	ctx := context.Background()
+
+	if v, err := p.GetSecret(ctx, "prod/ztcp/jwt#private_key"); err != nil || v != "pem-private" {
+		t.Errorf("JSON key = %q, %v", v, err)
+	}
+	if v, err := p.GetSecret(ctx, "prod/ztcp/sms"); err != nil || v != "sms-key" {
+		t.Errorf("plain SecretString = %q, %v", v, err)
+	}
+	if _, err := p.GetSecret(ctx, "prod/ztcp/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing secret: want ErrNotFound, got %v", err)
+	}
+	if _, err := p.GetSecret(ctx, "prod/ztcp/sms#key"); err == nil {
+		t.Error("key of a non-JSON secret: expected error")
+	}
+}
+
+func TestKMSProvider_GetSecret(t *testing.T) {
+	srv := newAWSTestServer(t, func(target string, body map[string]any) (int, any) {
+		if target != "TrentService.Decrypt" {
+			t.Errorf("X-Amz-Target = %q", target)
+		}
+		if body["CiphertextBlob"] != base64.StdEncoding.EncodeToString([]byte("ciphertext")) {
+			return http.StatusBadRequest, map[string]string{"__type": "InvalidCiphertextException"}
+		}
+		return http.StatusOK, map[string][]byte{"Plaintext": []byte("plaintext")}
+	})
+	defer srv.Close()
+	p := NewKMSProvider("us-east-1", srv.URL, testAWSCreds)
+	ctx := context.Background()
+
+	if v, err := p.GetSecret(ctx, base64.StdEncoding.EncodeToString([]byte("ciphertext"))); err != nil || v != "plaintext" {
+		t.Errorf("Decrypt = %q, %v", v, err)
+	}
+	if _, err := p.GetSecret(ctx, base64.StdEncoding.EncodeToString([]byte("other"))); err == nil {
+		t.Error("invalid ciphertext: expected error")
+	}
+	if _, err := p.GetSecret(ctx, "not base64!"); err == nil {
+		t.Error("non-base64 ref: expected error")
+	}
+}
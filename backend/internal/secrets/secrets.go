@@ -0,0 +1,54 @@
+// Package secrets loads secrets (JWT signing keys, SMS API keys) from an external secrets manager so they do not
+// have to be passed through the process environment. Providers fetch a single secret by reference; Store adds lazy
+// fetching, caching and periodic refresh so rotated secrets are picked up without a redeploy.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrNotFound is returned when the referenced secret or key does not exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Provider fetches the current value of a secret. The reference format is provider-specific (see VaultProvider,
+// SecretsManagerProvider and KMSProvider). Implementations must not log secret values.
+type Provider interface {
+	GetSecret(ctx context.Context, ref string) (string, error)
+}
+
+// splitRef splits "path#key" into path and key. key is empty when ref has no "#".
+func splitRef(ref string) (path, key string) {
+	path, key, _ = strings.Cut(ref, "#")
+	return strings.TrimSpace(path), strings.TrimSpace(key)
+}
+
+// Settings select and configure the provider returned by NewProvider: SECRETS_PROVIDER and the VAULT_* and AWS_*
+// settings of the configuration.
+type Settings struct {
+	Provider       string // "vault", "aws-secretsmanager", "aws-kms", or "" for none
+	VaultAddr      string
+	VaultKVMount   string
+	VaultToken     string
+	VaultTokenFile string
+	VaultNamespace string
+	AWSRegion      string
+	AWSEndpoint    string
+	AWSCredentials AWSCredentials
+}
+
+// NewProvider returns the provider selected by s.Provider, or nil when none is.
+func NewProvider(s Settings) Provider {
+	switch s.Provider {
+	case "vault":
+		vault := NewVaultProvider(s.VaultAddr, s.VaultKVMount, s.VaultToken, s.VaultTokenFile)
+		vault.Namespace = s.VaultNamespace
+		return vault
+	case "aws-secretsmanager":
+		return NewSecretsManagerProvider(s.AWSRegion, s.AWSEndpoint, s.AWSCredentials)
+	case "aws-kms":
+		return NewKMSProvider(s.AWSRegion, s.AWSEndpoint, s.AWSCredentials)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store caches secrets fetched from a Provider. A secret is fetched on first Get; Refresh (or Run) re-fetches every
+// secret that has been read and notifies watchers of the ones that changed. Safe for concurrent use.
+type Store struct {
+	provider Provider
+
+	mu       sync.Mutex
+	values   map[string]string
+	watchers map[string][]func(value string)
+}
+
+// NewStore returns a Store backed by provider.
+func NewStore(provider Provider) *Store {
+	return &Store{
+		provider: provider,
+		values:   make(map[string]string),
+		watchers: make(map[string][]func(value string)),
+	}
+}
+
+// Get returns the cached value of ref, fetching it from the provider on first use.
+func (s *Store) Get(ctx context.Context, ref string) (string, error) {
+	s.mu.Lock()
+	v, ok := s.values[ref]
+	s.mu.Unlock()
+	if ok {
+		return v, nil
+	}
+	v, err := s.provider.GetSecret(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.values[ref] = v
+	s.mu.Unlock()
+	return v, nil
+}
+
+// Watch registers fn to be called with the new value whenever a refresh finds that ref changed.
+func (s *Store) Watch(ref string, fn func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchers[ref] = append(s.watchers[ref], fn)
+}
+
+// Refresh re-fetches every cached secret. All secrets are fetched before any watcher runs, so secrets that rotate
+// together (e.g. a key pair) are seen together. A failed fetch keeps the cached value; the errors are returned joined.
+func (s *Store) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	refs := make([]string, 0, len(s.values))
+	for ref := range s.values {
+		refs = append(refs, ref)
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	type change struct {
+		value    string
+		watchers []func(string)
+	}
+	var changes []change
+	for _, ref := range refs {
+		v, err := s.provider.GetSecret(ctx, ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.mu.Lock()
+		if s.values[ref] != v {
+			s.values[ref] = v
+			changes = append(changes, change{value: v, watchers: slices.Clone(s.watchers[ref])})
+		}
+		s.mu.Unlock()
+	}
+	for _, c := range changes {
+		for _, fn := range c.watchers {
+			fn(c.value)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run refreshes the store every interval until ctx is done.
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("secrets: refresh failed: %v", err)
+			}
+		}
+	}
+}
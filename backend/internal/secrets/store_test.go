@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type mapProvider struct {
+	mu     sync.Mutex
+	values map[string]string
+	calls  int
+	err    error
+}
+
+func (p *mapProvider) GetSecret(ctx context.Context, ref string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.err != nil {
+		return "", p.err
+	}
+	v, ok := p.values[ref]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (p *mapProvider) set(ref, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.values[ref] = value
+}
+
+func TestStore_GetCaches(t *testing.T) {
+	p := &mapProvider{values: map[string]string{"jwt#private_key": "v1"}}
+	s := NewStore(p)
+	for i := 0; i < 3; i++ {
+		v, err := s.Get(context.Background(), "jwt#private_key")
+		if err != nil || v != "v1" {
+			t.Fatalf("Get = %q, %v; want v1", v, err)
+		}
+	}
+	if p.calls != 1 {
+		t.Errorf("provider calls = %d, want 1 (lazy fetch, then cached)", p.calls)
+	}
+	if _, err := s.Get(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get missing: want ErrNotFound, got %v", err)
+	}
+}
+
+func TestStore_RefreshNotifiesWatchers(t *testing.T) {
+	p := &mapProvider{values: map[string]string{"a": "1", "b": "1"}}
+	s := NewStore(p)
+	ctx := context.Background()
+	s.Get(ctx, "a")
+	s.Get(ctx, "b")
+	var got []string
+	s.Watch("a", func(v string) {
+		// Both secrets are fetched before watchers run, so a watcher sees its sibling's new value.
+		b, _ := s.Get(ctx, "b")
+		got = append(got, v+"/"+b)
+	})
+
+	if err := s.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("watcher called without a change: %v", got)
+	}
+	p.set("a", "2")
+	p.set("b", "2")
+	if err := s.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if len(got) != 1 || got[0] != "2/2" {
+		t.Errorf("watcher calls = %v, want [2/2]", got)
+	}
+}
+
+func TestStore_RefreshErrorKeepsValue(t *testing.T) {
+	p := &mapProvider{values: map[string]string{"a": "1"}}
+	s := NewStore(p)
+	ctx := context.Background()
+	s.Get(ctx, "a")
+	p.err = errors.New("unavailable")
+	if err := s.Refresh(ctx); err == nil {
+		t.Fatal("Refresh: expected error")
+	}
+	if v, err := s.Get(ctx, "a"); err != nil || v != "1" {
+		t.Errorf("Get after failed refresh = %q, %v; want cached 1", v, err)
+	}
+}
@@ -0,0 +1,111 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultVaultTimeout = 10 * time.Second
+
+// VaultProvider reads secrets from a HashiCorp Vault KV version 2 engine. References have the form "path#key",
+// e.g. "ztcp/jwt#private_key" reads key private_key of secret ztcp/jwt; key defaults to "value".
+type VaultProvider struct {
+	Addr  string // e.g. https://vault.internal:8200
+	Mount string // KV v2 mount, default "secret"
+	// Token is the Vault token. When TokenFile is set, the token is read from it on every request instead, so a
+	// token renewed by Vault Agent is picked up.
+	Token      string
+	TokenFile  string
+	Namespace  string // Vault Enterprise namespace; optional
+	HTTPClient *http.Client
+}
+
+// NewVaultProvider returns a provider for the KV v2 engine at mount (default "secret") on addr.
+func NewVaultProvider(addr, mount, token, tokenFile string) *VaultProvider {
+	if mount == "" {
+		mount = "secret"
+	}
+	return &VaultProvider{
+		Addr:       strings.TrimRight(addr, "/"),
+		Mount:      strings.Trim(mount, "/"),
+		Token:      token,
+		TokenFile:  tokenFile,
+		HTTPClient: &http.Client{Timeout: defaultVaultTimeout},
+	}
+}
+
+// GetSecret returns the value of key in the latest version of the secret at path.
+func (p *VaultProvider) GetSecret(ctx context.Context, ref string) (string, error) {
+	path, key := splitRef(ref)
+	if path == "" {
+		return "", fmt.Errorf("vault: empty secret path")
+	}
+	if key == "" {
+		key = "value"
+	}
+	token, err := p.token()
+	if err != nil {
+		return "", err
+	}
+	u := p.Addr + "/v1/" + url.PathEscape(p.Mount) + "/data/" + escapePath(strings.Trim(path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("vault: %s: %w", path, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("vault: read %s: status=%d body=%s", path, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("vault: decode %s: %w", path, err)
+	}
+	v, ok := body.Data.Data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("vault: %s#%s: %w", path, key, ErrNotFound)
+	}
+	return v, nil
+}
+
+func (p *VaultProvider) token() (string, error) {
+	if p.TokenFile == "" {
+		return p.Token, nil
+	}
+	b, err := os.ReadFile(p.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("vault: read token file: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// escapePath escapes each segment of a slash-separated path.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
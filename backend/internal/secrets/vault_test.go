@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newVaultTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/ztcp/jwt" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"private_key":"pem-private","value":"default"},"metadata":{"version":3}}}`))
+	}))
+}
+
+func TestVaultProvider_GetSecret(t *testing.T) {
+	srv := newVaultTestServer(t)
+	defer srv.Close()
+	p := NewVaultProvider(srv.URL, "kv", "s.token", "")
+	ctx := context.Background()
+
+	if v, err := p.GetSecret(ctx, "ztcp/jwt#private_key"); err != nil || v != "pem-private" {
+		t.Errorf("GetSecret with key = %q, %v; want pem-private", v, err)
+	}
+	if v, err := p.GetSecret(ctx, "ztcp/jwt"); err != nil || v != "default" {
+		t.Errorf("GetSecret without key = %q, %v; want value key", v, err)
+	}
+	if _, err := p.GetSecret(ctx, "ztcp/jwt#missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing key: want ErrNotFound, got %v", err)
+	}
+	if _, err := p.GetSecret(ctx, "ztcp/other#k"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing secret: want ErrNotFound, got %v", err)
+	}
+
+	bad := NewVaultProvider(srv.URL, "kv", "wrong", "")
+	if _, err := bad.GetSecret(ctx, "ztcp/jwt#private_key"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("wrong token: want non-NotFound error, got %v", err)
+	}
+}
+
+func TestVaultProvider_TokenFile(t *testing.T) {
+	srv := newVaultTestServer(t)
+	defer srv.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("s.token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := NewVaultProvider(srv.URL, "kv", "", tokenFile)
+	if v, err := p.GetSecret(context.Background(), "ztcp/jwt#private_key"); err != nil || v != "pem-private" {
+		t.Errorf("GetSecret with token file = %q, %v", v, err)
+	}
+}
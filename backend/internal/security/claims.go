@@ -0,0 +1,96 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+)
+
+// Limits on custom access-token claims, so a misconfigured org cannot bloat every token it is issued.
+const (
+	MaxCustomClaims       = 20
+	MaxCustomAudiences    = 10
+	MaxCustomClaimValue   = 256
+	maxCustomClaimNameLen = 64
+)
+
+var claimNameRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.:-]*$`)
+
+// reservedClaims are set by TokenProvider and relied on by ValidateAccess and downstream services; custom claims
+// must not replace them.
+var reservedClaims = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+	"org_id": true, "session_id": true, "typ": true,
+}
+
+// IsReservedClaim reports whether name is a claim TokenProvider sets itself.
+func IsReservedClaim(name string) bool {
+	return reservedClaims[name]
+}
+
+// ValidateClaimName returns an error if name is reserved or not a valid custom claim name.
+func ValidateClaimName(name string) error {
+	if IsReservedClaim(name) {
+		return fmt.Errorf("claim %q is reserved", name)
+	}
+	if len(name) > maxCustomClaimNameLen || !claimNameRe.MatchString(name) {
+		return fmt.Errorf("claim name %q must start with a letter, contain only letters, digits, _ . : -, and be at most %d characters", name, maxCustomClaimNameLen)
+	}
+	return nil
+}
+
+// CustomClaims are extra claims and audiences added to an access token.
+type CustomClaims struct {
+	Audiences []string
+	Claims    map[string]any
+}
+
+// ClaimsProvider supplies custom access-token claims for a user in an org (e.g. org-configured static claims).
+// Providers run in order on every access token issued with IssueAccessContext; an error fails issuance.
+type ClaimsProvider interface {
+	AccessTokenClaims(ctx context.Context, userID, orgID string) (*CustomClaims, error)
+}
+
+// SetClaimsProviders sets the providers consulted by IssueAccessContext. Not safe to call concurrently with issuance;
+// call once at startup.
+func (p *TokenProvider) SetClaimsProviders(providers ...ClaimsProvider) {
+	p.claimsProviders = providers
+}
+
+// customClaims merges the providers' output. Later providers override earlier claims with the same name. Reserved
+// claims, invalid names, and the platform audience are dropped, so a provider can never change how the token
+// validates.
+func (p *TokenProvider) customClaims(ctx context.Context, userID, orgID string) (audiences []string, claims map[string]any, err error) {
+	seenAud := map[string]bool{p.audience: true}
+	for _, cp := range p.claimsProviders {
+		cc, err := cp.AccessTokenClaims(ctx, userID, orgID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cc == nil {
+			continue
+		}
+		for _, a := range cc.Audiences {
+			if a == "" || seenAud[a] || len(audiences) >= MaxCustomAudiences {
+				continue
+			}
+			seenAud[a] = true
+			audiences = append(audiences, a)
+		}
+		for name, v := range cc.Claims {
+			if err := ValidateClaimName(name); err != nil {
+				log.Printf("security: dropping custom claim for org %s: %v", orgID, err)
+				continue
+			}
+			if claims == nil {
+				claims = make(map[string]any)
+			}
+			if _, exists := claims[name]; !exists && len(claims) >= MaxCustomClaims {
+				continue
+			}
+			claims[name] = v
+		}
+	}
+	return audiences, claims, nil
+}
@@ -0,0 +1,89 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type staticClaimsProvider struct {
+	cc  *CustomClaims
+	err error
+}
+
+func (p staticClaimsProvider) AccessTokenClaims(ctx context.Context, userID, orgID string) (*CustomClaims, error) {
+	return p.cc, p.err
+}
+
+func TestIssueAccessContext_CustomClaims(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	p.SetClaimsProviders(
+		staticClaimsProvider{cc: &CustomClaims{Audiences: []string{"payroll"}, Claims: map[string]any{"department": "eng"}}},
+		staticClaimsProvider{cc: &CustomClaims{
+			Audiences: []string{"test-audience", "payroll", "billing"},
+			Claims:    map[string]any{"department": "sales", "employee_id": "E-42", "sub": "admin", "org_id": "other-org", "bad name": "x"},
+		}},
+	)
+	token, _, _, err := p.IssueAccessContext(context.Background(), "s1", "u1", "o1")
+	if err != nil {
+		t.Fatalf("IssueAccessContext: %v", err)
+	}
+	sid, uid, oid, err := p.ValidateAccess(token)
+	if err != nil {
+		t.Fatalf("ValidateAccess: %v", err)
+	}
+	if sid != "s1" || uid != "u1" || oid != "o1" {
+		t.Errorf("ValidateAccess = %q/%q/%q, reserved claims must not be overridden", sid, uid, oid)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		t.Fatalf("ParseUnverified: %v", err)
+	}
+	if claims["department"] != "sales" || claims["employee_id"] != "E-42" {
+		t.Errorf("custom claims = %v, want later provider to win", claims)
+	}
+	if _, ok := claims["bad name"]; ok {
+		t.Error("invalid claim name should be dropped")
+	}
+	aud, _ := claims.GetAudience()
+	want := []string{"test-audience", "payroll", "billing"}
+	if len(aud) != len(want) {
+		t.Fatalf("aud = %v, want %v", aud, want)
+	}
+	for i := range want {
+		if aud[i] != want[i] {
+			t.Errorf("aud[%d] = %q, want %q", i, aud[i], want[i])
+		}
+	}
+}
+
+func TestIssueAccessContext_ProviderError(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	wantErr := errors.New("config unavailable")
+	p.SetClaimsProviders(staticClaimsProvider{err: wantErr})
+	if _, _, _, err := p.IssueAccessContext(context.Background(), "s1", "u1", "o1"); !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+}
+
+func TestValidateClaimName(t *testing.T) {
+	for _, name := range []string{"department", "employee_id", "https:x", "a.b-c"} {
+		if err := ValidateClaimName(name); err != nil {
+			t.Errorf("%q: %v", name, err)
+		}
+	}
+	for _, name := range []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti", "org_id", "session_id", "", "1x", "a b"} {
+		if err := ValidateClaimName(name); err == nil {
+			t.Errorf("%q should be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidJWKS is returned when a JSON Web Key Set cannot be parsed.
+var ErrInvalidJWKS = errors.New("invalid JWKS")
+
+// publishedJWK is a verification key in the JWKS: the public members plus the key ID (its RFC 7638 thumbprint, which
+// tokens carry in their kid header), algorithm and use.
+type publishedJWK struct {
+	jwk
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+type jwkSet struct {
+	Keys []publishedJWK `json:"keys"`
+}
+
+// publicJWK returns the JWK of an ES256 or RS256 public key, or false for other keys.
+func publicJWK(pub crypto.PublicKey) (jwk, bool) {
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return jwk{}, false
+		}
+		x, y := make([]byte, 32), make([]byte, 32)
+		key.X.FillBytes(x)
+		key.Y.FillBytes(y)
+		return jwk{Kty: "EC", Crv: "P-256", X: base64.RawURLEncoding.EncodeToString(x), Y: base64.RawURLEncoding.EncodeToString(y)}, true
+	case *rsa.PublicKey:
+		e := big.NewInt(int64(key.E)).Bytes()
+		return jwk{Kty: "RSA", N: base64.RawURLEncoding.EncodeToString(key.N.Bytes()), E: base64.RawURLEncoding.EncodeToString(e)}, true
+	}
+	return jwk{}, false
+}
+
+// KeyID returns the key ID of a token signing public key: its RFC 7638 thumbprint, or "" for unsupported keys.
+func KeyID(pub crypto.PublicKey) string {
+	k, ok := publicJWK(pub)
+	if !ok {
+		return ""
+	}
+	return k.thumbprint()
+}
+
+// JWKS returns the JSON Web Key Set (RFC 7517) of the keys that verify tokens: the current key and, during a
+// rotation, the previous one. Services outside the control plane use it to validate access and resource tokens.
+func (p *TokenProvider) JWKS() ([]byte, error) {
+	p.mu.RLock()
+	keys := []crypto.PublicKey{p.publicKey}
+	if p.previousPublicKey != nil {
+		keys = append(keys, p.previousPublicKey)
+	}
+	p.mu.RUnlock()
+	set := jwkSet{Keys: []publishedJWK{}}
+	for _, pub := range keys {
+		k, ok := publicJWK(pub)
+		if !ok {
+			return nil, ErrInvalidKey
+		}
+		set.Keys = append(set.Keys, publishedJWK{jwk: k, Kid: k.thumbprint(), Alg: KeyAlg(pub), Use: "sig"})
+	}
+	return json.Marshal(set)
+}
+
+// ParseJWKS parses a JSON Web Key Set as returned by JWKS into its public keys by key ID. Keys that are not EC P-256
+// or RSA signing keys are skipped.
+func ParseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set jwkSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, ErrInvalidJWKS
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, ok := k.verificationKey()
+		if !ok {
+			continue
+		}
+		kid := k.Kid
+		if kid == "" {
+			kid = k.thumbprint()
+		}
+		keys[kid] = pub
+	}
+	if len(keys) == 0 {
+		return nil, ErrInvalidJWKS
+	}
+	return keys, nil
+}
+
+// verificationKey decodes a published key. Unlike publicKey it accepts any RSA size: the key set comes from the
+// control plane, not from a client.
+func (k *jwk) verificationKey() (crypto.PublicKey, bool) {
+	switch k.Kty {
+	case "EC":
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if k.Crv != "P-256" || errX != nil || errY != nil {
+			return nil, false
+		}
+		ec := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !ec.Curve.IsOnCurve(ec.X, ec.Y) {
+			return nil, false
+		}
+		return ec, true
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, false
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, true
+	}
+	return nil, false
+}
+
+// Issuer returns the iss of the tokens the provider issues.
+func (p *TokenProvider) Issuer() string {
+	return p.issuer
+}
+
+// Audience returns the aud of access and refresh tokens.
+func (p *TokenProvider) Audience() string {
+	return p.audience
+}
@@ -0,0 +1,67 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTokenProvider_JWKS(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	before, _, _, err := p.IssueAccess("s1", "u1", "o1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	p.SetKeys(newKey, newKey.Public())
+	after, _, _, err := p.IssueAccess("s2", "u1", "o1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+
+	data, err := p.JWKS()
+	if err != nil {
+		t.Fatalf("JWKS: %v", err)
+	}
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		t.Fatalf("unmarshal JWKS: %v", err)
+	}
+	if len(set.Keys) != 2 || set.Keys[0]["alg"] != "ES256" || set.Keys[1]["alg"] != "RS256" || set.Keys[0]["use"] != "sig" {
+		t.Fatalf("JWKS = %s, want the ES256 current key and the RS256 previous key", data)
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		t.Fatalf("ParseJWKS: %v", err)
+	}
+	for _, token := range []string{before, after} {
+		parsed, err := jwt.ParseWithClaims(token, &jwt.RegisteredClaims{}, func(tok *jwt.Token) (interface{}, error) {
+			kid, _ := tok.Header["kid"].(string)
+			return keys[kid], nil
+		})
+		if err != nil || !parsed.Valid {
+			t.Errorf("token not verifiable with the JWKS key of its kid: %v", err)
+		}
+	}
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	for _, data := range []string{``, `{}`, `{"keys":[]}`, `{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`, `{"keys":[{"kty":"EC","crv":"P-256","x":"AA","y":"AA"}]}`} {
+		if _, err := ParseJWKS([]byte(data)); err != ErrInvalidJWKS {
+			t.Errorf("ParseJWKS(%q): want ErrInvalidJWKS, got %v", data, err)
+		}
+	}
+}
@@ -14,6 +14,9 @@ import (
 // ErrInvalidKey is returned when PEM or key type is invalid.
 var ErrInvalidKey = errors.New("invalid key")
 
+// ErrKeyMismatch is returned by ParseKeyPair when the public key does not belong to the private key.
+var ErrKeyMismatch = errors.New("public key does not match private key")
+
 // LoadPEM reads content from path if s does not look like inline PEM; otherwise returns s as bytes.
 // Inline PEM may use literal `\n` (e.g. from .env); those are converted to actual newlines for decoding.
 func LoadPEM(s string) ([]byte, error) {
@@ -79,6 +82,28 @@ func ParsePublicKey(s string) (crypto.PublicKey, error) {
 	}
 }
 
+// ParseKeyPair parses a private and public key (inline PEM or file paths) and checks that they form a pair, so a
+// half-rotated pair is rejected instead of issuing tokens that cannot be validated.
+func ParseKeyPair(privateKey, publicKey string) (crypto.Signer, crypto.PublicKey, error) {
+	signer, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !publicKeysEqual(signer.Public(), pub) {
+		return nil, nil, ErrKeyMismatch
+	}
+	return signer, pub, nil
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	eq, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && eq.Equal(b)
+}
+
 // KeyAlg returns "RS256" for RSA and "ES256" for ECDSA P-256; empty otherwise.
 func KeyAlg(pub crypto.PublicKey) string {
 	switch pub.(type) {
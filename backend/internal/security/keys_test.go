@@ -1,6 +1,11 @@
 package security
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
 	"path/filepath"
 	"strings"
@@ -136,6 +141,24 @@ func TestParsePublicKey_RSA(t *testing.T) {
 	}
 }
 
+func TestParseKeyPair(t *testing.T) {
+	if _, _, err := ParseKeyPair(testPrivateKeyPEM, testPublicKeyPEM); err != nil {
+		t.Fatalf("ParseKeyPair: %v", err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(other.Public())
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	otherPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if _, _, err := ParseKeyPair(testPrivateKeyPEM, otherPEM); err != ErrKeyMismatch {
+		t.Errorf("mismatched pair: want ErrKeyMismatch, got %v", err)
+	}
+}
+
 func TestParsePublicKey_InvalidPEM(t *testing.T) {
 	// Use a string that looks like inline PEM but is invalid
 	invalidPEM := "-----BEGIN PUBLIC KEY-----\ninvalid\n-----END PUBLIC KEY-----"
@@ -0,0 +1,54 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestParseLicense(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	expired := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	content, err := SignLicense(key, &LicenseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ID: "lic-1", Subject: "Acme", ExpiresAt: jwt.NewNumericDate(expired)},
+		Seats:            50,
+		Features:         []string{"custom_rego"},
+		GraceDays:        7,
+	})
+	if err != nil {
+		t.Fatalf("SignLicense: %v", err)
+	}
+	claims, err := ParseLicense(" "+content+"\n", &key.PublicKey)
+	if err != nil {
+		t.Fatalf("ParseLicense of an expired license: %v", err)
+	}
+	if claims.ID != "lic-1" || claims.Subject != "Acme" || claims.Seats != 50 || claims.GraceDays != 7 || !claims.ExpiresAt.Time.Equal(expired) {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if _, err := ParseLicense(content, &other.PublicKey); err != ErrInvalidLicense {
+		t.Errorf("other key: want ErrInvalidLicense, got %v", err)
+	}
+	noExpiry, _ := SignLicense(key, &LicenseClaims{Seats: 5})
+	if _, err := ParseLicense(noExpiry, &key.PublicKey); err != ErrInvalidLicense {
+		t.Errorf("no expiry: want ErrInvalidLicense, got %v", err)
+	}
+	// An ordinary token signed with the same key is not a license.
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodES256, &LicenseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}).SignedString(key)
+	if _, err := ParseLicense(token, &key.PublicKey); err != ErrInvalidLicense {
+		t.Errorf("token without license typ: want ErrInvalidLicense, got %v", err)
+	}
+	if _, err := ParseLicense("not a license", &key.PublicKey); err != ErrInvalidLicense {
+		t.Errorf("garbage: want ErrInvalidLicense, got %v", err)
+	}
+}
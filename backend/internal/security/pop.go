@@ -0,0 +1,184 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Proof-of-possession (DPoP-style) binding for refresh tokens. A client registers a public key (JWK) when its
+// session is created; the session stores the key's RFC 7638 thumbprint. Each Refresh then carries a proof: a JWT
+// of type PoPProofType signed with the private key, with the public JWK in its header, a server-issued nonce, and
+// rth, the hash of the refresh token being presented. A stolen refresh token is useless without the key.
+const (
+	PoPProofType     = "pop+jwt"
+	PoPNonceTTL      = 2 * time.Minute
+	popNonceAudience = "urn:ztcp:pop-nonce"
+	popClockLeeway   = 30 * time.Second
+	maxJWKLength     = 2048
+)
+
+var (
+	// ErrInvalidPoPKey is returned when a registered public key is not a supported JWK (EC P-256 or RSA >= 2048 bits).
+	ErrInvalidPoPKey = errors.New("invalid proof-of-possession key")
+	// ErrInvalidPoPProof is returned when a proof is malformed, not signed by the bound key, or has a bad nonce or hash.
+	ErrInvalidPoPProof = errors.New("invalid proof-of-possession proof")
+)
+
+// jwk holds the public members of an EC or RSA JSON Web Key.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// PoPProofClaims holds the claims of a refresh proof.
+type PoPProofClaims struct {
+	jwt.RegisteredClaims
+	Nonce            string `json:"nonce"`
+	RefreshTokenHash string `json:"rth"`
+}
+
+// PoPKeyThumbprint parses a public JWK (JSON) and returns its RFC 7638 SHA-256 thumbprint, base64url-encoded.
+func PoPKeyThumbprint(jwkJSON string) (string, error) {
+	if len(jwkJSON) > maxJWKLength {
+		return "", ErrInvalidPoPKey
+	}
+	var k jwk
+	if err := json.Unmarshal([]byte(jwkJSON), &k); err != nil {
+		return "", ErrInvalidPoPKey
+	}
+	_, thumbprint, err := k.publicKey()
+	return thumbprint, err
+}
+
+// publicKey returns the key and its thumbprint.
+func (k *jwk) publicKey() (crypto.PublicKey, string, error) {
+	var pub crypto.PublicKey
+	switch k.Kty {
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, "", ErrInvalidPoPKey
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+			return nil, "", ErrInvalidPoPKey
+		}
+		ec := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !ec.Curve.IsOnCurve(ec.X, ec.Y) {
+			return nil, "", ErrInvalidPoPKey
+		}
+		pub = ec
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, "", ErrInvalidPoPKey
+		}
+		exp := int(new(big.Int).SetBytes(e).Int64())
+		if exp < 3 || exp%2 == 0 {
+			return nil, "", ErrInvalidPoPKey
+		}
+		pub = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exp}
+	default:
+		return nil, "", ErrInvalidPoPKey
+	}
+	return pub, k.thumbprint(), nil
+}
+
+// thumbprint returns the RFC 7638 thumbprint of the key. Only the required members, in lexicographic order, are
+// hashed.
+func (k *jwk) thumbprint() string {
+	var canonical []byte
+	if k.Kty == "EC" {
+		canonical, _ = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{k.Crv, k.Kty, k.X, k.Y})
+	} else {
+		canonical, _ = json.Marshal(struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{k.E, k.Kty, k.N})
+	}
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// IssuePoPNonce issues a short-lived, signed nonce for the session's next refresh proof. Nonces are stateless:
+// their audience keeps them from being accepted as access or refresh tokens.
+func (p *TokenProvider) IssuePoPNonce(sessionID string) (nonce string, expiresAt time.Time, err error) {
+	jti, err := generateJTI()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	now := time.Now().UTC()
+	expiresAt = now.Add(PoPNonceTTL)
+	nonce, err = p.sign(jwt.RegisteredClaims{
+		ID:        jti,
+		Subject:   sessionID,
+		Issuer:    p.issuer,
+		Audience:  jwt.ClaimStrings{popNonceAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	})
+	return nonce, expiresAt, err
+}
+
+// VerifyPoPProof checks a refresh proof: signed (ES256 or RS256) by the key in its header, whose thumbprint must
+// equal thumbprint; recent iat; a valid nonce issued for sessionID; and rth matching refreshToken.
+func (p *TokenProvider) VerifyPoPProof(proof, refreshToken, sessionID, thumbprint string) error {
+	claims := &PoPProofClaims{}
+	token, err := jwt.ParseWithClaims(proof, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != PoPProofType {
+			return nil, ErrInvalidPoPProof
+		}
+		raw, err := json.Marshal(token.Header["jwk"])
+		if err != nil || len(raw) > maxJWKLength {
+			return nil, ErrInvalidPoPProof
+		}
+		var k jwk
+		if err := json.Unmarshal(raw, &k); err != nil {
+			return nil, ErrInvalidPoPProof
+		}
+		pub, tp, err := k.publicKey()
+		if err != nil || tp != thumbprint {
+			return nil, ErrInvalidPoPProof
+		}
+		return pub, nil
+	}, jwt.WithValidMethods([]string{"ES256", "RS256"}), jwt.WithIssuedAt(), jwt.WithLeeway(popClockLeeway))
+	if err != nil || !token.Valid || claims.IssuedAt == nil || claims.ID == "" {
+		return ErrInvalidPoPProof
+	}
+	if time.Since(claims.IssuedAt.Time) > PoPNonceTTL+popClockLeeway {
+		return ErrInvalidPoPProof
+	}
+	sum := sha256.Sum256([]byte(refreshToken))
+	if claims.RefreshTokenHash != base64.RawURLEncoding.EncodeToString(sum[:]) {
+		return ErrInvalidPoPProof
+	}
+	nonce := &jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(claims.Nonce, nonce, func(token *jwt.Token) (interface{}, error) {
+		return p.verificationKey(), nil
+	}, jwt.WithValidMethods([]string{"ES256", "RS256"}), jwt.WithIssuer(p.issuer), jwt.WithAudience(popNonceAudience))
+	if err != nil || nonce.Subject != sessionID {
+		return ErrInvalidPoPProof
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package security
+
+import (
+	"testing"
+)
+
+func TestPoPKeyThumbprint(t *testing.T) {
+	// RFC 7638 section 3.1 example key and thumbprint.
+	rfcKey := `{"kty":"RSA","n":"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw","e":"AQAB","alg":"RS256","kid":"2011-04-29"}`
+	got, err := PoPKeyThumbprint(rfcKey)
+	if err != nil {
+		t.Fatalf("PoPKeyThumbprint: %v", err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; got != want {
+		t.Errorf("thumbprint = %q, want %q", got, want)
+	}
+	for _, bad := range []string{"", "not json", `{"kty":"oct","k":"c2VjcmV0"}`, `{"kty":"EC","crv":"P-384","x":"AA","y":"AA"}`, `{"kty":"RSA","n":"AQAB","e":"AQAB"}`} {
+		if _, err := PoPKeyThumbprint(bad); err != ErrInvalidPoPKey {
+			t.Errorf("PoPKeyThumbprint(%q): want ErrInvalidPoPKey, got %v", bad, err)
+		}
+	}
+}
+
+func TestVerifyPoPProof(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	key, jwkJSON, err := NewTestPoPKey()
+	if err != nil {
+		t.Fatalf("NewTestPoPKey: %v", err)
+	}
+	thumbprint, err := PoPKeyThumbprint(jwkJSON)
+	if err != nil {
+		t.Fatalf("PoPKeyThumbprint: %v", err)
+	}
+	nonce, _, err := p.IssuePoPNonce("s1")
+	if err != nil {
+		t.Fatalf("IssuePoPNonce: %v", err)
+	}
+	proof, err := SignTestPoPProof(key, nonce, "refresh-1")
+	if err != nil {
+		t.Fatalf("SignTestPoPProof: %v", err)
+	}
+	if err := p.VerifyPoPProof(proof, "refresh-1", "s1", thumbprint); err != nil {
+		t.Errorf("VerifyPoPProof: %v", err)
+	}
+	if err := p.VerifyPoPProof(proof, "refresh-2", "s1", thumbprint); err != ErrInvalidPoPProof {
+		t.Errorf("other refresh token: want ErrInvalidPoPProof, got %v", err)
+	}
+	if err := p.VerifyPoPProof(proof, "refresh-1", "s2", thumbprint); err != ErrInvalidPoPProof {
+		t.Errorf("nonce for other session: want ErrInvalidPoPProof, got %v", err)
+	}
+	otherKey, _, _ := NewTestPoPKey()
+	forged, _ := SignTestPoPProof(otherKey, nonce, "refresh-1")
+	if err := p.VerifyPoPProof(forged, "refresh-1", "s1", thumbprint); err != ErrInvalidPoPProof {
+		t.Errorf("other key: want ErrInvalidPoPProof, got %v", err)
+	}
+	badNonce, _ := SignTestPoPProof(key, "made-up", "refresh-1")
+	if err := p.VerifyPoPProof(badNonce, "refresh-1", "s1", thumbprint); err != ErrInvalidPoPProof {
+		t.Errorf("bad nonce: want ErrInvalidPoPProof, got %v", err)
+	}
+	if err := p.VerifyPoPProof("", "refresh-1", "s1", thumbprint); err != ErrInvalidPoPProof {
+		t.Errorf("empty proof: want ErrInvalidPoPProof, got %v", err)
+	}
+}
+
+func TestIssuePoPNonce_NotAToken(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	nonce, _, err := p.IssuePoPNonce("s1")
+	if err != nil {
+		t.Fatalf("IssuePoPNonce: %v", err)
+	}
+	if _, _, _, _, err := p.ValidateRefresh(nonce); err != ErrInvalidToken {
+		t.Errorf("ValidateRefresh on nonce: want ErrInvalidToken, got %v", err)
+	}
+	if _, _, _, err := p.ValidateAccess(nonce); err != ErrInvalidToken {
+		t.Errorf("ValidateAccess on nonce: want ErrInvalidToken, got %v", err)
+	}
+}
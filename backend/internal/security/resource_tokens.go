@@ -0,0 +1,76 @@
+package security
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IssuedTokenTypeJWT is the RFC 8693 issued_token_type of resource tokens.
+const IssuedTokenTypeJWT = "urn:ietf:params:oauth:token-type:jwt"
+
+// ErrPlatformAudience is returned when a resource token is requested for the control plane's own audience, which
+// would make it as broad as an access token.
+var ErrPlatformAudience = errors.New("resource token audience must differ from the platform audience")
+
+// ResourceClaims holds JWT claims for a resource token: a short-lived token for one target service, obtained by
+// token exchange. Its aud contains only that service and its typ is resource, so ValidateAccess rejects it.
+type ResourceClaims struct {
+	jwt.RegisteredClaims
+	Type      string `json:"typ"`
+	OrgID     string `json:"org_id"`
+	SessionID string `json:"session_id"`
+	Scope     string `json:"scope,omitempty"`
+}
+
+// IssueResource issues a resource token for audience with the given scope and lifetime, bound to the caller's session.
+func (p *TokenProvider) IssueResource(sessionID, userID, orgID, audience, scope string, ttl time.Duration) (token, jti string, expiresAt time.Time, err error) {
+	if audience == "" || audience == p.audience {
+		return "", "", time.Time{}, ErrPlatformAudience
+	}
+	jti, err = generateJTI()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	now := time.Now().UTC()
+	expiresAt = now.Add(ttl)
+	claims := ResourceClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   userID,
+			Issuer:    p.issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Type:      TokenTypeResource,
+		OrgID:     orgID,
+		SessionID: sessionID,
+		Scope:     scope,
+	}
+	token, err = p.sign(claims)
+	return token, jti, expiresAt, err
+}
+
+// ValidateResource parses and validates a resource token for audience (signature, exp, nbf, iat, iss, aud and typ
+// resource). Target services with the public key can perform the same checks. The typ check rejects other tokens the
+// platform signs that can carry audience: access tokens with an org's custom audiences and PoP nonces.
+func (p *TokenProvider) ValidateResource(tokenString, audience string) (*ResourceClaims, error) {
+	claims := &ResourceClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			return p.verificationKey(), nil
+		}
+		return nil, ErrInvalidToken
+	}, p.parserOptions(jwt.WithIssuer(p.issuer), jwt.WithAudience(audience))...)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	if !token.Valid || claims.Type != TokenTypeResource {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,84 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIssueResource_AudienceRestricted(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	token, jti, exp, err := p.IssueResource("s1", "u1", "o1", "payroll-gateway", "read:payslips", time.Minute)
+	if err != nil {
+		t.Fatalf("IssueResource: %v", err)
+	}
+	if jti == "" || time.Until(exp) > time.Minute {
+		t.Errorf("jti = %q, expires in %v", jti, time.Until(exp))
+	}
+	claims, err := p.ValidateResource(token, "payroll-gateway")
+	if err != nil {
+		t.Fatalf("ValidateResource: %v", err)
+	}
+	if claims.Subject != "u1" || claims.OrgID != "o1" || claims.SessionID != "s1" || claims.Scope != "read:payslips" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if _, err := p.ValidateResource(token, "reports-api"); err != ErrInvalidToken {
+		t.Errorf("ValidateResource other audience: want ErrInvalidToken, got %v", err)
+	}
+	if _, _, _, err := p.ValidateAccess(token); err != ErrInvalidToken {
+		t.Errorf("ValidateAccess on resource token: want ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestIssueResource_RejectsPlatformAudience(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	for _, aud := range []string{"", "test-audience"} {
+		if _, _, _, err := p.IssueResource("s1", "u1", "o1", aud, "", time.Minute); err != ErrPlatformAudience {
+			t.Errorf("audience %q: want ErrPlatformAudience, got %v", aud, err)
+		}
+	}
+}
+
+func TestValidateResource_Expired(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	token, _, _, err := p.IssueResource("s1", "u1", "o1", "payroll-gateway", "", -time.Minute)
+	if err != nil {
+		t.Fatalf("IssueResource: %v", err)
+	}
+	if _, err := p.ValidateResource(token, "payroll-gateway"); !errors.Is(err, ErrTokenExpired) || !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired token: want ErrTokenExpired wrapping ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestValidateResource_RejectsOtherTokenTypes(t *testing.T) {
+	p, err := NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	// An org's token_claims can add audiences to its access tokens.
+	p.SetClaimsProviders(staticClaimsProvider{cc: &CustomClaims{Audiences: []string{"payroll-gateway"}}})
+	access, _, _, err := p.IssueAccessContext(context.Background(), "s1", "u1", "o1")
+	if err != nil {
+		t.Fatalf("IssueAccessContext: %v", err)
+	}
+	if _, err := p.ValidateResource(access, "payroll-gateway"); err != ErrInvalidToken {
+		t.Errorf("access token with the audience: want ErrInvalidToken, got %v", err)
+	}
+	nonce, _, err := p.IssuePoPNonce("s1")
+	if err != nil {
+		t.Fatalf("IssuePoPNonce: %v", err)
+	}
+	if _, err := p.ValidateResource(nonce, popNonceAudience); err != ErrInvalidToken {
+		t.Errorf("PoP nonce for its audience: want ErrInvalidToken, got %v", err)
+	}
+}
@@ -1,6 +1,16 @@
 package security
 
-import "time"
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 // Test key pair (RSA 1024) for unit tests only. Do not use in production.
 const (
@@ -41,3 +51,38 @@ func NewTestTokenProvider() (*TokenProvider, error) {
 	}
 	return NewTokenProvider(signer, pub, "test-issuer", "test-audience", 15*time.Minute, 24*time.Hour), nil
 }
+
+// NewTestPoPKey returns a fresh P-256 key and its public JWK (JSON) for proof-of-possession tests.
+// For unit tests only.
+func NewTestPoPKey() (*ecdsa.PrivateKey, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := json.Marshal(testPoPJWK(key))
+	return key, string(b), err
+}
+
+// SignTestPoPProof returns a refresh proof over nonce and refreshToken signed with key. For unit tests only.
+func SignTestPoPProof(key *ecdsa.PrivateKey, nonce, refreshToken string) (string, error) {
+	jti, err := generateJTI()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(refreshToken))
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, PoPProofClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ID: jti, IssuedAt: jwt.NewNumericDate(time.Now())},
+		Nonce:            nonce,
+		RefreshTokenHash: base64.RawURLEncoding.EncodeToString(sum[:]),
+	})
+	t.Header["typ"] = PoPProofType
+	t.Header["jwk"] = testPoPJWK(key)
+	return t.SignedString(key)
+}
+
+func testPoPJWK(key *ecdsa.PrivateKey) jwk {
+	x, y := make([]byte, 32), make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+	return jwk{Kty: "EC", Crv: "P-256", X: base64.RawURLEncoding.EncodeToString(x), Y: base64.RawURLEncoding.EncodeToString(y)}
+}
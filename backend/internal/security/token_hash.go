@@ -0,0 +1,14 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken returns the stored form of a random bearer token (magic links, email changes, invitations, data exports,
+// login holds), by which it is looked up: its SHA-256, hex-encoded. The tokens are random, so a plain hash without a
+// salt or key suffices.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
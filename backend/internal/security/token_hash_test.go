@@ -0,0 +1,19 @@
+package security
+
+import (
+	"testing"
+)
+
+func TestHashToken_KnownValue(t *testing.T) {
+	// SHA-256 of "abc", so hashes stored before the helper was shared still match.
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := HashToken("abc"); got != want {
+		t.Errorf("HashToken(abc) = %s, want %s", got, want)
+	}
+	if HashToken("ztcp_ml_a") == HashToken("ztcp_ml_b") {
+		t.Error("HashToken produced the same hash for different tokens")
+	}
+	if HashRefreshToken("abc") != want {
+		t.Error("HashRefreshToken must hash like HashToken")
+	}
+}
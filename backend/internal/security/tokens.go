@@ -1,12 +1,15 @@
 package security
 
 import (
+	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -15,11 +18,27 @@ import (
 var (
 	// ErrInvalidToken is returned when a token is malformed or invalid.
 	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is returned when a token's exp is past, even allowing for the clock leeway. It wraps
+	// ErrInvalidToken.
+	ErrTokenExpired = fmt.Errorf("%w: token expired", ErrInvalidToken)
+	// ErrTokenNotYetValid is returned when a token's nbf or iat is in the future, even allowing for the clock leeway;
+	// usually a sign of clock skew between the issuer and this server. It wraps ErrInvalidToken.
+	ErrTokenNotYetValid = fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+)
+
+// Token types, carried in the typ claim. Access, refresh and resource tokens share the issuer and signing key, and
+// access and refresh tokens the audience, so each validator also requires its own type: a refresh token is not
+// accepted as a bearer access token, nor an access token as a refresh token.
+const (
+	TokenTypeAccess   = "access"
+	TokenTypeRefresh  = "refresh"
+	TokenTypeResource = "resource"
 )
 
 // AccessClaims holds JWT claims for the access token.
 type AccessClaims struct {
 	jwt.RegisteredClaims
+	Type      string `json:"typ"`
 	OrgID     string `json:"org_id"`
 	SessionID string `json:"session_id"`
 }
@@ -27,26 +46,36 @@ type AccessClaims struct {
 // RefreshClaims holds JWT claims for the refresh token (includes jti for rotation).
 type RefreshClaims struct {
 	jwt.RegisteredClaims
+	Type      string `json:"typ"`
 	SessionID string `json:"session_id"`
 	OrgID     string `json:"org_id"`
 }
 
 // TokenProvider issues and validates JWT access and refresh tokens using RS256 or ES256 (private/public key).
 type TokenProvider struct {
+	mu         sync.RWMutex // guards keys, TTLs and the clock leeway, which can change at runtime
 	privateKey crypto.Signer
 	publicKey  crypto.PublicKey
-	issuer     string
-	audience   string
-	accessTTL  time.Duration
-	refreshTTL time.Duration
+	// previousPublicKey still verifies tokens signed before the last SetKeys, so a key rotation does not log
+	// everyone out. It is dropped on the next rotation.
+	previousPublicKey crypto.PublicKey
+	issuer            string
+	audience          string
+	accessTTL         time.Duration
+	refreshTTL        time.Duration
+	// clockLeeway is allowed on exp, nbf and iat when validating, so small clock differences between the issuer
+	// and clients or other instances do not reject valid tokens.
+	clockLeeway time.Duration
+
+	claimsProviders []ClaimsProvider
 }
 
 // NewTokenProvider returns a TokenProvider that signs with the given private key (RS256 or ES256).
 // issuer and audience are set on claims and validated on refresh.
 func NewTokenProvider(privateKey crypto.Signer, publicKey crypto.PublicKey, issuer, audience string, accessTTL, refreshTTL time.Duration) *TokenProvider {
 	return &TokenProvider{
-		privateKey:  privateKey,
-		publicKey:   publicKey,
+		privateKey: privateKey,
+		publicKey:  publicKey,
 		issuer:     issuer,
 		audience:   audience,
 		accessTTL:  accessTTL,
@@ -54,28 +83,151 @@ func NewTokenProvider(privateKey crypto.Signer, publicKey crypto.PublicKey, issu
 	}
 }
 
+// SetKeys rotates the signing key pair. New tokens are signed with privateKey; tokens signed with the previous
+// key remain valid until they expire or the keys are rotated again. Setting the current key again is a no-op and
+// returns false. Safe for concurrent use.
+func (p *TokenProvider) SetKeys(privateKey crypto.Signer, publicKey crypto.PublicKey) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if publicKeysEqual(p.publicKey, publicKey) {
+		return false
+	}
+	p.previousPublicKey = p.publicKey
+	p.privateKey = privateKey
+	p.publicKey = publicKey
+	return true
+}
+
+// SetTTLs changes the lifetime of tokens issued from now on; issued tokens keep their expiry. Non-positive values
+// keep the current TTL. Safe for concurrent use.
+func (p *TokenProvider) SetTTLs(accessTTL, refreshTTL time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if accessTTL > 0 {
+		p.accessTTL = accessTTL
+	}
+	if refreshTTL > 0 {
+		p.refreshTTL = refreshTTL
+	}
+}
+
+// SetClockLeeway changes the leeway allowed on exp, nbf and iat when validating tokens. Negative values are treated
+// as zero. Safe for concurrent use.
+func (p *TokenProvider) SetClockLeeway(leeway time.Duration) {
+	if leeway < 0 {
+		leeway = 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clockLeeway = leeway
+}
+
+// parserOptions returns the time validation options for jwt.ParseWithClaims: the clock leeway, and a check that
+// iat is not in the future.
+func (p *TokenProvider) parserOptions(opts ...jwt.ParserOption) []jwt.ParserOption {
+	p.mu.RLock()
+	leeway := p.clockLeeway
+	p.mu.RUnlock()
+	return append([]jwt.ParserOption{jwt.WithLeeway(leeway), jwt.WithIssuedAt()}, opts...)
+}
+
+// validationError maps a jwt parse error to ErrTokenExpired, ErrTokenNotYetValid or ErrInvalidToken, so callers
+// can tell clock skew apart from bad tokens without seeing the jwt library's errors.
+func validationError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ErrTokenNotYetValid
+	}
+	return ErrInvalidToken
+}
+
+func (p *TokenProvider) ttls() (accessTTL, refreshTTL time.Duration) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.accessTTL, p.refreshTTL
+}
+
+func (p *TokenProvider) signingKey() crypto.Signer {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.privateKey
+}
+
+// verificationKey returns the current public key, or a key set with the previous one during a rotation.
+// The result is meant to be returned from a jwt.Keyfunc.
+func (p *TokenProvider) verificationKey() interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.previousPublicKey == nil {
+		return p.publicKey
+	}
+	return jwt.VerificationKeySet{Keys: []jwt.VerificationKey{p.publicKey, p.previousPublicKey}}
+}
+
 // IssueAccess issues a short-lived access JWT for the given session, user, and org.
 // Returns the token string, its jti, and expiration time.
 func (p *TokenProvider) IssueAccess(sessionID, userID, orgID string) (token string, jti string, expiresAt time.Time, err error) {
+	return p.IssueAccessContext(context.Background(), sessionID, userID, orgID)
+}
+
+// IssueAccessContext is IssueAccess with custom claims and audiences from the configured ClaimsProviders.
+// The platform audience is always first, so ValidateAccess accepts the token regardless of custom audiences.
+func (p *TokenProvider) IssueAccessContext(ctx context.Context, sessionID, userID, orgID string) (token string, jti string, expiresAt time.Time, err error) {
+	return p.IssueAccessUntil(ctx, sessionID, userID, orgID, time.Time{})
+}
+
+// IssueAccessUntil is IssueAccessContext with the token's expiry capped at notAfter (e.g. the end of a time-boxed
+// session). A zero notAfter applies no cap.
+func (p *TokenProvider) IssueAccessUntil(ctx context.Context, sessionID, userID, orgID string, notAfter time.Time) (token string, jti string, expiresAt time.Time, err error) {
 	jti, err = generateJTI()
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
+	audiences, extra, err := p.customClaims(ctx, userID, orgID)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
 	now := time.Now().UTC()
-	expiresAt = now.Add(p.accessTTL)
+	accessTTL, _ := p.ttls()
+	expiresAt = now.Add(accessTTL)
+	if !notAfter.IsZero() && notAfter.Before(expiresAt) {
+		expiresAt = notAfter.UTC()
+	}
 	claims := AccessClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        jti,
 			Subject:   userID,
 			Issuer:    p.issuer,
-			Audience:  jwt.ClaimStrings{p.audience},
+			Audience:  append(jwt.ClaimStrings{p.audience}, audiences...),
 			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
+		Type:      TokenTypeAccess,
 		OrgID:     orgID,
 		SessionID: sessionID,
 	}
-	token, err = p.sign(claims)
+	if len(extra) == 0 {
+		token, err = p.sign(claims)
+		return token, jti, expiresAt, err
+	}
+	mapClaims := jwt.MapClaims{}
+	for name, v := range extra {
+		mapClaims[name] = v
+	}
+	mapClaims["jti"] = jti
+	mapClaims["sub"] = userID
+	mapClaims["iss"] = p.issuer
+	mapClaims["aud"] = claims.Audience
+	mapClaims["iat"] = claims.IssuedAt
+	mapClaims["nbf"] = claims.NotBefore
+	mapClaims["exp"] = claims.ExpiresAt
+	mapClaims["typ"] = TokenTypeAccess
+	mapClaims["org_id"] = orgID
+	mapClaims["session_id"] = sessionID
+	token, err = p.sign(mapClaims)
 	return token, jti, expiresAt, err
 }
 
@@ -87,7 +239,8 @@ func (p *TokenProvider) IssueRefresh(sessionID, userID, orgID string) (token, jt
 		return "", "", time.Time{}, err
 	}
 	now := time.Now().UTC()
-	expiresAt = now.Add(p.refreshTTL)
+	_, refreshTTL := p.ttls()
+	expiresAt = now.Add(refreshTTL)
 	claims := RefreshClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        jti,
@@ -95,8 +248,10 @@ func (p *TokenProvider) IssueRefresh(sessionID, userID, orgID string) (token, jt
 			Issuer:    p.issuer,
 			Audience:  jwt.ClaimStrings{p.audience},
 			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
+		Type:      TokenTypeRefresh,
 		SessionID: sessionID,
 		OrgID:     orgID,
 	}
@@ -105,8 +260,9 @@ func (p *TokenProvider) IssueRefresh(sessionID, userID, orgID string) (token, jt
 }
 
 func (p *TokenProvider) sign(claims jwt.Claims) (string, error) {
+	privateKey := p.signingKey()
 	var method jwt.SigningMethod
-	switch p.privateKey.Public().(type) {
+	switch privateKey.Public().(type) {
 	case *rsa.PublicKey:
 		method = jwt.SigningMethodRS256
 	case *ecdsa.PublicKey:
@@ -115,26 +271,30 @@ func (p *TokenProvider) sign(claims jwt.Claims) (string, error) {
 		return "", ErrInvalidToken
 	}
 	t := jwt.NewWithClaims(method, claims)
-	return t.SignedString(p.privateKey)
+	// kid lets services that verify with the JWKS pick the key, also during a rotation.
+	if kid := KeyID(privateKey.Public()); kid != "" {
+		t.Header["kid"] = kid
+	}
+	return t.SignedString(privateKey)
 }
 
-// ValidateRefresh parses and validates the refresh token (signature, exp, iss, aud).
-// Returns sessionID, jti, userID, orgID, or error.
+// ValidateRefresh parses and validates the refresh token (signature, exp, nbf, iat, iss, aud, and typ refresh).
+// Returns sessionID, jti, userID, orgID, or error; ErrTokenExpired or ErrTokenNotYetValid for time failures.
 func (p *TokenProvider) ValidateRefresh(tokenString string) (sessionID, jti, userID, orgID string, err error) {
 	token, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
-			return p.publicKey, nil
+			return p.verificationKey(), nil
 		}
 		if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
-			return p.publicKey, nil
+			return p.verificationKey(), nil
 		}
 		return nil, ErrInvalidToken
-	})
+	}, p.parserOptions()...)
 	if err != nil {
-		return "", "", "", "", ErrInvalidToken
+		return "", "", "", "", validationError(err)
 	}
 	claims, ok := token.Claims.(*RefreshClaims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.Type != TokenTypeRefresh {
 		return "", "", "", "", ErrInvalidToken
 	}
 	if claims.Issuer != p.issuer {
@@ -153,27 +313,36 @@ func (p *TokenProvider) ValidateRefresh(tokenString string) (sessionID, jti, use
 	return claims.SessionID, claims.ID, claims.Subject, claims.OrgID, nil
 }
 
-// ValidateAccess parses and validates the access token (signature, exp, iss, aud).
-// Returns sessionID, userID, orgID, or error.
+// ValidateAccess parses and validates the access token (signature, exp, nbf, iat, iss, aud, and typ access).
+// Returns sessionID, userID, orgID, or error; ErrTokenExpired or ErrTokenNotYetValid for time failures.
 func (p *TokenProvider) ValidateAccess(tokenString string) (sessionID, userID, orgID string, err error) {
+	claims, err := p.ParseAccess(tokenString)
+	if err != nil {
+		return "", "", "", err
+	}
+	return claims.SessionID, claims.Subject, claims.OrgID, nil
+}
+
+// ParseAccess is ValidateAccess returning all claims of the token (e.g. jti, iat and exp for introspection).
+func (p *TokenProvider) ParseAccess(tokenString string) (*AccessClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AccessClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
-			return p.publicKey, nil
+			return p.verificationKey(), nil
 		}
 		if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
-			return p.publicKey, nil
+			return p.verificationKey(), nil
 		}
 		return nil, ErrInvalidToken
-	})
+	}, p.parserOptions()...)
 	if err != nil {
-		return "", "", "", ErrInvalidToken
+		return nil, validationError(err)
 	}
 	claims, ok := token.Claims.(*AccessClaims)
-	if !ok || !token.Valid {
-		return "", "", "", ErrInvalidToken
+	if !ok || !token.Valid || claims.Type != TokenTypeAccess {
+		return nil, ErrInvalidToken
 	}
 	if claims.Issuer != p.issuer {
-		return "", "", "", ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 	audOk := false
 	for _, a := range claims.Audience {
@@ -183,9 +352,9 @@ func (p *TokenProvider) ValidateAccess(tokenString string) (sessionID, userID, o
 		}
 	}
 	if !audOk {
-		return "", "", "", ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
-	return claims.SessionID, claims.Subject, claims.OrgID, nil
+	return claims, nil
 }
 
 func generateJTI() (string, error) {
@@ -0,0 +1,88 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+)
+
+// benchmarkKeys are the key types a deployment can sign with: RSA (RS256) at the recommended size and P-256 (ES256).
+func benchmarkKeys(b *testing.B) []benchmarkKey {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		b.Fatalf("generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		b.Fatalf("generate EC key: %v", err)
+	}
+	return []benchmarkKey{{"rs256_2048", rsaKey}, {"es256", ecKey}}
+}
+
+type benchmarkKey struct {
+	name string
+	key  crypto.Signer
+}
+
+func BenchmarkTokenProvider(b *testing.B) {
+	for _, k := range benchmarkKeys(b) {
+		name := k.name
+		p := NewTokenProvider(k.key, k.key.Public(), "bench-issuer", "bench-audience", 15*time.Minute, 24*time.Hour)
+		access, _, _, err := p.IssueAccess("session-1", "user-1", "org-1")
+		if err != nil {
+			b.Fatalf("IssueAccess: %v", err)
+		}
+		refresh, _, _, err := p.IssueRefresh("session-1", "user-1", "org-1")
+		if err != nil {
+			b.Fatalf("IssueRefresh: %v", err)
+		}
+
+		b.Run(name+"/issue_access", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := p.IssueAccess("session-1", "user-1", "org-1"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/issue_refresh", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := p.IssueRefresh("session-1", "user-1", "org-1"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/validate_access", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := p.ValidateAccess(access); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/validate_refresh", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, _, _, err := p.ValidateRefresh(refresh); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/validate_access_parallel", func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, _, _, err := p.ValidateAccess(access); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
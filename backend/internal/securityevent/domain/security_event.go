@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// EventType identifies the kind of suspicious activity a security event reports.
+type EventType string
+
+const (
+	EventLoginFailure        EventType = "login_failure"            // wrong password for an existing account
+	EventRefreshTokenReuse   EventType = "refresh_token_reuse"      // rotated refresh token presented again; all sessions revoked
+	EventImpossibleTravel    EventType = "impossible_travel"        // sign-ins from different locations too close together
+	EventDeviceRevoked       EventType = "device_revoked"           // one of the user's devices was revoked
+	EventRecoveryCodeUsed    EventType = "recovery_code_used"       // an MFA recovery code was used in place of the second factor
+	EventPhoneChanged        EventType = "phone_changed"            // the user's MFA phone number was changed
+	EventMFAReset            EventType = "mfa_reset"                // an org admin reset the user's MFA; sessions were revoked
+	EventOrgLogout           EventType = "org_logout"               // an org owner signed everyone out of the org
+	EventLogoutAll           EventType = "logout_all"               // the user signed out of their sessions on every device
+	EventEmailChanged        EventType = "email_changed"            // the user's email was changed; sessions were revoked
+	EventLoginHeld           EventType = "login_held"               // a high-risk sign-in is waiting for an org admin's approval
+	EventHoneytokenTriggered EventType = "honeytoken_triggered"     // someone tried to sign in to this decoy account
+	EventDeviceQuarantined   EventType = "device_quarantined"       // an admin quarantined one of the user's devices (suspected compromise)
+	EventDeviceReleased      EventType = "device_released"          // an admin released one of the user's devices from quarantine
+	EventQuarantinedLogin    EventType = "quarantined_device_login" // a sign-in was attempted from a quarantined device
+
+	// Informational: raised by the trust expiry notice job (device_trust.expiry_notice_days).
+	EventDeviceTrustExpiring EventType = "device_trust_expiring" // a trusted device's trust expires soon; MFA will be required again
+
+	// Raised by the anomaly detector (cmd/detector) from audit log patterns.
+	EventCredentialStuffing    EventType = "credential_stuffing"     // one IP failed sign-in against many accounts, including this one
+	EventDistributedBruteForce EventType = "distributed_brute_force" // failed sign-ins to this account from many IPs
+)
+
+// Severity ranks how urgently the user should review an event.
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// SeverityFor returns the default severity for an event type.
+func SeverityFor(t EventType) Severity {
+	switch t {
+	case EventRefreshTokenReuse, EventImpossibleTravel, EventCredentialStuffing, EventDistributedBruteForce, EventMFAReset, EventLoginHeld, EventHoneytokenTriggered, EventQuarantinedLogin:
+		return SeverityHigh
+	case EventDeviceRevoked, EventRecoveryCodeUsed, EventPhoneChanged, EventOrgLogout, EventLogoutAll, EventEmailChanged, EventDeviceQuarantined:
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+// SecurityEvent is one entry in a user's security activity feed.
+// Acknowledged events stay in the feed; dismissed events are hidden unless explicitly requested.
+type SecurityEvent struct {
+	ID             string
+	OrgID          string // empty when the event has no org (e.g. token reuse before org is resolved)
+	UserID         string
+	Type           EventType
+	Severity       Severity
+	IP             string
+	Metadata       string // JSON object with type-specific details
+	CreatedAt      time.Time
+	AcknowledgedAt *time.Time
+	DismissedAt    *time.Time
+}
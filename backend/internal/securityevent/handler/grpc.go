@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	securityeventv1 "zero-trust-control-plane/backend/api/generated/securityevent/v1"
+	"zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/securityevent/repository"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// Server implements SecurityEventsService. Every RPC acts on the authenticated caller's own feed.
+// Proto: securityevent/securityevent.proto → internal/securityevent/handler.
+type Server struct {
+	securityeventv1.UnimplementedSecurityEventsServiceServer
+	repo repository.Repository
+}
+
+// NewServer returns a new SecurityEvents gRPC server. repo may be nil; then all RPCs return Unimplemented.
+func NewServer(repo repository.Repository) *Server {
+	return &Server{repo: repo}
+}
+
+// ListSecurityEvents returns the caller's security events, newest first. Dismissed events are omitted unless requested.
+func (s *Server) ListSecurityEvents(ctx context.Context, req *securityeventv1.ListSecurityEventsRequest) (*securityeventv1.ListSecurityEventsResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListSecurityEvents not implemented")
+	}
+	userID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.repo.ListByUser(ctx, userID, req.GetIncludeDismissed(), pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list security events")
+	}
+	events := make([]*securityeventv1.SecurityEvent, len(list))
+	for i, e := range list {
+		events[i] = eventToProto(e)
+	}
+	result := &securityeventv1.ListSecurityEventsResponse{
+		Events:     events,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// AcknowledgeSecurityEvent marks one of the caller's events as reviewed. Idempotent.
+func (s *Server) AcknowledgeSecurityEvent(ctx context.Context, req *securityeventv1.AcknowledgeSecurityEventRequest) (*securityeventv1.AcknowledgeSecurityEventResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method AcknowledgeSecurityEvent not implemented")
+	}
+	e, err := s.update(ctx, req.GetEventId(), s.repo.Acknowledge)
+	if err != nil {
+		return nil, err
+	}
+	return &securityeventv1.AcknowledgeSecurityEventResponse{Event: eventToProto(e)}, nil
+}
+
+// DismissSecurityEvent hides one of the caller's events from the default feed. Idempotent.
+func (s *Server) DismissSecurityEvent(ctx context.Context, req *securityeventv1.DismissSecurityEventRequest) (*securityeventv1.DismissSecurityEventResponse, error) {
+	if s.repo == nil {
+		return nil, status.Error(codes.Unimplemented, "method DismissSecurityEvent not implemented")
+	}
+	e, err := s.update(ctx, req.GetEventId(), s.repo.Dismiss)
+	if err != nil {
+		return nil, err
+	}
+	return &securityeventv1.DismissSecurityEventResponse{Event: eventToProto(e)}, nil
+}
+
+// update loads the caller's event, applies fn, and returns the reloaded event.
+// Events belonging to other users are reported as NotFound so IDs cannot be probed.
+func (s *Server) update(ctx context.Context, eventID string, fn func(context.Context, string, time.Time) error) (*domain.SecurityEvent, error) {
+	userID, err := callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, status.Error(codes.InvalidArgument, "event_id required")
+	}
+	e, err := s.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up security event")
+	}
+	if e == nil || e.UserID != userID {
+		return nil, status.Error(codes.NotFound, "security event not found")
+	}
+	if err := fn(ctx, eventID, time.Now().UTC()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to update security event")
+	}
+	updated, err := s.repo.GetByID(ctx, eventID)
+	if err != nil || updated == nil {
+		return nil, status.Error(codes.Internal, "failed to look up security event")
+	}
+	return updated, nil
+}
+
+func callerUserID(ctx context.Context) (string, error) {
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return "", status.Error(codes.Unauthenticated, "user context required")
+	}
+	return userID, nil
+}
+
+func eventToProto(e *domain.SecurityEvent) *securityeventv1.SecurityEvent {
+	if e == nil {
+		return nil
+	}
+	out := &securityeventv1.SecurityEvent{
+		Id:        e.ID,
+		OrgId:     e.OrgID,
+		UserId:    e.UserID,
+		Type:      string(e.Type),
+		Severity:  string(e.Severity),
+		Ip:        e.IP,
+		Metadata:  e.Metadata,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.AcknowledgedAt != nil {
+		out.AcknowledgedAt = timestamppb.New(*e.AcknowledgedAt)
+	}
+	if e.DismissedAt != nil {
+		out.DismissedAt = timestamppb.New(*e.DismissedAt)
+	}
+	return out
+}
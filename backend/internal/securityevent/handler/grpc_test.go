@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	securityeventv1 "zero-trust-control-plane/backend/api/generated/securityevent/v1"
+	"zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+)
+
+type mockSecurityEventRepo struct {
+	events map[string]*domain.SecurityEvent
+}
+
+func newMockRepo(events ...*domain.SecurityEvent) *mockSecurityEventRepo {
+	m := &mockSecurityEventRepo{events: make(map[string]*domain.SecurityEvent)}
+	for _, e := range events {
+		m.events[e.ID] = e
+	}
+	return m
+}
+
+func (m *mockSecurityEventRepo) Create(ctx context.Context, e *domain.SecurityEvent) error {
+	m.events[e.ID] = e
+	return nil
+}
+
+func (m *mockSecurityEventRepo) GetByID(ctx context.Context, id string) (*domain.SecurityEvent, error) {
+	e := m.events[id]
+	if e == nil {
+		return nil, nil
+	}
+	cp := *e
+	return &cp, nil
+}
+
+func (m *mockSecurityEventRepo) CountSince(ctx context.Context, userID string, eventType domain.EventType, since time.Time) (int64, error) {
+	var n int64
+	for _, e := range m.events {
+		if e.UserID == userID && e.Type == eventType && !e.CreatedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *mockSecurityEventRepo) ListByUser(ctx context.Context, userID string, includeDismissed bool, limit, offset int32) ([]*domain.SecurityEvent, error) {
+	var out []*domain.SecurityEvent
+	for _, e := range m.events {
+		if e.UserID != userID || (!includeDismissed && e.DismissedAt != nil) {
+			continue
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	if int(offset) >= len(out) {
+		return nil, nil
+	}
+	out = out[offset:]
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *mockSecurityEventRepo) Acknowledge(ctx context.Context, id string, at time.Time) error {
+	if e := m.events[id]; e != nil && e.AcknowledgedAt == nil {
+		e.AcknowledgedAt = &at
+	}
+	return nil
+}
+
+func (m *mockSecurityEventRepo) Dismiss(ctx context.Context, id string, at time.Time) error {
+	if e := m.events[id]; e != nil && e.DismissedAt == nil {
+		e.DismissedAt = &at
+	}
+	return nil
+}
+
+func ctxWithUser(userID string) context.Context {
+	return interceptors.WithIdentity(context.Background(), userID, "org-1", "session-1")
+}
+
+func event(id, userID string, createdAt time.Time) *domain.SecurityEvent {
+	return &domain.SecurityEvent{
+		ID:        id,
+		OrgID:     "org-1",
+		UserID:    userID,
+		Type:      domain.EventLoginFailure,
+		Severity:  domain.SeverityFor(domain.EventLoginFailure),
+		IP:        "203.0.113.7",
+		CreatedAt: createdAt,
+	}
+}
+
+func TestListSecurityEvents_OwnEventsNewestFirst(t *testing.T) {
+	now := time.Now().UTC()
+	repo := newMockRepo(
+		event("e1", "user-1", now.Add(-2*time.Hour)),
+		event("e2", "user-1", now.Add(-time.Hour)),
+		event("e3", "user-2", now),
+	)
+	srv := NewServer(repo)
+	resp, err := srv.ListSecurityEvents(ctxWithUser("user-1"), &securityeventv1.ListSecurityEventsRequest{})
+	if err != nil {
+		t.Fatalf("ListSecurityEvents: %v", err)
+	}
+	if len(resp.Events) != 2 {
+		t.Fatalf("events = %d, want 2", len(resp.Events))
+	}
+	if resp.Events[0].Id != "e2" || resp.Events[1].Id != "e1" {
+		t.Errorf("order = [%s %s], want [e2 e1]", resp.Events[0].Id, resp.Events[1].Id)
+	}
+	if resp.Events[0].Severity != string(domain.SeverityLow) {
+		t.Errorf("severity = %q, want %q", resp.Events[0].Severity, domain.SeverityLow)
+	}
+	if resp.Pagination.NextPageToken != "" {
+		t.Errorf("next page token = %q, want empty", resp.Pagination.NextPageToken)
+	}
+}
+
+func TestListSecurityEvents_Pagination(t *testing.T) {
+	now := time.Now().UTC()
+	repo := newMockRepo(
+		event("e1", "user-1", now.Add(-3*time.Hour)),
+		event("e2", "user-1", now.Add(-2*time.Hour)),
+		event("e3", "user-1", now.Add(-time.Hour)),
+	)
+	srv := NewServer(repo)
+	resp, err := srv.ListSecurityEvents(ctxWithUser("user-1"), &securityeventv1.ListSecurityEventsRequest{
+		Pagination: &commonv1.Pagination{PageSize: 2},
+	})
+	if err != nil {
+		t.Fatalf("ListSecurityEvents: %v", err)
+	}
+	if len(resp.Events) != 2 || resp.Pagination.NextPageToken != "2" {
+		t.Fatalf("events = %d, token = %q; want 2, \"2\"", len(resp.Events), resp.Pagination.NextPageToken)
+	}
+	resp, err = srv.ListSecurityEvents(ctxWithUser("user-1"), &securityeventv1.ListSecurityEventsRequest{
+		Pagination: &commonv1.Pagination{PageSize: 2, PageToken: "2"},
+	})
+	if err != nil {
+		t.Fatalf("ListSecurityEvents page 2: %v", err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].Id != "e1" {
+		t.Errorf("page 2 = %v, want [e1]", resp.Events)
+	}
+}
+
+func TestAcknowledgeSecurityEvent(t *testing.T) {
+	repo := newMockRepo(event("e1", "user-1", time.Now().UTC()))
+	srv := NewServer(repo)
+	resp, err := srv.AcknowledgeSecurityEvent(ctxWithUser("user-1"), &securityeventv1.AcknowledgeSecurityEventRequest{EventId: "e1"})
+	if err != nil {
+		t.Fatalf("AcknowledgeSecurityEvent: %v", err)
+	}
+	if resp.Event.AcknowledgedAt == nil {
+		t.Error("acknowledged_at should be set")
+	}
+	if resp.Event.DismissedAt != nil {
+		t.Error("dismissed_at should not be set")
+	}
+}
+
+func TestDismissSecurityEvent_HiddenFromDefaultList(t *testing.T) {
+	repo := newMockRepo(event("e1", "user-1", time.Now().UTC()))
+	srv := NewServer(repo)
+	if _, err := srv.DismissSecurityEvent(ctxWithUser("user-1"), &securityeventv1.DismissSecurityEventRequest{EventId: "e1"}); err != nil {
+		t.Fatalf("DismissSecurityEvent: %v", err)
+	}
+	resp, err := srv.ListSecurityEvents(ctxWithUser("user-1"), &securityeventv1.ListSecurityEventsRequest{})
+	if err != nil {
+		t.Fatalf("ListSecurityEvents: %v", err)
+	}
+	if len(resp.Events) != 0 {
+		t.Errorf("events = %d, want 0 after dismiss", len(resp.Events))
+	}
+	resp, err = srv.ListSecurityEvents(ctxWithUser("user-1"), &securityeventv1.ListSecurityEventsRequest{IncludeDismissed: true})
+	if err != nil {
+		t.Fatalf("ListSecurityEvents include_dismissed: %v", err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].DismissedAt == nil {
+		t.Errorf("include_dismissed should return the dismissed event, got %v", resp.Events)
+	}
+}
+
+func TestUpdateSecurityEvent_OtherUserNotFound(t *testing.T) {
+	repo := newMockRepo(event("e1", "user-2", time.Now().UTC()))
+	srv := NewServer(repo)
+	_, err := srv.AcknowledgeSecurityEvent(ctxWithUser("user-1"), &securityeventv1.AcknowledgeSecurityEventRequest{EventId: "e1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("acknowledge code = %v, want NotFound", status.Code(err))
+	}
+	_, err = srv.DismissSecurityEvent(ctxWithUser("user-1"), &securityeventv1.DismissSecurityEventRequest{EventId: "e1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("dismiss code = %v, want NotFound", status.Code(err))
+	}
+	if repo.events["e1"].AcknowledgedAt != nil || repo.events["e1"].DismissedAt != nil {
+		t.Error("other user's event should be unchanged")
+	}
+}
+
+func TestUpdateSecurityEvent_MissingEventID(t *testing.T) {
+	srv := NewServer(newMockRepo())
+	_, err := srv.AcknowledgeSecurityEvent(ctxWithUser("user-1"), &securityeventv1.AcknowledgeSecurityEventRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestSecurityEvents_Unauthenticated(t *testing.T) {
+	srv := NewServer(newMockRepo())
+	_, err := srv.ListSecurityEvents(context.Background(), &securityeventv1.ListSecurityEventsRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+func TestSecurityEvents_NilRepo(t *testing.T) {
+	srv := NewServer(nil)
+	_, err := srv.ListSecurityEvents(ctxWithUser("user-1"), &securityeventv1.ListSecurityEventsRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", status.Code(err))
+	}
+}
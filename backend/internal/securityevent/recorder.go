@@ -0,0 +1,57 @@
+// Package securityevent records suspicious activity into per-user security feeds (SecurityEventsService).
+package securityevent
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/securityevent/repository"
+)
+
+// IPExtractor returns the client IP from the request context (e.g. gRPC metadata or peer).
+type IPExtractor func(context.Context) string
+
+// Recorder writes one security event to a user's feed. Record is best-effort: failures are logged and do not
+// affect the caller. metadata is a JSON object with type-specific details, or "".
+type Recorder interface {
+	Record(ctx context.Context, orgID, userID string, eventType domain.EventType, metadata string)
+}
+
+// RepoRecorder implements Recorder using the security event repository.
+type RepoRecorder struct {
+	repo        repository.Repository
+	ipExtractor IPExtractor
+}
+
+// NewRecorder returns a Recorder that persists to repo. ipExtractor may be nil; then IP is recorded as "unknown".
+func NewRecorder(repo repository.Repository, ipExtractor IPExtractor) *RepoRecorder {
+	return &RepoRecorder{repo: repo, ipExtractor: ipExtractor}
+}
+
+// Record persists one event with the type's default severity. Events without a user are dropped.
+func (r *RepoRecorder) Record(ctx context.Context, orgID, userID string, eventType domain.EventType, metadata string) {
+	if r == nil || r.repo == nil || userID == "" {
+		return
+	}
+	ip := "unknown"
+	if r.ipExtractor != nil {
+		ip = r.ipExtractor(ctx)
+	}
+	e := &domain.SecurityEvent{
+		ID:        uuid.New().String(),
+		OrgID:     orgID,
+		UserID:    userID,
+		Type:      eventType,
+		Severity:  domain.SeverityFor(eventType),
+		IP:        ip,
+		Metadata:  metadata,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := r.repo.Create(ctx, e); err != nil {
+		log.Printf("securityevent: failed to record %s for user_id=%s: %v", eventType, userID, err)
+	}
+}
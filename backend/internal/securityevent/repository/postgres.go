@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+type PostgresRepository struct {
+	queries *gen.Queries
+	router  *residency.Router
+}
+
+// NewPostgresRepository returns a security event repository that uses the given db for persistence. When router is
+// not nil, the events of an org with a data region are stored in that region's database; a user's feed is read from
+// every database.
+func NewPostgresRepository(db *sql.DB, router *residency.Router) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), router: router}
+}
+
+// queriesFor returns the queries for the database holding orgID's events. Events without an org are stored in the
+// primary database.
+func (r *PostgresRepository) queriesFor(ctx context.Context, orgID string) (*gen.Queries, error) {
+	if r.router == nil || orgID == "" {
+		return r.queries, nil
+	}
+	db, err := r.router.DB(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return gen.New(db), nil
+}
+
+// eachDatabase calls fn with the queries of every database holding security events.
+func (r *PostgresRepository) eachDatabase(fn func(q *gen.Queries) error) error {
+	if r.router == nil {
+		return fn(r.queries)
+	}
+	for _, db := range r.router.Databases() {
+		if err := fn(gen.New(db)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create persists the security event. The event must have ID set.
+func (r *PostgresRepository) Create(ctx context.Context, e *domain.SecurityEvent) error {
+	q, err := r.queriesFor(ctx, e.OrgID)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateSecurityEvent(ctx, gen.CreateSecurityEventParams{
+		ID:        e.ID,
+		OrgID:     sql.NullString{String: e.OrgID, Valid: e.OrgID != ""},
+		UserID:    e.UserID,
+		EventType: string(e.Type),
+		Severity:  string(e.Severity),
+		Ip:        e.IP,
+		Metadata:  sql.NullString{String: e.Metadata, Valid: e.Metadata != ""},
+		CreatedAt: e.CreatedAt,
+	})
+	return err
+}
+
+// GetByID returns the security event for id from whichever database holds it, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.SecurityEvent, error) {
+	var out *domain.SecurityEvent
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		if out != nil {
+			return nil
+		}
+		row, err := q.GetSecurityEvent(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		out = genSecurityEventToDomain(&row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CountSince returns how many events of eventType the user has had since the given time, in every database.
+func (r *PostgresRepository) CountSince(ctx context.Context, userID string, eventType domain.EventType, since time.Time) (int64, error) {
+	var total int64
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		n, err := q.CountSecurityEventsSince(ctx, gen.CountSecurityEventsSinceParams{
+			UserID:    userID,
+			EventType: string(eventType),
+			CreatedAt: since,
+		})
+		total += n
+		return err
+	})
+	return total, err
+}
+
+// ListByUser returns the user's security events from every database, newest first, paginated by limit and offset.
+func (r *PostgresRepository) ListByUser(ctx context.Context, userID string, includeDismissed bool, limit, offset int32) ([]*domain.SecurityEvent, error) {
+	if r.router == nil {
+		return listByUser(ctx, r.queries, userID, includeDismissed, limit, offset)
+	}
+	// Each database returns its first offset+limit events; the page is cut from their merge.
+	var out []*domain.SecurityEvent
+	err := r.eachDatabase(func(q *gen.Queries) error {
+		list, err := listByUser(ctx, q, userID, includeDismissed, offset+limit, 0)
+		out = append(out, list...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	if int(offset) >= len(out) {
+		return []*domain.SecurityEvent{}, nil
+	}
+	out = out[offset:]
+	if len(out) > int(limit) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func listByUser(ctx context.Context, q *gen.Queries, userID string, includeDismissed bool, limit, offset int32) ([]*domain.SecurityEvent, error) {
+	list, err := q.ListSecurityEventsByUser(ctx, gen.ListSecurityEventsByUserParams{
+		UserID:           userID,
+		Limit:            limit,
+		Offset:           offset,
+		IncludeDismissed: includeDismissed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.SecurityEvent, len(list))
+	for i := range list {
+		out[i] = genSecurityEventToDomain(&list[i])
+	}
+	return out, nil
+}
+
+// Acknowledge sets acknowledged_at if not already set, in whichever database holds the event.
+func (r *PostgresRepository) Acknowledge(ctx context.Context, id string, at time.Time) error {
+	return r.eachDatabase(func(q *gen.Queries) error {
+		return q.AcknowledgeSecurityEvent(ctx, gen.AcknowledgeSecurityEventParams{
+			ID:             id,
+			AcknowledgedAt: sql.NullTime{Time: at, Valid: true},
+		})
+	})
+}
+
+// Dismiss sets dismissed_at if not already set, in whichever database holds the event.
+func (r *PostgresRepository) Dismiss(ctx context.Context, id string, at time.Time) error {
+	return r.eachDatabase(func(q *gen.Queries) error {
+		return q.DismissSecurityEvent(ctx, gen.DismissSecurityEventParams{
+			ID:          id,
+			DismissedAt: sql.NullTime{Time: at, Valid: true},
+		})
+	})
+}
+
+func genSecurityEventToDomain(e *gen.SecurityEvent) *domain.SecurityEvent {
+	out := &domain.SecurityEvent{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Type:      domain.EventType(e.EventType),
+		Severity:  domain.Severity(e.Severity),
+		IP:        e.Ip,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.OrgID.Valid {
+		out.OrgID = e.OrgID.String
+	}
+	if e.Metadata.Valid {
+		out.Metadata = e.Metadata.String
+	}
+	if e.AcknowledgedAt.Valid {
+		t := e.AcknowledgedAt.Time
+		out.AcknowledgedAt = &t
+	}
+	if e.DismissedAt.Valid {
+		t := e.DismissedAt.Time
+		out.DismissedAt = &t
+	}
+	return out
+}
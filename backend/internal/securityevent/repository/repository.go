@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/securityevent/domain"
+)
+
+// Repository persists per-user security events.
+type Repository interface {
+	// Create persists the event. The event must have ID set.
+	Create(ctx context.Context, e *domain.SecurityEvent) error
+	// GetByID returns the event for id, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.SecurityEvent, error)
+	// CountSince returns how many events of eventType the user has had since the given time (used to avoid duplicate alerts).
+	CountSince(ctx context.Context, userID string, eventType domain.EventType, since time.Time) (int64, error)
+	// ListByUser returns the user's events, newest first. Dismissed events are omitted unless includeDismissed is true.
+	ListByUser(ctx context.Context, userID string, includeDismissed bool, limit, offset int32) ([]*domain.SecurityEvent, error)
+	// Acknowledge marks the event acknowledged at the given time. No-op if already acknowledged.
+	Acknowledge(ctx context.Context, id string, at time.Time) error
+	// Dismiss marks the event dismissed at the given time. No-op if already dismissed.
+	Dismiss(ctx context.Context, id string, at time.Time) error
+}
@@ -1,43 +1,120 @@
 package server
 
 import (
+	"time"
+
 	"google.golang.org/grpc"
 
 	adminv1 "zero-trust-control-plane/backend/api/generated/admin/v1"
+	agentv1 "zero-trust-control-plane/backend/api/generated/agent/v1"
+	analyticsv1 "zero-trust-control-plane/backend/api/generated/analytics/v1"
 	auditv1 "zero-trust-control-plane/backend/api/generated/audit/v1"
+	auditwebhookv1 "zero-trust-control-plane/backend/api/generated/auditwebhook/v1"
 	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	breakglassv1 "zero-trust-control-plane/backend/api/generated/breakglass/v1"
+	changerequestv1 "zero-trust-control-plane/backend/api/generated/changerequest/v1"
 	devv1 "zero-trust-control-plane/backend/api/generated/dev/v1"
 	devicev1 "zero-trust-control-plane/backend/api/generated/device/v1"
+	elevationv1 "zero-trust-control-plane/backend/api/generated/elevation/v1"
+	featureflagv1 "zero-trust-control-plane/backend/api/generated/featureflag/v1"
+	groupv1 "zero-trust-control-plane/backend/api/generated/group/v1"
 	healthv1 "zero-trust-control-plane/backend/api/generated/health/v1"
+	invitationv1 "zero-trust-control-plane/backend/api/generated/invitation/v1"
 	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+	notificationv1 "zero-trust-control-plane/backend/api/generated/notification/v1"
 	organizationv1 "zero-trust-control-plane/backend/api/generated/organization/v1"
 	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	platformsettingsv1 "zero-trust-control-plane/backend/api/generated/platformsettings/v1"
 	policyv1 "zero-trust-control-plane/backend/api/generated/policy/v1"
+	policypresetv1 "zero-trust-control-plane/backend/api/generated/policypreset/v1"
+	policyviolationv1 "zero-trust-control-plane/backend/api/generated/policyviolation/v1"
+	securityeventv1 "zero-trust-control-plane/backend/api/generated/securityevent/v1"
 	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
+	telemetryv1 "zero-trust-control-plane/backend/api/generated/telemetry/v1"
+	urlexceptionv1 "zero-trust-control-plane/backend/api/generated/urlexception/v1"
 	userv1 "zero-trust-control-plane/backend/api/generated/user/v1"
+	userattributev1 "zero-trust-control-plane/backend/api/generated/userattribute/v1"
 
 	adminhandler "zero-trust-control-plane/backend/internal/admin/handler"
+	agenthandler "zero-trust-control-plane/backend/internal/agent/handler"
+	agentrepo "zero-trust-control-plane/backend/internal/agent/repository"
+	analyticshandler "zero-trust-control-plane/backend/internal/analytics/handler"
+	analyticsrepo "zero-trust-control-plane/backend/internal/analytics/repository"
 	"zero-trust-control-plane/backend/internal/audit"
 	audithandler "zero-trust-control-plane/backend/internal/audit/handler"
 	auditrepo "zero-trust-control-plane/backend/internal/audit/repository"
+	"zero-trust-control-plane/backend/internal/auditwebhook"
+	auditwebhookhandler "zero-trust-control-plane/backend/internal/auditwebhook/handler"
+	auditwebhookrepo "zero-trust-control-plane/backend/internal/auditwebhook/repository"
+	"zero-trust-control-plane/backend/internal/backup"
+	"zero-trust-control-plane/backend/internal/billing"
+	"zero-trust-control-plane/backend/internal/breakglass"
+	breakglasshandler "zero-trust-control-plane/backend/internal/breakglass/handler"
+	breakglassrepo "zero-trust-control-plane/backend/internal/breakglass/repository"
+	"zero-trust-control-plane/backend/internal/changerequest"
+	changerequesthandler "zero-trust-control-plane/backend/internal/changerequest/handler"
+	changerequestrepo "zero-trust-control-plane/backend/internal/changerequest/repository"
+	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/dataexport"
 	devicehandler "zero-trust-control-plane/backend/internal/device/handler"
 	devicerepo "zero-trust-control-plane/backend/internal/device/repository"
+	"zero-trust-control-plane/backend/internal/devicesharing"
+	elevationhandler "zero-trust-control-plane/backend/internal/elevation/handler"
+	elevationrepo "zero-trust-control-plane/backend/internal/elevation/repository"
+	"zero-trust-control-plane/backend/internal/featureflag"
+	featureflaghandler "zero-trust-control-plane/backend/internal/featureflag/handler"
+	featureflagrepo "zero-trust-control-plane/backend/internal/featureflag/repository"
+	grouphandler "zero-trust-control-plane/backend/internal/group/handler"
+	grouprepo "zero-trust-control-plane/backend/internal/group/repository"
 	healthhandler "zero-trust-control-plane/backend/internal/health/handler"
+	"zero-trust-control-plane/backend/internal/honeytoken"
 	identityhandler "zero-trust-control-plane/backend/internal/identity/handler"
 	identityservice "zero-trust-control-plane/backend/internal/identity/service"
+	invitationhandler "zero-trust-control-plane/backend/internal/invitation/handler"
+	invitationrepo "zero-trust-control-plane/backend/internal/invitation/repository"
+	"zero-trust-control-plane/backend/internal/license"
+	"zero-trust-control-plane/backend/internal/maintenance"
 	membershiphandler "zero-trust-control-plane/backend/internal/membership/handler"
 	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	meteringrepo "zero-trust-control-plane/backend/internal/metering/repository"
+	notificationhandler "zero-trust-control-plane/backend/internal/notification/handler"
+	notificationrepo "zero-trust-control-plane/backend/internal/notification/repository"
+	"zero-trust-control-plane/backend/internal/notiftemplate"
 	organizationhandler "zero-trust-control-plane/backend/internal/organization/handler"
 	organizationrepo "zero-trust-control-plane/backend/internal/organization/repository"
+	"zero-trust-control-plane/backend/internal/orgdomain"
 	orgmfasettingsrepo "zero-trust-control-plane/backend/internal/orgmfasettings/repository"
+	"zero-trust-control-plane/backend/internal/orgpolicyconfig"
 	orgpolicyconfighandler "zero-trust-control-plane/backend/internal/orgpolicyconfig/handler"
 	orgpolicyconfigrepo "zero-trust-control-plane/backend/internal/orgpolicyconfig/repository"
+	"zero-trust-control-plane/backend/internal/orgsetup"
+	"zero-trust-control-plane/backend/internal/orgsmtp"
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+	"zero-trust-control-plane/backend/internal/platform/confirm"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	platformsettingshandler "zero-trust-control-plane/backend/internal/platformsettings/handler"
+	platformsettingsrepo "zero-trust-control-plane/backend/internal/platformsettings/repository"
 	policyhandler "zero-trust-control-plane/backend/internal/policy/handler"
 	policyrepo "zero-trust-control-plane/backend/internal/policy/repository"
+	"zero-trust-control-plane/backend/internal/policypreset"
+	policypresethandler "zero-trust-control-plane/backend/internal/policypreset/handler"
+	policyviolationhandler "zero-trust-control-plane/backend/internal/policyviolation/handler"
+	policyviolationrepo "zero-trust-control-plane/backend/internal/policyviolation/repository"
+	"zero-trust-control-plane/backend/internal/quota"
+	"zero-trust-control-plane/backend/internal/securityevent"
+	securityeventhandler "zero-trust-control-plane/backend/internal/securityevent/handler"
+	securityeventrepo "zero-trust-control-plane/backend/internal/securityevent/repository"
 	sessionhandler "zero-trust-control-plane/backend/internal/session/handler"
 	sessionrepo "zero-trust-control-plane/backend/internal/session/repository"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	telemetryhandler "zero-trust-control-plane/backend/internal/telemetry/handler"
+	urlexceptionhandler "zero-trust-control-plane/backend/internal/urlexception/handler"
+	urlexceptionrepo "zero-trust-control-plane/backend/internal/urlexception/repository"
 	userhandler "zero-trust-control-plane/backend/internal/user/handler"
 	userrepo "zero-trust-control-plane/backend/internal/user/repository"
+	userattributehandler "zero-trust-control-plane/backend/internal/userattribute/handler"
+	userattributerepo "zero-trust-control-plane/backend/internal/userattribute/repository"
+	"zero-trust-control-plane/backend/internal/usermerge"
 )
 
 // Deps holds optional service dependencies for gRPC handlers.
@@ -50,6 +127,11 @@ type Deps struct {
 	PolicyRepo policyrepo.Repository
 	// AuditRepo is the audit log repository for AuditService and the audit interceptor. If nil, ListAuditLogs returns Unimplemented and no RPCs are audited.
 	AuditRepo auditrepo.Repository
+	// AuditWebhookRepo is used by AuditWebhookService. If nil, audit webhook RPCs return Unimplemented.
+	AuditWebhookRepo auditwebhookrepo.Repository
+	// AuditWebhooks delivers audit events to the org webhooks; AuditWebhookService drops its cache after changes. If
+	// nil, changes reach deliveries within auditwebhook.DefaultCacheTTL.
+	AuditWebhooks *auditwebhook.Dispatcher
 	// HealthPinger is used by HealthService for readiness (e.g. *sql.DB). If nil, HealthCheck skips DB ping.
 	HealthPinger healthhandler.Pinger
 	// HealthPolicyChecker is used by HealthService for readiness (e.g. OPA evaluator). If nil, HealthCheck skips policy check.
@@ -58,6 +140,31 @@ type Deps struct {
 	DevOTPHandler devv1.DevServiceServer
 	// MembershipRepo is used by MembershipService. If nil, membership RPCs return Unimplemented.
 	MembershipRepo membershiprepo.Repository
+	// InvitationRepo is used by InvitationService (invitations for invite-only registration). If nil, invitation RPCs
+	// return Unimplemented.
+	InvitationRepo invitationrepo.Repository
+	// GroupRepo is used by GroupService and to scope group admins in MembershipService, SessionService and
+	// DeviceService. If nil, group RPCs return Unimplemented and only org admins and owners manage users.
+	GroupRepo grouprepo.Repository
+	// UserAttributeRepo is used by UserAttributeService (org-defined member attributes). If nil, user attribute RPCs
+	// return Unimplemented.
+	UserAttributeRepo userattributerepo.Repository
+	// ElevationRepo is used by ElevationService (just-in-time admin elevation). If nil, elevation RPCs return Unimplemented.
+	ElevationRepo elevationrepo.Repository
+	// ElevationDefaultDuration and ElevationMaxDuration bound how long a requested elevation lasts once approved.
+	ElevationDefaultDuration time.Duration
+	ElevationMaxDuration     time.Duration
+	// BreakGlassRepo is used by BreakGlassService (per-org emergency access accounts). If nil, break-glass RPCs return
+	// Unimplemented.
+	BreakGlassRepo breakglassrepo.Repository
+	// BreakGlassTokens issues break-glass access tokens that end with the access window (e.g. *security.TokenProvider).
+	// If nil, BreakGlassService.SignIn returns Unimplemented.
+	BreakGlassTokens breakglasshandler.AccessIssuer
+	// BreakGlassAlerter sends break-glass alerts to the configured webhook and email recipients. If nil, no alerts
+	// are sent.
+	BreakGlassAlerter breakglass.Alerter
+	// BreakGlassSessionTTL is how long an approved break-glass unlock lasts.
+	BreakGlassSessionTTL time.Duration
 	// SessionRepo is used by SessionService. If nil, session RPCs return Unimplemented.
 	SessionRepo sessionrepo.Repository
 	// UserRepo is used by UserService (e.g. GetUserByEmail). If nil, user RPCs return Unimplemented.
@@ -66,10 +173,103 @@ type Deps struct {
 	AuditLogger audit.AuditLogger
 	// OrgPolicyConfigRepo is used by OrgPolicyConfigService. If nil, org policy config RPCs return Unimplemented.
 	OrgPolicyConfigRepo orgpolicyconfigrepo.Repository
+	// PolicyHub pushes org policy changes to SubscribeBrowserPolicy streams. If nil, SubscribeBrowserPolicy returns Unimplemented.
+	PolicyHub *orgpolicyconfig.Hub
 	// OrgMFASettingsRepo is used by OrgPolicyConfigService to sync auth_mfa and device_trust on update. If nil, sync is skipped.
 	OrgMFASettingsRepo orgmfasettingsrepo.Repository
 	// OrgRepo is used by OrganizationService. If nil, organization RPCs return Unimplemented.
 	OrgRepo organizationrepo.Repository
+	// Plans gates features by the org's billing plan (PolicyService writes, AuditService StreamAuditEvents,
+	// NotificationService UpdateOrgSMTPSettings, policy change requests) and backs AdminService ListBillingPlans and
+	// SetOrgBillingPlan. If nil, no feature is gated and the billing plan RPCs return Unimplemented.
+	Plans *billing.Gate
+	// License is the self-hosted license, served by AdminService GetLicenseStatus. If nil, GetLicenseStatus returns
+	// Unimplemented.
+	License *license.Manager
+	// Backups and BackupDir back AdminService CreateBackup, which writes org backups to BackupDir. If Backups is nil
+	// or BackupDir is empty, CreateBackup returns Unimplemented.
+	Backups   *backup.Service
+	BackupDir string
+	// DataExports backs UserService ExportMyData and DownloadDataExport and AdminService ExportUserData. If nil, they
+	// return Unimplemented.
+	DataExports *dataexport.Service
+	// SharedDevices backs AdminService ListSharedDevices. If nil, it returns Unimplemented.
+	SharedDevices *devicesharing.Detector
+	// DataRegions are the data regions configured in this deployment, which CreateOrganization accepts as data_region.
+	DataRegions []string
+	// NotificationRepo is used by NotificationService. If nil, notification RPCs return Unimplemented.
+	NotificationRepo notificationrepo.Repository
+	// SecurityEventRepo is used by SecurityEventsService. If nil, security event RPCs return Unimplemented.
+	SecurityEventRepo securityeventrepo.Repository
+	// Confirmations signs the confirmation tokens of SessionService RevokeAllSessionsForOrg and AdminService MergeUsers.
+	// If nil, a random per-process key is used.
+	Confirmations *confirm.Signer
+	// SecurityEvents records device revocations and org-wide logouts into the affected users' security feeds. If nil, they are not recorded.
+	SecurityEvents securityevent.Recorder
+	// AnalyticsRepo is used by AnalyticsService (login dashboards from rollup tables). If nil, analytics RPCs return Unimplemented.
+	AnalyticsRepo analyticsrepo.Repository
+	// UsageRepo holds the metered monthly usage for AnalyticsService GetUsage and AdminService ExportUsage. If nil,
+	// both return Unimplemented.
+	UsageRepo meteringrepo.Repository
+	// PolicyViolationRepo is used by PolicyViolationService (agent-reported blocked actions). If nil, policy violation RPCs return Unimplemented.
+	PolicyViolationRepo policyviolationrepo.Repository
+	// AgentRepo is used by AgentService (agent registration and heartbeats). If nil, agent RPCs return Unimplemented.
+	AgentRepo agentrepo.Repository
+	// AgentStaleAfter is how long an agent may go without a heartbeat before it is flagged stale.
+	AgentStaleAfter time.Duration
+	// TelemetryPublisher is used by TelemetryService (agent telemetry to Kafka). If nil, telemetry RPCs return Unimplemented.
+	TelemetryPublisher telemetry.Publisher
+	// TelemetryEvents serves QueryTelemetry with the embedded transport. If nil, QueryTelemetry returns Unimplemented.
+	TelemetryEvents telemetryhandler.EventQuerier
+	// Region is added to published telemetry events; it may be empty.
+	Region string
+	// ConfigWatcher serves AdminService.GetEffectiveConfig and names the platform admins (PLATFORM_ADMIN_USER_IDS).
+	// If nil, GetEffectiveConfig returns Unimplemented and no caller is a platform admin.
+	ConfigWatcher *config.Watcher
+	// PlatformSettingsRepo is used by PlatformSettingsService; pass the settingscache repository so changes are
+	// applied on every instance at once. If nil (or ConfigWatcher is nil), platform settings RPCs return Unimplemented.
+	PlatformSettingsRepo platformsettingsrepo.Repository
+	// FeatureFlagRepo is used by FeatureFlagService. If nil, feature flag RPCs return Unimplemented.
+	FeatureFlagRepo featureflagrepo.Repository
+	// FeatureFlags evaluates flags for EvaluateFeatureFlags and is invalidated when flags change. If nil, flags take their defaults.
+	FeatureFlags *featureflag.Evaluator
+	// ChangeRequestRepo is used by ChangeRequestService (four-eyes policy changes). If nil, change request RPCs return Unimplemented.
+	ChangeRequestRepo changerequestrepo.Repository
+	// ChangeRequestNotifier sends change request events to the configured webhook. If nil, no webhook is sent.
+	ChangeRequestNotifier changerequest.Notifier
+	// UrlExceptionRepo is used by UrlExceptionService and consulted by CheckUrlAccess. If nil, URL exception RPCs
+	// return Unimplemented and CheckUrlAccess ignores exceptions.
+	UrlExceptionRepo urlexceptionrepo.Repository
+	// Maintenance holds the platform's maintenance mode for AdminService Get/SetMaintenanceMode (the maintenance
+	// interceptor reads the same switch). If nil, the maintenance RPCs return Unimplemented.
+	Maintenance *maintenance.Switch
+	// Quotas holds the API quota plans and per-org overrides for AdminService ListQuotaPlans/Get/SetOrgQuota (the
+	// quota interceptor counts against the same enforcer). If nil, the quota RPCs return Unimplemented.
+	Quotas *quota.Enforcer
+	// Honeytokens marks decoy users for AdminService Mark/Unmark/ListHoneytokens (AuthService rejects and alerts their
+	// sign-ins). If nil, the honeytoken RPCs return Unimplemented.
+	Honeytokens *honeytoken.Registry
+	// UserMerger merges duplicate users for AdminService MergeUsers. If nil, MergeUsers returns Unimplemented.
+	UserMerger *usermerge.Merger
+	// Breakers holds the circuit breakers around external dependencies for AdminService ListCircuitBreakers. If nil,
+	// ListCircuitBreakers returns Unimplemented.
+	Breakers *breaker.Registry
+	// SMSRetryQueue reports the SMS messages waiting for retry in ListCircuitBreakers; it may be nil.
+	SMSRetryQueue adminhandler.SMSRetryQueue
+	// OrgDomains verifies email domains claimed by orgs for OrganizationService. If nil, the domain verification RPCs
+	// return Unimplemented.
+	OrgDomains *orgdomain.Verifier
+	// OrgSetup creates orgs with their initial settings for OrganizationService.SetupOrganization. If nil, the RPC
+	// returns Unimplemented.
+	OrgSetup *orgsetup.Setuper
+	// PolicyPresets lists policy presets and applies them to orgs for PolicyPresetService. If nil, its RPCs return
+	// Unimplemented.
+	PolicyPresets *policypreset.Library
+	// OrgSMTP holds orgs' own SMTP servers for NotificationService. If nil, the org SMTP RPCs return Unimplemented.
+	OrgSMTP *orgsmtp.Router
+	// NotificationTemplates holds orgs' notification templates for NotificationService. If nil, the template RPCs
+	// return Unimplemented.
+	NotificationTemplates *notiftemplate.Templates
 }
 
 // RegisterServices registers all proto gRPC services with the given server.
@@ -81,25 +281,91 @@ type Deps struct {
 //   - OrganizationService → internal/organization/handler
 //   - DeviceService      → internal/device/handler
 //   - MembershipService  → internal/membership/handler
+//   - InvitationService  → internal/invitation/handler
+//   - GroupService       → internal/group/handler
+//   - UserAttributeService → internal/userattribute/handler
+//   - ElevationService   → internal/elevation/handler
+//   - BreakGlassService  → internal/breakglass/handler
 //   - PolicyService      → internal/policy/handler
+//   - ChangeRequestService → internal/changerequest/handler
+//   - UrlExceptionService → internal/urlexception/handler
 //   - SessionService     → internal/session/handler
+//   - NotificationService → internal/notification/handler
+//   - SecurityEventsService → internal/securityevent/handler
+//   - AnalyticsService   → internal/analytics/handler
+//   - PolicyViolationService → internal/policyviolation/handler
+//   - AgentService       → internal/agent/handler
+//   - TelemetryService   → internal/telemetry/handler
+//   - FeatureFlagService → internal/featureflag/handler
+//   - PlatformSettingsService → internal/platformsettings/handler
 //   - AuditService       → internal/audit/handler
+//   - AuditWebhookService → internal/auditwebhook/handler
 //   - HealthService      → internal/health/handler
 func RegisterServices(s grpc.ServiceRegistrar, deps Deps) {
-	adminv1.RegisterAdminServiceServer(s, adminhandler.NewServer())
+	adminv1.RegisterAdminServiceServer(s, adminhandler.NewServer(deps.ConfigWatcher, deps.Maintenance, deps.Quotas, deps.Honeytokens, deps.UserMerger, deps.Breakers, deps.SMSRetryQueue, deps.OrgRepo, deps.Plans, deps.UsageRepo, deps.License, deps.Backups, deps.BackupDir, deps.DataExports, deps.SharedDevices, deps.AuditLogger))
 	var authSvc *identityservice.AuthService
 	if deps.Auth != nil {
 		authSvc = deps.Auth
 	}
 	authv1.RegisterAuthServiceServer(s, identityhandler.NewAuthServer(authSvc))
-	userv1.RegisterUserServiceServer(s, userhandler.NewServer(deps.UserRepo))
-	organizationv1.RegisterOrganizationServiceServer(s, organizationhandler.NewServer(deps.OrgRepo, deps.UserRepo, deps.MembershipRepo))
-	devicev1.RegisterDeviceServiceServer(s, devicehandler.NewServer(deps.DeviceRepo))
-	membershipv1.RegisterMembershipServiceServer(s, membershiphandler.NewServer(deps.MembershipRepo, deps.UserRepo, deps.AuditLogger))
-	policyv1.RegisterPolicyServiceServer(s, policyhandler.NewServer(deps.PolicyRepo))
-	orgpolicyconfigv1.RegisterOrgPolicyConfigServiceServer(s, orgpolicyconfighandler.NewServer(deps.OrgPolicyConfigRepo, deps.MembershipRepo, deps.OrgMFASettingsRepo))
-	sessionv1.RegisterSessionServiceServer(s, sessionhandler.NewServer(deps.SessionRepo, deps.MembershipRepo, deps.AuditLogger))
-	auditv1.RegisterAuditServiceServer(s, audithandler.NewServer(deps.AuditRepo, deps.MembershipRepo))
+	userv1.RegisterUserServiceServer(s, userhandler.NewServer(deps.UserRepo, deps.DataExports, deps.AuditLogger))
+	var platformAdmins rbac.PlatformAdminChecker
+	if deps.ConfigWatcher != nil {
+		platformAdmins = deps.ConfigWatcher
+	}
+	organizationv1.RegisterOrganizationServiceServer(s, organizationhandler.NewServer(deps.OrgRepo, deps.UserRepo, deps.MembershipRepo, deps.DataRegions, deps.OrgDomains, deps.OrgSetup, platformAdmins, deps.AuditLogger))
+	devicev1.RegisterDeviceServiceServer(s, devicehandler.NewServer(deps.DeviceRepo, deps.SecurityEvents, deps.MembershipRepo, deps.GroupRepo))
+	membershipv1.RegisterMembershipServiceServer(s, membershiphandler.NewServer(deps.MembershipRepo, deps.UserRepo, deps.AuditLogger, deps.GroupRepo))
+	invitationv1.RegisterInvitationServiceServer(s, invitationhandler.NewServer(deps.InvitationRepo, deps.MembershipRepo, deps.AuditLogger))
+	groupv1.RegisterGroupServiceServer(s, grouphandler.NewServer(deps.GroupRepo, deps.MembershipRepo, deps.AuditLogger))
+	userattributev1.RegisterUserAttributeServiceServer(s, userattributehandler.NewServer(deps.UserAttributeRepo, deps.MembershipRepo, deps.AuditLogger))
+	elevationv1.RegisterElevationServiceServer(s, elevationhandler.NewServer(deps.ElevationRepo, deps.MembershipRepo, deps.AuditLogger, deps.ElevationDefaultDuration, deps.ElevationMaxDuration))
+	policyv1.RegisterPolicyServiceServer(s, policyhandler.NewServer(deps.PolicyRepo, deps.OrgPolicyConfigRepo, deps.Plans))
+	policypresetv1.RegisterPolicyPresetServiceServer(s, policypresethandler.NewServer(deps.PolicyPresets, deps.MembershipRepo, deps.AuditLogger))
+	orgPolicyConfigServer := orgpolicyconfighandler.NewServer(deps.OrgPolicyConfigRepo, deps.MembershipRepo, deps.OrgMFASettingsRepo, deps.PolicyHub, deps.GroupRepo, deps.UrlExceptionRepo, deps.AuditLogger)
+	orgpolicyconfigv1.RegisterOrgPolicyConfigServiceServer(s, orgPolicyConfigServer)
+	var policyConfigs changerequesthandler.PolicyConfigStore
+	if deps.OrgPolicyConfigRepo != nil {
+		policyConfigs = orgPolicyConfigServer
+	}
+	var policies changerequesthandler.PolicyStore
+	if deps.PolicyRepo != nil {
+		policies = deps.PolicyRepo
+	}
+	changerequestv1.RegisterChangeRequestServiceServer(s, changerequesthandler.NewServer(deps.ChangeRequestRepo, deps.MembershipRepo, policyConfigs, policies, deps.AuditLogger, deps.ChangeRequestNotifier, deps.Plans))
+	var urlChecker urlexceptionhandler.URLChecker
+	if deps.OrgPolicyConfigRepo != nil {
+		urlChecker = orgPolicyConfigServer
+	}
+	urlexceptionv1.RegisterUrlExceptionServiceServer(s, urlexceptionhandler.NewServer(deps.UrlExceptionRepo, deps.MembershipRepo, urlChecker, deps.AuditLogger))
+	sessionv1.RegisterSessionServiceServer(s, sessionhandler.NewServer(deps.SessionRepo, deps.MembershipRepo, deps.AuditLogger, deps.OrgPolicyConfigRepo, deps.SecurityEvents, deps.GroupRepo, deps.Confirmations))
+	var orgSMTP notificationhandler.OrgSMTP
+	if deps.OrgSMTP != nil {
+		orgSMTP = deps.OrgSMTP
+	}
+	var notificationTemplates notificationhandler.NotificationTemplates
+	if deps.NotificationTemplates != nil {
+		notificationTemplates = deps.NotificationTemplates
+	}
+	notificationv1.RegisterNotificationServiceServer(s, notificationhandler.NewServer(deps.NotificationRepo, deps.OrgPolicyConfigRepo, orgSMTP, notificationTemplates, deps.MembershipRepo, deps.UserRepo, deps.AuditLogger, deps.Plans))
+	securityeventv1.RegisterSecurityEventsServiceServer(s, securityeventhandler.NewServer(deps.SecurityEventRepo))
+	analyticsv1.RegisterAnalyticsServiceServer(s, analyticshandler.NewServer(deps.AnalyticsRepo, deps.MembershipRepo, deps.UsageRepo))
+	policyviolationv1.RegisterPolicyViolationServiceServer(s, policyviolationhandler.NewServer(deps.PolicyViolationRepo, deps.MembershipRepo, deps.SessionRepo, deps.DeviceRepo, deps.OrgPolicyConfigRepo, deps.AuditLogger))
+	agentv1.RegisterAgentServiceServer(s, agenthandler.NewServer(deps.AgentRepo, deps.MembershipRepo, deps.SessionRepo, deps.OrgPolicyConfigRepo, deps.AuditLogger, deps.AgentStaleAfter))
+	telemetryv1.RegisterTelemetryServiceServer(s, telemetryhandler.NewServer(deps.TelemetryPublisher, deps.TelemetryEvents, deps.MembershipRepo, deps.SessionRepo, deps.Region))
+	featureflagv1.RegisterFeatureFlagServiceServer(s, featureflaghandler.NewServer(deps.FeatureFlagRepo, deps.FeatureFlags, deps.MembershipRepo, platformAdmins, deps.OrgRepo, deps.AuditLogger))
+	platformsettingsv1.RegisterPlatformSettingsServiceServer(s, platformsettingshandler.NewServer(deps.PlatformSettingsRepo, deps.ConfigWatcher, deps.AuditLogger))
+	var breakGlassProvisioner breakglasshandler.Provisioner
+	if deps.UserRepo != nil {
+		breakGlassProvisioner = breakglass.NewProvisioner(deps.UserRepo, deps.License)
+	}
+	breakglassv1.RegisterBreakGlassServiceServer(s, breakglasshandler.NewServer(deps.BreakGlassRepo, breakGlassProvisioner, deps.OrgRepo, deps.SessionRepo, deps.BreakGlassTokens, deps.AuditRepo, platformAdmins, deps.AuditLogger, deps.BreakGlassAlerter, deps.BreakGlassSessionTTL))
+	auditv1.RegisterAuditServiceServer(s, audithandler.NewServer(deps.AuditRepo, deps.MembershipRepo, deps.Plans))
+	var auditWebhookCache auditwebhookhandler.CacheInvalidator
+	if deps.AuditWebhooks != nil {
+		auditWebhookCache = deps.AuditWebhooks
+	}
+	auditwebhookv1.RegisterAuditWebhookServiceServer(s, auditwebhookhandler.NewServer(deps.AuditWebhookRepo, deps.MembershipRepo, auditWebhookCache, deps.Plans, deps.AuditLogger))
 	healthv1.RegisterHealthServiceServer(s, healthhandler.NewServer(deps.HealthPinger, deps.HealthPolicyChecker))
 	if deps.DevOTPHandler != nil {
 		devv1.RegisterDevServiceServer(s, deps.DevOTPHandler)
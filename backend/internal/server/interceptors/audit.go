@@ -34,6 +34,7 @@ func AuditUnary(auditRepo auditrepo.Repository, skipMethods map[string]bool) grp
 		userID, _ := GetUserID(ctx)
 		ar := audit.ParseFullMethod(info.FullMethod)
 		ip := ClientIP(ctx)
+		requestID := RequestID(ctx)
 		entry := &domain.AuditLog{
 			ID:        uuid.New().String(),
 			OrgID:     orgID,
@@ -42,10 +43,11 @@ func AuditUnary(auditRepo auditrepo.Repository, skipMethods map[string]bool) grp
 			Resource:  ar.Resource,
 			IP:        ip,
 			Metadata:  "",
+			RequestID: requestID,
 			CreatedAt: time.Now().UTC(),
 		}
 		if createErr := auditRepo.Create(ctx, entry); createErr != nil {
-			log.Printf("audit: failed to create audit log: %v", createErr)
+			log.Printf("audit: request_id=%s failed to create audit log: %v", requestID, createErr)
 		}
 		return resp, err
 	}
@@ -76,3 +78,53 @@ func ClientIP(ctx context.Context) string {
 	}
 	return "unknown"
 }
+
+// UserAgent returns the end-user's User-Agent from gRPC metadata: x-forwarded-user-agent (set by a BFF/proxy
+// forwarding a browser request) takes precedence over the transport's user-agent. Returns "" if absent.
+func UserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"x-forwarded-user-agent", "user-agent"} {
+		if vals := md.Get(key); len(vals) > 0 {
+			if s := strings.TrimSpace(vals[0]); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// ClientVersion returns the client app version from the x-client-version gRPC metadata (e.g. "web/1.4.2"), or ""
+// if absent.
+func ClientVersion(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if vals := md.Get("x-client-version"); len(vals) > 0 {
+		return strings.TrimSpace(vals[0])
+	}
+	return ""
+}
+
+// ClientCountry returns the client's ISO country code from edge geo headers (x-client-country, or Cloudflare's
+// cf-ipcountry), upper-cased. Returns "" if absent or unknown ("XX", Tor "T1").
+func ClientCountry(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"x-client-country", "cf-ipcountry"} {
+		if vals := md.Get(key); len(vals) > 0 {
+			if s := strings.ToUpper(strings.TrimSpace(vals[0])); s != "" {
+				if s == "XX" || s == "T1" {
+					return ""
+				}
+				return s
+			}
+		}
+	}
+	return ""
+}
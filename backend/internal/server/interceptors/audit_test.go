@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -27,7 +28,11 @@ func (m *mockAuditRepoForInterceptor) ListByOrg(ctx context.Context, orgID strin
 	return nil, nil
 }
 
-func (m *mockAuditRepoForInterceptor) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource *string) ([]*auditdomain.AuditLog, error) {
+func (m *mockAuditRepoForInterceptor) ListByOrgFiltered(ctx context.Context, orgID string, limit, offset int32, userID, action, resource, requestID *string) ([]*auditdomain.AuditLog, error) {
+	return nil, nil
+}
+
+func (m *mockAuditRepoForInterceptor) ListByOrgSince(ctx context.Context, orgID string, since time.Time, limit int32, userID *string, actions []string) ([]*auditdomain.AuditLog, error) {
 	return nil, nil
 }
 
@@ -278,3 +283,47 @@ func TestAuditUnary_HandlerError(t *testing.T) {
 		t.Errorf("audit entries = %d, want 1", len(repo.entries))
 	}
 }
+
+func TestUserAgent_ForwardedPrecedence(t *testing.T) {
+	md := metadata.Pairs("user-agent", "grpc-go/1.0", "x-forwarded-user-agent", "Mozilla/5.0 Chrome/120.0")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	if got := UserAgent(ctx); got != "Mozilla/5.0 Chrome/120.0" {
+		t.Errorf("UserAgent = %q, want forwarded user agent", got)
+	}
+}
+
+func TestUserAgent_Absent(t *testing.T) {
+	if got := UserAgent(context.Background()); got != "" {
+		t.Errorf("UserAgent = %q, want empty", got)
+	}
+}
+
+func TestClientVersion(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-client-version", " web/1.4.2 "))
+	if got := ClientVersion(ctx); got != "web/1.4.2" {
+		t.Errorf("ClientVersion = %q, want %q", got, "web/1.4.2")
+	}
+	if got := ClientVersion(context.Background()); got != "" {
+		t.Errorf("ClientVersion without metadata = %q, want empty", got)
+	}
+}
+
+func TestClientCountry(t *testing.T) {
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string
+	}{
+		{"x-client-country", metadata.Pairs("x-client-country", "de"), "DE"},
+		{"cloudflare", metadata.Pairs("cf-ipcountry", "US"), "US"},
+		{"precedence", metadata.Pairs("cf-ipcountry", "US", "x-client-country", "FR"), "FR"},
+		{"unknown", metadata.Pairs("cf-ipcountry", "XX"), ""},
+		{"absent", metadata.MD{}, ""},
+	}
+	for _, tt := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+		if got := ClientCountry(ctx); got != tt.want {
+			t.Errorf("%s: ClientCountry = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
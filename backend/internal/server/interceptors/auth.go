@@ -2,6 +2,7 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"google.golang.org/grpc"
@@ -15,7 +16,8 @@ import (
 const bearerPrefix = "bearer "
 
 // SessionValidator returns true if the session is active (exists and not revoked).
-// When non-nil, AuthUnary calls it after ValidateAccess; if it returns false or an error, the request is rejected with Unauthenticated.
+// When non-nil, AuthUnary calls it after ValidateAccess; if it returns false or an error, the request is rejected with Unauthenticated,
+// except that an Unavailable status error (revocation state cannot be trusted right now) is returned as is.
 type SessionValidator func(ctx context.Context, sessionID string) (active bool, err error)
 
 // AuthUnary returns a unary server interceptor that validates the Bearer (access) token
@@ -25,36 +27,80 @@ type SessionValidator func(ctx context.Context, sessionID string) (active bool,
 // If sessionValidator is non-nil, it is called after token validation; revoked or missing sessions are rejected with Unauthenticated.
 func AuthUnary(tokens *security.TokenProvider, publicMethods map[string]bool, sessionValidator SessionValidator) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		token := extractBearer(ctx)
-		public := publicMethods[info.FullMethod]
-
-		if token == "" {
-			if public {
-				return handler(ctx, req)
-			}
-			return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization")
+		ctx, err := authenticate(ctx, info.FullMethod, tokens, publicMethods, sessionValidator)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		sessionID, userID, orgID, err := tokens.ValidateAccess(token)
+// AuthStream is the streaming counterpart of AuthUnary: the Bearer token is validated once when the stream opens
+// and the identity is set on the stream's context.
+func AuthStream(tokens *security.TokenProvider, publicMethods map[string]bool, sessionValidator SessionValidator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod, tokens, publicMethods, sessionValidator)
 		if err != nil {
-			if public {
-				return handler(ctx, req)
-			}
-			return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization")
+			return err
 		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate validates the Bearer token for method and returns ctx with the caller's identity.
+// Public methods proceed without identity when the token is missing or invalid.
+func authenticate(ctx context.Context, method string, tokens *security.TokenProvider, publicMethods map[string]bool, sessionValidator SessionValidator) (context.Context, error) {
+	token := extractBearer(ctx)
+	public := publicMethods[method]
 
-		if sessionValidator != nil {
-			active, err := sessionValidator(ctx, sessionID)
-			if err != nil || !active {
-				return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization")
-			}
+	if token == "" {
+		if public {
+			return ctx, nil
 		}
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization")
+	}
 
-		ctx = WithIdentity(ctx, userID, orgID, sessionID)
-		return handler(ctx, req)
+	sessionID, userID, orgID, err := tokens.ValidateAccess(token)
+	if err != nil {
+		if public {
+			return ctx, nil
+		}
+		return nil, tokenError(err)
 	}
+
+	if sessionValidator != nil {
+		active, err := sessionValidator(ctx, sessionID)
+		if status.Code(err) == codes.Unavailable {
+			return nil, err
+		}
+		if err != nil || !active {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization")
+		}
+	}
+
+	return WithIdentity(ctx, userID, orgID, sessionID), nil
 }
 
+// tokenError returns the Unauthenticated status for an access token ValidateAccess rejected. Expired and not yet
+// valid tokens get their own messages (and so catalog reasons), so clock skew can be told apart from bad tokens.
+func tokenError(err error) error {
+	switch {
+	case errors.Is(err, security.ErrTokenExpired):
+		return status.Error(codes.Unauthenticated, "access token expired")
+	case errors.Is(err, security.ErrTokenNotYetValid):
+		return status.Error(codes.Unauthenticated, "access token not yet valid; check the clock of this device")
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid authorization")
+}
+
+// contextStream overrides a grpc.ServerStream's context so stream interceptors can pass values to the handler.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
 // extractBearer returns the Bearer token from ctx metadata, or "" if missing or malformed.
 func extractBearer(ctx context.Context) string {
 	md, ok := metadata.FromIncomingContext(ctx)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -140,6 +141,40 @@ func TestAuthUnary_ProtectedMethod_InvalidToken(t *testing.T) {
 	}
 }
 
+func TestAuthUnary_ProtectedMethod_ExpiredToken(t *testing.T) {
+	tokens, err := security.NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	token, _, _, err := tokens.IssueAccessUntil(context.Background(), "s1", "u1", "o1", time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("IssueAccessUntil: %v", err)
+	}
+	interceptor := AuthUnary(tokens, map[string]bool{}, nil)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	_, err = interceptor(ctx, "request", &grpc.UnaryServerInfo{FullMethod: "/test.Service/ProtectedMethod"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return "success", nil })
+	if st := status.Convert(err); st.Code() != codes.Unauthenticated || st.Message() != "access token expired" {
+		t.Errorf("expired token: err = %v, want Unauthenticated %q", err, "access token expired")
+	}
+}
+
+func TestTokenError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want string
+	}{
+		{security.ErrTokenExpired, "access token expired"},
+		{security.ErrTokenNotYetValid, "access token not yet valid; check the clock of this device"},
+		{security.ErrInvalidToken, "missing or invalid authorization"},
+	} {
+		st := status.Convert(tokenError(tc.err))
+		if st.Code() != codes.Unauthenticated || st.Message() != tc.want {
+			t.Errorf("tokenError(%v) = %v, want Unauthenticated %q", tc.err, st.Err(), tc.want)
+		}
+	}
+}
+
 func TestAuthUnary_SessionValidator_ValidSession(t *testing.T) {
 	tokens, err := security.NewTestTokenProvider()
 	if err != nil {
@@ -250,6 +285,36 @@ func TestAuthUnary_SessionValidator_Error(t *testing.T) {
 	}
 }
 
+func TestAuthUnary_SessionValidator_Unavailable(t *testing.T) {
+	tokens, err := security.NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	token, _, _, err := tokens.IssueAccess("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+
+	sessionValidator := func(ctx context.Context, sessionID string) (bool, error) {
+		return false, status.Error(codes.Unavailable, "session revocation state is stale")
+	}
+	interceptor := AuthUnary(tokens, map[string]bool{}, sessionValidator)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"authorization": "Bearer " + token,
+	}))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+
+	_, err = interceptor(ctx, "request", &grpc.UnaryServerInfo{
+		FullMethod: "/test.Service/ProtectedMethod",
+	}, handler)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("status code = %v, want %v", status.Code(err), codes.Unavailable)
+	}
+}
+
 func TestExtractBearer_Valid(t *testing.T) {
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
 		"authorization": "Bearer token123",
@@ -297,3 +362,53 @@ func TestExtractBearer_Whitespace(t *testing.T) {
 		t.Errorf("token = %q, want %q", token, "token123")
 	}
 }
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestAuthStream_ValidToken(t *testing.T) {
+	tokens, err := security.NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	token, _, _, err := tokens.IssueAccess("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+	interceptor := AuthStream(tokens, map[string]bool{}, nil)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	called := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		called = true
+		if userID, ok := GetUserID(ss.Context()); !ok || userID != "user-1" {
+			t.Errorf("user_id = %q, ok = %v, want %q", userID, ok, "user-1")
+		}
+		return nil
+	}
+	if err := interceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if !called {
+		t.Error("handler should be called")
+	}
+}
+
+func TestAuthStream_NoToken(t *testing.T) {
+	tokens, err := security.NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	interceptor := AuthStream(tokens, map[string]bool{}, nil)
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		t.Error("handler should not be called")
+		return nil
+	}
+	err = interceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("code = %v, want Unauthenticated", status.Code(err))
+	}
+}
@@ -8,6 +8,8 @@ var (
 	userIDKey    = contextKey{"user_id"}
 	orgIDKey     = contextKey{"org_id"}
 	sessionIDKey = contextKey{"session_id"}
+	requestIDKey = contextKey{"request_id"}
+	localeKey    = contextKey{"locale"}
 )
 
 // WithIdentity returns a context with user_id, org_id, and session_id set.
@@ -36,3 +38,28 @@ func GetSessionID(ctx context.Context) (string, bool) {
 	v, ok := ctx.Value(sessionIDKey).(string)
 	return v, ok
 }
+
+// WithRequestID returns a context with the request correlation ID set.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// GetRequestID returns the request correlation ID from context and true if set; otherwise "", false.
+func GetRequestID(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(requestIDKey).(string)
+	return v, ok
+}
+
+// WithLocale returns a context with the negotiated locale set.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// GetLocale returns the locale negotiated by LocalizeErrorsUnary/LocalizeErrorsStream, or i18n.DefaultLocale ("en")
+// if none was set.
+func GetLocale(ctx context.Context) string {
+	if v, ok := ctx.Value(localeKey).(string); ok && v != "" {
+		return v
+	}
+	return "en"
+}
@@ -0,0 +1,55 @@
+package interceptors
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/db"
+)
+
+// dbTimeoutMessage replaces the handler's message when a database timeout caused its error.
+const dbTimeoutMessage = "database operation timed out"
+
+// DBTimeoutUnary returns a unary server interceptor that reports database timeouts as DeadlineExceeded. Handlers map
+// repository errors to Internal without the cause; when a statement of the request ran out of DB_QUERY_TIMEOUT or
+// statement_timeout (see db.TrackTimeouts), an Internal or Unknown error becomes DeadlineExceeded, so clients can
+// retry and slow databases are told apart from bugs. Must run after LocalizeErrorsUnary so the new error is
+// localized.
+func DBTimeoutUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, timedOut := db.TrackTimeouts(ctx)
+		resp, err := handler(ctx, req)
+		if err != nil && timedOut() {
+			return resp, dbTimeoutError(ctx, info.FullMethod, err)
+		}
+		return resp, err
+	}
+}
+
+// DBTimeoutStream is the streaming counterpart of DBTimeoutUnary.
+func DBTimeoutStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, timedOut := db.TrackTimeouts(ss.Context())
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		if err != nil && timedOut() {
+			return dbTimeoutError(ctx, info.FullMethod, err)
+		}
+		return err
+	}
+}
+
+// dbTimeoutError returns DeadlineExceeded for an Internal or Unknown err, and err unchanged otherwise (e.g. a
+// handler that already chose Unavailable).
+func dbTimeoutError(ctx context.Context, method string, err error) error {
+	switch status.Code(err) {
+	case codes.Internal, codes.Unknown:
+		log.Printf("db: %s timed out (request_id=%s): %v", method, RequestID(ctx), err)
+		return status.Error(codes.DeadlineExceeded, dbTimeoutMessage)
+	default:
+		return err
+	}
+}
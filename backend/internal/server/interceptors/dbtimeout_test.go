@@ -0,0 +1,58 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/db"
+)
+
+func runDBTimeoutInterceptor(timedOut bool, handlerErr error) error {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		if timedOut {
+			db.RecordTimeout(ctx)
+		}
+		return nil, handlerErr
+	}
+	_, err := DBTimeoutUnary()(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+	return err
+}
+
+func TestDBTimeoutUnary_InternalAfterTimeoutIsDeadlineExceeded(t *testing.T) {
+	err := runDBTimeoutInterceptor(true, status.Error(codes.Internal, "failed to get user"))
+	if status.Code(err) != codes.DeadlineExceeded || status.Convert(err).Message() != dbTimeoutMessage {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestDBTimeoutUnary_InternalWithoutTimeoutUnchanged(t *testing.T) {
+	err := runDBTimeoutInterceptor(false, status.Error(codes.Internal, "failed to get user"))
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("err = %v, want Internal", err)
+	}
+}
+
+func TestDBTimeoutUnary_OtherCodesUnchanged(t *testing.T) {
+	err := runDBTimeoutInterceptor(true, status.Error(codes.Unavailable, "failed to publish"))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("err = %v, want Unavailable", err)
+	}
+	if err := runDBTimeoutInterceptor(true, nil); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+}
+
+func TestDBTimeoutStream_InternalAfterTimeoutIsDeadlineExceeded(t *testing.T) {
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		db.RecordTimeout(ss.Context())
+		return status.Error(codes.Internal, "failed to list")
+	}
+	err := DBTimeoutStream()(nil, &contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, handler)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,92 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+)
+
+// LocaleHeader is the gRPC metadata key a client sets to choose the locale of error messages explicitly. Without it
+// the locale is negotiated from accept-language.
+const LocaleHeader = "x-locale"
+
+// ContentLanguageHeader is the response header carrying the negotiated locale.
+const ContentLanguageHeader = "content-language"
+
+// LocalizeErrorsUnary returns a unary server interceptor that negotiates the client's locale from x-locale and
+// accept-language (GetLocale), echoes it in the content-language header, and adds an ErrorInfo (stable reason) and a
+// LocalizedMessage in that locale to errors. The status message stays English. Must run before the interceptors whose
+// errors it localizes (auth, maintenance, quota).
+func LocalizeErrorsUnary(catalog *i18n.Catalog) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		locale := negotiateLocale(ctx, catalog)
+		ctx = WithLocale(ctx, locale)
+		// Best-effort: SetHeader fails only when there is no server transport stream (e.g. direct handler calls in tests).
+		_ = grpc.SetHeader(ctx, metadata.Pairs(ContentLanguageHeader, locale))
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, localizeError(catalog, err, locale)
+		}
+		return resp, nil
+	}
+}
+
+// LocalizeErrorsStream is the streaming counterpart of LocalizeErrorsUnary.
+func LocalizeErrorsStream(catalog *i18n.Catalog) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		locale := negotiateLocale(ss.Context(), catalog)
+		_ = ss.SetHeader(metadata.Pairs(ContentLanguageHeader, locale))
+		if err := handler(srv, &contextStream{ServerStream: ss, ctx: WithLocale(ss.Context(), locale)}); err != nil {
+			return localizeError(catalog, err, locale)
+		}
+		return nil
+	}
+}
+
+// negotiateLocale returns the catalog locale for the incoming x-locale and accept-language metadata.
+func negotiateLocale(ctx context.Context, catalog *i18n.Catalog) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if vals := md.Get(key); len(vals) > 0 {
+			return vals[0]
+		}
+		return ""
+	}
+	return catalog.Negotiate(first(LocaleHeader), first("accept-language"))
+}
+
+// localizeError returns err as a status error that carries an ErrorInfo and a LocalizedMessage in locale, keeping its
+// code, message and details. Details already present are not added twice.
+func localizeError(catalog *i18n.Catalog, err error, locale string) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+	}
+	var hasLocalized bool
+	for _, d := range st.Details() {
+		if _, ok := d.(*errdetails.LocalizedMessage); ok {
+			hasLocalized = true
+		}
+	}
+	var details []protoadapt.MessageV1
+	if i18n.ErrorInfo(st) == nil {
+		details = append(details, &errdetails.ErrorInfo{Reason: catalog.Reason(st), Domain: i18n.ErrorDomain})
+	}
+	if !hasLocalized {
+		details = append(details, &errdetails.LocalizedMessage{Locale: locale, Message: catalog.Localize(st, locale)})
+	}
+	if len(details) == 0 {
+		return err
+	}
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return err
+	}
+	return withDetails.Err()
+}
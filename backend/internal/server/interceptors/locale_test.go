@@ -0,0 +1,90 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+)
+
+func runLocalizeInterceptor(t *testing.T, md metadata.MD, handlerErr error) (string, *status.Status) {
+	t.Helper()
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	var locale string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		locale = GetLocale(ctx)
+		return nil, handlerErr
+	}
+	_, err := LocalizeErrorsUnary(i18n.Default())(ctx, "request", &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+	return locale, status.Convert(err)
+}
+
+func errorDetails(st *status.Status) (*errdetails.ErrorInfo, *errdetails.LocalizedMessage) {
+	var info *errdetails.ErrorInfo
+	var localized *errdetails.LocalizedMessage
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.LocalizedMessage:
+			localized = d
+		}
+	}
+	return info, localized
+}
+
+func TestLocalizeErrorsUnary(t *testing.T) {
+	locale, st := runLocalizeInterceptor(t, metadata.Pairs("accept-language", "de-DE,de;q=0.9,en;q=0.8"), status.Error(codes.Unauthenticated, "invalid credentials"))
+	if locale != "de" {
+		t.Errorf("locale = %q, want de", locale)
+	}
+	info, localized := errorDetails(st)
+	if st.Code() != codes.Unauthenticated || st.Message() != "invalid credentials" {
+		t.Errorf("status = %v %q, want the original code and message", st.Code(), st.Message())
+	}
+	if info == nil || info.Reason != "INVALID_CREDENTIALS" || info.Domain != i18n.ErrorDomain {
+		t.Errorf("ErrorInfo = %v", info)
+	}
+	if localized == nil || localized.Locale != "de" || localized.Message != "Ungültige Anmeldedaten." {
+		t.Errorf("LocalizedMessage = %v", localized)
+	}
+
+	_, st = runLocalizeInterceptor(t, metadata.Pairs(LocaleHeader, "fr", "accept-language", "de"), status.Error(codes.Internal, "failed to load widget"))
+	if info, localized := errorDetails(st); info.Reason != "INTERNAL" || localized.Locale != "fr" || localized.Message != "Une erreur interne s'est produite." {
+		t.Errorf("unknown message = %v, %v", info, localized)
+	}
+
+	if locale, st := runLocalizeInterceptor(t, metadata.MD{}, nil); locale != "en" || st != nil {
+		t.Errorf("success = %q, %v", locale, st)
+	}
+}
+
+func TestLocalizeErrorsUnary_KeepsDetails(t *testing.T) {
+	gate := fixedMaintenanceGate{Mode: platformsettingsdomain.MaintenanceReadOnly, Message: "upgrading"}
+	err := maintenanceCheck(context.Background(), gate, nil, "/test.Service/UpdateThing", func(metadata.MD) {})
+	_, st := runLocalizeInterceptor(t, metadata.Pairs(LocaleHeader, "es"), err)
+
+	info, localized := errorDetails(st)
+	if info == nil || info.Reason != "READ_ONLY_MODE" || localized == nil || localized.Message != "La plataforma está en modo de solo lectura." {
+		t.Errorf("details = %v, %v", info, localized)
+	}
+	var infos, retries int
+	for _, d := range st.Details() {
+		switch d.(type) {
+		case *errdetails.ErrorInfo:
+			infos++
+		case *errdetails.RetryInfo:
+			retries++
+		}
+	}
+	if infos != 1 || retries != 1 || st.Message() != "the control plane is in read-only mode: upgrading" {
+		t.Errorf("details = %v, message %q; want one ErrorInfo, the RetryInfo and the original message", st.Details(), st.Message())
+	}
+}
@@ -0,0 +1,130 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adminv1 "zero-trust-control-plane/backend/api/generated/admin/v1"
+	auditv1 "zero-trust-control-plane/backend/api/generated/audit/v1"
+	auditwebhookv1 "zero-trust-control-plane/backend/api/generated/auditwebhook/v1"
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	healthv1 "zero-trust-control-plane/backend/api/generated/health/v1"
+	notificationv1 "zero-trust-control-plane/backend/api/generated/notification/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	policyv1 "zero-trust-control-plane/backend/api/generated/policy/v1"
+	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
+	telemetryv1 "zero-trust-control-plane/backend/api/generated/telemetry/v1"
+	userv1 "zero-trust-control-plane/backend/api/generated/user/v1"
+	userattributev1 "zero-trust-control-plane/backend/api/generated/userattribute/v1"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+)
+
+type fixedMaintenanceGate platformsettingsdomain.MaintenanceState
+
+func (g fixedMaintenanceGate) Current(ctx context.Context) platformsettingsdomain.MaintenanceState {
+	return platformsettingsdomain.MaintenanceState(g)
+}
+
+func runMaintenanceUnary(t *testing.T, gate MaintenanceGate, method string) error {
+	t.Helper()
+	exempt := map[string]bool{"/ztcp.auth.v1.AuthService/Refresh": true}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err := MaintenanceUnary(gate, exempt)(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return err
+}
+
+func TestMaintenanceUnary_Modes(t *testing.T) {
+	tests := []struct {
+		mode    platformsettingsdomain.MaintenanceMode
+		method  string
+		blocked bool
+	}{
+		{platformsettingsdomain.MaintenanceOff, "/ztcp.policy.v1.PolicyService/CreatePolicy", false},
+		{"", "/ztcp.policy.v1.PolicyService/CreatePolicy", false},
+		{platformsettingsdomain.MaintenanceReadOnly, "/ztcp.policy.v1.PolicyService/CreatePolicy", true},
+		{platformsettingsdomain.MaintenanceReadOnly, "/ztcp.auth.v1.AuthService/Login", true},
+		{platformsettingsdomain.MaintenanceReadOnly, policyv1.PolicyService_ListPolicies_FullMethodName, false},
+		{platformsettingsdomain.MaintenanceReadOnly, "/ztcp.auth.v1.AuthService/Refresh", false},
+		{platformsettingsdomain.MaintenanceReadOnly, authv1.AuthService_Introspect_FullMethodName, false},
+		{platformsettingsdomain.MaintenanceReadOnly, auditwebhookv1.AuditWebhookService_TestAuditWebhookFilter_FullMethodName, false},
+		{platformsettingsdomain.MaintenanceFull, policyv1.PolicyService_ListPolicies_FullMethodName, true},
+		{platformsettingsdomain.MaintenanceFull, "/ztcp.auth.v1.AuthService/Refresh", false},
+	}
+	for _, tt := range tests {
+		err := runMaintenanceUnary(t, fixedMaintenanceGate{Mode: tt.mode}, tt.method)
+		if tt.blocked && status.Code(err) != codes.Unavailable {
+			t.Errorf("%s %s: code = %v, want Unavailable", tt.mode, tt.method, status.Code(err))
+		}
+		if !tt.blocked && err != nil {
+			t.Errorf("%s %s: %v, want served", tt.mode, tt.method, err)
+		}
+	}
+}
+
+func TestMaintenanceUnary_RetryHint(t *testing.T) {
+	gate := fixedMaintenanceGate{Mode: platformsettingsdomain.MaintenanceReadOnly, Message: "database upgrade", RetryAfter: 90 * time.Second}
+	err := runMaintenanceUnary(t, gate, "/ztcp.policy.v1.PolicyService/UpdatePolicy")
+	st := status.Convert(err)
+	if !strings.Contains(st.Message(), "read-only") || !strings.Contains(st.Message(), "database upgrade") {
+		t.Errorf("message = %q", st.Message())
+	}
+	var retry *errdetails.RetryInfo
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			retry = ri
+		}
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() != 90*time.Second {
+		t.Errorf("RetryInfo = %v, want 90s", retry)
+	}
+
+	// Without a stored hint the default is used.
+	err = runMaintenanceUnary(t, fixedMaintenanceGate{Mode: platformsettingsdomain.MaintenanceFull}, policyv1.PolicyService_ListPolicies_FullMethodName)
+	for _, d := range status.Convert(err).Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok && ri.RetryDelay.AsDuration() != platformsettingsdomain.DefaultMaintenanceRetryAfter {
+			t.Errorf("default RetryInfo = %v", ri.RetryDelay.AsDuration())
+		}
+	}
+}
+
+func TestMaintenanceUnary_NilGate(t *testing.T) {
+	if err := runMaintenanceUnary(t, nil, "/ztcp.policy.v1.PolicyService/CreatePolicy"); err != nil {
+		t.Errorf("nil gate: %v", err)
+	}
+}
+
+func TestIsReadMethod(t *testing.T) {
+	for method, want := range map[string]bool{
+		orgpolicyconfigv1.OrgPolicyConfigService_GetOrgPolicyConfig_FullMethodName:     true,
+		orgpolicyconfigv1.OrgPolicyConfigService_SubscribeBrowserPolicy_FullMethodName: true,
+		orgpolicyconfigv1.OrgPolicyConfigService_CheckUrlAccess_FullMethodName:         true,
+		auditv1.AuditService_StreamAuditEvents_FullMethodName:                          true,
+		healthv1.HealthService_HealthCheck_FullMethodName:                              true,
+		// Reads whose names do not start with Get or List.
+		authv1.AuthService_Introspect_FullMethodName:                                  true,
+		telemetryv1.TelemetryService_QueryTelemetry_FullMethodName:                    true,
+		userattributev1.UserAttributeService_SearchMembers_FullMethodName:             true,
+		notificationv1.NotificationService_PreviewNotificationTemplate_FullMethodName: true,
+		adminv1.AdminService_ExportUsage_FullMethodName:                               true,
+		userv1.UserService_DownloadDataExport_FullMethodName:                          true,
+		orgpolicyconfigv1.OrgPolicyConfigService_UpdateOrgPolicyConfig_FullMethodName: false,
+		sessionv1.SessionService_RevokeSession_FullMethodName:                         false,
+		authv1.AuthService_Logout_FullMethodName:                                      false,
+		authv1.AuthService_PollDeviceAuthorization_FullMethodName:                     false,
+		notificationv1.NotificationService_SendTestEmail_FullMethodName:               false,
+		telemetryv1.TelemetryService_StreamTelemetry_FullMethodName:                   false,
+		"/ztcp.unknown.v1.UnknownService/GetThing":                                    false,
+		"malformed": false,
+	} {
+		if got := IsReadMethod(method); got != want {
+			t.Errorf("IsReadMethod(%s) = %v, want %v", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UsageMeter counts an API call of a user in an org for billing, e.g. *metering.Meter.
+type UsageMeter interface {
+	Record(orgID, userID string)
+}
+
+// MeteringUnary returns a unary server interceptor that counts authenticated RPCs against their org's usage. It must
+// run after the auth interceptor, which puts the identity in the context; unauthenticated calls (public methods) and
+// exemptMethods (e.g. HealthService HealthCheck) are not counted. Calls are counted whether or not they succeed.
+func MeteringUnary(meter UsageMeter, exemptMethods map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		meterCall(ctx, meter, exemptMethods, info.FullMethod)
+		return handler(ctx, req)
+	}
+}
+
+// MeteringStream is the streaming counterpart of MeteringUnary. Opening a stream counts as one call.
+func MeteringStream(meter UsageMeter, exemptMethods map[string]bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		meterCall(ss.Context(), meter, exemptMethods, info.FullMethod)
+		return handler(srv, ss)
+	}
+}
+
+func meterCall(ctx context.Context, meter UsageMeter, exemptMethods map[string]bool, method string) {
+	if meter == nil || exemptMethods[method] {
+		return
+	}
+	orgID, ok := GetOrgID(ctx)
+	if !ok || orgID == "" {
+		return
+	}
+	userID, _ := GetUserID(ctx)
+	meter.Record(orgID, userID)
+}
@@ -0,0 +1,44 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+type recordingMeter struct {
+	calls []string
+}
+
+func (m *recordingMeter) Record(orgID, userID string) {
+	m.calls = append(m.calls, orgID+"/"+userID)
+}
+
+func TestMeteringUnary_CountsAuthenticatedCalls(t *testing.T) {
+	meter := &recordingMeter{}
+	exempt := map[string]bool{"/ztcp.health.v1.HealthService/HealthCheck": true}
+	interceptor := MeteringUnary(meter, exempt)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	authed := WithIdentity(context.Background(), "user-1", "org-1", "sess-1")
+
+	for _, c := range []struct {
+		ctx    context.Context
+		method string
+	}{
+		{authed, "/ztcp.policy.v1.PolicyService/ListPolicies"},
+		{context.Background(), "/ztcp.auth.v1.AuthService/Login"},
+		{authed, "/ztcp.health.v1.HealthService/HealthCheck"},
+	} {
+		if _, err := interceptor(c.ctx, "request", &grpc.UnaryServerInfo{FullMethod: c.method}, handler); err != nil {
+			t.Fatalf("%s: %v", c.method, err)
+		}
+	}
+	if len(meter.calls) != 1 || meter.calls[0] != "org-1/user-1" {
+		t.Errorf("metered calls = %v, want only the authenticated, non-exempt one", meter.calls)
+	}
+
+	if _, err := MeteringUnary(nil, exempt)(authed, "request", &grpc.UnaryServerInfo{FullMethod: "/x"}, handler); err != nil {
+		t.Errorf("nil meter: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+	quotadomain "zero-trust-control-plane/backend/internal/quota/domain"
+)
+
+// QuotaGate counts a request against the quotas of its org and session, e.g. *quota.Enforcer.
+type QuotaGate interface {
+	Check(ctx context.Context, orgID, sessionID string) quotadomain.Decision
+}
+
+// QuotaUnary returns a unary server interceptor that rejects authenticated RPCs over their org's or session's
+// quota with ResourceExhausted. It must run after the auth interceptor, which puts the identity in the context;
+// unauthenticated calls (public methods) are not counted. exemptMethods is the set of full method names that are
+// never counted (e.g. HealthService HealthCheck, AdminService SetOrgQuota). Rejected calls carry QuotaFailure and
+// RetryInfo details and a retry-after header (seconds).
+func QuotaUnary(gate QuotaGate, exemptMethods map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := quotaCheck(ctx, gate, exemptMethods, info.FullMethod, func(md metadata.MD) { _ = grpc.SetHeader(ctx, md) }); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// QuotaStream is the streaming counterpart of QuotaUnary. Opening a stream counts as one request; messages on an
+// open stream are not counted.
+func QuotaStream(gate QuotaGate, exemptMethods map[string]bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := quotaCheck(ss.Context(), gate, exemptMethods, info.FullMethod, func(md metadata.MD) { _ = ss.SetHeader(md) }); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// quotaCheck returns the ResourceExhausted error for a call over quota, or nil if it may proceed. setHeader sends
+// the retry-after header of a rejected call.
+func quotaCheck(ctx context.Context, gate QuotaGate, exemptMethods map[string]bool, method string, setHeader func(metadata.MD)) error {
+	if gate == nil || exemptMethods[method] {
+		return nil
+	}
+	orgID, ok := GetOrgID(ctx)
+	if !ok || orgID == "" {
+		return nil
+	}
+	sessionID, _ := GetSessionID(ctx)
+	d := gate.Check(ctx, orgID, sessionID)
+	if d.Allowed {
+		return nil
+	}
+	// Round up so a client waiting the header's seconds lands in the next window.
+	retrySeconds := int((d.RetryAfter + time.Second - 1) / time.Second)
+	if retrySeconds < 1 {
+		retrySeconds = 1
+	}
+	setHeader(metadata.Pairs("retry-after", strconv.Itoa(retrySeconds)))
+	subject, reason := "org:"+orgID, "ORG_QUOTA_EXCEEDED"
+	msg := fmt.Sprintf("org quota of %d requests per minute exceeded", d.Limit)
+	if d.Scope == quotadomain.ScopeToken {
+		subject, reason = "session:"+sessionID, "SESSION_QUOTA_EXCEEDED"
+		msg = fmt.Sprintf("session quota of %d requests per minute exceeded", d.Limit)
+	}
+	st := status.New(codes.ResourceExhausted, msg)
+	withDetails, err := st.WithDetails(
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{Subject: subject, Description: msg}}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(retrySeconds) * time.Second)},
+		&errdetails.ErrorInfo{Reason: reason, Domain: i18n.ErrorDomain, Metadata: map[string]string{"limit": strconv.Itoa(d.Limit)}},
+	)
+	if err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
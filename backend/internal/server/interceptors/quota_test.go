@@ -0,0 +1,83 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	quotadomain "zero-trust-control-plane/backend/internal/quota/domain"
+)
+
+type recordingQuotaGate struct {
+	decision quotadomain.Decision
+	calls    []string
+}
+
+func (g *recordingQuotaGate) Check(ctx context.Context, orgID, sessionID string) quotadomain.Decision {
+	g.calls = append(g.calls, orgID+"/"+sessionID)
+	return g.decision
+}
+
+func runQuotaUnary(ctx context.Context, gate QuotaGate, method string) error {
+	exempt := map[string]bool{"/ztcp.health.v1.HealthService/HealthCheck": true}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err := QuotaUnary(gate, exempt)(ctx, "request", &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return err
+}
+
+func TestQuotaUnary_CountsAuthenticatedCalls(t *testing.T) {
+	gate := &recordingQuotaGate{decision: quotadomain.Decision{Allowed: true}}
+	authed := WithIdentity(context.Background(), "user-1", "org-1", "sess-1")
+
+	if err := runQuotaUnary(authed, gate, "/ztcp.policy.v1.PolicyService/ListPolicies"); err != nil {
+		t.Fatalf("allowed call: %v", err)
+	}
+	if err := runQuotaUnary(context.Background(), gate, "/ztcp.auth.v1.AuthService/Login"); err != nil {
+		t.Fatalf("public call: %v", err)
+	}
+	if err := runQuotaUnary(authed, gate, "/ztcp.health.v1.HealthService/HealthCheck"); err != nil {
+		t.Fatalf("exempt call: %v", err)
+	}
+	if len(gate.calls) != 1 || gate.calls[0] != "org-1/sess-1" {
+		t.Errorf("counted calls = %v, want only the authenticated, non-exempt one", gate.calls)
+	}
+}
+
+func TestQuotaUnary_Rejected(t *testing.T) {
+	tests := []struct {
+		scope   string
+		subject string
+	}{
+		{quotadomain.ScopeOrg, "org:org-1"},
+		{quotadomain.ScopeToken, "session:sess-1"},
+	}
+	for _, tt := range tests {
+		gate := &recordingQuotaGate{decision: quotadomain.Decision{Scope: tt.scope, Limit: 10, RetryAfter: 1500 * time.Millisecond}}
+		ctx := WithIdentity(context.Background(), "user-1", "org-1", "sess-1")
+		st := status.Convert(runQuotaUnary(ctx, gate, "/ztcp.policy.v1.PolicyService/ListPolicies"))
+		if st.Code() != codes.ResourceExhausted {
+			t.Fatalf("%s: code = %v, want ResourceExhausted", tt.scope, st.Code())
+		}
+		var failure *errdetails.QuotaFailure
+		var retry *errdetails.RetryInfo
+		for _, d := range st.Details() {
+			switch d := d.(type) {
+			case *errdetails.QuotaFailure:
+				failure = d
+			case *errdetails.RetryInfo:
+				retry = d
+			}
+		}
+		if failure == nil || len(failure.Violations) != 1 || failure.Violations[0].Subject != tt.subject {
+			t.Errorf("%s: QuotaFailure = %v, want subject %s", tt.scope, failure, tt.subject)
+		}
+		if retry == nil || retry.RetryDelay.AsDuration() != 2*time.Second {
+			t.Errorf("%s: RetryInfo = %v, want 2s (rounded up)", tt.scope, retry)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is the gRPC metadata key carrying the request correlation ID (incoming and outgoing).
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLen bounds client-supplied request IDs so they cannot bloat audit rows or log lines.
+const maxRequestIDLen = 128
+
+// RequestIDUnary returns a unary server interceptor that accepts the client's x-request-id (when well-formed)
+// or generates a new UUID, stores it in context (GetRequestID), and echoes it back in the response header.
+// Must run first in the chain so auth, audit, and handlers all see the same ID.
+func RequestIDUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestID := incomingRequestID(ctx)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		ctx = WithRequestID(ctx, requestID)
+		// Best-effort: SetHeader fails only when there is no server transport stream (e.g. direct handler calls in tests).
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+		return handler(ctx, req)
+	}
+}
+
+// RequestIDStream is the streaming counterpart of RequestIDUnary, so stream handlers (e.g. StreamTelemetry) see the
+// same ID. Must run first in the stream chain.
+func RequestIDStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		requestID := incomingRequestID(ss.Context())
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: WithRequestID(ss.Context(), requestID)})
+	}
+}
+
+// RequestID returns the request correlation ID from context, or "" if none was set.
+// Matches the audit.RequestIDExtractor signature.
+func RequestID(ctx context.Context) string {
+	id, _ := GetRequestID(ctx)
+	return id
+}
+
+// incomingRequestID returns the client-supplied x-request-id if present and well-formed; otherwise "".
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	vals := md.Get(RequestIDHeader)
+	if len(vals) == 0 {
+		return ""
+	}
+	if !validRequestID(vals[0]) {
+		return ""
+	}
+	return vals[0]
+}
+
+// validRequestID returns true if id is non-empty, at most maxRequestIDLen, and uses only [A-Za-z0-9._:-].
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	auditdomain "zero-trust-control-plane/backend/internal/audit/domain"
+)
+
+func runRequestIDInterceptor(t *testing.T, ctx context.Context) string {
+	t.Helper()
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = RequestID(ctx)
+		return "ok", nil
+	}
+	if _, err := RequestIDUnary()(ctx, "request", &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	return got
+}
+
+func TestRequestIDUnary_GeneratesWhenAbsent(t *testing.T) {
+	got := runRequestIDInterceptor(t, context.Background())
+	if got == "" {
+		t.Fatal("request id should be generated when absent")
+	}
+	other := runRequestIDInterceptor(t, context.Background())
+	if got == other {
+		t.Errorf("generated request ids should differ, both %q", got)
+	}
+}
+
+func TestRequestIDUnary_UsesIncoming(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "req-abc.123:x_y"))
+	if got := runRequestIDInterceptor(t, ctx); got != "req-abc.123:x_y" {
+		t.Errorf("request id = %q, want %q", got, "req-abc.123:x_y")
+	}
+}
+
+func TestRequestIDUnary_RejectsInvalidIncoming(t *testing.T) {
+	for _, id := range []string{"bad id", "id\nwith-newline", strings.Repeat("a", maxRequestIDLen+1)} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, id))
+		got := runRequestIDInterceptor(t, ctx)
+		if got == "" || got == id {
+			t.Errorf("invalid incoming id %q should be replaced, got %q", id, got)
+		}
+	}
+}
+
+// headerStream records the headers set on it.
+type headerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *headerStream) Context() context.Context { return s.ctx }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestRequestIDStream(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		incoming string
+	}{
+		{"incoming", "req-abc"},
+		{"absent", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.incoming != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(RequestIDHeader, tt.incoming))
+			}
+			ss := &headerStream{ctx: ctx}
+			var got string
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				got = RequestID(stream.Context())
+				return nil
+			}
+			if err := RequestIDStream()(nil, ss, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, handler); err != nil {
+				t.Fatalf("interceptor: %v", err)
+			}
+			if got == "" || (tt.incoming != "" && got != tt.incoming) {
+				t.Errorf("request id = %q, want %q or a generated one", got, tt.incoming)
+			}
+			if h := ss.header.Get(RequestIDHeader); len(h) != 1 || h[0] != got {
+				t.Errorf("response header = %v, want [%s]", h, got)
+			}
+		})
+	}
+}
+
+func TestRequestID_NotSet(t *testing.T) {
+	if got := RequestID(context.Background()); got != "" {
+		t.Errorf("RequestID = %q, want empty", got)
+	}
+}
+
+func TestAuditUnary_RecordsRequestID(t *testing.T) {
+	repo := &mockAuditRepoForInterceptor{entries: make([]*auditdomain.AuditLog, 0)}
+	interceptor := AuditUnary(repo, nil)
+	ctx := WithRequestID(WithIdentity(context.Background(), "user-1", "org-1", "session-1"), "req-1")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+	if _, err := interceptor(ctx, "request", &grpc.UnaryServerInfo{FullMethod: "/ztcp.user.v1.UserService/GetUser"}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if len(repo.entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(repo.entries))
+	}
+	if repo.entries[0].RequestID != "req-1" {
+		t.Errorf("RequestID = %q, want %q", repo.entries[0].RequestID, "req-1")
+	}
+}
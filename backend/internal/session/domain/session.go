@@ -2,17 +2,68 @@ package domain
 
 import "time"
 
-// Session represents a user session tied to a device.
+// Session represents a user session tied to a device, or to none for an ephemeral (public device) session.
 type Session struct {
-	ID                string
-	UserID            string
-	OrgID             string
-	DeviceID          string
-	ExpiresAt         time.Time
-	RevokedAt         *time.Time // nil when not revoked
-	LastSeenAt        *time.Time
-	IPAddress         string
-	RefreshJti        string // current refresh token jti for rotation; empty if not set
-	RefreshTokenHash  string // SHA-256 hash of current refresh token; empty for legacy sessions
-	CreatedAt         time.Time
+	ID               string
+	UserID           string
+	OrgID            string
+	DeviceID         string // empty for an ephemeral session
+	ExpiresAt        time.Time
+	RevokedAt        *time.Time // nil when not revoked
+	LastSeenAt       *time.Time
+	IPAddress        string
+	RefreshJti       string // current refresh token jti for rotation; empty if not set
+	RefreshTokenHash string // SHA-256 hash of current refresh token; empty for legacy sessions
+	CreatedAt        time.Time
+	Country          string           // ISO country code of the client at sign-in; empty when unknown
+	PoPKeyThumbprint string           // RFC 7638 thumbprint of the key refreshes must prove possession of; empty when unbound
+	UserAgent        string           // client User-Agent at sign-in; empty when not sent
+	ClientVersion    string           // client app version at sign-in (x-client-version metadata); empty when not sent
+	AuthMethod       string           // primary factor, e.g. AuthMethodPassword; empty for sessions created before it was recorded
+	MFAMethod        string           // second factor (e.g. sms_otp, recovery_code); empty when none was used
+	RevocationReason RevocationReason // why the session was revoked; empty when active or revoked before it was recorded
+	RevokedBy        string           // user ID of who revoked the session; empty when the system did
+	Ephemeral        bool             // public/shared device sign-in: no device, shorter lifetime, revoked when idle
+}
+
+// Primary authentication methods recorded in Session.AuthMethod.
+const (
+	AuthMethodPassword   = "password"
+	AuthMethodSSO        = "sso"         // OIDC or SAML sign-in; reserved until identity/provider implements them
+	AuthMethodBreakGlass = "break_glass" // BreakGlassService.SignIn with an org's sealed break-glass credential
+	AuthMethodDeviceCode = "device_code" // AuthService.PollDeviceAuthorization, approved from a trusted session
+	AuthMethodMagicLink  = "magic_link"  // AuthService.CompleteMagicLink with a link sent by email
+)
+
+// RevocationReason is why a session was revoked.
+type RevocationReason string
+
+// Revocation reasons recorded in Session.RevocationReason.
+const (
+	RevocationLogout        RevocationReason = "logout"         // the user signed out (AuthService.Logout)
+	RevocationAdminRevoke   RevocationReason = "admin_revoke"   // an org admin revoked it (SessionService, AdminResetMFA)
+	RevocationReuseDetected RevocationReason = "reuse_detected" // a rotated refresh token was reused; all of the user's sessions are revoked
+	RevocationPolicyChange  RevocationReason = "policy_change"  // policy ended it: MFA required on refresh, or a policy violation step-up
+	RevocationIdleTimeout   RevocationReason = "idle_timeout"   // an ephemeral (public device) session went unused past its idle timeout
+	RevocationBreakGlass    RevocationReason = "break_glass"    // the break-glass access window it belonged to ended
+	RevocationUserMerged    RevocationReason = "user_merged"    // its user was merged into another (AdminService MergeUsers)
+	RevocationLogoutAll     RevocationReason = "logout_all"     // the user signed out everywhere (AuthService.LogoutAllMySessions)
+	RevocationEmailChanged  RevocationReason = "email_changed"  // the user's email was changed (AuthService.ConfirmEmailChange)
+)
+
+// Revocation is the reason and actor recorded when sessions are revoked. A session that is already revoked keeps
+// its first revocation.
+type Revocation struct {
+	Reason RevocationReason
+	By     string // user ID of the actor; empty when the system revoked the session (e.g. reuse_detected)
+}
+
+// Details is a session together with its user and device, resolved in the same query. The device fields are empty
+// for an ephemeral session.
+type Details struct {
+	Session
+	UserEmail         string
+	UserName          string
+	DeviceFingerprint string
+	DeviceTrusted     bool // effective trust when read: trusted, not revoked and trust not expired
 }
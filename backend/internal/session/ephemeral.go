@@ -0,0 +1,81 @@
+// Package session runs background jobs over sessions: it revokes ephemeral (public device) sessions left idle.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// ephemeralSweepBatch is how many idle ephemeral sessions one pass of the sweeper revokes at most.
+const ephemeralSweepBatch = 500
+
+// IdleEphemeralLister lists active ephemeral sessions not refreshed since idleSince. Implemented by the session
+// repository.
+type IdleEphemeralLister interface {
+	ListIdleEphemeral(ctx context.Context, idleSince time.Time, limit int32) ([]*domain.Session, error)
+}
+
+// Revoker revokes one session. Implemented by the session repository, or by its replicating wrapper so the
+// revocation reaches other regions.
+type Revoker interface {
+	Revoke(ctx context.Context, id string, rev domain.Revocation) error
+}
+
+// EphemeralSweepJob revokes ephemeral (public device) sessions left idle past the idle timeout, as idle_timeout,
+// and audits each as session_expired. Refresh already refuses an idle ephemeral session; the job ends the ones whose
+// client never comes back, so they stop counting as active and their access tokens are revoked as soon as possible.
+type EphemeralSweepJob struct {
+	sessions    IdleEphemeralLister
+	revoker     Revoker
+	auditLogger audit.AuditLogger
+	idleTimeout time.Duration
+	now         func() time.Time
+}
+
+// NewEphemeralSweepJob returns an EphemeralSweepJob. auditLogger may be nil.
+func NewEphemeralSweepJob(sessions IdleEphemeralLister, revoker Revoker, auditLogger audit.AuditLogger, idleTimeout time.Duration) *EphemeralSweepJob {
+	return &EphemeralSweepJob{sessions: sessions, revoker: revoker, auditLogger: auditLogger, idleTimeout: idleTimeout, now: time.Now}
+}
+
+// RunOnce revokes up to one batch of idle ephemeral sessions and returns how many it revoked. A failed revocation is
+// logged and the session is retried on the next run.
+func (j *EphemeralSweepJob) RunOnce(ctx context.Context) (int, error) {
+	idle, err := j.sessions.ListIdleEphemeral(ctx, j.now().UTC().Add(-j.idleTimeout), ephemeralSweepBatch)
+	if err != nil {
+		return 0, err
+	}
+	revoked := 0
+	for _, s := range idle {
+		if err := j.revoker.Revoke(ctx, s.ID, domain.Revocation{Reason: domain.RevocationIdleTimeout}); err != nil {
+			log.Printf("session: revoke idle ephemeral session %s: %v", s.ID, err)
+			continue
+		}
+		revoked++
+		if j.auditLogger != nil {
+			meta, _ := json.Marshal(map[string]string{"session_id": s.ID, "limit": "idle_timeout"})
+			j.auditLogger.LogEvent(ctx, s.OrgID, s.UserID, "session_expired", "authentication", string(meta))
+		}
+	}
+	return revoked, nil
+}
+
+// Run calls RunOnce on start and then every interval until ctx is cancelled.
+func (j *EphemeralSweepJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := j.RunOnce(ctx); err != nil {
+			log.Printf("session: ephemeral sweep failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// idleSessions implements IdleEphemeralLister over a fixed list and records the cutoff it was asked for.
+type idleSessions struct {
+	sessions  []*domain.Session
+	idleSince time.Time
+}
+
+func (l *idleSessions) ListIdleEphemeral(ctx context.Context, idleSince time.Time, limit int32) ([]*domain.Session, error) {
+	l.idleSince = idleSince
+	return l.sessions, nil
+}
+
+type recordingRevoker struct {
+	revoked []string
+	failID  string
+}
+
+func (r *recordingRevoker) Revoke(ctx context.Context, id string, rev domain.Revocation) error {
+	if id == r.failID {
+		return errors.New("db down")
+	}
+	r.revoked = append(r.revoked, id+":"+string(rev.Reason))
+	return nil
+}
+
+type recordingAuditLogger struct {
+	actions []string
+}
+
+func (l *recordingAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	l.actions = append(l.actions, action)
+}
+
+func TestEphemeralSweepJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	lister := &idleSessions{sessions: []*domain.Session{
+		{ID: "s1", UserID: "u1", OrgID: "org-1", Ephemeral: true},
+		{ID: "s2", UserID: "u2", OrgID: "org-1", Ephemeral: true},
+	}}
+	revoker := &recordingRevoker{failID: "s2"}
+	auditLogger := &recordingAuditLogger{}
+	job := NewEphemeralSweepJob(lister, revoker, auditLogger, 15*time.Minute)
+	job.now = func() time.Time { return now }
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if n != 1 || len(revoker.revoked) != 1 || revoker.revoked[0] != "s1:idle_timeout" {
+		t.Errorf("revoked %d %v, want only s1 as idle_timeout", n, revoker.revoked)
+	}
+	if want := now.Add(-15 * time.Minute); !lister.idleSince.Equal(want) {
+		t.Errorf("idleSince = %v, want %v", lister.idleSince, want)
+	}
+	if len(auditLogger.actions) != 1 || auditLogger.actions[0] != "session_expired" {
+		t.Errorf("audited %v, want one session_expired", auditLogger.actions)
+	}
+}
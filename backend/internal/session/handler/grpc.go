@@ -2,7 +2,10 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -12,7 +15,13 @@ import (
 	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
 	"zero-trust-control-plane/backend/internal/audit"
 	membershiprepo "zero-trust-control-plane/backend/internal/membership/repository"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	orgpolicyconfigrepo "zero-trust-control-plane/backend/internal/orgpolicyconfig/repository"
+	"zero-trust-control-plane/backend/internal/platform/confirm"
 	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/securityevent"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 	"zero-trust-control-plane/backend/internal/session/domain"
 	sessionrepo "zero-trust-control-plane/backend/internal/session/repository"
 )
@@ -20,8 +29,29 @@ import (
 const (
 	defaultPageSize = 50
 	maxPageSize     = 100
+
+	// orgLogoutBatchSize is how many sessions RevokeAllSessionsForOrg revokes per batch (and progress message).
+	orgLogoutBatchSize = 500
+	// orgLogoutConfirmationTTL is how long a RevokeAllSessionsForOrg confirmation token is valid.
+	orgLogoutConfirmationTTL = 5 * time.Minute
+	// orgLogoutConfirmationPurpose binds RevokeAllSessionsForOrg confirmation tokens, which are bound to the org and
+	// the owner's session, to this RPC.
+	orgLogoutConfirmationPurpose = "org_logout"
+
+	// revocationPollInterval is how often a SubscribeRevocations stream looks for new revocations.
+	revocationPollInterval = time.Second
+	// revocationLookback is how far back each poll reads again, so that revocations committed (or replicated from
+	// another region) with a slightly older revoked_at are not missed.
+	revocationLookback = time.Minute
+	// revocationBatchSize is the most revocations read per query.
+	revocationBatchSize = 500
+	// maxRevocationReplay bounds SubscribeRevocations' since: older revocations only concern expired tokens.
+	maxRevocationReplay = 24 * time.Hour
 )
 
+// errOutsideGroups is returned to a group admin for a user outside the groups they administer.
+var errOutsideGroups = status.Error(codes.PermissionDenied, "user is not in a group you administer")
+
 // Server implements SessionService (proto server) for session lifecycle.
 // Proto: session/session.proto → internal/session/handler.
 type Server struct {
@@ -29,26 +59,45 @@ type Server struct {
 	sessionRepo    sessionrepo.Repository
 	membershipRepo membershiprepo.Repository
 	auditLogger    audit.AuditLogger
+	orgPolicyRepo  orgpolicyconfigrepo.Repository
+	securityEvents securityevent.Recorder
+	groups         rbac.GroupAdminScoper
+	confirmations  *confirm.Signer
+	pollInterval   time.Duration
 }
 
 // NewServer returns a new Session gRPC server. If sessionRepo is nil, all RPCs return Unimplemented.
-func NewServer(sessionRepo sessionrepo.Repository, membershipRepo membershiprepo.Repository, auditLogger audit.AuditLogger) *Server {
+// orgPolicyRepo is optional; when nil, the session_mgmt defaults apply. securityEvents is optional; when non-nil,
+// users signed out by RevokeAllSessionsForOrg get an event in their security feed. groups is optional; when non-nil,
+// group admins may manage the sessions of their groups' users. confirmations signs RevokeAllSessionsForOrg confirmation
+// tokens; when nil, a signer with a random per-process key is used.
+func NewServer(sessionRepo sessionrepo.Repository, membershipRepo membershiprepo.Repository, auditLogger audit.AuditLogger, orgPolicyRepo orgpolicyconfigrepo.Repository, securityEvents securityevent.Recorder, groups rbac.GroupAdminScoper, confirmations *confirm.Signer) *Server {
+	if confirmations == nil {
+		confirmations = confirm.NewSigner("")
+	}
 	return &Server{
 		sessionRepo:    sessionRepo,
 		membershipRepo: membershipRepo,
 		auditLogger:    auditLogger,
+		orgPolicyRepo:  orgPolicyRepo,
+		securityEvents: securityEvents,
+		groups:         groups,
+		confirmations:  confirmations,
+		pollInterval:   revocationPollInterval,
 	}
 }
 
-// RevokeSession revokes a session. Caller must be org admin or owner; session must belong to caller's org.
+// RevokeSession revokes a session. Caller must be org admin or owner, or a group admin of the session's user; session
+// must belong to caller's org.
 func (s *Server) RevokeSession(ctx context.Context, req *sessionv1.RevokeSessionRequest) (*sessionv1.RevokeSessionResponse, error) {
 	if s.sessionRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method RevokeSession not implemented")
 	}
-	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	scope, err := rbac.RequireAdminScope(ctx, s.membershipRepo, s.groups)
 	if err != nil {
 		return nil, err
 	}
+	orgID, userID := scope.OrgID, scope.UserID
 	sessionID := req.GetSessionId()
 	if sessionID == "" {
 		return nil, status.Error(codes.InvalidArgument, "session_id required")
@@ -63,24 +112,34 @@ func (s *Server) RevokeSession(ctx context.Context, req *sessionv1.RevokeSession
 	if ses.OrgID != orgID {
 		return nil, status.Error(codes.PermissionDenied, "session does not belong to your organization")
 	}
-	if err := s.sessionRepo.Revoke(ctx, sessionID); err != nil {
+	if !scope.Allows(ses.UserID) {
+		return nil, errOutsideGroups
+	}
+	rev := domain.Revocation{Reason: domain.RevocationAdminRevoke, By: userID}
+	if err := s.sessionRepo.Revoke(ctx, sessionID, rev); err != nil {
 		return nil, status.Error(codes.Internal, "failed to revoke session")
 	}
 	if s.auditLogger != nil {
-		s.auditLogger.LogEvent(ctx, orgID, userID, "revoke", "session", sessionID)
+		s.auditLogger.LogEvent(ctx, orgID, userID, "revoke", "session", revocationMetadata("session_id", sessionID, rev))
 	}
 	return &sessionv1.RevokeSessionResponse{}, nil
 }
 
-// ListSessions returns a paginated list of sessions for the org, optionally filtered by user. Caller must be org admin or owner.
+// ListSessions returns a paginated list of sessions for the org, optionally filtered by user. Caller must be org admin or owner,
+// or a group admin listing the sessions of a user in their groups (user_id required).
+// include_user and include_device embed each session's user and device, read in the same query as the sessions.
 func (s *Server) ListSessions(ctx context.Context, req *sessionv1.ListSessionsRequest) (*sessionv1.ListSessionsResponse, error) {
 	if s.sessionRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method ListSessions not implemented")
 	}
-	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	scope, err := rbac.RequireAdminScope(ctx, s.membershipRepo, s.groups)
 	if err != nil {
 		return nil, err
 	}
+	orgID := scope.OrgID
+	if !scope.OrgWide() && (req.GetUserId() == "" || !scope.Allows(req.GetUserId())) {
+		return nil, status.Error(codes.PermissionDenied, "group admins must set user_id to a user in their groups")
+	}
 	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
 		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
 	}
@@ -109,16 +168,28 @@ func (s *Server) ListSessions(ctx context.Context, req *sessionv1.ListSessionsRe
 	if req.GetUserId() != "" {
 		userID = &req.UserId
 	}
-	list, err := s.sessionRepo.ListByOrg(ctx, targetOrgID, userID, pageSize, offset)
-	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to list sessions")
-	}
-	sessions := make([]*sessionv1.Session, len(list))
-	for i := range list {
-		sessions[i] = domainSessionToProto(list[i])
+	var sessions []*sessionv1.Session
+	if req.GetIncludeUser() || req.GetIncludeDevice() {
+		list, err := s.sessionRepo.ListDetailsByOrg(ctx, targetOrgID, userID, pageSize, offset)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to list sessions")
+		}
+		sessions = make([]*sessionv1.Session, len(list))
+		for i := range list {
+			sessions[i] = domainDetailsToProto(list[i], req.GetIncludeUser(), req.GetIncludeDevice())
+		}
+	} else {
+		list, err := s.sessionRepo.ListByOrg(ctx, targetOrgID, userID, pageSize, offset)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to list sessions")
+		}
+		sessions = make([]*sessionv1.Session, len(list))
+		for i := range list {
+			sessions[i] = domainSessionToProto(list[i])
+		}
 	}
 	nextToken := ""
-	if len(list) == int(pageSize) {
+	if len(sessions) == int(pageSize) {
 		nextToken = strconv.Itoa(int(offset + pageSize))
 	}
 	return &sessionv1.ListSessionsResponse{
@@ -129,19 +200,40 @@ func (s *Server) ListSessions(ctx context.Context, req *sessionv1.ListSessionsRe
 	}, nil
 }
 
-// GetSession returns a session by ID. Caller must be org admin or owner; session must belong to caller's org.
+// GetSession returns a session by ID. Caller must be org admin or owner, or a group admin of the session's user;
+// session must belong to caller's org.
+// include_user and include_device embed the session's user and device, read in the same query as the session.
 func (s *Server) GetSession(ctx context.Context, req *sessionv1.GetSessionRequest) (*sessionv1.GetSessionResponse, error) {
 	if s.sessionRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method GetSession not implemented")
 	}
-	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	scope, err := rbac.RequireAdminScope(ctx, s.membershipRepo, s.groups)
 	if err != nil {
 		return nil, err
 	}
+	orgID := scope.OrgID
 	sessionID := req.GetSessionId()
 	if sessionID == "" {
 		return nil, status.Error(codes.InvalidArgument, "session_id required")
 	}
+	if req.GetIncludeUser() || req.GetIncludeDevice() {
+		details, err := s.sessionRepo.GetDetailsByID(ctx, sessionID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to get session")
+		}
+		if details == nil {
+			return nil, status.Error(codes.NotFound, "session not found")
+		}
+		if details.OrgID != orgID {
+			return nil, status.Error(codes.PermissionDenied, "session does not belong to your organization")
+		}
+		if !scope.Allows(details.UserID) {
+			return nil, errOutsideGroups
+		}
+		return &sessionv1.GetSessionResponse{
+			Session: domainDetailsToProto(details, req.GetIncludeUser(), req.GetIncludeDevice()),
+		}, nil
+	}
 	ses, err := s.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to get session")
@@ -152,20 +244,25 @@ func (s *Server) GetSession(ctx context.Context, req *sessionv1.GetSessionReques
 	if ses.OrgID != orgID {
 		return nil, status.Error(codes.PermissionDenied, "session does not belong to your organization")
 	}
+	if !scope.Allows(ses.UserID) {
+		return nil, errOutsideGroups
+	}
 	return &sessionv1.GetSessionResponse{
 		Session: domainSessionToProto(ses),
 	}, nil
 }
 
-// RevokeAllSessionsForUser revokes all sessions for the given user in the org. Caller must be org admin or owner.
+// RevokeAllSessionsForUser revokes all sessions for the given user in the org. Caller must be org admin or owner, or a
+// group admin of the user.
 func (s *Server) RevokeAllSessionsForUser(ctx context.Context, req *sessionv1.RevokeAllSessionsForUserRequest) (*sessionv1.RevokeAllSessionsForUserResponse, error) {
 	if s.sessionRepo == nil {
 		return nil, status.Error(codes.Unimplemented, "method RevokeAllSessionsForUser not implemented")
 	}
-	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	scope, err := rbac.RequireAdminScope(ctx, s.membershipRepo, s.groups)
 	if err != nil {
 		return nil, err
 	}
+	orgID, userID := scope.OrgID, scope.UserID
 	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
 		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
 	}
@@ -177,15 +274,204 @@ func (s *Server) RevokeAllSessionsForUser(ctx context.Context, req *sessionv1.Re
 	if targetUserID == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id required")
 	}
-	if err := s.sessionRepo.RevokeAllSessionsByUserAndOrg(ctx, targetUserID, targetOrgID); err != nil {
+	if !scope.Allows(targetUserID) {
+		return nil, errOutsideGroups
+	}
+	rev := domain.Revocation{Reason: domain.RevocationAdminRevoke, By: userID}
+	if err := s.sessionRepo.RevokeAllSessionsByUserAndOrg(ctx, targetUserID, targetOrgID, rev); err != nil {
 		return nil, status.Error(codes.Internal, "failed to revoke sessions")
 	}
 	if s.auditLogger != nil {
-		s.auditLogger.LogEvent(ctx, targetOrgID, userID, "revoke", "session", "all:"+targetUserID)
+		s.auditLogger.LogEvent(ctx, targetOrgID, userID, "revoke", "session", revocationMetadata("target_user_id", targetUserID, rev))
 	}
 	return &sessionv1.RevokeAllSessionsForUserResponse{}, nil
 }
 
+// RevokeAllSessionsForOrg signs everyone out of the caller's org except the caller's own session. Caller must be
+// org owner and the org's session_mgmt policy must allow admin_forced_logout.
+//
+// Without a confirmation token nothing is revoked: one message is sent with a token and the number of active
+// sessions, and the client calls again with the token within five minutes. A confirmed call revokes the sessions
+// created before it started in batches, sending progress after each one; sessions created afterwards (users signing
+// in again) are kept. The revocation continues if the client goes away.
+func (s *Server) RevokeAllSessionsForOrg(req *sessionv1.RevokeAllSessionsForOrgRequest, stream sessionv1.SessionService_RevokeAllSessionsForOrgServer) error {
+	if s.sessionRepo == nil {
+		return status.Error(codes.Unimplemented, "method RevokeAllSessionsForOrg not implemented")
+	}
+	ctx := stream.Context()
+	orgID, userID, err := rbac.RequireOrgOwner(ctx, s.membershipRepo)
+	if err != nil {
+		return err
+	}
+	callerSessionID, _ := interceptors.GetSessionID(ctx)
+	if s.orgPolicyRepo != nil {
+		cfg, err := s.orgPolicyRepo.GetByOrgID(ctx, orgID)
+		if err != nil {
+			return status.Error(codes.Internal, "failed to load org policy")
+		}
+		if !orgpolicyconfigdomain.MergeWithDefaults(cfg).SessionMgmt.AdminForcedLogout {
+			return status.Error(codes.FailedPrecondition, "admin forced logout is disabled by org policy")
+		}
+	}
+	active, err := s.sessionRepo.CountActiveByOrg(ctx, orgID, callerSessionID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to count sessions")
+	}
+	now := time.Now().UTC()
+	token := strings.TrimSpace(req.GetConfirmationToken())
+	if token == "" {
+		expiresAt := now.Add(orgLogoutConfirmationTTL)
+		return stream.Send(&sessionv1.RevokeAllSessionsForOrgProgress{
+			ConfirmationToken:     s.confirmations.Token(orgLogoutConfirmationPurpose, expiresAt, orgID, callerSessionID),
+			ConfirmationExpiresAt: timestamppb.New(expiresAt),
+			Total:                 int32(active),
+		})
+	}
+	if !s.confirmations.Valid(token, orgLogoutConfirmationPurpose, now, orgID, callerSessionID) {
+		return status.Error(codes.InvalidArgument, "invalid or expired confirmation_token")
+	}
+
+	// The logout is not tied to the stream: a client disconnect must not leave the org half signed out.
+	runCtx := context.WithoutCancel(ctx)
+	rev := domain.Revocation{Reason: domain.RevocationAdminRevoke, By: userID}
+	total, revoked := int32(active), int32(0)
+	notified := make(map[string]bool)
+	streaming := true
+	for {
+		batch, err := s.sessionRepo.RevokeBatchByOrg(runCtx, orgID, callerSessionID, now, orgLogoutBatchSize, rev)
+		if err != nil {
+			s.auditOrgLogout(runCtx, orgID, userID, revoked, false)
+			return status.Error(codes.Internal, "failed to revoke sessions")
+		}
+		revoked += int32(len(batch))
+		for _, ses := range batch {
+			if !notified[ses.UserID] {
+				notified[ses.UserID] = true
+				s.notifyOrgLogout(runCtx, orgID, userID, ses.UserID, rev)
+			}
+		}
+		if len(batch) < orgLogoutBatchSize {
+			break
+		}
+		if streaming {
+			streaming = stream.Send(&sessionv1.RevokeAllSessionsForOrgProgress{Total: max(total, revoked), Revoked: revoked}) == nil
+		}
+	}
+	s.auditOrgLogout(runCtx, orgID, userID, revoked, true)
+	if !streaming {
+		return nil
+	}
+	return stream.Send(&sessionv1.RevokeAllSessionsForOrgProgress{Total: max(total, revoked), Revoked: revoked, Done: true})
+}
+
+// SubscribeRevocations streams the revocations of the caller's org as they happen, oldest first, after replaying
+// those since req.since. Caller must be org admin or owner; services that validate the org's access tokens themselves
+// (pkg/enforcer) subscribe to reject revoked sessions before their tokens expire. A session is sent once per stream;
+// clients that reconnect resume from the revoked_at of the last revocation they received.
+func (s *Server) SubscribeRevocations(req *sessionv1.SubscribeRevocationsRequest, stream sessionv1.SessionService_SubscribeRevocationsServer) error {
+	if s.sessionRepo == nil {
+		return status.Error(codes.Unimplemented, "method SubscribeRevocations not implemented")
+	}
+	ctx := stream.Context()
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	start := now
+	if req.GetSince() != nil {
+		if err := req.GetSince().CheckValid(); err != nil {
+			return status.Error(codes.InvalidArgument, "invalid since")
+		}
+		start = req.GetSince().AsTime()
+		if oldest := now.Add(-maxRevocationReplay); start.Before(oldest) {
+			start = oldest
+		}
+	}
+	cursor := start
+	// seen holds the sessions sent within the lookback window, which every poll reads again.
+	seen := make(map[string]time.Time)
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		since := cursor.Add(-revocationLookback)
+		if since.Before(start) {
+			since = start
+		}
+		for {
+			revoked, err := s.sessionRepo.ListRevokedSince(ctx, orgID, since, revocationBatchSize)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return status.Error(codes.Internal, "failed to read revocations")
+			}
+			for _, ses := range revoked {
+				if _, ok := seen[ses.ID]; ok || ses.RevokedAt == nil {
+					continue
+				}
+				if err := stream.Send(revocationToProto(ses)); err != nil {
+					return err
+				}
+				seen[ses.ID] = *ses.RevokedAt
+				if ses.RevokedAt.After(cursor) {
+					cursor = *ses.RevokedAt
+				}
+			}
+			// A full batch may have more behind it; read on from its last revocation unless that does not advance.
+			if len(revoked) < revocationBatchSize || revoked[len(revoked)-1].RevokedAt == nil || !revoked[len(revoked)-1].RevokedAt.After(since) {
+				break
+			}
+			since = *revoked[len(revoked)-1].RevokedAt
+		}
+		for id, revokedAt := range seen {
+			if revokedAt.Before(cursor.Add(-revocationLookback)) {
+				delete(seen, id)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// auditOrgLogout records a RevokeAllSessionsForOrg run; completed is false when a batch failed.
+func (s *Server) auditOrgLogout(ctx context.Context, orgID, userID string, revoked int32, completed bool) {
+	if s.auditLogger != nil {
+		metadata := `{"sessions_revoked":` + strconv.Itoa(int(revoked)) + `,"completed":` + strconv.FormatBool(completed) + `,"reason":"` + string(domain.RevocationAdminRevoke) + `"}`
+		s.auditLogger.LogEvent(ctx, orgID, userID, "org_logout", "session", metadata)
+	}
+}
+
+// notifyOrgLogout tells targetUserID that userID signed them out of the org: an org_logout event in their security
+// feed, and a revoke audit event like RevokeAllSessionsForUser's, which audit webhooks deliver to the org's receivers.
+func (s *Server) notifyOrgLogout(ctx context.Context, orgID, userID, targetUserID string, rev domain.Revocation) {
+	if s.securityEvents != nil {
+		s.securityEvents.Record(ctx, orgID, targetUserID, securityeventdomain.EventOrgLogout, `{"revoked_by":"`+userID+`"}`)
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogEvent(ctx, orgID, userID, "revoke", "session", revocationMetadata("target_user_id", targetUserID, rev))
+	}
+}
+
+// revocationMetadata returns the audit metadata of a revocation of the session or user named by key and id.
+func revocationMetadata(key, id string, rev domain.Revocation) string {
+	metadata, _ := json.Marshal(map[string]string{key: id, "reason": string(rev.Reason)})
+	return string(metadata)
+}
+
+func revocationToProto(s *domain.Session) *sessionv1.SessionRevocation {
+	return &sessionv1.SessionRevocation{
+		SessionId: s.ID,
+		UserId:    s.UserID,
+		OrgId:     s.OrgID,
+		RevokedAt: timestamppb.New(*s.RevokedAt),
+		Reason:    string(s.RevocationReason),
+	}
+}
+
 func domainSessionToProto(s *domain.Session) *sessionv1.Session {
 	if s == nil {
 		return nil
@@ -198,14 +484,37 @@ func domainSessionToProto(s *domain.Session) *sessionv1.Session {
 		lastSeenAt = timestamppb.New(*s.LastSeenAt)
 	}
 	return &sessionv1.Session{
-		Id:         s.ID,
-		UserId:     s.UserID,
-		OrgId:      s.OrgID,
-		DeviceId:   s.DeviceID,
-		ExpiresAt:  timestamppb.New(s.ExpiresAt),
-		RevokedAt:  revokedAt,
-		LastSeenAt: lastSeenAt,
-		IpAddress:  s.IPAddress,
-		CreatedAt:  timestamppb.New(s.CreatedAt),
+		Id:               s.ID,
+		UserId:           s.UserID,
+		OrgId:            s.OrgID,
+		DeviceId:         s.DeviceID,
+		ExpiresAt:        timestamppb.New(s.ExpiresAt),
+		RevokedAt:        revokedAt,
+		LastSeenAt:       lastSeenAt,
+		IpAddress:        s.IPAddress,
+		CreatedAt:        timestamppb.New(s.CreatedAt),
+		UserAgent:        s.UserAgent,
+		ClientVersion:    s.ClientVersion,
+		AuthMethod:       s.AuthMethod,
+		MfaMethod:        s.MFAMethod,
+		RevocationReason: string(s.RevocationReason),
+		RevokedBy:        s.RevokedBy,
+		Ephemeral:        s.Ephemeral,
+	}
+}
+
+// domainDetailsToProto converts a session with its user and device, embedding the user and device as requested.
+// An ephemeral session has no device to embed.
+func domainDetailsToProto(d *domain.Details, includeUser, includeDevice bool) *sessionv1.Session {
+	if d == nil {
+		return nil
+	}
+	out := domainSessionToProto(&d.Session)
+	if includeUser {
+		out.User = &sessionv1.SessionUser{Id: d.UserID, Email: d.UserEmail, Name: d.UserName}
+	}
+	if includeDevice && d.DeviceID != "" {
+		out.Device = &sessionv1.SessionDevice{Id: d.DeviceID, Fingerprint: d.DeviceFingerprint, Trusted: d.DeviceTrusted}
 	}
+	return out
 }
@@ -2,16 +2,26 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
 	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
 	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/confirm"
+	securityeventdomain "zero-trust-control-plane/backend/internal/securityevent/domain"
 	"zero-trust-control-plane/backend/internal/server/interceptors"
 	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
 )
@@ -20,9 +30,12 @@ import (
 type mockSessionRepo struct {
 	sessions   map[string]*sessiondomain.Session
 	listByOrg  map[string][]*sessiondomain.Session
+	details    map[string]*sessiondomain.Details // user and device by session ID, for the *Details methods
 	getByIDErr error
 	listErr    error
 	revokeErr  error
+
+	revocations []sessiondomain.Revocation // one per Revoke* call
 }
 
 func (m *mockSessionRepo) GetByID(ctx context.Context, id string) (*sessiondomain.Session, error) {
@@ -64,33 +77,116 @@ func (m *mockSessionRepo) ListByOrg(ctx context.Context, orgID string, userID *s
 	return all[start:end], nil
 }
 
+func (m *mockSessionRepo) GetDetailsByID(ctx context.Context, id string) (*sessiondomain.Details, error) {
+	ses, err := m.GetByID(ctx, id)
+	if err != nil || ses == nil {
+		return nil, err
+	}
+	return m.withDetails(ses), nil
+}
+
+func (m *mockSessionRepo) ListDetailsByOrg(ctx context.Context, orgID string, userID *string, limit, offset int32) ([]*sessiondomain.Details, error) {
+	list, err := m.ListByOrg(ctx, orgID, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*sessiondomain.Details, len(list))
+	for i := range list {
+		out[i] = m.withDetails(list[i])
+	}
+	return out, nil
+}
+
+func (m *mockSessionRepo) withDetails(ses *sessiondomain.Session) *sessiondomain.Details {
+	d := sessiondomain.Details{}
+	if known := m.details[ses.ID]; known != nil {
+		d = *known
+	}
+	d.Session = *ses
+	return &d
+}
+
 func (m *mockSessionRepo) Create(ctx context.Context, s *sessiondomain.Session) error {
 	return nil
 }
 
-func (m *mockSessionRepo) Revoke(ctx context.Context, id string) error {
+func (m *mockSessionRepo) Revoke(ctx context.Context, id string, rev sessiondomain.Revocation) error {
 	if m.revokeErr != nil {
 		return m.revokeErr
 	}
+	m.revocations = append(m.revocations, rev)
 	return nil
 }
 
-func (m *mockSessionRepo) RevokeAllSessionsByUser(ctx context.Context, userID string) error {
+func (m *mockSessionRepo) RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error {
+	m.revocations = append(m.revocations, rev)
 	return nil
 }
 
-func (m *mockSessionRepo) RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string) error {
+func (m *mockSessionRepo) RevokeOtherSessionsByUser(ctx context.Context, userID, exceptSessionID string, rev sessiondomain.Revocation) (int64, error) {
+	m.revocations = append(m.revocations, rev)
+	return 0, nil
+}
+
+func (m *mockSessionRepo) RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev sessiondomain.Revocation) error {
 	if m.revokeErr != nil {
 		return m.revokeErr
 	}
+	m.revocations = append(m.revocations, rev)
 	return nil
 }
 
+func (m *mockSessionRepo) CountActiveByOrg(ctx context.Context, orgID, exceptSessionID string) (int64, error) {
+	var n int64
+	for _, ses := range m.sessions {
+		if ses.OrgID == orgID && ses.RevokedAt == nil && ses.ID != exceptSessionID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *mockSessionRepo) RevokeBatchByOrg(ctx context.Context, orgID, exceptSessionID string, createdBefore time.Time, limit int32, rev sessiondomain.Revocation) ([]*sessiondomain.Session, error) {
+	if m.revokeErr != nil {
+		return nil, m.revokeErr
+	}
+	m.revocations = append(m.revocations, rev)
+	var out []*sessiondomain.Session
+	now := time.Now()
+	for _, ses := range m.sessions {
+		if int32(len(out)) == limit {
+			break
+		}
+		if ses.OrgID == orgID && ses.RevokedAt == nil && ses.ID != exceptSessionID && !ses.CreatedAt.After(createdBefore) {
+			ses.RevokedAt = &now
+			out = append(out, ses)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockSessionRepo) ListRevokedSince(ctx context.Context, orgID string, since time.Time, limit int32) ([]*sessiondomain.Session, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	var out []*sessiondomain.Session
+	for _, ses := range m.sessions {
+		if ses.OrgID == orgID && ses.RevokedAt != nil && !ses.RevokedAt.Before(since) {
+			out = append(out, ses)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RevokedAt.Before(*out[j].RevokedAt) })
+	if int32(len(out)) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
 func (m *mockSessionRepo) UpdateLastSeen(ctx context.Context, id string, at time.Time) error {
 	return nil
 }
 
-func (m *mockSessionRepo) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string) error {
+func (m *mockSessionRepo) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string, expiresAt time.Time) error {
 	return nil
 }
 
@@ -169,15 +265,22 @@ func TestRevokeSession_Success(t *testing.T) {
 		},
 	}
 	auditLogger := &mockAuditLoggerForSession{}
-	srv := NewServer(sessionRepo, membershipRepo, auditLogger)
+	srv := NewServer(sessionRepo, membershipRepo, auditLogger, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "session-1"})
 	if err != nil {
 		t.Fatalf("RevokeSession: %v", err)
 	}
+	want := sessiondomain.Revocation{Reason: sessiondomain.RevocationAdminRevoke, By: "admin-1"}
+	if len(sessionRepo.revocations) != 1 || sessionRepo.revocations[0] != want {
+		t.Errorf("revocations = %+v, want [%+v]", sessionRepo.revocations, want)
+	}
 	if len(auditLogger.events) != 1 {
-		t.Errorf("audit events = %d, want 1", len(auditLogger.events))
+		t.Fatalf("audit events = %d, want 1", len(auditLogger.events))
+	}
+	if got := auditLogger.events[0].resourceID; got != `{"reason":"admin_revoke","session_id":"session-1"}` {
+		t.Errorf("audit metadata = %s", got)
 	}
 }
 
@@ -191,7 +294,7 @@ func TestRevokeSession_NotFound(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "nonexistent"})
@@ -226,7 +329,7 @@ func TestRevokeSession_WrongOrg(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "session-1"})
@@ -252,7 +355,7 @@ func TestRevokeSession_NonAdminCaller(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForSession("org-1", "member-1")
 
 	_, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "session-1"})
@@ -278,7 +381,7 @@ func TestRevokeSession_InvalidSessionID(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: ""})
@@ -295,7 +398,7 @@ func TestRevokeSession_InvalidSessionID(t *testing.T) {
 }
 
 func TestRevokeSession_NilRepo(t *testing.T) {
-	srv := NewServer(nil, nil, nil)
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "session-1"})
@@ -326,7 +429,7 @@ func TestListSessions_Success(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	resp, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{OrgId: "org-1"})
@@ -338,6 +441,46 @@ func TestListSessions_Success(t *testing.T) {
 	}
 }
 
+func TestListSessions_IncludeUser(t *testing.T) {
+	now := time.Now().UTC()
+	sessions := []*sessiondomain.Session{
+		{ID: "session-1", UserID: "user-1", OrgID: "org-1", DeviceID: "device-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+		{ID: "session-2", UserID: "user-2", OrgID: "org-1", DeviceID: "device-2", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+	}
+	sessionRepo := &mockSessionRepo{
+		sessions:  make(map[string]*sessiondomain.Session),
+		listByOrg: map[string][]*sessiondomain.Session{"org-1": sessions},
+		details: map[string]*sessiondomain.Details{
+			"session-1": {UserEmail: "one@example.com", DeviceFingerprint: "fp-1"},
+			"session-2": {UserEmail: "two@example.com", DeviceFingerprint: "fp-2"},
+		},
+	}
+	membershipRepo := &mockMembershipRepoForSession{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithAdminForSession("org-1", "admin-1")
+
+	resp, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{OrgId: "org-1", IncludeUser: true, Pagination: &commonv1.Pagination{PageSize: 2}})
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(resp.Sessions) != 2 {
+		t.Fatalf("sessions count = %d, want 2", len(resp.Sessions))
+	}
+	if got := resp.Sessions[1].GetUser().GetEmail(); got != "two@example.com" {
+		t.Errorf("sessions[1].user.email = %q, want two@example.com", got)
+	}
+	if resp.Sessions[0].Device != nil {
+		t.Error("device should not be embedded without include_device")
+	}
+	if resp.Pagination.NextPageToken != "2" {
+		t.Errorf("next_page_token = %q, want 2", resp.Pagination.NextPageToken)
+	}
+}
+
 func TestListSessions_FilteredByUserID(t *testing.T) {
 	now := time.Now().UTC()
 	sessions := []*sessiondomain.Session{
@@ -354,7 +497,7 @@ func TestListSessions_FilteredByUserID(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	resp, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{
@@ -391,7 +534,7 @@ func TestListSessions_Pagination(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	resp, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{
@@ -423,7 +566,7 @@ func TestListSessions_RepositoryError(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{OrgId: "org-1"})
@@ -449,7 +592,7 @@ func TestListSessions_EmptyResults(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	resp, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{OrgId: "org-1"})
@@ -475,7 +618,7 @@ func TestListSessions_OffsetBeyondResults(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	resp, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{
@@ -513,7 +656,7 @@ func TestRevokeSession_RepositoryError(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "session-1"})
@@ -539,7 +682,7 @@ func TestListSessions_NonAdminCaller(t *testing.T) {
 			"member-1:org-1": {ID: "m1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithMemberForSession("org-1", "member-1")
 
 	_, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{OrgId: "org-1"})
@@ -574,7 +717,7 @@ func TestGetSession_Success(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	resp, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-1"})
@@ -586,6 +729,53 @@ func TestGetSession_Success(t *testing.T) {
 	}
 }
 
+func TestGetSession_IncludeUserAndDevice(t *testing.T) {
+	now := time.Now().UTC()
+	session := &sessiondomain.Session{ID: "session-1", UserID: "user-1", OrgID: "org-1", DeviceID: "device-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	sessionRepo := &mockSessionRepo{
+		sessions: map[string]*sessiondomain.Session{"session-1": session},
+		details: map[string]*sessiondomain.Details{
+			"session-1": {UserEmail: "user@example.com", UserName: "User One", DeviceFingerprint: "fp-1", DeviceTrusted: true},
+		},
+	}
+	membershipRepo := &mockMembershipRepoForSession{
+		memberships: map[string]*membershipdomain.Membership{
+			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
+	ctx := ctxWithAdminForSession("org-1", "admin-1")
+
+	resp, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-1"})
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if resp.Session.User != nil || resp.Session.Device != nil {
+		t.Error("user and device should not be embedded unless requested")
+	}
+
+	resp, err = srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-1", IncludeUser: true, IncludeDevice: true})
+	if err != nil {
+		t.Fatalf("GetSession with includes: %v", err)
+	}
+	u := resp.Session.GetUser()
+	if u.GetId() != "user-1" || u.GetEmail() != "user@example.com" || u.GetName() != "User One" {
+		t.Errorf("user = %+v", u)
+	}
+	d := resp.Session.GetDevice()
+	if d.GetId() != "device-1" || d.GetFingerprint() != "fp-1" || !d.GetTrusted() {
+		t.Errorf("device = %+v", d)
+	}
+
+	if _, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "missing", IncludeUser: true}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing session: code = %v, want NotFound", status.Code(err))
+	}
+	sessionRepo.sessions["session-2"] = &sessiondomain.Session{ID: "session-2", UserID: "user-2", OrgID: "org-2", DeviceID: "device-2"}
+	if _, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-2", IncludeDevice: true}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("other org: code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
 func TestGetSession_WrongOrg(t *testing.T) {
 	now := time.Now().UTC()
 	session := &sessiondomain.Session{
@@ -605,7 +795,7 @@ func TestGetSession_WrongOrg(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-1"})
@@ -632,7 +822,7 @@ func TestRevokeAllSessionsForUser_Success(t *testing.T) {
 		},
 	}
 	auditLogger := &mockAuditLoggerForSession{}
-	srv := NewServer(sessionRepo, membershipRepo, auditLogger)
+	srv := NewServer(sessionRepo, membershipRepo, auditLogger, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeAllSessionsForUser(ctx, &sessionv1.RevokeAllSessionsForUserRequest{
@@ -642,8 +832,66 @@ func TestRevokeAllSessionsForUser_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("RevokeAllSessionsForUser: %v", err)
 	}
+	want := sessiondomain.Revocation{Reason: sessiondomain.RevocationAdminRevoke, By: "admin-1"}
+	if len(sessionRepo.revocations) != 1 || sessionRepo.revocations[0] != want {
+		t.Errorf("revocations = %+v, want [%+v]", sessionRepo.revocations, want)
+	}
 	if len(auditLogger.events) != 1 {
-		t.Errorf("audit events = %d, want 1", len(auditLogger.events))
+		t.Fatalf("audit events = %d, want 1", len(auditLogger.events))
+	}
+	if got := auditLogger.events[0].resourceID; got != `{"reason":"admin_revoke","target_user_id":"user-1"}` {
+		t.Errorf("audit metadata = %s", got)
+	}
+}
+
+// staticGroupScoper implements rbac.GroupAdminScoper for session handler tests.
+type staticGroupScoper map[string][]string // key: adminUserID:orgID
+
+func (m staticGroupScoper) ListManagedUserIDs(ctx context.Context, orgID, adminUserID string) ([]string, error) {
+	return m[adminUserID+":"+orgID], nil
+}
+
+func TestSessionRPCs_GroupAdminScope(t *testing.T) {
+	now := time.Now().UTC()
+	sessionRepo := &mockSessionRepo{
+		sessions: map[string]*sessiondomain.Session{
+			"session-1": {ID: "session-1", UserID: "user-1", OrgID: "org-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+			"session-2": {ID: "session-2", UserID: "user-2", OrgID: "org-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+		},
+		listByOrg: make(map[string][]*sessiondomain.Session),
+	}
+	membershipRepo := &mockMembershipRepoForSession{
+		memberships: map[string]*membershipdomain.Membership{
+			"lead-1:org-1": {ID: "m1", UserID: "lead-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		},
+	}
+	groups := staticGroupScoper{"lead-1:org-1": {"lead-1", "user-1"}}
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, groups, nil)
+	ctx := ctxWithMemberForSession("org-1", "lead-1")
+
+	if _, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-1"}); err != nil {
+		t.Errorf("GetSession in scope: %v", err)
+	}
+	if _, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GetSession outside scope: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "session-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RevokeSession outside scope: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.RevokeSession(ctx, &sessionv1.RevokeSessionRequest{SessionId: "session-1"}); err != nil {
+		t.Errorf("RevokeSession in scope: %v", err)
+	}
+	if _, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListSessions without user_id: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.ListSessions(ctx, &sessionv1.ListSessionsRequest{UserId: "user-1"}); err != nil {
+		t.Errorf("ListSessions in scope: %v", err)
+	}
+	if _, err := srv.RevokeAllSessionsForUser(ctx, &sessionv1.RevokeAllSessionsForUserRequest{UserId: "user-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RevokeAllSessionsForUser outside scope: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if len(sessionRepo.revocations) != 1 {
+		t.Errorf("revocations = %d, want 1", len(sessionRepo.revocations))
 	}
 }
 
@@ -657,7 +905,7 @@ func TestRevokeAllSessionsForUser_InvalidUserID(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeAllSessionsForUser(ctx, &sessionv1.RevokeAllSessionsForUserRequest{
@@ -688,7 +936,7 @@ func TestGetSession_NotFound(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "nonexistent"})
@@ -715,7 +963,7 @@ func TestGetSession_RepositoryError(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.GetSession(ctx, &sessionv1.GetSessionRequest{SessionId: "session-1"})
@@ -742,7 +990,7 @@ func TestRevokeAllSessionsForUser_RepositoryError(t *testing.T) {
 			"admin-1:org-1": {ID: "m1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
 		},
 	}
-	srv := NewServer(sessionRepo, membershipRepo, nil)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
 	ctx := ctxWithAdminForSession("org-1", "admin-1")
 
 	_, err := srv.RevokeAllSessionsForUser(ctx, &sessionv1.RevokeAllSessionsForUserRequest{
@@ -765,13 +1013,15 @@ func TestDomainSessionToProto_WithRevokedAt(t *testing.T) {
 	now := time.Now().UTC()
 	revokedAt := now.Add(1 * time.Hour)
 	session := &sessiondomain.Session{
-		ID:        "session-1",
-		UserID:    "user-1",
-		OrgID:     "org-1",
-		DeviceID:  "device-1",
-		ExpiresAt: now.Add(24 * time.Hour),
-		RevokedAt: &revokedAt,
-		CreatedAt: now,
+		ID:               "session-1",
+		UserID:           "user-1",
+		OrgID:            "org-1",
+		DeviceID:         "device-1",
+		ExpiresAt:        now.Add(24 * time.Hour),
+		RevokedAt:        &revokedAt,
+		CreatedAt:        now,
+		RevocationReason: sessiondomain.RevocationAdminRevoke,
+		RevokedBy:        "admin-1",
 	}
 
 	proto := domainSessionToProto(session)
@@ -787,6 +1037,9 @@ func TestDomainSessionToProto_WithRevokedAt(t *testing.T) {
 	if !proto.RevokedAt.AsTime().Equal(revokedAt) {
 		t.Errorf("revoked_at = %v, want %v", proto.RevokedAt.AsTime(), revokedAt)
 	}
+	if proto.RevocationReason != "admin_revoke" || proto.RevokedBy != "admin-1" {
+		t.Errorf("revocation_reason = %q, revoked_by = %q", proto.RevocationReason, proto.RevokedBy)
+	}
 }
 
 func TestDomainSessionToProto_WithoutRevokedAt(t *testing.T) {
@@ -883,3 +1136,337 @@ func TestDomainSessionToProto_WithIPAddress(t *testing.T) {
 		t.Errorf("ip_address = %q, want %q", proto.IpAddress, "192.168.1.1")
 	}
 }
+
+func TestDomainSessionToProto_WithClientMetadata(t *testing.T) {
+	now := time.Now().UTC()
+	session := &sessiondomain.Session{
+		ID:            "session-1",
+		UserID:        "user-1",
+		OrgID:         "org-1",
+		DeviceID:      "device-1",
+		ExpiresAt:     now.Add(24 * time.Hour),
+		CreatedAt:     now,
+		UserAgent:     "Mozilla/5.0",
+		ClientVersion: "web/2.1.0",
+		AuthMethod:    sessiondomain.AuthMethodPassword,
+		MFAMethod:     "sms_otp",
+	}
+
+	proto := domainSessionToProto(session)
+	if proto.UserAgent != "Mozilla/5.0" || proto.ClientVersion != "web/2.1.0" {
+		t.Errorf("user_agent, client_version = %q, %q", proto.UserAgent, proto.ClientVersion)
+	}
+	if proto.AuthMethod != "password" || proto.MfaMethod != "sms_otp" {
+		t.Errorf("auth_method, mfa_method = %q, %q", proto.AuthMethod, proto.MfaMethod)
+	}
+}
+
+// staticOrgPolicyRepo returns the same org policy config for every org.
+type staticOrgPolicyRepo struct {
+	cfg *orgpolicyconfigdomain.OrgPolicyConfig
+}
+
+func (r *staticOrgPolicyRepo) GetByOrgID(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, error) {
+	return r.cfg, nil
+}
+
+func (r *staticOrgPolicyRepo) Upsert(ctx context.Context, orgID string, cfg *orgpolicyconfigdomain.OrgPolicyConfig, change orgpolicyconfigdomain.Change) error {
+	return nil
+}
+
+func (r *staticOrgPolicyRepo) GetVersioned(ctx context.Context, orgID string) (*orgpolicyconfigdomain.OrgPolicyConfig, int64, error) {
+	return r.cfg, 1, nil
+}
+
+func (r *staticOrgPolicyRepo) UpdateIfVersion(ctx context.Context, orgID string, cfg *orgpolicyconfigdomain.OrgPolicyConfig, version int64, change orgpolicyconfigdomain.Change) (int64, bool, error) {
+	return version, false, nil
+}
+
+func (r *staticOrgPolicyRepo) ListVersions(ctx context.Context, orgID string, limit, offset int32) ([]*orgpolicyconfigdomain.ConfigVersion, error) {
+	return nil, nil
+}
+
+func (r *staticOrgPolicyRepo) GetVersion(ctx context.Context, orgID string, version int64) (*orgpolicyconfigdomain.ConfigVersion, error) {
+	return nil, nil
+}
+
+func (r *staticOrgPolicyRepo) CreateScheduledChange(ctx context.Context, c *orgpolicyconfigdomain.ScheduledChange) error {
+	return nil
+}
+
+func (r *staticOrgPolicyRepo) GetScheduledChange(ctx context.Context, id string) (*orgpolicyconfigdomain.ScheduledChange, error) {
+	return nil, nil
+}
+
+func (r *staticOrgPolicyRepo) ListScheduledChanges(ctx context.Context, orgID string, status orgpolicyconfigdomain.ScheduledStatus, limit, offset int32) ([]*orgpolicyconfigdomain.ScheduledChange, error) {
+	return nil, nil
+}
+
+func (r *staticOrgPolicyRepo) ListDueScheduledChanges(ctx context.Context, now time.Time, limit int32) ([]*orgpolicyconfigdomain.ScheduledChange, error) {
+	return nil, nil
+}
+
+func (r *staticOrgPolicyRepo) TransitionScheduledChange(ctx context.Context, id string, from, to orgpolicyconfigdomain.ScheduledStatus, res orgpolicyconfigdomain.Resolution) (bool, error) {
+	return false, nil
+}
+
+// recordingSecurityEvents records the users security events were recorded for.
+type recordingSecurityEvents struct {
+	users []string
+}
+
+func (r *recordingSecurityEvents) Record(ctx context.Context, orgID, userID string, eventType securityeventdomain.EventType, metadata string) {
+	if eventType == securityeventdomain.EventOrgLogout {
+		r.users = append(r.users, userID)
+	}
+}
+
+// fakeOrgLogoutStream collects the progress messages sent by RevokeAllSessionsForOrg.
+type fakeOrgLogoutStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*sessionv1.RevokeAllSessionsForOrgProgress
+}
+
+func (s *fakeOrgLogoutStream) Context() context.Context { return s.ctx }
+
+func (s *fakeOrgLogoutStream) Send(m *sessionv1.RevokeAllSessionsForOrgProgress) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func orgLogoutFixture(n int) (*mockSessionRepo, *mockMembershipRepoForSession) {
+	now := time.Now().UTC().Add(-time.Minute)
+	sessions := map[string]*sessiondomain.Session{
+		"owner-session": {ID: "owner-session", UserID: "owner-1", OrgID: "org-1", CreatedAt: now},
+		"other-org":     {ID: "other-org", UserID: "user-0", OrgID: "org-2", CreatedAt: now},
+	}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("s-%d", i)
+		sessions[id] = &sessiondomain.Session{ID: id, UserID: fmt.Sprintf("user-%d", i%3), OrgID: "org-1", CreatedAt: now}
+	}
+	sessionRepo := &mockSessionRepo{sessions: sessions, listByOrg: make(map[string][]*sessiondomain.Session)}
+	membershipRepo := &mockMembershipRepoForSession{
+		memberships: map[string]*membershipdomain.Membership{
+			"owner-1:org-1": {ID: "m1", UserID: "owner-1", OrgID: "org-1", Role: membershipdomain.RoleOwner},
+			"admin-1:org-1": {ID: "m2", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		},
+	}
+	return sessionRepo, membershipRepo
+}
+
+func TestRevokeAllSessionsForOrg_NilRepo(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil)
+	stream := &fakeOrgLogoutStream{ctx: interceptors.WithIdentity(context.Background(), "owner-1", "org-1", "owner-session")}
+	err := srv.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{}, stream)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("error = %v, want Unimplemented", err)
+	}
+}
+
+func TestRevokeAllSessionsForOrg_AdminDenied(t *testing.T) {
+	sessionRepo, membershipRepo := orgLogoutFixture(2)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
+	stream := &fakeOrgLogoutStream{ctx: interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "admin-session")}
+	err := srv.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{}, stream)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("error = %v, want PermissionDenied", err)
+	}
+}
+
+func TestRevokeAllSessionsForOrg_DisabledByPolicy(t *testing.T) {
+	sessionRepo, membershipRepo := orgLogoutFixture(2)
+	policy := &staticOrgPolicyRepo{cfg: &orgpolicyconfigdomain.OrgPolicyConfig{
+		SessionMgmt: &orgpolicyconfigdomain.SessionMgmt{AdminForcedLogout: false},
+	}}
+	srv := NewServer(sessionRepo, membershipRepo, nil, policy, nil, nil, nil)
+	stream := &fakeOrgLogoutStream{ctx: interceptors.WithIdentity(context.Background(), "owner-1", "org-1", "owner-session")}
+	err := srv.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{}, stream)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("error = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestRevokeAllSessionsForOrg_ConfirmAndRevokeInBatches(t *testing.T) {
+	n := 2*orgLogoutBatchSize + 1
+	sessionRepo, membershipRepo := orgLogoutFixture(n)
+	auditLogger := &mockAuditLoggerForSession{}
+	events := &recordingSecurityEvents{}
+	srv := NewServer(sessionRepo, membershipRepo, auditLogger, &staticOrgPolicyRepo{}, events, nil, confirm.NewSigner("secret"))
+	ctx := interceptors.WithIdentity(context.Background(), "owner-1", "org-1", "owner-session")
+
+	// Without a token nothing is revoked.
+	stream := &fakeOrgLogoutStream{ctx: ctx}
+	if err := srv.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{}, stream); err != nil {
+		t.Fatalf("RevokeAllSessionsForOrg without token: %v", err)
+	}
+	if len(stream.sent) != 1 || stream.sent[0].GetConfirmationToken() == "" || stream.sent[0].GetTotal() != int32(n) {
+		t.Fatalf("unconfirmed reply = %v, want one message with a token and total %d", stream.sent, n)
+	}
+	token := stream.sent[0].GetConfirmationToken()
+	if active, _ := sessionRepo.CountActiveByOrg(ctx, "org-1", "owner-session"); active != int64(n) {
+		t.Fatalf("active sessions after unconfirmed call = %d, want %d", active, n)
+	}
+
+	// A token issued to another session is rejected.
+	otherCtx := interceptors.WithIdentity(context.Background(), "owner-1", "org-1", "s-0")
+	err := srv.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{ConfirmationToken: token}, &fakeOrgLogoutStream{ctx: otherCtx})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("token from another session: error = %v, want InvalidArgument", err)
+	}
+
+	stream = &fakeOrgLogoutStream{ctx: ctx}
+	if err := srv.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{ConfirmationToken: token}, stream); err != nil {
+		t.Fatalf("RevokeAllSessionsForOrg: %v", err)
+	}
+	if len(stream.sent) != 3 {
+		t.Fatalf("progress messages = %d, want 3 (two full batches, then done)", len(stream.sent))
+	}
+	if got := stream.sent[0].GetRevoked(); got != orgLogoutBatchSize {
+		t.Errorf("first progress revoked = %d, want %d", got, orgLogoutBatchSize)
+	}
+	last := stream.sent[2]
+	if !last.GetDone() || last.GetRevoked() != int32(n) || last.GetTotal() != int32(n) {
+		t.Errorf("final progress = %v, want done with %d revoked", last, n)
+	}
+	if sessionRepo.sessions["owner-session"].RevokedAt != nil {
+		t.Error("caller's session should not be revoked")
+	}
+	for _, rev := range sessionRepo.revocations {
+		if rev.Reason != sessiondomain.RevocationAdminRevoke || rev.By != "owner-1" {
+			t.Errorf("revocation = %+v, want admin_revoke by owner-1", rev)
+		}
+	}
+	if sessionRepo.sessions["other-org"].RevokedAt != nil {
+		t.Error("session in another org should not be revoked")
+	}
+	if len(events.users) != 3 {
+		t.Errorf("security events for %v, want one per affected user (3)", events.users)
+	}
+	// One revoke per affected user for audit webhooks, then the org_logout summary.
+	if len(auditLogger.events) != 4 {
+		t.Fatalf("audit events = %+v, want three revoke and one org_logout", auditLogger.events)
+	}
+	targets := make(map[string]bool)
+	for _, e := range auditLogger.events[:3] {
+		if e.action != "revoke" || e.userID != "owner-1" || !strings.Contains(e.resourceID, `"reason":"admin_revoke"`) {
+			t.Errorf("audit event = %+v, want revoke by owner-1 with reason admin_revoke", e)
+		}
+		targets[e.resourceID] = true
+	}
+	if len(targets) != 3 {
+		t.Errorf("revoke audit events = %+v, want one per affected user", auditLogger.events[:3])
+	}
+	if last := auditLogger.events[3]; last.action != "org_logout" || !strings.Contains(last.resourceID, `"sessions_revoked":`+strconv.Itoa(n)) {
+		t.Errorf("audit event = %+v, want org_logout with sessions_revoked %d", last, n)
+	}
+}
+
+func TestRevokeAllSessionsForOrg_ConfirmationTokenIsKeyed(t *testing.T) {
+	sessionRepo, membershipRepo := orgLogoutFixture(2)
+	ctx := interceptors.WithIdentity(context.Background(), "owner-1", "org-1", "owner-session")
+	issuer := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, confirm.NewSigner("secret"))
+	stream := &fakeOrgLogoutStream{ctx: ctx}
+	if err := issuer.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{}, stream); err != nil {
+		t.Fatalf("RevokeAllSessionsForOrg without token: %v", err)
+	}
+	token := stream.sent[0].GetConfirmationToken()
+
+	// Neither a server with another secret nor an unkeyed hash of the bound values accepts or forges it.
+	other := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, confirm.NewSigner("other"))
+	err := other.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{ConfirmationToken: token}, &fakeOrgLogoutStream{ctx: ctx})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("token from a server with another secret: error = %v, want InvalidArgument", err)
+	}
+	exp, _, _ := strings.Cut(token, ".")
+	sum := sha256.Sum256([]byte("org_logout\x00org-1\x00owner-session\x00" + exp))
+	forged := exp + "." + hex.EncodeToString(sum[:])
+	err = issuer.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{ConfirmationToken: forged}, &fakeOrgLogoutStream{ctx: ctx})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unkeyed token: error = %v, want InvalidArgument", err)
+	}
+
+	// Another instance with the same secret accepts it.
+	peer := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, confirm.NewSigner("secret"))
+	stream = &fakeOrgLogoutStream{ctx: ctx}
+	if err := peer.RevokeAllSessionsForOrg(&sessionv1.RevokeAllSessionsForOrgRequest{ConfirmationToken: token}, stream); err != nil {
+		t.Fatalf("token on a server with the same secret: %v", err)
+	}
+	if last := stream.sent[len(stream.sent)-1]; !last.GetDone() || last.GetRevoked() != 2 {
+		t.Errorf("final progress = %v, want done with 2 revoked", last)
+	}
+}
+
+// fakeRevocationStream collects the revocations sent by SubscribeRevocations and calls onSend after each one.
+type fakeRevocationStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	sent   []*sessionv1.SessionRevocation
+	onSend func(n int)
+}
+
+func (s *fakeRevocationStream) Context() context.Context { return s.ctx }
+
+func (s *fakeRevocationStream) Send(m *sessionv1.SessionRevocation) error {
+	s.sent = append(s.sent, m)
+	if s.onSend != nil {
+		s.onSend(len(s.sent))
+	}
+	return nil
+}
+
+func TestSubscribeRevocations_NilRepo(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil)
+	stream := &fakeRevocationStream{ctx: interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "admin-session")}
+	if err := srv.SubscribeRevocations(&sessionv1.SubscribeRevocationsRequest{}, stream); status.Code(err) != codes.Unimplemented {
+		t.Errorf("error = %v, want Unimplemented", err)
+	}
+}
+
+func TestSubscribeRevocations_MemberDenied(t *testing.T) {
+	sessionRepo, membershipRepo := orgLogoutFixture(0)
+	membershipRepo.memberships["member-1:org-1"] = &membershipdomain.Membership{ID: "m3", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember}
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
+	stream := &fakeRevocationStream{ctx: interceptors.WithIdentity(context.Background(), "member-1", "org-1", "member-session")}
+	if err := srv.SubscribeRevocations(&sessionv1.SubscribeRevocationsRequest{}, stream); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("error = %v, want PermissionDenied", err)
+	}
+}
+
+func TestSubscribeRevocations_ReplayThenFollow(t *testing.T) {
+	sessionRepo, membershipRepo := orgLogoutFixture(3)
+	now := time.Now()
+	revokedAt := func(d time.Duration) *time.Time { at := now.Add(-d); return &at }
+	sessionRepo.sessions["s-0"].RevokedAt = revokedAt(2 * time.Hour)
+	sessionRepo.sessions["s-1"].RevokedAt = revokedAt(30 * time.Minute)
+	sessionRepo.sessions["s-1"].RevocationReason = sessiondomain.RevocationLogout
+	sessionRepo.sessions["other-org"].RevokedAt = revokedAt(10 * time.Minute)
+	srv := NewServer(sessionRepo, membershipRepo, nil, nil, nil, nil, nil)
+	srv.pollInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "admin-session"))
+	defer cancel()
+	stream := &fakeRevocationStream{ctx: ctx}
+	stream.onSend = func(n int) {
+		if n == 1 {
+			// Revoked while the stream is open; the next poll picks it up.
+			sessionRepo.sessions["s-2"].RevokedAt = revokedAt(0)
+			sessionRepo.sessions["s-2"].RevocationReason = sessiondomain.RevocationAdminRevoke
+			return
+		}
+		cancel()
+	}
+	req := &sessionv1.SubscribeRevocationsRequest{Since: timestamppb.New(now.Add(-time.Hour))}
+	if err := srv.SubscribeRevocations(req, stream); err != nil {
+		t.Fatalf("SubscribeRevocations: %v", err)
+	}
+	if len(stream.sent) != 2 {
+		t.Fatalf("sent %d revocations, want 2 (s-1 replayed, then s-2)", len(stream.sent))
+	}
+	if got := stream.sent[0]; got.GetSessionId() != "s-1" || got.GetReason() != "logout" || got.GetOrgId() != "org-1" {
+		t.Errorf("first revocation = %v, want s-1 (logout)", got)
+	}
+	if got := stream.sent[1]; got.GetSessionId() != "s-2" || got.GetReason() != "admin_revoke" {
+		t.Errorf("second revocation = %v, want s-2 (admin_revoke)", got)
+	}
+}
@@ -0,0 +1,107 @@
+package replication
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// Applier applies events from other regions to this region's sessions. Safe for concurrent use.
+//
+// A session revocation can arrive before the session itself exists here (e.g. while the session row is still being
+// replicated). Such revocations are kept as pending and retried on every heartbeat until pendingTTL has passed,
+// which should be at least the refresh token lifetime.
+type Applier struct {
+	store      Store
+	region     string
+	pendingTTL time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]pendingRevocation // session ID → revocation not yet applied
+}
+
+type pendingRevocation struct {
+	at        time.Time
+	rev       sessiondomain.Revocation
+	expiresAt time.Time
+}
+
+// NewApplier returns an Applier for region that writes to store.
+func NewApplier(store Store, region string, pendingTTL time.Duration) *Applier {
+	return &Applier{
+		store:      store,
+		region:     region,
+		pendingTTL: pendingTTL,
+		now:        time.Now,
+		pending:    make(map[string]pendingRevocation),
+	}
+}
+
+// Apply records that e was received and applies it unless it originated in this region, where it was applied when
+// it was published. Applying the same event twice, or events out of order, has the same result as applying them
+// once in order. Returns an error only when the store fails; the event should then be retried.
+func (a *Applier) Apply(ctx context.Context, e Event) error {
+	if err := a.store.RecordReplicationWatermark(ctx, e.Region, e.At, a.now().UTC()); err != nil {
+		return err
+	}
+	if e.Type == EventHeartbeat {
+		a.retryPending(ctx)
+		return nil
+	}
+	if e.Region == a.region {
+		return nil
+	}
+	switch e.Type {
+	case EventSession:
+		found, err := a.store.RevokeAt(ctx, e.SessionID, e.At, e.revocation())
+		if err != nil {
+			return err
+		}
+		if !found {
+			a.mu.Lock()
+			a.pending[e.SessionID] = pendingRevocation{at: e.At, rev: e.revocation(), expiresAt: a.now().Add(a.pendingTTL)}
+			a.mu.Unlock()
+		}
+	case EventUser:
+		return a.store.RevokeAllByUserBefore(ctx, e.UserID, e.SessionID, e.At, e.revocation())
+	case EventUserOrg:
+		return a.store.RevokeAllByUserAndOrgBefore(ctx, e.UserID, e.OrgID, e.At, e.revocation())
+	case EventOrg:
+		return a.store.RevokeAllByOrgBefore(ctx, e.OrgID, e.SessionID, e.At, e.revocation())
+	default:
+		log.Printf("replication: ignoring unknown event type %q from %s", e.Type, e.Region)
+	}
+	return nil
+}
+
+// Pending returns the number of session revocations waiting for their session to appear in this region.
+func (a *Applier) Pending() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
+
+// retryPending re-applies pending session revocations, dropping those applied or expired.
+func (a *Applier) retryPending(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	for id, p := range a.pending {
+		if now.After(p.expiresAt) {
+			delete(a.pending, id)
+			continue
+		}
+		found, err := a.store.RevokeAt(ctx, id, p.at, p.rev)
+		if err != nil {
+			log.Printf("replication: retry pending revocation of session %s: %v", id, err)
+			return
+		}
+		if found {
+			delete(a.pending, id)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+type revocation struct {
+	userID, orgID, exceptSessionID string
+	at                             time.Time
+	rev                            sessiondomain.Revocation
+}
+
+// memStore is an in-memory Store. sessions maps session ID to its revocation time (zero if active) and reasons to
+// the reason and actor recorded with it.
+type memStore struct {
+	sessions   map[string]time.Time
+	reasons    map[string]sessiondomain.Revocation
+	userRevs   []revocation
+	orgRevs    []revocation
+	watermarks map[string]time.Time // origin region → received at
+	err        error
+}
+
+func newMemStore(sessionIDs ...string) *memStore {
+	s := &memStore{sessions: map[string]time.Time{}, reasons: map[string]sessiondomain.Revocation{}, watermarks: map[string]time.Time{}}
+	for _, id := range sessionIDs {
+		s.sessions[id] = time.Time{}
+	}
+	return s
+}
+
+func (s *memStore) RevokeAt(_ context.Context, id string, at time.Time, rev sessiondomain.Revocation) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	cur, ok := s.sessions[id]
+	if !ok {
+		return false, nil
+	}
+	if cur.IsZero() || at.Before(cur) {
+		s.sessions[id] = at
+		s.reasons[id] = rev
+	}
+	return true, nil
+}
+
+func (s *memStore) RevokeAllByUserBefore(_ context.Context, userID, exceptSessionID string, at time.Time, rev sessiondomain.Revocation) error {
+	s.userRevs = append(s.userRevs, revocation{userID: userID, exceptSessionID: exceptSessionID, at: at, rev: rev})
+	return s.err
+}
+
+func (s *memStore) RevokeAllByUserAndOrgBefore(_ context.Context, userID, orgID string, at time.Time, rev sessiondomain.Revocation) error {
+	s.userRevs = append(s.userRevs, revocation{userID: userID, orgID: orgID, at: at, rev: rev})
+	return s.err
+}
+
+func (s *memStore) RevokeAllByOrgBefore(_ context.Context, orgID, exceptSessionID string, at time.Time, rev sessiondomain.Revocation) error {
+	s.orgRevs = append(s.orgRevs, revocation{orgID: orgID, exceptSessionID: exceptSessionID, at: at, rev: rev})
+	return s.err
+}
+
+func (s *memStore) RecordReplicationWatermark(_ context.Context, originRegion string, _, receivedAt time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.watermarks[originRegion] = receivedAt
+	return nil
+}
+
+func (s *memStore) LatestReplicationReceivedAt(context.Context) (time.Time, error) {
+	var latest time.Time
+	for _, t := range s.watermarks {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest, s.err
+}
+
+func TestApplier_EarliestRevocationWins(t *testing.T) {
+	store := newMemStore("s1")
+	a := NewApplier(store, "eu", time.Hour)
+	ctx := context.Background()
+	early := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	late := early.Add(time.Minute)
+
+	reasons := map[time.Time]sessiondomain.RevocationReason{early: sessiondomain.RevocationLogout, late: sessiondomain.RevocationAdminRevoke}
+
+	// Delivered out of order and duplicated.
+	for _, at := range []time.Time{late, early, late, early} {
+		if err := a.Apply(ctx, Event{Type: EventSession, SessionID: "s1", At: at, Region: "us", Reason: reasons[at], RevokedBy: "u1"}); err != nil {
+			t.Fatalf("Apply: %v", err)
+		}
+	}
+	if got := store.sessions["s1"]; !got.Equal(early) {
+		t.Errorf("revoked_at = %v, want %v", got, early)
+	}
+	if got := store.reasons["s1"]; got.Reason != sessiondomain.RevocationLogout || got.By != "u1" {
+		t.Errorf("revocation = %+v, want the earliest one's (logout by u1)", got)
+	}
+	if _, ok := store.watermarks["us"]; !ok {
+		t.Error("watermark for origin region not recorded")
+	}
+}
+
+func TestApplier_SkipsOwnRegion(t *testing.T) {
+	store := newMemStore("s1")
+	a := NewApplier(store, "eu", time.Hour)
+	if err := a.Apply(context.Background(), Event{Type: EventSession, SessionID: "s1", At: time.Now(), Region: "eu"}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !store.sessions["s1"].IsZero() {
+		t.Error("event from own region should not be applied again")
+	}
+	if _, ok := store.watermarks["eu"]; !ok {
+		t.Error("own-region event should still count as received")
+	}
+}
+
+func TestApplier_UserRevocations(t *testing.T) {
+	store := newMemStore()
+	a := NewApplier(store, "eu", time.Hour)
+	ctx := context.Background()
+	at := time.Now().UTC()
+	if err := a.Apply(ctx, Event{Type: EventUser, UserID: "u1", At: at, Region: "us", Reason: sessiondomain.RevocationReuseDetected}); err != nil {
+		t.Fatalf("Apply user: %v", err)
+	}
+	if err := a.Apply(ctx, Event{Type: EventUserOrg, UserID: "u1", OrgID: "o1", At: at, Region: "us", Reason: sessiondomain.RevocationAdminRevoke, RevokedBy: "admin-1"}); err != nil {
+		t.Fatalf("Apply user_org: %v", err)
+	}
+	if err := a.Apply(ctx, Event{Type: EventUser, UserID: "u1", SessionID: "s-current", At: at, Region: "us", Reason: sessiondomain.RevocationLogoutAll, RevokedBy: "u1"}); err != nil {
+		t.Fatalf("Apply user except session: %v", err)
+	}
+	want := []revocation{
+		{userID: "u1", at: at, rev: sessiondomain.Revocation{Reason: sessiondomain.RevocationReuseDetected}},
+		{userID: "u1", orgID: "o1", at: at, rev: sessiondomain.Revocation{Reason: sessiondomain.RevocationAdminRevoke, By: "admin-1"}},
+		{userID: "u1", exceptSessionID: "s-current", at: at, rev: sessiondomain.Revocation{Reason: sessiondomain.RevocationLogoutAll, By: "u1"}},
+	}
+	if len(store.userRevs) != len(want) || store.userRevs[0] != want[0] || store.userRevs[1] != want[1] || store.userRevs[2] != want[2] {
+		t.Errorf("user revocations = %+v, want %+v", store.userRevs, want)
+	}
+}
+
+func TestApplier_OrgRevocation(t *testing.T) {
+	store := newMemStore()
+	a := NewApplier(store, "eu", time.Hour)
+	at := time.Now().UTC()
+	if err := a.Apply(context.Background(), Event{Type: EventOrg, OrgID: "o1", SessionID: "s-owner", At: at, Region: "us"}); err != nil {
+		t.Fatalf("Apply org: %v", err)
+	}
+	want := revocation{orgID: "o1", exceptSessionID: "s-owner", at: at}
+	if len(store.orgRevs) != 1 || store.orgRevs[0] != want {
+		t.Errorf("org revocations = %+v, want [%+v]", store.orgRevs, want)
+	}
+}
+
+func TestApplier_PendingUntilSessionArrives(t *testing.T) {
+	store := newMemStore()
+	a := NewApplier(store, "eu", time.Hour)
+	ctx := context.Background()
+	at := time.Now().UTC()
+	if err := a.Apply(ctx, Event{Type: EventSession, SessionID: "s1", At: at, Region: "us", Reason: sessiondomain.RevocationLogout, RevokedBy: "u1"}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if a.Pending() != 1 {
+		t.Fatalf("Pending = %d, want 1", a.Pending())
+	}
+
+	store.sessions["s1"] = time.Time{} // the session row arrives
+	if err := a.Apply(ctx, Event{Type: EventHeartbeat, At: time.Now(), Region: "us"}); err != nil {
+		t.Fatalf("Apply heartbeat: %v", err)
+	}
+	if a.Pending() != 0 || !store.sessions["s1"].Equal(at) {
+		t.Errorf("Pending = %d, revoked_at = %v; want 0, %v", a.Pending(), store.sessions["s1"], at)
+	}
+	if got := store.reasons["s1"]; got.Reason != sessiondomain.RevocationLogout || got.By != "u1" {
+		t.Errorf("revocation = %+v, want logout by u1 kept while pending", got)
+	}
+}
+
+func TestApplier_PendingExpires(t *testing.T) {
+	store := newMemStore()
+	a := NewApplier(store, "eu", time.Minute)
+	now := time.Now()
+	a.now = func() time.Time { return now }
+	ctx := context.Background()
+	if err := a.Apply(ctx, Event{Type: EventSession, SessionID: "s1", At: now, Region: "us"}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := a.Apply(ctx, Event{Type: EventHeartbeat, At: now, Region: "us"}); err != nil {
+		t.Fatalf("Apply heartbeat: %v", err)
+	}
+	if a.Pending() != 0 {
+		t.Errorf("Pending = %d, want 0 after pendingTTL", a.Pending())
+	}
+}
+
+func TestApplier_StoreError(t *testing.T) {
+	store := newMemStore("s1")
+	store.err = errors.New("db down")
+	a := NewApplier(store, "eu", time.Hour)
+	if err := a.Apply(context.Background(), Event{Type: EventSession, SessionID: "s1", At: time.Now(), Region: "us"}); err == nil {
+		t.Error("expected store error so the event is retried")
+	}
+}
+
+func TestFreshness_Check(t *testing.T) {
+	store := newMemStore()
+	now := time.Now()
+	f := NewFreshness(store, 30*time.Second)
+	f.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := f.Check(ctx); !errors.Is(err, ErrStale) {
+		t.Errorf("nothing received: err = %v, want ErrStale", err)
+	}
+
+	store.watermarks["us"] = now.Add(-10 * time.Second)
+	now = now.Add(freshnessCacheTTL)
+	if err := f.Check(ctx); err != nil {
+		t.Errorf("received 10s ago: err = %v, want nil", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := f.Check(ctx); !errors.Is(err, ErrStale) {
+		t.Errorf("received over max lag ago: err = %v, want ErrStale", err)
+	}
+}
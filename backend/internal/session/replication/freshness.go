@@ -0,0 +1,57 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrStale is returned by Freshness.Check when this region has not received an event within the maximum lag.
+var ErrStale = errors.New("replication: session revocation stream is stale")
+
+// freshnessCacheTTL is how long Check reuses the last stored receive time, so it is not read on every request.
+const freshnessCacheTTL = time.Second
+
+// latestReceiver is the part of Store read by Freshness.
+type latestReceiver interface {
+	LatestReplicationReceivedAt(ctx context.Context) (time.Time, error)
+}
+
+// Freshness reports whether this region is keeping up with the revocation stream, for strict consistency.
+// The receive time is shared through the database, so every instance in a region agrees regardless of which one
+// consumes the stream. Safe for concurrent use.
+type Freshness struct {
+	store  latestReceiver
+	maxLag time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	latest    time.Time
+}
+
+// NewFreshness returns a Freshness that considers the stream stale when nothing was received for maxLag.
+// Heartbeats must be published more often than maxLag.
+func NewFreshness(store latestReceiver, maxLag time.Duration) *Freshness {
+	return &Freshness{store: store, maxLag: maxLag, now: time.Now}
+}
+
+// Check returns nil if an event or heartbeat was received within the maximum lag, ErrStale if not, or the store's
+// error.
+func (f *Freshness) Check(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+	if f.checkedAt.IsZero() || now.Sub(f.checkedAt) >= freshnessCacheTTL {
+		latest, err := f.store.LatestReplicationReceivedAt(ctx)
+		if err != nil {
+			return err
+		}
+		f.latest, f.checkedAt = latest, now
+	}
+	if now.Sub(f.latest) > f.maxLag {
+		return ErrStale
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// retryDelay is how long the consumer waits after a failed fetch or Apply before trying again.
+const retryDelay = 2 * time.Second
+
+// KafkaPublisher publishes events to a Kafka topic. Events are keyed by user (by region for heartbeats), so one
+// user's revocations stay ordered within a partition.
+type KafkaPublisher struct {
+	w *kafka.Writer
+}
+
+// NewKafkaPublisher returns a publisher for topic on brokers. Writes wait for all in-sync replicas.
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{w: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		// Revocations are rare and latency-sensitive; do not hold them back to fill a batch.
+		BatchTimeout: 10 * time.Millisecond,
+	}}
+}
+
+// Publish writes e to the topic and waits for it to be acknowledged.
+func (p *KafkaPublisher) Publish(ctx context.Context, e Event) error {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	key := e.UserID
+	if key == "" {
+		key = e.Region
+	}
+	return p.w.WriteMessages(ctx, kafka.Message{Key: []byte(key), Value: value})
+}
+
+// Close flushes pending writes and closes the connections.
+func (p *KafkaPublisher) Close() error {
+	return p.w.Close()
+}
+
+// KafkaConsumer reads the topic as one consumer group per region and hands each event to an Applier. Offsets are
+// committed only after an event is applied, so a crash replays rather than loses events.
+type KafkaConsumer struct {
+	r       *kafka.Reader
+	applier *Applier
+}
+
+// NewKafkaConsumer returns a consumer of topic on brokers in groupID. A new group starts from the oldest retained
+// event, so the topic's retention should cover the refresh token lifetime.
+func NewKafkaConsumer(brokers []string, topic, groupID string, applier *Applier) *KafkaConsumer {
+	return &KafkaConsumer{
+		r: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     brokers,
+			Topic:       topic,
+			GroupID:     groupID,
+			StartOffset: kafka.FirstOffset,
+			MaxWait:     time.Second,
+		}),
+		applier: applier,
+	}
+}
+
+// Run consumes events until ctx is done, then closes the reader. Malformed messages are logged and skipped; an
+// event that fails to apply is retried until it succeeds.
+func (c *KafkaConsumer) Run(ctx context.Context) {
+	defer c.r.Close()
+	for {
+		msg, err := c.r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("replication: fetch: %v", err)
+			if !sleep(ctx, retryDelay) {
+				return
+			}
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(msg.Value, &e); err != nil {
+			log.Printf("replication: skipping malformed event at offset %d: %v", msg.Offset, err)
+		} else if !c.apply(ctx, e) {
+			return
+		}
+		if err := c.r.CommitMessages(ctx, msg); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("replication: commit offset %d: %v", msg.Offset, err)
+		}
+	}
+}
+
+// apply retries Apply until it succeeds. Returns false if ctx is done first.
+func (c *KafkaConsumer) apply(ctx context.Context, e Event) bool {
+	for {
+		err := c.applier.Apply(ctx, e)
+		if err == nil {
+			return true
+		}
+		log.Printf("replication: apply %s event from %s: %v", e.Type, e.Region, err)
+		if !sleep(ctx, retryDelay) {
+			return false
+		}
+	}
+}
+
+// sleep waits for d. Returns false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// RunHeartbeat publishes a heartbeat from region every interval until ctx is done, so consumers can tell an idle
+// stream from a stalled one.
+func RunHeartbeat(ctx context.Context, publisher Publisher, region string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := publisher.Publish(ctx, Event{Type: EventHeartbeat, At: time.Now().UTC(), Region: region}); err != nil && ctx.Err() == nil {
+				log.Printf("replication: publish heartbeat: %v", err)
+			}
+		}
+	}
+}
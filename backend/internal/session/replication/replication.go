@@ -0,0 +1,87 @@
+// Package replication propagates session revocations between regions of an active-active deployment.
+//
+// Every revocation made in a region is published as an Event on a shared stream (Kafka). Each region consumes the
+// stream and applies events from other regions to its own sessions table, so the AuthUnary session check sees a
+// revocation made anywhere within seconds. Events are applied idempotently and in any order: the earliest
+// revocation time wins, and user-wide revocations only affect sessions created before them.
+package replication
+
+import (
+	"context"
+	"time"
+
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+)
+
+// Consistency selects how session revocations propagate between regions (SESSION_REVOCATION_CONSISTENCY).
+type Consistency string
+
+const (
+	// ConsistencyLocal keeps revocations in this region's database only (single-region deployments).
+	ConsistencyLocal Consistency = "local"
+	// ConsistencyEventual publishes and applies revocations; requests are served even if the stream lags.
+	ConsistencyEventual Consistency = "eventual"
+	// ConsistencyStrict is ConsistencyEventual, but authenticated requests fail with Unavailable while this region
+	// has not received an event or heartbeat within the configured maximum lag.
+	ConsistencyStrict Consistency = "strict"
+)
+
+// EventType is the kind of revocation carried by an Event.
+type EventType string
+
+const (
+	// EventSession revokes one session (Logout, RevokeSession).
+	EventSession EventType = "session"
+	// EventUser revokes all of a user's sessions (e.g. refresh token reuse, password change), except SessionID when
+	// set (LogoutAllMySessions keeping the caller's session).
+	EventUser EventType = "user"
+	// EventUserOrg revokes all of a user's sessions in one org (RevokeAllSessionsForUser).
+	EventUserOrg EventType = "user_org"
+	// EventOrg revokes all sessions in an org except SessionID, the revoking owner's (RevokeAllSessionsForOrg).
+	EventOrg EventType = "org"
+	// EventHeartbeat carries no revocation; it lets consumers tell an idle stream from a stalled one.
+	EventHeartbeat EventType = "heartbeat"
+)
+
+// Event is one message on the revocation stream, JSON-encoded.
+type Event struct {
+	Type      EventType `json:"type"`
+	SessionID string    `json:"session_id,omitempty"`
+	UserID    string    `json:"user_id,omitempty"`
+	OrgID     string    `json:"org_id,omitempty"`
+	// At is when the revocation happened in the origin region (send time for heartbeats).
+	At time.Time `json:"at"`
+	// Region is the origin region (REGION).
+	Region string `json:"region"`
+	// Reason and RevokedBy are the revocation's reason and actor. Empty in events from regions that predate them.
+	Reason    sessiondomain.RevocationReason `json:"reason,omitempty"`
+	RevokedBy string                         `json:"revoked_by,omitempty"`
+}
+
+// revocation returns the reason and actor carried by e.
+func (e Event) revocation() sessiondomain.Revocation {
+	return sessiondomain.Revocation{Reason: e.Reason, By: e.RevokedBy}
+}
+
+// Publisher sends events to the revocation stream.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// Store applies replicated revocations to this region's sessions and tracks stream progress.
+// Implemented by the session repository's PostgresRepository.
+type Store interface {
+	// RevokeAt revokes the session at the earlier of its current revocation time and at. Returns false if the
+	// session does not exist in this region. Each Revoke method records rev when at is the earlier time.
+	RevokeAt(ctx context.Context, id string, at time.Time, rev sessiondomain.Revocation) (bool, error)
+	// RevokeAllByUserBefore revokes the user's sessions created at or before at, except exceptSessionID.
+	RevokeAllByUserBefore(ctx context.Context, userID, exceptSessionID string, at time.Time, rev sessiondomain.Revocation) error
+	// RevokeAllByUserAndOrgBefore revokes the user's sessions in orgID created at or before at.
+	RevokeAllByUserAndOrgBefore(ctx context.Context, userID, orgID string, at time.Time, rev sessiondomain.Revocation) error
+	// RevokeAllByOrgBefore revokes the sessions in orgID created at or before at, except exceptSessionID.
+	RevokeAllByOrgBefore(ctx context.Context, orgID, exceptSessionID string, at time.Time, rev sessiondomain.Revocation) error
+	// RecordReplicationWatermark records that an event from originRegion sent at eventAt was received at receivedAt.
+	RecordReplicationWatermark(ctx context.Context, originRegion string, eventAt, receivedAt time.Time) error
+	// LatestReplicationReceivedAt returns when this region last received any event.
+	LatestReplicationReceivedAt(ctx context.Context) (time.Time, error)
+}
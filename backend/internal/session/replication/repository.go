@@ -0,0 +1,95 @@
+package replication
+
+import (
+	"context"
+	"log"
+	"time"
+
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	sessionrepo "zero-trust-control-plane/backend/internal/session/repository"
+)
+
+// publishTimeout bounds how long a revocation waits for the stream after it has been applied locally.
+const publishTimeout = 5 * time.Second
+
+var _ Store = (*sessionrepo.PostgresRepository)(nil)
+
+// PublishingRepository is a session repository that publishes every successful revocation to the stream.
+// All other methods are served by the wrapped repository.
+type PublishingRepository struct {
+	sessionrepo.Repository
+	publisher Publisher
+	region    string
+	now       func() time.Time
+}
+
+// NewPublishingRepository wraps repo so that revocations are published as events from region.
+func NewPublishingRepository(repo sessionrepo.Repository, publisher Publisher, region string) *PublishingRepository {
+	return &PublishingRepository{Repository: repo, publisher: publisher, region: region, now: time.Now}
+}
+
+// Revoke revokes the session locally, then publishes it.
+func (r *PublishingRepository) Revoke(ctx context.Context, id string, rev sessiondomain.Revocation) error {
+	if err := r.Repository.Revoke(ctx, id, rev); err != nil {
+		return err
+	}
+	r.publish(ctx, Event{Type: EventSession, SessionID: id}, rev)
+	return nil
+}
+
+// RevokeAllSessionsByUser revokes the user's sessions locally, then publishes it.
+func (r *PublishingRepository) RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error {
+	if err := r.Repository.RevokeAllSessionsByUser(ctx, userID, rev); err != nil {
+		return err
+	}
+	r.publish(ctx, Event{Type: EventUser, UserID: userID}, rev)
+	return nil
+}
+
+// RevokeOtherSessionsByUser revokes the user's sessions except exceptSessionID locally, then publishes it.
+func (r *PublishingRepository) RevokeOtherSessionsByUser(ctx context.Context, userID, exceptSessionID string, rev sessiondomain.Revocation) (int64, error) {
+	n, err := r.Repository.RevokeOtherSessionsByUser(ctx, userID, exceptSessionID, rev)
+	if err != nil {
+		return 0, err
+	}
+	r.publish(ctx, Event{Type: EventUser, UserID: userID, SessionID: exceptSessionID}, rev)
+	return n, nil
+}
+
+// RevokeAllSessionsByUserAndOrg revokes the user's sessions in the org locally, then publishes it.
+func (r *PublishingRepository) RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev sessiondomain.Revocation) error {
+	if err := r.Repository.RevokeAllSessionsByUserAndOrg(ctx, userID, orgID, rev); err != nil {
+		return err
+	}
+	r.publish(ctx, Event{Type: EventUserOrg, UserID: userID, OrgID: orgID}, rev)
+	return nil
+}
+
+// RevokeBatchByOrg revokes a batch of the org's sessions locally, then publishes an org-wide revocation of the
+// sessions created at or before createdBefore. Every batch of one RevokeAllSessionsForOrg call publishes the same
+// revocation, so applying it repeatedly is harmless.
+func (r *PublishingRepository) RevokeBatchByOrg(ctx context.Context, orgID, exceptSessionID string, createdBefore time.Time, limit int32, rev sessiondomain.Revocation) ([]*sessiondomain.Session, error) {
+	revoked, err := r.Repository.RevokeBatchByOrg(ctx, orgID, exceptSessionID, createdBefore, limit, rev)
+	if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, Event{Type: EventOrg, OrgID: orgID, SessionID: exceptSessionID, At: createdBefore}, rev)
+	return revoked, nil
+}
+
+// publish sends e with the reason and actor of rev without failing the caller: the revocation is already committed
+// in this region. The publish outlives a cancelled request so that a client disconnect does not keep other regions
+// from seeing it. e.At defaults to now.
+func (r *PublishingRepository) publish(ctx context.Context, e Event, rev sessiondomain.Revocation) {
+	e.Reason, e.RevokedBy = rev.Reason, rev.By
+	if e.At.IsZero() {
+		e.At = r.now()
+	}
+	e.At = e.At.UTC()
+	e.Region = r.region
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+	defer cancel()
+	if err := r.publisher.Publish(ctx, e); err != nil {
+		log.Printf("replication: publish %s revocation: %v", e.Type, err)
+	}
+}
@@ -0,0 +1,133 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	sessionrepo "zero-trust-control-plane/backend/internal/session/repository"
+)
+
+// stubSessionRepo implements the revoke methods of sessionrepo.Repository; other methods panic.
+type stubSessionRepo struct {
+	sessionrepo.Repository
+	err error
+}
+
+func (r *stubSessionRepo) Revoke(context.Context, string, sessiondomain.Revocation) error {
+	return r.err
+}
+
+func (r *stubSessionRepo) RevokeAllSessionsByUser(context.Context, string, sessiondomain.Revocation) error {
+	return r.err
+}
+
+func (r *stubSessionRepo) RevokeOtherSessionsByUser(context.Context, string, string, sessiondomain.Revocation) (int64, error) {
+	return 2, r.err
+}
+
+func (r *stubSessionRepo) RevokeAllSessionsByUserAndOrg(context.Context, string, string, sessiondomain.Revocation) error {
+	return r.err
+}
+
+func (r *stubSessionRepo) RevokeBatchByOrg(_ context.Context, orgID, _ string, _ time.Time, _ int32, _ sessiondomain.Revocation) ([]*sessiondomain.Session, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return []*sessiondomain.Session{{ID: "s2", UserID: "u2", OrgID: orgID}}, nil
+}
+
+type recordingPublisher struct {
+	events []Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e Event) error {
+	p.events = append(p.events, e)
+	return p.err
+}
+
+func TestPublishingRepository_PublishesRevocations(t *testing.T) {
+	pub := &recordingPublisher{}
+	repo := NewPublishingRepository(&stubSessionRepo{}, pub, "eu")
+	ctx := context.Background()
+
+	logout := sessiondomain.Revocation{Reason: sessiondomain.RevocationLogout, By: "u1"}
+	reuse := sessiondomain.Revocation{Reason: sessiondomain.RevocationReuseDetected}
+	adminRevoke := sessiondomain.Revocation{Reason: sessiondomain.RevocationAdminRevoke, By: "admin-1"}
+	logoutAll := sessiondomain.Revocation{Reason: sessiondomain.RevocationLogoutAll, By: "u1"}
+
+	if err := repo.Revoke(ctx, "s1", logout); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := repo.RevokeAllSessionsByUser(ctx, "u1", reuse); err != nil {
+		t.Fatalf("RevokeAllSessionsByUser: %v", err)
+	}
+	if err := repo.RevokeAllSessionsByUserAndOrg(ctx, "u1", "o1", adminRevoke); err != nil {
+		t.Fatalf("RevokeAllSessionsByUserAndOrg: %v", err)
+	}
+	if _, err := repo.RevokeOtherSessionsByUser(ctx, "u1", "s-current", logoutAll); err != nil {
+		t.Fatalf("RevokeOtherSessionsByUser: %v", err)
+	}
+
+	if len(pub.events) != 4 {
+		t.Fatalf("published %d events, want 4", len(pub.events))
+	}
+	want := []Event{
+		{Type: EventSession, SessionID: "s1", Reason: logout.Reason, RevokedBy: logout.By},
+		{Type: EventUser, UserID: "u1", Reason: reuse.Reason},
+		{Type: EventUserOrg, UserID: "u1", OrgID: "o1", Reason: adminRevoke.Reason, RevokedBy: adminRevoke.By},
+		{Type: EventUser, UserID: "u1", SessionID: "s-current", Reason: logoutAll.Reason, RevokedBy: logoutAll.By},
+	}
+	for i, e := range pub.events {
+		if e.Type != want[i].Type || e.SessionID != want[i].SessionID || e.UserID != want[i].UserID || e.OrgID != want[i].OrgID ||
+			e.Reason != want[i].Reason || e.RevokedBy != want[i].RevokedBy {
+			t.Errorf("event %d = %+v, want %+v", i, e, want[i])
+		}
+		if e.Region != "eu" || e.At.IsZero() {
+			t.Errorf("event %d region/at = %q/%v", i, e.Region, e.At)
+		}
+	}
+}
+
+func TestPublishingRepository_LocalFailureNotPublished(t *testing.T) {
+	pub := &recordingPublisher{}
+	repo := NewPublishingRepository(&stubSessionRepo{err: errors.New("db down")}, pub, "eu")
+	if err := repo.Revoke(context.Background(), "s1", sessiondomain.Revocation{Reason: sessiondomain.RevocationLogout}); err == nil {
+		t.Error("expected local error")
+	}
+	if len(pub.events) != 0 {
+		t.Errorf("published %d events after a failed revoke, want 0", len(pub.events))
+	}
+}
+
+func TestPublishingRepository_PublishFailureDoesNotFailRevoke(t *testing.T) {
+	pub := &recordingPublisher{err: errors.New("broker unavailable")}
+	repo := NewPublishingRepository(&stubSessionRepo{}, pub, "eu")
+	if err := repo.Revoke(context.Background(), "s1", sessiondomain.Revocation{Reason: sessiondomain.RevocationLogout}); err != nil {
+		t.Errorf("Revoke = %v, want nil (already revoked locally)", err)
+	}
+}
+
+func TestPublishingRepository_OrgBatchPublishesOrgRevocation(t *testing.T) {
+	pub := &recordingPublisher{}
+	repo := NewPublishingRepository(&stubSessionRepo{}, pub, "eu")
+	startedAt := time.Now().Add(-time.Minute)
+	revoked, err := repo.RevokeBatchByOrg(context.Background(), "o1", "s-owner", startedAt, 100, sessiondomain.Revocation{Reason: sessiondomain.RevocationAdminRevoke, By: "owner-1"})
+	if err != nil {
+		t.Fatalf("RevokeBatchByOrg: %v", err)
+	}
+	if len(revoked) != 1 {
+		t.Errorf("revoked %d sessions, want 1", len(revoked))
+	}
+	if len(pub.events) != 1 {
+		t.Fatalf("published %d events, want 1", len(pub.events))
+	}
+	e := pub.events[0]
+	if e.Type != EventOrg || e.OrgID != "o1" || e.SessionID != "s-owner" || !e.At.Equal(startedAt) || e.Region != "eu" ||
+		e.Reason != sessiondomain.RevocationAdminRevoke || e.RevokedBy != "owner-1" {
+		t.Errorf("event = %+v, want org revocation of o1 except s-owner at the start of the logout", e)
+	}
+}
@@ -7,16 +7,23 @@ import (
 	"time"
 
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	"zero-trust-control-plane/backend/internal/pii"
 	"zero-trust-control-plane/backend/internal/session/domain"
 )
 
+// columnUserEmail is the encrypted users.email column, as named by the user repository.
+const columnUserEmail = "users.email"
+
 type PostgresRepository struct {
 	queries *gen.Queries
+	keyring *pii.Keyring
 }
 
-// NewPostgresRepository returns a session repository that uses the given db for persistence.
-func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+// NewPostgresRepository returns a session repository that uses the given db for persistence. keyring decrypts the
+// user emails of session details; it may be nil when PII encryption is off.
+func NewPostgresRepository(db *sql.DB, keyring *pii.Keyring) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db), keyring: keyring}
 }
 
 // GetByID returns the session for id, or nil if not found.
@@ -62,20 +69,125 @@ func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, userID
 	return out, nil
 }
 
-// RevokeAllSessionsByUserAndOrg revokes all sessions for the given user in the given org.
-func (r *PostgresRepository) RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string) error {
+// GetDetailsByID returns the session for id with its user and device, or nil if not found.
+func (r *PostgresRepository) GetDetailsByID(ctx context.Context, id string) (*domain.Details, error) {
+	row, err := r.queries.GetSessionDetails(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	listRow := gen.ListSessionDetailsByOrgRow(row)
+	d := sessionDetailsRowToDomain(&listRow, time.Now())
+	if d.UserEmail, err = r.keyring.Decrypt(ctx, columnUserEmail, d.UserEmail); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ListDetailsByOrg is ListByOrg with each session's user and device, resolved in the same query.
+func (r *PostgresRepository) ListDetailsByOrg(ctx context.Context, orgID string, userID *string, limit, offset int32) ([]*domain.Details, error) {
+	arg := gen.ListSessionDetailsByOrgParams{OrgID: orgID, Limit: limit, Offset: offset}
+	if userID != nil && *userID != "" {
+		arg.UserID = sql.NullString{String: *userID, Valid: true}
+	}
+	list, err := r.queries.ListSessionDetailsByOrg(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	out := make([]*domain.Details, len(list))
+	for i := range list {
+		out[i] = sessionDetailsRowToDomain(&list[i], now)
+		if out[i].UserEmail, err = r.keyring.Decrypt(ctx, columnUserEmail, out[i].UserEmail); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// RevokeAllSessionsByUserAndOrg revokes all active sessions for the given user in the given org, recording rev.
+func (r *PostgresRepository) RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev domain.Revocation) error {
 	return r.queries.RevokeAllSessionsByUserAndOrg(ctx, gen.RevokeAllSessionsByUserAndOrgParams{
 		UserID: userID, OrgID: orgID, RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		RevocationReason: nullString(string(rev.Reason)), RevokedBy: nullString(rev.By),
 	})
 }
 
+// CountActiveByOrg returns the number of non-revoked sessions in the org, not counting exceptSessionID.
+func (r *PostgresRepository) CountActiveByOrg(ctx context.Context, orgID, exceptSessionID string) (int64, error) {
+	return r.queries.CountActiveSessionsByOrg(ctx, gen.CountActiveSessionsByOrgParams{OrgID: orgID, ID: exceptSessionID})
+}
+
+// RevokeBatchByOrg revokes up to limit non-revoked sessions in the org created at or before createdBefore, sparing
+// exceptSessionID, records rev, and returns them (only ID, UserID and OrgID are set). Fewer than limit means none
+// are left.
+func (r *PostgresRepository) RevokeBatchByOrg(ctx context.Context, orgID, exceptSessionID string, createdBefore time.Time, limit int32, rev domain.Revocation) ([]*domain.Session, error) {
+	rows, err := r.queries.RevokeSessionsByOrgBatch(ctx, gen.RevokeSessionsByOrgBatchParams{
+		OrgID:            orgID,
+		RevokedAt:        sql.NullTime{Time: time.Now(), Valid: true},
+		ID:               exceptSessionID,
+		CreatedAt:        createdBefore,
+		Limit:            limit,
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Session, len(rows))
+	for i := range rows {
+		out[i] = &domain.Session{ID: rows[i].ID, UserID: rows[i].UserID, OrgID: orgID}
+	}
+	return out, nil
+}
+
+// ListRevokedSince returns up to limit sessions of the org revoked at or after since, oldest revocation first. Only
+// ID, UserID, OrgID, RevokedAt and RevocationReason are set.
+func (r *PostgresRepository) ListRevokedSince(ctx context.Context, orgID string, since time.Time, limit int32) ([]*domain.Session, error) {
+	rows, err := r.queries.ListSessionsRevokedSince(ctx, gen.ListSessionsRevokedSinceParams{
+		OrgID:     orgID,
+		RevokedAt: sql.NullTime{Time: since, Valid: true},
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Session, len(rows))
+	for i := range rows {
+		out[i] = &domain.Session{
+			ID:               rows[i].ID,
+			UserID:           rows[i].UserID,
+			OrgID:            rows[i].OrgID,
+			RevokedAt:        nullTimeToPtr(rows[i].RevokedAt),
+			RevocationReason: domain.RevocationReason(rows[i].RevocationReason.String),
+		}
+	}
+	return out, nil
+}
+
+// ListIdleEphemeral returns up to limit active ephemeral sessions not refreshed since idleSince, longest idle first.
+// Only ID, UserID, OrgID and Ephemeral are set.
+func (r *PostgresRepository) ListIdleEphemeral(ctx context.Context, idleSince time.Time, limit int32) ([]*domain.Session, error) {
+	rows, err := r.queries.ListIdleEphemeralSessions(ctx, gen.ListIdleEphemeralSessionsParams{Limit: limit, IdleSince: idleSince})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Session, len(rows))
+	for i := range rows {
+		out[i] = &domain.Session{ID: rows[i].ID, UserID: rows[i].UserID, OrgID: rows[i].OrgID, Ephemeral: true}
+	}
+	return out, nil
+}
+
 // Create persists the session to the database. The session must have ID set.
 func (r *PostgresRepository) Create(ctx context.Context, s *domain.Session) error {
 	_, err := r.queries.CreateSession(ctx, gen.CreateSessionParams{
 		ID:               s.ID,
 		UserID:           s.UserID,
 		OrgID:            s.OrgID,
-		DeviceID:         s.DeviceID,
+		DeviceID:         nullString(s.DeviceID),
 		ExpiresAt:        s.ExpiresAt,
 		RevokedAt:        timeToNullTime(s.RevokedAt),
 		LastSeenAt:       timeToNullTime(s.LastSeenAt),
@@ -83,27 +195,115 @@ func (r *PostgresRepository) Create(ctx context.Context, s *domain.Session) erro
 		RefreshJti:       sql.NullString{String: s.RefreshJti, Valid: s.RefreshJti != ""},
 		RefreshTokenHash: sql.NullString{String: s.RefreshTokenHash, Valid: s.RefreshTokenHash != ""},
 		CreatedAt:        s.CreatedAt,
+		Country:          sql.NullString{String: s.Country, Valid: s.Country != ""},
+		PopJkt:           sql.NullString{String: s.PoPKeyThumbprint, Valid: s.PoPKeyThumbprint != ""},
+		UserAgent:        sql.NullString{String: s.UserAgent, Valid: s.UserAgent != ""},
+		ClientVersion:    sql.NullString{String: s.ClientVersion, Valid: s.ClientVersion != ""},
+		AuthMethod:       sql.NullString{String: s.AuthMethod, Valid: s.AuthMethod != ""},
+		MfaMethod:        sql.NullString{String: s.MFAMethod, Valid: s.MFAMethod != ""},
+		Ephemeral:        s.Ephemeral,
 	})
 	return err
 }
 
-// Revoke marks the session with the given id as revoked. Returns an error if the update fails.
-func (r *PostgresRepository) Revoke(ctx context.Context, id string) error {
+// Revoke marks the session with the given id as revoked, recording rev. A session that is already revoked keeps its
+// first revocation. Returns an error if the update fails.
+func (r *PostgresRepository) Revoke(ctx context.Context, id string, rev domain.Revocation) error {
 	_, err := r.queries.RevokeSession(ctx, gen.RevokeSessionParams{
-		ID:        id,
-		RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:               id,
+		RevokedAt:        sql.NullTime{Time: time.Now(), Valid: true},
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
 	})
 	return err
 }
 
-// RevokeAllSessionsByUser revokes all sessions for the given user. Returns an error if the update fails.
-func (r *PostgresRepository) RevokeAllSessionsByUser(ctx context.Context, userID string) error {
+// RevokeAllSessionsByUser revokes all active sessions for the given user, recording rev. Returns an error if the
+// update fails.
+func (r *PostgresRepository) RevokeAllSessionsByUser(ctx context.Context, userID string, rev domain.Revocation) error {
 	return r.queries.RevokeAllSessionsByUser(ctx, gen.RevokeAllSessionsByUserParams{
-		UserID:    userID,
-		RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		UserID:           userID,
+		RevokedAt:        sql.NullTime{Time: time.Now(), Valid: true},
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
 	})
 }
 
+// RevokeOtherSessionsByUser revokes the user's active sessions in every org except exceptSessionID (all of them
+// when it is empty), recording rev, and returns how many were revoked.
+func (r *PostgresRepository) RevokeOtherSessionsByUser(ctx context.Context, userID, exceptSessionID string, rev domain.Revocation) (int64, error) {
+	return r.queries.RevokeOtherSessionsByUser(ctx, gen.RevokeOtherSessionsByUserParams{
+		UserID:           userID,
+		ID:               exceptSessionID,
+		RevokedAt:        sql.NullTime{Time: time.Now(), Valid: true},
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
+	})
+}
+
+// RevokeAt applies a revocation replicated from another region: the session is revoked at the earlier of its
+// current revocation time and at; rev is recorded when at is the earlier. Returns false if the session does not
+// exist in this region.
+func (r *PostgresRepository) RevokeAt(ctx context.Context, id string, at time.Time, rev domain.Revocation) (bool, error) {
+	n, err := r.queries.RevokeSessionAt(ctx, gen.RevokeSessionAtParams{
+		ID:               id,
+		RevokedAt:        sql.NullTime{Time: at, Valid: true},
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
+	})
+	return n > 0, err
+}
+
+// RevokeAllByUserBefore applies a replicated user-wide revocation to the user's sessions created at or before at,
+// except exceptSessionID. Sessions created later (e.g. a new login in this region) are left alone.
+func (r *PostgresRepository) RevokeAllByUserBefore(ctx context.Context, userID, exceptSessionID string, at time.Time, rev domain.Revocation) error {
+	return r.queries.RevokeSessionsByUserBefore(ctx, gen.RevokeSessionsByUserBeforeParams{
+		UserID:           userID,
+		ID:               exceptSessionID,
+		RevokedAt:        sql.NullTime{Time: at, Valid: true},
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
+	})
+}
+
+// RevokeAllByUserAndOrgBefore is RevokeAllByUserBefore restricted to one org.
+func (r *PostgresRepository) RevokeAllByUserAndOrgBefore(ctx context.Context, userID, orgID string, at time.Time, rev domain.Revocation) error {
+	return r.queries.RevokeSessionsByUserAndOrgBefore(ctx, gen.RevokeSessionsByUserAndOrgBeforeParams{
+		UserID:           userID,
+		OrgID:            orgID,
+		RevokedAt:        sql.NullTime{Time: at, Valid: true},
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
+	})
+}
+
+// RevokeAllByOrgBefore applies a replicated org-wide revocation to the org's sessions created at or before at,
+// except exceptSessionID.
+func (r *PostgresRepository) RevokeAllByOrgBefore(ctx context.Context, orgID, exceptSessionID string, at time.Time, rev domain.Revocation) error {
+	return r.queries.RevokeSessionsByOrgBefore(ctx, gen.RevokeSessionsByOrgBeforeParams{
+		OrgID:            orgID,
+		ID:               exceptSessionID,
+		RevokedAt:        sql.NullTime{Time: at, Valid: true},
+		RevocationReason: nullString(string(rev.Reason)),
+		RevokedBy:        nullString(rev.By),
+	})
+}
+
+// RecordReplicationWatermark records that an event from originRegion, sent at eventAt, was received at receivedAt.
+func (r *PostgresRepository) RecordReplicationWatermark(ctx context.Context, originRegion string, eventAt, receivedAt time.Time) error {
+	return r.queries.UpsertSessionReplicationWatermark(ctx, gen.UpsertSessionReplicationWatermarkParams{
+		OriginRegion: originRegion,
+		LastEventAt:  eventAt,
+		ReceivedAt:   receivedAt,
+	})
+}
+
+// LatestReplicationReceivedAt returns when this region last received a replication event from any region,
+// or the zero Unix time if it never has.
+func (r *PostgresRepository) LatestReplicationReceivedAt(ctx context.Context) (time.Time, error) {
+	return r.queries.GetLatestSessionReplicationReceivedAt(ctx)
+}
+
 // UpdateLastSeen sets the session's last-seen timestamp for the given id. Returns an error if the update fails.
 func (r *PostgresRepository) UpdateLastSeen(ctx context.Context, id string, at time.Time) error {
 	_, err := r.queries.UpdateSessionLastSeen(ctx, gen.UpdateSessionLastSeenParams{
@@ -113,12 +313,14 @@ func (r *PostgresRepository) UpdateLastSeen(ctx context.Context, id string, at t
 	return err
 }
 
-// UpdateRefreshToken sets the session's current refresh token jti and hash for rotation. Returns an error if the update fails.
-func (r *PostgresRepository) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string) error {
+// UpdateRefreshToken sets the session's current refresh token jti and hash for rotation, and its new expiry.
+// Returns an error if the update fails.
+func (r *PostgresRepository) UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string, expiresAt time.Time) error {
 	_, err := r.queries.UpdateSessionRefreshToken(ctx, gen.UpdateSessionRefreshTokenParams{
 		ID:               sessionID,
 		RefreshJti:       sql.NullString{String: jti, Valid: jti != ""},
 		RefreshTokenHash: sql.NullString{String: refreshTokenHash, Valid: refreshTokenHash != ""},
+		ExpiresAt:        expiresAt,
 	})
 	return err
 }
@@ -130,6 +332,10 @@ func timeToNullTime(t *time.Time) sql.NullTime {
 	return sql.NullTime{Time: *t, Valid: true}
 }
 
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 func nullTimeToPtr(n sql.NullTime) *time.Time {
 	if !n.Valid {
 		return nil
@@ -149,7 +355,7 @@ func listSessionsByOrgRowToDomain(row *gen.ListSessionsByOrgRow) *domain.Session
 		ID:               row.ID,
 		UserID:           row.UserID,
 		OrgID:            row.OrgID,
-		DeviceID:         row.DeviceID,
+		DeviceID:         row.DeviceID.String,
 		ExpiresAt:        row.ExpiresAt,
 		RevokedAt:        nullTimeToPtr(row.RevokedAt),
 		LastSeenAt:       nullTimeToPtr(row.LastSeenAt),
@@ -157,6 +363,43 @@ func listSessionsByOrgRowToDomain(row *gen.ListSessionsByOrgRow) *domain.Session
 		RefreshJti:       "",
 		RefreshTokenHash: "",
 		CreatedAt:        row.CreatedAt,
+		UserAgent:        row.UserAgent.String,
+		ClientVersion:    row.ClientVersion.String,
+		AuthMethod:       row.AuthMethod.String,
+		MFAMethod:        row.MfaMethod.String,
+		Ephemeral:        row.Ephemeral,
+	}
+}
+
+func sessionDetailsRowToDomain(row *gen.ListSessionDetailsByOrgRow, now time.Time) *domain.Details {
+	device := devicedomain.Device{
+		Trusted:      row.DeviceTrusted.Bool,
+		TrustedUntil: nullTimeToPtr(row.DeviceTrustedUntil),
+		RevokedAt:    nullTimeToPtr(row.DeviceRevokedAt),
+	}
+	return &domain.Details{
+		Session: domain.Session{
+			ID:               row.ID,
+			UserID:           row.UserID,
+			OrgID:            row.OrgID,
+			DeviceID:         row.DeviceID.String,
+			ExpiresAt:        row.ExpiresAt,
+			RevokedAt:        nullTimeToPtr(row.RevokedAt),
+			LastSeenAt:       nullTimeToPtr(row.LastSeenAt),
+			IPAddress:        row.IpAddress.String,
+			CreatedAt:        row.CreatedAt,
+			UserAgent:        row.UserAgent.String,
+			ClientVersion:    row.ClientVersion.String,
+			AuthMethod:       row.AuthMethod.String,
+			MFAMethod:        row.MfaMethod.String,
+			RevocationReason: domain.RevocationReason(row.RevocationReason.String),
+			RevokedBy:        row.RevokedBy.String,
+			Ephemeral:        row.Ephemeral,
+		},
+		UserEmail:         row.UserEmail,
+		UserName:          row.UserName.String,
+		DeviceFingerprint: row.DeviceFingerprint.String,
+		DeviceTrusted:     device.IsEffectivelyTrusted(now),
 	}
 }
 
@@ -180,7 +423,7 @@ func genSessionToDomain(s *gen.Session) *domain.Session {
 		ID:               s.ID,
 		UserID:           s.UserID,
 		OrgID:            s.OrgID,
-		DeviceID:         s.DeviceID,
+		DeviceID:         s.DeviceID.String,
 		ExpiresAt:        s.ExpiresAt,
 		RevokedAt:        nullTimeToPtr(s.RevokedAt),
 		LastSeenAt:       nullTimeToPtr(s.LastSeenAt),
@@ -188,5 +431,14 @@ func genSessionToDomain(s *gen.Session) *domain.Session {
 		RefreshJti:       refreshJti,
 		RefreshTokenHash: refreshTokenHash,
 		CreatedAt:        s.CreatedAt,
+		Country:          s.Country.String,
+		PoPKeyThumbprint: s.PopJkt.String,
+		UserAgent:        s.UserAgent.String,
+		ClientVersion:    s.ClientVersion.String,
+		AuthMethod:       s.AuthMethod.String,
+		MFAMethod:        s.MfaMethod.String,
+		RevocationReason: domain.RevocationReason(s.RevocationReason.String),
+		RevokedBy:        s.RevokedBy.String,
+		Ephemeral:        s.Ephemeral,
 	}
 }
@@ -12,10 +12,16 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*domain.Session, error)
 	ListByUserAndOrg(ctx context.Context, userID, orgID string) ([]*domain.Session, error)
 	ListByOrg(ctx context.Context, orgID string, userID *string, limit, offset int32) ([]*domain.Session, error)
+	GetDetailsByID(ctx context.Context, id string) (*domain.Details, error)
+	ListDetailsByOrg(ctx context.Context, orgID string, userID *string, limit, offset int32) ([]*domain.Details, error)
 	Create(ctx context.Context, s *domain.Session) error
-	Revoke(ctx context.Context, id string) error
-	RevokeAllSessionsByUser(ctx context.Context, userID string) error
-	RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string) error
+	Revoke(ctx context.Context, id string, rev domain.Revocation) error
+	RevokeAllSessionsByUser(ctx context.Context, userID string, rev domain.Revocation) error
+	RevokeOtherSessionsByUser(ctx context.Context, userID, exceptSessionID string, rev domain.Revocation) (int64, error)
+	RevokeAllSessionsByUserAndOrg(ctx context.Context, userID, orgID string, rev domain.Revocation) error
+	CountActiveByOrg(ctx context.Context, orgID, exceptSessionID string) (int64, error)
+	RevokeBatchByOrg(ctx context.Context, orgID, exceptSessionID string, createdBefore time.Time, limit int32, rev domain.Revocation) ([]*domain.Session, error)
+	ListRevokedSince(ctx context.Context, orgID string, since time.Time, limit int32) ([]*domain.Session, error)
 	UpdateLastSeen(ctx context.Context, id string, at time.Time) error
-	UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string) error
+	UpdateRefreshToken(ctx context.Context, sessionID, jti, refreshTokenHash string, expiresAt time.Time) error
 }
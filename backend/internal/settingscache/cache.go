@@ -0,0 +1,213 @@
+// Package settingscache keeps org MFA settings and platform device-trust settings in memory for a short TTL. Both
+// are read on every login and refresh but rarely change. Writes made through this instance invalidate the cache at
+// once and are broadcast to the other instances (see PGBroadcaster and Listen); changes made elsewhere, e.g.
+// directly in the database, apply within the TTL.
+package settingscache
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	orgmfasettingsrepo "zero-trust-control-plane/backend/internal/orgmfasettings/repository"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+	platformsettingsrepo "zero-trust-control-plane/backend/internal/platformsettings/repository"
+)
+
+// DefaultTTL is how long cached settings are served before they are reloaded.
+const DefaultTTL = 30 * time.Second
+
+// Invalidation names settings that changed: one org's MFA settings, or the platform settings.
+type Invalidation struct {
+	OrgID    string `json:"org_id,omitempty"`
+	Platform bool   `json:"platform,omitempty"`
+	// All drops everything, e.g. after missing invalidations while disconnected.
+	All bool `json:"all,omitempty"`
+}
+
+// Broadcaster tells the other server instances about an invalidation.
+type Broadcaster interface {
+	Broadcast(ctx context.Context, inv Invalidation) error
+}
+
+type entry[T any] struct {
+	value    T
+	loadedAt time.Time
+}
+
+// Cache caches reads of the org MFA settings and platform settings repositories. Use OrgMFASettings and
+// PlatformSettings as drop-in replacements for those repositories. Failed reads are not cached. Safe for concurrent
+// use.
+type Cache struct {
+	orgs        orgmfasettingsrepo.Repository
+	platform    platformsettingsrepo.Repository
+	broadcaster Broadcaster
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu     sync.Mutex
+	orgMFA map[string]entry[*orgmfasettingsdomain.OrgMFASettings]
+	trust  map[int]entry[*platformsettingsdomain.PlatformDeviceTrustSettings] // keyed by default trust TTL days
+	// generation is bumped by every invalidation; a read that started before one is not cached.
+	generation uint64
+}
+
+// New returns a cache over orgs and platform. ttl <= 0 uses DefaultTTL. broadcaster may be nil (single instance).
+func New(orgs orgmfasettingsrepo.Repository, platform platformsettingsrepo.Repository, broadcaster Broadcaster, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{
+		orgs:        orgs,
+		platform:    platform,
+		broadcaster: broadcaster,
+		ttl:         ttl,
+		now:         time.Now,
+		orgMFA:      make(map[string]entry[*orgmfasettingsdomain.OrgMFASettings]),
+		trust:       make(map[int]entry[*platformsettingsdomain.PlatformDeviceTrustSettings]),
+	}
+}
+
+// OrgMFASettings returns the org MFA settings repository backed by the cache.
+func (c *Cache) OrgMFASettings() orgmfasettingsrepo.Repository { return orgMFASettings{c} }
+
+// PlatformSettings returns the platform settings repository backed by the cache.
+func (c *Cache) PlatformSettings() platformsettingsrepo.Repository { return platformSettings{c} }
+
+// Invalidate drops the cached settings named by inv on this instance only. Called for invalidations received from
+// other instances.
+func (c *Cache) Invalidate(inv Invalidation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	switch {
+	case inv.All:
+		clear(c.orgMFA)
+		clear(c.trust)
+	case inv.Platform:
+		clear(c.trust)
+	default:
+		delete(c.orgMFA, inv.OrgID)
+	}
+}
+
+// invalidate drops inv locally and broadcasts it. A failed broadcast is logged; other instances then catch up
+// within the TTL.
+func (c *Cache) invalidate(ctx context.Context, inv Invalidation) {
+	c.Invalidate(inv)
+	if c.broadcaster == nil {
+		return
+	}
+	if err := c.broadcaster.Broadcast(ctx, inv); err != nil {
+		log.Printf("settingscache: failed to broadcast invalidation %+v: %v", inv, err)
+	}
+}
+
+// cached returns the entry for key if it is fresh, and the current generation.
+func cached[K comparable, T any](c *Cache, m map[K]entry[T], key K) (T, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := m[key]
+	if ok && c.now().Sub(e.loadedAt) < c.ttl {
+		return e.value, true, c.generation
+	}
+	var zero T
+	return zero, false, c.generation
+}
+
+// store caches value for key unless an invalidation happened since generation gen was read.
+func store[K comparable, T any](c *Cache, m map[K]entry[T], key K, value T, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == gen {
+		m[key] = entry[T]{value: value, loadedAt: c.now()}
+	}
+}
+
+type orgMFASettings struct{ c *Cache }
+
+// GetByOrgID returns a copy of the org's settings (nil if none) from the cache, loading them on a miss.
+func (r orgMFASettings) GetByOrgID(ctx context.Context, orgID string) (*orgmfasettingsdomain.OrgMFASettings, error) {
+	v, ok, gen := cached(r.c, r.c.orgMFA, orgID)
+	if ok {
+		return copyOrg(v), nil
+	}
+	v, err := r.c.orgs.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	store(r.c, r.c.orgMFA, orgID, copyOrg(v), gen)
+	return v, nil
+}
+
+// Upsert writes through to the repository, then invalidates the org's settings here and on the other instances.
+func (r orgMFASettings) Upsert(ctx context.Context, settings *orgmfasettingsdomain.OrgMFASettings) error {
+	if err := r.c.orgs.Upsert(ctx, settings); err != nil {
+		return err
+	}
+	r.c.invalidate(ctx, Invalidation{OrgID: settings.OrgID})
+	return nil
+}
+
+type platformSettings struct{ c *Cache }
+
+// GetDeviceTrustSettings returns a copy of the platform device-trust settings from the cache, loading them on a
+// miss.
+func (r platformSettings) GetDeviceTrustSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.PlatformDeviceTrustSettings, error) {
+	v, ok, gen := cached(r.c, r.c.trust, defaultTrustTTLDays)
+	if ok {
+		return copyTrust(v), nil
+	}
+	v, err := r.c.platform.GetDeviceTrustSettings(ctx, defaultTrustTTLDays)
+	if err != nil {
+		return nil, err
+	}
+	store(r.c, r.c.trust, defaultTrustTTLDays, copyTrust(v), gen)
+	return v, nil
+}
+
+// GetSettings is not cached; it is read by Register and PlatformSettingsService, not on every login.
+func (r platformSettings) GetSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.Settings, error) {
+	return r.c.platform.GetSettings(ctx, defaultTrustTTLDays)
+}
+
+// SetSettings writes through, then invalidates the platform settings here and on the other instances.
+func (r platformSettings) SetSettings(ctx context.Context, u platformsettingsdomain.SettingsUpdate) error {
+	if err := r.c.platform.SetSettings(ctx, u); err != nil {
+		return err
+	}
+	r.c.invalidate(ctx, Invalidation{Platform: true})
+	return nil
+}
+
+// GetMaintenanceState is not cached here; maintenance.Switch keeps its own short-lived copy.
+func (r platformSettings) GetMaintenanceState(ctx context.Context) (*platformsettingsdomain.MaintenanceState, error) {
+	return r.c.platform.GetMaintenanceState(ctx)
+}
+
+// SetMaintenanceState writes through, then invalidates the platform settings here and on the other instances.
+func (r platformSettings) SetMaintenanceState(ctx context.Context, state *platformsettingsdomain.MaintenanceState) error {
+	if err := r.c.platform.SetMaintenanceState(ctx, state); err != nil {
+		return err
+	}
+	r.c.invalidate(ctx, Invalidation{Platform: true})
+	return nil
+}
+
+func copyOrg(v *orgmfasettingsdomain.OrgMFASettings) *orgmfasettingsdomain.OrgMFASettings {
+	if v == nil {
+		return nil
+	}
+	out := *v
+	return &out
+}
+
+func copyTrust(v *platformsettingsdomain.PlatformDeviceTrustSettings) *platformsettingsdomain.PlatformDeviceTrustSettings {
+	if v == nil {
+		return nil
+	}
+	out := *v
+	return &out
+}
@@ -0,0 +1,253 @@
+package settingscache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	orgmfasettingsdomain "zero-trust-control-plane/backend/internal/orgmfasettings/domain"
+	platformsettingsdomain "zero-trust-control-plane/backend/internal/platformsettings/domain"
+)
+
+type fakeOrgRepo struct {
+	settings map[string]*orgmfasettingsdomain.OrgMFASettings
+	gets     int
+	getErr   error
+	// onGet runs inside GetByOrgID, after the read, to simulate a concurrent write.
+	onGet func()
+}
+
+func (f *fakeOrgRepo) GetByOrgID(ctx context.Context, orgID string) (*orgmfasettingsdomain.OrgMFASettings, error) {
+	f.gets++
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	s := f.settings[orgID]
+	if f.onGet != nil {
+		f.onGet()
+	}
+	return copyOrg(s), nil
+}
+
+func (f *fakeOrgRepo) Upsert(ctx context.Context, settings *orgmfasettingsdomain.OrgMFASettings) error {
+	f.settings[settings.OrgID] = copyOrg(settings)
+	return nil
+}
+
+type fakePlatformRepo struct {
+	settings platformsettingsdomain.PlatformDeviceTrustSettings
+	gets     int
+}
+
+func (f *fakePlatformRepo) GetDeviceTrustSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.PlatformDeviceTrustSettings, error) {
+	f.gets++
+	out := f.settings
+	if out.DefaultTrustTTLDays == 0 {
+		out.DefaultTrustTTLDays = defaultTrustTTLDays
+	}
+	return &out, nil
+}
+
+func (f *fakePlatformRepo) GetSettings(ctx context.Context, defaultTrustTTLDays int) (*platformsettingsdomain.Settings, error) {
+	out := platformsettingsdomain.DefaultSettings(defaultTrustTTLDays)
+	out.MFARequiredAlways = f.settings.MFARequiredAlways
+	return &out, nil
+}
+
+func (f *fakePlatformRepo) SetSettings(ctx context.Context, u platformsettingsdomain.SettingsUpdate) error {
+	if u.MFARequiredAlways != nil {
+		f.settings.MFARequiredAlways = *u.MFARequiredAlways
+	}
+	if u.DefaultTrustTTLDays != nil {
+		f.settings.DefaultTrustTTLDays = *u.DefaultTrustTTLDays
+	}
+	return nil
+}
+
+func (f *fakePlatformRepo) GetMaintenanceState(ctx context.Context) (*platformsettingsdomain.MaintenanceState, error) {
+	return &platformsettingsdomain.MaintenanceState{}, nil
+}
+
+func (f *fakePlatformRepo) SetMaintenanceState(ctx context.Context, state *platformsettingsdomain.MaintenanceState) error {
+	return nil
+}
+
+type recordingBroadcaster struct {
+	sent []Invalidation
+	err  error
+}
+
+func (b *recordingBroadcaster) Broadcast(ctx context.Context, inv Invalidation) error {
+	b.sent = append(b.sent, inv)
+	return b.err
+}
+
+func newTestCache(b Broadcaster) (*Cache, *fakeOrgRepo, *fakePlatformRepo, *time.Time) {
+	orgs := &fakeOrgRepo{settings: map[string]*orgmfasettingsdomain.OrgMFASettings{
+		"org-1": {OrgID: "org-1", MFARequiredForNewDevice: true},
+	}}
+	platform := &fakePlatformRepo{}
+	c := New(orgs, platform, b, time.Minute)
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	return c, orgs, platform, &now
+}
+
+func TestCache_OrgSettingsCachedUntilTTL(t *testing.T) {
+	c, orgs, _, now := newTestCache(nil)
+	repo := c.OrgMFASettings()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		s, err := repo.GetByOrgID(ctx, "org-1")
+		if err != nil || s == nil || !s.MFARequiredForNewDevice {
+			t.Fatalf("GetByOrgID = %+v, %v", s, err)
+		}
+	}
+	if s, _ := repo.GetByOrgID(ctx, "org-missing"); s != nil {
+		t.Fatalf("missing org = %+v, want nil", s)
+	}
+	_, _ = repo.GetByOrgID(ctx, "org-missing")
+	if orgs.gets != 2 {
+		t.Fatalf("repo reads = %d, want 2 (one per org, nil results cached)", orgs.gets)
+	}
+
+	*now = now.Add(time.Minute)
+	_, _ = repo.GetByOrgID(ctx, "org-1")
+	if orgs.gets != 3 {
+		t.Errorf("repo reads after TTL = %d, want 3", orgs.gets)
+	}
+}
+
+func TestCache_ReturnsCopies(t *testing.T) {
+	c, _, _, _ := newTestCache(nil)
+	repo := c.OrgMFASettings()
+	ctx := context.Background()
+
+	s, _ := repo.GetByOrgID(ctx, "org-1")
+	s.MFARequiredForNewDevice = false
+	s, _ = repo.GetByOrgID(ctx, "org-1")
+	if !s.MFARequiredForNewDevice {
+		t.Error("mutating a returned value changed the cached settings")
+	}
+}
+
+func TestCache_UpsertInvalidatesAndBroadcasts(t *testing.T) {
+	b := &recordingBroadcaster{err: errors.New("notify failed")}
+	c, orgs, _, _ := newTestCache(b)
+	repo := c.OrgMFASettings()
+	ctx := context.Background()
+
+	_, _ = repo.GetByOrgID(ctx, "org-1")
+	if err := repo.Upsert(ctx, &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	s, _ := repo.GetByOrgID(ctx, "org-1")
+	if s.MFARequiredForNewDevice {
+		t.Error("read after Upsert returned stale settings")
+	}
+	if orgs.gets != 2 {
+		t.Errorf("repo reads = %d, want 2", orgs.gets)
+	}
+	if len(b.sent) != 1 || b.sent[0] != (Invalidation{OrgID: "org-1"}) {
+		t.Errorf("broadcast = %+v, want one org-1 invalidation", b.sent)
+	}
+}
+
+func TestCache_SetSettingsInvalidatesAndBroadcasts(t *testing.T) {
+	b := &recordingBroadcaster{}
+	c, _, platform, _ := newTestCache(b)
+	repo := c.PlatformSettings()
+	ctx := context.Background()
+
+	if s, _ := repo.GetDeviceTrustSettings(ctx, 30); s.MFARequiredAlways {
+		t.Fatal("MFARequiredAlways should start false")
+	}
+	always := true
+	if err := repo.SetSettings(ctx, platformsettingsdomain.SettingsUpdate{MFARequiredAlways: &always}); err != nil {
+		t.Fatalf("SetSettings: %v", err)
+	}
+	if s, _ := repo.GetDeviceTrustSettings(ctx, 30); !s.MFARequiredAlways || platform.gets != 2 {
+		t.Errorf("after SetSettings: %+v with %d reads, want MFARequiredAlways with 2 reads", s, platform.gets)
+	}
+	if len(b.sent) != 1 || !b.sent[0].Platform {
+		t.Errorf("broadcast = %+v, want one platform invalidation", b.sent)
+	}
+}
+
+func TestCache_InvalidateFromOtherInstance(t *testing.T) {
+	c, orgs, platform, _ := newTestCache(nil)
+	ctx := context.Background()
+
+	_, _ = c.OrgMFASettings().GetByOrgID(ctx, "org-1")
+	_, _ = c.PlatformSettings().GetDeviceTrustSettings(ctx, 30)
+
+	c.Invalidate(Invalidation{Platform: true})
+	_, _ = c.OrgMFASettings().GetByOrgID(ctx, "org-1")
+	_, _ = c.PlatformSettings().GetDeviceTrustSettings(ctx, 30)
+	if orgs.gets != 1 || platform.gets != 2 {
+		t.Fatalf("reads after platform invalidation: org %d platform %d, want 1 and 2", orgs.gets, platform.gets)
+	}
+
+	c.Invalidate(Invalidation{OrgID: "org-1"})
+	_, _ = c.OrgMFASettings().GetByOrgID(ctx, "org-1")
+	if orgs.gets != 2 {
+		t.Fatalf("org reads after org invalidation = %d, want 2", orgs.gets)
+	}
+
+	c.Invalidate(Invalidation{All: true})
+	_, _ = c.OrgMFASettings().GetByOrgID(ctx, "org-1")
+	_, _ = c.PlatformSettings().GetDeviceTrustSettings(ctx, 30)
+	if orgs.gets != 3 || platform.gets != 3 {
+		t.Errorf("reads after full invalidation: org %d platform %d, want 3 and 3", orgs.gets, platform.gets)
+	}
+}
+
+func TestCache_PlatformSettingsKeyedByDefaultTTL(t *testing.T) {
+	c, _, platform, _ := newTestCache(nil)
+	ctx := context.Background()
+
+	a, _ := c.PlatformSettings().GetDeviceTrustSettings(ctx, 30)
+	b, _ := c.PlatformSettings().GetDeviceTrustSettings(ctx, 7)
+	if a.DefaultTrustTTLDays != 30 || b.DefaultTrustTTLDays != 7 || platform.gets != 2 {
+		t.Errorf("got %d and %d days with %d reads, want 30 and 7 with 2", a.DefaultTrustTTLDays, b.DefaultTrustTTLDays, platform.gets)
+	}
+}
+
+func TestCache_ErrorsNotCached(t *testing.T) {
+	c, orgs, _, _ := newTestCache(nil)
+	repo := c.OrgMFASettings()
+	ctx := context.Background()
+
+	orgs.getErr = errors.New("db down")
+	if _, err := repo.GetByOrgID(ctx, "org-1"); err == nil {
+		t.Fatal("expected error")
+	}
+	orgs.getErr = nil
+	if s, err := repo.GetByOrgID(ctx, "org-1"); err != nil || s == nil {
+		t.Fatalf("GetByOrgID after error = %+v, %v", s, err)
+	}
+	if orgs.gets != 2 {
+		t.Errorf("repo reads = %d, want 2", orgs.gets)
+	}
+}
+
+func TestCache_ReadRacingInvalidationNotStored(t *testing.T) {
+	c, orgs, _, _ := newTestCache(nil)
+	repo := c.OrgMFASettings()
+	ctx := context.Background()
+
+	// The read returns the old settings but the write and its invalidation land before it is stored.
+	orgs.onGet = func() {
+		orgs.onGet = nil
+		orgs.settings["org-1"] = &orgmfasettingsdomain.OrgMFASettings{OrgID: "org-1"}
+		c.Invalidate(Invalidation{OrgID: "org-1"})
+	}
+	if s, _ := repo.GetByOrgID(ctx, "org-1"); !s.MFARequiredForNewDevice {
+		t.Fatal("first read should return the settings it loaded")
+	}
+	if s, _ := repo.GetByOrgID(ctx, "org-1"); s.MFARequiredForNewDevice {
+		t.Error("stale read was cached across an invalidation")
+	}
+}
@@ -0,0 +1,80 @@
+package settingscache
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+)
+
+// Channel is the Postgres NOTIFY channel invalidations are broadcast on.
+const Channel = "ztcp_settings_invalidation"
+
+// listenRetryDelay is how long Listen waits before reconnecting after the connection fails.
+const listenRetryDelay = 5 * time.Second
+
+// PGBroadcaster broadcasts invalidations with Postgres NOTIFY. Every instance connected to the same database
+// receives them through Listen, including the sender, which then invalidates a second time.
+type PGBroadcaster struct {
+	queries *gen.Queries
+}
+
+// NewPGBroadcaster returns a broadcaster that sends notifications over db.
+func NewPGBroadcaster(db *sql.DB) *PGBroadcaster {
+	return &PGBroadcaster{queries: gen.New(db)}
+}
+
+// Broadcast sends inv as JSON on Channel.
+func (b *PGBroadcaster) Broadcast(ctx context.Context, inv Invalidation) error {
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	return b.queries.NotifySettingsChanged(ctx, gen.NotifySettingsChangedParams{Channel: Channel, Payload: string(payload)})
+}
+
+// Listen holds a dedicated connection to dsn that LISTENs on Channel and applies received invalidations to c, until
+// ctx is done. Notifications sent while disconnected are lost, so the whole cache is dropped on every (re)connect.
+func Listen(ctx context.Context, dsn string, c *Cache) {
+	for {
+		err := listen(ctx, dsn, c)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("settingscache: invalidation listener failed, retrying in %s: %v", listenRetryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func listen(ctx context.Context, dsn string, c *Cache) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize()); err != nil {
+		return err
+	}
+	c.Invalidate(Invalidation{All: true})
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var inv Invalidation
+		if err := json.Unmarshal([]byte(n.Payload), &inv); err != nil {
+			log.Printf("settingscache: ignoring malformed invalidation %q: %v", n.Payload, err)
+			continue
+		}
+		c.Invalidate(inv)
+	}
+}
@@ -0,0 +1,91 @@
+package signupguard
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//go:embed disposable_domains.txt
+var builtinDisposableDomains string
+
+// StaticList is a DomainList held in memory. A domain is disposable when it or one of its parent domains is listed.
+type StaticList struct {
+	domains map[string]struct{}
+}
+
+// NewStaticList returns a list of the given domains.
+func NewStaticList(domains ...string) *StaticList {
+	l := &StaticList{domains: make(map[string]struct{}, len(domains))}
+	for _, d := range domains {
+		if d = normalizeDomain(d); d != "" {
+			l.domains[d] = struct{}{}
+		}
+	}
+	return l
+}
+
+// BuiltinList returns the list of well-known disposable email providers shipped with the server.
+func BuiltinList() *StaticList {
+	l, _ := ReadList(strings.NewReader(builtinDisposableDomains))
+	return l
+}
+
+// ReadList reads one domain per line; blank lines and lines starting with # are skipped.
+func ReadList(r io.Reader) (*StaticList, error) {
+	var domains []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return NewStaticList(domains...), nil
+}
+
+// LoadListFile reads a list file in the ReadList format and adds the built-in list.
+func LoadListFile(path string) (*StaticList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("signupguard: open disposable domain list: %w", err)
+	}
+	defer f.Close()
+	l, err := ReadList(f)
+	if err != nil {
+		return nil, fmt.Errorf("signupguard: read disposable domain list: %w", err)
+	}
+	for d := range BuiltinList().domains {
+		l.domains[d] = struct{}{}
+	}
+	return l, nil
+}
+
+// Len returns the number of listed domains.
+func (l *StaticList) Len() int {
+	return len(l.domains)
+}
+
+// Disposable reports whether domain or one of its parent domains is listed.
+func (l *StaticList) Disposable(_ context.Context, domain string) (bool, error) {
+	domain = normalizeDomain(domain)
+	for domain != "" {
+		if _, ok := l.domains[domain]; ok {
+			return true, nil
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			break
+		}
+		domain = parent
+	}
+	return false, nil
+}
@@ -0,0 +1,133 @@
+// Package signupguard screens self-service registrations for abuse before an account is created: addresses at
+// disposable email providers, domains outside the platform allowlist, and bursts of sign-ups from one client IP.
+package signupguard
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDisposableEmail is returned for an address at a disposable email provider.
+	ErrDisposableEmail = errors.New("signupguard: disposable email address")
+	// ErrDomainNotAllowed is returned for an address outside the allowlist.
+	ErrDomainNotAllowed = errors.New("signupguard: email domain not allowed")
+	// ErrTooManySignups is returned when the client IP exceeded its sign-up limit.
+	ErrTooManySignups = errors.New("signupguard: too many sign-ups from this address")
+)
+
+// Reasons name a rejection in audit metadata.
+const (
+	ReasonDisposableEmail  = "disposable_email"
+	ReasonDomainNotAllowed = "domain_not_allowed"
+	ReasonVelocity         = "velocity"
+)
+
+// DomainList reports whether an email domain belongs to a disposable email provider (e.g. *StaticList).
+type DomainList interface {
+	Disposable(ctx context.Context, domain string) (bool, error)
+}
+
+// Limiter reports whether another sign-up from key is allowed (e.g. *ratelimit.Limiter).
+type Limiter interface {
+	Allow(key string) bool
+}
+
+// Guard applies the sign-up checks. Safe for concurrent use; the allowlist may be changed at runtime.
+type Guard struct {
+	disposable DomainList
+	perIP      Limiter
+
+	mu      sync.RWMutex
+	allowed []string
+}
+
+// NewGuard returns a Guard. disposable may be nil to accept disposable addresses, and perIP nil to not limit
+// sign-ups per client IP. allowedDomains, when non-empty, restricts sign-up to those domains and their subdomains.
+func NewGuard(disposable DomainList, perIP Limiter, allowedDomains []string) *Guard {
+	g := &Guard{disposable: disposable, perIP: perIP}
+	g.SetAllowedDomains(allowedDomains)
+	return g
+}
+
+// SetAllowedDomains replaces the allowlist (e.g. on config reload). Empty allows every domain.
+func (g *Guard) SetAllowedDomains(domains []string) {
+	var allowed []string
+	for _, d := range domains {
+		if d = normalizeDomain(d); d != "" {
+			allowed = append(allowed, d)
+		}
+	}
+	g.mu.Lock()
+	g.allowed = allowed
+	g.mu.Unlock()
+}
+
+// Check screens a sign-up of email from clientIP and returns ErrTooManySignups, ErrDomainNotAllowed or
+// ErrDisposableEmail. Every attempt counts against the IP's limit, including rejected ones. A failing DomainList
+// is logged and the address accepted.
+func (g *Guard) Check(ctx context.Context, email, clientIP string) error {
+	if g.perIP != nil && clientIP != "" && !g.perIP.Allow(clientIP) {
+		return ErrTooManySignups
+	}
+	_, domain, ok := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !ok {
+		return nil
+	}
+	domain = normalizeDomain(domain)
+	if !g.domainAllowed(domain) {
+		return ErrDomainNotAllowed
+	}
+	if g.disposable == nil {
+		return nil
+	}
+	disposable, err := g.disposable.Disposable(ctx, domain)
+	if err != nil {
+		log.Printf("signupguard: disposable domain lookup failed: %v", err)
+		return nil
+	}
+	if disposable {
+		return ErrDisposableEmail
+	}
+	return nil
+}
+
+// Reason returns the audit reason for an error from Check, or "" for other errors.
+func Reason(err error) string {
+	switch {
+	case errors.Is(err, ErrDisposableEmail):
+		return ReasonDisposableEmail
+	case errors.Is(err, ErrDomainNotAllowed):
+		return ReasonDomainNotAllowed
+	case errors.Is(err, ErrTooManySignups):
+		return ReasonVelocity
+	default:
+		return ""
+	}
+}
+
+func (g *Guard) domainAllowed(domain string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if len(g.allowed) == 0 {
+		return true
+	}
+	for _, a := range g.allowed {
+		if matchDomain(domain, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDomain reports whether domain is parent or one of its subdomains.
+func matchDomain(domain, parent string) bool {
+	return domain == parent || strings.HasSuffix(domain, "."+parent)
+}
+
+func normalizeDomain(d string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+}
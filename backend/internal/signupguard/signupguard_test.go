@@ -0,0 +1,95 @@
+package signupguard
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/ratelimit"
+)
+
+type failingList struct{}
+
+func (failingList) Disposable(context.Context, string) (bool, error) {
+	return false, errors.New("list unavailable")
+}
+
+func TestGuard_Check(t *testing.T) {
+	ctx := context.Background()
+	g := NewGuard(NewStaticList("mailinator.com"), nil, nil)
+	tests := []struct {
+		email string
+		want  error
+	}{
+		{"user@example.com", nil},
+		{"user@Mailinator.com", ErrDisposableEmail},
+		{"user@eu.mailinator.com", ErrDisposableEmail},
+		{"user@notmailinator.com", nil},
+	}
+	for _, tt := range tests {
+		if err := g.Check(ctx, tt.email, "203.0.113.7"); !errors.Is(err, tt.want) {
+			t.Errorf("Check(%q) = %v, want %v", tt.email, err, tt.want)
+		}
+	}
+
+	g.SetAllowedDomains([]string{"example.com", " Corp.Example.org. "})
+	for email, want := range map[string]error{
+		"user@example.com":          nil,
+		"user@eu.corp.example.org":  nil,
+		"user@other.com":            ErrDomainNotAllowed,
+		"user@example.com.evil.net": ErrDomainNotAllowed,
+	} {
+		if err := g.Check(ctx, email, ""); !errors.Is(err, want) {
+			t.Errorf("with allowlist, Check(%q) = %v, want %v", email, err, want)
+		}
+	}
+
+	if err := NewGuard(failingList{}, nil, nil).Check(ctx, "user@example.com", ""); err != nil {
+		t.Errorf("a failing list should accept the address, got %v", err)
+	}
+}
+
+func TestGuard_Velocity(t *testing.T) {
+	ctx := context.Background()
+	g := NewGuard(nil, ratelimit.NewLimiter(2, time.Hour), nil)
+	for i := 0; i < 2; i++ {
+		if err := g.Check(ctx, "user@example.com", "203.0.113.7"); err != nil {
+			t.Fatalf("sign-up %d: %v", i+1, err)
+		}
+	}
+	if err := g.Check(ctx, "other@example.com", "203.0.113.7"); !errors.Is(err, ErrTooManySignups) {
+		t.Errorf("third sign-up = %v, want ErrTooManySignups", err)
+	}
+	if err := g.Check(ctx, "user@example.com", "198.51.100.1"); err != nil {
+		t.Errorf("another IP: %v", err)
+	}
+	if got := Reason(ErrTooManySignups); got != ReasonVelocity {
+		t.Errorf("Reason = %q, want %q", got, ReasonVelocity)
+	}
+}
+
+func TestLoadListFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.txt")
+	if err := os.WriteFile(path, []byte("# custom\n\nthrowaway.example\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	l, err := LoadListFile(path)
+	if err != nil {
+		t.Fatalf("LoadListFile: %v", err)
+	}
+	for _, d := range []string{"throwaway.example", "mailinator.com"} {
+		if ok, _ := l.Disposable(context.Background(), d); !ok {
+			t.Errorf("%s should be disposable", d)
+		}
+	}
+	if l.Len() != BuiltinList().Len()+1 {
+		t.Errorf("Len = %d, want the built-in list plus one", l.Len())
+	}
+	if _, err := LoadListFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil || !strings.Contains(err.Error(), "open") {
+		t.Errorf("missing file: err = %v", err)
+	}
+}
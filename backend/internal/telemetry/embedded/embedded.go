@@ -0,0 +1,246 @@
+// Package embedded stores agent telemetry in the control plane's own database, for deployments that run cmd/server
+// without Kafka, NATS, RabbitMQ or Loki (TELEMETRY_TRANSPORT=embedded). Ingested events go to an in-memory ring buffer
+// that a background goroutine writes to the telemetry_events table in batches; TelemetryService.QueryTelemetry reads
+// them back and a RetentionJob deletes old ones.
+package embedded
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// Defaults for Options fields left zero.
+const (
+	DefaultBufferSize    = 10000
+	DefaultBatchSize     = telemetry.MaxBatchSize
+	DefaultFlushInterval = time.Second
+	// batchWriteTimeout bounds one batch insert, so a hung database cannot stall the recorder forever.
+	batchWriteTimeout = 10 * time.Second
+	// dropLogInterval is the minimum time between two "events dropped" log lines.
+	dropLogInterval = time.Minute
+)
+
+// ErrRecorderClosed is returned by Publish after Close.
+var ErrRecorderClosed = errors.New("telemetry: embedded recorder closed")
+
+// Filter narrows a query. Empty fields match every event.
+type Filter struct {
+	Type     string
+	UserID   string
+	DeviceID string
+	Since    *time.Time // received at or after
+	Until    *time.Time // received before
+}
+
+// Store persists telemetry events. Implemented by PostgresStore.
+type Store interface {
+	// Insert stores events, skipping those whose (org, event ID) is already stored.
+	Insert(ctx context.Context, events []telemetry.Event) error
+	// Query returns the org's events matching filter, newest first.
+	Query(ctx context.Context, orgID string, filter Filter, limit, offset int32) ([]telemetry.Event, error)
+	// DeleteBefore deletes events received before t and returns how many it deleted.
+	DeleteBefore(ctx context.Context, t time.Time) (int64, error)
+}
+
+// Options configures a Recorder.
+type Options struct {
+	// BufferSize is how many events may wait to be written (default DefaultBufferSize).
+	BufferSize int
+	// BatchSize is the most events written in one insert (default DefaultBatchSize).
+	BatchSize int
+	// FlushInterval is how long a partial batch waits before it is written (default DefaultFlushInterval).
+	FlushInterval time.Duration
+}
+
+// Stats are the counters of a Recorder since it started.
+type Stats struct {
+	Buffered int   // events currently waiting to be written
+	Written  int64 // events passed to the store
+	Dropped  int64 // events overwritten in a full buffer before they were written
+	Failed   int64 // events lost because their batch insert failed
+}
+
+// Recorder is a telemetry.Publisher that keeps events in a ring buffer and writes them to a Store in the background.
+// Publish never waits for the database: when the buffer is full, the oldest waiting events are overwritten and
+// counted as dropped, so ingestion keeps up at the cost of the oldest telemetry. Events still in the buffer are not
+// visible to queries yet. Call Close on shutdown to write the buffer.
+type Recorder struct {
+	store Store
+	opts  Options
+
+	mu      sync.Mutex
+	buf     []telemetry.Event
+	head    int // index of the oldest waiting event
+	n       int // number of waiting events
+	closed  bool
+	wake    chan struct{}
+	closing chan struct{}
+	done    chan struct{}
+
+	written  atomic.Int64
+	dropped  atomic.Int64
+	failed   atomic.Int64
+	lastDrop atomic.Int64 // unix nanos of the last drop log line
+}
+
+// NewRecorder returns a Recorder writing to store and starts its background goroutine.
+func NewRecorder(store Store, opts Options) *Recorder {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DefaultBufferSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultFlushInterval
+	}
+	r := &Recorder{
+		store:   store,
+		opts:    opts,
+		buf:     make([]telemetry.Event, opts.BufferSize),
+		wake:    make(chan struct{}, 1),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Publish adds events to the buffer, overwriting the oldest waiting events when it is full. Returns
+// ErrRecorderClosed after Close.
+func (r *Recorder) Publish(ctx context.Context, events []telemetry.Event) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return ErrRecorderClosed
+	}
+	dropped := 0
+	for _, e := range events {
+		if r.n == len(r.buf) {
+			r.buf[r.head] = telemetry.Event{}
+			r.head = (r.head + 1) % len(r.buf)
+			r.n--
+			dropped++
+		}
+		r.buf[(r.head+r.n)%len(r.buf)] = e
+		r.n++
+	}
+	full := r.n >= r.opts.BatchSize
+	r.mu.Unlock()
+	if full {
+		select {
+		case r.wake <- struct{}{}:
+		default:
+		}
+	}
+	if dropped > 0 {
+		r.drop(dropped)
+	}
+	return nil
+}
+
+// Stats returns the recorder's counters.
+func (r *Recorder) Stats() Stats {
+	r.mu.Lock()
+	buffered := r.n
+	r.mu.Unlock()
+	return Stats{
+		Buffered: buffered,
+		Written:  r.written.Load(),
+		Dropped:  r.dropped.Load(),
+		Failed:   r.failed.Load(),
+	}
+}
+
+// Close stops accepting events and waits until the buffered ones are written or ctx is done. Events still buffered
+// when ctx is done are lost; Close then returns ctx.Err().
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.closing)
+	}
+	r.mu.Unlock()
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.wake:
+			r.flush(false)
+		case <-ticker.C:
+			r.flush(true)
+		case <-r.closing:
+			// Publish cannot add events once closed is set, so this writes everything.
+			r.flush(true)
+			return
+		}
+	}
+}
+
+// flush writes the waiting events a batch at a time: every full batch, and with partial also the rest.
+func (r *Recorder) flush(partial bool) {
+	for {
+		batch := r.take(partial)
+		if len(batch) == 0 {
+			return
+		}
+		r.write(batch)
+	}
+}
+
+// take removes up to BatchSize of the oldest waiting events from the buffer. Without partial it takes a full batch
+// or nothing.
+func (r *Recorder) take(partial bool) []telemetry.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !partial && r.n < r.opts.BatchSize {
+		return nil
+	}
+	batch := make([]telemetry.Event, min(r.n, r.opts.BatchSize))
+	for i := range batch {
+		batch[i] = r.buf[r.head]
+		r.buf[r.head] = telemetry.Event{}
+		r.head = (r.head + 1) % len(r.buf)
+	}
+	r.n -= len(batch)
+	return batch
+}
+
+// write stores batch with a fresh context: the requests that produced the events may long be finished.
+func (r *Recorder) write(batch []telemetry.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), batchWriteTimeout)
+	defer cancel()
+	if err := r.store.Insert(ctx, batch); err != nil {
+		r.failed.Add(int64(len(batch)))
+		log.Printf("telemetry: failed to store batch of %d events: %v", len(batch), err)
+		return
+	}
+	r.written.Add(int64(len(batch)))
+}
+
+// drop counts overwritten events and logs at most once per dropLogInterval.
+func (r *Recorder) drop(n int) {
+	total := r.dropped.Add(int64(n))
+	now := time.Now().UnixNano()
+	last := r.lastDrop.Load()
+	if now-last < int64(dropLogInterval) || !r.lastDrop.CompareAndSwap(last, now) {
+		return
+	}
+	log.Printf("telemetry: embedded buffer full, dropped %d oldest events; %d dropped in total", n, total)
+}
@@ -0,0 +1,160 @@
+package embedded
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// memStore is a concurrency-safe Store. While gate is non-nil, inserts wait for it to be closed.
+type memStore struct {
+	mu        sync.Mutex
+	batches   [][]telemetry.Event
+	insertErr error
+	gate      chan struct{}
+	deleted   []time.Time
+}
+
+func (m *memStore) Insert(ctx context.Context, events []telemetry.Event) error {
+	if m.gate != nil {
+		<-m.gate
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.insertErr != nil {
+		return m.insertErr
+	}
+	m.batches = append(m.batches, append([]telemetry.Event(nil), events...))
+	return nil
+}
+
+func (m *memStore) Query(ctx context.Context, orgID string, filter Filter, limit, offset int32) ([]telemetry.Event, error) {
+	return nil, nil
+}
+
+func (m *memStore) DeleteBefore(ctx context.Context, t time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleted = append(m.deleted, t)
+	return 3, nil
+}
+
+// ids returns the event IDs of every stored batch, in order.
+func (m *memStore) ids() (batches int, ids []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, b := range m.batches {
+		for _, e := range b {
+			ids = append(ids, e.EventID)
+		}
+	}
+	return len(m.batches), ids
+}
+
+func events(ids ...string) []telemetry.Event {
+	out := make([]telemetry.Event, len(ids))
+	for i, id := range ids {
+		out[i] = telemetry.Event{EventID: id, OrgID: "org-1", Type: "page_view"}
+	}
+	return out
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 2s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRecorder_WritesFullBatches(t *testing.T) {
+	store := &memStore{}
+	r := NewRecorder(store, Options{BufferSize: 10, BatchSize: 3, FlushInterval: time.Hour})
+	if err := r.Publish(context.Background(), events("a", "b", "c", "d")); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	waitFor(t, func() bool { return r.Stats().Written == 3 })
+	if batches, ids := store.ids(); batches != 1 || len(ids) != 3 || ids[0] != "a" {
+		t.Fatalf("stored %d batches %v, want one of a,b,c", batches, ids)
+	}
+	// The partial batch waits for the flush interval, or for Close.
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, ids := store.ids(); len(ids) != 4 || ids[3] != "d" {
+		t.Fatalf("stored %v after Close, want a..d", ids)
+	}
+	if err := r.Publish(context.Background(), events("e")); !errors.Is(err, ErrRecorderClosed) {
+		t.Fatalf("Publish after Close = %v, want ErrRecorderClosed", err)
+	}
+}
+
+func TestRecorder_FlushesPartialBatchOnInterval(t *testing.T) {
+	store := &memStore{}
+	r := NewRecorder(store, Options{BufferSize: 10, BatchSize: 100, FlushInterval: 10 * time.Millisecond})
+	defer r.Close(context.Background())
+	_ = r.Publish(context.Background(), events("a"))
+	waitFor(t, func() bool { return r.Stats().Written == 1 })
+}
+
+func TestRecorder_FullBufferDropsOldest(t *testing.T) {
+	store := &memStore{gate: make(chan struct{})}
+	r := NewRecorder(store, Options{BufferSize: 3, BatchSize: 1, FlushInterval: time.Hour})
+	// The first event is taken and blocks on the gate; the buffer then holds b, c, d and e overwrites b.
+	_ = r.Publish(context.Background(), events("a"))
+	waitFor(t, func() bool { return r.Stats().Buffered == 0 })
+	_ = r.Publish(context.Background(), events("b", "c", "d", "e"))
+	if st := r.Stats(); st.Buffered != 3 || st.Dropped != 1 {
+		t.Fatalf("stats = %+v, want 3 buffered, 1 dropped", st)
+	}
+	close(store.gate)
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, ids := store.ids(); len(ids) != 4 || ids[0] != "a" || ids[1] != "c" || ids[3] != "e" {
+		t.Fatalf("stored %v, want a,c,d,e", ids)
+	}
+}
+
+func TestRecorder_CountsFailedBatches(t *testing.T) {
+	store := &memStore{insertErr: errors.New("db down")}
+	r := NewRecorder(store, Options{BufferSize: 10, BatchSize: 2, FlushInterval: time.Hour})
+	_ = r.Publish(context.Background(), events("a", "b"))
+	_ = r.Close(context.Background())
+	if st := r.Stats(); st.Failed != 2 || st.Written != 0 {
+		t.Fatalf("stats = %+v, want 2 failed", st)
+	}
+}
+
+func TestRecorder_CloseGivesUpWhenContextDone(t *testing.T) {
+	store := &memStore{gate: make(chan struct{})}
+	defer close(store.gate)
+	r := NewRecorder(store, Options{BufferSize: 10, BatchSize: 1, FlushInterval: time.Hour})
+	_ = r.Publish(context.Background(), events("a", "b"))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := r.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestRetentionJob_DeletesExpiredEvents(t *testing.T) {
+	store := &memStore{}
+	job := NewRetentionJob(store, 7*24*time.Hour)
+	now := time.Date(2026, 10, 8, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+	n, err := job.RunOnce(context.Background())
+	if err != nil || n != 3 {
+		t.Fatalf("RunOnce = %d, %v", n, err)
+	}
+	if len(store.deleted) != 1 || !store.deleted[0].Equal(now.Add(-7*24*time.Hour)) {
+		t.Fatalf("deleted before %v, want %v", store.deleted, now.Add(-7*24*time.Hour))
+	}
+}
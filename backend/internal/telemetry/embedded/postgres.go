@@ -0,0 +1,167 @@
+package embedded
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// PostgresStore implements Store on the telemetry_events table using the sqlc-generated queries.
+type PostgresStore struct {
+	queries *gen.Queries
+	router  *residency.Router
+}
+
+// NewPostgresStore returns a store that uses the given db for persistence. When router is not nil, the events of an
+// org with a data region are stored in that region's database.
+func NewPostgresStore(db *sql.DB, router *residency.Router) *PostgresStore {
+	return &PostgresStore{queries: gen.New(db), router: router}
+}
+
+// queriesFor returns the queries for the database holding orgID's events.
+func (s *PostgresStore) queriesFor(ctx context.Context, orgID string) (*gen.Queries, error) {
+	if s.router == nil {
+		return s.queries, nil
+	}
+	db, err := s.router.DB(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return gen.New(db), nil
+}
+
+// Insert stores events with one multi-row insert per database, skipping those whose (org, event ID) is already
+// stored.
+func (s *PostgresStore) Insert(ctx context.Context, events []telemetry.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if s.router == nil {
+		return insertEvents(ctx, s.queries, events)
+	}
+	groups := make(map[gen.DBTX][]telemetry.Event)
+	var order []gen.DBTX
+	for _, e := range events {
+		db, err := s.router.DB(ctx, e.OrgID)
+		if err != nil {
+			return err
+		}
+		if _, ok := groups[db]; !ok {
+			order = append(order, db)
+		}
+		groups[db] = append(groups[db], e)
+	}
+	for _, db := range order {
+		if err := insertEvents(ctx, gen.New(db), groups[db]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertEvents(ctx context.Context, q *gen.Queries, events []telemetry.Event) error {
+	arg := gen.CreateTelemetryEventsParams{
+		OrgIds:          make([]string, len(events)),
+		EventIds:        make([]string, len(events)),
+		SchemaVersions:  make([]int32, len(events)),
+		Types:           make([]string, len(events)),
+		OccurredAts:     make([]time.Time, len(events)),
+		ReceivedAts:     make([]time.Time, len(events)),
+		UserIds:         make([]string, len(events)),
+		SessionIds:      make([]string, len(events)),
+		DeviceIds:       make([]string, len(events)),
+		Regions:         make([]string, len(events)),
+		AttributesJsons: make([]string, len(events)),
+		RequestIds:      make([]string, len(events)),
+	}
+	for i, e := range events {
+		attributes := "{}"
+		if len(e.Attributes) > 0 {
+			raw, err := json.Marshal(e.Attributes)
+			if err != nil {
+				return err
+			}
+			attributes = string(raw)
+		}
+		arg.OrgIds[i] = e.OrgID
+		arg.EventIds[i] = e.EventID
+		arg.SchemaVersions[i] = int32(e.SchemaVersion)
+		arg.Types[i] = e.Type
+		arg.OccurredAts[i] = e.OccurredAt
+		arg.ReceivedAts[i] = e.ReceivedAt
+		arg.UserIds[i] = e.UserID
+		arg.SessionIds[i] = e.SessionID
+		arg.DeviceIds[i] = e.DeviceID
+		arg.Regions[i] = e.Region
+		arg.AttributesJsons[i] = attributes
+		arg.RequestIds[i] = e.RequestID
+	}
+	_, err := q.CreateTelemetryEvents(ctx, arg)
+	return err
+}
+
+// Query returns the org's events matching filter, newest first, paginated by limit and offset.
+func (s *PostgresStore) Query(ctx context.Context, orgID string, filter Filter, limit, offset int32) ([]telemetry.Event, error) {
+	params := gen.ListTelemetryEventsByOrgParams{
+		OrgID:          orgID,
+		Limit:          limit,
+		Offset:         offset,
+		FilterType:     sql.NullString{String: filter.Type, Valid: filter.Type != ""},
+		FilterUserID:   sql.NullString{String: filter.UserID, Valid: filter.UserID != ""},
+		FilterDeviceID: sql.NullString{String: filter.DeviceID, Valid: filter.DeviceID != ""},
+	}
+	if filter.Since != nil {
+		params.Since = sql.NullTime{Time: *filter.Since, Valid: true}
+	}
+	if filter.Until != nil {
+		params.Until = sql.NullTime{Time: *filter.Until, Valid: true}
+	}
+	q, err := s.queriesFor(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListTelemetryEventsByOrg(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]telemetry.Event, len(rows))
+	for i, row := range rows {
+		out[i] = telemetry.Event{
+			SchemaVersion: int(row.SchemaVersion),
+			EventID:       row.EventID,
+			Type:          row.Type,
+			OccurredAt:    row.OccurredAt,
+			ReceivedAt:    row.ReceivedAt,
+			OrgID:         row.OrgID,
+			UserID:        row.UserID,
+			SessionID:     row.SessionID,
+			DeviceID:      row.DeviceID,
+			Region:        row.Region,
+			RequestID:     row.RequestID,
+		}
+		// Stored by Insert; unreadable attributes are reported as empty rather than failing the query.
+		_ = json.Unmarshal([]byte(row.AttributesJson), &out[i].Attributes)
+	}
+	return out, nil
+}
+
+// DeleteBefore deletes events received before t in every database and returns how many it deleted.
+func (s *PostgresStore) DeleteBefore(ctx context.Context, t time.Time) (int64, error) {
+	if s.router == nil {
+		return s.queries.DeleteTelemetryEventsBefore(ctx, t)
+	}
+	var deleted int64
+	for _, db := range s.router.Databases() {
+		n, err := gen.New(db).DeleteTelemetryEventsBefore(ctx, t)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
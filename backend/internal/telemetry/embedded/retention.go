@@ -0,0 +1,50 @@
+package embedded
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RetentionInterval is how often expired events are deleted.
+const RetentionInterval = time.Hour
+
+// EventDeleter deletes old events. Implemented by PostgresStore.
+type EventDeleter interface {
+	DeleteBefore(ctx context.Context, t time.Time) (int64, error)
+}
+
+// RetentionJob deletes stored telemetry received more than retention ago.
+type RetentionJob struct {
+	store     EventDeleter
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewRetentionJob returns a RetentionJob. retention must be positive.
+func NewRetentionJob(store EventDeleter, retention time.Duration) *RetentionJob {
+	return &RetentionJob{store: store, retention: retention, now: time.Now}
+}
+
+// RunOnce deletes the expired events and returns how many it deleted.
+func (j *RetentionJob) RunOnce(ctx context.Context) (int64, error) {
+	return j.store.DeleteBefore(ctx, j.now().UTC().Add(-j.retention))
+}
+
+// Run calls RunOnce on start and then every interval until ctx is cancelled.
+func (j *RetentionJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := j.RunOnce(ctx); err != nil {
+			log.Printf("telemetry: retention run failed: %v", err)
+		} else if n > 0 {
+			log.Printf("telemetry: deleted %d events older than %s", n, j.retention)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	telemetryv1 "zero-trust-control-plane/backend/api/generated/telemetry/v1"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/internal/telemetry/embedded"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// EventQuerier reads stored telemetry. Implemented by embedded.PostgresStore.
+type EventQuerier interface {
+	Query(ctx context.Context, orgID string, filter embedded.Filter, limit, offset int32) ([]telemetry.Event, error)
+}
+
+// SessionGetter resolves the caller's session to the device the events came from.
+type SessionGetter interface {
+	GetByID(ctx context.Context, id string) (*sessiondomain.Session, error)
+}
+
+// Server implements TelemetryService (proto server).
+// Proto: telemetry/telemetry.proto → internal/telemetry/handler.
+type Server struct {
+	telemetryv1.UnimplementedTelemetryServiceServer
+	publisher      telemetry.Publisher
+	events         EventQuerier
+	membershipRepo rbac.OrgMembershipGetter
+	sessions       SessionGetter
+	region         string
+	schemas        *telemetry.Registry
+	now            func() time.Time
+}
+
+// NewServer returns a new Telemetry gRPC server. If publisher is nil (telemetry transport not configured), the ingest
+// RPCs return Unimplemented; if events is nil (any transport but embedded), so does QueryTelemetry. sessions resolves
+// the device of the caller's session; when nil, events are published without a device ID. region is added to every
+// event; it may be empty.
+func NewServer(publisher telemetry.Publisher, events EventQuerier, membershipRepo rbac.OrgMembershipGetter, sessions SessionGetter, region string) *Server {
+	return &Server{
+		publisher:      publisher,
+		events:         events,
+		membershipRepo: membershipRepo,
+		sessions:       sessions,
+		region:         region,
+		schemas:        telemetry.Schemas,
+		now:            time.Now,
+	}
+}
+
+// IngestTelemetry validates a batch, adds the caller's org, user, session and device, and publishes it.
+func (s *Server) IngestTelemetry(ctx context.Context, req *telemetryv1.IngestTelemetryRequest) (*telemetryv1.IngestTelemetryResponse, error) {
+	if s.publisher == nil {
+		return nil, status.Error(codes.Unimplemented, "method IngestTelemetry not implemented")
+	}
+	src, err := s.source(ctx)
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.publish(ctx, src, req)
+	if err != nil {
+		return nil, err
+	}
+	return &telemetryv1.IngestTelemetryResponse{Accepted: int32(n)}, nil
+}
+
+// StreamTelemetry publishes each batch of the stream as IngestTelemetry does. The caller is resolved once, when the
+// stream opens. The first rejected batch ends the stream with its error.
+func (s *Server) StreamTelemetry(stream grpc.ClientStreamingServer[telemetryv1.IngestTelemetryRequest, telemetryv1.IngestTelemetryResponse]) error {
+	if s.publisher == nil {
+		return status.Error(codes.Unimplemented, "method StreamTelemetry not implemented")
+	}
+	ctx := stream.Context()
+	src, err := s.source(ctx)
+	if err != nil {
+		return err
+	}
+	var accepted int32
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&telemetryv1.IngestTelemetryResponse{Accepted: accepted})
+		}
+		if err != nil {
+			return err
+		}
+		n, err := s.publish(ctx, src, req)
+		if err != nil {
+			return err
+		}
+		accepted += int32(n)
+	}
+}
+
+// QueryTelemetry lists the org's stored events matching the request's filters, newest first. Caller must be org admin
+// or owner.
+func (s *Server) QueryTelemetry(ctx context.Context, req *telemetryv1.QueryTelemetryRequest) (*telemetryv1.QueryTelemetryResponse, error) {
+	if s.events == nil {
+		return nil, status.Error(codes.Unimplemented, "method QueryTelemetry not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrgId() != "" && req.GetOrgId() != orgID {
+		return nil, status.Error(codes.PermissionDenied, "org_id does not match context")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	filter := embedded.Filter{Type: req.GetType(), UserID: req.GetUserId(), DeviceID: req.GetDeviceId()}
+	if req.GetSince() != nil {
+		since := req.GetSince().AsTime()
+		filter.Since = &since
+	}
+	if req.GetUntil() != nil {
+		until := req.GetUntil().AsTime()
+		filter.Until = &until
+	}
+	if filter.Since != nil && filter.Until != nil && !filter.Until.After(*filter.Since) {
+		return nil, status.Error(codes.InvalidArgument, "until must be after since")
+	}
+	list, err := s.events.Query(ctx, orgID, filter, pageSize, offset)
+	if err != nil {
+		if errors.Is(err, residency.ErrRegionUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, "org data region is not available")
+		}
+		return nil, status.Error(codes.Internal, "failed to query telemetry")
+	}
+	events := make([]*telemetryv1.TelemetryEnvelope, len(list))
+	for i, e := range list {
+		events[i] = eventToProto(e)
+	}
+	result := &telemetryv1.QueryTelemetryResponse{
+		Events:     events,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+func eventToProto(e telemetry.Event) *telemetryv1.TelemetryEnvelope {
+	return &telemetryv1.TelemetryEnvelope{
+		SchemaVersion: int32(e.SchemaVersion),
+		EventId:       e.EventID,
+		Type:          e.Type,
+		OccurredAt:    timestamppb.New(e.OccurredAt),
+		ReceivedAt:    timestamppb.New(e.ReceivedAt),
+		OrgId:         e.OrgID,
+		UserId:        e.UserID,
+		SessionId:     e.SessionID,
+		DeviceId:      e.DeviceID,
+		Region:        e.Region,
+		RequestId:     e.RequestID,
+		Attributes:    e.Attributes,
+	}
+}
+
+// source returns the caller's org, user, session and device. The caller must be an org member.
+func (s *Server) source(ctx context.Context) (telemetry.Source, error) {
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return telemetry.Source{}, err
+	}
+	sessionID, _ := interceptors.GetSessionID(ctx)
+	if sessionID == "" {
+		return telemetry.Source{}, status.Error(codes.Unauthenticated, "session context required")
+	}
+	src := telemetry.Source{OrgID: orgID, UserID: userID, SessionID: sessionID}
+	if s.sessions != nil {
+		sess, err := s.sessions.GetByID(ctx, sessionID)
+		if err != nil {
+			return telemetry.Source{}, status.Error(codes.Internal, "failed to load session")
+		}
+		if sess != nil {
+			src.DeviceID = sess.DeviceID
+		}
+	}
+	return src, nil
+}
+
+// publish validates req against its schema version and publishes its events from src. Returns how many were
+// published.
+func (s *Server) publish(ctx context.Context, src telemetry.Source, req *telemetryv1.IngestTelemetryRequest) (int, error) {
+	version := int(req.GetSchemaVersion())
+	if version == 0 {
+		version = telemetry.SchemaVersion
+	}
+	if _, ok := s.schemas.Lookup(version); !ok {
+		return 0, status.Errorf(codes.InvalidArgument, "schema_version %d is not supported; supported: %v", version, s.schemas.Versions())
+	}
+	if len(req.GetEvents()) == 0 || len(req.GetEvents()) > telemetry.MaxBatchSize {
+		return 0, status.Errorf(codes.InvalidArgument, "events must have 1 to %d entries", telemetry.MaxBatchSize)
+	}
+	now := s.now().UTC()
+	events := make([]telemetry.Event, len(req.GetEvents()))
+	for i, e := range req.GetEvents() {
+		var occurredAt time.Time
+		if e.GetOccurredAt() != nil {
+			occurredAt = e.GetOccurredAt().AsTime()
+		}
+		if err := telemetry.Validate(e.GetType(), e.GetEventId(), occurredAt, e.GetAttributes(), now); err != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "events[%d]: %v", i, err)
+		}
+		id := e.GetEventId()
+		if id == "" {
+			id = uuid.New().String()
+		}
+		events[i] = telemetry.Event{
+			SchemaVersion: version,
+			EventID:       id,
+			Type:          e.GetType(),
+			OccurredAt:    occurredAt,
+			ReceivedAt:    now,
+			OrgID:         src.OrgID,
+			UserID:        src.UserID,
+			SessionID:     src.SessionID,
+			DeviceID:      src.DeviceID,
+			Region:        s.region,
+			RequestID:     interceptors.RequestID(ctx),
+			Attributes:    e.GetAttributes(),
+		}
+		// Publish only what consumers of this version accept.
+		if err := s.schemas.Check(events[i]); err != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "events[%d]: %v", i, err)
+		}
+	}
+	if err := s.publisher.Publish(ctx, events); err != nil {
+		return 0, status.Error(codes.Unavailable, "failed to publish telemetry")
+	}
+	return len(events), nil
+}
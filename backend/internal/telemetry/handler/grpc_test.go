@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	telemetryv1 "zero-trust-control-plane/backend/api/generated/telemetry/v1"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/internal/telemetry/embedded"
+)
+
+type mockPublisher struct {
+	batches [][]telemetry.Event
+	err     error
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, events []telemetry.Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.batches = append(m.batches, events)
+	return nil
+}
+
+type mockEventQuerier struct {
+	events []telemetry.Event
+	orgID  string
+	filter embedded.Filter
+	limit  int32
+	offset int32
+	err    error
+}
+
+func (m *mockEventQuerier) Query(ctx context.Context, orgID string, filter embedded.Filter, limit, offset int32) ([]telemetry.Event, error) {
+	m.orgID, m.filter, m.limit, m.offset = orgID, filter, limit, offset
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.events, nil
+}
+
+type mockMembershipRepo struct {
+	memberships map[string]*membershipdomain.Membership
+}
+
+func (m *mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	return m.memberships[userID+":"+orgID], nil
+}
+
+type mockSessionGetter struct {
+	sessions map[string]*sessiondomain.Session
+}
+
+func (m *mockSessionGetter) GetByID(ctx context.Context, id string) (*sessiondomain.Session, error) {
+	return m.sessions[id], nil
+}
+
+// mockStream is a client stream that yields reqs, then io.EOF.
+type mockStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*telemetryv1.IngestTelemetryRequest
+	resp *telemetryv1.IngestTelemetryResponse
+}
+
+func (m *mockStream) Context() context.Context { return m.ctx }
+
+func (m *mockStream) Recv() (*telemetryv1.IngestTelemetryRequest, error) {
+	if len(m.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := m.reqs[0]
+	m.reqs = m.reqs[1:]
+	return req, nil
+}
+
+func (m *mockStream) SendAndClose(resp *telemetryv1.IngestTelemetryResponse) error {
+	m.resp = resp
+	return nil
+}
+
+var testNow = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestServer(pub *mockPublisher) *Server {
+	membershipRepo := &mockMembershipRepo{memberships: map[string]*membershipdomain.Membership{
+		"user-1:org-1":  {ID: "m1", UserID: "user-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		"admin-1:org-1": {ID: "m2", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+	}}
+	sessions := &mockSessionGetter{sessions: map[string]*sessiondomain.Session{
+		"sess-1": {ID: "sess-1", UserID: "user-1", OrgID: "org-1", DeviceID: "dev-1"},
+	}}
+	srv := NewServer(pub, &mockEventQuerier{}, membershipRepo, sessions, "eu-west-1")
+	srv.now = func() time.Time { return testNow }
+	return srv
+}
+
+func memberCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "user-1", "org-1", "sess-1")
+}
+
+func event(eventType string) *telemetryv1.TelemetryEvent {
+	return &telemetryv1.TelemetryEvent{Type: eventType, OccurredAt: timestamppb.New(testNow.Add(-time.Minute))}
+}
+
+func TestIngestTelemetry_NilPublisher(t *testing.T) {
+	srv := NewServer(nil, nil, nil, nil, "")
+	_, err := srv.IngestTelemetry(memberCtx(), &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{event("page_view")}})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+func TestIngestTelemetry_PublishesEnvelope(t *testing.T) {
+	pub := &mockPublisher{}
+	srv := newTestServer(pub)
+	withID := event("process.start")
+	withID.EventId = "client-1"
+	withID.Attributes = map[string]string{"name": "bash"}
+
+	ctx := interceptors.WithRequestID(memberCtx(), "req-1")
+	resp, err := srv.IngestTelemetry(ctx, &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{withID, event("page_view")}})
+	if err != nil {
+		t.Fatalf("IngestTelemetry: %v", err)
+	}
+	if resp.GetAccepted() != 2 || len(pub.batches) != 1 || len(pub.batches[0]) != 2 {
+		t.Fatalf("accepted = %d, batches = %v", resp.GetAccepted(), pub.batches)
+	}
+	got := pub.batches[0][0]
+	if got.SchemaVersion != telemetry.SchemaVersion || got.EventID != "client-1" || got.Type != "process.start" {
+		t.Errorf("event = %+v", got)
+	}
+	if got.OrgID != "org-1" || got.UserID != "user-1" || got.SessionID != "sess-1" || got.DeviceID != "dev-1" || got.Region != "eu-west-1" {
+		t.Errorf("source = %+v", got)
+	}
+	if got.RequestID != "req-1" {
+		t.Errorf("RequestID = %q, want the call's request ID", got.RequestID)
+	}
+	if !got.ReceivedAt.Equal(testNow) || !got.OccurredAt.Equal(testNow.Add(-time.Minute)) || got.Attributes["name"] != "bash" {
+		t.Errorf("event = %+v", got)
+	}
+	if pub.batches[0][1].EventID == "" {
+		t.Error("event_id should be generated when empty")
+	}
+}
+
+func TestIngestTelemetry_Validation(t *testing.T) {
+	tooMany := make([]*telemetryv1.TelemetryEvent, telemetry.MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = event("page_view")
+	}
+	future := event("page_view")
+	future.OccurredAt = timestamppb.New(testNow.Add(time.Hour))
+	tests := []struct {
+		name string
+		req  *telemetryv1.IngestTelemetryRequest
+	}{
+		{"empty batch", &telemetryv1.IngestTelemetryRequest{}},
+		{"too many events", &telemetryv1.IngestTelemetryRequest{Events: tooMany}},
+		{"unknown schema version", &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{event("page_view")}, SchemaVersion: 2}},
+		{"bad type", &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{event("Page View")}}},
+		{"missing occurred_at", &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{{Type: "page_view"}}}},
+		{"occurred_at in the future", &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{event("page_view"), future}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pub := &mockPublisher{}
+			_, err := newTestServer(pub).IngestTelemetry(memberCtx(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("code = %v, want InvalidArgument", status.Code(err))
+			}
+			if len(pub.batches) != 0 {
+				t.Error("rejected batch should not be published")
+			}
+		})
+	}
+
+	_, err := newTestServer(&mockPublisher{}).IngestTelemetry(memberCtx(), tests[5].req)
+	if !strings.Contains(status.Convert(err).Message(), "events[1]") {
+		t.Errorf("message = %q, want the index of the bad event", status.Convert(err).Message())
+	}
+}
+
+func TestIngestTelemetry_NotMember(t *testing.T) {
+	ctx := interceptors.WithIdentity(context.Background(), "user-2", "org-1", "sess-2")
+	_, err := newTestServer(&mockPublisher{}).IngestTelemetry(ctx, &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{event("page_view")}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestIngestTelemetry_PublishFailure(t *testing.T) {
+	pub := &mockPublisher{err: errors.New("broker down")}
+	_, err := newTestServer(pub).IngestTelemetry(memberCtx(), &telemetryv1.IngestTelemetryRequest{Events: []*telemetryv1.TelemetryEvent{event("page_view")}})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("code = %v, want Unavailable", status.Code(err))
+	}
+}
+
+func TestStreamTelemetry(t *testing.T) {
+	pub := &mockPublisher{}
+	stream := &mockStream{ctx: interceptors.WithRequestID(memberCtx(), "req-1"), reqs: []*telemetryv1.IngestTelemetryRequest{
+		{Events: []*telemetryv1.TelemetryEvent{event("page_view")}},
+		{Events: []*telemetryv1.TelemetryEvent{event("page_view"), event("process.start")}},
+	}}
+	if err := newTestServer(pub).StreamTelemetry(stream); err != nil {
+		t.Fatalf("StreamTelemetry: %v", err)
+	}
+	if stream.resp.GetAccepted() != 3 || len(pub.batches) != 2 {
+		t.Errorf("accepted = %d, batches = %d; want 3, 2", stream.resp.GetAccepted(), len(pub.batches))
+	}
+	if got := pub.batches[1][0].RequestID; got != "req-1" {
+		t.Errorf("RequestID = %q, want the stream's request ID", got)
+	}
+}
+
+func TestStreamTelemetry_RejectedBatchEndsStream(t *testing.T) {
+	pub := &mockPublisher{}
+	stream := &mockStream{ctx: memberCtx(), reqs: []*telemetryv1.IngestTelemetryRequest{
+		{Events: []*telemetryv1.TelemetryEvent{event("page_view")}},
+		{},
+		{Events: []*telemetryv1.TelemetryEvent{event("page_view")}},
+	}}
+	err := newTestServer(pub).StreamTelemetry(stream)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", status.Code(err))
+	}
+	if len(pub.batches) != 1 || stream.resp != nil {
+		t.Errorf("batches = %d, resp = %v; want the first batch only and no response", len(pub.batches), stream.resp)
+	}
+}
+
+func adminCtx() context.Context {
+	return interceptors.WithIdentity(context.Background(), "admin-1", "org-1", "sess-2")
+}
+
+func TestQueryTelemetry_NilQuerier(t *testing.T) {
+	srv := NewServer(&mockPublisher{}, nil, nil, nil, "")
+	_, err := srv.QueryTelemetry(adminCtx(), &telemetryv1.QueryTelemetryRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("code = %v, want Unimplemented", status.Code(err))
+	}
+}
+
+func TestQueryTelemetry_RequiresAdmin(t *testing.T) {
+	srv := newTestServer(&mockPublisher{})
+	_, err := srv.QueryTelemetry(memberCtx(), &telemetryv1.QueryTelemetryRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("code = %v, want PermissionDenied", status.Code(err))
+	}
+	_, err = srv.QueryTelemetry(adminCtx(), &telemetryv1.QueryTelemetryRequest{OrgId: "org-2"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("other org: code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestQueryTelemetry_FiltersAndPages(t *testing.T) {
+	srv := newTestServer(&mockPublisher{})
+	querier := &mockEventQuerier{events: []telemetry.Event{
+		{SchemaVersion: 1, EventID: "e2", Type: "page_view", OrgID: "org-1", UserID: "user-1", DeviceID: "dev-1", ReceivedAt: testNow, Attributes: map[string]string{"url": "/"}},
+		{SchemaVersion: 1, EventID: "e1", Type: "page_view", OrgID: "org-1", UserID: "user-1", ReceivedAt: testNow.Add(-time.Minute)},
+	}}
+	srv.events = querier
+	since := testNow.Add(-time.Hour)
+	resp, err := srv.QueryTelemetry(adminCtx(), &telemetryv1.QueryTelemetryRequest{
+		Type:       "page_view",
+		UserId:     "user-1",
+		DeviceId:   "dev-1",
+		Since:      timestamppb.New(since),
+		Pagination: &commonv1.Pagination{PageSize: 2, PageToken: "4"},
+	})
+	if err != nil {
+		t.Fatalf("QueryTelemetry: %v", err)
+	}
+	if querier.orgID != "org-1" || querier.limit != 2 || querier.offset != 4 {
+		t.Errorf("query org/limit/offset = %s/%d/%d", querier.orgID, querier.limit, querier.offset)
+	}
+	f := querier.filter
+	if f.Type != "page_view" || f.UserID != "user-1" || f.DeviceID != "dev-1" || f.Since == nil || !f.Since.Equal(since) || f.Until != nil {
+		t.Errorf("filter = %+v", f)
+	}
+	if len(resp.GetEvents()) != 2 || resp.GetEvents()[0].GetEventId() != "e2" || resp.GetEvents()[0].GetAttributes()["url"] != "/" {
+		t.Fatalf("events = %v", resp.GetEvents())
+	}
+	if !resp.GetEvents()[0].GetReceivedAt().AsTime().Equal(testNow) || resp.GetEvents()[0].GetDeviceId() != "dev-1" {
+		t.Errorf("event = %v", resp.GetEvents()[0])
+	}
+	if resp.GetPagination().GetNextPageToken() != "6" {
+		t.Errorf("next page token = %q, want 6", resp.GetPagination().GetNextPageToken())
+	}
+}
+
+func TestQueryTelemetry_InvalidRange(t *testing.T) {
+	srv := newTestServer(&mockPublisher{})
+	_, err := srv.QueryTelemetry(adminCtx(), &telemetryv1.QueryTelemetryRequest{
+		Since: timestamppb.New(testNow),
+		Until: timestamppb.New(testNow.Add(-time.Hour)),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestQueryTelemetry_StoreError(t *testing.T) {
+	srv := newTestServer(&mockPublisher{})
+	srv.events = &mockEventQuerier{err: errors.New("db down")}
+	_, err := srv.QueryTelemetry(adminCtx(), &telemetryv1.QueryTelemetryRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("code = %v, want Internal", status.Code(err))
+	}
+}
+
+func TestQueryTelemetry_RegionUnavailable(t *testing.T) {
+	srv := newTestServer(&mockPublisher{})
+	srv.events = &mockEventQuerier{err: fmt.Errorf("%w: org org-1 requires region \"eu\"", residency.ErrRegionUnavailable)}
+	_, err := srv.QueryTelemetry(adminCtx(), &telemetryv1.QueryTelemetryRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("code = %v, want FailedPrecondition", status.Code(err))
+	}
+}
@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink writes messages to a Kafka topic. Messages are partitioned by key, so one device's events stay ordered
+// within a partition.
+type KafkaSink struct {
+	w *kafka.Writer
+}
+
+// NewKafkaSink returns a sink for topic on brokers. Writes wait for all in-sync replicas, so an accepted batch is not
+// lost with a broker.
+func NewKafkaSink(brokers []string, topic string) *KafkaSink {
+	return &KafkaSink{w: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		Compression:  kafka.Snappy,
+		// Agents wait for the acknowledgement; batches from concurrent requests are combined within this window.
+		BatchTimeout: 20 * time.Millisecond,
+	}}
+}
+
+// Write writes msgs to the topic and waits for them to be acknowledged.
+func (s *KafkaSink) Write(ctx context.Context, msgs ...Message) error {
+	out := make([]kafka.Message, len(msgs))
+	for i, m := range msgs {
+		headers := make([]kafka.Header, len(m.Headers))
+		for j, h := range m.Headers {
+			headers[j] = kafka.Header{Key: h.Key, Value: h.Value}
+		}
+		out[i] = kafka.Message{Key: m.Key, Value: m.Value, Headers: headers}
+	}
+	return s.w.WriteMessages(ctx, out...)
+}
+
+// Close flushes pending writes and closes the connections.
+func (s *KafkaSink) Close() error {
+	return s.w.Close()
+}
+
+// KafkaSubscription reads a Kafka topic as a consumer group. Ack commits the message's offset.
+type KafkaSubscription struct {
+	r *kafka.Reader
+}
+
+// NewKafkaSubscription returns a subscription to topic on brokers in groupID. A new group starts from the oldest
+// retained event.
+func NewKafkaSubscription(brokers []string, topic, groupID string) *KafkaSubscription {
+	return &KafkaSubscription{r: kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		Topic:       topic,
+		GroupID:     groupID,
+		StartOffset: kafka.FirstOffset,
+		MaxWait:     time.Second,
+	})}
+}
+
+// Fetch returns the next message of the group's partitions.
+func (s *KafkaSubscription) Fetch(ctx context.Context) (Message, error) {
+	m, err := s.r.FetchMessage(ctx)
+	if err != nil {
+		return Message{}, err
+	}
+	headers := make([]Header, len(m.Headers))
+	for i, h := range m.Headers {
+		headers[i] = Header{Key: h.Key, Value: h.Value}
+	}
+	return Message{Key: m.Key, Value: m.Value, Headers: headers, Origin: m.Topic, Partition: m.Partition, Offset: m.Offset}, nil
+}
+
+// Ack commits msg's offset for the group.
+func (s *KafkaSubscription) Ack(ctx context.Context, msg Message) error {
+	return s.r.CommitMessages(ctx, kafka.Message{Topic: msg.Origin, Partition: msg.Partition, Offset: msg.Offset})
+}
+
+// Close closes the reader.
+func (s *KafkaSubscription) Close() error {
+	return s.r.Close()
+}
@@ -0,0 +1,247 @@
+// Package loki pushes telemetry events to Grafana Loki for cmd/telemetry-worker. Each event becomes one log line, its
+// JSON envelope, in the stream named by labels extracted from the event. A per-label cap on distinct values keeps a
+// noisy attribute from creating unbounded streams. Per-call values such as request_id are in the line only, never a
+// label: query them with `| json | request_id="..."`.
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/pkg/telemetryconsumer"
+)
+
+const (
+	// DefaultLabels is the label spec used when LOKI_LABELS is empty.
+	DefaultLabels = "org_id,event_type,severity"
+	// JobLabel is set on every stream, so each has at least one label and the worker's streams are easy to select.
+	JobLabel = "ztcp-telemetry"
+	// OverflowValue replaces label values past the per-label cap and values longer than MaxLabelValueLength.
+	OverflowValue = "_overflow"
+	// MaxLabelValueLength is the longest label value kept.
+	MaxLabelValueLength = 128
+	// TenantHeader carries the Loki tenant in multi-tenant deployments.
+	TenantHeader = "X-Scope-OrgID"
+)
+
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// envelopeFields are the event fields a label can be taken from; any other source is an attribute.
+var envelopeFields = map[string]func(e telemetry.Event) string{
+	"org_id":         func(e telemetry.Event) string { return e.OrgID },
+	"user_id":        func(e telemetry.Event) string { return e.UserID },
+	"device_id":      func(e telemetry.Event) string { return e.DeviceID },
+	"type":           func(e telemetry.Event) string { return e.Type },
+	"region":         func(e telemetry.Event) string { return e.Region },
+	"schema_version": func(e telemetry.Event) string { return strconv.Itoa(e.SchemaVersion) },
+}
+
+// Label is one stream label and the event field it is taken from.
+type Label struct {
+	Name string
+	// Source is an envelope field (org_id, user_id, device_id, type, region, schema_version) or attributes.<key>.
+	Source string
+}
+
+// value returns the label's value in e, or "" when e has none.
+func (l Label) value(e telemetry.Event) string {
+	if f, ok := envelopeFields[l.Source]; ok {
+		return f(e)
+	}
+	return e.Attributes[strings.TrimPrefix(l.Source, "attributes.")]
+}
+
+// ParseLabels parses a comma-separated label spec. Each entry is name=source, or a bare name: event_type for the event
+// type, an envelope field of that name, or else the attribute of that name. An empty spec is DefaultLabels.
+func ParseLabels(spec string) ([]Label, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = DefaultLabels
+	}
+	var labels []Label
+	seen := map[string]bool{"job": true}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, source, explicit := strings.Cut(entry, "=")
+		name, source = strings.TrimSpace(name), strings.TrimSpace(source)
+		if name == "request_id" && !explicit {
+			// Unique per call, so a stream per value; it is in the line.
+			return nil, fmt.Errorf("loki: request_id cannot be a label; query it from the line")
+		}
+		if !explicit {
+			switch _, field := envelopeFields[name]; {
+			case name == "event_type":
+				source = "type"
+			case field:
+				source = name
+			default:
+				source = "attributes." + name
+			}
+		}
+		if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return nil, fmt.Errorf("loki: invalid label name %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("loki: duplicate label %q", name)
+		}
+		if _, field := envelopeFields[source]; !field && (!strings.HasPrefix(source, "attributes.") || source == "attributes.") {
+			return nil, fmt.Errorf("loki: label %q has unknown source %q", name, source)
+		}
+		seen[name] = true
+		labels = append(labels, Label{Name: name, Source: source})
+	}
+	return labels, nil
+}
+
+// Config configures a Pusher.
+type Config struct {
+	// URL is Loki's base URL, e.g. http://loki:3100.
+	URL    string
+	Labels []Label
+	// MaxLabelValues is how many distinct values each label keeps before new ones become OverflowValue.
+	MaxLabelValues int
+	// TenantPerOrg sends each event as the tenant of its org (X-Scope-OrgID = org_id). Otherwise TenantID, when set,
+	// is sent for every event.
+	TenantPerOrg bool
+	TenantID     string
+	// Client defaults to a client with a 10s timeout.
+	Client *http.Client
+	// Breaker, when set, fails pushes at once with breaker.ErrOpen while Loki keeps failing, so the worker backs off
+	// instead of waiting out the client timeout for every event. Rejected events do not count as failures.
+	Breaker *breaker.Breaker
+}
+
+// Pusher pushes events to Loki. Safe for concurrent use.
+type Pusher struct {
+	cfg    Config
+	client *http.Client
+
+	mu     sync.Mutex
+	values map[string]map[string]struct{} // label name → values seen
+}
+
+// New returns a pusher for cfg.
+func New(cfg Config) *Pusher {
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Pusher{cfg: cfg, client: client, values: make(map[string]map[string]struct{})}
+}
+
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Push writes e to Loki, timestamped with its received_at so each stream stays in order. Loki's 4xx answers other than
+// 429 wrap telemetryconsumer.ErrReject, so the worker dead-letters the event instead of retrying it.
+func (p *Pusher) Push(ctx context.Context, e telemetry.Event) error {
+	if err := p.cfg.Breaker.Allow(); err != nil {
+		return err
+	}
+	err := p.push(ctx, e)
+	if errors.Is(err, telemetryconsumer.ErrReject) {
+		p.cfg.Breaker.Record(nil)
+	} else {
+		p.cfg.Breaker.Record(err)
+	}
+	return err
+}
+
+func (p *Pusher) push(ctx context.Context, e telemetry.Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(pushRequest{Streams: []stream{{
+		Stream: p.labels(e),
+		Values: [][2]string{{strconv.FormatInt(e.ReceivedAt.UnixNano(), 10), string(line)}},
+	}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(p.cfg.URL, "/")+"/loki/api/v1/push", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tenant := p.tenant(e); tenant != "" {
+		req.Header.Set(TenantHeader, tenant)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("loki: push returned %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %v", telemetryconsumer.ErrReject, err)
+	}
+	return err
+}
+
+// tenant returns the X-Scope-OrgID for e, or "" for none.
+func (p *Pusher) tenant(e telemetry.Event) string {
+	if p.cfg.TenantPerOrg {
+		return e.OrgID
+	}
+	return p.cfg.TenantID
+}
+
+// labels returns the stream labels of e. Labels without a value are left out.
+func (p *Pusher) labels(e telemetry.Event) map[string]string {
+	out := map[string]string{"job": JobLabel}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, l := range p.cfg.Labels {
+		v := l.value(e)
+		if v == "" {
+			continue
+		}
+		out[l.Name] = p.guard(l.Name, v)
+	}
+	return out
+}
+
+// guard returns v, or OverflowValue when v is too long or name already has MaxLabelValues other values. p.mu is held.
+func (p *Pusher) guard(name, v string) string {
+	if len(v) > MaxLabelValueLength {
+		return OverflowValue
+	}
+	seen := p.values[name]
+	if seen == nil {
+		seen = make(map[string]struct{})
+		p.values[name] = seen
+	}
+	if _, ok := seen[v]; ok {
+		return v
+	}
+	if len(seen) >= p.cfg.MaxLabelValues {
+		return OverflowValue
+	}
+	seen[v] = struct{}{}
+	return v
+}
@@ -0,0 +1,196 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/breaker"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/pkg/telemetryconsumer"
+)
+
+type recordedPush struct {
+	tenant string
+	body   pushRequest
+}
+
+func newLoki(t *testing.T, status int) (*httptest.Server, *[]recordedPush) {
+	t.Helper()
+	var pushes []recordedPush
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/push" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		var body pushRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode push: %v", err)
+		}
+		pushes = append(pushes, recordedPush{tenant: r.Header.Get(TenantHeader), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &pushes
+}
+
+func event(orgID string, attributes map[string]string) telemetry.Event {
+	return telemetry.Event{
+		SchemaVersion: 1,
+		EventID:       "evt-1",
+		Type:          "process.start",
+		OccurredAt:    time.Date(2026, 10, 1, 11, 59, 0, 0, time.UTC),
+		ReceivedAt:    time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		OrgID:         orgID,
+		UserID:        "user-1",
+		SessionID:     "sess-1",
+		RequestID:     "req-1",
+		Attributes:    attributes,
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	got, err := ParseLabels("")
+	if err != nil {
+		t.Fatalf("ParseLabels(default): %v", err)
+	}
+	want := []Label{{"org_id", "org_id"}, {"event_type", "type"}, {"severity", "attributes.severity"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLabels(default) = %v, want %v", got, want)
+	}
+	got, err = ParseLabels(" region , sev=attributes.level,kind=type ")
+	if err != nil {
+		t.Fatalf("ParseLabels: %v", err)
+	}
+	want = []Label{{"region", "region"}, {"sev", "attributes.level"}, {"kind", "type"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLabels = %v, want %v", got, want)
+	}
+	for _, spec := range []string{"bad-name", "__name__", "job", "org_id,org_id", "x=session", "x=attributes.", "request_id", "x=request_id"} {
+		if _, err := ParseLabels(spec); err == nil {
+			t.Errorf("ParseLabels(%q) should fail", spec)
+		}
+	}
+}
+
+func TestPush_LabelsAndLine(t *testing.T) {
+	srv, pushes := newLoki(t, http.StatusNoContent)
+	labels, _ := ParseLabels("")
+	p := New(Config{URL: srv.URL + "/", Labels: labels, MaxLabelValues: 10})
+
+	e := event("org-1", map[string]string{"severity": "high", "name": "bash"})
+	if err := p.Push(context.Background(), e); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if len(*pushes) != 1 || len((*pushes)[0].body.Streams) != 1 {
+		t.Fatalf("pushes = %+v", *pushes)
+	}
+	push := (*pushes)[0]
+	s := push.body.Streams[0]
+	wantLabels := map[string]string{"job": JobLabel, "org_id": "org-1", "event_type": "process.start", "severity": "high"}
+	if !reflect.DeepEqual(s.Stream, wantLabels) {
+		t.Errorf("labels = %v, want %v", s.Stream, wantLabels)
+	}
+	if push.tenant != "" {
+		t.Errorf("tenant = %q, want none", push.tenant)
+	}
+	if len(s.Values) != 1 || s.Values[0][0] != fmt.Sprint(e.ReceivedAt.UnixNano()) {
+		t.Fatalf("values = %v", s.Values)
+	}
+	var line telemetry.Event
+	if err := json.Unmarshal([]byte(s.Values[0][1]), &line); err != nil || !reflect.DeepEqual(line, e) {
+		t.Errorf("line = %+v (%v), want the event envelope", line, err)
+	}
+	if !strings.Contains(s.Values[0][1], `"request_id":"req-1"`) {
+		t.Errorf("line = %s, want the request_id", s.Values[0][1])
+	}
+
+	// A missing attribute leaves its label out.
+	if err := p.Push(context.Background(), event("org-1", nil)); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if _, ok := (*pushes)[1].body.Streams[0].Stream["severity"]; ok {
+		t.Error("label without a value should be left out")
+	}
+}
+
+func TestPush_CardinalityGuard(t *testing.T) {
+	srv, pushes := newLoki(t, http.StatusNoContent)
+	p := New(Config{URL: srv.URL, Labels: []Label{{"path", "attributes.path"}}, MaxLabelValues: 2})
+	for _, path := range []string{"/a", "/b", "/c", "/a", strings.Repeat("x", MaxLabelValueLength+1)} {
+		if err := p.Push(context.Background(), event("org-1", map[string]string{"path": path})); err != nil {
+			t.Fatalf("Push: %v", err)
+		}
+	}
+	var got []string
+	for _, push := range *pushes {
+		got = append(got, push.body.Streams[0].Stream["path"])
+	}
+	want := []string{"/a", "/b", OverflowValue, "/a", OverflowValue}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("path labels = %v, want %v", got, want)
+	}
+}
+
+func TestPush_Tenants(t *testing.T) {
+	srv, pushes := newLoki(t, http.StatusNoContent)
+	perOrg := New(Config{URL: srv.URL, MaxLabelValues: 10, TenantPerOrg: true})
+	fixed := New(Config{URL: srv.URL, MaxLabelValues: 10, TenantID: "ztcp"})
+	_ = perOrg.Push(context.Background(), event("org-1", nil))
+	_ = perOrg.Push(context.Background(), event("org-2", nil))
+	_ = fixed.Push(context.Background(), event("org-1", nil))
+	var got []string
+	for _, push := range *pushes {
+		got = append(got, push.tenant)
+	}
+	if want := []string{"org-1", "org-2", "ztcp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tenants = %v, want %v", got, want)
+	}
+}
+
+func TestPush_Errors(t *testing.T) {
+	tests := []struct {
+		status int
+		reject bool
+	}{
+		{http.StatusBadRequest, true},
+		{http.StatusTooManyRequests, false},
+		{http.StatusInternalServerError, false},
+	}
+	for _, tt := range tests {
+		srv, _ := newLoki(t, tt.status)
+		err := New(Config{URL: srv.URL, MaxLabelValues: 10}).Push(context.Background(), event("org-1", nil))
+		if err == nil {
+			t.Fatalf("status %d: Push should fail", tt.status)
+		}
+		if got := errors.Is(err, telemetryconsumer.ErrReject); got != tt.reject {
+			t.Errorf("status %d: errors.Is(err, ErrReject) = %v, want %v", tt.status, got, tt.reject)
+		}
+	}
+}
+
+func TestPush_Breaker(t *testing.T) {
+	srv, pushes := newLoki(t, http.StatusBadRequest)
+	b := breaker.New("loki", breaker.Config{FailureThreshold: 1, OpenTimeout: time.Hour})
+	p := New(Config{URL: srv.URL, MaxLabelValues: 10, Breaker: b})
+	p.Push(context.Background(), event("org-1", nil))
+	if b.State() != breaker.StateClosed {
+		t.Fatalf("breaker state = %s after a rejected event, want closed", b.State())
+	}
+
+	down, downPushes := newLoki(t, http.StatusServiceUnavailable)
+	p = New(Config{URL: down.URL, MaxLabelValues: 10, Breaker: b})
+	p.Push(context.Background(), event("org-1", nil))
+	if err := p.Push(context.Background(), event("org-1", nil)); !errors.Is(err, breaker.ErrOpen) {
+		t.Errorf("Push with the breaker open = %v, want ErrOpen", err)
+	}
+	if len(*pushes) != 1 || len(*downPushes) != 1 {
+		t.Errorf("pushes = %d/%d, want 1/1 (no request while open)", len(*pushes), len(*downPushes))
+	}
+}
@@ -0,0 +1,463 @@
+// Package nats carries telemetry over NATS JetStream: a Sink that publishes to a subject captured by a stream and
+// waits for the stream's ack, and a Subscription that pulls from a durable consumer with explicit acks. It speaks the
+// NATS client protocol itself and implements only what these two need: CONNECT with user, password or token auth,
+// TLS, headers, and request/reply over one inbox subscription.
+package nats
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+const defaultPort = "4222"
+
+var errClosed = errors.New("nats: connection closed")
+
+// msg is a message delivered to the inbox.
+type msg struct {
+	subject string
+	reply   string
+	status  int    // from the header line (408, 503, ...); 0 for an ordinary message
+	desc    string // status description
+	headers []telemetry.Header
+	data    []byte
+}
+
+// options are the connection settings taken from a nats:// or tls:// URL.
+type options struct {
+	addr   string
+	host   string
+	useTLS bool
+	user   string
+	pass   string
+	token  string
+}
+
+func parseURL(rawURL string) (options, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return options{}, err
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return options{}, fmt.Errorf("scheme must be nats or tls, got %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return options{}, errors.New("host is required")
+	}
+	o := options{host: u.Hostname(), useTLS: u.Scheme == "tls"}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	o.addr = net.JoinHostPort(o.host, port)
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			o.user, o.pass = u.User.Username(), pass
+		} else {
+			o.token = u.User.Username()
+		}
+	}
+	return o, nil
+}
+
+// conn is one client connection. Replies arrive on a single wildcard subscription (sid 1) to the connection's inbox
+// and are routed to waiters by subject. JetStream delivers pulled messages under their own subject, so each pull
+// request gets a one-message subscription of its own and its reply is routed by sid.
+type conn struct {
+	nc    net.Conn
+	rd    *bufio.Reader
+	wmu   sync.Mutex
+	w     *bufio.Writer
+	inbox string
+
+	mu      sync.Mutex
+	waiters map[string]chan *msg // by reply subject, for sid 1
+	subs    map[string]chan *msg // by sid
+	next    uint64
+	err     error
+	done    chan struct{}
+}
+
+// serverInfo is the part of the server's INFO we use.
+type serverInfo struct {
+	TLSRequired bool `json:"tls_required"`
+	Headers     bool `json:"headers"`
+}
+
+// dial connects to rawURL, authenticates and subscribes to a fresh inbox.
+func dial(ctx context.Context, rawURL string) (*conn, error) {
+	o, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", o.addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = nc.SetDeadline(deadline)
+	} else {
+		_ = nc.SetDeadline(time.Now().Add(10 * time.Second))
+	}
+	c, err := handshake(nc, o)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	_ = c.nc.SetDeadline(time.Time{})
+	go c.readLoop()
+	return c, nil
+}
+
+func handshake(nc net.Conn, o options) (*conn, error) {
+	rd := bufio.NewReader(nc)
+	line, err := readLine(rd)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return nil, fmt.Errorf("nats: expected INFO, got %q", line)
+	}
+	var info serverInfo
+	if err := json.Unmarshal([]byte(line[5:]), &info); err != nil {
+		return nil, fmt.Errorf("nats: INFO: %w", err)
+	}
+	if !info.Headers {
+		return nil, errors.New("nats: server does not support headers (NATS 2.2 or later is required)")
+	}
+	if o.useTLS || info.TLSRequired {
+		tc := tls.Client(nc, &tls.Config{ServerName: o.host, MinVersion: tls.VersionTLS12})
+		if err := tc.Handshake(); err != nil {
+			return nil, err
+		}
+		nc, rd = tc, bufio.NewReader(tc)
+	}
+	inbox, err := newInbox()
+	if err != nil {
+		return nil, err
+	}
+	c := &conn{nc: nc, rd: rd, w: bufio.NewWriter(nc), inbox: inbox, waiters: map[string]chan *msg{}, subs: map[string]chan *msg{}, done: make(chan struct{})}
+	connect, _ := json.Marshal(map[string]any{
+		"verbose":       false,
+		"pedantic":      false,
+		"headers":       true,
+		"no_responders": true,
+		"lang":          "go",
+		"version":       "ztcp",
+		"name":          "ztcp-telemetry",
+		"protocol":      1,
+		"user":          o.user,
+		"pass":          o.pass,
+		"auth_token":    o.token,
+	})
+	fmt.Fprintf(c.w, "CONNECT %s\r\nSUB %s.* 1\r\nPING\r\n", connect, inbox)
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+	for {
+		line, err := readLine(rd)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case line == "PONG":
+			return c, nil
+		case strings.HasPrefix(line, "-ERR"):
+			return nil, fmt.Errorf("nats: %s", strings.Trim(strings.TrimSpace(line[4:]), "'"))
+		}
+		// INFO updates and +OK are ignored.
+	}
+}
+
+func newInbox() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "_INBOX." + hex.EncodeToString(b), nil
+}
+
+func readLine(rd *bufio.Reader) (string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readLoop reads server messages until the connection fails, answering PINGs and routing inbox messages to waiters.
+func (c *conn) readLoop() {
+	err := c.read()
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+	c.nc.Close()
+	close(c.done)
+}
+
+func (c *conn) read() error {
+	for {
+		line, err := readLine(c.rd)
+		if err != nil {
+			return err
+		}
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "PING":
+			if err := c.write(func(w *bufio.Writer) { w.WriteString("PONG\r\n") }); err != nil {
+				return err
+			}
+		case "MSG", "HMSG":
+			m, sid, err := c.readMsg(strings.ToUpper(op) == "HMSG", strings.Fields(args))
+			if err != nil {
+				return err
+			}
+			routes, key := c.waiters, m.subject
+			if sid != "1" {
+				routes, key = c.subs, sid
+			}
+			c.mu.Lock()
+			ch := routes[key]
+			delete(routes, key)
+			c.mu.Unlock()
+			if ch != nil {
+				ch <- m
+			}
+		case "-ERR":
+			return fmt.Errorf("nats: %s", strings.Trim(strings.TrimSpace(args), "'"))
+		}
+	}
+}
+
+// readMsg reads the payload of a MSG (subject sid [reply] size) or HMSG (subject sid [reply] header-size size).
+func (c *conn) readMsg(withHeaders bool, f []string) (*msg, string, error) {
+	sizes := 1
+	if withHeaders {
+		sizes = 2
+	}
+	if len(f) != 2+sizes && len(f) != 3+sizes {
+		return nil, "", fmt.Errorf("nats: malformed message line %q", strings.Join(f, " "))
+	}
+	m := &msg{subject: f[0]}
+	if len(f) == 3+sizes {
+		m.reply = f[2]
+	}
+	total, err := strconv.Atoi(f[len(f)-1])
+	if err != nil || total < 0 {
+		return nil, "", fmt.Errorf("nats: malformed message size %q", f[len(f)-1])
+	}
+	buf := make([]byte, total+2)
+	if _, err := io.ReadFull(c.rd, buf); err != nil {
+		return nil, "", err
+	}
+	buf = buf[:total]
+	if !withHeaders {
+		m.data = buf
+		return m, f[1], nil
+	}
+	hdrLen, err := strconv.Atoi(f[len(f)-2])
+	if err != nil || hdrLen < 0 || hdrLen > total {
+		return nil, "", fmt.Errorf("nats: malformed header size %q", f[len(f)-2])
+	}
+	if err := parseHeaders(buf[:hdrLen], m); err != nil {
+		return nil, "", err
+	}
+	m.data = buf[hdrLen:]
+	return m, f[1], nil
+}
+
+// parseHeaders parses "NATS/1.0[ status[ description]]" followed by "Key: Value" lines.
+func parseHeaders(b []byte, m *msg) error {
+	lines := strings.Split(string(b), "\r\n")
+	version, status, _ := strings.Cut(lines[0], " ")
+	if version != "NATS/1.0" {
+		return fmt.Errorf("nats: malformed header line %q", lines[0])
+	}
+	if status = strings.TrimSpace(status); status != "" {
+		code, desc, _ := strings.Cut(status, " ")
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			return fmt.Errorf("nats: malformed status %q", status)
+		}
+		m.status, m.desc = n, desc
+	}
+	for _, l := range lines[1:] {
+		if l == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(l, ":")
+		if !ok {
+			return fmt.Errorf("nats: malformed header %q", l)
+		}
+		m.headers = append(m.headers, telemetry.Header{Key: k, Value: []byte(strings.TrimSpace(v))})
+	}
+	return nil
+}
+
+func (c *conn) write(fn func(w *bufio.Writer)) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	fn(c.w)
+	return c.w.Flush()
+}
+
+// encodeHeaders returns the header block of an HPUB. Line breaks in values, which would end the header, become
+// spaces.
+func encodeHeaders(headers []telemetry.Header) []byte {
+	var b bytes.Buffer
+	b.WriteString("NATS/1.0\r\n")
+	for _, h := range headers {
+		v := strings.NewReplacer("\r", " ", "\n", " ").Replace(string(h.Value))
+		fmt.Fprintf(&b, "%s: %s\r\n", h.Key, v)
+	}
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
+
+// pending is a request whose reply has not arrived yet.
+type pending struct {
+	c      *conn
+	routes map[string]chan *msg
+	key    string
+	ch     chan *msg
+}
+
+// publish writes one message to w, with reply as its reply subject when set.
+func publish(w *bufio.Writer, subject, reply string, headers []telemetry.Header, data []byte) {
+	if reply != "" {
+		reply = " " + reply
+	}
+	if len(headers) == 0 {
+		fmt.Fprintf(w, "PUB %s%s %d\r\n", subject, reply, len(data))
+	} else {
+		hdr := encodeHeaders(headers)
+		fmt.Fprintf(w, "HPUB %s%s %d %d\r\n", subject, reply, len(hdr), len(hdr)+len(data))
+		w.Write(hdr)
+	}
+	w.Write(data)
+	w.WriteString("\r\n")
+}
+
+// request publishes the messages built by send, each with its own reply subject, and returns their pending replies.
+// send is called once per reply subject it asks for.
+func (c *conn) request(n int, send func(w *bufio.Writer, i int, reply string)) ([]*pending, error) {
+	ps := make([]*pending, n)
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	for i := range ps {
+		c.next++
+		p := &pending{c: c, routes: c.waiters, key: c.inbox + "." + strconv.FormatUint(c.next, 10), ch: make(chan *msg, 1)}
+		c.waiters[p.key] = p.ch
+		ps[i] = p
+	}
+	c.mu.Unlock()
+	err := c.write(func(w *bufio.Writer) {
+		for i, p := range ps {
+			send(w, i, p.key)
+		}
+	})
+	if err != nil {
+		for _, p := range ps {
+			p.cancel()
+		}
+		return nil, err
+	}
+	return ps, nil
+}
+
+// requestOwn publishes the message built by send with a reply subject that has a subscription of its own, which ends
+// after one message.
+func (c *conn) requestOwn(send func(w *bufio.Writer, reply string)) (*pending, error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	c.next++
+	n := strconv.FormatUint(c.next+1, 10) // sid 1 is the inbox subscription
+	p := &pending{c: c, routes: c.subs, key: n, ch: make(chan *msg, 1)}
+	c.subs[n] = p.ch
+	c.mu.Unlock()
+	reply := c.inbox + "-own." + n
+	err := c.write(func(w *bufio.Writer) {
+		fmt.Fprintf(w, "SUB %s %s\r\nUNSUB %s 1\r\n", reply, n, n)
+		send(w, reply)
+	})
+	if err != nil {
+		p.cancel()
+		return nil, err
+	}
+	return p, nil
+}
+
+// wait returns the reply, or an error when ctx is done, timeout passes or the connection fails first.
+func (p *pending) wait(ctx context.Context, timeout time.Duration) (*msg, error) {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case m := <-p.ch:
+		return m, nil
+	case <-ctx.Done():
+		p.cancel()
+		return nil, ctx.Err()
+	case <-t.C:
+		p.cancel()
+		return nil, errors.New("nats: timed out waiting for a reply")
+	case <-p.c.done:
+		return nil, p.c.failure()
+	}
+}
+
+func (p *pending) cancel() {
+	p.c.mu.Lock()
+	delete(p.routes, p.key)
+	p.c.mu.Unlock()
+}
+
+func (c *conn) failure() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil || errors.Is(c.err, net.ErrClosed) {
+		return errClosed
+	}
+	return c.err
+}
+
+func (c *conn) close() error {
+	err := c.nc.Close()
+	<-c.done
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// broken reports whether the connection has failed.
+func (c *conn) broken() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
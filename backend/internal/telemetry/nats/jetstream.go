@@ -0,0 +1,296 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+const (
+	// HeaderMsgID is JetStream's deduplication header: a stream drops a message whose ID it stored within its
+	// duplicate window (two minutes by default).
+	HeaderMsgID = "Nats-Msg-Id"
+
+	// DefaultAckWait is how long the server waits for an ack before redelivering a fetched message.
+	DefaultAckWait = 5 * time.Minute
+
+	requestTimeout = 10 * time.Second
+	fetchExpires   = 5 * time.Second
+)
+
+// apiError is the error of a JetStream API response.
+type apiError struct {
+	Code        int    `json:"code"`
+	ErrCode     int    `json:"err_code"`
+	Description string `json:"description"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("nats: jetstream: %s (code %d, err_code %d)", e.Description, e.Code, e.ErrCode)
+}
+
+// apiReply decodes a JetStream API reply into v, returning its error, if any.
+func apiReply(m *msg, what string, v any) error {
+	if m.status == 503 {
+		return fmt.Errorf("nats: %s: no responders (is JetStream enabled and does a stream capture the subject?)", what)
+	}
+	var resp struct {
+		Error *apiError `json:"error"`
+	}
+	if err := json.Unmarshal(m.data, &resp); err != nil {
+		return fmt.Errorf("nats: %s: %w", what, err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(m.data, v)
+}
+
+// connHolder keeps one connection, dialling on first use and again after it fails.
+type connHolder struct {
+	url string
+	mu  sync.Mutex
+	c   *conn
+}
+
+func (h *connHolder) get(ctx context.Context) (*conn, bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.c != nil && !h.c.broken() {
+		return h.c, false, nil
+	}
+	if h.c != nil {
+		h.c.close()
+		h.c = nil
+	}
+	c, err := dial(ctx, h.url)
+	if err != nil {
+		return nil, false, err
+	}
+	h.c = c
+	return c, true, nil
+}
+
+func (h *connHolder) close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.c == nil {
+		return nil
+	}
+	err := h.c.close()
+	h.c = nil
+	return err
+}
+
+// Sink publishes messages to a subject captured by a JetStream stream. Write returns once the stream has stored
+// every message. Messages with an ID carry it as Nats-Msg-Id, so a publish retried within the stream's duplicate
+// window is stored once.
+type Sink struct {
+	subject string
+	conn    connHolder
+}
+
+// NewSink returns a sink publishing to subject on the server at rawURL (nats://[user:pass@]host:port, or tls:// for
+// TLS; a user without a password is a token). The URL is checked here; the connection is made on first use.
+func NewSink(rawURL, subject string) (*Sink, error) {
+	if _, err := parseURL(rawURL); err != nil {
+		return nil, err
+	}
+	if subject == "" {
+		return nil, errors.New("subject is required")
+	}
+	return &Sink{subject: subject, conn: connHolder{url: rawURL}}, nil
+}
+
+// Write publishes msgs and waits for the stream's acks. Kafka keys are not carried: a stream keeps one order.
+func (s *Sink) Write(ctx context.Context, msgs ...telemetry.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	c, _, err := s.conn.get(ctx)
+	if err != nil {
+		return err
+	}
+	ps, err := c.request(len(msgs), func(w *bufio.Writer, i int, reply string) {
+		headers := msgs[i].Headers
+		if msgs[i].ID != "" {
+			headers = append(append([]telemetry.Header(nil), headers...), telemetry.Header{Key: HeaderMsgID, Value: []byte(msgs[i].ID)})
+		}
+		publish(w, s.subject, reply, headers, msgs[i].Value)
+	})
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, p := range ps {
+		if firstErr != nil {
+			p.cancel()
+			continue
+		}
+		m, err := p.wait(ctx, requestTimeout)
+		if err == nil {
+			err = apiReply(m, "publish to "+s.subject, nil)
+		}
+		firstErr = err
+	}
+	return firstErr
+}
+
+// Close closes the connection.
+func (s *Sink) Close() error {
+	return s.conn.close()
+}
+
+// Subscription pulls messages one at a time from a durable JetStream consumer with explicit acks. The consumer is
+// created on the first connection if it does not exist, delivering the whole stream filtered to the subject.
+type Subscription struct {
+	stream  string
+	durable string
+	subject string
+	ackWait time.Duration
+	conn    connHolder
+}
+
+// NewSubscription returns a subscription to subject in stream as durable on the server at rawURL (see NewSink). The
+// URL is checked here; the connection is made on first use.
+func NewSubscription(rawURL, stream, durable, subject string) (*Subscription, error) {
+	if _, err := parseURL(rawURL); err != nil {
+		return nil, err
+	}
+	if stream == "" || durable == "" || subject == "" {
+		return nil, errors.New("stream, durable and subject are required")
+	}
+	if strings.ContainsAny(stream+durable, ". *>") {
+		return nil, errors.New("stream and durable names must not contain '.', '*', '>' or spaces")
+	}
+	return &Subscription{stream: stream, durable: durable, subject: subject, ackWait: DefaultAckWait, conn: connHolder{url: rawURL}}, nil
+}
+
+// connect returns the connection, creating the consumer when it was just dialled.
+func (s *Subscription) connect(ctx context.Context) (*conn, error) {
+	c, fresh, err := s.conn.get(ctx)
+	if err != nil || !fresh {
+		return c, err
+	}
+	if err := s.createConsumer(ctx, c); err != nil {
+		s.conn.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *Subscription) createConsumer(ctx context.Context, c *conn) error {
+	body, _ := json.Marshal(map[string]any{
+		"stream_name": s.stream,
+		"config": map[string]any{
+			"durable_name":   s.durable,
+			"deliver_policy": "all",
+			"ack_policy":     "explicit",
+			"ack_wait":       s.ackWait.Nanoseconds(),
+			"filter_subject": s.subject,
+		},
+	})
+	ps, err := c.request(1, func(w *bufio.Writer, _ int, reply string) {
+		publish(w, "$JS.API.CONSUMER.DURABLE.CREATE."+s.stream+"."+s.durable, reply, nil, body)
+	})
+	if err != nil {
+		return err
+	}
+	m, err := ps[0].wait(ctx, requestTimeout)
+	if err != nil {
+		return err
+	}
+	return apiReply(m, "create consumer "+s.durable, nil)
+}
+
+// Fetch returns the next message, waiting until one is available or ctx is done.
+func (s *Subscription) Fetch(ctx context.Context) (telemetry.Message, error) {
+	for {
+		c, err := s.connect(ctx)
+		if err != nil {
+			return telemetry.Message{}, err
+		}
+		body, _ := json.Marshal(map[string]any{"batch": 1, "expires": fetchExpires.Nanoseconds()})
+		p, err := c.requestOwn(func(w *bufio.Writer, reply string) {
+			publish(w, "$JS.API.CONSUMER.MSG.NEXT."+s.stream+"."+s.durable, reply, nil, body)
+		})
+		if err != nil {
+			return telemetry.Message{}, err
+		}
+		m, err := p.wait(ctx, fetchExpires+requestTimeout)
+		if err != nil {
+			return telemetry.Message{}, err
+		}
+		switch m.status {
+		case 0:
+			return s.message(m), nil
+		case 404, 408:
+			continue // no message before the request expired
+		case 409:
+			// The consumer was deleted or changed; recreate it on a new connection.
+			s.conn.close()
+			return telemetry.Message{}, fmt.Errorf("nats: fetch: %d %s", m.status, m.desc)
+		default:
+			return telemetry.Message{}, fmt.Errorf("nats: fetch: %d %s", m.status, m.desc)
+		}
+	}
+}
+
+// message converts a delivered message. The stream sequence is taken from the ack subject,
+// $JS.ACK.<stream>.<consumer>.<delivered>.<stream seq>.<consumer seq>.<timestamp>.<pending>, which newer servers
+// prefix with a domain and an account hash.
+func (s *Subscription) message(m *msg) telemetry.Message {
+	out := telemetry.Message{Value: m.data, Origin: m.subject, AckToken: m.reply}
+	for _, h := range m.headers {
+		if h.Key == HeaderMsgID {
+			out.ID = string(h.Value)
+			continue
+		}
+		out.Headers = append(out.Headers, h)
+	}
+	tokens := strings.Split(m.reply, ".")
+	seq := ""
+	switch {
+	case len(tokens) == 9:
+		seq = tokens[5]
+	case len(tokens) >= 12:
+		seq = tokens[7]
+	}
+	out.Offset, _ = strconv.ParseInt(seq, 10, 64)
+	return out
+}
+
+// Ack acknowledges msg and waits for the server to confirm it, so an acked message is not redelivered.
+func (s *Subscription) Ack(ctx context.Context, msg telemetry.Message) error {
+	if msg.AckToken == "" {
+		return errors.New("nats: message has no ack subject")
+	}
+	c, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	ps, err := c.request(1, func(w *bufio.Writer, _ int, reply string) {
+		publish(w, msg.AckToken, reply, nil, []byte("+ACK"))
+	})
+	if err != nil {
+		return err
+	}
+	_, err = ps[0].wait(ctx, requestTimeout)
+	return err
+}
+
+// Close closes the connection. Messages fetched but not acked are redelivered after the ack wait.
+func (s *Subscription) Close() error {
+	return s.conn.close()
+}
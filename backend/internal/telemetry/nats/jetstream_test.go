@@ -0,0 +1,283 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// fakeJetStream is a NATS server with one stream: it stores published messages (dropping repeated Nats-Msg-Ids),
+// creates consumers, serves pull requests and records acks.
+type fakeJetStream struct {
+	ln        net.Listener
+	mu        sync.Mutex
+	connect   map[string]any
+	stored    []storedMsg
+	consumers map[string]map[string]any
+	delivered int   // index of the next message to deliver
+	acked     []int // stream sequences
+}
+
+type storedMsg struct {
+	subject string
+	header  string
+	data    string
+}
+
+func newFakeJetStream(t *testing.T) *fakeJetStream {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	f := &fakeJetStream{ln: ln, consumers: map[string]map[string]any{}}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeJetStream) url() string {
+	return "nats://ztcp:secret@" + f.ln.Addr().String()
+}
+
+func (f *fakeJetStream) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	var wmu sync.Mutex
+	sids := map[string]string{} // subject of each one-message subscription to its sid
+	send := func(format string, args ...any) {
+		wmu.Lock()
+		defer wmu.Unlock()
+		fmt.Fprintf(conn, format, args...)
+	}
+	send("INFO {\"server_id\":\"fake\",\"headers\":true,\"max_payload\":1048576}\r\n")
+	for {
+		line, err := readLine(rd)
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(line, " ")
+		fields := strings.Fields(args)
+		switch op {
+		case "CONNECT":
+			var opts map[string]any
+			_ = json.Unmarshal([]byte(args), &opts)
+			f.mu.Lock()
+			f.connect = opts
+			f.mu.Unlock()
+		case "PING":
+			send("PONG\r\n")
+		case "SUB":
+			if !strings.HasSuffix(fields[0], ".*") {
+				sids[fields[0]] = fields[1]
+			}
+		case "UNSUB":
+		case "PUB", "HPUB":
+			subject, reply := fields[0], ""
+			if len(fields) == 3 || (op == "HPUB" && len(fields) == 4) {
+				reply = fields[1]
+			}
+			total, _ := strconv.Atoi(fields[len(fields)-1])
+			hdrLen := 0
+			if op == "HPUB" {
+				hdrLen, _ = strconv.Atoi(fields[len(fields)-2])
+			}
+			buf := make([]byte, total+2)
+			if _, err := io.ReadFull(rd, buf); err != nil {
+				return
+			}
+			sid := sids[reply]
+			if sid == "" {
+				sid = "1"
+			}
+			f.handle(send, subject, reply, sid, string(buf[:hdrLen]), string(buf[hdrLen:total]))
+		}
+	}
+}
+
+func (f *fakeJetStream) handle(send func(string, ...any), subject, reply, sid, header, data string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case strings.HasPrefix(subject, "$JS.API.CONSUMER.DURABLE.CREATE.TELEMETRY."):
+		var req map[string]any
+		_ = json.Unmarshal([]byte(data), &req)
+		f.consumers[strings.TrimPrefix(subject, "$JS.API.CONSUMER.DURABLE.CREATE.TELEMETRY.")] = req["config"].(map[string]any)
+		send("MSG %s 1 2\r\n{}\r\n", reply)
+	case strings.HasPrefix(subject, "$JS.API.CONSUMER.MSG.NEXT.TELEMETRY."):
+		if f.delivered == len(f.stored) {
+			status := "NATS/1.0 408 Request Timeout\r\n\r\n"
+			send("HMSG %s %s %d %d\r\n%s\r\n", reply, sid, len(status), len(status), status)
+			return
+		}
+		m := f.stored[f.delivered]
+		f.delivered++
+		ack := fmt.Sprintf("$JS.ACK.TELEMETRY.worker.1.%d.%d.1727784000000000000.0", f.delivered, f.delivered)
+		// Pulled messages keep their subject and arrive on the sid of the reply subscription.
+		send("HMSG %s %s %s %d %d\r\n%s%s\r\n", m.subject, sid, ack, len(m.header), len(m.header)+len(m.data), m.header, m.data)
+	case strings.HasPrefix(subject, "$JS.ACK.TELEMETRY.worker."):
+		seq, _ := strconv.Atoi(strings.Split(subject, ".")[5])
+		f.acked = append(f.acked, seq)
+		if reply != "" {
+			send("MSG %s 1 0\r\n\r\n", reply)
+		}
+	case subject == "ztcp.telemetry" || subject == "ztcp.telemetry.dead-letter":
+		for _, m := range f.stored {
+			if strings.Contains(header, HeaderMsgID) && m.header == header {
+				dup := `{"stream":"TELEMETRY","seq":1,"duplicate":true}`
+				send("MSG %s 1 %d\r\n%s\r\n", reply, len(dup), dup)
+				return
+			}
+		}
+		f.stored = append(f.stored, storedMsg{subject: subject, header: header, data: data})
+		ack := fmt.Sprintf(`{"stream":"TELEMETRY","seq":%d}`, len(f.stored))
+		send("MSG %s 1 %d\r\n%s\r\n", reply, len(ack), ack)
+	default:
+		status := "NATS/1.0 503\r\n\r\n"
+		send("HMSG %s 1 %d %d\r\n%s\r\n", reply, len(status), len(status), status)
+	}
+}
+
+func TestJetStream_PublishFetchAck(t *testing.T) {
+	f := newFakeJetStream(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sink, err := NewSink(f.url(), "ztcp.telemetry")
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	defer sink.Close()
+	msgs := []telemetry.Message{
+		{Key: []byte("dev-1"), Value: []byte(`{"event_id":"a"}`), ID: "org-1:a", Headers: []telemetry.Header{{Key: "schema-version", Value: []byte("1")}}},
+		{Key: []byte("dev-1"), Value: []byte(`{"event_id":"b"}`), ID: "org-1:b"},
+	}
+	if err := sink.Write(ctx, msgs...); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := sink.Write(ctx, msgs[0]); err != nil { // a retried publish
+		t.Fatalf("Write retry: %v", err)
+	}
+	if len(f.stored) != 2 {
+		t.Fatalf("stored %d messages, want the retry dropped as a duplicate", len(f.stored))
+	}
+	if f.connect["user"] != "ztcp" || f.connect["pass"] != "secret" || f.connect["headers"] != true {
+		t.Errorf("CONNECT = %v", f.connect)
+	}
+
+	sub, err := NewSubscription(f.url(), "TELEMETRY", "worker", "ztcp.telemetry")
+	if err != nil {
+		t.Fatalf("NewSubscription: %v", err)
+	}
+	defer sub.Close()
+	got, err := sub.Fetch(ctx)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if string(got.Value) != `{"event_id":"a"}` || got.ID != "org-1:a" || got.Origin != "ztcp.telemetry" || got.Offset != 1 {
+		t.Errorf("Fetch = %+v", got)
+	}
+	if len(got.Headers) != 1 || got.Headers[0].Key != "schema-version" || string(got.Headers[0].Value) != "1" {
+		t.Errorf("headers = %v, want schema-version without Nats-Msg-Id", got.Headers)
+	}
+	if err := sub.Ack(ctx, got); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	cfg := f.consumers["worker"]
+	if cfg["ack_policy"] != "explicit" || cfg["filter_subject"] != "ztcp.telemetry" || cfg["durable_name"] != "worker" {
+		t.Errorf("consumer config = %v", cfg)
+	}
+
+	got, err = sub.Fetch(ctx)
+	if err != nil || got.Offset != 2 {
+		t.Fatalf("second Fetch = %+v, %v", got, err)
+	}
+	if fmt.Sprint(f.acked) != "[1]" {
+		t.Errorf("acked = %v", f.acked)
+	}
+}
+
+func TestJetStream_FetchWaitsPastExpiredRequests(t *testing.T) {
+	f := newFakeJetStream(t)
+	sub, _ := NewSubscription(f.url(), "TELEMETRY", "worker", "ztcp.telemetry")
+	defer sub.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	// The fake answers every pull request with 408, so Fetch keeps asking until ctx is done.
+	if _, err := sub.Fetch(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Fetch on an empty stream = %v, want the context's error", err)
+	}
+}
+
+func TestJetStream_NoStream(t *testing.T) {
+	f := newFakeJetStream(t)
+	sink, _ := NewSink(f.url(), "other.subject")
+	defer sink.Close()
+	err := sink.Write(context.Background(), telemetry.Message{Value: []byte("{}")})
+	if err == nil || !strings.Contains(err.Error(), "no responders") {
+		t.Errorf("Write to an uncaptured subject = %v, want no responders", err)
+	}
+}
+
+func TestJetStream_Redials(t *testing.T) {
+	f := newFakeJetStream(t)
+	sink, _ := NewSink(f.url(), "ztcp.telemetry")
+	defer sink.Close()
+	ctx := context.Background()
+	if err := sink.Write(ctx, telemetry.Message{Value: []byte("1")}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	sink.conn.c.nc.Close() // the server went away
+	<-sink.conn.c.done
+	if err := sink.Write(ctx, telemetry.Message{Value: []byte("2")}); err != nil {
+		t.Fatalf("Write after redial: %v", err)
+	}
+	if len(f.stored) != 2 {
+		t.Errorf("stored %d messages, want 2", len(f.stored))
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	o, err := parseURL("tls://s3cr3t@nats.internal")
+	if err != nil || o.addr != "nats.internal:4222" || !o.useTLS || o.token != "s3cr3t" || o.user != "" {
+		t.Errorf("parseURL(token) = %+v, %v", o, err)
+	}
+	for _, u := range []string{"http://nats:4222", "nats://", "::"} {
+		if _, err := parseURL(u); err == nil {
+			t.Errorf("parseURL(%q) should fail", u)
+		}
+	}
+	if _, err := NewSubscription("nats://nats", "TELE.METRY", "worker", "ztcp.telemetry"); err == nil {
+		t.Error("a stream name with a dot should be refused")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	var m msg
+	if err := parseHeaders([]byte("NATS/1.0 404 No Messages\r\n\r\n"), &m); err != nil || m.status != 404 || m.desc != "No Messages" {
+		t.Errorf("status = %d %q, %v", m.status, m.desc, err)
+	}
+	m = msg{}
+	hdr := encodeHeaders([]telemetry.Header{{Key: "dead-letter-reason", Value: []byte("bad\r\nvalue")}})
+	if err := parseHeaders(hdr, &m); err != nil || len(m.headers) != 1 || string(m.headers[0].Value) != "bad  value" {
+		t.Errorf("round trip = %v, %v", m.headers, err)
+	}
+}
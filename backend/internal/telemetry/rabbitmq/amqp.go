@@ -0,0 +1,720 @@
+// Package rabbitmq carries telemetry over RabbitMQ: a Sink that publishes persistent messages with publisher confirms,
+// and a Subscription that consumes a durable queue with manual acks. It speaks AMQP 0-9-1 itself and implements only
+// what these two need: PLAIN auth, TLS, heartbeats, one channel, exchange and queue declaration, publish, consume and
+// ack.
+package rabbitmq
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// Frame types.
+const (
+	frameMethod    = 1
+	frameHeader    = 2
+	frameBody      = 3
+	frameHeartbeat = 8
+	frameEnd       = 0xCE
+)
+
+// Class and method IDs.
+const (
+	classConnection = 10
+	classChannel    = 20
+	classExchange   = 40
+	classQueue      = 50
+	classBasic      = 60
+	classConfirm    = 85
+)
+
+const (
+	maxFrameSize = 131072
+	channelID    = 1
+)
+
+var errClosed = errors.New("rabbitmq: connection closed")
+
+// options are the connection settings taken from an amqp:// or amqps:// URL.
+type options struct {
+	addr   string
+	host   string
+	useTLS bool
+	user   string
+	pass   string
+	vhost  string
+}
+
+func parseURL(rawURL string) (options, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return options{}, err
+	}
+	if u.Scheme != "amqp" && u.Scheme != "amqps" {
+		return options{}, fmt.Errorf("scheme must be amqp or amqps, got %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return options{}, errors.New("host is required")
+	}
+	o := options{host: u.Hostname(), useTLS: u.Scheme == "amqps", user: "guest", pass: "guest", vhost: "/"}
+	port := u.Port()
+	if port == "" {
+		port = "5672"
+		if o.useTLS {
+			port = "5671"
+		}
+	}
+	o.addr = net.JoinHostPort(o.host, port)
+	if u.User != nil {
+		o.user = u.User.Username()
+		o.pass, _ = u.User.Password()
+	}
+	if len(u.Path) > 1 {
+		o.vhost = u.Path[1:]
+	}
+	return o, nil
+}
+
+// method is a method frame's class, method and arguments.
+type method struct {
+	class, id uint16
+	args      []byte
+}
+
+// delivery is a message delivered to the consumer.
+type delivery struct {
+	tag       uint64
+	messageID string
+	headers   []telemetry.Header
+	body      []byte
+	size      uint64
+}
+
+// confirm is a publisher confirm: the broker's ack or nack of the publishes up to tag (of tag alone unless multiple).
+type confirm struct {
+	tag      uint64
+	multiple bool
+	nack     bool
+}
+
+// conn is one connection with one channel. Synchronous replies, confirms and deliveries are handed from the read
+// loop over channels; any protocol error closes the connection, and callers dial again.
+type conn struct {
+	nc        net.Conn
+	rd        *bufio.Reader
+	wmu       sync.Mutex
+	w         *bufio.Writer
+	frameMax  uint32
+	heartbeat time.Duration
+
+	methods    chan method
+	confirms   chan confirm
+	deliveries chan *delivery
+
+	mu   sync.Mutex
+	err  error
+	done chan struct{}
+}
+
+// dial connects to rawURL, authenticates and opens channel 1. prefetch sizes the delivery buffer.
+func dial(ctx context.Context, rawURL string, prefetch int) (*conn, error) {
+	o, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", o.addr)
+	if err != nil {
+		return nil, err
+	}
+	if o.useTLS {
+		tc := tls.Client(nc, &tls.Config{ServerName: o.host, MinVersion: tls.VersionTLS12})
+		nc = tc
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = nc.SetDeadline(deadline)
+	} else {
+		_ = nc.SetDeadline(time.Now().Add(10 * time.Second))
+	}
+	c := &conn{
+		nc:         nc,
+		rd:         bufio.NewReader(nc),
+		w:          bufio.NewWriter(nc),
+		methods:    make(chan method, 1),
+		confirms:   make(chan confirm, 64),
+		deliveries: make(chan *delivery, prefetch),
+		done:       make(chan struct{}),
+	}
+	if err := c.handshake(o); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	_ = nc.SetDeadline(time.Time{})
+	go c.readLoop()
+	if c.heartbeat > 0 {
+		go c.heartbeatLoop()
+	}
+	if _, err := c.call(ctx, classChannel, 10, classChannel, 11, func(b *encoder) { b.shortstr("") }); err != nil {
+		c.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// handshake runs the connection negotiation, reading frames directly since the read loop is not running yet.
+func (c *conn) handshake(o options) error {
+	if _, err := c.w.WriteString("AMQP\x00\x00\x09\x01"); err != nil {
+		return err
+	}
+	if err := c.w.Flush(); err != nil {
+		return err
+	}
+	m, err := c.expect(classConnection, 10)
+	if err != nil {
+		return err
+	}
+	d := decoder{b: m.args}
+	d.octet()
+	d.octet()
+	d.skipTable()
+	mechanisms := d.longstr()
+	if d.err != nil {
+		return d.err
+	}
+	if !strings.Contains(" "+mechanisms+" ", " PLAIN ") {
+		return fmt.Errorf("rabbitmq: server does not offer PLAIN authentication (offers %q)", mechanisms)
+	}
+	err = c.send(classConnection, 11, func(b *encoder) {
+		b.table([]telemetry.Header{{Key: "product", Value: []byte("ztcp-telemetry")}})
+		b.shortstr("PLAIN")
+		b.longstr("\x00" + o.user + "\x00" + o.pass)
+		b.shortstr("en_US")
+	})
+	if err != nil {
+		return err
+	}
+	m, err = c.expect(classConnection, 30)
+	if err != nil {
+		return err
+	}
+	d = decoder{b: m.args}
+	d.short()
+	frameMax := d.long()
+	heartbeat := d.short()
+	if d.err != nil {
+		return d.err
+	}
+	c.frameMax = maxFrameSize
+	if frameMax != 0 && frameMax < c.frameMax {
+		c.frameMax = frameMax
+	}
+	c.heartbeat = time.Duration(heartbeat) * time.Second
+	err = c.send(classConnection, 31, func(b *encoder) {
+		b.short(1)
+		b.long(c.frameMax)
+		b.short(heartbeat)
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.send(classConnection, 40, func(b *encoder) { b.shortstr(o.vhost); b.shortstr(""); b.octet(0) }); err != nil {
+		return err
+	}
+	_, err = c.expect(classConnection, 41)
+	return err
+}
+
+// expect reads frames until a method arrives and checks that it is class.id; a Connection.Close is returned as an
+// error.
+func (c *conn) expect(class, id uint16) (method, error) {
+	for {
+		typ, _, payload, err := readFrame(c.rd)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return method{}, errors.New("rabbitmq: connection closed during handshake (check the credentials and vhost)")
+			}
+			return method{}, err
+		}
+		if typ != frameMethod {
+			continue
+		}
+		m, err := parseMethod(payload)
+		if err != nil {
+			return method{}, err
+		}
+		if m.class == classConnection && m.id == 50 {
+			return method{}, closeReason(m)
+		}
+		if m.class != class || m.id != id {
+			return method{}, fmt.Errorf("rabbitmq: expected method %d.%d, got %d.%d", class, id, m.class, m.id)
+		}
+		return m, nil
+	}
+}
+
+// closeReason returns the error of a Connection.Close or Channel.Close.
+func closeReason(m method) error {
+	d := decoder{b: m.args}
+	code := d.short()
+	text := d.shortstr()
+	return fmt.Errorf("rabbitmq: %s (%d)", text, code)
+}
+
+func parseMethod(payload []byte) (method, error) {
+	if len(payload) < 4 {
+		return method{}, errors.New("rabbitmq: short method frame")
+	}
+	return method{class: binary.BigEndian.Uint16(payload), id: binary.BigEndian.Uint16(payload[2:]), args: payload[4:]}, nil
+}
+
+func readFrame(rd *bufio.Reader) (typ byte, channel uint16, payload []byte, err error) {
+	var h [7]byte
+	if _, err := io.ReadFull(rd, h[:]); err != nil {
+		return 0, 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(h[3:])
+	if size > maxFrameSize {
+		return 0, 0, nil, fmt.Errorf("rabbitmq: frame of %d bytes exceeds the negotiated maximum", size)
+	}
+	payload = make([]byte, size+1)
+	if _, err := io.ReadFull(rd, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	if payload[size] != frameEnd {
+		return 0, 0, nil, errors.New("rabbitmq: malformed frame")
+	}
+	return h[0], binary.BigEndian.Uint16(h[1:]), payload[:size], nil
+}
+
+func writeFrame(w *bufio.Writer, typ byte, channel uint16, payload []byte) {
+	var h [7]byte
+	h[0] = typ
+	binary.BigEndian.PutUint16(h[1:], channel)
+	binary.BigEndian.PutUint32(h[3:], uint32(len(payload)))
+	w.Write(h[:])
+	w.Write(payload)
+	w.WriteByte(frameEnd)
+}
+
+func methodPayload(class, id uint16, args func(b *encoder)) []byte {
+	var b encoder
+	b.short(class)
+	b.short(id)
+	if args != nil {
+		args(&b)
+	}
+	return b.Bytes()
+}
+
+// send writes one method frame: on channel 0 for connection methods, on channel 1 otherwise.
+func (c *conn) send(class, id uint16, args func(b *encoder)) error {
+	channel := uint16(channelID)
+	if class == classConnection {
+		channel = 0
+	}
+	return c.write(func(w *bufio.Writer) { writeFrame(w, frameMethod, channel, methodPayload(class, id, args)) })
+}
+
+func (c *conn) write(fn func(w *bufio.Writer)) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	fn(c.w)
+	return c.w.Flush()
+}
+
+// call sends a synchronous method and waits for its reply, replyClass.replyID.
+func (c *conn) call(ctx context.Context, class, id, replyClass, replyID uint16, args func(b *encoder)) (method, error) {
+	if err := c.send(class, id, args); err != nil {
+		return method{}, err
+	}
+	t := time.NewTimer(10 * time.Second)
+	defer t.Stop()
+	select {
+	case m := <-c.methods:
+		if m.class != replyClass || m.id != replyID {
+			c.close()
+			return method{}, fmt.Errorf("rabbitmq: expected method %d.%d, got %d.%d", replyClass, replyID, m.class, m.id)
+		}
+		return m, nil
+	case <-c.done:
+		return method{}, c.failure()
+	case <-ctx.Done():
+		c.close()
+		return method{}, ctx.Err()
+	case <-t.C:
+		c.close()
+		return method{}, errors.New("rabbitmq: timed out waiting for the broker")
+	}
+}
+
+// publish writes Basic.Publish with its content header and body frames as one unit.
+func (c *conn) publish(exchange, routingKey string, msg telemetry.Message) error {
+	header := func(b *encoder) {
+		flags := uint16(1<<15 | 1<<13 | 1<<12) // content-type, headers, delivery-mode
+		if msg.ID != "" {
+			flags |= 1 << 7 // message-id
+		}
+		b.short(classBasic)
+		b.short(0)
+		b.longlong(uint64(len(msg.Value)))
+		b.short(flags)
+		b.shortstr("application/json")
+		b.table(msg.Headers)
+		b.octet(2) // persistent
+		if msg.ID != "" {
+			b.shortstr(msg.ID)
+		}
+	}
+	return c.write(func(w *bufio.Writer) {
+		writeFrame(w, frameMethod, channelID, methodPayload(classBasic, 40, func(b *encoder) {
+			b.short(0)
+			b.shortstr(exchange)
+			b.shortstr(routingKey)
+			b.octet(0) // not mandatory, not immediate
+		}))
+		var hb encoder
+		header(&hb)
+		writeFrame(w, frameHeader, channelID, hb.Bytes())
+		chunk := int(c.frameMax) - 8
+		for body := msg.Value; len(body) > 0; {
+			n := min(chunk, len(body))
+			writeFrame(w, frameBody, channelID, body[:n])
+			body = body[n:]
+		}
+	})
+}
+
+// readLoop reads frames until the connection fails, routing them to the methods, confirms and deliveries channels.
+func (c *conn) readLoop() {
+	err := c.read()
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+	c.nc.Close()
+	close(c.done)
+}
+
+func (c *conn) read() error {
+	var current *delivery
+	for {
+		if c.heartbeat > 0 {
+			// Two missed heartbeats mean the broker is gone.
+			_ = c.nc.SetReadDeadline(time.Now().Add(2 * c.heartbeat))
+		}
+		typ, _, payload, err := readFrame(c.rd)
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case frameHeartbeat:
+		case frameMethod:
+			m, err := parseMethod(payload)
+			if err != nil {
+				return err
+			}
+			d := decoder{b: m.args}
+			switch {
+			case m.class == classConnection && m.id == 50:
+				_ = c.send(classConnection, 51, nil)
+				return closeReason(m)
+			case m.class == classChannel && m.id == 40:
+				_ = c.send(classChannel, 41, nil)
+				return closeReason(m)
+			case m.class == classBasic && (m.id == 80 || m.id == 120):
+				tag := d.longlong()
+				c.confirms <- confirm{tag: tag, multiple: d.octet()&1 != 0, nack: m.id == 120}
+			case m.class == classBasic && m.id == 60:
+				d.shortstr() // consumer tag
+				current = &delivery{tag: d.longlong()}
+			case m.class == classBasic && m.id == 30:
+				return errors.New("rabbitmq: the broker cancelled the consumer (was the queue deleted?)")
+			default:
+				select {
+				case c.methods <- m:
+				default:
+					return fmt.Errorf("rabbitmq: unexpected method %d.%d", m.class, m.id)
+				}
+			}
+			if d.err != nil {
+				return d.err
+			}
+		case frameHeader:
+			if current == nil {
+				return errors.New("rabbitmq: content header without a delivery")
+			}
+			if err := parseProperties(payload, current); err != nil {
+				return err
+			}
+			if current.size == 0 {
+				c.deliveries <- current
+				current = nil
+			}
+		case frameBody:
+			if current == nil {
+				return errors.New("rabbitmq: content body without a delivery")
+			}
+			current.body = append(current.body, payload...)
+			if uint64(len(current.body)) >= current.size {
+				c.deliveries <- current
+				current = nil
+			}
+		}
+	}
+}
+
+// parseProperties reads a content header: class, weight, body size and the basic properties, keeping the headers and
+// message-id.
+func parseProperties(payload []byte, dl *delivery) error {
+	d := decoder{b: payload}
+	d.short()
+	d.short()
+	dl.size = d.longlong()
+	flags := d.short()
+	if flags&1 != 0 {
+		return errors.New("rabbitmq: unsupported property flags")
+	}
+	for bit := 15; bit >= 2; bit-- {
+		if flags&(1<<bit) == 0 {
+			continue
+		}
+		switch bit {
+		case 13:
+			dl.headers = d.table()
+		case 12, 11: // delivery-mode, priority
+			d.octet()
+		case 7:
+			dl.messageID = d.shortstr()
+		case 6: // timestamp
+			d.longlong()
+		default:
+			d.shortstr()
+		}
+	}
+	return d.err
+}
+
+func (c *conn) heartbeatLoop() {
+	t := time.NewTicker(c.heartbeat / 2)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-t.C:
+			if err := c.write(func(w *bufio.Writer) { writeFrame(w, frameHeartbeat, 0, nil) }); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *conn) failure() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil || errors.Is(c.err, net.ErrClosed) {
+		return errClosed
+	}
+	return c.err
+}
+
+// close closes the connection without the closing handshake; the broker requeues unacked deliveries.
+func (c *conn) close() error {
+	err := c.nc.Close()
+	<-c.done
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func (c *conn) broken() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// encoder writes AMQP field types.
+type encoder struct {
+	bytes.Buffer
+}
+
+func (b *encoder) octet(v byte) { b.WriteByte(v) }
+
+func (b *encoder) short(v uint16) { b.Write(binary.BigEndian.AppendUint16(nil, v)) }
+
+func (b *encoder) long(v uint32) { b.Write(binary.BigEndian.AppendUint32(nil, v)) }
+
+func (b *encoder) longlong(v uint64) { b.Write(binary.BigEndian.AppendUint64(nil, v)) }
+
+// shortstr writes s, cut to the 255 bytes a short string holds.
+func (b *encoder) shortstr(s string) {
+	if len(s) > math.MaxUint8 {
+		s = s[:math.MaxUint8]
+	}
+	b.octet(byte(len(s)))
+	b.WriteString(s)
+}
+
+func (b *encoder) longstr(s string) {
+	b.long(uint32(len(s)))
+	b.WriteString(s)
+}
+
+// table writes headers as a field table of long strings.
+func (b *encoder) table(headers []telemetry.Header) {
+	var t encoder
+	for _, h := range headers {
+		t.shortstr(h.Key)
+		t.octet('S')
+		t.longstr(string(h.Value))
+	}
+	b.long(uint32(t.Len()))
+	b.Write(t.Bytes())
+}
+
+// decoder reads AMQP field types, remembering the first error.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.b) < n {
+		d.err = errors.New("rabbitmq: truncated frame")
+		return nil
+	}
+	v := d.b[:n]
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) octet() byte {
+	if v := d.take(1); v != nil {
+		return v[0]
+	}
+	return 0
+}
+
+func (d *decoder) short() uint16 {
+	if v := d.take(2); v != nil {
+		return binary.BigEndian.Uint16(v)
+	}
+	return 0
+}
+
+func (d *decoder) long() uint32 {
+	if v := d.take(4); v != nil {
+		return binary.BigEndian.Uint32(v)
+	}
+	return 0
+}
+
+func (d *decoder) longlong() uint64 {
+	if v := d.take(8); v != nil {
+		return binary.BigEndian.Uint64(v)
+	}
+	return 0
+}
+
+func (d *decoder) shortstr() string {
+	return string(d.take(int(d.octet())))
+}
+
+func (d *decoder) longstr() string {
+	return string(d.take(int(d.long())))
+}
+
+func (d *decoder) skipTable() {
+	d.take(int(d.long()))
+}
+
+// table reads a field table as headers. Values that are not strings are formatted with fmt.
+func (d *decoder) table() []telemetry.Header {
+	t := decoder{b: d.take(int(d.long()))}
+	var headers []telemetry.Header
+	for d.err == nil && t.err == nil && len(t.b) > 0 {
+		key := t.shortstr()
+		v := t.value()
+		if s, ok := v.(string); ok {
+			headers = append(headers, telemetry.Header{Key: key, Value: []byte(s)})
+		} else {
+			headers = append(headers, telemetry.Header{Key: key, Value: []byte(fmt.Sprint(v))})
+		}
+	}
+	if d.err == nil {
+		d.err = t.err
+	}
+	return headers
+}
+
+// value reads one typed field value, using RabbitMQ's type tags.
+func (d *decoder) value() any {
+	switch tag := d.octet(); tag {
+	case 't':
+		return d.octet() != 0
+	case 'b':
+		return int8(d.octet())
+	case 'B':
+		return d.octet()
+	case 's':
+		return int16(d.short())
+	case 'u':
+		return d.short()
+	case 'I':
+		return int32(d.long())
+	case 'i':
+		return d.long()
+	case 'l':
+		return int64(d.longlong())
+	case 'f':
+		return math.Float32frombits(d.long())
+	case 'd':
+		return math.Float64frombits(d.longlong())
+	case 'D':
+		scale := d.octet()
+		return fmt.Sprintf("%de-%d", int32(d.long()), scale)
+	case 'S', 'x':
+		return d.longstr()
+	case 'T':
+		return time.Unix(int64(d.longlong()), 0).UTC()
+	case 'F':
+		return d.table()
+	case 'A':
+		a := decoder{b: d.take(int(d.long()))}
+		var values []any
+		for a.err == nil && len(a.b) > 0 {
+			values = append(values, a.value())
+		}
+		if d.err == nil {
+			d.err = a.err
+		}
+		return values
+	case 'V':
+		return nil
+	default:
+		if d.err == nil {
+			d.err = fmt.Errorf("rabbitmq: unknown field type %q", tag)
+		}
+		return nil
+	}
+}
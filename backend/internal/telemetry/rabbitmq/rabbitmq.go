@@ -0,0 +1,309 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+const (
+	// DefaultPrefetch is how many unacked deliveries the broker sends a subscription ahead of its acks.
+	DefaultPrefetch = 16
+
+	confirmTimeout = 10 * time.Second
+)
+
+// declareExchange declares a durable topic exchange; declaring an existing one with the same type is a no-op.
+func declareExchange(ctx context.Context, c *conn, exchange string) error {
+	_, err := c.call(ctx, classExchange, 10, classExchange, 11, func(b *encoder) {
+		b.short(0)
+		b.shortstr(exchange)
+		b.shortstr("topic")
+		b.octet(1 << 1) // durable
+		b.table(nil)
+	})
+	return err
+}
+
+// declareQueue declares a durable queue.
+func declareQueue(ctx context.Context, c *conn, queue string) error {
+	_, err := c.call(ctx, classQueue, 10, classQueue, 11, func(b *encoder) {
+		b.short(0)
+		b.shortstr(queue)
+		b.octet(1 << 1) // durable
+		b.table(nil)
+	})
+	return err
+}
+
+// Sink publishes persistent messages with publisher confirms. Write returns once the broker has confirmed every
+// message, which for a durable queue means it is on disk.
+type Sink struct {
+	url      string
+	exchange string // empty for the default exchange
+	queue    string // set for a queue sink, the routing key on the default exchange
+
+	mu  sync.Mutex
+	c   *conn
+	seq uint64 // publish sequence number of the current connection
+}
+
+// NewExchangeSink returns a sink publishing to exchange, a durable topic exchange declared on first use, with each
+// message's event-type header as the routing key. rawURL is amqp://[user:pass@]host[:port][/vhost], or amqps:// for
+// TLS; the URL is checked here and the connection is made on first use.
+func NewExchangeSink(rawURL, exchange string) (*Sink, error) {
+	if _, err := parseURL(rawURL); err != nil {
+		return nil, err
+	}
+	if exchange == "" {
+		return nil, errors.New("exchange is required")
+	}
+	return &Sink{url: rawURL, exchange: exchange}, nil
+}
+
+// NewQueueSink returns a sink publishing straight to queue, a durable queue declared on first use. It suits dead
+// letters, which have no consumer group to fan out to.
+func NewQueueSink(rawURL, queue string) (*Sink, error) {
+	if _, err := parseURL(rawURL); err != nil {
+		return nil, err
+	}
+	if queue == "" {
+		return nil, errors.New("queue is required")
+	}
+	return &Sink{url: rawURL, queue: queue}, nil
+}
+
+// connect returns the connection, dialling and declaring the destination when there is none.
+func (s *Sink) connect(ctx context.Context) (*conn, error) {
+	if s.c != nil && !s.c.broken() {
+		return s.c, nil
+	}
+	if s.c != nil {
+		s.c.close()
+		s.c = nil
+	}
+	c, err := dial(ctx, s.url, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.call(ctx, classConfirm, 10, classConfirm, 11, func(b *encoder) { b.octet(0) }); err == nil {
+		if s.queue != "" {
+			err = declareQueue(ctx, c, s.queue)
+		} else {
+			err = declareExchange(ctx, c, s.exchange)
+		}
+	}
+	if err != nil {
+		c.close()
+		return nil, err
+	}
+	s.c, s.seq = c, 0
+	return c, nil
+}
+
+// Write publishes msgs and waits for the broker's confirms. A nack, a timeout or a closed channel fails the write and
+// the connection, which is dialled again on the next write.
+func (s *Sink) Write(ctx context.Context, msgs ...telemetry.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	outstanding := make(map[uint64]bool, len(msgs))
+	for _, m := range msgs {
+		routingKey := s.queue
+		if s.exchange != "" {
+			routingKey = header(m.Headers, telemetry.HeaderEventType)
+		}
+		if err := c.publish(s.exchange, routingKey, m); err != nil {
+			c.close()
+			return err
+		}
+		s.seq++
+		outstanding[s.seq] = true
+	}
+	t := time.NewTimer(confirmTimeout)
+	defer t.Stop()
+	for len(outstanding) > 0 {
+		select {
+		case cf := <-c.confirms:
+			for tag := range outstanding {
+				if tag == cf.tag || (cf.multiple && tag < cf.tag) {
+					if cf.nack {
+						c.close()
+						return fmt.Errorf("rabbitmq: broker refused message %d", tag)
+					}
+					delete(outstanding, tag)
+				}
+			}
+		case <-c.done:
+			return c.failure()
+		case <-ctx.Done():
+			c.close()
+			return ctx.Err()
+		case <-t.C:
+			c.close()
+			return errors.New("rabbitmq: timed out waiting for publisher confirms")
+		}
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.c == nil {
+		return nil
+	}
+	err := s.c.close()
+	s.c = nil
+	return err
+}
+
+func header(headers []telemetry.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Subscription consumes a durable queue bound to a topic exchange with manual acks. The exchange, the queue and a
+// binding of every routing key are declared on each connection. Deliveries not acked when a connection is lost are
+// requeued by the broker.
+type Subscription struct {
+	url      string
+	exchange string
+	queue    string
+	prefetch int
+
+	mu         sync.Mutex
+	c          *conn
+	generation int // counts connections; a delivery can be acked only on the one it arrived on
+}
+
+// NewSubscription returns a subscription to queue, bound to exchange, on the broker at rawURL (see NewExchangeSink).
+// The URL is checked here; the connection is made on first use.
+func NewSubscription(rawURL, exchange, queue string) (*Subscription, error) {
+	if _, err := parseURL(rawURL); err != nil {
+		return nil, err
+	}
+	if exchange == "" || queue == "" {
+		return nil, errors.New("exchange and queue are required")
+	}
+	return &Subscription{url: rawURL, exchange: exchange, queue: queue, prefetch: DefaultPrefetch}, nil
+}
+
+func (s *Subscription) connect(ctx context.Context) (*conn, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.c != nil && !s.c.broken() {
+		return s.c, s.generation, nil
+	}
+	if s.c != nil {
+		s.c.close()
+		s.c = nil
+	}
+	c, err := dial(ctx, s.url, s.prefetch)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = declareExchange(ctx, c, s.exchange)
+	if err == nil {
+		err = declareQueue(ctx, c, s.queue)
+	}
+	if err == nil {
+		_, err = c.call(ctx, classQueue, 20, classQueue, 21, func(b *encoder) {
+			b.short(0)
+			b.shortstr(s.queue)
+			b.shortstr(s.exchange)
+			b.shortstr("#")
+			b.octet(0)
+			b.table(nil)
+		})
+	}
+	if err == nil {
+		_, err = c.call(ctx, classBasic, 10, classBasic, 11, func(b *encoder) {
+			b.long(0)
+			b.short(uint16(s.prefetch))
+			b.octet(0)
+		})
+	}
+	if err == nil {
+		_, err = c.call(ctx, classBasic, 20, classBasic, 21, func(b *encoder) {
+			b.short(0)
+			b.shortstr(s.queue)
+			b.shortstr("") // the broker picks the consumer tag
+			b.octet(0)     // manual acks
+			b.table(nil)
+		})
+	}
+	if err != nil {
+		c.close()
+		return nil, 0, err
+	}
+	s.c = c
+	s.generation++
+	return c, s.generation, nil
+}
+
+// Fetch returns the next delivery, waiting until one arrives or ctx is done.
+func (s *Subscription) Fetch(ctx context.Context) (telemetry.Message, error) {
+	c, generation, err := s.connect(ctx)
+	if err != nil {
+		return telemetry.Message{}, err
+	}
+	select {
+	case dl := <-c.deliveries:
+		return telemetry.Message{
+			Value:    dl.body,
+			ID:       dl.messageID,
+			Headers:  dl.headers,
+			Origin:   s.queue,
+			Offset:   int64(dl.tag),
+			AckToken: strconv.Itoa(generation),
+		}, nil
+	case <-c.done:
+		return telemetry.Message{}, c.failure()
+	case <-ctx.Done():
+		return telemetry.Message{}, ctx.Err()
+	}
+}
+
+// Ack acknowledges msg. A delivery from an earlier connection cannot be acked: the broker has requeued it and
+// delivers it again.
+func (s *Subscription) Ack(ctx context.Context, msg telemetry.Message) error {
+	s.mu.Lock()
+	c, generation := s.c, s.generation
+	s.mu.Unlock()
+	if c == nil || c.broken() || msg.AckToken != strconv.Itoa(generation) {
+		return errors.New("rabbitmq: the delivery's connection was lost; the broker redelivers it")
+	}
+	return c.send(classBasic, 80, func(b *encoder) {
+		b.longlong(uint64(msg.Offset))
+		b.octet(0)
+	})
+}
+
+// Close closes the connection. Unacked deliveries are requeued.
+func (s *Subscription) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.c == nil {
+		return nil
+	}
+	err := s.c.close()
+	s.c = nil
+	return err
+}
@@ -0,0 +1,363 @@
+package rabbitmq
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// fakeBroker is an AMQP 0-9-1 broker with durable topic exchanges, queues bound with "#", publisher confirms and one
+// consumer per queue.
+type fakeBroker struct {
+	ln        net.Listener
+	mu        sync.Mutex
+	auth      string
+	vhost     string
+	bindings  map[string][]string // exchange to queues
+	queues    map[string][]published
+	consumers map[string]func() // queue to its consumer's delivery function
+	acked     []uint64
+	nack      bool // nack publishes instead of confirming them
+}
+
+type published struct {
+	routingKey string
+	header     []byte // content header payload
+	body       []byte
+}
+
+func newFakeBroker(t *testing.T) *fakeBroker {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	b := &fakeBroker{ln: ln, bindings: map[string][]string{}, queues: map[string][]published{}, consumers: map[string]func(){}}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+func (b *fakeBroker) url() string {
+	return "amqp://ztcp:secret@" + b.ln.Addr().String() + "/telemetry"
+}
+
+func (b *fakeBroker) serve(nc net.Conn) {
+	defer nc.Close()
+	rd := bufio.NewReader(nc)
+	w := bufio.NewWriter(nc)
+	var wmu sync.Mutex
+	reply := func(channel uint16, class, id uint16, args func(e *encoder)) {
+		wmu.Lock()
+		defer wmu.Unlock()
+		writeFrame(w, frameMethod, channel, methodPayload(class, id, args))
+		w.Flush()
+	}
+	proto := make([]byte, 8)
+	if _, err := io.ReadFull(rd, proto); err != nil || string(proto) != "AMQP\x00\x00\x09\x01" {
+		return
+	}
+	reply(0, classConnection, 10, func(e *encoder) {
+		e.octet(0)
+		e.octet(9)
+		e.table([]telemetry.Header{{Key: "product", Value: []byte("fake")}})
+		e.longstr("AMQPLAIN PLAIN")
+		e.longstr("en_US")
+	})
+
+	var (
+		publish   *published
+		exchange  string
+		seq       uint64
+		consuming string
+		tag       uint64
+	)
+	// deliver sends the consumed queue's messages; called with b.mu held.
+	deliver := func() {
+		msgs := b.queues[consuming]
+		b.queues[consuming] = nil
+		wmu.Lock()
+		defer wmu.Unlock()
+		for _, m := range msgs {
+			tag++
+			writeFrame(w, frameMethod, 1, methodPayload(classBasic, 60, func(e *encoder) {
+				e.shortstr("ctag")
+				e.longlong(tag)
+				e.octet(0)
+				e.shortstr("")
+				e.shortstr(m.routingKey)
+			}))
+			writeFrame(w, frameHeader, 1, m.header)
+			writeFrame(w, frameBody, 1, m.body)
+		}
+		w.Flush()
+	}
+	for {
+		typ, channel, payload, err := readFrame(rd)
+		if err != nil {
+			return
+		}
+		switch typ {
+		case frameHeader:
+			publish.header = payload
+			continue
+		case frameBody:
+			publish.body = append(publish.body, payload...)
+			if len(publish.body) < int((&decoder{b: publish.header[4:]}).longlong()) {
+				continue
+			}
+			b.mu.Lock()
+			queues := b.bindings[exchange]
+			if exchange == "" {
+				queues = []string{publish.routingKey}
+			}
+			for _, q := range queues {
+				b.queues[q] = append(b.queues[q], *publish)
+				if consumer := b.consumers[q]; consumer != nil {
+					consumer()
+				}
+			}
+			nack := b.nack
+			b.mu.Unlock()
+			seq++
+			if nack {
+				reply(1, classBasic, 120, func(e *encoder) { e.longlong(seq); e.octet(0) })
+			} else {
+				reply(1, classBasic, 80, func(e *encoder) { e.longlong(seq); e.octet(0) })
+			}
+			continue
+		}
+		m, _ := parseMethod(payload)
+		d := decoder{b: m.args}
+		switch {
+		case m.class == classConnection && m.id == 11:
+			d.skipTable()
+			d.shortstr()
+			b.mu.Lock()
+			b.auth = d.longstr()
+			b.mu.Unlock()
+			reply(0, classConnection, 30, func(e *encoder) { e.short(0); e.long(4096); e.short(0) })
+		case m.class == classConnection && m.id == 31:
+		case m.class == classConnection && m.id == 40:
+			b.mu.Lock()
+			b.vhost = d.shortstr()
+			b.mu.Unlock()
+			reply(0, classConnection, 41, func(e *encoder) { e.shortstr("") })
+		case m.class == classChannel && m.id == 10:
+			reply(channel, classChannel, 11, func(e *encoder) { e.longstr("") })
+		case m.class == classConfirm && m.id == 10:
+			reply(channel, classConfirm, 11, nil)
+		case m.class == classExchange && m.id == 10:
+			reply(channel, classExchange, 11, nil)
+		case m.class == classQueue && m.id == 10:
+			d.short()
+			q := d.shortstr()
+			reply(channel, classQueue, 11, func(e *encoder) { e.shortstr(q); e.long(0); e.long(0) })
+		case m.class == classQueue && m.id == 20:
+			d.short()
+			q, ex := d.shortstr(), d.shortstr()
+			b.mu.Lock()
+			b.bindings[ex] = append(b.bindings[ex], q)
+			b.mu.Unlock()
+			reply(channel, classQueue, 21, nil)
+		case m.class == classBasic && m.id == 10:
+			reply(channel, classBasic, 11, nil)
+		case m.class == classBasic && m.id == 20:
+			d.short()
+			consuming = d.shortstr()
+			reply(channel, classBasic, 21, func(e *encoder) { e.shortstr("ctag") })
+			b.mu.Lock()
+			b.consumers[consuming] = deliver
+			deliver()
+			b.mu.Unlock()
+		case m.class == classBasic && m.id == 40:
+			d.short()
+			exchange = d.shortstr()
+			publish = &published{routingKey: d.shortstr()}
+		case m.class == classBasic && m.id == 80:
+			b.mu.Lock()
+			b.acked = append(b.acked, d.longlong())
+			b.mu.Unlock()
+		}
+	}
+}
+
+func TestRabbitMQ_PublishConsumeAck(t *testing.T) {
+	b := newFakeBroker(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := NewSubscription(b.url(), "ztcp.telemetry", "loki")
+	if err != nil {
+		t.Fatalf("NewSubscription: %v", err)
+	}
+	defer sub.Close()
+	if _, _, err := sub.connect(ctx); err != nil { // declares and binds the queue before anything is published
+		t.Fatalf("connect: %v", err)
+	}
+
+	sink, err := NewExchangeSink(b.url(), "ztcp.telemetry")
+	if err != nil {
+		t.Fatalf("NewExchangeSink: %v", err)
+	}
+	defer sink.Close()
+	big := strings.Repeat("x", 10000) // spans several body frames at the fake's 4096-byte frame size
+	msgs := []telemetry.Message{
+		{Value: []byte(`{"event_id":"a"}`), ID: "org-1:a", Headers: []telemetry.Header{{Key: "event-type", Value: []byte("page_view")}}},
+		{Value: []byte(big), ID: "org-1:b"},
+	}
+	if err := sink.Write(ctx, msgs...); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if b.auth != "\x00ztcp\x00secret" || b.vhost != "telemetry" {
+		t.Errorf("auth = %q, vhost = %q", b.auth, b.vhost)
+	}
+
+	got, err := sub.Fetch(ctx)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if string(got.Value) != `{"event_id":"a"}` || got.ID != "org-1:a" || got.Origin != "loki" || got.Offset != 1 {
+		t.Errorf("Fetch = %+v", got)
+	}
+	if len(got.Headers) != 1 || got.Headers[0].Key != "event-type" || string(got.Headers[0].Value) != "page_view" {
+		t.Errorf("headers = %v", got.Headers)
+	}
+	if err := sub.Ack(ctx, got); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	got, err = sub.Fetch(ctx)
+	if err != nil || string(got.Value) != big || got.Offset != 2 {
+		t.Fatalf("second Fetch = %d bytes, offset %d, %v", len(got.Value), got.Offset, err)
+	}
+	if err := sub.Ack(ctx, got); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		b.mu.Lock()
+		acked := fmt.Sprint(b.acked)
+		b.mu.Unlock()
+		if acked == "[1 2]" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("acked = %s, want [1 2]", acked)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRabbitMQ_QueueSinkAndNack(t *testing.T) {
+	b := newFakeBroker(t)
+	ctx := context.Background()
+	sink, err := NewQueueSink(b.url(), "ztcp.telemetry.dead-letter")
+	if err != nil {
+		t.Fatalf("NewQueueSink: %v", err)
+	}
+	defer sink.Close()
+	if err := sink.Write(ctx, telemetry.Message{Value: []byte("{")}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	b.mu.Lock()
+	if n := len(b.queues["ztcp.telemetry.dead-letter"]); n != 1 {
+		t.Errorf("dead-letter queue holds %d messages, want 1", n)
+	}
+	b.nack = true
+	b.mu.Unlock()
+	if err := sink.Write(ctx, telemetry.Message{Value: []byte("{")}); err == nil {
+		t.Error("a nacked publish should fail the write")
+	}
+	b.mu.Lock()
+	b.nack = false
+	b.mu.Unlock()
+	if err := sink.Write(ctx, telemetry.Message{Value: []byte("{")}); err != nil {
+		t.Errorf("Write after a nack should redial: %v", err)
+	}
+}
+
+func TestRabbitMQ_AckAfterReconnectFails(t *testing.T) {
+	b := newFakeBroker(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sub, _ := NewSubscription(b.url(), "ztcp.telemetry", "loki")
+	defer sub.Close()
+	sink, _ := NewExchangeSink(b.url(), "ztcp.telemetry")
+	defer sink.Close()
+	if _, _, err := sub.connect(ctx); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if err := sink.Write(ctx, telemetry.Message{Value: []byte("{}")}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := sub.Fetch(ctx)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	sub.c.close() // the broker went away
+	if _, _, err := sub.connect(ctx); err != nil {
+		t.Fatalf("reconnect: %v", err)
+	}
+	if err := sub.Ack(ctx, got); err == nil {
+		t.Error("a delivery from the lost connection should not be acked on the new one")
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	o, err := parseURL("amqps://rabbit.internal")
+	if err != nil || o.addr != "rabbit.internal:5671" || !o.useTLS || o.user != "guest" || o.vhost != "/" {
+		t.Errorf("parseURL(amqps) = %+v, %v", o, err)
+	}
+	o, _ = parseURL("amqp://u:p@rabbit:5673/%2Fprod")
+	if o.vhost != "/prod" || o.user != "u" || o.pass != "p" {
+		t.Errorf("parseURL(vhost) = %+v", o)
+	}
+	for _, u := range []string{"http://rabbit", "amqp://", "::"} {
+		if _, err := parseURL(u); err == nil {
+			t.Errorf("parseURL(%q) should fail", u)
+		}
+	}
+}
+
+func TestTableRoundTrip(t *testing.T) {
+	var e encoder
+	e.table([]telemetry.Header{{Key: "schema-version", Value: []byte("1")}, {Key: "dead-letter-reason", Value: []byte("bad")}})
+	d := decoder{b: e.Bytes()}
+	headers := d.table()
+	if d.err != nil || len(headers) != 2 || string(headers[1].Value) != "bad" {
+		t.Errorf("table = %v, %v", headers, d.err)
+	}
+
+	// Other publishers may set typed headers.
+	var inner encoder
+	inner.shortstr("retries")
+	inner.octet('I')
+	inner.long(3)
+	inner.shortstr("flag")
+	inner.octet('t')
+	inner.octet(1)
+	var outer encoder
+	outer.long(uint32(inner.Len()))
+	outer.Write(inner.Bytes())
+	d = decoder{b: outer.Bytes()}
+	headers = d.table()
+	if d.err != nil || fmt.Sprint(headers) != "[{retries [51]} {flag [116 114 117 101]}]" {
+		t.Errorf("typed table = %v, %v", headers, d.err)
+	}
+}
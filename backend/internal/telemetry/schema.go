@@ -0,0 +1,95 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownVersion is returned for an event whose schema version is not in the registry.
+var ErrUnknownVersion = errors.New("unknown schema version")
+
+// Schema is one version of the Event envelope.
+type Schema struct {
+	Version int
+	// Check validates a decoded event of this version. Unlike Validate it does not judge occurred_at against the
+	// clock, since consumers may read an event long after it was published.
+	Check func(e Event) error
+}
+
+// Registry is the set of schema versions a producer publishes or a consumer understands. Safe for concurrent use
+// once built.
+type Registry struct {
+	schemas map[int]Schema
+}
+
+// NewRegistry returns a registry of schemas. A later schema with the same version replaces an earlier one.
+func NewRegistry(schemas ...Schema) *Registry {
+	r := &Registry{schemas: make(map[int]Schema, len(schemas))}
+	for _, s := range schemas {
+		r.schemas[s.Version] = s
+	}
+	return r
+}
+
+// Schemas is the registry of the versions this build accepts from agents and publishes.
+var Schemas = NewRegistry(Schema{Version: 1, Check: checkV1})
+
+// Lookup returns the schema of version.
+func (r *Registry) Lookup(version int) (Schema, bool) {
+	s, ok := r.schemas[version]
+	return s, ok
+}
+
+// Versions returns the registered versions in ascending order.
+func (r *Registry) Versions() []int {
+	out := make([]int, 0, len(r.schemas))
+	for v := range r.schemas {
+		out = append(out, v)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// Check validates e against the schema of its version. Returns an error wrapping ErrUnknownVersion when the version
+// is not registered.
+func (r *Registry) Check(e Event) error {
+	s, ok := r.Lookup(e.SchemaVersion)
+	if !ok {
+		return fmt.Errorf("%w %d", ErrUnknownVersion, e.SchemaVersion)
+	}
+	if s.Check == nil {
+		return nil
+	}
+	return s.Check(e)
+}
+
+// Decode parses a message value from the telemetry topic and checks it with Check.
+func (r *Registry) Decode(value []byte) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(value, &e); err != nil {
+		return Event{}, fmt.Errorf("malformed event: %w", err)
+	}
+	if err := r.Check(e); err != nil {
+		return Event{}, err
+	}
+	return e, nil
+}
+
+// checkV1 checks the fields of a version 1 event that the control plane always sets.
+func checkV1(e Event) error {
+	if err := validateType(e.Type); err != nil {
+		return err
+	}
+	if e.EventID == "" || len(e.EventID) > MaxEventIDLength {
+		return fmt.Errorf("event_id must be 1 to %d characters", MaxEventIDLength)
+	}
+	if e.OccurredAt.IsZero() || e.ReceivedAt.IsZero() {
+		return errors.New("occurred_at and received_at are required")
+	}
+	if e.OrgID == "" || e.UserID == "" || e.SessionID == "" {
+		return errors.New("org_id, user_id and session_id are required")
+	}
+	return validateAttributes(e.Attributes)
+}
@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	telemetryv1 "zero-trust-control-plane/backend/api/generated/telemetry/v1"
+)
+
+func validEvent() Event {
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	return Event{
+		SchemaVersion: 1,
+		EventID:       "evt-1",
+		Type:          "process.start",
+		OccurredAt:    at.Add(-time.Minute),
+		ReceivedAt:    at,
+		OrgID:         "org-1",
+		UserID:        "user-1",
+		SessionID:     "sess-1",
+		DeviceID:      "dev-1",
+		Region:        "eu-west-1",
+		Attributes:    map[string]string{"name": "bash"},
+	}
+}
+
+func TestRegistry_Check(t *testing.T) {
+	if err := Schemas.Check(validEvent()); err != nil {
+		t.Fatalf("Check(valid) = %v", err)
+	}
+
+	unknown := validEvent()
+	unknown.SchemaVersion = 2
+	if err := Schemas.Check(unknown); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Check(version 2) = %v, want ErrUnknownVersion", err)
+	}
+	unknown.SchemaVersion = 0
+	if err := Schemas.Check(unknown); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Check(version 0) = %v, want ErrUnknownVersion", err)
+	}
+
+	for name, mutate := range map[string]func(*Event){
+		"missing event_id": func(e *Event) { e.EventID = "" },
+		"bad type":         func(e *Event) { e.Type = "Process Start" },
+		"missing org":      func(e *Event) { e.OrgID = "" },
+		"missing received": func(e *Event) { e.ReceivedAt = time.Time{} },
+		"empty attribute":  func(e *Event) { e.Attributes = map[string]string{"": "x"} },
+	} {
+		e := validEvent()
+		mutate(&e)
+		if err := Schemas.Check(e); err == nil || errors.Is(err, ErrUnknownVersion) {
+			t.Errorf("%s: Check = %v, want a validation error", name, err)
+		}
+	}
+}
+
+func TestRegistry_Versions(t *testing.T) {
+	r := NewRegistry(Schema{Version: 3}, Schema{Version: 1, Check: checkV1}, Schema{Version: 2})
+	if got := r.Versions(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Versions() = %v, want [1 2 3]", got)
+	}
+	e := validEvent()
+	e.SchemaVersion, e.EventID = 2, ""
+	if err := r.Check(e); err != nil {
+		t.Errorf("Check with a nil Check func = %v, want nil", err)
+	}
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	value, _ := json.Marshal(validEvent())
+	got, err := Schemas.Decode(value)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, validEvent()) {
+		t.Errorf("Decode = %+v, want %+v", got, validEvent())
+	}
+	if _, err := Schemas.Decode([]byte("{not json")); err == nil {
+		t.Error("Decode(malformed) should fail")
+	}
+	if _, err := Schemas.Decode([]byte(`{"schema_version":9,"type":"x"}`)); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Decode(version 9) = %v, want ErrUnknownVersion", err)
+	}
+}
+
+// The JSON published by KafkaPublisher must decode as the TelemetryEnvelope proto message, the topic's contract.
+func TestEvent_MatchesTelemetryEnvelope(t *testing.T) {
+	e := validEvent()
+	value, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var env telemetryv1.TelemetryEnvelope
+	if err := protojson.Unmarshal(value, &env); err != nil {
+		t.Fatalf("protojson.Unmarshal: %v", err)
+	}
+	if env.GetSchemaVersion() != 1 || env.GetEventId() != e.EventID || env.GetType() != e.Type ||
+		env.GetOrgId() != e.OrgID || env.GetUserId() != e.UserID || env.GetSessionId() != e.SessionID ||
+		env.GetDeviceId() != e.DeviceID || env.GetRegion() != e.Region || env.GetAttributes()["name"] != "bash" {
+		t.Errorf("envelope = %v", &env)
+	}
+	if !env.GetOccurredAt().AsTime().Equal(e.OccurredAt) || !env.GetReceivedAt().AsTime().Equal(e.ReceivedAt) {
+		t.Errorf("times = %v/%v", env.GetOccurredAt().AsTime(), env.GetReceivedAt().AsTime())
+	}
+}
@@ -0,0 +1,99 @@
+// Package telemetry publishes agent telemetry to Kafka for downstream consumers. Each event is wrapped in an Event
+// envelope that carries the schema version and the org, user, session and device of the agent that sent it.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// SchemaVersion is the version of the Event envelope that agents get when they send no schema_version. Consumers read
+// the version from the schema_version field or the schema-version message header and dead-letter versions they do not
+// know (see Registry).
+const SchemaVersion = 1
+
+// Limits on a batch, so agents cannot publish arbitrary blobs.
+const (
+	MaxBatchSize            = 500
+	MaxTypeLength           = 64
+	MaxEventIDLength        = 64
+	MaxAttributes           = 64
+	MaxAttributeKeyLength   = 64
+	MaxAttributeValueLength = 1024
+	MaxEventAge             = 7 * 24 * time.Hour
+	MaxClockSkew            = 5 * time.Minute
+)
+
+var typePattern = regexp.MustCompile(`^[a-z0-9_.]+$`)
+
+// Source is the agent an event came from, as taken from its access token and session.
+type Source struct {
+	OrgID     string
+	UserID    string
+	SessionID string
+	DeviceID  string // empty when the session has no device
+}
+
+// Event is the message published for one telemetry event. The JSON field names are the topic's schema, described by
+// the TelemetryEnvelope proto message.
+type Event struct {
+	SchemaVersion int               `json:"schema_version"`
+	EventID       string            `json:"event_id"`
+	Type          string            `json:"type"`
+	OccurredAt    time.Time         `json:"occurred_at"`
+	ReceivedAt    time.Time         `json:"received_at"`
+	OrgID         string            `json:"org_id"`
+	UserID        string            `json:"user_id"`
+	SessionID     string            `json:"session_id"`
+	DeviceID      string            `json:"device_id,omitempty"`
+	Region        string            `json:"region,omitempty"`     // REGION of the instance that received it, when set
+	RequestID     string            `json:"request_id,omitempty"` // x-request-id of the call that delivered it
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// Publisher publishes a batch of events. Implemented by SinkPublisher.
+type Publisher interface {
+	Publish(ctx context.Context, events []Event) error
+}
+
+// Validate checks an event reported by an agent, with occurredAt judged against now.
+func Validate(eventType, eventID string, occurredAt time.Time, attributes map[string]string, now time.Time) error {
+	if err := validateType(eventType); err != nil {
+		return err
+	}
+	if len(eventID) > MaxEventIDLength {
+		return fmt.Errorf("event_id must be at most %d characters", MaxEventIDLength)
+	}
+	if occurredAt.IsZero() {
+		return errors.New("occurred_at is required")
+	}
+	if occurredAt.Before(now.Add(-MaxEventAge)) || occurredAt.After(now.Add(MaxClockSkew)) {
+		return errors.New("occurred_at must be within the last 7 days and not in the future")
+	}
+	return validateAttributes(attributes)
+}
+
+func validateType(eventType string) error {
+	if eventType == "" || len(eventType) > MaxTypeLength || !typePattern.MatchString(eventType) {
+		return fmt.Errorf("type is required and must be at most %d lowercase letters, digits, '_' or '.'", MaxTypeLength)
+	}
+	return nil
+}
+
+func validateAttributes(attributes map[string]string) error {
+	if len(attributes) > MaxAttributes {
+		return fmt.Errorf("attributes must have at most %d entries", MaxAttributes)
+	}
+	for k, v := range attributes {
+		if k == "" || len(k) > MaxAttributeKeyLength {
+			return fmt.Errorf("attribute keys must be 1 to %d characters", MaxAttributeKeyLength)
+		}
+		if len(v) > MaxAttributeValueLength {
+			return fmt.Errorf("attribute values must be at most %d characters", MaxAttributeValueLength)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// Transports that carry telemetry (TELEMETRY_TRANSPORT).
+const (
+	TransportKafka    = "kafka"
+	TransportNATS     = "nats"
+	TransportRabbitMQ = "rabbitmq"
+	TransportEmbedded = "embedded" // stored in the server's database; see package embedded
+)
+
+// Headers set on every published event.
+const (
+	HeaderSchemaVersion = "schema-version"
+	HeaderEventType     = "event-type"
+)
+
+// Header is one message header.
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Message is one message on a telemetry transport.
+type Message struct {
+	// Key keeps one device's events in order: the Kafka partition key. NATS and RabbitMQ keep a single order per
+	// stream or queue and ignore it.
+	Key   []byte
+	Value []byte
+	// ID is org_id:event_id. Transports with broker-side deduplication use it (JetStream's Nats-Msg-Id, RabbitMQ's
+	// message-id).
+	ID      string
+	Headers []Header
+
+	// Set by a Subscription on the messages it returns.
+	Origin    string // topic, subject or queue the message was read from
+	Partition int    // Kafka only
+	Offset    int64  // Kafka offset, JetStream stream sequence or RabbitMQ delivery tag
+	AckToken  string // the transport's handle for Ack (the JetStream ack subject); empty for Kafka and RabbitMQ
+}
+
+// Sink writes messages and returns once the transport holds them durably: acknowledged by all in-sync Kafka
+// replicas, stored by JetStream, or confirmed by RabbitMQ. On error some of them may have been written.
+type Sink interface {
+	Write(ctx context.Context, msgs ...Message) error
+	Close() error
+}
+
+// Subscription reads the messages of one consumer group: a Kafka group, a JetStream durable consumer or a RabbitMQ
+// queue. A message that is not acked is delivered again, after a restart at the latest.
+type Subscription interface {
+	Fetch(ctx context.Context) (Message, error)
+	Ack(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Encode returns the message published for e: its JSON envelope, keyed by device (by user when there is none), with
+// the schema-version and event-type headers.
+func Encode(e Event) (Message, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return Message{}, err
+	}
+	key := e.DeviceID
+	if key == "" {
+		key = e.UserID
+	}
+	return Message{
+		Key:   []byte(key),
+		Value: value,
+		ID:    e.OrgID + ":" + e.EventID,
+		Headers: []Header{
+			{Key: HeaderSchemaVersion, Value: []byte(strconv.Itoa(e.SchemaVersion))},
+			{Key: HeaderEventType, Value: []byte(e.Type)},
+		},
+	}, nil
+}
+
+// SinkPublisher publishes events to a Sink.
+type SinkPublisher struct {
+	sink Sink
+}
+
+// NewPublisher returns a publisher writing to sink.
+func NewPublisher(sink Sink) *SinkPublisher {
+	return &SinkPublisher{sink: sink}
+}
+
+// Publish encodes events and writes them in one call, which returns once the transport holds them.
+func (p *SinkPublisher) Publish(ctx context.Context, events []Event) error {
+	msgs := make([]Message, len(events))
+	for i, e := range events {
+		msg, err := Encode(e)
+		if err != nil {
+			return err
+		}
+		msgs[i] = msg
+	}
+	return p.sink.Write(ctx, msgs...)
+}
+
+// Close closes the sink.
+func (p *SinkPublisher) Close() error {
+	return p.sink.Close()
+}
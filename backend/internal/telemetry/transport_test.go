@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type recordingSink struct {
+	msgs []Message
+}
+
+func (s *recordingSink) Write(ctx context.Context, msgs ...Message) error {
+	s.msgs = append(s.msgs, msgs...)
+	return nil
+}
+
+func (s *recordingSink) Close() error { return nil }
+
+func TestSinkPublisher_EncodesEvents(t *testing.T) {
+	sink := &recordingSink{}
+	events := []Event{
+		{SchemaVersion: 1, EventID: "a", Type: "page_view", OccurredAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), OrgID: "org-1", UserID: "user-1", DeviceID: "dev-1"},
+		{SchemaVersion: 1, EventID: "b", Type: "login", OrgID: "org-1", UserID: "user-1"},
+	}
+	if err := NewPublisher(sink).Publish(context.Background(), events); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(sink.msgs) != 2 {
+		t.Fatalf("wrote %d messages, want 2", len(sink.msgs))
+	}
+	first, second := sink.msgs[0], sink.msgs[1]
+	if string(first.Key) != "dev-1" || string(second.Key) != "user-1" {
+		t.Errorf("keys = %q/%q, want the device, else the user", first.Key, second.Key)
+	}
+	if first.ID != "org-1:a" {
+		t.Errorf("ID = %q, want org-1:a", first.ID)
+	}
+	var decoded Event
+	if err := json.Unmarshal(first.Value, &decoded); err != nil || decoded.EventID != "a" {
+		t.Errorf("value = %s, %v", first.Value, err)
+	}
+	if len(first.Headers) != 2 || string(first.Headers[0].Value) != "1" || string(first.Headers[1].Value) != "page_view" {
+		t.Errorf("headers = %v", first.Headers)
+	}
+}
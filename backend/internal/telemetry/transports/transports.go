@@ -0,0 +1,113 @@
+// Package transports opens the telemetry transport selected by TELEMETRY_TRANSPORT, for cmd/server (publishing) and
+// cmd/telemetry-worker (consuming and dead-lettering). The embedded transport needs the server's database and is
+// opened by cmd/server with package embedded.
+package transports
+
+import (
+	"errors"
+	"fmt"
+
+	"zero-trust-control-plane/backend/internal/config"
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/internal/telemetry/nats"
+	"zero-trust-control-plane/backend/internal/telemetry/rabbitmq"
+)
+
+// errEmbedded is returned for the embedded transport, which has no broker to publish to or consume from.
+var errEmbedded = errors.New("TELEMETRY_TRANSPORT=embedded has no broker; cmd/server stores the events itself")
+
+// Publisher returns a publisher on cfg's transport. Call it only when cfg.TelemetryEnabled() and the transport is
+// not embedded.
+func Publisher(cfg *config.Config) (*telemetry.SinkPublisher, error) {
+	var sink telemetry.Sink
+	switch cfg.TelemetryTransport {
+	case telemetry.TransportEmbedded:
+		return nil, errEmbedded
+	case telemetry.TransportNATS:
+		s, err := nats.NewSink(cfg.TelemetryNATSURL, cfg.TelemetryNATSSubject)
+		if err != nil {
+			return nil, fmt.Errorf("TELEMETRY_NATS_URL: %w", err)
+		}
+		sink = s
+	case telemetry.TransportRabbitMQ:
+		s, err := rabbitmq.NewExchangeSink(cfg.TelemetryRabbitMQURL, cfg.TelemetryRabbitMQExchange)
+		if err != nil {
+			return nil, fmt.Errorf("TELEMETRY_RABBITMQ_URL: %w", err)
+		}
+		sink = s
+	default:
+		sink = telemetry.NewKafkaSink(cfg.TelemetryKafkaBrokerList(), cfg.TelemetryKafkaTopic)
+	}
+	return telemetry.NewPublisher(sink), nil
+}
+
+// Consumer returns the subscription of cfg.TelemetryWorkerGroup and the sink for cfg.TelemetryDeadLetterTopic, nil
+// when that is empty. Call it only when cfg.TelemetryEnabled().
+func Consumer(cfg *config.Config) (telemetry.Subscription, telemetry.Sink, error) {
+	deadLetter := cfg.TelemetryDeadLetterTopic
+	switch cfg.TelemetryTransport {
+	case telemetry.TransportEmbedded:
+		return nil, nil, errEmbedded
+	case telemetry.TransportNATS:
+		sub, err := nats.NewSubscription(cfg.TelemetryNATSURL, cfg.TelemetryNATSStream, cfg.TelemetryWorkerGroup, cfg.TelemetryNATSSubject)
+		if err != nil {
+			return nil, nil, fmt.Errorf("TELEMETRY_NATS_URL: %w", err)
+		}
+		if deadLetter == "" {
+			return sub, nil, nil
+		}
+		sink, err := nats.NewSink(cfg.TelemetryNATSURL, deadLetter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("TELEMETRY_DEAD_LETTER_TOPIC: %w", err)
+		}
+		return sub, sink, nil
+	case telemetry.TransportRabbitMQ:
+		sub, err := rabbitmq.NewSubscription(cfg.TelemetryRabbitMQURL, cfg.TelemetryRabbitMQExchange, cfg.TelemetryWorkerGroup)
+		if err != nil {
+			return nil, nil, fmt.Errorf("TELEMETRY_RABBITMQ_URL: %w", err)
+		}
+		if deadLetter == "" {
+			return sub, nil, nil
+		}
+		sink, err := rabbitmq.NewQueueSink(cfg.TelemetryRabbitMQURL, deadLetter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("TELEMETRY_DEAD_LETTER_TOPIC: %w", err)
+		}
+		return sub, sink, nil
+	default:
+		brokers := cfg.TelemetryKafkaBrokerList()
+		sub := telemetry.NewKafkaSubscription(brokers, cfg.TelemetryKafkaTopic, cfg.TelemetryWorkerGroup)
+		if deadLetter == "" {
+			return sub, nil, nil
+		}
+		return sub, telemetry.NewKafkaSink(brokers, deadLetter), nil
+	}
+}
+
+// Destination describes where cfg's transport publishes telemetry, for log lines.
+func Destination(cfg *config.Config) string {
+	switch cfg.TelemetryTransport {
+	case telemetry.TransportEmbedded:
+		return "the telemetry_events table (embedded)"
+	case telemetry.TransportNATS:
+		return fmt.Sprintf("nats subject %s (stream %s)", cfg.TelemetryNATSSubject, cfg.TelemetryNATSStream)
+	case telemetry.TransportRabbitMQ:
+		return "rabbitmq exchange " + cfg.TelemetryRabbitMQExchange
+	default:
+		return "kafka topic " + cfg.TelemetryKafkaTopic
+	}
+}
+
+// SettingName is the connection setting that enables cfg's transport, for log lines and errors.
+func SettingName(cfg *config.Config) string {
+	switch cfg.TelemetryTransport {
+	case telemetry.TransportEmbedded:
+		return "TELEMETRY_TRANSPORT"
+	case telemetry.TransportNATS:
+		return "TELEMETRY_NATS_URL"
+	case telemetry.TransportRabbitMQ:
+		return "TELEMETRY_RABBITMQ_URL"
+	default:
+		return "TELEMETRY_KAFKA_BROKERS"
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// Status is where an exception is in its lifecycle. Only pending exceptions can be approved or rejected, and only
+// approved ones revoked.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+	StatusRevoked  Status = "revoked"
+	// StatusExpired is reported for approved exceptions whose window has ended; it is never stored.
+	StatusExpired Status = "expired"
+)
+
+const (
+	// MaxJustificationLength caps the requester's justification and the reviewer's comment.
+	MaxJustificationLength = 1000
+	// DefaultDuration is how long an exception lasts when neither the request nor the approval names a duration.
+	DefaultDuration = 24 * time.Hour
+	// MaxDuration is the longest exception that can be requested or approved.
+	MaxDuration = 30 * 24 * time.Hour
+)
+
+// Review is an org admin's decision on an exception request.
+type Review struct {
+	By      string
+	Comment string
+	At      time.Time
+}
+
+// Exception is a member's request to reach one domain that the org's access control blocks for them. Once an admin
+// approves it, CheckUrlAccess allows the domain for that member until ExpiresAt, unless it is revoked first.
+type Exception struct {
+	ID            string
+	OrgID         string
+	UserID        string // the member the exception is for
+	Domain        string // normalized host; the exception covers this host only, not its subdomains
+	URL           string // the URL the member was blocked on
+	Status        Status
+	Justification string
+	Duration      time.Duration // window; starts at approval
+	Review        *Review       // nil while pending
+	ExpiresAt     *time.Time    // set on approval
+	RevokedBy     string
+	RevokedAt     *time.Time
+	CreatedAt     time.Time
+}
+
+// Active reports whether the exception allows its domain at now.
+func (e *Exception) Active(now time.Time) bool {
+	return e.Status == StatusApproved && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+// EffectiveStatus is Status, except that an approved exception whose window has ended is reported as expired.
+func (e *Exception) EffectiveStatus(now time.Time) Status {
+	if e.Status == StatusApproved && !e.Active(now) {
+		return StatusExpired
+	}
+	return e.Status
+}
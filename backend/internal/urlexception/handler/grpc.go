@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	urlexceptionv1 "zero-trust-control-plane/backend/api/generated/urlexception/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	orgpolicyconfigdomain "zero-trust-control-plane/backend/internal/orgpolicyconfig/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/urlexception/domain"
+	"zero-trust-control-plane/backend/internal/urlexception/repository"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// URLChecker evaluates a URL against the org's access control for a user, without URL exceptions (orgpolicyconfig
+// handler.Server).
+type URLChecker interface {
+	CheckURL(ctx context.Context, orgID, userID, rawURL string) (allowed bool, reason string, err error)
+}
+
+// Server implements UrlExceptionService (proto server).
+// Proto: urlexception/urlexception.proto → internal/urlexception/handler.
+type Server struct {
+	urlexceptionv1.UnimplementedUrlExceptionServiceServer
+	repo           repository.Repository
+	membershipRepo rbac.OrgMembershipGetter
+	urls           URLChecker
+	auditLogger    audit.AuditLogger
+	now            func() time.Time
+}
+
+// NewServer returns a new UrlException gRPC server. If repo or membershipRepo is nil, all RPCs return Unimplemented.
+// urls may be nil; then RequestUrlException does not check that the URL is actually denied. auditLogger may be nil.
+func NewServer(repo repository.Repository, membershipRepo rbac.OrgMembershipGetter, urls URLChecker, auditLogger audit.AuditLogger) *Server {
+	return &Server{
+		repo:           repo,
+		membershipRepo: membershipRepo,
+		urls:           urls,
+		auditLogger:    auditLogger,
+		now:            time.Now,
+	}
+}
+
+// RequestUrlException stores a pending request for access to the domain of a URL the caller is denied. The caller
+// must have no pending or active exception for that domain.
+func (s *Server) RequestUrlException(ctx context.Context, req *urlexceptionv1.RequestUrlExceptionRequest) (*urlexceptionv1.RequestUrlExceptionResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RequestUrlException not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	rawURL := strings.TrimSpace(req.GetUrl())
+	if rawURL == "" {
+		return nil, status.Error(codes.InvalidArgument, "url required")
+	}
+	if len(rawURL) > orgpolicyconfigdomain.MaxURLLength {
+		return nil, status.Errorf(codes.InvalidArgument, "url must be at most %d characters", orgpolicyconfigdomain.MaxURLLength)
+	}
+	host, err := orgpolicyconfigdomain.NormalizeDomain(rawURL)
+	if err != nil || strings.HasPrefix(host, "*.") {
+		return nil, status.Error(codes.InvalidArgument, "url must name a host")
+	}
+	justification, err := validateText("justification", req.GetJustification())
+	if err != nil {
+		return nil, err
+	}
+	if justification == "" {
+		return nil, status.Error(codes.InvalidArgument, "justification required")
+	}
+	duration, err := validateDuration(req.GetDurationSeconds(), domain.DefaultDuration)
+	if err != nil {
+		return nil, err
+	}
+	if s.urls != nil {
+		allowed, _, err := s.urls.CheckURL(ctx, orgID, userID, rawURL)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			return nil, status.Error(codes.FailedPrecondition, "url is not blocked for you")
+		}
+	}
+	now := s.now().UTC()
+	open, err := s.repo.HasOpen(ctx, orgID, userID, host, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check existing exceptions")
+	}
+	if open {
+		return nil, status.Error(codes.FailedPrecondition, "a pending or active exception for this domain already exists")
+	}
+	e := &domain.Exception{
+		ID:            uuid.New().String(),
+		OrgID:         orgID,
+		UserID:        userID,
+		Domain:        host,
+		URL:           rawURL,
+		Status:        domain.StatusPending,
+		Justification: justification,
+		Duration:      duration,
+		CreatedAt:     now,
+	}
+	if err := s.repo.Create(ctx, e); err != nil {
+		return nil, status.Error(codes.Internal, "failed to create exception")
+	}
+	s.record(ctx, e, "url_exception_requested", userID, justification)
+	return &urlexceptionv1.RequestUrlExceptionResponse{Exception: s.exceptionToProto(e)}, nil
+}
+
+// ListUrlExceptions returns the org's exceptions, newest first. Org admins and owners see every exception and may
+// filter by user; members see only their own.
+func (s *Server) ListUrlExceptions(ctx context.Context, req *urlexceptionv1.ListUrlExceptionsRequest) (*urlexceptionv1.ListUrlExceptionsResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListUrlExceptions not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	filterUser := req.GetUserId()
+	if _, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo); err != nil {
+		if status.Code(err) != codes.PermissionDenied {
+			return nil, err
+		}
+		if filterUser != "" && filterUser != userID {
+			return nil, status.Error(codes.PermissionDenied, "members can only list their own exceptions")
+		}
+		filterUser = userID
+	}
+	filter := domain.Status(req.GetStatus())
+	switch filter {
+	case "", domain.StatusPending, domain.StatusApproved, domain.StatusRejected, domain.StatusRevoked:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be one of pending, approved, rejected, revoked")
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.repo.ListByOrg(ctx, orgID, filter, filterUser, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list exceptions")
+	}
+	out := make([]*urlexceptionv1.UrlException, len(list))
+	for i, e := range list {
+		out[i] = s.exceptionToProto(e)
+	}
+	result := &urlexceptionv1.ListUrlExceptionsResponse{
+		Exceptions: out,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// ApproveUrlException approves a pending request; the exception window starts now and lasts the requested duration
+// unless the approver names another. Caller must be an org admin or owner other than the requester.
+func (s *Server) ApproveUrlException(ctx context.Context, req *urlexceptionv1.ApproveUrlExceptionRequest) (*urlexceptionv1.ApproveUrlExceptionResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ApproveUrlException not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := validateText("comment", req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.load(ctx, orgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if e.UserID == userID {
+		return nil, status.Error(codes.PermissionDenied, "exception must be approved by an admin other than the requester")
+	}
+	duration, err := validateDuration(req.GetDurationSeconds(), e.Duration)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	review := &domain.Review{By: userID, Comment: comment, At: now}
+	expiresAt := now.Add(duration)
+	ok, err := s.repo.Review(ctx, e.ID, domain.StatusApproved, review, duration, &expiresAt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to approve exception")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "exception is not pending")
+	}
+	e.Status, e.Review, e.Duration, e.ExpiresAt = domain.StatusApproved, review, duration, &expiresAt
+	s.record(ctx, e, "url_exception_approved", userID, comment)
+	return &urlexceptionv1.ApproveUrlExceptionResponse{Exception: s.exceptionToProto(e)}, nil
+}
+
+// RejectUrlException rejects a pending request. Caller must be an org admin or owner, or the requester (withdrawing
+// it).
+func (s *Server) RejectUrlException(ctx context.Context, req *urlexceptionv1.RejectUrlExceptionRequest) (*urlexceptionv1.RejectUrlExceptionResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RejectUrlException not implemented")
+	}
+	e, userID, comment, err := s.loadForAdminOrSubject(ctx, req.GetId(), req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	review := &domain.Review{By: userID, Comment: comment, At: s.now().UTC()}
+	ok, err := s.repo.Review(ctx, e.ID, domain.StatusRejected, review, e.Duration, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to reject exception")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "exception is not pending")
+	}
+	e.Status, e.Review = domain.StatusRejected, review
+	s.record(ctx, e, "url_exception_rejected", userID, comment)
+	return &urlexceptionv1.RejectUrlExceptionResponse{Exception: s.exceptionToProto(e)}, nil
+}
+
+// RevokeUrlException ends an active exception. Caller must be an org admin or owner, or the member it is for.
+func (s *Server) RevokeUrlException(ctx context.Context, req *urlexceptionv1.RevokeUrlExceptionRequest) (*urlexceptionv1.RevokeUrlExceptionResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method RevokeUrlException not implemented")
+	}
+	e, userID, comment, err := s.loadForAdminOrSubject(ctx, req.GetId(), req.GetComment())
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	ok, err := s.repo.Revoke(ctx, e.ID, userID, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke exception")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "exception is not active")
+	}
+	e.Status, e.RevokedBy, e.RevokedAt = domain.StatusRevoked, userID, &now
+	s.record(ctx, e, "url_exception_revoked", userID, comment)
+	return &urlexceptionv1.RevokeUrlExceptionResponse{Exception: s.exceptionToProto(e)}, nil
+}
+
+// loadForAdminOrSubject authorizes an org admin or owner, or the member the exception is for, and returns the
+// exception, the caller and the validated comment. Other members get NotFound, so they cannot probe other users'
+// exceptions.
+func (s *Server) loadForAdminOrSubject(ctx context.Context, id, comment string) (*domain.Exception, string, string, error) {
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, "", "", err
+	}
+	comment, err = validateText("comment", comment)
+	if err != nil {
+		return nil, "", "", err
+	}
+	e, err := s.load(ctx, orgID, id)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if e.UserID != userID {
+		if _, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo); err != nil {
+			if status.Code(err) == codes.PermissionDenied {
+				return nil, "", "", status.Error(codes.NotFound, "exception not found")
+			}
+			return nil, "", "", err
+		}
+	}
+	return e, userID, comment, nil
+}
+
+// load returns the exception if it belongs to orgID; otherwise NotFound, so other orgs' IDs are not revealed.
+func (s *Server) load(ctx context.Context, orgID, id string) (*domain.Exception, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	e, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load exception")
+	}
+	if e == nil || e.OrgID != orgID {
+		return nil, status.Error(codes.NotFound, "exception not found")
+	}
+	return e, nil
+}
+
+// record audits an exception step under resource url_exception, with the actor as user and the member, domain and
+// URL in the metadata.
+func (s *Server) record(ctx context.Context, e *domain.Exception, action, actor, comment string) {
+	if s.auditLogger == nil {
+		return
+	}
+	metadata := map[string]interface{}{
+		"url_exception_id": e.ID,
+		"user_id":          e.UserID,
+		"domain":           e.Domain,
+		"url":              e.URL,
+		"duration_seconds": int64(e.Duration / time.Second),
+	}
+	if comment != "" {
+		metadata["comment"] = comment
+	}
+	if e.ExpiresAt != nil {
+		metadata["expires_at"] = e.ExpiresAt
+	}
+	meta, _ := json.Marshal(metadata)
+	s.auditLogger.LogEvent(ctx, e.OrgID, actor, action, "url_exception", string(meta))
+}
+
+func validateText(field, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > domain.MaxJustificationLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", field, domain.MaxJustificationLength)
+	}
+	return s, nil
+}
+
+// validateDuration returns secs as a duration, or def when secs is 0. Durations under a minute or over
+// domain.MaxDuration are rejected.
+func validateDuration(secs int64, def time.Duration) (time.Duration, error) {
+	if secs == 0 {
+		return def, nil
+	}
+	d := time.Duration(secs) * time.Second
+	if secs < 0 || d < time.Minute || d > domain.MaxDuration {
+		return 0, status.Errorf(codes.InvalidArgument, "duration_seconds must be between 60 and %d", int64(domain.MaxDuration/time.Second))
+	}
+	return d, nil
+}
+
+// exceptionToProto converts e, reporting approved exceptions whose window has ended as expired.
+func (s *Server) exceptionToProto(e *domain.Exception) *urlexceptionv1.UrlException {
+	out := &urlexceptionv1.UrlException{
+		Id:              e.ID,
+		OrgId:           e.OrgID,
+		UserId:          e.UserID,
+		Domain:          e.Domain,
+		Url:             e.URL,
+		Status:          string(e.EffectiveStatus(s.now())),
+		Justification:   e.Justification,
+		DurationSeconds: int64(e.Duration / time.Second),
+		RevokedBy:       e.RevokedBy,
+		CreatedAt:       timestamppb.New(e.CreatedAt),
+	}
+	if r := e.Review; r != nil {
+		out.ReviewedBy = r.By
+		out.ReviewComment = r.Comment
+		out.ReviewedAt = timestamppb.New(r.At)
+	}
+	if e.ExpiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*e.ExpiresAt)
+	}
+	if e.RevokedAt != nil {
+		out.RevokedAt = timestamppb.New(*e.RevokedAt)
+	}
+	return out
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	urlexceptionv1 "zero-trust-control-plane/backend/api/generated/urlexception/v1"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	"zero-trust-control-plane/backend/internal/urlexception/domain"
+)
+
+// memExceptions implements repository.Repository in memory.
+type memExceptions struct {
+	byID map[string]*domain.Exception
+}
+
+func (m *memExceptions) Create(ctx context.Context, e *domain.Exception) error {
+	c := *e
+	m.byID[e.ID] = &c
+	return nil
+}
+
+func (m *memExceptions) GetByID(ctx context.Context, id string) (*domain.Exception, error) {
+	e, ok := m.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *e
+	return &c, nil
+}
+
+func (m *memExceptions) GetActive(ctx context.Context, orgID, userID, host string, now time.Time) (*domain.Exception, error) {
+	for _, e := range m.byID {
+		if e.OrgID == orgID && e.UserID == userID && e.Domain == host && e.Active(now) {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memExceptions) HasOpen(ctx context.Context, orgID, userID, host string, now time.Time) (bool, error) {
+	for _, e := range m.byID {
+		if e.OrgID == orgID && e.UserID == userID && e.Domain == host && (e.Status == domain.StatusPending || e.Active(now)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memExceptions) ListByOrg(ctx context.Context, orgID string, st domain.Status, userID string, limit, offset int32) ([]*domain.Exception, error) {
+	var out []*domain.Exception
+	for _, e := range m.byID {
+		if e.OrgID == orgID && (st == "" || e.Status == st) && (userID == "" || e.UserID == userID) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memExceptions) Review(ctx context.Context, id string, to domain.Status, review *domain.Review, duration time.Duration, expiresAt *time.Time) (bool, error) {
+	e, ok := m.byID[id]
+	if !ok || e.Status != domain.StatusPending {
+		return false, nil
+	}
+	e.Status, e.Review, e.Duration, e.ExpiresAt = to, review, duration, expiresAt
+	return true, nil
+}
+
+func (m *memExceptions) Revoke(ctx context.Context, id, by string, at time.Time) (bool, error) {
+	e, ok := m.byID[id]
+	if !ok || !e.Active(at) {
+		return false, nil
+	}
+	e.Status, e.RevokedBy, e.RevokedAt = domain.StatusRevoked, by, &at
+	return true, nil
+}
+
+// memMemberships implements rbac.OrgMembershipGetter.
+type memMemberships map[string]membershipdomain.Role // key: userID:orgID
+
+func (m memMemberships) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	role, ok := m[userID+":"+orgID]
+	if !ok {
+		return nil, nil
+	}
+	return &membershipdomain.Membership{UserID: userID, OrgID: orgID, Role: role}, nil
+}
+
+// blockedHosts implements URLChecker, denying URLs whose raw text is in the set.
+type blockedHosts map[string]bool
+
+func (b blockedHosts) CheckURL(ctx context.Context, orgID, userID, rawURL string) (bool, string, error) {
+	if b[rawURL] {
+		return false, "Access denied by organization policy: this domain is blocked.", nil
+	}
+	return true, "", nil
+}
+
+func testServer() (*Server, *memExceptions) {
+	repo := &memExceptions{byID: map[string]*domain.Exception{}}
+	memberships := memMemberships{
+		"owner-1:org-1": membershipdomain.RoleOwner,
+		"admin-1:org-1": membershipdomain.RoleAdmin,
+		"user-1:org-1":  membershipdomain.RoleMember,
+		"user-2:org-1":  membershipdomain.RoleMember,
+		"admin-2:org-2": membershipdomain.RoleAdmin,
+	}
+	urls := blockedHosts{"https://pastebin.com/raw/x": true, "https://pastebin.com/other": true}
+	return NewServer(repo, memberships, urls, nil), repo
+}
+
+func as(userID, orgID string) context.Context {
+	return interceptors.WithIdentity(context.Background(), userID, orgID, "session-1")
+}
+
+func TestUrlExceptionLifecycle(t *testing.T) {
+	srv, _ := testServer()
+	user, admin := as("user-1", "org-1"), as("admin-1", "org-1")
+
+	req, err := srv.RequestUrlException(user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/raw/x", Justification: " sharing logs with vendor "})
+	if err != nil {
+		t.Fatalf("RequestUrlException: %v", err)
+	}
+	e := req.GetException()
+	if e.GetStatus() != "pending" || e.GetDomain() != "pastebin.com" || e.GetJustification() != "sharing logs with vendor" || e.GetDurationSeconds() != int64(domain.DefaultDuration/time.Second) {
+		t.Errorf("requested = %+v", e)
+	}
+	if _, err := srv.RequestUrlException(user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/other", Justification: "again"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second request for the domain: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.ApproveUrlException(user, &urlexceptionv1.ApproveUrlExceptionRequest{Id: e.GetId()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member approve: code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	approved, err := srv.ApproveUrlException(admin, &urlexceptionv1.ApproveUrlExceptionRequest{Id: e.GetId(), Comment: "ok for today", DurationSeconds: 7200})
+	if err != nil {
+		t.Fatalf("ApproveUrlException: %v", err)
+	}
+	a := approved.GetException()
+	if a.GetStatus() != "approved" || a.GetReviewedBy() != "admin-1" || a.GetDurationSeconds() != 7200 || a.GetExpiresAt() == nil {
+		t.Errorf("approved = %+v", a)
+	}
+	if got := a.GetExpiresAt().AsTime().Sub(a.GetReviewedAt().AsTime()); got != 2*time.Hour {
+		t.Errorf("window = %v, want 2h from approval", got)
+	}
+	if _, err := srv.RejectUrlException(admin, &urlexceptionv1.RejectUrlExceptionRequest{Id: e.GetId()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("reject approved: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.RevokeUrlException(as("user-2", "org-1"), &urlexceptionv1.RevokeUrlExceptionRequest{Id: e.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("other member revoke: code = %v, want NotFound", status.Code(err))
+	}
+
+	revoked, err := srv.RevokeUrlException(admin, &urlexceptionv1.RevokeUrlExceptionRequest{Id: e.GetId()})
+	if err != nil {
+		t.Fatalf("RevokeUrlException: %v", err)
+	}
+	if r := revoked.GetException(); r.GetStatus() != "revoked" || r.GetRevokedBy() != "admin-1" {
+		t.Errorf("revoked = %+v", r)
+	}
+	if _, err := srv.RequestUrlException(user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/other", Justification: "again"}); err != nil {
+		t.Errorf("request after revoke: %v", err)
+	}
+}
+
+func TestUrlExceptionRequesterCannotApprove(t *testing.T) {
+	srv, repo := testServer()
+	repo.byID["x-1"] = &domain.Exception{ID: "x-1", OrgID: "org-1", UserID: "admin-1", Domain: "pastebin.com", Status: domain.StatusPending, Duration: time.Hour}
+
+	if _, err := srv.ApproveUrlException(as("admin-1", "org-1"), &urlexceptionv1.ApproveUrlExceptionRequest{Id: "x-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("self approve: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := srv.ApproveUrlException(as("admin-2", "org-2"), &urlexceptionv1.ApproveUrlExceptionRequest{Id: "x-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("approve from another org: code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.ApproveUrlException(as("owner-1", "org-1"), &urlexceptionv1.ApproveUrlExceptionRequest{Id: "x-1"}); err != nil {
+		t.Errorf("owner approve: %v", err)
+	}
+}
+
+func TestUrlExceptionReportsExpired(t *testing.T) {
+	srv, repo := testServer()
+	ended := time.Now().Add(-time.Minute)
+	repo.byID["x-1"] = &domain.Exception{ID: "x-1", OrgID: "org-1", UserID: "user-1", Domain: "pastebin.com", Status: domain.StatusApproved, Duration: time.Hour, ExpiresAt: &ended}
+	repo.byID["x-2"] = &domain.Exception{ID: "x-2", OrgID: "org-1", UserID: "user-2", Domain: "pastebin.com", Status: domain.StatusPending}
+
+	list, err := srv.ListUrlExceptions(as("user-1", "org-1"), &urlexceptionv1.ListUrlExceptionsRequest{})
+	if err != nil || len(list.GetExceptions()) != 1 || list.GetExceptions()[0].GetStatus() != "expired" {
+		t.Fatalf("member ListUrlExceptions = %v, %v; want own expired exception only", list, err)
+	}
+	if _, err := srv.ListUrlExceptions(as("user-1", "org-1"), &urlexceptionv1.ListUrlExceptionsRequest{UserId: "user-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member listing another user: code = %v, want PermissionDenied", status.Code(err))
+	}
+	list, err = srv.ListUrlExceptions(as("admin-1", "org-1"), &urlexceptionv1.ListUrlExceptionsRequest{Status: "pending"})
+	if err != nil || len(list.GetExceptions()) != 1 || list.GetExceptions()[0].GetId() != "x-2" {
+		t.Errorf("admin ListUrlExceptions(pending) = %v, %v; want x-2", list, err)
+	}
+	if _, err := srv.RevokeUrlException(as("admin-1", "org-1"), &urlexceptionv1.RevokeUrlExceptionRequest{Id: "x-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("revoke expired: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if _, err := srv.RequestUrlException(as("user-1", "org-1"), &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/raw/x", Justification: "again"}); err != nil {
+		t.Errorf("request after expiry: %v", err)
+	}
+}
+
+func TestRequestUrlExceptionValidation(t *testing.T) {
+	srv, _ := testServer()
+	user := as("user-1", "org-1")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *urlexceptionv1.RequestUrlExceptionRequest
+		code codes.Code
+	}{
+		{"no url", user, &urlexceptionv1.RequestUrlExceptionRequest{Justification: "x"}, codes.InvalidArgument},
+		{"no host", user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://", Justification: "x"}, codes.InvalidArgument},
+		{"no justification", user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/raw/x", Justification: " "}, codes.InvalidArgument},
+		{"too long", user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/raw/x", Justification: "x", DurationSeconds: 31 * 24 * 3600}, codes.InvalidArgument},
+		{"too short", user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/raw/x", Justification: "x", DurationSeconds: 30}, codes.InvalidArgument},
+		{"not blocked", user, &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://example.com/", Justification: "x"}, codes.FailedPrecondition},
+		{"not a member", as("user-3", "org-1"), &urlexceptionv1.RequestUrlExceptionRequest{Url: "https://pastebin.com/raw/x", Justification: "x"}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := srv.RequestUrlException(tt.ctx, tt.req); status.Code(err) != tt.code {
+				t.Errorf("code = %v, want %v", status.Code(err), tt.code)
+			}
+		})
+	}
+	if _, err := NewServer(nil, nil, nil, nil).ListUrlExceptions(user, &urlexceptionv1.ListUrlExceptionsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil repos: code = %v, want Unimplemented", status.Code(err))
+	}
+}
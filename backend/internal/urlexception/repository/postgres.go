@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/urlexception/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a URL exception repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{queries: gen.New(db)}
+}
+
+// Create persists a new exception request.
+func (r *PostgresRepository) Create(ctx context.Context, e *domain.Exception) error {
+	return r.queries.CreateUrlException(ctx, gen.CreateUrlExceptionParams{
+		ID:            e.ID,
+		OrgID:         e.OrgID,
+		UserID:        e.UserID,
+		Domain:        e.Domain,
+		Url:           e.URL,
+		Status:        string(e.Status),
+		Justification: e.Justification,
+		DurationSecs:  int32(e.Duration / time.Second),
+		CreatedAt:     e.CreatedAt,
+	})
+}
+
+// GetByID returns the exception, or nil if not found.
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Exception, error) {
+	row, err := r.queries.GetUrlException(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genExceptionToDomain(&row), nil
+}
+
+// GetActive returns the user's active exception for domain in the org, or nil if none.
+func (r *PostgresRepository) GetActive(ctx context.Context, orgID, userID, domainName string, now time.Time) (*domain.Exception, error) {
+	row, err := r.queries.GetActiveUrlException(ctx, gen.GetActiveUrlExceptionParams{UserID: userID, OrgID: orgID, Domain: domainName, Now: now})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genExceptionToDomain(&row), nil
+}
+
+// HasOpen reports whether the user has a pending or active exception for domain in the org.
+func (r *PostgresRepository) HasOpen(ctx context.Context, orgID, userID, domainName string, now time.Time) (bool, error) {
+	return r.queries.HasOpenUrlException(ctx, gen.HasOpenUrlExceptionParams{UserID: userID, OrgID: orgID, Domain: domainName, Now: now})
+}
+
+// ListByOrg returns the org's exceptions, newest first, optionally only those in status or of userID.
+func (r *PostgresRepository) ListByOrg(ctx context.Context, orgID string, status domain.Status, userID string, limit, offset int32) ([]*domain.Exception, error) {
+	rows, err := r.queries.ListUrlExceptionsByOrg(ctx, gen.ListUrlExceptionsByOrgParams{
+		OrgID:        orgID,
+		Limit:        limit,
+		Offset:       offset,
+		FilterStatus: sql.NullString{String: string(status), Valid: status != ""},
+		FilterUserID: sql.NullString{String: userID, Valid: userID != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Exception, len(rows))
+	for i := range rows {
+		out[i] = genExceptionToDomain(&rows[i])
+	}
+	return out, nil
+}
+
+// Review approves or rejects a pending exception.
+func (r *PostgresRepository) Review(ctx context.Context, id string, to domain.Status, review *domain.Review, duration time.Duration, expiresAt *time.Time) (bool, error) {
+	arg := gen.ReviewUrlExceptionParams{
+		Status:        string(to),
+		DurationSecs:  int32(duration / time.Second),
+		ReviewedBy:    sql.NullString{String: review.By, Valid: true},
+		ReviewComment: review.Comment,
+		ReviewedAt:    sql.NullTime{Time: review.At, Valid: true},
+		ID:            id,
+	}
+	if expiresAt != nil {
+		arg.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+	n, err := r.queries.ReviewUrlException(ctx, arg)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Revoke ends an active exception.
+func (r *PostgresRepository) Revoke(ctx context.Context, id, by string, at time.Time) (bool, error) {
+	n, err := r.queries.RevokeUrlException(ctx, gen.RevokeUrlExceptionParams{
+		RevokedBy: sql.NullString{String: by, Valid: true},
+		RevokedAt: sql.NullTime{Time: at, Valid: true},
+		ID:        id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func genExceptionToDomain(row *gen.UrlException) *domain.Exception {
+	e := &domain.Exception{
+		ID:            row.ID,
+		OrgID:         row.OrgID,
+		UserID:        row.UserID,
+		Domain:        row.Domain,
+		URL:           row.Url,
+		Status:        domain.Status(row.Status),
+		Justification: row.Justification,
+		Duration:      time.Duration(row.DurationSecs) * time.Second,
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.ReviewedBy.Valid {
+		e.Review = &domain.Review{By: row.ReviewedBy.String, Comment: row.ReviewComment, At: row.ReviewedAt.Time}
+	}
+	if row.ExpiresAt.Valid {
+		t := row.ExpiresAt.Time
+		e.ExpiresAt = &t
+	}
+	if row.RevokedBy.Valid {
+		e.RevokedBy = row.RevokedBy.String
+	}
+	if row.RevokedAt.Valid {
+		t := row.RevokedAt.Time
+		e.RevokedAt = &t
+	}
+	return e
+}
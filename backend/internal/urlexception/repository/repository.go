@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/urlexception/domain"
+)
+
+// Repository persists URL access exceptions.
+type Repository interface {
+	// Create persists a new pending exception. The exception must have ID set.
+	Create(ctx context.Context, e *domain.Exception) error
+	// GetByID returns the exception, or nil if not found.
+	GetByID(ctx context.Context, id string) (*domain.Exception, error)
+	// GetActive returns the user's exception for domain in the org that is active at now, or nil if none.
+	GetActive(ctx context.Context, orgID, userID, domain string, now time.Time) (*domain.Exception, error)
+	// HasOpen reports whether the user has a pending exception, or one that is active at now, for domain in the org.
+	HasOpen(ctx context.Context, orgID, userID, domain string, now time.Time) (bool, error)
+	// ListByOrg returns the org's exceptions, newest first. An empty status or userID matches every status or user.
+	ListByOrg(ctx context.Context, orgID string, status domain.Status, userID string, limit, offset int32) ([]*domain.Exception, error)
+	// Review moves a pending exception to approved (with its duration and expiresAt) or rejected. It reports false,
+	// without changing anything, when the exception is no longer pending.
+	Review(ctx context.Context, id string, to domain.Status, review *domain.Review, duration time.Duration, expiresAt *time.Time) (bool, error)
+	// Revoke ends an active exception at the given time. It reports false when the exception is not active.
+	Revoke(ctx context.Context, id, by string, at time.Time) (bool, error)
+}
@@ -7,14 +7,15 @@ import (
 
 // User is the core user entity.
 type User struct {
-	ID            string
-	Email         string
-	Name          string
-	Phone         string // optional; used for MFA (PoC); immutable after PhoneVerified
-	PhoneVerified bool   // true after first successful MFA verification
-	Status        UserStatus
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID               string
+	Email            string
+	Name             string
+	Phone            string // optional; used for MFA (PoC); once PhoneVerified, changed only by re-verification or an MFA reset
+	PhoneVerified    bool   // true after first successful MFA verification
+	MFAResetRequired bool   // set by an admin MFA reset; next sign-in requires MFA and enrolling a new phone
+	Status           UserStatus
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 type UserStatus string
@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"strings"
 
 	"google.golang.org/grpc/codes"
@@ -9,6 +11,11 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	userv1 "zero-trust-control-plane/backend/api/generated/user/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	"zero-trust-control-plane/backend/internal/dataexport"
+	dataexportdomain "zero-trust-control-plane/backend/internal/dataexport/domain"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 	"zero-trust-control-plane/backend/internal/user/domain"
 	userrepo "zero-trust-control-plane/backend/internal/user/repository"
 )
@@ -17,12 +24,15 @@ import (
 // Proto: user/user.proto → internal/user/handler.
 type Server struct {
 	userv1.UnimplementedUserServiceServer
-	userRepo userrepo.Repository
+	userRepo    userrepo.Repository
+	dataExports *dataexport.Service
+	auditLogger audit.AuditLogger
 }
 
-// NewServer returns a new User gRPC server. userRepo may be nil; then all RPCs return Unimplemented.
-func NewServer(userRepo userrepo.Repository) *Server {
-	return &Server{userRepo: userRepo}
+// NewServer returns a new User gRPC server. userRepo may be nil; then the user lifecycle RPCs return Unimplemented.
+// dataExports may be nil; then ExportMyData and DownloadDataExport return Unimplemented. auditLogger may be nil.
+func NewServer(userRepo userrepo.Repository, dataExports *dataexport.Service, auditLogger audit.AuditLogger) *Server {
+	return &Server{userRepo: userRepo, dataExports: dataExports, auditLogger: auditLogger}
 }
 
 // GetUser returns a user by ID.
@@ -91,6 +101,99 @@ func (s *Server) EnableUser(ctx context.Context, req *userv1.EnableUserRequest)
 	return nil, status.Error(codes.Unimplemented, "method EnableUser not implemented")
 }
 
+// ExportMyData requests an archive of the caller's personal data. The archive is generated by the data export job;
+// the returned token downloads it with DownloadDataExport.
+func (s *Server) ExportMyData(ctx context.Context, req *userv1.ExportMyDataRequest) (*userv1.ExportMyDataResponse, error) {
+	if s.dataExports == nil {
+		return nil, status.Error(codes.Unimplemented, "method ExportMyData not implemented")
+	}
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "user context required")
+	}
+	e, token, err := s.dataExports.Request(ctx, userID, userID)
+	if err != nil {
+		return nil, dataExportErr(err)
+	}
+	s.audit(ctx, userID, "data_export_requested", map[string]string{"export_id": e.ID, "user_id": e.UserID})
+	return &userv1.ExportMyDataResponse{
+		ExportId:      e.ID,
+		DownloadToken: token,
+		ExpiresAt:     timestamppb.New(e.ExpiresAt),
+	}, nil
+}
+
+// DownloadDataExport returns the status of an export the caller requested and, when ready, its archive.
+func (s *Server) DownloadDataExport(ctx context.Context, req *userv1.DownloadDataExportRequest) (*userv1.DownloadDataExportResponse, error) {
+	if s.dataExports == nil {
+		return nil, status.Error(codes.Unimplemented, "method DownloadDataExport not implemented")
+	}
+	userID, ok := interceptors.GetUserID(ctx)
+	if !ok || userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "user context required")
+	}
+	token := strings.TrimSpace(req.GetDownloadToken())
+	if token == "" {
+		return nil, status.Error(codes.InvalidArgument, "download_token required")
+	}
+	e, err := s.dataExports.Download(ctx, token, userID)
+	if err != nil {
+		return nil, dataExportErr(err)
+	}
+	resp := &userv1.DownloadDataExportResponse{
+		Status:    dataExportStatusToProto(e.Status),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		ExpiresAt: timestamppb.New(e.ExpiresAt),
+	}
+	if e.CompletedAt != nil {
+		resp.CompletedAt = timestamppb.New(*e.CompletedAt)
+	}
+	if e.Status == dataexportdomain.StatusReady {
+		resp.FileName = dataexport.FileName(e)
+		resp.Archive = e.Archive
+		s.audit(ctx, userID, "data_export_downloaded", map[string]string{"export_id": e.ID, "user_id": e.UserID})
+	}
+	return resp, nil
+}
+
+// dataExportErr maps dataexport errors to gRPC status errors.
+func dataExportErr(err error) error {
+	switch {
+	case errors.Is(err, dataexport.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, dataexport.ErrExportInProgress):
+		return status.Error(codes.FailedPrecondition, "an export of this user is already being generated")
+	case errors.Is(err, dataexport.ErrExportNotFound):
+		return status.Error(codes.NotFound, "export not found or expired")
+	case errors.Is(err, residency.ErrRegionUnavailable):
+		return status.Error(codes.FailedPrecondition, "org data region is not available")
+	default:
+		return status.Error(codes.Internal, "failed to export data")
+	}
+}
+
+func dataExportStatusToProto(st dataexportdomain.Status) userv1.DataExportStatus {
+	switch st {
+	case dataexportdomain.StatusPending, dataexportdomain.StatusGenerating:
+		return userv1.DataExportStatus_DATA_EXPORT_STATUS_PENDING
+	case dataexportdomain.StatusReady:
+		return userv1.DataExportStatus_DATA_EXPORT_STATUS_READY
+	case dataexportdomain.StatusFailed:
+		return userv1.DataExportStatus_DATA_EXPORT_STATUS_FAILED
+	default:
+		return userv1.DataExportStatus_DATA_EXPORT_STATUS_UNSPECIFIED
+	}
+}
+
+func (s *Server) audit(ctx context.Context, userID, action string, meta map[string]string) {
+	if s.auditLogger == nil {
+		return
+	}
+	orgID, _ := interceptors.GetOrgID(ctx)
+	b, _ := json.Marshal(meta)
+	s.auditLogger.LogEvent(ctx, orgID, userID, action, "user", string(b))
+}
+
 func domainUserToProto(u *domain.User) *userv1.User {
 	if u == nil {
 		return nil
@@ -10,6 +10,13 @@ import (
 	"google.golang.org/grpc/status"
 
 	userv1 "zero-trust-control-plane/backend/api/generated/user/v1"
+	"zero-trust-control-plane/backend/internal/dataexport"
+	dataexportdomain "zero-trust-control-plane/backend/internal/dataexport/domain"
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
 	"zero-trust-control-plane/backend/internal/user/domain"
 )
 
@@ -39,6 +46,14 @@ func (m *mockUserRepo) Create(ctx context.Context, u *domain.User) error {
 	return nil
 }
 
+func (m *mockUserRepo) CreateWithIdentity(ctx context.Context, u *domain.User, i *identitydomain.Identity, seats license.SeatReserver) error {
+	return nil
+}
+
+func (m *mockUserRepo) CreateWithMembership(ctx context.Context, u *domain.User, mem *membershipdomain.Membership, d *devicedomain.Device, seats license.SeatReserver) error {
+	return nil
+}
+
 func (m *mockUserRepo) Update(ctx context.Context, u *domain.User) error {
 	return nil
 }
@@ -47,6 +62,18 @@ func (m *mockUserRepo) SetPhoneVerified(ctx context.Context, userID, phone strin
 	return nil
 }
 
+func (m *mockUserRepo) ChangePhone(ctx context.Context, userID, oldPhone, newPhone string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockUserRepo) ChangeEmail(ctx context.Context, userID, oldEmail, newEmail string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockUserRepo) ResetMFA(ctx context.Context, userID string) (bool, error) {
+	return false, nil
+}
+
 func TestGetUser_Success(t *testing.T) {
 	now := time.Now().UTC()
 	user := &domain.User{
@@ -60,7 +87,7 @@ func TestGetUser_Success(t *testing.T) {
 	repo := &mockUserRepo{
 		usersByID: map[string]*domain.User{"user-1": user},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.GetUser(ctx, &userv1.GetUserRequest{UserId: "user-1"})
@@ -88,7 +115,7 @@ func TestGetUser_NotFound(t *testing.T) {
 	repo := &mockUserRepo{
 		usersByID: make(map[string]*domain.User),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetUser(ctx, &userv1.GetUserRequest{UserId: "nonexistent"})
@@ -106,7 +133,7 @@ func TestGetUser_NotFound(t *testing.T) {
 
 func TestGetUser_InvalidUserID(t *testing.T) {
 	repo := &mockUserRepo{usersByID: make(map[string]*domain.User)}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -140,7 +167,7 @@ func TestGetUser_RepositoryError(t *testing.T) {
 		usersByID:  make(map[string]*domain.User),
 		getByIDErr: errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetUser(ctx, &userv1.GetUserRequest{UserId: "user-1"})
@@ -157,7 +184,7 @@ func TestGetUser_RepositoryError(t *testing.T) {
 }
 
 func TestGetUser_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetUser(ctx, &userv1.GetUserRequest{UserId: "user-1"})
@@ -186,7 +213,7 @@ func TestGetUserByEmail_Success(t *testing.T) {
 	repo := &mockUserRepo{
 		usersByEmail: map[string]*domain.User{"test@example.com": user},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.GetUserByEmail(ctx, &userv1.GetUserByEmailRequest{Email: "test@example.com"})
@@ -205,7 +232,7 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 	repo := &mockUserRepo{
 		usersByEmail: make(map[string]*domain.User),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetUserByEmail(ctx, &userv1.GetUserByEmailRequest{Email: "nonexistent@example.com"})
@@ -223,7 +250,7 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 
 func TestGetUserByEmail_InvalidEmail(t *testing.T) {
 	repo := &mockUserRepo{usersByEmail: make(map[string]*domain.User)}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -257,7 +284,7 @@ func TestGetUserByEmail_RepositoryError(t *testing.T) {
 		usersByEmail: make(map[string]*domain.User),
 		getByEmailErr: errors.New("database error"),
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetUserByEmail(ctx, &userv1.GetUserByEmailRequest{Email: "test@example.com"})
@@ -274,7 +301,7 @@ func TestGetUserByEmail_RepositoryError(t *testing.T) {
 }
 
 func TestGetUserByEmail_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.GetUserByEmail(ctx, &userv1.GetUserByEmailRequest{Email: "test@example.com"})
@@ -303,7 +330,7 @@ func TestGetUser_DisabledStatus(t *testing.T) {
 	repo := &mockUserRepo{
 		usersByID: map[string]*domain.User{"user-1": user},
 	}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	resp, err := srv.GetUser(ctx, &userv1.GetUserRequest{UserId: "user-1"})
@@ -317,7 +344,7 @@ func TestGetUser_DisabledStatus(t *testing.T) {
 
 func TestListUsers_Unimplemented(t *testing.T) {
 	repo := &mockUserRepo{usersByID: make(map[string]*domain.User)}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListUsers(ctx, &userv1.ListUsersRequest{})
@@ -334,7 +361,7 @@ func TestListUsers_Unimplemented(t *testing.T) {
 }
 
 func TestListUsers_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.ListUsers(ctx, &userv1.ListUsersRequest{})
@@ -352,7 +379,7 @@ func TestListUsers_NilRepo(t *testing.T) {
 
 func TestDisableUser_Unimplemented(t *testing.T) {
 	repo := &mockUserRepo{usersByID: make(map[string]*domain.User)}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.DisableUser(ctx, &userv1.DisableUserRequest{UserId: "user-1"})
@@ -369,7 +396,7 @@ func TestDisableUser_Unimplemented(t *testing.T) {
 }
 
 func TestDisableUser_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.DisableUser(ctx, &userv1.DisableUserRequest{UserId: "user-1"})
@@ -387,7 +414,7 @@ func TestDisableUser_NilRepo(t *testing.T) {
 
 func TestEnableUser_Unimplemented(t *testing.T) {
 	repo := &mockUserRepo{usersByID: make(map[string]*domain.User)}
-	srv := NewServer(repo)
+	srv := NewServer(repo, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.EnableUser(ctx, &userv1.EnableUserRequest{UserId: "user-1"})
@@ -404,7 +431,7 @@ func TestEnableUser_Unimplemented(t *testing.T) {
 }
 
 func TestEnableUser_NilRepo(t *testing.T) {
-	srv := NewServer(nil)
+	srv := NewServer(nil, nil, nil)
 	ctx := context.Background()
 
 	_, err := srv.EnableUser(ctx, &userv1.EnableUserRequest{UserId: "user-1"})
@@ -419,3 +446,118 @@ func TestEnableUser_NilRepo(t *testing.T) {
 		t.Errorf("status code = %v, want %v", st.Code(), codes.Unimplemented)
 	}
 }
+
+// memDataExportRepo keeps data exports in memory; the exported user has no rows besides the profile.
+type memDataExportRepo struct {
+	exports []*dataexportdomain.Export
+}
+
+func (m *memDataExportRepo) Create(ctx context.Context, e *dataexportdomain.Export) error {
+	m.exports = append(m.exports, e)
+	return nil
+}
+
+func (m *memDataExportRepo) HasActive(ctx context.Context, userID string) (bool, error) {
+	for _, e := range m.exports {
+		if e.UserID == userID && e.Status == dataexportdomain.StatusPending {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memDataExportRepo) GetByTokenHash(ctx context.Context, hash string) (*dataexportdomain.Export, error) {
+	for _, e := range m.exports {
+		if e.TokenHash == hash {
+			c := *e
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memDataExportRepo) Claim(ctx context.Context, now, staleBefore time.Time) (*dataexportdomain.Export, error) {
+	for _, e := range m.exports {
+		if e.Status == dataexportdomain.StatusPending {
+			e.Status = dataexportdomain.StatusGenerating
+			c := *e
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memDataExportRepo) Finish(ctx context.Context, orgID, id string, st dataexportdomain.Status, archive []byte, reason string, now time.Time) error {
+	for _, e := range m.exports {
+		if e.ID == id {
+			e.Status, e.Archive, e.Error, e.CompletedAt = st, archive, reason, &now
+		}
+	}
+	return nil
+}
+
+func (m *memDataExportRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *memDataExportRepo) HomeOrg(ctx context.Context, userID string) (string, error) {
+	return "", nil
+}
+
+func (m *memDataExportRepo) Records(ctx context.Context, userID string) (*dataexportdomain.Records, error) {
+	return &dataexportdomain.Records{}, nil
+}
+
+type recordingAuditLogger struct{ actions []string }
+
+func (r *recordingAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	r.actions = append(r.actions, userID+":"+action)
+}
+
+func TestExportMyDataAndDownload(t *testing.T) {
+	repo := &mockUserRepo{usersByID: map[string]*domain.User{"user-1": {ID: "user-1", Email: "ada@example.com", Status: domain.UserStatusActive}}}
+	exports := dataexport.NewService(&memDataExportRepo{}, repo, nil, 72*time.Hour)
+	auditLogger := &recordingAuditLogger{}
+	srv := NewServer(repo, exports, auditLogger)
+	ctx := interceptors.WithIdentity(context.Background(), "user-1", "org-1", "session-1")
+
+	resp, err := srv.ExportMyData(ctx, &userv1.ExportMyDataRequest{})
+	if err != nil {
+		t.Fatalf("ExportMyData: %v", err)
+	}
+	if resp.GetExportId() == "" || resp.GetDownloadToken() == "" || resp.GetExpiresAt() == nil {
+		t.Fatalf("response = %v", resp)
+	}
+	if _, err := srv.ExportMyData(ctx, &userv1.ExportMyDataRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second request: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	download := &userv1.DownloadDataExportRequest{DownloadToken: resp.GetDownloadToken()}
+	got, err := srv.DownloadDataExport(ctx, download)
+	if err != nil || got.GetStatus() != userv1.DataExportStatus_DATA_EXPORT_STATUS_PENDING || len(got.GetArchive()) != 0 {
+		t.Fatalf("DownloadDataExport before the job = %v, %v", got, err)
+	}
+	if _, err := exports.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	got, err = srv.DownloadDataExport(ctx, download)
+	if err != nil || got.GetStatus() != userv1.DataExportStatus_DATA_EXPORT_STATUS_READY || len(got.GetArchive()) == 0 || got.GetFileName() == "" {
+		t.Fatalf("DownloadDataExport after the job = %v, %v", got, err)
+	}
+	other := interceptors.WithIdentity(context.Background(), "user-2", "org-1", "session-2")
+	if _, err := srv.DownloadDataExport(other, download); status.Code(err) != codes.NotFound {
+		t.Errorf("other user: code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := srv.DownloadDataExport(ctx, &userv1.DownloadDataExportRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("missing token: code = %v, want InvalidArgument", status.Code(err))
+	}
+	if _, err := srv.ExportMyData(context.Background(), &userv1.ExportMyDataRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("no identity: code = %v, want Unauthenticated", status.Code(err))
+	}
+	want := []string{"user-1:data_export_requested", "user-1:data_export_downloaded"}
+	if len(auditLogger.actions) != len(want) || auditLogger.actions[0] != want[0] || auditLogger.actions[1] != want[1] {
+		t.Errorf("audit = %v, want %v", auditLogger.actions, want)
+	}
+	if _, err := NewServer(repo, nil, nil).ExportMyData(ctx, &userv1.ExportMyDataRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("nil service: code = %v, want Unimplemented", status.Code(err))
+	}
+}
@@ -7,16 +7,32 @@ import (
 	"time"
 
 	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/orgquota"
+	"zero-trust-control-plane/backend/internal/pii"
+	piidomain "zero-trust-control-plane/backend/internal/pii/domain"
 	"zero-trust-control-plane/backend/internal/user/domain"
 )
 
+// Encrypted columns; the column name is authenticated with each value.
+const (
+	columnEmail = "users.email"
+	columnPhone = "users.phone"
+)
+
 type PostgresRepository struct {
+	db      *sql.DB
 	queries *gen.Queries
+	keyring *pii.Keyring
 }
 
-// NewPostgresRepository returns a user repository that uses the given db for persistence.
-func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{queries: gen.New(db)}
+// NewPostgresRepository returns a user repository that uses the given db for persistence. Email and phone are
+// encrypted with the platform data key of keyring; with a nil keyring they are stored in plaintext.
+func NewPostgresRepository(db *sql.DB, keyring *pii.Keyring) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db), keyring: keyring}
 }
 
 // GetByID returns the user for id, or nil if not found.
@@ -29,37 +45,130 @@ func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*domain.Us
 		}
 		return nil, err
 	}
-	return genUserToDomain(&u), nil
+	return r.toDomain(ctx, &u)
 }
 
 // GetByEmail returns the user with the given email, or nil if not found.
 // It returns an error only for database failures, not for missing rows.
 func (r *PostgresRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	u, err := r.queries.GetUserByEmail(ctx, email)
+	hash, err := r.keyring.BlindIndex(ctx, columnEmail, email)
+	if err != nil {
+		return nil, err
+	}
+	u, err := r.queries.GetUserByEmail(ctx, gen.GetUserByEmailParams{EmailHash: nullString(hash), Email: email})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return genUserToDomain(&u), nil
+	return r.toDomain(ctx, &u)
 }
 
 // Create persists the user to the database. The user must have ID set; it is not assigned by this method.
 func (r *PostgresRepository) Create(ctx context.Context, u *domain.User) error {
-	name := sql.NullString{String: u.Name, Valid: u.Name != ""}
-	phone := sql.NullString{String: u.Phone, Valid: u.Phone != ""}
-	_, err := r.queries.CreateUser(ctx, gen.CreateUserParams{
+	params, err := r.createParams(ctx, u)
+	if err != nil {
+		return err
+	}
+	_, err = r.queries.CreateUser(ctx, params)
+	return err
+}
+
+// CreateWithIdentity persists the user and its identity in one transaction, after seats reserved a license seat in
+// it (license.ErrSeatLimitReached, license.ErrLicenseExpired). seats may be nil.
+func (r *PostgresRepository) CreateWithIdentity(ctx context.Context, u *domain.User, i *identitydomain.Identity, seats license.SeatReserver) error {
+	params, err := r.createParams(ctx, u)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	if seats != nil {
+		if err := seats.ReserveSeat(ctx, q); err != nil {
+			return err
+		}
+	}
+	if _, err := q.CreateUser(ctx, params); err != nil {
+		return err
+	}
+	if _, err := q.CreateIdentity(ctx, gen.CreateIdentityParams{
+		ID:           i.ID,
+		UserID:       i.UserID,
+		Provider:     gen.IdentityProvider(i.Provider),
+		ProviderID:   i.ProviderID,
+		PasswordHash: nullString(i.PasswordHash),
+		CreatedAt:    i.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateWithMembership persists the user, its membership and its device in one transaction, so a failure leaves none
+// of them behind. seats reserves a license seat first and may be nil; the org's max_users and max_devices quotas are
+// checked as by the membership and device repositories (orgquota.ErrUserQuotaExceeded,
+// orgquota.ErrDeviceQuotaExceeded).
+func (r *PostgresRepository) CreateWithMembership(ctx context.Context, u *domain.User, m *membershipdomain.Membership, d *devicedomain.Device, seats license.SeatReserver) error {
+	params, err := r.createParams(ctx, u)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	if seats != nil {
+		if err := seats.ReserveSeat(ctx, q); err != nil {
+			return err
+		}
+	}
+	if _, err := q.CreateUser(ctx, params); err != nil {
+		return err
+	}
+	if err := orgquota.ReserveUser(ctx, q, m.OrgID); err != nil {
+		return err
+	}
+	if _, err := q.CreateMembership(ctx, gen.CreateMembershipParams{
+		ID: m.ID, UserID: m.UserID, OrgID: m.OrgID, Role: gen.Role(m.Role), CreatedAt: m.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	if err := orgquota.ReserveDevice(ctx, q, d.OrgID); err != nil {
+		return err
+	}
+	if _, err := q.CreateDevice(ctx, gen.CreateDeviceParams{
+		ID: d.ID, UserID: d.UserID, OrgID: d.OrgID, Fingerprint: d.Fingerprint,
+		Trusted: d.Trusted, CreatedAt: d.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// createParams encrypts the PII of u into the parameters of CreateUser.
+func (r *PostgresRepository) createParams(ctx context.Context, u *domain.User) (gen.CreateUserParams, error) {
+	email, phone, hash, err := r.encryptPII(ctx, u.Email, u.Phone)
+	if err != nil {
+		return gen.CreateUserParams{}, err
+	}
+	return gen.CreateUserParams{
 		ID:            u.ID,
-		Email:         u.Email,
-		Name:          name,
+		Email:         email,
+		Name:          nullString(u.Name),
 		Phone:         phone,
 		PhoneVerified: u.PhoneVerified,
 		Status:        gen.UserStatus(u.Status),
 		CreatedAt:     u.CreatedAt,
 		UpdatedAt:     u.UpdatedAt,
-	})
-	return err
+		EmailHash:     hash,
+	}, nil
 }
 
 // Update updates the existing user record in the database. If the user has PhoneVerified true, phone is preserved and not overwritten.
@@ -71,29 +180,35 @@ func (r *PostgresRepository) Update(ctx context.Context, u *domain.User) error {
 		}
 		return err
 	}
-	name := sql.NullString{String: u.Name, Valid: u.Name != ""}
-	phone := sql.NullString{String: u.Phone, Valid: u.Phone != ""}
+	email, phone, hash, err := r.encryptPII(ctx, u.Email, u.Phone)
+	if err != nil {
+		return err
+	}
 	if current.PhoneVerified {
 		phone = current.Phone
 	}
 	_, err = r.queries.UpdateUser(ctx, gen.UpdateUserParams{
 		ID:            u.ID,
-		Email:         u.Email,
-		Name:          name,
+		Email:         email,
+		Name:          nullString(u.Name),
 		Phone:         phone,
 		PhoneVerified: current.PhoneVerified,
 		Status:        gen.UserStatus(u.Status),
 		UpdatedAt:     u.UpdatedAt,
+		EmailHash:     hash,
 	})
 	return err
 }
 
 // SetPhoneVerified sets the user's phone and phone_verified only when phone is currently empty and not verified. Returns nil if no row was updated.
 func (r *PostgresRepository) SetPhoneVerified(ctx context.Context, userID, phone string) error {
-	phoneVal := sql.NullString{String: phone, Valid: phone != ""}
-	_, err := r.queries.SetPhoneVerified(ctx, gen.SetPhoneVerifiedParams{
+	encrypted, err := r.keyring.Encrypt(ctx, piidomain.ScopePlatform, columnPhone, phone)
+	if err != nil {
+		return err
+	}
+	_, err = r.queries.SetPhoneVerified(ctx, gen.SetPhoneVerifiedParams{
 		ID:        userID,
-		Phone:     phoneVal,
+		Phone:     nullString(encrypted),
 		UpdatedAt: time.Now().UTC(),
 	})
 	if err != nil {
@@ -105,6 +220,189 @@ func (r *PostgresRepository) SetPhoneVerified(ctx context.Context, userID, phone
 	return nil
 }
 
+// ChangePhone replaces the user's phone with newPhone and marks it verified, only if it is still oldPhone.
+// The encrypted phone is compared after decryption, then swapped only if the stored ciphertext did not change.
+func (r *PostgresRepository) ChangePhone(ctx context.Context, userID, oldPhone, newPhone string) (bool, error) {
+	current, err := r.queries.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	phone, err := r.keyring.Decrypt(ctx, columnPhone, current.Phone.String)
+	if err != nil {
+		return false, err
+	}
+	if phone != oldPhone {
+		return false, nil
+	}
+	encrypted, err := r.keyring.Encrypt(ctx, piidomain.ScopePlatform, columnPhone, newPhone)
+	if err != nil {
+		return false, err
+	}
+	n, err := r.queries.ChangeUserPhone(ctx, gen.ChangeUserPhoneParams{
+		NewPhone:  nullString(encrypted),
+		UpdatedAt: time.Now().UTC(),
+		ID:        userID,
+		OldPhone:  current.Phone.String,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ChangeEmail replaces the user's email with newEmail, and the provider_id of their local identity with it, in one
+// transaction, only if the email is still oldEmail. The encrypted email is compared after decryption, then swapped
+// only if the stored ciphertext did not change.
+func (r *PostgresRepository) ChangeEmail(ctx context.Context, userID, oldEmail, newEmail string) (bool, error) {
+	current, err := r.queries.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	email, err := r.keyring.Decrypt(ctx, columnEmail, current.Email)
+	if err != nil {
+		return false, err
+	}
+	if email != oldEmail {
+		return false, nil
+	}
+	encrypted, err := r.keyring.Encrypt(ctx, piidomain.ScopePlatform, columnEmail, newEmail)
+	if err != nil {
+		return false, err
+	}
+	hash, err := r.keyring.BlindIndex(ctx, columnEmail, newEmail)
+	if err != nil {
+		return false, err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	n, err := q.ChangeUserEmail(ctx, gen.ChangeUserEmailParams{
+		NewEmail:  encrypted,
+		EmailHash: nullString(hash),
+		UpdatedAt: time.Now().UTC(),
+		ID:        userID,
+		OldEmail:  current.Email,
+	})
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+	if _, err := q.UpdateLocalIdentityProviderID(ctx, gen.UpdateLocalIdentityProviderIDParams{ProviderID: newEmail, UserID: userID}); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ResetMFA clears the user's phone and phone verification and requires MFA with a new phone at next sign-in.
+func (r *PostgresRepository) ResetMFA(ctx context.Context, userID string) (bool, error) {
+	n, err := r.queries.ResetUserMFA(ctx, gen.ResetUserMFAParams{ID: userID, UpdatedAt: time.Now().UTC()})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// CountLicensed counts the users that take a license seat: active users with an identity that are not honeytokens.
+// Implements license.UserCounter.
+func (r *PostgresRepository) CountLicensed(ctx context.Context) (int64, error) {
+	return r.queries.CountLicensedUsers(ctx)
+}
+
+// ReencryptPII re-encrypts the email and phone of up to limit users after afterID that are not encrypted with the
+// active platform key, and sets their email_hash. Implements pii.Reencrypter.
+func (r *PostgresRepository) ReencryptPII(ctx context.Context, afterID string, limit int) (string, int, error) {
+	prefix, err := r.keyring.CiphertextPrefix(ctx, piidomain.ScopePlatform)
+	if err != nil {
+		return "", 0, err
+	}
+	rows, err := r.queries.ListUsersForPIIReencryption(ctx, gen.ListUsersForPIIReencryptionParams{
+		AfterID:   afterID,
+		KeyPrefix: prefix,
+		RowLimit:  int32(limit),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	updated := 0
+	for i := range rows {
+		u, err := r.toDomain(ctx, &rows[i])
+		if err != nil {
+			return "", updated, err
+		}
+		email, phone, hash, err := r.encryptPII(ctx, u.Email, u.Phone)
+		if err != nil {
+			return "", updated, err
+		}
+		n, err := r.queries.UpdateUserPII(ctx, gen.UpdateUserPIIParams{
+			Email:     email,
+			Phone:     phone,
+			EmailHash: hash,
+			ID:        u.ID,
+			OldEmail:  rows[i].Email,
+			OldPhone:  rows[i].Phone.String,
+		})
+		if err != nil {
+			return "", updated, err
+		}
+		updated += int(n)
+	}
+	if len(rows) < limit {
+		return "", updated, nil
+	}
+	return rows[len(rows)-1].ID, updated, nil
+}
+
+// encryptPII returns the stored forms of email and phone and the email's blind index.
+func (r *PostgresRepository) encryptPII(ctx context.Context, email, phone string) (string, sql.NullString, sql.NullString, error) {
+	encEmail, err := r.keyring.Encrypt(ctx, piidomain.ScopePlatform, columnEmail, email)
+	if err != nil {
+		return "", sql.NullString{}, sql.NullString{}, err
+	}
+	encPhone, err := r.keyring.Encrypt(ctx, piidomain.ScopePlatform, columnPhone, phone)
+	if err != nil {
+		return "", sql.NullString{}, sql.NullString{}, err
+	}
+	hash, err := r.keyring.BlindIndex(ctx, columnEmail, email)
+	if err != nil {
+		return "", sql.NullString{}, sql.NullString{}, err
+	}
+	return encEmail, nullString(encPhone), nullString(hash), nil
+}
+
+// toDomain decrypts the user's email and phone and converts it to the domain type.
+func (r *PostgresRepository) toDomain(ctx context.Context, u *gen.User) (*domain.User, error) {
+	email, err := r.keyring.Decrypt(ctx, columnEmail, u.Email)
+	if err != nil {
+		return nil, err
+	}
+	phone, err := r.keyring.Decrypt(ctx, columnPhone, u.Phone.String)
+	if err != nil {
+		return nil, err
+	}
+	d := genUserToDomain(u)
+	d.Email = email
+	d.Phone = phone
+	return d, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 func genUserToDomain(u *gen.User) *domain.User {
 	if u == nil {
 		return nil
@@ -118,13 +416,14 @@ func genUserToDomain(u *gen.User) *domain.User {
 		phone = u.Phone.String
 	}
 	return &domain.User{
-		ID:            u.ID,
-		Email:         u.Email,
-		Name:          name,
-		Phone:         phone,
-		PhoneVerified: u.PhoneVerified,
-		Status:        domain.UserStatus(u.Status),
-		CreatedAt:     u.CreatedAt,
-		UpdatedAt:     u.UpdatedAt,
+		ID:               u.ID,
+		Email:            u.Email,
+		Name:             name,
+		Phone:            phone,
+		PhoneVerified:    u.PhoneVerified,
+		MFAResetRequired: u.MfaResetRequired,
+		Status:           domain.UserStatus(u.Status),
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
 	}
 }
@@ -3,6 +3,10 @@ package repository
 import (
 	"context"
 
+	devicedomain "zero-trust-control-plane/backend/internal/device/domain"
+	identitydomain "zero-trust-control-plane/backend/internal/identity/domain"
+	"zero-trust-control-plane/backend/internal/license"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
 	"zero-trust-control-plane/backend/internal/user/domain"
 )
 
@@ -11,7 +15,21 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Create(ctx context.Context, u *domain.User) error
+	// CreateWithIdentity creates the user and its identity in one transaction, after seats reserved a license seat in
+	// it. seats may be nil.
+	CreateWithIdentity(ctx context.Context, u *domain.User, i *identitydomain.Identity, seats license.SeatReserver) error
+	// CreateWithMembership creates the user, its membership and its device in one transaction, after seats reserved a
+	// license seat in it and the org's user and device quotas were checked. seats may be nil.
+	CreateWithMembership(ctx context.Context, u *domain.User, m *membershipdomain.Membership, d *devicedomain.Device, seats license.SeatReserver) error
 	Update(ctx context.Context, u *domain.User) error
 	// SetPhoneVerified sets phone and phone_verified only when user has no phone and not yet verified. No-op if already set.
 	SetPhoneVerified(ctx context.Context, userID, phone string) error
+	// ChangePhone replaces the phone with newPhone (verified) only if it is still oldPhone ("" for none). Returns false
+	// when the phone changed meanwhile.
+	ChangePhone(ctx context.Context, userID, oldPhone, newPhone string) (bool, error)
+	// ChangeEmail replaces the email with newEmail, together with the provider_id of the user's local identity, only
+	// if it is still oldEmail. Returns false when the email changed meanwhile.
+	ChangeEmail(ctx context.Context, userID, oldEmail, newEmail string) (bool, error)
+	// ResetMFA clears the phone and sets MFAResetRequired. Returns false when the user does not exist.
+	ResetMFA(ctx context.Context, userID string) (bool, error)
 }
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+)
+
+// Type is the value type of a custom attribute. Values are stored as text; the type decides which values are
+// accepted and how they appear in policy input.
+type Type string
+
+const (
+	TypeString  Type = "string"
+	TypeNumber  Type = "number"
+	TypeBoolean Type = "boolean"
+	// TypeEnum accepts only the definition's AllowedValues.
+	TypeEnum Type = "enum"
+)
+
+const (
+	// MaxDefinitionsPerOrg caps the number of custom attributes an org can define.
+	MaxDefinitionsPerOrg = 50
+	// MaxValueLength is the maximum length of an attribute value and of an allowed enum value.
+	MaxValueLength = 256
+	// MaxAllowedValues caps the values of an enum attribute.
+	MaxAllowedValues = 100
+	// MaxDisplayNameLength and MaxDescriptionLength bound the descriptive fields of a definition.
+	MaxDisplayNameLength = 100
+	MaxDescriptionLength = 500
+)
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// Definition is an attribute an org defines for its members (e.g. department, employee_id, cost_center).
+type Definition struct {
+	OrgID         string
+	Key           string
+	DisplayName   string
+	Description   string
+	Type          Type
+	AllowedValues []string // enum only
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Member is a member of the org with their attribute values (key → value).
+type Member struct {
+	MembershipID string
+	UserID       string
+	Role         membershipdomain.Role
+	Attributes   map[string]string
+}
+
+// Filter matches members whose attribute Key has exactly Value.
+type Filter struct {
+	Key   string
+	Value string
+}
+
+// ValidKey reports whether key is a valid attribute key: a lowercase letter followed by up to 62 lowercase
+// letters, digits and underscores. Keys appear as-is in policy input (input.user.attributes.<key>).
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Validate checks the definition and normalizes its enum values (trimmed, without duplicates).
+func (d *Definition) Validate() error {
+	if !ValidKey(d.Key) {
+		return fmt.Errorf("invalid key %q (use a lowercase letter followed by lowercase letters, digits, and underscores)", d.Key)
+	}
+	if len(d.DisplayName) > MaxDisplayNameLength {
+		return fmt.Errorf("display_name must be at most %d characters", MaxDisplayNameLength)
+	}
+	if len(d.Description) > MaxDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", MaxDescriptionLength)
+	}
+	switch d.Type {
+	case TypeString, TypeNumber, TypeBoolean:
+		if len(d.AllowedValues) > 0 {
+			return fmt.Errorf("allowed_values is only valid for enum attributes")
+		}
+		d.AllowedValues = []string{}
+	case TypeEnum:
+		seen := make(map[string]bool, len(d.AllowedValues))
+		values := make([]string, 0, len(d.AllowedValues))
+		for _, v := range d.AllowedValues {
+			v = strings.TrimSpace(v)
+			if v == "" || len(v) > MaxValueLength {
+				return fmt.Errorf("allowed values must be 1 to %d characters", MaxValueLength)
+			}
+			if !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("an enum attribute needs allowed_values")
+		}
+		if len(values) > MaxAllowedValues {
+			return fmt.Errorf("allowed_values exceeds %d entries", MaxAllowedValues)
+		}
+		d.AllowedValues = values
+	default:
+		return fmt.Errorf("type must be string, number, boolean or enum")
+	}
+	return nil
+}
+
+// Normalize validates value against the definition and returns its canonical form: trimmed, numbers without
+// redundant digits ("1.50" → "1.5") and booleans as "true" or "false". Search filters are normalized the same way,
+// so they match regardless of how the value was written.
+func (d *Definition) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("%s: value required (remove the attribute instead)", d.Key)
+	}
+	if len(value) > MaxValueLength {
+		return "", fmt.Errorf("%s: value must be at most %d characters", d.Key, MaxValueLength)
+	}
+	switch d.Type {
+	case TypeNumber:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s: value must be a number", d.Key)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case TypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%s: value must be true or false", d.Key)
+		}
+		return strconv.FormatBool(b), nil
+	case TypeEnum:
+		for _, v := range d.AllowedValues {
+			if v == value {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("%s: value must be one of %s", d.Key, strings.Join(d.AllowedValues, ", "))
+	default:
+		return value, nil
+	}
+}
+
+// PolicyValue returns a stored value as it appears in policy input: a number for number attributes, a boolean for
+// boolean attributes, otherwise the string. A value that no longer parses is passed as its string.
+func (d *Definition) PolicyValue(value string) interface{} {
+	switch d.Type {
+	case TypeNumber:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case TypeBoolean:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// PolicyInput returns the member's values (key → value) as policy input, typed by their definitions. Values
+// without a definition are left out.
+func PolicyInput(defs []*Definition, values map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(values))
+	for _, d := range defs {
+		if v, ok := values[d.Key]; ok {
+			out[d.Key] = d.PolicyValue(v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     Definition
+		wantErr bool
+	}{
+		{"string", Definition{Key: "department", Type: TypeString}, false},
+		{"enum", Definition{Key: "cost_center", Type: TypeEnum, AllowedValues: []string{" cc-1 ", "cc-2", "cc-1"}}, false},
+		{"bad key", Definition{Key: "Department", Type: TypeString}, true},
+		{"key starts with digit", Definition{Key: "1st", Type: TypeString}, true},
+		{"unknown type", Definition{Key: "level", Type: "date"}, true},
+		{"enum without values", Definition{Key: "region", Type: TypeEnum}, true},
+		{"values on string", Definition{Key: "region", Type: TypeString, AllowedValues: []string{"eu"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.def
+			if err := d.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	d := Definition{Key: "cost_center", Type: TypeEnum, AllowedValues: []string{" cc-1 ", "cc-2", "cc-1"}}
+	_ = d.Validate()
+	if !reflect.DeepEqual(d.AllowedValues, []string{"cc-1", "cc-2"}) {
+		t.Errorf("AllowedValues = %v, want trimmed and deduplicated", d.AllowedValues)
+	}
+}
+
+func TestDefinition_Normalize(t *testing.T) {
+	tests := []struct {
+		def     Definition
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{Definition{Key: "department", Type: TypeString}, "  Sales ", "Sales", false},
+		{Definition{Key: "department", Type: TypeString}, "  ", "", true},
+		{Definition{Key: "level", Type: TypeNumber}, "1.50", "1.5", false},
+		{Definition{Key: "level", Type: TypeNumber}, "high", "", true},
+		{Definition{Key: "contractor", Type: TypeBoolean}, "TRUE", "true", false},
+		{Definition{Key: "contractor", Type: TypeBoolean}, "yes", "", true},
+		{Definition{Key: "region", Type: TypeEnum, AllowedValues: []string{"eu", "us"}}, "eu", "eu", false},
+		{Definition{Key: "region", Type: TypeEnum, AllowedValues: []string{"eu", "us"}}, "apac", "", true},
+	}
+	for _, tt := range tests {
+		got, err := tt.def.Normalize(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s.Normalize(%q) = %q, %v; want %q, wantErr %v", tt.def.Key, tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPolicyInput(t *testing.T) {
+	defs := []*Definition{
+		{Key: "contractor", Type: TypeBoolean},
+		{Key: "department", Type: TypeString},
+		{Key: "level", Type: TypeNumber},
+		{Key: "region", Type: TypeEnum, AllowedValues: []string{"eu"}},
+	}
+	got := PolicyInput(defs, map[string]string{"contractor": "true", "department": "Sales", "level": "3", "stale": "x"})
+	want := map[string]interface{}{"contractor": true, "department": "Sales", "level": float64(3)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PolicyInput = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,394 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "zero-trust-control-plane/backend/api/generated/common/v1"
+	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+	userattributev1 "zero-trust-control-plane/backend/api/generated/userattribute/v1"
+	"zero-trust-control-plane/backend/internal/audit"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/platform/rbac"
+	"zero-trust-control-plane/backend/internal/userattribute/domain"
+	"zero-trust-control-plane/backend/internal/userattribute/repository"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+	// maxFilters caps the filters of a SearchMembers request.
+	maxFilters = 10
+)
+
+// Server implements UserAttributeService (proto server) for org-defined member attributes.
+// Proto: userattribute/userattribute.proto → internal/userattribute/handler.
+type Server struct {
+	userattributev1.UnimplementedUserAttributeServiceServer
+	repo           repository.Repository
+	membershipRepo rbac.OrgMembershipGetter
+	auditLogger    audit.AuditLogger
+}
+
+// NewServer returns a new UserAttribute gRPC server. If repo or membershipRepo is nil, all RPCs return
+// Unimplemented. auditLogger may be nil.
+func NewServer(repo repository.Repository, membershipRepo rbac.OrgMembershipGetter, auditLogger audit.AuditLogger) *Server {
+	return &Server{repo: repo, membershipRepo: membershipRepo, auditLogger: auditLogger}
+}
+
+// ListAttributeDefinitions returns the attribute definitions of the caller's org. Caller must be a member.
+func (s *Server) ListAttributeDefinitions(ctx context.Context, req *userattributev1.ListAttributeDefinitionsRequest) (*userattributev1.ListAttributeDefinitionsResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method ListAttributeDefinitions not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListDefinitions(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list attribute definitions")
+	}
+	out := make([]*userattributev1.AttributeDefinition, len(list))
+	for i, d := range list {
+		out[i] = definitionToProto(d)
+	}
+	return &userattributev1.ListAttributeDefinitionsResponse{Definitions: out}, nil
+}
+
+// UpsertAttributeDefinition creates or updates an attribute definition of the caller's org. The type of an existing
+// attribute cannot change, since stored values may not fit the new type. Caller must be org admin or owner.
+func (s *Server) UpsertAttributeDefinition(ctx context.Context, req *userattributev1.UpsertAttributeDefinitionRequest) (*userattributev1.UpsertAttributeDefinitionResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method UpsertAttributeDefinition not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	in := req.GetDefinition()
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "definition required")
+	}
+	d := &domain.Definition{
+		OrgID:         orgID,
+		Key:           strings.TrimSpace(in.GetKey()),
+		DisplayName:   strings.TrimSpace(in.GetDisplayName()),
+		Description:   strings.TrimSpace(in.GetDescription()),
+		Type:          protoTypeToDomain(in.GetType()),
+		AllowedValues: in.GetAllowedValues(),
+	}
+	if d.DisplayName == "" {
+		d.DisplayName = d.Key
+	}
+	if err := d.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	existing, err := s.repo.GetDefinition(ctx, orgID, d.Key)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up attribute definition")
+	}
+	now := time.Now().UTC()
+	d.CreatedAt, d.UpdatedAt = now, now
+	if existing != nil {
+		if existing.Type != d.Type {
+			return nil, status.Error(codes.FailedPrecondition, "the type of an attribute cannot change; delete and recreate it")
+		}
+		d.CreatedAt = existing.CreatedAt
+	} else {
+		list, err := s.repo.ListDefinitions(ctx, orgID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to list attribute definitions")
+		}
+		if len(list) >= domain.MaxDefinitionsPerOrg {
+			return nil, status.Errorf(codes.FailedPrecondition, "an organization can define at most %d attributes", domain.MaxDefinitionsPerOrg)
+		}
+	}
+	if err := s.repo.UpsertDefinition(ctx, d); err != nil {
+		return nil, status.Error(codes.Internal, "failed to save attribute definition")
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"key": d.Key, "type": string(d.Type)})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "attribute_definition_upserted", "user_attribute", string(meta))
+	}
+	return &userattributev1.UpsertAttributeDefinitionResponse{Definition: definitionToProto(d)}, nil
+}
+
+// DeleteAttributeDefinition deletes an attribute definition of the caller's org and every member's value for it.
+// Caller must be org admin or owner.
+func (s *Server) DeleteAttributeDefinition(ctx context.Context, req *userattributev1.DeleteAttributeDefinitionRequest) (*userattributev1.DeleteAttributeDefinitionResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method DeleteAttributeDefinition not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.TrimSpace(req.GetKey())
+	if key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key required")
+	}
+	deleted, err := s.repo.DeleteDefinition(ctx, orgID, key)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete attribute definition")
+	}
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "attribute not found")
+	}
+	if s.auditLogger != nil {
+		meta, _ := json.Marshal(map[string]string{"key": key})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "attribute_definition_deleted", "user_attribute", string(meta))
+	}
+	return &userattributev1.DeleteAttributeDefinitionResponse{}, nil
+}
+
+// GetMemberAttributes returns the attribute values of a member of the caller's org. Members may read their own;
+// reading another member's needs org admin or owner.
+func (s *Server) GetMemberAttributes(ctx context.Context, req *userattributev1.GetMemberAttributesRequest) (*userattributev1.GetMemberAttributesResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetMemberAttributes not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgMember(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	targetUserID := req.GetUserId()
+	if targetUserID == "" {
+		targetUserID = userID
+	}
+	if targetUserID != userID {
+		if _, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo); err != nil {
+			return nil, err
+		}
+	}
+	m, err := s.orgMember(ctx, orgID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.repo.GetMemberValues(ctx, orgID, targetUserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get member attributes")
+	}
+	return &userattributev1.GetMemberAttributesResponse{Member: memberToProto(m.UserID, m.Role, values)}, nil
+}
+
+// SetMemberAttributes sets and removes attribute values of a member of the caller's org. Every key must be defined
+// and every value valid for its type; values are stored normalized. Caller must be org admin or owner.
+func (s *Server) SetMemberAttributes(ctx context.Context, req *userattributev1.SetMemberAttributesRequest) (*userattributev1.SetMemberAttributesResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method SetMemberAttributes not implemented")
+	}
+	orgID, userID, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	if len(req.GetAttributes()) == 0 && len(req.GetRemoveKeys()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "attributes or remove_keys required")
+	}
+	m, err := s.orgMember(ctx, orgID, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	defs, err := s.definitions(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]string, len(req.GetAttributes()))
+	for key, value := range req.GetAttributes() {
+		d, ok := defs[key]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown attribute %q", key)
+		}
+		v, err := d.Normalize(value)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		set[key] = v
+	}
+	for _, key := range req.GetRemoveKeys() {
+		if _, ok := defs[key]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown attribute %q", key)
+		}
+		if _, ok := set[key]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "attribute %q is both set and removed", key)
+		}
+	}
+	if err := s.repo.SetMemberValues(ctx, m.ID, orgID, set, req.GetRemoveKeys(), time.Now().UTC()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to set member attributes")
+	}
+	if s.auditLogger != nil {
+		// Values may be personal data (e.g. employee ID); only the keys are logged.
+		setKeys := make([]string, 0, len(set))
+		for key := range set {
+			setKeys = append(setKeys, key)
+		}
+		sort.Strings(setKeys)
+		removed := append([]string{}, req.GetRemoveKeys()...)
+		meta, _ := json.Marshal(map[string]interface{}{"user_id": m.UserID, "set": setKeys, "removed": removed})
+		s.auditLogger.LogEvent(ctx, orgID, userID, "member_attributes_updated", "user_attribute", string(meta))
+	}
+	values, err := s.repo.GetMemberValues(ctx, orgID, m.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get member attributes")
+	}
+	return &userattributev1.SetMemberAttributesResponse{Member: memberToProto(m.UserID, m.Role, values)}, nil
+}
+
+// SearchMembers returns the members of the caller's org whose values match every filter, oldest membership first.
+// Filter values are normalized like stored values. Caller must be org admin or owner.
+func (s *Server) SearchMembers(ctx context.Context, req *userattributev1.SearchMembersRequest) (*userattributev1.SearchMembersResponse, error) {
+	if s.repo == nil || s.membershipRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "method SearchMembers not implemented")
+	}
+	orgID, _, err := rbac.RequireOrgAdmin(ctx, s.membershipRepo)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetFilters()) > maxFilters {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d filters", maxFilters)
+	}
+	var filters []domain.Filter
+	if len(req.GetFilters()) > 0 {
+		defs, err := s.definitions(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range req.GetFilters() {
+			d, ok := defs[f.GetKey()]
+			if !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "unknown attribute %q", f.GetKey())
+			}
+			v, err := d.Normalize(f.GetValue())
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			filters = append(filters, domain.Filter{Key: d.Key, Value: v})
+		}
+	}
+	pageSize := int32(defaultPageSize)
+	offset := int32(0)
+	if pag := req.GetPagination(); pag != nil {
+		if ps := pag.GetPageSize(); ps > 0 {
+			pageSize = ps
+		}
+		if tok := pag.GetPageToken(); tok != "" {
+			if n, err := strconv.ParseInt(tok, 10, 32); err == nil && n >= 0 {
+				offset = int32(n)
+			}
+		}
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	list, err := s.repo.SearchMembers(ctx, orgID, filters, pageSize, offset)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to search members")
+	}
+	out := make([]*userattributev1.MemberAttributes, len(list))
+	for i, m := range list {
+		out[i] = memberToProto(m.UserID, m.Role, m.Attributes)
+	}
+	result := &userattributev1.SearchMembersResponse{
+		Members:    out,
+		Pagination: &commonv1.PaginationResult{},
+	}
+	if len(list) == int(pageSize) {
+		result.Pagination.NextPageToken = strconv.Itoa(int(offset + pageSize))
+	}
+	return result, nil
+}
+
+// orgMember returns the user's membership in the org, or NotFound if they are not a member.
+func (s *Server) orgMember(ctx context.Context, orgID, userID string) (*membershipdomain.Membership, error) {
+	m, err := s.membershipRepo.GetMembershipByUserAndOrg(ctx, userID, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to look up membership")
+	}
+	if m == nil {
+		return nil, status.Error(codes.NotFound, "user is not a member of this organization")
+	}
+	return m, nil
+}
+
+// definitions returns the org's attribute definitions by key.
+func (s *Server) definitions(ctx context.Context, orgID string) (map[string]*domain.Definition, error) {
+	list, err := s.repo.ListDefinitions(ctx, orgID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list attribute definitions")
+	}
+	out := make(map[string]*domain.Definition, len(list))
+	for _, d := range list {
+		out[d.Key] = d
+	}
+	return out, nil
+}
+
+func protoTypeToDomain(t userattributev1.AttributeType) domain.Type {
+	switch t {
+	case userattributev1.AttributeType_ATTRIBUTE_TYPE_STRING:
+		return domain.TypeString
+	case userattributev1.AttributeType_ATTRIBUTE_TYPE_NUMBER:
+		return domain.TypeNumber
+	case userattributev1.AttributeType_ATTRIBUTE_TYPE_BOOLEAN:
+		return domain.TypeBoolean
+	case userattributev1.AttributeType_ATTRIBUTE_TYPE_ENUM:
+		return domain.TypeEnum
+	default:
+		return ""
+	}
+}
+
+func domainTypeToProto(t domain.Type) userattributev1.AttributeType {
+	switch t {
+	case domain.TypeString:
+		return userattributev1.AttributeType_ATTRIBUTE_TYPE_STRING
+	case domain.TypeNumber:
+		return userattributev1.AttributeType_ATTRIBUTE_TYPE_NUMBER
+	case domain.TypeBoolean:
+		return userattributev1.AttributeType_ATTRIBUTE_TYPE_BOOLEAN
+	case domain.TypeEnum:
+		return userattributev1.AttributeType_ATTRIBUTE_TYPE_ENUM
+	default:
+		return userattributev1.AttributeType_ATTRIBUTE_TYPE_UNSPECIFIED
+	}
+}
+
+func definitionToProto(d *domain.Definition) *userattributev1.AttributeDefinition {
+	return &userattributev1.AttributeDefinition{
+		Key:           d.Key,
+		DisplayName:   d.DisplayName,
+		Description:   d.Description,
+		Type:          domainTypeToProto(d.Type),
+		AllowedValues: d.AllowedValues,
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+		UpdatedAt:     timestamppb.New(d.UpdatedAt),
+	}
+}
+
+func memberToProto(userID string, role membershipdomain.Role, values map[string]string) *userattributev1.MemberAttributes {
+	out := &userattributev1.MemberAttributes{
+		UserId:     userID,
+		Role:       membershipv1.Role_ROLE_UNSPECIFIED,
+		Attributes: values,
+	}
+	switch role {
+	case membershipdomain.RoleOwner:
+		out.Role = membershipv1.Role_ROLE_OWNER
+	case membershipdomain.RoleAdmin:
+		out.Role = membershipv1.Role_ROLE_ADMIN
+	case membershipdomain.RoleMember:
+		out.Role = membershipv1.Role_ROLE_MEMBER
+	}
+	return out
+}
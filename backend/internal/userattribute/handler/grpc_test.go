@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	userattributev1 "zero-trust-control-plane/backend/api/generated/userattribute/v1"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/server/interceptors"
+	"zero-trust-control-plane/backend/internal/userattribute/domain"
+)
+
+// memRepo implements repository.Repository in memory for org-1. Values are keyed by membership ID.
+type memRepo struct {
+	defs    map[string]*domain.Definition
+	values  map[string]map[string]string
+	members mockMembershipRepo
+}
+
+func (m *memRepo) ListDefinitions(ctx context.Context, orgID string) ([]*domain.Definition, error) {
+	var out []*domain.Definition
+	for _, d := range m.defs {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+func (m *memRepo) GetDefinition(ctx context.Context, orgID, key string) (*domain.Definition, error) {
+	return m.defs[key], nil
+}
+
+func (m *memRepo) UpsertDefinition(ctx context.Context, d *domain.Definition) error {
+	m.defs[d.Key] = d
+	return nil
+}
+
+func (m *memRepo) DeleteDefinition(ctx context.Context, orgID, key string) (bool, error) {
+	_, ok := m.defs[key]
+	delete(m.defs, key)
+	for _, vals := range m.values {
+		delete(vals, key)
+	}
+	return ok, nil
+}
+
+func (m *memRepo) GetMemberValues(ctx context.Context, orgID, userID string) (map[string]string, error) {
+	out := map[string]string{}
+	if mem, ok := m.members[userID]; ok {
+		for k, v := range m.values[mem.ID] {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+func (m *memRepo) SetMemberValues(ctx context.Context, membershipID, orgID string, set map[string]string, remove []string, now time.Time) error {
+	if m.values[membershipID] == nil {
+		m.values[membershipID] = map[string]string{}
+	}
+	for _, k := range remove {
+		delete(m.values[membershipID], k)
+	}
+	for k, v := range set {
+		m.values[membershipID][k] = v
+	}
+	return nil
+}
+
+func (m *memRepo) SearchMembers(ctx context.Context, orgID string, filters []domain.Filter, limit, offset int32) ([]*domain.Member, error) {
+	var out []*domain.Member
+	for _, userID := range []string{"admin-1", "member-1", "member-2"} {
+		mem := m.members[userID]
+		vals, _ := m.GetMemberValues(ctx, orgID, userID)
+		match := true
+		for _, f := range filters {
+			if vals[f.Key] != f.Value {
+				match = false
+			}
+		}
+		if match {
+			out = append(out, &domain.Member{MembershipID: mem.ID, UserID: userID, Role: mem.Role, Attributes: vals})
+		}
+	}
+	return out, nil
+}
+
+type mockMembershipRepo map[string]*membershipdomain.Membership
+
+func (m mockMembershipRepo) GetMembershipByUserAndOrg(ctx context.Context, userID, orgID string) (*membershipdomain.Membership, error) {
+	mem, ok := m[userID]
+	if !ok || orgID != "org-1" {
+		return nil, nil
+	}
+	return mem, nil
+}
+
+type mockAuditLogger struct {
+	actions  []string
+	metadata []string
+}
+
+func (m *mockAuditLogger) LogEvent(ctx context.Context, orgID, userID, action, resource, metadata string) {
+	m.actions = append(m.actions, action)
+	m.metadata = append(m.metadata, metadata)
+}
+
+func newTestServer() (*Server, *memRepo, *mockAuditLogger) {
+	members := mockMembershipRepo{
+		"admin-1":  {ID: "m-admin-1", UserID: "admin-1", OrgID: "org-1", Role: membershipdomain.RoleAdmin},
+		"member-1": {ID: "m-member-1", UserID: "member-1", OrgID: "org-1", Role: membershipdomain.RoleMember},
+		"member-2": {ID: "m-member-2", UserID: "member-2", OrgID: "org-1", Role: membershipdomain.RoleMember},
+	}
+	repo := &memRepo{defs: map[string]*domain.Definition{}, values: map[string]map[string]string{}, members: members}
+	auditLogger := &mockAuditLogger{}
+	return NewServer(repo, members, auditLogger), repo, auditLogger
+}
+
+func ctxFor(userID string) context.Context {
+	return interceptors.WithIdentity(context.Background(), userID, "org-1", "session-1")
+}
+
+func define(t *testing.T, srv *Server, def *userattributev1.AttributeDefinition) {
+	t.Helper()
+	if _, err := srv.UpsertAttributeDefinition(ctxFor("admin-1"), &userattributev1.UpsertAttributeDefinitionRequest{Definition: def}); err != nil {
+		t.Fatalf("UpsertAttributeDefinition(%s): %v", def.GetKey(), err)
+	}
+}
+
+func TestUpsertAttributeDefinition(t *testing.T) {
+	srv, repo, auditLogger := newTestServer()
+	define(t, srv, &userattributev1.AttributeDefinition{Key: "department", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_STRING})
+	if d := repo.defs["department"]; d == nil || d.DisplayName != "department" || d.Type != domain.TypeString {
+		t.Fatalf("stored definition = %+v", d)
+	}
+	if len(auditLogger.actions) != 1 || auditLogger.actions[0] != "attribute_definition_upserted" {
+		t.Errorf("audit actions = %v", auditLogger.actions)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		def  *userattributev1.AttributeDefinition
+		want codes.Code
+	}{
+		{"member", ctxFor("member-1"), &userattributev1.AttributeDefinition{Key: "level", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_NUMBER}, codes.PermissionDenied},
+		{"invalid key", ctxFor("admin-1"), &userattributev1.AttributeDefinition{Key: "Cost Center", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_STRING}, codes.InvalidArgument},
+		{"no type", ctxFor("admin-1"), &userattributev1.AttributeDefinition{Key: "level"}, codes.InvalidArgument},
+		{"type change", ctxFor("admin-1"), &userattributev1.AttributeDefinition{Key: "department", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_NUMBER}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.UpsertAttributeDefinition(tt.ctx, &userattributev1.UpsertAttributeDefinitionRequest{Definition: tt.def})
+			if status.Code(err) != tt.want {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	for i := len(repo.defs); i < domain.MaxDefinitionsPerOrg; i++ {
+		key := fmt.Sprintf("attr_%d", i)
+		repo.defs[key] = &domain.Definition{Key: key, Type: domain.TypeString}
+	}
+	_, err := srv.UpsertAttributeDefinition(ctxFor("admin-1"), &userattributev1.UpsertAttributeDefinitionRequest{
+		Definition: &userattributev1.AttributeDefinition{Key: "one_too_many", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_STRING},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("over the limit: err = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestMemberAttributes_SetGetSearch(t *testing.T) {
+	srv, repo, auditLogger := newTestServer()
+	define(t, srv, &userattributev1.AttributeDefinition{Key: "department", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_STRING})
+	define(t, srv, &userattributev1.AttributeDefinition{Key: "level", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_NUMBER})
+	define(t, srv, &userattributev1.AttributeDefinition{
+		Key: "region", Type: userattributev1.AttributeType_ATTRIBUTE_TYPE_ENUM, AllowedValues: []string{"eu", "us"},
+	})
+	admin := ctxFor("admin-1")
+
+	set, err := srv.SetMemberAttributes(admin, &userattributev1.SetMemberAttributesRequest{
+		UserId:     "member-1",
+		Attributes: map[string]string{"department": " Sales ", "level": "3.0", "region": "eu"},
+	})
+	if err != nil {
+		t.Fatalf("SetMemberAttributes: %v", err)
+	}
+	want := map[string]string{"department": "Sales", "level": "3", "region": "eu"}
+	for k, v := range want {
+		if got := set.GetMember().GetAttributes()[k]; got != v {
+			t.Errorf("attributes[%s] = %q, want %q", k, got, v)
+		}
+	}
+	if repo.values["m-member-1"]["level"] != "3" {
+		t.Errorf("stored values = %v", repo.values["m-member-1"])
+	}
+	if n := len(auditLogger.actions); auditLogger.actions[n-1] != "member_attributes_updated" || auditLogger.metadata[n-1] != `{"removed":[],"set":["department","level","region"],"user_id":"member-1"}` {
+		t.Errorf("last audit event = %s %s", auditLogger.actions[n-1], auditLogger.metadata[n-1])
+	}
+	if _, err := srv.SetMemberAttributes(admin, &userattributev1.SetMemberAttributesRequest{
+		UserId: "member-2", Attributes: map[string]string{"department": "Sales", "region": "us"},
+	}); err != nil {
+		t.Fatalf("SetMemberAttributes(member-2): %v", err)
+	}
+
+	for _, req := range []*userattributev1.SetMemberAttributesRequest{
+		{UserId: "member-1", Attributes: map[string]string{"unknown": "x"}},
+		{UserId: "member-1", Attributes: map[string]string{"level": "high"}},
+		{UserId: "member-1", Attributes: map[string]string{"region": "apac"}},
+		{UserId: "member-1", Attributes: map[string]string{"region": "eu"}, RemoveKeys: []string{"region"}},
+		{UserId: "member-1"},
+	} {
+		if _, err := srv.SetMemberAttributes(admin, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetMemberAttributes(%v) = %v, want InvalidArgument", req, err)
+		}
+	}
+	if _, err := srv.SetMemberAttributes(admin, &userattributev1.SetMemberAttributesRequest{UserId: "stranger", RemoveKeys: []string{"region"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("non-member: err = %v, want NotFound", err)
+	}
+
+	self, err := srv.GetMemberAttributes(ctxFor("member-1"), &userattributev1.GetMemberAttributesRequest{})
+	if err != nil || self.GetMember().GetAttributes()["department"] != "Sales" {
+		t.Fatalf("GetMemberAttributes(self) = %v, %v", self, err)
+	}
+	if _, err := srv.GetMemberAttributes(ctxFor("member-1"), &userattributev1.GetMemberAttributesRequest{UserId: "member-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member reading another member: err = %v, want PermissionDenied", err)
+	}
+
+	found, err := srv.SearchMembers(admin, &userattributev1.SearchMembersRequest{
+		Filters: []*userattributev1.AttributeFilter{{Key: "department", Value: "Sales"}, {Key: "region", Value: "eu"}},
+	})
+	if err != nil {
+		t.Fatalf("SearchMembers: %v", err)
+	}
+	if len(found.GetMembers()) != 1 || found.GetMembers()[0].GetUserId() != "member-1" {
+		t.Errorf("SearchMembers = %v, want member-1", found.GetMembers())
+	}
+	found, err = srv.SearchMembers(admin, &userattributev1.SearchMembersRequest{
+		Filters: []*userattributev1.AttributeFilter{{Key: "level", Value: "3.00"}},
+	})
+	if err != nil || len(found.GetMembers()) != 1 {
+		t.Errorf("search by normalized number = %v, %v", found.GetMembers(), err)
+	}
+	if _, err := srv.SearchMembers(admin, &userattributev1.SearchMembersRequest{
+		Filters: []*userattributev1.AttributeFilter{{Key: "unknown", Value: "x"}},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown filter key: err = %v, want InvalidArgument", err)
+	}
+	if _, err := srv.SearchMembers(ctxFor("member-1"), &userattributev1.SearchMembersRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("member search: err = %v, want PermissionDenied", err)
+	}
+
+	if _, err := srv.DeleteAttributeDefinition(admin, &userattributev1.DeleteAttributeDefinitionRequest{Key: "region"}); err != nil {
+		t.Fatalf("DeleteAttributeDefinition: %v", err)
+	}
+	if _, ok := repo.values["m-member-1"]["region"]; ok {
+		t.Error("values of a deleted attribute should be deleted")
+	}
+	if _, err := srv.DeleteAttributeDefinition(admin, &userattributev1.DeleteAttributeDefinitionRequest{Key: "region"}); status.Code(err) != codes.NotFound {
+		t.Errorf("second delete: err = %v, want NotFound", err)
+	}
+}
+
+func TestUserAttributes_NilRepo(t *testing.T) {
+	srv := NewServer(nil, nil, nil)
+	if _, err := srv.ListAttributeDefinitions(ctxFor("admin-1"), &userattributev1.ListAttributeDefinitionsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("err = %v, want Unimplemented", err)
+	}
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	membershipdomain "zero-trust-control-plane/backend/internal/membership/domain"
+	"zero-trust-control-plane/backend/internal/userattribute/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a user attribute repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db)}
+}
+
+// ListDefinitions returns the org's attribute definitions ordered by key.
+func (r *PostgresRepository) ListDefinitions(ctx context.Context, orgID string) ([]*domain.Definition, error) {
+	list, err := r.queries.ListOrgAttributeDefinitions(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Definition, len(list))
+	for i := range list {
+		out[i] = genDefinitionToDomain(&list[i])
+	}
+	return out, nil
+}
+
+// GetDefinition returns the definition for orgID and key, or nil if not found.
+func (r *PostgresRepository) GetDefinition(ctx context.Context, orgID, key string) (*domain.Definition, error) {
+	d, err := r.queries.GetOrgAttributeDefinition(ctx, gen.GetOrgAttributeDefinitionParams{OrgID: orgID, Key: key})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return genDefinitionToDomain(&d), nil
+}
+
+// UpsertDefinition creates or updates the definition. d.UpdatedAt is used as the creation time of a new definition.
+func (r *PostgresRepository) UpsertDefinition(ctx context.Context, d *domain.Definition) error {
+	return r.queries.UpsertOrgAttributeDefinition(ctx, gen.UpsertOrgAttributeDefinitionParams{
+		OrgID:         d.OrgID,
+		Key:           d.Key,
+		DisplayName:   d.DisplayName,
+		Description:   d.Description,
+		Type:          string(d.Type),
+		AllowedValues: d.AllowedValues,
+		CreatedAt:     d.UpdatedAt,
+	})
+}
+
+// DeleteDefinition deletes the definition; the members' values are deleted with it.
+func (r *PostgresRepository) DeleteDefinition(ctx context.Context, orgID, key string) (bool, error) {
+	n, err := r.queries.DeleteOrgAttributeDefinition(ctx, gen.DeleteOrgAttributeDefinitionParams{OrgID: orgID, Key: key})
+	return n > 0, err
+}
+
+// GetMemberValues returns the user's attribute values in the org.
+func (r *PostgresRepository) GetMemberValues(ctx context.Context, orgID, userID string) (map[string]string, error) {
+	rows, err := r.queries.ListMemberAttributesByUserAndOrg(ctx, gen.ListMemberAttributesByUserAndOrgParams{OrgID: orgID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(rows))
+	for _, row := range rows {
+		out[row.Key] = row.Value
+	}
+	return out, nil
+}
+
+// SetMemberValues sets and removes the membership's values in one transaction.
+func (r *PostgresRepository) SetMemberValues(ctx context.Context, membershipID, orgID string, set map[string]string, remove []string, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	for _, key := range remove {
+		if err := q.DeleteMembershipAttribute(ctx, gen.DeleteMembershipAttributeParams{MembershipID: membershipID, Key: key}); err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // stable lock order for concurrent updates of the same membership
+	for _, key := range keys {
+		if err := q.UpsertMembershipAttribute(ctx, gen.UpsertMembershipAttributeParams{
+			MembershipID: membershipID,
+			OrgID:        orgID,
+			Key:          key,
+			Value:        set[key],
+			UpdatedAt:    now,
+		}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SearchMembers returns the org's members matching every filter, with all their attribute values.
+func (r *PostgresRepository) SearchMembers(ctx context.Context, orgID string, filters []domain.Filter, limit, offset int32) ([]*domain.Member, error) {
+	keys := make([]string, len(filters))
+	values := make([]string, len(filters))
+	for i, f := range filters {
+		keys[i], values[i] = f.Key, f.Value
+	}
+	list, err := r.queries.SearchMembershipsByAttributes(ctx, gen.SearchMembershipsByAttributesParams{
+		OrgID:  orgID,
+		Keys:   keys,
+		Values: values,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	out := make([]*domain.Member, len(list))
+	byMembership := make(map[string]*domain.Member, len(list))
+	ids := make([]string, len(list))
+	for i, m := range list {
+		out[i] = &domain.Member{
+			MembershipID: m.ID,
+			UserID:       m.UserID,
+			Role:         membershipdomain.Role(m.Role),
+			Attributes:   map[string]string{},
+		}
+		byMembership[m.ID] = out[i]
+		ids[i] = m.ID
+	}
+	rows, err := r.queries.ListMembershipAttributesByMemberships(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if m := byMembership[row.MembershipID]; m != nil {
+			m.Attributes[row.Key] = row.Value
+		}
+	}
+	return out, nil
+}
+
+// PolicyAttributes returns the user's attribute values in the org typed for policy input (see domain.PolicyInput).
+// It implements identityservice.UserAttributeSource.
+func (r *PostgresRepository) PolicyAttributes(ctx context.Context, orgID, userID string) (map[string]interface{}, error) {
+	values, err := r.GetMemberValues(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	defs, err := r.ListDefinitions(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.PolicyInput(defs, values), nil
+}
+
+func genDefinitionToDomain(d *gen.OrgAttributeDefinition) *domain.Definition {
+	allowed := d.AllowedValues
+	if allowed == nil {
+		allowed = []string{}
+	}
+	return &domain.Definition{
+		OrgID:         d.OrgID,
+		Key:           d.Key,
+		DisplayName:   d.DisplayName,
+		Description:   d.Description,
+		Type:          domain.Type(d.Type),
+		AllowedValues: allowed,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/userattribute/domain"
+)
+
+// Repository defines persistence for org attribute definitions and the members' values.
+type Repository interface {
+	// ListDefinitions returns the org's attribute definitions ordered by key.
+	ListDefinitions(ctx context.Context, orgID string) ([]*domain.Definition, error)
+	// GetDefinition returns the definition, or nil if not found.
+	GetDefinition(ctx context.Context, orgID, key string) (*domain.Definition, error)
+	// UpsertDefinition creates the definition or updates the one with the same org and key.
+	UpsertDefinition(ctx context.Context, d *domain.Definition) error
+	// DeleteDefinition deletes the definition and every member's value for it. Reports whether it existed.
+	DeleteDefinition(ctx context.Context, orgID, key string) (bool, error)
+	// GetMemberValues returns the user's attribute values in the org (key → value); empty if none.
+	GetMemberValues(ctx context.Context, orgID, userID string) (map[string]string, error)
+	// SetMemberValues sets the values in set and removes the keys in remove for the membership, in one transaction.
+	SetMemberValues(ctx context.Context, membershipID, orgID string, set map[string]string, remove []string, now time.Time) error
+	// SearchMembers returns the org's members that match every filter, oldest membership first, with their values.
+	SearchMembers(ctx context.Context, orgID string, filters []domain.Filter, limit, offset int32) ([]*domain.Member, error)
+}
@@ -0,0 +1,23 @@
+package domain
+
+// Diff is what merging a duplicate user into a primary user moves or drops. Counts are the duplicate's rows; moved
+// rows are re-pointed to the primary.
+type Diff struct {
+	PrimaryUserID   string
+	PrimaryEmail    string
+	DuplicateUserID string
+	DuplicateEmail  string
+
+	Identities             int64 // moved
+	IdentitiesDropped      int64 // the duplicate's password, dropped because the primary has one
+	Memberships            int64 // moved
+	MembershipsMerged      int64 // orgs both users belong to; the primary keeps the higher role
+	GroupMemberships       int64 // moved
+	GroupMembershipsMerged int64 // groups both users are in
+	Devices                int64
+	Sessions               int64
+	AuditLogs              int64
+
+	// Protected is set when either user is a honeytoken or a break-glass account; such users are not merged.
+	Protected bool
+}
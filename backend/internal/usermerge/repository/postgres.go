@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/residency"
+	"zero-trust-control-plane/backend/internal/usermerge/domain"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	router  *residency.Router
+}
+
+// NewPostgresRepository returns a user merge repository that uses the given db. Audit logs of orgs pinned to a
+// region live in that region's database, so when router is non-nil they are counted and moved there too.
+func NewPostgresRepository(db *sql.DB, router *residency.Router) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db), router: router}
+}
+
+// regionalQueries returns the queries of every regional database, keyed by region.
+func (r *PostgresRepository) regionalQueries() map[string]*gen.Queries {
+	out := make(map[string]*gen.Queries)
+	if r.router == nil {
+		return out
+	}
+	for region, db := range r.router.Databases() {
+		if region != "" {
+			out[region] = gen.New(db)
+		}
+	}
+	return out
+}
+
+// Diff counts the duplicate's rows that a merge would move or drop.
+func (r *PostgresRepository) Diff(ctx context.Context, primaryID, duplicateID string) (*domain.Diff, error) {
+	row, err := r.queries.GetUserMergeDiff(ctx, gen.GetUserMergeDiffParams{DuplicateID: duplicateID, PrimaryID: primaryID})
+	if err != nil {
+		return nil, err
+	}
+	auditLogs := row.AuditLogs
+	for _, q := range r.regionalQueries() {
+		n, err := q.CountAuditLogsByUser(ctx, duplicateID)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs += n
+	}
+	return &domain.Diff{
+		PrimaryUserID:          primaryID,
+		DuplicateUserID:        duplicateID,
+		Identities:             row.Identities - row.IdentitiesDropped,
+		IdentitiesDropped:      row.IdentitiesDropped,
+		Memberships:            row.Memberships - row.MembershipsMerged,
+		MembershipsMerged:      row.MembershipsMerged,
+		GroupMemberships:       row.GroupMemberships - row.GroupMembershipsMerged,
+		GroupMembershipsMerged: row.GroupMembershipsMerged,
+		Devices:                row.Devices,
+		Sessions:               row.Sessions,
+		AuditLogs:              auditLogs,
+		Protected:              row.Honeytoken || row.BreakGlass,
+	}, nil
+}
+
+// Merge re-points the duplicate's rows to the primary and disables the duplicate in one transaction. Rows that
+// would clash with the primary's (its password, and memberships of the same org or group) are dropped first. Audit
+// logs in regional databases are moved after that transaction commits; if one fails the merge can be run again,
+// since every step is a no-op once the duplicate has nothing left to move.
+func (r *PostgresRepository) Merge(ctx context.Context, primaryID, duplicateID string, now time.Time) (*domain.Diff, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	d := &domain.Diff{PrimaryUserID: primaryID, DuplicateUserID: duplicateID}
+	steps := []struct {
+		n   *int64
+		run func() (int64, error)
+	}{
+		{&d.IdentitiesDropped, func() (int64, error) {
+			return q.DeleteMergedLocalIdentity(ctx, gen.DeleteMergedLocalIdentityParams{DuplicateID: duplicateID, PrimaryID: primaryID})
+		}},
+		{&d.Identities, func() (int64, error) {
+			return q.MoveIdentities(ctx, gen.MoveIdentitiesParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		}},
+		{nil, func() (int64, error) {
+			return q.MergeMembershipRoles(ctx, gen.MergeMembershipRolesParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		}},
+		{&d.MembershipsMerged, func() (int64, error) {
+			return q.DeleteMergedMemberships(ctx, gen.DeleteMergedMembershipsParams{DuplicateID: duplicateID, PrimaryID: primaryID})
+		}},
+		{&d.Memberships, func() (int64, error) {
+			return q.MoveMemberships(ctx, gen.MoveMembershipsParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		}},
+		{&d.GroupMembershipsMerged, func() (int64, error) {
+			return q.DeleteMergedGroupMembers(ctx, gen.DeleteMergedGroupMembersParams{DuplicateID: duplicateID, PrimaryID: primaryID})
+		}},
+		{&d.GroupMemberships, func() (int64, error) {
+			return q.MoveGroupMembers(ctx, gen.MoveGroupMembersParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		}},
+		{&d.Devices, func() (int64, error) {
+			return q.MoveDevices(ctx, gen.MoveDevicesParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		}},
+		{&d.Sessions, func() (int64, error) {
+			return q.MoveSessions(ctx, gen.MoveSessionsParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		}},
+		{&d.AuditLogs, func() (int64, error) {
+			return q.MoveAuditLogs(ctx, gen.MoveAuditLogsParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		}},
+	}
+	for _, s := range steps {
+		n, err := s.run()
+		if err != nil {
+			return nil, err
+		}
+		if s.n != nil {
+			*s.n = n
+		}
+	}
+	if err := q.DisableMergedUser(ctx, gen.DisableMergedUserParams{ID: duplicateID, UpdatedAt: now}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	for region, rq := range r.regionalQueries() {
+		n, err := rq.MoveAuditLogs(ctx, gen.MoveAuditLogsParams{PrimaryID: primaryID, DuplicateID: duplicateID})
+		if err != nil {
+			return nil, fmt.Errorf("move audit logs in region %s (re-run the merge to finish): %w", region, err)
+		}
+		d.AuditLogs += n
+	}
+	return d, nil
+}
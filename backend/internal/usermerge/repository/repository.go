@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/usermerge/domain"
+)
+
+// Repository reads and applies user merges.
+type Repository interface {
+	// Diff returns what merging duplicateID into primaryID would move or drop. The user emails are not set.
+	Diff(ctx context.Context, primaryID, duplicateID string) (*domain.Diff, error)
+	// Merge moves the duplicate's identities, memberships, group memberships, devices, sessions and audit log
+	// entries to the primary and disables the duplicate at now, in one transaction. Returns what was moved or dropped.
+	Merge(ctx context.Context, primaryID, duplicateID string, now time.Time) (*domain.Diff, error)
+}
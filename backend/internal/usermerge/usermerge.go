@@ -0,0 +1,135 @@
+// Package usermerge merges duplicate users: the same person signed up twice, or through two identity providers. A
+// merge moves the duplicate's identities, memberships, devices, sessions and audit log entries to the primary user
+// and disables the duplicate. It cannot be undone, so it is applied only with a confirmation token issued for a dry
+// run of the same merge.
+package usermerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/confirm"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+	"zero-trust-control-plane/backend/internal/usermerge/domain"
+	"zero-trust-control-plane/backend/internal/usermerge/repository"
+)
+
+// ConfirmationTTL is how long a confirmation token from a dry run is valid.
+const ConfirmationTTL = 5 * time.Minute
+
+// confirmationPurpose binds confirmation tokens to user merges.
+const confirmationPurpose = "user_merge"
+
+var (
+	// ErrSameUser is returned when the primary and the duplicate are the same user.
+	ErrSameUser = errors.New("usermerge: primary and duplicate are the same user")
+	// ErrUserNotFound is returned when either user does not exist.
+	ErrUserNotFound = errors.New("usermerge: user not found")
+	// ErrPrimaryDisabled is returned when the primary user is disabled.
+	ErrPrimaryDisabled = errors.New("usermerge: primary user is disabled")
+	// ErrProtectedUser is returned when either user is a honeytoken or a break-glass account.
+	ErrProtectedUser = errors.New("usermerge: honeytoken and break-glass users cannot be merged")
+	// ErrInvalidConfirmation is returned by Merge for a confirmation token that is invalid, expired, or was issued
+	// for a different merge or diff.
+	ErrInvalidConfirmation = errors.New("usermerge: invalid or expired confirmation token")
+)
+
+// UserGetter resolves the users being merged.
+type UserGetter interface {
+	GetByID(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+// SessionRevoker ends the duplicate's sessions before they move to the primary: their tokens carry the duplicate's
+// user ID.
+type SessionRevoker interface {
+	RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error
+}
+
+// Merger previews and applies user merges.
+type Merger struct {
+	repo          repository.Repository
+	users         UserGetter
+	sessions      SessionRevoker
+	confirmations *confirm.Signer
+}
+
+// NewMerger returns a merger backed by repo. sessions may be nil; the duplicate's sessions then move to the primary
+// without being revoked. confirmations signs confirmation tokens; when nil, a signer with a random per-process key is
+// used.
+func NewMerger(repo repository.Repository, users UserGetter, sessions SessionRevoker, confirmations *confirm.Signer) *Merger {
+	if confirmations == nil {
+		confirmations = confirm.NewSigner("")
+	}
+	return &Merger{repo: repo, users: users, sessions: sessions, confirmations: confirmations}
+}
+
+// Plan returns what merging duplicateID into primaryID would move or drop, without changing anything.
+func (m *Merger) Plan(ctx context.Context, primaryID, duplicateID string) (*domain.Diff, error) {
+	if primaryID == duplicateID {
+		return nil, ErrSameUser
+	}
+	primary, err := m.users.GetByID(ctx, primaryID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := m.users.GetByID(ctx, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if primary == nil || duplicate == nil {
+		return nil, ErrUserNotFound
+	}
+	if primary.Status != userdomain.UserStatusActive {
+		return nil, ErrPrimaryDisabled
+	}
+	d, err := m.repo.Diff(ctx, primaryID, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Protected {
+		return nil, ErrProtectedUser
+	}
+	d.PrimaryEmail, d.DuplicateEmail = primary.Email, duplicate.Email
+	return d, nil
+}
+
+// Merge merges duplicateID into primaryID. token must have been issued to by for a dry run of this merge (see
+// ConfirmationToken) and not have expired at now; if the diff has changed since, the merge is refused and must be
+// reviewed again. The duplicate's sessions are revoked as user_merged by by, so its users sign in again as the
+// primary. Returns what was moved or dropped.
+func (m *Merger) Merge(ctx context.Context, primaryID, duplicateID, by, token string, now time.Time) (*domain.Diff, error) {
+	plan, err := m.Plan(ctx, primaryID, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if !m.confirmations.Valid(token, confirmationPurpose, now, confirmationFields(plan, by)...) {
+		return nil, ErrInvalidConfirmation
+	}
+	if m.sessions != nil {
+		if err := m.sessions.RevokeAllSessionsByUser(ctx, duplicateID, sessiondomain.Revocation{Reason: sessiondomain.RevocationUserMerged, By: by}); err != nil {
+			return nil, err
+		}
+	}
+	d, err := m.repo.Merge(ctx, primaryID, duplicateID, now)
+	if err != nil {
+		return nil, err
+	}
+	d.PrimaryEmail, d.DuplicateEmail = plan.PrimaryEmail, plan.DuplicateEmail
+	return d, nil
+}
+
+// ConfirmationToken returns a confirmation token for the merge previewed by d, bound to the admin by and valid until
+// expiresAt. It is signed with the merger's confirmation key, so only the server can issue one.
+func (m *Merger) ConfirmationToken(d *domain.Diff, by string, expiresAt time.Time) string {
+	return m.confirmations.Token(confirmationPurpose, expiresAt, confirmationFields(d, by)...)
+}
+
+// confirmationFields are the values a confirmation token is bound to: the users, the admin and the counts of the diff.
+func confirmationFields(d *domain.Diff, by string) []string {
+	diff := fmt.Sprintf("%d/%d/%d/%d/%d/%d/%d/%d/%d", d.Identities, d.IdentitiesDropped, d.Memberships, d.MembershipsMerged,
+		d.GroupMemberships, d.GroupMembershipsMerged, d.Devices, d.Sessions, d.AuditLogs)
+	return []string{d.PrimaryUserID, d.DuplicateUserID, by, diff}
+}
@@ -0,0 +1,154 @@
+package usermerge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/platform/confirm"
+	sessiondomain "zero-trust-control-plane/backend/internal/session/domain"
+	userdomain "zero-trust-control-plane/backend/internal/user/domain"
+	"zero-trust-control-plane/backend/internal/usermerge/domain"
+)
+
+type memRepo struct {
+	diff   domain.Diff
+	merged int
+}
+
+func (m *memRepo) Diff(ctx context.Context, primaryID, duplicateID string) (*domain.Diff, error) {
+	d := m.diff
+	d.PrimaryUserID, d.DuplicateUserID = primaryID, duplicateID
+	return &d, nil
+}
+
+func (m *memRepo) Merge(ctx context.Context, primaryID, duplicateID string, now time.Time) (*domain.Diff, error) {
+	m.merged++
+	return m.Diff(ctx, primaryID, duplicateID)
+}
+
+type memUsers map[string]*userdomain.User
+
+func (m memUsers) GetByID(ctx context.Context, id string) (*userdomain.User, error) {
+	return m[id], nil
+}
+
+type recordingRevoker struct {
+	revoked []string
+}
+
+func (r *recordingRevoker) RevokeAllSessionsByUser(ctx context.Context, userID string, rev sessiondomain.Revocation) error {
+	r.revoked = append(r.revoked, userID+":"+string(rev.Reason)+":"+rev.By)
+	return nil
+}
+
+func newTestMerger() (*Merger, *memRepo, *recordingRevoker) {
+	repo := &memRepo{diff: domain.Diff{Identities: 1, Memberships: 2, MembershipsMerged: 1, Devices: 3, Sessions: 4, AuditLogs: 20}}
+	users := memUsers{
+		"u1": {ID: "u1", Email: "alice@example.com", Status: userdomain.UserStatusActive},
+		"u2": {ID: "u2", Email: "alice@corp.example.com", Status: userdomain.UserStatusActive},
+		"u3": {ID: "u3", Email: "bob@example.com", Status: userdomain.UserStatusDisabled},
+	}
+	sessions := &recordingRevoker{}
+	return NewMerger(repo, users, sessions, confirm.NewSigner("secret")), repo, sessions
+}
+
+func TestMerger_PlanAndMerge(t *testing.T) {
+	m, repo, sessions := newTestMerger()
+	now := time.Now()
+	plan, err := m.Plan(context.Background(), "u1", "u2")
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if plan.PrimaryEmail != "alice@example.com" || plan.DuplicateEmail != "alice@corp.example.com" || plan.Sessions != 4 {
+		t.Errorf("plan = %+v", plan)
+	}
+	token := m.ConfirmationToken(plan, "admin-1", now.Add(ConfirmationTTL))
+
+	if _, err := m.Merge(context.Background(), "u1", "u2", "admin-2", token, now); !errors.Is(err, ErrInvalidConfirmation) {
+		t.Errorf("Merge by another admin: want ErrInvalidConfirmation, got %v", err)
+	}
+	if _, err := m.Merge(context.Background(), "u2", "u1", "admin-1", token, now); !errors.Is(err, ErrInvalidConfirmation) {
+		t.Errorf("Merge in the other direction: want ErrInvalidConfirmation, got %v", err)
+	}
+	if _, err := m.Merge(context.Background(), "u1", "u2", "admin-1", token, now.Add(ConfirmationTTL)); !errors.Is(err, ErrInvalidConfirmation) {
+		t.Errorf("Merge after expiry: want ErrInvalidConfirmation, got %v", err)
+	}
+	if repo.merged != 0 || len(sessions.revoked) != 0 {
+		t.Fatalf("merged %d times and revoked %v before a valid confirmation", repo.merged, sessions.revoked)
+	}
+
+	d, err := m.Merge(context.Background(), "u1", "u2", "admin-1", token, now)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if repo.merged != 1 || d.DuplicateEmail != "alice@corp.example.com" || d.AuditLogs != 20 {
+		t.Errorf("merged = %d, diff = %+v", repo.merged, d)
+	}
+	if len(sessions.revoked) != 1 || sessions.revoked[0] != "u2:user_merged:admin-1" {
+		t.Errorf("revoked = %v, want the duplicate's sessions as user_merged", sessions.revoked)
+	}
+}
+
+func TestMerger_DiffChangedSinceDryRun(t *testing.T) {
+	m, repo, _ := newTestMerger()
+	now := time.Now()
+	plan, err := m.Plan(context.Background(), "u1", "u2")
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	token := m.ConfirmationToken(plan, "admin-1", now.Add(ConfirmationTTL))
+	repo.diff.Sessions++
+	if _, err := m.Merge(context.Background(), "u1", "u2", "admin-1", token, now); !errors.Is(err, ErrInvalidConfirmation) {
+		t.Errorf("Merge after the diff changed: want ErrInvalidConfirmation, got %v", err)
+	}
+}
+
+func TestMerger_ConfirmationTokenIsKeyed(t *testing.T) {
+	m, repo, _ := newTestMerger()
+	now := time.Now()
+	plan, err := m.Plan(context.Background(), "u1", "u2")
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	token := m.ConfirmationToken(plan, "admin-1", now.Add(ConfirmationTTL))
+
+	other := NewMerger(repo, m.users, nil, confirm.NewSigner("other"))
+	if _, err := other.Merge(context.Background(), "u1", "u2", "admin-1", token, now); !errors.Is(err, ErrInvalidConfirmation) {
+		t.Errorf("Merge on a server with another secret: want ErrInvalidConfirmation, got %v", err)
+	}
+	if repo.merged != 0 {
+		t.Fatalf("merged %d times with a token signed by another key", repo.merged)
+	}
+	peer := NewMerger(repo, m.users, nil, confirm.NewSigner("secret"))
+	if _, err := peer.Merge(context.Background(), "u1", "u2", "admin-1", token, now); err != nil {
+		t.Errorf("Merge on a server with the same secret: %v", err)
+	}
+}
+
+func TestMerger_Rejects(t *testing.T) {
+	m, repo, _ := newTestMerger()
+	for _, tc := range []struct {
+		name               string
+		primary, duplicate string
+		want               error
+	}{
+		{"same user", "u1", "u1", ErrSameUser},
+		{"unknown primary", "nope", "u2", ErrUserNotFound},
+		{"unknown duplicate", "u1", "nope", ErrUserNotFound},
+		{"disabled primary", "u3", "u1", ErrPrimaryDisabled},
+	} {
+		if _, err := m.Plan(context.Background(), tc.primary, tc.duplicate); !errors.Is(err, tc.want) {
+			t.Errorf("%s: want %v, got %v", tc.name, tc.want, err)
+		}
+	}
+	// A disabled duplicate can still be merged away.
+	if _, err := m.Plan(context.Background(), "u1", "u3"); err != nil {
+		t.Errorf("disabled duplicate: %v", err)
+	}
+	repo.diff.Protected = true
+	if _, err := m.Plan(context.Background(), "u1", "u2"); !errors.Is(err, ErrProtectedUser) {
+		t.Errorf("protected user: want ErrProtectedUser, got %v", err)
+	}
+}
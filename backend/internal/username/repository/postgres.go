@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/db"
+	"zero-trust-control-plane/backend/internal/db/sqlc/gen"
+	"zero-trust-control-plane/backend/internal/username"
+)
+
+// PostgresRepository implements Repository using the sqlc-generated queries.
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+// NewPostgresRepository returns a username repository that uses the given db for persistence.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db, queries: gen.New(db)}
+}
+
+// GetUserID returns the ID of the user holding the username, or "" if none.
+func (r *PostgresRepository) GetUserID(ctx context.Context, orgID, name string) (string, error) {
+	userID, err := r.queries.GetUsernameUserID(ctx, gen.GetUsernameUserIDParams{OrgID: orgID, Username: name})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return userID, err
+}
+
+// Get returns the user's username, or "" if they have none.
+func (r *PostgresRepository) Get(ctx context.Context, userID, orgID string) (string, error) {
+	name, err := r.queries.GetUsername(ctx, gen.GetUsernameParams{UserID: userID, OrgID: orgID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return name, err
+}
+
+// Set frees a stale org-scoped username and sets the user's username in one transaction. The unique constraint on
+// (org_id, username) makes it fail with username.ErrTaken when another user holds the name.
+func (r *PostgresRepository) Set(ctx context.Context, userID, orgID, name string, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := r.queries.WithTx(tx)
+	if orgID != "" {
+		if err := q.DeleteStaleOrgUsername(ctx, gen.DeleteStaleOrgUsernameParams{OrgID: orgID, Username: name}); err != nil {
+			return err
+		}
+	}
+	err = q.UpsertUsername(ctx, gen.UpsertUsernameParams{UserID: userID, OrgID: orgID, Username: name, Now: now})
+	if err != nil {
+		if db.IsUniqueViolation(err) {
+			return username.ErrTaken
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Delete removes the user's username and reports whether they had one.
+func (r *PostgresRepository) Delete(ctx context.Context, userID, orgID string) (bool, error) {
+	n, err := r.queries.DeleteUsername(ctx, gen.DeleteUsernameParams{UserID: userID, OrgID: orgID})
+	return n > 0, err
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Repository persists usernames. orgID is "" for global usernames, or the org an org-scoped username is unique in.
+// Usernames are passed normalized (see username.Normalize).
+type Repository interface {
+	// GetUserID returns the ID of the user holding the username in orgID, or "" if none. An org-scoped username
+	// counts only while its user is a member of the org.
+	GetUserID(ctx context.Context, orgID, name string) (string, error)
+	// Get returns the user's username in orgID, or "" if they have none.
+	Get(ctx context.Context, userID, orgID string) (string, error)
+	// Set gives the user the username in orgID, replacing the one they had. It returns username.ErrTaken when another
+	// user holds it; an org-scoped username of a user who left the org is freed first.
+	Set(ctx context.Context, userID, orgID, name string, now time.Time) error
+	// Delete removes the user's username in orgID and reports whether they had one.
+	Delete(ctx context.Context, userID, orgID string) (bool, error)
+}
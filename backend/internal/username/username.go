@@ -0,0 +1,77 @@
+// Package username holds the rules for usernames: optional sign-in names users can use in place of their email
+// (AuthService SetUsername, Login). Usernames are unique platform-wide or within each org, depending on the Scope the
+// server is configured with.
+package username
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Scope is where usernames must be unique.
+type Scope string
+
+const (
+	ScopeGlobal Scope = "global" // one username per user, unique across the platform
+	ScopeOrg    Scope = "org"    // one username per user and org, unique within the org
+)
+
+// MinLength and MaxLength bound the length of a username.
+const (
+	MinLength = 3
+	MaxLength = 32
+)
+
+var (
+	// ErrInvalid is returned by Validate for a username that breaks the rules; the error says which.
+	ErrInvalid = errors.New("invalid username")
+	// ErrReserved is returned by Validate for a name kept for the platform (e.g. admin, root).
+	ErrReserved = errors.New("username is reserved")
+	// ErrTaken is returned by the repository's Set when another user holds the username in the scope.
+	ErrTaken = errors.New("username is taken")
+)
+
+// reserved are names that could pass for the platform or its operators.
+var reserved = map[string]bool{
+	"admin": true, "administrator": true, "root": true, "system": true, "support": true, "security": true,
+	"help": true, "api": true, "service": true, "owner": true, "null": true, "anonymous": true,
+}
+
+// Normalize returns the stored form of a username: trimmed and lower case. Usernames are matched case-insensitively.
+func Normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Validate checks a normalized username: MinLength to MaxLength characters of a-z, 0-9, '.', '_' and '-', starting
+// and ending with a letter or digit, without two separators in a row. There is no '@', so a username is never
+// mistaken for an email. Returns an error wrapping ErrInvalid or ErrReserved.
+func Validate(name string) error {
+	if n := len(name); n < MinLength || n > MaxLength {
+		return fmt.Errorf("%w: must be %d to %d characters", ErrInvalid, MinLength, MaxLength)
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '.' || c == '_' || c == '-':
+			if i == 0 || i == len(name)-1 {
+				return fmt.Errorf("%w: must start and end with a letter or digit", ErrInvalid)
+			}
+			if p := name[i-1]; p == '.' || p == '_' || p == '-' {
+				return fmt.Errorf("%w: must not have two of '.', '_' and '-' in a row", ErrInvalid)
+			}
+		default:
+			return fmt.Errorf("%w: may only contain letters, digits, '.', '_' and '-'", ErrInvalid)
+		}
+	}
+	if reserved[name] {
+		return ErrReserved
+	}
+	return nil
+}
+
+// IsUsername reports whether a sign-in identifier is meant as a username rather than an email: it has no '@'.
+func IsUsername(identifier string) bool {
+	return !strings.Contains(identifier, "@")
+}
@@ -0,0 +1,32 @@
+package username
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	for _, name := range []string{"ada", "ada.lovelace", "a_l-1", "12345", strings.Repeat("a", MaxLength)} {
+		if err := Validate(name); err != nil {
+			t.Errorf("Validate(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"ab", strings.Repeat("a", MaxLength+1), "Ada", "ada@example.com", "ada lovelace", ".ada", "ada-", "ada..l", "ad_-a", "ädä"} {
+		if err := Validate(name); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Validate(%q) = %v, want ErrInvalid", name, err)
+		}
+	}
+	if err := Validate("admin"); !errors.Is(err, ErrReserved) {
+		t.Errorf("Validate(admin) = %v, want ErrReserved", err)
+	}
+}
+
+func TestNormalizeAndIsUsername(t *testing.T) {
+	if got := Normalize("  Ada.Lovelace "); got != "ada.lovelace" {
+		t.Errorf("Normalize = %q", got)
+	}
+	if !IsUsername("ada") || IsUsername("ada@example.com") {
+		t.Error("IsUsername must be true exactly for identifiers without '@'")
+	}
+}
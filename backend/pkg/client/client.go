@@ -0,0 +1,446 @@
+// Package client is the Go client of the zero-trust control plane. It wraps the gRPC API with what every client
+// needs: the access token is attached to each call and refreshed before it expires (and once more when the server
+// rejects it), the device fingerprint is generated once and sent with sign-ins and refreshes, tokens issued by
+// Login, VerifyMFA, CompleteMagicLink, ResumeLogin and PollDeviceAuthorization are kept, transient failures are
+// retried with backoff, and errors are returned as *Error with a stable reason.
+//
+//	c, err := client.New("ztcp.example.com:443", client.WithStore(client.NewFileStore(path)))
+//	resp, err := c.Auth.Login(ctx, &authv1.LoginRequest{Email: email, Password: password, OrgId: orgID})
+//	// resp.GetMfaRequired() != nil: call c.Auth.VerifyMFA with the code; the tokens are kept either way.
+//	users, err := c.Users.ListUsers(ctx, &userv1.ListUsersRequest{OrgId: orgID})
+//	if errors.Is(err, client.ErrOrgAdminRequired) { ... }
+//
+// Sessions bound to a proof-of-possession key (LoginRequest.pop_public_key) are not refreshed automatically.
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	adminv1 "zero-trust-control-plane/backend/api/generated/admin/v1"
+	agentv1 "zero-trust-control-plane/backend/api/generated/agent/v1"
+	analyticsv1 "zero-trust-control-plane/backend/api/generated/analytics/v1"
+	auditv1 "zero-trust-control-plane/backend/api/generated/audit/v1"
+	auditwebhookv1 "zero-trust-control-plane/backend/api/generated/auditwebhook/v1"
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	breakglassv1 "zero-trust-control-plane/backend/api/generated/breakglass/v1"
+	changerequestv1 "zero-trust-control-plane/backend/api/generated/changerequest/v1"
+	devicev1 "zero-trust-control-plane/backend/api/generated/device/v1"
+	elevationv1 "zero-trust-control-plane/backend/api/generated/elevation/v1"
+	featureflagv1 "zero-trust-control-plane/backend/api/generated/featureflag/v1"
+	groupv1 "zero-trust-control-plane/backend/api/generated/group/v1"
+	healthv1 "zero-trust-control-plane/backend/api/generated/health/v1"
+	invitationv1 "zero-trust-control-plane/backend/api/generated/invitation/v1"
+	membershipv1 "zero-trust-control-plane/backend/api/generated/membership/v1"
+	notificationv1 "zero-trust-control-plane/backend/api/generated/notification/v1"
+	organizationv1 "zero-trust-control-plane/backend/api/generated/organization/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	platformsettingsv1 "zero-trust-control-plane/backend/api/generated/platformsettings/v1"
+	policyv1 "zero-trust-control-plane/backend/api/generated/policy/v1"
+	policypresetv1 "zero-trust-control-plane/backend/api/generated/policypreset/v1"
+	policyviolationv1 "zero-trust-control-plane/backend/api/generated/policyviolation/v1"
+	securityeventv1 "zero-trust-control-plane/backend/api/generated/securityevent/v1"
+	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
+	telemetryv1 "zero-trust-control-plane/backend/api/generated/telemetry/v1"
+	urlexceptionv1 "zero-trust-control-plane/backend/api/generated/urlexception/v1"
+	userv1 "zero-trust-control-plane/backend/api/generated/user/v1"
+	userattributev1 "zero-trust-control-plane/backend/api/generated/userattribute/v1"
+)
+
+// refreshSkew is how long before its expiry an access token is refreshed, so it does not expire in flight.
+const refreshSkew = 30 * time.Second
+
+// publicMethods are the RPCs called without an access token (the server's public methods). Their Unauthenticated
+// errors (e.g. invalid credentials) never trigger a refresh.
+var publicMethods = map[string]bool{
+	authv1.AuthService_Register_FullMethodName:                           true,
+	authv1.AuthService_Login_FullMethodName:                              true,
+	authv1.AuthService_VerifyMFA_FullMethodName:                          true,
+	authv1.AuthService_ResendMFACode_FullMethodName:                      true,
+	authv1.AuthService_SubmitPhoneAndRequestMFA_FullMethodName:           true,
+	authv1.AuthService_Refresh_FullMethodName:                            true,
+	authv1.AuthService_CreateRefreshNonce_FullMethodName:                 true,
+	authv1.AuthService_VerifyCredentials_FullMethodName:                  true,
+	authv1.AuthService_DiscoverOrganizations_FullMethodName:              true,
+	authv1.AuthService_ResumeLogin_FullMethodName:                        true,
+	authv1.AuthService_StartDeviceAuthorization_FullMethodName:           true,
+	authv1.AuthService_PollDeviceAuthorization_FullMethodName:            true,
+	authv1.AuthService_RequestMagicLink_FullMethodName:                   true,
+	authv1.AuthService_CompleteMagicLink_FullMethodName:                  true,
+	authv1.AuthService_GetJWKS_FullMethodName:                            true,
+	healthv1.HealthService_HealthCheck_FullMethodName:                    true,
+	breakglassv1.BreakGlassService_SignIn_FullMethodName:                 true,
+	organizationv1.OrganizationService_CreateOrganization_FullMethodName: true,
+	organizationv1.OrganizationService_SetupOrganization_FullMethodName:  true,
+}
+
+// Client is a connection to the control plane with a client per service. All calls go through the client's token,
+// fingerprint, retry and error handling. Safe for concurrent use.
+type Client struct {
+	Admin            adminv1.AdminServiceClient
+	Agents           agentv1.AgentServiceClient
+	Analytics        analyticsv1.AnalyticsServiceClient
+	Audit            auditv1.AuditServiceClient
+	AuditWebhooks    auditwebhookv1.AuditWebhookServiceClient
+	Auth             authv1.AuthServiceClient
+	BreakGlass       breakglassv1.BreakGlassServiceClient
+	ChangeRequests   changerequestv1.ChangeRequestServiceClient
+	Devices          devicev1.DeviceServiceClient
+	Elevations       elevationv1.ElevationServiceClient
+	FeatureFlags     featureflagv1.FeatureFlagServiceClient
+	Groups           groupv1.GroupServiceClient
+	Health           healthv1.HealthServiceClient
+	Invitations      invitationv1.InvitationServiceClient
+	Memberships      membershipv1.MembershipServiceClient
+	Notifications    notificationv1.NotificationServiceClient
+	Organizations    organizationv1.OrganizationServiceClient
+	OrgPolicyConfig  orgpolicyconfigv1.OrgPolicyConfigServiceClient
+	PlatformSettings platformsettingsv1.PlatformSettingsServiceClient
+	Policies         policyv1.PolicyServiceClient
+	PolicyPresets    policypresetv1.PolicyPresetServiceClient
+	PolicyViolations policyviolationv1.PolicyViolationServiceClient
+	SecurityEvents   securityeventv1.SecurityEventsServiceClient
+	Sessions         sessionv1.SessionServiceClient
+	Telemetry        telemetryv1.TelemetryServiceClient
+	UrlExceptions    urlexceptionv1.UrlExceptionServiceClient
+	UserAttributes   userattributev1.UserAttributeServiceClient
+	Users            userv1.UserServiceClient
+
+	conn   *grpc.ClientConn
+	store  Store
+	retry  RetryPolicy
+	locale string
+	now    func() time.Time
+	sleep  func(context.Context, time.Duration) error
+
+	mu    sync.Mutex // guards state
+	state State
+	// refreshMu serializes refreshes, so concurrent calls with an expired token refresh once.
+	refreshMu sync.Mutex
+}
+
+type options struct {
+	creds       credentials.TransportCredentials
+	store       Store
+	retry       RetryPolicy
+	locale      string
+	dialOptions []grpc.DialOption
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithStore sets where the fingerprint and tokens are kept. Default: a MemoryStore.
+func WithStore(s Store) Option {
+	return func(o *options) { o.store = s }
+}
+
+// WithTransportCredentials sets the connection's credentials. Default: TLS with the system's root CAs.
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(o *options) { o.creds = creds }
+}
+
+// WithInsecure connects without TLS, for a local server.
+func WithInsecure() Option {
+	return func(o *options) { o.creds = insecure.NewCredentials() }
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) { o.retry = p }
+}
+
+// WithLocale asks for error messages in locale (sent as x-locale), e.g. "de". Without it the server answers in
+// English.
+func WithLocale(locale string) Option {
+	return func(o *options) { o.locale = locale }
+}
+
+// WithDialOptions adds gRPC dial options, e.g. for a custom dialer or more interceptors (which run after the
+// client's).
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, opts...) }
+}
+
+// New returns a client of the server at target (host:port). It loads the saved state and, on first use, generates
+// and saves the device fingerprint. No connection is made until the first call.
+func New(target string, opts ...Option) (*Client, error) {
+	o := options{retry: DefaultRetryPolicy}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.creds == nil {
+		o.creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	if o.store == nil {
+		o.store = NewMemoryStore()
+	}
+	if o.retry.MaxAttempts < 1 {
+		o.retry.MaxAttempts = 1
+	}
+	state, err := o.store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.DeviceFingerprint == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		state.DeviceFingerprint = hex.EncodeToString(b)
+		if err := o.store.Save(state); err != nil {
+			return nil, err
+		}
+	}
+	c := &Client{store: o.store, retry: o.retry, locale: o.locale, state: state, now: time.Now, sleep: sleepContext}
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(o.creds),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.streamInterceptor),
+	}, o.dialOptions...)
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	c.Admin = adminv1.NewAdminServiceClient(conn)
+	c.Agents = agentv1.NewAgentServiceClient(conn)
+	c.Analytics = analyticsv1.NewAnalyticsServiceClient(conn)
+	c.Audit = auditv1.NewAuditServiceClient(conn)
+	c.AuditWebhooks = auditwebhookv1.NewAuditWebhookServiceClient(conn)
+	c.Auth = authv1.NewAuthServiceClient(conn)
+	c.BreakGlass = breakglassv1.NewBreakGlassServiceClient(conn)
+	c.ChangeRequests = changerequestv1.NewChangeRequestServiceClient(conn)
+	c.Devices = devicev1.NewDeviceServiceClient(conn)
+	c.Elevations = elevationv1.NewElevationServiceClient(conn)
+	c.FeatureFlags = featureflagv1.NewFeatureFlagServiceClient(conn)
+	c.Groups = groupv1.NewGroupServiceClient(conn)
+	c.Health = healthv1.NewHealthServiceClient(conn)
+	c.Invitations = invitationv1.NewInvitationServiceClient(conn)
+	c.Memberships = membershipv1.NewMembershipServiceClient(conn)
+	c.Notifications = notificationv1.NewNotificationServiceClient(conn)
+	c.Organizations = organizationv1.NewOrganizationServiceClient(conn)
+	c.OrgPolicyConfig = orgpolicyconfigv1.NewOrgPolicyConfigServiceClient(conn)
+	c.PlatformSettings = platformsettingsv1.NewPlatformSettingsServiceClient(conn)
+	c.Policies = policyv1.NewPolicyServiceClient(conn)
+	c.PolicyPresets = policypresetv1.NewPolicyPresetServiceClient(conn)
+	c.PolicyViolations = policyviolationv1.NewPolicyViolationServiceClient(conn)
+	c.SecurityEvents = securityeventv1.NewSecurityEventsServiceClient(conn)
+	c.Sessions = sessionv1.NewSessionServiceClient(conn)
+	c.Telemetry = telemetryv1.NewTelemetryServiceClient(conn)
+	c.UrlExceptions = urlexceptionv1.NewUrlExceptionServiceClient(conn)
+	c.UserAttributes = userattributev1.NewUserAttributeServiceClient(conn)
+	c.Users = userv1.NewUserServiceClient(conn)
+	return c, nil
+}
+
+// Conn returns the underlying connection, e.g. for services added after this package.
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close closes the connection. The saved state is kept.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// State returns a copy of the client's state: the fingerprint and the current session's tokens.
+func (c *Client) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// DeviceFingerprint returns the fingerprint sent with sign-ins and refreshes.
+func (c *Client) DeviceFingerprint() string {
+	return c.State().DeviceFingerprint
+}
+
+// Logout ends the session on the server and forgets its tokens. The tokens are forgotten even if the server call
+// fails; the fingerprint is kept.
+func (c *Client) Logout(ctx context.Context) error {
+	refreshToken := c.State().RefreshToken
+	var err error
+	if refreshToken != "" {
+		_, err = c.Auth.Logout(ctx, &authv1.LogoutRequest{RefreshToken: refreshToken})
+	}
+	if saveErr := c.setTokens(nil); err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+// unaryInterceptor adds the fingerprint, locale and access token, retries methods that are safe to repeat (retryable),
+// keeps issued tokens and types errors.
+func (c *Client) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	req = c.withFingerprint(req)
+	ctx = c.withLocale(ctx)
+	policy := c.retry
+	if !retryable(method) {
+		policy.MaxAttempts = 1
+	}
+	err := policy.do(ctx, c.sleep, func() error {
+		if publicMethods[method] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		token, err := c.accessToken(ctx)
+		if err != nil {
+			// The refresh may have rotated the tokens before it failed; running it again could replay them.
+			return finalError{err}
+		}
+		err = invoker(withBearer(ctx, token), method, req, reply, cc, opts...)
+		if token != "" && isUnauthenticated(err) {
+			// The token may have been revoked or rotated by another client sharing the store: refresh once.
+			if fresh, refreshErr := c.refresh(ctx, token); refreshErr == nil {
+				err = invoker(withBearer(ctx, fresh), method, req, reply, cc, opts...)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return FromError(err)
+	}
+	return c.keepTokens(reply)
+}
+
+// streamInterceptor adds the locale and access token when a stream opens. Errors received on the stream are not
+// converted; use FromError.
+func (c *Client) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx = c.withLocale(ctx)
+	if !publicMethods[method] {
+		token, err := c.accessToken(ctx)
+		if err != nil {
+			return nil, FromError(err)
+		}
+		ctx = withBearer(ctx, token)
+	}
+	s, err := streamer(ctx, desc, cc, method, opts...)
+	return s, FromError(err)
+}
+
+// accessToken returns the access token for a call, refreshing it first when it is about to expire. "" means not
+// signed in: the call is made without a token and the server rejects it.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	s := c.State()
+	if s.RefreshToken == "" || (s.AccessToken != "" && c.now().Add(refreshSkew).Before(s.ExpiresAt)) {
+		return s.AccessToken, nil
+	}
+	return c.refresh(ctx, s.AccessToken)
+}
+
+// refresh exchanges the refresh token for new tokens, unless another call already replaced stale, and returns the
+// new access token. A rejected refresh token is forgotten.
+func (c *Client) refresh(ctx context.Context, stale string) (string, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	s := c.State()
+	if s.AccessToken != stale && s.AccessToken != "" {
+		return s.AccessToken, nil
+	}
+	if s.RefreshToken == "" {
+		return "", ErrReauthenticationRequired
+	}
+	resp, err := c.Auth.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: s.RefreshToken, DeviceFingerprint: s.DeviceFingerprint})
+	if err != nil {
+		if isUnauthenticated(err) {
+			_ = c.setTokens(nil)
+		}
+		return "", err
+	}
+	if resp.GetTokens() == nil {
+		// The device-trust policy asks for MFA or a phone number: only the user can continue.
+		_ = c.setTokens(nil)
+		return "", ErrReauthenticationRequired
+	}
+	// The interceptor kept the tokens.
+	return resp.GetTokens().GetAccessToken(), nil
+}
+
+// keepTokens saves the tokens of a response that issued some.
+func (c *Client) keepTokens(reply interface{}) error {
+	var tokens *authv1.AuthResponse
+	switch r := reply.(type) {
+	case *authv1.AuthResponse:
+		tokens = r
+	case *authv1.LoginResponse:
+		tokens = r.GetTokens()
+	case *authv1.RefreshResponse:
+		tokens = r.GetTokens()
+	}
+	if tokens.GetAccessToken() == "" {
+		return nil
+	}
+	return c.setTokens(tokens)
+}
+
+// setTokens replaces the session's tokens (nil clears them) and saves the state.
+func (c *Client) setTokens(t *authv1.AuthResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.AccessToken = t.GetAccessToken()
+	c.state.RefreshToken = t.GetRefreshToken()
+	c.state.ExpiresAt = t.GetExpiresAt().AsTime()
+	c.state.UserID = t.GetUserId()
+	c.state.OrgID = t.GetOrgId()
+	if t == nil {
+		c.state.ExpiresAt = time.Time{}
+	}
+	return c.store.Save(c.state)
+}
+
+// withFingerprint returns req with the client's device fingerprint when it is a request that takes one and has none.
+// The caller's message is not modified.
+func (c *Client) withFingerprint(req interface{}) interface{} {
+	m, ok := req.(interface {
+		proto.Message
+		GetDeviceFingerprint() string
+	})
+	if !ok || m.GetDeviceFingerprint() != "" {
+		return req
+	}
+	fingerprint := c.DeviceFingerprint()
+	switch r := proto.Clone(m).(type) {
+	case *authv1.LoginRequest:
+		r.DeviceFingerprint = fingerprint
+		return r
+	case *authv1.RefreshRequest:
+		r.DeviceFingerprint = fingerprint
+		return r
+	case *authv1.VerifyCredentialsRequest:
+		r.DeviceFingerprint = fingerprint
+		return r
+	case *authv1.StartDeviceAuthorizationRequest:
+		r.DeviceFingerprint = fingerprint
+		return r
+	case *authv1.CompleteMagicLinkRequest:
+		r.DeviceFingerprint = fingerprint
+		return r
+	}
+	return req
+}
+
+// withLocale adds the x-locale header when WithLocale was given.
+func (c *Client) withLocale(ctx context.Context) context.Context {
+	if c.locale == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "x-locale", c.locale)
+}
+
+// withBearer adds the access token, if any.
+func withBearer(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
@@ -0,0 +1,302 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	userv1 "zero-trust-control-plane/backend/api/generated/user/v1"
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+)
+
+// fakeServer issues tokens access-N/refresh-N and serves GetUser to holders of a current access token.
+type fakeServer struct {
+	authv1.UnimplementedAuthServiceServer
+	userv1.UnimplementedUserServiceServer
+
+	mu           sync.Mutex
+	issued       int
+	access       string
+	refresh      string
+	fingerprints []string
+	refreshes    int
+	loginErrs    []error
+	refreshErrs  []error
+	getUserErrs  []error
+	getUserAuth  []string
+}
+
+func (f *fakeServer) issue() *authv1.AuthResponse {
+	f.issued++
+	f.access = "access-" + string(rune('0'+f.issued))
+	f.refresh = "refresh-" + string(rune('0'+f.issued))
+	return &authv1.AuthResponse{AccessToken: f.access, RefreshToken: f.refresh, ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)), UserId: "user-1", OrgId: "org-1"}
+}
+
+func (f *fakeServer) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.LoginResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fingerprints = append(f.fingerprints, req.GetDeviceFingerprint())
+	if len(f.loginErrs) > 0 {
+		err := f.loginErrs[0]
+		f.loginErrs = f.loginErrs[1:]
+		return nil, err
+	}
+	if req.GetPassword() != "secret" {
+		st := i18n.Error(codes.Unauthenticated, "INVALID_CREDENTIALS", "invalid credentials", nil)
+		st, _ = st.WithDetails(&errdetails.LocalizedMessage{Locale: "de", Message: "Ungültige Anmeldedaten."})
+		return nil, st.Err()
+	}
+	return &authv1.LoginResponse{Result: &authv1.LoginResponse_Tokens{Tokens: f.issue()}}, nil
+}
+
+func (f *fakeServer) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.RefreshResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refreshes++
+	f.fingerprints = append(f.fingerprints, req.GetDeviceFingerprint())
+	if len(f.refreshErrs) > 0 {
+		err := f.refreshErrs[0]
+		f.refreshErrs = f.refreshErrs[1:]
+		return nil, err
+	}
+	if req.GetRefreshToken() != f.refresh {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+	}
+	return &authv1.RefreshResponse{Result: &authv1.RefreshResponse_Tokens{Tokens: f.issue()}}, nil
+}
+
+func (f *fakeServer) Logout(ctx context.Context, req *authv1.LogoutRequest) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refresh = ""
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := strings.Join(md.Get("authorization"), ",")
+	f.getUserAuth = append(f.getUserAuth, auth)
+	if len(f.getUserErrs) > 0 {
+		err := f.getUserErrs[0]
+		f.getUserErrs = f.getUserErrs[1:]
+		return nil, err
+	}
+	if auth != "Bearer "+f.access {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization")
+	}
+	return &userv1.GetUserResponse{}, nil
+}
+
+func newTestClient(t *testing.T, opts ...Option) (*Client, *fakeServer) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	fake := &fakeServer{}
+	authv1.RegisterAuthServiceServer(srv, fake)
+	userv1.RegisterUserServiceServer(srv, fake)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) })
+	c, err := New("passthrough:///bufnet", append([]Option{WithInsecure(), WithDialOptions(dialer)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+	return c, fake
+}
+
+func TestClient_TokensAndFingerprint(t *testing.T) {
+	c, fake := newTestClient(t)
+	ctx := context.Background()
+
+	req := &authv1.LoginRequest{Email: "a@example.com", Password: "secret", OrgId: "org-1"}
+	if _, err := c.Auth.Login(ctx, req); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if s := c.State(); s.AccessToken != "access-1" || s.RefreshToken != "refresh-1" || s.UserID != "user-1" {
+		t.Errorf("state after Login = %+v", s)
+	}
+	if req.DeviceFingerprint != "" || len(fake.fingerprints) != 1 || fake.fingerprints[0] != c.DeviceFingerprint() || len(c.DeviceFingerprint()) != 32 {
+		t.Errorf("fingerprints = %v, caller's request %q; want the client's fingerprint sent on a copy", fake.fingerprints, req.DeviceFingerprint)
+	}
+
+	if _, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{}); err != nil || fake.getUserAuth[0] != "Bearer access-1" {
+		t.Fatalf("GetUser = %v with %q", err, fake.getUserAuth)
+	}
+
+	// The server rejects the token (e.g. it was rotated elsewhere): refresh once and retry.
+	fake.access = "revoked"
+	if _, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{}); err != nil {
+		t.Fatalf("GetUser after revocation: %v", err)
+	}
+	if fake.refreshes != 1 || c.State().AccessToken != "access-2" || fake.fingerprints[1] != c.DeviceFingerprint() {
+		t.Errorf("refreshes = %d, state %+v, fingerprints %v", fake.refreshes, c.State(), fake.fingerprints)
+	}
+
+	// A token about to expire is refreshed before the call.
+	c.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if _, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{}); err != nil || fake.refreshes != 2 || fake.getUserAuth[len(fake.getUserAuth)-1] != "Bearer access-3" {
+		t.Errorf("expiring token: err %v, refreshes %d, auth %v", err, fake.refreshes, fake.getUserAuth)
+	}
+	c.now = time.Now
+
+	// A rejected refresh token is forgotten.
+	fake.refresh, fake.access = "other", "revoked"
+	_, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{})
+	if status.Code(err) != codes.Unauthenticated || c.State().SignedIn() {
+		t.Errorf("rejected refresh: err %v, state %+v", err, c.State())
+	}
+	if c.State().DeviceFingerprint == "" {
+		t.Error("fingerprint cleared with the tokens")
+	}
+}
+
+func TestClient_Logout(t *testing.T) {
+	c, fake := newTestClient(t)
+	ctx := context.Background()
+	if _, err := c.Auth.Login(ctx, &authv1.LoginRequest{Password: "secret"}); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if err := c.Logout(ctx); err != nil || c.State().SignedIn() || fake.refresh != "" {
+		t.Errorf("Logout = %v, state %+v", err, c.State())
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	c, fake := newTestClient(t, WithLocale("de"))
+	ctx := context.Background()
+
+	_, err := c.Auth.Login(ctx, &authv1.LoginRequest{Password: "wrong"})
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrInvalidCredentials) || e.Code != codes.Unauthenticated || e.UserMessage() != "Ungültige Anmeldedaten." {
+		t.Fatalf("Login error = %#v", err)
+	}
+	if status.Code(err) != codes.Unauthenticated || fake.refreshes != 0 {
+		t.Errorf("status.Code = %v, refreshes %d; want Unauthenticated without a refresh", status.Code(err), fake.refreshes)
+	}
+
+	_, err = c.Users.GetUser(ctx, &userv1.GetUserRequest{})
+	if !errors.As(err, &e) || e.Reason != "UNAUTHENTICATED" || errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("error without ErrorInfo = %#v, want the code's reason", err)
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	c, fake := newTestClient(t)
+	ctx := context.Background()
+	if _, err := c.Auth.Login(ctx, &authv1.LoginRequest{Password: "secret"}); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	withRetryInfo := func(code codes.Code, reason string, delay time.Duration) error {
+		st, _ := i18n.Error(code, reason, "retry later", nil).WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+		return st.Err()
+	}
+
+	fake.getUserErrs = []error{status.Error(codes.Unavailable, "connection refused"), withRetryInfo(codes.ResourceExhausted, "ORG_QUOTA_EXCEEDED", 2*time.Second)}
+	if _, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{}); err != nil || len(fake.getUserAuth) != 3 {
+		t.Errorf("transient failures: err %v after %d attempts, want success on the third", err, len(fake.getUserAuth))
+	}
+
+	fake.getUserAuth = nil
+	fake.getUserErrs = []error{withRetryInfo(codes.Unavailable, "MAINTENANCE_MODE", 10*time.Minute)}
+	_, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{})
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrMaintenanceMode) || e.RetryAfter != 10*time.Minute || len(fake.getUserAuth) != 1 {
+		t.Errorf("long retry hint: err %#v after %d attempts, want it returned at once", err, len(fake.getUserAuth))
+	}
+
+	fake.getUserAuth = nil
+	fake.getUserErrs = []error{status.Error(codes.InvalidArgument, "user_id required")}
+	if _, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{}); status.Code(err) != codes.InvalidArgument || len(fake.getUserAuth) != 1 {
+		t.Errorf("InvalidArgument retried: %d attempts", len(fake.getUserAuth))
+	}
+
+	fake.getUserAuth = nil
+	fake.getUserErrs = []error{status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down")}
+	if _, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{}); status.Code(err) != codes.Unavailable || len(fake.getUserAuth) != DefaultRetryPolicy.MaxAttempts {
+		t.Errorf("attempts = %d, want %d", len(fake.getUserAuth), DefaultRetryPolicy.MaxAttempts)
+	}
+}
+
+func TestClient_RetryOnlySafeMethods(t *testing.T) {
+	c, fake := newTestClient(t)
+	ctx := context.Background()
+
+	fake.loginErrs = []error{status.Error(codes.Unavailable, "connection reset")}
+	if _, err := c.Auth.Login(ctx, &authv1.LoginRequest{Password: "secret"}); status.Code(err) != codes.Unavailable || len(fake.fingerprints) != 1 {
+		t.Fatalf("Login: err %v after %d attempts, want Unavailable after one", err, len(fake.fingerprints))
+	}
+	if _, err := c.Auth.Login(ctx, &authv1.LoginRequest{Password: "secret"}); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	// An expired access token makes GetUser refresh first; the refresh fails and is not run again.
+	c.mu.Lock()
+	c.state.ExpiresAt = time.Now()
+	c.mu.Unlock()
+	fake.refreshErrs = []error{status.Error(codes.Unavailable, "connection reset"), status.Error(codes.Unavailable, "connection reset")}
+	if _, err := c.Users.GetUser(ctx, &userv1.GetUserRequest{}); status.Code(err) != codes.Unavailable || fake.refreshes != 1 || len(fake.getUserAuth) != 0 {
+		t.Errorf("GetUser with a failing refresh: err %v after %d refreshes and %d calls, want Unavailable after one refresh", err, fake.refreshes, len(fake.getUserAuth))
+	}
+
+	for method, want := range map[string]bool{
+		userv1.UserService_GetUser_FullMethodName:     true,
+		userv1.UserService_DisableUser_FullMethodName: false,
+		authv1.AuthService_Login_FullMethodName:       false,
+		authv1.AuthService_Refresh_FullMethodName:     false,
+		"/unknown.Service/Method":                     false,
+	} {
+		if got := retryable(method); got != want {
+			t.Errorf("retryable(%s) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ztcp", "state.json")
+	store := NewFileStore(path)
+	if s, err := store.Load(); err != nil || s != (State{}) {
+		t.Fatalf("Load of a missing file = %+v, %v", s, err)
+	}
+
+	c, err := New("localhost:1", WithInsecure(), WithStore(store))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	c.Close()
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("state file = %v, %v; want mode 0600", info, err)
+	}
+
+	again, err := New("localhost:1", WithInsecure(), WithStore(NewFileStore(path)))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer again.Close()
+	if again.DeviceFingerprint() != c.DeviceFingerprint() {
+		t.Errorf("fingerprint = %q, want the saved %q", again.DeviceFingerprint(), c.DeviceFingerprint())
+	}
+}
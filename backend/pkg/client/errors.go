@@ -0,0 +1,118 @@
+package client
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zero-trust-control-plane/backend/internal/platform/i18n"
+)
+
+// Error is an error returned by the control plane. Branch on Reason (or errors.Is with the Err* values), never on
+// Message: reasons are stable, messages are not.
+type Error struct {
+	// Code is the gRPC status code.
+	Code codes.Code
+	// Reason is the machine-readable reason, e.g. INVALID_CREDENTIALS; the code's name (e.g. NOT_FOUND) for errors
+	// without a more specific one.
+	Reason string
+	// Message is the English status message.
+	Message string
+	// LocalizedMessage is the message in Locale, for display; "" if the server sent none.
+	LocalizedMessage string
+	Locale           string
+	// Metadata holds the reason's parameters, e.g. the limit of ORG_QUOTA_EXCEEDED.
+	Metadata map[string]string
+	// RetryAfter is the server's retry hint (maintenance mode, quotas); 0 if it gave none.
+	RetryAfter time.Duration
+
+	status *status.Status
+}
+
+// Reasons the client handles itself or callers commonly branch on. Compare with errors.Is; the full list is in the
+// server's error catalog (internal/platform/i18n).
+var (
+	ErrAuthenticationRequired = &Error{Reason: "AUTHENTICATION_REQUIRED"}
+	ErrAccessTokenExpired     = &Error{Reason: "ACCESS_TOKEN_EXPIRED"}
+	// ErrAccessTokenNotYetValid usually means this machine's clock is behind the server's by more than the
+	// server's clock leeway.
+	ErrAccessTokenNotYetValid = &Error{Reason: "ACCESS_TOKEN_NOT_YET_VALID"}
+	ErrInvalidCredentials     = &Error{Reason: "INVALID_CREDENTIALS"}
+	ErrInvalidRefreshToken    = &Error{Reason: "INVALID_REFRESH_TOKEN"}
+	ErrRefreshTokenReused     = &Error{Reason: "REFRESH_TOKEN_REUSED"}
+	ErrRateLimited            = &Error{Reason: "RATE_LIMITED"}
+	ErrLoginHoldPending       = &Error{Reason: "LOGIN_HOLD_PENDING"}
+	ErrNotOrgMember           = &Error{Reason: "NOT_ORG_MEMBER"}
+	ErrOrgAdminRequired       = &Error{Reason: "ORG_ADMIN_REQUIRED"}
+	ErrMaintenanceMode        = &Error{Reason: "MAINTENANCE_MODE"}
+	ErrReadOnlyMode           = &Error{Reason: "READ_ONLY_MODE"}
+	ErrOrgQuotaExceeded       = &Error{Reason: "ORG_QUOTA_EXCEEDED"}
+	ErrSessionQuotaExceeded   = &Error{Reason: "SESSION_QUOTA_EXCEEDED"}
+	// ErrReauthenticationRequired is returned when the session cannot be refreshed without the user (the refresh
+	// token is gone, or the refresh asks for MFA); sign in again.
+	ErrReauthenticationRequired = &Error{Code: codes.Unauthenticated, Reason: "REAUTHENTICATION_REQUIRED", Message: "the session cannot be refreshed; sign in again"}
+)
+
+// Error returns the English message and the reason.
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Reason
+	}
+	return e.Message + " (" + e.Reason + ")"
+}
+
+// Is reports whether target is an *Error with the same reason, so errors.Is(err, client.ErrInvalidCredentials) works.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Reason != "" && t.Reason == e.Reason
+}
+
+// GRPCStatus returns the underlying status, so status.Code and status.FromError keep working.
+func (e *Error) GRPCStatus() *status.Status {
+	if e.status != nil {
+		return e.status
+	}
+	return status.New(e.Code, e.Message)
+}
+
+// UserMessage returns the message to show the user: the localized message if there is one, else the English one.
+func (e *Error) UserMessage() string {
+	if e.LocalizedMessage != "" {
+		return e.LocalizedMessage
+	}
+	return e.Message
+}
+
+// FromError returns err as an *Error when it is a gRPC status error, and err unchanged otherwise (nil, context
+// errors, *Error).
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var typed *Error
+	if errors.As(err, &typed) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	e := &Error{Code: st.Code(), Reason: i18n.CodeReason(st.Code()), Message: st.Message(), status: st}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetReason() != "" {
+				e.Reason = d.GetReason()
+			}
+			e.Metadata = d.GetMetadata()
+		case *errdetails.LocalizedMessage:
+			e.LocalizedMessage, e.Locale = d.GetMessage(), d.GetLocale()
+		case *errdetails.RetryInfo:
+			e.RetryAfter = d.GetRetryDelay().AsDuration()
+		}
+	}
+	return e
+}
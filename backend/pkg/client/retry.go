@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+)
+
+// RetryPolicy controls how calls are retried. Only methods that are safe to repeat are retried (see retryable). Such a
+// call is retried when the server sent a retry hint (RetryInfo: maintenance mode, quotas) no longer than MaxBackoff, or
+// failed with UNAVAILABLE without one (the server or the network was down). Longer hints are returned to the caller as
+// Error.RetryAfter instead of blocking the call, as are the errors of every other method.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first; 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the base delay of the first retry without a hint; it doubles per retry, with jitter.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+// finalError wraps an error of an attempt that must not be retried whatever its code, e.g. a failed Refresh made by
+// the attempt.
+type finalError struct{ err error }
+
+func (e finalError) Error() string { return e.err.Error() }
+
+// retryable reports whether the full method name (/package.Service/Method) may be retried: one declared with option
+// idempotency_level = NO_SIDE_EFFECTS or IDEMPOTENT in its proto, so running it again after the server may already
+// have run it is safe. Refresh is never retried: the server would see the rotated refresh token again as reuse and
+// revoke the session.
+func retryable(fullMethod string) bool {
+	if fullMethod == authv1.AuthService_Refresh_FullMethodName {
+		return false
+	}
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return false
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return false
+	}
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	if !ok {
+		return false
+	}
+	level := opts.GetIdempotencyLevel()
+	return level == descriptorpb.MethodOptions_NO_SIDE_EFFECTS || level == descriptorpb.MethodOptions_IDEMPOTENT
+}
+
+// do calls attempt until it succeeds, fails with an error that is not retried, runs out of attempts, or ctx ends before
+// the next attempt is due. A finalError is returned unwrapped at once.
+func (p RetryPolicy) do(ctx context.Context, sleep func(context.Context, time.Duration) error, attempt func() error) error {
+	for n := 1; ; n++ {
+		err := attempt()
+		var final finalError
+		if errors.As(err, &final) {
+			return final.err
+		}
+		if err == nil || n >= p.MaxAttempts {
+			return err
+		}
+		delay, ok := p.delay(err, n)
+		if !ok {
+			return err
+		}
+		if deadline, has := ctx.Deadline(); has && time.Until(deadline) < delay {
+			return err
+		}
+		if sleep(ctx, delay) != nil {
+			return err
+		}
+	}
+}
+
+// delay returns how long to wait before retrying after the n-th failed attempt, and false if err is not retried.
+func (p RetryPolicy) delay(err error, n int) (time.Duration, bool) {
+	e, ok := FromError(err).(*Error)
+	if !ok {
+		return 0, false
+	}
+	if e.RetryAfter > 0 {
+		return e.RetryAfter, e.RetryAfter <= p.MaxBackoff
+	}
+	if e.Code != codes.Unavailable {
+		return 0, false
+	}
+	backoff := p.InitialBackoff << (n - 1)
+	if backoff <= 0 || backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	// Full jitter keeps clients that failed together from retrying together.
+	return time.Duration(rand.Int63n(int64(backoff)) + 1), true
+}
+
+// sleepContext waits for d or until ctx ends.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isUnauthenticated reports whether err is an UNAUTHENTICATED status.
+func isUnauthenticated(err error) bool {
+	return status.Code(err) == codes.Unauthenticated
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// State is what a client keeps between runs: the device fingerprint, so the server recognizes the device (device
+// trust, trusted-device MFA skips), and the session's tokens.
+type State struct {
+	DeviceFingerprint string    `json:"device_fingerprint"`
+	AccessToken       string    `json:"access_token,omitempty"`
+	RefreshToken      string    `json:"refresh_token,omitempty"`
+	ExpiresAt         time.Time `json:"expires_at,omitempty"`
+	UserID            string    `json:"user_id,omitempty"`
+	OrgID             string    `json:"org_id,omitempty"`
+}
+
+// SignedIn reports whether the state holds a session.
+func (s State) SignedIn() bool {
+	return s.AccessToken != "" || s.RefreshToken != ""
+}
+
+// Store persists a client's State. Implementations must be safe for concurrent use.
+type Store interface {
+	// Load returns the saved state, or the zero State if nothing was saved.
+	Load() (State, error)
+	Save(s State) error
+}
+
+// MemoryStore keeps the state in memory: the session and fingerprint last as long as the process.
+type MemoryStore struct {
+	mu sync.Mutex
+	s  State
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Load returns the saved state.
+func (m *MemoryStore) Load() (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.s, nil
+}
+
+// Save replaces the saved state.
+func (m *MemoryStore) Save(s State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.s = s
+	return nil
+}
+
+// FileStore keeps the state in a JSON file readable only by its owner (0600). The file holds the refresh token:
+// keep it out of shared directories.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore returns a FileStore at path. The file and its directory are created on the first Save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the state; a missing file is the zero State.
+func (f *FileStore) Load() (State, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var s State
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// Save writes the state to a temporary file and renames it over the old one, so a crash never leaves a torn file.
+func (f *FileStore) Save(s State) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
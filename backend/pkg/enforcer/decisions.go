@@ -0,0 +1,64 @@
+package enforcer
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+)
+
+// Decision is the outcome of a policy check.
+type Decision struct {
+	Allowed bool
+	Reason  string // why access is denied; empty when allowed
+}
+
+// decisionKey identifies a cached decision. CheckUrlAccess applies the caller's group overrides, so decisions are
+// per user.
+type decisionKey struct {
+	orgID, userID, url string
+}
+
+type decision struct {
+	Decision
+	expiresAt time.Time
+}
+
+// CheckURL returns whether the org's access control policy lets id open url, from the cache when the same user asked
+// within DecisionTTL. The control plane is called with id's token. Errors (the control plane being unreachable) are
+// not cached; callers decide whether to fail open or closed.
+func (e *Enforcer) CheckURL(ctx context.Context, id *Identity, url string) (Decision, error) {
+	if e.cfg.Policy == nil {
+		return Decision{}, ErrNoPolicy
+	}
+	key := decisionKey{orgID: id.OrgID, userID: id.UserID, url: url}
+	now := e.now()
+	e.decisionsMu.Lock()
+	d, ok := e.decisions[key]
+	e.decisionsMu.Unlock()
+	if ok && now.Before(d.expiresAt) {
+		return d.Decision, nil
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+id.Token)
+	resp, err := e.cfg.Policy.CheckUrlAccess(ctx, &orgpolicyconfigv1.CheckUrlAccessRequest{OrgId: id.OrgID, Url: url})
+	if err != nil {
+		return Decision{}, err
+	}
+	d = decision{Decision: Decision{Allowed: resp.GetAllowed(), Reason: resp.GetReason()}, expiresAt: now.Add(e.cfg.DecisionTTL)}
+	e.decisionsMu.Lock()
+	defer e.decisionsMu.Unlock()
+	if len(e.decisions) >= maxDecisions {
+		for k, cached := range e.decisions {
+			if !now.Before(cached.expiresAt) {
+				delete(e.decisions, k)
+			}
+		}
+		if len(e.decisions) >= maxDecisions {
+			clear(e.decisions)
+		}
+	}
+	e.decisions[key] = d
+	return d.Decision, nil
+}
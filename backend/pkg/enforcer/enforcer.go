@@ -0,0 +1,276 @@
+// Package enforcer lets services outside the control plane enforce its decisions without access to its database.
+// An Enforcer validates access tokens locally against the signing keys published by AuthService.GetJWKS, rejects
+// tokens of sessions revoked since they were issued (followed with SessionService.SubscribeRevocations), caches
+// OrgPolicyConfigService.CheckUrlAccess decisions, and provides gRPC interceptors and an HTTP middleware that do all
+// of it per request.
+//
+//	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+//	admin, err := client.New(target, client.WithStore(store)) // an org admin's session, for the revocation stream
+//	e, err := enforcer.New(ctx, enforcer.Config{
+//		Auth:     authv1.NewAuthServiceClient(conn),
+//		Sessions: admin.Sessions,
+//		Policy:   orgpolicyconfigv1.NewOrgPolicyConfigServiceClient(conn),
+//	})
+//	go e.Run(ctx)
+//	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(e.UnaryServerInterceptor()))
+//	http.Handle("/", e.HTTPMiddleware(handler))
+//
+// Handlers read the caller with FromContext.
+package enforcer
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+const (
+	// DefaultKeyRefreshInterval is how often the signing keys are fetched again, so a rotated key is known before
+	// tokens signed with it arrive.
+	DefaultKeyRefreshInterval = 10 * time.Minute
+	// DefaultDecisionTTL is how long a CheckUrlAccess decision is reused.
+	DefaultDecisionTTL = time.Minute
+	// DefaultRevocationRetention is how long a revoked session is remembered, and how far back revocations are
+	// replayed on start. It must be at least the access token lifetime; the server replays at most 24 hours.
+	DefaultRevocationRetention = 24 * time.Hour
+	// DefaultClockLeeway is the clock difference to the control plane tolerated on a token's exp, nbf and iat.
+	DefaultClockLeeway = 30 * time.Second
+
+	// minKeyRefetch is the least time between fetches triggered by tokens with an unknown kid.
+	minKeyRefetch = 10 * time.Second
+	// maxDecisions bounds the decision cache.
+	maxDecisions = 10000
+)
+
+var (
+	// ErrInvalidToken is returned for a token that is malformed, expired, not signed by the control plane, or for
+	// another issuer, audience or token type.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is returned for a token past its exp, even allowing for the clock leeway. It wraps
+	// ErrInvalidToken.
+	ErrTokenExpired = fmt.Errorf("%w: token expired", ErrInvalidToken)
+	// ErrTokenNotYetValid is returned for a token whose nbf or iat is in the future, even allowing for the clock
+	// leeway: the clocks of this service and the control plane differ. It wraps ErrInvalidToken.
+	ErrTokenNotYetValid = fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+	// ErrSessionRevoked is returned for a token of a revoked session.
+	ErrSessionRevoked = errors.New("session revoked")
+	// ErrNoPolicy is returned by CheckURL when Config.Policy is not set.
+	ErrNoPolicy = errors.New("no policy client configured")
+)
+
+// Config configures an Enforcer.
+type Config struct {
+	// Auth fetches the signing keys (GetJWKS, a public RPC). Required.
+	Auth authv1.AuthServiceClient
+	// Sessions follows revocations (SubscribeRevocations). It must carry the credentials of an org admin or owner,
+	// e.g. pkg/client's Sessions; revocations are those of that org. Optional: without it, tokens of revoked
+	// sessions are accepted until they expire.
+	Sessions sessionv1.SessionServiceClient
+	// Policy checks URLs (CheckUrlAccess) with the caller's own token, so it must not add credentials of its own.
+	// Optional: without it CheckURL returns ErrNoPolicy.
+	Policy orgpolicyconfigv1.OrgPolicyConfigServiceClient
+
+	// Audience, when set, is the aud of the resource tokens from TokenExchange the service accepts instead of access
+	// tokens: its TOKEN_EXCHANGE_AUDIENCES entry. The control plane rejects those, so CheckURL then needs a separate
+	// access token. When empty, only access tokens are accepted; they are told apart by their typ claim, since refresh
+	// tokens carry the same aud.
+	Audience string
+	// KeyRefreshInterval defaults to DefaultKeyRefreshInterval.
+	KeyRefreshInterval time.Duration
+	// DecisionTTL defaults to DefaultDecisionTTL.
+	DecisionTTL time.Duration
+	// RevocationRetention defaults to DefaultRevocationRetention.
+	RevocationRetention time.Duration
+	// ClockLeeway defaults to DefaultClockLeeway; a negative value allows none.
+	ClockLeeway time.Duration
+
+	// PublicMethods are the full gRPC method names the interceptors let through without a token, e.g. health checks.
+	PublicMethods map[string]bool
+	// URLOf returns the URL HTTPMiddleware checks with CheckURL for a request, e.g. RequestURL. Nil skips the check.
+	URLOf func(r *http.Request) string
+}
+
+// Identity is the caller of a verified token.
+type Identity struct {
+	UserID    string
+	OrgID     string
+	SessionID string
+	Scope     string // resource tokens only
+	ExpiresAt time.Time
+	// Token is the verified token, for calls to the control plane on the caller's behalf.
+	Token string
+}
+
+// Enforcer validates tokens and caches decisions. Safe for concurrent use.
+type Enforcer struct {
+	cfg Config
+	now func() time.Time
+
+	keysMu    sync.RWMutex // guards keys, issuer and fetchedAt
+	keys      map[string]crypto.PublicKey
+	issuer    string
+	fetchedAt time.Time  // last fetch attempt, successful or not
+	refetchMu sync.Mutex // serializes key fetches
+
+	revokedMu     sync.RWMutex // guards revoked, lastRevokedAt and prunedAt
+	revoked       map[string]time.Time
+	lastRevokedAt time.Time
+	prunedAt      time.Time
+
+	decisionsMu sync.Mutex
+	decisions   map[decisionKey]decision
+}
+
+// tokenClaims are the claims shared by access and resource tokens.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Type      string `json:"typ"`
+	OrgID     string `json:"org_id"`
+	SessionID string `json:"session_id"`
+	Scope     string `json:"scope,omitempty"`
+}
+
+// New returns an Enforcer for cfg after fetching the signing keys. Call Run to follow revocations.
+func New(ctx context.Context, cfg Config) (*Enforcer, error) {
+	if cfg.Auth == nil {
+		return nil, errors.New("enforcer: Auth client is required")
+	}
+	if cfg.KeyRefreshInterval <= 0 {
+		cfg.KeyRefreshInterval = DefaultKeyRefreshInterval
+	}
+	if cfg.DecisionTTL <= 0 {
+		cfg.DecisionTTL = DefaultDecisionTTL
+	}
+	if cfg.RevocationRetention <= 0 {
+		cfg.RevocationRetention = DefaultRevocationRetention
+	}
+	if cfg.ClockLeeway == 0 {
+		cfg.ClockLeeway = DefaultClockLeeway
+	} else if cfg.ClockLeeway < 0 {
+		cfg.ClockLeeway = 0
+	}
+	e := &Enforcer{cfg: cfg, now: time.Now, revoked: make(map[string]time.Time), decisions: make(map[decisionKey]decision)}
+	if err := e.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Verify validates token (signature, expiry, issuer, type, and audience when Config.Audience is set) and that its
+// session is not revoked. Without Config.Audience only access tokens verify; with it, only resource tokens for it.
+func (e *Enforcer) Verify(ctx context.Context, token string) (*Identity, error) {
+	e.refreshKeys(ctx)
+	claims := &tokenClaims{}
+	e.keysMu.RLock()
+	issuer := e.issuer
+	e.keysMu.RUnlock()
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{"RS256", "ES256"}), jwt.WithIssuer(issuer), jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(), jwt.WithLeeway(e.cfg.ClockLeeway), jwt.WithTimeFunc(e.now)}
+	wantType := security.TokenTypeAccess
+	if e.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(e.cfg.Audience))
+		wantType = security.TokenTypeResource
+	}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return e.key(ctx, t)
+	}, opts...)
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return nil, ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return nil, ErrTokenNotYetValid
+	}
+	if err != nil || !parsed.Valid || claims.Type != wantType || claims.Subject == "" || claims.SessionID == "" {
+		return nil, ErrInvalidToken
+	}
+	if e.isRevoked(claims.SessionID) {
+		return nil, ErrSessionRevoked
+	}
+	return &Identity{
+		UserID:    claims.Subject,
+		OrgID:     claims.OrgID,
+		SessionID: claims.SessionID,
+		Scope:     claims.Scope,
+		ExpiresAt: claims.ExpiresAt.Time,
+		Token:     token,
+	}, nil
+}
+
+// key returns the verification key of t: the key of its kid, fetching the keys again when the kid is unknown (a
+// rotation), or every key for tokens without a kid.
+func (e *Enforcer) key(ctx context.Context, t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		e.keysMu.RLock()
+		defer e.keysMu.RUnlock()
+		set := jwt.VerificationKeySet{}
+		for _, k := range e.keys {
+			set.Keys = append(set.Keys, k)
+		}
+		return set, nil
+	}
+	if k, ok := e.lookupKey(kid); ok {
+		return k, nil
+	}
+	e.refetchMu.Lock()
+	e.keysMu.RLock()
+	stale := e.now().Sub(e.fetchedAt) >= minKeyRefetch
+	e.keysMu.RUnlock()
+	if stale {
+		_ = e.fetchKeys(ctx)
+	}
+	e.refetchMu.Unlock()
+	if k, ok := e.lookupKey(kid); ok {
+		return k, nil
+	}
+	return nil, ErrInvalidToken
+}
+
+func (e *Enforcer) lookupKey(kid string) (crypto.PublicKey, bool) {
+	e.keysMu.RLock()
+	defer e.keysMu.RUnlock()
+	k, ok := e.keys[kid]
+	return k, ok
+}
+
+// refreshKeys fetches the keys when they are older than KeyRefreshInterval. On failure the known keys stay in use.
+func (e *Enforcer) refreshKeys(ctx context.Context) {
+	e.keysMu.RLock()
+	due := e.now().Sub(e.fetchedAt) >= e.cfg.KeyRefreshInterval
+	e.keysMu.RUnlock()
+	if !due || !e.refetchMu.TryLock() {
+		return
+	}
+	defer e.refetchMu.Unlock()
+	_ = e.fetchKeys(ctx)
+}
+
+// fetchKeys replaces the keys with those of GetJWKS.
+func (e *Enforcer) fetchKeys(ctx context.Context) error {
+	e.keysMu.Lock()
+	e.fetchedAt = e.now()
+	e.keysMu.Unlock()
+	resp, err := e.cfg.Auth.GetJWKS(ctx, &authv1.GetJWKSRequest{})
+	if err != nil {
+		return err
+	}
+	keys, err := security.ParseJWKS([]byte(resp.GetJwks()))
+	if err != nil {
+		return err
+	}
+	e.keysMu.Lock()
+	e.keys, e.issuer = keys, resp.GetIssuer()
+	e.keysMu.Unlock()
+	return nil
+}
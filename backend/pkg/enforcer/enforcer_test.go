@@ -0,0 +1,407 @@
+package enforcer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "zero-trust-control-plane/backend/api/generated/auth/v1"
+	orgpolicyconfigv1 "zero-trust-control-plane/backend/api/generated/orgpolicyconfig/v1"
+	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
+	"zero-trust-control-plane/backend/internal/security"
+)
+
+// fakeControlPlane serves GetJWKS from a token provider, streams the revocations put on its channel, and allows every
+// URL except those containing "blocked".
+type fakeControlPlane struct {
+	authv1.UnimplementedAuthServiceServer
+	sessionv1.UnimplementedSessionServiceServer
+	orgpolicyconfigv1.UnimplementedOrgPolicyConfigServiceServer
+
+	tokens      *security.TokenProvider
+	revocations chan *sessionv1.SessionRevocation
+
+	mu         sync.Mutex
+	jwksCalls  int
+	since      []time.Time
+	checks     int
+	checkAuths []string
+}
+
+func (f *fakeControlPlane) GetJWKS(ctx context.Context, req *authv1.GetJWKSRequest) (*authv1.GetJWKSResponse, error) {
+	f.mu.Lock()
+	f.jwksCalls++
+	f.mu.Unlock()
+	jwks, err := f.tokens.JWKS()
+	if err != nil {
+		return nil, err
+	}
+	return &authv1.GetJWKSResponse{Jwks: string(jwks), Issuer: f.tokens.Issuer(), Audience: f.tokens.Audience()}, nil
+}
+
+func (f *fakeControlPlane) SubscribeRevocations(req *sessionv1.SubscribeRevocationsRequest, stream grpc.ServerStreamingServer[sessionv1.SessionRevocation]) error {
+	f.mu.Lock()
+	f.since = append(f.since, req.GetSince().AsTime())
+	f.mu.Unlock()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case rev := <-f.revocations:
+			if err := stream.Send(rev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (f *fakeControlPlane) CheckUrlAccess(ctx context.Context, req *orgpolicyconfigv1.CheckUrlAccessRequest) (*orgpolicyconfigv1.CheckUrlAccessResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.mu.Lock()
+	f.checks++
+	f.checkAuths = append(f.checkAuths, strings.Join(md.Get("authorization"), ","))
+	f.mu.Unlock()
+	if strings.Contains(req.GetUrl(), "blocked") {
+		return &orgpolicyconfigv1.CheckUrlAccessResponse{Allowed: false, Reason: "domain is blocked"}, nil
+	}
+	return &orgpolicyconfigv1.CheckUrlAccessResponse{Allowed: true}, nil
+}
+
+func newTestEnforcer(t *testing.T, cfg Config) (*Enforcer, *fakeControlPlane) {
+	t.Helper()
+	tokens, err := security.NewTestTokenProvider()
+	if err != nil {
+		t.Fatalf("NewTestTokenProvider: %v", err)
+	}
+	fake := &fakeControlPlane{tokens: tokens, revocations: make(chan *sessionv1.SessionRevocation, 10)}
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	authv1.RegisterAuthServiceServer(srv, fake)
+	sessionv1.RegisterSessionServiceServer(srv, fake)
+	orgpolicyconfigv1.RegisterOrgPolicyConfigServiceServer(srv, fake)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	cfg.Auth = authv1.NewAuthServiceClient(conn)
+	cfg.Sessions = sessionv1.NewSessionServiceClient(conn)
+	cfg.Policy = orgpolicyconfigv1.NewOrgPolicyConfigServiceClient(conn)
+	e, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e, fake
+}
+
+func TestEnforcer_Verify(t *testing.T) {
+	e, fake := newTestEnforcer(t, Config{})
+	ctx := context.Background()
+	access, _, _, err := fake.tokens.IssueAccess("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+	id, err := e.Verify(ctx, access)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if id.UserID != "user-1" || id.OrgID != "org-1" || id.SessionID != "session-1" || id.Token != access {
+		t.Errorf("identity = %+v", id)
+	}
+
+	resource, _, _, err := fake.tokens.IssueResource("session-1", "user-1", "org-1", "payroll", "read", time.Minute)
+	if err != nil {
+		t.Fatalf("IssueResource: %v", err)
+	}
+	if _, err := e.Verify(ctx, resource); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("resource token for another audience: err = %v, want ErrInvalidToken", err)
+	}
+	refresh, _, _, err := fake.tokens.IssueRefresh("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueRefresh: %v", err)
+	}
+	if _, err := e.Verify(ctx, refresh); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("refresh token: err = %v, want ErrInvalidToken", err)
+	}
+	other, _ := security.NewTestTokenProvider()
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other.SetKeys(otherKey, otherKey.Public())
+	forged, _, _, _ := other.IssueAccess("session-1", "user-1", "org-1")
+	if _, err := e.Verify(ctx, forged); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token signed with an unknown key: err = %v, want ErrInvalidToken", err)
+	}
+	if _, err := e.Verify(ctx, "not-a-token"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("malformed token: err = %v, want ErrInvalidToken", err)
+	}
+
+	// Test token providers share one key, so the resource token verifies with the other fake's JWKS.
+	payroll, _ := newTestEnforcer(t, Config{Audience: "payroll"})
+	id, err = payroll.Verify(ctx, resource)
+	if err != nil || id.Scope != "read" {
+		t.Errorf("resource token for the configured audience: id = %+v, err = %v", id, err)
+	}
+	// An org's token_claims can add the service's audience to its access tokens; they are still not resource tokens.
+	fake.tokens.SetClaimsProviders(audienceProvider("payroll"))
+	access, _, _, err = fake.tokens.IssueAccessContext(ctx, "session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccessContext: %v", err)
+	}
+	if _, err := payroll.Verify(ctx, access); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("access token with the configured audience: err = %v, want ErrInvalidToken", err)
+	}
+}
+
+// audienceProvider adds its audience to every access token.
+type audienceProvider string
+
+func (a audienceProvider) AccessTokenClaims(context.Context, string, string) (*security.CustomClaims, error) {
+	return &security.CustomClaims{Audiences: []string{string(a)}}, nil
+}
+
+func TestEnforcer_VerifyClockSkew(t *testing.T) {
+	e, fake := newTestEnforcer(t, Config{})
+	ctx := context.Background()
+	access, _, expiresAt, err := fake.tokens.IssueAccess("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+	issuedAt := time.Now()
+	for _, tc := range []struct {
+		name    string
+		clock   time.Time
+		wantErr error
+	}{
+		{"clock behind within the leeway", issuedAt.Add(-20 * time.Second), nil},
+		{"clock behind beyond the leeway", issuedAt.Add(-2 * time.Minute), ErrTokenNotYetValid},
+		{"clock ahead within the leeway", expiresAt.Add(20 * time.Second), nil},
+		{"clock ahead beyond the leeway", expiresAt.Add(2 * time.Minute), ErrTokenExpired},
+	} {
+		clock := tc.clock
+		e.now = func() time.Time { return clock }
+		_, err := e.Verify(ctx, access)
+		if !errors.Is(err, tc.wantErr) || (tc.wantErr != nil && !errors.Is(err, ErrInvalidToken)) {
+			t.Errorf("%s: err = %v, want %v (wrapping ErrInvalidToken)", tc.name, err, tc.wantErr)
+		}
+	}
+
+	e.now = func() time.Time { return issuedAt.Add(-2 * time.Minute) }
+	_, err = e.authenticate(metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+access)))
+	if st := status.Convert(err); st.Code() != codes.Unauthenticated || !strings.HasPrefix(st.Message(), "token not yet valid") {
+		t.Errorf("authenticate with a token from the future = %v", err)
+	}
+
+	strict, _ := newTestEnforcer(t, Config{ClockLeeway: -1})
+	strict.now = func() time.Time { return expiresAt.Add(time.Second) }
+	if _, err := strict.Verify(ctx, access); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("negative ClockLeeway: err = %v, want ErrTokenExpired", err)
+	}
+}
+
+func TestEnforcer_KeyRotation(t *testing.T) {
+	e, fake := newTestEnforcer(t, Config{})
+	ctx := context.Background()
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	fake.tokens.SetKeys(newKey, newKey.Public())
+	rotated, _, _, err := fake.tokens.IssueAccess("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+
+	// Right after a fetch an unknown kid does not trigger another one.
+	if _, err := e.Verify(ctx, rotated); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("token of a key fetched %v ago: err = %v, want ErrInvalidToken", minKeyRefetch, err)
+	}
+	clock := time.Now().Add(minKeyRefetch)
+	e.now = func() time.Time { return clock }
+	if _, err := e.Verify(ctx, rotated); err != nil {
+		t.Fatalf("token of the rotated key: %v", err)
+	}
+	if fake.jwksCalls != 2 {
+		t.Errorf("GetJWKS calls = %d, want 2 (New, then the unknown kid)", fake.jwksCalls)
+	}
+}
+
+func TestEnforcer_Revocations(t *testing.T) {
+	e, fake := newTestEnforcer(t, Config{RevocationRetention: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	access, _, _, err := fake.tokens.IssueAccess("session-1", "user-1", "org-1")
+	if err != nil {
+		t.Fatalf("IssueAccess: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- e.Run(ctx) }()
+
+	fake.revocations <- &sessionv1.SessionRevocation{SessionId: "session-1", UserId: "user-1", OrgId: "org-1", RevokedAt: timestamppb.Now(), Reason: "logout"}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := e.Verify(ctx, access)
+		if errors.Is(err, ErrSessionRevoked) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Verify after revocation: err = %v, want ErrSessionRevoked", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	fake.mu.Lock()
+	since := fake.since[0]
+	fake.mu.Unlock()
+	if d := time.Since(since); d < 59*time.Minute || d > 61*time.Minute {
+		t.Errorf("first subscription since %v ago, want the retention (1h)", d)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run after cancel = %v, want nil", err)
+	}
+}
+
+func TestEnforcer_RevokePrunes(t *testing.T) {
+	e, _ := newTestEnforcer(t, Config{RevocationRetention: time.Hour})
+	now := time.Now()
+	e.now = func() time.Time { return now }
+	e.revoke("old", now.Add(-2*time.Hour))
+	e.revoke("recent", now)
+	if !e.isRevoked("recent") {
+		t.Error("recent revocation not recorded")
+	}
+	if e.isRevoked("old") {
+		t.Error("revocation older than the retention should be pruned")
+	}
+	if !e.lastRevokedAt.Equal(now) {
+		t.Errorf("lastRevokedAt = %v, want %v", e.lastRevokedAt, now)
+	}
+}
+
+func TestEnforcer_CheckURL(t *testing.T) {
+	e, fake := newTestEnforcer(t, Config{DecisionTTL: time.Minute})
+	ctx := context.Background()
+	id := &Identity{UserID: "user-1", OrgID: "org-1", Token: "tok"}
+	for i := 0; i < 2; i++ {
+		d, err := e.CheckURL(ctx, id, "https://example.com/")
+		if err != nil || !d.Allowed {
+			t.Fatalf("CheckURL: %+v, %v", d, err)
+		}
+	}
+	if fake.checks != 1 || fake.checkAuths[0] != "Bearer tok" {
+		t.Fatalf("CheckUrlAccess calls = %d with %v, want 1 with the caller's token", fake.checks, fake.checkAuths)
+	}
+	d, err := e.CheckURL(ctx, id, "https://blocked.example.com/")
+	if err != nil || d.Allowed || d.Reason != "domain is blocked" {
+		t.Errorf("blocked URL: %+v, %v", d, err)
+	}
+	clock := time.Now().Add(2 * time.Minute)
+	e.now = func() time.Time { return clock }
+	if _, err := e.CheckURL(ctx, id, "https://example.com/"); err != nil {
+		t.Fatalf("CheckURL: %v", err)
+	}
+	if fake.checks != 3 {
+		t.Errorf("CheckUrlAccess calls = %d, want 3 (an expired decision is checked again)", fake.checks)
+	}
+
+	e.cfg.Policy = nil
+	if _, err := e.CheckURL(ctx, id, "https://example.com/other"); !errors.Is(err, ErrNoPolicy) {
+		t.Errorf("without Policy: err = %v, want ErrNoPolicy", err)
+	}
+}
+
+func TestEnforcer_UnaryServerInterceptor(t *testing.T) {
+	e, fake := newTestEnforcer(t, Config{PublicMethods: map[string]bool{"/svc/Health": true}})
+	access, _, _, _ := fake.tokens.IssueAccess("session-1", "user-1", "org-1")
+	interceptor := e.UnaryServerInterceptor()
+	var got *Identity
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = FromContext(ctx)
+		return "ok", nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+access))
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Get"}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if got == nil || got.UserID != "user-1" {
+		t.Errorf("identity in handler = %+v", got)
+	}
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Get"}, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("without token: err = %v, want Unauthenticated", err)
+	}
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Health"}, handler); err != nil {
+		t.Errorf("public method without token: %v", err)
+	}
+	e.revoke("session-1", time.Now())
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Get"}, handler)
+	if status.Code(err) != codes.Unauthenticated || status.Convert(err).Message() != "session revoked" {
+		t.Errorf("revoked session: err = %v, want Unauthenticated session revoked", err)
+	}
+}
+
+func TestEnforcer_HTTPMiddleware(t *testing.T) {
+	e, fake := newTestEnforcer(t, Config{URLOf: RequestURL})
+	access, _, _, _ := fake.tokens.IssueAccess("session-1", "user-1", "org-1")
+	h := e.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := FromContext(r.Context())
+		w.Write([]byte(id.UserID))
+	}))
+	serve := func(host, authorization string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "http://"+host+"/reports?q=1", nil)
+		r.Header.Set("X-Forwarded-Proto", "https")
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := serve("app.example.com", "Bearer "+access); w.Code != http.StatusOK || w.Body.String() != "user-1" {
+		t.Errorf("valid token: %d %q", w.Code, w.Body.String())
+	}
+	if fake.checks != 1 {
+		t.Errorf("CheckUrlAccess calls = %d, want 1", fake.checks)
+	}
+	if w := serve("blocked.example.com", "Bearer "+access); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "domain is blocked") {
+		t.Errorf("blocked URL: %d %q", w.Code, w.Body.String())
+	}
+	if w := serve("app.example.com", ""); w.Code != http.StatusUnauthorized || w.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("without token: %d", w.Code)
+	}
+	if w := serve("app.example.com", "Bearer nope"); w.Code != http.StatusUnauthorized {
+		t.Errorf("invalid token: %d", w.Code)
+	}
+}
+
+func TestRequestURL(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://app.example.com/a/b?x=1", nil)
+	if got := RequestURL(r); got != "http://app.example.com/a/b?x=1" {
+		t.Errorf("RequestURL = %q", got)
+	}
+	r.Header.Set("X-Forwarded-Proto", "https")
+	if got := RequestURL(r); got != "https://app.example.com/a/b?x=1" {
+		t.Errorf("RequestURL behind a TLS proxy = %q", got)
+	}
+}
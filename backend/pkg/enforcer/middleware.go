@@ -0,0 +1,151 @@
+package enforcer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type identityKey struct{}
+
+// WithIdentity returns ctx carrying id, as the interceptors and HTTPMiddleware do.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the caller verified by the interceptors or HTTPMiddleware.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// UnaryServerInterceptor verifies the Bearer token of each call (except PublicMethods) and puts the caller in the
+// context. Calls without a valid token fail with Unauthenticated.
+func (e *Enforcer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if e.cfg.PublicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, err := e.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams. The token is verified when the stream opens.
+func (e *Enforcer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if e.cfg.PublicMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, err := e.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context { return s.ctx }
+
+func (e *Enforcer) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if values := md.Get("authorization"); len(values) > 0 {
+		token = bearerToken(values[0])
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization")
+	}
+	id, err := e.Verify(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, verifyErrorMessage(err))
+	}
+	return WithIdentity(ctx, id), nil
+}
+
+// verifyErrorMessage returns the message for a token Verify rejected. Expired and not yet valid tokens get their
+// own, so clock skew between this service and the control plane shows up as such.
+func verifyErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, ErrSessionRevoked):
+		return "session revoked"
+	case errors.Is(err, ErrTokenExpired):
+		return "token expired"
+	case errors.Is(err, ErrTokenNotYetValid):
+		return "token not yet valid; check the clocks of this service and the control plane"
+	}
+	return "invalid token"
+}
+
+// HTTPMiddleware verifies the Bearer token of each request and puts the caller in the request context; with
+// Config.URLOf it also checks the request's URL with CheckURL. Requests without a valid token get 401, denied URLs
+// 403, and 503 when the URL cannot be checked.
+func (e *Enforcer) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r.Header.Get("Authorization"))
+		if token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer`)
+			http.Error(w, "missing or invalid authorization", http.StatusUnauthorized)
+			return
+		}
+		id, err := e.Verify(r.Context(), token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, verifyErrorMessage(err), http.StatusUnauthorized)
+			return
+		}
+		ctx := WithIdentity(r.Context(), id)
+		if e.cfg.URLOf != nil {
+			d, err := e.CheckURL(ctx, id, e.cfg.URLOf(r))
+			if err != nil {
+				http.Error(w, "access policy unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			if !d.Allowed {
+				msg := "access denied by policy"
+				if d.Reason != "" {
+					msg += ": " + d.Reason
+				}
+				http.Error(w, msg, http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestURL returns the absolute URL of r as the client sent it: the scheme of X-Forwarded-Proto or the
+// connection, the Host header, and the path and query.
+func RequestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// bearerToken returns the token of an Authorization value "Bearer <token>", or "".
+func bearerToken(authorization string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
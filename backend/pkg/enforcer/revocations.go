@@ -0,0 +1,110 @@
+package enforcer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	sessionv1 "zero-trust-control-plane/backend/api/generated/session/v1"
+)
+
+const (
+	// minResubscribeBackoff and maxResubscribeBackoff bound the wait before the revocation stream is opened again.
+	minResubscribeBackoff = time.Second
+	maxResubscribeBackoff = 30 * time.Second
+	// pruneInterval is the least time between removals of revocations older than the retention.
+	pruneInterval = time.Minute
+)
+
+// Run follows the org's revocations until ctx is done, opening the stream again with backoff when it ends. The first
+// subscription replays the revocations of the last RevocationRetention; later ones resume from the last revocation
+// received. It returns nil when ctx is done, and an error when Config.Sessions is not set or the server refuses the
+// subscription (the credentials are not an org admin's).
+func (e *Enforcer) Run(ctx context.Context) error {
+	if e.cfg.Sessions == nil {
+		return errors.New("enforcer: Sessions client is required to follow revocations")
+	}
+	backoff := minResubscribeBackoff
+	for {
+		received, err := e.follow(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		switch status.Code(err) {
+		case codes.PermissionDenied, codes.Unauthenticated, codes.Unimplemented:
+			return err
+		}
+		if received {
+			backoff = minResubscribeBackoff
+		}
+		slog.Warn("enforcer: revocation stream ended", "error", err, "retry_in", backoff)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxResubscribeBackoff)
+	}
+}
+
+// follow subscribes once and records revocations until the stream ends. received reports whether any arrived.
+func (e *Enforcer) follow(ctx context.Context) (received bool, err error) {
+	e.revokedMu.RLock()
+	since := e.lastRevokedAt
+	e.revokedMu.RUnlock()
+	if since.IsZero() {
+		since = e.now().Add(-e.cfg.RevocationRetention)
+	}
+	stream, err := e.cfg.Sessions.SubscribeRevocations(ctx, &sessionv1.SubscribeRevocationsRequest{Since: timestamppb.New(since)})
+	if err != nil {
+		return false, err
+	}
+	for {
+		rev, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return received, nil
+			}
+			return received, err
+		}
+		received = true
+		e.revoke(rev.GetSessionId(), rev.GetRevokedAt().AsTime())
+	}
+}
+
+// revoke records that sessionID was revoked at revokedAt, and drops revocations older than the retention.
+func (e *Enforcer) revoke(sessionID string, revokedAt time.Time) {
+	if sessionID == "" {
+		return
+	}
+	now := e.now()
+	e.revokedMu.Lock()
+	defer e.revokedMu.Unlock()
+	e.revoked[sessionID] = revokedAt
+	if revokedAt.After(e.lastRevokedAt) {
+		e.lastRevokedAt = revokedAt
+	}
+	if now.Sub(e.prunedAt) < pruneInterval {
+		return
+	}
+	e.prunedAt = now
+	cutoff := now.Add(-e.cfg.RevocationRetention)
+	for id, at := range e.revoked {
+		if at.Before(cutoff) {
+			delete(e.revoked, id)
+		}
+	}
+}
+
+func (e *Enforcer) isRevoked(sessionID string) bool {
+	e.revokedMu.RLock()
+	defer e.revokedMu.RUnlock()
+	_, ok := e.revoked[sessionID]
+	return ok
+}
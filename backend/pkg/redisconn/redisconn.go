@@ -0,0 +1,172 @@
+// Package redisconn is a minimal Redis client: one connection, redialed after an error, speaking the plain RESP
+// commands its users need (SET, EXISTS, DEL). It backs the telemetry worker's shared dedup window and the auth
+// server's refresh replay cache without pulling in a full client library.
+package redisconn
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timeout bounds each command when the context has no earlier deadline.
+const Timeout = 5 * time.Second
+
+// Conn is a connection to one Redis, safe for concurrent use; commands are sent one at a time.
+type Conn struct {
+	addr     string
+	password string
+	db       int
+	useTLS   bool
+
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// New returns a Conn to the Redis at rawURL (redis://[:password@]host:port[/db], or rediss:// for TLS). It does not
+// dial until the first command.
+func New(rawURL string) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+		return nil, errors.New("redisconn: redis URL must be redis://host:port or rediss://host:port")
+	}
+	c := &Conn{addr: u.Host, useTLS: u.Scheme == "rediss"}
+	if _, _, err := net.SplitHostPort(c.addr); err != nil {
+		c.addr = net.JoinHostPort(c.addr, "6379")
+	}
+	if u.User != nil {
+		c.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil || c.db < 0 {
+			return nil, fmt.Errorf("redisconn: invalid redis database %q", db)
+		}
+	}
+	return c, nil
+}
+
+// Close closes the connection. A later command dials again.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeLocked()
+}
+
+func (c *Conn) closeLocked() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.rd = nil, nil
+	return err
+}
+
+// Do sends one command and returns its reply: the text of a simple string, integer or bulk string, or "" for nil.
+// An error reply from Redis is returned as an Error.
+func (c *Conn) Do(ctx context.Context, args ...string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > Timeout {
+		deadline = time.Now().Add(Timeout)
+	}
+	if c.conn == nil {
+		if err := c.dial(ctx, deadline); err != nil {
+			return "", err
+		}
+	}
+	reply, err := c.command(deadline, args...)
+	var redisErr Error
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection may be out of step with the replies; start over on the next command.
+		c.closeLocked()
+	}
+	return reply, err
+}
+
+func (c *Conn) dial(ctx context.Context, deadline time.Time) error {
+	d := &net.Dialer{Deadline: deadline}
+	var conn net.Conn
+	var err error
+	if c.useTLS {
+		host, _, _ := net.SplitHostPort(c.addr)
+		conn, err = (&tls.Dialer{NetDialer: d, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", c.addr)
+	} else {
+		conn, err = d.DialContext(ctx, "tcp", c.addr)
+	}
+	if err != nil {
+		return fmt.Errorf("redisconn: dial: %w", err)
+	}
+	c.conn, c.rd = conn, bufio.NewReader(conn)
+	if c.password != "" {
+		if _, err := c.command(deadline, "AUTH", c.password); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("redisconn: auth: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := c.command(deadline, "SELECT", strconv.Itoa(c.db)); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("redisconn: select: %w", err)
+		}
+	}
+	return nil
+}
+
+// Error is an error reply from Redis; the connection stays usable after one.
+type Error string
+
+func (e Error) Error() string { return "redis: " + string(e) }
+
+func (c *Conn) command(deadline time.Time, args ...string) (string, error) {
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return "", err
+	}
+	line, err := c.rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", Error(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("redis: bad bulk length %q", line)
+		}
+		if n < 0 {
+			return "", nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.rd, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:n]), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
@@ -0,0 +1,66 @@
+package redisconn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"zero-trust-control-plane/backend/pkg/redisconn/redistest"
+)
+
+func TestConn_Do(t *testing.T) {
+	f := redistest.NewServer(t)
+	c, err := New("redis://:secret@" + f.Addr() + "/2")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	if reply, err := c.Do(ctx, "SET", "k", "1", "NX"); reply != "OK" || err != nil {
+		t.Fatalf("SET = %q, %v", reply, err)
+	}
+	if reply, err := c.Do(ctx, "SET", "k", "1", "NX"); reply != "" || err != nil {
+		t.Fatalf("SET NX on an existing key = %q, %v; want nil reply", reply, err)
+	}
+	if reply, err := c.Do(ctx, "EXISTS", "k"); reply != "1" || err != nil {
+		t.Fatalf("EXISTS = %q, %v", reply, err)
+	}
+	var redisErr Error
+	if _, err := c.Do(ctx, "FLUSHALL"); !errors.As(err, &redisErr) {
+		t.Fatalf("unknown command error = %v, want Error", err)
+	}
+	want := []string{"AUTH secret", "SELECT 2", "SET k 1 NX", "SET k 1 NX", "EXISTS k", "FLUSHALL"}
+	if fmt.Sprint(f.Commands()) != fmt.Sprint(want) {
+		t.Errorf("commands = %q, want %q (an error reply should keep the connection)", f.Commands(), want)
+	}
+}
+
+func TestConn_Reconnects(t *testing.T) {
+	f := redistest.NewServer(t)
+	c, _ := New("redis://" + f.Addr())
+	ctx := context.Background()
+	if _, err := c.Do(ctx, "SET", "k", "1"); err != nil {
+		t.Fatalf("SET: %v", err)
+	}
+	f.CloseConns() // the server went away
+	if _, err := c.Do(ctx, "EXISTS", "k"); err == nil {
+		t.Fatal("EXISTS on a closed connection should fail")
+	}
+	if reply, err := c.Do(ctx, "EXISTS", "k"); reply != "1" || err != nil {
+		t.Errorf("EXISTS after redial = %q, %v", reply, err)
+	}
+}
+
+func TestNew_InvalidURL(t *testing.T) {
+	for _, u := range []string{"http://redis:6379", "redis://", "redis://host:6379/x"} {
+		if _, err := New(u); err == nil {
+			t.Errorf("New(%q) should fail", u)
+		}
+	}
+	c, err := New("rediss://redis.internal")
+	if err != nil || c.addr != "redis.internal:6379" || !c.useTLS {
+		t.Errorf("New(rediss) = %+v, %v", c, err)
+	}
+}
@@ -0,0 +1,136 @@
+// Package redistest provides a fake Redis for tests of redisconn users.
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server answers AUTH, SELECT, EXISTS, SET (with NX) and DEL on a local listener and records the commands. Keys
+// never expire.
+type Server struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	keys     map[string]bool
+	commands []string
+	conns    []net.Conn
+}
+
+// NewServer starts a Server, closed when the test ends.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &Server{ln: ln, keys: map[string]bool{}}
+	t.Cleanup(func() {
+		ln.Close()
+		s.CloseConns()
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// Addr returns the host:port the Server listens on.
+func (s *Server) Addr() string { return s.ln.Addr().String() }
+
+// Commands returns the commands received so far, each with its arguments joined by spaces.
+func (s *Server) Commands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+// Has reports whether key is set.
+func (s *Server) Has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys[key]
+}
+
+// CloseConns closes the open client connections, as if the server went away; new ones are still accepted.
+func (s *Server) CloseConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.conns {
+		c.Close()
+	}
+	s.conns = nil
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		header, err := rd.ReadString('\n')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+		args := make([]string, n)
+		for i := range args {
+			lenLine, _ := rd.ReadString('\n')
+			size, _ := strconv.Atoi(strings.TrimSpace(lenLine[1:]))
+			buf := make([]byte, size+2)
+			if _, err := io.ReadFull(rd, buf); err != nil {
+				return
+			}
+			args[i] = string(buf[:size])
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(args, " "))
+		reply := s.reply(args)
+		s.mu.Unlock()
+		fmt.Fprint(conn, reply)
+	}
+}
+
+func (s *Server) reply(args []string) string {
+	switch args[0] {
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+	case "EXISTS":
+		if s.keys[args[1]] {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	case "SET":
+		for _, a := range args[3:] {
+			if a == "NX" && s.keys[args[1]] {
+				return "$-1\r\n"
+			}
+		}
+		s.keys[args[1]] = true
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, k := range args[1:] {
+			if s.keys[k] {
+				delete(s.keys, k)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
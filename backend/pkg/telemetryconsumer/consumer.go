@@ -0,0 +1,218 @@
+// Package telemetryconsumer lets services outside the control plane consume the agent telemetry stream. A Consumer
+// reads the stream as one consumer group, checks each message against the schema versions it understands, hands valid
+// events to a Handler and moves the rest to a dead-letter destination. A producer that moves to a new schema version
+// therefore never stalls or crashes an older consumer. The stream is a Kafka topic, a NATS JetStream stream or a
+// RabbitMQ queue (see NewKafkaSubscription, NewNATSSubscription and
+// NewRabbitMQSubscription).
+//
+//	c := telemetryconsumer.New(telemetryconsumer.Config{
+//		Subscription: telemetryconsumer.NewKafkaSubscription(brokers, "ztcp.telemetry", "detections"),
+//		DeadLetter:   telemetryconsumer.NewKafkaSink(brokers, "ztcp.telemetry.dead-letter"),
+//		Handler: func(ctx context.Context, e telemetryconsumer.Event) error {
+//			...
+//		},
+//	})
+//	go c.Run(ctx)
+package telemetryconsumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// DefaultRetryDelay is how long the consumer waits after a failed fetch, handler call or dead-letter write before
+// trying again.
+const DefaultRetryDelay = 2 * time.Second
+
+// Headers added to dead-lettered messages, next to the original ones.
+const (
+	HeaderDeadLetterReason = "dead-letter-reason"
+	HeaderSourceTopic      = "source-topic"
+	HeaderSourcePartition  = "source-partition"
+	HeaderSourceOffset     = "source-offset"
+)
+
+// ErrReject may be wrapped by a Handler to dead-letter an event it cannot use instead of retrying it.
+var ErrReject = errors.New("event rejected by handler")
+
+// Event is one telemetry event, as published by the control plane.
+type Event = telemetry.Event
+
+// Handler processes one event. Errors are retried until the handler succeeds, except those wrapping ErrReject.
+type Handler func(ctx context.Context, e Event) error
+
+// Message, Subscription and Sink are the transport types the consumer reads and dead-letters with.
+type (
+	Message      = telemetry.Message
+	Subscription = telemetry.Subscription
+	Sink         = telemetry.Sink
+)
+
+// Config configures a Consumer. Subscription and Handler are required.
+type Config struct {
+	Subscription Subscription
+	// DeadLetter receives messages that fail the schema check or are rejected by the handler, unchanged, with the
+	// reason and origin in headers. Nil drops them with a log line.
+	DeadLetter Sink
+	// Schemas are the versions the consumer understands. Nil means the versions of this build.
+	Schemas *telemetry.Registry
+	Handler Handler
+	// Dedup, when set, skips events already handled within its window (see NewMemoryDedup and NewRedisDedup).
+	Dedup Dedup
+	// RetryDelay defaults to DefaultRetryDelay.
+	RetryDelay time.Duration
+}
+
+// Consumer reads the telemetry stream. Messages are acked only after an event is handled or dead-lettered, so a crash
+// replays rather than loses events. Set Config.Dedup, or deduplicate by event_id in the handler, so replayed events
+// are not handled twice.
+type Consumer struct {
+	sub        Subscription
+	deadLetter Sink // nil when Config.DeadLetter is not set
+	schemas    *telemetry.Registry
+	handler    Handler
+	dedup      Dedup // nil when Config.Dedup is not set
+	retryDelay time.Duration
+}
+
+// New returns a consumer for cfg.
+func New(cfg Config) *Consumer {
+	c := &Consumer{
+		sub:        cfg.Subscription,
+		deadLetter: cfg.DeadLetter,
+		schemas:    cfg.Schemas,
+		handler:    cfg.Handler,
+		dedup:      cfg.Dedup,
+		retryDelay: cfg.RetryDelay,
+	}
+	if c.schemas == nil {
+		c.schemas = telemetry.Schemas
+	}
+	if c.retryDelay <= 0 {
+		c.retryDelay = DefaultRetryDelay
+	}
+	return c
+}
+
+// Run consumes events until ctx is done, then closes the subscription and the dead-letter sink.
+func (c *Consumer) Run(ctx context.Context) {
+	defer c.sub.Close()
+	if c.deadLetter != nil {
+		defer c.deadLetter.Close()
+	}
+	for {
+		msg, err := c.sub.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("telemetryconsumer: fetch: %v", err)
+			if !sleep(ctx, c.retryDelay) {
+				return
+			}
+			continue
+		}
+		if !c.process(ctx, msg) {
+			return
+		}
+		if err := c.sub.Ack(ctx, msg); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("telemetryconsumer: ack %s offset %d: %v", msg.Origin, msg.Offset, err)
+		}
+	}
+}
+
+// process handles or dead-letters msg. Returns false if ctx is done first.
+func (c *Consumer) process(ctx context.Context, msg Message) bool {
+	e, err := c.decode(msg)
+	if err != nil {
+		return c.reject(ctx, msg, err)
+	}
+	if c.dedup != nil {
+		// A failed lookup handles the event: a duplicate is better than a loss.
+		seen, err := c.dedup.Seen(ctx, dedupKey(e))
+		if err != nil {
+			log.Printf("telemetryconsumer: dedup lookup of event %s: %v", e.EventID, err)
+		}
+		if seen {
+			return true
+		}
+	}
+	for {
+		err := c.handler(ctx, e)
+		if err == nil {
+			if c.dedup != nil {
+				if err := c.dedup.Mark(ctx, dedupKey(e)); err != nil {
+					log.Printf("telemetryconsumer: dedup mark of event %s: %v", e.EventID, err)
+				}
+			}
+			return true
+		}
+		if errors.Is(err, ErrReject) {
+			return c.reject(ctx, msg, err)
+		}
+		log.Printf("telemetryconsumer: handle %s event %s: %v", e.Type, e.EventID, err)
+		if !sleep(ctx, c.retryDelay) {
+			return false
+		}
+	}
+}
+
+// decode parses msg and checks it against the consumer's schemas. A schema-version header that disagrees with the
+// value is an error.
+func (c *Consumer) decode(msg Message) (Event, error) {
+	e, err := c.schemas.Decode(msg.Value)
+	if err != nil {
+		return Event{}, err
+	}
+	for _, h := range msg.Headers {
+		if h.Key == telemetry.HeaderSchemaVersion && string(h.Value) != strconv.Itoa(e.SchemaVersion) {
+			return Event{}, fmt.Errorf("schema-version header %q does not match schema_version %d", h.Value, e.SchemaVersion)
+		}
+	}
+	return e, nil
+}
+
+// reject writes msg to the dead-letter sink with reason, retrying until it succeeds. Without a dead-letter sink the
+// message is logged and dropped. Returns false if ctx is done first.
+func (c *Consumer) reject(ctx context.Context, msg Message, reason error) bool {
+	if c.deadLetter == nil {
+		log.Printf("telemetryconsumer: dropping message from %s at partition %d offset %d: %v", msg.Origin, msg.Partition, msg.Offset, reason)
+		return true
+	}
+	headers := append(append([]telemetry.Header(nil), msg.Headers...),
+		telemetry.Header{Key: HeaderDeadLetterReason, Value: []byte(reason.Error())},
+		telemetry.Header{Key: HeaderSourceTopic, Value: []byte(msg.Origin)},
+		telemetry.Header{Key: HeaderSourcePartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		telemetry.Header{Key: HeaderSourceOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+	)
+	// No ID: JetStream would take the copy for a duplicate of the original when both subjects are in one stream.
+	out := Message{Key: msg.Key, Value: msg.Value, Headers: headers}
+	for {
+		err := c.deadLetter.Write(ctx, out)
+		if err == nil {
+			return true
+		}
+		log.Printf("telemetryconsumer: dead-letter offset %d: %v", msg.Offset, err)
+		if !sleep(ctx, c.retryDelay) {
+			return false
+		}
+	}
+}
+
+// sleep waits for d. Returns false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
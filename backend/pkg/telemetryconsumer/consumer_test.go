@@ -0,0 +1,204 @@
+package telemetryconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/internal/telemetry"
+)
+
+// fakeSubscription yields msgs, then cancels the run.
+type fakeSubscription struct {
+	msgs   []Message
+	acked  []int64
+	cancel context.CancelFunc
+}
+
+func (f *fakeSubscription) Fetch(ctx context.Context) (Message, error) {
+	if len(f.msgs) == 0 {
+		f.cancel()
+		return Message{}, ctx.Err()
+	}
+	msg := f.msgs[0]
+	f.msgs = f.msgs[1:]
+	return msg, nil
+}
+
+func (f *fakeSubscription) Ack(ctx context.Context, msg Message) error {
+	f.acked = append(f.acked, msg.Offset)
+	return nil
+}
+
+func (f *fakeSubscription) Close() error { return nil }
+
+type fakeSink struct {
+	msgs  []Message
+	fails int
+}
+
+func (f *fakeSink) Write(ctx context.Context, msgs ...Message) error {
+	if f.fails > 0 {
+		f.fails--
+		return errors.New("broker down")
+	}
+	f.msgs = append(f.msgs, msgs...)
+	return nil
+}
+
+func (f *fakeSink) Close() error { return nil }
+
+func message(offset int64, version int, eventID string) Message {
+	value, _ := json.Marshal(telemetry.Event{
+		SchemaVersion: version,
+		EventID:       eventID,
+		Type:          "page_view",
+		OccurredAt:    time.Date(2026, 10, 1, 11, 59, 0, 0, time.UTC),
+		ReceivedAt:    time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		OrgID:         "org-1",
+		UserID:        "user-1",
+		SessionID:     "sess-1",
+	})
+	return Message{
+		Origin:  "ztcp.telemetry",
+		Offset:  offset,
+		Value:   value,
+		Headers: []telemetry.Header{{Key: "schema-version", Value: []byte(fmt.Sprint(version))}},
+	}
+}
+
+func newTestConsumer(msgs []Message, handler Handler) (*Consumer, *fakeSubscription, *fakeSink, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &fakeSubscription{msgs: msgs, cancel: cancel}
+	w := &fakeSink{}
+	c := &Consumer{sub: r, deadLetter: w, schemas: telemetry.Schemas, handler: handler, retryDelay: time.Millisecond}
+	return c, r, w, ctx
+}
+
+func header(msg Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestConsumer_HandlesKnownVersionsAndDeadLettersOthers(t *testing.T) {
+	var handled []string
+	msgs := []Message{message(1, 1, "a"), message(2, 2, "b"), {Origin: "ztcp.telemetry", Offset: 3, Value: []byte("{")}, message(4, 1, "c")}
+	c, r, w, ctx := newTestConsumer(msgs, func(ctx context.Context, e Event) error {
+		handled = append(handled, e.EventID)
+		return nil
+	})
+	c.Run(ctx)
+
+	if fmt.Sprint(handled) != "[a c]" {
+		t.Errorf("handled = %v, want [a c]", handled)
+	}
+	if fmt.Sprint(r.acked) != "[1 2 3 4]" {
+		t.Errorf("acked = %v, want every offset", r.acked)
+	}
+	if len(w.msgs) != 2 {
+		t.Fatalf("dead-lettered %d messages, want 2", len(w.msgs))
+	}
+	dl := w.msgs[0]
+	if string(dl.Value) != string(msgs[1].Value) || header(dl, "schema-version") != "2" {
+		t.Error("dead-lettered message should keep its value and headers")
+	}
+	if header(dl, HeaderDeadLetterReason) != "unknown schema version 2" || header(dl, HeaderSourceTopic) != "ztcp.telemetry" || header(dl, HeaderSourceOffset) != "2" {
+		t.Errorf("dead-letter headers = %v", dl.Headers)
+	}
+}
+
+func TestConsumer_HeaderMismatchDeadLettered(t *testing.T) {
+	msg := message(1, 1, "a")
+	msg.Headers = []telemetry.Header{{Key: "schema-version", Value: []byte("2")}}
+	c, _, w, ctx := newTestConsumer([]Message{msg}, func(ctx context.Context, e Event) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+	c.Run(ctx)
+	if len(w.msgs) != 1 {
+		t.Fatalf("dead-lettered %d messages, want 1", len(w.msgs))
+	}
+}
+
+func TestConsumer_RetriesHandlerAndDeadLetterErrors(t *testing.T) {
+	calls := 0
+	c, r, w, ctx := newTestConsumer([]Message{message(1, 1, "a"), message(2, 1, "b")}, func(ctx context.Context, e Event) error {
+		calls++
+		if e.EventID == "b" {
+			return fmt.Errorf("unusable payload: %w", ErrReject)
+		}
+		if calls < 3 {
+			return errors.New("sink down")
+		}
+		return nil
+	})
+	w.fails = 2
+	c.Run(ctx)
+
+	if calls != 4 {
+		t.Errorf("handler calls = %d, want 3 for a and 1 for b", calls)
+	}
+	if len(w.msgs) != 1 || header(w.msgs[0], HeaderSourceOffset) != "2" {
+		t.Errorf("dead-lettered = %v, want offset 2 after the write is retried", w.msgs)
+	}
+	if fmt.Sprint(r.acked) != "[1 2]" {
+		t.Errorf("acked = %v", r.acked)
+	}
+}
+
+func TestConsumer_NoDeadLetterDrops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &fakeSubscription{msgs: []Message{message(1, 7, "a")}, cancel: cancel}
+	c := &Consumer{sub: r, schemas: telemetry.Schemas, retryDelay: time.Millisecond, handler: func(ctx context.Context, e Event) error {
+		t.Error("handler should not be called")
+		return nil
+	}}
+	c.Run(ctx)
+	if fmt.Sprint(r.acked) != "[1]" {
+		t.Errorf("acked = %v, want the dropped message", r.acked)
+	}
+}
+
+func TestConsumer_DedupSkipsRedeliveries(t *testing.T) {
+	var handled []string
+	fail := true
+	msgs := []Message{message(1, 1, "a"), message(2, 1, "b"), message(3, 1, "a"), message(4, 1, "b")}
+	c, r, _, ctx := newTestConsumer(msgs, func(ctx context.Context, e Event) error {
+		if e.EventID == "b" && fail {
+			fail = false
+			return errors.New("sink down")
+		}
+		handled = append(handled, e.EventID)
+		return nil
+	})
+	c.dedup = NewMemoryDedup(10, time.Hour, nil)
+	c.Run(ctx)
+
+	// b is marked only once it is handled, so the failed attempt is retried rather than skipped.
+	if fmt.Sprint(handled) != "[a b]" {
+		t.Errorf("handled = %v, want [a b]", handled)
+	}
+	if fmt.Sprint(r.acked) != "[1 2 3 4]" {
+		t.Errorf("acked = %v, want the skipped duplicates too", r.acked)
+	}
+}
+
+func TestConsumer_DedupErrorsHandleEvent(t *testing.T) {
+	calls := 0
+	c, _, _, ctx := newTestConsumer([]Message{message(1, 1, "a")}, func(ctx context.Context, e Event) error {
+		calls++
+		return nil
+	})
+	c.dedup = &mapDedup{keys: map[string]bool{}, err: errors.New("redis down")}
+	c.Run(ctx)
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1 when the lookup fails", calls)
+	}
+}
@@ -0,0 +1,109 @@
+package telemetryconsumer
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Dedup remembers the events a consumer has handled, so a message delivered again (after a rebalance, a crash before
+// the offset commit, or an agent retry) is not handled twice. Keys are org_id:event_id.
+type Dedup interface {
+	// Seen reports whether key was marked within the window.
+	Seen(ctx context.Context, key string) (bool, error)
+	// Mark records key as handled.
+	Mark(ctx context.Context, key string) error
+}
+
+// dedupKey returns the Dedup key of e. Event IDs set by agents are only unique within an org.
+func dedupKey(e Event) string {
+	return e.OrgID + ":" + e.EventID
+}
+
+// MemoryDedup is an in-memory Dedup holding the most recently marked keys, at most size of them, for window each.
+// It only covers redeliveries to the same process; use RedisDedup behind it to share the window between consumers.
+type MemoryDedup struct {
+	size   int
+	window time.Duration
+	next   Dedup // consulted on a miss and marked too; nil for none
+	now    func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front is the most recently marked
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key string
+	at  time.Time
+}
+
+// NewMemoryDedup returns an in-memory Dedup of size keys kept for window. When next is non-nil, keys missing here are
+// looked up in next, and marks are written through to it.
+func NewMemoryDedup(size int, window time.Duration, next Dedup) *MemoryDedup {
+	return &MemoryDedup{
+		size:    size,
+		window:  window,
+		next:    next,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Seen reports whether key was marked within the window, here or in the next Dedup.
+func (m *MemoryDedup) Seen(ctx context.Context, key string) (bool, error) {
+	if m.seenLocal(key) {
+		return true, nil
+	}
+	if m.next == nil {
+		return false, nil
+	}
+	seen, err := m.next.Seen(ctx, key)
+	if err != nil || !seen {
+		return false, err
+	}
+	m.markLocal(key)
+	return true, nil
+}
+
+// Mark records key here and in the next Dedup.
+func (m *MemoryDedup) Mark(ctx context.Context, key string) error {
+	m.markLocal(key)
+	if m.next == nil {
+		return nil
+	}
+	return m.next.Mark(ctx, key)
+}
+
+func (m *MemoryDedup) seenLocal(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	if m.now().Sub(el.Value.(*memoryEntry).at) >= m.window {
+		m.order.Remove(el)
+		delete(m.entries, key)
+		return false
+	}
+	return true
+}
+
+func (m *MemoryDedup) markLocal(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		el.Value.(*memoryEntry).at = m.now()
+		m.order.MoveToFront(el)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, at: m.now()})
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
@@ -0,0 +1,104 @@
+package telemetryconsumer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"zero-trust-control-plane/backend/pkg/redisconn/redistest"
+)
+
+func TestMemoryDedup_WindowAndSize(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemoryDedup(2, time.Hour, nil)
+	m.now = func() time.Time { return now }
+
+	_ = m.Mark(ctx, "a")
+	_ = m.Mark(ctx, "b")
+	if seen, _ := m.Seen(ctx, "a"); !seen {
+		t.Error("a should be seen")
+	}
+	_ = m.Mark(ctx, "c") // evicts a, the least recently marked
+	if seen, _ := m.Seen(ctx, "a"); seen {
+		t.Error("a should be evicted past the size")
+	}
+	if seen, _ := m.Seen(ctx, "b"); !seen {
+		t.Error("b should be seen")
+	}
+
+	now = now.Add(time.Hour)
+	if seen, _ := m.Seen(ctx, "c"); seen {
+		t.Error("c should expire after the window")
+	}
+	if len(m.entries) != 1 || m.order.Len() != 1 {
+		t.Errorf("entries = %d/%d, want the expired key removed", len(m.entries), m.order.Len())
+	}
+}
+
+// mapDedup is a Dedup in a map, standing in for Redis.
+type mapDedup struct {
+	keys  map[string]bool
+	err   error
+	reads int
+}
+
+func (m *mapDedup) Seen(ctx context.Context, key string) (bool, error) {
+	m.reads++
+	return m.keys[key], m.err
+}
+
+func (m *mapDedup) Mark(ctx context.Context, key string) error {
+	m.keys[key] = true
+	return m.err
+}
+
+func TestMemoryDedup_Next(t *testing.T) {
+	ctx := context.Background()
+	shared := &mapDedup{keys: map[string]bool{"other-instance": true}}
+	m := NewMemoryDedup(10, time.Hour, shared)
+
+	if seen, err := m.Seen(ctx, "other-instance"); !seen || err != nil {
+		t.Errorf("Seen(other-instance) = %v, %v; want a hit from next", seen, err)
+	}
+	if seen, _ := m.Seen(ctx, "other-instance"); !seen || shared.reads != 1 {
+		t.Errorf("second lookup should be served from memory, next read %d times", shared.reads)
+	}
+	_ = m.Mark(ctx, "new")
+	if !shared.keys["new"] {
+		t.Error("Mark should write through to next")
+	}
+}
+
+func TestRedisDedup(t *testing.T) {
+	f := redistest.NewServer(t)
+	r, err := NewRedisDedup("redis://:secret@"+f.Addr()+"/2", "dedup:", 90*time.Second)
+	if err != nil {
+		t.Fatalf("NewRedisDedup: %v", err)
+	}
+	defer r.Close()
+	ctx := context.Background()
+
+	if seen, err := r.Seen(ctx, "org-1:evt-1"); seen || err != nil {
+		t.Fatalf("Seen before Mark = %v, %v", seen, err)
+	}
+	if err := r.Mark(ctx, "org-1:evt-1"); err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+	if seen, err := r.Seen(ctx, "org-1:evt-1"); !seen || err != nil {
+		t.Fatalf("Seen after Mark = %v, %v", seen, err)
+	}
+	want := []string{"AUTH secret", "SELECT 2", "EXISTS dedup:org-1:evt-1", "SET dedup:org-1:evt-1 1 PX 90000", "EXISTS dedup:org-1:evt-1"}
+	if fmt.Sprint(f.Commands()) != fmt.Sprint(want) {
+		t.Errorf("commands = %q, want %q", f.Commands(), want)
+	}
+}
+
+func TestNewRedisDedup_InvalidURL(t *testing.T) {
+	for _, u := range []string{"http://redis:6379", "redis://", "redis://host:6379/x"} {
+		if _, err := NewRedisDedup(u, "", time.Minute); err == nil {
+			t.Errorf("NewRedisDedup(%q) should fail", u)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package telemetryconsumer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"zero-trust-control-plane/backend/pkg/redisconn"
+)
+
+// RedisDedup is a Dedup in Redis, shared by every consumer of a group, so a message redelivered to another instance
+// after a rebalance is recognized too. Keys expire after the window.
+type RedisDedup struct {
+	conn   *redisconn.Conn
+	prefix string
+	window time.Duration
+}
+
+// NewRedisDedup returns a Dedup in the Redis at rawURL (redis://[:password@]host:port[/db], or rediss:// for TLS)
+// keeping keys for window under prefix.
+func NewRedisDedup(rawURL, prefix string, window time.Duration) (*RedisDedup, error) {
+	conn, err := redisconn.New(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisDedup{conn: conn, prefix: prefix, window: window}, nil
+}
+
+// Seen reports whether key exists.
+func (r *RedisDedup) Seen(ctx context.Context, key string) (bool, error) {
+	reply, err := r.conn.Do(ctx, "EXISTS", r.prefix+key)
+	if err != nil {
+		return false, err
+	}
+	return reply == "1", nil
+}
+
+// Mark sets key, expiring after the window.
+func (r *RedisDedup) Mark(ctx context.Context, key string) error {
+	_, err := r.conn.Do(ctx, "SET", r.prefix+key, "1", "PX", strconv.FormatInt(r.window.Milliseconds(), 10))
+	return err
+}
+
+// Close closes the connection.
+func (r *RedisDedup) Close() error {
+	return r.conn.Close()
+}
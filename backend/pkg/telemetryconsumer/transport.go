@@ -0,0 +1,41 @@
+package telemetryconsumer
+
+import (
+	"zero-trust-control-plane/backend/internal/telemetry"
+	"zero-trust-control-plane/backend/internal/telemetry/nats"
+	"zero-trust-control-plane/backend/internal/telemetry/rabbitmq"
+)
+
+// NewKafkaSubscription reads topic on brokers as groupID. Ack commits the offset; a new group starts from the oldest
+// retained event.
+func NewKafkaSubscription(brokers []string, topic, groupID string) Subscription {
+	return telemetry.NewKafkaSubscription(brokers, topic, groupID)
+}
+
+// NewKafkaSink writes to topic on brokers, waiting for all in-sync replicas.
+func NewKafkaSink(brokers []string, topic string) Sink {
+	return telemetry.NewKafkaSink(brokers, topic)
+}
+
+// NewNATSSubscription pulls subject from a JetStream stream as the durable consumer durable, which is created with
+// explicit acks if it does not exist. url is nats://[user:pass@]host:port, or tls:// for TLS.
+func NewNATSSubscription(url, stream, durable, subject string) (Subscription, error) {
+	return nats.NewSubscription(url, stream, durable, subject)
+}
+
+// NewNATSSink publishes to subject, which a JetStream stream must capture, waiting for the stream's ack.
+func NewNATSSink(url, subject string) (Sink, error) {
+	return nats.NewSink(url, subject)
+}
+
+// NewRabbitMQSubscription consumes queue, a durable queue bound to the topic exchange with every routing key, with
+// manual acks. url is amqp://[user:pass@]host:port/vhost, or amqps:// for TLS.
+func NewRabbitMQSubscription(url, exchange, queue string) (Subscription, error) {
+	return rabbitmq.NewSubscription(url, exchange, queue)
+}
+
+// NewRabbitMQSink publishes persistent messages straight to queue, a durable queue, waiting for publisher confirms.
+// Use it as the dead-letter sink.
+func NewRabbitMQSink(url, queue string) (Sink, error) {
+	return rabbitmq.NewQueueSink(url, queue)
+}
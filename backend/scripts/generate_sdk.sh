@@ -0,0 +1,19 @@
+#!/usr/bin/env bash
+# generate_sdk.sh: generate Python and TypeScript client stubs from proto/ into build/sdk/ (or $1).
+# Uses buf with the remote plugins in proto/buf.gen.sdk.yaml (needs network access). Go clients use pkg/client.
+set -euo pipefail
+cd "$(dirname "$0")/.."
+ROOT="$(pwd)"
+OUT_DIR="${1:-$ROOT/build/sdk}"
+
+if ! command -v buf >/dev/null 2>&1; then
+  echo "error: buf not found. Install buf (brew install buf or https://buf.build/docs/installation)."
+  exit 1
+fi
+
+mkdir -p "$OUT_DIR"
+OUT_DIR="$(cd "$OUT_DIR" && pwd)"
+echo "buf generate (SDK): $ROOT/proto -> $OUT_DIR"
+rm -rf "$OUT_DIR/python" "$OUT_DIR/typescript"
+(cd "$ROOT/proto" && buf generate --template buf.gen.sdk.yaml -o "$OUT_DIR")
+echo "SDK stubs written to $OUT_DIR/python and $OUT_DIR/typescript."
@@ -1,5 +1,6 @@
 #!/usr/bin/env bash
-# seed.sh: seed development/sample data (run after ./scripts/migrate.sh).
+# seed.sh: seed development/sample data (run after ./scripts/migrate.sh). Arguments are passed to cmd/seed,
+# e.g. ./scripts/seed.sh -loadtest-orgs 5 -loadtest-users 200 for the load-test scenario.
 set -euo pipefail
 cd "$(dirname "$0")/.."
 
@@ -17,4 +18,4 @@ if [ -z "${DATABASE_URL:-}" ]; then
   exit 1
 fi
 
-go run ./cmd/seed
+go run ./cmd/seed "$@"
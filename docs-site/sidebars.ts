@@ -14,17 +14,57 @@ const sidebars: SidebarsConfig = {
       items: [
         "backend/grpc-api-overview",
         "backend/auth",
+        "backend/agents",
         "backend/audit",
+        "backend/audit-webhooks",
+        "backend/backup",
+        "backend/billing-plans",
+        "backend/break-glass",
+        "backend/change-requests",
+        "backend/circuit-breakers",
+        "backend/data-export",
+        "backend/data-residency",
         "backend/database",
+        "backend/device-codes",
         "backend/device-trust",
+        "backend/elevation",
+        "backend/email-change",
+        "backend/error-localization",
+        "backend/feature-flags",
+        "backend/go-client",
+        "backend/groups",
         "backend/health",
+        "backend/honeytokens",
+        "backend/license",
+        "backend/load-testing",
+        "backend/login-holds",
+        "backend/magic-links",
+        "backend/maintenance-mode",
         "backend/mfa",
+        "backend/notification-templates",
+        "backend/org-discovery",
+        "backend/org-domains",
         "backend/org-policy-config",
+        "backend/org-smtp",
         "backend/organization-membership",
+        "backend/pii-encryption",
+        "backend/platform-settings",
+        "backend/policy-analytics",
         "backend/policy-engine",
+        "backend/policy-enforcer",
+        "backend/policy-presets",
+        "backend/quotas",
+        "backend/registration",
         "backend/sessions",
         "backend/session-lifecycle",
+        "backend/shared-devices",
+        "backend/telemetry",
         "backend/testing",
+        "backend/url-exceptions",
+        "backend/usage-metering",
+        "backend/user-attributes",
+        "backend/user-merge",
+        "backend/usernames",
       ],
     },
     {
@@ -6,7 +6,7 @@ import { verifyBodySchema } from "@/lib/api/auth-schemas";
 /**
  * POST /api/auth/verify — verify email/password and return user_id (no session).
  * Used by the org-creation flow so registered users can create an organization from the sign-in page.
- * Body: { email, password }. Returns { user_id }.
+ * Body: { email, password }. Returns { user_id }; 403 if the account is disabled, 429 when rate-limited.
  */
 export async function POST(request: NextRequest) {
   try {
@@ -18,6 +18,9 @@ export async function POST(request: NextRequest) {
     }
     const { email, password } = parsed.data;
     const res = await auth.verifyCredentials(email, password);
+    if (!res.valid) {
+      return NextResponse.json({ error: "This account is disabled." }, { status: 403 });
+    }
     if (!res.user_id) {
       return NextResponse.json({ error: "user_id not returned" }, { status: 500 });
     }
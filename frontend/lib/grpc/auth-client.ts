@@ -307,23 +307,36 @@ export async function refresh(
   return refreshResponseToJson(res);
 }
 
+export interface VerifyCredentialsResult {
+  user_id: string;
+  valid: boolean;
+  mfa_would_be_required: boolean;
+  account_status: string;
+}
+
 /**
- * VerifyCredentials validates email and password and returns user_id. Does not create a session.
+ * VerifyCredentials validates email and password and returns user_id, valid (false for a disabled account) and account_status. Does not create a session.
  * Used by the org-creation flow so registered users can create an organization from the sign-in page.
  */
-export async function verifyCredentials(email: string, password: string): Promise<{ user_id: string }> {
+export async function verifyCredentials(email: string, password: string): Promise<VerifyCredentialsResult> {
   const client = getAuthClient();
   return new Promise((resolve, reject) => {
     (client as grpc.Client & {
       VerifyCredentials: (
         r: { email: string; password: string },
-        c: (e: grpc.ServiceError | null, r: { user_id?: string }) => void
+        c: (e: grpc.ServiceError | null, r: Partial<VerifyCredentialsResult>) => void
       ) => void;
     }).VerifyCredentials(
       { email, password },
-      (err: grpc.ServiceError | null, res: { user_id?: string }) => {
+      (err: grpc.ServiceError | null, res: Partial<VerifyCredentialsResult>) => {
         if (err) reject({ code: err.code, message: err.details || err.message });
-        else resolve({ user_id: res?.user_id ?? "" });
+        else
+          resolve({
+            user_id: res?.user_id ?? "",
+            valid: res?.valid ?? false,
+            mfa_would_be_required: res?.mfa_would_be_required ?? false,
+            account_status: res?.account_status ?? "",
+          });
       }
     );
   });
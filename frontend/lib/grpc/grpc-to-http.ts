@@ -27,6 +27,8 @@ export function grpcErrorToHttp(err: GrpcError): { status: number; message: stri
       return { status: 400, message: err.message || "Invalid input." };
     case GrpcStatus.FAILED_PRECONDITION:
       return { status: 400, message: err.message || "Precondition failed." };
+    case GrpcStatus.RESOURCE_EXHAUSTED:
+      return { status: 429, message: err.message || "Too many attempts; try again later." };
     case GrpcStatus.UNIMPLEMENTED:
       return { status: 501, message: err.message || "Not configured." };
     default: